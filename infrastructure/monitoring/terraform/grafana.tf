@@ -210,6 +210,18 @@ resource "kubernetes_config_map" "grafana_dashboard_api" {
   }
 }
 
+# ConfigMap containing Grafana API RED (rate, errors, duration) dashboard
+resource "kubernetes_config_map" "grafana_dashboard_red" {
+  metadata {
+    name      = "grafana-dashboard-red"
+    namespace = local.grafana_config.namespace
+  }
+
+  data = {
+    "red-dashboard.json" = file("${path.module}/../grafana/dashboards/red-dashboard.json")
+  }
+}
+
 # ConfigMap containing Grafana Document Service dashboard
 resource "kubernetes_config_map" "grafana_dashboard_document_service" {
   metadata {
@@ -392,6 +404,11 @@ resource "kubernetes_deployment" "grafana" {
             mount_path = "/var/lib/grafana/dashboards/api"
           }
           
+          volume_mount {
+            name       = "grafana-dashboard-red"
+            mount_path = "/var/lib/grafana/dashboards/red"
+          }
+          
           volume_mount {
             name       = "grafana-dashboard-document-service"
             mount_path = "/var/lib/grafana/dashboards/document-service"
@@ -463,6 +480,13 @@ resource "kubernetes_deployment" "grafana" {
           }
         }
         
+        volume {
+          name = "grafana-dashboard-red"
+          config_map {
+            name = "grafana-dashboard-red"
+          }
+        }
+        
         volume {
           name = "grafana-dashboard-document-service"
           config_map {
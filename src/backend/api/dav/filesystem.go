@@ -0,0 +1,505 @@
+// Package dav provides the WebDAV interface of the Document Management Platform, which lets users
+// mount the folders of their tenant as a network drive. Folders map to WebDAV collections and
+// documents to WebDAV resources.
+package dav
+
+import (
+	"context" // standard library
+	"io"      // standard library
+	"io/fs"   // standard library
+	"mime"    // standard library
+	"os"      // standard library
+	"path"    // standard library
+	"strings" // standard library
+	"time"    // standard library
+
+	"golang.org/x/net/webdav" // v0.17.0+
+
+	"../../application/usecases"
+	"../../domain/models"
+	"../../pkg/errors"
+	"../../pkg/logger"
+	"../../pkg/utils"
+)
+
+// defaultContentType is the content type of uploads whose extension has no known type
+const defaultContentType = "application/octet-stream"
+
+// FolderUseCase is the part of the folder use case the WebDAV interface needs
+type FolderUseCase interface {
+	CreateFolder(ctx context.Context, name, parentID, tenantID, userID string) (string, error)
+	GetFolderByPath(ctx context.Context, path, tenantID, userID string) (*models.Folder, error)
+	UpdateFolder(ctx context.Context, id, name, tenantID, userID string) error
+	DeleteFolder(ctx context.Context, id, tenantID, userID string) error
+	MoveFolder(ctx context.Context, id, newParentID, tenantID, userID string) error
+	ListFolderContents(ctx context.Context, id, tenantID, userID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Folder], utils.PaginatedResult[models.Document], error)
+	ListRootFolders(ctx context.Context, tenantID, userID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Folder], error)
+}
+
+// fileSystem implements webdav.FileSystem on top of the folder and document use cases. Paths are
+// folder paths, with the document name as the last element for documents. The root only holds
+// folders. Documents cannot be overwritten, renamed or moved, since their content is immutable.
+type fileSystem struct {
+	folderUseCase   FolderUseCase
+	documentUseCase usecases.DocumentUseCase
+}
+
+// newFileSystem creates a new fileSystem
+func newFileSystem(folderUseCase FolderUseCase, documentUseCase usecases.DocumentUseCase) *fileSystem {
+	return &fileSystem{
+		folderUseCase:   folderUseCase,
+		documentUseCase: documentUseCase,
+	}
+}
+
+// Mkdir creates a folder
+func (f *fileSystem) Mkdir(ctx context.Context, name string, perm os.FileMode) error {
+	caller := callerFromContext(ctx)
+	parent, base := path.Split(cleanPath(name))
+	if base == "" {
+		return os.ErrExist
+	}
+
+	parentID, err := f.folderID(ctx, parent)
+	if err != nil {
+		return err
+	}
+
+	_, err = f.folderUseCase.CreateFolder(ctx, base, parentID, caller.tenantID, caller.userID)
+	return toFSError(err)
+}
+
+// OpenFile opens a folder for listing, a document for reading, or a new document for writing
+func (f *fileSystem) OpenFile(ctx context.Context, name string, flag int, perm os.FileMode) (webdav.File, error) {
+	name = cleanPath(name)
+	if flag&(os.O_WRONLY|os.O_RDWR) != 0 {
+		return f.create(ctx, name)
+	}
+
+	folder, document, err := f.lookup(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if document != nil {
+		return &documentFile{fs: f, ctx: ctx, document: document}, nil
+	}
+	return &folderFile{fs: f, ctx: ctx, folder: folder}, nil
+}
+
+// RemoveAll deletes a folder or a document
+func (f *fileSystem) RemoveAll(ctx context.Context, name string) error {
+	caller := callerFromContext(ctx)
+	folder, document, err := f.lookup(ctx, cleanPath(name))
+	if err != nil {
+		return err
+	}
+	if document != nil {
+		return toFSError(f.documentUseCase.DeleteDocument(ctx, document.ID, caller.tenantID, caller.userID))
+	}
+	if folder == nil {
+		return os.ErrPermission
+	}
+	return toFSError(f.folderUseCase.DeleteFolder(ctx, folder.ID, caller.tenantID, caller.userID))
+}
+
+// Rename renames or moves a folder
+func (f *fileSystem) Rename(ctx context.Context, oldName, newName string) error {
+	caller := callerFromContext(ctx)
+	folder, document, err := f.lookup(ctx, cleanPath(oldName))
+	if err != nil {
+		return err
+	}
+	if document != nil || folder == nil {
+		return os.ErrPermission
+	}
+
+	newParent, newBase := path.Split(cleanPath(newName))
+	if newBase == "" {
+		return os.ErrPermission
+	}
+	oldParent, _ := path.Split(folder.Path)
+
+	if cleanPath(newParent) != cleanPath(oldParent) {
+		newParentID, err := f.folderID(ctx, newParent)
+		if err != nil {
+			return err
+		}
+		if err := f.folderUseCase.MoveFolder(ctx, folder.ID, newParentID, caller.tenantID, caller.userID); err != nil {
+			return toFSError(err)
+		}
+	}
+	if newBase != folder.Name {
+		if err := f.folderUseCase.UpdateFolder(ctx, folder.ID, newBase, caller.tenantID, caller.userID); err != nil {
+			return toFSError(err)
+		}
+	}
+	return nil
+}
+
+// Stat describes a folder or a document
+func (f *fileSystem) Stat(ctx context.Context, name string) (os.FileInfo, error) {
+	folder, document, err := f.lookup(ctx, cleanPath(name))
+	if err != nil {
+		return nil, err
+	}
+	if document != nil {
+		return documentInfo(document), nil
+	}
+	return folderInfo(folder), nil
+}
+
+// lookup finds the folder or the document at a path. The root is a nil folder.
+func (f *fileSystem) lookup(ctx context.Context, name string) (*models.Folder, *models.Document, error) {
+	if name == models.PathSeparator {
+		return nil, nil, nil
+	}
+
+	caller := callerFromContext(ctx)
+	folder, err := f.folderUseCase.GetFolderByPath(ctx, name, caller.tenantID, caller.userID)
+	if err == nil {
+		return folder, nil, nil
+	}
+	if !errors.IsResourceNotFoundError(err) {
+		return nil, nil, toFSError(err)
+	}
+
+	parent, base := path.Split(name)
+	if cleanPath(parent) == models.PathSeparator {
+		return nil, nil, os.ErrNotExist
+	}
+	parentFolder, err := f.folderUseCase.GetFolderByPath(ctx, cleanPath(parent), caller.tenantID, caller.userID)
+	if err != nil {
+		return nil, nil, toFSError(err)
+	}
+	document, err := f.findDocument(ctx, parentFolder.ID, base)
+	if err != nil {
+		return nil, nil, err
+	}
+	return nil, document, nil
+}
+
+// folderID returns the ID of the folder at a path, which is empty for the root
+func (f *fileSystem) folderID(ctx context.Context, name string) (string, error) {
+	name = cleanPath(name)
+	if name == models.PathSeparator {
+		return "", nil
+	}
+
+	caller := callerFromContext(ctx)
+	folder, err := f.folderUseCase.GetFolderByPath(ctx, name, caller.tenantID, caller.userID)
+	if err != nil {
+		return "", toFSError(err)
+	}
+	return folder.ID, nil
+}
+
+// findDocument finds the document with a name in a folder
+func (f *fileSystem) findDocument(ctx context.Context, folderID, name string) (*models.Document, error) {
+	caller := callerFromContext(ctx)
+	for page := 1; ; page++ {
+		folders, documents, err := f.folderUseCase.ListFolderContents(ctx, folderID, caller.tenantID, caller.userID, utils.NewPagination(page, utils.MaxPageSize))
+		if err != nil {
+			return nil, toFSError(err)
+		}
+		for i := range documents.Items {
+			if documents.Items[i].Name == name {
+				return &documents.Items[i], nil
+			}
+		}
+		if !folders.Pagination.HasNext && !documents.Pagination.HasNext {
+			return nil, os.ErrNotExist
+		}
+	}
+}
+
+// create opens a new document for writing. Its content is buffered in a temporary file, since the
+// document's size must be known before it is uploaded.
+func (f *fileSystem) create(ctx context.Context, name string) (webdav.File, error) {
+	parent, base := path.Split(name)
+	if base == "" || cleanPath(parent) == models.PathSeparator {
+		return nil, os.ErrPermission
+	}
+
+	folderID, err := f.folderID(ctx, parent)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := f.findDocument(ctx, folderID, base); err == nil {
+		return nil, os.ErrExist
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	buffer, err := os.CreateTemp("", "dav-upload-*")
+	if err != nil {
+		return nil, err
+	}
+	return &uploadFile{fs: f, ctx: ctx, name: base, folderID: folderID, buffer: buffer}, nil
+}
+
+// listFolder lists the subfolders and documents of a folder, or the root folders for a nil folder
+func (f *fileSystem) listFolder(ctx context.Context, folder *models.Folder) ([]fs.FileInfo, error) {
+	caller := callerFromContext(ctx)
+	var infos []fs.FileInfo
+	for page := 1; ; page++ {
+		pagination := utils.NewPagination(page, utils.MaxPageSize)
+		var folders utils.PaginatedResult[models.Folder]
+		var documents utils.PaginatedResult[models.Document]
+		var err error
+		if folder == nil {
+			folders, err = f.folderUseCase.ListRootFolders(ctx, caller.tenantID, caller.userID, pagination)
+		} else {
+			folders, documents, err = f.folderUseCase.ListFolderContents(ctx, folder.ID, caller.tenantID, caller.userID, pagination)
+		}
+		if err != nil {
+			return nil, toFSError(err)
+		}
+
+		for i := range folders.Items {
+			infos = append(infos, folderInfo(&folders.Items[i]))
+		}
+		for i := range documents.Items {
+			infos = append(infos, documentInfo(&documents.Items[i]))
+		}
+		if !folders.Pagination.HasNext && !documents.Pagination.HasNext {
+			return infos, nil
+		}
+	}
+}
+
+// folderFile is an open folder
+type folderFile struct {
+	fs     *fileSystem
+	ctx    context.Context
+	folder *models.Folder
+}
+
+func (d *folderFile) Close() error                                 { return nil }
+func (d *folderFile) Read(p []byte) (int, error)                   { return 0, os.ErrInvalid }
+func (d *folderFile) Write(p []byte) (int, error)                  { return 0, os.ErrInvalid }
+func (d *folderFile) Seek(offset int64, whence int) (int64, error) { return 0, os.ErrInvalid }
+func (d *folderFile) Stat() (fs.FileInfo, error)                   { return folderInfo(d.folder), nil }
+
+// Readdir lists the folder's subfolders and documents
+func (d *folderFile) Readdir(count int) ([]fs.FileInfo, error) {
+	infos, err := d.fs.listFolder(d.ctx, d.folder)
+	if err != nil {
+		return nil, err
+	}
+	if count > 0 && len(infos) > count {
+		infos = infos[:count]
+	}
+	return infos, nil
+}
+
+// documentFile is a document open for reading. Its content is downloaded on the first read; a
+// read after seeking elsewhere downloads it again and skips to the offset.
+type documentFile struct {
+	fs       *fileSystem
+	ctx      context.Context
+	document *models.Document
+	content  io.ReadCloser
+	position int64 // Offset of content in the document
+	offset   int64 // Offset of the next read
+}
+
+// Read reads the document's content from the current offset
+func (d *documentFile) Read(p []byte) (int, error) {
+	if d.offset >= d.document.Size {
+		return 0, io.EOF
+	}
+
+	if d.content == nil || d.position != d.offset {
+		if err := d.open(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := d.content.Read(p)
+	d.position += int64(n)
+	d.offset = d.position
+	return n, err
+}
+
+// open downloads the document's content and skips to the current offset
+func (d *documentFile) open() error {
+	if d.content != nil {
+		d.content.Close()
+		d.content = nil
+	}
+
+	caller := callerFromContext(d.ctx)
+	download, err := d.fs.documentUseCase.DownloadDocumentRange(d.ctx, d.document.ID, caller.tenantID, caller.userID, "", "")
+	if err != nil {
+		return toFSError(err)
+	}
+	content := download.Content
+	// Stamped documents differ in size from the listed document and on every download, so they
+	// cannot be read in parts
+	if download.Watermarked {
+		content.Close()
+		return os.ErrPermission
+	}
+	if _, err := io.CopyN(io.Discard, content, d.offset); err != nil {
+		content.Close()
+		return err
+	}
+	d.content = content
+	d.position = d.offset
+	return nil
+}
+
+// Seek sets the offset of the next read
+func (d *documentFile) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += d.offset
+	case io.SeekEnd:
+		offset += d.document.Size
+	default:
+		return 0, os.ErrInvalid
+	}
+	if offset < 0 {
+		return 0, os.ErrInvalid
+	}
+	d.offset = offset
+	return offset, nil
+}
+
+// Close releases the document's content
+func (d *documentFile) Close() error {
+	if d.content != nil {
+		return d.content.Close()
+	}
+	return nil
+}
+
+func (d *documentFile) Write(p []byte) (int, error)              { return 0, os.ErrPermission }
+func (d *documentFile) Readdir(count int) ([]fs.FileInfo, error) { return nil, os.ErrInvalid }
+func (d *documentFile) Stat() (fs.FileInfo, error)               { return documentInfo(d.document), nil }
+
+// uploadFile is a new document open for writing. It is uploaded when it is closed.
+type uploadFile struct {
+	fs       *fileSystem
+	ctx      context.Context
+	name     string
+	folderID string
+	buffer   *os.File
+	size     int64
+}
+
+// Write buffers content of the document
+func (u *uploadFile) Write(p []byte) (int, error) {
+	n, err := u.buffer.Write(p)
+	u.size += int64(n)
+	return n, err
+}
+
+// Close uploads the buffered document and removes the buffer
+func (u *uploadFile) Close() error {
+	defer os.Remove(u.buffer.Name())
+	defer u.buffer.Close()
+
+	if _, err := u.buffer.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	contentType := mime.TypeByExtension(path.Ext(u.name))
+	if contentType == "" {
+		contentType = defaultContentType
+	}
+
+	caller := callerFromContext(u.ctx)
+	_, err := u.fs.documentUseCase.UploadDocument(u.ctx, u.name, contentType, u.size, u.folderID,
+		caller.tenantID, caller.userID, u.buffer, nil, "", "")
+	if err != nil {
+		logger.ErrorContext(u.ctx, "WebDAV upload failed", "name", u.name, "folder_id", u.folderID, "error", err.Error())
+		return toFSError(err)
+	}
+	return nil
+}
+
+// Stat describes the document as written so far
+func (u *uploadFile) Stat() (fs.FileInfo, error) {
+	return &fileInfo{name: u.name, size: u.size, modTime: time.Now()}, nil
+}
+
+func (u *uploadFile) Read(p []byte) (int, error)                   { return 0, os.ErrInvalid }
+func (u *uploadFile) Seek(offset int64, whence int) (int64, error) { return 0, os.ErrInvalid }
+func (u *uploadFile) Readdir(count int) ([]fs.FileInfo, error)     { return nil, os.ErrInvalid }
+
+// fileInfo describes a folder or a document
+type fileInfo struct {
+	name        string
+	size        int64
+	modTime     time.Time
+	dir         bool
+	contentType string
+}
+
+// folderInfo describes a folder, or the root for a nil folder
+func folderInfo(folder *models.Folder) *fileInfo {
+	if folder == nil {
+		return &fileInfo{name: models.PathSeparator, dir: true}
+	}
+	return &fileInfo{name: folder.Name, modTime: folder.UpdatedAt, dir: true}
+}
+
+// documentInfo describes a document
+func documentInfo(document *models.Document) *fileInfo {
+	return &fileInfo{
+		name:        document.Name,
+		size:        document.Size,
+		modTime:     document.UpdatedAt,
+		contentType: document.ContentType,
+	}
+}
+
+func (i *fileInfo) Name() string       { return i.name }
+func (i *fileInfo) Size() int64        { return i.size }
+func (i *fileInfo) ModTime() time.Time { return i.modTime }
+func (i *fileInfo) IsDir() bool        { return i.dir }
+func (i *fileInfo) Sys() interface{}   { return nil }
+
+// Mode returns the permission bits of a folder or a document; access is enforced by the use cases
+func (i *fileInfo) Mode() fs.FileMode {
+	if i.dir {
+		return fs.ModeDir | 0755
+	}
+	return 0644
+}
+
+// ContentType returns the stored content type of a document, so that WebDAV does not download
+// documents to sniff it
+func (i *fileInfo) ContentType(ctx context.Context) (string, error) {
+	if i.contentType == "" {
+		return "", webdav.ErrNotImplemented
+	}
+	return i.contentType, nil
+}
+
+// cleanPath returns the canonical form of a WebDAV path
+func cleanPath(name string) string {
+	if name == "" {
+		return models.PathSeparator
+	}
+	if !strings.HasPrefix(name, models.PathSeparator) {
+		name = models.PathSeparator + name
+	}
+	return path.Clean(name)
+}
+
+// toFSError converts an application error into the file system error WebDAV expects
+func toFSError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.IsResourceNotFoundError(err):
+		return os.ErrNotExist
+	case errors.IsAuthorizationError(err), errors.IsAuthenticationError(err), errors.IsValidationError(err), errors.IsQuotaExceededError(err):
+		return os.ErrPermission
+	default:
+		return err
+	}
+}
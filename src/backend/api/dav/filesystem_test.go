@@ -0,0 +1,76 @@
+package dav
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert" // v1.8.0+
+
+	"../../application/usecases" // For the folder and document use cases
+	"../../domain/models"        // For folders and documents
+	"../../domain/services"      // For the folder service behind the folder use case
+	"../../pkg/errors"           // For returning typed errors from fakes
+	"../../pkg/utils"            // For paged folder contents
+)
+
+// fakeFolderService serves a single folder, /inbox, whose documents are split over two pages
+type fakeFolderService struct {
+	services.FolderService
+	pages [][]models.Document
+}
+
+// GetFolderByPath returns the inbox folder
+func (f *fakeFolderService) GetFolderByPath(ctx context.Context, path, tenantID, userID string) (*models.Folder, error) {
+	if path != "/inbox" {
+		return nil, errors.NewResourceNotFoundError("folder not found")
+	}
+	return &models.Folder{ID: "folder-1", Name: "inbox", TenantID: tenantID}, nil
+}
+
+// ListFolderContents returns a page of the inbox documents
+func (f *fakeFolderService) ListFolderContents(ctx context.Context, id, tenantID, userID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Folder], utils.PaginatedResult[models.Document], error) {
+	documents := utils.PaginatedResult[models.Document]{
+		Items:      f.pages[pagination.Page-1],
+		Pagination: utils.PageInfo{Page: pagination.Page, HasNext: pagination.Page < len(f.pages)},
+	}
+	return utils.PaginatedResult[models.Folder]{}, documents, nil
+}
+
+// fakeEventService satisfies the folder use case, which publishes no events when listing
+type fakeEventService struct {
+	services.EventServiceInterface
+}
+
+// fakeDocumentUseCase records the documents deleted through it
+type fakeDocumentUseCase struct {
+	usecases.DocumentUseCase
+	deleted []string
+}
+
+// DeleteDocument records the deleted document
+func (f *fakeDocumentUseCase) DeleteDocument(ctx context.Context, id, tenantID, userID string) error {
+	f.deleted = append(f.deleted, id)
+	return nil
+}
+
+// TestRemoveAllDeletesDocumentFoundThroughFolderContents tests that documents are looked up on
+// every page of their folder's contents and deleted through the document use case
+func TestRemoveAllDeletesDocumentFoundThroughFolderContents(t *testing.T) {
+	folderService := &fakeFolderService{pages: [][]models.Document{
+		{{ID: "doc-1", Name: "invoice.pdf"}},
+		{{ID: "doc-2", Name: "report.pdf"}},
+	}}
+	documentUseCase := &fakeDocumentUseCase{}
+	fs := newFileSystem(usecases.NewFolderUseCase(folderService, &fakeEventService{}), documentUseCase)
+	ctx := context.WithValue(context.Background(), contextKeyCaller, caller{tenantID: "tenant-1", userID: "user-1"})
+
+	info, err := fs.Stat(ctx, "/inbox/report.pdf")
+	assert.NoError(t, err)
+	assert.Equal(t, "report.pdf", info.Name())
+
+	assert.NoError(t, fs.RemoveAll(ctx, "/inbox/report.pdf"))
+	assert.Equal(t, []string{"doc-2"}, documentUseCase.deleted)
+
+	assert.Equal(t, os.ErrNotExist, fs.RemoveAll(ctx, "/inbox/missing.pdf"))
+}
@@ -0,0 +1,205 @@
+package dav
+
+import (
+	"context"       // standard library
+	"crypto/sha256" // standard library
+	"encoding/hex"  // standard library
+	"net"           // standard library
+	"net/http"      // standard library
+	"sync"          // standard library
+	"time"          // standard library
+
+	"github.com/gin-gonic/gin"     // v1.9.0+
+	"github.com/golang-jwt/jwt/v5" // v5.0.0+
+	"golang.org/x/net/webdav"      // v0.17.0+
+
+	"../../application/usecases"
+	"../../domain/services"
+	"../../pkg/errors"
+	"../../pkg/logger"
+)
+
+// PathPrefix is the path under which tenants mount their folders, as PathPrefix/<tenant ID>/
+const PathPrefix = "/dav"
+
+// Methods lists the HTTP methods of WebDAV, which all have to be routed to the handler
+var Methods = []string{
+	http.MethodOptions, http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete,
+	"PROPFIND", "PROPPATCH", "MKCOL", "COPY", "MOVE", "LOCK", "UNLOCK",
+}
+
+// credentialsTTL is how long verified credentials are remembered. Drive clients send credentials
+// with every request, and verifying a password hash for each of them would be slow.
+const credentialsTTL = 5 * time.Minute
+
+// maxCachedCredentials bounds the credentials cache; it is emptied when it grows beyond this
+const maxCachedCredentials = 10000
+
+// basicAuthRealm is the realm announced to clients that send no credentials
+const basicAuthRealm = `Basic realm="Document Management Platform", charset="UTF-8"`
+
+// Authenticator signs users in with their password and validates the resulting access tokens
+type Authenticator interface {
+	Login(ctx context.Context, tenantID, usernameOrEmail, password string) (*usecases.LoginResult, error)
+	ValidateToken(ctx context.Context, token string) (string, []string, error)
+}
+
+// contextKey is the type of the context keys set by the handler
+type contextKey string
+
+// contextKeyCaller is the context key of the authenticated caller
+const contextKeyCaller contextKey = "dav_caller"
+
+// caller is the user a WebDAV request is served for
+type caller struct {
+	tenantID  string
+	userID    string
+	expiresAt time.Time
+}
+
+// callerFromContext returns the caller of a request
+func callerFromContext(ctx context.Context) caller {
+	c, _ := ctx.Value(contextKeyCaller).(caller)
+	return c
+}
+
+// Handler serves WebDAV requests for the folders of the tenant in their path. Clients authenticate
+// with HTTP basic auth, which is exchanged for a platform access token by signing in, so that the
+// account's lockout and password policies apply. Accounts that require MFA cannot use WebDAV.
+// Requests from networks the tenant's network policy does not allow are refused.
+type Handler struct {
+	authenticator        Authenticator
+	networkPolicyService services.NetworkPolicyService
+	fileSystem           *fileSystem
+
+	mu          sync.Mutex
+	credentials map[string]caller
+	locks       map[string]webdav.LockSystem
+}
+
+// NewHandler creates a new WebDAV Handler
+func NewHandler(authenticator Authenticator, networkPolicyService services.NetworkPolicyService, folderUseCase FolderUseCase, documentUseCase usecases.DocumentUseCase) *Handler {
+	if authenticator == nil {
+		panic("authenticator cannot be nil")
+	}
+	if networkPolicyService == nil {
+		panic("networkPolicyService cannot be nil")
+	}
+
+	return &Handler{
+		authenticator:        authenticator,
+		networkPolicyService: networkPolicyService,
+		fileSystem:           newFileSystem(folderUseCase, documentUseCase),
+		credentials:          make(map[string]caller),
+		locks:                make(map[string]webdav.LockSystem),
+	}
+}
+
+// ServeDAV handles a WebDAV request routed as PathPrefix/:tenantId/*path
+func (h *Handler) ServeDAV(c *gin.Context) {
+	tenantID := c.Param("tenantId")
+	username, password, ok := c.Request.BasicAuth()
+	if !ok || tenantID == "" {
+		c.Header("WWW-Authenticate", basicAuthRealm)
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	ctx := c.Request.Context()
+	authenticated, ok := h.authenticate(ctx, tenantID, username, password)
+	if !ok {
+		c.Header("WWW-Authenticate", basicAuthRealm)
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.networkPolicyService.CheckAccess(ctx, authenticated.tenantID, authenticated.userID, net.ParseIP(c.ClientIP())); err != nil {
+		if errors.IsAuthorizationError(err) {
+			c.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+		logger.ErrorContext(ctx, "Failed to check tenant network policy for WebDAV request", "tenant_id", tenantID, "error", err.Error())
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	davHandler := &webdav.Handler{
+		Prefix:     PathPrefix + "/" + tenantID,
+		FileSystem: h.fileSystem,
+		LockSystem: h.lockSystem(tenantID),
+		Logger: func(r *http.Request, err error) {
+			if err != nil {
+				logger.WarnContext(r.Context(), "WebDAV request failed",
+					"method", r.Method,
+					"path", r.URL.Path,
+					"error", err.Error())
+			}
+		},
+	}
+	// Clients send the password with every request, so each one counts as a fresh sign-in for
+	// folders whose download policy requires one
+	ctx = services.ContextWithAuthenticatedAt(context.WithValue(ctx, contextKeyCaller, authenticated), time.Now())
+	davHandler.ServeHTTP(c.Writer, c.Request.WithContext(ctx))
+}
+
+// authenticate verifies basic auth credentials, remembering them for a while once verified
+func (h *Handler) authenticate(ctx context.Context, tenantID, username, password string) (caller, bool) {
+	sum := sha256.Sum256([]byte(tenantID + "\x00" + username + "\x00" + password))
+	key := hex.EncodeToString(sum[:])
+
+	h.mu.Lock()
+	cached, ok := h.credentials[key]
+	h.mu.Unlock()
+	if ok && time.Now().Before(cached.expiresAt) {
+		return cached, true
+	}
+
+	result, err := h.authenticator.Login(ctx, tenantID, username, password)
+	if err != nil {
+		logger.InfoContext(ctx, "WebDAV authentication failed", "tenant_id", tenantID, "error", err.Error())
+		return caller{}, false
+	}
+	if result.AccessToken == "" || result.PasswordExpired {
+		// A second factor or a password change is required, which basic auth cannot provide
+		logger.InfoContext(ctx, "WebDAV authentication requires interactive sign-in", "tenant_id", tenantID)
+		return caller{}, false
+	}
+
+	tokenTenantID, _, err := h.authenticator.ValidateToken(ctx, result.AccessToken)
+	if err != nil || tokenTenantID != tenantID {
+		return caller{}, false
+	}
+
+	// The token was validated above; it is only parsed again for its subject
+	claims := jwt.MapClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(result.AccessToken, claims); err != nil {
+		return caller{}, false
+	}
+	userID, err := claims.GetSubject()
+	if err != nil || userID == "" {
+		return caller{}, false
+	}
+
+	authenticated := caller{tenantID: tenantID, userID: userID, expiresAt: time.Now().Add(credentialsTTL)}
+	h.mu.Lock()
+	if len(h.credentials) >= maxCachedCredentials {
+		h.credentials = make(map[string]caller)
+	}
+	h.credentials[key] = authenticated
+	h.mu.Unlock()
+
+	return authenticated, true
+}
+
+// lockSystem returns the WebDAV locks of a tenant; tenants' paths overlap, so locks are kept apart
+func (h *Handler) lockSystem(tenantID string) webdav.LockSystem {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	locks, ok := h.locks[tenantID]
+	if !ok {
+		locks = webdav.NewMemLS()
+		h.locks[tenantID] = locks
+	}
+	return locks
+}
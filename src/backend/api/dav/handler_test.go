@@ -0,0 +1,148 @@
+package dav
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"            // v1.9.0+
+	"github.com/golang-jwt/jwt/v5"        // v5.0.0+
+	"github.com/stretchr/testify/assert"  // v1.8.0+
+	"github.com/stretchr/testify/require" // v1.8.0+
+
+	"../../application/usecases" // For sign-in results
+	"../../domain/services"      // For the network policy of the handler
+	"../../pkg/errors"           // For returning typed errors from fakes
+)
+
+// fakeAuthenticator signs in a single user with a fixed password
+type fakeAuthenticator struct {
+	result *usecases.LoginResult
+	logins int
+}
+
+// Login returns the configured result for the password "secret"
+func (f *fakeAuthenticator) Login(ctx context.Context, tenantID, usernameOrEmail, password string) (*usecases.LoginResult, error) {
+	f.logins++
+	if password != "secret" {
+		return nil, errors.NewAuthenticationError("invalid credentials")
+	}
+	return f.result, nil
+}
+
+// ValidateToken accepts any token for tenant-1
+func (f *fakeAuthenticator) ValidateToken(ctx context.Context, token string) (string, []string, error) {
+	return "tenant-1", []string{"reader"}, nil
+}
+
+// fakeNetworkPolicyService refuses requests from a single address
+type fakeNetworkPolicyService struct {
+	refused string
+}
+
+// CheckAccess refuses requests from the refused address
+func (f *fakeNetworkPolicyService) CheckAccess(ctx context.Context, tenantID, userID string, ip net.IP) error {
+	if f.refused != "" && ip.Equal(net.ParseIP(f.refused)) {
+		return errors.NewAuthorizationError("access from this network is not allowed by the tenant")
+	}
+	return nil
+}
+
+// newTestRouter routes WebDAV requests to a handler without use cases, which suffices for requests
+// on the root collection
+func newTestRouter(authenticator Authenticator) *gin.Engine {
+	return newTestRouterWithNetworkPolicy(authenticator, &fakeNetworkPolicyService{})
+}
+
+// newTestRouterWithNetworkPolicy routes WebDAV requests to a handler enforcing a network policy
+func newTestRouterWithNetworkPolicy(authenticator Authenticator, networkPolicyService services.NetworkPolicyService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler := NewHandler(authenticator, networkPolicyService, nil, nil)
+	for _, method := range Methods {
+		router.Handle(method, PathPrefix+"/:tenantId/*path", handler.ServeDAV)
+	}
+	return router
+}
+
+// newAccessToken creates an access token for user-1
+func newAccessToken(t *testing.T) string {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"sub": "user-1"}).SignedString([]byte("test-secret"))
+	require.NoError(t, err)
+	return token
+}
+
+// serveOptions sends an OPTIONS request for the root of tenant-1
+func serveOptions(router *gin.Engine, username, password string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodOptions, PathPrefix+"/tenant-1/", nil)
+	if username != "" {
+		req.SetBasicAuth(username, password)
+	}
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	return recorder
+}
+
+// TestServeDAVRequiresCredentials tests that requests without basic auth are challenged
+func TestServeDAVRequiresCredentials(t *testing.T) {
+	router := newTestRouter(&fakeAuthenticator{})
+
+	recorder := serveOptions(router, "", "")
+
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	assert.Contains(t, recorder.Header().Get("WWW-Authenticate"), "Basic")
+}
+
+// TestServeDAVRejectsWrongPassword tests that invalid credentials are rejected
+func TestServeDAVRejectsWrongPassword(t *testing.T) {
+	router := newTestRouter(&fakeAuthenticator{result: &usecases.LoginResult{AccessToken: newAccessToken(t)}})
+
+	recorder := serveOptions(router, "alice", "wrong")
+
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+}
+
+// TestServeDAVRejectsMFAAccounts tests that accounts that need a second factor cannot use WebDAV
+func TestServeDAVRejectsMFAAccounts(t *testing.T) {
+	router := newTestRouter(&fakeAuthenticator{result: &usecases.LoginResult{MFAToken: "mfa", MFARequired: true}})
+
+	recorder := serveOptions(router, "alice", "secret")
+
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+}
+
+// TestServeDAVCachesCredentials tests that verified credentials are not verified again for every request
+func TestServeDAVCachesCredentials(t *testing.T) {
+	authenticator := &fakeAuthenticator{result: &usecases.LoginResult{AccessToken: newAccessToken(t)}}
+	router := newTestRouter(authenticator)
+
+	first := serveOptions(router, "alice", "secret")
+	second := serveOptions(router, "alice", "secret")
+
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, http.StatusOK, second.Code)
+	assert.Contains(t, first.Header().Get("DAV"), "1")
+	assert.Equal(t, 1, authenticator.logins)
+}
+
+// TestServeDAVRefusesDisallowedNetworks tests that requests from networks the tenant does not
+// allow are refused once the caller is known
+func TestServeDAVRefusesDisallowedNetworks(t *testing.T) {
+	authenticator := &fakeAuthenticator{result: &usecases.LoginResult{AccessToken: newAccessToken(t)}}
+	// httptest requests come from 192.0.2.1
+	router := newTestRouterWithNetworkPolicy(authenticator, &fakeNetworkPolicyService{refused: "192.0.2.1"})
+
+	recorder := serveOptions(router, "alice", "secret")
+
+	assert.Equal(t, http.StatusForbidden, recorder.Code)
+}
+
+// TestCleanPath tests the canonical form of WebDAV paths
+func TestCleanPath(t *testing.T) {
+	assert.Equal(t, "/", cleanPath(""))
+	assert.Equal(t, "/", cleanPath("/"))
+	assert.Equal(t, "/finance/invoices", cleanPath("finance/invoices/"))
+	assert.Equal(t, "/finance", cleanPath("/finance/invoices/.."))
+}
@@ -0,0 +1,32 @@
+// Package dto provides Data Transfer Objects for the activity timelines in the Document Management Platform API.
+// This file defines the response structure for the document and folder activity endpoints.
+package dto
+
+import (
+	"../../domain/models"
+	timeutils "../../pkg/utils/time_utils"
+)
+
+// ActivityDTO is a DTO for an entry of the activity timeline of a document or folder
+type ActivityDTO struct {
+	ID           string `json:"id"`
+	Kind         string `json:"kind"`
+	ActorID      string `json:"actor_id"`
+	ResourceType string `json:"resource_type"`
+	ResourceID   string `json:"resource_id"`
+	Description  string `json:"description"`
+	OccurredAt   string `json:"occurred_at"`
+}
+
+// ToActivityDTO converts an activity timeline entry to an ActivityDTO
+func ToActivityDTO(activity models.Activity) ActivityDTO {
+	return ActivityDTO{
+		ID:           activity.ID,
+		Kind:         activity.Kind,
+		ActorID:      activity.ActorID,
+		ResourceType: activity.ResourceType,
+		ResourceID:   activity.ResourceID,
+		Description:  activity.Description,
+		OccurredAt:   timeutils.FormatTime(activity.OccurredAt, ""),
+	}
+}
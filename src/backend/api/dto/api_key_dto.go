@@ -0,0 +1,70 @@
+// Package dto provides Data Transfer Objects for API keys in the Document Management Platform API.
+// This file defines the request and response structures for the API key endpoints.
+package dto
+
+import (
+	"time"
+
+	"../../domain/models"
+	"../../pkg/utils/pagination"
+	timeutils "../../pkg/utils/time_utils"
+)
+
+// CreateAPIKeyRequest is a DTO for creating an API key. An ExpiresInDays of 0 creates a key that does not expire.
+type CreateAPIKeyRequest struct {
+	Name          string `json:"name" binding:"required,max=100"`
+	Role          string `json:"role" binding:"required"`
+	ExpiresInDays int    `json:"expires_in_days" binding:"min=0,max=365"`
+}
+
+// APIKeyDTO is a DTO for API key responses. Key is only set when the key is created or rotated,
+// because the secret is not stored and cannot be shown again.
+type APIKeyDTO struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Role       string `json:"role"`
+	KeyPrefix  string `json:"key_prefix"`
+	Key        string `json:"key,omitempty"`
+	Active     bool   `json:"active"`
+	CreatedBy  string `json:"created_by"`
+	CreatedAt  string `json:"created_at"`
+	RotatedAt  string `json:"rotated_at,omitempty"`
+	LastUsedAt string `json:"last_used_at,omitempty"`
+	ExpiresAt  string `json:"expires_at,omitempty"`
+	RevokedAt  string `json:"revoked_at,omitempty"`
+}
+
+// ToAPIKeyDTO converts a domain APIKey model to an APIKeyDTO
+func ToAPIKeyDTO(key *models.APIKey) APIKeyDTO {
+	dto := APIKeyDTO{
+		ID:        key.ID,
+		Name:      key.Name,
+		Role:      key.Role,
+		KeyPrefix: key.KeyPrefix,
+		Active:    key.IsUsable(time.Now()),
+		CreatedBy: key.CreatedBy,
+		CreatedAt: timeutils.FormatTime(key.CreatedAt, ""),
+	}
+	if key.RotatedAt != nil {
+		dto.RotatedAt = timeutils.FormatTime(*key.RotatedAt, "")
+	}
+	if key.LastUsedAt != nil {
+		dto.LastUsedAt = timeutils.FormatTime(*key.LastUsedAt, "")
+	}
+	if key.ExpiresAt != nil {
+		dto.ExpiresAt = timeutils.FormatTime(*key.ExpiresAt, "")
+	}
+	if key.RevokedAt != nil {
+		dto.RevokedAt = timeutils.FormatTime(*key.RevokedAt, "")
+	}
+	return dto
+}
+
+// ToAPIKeyListDTO converts a paginated list of domain APIKey models to APIKeyDTOs
+func ToAPIKeyListDTO(result pagination.PaginatedResult[models.APIKey]) []APIKeyDTO {
+	dtos := make([]APIKeyDTO, len(result.Items))
+	for i, key := range result.Items {
+		dtos[i] = ToAPIKeyDTO(&key)
+	}
+	return dtos
+}
@@ -0,0 +1,141 @@
+// Package dto provides Data Transfer Objects for document approvals in the Document Management Platform API.
+// This file defines the request and response structures for the approval workflow and approval request endpoints.
+package dto
+
+import (
+	"../../domain/models"
+	timeutils "../../pkg/utils/time_utils"
+)
+
+// ApprovalWorkflowRequest is a DTO for attaching an approval chain to a folder or replacing it.
+// Mode is sequential or parallel and defaults to sequential when omitted; approvers are user IDs in
+// the order they are asked; folder_id is ignored on update.
+type ApprovalWorkflowRequest struct {
+	FolderID  string   `json:"folder_id"`
+	Name      string   `json:"name"`
+	Mode      string   `json:"mode"`
+	Approvers []string `json:"approvers"`
+}
+
+// SubmitForApprovalRequest is a DTO for submitting a document for approval with an optional comment
+type SubmitForApprovalRequest struct {
+	Comment string `json:"comment"`
+}
+
+// ApprovalDecisionRequest is a DTO for approving or rejecting an approval request with an optional comment
+type ApprovalDecisionRequest struct {
+	Comment string `json:"comment"`
+}
+
+// ApprovalWorkflowDTO is a DTO for approval workflow data
+type ApprovalWorkflowDTO struct {
+	ID        string   `json:"id"`
+	FolderID  string   `json:"folder_id"`
+	Name      string   `json:"name"`
+	Mode      string   `json:"mode"`
+	Approvers []string `json:"approvers"`
+	CreatedBy string   `json:"created_by"`
+	CreatedAt string   `json:"created_at"`
+	UpdatedAt string   `json:"updated_at"`
+}
+
+// ApprovalDecisionDTO is a DTO for the decision of one approver of an approval request
+type ApprovalDecisionDTO struct {
+	ApproverID string `json:"approver_id"`
+	Position   int    `json:"position"`
+	Decision   string `json:"decision"`
+	Comment    string `json:"comment,omitempty"`
+	DecidedAt  string `json:"decided_at,omitempty"`
+}
+
+// ApprovalRequestDTO is a DTO for approval request responses; pending_approvers lists the approvers
+// whose decision the request is waiting for
+type ApprovalRequestDTO struct {
+	ID               string                `json:"id"`
+	DocumentID       string                `json:"document_id"`
+	FolderID         string                `json:"folder_id"`
+	WorkflowID       string                `json:"workflow_id,omitempty"`
+	Mode             string                `json:"mode"`
+	Status           string                `json:"status"`
+	SubmittedBy      string                `json:"submitted_by"`
+	Comment          string                `json:"comment,omitempty"`
+	Decisions        []ApprovalDecisionDTO `json:"decisions"`
+	PendingApprovers []string              `json:"pending_approvers"`
+	CreatedAt        string                `json:"created_at"`
+	UpdatedAt        string                `json:"updated_at"`
+	CompletedAt      string                `json:"completed_at,omitempty"`
+}
+
+// ToApprovalWorkflowDomain converts an ApprovalWorkflowRequest to a domain ApprovalWorkflow model
+func ToApprovalWorkflowDomain(request *ApprovalWorkflowRequest, tenantID string, userID string) *models.ApprovalWorkflow {
+	mode := request.Mode
+	if mode == "" {
+		mode = models.ApprovalModeSequential
+	}
+	return models.NewApprovalWorkflow(tenantID, request.FolderID, request.Name, mode, request.Approvers, userID)
+}
+
+// ToApprovalWorkflowDTO converts a domain ApprovalWorkflow model to an ApprovalWorkflowDTO
+func ToApprovalWorkflowDTO(workflow *models.ApprovalWorkflow) ApprovalWorkflowDTO {
+	return ApprovalWorkflowDTO{
+		ID:        workflow.ID,
+		FolderID:  workflow.FolderID,
+		Name:      workflow.Name,
+		Mode:      workflow.Mode,
+		Approvers: workflow.Approvers,
+		CreatedBy: workflow.CreatedBy,
+		CreatedAt: timeutils.FormatTime(workflow.CreatedAt, ""),
+		UpdatedAt: timeutils.FormatTime(workflow.UpdatedAt, ""),
+	}
+}
+
+// ToApprovalWorkflowListDTO converts domain ApprovalWorkflow models to ApprovalWorkflowDTOs
+func ToApprovalWorkflowListDTO(workflows []*models.ApprovalWorkflow) []ApprovalWorkflowDTO {
+	dtos := make([]ApprovalWorkflowDTO, len(workflows))
+	for i, workflow := range workflows {
+		dtos[i] = ToApprovalWorkflowDTO(workflow)
+	}
+	return dtos
+}
+
+// ToApprovalRequestDTO converts a domain ApprovalRequest model to an ApprovalRequestDTO
+func ToApprovalRequestDTO(request *models.ApprovalRequest) ApprovalRequestDTO {
+	dto := ApprovalRequestDTO{
+		ID:               request.ID,
+		DocumentID:       request.DocumentID,
+		FolderID:         request.FolderID,
+		WorkflowID:       request.WorkflowID,
+		Mode:             request.Mode,
+		Status:           request.Status,
+		SubmittedBy:      request.SubmittedBy,
+		Comment:          request.Comment,
+		Decisions:        make([]ApprovalDecisionDTO, len(request.Decisions)),
+		PendingApprovers: request.PendingApprovers(),
+		CreatedAt:        timeutils.FormatTime(request.CreatedAt, ""),
+		UpdatedAt:        timeutils.FormatTime(request.UpdatedAt, ""),
+	}
+	for i, decision := range request.Decisions {
+		dto.Decisions[i] = ApprovalDecisionDTO{
+			ApproverID: decision.ApproverID,
+			Position:   decision.Position,
+			Decision:   decision.Decision,
+			Comment:    decision.Comment,
+		}
+		if decision.DecidedAt != nil {
+			dto.Decisions[i].DecidedAt = timeutils.FormatTime(*decision.DecidedAt, "")
+		}
+	}
+	if request.CompletedAt != nil {
+		dto.CompletedAt = timeutils.FormatTime(*request.CompletedAt, "")
+	}
+	return dto
+}
+
+// ToApprovalRequestListDTO converts a list of domain ApprovalRequest models to ApprovalRequestDTOs
+func ToApprovalRequestListDTO(requests []models.ApprovalRequest) []ApprovalRequestDTO {
+	dtos := make([]ApprovalRequestDTO, len(requests))
+	for i := range requests {
+		dtos[i] = ToApprovalRequestDTO(&requests[i])
+	}
+	return dtos
+}
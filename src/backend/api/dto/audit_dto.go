@@ -0,0 +1,52 @@
+// Package dto provides Data Transfer Objects for audit log operations in the Document Management Platform API.
+// This file defines the response structures for the audit log query endpoints.
+package dto
+
+import (
+	"encoding/json" // standard library
+
+	"../../domain/models"
+	"../../pkg/utils/pagination"
+	timeutils "../../pkg/utils/time_utils"
+)
+
+// AuditLogDTO is a DTO for audit log entry data
+type AuditLogDTO struct {
+	ID           string          `json:"id"`
+	ActorID      string          `json:"actor_id"`
+	ActorIP      string          `json:"actor_ip"`
+	UserAgent    string          `json:"user_agent"`
+	RequestID    string          `json:"request_id"`
+	Action       string          `json:"action"`
+	ResourceType string          `json:"resource_type"`
+	ResourceID   string          `json:"resource_id"`
+	Before       json.RawMessage `json:"before,omitempty"`
+	After        json.RawMessage `json:"after,omitempty"`
+	OccurredAt   string          `json:"occurred_at"`
+}
+
+// ToAuditLogDTO converts a domain AuditLog model to an AuditLogDTO
+func ToAuditLogDTO(entry *models.AuditLog) AuditLogDTO {
+	return AuditLogDTO{
+		ID:           entry.ID,
+		ActorID:      entry.ActorID,
+		ActorIP:      entry.ActorIP,
+		UserAgent:    entry.UserAgent,
+		RequestID:    entry.RequestID,
+		Action:       entry.Action,
+		ResourceType: entry.ResourceType,
+		ResourceID:   entry.ResourceID,
+		Before:       entry.Before,
+		After:        entry.After,
+		OccurredAt:   timeutils.FormatTime(entry.OccurredAt, ""),
+	}
+}
+
+// ToAuditLogListDTO converts a paginated list of domain AuditLog models to AuditLogDTOs
+func ToAuditLogListDTO(result pagination.PaginatedResult[models.AuditLog]) []AuditLogDTO {
+	dtos := make([]AuditLogDTO, len(result.Items))
+	for i, entry := range result.Items {
+		dtos[i] = ToAuditLogDTO(&entry)
+	}
+	return dtos
+}
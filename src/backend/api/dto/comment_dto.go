@@ -0,0 +1,97 @@
+// Package dto provides Data Transfer Objects for document comments in the Document Management Platform API.
+// This file defines the request and response structures for the comment endpoints.
+package dto
+
+import (
+	"../../domain/models"
+	timeutils "../../pkg/utils/time_utils"
+)
+
+// CommentAnchorDTO is a DTO placing a comment on a page of a document and optionally on a region of
+// the page, with coordinates relative to the page from 0 to 1
+type CommentAnchorDTO struct {
+	Page   int     `json:"page"`
+	X      float64 `json:"x,omitempty"`
+	Y      float64 `json:"y,omitempty"`
+	Width  float64 `json:"width,omitempty"`
+	Height float64 `json:"height,omitempty"`
+}
+
+// CreateCommentRequest is a DTO for commenting on a document; parent_id replies to a thread and
+// anchor places a new thread on a page or region
+type CreateCommentRequest struct {
+	Body     string            `json:"body" binding:"required"`
+	ParentID string            `json:"parent_id"`
+	Anchor   *CommentAnchorDTO `json:"anchor"`
+}
+
+// UpdateCommentRequest is a DTO for editing the body of a comment
+type UpdateCommentRequest struct {
+	Body string `json:"body" binding:"required"`
+}
+
+// CommentDTO is a DTO for comment responses
+type CommentDTO struct {
+	ID         string            `json:"id"`
+	DocumentID string            `json:"document_id"`
+	ParentID   string            `json:"parent_id,omitempty"`
+	AuthorID   string            `json:"author_id"`
+	Body       string            `json:"body"`
+	Anchor     *CommentAnchorDTO `json:"anchor,omitempty"`
+	Resolved   bool              `json:"resolved"`
+	ResolvedBy string            `json:"resolved_by,omitempty"`
+	ResolvedAt string            `json:"resolved_at,omitempty"`
+	CreatedAt  string            `json:"created_at"`
+	UpdatedAt  string            `json:"updated_at"`
+}
+
+// ToCommentAnchor converts an optional CommentAnchorDTO to a domain CommentAnchor
+func ToCommentAnchor(anchor *CommentAnchorDTO) models.CommentAnchor {
+	if anchor == nil {
+		return models.CommentAnchor{}
+	}
+	return models.CommentAnchor{
+		Page:   anchor.Page,
+		X:      anchor.X,
+		Y:      anchor.Y,
+		Width:  anchor.Width,
+		Height: anchor.Height,
+	}
+}
+
+// ToCommentDTO converts a domain Comment model to a CommentDTO
+func ToCommentDTO(comment *models.Comment) CommentDTO {
+	dto := CommentDTO{
+		ID:         comment.ID,
+		DocumentID: comment.DocumentID,
+		ParentID:   comment.ParentID,
+		AuthorID:   comment.AuthorID,
+		Body:       comment.Body,
+		Resolved:   comment.IsResolved(),
+		ResolvedBy: comment.ResolvedBy,
+		CreatedAt:  timeutils.FormatTime(comment.CreatedAt, ""),
+		UpdatedAt:  timeutils.FormatTime(comment.UpdatedAt, ""),
+	}
+	if !comment.Anchor.IsZero() {
+		dto.Anchor = &CommentAnchorDTO{
+			Page:   comment.Anchor.Page,
+			X:      comment.Anchor.X,
+			Y:      comment.Anchor.Y,
+			Width:  comment.Anchor.Width,
+			Height: comment.Anchor.Height,
+		}
+	}
+	if comment.ResolvedAt != nil {
+		dto.ResolvedAt = timeutils.FormatTime(*comment.ResolvedAt, "")
+	}
+	return dto
+}
+
+// ToCommentListDTO converts a list of domain Comment models to CommentDTOs
+func ToCommentListDTO(comments []models.Comment) []CommentDTO {
+	dtos := make([]CommentDTO, len(comments))
+	for i := range comments {
+		dtos[i] = ToCommentDTO(&comments[i])
+	}
+	return dtos
+}
@@ -22,12 +22,16 @@ type DocumentDTO struct {
 	Size          int64                 `json:"size"`
 	FolderID      string                `json:"folder_id"`
 	Status        string                `json:"status"`
+	Visibility    string                `json:"visibility"`
+	PublishAt     string                `json:"publish_at,omitempty"`
+	ExpireAt      string                `json:"expire_at,omitempty"`
 	CreatedAt     string                `json:"created_at"`
 	UpdatedAt     string                `json:"updated_at"`
 	CreatedBy     string                `json:"created_by"`
 	Metadata      []DocumentMetadataDTO `json:"metadata,omitempty"`
 	Tags          []TagDTO              `json:"tags,omitempty"`
 	LatestVersion DocumentVersionDTO    `json:"latest_version,omitempty"`
+	Links         []DocumentLinkDTO     `json:"links,omitempty"`
 }
 
 // DocumentMetadataDTO represents document metadata in API responses
@@ -41,13 +45,15 @@ type DocumentMetadataDTO struct {
 
 // DocumentVersionDTO represents a document version in API responses
 type DocumentVersionDTO struct {
-	ID            string `json:"id"`
-	VersionNumber int    `json:"version_number"`
-	Size          int64  `json:"size"`
-	ContentHash   string `json:"content_hash"`
-	Status        string `json:"status"`
-	CreatedAt     string `json:"created_at"`
-	CreatedBy     string `json:"created_by"`
+	ID                  string `json:"id"`
+	VersionNumber       int    `json:"version_number"`
+	Size                int64  `json:"size"`
+	ContentHash         string `json:"content_hash"`
+	ClientChecksum      string `json:"client_checksum,omitempty"`
+	IntegrityVerifiedAt string `json:"integrity_verified_at,omitempty"`
+	Status              string `json:"status"`
+	CreatedAt           string `json:"created_at"`
+	CreatedBy           string `json:"created_by"`
 }
 
 // TagDTO represents a tag in API responses
@@ -63,6 +69,8 @@ type CreateDocumentRequest struct {
 	File     *multipart.FileHeader `form:"file" json:"-"`
 	Metadata map[string]string     `form:"metadata" json:"metadata,omitempty"`
 	Tags     []string              `form:"tags" json:"tags,omitempty"`
+	Priority string                `form:"priority" json:"priority,omitempty"` // Scan priority hint: high, normal or low
+	Sha256   string                `form:"sha256" json:"sha256,omitempty"`     // Hex encoded SHA-256 hash of the file, checked after it is stored
 }
 
 // Validate validates the create document request
@@ -79,6 +87,32 @@ func (r *CreateDocumentRequest) Validate() error {
 	return nil
 }
 
+// UploadVersionDeltaRequest represents a request to upload a new version of a document as a delta
+// patch against one of its versions, built from the signature of that version
+type UploadVersionDeltaRequest struct {
+	BaseVersionID string                `form:"base_version_id" json:"base_version_id"`
+	Size          int64                 `form:"size" json:"size"`     // Size of the new version in bytes
+	Sha256        string                `form:"sha256" json:"sha256"` // Hex encoded SHA-256 hash of the new version
+	Patch         *multipart.FileHeader `form:"patch" json:"-"`
+}
+
+// Validate validates the delta upload request
+func (r *UploadVersionDeltaRequest) Validate() error {
+	if r.BaseVersionID == "" {
+		return errors.NewValidationError("base version ID is required")
+	}
+	if r.Size <= 0 {
+		return errors.NewValidationError("size must be greater than 0")
+	}
+	if r.Sha256 == "" {
+		return errors.NewValidationError("sha256 is required")
+	}
+	if r.Patch == nil {
+		return errors.NewValidationError("patch is required")
+	}
+	return nil
+}
+
 // UpdateDocumentRequest represents a request to update an existing document
 type UpdateDocumentRequest struct {
 	Name       string            `json:"name,omitempty"`
@@ -97,6 +131,14 @@ func (r *UpdateDocumentRequest) Validate() error {
 	return nil
 }
 
+// ScheduleDocumentRequest represents a request to set when a document is published to readers and
+// when it expires, as RFC 3339 times. Omitted or null times publish the document right away and
+// never expire it.
+type ScheduleDocumentRequest struct {
+	PublishAt *time.Time `json:"publish_at"`
+	ExpireAt  *time.Time `json:"expire_at"`
+}
+
 // DocumentUploadResponse represents a response to a document upload request
 type DocumentUploadResponse struct {
 	DocumentID string `json:"document_id"`
@@ -104,6 +146,77 @@ type DocumentUploadResponse struct {
 	Message    string `json:"message,omitempty"`
 }
 
+// BatchUploadRequest represents a request to upload several documents into a folder; the files are
+// sent as repeated "files" parts and are named after their file names
+type BatchUploadRequest struct {
+	FolderID string                  `form:"folder_id" json:"folder_id"`
+	Files    []*multipart.FileHeader `form:"files" json:"-"`
+	Priority string                  `form:"priority" json:"priority,omitempty"` // Scan priority hint: high, normal or low
+}
+
+// Validate validates the batch upload request
+func (r *BatchUploadRequest) Validate() error {
+	if r.FolderID == "" {
+		return errors.NewValidationError("folder ID is required")
+	}
+	if len(r.Files) == 0 {
+		return errors.NewValidationError("at least one file is required")
+	}
+	return nil
+}
+
+// BatchUploadFileResult represents the outcome of uploading a file of a batch
+type BatchUploadFileResult struct {
+	Name       string       `json:"name"`
+	DocumentID string       `json:"document_id,omitempty"`
+	Status     string       `json:"status"` // processing or failed
+	Error      *ErrorDetail `json:"error,omitempty"`
+}
+
+// BatchUploadResponse represents a response to a batch upload request, with a result per file in
+// the order the files were sent
+type BatchUploadResponse struct {
+	Results   []BatchUploadFileResult `json:"results"`
+	Succeeded int                     `json:"succeeded"`
+	Failed    int                     `json:"failed"`
+}
+
+// BulkMetadataUpdateRequest represents a request to change the metadata of many documents at once:
+// the keys of set are added or overwritten and the keys of remove are deleted. With atomic, no
+// document is updated unless all of them can be.
+type BulkMetadataUpdateRequest struct {
+	DocumentIDs []string          `json:"document_ids"`
+	Set         map[string]string `json:"set"`
+	Remove      []string          `json:"remove"`
+	Atomic      bool              `json:"atomic"`
+}
+
+// Validate validates the bulk metadata update request
+func (r *BulkMetadataUpdateRequest) Validate() error {
+	if len(r.DocumentIDs) == 0 {
+		return errors.NewValidationError("at least one document ID is required")
+	}
+	if len(r.Set) == 0 && len(r.Remove) == 0 {
+		return errors.NewValidationError("at least one metadata key must be set or removed")
+	}
+	return nil
+}
+
+// BulkMetadataDocumentResult represents the outcome of a bulk metadata update for a document
+type BulkMetadataDocumentResult struct {
+	DocumentID string       `json:"document_id"`
+	Status     string       `json:"status"` // updated or failed
+	Error      *ErrorDetail `json:"error,omitempty"`
+}
+
+// BulkMetadataUpdateResponse represents a response to a bulk metadata update request, with a result
+// per document in the order the document IDs were sent
+type BulkMetadataUpdateResponse struct {
+	Results   []BulkMetadataDocumentResult `json:"results"`
+	Succeeded int                          `json:"succeeded"`
+	Failed    int                          `json:"failed"`
+}
+
 // DocumentDownloadResponse represents a response to a document download request
 type DocumentDownloadResponse struct {
 	DocumentID  string `json:"document_id"`
@@ -147,6 +260,7 @@ type DocumentListRequest struct {
 	PageSize  int               `form:"page_size" json:"page_size"`
 	SortBy    string            `form:"sort_by" json:"sort_by,omitempty"`
 	SortOrder string            `form:"sort_order" json:"sort_order,omitempty"`
+	Cursor    string            `form:"cursor" json:"cursor,omitempty"` // NextCursor of the previous page; Page is ignored when set
 	Filters   map[string]string `form:"filters" json:"filters,omitempty"`
 }
 
@@ -161,15 +275,23 @@ func (r *DocumentListRequest) Validate() error {
 	if r.PageSize < 1 || r.PageSize > 100 {
 		return errors.NewValidationError("page size must be between 1 and 100")
 	}
-	if r.SortBy != "" && r.SortBy != "name" && r.SortBy != "created_at" && r.SortBy != "updated_at" && r.SortBy != "size" {
-		return errors.NewValidationError("invalid sort_by parameter")
-	}
-	if r.SortOrder != "" && r.SortOrder != "asc" && r.SortOrder != "desc" {
-		return errors.NewValidationError("sort_order must be 'asc' or 'desc'")
+	if err := ValidateSort(r.SortBy, r.SortOrder, []string{SortByName, SortByCreatedAt, SortByUpdatedAt, SortBySize}); err != nil {
+		return err
 	}
 	return nil
 }
 
+// IntegrityCheckResponse represents the result of reading a document's stored content back and
+// hashing it again
+type IntegrityCheckResponse struct {
+	DocumentID   string `json:"document_id"`
+	VersionID    string `json:"version_id"`
+	ExpectedHash string `json:"expected_hash"`
+	ActualHash   string `json:"actual_hash"`
+	Intact       bool   `json:"intact"`
+	CheckedAt    string `json:"checked_at"`
+}
+
 // DocumentStatusResponse represents a response to a document status check request
 type DocumentStatusResponse struct {
 	DocumentID         string `json:"document_id"`
@@ -187,6 +309,7 @@ func DocumentToDTO(document models.Document) DocumentDTO {
 		Size:        document.Size,
 		FolderID:    document.FolderID,
 		Status:      document.Status,
+		Visibility:  models.DocumentVisibilityPublished,
 		CreatedAt:   timeutils.FormatTimeDefault(document.CreatedAt),
 		UpdatedAt:   timeutils.FormatTimeDefault(document.UpdatedAt),
 		CreatedBy:   document.OwnerID,
@@ -194,6 +317,17 @@ func DocumentToDTO(document models.Document) DocumentDTO {
 		Tags:        make([]TagDTO, 0, len(document.Tags)),
 	}
 
+	// Add the publication schedule
+	if !document.IsPublished() {
+		dto.Visibility = document.Visibility
+	}
+	if document.PublishAt != nil {
+		dto.PublishAt = timeutils.FormatTimeDefault(*document.PublishAt)
+	}
+	if document.ExpireAt != nil {
+		dto.ExpireAt = timeutils.FormatTimeDefault(*document.ExpireAt)
+	}
+
 	// Convert metadata
 	for _, metadata := range document.Metadata {
 		dto.Metadata = append(dto.Metadata, DocumentMetadataToDTO(metadata))
@@ -210,6 +344,11 @@ func DocumentToDTO(document models.Document) DocumentDTO {
 		dto.LatestVersion = DocumentVersionToDTO(*latestVersion)
 	}
 
+	// Add the links from and to the document, outgoing first
+	if len(document.Links)+len(document.LinkedFrom) > 0 {
+		dto.Links = append(ToDocumentLinkListDTO(document.Links, document.ID), ToDocumentLinkListDTO(document.LinkedFrom, document.ID)...)
+	}
+
 	return dto
 }
 
@@ -224,14 +363,31 @@ func DocumentsToDTOs(documents []models.Document) []DocumentDTO {
 
 // DocumentVersionToDTO converts a domain DocumentVersion model to a DocumentVersionDTO
 func DocumentVersionToDTO(version models.DocumentVersion) DocumentVersionDTO {
-	return DocumentVersionDTO{
-		ID:            version.ID,
-		VersionNumber: version.VersionNumber,
-		Size:          version.Size,
-		ContentHash:   version.ContentHash,
-		Status:        version.Status,
-		CreatedAt:     timeutils.FormatTimeDefault(version.CreatedAt),
-		CreatedBy:     version.CreatedBy,
+	dto := DocumentVersionDTO{
+		ID:             version.ID,
+		VersionNumber:  version.VersionNumber,
+		Size:           version.Size,
+		ContentHash:    version.ContentHash,
+		ClientChecksum: version.ClientChecksum,
+		Status:         version.Status,
+		CreatedAt:      timeutils.FormatTimeDefault(version.CreatedAt),
+		CreatedBy:      version.CreatedBy,
+	}
+	if version.IntegrityVerifiedAt != nil {
+		dto.IntegrityVerifiedAt = timeutils.FormatTimeDefault(*version.IntegrityVerifiedAt)
+	}
+	return dto
+}
+
+// IntegrityCheckToResponse converts a domain IntegrityCheck model to an IntegrityCheckResponse
+func IntegrityCheckToResponse(check models.IntegrityCheck) IntegrityCheckResponse {
+	return IntegrityCheckResponse{
+		DocumentID:   check.DocumentID,
+		VersionID:    check.VersionID,
+		ExpectedHash: check.ExpectedHash,
+		ActualHash:   check.ActualHash,
+		Intact:       check.Intact,
+		CheckedAt:    timeutils.FormatTimeDefault(check.CheckedAt),
 	}
 }
 
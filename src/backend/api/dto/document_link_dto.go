@@ -0,0 +1,70 @@
+// Package dto provides Data Transfer Objects for document links in the Document Management Platform API.
+// This file defines the request and response structures for the document link endpoints.
+package dto
+
+import (
+	"../../domain/models"
+	timeutils "../../pkg/utils/time_utils"
+)
+
+// Document link directions, relative to the document a link is listed for
+const (
+	DocumentLinkDirectionOutgoing = "outgoing"
+	DocumentLinkDirectionIncoming = "incoming"
+)
+
+// CreateDocumentLinkRequest is a DTO for linking a document to a target document; type is
+// relates-to, supersedes or attachment-of and reads as "document <type> target"
+type CreateDocumentLinkRequest struct {
+	TargetID string `json:"target_id" binding:"required"`
+	Type     string `json:"type" binding:"required"`
+}
+
+// UpdateDocumentLinkRequest is a DTO for changing the type of a document link
+type UpdateDocumentLinkRequest struct {
+	Type string `json:"type" binding:"required"`
+}
+
+// DocumentLinkDTO is a DTO for document link responses. Direction is outgoing when the document the
+// link is listed for is its source and incoming when it is its target.
+type DocumentLinkDTO struct {
+	ID        string `json:"id"`
+	SourceID  string `json:"source_id"`
+	TargetID  string `json:"target_id"`
+	Type      string `json:"type"`
+	Direction string `json:"direction,omitempty"`
+	CreatedBy string `json:"created_by"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+// ToDocumentLinkDTO converts a domain DocumentLink model to a DocumentLinkDTO, with the direction
+// relative to the given document
+func ToDocumentLinkDTO(link *models.DocumentLink, documentID string) DocumentLinkDTO {
+	dto := DocumentLinkDTO{
+		ID:        link.ID,
+		SourceID:  link.SourceID,
+		TargetID:  link.TargetID,
+		Type:      link.Type,
+		CreatedBy: link.CreatedBy,
+		CreatedAt: timeutils.FormatTime(link.CreatedAt, ""),
+		UpdatedAt: timeutils.FormatTime(link.UpdatedAt, ""),
+	}
+	switch documentID {
+	case link.SourceID:
+		dto.Direction = DocumentLinkDirectionOutgoing
+	case link.TargetID:
+		dto.Direction = DocumentLinkDirectionIncoming
+	}
+	return dto
+}
+
+// ToDocumentLinkListDTO converts a list of domain DocumentLink models to DocumentLinkDTOs, with the
+// directions relative to the given document
+func ToDocumentLinkListDTO(links []models.DocumentLink, documentID string) []DocumentLinkDTO {
+	dtos := make([]DocumentLinkDTO, len(links))
+	for i := range links {
+		dtos[i] = ToDocumentLinkDTO(&links[i], documentID)
+	}
+	return dtos
+}
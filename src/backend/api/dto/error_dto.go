@@ -1,127 +1,278 @@
 // Package dto provides Data Transfer Objects for the Document Management Platform API.
 // This file contains error response DTOs and utility functions for creating standardized
 // error responses. These DTOs ensure consistent error handling and presentation across all
-// API endpoints.
+// API endpoints: errors are returned as problem details (RFC 7807) with a tenant-safe code.
 package dto
 
 import (
+	"context"  // standard library
 	"net/http" // standard library
 	"time"     // standard library
 
 	"../../pkg/errors"
+	"../../pkg/i18n"
+	"../../pkg/logger"
 	timeutils "../../pkg/utils/time_utils"
 )
 
+// Errors shared by the handlers and middleware, for requests that cannot be served whatever they ask for
+var (
+	// ErrInvalidRequestFormat is returned for requests whose body or parameters cannot be read
+	ErrInvalidRequestFormat = errors.WithCode(errors.NewValidationError("invalid request format"), errors.CodeInvalidRequestFormat)
+
+	// ErrTenantContextRequired is returned for requests made on behalf of no tenant
+	ErrTenantContextRequired = errors.WithCode(errors.NewAuthenticationError("tenant context required"), errors.CodeTenantContextRequired)
+
+	// ErrUserContextRequired is returned for requests made on behalf of no user
+	ErrUserContextRequired = errors.WithCode(errors.NewAuthenticationError("user context required"), errors.CodeUserContextRequired)
+)
+
+// ProblemContentType is the media type of error responses, which are problem details (RFC 7807)
+const ProblemContentType = "application/problem+json"
+
+// ProblemTypeBaseURI is the base of the type URIs of problems. The type of a problem is the base
+// followed by its code, which is also where the error catalog describes the code.
+const ProblemTypeBaseURI = "/api/v1/errors/"
+
 // ErrorDetail contains detailed information about an error
 type ErrorDetail struct {
 	Type       string `json:"type"`
+	Code       string `json:"code"`
 	Message    string `json:"message"`
 	StatusCode int    `json:"status_code"`
 }
 
-// ErrorResponse represents a standard error response for API endpoints
+// FieldViolationDTO describes a field of a request breaking a validation rule
+type FieldViolationDTO struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// ErrorResponse represents a standard error response for API endpoints, as problem details
+// (RFC 7807). Violations lists every field violation of invalid requests. Success, Timestamp and
+// Error are extension members kept for clients written before error responses were problem
+// details; they are deprecated.
 type ErrorResponse struct {
-	Success   bool        `json:"success"`
-	Timestamp string      `json:"timestamp"`
-	Error     ErrorDetail `json:"error"`
+	Type       string              `json:"type"`
+	Title      string              `json:"title"`
+	Status     int                 `json:"status"`
+	Detail     string              `json:"detail"`
+	Instance   string              `json:"instance,omitempty"`
+	Code       string              `json:"code"`
+	Violations []FieldViolationDTO `json:"violations,omitempty"`
+	Success    bool                `json:"success"`
+	Timestamp  string              `json:"timestamp"`
+	Error      ErrorDetail         `json:"error"`
 }
 
-// ValidationErrorResponse represents a validation error response for API endpoints
+// ValidationErrorResponse represents a validation error response for API endpoints.
+// ValidationErrors holds the messages by field, those of the violations unless given.
 type ValidationErrorResponse struct {
-	Success          bool              `json:"success"`
-	Timestamp        string            `json:"timestamp"`
-	Error            ErrorDetail       `json:"error"`
+	ErrorResponse
 	ValidationErrors map[string]string `json:"validation_errors"`
 }
 
-// NewErrorResponse creates a new ErrorResponse with the given error
-func NewErrorResponse(err error) ErrorResponse {
-	return ErrorResponse{
-		Success:   false,
-		Timestamp: timeutils.FormatTime(time.Now(), ""),
-		Error: ErrorDetail{
-			Type:       errors.GetErrorType(err),
-			Message:    err.Error(),
-			StatusCode: errors.GetStatusCode(err),
-		},
+// NewErrorResponse creates a new ErrorResponse with the given error. The message is shown in the
+// language carried by ctx when the error has a localized message. Internal errors, and errors
+// that are not AppErrors, are shown as NewInternalErrorResponse shows them.
+func NewErrorResponse(ctx context.Context, err error) ErrorResponse {
+	response := newProblem(ctx, NewErrorDetail(ctx, err))
+	if response.Error.Type == errors.ErrorTypeValidation {
+		response.Violations = toFieldViolationDTOs(errors.GetViolations(err))
+	}
+	return response
+}
+
+// NewErrorDetail creates the ErrorDetail NewErrorResponse describes the error with, for responses
+// reporting the errors of several items, such as batch uploads
+func NewErrorDetail(ctx context.Context, err error) ErrorDetail {
+	errorType := errors.GetErrorType(err)
+	if errorType == "" || errorType == errors.ErrorTypeInternal {
+		return internalErrorDetail(ctx)
+	}
+	return ErrorDetail{
+		Type:       errorType,
+		Code:       errors.GetCode(err),
+		Message:    localizedMessage(ctx, err),
+		StatusCode: errors.GetStatusCode(err),
 	}
 }
 
 // NewValidationErrorResponse creates a new ValidationErrorResponse with the given validation errors
-func NewValidationErrorResponse(err error, validationErrors map[string]string) ValidationErrorResponse {
-	return ValidationErrorResponse{
-		Success:   false,
-		Timestamp: timeutils.FormatTime(time.Now(), ""),
-		Error: ErrorDetail{
+func NewValidationErrorResponse(ctx context.Context, err error, validationErrors map[string]string) ValidationErrorResponse {
+	response := ValidationErrorResponse{
+		ErrorResponse: newProblem(ctx, ErrorDetail{
 			Type:       errors.ErrorTypeValidation,
-			Message:    err.Error(),
+			Code:       errorCode(err, errors.ErrorTypeValidation),
+			Message:    localizedMessage(ctx, err),
 			StatusCode: http.StatusBadRequest,
-		},
+		}),
 		ValidationErrors: validationErrors,
 	}
+
+	violations := errors.GetViolations(err)
+	if errors.IsValidationError(err) && len(violations) > 0 {
+		response.Violations = toFieldViolationDTOs(violations)
+		if len(validationErrors) == 0 {
+			response.ValidationErrors = make(map[string]string, len(violations))
+			for _, violation := range violations {
+				if message, ok := response.ValidationErrors[violation.Field]; ok {
+					response.ValidationErrors[violation.Field] = message + "; " + violation.Message
+					continue
+				}
+				response.ValidationErrors[violation.Field] = violation.Message
+			}
+		}
+	}
+	return response
 }
 
 // NewResourceNotFoundErrorResponse creates a new ErrorResponse for resource not found errors
-func NewResourceNotFoundErrorResponse(err error) ErrorResponse {
-	return ErrorResponse{
-		Success:   false,
-		Timestamp: timeutils.FormatTime(time.Now(), ""),
-		Error: ErrorDetail{
-			Type:       errors.ErrorTypeNotFound,
-			Message:    err.Error(),
-			StatusCode: http.StatusNotFound,
-		},
-	}
+func NewResourceNotFoundErrorResponse(ctx context.Context, err error) ErrorResponse {
+	return newProblem(ctx, ErrorDetail{
+		Type:       errors.ErrorTypeNotFound,
+		Code:       errorCode(err, errors.ErrorTypeNotFound),
+		Message:    localizedMessage(ctx, err),
+		StatusCode: http.StatusNotFound,
+	})
 }
 
 // NewAuthorizationErrorResponse creates a new ErrorResponse for authorization errors
-func NewAuthorizationErrorResponse(err error) ErrorResponse {
-	return ErrorResponse{
-		Success:   false,
-		Timestamp: timeutils.FormatTime(time.Now(), ""),
-		Error: ErrorDetail{
-			Type:       errors.ErrorTypeAuthorization,
-			Message:    err.Error(),
-			StatusCode: http.StatusForbidden,
-		},
-	}
+func NewAuthorizationErrorResponse(ctx context.Context, err error) ErrorResponse {
+	return newProblem(ctx, ErrorDetail{
+		Type:       errors.ErrorTypeAuthorization,
+		Code:       errorCode(err, errors.ErrorTypeAuthorization),
+		Message:    localizedMessage(ctx, err),
+		StatusCode: http.StatusForbidden,
+	})
 }
 
 // NewAuthenticationErrorResponse creates a new ErrorResponse for authentication errors
-func NewAuthenticationErrorResponse(err error) ErrorResponse {
-	return ErrorResponse{
-		Success:   false,
-		Timestamp: timeutils.FormatTime(time.Now(), ""),
-		Error: ErrorDetail{
-			Type:       errors.ErrorTypeAuthentication,
-			Message:    err.Error(),
-			StatusCode: http.StatusUnauthorized,
-		},
+func NewAuthenticationErrorResponse(ctx context.Context, err error) ErrorResponse {
+	return newProblem(ctx, ErrorDetail{
+		Type:       errors.ErrorTypeAuthentication,
+		Code:       errorCode(err, errors.ErrorTypeAuthentication),
+		Message:    localizedMessage(ctx, err),
+		StatusCode: http.StatusUnauthorized,
+	})
+}
+
+// NewInternalErrorResponse creates a new ErrorResponse for internal server errors. Neither the
+// message nor the code of err are shown, since they may reveal details of the platform.
+func NewInternalErrorResponse(ctx context.Context, err error) ErrorResponse {
+	return newProblem(ctx, internalErrorDetail(ctx))
+}
+
+// NewDependencyErrorResponse creates a new ErrorResponse for dependency errors
+func NewDependencyErrorResponse(ctx context.Context, err error) ErrorResponse {
+	return newProblem(ctx, ErrorDetail{
+		Type:       errors.ErrorTypeDependency,
+		Code:       errorCode(err, errors.ErrorTypeDependency),
+		Message:    localizedMessage(ctx, err),
+		StatusCode: http.StatusServiceUnavailable,
+	})
+}
+
+// ErrorCodeDTO describes a code of the error catalog, in the language carried by the context
+// it was created with
+type ErrorCodeDTO struct {
+	Code        string `json:"code"`
+	Type        string `json:"type"`
+	Title       string `json:"title"`
+	Status      int    `json:"status"`
+	Category    string `json:"category"`
+	Description string `json:"description"`
+}
+
+// ToErrorCodeDTO converts a code of the error catalog to its DTO. The type and title are those of
+// the problems with the code, and the category is their error type.
+func ToErrorCodeDTO(ctx context.Context, info errors.CodeInfo) ErrorCodeDTO {
+	description, _ := i18n.Translate(i18n.LanguageFromContext(ctx), info.Code, nil)
+	return ErrorCodeDTO{
+		Code:        info.Code,
+		Type:        ProblemTypeBaseURI + info.Code,
+		Title:       ProblemTitle(ctx, info.Type),
+		Status:      info.Status,
+		Category:    info.Type,
+		Description: description,
 	}
 }
 
-// NewInternalErrorResponse creates a new ErrorResponse for internal server errors
-func NewInternalErrorResponse(err error) ErrorResponse {
-	return ErrorResponse{
-		Success:   false,
-		Timestamp: timeutils.FormatTime(time.Now(), ""),
-		Error: ErrorDetail{
-			Type:       errors.ErrorTypeInternal,
-			Message:    "An internal server error occurred",
-			StatusCode: http.StatusInternalServerError,
-		},
+// ProblemTitle returns the title of the problems of an error type in the language carried by
+// ctx, the type itself when it has no title
+func ProblemTitle(ctx context.Context, errorType string) string {
+	if title, ok := i18n.Translate(i18n.LanguageFromContext(ctx), "error_type."+errorType, nil); ok {
+		return title
 	}
+	return errorType
 }
 
-// NewDependencyErrorResponse creates a new ErrorResponse for dependency errors
-func NewDependencyErrorResponse(err error) ErrorResponse {
-	return ErrorResponse{
+// newProblem creates the ErrorResponse describing an error. The instance identifies the request
+// by its ID, which support can look up in the logs.
+func newProblem(ctx context.Context, detail ErrorDetail) ErrorResponse {
+	response := ErrorResponse{
+		Type:      ProblemTypeBaseURI + detail.Code,
+		Title:     ProblemTitle(ctx, detail.Type),
+		Status:    detail.StatusCode,
+		Detail:    detail.Message,
+		Code:      detail.Code,
 		Success:   false,
 		Timestamp: timeutils.FormatTime(time.Now(), ""),
-		Error: ErrorDetail{
-			Type:       errors.ErrorTypeDependency,
-			Message:    err.Error(),
-			StatusCode: http.StatusServiceUnavailable,
-		},
+		Error:     detail,
+	}
+	if requestID := logger.RequestIDFromContext(ctx); requestID != "" {
+		response.Instance = "urn:request:" + requestID
+	}
+	return response
+}
+
+// toFieldViolationDTOs converts field violations to their DTOs
+func toFieldViolationDTOs(violations []errors.FieldViolation) []FieldViolationDTO {
+	if len(violations) == 0 {
+		return nil
 	}
-}
\ No newline at end of file
+	dtos := make([]FieldViolationDTO, len(violations))
+	for i, violation := range violations {
+		dtos[i] = FieldViolationDTO{Field: violation.Field, Rule: violation.Rule, Message: violation.Message}
+	}
+	return dtos
+}
+
+// internalErrorDetail returns the ErrorDetail of internal server errors
+func internalErrorDetail(ctx context.Context) ErrorDetail {
+	return ErrorDetail{
+		Type:       errors.ErrorTypeInternal,
+		Code:       errors.CodeInternalError,
+		Message:    internalErrorMessage(ctx),
+		StatusCode: http.StatusInternalServerError,
+	}
+}
+
+// errorCode returns the code of err when it is an error of the type of the response, the default
+// code of the type otherwise, so that the code never contradicts the type
+func errorCode(err error, errorType string) string {
+	if errors.GetErrorType(err) == errorType {
+		return errors.GetCode(err)
+	}
+	return errors.DefaultCode(errorType)
+}
+
+// localizedMessage returns the message of err in the language carried by ctx, or its English
+// message when it has no localized message
+func localizedMessage(ctx context.Context, err error) string {
+	if key, params := errors.GetMessageKey(err); key != "" {
+		if message, ok := i18n.Translate(i18n.LanguageFromContext(ctx), key, params); ok {
+			return message
+		}
+	}
+	return err.Error()
+}
+
+// internalErrorMessage returns the message of internal server errors in the language carried by ctx
+func internalErrorMessage(ctx context.Context) string {
+	if message, ok := i18n.Translate(i18n.LanguageFromContext(ctx), errors.CodeInternalError, nil); ok {
+		return message
+	}
+	return "An internal server error occurred"
+}
@@ -0,0 +1,44 @@
+// Package dto provides Data Transfer Objects for background folder exports in the Document Management Platform API.
+// This file defines the response structures for the export endpoints.
+package dto
+
+import (
+	"../../domain/models"
+	timeutils "../../pkg/utils/time_utils"
+)
+
+// ExportJobDTO is a DTO for export job responses. DownloadURL is a presigned URL set once the
+// archive is complete.
+type ExportJobDTO struct {
+	ID                string `json:"id"`
+	FolderID          string `json:"folder_id"`
+	Status            string `json:"status"`
+	Progress          int    `json:"progress"`
+	TotalDocuments    int    `json:"total_documents"`
+	ExportedDocuments int    `json:"exported_documents"`
+	ArchiveSize       int64  `json:"archive_size,omitempty"`
+	DownloadURL       string `json:"download_url,omitempty"`
+	Error             string `json:"error,omitempty"`
+	CreatedAt         string `json:"created_at"`
+	CompletedAt       string `json:"completed_at,omitempty"`
+}
+
+// ToExportJobDTO converts a domain ExportJob model to an ExportJobDTO
+func ToExportJobDTO(job *models.ExportJob, downloadURL string) ExportJobDTO {
+	dto := ExportJobDTO{
+		ID:                job.ID,
+		FolderID:          job.FolderID,
+		Status:            job.Status,
+		Progress:          job.Progress(),
+		TotalDocuments:    job.TotalDocuments,
+		ExportedDocuments: job.ExportedDocuments,
+		ArchiveSize:       job.ArchiveSize,
+		DownloadURL:       downloadURL,
+		Error:             job.Error,
+		CreatedAt:         timeutils.FormatTime(job.CreatedAt, ""),
+	}
+	if job.CompletedAt != nil {
+		dto.CompletedAt = timeutils.FormatTime(*job.CompletedAt, "")
+	}
+	return dto
+}
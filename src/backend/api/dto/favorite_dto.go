@@ -0,0 +1,53 @@
+// Package dto provides Data Transfer Objects for the Starred and Recents views in the Document Management Platform API.
+// This file defines the response structures for the starred items and recent documents endpoints.
+package dto
+
+import (
+	"time"
+
+	"../../domain/models"
+	timeutils "../../pkg/utils/time_utils"
+)
+
+// StarredItemDTO is a DTO for a document or folder a user starred; exactly one of document and folder is set
+type StarredItemDTO struct {
+	ResourceType string       `json:"resource_type"`
+	ResourceID   string       `json:"resource_id"`
+	StarredAt    string       `json:"starred_at"`
+	Document     *DocumentDTO `json:"document,omitempty"`
+	Folder       *FolderDTO   `json:"folder,omitempty"`
+}
+
+// RecentDocumentDTO is a DTO for a document a user recently downloaded, uploaded or edited
+type RecentDocumentDTO struct {
+	Document   DocumentDTO `json:"document"`
+	Action     string      `json:"action"`
+	AccessedAt string      `json:"accessed_at"`
+}
+
+// ToStarredItemDTO converts a favorite and the document or folder it stars to a StarredItemDTO
+func ToStarredItemDTO(favorite models.Favorite, document *models.Document, folder *models.Folder) StarredItemDTO {
+	item := StarredItemDTO{
+		ResourceType: favorite.ResourceType,
+		ResourceID:   favorite.ResourceID,
+		StarredAt:    timeutils.FormatTime(favorite.CreatedAt, ""),
+	}
+	if document != nil {
+		documentDTO := DocumentToDTO(*document)
+		item.Document = &documentDTO
+	}
+	if folder != nil {
+		folderDTO := FolderToDTO(folder)
+		item.Folder = &folderDTO
+	}
+	return item
+}
+
+// ToRecentDocumentDTO converts a recently accessed document to a RecentDocumentDTO
+func ToRecentDocumentDTO(document *models.Document, action string, accessedAt time.Time) RecentDocumentDTO {
+	return RecentDocumentDTO{
+		Document:   DocumentToDTO(*document),
+		Action:     action,
+		AccessedAt: timeutils.FormatTime(accessedAt, ""),
+	}
+}
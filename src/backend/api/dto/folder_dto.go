@@ -18,6 +18,24 @@ type FolderDTO struct {
 	Path      string `json:"path"`
 	CreatedAt string `json:"createdAt"`
 	UpdatedAt string `json:"updatedAt"`
+	// Rollups of the documents in the folder and its subfolders
+	DocumentCount  int64  `json:"documentCount"`
+	TotalSize      int64  `json:"totalSize"`
+	LastModifiedAt string `json:"lastModifiedAt,omitempty"`
+
+	// WatermarkDownloads is set when PDFs downloaded from the folder are stamped with who downloaded them
+	WatermarkDownloads bool `json:"watermarkDownloads"`
+
+	// DownloadPolicy restricts how the documents directly in the folder can be downloaded
+	DownloadPolicy FolderDownloadPolicyDTO `json:"downloadPolicy"`
+}
+
+// FolderDownloadPolicyDTO represents the download policy of a folder in requests and responses. The
+// zero value lets documents be downloaded without restrictions.
+type FolderDownloadPolicyDTO struct {
+	ViewOnly                bool `json:"viewOnly"`                // Documents can be previewed but not downloaded
+	DailyLimit              int  `json:"dailyLimit"`              // Downloads per user and day, unlimited when 0
+	ReauthenticationMinutes int  `json:"reauthenticationMinutes"` // Maximum minutes since signing in to download, not required when 0
 }
 
 // FolderCreateRequest represents the payload for folder creation
@@ -36,6 +54,11 @@ type FolderMoveRequest struct {
 	NewParentID string `json:"newParentId" binding:"required"`
 }
 
+// FolderWatermarkRequest represents the payload for turning download watermarking of a folder on or off
+type FolderWatermarkRequest struct {
+	Enabled *bool `json:"enabled" binding:"required"`
+}
+
 // FolderListRequest represents the parameters for folder listing
 type FolderListRequest struct {
 	ParentID  string `form:"parentId" json:"parentId"`
@@ -43,6 +66,7 @@ type FolderListRequest struct {
 	PageSize  int    `form:"pageSize" json:"pageSize"`
 	SortBy    string `form:"sortBy" json:"sortBy"`
 	SortOrder string `form:"sortOrder" json:"sortOrder"`
+	Cursor    string `form:"cursor" json:"cursor"` // NextCursor of the previous page; Page is ignored when set
 }
 
 // FolderSearchRequest represents the parameters for folder search
@@ -69,13 +93,39 @@ type PaginatedFolderResponse struct {
 
 // FolderToDTO converts a domain Folder model to a FolderDTO
 func FolderToDTO(folder *models.Folder) FolderDTO {
-	return FolderDTO{
-		ID:        folder.ID,
-		Name:      folder.Name,
-		ParentID:  folder.ParentID,
-		Path:      folder.Path,
-		CreatedAt: timeutils.FormatTime(folder.CreatedAt, ""),
-		UpdatedAt: timeutils.FormatTime(folder.UpdatedAt, ""),
+	folderDTO := FolderDTO{
+		ID:                 folder.ID,
+		Name:               folder.Name,
+		ParentID:           folder.ParentID,
+		Path:               folder.Path,
+		CreatedAt:          timeutils.FormatTime(folder.CreatedAt, ""),
+		UpdatedAt:          timeutils.FormatTime(folder.UpdatedAt, ""),
+		DocumentCount:      folder.DocumentCount,
+		TotalSize:          folder.TotalSize,
+		WatermarkDownloads: folder.WatermarkDownloads,
+		DownloadPolicy:     FolderDownloadPolicyToDTO(folder.DownloadPolicy()),
+	}
+	if folder.LastModifiedAt != nil {
+		folderDTO.LastModifiedAt = timeutils.FormatTime(*folder.LastModifiedAt, "")
+	}
+	return folderDTO
+}
+
+// FolderDownloadPolicyToDTO converts a domain DownloadPolicy to a FolderDownloadPolicyDTO
+func FolderDownloadPolicyToDTO(policy models.DownloadPolicy) FolderDownloadPolicyDTO {
+	return FolderDownloadPolicyDTO{
+		ViewOnly:                policy.ViewOnly,
+		DailyLimit:              policy.DailyLimit,
+		ReauthenticationMinutes: policy.ReauthenticationMinutes,
+	}
+}
+
+// ToModel converts a FolderDownloadPolicyDTO to a domain DownloadPolicy
+func (p FolderDownloadPolicyDTO) ToModel() models.DownloadPolicy {
+	return models.DownloadPolicy{
+		ViewOnly:                p.ViewOnly,
+		DailyLimit:              p.DailyLimit,
+		ReauthenticationMinutes: p.ReauthenticationMinutes,
 	}
 }
 
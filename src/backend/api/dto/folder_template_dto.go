@@ -0,0 +1,70 @@
+// Package dto provides Data Transfer Objects for folder templates in the Document Management Platform API.
+// This file defines the request and response structures for the folder template endpoints.
+package dto
+
+import (
+	"../../domain/models"
+	timeutils "../../pkg/utils/time_utils"
+)
+
+// FolderTemplateRequest is a DTO for creating or replacing a folder template. Permissions are
+// granted on the folder the template is applied as; folders are created below it, each with its
+// permissions, metadata_fields, metadata_mode and subfolders.
+type FolderTemplateRequest struct {
+	Name        string                        `json:"name"`
+	Description string                        `json:"description"`
+	Permissions []models.FolderTemplateGrant  `json:"permissions"`
+	Folders     []models.FolderTemplateFolder `json:"folders"`
+}
+
+// ApplyFolderTemplateRequest is a DTO for applying a folder template: the folder named name is
+// created in parent_id, or at the root when parent_id is omitted
+type ApplyFolderTemplateRequest struct {
+	Name     string `json:"name" binding:"required"`
+	ParentID string `json:"parent_id"`
+}
+
+// FolderTemplateDTO is a DTO for folder template data
+type FolderTemplateDTO struct {
+	ID          string                        `json:"id"`
+	Name        string                        `json:"name"`
+	Description string                        `json:"description"`
+	Permissions []models.FolderTemplateGrant  `json:"permissions"`
+	Folders     []models.FolderTemplateFolder `json:"folders"`
+	FolderCount int                           `json:"folder_count"`
+	CreatedBy   string                        `json:"created_by"`
+	CreatedAt   string                        `json:"created_at"`
+	UpdatedAt   string                        `json:"updated_at"`
+}
+
+// ToFolderTemplateDomain converts a FolderTemplateRequest to a domain FolderTemplate model
+func ToFolderTemplateDomain(request *FolderTemplateRequest, tenantID string, userID string) *models.FolderTemplate {
+	template := models.NewFolderTemplate(tenantID, request.Name, request.Folders, userID)
+	template.Description = request.Description
+	template.Permissions = request.Permissions
+	return template
+}
+
+// ToFolderTemplateDTO converts a domain FolderTemplate model to a FolderTemplateDTO
+func ToFolderTemplateDTO(template *models.FolderTemplate) FolderTemplateDTO {
+	return FolderTemplateDTO{
+		ID:          template.ID,
+		Name:        template.Name,
+		Description: template.Description,
+		Permissions: template.Permissions,
+		Folders:     template.Folders,
+		FolderCount: template.FolderCount(),
+		CreatedBy:   template.CreatedBy,
+		CreatedAt:   timeutils.FormatTime(template.CreatedAt, ""),
+		UpdatedAt:   timeutils.FormatTime(template.UpdatedAt, ""),
+	}
+}
+
+// ToFolderTemplateListDTO converts domain FolderTemplate models to FolderTemplateDTOs
+func ToFolderTemplateListDTO(templates []*models.FolderTemplate) []FolderTemplateDTO {
+	dtos := make([]FolderTemplateDTO, len(templates))
+	for i, template := range templates {
+		dtos[i] = ToFolderTemplateDTO(template)
+	}
+	return dtos
+}
@@ -0,0 +1,77 @@
+// Package dto provides Data Transfer Objects for user group management in the Document Management Platform API.
+// This file defines the request and response structures for the group endpoints.
+package dto
+
+import (
+	"../../domain/models"
+	"../../pkg/utils/pagination"
+	timeutils "../../pkg/utils/time_utils"
+)
+
+// GroupRequest is a DTO for creating or updating a group
+type GroupRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// AddGroupMemberRequest is a DTO for adding a user to a group
+type AddGroupMemberRequest struct {
+	UserID string `json:"user_id" binding:"required"`
+}
+
+// GrantGroupFolderPermissionRequest is a DTO for granting a group a permission on a folder
+type GrantGroupFolderPermissionRequest struct {
+	FolderID       string `json:"folder_id" binding:"required"`
+	PermissionType string `json:"permission_type" binding:"required"`
+}
+
+// GroupDTO is a DTO for group data
+type GroupDTO struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	CreatedBy   string `json:"created_by"`
+	CreatedAt   string `json:"created_at"`
+	UpdatedAt   string `json:"updated_at"`
+}
+
+// GroupMemberDTO is a DTO for group membership data
+type GroupMemberDTO struct {
+	UserID  string `json:"user_id"`
+	AddedBy string `json:"added_by"`
+	AddedAt string `json:"added_at"`
+}
+
+// ToGroupDTO converts a domain Group model to a GroupDTO
+func ToGroupDTO(group *models.Group) GroupDTO {
+	return GroupDTO{
+		ID:          group.ID,
+		Name:        group.Name,
+		Description: group.Description,
+		CreatedBy:   group.CreatedBy,
+		CreatedAt:   timeutils.FormatTime(group.CreatedAt, ""),
+		UpdatedAt:   timeutils.FormatTime(group.UpdatedAt, ""),
+	}
+}
+
+// ToGroupListDTO converts a paginated list of domain Group models to GroupDTOs
+func ToGroupListDTO(result pagination.PaginatedResult[models.Group]) []GroupDTO {
+	dtos := make([]GroupDTO, len(result.Items))
+	for i, group := range result.Items {
+		dtos[i] = ToGroupDTO(&group)
+	}
+	return dtos
+}
+
+// ToGroupMemberListDTO converts a paginated list of domain GroupMembership models to GroupMemberDTOs
+func ToGroupMemberListDTO(result pagination.PaginatedResult[models.GroupMembership]) []GroupMemberDTO {
+	dtos := make([]GroupMemberDTO, len(result.Items))
+	for i, membership := range result.Items {
+		dtos[i] = GroupMemberDTO{
+			UserID:  membership.UserID,
+			AddedBy: membership.AddedBy,
+			AddedAt: timeutils.FormatTime(membership.CreatedAt, ""),
+		}
+	}
+	return dtos
+}
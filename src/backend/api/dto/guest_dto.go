@@ -0,0 +1,39 @@
+// Package dto provides Data Transfer Objects for external guest access in the Document Management Platform API.
+// This file defines the request and response structures for guest invitations.
+package dto
+
+import (
+	"../../domain/models"
+	timeutils "../../pkg/utils/time_utils"
+)
+
+// InviteGuestRequest is a DTO for inviting an external guest to a document or folder
+type InviteGuestRequest struct {
+	Email          string `json:"email" binding:"required,email"`
+	ResourceType   string `json:"resource_type" binding:"required,oneof=document folder"`
+	ResourceID     string `json:"resource_id" binding:"required"`
+	ExpiresInHours int    `json:"expires_in_hours" binding:"required,min=1,max=720"`
+}
+
+// GuestInvitationDTO is a DTO for guest invitation responses. The guest token itself is only
+// sent to the guest by email.
+type GuestInvitationDTO struct {
+	Email        string   `json:"email"`
+	ResourceType string   `json:"resource_type"`
+	ResourceID   string   `json:"resource_id"`
+	Permissions  []string `json:"permissions"`
+	InvitedBy    string   `json:"invited_by"`
+	ExpiresAt    string   `json:"expires_at"`
+}
+
+// ToGuestInvitationDTO converts a domain GuestScope model to a GuestInvitationDTO
+func ToGuestInvitationDTO(scope *models.GuestScope) GuestInvitationDTO {
+	return GuestInvitationDTO{
+		Email:        scope.GuestEmail,
+		ResourceType: scope.ResourceType,
+		ResourceID:   scope.ResourceID,
+		Permissions:  scope.Permissions,
+		InvitedBy:    scope.InvitedBy,
+		ExpiresAt:    timeutils.FormatTime(scope.ExpiresAt, ""),
+	}
+}
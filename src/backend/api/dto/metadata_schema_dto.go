@@ -0,0 +1,62 @@
+// Package dto provides Data Transfer Objects for metadata schema management in the Document Management Platform API.
+// This file defines the request and response structures for the metadata field endpoints.
+package dto
+
+import (
+	"../../domain/models"
+	timeutils "../../pkg/utils/time_utils"
+)
+
+// MetadataFieldRequest is a DTO for creating or replacing a metadata field.
+// Type is one of string, number, date or enum; allowed_values lists the values of an enum field.
+type MetadataFieldRequest struct {
+	Name          string   `json:"name"`
+	Description   string   `json:"description"`
+	Type          string   `json:"type"`
+	Required      bool     `json:"required"`
+	AllowedValues []string `json:"allowed_values"`
+}
+
+// MetadataFieldDTO is a DTO for metadata field data
+type MetadataFieldDTO struct {
+	ID            string   `json:"id"`
+	Name          string   `json:"name"`
+	Description   string   `json:"description"`
+	Type          string   `json:"type"`
+	Required      bool     `json:"required"`
+	AllowedValues []string `json:"allowed_values,omitempty"`
+	CreatedBy     string   `json:"created_by"`
+	CreatedAt     string   `json:"created_at"`
+	UpdatedAt     string   `json:"updated_at"`
+}
+
+// ToMetadataFieldDomain converts a MetadataFieldRequest to a domain MetadataField model
+func ToMetadataFieldDomain(request *MetadataFieldRequest, tenantID string, userID string) *models.MetadataField {
+	field := models.NewMetadataField(tenantID, request.Name, request.Type, request.Required, request.AllowedValues, userID)
+	field.Description = request.Description
+	return field
+}
+
+// ToMetadataFieldDTO converts a domain MetadataField model to a MetadataFieldDTO
+func ToMetadataFieldDTO(field *models.MetadataField) MetadataFieldDTO {
+	return MetadataFieldDTO{
+		ID:            field.ID,
+		Name:          field.Name,
+		Description:   field.Description,
+		Type:          field.Type,
+		Required:      field.Required,
+		AllowedValues: field.AllowedValues,
+		CreatedBy:     field.CreatedBy,
+		CreatedAt:     timeutils.FormatTime(field.CreatedAt, ""),
+		UpdatedAt:     timeutils.FormatTime(field.UpdatedAt, ""),
+	}
+}
+
+// ToMetadataSchemaDTO converts a domain MetadataSchema to MetadataFieldDTOs
+func ToMetadataSchemaDTO(schema models.MetadataSchema) []MetadataFieldDTO {
+	dtos := make([]MetadataFieldDTO, len(schema))
+	for i, field := range schema {
+		dtos[i] = ToMetadataFieldDTO(field)
+	}
+	return dtos
+}
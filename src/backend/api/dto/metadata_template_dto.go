@@ -0,0 +1,65 @@
+// Package dto provides Data Transfer Objects for metadata template management in the Document Management Platform API.
+// This file defines the request and response structures for the folder metadata template endpoints.
+package dto
+
+import (
+	"../../domain/models"
+	timeutils "../../pkg/utils/time_utils"
+)
+
+// MetadataTemplateRequest is a DTO for creating or replacing a folder's metadata template.
+// Mode is enforce or prompt and defaults to enforce when omitted; folder_id is ignored on update.
+type MetadataTemplateRequest struct {
+	FolderID    string   `json:"folder_id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Fields      []string `json:"fields"`
+	Mode        string   `json:"mode"`
+}
+
+// MetadataTemplateDTO is a DTO for metadata template data
+type MetadataTemplateDTO struct {
+	ID          string   `json:"id"`
+	FolderID    string   `json:"folder_id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Fields      []string `json:"fields"`
+	Mode        string   `json:"mode"`
+	CreatedBy   string   `json:"created_by"`
+	CreatedAt   string   `json:"created_at"`
+	UpdatedAt   string   `json:"updated_at"`
+}
+
+// ToMetadataTemplateDomain converts a MetadataTemplateRequest to a domain MetadataTemplate model
+func ToMetadataTemplateDomain(request *MetadataTemplateRequest, tenantID string, userID string) *models.MetadataTemplate {
+	template := models.NewMetadataTemplate(tenantID, request.FolderID, request.Name, request.Fields, userID)
+	template.Description = request.Description
+	if request.Mode != "" {
+		template.Mode = request.Mode
+	}
+	return template
+}
+
+// ToMetadataTemplateDTO converts a domain MetadataTemplate model to a MetadataTemplateDTO
+func ToMetadataTemplateDTO(template *models.MetadataTemplate) MetadataTemplateDTO {
+	return MetadataTemplateDTO{
+		ID:          template.ID,
+		FolderID:    template.FolderID,
+		Name:        template.Name,
+		Description: template.Description,
+		Fields:      template.Fields,
+		Mode:        template.Mode,
+		CreatedBy:   template.CreatedBy,
+		CreatedAt:   timeutils.FormatTime(template.CreatedAt, ""),
+		UpdatedAt:   timeutils.FormatTime(template.UpdatedAt, ""),
+	}
+}
+
+// ToMetadataTemplateListDTO converts domain MetadataTemplate models to MetadataTemplateDTOs
+func ToMetadataTemplateListDTO(templates []*models.MetadataTemplate) []MetadataTemplateDTO {
+	dtos := make([]MetadataTemplateDTO, len(templates))
+	for i, template := range templates {
+		dtos[i] = ToMetadataTemplateDTO(template)
+	}
+	return dtos
+}
@@ -0,0 +1,74 @@
+// Package dto provides Data Transfer Objects for numbering sequence management in the Document Management Platform API.
+// This file defines the request and response structures for the document numbering sequence endpoints.
+package dto
+
+import (
+	"../../domain/models"
+	timeutils "../../pkg/utils/time_utils"
+)
+
+// NumberingSequenceRequest is a DTO for creating or replacing a numbering sequence.
+// An empty folder_id creates the tenant's default sequence and is ignored on update. Padding
+// defaults to 5 digits and mode to gap-free when omitted.
+type NumberingSequenceRequest struct {
+	FolderID    string `json:"folder_id"`
+	Name        string `json:"name"`
+	Format      string `json:"format"`
+	Padding     int    `json:"padding"`
+	Mode        string `json:"mode"`
+	ResetYearly bool   `json:"reset_yearly"`
+}
+
+// NumberingSequenceDTO is a DTO for numbering sequence data
+type NumberingSequenceDTO struct {
+	ID          string `json:"id"`
+	FolderID    string `json:"folder_id"`
+	Name        string `json:"name"`
+	Format      string `json:"format"`
+	Padding     int    `json:"padding"`
+	Mode        string `json:"mode"`
+	ResetYearly bool   `json:"reset_yearly"`
+	LastValue   int64  `json:"last_value"`
+	CreatedBy   string `json:"created_by"`
+	CreatedAt   string `json:"created_at"`
+	UpdatedAt   string `json:"updated_at"`
+}
+
+// ToNumberingSequenceDomain converts a NumberingSequenceRequest to a domain NumberingSequence model
+func ToNumberingSequenceDomain(request *NumberingSequenceRequest, tenantID string, userID string) *models.NumberingSequence {
+	sequence := models.NewNumberingSequence(tenantID, request.FolderID, request.Name, request.Format, userID)
+	sequence.ResetYearly = request.ResetYearly
+	if request.Padding != 0 {
+		sequence.Padding = request.Padding
+	}
+	if request.Mode != "" {
+		sequence.Mode = request.Mode
+	}
+	return sequence
+}
+
+// ToNumberingSequenceDTO converts a domain NumberingSequence model to a NumberingSequenceDTO
+func ToNumberingSequenceDTO(sequence *models.NumberingSequence) NumberingSequenceDTO {
+	return NumberingSequenceDTO{
+		ID:          sequence.ID,
+		FolderID:    sequence.FolderID,
+		Name:        sequence.Name,
+		Format:      sequence.Format,
+		Padding:     sequence.Padding,
+		Mode:        sequence.Mode,
+		ResetYearly: sequence.ResetYearly,
+		LastValue:   sequence.LastValue,
+		CreatedBy:   sequence.CreatedBy,
+		CreatedAt:   timeutils.FormatTime(sequence.CreatedAt, ""),
+		UpdatedAt:   timeutils.FormatTime(sequence.UpdatedAt, ""),
+	}
+}
+
+// ToNumberingSequenceListDTO converts domain NumberingSequence models to NumberingSequenceDTOs
+func ToNumberingSequenceListDTO(sequences []*models.NumberingSequence) []NumberingSequenceDTO {
+	dtos := make([]NumberingSequenceDTO, len(sequences))
+	for i, sequence := range sequences {
+		dtos[i] = ToNumberingSequenceDTO(sequence)
+	}
+	return dtos
+}
@@ -0,0 +1,105 @@
+// Package dto provides Data Transfer Objects for the platform operator API of the Document Management Platform.
+// This file defines the request and response structures for provisioning tenants and re-driving
+// the scan dead letter queue.
+package dto
+
+import (
+	"../../domain/models"
+	"../../domain/services"
+	timeutils "../../pkg/utils/time_utils"
+)
+
+// ProvisionTenantRequest is a DTO for provisioning a tenant with its initial administrator
+type ProvisionTenantRequest struct {
+	Name             string `json:"name" binding:"required"`
+	AdminUsername    string `json:"admin_username" binding:"required"`
+	AdminEmail       string `json:"admin_email" binding:"required,email"`
+	FolderTemplateID string `json:"folder_template_id"`
+	RequestedBy      string `json:"requested_by" binding:"required"`
+}
+
+// ProvisionedTenantDTO is a DTO for the response to provisioning a tenant. The temporary password
+// of the administrator is only returned when it is not emailed. The search index is omitted when
+// the search backend does not keep tenants in indices.
+type ProvisionedTenantDTO struct {
+	ID            string         `json:"id"`
+	Name          string         `json:"name"`
+	Status        string         `json:"status"`
+	Admin         InvitedUserDTO `json:"admin"`
+	Roles         []string       `json:"roles"`
+	Folders       []FolderDTO    `json:"folders"`
+	StoragePrefix string         `json:"storage_prefix"`
+	SearchIndex   string         `json:"search_index,omitempty"`
+	SearchRouting string         `json:"search_routing,omitempty"`
+	CreatedAt     string         `json:"created_at"`
+}
+
+// ToProvisionedTenantDTO converts a provisioned tenant with its roles, administrator and folders to a
+// ProvisionedTenantDTO
+func ToProvisionedTenantDTO(tenant *models.Tenant, roles []*models.Role, admin *models.User, adminPassword string, folders []*models.Folder) ProvisionedTenantDTO {
+	roleNames := make([]string, len(roles))
+	for i, role := range roles {
+		roleNames[i] = role.Name
+	}
+	folderDTOs := make([]FolderDTO, len(folders))
+	for i, folder := range folders {
+		folderDTOs[i] = FolderToDTO(folder)
+	}
+
+	return ProvisionedTenantDTO{
+		ID:     tenant.ID,
+		Name:   tenant.Name,
+		Status: tenant.Status,
+		Admin: InvitedUserDTO{
+			User:              ToUserDTO(admin),
+			TemporaryPassword: adminPassword,
+		},
+		Roles:         roleNames,
+		Folders:       folderDTOs,
+		StoragePrefix: tenant.StoragePrefix(),
+		CreatedAt:     timeutils.FormatTime(tenant.CreatedAt, ""),
+	}
+}
+
+// ScanDeadLetterDTO is a DTO for a scan task in the dead letter queue. Unreadable messages are not
+// scan tasks and cannot be replayed.
+type ScanDeadLetterDTO struct {
+	MessageID      string `json:"message_id"`
+	TenantID       string `json:"tenant_id"`
+	DocumentID     string `json:"document_id"`
+	VersionID      string `json:"version_id"`
+	Priority       string `json:"priority"`
+	Reason         string `json:"reason"`
+	DeadLetteredAt string `json:"dead_lettered_at,omitempty"`
+	Readable       bool   `json:"readable"`
+}
+
+// ReplayScanDeadLettersRequest is a DTO for replaying dead-lettered scan tasks, selected either by
+// message ID, by tenant or all of them
+type ReplayScanDeadLettersRequest struct {
+	MessageIDs []string `json:"message_ids"`
+	TenantID   string   `json:"tenant_id"`
+	All        bool     `json:"all"`
+}
+
+// ReplayedScanDeadLettersDTO is a DTO for the response to replaying dead-lettered scan tasks
+type ReplayedScanDeadLettersDTO struct {
+	Replayed int `json:"replayed"`
+}
+
+// ToScanDeadLetterDTO converts a dead-lettered scan task to a ScanDeadLetterDTO
+func ToScanDeadLetterDTO(deadLetter services.DeadLetteredScanTask) ScanDeadLetterDTO {
+	result := ScanDeadLetterDTO{
+		MessageID:  deadLetter.MessageID,
+		TenantID:   deadLetter.Task.TenantID,
+		DocumentID: deadLetter.Task.DocumentID,
+		VersionID:  deadLetter.Task.VersionID,
+		Priority:   deadLetter.Task.Priority,
+		Reason:     deadLetter.Reason,
+		Readable:   deadLetter.Readable,
+	}
+	if !deadLetter.DeadLetteredAt.IsZero() {
+		result.DeadLetteredAt = timeutils.FormatTime(deadLetter.DeadLetteredAt, "")
+	}
+	return result
+}
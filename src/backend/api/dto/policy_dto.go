@@ -0,0 +1,76 @@
+// Package dto provides Data Transfer Objects for access policy management in the Document Management Platform API.
+// This file defines the request and response structures for the attribute-based access policy endpoints.
+package dto
+
+import (
+	"../../domain/models"
+	"../../pkg/utils/pagination"
+	timeutils "../../pkg/utils/time_utils"
+)
+
+// AccessPolicyRequest is a DTO for creating or replacing an access policy.
+// Enabled defaults to true when omitted.
+type AccessPolicyRequest struct {
+	Name          string   `json:"name"`
+	Description   string   `json:"description"`
+	Attribute     string   `json:"attribute"`
+	Operator      string   `json:"operator"`
+	Values        []string `json:"values"`
+	Actions       []string `json:"actions"`
+	RequiredRoles []string `json:"required_roles"`
+	Enabled       *bool    `json:"enabled"`
+}
+
+// AccessPolicyDTO is a DTO for access policy data
+type AccessPolicyDTO struct {
+	ID            string   `json:"id"`
+	Name          string   `json:"name"`
+	Description   string   `json:"description"`
+	Attribute     string   `json:"attribute"`
+	Operator      string   `json:"operator"`
+	Values        []string `json:"values"`
+	Actions       []string `json:"actions"`
+	RequiredRoles []string `json:"required_roles"`
+	Enabled       bool     `json:"enabled"`
+	CreatedBy     string   `json:"created_by"`
+	CreatedAt     string   `json:"created_at"`
+	UpdatedAt     string   `json:"updated_at"`
+}
+
+// ToAccessPolicyDomain converts an AccessPolicyRequest to a domain AccessPolicy model
+func ToAccessPolicyDomain(request *AccessPolicyRequest, tenantID string, userID string) *models.AccessPolicy {
+	policy := models.NewAccessPolicy(tenantID, request.Name, request.Attribute, request.Operator,
+		request.Values, request.Actions, request.RequiredRoles, userID)
+	policy.Description = request.Description
+	if request.Enabled != nil {
+		policy.Enabled = *request.Enabled
+	}
+	return policy
+}
+
+// ToAccessPolicyDTO converts a domain AccessPolicy model to an AccessPolicyDTO
+func ToAccessPolicyDTO(policy *models.AccessPolicy) AccessPolicyDTO {
+	return AccessPolicyDTO{
+		ID:            policy.ID,
+		Name:          policy.Name,
+		Description:   policy.Description,
+		Attribute:     policy.Attribute,
+		Operator:      policy.Operator,
+		Values:        policy.MatchValues,
+		Actions:       policy.Actions,
+		RequiredRoles: policy.RequiredRoles,
+		Enabled:       policy.Enabled,
+		CreatedBy:     policy.CreatedBy,
+		CreatedAt:     timeutils.FormatTime(policy.CreatedAt, ""),
+		UpdatedAt:     timeutils.FormatTime(policy.UpdatedAt, ""),
+	}
+}
+
+// ToAccessPolicyListDTO converts a paginated list of domain AccessPolicy models to AccessPolicyDTOs
+func ToAccessPolicyListDTO(result pagination.PaginatedResult[models.AccessPolicy]) []AccessPolicyDTO {
+	dtos := make([]AccessPolicyDTO, len(result.Items))
+	for i, policy := range result.Items {
+		dtos[i] = ToAccessPolicyDTO(&policy)
+	}
+	return dtos
+}
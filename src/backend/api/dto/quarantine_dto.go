@@ -0,0 +1,64 @@
+// Package dto provides Data Transfer Objects for quarantine management in the Document Management Platform API.
+// This file defines the request and response structures for the quarantine endpoints.
+package dto
+
+import (
+	"../../domain/models"
+	"../../pkg/utils/pagination"
+	timeutils "../../pkg/utils/time_utils"
+)
+
+// ReleaseQuarantinedItemRequest is a DTO for releasing a quarantined document.
+// Mode is rescan or override; a reason is required to override the scan verdict.
+type ReleaseQuarantinedItemRequest struct {
+	Mode   string `json:"mode"`
+	Reason string `json:"reason"`
+}
+
+// DestroyQuarantinedItemRequest is a DTO for permanently destroying a quarantined document
+type DestroyQuarantinedItemRequest struct {
+	Reason string `json:"reason"`
+}
+
+// QuarantinedItemDTO is a DTO for quarantined item responses. ScanDetails is the verdict of the
+// scanning engines, such as the name of the virus found.
+type QuarantinedItemDTO struct {
+	ID            string `json:"id"`
+	DocumentID    string `json:"document_id"`
+	VersionID     string `json:"version_id"`
+	ScanDetails   string `json:"scan_details"`
+	Status        string `json:"status"`
+	Resolution    string `json:"resolution,omitempty"`
+	Reason        string `json:"reason,omitempty"`
+	ResolvedBy    string `json:"resolved_by,omitempty"`
+	QuarantinedAt string `json:"quarantined_at"`
+	ResolvedAt    string `json:"resolved_at,omitempty"`
+}
+
+// ToQuarantinedItemDTO converts a domain QuarantinedItem model to a QuarantinedItemDTO
+func ToQuarantinedItemDTO(item *models.QuarantinedItem) QuarantinedItemDTO {
+	dto := QuarantinedItemDTO{
+		ID:            item.ID,
+		DocumentID:    item.DocumentID,
+		VersionID:     item.VersionID,
+		ScanDetails:   item.ScanDetails,
+		Status:        item.Status,
+		Resolution:    item.Resolution,
+		Reason:        item.Reason,
+		ResolvedBy:    item.ResolvedBy,
+		QuarantinedAt: timeutils.FormatTime(item.QuarantinedAt, ""),
+	}
+	if item.ResolvedAt != nil {
+		dto.ResolvedAt = timeutils.FormatTime(*item.ResolvedAt, "")
+	}
+	return dto
+}
+
+// ToQuarantinedItemListDTO converts a paginated result of quarantined items to a list of QuarantinedItemDTOs
+func ToQuarantinedItemListDTO(result pagination.PaginatedResult[models.QuarantinedItem]) []QuarantinedItemDTO {
+	dtos := make([]QuarantinedItemDTO, len(result.Items))
+	for i, item := range result.Items {
+		dtos[i] = ToQuarantinedItemDTO(&item)
+	}
+	return dtos
+}
@@ -0,0 +1,37 @@
+// Package dto provides Data Transfer Objects for search index rebuilds in the Document Management Platform API.
+// This file defines the response structures for the reindex endpoints.
+package dto
+
+import (
+	"../../domain/models"
+	timeutils "../../pkg/utils/time_utils"
+)
+
+// ReindexJobDTO is a DTO for reindex job responses
+type ReindexJobDTO struct {
+	ID               string `json:"id"`
+	Status           string `json:"status"`
+	Progress         int    `json:"progress"`
+	TotalDocuments   int    `json:"total_documents"`
+	IndexedDocuments int    `json:"indexed_documents"`
+	Error            string `json:"error,omitempty"`
+	CreatedAt        string `json:"created_at"`
+	CompletedAt      string `json:"completed_at,omitempty"`
+}
+
+// ToReindexJobDTO converts a domain ReindexJob model to a ReindexJobDTO
+func ToReindexJobDTO(job *models.ReindexJob) ReindexJobDTO {
+	dto := ReindexJobDTO{
+		ID:               job.ID,
+		Status:           job.Status,
+		Progress:         job.Progress(),
+		TotalDocuments:   job.TotalDocuments,
+		IndexedDocuments: job.IndexedDocuments,
+		Error:            job.Error,
+		CreatedAt:        timeutils.FormatTime(job.CreatedAt, ""),
+	}
+	if job.CompletedAt != nil {
+		dto.CompletedAt = timeutils.FormatTime(*job.CompletedAt, "")
+	}
+	return dto
+}
@@ -0,0 +1,61 @@
+// Package dto provides Data Transfer Objects for role management in the Document Management Platform API.
+// This file defines the request and response structures for the tenant role endpoints.
+package dto
+
+import (
+	"../../domain/models"
+	"../../pkg/utils/pagination"
+	timeutils "../../pkg/utils/time_utils"
+)
+
+// CreateRoleRequest is a DTO for creating a custom role
+type CreateRoleRequest struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+// UpdateRoleRequest is a DTO for updating a custom role.
+// The permissions replace the role's current permissions.
+type UpdateRoleRequest struct {
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+// RoleDTO is a DTO for role data
+type RoleDTO struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+	System      bool     `json:"system"`
+	CreatedAt   string   `json:"created_at"`
+	UpdatedAt   string   `json:"updated_at"`
+}
+
+// ToRoleDTO converts a domain Role model to a RoleDTO
+func ToRoleDTO(role *models.Role) RoleDTO {
+	permissions := role.Permissions
+	if permissions == nil {
+		permissions = []string{}
+	}
+
+	return RoleDTO{
+		ID:          role.ID,
+		Name:        role.Name,
+		Description: role.Description,
+		Permissions: permissions,
+		System:      role.IsSystemRole(),
+		CreatedAt:   timeutils.FormatTime(role.CreatedAt, ""),
+		UpdatedAt:   timeutils.FormatTime(role.UpdatedAt, ""),
+	}
+}
+
+// ToRoleListDTO converts a paginated list of domain Role models to RoleDTOs
+func ToRoleListDTO(result pagination.PaginatedResult[models.Role]) []RoleDTO {
+	dtos := make([]RoleDTO, len(result.Items))
+	for i, role := range result.Items {
+		dtos[i] = ToRoleDTO(&role)
+	}
+	return dtos
+}
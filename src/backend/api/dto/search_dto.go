@@ -6,6 +6,7 @@
 package dto
 
 import (
+	"fmt"  // standard library
 	"time" // standard library
 
 	"../../domain/models"
@@ -27,6 +28,31 @@ const (
 	SortOrderDesc   = "desc"
 )
 
+// SearchSortFields are the fields search results can be sorted by
+var SearchSortFields = []string{SortByRelevance, SortByName, SortByCreatedAt, SortByUpdatedAt, SortBySize}
+
+// ValidateSort validates sort parameters: sortBy is a comma-separated list of fields, each
+// optionally followed by :asc or :desc, and sortOrder the direction of the fields without one.
+func ValidateSort(sortBy string, sortOrder string, fields []string) error {
+	keys, err := pagination.ParseSort(sortBy, sortOrder)
+	if err != nil {
+		return errors.NewValidationError(err.Error())
+	}
+	for _, key := range keys {
+		valid := false
+		for _, field := range fields {
+			if key.Field == field {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return errors.NewValidationError(fmt.Sprintf("cannot sort by %s", key.Field))
+		}
+	}
+	return nil
+}
+
 // ContentSearchRequest represents a request for content-based document search
 type ContentSearchRequest struct {
 	Query     string `json:"query"`
@@ -34,6 +60,7 @@ type ContentSearchRequest struct {
 	PageSize  int    `json:"page_size"`
 	SortBy    string `json:"sort_by,omitempty"`
 	SortOrder string `json:"sort_order,omitempty"`
+	Cursor    string `json:"cursor,omitempty"` // NextCursor of the previous page; Page is ignored when set
 }
 
 // Validate validates the content search request
@@ -50,32 +77,29 @@ func (r *ContentSearchRequest) Validate() error {
 		return errors.NewValidationError("page size must be between 1 and 100")
 	}
 	
-	if r.SortBy != "" && r.SortBy != SortByRelevance && 
-	   r.SortBy != SortByName && r.SortBy != SortByCreatedAt && 
-	   r.SortBy != SortByUpdatedAt && r.SortBy != SortBySize {
-		return errors.NewValidationError("invalid sort_by parameter")
-	}
-	
-	if r.SortOrder != "" && r.SortOrder != SortOrderAsc && r.SortOrder != SortOrderDesc {
-		return errors.NewValidationError("sort_order must be 'asc' or 'desc'")
+	if err := ValidateSort(r.SortBy, r.SortOrder, SearchSortFields); err != nil {
+		return err
 	}
 	
 	return nil
 }
 
-// MetadataSearchRequest represents a request for metadata-based document search
+// MetadataSearchRequest represents a request for metadata-based document search.
+// Documents must match every metadata value and every filter.
 type MetadataSearchRequest struct {
-	Metadata  map[string]string `json:"metadata"`
-	Page      int               `json:"page"`
-	PageSize  int               `json:"page_size"`
-	SortBy    string            `json:"sort_by,omitempty"`
-	SortOrder string            `json:"sort_order,omitempty"`
+	Metadata  map[string]string       `json:"metadata"`
+	Filters   []models.MetadataFilter `json:"filters,omitempty"`
+	Page      int                     `json:"page"`
+	PageSize  int                     `json:"page_size"`
+	SortBy    string                  `json:"sort_by,omitempty"`
+	SortOrder string                  `json:"sort_order,omitempty"`
+	Cursor    string                  `json:"cursor,omitempty"` // NextCursor of the previous page; Page is ignored when set
 }
 
 // Validate validates the metadata search request
 func (r *MetadataSearchRequest) Validate() error {
-	if r.Metadata == nil || len(r.Metadata) == 0 {
-		return errors.NewValidationError("at least one metadata field is required")
+	if len(r.Metadata) == 0 && len(r.Filters) == 0 {
+		return errors.NewValidationError("at least one metadata field or filter is required")
 	}
 	
 	if r.Page < 1 {
@@ -86,14 +110,8 @@ func (r *MetadataSearchRequest) Validate() error {
 		return errors.NewValidationError("page size must be between 1 and 100")
 	}
 	
-	if r.SortBy != "" && r.SortBy != SortByRelevance && 
-	   r.SortBy != SortByName && r.SortBy != SortByCreatedAt && 
-	   r.SortBy != SortByUpdatedAt && r.SortBy != SortBySize {
-		return errors.NewValidationError("invalid sort_by parameter")
-	}
-	
-	if r.SortOrder != "" && r.SortOrder != SortOrderAsc && r.SortOrder != SortOrderDesc {
-		return errors.NewValidationError("sort_order must be 'asc' or 'desc'")
+	if err := ValidateSort(r.SortBy, r.SortOrder, SearchSortFields); err != nil {
+		return err
 	}
 	
 	return nil
@@ -101,18 +119,20 @@ func (r *MetadataSearchRequest) Validate() error {
 
 // CombinedSearchRequest represents a request for combined content and metadata search
 type CombinedSearchRequest struct {
-	Query     string            `json:"query,omitempty"`
-	Metadata  map[string]string `json:"metadata,omitempty"`
-	Page      int               `json:"page"`
-	PageSize  int               `json:"page_size"`
-	SortBy    string            `json:"sort_by,omitempty"`
-	SortOrder string            `json:"sort_order,omitempty"`
+	Query     string                  `json:"query,omitempty"`
+	Metadata  map[string]string       `json:"metadata,omitempty"`
+	Filters   []models.MetadataFilter `json:"filters,omitempty"`
+	Page      int                     `json:"page"`
+	PageSize  int                     `json:"page_size"`
+	SortBy    string                  `json:"sort_by,omitempty"`
+	SortOrder string                  `json:"sort_order,omitempty"`
+	Cursor    string                  `json:"cursor,omitempty"` // NextCursor of the previous page; Page is ignored when set
 }
 
 // Validate validates the combined search request
 func (r *CombinedSearchRequest) Validate() error {
-	if r.Query == "" && (r.Metadata == nil || len(r.Metadata) == 0) {
-		return errors.NewValidationError("either query or metadata must be provided")
+	if r.Query == "" && len(r.Metadata) == 0 && len(r.Filters) == 0 {
+		return errors.NewValidationError("either query, metadata or filters must be provided")
 	}
 	
 	if r.Page < 1 {
@@ -123,14 +143,8 @@ func (r *CombinedSearchRequest) Validate() error {
 		return errors.NewValidationError("page size must be between 1 and 100")
 	}
 	
-	if r.SortBy != "" && r.SortBy != SortByRelevance && 
-	   r.SortBy != SortByName && r.SortBy != SortByCreatedAt && 
-	   r.SortBy != SortByUpdatedAt && r.SortBy != SortBySize {
-		return errors.NewValidationError("invalid sort_by parameter")
-	}
-	
-	if r.SortOrder != "" && r.SortOrder != SortOrderAsc && r.SortOrder != SortOrderDesc {
-		return errors.NewValidationError("sort_order must be 'asc' or 'desc'")
+	if err := ValidateSort(r.SortBy, r.SortOrder, SearchSortFields); err != nil {
+		return err
 	}
 	
 	return nil
@@ -144,6 +158,7 @@ type FolderSearchRequest struct {
 	PageSize  int    `json:"page_size"`
 	SortBy    string `json:"sort_by,omitempty"`
 	SortOrder string `json:"sort_order,omitempty"`
+	Cursor    string `json:"cursor,omitempty"` // NextCursor of the previous page; Page is ignored when set
 }
 
 // Validate validates the folder search request
@@ -164,14 +179,8 @@ func (r *FolderSearchRequest) Validate() error {
 		return errors.NewValidationError("page size must be between 1 and 100")
 	}
 	
-	if r.SortBy != "" && r.SortBy != SortByRelevance && 
-	   r.SortBy != SortByName && r.SortBy != SortByCreatedAt && 
-	   r.SortBy != SortByUpdatedAt && r.SortBy != SortBySize {
-		return errors.NewValidationError("invalid sort_by parameter")
-	}
-	
-	if r.SortOrder != "" && r.SortOrder != SortOrderAsc && r.SortOrder != SortOrderDesc {
-		return errors.NewValidationError("sort_order must be 'asc' or 'desc'")
+	if err := ValidateSort(r.SortBy, r.SortOrder, SearchSortFields); err != nil {
+		return err
 	}
 	
 	return nil
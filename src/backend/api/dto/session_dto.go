@@ -0,0 +1,46 @@
+// Package dto provides Data Transfer Objects for sessions in the Document Management Platform API.
+// This file defines the response structures for the session endpoints.
+package dto
+
+import (
+	"../../domain/models"
+	timeutils "../../pkg/utils/time_utils"
+)
+
+// SessionDTO is a DTO for session responses
+type SessionDTO struct {
+	ID         string `json:"id"`
+	UserID     string `json:"user_id"`
+	IPAddress  string `json:"ip_address"`
+	UserAgent  string `json:"user_agent"`
+	CreatedAt  string `json:"created_at"`
+	LastUsedAt string `json:"last_used_at"`
+	ExpiresAt  string `json:"expires_at"`
+}
+
+// RevokedSessionsDTO is a DTO for the response to revoking all sessions of a user
+type RevokedSessionsDTO struct {
+	Revoked int `json:"revoked"`
+}
+
+// ToSessionDTO converts a domain Session model to a SessionDTO
+func ToSessionDTO(session *models.Session) SessionDTO {
+	return SessionDTO{
+		ID:         session.ID,
+		UserID:     session.UserID,
+		IPAddress:  session.IPAddress,
+		UserAgent:  session.UserAgent,
+		CreatedAt:  timeutils.FormatTime(session.CreatedAt, ""),
+		LastUsedAt: timeutils.FormatTime(session.LastUsedAt, ""),
+		ExpiresAt:  timeutils.FormatTime(session.ExpiresAt, ""),
+	}
+}
+
+// ToSessionListDTO converts a list of domain Session models to SessionDTOs
+func ToSessionListDTO(sessions []models.Session) []SessionDTO {
+	dtos := make([]SessionDTO, len(sessions))
+	for i := range sessions {
+		dtos[i] = ToSessionDTO(&sessions[i])
+	}
+	return dtos
+}
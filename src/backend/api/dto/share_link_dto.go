@@ -0,0 +1,92 @@
+// Package dto provides Data Transfer Objects for document share links in the Document Management Platform API.
+// This file defines the request and response structures for the share link endpoints.
+package dto
+
+import (
+	"time"
+
+	"../../domain/models"
+	timeutils "../../pkg/utils/time_utils"
+)
+
+// CreateShareLinkRequest is a DTO for creating a public link to a document. Links without an expiry
+// get the share link expiry of the tenant.
+type CreateShareLinkRequest struct {
+	ExpiresInHours int    `json:"expires_in_hours" binding:"min=0,max=2160"`
+	MaxDownloads   int    `json:"max_downloads" binding:"min=0"`
+	Password       string `json:"password"`
+	Watermark      bool   `json:"watermark"`
+}
+
+// ShareLinkDTO is a DTO for share link responses. URL is only set when the link is created,
+// because the token is not stored and cannot be shown again.
+type ShareLinkDTO struct {
+	ID                string `json:"id"`
+	DocumentID        string `json:"document_id"`
+	URL               string `json:"url,omitempty"`
+	ExpiresAt         string `json:"expires_at"`
+	MaxDownloads      int    `json:"max_downloads"`
+	DownloadCount     int    `json:"download_count"`
+	PasswordProtected bool   `json:"password_protected"`
+	Watermark         bool   `json:"watermark"`
+	Active            bool   `json:"active"`
+	CreatedBy         string `json:"created_by"`
+	CreatedAt         string `json:"created_at"`
+	RevokedAt         string `json:"revoked_at,omitempty"`
+}
+
+// ToShareLinkDTO converts a domain ShareLink model to a ShareLinkDTO
+func ToShareLinkDTO(link *models.ShareLink) ShareLinkDTO {
+	dto := ShareLinkDTO{
+		ID:                link.ID,
+		DocumentID:        link.DocumentID,
+		ExpiresAt:         timeutils.FormatTime(link.ExpiresAt, ""),
+		MaxDownloads:      link.MaxDownloads,
+		DownloadCount:     link.DownloadCount,
+		PasswordProtected: link.HasPassword(),
+		Watermark:         link.Watermark,
+		Active:            link.IsUsable(time.Now()),
+		CreatedBy:         link.CreatedBy,
+		CreatedAt:         timeutils.FormatTime(link.CreatedAt, ""),
+	}
+	if link.RevokedAt != nil {
+		dto.RevokedAt = timeutils.FormatTime(*link.RevokedAt, "")
+	}
+	return dto
+}
+
+// ToShareLinkListDTO converts a list of domain ShareLink models to ShareLinkDTOs
+func ToShareLinkListDTO(links []*models.ShareLink) []ShareLinkDTO {
+	dtos := make([]ShareLinkDTO, len(links))
+	for i, link := range links {
+		dtos[i] = ToShareLinkDTO(link)
+	}
+	return dtos
+}
+
+// ShareLinkPageDTO is a DTO for what the page of a share link shows. The document is only described
+// once the password of a protected link is given.
+type ShareLinkPageDTO struct {
+	TenantName       string `json:"tenant_name"`
+	LogoURL          string `json:"logo_url,omitempty"`
+	Language         string `json:"language"`
+	PasswordRequired bool   `json:"password_required"`
+	FileName         string `json:"file_name,omitempty"`
+	ContentType      string `json:"content_type,omitempty"`
+	Size             int64  `json:"size,omitempty"`
+	ExpiresAt        string `json:"expires_at"`
+}
+
+// ToShareLinkPageDTO converts the branding of a tenant and the document of a share link to a ShareLinkPageDTO
+func ToShareLinkPageDTO(branding models.TenantPreferences, passwordRequired bool, fileName, contentType string, size int64, expiresAt time.Time) ShareLinkPageDTO {
+	return ShareLinkPageDTO{
+		TenantName:       branding.TenantName,
+		LogoURL:          branding.LogoURL,
+		Language:         branding.Language,
+		PasswordRequired: passwordRequired,
+		FileName:         fileName,
+		ContentType:      contentType,
+		Size:             size,
+		ExpiresAt:        timeutils.FormatTime(expiresAt, ""),
+	}
+}
@@ -0,0 +1,118 @@
+// Package dto provides Data Transfer Objects for electronic signatures in the Document Management Platform API.
+// This file defines the request and response structures for the signature request endpoints.
+package dto
+
+import (
+	"../../domain/models"
+	timeutils "../../pkg/utils/time_utils"
+)
+
+// SignerRequest is a DTO for a signer of a signature request. Signers with the same routing order
+// sign in parallel, lower orders sign first; the order defaults to 1 when omitted.
+type SignerRequest struct {
+	Name         string `json:"name"`
+	Email        string `json:"email"`
+	RoutingOrder int    `json:"routing_order"`
+}
+
+// SignatureRequestRequest is a DTO for sending the latest version of a document for signature
+// through one of the configured providers
+type SignatureRequestRequest struct {
+	Provider string          `json:"provider"`
+	Subject  string          `json:"subject"`
+	Message  string          `json:"message"`
+	Signers  []SignerRequest `json:"signers"`
+}
+
+// CancelSignatureRequest is a DTO for cancelling a signature request with an optional reason shown to the signers
+type CancelSignatureRequest struct {
+	Reason string `json:"reason"`
+}
+
+// SignerDTO is a DTO for a signer of a signature request and the signing details reported by the provider
+type SignerDTO struct {
+	Name         string `json:"name"`
+	Email        string `json:"email"`
+	RoutingOrder int    `json:"routing_order"`
+	Status       string `json:"status"`
+	SignedAt     string `json:"signed_at,omitempty"`
+	IPAddress    string `json:"ip_address,omitempty"`
+}
+
+// SignatureRequestDTO is a DTO for signature request responses; signed_version_id is the version
+// holding the signed document once the request completed
+type SignatureRequestDTO struct {
+	ID              string      `json:"id"`
+	DocumentID      string      `json:"document_id"`
+	VersionID       string      `json:"version_id"`
+	Provider        string      `json:"provider"`
+	EnvelopeID      string      `json:"envelope_id"`
+	Status          string      `json:"status"`
+	Subject         string      `json:"subject"`
+	Message         string      `json:"message,omitempty"`
+	RequestedBy     string      `json:"requested_by"`
+	Signers         []SignerDTO `json:"signers"`
+	SignedVersionID string      `json:"signed_version_id,omitempty"`
+	StatusReason    string      `json:"status_reason,omitempty"`
+	CreatedAt       string      `json:"created_at"`
+	UpdatedAt       string      `json:"updated_at"`
+	CompletedAt     string      `json:"completed_at,omitempty"`
+}
+
+// ToSignersDomain converts the signers of a SignatureRequestRequest to domain Signer models
+func ToSignersDomain(signers []SignerRequest) []models.Signer {
+	result := make([]models.Signer, len(signers))
+	for i, signer := range signers {
+		result[i] = models.Signer{
+			Name:         signer.Name,
+			Email:        signer.Email,
+			RoutingOrder: signer.RoutingOrder,
+		}
+	}
+	return result
+}
+
+// ToSignatureRequestDTO converts a domain SignatureRequest model to a SignatureRequestDTO
+func ToSignatureRequestDTO(request *models.SignatureRequest) SignatureRequestDTO {
+	dto := SignatureRequestDTO{
+		ID:              request.ID,
+		DocumentID:      request.DocumentID,
+		VersionID:       request.VersionID,
+		Provider:        request.Provider,
+		EnvelopeID:      request.EnvelopeID,
+		Status:          request.Status,
+		Subject:         request.Subject,
+		Message:         request.Message,
+		RequestedBy:     request.RequestedBy,
+		Signers:         make([]SignerDTO, len(request.Signers)),
+		SignedVersionID: request.SignedVersionID,
+		StatusReason:    request.StatusReason,
+		CreatedAt:       timeutils.FormatTime(request.CreatedAt, ""),
+		UpdatedAt:       timeutils.FormatTime(request.UpdatedAt, ""),
+	}
+	for i, signer := range request.Signers {
+		dto.Signers[i] = SignerDTO{
+			Name:         signer.Name,
+			Email:        signer.Email,
+			RoutingOrder: signer.RoutingOrder,
+			Status:       signer.Status,
+			IPAddress:    signer.IPAddress,
+		}
+		if signer.SignedAt != nil {
+			dto.Signers[i].SignedAt = timeutils.FormatTime(*signer.SignedAt, "")
+		}
+	}
+	if request.CompletedAt != nil {
+		dto.CompletedAt = timeutils.FormatTime(*request.CompletedAt, "")
+	}
+	return dto
+}
+
+// ToSignatureRequestListDTO converts a list of domain SignatureRequest models to SignatureRequestDTOs
+func ToSignatureRequestListDTO(requests []models.SignatureRequest) []SignatureRequestDTO {
+	dtos := make([]SignatureRequestDTO, len(requests))
+	for i := range requests {
+		dtos[i] = ToSignatureRequestDTO(&requests[i])
+	}
+	return dtos
+}
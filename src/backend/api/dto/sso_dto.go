@@ -0,0 +1,45 @@
+// Package dto provides Data Transfer Objects for single sign-on in the Document Management Platform API.
+// This file defines the request and response structures for exchanging IdP ID tokens.
+package dto
+
+// SSOTokenType is the type of the access tokens issued for single sign-on
+const SSOTokenType = "Bearer"
+
+// SSOTokenRequest is a DTO for exchanging an ID token issued by the tenant's identity provider
+type SSOTokenRequest struct {
+	TenantID string `json:"tenant_id" binding:"required"`
+	IDToken  string `json:"id_token" binding:"required"`
+}
+
+// SSOTokenDTO is a DTO for the platform tokens issued in exchange for an ID token
+type SSOTokenDTO struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+}
+
+// NewSSOTokenDTO creates an SSOTokenDTO for an access and refresh token pair
+func NewSSOTokenDTO(accessToken, refreshToken string) SSOTokenDTO {
+	return SSOTokenDTO{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    SSOTokenType,
+	}
+}
+
+// SSOMFAChallengeDTO is a DTO for the partial-auth token issued in exchange for an ID token when
+// the user still has to verify, or first enroll, a second factor
+type SSOMFAChallengeDTO struct {
+	MFAToken              string `json:"mfa_token"`
+	MFARequired           bool   `json:"mfa_required"`
+	MFAEnrollmentRequired bool   `json:"mfa_enrollment_required"`
+}
+
+// NewSSOMFAChallengeDTO creates an SSOMFAChallengeDTO for a partial-auth MFA token
+func NewSSOMFAChallengeDTO(mfaToken string, enrollmentRequired bool) SSOMFAChallengeDTO {
+	return SSOMFAChallengeDTO{
+		MFAToken:              mfaToken,
+		MFARequired:           true,
+		MFAEnrollmentRequired: enrollmentRequired,
+	}
+}
@@ -0,0 +1,44 @@
+// Package dto provides Data Transfer Objects for GDPR subject access exports in the Document Management Platform API.
+// This file defines the response structures for the subject access endpoints.
+package dto
+
+import (
+	"../../domain/models"
+	timeutils "../../pkg/utils/time_utils"
+)
+
+// SubjectAccessExportDTO is a DTO for subject access export responses. DownloadURL is a presigned
+// URL set once the archive is complete.
+type SubjectAccessExportDTO struct {
+	ID              string `json:"id"`
+	SubjectID       string `json:"subject_id"`
+	RequestedBy     string `json:"requested_by"`
+	Status          string `json:"status"`
+	DocumentCount   int    `json:"document_count"`
+	AuditEventCount int    `json:"audit_event_count"`
+	ArchiveSize     int64  `json:"archive_size,omitempty"`
+	DownloadURL     string `json:"download_url,omitempty"`
+	Error           string `json:"error,omitempty"`
+	CreatedAt       string `json:"created_at"`
+	CompletedAt     string `json:"completed_at,omitempty"`
+}
+
+// ToSubjectAccessExportDTO converts a domain SubjectAccessExport model to a SubjectAccessExportDTO
+func ToSubjectAccessExportDTO(export *models.SubjectAccessExport, downloadURL string) SubjectAccessExportDTO {
+	dto := SubjectAccessExportDTO{
+		ID:              export.ID,
+		SubjectID:       export.SubjectID,
+		RequestedBy:     export.RequestedBy,
+		Status:          export.Status,
+		DocumentCount:   export.DocumentCount,
+		AuditEventCount: export.AuditEventCount,
+		ArchiveSize:     export.ArchiveSize,
+		DownloadURL:     downloadURL,
+		Error:           export.Error,
+		CreatedAt:       timeutils.FormatTime(export.CreatedAt, ""),
+	}
+	if export.CompletedAt != nil {
+		dto.CompletedAt = timeutils.FormatTime(*export.CompletedAt, "")
+	}
+	return dto
+}
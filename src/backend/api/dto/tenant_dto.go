@@ -0,0 +1,166 @@
+// Package dto provides Data Transfer Objects for tenant administration in the Document Management Platform API.
+// This file defines the request and response structures for the tenant self-service endpoints.
+package dto
+
+import (
+	"../../domain/models"
+	"../../pkg/utils/pagination"
+	timeutils "../../pkg/utils/time_utils"
+)
+
+// InviteUserRequest is a DTO for inviting a user to the tenant
+type InviteUserRequest struct {
+	Username string   `json:"username" binding:"required"`
+	Email    string   `json:"email" binding:"required,email"`
+	Roles    []string `json:"roles"`
+}
+
+// AssignRolesRequest is a DTO for assigning roles to a user.
+// The roles replace the user's current roles.
+type AssignRolesRequest struct {
+	Roles []string `json:"roles"`
+}
+
+// UpdateTenantSettingsRequest is a DTO for changing tenant settings.
+// An empty value removes a setting, restoring its default.
+type UpdateTenantSettingsRequest struct {
+	Settings map[string]string `json:"settings" binding:"required"`
+}
+
+// SetUploadLimitRequest is a DTO for setting the upload limits of a user or role.
+// A zero limit means unlimited.
+type SetUploadLimitRequest struct {
+	MaxFileSizeBytes    int64 `json:"max_file_size_bytes" binding:"min=0"`
+	MaxDailyUploadBytes int64 `json:"max_daily_upload_bytes" binding:"min=0"`
+}
+
+// UserDTO is a DTO for user data returned to tenant administrators
+type UserDTO struct {
+	ID          string   `json:"id"`
+	Username    string   `json:"username"`
+	Email       string   `json:"email"`
+	Status      string   `json:"status"`
+	Roles       []string `json:"roles"`
+	LockedUntil string   `json:"locked_until,omitempty"`
+	CreatedAt   string   `json:"created_at"`
+	UpdatedAt   string   `json:"updated_at"`
+}
+
+// InvitedUserDTO is a DTO for the response to inviting a user. The temporary password is
+// only returned when it is not emailed to the user.
+type InvitedUserDTO struct {
+	User              UserDTO `json:"user"`
+	TemporaryPassword string  `json:"temporary_password,omitempty"`
+}
+
+// TemporaryPasswordDTO is a DTO for the response to resetting a user's password
+type TemporaryPasswordDTO struct {
+	TemporaryPassword string `json:"temporary_password"`
+}
+
+// TenantUsageDTO is a DTO for the users, documents and storage a tenant consumes.
+// Quota fields are omitted when the corresponding limit is unlimited.
+type TenantUsageDTO struct {
+	Users                 int64  `json:"users"`
+	ActiveUsers           int64  `json:"active_users"`
+	Documents             int64  `json:"documents"`
+	StorageBytes          int64  `json:"storage_bytes"`
+	MaxDocuments          *int64 `json:"max_documents,omitempty"`
+	RemainingDocuments    *int64 `json:"remaining_documents,omitempty"`
+	MaxStorageBytes       *int64 `json:"max_storage_bytes,omitempty"`
+	RemainingStorageBytes *int64 `json:"remaining_storage_bytes,omitempty"`
+}
+
+// TenantSettingsDTO is a DTO for a tenant's settings
+type TenantSettingsDTO struct {
+	Settings map[string]string `json:"settings"`
+}
+
+// UploadLimitDTO is a DTO for the upload limits of a user or role
+type UploadLimitDTO struct {
+	Scope               string `json:"scope"`
+	ScopeID             string `json:"scope_id"`
+	MaxFileSizeBytes    int64  `json:"max_file_size_bytes"`
+	MaxDailyUploadBytes int64  `json:"max_daily_upload_bytes"`
+	UpdatedAt           string `json:"updated_at"`
+}
+
+// ToUserDTO converts a domain User model to a UserDTO
+func ToUserDTO(user *models.User) UserDTO {
+	roles := user.Roles
+	if roles == nil {
+		roles = []string{}
+	}
+
+	dto := UserDTO{
+		ID:        user.ID,
+		Username:  user.Username,
+		Email:     user.Email,
+		Status:    user.Status,
+		Roles:     roles,
+		CreatedAt: timeutils.FormatTime(user.CreatedAt, ""),
+		UpdatedAt: timeutils.FormatTime(user.UpdatedAt, ""),
+	}
+	if user.LockedUntil != nil {
+		dto.LockedUntil = timeutils.FormatTime(*user.LockedUntil, "")
+	}
+	return dto
+}
+
+// ToUserListDTO converts a paginated list of domain User models to UserDTOs
+func ToUserListDTO(result pagination.PaginatedResult[models.User]) []UserDTO {
+	dtos := make([]UserDTO, len(result.Items))
+	for i, user := range result.Items {
+		dtos[i] = ToUserDTO(&user)
+	}
+	return dtos
+}
+
+// ToTenantUsageDTO converts a domain TenantUsage model to a TenantUsageDTO
+func ToTenantUsageDTO(usage *models.TenantUsage) TenantUsageDTO {
+	dto := TenantUsageDTO{
+		Users:        usage.Users,
+		ActiveUsers:  usage.ActiveUsers,
+		Documents:    usage.Documents,
+		StorageBytes: usage.StorageBytes,
+	}
+	if usage.MaxDocuments > 0 {
+		remaining := max(usage.MaxDocuments-usage.Documents, 0)
+		dto.MaxDocuments = &usage.MaxDocuments
+		dto.RemainingDocuments = &remaining
+	}
+	if usage.MaxStorageBytes > 0 {
+		remaining := max(usage.MaxStorageBytes-usage.StorageBytes, 0)
+		dto.MaxStorageBytes = &usage.MaxStorageBytes
+		dto.RemainingStorageBytes = &remaining
+	}
+	return dto
+}
+
+// ToUploadLimitDTO converts a domain UploadLimit model to an UploadLimitDTO
+func ToUploadLimitDTO(limit *models.UploadLimit) UploadLimitDTO {
+	return UploadLimitDTO{
+		Scope:               limit.Scope,
+		ScopeID:             limit.ScopeID,
+		MaxFileSizeBytes:    limit.MaxFileSizeBytes,
+		MaxDailyUploadBytes: limit.MaxDailyUploadBytes,
+		UpdatedAt:           timeutils.FormatTime(limit.UpdatedAt, ""),
+	}
+}
+
+// ToUploadLimitListDTO converts a list of domain UploadLimit models to UploadLimitDTOs
+func ToUploadLimitListDTO(limits []*models.UploadLimit) []UploadLimitDTO {
+	dtos := make([]UploadLimitDTO, len(limits))
+	for i, limit := range limits {
+		dtos[i] = ToUploadLimitDTO(limit)
+	}
+	return dtos
+}
+
+// ToTenantSettingsDTO converts tenant settings to a TenantSettingsDTO
+func ToTenantSettingsDTO(settings map[string]string) TenantSettingsDTO {
+	if settings == nil {
+		settings = map[string]string{}
+	}
+	return TenantSettingsDTO{Settings: settings}
+}
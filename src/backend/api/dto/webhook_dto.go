@@ -15,10 +15,28 @@ import (
 var SupportedEventTypes = []string{
 	"document.uploaded",
 	"document.processed",
-	"document.downloaded",
+	"document.scanning",
+	"document.clean",
 	"document.quarantined",
+	"document.scan_failed",
+	"document.downloaded",
+	"document.deleted",
 	"folder.created",
 	"folder.updated",
+	"folder.moved",
+	"folder.deleted",
+	"comment.created",
+	"approval.submitted",
+	"approval.step_approved",
+	"approval.approved",
+	"approval.rejected",
+	"approval.cancelled",
+	"signature.sent",
+	"signature.completed",
+	"signature.declined",
+	"signature.voided",
+	"subject_access_export.completed",
+	"subject_access_export.failed",
 }
 
 // CreateWebhookRequest is a DTO for creating a new webhook
@@ -38,6 +56,26 @@ type UpdateWebhookRequest struct {
 	SecretKey   string   `json:"secret_key"`
 }
 
+// CreateWebhookSubscriptionRequest is a DTO for adding a subscription filter to a webhook
+type CreateWebhookSubscriptionRequest struct {
+	EventTypes      []string          `json:"event_types"`
+	FolderIDs       []string          `json:"folder_ids"`
+	MetadataFilters map[string]string `json:"metadata_filters"`
+}
+
+// UpdateWebhookSubscriptionRequest is a DTO for updating a webhook subscription.
+// Nil fields are left unchanged; empty values clear the filter.
+type UpdateWebhookSubscriptionRequest struct {
+	EventTypes      *[]string          `json:"event_types"`
+	FolderIDs       *[]string          `json:"folder_ids"`
+	MetadataFilters *map[string]string `json:"metadata_filters"`
+}
+
+// TestWebhookDeliveryRequest is a DTO for sending a sample payload to a webhook
+type TestWebhookDeliveryRequest struct {
+	EventType string `json:"event_type"`
+}
+
 // WebhookDTO is a DTO for webhook data
 type WebhookDTO struct {
 	ID          string   `json:"id"`
@@ -49,21 +87,58 @@ type WebhookDTO struct {
 	UpdatedAt   string   `json:"updated_at"`
 }
 
+// WebhookSecretDTO is a DTO returning a webhook signing secret.
+// Secrets are only ever returned on creation and rotation.
+type WebhookSecretDTO struct {
+	ID        string `json:"id"`
+	SecretKey string `json:"secret_key"`
+}
+
 // WebhookDeliveryDTO is a DTO for webhook delivery data
 type WebhookDeliveryDTO struct {
 	ID             string `json:"id"`
 	WebhookID      string `json:"webhook_id"`
 	EventID        string `json:"event_id"`
+	EventType      string `json:"event_type"`
 	Status         string `json:"status"`
 	AttemptCount   int    `json:"attempt_count"`
 	ResponseStatus int    `json:"response_status"`
 	ResponseBody   string `json:"response_body"`
 	ErrorMessage   string `json:"error_message"`
+	NextAttemptAt  string `json:"next_attempt_at,omitempty"`
 	CreatedAt      string `json:"created_at"`
 	UpdatedAt      string `json:"updated_at"`
 	CompletedAt    string `json:"completed_at"`
 }
 
+// WebhookDeliveryDetailDTO is a DTO for a webhook delivery with its attempt history
+type WebhookDeliveryDetailDTO struct {
+	WebhookDeliveryDTO
+	Attempts []WebhookDeliveryAttemptDTO `json:"attempts"`
+}
+
+// WebhookDeliveryAttemptDTO is a DTO for a single webhook delivery attempt
+type WebhookDeliveryAttemptDTO struct {
+	ID             string `json:"id"`
+	AttemptNumber  int    `json:"attempt_number"`
+	ResponseStatus int    `json:"response_status"`
+	ResponseBody   string `json:"response_body"`
+	ErrorMessage   string `json:"error_message"`
+	DurationMs     int64  `json:"duration_ms"`
+	AttemptedAt    string `json:"attempted_at"`
+}
+
+// WebhookSubscriptionDTO is a DTO for webhook subscription data
+type WebhookSubscriptionDTO struct {
+	ID              string            `json:"id"`
+	WebhookID       string            `json:"webhook_id"`
+	EventTypes      []string          `json:"event_types"`
+	FolderIDs       []string          `json:"folder_ids"`
+	MetadataFilters map[string]string `json:"metadata_filters"`
+	CreatedAt       string            `json:"created_at"`
+	UpdatedAt       string            `json:"updated_at"`
+}
+
 // WebhookEventTypesResponse is a DTO for listing supported webhook event types
 type WebhookEventTypesResponse struct {
 	EventTypes []string `json:"event_types"`
@@ -120,6 +195,7 @@ func ToWebhookDomain(request *CreateWebhookRequest, tenantID string) *models.Web
 		URL:         request.URL,
 		EventTypes:  request.EventTypes,
 		Description: request.Description,
+		SecretKey:   request.SecretKey,
 		Status:      models.WebhookStatusActive,
 	}
 	return webhook
@@ -148,6 +224,7 @@ func ToWebhookDeliveryDTO(delivery *models.WebhookDelivery) WebhookDeliveryDTO {
 		ID:             delivery.ID,
 		WebhookID:      delivery.WebhookID,
 		EventID:        delivery.EventID,
+		EventType:      delivery.EventType,
 		Status:         delivery.Status,
 		AttemptCount:   delivery.AttemptCount,
 		ResponseStatus: delivery.ResponseStatus,
@@ -161,6 +238,10 @@ func ToWebhookDeliveryDTO(delivery *models.WebhookDelivery) WebhookDeliveryDTO {
 		dto.CompletedAt = timeutils.FormatTime(delivery.CompletedAt, "")
 	}
 
+	if !delivery.NextAttemptAt.IsZero() {
+		dto.NextAttemptAt = timeutils.FormatTime(delivery.NextAttemptAt, "")
+	}
+
 	return dto
 }
 
@@ -173,6 +254,83 @@ func ToWebhookDeliveryListDTO(result pagination.PaginatedResult[models.WebhookDe
 	return dtos
 }
 
+// ToWebhookDeliveryDetailDTO converts a domain WebhookDelivery model and its attempts to a WebhookDeliveryDetailDTO
+func ToWebhookDeliveryDetailDTO(delivery *models.WebhookDelivery, attempts []*models.WebhookDeliveryAttempt) WebhookDeliveryDetailDTO {
+	return WebhookDeliveryDetailDTO{
+		WebhookDeliveryDTO: ToWebhookDeliveryDTO(delivery),
+		Attempts:           ToWebhookDeliveryAttemptListDTO(attempts),
+	}
+}
+
+// ToWebhookDeliveryAttemptDTO converts a domain WebhookDeliveryAttempt model to a WebhookDeliveryAttemptDTO
+func ToWebhookDeliveryAttemptDTO(attempt *models.WebhookDeliveryAttempt) WebhookDeliveryAttemptDTO {
+	return WebhookDeliveryAttemptDTO{
+		ID:             attempt.ID,
+		AttemptNumber:  attempt.AttemptNumber,
+		ResponseStatus: attempt.ResponseStatus,
+		ResponseBody:   attempt.ResponseBody,
+		ErrorMessage:   attempt.ErrorMessage,
+		DurationMs:     attempt.DurationMs,
+		AttemptedAt:    timeutils.FormatTime(attempt.AttemptedAt, ""),
+	}
+}
+
+// ToWebhookDeliveryAttemptListDTO converts domain WebhookDeliveryAttempt models to WebhookDeliveryAttemptDTOs
+func ToWebhookDeliveryAttemptListDTO(attempts []*models.WebhookDeliveryAttempt) []WebhookDeliveryAttemptDTO {
+	dtos := make([]WebhookDeliveryAttemptDTO, len(attempts))
+	for i, attempt := range attempts {
+		dtos[i] = ToWebhookDeliveryAttemptDTO(attempt)
+	}
+	return dtos
+}
+
+// ToWebhookSubscriptionDTO converts a domain WebhookSubscription model to a WebhookSubscriptionDTO
+func ToWebhookSubscriptionDTO(subscription *models.WebhookSubscription) WebhookSubscriptionDTO {
+	return WebhookSubscriptionDTO{
+		ID:              subscription.ID,
+		WebhookID:       subscription.WebhookID,
+		EventTypes:      subscription.EventTypes,
+		FolderIDs:       subscription.FolderIDs,
+		MetadataFilters: subscription.MetadataFilters,
+		CreatedAt:       timeutils.FormatTime(subscription.CreatedAt, ""),
+		UpdatedAt:       timeutils.FormatTime(subscription.UpdatedAt, ""),
+	}
+}
+
+// ToWebhookSubscriptionListDTO converts domain WebhookSubscription models to WebhookSubscriptionDTOs
+func ToWebhookSubscriptionListDTO(subscriptions []*models.WebhookSubscription) []WebhookSubscriptionDTO {
+	dtos := make([]WebhookSubscriptionDTO, len(subscriptions))
+	for i, subscription := range subscriptions {
+		dtos[i] = ToWebhookSubscriptionDTO(subscription)
+	}
+	return dtos
+}
+
+// ToWebhookSubscriptionDomain converts a CreateWebhookSubscriptionRequest to a domain WebhookSubscription model
+func ToWebhookSubscriptionDomain(request *CreateWebhookSubscriptionRequest, webhookID string, tenantID string) *models.WebhookSubscription {
+	return &models.WebhookSubscription{
+		WebhookID:       webhookID,
+		TenantID:        tenantID,
+		EventTypes:      request.EventTypes,
+		FolderIDs:       request.FolderIDs,
+		MetadataFilters: request.MetadataFilters,
+	}
+}
+
+// UpdateWebhookSubscriptionFromRequest updates a domain WebhookSubscription model with values from an UpdateWebhookSubscriptionRequest
+func UpdateWebhookSubscriptionFromRequest(subscription *models.WebhookSubscription, request *UpdateWebhookSubscriptionRequest) *models.WebhookSubscription {
+	if request.EventTypes != nil {
+		subscription.EventTypes = *request.EventTypes
+	}
+	if request.FolderIDs != nil {
+		subscription.FolderIDs = *request.FolderIDs
+	}
+	if request.MetadataFilters != nil {
+		subscription.MetadataFilters = *request.MetadataFilters
+	}
+	return subscription
+}
+
 // NewSuccessResponse creates a new success response with a message
 func NewSuccessResponse(message string) MessageResponse {
 	return MessageResponse{
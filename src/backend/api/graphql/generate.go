@@ -0,0 +1,8 @@
+// Package graphql provides the GraphQL API of the Document Management Platform. It layers read
+// queries over the document, folder and search use cases so that clients can fetch a folder with
+// its children, documents and permissions in a single request.
+package graphql
+
+// The generated and model packages are generated from schema.graphqls; run `make graphql` after
+// changing the schema.
+//go:generate go run github.com/99designs/gqlgen generate --config gqlgen.yml
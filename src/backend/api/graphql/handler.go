@@ -0,0 +1,91 @@
+package graphql
+
+import (
+	"context" // standard library
+
+	gqlgen "github.com/99designs/gqlgen/graphql"            // v0.17.0+
+	"github.com/99designs/gqlgen/graphql/handler"           // v0.17.0+
+	"github.com/99designs/gqlgen/graphql/handler/extension" // v0.17.0+
+	"github.com/gin-gonic/gin"                              // v1.9.0+
+	"github.com/vektah/gqlparser/v2/gqlerror"               // v2.5.0+
+
+	"../../pkg/errors"
+	"../../pkg/logger"
+	"../middleware"
+	"./generated"
+)
+
+// maxQueryComplexity bounds how many fields a single query may resolve, so that deeply nested
+// folder queries cannot fan out into an unbounded number of use case calls
+const maxQueryComplexity = 500
+
+// contextKey is the type of the context keys set by the handler
+type contextKey string
+
+// Context keys for the authenticated caller
+const (
+	contextKeyUserID   contextKey = "user_id"
+	contextKeyTenantID contextKey = "tenant_id"
+)
+
+// NewHandler creates a Gin handler that serves GraphQL queries with the resolver. It must run after
+// authentication: queries are resolved for the tenant and user of the request.
+func NewHandler(resolver *Resolver) gin.HandlerFunc {
+	server := handler.NewDefaultServer(generated.NewExecutableSchema(generated.Config{Resolvers: resolver}))
+	server.Use(extension.FixedComplexityLimit(maxQueryComplexity))
+	server.SetErrorPresenter(presentError)
+
+	return func(c *gin.Context) {
+		ctx := context.WithValue(c.Request.Context(), contextKeyUserID, middleware.GetUserID(c))
+		ctx = context.WithValue(ctx, contextKeyTenantID, middleware.GetTenantID(c))
+		server.ServeHTTP(c.Writer, c.Request.WithContext(ctx))
+	}
+}
+
+// getUserID returns the ID of the user a query is resolved for
+func getUserID(ctx context.Context) string {
+	userID, _ := ctx.Value(contextKeyUserID).(string)
+	return userID
+}
+
+// getTenantID returns the ID of the tenant a query is resolved for
+func getTenantID(ctx context.Context) string {
+	tenantID, _ := ctx.Value(contextKeyTenantID).(string)
+	return tenantID
+}
+
+// presentError adds the error type of application errors to GraphQL errors as an extension code.
+// Internal errors are logged and not described to clients.
+func presentError(ctx context.Context, err error) *gqlerror.Error {
+	presented := gqlgen.DefaultErrorPresenter(ctx, err)
+
+	code := ""
+	switch errors.GetErrorType(err) {
+	case errors.ErrorTypeValidation:
+		code = "BAD_USER_INPUT"
+	case errors.ErrorTypeNotFound:
+		code = "NOT_FOUND"
+	case errors.ErrorTypeAuthentication:
+		code = "UNAUTHENTICATED"
+	case errors.ErrorTypeAuthorization, errors.ErrorTypeSecurity:
+		code = "FORBIDDEN"
+	case errors.ErrorTypeQuotaExceeded:
+		code = "QUOTA_EXCEEDED"
+	case errors.ErrorTypeDependency:
+		code = "SERVICE_UNAVAILABLE"
+		presented.Message = "a dependency is unavailable, please retry"
+	case errors.ErrorTypeInternal:
+		logger.ErrorContext(ctx, "GraphQL resolver failed", "path", presented.Path.String(), "error", err.Error())
+		code = "INTERNAL"
+		presented.Message = "internal error"
+	default:
+		// Errors of the GraphQL layer itself, such as invalid arguments, are presented as they are
+		return presented
+	}
+
+	if presented.Extensions == nil {
+		presented.Extensions = map[string]interface{}{}
+	}
+	presented.Extensions["code"] = code
+	return presented
+}
@@ -0,0 +1,71 @@
+package graphql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert" // v1.8.0+
+
+	"../../application/usecases" // For the folder use case the resolvers query
+	"../../domain/models"        // For folders and documents
+	"../../domain/services"      // For the folder service behind the folder use case
+	"../../pkg/errors"           // For creating typed application errors
+	"../../pkg/utils"            // For paged folder contents
+)
+
+// TestPresentError tests that application errors are presented with an extension code
+func TestPresentError(t *testing.T) {
+	presented := presentError(context.Background(), errors.Wrap(errors.NewResourceNotFoundError("folder not found"), "failed to get folder"))
+	assert.Equal(t, "NOT_FOUND", presented.Extensions["code"])
+
+	presented = presentError(context.Background(), errors.NewValidationError("a query or metadata is required"))
+	assert.Equal(t, "BAD_USER_INPUT", presented.Extensions["code"])
+	assert.Equal(t, "a query or metadata is required", presented.Message)
+}
+
+// TestPresentErrorHidesInternalErrors tests that internal errors are not described to clients
+func TestPresentErrorHidesInternalErrors(t *testing.T) {
+	presented := presentError(context.Background(), errors.NewInternalError("connection to 10.0.0.5 refused"))
+
+	assert.Equal(t, "INTERNAL", presented.Extensions["code"])
+	assert.Equal(t, "internal error", presented.Message)
+}
+
+// TestGetCaller tests that the caller set by the handler can be read back
+func TestGetCaller(t *testing.T) {
+	ctx := context.WithValue(context.Background(), contextKeyUserID, "user-1")
+	ctx = context.WithValue(ctx, contextKeyTenantID, "tenant-1")
+
+	assert.Equal(t, "user-1", getUserID(ctx))
+	assert.Equal(t, "tenant-1", getTenantID(ctx))
+	assert.Equal(t, "", getUserID(context.Background()))
+}
+
+// fakeFolderService lists a folder holding a single document
+type fakeFolderService struct {
+	services.FolderService
+}
+
+func (f *fakeFolderService) ListFolderContents(ctx context.Context, id, tenantID, userID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Folder], utils.PaginatedResult[models.Document], error) {
+	documents := []models.Document{{ID: "doc-1", Name: "report.pdf", FolderID: id, TenantID: tenantID}}
+	return utils.PaginatedResult[models.Folder]{}, utils.NewPaginatedResult(documents, pagination, 1), nil
+}
+
+// fakeEventService satisfies the folder use case, which publishes no events when listing
+type fakeEventService struct {
+	services.EventServiceInterface
+}
+
+// TestFolderDocuments tests that the documents of a folder are listed through the folder use case
+func TestFolderDocuments(t *testing.T) {
+	resolver := NewResolver(nil, usecases.NewFolderUseCase(&fakeFolderService{}, &fakeEventService{}), nil)
+	ctx := context.WithValue(context.Background(), contextKeyUserID, "user-1")
+	ctx = context.WithValue(ctx, contextKeyTenantID, "tenant-1")
+
+	page, err := resolver.Folder().Documents(ctx, &models.Folder{ID: "folder-1", TenantID: "tenant-1"}, nil, nil)
+
+	assert.NoError(t, err)
+	assert.Len(t, page.Items, 1)
+	assert.Equal(t, "report.pdf", page.Items[0].Name)
+	assert.Equal(t, 1, page.PageInfo.Page)
+}
@@ -0,0 +1,60 @@
+package graphql
+
+import (
+	"../../application/usecases"
+	"../../domain/models"
+	"../../pkg/utils"
+	"./model"
+)
+
+// Resolver resolves GraphQL queries with the use cases that also serve the REST API
+type Resolver struct {
+	documentUseCase usecases.DocumentUseCase
+	folderUseCase   *usecases.FolderUseCase
+	searchUseCase   usecases.SearchUseCase
+}
+
+// NewResolver creates a new Resolver with the provided use cases
+func NewResolver(
+	documentUseCase usecases.DocumentUseCase,
+	folderUseCase *usecases.FolderUseCase,
+	searchUseCase usecases.SearchUseCase,
+) *Resolver {
+	return &Resolver{
+		documentUseCase: documentUseCase,
+		folderUseCase:   folderUseCase,
+		searchUseCase:   searchUseCase,
+	}
+}
+
+// newPagination converts the optional page arguments of a query into pagination, applying the defaults
+func newPagination(page, pageSize *int) *utils.Pagination {
+	var p, size int
+	if page != nil {
+		p = *page
+	}
+	if pageSize != nil {
+		size = *pageSize
+	}
+	return utils.NewPagination(p, size)
+}
+
+// newDocumentPage converts a page of documents into its GraphQL type
+func newDocumentPage(result utils.PaginatedResult[models.Document]) *model.DocumentPage {
+	items := make([]*models.Document, 0, len(result.Items))
+	for i := range result.Items {
+		items = append(items, &result.Items[i])
+	}
+	pageInfo := result.Pagination
+	return &model.DocumentPage{Items: items, PageInfo: &pageInfo}
+}
+
+// newFolderPage converts a page of folders into its GraphQL type
+func newFolderPage(result utils.PaginatedResult[models.Folder]) *model.FolderPage {
+	items := make([]*models.Folder, 0, len(result.Items))
+	for i := range result.Items {
+		items = append(items, &result.Items[i])
+	}
+	pageInfo := result.Pagination
+	return &model.FolderPage{Items: items, PageInfo: &pageInfo}
+}
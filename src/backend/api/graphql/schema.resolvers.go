@@ -0,0 +1,156 @@
+package graphql
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+
+	"../../domain/models"
+	"../../pkg/errors"
+	"./generated"
+	"./model"
+)
+
+// Folder is the resolver for the folder field.
+func (r *documentResolver) Folder(ctx context.Context, obj *models.Document) (*models.Folder, error) {
+	if obj.FolderID == "" {
+		return nil, nil
+	}
+	return r.Query().Folder(ctx, obj.FolderID)
+}
+
+// ParentID is the resolver for the parentId field.
+func (r *folderResolver) ParentID(ctx context.Context, obj *models.Folder) (*string, error) {
+	if obj.ParentID == "" {
+		return nil, nil
+	}
+	return &obj.ParentID, nil
+}
+
+// Parent is the resolver for the parent field.
+func (r *folderResolver) Parent(ctx context.Context, obj *models.Folder) (*models.Folder, error) {
+	if obj.ParentID == "" {
+		return nil, nil
+	}
+	return r.Query().Folder(ctx, obj.ParentID)
+}
+
+// Children is the resolver for the children field.
+func (r *folderResolver) Children(ctx context.Context, obj *models.Folder, page *int, pageSize *int) (*model.FolderPage, error) {
+	folders, _, err := r.folderUseCase.ListFolderContents(ctx, obj.ID, getTenantID(ctx), getUserID(ctx), newPagination(page, pageSize))
+	if err != nil {
+		return nil, err
+	}
+	return newFolderPage(folders), nil
+}
+
+// Documents is the resolver for the documents field.
+func (r *folderResolver) Documents(ctx context.Context, obj *models.Folder, page *int, pageSize *int) (*model.DocumentPage, error) {
+	_, documents, err := r.folderUseCase.ListFolderContents(ctx, obj.ID, getTenantID(ctx), getUserID(ctx), newPagination(page, pageSize))
+	if err != nil {
+		return nil, err
+	}
+	return newDocumentPage(documents), nil
+}
+
+// Permissions is the resolver for the permissions field.
+func (r *folderResolver) Permissions(ctx context.Context, obj *models.Folder) ([]*models.Permission, error) {
+	return r.folderUseCase.GetFolderPermissions(ctx, obj.ID, getTenantID(ctx), getUserID(ctx))
+}
+
+// Document is the resolver for the document field.
+func (r *queryResolver) Document(ctx context.Context, id string) (*models.Document, error) {
+	document, err := r.documentUseCase.GetDocument(ctx, id, getTenantID(ctx), getUserID(ctx))
+	if errors.IsResourceNotFoundError(err) {
+		return nil, nil
+	}
+	return document, err
+}
+
+// Folder is the resolver for the folder field.
+func (r *queryResolver) Folder(ctx context.Context, id string) (*models.Folder, error) {
+	folder, err := r.folderUseCase.GetFolder(ctx, id, getTenantID(ctx), getUserID(ctx))
+	if errors.IsResourceNotFoundError(err) {
+		return nil, nil
+	}
+	return folder, err
+}
+
+// FolderByPath is the resolver for the folderByPath field.
+func (r *queryResolver) FolderByPath(ctx context.Context, path string) (*models.Folder, error) {
+	folder, err := r.folderUseCase.GetFolderByPath(ctx, path, getTenantID(ctx), getUserID(ctx))
+	if errors.IsResourceNotFoundError(err) {
+		return nil, nil
+	}
+	return folder, err
+}
+
+// RootFolders is the resolver for the rootFolders field.
+func (r *queryResolver) RootFolders(ctx context.Context, page *int, pageSize *int) (*model.FolderPage, error) {
+	folders, err := r.folderUseCase.ListRootFolders(ctx, getTenantID(ctx), getUserID(ctx), newPagination(page, pageSize))
+	if err != nil {
+		return nil, err
+	}
+	return newFolderPage(folders), nil
+}
+
+// Search is the resolver for the search field.
+func (r *queryResolver) Search(ctx context.Context, query *string, metadata []*model.MetadataInput, folderID *string, page *int, pageSize *int) (*model.DocumentPage, error) {
+	tenantID := getTenantID(ctx)
+	pagination := newPagination(page, pageSize)
+
+	var contentQuery string
+	if query != nil {
+		contentQuery = *query
+	}
+	metadataFilter := make(map[string]string, len(metadata))
+	for _, entry := range metadata {
+		metadataFilter[entry.Key] = entry.Value
+	}
+
+	switch {
+	case folderID != nil && *folderID != "":
+		if len(metadataFilter) > 0 {
+			return nil, errors.NewValidationError("metadata cannot be combined with a folder")
+		}
+		result, err := r.searchUseCase.SearchInFolder(ctx, *folderID, contentQuery, tenantID, pagination)
+		if err != nil {
+			return nil, err
+		}
+		return newDocumentPage(result), nil
+	case contentQuery != "" && len(metadataFilter) > 0:
+		result, err := r.searchUseCase.CombinedSearch(ctx, contentQuery, metadataFilter, nil, tenantID, pagination)
+		if err != nil {
+			return nil, err
+		}
+		return newDocumentPage(result), nil
+	case contentQuery != "":
+		result, err := r.searchUseCase.SearchByContent(ctx, contentQuery, tenantID, pagination)
+		if err != nil {
+			return nil, err
+		}
+		return newDocumentPage(result), nil
+	case len(metadataFilter) > 0:
+		result, err := r.searchUseCase.SearchByMetadata(ctx, metadataFilter, nil, tenantID, pagination)
+		if err != nil {
+			return nil, err
+		}
+		return newDocumentPage(result), nil
+	default:
+		return nil, errors.NewValidationError("a query or metadata is required")
+	}
+}
+
+// Document returns generated.DocumentResolver implementation.
+func (r *Resolver) Document() generated.DocumentResolver { return &documentResolver{r} }
+
+// Folder returns generated.FolderResolver implementation.
+func (r *Resolver) Folder() generated.FolderResolver { return &folderResolver{r} }
+
+// Query returns generated.QueryResolver implementation.
+func (r *Resolver) Query() generated.QueryResolver { return &queryResolver{r} }
+
+type documentResolver struct{ *Resolver }
+type folderResolver struct{ *Resolver }
+type queryResolver struct{ *Resolver }
@@ -0,0 +1,78 @@
+package grpcapi
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb" // v1.28.0+
+
+	"../../domain/models"
+	"../../pkg/utils"
+	dmsv1 "./dmsv1"
+)
+
+// documentToProto converts a document into its protobuf message
+func documentToProto(document models.Document) *dmsv1.Document {
+	metadata := make(map[string]string, len(document.Metadata))
+	for _, entry := range document.Metadata {
+		metadata[entry.Key] = entry.Value
+	}
+
+	return &dmsv1.Document{
+		Id:          document.ID,
+		Name:        document.Name,
+		ContentType: document.ContentType,
+		Size:        document.Size,
+		FolderId:    document.FolderID,
+		OwnerId:     document.OwnerID,
+		Status:      document.Status,
+		Metadata:    metadata,
+		CreatedAt:   timestamppb.New(document.CreatedAt),
+		UpdatedAt:   timestamppb.New(document.UpdatedAt),
+	}
+}
+
+// documentsToProto converts documents into their protobuf messages
+func documentsToProto(documents []models.Document) []*dmsv1.Document {
+	result := make([]*dmsv1.Document, 0, len(documents))
+	for _, document := range documents {
+		result = append(result, documentToProto(document))
+	}
+	return result
+}
+
+// folderToProto converts a folder into its protobuf message
+func folderToProto(folder models.Folder) *dmsv1.Folder {
+	return &dmsv1.Folder{
+		Id:        folder.ID,
+		Name:      folder.Name,
+		ParentId:  folder.ParentID,
+		Path:      folder.Path,
+		OwnerId:   folder.OwnerID,
+		CreatedAt: timestamppb.New(folder.CreatedAt),
+		UpdatedAt: timestamppb.New(folder.UpdatedAt),
+	}
+}
+
+// foldersToProto converts folders into their protobuf messages
+func foldersToProto(folders []models.Folder) []*dmsv1.Folder {
+	result := make([]*dmsv1.Folder, 0, len(folders))
+	for _, folder := range folders {
+		result = append(result, folderToProto(folder))
+	}
+	return result
+}
+
+// paginationFromProto converts a page request into pagination, applying the defaults
+func paginationFromProto(page *dmsv1.PageRequest) *utils.Pagination {
+	return utils.NewPagination(int(page.GetPage()), int(page.GetPageSize()))
+}
+
+// pageInfoToProto converts the page info of a listing into its protobuf message
+func pageInfoToProto(info utils.PageInfo) *dmsv1.PageInfo {
+	return &dmsv1.PageInfo{
+		Page:        int32(info.Page),
+		PageSize:    int32(info.PageSize),
+		TotalPages:  int32(info.TotalPages),
+		TotalItems:  info.TotalItems,
+		HasNext:     info.HasNext,
+		HasPrevious: info.HasPrevious,
+	}
+}
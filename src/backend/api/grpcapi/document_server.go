@@ -0,0 +1,174 @@
+package grpcapi
+
+import (
+	"context" // standard library
+	"io"      // standard library
+
+	"google.golang.org/grpc/codes"  // v1.53.0+
+	"google.golang.org/grpc/status" // v1.53.0+
+
+	"../../application/usecases"
+	"../../domain/models"
+	"../../pkg/logger"
+	dmsv1 "./dmsv1"
+)
+
+// downloadChunkSize is the size of the content chunks streamed to clients
+const downloadChunkSize = 64 * 1024
+
+// DocumentServer implements the DocumentService of the gRPC API on top of the document use case.
+// Documents are listed through the folder use case, which checks access to their folder.
+type DocumentServer struct {
+	dmsv1.UnimplementedDocumentServiceServer
+	documentUseCase usecases.DocumentUseCase
+	folderUseCase   *usecases.FolderUseCase
+}
+
+// NewDocumentServer creates a new DocumentServer
+func NewDocumentServer(documentUseCase usecases.DocumentUseCase, folderUseCase *usecases.FolderUseCase) *DocumentServer {
+	return &DocumentServer{
+		documentUseCase: documentUseCase,
+		folderUseCase:   folderUseCase,
+	}
+}
+
+// GetDocument returns a document's details
+func (s *DocumentServer) GetDocument(ctx context.Context, req *dmsv1.GetDocumentRequest) (*dmsv1.Document, error) {
+	document, err := s.documentUseCase.GetDocument(ctx, req.GetId(), GetTenantID(ctx), GetUserID(ctx))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return documentToProto(*document), nil
+}
+
+// UploadDocument receives a document's info and then its content, which is piped into the
+// document use case as it arrives
+func (s *DocumentServer) UploadDocument(stream dmsv1.DocumentService_UploadDocumentServer) error {
+	ctx := stream.Context()
+
+	first, err := stream.Recv()
+	if err == io.EOF {
+		return status.Error(codes.InvalidArgument, "document info is required")
+	}
+	if err != nil {
+		return err
+	}
+	info := first.GetInfo()
+	if info == nil {
+		return status.Error(codes.InvalidArgument, "the first message must carry the document info")
+	}
+
+	reader, writer := io.Pipe()
+	go func() {
+		for {
+			req, err := stream.Recv()
+			if err == io.EOF {
+				writer.Close()
+				return
+			}
+			if err != nil {
+				writer.CloseWithError(err)
+				return
+			}
+			if req.GetInfo() != nil {
+				writer.CloseWithError(status.Error(codes.InvalidArgument, "document info can only be sent once"))
+				return
+			}
+			if _, err := writer.Write(req.GetChunk()); err != nil {
+				// The use case stopped reading
+				return
+			}
+		}
+	}()
+
+	documentID, err := s.documentUseCase.UploadDocument(ctx, info.GetName(), info.GetContentType(), info.GetSize(),
+		info.GetFolderId(), GetTenantID(ctx), GetUserID(ctx), reader, info.GetMetadata(), "", info.GetSha256())
+	reader.Close()
+	if err != nil {
+		return toStatus(err)
+	}
+
+	// Small files scanned inline are available right away
+	documentStatus := models.DocumentStatusProcessing
+	if document, err := s.documentUseCase.GetDocument(ctx, documentID, GetTenantID(ctx), GetUserID(ctx)); err == nil && document.Status == models.DocumentStatusAvailable {
+		documentStatus = models.DocumentStatusAvailable
+	}
+
+	return stream.SendAndClose(&dmsv1.UploadDocumentResponse{
+		Id:     documentID,
+		Status: documentStatus,
+	})
+}
+
+// DownloadDocument sends a document's info and then streams its content in chunks
+func (s *DocumentServer) DownloadDocument(req *dmsv1.DownloadDocumentRequest, stream dmsv1.DocumentService_DownloadDocumentServer) error {
+	ctx := stream.Context()
+	tenantID, userID := GetTenantID(ctx), GetUserID(ctx)
+
+	document, err := s.documentUseCase.GetDocument(ctx, req.GetId(), tenantID, userID)
+	if err != nil {
+		return toStatus(err)
+	}
+
+	// The download reports the size of its content, which differs from the document's when it is watermarked
+	var download *usecases.DocumentDownload
+	if req.GetVersionId() != "" {
+		download, err = s.documentUseCase.DownloadDocumentVersion(ctx, req.GetId(), req.GetVersionId(), tenantID, userID, "", "")
+	} else {
+		download, err = s.documentUseCase.DownloadDocumentRange(ctx, req.GetId(), tenantID, userID, "", "")
+	}
+	if err != nil {
+		return toStatus(err)
+	}
+	content := download.Content
+	defer content.Close()
+
+	if err := stream.Send(&dmsv1.DownloadDocumentResponse{
+		Payload: &dmsv1.DownloadDocumentResponse_Info{Info: &dmsv1.DownloadDocumentInfo{
+			Name:        download.FileName,
+			ContentType: document.ContentType,
+			Size:        download.Size,
+		}},
+	}); err != nil {
+		return err
+	}
+
+	buffer := make([]byte, downloadChunkSize)
+	for {
+		n, readErr := content.Read(buffer)
+		if n > 0 {
+			if err := stream.Send(&dmsv1.DownloadDocumentResponse{
+				Payload: &dmsv1.DownloadDocumentResponse_Chunk{Chunk: buffer[:n]},
+			}); err != nil {
+				return err
+			}
+		}
+		if readErr == io.EOF {
+			return nil
+		}
+		if readErr != nil {
+			logger.ErrorContext(ctx, "Failed to stream document content", "document_id", req.GetId(), "error", readErr.Error())
+			return status.Error(codes.Internal, "failed to stream document content")
+		}
+	}
+}
+
+// DeleteDocument deletes a document
+func (s *DocumentServer) DeleteDocument(ctx context.Context, req *dmsv1.DeleteDocumentRequest) (*dmsv1.DeleteDocumentResponse, error) {
+	if err := s.documentUseCase.DeleteDocument(ctx, req.GetId(), GetTenantID(ctx), GetUserID(ctx)); err != nil {
+		return nil, toStatus(err)
+	}
+	return &dmsv1.DeleteDocumentResponse{}, nil
+}
+
+// ListDocuments lists the documents in a folder
+func (s *DocumentServer) ListDocuments(ctx context.Context, req *dmsv1.ListDocumentsRequest) (*dmsv1.ListDocumentsResponse, error) {
+	_, documents, err := s.folderUseCase.ListFolderContents(ctx, req.GetFolderId(), GetTenantID(ctx), GetUserID(ctx), paginationFromProto(req.GetPage()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &dmsv1.ListDocumentsResponse{
+		Documents: documentsToProto(documents.Items),
+		PageInfo:  pageInfoToProto(documents.Pagination),
+	}, nil
+}
@@ -0,0 +1,96 @@
+package grpcapi
+
+import (
+	"context" // standard library
+
+	"../../application/usecases"
+	dmsv1 "./dmsv1"
+)
+
+// FolderServer implements the FolderService of the gRPC API on top of the folder use case
+type FolderServer struct {
+	dmsv1.UnimplementedFolderServiceServer
+	folderUseCase *usecases.FolderUseCase
+}
+
+// NewFolderServer creates a new FolderServer
+func NewFolderServer(folderUseCase *usecases.FolderUseCase) *FolderServer {
+	return &FolderServer{
+		folderUseCase: folderUseCase,
+	}
+}
+
+// CreateFolder creates a folder and returns it
+func (s *FolderServer) CreateFolder(ctx context.Context, req *dmsv1.CreateFolderRequest) (*dmsv1.Folder, error) {
+	folderID, err := s.folderUseCase.CreateFolder(ctx, req.GetName(), req.GetParentId(), GetTenantID(ctx), GetUserID(ctx))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return s.getFolder(ctx, folderID)
+}
+
+// GetFolder returns a folder's details
+func (s *FolderServer) GetFolder(ctx context.Context, req *dmsv1.GetFolderRequest) (*dmsv1.Folder, error) {
+	return s.getFolder(ctx, req.GetId())
+}
+
+// UpdateFolder renames a folder and returns it
+func (s *FolderServer) UpdateFolder(ctx context.Context, req *dmsv1.UpdateFolderRequest) (*dmsv1.Folder, error) {
+	if err := s.folderUseCase.UpdateFolder(ctx, req.GetId(), req.GetName(), GetTenantID(ctx), GetUserID(ctx)); err != nil {
+		return nil, toStatus(err)
+	}
+	return s.getFolder(ctx, req.GetId())
+}
+
+// DeleteFolder deletes a folder
+func (s *FolderServer) DeleteFolder(ctx context.Context, req *dmsv1.DeleteFolderRequest) (*dmsv1.DeleteFolderResponse, error) {
+	if err := s.folderUseCase.DeleteFolder(ctx, req.GetId(), GetTenantID(ctx), GetUserID(ctx)); err != nil {
+		return nil, toStatus(err)
+	}
+	return &dmsv1.DeleteFolderResponse{}, nil
+}
+
+// MoveFolder moves a folder under a new parent and returns it
+func (s *FolderServer) MoveFolder(ctx context.Context, req *dmsv1.MoveFolderRequest) (*dmsv1.Folder, error) {
+	if err := s.folderUseCase.MoveFolder(ctx, req.GetId(), req.GetNewParentId(), GetTenantID(ctx), GetUserID(ctx)); err != nil {
+		return nil, toStatus(err)
+	}
+	return s.getFolder(ctx, req.GetId())
+}
+
+// ListFolderContents lists the subfolders and documents of a folder, or the root folders
+func (s *FolderServer) ListFolderContents(ctx context.Context, req *dmsv1.ListFolderContentsRequest) (*dmsv1.ListFolderContentsResponse, error) {
+	tenantID, userID := GetTenantID(ctx), GetUserID(ctx)
+	pagination := paginationFromProto(req.GetPage())
+
+	if req.GetId() == "" {
+		folders, err := s.folderUseCase.ListRootFolders(ctx, tenantID, userID, pagination)
+		if err != nil {
+			return nil, toStatus(err)
+		}
+		return &dmsv1.ListFolderContentsResponse{
+			Folders:         foldersToProto(folders.Items),
+			FoldersPageInfo: pageInfoToProto(folders.Pagination),
+		}, nil
+	}
+
+	folders, documents, err := s.folderUseCase.ListFolderContents(ctx, req.GetId(), tenantID, userID, pagination)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &dmsv1.ListFolderContentsResponse{
+		Folders:           foldersToProto(folders.Items),
+		FoldersPageInfo:   pageInfoToProto(folders.Pagination),
+		Documents:         documentsToProto(documents.Items),
+		DocumentsPageInfo: pageInfoToProto(documents.Pagination),
+	}, nil
+}
+
+// getFolder returns a folder's details
+func (s *FolderServer) getFolder(ctx context.Context, id string) (*dmsv1.Folder, error) {
+	folder, err := s.folderUseCase.GetFolder(ctx, id, GetTenantID(ctx), GetUserID(ctx))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return folderToProto(*folder), nil
+}
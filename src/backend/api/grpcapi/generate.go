@@ -0,0 +1,7 @@
+// Package grpcapi provides the gRPC API of the Document Management Platform. It serves the
+// document, folder and search services defined in proto/dms/v1 next to the REST API, on top of the
+// same use cases, for internal services that want typed clients and streaming transfers.
+package grpcapi
+
+// The dmsv1 package is generated from the protobuf definitions; run `make proto` after changing them.
+//go:generate protoc --proto_path=proto --go_out=. --go_opt=module=src/backend/api/grpcapi --go-grpc_out=. --go-grpc_opt=module=src/backend/api/grpcapi proto/dms/v1/common.proto proto/dms/v1/document.proto proto/dms/v1/folder.proto proto/dms/v1/search.proto
@@ -0,0 +1,315 @@
+package grpcapi
+
+import (
+	"context"       // standard library
+	"fmt"           // standard library
+	"net"           // standard library
+	"runtime/debug" // standard library
+	"strings"       // standard library
+	"time"          // standard library
+
+	"github.com/golang-jwt/jwt/v5"    // v5.0.0+
+	"google.golang.org/grpc"          // v1.53.0+
+	"google.golang.org/grpc/codes"    // v1.53.0+
+	"google.golang.org/grpc/metadata" // v1.53.0+
+	"google.golang.org/grpc/peer"     // v1.53.0+
+	"google.golang.org/grpc/status"   // v1.53.0+
+
+	"../../domain/services"
+	"../../pkg/logger"
+	"../../pkg/metrics"
+)
+
+// Metadata keys read from incoming calls
+const (
+	authorizationMetadataKey = "authorization"
+	tenantIDMetadataKey      = "x-tenant-id"
+	bearerPrefix             = "Bearer "
+)
+
+// healthServicePrefix prefixes the methods of the standard health service, which are served
+// without authentication so that load balancers and orchestrators can probe the server
+const healthServicePrefix = "/grpc.health.v1.Health/"
+
+// contextKey is the type of the context keys set by the interceptors
+type contextKey string
+
+// Context keys for the authenticated caller
+const (
+	contextKeyUserID   contextKey = "user_id"
+	contextKeyTenantID contextKey = "tenant_id"
+	contextKeyRoles    contextKey = "roles"
+)
+
+// GetUserID returns the ID of the authenticated user of a call
+func GetUserID(ctx context.Context) string {
+	userID, _ := ctx.Value(contextKeyUserID).(string)
+	return userID
+}
+
+// GetTenantID returns the ID of the tenant of the authenticated user of a call
+func GetTenantID(ctx context.Context) string {
+	tenantID, _ := ctx.Value(contextKeyTenantID).(string)
+	return tenantID
+}
+
+// GetUserRoles returns the roles of the authenticated user of a call
+func GetUserRoles(ctx context.Context) []string {
+	roles, _ := ctx.Value(contextKeyRoles).([]string)
+	return roles
+}
+
+// contextServerStream replaces the context of a server stream
+type contextServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the replaced context
+func (s *contextServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// MetricsUnaryInterceptor counts unary calls by method and status code and records their duration
+func MetricsUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		observeCall(info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+// MetricsStreamInterceptor counts streaming calls by method and status code and records their duration
+func MetricsStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, stream)
+		observeCall(info.FullMethod, start, err)
+		return err
+	}
+}
+
+// observeCall records the metrics of a finished call
+func observeCall(method string, start time.Time, err error) {
+	metrics.IncGRPCRequests(method, status.Code(err).String())
+	metrics.ObserveGRPCRequestDuration(method, time.Since(start))
+}
+
+// RecoveryUnaryInterceptor recovers panics of unary calls, which grpc-go does not, so that a failing
+// call is answered with an Internal status instead of crashing the process
+func RecoveryUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recoveredStatus(ctx, info.FullMethod, r)
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// RecoveryStreamInterceptor applies the recovery of RecoveryUnaryInterceptor to streaming calls
+func RecoveryStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recoveredStatus(stream.Context(), info.FullMethod, r)
+			}
+		}()
+		return handler(srv, stream)
+	}
+}
+
+// recoveredStatus logs a recovered panic with its stack trace and returns the status of the call
+func recoveredStatus(ctx context.Context, method string, r interface{}) error {
+	logger.ErrorContext(ctx, "Panic recovered in gRPC call",
+		"method", method,
+		"panic", fmt.Sprint(r),
+		"stack", string(debug.Stack()))
+	return status.Error(codes.Internal, "internal error")
+}
+
+// AuthUnaryInterceptor validates the JWT in the authorization metadata of unary calls and puts the
+// caller's user, tenant and roles into the call's context
+func AuthUnaryInterceptor(authService services.AuthService) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if strings.HasPrefix(info.FullMethod, healthServicePrefix) {
+			return handler(ctx, req)
+		}
+
+		ctx, err := authenticate(ctx, authService)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// AuthStreamInterceptor validates the JWT in the authorization metadata of streaming calls and
+// puts the caller's user, tenant and roles into the stream's context
+func AuthStreamInterceptor(authService services.AuthService) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if strings.HasPrefix(info.FullMethod, healthServicePrefix) {
+			return handler(srv, stream)
+		}
+
+		ctx, err := authenticate(stream.Context(), authService)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &contextServerStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+// authenticate validates the bearer token of a call and returns a context carrying the caller
+func authenticate(ctx context.Context, authService services.AuthService) (context.Context, error) {
+	token := bearerToken(ctx)
+	if token == "" {
+		logger.InfoContext(ctx, "gRPC authentication failed: missing or invalid token format")
+		return nil, status.Error(codes.Unauthenticated, "missing or invalid authentication token")
+	}
+
+	tenantID, roles, err := authService.ValidateToken(ctx, token)
+	if err != nil {
+		logger.InfoContext(ctx, "gRPC authentication failed: invalid token", "error", err.Error())
+		return nil, status.Error(codes.Unauthenticated, "invalid authentication token")
+	}
+
+	// The token was verified above; it is only parsed again for its subject
+	claims := jwt.MapClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(token, claims); err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid authentication token")
+	}
+	userID, err := claims.GetSubject()
+	if err != nil || userID == "" {
+		return nil, status.Error(codes.Unauthenticated, "invalid authentication token")
+	}
+
+	ctx = context.WithValue(ctx, contextKeyUserID, userID)
+	ctx = context.WithValue(ctx, contextKeyTenantID, tenantID)
+	ctx = context.WithValue(ctx, contextKeyRoles, roles)
+
+	// Downloads from folders requiring a recent sign-in check when the session was signed in to
+	if authTime, ok := claims["auth_time"].(float64); ok {
+		ctx = services.ContextWithAuthenticatedAt(ctx, time.Unix(int64(authTime), 0))
+	}
+	return ctx, nil
+}
+
+// bearerToken returns the bearer token in the authorization metadata of a call
+func bearerToken(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	values := md.Get(authorizationMetadataKey)
+	if len(values) == 0 || !strings.HasPrefix(values[0], bearerPrefix) {
+		return ""
+	}
+	return strings.TrimSpace(strings.TrimPrefix(values[0], bearerPrefix))
+}
+
+// TenantIsolationUnaryInterceptor rejects unary calls without a tenant and calls whose x-tenant-id
+// metadata names another tenant than the caller's token. Services only ever use the tenant of the
+// token, so the metadata cannot widen access; rejecting a mismatch surfaces misrouted calls early.
+func TenantIsolationUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if strings.HasPrefix(info.FullMethod, healthServicePrefix) {
+			return handler(ctx, req)
+		}
+		if err := checkTenant(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// TenantIsolationStreamInterceptor applies the checks of TenantIsolationUnaryInterceptor to
+// streaming calls
+func TenantIsolationStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if strings.HasPrefix(info.FullMethod, healthServicePrefix) {
+			return handler(srv, stream)
+		}
+		if err := checkTenant(stream.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, stream)
+	}
+}
+
+// checkTenant checks that a call has a tenant that matches its x-tenant-id metadata, if any
+func checkTenant(ctx context.Context, method string) error {
+	tenantID := GetTenantID(ctx)
+	if tenantID == "" {
+		logger.ErrorContext(ctx, "Tenant context missing in gRPC call", "method", method)
+		return status.Error(codes.Unauthenticated, "tenant context required")
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, requested := range md.Get(tenantIDMetadataKey) {
+		if requested != tenantID {
+			logger.WarnContext(ctx, "Tenant mismatch detected in gRPC call",
+				"method", method,
+				"user_id", GetUserID(ctx),
+				"user_tenant_id", tenantID,
+				"requested_tenant_id", requested)
+			return status.Error(codes.PermissionDenied, "access to the requested tenant is not allowed")
+		}
+	}
+	return nil
+}
+
+// NetworkPolicyUnaryInterceptor rejects unary calls from networks and countries the network policy
+// of the caller's tenant does not allow. It must run after authentication, which sets the tenant.
+// The client address is the peer address of the connection, as the gRPC server is reached directly.
+func NetworkPolicyUnaryInterceptor(networkPolicyService services.NetworkPolicyService) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if strings.HasPrefix(info.FullMethod, healthServicePrefix) {
+			return handler(ctx, req)
+		}
+		if err := checkNetworkPolicy(ctx, networkPolicyService); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// NetworkPolicyStreamInterceptor applies the checks of NetworkPolicyUnaryInterceptor to streaming calls
+func NetworkPolicyStreamInterceptor(networkPolicyService services.NetworkPolicyService) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if strings.HasPrefix(info.FullMethod, healthServicePrefix) {
+			return handler(srv, stream)
+		}
+		if err := checkNetworkPolicy(stream.Context(), networkPolicyService); err != nil {
+			return err
+		}
+		return handler(srv, stream)
+	}
+}
+
+// checkNetworkPolicy checks that the tenant's network policy allows the peer address of a call
+func checkNetworkPolicy(ctx context.Context, networkPolicyService services.NetworkPolicyService) error {
+	tenantID := GetTenantID(ctx)
+	if tenantID == "" {
+		return nil
+	}
+	return toStatus(networkPolicyService.CheckAccess(ctx, tenantID, GetUserID(ctx), peerIP(ctx)))
+}
+
+// peerIP returns the address of the peer of a call, or nil when it has no IP address
+func peerIP(ctx context.Context) net.IP {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return nil
+	}
+	if addr, ok := p.Addr.(*net.TCPAddr); ok {
+		return addr.IP
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return nil
+	}
+	return net.ParseIP(host)
+}
@@ -0,0 +1,192 @@
+package grpcapi
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"       // v5.0.0+
+	"github.com/stretchr/testify/assert" // v1.8.0+
+	"github.com/stretchr/testify/mock"   // v1.8.0+
+	"github.com/stretchr/testify/suite"  // v1.8.0+
+	"google.golang.org/grpc"             // v1.53.0+
+	"google.golang.org/grpc/codes"       // v1.53.0+
+	"google.golang.org/grpc/metadata"    // v1.53.0+
+	"google.golang.org/grpc/peer"        // v1.53.0+
+	"google.golang.org/grpc/status"      // v1.53.0+
+
+	"../../domain/services" // For mocking the authentication service in tests
+	"../../pkg/errors"      // For returning typed errors from mocks
+)
+
+// MockAuthService is a mock implementation of the AuthService interface for testing
+type MockAuthService struct {
+	services.AuthService
+	mock.Mock
+}
+
+// ValidateToken mocks the ValidateToken method of AuthService
+func (m *MockAuthService) ValidateToken(ctx context.Context, token string) (string, []string, error) {
+	args := m.Called(ctx, token)
+	roles, _ := args.Get(1).([]string)
+	return args.String(0), roles, args.Error(2)
+}
+
+// MockNetworkPolicyService is a mock implementation of the NetworkPolicyService interface for testing
+type MockNetworkPolicyService struct {
+	mock.Mock
+}
+
+// CheckAccess mocks the CheckAccess method of NetworkPolicyService
+func (m *MockNetworkPolicyService) CheckAccess(ctx context.Context, tenantID, userID string, ip net.IP) error {
+	args := m.Called(ctx, tenantID, userID, ip)
+	return args.Error(0)
+}
+
+// InterceptorSuite is a test suite for the gRPC interceptors
+type InterceptorSuite struct {
+	suite.Suite
+	mockAuthService *MockAuthService
+	interceptor     grpc.UnaryServerInterceptor
+	token           string
+}
+
+// SetupTest chains the authentication and tenant isolation interceptors around a fresh mock
+func (s *InterceptorSuite) SetupTest() {
+	s.mockAuthService = new(MockAuthService)
+	auth := AuthUnaryInterceptor(s.mockAuthService)
+	tenant := TenantIsolationUnaryInterceptor()
+	s.interceptor = func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return auth(ctx, req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return tenant(ctx, req, info, handler)
+		})
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"sub": "user-1"}).SignedString([]byte("test-secret"))
+	s.Require().NoError(err)
+	s.token = token
+}
+
+// call runs the interceptors for a method with the given metadata and returns the handler's context
+func (s *InterceptorSuite) call(method string, md metadata.MD) (context.Context, error) {
+	var handlerCtx context.Context
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+	_, err := s.interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		handlerCtx = ctx
+		return nil, nil
+	})
+	return handlerCtx, err
+}
+
+// TestAuthenticatedCall tests that a valid token puts the caller into the context
+func (s *InterceptorSuite) TestAuthenticatedCall() {
+	s.mockAuthService.On("ValidateToken", mock.Anything, s.token).Return("tenant-1", []string{"reader"}, nil)
+
+	ctx, err := s.call("/dms.v1.DocumentService/GetDocument", metadata.Pairs("authorization", "Bearer "+s.token))
+
+	s.Require().NoError(err)
+	assert.Equal(s.T(), "user-1", GetUserID(ctx))
+	assert.Equal(s.T(), "tenant-1", GetTenantID(ctx))
+	assert.Equal(s.T(), []string{"reader"}, GetUserRoles(ctx))
+}
+
+// TestMissingToken tests that calls without a token are rejected
+func (s *InterceptorSuite) TestMissingToken() {
+	_, err := s.call("/dms.v1.DocumentService/GetDocument", metadata.MD{})
+
+	assert.Equal(s.T(), codes.Unauthenticated, status.Code(err))
+	s.mockAuthService.AssertNotCalled(s.T(), "ValidateToken", mock.Anything, mock.Anything)
+}
+
+// TestInvalidToken tests that calls with a token the auth service rejects are rejected
+func (s *InterceptorSuite) TestInvalidToken() {
+	s.mockAuthService.On("ValidateToken", mock.Anything, s.token).Return("", nil, errors.NewAuthenticationError("token expired"))
+
+	_, err := s.call("/dms.v1.DocumentService/GetDocument", metadata.Pairs("authorization", "Bearer "+s.token))
+
+	assert.Equal(s.T(), codes.Unauthenticated, status.Code(err))
+}
+
+// TestTenantMismatch tests that calls naming another tenant than their token are rejected
+func (s *InterceptorSuite) TestTenantMismatch() {
+	s.mockAuthService.On("ValidateToken", mock.Anything, s.token).Return("tenant-1", []string{"reader"}, nil)
+
+	_, err := s.call("/dms.v1.DocumentService/GetDocument", metadata.Pairs(
+		"authorization", "Bearer "+s.token,
+		"x-tenant-id", "tenant-2"))
+
+	assert.Equal(s.T(), codes.PermissionDenied, status.Code(err))
+}
+
+// TestHealthCheckSkipsAuthentication tests that health checks need no token
+func (s *InterceptorSuite) TestHealthCheckSkipsAuthentication() {
+	_, err := s.call("/grpc.health.v1.Health/Check", metadata.MD{})
+
+	assert.NoError(s.T(), err)
+}
+
+// TestToStatus tests the mapping of application errors to gRPC status codes
+func TestToStatus(t *testing.T) {
+	assert.Equal(t, codes.InvalidArgument, status.Code(toStatus(errors.NewValidationError("bad"))))
+	assert.Equal(t, codes.NotFound, status.Code(toStatus(errors.Wrap(errors.NewResourceNotFoundError("missing"), "failed to get document"))))
+	assert.Equal(t, codes.PermissionDenied, status.Code(toStatus(errors.NewAuthorizationError("denied"))))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(toStatus(errors.NewStorageQuotaExceededError("full"))))
+	assert.Equal(t, codes.Internal, status.Code(toStatus(errors.NewInternalError("database password is wrong"))))
+	assert.Equal(t, "internal error", status.Convert(toStatus(errors.NewInternalError("database password is wrong"))).Message())
+}
+
+// TestNetworkPolicyInterceptor tests that calls are checked against the tenant's network policy
+// with the peer address of the connection
+func TestNetworkPolicyInterceptor(t *testing.T) {
+	networkPolicyService := new(MockNetworkPolicyService)
+	interceptor := NetworkPolicyUnaryInterceptor(networkPolicyService)
+	info := &grpc.UnaryServerInfo{FullMethod: "/dms.v1.DocumentService/GetDocument"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+
+	ctx := context.WithValue(context.Background(), contextKeyTenantID, "tenant-1")
+	ctx = context.WithValue(ctx, contextKeyUserID, "user-1")
+	allowed := peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.5"), Port: 40000}})
+	refused := peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("203.0.113.7"), Port: 40000}})
+
+	networkPolicyService.On("CheckAccess", mock.Anything, "tenant-1", "user-1", net.ParseIP("10.0.0.5")).Return(nil)
+	networkPolicyService.On("CheckAccess", mock.Anything, "tenant-1", "user-1", net.ParseIP("203.0.113.7")).Return(services.ErrNetworkAccessDenied)
+
+	resp, err := interceptor(allowed, nil, info, handler)
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+
+	_, err = interceptor(refused, nil, info, handler)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/grpc.health.v1.Health/Check"}, handler)
+	assert.NoError(t, err)
+	networkPolicyService.AssertNumberOfCalls(t, "CheckAccess", 2)
+}
+
+// TestRecoveryInterceptors tests that panics of calls are answered with an Internal status
+func TestRecoveryInterceptors(t *testing.T) {
+	unary := RecoveryUnaryInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/dms.v1.DocumentService/DeleteDocument"}
+	_, err := unary(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		panic("implement me")
+	})
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Equal(t, "internal error", status.Convert(err).Message())
+
+	resp, err := unary(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+
+	stream := RecoveryStreamInterceptor()
+	err = stream(nil, &contextServerStream{ctx: context.Background()}, &grpc.StreamServerInfo{FullMethod: "/dms.v1.DocumentService/DownloadDocument"}, func(srv interface{}, stream grpc.ServerStream) error {
+		panic("implement me")
+	})
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
+
+// TestInterceptorSuite runs the interceptor test suite
+func TestInterceptorSuite(t *testing.T) {
+	suite.Run(t, new(InterceptorSuite))
+}
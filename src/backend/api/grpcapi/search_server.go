@@ -0,0 +1,59 @@
+package grpcapi
+
+import (
+	"context" // standard library
+
+	"google.golang.org/grpc/codes"  // v1.53.0+
+	"google.golang.org/grpc/status" // v1.53.0+
+
+	"../../application/usecases"
+	"../../domain/models"
+	"../../pkg/utils"
+	dmsv1 "./dmsv1"
+)
+
+// SearchServer implements the SearchService of the gRPC API on top of the search use case
+type SearchServer struct {
+	dmsv1.UnimplementedSearchServiceServer
+	searchUseCase usecases.SearchUseCase
+}
+
+// NewSearchServer creates a new SearchServer
+func NewSearchServer(searchUseCase usecases.SearchUseCase) *SearchServer {
+	return &SearchServer{
+		searchUseCase: searchUseCase,
+	}
+}
+
+// Search finds documents by content, metadata, or both, optionally within a folder
+func (s *SearchServer) Search(ctx context.Context, req *dmsv1.SearchRequest) (*dmsv1.SearchResponse, error) {
+	tenantID := GetTenantID(ctx)
+	pagination := paginationFromProto(req.GetPage())
+	query, metadata := req.GetQuery(), req.GetMetadata()
+
+	var result utils.PaginatedResult[models.Document]
+	var err error
+	switch {
+	case req.GetFolderId() != "":
+		if len(metadata) > 0 {
+			return nil, status.Error(codes.InvalidArgument, "metadata cannot be combined with a folder")
+		}
+		result, err = s.searchUseCase.SearchInFolder(ctx, req.GetFolderId(), query, tenantID, pagination)
+	case query != "" && len(metadata) > 0:
+		result, err = s.searchUseCase.CombinedSearch(ctx, query, metadata, nil, tenantID, pagination)
+	case query != "":
+		result, err = s.searchUseCase.SearchByContent(ctx, query, tenantID, pagination)
+	case len(metadata) > 0:
+		result, err = s.searchUseCase.SearchByMetadata(ctx, metadata, nil, tenantID, pagination)
+	default:
+		return nil, status.Error(codes.InvalidArgument, "a query or metadata is required")
+	}
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &dmsv1.SearchResponse{
+		Documents: documentsToProto(result.Items),
+		PageInfo:  pageInfoToProto(result.Pagination),
+	}, nil
+}
@@ -0,0 +1,94 @@
+package grpcapi
+
+import (
+	"fmt" // standard library
+
+	"google.golang.org/grpc"                                // v1.53.0+
+	"google.golang.org/grpc/codes"                          // v1.53.0+
+	"google.golang.org/grpc/health"                         // v1.53.0+
+	healthpb "google.golang.org/grpc/health/grpc_health_v1" // v1.53.0+
+	"google.golang.org/grpc/status"                         // v1.53.0+
+
+	"../../application/usecases"
+	"../../domain/services"
+	"../../pkg/errors"
+	dmsv1 "./dmsv1"
+)
+
+// NewServer creates a gRPC server with the document, folder and search services and the standard
+// health service registered. Every call except health checks must carry a valid JWT and is scoped
+// to the tenant of its token. Panics of calls are recovered into Internal statuses.
+func NewServer(
+	authService services.AuthService,
+	networkPolicyService services.NetworkPolicyService,
+	documentUseCase usecases.DocumentUseCase,
+	folderUseCase *usecases.FolderUseCase,
+	searchUseCase usecases.SearchUseCase,
+) (*grpc.Server, error) {
+	if authService == nil {
+		return nil, fmt.Errorf("authService cannot be nil")
+	}
+	if networkPolicyService == nil {
+		return nil, fmt.Errorf("networkPolicyService cannot be nil")
+	}
+	if documentUseCase == nil {
+		return nil, fmt.Errorf("documentUseCase cannot be nil")
+	}
+	if folderUseCase == nil {
+		return nil, fmt.Errorf("folderUseCase cannot be nil")
+	}
+	if searchUseCase == nil {
+		return nil, fmt.Errorf("searchUseCase cannot be nil")
+	}
+
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			MetricsUnaryInterceptor(),
+			RecoveryUnaryInterceptor(),
+			AuthUnaryInterceptor(authService),
+			TenantIsolationUnaryInterceptor(),
+			NetworkPolicyUnaryInterceptor(networkPolicyService),
+		),
+		grpc.ChainStreamInterceptor(
+			MetricsStreamInterceptor(),
+			RecoveryStreamInterceptor(),
+			AuthStreamInterceptor(authService),
+			TenantIsolationStreamInterceptor(),
+			NetworkPolicyStreamInterceptor(networkPolicyService),
+		),
+	)
+
+	dmsv1.RegisterDocumentServiceServer(server, NewDocumentServer(documentUseCase, folderUseCase))
+	dmsv1.RegisterFolderServiceServer(server, NewFolderServer(folderUseCase))
+	dmsv1.RegisterSearchServiceServer(server, NewSearchServer(searchUseCase))
+	healthpb.RegisterHealthServer(server, health.NewServer())
+
+	return server, nil
+}
+
+// toStatus converts an application error into a gRPC status error. Internal errors are not
+// described to clients.
+func toStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	switch errors.GetErrorType(err) {
+	case errors.ErrorTypeValidation:
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.ErrorTypeNotFound:
+		return status.Error(codes.NotFound, err.Error())
+	case errors.ErrorTypeAuthentication:
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.ErrorTypeAuthorization:
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.ErrorTypeSecurity:
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.ErrorTypeQuotaExceeded:
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.ErrorTypeDependency:
+		return status.Error(codes.Unavailable, "a dependency is unavailable, please retry")
+	default:
+		return status.Error(codes.Internal, "internal error")
+	}
+}
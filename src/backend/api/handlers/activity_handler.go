@@ -0,0 +1,158 @@
+// Package handlers implements HTTP handlers for the activity timelines in the Document Management Platform.
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin" // v1.9.0+
+
+	"../../application/usecases"
+	"../../domain/models"
+	"../../pkg/errors"
+	"../../pkg/logger"
+	"../../pkg/utils"
+	"../dto"
+	"../middleware"
+)
+
+// ActivityHandler handles HTTP requests for the activity timelines of documents and folders
+type ActivityHandler struct {
+	activityUseCase usecases.ActivityUseCase
+}
+
+// NewActivityHandler creates a new ActivityHandler instance
+func NewActivityHandler(activityUseCase usecases.ActivityUseCase) (*ActivityHandler, error) {
+	if activityUseCase == nil {
+		return nil, errors.NewValidationError("activity use case cannot be nil")
+	}
+
+	return &ActivityHandler{
+		activityUseCase: activityUseCase,
+	}, nil
+}
+
+// RegisterRoutes registers activity timeline routes with the provided router group
+func (h *ActivityHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/documents/:id/activity", h.ListDocumentActivity)
+	router.GET("/folders/:id/activity", h.ListFolderActivity)
+}
+
+// ListDocumentActivity handles requests to list the activity of a document, newest first
+func (h *ActivityHandler) ListDocumentActivity(c *gin.Context) {
+	tenantID, userID, resourceID, ok := h.getResourceParams(c, models.ResourceTypeDocument)
+	if !ok {
+		return
+	}
+
+	page, pageSize := h.getPaginationParams(c)
+
+	// Call use case to list the activity of the document
+	result, err := h.activityUseCase.ListDocumentActivity(c.Request.Context(), resourceID, tenantID, userID, page, pageSize)
+	h.respond(c, result, err)
+}
+
+// ListFolderActivity handles requests to list the activity of a folder and of the documents in it,
+// newest first
+func (h *ActivityHandler) ListFolderActivity(c *gin.Context) {
+	tenantID, userID, resourceID, ok := h.getResourceParams(c, models.ResourceTypeFolder)
+	if !ok {
+		return
+	}
+
+	page, pageSize := h.getPaginationParams(c)
+
+	// Call use case to list the activity of the folder
+	result, err := h.activityUseCase.ListFolderActivity(c.Request.Context(), resourceID, tenantID, userID, page, pageSize)
+	h.respond(c, result, err)
+}
+
+// respond writes a page of activity, or the error listing it
+func (h *ActivityHandler) respond(c *gin.Context, result utils.PaginatedResult[models.Activity], err error) {
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	activities := make([]dto.ActivityDTO, len(result.Items))
+	for i, activity := range result.Items {
+		activities[i] = dto.ToActivityDTO(activity)
+	}
+	c.JSON(http.StatusOK, dto.NewPaginatedResponse(activities, result.Pagination))
+}
+
+// getResourceParams extracts the tenant and user IDs from the request context and the document or
+// folder ID from the request path
+func (h *ActivityHandler) getResourceParams(c *gin.Context, resourceType string) (string, string, string, bool) {
+	log := logger.WithContext(c.Request.Context())
+
+	tenantID := middleware.GetTenantID(c)
+	userID := middleware.GetUserID(c)
+	if tenantID == "" || userID == "" {
+		log.Error("tenant or user ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrUserContextRequired,
+		))
+		return "", "", "", false
+	}
+
+	resourceID := c.Param("id")
+	if resourceID == "" {
+		log.Error(resourceType + " ID missing in request path")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			errors.NewValidationError(resourceType+" ID is required"),
+			map[string]string{"id": "required"},
+		))
+		return "", "", "", false
+	}
+
+	return tenantID, userID, resourceID, true
+}
+
+// getPaginationParams extracts and validates pagination parameters from the request
+func (h *ActivityHandler) getPaginationParams(c *gin.Context) (int, int) {
+	page := 1
+	if p, err := strconv.Atoi(c.Query("page")); err == nil && p > 0 {
+		page = p
+	}
+
+	pageSize := defaultPageSize
+	if ps, err := strconv.Atoi(c.Query("pageSize")); err == nil && ps > 0 {
+		pageSize = ps
+	}
+
+	// Cap pageSize at maximum
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	return page, pageSize
+}
+
+// handleError handles errors and returns appropriate HTTP responses
+func (h *ActivityHandler) handleError(c *gin.Context, err error) {
+	if errors.IsValidationError(err) {
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			err,
+			map[string]string{},
+		))
+		return
+	}
+
+	if errors.IsResourceNotFoundError(err) {
+		c.JSON(http.StatusNotFound, dto.NewResourceNotFoundErrorResponse(c.Request.Context(), err))
+		return
+	}
+
+	if errors.IsAuthorizationError(err) {
+		c.JSON(http.StatusForbidden, dto.NewAuthorizationErrorResponse(c.Request.Context(), err))
+		return
+	}
+
+	// Default to internal server error
+	logger.WithError(err).Error("internal server error")
+	c.JSON(http.StatusInternalServerError, dto.NewInternalErrorResponse(c.Request.Context(), err))
+}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"../../domain/models"
+	apperrors "../../pkg/errors"
+	"../../pkg/utils"
+)
+
+// MockActivityUseCase is a mock implementation of the ActivityUseCase interface
+type MockActivityUseCase struct {
+	mock.Mock
+}
+
+func (m *MockActivityUseCase) ListDocumentActivity(ctx context.Context, id, tenantID, userID string, page int, pageSize int) (utils.PaginatedResult[models.Activity], error) {
+	args := m.Called(ctx, id, tenantID, userID, page, pageSize)
+	return args.Get(0).(utils.PaginatedResult[models.Activity]), args.Error(1)
+}
+
+func (m *MockActivityUseCase) ListFolderActivity(ctx context.Context, id, tenantID, userID string, page int, pageSize int) (utils.PaginatedResult[models.Activity], error) {
+	args := m.Called(ctx, id, tenantID, userID, page, pageSize)
+	return args.Get(0).(utils.PaginatedResult[models.Activity]), args.Error(1)
+}
+
+// ActivityHandlerSuite defines the test suite
+type ActivityHandlerSuite struct {
+	suite.Suite
+	router          *gin.Engine
+	recorder        *httptest.ResponseRecorder
+	activityUseCase *MockActivityUseCase
+	activityHandler *ActivityHandler
+}
+
+// SetupTest is called before each test
+func (s *ActivityHandlerSuite) SetupTest() {
+	// Create a gin router in test mode
+	gin.SetMode(gin.TestMode)
+	s.router = gin.New()
+	s.recorder = httptest.NewRecorder()
+
+	// Create the activity handler with a mock use case
+	s.activityUseCase = new(MockActivityUseCase)
+	handler, err := NewActivityHandler(s.activityUseCase)
+	s.Require().NoError(err)
+	s.activityHandler = handler
+
+	// Set up a router group with an authenticated user and the activity handler routes
+	group := s.router.Group("/api/v1")
+	group.Use(func(c *gin.Context) {
+		c.Set("tenant_id", "tenant-123")
+		c.Set("user_id", "user-123")
+		c.Next()
+	})
+	s.activityHandler.RegisterRoutes(group)
+}
+
+// TestListDocumentActivity_Success tests listing the activity of a document with pagination
+func (s *ActivityHandlerSuite) TestListDocumentActivity_Success() {
+	activities := []models.Activity{{
+		ID:           "log-1",
+		Kind:         models.ActivityKindRenamed,
+		ActorID:      "user-456",
+		ResourceType: models.ResourceTypeDocument,
+		ResourceID:   "doc-123",
+		Description:  "renamed Q1.pdf to Q1 report.pdf",
+		OccurredAt:   time.Now(),
+	}}
+	s.activityUseCase.On("ListDocumentActivity", mock.Anything, "doc-123", "tenant-123", "user-123", 2, 10).
+		Return(utils.NewPaginatedResult(activities, utils.NewPagination(2, 10), 11), nil)
+
+	req, _ := http.NewRequest("GET", "/api/v1/documents/doc-123/activity?page=2&pageSize=10", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusOK, s.recorder.Code)
+	s.Contains(s.recorder.Body.String(), `"kind":"renamed"`)
+	s.Contains(s.recorder.Body.String(), `"description":"renamed Q1.pdf to Q1 report.pdf"`)
+	s.activityUseCase.AssertExpectations(s.T())
+}
+
+// TestListFolderActivity_Forbidden tests listing the activity of a folder the user cannot read
+func (s *ActivityHandlerSuite) TestListFolderActivity_Forbidden() {
+	s.activityUseCase.On("ListFolderActivity", mock.Anything, "folder-123", "tenant-123", "user-123", 1, 20).
+		Return(utils.PaginatedResult[models.Activity]{}, apperrors.NewAuthorizationError("permission denied"))
+
+	req, _ := http.NewRequest("GET", "/api/v1/folders/folder-123/activity", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusForbidden, s.recorder.Code)
+	s.activityUseCase.AssertExpectations(s.T())
+}
+
+// TestActivityHandlerSuite runs the test suite
+func TestActivityHandlerSuite(t *testing.T) {
+	suite.Run(t, new(ActivityHandlerSuite))
+}
@@ -0,0 +1,235 @@
+// Package handlers implements HTTP handlers for API key management in the Document Management Platform.
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin" // v1.9.0+
+
+	"../dto"
+	"../middleware"
+	"../validators"
+	"../../application/usecases"
+	"../../pkg/errors"
+	"../../pkg/logger"
+)
+
+// APIKeyHandler handles HTTP requests for creating, rotating and revoking a tenant's API keys
+type APIKeyHandler struct {
+	apiKeyUseCase usecases.APIKeyUseCase
+}
+
+// NewAPIKeyHandler creates a new APIKeyHandler instance
+func NewAPIKeyHandler(apiKeyUseCase usecases.APIKeyUseCase) (*APIKeyHandler, error) {
+	if apiKeyUseCase == nil {
+		return nil, errors.NewValidationError("API key use case cannot be nil")
+	}
+
+	return &APIKeyHandler{
+		apiKeyUseCase: apiKeyUseCase,
+	}, nil
+}
+
+// RegisterRoutes registers API key routes with the provided router group
+func (h *APIKeyHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.POST("/api-keys", h.CreateAPIKey)
+	router.GET("/api-keys", h.ListAPIKeys)
+	router.GET("/api-keys/:id", h.GetAPIKey)
+	router.POST("/api-keys/:id/rotate", h.RotateAPIKey)
+	router.DELETE("/api-keys/:id", h.RevokeAPIKey)
+}
+
+// CreateAPIKey handles API key creation requests
+func (h *APIKeyHandler) CreateAPIKey(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Bind request body to DTO
+	var req dto.CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.WithError(err).Error("failed to bind request body")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			validators.BindingError(err),
+			nil,
+		))
+		return
+	}
+
+	// Call use case to create the API key
+	expiresIn := time.Duration(req.ExpiresInDays) * 24 * time.Hour
+	key, secret, err := h.apiKeyUseCase.CreateAPIKey(c.Request.Context(), tenantID, middleware.GetUserID(c), req.Name, req.Role, expiresIn)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	// The secret is only available now, so hand it out once
+	response := dto.ToAPIKeyDTO(key)
+	response.Key = secret
+	c.JSON(http.StatusCreated, dto.NewDataResponse(response))
+}
+
+// ListAPIKeys handles requests to list the tenant's API keys
+func (h *APIKeyHandler) ListAPIKeys(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	page, pageSize := h.getPaginationParams(c)
+
+	// Call use case to list API keys
+	result, err := h.apiKeyUseCase.ListAPIKeys(c.Request.Context(), tenantID, page, pageSize)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	// Convert domain models to DTOs and return
+	keys := dto.ToAPIKeyListDTO(result)
+	c.JSON(http.StatusOK, dto.NewPaginatedResponse(keys, result.Pagination))
+}
+
+// GetAPIKey handles API key retrieval requests
+func (h *APIKeyHandler) GetAPIKey(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Call use case to get the API key
+	key, err := h.apiKeyUseCase.GetAPIKey(c.Request.Context(), c.Param("id"), tenantID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.ToAPIKeyDTO(key)))
+}
+
+// RotateAPIKey handles requests to replace an API key's secret
+func (h *APIKeyHandler) RotateAPIKey(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Call use case to rotate the API key
+	key, secret, err := h.apiKeyUseCase.RotateAPIKey(c.Request.Context(), c.Param("id"), tenantID, middleware.GetUserID(c))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	// The new secret is only available now, so hand it out once
+	response := dto.ToAPIKeyDTO(key)
+	response.Key = secret
+	c.JSON(http.StatusOK, dto.NewDataResponse(response))
+}
+
+// RevokeAPIKey handles requests to revoke an API key
+func (h *APIKeyHandler) RevokeAPIKey(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Call use case to revoke the API key
+	if err := h.apiKeyUseCase.RevokeAPIKey(c.Request.Context(), c.Param("id"), tenantID, middleware.GetUserID(c)); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewMessageResponse("API key revoked successfully"))
+}
+
+// getPaginationParams extracts and validates pagination parameters from the request
+func (h *APIKeyHandler) getPaginationParams(c *gin.Context) (int, int) {
+	page := 1
+	if p, err := strconv.Atoi(c.Query("page")); err == nil && p > 0 {
+		page = p
+	}
+
+	pageSize := defaultPageSize
+	if ps, err := strconv.Atoi(c.Query("pageSize")); err == nil && ps > 0 {
+		pageSize = ps
+	}
+
+	// Cap pageSize at maximum
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	return page, pageSize
+}
+
+// handleError handles errors and returns appropriate HTTP responses
+func (h *APIKeyHandler) handleError(c *gin.Context, err error) {
+	if errors.IsValidationError(err) {
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			err,
+			map[string]string{},
+		))
+		return
+	}
+
+	if errors.IsResourceNotFoundError(err) {
+		c.JSON(http.StatusNotFound, dto.NewResourceNotFoundErrorResponse(c.Request.Context(), err))
+		return
+	}
+
+	if errors.IsAuthorizationError(err) {
+		c.JSON(http.StatusForbidden, dto.NewAuthorizationErrorResponse(c.Request.Context(), err))
+		return
+	}
+
+	// Default to internal server error
+	logger.WithError(err).Error("internal server error")
+	c.JSON(http.StatusInternalServerError, dto.NewInternalErrorResponse(c.Request.Context(), err))
+}
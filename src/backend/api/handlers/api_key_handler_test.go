@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"../../domain/models"
+	apperrors "../../pkg/errors"
+	"../../pkg/utils"
+)
+
+// MockAPIKeyUseCase is a mock implementation of the APIKeyUseCase interface
+type MockAPIKeyUseCase struct {
+	mock.Mock
+}
+
+func (m *MockAPIKeyUseCase) CreateAPIKey(ctx context.Context, tenantID, userID, name, role string, expiresIn time.Duration) (*models.APIKey, string, error) {
+	args := m.Called(ctx, tenantID, userID, name, role, expiresIn)
+	if args.Get(0) == nil {
+		return nil, "", args.Error(2)
+	}
+	return args.Get(0).(*models.APIKey), args.String(1), args.Error(2)
+}
+
+func (m *MockAPIKeyUseCase) GetAPIKey(ctx context.Context, id, tenantID string) (*models.APIKey, error) {
+	args := m.Called(ctx, id, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyUseCase) ListAPIKeys(ctx context.Context, tenantID string, page, pageSize int) (utils.PaginatedResult[models.APIKey], error) {
+	args := m.Called(ctx, tenantID, page, pageSize)
+	return args.Get(0).(utils.PaginatedResult[models.APIKey]), args.Error(1)
+}
+
+func (m *MockAPIKeyUseCase) RotateAPIKey(ctx context.Context, id, tenantID, userID string) (*models.APIKey, string, error) {
+	args := m.Called(ctx, id, tenantID, userID)
+	if args.Get(0) == nil {
+		return nil, "", args.Error(2)
+	}
+	return args.Get(0).(*models.APIKey), args.String(1), args.Error(2)
+}
+
+func (m *MockAPIKeyUseCase) RevokeAPIKey(ctx context.Context, id, tenantID, userID string) error {
+	args := m.Called(ctx, id, tenantID, userID)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyUseCase) AuthenticateAPIKey(ctx context.Context, secret string) (*models.APIKey, error) {
+	args := m.Called(ctx, secret)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.APIKey), args.Error(1)
+}
+
+// APIKeyHandlerSuite defines the test suite
+type APIKeyHandlerSuite struct {
+	suite.Suite
+	router        *gin.Engine
+	recorder      *httptest.ResponseRecorder
+	apiKeyUseCase *MockAPIKeyUseCase
+	apiKeyHandler *APIKeyHandler
+}
+
+// SetupTest is called before each test
+func (s *APIKeyHandlerSuite) SetupTest() {
+	// Create a gin router in test mode
+	gin.SetMode(gin.TestMode)
+	s.router = gin.New()
+	s.recorder = httptest.NewRecorder()
+
+	// Create the API key handler with a mock use case
+	s.apiKeyUseCase = new(MockAPIKeyUseCase)
+	handler, err := NewAPIKeyHandler(s.apiKeyUseCase)
+	s.Require().NoError(err)
+	s.apiKeyHandler = handler
+
+	// Set up a router group with an authenticated tenant and the API key handler routes
+	group := s.router.Group("/api/v1")
+	group.Use(func(c *gin.Context) {
+		c.Set("tenant_id", "tenant-123")
+		c.Set("user_id", "user-123")
+		c.Next()
+	})
+	s.apiKeyHandler.RegisterRoutes(group)
+}
+
+// Helper function to create a test API key model
+func (s *APIKeyHandlerSuite) createTestAPIKey() *models.APIKey {
+	return &models.APIKey{
+		ID:        "key-123",
+		TenantID:  "tenant-123",
+		Name:      "CI pipeline",
+		Role:      "contributor",
+		KeyPrefix: "dmk_0123abcd",
+		CreatedBy: "user-123",
+		CreatedAt: time.Now(),
+	}
+}
+
+// TestCreateAPIKey_Success tests that the secret is returned on creation
+func (s *APIKeyHandlerSuite) TestCreateAPIKey_Success() {
+	s.apiKeyUseCase.On("CreateAPIKey", mock.Anything, "tenant-123", "user-123", "CI pipeline", "contributor", 30*24*time.Hour).
+		Return(s.createTestAPIKey(), "dmk_0123abcdsecret", nil)
+
+	body := `{"name":"CI pipeline","role":"contributor","expires_in_days":30}`
+	req, _ := http.NewRequest("POST", "/api/v1/api-keys", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusCreated, s.recorder.Code)
+	s.Contains(s.recorder.Body.String(), `"key":"dmk_0123abcdsecret"`)
+	s.Contains(s.recorder.Body.String(), `"active":true`)
+	s.apiKeyUseCase.AssertExpectations(s.T())
+}
+
+// TestCreateAPIKey_MissingRole tests creating a key without a role
+func (s *APIKeyHandlerSuite) TestCreateAPIKey_MissingRole() {
+	req, _ := http.NewRequest("POST", "/api/v1/api-keys", strings.NewReader(`{"name":"CI pipeline"}`))
+	req.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusBadRequest, s.recorder.Code)
+	s.apiKeyUseCase.AssertNotCalled(s.T(), "CreateAPIKey")
+}
+
+// TestGetAPIKey_HidesSecret tests that retrieving a key never includes its secret
+func (s *APIKeyHandlerSuite) TestGetAPIKey_HidesSecret() {
+	key := s.createTestAPIKey()
+	key.KeyHash = "stored-hash"
+	s.apiKeyUseCase.On("GetAPIKey", mock.Anything, "key-123", "tenant-123").Return(key, nil)
+
+	req, _ := http.NewRequest("GET", "/api/v1/api-keys/key-123", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusOK, s.recorder.Code)
+	s.NotContains(s.recorder.Body.String(), `"key":`)
+	s.NotContains(s.recorder.Body.String(), "stored-hash")
+}
+
+// TestRotateAPIKey_Success tests that the new secret is returned on rotation
+func (s *APIKeyHandlerSuite) TestRotateAPIKey_Success() {
+	s.apiKeyUseCase.On("RotateAPIKey", mock.Anything, "key-123", "tenant-123", "user-123").
+		Return(s.createTestAPIKey(), "dmk_0123abcdrotated", nil)
+
+	req, _ := http.NewRequest("POST", "/api/v1/api-keys/key-123/rotate", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusOK, s.recorder.Code)
+	s.Contains(s.recorder.Body.String(), `"key":"dmk_0123abcdrotated"`)
+}
+
+// TestRevokeAPIKey_NotFound tests revoking an unknown key
+func (s *APIKeyHandlerSuite) TestRevokeAPIKey_NotFound() {
+	s.apiKeyUseCase.On("RevokeAPIKey", mock.Anything, "key-999", "tenant-123", "user-123").
+		Return(apperrors.NewResourceNotFoundError("API key not found"))
+
+	req, _ := http.NewRequest("DELETE", "/api/v1/api-keys/key-999", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusNotFound, s.recorder.Code)
+}
+
+// TestAPIKeyHandlerSuite runs the test suite
+func TestAPIKeyHandlerSuite(t *testing.T) {
+	suite.Run(t, new(APIKeyHandlerSuite))
+}
@@ -0,0 +1,417 @@
+// Package handlers implements HTTP handlers for document approvals in the Document Management Platform.
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin" // v1.9.0+
+
+	"../../application/usecases"
+	"../../pkg/errors"
+	"../../pkg/logger"
+	"../dto"
+	"../middleware"
+	"../validators"
+)
+
+// ApprovalHandler handles HTTP requests for the approval workflows of folders and the approval
+// requests of documents
+type ApprovalHandler struct {
+	approvalUseCase usecases.ApprovalUseCase
+}
+
+// NewApprovalHandler creates a new ApprovalHandler instance
+func NewApprovalHandler(approvalUseCase usecases.ApprovalUseCase) (*ApprovalHandler, error) {
+	if approvalUseCase == nil {
+		return nil, errors.NewValidationError("approval use case cannot be nil")
+	}
+
+	return &ApprovalHandler{
+		approvalUseCase: approvalUseCase,
+	}, nil
+}
+
+// RegisterRoutes registers approval routes with the provided router group
+func (h *ApprovalHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.POST("/approval-workflows", h.CreateWorkflow)
+	router.GET("/approval-workflows", h.ListWorkflows)
+	router.GET("/approval-workflows/:id", h.GetWorkflow)
+	router.PUT("/approval-workflows/:id", h.UpdateWorkflow)
+	router.DELETE("/approval-workflows/:id", h.DeleteWorkflow)
+	router.GET("/folders/:id/approval-workflow", h.GetFolderWorkflow)
+	router.POST("/documents/:id/approval-requests", h.SubmitForApproval)
+	router.GET("/documents/:id/approval-requests", h.ListDocumentRequests)
+	router.GET("/me/approvals", h.ListAwaitingApproval)
+	router.GET("/approval-requests/:id", h.GetRequest)
+	router.POST("/approval-requests/:id/approve", h.Approve)
+	router.POST("/approval-requests/:id/reject", h.Reject)
+	router.POST("/approval-requests/:id/cancel", h.CancelRequest)
+}
+
+// CreateWorkflow handles requests to attach an approval chain to a folder
+func (h *ApprovalHandler) CreateWorkflow(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	tenantID, userID, ok := h.getUserParams(c)
+	if !ok {
+		return
+	}
+
+	var req dto.ApprovalWorkflowRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.WithError(err).Error("failed to bind request body")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			validators.BindingError(err),
+			nil,
+		))
+		return
+	}
+
+	// Call use case to create the workflow
+	workflow, err := h.approvalUseCase.CreateWorkflow(c.Request.Context(), dto.ToApprovalWorkflowDomain(&req, tenantID, userID))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.NewDataResponse(dto.ToApprovalWorkflowDTO(workflow)))
+}
+
+// ListWorkflows handles requests to list the tenant's approval workflows
+func (h *ApprovalHandler) ListWorkflows(c *gin.Context) {
+	tenantID, _, ok := h.getUserParams(c)
+	if !ok {
+		return
+	}
+
+	// Call use case to list the workflows
+	workflows, err := h.approvalUseCase.ListWorkflows(c.Request.Context(), tenantID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.ToApprovalWorkflowListDTO(workflows)))
+}
+
+// GetWorkflow handles approval workflow retrieval requests
+func (h *ApprovalHandler) GetWorkflow(c *gin.Context) {
+	tenantID, _, workflowID, ok := h.getIDParams(c, "approval workflow")
+	if !ok {
+		return
+	}
+
+	// Call use case to get the workflow
+	workflow, err := h.approvalUseCase.GetWorkflow(c.Request.Context(), workflowID, tenantID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.ToApprovalWorkflowDTO(workflow)))
+}
+
+// GetFolderWorkflow handles requests for the approval workflow of a folder, which clients use to
+// show who approves the documents submitted in the folder
+func (h *ApprovalHandler) GetFolderWorkflow(c *gin.Context) {
+	tenantID, _, folderID, ok := h.getIDParams(c, "folder")
+	if !ok {
+		return
+	}
+
+	// Call use case to get the folder's workflow
+	workflow, err := h.approvalUseCase.GetFolderWorkflow(c.Request.Context(), folderID, tenantID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.ToApprovalWorkflowDTO(workflow)))
+}
+
+// UpdateWorkflow handles requests to replace the name, mode and approvers of an approval workflow;
+// requests already submitted keep the chain they were submitted to
+func (h *ApprovalHandler) UpdateWorkflow(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	tenantID, userID, workflowID, ok := h.getIDParams(c, "approval workflow")
+	if !ok {
+		return
+	}
+
+	var req dto.ApprovalWorkflowRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.WithError(err).Error("failed to bind request body")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			validators.BindingError(err),
+			nil,
+		))
+		return
+	}
+
+	workflow := dto.ToApprovalWorkflowDomain(&req, tenantID, userID)
+	workflow.ID = workflowID
+
+	// Call use case to update the workflow
+	updated, err := h.approvalUseCase.UpdateWorkflow(c.Request.Context(), workflow)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.ToApprovalWorkflowDTO(updated)))
+}
+
+// DeleteWorkflow handles approval workflow deletion requests
+func (h *ApprovalHandler) DeleteWorkflow(c *gin.Context) {
+	tenantID, _, workflowID, ok := h.getIDParams(c, "approval workflow")
+	if !ok {
+		return
+	}
+
+	// Call use case to delete the workflow
+	if err := h.approvalUseCase.DeleteWorkflow(c.Request.Context(), workflowID, tenantID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewMessageResponse("Approval workflow deleted successfully"))
+}
+
+// SubmitForApproval handles requests to submit a document to the approval chain of its folder,
+// which locks the document until the request is decided or cancelled
+func (h *ApprovalHandler) SubmitForApproval(c *gin.Context) {
+	tenantID, userID, documentID, ok := h.getIDParams(c, "document")
+	if !ok {
+		return
+	}
+
+	var req dto.SubmitForApprovalRequest
+	if !h.bindOptionalJSON(c, &req) {
+		return
+	}
+
+	// Call use case to submit the document
+	request, err := h.approvalUseCase.SubmitForApproval(c.Request.Context(), documentID, req.Comment, tenantID, userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.NewDataResponse(dto.ToApprovalRequestDTO(request)))
+}
+
+// ListDocumentRequests handles requests to list the approval history of a document, newest first
+func (h *ApprovalHandler) ListDocumentRequests(c *gin.Context) {
+	tenantID, userID, documentID, ok := h.getIDParams(c, "document")
+	if !ok {
+		return
+	}
+
+	page, pageSize := h.getPaginationParams(c)
+
+	// Call use case to list the document's requests
+	result, err := h.approvalUseCase.ListDocumentRequests(c.Request.Context(), documentID, tenantID, userID, page, pageSize)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewPaginatedResponse(dto.ToApprovalRequestListDTO(result.Items), result.Pagination))
+}
+
+// ListAwaitingApproval handles requests to list the approval requests waiting for a decision of the
+// requesting user, oldest first
+func (h *ApprovalHandler) ListAwaitingApproval(c *gin.Context) {
+	tenantID, userID, ok := h.getUserParams(c)
+	if !ok {
+		return
+	}
+
+	page, pageSize := h.getPaginationParams(c)
+
+	// Call use case to list the user's pending approvals
+	result, err := h.approvalUseCase.ListAwaitingApproval(c.Request.Context(), tenantID, userID, page, pageSize)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewPaginatedResponse(dto.ToApprovalRequestListDTO(result.Items), result.Pagination))
+}
+
+// GetRequest handles approval request retrieval requests
+func (h *ApprovalHandler) GetRequest(c *gin.Context) {
+	tenantID, userID, requestID, ok := h.getIDParams(c, "approval request")
+	if !ok {
+		return
+	}
+
+	// Call use case to get the request
+	request, err := h.approvalUseCase.GetRequest(c.Request.Context(), requestID, tenantID, userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.ToApprovalRequestDTO(request)))
+}
+
+// Approve handles requests of an approver to approve an approval request
+func (h *ApprovalHandler) Approve(c *gin.Context) {
+	h.decide(c, true)
+}
+
+// Reject handles requests of an approver to reject an approval request
+func (h *ApprovalHandler) Reject(c *gin.Context) {
+	h.decide(c, false)
+}
+
+// decide records the decision of the requesting user on the approval request in the request path
+func (h *ApprovalHandler) decide(c *gin.Context, approve bool) {
+	tenantID, userID, requestID, ok := h.getIDParams(c, "approval request")
+	if !ok {
+		return
+	}
+
+	var req dto.ApprovalDecisionRequest
+	if !h.bindOptionalJSON(c, &req) {
+		return
+	}
+
+	// Call use case to record the decision
+	decide := h.approvalUseCase.Reject
+	if approve {
+		decide = h.approvalUseCase.Approve
+	}
+	request, err := decide(c.Request.Context(), requestID, req.Comment, tenantID, userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.ToApprovalRequestDTO(request)))
+}
+
+// CancelRequest handles requests of the submitter to withdraw a pending approval request
+func (h *ApprovalHandler) CancelRequest(c *gin.Context) {
+	tenantID, userID, requestID, ok := h.getIDParams(c, "approval request")
+	if !ok {
+		return
+	}
+
+	// Call use case to cancel the request
+	request, err := h.approvalUseCase.CancelRequest(c.Request.Context(), requestID, tenantID, userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.ToApprovalRequestDTO(request)))
+}
+
+// getUserParams extracts the tenant and user IDs from the request context
+func (h *ApprovalHandler) getUserParams(c *gin.Context) (string, string, bool) {
+	tenantID := middleware.GetTenantID(c)
+	userID := middleware.GetUserID(c)
+	if tenantID == "" || userID == "" {
+		logger.WithContext(c.Request.Context()).Error("tenant or user ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrUserContextRequired,
+		))
+		return "", "", false
+	}
+
+	return tenantID, userID, true
+}
+
+// getIDParams extracts the tenant and user IDs from the request context and the ID of the named
+// resource from the request path
+func (h *ApprovalHandler) getIDParams(c *gin.Context, resource string) (string, string, string, bool) {
+	tenantID, userID, ok := h.getUserParams(c)
+	if !ok {
+		return "", "", "", false
+	}
+
+	id := c.Param("id")
+	if id == "" {
+		logger.WithContext(c.Request.Context()).Error(resource + " ID missing in request path")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			errors.NewValidationError(resource+" ID is required"),
+			map[string]string{"id": "required"},
+		))
+		return "", "", "", false
+	}
+
+	return tenantID, userID, id, true
+}
+
+// bindOptionalJSON binds the request body to req; the comment is optional, so an empty body is accepted
+func (h *ApprovalHandler) bindOptionalJSON(c *gin.Context, req interface{}) bool {
+	if c.Request.ContentLength <= 0 {
+		return true
+	}
+
+	if err := c.ShouldBindJSON(req); err != nil {
+		logger.WithContext(c.Request.Context()).WithError(err).Error("failed to bind request body")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			validators.BindingError(err),
+			nil,
+		))
+		return false
+	}
+
+	return true
+}
+
+// getPaginationParams extracts and validates pagination parameters from the request
+func (h *ApprovalHandler) getPaginationParams(c *gin.Context) (int, int) {
+	page := 1
+	if p, err := strconv.Atoi(c.Query("page")); err == nil && p > 0 {
+		page = p
+	}
+
+	pageSize := defaultPageSize
+	if ps, err := strconv.Atoi(c.Query("pageSize")); err == nil && ps > 0 {
+		pageSize = ps
+	}
+
+	// Cap pageSize at maximum
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	return page, pageSize
+}
+
+// handleError handles errors and returns appropriate HTTP responses
+func (h *ApprovalHandler) handleError(c *gin.Context, err error) {
+	if errors.IsValidationError(err) {
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			err,
+			map[string]string{},
+		))
+		return
+	}
+
+	if errors.IsResourceNotFoundError(err) {
+		c.JSON(http.StatusNotFound, dto.NewResourceNotFoundErrorResponse(c.Request.Context(), err))
+		return
+	}
+
+	if errors.IsAuthorizationError(err) {
+		c.JSON(http.StatusForbidden, dto.NewAuthorizationErrorResponse(c.Request.Context(), err))
+		return
+	}
+
+	// Default to internal server error
+	logger.WithError(err).Error("internal server error")
+	c.JSON(http.StatusInternalServerError, dto.NewInternalErrorResponse(c.Request.Context(), err))
+}
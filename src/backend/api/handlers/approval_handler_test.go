@@ -0,0 +1,251 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"../../application/usecases"
+	"../../domain/models"
+	apperrors "../../pkg/errors"
+	"../../pkg/utils"
+)
+
+// MockApprovalUseCase is a mock implementation of the ApprovalUseCase interface
+type MockApprovalUseCase struct {
+	mock.Mock
+}
+
+func (m *MockApprovalUseCase) CreateWorkflow(ctx context.Context, workflow *models.ApprovalWorkflow) (*models.ApprovalWorkflow, error) {
+	args := m.Called(ctx, workflow)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ApprovalWorkflow), args.Error(1)
+}
+
+func (m *MockApprovalUseCase) GetWorkflow(ctx context.Context, id, tenantID string) (*models.ApprovalWorkflow, error) {
+	args := m.Called(ctx, id, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ApprovalWorkflow), args.Error(1)
+}
+
+func (m *MockApprovalUseCase) GetFolderWorkflow(ctx context.Context, folderID, tenantID string) (*models.ApprovalWorkflow, error) {
+	args := m.Called(ctx, folderID, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ApprovalWorkflow), args.Error(1)
+}
+
+func (m *MockApprovalUseCase) ListWorkflows(ctx context.Context, tenantID string) ([]*models.ApprovalWorkflow, error) {
+	args := m.Called(ctx, tenantID)
+	return args.Get(0).([]*models.ApprovalWorkflow), args.Error(1)
+}
+
+func (m *MockApprovalUseCase) UpdateWorkflow(ctx context.Context, workflow *models.ApprovalWorkflow) (*models.ApprovalWorkflow, error) {
+	args := m.Called(ctx, workflow)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ApprovalWorkflow), args.Error(1)
+}
+
+func (m *MockApprovalUseCase) DeleteWorkflow(ctx context.Context, id, tenantID string) error {
+	args := m.Called(ctx, id, tenantID)
+	return args.Error(0)
+}
+
+func (m *MockApprovalUseCase) SubmitForApproval(ctx context.Context, documentID, comment, tenantID, userID string) (*models.ApprovalRequest, error) {
+	args := m.Called(ctx, documentID, comment, tenantID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ApprovalRequest), args.Error(1)
+}
+
+func (m *MockApprovalUseCase) GetRequest(ctx context.Context, id, tenantID, userID string) (*models.ApprovalRequest, error) {
+	args := m.Called(ctx, id, tenantID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ApprovalRequest), args.Error(1)
+}
+
+func (m *MockApprovalUseCase) ListDocumentRequests(ctx context.Context, documentID, tenantID, userID string, page int, pageSize int) (utils.PaginatedResult[models.ApprovalRequest], error) {
+	args := m.Called(ctx, documentID, tenantID, userID, page, pageSize)
+	return args.Get(0).(utils.PaginatedResult[models.ApprovalRequest]), args.Error(1)
+}
+
+func (m *MockApprovalUseCase) ListAwaitingApproval(ctx context.Context, tenantID, userID string, page int, pageSize int) (utils.PaginatedResult[models.ApprovalRequest], error) {
+	args := m.Called(ctx, tenantID, userID, page, pageSize)
+	return args.Get(0).(utils.PaginatedResult[models.ApprovalRequest]), args.Error(1)
+}
+
+func (m *MockApprovalUseCase) Approve(ctx context.Context, id, comment, tenantID, userID string) (*models.ApprovalRequest, error) {
+	args := m.Called(ctx, id, comment, tenantID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ApprovalRequest), args.Error(1)
+}
+
+func (m *MockApprovalUseCase) Reject(ctx context.Context, id, comment, tenantID, userID string) (*models.ApprovalRequest, error) {
+	args := m.Called(ctx, id, comment, tenantID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ApprovalRequest), args.Error(1)
+}
+
+func (m *MockApprovalUseCase) CancelRequest(ctx context.Context, id, tenantID, userID string) (*models.ApprovalRequest, error) {
+	args := m.Called(ctx, id, tenantID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ApprovalRequest), args.Error(1)
+}
+
+// ApprovalHandlerSuite defines the test suite
+type ApprovalHandlerSuite struct {
+	suite.Suite
+	router          *gin.Engine
+	recorder        *httptest.ResponseRecorder
+	approvalUseCase *MockApprovalUseCase
+	approvalHandler *ApprovalHandler
+}
+
+// SetupTest is called before each test
+func (s *ApprovalHandlerSuite) SetupTest() {
+	// Create a gin router in test mode
+	gin.SetMode(gin.TestMode)
+	s.router = gin.New()
+	s.recorder = httptest.NewRecorder()
+
+	// Create the approval handler with a mock use case
+	s.approvalUseCase = new(MockApprovalUseCase)
+	handler, err := NewApprovalHandler(s.approvalUseCase)
+	s.Require().NoError(err)
+	s.approvalHandler = handler
+
+	// Set up a router group with an authenticated user and the approval handler routes
+	group := s.router.Group("/api/v1")
+	group.Use(func(c *gin.Context) {
+		c.Set("tenant_id", "tenant-123")
+		c.Set("user_id", "user-123")
+		c.Next()
+	})
+	s.approvalHandler.RegisterRoutes(group)
+}
+
+// pendingRequest returns a pending request of doc-123 waiting for user-456
+func (s *ApprovalHandlerSuite) pendingRequest() *models.ApprovalRequest {
+	workflow := models.NewApprovalWorkflow("tenant-123", "folder-123", "Contracts", models.ApprovalModeSequential, []string{"user-456"}, "admin-123")
+	workflow.ID = "workflow-123"
+	request := models.NewApprovalRequest(workflow, "doc-123", "user-123", "")
+	request.ID = "request-123"
+	return request
+}
+
+// TestCreateWorkflow_DefaultsToSequential tests attaching a chain without a mode
+func (s *ApprovalHandlerSuite) TestCreateWorkflow_DefaultsToSequential() {
+	s.approvalUseCase.On("CreateWorkflow", mock.Anything, mock.MatchedBy(func(w *models.ApprovalWorkflow) bool {
+		return w.FolderID == "folder-123" && w.Mode == models.ApprovalModeSequential && w.CreatedBy == "user-123"
+	})).Return(&models.ApprovalWorkflow{
+		ID:        "workflow-123",
+		FolderID:  "folder-123",
+		Name:      "Contracts",
+		Mode:      models.ApprovalModeSequential,
+		Approvers: []string{"user-456", "user-789"},
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}, nil)
+
+	body := `{"folder_id":"folder-123","name":"Contracts","approvers":["user-456","user-789"]}`
+	req, _ := http.NewRequest("POST", "/api/v1/approval-workflows", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusCreated, s.recorder.Code)
+	s.Contains(s.recorder.Body.String(), `"mode":"sequential"`)
+	s.approvalUseCase.AssertExpectations(s.T())
+}
+
+// TestSubmitForApproval_EmptyBody tests submitting a document without a comment
+func (s *ApprovalHandlerSuite) TestSubmitForApproval_EmptyBody() {
+	s.approvalUseCase.On("SubmitForApproval", mock.Anything, "doc-123", "", "tenant-123", "user-123").Return(s.pendingRequest(), nil)
+
+	req, _ := http.NewRequest("POST", "/api/v1/documents/doc-123/approval-requests", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusCreated, s.recorder.Code)
+	s.Contains(s.recorder.Body.String(), `"status":"pending"`)
+	s.Contains(s.recorder.Body.String(), `"pending_approvers":["user-456"]`)
+	s.approvalUseCase.AssertExpectations(s.T())
+}
+
+// TestSubmitForApproval_Locked tests submitting a document that already awaits approval
+func (s *ApprovalHandlerSuite) TestSubmitForApproval_Locked() {
+	s.approvalUseCase.On("SubmitForApproval", mock.Anything, "doc-123", "Please review", "tenant-123", "user-123").Return(nil, usecases.ErrDocumentLocked)
+
+	req, _ := http.NewRequest("POST", "/api/v1/documents/doc-123/approval-requests", bytes.NewBufferString(`{"comment":"Please review"}`))
+	req.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusBadRequest, s.recorder.Code)
+}
+
+// TestReject_WithComment tests an approver rejecting a request with a comment
+func (s *ApprovalHandlerSuite) TestReject_WithComment() {
+	request := s.pendingRequest()
+	_, err := request.Decide("user-456", false, "Missing signature page", time.Now())
+	s.Require().NoError(err)
+	s.approvalUseCase.On("Reject", mock.Anything, "request-123", "Missing signature page", "tenant-123", "user-123").Return(request, nil)
+
+	req, _ := http.NewRequest("POST", "/api/v1/approval-requests/request-123/reject", bytes.NewBufferString(`{"comment":"Missing signature page"}`))
+	req.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusOK, s.recorder.Code)
+	s.Contains(s.recorder.Body.String(), `"status":"rejected"`)
+	s.Contains(s.recorder.Body.String(), `"comment":"Missing signature page"`)
+	s.approvalUseCase.AssertNotCalled(s.T(), "Approve")
+}
+
+// TestApprove_NotApprover tests a user outside the chain approving
+func (s *ApprovalHandlerSuite) TestApprove_NotApprover() {
+	s.approvalUseCase.On("Approve", mock.Anything, "request-123", "", "tenant-123", "user-123").
+		Return(nil, apperrors.NewAuthorizationError("user is not an approver of the approval request"))
+
+	req, _ := http.NewRequest("POST", "/api/v1/approval-requests/request-123/approve", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusForbidden, s.recorder.Code)
+}
+
+// TestListAwaitingApproval_Success tests listing the requests waiting for the user
+func (s *ApprovalHandlerSuite) TestListAwaitingApproval_Success() {
+	s.approvalUseCase.On("ListAwaitingApproval", mock.Anything, "tenant-123", "user-123", 2, 10).
+		Return(utils.NewPaginatedResult([]models.ApprovalRequest{*s.pendingRequest()}, utils.NewPagination(2, 10), 11), nil)
+
+	req, _ := http.NewRequest("GET", "/api/v1/me/approvals?page=2&pageSize=10", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusOK, s.recorder.Code)
+	s.Contains(s.recorder.Body.String(), `"id":"request-123"`)
+	s.approvalUseCase.AssertExpectations(s.T())
+}
+
+// TestApprovalHandlerSuite runs the test suite
+func TestApprovalHandlerSuite(t *testing.T) {
+	suite.Run(t, new(ApprovalHandlerSuite))
+}
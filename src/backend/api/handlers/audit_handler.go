@@ -0,0 +1,248 @@
+// Package handlers implements HTTP handlers for audit log queries in the Document Management Platform.
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin" // v1.9.0+
+
+	"../dto"
+	"../middleware"
+	"../../domain/models"
+	"../../application/usecases"
+	"../../pkg/errors"
+	"../../pkg/logger"
+)
+
+// auditExportContentTypes maps export formats to the response content type
+var auditExportContentTypes = map[string]string{
+	models.AuditExportFormatCSV:   "text/csv",
+	models.AuditExportFormatJSONL: "application/x-ndjson",
+}
+
+// AuditHandler handles HTTP requests for audit log queries and exports
+type AuditHandler struct {
+	auditUseCase usecases.AuditUseCase
+}
+
+// NewAuditHandler creates a new AuditHandler instance
+func NewAuditHandler(auditUseCase usecases.AuditUseCase) (*AuditHandler, error) {
+	if auditUseCase == nil {
+		return nil, errors.NewValidationError("audit use case cannot be nil")
+	}
+
+	return &AuditHandler{
+		auditUseCase: auditUseCase,
+	}, nil
+}
+
+// RegisterRoutes registers audit routes with the provided router group
+func (h *AuditHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/audit", h.ListAuditLogs)
+	router.GET("/audit/export", h.ExportAuditLogs)
+	router.GET("/audit/:id", h.GetAuditLog)
+}
+
+// ListAuditLogs handles requests to query the tenant's audit log
+func (h *AuditHandler) ListAuditLogs(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Get audit filter and pagination parameters
+	filter, err := h.getAuditFilter(c)
+	if err != nil {
+		log.WithError(err).Error("invalid audit log filter")
+		h.handleError(c, err)
+		return
+	}
+	page, pageSize := h.getPaginationParams(c)
+
+	// Call use case to list audit logs
+	result, err := h.auditUseCase.ListAuditLogs(c.Request.Context(), tenantID, filter, page, pageSize)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	// Convert domain models to DTOs and return
+	entries := dto.ToAuditLogListDTO(result)
+	c.JSON(http.StatusOK, dto.NewPaginatedResponse(entries, result.Pagination))
+}
+
+// GetAuditLog handles requests for a single audit log entry
+func (h *AuditHandler) GetAuditLog(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Get audit log ID from URL
+	id := c.Param("id")
+	if id == "" {
+		log.Error("audit log ID missing in request path")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			errors.NewValidationError("audit log ID is required"),
+			map[string]string{"id": "required"},
+		))
+		return
+	}
+
+	// Call use case to get the audit log entry
+	entry, err := h.auditUseCase.GetAuditLog(c.Request.Context(), id, tenantID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	// Convert domain model to DTO and return
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.ToAuditLogDTO(entry)))
+}
+
+// ExportAuditLogs handles requests to export the tenant's audit log as CSV or JSON Lines.
+// The export is streamed to the response; the format is selected with the format query parameter.
+func (h *AuditHandler) ExportAuditLogs(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Validate the export format before any output is written
+	format := c.DefaultQuery("format", models.AuditExportFormatCSV)
+	contentType, ok := auditExportContentTypes[format]
+	if !ok {
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			errors.NewValidationError(models.ErrAuditInvalidFormat.Error()),
+			map[string]string{"format": "must be csv or jsonl"},
+		))
+		return
+	}
+
+	// Get audit filter parameters
+	filter, err := h.getAuditFilter(c)
+	if err != nil {
+		log.WithError(err).Error("invalid audit log filter")
+		h.handleError(c, err)
+		return
+	}
+
+	filename := fmt.Sprintf("audit-%s.%s", time.Now().UTC().Format("20060102T150405Z"), format)
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Status(http.StatusOK)
+
+	// Call use case to stream the export. Once rows have been written the status can no
+	// longer change, so failures after that point are only logged.
+	count, err := h.auditUseCase.ExportAuditLogs(c.Request.Context(), tenantID, filter, format, c.Writer)
+	if err != nil {
+		if !c.Writer.Written() {
+			h.handleError(c, err)
+			return
+		}
+		log.WithError(err).Error("audit log export interrupted", "tenantID", tenantID, "written", count)
+	}
+}
+
+// getAuditFilter extracts the actor, action, resource and time range filters for audit log queries.
+// Times are expected in RFC 3339 format.
+func (h *AuditHandler) getAuditFilter(c *gin.Context) (models.AuditLogFilter, error) {
+	filter := models.AuditLogFilter{
+		ActorID:      c.Query("actorId"),
+		Action:       c.Query("action"),
+		ResourceType: c.Query("resourceType"),
+		ResourceID:   c.Query("resourceId"),
+	}
+
+	if from := c.Query("from"); from != "" {
+		t, err := time.Parse(time.RFC3339, from)
+		if err != nil {
+			return filter, errors.NewValidationError("from must be an RFC 3339 timestamp")
+		}
+		filter.From = t
+	}
+
+	if to := c.Query("to"); to != "" {
+		t, err := time.Parse(time.RFC3339, to)
+		if err != nil {
+			return filter, errors.NewValidationError("to must be an RFC 3339 timestamp")
+		}
+		filter.To = t
+	}
+
+	if err := filter.Validate(); err != nil {
+		return filter, errors.NewValidationError(err.Error())
+	}
+
+	return filter, nil
+}
+
+// getPaginationParams extracts and validates pagination parameters from the request
+func (h *AuditHandler) getPaginationParams(c *gin.Context) (int, int) {
+	page := 1
+	if p, err := strconv.Atoi(c.Query("page")); err == nil && p > 0 {
+		page = p
+	}
+
+	pageSize := defaultPageSize
+	if ps, err := strconv.Atoi(c.Query("pageSize")); err == nil && ps > 0 {
+		pageSize = ps
+	}
+
+	// Cap pageSize at maximum
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	return page, pageSize
+}
+
+// handleError handles errors and returns appropriate HTTP responses
+func (h *AuditHandler) handleError(c *gin.Context, err error) {
+	if errors.IsValidationError(err) {
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			err,
+			map[string]string{},
+		))
+		return
+	}
+
+	if errors.IsResourceNotFoundError(err) {
+		c.JSON(http.StatusNotFound, dto.NewResourceNotFoundErrorResponse(c.Request.Context(), err))
+		return
+	}
+
+	// Default to internal server error
+	logger.WithError(err).Error("internal server error")
+	c.JSON(http.StatusInternalServerError, dto.NewInternalErrorResponse(c.Request.Context(), err))
+}
@@ -0,0 +1,184 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"../../domain/models"
+	apperrors "../../pkg/errors"
+	"../../pkg/utils/pagination"
+)
+
+// MockAuditUseCase is a mock implementation of the AuditUseCase interface
+type MockAuditUseCase struct {
+	mock.Mock
+}
+
+func (m *MockAuditUseCase) ListAuditLogs(ctx context.Context, tenantID string, filter models.AuditLogFilter, page int, pageSize int) (pagination.PaginatedResult[models.AuditLog], error) {
+	args := m.Called(ctx, tenantID, filter, page, pageSize)
+	return args.Get(0).(pagination.PaginatedResult[models.AuditLog]), args.Error(1)
+}
+
+func (m *MockAuditUseCase) GetAuditLog(ctx context.Context, id string, tenantID string) (*models.AuditLog, error) {
+	args := m.Called(ctx, id, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.AuditLog), args.Error(1)
+}
+
+func (m *MockAuditUseCase) ExportAuditLogs(ctx context.Context, tenantID string, filter models.AuditLogFilter, format string, w io.Writer) (int, error) {
+	args := m.Called(ctx, tenantID, filter, format, w)
+	return args.Int(0), args.Error(1)
+}
+
+// AuditHandlerSuite defines the test suite
+type AuditHandlerSuite struct {
+	suite.Suite
+	router       *gin.Engine
+	recorder     *httptest.ResponseRecorder
+	auditUseCase *MockAuditUseCase
+	auditHandler *AuditHandler
+}
+
+// SetupTest is called before each test
+func (s *AuditHandlerSuite) SetupTest() {
+	// Create a gin router in test mode
+	gin.SetMode(gin.TestMode)
+	s.router = gin.New()
+	s.recorder = httptest.NewRecorder()
+
+	// Create the audit handler with a mock use case
+	s.auditUseCase = new(MockAuditUseCase)
+	handler, err := NewAuditHandler(s.auditUseCase)
+	s.Require().NoError(err)
+	s.auditHandler = handler
+
+	// Set up a router group with an authenticated tenant and the audit handler routes
+	group := s.router.Group("/api/v1")
+	group.Use(func(c *gin.Context) {
+		c.Set("tenant_id", "tenant-123")
+		c.Set("user_id", "user-123")
+		c.Next()
+	})
+	s.auditHandler.RegisterRoutes(group)
+}
+
+// Helper function to create a test audit log model
+func (s *AuditHandlerSuite) createTestAuditLog() *models.AuditLog {
+	return &models.AuditLog{
+		ID:           "audit-123",
+		TenantID:     "tenant-123",
+		ActorID:      "user-123",
+		ActorIP:      "10.0.0.1",
+		Action:       models.AuditActionUpdate,
+		ResourceType: models.ResourceTypeFolder,
+		ResourceID:   "folder-123",
+		Before:       []byte(`{"name":"old"}`),
+		After:        []byte(`{"name":"new"}`),
+		OccurredAt:   time.Now(),
+	}
+}
+
+// TestListAuditLogs_WithFilters tests querying the audit log by actor, resource and time range
+func (s *AuditHandlerSuite) TestListAuditLogs_WithFilters() {
+	from, _ := time.Parse(time.RFC3339, "2024-01-01T00:00:00Z")
+	to, _ := time.Parse(time.RFC3339, "2024-01-31T23:59:59Z")
+	filter := models.AuditLogFilter{
+		ActorID:      "user-123",
+		ResourceType: models.ResourceTypeFolder,
+		ResourceID:   "folder-123",
+		From:         from,
+		To:           to,
+	}
+	result := pagination.PaginatedResult[models.AuditLog]{
+		Items:      []models.AuditLog{*s.createTestAuditLog()},
+		Pagination: pagination.PageInfo{Page: 1, PageSize: 20, TotalPages: 1, TotalItems: 1},
+	}
+
+	// Expect the use case to receive the parsed filter
+	s.auditUseCase.On("ListAuditLogs", mock.Anything, "tenant-123", filter, 1, 20).Return(result, nil)
+
+	req, _ := http.NewRequest("GET", "/api/v1/audit?actorId=user-123&resourceType=folder&resourceId=folder-123&from=2024-01-01T00:00:00Z&to=2024-01-31T23:59:59Z", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusOK, s.recorder.Code)
+	s.Contains(s.recorder.Body.String(), `"before":{"name":"old"}`)
+	s.auditUseCase.AssertExpectations(s.T())
+}
+
+// TestListAuditLogs_InvalidRange tests the audit log with a from time after the to time
+func (s *AuditHandlerSuite) TestListAuditLogs_InvalidRange() {
+	req, _ := http.NewRequest("GET", "/api/v1/audit?from=2024-02-01T00:00:00Z&to=2024-01-01T00:00:00Z", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusBadRequest, s.recorder.Code)
+	s.auditUseCase.AssertNotCalled(s.T(), "ListAuditLogs")
+}
+
+// TestGetAuditLog_NotFound tests retrieval of an audit log entry that does not exist
+func (s *AuditHandlerSuite) TestGetAuditLog_NotFound() {
+	s.auditUseCase.On("GetAuditLog", mock.Anything, "audit-404", "tenant-123").
+		Return(nil, apperrors.NewResourceNotFoundError("audit log not found"))
+
+	req, _ := http.NewRequest("GET", "/api/v1/audit/audit-404", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusNotFound, s.recorder.Code)
+	s.auditUseCase.AssertExpectations(s.T())
+}
+
+// TestExportAuditLogs_CSV tests that the export is streamed with CSV headers
+func (s *AuditHandlerSuite) TestExportAuditLogs_CSV() {
+	s.auditUseCase.On("ExportAuditLogs", mock.Anything, "tenant-123", models.AuditLogFilter{Action: models.AuditActionDelete}, models.AuditExportFormatCSV, mock.Anything).
+		Run(func(args mock.Arguments) {
+			w := args.Get(4).(io.Writer)
+			io.WriteString(w, "id,occurred_at\naudit-123,2024-01-01T00:00:00Z\n")
+		}).
+		Return(1, nil)
+
+	req, _ := http.NewRequest("GET", "/api/v1/audit/export?format=csv&action=delete", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusOK, s.recorder.Code)
+	s.Equal("text/csv", s.recorder.Header().Get("Content-Type"))
+	s.True(strings.HasPrefix(s.recorder.Header().Get("Content-Disposition"), "attachment;"))
+	s.Contains(s.recorder.Body.String(), "audit-123")
+	s.auditUseCase.AssertExpectations(s.T())
+}
+
+// TestExportAuditLogs_InvalidFormat tests that unsupported export formats are rejected
+func (s *AuditHandlerSuite) TestExportAuditLogs_InvalidFormat() {
+	req, _ := http.NewRequest("GET", "/api/v1/audit/export?format=xml", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusBadRequest, s.recorder.Code)
+	s.auditUseCase.AssertNotCalled(s.T(), "ExportAuditLogs")
+}
+
+// TestExportAuditLogs_UseCaseError tests an export that fails before any output is written
+func (s *AuditHandlerSuite) TestExportAuditLogs_UseCaseError() {
+	s.auditUseCase.On("ExportAuditLogs", mock.Anything, "tenant-123", models.AuditLogFilter{}, models.AuditExportFormatJSONL, mock.Anything).
+		Return(0, errors.New("database unavailable"))
+
+	req, _ := http.NewRequest("GET", "/api/v1/audit/export?format=jsonl", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusInternalServerError, s.recorder.Code)
+	s.auditUseCase.AssertExpectations(s.T())
+}
+
+// TestAuditHandlerSuite is the entry point for the test suite
+func TestAuditHandlerSuite(t *testing.T) {
+	suite.Run(t, new(AuditHandlerSuite))
+}
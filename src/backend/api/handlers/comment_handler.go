@@ -0,0 +1,283 @@
+// Package handlers implements HTTP handlers for document comments in the Document Management Platform.
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin" // v1.9.0+
+
+	"../../application/usecases"
+	"../../domain/models"
+	"../../pkg/errors"
+	"../../pkg/logger"
+	"../dto"
+	"../middleware"
+	"../validators"
+)
+
+// CommentHandler handles HTTP requests for the comment threads of documents
+type CommentHandler struct {
+	commentUseCase usecases.CommentUseCase
+}
+
+// NewCommentHandler creates a new CommentHandler instance
+func NewCommentHandler(commentUseCase usecases.CommentUseCase) (*CommentHandler, error) {
+	if commentUseCase == nil {
+		return nil, errors.NewValidationError("comment use case cannot be nil")
+	}
+
+	return &CommentHandler{
+		commentUseCase: commentUseCase,
+	}, nil
+}
+
+// RegisterRoutes registers comment routes with the provided router group
+func (h *CommentHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.POST("/documents/:id/comments", h.CreateComment)
+	router.GET("/documents/:id/comments", h.ListComments)
+	router.GET("/documents/:id/comments/:commentId", h.GetComment)
+	router.PUT("/documents/:id/comments/:commentId", h.UpdateComment)
+	router.DELETE("/documents/:id/comments/:commentId", h.DeleteComment)
+	router.POST("/documents/:id/comments/:commentId/resolve", h.ResolveComment)
+	router.POST("/documents/:id/comments/:commentId/reopen", h.ReopenComment)
+}
+
+// CreateComment handles requests to comment on a document or reply to a thread
+func (h *CommentHandler) CreateComment(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	tenantID, userID, documentID, ok := h.getDocumentParams(c)
+	if !ok {
+		return
+	}
+
+	var req dto.CreateCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.WithError(err).Error("failed to bind request body")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			validators.BindingError(err),
+			nil,
+		))
+		return
+	}
+
+	comment := models.NewComment(tenantID, documentID, req.ParentID, userID, req.Body, dto.ToCommentAnchor(req.Anchor))
+
+	// Call use case to create the comment
+	created, err := h.commentUseCase.CreateComment(c.Request.Context(), comment)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.NewDataResponse(dto.ToCommentDTO(created)))
+}
+
+// ListComments handles requests to list the comments of a document oldest first
+func (h *CommentHandler) ListComments(c *gin.Context) {
+	tenantID, userID, documentID, ok := h.getDocumentParams(c)
+	if !ok {
+		return
+	}
+
+	page, pageSize := h.getPaginationParams(c)
+
+	// Call use case to list the comments
+	result, err := h.commentUseCase.ListComments(c.Request.Context(), documentID, tenantID, userID, page, pageSize)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewPaginatedResponse(dto.ToCommentListDTO(result.Items), result.Pagination))
+}
+
+// GetComment handles requests to retrieve a comment of a document
+func (h *CommentHandler) GetComment(c *gin.Context) {
+	tenantID, userID, documentID, commentID, ok := h.getCommentParams(c)
+	if !ok {
+		return
+	}
+
+	// Call use case to get the comment
+	comment, err := h.commentUseCase.GetComment(c.Request.Context(), documentID, commentID, tenantID, userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.ToCommentDTO(comment)))
+}
+
+// UpdateComment handles requests to edit the body of a comment
+func (h *CommentHandler) UpdateComment(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	tenantID, userID, documentID, commentID, ok := h.getCommentParams(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.WithError(err).Error("failed to bind request body")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			validators.BindingError(err),
+			nil,
+		))
+		return
+	}
+
+	// Call use case to update the comment
+	comment, err := h.commentUseCase.UpdateComment(c.Request.Context(), documentID, commentID, req.Body, tenantID, userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.ToCommentDTO(comment)))
+}
+
+// DeleteComment handles requests to delete a comment together with its replies
+func (h *CommentHandler) DeleteComment(c *gin.Context) {
+	tenantID, userID, documentID, commentID, ok := h.getCommentParams(c)
+	if !ok {
+		return
+	}
+
+	// Call use case to delete the comment
+	if err := h.commentUseCase.DeleteComment(c.Request.Context(), documentID, commentID, tenantID, userID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ResolveComment handles requests to resolve a comment thread
+func (h *CommentHandler) ResolveComment(c *gin.Context) {
+	h.resolve(c, true)
+}
+
+// ReopenComment handles requests to reopen a resolved comment thread
+func (h *CommentHandler) ReopenComment(c *gin.Context) {
+	h.resolve(c, false)
+}
+
+// resolve resolves or reopens the comment thread in the request path
+func (h *CommentHandler) resolve(c *gin.Context, resolved bool) {
+	tenantID, userID, documentID, commentID, ok := h.getCommentParams(c)
+	if !ok {
+		return
+	}
+
+	// Call use case to change the resolution of the thread
+	comment, err := h.commentUseCase.ResolveComment(c.Request.Context(), documentID, commentID, resolved, tenantID, userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.ToCommentDTO(comment)))
+}
+
+// getDocumentParams extracts the tenant and user IDs from the request context and the document ID
+// from the request path
+func (h *CommentHandler) getDocumentParams(c *gin.Context) (string, string, string, bool) {
+	log := logger.WithContext(c.Request.Context())
+
+	tenantID := middleware.GetTenantID(c)
+	userID := middleware.GetUserID(c)
+	if tenantID == "" || userID == "" {
+		log.Error("tenant or user ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrUserContextRequired,
+		))
+		return "", "", "", false
+	}
+
+	documentID := c.Param("id")
+	if documentID == "" {
+		log.Error("document ID missing in request path")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			errors.NewValidationError("document ID is required"),
+			map[string]string{"id": "required"},
+		))
+		return "", "", "", false
+	}
+
+	return tenantID, userID, documentID, true
+}
+
+// getCommentParams extracts the tenant and user IDs from the request context and the document and
+// comment IDs from the request path
+func (h *CommentHandler) getCommentParams(c *gin.Context) (string, string, string, string, bool) {
+	tenantID, userID, documentID, ok := h.getDocumentParams(c)
+	if !ok {
+		return "", "", "", "", false
+	}
+
+	commentID := c.Param("commentId")
+	if commentID == "" {
+		logger.WithContext(c.Request.Context()).Error("comment ID missing in request path")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			errors.NewValidationError("comment ID is required"),
+			map[string]string{"commentId": "required"},
+		))
+		return "", "", "", "", false
+	}
+
+	return tenantID, userID, documentID, commentID, true
+}
+
+// getPaginationParams extracts and validates pagination parameters from the request
+func (h *CommentHandler) getPaginationParams(c *gin.Context) (int, int) {
+	page := 1
+	if p, err := strconv.Atoi(c.Query("page")); err == nil && p > 0 {
+		page = p
+	}
+
+	pageSize := defaultPageSize
+	if ps, err := strconv.Atoi(c.Query("pageSize")); err == nil && ps > 0 {
+		pageSize = ps
+	}
+
+	// Cap pageSize at maximum
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	return page, pageSize
+}
+
+// handleError handles errors and returns appropriate HTTP responses
+func (h *CommentHandler) handleError(c *gin.Context, err error) {
+	if errors.IsValidationError(err) {
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			err,
+			map[string]string{},
+		))
+		return
+	}
+
+	if errors.IsResourceNotFoundError(err) {
+		c.JSON(http.StatusNotFound, dto.NewResourceNotFoundErrorResponse(c.Request.Context(), err))
+		return
+	}
+
+	if errors.IsAuthorizationError(err) {
+		c.JSON(http.StatusForbidden, dto.NewAuthorizationErrorResponse(c.Request.Context(), err))
+		return
+	}
+
+	// Default to internal server error
+	logger.WithError(err).Error("internal server error")
+	c.JSON(http.StatusInternalServerError, dto.NewInternalErrorResponse(c.Request.Context(), err))
+}
@@ -0,0 +1,187 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"../../application/usecases"
+	"../../domain/models"
+	apperrors "../../pkg/errors"
+	"../../pkg/utils"
+)
+
+// MockCommentUseCase is a mock implementation of the CommentUseCase interface
+type MockCommentUseCase struct {
+	mock.Mock
+}
+
+func (m *MockCommentUseCase) CreateComment(ctx context.Context, comment *models.Comment) (*models.Comment, error) {
+	args := m.Called(ctx, comment)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Comment), args.Error(1)
+}
+
+func (m *MockCommentUseCase) GetComment(ctx context.Context, documentID, id, tenantID, userID string) (*models.Comment, error) {
+	args := m.Called(ctx, documentID, id, tenantID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Comment), args.Error(1)
+}
+
+func (m *MockCommentUseCase) ListComments(ctx context.Context, documentID, tenantID, userID string, page int, pageSize int) (utils.PaginatedResult[models.Comment], error) {
+	args := m.Called(ctx, documentID, tenantID, userID, page, pageSize)
+	return args.Get(0).(utils.PaginatedResult[models.Comment]), args.Error(1)
+}
+
+func (m *MockCommentUseCase) UpdateComment(ctx context.Context, documentID, id, body, tenantID, userID string) (*models.Comment, error) {
+	args := m.Called(ctx, documentID, id, body, tenantID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Comment), args.Error(1)
+}
+
+func (m *MockCommentUseCase) ResolveComment(ctx context.Context, documentID, id string, resolved bool, tenantID, userID string) (*models.Comment, error) {
+	args := m.Called(ctx, documentID, id, resolved, tenantID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Comment), args.Error(1)
+}
+
+func (m *MockCommentUseCase) DeleteComment(ctx context.Context, documentID, id, tenantID, userID string) error {
+	args := m.Called(ctx, documentID, id, tenantID, userID)
+	return args.Error(0)
+}
+
+// CommentHandlerSuite defines the test suite
+type CommentHandlerSuite struct {
+	suite.Suite
+	router         *gin.Engine
+	recorder       *httptest.ResponseRecorder
+	commentUseCase *MockCommentUseCase
+	commentHandler *CommentHandler
+}
+
+// SetupTest is called before each test
+func (s *CommentHandlerSuite) SetupTest() {
+	// Create a gin router in test mode
+	gin.SetMode(gin.TestMode)
+	s.router = gin.New()
+	s.recorder = httptest.NewRecorder()
+
+	// Create the comment handler with a mock use case
+	s.commentUseCase = new(MockCommentUseCase)
+	handler, err := NewCommentHandler(s.commentUseCase)
+	s.Require().NoError(err)
+	s.commentHandler = handler
+
+	// Set up a router group with an authenticated user and the comment handler routes
+	group := s.router.Group("/api/v1")
+	group.Use(func(c *gin.Context) {
+		c.Set("tenant_id", "tenant-123")
+		c.Set("user_id", "user-123")
+		c.Next()
+	})
+	s.commentHandler.RegisterRoutes(group)
+}
+
+// TestCreateComment_Success tests commenting on a region of a page of a document
+func (s *CommentHandlerSuite) TestCreateComment_Success() {
+	s.commentUseCase.On("CreateComment", mock.Anything, mock.MatchedBy(func(c *models.Comment) bool {
+		return c.DocumentID == "doc-123" && c.AuthorID == "user-123" && c.Anchor.Page == 3 && c.Anchor.Width == 0.5
+	})).Return(&models.Comment{
+		ID:         "comment-123",
+		DocumentID: "doc-123",
+		AuthorID:   "user-123",
+		Body:       "Check this clause",
+		Anchor:     models.CommentAnchor{Page: 3, X: 0.1, Y: 0.2, Width: 0.5, Height: 0.1},
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}, nil)
+
+	body := `{"body":"Check this clause","anchor":{"page":3,"x":0.1,"y":0.2,"width":0.5,"height":0.1}}`
+	req, _ := http.NewRequest("POST", "/api/v1/documents/doc-123/comments", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusCreated, s.recorder.Code)
+	s.Contains(s.recorder.Body.String(), `"id":"comment-123"`)
+	s.Contains(s.recorder.Body.String(), `"page":3`)
+	s.commentUseCase.AssertExpectations(s.T())
+}
+
+// TestCreateComment_MissingBody tests commenting without a body
+func (s *CommentHandlerSuite) TestCreateComment_MissingBody() {
+	req, _ := http.NewRequest("POST", "/api/v1/documents/doc-123/comments", bytes.NewBufferString(`{"parent_id":"comment-123"}`))
+	req.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusBadRequest, s.recorder.Code)
+	s.commentUseCase.AssertNotCalled(s.T(), "CreateComment")
+}
+
+// TestListComments_Forbidden tests listing the comments of a document the user cannot read
+func (s *CommentHandlerSuite) TestListComments_Forbidden() {
+	s.commentUseCase.On("ListComments", mock.Anything, "doc-123", "tenant-123", "user-123", 1, 20).
+		Return(utils.PaginatedResult[models.Comment]{}, apperrors.NewAuthorizationError("permission denied"))
+
+	req, _ := http.NewRequest("GET", "/api/v1/documents/doc-123/comments", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusForbidden, s.recorder.Code)
+	s.commentUseCase.AssertExpectations(s.T())
+}
+
+// TestUpdateComment_NotAuthor tests editing a comment of another user
+func (s *CommentHandlerSuite) TestUpdateComment_NotAuthor() {
+	s.commentUseCase.On("UpdateComment", mock.Anything, "doc-123", "comment-123", "Changed", "tenant-123", "user-123").
+		Return(nil, usecases.ErrCommentNotAuthor)
+
+	req, _ := http.NewRequest("PUT", "/api/v1/documents/doc-123/comments/comment-123", bytes.NewBufferString(`{"body":"Changed"}`))
+	req.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusForbidden, s.recorder.Code)
+	s.commentUseCase.AssertExpectations(s.T())
+}
+
+// TestReopenComment_Success tests reopening a resolved thread
+func (s *CommentHandlerSuite) TestReopenComment_Success() {
+	s.commentUseCase.On("ResolveComment", mock.Anything, "doc-123", "comment-123", false, "tenant-123", "user-123").
+		Return(&models.Comment{ID: "comment-123", DocumentID: "doc-123", AuthorID: "user-456", Body: "Typo"}, nil)
+
+	req, _ := http.NewRequest("POST", "/api/v1/documents/doc-123/comments/comment-123/reopen", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusOK, s.recorder.Code)
+	s.Contains(s.recorder.Body.String(), `"resolved":false`)
+	s.commentUseCase.AssertExpectations(s.T())
+}
+
+// TestDeleteComment_Success tests deleting a comment
+func (s *CommentHandlerSuite) TestDeleteComment_Success() {
+	s.commentUseCase.On("DeleteComment", mock.Anything, "doc-123", "comment-123", "tenant-123", "user-123").Return(nil)
+
+	req, _ := http.NewRequest("DELETE", "/api/v1/documents/doc-123/comments/comment-123", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusNoContent, s.recorder.Code)
+	s.commentUseCase.AssertExpectations(s.T())
+}
+
+// TestCommentHandlerSuite runs the test suite
+func TestCommentHandlerSuite(t *testing.T) {
+	suite.Run(t, new(CommentHandlerSuite))
+}
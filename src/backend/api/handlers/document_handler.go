@@ -12,16 +12,26 @@ import (
 	"github.com/gin-gonic/gin" // v1.9.0+
 
 	"../../application/usecases"
+	"../../domain/models"
 	"../dto/document_dto"
 	errdto "../dto/error_dto"
 	"../dto/response_dto"
 	"../middleware"
+	"../validators"
 	"../../pkg/errors"
 	"../../pkg/logger"
 	"../../pkg/validator"
 	"../../pkg/utils/pagination"
 )
 
+// Response headers reporting the tenant's quota on uploads
+const (
+	headerQuotaStorageLimit       = "X-Quota-Storage-Limit"
+	headerQuotaStorageRemaining   = "X-Quota-Storage-Remaining"
+	headerQuotaDocumentsLimit     = "X-Quota-Documents-Limit"
+	headerQuotaDocumentsRemaining = "X-Quota-Documents-Remaining"
+)
+
 // DocumentHandler handles HTTP requests for document-related operations
 type DocumentHandler struct {
 	documentUseCase usecases.DocumentUseCase
@@ -47,12 +57,30 @@ func (h *DocumentHandler) RegisterRoutes(router *gin.RouterGroup) {
 	// Register POST /documents for document upload
 	router.POST("/documents", h.UploadDocument)
 
+	// Register POST /documents/batch for uploading several documents in one request
+	router.POST("/documents/batch", h.UploadDocuments)
+
+	// Register POST /documents/metadata/bulk for changing the metadata of many documents at once
+	router.POST("/documents/metadata/bulk", h.BulkUpdateMetadata)
+
 	// Register GET /documents/:id for getting document metadata
 	router.GET("/documents/:id", h.GetDocument)
 
 	// Register GET /documents/:id/content for document download
 	router.GET("/documents/:id/content", h.DownloadDocument)
 
+	// Register GET /documents/:id/versions for listing the versions of a document
+	router.GET("/documents/:id/versions", h.ListDocumentVersions)
+
+	// Register GET /documents/:id/versions/:versionId/content for downloading a version of a document
+	router.GET("/documents/:id/versions/:versionId/content", h.DownloadDocumentVersion)
+
+	// Register GET /documents/:id/versions/:versionId/signature for the block signature delta patches are built against
+	router.GET("/documents/:id/versions/:versionId/signature", h.GetVersionSignature)
+
+	// Register POST /documents/:id/versions/delta for uploading a new version as a delta patch
+	router.POST("/documents/:id/versions/delta", h.UploadVersionDelta)
+
 	// Register GET /documents/:id/content/url for getting document download URL
 	router.GET("/documents/:id/content/url", h.GetDocumentDownloadURL)
 
@@ -71,6 +99,12 @@ func (h *DocumentHandler) RegisterRoutes(router *gin.RouterGroup) {
 	// Register GET /documents/:id/thumbnail/url for getting thumbnail URL
 	router.GET("/documents/:id/thumbnail/url", h.GetDocumentThumbnailURL)
 
+	// Register PUT /documents/:id/schedule for setting when a document is published and expires
+	router.PUT("/documents/:id/schedule", h.ScheduleDocument)
+
+	// Register POST /documents/:id/verify-integrity for checking the stored content against its hash
+	router.POST("/documents/:id/verify-integrity", h.VerifyIntegrity)
+
 	// Register PUT /documents/:id for updating document metadata
 	router.PUT("/documents/:id", h.UpdateDocument)
 
@@ -97,7 +131,7 @@ func (h *DocumentHandler) UploadDocument(c *gin.Context) {
 	file, header, err := c.Request.FormFile("file")
 	if err != nil {
 		log.WithError(err).Error("Failed to parse multipart form data")
-		c.AbortWithStatusJSON(http.StatusBadRequest, errdto.NewErrorResponse(errors.NewValidationError("invalid form data: " + err.Error())))
+		c.AbortWithStatusJSON(http.StatusBadRequest, errdto.NewErrorResponse(c.Request.Context(), errors.NewValidationError("invalid form data: " + err.Error())))
 		return
 	}
 	defer file.Close()
@@ -106,7 +140,7 @@ func (h *DocumentHandler) UploadDocument(c *gin.Context) {
 	var req document_dto.CreateDocumentRequest
 	if err := c.ShouldBind(&req); err != nil {
 		log.WithError(err).Error("Failed to bind request to CreateDocumentRequest struct")
-		c.AbortWithStatusJSON(http.StatusBadRequest, errdto.NewErrorResponse(errors.NewValidationError("invalid request payload: " + err.Error())))
+		c.AbortWithStatusJSON(http.StatusBadRequest, errdto.NewErrorResponse(c.Request.Context(), validators.BindingError(err)))
 		return
 	}
 	req.File = header
@@ -114,7 +148,7 @@ func (h *DocumentHandler) UploadDocument(c *gin.Context) {
 	// Validate the request
 	if err := validator.Validate(req); err != nil {
 		log.WithError(err).Error("Invalid request")
-		c.AbortWithStatusJSON(http.StatusBadRequest, errdto.NewErrorResponse(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, errdto.NewErrorResponse(c.Request.Context(), err))
 		return
 	}
 
@@ -122,25 +156,178 @@ func (h *DocumentHandler) UploadDocument(c *gin.Context) {
 	src, err := header.Open()
 	if err != nil {
 		log.WithError(err).Error("Failed to open uploaded file")
-		c.AbortWithStatusJSON(http.StatusInternalServerError, errdto.NewErrorResponse(errors.NewInternalError("failed to open uploaded file: " + err.Error())))
+		c.AbortWithStatusJSON(http.StatusInternalServerError, errdto.NewErrorResponse(c.Request.Context(), errors.NewInternalError("failed to open uploaded file: " + err.Error())))
 		return
 	}
 	defer src.Close()
 
 	// Call documentUseCase.UploadDocument with the request data
-	documentID, err := h.documentUseCase.UploadDocument(c.Request.Context(), req.Name, header.Header.Get("Content-Type"), header.Size, req.FolderID, tenantID, userID, src, req.Metadata)
+	documentID, err := h.documentUseCase.UploadDocument(c.Request.Context(), req.Name, header.Header.Get("Content-Type"), header.Size, req.FolderID, tenantID, userID, src, req.Metadata, req.Priority, req.Sha256)
+
+	// Tell the client how much of the tenant's quota is left, whether or not the upload was accepted
+	h.setQuotaHeaders(c, tenantID)
+
 	if err != nil {
 		h.handleError(c, err)
 		return
 	}
 
-	// Return 202 Accepted with document ID and status
+	// Return 201 Created for small files scanned inline, which are available right away, and 202
+	// Accepted for documents still being scanned
+	if h.uploadIsAvailable(c, documentID, tenantID, userID) {
+		c.JSON(http.StatusCreated, response_dto.NewDataResponse(document_dto.DocumentUploadResponse{
+			DocumentID: documentID,
+			Status:     models.DocumentStatusAvailable,
+		}))
+		return
+	}
 	c.JSON(http.StatusAccepted, response_dto.NewDataResponse(document_dto.DocumentUploadResponse{
 		DocumentID: documentID,
 		Status:     "processing",
 	}))
 }
 
+// uploadIsAvailable checks if an uploaded document was scanned inline and is available already
+func (h *DocumentHandler) uploadIsAvailable(c *gin.Context, documentID, tenantID, userID string) bool {
+	document, err := h.documentUseCase.GetDocument(c.Request.Context(), documentID, tenantID, userID)
+	return err == nil && document.Status == models.DocumentStatusAvailable
+}
+
+// UploadDocuments handles requests uploading several documents into a folder. Files are validated
+// and uploaded independently, so the response reports the outcome of each file: 202 Accepted when
+// all files were accepted, 207 Multi-Status otherwise.
+func (h *DocumentHandler) UploadDocuments(c *gin.Context) {
+	// Extract user ID and tenant ID from the request context
+	userID := middleware.GetUserID(c)
+	tenantID := middleware.GetTenantID(c)
+
+	// Get logger with context
+	log := h.logger.WithContext(c.Request.Context())
+
+	// Parse multipart form data; large files are buffered in temporary files
+	form, err := c.MultipartForm()
+	if err != nil {
+		log.WithError(err).Error("Failed to parse multipart form data")
+		c.AbortWithStatusJSON(http.StatusBadRequest, errdto.NewErrorResponse(c.Request.Context(), errors.NewValidationError("invalid form data: " + err.Error())))
+		return
+	}
+	defer form.RemoveAll()
+
+	req := document_dto.BatchUploadRequest{Files: form.File["files"]}
+	if folderIDs := form.Value["folder_id"]; len(folderIDs) > 0 {
+		req.FolderID = folderIDs[0]
+	}
+	if priorities := form.Value["priority"]; len(priorities) > 0 {
+		req.Priority = priorities[0]
+	}
+	if err := req.Validate(); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, errdto.NewErrorResponse(c.Request.Context(), err))
+		return
+	}
+	if len(req.Files) > usecases.MaxBatchUploadSize {
+		c.AbortWithStatusJSON(http.StatusBadRequest, errdto.NewErrorResponse(c.Request.Context(), errors.NewValidationError(
+			fmt.Sprintf("at most %d files can be uploaded in a batch", usecases.MaxBatchUploadSize))))
+		return
+	}
+
+	// Open the uploaded files
+	uploads := make([]usecases.DocumentUpload, 0, len(req.Files))
+	for _, header := range req.Files {
+		src, err := header.Open()
+		if err != nil {
+			log.WithError(err).Error("Failed to open uploaded file", "name", header.Filename)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errdto.NewErrorResponse(c.Request.Context(), errors.NewInternalError("failed to open uploaded file: " + err.Error())))
+			return
+		}
+		defer src.Close()
+
+		uploads = append(uploads, usecases.DocumentUpload{
+			Name:        header.Filename,
+			ContentType: header.Header.Get("Content-Type"),
+			Size:        header.Size,
+			Content:     src,
+			Priority:    req.Priority,
+		})
+	}
+
+	// Call documentUseCase.UploadDocuments with the opened files
+	results, err := h.documentUseCase.UploadDocuments(c.Request.Context(), uploads, req.FolderID, tenantID, userID)
+
+	// Tell the client how much of the tenant's quota is left, whether or not the uploads were accepted
+	h.setQuotaHeaders(c, tenantID)
+
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	response := document_dto.BatchUploadResponse{Results: make([]document_dto.BatchUploadFileResult, len(results))}
+	for i, result := range results {
+		if result.Err != nil {
+			detail := errdto.NewErrorDetail(c.Request.Context(), result.Err)
+			response.Results[i] = document_dto.BatchUploadFileResult{Name: result.Name, Status: "failed", Error: &detail}
+			response.Failed++
+			continue
+		}
+		response.Results[i] = document_dto.BatchUploadFileResult{Name: result.Name, DocumentID: result.DocumentID, Status: "processing"}
+		response.Succeeded++
+	}
+
+	status := http.StatusAccepted
+	if response.Failed > 0 {
+		status = http.StatusMultiStatus
+	}
+	c.JSON(status, response_dto.NewDataResponse(response))
+}
+
+// BulkUpdateMetadata handles requests to change the metadata of many documents at once. Each document
+// is checked on its own, so the response reports the outcome of each document: 200 OK when all
+// documents were updated, 207 Multi-Status otherwise.
+func (h *DocumentHandler) BulkUpdateMetadata(c *gin.Context) {
+	// Extract user ID and tenant ID from the request context
+	userID := middleware.GetUserID(c)
+	tenantID := middleware.GetTenantID(c)
+
+	// Get logger with context
+	log := h.logger.WithContext(c.Request.Context())
+
+	var req document_dto.BulkMetadataUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.WithError(err).Error("Failed to bind request to BulkMetadataUpdateRequest struct")
+		c.AbortWithStatusJSON(http.StatusBadRequest, errdto.NewErrorResponse(c.Request.Context(), validators.BindingError(err)))
+		return
+	}
+	if err := req.Validate(); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, errdto.NewErrorResponse(c.Request.Context(), err))
+		return
+	}
+
+	patch := models.MetadataPatch{Set: req.Set, Remove: req.Remove}
+	results, err := h.documentUseCase.BulkUpdateMetadata(c.Request.Context(), req.DocumentIDs, patch, req.Atomic, tenantID, userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	response := document_dto.BulkMetadataUpdateResponse{Results: make([]document_dto.BulkMetadataDocumentResult, len(results))}
+	for i, result := range results {
+		if result.Err != nil {
+			detail := errdto.NewErrorDetail(c.Request.Context(), result.Err)
+			response.Results[i] = document_dto.BulkMetadataDocumentResult{DocumentID: result.DocumentID, Status: "failed", Error: &detail}
+			response.Failed++
+			continue
+		}
+		response.Results[i] = document_dto.BulkMetadataDocumentResult{DocumentID: result.DocumentID, Status: "updated"}
+		response.Succeeded++
+	}
+
+	status := http.StatusOK
+	if response.Failed > 0 {
+		status = http.StatusMultiStatus
+	}
+	c.JSON(status, response_dto.NewDataResponse(response))
+}
+
 // GetDocument handles requests to get document metadata
 func (h *DocumentHandler) GetDocument(c *gin.Context) {
 	// Extract document ID from the URL path
@@ -179,26 +366,189 @@ func (h *DocumentHandler) DownloadDocument(c *gin.Context) {
 	userID := middleware.GetUserID(c)
 	tenantID := middleware.GetTenantID(c)
 
+	// Call documentUseCase.DownloadDocumentRange with the document ID and the range headers
+	download, err := h.documentUseCase.DownloadDocumentRange(c.Request.Context(), id, tenantID, userID, c.GetHeader("Range"), c.GetHeader("If-Range"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	defer download.Content.Close()
+
+	h.writeDownload(c, download)
+}
+
+// ListDocumentVersions handles requests to list the versions of a document, newest first
+func (h *DocumentHandler) ListDocumentVersions(c *gin.Context) {
+	// Extract document ID from the URL path
+	id := c.Param("id")
+
+	// Extract user ID and tenant ID from the request context
+	userID := middleware.GetUserID(c)
+	tenantID := middleware.GetTenantID(c)
+
+	versions, err := h.documentUseCase.ListDocumentVersions(c.Request.Context(), id, tenantID, userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	dtos := make([]document_dto.DocumentVersionDTO, 0, len(versions))
+	for _, version := range versions {
+		dtos = append(dtos, document_dto.DocumentVersionToDTO(version))
+	}
+	c.JSON(http.StatusOK, response_dto.NewDataResponse(dtos))
+}
+
+// DownloadDocumentVersion handles requests to download a version of a document, supporting range
+// requests like the download of the latest version
+func (h *DocumentHandler) DownloadDocumentVersion(c *gin.Context) {
+	// Extract document and version IDs from the URL path
+	id := c.Param("id")
+	versionID := c.Param("versionId")
+
+	// Extract user ID and tenant ID from the request context
+	userID := middleware.GetUserID(c)
+	tenantID := middleware.GetTenantID(c)
+
+	download, err := h.documentUseCase.DownloadDocumentVersion(c.Request.Context(), id, versionID, tenantID, userID, c.GetHeader("Range"), c.GetHeader("If-Range"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	defer download.Content.Close()
+
+	h.writeDownload(c, download)
+}
+
+// GetVersionSignature handles requests for the block signature of a version of a document, which
+// clients build delta patches against. The signature is sent in its binary encoding; the
+// block_size query parameter sets the size of its blocks.
+func (h *DocumentHandler) GetVersionSignature(c *gin.Context) {
+	// Extract document and version IDs from the URL path
+	id := c.Param("id")
+	versionID := c.Param("versionId")
+
+	// Extract user ID and tenant ID from the request context
+	userID := middleware.GetUserID(c)
+	tenantID := middleware.GetTenantID(c)
+
 	// Get logger with context
 	log := h.logger.WithContext(c.Request.Context())
 
-	// Call documentUseCase.DownloadDocument with the document ID
-	contentStream, fileName, err := h.documentUseCase.DownloadDocument(c.Request.Context(), id, tenantID, userID)
+	blockSize := 0
+	if value := c.Query("block_size"); value != "" {
+		var err error
+		blockSize, err = strconv.Atoi(value)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errdto.NewErrorResponse(c.Request.Context(), errors.NewValidationError("block_size must be a number")))
+			return
+		}
+	}
+
+	signature, err := h.documentUseCase.GetVersionSignature(c.Request.Context(), id, versionID, blockSize, tenantID, userID)
 	if err != nil {
 		h.handleError(c, err)
 		return
 	}
-	defer contentStream.Close()
 
-	// Set appropriate content headers
-	c.Header("Content-Disposition", "attachment; filename="+fileName)
 	c.Header("Content-Type", "application/octet-stream")
+	c.Header("Content-Length", strconv.FormatInt(signature.EncodedSize(), 10))
+	c.Status(http.StatusOK)
+	if _, err := signature.WriteTo(c.Writer); err != nil {
+		log.WithError(err).Error("Failed to write version signature to response")
+		c.Abort()
+	}
+}
+
+// UploadVersionDelta handles requests uploading a new version of a document as a delta patch
+// against one of its versions. The version is scanned before it becomes available, so the
+// response is 202 Accepted with the processing version.
+func (h *DocumentHandler) UploadVersionDelta(c *gin.Context) {
+	// Extract document ID from the URL path
+	id := c.Param("id")
+
+	// Extract user ID and tenant ID from the request context
+	userID := middleware.GetUserID(c)
+	tenantID := middleware.GetTenantID(c)
+
+	// Get logger with context
+	log := h.logger.WithContext(c.Request.Context())
+
+	// Parse multipart form data
+	file, header, err := c.Request.FormFile("patch")
+	if err != nil {
+		log.WithError(err).Error("Failed to parse multipart form data")
+		c.AbortWithStatusJSON(http.StatusBadRequest, errdto.NewErrorResponse(c.Request.Context(), errors.NewValidationError("invalid form data: " + err.Error())))
+		return
+	}
+	defer file.Close()
+
+	var req document_dto.UploadVersionDeltaRequest
+	if err := c.ShouldBind(&req); err != nil {
+		log.WithError(err).Error("Failed to bind request to UploadVersionDeltaRequest struct")
+		c.AbortWithStatusJSON(http.StatusBadRequest, errdto.NewErrorResponse(c.Request.Context(), validators.BindingError(err)))
+		return
+	}
+	req.Patch = header
+	if err := req.Validate(); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, errdto.NewErrorResponse(c.Request.Context(), err))
+		return
+	}
+
+	version, err := h.documentUseCase.UploadVersionDelta(c.Request.Context(), id, req.BaseVersionID, req.Size, req.Sha256, file, tenantID, userID)
+
+	// Tell the client how much of the tenant's quota is left, whether or not the version was accepted
+	h.setQuotaHeaders(c, tenantID)
 
-	// Stream the document content to the response
-	_, err = io.Copy(c.Writer, contentStream)
 	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	log.Info("Document version uploaded as delta", "documentID", id, "versionID", version.ID, "patchSize", header.Size)
+	c.JSON(http.StatusAccepted, response_dto.NewDataResponse(document_dto.DocumentVersionToDTO(*version)))
+}
+
+// writeDownload streams downloaded content to the response, with the headers clients need to
+// resume it and 206 Partial Content for ranges
+func (h *DocumentHandler) writeDownload(c *gin.Context, download *usecases.DocumentDownload) {
+	// Get logger with context
+	log := h.logger.WithContext(c.Request.Context())
+
+	contentType := download.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	// Set appropriate content headers, advertising range support and the validators for If-Range
+	c.Header("Content-Disposition", "attachment; filename="+download.FileName)
+	c.Header("Content-Type", contentType)
+	if download.Watermarked {
+		// Watermarked copies are stamped for this download, so they are neither resumed nor cached
+		c.Header("Accept-Ranges", "none")
+		c.Header("Cache-Control", "no-store")
+	} else {
+		c.Header("Accept-Ranges", "bytes")
+		c.Header("ETag", download.ETag)
+		c.Header("Last-Modified", download.LastModified.UTC().Format(http.TimeFormat))
+	}
+
+	// Answer a satisfiable range with 206 Partial Content
+	status := http.StatusOK
+	contentLength := download.Size
+	if download.Range != nil {
+		status = http.StatusPartialContent
+		contentLength = download.Range.Length
+		c.Header("Content-Range", download.Range.ContentRange(download.Size))
+	}
+	c.Header("Content-Length", strconv.FormatInt(contentLength, 10))
+	c.Status(status)
+
+	// Stream the document content to the response
+	if _, err := io.Copy(c.Writer, download.Content); err != nil {
+		// The status and part of the content have already been sent, so the client sees a truncated download
 		log.WithError(err).Error("Failed to stream document content to response")
-		c.AbortWithStatusJSON(http.StatusInternalServerError, errdto.NewErrorResponse(errors.NewInternalError("failed to stream document content: " + err.Error())))
+		c.Abort()
 		return
 	}
 }
@@ -220,7 +570,7 @@ func (h *DocumentHandler) GetDocumentDownloadURL(c *gin.Context) {
 	expirationSeconds, err := strconv.Atoi(expirationStr)
 	if err != nil {
 		log.WithError(err).Error("Invalid expiration time in query parameters")
-		c.AbortWithStatusJSON(http.StatusBadRequest, errdto.NewErrorResponse(errors.NewValidationError("invalid expiration time: " + err.Error())))
+		c.AbortWithStatusJSON(http.StatusBadRequest, errdto.NewErrorResponse(c.Request.Context(), errors.NewValidationError("invalid expiration time: " + err.Error())))
 		return
 	}
 
@@ -252,14 +602,14 @@ func (h *DocumentHandler) BatchDownloadDocuments(c *gin.Context) {
 	var req document_dto.BatchDownloadRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		log.WithError(err).Error("Failed to bind request to BatchDownloadRequest struct")
-		c.AbortWithStatusJSON(http.StatusBadRequest, errdto.NewErrorResponse(errors.NewValidationError("invalid request payload: " + err.Error())))
+		c.AbortWithStatusJSON(http.StatusBadRequest, errdto.NewErrorResponse(c.Request.Context(), validators.BindingError(err)))
 		return
 	}
 
 	// Validate the request
 	if err := validator.Validate(req); err != nil {
 		log.WithError(err).Error("Invalid request")
-		c.AbortWithStatusJSON(http.StatusBadRequest, errdto.NewErrorResponse(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, errdto.NewErrorResponse(c.Request.Context(), err))
 		return
 	}
 
@@ -275,11 +625,20 @@ func (h *DocumentHandler) BatchDownloadDocuments(c *gin.Context) {
 	c.Header("Content-Disposition", "attachment; filename=documents.zip")
 	c.Header("Content-Type", "application/zip")
 
-	// Stream the archive content to the response
+	// Stream the archive content to the response as it is built
 	_, err = io.Copy(c.Writer, contentStream)
 	if err != nil {
+		if !c.Writer.Written() {
+			// Nothing was sent yet, such as when the first document cannot be read, so the error can still be reported
+			c.Writer.Header().Del("Content-Disposition")
+			c.Writer.Header().Del("Content-Type")
+			h.handleError(c, err)
+			return
+		}
+
+		// The status and part of the archive have already been sent, so the client sees a truncated download
 		log.WithError(err).Error("Failed to stream archive content to response")
-		c.AbortWithStatusJSON(http.StatusInternalServerError, errdto.NewErrorResponse(errors.NewInternalError("failed to stream archive content: " + err.Error())))
+		c.Abort()
 		return
 	}
 }
@@ -297,14 +656,14 @@ func (h *DocumentHandler) GetBatchDownloadURL(c *gin.Context) {
 	var req document_dto.BatchDownloadRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		log.WithError(err).Error("Failed to bind request to BatchDownloadRequest struct")
-		c.AbortWithStatusJSON(http.StatusBadRequest, errdto.NewErrorResponse(errors.NewValidationError("invalid request payload: " + err.Error())))
+		c.AbortWithStatusJSON(http.StatusBadRequest, errdto.NewErrorResponse(c.Request.Context(), validators.BindingError(err)))
 		return
 	}
 
 	// Validate the request
 	if err := validator.Validate(req); err != nil {
 		log.WithError(err).Error("Invalid request")
-		c.AbortWithStatusJSON(http.StatusBadRequest, errdto.NewErrorResponse(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, errdto.NewErrorResponse(c.Request.Context(), err))
 		return
 	}
 
@@ -313,7 +672,7 @@ func (h *DocumentHandler) GetBatchDownloadURL(c *gin.Context) {
 	expirationSeconds, err := strconv.Atoi(expirationStr)
 	if err != nil {
 		log.WithError(err).Error("Invalid expiration time in query parameters")
-		c.AbortWithStatusJSON(http.StatusBadRequest, errdto.NewErrorResponse(errors.NewValidationError("invalid expiration time: " + err.Error())))
+		c.AbortWithStatusJSON(http.StatusBadRequest, errdto.NewErrorResponse(c.Request.Context(), errors.NewValidationError("invalid expiration time: " + err.Error())))
 		return
 	}
 
@@ -386,7 +745,7 @@ func (h *DocumentHandler) GetDocumentThumbnail(c *gin.Context) {
 	_, err = io.Copy(c.Writer, contentStream)
 	if err != nil {
 		log.WithError(err).Error("Failed to stream thumbnail content to response")
-		c.AbortWithStatusJSON(http.StatusInternalServerError, errdto.NewErrorResponse(errors.NewInternalError("failed to stream thumbnail content: " + err.Error())))
+		c.AbortWithStatusJSON(http.StatusInternalServerError, errdto.NewErrorResponse(c.Request.Context(), errors.NewInternalError("failed to stream thumbnail content: " + err.Error())))
 		return
 	}
 }
@@ -408,7 +767,7 @@ func (h *DocumentHandler) GetDocumentThumbnailURL(c *gin.Context) {
 	expirationSeconds, err := strconv.Atoi(expirationStr)
 	if err != nil {
 		log.WithError(err).Error("Invalid expiration time in query parameters")
-		c.AbortWithStatusJSON(http.StatusBadRequest, errdto.NewErrorResponse(errors.NewValidationError("invalid expiration time: " + err.Error())))
+		c.AbortWithStatusJSON(http.StatusBadRequest, errdto.NewErrorResponse(c.Request.Context(), errors.NewValidationError("invalid expiration time: " + err.Error())))
 		return
 	}
 
@@ -443,14 +802,14 @@ func (h *DocumentHandler) UpdateDocument(c *gin.Context) {
 	var req document_dto.UpdateDocumentRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		log.WithError(err).Error("Failed to bind request to UpdateDocumentRequest struct")
-		c.AbortWithStatusJSON(http.StatusBadRequest, errdto.NewErrorResponse(errors.NewValidationError("invalid request payload: " + err.Error())))
+		c.AbortWithStatusJSON(http.StatusBadRequest, errdto.NewErrorResponse(c.Request.Context(), validators.BindingError(err)))
 		return
 	}
 
 	// Validate the request
 	if err := validator.Validate(req); err != nil {
 		log.WithError(err).Error("Invalid request")
-		c.AbortWithStatusJSON(http.StatusBadRequest, errdto.NewErrorResponse(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, errdto.NewErrorResponse(c.Request.Context(), err))
 		return
 	}
 
@@ -467,6 +826,59 @@ func (h *DocumentHandler) UpdateDocument(c *gin.Context) {
 	fmt.Println("Implement UpdateDocument")
 }
 
+// ScheduleDocument handles requests to set when a document is published to readers and when it
+// expires, so that documents can be staged to go live at a given time
+func (h *DocumentHandler) ScheduleDocument(c *gin.Context) {
+	// Extract document ID from the URL path
+	id := c.Param("id")
+
+	// Extract user ID and tenant ID from the request context
+	userID := middleware.GetUserID(c)
+	tenantID := middleware.GetTenantID(c)
+
+	// Get logger with context
+	log := h.logger.WithContext(c.Request.Context())
+
+	var req document_dto.ScheduleDocumentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.WithError(err).Error("Failed to bind request to ScheduleDocumentRequest struct")
+		c.AbortWithStatusJSON(http.StatusBadRequest, errdto.NewErrorResponse(c.Request.Context(), validators.BindingError(err)))
+		return
+	}
+
+	document, err := h.documentUseCase.ScheduleDocument(c.Request.Context(), id, req.PublishAt, req.ExpireAt, tenantID, userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	log.Info("Document scheduled successfully", "documentID", id, "visibility", document.Visibility)
+	c.JSON(http.StatusOK, response_dto.NewDataResponse(document_dto.DocumentToDTO(*document)))
+}
+
+// VerifyIntegrity handles requests to read a document's stored content back and hash it again. A
+// corrupted document is reported with intact set to false, not as an error.
+func (h *DocumentHandler) VerifyIntegrity(c *gin.Context) {
+	// Extract document ID from the URL path
+	id := c.Param("id")
+
+	// Extract user ID and tenant ID from the request context
+	userID := middleware.GetUserID(c)
+	tenantID := middleware.GetTenantID(c)
+
+	// Get logger with context
+	log := h.logger.WithContext(c.Request.Context())
+
+	check, err := h.documentUseCase.VerifyIntegrity(c.Request.Context(), id, tenantID, userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	log.Info("Document integrity verified", "documentID", id, "intact", check.Intact)
+	c.JSON(http.StatusOK, response_dto.NewDataResponse(document_dto.IntegrityCheckToResponse(*check)))
+}
+
 // DeleteDocument handles requests to delete a document
 func (h *DocumentHandler) DeleteDocument(c *gin.Context) {
 	// Extract document ID from the URL path
@@ -524,6 +936,25 @@ func (h *DocumentHandler) SearchDocuments(c *gin.Context) {
 	fmt.Println("Implement SearchDocuments")
 }
 
+// setQuotaHeaders sets the limits of the tenant's quota and what is left of them as response headers.
+// Unlimited quotas are left out.
+func (h *DocumentHandler) setQuotaHeaders(c *gin.Context, tenantID string) {
+	quota, err := h.documentUseCase.GetQuota(c.Request.Context(), tenantID)
+	if err != nil {
+		h.logger.WithContext(c.Request.Context()).WithError(err).Warn("Failed to get tenant quota for response headers")
+		return
+	}
+
+	if quota.MaxStorageBytes > 0 {
+		c.Header(headerQuotaStorageLimit, strconv.FormatInt(quota.MaxStorageBytes, 10))
+		c.Header(headerQuotaStorageRemaining, strconv.FormatInt(quota.RemainingStorageBytes(), 10))
+	}
+	if quota.MaxDocuments > 0 {
+		c.Header(headerQuotaDocumentsLimit, strconv.FormatInt(quota.MaxDocuments, 10))
+		c.Header(headerQuotaDocumentsRemaining, strconv.FormatInt(quota.RemainingDocuments(), 10))
+	}
+}
+
 // handleError handles errors and returns appropriate HTTP responses
 func (h *DocumentHandler) handleError(c *gin.Context, err error) {
 	// Log the error with context
@@ -532,17 +963,28 @@ func (h *DocumentHandler) handleError(c *gin.Context, err error) {
 	// Check error type using errors package functions
 	// Return appropriate error response based on error type
 	switch {
+	case err == usecases.ErrRangeNotSatisfiable:
+		// For ranges outside of the document, return 416 Range Not Satisfiable
+		c.AbortWithStatusJSON(http.StatusRequestedRangeNotSatisfiable, errdto.NewErrorResponse(c.Request.Context(), err))
 	case errors.IsValidationError(err):
 		// For validation errors, return 400 Bad Request
-		c.AbortWithStatusJSON(http.StatusBadRequest, errdto.NewErrorResponse(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, errdto.NewErrorResponse(c.Request.Context(), err))
 	case errors.IsResourceNotFoundError(err):
 		// For resource not found errors, return 404 Not Found
-		c.AbortWithStatusJSON(http.StatusNotFound, errdto.NewErrorResponse(err))
+		c.AbortWithStatusJSON(http.StatusNotFound, errdto.NewErrorResponse(c.Request.Context(), err))
+	case errors.IsAuthenticationError(err):
+		// For downloads that need a more recent sign-in, return 401 Unauthorized
+		c.AbortWithStatusJSON(http.StatusUnauthorized, errdto.NewErrorResponse(c.Request.Context(), err))
 	case errors.IsAuthorizationError(err):
 		// For authorization errors, return 403 Forbidden
-		c.AbortWithStatusJSON(http.StatusForbidden, errdto.NewErrorResponse(err))
+		c.AbortWithStatusJSON(http.StatusForbidden, errdto.NewErrorResponse(c.Request.Context(), err))
+	case errors.IsQuotaExceededError(err):
+		// For quota errors, return 413 Request Entity Too Large when the document does not fit the
+		// remaining storage, or 429 Too Many Requests when the tenant has too many documents or the
+		// user reached the daily download limit of a folder
+		c.AbortWithStatusJSON(errors.GetStatusCode(err), errdto.NewErrorResponse(c.Request.Context(), err))
 	default:
 		// For other errors, return 500 Internal Server Error
-		c.AbortWithStatusJSON(http.StatusInternalServerError, errdto.NewErrorResponse(errors.NewInternalErrorResponse(err)))
+		c.AbortWithStatusJSON(http.StatusInternalServerError, errdto.NewErrorResponse(c.Request.Context(), errors.NewInternalErrorResponse(err)))
 	}
 }
\ No newline at end of file
@@ -0,0 +1,510 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"../../application/usecases"
+	"../../domain/models"
+	"../../domain/services"
+	"../../pkg/delta"
+	apperrors "../../pkg/errors"
+	"../../pkg/utils"
+)
+
+// MockDocumentUseCase mocks the DocumentUseCase methods used by the batch upload endpoint
+type MockDocumentUseCase struct {
+	usecases.DocumentUseCase
+	mock.Mock
+}
+
+func (m *MockDocumentUseCase) UploadDocuments(ctx context.Context, uploads []usecases.DocumentUpload, folderID string, tenantID string, userID string) ([]usecases.DocumentUploadResult, error) {
+	names := make([]string, len(uploads))
+	for i, upload := range uploads {
+		names[i] = upload.Name
+	}
+	args := m.Called(ctx, names, folderID, tenantID, userID)
+	if results := args.Get(0); results != nil {
+		return results.([]usecases.DocumentUploadResult), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockDocumentUseCase) DownloadDocumentRange(ctx context.Context, id string, tenantID string, userID string, rangeHeader string, ifRange string) (*usecases.DocumentDownload, error) {
+	args := m.Called(ctx, id, tenantID, userID, rangeHeader, ifRange)
+	if download := args.Get(0); download != nil {
+		return download.(*usecases.DocumentDownload), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockDocumentUseCase) DownloadDocumentVersion(ctx context.Context, id string, versionID string, tenantID string, userID string, rangeHeader string, ifRange string) (*usecases.DocumentDownload, error) {
+	args := m.Called(ctx, id, versionID, tenantID, userID, rangeHeader, ifRange)
+	if download := args.Get(0); download != nil {
+		return download.(*usecases.DocumentDownload), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockDocumentUseCase) ListDocumentVersions(ctx context.Context, id string, tenantID string, userID string) ([]models.DocumentVersion, error) {
+	args := m.Called(ctx, id, tenantID, userID)
+	if versions := args.Get(0); versions != nil {
+		return versions.([]models.DocumentVersion), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockDocumentUseCase) GetVersionSignature(ctx context.Context, id string, versionID string, blockSize int, tenantID string, userID string) (*delta.Signature, error) {
+	args := m.Called(ctx, id, versionID, blockSize, tenantID, userID)
+	if signature := args.Get(0); signature != nil {
+		return signature.(*delta.Signature), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockDocumentUseCase) UploadVersionDelta(ctx context.Context, id string, baseVersionID string, size int64, checksum string, patch io.Reader, tenantID string, userID string) (*models.DocumentVersion, error) {
+	content, _ := io.ReadAll(patch)
+	args := m.Called(ctx, id, baseVersionID, size, checksum, string(content), tenantID, userID)
+	if version := args.Get(0); version != nil {
+		return version.(*models.DocumentVersion), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockDocumentUseCase) BulkUpdateMetadata(ctx context.Context, documentIDs []string, patch models.MetadataPatch, atomic bool, tenantID string, userID string) ([]usecases.BulkMetadataResult, error) {
+	args := m.Called(ctx, documentIDs, patch, atomic, tenantID, userID)
+	if results := args.Get(0); results != nil {
+		return results.([]usecases.BulkMetadataResult), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockDocumentUseCase) ScheduleDocument(ctx context.Context, id string, publishAt, expireAt *time.Time, tenantID string, userID string) (*models.Document, error) {
+	args := m.Called(ctx, id, publishAt, expireAt, tenantID, userID)
+	if document := args.Get(0); document != nil {
+		return document.(*models.Document), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockDocumentUseCase) VerifyIntegrity(ctx context.Context, id string, tenantID string, userID string) (*models.IntegrityCheck, error) {
+	args := m.Called(ctx, id, tenantID, userID)
+	if check := args.Get(0); check != nil {
+		return check.(*models.IntegrityCheck), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockDocumentUseCase) BatchDownloadDocuments(ctx context.Context, ids []string, tenantID string, userID string) (io.ReadCloser, error) {
+	args := m.Called(ctx, ids, tenantID, userID)
+	if content := args.Get(0); content != nil {
+		return content.(io.ReadCloser), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockDocumentUseCase) GetQuota(ctx context.Context, tenantID string) (*models.TenantQuota, error) {
+	return &models.TenantQuota{TenantID: tenantID}, nil
+}
+
+// DocumentHandlerSuite defines the test suite
+type DocumentHandlerSuite struct {
+	suite.Suite
+	router          *gin.Engine
+	recorder        *httptest.ResponseRecorder
+	documentUseCase *MockDocumentUseCase
+}
+
+// SetupTest is called before each test
+func (s *DocumentHandlerSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	s.router = gin.New()
+	s.recorder = httptest.NewRecorder()
+
+	s.documentUseCase = new(MockDocumentUseCase)
+	handler, err := NewDocumentHandler(s.documentUseCase)
+	s.Require().NoError(err)
+
+	group := s.router.Group("/api/v1")
+	group.Use(func(c *gin.Context) {
+		c.Set("tenant_id", "tenant-123")
+		c.Set("user_id", "user-123")
+		c.Next()
+	})
+	handler.RegisterRoutes(group)
+}
+
+// newBatchUploadRequest builds a multipart batch upload of the named files
+func (s *DocumentHandlerSuite) newBatchUploadRequest(folderID string, names ...string) *http.Request {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	if folderID != "" {
+		s.Require().NoError(writer.WriteField("folder_id", folderID))
+	}
+	for _, name := range names {
+		part, err := writer.CreateFormFile("files", name)
+		s.Require().NoError(err)
+		_, err = part.Write([]byte("content of " + name))
+		s.Require().NoError(err)
+	}
+	s.Require().NoError(writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/documents/batch", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+// TestUploadDocuments_AllAccepted tests that a batch whose files are all accepted returns 202
+func (s *DocumentHandlerSuite) TestUploadDocuments_AllAccepted() {
+	s.documentUseCase.On("UploadDocuments", mock.Anything, []string{"a.pdf", "b.pdf"}, "folder-123", "tenant-123", "user-123").
+		Return([]usecases.DocumentUploadResult{{Name: "a.pdf", DocumentID: "doc-1"}, {Name: "b.pdf", DocumentID: "doc-2"}}, nil)
+
+	s.router.ServeHTTP(s.recorder, s.newBatchUploadRequest("folder-123", "a.pdf", "b.pdf"))
+
+	s.Equal(http.StatusAccepted, s.recorder.Code)
+	var response struct {
+		Data struct {
+			Results []struct {
+				Name       string `json:"name"`
+				DocumentID string `json:"document_id"`
+				Status     string `json:"status"`
+			} `json:"results"`
+			Succeeded int `json:"succeeded"`
+			Failed    int `json:"failed"`
+		} `json:"data"`
+	}
+	s.Require().NoError(json.Unmarshal(s.recorder.Body.Bytes(), &response))
+	s.Equal(2, response.Data.Succeeded)
+	s.Equal(0, response.Data.Failed)
+	s.Equal("doc-2", response.Data.Results[1].DocumentID)
+	s.Equal("processing", response.Data.Results[1].Status)
+}
+
+// TestUploadDocuments_PartialFailure tests that a batch with rejected files returns 207 with the error of each file
+func (s *DocumentHandlerSuite) TestUploadDocuments_PartialFailure() {
+	s.documentUseCase.On("UploadDocuments", mock.Anything, []string{"a.pdf", "b.exe"}, "folder-123", "tenant-123", "user-123").
+		Return([]usecases.DocumentUploadResult{
+			{Name: "a.pdf", DocumentID: "doc-1"},
+			{Name: "b.exe", Err: apperrors.NewValidationError("file type is not allowed")},
+		}, nil)
+
+	s.router.ServeHTTP(s.recorder, s.newBatchUploadRequest("folder-123", "a.pdf", "b.exe"))
+
+	s.Equal(http.StatusMultiStatus, s.recorder.Code)
+	s.Contains(s.recorder.Body.String(), `"failed":1`)
+	s.Contains(s.recorder.Body.String(), "file type is not allowed")
+}
+
+// TestUploadDocuments_MissingFolder tests that a batch without a folder is rejected
+func (s *DocumentHandlerSuite) TestUploadDocuments_MissingFolder() {
+	s.router.ServeHTTP(s.recorder, s.newBatchUploadRequest("", "a.pdf"))
+
+	s.Equal(http.StatusBadRequest, s.recorder.Code)
+	s.documentUseCase.AssertNotCalled(s.T(), "UploadDocuments", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestBulkUpdateMetadata_PartialFailure tests that a bulk metadata update with rejected documents
+// returns 207 with the error of each document
+func (s *DocumentHandlerSuite) TestBulkUpdateMetadata_PartialFailure() {
+	patch := models.MetadataPatch{Set: map[string]string{"status": "reviewed"}}
+	s.documentUseCase.On("BulkUpdateMetadata", mock.Anything, []string{"doc-1", "doc-2"}, patch, false, "tenant-123", "user-123").
+		Return([]usecases.BulkMetadataResult{
+			{DocumentID: "doc-1"},
+			{DocumentID: "doc-2", Err: apperrors.NewResourceNotFoundError("document not found")},
+		}, nil)
+
+	body := `{"document_ids":["doc-1","doc-2"],"set":{"status":"reviewed"}}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/documents/metadata/bulk", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusMultiStatus, s.recorder.Code)
+	s.Contains(s.recorder.Body.String(), `"succeeded":1`)
+	s.Contains(s.recorder.Body.String(), "document not found")
+	s.documentUseCase.AssertExpectations(s.T())
+}
+
+// TestBulkUpdateMetadata_EmptyPatch tests that a bulk metadata update changing no key is rejected
+func (s *DocumentHandlerSuite) TestBulkUpdateMetadata_EmptyPatch() {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/documents/metadata/bulk", strings.NewReader(`{"document_ids":["doc-1"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusBadRequest, s.recorder.Code)
+	s.documentUseCase.AssertNotCalled(s.T(), "BulkUpdateMetadata", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestDownloadDocument_Range tests that a range request is answered with 206 and the requested bytes
+func (s *DocumentHandlerSuite) TestDownloadDocument_Range() {
+	s.documentUseCase.On("DownloadDocumentRange", mock.Anything, "doc-1", "tenant-123", "user-123", "bytes=4-7", "").
+		Return(&usecases.DocumentDownload{
+			Content:      io.NopCloser(strings.NewReader("4567")),
+			FileName:     "clip.mp4",
+			ContentType:  "video/mp4",
+			Size:         10,
+			ETag:         `"abc"`,
+			LastModified: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+			Range:        &utils.ByteRange{Start: 4, Length: 4},
+		}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/documents/doc-1/content", nil)
+	req.Header.Set("Range", "bytes=4-7")
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusPartialContent, s.recorder.Code)
+	s.Equal("bytes 4-7/10", s.recorder.Header().Get("Content-Range"))
+	s.Equal("4", s.recorder.Header().Get("Content-Length"))
+	s.Equal("bytes", s.recorder.Header().Get("Accept-Ranges"))
+	s.Equal(`"abc"`, s.recorder.Header().Get("ETag"))
+	s.Equal("video/mp4", s.recorder.Header().Get("Content-Type"))
+	s.Equal("4567", s.recorder.Body.String())
+}
+
+// TestListDocumentVersions tests that the versions of a document are listed
+func (s *DocumentHandlerSuite) TestListDocumentVersions() {
+	s.documentUseCase.On("ListDocumentVersions", mock.Anything, "doc-1", "tenant-123", "user-123").
+		Return([]models.DocumentVersion{
+			{ID: "ver-2", DocumentID: "doc-1", VersionNumber: 2, Status: models.VersionStatusProcessing},
+			{ID: "ver-1", DocumentID: "doc-1", VersionNumber: 1, Status: models.VersionStatusAvailable},
+		}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/documents/doc-1/versions", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusOK, s.recorder.Code)
+	var resp struct {
+		Data []struct {
+			ID            string `json:"id"`
+			VersionNumber int    `json:"version_number"`
+			Status        string `json:"status"`
+		} `json:"data"`
+	}
+	s.Require().NoError(json.Unmarshal(s.recorder.Body.Bytes(), &resp))
+	s.Require().Len(resp.Data, 2)
+	s.Equal("ver-2", resp.Data[0].ID)
+	s.Equal(models.VersionStatusAvailable, resp.Data[1].Status)
+}
+
+// TestDownloadDocumentVersion tests that a version is downloaded with the validators of its content
+func (s *DocumentHandlerSuite) TestDownloadDocumentVersion() {
+	s.documentUseCase.On("DownloadDocumentVersion", mock.Anything, "doc-1", "ver-1", "tenant-123", "user-123", "", "").
+		Return(&usecases.DocumentDownload{
+			Content:      io.NopCloser(strings.NewReader("first version")),
+			FileName:     "report.pdf",
+			ContentType:  "application/pdf",
+			Size:         13,
+			ETag:         `"hash-1"`,
+			LastModified: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/documents/doc-1/versions/ver-1/content", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusOK, s.recorder.Code)
+	s.Equal(`"hash-1"`, s.recorder.Header().Get("ETag"))
+	s.Equal("13", s.recorder.Header().Get("Content-Length"))
+	s.Equal("first version", s.recorder.Body.String())
+}
+
+// TestDownloadDocumentVersion_NotFound tests that unknown versions are answered with 404
+func (s *DocumentHandlerSuite) TestDownloadDocumentVersion_NotFound() {
+	s.documentUseCase.On("DownloadDocumentVersion", mock.Anything, "doc-1", "ver-9", "tenant-123", "user-123", "", "").
+		Return(nil, usecases.ErrVersionNotFound)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/documents/doc-1/versions/ver-9/content", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusNotFound, s.recorder.Code)
+}
+
+// TestGetVersionSignature tests that the signature of a version is sent in its binary encoding
+func (s *DocumentHandlerSuite) TestGetVersionSignature() {
+	signature, err := delta.ComputeSignature(strings.NewReader(strings.Repeat("version content ", 100)), delta.MinBlockSize)
+	s.Require().NoError(err)
+	s.documentUseCase.On("GetVersionSignature", mock.Anything, "doc-1", "ver-1", delta.MinBlockSize, "tenant-123", "user-123").Return(signature, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/documents/doc-1/versions/ver-1/signature?block_size=512", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusOK, s.recorder.Code)
+	decoded, err := delta.ReadSignature(s.recorder.Body)
+	s.Require().NoError(err)
+	s.Equal(signature, decoded)
+}
+
+// TestUploadVersionDelta tests that a delta upload is answered with 202 and the processing version
+func (s *DocumentHandlerSuite) TestUploadVersionDelta() {
+	checksum := "6ae8a75555209fd6c44157c0aed8016e763ff435a19cf186f76863140143ff72"
+	s.documentUseCase.On("UploadVersionDelta", mock.Anything, "doc-1", "ver-1", int64(2048), checksum, "patch bytes", "tenant-123", "user-123").
+		Return(&models.DocumentVersion{ID: "ver-2", DocumentID: "doc-1", VersionNumber: 2, Status: models.VersionStatusProcessing}, nil)
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	s.Require().NoError(writer.WriteField("base_version_id", "ver-1"))
+	s.Require().NoError(writer.WriteField("size", "2048"))
+	s.Require().NoError(writer.WriteField("sha256", checksum))
+	part, err := writer.CreateFormFile("patch", "patch.bin")
+	s.Require().NoError(err)
+	_, err = part.Write([]byte("patch bytes"))
+	s.Require().NoError(err)
+	s.Require().NoError(writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/documents/doc-1/versions/delta", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusAccepted, s.recorder.Code)
+	s.Contains(s.recorder.Body.String(), `"version_number":2`)
+	s.documentUseCase.AssertExpectations(s.T())
+}
+
+// TestUploadVersionDelta_MissingChecksum tests that delta uploads without a checksum are answered with 400
+func (s *DocumentHandlerSuite) TestUploadVersionDelta_MissingChecksum() {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	s.Require().NoError(writer.WriteField("base_version_id", "ver-1"))
+	s.Require().NoError(writer.WriteField("size", "2048"))
+	part, err := writer.CreateFormFile("patch", "patch.bin")
+	s.Require().NoError(err)
+	_, err = part.Write([]byte("patch bytes"))
+	s.Require().NoError(err)
+	s.Require().NoError(writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/documents/doc-1/versions/delta", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusBadRequest, s.recorder.Code)
+	s.documentUseCase.AssertNotCalled(s.T(), "UploadVersionDelta", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestDownloadDocument_RangeNotSatisfiable tests that a range outside of the document is answered with 416
+func (s *DocumentHandlerSuite) TestDownloadDocument_RangeNotSatisfiable() {
+	s.documentUseCase.On("DownloadDocumentRange", mock.Anything, "doc-1", "tenant-123", "user-123", "bytes=20-", "").
+		Return(nil, usecases.ErrRangeNotSatisfiable)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/documents/doc-1/content", nil)
+	req.Header.Set("Range", "bytes=20-")
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusRequestedRangeNotSatisfiable, s.recorder.Code)
+}
+
+// TestDownloadDocument_ReauthenticationRequired tests that downloads needing a more recent sign-in
+// are answered with 401, so that clients ask the user to sign in again
+func (s *DocumentHandlerSuite) TestDownloadDocument_ReauthenticationRequired() {
+	s.documentUseCase.On("DownloadDocumentRange", mock.Anything, "doc-1", "tenant-123", "user-123", "", "").
+		Return(nil, services.ErrReauthenticationRequired)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/documents/doc-1/content", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusUnauthorized, s.recorder.Code)
+}
+
+// TestDownloadDocument_DailyLimitReached tests that downloads past the daily limit of a folder are
+// answered with 429
+func (s *DocumentHandlerSuite) TestDownloadDocument_DailyLimitReached() {
+	s.documentUseCase.On("DownloadDocumentRange", mock.Anything, "doc-1", "tenant-123", "user-123", "", "").
+		Return(nil, services.ErrDownloadLimitReached)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/documents/doc-1/content", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusTooManyRequests, s.recorder.Code)
+}
+
+// TestBatchDownloadDocuments_FirstDocumentFails tests that an archive failing before any of it was
+// sent is answered with an error rather than an empty archive
+func (s *DocumentHandlerSuite) TestBatchDownloadDocuments_FirstDocumentFails() {
+	reader, writer := io.Pipe()
+	writer.CloseWithError(apperrors.NewResourceNotFoundError("document not found"))
+	s.documentUseCase.On("BatchDownloadDocuments", mock.Anything, []string{"doc-1", "doc-2"}, "tenant-123", "user-123").
+		Return(reader, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/documents/batch/download", strings.NewReader(`{"document_ids":["doc-1","doc-2"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusNotFound, s.recorder.Code)
+	s.Empty(s.recorder.Header().Get("Content-Disposition"))
+	s.Contains(s.recorder.Header().Get("Content-Type"), "json")
+}
+
+// TestScheduleDocument_Success tests that scheduling a document returns it with its schedule
+func (s *DocumentHandlerSuite) TestScheduleDocument_Success() {
+	publishAt := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	document := &models.Document{ID: "doc-1", TenantID: "tenant-123", Name: "launch.pdf", Visibility: models.DocumentVisibilityScheduled, PublishAt: &publishAt}
+	s.documentUseCase.On("ScheduleDocument", mock.Anything, "doc-1", mock.AnythingOfType("*time.Time"), (*time.Time)(nil), "tenant-123", "user-123").
+		Return(document, nil)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/documents/doc-1/schedule", strings.NewReader(`{"publish_at":"2030-01-01T00:00:00Z"}`))
+	req.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusOK, s.recorder.Code)
+	s.Contains(s.recorder.Body.String(), `"visibility":"scheduled"`)
+	s.Contains(s.recorder.Body.String(), `"publish_at"`)
+	s.documentUseCase.AssertExpectations(s.T())
+}
+
+// TestScheduleDocument_ExpiryBeforePublication tests that a schedule the use case rejects is answered with 400
+func (s *DocumentHandlerSuite) TestScheduleDocument_ExpiryBeforePublication() {
+	s.documentUseCase.On("ScheduleDocument", mock.Anything, "doc-1", mock.AnythingOfType("*time.Time"), mock.AnythingOfType("*time.Time"), "tenant-123", "user-123").
+		Return(nil, apperrors.NewValidationError(models.ErrDocumentExpiryBeforePublication.Error()))
+
+	body := `{"publish_at":"2030-01-02T00:00:00Z","expire_at":"2030-01-01T00:00:00Z"}`
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/documents/doc-1/schedule", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusBadRequest, s.recorder.Code)
+}
+
+// TestVerifyIntegrity_Corrupted tests that corrupted content is reported with 200 and intact set to false
+func (s *DocumentHandlerSuite) TestVerifyIntegrity_Corrupted() {
+	check := &models.IntegrityCheck{
+		DocumentID:   "doc-1",
+		VersionID:    "version-1",
+		ExpectedHash: "6ae8a75555209fd6c44157c0aed8016e763ff435a19cf186f76863140143ff72",
+		ActualHash:   "923b805711041e23a99f07e146591c500261d1c289f62a9d39f8581ceb8a10ca",
+		CheckedAt:    time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	s.documentUseCase.On("VerifyIntegrity", mock.Anything, "doc-1", "tenant-123", "user-123").Return(check, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/documents/doc-1/verify-integrity", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusOK, s.recorder.Code)
+	s.Contains(s.recorder.Body.String(), `"intact":false`)
+	s.Contains(s.recorder.Body.String(), `"actual_hash":"923b805711041e23a99f07e146591c500261d1c289f62a9d39f8581ceb8a10ca"`)
+	s.documentUseCase.AssertExpectations(s.T())
+}
+
+// TestVerifyIntegrity_NoContentHash tests that documents without a hash to verify are answered with 400
+func (s *DocumentHandlerSuite) TestVerifyIntegrity_NoContentHash() {
+	s.documentUseCase.On("VerifyIntegrity", mock.Anything, "doc-1", "tenant-123", "user-123").
+		Return(nil, apperrors.NewValidationError(models.ErrNoContentHash.Error()))
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/documents/doc-1/verify-integrity", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusBadRequest, s.recorder.Code)
+}
+
+// TestDocumentHandlerSuite runs the test suite
+func TestDocumentHandlerSuite(t *testing.T) {
+	suite.Run(t, new(DocumentHandlerSuite))
+}
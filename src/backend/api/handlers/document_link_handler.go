@@ -0,0 +1,228 @@
+// Package handlers implements HTTP handlers for document links in the Document Management Platform.
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin" // v1.9.0+
+
+	"../../application/usecases"
+	"../../pkg/errors"
+	"../../pkg/logger"
+	"../dto"
+	"../middleware"
+	"../validators"
+)
+
+// DocumentLinkHandler handles HTTP requests for the links between documents
+type DocumentLinkHandler struct {
+	linkUseCase usecases.DocumentLinkUseCase
+}
+
+// NewDocumentLinkHandler creates a new DocumentLinkHandler instance
+func NewDocumentLinkHandler(linkUseCase usecases.DocumentLinkUseCase) (*DocumentLinkHandler, error) {
+	if linkUseCase == nil {
+		return nil, errors.NewValidationError("document link use case cannot be nil")
+	}
+
+	return &DocumentLinkHandler{
+		linkUseCase: linkUseCase,
+	}, nil
+}
+
+// RegisterRoutes registers document link routes with the provided router group
+func (h *DocumentLinkHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.POST("/documents/:id/links", h.CreateLink)
+	router.GET("/documents/:id/links", h.ListLinks)
+	router.GET("/documents/:id/links/:linkId", h.GetLink)
+	router.PUT("/documents/:id/links/:linkId", h.UpdateLink)
+	router.DELETE("/documents/:id/links/:linkId", h.DeleteLink)
+}
+
+// CreateLink handles requests to link a document to a target document
+func (h *DocumentLinkHandler) CreateLink(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	tenantID, userID, documentID, ok := h.getDocumentParams(c)
+	if !ok {
+		return
+	}
+
+	var req dto.CreateDocumentLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.WithError(err).Error("failed to bind request body")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			validators.BindingError(err),
+			nil,
+		))
+		return
+	}
+
+	// Call use case to create the link
+	link, err := h.linkUseCase.CreateLink(c.Request.Context(), documentID, req.TargetID, req.Type, tenantID, userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.NewDataResponse(dto.ToDocumentLinkDTO(link, documentID)))
+}
+
+// ListLinks handles requests to list the links from and to a document, oldest first
+func (h *DocumentLinkHandler) ListLinks(c *gin.Context) {
+	tenantID, userID, documentID, ok := h.getDocumentParams(c)
+	if !ok {
+		return
+	}
+
+	// Call use case to list the links
+	links, err := h.linkUseCase.ListLinks(c.Request.Context(), documentID, tenantID, userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.ToDocumentLinkListDTO(links, documentID)))
+}
+
+// GetLink handles requests to retrieve a link of a document
+func (h *DocumentLinkHandler) GetLink(c *gin.Context) {
+	tenantID, userID, documentID, linkID, ok := h.getLinkParams(c)
+	if !ok {
+		return
+	}
+
+	// Call use case to get the link
+	link, err := h.linkUseCase.GetLink(c.Request.Context(), documentID, linkID, tenantID, userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.ToDocumentLinkDTO(link, documentID)))
+}
+
+// UpdateLink handles requests to change the type of a link
+func (h *DocumentLinkHandler) UpdateLink(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	tenantID, userID, documentID, linkID, ok := h.getLinkParams(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateDocumentLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.WithError(err).Error("failed to bind request body")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			validators.BindingError(err),
+			nil,
+		))
+		return
+	}
+
+	// Call use case to update the link
+	link, err := h.linkUseCase.UpdateLink(c.Request.Context(), documentID, linkID, req.Type, tenantID, userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.ToDocumentLinkDTO(link, documentID)))
+}
+
+// DeleteLink handles requests to delete a link
+func (h *DocumentLinkHandler) DeleteLink(c *gin.Context) {
+	tenantID, userID, documentID, linkID, ok := h.getLinkParams(c)
+	if !ok {
+		return
+	}
+
+	// Call use case to delete the link
+	if err := h.linkUseCase.DeleteLink(c.Request.Context(), documentID, linkID, tenantID, userID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// getDocumentParams extracts the tenant and user IDs from the request context and the document ID
+// from the request path
+func (h *DocumentLinkHandler) getDocumentParams(c *gin.Context) (string, string, string, bool) {
+	log := logger.WithContext(c.Request.Context())
+
+	tenantID := middleware.GetTenantID(c)
+	userID := middleware.GetUserID(c)
+	if tenantID == "" || userID == "" {
+		log.Error("tenant or user ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrUserContextRequired,
+		))
+		return "", "", "", false
+	}
+
+	documentID := c.Param("id")
+	if documentID == "" {
+		log.Error("document ID missing in request path")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			errors.NewValidationError("document ID is required"),
+			map[string]string{"id": "required"},
+		))
+		return "", "", "", false
+	}
+
+	return tenantID, userID, documentID, true
+}
+
+// getLinkParams extracts the tenant and user IDs from the request context and the document and
+// link IDs from the request path
+func (h *DocumentLinkHandler) getLinkParams(c *gin.Context) (string, string, string, string, bool) {
+	tenantID, userID, documentID, ok := h.getDocumentParams(c)
+	if !ok {
+		return "", "", "", "", false
+	}
+
+	linkID := c.Param("linkId")
+	if linkID == "" {
+		logger.WithContext(c.Request.Context()).Error("link ID missing in request path")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			errors.NewValidationError("link ID is required"),
+			map[string]string{"linkId": "required"},
+		))
+		return "", "", "", "", false
+	}
+
+	return tenantID, userID, documentID, linkID, true
+}
+
+// handleError handles errors and returns appropriate HTTP responses
+func (h *DocumentLinkHandler) handleError(c *gin.Context, err error) {
+	if errors.IsValidationError(err) {
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			err,
+			map[string]string{},
+		))
+		return
+	}
+
+	if errors.IsResourceNotFoundError(err) {
+		c.JSON(http.StatusNotFound, dto.NewResourceNotFoundErrorResponse(c.Request.Context(), err))
+		return
+	}
+
+	if errors.IsAuthorizationError(err) {
+		c.JSON(http.StatusForbidden, dto.NewAuthorizationErrorResponse(c.Request.Context(), err))
+		return
+	}
+
+	// Default to internal server error
+	logger.WithError(err).Error("internal server error")
+	c.JSON(http.StatusInternalServerError, dto.NewInternalErrorResponse(c.Request.Context(), err))
+}
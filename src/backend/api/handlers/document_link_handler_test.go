@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"../../application/usecases"
+	"../../domain/models"
+	apperrors "../../pkg/errors"
+)
+
+// MockDocumentLinkUseCase is a mock implementation of the DocumentLinkUseCase interface
+type MockDocumentLinkUseCase struct {
+	mock.Mock
+}
+
+func (m *MockDocumentLinkUseCase) CreateLink(ctx context.Context, sourceID, targetID, linkType, tenantID, userID string) (*models.DocumentLink, error) {
+	args := m.Called(ctx, sourceID, targetID, linkType, tenantID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.DocumentLink), args.Error(1)
+}
+
+func (m *MockDocumentLinkUseCase) GetLink(ctx context.Context, documentID, id, tenantID, userID string) (*models.DocumentLink, error) {
+	args := m.Called(ctx, documentID, id, tenantID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.DocumentLink), args.Error(1)
+}
+
+func (m *MockDocumentLinkUseCase) ListLinks(ctx context.Context, documentID, tenantID, userID string) ([]models.DocumentLink, error) {
+	args := m.Called(ctx, documentID, tenantID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.DocumentLink), args.Error(1)
+}
+
+func (m *MockDocumentLinkUseCase) UpdateLink(ctx context.Context, documentID, id, linkType, tenantID, userID string) (*models.DocumentLink, error) {
+	args := m.Called(ctx, documentID, id, linkType, tenantID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.DocumentLink), args.Error(1)
+}
+
+func (m *MockDocumentLinkUseCase) DeleteLink(ctx context.Context, documentID, id, tenantID, userID string) error {
+	args := m.Called(ctx, documentID, id, tenantID, userID)
+	return args.Error(0)
+}
+
+// DocumentLinkHandlerSuite defines the test suite
+type DocumentLinkHandlerSuite struct {
+	suite.Suite
+	router      *gin.Engine
+	recorder    *httptest.ResponseRecorder
+	linkUseCase *MockDocumentLinkUseCase
+	linkHandler *DocumentLinkHandler
+}
+
+// SetupTest is called before each test
+func (s *DocumentLinkHandlerSuite) SetupTest() {
+	// Create a gin router in test mode
+	gin.SetMode(gin.TestMode)
+	s.router = gin.New()
+	s.recorder = httptest.NewRecorder()
+
+	// Create the document link handler with a mock use case
+	s.linkUseCase = new(MockDocumentLinkUseCase)
+	handler, err := NewDocumentLinkHandler(s.linkUseCase)
+	s.Require().NoError(err)
+	s.linkHandler = handler
+
+	// Set up a router group with an authenticated user and the document link handler routes
+	group := s.router.Group("/api/v1")
+	group.Use(func(c *gin.Context) {
+		c.Set("tenant_id", "tenant-123")
+		c.Set("user_id", "user-123")
+		c.Next()
+	})
+	s.linkHandler.RegisterRoutes(group)
+}
+
+// link returns link-123 by which amendment-123 supersedes contract-123
+func (s *DocumentLinkHandlerSuite) link() *models.DocumentLink {
+	link := models.NewDocumentLink("tenant-123", "amendment-123", "contract-123", models.DocumentLinkSupersedes, "user-123")
+	link.ID = "link-123"
+	return link
+}
+
+// TestCreateLink_Success tests linking an amendment to the contract it supersedes
+func (s *DocumentLinkHandlerSuite) TestCreateLink_Success() {
+	s.linkUseCase.On("CreateLink", mock.Anything, "amendment-123", "contract-123", models.DocumentLinkSupersedes, "tenant-123", "user-123").Return(s.link(), nil)
+
+	body := `{"target_id":"contract-123","type":"supersedes"}`
+	req, _ := http.NewRequest("POST", "/api/v1/documents/amendment-123/links", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusCreated, s.recorder.Code)
+	s.Contains(s.recorder.Body.String(), `"direction":"outgoing"`)
+	s.linkUseCase.AssertExpectations(s.T())
+}
+
+// TestCreateLink_MissingType tests that links without a type are rejected
+func (s *DocumentLinkHandlerSuite) TestCreateLink_MissingType() {
+	req, _ := http.NewRequest("POST", "/api/v1/documents/amendment-123/links", bytes.NewBufferString(`{"target_id":"contract-123"}`))
+	req.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusBadRequest, s.recorder.Code)
+	s.linkUseCase.AssertNotCalled(s.T(), "CreateLink", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestListLinks_Incoming tests that links to the document are listed as incoming
+func (s *DocumentLinkHandlerSuite) TestListLinks_Incoming() {
+	s.linkUseCase.On("ListLinks", mock.Anything, "contract-123", "tenant-123", "user-123").Return([]models.DocumentLink{*s.link()}, nil)
+
+	req, _ := http.NewRequest("GET", "/api/v1/documents/contract-123/links", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusOK, s.recorder.Code)
+	s.Contains(s.recorder.Body.String(), `"direction":"incoming"`)
+}
+
+// TestDeleteLink_Forbidden tests that users who cannot write the source document cannot delete its links
+func (s *DocumentLinkHandlerSuite) TestDeleteLink_Forbidden() {
+	s.linkUseCase.On("DeleteLink", mock.Anything, "contract-123", "link-123", "tenant-123", "user-123").Return(usecases.ErrPermissionDenied)
+
+	req, _ := http.NewRequest("DELETE", "/api/v1/documents/contract-123/links/link-123", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusForbidden, s.recorder.Code)
+}
+
+// TestGetLink_NotFound tests retrieving a link that does not exist
+func (s *DocumentLinkHandlerSuite) TestGetLink_NotFound() {
+	s.linkUseCase.On("GetLink", mock.Anything, "contract-123", "link-404", "tenant-123", "user-123").
+		Return(nil, apperrors.NewResourceNotFoundError("Document link not found"))
+
+	req, _ := http.NewRequest("GET", "/api/v1/documents/contract-123/links/link-404", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusNotFound, s.recorder.Code)
+}
+
+// TestDocumentLinkHandlerSuite runs the test suite
+func TestDocumentLinkHandlerSuite(t *testing.T) {
+	suite.Run(t, new(DocumentLinkHandlerSuite))
+}
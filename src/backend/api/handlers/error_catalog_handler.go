@@ -0,0 +1,49 @@
+// Package handlers implements HTTP handlers for the error catalog of the Document Management Platform.
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin" // v1.9.0+
+
+	"../../pkg/errors"
+	"../dto"
+)
+
+// ErrorCatalogHandler handles HTTP requests describing the codes of error responses. The type URI
+// of each problem points to the description of its code.
+type ErrorCatalogHandler struct{}
+
+// NewErrorCatalogHandler creates a new ErrorCatalogHandler instance
+func NewErrorCatalogHandler() *ErrorCatalogHandler {
+	return &ErrorCatalogHandler{}
+}
+
+// RegisterRoutes registers the error catalog routes with the provided router group
+func (h *ErrorCatalogHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/errors", h.ListErrorCodes)
+	router.GET("/errors/:code", h.GetErrorCode)
+}
+
+// ListErrorCodes handles requests to list every code clients can receive in error responses
+func (h *ErrorCatalogHandler) ListErrorCodes(c *gin.Context) {
+	catalog := errors.Catalog()
+	codes := make([]dto.ErrorCodeDTO, 0, len(catalog))
+	for _, info := range catalog {
+		codes = append(codes, dto.ToErrorCodeDTO(c.Request.Context(), info))
+	}
+	c.JSON(http.StatusOK, dto.NewDataResponse(codes))
+}
+
+// GetErrorCode handles requests to describe a single error code
+func (h *ErrorCatalogHandler) GetErrorCode(c *gin.Context) {
+	info, ok := errors.LookupCode(c.Param("code"))
+	if !ok {
+		c.JSON(http.StatusNotFound, dto.NewResourceNotFoundErrorResponse(
+			c.Request.Context(),
+			errors.NewResourceNotFoundError("error code not found"),
+		))
+		return
+	}
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.ToErrorCodeDTO(c.Request.Context(), info)))
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+
+	apperrors "../../pkg/errors"
+	"../../pkg/i18n"
+	"../dto"
+)
+
+// ErrorCatalogHandlerSuite defines the test suite
+type ErrorCatalogHandlerSuite struct {
+	suite.Suite
+	router   *gin.Engine
+	recorder *httptest.ResponseRecorder
+}
+
+// SetupTest is called before each test
+func (s *ErrorCatalogHandlerSuite) SetupTest() {
+	// Create a gin router in test mode; the error catalog is unauthenticated
+	gin.SetMode(gin.TestMode)
+	s.router = gin.New()
+	s.recorder = httptest.NewRecorder()
+
+	NewErrorCatalogHandler().RegisterRoutes(s.router.Group("/api/v1"))
+}
+
+// TestListErrorCodes tests that every code clients can receive is listed with its description
+func (s *ErrorCatalogHandlerSuite) TestListErrorCodes() {
+	req, _ := http.NewRequest("GET", "/api/v1/errors", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusOK, s.recorder.Code)
+
+	var response struct {
+		Data []dto.ErrorCodeDTO `json:"data"`
+	}
+	s.Require().NoError(json.Unmarshal(s.recorder.Body.Bytes(), &response))
+	s.Require().Len(response.Data, len(apperrors.Catalog()))
+	for _, code := range response.Data {
+		s.NotEmpty(code.Description, "code %s has no message", code.Code)
+		s.Equal("/api/v1/errors/"+code.Code, code.Type)
+		s.NotEqual(code.Category, code.Title, "type %s has no title", code.Category)
+	}
+}
+
+// TestGetErrorCode tests that a code is described in the language of the request
+func (s *ErrorCatalogHandlerSuite) TestGetErrorCode() {
+	req, _ := http.NewRequestWithContext(i18n.ContextWithLanguage(context.Background(), "de"), "GET", "/api/v1/errors/rate_limit_exceeded", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusOK, s.recorder.Code)
+
+	var response struct {
+		Data dto.ErrorCodeDTO `json:"data"`
+	}
+	s.Require().NoError(json.Unmarshal(s.recorder.Body.Bytes(), &response))
+	s.Equal(dto.ErrorCodeDTO{
+		Code:        "rate_limit_exceeded",
+		Type:        "/api/v1/errors/rate_limit_exceeded",
+		Title:       "Limit überschritten",
+		Status:      http.StatusTooManyRequests,
+		Category:    apperrors.ErrorTypeQuotaExceeded,
+		Description: "Zu viele Anfragen. Bitte versuchen Sie es später erneut.",
+	}, response.Data)
+}
+
+// TestGetErrorCode_Unknown tests that unknown codes return a not found problem
+func (s *ErrorCatalogHandlerSuite) TestGetErrorCode_Unknown() {
+	req, _ := http.NewRequest("GET", "/api/v1/errors/unknown_code", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusNotFound, s.recorder.Code)
+	s.Contains(s.recorder.Body.String(), `"type":"/api/v1/errors/not_found"`)
+	s.Contains(s.recorder.Body.String(), `"status":404`)
+}
+
+// TestErrorCatalogHandlerSuite runs the test suite
+func TestErrorCatalogHandlerSuite(t *testing.T) {
+	suite.Run(t, new(ErrorCatalogHandlerSuite))
+}
@@ -0,0 +1,120 @@
+// Package handlers implements HTTP handlers for background folder exports in the Document Management Platform.
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin" // v1.9.0+
+
+	"../dto"
+	"../middleware"
+	"../../application/usecases"
+	"../../pkg/errors"
+	"../../pkg/logger"
+)
+
+// ExportHandler handles HTTP requests for scheduling folder exports and tracking their progress
+type ExportHandler struct {
+	exportUseCase usecases.ExportUseCase
+}
+
+// NewExportHandler creates a new ExportHandler instance
+func NewExportHandler(exportUseCase usecases.ExportUseCase) (*ExportHandler, error) {
+	if exportUseCase == nil {
+		return nil, errors.NewValidationError("export use case cannot be nil")
+	}
+
+	return &ExportHandler{
+		exportUseCase: exportUseCase,
+	}, nil
+}
+
+// RegisterRoutes registers the export routes with the provided router group
+func (h *ExportHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.POST("/folders/:id/export", h.ExportFolder)
+	router.GET("/exports/:id", h.GetExportJob)
+}
+
+// ExportFolder handles requests to export a folder tree into a ZIP archive. The archive is built
+// in the background; the response points to the export job to poll for progress.
+func (h *ExportHandler) ExportFolder(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Call use case to schedule the export
+	job, err := h.exportUseCase.ExportFolder(c.Request.Context(), c.Param("id"), tenantID, middleware.GetUserID(c))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Header("Location", "/api/v1/exports/"+job.ID)
+	c.JSON(http.StatusAccepted, dto.NewDataResponse(dto.ToExportJobDTO(job, "")))
+}
+
+// GetExportJob handles requests for the status and progress of an export, including the download
+// URL once the archive is ready
+func (h *ExportHandler) GetExportJob(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Call use case to get the export job
+	job, downloadURL, err := h.exportUseCase.GetExportJob(c.Request.Context(), c.Param("id"), tenantID, middleware.GetUserID(c))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.ToExportJobDTO(job, downloadURL)))
+}
+
+// handleError handles errors and returns appropriate HTTP responses
+func (h *ExportHandler) handleError(c *gin.Context, err error) {
+	if errors.IsValidationError(err) {
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			err,
+			map[string]string{},
+		))
+		return
+	}
+
+	if errors.IsResourceNotFoundError(err) {
+		c.JSON(http.StatusNotFound, dto.NewResourceNotFoundErrorResponse(c.Request.Context(), err))
+		return
+	}
+
+	if errors.IsAuthenticationError(err) {
+		c.JSON(http.StatusUnauthorized, dto.NewAuthenticationErrorResponse(c.Request.Context(), err))
+		return
+	}
+
+	if errors.IsAuthorizationError(err) {
+		c.JSON(http.StatusForbidden, dto.NewAuthorizationErrorResponse(c.Request.Context(), err))
+		return
+	}
+
+	// Default to internal server error
+	logger.WithError(err).Error("internal server error")
+	c.JSON(http.StatusInternalServerError, dto.NewInternalErrorResponse(c.Request.Context(), err))
+}
@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"../../domain/models"
+	apperrors "../../pkg/errors"
+)
+
+// MockExportUseCase is a mock implementation of the ExportUseCase interface
+type MockExportUseCase struct {
+	mock.Mock
+}
+
+func (m *MockExportUseCase) ExportFolder(ctx context.Context, folderID, tenantID, userID string) (*models.ExportJob, error) {
+	args := m.Called(ctx, folderID, tenantID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ExportJob), args.Error(1)
+}
+
+func (m *MockExportUseCase) GetExportJob(ctx context.Context, id, tenantID, userID string) (*models.ExportJob, string, error) {
+	args := m.Called(ctx, id, tenantID, userID)
+	if args.Get(0) == nil {
+		return nil, "", args.Error(2)
+	}
+	return args.Get(0).(*models.ExportJob), args.String(1), args.Error(2)
+}
+
+// ExportHandlerSuite defines the test suite
+type ExportHandlerSuite struct {
+	suite.Suite
+	router        *gin.Engine
+	recorder      *httptest.ResponseRecorder
+	exportUseCase *MockExportUseCase
+}
+
+// SetupTest is called before each test
+func (s *ExportHandlerSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	s.router = gin.New()
+	s.recorder = httptest.NewRecorder()
+
+	s.exportUseCase = new(MockExportUseCase)
+	handler, err := NewExportHandler(s.exportUseCase)
+	s.Require().NoError(err)
+
+	group := s.router.Group("/api/v1")
+	group.Use(func(c *gin.Context) {
+		c.Set("tenant_id", "tenant-123")
+		c.Set("user_id", "user-123")
+		c.Next()
+	})
+	handler.RegisterRoutes(group)
+}
+
+// createTestJob returns an export job of the test user with the given status
+func (s *ExportHandlerSuite) createTestJob(status string) *models.ExportJob {
+	job := models.NewExportJob("tenant-123", "folder-123", "user-123")
+	job.ID = "export-123"
+	job.Status = status
+	return job
+}
+
+// TestExportFolder_Accepted tests that scheduling an export returns 202 with the job location
+func (s *ExportHandlerSuite) TestExportFolder_Accepted() {
+	s.exportUseCase.On("ExportFolder", mock.Anything, "folder-123", "tenant-123", "user-123").
+		Return(s.createTestJob(models.ExportJobStatusPending), nil)
+
+	req, _ := http.NewRequest("POST", "/api/v1/folders/folder-123/export", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusAccepted, s.recorder.Code)
+	s.Equal("/api/v1/exports/export-123", s.recorder.Header().Get("Location"))
+	s.Contains(s.recorder.Body.String(), `"status":"pending"`)
+	s.exportUseCase.AssertExpectations(s.T())
+}
+
+// TestGetExportJob_Completed tests that a completed export includes its progress and download URL
+func (s *ExportHandlerSuite) TestGetExportJob_Completed() {
+	job := s.createTestJob(models.ExportJobStatusCompleted)
+	job.TotalDocuments = 3
+	job.ExportedDocuments = 3
+	s.exportUseCase.On("GetExportJob", mock.Anything, "export-123", "tenant-123", "user-123").
+		Return(job, "https://s3.example.com/presigned", nil)
+
+	req, _ := http.NewRequest("GET", "/api/v1/exports/export-123", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusOK, s.recorder.Code)
+	s.Contains(s.recorder.Body.String(), `"progress":100`)
+	s.Contains(s.recorder.Body.String(), `"download_url":"https://s3.example.com/presigned"`)
+}
+
+// TestGetExportJob_NotFound tests requesting an unknown export
+func (s *ExportHandlerSuite) TestGetExportJob_NotFound() {
+	s.exportUseCase.On("GetExportJob", mock.Anything, "export-404", "tenant-123", "user-123").
+		Return(nil, "", apperrors.NewResourceNotFoundError("export job not found"))
+
+	req, _ := http.NewRequest("GET", "/api/v1/exports/export-404", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusNotFound, s.recorder.Code)
+}
+
+// TestExportHandlerSuite runs the test suite
+func TestExportHandlerSuite(t *testing.T) {
+	suite.Run(t, new(ExportHandlerSuite))
+}
@@ -0,0 +1,233 @@
+// Package handlers implements HTTP handlers for the Starred and Recents views in the Document Management Platform.
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin" // v1.9.0+
+
+	"../../application/usecases"
+	"../../domain/models"
+	"../../pkg/errors"
+	"../../pkg/logger"
+	"../dto"
+	"../middleware"
+)
+
+// FavoriteHandler handles HTTP requests for starring documents and folders and listing a user's
+// starred and recent items
+type FavoriteHandler struct {
+	favoriteUseCase usecases.FavoriteUseCase
+}
+
+// NewFavoriteHandler creates a new FavoriteHandler instance
+func NewFavoriteHandler(favoriteUseCase usecases.FavoriteUseCase) (*FavoriteHandler, error) {
+	if favoriteUseCase == nil {
+		return nil, errors.NewValidationError("favorite use case cannot be nil")
+	}
+
+	return &FavoriteHandler{
+		favoriteUseCase: favoriteUseCase,
+	}, nil
+}
+
+// RegisterRoutes registers starred and recent item routes with the provided router group
+func (h *FavoriteHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.PUT("/documents/:id/star", h.StarDocument)
+	router.DELETE("/documents/:id/star", h.UnstarDocument)
+	router.PUT("/folders/:id/star", h.StarFolder)
+	router.DELETE("/folders/:id/star", h.UnstarFolder)
+	router.GET("/me/starred", h.ListStarred)
+	router.GET("/me/recent", h.ListRecent)
+}
+
+// StarDocument handles requests to star a document
+func (h *FavoriteHandler) StarDocument(c *gin.Context) {
+	h.star(c, models.ResourceTypeDocument)
+}
+
+// UnstarDocument handles requests to unstar a document
+func (h *FavoriteHandler) UnstarDocument(c *gin.Context) {
+	h.unstar(c, models.ResourceTypeDocument)
+}
+
+// StarFolder handles requests to star a folder
+func (h *FavoriteHandler) StarFolder(c *gin.Context) {
+	h.star(c, models.ResourceTypeFolder)
+}
+
+// UnstarFolder handles requests to unstar a folder
+func (h *FavoriteHandler) UnstarFolder(c *gin.Context) {
+	h.unstar(c, models.ResourceTypeFolder)
+}
+
+// star stars the document or folder in the request path for the requesting user
+func (h *FavoriteHandler) star(c *gin.Context, resourceType string) {
+	tenantID, userID, resourceID, ok := h.getResourceParams(c, resourceType)
+	if !ok {
+		return
+	}
+
+	// Call use case to star the resource
+	if err := h.favoriteUseCase.Star(c.Request.Context(), resourceType, resourceID, tenantID, userID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// unstar unstars the document or folder in the request path for the requesting user
+func (h *FavoriteHandler) unstar(c *gin.Context, resourceType string) {
+	tenantID, userID, resourceID, ok := h.getResourceParams(c, resourceType)
+	if !ok {
+		return
+	}
+
+	// Call use case to unstar the resource
+	if err := h.favoriteUseCase.Unstar(c.Request.Context(), resourceType, resourceID, tenantID, userID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListStarred handles requests to list the documents and folders the requesting user starred,
+// optionally only those of the resource type in the type query parameter
+func (h *FavoriteHandler) ListStarred(c *gin.Context) {
+	tenantID, userID, ok := h.getUserParams(c)
+	if !ok {
+		return
+	}
+
+	page, pageSize := h.getPaginationParams(c)
+
+	// Call use case to list the starred items
+	result, err := h.favoriteUseCase.ListStarred(c.Request.Context(), c.Query("type"), tenantID, userID, page, pageSize)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	items := make([]dto.StarredItemDTO, len(result.Items))
+	for i, item := range result.Items {
+		items[i] = dto.ToStarredItemDTO(item.Favorite, item.Document, item.Folder)
+	}
+	c.JSON(http.StatusOK, dto.NewPaginatedResponse(items, result.Pagination))
+}
+
+// ListRecent handles requests to list the documents the requesting user most recently downloaded,
+// uploaded or edited, at most limit of them
+func (h *FavoriteHandler) ListRecent(c *gin.Context) {
+	tenantID, userID, ok := h.getUserParams(c)
+	if !ok {
+		return
+	}
+
+	limit := usecases.DefaultRecentDocuments
+	if l, err := strconv.Atoi(c.Query("limit")); err == nil && l > 0 {
+		limit = l
+	}
+
+	// Call use case to list the recent documents
+	recent, err := h.favoriteUseCase.ListRecent(c.Request.Context(), tenantID, userID, limit)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	documents := make([]dto.RecentDocumentDTO, len(recent))
+	for i, document := range recent {
+		documents[i] = dto.ToRecentDocumentDTO(document.Document, document.Action, document.AccessedAt)
+	}
+	c.JSON(http.StatusOK, dto.NewDataResponse(documents))
+}
+
+// getUserParams extracts the tenant and user IDs from the request context, responding with an
+// authentication error when either is missing
+func (h *FavoriteHandler) getUserParams(c *gin.Context) (string, string, bool) {
+	log := logger.WithContext(c.Request.Context())
+
+	tenantID := middleware.GetTenantID(c)
+	userID := middleware.GetUserID(c)
+	if tenantID == "" || userID == "" {
+		log.Error("tenant or user ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrUserContextRequired,
+		))
+		return "", "", false
+	}
+
+	return tenantID, userID, true
+}
+
+// getResourceParams extracts the tenant and user IDs from the request context and the document or
+// folder ID from the request path
+func (h *FavoriteHandler) getResourceParams(c *gin.Context, resourceType string) (string, string, string, bool) {
+	tenantID, userID, ok := h.getUserParams(c)
+	if !ok {
+		return "", "", "", false
+	}
+
+	resourceID := c.Param("id")
+	if resourceID == "" {
+		logger.WithContext(c.Request.Context()).Error(resourceType + " ID missing in request path")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			errors.NewValidationError(resourceType+" ID is required"),
+			map[string]string{"id": "required"},
+		))
+		return "", "", "", false
+	}
+
+	return tenantID, userID, resourceID, true
+}
+
+// getPaginationParams extracts and validates pagination parameters from the request
+func (h *FavoriteHandler) getPaginationParams(c *gin.Context) (int, int) {
+	page := 1
+	if p, err := strconv.Atoi(c.Query("page")); err == nil && p > 0 {
+		page = p
+	}
+
+	pageSize := defaultPageSize
+	if ps, err := strconv.Atoi(c.Query("pageSize")); err == nil && ps > 0 {
+		pageSize = ps
+	}
+
+	// Cap pageSize at maximum
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	return page, pageSize
+}
+
+// handleError handles errors and returns appropriate HTTP responses
+func (h *FavoriteHandler) handleError(c *gin.Context, err error) {
+	if errors.IsValidationError(err) {
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			err,
+			map[string]string{},
+		))
+		return
+	}
+
+	if errors.IsResourceNotFoundError(err) {
+		c.JSON(http.StatusNotFound, dto.NewResourceNotFoundErrorResponse(c.Request.Context(), err))
+		return
+	}
+
+	if errors.IsAuthorizationError(err) {
+		c.JSON(http.StatusForbidden, dto.NewAuthorizationErrorResponse(c.Request.Context(), err))
+		return
+	}
+
+	// Default to internal server error
+	logger.WithError(err).Error("internal server error")
+	c.JSON(http.StatusInternalServerError, dto.NewInternalErrorResponse(c.Request.Context(), err))
+}
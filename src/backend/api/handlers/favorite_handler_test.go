@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"../../application/usecases"
+	"../../domain/models"
+	apperrors "../../pkg/errors"
+	"../../pkg/utils"
+)
+
+// MockFavoriteUseCase is a mock implementation of the FavoriteUseCase interface
+type MockFavoriteUseCase struct {
+	mock.Mock
+}
+
+func (m *MockFavoriteUseCase) Star(ctx context.Context, resourceType, resourceID, tenantID, userID string) error {
+	args := m.Called(ctx, resourceType, resourceID, tenantID, userID)
+	return args.Error(0)
+}
+
+func (m *MockFavoriteUseCase) Unstar(ctx context.Context, resourceType, resourceID, tenantID, userID string) error {
+	args := m.Called(ctx, resourceType, resourceID, tenantID, userID)
+	return args.Error(0)
+}
+
+func (m *MockFavoriteUseCase) ListStarred(ctx context.Context, resourceType, tenantID, userID string, page int, pageSize int) (utils.PaginatedResult[usecases.StarredItem], error) {
+	args := m.Called(ctx, resourceType, tenantID, userID, page, pageSize)
+	return args.Get(0).(utils.PaginatedResult[usecases.StarredItem]), args.Error(1)
+}
+
+func (m *MockFavoriteUseCase) ListRecent(ctx context.Context, tenantID, userID string, limit int) ([]usecases.RecentDocument, error) {
+	args := m.Called(ctx, tenantID, userID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]usecases.RecentDocument), args.Error(1)
+}
+
+// FavoriteHandlerSuite defines the test suite
+type FavoriteHandlerSuite struct {
+	suite.Suite
+	router          *gin.Engine
+	recorder        *httptest.ResponseRecorder
+	favoriteUseCase *MockFavoriteUseCase
+	favoriteHandler *FavoriteHandler
+}
+
+// SetupTest is called before each test
+func (s *FavoriteHandlerSuite) SetupTest() {
+	// Create a gin router in test mode
+	gin.SetMode(gin.TestMode)
+	s.router = gin.New()
+	s.recorder = httptest.NewRecorder()
+
+	// Create the favorite handler with a mock use case
+	s.favoriteUseCase = new(MockFavoriteUseCase)
+	handler, err := NewFavoriteHandler(s.favoriteUseCase)
+	s.Require().NoError(err)
+	s.favoriteHandler = handler
+
+	// Set up a router group with an authenticated user and the favorite handler routes
+	group := s.router.Group("/api/v1")
+	group.Use(func(c *gin.Context) {
+		c.Set("tenant_id", "tenant-123")
+		c.Set("user_id", "user-123")
+		c.Next()
+	})
+	s.favoriteHandler.RegisterRoutes(group)
+}
+
+// TestStarDocument_Success tests starring a document
+func (s *FavoriteHandlerSuite) TestStarDocument_Success() {
+	s.favoriteUseCase.On("Star", mock.Anything, models.ResourceTypeDocument, "doc-123", "tenant-123", "user-123").Return(nil)
+
+	req, _ := http.NewRequest("PUT", "/api/v1/documents/doc-123/star", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusNoContent, s.recorder.Code)
+	s.favoriteUseCase.AssertExpectations(s.T())
+}
+
+// TestStarFolder_Forbidden tests starring a folder the user cannot read
+func (s *FavoriteHandlerSuite) TestStarFolder_Forbidden() {
+	s.favoriteUseCase.On("Star", mock.Anything, models.ResourceTypeFolder, "folder-123", "tenant-123", "user-123").
+		Return(apperrors.NewAuthorizationError("permission denied"))
+
+	req, _ := http.NewRequest("PUT", "/api/v1/folders/folder-123/star", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusForbidden, s.recorder.Code)
+	s.favoriteUseCase.AssertExpectations(s.T())
+}
+
+// TestUnstarDocument_NotStarred tests unstarring a document the user had not starred
+func (s *FavoriteHandlerSuite) TestUnstarDocument_NotStarred() {
+	s.favoriteUseCase.On("Unstar", mock.Anything, models.ResourceTypeDocument, "doc-123", "tenant-123", "user-123").
+		Return(apperrors.NewResourceNotFoundError("Favorite not found"))
+
+	req, _ := http.NewRequest("DELETE", "/api/v1/documents/doc-123/star", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusNotFound, s.recorder.Code)
+	s.favoriteUseCase.AssertExpectations(s.T())
+}
+
+// TestListStarred_Success tests listing the starred folders of the user
+func (s *FavoriteHandlerSuite) TestListStarred_Success() {
+	items := []usecases.StarredItem{{
+		Favorite: models.Favorite{ResourceType: models.ResourceTypeFolder, ResourceID: "folder-123", CreatedAt: time.Now()},
+		Folder:   &models.Folder{ID: "folder-123", Name: "Invoices"},
+	}}
+	s.favoriteUseCase.On("ListStarred", mock.Anything, models.ResourceTypeFolder, "tenant-123", "user-123", 1, 20).
+		Return(utils.NewPaginatedResult(items, utils.NewPagination(1, 20), 1), nil)
+
+	req, _ := http.NewRequest("GET", "/api/v1/me/starred?type=folder", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusOK, s.recorder.Code)
+	s.Contains(s.recorder.Body.String(), `"resource_id":"folder-123"`)
+	s.favoriteUseCase.AssertExpectations(s.T())
+}
+
+// TestListRecent_Success tests listing the recent documents of the user with a limit
+func (s *FavoriteHandlerSuite) TestListRecent_Success() {
+	recent := []usecases.RecentDocument{{
+		Document:   &models.Document{ID: "doc-123", Name: "invoice.pdf"},
+		Action:     models.AuditActionDownload,
+		AccessedAt: time.Now(),
+	}}
+	s.favoriteUseCase.On("ListRecent", mock.Anything, "tenant-123", "user-123", 5).Return(recent, nil)
+
+	req, _ := http.NewRequest("GET", "/api/v1/me/recent?limit=5", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusOK, s.recorder.Code)
+	s.Contains(s.recorder.Body.String(), `"action":"download"`)
+	s.favoriteUseCase.AssertExpectations(s.T())
+}
+
+// TestFavoriteHandlerSuite runs the test suite
+func TestFavoriteHandlerSuite(t *testing.T) {
+	suite.Run(t, new(FavoriteHandlerSuite))
+}
@@ -51,11 +51,12 @@ func (h *FolderHandler) CreateFolder(c *gin.Context) {
 
 	// Bind the request body to a FolderCreateRequest struct
 	var request dto.FolderCreateRequest
-	if err := c.BindJSON(&request); err != nil {
+	if err := c.ShouldBindJSON(&request); err != nil {
 		// If binding fails, return a bad request error
 		log.WithError(err).Error("Invalid request body")
 		c.AbortWithStatusJSON(http.StatusBadRequest, errordto.NewValidationErrorResponse(
-			errors.NewValidationError("Invalid request body"),
+			c.Request.Context(),
+			validators.BindingError(err),
 			nil,
 		))
 		return
@@ -65,10 +66,10 @@ func (h *FolderHandler) CreateFolder(c *gin.Context) {
 	if err := validators.ValidateCreateFolderRequest(&request); err != nil {
 		// If validation fails, return a validation error response
 		log.WithError(err).Error("Validation failed")
-		validationErrors := errors.GetValidationErrors(err)
 		c.AbortWithStatusJSON(http.StatusBadRequest, errordto.NewValidationErrorResponse(
-			errors.NewValidationError("Validation failed"),
-			validationErrors,
+			c.Request.Context(),
+			err,
+			nil,
 		))
 		return
 	}
@@ -148,11 +149,12 @@ func (h *FolderHandler) UpdateFolder(c *gin.Context) {
 
 	// Bind the request body to a FolderUpdateRequest struct
 	var request dto.FolderUpdateRequest
-	if err := c.BindJSON(&request); err != nil {
+	if err := c.ShouldBindJSON(&request); err != nil {
 		// If binding fails, return a bad request error
 		log.WithError(err).Error("Invalid request body")
 		c.AbortWithStatusJSON(http.StatusBadRequest, errordto.NewValidationErrorResponse(
-			errors.NewValidationError("Invalid request body"),
+			c.Request.Context(),
+			validators.BindingError(err),
 			nil,
 		))
 		return
@@ -162,10 +164,10 @@ func (h *FolderHandler) UpdateFolder(c *gin.Context) {
 	if err := validators.ValidateUpdateFolderRequest(&request); err != nil {
 		// If validation fails, return a validation error response
 		log.WithError(err).Error("Validation failed")
-		validationErrors := errors.GetValidationErrors(err)
 		c.AbortWithStatusJSON(http.StatusBadRequest, errordto.NewValidationErrorResponse(
-			errors.NewValidationError("Validation failed"),
-			validationErrors,
+			c.Request.Context(),
+			err,
+			nil,
 		))
 		return
 	}
@@ -235,11 +237,12 @@ func (h *FolderHandler) ListFolders(c *gin.Context) {
 
 	// Bind query parameters to a FolderListRequest struct
 	var request dto.FolderListRequest
-	if err := c.BindQuery(&request); err != nil {
+	if err := c.ShouldBindQuery(&request); err != nil {
 		// If binding fails, return a bad request error
 		log.WithError(err).Error("Invalid query parameters")
 		c.AbortWithStatusJSON(http.StatusBadRequest, errordto.NewValidationErrorResponse(
-			errors.NewValidationError("Invalid query parameters"),
+			c.Request.Context(),
+			validators.BindingError(err),
 			nil,
 		))
 		return
@@ -249,16 +252,16 @@ func (h *FolderHandler) ListFolders(c *gin.Context) {
 	if err := validators.ValidateFolderListRequest(&request); err != nil {
 		// If validation fails, return a validation error response
 		log.WithError(err).Error("Validation failed")
-		validationErrors := errors.GetValidationErrors(err)
 		c.AbortWithStatusJSON(http.StatusBadRequest, errordto.NewValidationErrorResponse(
-			errors.NewValidationError("Validation failed"),
-			validationErrors,
+			c.Request.Context(),
+			err,
+			nil,
 		))
 		return
 	}
 
-	// Create pagination parameters from the request
-	paginationParams := pagination.NewPagination(request.Page, request.PageSize)
+	// Create pagination parameters from the request, sorted by the requested keys
+	paginationParams := pagination.NewPagination(request.Page, request.PageSize).WithSort(sortKeys(request.SortBy, request.SortOrder)).WithCursor(request.Cursor)
 
 	// Log folder listing attempt
 	log.Info("Attempting to list folders", "userID", userID, "tenantID", tenantID, "parentID", request.ParentID, "page", request.Page, "pageSize", request.PageSize)
@@ -313,11 +316,12 @@ func (h *FolderHandler) MoveFolder(c *gin.Context) {
 
 	// Bind the request body to a FolderMoveRequest struct
 	var request dto.FolderMoveRequest
-	if err := c.BindJSON(&request); err != nil {
+	if err := c.ShouldBindJSON(&request); err != nil {
 		// If binding fails, return a bad request error
 		log.WithError(err).Error("Invalid request body")
 		c.AbortWithStatusJSON(http.StatusBadRequest, errordto.NewValidationErrorResponse(
-			errors.NewValidationError("Invalid request body"),
+			c.Request.Context(),
+			validators.BindingError(err),
 			nil,
 		))
 		return
@@ -327,10 +331,10 @@ func (h *FolderHandler) MoveFolder(c *gin.Context) {
 	if err := validators.ValidateMoveFolderRequest(&request); err != nil {
 		// If validation fails, return a validation error response
 		log.WithError(err).Error("Validation failed")
-		validationErrors := errors.GetValidationErrors(err)
 		c.AbortWithStatusJSON(http.StatusBadRequest, errordto.NewValidationErrorResponse(
-			errors.NewValidationError("Validation failed"),
-			validationErrors,
+			c.Request.Context(),
+			err,
+			nil,
 		))
 		return
 	}
@@ -359,6 +363,80 @@ func (h *FolderHandler) MoveFolder(c *gin.Context) {
 	log.Info("Folder moved successfully", "folderID", id)
 }
 
+// SetDownloadWatermark handles requests to turn watermarking of documents downloaded from a folder on or off
+func (h *FolderHandler) SetDownloadWatermark(c *gin.Context) {
+	// Extract user ID and tenant ID from the request context
+	userID := middleware.GetUserID(c)
+	tenantID := middleware.GetTenantID(c)
+
+	// Get logger with context
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract folder ID from the URL path parameter
+	id := c.Param("id")
+
+	var request dto.FolderWatermarkRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		log.WithError(err).Error("Invalid request body")
+		c.AbortWithStatusJSON(http.StatusBadRequest, errordto.NewValidationErrorResponse(
+			c.Request.Context(),
+			validators.BindingError(err),
+			nil,
+		))
+		return
+	}
+
+	if err := h.folderUseCase.SetDownloadWatermark(c.Request.Context(), id, *request.Enabled, tenantID, userID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	folder, err := h.folderUseCase.GetFolder(c.Request.Context(), id, tenantID, userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responsedto.NewDataResponse(dto.FolderToDTO(folder)))
+}
+
+// SetDownloadPolicy handles requests to replace the download policy of a folder
+func (h *FolderHandler) SetDownloadPolicy(c *gin.Context) {
+	// Extract user ID and tenant ID from the request context
+	userID := middleware.GetUserID(c)
+	tenantID := middleware.GetTenantID(c)
+
+	// Get logger with context
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract folder ID from the URL path parameter
+	id := c.Param("id")
+
+	var request dto.FolderDownloadPolicyDTO
+	if err := c.ShouldBindJSON(&request); err != nil {
+		log.WithError(err).Error("Invalid request body")
+		c.AbortWithStatusJSON(http.StatusBadRequest, errordto.NewValidationErrorResponse(
+			c.Request.Context(),
+			validators.BindingError(err),
+			nil,
+		))
+		return
+	}
+
+	if err := h.folderUseCase.SetDownloadPolicy(c.Request.Context(), id, request.ToModel(), tenantID, userID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	folder, err := h.folderUseCase.GetFolder(c.Request.Context(), id, tenantID, userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responsedto.NewDataResponse(dto.FolderToDTO(folder)))
+}
+
 // SearchFolders handles requests to search folders by name
 func (h *FolderHandler) SearchFolders(c *gin.Context) {
 	// Extract user ID and tenant ID from the request context
@@ -370,11 +448,12 @@ func (h *FolderHandler) SearchFolders(c *gin.Context) {
 
 	// Bind query parameters to a FolderSearchRequest struct
 	var request dto.FolderSearchRequest
-	if err := c.BindQuery(&request); err != nil {
+	if err := c.ShouldBindQuery(&request); err != nil {
 		// If binding fails, return a bad request error
 		log.WithError(err).Error("Invalid query parameters")
 		c.AbortWithStatusJSON(http.StatusBadRequest, errordto.NewValidationErrorResponse(
-			errors.NewValidationError("Invalid query parameters"),
+			c.Request.Context(),
+			validators.BindingError(err),
 			nil,
 		))
 		return
@@ -384,10 +463,10 @@ func (h *FolderHandler) SearchFolders(c *gin.Context) {
 	if err := validators.ValidateFolderSearchRequest(&request); err != nil {
 		// If validation fails, return a validation error response
 		log.WithError(err).Error("Validation failed")
-		validationErrors := errors.GetValidationErrors(err)
 		c.AbortWithStatusJSON(http.StatusBadRequest, errordto.NewValidationErrorResponse(
-			errors.NewValidationError("Validation failed"),
-			validationErrors,
+			c.Request.Context(),
+			err,
+			nil,
 		))
 		return
 	}
@@ -456,28 +535,28 @@ func (h *FolderHandler) handleError(c *gin.Context, err error) {
 	// Check error type using error package utilities
 	if errors.IsValidationError(err) {
 		// If validation error, return validation error response with validation errors
-		validationErrors := errors.GetValidationErrors(err)
 		c.AbortWithStatusJSON(http.StatusBadRequest, errordto.NewValidationErrorResponse(
-			errors.NewValidationError("Validation failed"),
-			validationErrors,
+			c.Request.Context(),
+			err,
+			nil,
 		))
 		return
 	}
 
 	if errors.IsResourceNotFoundError(err) {
 		// If not found error, return resource not found error response
-		c.AbortWithStatusJSON(http.StatusNotFound, errordto.NewResourceNotFoundErrorResponse(err))
+		c.AbortWithStatusJSON(http.StatusNotFound, errordto.NewResourceNotFoundErrorResponse(c.Request.Context(), err))
 		return
 	}
 
 	if errors.IsAuthorizationError(err) {
 		// If authorization error, return authorization error response
-		c.AbortWithStatusJSON(http.StatusForbidden, errordto.NewAuthorizationErrorResponse(err))
+		c.AbortWithStatusJSON(http.StatusForbidden, errordto.NewAuthorizationErrorResponse(c.Request.Context(), err))
 		return
 	}
 
 	// Otherwise, return internal server error response
-	c.AbortWithStatusJSON(http.StatusInternalServerError, errordto.NewInternalErrorResponse(err))
+	c.AbortWithStatusJSON(http.StatusInternalServerError, errordto.NewInternalErrorResponse(c.Request.Context(), err))
 }
 func (h *FolderHandler) GetFolderByPath(c *gin.Context) {
 	userID := middleware.GetUserID(c)
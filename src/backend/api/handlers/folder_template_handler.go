@@ -0,0 +1,242 @@
+// Package handlers implements HTTP handlers for folder templates in the Document Management Platform.
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin" // v1.9.0+
+
+	"../../application/usecases"
+	"../../pkg/errors"
+	"../../pkg/logger"
+	"../dto"
+	"../middleware"
+	"../validators"
+)
+
+// FolderTemplateHandler handles HTTP requests for managing folder templates and applying them to
+// set up projects and tenants
+type FolderTemplateHandler struct {
+	folderTemplateUseCase usecases.FolderTemplateUseCase
+}
+
+// NewFolderTemplateHandler creates a new FolderTemplateHandler instance
+func NewFolderTemplateHandler(folderTemplateUseCase usecases.FolderTemplateUseCase) (*FolderTemplateHandler, error) {
+	if folderTemplateUseCase == nil {
+		return nil, errors.NewValidationError("folder template use case cannot be nil")
+	}
+
+	return &FolderTemplateHandler{
+		folderTemplateUseCase: folderTemplateUseCase,
+	}, nil
+}
+
+// RegisterRoutes registers folder template routes with the provided router group
+func (h *FolderTemplateHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.POST("/folder-templates", h.CreateTemplate)
+	router.GET("/folder-templates", h.ListTemplates)
+	router.GET("/folder-templates/:id", h.GetTemplate)
+	router.PUT("/folder-templates/:id", h.UpdateTemplate)
+	router.DELETE("/folder-templates/:id", h.DeleteTemplate)
+	router.POST("/folder-templates/:id/apply", h.ApplyTemplate)
+}
+
+// CreateTemplate handles folder template creation requests
+func (h *FolderTemplateHandler) CreateTemplate(c *gin.Context) {
+	tenantID, userID, ok := h.getUserParams(c)
+	if !ok {
+		return
+	}
+
+	var req dto.FolderTemplateRequest
+	if !h.bindJSON(c, &req) {
+		return
+	}
+
+	// Call use case to create the template
+	template, err := h.folderTemplateUseCase.CreateTemplate(c.Request.Context(), dto.ToFolderTemplateDomain(&req, tenantID, userID))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.NewDataResponse(dto.ToFolderTemplateDTO(template)))
+}
+
+// ListTemplates handles requests to list the tenant's folder templates
+func (h *FolderTemplateHandler) ListTemplates(c *gin.Context) {
+	tenantID, _, ok := h.getUserParams(c)
+	if !ok {
+		return
+	}
+
+	// Call use case to list the templates
+	templates, err := h.folderTemplateUseCase.ListTemplates(c.Request.Context(), tenantID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.ToFolderTemplateListDTO(templates)))
+}
+
+// GetTemplate handles folder template retrieval requests
+func (h *FolderTemplateHandler) GetTemplate(c *gin.Context) {
+	tenantID, _, templateID, ok := h.getIDParams(c)
+	if !ok {
+		return
+	}
+
+	// Call use case to get the template
+	template, err := h.folderTemplateUseCase.GetTemplate(c.Request.Context(), templateID, tenantID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.ToFolderTemplateDTO(template)))
+}
+
+// UpdateTemplate handles requests to replace a folder template; folders already created from it
+// are not changed
+func (h *FolderTemplateHandler) UpdateTemplate(c *gin.Context) {
+	tenantID, userID, templateID, ok := h.getIDParams(c)
+	if !ok {
+		return
+	}
+
+	var req dto.FolderTemplateRequest
+	if !h.bindJSON(c, &req) {
+		return
+	}
+
+	template := dto.ToFolderTemplateDomain(&req, tenantID, userID)
+	template.ID = templateID
+
+	// Call use case to update the template
+	updated, err := h.folderTemplateUseCase.UpdateTemplate(c.Request.Context(), template)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.ToFolderTemplateDTO(updated)))
+}
+
+// DeleteTemplate handles folder template deletion requests
+func (h *FolderTemplateHandler) DeleteTemplate(c *gin.Context) {
+	tenantID, _, templateID, ok := h.getIDParams(c)
+	if !ok {
+		return
+	}
+
+	// Call use case to delete the template
+	if err := h.folderTemplateUseCase.DeleteTemplate(c.Request.Context(), templateID, tenantID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewMessageResponse("Folder template deleted successfully"))
+}
+
+// ApplyTemplate handles requests to create a folder from a template, with the template's folders,
+// permissions and metadata templates
+func (h *FolderTemplateHandler) ApplyTemplate(c *gin.Context) {
+	tenantID, userID, templateID, ok := h.getIDParams(c)
+	if !ok {
+		return
+	}
+
+	var req dto.ApplyFolderTemplateRequest
+	if !h.bindJSON(c, &req) {
+		return
+	}
+
+	// Call use case to apply the template
+	folder, err := h.folderTemplateUseCase.ApplyTemplate(c.Request.Context(), templateID, req.Name, req.ParentID, tenantID, userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.NewDataResponse(dto.FolderToDTO(folder)))
+}
+
+// getUserParams extracts the tenant and user IDs from the request context
+func (h *FolderTemplateHandler) getUserParams(c *gin.Context) (string, string, bool) {
+	tenantID := middleware.GetTenantID(c)
+	userID := middleware.GetUserID(c)
+	if tenantID == "" || userID == "" {
+		logger.WithContext(c.Request.Context()).Error("tenant or user ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrUserContextRequired,
+		))
+		return "", "", false
+	}
+
+	return tenantID, userID, true
+}
+
+// getIDParams extracts the tenant and user IDs from the request context and the folder template ID
+// from the request path
+func (h *FolderTemplateHandler) getIDParams(c *gin.Context) (string, string, string, bool) {
+	tenantID, userID, ok := h.getUserParams(c)
+	if !ok {
+		return "", "", "", false
+	}
+
+	id := c.Param("id")
+	if id == "" {
+		logger.WithContext(c.Request.Context()).Error("folder template ID missing in request path")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			errors.NewValidationError("folder template ID is required"),
+			map[string]string{"id": "required"},
+		))
+		return "", "", "", false
+	}
+
+	return tenantID, userID, id, true
+}
+
+// bindJSON binds the request body to req, responding with a validation error if it is malformed
+func (h *FolderTemplateHandler) bindJSON(c *gin.Context, req interface{}) bool {
+	if err := c.ShouldBindJSON(req); err != nil {
+		logger.WithContext(c.Request.Context()).WithError(err).Error("failed to bind request body")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			validators.BindingError(err),
+			nil,
+		))
+		return false
+	}
+
+	return true
+}
+
+// handleError handles errors and returns appropriate HTTP responses
+func (h *FolderTemplateHandler) handleError(c *gin.Context, err error) {
+	if errors.IsValidationError(err) {
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			err,
+			map[string]string{},
+		))
+		return
+	}
+
+	if errors.IsResourceNotFoundError(err) {
+		c.JSON(http.StatusNotFound, dto.NewResourceNotFoundErrorResponse(c.Request.Context(), err))
+		return
+	}
+
+	if errors.IsAuthorizationError(err) {
+		c.JSON(http.StatusForbidden, dto.NewAuthorizationErrorResponse(c.Request.Context(), err))
+		return
+	}
+
+	// Default to internal server error
+	logger.WithError(err).Error("internal server error")
+	c.JSON(http.StatusInternalServerError, dto.NewInternalErrorResponse(c.Request.Context(), err))
+}
@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"../../application/usecases"
+	"../../domain/models"
+)
+
+// MockFolderTemplateUseCase is a mock implementation of the FolderTemplateUseCase interface
+type MockFolderTemplateUseCase struct {
+	mock.Mock
+}
+
+func (m *MockFolderTemplateUseCase) CreateTemplate(ctx context.Context, template *models.FolderTemplate) (*models.FolderTemplate, error) {
+	args := m.Called(ctx, template)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.FolderTemplate), args.Error(1)
+}
+
+func (m *MockFolderTemplateUseCase) GetTemplate(ctx context.Context, id, tenantID string) (*models.FolderTemplate, error) {
+	args := m.Called(ctx, id, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.FolderTemplate), args.Error(1)
+}
+
+func (m *MockFolderTemplateUseCase) ListTemplates(ctx context.Context, tenantID string) ([]*models.FolderTemplate, error) {
+	args := m.Called(ctx, tenantID)
+	return args.Get(0).([]*models.FolderTemplate), args.Error(1)
+}
+
+func (m *MockFolderTemplateUseCase) UpdateTemplate(ctx context.Context, template *models.FolderTemplate) (*models.FolderTemplate, error) {
+	args := m.Called(ctx, template)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.FolderTemplate), args.Error(1)
+}
+
+func (m *MockFolderTemplateUseCase) DeleteTemplate(ctx context.Context, id, tenantID string) error {
+	args := m.Called(ctx, id, tenantID)
+	return args.Error(0)
+}
+
+func (m *MockFolderTemplateUseCase) ApplyTemplate(ctx context.Context, id, name, parentID, tenantID, userID string) (*models.Folder, error) {
+	args := m.Called(ctx, id, name, parentID, tenantID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Folder), args.Error(1)
+}
+
+// FolderTemplateHandlerSuite defines the test suite for FolderTemplateHandler
+type FolderTemplateHandlerSuite struct {
+	suite.Suite
+	router                *gin.Engine
+	recorder              *httptest.ResponseRecorder
+	folderTemplateUseCase *MockFolderTemplateUseCase
+}
+
+// SetupTest is called before each test
+func (s *FolderTemplateHandlerSuite) SetupTest() {
+	// Create a gin router in test mode
+	gin.SetMode(gin.TestMode)
+	s.router = gin.New()
+	s.recorder = httptest.NewRecorder()
+
+	// Create the folder template handler with a mock use case
+	s.folderTemplateUseCase = new(MockFolderTemplateUseCase)
+	handler, err := NewFolderTemplateHandler(s.folderTemplateUseCase)
+	s.Require().NoError(err)
+
+	// Set up a router group with an authenticated user and the folder template handler routes
+	group := s.router.Group("/api/v1")
+	group.Use(func(c *gin.Context) {
+		c.Set("tenant_id", "tenant-123")
+		c.Set("user_id", "user-123")
+		c.Next()
+	})
+	handler.RegisterRoutes(group)
+}
+
+// TestCreateTemplate_Success tests defining a folder tree with nested folders and grants
+func (s *FolderTemplateHandlerSuite) TestCreateTemplate_Success() {
+	s.folderTemplateUseCase.On("CreateTemplate", mock.Anything, mock.MatchedBy(func(t *models.FolderTemplate) bool {
+		return t.Name == "Project" && t.CreatedBy == "user-123" && len(t.Permissions) == 1 &&
+			len(t.Folders) == 1 && t.Folders[0].Folders[0].Name == "Archive"
+	})).Return(&models.FolderTemplate{
+		ID:   "template-123",
+		Name: "Project",
+		Folders: []models.FolderTemplateFolder{
+			{Name: "Invoices", MetadataFields: []string{"vendor"}, Folders: []models.FolderTemplateFolder{{Name: "Archive"}}},
+		},
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}, nil)
+
+	body := `{"name":"Project","permissions":[{"grantee_type":"role","grantee_id":"reader","permission_type":"read"}],` +
+		`"folders":[{"name":"Invoices","metadata_fields":["vendor"],"folders":[{"name":"Archive"}]}]}`
+	req, _ := http.NewRequest("POST", "/api/v1/folder-templates", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusCreated, s.recorder.Code)
+	s.Contains(s.recorder.Body.String(), `"folder_count":2`)
+	s.folderTemplateUseCase.AssertExpectations(s.T())
+}
+
+// TestApplyTemplate_Success tests creating a project folder from a template
+func (s *FolderTemplateHandlerSuite) TestApplyTemplate_Success() {
+	s.folderTemplateUseCase.On("ApplyTemplate", mock.Anything, "template-123", "Apollo", "folder-123", "tenant-123", "user-123").Return(&models.Folder{
+		ID:        "folder-456",
+		Name:      "Apollo",
+		ParentID:  "folder-123",
+		Path:      "/Projects/Apollo",
+		TenantID:  "tenant-123",
+		OwnerID:   "user-123",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}, nil)
+
+	req, _ := http.NewRequest("POST", "/api/v1/folder-templates/template-123/apply", bytes.NewBufferString(`{"name":"Apollo","parent_id":"folder-123"}`))
+	req.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusCreated, s.recorder.Code)
+	s.Contains(s.recorder.Body.String(), `"id":"folder-456"`)
+	s.folderTemplateUseCase.AssertExpectations(s.T())
+}
+
+// TestApplyTemplate_FolderExists tests applying a template as a folder that already exists
+func (s *FolderTemplateHandlerSuite) TestApplyTemplate_FolderExists() {
+	s.folderTemplateUseCase.On("ApplyTemplate", mock.Anything, "template-123", "Apollo", "", "tenant-123", "user-123").Return(nil, usecases.ErrFolderTemplateFolderExists)
+
+	req, _ := http.NewRequest("POST", "/api/v1/folder-templates/template-123/apply", bytes.NewBufferString(`{"name":"Apollo"}`))
+	req.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusBadRequest, s.recorder.Code)
+}
+
+// TestFolderTemplateHandlerSuite runs the test suite
+func TestFolderTemplateHandlerSuite(t *testing.T) {
+	suite.Run(t, new(FolderTemplateHandlerSuite))
+}
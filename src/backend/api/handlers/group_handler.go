@@ -0,0 +1,370 @@
+// Package handlers implements HTTP handlers for user group management in the Document Management Platform.
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin" // v1.9.0+
+
+	"../dto"
+	"../middleware"
+	"../validators"
+	"../../application/usecases"
+	"../../pkg/errors"
+	"../../pkg/logger"
+)
+
+// GroupHandler handles HTTP requests for managing a tenant's user groups
+type GroupHandler struct {
+	groupUseCase usecases.GroupUseCase
+}
+
+// NewGroupHandler creates a new GroupHandler instance
+func NewGroupHandler(groupUseCase usecases.GroupUseCase) (*GroupHandler, error) {
+	if groupUseCase == nil {
+		return nil, errors.NewValidationError("group use case cannot be nil")
+	}
+
+	return &GroupHandler{
+		groupUseCase: groupUseCase,
+	}, nil
+}
+
+// RegisterRoutes registers group routes with the provided router group
+func (h *GroupHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.POST("/groups", h.CreateGroup)
+	router.GET("/groups", h.ListGroups)
+	router.GET("/groups/:id", h.GetGroup)
+	router.PUT("/groups/:id", h.UpdateGroup)
+	router.DELETE("/groups/:id", h.DeleteGroup)
+	router.GET("/groups/:id/members", h.ListMembers)
+	router.POST("/groups/:id/members", h.AddMember)
+	router.DELETE("/groups/:id/members/:userId", h.RemoveMember)
+	router.POST("/groups/:id/folder-permissions", h.GrantFolderPermission)
+}
+
+// CreateGroup handles group creation requests
+func (h *GroupHandler) CreateGroup(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Bind request body to DTO
+	var req dto.GroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.WithError(err).Error("failed to bind request body")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			validators.BindingError(err),
+			nil,
+		))
+		return
+	}
+
+	// Call use case to create the group
+	group, err := h.groupUseCase.CreateGroup(c.Request.Context(), tenantID, req.Name, req.Description, middleware.GetUserID(c))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.NewDataResponse(dto.ToGroupDTO(group)))
+}
+
+// ListGroups handles requests to list the tenant's groups
+func (h *GroupHandler) ListGroups(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	page, pageSize := h.getPaginationParams(c)
+
+	// Call use case to list groups
+	result, err := h.groupUseCase.ListGroups(c.Request.Context(), tenantID, page, pageSize)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	// Convert domain models to DTOs and return
+	groups := dto.ToGroupListDTO(result)
+	c.JSON(http.StatusOK, dto.NewPaginatedResponse(groups, result.Pagination))
+}
+
+// GetGroup handles group retrieval requests
+func (h *GroupHandler) GetGroup(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Call use case to get the group
+	group, err := h.groupUseCase.GetGroup(c.Request.Context(), c.Param("id"), tenantID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.ToGroupDTO(group)))
+}
+
+// UpdateGroup handles group update requests
+func (h *GroupHandler) UpdateGroup(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Bind request body to DTO
+	var req dto.GroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.WithError(err).Error("failed to bind request body")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			validators.BindingError(err),
+			nil,
+		))
+		return
+	}
+
+	// Call use case to update the group
+	group, err := h.groupUseCase.UpdateGroup(c.Request.Context(), c.Param("id"), tenantID, req.Name, req.Description)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.ToGroupDTO(group)))
+}
+
+// DeleteGroup handles group deletion requests
+func (h *GroupHandler) DeleteGroup(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Call use case to delete the group
+	if err := h.groupUseCase.DeleteGroup(c.Request.Context(), c.Param("id"), tenantID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	// Return success response
+	c.JSON(http.StatusOK, dto.NewMessageResponse("Group deleted successfully"))
+}
+
+// ListMembers handles requests to list the members of a group
+func (h *GroupHandler) ListMembers(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	page, pageSize := h.getPaginationParams(c)
+
+	// Call use case to list the group's members
+	result, err := h.groupUseCase.ListMembers(c.Request.Context(), c.Param("id"), tenantID, page, pageSize)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	// Convert domain models to DTOs and return
+	members := dto.ToGroupMemberListDTO(result)
+	c.JSON(http.StatusOK, dto.NewPaginatedResponse(members, result.Pagination))
+}
+
+// AddMember handles requests to add a user to a group
+func (h *GroupHandler) AddMember(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Bind request body to DTO
+	var req dto.AddGroupMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.WithError(err).Error("failed to bind request body")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			validators.BindingError(err),
+			nil,
+		))
+		return
+	}
+
+	// Call use case to add the member
+	if err := h.groupUseCase.AddMember(c.Request.Context(), c.Param("id"), req.UserID, tenantID, middleware.GetUserID(c)); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewMessageResponse("Group member added successfully"))
+}
+
+// RemoveMember handles requests to remove a user from a group
+func (h *GroupHandler) RemoveMember(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Call use case to remove the member
+	if err := h.groupUseCase.RemoveMember(c.Request.Context(), c.Param("id"), c.Param("userId"), tenantID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewMessageResponse("Group member removed successfully"))
+}
+
+// GrantFolderPermission handles requests to grant every member of a group a permission on a folder
+func (h *GroupHandler) GrantFolderPermission(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Bind request body to DTO
+	var req dto.GrantGroupFolderPermissionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.WithError(err).Error("failed to bind request body")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			validators.BindingError(err),
+			nil,
+		))
+		return
+	}
+
+	// Call use case to grant the permission
+	permissionID, err := h.groupUseCase.GrantFolderPermission(c.Request.Context(), c.Param("id"), req.FolderID, req.PermissionType, tenantID, middleware.GetUserID(c))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.NewDataResponse(map[string]string{"id": permissionID}))
+}
+
+// getPaginationParams extracts and validates pagination parameters from the request
+func (h *GroupHandler) getPaginationParams(c *gin.Context) (int, int) {
+	page := 1
+	if p, err := strconv.Atoi(c.Query("page")); err == nil && p > 0 {
+		page = p
+	}
+
+	pageSize := defaultPageSize
+	if ps, err := strconv.Atoi(c.Query("pageSize")); err == nil && ps > 0 {
+		pageSize = ps
+	}
+
+	// Cap pageSize at maximum
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	return page, pageSize
+}
+
+// handleError handles errors and returns appropriate HTTP responses
+func (h *GroupHandler) handleError(c *gin.Context, err error) {
+	if errors.IsValidationError(err) {
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			err,
+			map[string]string{},
+		))
+		return
+	}
+
+	if errors.IsResourceNotFoundError(err) {
+		c.JSON(http.StatusNotFound, dto.NewResourceNotFoundErrorResponse(c.Request.Context(), err))
+		return
+	}
+
+	if errors.IsAuthorizationError(err) {
+		c.JSON(http.StatusForbidden, dto.NewAuthorizationErrorResponse(c.Request.Context(), err))
+		return
+	}
+
+	// Default to internal server error
+	logger.WithError(err).Error("internal server error")
+	c.JSON(http.StatusInternalServerError, dto.NewInternalErrorResponse(c.Request.Context(), err))
+}
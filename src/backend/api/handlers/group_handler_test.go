@@ -0,0 +1,211 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"../../domain/models"
+	apperrors "../../pkg/errors"
+	"../../pkg/utils/pagination"
+)
+
+// MockGroupUseCase is a mock implementation of the GroupUseCase interface
+type MockGroupUseCase struct {
+	mock.Mock
+}
+
+func (m *MockGroupUseCase) CreateGroup(ctx context.Context, tenantID, name, description, userID string) (*models.Group, error) {
+	args := m.Called(ctx, tenantID, name, description, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Group), args.Error(1)
+}
+
+func (m *MockGroupUseCase) GetGroup(ctx context.Context, id string, tenantID string) (*models.Group, error) {
+	args := m.Called(ctx, id, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Group), args.Error(1)
+}
+
+func (m *MockGroupUseCase) ListGroups(ctx context.Context, tenantID string, page int, pageSize int) (pagination.PaginatedResult[models.Group], error) {
+	args := m.Called(ctx, tenantID, page, pageSize)
+	return args.Get(0).(pagination.PaginatedResult[models.Group]), args.Error(1)
+}
+
+func (m *MockGroupUseCase) UpdateGroup(ctx context.Context, id, tenantID, name, description string) (*models.Group, error) {
+	args := m.Called(ctx, id, tenantID, name, description)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Group), args.Error(1)
+}
+
+func (m *MockGroupUseCase) DeleteGroup(ctx context.Context, id string, tenantID string) error {
+	args := m.Called(ctx, id, tenantID)
+	return args.Error(0)
+}
+
+func (m *MockGroupUseCase) AddMember(ctx context.Context, groupID, memberID, tenantID, userID string) error {
+	args := m.Called(ctx, groupID, memberID, tenantID, userID)
+	return args.Error(0)
+}
+
+func (m *MockGroupUseCase) RemoveMember(ctx context.Context, groupID, memberID, tenantID string) error {
+	args := m.Called(ctx, groupID, memberID, tenantID)
+	return args.Error(0)
+}
+
+func (m *MockGroupUseCase) ListMembers(ctx context.Context, groupID, tenantID string, page int, pageSize int) (pagination.PaginatedResult[models.GroupMembership], error) {
+	args := m.Called(ctx, groupID, tenantID, page, pageSize)
+	return args.Get(0).(pagination.PaginatedResult[models.GroupMembership]), args.Error(1)
+}
+
+func (m *MockGroupUseCase) GrantFolderPermission(ctx context.Context, groupID, folderID, permissionType, tenantID, userID string) (string, error) {
+	args := m.Called(ctx, groupID, folderID, permissionType, tenantID, userID)
+	return args.String(0), args.Error(1)
+}
+
+// GroupHandlerSuite defines the test suite
+type GroupHandlerSuite struct {
+	suite.Suite
+	router       *gin.Engine
+	recorder     *httptest.ResponseRecorder
+	groupUseCase *MockGroupUseCase
+	groupHandler *GroupHandler
+}
+
+// SetupTest is called before each test
+func (s *GroupHandlerSuite) SetupTest() {
+	// Create a gin router in test mode
+	gin.SetMode(gin.TestMode)
+	s.router = gin.New()
+	s.recorder = httptest.NewRecorder()
+
+	// Create the group handler with a mock use case
+	s.groupUseCase = new(MockGroupUseCase)
+	handler, err := NewGroupHandler(s.groupUseCase)
+	s.Require().NoError(err)
+	s.groupHandler = handler
+
+	// Set up a router group with an authenticated tenant and the group handler routes
+	group := s.router.Group("/api/v1")
+	group.Use(func(c *gin.Context) {
+		c.Set("tenant_id", "tenant-123")
+		c.Set("user_id", "user-123")
+		c.Next()
+	})
+	s.groupHandler.RegisterRoutes(group)
+}
+
+// Helper function to create a test group model
+func (s *GroupHandlerSuite) createTestGroup() *models.Group {
+	return &models.Group{
+		ID:          "group-123",
+		TenantID:    "tenant-123",
+		Name:        "legal",
+		Description: "Legal team",
+		CreatedBy:   "user-123",
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+}
+
+// TestCreateGroup_Success tests creating a group
+func (s *GroupHandlerSuite) TestCreateGroup_Success() {
+	s.groupUseCase.On("CreateGroup", mock.Anything, "tenant-123", "legal", "Legal team", "user-123").Return(s.createTestGroup(), nil)
+
+	body := `{"name":"legal","description":"Legal team"}`
+	req, _ := http.NewRequest("POST", "/api/v1/groups", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusCreated, s.recorder.Code)
+	s.Contains(s.recorder.Body.String(), `"name":"legal"`)
+	s.groupUseCase.AssertExpectations(s.T())
+}
+
+// TestListGroups_Success tests listing the tenant's groups
+func (s *GroupHandlerSuite) TestListGroups_Success() {
+	result := pagination.PaginatedResult[models.Group]{
+		Items:      []models.Group{*s.createTestGroup()},
+		Pagination: pagination.PageInfo{Page: 1, PageSize: 20, TotalPages: 1, TotalItems: 1},
+	}
+	s.groupUseCase.On("ListGroups", mock.Anything, "tenant-123", 1, 20).Return(result, nil)
+
+	req, _ := http.NewRequest("GET", "/api/v1/groups", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusOK, s.recorder.Code)
+	s.Contains(s.recorder.Body.String(), `"name":"legal"`)
+	s.groupUseCase.AssertExpectations(s.T())
+}
+
+// TestAddMember_Success tests adding a user to a group
+func (s *GroupHandlerSuite) TestAddMember_Success() {
+	s.groupUseCase.On("AddMember", mock.Anything, "group-123", "member-456", "tenant-123", "user-123").Return(nil)
+
+	body := `{"user_id":"member-456"}`
+	req, _ := http.NewRequest("POST", "/api/v1/groups/group-123/members", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusOK, s.recorder.Code)
+	s.groupUseCase.AssertExpectations(s.T())
+}
+
+// TestRemoveMember_NotFound tests removing a user who is not a member of the group
+func (s *GroupHandlerSuite) TestRemoveMember_NotFound() {
+	s.groupUseCase.On("RemoveMember", mock.Anything, "group-123", "member-456", "tenant-123").
+		Return(apperrors.NewResourceNotFoundError("Group member not found"))
+
+	req, _ := http.NewRequest("DELETE", "/api/v1/groups/group-123/members/member-456", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusNotFound, s.recorder.Code)
+	s.groupUseCase.AssertExpectations(s.T())
+}
+
+// TestGrantFolderPermission_Success tests granting a group access to a folder
+func (s *GroupHandlerSuite) TestGrantFolderPermission_Success() {
+	s.groupUseCase.On("GrantFolderPermission", mock.Anything, "group-123", "folder-123", models.PermissionTypeWrite, "tenant-123", "user-123").
+		Return("perm-123", nil)
+
+	body := `{"folder_id":"folder-123","permission_type":"write"}`
+	req, _ := http.NewRequest("POST", "/api/v1/groups/group-123/folder-permissions", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusCreated, s.recorder.Code)
+	s.Contains(s.recorder.Body.String(), `"id":"perm-123"`)
+	s.groupUseCase.AssertExpectations(s.T())
+}
+
+// TestGrantFolderPermission_Forbidden tests granting access to a folder the caller cannot administer
+func (s *GroupHandlerSuite) TestGrantFolderPermission_Forbidden() {
+	s.groupUseCase.On("GrantFolderPermission", mock.Anything, "group-123", "folder-123", models.PermissionTypeRead, "tenant-123", "user-123").
+		Return("", apperrors.NewAuthorizationError("user does not have admin permission on folder"))
+
+	body := `{"folder_id":"folder-123","permission_type":"read"}`
+	req, _ := http.NewRequest("POST", "/api/v1/groups/group-123/folder-permissions", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusForbidden, s.recorder.Code)
+	s.groupUseCase.AssertExpectations(s.T())
+}
+
+// TestGroupHandlerSuite runs the test suite
+func TestGroupHandlerSuite(t *testing.T) {
+	suite.Run(t, new(GroupHandlerSuite))
+}
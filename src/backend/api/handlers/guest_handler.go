@@ -0,0 +1,211 @@
+// Package handlers implements HTTP handlers for external guest access in the Document Management Platform.
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin" // v1.9.0+
+
+	"../dto"
+	"../middleware"
+	"../validators"
+	"../../application/usecases"
+	"../../domain/models"
+	"../../pkg/errors"
+	"../../pkg/logger"
+)
+
+// GuestInviter invites external guests; it is implemented by usecases.AuthUseCase
+type GuestInviter interface {
+	InviteGuest(ctx context.Context, inviterID, tenantID, guestEmail, resourceType, resourceID string, expiresIn time.Duration) (*models.GuestScope, error)
+}
+
+// GuestHandler handles HTTP requests for inviting external guests and for the guests' read-only access
+type GuestHandler struct {
+	guestUseCase usecases.GuestUseCase
+	guestInviter GuestInviter
+}
+
+// NewGuestHandler creates a new GuestHandler instance
+func NewGuestHandler(guestUseCase usecases.GuestUseCase, guestInviter GuestInviter) (*GuestHandler, error) {
+	if guestUseCase == nil {
+		return nil, errors.NewValidationError("guest use case cannot be nil")
+	}
+	if guestInviter == nil {
+		return nil, errors.NewValidationError("guest inviter cannot be nil")
+	}
+
+	return &GuestHandler{
+		guestUseCase: guestUseCase,
+		guestInviter: guestInviter,
+	}, nil
+}
+
+// RegisterRoutes registers the authenticated guest invitation route with the provided router group
+func (h *GuestHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.POST("/guest-invitations", h.InviteGuest)
+}
+
+// RegisterGuestRoutes registers the routes available to guest tokens with the provided router group
+func (h *GuestHandler) RegisterGuestRoutes(router *gin.RouterGroup) {
+	router.GET("/documents", h.ListDocuments)
+	router.GET("/documents/:id", h.GetDocument)
+	router.GET("/documents/:id/download", h.DownloadDocument)
+}
+
+// InviteGuest handles requests to invite an external guest to a document or folder
+func (h *GuestHandler) InviteGuest(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Bind request body to DTO
+	var req dto.InviteGuestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.WithError(err).Error("failed to bind request body")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			validators.BindingError(err),
+			nil,
+		))
+		return
+	}
+
+	// Invite the guest; the token is emailed to the guest and not returned
+	expiresIn := time.Duration(req.ExpiresInHours) * time.Hour
+	scope, err := h.guestInviter.InviteGuest(c.Request.Context(), middleware.GetUserID(c), tenantID, req.Email, req.ResourceType, req.ResourceID, expiresIn)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.NewDataResponse(dto.ToGuestInvitationDTO(scope)))
+}
+
+// ListDocuments handles guest requests to list the documents they have been given access to
+func (h *GuestHandler) ListDocuments(c *gin.Context) {
+	scope := middleware.GetGuestScope(c)
+	if scope == nil {
+		c.JSON(http.StatusUnauthorized, dto.NewAuthenticationErrorResponse(
+			c.Request.Context(),
+			errors.NewAuthenticationError("guest token required"),
+		))
+		return
+	}
+
+	page, pageSize := h.getPaginationParams(c)
+
+	// Call use case to list the documents in scope
+	result, err := h.guestUseCase.ListDocuments(c.Request.Context(), scope, page, pageSize)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewPaginatedResponse(dto.DocumentsToDTOs(result.Items), result.Pagination))
+}
+
+// GetDocument handles guest requests to retrieve a document they have been given access to
+func (h *GuestHandler) GetDocument(c *gin.Context) {
+	scope := middleware.GetGuestScope(c)
+	if scope == nil {
+		c.JSON(http.StatusUnauthorized, dto.NewAuthenticationErrorResponse(
+			c.Request.Context(),
+			errors.NewAuthenticationError("guest token required"),
+		))
+		return
+	}
+
+	// Call use case to get the document
+	document, err := h.guestUseCase.GetDocument(c.Request.Context(), scope, c.Param("id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.DocumentToDTO(*document)))
+}
+
+// DownloadDocument handles guest download requests by redirecting to a short-lived presigned download URL
+func (h *GuestHandler) DownloadDocument(c *gin.Context) {
+	scope := middleware.GetGuestScope(c)
+	if scope == nil {
+		c.JSON(http.StatusUnauthorized, dto.NewAuthenticationErrorResponse(
+			c.Request.Context(),
+			errors.NewAuthenticationError("guest token required"),
+		))
+		return
+	}
+
+	// Call use case to get the download URL
+	url, err := h.guestUseCase.GetDocumentDownloadURL(c.Request.Context(), scope, c.Param("id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Redirect(http.StatusFound, url)
+}
+
+// getPaginationParams extracts and validates pagination parameters from the request
+func (h *GuestHandler) getPaginationParams(c *gin.Context) (int, int) {
+	page := 1
+	if p, err := strconv.Atoi(c.Query("page")); err == nil && p > 0 {
+		page = p
+	}
+
+	pageSize := defaultPageSize
+	if ps, err := strconv.Atoi(c.Query("pageSize")); err == nil && ps > 0 {
+		pageSize = ps
+	}
+
+	// Cap pageSize at maximum
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	return page, pageSize
+}
+
+// handleError handles errors and returns appropriate HTTP responses
+func (h *GuestHandler) handleError(c *gin.Context, err error) {
+	if errors.IsValidationError(err) {
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			err,
+			map[string]string{},
+		))
+		return
+	}
+
+	if errors.IsResourceNotFoundError(err) {
+		c.JSON(http.StatusNotFound, dto.NewResourceNotFoundErrorResponse(c.Request.Context(), err))
+		return
+	}
+
+	if errors.IsAuthenticationError(err) {
+		c.JSON(http.StatusUnauthorized, dto.NewAuthenticationErrorResponse(c.Request.Context(), err))
+		return
+	}
+
+	if errors.IsAuthorizationError(err) {
+		c.JSON(http.StatusForbidden, dto.NewAuthorizationErrorResponse(c.Request.Context(), err))
+		return
+	}
+
+	// Default to internal server error
+	logger.WithError(err).Error("internal server error")
+	c.JSON(http.StatusInternalServerError, dto.NewInternalErrorResponse(c.Request.Context(), err))
+}
@@ -0,0 +1,202 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"../../domain/models"
+	apperrors "../../pkg/errors"
+	"../../pkg/utils"
+	"../../pkg/validator"
+	"../dto"
+)
+
+// MockGuestUseCase is a mock implementation of the GuestUseCase interface
+type MockGuestUseCase struct {
+	mock.Mock
+}
+
+func (m *MockGuestUseCase) ListDocuments(ctx context.Context, scope *models.GuestScope, page, pageSize int) (utils.PaginatedResult[models.Document], error) {
+	args := m.Called(ctx, scope, page, pageSize)
+	return args.Get(0).(utils.PaginatedResult[models.Document]), args.Error(1)
+}
+
+func (m *MockGuestUseCase) GetDocument(ctx context.Context, scope *models.GuestScope, documentID string) (*models.Document, error) {
+	args := m.Called(ctx, scope, documentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Document), args.Error(1)
+}
+
+func (m *MockGuestUseCase) GetDocumentDownloadURL(ctx context.Context, scope *models.GuestScope, documentID string) (string, error) {
+	args := m.Called(ctx, scope, documentID)
+	return args.String(0), args.Error(1)
+}
+
+// MockGuestInviter is a mock implementation of the GuestInviter interface
+type MockGuestInviter struct {
+	mock.Mock
+}
+
+func (m *MockGuestInviter) InviteGuest(ctx context.Context, inviterID, tenantID, guestEmail, resourceType, resourceID string, expiresIn time.Duration) (*models.GuestScope, error) {
+	args := m.Called(ctx, inviterID, tenantID, guestEmail, resourceType, resourceID, expiresIn)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.GuestScope), args.Error(1)
+}
+
+// GuestHandlerSuite defines the test suite
+type GuestHandlerSuite struct {
+	suite.Suite
+	router       *gin.Engine
+	recorder     *httptest.ResponseRecorder
+	guestUseCase *MockGuestUseCase
+	guestInviter *MockGuestInviter
+	guestHandler *GuestHandler
+	scope        *models.GuestScope
+}
+
+// SetupTest is called before each test
+func (s *GuestHandlerSuite) SetupTest() {
+	// Create a gin router in test mode
+	gin.SetMode(gin.TestMode)
+	s.router = gin.New()
+	s.recorder = httptest.NewRecorder()
+
+	// Create the guest handler with mock dependencies
+	s.guestUseCase = new(MockGuestUseCase)
+	s.guestInviter = new(MockGuestInviter)
+	handler, err := NewGuestHandler(s.guestUseCase, s.guestInviter)
+	s.Require().NoError(err)
+	s.guestHandler = handler
+
+	// Set up a router group with an authenticated tenant and the invitation route
+	group := s.router.Group("/api/v1")
+	group.Use(func(c *gin.Context) {
+		c.Set("tenant_id", "tenant-123")
+		c.Set("user_id", "user-123")
+		c.Next()
+	})
+	s.guestHandler.RegisterRoutes(group)
+
+	// Set up a router group authenticated with a guest token for a shared folder
+	s.scope = models.NewGuestScope("tenant-123", "reviewer@example.com", models.ResourceTypeFolder, "folder-123", "user-123", time.Now().Add(24*time.Hour))
+	guestGroup := s.router.Group("/api/v1/guest")
+	guestGroup.Use(func(c *gin.Context) {
+		c.Set("guest_scope", s.scope)
+		c.Set("tenant_id", "tenant-123")
+		c.Next()
+	})
+	s.guestHandler.RegisterGuestRoutes(guestGroup)
+}
+
+// TestInviteGuest_Success tests inviting a guest to a folder
+func (s *GuestHandlerSuite) TestInviteGuest_Success() {
+	s.guestInviter.On("InviteGuest", mock.Anything, "user-123", "tenant-123", "reviewer@example.com", "folder", "folder-123", 48*time.Hour).
+		Return(s.scope, nil)
+
+	body := `{"email":"reviewer@example.com","resource_type":"folder","resource_id":"folder-123","expires_in_hours":48}`
+	req, _ := http.NewRequest("POST", "/api/v1/guest-invitations", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusCreated, s.recorder.Code)
+	s.Contains(s.recorder.Body.String(), `"email":"reviewer@example.com"`)
+	s.NotContains(s.recorder.Body.String(), "token")
+	s.guestInviter.AssertExpectations(s.T())
+}
+
+// TestInviteGuest_InvalidResourceType tests inviting a guest to an unsupported resource
+func (s *GuestHandlerSuite) TestInviteGuest_InvalidResourceType() {
+	body := `{"email":"reviewer@example.com","resource_type":"tenant","resource_id":"tenant-123","expires_in_hours":48}`
+	req, _ := http.NewRequest("POST", "/api/v1/guest-invitations", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusBadRequest, s.recorder.Code)
+	s.guestInviter.AssertNotCalled(s.T(), "InviteGuest")
+}
+
+// TestInviteGuest_EveryViolation tests that every invalid field of a request is reported at once
+func (s *GuestHandlerSuite) TestInviteGuest_EveryViolation() {
+	defaultValidator := binding.Validator
+	binding.Validator = validator.StructValidator{}
+	defer func() { binding.Validator = defaultValidator }()
+
+	body := `{"email":"not-an-email","resource_type":"tenant","expires_in_hours":0}`
+	req, _ := http.NewRequest("POST", "/api/v1/guest-invitations", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusBadRequest, s.recorder.Code)
+	s.guestInviter.AssertNotCalled(s.T(), "InviteGuest")
+
+	var response dto.ValidationErrorResponse
+	s.Require().NoError(json.Unmarshal(s.recorder.Body.Bytes(), &response))
+	s.Equal(apperrors.CodeValidationFailed, response.Code)
+	s.Require().Len(response.Violations, 4)
+	rules := make(map[string]string, len(response.Violations))
+	for _, violation := range response.Violations {
+		rules[violation.Field] = violation.Rule
+		s.NotEmpty(violation.Message)
+	}
+	s.Equal(map[string]string{
+		"email":            "email",
+		"resource_type":    "oneof",
+		"resource_id":      "required",
+		"expires_in_hours": "required",
+	}, rules)
+	s.Equal("email must be a valid email address", response.ValidationErrors["email"])
+}
+
+// TestInviteGuest_Forbidden tests inviting a guest to a resource the user cannot read
+func (s *GuestHandlerSuite) TestInviteGuest_Forbidden() {
+	s.guestInviter.On("InviteGuest", mock.Anything, "user-123", "tenant-123", "reviewer@example.com", "document", "doc-123", 24*time.Hour).
+		Return(nil, apperrors.NewAuthorizationError("user does not have access to the resource"))
+
+	body := `{"email":"reviewer@example.com","resource_type":"document","resource_id":"doc-123","expires_in_hours":24}`
+	req, _ := http.NewRequest("POST", "/api/v1/guest-invitations", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusForbidden, s.recorder.Code)
+}
+
+// TestDownloadDocument_Redirect tests that a guest download redirects to the presigned URL
+func (s *GuestHandlerSuite) TestDownloadDocument_Redirect() {
+	s.guestUseCase.On("GetDocumentDownloadURL", mock.Anything, s.scope, "doc-123").Return("https://s3.example.com/presigned", nil)
+
+	req, _ := http.NewRequest("GET", "/api/v1/guest/documents/doc-123/download", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusFound, s.recorder.Code)
+	s.Equal("https://s3.example.com/presigned", s.recorder.Header().Get("Location"))
+	s.guestUseCase.AssertExpectations(s.T())
+}
+
+// TestGetDocument_OutsideScope tests requesting a document outside the guest scope
+func (s *GuestHandlerSuite) TestGetDocument_OutsideScope() {
+	s.guestUseCase.On("GetDocument", mock.Anything, s.scope, "doc-999").Return(nil, apperrors.NewResourceNotFoundError("document not found"))
+
+	req, _ := http.NewRequest("GET", "/api/v1/guest/documents/doc-999", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusNotFound, s.recorder.Code)
+}
+
+// TestGuestHandlerSuite runs the test suite
+func TestGuestHandlerSuite(t *testing.T) {
+	suite.Run(t, new(GuestHandlerSuite))
+}
@@ -3,19 +3,36 @@
 package handlers
 
 import (
-	"context"
-	"fmt"
-	"net/http"
-	"time"
+	"context"  // standard library
+	"fmt"      // standard library
+	"net/http" // standard library
+	"sort"     // standard library
+	"strings"  // standard library
+	"sync"     // standard library
+	"time"     // standard library
 
 	"github.com/gin-gonic/gin" // v1.9.0+
-	"context" // standard library
-	"fmt" // standard library
-	"time" // standard library
 
-	"../dto" // For creating standardized API responses
-	"../../pkg/logger" // For structured logging of health check operations
-	"../../pkg/errors" // For standardized error handling
+	"../../domain/services" // For the dependency probes of the infrastructure clients
+	"../../pkg/errors"      // For standardized error handling
+	"../../pkg/logger"      // For structured logging of health check operations
+	"../dto"                // For creating standardized API responses
+)
+
+// Timeouts of the checks of each dependency. Dependencies are checked concurrently, so a probe answers
+// within about its timeout even when several dependencies hang; the probe timeout stays below the
+// timeoutSeconds of the Kubernetes probes.
+const (
+	probeTimeout      = 3 * time.Second
+	deepHealthTimeout = 15 * time.Second
+)
+
+// Health status values reported by the health endpoints
+const (
+	healthStatusOK       = "ok"
+	healthStatusDegraded = "degraded"
+	dependencyStatusUp   = "up"
+	dependencyStatusDown = "down"
 )
 
 // HealthChecker is an interface for components that can be health-checked
@@ -26,16 +43,38 @@ type HealthChecker interface {
 
 // HealthHandler handles health check endpoints
 type HealthHandler struct {
-	checkers map[string]HealthChecker
+	checkers     map[string]HealthChecker
+	probeTimeout time.Duration
 }
 
-// NewHealthHandler creates a new HealthHandler with the provided health checkers
+// NewHealthHandler creates a new HealthHandler with the provided health checkers, keyed by the name
+// of the dependency they check
 func NewHealthHandler(checkers map[string]HealthChecker) *HealthHandler {
 	return &HealthHandler{
-		checkers: checkers,
+		checkers:     checkers,
+		probeTimeout: probeTimeout,
 	}
 }
 
+// RegisterRoutes registers the health check endpoints on the router. /healthz and /readyz are the
+// endpoints of the Kubernetes liveness and readiness probes; the /health group keeps the older paths.
+func (h *HealthHandler) RegisterRoutes(router *gin.Engine) {
+	// Liveness probe reporting the status of each dependency without failing on them
+	router.GET("/healthz", h.HealthCheck)
+	// Readiness probe failing while any dependency is unavailable
+	router.GET("/readyz", h.ReadinessCheck)
+
+	health := router.Group("/health")
+	// Simple liveness check to indicate the service is running
+	health.GET("/live", h.LivenessCheck)
+	health.GET("/liveness", h.LivenessCheck)
+	// Readiness check to verify the service can handle requests
+	health.GET("/ready", h.ReadinessCheck)
+	health.GET("/readiness", h.ReadinessCheck)
+	// Deep health check to verify connections to dependencies with a longer timeout
+	health.GET("/deep", h.DeepHealthCheck)
+}
+
 // LivenessCheck handles the liveness probe endpoint
 // This is a basic check that the application is running
 func (h *HealthHandler) LivenessCheck(c *gin.Context) {
@@ -43,23 +82,36 @@ func (h *HealthHandler) LivenessCheck(c *gin.Context) {
 	c.JSON(http.StatusOK, dto.NewDataResponse(map[string]bool{"alive": true}))
 }
 
+// HealthCheck handles the /healthz endpoint
+// This reports the status of each dependency, but answers 200 OK while the application is running so
+// that an outage of a dependency takes pods out of rotation through readiness instead of restarting them
+func (h *HealthHandler) HealthCheck(c *gin.Context) {
+	status, err := h.checkDependencies(c.Request.Context(), h.probeTimeout)
+
+	overall := healthStatusOK
+	if err != nil {
+		logger.ErrorContext(c.Request.Context(), "Health check found unavailable dependencies", "error", err.Error())
+		overall = healthStatusDegraded
+	}
+
+	c.JSON(http.StatusOK, dto.NewDataResponse(map[string]interface{}{
+		"status":       overall,
+		"dependencies": status,
+	}))
+}
+
 // ReadinessCheck handles the readiness probe endpoint
 // This checks if the application is ready to handle requests
 func (h *HealthHandler) ReadinessCheck(c *gin.Context) {
 	logger.InfoContext(c.Request.Context(), "Readiness check requested")
-	
-	// Create context with timeout for readiness check
-	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
-	defer cancel()
-	
-	// Check all dependencies
-	status, err := h.checkDependencies(ctx)
+
+	status, err := h.checkDependencies(c.Request.Context(), h.probeTimeout)
 	if err != nil {
-		logger.ErrorContext(ctx, "Readiness check failed", "error", err.Error())
-		c.JSON(http.StatusServiceUnavailable, dto.NewDependencyErrorResponse(err.Error()))
+		logger.ErrorContext(c.Request.Context(), "Readiness check failed", "error", err.Error())
+		c.JSON(http.StatusServiceUnavailable, newUnhealthyResponse(err, status))
 		return
 	}
-	
+
 	// Return success with dependency status
 	c.JSON(http.StatusOK, dto.NewDataResponse(status))
 }
@@ -68,123 +120,105 @@ func (h *HealthHandler) ReadinessCheck(c *gin.Context) {
 // This performs a more thorough check of all system components
 func (h *HealthHandler) DeepHealthCheck(c *gin.Context) {
 	logger.InfoContext(c.Request.Context(), "Deep health check requested")
-	
-	// Create context with longer timeout for deep health check
-	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
-	defer cancel()
-	
-	// Check all dependencies
-	status, err := h.checkDependencies(ctx)
+
+	status, err := h.checkDependencies(c.Request.Context(), deepHealthTimeout)
 	if err != nil {
-		logger.ErrorContext(ctx, "Deep health check failed", "error", err.Error())
-		c.JSON(http.StatusServiceUnavailable, dto.NewDependencyErrorResponse(err.Error()))
+		logger.ErrorContext(c.Request.Context(), "Deep health check failed", "error", err.Error())
+		c.JSON(http.StatusServiceUnavailable, newUnhealthyResponse(err, status))
 		return
 	}
-	
+
 	// Return success with detailed status information
 	c.JSON(http.StatusOK, dto.NewDataResponse(status))
 }
 
-// checkDependencies checks the health of all registered dependencies
-func (h *HealthHandler) checkDependencies(ctx context.Context) (map[string]interface{}, error) {
-	status := make(map[string]interface{})
-	
-	// Check each registered health checker
+// checkDependencies checks all registered dependencies concurrently, each within timeout, and returns
+// the status of each of them. The error names the dependencies that are unavailable.
+func (h *HealthHandler) checkDependencies(ctx context.Context, timeout time.Duration) (map[string]interface{}, error) {
+	status := make(map[string]interface{}, len(h.checkers))
+	var failed []string
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
 	for name, checker := range h.checkers {
-		result, err := checker.Check(ctx)
-		if err != nil {
-			return nil, errors.NewDependencyError(fmt.Sprintf("dependency %s check failed: %s", name, err.Error()))
-		}
-		status[name] = result
+		wg.Add(1)
+		go func(name string, checker HealthChecker) {
+			defer wg.Done()
+
+			result, err := runHealthCheck(ctx, checker, timeout)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				status[name] = map[string]interface{}{"status": dependencyStatusDown, "error": err.Error()}
+				failed = append(failed, name)
+				return
+			}
+			status[name] = result
+		}(name, checker)
 	}
-	
-	return status, nil
-}
-
-// DatabaseHealthChecker implements health checking for database connections
-type DatabaseHealthChecker struct {
-	// In a real implementation, this would have a reference to the database
-}
-
-// NewDatabaseHealthChecker creates a new DatabaseHealthChecker
-func NewDatabaseHealthChecker() *DatabaseHealthChecker {
-	return &DatabaseHealthChecker{}
-}
+	wg.Wait()
 
-// Check performs a health check on the database
-func (c *DatabaseHealthChecker) Check(ctx context.Context) (interface{}, error) {
-	// In a real implementation, this would execute a simple query to verify connection
-	// and collect connection pool statistics
-	
-	// Simulate database check
-	// If database connection fails, return an error instead
-	return map[string]interface{}{
-		"status": "connected",
-		"latency_ms": 5,
-		"connections": map[string]interface{}{
-			"active": 5,
-			"idle": 10,
-			"max": 25,
-		},
-	}, nil
+	if len(failed) > 0 {
+		sort.Strings(failed)
+		return status, errors.NewDependencyError(fmt.Sprintf("dependencies unavailable: %s", strings.Join(failed, ", ")))
+	}
+	return status, nil
 }
 
-// StorageHealthChecker implements health checking for S3 storage
-type StorageHealthChecker struct {
-	storageService services.StorageService
-}
+// runHealthCheck runs a health check within timeout. The check is abandoned when the timeout passes,
+// also when the checker does not honor the cancellation of its context.
+func runHealthCheck(ctx context.Context, checker HealthChecker, timeout time.Duration) (interface{}, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
-// NewStorageHealthChecker creates a new StorageHealthChecker
-func NewStorageHealthChecker(storageService services.StorageService) *StorageHealthChecker {
-	return &StorageHealthChecker{
-		storageService: storageService,
+	type outcome struct {
+		result interface{}
+		err    error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		result, err := checker.Check(ctx)
+		done <- outcome{result: result, err: err}
+	}()
+
+	select {
+	case o := <-done:
+		return o.result, o.err
+	case <-ctx.Done():
+		return nil, fmt.Errorf("check timed out after %s", timeout)
 	}
 }
 
-// Check performs a health check on the storage service
-func (c *StorageHealthChecker) Check(ctx context.Context) (interface{}, error) {
-	// In a real implementation, this would check connectivity to S3
-	// and collect metrics about storage service operations
-	
-	// Simulate storage service check
-	// If S3 connectivity fails, return an error instead
-	return map[string]interface{}{
-		"status": "connected",
-		"buckets_accessible": true,
-		"operations": map[string]interface{}{
-			"uploads_last_minute": 12,
-			"downloads_last_minute": 35,
-			"avg_latency_ms": 125,
-		},
-	}, nil
+// newUnhealthyResponse creates the response of a failed readiness or deep health check, with the
+// status of every dependency as details
+func newUnhealthyResponse(err error, status map[string]interface{}) dto.DataResponse {
+	response := dto.NewDataResponse(map[string]interface{}{
+		"error":   err.Error(),
+		"details": status,
+	})
+	response.Success = false
+	return response
 }
 
-// SearchHealthChecker implements health checking for Elasticsearch service
-type SearchHealthChecker struct {
-	searchService services.SearchService
+// probeHealthChecker implements health checking for a dependency with a services.DependencyProbe
+type probeHealthChecker struct {
+	probe services.DependencyProbe
 }
 
-// NewSearchHealthChecker creates a new SearchHealthChecker
-func NewSearchHealthChecker(searchService services.SearchService) *SearchHealthChecker {
-	return &SearchHealthChecker{
-		searchService: searchService,
-	}
+// NewProbeHealthChecker creates a HealthChecker that pings a dependency and reports the latency of the ping
+func NewProbeHealthChecker(probe services.DependencyProbe) HealthChecker {
+	return &probeHealthChecker{probe: probe}
 }
 
-// Check performs a health check on the search service
-func (c *SearchHealthChecker) Check(ctx context.Context) (interface{}, error) {
-	// In a real implementation, this would check Elasticsearch cluster health
-	// and collect metrics about search service operations
-	
-	// Simulate search service check
-	// If Elasticsearch is unhealthy, return an error instead
+// Check pings the dependency
+func (c *probeHealthChecker) Check(ctx context.Context) (interface{}, error) {
+	start := time.Now()
+	if err := c.probe.Ping(ctx); err != nil {
+		return nil, err
+	}
 	return map[string]interface{}{
-		"status": "green",
-		"nodes": 3,
-		"indices": 5,
-		"search_performance": map[string]interface{}{
-			"queries_last_minute": 47,
-			"avg_query_time_ms": 75,
-		},
+		"status":     dependencyStatusUp,
+		"latency_ms": time.Since(start).Milliseconds(),
 	}, nil
-}
\ No newline at end of file
+}
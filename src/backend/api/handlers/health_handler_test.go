@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -409,6 +410,88 @@ func (s *HealthHandlerSuite) TestDeepHealthCheck_DependencyUnhealthy() {
 	assert.Equal(s.T(), "up", searchStatus["status"])
 }
 
+// TestReadyz_DependencyUnhealthy tests that /readyz returns 503 Service Unavailable with the status of each dependency
+func (s *HealthHandlerSuite) TestReadyz_DependencyUnhealthy() {
+	s.mockDBChecker.On("Check", mock.Anything).Return(map[string]string{"status": "up"}, nil)
+	s.mockStorageChecker.On("Check", mock.Anything).Return(nil, errors.NewDependencyError("bucket not found"))
+	s.mockSearchChecker.On("Check", mock.Anything).Return(map[string]string{"status": "up"}, nil)
+
+	req, _ := http.NewRequest(http.MethodGet, "/readyz", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	assert.Equal(s.T(), http.StatusServiceUnavailable, w.Code)
+
+	var response dto.DataResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(s.T(), err)
+	assert.False(s.T(), response.Success)
+
+	responseData, ok := response.Data.(map[string]interface{})
+	assert.True(s.T(), ok)
+	assert.Contains(s.T(), responseData["error"], "storage")
+	details, ok := responseData["details"].(map[string]interface{})
+	assert.True(s.T(), ok)
+	storageStatus, ok := details["storage"].(map[string]interface{})
+	assert.True(s.T(), ok)
+	assert.Equal(s.T(), "down", storageStatus["status"])
+	assert.Contains(s.T(), storageStatus["error"], "bucket not found")
+}
+
+// TestHealthz_DependencyUnhealthy tests that /healthz reports a degraded status but still returns 200 OK
+func (s *HealthHandlerSuite) TestHealthz_DependencyUnhealthy() {
+	s.mockDBChecker.On("Check", mock.Anything).Return(nil, errors.NewDependencyError("database connection error"))
+	s.mockStorageChecker.On("Check", mock.Anything).Return(map[string]string{"status": "up"}, nil)
+	s.mockSearchChecker.On("Check", mock.Anything).Return(map[string]string{"status": "up"}, nil)
+
+	req, _ := http.NewRequest(http.MethodGet, "/healthz", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+
+	var response dto.DataResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(s.T(), err)
+
+	responseData, ok := response.Data.(map[string]interface{})
+	assert.True(s.T(), ok)
+	assert.Equal(s.T(), "degraded", responseData["status"])
+	dependencies, ok := responseData["dependencies"].(map[string]interface{})
+	assert.True(s.T(), ok)
+	dbStatus, ok := dependencies["database"].(map[string]interface{})
+	assert.True(s.T(), ok)
+	assert.Equal(s.T(), "down", dbStatus["status"])
+}
+
+// TestReadyz_DependencyTimeout tests that a dependency that does not answer within the probe timeout is reported as down
+func (s *HealthHandlerSuite) TestReadyz_DependencyTimeout() {
+	s.healthHandler.probeTimeout = 20 * time.Millisecond
+	s.mockDBChecker.On("Check", mock.Anything).Return(map[string]string{"status": "up"}, nil).After(time.Second)
+	s.mockStorageChecker.On("Check", mock.Anything).Return(map[string]string{"status": "up"}, nil)
+	s.mockSearchChecker.On("Check", mock.Anything).Return(map[string]string{"status": "up"}, nil)
+
+	req, _ := http.NewRequest(http.MethodGet, "/readyz", nil)
+	w := httptest.NewRecorder()
+	start := time.Now()
+	s.router.ServeHTTP(w, req)
+
+	assert.Less(s.T(), time.Since(start), time.Second)
+	assert.Equal(s.T(), http.StatusServiceUnavailable, w.Code)
+
+	var response dto.DataResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(s.T(), err)
+
+	responseData, ok := response.Data.(map[string]interface{})
+	assert.True(s.T(), ok)
+	details, ok := responseData["details"].(map[string]interface{})
+	assert.True(s.T(), ok)
+	dbStatus, ok := details["database"].(map[string]interface{})
+	assert.True(s.T(), ok)
+	assert.Contains(s.T(), dbStatus["error"], "timed out")
+}
+
 // TestHealthHandlerSuite runs the health handler test suite
 func TestHealthHandlerSuite(t *testing.T) {
 	suite.Run(t, new(HealthHandlerSuite))
@@ -0,0 +1,253 @@
+// Package handlers implements HTTP handlers for metadata schema management in the Document Management Platform.
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin" // v1.9.0+
+
+	"../../application/usecases"
+	"../../pkg/errors"
+	"../../pkg/logger"
+	"../dto"
+	"../middleware"
+	"../validators"
+)
+
+// MetadataSchemaHandler handles HTTP requests for managing the metadata fields of a tenant's schema
+type MetadataSchemaHandler struct {
+	metadataSchemaUseCase usecases.MetadataSchemaUseCase
+}
+
+// NewMetadataSchemaHandler creates a new MetadataSchemaHandler instance
+func NewMetadataSchemaHandler(metadataSchemaUseCase usecases.MetadataSchemaUseCase) (*MetadataSchemaHandler, error) {
+	if metadataSchemaUseCase == nil {
+		return nil, errors.NewValidationError("metadata schema use case cannot be nil")
+	}
+
+	return &MetadataSchemaHandler{
+		metadataSchemaUseCase: metadataSchemaUseCase,
+	}, nil
+}
+
+// RegisterRoutes registers metadata schema routes with the provided router group
+func (h *MetadataSchemaHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.POST("/metadata-schema/fields", h.CreateField)
+	router.GET("/metadata-schema/fields", h.GetSchema)
+	router.GET("/metadata-schema/fields/:id", h.GetField)
+	router.PUT("/metadata-schema/fields/:id", h.UpdateField)
+	router.DELETE("/metadata-schema/fields/:id", h.DeleteField)
+}
+
+// CreateField handles metadata field creation requests
+func (h *MetadataSchemaHandler) CreateField(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Bind request body to DTO
+	var req dto.MetadataFieldRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.WithError(err).Error("failed to bind request body")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			validators.BindingError(err),
+			nil,
+		))
+		return
+	}
+
+	// Convert DTO to domain model
+	field := dto.ToMetadataFieldDomain(&req, tenantID, middleware.GetUserID(c))
+
+	// Call use case to create the field
+	fieldID, err := h.metadataSchemaUseCase.CreateField(c.Request.Context(), field)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	field.ID = fieldID
+	c.JSON(http.StatusCreated, dto.NewDataResponse(dto.ToMetadataFieldDTO(field)))
+}
+
+// GetSchema handles requests to list the metadata fields of the tenant's schema
+func (h *MetadataSchemaHandler) GetSchema(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Call use case to get the schema
+	schema, err := h.metadataSchemaUseCase.GetSchema(c.Request.Context(), tenantID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.ToMetadataSchemaDTO(schema)))
+}
+
+// GetField handles metadata field retrieval requests
+func (h *MetadataSchemaHandler) GetField(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Get field ID from URL
+	fieldID := c.Param("id")
+	if fieldID == "" {
+		log.Error("metadata field ID missing in request path")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			errors.NewValidationError("metadata field ID is required"),
+			map[string]string{"id": "required"},
+		))
+		return
+	}
+
+	// Call use case to get the field
+	field, err := h.metadataSchemaUseCase.GetField(c.Request.Context(), fieldID, tenantID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.ToMetadataFieldDTO(field)))
+}
+
+// UpdateField handles requests to replace a metadata field's definition
+func (h *MetadataSchemaHandler) UpdateField(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Get field ID from URL
+	fieldID := c.Param("id")
+	if fieldID == "" {
+		log.Error("metadata field ID missing in request path")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			errors.NewValidationError("metadata field ID is required"),
+			map[string]string{"id": "required"},
+		))
+		return
+	}
+
+	// Bind request body to DTO
+	var req dto.MetadataFieldRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.WithError(err).Error("failed to bind request body")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			validators.BindingError(err),
+			nil,
+		))
+		return
+	}
+
+	// Convert DTO to domain model
+	field := dto.ToMetadataFieldDomain(&req, tenantID, middleware.GetUserID(c))
+	field.ID = fieldID
+
+	// Call use case to update the field
+	if err := h.metadataSchemaUseCase.UpdateField(c.Request.Context(), field); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.ToMetadataFieldDTO(field)))
+}
+
+// DeleteField handles metadata field deletion requests
+func (h *MetadataSchemaHandler) DeleteField(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Get field ID from URL
+	fieldID := c.Param("id")
+	if fieldID == "" {
+		log.Error("metadata field ID missing in request path")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			errors.NewValidationError("metadata field ID is required"),
+			map[string]string{"id": "required"},
+		))
+		return
+	}
+
+	// Call use case to delete the field
+	if err := h.metadataSchemaUseCase.DeleteField(c.Request.Context(), fieldID, tenantID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	// Return success response
+	c.JSON(http.StatusOK, dto.NewMessageResponse("Metadata field deleted successfully"))
+}
+
+// handleError handles errors and returns appropriate HTTP responses
+func (h *MetadataSchemaHandler) handleError(c *gin.Context, err error) {
+	if errors.IsValidationError(err) {
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			err,
+			map[string]string{},
+		))
+		return
+	}
+
+	if errors.IsResourceNotFoundError(err) {
+		c.JSON(http.StatusNotFound, dto.NewResourceNotFoundErrorResponse(c.Request.Context(), err))
+		return
+	}
+
+	// Default to internal server error
+	logger.WithError(err).Error("internal server error")
+	c.JSON(http.StatusInternalServerError, dto.NewInternalErrorResponse(c.Request.Context(), err))
+}
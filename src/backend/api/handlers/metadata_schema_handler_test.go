@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"../../domain/models"
+	apperrors "../../pkg/errors"
+)
+
+// MockMetadataSchemaUseCase is a mock implementation of the MetadataSchemaUseCase interface
+type MockMetadataSchemaUseCase struct {
+	mock.Mock
+}
+
+func (m *MockMetadataSchemaUseCase) CreateField(ctx context.Context, field *models.MetadataField) (string, error) {
+	args := m.Called(ctx, field)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockMetadataSchemaUseCase) GetField(ctx context.Context, id string, tenantID string) (*models.MetadataField, error) {
+	args := m.Called(ctx, id, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.MetadataField), args.Error(1)
+}
+
+func (m *MockMetadataSchemaUseCase) GetSchema(ctx context.Context, tenantID string) (models.MetadataSchema, error) {
+	args := m.Called(ctx, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(models.MetadataSchema), args.Error(1)
+}
+
+func (m *MockMetadataSchemaUseCase) UpdateField(ctx context.Context, field *models.MetadataField) error {
+	args := m.Called(ctx, field)
+	return args.Error(0)
+}
+
+func (m *MockMetadataSchemaUseCase) DeleteField(ctx context.Context, id string, tenantID string) error {
+	args := m.Called(ctx, id, tenantID)
+	return args.Error(0)
+}
+
+// MetadataSchemaHandlerSuite defines the test suite
+type MetadataSchemaHandlerSuite struct {
+	suite.Suite
+	router                *gin.Engine
+	recorder              *httptest.ResponseRecorder
+	metadataSchemaUseCase *MockMetadataSchemaUseCase
+	metadataSchemaHandler *MetadataSchemaHandler
+}
+
+// SetupTest is called before each test
+func (s *MetadataSchemaHandlerSuite) SetupTest() {
+	// Create a gin router in test mode
+	gin.SetMode(gin.TestMode)
+	s.router = gin.New()
+	s.recorder = httptest.NewRecorder()
+
+	// Create the metadata schema handler with a mock use case
+	s.metadataSchemaUseCase = new(MockMetadataSchemaUseCase)
+	handler, err := NewMetadataSchemaHandler(s.metadataSchemaUseCase)
+	s.Require().NoError(err)
+	s.metadataSchemaHandler = handler
+
+	// Set up a router group with an authenticated tenant and the metadata schema handler routes
+	group := s.router.Group("/api/v1")
+	group.Use(func(c *gin.Context) {
+		c.Set("tenant_id", "tenant-123")
+		c.Set("user_id", "user-123")
+		c.Next()
+	})
+	s.metadataSchemaHandler.RegisterRoutes(group)
+}
+
+// TestCreateField_Success tests creating an enum metadata field
+func (s *MetadataSchemaHandlerSuite) TestCreateField_Success() {
+	s.metadataSchemaUseCase.On("CreateField", mock.Anything, mock.MatchedBy(func(f *models.MetadataField) bool {
+		return f.TenantID == "tenant-123" &&
+			f.CreatedBy == "user-123" &&
+			f.Type == models.MetadataFieldTypeEnum &&
+			f.Required &&
+			len(f.AllowedValues) == 2
+	})).Return("field-123", nil)
+
+	body := `{"name":"status","type":"enum","required":true,"allowed_values":["draft","final"]}`
+	req, _ := http.NewRequest("POST", "/api/v1/metadata-schema/fields", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusCreated, s.recorder.Code)
+	s.Contains(s.recorder.Body.String(), `"id":"field-123"`)
+	s.metadataSchemaUseCase.AssertExpectations(s.T())
+}
+
+// TestCreateField_InvalidType tests creating a field of an unsupported type
+func (s *MetadataSchemaHandlerSuite) TestCreateField_InvalidType() {
+	s.metadataSchemaUseCase.On("CreateField", mock.Anything, mock.Anything).
+		Return("", apperrors.NewValidationError(models.ErrMetadataFieldInvalidType.Error()))
+
+	body := `{"name":"amount","type":"currency"}`
+	req, _ := http.NewRequest("POST", "/api/v1/metadata-schema/fields", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusBadRequest, s.recorder.Code)
+	s.metadataSchemaUseCase.AssertExpectations(s.T())
+}
+
+// TestGetSchema_Success tests listing the tenant's metadata fields
+func (s *MetadataSchemaHandlerSuite) TestGetSchema_Success() {
+	schema := models.MetadataSchema{
+		{ID: "field-123", TenantID: "tenant-123", Name: "contract-value", Type: models.MetadataFieldTypeNumber},
+	}
+	s.metadataSchemaUseCase.On("GetSchema", mock.Anything, "tenant-123").Return(schema, nil)
+
+	req, _ := http.NewRequest("GET", "/api/v1/metadata-schema/fields", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusOK, s.recorder.Code)
+	s.Contains(s.recorder.Body.String(), `"type":"number"`)
+	s.metadataSchemaUseCase.AssertExpectations(s.T())
+}
+
+// TestDeleteField_NotFound tests deleting a field that does not exist
+func (s *MetadataSchemaHandlerSuite) TestDeleteField_NotFound() {
+	s.metadataSchemaUseCase.On("DeleteField", mock.Anything, "missing", "tenant-123").Return(apperrors.NewResourceNotFoundError("Metadata field not found"))
+
+	req, _ := http.NewRequest("DELETE", "/api/v1/metadata-schema/fields/missing", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusNotFound, s.recorder.Code)
+	s.metadataSchemaUseCase.AssertExpectations(s.T())
+}
+
+// TestMetadataSchemaHandlerSuite runs the test suite
+func TestMetadataSchemaHandlerSuite(t *testing.T) {
+	suite.Run(t, new(MetadataSchemaHandlerSuite))
+}
@@ -0,0 +1,292 @@
+// Package handlers implements HTTP handlers for folder metadata template management in the Document Management Platform.
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin" // v1.9.0+
+
+	"../../application/usecases"
+	"../../pkg/errors"
+	"../../pkg/logger"
+	"../dto"
+	"../middleware"
+	"../validators"
+)
+
+// MetadataTemplateHandler handles HTTP requests for managing the metadata templates of folders
+type MetadataTemplateHandler struct {
+	metadataTemplateUseCase usecases.MetadataTemplateUseCase
+}
+
+// NewMetadataTemplateHandler creates a new MetadataTemplateHandler instance
+func NewMetadataTemplateHandler(metadataTemplateUseCase usecases.MetadataTemplateUseCase) (*MetadataTemplateHandler, error) {
+	if metadataTemplateUseCase == nil {
+		return nil, errors.NewValidationError("metadata template use case cannot be nil")
+	}
+
+	return &MetadataTemplateHandler{
+		metadataTemplateUseCase: metadataTemplateUseCase,
+	}, nil
+}
+
+// RegisterRoutes registers metadata template routes with the provided router group
+func (h *MetadataTemplateHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.POST("/metadata-templates", h.CreateTemplate)
+	router.GET("/metadata-templates", h.ListTemplates)
+	router.GET("/metadata-templates/:id", h.GetTemplate)
+	router.PUT("/metadata-templates/:id", h.UpdateTemplate)
+	router.DELETE("/metadata-templates/:id", h.DeleteTemplate)
+	router.GET("/folders/:id/metadata-template", h.GetFolderTemplate)
+}
+
+// CreateTemplate handles metadata template creation requests
+func (h *MetadataTemplateHandler) CreateTemplate(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Bind request body to DTO
+	var req dto.MetadataTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.WithError(err).Error("failed to bind request body")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			validators.BindingError(err),
+			nil,
+		))
+		return
+	}
+
+	// Convert DTO to domain model
+	template := dto.ToMetadataTemplateDomain(&req, tenantID, middleware.GetUserID(c))
+
+	// Call use case to create the template
+	templateID, err := h.metadataTemplateUseCase.CreateTemplate(c.Request.Context(), template)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	template.ID = templateID
+	c.JSON(http.StatusCreated, dto.NewDataResponse(dto.ToMetadataTemplateDTO(template)))
+}
+
+// ListTemplates handles requests to list the tenant's metadata templates
+func (h *MetadataTemplateHandler) ListTemplates(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Call use case to list the templates
+	templates, err := h.metadataTemplateUseCase.ListTemplates(c.Request.Context(), tenantID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.ToMetadataTemplateListDTO(templates)))
+}
+
+// GetTemplate handles metadata template retrieval requests
+func (h *MetadataTemplateHandler) GetTemplate(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Get template ID from URL
+	templateID := c.Param("id")
+	if templateID == "" {
+		log.Error("metadata template ID missing in request path")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			errors.NewValidationError("metadata template ID is required"),
+			map[string]string{"id": "required"},
+		))
+		return
+	}
+
+	// Call use case to get the template
+	template, err := h.metadataTemplateUseCase.GetTemplate(c.Request.Context(), templateID, tenantID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.ToMetadataTemplateDTO(template)))
+}
+
+// GetFolderTemplate handles requests for the metadata template of a folder, which clients use to
+// prompt for the template's fields before uploading to the folder
+func (h *MetadataTemplateHandler) GetFolderTemplate(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Get folder ID from URL
+	folderID := c.Param("id")
+	if folderID == "" {
+		log.Error("folder ID missing in request path")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			errors.NewValidationError("folder ID is required"),
+			map[string]string{"id": "required"},
+		))
+		return
+	}
+
+	// Call use case to get the folder's template
+	template, err := h.metadataTemplateUseCase.GetFolderTemplate(c.Request.Context(), folderID, tenantID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.ToMetadataTemplateDTO(template)))
+}
+
+// UpdateTemplate handles requests to replace a metadata template's fields and mode
+func (h *MetadataTemplateHandler) UpdateTemplate(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Get template ID from URL
+	templateID := c.Param("id")
+	if templateID == "" {
+		log.Error("metadata template ID missing in request path")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			errors.NewValidationError("metadata template ID is required"),
+			map[string]string{"id": "required"},
+		))
+		return
+	}
+
+	// Bind request body to DTO
+	var req dto.MetadataTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.WithError(err).Error("failed to bind request body")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			validators.BindingError(err),
+			nil,
+		))
+		return
+	}
+
+	// Convert DTO to domain model
+	template := dto.ToMetadataTemplateDomain(&req, tenantID, middleware.GetUserID(c))
+	template.ID = templateID
+
+	// Call use case to update the template
+	if err := h.metadataTemplateUseCase.UpdateTemplate(c.Request.Context(), template); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.ToMetadataTemplateDTO(template)))
+}
+
+// DeleteTemplate handles metadata template deletion requests
+func (h *MetadataTemplateHandler) DeleteTemplate(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Get template ID from URL
+	templateID := c.Param("id")
+	if templateID == "" {
+		log.Error("metadata template ID missing in request path")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			errors.NewValidationError("metadata template ID is required"),
+			map[string]string{"id": "required"},
+		))
+		return
+	}
+
+	// Call use case to delete the template
+	if err := h.metadataTemplateUseCase.DeleteTemplate(c.Request.Context(), templateID, tenantID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	// Return success response
+	c.JSON(http.StatusOK, dto.NewMessageResponse("Metadata template deleted successfully"))
+}
+
+// handleError handles errors and returns appropriate HTTP responses
+func (h *MetadataTemplateHandler) handleError(c *gin.Context, err error) {
+	if errors.IsValidationError(err) {
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			err,
+			map[string]string{},
+		))
+		return
+	}
+
+	if errors.IsResourceNotFoundError(err) {
+		c.JSON(http.StatusNotFound, dto.NewResourceNotFoundErrorResponse(c.Request.Context(), err))
+		return
+	}
+
+	// Default to internal server error
+	logger.WithError(err).Error("internal server error")
+	c.JSON(http.StatusInternalServerError, dto.NewInternalErrorResponse(c.Request.Context(), err))
+}
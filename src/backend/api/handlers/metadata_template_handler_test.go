@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"../../domain/models"
+	apperrors "../../pkg/errors"
+)
+
+// MockMetadataTemplateUseCase is a mock implementation of the MetadataTemplateUseCase interface
+type MockMetadataTemplateUseCase struct {
+	mock.Mock
+}
+
+func (m *MockMetadataTemplateUseCase) CreateTemplate(ctx context.Context, template *models.MetadataTemplate) (string, error) {
+	args := m.Called(ctx, template)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockMetadataTemplateUseCase) GetTemplate(ctx context.Context, id string, tenantID string) (*models.MetadataTemplate, error) {
+	args := m.Called(ctx, id, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.MetadataTemplate), args.Error(1)
+}
+
+func (m *MockMetadataTemplateUseCase) GetFolderTemplate(ctx context.Context, folderID string, tenantID string) (*models.MetadataTemplate, error) {
+	args := m.Called(ctx, folderID, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.MetadataTemplate), args.Error(1)
+}
+
+func (m *MockMetadataTemplateUseCase) ListTemplates(ctx context.Context, tenantID string) ([]*models.MetadataTemplate, error) {
+	args := m.Called(ctx, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.MetadataTemplate), args.Error(1)
+}
+
+func (m *MockMetadataTemplateUseCase) UpdateTemplate(ctx context.Context, template *models.MetadataTemplate) error {
+	args := m.Called(ctx, template)
+	return args.Error(0)
+}
+
+func (m *MockMetadataTemplateUseCase) DeleteTemplate(ctx context.Context, id string, tenantID string) error {
+	args := m.Called(ctx, id, tenantID)
+	return args.Error(0)
+}
+
+// MetadataTemplateHandlerSuite defines the test suite
+type MetadataTemplateHandlerSuite struct {
+	suite.Suite
+	router                  *gin.Engine
+	recorder                *httptest.ResponseRecorder
+	metadataTemplateUseCase *MockMetadataTemplateUseCase
+	metadataTemplateHandler *MetadataTemplateHandler
+}
+
+// SetupTest is called before each test
+func (s *MetadataTemplateHandlerSuite) SetupTest() {
+	// Create a gin router in test mode
+	gin.SetMode(gin.TestMode)
+	s.router = gin.New()
+	s.recorder = httptest.NewRecorder()
+
+	// Create the metadata template handler with a mock use case
+	s.metadataTemplateUseCase = new(MockMetadataTemplateUseCase)
+	handler, err := NewMetadataTemplateHandler(s.metadataTemplateUseCase)
+	s.Require().NoError(err)
+	s.metadataTemplateHandler = handler
+
+	// Set up a router group with an authenticated tenant and the metadata template handler routes
+	group := s.router.Group("/api/v1")
+	group.Use(func(c *gin.Context) {
+		c.Set("tenant_id", "tenant-123")
+		c.Set("user_id", "user-123")
+		c.Next()
+	})
+	s.metadataTemplateHandler.RegisterRoutes(group)
+}
+
+// TestCreateTemplate_Success tests creating a template enforced by default
+func (s *MetadataTemplateHandlerSuite) TestCreateTemplate_Success() {
+	s.metadataTemplateUseCase.On("CreateTemplate", mock.Anything, mock.MatchedBy(func(t *models.MetadataTemplate) bool {
+		return t.TenantID == "tenant-123" &&
+			t.FolderID == "folder-123" &&
+			t.CreatedBy == "user-123" &&
+			t.Mode == models.MetadataTemplateModeEnforce &&
+			len(t.Fields) == 2
+	})).Return("template-123", nil)
+
+	body := `{"folder_id":"folder-123","name":"Invoices","fields":["invoice_number","vendor"]}`
+	req, _ := http.NewRequest("POST", "/api/v1/metadata-templates", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusCreated, s.recorder.Code)
+	s.Contains(s.recorder.Body.String(), `"id":"template-123"`)
+	s.metadataTemplateUseCase.AssertExpectations(s.T())
+}
+
+// TestCreateTemplate_InvalidMode tests creating a template with an unsupported mode
+func (s *MetadataTemplateHandlerSuite) TestCreateTemplate_InvalidMode() {
+	s.metadataTemplateUseCase.On("CreateTemplate", mock.Anything, mock.Anything).
+		Return("", apperrors.NewValidationError(models.ErrMetadataTemplateInvalidMode.Error()))
+
+	body := `{"folder_id":"folder-123","name":"Invoices","fields":["vendor"],"mode":"suggest"}`
+	req, _ := http.NewRequest("POST", "/api/v1/metadata-templates", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusBadRequest, s.recorder.Code)
+	s.metadataTemplateUseCase.AssertExpectations(s.T())
+}
+
+// TestGetFolderTemplate_Success tests retrieving the template clients prompt for before uploading
+func (s *MetadataTemplateHandlerSuite) TestGetFolderTemplate_Success() {
+	template := &models.MetadataTemplate{ID: "template-123", TenantID: "tenant-123", FolderID: "folder-123",
+		Name: "Invoices", Fields: []string{"invoice_number"}, Mode: models.MetadataTemplateModePrompt}
+	s.metadataTemplateUseCase.On("GetFolderTemplate", mock.Anything, "folder-123", "tenant-123").Return(template, nil)
+
+	req, _ := http.NewRequest("GET", "/api/v1/folders/folder-123/metadata-template", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusOK, s.recorder.Code)
+	s.Contains(s.recorder.Body.String(), `"mode":"prompt"`)
+	s.metadataTemplateUseCase.AssertExpectations(s.T())
+}
+
+// TestGetFolderTemplate_NotFound tests retrieving the template of a folder without one
+func (s *MetadataTemplateHandlerSuite) TestGetFolderTemplate_NotFound() {
+	s.metadataTemplateUseCase.On("GetFolderTemplate", mock.Anything, "folder-456", "tenant-123").
+		Return(nil, apperrors.NewResourceNotFoundError("Metadata template not found"))
+
+	req, _ := http.NewRequest("GET", "/api/v1/folders/folder-456/metadata-template", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusNotFound, s.recorder.Code)
+	s.metadataTemplateUseCase.AssertExpectations(s.T())
+}
+
+// TestMetadataTemplateHandlerSuite runs the test suite
+func TestMetadataTemplateHandlerSuite(t *testing.T) {
+	suite.Run(t, new(MetadataTemplateHandlerSuite))
+}
@@ -0,0 +1,64 @@
+// Package handlers implements HTTP handlers serving the OpenAPI document of the Document Management Platform.
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin" // v1.9.0+
+
+	"../openapi"
+)
+
+// Paths of the OpenAPI document and of the Swagger UI page browsing it
+const (
+	OpenAPIPath   = "/openapi.json"
+	SwaggerUIPath = "/docs"
+)
+
+// swaggerUIPage loads Swagger UI from its CDN and points it to the OpenAPI document
+const swaggerUIPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <meta name="viewport" content="width=device-width, initial-scale=1">
+  <title>Document Management Platform API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js" crossorigin></script>
+  <script>
+    window.onload = function () {
+      window.ui = SwaggerUIBundle({ url: "` + OpenAPIPath + `", dom_id: "#swagger-ui", deepLinking: true });
+    };
+  </script>
+</body>
+</html>
+`
+
+// OpenAPIHandler handles HTTP requests for the OpenAPI document generated from the routes of the
+// API, so that integrators do not have to read the handlers to learn the API
+type OpenAPIHandler struct {
+	document *openapi.Document
+}
+
+// NewOpenAPIHandler creates a new OpenAPIHandler instance serving the given document
+func NewOpenAPIHandler(document *openapi.Document) *OpenAPIHandler {
+	return &OpenAPIHandler{document: document}
+}
+
+// RegisterRoutes registers the unauthenticated OpenAPI document and Swagger UI routes with the router
+func (h *OpenAPIHandler) RegisterRoutes(router *gin.Engine) {
+	router.GET(OpenAPIPath, h.GetOpenAPIDocument)
+	router.GET(SwaggerUIPath, h.ServeSwaggerUI)
+}
+
+// GetOpenAPIDocument handles requests for the OpenAPI document of the API
+func (h *OpenAPIHandler) GetOpenAPIDocument(c *gin.Context) {
+	c.JSON(http.StatusOK, h.document)
+}
+
+// ServeSwaggerUI handles requests for the Swagger UI page browsing the OpenAPI document
+func (h *OpenAPIHandler) ServeSwaggerUI(c *gin.Context) {
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(swaggerUIPage))
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+
+	"../openapi"
+)
+
+// OpenAPIHandlerSuite defines the test suite
+type OpenAPIHandlerSuite struct {
+	suite.Suite
+	router   *gin.Engine
+	recorder *httptest.ResponseRecorder
+}
+
+// SetupTest is called before each test
+func (s *OpenAPIHandlerSuite) SetupTest() {
+	// Create a gin router in test mode serving the document of its own routes
+	gin.SetMode(gin.TestMode)
+	s.router = gin.New()
+	s.recorder = httptest.NewRecorder()
+
+	NewErrorCatalogHandler().RegisterRoutes(s.router.Group("/api/v1"))
+	spec := &openapi.Spec{
+		Info:    openapi.Info{Title: "Document Management Platform API", Version: "1.0.0"},
+		Exclude: []string{OpenAPIPath, SwaggerUIPath},
+	}
+	NewOpenAPIHandler(spec.Generate(s.router.Routes())).RegisterRoutes(s.router)
+}
+
+// TestGetOpenAPIDocument tests that the document describes the routes of the router
+func (s *OpenAPIHandlerSuite) TestGetOpenAPIDocument() {
+	req, _ := http.NewRequest("GET", "/openapi.json", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusOK, s.recorder.Code)
+
+	var document openapi.Document
+	s.Require().NoError(json.Unmarshal(s.recorder.Body.Bytes(), &document))
+	s.Equal(openapi.Version, document.OpenAPI)
+	s.Equal("Document Management Platform API", document.Info.Title)
+	s.Require().Contains(document.Paths, "/api/v1/errors/{code}")
+	s.Equal("getErrorCode", document.Paths["/api/v1/errors/{code}"].Get.OperationID)
+	s.NotContains(document.Paths, "/openapi.json")
+}
+
+// TestServeSwaggerUI tests that Swagger UI is pointed to the document
+func (s *OpenAPIHandlerSuite) TestServeSwaggerUI() {
+	req, _ := http.NewRequest("GET", "/docs", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusOK, s.recorder.Code)
+	s.Equal("text/html; charset=utf-8", s.recorder.Header().Get("Content-Type"))
+	s.Contains(s.recorder.Body.String(), `url: "/openapi.json"`)
+}
+
+// TestOpenAPIHandlerSuite runs the test suite
+func TestOpenAPIHandlerSuite(t *testing.T) {
+	suite.Run(t, new(OpenAPIHandlerSuite))
+}
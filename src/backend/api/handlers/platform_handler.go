@@ -0,0 +1,183 @@
+// Package handlers implements HTTP handlers for the platform operator API of the Document Management Platform.
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin" // v1.9.0+
+
+	"../../application/usecases"
+	"../../domain/services"
+	"../../pkg/errors"
+	"../../pkg/logger"
+	"../dto"
+	"../validators"
+)
+
+// defaultScanDeadLetterLimit is the number of dead-lettered scan tasks listed unless the request sets
+// a limit, and maxScanDeadLetterLimit the most it can set
+const (
+	defaultScanDeadLetterLimit = 100
+	maxScanDeadLetterLimit     = 1000
+)
+
+// PlatformHandler handles HTTP requests from platform operators managing tenants
+type PlatformHandler struct {
+	provisioningUseCase usecases.TenantProvisioningUseCase
+	deadLetters         services.ScanDeadLetterQueue
+}
+
+// NewPlatformHandler creates a new PlatformHandler instance. deadLetters is nil when the scan queue
+// keeps no dead letters, in which case the dead letter routes are not registered.
+func NewPlatformHandler(provisioningUseCase usecases.TenantProvisioningUseCase, deadLetters services.ScanDeadLetterQueue) (*PlatformHandler, error) {
+	if provisioningUseCase == nil {
+		return nil, errors.NewValidationError("tenant provisioning use case cannot be nil")
+	}
+
+	return &PlatformHandler{
+		provisioningUseCase: provisioningUseCase,
+		deadLetters:         deadLetters,
+	}, nil
+}
+
+// RegisterRoutes registers platform operator routes with the provided router group
+func (h *PlatformHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.POST("/tenants", h.ProvisionTenant)
+	if h.deadLetters != nil {
+		router.GET("/scan-dead-letters", h.ListScanDeadLetters)
+		router.POST("/scan-dead-letters/replay", h.ReplayScanDeadLetters)
+	}
+}
+
+// ProvisionTenant handles requests to provision a tenant with its initial administrator
+func (h *PlatformHandler) ProvisionTenant(c *gin.Context) {
+	var req dto.ProvisionTenantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.WithContext(c.Request.Context()).WithError(err).Error("failed to bind request body")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			validators.BindingError(err),
+			nil,
+		))
+		return
+	}
+
+	// Call use case to provision the tenant
+	provisioning, err := h.provisioningUseCase.ProvisionTenant(c.Request.Context(), usecases.TenantProvisioningRequest{
+		Name:             req.Name,
+		AdminUsername:    req.AdminUsername,
+		AdminEmail:       req.AdminEmail,
+		FolderTemplateID: req.FolderTemplateID,
+		RequestedBy:      req.RequestedBy,
+	})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	tenant := dto.ToProvisionedTenantDTO(provisioning.Tenant, provisioning.Roles, provisioning.Admin, provisioning.AdminPassword, provisioning.Folders)
+	if provisioning.Search != nil {
+		tenant.SearchIndex = provisioning.Search.Index
+		tenant.SearchRouting = provisioning.Search.Routing
+	}
+	c.JSON(http.StatusCreated, dto.NewDataResponse(tenant))
+}
+
+// ListScanDeadLetters handles requests to list the scan tasks that exhausted their retries, without
+// removing them from the dead letter queue
+func (h *PlatformHandler) ListScanDeadLetters(c *gin.Context) {
+	limit := defaultScanDeadLetterLimit
+	if value := c.Query("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > maxScanDeadLetterLimit {
+			h.handleError(c, errors.NewFieldValidationError([]errors.FieldViolation{{
+				Field:   "limit",
+				Rule:    "range",
+				Message: "limit must be between 1 and " + strconv.Itoa(maxScanDeadLetterLimit),
+			}}))
+			return
+		}
+		limit = parsed
+	}
+
+	deadLetters, err := h.deadLetters.ListDeadLetters(c.Request.Context(), limit)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	result := make([]dto.ScanDeadLetterDTO, len(deadLetters))
+	for i, deadLetter := range deadLetters {
+		result[i] = dto.ToScanDeadLetterDTO(deadLetter)
+	}
+	c.JSON(http.StatusOK, dto.NewDataResponse(result))
+}
+
+// ReplayScanDeadLetters handles requests to move dead-lettered scan tasks back to the queue of their
+// priority, once the cause of their failure is fixed. Tasks are selected either by message ID, by
+// tenant or all of them, so that a request cannot replay more than the operator meant to.
+func (h *PlatformHandler) ReplayScanDeadLetters(c *gin.Context) {
+	var req dto.ReplayScanDeadLettersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.WithContext(c.Request.Context()).WithError(err).Error("failed to bind request body")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			validators.BindingError(err),
+			nil,
+		))
+		return
+	}
+
+	var selected func(services.DeadLetteredScanTask) bool
+	switch {
+	case len(req.MessageIDs) > 0 && req.TenantID == "" && !req.All:
+		messageIDs := make(map[string]bool, len(req.MessageIDs))
+		for _, id := range req.MessageIDs {
+			messageIDs[id] = true
+		}
+		selected = func(deadLetter services.DeadLetteredScanTask) bool { return messageIDs[deadLetter.MessageID] }
+	case req.TenantID != "" && len(req.MessageIDs) == 0 && !req.All:
+		selected = func(deadLetter services.DeadLetteredScanTask) bool { return deadLetter.Task.TenantID == req.TenantID }
+	case req.All && len(req.MessageIDs) == 0 && req.TenantID == "":
+		selected = func(deadLetter services.DeadLetteredScanTask) bool { return true }
+	default:
+		h.handleError(c, errors.NewFieldValidationError([]errors.FieldViolation{{
+			Field:   "message_ids",
+			Rule:    "selector",
+			Message: "select the tasks to replay with either message_ids, tenant_id or all",
+		}}))
+		return
+	}
+
+	// Tasks replayed before an error are already back in their queue, so the count is logged
+	replayed, err := h.deadLetters.ReplayDeadLetters(c.Request.Context(), selected)
+	if err != nil {
+		logger.WithContext(c.Request.Context()).WithError(err).Error("failed to replay dead-lettered scan tasks, " + strconv.Itoa(replayed) + " replayed")
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.ReplayedScanDeadLettersDTO{Replayed: replayed}))
+}
+
+// handleError handles errors and returns appropriate HTTP responses
+func (h *PlatformHandler) handleError(c *gin.Context, err error) {
+	if errors.IsValidationError(err) {
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			err,
+			map[string]string{},
+		))
+		return
+	}
+
+	if errors.IsResourceNotFoundError(err) {
+		c.JSON(http.StatusNotFound, dto.NewResourceNotFoundErrorResponse(c.Request.Context(), err))
+		return
+	}
+
+	// Default to internal server error
+	logger.WithError(err).Error("internal server error")
+	c.JSON(http.StatusInternalServerError, dto.NewInternalErrorResponse(c.Request.Context(), err))
+}
@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"../../application/usecases"
+	"../../domain/services"
+)
+
+// MockTenantProvisioningUseCase is a mock implementation of the TenantProvisioningUseCase interface
+type MockTenantProvisioningUseCase struct {
+	mock.Mock
+}
+
+func (m *MockTenantProvisioningUseCase) ProvisionTenant(ctx context.Context, request usecases.TenantProvisioningRequest) (*usecases.TenantProvisioning, error) {
+	args := m.Called(ctx, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecases.TenantProvisioning), args.Error(1)
+}
+
+// fakeScanDeadLetterQueue is an in-memory ScanDeadLetterQueue, which applies the selection of
+// replays so that tests can check what a request selects
+type fakeScanDeadLetterQueue struct {
+	deadLetters []services.DeadLetteredScanTask
+	replayed    []string
+	listLimit   int
+}
+
+func (q *fakeScanDeadLetterQueue) ListDeadLetters(ctx context.Context, limit int) ([]services.DeadLetteredScanTask, error) {
+	q.listLimit = limit
+	return q.deadLetters, nil
+}
+
+func (q *fakeScanDeadLetterQueue) ReplayDeadLetters(ctx context.Context, selected func(services.DeadLetteredScanTask) bool) (int, error) {
+	for _, deadLetter := range q.deadLetters {
+		if selected(deadLetter) {
+			q.replayed = append(q.replayed, deadLetter.MessageID)
+		}
+	}
+	return len(q.replayed), nil
+}
+
+func (q *fakeScanDeadLetterQueue) DiscardDeadLetters(ctx context.Context, selected func(services.DeadLetteredScanTask) bool) (int, error) {
+	return 0, nil
+}
+
+// PlatformHandlerSuite defines the test suite
+type PlatformHandlerSuite struct {
+	suite.Suite
+	router      *gin.Engine
+	recorder    *httptest.ResponseRecorder
+	deadLetters *fakeScanDeadLetterQueue
+}
+
+// SetupTest is called before each test
+func (s *PlatformHandlerSuite) SetupTest() {
+	// Create a gin router in test mode
+	gin.SetMode(gin.TestMode)
+	s.router = gin.New()
+	s.recorder = httptest.NewRecorder()
+
+	// Create the platform handler with dead-lettered tasks of two tenants
+	s.deadLetters = &fakeScanDeadLetterQueue{deadLetters: []services.DeadLetteredScanTask{
+		{MessageID: "msg-1", Task: services.ScanTask{DocumentID: "doc-1", VersionID: "ver-1", TenantID: "tenant-1", Priority: "high"},
+			Reason: "scanning engine unavailable", DeadLetteredAt: time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC), Readable: true},
+		{MessageID: "msg-2", Task: services.ScanTask{DocumentID: "doc-2", VersionID: "ver-2", TenantID: "tenant-2"},
+			Reason: "scanning engine unavailable", Readable: true},
+	}}
+	handler, err := NewPlatformHandler(new(MockTenantProvisioningUseCase), s.deadLetters)
+	s.Require().NoError(err)
+	handler.RegisterRoutes(s.router.Group("/platform/v1"))
+}
+
+// TestListScanDeadLetters_Success tests listing dead-lettered scan tasks
+func (s *PlatformHandlerSuite) TestListScanDeadLetters_Success() {
+	req, _ := http.NewRequest("GET", "/platform/v1/scan-dead-letters?limit=10", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusOK, s.recorder.Code)
+	s.Equal(10, s.deadLetters.listLimit)
+	s.Contains(s.recorder.Body.String(), `"message_id":"msg-1"`)
+	s.Contains(s.recorder.Body.String(), `"tenant_id":"tenant-2"`)
+}
+
+// TestListScanDeadLetters_InvalidLimit tests that limits out of range are rejected
+func (s *PlatformHandlerSuite) TestListScanDeadLetters_InvalidLimit() {
+	req, _ := http.NewRequest("GET", "/platform/v1/scan-dead-letters?limit=0", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusBadRequest, s.recorder.Code)
+	s.Contains(s.recorder.Body.String(), `"field":"limit"`)
+}
+
+// TestReplayScanDeadLetters_ByTenant tests that only the tasks of the tenant are replayed
+func (s *PlatformHandlerSuite) TestReplayScanDeadLetters_ByTenant() {
+	req, _ := http.NewRequest("POST", "/platform/v1/scan-dead-letters/replay", strings.NewReader(`{"tenant_id":"tenant-2"}`))
+	req.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusOK, s.recorder.Code)
+	s.Equal([]string{"msg-2"}, s.deadLetters.replayed)
+	s.Contains(s.recorder.Body.String(), `"replayed":1`)
+}
+
+// TestReplayScanDeadLetters_AmbiguousSelection tests that a request must select tasks in exactly
+// one way, so that it cannot replay more than meant to
+func (s *PlatformHandlerSuite) TestReplayScanDeadLetters_AmbiguousSelection() {
+	for _, body := range []string{`{}`, `{"message_ids":["msg-1"],"all":true}`} {
+		s.recorder = httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/platform/v1/scan-dead-letters/replay", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		s.router.ServeHTTP(s.recorder, req)
+
+		s.Equal(http.StatusBadRequest, s.recorder.Code, body)
+	}
+	s.Empty(s.deadLetters.replayed)
+}
+
+// TestRegisterRoutes_WithoutDeadLetterQueue tests that the dead letter routes are only served when
+// the scan queue keeps dead letters
+func (s *PlatformHandlerSuite) TestRegisterRoutes_WithoutDeadLetterQueue() {
+	router := gin.New()
+	handler, err := NewPlatformHandler(new(MockTenantProvisioningUseCase), nil)
+	s.Require().NoError(err)
+	handler.RegisterRoutes(router.Group("/platform/v1"))
+
+	req, _ := http.NewRequest("GET", "/platform/v1/scan-dead-letters", nil)
+	router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusNotFound, s.recorder.Code)
+}
+
+// TestPlatformHandlerSuite runs the test suite
+func TestPlatformHandlerSuite(t *testing.T) {
+	suite.Run(t, new(PlatformHandlerSuite))
+}
@@ -0,0 +1,278 @@
+// Package handlers implements HTTP handlers for attribute-based access policy management in the Document Management Platform.
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin" // v1.9.0+
+
+	"../dto"
+	"../middleware"
+	"../validators"
+	"../../application/usecases"
+	"../../pkg/errors"
+	"../../pkg/logger"
+)
+
+// PolicyHandler handles HTTP requests for managing a tenant's access policies
+type PolicyHandler struct {
+	policyUseCase usecases.PolicyUseCase
+}
+
+// NewPolicyHandler creates a new PolicyHandler instance
+func NewPolicyHandler(policyUseCase usecases.PolicyUseCase) (*PolicyHandler, error) {
+	if policyUseCase == nil {
+		return nil, errors.NewValidationError("policy use case cannot be nil")
+	}
+
+	return &PolicyHandler{
+		policyUseCase: policyUseCase,
+	}, nil
+}
+
+// RegisterRoutes registers access policy routes with the provided router group
+func (h *PolicyHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.POST("/policies", h.CreatePolicy)
+	router.GET("/policies", h.ListPolicies)
+	router.GET("/policies/:id", h.GetPolicy)
+	router.PUT("/policies/:id", h.UpdatePolicy)
+	router.DELETE("/policies/:id", h.DeletePolicy)
+}
+
+// CreatePolicy handles access policy creation requests
+func (h *PolicyHandler) CreatePolicy(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Bind request body to DTO
+	var req dto.AccessPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.WithError(err).Error("failed to bind request body")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			validators.BindingError(err),
+			nil,
+		))
+		return
+	}
+
+	// Convert DTO to domain model
+	policy := dto.ToAccessPolicyDomain(&req, tenantID, middleware.GetUserID(c))
+
+	// Call use case to create the policy
+	policyID, err := h.policyUseCase.CreatePolicy(c.Request.Context(), policy)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	policy.ID = policyID
+	c.JSON(http.StatusCreated, dto.NewDataResponse(dto.ToAccessPolicyDTO(policy)))
+}
+
+// ListPolicies handles requests to list the tenant's access policies
+func (h *PolicyHandler) ListPolicies(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	page, pageSize := h.getPaginationParams(c)
+
+	// Call use case to list policies
+	result, err := h.policyUseCase.ListPolicies(c.Request.Context(), tenantID, page, pageSize)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	// Convert domain models to DTOs and return
+	policies := dto.ToAccessPolicyListDTO(result)
+	c.JSON(http.StatusOK, dto.NewPaginatedResponse(policies, result.Pagination))
+}
+
+// GetPolicy handles access policy retrieval requests
+func (h *PolicyHandler) GetPolicy(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Get policy ID from URL
+	policyID := c.Param("id")
+	if policyID == "" {
+		log.Error("policy ID missing in request path")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			errors.NewValidationError("policy ID is required"),
+			map[string]string{"id": "required"},
+		))
+		return
+	}
+
+	// Call use case to get the policy
+	policy, err := h.policyUseCase.GetPolicy(c.Request.Context(), policyID, tenantID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.ToAccessPolicyDTO(policy)))
+}
+
+// UpdatePolicy handles requests to replace an access policy's rule
+func (h *PolicyHandler) UpdatePolicy(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Get policy ID from URL
+	policyID := c.Param("id")
+	if policyID == "" {
+		log.Error("policy ID missing in request path")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			errors.NewValidationError("policy ID is required"),
+			map[string]string{"id": "required"},
+		))
+		return
+	}
+
+	// Bind request body to DTO
+	var req dto.AccessPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.WithError(err).Error("failed to bind request body")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			validators.BindingError(err),
+			nil,
+		))
+		return
+	}
+
+	// Convert DTO to domain model
+	policy := dto.ToAccessPolicyDomain(&req, tenantID, middleware.GetUserID(c))
+	policy.ID = policyID
+
+	// Call use case to update the policy
+	if err := h.policyUseCase.UpdatePolicy(c.Request.Context(), policy); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.ToAccessPolicyDTO(policy)))
+}
+
+// DeletePolicy handles access policy deletion requests
+func (h *PolicyHandler) DeletePolicy(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Get policy ID from URL
+	policyID := c.Param("id")
+	if policyID == "" {
+		log.Error("policy ID missing in request path")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			errors.NewValidationError("policy ID is required"),
+			map[string]string{"id": "required"},
+		))
+		return
+	}
+
+	// Call use case to delete the policy
+	if err := h.policyUseCase.DeletePolicy(c.Request.Context(), policyID, tenantID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	// Return success response
+	c.JSON(http.StatusOK, dto.NewMessageResponse("Access policy deleted successfully"))
+}
+
+// getPaginationParams extracts and validates pagination parameters from the request
+func (h *PolicyHandler) getPaginationParams(c *gin.Context) (int, int) {
+	page := 1
+	if p, err := strconv.Atoi(c.Query("page")); err == nil && p > 0 {
+		page = p
+	}
+
+	pageSize := defaultPageSize
+	if ps, err := strconv.Atoi(c.Query("pageSize")); err == nil && ps > 0 {
+		pageSize = ps
+	}
+
+	// Cap pageSize at maximum
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	return page, pageSize
+}
+
+// handleError handles errors and returns appropriate HTTP responses
+func (h *PolicyHandler) handleError(c *gin.Context, err error) {
+	if errors.IsValidationError(err) {
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			err,
+			map[string]string{},
+		))
+		return
+	}
+
+	if errors.IsResourceNotFoundError(err) {
+		c.JSON(http.StatusNotFound, dto.NewResourceNotFoundErrorResponse(c.Request.Context(), err))
+		return
+	}
+
+	// Default to internal server error
+	logger.WithError(err).Error("internal server error")
+	c.JSON(http.StatusInternalServerError, dto.NewInternalErrorResponse(c.Request.Context(), err))
+}
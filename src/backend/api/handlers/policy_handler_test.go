@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"../../domain/models"
+	apperrors "../../pkg/errors"
+	"../../pkg/utils/pagination"
+)
+
+// MockPolicyUseCase is a mock implementation of the PolicyUseCase interface
+type MockPolicyUseCase struct {
+	mock.Mock
+}
+
+func (m *MockPolicyUseCase) CreatePolicy(ctx context.Context, policy *models.AccessPolicy) (string, error) {
+	args := m.Called(ctx, policy)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockPolicyUseCase) GetPolicy(ctx context.Context, id string, tenantID string) (*models.AccessPolicy, error) {
+	args := m.Called(ctx, id, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.AccessPolicy), args.Error(1)
+}
+
+func (m *MockPolicyUseCase) ListPolicies(ctx context.Context, tenantID string, page int, pageSize int) (pagination.PaginatedResult[models.AccessPolicy], error) {
+	args := m.Called(ctx, tenantID, page, pageSize)
+	return args.Get(0).(pagination.PaginatedResult[models.AccessPolicy]), args.Error(1)
+}
+
+func (m *MockPolicyUseCase) UpdatePolicy(ctx context.Context, policy *models.AccessPolicy) error {
+	args := m.Called(ctx, policy)
+	return args.Error(0)
+}
+
+func (m *MockPolicyUseCase) DeletePolicy(ctx context.Context, id string, tenantID string) error {
+	args := m.Called(ctx, id, tenantID)
+	return args.Error(0)
+}
+
+// PolicyHandlerSuite defines the test suite
+type PolicyHandlerSuite struct {
+	suite.Suite
+	router        *gin.Engine
+	recorder      *httptest.ResponseRecorder
+	policyUseCase *MockPolicyUseCase
+	policyHandler *PolicyHandler
+}
+
+// SetupTest is called before each test
+func (s *PolicyHandlerSuite) SetupTest() {
+	// Create a gin router in test mode
+	gin.SetMode(gin.TestMode)
+	s.router = gin.New()
+	s.recorder = httptest.NewRecorder()
+
+	// Create the policy handler with a mock use case
+	s.policyUseCase = new(MockPolicyUseCase)
+	handler, err := NewPolicyHandler(s.policyUseCase)
+	s.Require().NoError(err)
+	s.policyHandler = handler
+
+	// Set up a router group with an authenticated tenant and the policy handler routes
+	group := s.router.Group("/api/v1")
+	group.Use(func(c *gin.Context) {
+		c.Set("tenant_id", "tenant-123")
+		c.Set("user_id", "user-123")
+		c.Next()
+	})
+	s.policyHandler.RegisterRoutes(group)
+}
+
+// TestCreatePolicy_Success tests creating a metadata classification policy
+func (s *PolicyHandlerSuite) TestCreatePolicy_Success() {
+	s.policyUseCase.On("CreatePolicy", mock.Anything, mock.MatchedBy(func(p *models.AccessPolicy) bool {
+		return p.TenantID == "tenant-123" &&
+			p.CreatedBy == "user-123" &&
+			p.Attribute == "metadata.classification" &&
+			p.MatchValues[0] == "confidential" &&
+			p.Enabled
+	})).Return("policy-123", nil)
+
+	body := `{"name":"confidential-legal-only","attribute":"metadata.classification","operator":"equals","values":["confidential"],"actions":["read"],"required_roles":["legal"]}`
+	req, _ := http.NewRequest("POST", "/api/v1/policies", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusCreated, s.recorder.Code)
+	s.Contains(s.recorder.Body.String(), `"id":"policy-123"`)
+	s.policyUseCase.AssertExpectations(s.T())
+}
+
+// TestCreatePolicy_InvalidRule tests creating a policy the engine rejects
+func (s *PolicyHandlerSuite) TestCreatePolicy_InvalidRule() {
+	s.policyUseCase.On("CreatePolicy", mock.Anything, mock.Anything).
+		Return("", apperrors.NewValidationError(models.ErrPolicyInvalidOperator.Error()))
+
+	body := `{"name":"broken","attribute":"metadata.classification","operator":"matches","values":["x"],"actions":["read"],"required_roles":["legal"]}`
+	req, _ := http.NewRequest("POST", "/api/v1/policies", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusBadRequest, s.recorder.Code)
+	s.policyUseCase.AssertExpectations(s.T())
+}
+
+// TestListPolicies_Success tests listing the tenant's policies
+func (s *PolicyHandlerSuite) TestListPolicies_Success() {
+	result := pagination.PaginatedResult[models.AccessPolicy]{
+		Items:      []models.AccessPolicy{{ID: "policy-123", TenantID: "tenant-123", Name: "confidential-legal-only", Enabled: true}},
+		Pagination: pagination.PageInfo{Page: 1, PageSize: 20, TotalPages: 1, TotalItems: 1},
+	}
+	s.policyUseCase.On("ListPolicies", mock.Anything, "tenant-123", 1, 20).Return(result, nil)
+
+	req, _ := http.NewRequest("GET", "/api/v1/policies", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusOK, s.recorder.Code)
+	s.Contains(s.recorder.Body.String(), `"name":"confidential-legal-only"`)
+	s.policyUseCase.AssertExpectations(s.T())
+}
+
+// TestUpdatePolicy_Disable tests disabling a policy
+func (s *PolicyHandlerSuite) TestUpdatePolicy_Disable() {
+	s.policyUseCase.On("UpdatePolicy", mock.Anything, mock.MatchedBy(func(p *models.AccessPolicy) bool {
+		return p.ID == "policy-123" && p.TenantID == "tenant-123" && !p.Enabled
+	})).Return(nil)
+
+	body := `{"name":"confidential-legal-only","attribute":"metadata.classification","operator":"equals","values":["confidential"],"actions":["read"],"required_roles":["legal"],"enabled":false}`
+	req, _ := http.NewRequest("PUT", "/api/v1/policies/policy-123", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusOK, s.recorder.Code)
+	s.Contains(s.recorder.Body.String(), `"enabled":false`)
+	s.policyUseCase.AssertExpectations(s.T())
+}
+
+// TestDeletePolicy_NotFound tests deleting a policy that does not exist
+func (s *PolicyHandlerSuite) TestDeletePolicy_NotFound() {
+	s.policyUseCase.On("DeletePolicy", mock.Anything, "missing", "tenant-123").Return(apperrors.NewResourceNotFoundError("Access policy not found"))
+
+	req, _ := http.NewRequest("DELETE", "/api/v1/policies/missing", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusNotFound, s.recorder.Code)
+	s.policyUseCase.AssertExpectations(s.T())
+}
+
+// TestPolicyHandlerSuite runs the test suite
+func TestPolicyHandlerSuite(t *testing.T) {
+	suite.Run(t, new(PolicyHandlerSuite))
+}
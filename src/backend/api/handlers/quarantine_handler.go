@@ -0,0 +1,221 @@
+// Package handlers implements HTTP handlers for quarantine management in the Document Management Platform.
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin" // v1.9.0+
+
+	"../dto"
+	"../middleware"
+	"../validators"
+	"../../application/usecases"
+	"../../pkg/errors"
+	"../../pkg/logger"
+)
+
+// QuarantineHandler handles HTTP requests for security administrators reviewing, releasing and
+// destroying the documents virus scans found infected
+type QuarantineHandler struct {
+	quarantineUseCase usecases.QuarantineUseCase
+}
+
+// NewQuarantineHandler creates a new QuarantineHandler instance
+func NewQuarantineHandler(quarantineUseCase usecases.QuarantineUseCase) (*QuarantineHandler, error) {
+	if quarantineUseCase == nil {
+		return nil, errors.NewValidationError("quarantine use case cannot be nil")
+	}
+
+	return &QuarantineHandler{
+		quarantineUseCase: quarantineUseCase,
+	}, nil
+}
+
+// RegisterRoutes registers the quarantine routes with the provided router group
+func (h *QuarantineHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/quarantine", h.ListQuarantinedItems)
+	router.GET("/quarantine/:id", h.GetQuarantinedItem)
+	router.POST("/quarantine/:id/release", h.ReleaseQuarantinedItem)
+	router.POST("/quarantine/:id/destroy", h.DestroyQuarantinedItem)
+}
+
+// ListQuarantinedItems handles requests to list the tenant's quarantined items, optionally filtered by status
+func (h *QuarantineHandler) ListQuarantinedItems(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	page, pageSize := h.getPaginationParams(c)
+
+	// Call use case to list quarantined items
+	result, err := h.quarantineUseCase.ListQuarantinedItems(c.Request.Context(), tenantID, c.Query("status"), page, pageSize)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewPaginatedResponse(dto.ToQuarantinedItemListDTO(result), result.Pagination))
+}
+
+// GetQuarantinedItem handles requests for a quarantined item and its scan verdict
+func (h *QuarantineHandler) GetQuarantinedItem(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Call use case to get the quarantined item
+	item, err := h.quarantineUseCase.GetQuarantinedItem(c.Request.Context(), c.Param("id"), tenantID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.ToQuarantinedItemDTO(item)))
+}
+
+// ReleaseQuarantinedItem handles requests to release a quarantined document, either to scan it
+// again or to override the scan verdict
+func (h *QuarantineHandler) ReleaseQuarantinedItem(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Bind request body to DTO
+	var req dto.ReleaseQuarantinedItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.WithError(err).Error("failed to bind request body")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			validators.BindingError(err),
+			nil,
+		))
+		return
+	}
+
+	// Call use case to release the quarantined item
+	item, err := h.quarantineUseCase.ReleaseQuarantinedItem(c.Request.Context(), c.Param("id"), tenantID, middleware.GetUserID(c), req.Mode, req.Reason)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.ToQuarantinedItemDTO(item)))
+}
+
+// DestroyQuarantinedItem handles requests to permanently destroy a quarantined document
+func (h *QuarantineHandler) DestroyQuarantinedItem(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// The reason is optional, so an empty body is accepted
+	var req dto.DestroyQuarantinedItemRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			log.WithError(err).Error("failed to bind request body")
+			c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+				c.Request.Context(),
+				validators.BindingError(err),
+				nil,
+			))
+			return
+		}
+	}
+
+	// Call use case to destroy the quarantined item
+	item, err := h.quarantineUseCase.DestroyQuarantinedItem(c.Request.Context(), c.Param("id"), tenantID, middleware.GetUserID(c), req.Reason)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.ToQuarantinedItemDTO(item)))
+}
+
+// getPaginationParams extracts pagination parameters from the request
+func (h *QuarantineHandler) getPaginationParams(c *gin.Context) (int, int) {
+	page := 1
+	if p, err := strconv.Atoi(c.Query("page")); err == nil && p > 0 {
+		page = p
+	}
+
+	pageSize := defaultPageSize
+	if ps, err := strconv.Atoi(c.Query("pageSize")); err == nil && ps > 0 {
+		pageSize = ps
+	}
+
+	// Cap pageSize at maximum
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	return page, pageSize
+}
+
+// handleError handles errors and returns appropriate HTTP responses
+func (h *QuarantineHandler) handleError(c *gin.Context, err error) {
+	if errors.IsValidationError(err) {
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			err,
+			map[string]string{},
+		))
+		return
+	}
+
+	if errors.IsResourceNotFoundError(err) {
+		c.JSON(http.StatusNotFound, dto.NewResourceNotFoundErrorResponse(c.Request.Context(), err))
+		return
+	}
+
+	if errors.IsAuthenticationError(err) {
+		c.JSON(http.StatusUnauthorized, dto.NewAuthenticationErrorResponse(c.Request.Context(), err))
+		return
+	}
+
+	if errors.IsAuthorizationError(err) {
+		c.JSON(http.StatusForbidden, dto.NewAuthorizationErrorResponse(c.Request.Context(), err))
+		return
+	}
+
+	// Default to internal server error
+	logger.WithError(err).Error("internal server error")
+	c.JSON(http.StatusInternalServerError, dto.NewInternalErrorResponse(c.Request.Context(), err))
+}
@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"../../application/usecases"
+	"../../domain/models"
+	apperrors "../../pkg/errors"
+	"../../pkg/utils"
+)
+
+// MockQuarantineUseCase is a mock implementation of the QuarantineUseCase interface
+type MockQuarantineUseCase struct {
+	mock.Mock
+}
+
+func (m *MockQuarantineUseCase) ListQuarantinedItems(ctx context.Context, tenantID, status string, page, pageSize int) (utils.PaginatedResult[models.QuarantinedItem], error) {
+	args := m.Called(ctx, tenantID, status, page, pageSize)
+	return args.Get(0).(utils.PaginatedResult[models.QuarantinedItem]), args.Error(1)
+}
+
+func (m *MockQuarantineUseCase) GetQuarantinedItem(ctx context.Context, id, tenantID string) (*models.QuarantinedItem, error) {
+	args := m.Called(ctx, id, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.QuarantinedItem), args.Error(1)
+}
+
+func (m *MockQuarantineUseCase) ReleaseQuarantinedItem(ctx context.Context, id, tenantID, userID, mode, reason string) (*models.QuarantinedItem, error) {
+	args := m.Called(ctx, id, tenantID, userID, mode, reason)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.QuarantinedItem), args.Error(1)
+}
+
+func (m *MockQuarantineUseCase) DestroyQuarantinedItem(ctx context.Context, id, tenantID, userID, reason string) (*models.QuarantinedItem, error) {
+	args := m.Called(ctx, id, tenantID, userID, reason)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.QuarantinedItem), args.Error(1)
+}
+
+// QuarantineHandlerSuite defines the test suite
+type QuarantineHandlerSuite struct {
+	suite.Suite
+	router            *gin.Engine
+	recorder          *httptest.ResponseRecorder
+	quarantineUseCase *MockQuarantineUseCase
+}
+
+// SetupTest is called before each test
+func (s *QuarantineHandlerSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	s.router = gin.New()
+	s.recorder = httptest.NewRecorder()
+
+	s.quarantineUseCase = new(MockQuarantineUseCase)
+	handler, err := NewQuarantineHandler(s.quarantineUseCase)
+	s.Require().NoError(err)
+
+	group := s.router.Group("/api/v1")
+	group.Use(func(c *gin.Context) {
+		c.Set("tenant_id", "tenant-123")
+		c.Set("user_id", "admin-123")
+		c.Next()
+	})
+	handler.RegisterRoutes(group)
+}
+
+// createTestItem returns a quarantined item of the test tenant
+func (s *QuarantineHandlerSuite) createTestItem() *models.QuarantinedItem {
+	item := models.NewQuarantinedItem("tenant-123", "doc-123", "ver-123", "quarantine/tenant-123/doc-123", "EICAR-Test-File")
+	item.ID = "item-123"
+	return item
+}
+
+// TestListQuarantinedItems tests listing quarantined items filtered by status
+func (s *QuarantineHandlerSuite) TestListQuarantinedItems() {
+	items := []models.QuarantinedItem{*s.createTestItem()}
+	s.quarantineUseCase.On("ListQuarantinedItems", mock.Anything, "tenant-123", models.QuarantineStatusQuarantined, 1, 20).
+		Return(utils.NewPaginatedResult(items, utils.NewPagination(1, 20), 1), nil)
+
+	req, _ := http.NewRequest("GET", "/api/v1/quarantine?status=quarantined", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusOK, s.recorder.Code)
+	s.Contains(s.recorder.Body.String(), `"scan_details":"EICAR-Test-File"`)
+	s.quarantineUseCase.AssertExpectations(s.T())
+}
+
+// TestReleaseQuarantinedItem tests releasing an item for rescanning
+func (s *QuarantineHandlerSuite) TestReleaseQuarantinedItem() {
+	item := s.createTestItem()
+	item.Release(models.QuarantineReleaseRescan, "", "admin-123", time.Now())
+	s.quarantineUseCase.On("ReleaseQuarantinedItem", mock.Anything, "item-123", "tenant-123", "admin-123", models.QuarantineReleaseRescan, "").
+		Return(item, nil)
+
+	req, _ := http.NewRequest("POST", "/api/v1/quarantine/item-123/release", strings.NewReader(`{"mode":"rescan"}`))
+	req.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusOK, s.recorder.Code)
+	s.Contains(s.recorder.Body.String(), `"status":"released"`)
+	s.quarantineUseCase.AssertExpectations(s.T())
+}
+
+// TestReleaseQuarantinedItem_AlreadyResolved tests that releasing a resolved item is a bad request
+func (s *QuarantineHandlerSuite) TestReleaseQuarantinedItem_AlreadyResolved() {
+	s.quarantineUseCase.On("ReleaseQuarantinedItem", mock.Anything, "item-123", "tenant-123", "admin-123", models.QuarantineReleaseOverride, "false positive").
+		Return(nil, usecases.ErrQuarantinedItemResolved)
+
+	req, _ := http.NewRequest("POST", "/api/v1/quarantine/item-123/release", strings.NewReader(`{"mode":"override","reason":"false positive"}`))
+	req.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusBadRequest, s.recorder.Code)
+}
+
+// TestDestroyQuarantinedItem_WithoutBody tests that an item can be destroyed without giving a reason
+func (s *QuarantineHandlerSuite) TestDestroyQuarantinedItem_WithoutBody() {
+	item := s.createTestItem()
+	item.Destroy("", "admin-123", time.Now())
+	s.quarantineUseCase.On("DestroyQuarantinedItem", mock.Anything, "item-123", "tenant-123", "admin-123", "").
+		Return(item, nil)
+
+	req, _ := http.NewRequest("POST", "/api/v1/quarantine/item-123/destroy", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusOK, s.recorder.Code)
+	s.Contains(s.recorder.Body.String(), `"status":"destroyed"`)
+}
+
+// TestGetQuarantinedItem_NotFound tests requesting an unknown item
+func (s *QuarantineHandlerSuite) TestGetQuarantinedItem_NotFound() {
+	s.quarantineUseCase.On("GetQuarantinedItem", mock.Anything, "item-404", "tenant-123").
+		Return(nil, apperrors.NewResourceNotFoundError("Quarantined item not found"))
+
+	req, _ := http.NewRequest("GET", "/api/v1/quarantine/item-404", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusNotFound, s.recorder.Code)
+}
+
+// TestQuarantineHandlerSuite runs the test suite
+func TestQuarantineHandlerSuite(t *testing.T) {
+	suite.Run(t, new(QuarantineHandlerSuite))
+}
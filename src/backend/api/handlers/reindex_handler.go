@@ -0,0 +1,120 @@
+// Package handlers implements HTTP handlers for search index rebuilds in the Document Management Platform.
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin" // v1.9.0+
+
+	"../dto"
+	"../middleware"
+	"../../application/usecases"
+	"../../pkg/errors"
+	"../../pkg/logger"
+)
+
+// ReindexHandler handles HTTP requests for scheduling rebuilds of the search index of a tenant and
+// tracking their progress
+type ReindexHandler struct {
+	reindexUseCase usecases.ReindexUseCase
+}
+
+// NewReindexHandler creates a new ReindexHandler instance
+func NewReindexHandler(reindexUseCase usecases.ReindexUseCase) (*ReindexHandler, error) {
+	if reindexUseCase == nil {
+		return nil, errors.NewValidationError("reindex use case cannot be nil")
+	}
+
+	return &ReindexHandler{
+		reindexUseCase: reindexUseCase,
+	}, nil
+}
+
+// RegisterRoutes registers the reindex routes with the provided router group
+func (h *ReindexHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.POST("/search/reindex", h.ReindexTenant)
+	router.GET("/search/reindex/:id", h.GetReindexJob)
+}
+
+// ReindexTenant handles requests to rebuild the search index of the tenant. The index is rebuilt
+// in the background; the response points to the reindex job to poll for progress.
+func (h *ReindexHandler) ReindexTenant(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Call use case to schedule the rebuild
+	job, err := h.reindexUseCase.ReindexTenant(c.Request.Context(), tenantID, middleware.GetUserID(c))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Header("Location", "/api/v1/search/reindex/"+job.ID)
+	c.JSON(http.StatusAccepted, dto.NewDataResponse(dto.ToReindexJobDTO(job)))
+}
+
+// GetReindexJob handles requests for the status and progress of a rebuild
+func (h *ReindexHandler) GetReindexJob(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Call use case to get the reindex job
+	job, err := h.reindexUseCase.GetReindexJob(c.Request.Context(), c.Param("id"), tenantID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.ToReindexJobDTO(job)))
+}
+
+// handleError handles errors and returns appropriate HTTP responses
+func (h *ReindexHandler) handleError(c *gin.Context, err error) {
+	if errors.IsValidationError(err) {
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			err,
+			map[string]string{},
+		))
+		return
+	}
+
+	if errors.IsResourceNotFoundError(err) {
+		c.JSON(http.StatusNotFound, dto.NewResourceNotFoundErrorResponse(c.Request.Context(), err))
+		return
+	}
+
+	if errors.IsAuthenticationError(err) {
+		c.JSON(http.StatusUnauthorized, dto.NewAuthenticationErrorResponse(c.Request.Context(), err))
+		return
+	}
+
+	if errors.IsAuthorizationError(err) {
+		c.JSON(http.StatusForbidden, dto.NewAuthorizationErrorResponse(c.Request.Context(), err))
+		return
+	}
+
+	// Default to internal server error
+	logger.WithError(err).Error("internal server error")
+	c.JSON(http.StatusInternalServerError, dto.NewInternalErrorResponse(c.Request.Context(), err))
+}
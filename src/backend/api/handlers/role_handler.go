@@ -0,0 +1,271 @@
+// Package handlers implements HTTP handlers for tenant role management in the Document Management Platform.
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin" // v1.9.0+
+
+	"../dto"
+	"../middleware"
+	"../validators"
+	"../../application/usecases"
+	"../../pkg/errors"
+	"../../pkg/logger"
+)
+
+// RoleHandler handles HTTP requests for managing a tenant's roles
+type RoleHandler struct {
+	roleUseCase usecases.RoleUseCase
+}
+
+// NewRoleHandler creates a new RoleHandler instance
+func NewRoleHandler(roleUseCase usecases.RoleUseCase) (*RoleHandler, error) {
+	if roleUseCase == nil {
+		return nil, errors.NewValidationError("role use case cannot be nil")
+	}
+
+	return &RoleHandler{
+		roleUseCase: roleUseCase,
+	}, nil
+}
+
+// RegisterRoutes registers role routes with the provided router group
+func (h *RoleHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.POST("/roles", h.CreateRole)
+	router.GET("/roles", h.ListRoles)
+	router.GET("/roles/:id", h.GetRole)
+	router.PUT("/roles/:id", h.UpdateRole)
+	router.DELETE("/roles/:id", h.DeleteRole)
+}
+
+// CreateRole handles custom role creation requests
+func (h *RoleHandler) CreateRole(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Bind request body to DTO
+	var req dto.CreateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.WithError(err).Error("failed to bind request body")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			validators.BindingError(err),
+			nil,
+		))
+		return
+	}
+
+	// Call use case to create the role
+	role, err := h.roleUseCase.CreateRole(c.Request.Context(), tenantID, req.Name, req.Description, req.Permissions)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.NewDataResponse(dto.ToRoleDTO(role)))
+}
+
+// ListRoles handles requests to list the tenant's roles
+func (h *RoleHandler) ListRoles(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	page, pageSize := h.getPaginationParams(c)
+
+	// Call use case to list roles
+	result, err := h.roleUseCase.ListRoles(c.Request.Context(), tenantID, page, pageSize)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	// Convert domain models to DTOs and return
+	roles := dto.ToRoleListDTO(result)
+	c.JSON(http.StatusOK, dto.NewPaginatedResponse(roles, result.Pagination))
+}
+
+// GetRole handles role retrieval requests
+func (h *RoleHandler) GetRole(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Get role ID from URL
+	roleID := c.Param("id")
+	if roleID == "" {
+		log.Error("role ID missing in request path")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			errors.NewValidationError("role ID is required"),
+			map[string]string{"id": "required"},
+		))
+		return
+	}
+
+	// Call use case to get the role
+	role, err := h.roleUseCase.GetRole(c.Request.Context(), roleID, tenantID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.ToRoleDTO(role)))
+}
+
+// UpdateRole handles custom role update requests
+func (h *RoleHandler) UpdateRole(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Get role ID from URL
+	roleID := c.Param("id")
+	if roleID == "" {
+		log.Error("role ID missing in request path")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			errors.NewValidationError("role ID is required"),
+			map[string]string{"id": "required"},
+		))
+		return
+	}
+
+	// Bind request body to DTO
+	var req dto.UpdateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.WithError(err).Error("failed to bind request body")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			validators.BindingError(err),
+			nil,
+		))
+		return
+	}
+
+	// Call use case to update the role
+	role, err := h.roleUseCase.UpdateRole(c.Request.Context(), roleID, tenantID, req.Description, req.Permissions)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.ToRoleDTO(role)))
+}
+
+// DeleteRole handles custom role deletion requests
+func (h *RoleHandler) DeleteRole(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Get role ID from URL
+	roleID := c.Param("id")
+	if roleID == "" {
+		log.Error("role ID missing in request path")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			errors.NewValidationError("role ID is required"),
+			map[string]string{"id": "required"},
+		))
+		return
+	}
+
+	// Call use case to delete the role
+	if err := h.roleUseCase.DeleteRole(c.Request.Context(), roleID, tenantID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	// Return success response
+	c.JSON(http.StatusOK, dto.NewMessageResponse("Role deleted successfully"))
+}
+
+// getPaginationParams extracts and validates pagination parameters from the request
+func (h *RoleHandler) getPaginationParams(c *gin.Context) (int, int) {
+	page := 1
+	if p, err := strconv.Atoi(c.Query("page")); err == nil && p > 0 {
+		page = p
+	}
+
+	pageSize := defaultPageSize
+	if ps, err := strconv.Atoi(c.Query("pageSize")); err == nil && ps > 0 {
+		pageSize = ps
+	}
+
+	// Cap pageSize at maximum
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	return page, pageSize
+}
+
+// handleError handles errors and returns appropriate HTTP responses
+func (h *RoleHandler) handleError(c *gin.Context, err error) {
+	if errors.IsValidationError(err) {
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			err,
+			map[string]string{},
+		))
+		return
+	}
+
+	if errors.IsResourceNotFoundError(err) {
+		c.JSON(http.StatusNotFound, dto.NewResourceNotFoundErrorResponse(c.Request.Context(), err))
+		return
+	}
+
+	// Default to internal server error
+	logger.WithError(err).Error("internal server error")
+	c.JSON(http.StatusInternalServerError, dto.NewInternalErrorResponse(c.Request.Context(), err))
+}
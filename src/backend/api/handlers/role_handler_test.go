@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"../../domain/models"
+	apperrors "../../pkg/errors"
+	"../../pkg/utils/pagination"
+)
+
+// MockRoleUseCase is a mock implementation of the RoleUseCase interface
+type MockRoleUseCase struct {
+	mock.Mock
+}
+
+func (m *MockRoleUseCase) CreateRole(ctx context.Context, tenantID, name, description string, permissions []string) (*models.Role, error) {
+	args := m.Called(ctx, tenantID, name, description, permissions)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Role), args.Error(1)
+}
+
+func (m *MockRoleUseCase) GetRole(ctx context.Context, id string, tenantID string) (*models.Role, error) {
+	args := m.Called(ctx, id, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Role), args.Error(1)
+}
+
+func (m *MockRoleUseCase) ListRoles(ctx context.Context, tenantID string, page int, pageSize int) (pagination.PaginatedResult[models.Role], error) {
+	args := m.Called(ctx, tenantID, page, pageSize)
+	return args.Get(0).(pagination.PaginatedResult[models.Role]), args.Error(1)
+}
+
+func (m *MockRoleUseCase) UpdateRole(ctx context.Context, id, tenantID, description string, permissions []string) (*models.Role, error) {
+	args := m.Called(ctx, id, tenantID, description, permissions)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Role), args.Error(1)
+}
+
+func (m *MockRoleUseCase) DeleteRole(ctx context.Context, id string, tenantID string) error {
+	args := m.Called(ctx, id, tenantID)
+	return args.Error(0)
+}
+
+// RoleHandlerSuite defines the test suite
+type RoleHandlerSuite struct {
+	suite.Suite
+	router      *gin.Engine
+	recorder    *httptest.ResponseRecorder
+	roleUseCase *MockRoleUseCase
+	roleHandler *RoleHandler
+}
+
+// SetupTest is called before each test
+func (s *RoleHandlerSuite) SetupTest() {
+	// Create a gin router in test mode
+	gin.SetMode(gin.TestMode)
+	s.router = gin.New()
+	s.recorder = httptest.NewRecorder()
+
+	// Create the role handler with a mock use case
+	s.roleUseCase = new(MockRoleUseCase)
+	handler, err := NewRoleHandler(s.roleUseCase)
+	s.Require().NoError(err)
+	s.roleHandler = handler
+
+	// Set up a router group with an authenticated tenant and the role handler routes
+	group := s.router.Group("/api/v1")
+	group.Use(func(c *gin.Context) {
+		c.Set("tenant_id", "tenant-123")
+		c.Set("user_id", "user-123")
+		c.Next()
+	})
+	s.roleHandler.RegisterRoutes(group)
+}
+
+// Helper function to create a test role model
+func (s *RoleHandlerSuite) createTestRole() *models.Role {
+	return &models.Role{
+		ID:          "role-123",
+		TenantID:    "tenant-123",
+		Name:        "reviewer",
+		Description: "Reviews documents",
+		Permissions: []string{models.RolePermissionRead, models.RolePermissionWrite},
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+}
+
+// TestCreateRole_Success tests creating a custom role
+func (s *RoleHandlerSuite) TestCreateRole_Success() {
+	permissions := []string{models.RolePermissionRead, models.RolePermissionWrite}
+	s.roleUseCase.On("CreateRole", mock.Anything, "tenant-123", "reviewer", "Reviews documents", permissions).Return(s.createTestRole(), nil)
+
+	body := `{"name":"reviewer","description":"Reviews documents","permissions":["read","write"]}`
+	req, _ := http.NewRequest("POST", "/api/v1/roles", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusCreated, s.recorder.Code)
+	s.Contains(s.recorder.Body.String(), `"permissions":["read","write"]`)
+	s.Contains(s.recorder.Body.String(), `"system":false`)
+	s.roleUseCase.AssertExpectations(s.T())
+}
+
+// TestCreateRole_InvalidPermission tests creating a role with an unknown permission
+func (s *RoleHandlerSuite) TestCreateRole_InvalidPermission() {
+	s.roleUseCase.On("CreateRole", mock.Anything, "tenant-123", "reviewer", "Reviews documents", []string{"approve"}).
+		Return(nil, apperrors.NewValidationError(models.ErrRolePermissionInvalid.Error()))
+
+	body := `{"name":"reviewer","description":"Reviews documents","permissions":["approve"]}`
+	req, _ := http.NewRequest("POST", "/api/v1/roles", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusBadRequest, s.recorder.Code)
+	s.roleUseCase.AssertExpectations(s.T())
+}
+
+// TestListRoles_Success tests listing the tenant's roles
+func (s *RoleHandlerSuite) TestListRoles_Success() {
+	result := pagination.PaginatedResult[models.Role]{
+		Items:      []models.Role{*s.createTestRole()},
+		Pagination: pagination.PageInfo{Page: 1, PageSize: 20, TotalPages: 1, TotalItems: 1},
+	}
+	s.roleUseCase.On("ListRoles", mock.Anything, "tenant-123", 1, 20).Return(result, nil)
+
+	req, _ := http.NewRequest("GET", "/api/v1/roles", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusOK, s.recorder.Code)
+	s.Contains(s.recorder.Body.String(), `"name":"reviewer"`)
+	s.roleUseCase.AssertExpectations(s.T())
+}
+
+// TestGetRole_NotFound tests retrieving a role that does not exist
+func (s *RoleHandlerSuite) TestGetRole_NotFound() {
+	s.roleUseCase.On("GetRole", mock.Anything, "missing", "tenant-123").Return(nil, apperrors.NewResourceNotFoundError("Role not found"))
+
+	req, _ := http.NewRequest("GET", "/api/v1/roles/missing", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusNotFound, s.recorder.Code)
+	s.roleUseCase.AssertExpectations(s.T())
+}
+
+// TestDeleteRole_SystemRole tests that deleting a system role is rejected
+func (s *RoleHandlerSuite) TestDeleteRole_SystemRole() {
+	s.roleUseCase.On("DeleteRole", mock.Anything, "role-admin", "tenant-123").
+		Return(apperrors.NewValidationError("system role administrator cannot be deleted"))
+
+	req, _ := http.NewRequest("DELETE", "/api/v1/roles/role-admin", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusBadRequest, s.recorder.Code)
+	s.roleUseCase.AssertExpectations(s.T())
+}
+
+// TestRoleHandlerSuite runs the test suite
+func TestRoleHandlerSuite(t *testing.T) {
+	suite.Run(t, new(RoleHandlerSuite))
+}
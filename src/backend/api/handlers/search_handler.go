@@ -65,8 +65,8 @@ func (h *SearchHandler) SearchByContent(c *gin.Context) {
 		return
 	}
 
-	// Create pagination parameters
-	pagination := utils.NewPagination(request.Page, request.PageSize)
+	// Create pagination parameters, sorted by the requested keys and following the cursor if any
+	pagination := utils.NewPagination(request.Page, request.PageSize).WithSort(sortKeys(request.SortBy, request.SortOrder)).WithCursor(request.Cursor)
 
 	// Call searchUseCase.SearchByContent with query, tenant ID, and pagination
 	result, err := h.searchUseCase.SearchByContent(c, request.Query, tenantID, pagination)
@@ -78,8 +78,8 @@ func (h *SearchHandler) SearchByContent(c *gin.Context) {
 	// Convert domain documents to DocumentSearchResult DTOs
 	searchResults := h.convertToSearchResults(result.Items)
 
-	// Create page info from pagination and total items
-	pageInfo := utils.NewPageInfo(pagination, result.Pagination.TotalItems)
+	// Page info of the results, with the cursor of the next page
+	pageInfo := result.Pagination
 
 	// Return 200 OK with search results and pagination info
 	c.JSON(http.StatusOK, dto.NewDocumentSearchResponse(searchResults, pageInfo))
@@ -117,11 +117,11 @@ func (h *SearchHandler) SearchByMetadata(c *gin.Context) {
 		return
 	}
 
-	// Create pagination parameters
-	pagination := utils.NewPagination(request.Page, request.PageSize)
+	// Create pagination parameters, sorted by the requested keys and following the cursor if any
+	pagination := utils.NewPagination(request.Page, request.PageSize).WithSort(sortKeys(request.SortBy, request.SortOrder)).WithCursor(request.Cursor)
 
-	// Call searchUseCase.SearchByMetadata with metadata, tenant ID, and pagination
-	result, err := h.searchUseCase.SearchByMetadata(c, request.Metadata, tenantID, pagination)
+	// Call searchUseCase.SearchByMetadata with metadata, filters, tenant ID, and pagination
+	result, err := h.searchUseCase.SearchByMetadata(c, request.Metadata, request.Filters, tenantID, pagination)
 	if err != nil {
 		h.handleSearchError(c, err)
 		return
@@ -130,8 +130,8 @@ func (h *SearchHandler) SearchByMetadata(c *gin.Context) {
 	// Convert domain documents to DocumentSearchResult DTOs
 	searchResults := h.convertToSearchResults(result.Items)
 
-	// Create page info from pagination and total items
-	pageInfo := utils.NewPageInfo(pagination, result.Pagination.TotalItems)
+	// Page info of the results, with the cursor of the next page
+	pageInfo := result.Pagination
 
 	// Return 200 OK with search results and pagination info
 	c.JSON(http.StatusOK, dto.NewDocumentSearchResponse(searchResults, pageInfo))
@@ -169,11 +169,11 @@ func (h *SearchHandler) CombinedSearch(c *gin.Context) {
 		return
 	}
 
-	// Create pagination parameters
-	pagination := utils.NewPagination(request.Page, request.PageSize)
+	// Create pagination parameters, sorted by the requested keys and following the cursor if any
+	pagination := utils.NewPagination(request.Page, request.PageSize).WithSort(sortKeys(request.SortBy, request.SortOrder)).WithCursor(request.Cursor)
 
-	// Call searchUseCase.CombinedSearch with query, metadata, tenant ID, and pagination
-	result, err := h.searchUseCase.CombinedSearch(c, request.Query, request.Metadata, tenantID, pagination)
+	// Call searchUseCase.CombinedSearch with query, metadata, filters, tenant ID, and pagination
+	result, err := h.searchUseCase.CombinedSearch(c, request.Query, request.Metadata, request.Filters, tenantID, pagination)
 	if err != nil {
 		h.handleSearchError(c, err)
 		return
@@ -182,8 +182,8 @@ func (h *SearchHandler) CombinedSearch(c *gin.Context) {
 	// Convert domain documents to DocumentSearchResult DTOs
 	searchResults := h.convertToSearchResults(result.Items)
 
-	// Create page info from pagination and total items
-	pageInfo := utils.NewPageInfo(pagination, result.Pagination.TotalItems)
+	// Page info of the results, with the cursor of the next page
+	pageInfo := result.Pagination
 
 	// Return 200 OK with search results and pagination info
 	c.JSON(http.StatusOK, dto.NewDocumentSearchResponse(searchResults, pageInfo))
@@ -221,8 +221,8 @@ func (h *SearchHandler) SearchInFolder(c *gin.Context) {
 		return
 	}
 
-	// Create pagination parameters
-	pagination := utils.NewPagination(request.Page, request.PageSize)
+	// Create pagination parameters, sorted by the requested keys and following the cursor if any
+	pagination := utils.NewPagination(request.Page, request.PageSize).WithSort(sortKeys(request.SortBy, request.SortOrder)).WithCursor(request.Cursor)
 
 	// Call searchUseCase.SearchInFolder with folder ID, query, tenant ID, and pagination
 	result, err := h.searchUseCase.SearchInFolder(c, request.FolderID, request.Query, tenantID, pagination)
@@ -234,8 +234,8 @@ func (h *SearchHandler) SearchInFolder(c *gin.Context) {
 	// Convert domain documents to DocumentSearchResult DTOs
 	searchResults := h.convertToSearchResults(result.Items)
 
-	// Create page info from pagination and total items
-	pageInfo := utils.NewPageInfo(pagination, result.Pagination.TotalItems)
+	// Page info of the results, with the cursor of the next page
+	pageInfo := result.Pagination
 
 	// Return 200 OK with search results and pagination info
 	c.JSON(http.StatusOK, dto.NewDocumentSearchResponse(searchResults, pageInfo))
@@ -266,4 +266,10 @@ func (h *SearchHandler) convertToSearchResults(documents []models.Document) []dt
 		results = append(results, dto.DocumentToSearchResult(doc))
 	}
 	return results
-}
\ No newline at end of file
+}
+
+// sortKeys returns the sort keys of a request whose sort parameters were validated
+func sortKeys(sortBy string, sortOrder string) []utils.SortKey {
+	keys, _ := utils.ParseSort(sortBy, sortOrder)
+	return keys
+}
@@ -31,13 +31,13 @@ func (m *MockSearchUseCase) SearchByContent(ctx context.Context, query string, t
 	return args.Get(0).(pagination.PaginatedResult[models.Document]), args.Error(1)
 }
 
-func (m *MockSearchUseCase) SearchByMetadata(ctx context.Context, metadata map[string]string, tenantID string, pagination *pagination.Pagination) (pagination.PaginatedResult[models.Document], error) {
-	args := m.Called(ctx, metadata, tenantID, pagination)
+func (m *MockSearchUseCase) SearchByMetadata(ctx context.Context, metadata map[string]string, filters []models.MetadataFilter, tenantID string, pagination *pagination.Pagination) (pagination.PaginatedResult[models.Document], error) {
+	args := m.Called(ctx, metadata, filters, tenantID, pagination)
 	return args.Get(0).(pagination.PaginatedResult[models.Document]), args.Error(1)
 }
 
-func (m *MockSearchUseCase) CombinedSearch(ctx context.Context, contentQuery string, metadata map[string]string, tenantID string, pagination *pagination.Pagination) (pagination.PaginatedResult[models.Document], error) {
-	args := m.Called(ctx, contentQuery, metadata, tenantID, pagination)
+func (m *MockSearchUseCase) CombinedSearch(ctx context.Context, contentQuery string, metadata map[string]string, filters []models.MetadataFilter, tenantID string, pagination *pagination.Pagination) (pagination.PaginatedResult[models.Document], error) {
+	args := m.Called(ctx, contentQuery, metadata, filters, tenantID, pagination)
 	return args.Get(0).(pagination.PaginatedResult[models.Document]), args.Error(1)
 }
 
@@ -240,6 +240,41 @@ func TestSearchHandler_SearchByContent(t *testing.T) {
 	mockUseCase.AssertExpectations(t)
 }
 
+func TestSearchHandler_SearchByContentSorted(t *testing.T) {
+	mockUseCase, _, handler := setupTest()
+
+	// Fields without a direction are sorted in sort_order
+	mockUseCase.On("SearchByContent", mock.Anything, "invoice", "tenant-123", mock.MatchedBy(func(p *pagination.Pagination) bool {
+		return assert.ObjectsAreEqual([]pagination.SortKey{
+			{Field: "size", Direction: "desc"},
+			{Field: "name", Direction: "asc"},
+		}, p.Sort)
+	})).Return(pagination.PaginatedResult[models.Document]{Items: []models.Document{}}, nil)
+
+	body := `{"query":"invoice","page":1,"page_size":10,"sort_by":"size,name:asc","sort_order":"desc"}`
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/search/content", bytes.NewBufferString(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("tenant_id", "tenant-123")
+
+	handler.SearchByContent(c)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// A field search results cannot be sorted by is rejected before searching
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/search/content",
+		bytes.NewBufferString(`{"query":"invoice","page":1,"page_size":10,"sort_by":"owner"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("tenant_id", "tenant-123")
+
+	handler.SearchByContent(c)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	mockUseCase.AssertExpectations(t)
+}
+
 func TestSearchHandler_SearchByMetadata(t *testing.T) {
 	mockUseCase, _, handler := setupTest()
 	
@@ -272,7 +307,7 @@ func TestSearchHandler_SearchByMetadata(t *testing.T) {
 	}
 	
 	// Set up mock expectations
-	mockUseCase.On("SearchByMetadata", mock.Anything, metadataReq.Metadata, "tenant-123", mock.Anything).
+	mockUseCase.On("SearchByMetadata", mock.Anything, metadataReq.Metadata, []models.MetadataFilter(nil), "tenant-123", mock.Anything).
 		Return(expectedResult, nil)
 	
 	// Create request
@@ -331,7 +366,7 @@ func TestSearchHandler_SearchByMetadata(t *testing.T) {
 		PageSize: 10,
 	}
 	
-	mockUseCase.On("SearchByMetadata", mock.Anything, authErrorReq.Metadata, "tenant-123", mock.Anything).
+	mockUseCase.On("SearchByMetadata", mock.Anything, authErrorReq.Metadata, []models.MetadataFilter(nil), "tenant-123", mock.Anything).
 		Return(pagination.PaginatedResult[models.Document]{}, errors.NewAuthorizationError("unauthorized access"))
 	
 	body, _ = json.Marshal(authErrorReq)
@@ -345,6 +380,41 @@ func TestSearchHandler_SearchByMetadata(t *testing.T) {
 	mockUseCase.AssertExpectations(t)
 }
 
+func TestSearchHandler_SearchByMetadataFilters(t *testing.T) {
+	mockUseCase, _, handler := setupTest()
+
+	filters := []models.MetadataFilter{
+		{Key: "invoice_date", Operator: models.MetadataFilterBetween, Values: []string{"2024-01-01", "2024-03-31"}},
+		{Key: "amount", Operator: models.MetadataFilterGte, Value: "1000"},
+	}
+	mockUseCase.On("SearchByMetadata", mock.Anything, map[string]string(nil), filters, "tenant-123", mock.Anything).
+		Return(pagination.PaginatedResult[models.Document]{Items: []models.Document{createTestDocument("doc-1")}}, nil)
+
+	body := `{"filters":[{"key":"invoice_date","op":"between","values":["2024-01-01","2024-03-31"]},` +
+		`{"key":"amount","op":"gte","value":"1000"}],"page":1,"page_size":10}`
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/search/metadata", bytes.NewBufferString(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("tenant_id", "tenant-123")
+
+	handler.SearchByMetadata(c)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// A filter with an unsupported operator is rejected before searching
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/search/metadata",
+		bytes.NewBufferString(`{"filters":[{"key":"amount","op":"near","value":"1000"}],"page":1,"page_size":10}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("tenant_id", "tenant-123")
+
+	handler.SearchByMetadata(c)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	mockUseCase.AssertExpectations(t)
+}
+
 func TestSearchHandler_CombinedSearch(t *testing.T) {
 	mockUseCase, _, handler := setupTest()
 	
@@ -377,7 +447,7 @@ func TestSearchHandler_CombinedSearch(t *testing.T) {
 	}
 	
 	// Set up mock expectations
-	mockUseCase.On("CombinedSearch", mock.Anything, combinedReq.Query, combinedReq.Metadata, "tenant-123", mock.Anything).
+	mockUseCase.On("CombinedSearch", mock.Anything, combinedReq.Query, combinedReq.Metadata, []models.MetadataFilter(nil), "tenant-123", mock.Anything).
 		Return(expectedResult, nil)
 	
 	// Create request
@@ -440,7 +510,7 @@ func TestSearchHandler_CombinedSearch(t *testing.T) {
 		PageSize: 10,
 	}
 	
-	mockUseCase.On("CombinedSearch", mock.Anything, errorReq.Query, errorReq.Metadata, "tenant-123", mock.Anything).
+	mockUseCase.On("CombinedSearch", mock.Anything, errorReq.Query, errorReq.Metadata, []models.MetadataFilter(nil), "tenant-123", mock.Anything).
 		Return(pagination.PaginatedResult[models.Document]{}, errors.NewInternalError("internal error"))
 	
 	body, _ = json.Marshal(errorReq)
@@ -0,0 +1,253 @@
+// Package handlers implements HTTP handlers for document numbering sequence management in the Document Management Platform.
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin" // v1.9.0+
+
+	"../../application/usecases"
+	"../../pkg/errors"
+	"../../pkg/logger"
+	"../dto"
+	"../middleware"
+	"../validators"
+)
+
+// SequenceHandler handles HTTP requests for managing the numbering sequences of tenants and folders
+type SequenceHandler struct {
+	sequenceUseCase usecases.SequenceUseCase
+}
+
+// NewSequenceHandler creates a new SequenceHandler instance
+func NewSequenceHandler(sequenceUseCase usecases.SequenceUseCase) (*SequenceHandler, error) {
+	if sequenceUseCase == nil {
+		return nil, errors.NewValidationError("sequence use case cannot be nil")
+	}
+
+	return &SequenceHandler{
+		sequenceUseCase: sequenceUseCase,
+	}, nil
+}
+
+// RegisterRoutes registers numbering sequence routes with the provided router group
+func (h *SequenceHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.POST("/numbering-sequences", h.CreateSequence)
+	router.GET("/numbering-sequences", h.ListSequences)
+	router.GET("/numbering-sequences/:id", h.GetSequence)
+	router.PUT("/numbering-sequences/:id", h.UpdateSequence)
+	router.DELETE("/numbering-sequences/:id", h.DeleteSequence)
+}
+
+// CreateSequence handles numbering sequence creation requests
+func (h *SequenceHandler) CreateSequence(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Bind request body to DTO
+	var req dto.NumberingSequenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.WithError(err).Error("failed to bind request body")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			validators.BindingError(err),
+			nil,
+		))
+		return
+	}
+
+	// Convert DTO to domain model
+	sequence := dto.ToNumberingSequenceDomain(&req, tenantID, middleware.GetUserID(c))
+
+	// Call use case to create the sequence
+	sequenceID, err := h.sequenceUseCase.CreateSequence(c.Request.Context(), sequence)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	sequence.ID = sequenceID
+	c.JSON(http.StatusCreated, dto.NewDataResponse(dto.ToNumberingSequenceDTO(sequence)))
+}
+
+// ListSequences handles requests to list the tenant's numbering sequences
+func (h *SequenceHandler) ListSequences(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Call use case to list the sequences
+	sequences, err := h.sequenceUseCase.ListSequences(c.Request.Context(), tenantID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.ToNumberingSequenceListDTO(sequences)))
+}
+
+// GetSequence handles numbering sequence retrieval requests
+func (h *SequenceHandler) GetSequence(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Get sequence ID from URL
+	sequenceID := c.Param("id")
+	if sequenceID == "" {
+		log.Error("numbering sequence ID missing in request path")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			errors.NewValidationError("numbering sequence ID is required"),
+			map[string]string{"id": "required"},
+		))
+		return
+	}
+
+	// Call use case to get the sequence
+	sequence, err := h.sequenceUseCase.GetSequence(c.Request.Context(), sequenceID, tenantID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.ToNumberingSequenceDTO(sequence)))
+}
+
+// UpdateSequence handles requests to replace a numbering sequence's name, format, padding, mode and reset
+func (h *SequenceHandler) UpdateSequence(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Get sequence ID from URL
+	sequenceID := c.Param("id")
+	if sequenceID == "" {
+		log.Error("numbering sequence ID missing in request path")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			errors.NewValidationError("numbering sequence ID is required"),
+			map[string]string{"id": "required"},
+		))
+		return
+	}
+
+	// Bind request body to DTO
+	var req dto.NumberingSequenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.WithError(err).Error("failed to bind request body")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			validators.BindingError(err),
+			nil,
+		))
+		return
+	}
+
+	// Convert DTO to domain model
+	sequence := dto.ToNumberingSequenceDomain(&req, tenantID, middleware.GetUserID(c))
+	sequence.ID = sequenceID
+
+	// Call use case to update the sequence
+	if err := h.sequenceUseCase.UpdateSequence(c.Request.Context(), sequence); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.ToNumberingSequenceDTO(sequence)))
+}
+
+// DeleteSequence handles numbering sequence deletion requests
+func (h *SequenceHandler) DeleteSequence(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Get sequence ID from URL
+	sequenceID := c.Param("id")
+	if sequenceID == "" {
+		log.Error("numbering sequence ID missing in request path")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			errors.NewValidationError("numbering sequence ID is required"),
+			map[string]string{"id": "required"},
+		))
+		return
+	}
+
+	// Call use case to delete the sequence
+	if err := h.sequenceUseCase.DeleteSequence(c.Request.Context(), sequenceID, tenantID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	// Return success response
+	c.JSON(http.StatusOK, dto.NewMessageResponse("Numbering sequence deleted successfully"))
+}
+
+// handleError handles errors and returns appropriate HTTP responses
+func (h *SequenceHandler) handleError(c *gin.Context, err error) {
+	if errors.IsValidationError(err) {
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			err,
+			map[string]string{},
+		))
+		return
+	}
+
+	if errors.IsResourceNotFoundError(err) {
+		c.JSON(http.StatusNotFound, dto.NewResourceNotFoundErrorResponse(c.Request.Context(), err))
+		return
+	}
+
+	// Default to internal server error
+	logger.WithError(err).Error("internal server error")
+	c.JSON(http.StatusInternalServerError, dto.NewInternalErrorResponse(c.Request.Context(), err))
+}
@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"../../domain/models"
+	apperrors "../../pkg/errors"
+)
+
+// MockSequenceUseCase is a mock implementation of the SequenceUseCase interface
+type MockSequenceUseCase struct {
+	mock.Mock
+}
+
+func (m *MockSequenceUseCase) CreateSequence(ctx context.Context, sequence *models.NumberingSequence) (string, error) {
+	args := m.Called(ctx, sequence)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockSequenceUseCase) GetSequence(ctx context.Context, id string, tenantID string) (*models.NumberingSequence, error) {
+	args := m.Called(ctx, id, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.NumberingSequence), args.Error(1)
+}
+
+func (m *MockSequenceUseCase) ListSequences(ctx context.Context, tenantID string) ([]*models.NumberingSequence, error) {
+	args := m.Called(ctx, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.NumberingSequence), args.Error(1)
+}
+
+func (m *MockSequenceUseCase) UpdateSequence(ctx context.Context, sequence *models.NumberingSequence) error {
+	args := m.Called(ctx, sequence)
+	return args.Error(0)
+}
+
+func (m *MockSequenceUseCase) DeleteSequence(ctx context.Context, id string, tenantID string) error {
+	args := m.Called(ctx, id, tenantID)
+	return args.Error(0)
+}
+
+// SequenceHandlerSuite defines the test suite
+type SequenceHandlerSuite struct {
+	suite.Suite
+	router          *gin.Engine
+	recorder        *httptest.ResponseRecorder
+	sequenceUseCase *MockSequenceUseCase
+	sequenceHandler *SequenceHandler
+}
+
+// SetupTest is called before each test
+func (s *SequenceHandlerSuite) SetupTest() {
+	// Create a gin router in test mode
+	gin.SetMode(gin.TestMode)
+	s.router = gin.New()
+	s.recorder = httptest.NewRecorder()
+
+	// Create the sequence handler with a mock use case
+	s.sequenceUseCase = new(MockSequenceUseCase)
+	handler, err := NewSequenceHandler(s.sequenceUseCase)
+	s.Require().NoError(err)
+	s.sequenceHandler = handler
+
+	// Set up a router group with an authenticated tenant and the sequence handler routes
+	group := s.router.Group("/api/v1")
+	group.Use(func(c *gin.Context) {
+		c.Set("tenant_id", "tenant-123")
+		c.Set("user_id", "user-123")
+		c.Next()
+	})
+	s.sequenceHandler.RegisterRoutes(group)
+}
+
+// TestCreateSequence_Defaults tests creating a sequence gap-free with the default padding
+func (s *SequenceHandlerSuite) TestCreateSequence_Defaults() {
+	s.sequenceUseCase.On("CreateSequence", mock.Anything, mock.MatchedBy(func(seq *models.NumberingSequence) bool {
+		return seq.TenantID == "tenant-123" &&
+			seq.FolderID == "folder-123" &&
+			seq.CreatedBy == "user-123" &&
+			seq.Format == "INV-{YYYY}-{SEQ}" &&
+			seq.Padding == models.DefaultNumberPadding &&
+			seq.Mode == models.NumberingModeGapFree &&
+			seq.ResetYearly
+	})).Return("sequence-123", nil)
+
+	body := `{"folder_id":"folder-123","name":"Invoices","format":"INV-{YYYY}-{SEQ}","reset_yearly":true}`
+	req, _ := http.NewRequest("POST", "/api/v1/numbering-sequences", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusCreated, s.recorder.Code)
+	s.Contains(s.recorder.Body.String(), `"id":"sequence-123"`)
+	s.sequenceUseCase.AssertExpectations(s.T())
+}
+
+// TestCreateSequence_InvalidFormat tests creating a sequence whose format lacks the sequence value
+func (s *SequenceHandlerSuite) TestCreateSequence_InvalidFormat() {
+	s.sequenceUseCase.On("CreateSequence", mock.Anything, mock.Anything).
+		Return("", apperrors.NewValidationError(models.ErrNumberingSequenceFormatInvalid.Error()))
+
+	body := `{"name":"Invoices","format":"INV-{YYYY}","mode":"gap-tolerant"}`
+	req, _ := http.NewRequest("POST", "/api/v1/numbering-sequences", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusBadRequest, s.recorder.Code)
+	s.sequenceUseCase.AssertExpectations(s.T())
+}
+
+// TestGetSequence_NotFound tests retrieving a sequence of another tenant
+func (s *SequenceHandlerSuite) TestGetSequence_NotFound() {
+	s.sequenceUseCase.On("GetSequence", mock.Anything, "sequence-456", "tenant-123").
+		Return(nil, apperrors.NewResourceNotFoundError("Numbering sequence not found"))
+
+	req, _ := http.NewRequest("GET", "/api/v1/numbering-sequences/sequence-456", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusNotFound, s.recorder.Code)
+	s.sequenceUseCase.AssertExpectations(s.T())
+}
+
+// TestListSequences_Success tests listing the tenant's sequences with their last values
+func (s *SequenceHandlerSuite) TestListSequences_Success() {
+	sequences := []*models.NumberingSequence{{ID: "sequence-123", TenantID: "tenant-123", Name: "Invoices",
+		Format: "INV-{YYYY}-{SEQ}", Padding: 5, Mode: models.NumberingModeGapFree, LastValue: 42}}
+	s.sequenceUseCase.On("ListSequences", mock.Anything, "tenant-123").Return(sequences, nil)
+
+	req, _ := http.NewRequest("GET", "/api/v1/numbering-sequences", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusOK, s.recorder.Code)
+	s.Contains(s.recorder.Body.String(), `"last_value":42`)
+	s.sequenceUseCase.AssertExpectations(s.T())
+}
+
+// TestSequenceHandlerSuite runs the test suite
+func TestSequenceHandlerSuite(t *testing.T) {
+	suite.Run(t, new(SequenceHandlerSuite))
+}
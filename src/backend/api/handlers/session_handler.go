@@ -0,0 +1,140 @@
+// Package handlers implements HTTP handlers for session management in the Document Management Platform.
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin" // v1.9.0+
+
+	"../dto"
+	"../middleware"
+	"../../application/usecases"
+	"../../pkg/errors"
+	"../../pkg/logger"
+)
+
+// SessionHandler handles HTTP requests for listing and terminating active sessions
+type SessionHandler struct {
+	sessionUseCase usecases.SessionUseCase
+}
+
+// NewSessionHandler creates a new SessionHandler instance
+func NewSessionHandler(sessionUseCase usecases.SessionUseCase) (*SessionHandler, error) {
+	if sessionUseCase == nil {
+		return nil, errors.NewValidationError("session use case cannot be nil")
+	}
+
+	return &SessionHandler{
+		sessionUseCase: sessionUseCase,
+	}, nil
+}
+
+// RegisterRoutes registers session routes with the provided router group. The user_id query
+// parameter selects another user's sessions and defaults to the requesting user.
+func (h *SessionHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/sessions", h.ListSessions)
+	router.DELETE("/sessions", h.RevokeUserSessions)
+	router.DELETE("/sessions/:id", h.RevokeSession)
+}
+
+// ListSessions handles requests to list a user's active sessions
+func (h *SessionHandler) ListSessions(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Call use case to list the sessions
+	actorID := middleware.GetUserID(c)
+	sessions, err := h.sessionUseCase.ListSessions(c.Request.Context(), tenantID, actorID, c.DefaultQuery("user_id", actorID))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.ToSessionListDTO(sessions)))
+}
+
+// RevokeSession handles requests to terminate a session
+func (h *SessionHandler) RevokeSession(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Call use case to revoke the session
+	if err := h.sessionUseCase.RevokeSession(c.Request.Context(), tenantID, middleware.GetUserID(c), c.Param("id")); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewMessageResponse("session revoked successfully"))
+}
+
+// RevokeUserSessions handles requests to terminate all active sessions of a user
+func (h *SessionHandler) RevokeUserSessions(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Call use case to revoke the sessions
+	actorID := middleware.GetUserID(c)
+	count, err := h.sessionUseCase.RevokeUserSessions(c.Request.Context(), tenantID, actorID, c.DefaultQuery("user_id", actorID))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.RevokedSessionsDTO{Revoked: count}))
+}
+
+// handleError handles errors and returns appropriate HTTP responses
+func (h *SessionHandler) handleError(c *gin.Context, err error) {
+	if errors.IsValidationError(err) {
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			err,
+			map[string]string{},
+		))
+		return
+	}
+
+	if errors.IsResourceNotFoundError(err) {
+		c.JSON(http.StatusNotFound, dto.NewResourceNotFoundErrorResponse(c.Request.Context(), err))
+		return
+	}
+
+	if errors.IsAuthorizationError(err) {
+		c.JSON(http.StatusForbidden, dto.NewAuthorizationErrorResponse(c.Request.Context(), err))
+		return
+	}
+
+	// Default to internal server error
+	logger.WithError(err).Error("internal server error")
+	c.JSON(http.StatusInternalServerError, dto.NewInternalErrorResponse(c.Request.Context(), err))
+}
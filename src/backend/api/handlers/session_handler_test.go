@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"../../domain/models"
+	apperrors "../../pkg/errors"
+)
+
+// MockSessionUseCase is a mock implementation of the SessionUseCase interface
+type MockSessionUseCase struct {
+	mock.Mock
+}
+
+func (m *MockSessionUseCase) ListSessions(ctx context.Context, tenantID, actorID, userID string) ([]models.Session, error) {
+	args := m.Called(ctx, tenantID, actorID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Session), args.Error(1)
+}
+
+func (m *MockSessionUseCase) RevokeSession(ctx context.Context, tenantID, actorID, sessionID string) error {
+	args := m.Called(ctx, tenantID, actorID, sessionID)
+	return args.Error(0)
+}
+
+func (m *MockSessionUseCase) RevokeUserSessions(ctx context.Context, tenantID, actorID, userID string) (int, error) {
+	args := m.Called(ctx, tenantID, actorID, userID)
+	return args.Int(0), args.Error(1)
+}
+
+// SessionHandlerSuite defines the test suite
+type SessionHandlerSuite struct {
+	suite.Suite
+	router         *gin.Engine
+	recorder       *httptest.ResponseRecorder
+	sessionUseCase *MockSessionUseCase
+}
+
+// SetupTest is called before each test
+func (s *SessionHandlerSuite) SetupTest() {
+	// Create a gin router in test mode
+	gin.SetMode(gin.TestMode)
+	s.router = gin.New()
+	s.recorder = httptest.NewRecorder()
+
+	// Create the session handler with a mock use case
+	s.sessionUseCase = new(MockSessionUseCase)
+	handler, err := NewSessionHandler(s.sessionUseCase)
+	s.Require().NoError(err)
+
+	// Set up a router group with an authenticated tenant and the session handler routes
+	group := s.router.Group("/api/v1")
+	group.Use(func(c *gin.Context) {
+		c.Set("tenant_id", "tenant-123")
+		c.Set("user_id", "user-123")
+		c.Next()
+	})
+	handler.RegisterRoutes(group)
+}
+
+// TestListSessions_Own tests that the requesting user's sessions are listed by default
+func (s *SessionHandlerSuite) TestListSessions_Own() {
+	session := models.NewSession("user-123", "tenant-123", "203.0.113.7", "Mozilla/5.0", time.Now().Add(time.Hour))
+	session.ID = "session-123"
+	s.sessionUseCase.On("ListSessions", mock.Anything, "tenant-123", "user-123", "user-123").Return([]models.Session{*session}, nil)
+
+	req, _ := http.NewRequest("GET", "/api/v1/sessions", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusOK, s.recorder.Code)
+	s.Contains(s.recorder.Body.String(), `"id":"session-123"`)
+	s.Contains(s.recorder.Body.String(), `"user_agent":"Mozilla/5.0"`)
+}
+
+// TestListSessions_OtherUserForbidden tests that listing another user's sessions without permission returns 403
+func (s *SessionHandlerSuite) TestListSessions_OtherUserForbidden() {
+	s.sessionUseCase.On("ListSessions", mock.Anything, "tenant-123", "user-123", "user-456").
+		Return(nil, apperrors.NewAuthorizationError("only administrators can manage other users' sessions"))
+
+	req, _ := http.NewRequest("GET", "/api/v1/sessions?user_id=user-456", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusForbidden, s.recorder.Code)
+}
+
+// TestRevokeSession tests revoking a session
+func (s *SessionHandlerSuite) TestRevokeSession() {
+	s.sessionUseCase.On("RevokeSession", mock.Anything, "tenant-123", "user-123", "session-123").Return(nil)
+
+	req, _ := http.NewRequest("DELETE", "/api/v1/sessions/session-123", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusOK, s.recorder.Code)
+}
+
+// TestRevokeSession_NotFound tests revoking an unknown session
+func (s *SessionHandlerSuite) TestRevokeSession_NotFound() {
+	s.sessionUseCase.On("RevokeSession", mock.Anything, "tenant-123", "user-123", "session-999").
+		Return(apperrors.NewResourceNotFoundError("session not found"))
+
+	req, _ := http.NewRequest("DELETE", "/api/v1/sessions/session-999", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusNotFound, s.recorder.Code)
+}
+
+// TestRevokeUserSessions tests forcing a user to sign out everywhere
+func (s *SessionHandlerSuite) TestRevokeUserSessions() {
+	s.sessionUseCase.On("RevokeUserSessions", mock.Anything, "tenant-123", "user-123", "user-456").Return(3, nil)
+
+	req, _ := http.NewRequest("DELETE", "/api/v1/sessions?user_id=user-456", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusOK, s.recorder.Code)
+	s.Contains(s.recorder.Body.String(), `"revoked":3`)
+}
+
+// TestSessionHandlerSuite runs the test suite
+func TestSessionHandlerSuite(t *testing.T) {
+	suite.Run(t, new(SessionHandlerSuite))
+}
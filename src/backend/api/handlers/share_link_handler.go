@@ -0,0 +1,216 @@
+// Package handlers implements HTTP handlers for public document share links in the Document Management Platform.
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin" // v1.9.0+
+
+	"../dto"
+	"../middleware"
+	"../validators"
+	"../../application/usecases"
+	"../../pkg/errors"
+	"../../pkg/logger"
+)
+
+// shareLinkPasswordHeader carries the password of a password protected share link
+const shareLinkPasswordHeader = "X-Share-Password"
+
+// shareLinkPathPrefix is the public path under which share links are served
+const shareLinkPathPrefix = "/share/"
+
+// ShareLinkHandler handles HTTP requests for creating, revoking and serving document share links
+type ShareLinkHandler struct {
+	shareLinkUseCase usecases.ShareLinkUseCase
+	publicURL        string
+}
+
+// NewShareLinkHandler creates a new ShareLinkHandler instance. publicURL is the externally
+// reachable base URL of the API used to build the links handed out to users.
+func NewShareLinkHandler(shareLinkUseCase usecases.ShareLinkUseCase, publicURL string) (*ShareLinkHandler, error) {
+	if shareLinkUseCase == nil {
+		return nil, errors.NewValidationError("share link use case cannot be nil")
+	}
+
+	return &ShareLinkHandler{
+		shareLinkUseCase: shareLinkUseCase,
+		publicURL:        strings.TrimRight(publicURL, "/"),
+	}, nil
+}
+
+// RegisterRoutes registers the authenticated share link routes with the provided router group
+func (h *ShareLinkHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.POST("/documents/:id/share-links", h.CreateShareLink)
+	router.GET("/documents/:id/share-links", h.ListShareLinks)
+	router.DELETE("/share-links/:id", h.RevokeShareLink)
+}
+
+// RegisterPublicRoutes registers the unauthenticated share link download and page routes with the provided router group
+func (h *ShareLinkHandler) RegisterPublicRoutes(router *gin.RouterGroup) {
+	router.GET(shareLinkPathPrefix+":token", h.AccessShareLink)
+	router.GET(shareLinkPathPrefix+":token/page", h.DescribeShareLink)
+}
+
+// CreateShareLink handles requests to create a public link to a document
+func (h *ShareLinkHandler) CreateShareLink(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Bind request body to DTO
+	var req dto.CreateShareLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.WithError(err).Error("failed to bind request body")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			validators.BindingError(err),
+			nil,
+		))
+		return
+	}
+
+	// Call use case to create the share link
+	expiresIn := time.Duration(req.ExpiresInHours) * time.Hour
+	link, token, err := h.shareLinkUseCase.CreateShareLink(c.Request.Context(), c.Param("id"), tenantID, middleware.GetUserID(c), expiresIn, req.MaxDownloads, req.Password, req.Watermark)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	// The token is only available now, so hand out the full URL once
+	response := dto.ToShareLinkDTO(link)
+	response.URL = h.publicURL + shareLinkPathPrefix + token
+	c.JSON(http.StatusCreated, dto.NewDataResponse(response))
+}
+
+// ListShareLinks handles requests to list the share links of a document
+func (h *ShareLinkHandler) ListShareLinks(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Call use case to list the share links
+	links, err := h.shareLinkUseCase.ListShareLinks(c.Request.Context(), c.Param("id"), tenantID, middleware.GetUserID(c))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.ToShareLinkListDTO(links)))
+}
+
+// RevokeShareLink handles requests to revoke a share link
+func (h *ShareLinkHandler) RevokeShareLink(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Call use case to revoke the share link
+	if err := h.shareLinkUseCase.RevokeShareLink(c.Request.Context(), c.Param("id"), tenantID, middleware.GetUserID(c)); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewMessageResponse("Share link revoked successfully"))
+}
+
+// AccessShareLink handles unauthenticated share link requests by redirecting to a short-lived presigned
+// download URL, or by streaming the content when the download is watermarked
+func (h *ShareLinkHandler) AccessShareLink(c *gin.Context) {
+	download, err := h.shareLinkUseCase.AccessShareLink(c.Request.Context(), c.Param("token"), c.GetHeader(shareLinkPasswordHeader))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	if download.Content == nil {
+		c.Redirect(http.StatusFound, download.URL)
+		return
+	}
+	defer download.Content.Close()
+
+	contentType := download.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	// The content is stamped for this download, so it must not be cached
+	c.Header("Content-Disposition", "attachment; filename="+download.FileName)
+	c.Header("Cache-Control", "no-store")
+	c.DataFromReader(http.StatusOK, download.Size, contentType, download.Content, nil)
+}
+
+// DescribeShareLink handles unauthenticated requests for what the page of a share link shows: the
+// branding of the tenant and the document to download
+func (h *ShareLinkHandler) DescribeShareLink(c *gin.Context) {
+	page, err := h.shareLinkUseCase.DescribeShareLink(c.Request.Context(), c.Param("token"), c.GetHeader(shareLinkPasswordHeader))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	// The page must not be cached, since the link can be revoked or run out at any time
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.ToShareLinkPageDTO(page.Branding, page.PasswordRequired, page.FileName, page.ContentType, page.Size, page.ExpiresAt)))
+}
+
+// handleError handles errors and returns appropriate HTTP responses
+func (h *ShareLinkHandler) handleError(c *gin.Context, err error) {
+	if errors.IsValidationError(err) {
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			err,
+			map[string]string{},
+		))
+		return
+	}
+
+	if errors.IsResourceNotFoundError(err) {
+		c.JSON(http.StatusNotFound, dto.NewResourceNotFoundErrorResponse(c.Request.Context(), err))
+		return
+	}
+
+	if errors.IsAuthenticationError(err) {
+		c.JSON(http.StatusUnauthorized, dto.NewAuthenticationErrorResponse(c.Request.Context(), err))
+		return
+	}
+
+	if errors.IsAuthorizationError(err) {
+		c.JSON(http.StatusForbidden, dto.NewAuthorizationErrorResponse(c.Request.Context(), err))
+		return
+	}
+
+	// Default to internal server error
+	logger.WithError(err).Error("internal server error")
+	c.JSON(http.StatusInternalServerError, dto.NewInternalErrorResponse(c.Request.Context(), err))
+}
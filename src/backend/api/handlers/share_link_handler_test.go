@@ -0,0 +1,245 @@
+package handlers
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"../../application/usecases"
+	"../../domain/models"
+	apperrors "../../pkg/errors"
+	"../../pkg/i18n"
+)
+
+// MockShareLinkUseCase is a mock implementation of the ShareLinkUseCase interface
+type MockShareLinkUseCase struct {
+	mock.Mock
+}
+
+func (m *MockShareLinkUseCase) CreateShareLink(ctx context.Context, documentID, tenantID, userID string, expiresIn time.Duration, maxDownloads int, password string, watermark bool) (*models.ShareLink, string, error) {
+	args := m.Called(ctx, documentID, tenantID, userID, expiresIn, maxDownloads, password, watermark)
+	if args.Get(0) == nil {
+		return nil, "", args.Error(2)
+	}
+	return args.Get(0).(*models.ShareLink), args.String(1), args.Error(2)
+}
+
+func (m *MockShareLinkUseCase) ListShareLinks(ctx context.Context, documentID, tenantID, userID string) ([]*models.ShareLink, error) {
+	args := m.Called(ctx, documentID, tenantID, userID)
+	return args.Get(0).([]*models.ShareLink), args.Error(1)
+}
+
+func (m *MockShareLinkUseCase) RevokeShareLink(ctx context.Context, id, tenantID, userID string) error {
+	args := m.Called(ctx, id, tenantID, userID)
+	return args.Error(0)
+}
+
+func (m *MockShareLinkUseCase) AccessShareLink(ctx context.Context, token, password string) (*usecases.ShareLinkDownload, error) {
+	args := m.Called(ctx, token, password)
+	if download := args.Get(0); download != nil {
+		return download.(*usecases.ShareLinkDownload), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockShareLinkUseCase) DescribeShareLink(ctx context.Context, token, password string) (*usecases.ShareLinkPage, error) {
+	args := m.Called(ctx, token, password)
+	if page := args.Get(0); page != nil {
+		return page.(*usecases.ShareLinkPage), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// ShareLinkHandlerSuite defines the test suite
+type ShareLinkHandlerSuite struct {
+	suite.Suite
+	router           *gin.Engine
+	recorder         *httptest.ResponseRecorder
+	shareLinkUseCase *MockShareLinkUseCase
+	shareLinkHandler *ShareLinkHandler
+}
+
+// SetupTest is called before each test
+func (s *ShareLinkHandlerSuite) SetupTest() {
+	// Create a gin router in test mode
+	gin.SetMode(gin.TestMode)
+	s.router = gin.New()
+	s.recorder = httptest.NewRecorder()
+
+	// Create the share link handler with a mock use case
+	s.shareLinkUseCase = new(MockShareLinkUseCase)
+	handler, err := NewShareLinkHandler(s.shareLinkUseCase, "https://dms.example.com/")
+	s.Require().NoError(err)
+	s.shareLinkHandler = handler
+
+	// Set up a router group with an authenticated tenant and the share link handler routes
+	group := s.router.Group("/api/v1")
+	group.Use(func(c *gin.Context) {
+		c.Set("tenant_id", "tenant-123")
+		c.Set("user_id", "user-123")
+		c.Next()
+	})
+	s.shareLinkHandler.RegisterRoutes(group)
+
+	// The download route is served without authentication
+	s.shareLinkHandler.RegisterPublicRoutes(s.router.Group(""))
+}
+
+// Helper function to create a test share link model
+func (s *ShareLinkHandlerSuite) createTestShareLink() *models.ShareLink {
+	return &models.ShareLink{
+		ID:           "link-123",
+		TenantID:     "tenant-123",
+		DocumentID:   "doc-123",
+		ExpiresAt:    time.Now().Add(24 * time.Hour),
+		MaxDownloads: 5,
+		CreatedBy:    "user-123",
+		CreatedAt:    time.Now(),
+	}
+}
+
+// TestCreateShareLink_Success tests that the link URL is returned on creation
+func (s *ShareLinkHandlerSuite) TestCreateShareLink_Success() {
+	s.shareLinkUseCase.On("CreateShareLink", mock.Anything, "doc-123", "tenant-123", "user-123", 24*time.Hour, 5, "", false).
+		Return(s.createTestShareLink(), "shl_abc", nil)
+
+	body := `{"expires_in_hours":24,"max_downloads":5}`
+	req, _ := http.NewRequest("POST", "/api/v1/documents/doc-123/share-links", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusCreated, s.recorder.Code)
+	s.Contains(s.recorder.Body.String(), `"url":"https://dms.example.com/share/shl_abc"`)
+	s.Contains(s.recorder.Body.String(), `"active":true`)
+	s.shareLinkUseCase.AssertExpectations(s.T())
+}
+
+// TestCreateShareLink_MissingExpiry tests that a link without an expiry is left to the tenant's default
+func (s *ShareLinkHandlerSuite) TestCreateShareLink_MissingExpiry() {
+	s.shareLinkUseCase.On("CreateShareLink", mock.Anything, "doc-123", "tenant-123", "user-123", time.Duration(0), 5, "", false).
+		Return(s.createTestShareLink(), "shl_abc", nil)
+
+	req, _ := http.NewRequest("POST", "/api/v1/documents/doc-123/share-links", strings.NewReader(`{"max_downloads":5}`))
+	req.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusCreated, s.recorder.Code)
+	s.shareLinkUseCase.AssertExpectations(s.T())
+}
+
+// TestCreateShareLink_ExpiryTooLong tests creating a link outliving the maximum expiry
+func (s *ShareLinkHandlerSuite) TestCreateShareLink_ExpiryTooLong() {
+	req, _ := http.NewRequest("POST", "/api/v1/documents/doc-123/share-links", strings.NewReader(`{"expires_in_hours":2161}`))
+	req.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusBadRequest, s.recorder.Code)
+	s.shareLinkUseCase.AssertNotCalled(s.T(), "CreateShareLink")
+}
+
+// TestAccessShareLink_Redirect tests that a valid link redirects to the presigned download URL
+func (s *ShareLinkHandlerSuite) TestAccessShareLink_Redirect() {
+	s.shareLinkUseCase.On("AccessShareLink", mock.Anything, "shl_abc", "s3cret-pass").Return(&usecases.ShareLinkDownload{URL: "https://s3.example.com/presigned"}, nil)
+
+	req, _ := http.NewRequest("GET", "/share/shl_abc", nil)
+	req.Header.Set("X-Share-Password", "s3cret-pass")
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusFound, s.recorder.Code)
+	s.Equal("https://s3.example.com/presigned", s.recorder.Header().Get("Location"))
+	s.shareLinkUseCase.AssertExpectations(s.T())
+}
+
+// TestAccessShareLink_Watermarked tests that watermarked downloads are streamed instead of redirected
+func (s *ShareLinkHandlerSuite) TestAccessShareLink_Watermarked() {
+	s.shareLinkUseCase.On("AccessShareLink", mock.Anything, "shl_abc", "").Return(&usecases.ShareLinkDownload{
+		Content:     io.NopCloser(strings.NewReader("%PDF-stamped")),
+		FileName:    "contract.pdf",
+		ContentType: "application/pdf",
+		Size:        12,
+	}, nil)
+
+	req, _ := http.NewRequest("GET", "/share/shl_abc", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusOK, s.recorder.Code)
+	s.Equal("%PDF-stamped", s.recorder.Body.String())
+	s.Equal("application/pdf", s.recorder.Header().Get("Content-Type"))
+	s.Equal("no-store", s.recorder.Header().Get("Cache-Control"))
+	s.Empty(s.recorder.Header().Get("Location"))
+}
+
+// TestAccessShareLink_WrongPassword tests a protected link requested with a wrong password
+func (s *ShareLinkHandlerSuite) TestAccessShareLink_WrongPassword() {
+	s.shareLinkUseCase.On("AccessShareLink", mock.Anything, "shl_abc", "").
+		Return(nil, apperrors.NewAuthenticationError("share link password is missing or incorrect"))
+
+	req, _ := http.NewRequest("GET", "/share/shl_abc", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusUnauthorized, s.recorder.Code)
+	s.shareLinkUseCase.AssertExpectations(s.T())
+}
+
+// TestAccessShareLink_Unavailable tests that a missing link is reported with its code, in the
+// language of the request
+func (s *ShareLinkHandlerSuite) TestAccessShareLink_Unavailable() {
+	s.shareLinkUseCase.On("AccessShareLink", mock.Anything, "shl_abc", "").
+		Return(nil, usecases.ErrShareLinkUnavailable)
+
+	req, _ := http.NewRequestWithContext(i18n.ContextWithLanguage(context.Background(), "es"), "GET", "/share/shl_abc", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusNotFound, s.recorder.Code)
+	s.Contains(s.recorder.Body.String(), `"type":"/api/v1/errors/share_link_unavailable"`)
+	s.Contains(s.recorder.Body.String(), `"title":"No encontrado"`)
+	s.Contains(s.recorder.Body.String(), `"code":"share_link_unavailable"`)
+	s.Contains(s.recorder.Body.String(), `"detail":"Este enlace compartido no existe o ya no está disponible."`)
+	s.shareLinkUseCase.AssertExpectations(s.T())
+}
+
+// TestDescribeShareLink tests that the page of a link shows the tenant's branding and the document
+func (s *ShareLinkHandlerSuite) TestDescribeShareLink() {
+	s.shareLinkUseCase.On("DescribeShareLink", mock.Anything, "shl_abc", "").Return(&usecases.ShareLinkPage{
+		Branding:    models.TenantPreferences{TenantName: "Acme", LogoURL: "https://acme.example/logo.png", Language: "de"},
+		FileName:    "contract.pdf",
+		ContentType: "application/pdf",
+		Size:        2048,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}, nil)
+
+	req, _ := http.NewRequest("GET", "/share/shl_abc/page", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusOK, s.recorder.Code)
+	s.Equal("no-store", s.recorder.Header().Get("Cache-Control"))
+	s.Contains(s.recorder.Body.String(), `"logo_url":"https://acme.example/logo.png"`)
+	s.Contains(s.recorder.Body.String(), `"language":"de"`)
+	s.Contains(s.recorder.Body.String(), `"file_name":"contract.pdf"`)
+	s.shareLinkUseCase.AssertNotCalled(s.T(), "AccessShareLink", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestRevokeShareLink_Forbidden tests revoking another user's link without admin access
+func (s *ShareLinkHandlerSuite) TestRevokeShareLink_Forbidden() {
+	s.shareLinkUseCase.On("RevokeShareLink", mock.Anything, "link-123", "tenant-123", "user-123").
+		Return(apperrors.NewAuthorizationError("only the link creator or a document administrator can revoke a share link"))
+
+	req, _ := http.NewRequest("DELETE", "/api/v1/share-links/link-123", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusForbidden, s.recorder.Code)
+	s.shareLinkUseCase.AssertExpectations(s.T())
+}
+
+// TestShareLinkHandlerSuite runs the test suite
+func TestShareLinkHandlerSuite(t *testing.T) {
+	suite.Run(t, new(ShareLinkHandlerSuite))
+}
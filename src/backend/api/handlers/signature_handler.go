@@ -0,0 +1,285 @@
+// Package handlers implements HTTP handlers for electronic signatures in the Document Management Platform.
+package handlers
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin" // v1.9.0+
+
+	"../../application/usecases"
+	"../../pkg/errors"
+	"../../pkg/logger"
+	"../dto"
+	"../middleware"
+	"../validators"
+)
+
+// maxSignatureCallbackSize limits the status updates read from signature providers
+const maxSignatureCallbackSize = 1024 * 1024
+
+// SignatureHandler handles HTTP requests for the signature requests of documents and the status
+// updates signature providers post back
+type SignatureHandler struct {
+	signatureUseCase usecases.SignatureUseCase
+}
+
+// NewSignatureHandler creates a new SignatureHandler instance
+func NewSignatureHandler(signatureUseCase usecases.SignatureUseCase) (*SignatureHandler, error) {
+	if signatureUseCase == nil {
+		return nil, errors.NewValidationError("signature use case cannot be nil")
+	}
+
+	return &SignatureHandler{
+		signatureUseCase: signatureUseCase,
+	}, nil
+}
+
+// RegisterRoutes registers the authenticated signature routes with the provided router group
+func (h *SignatureHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/signature-providers", h.ListProviders)
+	router.POST("/documents/:id/signature-requests", h.RequestSignature)
+	router.GET("/documents/:id/signature-requests", h.ListDocumentRequests)
+	router.GET("/signature-requests/:id", h.GetRequest)
+	router.POST("/signature-requests/:id/cancel", h.CancelRequest)
+}
+
+// RegisterCallbackRoutes registers the unauthenticated routes providers post status updates to;
+// each provider verifies its own updates
+func (h *SignatureHandler) RegisterCallbackRoutes(router *gin.RouterGroup) {
+	router.POST("/signature-callbacks/:provider", h.HandleCallback)
+	router.GET("/signature-callbacks/:provider", h.HandleCallback)
+}
+
+// ListProviders handles requests to list the signature providers documents can be sent with
+func (h *SignatureHandler) ListProviders(c *gin.Context) {
+	if _, _, ok := h.getUserParams(c); !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDataResponse(h.signatureUseCase.ListProviders()))
+}
+
+// RequestSignature handles requests to send the latest version of a document for signature
+func (h *SignatureHandler) RequestSignature(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	tenantID, userID, documentID, ok := h.getIDParams(c, "document")
+	if !ok {
+		return
+	}
+
+	var req dto.SignatureRequestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.WithError(err).Error("failed to bind request body")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			validators.BindingError(err),
+			nil,
+		))
+		return
+	}
+
+	// Call use case to send the document to the provider
+	request, err := h.signatureUseCase.RequestSignature(c.Request.Context(), documentID, req.Provider, req.Subject, req.Message, dto.ToSignersDomain(req.Signers), tenantID, userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.NewDataResponse(dto.ToSignatureRequestDTO(request)))
+}
+
+// ListDocumentRequests handles requests to list the signature requests of a document, newest first
+func (h *SignatureHandler) ListDocumentRequests(c *gin.Context) {
+	tenantID, userID, documentID, ok := h.getIDParams(c, "document")
+	if !ok {
+		return
+	}
+
+	page, pageSize := h.getPaginationParams(c)
+
+	// Call use case to list the document's requests
+	result, err := h.signatureUseCase.ListDocumentRequests(c.Request.Context(), documentID, tenantID, userID, page, pageSize)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewPaginatedResponse(dto.ToSignatureRequestListDTO(result.Items), result.Pagination))
+}
+
+// GetRequest handles signature request retrieval requests
+func (h *SignatureHandler) GetRequest(c *gin.Context) {
+	tenantID, userID, requestID, ok := h.getIDParams(c, "signature request")
+	if !ok {
+		return
+	}
+
+	// Call use case to get the request
+	request, err := h.signatureUseCase.GetRequest(c.Request.Context(), requestID, tenantID, userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.ToSignatureRequestDTO(request)))
+}
+
+// CancelRequest handles requests to void the envelope of a signature request that is out for signature
+func (h *SignatureHandler) CancelRequest(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	tenantID, userID, requestID, ok := h.getIDParams(c, "signature request")
+	if !ok {
+		return
+	}
+
+	// The reason is optional, so an empty body is accepted
+	var req dto.CancelSignatureRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			log.WithError(err).Error("failed to bind request body")
+			c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+				c.Request.Context(),
+				validators.BindingError(err),
+				nil,
+			))
+			return
+		}
+	}
+
+	// Call use case to cancel the request
+	request, err := h.signatureUseCase.CancelRequest(c.Request.Context(), requestID, req.Reason, tenantID, userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.ToSignatureRequestDTO(request)))
+}
+
+// HandleCallback handles the status updates a provider posts for its envelopes. The body is passed
+// to the provider unparsed, since signatures are computed over the raw body.
+func (h *SignatureHandler) HandleCallback(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	body, err := ioutil.ReadAll(io.LimitReader(c.Request.Body, maxSignatureCallbackSize))
+	if err != nil {
+		log.WithError(err).Error("failed to read signature callback body")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			errors.NewValidationError("invalid request body"),
+			map[string]string{},
+		))
+		return
+	}
+
+	// Call use case to verify and apply the update
+	headers, err := h.signatureUseCase.HandleProviderCallback(c.Request.Context(), c.Param("provider"), c.Request.Header, body)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	for name, value := range headers {
+		c.Header(name, value)
+	}
+	c.Status(http.StatusOK)
+}
+
+// getUserParams extracts the tenant and user IDs from the request context
+func (h *SignatureHandler) getUserParams(c *gin.Context) (string, string, bool) {
+	tenantID := middleware.GetTenantID(c)
+	userID := middleware.GetUserID(c)
+	if tenantID == "" || userID == "" {
+		logger.WithContext(c.Request.Context()).Error("tenant or user ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrUserContextRequired,
+		))
+		return "", "", false
+	}
+
+	return tenantID, userID, true
+}
+
+// getIDParams extracts the tenant and user IDs from the request context and the ID of the named
+// resource from the request path
+func (h *SignatureHandler) getIDParams(c *gin.Context, resource string) (string, string, string, bool) {
+	tenantID, userID, ok := h.getUserParams(c)
+	if !ok {
+		return "", "", "", false
+	}
+
+	id := c.Param("id")
+	if id == "" {
+		logger.WithContext(c.Request.Context()).Error(resource + " ID missing in request path")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			errors.NewValidationError(resource+" ID is required"),
+			map[string]string{"id": "required"},
+		))
+		return "", "", "", false
+	}
+
+	return tenantID, userID, id, true
+}
+
+// getPaginationParams extracts and validates pagination parameters from the request
+func (h *SignatureHandler) getPaginationParams(c *gin.Context) (int, int) {
+	page := 1
+	if p, err := strconv.Atoi(c.Query("page")); err == nil && p > 0 {
+		page = p
+	}
+
+	pageSize := defaultPageSize
+	if ps, err := strconv.Atoi(c.Query("pageSize")); err == nil && ps > 0 {
+		pageSize = ps
+	}
+
+	// Cap pageSize at maximum
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	return page, pageSize
+}
+
+// handleError handles errors and returns appropriate HTTP responses
+func (h *SignatureHandler) handleError(c *gin.Context, err error) {
+	if errors.IsValidationError(err) {
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			err,
+			map[string]string{},
+		))
+		return
+	}
+
+	if errors.IsResourceNotFoundError(err) {
+		c.JSON(http.StatusNotFound, dto.NewResourceNotFoundErrorResponse(c.Request.Context(), err))
+		return
+	}
+
+	if errors.IsAuthenticationError(err) {
+		c.JSON(http.StatusUnauthorized, dto.NewAuthenticationErrorResponse(c.Request.Context(), err))
+		return
+	}
+
+	if errors.IsAuthorizationError(err) {
+		c.JSON(http.StatusForbidden, dto.NewAuthorizationErrorResponse(c.Request.Context(), err))
+		return
+	}
+
+	if errors.IsDependencyError(err) {
+		c.JSON(http.StatusServiceUnavailable, dto.NewDependencyErrorResponse(c.Request.Context(), err))
+		return
+	}
+
+	// Default to internal server error
+	logger.WithError(err).Error("internal server error")
+	c.JSON(http.StatusInternalServerError, dto.NewInternalErrorResponse(c.Request.Context(), err))
+}
@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"../../domain/models"
+	apperrors "../../pkg/errors"
+	"../../pkg/utils"
+)
+
+// MockSignatureUseCase is a mock implementation of the SignatureUseCase interface
+type MockSignatureUseCase struct {
+	mock.Mock
+}
+
+func (m *MockSignatureUseCase) ListProviders() []string {
+	args := m.Called()
+	return args.Get(0).([]string)
+}
+
+func (m *MockSignatureUseCase) RequestSignature(ctx context.Context, documentID, provider, subject, message string, signers []models.Signer, tenantID, userID string) (*models.SignatureRequest, error) {
+	args := m.Called(ctx, documentID, provider, subject, message, signers, tenantID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SignatureRequest), args.Error(1)
+}
+
+func (m *MockSignatureUseCase) GetRequest(ctx context.Context, id, tenantID, userID string) (*models.SignatureRequest, error) {
+	args := m.Called(ctx, id, tenantID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SignatureRequest), args.Error(1)
+}
+
+func (m *MockSignatureUseCase) ListDocumentRequests(ctx context.Context, documentID, tenantID, userID string, page int, pageSize int) (utils.PaginatedResult[models.SignatureRequest], error) {
+	args := m.Called(ctx, documentID, tenantID, userID, page, pageSize)
+	return args.Get(0).(utils.PaginatedResult[models.SignatureRequest]), args.Error(1)
+}
+
+func (m *MockSignatureUseCase) CancelRequest(ctx context.Context, id, reason, tenantID, userID string) (*models.SignatureRequest, error) {
+	args := m.Called(ctx, id, reason, tenantID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SignatureRequest), args.Error(1)
+}
+
+func (m *MockSignatureUseCase) HandleProviderCallback(ctx context.Context, provider string, header http.Header, body []byte) (map[string]string, error) {
+	args := m.Called(ctx, provider, header, body)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]string), args.Error(1)
+}
+
+// SignatureHandlerSuite defines the test suite
+type SignatureHandlerSuite struct {
+	suite.Suite
+	router           *gin.Engine
+	recorder         *httptest.ResponseRecorder
+	signatureUseCase *MockSignatureUseCase
+	signatureHandler *SignatureHandler
+}
+
+// SetupTest is called before each test
+func (s *SignatureHandlerSuite) SetupTest() {
+	// Create a gin router in test mode
+	gin.SetMode(gin.TestMode)
+	s.router = gin.New()
+	s.recorder = httptest.NewRecorder()
+
+	// Create the signature handler with a mock use case
+	s.signatureUseCase = new(MockSignatureUseCase)
+	handler, err := NewSignatureHandler(s.signatureUseCase)
+	s.Require().NoError(err)
+	s.signatureHandler = handler
+
+	// Set up the unauthenticated callback routes and a router group with an authenticated user
+	s.signatureHandler.RegisterCallbackRoutes(s.router.Group("/api/v1"))
+	group := s.router.Group("/api/v1")
+	group.Use(func(c *gin.Context) {
+		c.Set("tenant_id", "tenant-123")
+		c.Set("user_id", "user-123")
+		c.Next()
+	})
+	s.signatureHandler.RegisterRoutes(group)
+}
+
+// sentRequest returns request-123 of doc-123, out for signature with envelope env-123
+func (s *SignatureHandlerSuite) sentRequest() *models.SignatureRequest {
+	request := models.NewSignatureRequest("tenant-123", "doc-123", models.SignatureProviderDocuSign, "Contract", "",
+		[]models.Signer{{Name: "Jane", Email: "jane@example.com"}}, "user-123")
+	request.ID = "request-123"
+	request.MarkSent("env-123", request.CreatedAt)
+	return request
+}
+
+// TestRequestSignature_Success tests sending a document for signature
+func (s *SignatureHandlerSuite) TestRequestSignature_Success() {
+	s.signatureUseCase.On("RequestSignature", mock.Anything, "doc-123", models.SignatureProviderDocuSign, "Contract", "", mock.MatchedBy(func(signers []models.Signer) bool {
+		return len(signers) == 1 && signers[0].Email == "jane@example.com" && signers[0].RoutingOrder == 2
+	}), "tenant-123", "user-123").Return(s.sentRequest(), nil)
+
+	body := `{"provider":"docusign","subject":"Contract","signers":[{"name":"Jane","email":"jane@example.com","routing_order":2}]}`
+	req, _ := http.NewRequest("POST", "/api/v1/documents/doc-123/signature-requests", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusCreated, s.recorder.Code)
+	s.Contains(s.recorder.Body.String(), `"status":"sent"`)
+	s.Contains(s.recorder.Body.String(), `"envelope_id":"env-123"`)
+	s.signatureUseCase.AssertExpectations(s.T())
+}
+
+// TestCancelRequest_EmptyBody tests cancelling a request without a reason
+func (s *SignatureHandlerSuite) TestCancelRequest_EmptyBody() {
+	request := s.sentRequest()
+	s.Require().NoError(request.Void("", request.CreatedAt))
+	s.signatureUseCase.On("CancelRequest", mock.Anything, "request-123", "", "tenant-123", "user-123").Return(request, nil)
+
+	req, _ := http.NewRequest("POST", "/api/v1/signature-requests/request-123/cancel", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusOK, s.recorder.Code)
+	s.Contains(s.recorder.Body.String(), `"status":"voided"`)
+}
+
+// TestHandleCallback_Success tests that the raw body is passed on and the response headers set
+func (s *SignatureHandlerSuite) TestHandleCallback_Success() {
+	s.signatureUseCase.On("HandleProviderCallback", mock.Anything, models.SignatureProviderAdobeSign, mock.Anything, []byte(`{"event":"AGREEMENT_WORKFLOW_COMPLETED"}`)).
+		Return(map[string]string{"X-AdobeSign-ClientId": "client-123"}, nil)
+
+	req, _ := http.NewRequest("POST", "/api/v1/signature-callbacks/adobesign", bytes.NewBufferString(`{"event":"AGREEMENT_WORKFLOW_COMPLETED"}`))
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusOK, s.recorder.Code)
+	s.Equal("client-123", s.recorder.Header().Get("X-AdobeSign-ClientId"))
+}
+
+// TestHandleCallback_Unverified tests that updates the provider cannot verify are rejected
+func (s *SignatureHandlerSuite) TestHandleCallback_Unverified() {
+	s.signatureUseCase.On("HandleProviderCallback", mock.Anything, models.SignatureProviderDocuSign, mock.Anything, mock.Anything).
+		Return(nil, apperrors.NewAuthenticationError("invalid DocuSign Connect signature"))
+
+	req, _ := http.NewRequest("POST", "/api/v1/signature-callbacks/docusign", bytes.NewBufferString(`{}`))
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusUnauthorized, s.recorder.Code)
+}
+
+// TestSignatureHandlerSuite runs the test suite
+func TestSignatureHandlerSuite(t *testing.T) {
+	suite.Run(t, new(SignatureHandlerSuite))
+}
@@ -0,0 +1,93 @@
+// Package handlers implements HTTP handlers for single sign-on in the Document Management Platform.
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin" // v1.9.0+
+
+	"../dto"
+	"../validators"
+	"../../application/usecases"
+	"../../pkg/errors"
+	"../../pkg/logger"
+)
+
+// SSOHandler handles HTTP requests for signing in with an external identity provider
+type SSOHandler struct {
+	ssoUseCase usecases.SSOUseCase
+}
+
+// NewSSOHandler creates a new SSOHandler instance
+func NewSSOHandler(ssoUseCase usecases.SSOUseCase) (*SSOHandler, error) {
+	if ssoUseCase == nil {
+		return nil, errors.NewValidationError("SSO use case cannot be nil")
+	}
+
+	return &SSOHandler{
+		ssoUseCase: ssoUseCase,
+	}, nil
+}
+
+// RegisterRoutes registers the unauthenticated SSO routes with the provided router group
+func (h *SSOHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.POST("/sso/token", h.ExchangeToken)
+}
+
+// ExchangeToken handles requests to exchange an IdP ID token for platform tokens
+func (h *SSOHandler) ExchangeToken(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Bind request body to DTO
+	var req dto.SSOTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.WithError(err).Error("failed to bind request body")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			validators.BindingError(err),
+			nil,
+		))
+		return
+	}
+
+	// Call use case to verify the ID token and issue platform tokens
+	result, err := h.ssoUseCase.LoginWithSSO(c.Request.Context(), req.TenantID, req.IDToken)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	// Users who have to verify a second factor only get a partial-auth token
+	if result.MFARequired {
+		c.JSON(http.StatusOK, dto.NewDataResponse(dto.NewSSOMFAChallengeDTO(result.MFAToken, result.MFAEnrollmentRequired)))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.NewSSOTokenDTO(result.AccessToken, result.RefreshToken)))
+}
+
+// handleError handles errors and returns appropriate HTTP responses
+func (h *SSOHandler) handleError(c *gin.Context, err error) {
+	if errors.IsValidationError(err) {
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			err,
+			map[string]string{},
+		))
+		return
+	}
+
+	if errors.IsAuthenticationError(err) {
+		c.JSON(http.StatusUnauthorized, dto.NewAuthenticationErrorResponse(c.Request.Context(), err))
+		return
+	}
+
+	if errors.IsDependencyError(err) {
+		c.JSON(http.StatusServiceUnavailable, dto.NewDependencyErrorResponse(c.Request.Context(), err))
+		return
+	}
+
+	// Default to internal server error
+	logger.WithError(err).Error("internal server error")
+	c.JSON(http.StatusInternalServerError, dto.NewInternalErrorResponse(c.Request.Context(), err))
+}
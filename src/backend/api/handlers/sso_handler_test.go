@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"../../application/usecases"
+	apperrors "../../pkg/errors"
+)
+
+// MockSSOUseCase is a mock implementation of the SSOUseCase interface
+type MockSSOUseCase struct {
+	mock.Mock
+}
+
+func (m *MockSSOUseCase) LoginWithSSO(ctx context.Context, tenantID, idToken string) (*usecases.LoginResult, error) {
+	args := m.Called(ctx, tenantID, idToken)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecases.LoginResult), args.Error(1)
+}
+
+// SSOHandlerSuite defines the test suite
+type SSOHandlerSuite struct {
+	suite.Suite
+	router     *gin.Engine
+	recorder   *httptest.ResponseRecorder
+	ssoUseCase *MockSSOUseCase
+}
+
+// SetupTest is called before each test
+func (s *SSOHandlerSuite) SetupTest() {
+	// Create a gin router in test mode
+	gin.SetMode(gin.TestMode)
+	s.router = gin.New()
+	s.recorder = httptest.NewRecorder()
+
+	// Create the SSO handler with a mock use case; its routes are unauthenticated
+	s.ssoUseCase = new(MockSSOUseCase)
+	handler, err := NewSSOHandler(s.ssoUseCase)
+	s.Require().NoError(err)
+	handler.RegisterRoutes(s.router.Group("/api/v1"))
+}
+
+// TestExchangeToken_Success tests that a verified ID token is exchanged for platform tokens
+func (s *SSOHandlerSuite) TestExchangeToken_Success() {
+	s.ssoUseCase.On("LoginWithSSO", mock.Anything, "tenant-123", "id-token").Return(&usecases.LoginResult{AccessToken: "access-token", RefreshToken: "refresh-token"}, nil)
+
+	body := `{"tenant_id":"tenant-123","id_token":"id-token"}`
+	req, _ := http.NewRequest("POST", "/api/v1/sso/token", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusOK, s.recorder.Code)
+	s.Contains(s.recorder.Body.String(), `"access_token":"access-token"`)
+	s.Contains(s.recorder.Body.String(), `"refresh_token":"refresh-token"`)
+	s.Contains(s.recorder.Body.String(), `"token_type":"Bearer"`)
+}
+
+// TestExchangeToken_MFARequired tests that users who have to verify a second factor only get an MFA token
+func (s *SSOHandlerSuite) TestExchangeToken_MFARequired() {
+	s.ssoUseCase.On("LoginWithSSO", mock.Anything, "tenant-123", "id-token").
+		Return(&usecases.LoginResult{MFAToken: "mfa-token", MFARequired: true}, nil)
+
+	body := `{"tenant_id":"tenant-123","id_token":"id-token"}`
+	req, _ := http.NewRequest("POST", "/api/v1/sso/token", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusOK, s.recorder.Code)
+	s.Contains(s.recorder.Body.String(), `"mfa_token":"mfa-token"`)
+	s.Contains(s.recorder.Body.String(), `"mfa_required":true`)
+	s.NotContains(s.recorder.Body.String(), `"access_token"`)
+}
+
+// TestExchangeToken_MissingToken tests that requests without an ID token are rejected
+func (s *SSOHandlerSuite) TestExchangeToken_MissingToken() {
+	req, _ := http.NewRequest("POST", "/api/v1/sso/token", strings.NewReader(`{"tenant_id":"tenant-123"}`))
+	req.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusBadRequest, s.recorder.Code)
+	s.ssoUseCase.AssertNotCalled(s.T(), "LoginWithSSO")
+}
+
+// TestExchangeToken_InvalidToken tests that ID tokens rejected by the use case return 401
+func (s *SSOHandlerSuite) TestExchangeToken_InvalidToken() {
+	s.ssoUseCase.On("LoginWithSSO", mock.Anything, "tenant-123", "forged").
+		Return(nil, apperrors.NewAuthenticationError("invalid ID token"))
+
+	body := `{"tenant_id":"tenant-123","id_token":"forged"}`
+	req, _ := http.NewRequest("POST", "/api/v1/sso/token", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusUnauthorized, s.recorder.Code)
+}
+
+// TestExchangeToken_IdPUnavailable tests that an unreachable identity provider returns 503
+func (s *SSOHandlerSuite) TestExchangeToken_IdPUnavailable() {
+	s.ssoUseCase.On("LoginWithSSO", mock.Anything, "tenant-123", "id-token").
+		Return(nil, apperrors.NewDependencyError("identity provider is unavailable"))
+
+	body := `{"tenant_id":"tenant-123","id_token":"id-token"}`
+	req, _ := http.NewRequest("POST", "/api/v1/sso/token", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusServiceUnavailable, s.recorder.Code)
+}
+
+// TestSSOHandlerSuite runs the test suite
+func TestSSOHandlerSuite(t *testing.T) {
+	suite.Run(t, new(SSOHandlerSuite))
+}
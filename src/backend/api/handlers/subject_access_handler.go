@@ -0,0 +1,122 @@
+// Package handlers implements HTTP handlers for GDPR subject access exports in the Document Management Platform.
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin" // v1.9.0+
+
+	"../dto"
+	"../middleware"
+	"../../application/usecases"
+	"../../pkg/errors"
+	"../../pkg/logger"
+)
+
+// SubjectAccessHandler handles HTTP requests for administrators to export the personal data of a
+// user of their tenant and to track the export
+type SubjectAccessHandler struct {
+	subjectAccessUseCase usecases.SubjectAccessUseCase
+}
+
+// NewSubjectAccessHandler creates a new SubjectAccessHandler instance
+func NewSubjectAccessHandler(subjectAccessUseCase usecases.SubjectAccessUseCase) (*SubjectAccessHandler, error) {
+	if subjectAccessUseCase == nil {
+		return nil, errors.NewValidationError("subject access use case cannot be nil")
+	}
+
+	return &SubjectAccessHandler{
+		subjectAccessUseCase: subjectAccessUseCase,
+	}, nil
+}
+
+// RegisterRoutes registers the subject access routes with the provided router group
+func (h *SubjectAccessHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.POST("/tenant/users/:id/data-export", h.RequestExport)
+	router.GET("/tenant/data-exports/:id", h.GetExport)
+}
+
+// RequestExport handles requests to export the personal data of a user. The archive is compiled in
+// the background; the response points to the export to poll, and a webhook event is sent once it
+// has finished.
+func (h *SubjectAccessHandler) RequestExport(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Call use case to schedule the export
+	export, err := h.subjectAccessUseCase.RequestExport(c.Request.Context(), c.Param("id"), tenantID, middleware.GetUserID(c))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Header("Location", "/api/v1/tenant/data-exports/"+export.ID)
+	c.JSON(http.StatusAccepted, dto.NewDataResponse(dto.ToSubjectAccessExportDTO(export, "")))
+}
+
+// GetExport handles requests for the status of a subject access export, including the download URL
+// once the archive is ready
+func (h *SubjectAccessHandler) GetExport(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Call use case to get the export
+	export, downloadURL, err := h.subjectAccessUseCase.GetExport(c.Request.Context(), c.Param("id"), tenantID, middleware.GetUserID(c))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.ToSubjectAccessExportDTO(export, downloadURL)))
+}
+
+// handleError handles errors and returns appropriate HTTP responses
+func (h *SubjectAccessHandler) handleError(c *gin.Context, err error) {
+	if errors.IsValidationError(err) {
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			err,
+			map[string]string{},
+		))
+		return
+	}
+
+	if errors.IsResourceNotFoundError(err) {
+		c.JSON(http.StatusNotFound, dto.NewResourceNotFoundErrorResponse(c.Request.Context(), err))
+		return
+	}
+
+	if errors.IsAuthenticationError(err) {
+		c.JSON(http.StatusUnauthorized, dto.NewAuthenticationErrorResponse(c.Request.Context(), err))
+		return
+	}
+
+	if errors.IsAuthorizationError(err) {
+		c.JSON(http.StatusForbidden, dto.NewAuthorizationErrorResponse(c.Request.Context(), err))
+		return
+	}
+
+	// Default to internal server error
+	logger.WithError(err).Error("internal server error")
+	c.JSON(http.StatusInternalServerError, dto.NewInternalErrorResponse(c.Request.Context(), err))
+}
@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"../../domain/models"
+	apperrors "../../pkg/errors"
+)
+
+// MockSubjectAccessUseCase is a mock implementation of the SubjectAccessUseCase interface
+type MockSubjectAccessUseCase struct {
+	mock.Mock
+}
+
+func (m *MockSubjectAccessUseCase) RequestExport(ctx context.Context, subjectID, tenantID, userID string) (*models.SubjectAccessExport, error) {
+	args := m.Called(ctx, subjectID, tenantID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SubjectAccessExport), args.Error(1)
+}
+
+func (m *MockSubjectAccessUseCase) GetExport(ctx context.Context, id, tenantID, userID string) (*models.SubjectAccessExport, string, error) {
+	args := m.Called(ctx, id, tenantID, userID)
+	if args.Get(0) == nil {
+		return nil, "", args.Error(2)
+	}
+	return args.Get(0).(*models.SubjectAccessExport), args.String(1), args.Error(2)
+}
+
+// SubjectAccessHandlerSuite defines the test suite
+type SubjectAccessHandlerSuite struct {
+	suite.Suite
+	router               *gin.Engine
+	recorder             *httptest.ResponseRecorder
+	subjectAccessUseCase *MockSubjectAccessUseCase
+}
+
+// SetupTest is called before each test
+func (s *SubjectAccessHandlerSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	s.router = gin.New()
+	s.recorder = httptest.NewRecorder()
+
+	s.subjectAccessUseCase = new(MockSubjectAccessUseCase)
+	handler, err := NewSubjectAccessHandler(s.subjectAccessUseCase)
+	s.Require().NoError(err)
+
+	group := s.router.Group("/api/v1")
+	group.Use(func(c *gin.Context) {
+		c.Set("tenant_id", "tenant-123")
+		c.Set("user_id", "admin-123")
+		c.Next()
+	})
+	handler.RegisterRoutes(group)
+}
+
+// createTestExport returns an export of user-456's data with the given status
+func (s *SubjectAccessHandlerSuite) createTestExport(status string) *models.SubjectAccessExport {
+	export := models.NewSubjectAccessExport("tenant-123", "user-456", "admin-123")
+	export.ID = "sar-123"
+	export.Status = status
+	return export
+}
+
+// TestRequestExport_Accepted tests that requesting an export returns 202 with the export location
+func (s *SubjectAccessHandlerSuite) TestRequestExport_Accepted() {
+	s.subjectAccessUseCase.On("RequestExport", mock.Anything, "user-456", "tenant-123", "admin-123").
+		Return(s.createTestExport(models.SubjectAccessExportStatusPending), nil)
+
+	req, _ := http.NewRequest("POST", "/api/v1/tenant/users/user-456/data-export", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusAccepted, s.recorder.Code)
+	s.Equal("/api/v1/tenant/data-exports/sar-123", s.recorder.Header().Get("Location"))
+	s.Contains(s.recorder.Body.String(), `"subject_id":"user-456"`)
+	s.subjectAccessUseCase.AssertExpectations(s.T())
+}
+
+// TestRequestExport_UnknownUser tests requesting an export of a user outside the tenant
+func (s *SubjectAccessHandlerSuite) TestRequestExport_UnknownUser() {
+	s.subjectAccessUseCase.On("RequestExport", mock.Anything, "user-404", "tenant-123", "admin-123").
+		Return(nil, apperrors.NewResourceNotFoundError("user not found"))
+
+	req, _ := http.NewRequest("POST", "/api/v1/tenant/users/user-404/data-export", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusNotFound, s.recorder.Code)
+}
+
+// TestGetExport_Completed tests that a completed export includes its counts and download URL
+func (s *SubjectAccessHandlerSuite) TestGetExport_Completed() {
+	export := s.createTestExport(models.SubjectAccessExportStatusCompleted)
+	export.DocumentCount = 2
+	export.AuditEventCount = 7
+	s.subjectAccessUseCase.On("GetExport", mock.Anything, "sar-123", "tenant-123", "admin-123").
+		Return(export, "https://s3.example.com/presigned", nil)
+
+	req, _ := http.NewRequest("GET", "/api/v1/tenant/data-exports/sar-123", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusOK, s.recorder.Code)
+	s.Contains(s.recorder.Body.String(), `"audit_event_count":7`)
+	s.Contains(s.recorder.Body.String(), `"download_url":"https://s3.example.com/presigned"`)
+}
+
+// TestSubjectAccessHandlerSuite runs the test suite
+func TestSubjectAccessHandlerSuite(t *testing.T) {
+	suite.Run(t, new(SubjectAccessHandlerSuite))
+}
@@ -0,0 +1,372 @@
+// Package handlers implements HTTP handlers for tenant self-service administration in the Document Management Platform.
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin" // v1.9.0+
+
+	"../dto"
+	"../middleware"
+	"../validators"
+	"../../application/usecases"
+	"../../pkg/errors"
+	"../../pkg/logger"
+)
+
+// TenantHandler handles HTTP requests from tenant administrators managing their users and settings
+type TenantHandler struct {
+	tenantUseCase usecases.TenantUseCase
+}
+
+// NewTenantHandler creates a new TenantHandler instance
+func NewTenantHandler(tenantUseCase usecases.TenantUseCase) (*TenantHandler, error) {
+	if tenantUseCase == nil {
+		return nil, errors.NewValidationError("tenant use case cannot be nil")
+	}
+
+	return &TenantHandler{
+		tenantUseCase: tenantUseCase,
+	}, nil
+}
+
+// RegisterRoutes registers tenant administration routes with the provided router group
+func (h *TenantHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/tenant/users", h.ListUsers)
+	router.POST("/tenant/users", h.InviteUser)
+	router.POST("/tenant/users/:id/deactivate", h.DeactivateUser)
+	router.POST("/tenant/users/:id/activate", h.ActivateUser)
+	router.POST("/tenant/users/:id/reset-password", h.ResetUserPassword)
+	router.PUT("/tenant/users/:id/roles", h.AssignRoles)
+	router.GET("/tenant/usage", h.GetUsage)
+	router.GET("/tenant/settings", h.GetSettings)
+	router.PATCH("/tenant/settings", h.UpdateSettings)
+	router.GET("/tenant/upload-limits", h.ListUploadLimits)
+	router.PUT("/tenant/upload-limits/:scope/:id", h.SetUploadLimit)
+	router.DELETE("/tenant/upload-limits/:scope/:id", h.DeleteUploadLimit)
+}
+
+// ListUsers handles requests to list the tenant's users
+func (h *TenantHandler) ListUsers(c *gin.Context) {
+	tenantID, ok := h.getTenantID(c)
+	if !ok {
+		return
+	}
+
+	page, pageSize := h.getPaginationParams(c)
+
+	// Call use case to list users
+	result, err := h.tenantUseCase.ListUsers(c.Request.Context(), tenantID, middleware.GetUserID(c), page, pageSize)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	// Convert domain models to DTOs and return
+	users := dto.ToUserListDTO(result)
+	c.JSON(http.StatusOK, dto.NewPaginatedResponse(users, result.Pagination))
+}
+
+// InviteUser handles requests to invite a user to the tenant
+func (h *TenantHandler) InviteUser(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	tenantID, ok := h.getTenantID(c)
+	if !ok {
+		return
+	}
+
+	// Bind request body to DTO
+	var req dto.InviteUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.WithError(err).Error("failed to bind request body")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			validators.BindingError(err),
+			nil,
+		))
+		return
+	}
+
+	// Call use case to invite the user
+	user, password, err := h.tenantUseCase.InviteUser(c.Request.Context(), tenantID, middleware.GetUserID(c), req.Username, req.Email, req.Roles)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.NewDataResponse(dto.InvitedUserDTO{
+		User:              dto.ToUserDTO(user),
+		TemporaryPassword: password,
+	}))
+}
+
+// DeactivateUser handles requests to deactivate a user
+func (h *TenantHandler) DeactivateUser(c *gin.Context) {
+	tenantID, ok := h.getTenantID(c)
+	if !ok {
+		return
+	}
+
+	// Call use case to deactivate the user
+	if err := h.tenantUseCase.DeactivateUser(c.Request.Context(), tenantID, middleware.GetUserID(c), c.Param("id")); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewMessageResponse("user deactivated successfully"))
+}
+
+// ActivateUser handles requests to reactivate a user
+func (h *TenantHandler) ActivateUser(c *gin.Context) {
+	tenantID, ok := h.getTenantID(c)
+	if !ok {
+		return
+	}
+
+	// Call use case to activate the user
+	if err := h.tenantUseCase.ActivateUser(c.Request.Context(), tenantID, middleware.GetUserID(c), c.Param("id")); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewMessageResponse("user activated successfully"))
+}
+
+// ResetUserPassword handles requests to reset a user's password
+func (h *TenantHandler) ResetUserPassword(c *gin.Context) {
+	tenantID, ok := h.getTenantID(c)
+	if !ok {
+		return
+	}
+
+	// Call use case to reset the password
+	password, err := h.tenantUseCase.ResetUserPassword(c.Request.Context(), tenantID, middleware.GetUserID(c), c.Param("id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.TemporaryPasswordDTO{TemporaryPassword: password}))
+}
+
+// AssignRoles handles requests to replace a user's roles
+func (h *TenantHandler) AssignRoles(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	tenantID, ok := h.getTenantID(c)
+	if !ok {
+		return
+	}
+
+	// Bind request body to DTO
+	var req dto.AssignRolesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.WithError(err).Error("failed to bind request body")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			validators.BindingError(err),
+			nil,
+		))
+		return
+	}
+
+	// Call use case to assign the roles
+	user, err := h.tenantUseCase.AssignRoles(c.Request.Context(), tenantID, middleware.GetUserID(c), c.Param("id"), req.Roles)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.ToUserDTO(user)))
+}
+
+// GetUsage handles requests for the tenant's usage
+func (h *TenantHandler) GetUsage(c *gin.Context) {
+	tenantID, ok := h.getTenantID(c)
+	if !ok {
+		return
+	}
+
+	// Call use case to get the usage
+	usage, err := h.tenantUseCase.GetUsage(c.Request.Context(), tenantID, middleware.GetUserID(c))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.ToTenantUsageDTO(usage)))
+}
+
+// GetSettings handles requests for the tenant's settings
+func (h *TenantHandler) GetSettings(c *gin.Context) {
+	tenantID, ok := h.getTenantID(c)
+	if !ok {
+		return
+	}
+
+	// Call use case to get the settings
+	settings, err := h.tenantUseCase.GetSettings(c.Request.Context(), tenantID, middleware.GetUserID(c))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.ToTenantSettingsDTO(settings)))
+}
+
+// UpdateSettings handles requests to change tenant settings
+func (h *TenantHandler) UpdateSettings(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	tenantID, ok := h.getTenantID(c)
+	if !ok {
+		return
+	}
+
+	// Bind request body to DTO
+	var req dto.UpdateTenantSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.WithError(err).Error("failed to bind request body")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			validators.BindingError(err),
+			nil,
+		))
+		return
+	}
+
+	// Call use case to update the settings
+	settings, err := h.tenantUseCase.UpdateSettings(c.Request.Context(), tenantID, middleware.GetUserID(c), req.Settings)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.ToTenantSettingsDTO(settings)))
+}
+
+// ListUploadLimits handles requests to list the upload limits of the tenant's users and roles
+func (h *TenantHandler) ListUploadLimits(c *gin.Context) {
+	tenantID, ok := h.getTenantID(c)
+	if !ok {
+		return
+	}
+
+	// Call use case to list the upload limits
+	limits, err := h.tenantUseCase.ListUploadLimits(c.Request.Context(), tenantID, middleware.GetUserID(c))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.ToUploadLimitListDTO(limits)))
+}
+
+// SetUploadLimit handles requests to set the upload limits of a user or role
+func (h *TenantHandler) SetUploadLimit(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	tenantID, ok := h.getTenantID(c)
+	if !ok {
+		return
+	}
+
+	// Bind request body to DTO
+	var req dto.SetUploadLimitRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.WithError(err).Error("failed to bind request body")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			validators.BindingError(err),
+			nil,
+		))
+		return
+	}
+
+	// Call use case to set the upload limit
+	limit, err := h.tenantUseCase.SetUploadLimit(c.Request.Context(), tenantID, middleware.GetUserID(c), c.Param("scope"), c.Param("id"), req.MaxFileSizeBytes, req.MaxDailyUploadBytes)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.ToUploadLimitDTO(limit)))
+}
+
+// DeleteUploadLimit handles requests to remove the upload limits of a user or role
+func (h *TenantHandler) DeleteUploadLimit(c *gin.Context) {
+	tenantID, ok := h.getTenantID(c)
+	if !ok {
+		return
+	}
+
+	// Call use case to delete the upload limit
+	if err := h.tenantUseCase.DeleteUploadLimit(c.Request.Context(), tenantID, middleware.GetUserID(c), c.Param("scope"), c.Param("id")); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewMessageResponse("upload limit deleted successfully"))
+}
+
+// getTenantID extracts the tenant ID from the request context, responding with an error when it is missing
+func (h *TenantHandler) getTenantID(c *gin.Context) (string, bool) {
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		logger.WithContext(c.Request.Context()).Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return "", false
+	}
+	return tenantID, true
+}
+
+// getPaginationParams extracts and validates pagination parameters from the request
+func (h *TenantHandler) getPaginationParams(c *gin.Context) (int, int) {
+	page := 1
+	if p, err := strconv.Atoi(c.Query("page")); err == nil && p > 0 {
+		page = p
+	}
+
+	pageSize := defaultPageSize
+	if ps, err := strconv.Atoi(c.Query("pageSize")); err == nil && ps > 0 {
+		pageSize = ps
+	}
+
+	// Cap pageSize at maximum
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	return page, pageSize
+}
+
+// handleError handles errors and returns appropriate HTTP responses
+func (h *TenantHandler) handleError(c *gin.Context, err error) {
+	if errors.IsValidationError(err) {
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			err,
+			map[string]string{},
+		))
+		return
+	}
+
+	if errors.IsResourceNotFoundError(err) {
+		c.JSON(http.StatusNotFound, dto.NewResourceNotFoundErrorResponse(c.Request.Context(), err))
+		return
+	}
+
+	if errors.IsAuthorizationError(err) {
+		c.JSON(http.StatusForbidden, dto.NewAuthorizationErrorResponse(c.Request.Context(), err))
+		return
+	}
+
+	// Default to internal server error
+	logger.WithError(err).Error("internal server error")
+	c.JSON(http.StatusInternalServerError, dto.NewInternalErrorResponse(c.Request.Context(), err))
+}
@@ -0,0 +1,303 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"../../domain/models"
+	apperrors "../../pkg/errors"
+	"../../pkg/utils/pagination"
+)
+
+// MockTenantUseCase is a mock implementation of the TenantUseCase interface
+type MockTenantUseCase struct {
+	mock.Mock
+}
+
+func (m *MockTenantUseCase) ListUsers(ctx context.Context, tenantID, actorID string, page, pageSize int) (pagination.PaginatedResult[models.User], error) {
+	args := m.Called(ctx, tenantID, actorID, page, pageSize)
+	return args.Get(0).(pagination.PaginatedResult[models.User]), args.Error(1)
+}
+
+func (m *MockTenantUseCase) InviteUser(ctx context.Context, tenantID, actorID, username, email string, roles []string) (*models.User, string, error) {
+	args := m.Called(ctx, tenantID, actorID, username, email, roles)
+	if args.Get(0) == nil {
+		return nil, args.String(1), args.Error(2)
+	}
+	return args.Get(0).(*models.User), args.String(1), args.Error(2)
+}
+
+func (m *MockTenantUseCase) DeactivateUser(ctx context.Context, tenantID, actorID, userID string) error {
+	args := m.Called(ctx, tenantID, actorID, userID)
+	return args.Error(0)
+}
+
+func (m *MockTenantUseCase) ActivateUser(ctx context.Context, tenantID, actorID, userID string) error {
+	args := m.Called(ctx, tenantID, actorID, userID)
+	return args.Error(0)
+}
+
+func (m *MockTenantUseCase) ResetUserPassword(ctx context.Context, tenantID, actorID, userID string) (string, error) {
+	args := m.Called(ctx, tenantID, actorID, userID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockTenantUseCase) AssignRoles(ctx context.Context, tenantID, actorID, userID string, roles []string) (*models.User, error) {
+	args := m.Called(ctx, tenantID, actorID, userID, roles)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockTenantUseCase) GetUsage(ctx context.Context, tenantID, actorID string) (*models.TenantUsage, error) {
+	args := m.Called(ctx, tenantID, actorID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TenantUsage), args.Error(1)
+}
+
+func (m *MockTenantUseCase) GetSettings(ctx context.Context, tenantID, actorID string) (map[string]string, error) {
+	args := m.Called(ctx, tenantID, actorID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]string), args.Error(1)
+}
+
+func (m *MockTenantUseCase) UpdateSettings(ctx context.Context, tenantID, actorID string, settings map[string]string) (map[string]string, error) {
+	args := m.Called(ctx, tenantID, actorID, settings)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]string), args.Error(1)
+}
+
+func (m *MockTenantUseCase) ListUploadLimits(ctx context.Context, tenantID, actorID string) ([]*models.UploadLimit, error) {
+	args := m.Called(ctx, tenantID, actorID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.UploadLimit), args.Error(1)
+}
+
+func (m *MockTenantUseCase) SetUploadLimit(ctx context.Context, tenantID, actorID, scope, scopeID string, maxFileSizeBytes, maxDailyUploadBytes int64) (*models.UploadLimit, error) {
+	args := m.Called(ctx, tenantID, actorID, scope, scopeID, maxFileSizeBytes, maxDailyUploadBytes)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.UploadLimit), args.Error(1)
+}
+
+func (m *MockTenantUseCase) DeleteUploadLimit(ctx context.Context, tenantID, actorID, scope, scopeID string) error {
+	args := m.Called(ctx, tenantID, actorID, scope, scopeID)
+	return args.Error(0)
+}
+
+// TenantHandlerSuite defines the test suite
+type TenantHandlerSuite struct {
+	suite.Suite
+	router        *gin.Engine
+	recorder      *httptest.ResponseRecorder
+	tenantUseCase *MockTenantUseCase
+}
+
+// SetupTest is called before each test
+func (s *TenantHandlerSuite) SetupTest() {
+	// Create a gin router in test mode
+	gin.SetMode(gin.TestMode)
+	s.router = gin.New()
+	s.recorder = httptest.NewRecorder()
+
+	// Create the tenant handler with a mock use case
+	s.tenantUseCase = new(MockTenantUseCase)
+	handler, err := NewTenantHandler(s.tenantUseCase)
+	s.Require().NoError(err)
+
+	// Set up a router group with an authenticated tenant and the tenant handler routes
+	group := s.router.Group("/api/v1")
+	group.Use(func(c *gin.Context) {
+		c.Set("tenant_id", "tenant-123")
+		c.Set("user_id", "user-123")
+		c.Next()
+	})
+	handler.RegisterRoutes(group)
+}
+
+// Helper function to create a test user model
+func (s *TenantHandlerSuite) createTestUser() *models.User {
+	return &models.User{
+		ID:        "user-456",
+		TenantID:  "tenant-123",
+		Username:  "jane",
+		Email:     "jane@example.com",
+		Status:    models.UserStatusActive,
+		Roles:     []string{models.RoleReader},
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+}
+
+// TestListUsers_Success tests listing the tenant's users
+func (s *TenantHandlerSuite) TestListUsers_Success() {
+	result := pagination.PaginatedResult[models.User]{
+		Items:      []models.User{*s.createTestUser()},
+		Pagination: pagination.PageInfo{Page: 1, PageSize: 20, TotalPages: 1, TotalItems: 1},
+	}
+	s.tenantUseCase.On("ListUsers", mock.Anything, "tenant-123", "user-123", 1, 20).Return(result, nil)
+
+	req, _ := http.NewRequest("GET", "/api/v1/tenant/users", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusOK, s.recorder.Code)
+	s.Contains(s.recorder.Body.String(), `"username":"jane"`)
+	s.NotContains(s.recorder.Body.String(), "password")
+	s.tenantUseCase.AssertExpectations(s.T())
+}
+
+// TestInviteUser_Success tests that the temporary password is returned with the invited user
+func (s *TenantHandlerSuite) TestInviteUser_Success() {
+	s.tenantUseCase.On("InviteUser", mock.Anything, "tenant-123", "user-123", "jane", "jane@example.com", []string{"reader"}).
+		Return(s.createTestUser(), "Tmp-Passw0rd-123", nil)
+
+	body := `{"username":"jane","email":"jane@example.com","roles":["reader"]}`
+	req, _ := http.NewRequest("POST", "/api/v1/tenant/users", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusCreated, s.recorder.Code)
+	s.Contains(s.recorder.Body.String(), `"temporary_password":"Tmp-Passw0rd-123"`)
+	s.tenantUseCase.AssertExpectations(s.T())
+}
+
+// TestInviteUser_InvalidEmail tests that invitations without a valid email are rejected
+func (s *TenantHandlerSuite) TestInviteUser_InvalidEmail() {
+	body := `{"username":"jane","email":"not-an-email"}`
+	req, _ := http.NewRequest("POST", "/api/v1/tenant/users", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusBadRequest, s.recorder.Code)
+	s.tenantUseCase.AssertNotCalled(s.T(), "InviteUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestDeactivateUser_NotAdmin tests that non-administrators are forbidden
+func (s *TenantHandlerSuite) TestDeactivateUser_NotAdmin() {
+	s.tenantUseCase.On("DeactivateUser", mock.Anything, "tenant-123", "user-123", "user-456").
+		Return(apperrors.NewAuthorizationError("only administrators can administer the tenant"))
+
+	req, _ := http.NewRequest("POST", "/api/v1/tenant/users/user-456/deactivate", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusForbidden, s.recorder.Code)
+	s.tenantUseCase.AssertExpectations(s.T())
+}
+
+// TestResetUserPassword_Success tests resetting a user's password
+func (s *TenantHandlerSuite) TestResetUserPassword_Success() {
+	s.tenantUseCase.On("ResetUserPassword", mock.Anything, "tenant-123", "user-123", "user-456").Return("Tmp-Passw0rd-123", nil)
+
+	req, _ := http.NewRequest("POST", "/api/v1/tenant/users/user-456/reset-password", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusOK, s.recorder.Code)
+	s.Contains(s.recorder.Body.String(), `"temporary_password":"Tmp-Passw0rd-123"`)
+	s.tenantUseCase.AssertExpectations(s.T())
+}
+
+// TestAssignRoles_Success tests replacing a user's roles
+func (s *TenantHandlerSuite) TestAssignRoles_Success() {
+	user := s.createTestUser()
+	user.Roles = []string{models.RoleEditor}
+	s.tenantUseCase.On("AssignRoles", mock.Anything, "tenant-123", "user-123", "user-456", []string{"editor"}).Return(user, nil)
+
+	req, _ := http.NewRequest("PUT", "/api/v1/tenant/users/user-456/roles", strings.NewReader(`{"roles":["editor"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusOK, s.recorder.Code)
+	s.Contains(s.recorder.Body.String(), `"roles":["editor"]`)
+	s.tenantUseCase.AssertExpectations(s.T())
+}
+
+// TestGetUsage_Success tests retrieving the tenant's usage
+func (s *TenantHandlerSuite) TestGetUsage_Success() {
+	s.tenantUseCase.On("GetUsage", mock.Anything, "tenant-123", "user-123").
+		Return(&models.TenantUsage{Users: 12, ActiveUsers: 10, Documents: 340, StorageBytes: 1024}, nil)
+
+	req, _ := http.NewRequest("GET", "/api/v1/tenant/usage", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusOK, s.recorder.Code)
+	s.Contains(s.recorder.Body.String(), `"active_users":10`)
+	s.Contains(s.recorder.Body.String(), `"storage_bytes":1024`)
+	s.NotContains(s.recorder.Body.String(), "max_storage_bytes")
+	s.tenantUseCase.AssertExpectations(s.T())
+}
+
+// TestGetUsage_WithQuota tests that the tenant's limits and remaining quota are returned
+func (s *TenantHandlerSuite) TestGetUsage_WithQuota() {
+	s.tenantUseCase.On("GetUsage", mock.Anything, "tenant-123", "user-123").
+		Return(&models.TenantUsage{Users: 12, ActiveUsers: 10, Documents: 340, StorageBytes: 1024, MaxStorageBytes: 4096}, nil)
+
+	req, _ := http.NewRequest("GET", "/api/v1/tenant/usage", nil)
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusOK, s.recorder.Code)
+	s.Contains(s.recorder.Body.String(), `"max_storage_bytes":4096`)
+	s.Contains(s.recorder.Body.String(), `"remaining_storage_bytes":3072`)
+	s.NotContains(s.recorder.Body.String(), "max_documents")
+	s.tenantUseCase.AssertExpectations(s.T())
+}
+
+// TestSetUploadLimit_Success tests setting the upload limits of a user
+func (s *TenantHandlerSuite) TestSetUploadLimit_Success() {
+	limit := models.NewUploadLimit("tenant-123", models.UploadLimitScopeUser, "user-456", 1048576, 0)
+	s.tenantUseCase.On("SetUploadLimit", mock.Anything, "tenant-123", "user-123", "user", "user-456", int64(1048576), int64(0)).Return(limit, nil)
+
+	req, _ := http.NewRequest("PUT", "/api/v1/tenant/upload-limits/user/user-456", strings.NewReader(`{"max_file_size_bytes":1048576}`))
+	req.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusOK, s.recorder.Code)
+	s.Contains(s.recorder.Body.String(), `"max_file_size_bytes":1048576`)
+	s.tenantUseCase.AssertExpectations(s.T())
+}
+
+// TestSetUploadLimit_Negative tests that negative limits are rejected
+func (s *TenantHandlerSuite) TestSetUploadLimit_Negative() {
+	req, _ := http.NewRequest("PUT", "/api/v1/tenant/upload-limits/role/reader", strings.NewReader(`{"max_file_size_bytes":-1}`))
+	req.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusBadRequest, s.recorder.Code)
+	s.tenantUseCase.AssertNotCalled(s.T(), "SetUploadLimit", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestUpdateSettings_Invalid tests that invalid settings are rejected
+func (s *TenantHandlerSuite) TestUpdateSettings_Invalid() {
+	settings := map[string]string{models.TenantSettingMFARequired: "yes"}
+	s.tenantUseCase.On("UpdateSettings", mock.Anything, "tenant-123", "user-123", settings).
+		Return(nil, apperrors.NewValidationError("mfa_required: invalid tenant setting value"))
+
+	req, _ := http.NewRequest("PATCH", "/api/v1/tenant/settings", strings.NewReader(`{"settings":{"mfa_required":"yes"}}`))
+	req.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(s.recorder, req)
+
+	s.Equal(http.StatusBadRequest, s.recorder.Code)
+	s.tenantUseCase.AssertExpectations(s.T())
+}
+
+// TestTenantHandlerSuite runs the test suite
+func TestTenantHandlerSuite(t *testing.T) {
+	suite.Run(t, new(TenantHandlerSuite))
+}
@@ -4,6 +4,7 @@ package handlers
 import (
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin" // v1.9.0+
 
@@ -44,9 +45,20 @@ func (h *WebhookHandler) RegisterRoutes(router *gin.RouterGroup) {
 	router.GET("/webhooks/:id", h.GetWebhook)
 	router.PUT("/webhooks/:id", h.UpdateWebhook)
 	router.DELETE("/webhooks/:id", h.DeleteWebhook)
+	router.POST("/webhooks/:id/rotate-secret", h.RotateWebhookSecret)
+	router.POST("/webhooks/:id/test", h.SendTestDelivery)
+	router.POST("/webhooks/:id/subscriptions", h.CreateSubscription)
+	router.GET("/webhooks/:id/subscriptions", h.ListSubscriptions)
+	router.GET("/webhooks/:id/subscriptions/:subscriptionId", h.GetSubscription)
+	router.PUT("/webhooks/:id/subscriptions/:subscriptionId", h.UpdateSubscription)
+	router.DELETE("/webhooks/:id/subscriptions/:subscriptionId", h.DeleteSubscription)
 	router.GET("/webhooks/event-types", h.GetEventTypes)
 	router.GET("/webhooks/:id/deliveries", h.ListWebhookDeliveries)
+	router.GET("/webhooks/:id/deliveries/:deliveryId", h.GetWebhookDelivery)
+	router.GET("/webhooks/deliveries", h.ListDeliveryLog)
+	router.GET("/webhooks/deliveries/dead-letter", h.ListDeadLetteredDeliveries)
 	router.GET("/webhooks/deliveries/:id", h.GetDeliveryStatus)
+	router.GET("/webhooks/deliveries/:id/attempts", h.ListDeliveryAttempts)
 	router.POST("/webhooks/deliveries/:id/retry", h.RetryDelivery)
 }
 
@@ -59,7 +71,8 @@ func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
 	if tenantID == "" {
 		log.Error("tenant ID missing in request context")
 		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
-			errors.NewAuthenticationError("tenant context required"),
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
 		))
 		return
 	}
@@ -69,8 +82,9 @@ func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
 	if err := c.ShouldBindJSON(&req); err != nil {
 		log.WithError(err).Error("failed to bind request body")
 		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
-			errors.NewValidationError("invalid request format"),
-			map[string]string{"request": err.Error()},
+			c.Request.Context(),
+			validators.BindingError(err),
+			nil,
 		))
 		return
 	}
@@ -93,9 +107,11 @@ func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
 	}
 
 	// Return success response
+	// The signing secret is only returned once, so integrators can configure verification
 	c.JSON(http.StatusCreated, dto.NewDataResponse(map[string]string{
-		"id":      webhookID,
-		"message": "Webhook created successfully",
+		"id":         webhookID,
+		"secret_key": webhook.SecretKey,
+		"message":    "Webhook created successfully",
 	}))
 }
 
@@ -108,7 +124,8 @@ func (h *WebhookHandler) GetWebhook(c *gin.Context) {
 	if tenantID == "" {
 		log.Error("tenant ID missing in request context")
 		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
-			errors.NewAuthenticationError("tenant context required"),
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
 		))
 		return
 	}
@@ -118,6 +135,7 @@ func (h *WebhookHandler) GetWebhook(c *gin.Context) {
 	if webhookID == "" {
 		log.Error("webhook ID missing in request path")
 		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
 			errors.NewValidationError("webhook ID is required"),
 			map[string]string{"id": "required"},
 		))
@@ -144,7 +162,8 @@ func (h *WebhookHandler) ListWebhooks(c *gin.Context) {
 	if tenantID == "" {
 		log.Error("tenant ID missing in request context")
 		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
-			errors.NewAuthenticationError("tenant context required"),
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
 		))
 		return
 	}
@@ -173,7 +192,8 @@ func (h *WebhookHandler) UpdateWebhook(c *gin.Context) {
 	if tenantID == "" {
 		log.Error("tenant ID missing in request context")
 		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
-			errors.NewAuthenticationError("tenant context required"),
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
 		))
 		return
 	}
@@ -183,6 +203,7 @@ func (h *WebhookHandler) UpdateWebhook(c *gin.Context) {
 	if webhookID == "" {
 		log.Error("webhook ID missing in request path")
 		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
 			errors.NewValidationError("webhook ID is required"),
 			map[string]string{"id": "required"},
 		))
@@ -194,8 +215,9 @@ func (h *WebhookHandler) UpdateWebhook(c *gin.Context) {
 	if err := c.ShouldBindJSON(&req); err != nil {
 		log.WithError(err).Error("failed to bind request body")
 		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
-			errors.NewValidationError("invalid request format"),
-			map[string]string{"request": err.Error()},
+			c.Request.Context(),
+			validators.BindingError(err),
+			nil,
 		))
 		return
 	}
@@ -237,7 +259,8 @@ func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
 	if tenantID == "" {
 		log.Error("tenant ID missing in request context")
 		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
-			errors.NewAuthenticationError("tenant context required"),
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
 		))
 		return
 	}
@@ -247,6 +270,7 @@ func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
 	if webhookID == "" {
 		log.Error("webhook ID missing in request path")
 		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
 			errors.NewValidationError("webhook ID is required"),
 			map[string]string{"id": "required"},
 		))
@@ -264,6 +288,384 @@ func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
 	c.JSON(http.StatusOK, dto.NewMessageResponse("Webhook deleted successfully"))
 }
 
+// RotateWebhookSecret handles requests to rotate a webhook's signing secret
+func (h *WebhookHandler) RotateWebhookSecret(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Get webhook ID from URL
+	webhookID := c.Param("id")
+	if webhookID == "" {
+		log.Error("webhook ID missing in request path")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			errors.NewValidationError("webhook ID is required"),
+			map[string]string{"id": "required"},
+		))
+		return
+	}
+
+	// Call use case to rotate the secret
+	secret, err := h.webhookUseCase.RotateWebhookSecret(c.Request.Context(), webhookID, tenantID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	// Return the new secret; the previous one keeps signing deliveries during the grace period
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.WebhookSecretDTO{
+		ID:        webhookID,
+		SecretKey: secret,
+	}))
+}
+
+// CreateSubscription handles requests to add a subscription filter to a webhook
+func (h *WebhookHandler) CreateSubscription(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Get webhook ID from URL
+	webhookID := c.Param("id")
+	if webhookID == "" {
+		log.Error("webhook ID missing in request path")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			errors.NewValidationError("webhook ID is required"),
+			map[string]string{"id": "required"},
+		))
+		return
+	}
+
+	// Bind request body to DTO
+	var req dto.CreateWebhookSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.WithError(err).Error("failed to bind request body")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			validators.BindingError(err),
+			nil,
+		))
+		return
+	}
+
+	// Validate request
+	if err := validators.ValidateCreateWebhookSubscriptionRequest(&req); err != nil {
+		log.WithError(err).Error("webhook subscription validation failed")
+		h.handleError(c, err)
+		return
+	}
+
+	// Convert DTO to domain model
+	subscription := dto.ToWebhookSubscriptionDomain(&req, webhookID, tenantID)
+
+	// Call use case to create subscription
+	subscriptionID, err := h.webhookUseCase.CreateSubscription(c.Request.Context(), subscription)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	// Return success response
+	c.JSON(http.StatusCreated, dto.NewDataResponse(map[string]string{
+		"id":      subscriptionID,
+		"message": "Webhook subscription created successfully",
+	}))
+}
+
+// ListSubscriptions handles requests to list the subscriptions of a webhook
+func (h *WebhookHandler) ListSubscriptions(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Get webhook ID from URL
+	webhookID := c.Param("id")
+	if webhookID == "" {
+		log.Error("webhook ID missing in request path")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			errors.NewValidationError("webhook ID is required"),
+			map[string]string{"id": "required"},
+		))
+		return
+	}
+
+	// Call use case to list subscriptions
+	subscriptions, err := h.webhookUseCase.ListSubscriptions(c.Request.Context(), webhookID, tenantID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	// Convert domain models to DTOs and return
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.ToWebhookSubscriptionListDTO(subscriptions)))
+}
+
+// GetSubscription handles requests to retrieve a webhook subscription
+func (h *WebhookHandler) GetSubscription(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Get webhook ID from URL
+	webhookID := c.Param("id")
+	if webhookID == "" {
+		log.Error("webhook ID missing in request path")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			errors.NewValidationError("webhook ID is required"),
+			map[string]string{"id": "required"},
+		))
+		return
+	}
+
+	// Get subscription ID from URL
+	subscriptionID := c.Param("subscriptionId")
+	if subscriptionID == "" {
+		log.Error("subscription ID missing in request path")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			errors.NewValidationError("subscription ID is required"),
+			map[string]string{"subscriptionId": "required"},
+		))
+		return
+	}
+
+	// Call use case to get subscription
+	subscription, err := h.webhookUseCase.GetSubscription(c.Request.Context(), webhookID, subscriptionID, tenantID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	// Convert domain model to DTO and return
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.ToWebhookSubscriptionDTO(subscription)))
+}
+
+// UpdateSubscription handles requests to update a webhook subscription
+func (h *WebhookHandler) UpdateSubscription(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Get webhook ID from URL
+	webhookID := c.Param("id")
+	if webhookID == "" {
+		log.Error("webhook ID missing in request path")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			errors.NewValidationError("webhook ID is required"),
+			map[string]string{"id": "required"},
+		))
+		return
+	}
+
+	// Get subscription ID from URL
+	subscriptionID := c.Param("subscriptionId")
+	if subscriptionID == "" {
+		log.Error("subscription ID missing in request path")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			errors.NewValidationError("subscription ID is required"),
+			map[string]string{"subscriptionId": "required"},
+		))
+		return
+	}
+
+	// Bind request body to DTO
+	var req dto.UpdateWebhookSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.WithError(err).Error("failed to bind request body")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			validators.BindingError(err),
+			nil,
+		))
+		return
+	}
+
+	// Validate request
+	if err := validators.ValidateUpdateWebhookSubscriptionRequest(&req); err != nil {
+		log.WithError(err).Error("webhook subscription validation failed")
+		h.handleError(c, err)
+		return
+	}
+
+	// Get existing subscription
+	subscription, err := h.webhookUseCase.GetSubscription(c.Request.Context(), webhookID, subscriptionID, tenantID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	// Update subscription with request data
+	subscription = dto.UpdateWebhookSubscriptionFromRequest(subscription, &req)
+
+	// Call use case to update subscription
+	err = h.webhookUseCase.UpdateSubscription(c.Request.Context(), subscription)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	// Return success response
+	c.JSON(http.StatusOK, dto.NewMessageResponse("Webhook subscription updated successfully"))
+}
+
+// DeleteSubscription handles requests to delete a webhook subscription
+func (h *WebhookHandler) DeleteSubscription(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Get webhook ID from URL
+	webhookID := c.Param("id")
+	if webhookID == "" {
+		log.Error("webhook ID missing in request path")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			errors.NewValidationError("webhook ID is required"),
+			map[string]string{"id": "required"},
+		))
+		return
+	}
+
+	// Get subscription ID from URL
+	subscriptionID := c.Param("subscriptionId")
+	if subscriptionID == "" {
+		log.Error("subscription ID missing in request path")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			errors.NewValidationError("subscription ID is required"),
+			map[string]string{"subscriptionId": "required"},
+		))
+		return
+	}
+
+	// Call use case to delete subscription
+	err := h.webhookUseCase.DeleteSubscription(c.Request.Context(), webhookID, subscriptionID, tenantID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	// Return success response
+	c.JSON(http.StatusOK, dto.NewMessageResponse("Webhook subscription deleted successfully"))
+}
+
+// SendTestDelivery handles requests to send a sample payload to a webhook
+func (h *WebhookHandler) SendTestDelivery(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Get webhook ID from URL
+	webhookID := c.Param("id")
+	if webhookID == "" {
+		log.Error("webhook ID missing in request path")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			errors.NewValidationError("webhook ID is required"),
+			map[string]string{"id": "required"},
+		))
+		return
+	}
+
+	// Bind request body to DTO
+	var req dto.TestWebhookDeliveryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.WithError(err).Error("failed to bind request body")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			validators.BindingError(err),
+			nil,
+		))
+		return
+	}
+
+	// Validate request
+	if err := validators.ValidateTestWebhookDeliveryRequest(&req); err != nil {
+		log.WithError(err).Error("webhook test delivery validation failed")
+		h.handleError(c, err)
+		return
+	}
+
+	// Call use case to send the test delivery
+	attempt, err := h.webhookUseCase.SendTestDelivery(c.Request.Context(), webhookID, tenantID, req.EventType)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	// Return the outcome of the test delivery, including failures at the receiving end
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.ToWebhookDeliveryAttemptDTO(attempt)))
+}
+
 // GetEventTypes handles requests for supported webhook event types
 func (h *WebhookHandler) GetEventTypes(c *gin.Context) {
 	// Return list of supported event types
@@ -282,7 +684,8 @@ func (h *WebhookHandler) ListWebhookDeliveries(c *gin.Context) {
 	if tenantID == "" {
 		log.Error("tenant ID missing in request context")
 		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
-			errors.NewAuthenticationError("tenant context required"),
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
 		))
 		return
 	}
@@ -292,17 +695,25 @@ func (h *WebhookHandler) ListWebhookDeliveries(c *gin.Context) {
 	if webhookID == "" {
 		log.Error("webhook ID missing in request path")
 		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
 			errors.NewValidationError("webhook ID is required"),
 			map[string]string{"id": "required"},
 		))
 		return
 	}
 
-	// Get pagination parameters
+	// Get delivery filter and pagination parameters
+	filter, err := h.getDeliveryFilter(c)
+	if err != nil {
+		log.WithError(err).Error("invalid delivery filter")
+		h.handleError(c, err)
+		return
+	}
+	filter.WebhookID = webhookID
 	page, pageSize := h.getPaginationParams(c)
 
 	// Call use case to list webhook deliveries
-	result, err := h.webhookUseCase.ListDeliveries(c.Request.Context(), webhookID, tenantID, page, pageSize)
+	result, err := h.webhookUseCase.ListWebhookDeliveries(c.Request.Context(), tenantID, filter, page, pageSize)
 	if err != nil {
 		h.handleError(c, err)
 		return
@@ -313,6 +724,88 @@ func (h *WebhookHandler) ListWebhookDeliveries(c *gin.Context) {
 	c.JSON(http.StatusOK, dto.NewPaginatedResponse(deliveries, result.Pagination))
 }
 
+// ListDeliveryLog handles requests for the tenant-wide webhook delivery log
+func (h *WebhookHandler) ListDeliveryLog(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Get delivery filter and pagination parameters
+	filter, err := h.getDeliveryFilter(c)
+	if err != nil {
+		log.WithError(err).Error("invalid delivery filter")
+		h.handleError(c, err)
+		return
+	}
+	filter.WebhookID = c.Query("webhookId")
+	page, pageSize := h.getPaginationParams(c)
+
+	// Call use case to list webhook deliveries
+	result, err := h.webhookUseCase.ListWebhookDeliveries(c.Request.Context(), tenantID, filter, page, pageSize)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	// Convert domain models to DTOs and return
+	deliveries := dto.ToWebhookDeliveryListDTO(result)
+	c.JSON(http.StatusOK, dto.NewPaginatedResponse(deliveries, result.Pagination))
+}
+
+// GetWebhookDelivery handles requests for a webhook delivery and its attempt history
+func (h *WebhookHandler) GetWebhookDelivery(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Get webhook and delivery IDs from URL
+	webhookID := c.Param("id")
+	deliveryID := c.Param("deliveryId")
+	if webhookID == "" || deliveryID == "" {
+		log.Error("webhook or delivery ID missing in request path")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			errors.NewValidationError("webhook ID and delivery ID are required"),
+			map[string]string{"id": "required", "deliveryId": "required"},
+		))
+		return
+	}
+
+	// Call use case to get the delivery with its attempts
+	delivery, attempts, err := h.webhookUseCase.GetWebhookDelivery(c.Request.Context(), deliveryID, tenantID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	// Deliveries are addressed through their webhook, so a mismatch is reported as not found
+	if delivery.WebhookID != webhookID {
+		h.handleError(c, errors.NewResourceNotFoundError("webhook delivery not found"))
+		return
+	}
+
+	// Convert domain models to DTO and return
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.ToWebhookDeliveryDetailDTO(delivery, attempts)))
+}
+
 // GetDeliveryStatus handles webhook delivery status retrieval requests
 func (h *WebhookHandler) GetDeliveryStatus(c *gin.Context) {
 	log := logger.WithContext(c.Request.Context())
@@ -322,7 +815,8 @@ func (h *WebhookHandler) GetDeliveryStatus(c *gin.Context) {
 	if tenantID == "" {
 		log.Error("tenant ID missing in request context")
 		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
-			errors.NewAuthenticationError("tenant context required"),
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
 		))
 		return
 	}
@@ -332,6 +826,7 @@ func (h *WebhookHandler) GetDeliveryStatus(c *gin.Context) {
 	if deliveryID == "" {
 		log.Error("delivery ID missing in request path")
 		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
 			errors.NewValidationError("delivery ID is required"),
 			map[string]string{"id": "required"},
 		))
@@ -358,7 +853,8 @@ func (h *WebhookHandler) RetryDelivery(c *gin.Context) {
 	if tenantID == "" {
 		log.Error("tenant ID missing in request context")
 		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
-			errors.NewAuthenticationError("tenant context required"),
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
 		))
 		return
 	}
@@ -368,6 +864,7 @@ func (h *WebhookHandler) RetryDelivery(c *gin.Context) {
 	if deliveryID == "" {
 		log.Error("delivery ID missing in request path")
 		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
 			errors.NewValidationError("delivery ID is required"),
 			map[string]string{"id": "required"},
 		))
@@ -385,6 +882,104 @@ func (h *WebhookHandler) RetryDelivery(c *gin.Context) {
 	c.JSON(http.StatusAccepted, dto.NewMessageResponse("Webhook delivery retry initiated"))
 }
 
+// ListDeadLetteredDeliveries handles requests for deliveries that exhausted their retries
+func (h *WebhookHandler) ListDeadLetteredDeliveries(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Get pagination parameters
+	page, pageSize := h.getPaginationParams(c)
+
+	// Call use case to list dead-lettered deliveries
+	result, err := h.webhookUseCase.ListDeadLetteredDeliveries(c.Request.Context(), tenantID, page, pageSize)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	// Convert domain models to DTOs and return
+	deliveries := dto.ToWebhookDeliveryListDTO(result)
+	c.JSON(http.StatusOK, dto.NewPaginatedResponse(deliveries, result.Pagination))
+}
+
+// ListDeliveryAttempts handles requests for the attempt history of a delivery
+func (h *WebhookHandler) ListDeliveryAttempts(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context())
+
+	// Extract tenant ID from request context
+	tenantID := middleware.GetTenantID(c)
+	if tenantID == "" {
+		log.Error("tenant ID missing in request context")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse(
+			c.Request.Context(),
+			dto.ErrTenantContextRequired,
+		))
+		return
+	}
+
+	// Get delivery ID from URL
+	deliveryID := c.Param("id")
+	if deliveryID == "" {
+		log.Error("delivery ID missing in request path")
+		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
+			errors.NewValidationError("delivery ID is required"),
+			map[string]string{"id": "required"},
+		))
+		return
+	}
+
+	// Call use case to list delivery attempts
+	attempts, err := h.webhookUseCase.ListDeliveryAttempts(c.Request.Context(), deliveryID, tenantID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	// Convert domain models to DTOs and return
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.ToWebhookDeliveryAttemptListDTO(attempts)))
+}
+
+// getDeliveryFilter extracts the status and time range filters for delivery log queries.
+// Times are expected in RFC 3339 format.
+func (h *WebhookHandler) getDeliveryFilter(c *gin.Context) (models.WebhookDeliveryFilter, error) {
+	filter := models.WebhookDeliveryFilter{
+		Status: c.Query("status"),
+	}
+
+	if from := c.Query("from"); from != "" {
+		t, err := time.Parse(time.RFC3339, from)
+		if err != nil {
+			return filter, errors.NewValidationError("from must be an RFC 3339 timestamp")
+		}
+		filter.From = t
+	}
+
+	if to := c.Query("to"); to != "" {
+		t, err := time.Parse(time.RFC3339, to)
+		if err != nil {
+			return filter, errors.NewValidationError("to must be an RFC 3339 timestamp")
+		}
+		filter.To = t
+	}
+
+	if err := filter.Validate(); err != nil {
+		return filter, errors.NewValidationError(err.Error())
+	}
+
+	return filter, nil
+}
+
 // getPaginationParams extracts and validates pagination parameters from the request
 func (h *WebhookHandler) getPaginationParams(c *gin.Context) (int, int) {
 	// Extract page parameter
@@ -417,6 +1012,7 @@ func (h *WebhookHandler) getPaginationParams(c *gin.Context) (int, int) {
 func (h *WebhookHandler) handleError(c *gin.Context, err error) {
 	if errors.IsValidationError(err) {
 		c.JSON(http.StatusBadRequest, dto.NewValidationErrorResponse(
+			c.Request.Context(),
 			err,
 			map[string]string{}, // In a real implementation, we would extract validation details
 		))
@@ -424,11 +1020,11 @@ func (h *WebhookHandler) handleError(c *gin.Context, err error) {
 	}
 
 	if errors.IsResourceNotFoundError(err) {
-		c.JSON(http.StatusNotFound, dto.NewResourceNotFoundErrorResponse(err))
+		c.JSON(http.StatusNotFound, dto.NewResourceNotFoundErrorResponse(c.Request.Context(), err))
 		return
 	}
 
 	// Default to internal server error
 	logger.WithError(err).Error("internal server error")
-	c.JSON(http.StatusInternalServerError, dto.NewInternalErrorResponse(err))
+	c.JSON(http.StatusInternalServerError, dto.NewInternalErrorResponse(c.Request.Context(), err))
 }
\ No newline at end of file
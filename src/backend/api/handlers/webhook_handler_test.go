@@ -79,6 +79,85 @@ func (m *MockWebhookUseCase) RetryDelivery(ctx context.Context, deliveryID strin
 	return args.Error(0)
 }
 
+func (m *MockWebhookUseCase) ListDeadLetteredDeliveries(ctx context.Context, tenantID string, page int, pageSize int) (pagination.PaginatedResult[models.WebhookDelivery], error) {
+	args := m.Called(ctx, tenantID, page, pageSize)
+	return args.Get(0).(pagination.PaginatedResult[models.WebhookDelivery]), args.Error(1)
+}
+
+func (m *MockWebhookUseCase) ListDeliveryAttempts(ctx context.Context, deliveryID string, tenantID string) ([]*models.WebhookDeliveryAttempt, error) {
+	args := m.Called(ctx, deliveryID, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.WebhookDeliveryAttempt), args.Error(1)
+}
+
+// RotateWebhookSecret mocks the RotateWebhookSecret method
+func (m *MockWebhookUseCase) RotateWebhookSecret(ctx context.Context, id string, tenantID string) (string, error) {
+	args := m.Called(ctx, id, tenantID)
+	return args.String(0), args.Error(1)
+}
+
+// ListWebhookDeliveries mocks the ListWebhookDeliveries method
+func (m *MockWebhookUseCase) ListWebhookDeliveries(ctx context.Context, tenantID string, filter models.WebhookDeliveryFilter, page int, pageSize int) (pagination.PaginatedResult[models.WebhookDelivery], error) {
+	args := m.Called(ctx, tenantID, filter, page, pageSize)
+	return args.Get(0).(pagination.PaginatedResult[models.WebhookDelivery]), args.Error(1)
+}
+
+// GetWebhookDelivery mocks the GetWebhookDelivery method
+func (m *MockWebhookUseCase) GetWebhookDelivery(ctx context.Context, deliveryID string, tenantID string) (*models.WebhookDelivery, []*models.WebhookDeliveryAttempt, error) {
+	args := m.Called(ctx, deliveryID, tenantID)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*models.WebhookDelivery), args.Get(1).([]*models.WebhookDeliveryAttempt), args.Error(2)
+}
+
+// CreateSubscription mocks the CreateSubscription method
+func (m *MockWebhookUseCase) CreateSubscription(ctx context.Context, subscription *models.WebhookSubscription) (string, error) {
+	args := m.Called(ctx, subscription)
+	return args.String(0), args.Error(1)
+}
+
+// GetSubscription mocks the GetSubscription method
+func (m *MockWebhookUseCase) GetSubscription(ctx context.Context, webhookID string, id string, tenantID string) (*models.WebhookSubscription, error) {
+	args := m.Called(ctx, webhookID, id, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.WebhookSubscription), args.Error(1)
+}
+
+// UpdateSubscription mocks the UpdateSubscription method
+func (m *MockWebhookUseCase) UpdateSubscription(ctx context.Context, subscription *models.WebhookSubscription) error {
+	args := m.Called(ctx, subscription)
+	return args.Error(0)
+}
+
+// DeleteSubscription mocks the DeleteSubscription method
+func (m *MockWebhookUseCase) DeleteSubscription(ctx context.Context, webhookID string, id string, tenantID string) error {
+	args := m.Called(ctx, webhookID, id, tenantID)
+	return args.Error(0)
+}
+
+// ListSubscriptions mocks the ListSubscriptions method
+func (m *MockWebhookUseCase) ListSubscriptions(ctx context.Context, webhookID string, tenantID string) ([]*models.WebhookSubscription, error) {
+	args := m.Called(ctx, webhookID, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.WebhookSubscription), args.Error(1)
+}
+
+// SendTestDelivery mocks the SendTestDelivery method
+func (m *MockWebhookUseCase) SendTestDelivery(ctx context.Context, webhookID string, tenantID string, eventType string) (*models.WebhookDeliveryAttempt, error) {
+	args := m.Called(ctx, webhookID, tenantID, eventType)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.WebhookDeliveryAttempt), args.Error(1)
+}
+
 // WebhookHandlerSuite defines the test suite
 type WebhookHandlerSuite struct {
 	suite.Suite
@@ -720,7 +799,7 @@ func (s *WebhookHandlerSuite) TestListWebhookDeliveries_Success() {
 	}
 	
 	// Expect the use case to return the paginated result
-	s.webhookUseCase.On("ListDeliveries", mock.Anything, "webhook-123", "tenant-123", 1, 10).Return(paginatedResult, nil)
+	s.webhookUseCase.On("ListWebhookDeliveries", mock.Anything, "tenant-123", models.WebhookDeliveryFilter{WebhookID: "webhook-123"}, 1, 10).Return(paginatedResult, nil)
 	
 	// Create a request
 	req, _ := http.NewRequest("GET", "/api/v1/webhooks/webhook-123/deliveries?page=1&page_size=10", nil)
@@ -762,7 +841,7 @@ func (s *WebhookHandlerSuite) TestListWebhookDeliveries_Success() {
 // TestListWebhookDeliveries_NotFound tests delivery listing when the webhook is not found
 func (s *WebhookHandlerSuite) TestListWebhookDeliveries_NotFound() {
 	// Expect the use case to return a not found error
-	s.webhookUseCase.On("ListDeliveries", mock.Anything, "webhook-999", "tenant-123", 1, 10).Return(
+	s.webhookUseCase.On("ListWebhookDeliveries", mock.Anything, "tenant-123", models.WebhookDeliveryFilter{WebhookID: "webhook-999"}, 1, 10).Return(
 		pagination.PaginatedResult[models.WebhookDelivery]{},
 		apperrors.NewResourceNotFoundError("webhook not found"))
 	
@@ -944,6 +1023,281 @@ func (s *WebhookHandlerSuite) TestRetryDelivery_NotFound() {
 	s.webhookUseCase.AssertExpectations(s.T())
 }
 
+// TestListDeliveryAttempts_Success tests successful retrieval of a delivery's attempt history
+func (s *WebhookHandlerSuite) TestListDeliveryAttempts_Success() {
+	attempts := []*models.WebhookDeliveryAttempt{
+		{ID: "attempt-1", DeliveryID: "delivery-123", AttemptNumber: 1, ResponseStatus: 503, ErrorMessage: "HTTP error: 503", AttemptedAt: time.Now()},
+		{ID: "attempt-2", DeliveryID: "delivery-123", AttemptNumber: 2, ResponseStatus: 200, AttemptedAt: time.Now()},
+	}
+	
+	// Expect the use case to return the attempts
+	s.webhookUseCase.On("ListDeliveryAttempts", mock.Anything, "delivery-123", "tenant-123").Return(attempts, nil)
+	
+	// Create a request
+	req, _ := http.NewRequest("GET", "/api/v1/webhooks/deliveries/delivery-123/attempts", nil)
+	
+	// Create a gin context with the request
+	c, _ := gin.CreateTestContext(s.recorder)
+	c.Request = req
+	s.setupAuthContext(c)
+	
+	// Call the handler
+	s.router.ServeHTTP(s.recorder, req)
+	
+	// Assert the response
+	s.Equal(http.StatusOK, s.recorder.Code)
+	
+	// Parse the response body
+	var response map[string]interface{}
+	err := json.Unmarshal(s.recorder.Body.Bytes(), &response)
+	s.NoError(err)
+	s.Equal(true, response["success"])
+	
+	// Assert that the use case was called with the expected parameters
+	s.webhookUseCase.AssertExpectations(s.T())
+}
+
+// TestRotateWebhookSecret_Success tests successful webhook secret rotation
+func (s *WebhookHandlerSuite) TestRotateWebhookSecret_Success() {
+	// Expect the use case to return a new secret
+	s.webhookUseCase.On("RotateWebhookSecret", mock.Anything, "webhook-123", "tenant-123").Return("whsec_new", nil)
+	
+	// Create a request
+	req, _ := http.NewRequest("POST", "/api/v1/webhooks/webhook-123/rotate-secret", nil)
+	
+	// Create a gin context with the request
+	c, _ := gin.CreateTestContext(s.recorder)
+	c.Request = req
+	s.setupAuthContext(c)
+	
+	// Call the handler
+	s.router.ServeHTTP(s.recorder, req)
+	
+	// Assert the response
+	s.Equal(http.StatusOK, s.recorder.Code)
+	
+	// Parse the response body
+	var response map[string]interface{}
+	err := json.Unmarshal(s.recorder.Body.Bytes(), &response)
+	s.NoError(err)
+	s.Equal(true, response["success"])
+	
+	// Assert that the use case was called with the expected parameters
+	s.webhookUseCase.AssertExpectations(s.T())
+}
+
+// TestListDeliveryLog_WithFilters tests querying the delivery log by status and time range
+func (s *WebhookHandlerSuite) TestListDeliveryLog_WithFilters() {
+	from, _ := time.Parse(time.RFC3339, "2024-01-01T00:00:00Z")
+	to, _ := time.Parse(time.RFC3339, "2024-01-31T23:59:59Z")
+	filter := models.WebhookDeliveryFilter{
+		WebhookID: "webhook-123",
+		Status:    models.WebhookDeliveryStatusFailed,
+		From:      from,
+		To:        to,
+	}
+	result := pagination.PaginatedResult[models.WebhookDelivery]{
+		Items:      []models.WebhookDelivery{*s.createTestWebhookDelivery()},
+		Pagination: pagination.PageInfo{Page: 1, PageSize: 20, TotalPages: 1, TotalItems: 1},
+	}
+	
+	// Expect the use case to receive the parsed filter
+	s.webhookUseCase.On("ListWebhookDeliveries", mock.Anything, "tenant-123", filter, 1, 20).Return(result, nil)
+	
+	// Create a request
+	req, _ := http.NewRequest("GET", "/api/v1/webhooks/deliveries?webhookId=webhook-123&status=failed&from=2024-01-01T00:00:00Z&to=2024-01-31T23:59:59Z", nil)
+	
+	// Create a gin context with the request
+	c, _ := gin.CreateTestContext(s.recorder)
+	c.Request = req
+	s.setupAuthContext(c)
+	
+	// Call the handler
+	s.router.ServeHTTP(s.recorder, req)
+	
+	// Assert the response
+	s.Equal(http.StatusOK, s.recorder.Code)
+	
+	// Assert that the use case was called with the expected parameters
+	s.webhookUseCase.AssertExpectations(s.T())
+}
+
+// TestListDeliveryLog_InvalidFilter tests the delivery log with an unknown status filter
+func (s *WebhookHandlerSuite) TestListDeliveryLog_InvalidFilter() {
+	// Create a request
+	req, _ := http.NewRequest("GET", "/api/v1/webhooks/deliveries?status=unknown", nil)
+	
+	// Create a gin context with the request
+	c, _ := gin.CreateTestContext(s.recorder)
+	c.Request = req
+	s.setupAuthContext(c)
+	
+	// Call the handler
+	s.router.ServeHTTP(s.recorder, req)
+	
+	// Assert the response
+	s.Equal(http.StatusBadRequest, s.recorder.Code)
+	
+	// Assert that the use case was not called
+	s.webhookUseCase.AssertNotCalled(s.T(), "ListWebhookDeliveries")
+}
+
+// TestGetWebhookDelivery_Success tests retrieval of a delivery with its attempt history
+func (s *WebhookHandlerSuite) TestGetWebhookDelivery_Success() {
+	delivery := s.createTestWebhookDelivery()
+	attempts := []*models.WebhookDeliveryAttempt{
+		{ID: "attempt-1", DeliveryID: delivery.ID, AttemptNumber: 1, ResponseStatus: 200, AttemptedAt: time.Now()},
+	}
+	
+	// Expect the use case to return the delivery and its attempts
+	s.webhookUseCase.On("GetWebhookDelivery", mock.Anything, delivery.ID, "tenant-123").Return(delivery, attempts, nil)
+	
+	// Create a request
+	req, _ := http.NewRequest("GET", "/api/v1/webhooks/"+delivery.WebhookID+"/deliveries/"+delivery.ID, nil)
+	
+	// Create a gin context with the request
+	c, _ := gin.CreateTestContext(s.recorder)
+	c.Request = req
+	s.setupAuthContext(c)
+	
+	// Call the handler
+	s.router.ServeHTTP(s.recorder, req)
+	
+	// Assert the response
+	s.Equal(http.StatusOK, s.recorder.Code)
+	
+	// Parse the response body
+	var response map[string]interface{}
+	err := json.Unmarshal(s.recorder.Body.Bytes(), &response)
+	s.NoError(err)
+	s.Equal(true, response["success"])
+	
+	// Assert that the use case was called with the expected parameters
+	s.webhookUseCase.AssertExpectations(s.T())
+}
+
+// TestCreateSubscription_Success tests successful webhook subscription creation
+func (s *WebhookHandlerSuite) TestCreateSubscription_Success() {
+	// Create a test subscription request
+	reqBody := dto.CreateWebhookSubscriptionRequest{
+		EventTypes:      []string{"document.uploaded"},
+		FolderIDs:       []string{"folder-123"},
+		MetadataFilters: map[string]string{"project": "alpha"},
+	}
+	jsonBody, err := json.Marshal(reqBody)
+	s.NoError(err)
+	
+	// Expect the use case to return a subscription ID
+	s.webhookUseCase.On("CreateSubscription", mock.Anything, mock.AnythingOfType("*models.WebhookSubscription")).Return("sub-123", nil)
+	
+	// Create a request
+	req, _ := http.NewRequest("POST", "/api/v1/webhooks/webhook-123/subscriptions", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	
+	// Create a gin context with the request
+	c, _ := gin.CreateTestContext(s.recorder)
+	c.Request = req
+	s.setupAuthContext(c)
+	
+	// Call the handler
+	s.router.ServeHTTP(s.recorder, req)
+	
+	// Assert the response
+	s.Equal(http.StatusCreated, s.recorder.Code)
+	
+	// Assert that the use case was called with the expected parameters
+	s.webhookUseCase.AssertExpectations(s.T())
+}
+
+// TestCreateSubscription_ValidationError tests webhook subscription creation without any filter
+func (s *WebhookHandlerSuite) TestCreateSubscription_ValidationError() {
+	// Create an empty subscription request
+	jsonBody, err := json.Marshal(dto.CreateWebhookSubscriptionRequest{})
+	s.NoError(err)
+	
+	// Create a request
+	req, _ := http.NewRequest("POST", "/api/v1/webhooks/webhook-123/subscriptions", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	
+	// Create a gin context with the request
+	c, _ := gin.CreateTestContext(s.recorder)
+	c.Request = req
+	s.setupAuthContext(c)
+	
+	// Call the handler
+	s.router.ServeHTTP(s.recorder, req)
+	
+	// Assert the response
+	s.Equal(http.StatusBadRequest, s.recorder.Code)
+	
+	// Assert that the use case was not called
+	s.webhookUseCase.AssertNotCalled(s.T(), "CreateSubscription")
+}
+
+// TestSendTestDelivery_Success tests sending a sample payload to a webhook
+func (s *WebhookHandlerSuite) TestSendTestDelivery_Success() {
+	jsonBody, err := json.Marshal(dto.TestWebhookDeliveryRequest{EventType: "document.uploaded"})
+	s.NoError(err)
+	
+	// Expect the use case to return the attempt outcome
+	attempt := &models.WebhookDeliveryAttempt{AttemptNumber: 1, ResponseStatus: 200, DurationMs: 42, AttemptedAt: time.Now()}
+	s.webhookUseCase.On("SendTestDelivery", mock.Anything, "webhook-123", "tenant-123", "document.uploaded").Return(attempt, nil)
+	
+	// Create a request
+	req, _ := http.NewRequest("POST", "/api/v1/webhooks/webhook-123/test", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	
+	// Create a gin context with the request
+	c, _ := gin.CreateTestContext(s.recorder)
+	c.Request = req
+	s.setupAuthContext(c)
+	
+	// Call the handler
+	s.router.ServeHTTP(s.recorder, req)
+	
+	// Assert the response
+	s.Equal(http.StatusOK, s.recorder.Code)
+	
+	// Parse the response body
+	var response map[string]interface{}
+	err = json.Unmarshal(s.recorder.Body.Bytes(), &response)
+	s.NoError(err)
+	s.Equal(true, response["success"])
+	
+	// Assert that the use case was called with the expected parameters
+	s.webhookUseCase.AssertExpectations(s.T())
+}
+
+// TestListDeadLetteredDeliveries_Success tests successful listing of dead-lettered deliveries
+func (s *WebhookHandlerSuite) TestListDeadLetteredDeliveries_Success() {
+	delivery := s.createTestWebhookDelivery()
+	delivery.Status = models.WebhookDeliveryStatusDeadLettered
+	delivery.AttemptCount = 5
+	result := pagination.PaginatedResult[models.WebhookDelivery]{
+		Items: []models.WebhookDelivery{*delivery},
+	}
+	
+	// Expect the use case to return the dead-lettered deliveries
+	s.webhookUseCase.On("ListDeadLetteredDeliveries", mock.Anything, "tenant-123", 1, defaultPageSize).Return(result, nil)
+	
+	// Create a request
+	req, _ := http.NewRequest("GET", "/api/v1/webhooks/deliveries/dead-letter", nil)
+	
+	// Create a gin context with the request
+	c, _ := gin.CreateTestContext(s.recorder)
+	c.Request = req
+	s.setupAuthContext(c)
+	
+	// Call the handler
+	s.router.ServeHTTP(s.recorder, req)
+	
+	// Assert the response
+	s.Equal(http.StatusOK, s.recorder.Code)
+	
+	// Assert that the use case was called with the expected parameters
+	s.webhookUseCase.AssertExpectations(s.T())
+}
+
 // TestWebhookHandlerSuite is the entry point for the test suite
 func TestWebhookHandlerSuite(t *testing.T) {
 	suite.Run(t, new(WebhookHandlerSuite))
@@ -0,0 +1,87 @@
+// Package middleware provides HTTP middleware components for the Document Management Platform.
+// This file implements API key authentication for service-to-service integrations, as an
+// alternative to JWT authentication.
+package middleware
+
+import (
+	"context"  // standard library
+	"net/http" // standard library
+
+	"github.com/gin-gonic/gin" // v1.9.0+
+
+	"../../domain/models"
+	"../../pkg/errors"
+	"../../pkg/logger"
+	"../dto/error_dto"
+)
+
+// apiKeyHeader carries the API key of a service-to-service request
+const apiKeyHeader = "X-API-Key"
+
+// contextKeyAPIKeyID is the context key under which the ID of the authenticating API key is stored
+const contextKeyAPIKeyID = "api_key_id"
+
+// APIKeyAuthenticator resolves API key secrets; it is implemented by usecases.APIKeyUseCase
+type APIKeyAuthenticator interface {
+	AuthenticateAPIKey(ctx context.Context, secret string) (*models.APIKey, error)
+}
+
+// APIKeyAuthentication creates a Gin middleware that authenticates requests carrying an X-API-Key
+// header with the API key, and hands all other requests to jwtAuthentication. An API key request
+// acts in the key's tenant with the key's role; its user ID is the key's principal ID.
+func APIKeyAuthentication(authenticator APIKeyAuthenticator, jwtAuthentication gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		secret := c.GetHeader(apiKeyHeader)
+		if secret == "" {
+			jwtAuthentication(c)
+			return
+		}
+
+		// A request must not carry two identities
+		if c.GetHeader(authHeaderKey) != "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, errordto.NewAuthenticationErrorResponse(
+				c.Request.Context(),
+				errors.WithCode(errors.NewAuthenticationError("Use either an API key or a bearer token, not both"), errors.CodeAmbiguousCredentials)))
+			return
+		}
+
+		key, err := authenticator.AuthenticateAPIKey(c.Request.Context(), secret)
+		if err != nil {
+			logger.WithError(err).InfoContext(c.Request.Context(), "Authentication failed: invalid API key")
+			c.AbortWithStatusJSON(http.StatusUnauthorized, errordto.NewAuthenticationErrorResponse(
+				c.Request.Context(),
+				errors.WithCode(errors.NewAuthenticationError("Invalid API key"), errors.CodeInvalidAPIKey)))
+			return
+		}
+
+		// Set claims in context for downstream handlers
+		c.Set(contextKeyUserID, key.PrincipalID())
+		c.Set(contextKeyTenantID, key.TenantID)
+		c.Set(contextKeyRoles, []string{key.Role})
+		c.Set(contextKeyAPIKeyID, key.ID)
+
+		logger.InfoContext(c.Request.Context(), "API key authentication successful",
+			"api_key_id", key.ID,
+			"tenant_id", key.TenantID)
+
+		c.Next()
+	}
+}
+
+// GetAPIKeyID extracts the ID of the authenticating API key from the request context.
+// It is empty for requests authenticated with a JWT.
+func GetAPIKeyID(c *gin.Context) string {
+	// Extract API key ID from context
+	keyID, exists := c.Get(contextKeyAPIKeyID)
+	if !exists {
+		return ""
+	}
+
+	// Convert to string and return
+	keyIDStr, ok := keyID.(string)
+	if !ok {
+		return ""
+	}
+
+	return keyIDStr
+}
@@ -0,0 +1,29 @@
+// Package middleware provides HTTP middleware components for the Document Management Platform.
+// This file implements the audit context middleware that records who issued a request so that
+// services can attach it to audit log entries.
+package middleware
+
+import (
+	"github.com/gin-gonic/gin" // v1.9.0+
+
+	"../../domain/services"
+)
+
+// AuditContext creates a middleware that attaches the request's actor (user, client IP,
+// user agent and request ID) to the request context for audit logging.
+// It must run after authentication so that the user ID is available; on public routes the user is empty.
+func AuditContext() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actor := services.AuditActor{
+			UserID:    GetUserID(c),
+			IPAddress: getClientIP(c),
+			UserAgent: c.Request.UserAgent(),
+			RequestID: GetRequestID(c),
+		}
+
+		ctx := services.ContextWithAuditActor(c.Request.Context(), actor)
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+	}
+}
@@ -6,9 +6,12 @@ package middleware
 import (
 	"net/http" // standard library
 	"strings"  // standard library
+	"time"     // standard library
 
-	"github.com/gin-gonic/gin" // v1.9.0+
+	"github.com/gin-gonic/gin"     // v1.9.0+
+	"github.com/golang-jwt/jwt/v5" // v5.0.0+
 
+	"../../domain/services"
 	"../../domain/services/auth_service"
 	"../../pkg/errors"
 	"../../pkg/logger"
@@ -33,7 +36,8 @@ func AuthMiddleware(authService auth.AuthService) gin.HandlerFunc {
 		if err != nil {
 			logger.InfoContext(c.Request.Context(), "Authentication failed: missing or invalid token format")
 			c.AbortWithStatusJSON(http.StatusUnauthorized, errordto.NewAuthenticationErrorResponse(
-				errors.NewAuthenticationError("Missing or invalid authentication token")))
+				c.Request.Context(),
+				errors.WithCode(errors.NewAuthenticationError("Missing or invalid authentication token"), errors.CodeInvalidAuthenticationToken)))
 			return
 		}
 
@@ -42,7 +46,8 @@ func AuthMiddleware(authService auth.AuthService) gin.HandlerFunc {
 		if err != nil {
 			logger.WithError(err).InfoContext(c.Request.Context(), "Authentication failed: invalid token")
 			c.AbortWithStatusJSON(http.StatusUnauthorized, errordto.NewAuthenticationErrorResponse(
-				errors.NewAuthenticationError("Invalid authentication token")))
+				c.Request.Context(),
+				errors.WithCode(errors.NewAuthenticationError("Invalid authentication token"), errors.CodeInvalidAuthenticationToken)))
 			return
 		}
 
@@ -53,6 +58,15 @@ func AuthMiddleware(authService auth.AuthService) gin.HandlerFunc {
 		// This would normally be done by the auth service
 		userID := c.GetString("sub") // This is an example; in reality authService would provide this
 
+		// Downloads from folders requiring a recent sign-in check when the session was signed in to;
+		// the token was verified above, so it is only parsed again for that claim
+		claims := jwt.MapClaims{}
+		if _, _, err := jwt.NewParser().ParseUnverified(token, claims); err == nil {
+			if authTime, ok := claims["auth_time"].(float64); ok {
+				c.Request = c.Request.WithContext(services.ContextWithAuthenticatedAt(c.Request.Context(), time.Unix(int64(authTime), 0)))
+			}
+		}
+
 		// Set claims in context for downstream handlers
 		c.Set(contextKeyUserID, userID)
 		c.Set(contextKeyTenantID, tenantID)
@@ -73,7 +87,8 @@ func RequireAuthentication() gin.HandlerFunc {
 		userID := GetUserID(c)
 		if userID == "" {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, errordto.NewAuthenticationErrorResponse(
-				errors.NewAuthenticationError("Authentication required")))
+				c.Request.Context(),
+				errors.WithCode(errors.NewAuthenticationError("Authentication required"), errors.CodeAuthenticationRequired)))
 			return
 		}
 
@@ -88,7 +103,8 @@ func RequireRole(requiredRole string) gin.HandlerFunc {
 		roles := GetUserRoles(c)
 		if roles == nil {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, errordto.NewAuthenticationErrorResponse(
-				errors.NewAuthenticationError("Authentication required")))
+				c.Request.Context(),
+				errors.WithCode(errors.NewAuthenticationError("Authentication required"), errors.CodeAuthenticationRequired)))
 			return
 		}
 
@@ -98,7 +114,8 @@ func RequireRole(requiredRole string) gin.HandlerFunc {
 				"required_role", requiredRole,
 				"user_roles", roles)
 			c.AbortWithStatusJSON(http.StatusForbidden, errordto.NewAuthorizationErrorResponse(
-				errors.NewAuthorizationError("Insufficient permissions")))
+				c.Request.Context(),
+				errors.WithCode(errors.NewAuthorizationError("Insufficient permissions"), errors.CodeInsufficientPermissions)))
 			return
 		}
 
@@ -113,7 +130,8 @@ func RequireAnyRole(requiredRoles []string) gin.HandlerFunc {
 		userRoles := GetUserRoles(c)
 		if userRoles == nil {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, errordto.NewAuthenticationErrorResponse(
-				errors.NewAuthenticationError("Authentication required")))
+				c.Request.Context(),
+				errors.WithCode(errors.NewAuthenticationError("Authentication required"), errors.CodeAuthenticationRequired)))
 			return
 		}
 
@@ -131,7 +149,8 @@ func RequireAnyRole(requiredRoles []string) gin.HandlerFunc {
 				"required_roles", requiredRoles,
 				"user_roles", userRoles)
 			c.AbortWithStatusJSON(http.StatusForbidden, errordto.NewAuthorizationErrorResponse(
-				errors.NewAuthorizationError("Insufficient permissions")))
+				c.Request.Context(),
+				errors.WithCode(errors.NewAuthorizationError("Insufficient permissions"), errors.CodeInsufficientPermissions)))
 			return
 		}
 
@@ -20,8 +20,8 @@ var (
 	defaultMaxAge = 24 * time.Hour
 	defaultAllowOrigins = []string{"*"}
 	defaultAllowMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}
-	defaultAllowHeaders = []string{"Origin", "Content-Type", "Content-Length", "Accept-Encoding", "Authorization", "Accept"}
-	defaultExposeHeaders = []string{"Content-Length", "Content-Type"}
+	defaultAllowHeaders = []string{"Origin", "Content-Type", "Content-Length", "Accept-Encoding", "Authorization", "Accept", "X-Request-ID", "X-Correlation-ID"}
+	defaultExposeHeaders = []string{"Content-Length", "Content-Type", "X-Request-ID"}
 )
 
 // CORSConfig defines configuration options for the CORS middleware
@@ -0,0 +1,67 @@
+// Package middleware provides HTTP middleware components for the Document Management Platform.
+// This file implements the authentication middleware for external guests holding a scoped guest token.
+package middleware
+
+import (
+	"net/http" // standard library
+
+	"github.com/gin-gonic/gin" // v1.9.0+
+
+	"../../domain/models"
+	"../../domain/services"
+	"../../pkg/errors"
+	"../../pkg/logger"
+	"../dto/error_dto"
+)
+
+// contextKeyGuestScope is the context key under which the validated guest scope is stored
+const contextKeyGuestScope = "guest_scope"
+
+// GuestAuthentication creates a Gin middleware that validates guest tokens and stores the guest scope
+// together with its tenant in the request context. Regular user tokens are rejected.
+func GuestAuthentication(authService services.AuthService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Extract token from Authorization header
+		token, err := extractTokenFromHeader(c)
+		if err != nil {
+			logger.InfoContext(c.Request.Context(), "Guest authentication failed: missing or invalid token format")
+			c.AbortWithStatusJSON(http.StatusUnauthorized, errordto.NewAuthenticationErrorResponse(
+				c.Request.Context(),
+				errors.WithCode(errors.NewAuthenticationError("Missing or invalid guest token"), errors.CodeInvalidGuestToken)))
+			return
+		}
+
+		// Validate token and extract the scope it is limited to
+		scope, err := authService.ValidateGuestToken(c.Request.Context(), token)
+		if err != nil {
+			logger.WithError(err).InfoContext(c.Request.Context(), "Guest authentication failed: invalid token")
+			c.AbortWithStatusJSON(http.StatusUnauthorized, errordto.NewAuthenticationErrorResponse(
+				c.Request.Context(),
+				errors.WithCode(errors.NewAuthenticationError("Invalid guest token"), errors.CodeInvalidGuestToken)))
+			return
+		}
+
+		// Set scope in context for downstream handlers; guests have no user ID
+		c.Set(contextKeyGuestScope, scope)
+		c.Set(contextKeyTenantID, scope.TenantID)
+
+		c.Next()
+	}
+}
+
+// GetGuestScope extracts the guest scope from the request context
+func GetGuestScope(c *gin.Context) *models.GuestScope {
+	// Extract guest scope from context
+	scope, exists := c.Get(contextKeyGuestScope)
+	if !exists {
+		return nil
+	}
+
+	// Convert to *models.GuestScope and return
+	guestScope, ok := scope.(*models.GuestScope)
+	if !ok {
+		return nil
+	}
+
+	return guestScope
+}
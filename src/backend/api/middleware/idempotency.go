@@ -0,0 +1,264 @@
+// Package middleware provides HTTP middleware components for the Document Management Platform.
+// This file implements idempotency keys, which let clients safely retry mutating requests: a retry
+// with the same Idempotency-Key header gets the original response instead of repeating the request.
+package middleware
+
+import (
+	"bytes"         // standard library
+	"crypto/sha256" // standard library
+	"io"            // standard library
+	"mime"          // standard library
+	"net/http"      // standard library
+	"strings"       // standard library
+	"time"          // standard library
+
+	"github.com/gin-gonic/gin" // v1.9.0+
+
+	"../../domain/models"
+	"../../domain/repositories"
+	"../../pkg/config"
+	"../../pkg/errors"
+	"../../pkg/logger"
+	"../dto/error_dto"
+)
+
+// Idempotency headers
+const (
+	idempotencyKeyHeader      = "Idempotency-Key"
+	idempotencyReplayedHeader = "Idempotent-Replayed"
+)
+
+// defaultIdempotencyKeyTTL is how long idempotency keys are remembered when no TTL is configured
+const defaultIdempotencyKeyTTL = 24 * time.Hour
+
+// idempotencyBodyDigestLimit is how much of a request body is hashed into its fingerprint. Larger
+// bodies, such as uploads, are identified by their beginning so that they need not be buffered.
+const idempotencyBodyDigestLimit = 1 << 20
+
+// maxIdempotentResponseSize is the largest response body that is stored for replaying
+const maxIdempotentResponseSize = 1 << 20
+
+// idempotencyResponseWriter captures the response of a request so that it can be replayed
+type idempotencyResponseWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+	// skipped is set once the response turns out to be streamed, a download or too large to store
+	skipped bool
+}
+
+// Write writes the response body to the client and captures it
+func (w *idempotencyResponseWriter) Write(data []byte) (int, error) {
+	w.capture(data)
+	return w.ResponseWriter.Write(data)
+}
+
+// WriteString writes the response body to the client and captures it
+func (w *idempotencyResponseWriter) WriteString(s string) (int, error) {
+	w.capture([]byte(s))
+	return w.ResponseWriter.WriteString(s)
+}
+
+// Flush sends buffered data to the client. Flushed responses are streamed and are not captured.
+func (w *idempotencyResponseWriter) Flush() {
+	w.skip()
+	w.ResponseWriter.Flush()
+}
+
+// capture adds data to the captured body unless the response cannot be stored
+func (w *idempotencyResponseWriter) capture(data []byte) {
+	if w.skipped {
+		return
+	}
+	if w.body.Len()+len(data) > maxIdempotentResponseSize || isStreamedResponse(w.Header()) {
+		w.skip()
+		return
+	}
+	w.body.Write(data)
+}
+
+// skip stops capturing the response and frees what was captured so far
+func (w *idempotencyResponseWriter) skip() {
+	w.skipped = true
+	w.body = bytes.Buffer{}
+}
+
+// isStreamedResponse reports whether response headers announce a download or an event stream,
+// which are too large or too long-lived to store
+func isStreamedResponse(header http.Header) bool {
+	if strings.HasPrefix(strings.ToLower(header.Get("Content-Disposition")), "attachment") {
+		return true
+	}
+	mediaType, _, _ := mime.ParseMediaType(header.Get("Content-Type"))
+	return mediaType == "text/event-stream"
+}
+
+// Idempotency creates a Gin middleware that makes POST, PUT, PATCH and DELETE requests carrying an
+// Idempotency-Key header idempotent. It must run after authentication, since keys are scoped to the
+// tenant and user that sent them. The first request with a key runs and its response is stored;
+// retries get the stored response with an Idempotent-Replayed header. Retries arriving while the
+// first request still runs get 409 Conflict, and reusing a key for a different request gets 422.
+// Server errors and throttled requests are not stored, so that they can be retried. Neither are
+// downloads, streamed responses and responses larger than maxIdempotentResponseSize.
+func Idempotency(cfg config.IdempotencyConfig, repository repositories.IdempotencyRepository) gin.HandlerFunc {
+	if !cfg.Enabled {
+		return func(c *gin.Context) {
+			c.Next()
+		}
+	}
+
+	ttl := defaultIdempotencyKeyTTL
+	if cfg.KeyTTL != "" {
+		parsed, err := time.ParseDuration(cfg.KeyTTL)
+		if err != nil || parsed <= 0 {
+			logger.Error("Invalid idempotency key TTL, using default", "key_ttl", cfg.KeyTTL, "default", defaultIdempotencyKeyTTL.String())
+		} else {
+			ttl = parsed
+		}
+	}
+
+	return func(c *gin.Context) {
+		key := c.GetHeader(idempotencyKeyHeader)
+		if key == "" || !isMutatingMethod(c.Request.Method) {
+			c.Next()
+			return
+		}
+
+		if err := models.ValidateIdempotencyKey(key); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errordto.NewErrorResponse(c.Request.Context(), errors.NewValidationError(err.Error())))
+			return
+		}
+
+		bodyDigest, err := requestBodyDigest(c.Request)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errordto.NewErrorResponse(c.Request.Context(), errors.NewValidationError("failed to read request body")))
+			return
+		}
+
+		ctx := c.Request.Context()
+		record := models.NewIdempotencyRecord(key, GetTenantID(c), GetUserID(c),
+			models.IdempotencyFingerprint(c.Request.Method, c.Request.URL.RequestURI(), bodyDigest))
+
+		reserved, existing, err := repository.Reserve(ctx, record, ttl)
+		if err != nil {
+			// Without the store the request cannot be deduplicated; running it is better than failing it
+			logger.ErrorContext(ctx, "Failed to reserve idempotency key", "error", err.Error())
+			c.Next()
+			return
+		}
+
+		if !reserved {
+			replayIdempotentResponse(c, record, existing)
+			return
+		}
+
+		// Forget the key if the request panics, so that it can be retried
+		completed := false
+		defer func() {
+			if !completed {
+				if err := repository.Release(ctx, record.TenantID, record.UserID, key); err != nil {
+					logger.ErrorContext(ctx, "Failed to release idempotency key", "error", err.Error())
+				}
+			}
+		}()
+
+		writer := &idempotencyResponseWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		c.Next()
+
+		status := writer.Status()
+		if status >= http.StatusInternalServerError || status == http.StatusTooManyRequests || writer.skipped {
+			return
+		}
+
+		record.Complete(status, writer.Header().Clone(), writer.body.Bytes())
+		if err := repository.Save(ctx, record, ttl); err != nil {
+			logger.ErrorContext(ctx, "Failed to store idempotent response", "error", err.Error())
+			return
+		}
+		completed = true
+	}
+}
+
+// Errors of retried requests whose Idempotency-Key cannot be replayed
+var (
+	errIdempotencyKeyReused = errors.WithStatusCode(errors.WithCode(
+		errors.NewValidationError("Idempotency-Key was already used for a different request"),
+		errors.CodeIdempotencyKeyReused), http.StatusUnprocessableEntity)
+	errIdempotencyKeyInProgress = errors.WithStatusCode(errors.WithCode(
+		errors.NewValidationError("A request with this Idempotency-Key is still being processed"),
+		errors.CodeIdempotencyKeyInProgress), http.StatusConflict)
+)
+
+// replayIdempotentResponse answers a request whose key was used before
+func replayIdempotentResponse(c *gin.Context, record, existing *models.IdempotencyRecord) {
+	if existing.Fingerprint != record.Fingerprint {
+		c.AbortWithStatusJSON(http.StatusUnprocessableEntity, errordto.NewErrorResponse(
+			c.Request.Context(),
+			errIdempotencyKeyReused))
+		return
+	}
+
+	if !existing.IsCompleted() {
+		c.AbortWithStatusJSON(http.StatusConflict, errordto.NewErrorResponse(
+			c.Request.Context(),
+			errIdempotencyKeyInProgress))
+		return
+	}
+
+	logger.InfoContext(c.Request.Context(), "Replaying idempotent response",
+		"tenant_id", record.TenantID,
+		"status", existing.StatusCode)
+
+	// Headers this request already has, such as its request ID, are not replayed
+	for name, values := range existing.Header {
+		if c.Writer.Header().Get(name) != "" {
+			continue
+		}
+		for _, value := range values {
+			c.Writer.Header().Add(name, value)
+		}
+	}
+	c.Header(idempotencyReplayedHeader, "true")
+	c.Status(existing.StatusCode)
+	c.Writer.Write(existing.Body)
+	c.Abort()
+}
+
+// prefixedBody is a request body whose beginning was already read and is served again from memory
+type prefixedBody struct {
+	io.Reader
+	io.Closer
+}
+
+// requestBodyDigest returns the SHA-256 digest of the first idempotencyBodyDigestLimit bytes of the
+// request body, and puts those bytes back so that the handler still reads the whole body. Multipart
+// boundaries are left out of the digest, since clients choose new ones when they retry.
+func requestBodyDigest(req *http.Request) ([]byte, error) {
+	var prefix []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		prefix, err = io.ReadAll(io.LimitReader(req.Body, idempotencyBodyDigestLimit))
+		if err != nil {
+			return nil, err
+		}
+		req.Body = prefixedBody{Reader: io.MultiReader(bytes.NewReader(prefix), req.Body), Closer: req.Body}
+	}
+
+	hashed := prefix
+	mediaType, params, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if err == nil && strings.HasPrefix(mediaType, "multipart/") && params["boundary"] != "" {
+		hashed = bytes.ReplaceAll(prefix, []byte(params["boundary"]), nil)
+	}
+
+	sum := sha256.Sum256(hashed)
+	return sum[:], nil
+}
+
+// isMutatingMethod reports whether requests with the HTTP method change state
+func isMutatingMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
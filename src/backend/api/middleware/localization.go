@@ -0,0 +1,77 @@
+// Package middleware provides HTTP middleware components for the Document Management Platform.
+// This file implements the localization middleware that chooses the language error messages
+// are shown in.
+package middleware
+
+import (
+	"github.com/gin-gonic/gin" // v1.9.0+
+
+	"../../domain/services"
+	"../../pkg/i18n"
+	"../../pkg/logger"
+)
+
+// contextKeyLanguage is the context key under which the language of the request is stored once chosen
+const contextKeyLanguage = "language"
+
+// acceptLanguageHeader carries the languages the client prefers
+const acceptLanguageHeader = "Accept-Language"
+
+// Localization creates a middleware choosing the language error messages are shown in: the
+// language the client prefers most according to its Accept-Language header, otherwise the
+// default language of the caller's tenant, otherwise English. It should run before
+// authentication, so that its errors are shown in the client's language, and again after it,
+// which sets the tenant, so that the tenant's language applies. Once a language was chosen, later
+// runs keep it.
+func Localization(tenantSettingsService services.TenantSettingsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// An empty language records that the header was read without choosing one
+		chosen, ran := c.Get(contextKeyLanguage)
+		if chosen != "" && ran {
+			c.Next()
+			return
+		}
+		if !ran {
+			// Messages depend on the header, so caches must not share responses between languages
+			c.Writer.Header().Add("Vary", acceptLanguageHeader)
+			if language, ok := i18n.Match(c.GetHeader(acceptLanguageHeader)); ok {
+				setLanguage(c, language)
+				c.Next()
+				return
+			}
+			c.Set(contextKeyLanguage, "")
+		}
+
+		tenantID := GetTenantID(c)
+		if tenantID == "" || tenantSettingsService == nil {
+			c.Next()
+			return
+		}
+		preferences, err := tenantSettingsService.Preferences(c.Request.Context(), tenantID)
+		if err != nil {
+			// The default language is only a preference, so messages are shown in English instead
+			logger.WarnContext(c.Request.Context(), "Failed to get tenant language", "tenant_id", tenantID, "error", err.Error())
+			c.Next()
+			return
+		}
+		if language, ok := i18n.Supported(preferences.Language); ok {
+			setLanguage(c, language)
+		}
+		c.Next()
+	}
+}
+
+// GetLanguage retrieves the language error messages are shown in from the Gin context
+func GetLanguage(c *gin.Context) string {
+	if language := c.GetString(contextKeyLanguage); language != "" {
+		return language
+	}
+	return i18n.DefaultLanguage
+}
+
+// setLanguage stores the language of the request in the Gin context, and in the request context
+// read when rendering error responses
+func setLanguage(c *gin.Context, language string) {
+	c.Set(contextKeyLanguage, language)
+	c.Request = c.Request.WithContext(i18n.ContextWithLanguage(c.Request.Context(), language))
+}
@@ -3,123 +3,150 @@
 package middleware
 
 import (
-	"context"
+	"math/rand"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin" // v1.9.0+
-	"github.com/google/uuid"    // v1.3.0+
+	"go.uber.org/zap"          // v1.24.0+
 
+	"../../pkg/config"
 	"../../pkg/logger"
 )
 
-// Context and header constants for request identification
-const (
-	// contextKeyRequestID is the key used to store request ID in the context
-	contextKeyRequestID = "request_id"
-	
-	// headerRequestID is the HTTP header name for the request ID
-	headerRequestID = "X-Request-ID"
-)
+// redactedValue replaces the values of redacted headers and query parameters in the access log
+const redactedValue = "[REDACTED]"
+
+// alwaysRedactedHeaders are the headers carrying credentials, redacted whatever the configuration
+var alwaysRedactedHeaders = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"Set-Cookie",
+	"X-API-Key",
+	"X-Share-Link-Password",
+}
 
-// LoggingMiddleware creates a Gin middleware that logs HTTP requests and responses.
-// It captures request details, generates unique request IDs, measures response times,
-// and logs the outcome of each API call in a structured format.
-//
-// This middleware helps with:
-// - Request tracking across microservices
-// - Performance monitoring of API endpoints
-// - Security audit logging of API access
-// - Debugging of API issues
-//
-// It integrates with the application's structured logging system to ensure
-// consistent log format across all components.
+// alwaysRedactedQueryParams are the query parameters carrying tokens and signatures, redacted
+// whatever the configuration
+var alwaysRedactedQueryParams = []string{
+	"token",
+	"access_token",
+	"refresh_token",
+	"password",
+	"signature",
+	"x-amz-signature",
+	"x-amz-credential",
+	"x-amz-security-token",
+}
+
+// LoggingMiddleware creates a Gin middleware that logs every request with the default access log configuration.
 func LoggingMiddleware() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		// Generate a unique request ID
-		requestID := generateRequestID()
+	return Logger(config.AccessLogConfig{Enabled: true, SampleRate: 1})
+}
 
-		// Set request ID in context and response header
-		setRequestIDInContext(c, requestID)
-		c.Header(headerRequestID, requestID)
+// Logger creates a Gin middleware that writes an access log line when the response is complete,
+// with the request ID, tenant, user, route, status, latency and bytes transferred. Credentials in
+// headers and query strings are redacted along with the configured headers and query parameters.
+// The request ID is assigned by the RequestID middleware, which must run first.
+//
+// Successful requests are sampled at cfg.SampleRate to bound the log volume of busy deployments;
+// requests that fail with a client or server error are always logged.
+func Logger(cfg config.AccessLogConfig) gin.HandlerFunc {
+	redactedHeaders := make(map[string]bool)
+	for _, name := range append(alwaysRedactedHeaders, cfg.RedactHeaders...) {
+		redactedHeaders[http.CanonicalHeaderKey(name)] = true
+	}
+	redactedQueryParams := make(map[string]bool)
+	for _, name := range append(alwaysRedactedQueryParams, cfg.RedactQueryParams...) {
+		redactedQueryParams[strings.ToLower(name)] = true
+	}
+	skipPaths := make(map[string]bool, len(cfg.SkipPaths))
+	for _, path := range cfg.SkipPaths {
+		skipPaths[path] = true
+	}
 
-		// Record start time
-		startTime := time.Now()
+	return func(c *gin.Context) {
+		if !cfg.Enabled || skipPaths[c.Request.URL.Path] {
+			c.Next()
+			return
+		}
 
-		// Log incoming request with structured fields
-		logger.InfoContext(c.Request.Context(), "API Request",
-			"method", c.Request.Method,
-			"path", c.Request.URL.Path,
-			"client_ip", c.ClientIP(),
-			"user_agent", c.Request.UserAgent(),
-			"request_id", requestID,
-		)
+		startTime := time.Now()
 
 		// Process request (continue to the next middleware/handler)
 		c.Next()
 
-		// Calculate request duration after processing
-		duration := time.Since(startTime)
-
-		// Get status code after request is processed
 		statusCode := c.Writer.Status()
-
-		// Prepare log fields for the response
-		logFields := []interface{}{
-			"method", c.Request.Method,
-			"path", c.Request.URL.Path,
-			"status", statusCode,
-			"duration_ms", duration.Milliseconds(),
-			"request_id", requestID,
-			"bytes_out", c.Writer.Size(),
+		if statusCode < http.StatusBadRequest && !sampled(cfg.SampleRate) {
+			return
 		}
 
-		// If path has query parameters, log them
+		fields := []zap.Field{
+			zap.String("method", c.Request.Method),
+			zap.String("route", c.FullPath()),
+			zap.String("path", c.Request.URL.Path),
+			zap.Int("status", statusCode),
+			zap.Int64("latency_ms", time.Since(startTime).Milliseconds()),
+			zap.Int64("bytes_in", c.Request.ContentLength),
+			zap.Int("bytes_out", c.Writer.Size()),
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_agent", c.Request.UserAgent()),
+			zap.String("tenant_id", c.GetString(contextKeyTenantID)),
+			zap.String("user_id", c.GetString(contextKeyUserID)),
+		}
 		if c.Request.URL.RawQuery != "" {
-			logFields = append(logFields, "query", c.Request.URL.RawQuery)
+			fields = append(fields, zap.String("query", redactQuery(c.Request.URL.Query(), redactedQueryParams)))
+		}
+		if cfg.LogHeaders {
+			fields = append(fields, zap.Any("headers", redactHeaders(c.Request.Header, redactedHeaders)))
 		}
-
-		// If there are errors from handlers, include them in the log
 		if len(c.Errors) > 0 {
-			logFields = append(logFields, "errors", c.Errors.String())
+			fields = append(fields, zap.String("errors", c.Errors.String()))
 		}
 
-		// Log at appropriate level based on status code
-		if statusCode >= http.StatusBadRequest {
-			logger.ErrorContext(c.Request.Context(), "API Response", logFields...)
-		} else {
-			logger.InfoContext(c.Request.Context(), "API Response", logFields...)
+		// The request ID and trace ID are added from the request context
+		log := logger.WithContext(c.Request.Context())
+		switch {
+		case statusCode >= http.StatusInternalServerError:
+			log.Error("API Request", fields...)
+		case statusCode >= http.StatusBadRequest:
+			log.Warn("API Request", fields...)
+		default:
+			log.Info("API Request", fields...)
 		}
 	}
 }
 
-// GetRequestID extracts the request ID from the context.
-// Returns an empty string if the request ID is not found.
-//
-// This function is useful for other middleware or handlers that need
-// to access the request ID for correlation or logging purposes.
-func GetRequestID(c *gin.Context) string {
-	requestID, exists := c.Get(contextKeyRequestID)
-	if !exists {
-		return ""
-	}
-	return requestID.(string)
+// sampled reports whether a successful request is logged at the sample rate
+func sampled(rate float64) bool {
+	return rate >= 1 || rand.Float64() < rate
 }
 
-// generateRequestID creates a new unique request ID using UUID.
-// This ensures that each request can be uniquely identified across the system.
-func generateRequestID() string {
-	return uuid.New().String()
+// redactHeaders returns the headers of a request with the values of the redacted headers replaced
+func redactHeaders(header http.Header, redacted map[string]bool) map[string]string {
+	headers := make(map[string]string, len(header))
+	for name, values := range header {
+		if redacted[http.CanonicalHeaderKey(name)] {
+			headers[name] = redactedValue
+			continue
+		}
+		headers[name] = strings.Join(values, ", ")
+	}
+	return headers
 }
 
-// setRequestIDInContext sets the request ID in both the Gin context and request context.
-// This ensures the request ID is available both to Gin middleware/handlers and through
-// the standard context mechanism for use with other packages.
-func setRequestIDInContext(c *gin.Context, requestID string) {
-	// Set in Gin context
-	c.Set(contextKeyRequestID, requestID)
-
-	// Set in request context
-	ctx := context.WithValue(c.Request.Context(), contextKeyRequestID, requestID)
-	c.Request = c.Request.WithContext(ctx)
-}
\ No newline at end of file
+// redactQuery returns the query string of a request with the values of the redacted parameters replaced
+func redactQuery(query url.Values, redacted map[string]bool) string {
+	for name, values := range query {
+		if !redacted[strings.ToLower(name)] {
+			continue
+		}
+		for i := range values {
+			values[i] = redactedValue
+		}
+	}
+	return query.Encode()
+}
@@ -0,0 +1,26 @@
+// Package middleware provides HTTP middleware components for the Document Management Platform API.
+package middleware
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin" // v1.9.0+
+
+	"../../pkg/metrics"
+)
+
+// Metrics creates a Gin middleware that records the rate, errors and duration of requests by method,
+// route template, status and tenant, and the number of requests in flight. Requests are labelled by
+// route rather than path so that document and folder IDs do not each create a series.
+func Metrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		metrics.IncHTTPRequestsInFlight()
+		defer metrics.DecHTTPRequestsInFlight()
+
+		c.Next()
+
+		tenantID := c.GetString(contextKeyTenantID)
+		metrics.ObserveHTTPRequest(c.Request.Method, c.FullPath(), c.Writer.Status(), tenantID, time.Since(start))
+	}
+}
@@ -2,20 +2,28 @@ package middleware
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/json"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/gin-gonic/gin" // v1.9.0+
+	"github.com/google/uuid" // v1.3.0+
 	"github.com/stretchr/testify/assert" // v1.8.0+
 	"github.com/stretchr/testify/mock" // v1.8.0+
 	"github.com/stretchr/testify/suite" // v1.8.0+
 
+	"../../domain/models" // For rate limit decisions
 	"../../domain/services/auth_service" // For mocking authentication service in tests
 	"../../pkg/errors" // For verifying error types in tests
+	"../../pkg/i18n" // For reading the language from the request context
 	"../../pkg/config" // For creating test configurations
+	"../../pkg/logger" // For reading the request ID from the request context
 )
 
 // MockAuthService is a mock implementation of the AuthService interface for testing
@@ -327,10 +335,10 @@ func (s *MiddlewareSuite) TestCORSMiddleware() {
 	assert.Equal(s.T(), "86400", w.Header().Get("Access-Control-Max-Age"))
 }
 
-// TestLoggingMiddleware tests that LoggingMiddleware adds request ID and logs requests
+// TestLoggingMiddleware tests that LoggingMiddleware logs requests identified by RequestID
 func (s *MiddlewareSuite) TestLoggingMiddleware() {
 	// Arrange
-	router := setupTestRouter(s, LoggingMiddleware())
+	router := setupTestRouter(s, RequestID(), LoggingMiddleware())
 	req := createTestRequest("GET", "/test", nil)
 	
 	// Act
@@ -342,6 +350,93 @@ func (s *MiddlewareSuite) TestLoggingMiddleware() {
 	assert.NotEmpty(s.T(), w.Header().Get("X-Request-ID"))
 }
 
+// TestLogger_SkipPaths tests that skipped paths still get a request ID
+func (s *MiddlewareSuite) TestLogger_SkipPaths() {
+	// Arrange
+	router := setupTestRouter(s, RequestID(), Logger(config.AccessLogConfig{Enabled: true, SampleRate: 1, SkipPaths: []string{"/test"}}))
+	req := createTestRequest("GET", "/test", nil)
+	
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	
+	// Assert
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+	assert.NotEmpty(s.T(), w.Header().Get("X-Request-ID"))
+}
+
+// TestRequestID_KeepsIncomingID tests that the caller's request or correlation ID is kept
+func (s *MiddlewareSuite) TestRequestID_KeepsIncomingID() {
+	router := setupTestRouter(s, RequestID())
+	
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, createTestRequest("GET", "/test", map[string]string{"X-Request-ID": "client-req:42"}))
+	assert.Equal(s.T(), "client-req:42", w.Header().Get("X-Request-ID"))
+	
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, createTestRequest("GET", "/test", map[string]string{"X-Correlation-ID": "corr.7"}))
+	assert.Equal(s.T(), "corr.7", w.Header().Get("X-Request-ID"))
+}
+
+// TestRequestID_ReplacesInvalidID tests that unsafe or oversized incoming IDs are replaced by a generated ID
+func (s *MiddlewareSuite) TestRequestID_ReplacesInvalidID() {
+	router := setupTestRouter(s, RequestID())
+	
+	for _, requestID := range []string{"bad id\r\nX-Injected: 1", strings.Repeat("a", 129)} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, createTestRequest("GET", "/test", map[string]string{"X-Request-ID": requestID}))
+		
+		assert.NotEqual(s.T(), requestID, w.Header().Get("X-Request-ID"))
+		_, err := uuid.Parse(w.Header().Get("X-Request-ID"))
+		assert.NoError(s.T(), err)
+	}
+}
+
+// TestRequestID_StoresIDInContext tests that handlers and loggers can read the request ID from the request context
+func (s *MiddlewareSuite) TestRequestID_StoresIDInContext() {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RequestID())
+	
+	var fromGin, fromContext string
+	router.GET("/test", func(c *gin.Context) {
+		fromGin = GetRequestID(c)
+		fromContext = logger.RequestIDFromContext(c.Request.Context())
+		c.Status(http.StatusOK)
+	})
+	
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, createTestRequest("GET", "/test", map[string]string{"X-Request-ID": "req-1"}))
+	
+	assert.Equal(s.T(), "req-1", fromGin)
+	assert.Equal(s.T(), "req-1", fromContext)
+}
+
+// TestRedactHeaders tests that credentials and configured headers are redacted in the access log
+func (s *MiddlewareSuite) TestRedactHeaders() {
+	header := http.Header{}
+	header.Set("Authorization", "Bearer secret")
+	header.Set("X-Tenant-Secret", "secret")
+	header.Set("Accept", "application/json")
+	
+	redacted := map[string]bool{"Authorization": true, "X-Tenant-Secret": true}
+	headers := redactHeaders(header, redacted)
+	
+	assert.Equal(s.T(), "[REDACTED]", headers["Authorization"])
+	assert.Equal(s.T(), "[REDACTED]", headers["X-Tenant-Secret"])
+	assert.Equal(s.T(), "application/json", headers["Accept"])
+}
+
+// TestRedactQuery tests that tokens and signatures are redacted from query strings whatever their case
+func (s *MiddlewareSuite) TestRedactQuery() {
+	query, err := url.ParseQuery("X-Amz-Signature=abc&token=xyz&page=2")
+	assert.NoError(s.T(), err)
+	
+	redacted := map[string]bool{"x-amz-signature": true, "token": true}
+	
+	assert.Equal(s.T(), "X-Amz-Signature=%5BREDACTED%5D&page=2&token=%5BREDACTED%5D", redactQuery(query, redacted))
+}
+
 // TestRateLimiterMiddleware tests that RateLimiterMiddleware enforces rate limits
 func (s *MiddlewareSuite) TestRateLimiterMiddleware() {
 	// Arrange - create router with a low rate limit
@@ -378,6 +473,458 @@ func (s *MiddlewareSuite) TestRateLimiterMiddleware() {
 	assert.Equal(s.T(), http.StatusTooManyRequests, w3.Code)
 }
 
+// fakeRateLimitRepository hands out a fixed number of tokens per bucket key
+type fakeRateLimitRepository struct {
+	taken map[string]int
+	err   error
+}
+
+func (r *fakeRateLimitRepository) Take(ctx context.Context, key string, limit models.RateLimit) (*models.RateLimitDecision, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	if r.taken[key] >= limit.Capacity() {
+		return &models.RateLimitDecision{Allowed: false, Limit: limit.Capacity(), RetryAfter: 1500 * time.Millisecond}, nil
+	}
+	r.taken[key]++
+	return &models.RateLimitDecision{Allowed: true, Limit: limit.Capacity(), Remaining: limit.Capacity() - r.taken[key]}, nil
+}
+
+// setupRateLimitRouter creates a test router authenticating requests as the user in the X-Test-User header
+func setupRateLimitRouter(s *MiddlewareSuite, cfg config.RateLimiterConfig, repository *fakeRateLimitRepository) *gin.Engine {
+	authenticate := func(c *gin.Context) {
+		c.Set(contextKeyTenantID, "tenant-123")
+		c.Set(contextKeyUserID, c.GetHeader("X-Test-User"))
+		c.Next()
+	}
+	return setupTestRouter(s, authenticate, RateLimit(cfg, repository))
+}
+
+// TestRateLimit_ThrottlesUser tests that a user's requests are throttled once their bucket is empty
+// without affecting other users of the tenant
+func (s *MiddlewareSuite) TestRateLimit_ThrottlesUser() {
+	repository := &fakeRateLimitRepository{taken: map[string]int{}}
+	router := setupRateLimitRouter(s, config.RateLimiterConfig{
+		Enabled: true,
+		Tenant:  config.RateLimitRule{RequestsPerMinute: 100},
+		User:    config.RateLimitRule{RequestsPerMinute: 60, BurstSize: 2},
+	}, repository)
+
+	for i := 0; i < 2; i++ {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, createTestRequest("GET", "/test", map[string]string{"X-Test-User": "user-1"}))
+		assert.Equal(s.T(), http.StatusOK, w.Code)
+		assert.Equal(s.T(), "2", w.Header().Get("X-RateLimit-Limit"))
+	}
+
+	// The third request of the same user is throttled
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, createTestRequest("GET", "/test", map[string]string{"X-Test-User": "user-1"}))
+	assert.Equal(s.T(), http.StatusTooManyRequests, w.Code)
+	assert.Equal(s.T(), "2", w.Header().Get("Retry-After"))
+
+	// Another user of the tenant is not
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, createTestRequest("GET", "/test", map[string]string{"X-Test-User": "user-2"}))
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+}
+
+// TestRateLimit_Route tests that route limits only apply to their route
+func (s *MiddlewareSuite) TestRateLimit_Route() {
+	repository := &fakeRateLimitRepository{taken: map[string]int{}}
+	router := setupRateLimitRouter(s, config.RateLimiterConfig{
+		Enabled: true,
+		Routes:  []config.RouteRateLimitRule{{Method: "post", Path: "/test", RequestsPerMinute: 1}},
+	}, repository)
+	router.POST("/test", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, createTestRequest("POST", "/test", map[string]string{"X-Test-User": "user-1"}))
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, createTestRequest("POST", "/test", map[string]string{"X-Test-User": "user-1"}))
+	assert.Equal(s.T(), http.StatusTooManyRequests, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, createTestRequest("GET", "/test", map[string]string{"X-Test-User": "user-1"}))
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+}
+
+// TestRateLimit_StoreUnavailable tests that requests are let through when the buckets cannot be reached
+func (s *MiddlewareSuite) TestRateLimit_StoreUnavailable() {
+	repository := &fakeRateLimitRepository{err: errors.NewDependencyError("redis unavailable")}
+	router := setupRateLimitRouter(s, config.RateLimiterConfig{
+		Enabled: true,
+		User:    config.RateLimitRule{RequestsPerMinute: 1},
+	}, repository)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, createTestRequest("GET", "/test", map[string]string{"X-Test-User": "user-1"}))
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+}
+
+// fakeNetworkPolicyService refuses requests from a single address
+type fakeNetworkPolicyService struct {
+	refused string
+	err     error
+	checked []string
+}
+
+func (f *fakeNetworkPolicyService) CheckAccess(ctx context.Context, tenantID, userID string, ip net.IP) error {
+	f.checked = append(f.checked, ip.String())
+	if f.err != nil {
+		return f.err
+	}
+	if ip.Equal(net.ParseIP(f.refused)) {
+		return errors.NewAuthorizationError("access from this network is not allowed by the tenant")
+	}
+	return nil
+}
+
+// setupNetworkPolicyRouter creates a test router authenticating requests as a user of tenant-123,
+// unless the X-Test-Anonymous header is set, and trusting no proxies
+func setupNetworkPolicyRouter(s *MiddlewareSuite, networkPolicyService *fakeNetworkPolicyService) *gin.Engine {
+	authenticate := func(c *gin.Context) {
+		if c.GetHeader("X-Test-Anonymous") == "" {
+			c.Set(contextKeyTenantID, "tenant-123")
+			c.Set(contextKeyUserID, "user-1")
+		}
+		c.Next()
+	}
+	router := setupTestRouter(s, authenticate, NetworkPolicy(networkPolicyService))
+	s.Require().NoError(router.SetTrustedProxies(nil))
+	return router
+}
+
+// TestNetworkPolicy_RefusesDisallowedAddress tests that requests the tenant's network policy does
+// not allow are refused, and that X-Forwarded-For from untrusted clients is ignored
+func (s *MiddlewareSuite) TestNetworkPolicy_RefusesDisallowedAddress() {
+	networkPolicyService := &fakeNetworkPolicyService{refused: "192.0.2.1"}
+	router := setupNetworkPolicyRouter(s, networkPolicyService)
+
+	// httptest requests come from 192.0.2.1
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, createTestRequest("GET", "/test", map[string]string{"X-Forwarded-For": "198.51.100.1"}))
+
+	assert.Equal(s.T(), http.StatusForbidden, w.Code)
+	assert.Equal(s.T(), []string{"192.0.2.1"}, networkPolicyService.checked)
+}
+
+// TestNetworkPolicy_AllowsAddress tests that allowed requests and requests without a tenant pass
+func (s *MiddlewareSuite) TestNetworkPolicy_AllowsAddress() {
+	networkPolicyService := &fakeNetworkPolicyService{refused: "198.51.100.1"}
+	router := setupNetworkPolicyRouter(s, networkPolicyService)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, createTestRequest("GET", "/test", nil))
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, createTestRequest("GET", "/test", map[string]string{"X-Test-Anonymous": "1"}))
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+	assert.Len(s.T(), networkPolicyService.checked, 1)
+}
+
+// TestNetworkPolicy_CheckFails tests that requests are not let through when the policy cannot be read
+func (s *MiddlewareSuite) TestNetworkPolicy_CheckFails() {
+	router := setupNetworkPolicyRouter(s, &fakeNetworkPolicyService{err: errors.NewInternalError("database unavailable")})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, createTestRequest("GET", "/test", nil))
+
+	assert.Equal(s.T(), http.StatusInternalServerError, w.Code)
+}
+
+// TestPlatformOperatorAuthentication tests that only requests with the operator token are let through
+func (s *MiddlewareSuite) TestPlatformOperatorAuthentication() {
+	router := setupTestRouter(s, PlatformOperatorAuthentication("operator-token"))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, createTestRequest("GET", "/test", map[string]string{"Authorization": "Bearer operator-token"}))
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, createTestRequest("GET", "/test", map[string]string{"Authorization": "Bearer other-token"}))
+	assert.Equal(s.T(), http.StatusUnauthorized, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, createTestRequest("GET", "/test", nil))
+	assert.Equal(s.T(), http.StatusUnauthorized, w.Code)
+
+	// Without an operator token, every request is refused
+	router = setupTestRouter(s, PlatformOperatorAuthentication(""))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, createTestRequest("GET", "/test", map[string]string{"Authorization": "Bearer "}))
+	assert.Equal(s.T(), http.StatusUnauthorized, w.Code)
+}
+
+// fakeIdempotencyRepository keeps idempotency records in memory
+type fakeIdempotencyRepository struct {
+	records map[string]*models.IdempotencyRecord
+}
+
+func (r *fakeIdempotencyRepository) Reserve(ctx context.Context, record *models.IdempotencyRecord, ttl time.Duration) (bool, *models.IdempotencyRecord, error) {
+	if existing, ok := r.records[record.Key]; ok {
+		return false, existing, nil
+	}
+	stored := *record
+	r.records[record.Key] = &stored
+	return true, nil, nil
+}
+
+func (r *fakeIdempotencyRepository) Save(ctx context.Context, record *models.IdempotencyRecord, ttl time.Duration) error {
+	stored := *record
+	r.records[record.Key] = &stored
+	return nil
+}
+
+func (r *fakeIdempotencyRepository) Release(ctx context.Context, tenantID, userID, key string) error {
+	delete(r.records, key)
+	return nil
+}
+
+// setupIdempotencyRouter creates a test router with an upload endpoint counting its calls
+func setupIdempotencyRouter(s *MiddlewareSuite, repository *fakeIdempotencyRepository, status *int, calls *int) *gin.Engine {
+	authenticate := func(c *gin.Context) {
+		c.Set(contextKeyTenantID, "tenant-123")
+		c.Set(contextKeyUserID, "user-123")
+		c.Next()
+	}
+	router := setupTestRouter(s, authenticate, Idempotency(config.IdempotencyConfig{Enabled: true, KeyTTL: "1h"}, repository))
+	router.POST("/documents", func(c *gin.Context) {
+		*calls++
+		c.JSON(*status, gin.H{"call": *calls})
+	})
+	return router
+}
+
+// TestIdempotency_ReplaysResponse tests that a retried request gets the original response without running again
+func (s *MiddlewareSuite) TestIdempotency_ReplaysResponse() {
+	repository := &fakeIdempotencyRepository{records: map[string]*models.IdempotencyRecord{}}
+	status, calls := http.StatusCreated, 0
+	router := setupIdempotencyRouter(s, repository, &status, &calls)
+
+	w1 := httptest.NewRecorder()
+	router.ServeHTTP(w1, createTestRequest("POST", "/documents", map[string]string{"Idempotency-Key": "upload-1"}))
+	w2 := httptest.NewRecorder()
+	router.ServeHTTP(w2, createTestRequest("POST", "/documents", map[string]string{"Idempotency-Key": "upload-1"}))
+
+	assert.Equal(s.T(), 1, calls)
+	assert.Equal(s.T(), http.StatusCreated, w2.Code)
+	assert.Equal(s.T(), w1.Body.String(), w2.Body.String())
+	assert.Equal(s.T(), "true", w2.Header().Get("Idempotent-Replayed"))
+}
+
+// TestIdempotency_ServerErrorNotStored tests that requests failing with a server error can be retried
+func (s *MiddlewareSuite) TestIdempotency_ServerErrorNotStored() {
+	repository := &fakeIdempotencyRepository{records: map[string]*models.IdempotencyRecord{}}
+	status, calls := http.StatusInternalServerError, 0
+	router := setupIdempotencyRouter(s, repository, &status, &calls)
+
+	router.ServeHTTP(httptest.NewRecorder(), createTestRequest("POST", "/documents", map[string]string{"Idempotency-Key": "upload-1"}))
+	status = http.StatusCreated
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, createTestRequest("POST", "/documents", map[string]string{"Idempotency-Key": "upload-1"}))
+
+	assert.Equal(s.T(), 2, calls)
+	assert.Equal(s.T(), http.StatusCreated, w.Code)
+	assert.Empty(s.T(), w.Header().Get("Idempotent-Replayed"))
+}
+
+// TestIdempotency_InProgressAndReuse tests that concurrent retries conflict and keys cannot be reused for other requests
+func (s *MiddlewareSuite) TestIdempotency_InProgressAndReuse() {
+	repository := &fakeIdempotencyRepository{records: map[string]*models.IdempotencyRecord{}}
+	status, calls := http.StatusCreated, 0
+	router := setupIdempotencyRouter(s, repository, &status, &calls)
+
+	// A request with the key is still running
+	emptyBody := sha256.Sum256(nil)
+	repository.records["upload-1"] = models.NewIdempotencyRecord("upload-1", "tenant-123", "user-123",
+		models.IdempotencyFingerprint("POST", "/documents", emptyBody[:]))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, createTestRequest("POST", "/documents", map[string]string{"Idempotency-Key": "upload-1"}))
+	assert.Equal(s.T(), http.StatusConflict, w.Code)
+
+	// The key was used for another request
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, createTestRequest("POST", "/documents?folder=other", map[string]string{"Idempotency-Key": "upload-1"}))
+	assert.Equal(s.T(), http.StatusUnprocessableEntity, w.Code)
+	assert.Equal(s.T(), 0, calls)
+}
+
+// TestIdempotency_BodyReuse tests that a key cannot be reused for the same endpoint with another body,
+// while a retried multipart body with a new boundary is replayed
+func (s *MiddlewareSuite) TestIdempotency_BodyReuse() {
+	repository := &fakeIdempotencyRepository{records: map[string]*models.IdempotencyRecord{}}
+	status, calls := http.StatusCreated, 0
+	router := setupIdempotencyRouter(s, repository, &status, &calls)
+
+	newRequest := func(body string, contentType string) *http.Request {
+		req := httptest.NewRequest("POST", "/documents", strings.NewReader(body))
+		req.Header.Set("Idempotency-Key", "upload-1")
+		req.Header.Set("Content-Type", contentType)
+		return req
+	}
+
+	router.ServeHTTP(httptest.NewRecorder(), newRequest("--aaa\r\nreport\r\n--aaa--", "multipart/form-data; boundary=aaa"))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newRequest("--bbb\r\nreport\r\n--bbb--", "multipart/form-data; boundary=bbb"))
+	assert.Equal(s.T(), http.StatusCreated, w.Code)
+	assert.Equal(s.T(), "true", w.Header().Get("Idempotent-Replayed"))
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, newRequest("--aaa\r\ninvoice\r\n--aaa--", "multipart/form-data; boundary=aaa"))
+	assert.Equal(s.T(), http.StatusUnprocessableEntity, w.Code)
+	assert.Equal(s.T(), 1, calls)
+}
+
+// TestIdempotency_LargeAndDownloadResponsesNotStored tests that responses too large to store and
+// downloads are not replayed, so that retries run again
+func (s *MiddlewareSuite) TestIdempotency_LargeAndDownloadResponsesNotStored() {
+	repository := &fakeIdempotencyRepository{records: map[string]*models.IdempotencyRecord{}}
+	status, calls := http.StatusCreated, 0
+	router := setupIdempotencyRouter(s, repository, &status, &calls)
+	router.POST("/export", func(c *gin.Context) {
+		calls++
+		c.Data(http.StatusOK, "text/plain", make([]byte, maxIdempotentResponseSize+1))
+	})
+	router.POST("/download", func(c *gin.Context) {
+		calls++
+		c.Header("Content-Disposition", "attachment; filename=documents.zip")
+		c.Data(http.StatusOK, "application/zip", []byte("PK"))
+	})
+
+	for _, path := range []string{"/export", "/download"} {
+		calls = 0
+		router.ServeHTTP(httptest.NewRecorder(), createTestRequest("POST", path, map[string]string{"Idempotency-Key": "key" + path}))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, createTestRequest("POST", path, map[string]string{"Idempotency-Key": "key" + path}))
+
+		assert.Equal(s.T(), 2, calls, path)
+		assert.Empty(s.T(), w.Header().Get("Idempotent-Replayed"), path)
+		assert.NotContains(s.T(), repository.records, "key"+path)
+	}
+}
+
+// fakeTenantSettingsService returns the same preferences for every tenant
+type fakeTenantSettingsService struct {
+	language string
+	err      error
+	calls    int
+}
+
+func (f *fakeTenantSettingsService) Preferences(ctx context.Context, tenantID string) (models.TenantPreferences, error) {
+	f.calls++
+	if f.err != nil {
+		return models.TenantPreferences{}, f.err
+	}
+	return models.TenantPreferences{Language: f.language}, nil
+}
+
+// setupLocalizationRouter creates a test router localizing requests before and after authenticating
+// them as a user of tenant-123, whose /language endpoint returns the language of the request
+func setupLocalizationRouter(s *MiddlewareSuite, tenantSettingsService *fakeTenantSettingsService) *gin.Engine {
+	authenticate := func(c *gin.Context) {
+		c.Set(contextKeyTenantID, "tenant-123")
+		c.Set(contextKeyUserID, "user-1")
+		c.Next()
+	}
+	router := setupTestRouter(s, Localization(nil), authenticate, Localization(tenantSettingsService))
+	router.GET("/language", func(c *gin.Context) {
+		c.String(http.StatusOK, GetLanguage(c)+" "+i18n.LanguageFromContext(c.Request.Context()))
+	})
+	return router
+}
+
+// TestLocalization_AcceptLanguage tests that the language the client prefers wins over the tenant's
+func (s *MiddlewareSuite) TestLocalization_AcceptLanguage() {
+	tenantSettingsService := &fakeTenantSettingsService{language: "fr"}
+	router := setupLocalizationRouter(s, tenantSettingsService)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, createTestRequest("GET", "/language", map[string]string{"Accept-Language": "ja, de-DE;q=0.9, en;q=0.5"}))
+
+	assert.Equal(s.T(), "de de", w.Body.String())
+	assert.Equal(s.T(), []string{"Accept-Language"}, w.Header().Values("Vary"))
+	assert.Equal(s.T(), 0, tenantSettingsService.calls)
+}
+
+// TestLocalization_TenantLanguage tests that the tenant's default language applies when the client
+// prefers no translated language, and English when the tenant's is not translated or cannot be read
+func (s *MiddlewareSuite) TestLocalization_TenantLanguage() {
+	router := setupLocalizationRouter(s, &fakeTenantSettingsService{language: "pt-BR"})
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, createTestRequest("GET", "/language", map[string]string{"Accept-Language": "ja"}))
+	assert.Equal(s.T(), "pt pt", w.Body.String())
+	assert.Equal(s.T(), []string{"Accept-Language"}, w.Header().Values("Vary"))
+
+	router = setupLocalizationRouter(s, &fakeTenantSettingsService{language: "ja"})
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, createTestRequest("GET", "/language", nil))
+	assert.Equal(s.T(), "en en", w.Body.String())
+
+	router = setupLocalizationRouter(s, &fakeTenantSettingsService{err: errors.NewDependencyError("database unavailable")})
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, createTestRequest("GET", "/language", nil))
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+	assert.Equal(s.T(), "en en", w.Body.String())
+}
+
+// TestLocalization_ErrorResponse tests that middleware errors are problem details carrying their
+// code and a localized title and detail
+func (s *MiddlewareSuite) TestLocalization_ErrorResponse() {
+	router := setupTestRouter(s, ProblemDetails(), Localization(nil), PlatformOperatorAuthentication("operator-token"))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, createTestRequest("GET", "/test", map[string]string{
+		"Authorization":   "Bearer other-token",
+		"Accept-Language": "fr-CA",
+	}))
+
+	assert.Equal(s.T(), http.StatusUnauthorized, w.Code)
+	assert.Equal(s.T(), "application/problem+json; charset=utf-8", w.Header().Get("Content-Type"))
+	var response struct {
+		Type   string `json:"type"`
+		Title  string `json:"title"`
+		Status int    `json:"status"`
+		Detail string `json:"detail"`
+		Code   string `json:"code"`
+		Error  struct {
+			Type    string `json:"type"`
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(s.T(), "/api/v1/errors/invalid_platform_operator_token", response.Type)
+	assert.Equal(s.T(), "Non authentifié", response.Title)
+	assert.Equal(s.T(), http.StatusUnauthorized, response.Status)
+	assert.Equal(s.T(), "Le jeton d'opérateur de la plateforme est manquant ou invalide.", response.Detail)
+	assert.Equal(s.T(), "invalid_platform_operator_token", response.Code)
+
+	// The members of error responses before problem details are kept for existing clients
+	assert.Equal(s.T(), errors.ErrorTypeAuthentication, response.Error.Type)
+	assert.Equal(s.T(), "invalid_platform_operator_token", response.Error.Code)
+	assert.Equal(s.T(), response.Detail, response.Error.Message)
+}
+
+// TestProblemDetails_SuccessResponse tests that responses other than errors keep their content type
+func (s *MiddlewareSuite) TestProblemDetails_SuccessResponse() {
+	router := setupTestRouter(s, ProblemDetails())
+	router.GET("/json", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"success": true})
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, createTestRequest("GET", "/json", nil))
+
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+	assert.Equal(s.T(), "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+}
+
 // TestRecoveryMiddleware tests that RecoveryMiddleware catches panics
 func (s *MiddlewareSuite) TestRecoveryMiddleware() {
 	// Arrange - create router with recovery middleware and a handler that panics
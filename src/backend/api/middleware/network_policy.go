@@ -0,0 +1,44 @@
+// Package middleware provides HTTP middleware components for the Document Management Platform.
+// This file implements the network policy middleware that refuses requests from networks and
+// countries a tenant does not allow.
+package middleware
+
+import (
+	"net"      // standard library
+	"net/http" // standard library
+
+	"github.com/gin-gonic/gin" // v1.9.0+
+
+	"../../domain/services"
+	"../../pkg/errors"
+	"../../pkg/logger"
+	"../dto/error_dto"
+)
+
+// NetworkPolicy creates a middleware that refuses requests the network policy of the caller's
+// tenant does not allow with 403 Forbidden. It must run after authentication, which sets the
+// tenant, and after AuditContext, so that refused requests are audited with their details.
+// The client address is taken from X-Forwarded-For only when the request came through one of
+// the router's trusted proxies.
+func NetworkPolicy(networkPolicyService services.NetworkPolicyService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantID := GetTenantID(c)
+		if tenantID == "" {
+			c.Next()
+			return
+		}
+
+		err := networkPolicyService.CheckAccess(c.Request.Context(), tenantID, GetUserID(c), net.ParseIP(getClientIP(c)))
+		if err == nil {
+			c.Next()
+			return
+		}
+
+		if errors.IsAuthorizationError(err) {
+			c.AbortWithStatusJSON(http.StatusForbidden, errordto.NewAuthorizationErrorResponse(c.Request.Context(), err))
+			return
+		}
+		logger.ErrorContext(c.Request.Context(), "Failed to check tenant network policy", "tenant_id", tenantID, "error", err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, errordto.NewInternalErrorResponse(c.Request.Context(), err))
+	}
+}
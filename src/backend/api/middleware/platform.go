@@ -0,0 +1,38 @@
+// Package middleware provides HTTP middleware components for the Document Management Platform.
+// This file implements the authentication of platform operators, who manage tenants rather than
+// act within one.
+package middleware
+
+import (
+	"crypto/subtle" // standard library
+	"net/http"      // standard library
+
+	"github.com/gin-gonic/gin" // v1.9.0+
+
+	"../../pkg/errors"
+	"../../pkg/logger"
+	"../dto/error_dto"
+)
+
+// PlatformOperatorAuthentication creates a Gin middleware that only lets through requests carrying
+// the operator token as a bearer token. Platform requests act in no tenant, so no user or tenant is
+// set in the context. An empty operator token refuses every request.
+func PlatformOperatorAuthentication(operatorToken string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, err := extractTokenFromHeader(c)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, errordto.NewAuthenticationErrorResponse(c.Request.Context(), err))
+			return
+		}
+
+		if operatorToken == "" || subtle.ConstantTimeCompare([]byte(token), []byte(operatorToken)) != 1 {
+			logger.InfoContext(c.Request.Context(), "Authentication failed: invalid platform operator token")
+			c.AbortWithStatusJSON(http.StatusUnauthorized, errordto.NewAuthenticationErrorResponse(
+				c.Request.Context(),
+				errors.WithCode(errors.NewAuthenticationError("Invalid platform operator token"), errors.CodeInvalidPlatformOperatorToken)))
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,54 @@
+// Package middleware provides HTTP middleware components for the Document Management Platform.
+// This file implements the middleware that marks error responses as problem details (RFC 7807).
+package middleware
+
+import (
+	"net/http" // standard library
+	"strings"  // standard library
+
+	"github.com/gin-gonic/gin" // v1.9.0+
+
+	errordto "../dto/error_dto"
+)
+
+// jsonContentType is the media type error responses are rendered with by the handlers
+const jsonContentType = "application/json"
+
+// problemResponseWriter sets the content type of JSON error responses to the problem details one
+// before their body is written
+type problemResponseWriter struct {
+	gin.ResponseWriter
+}
+
+// Write writes the response body to the client
+func (w *problemResponseWriter) Write(data []byte) (int, error) {
+	w.setProblemContentType()
+	return w.ResponseWriter.Write(data)
+}
+
+// WriteString writes the response body to the client
+func (w *problemResponseWriter) WriteString(s string) (int, error) {
+	w.setProblemContentType()
+	return w.ResponseWriter.WriteString(s)
+}
+
+// setProblemContentType replaces the JSON content type of error responses whose headers were not
+// sent yet
+func (w *problemResponseWriter) setProblemContentType() {
+	if w.Written() || w.Status() < http.StatusBadRequest {
+		return
+	}
+	if strings.HasPrefix(w.Header().Get("Content-Type"), jsonContentType) {
+		w.Header().Set("Content-Type", errordto.ProblemContentType+"; charset=utf-8")
+	}
+}
+
+// ProblemDetails creates a middleware returning JSON error responses with the
+// application/problem+json content type, so that handlers and middleware keep rendering errors
+// with c.JSON. It should run before any middleware that can respond with an error.
+func ProblemDetails() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Writer = &problemResponseWriter{ResponseWriter: c.Writer}
+		c.Next()
+	}
+}
@@ -6,7 +6,9 @@
 package middleware
 
 import (
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -15,9 +17,12 @@ import (
 	"github.com/ulule/limiter/v3/drivers/store/memory" // v3.10.0+
 	"github.com/ulule/limiter/v3/drivers/store/redis" // v3.10.0+
 
+	"../../domain/models"
+	"../../domain/repositories"
 	"../../pkg/config"
 	"../../pkg/errors"
 	"../../pkg/logger"
+	"../../pkg/metrics"
 	"../dto/error_dto"
 )
 
@@ -34,6 +39,7 @@ const (
 	headerRateLimit = "X-RateLimit-Limit"
 	headerRateRemaining = "X-RateLimit-Remaining"
 	headerRateReset = "X-RateLimit-Reset"
+	headerRetryAfter = "Retry-After"
 )
 
 // RateLimiterMiddleware creates a Gin middleware that applies rate limiting to requests
@@ -82,8 +88,8 @@ func RateLimiterMiddleware(cfg config.Config) gin.HandlerFunc {
 				"reset", limiterContext.Reset)
 			
 			// If exceeded, log the event and abort with 429 Too Many Requests
-			validationErr := errors.NewValidationError(rateLimitExceededMessage)
-			errorResponse := errordto.NewErrorResponse(validationErr)
+			rateLimitErr := errors.WithCode(errors.NewQuotaExceededError(rateLimitExceededMessage), errors.CodeRateLimitExceeded)
+			errorResponse := errordto.NewErrorResponse(c.Request.Context(), rateLimitErr)
 			c.JSON(http.StatusTooManyRequests, errorResponse)
 			c.Abort()
 			return
@@ -154,8 +160,8 @@ func UploadRateLimiterMiddleware(cfg config.Config) gin.HandlerFunc {
 				"reset", limiterContext.Reset)
 			
 			// If exceeded, log the event and abort with 429 Too Many Requests
-			validationErr := errors.NewValidationError(rateLimitExceededMessage)
-			errorResponse := errordto.NewErrorResponse(validationErr)
+			rateLimitErr := errors.WithCode(errors.NewQuotaExceededError(rateLimitExceededMessage), errors.CodeRateLimitExceeded)
+			errorResponse := errordto.NewErrorResponse(c.Request.Context(), rateLimitErr)
 			c.JSON(http.StatusTooManyRequests, errorResponse)
 			c.Abort()
 			return
@@ -226,8 +232,8 @@ func SearchRateLimiterMiddleware(cfg config.Config) gin.HandlerFunc {
 				"reset", limiterContext.Reset)
 			
 			// If exceeded, log the event and abort with 429 Too Many Requests
-			validationErr := errors.NewValidationError(rateLimitExceededMessage)
-			errorResponse := errordto.NewErrorResponse(validationErr)
+			rateLimitErr := errors.WithCode(errors.NewQuotaExceededError(rateLimitExceededMessage), errors.CodeRateLimitExceeded)
+			errorResponse := errordto.NewErrorResponse(c.Request.Context(), rateLimitErr)
 			c.JSON(http.StatusTooManyRequests, errorResponse)
 			c.Abort()
 			return
@@ -294,8 +300,8 @@ func TenantRateLimiterMiddleware(cfg config.Config, rate string) gin.HandlerFunc
 				"reset", limiterContext.Reset)
 			
 			// If exceeded, log the event and abort with 429 Too Many Requests
-			validationErr := errors.NewValidationError(rateLimitExceededMessage)
-			errorResponse := errordto.NewErrorResponse(validationErr)
+			rateLimitErr := errors.WithCode(errors.NewQuotaExceededError(rateLimitExceededMessage), errors.CodeRateLimitExceeded)
+			errorResponse := errordto.NewErrorResponse(c.Request.Context(), rateLimitErr)
 			c.JSON(http.StatusTooManyRequests, errorResponse)
 			c.Abort()
 			return
@@ -348,16 +354,125 @@ func setRateLimitHeaders(c *gin.Context, context limiter.Context) {
 	c.Header(headerRateReset, time.Unix(context.Reset, 0).Format(time.RFC3339))
 }
 
-// getClientIP extracts the client IP address from the request
+// getClientIP extracts the client IP address from the request. The X-Forwarded-For header is only
+// followed through the trusted proxies configured on the router, since clients can set it to any
+// address.
 func getClientIP(c *gin.Context) string {
-	// Check for X-Forwarded-For header
-	if xff := c.GetHeader("X-Forwarded-For"); xff != "" {
-		// If present, extract the first IP address
-		ips := strings.Split(xff, ",")
-		ip := strings.TrimSpace(ips[0])
-		return ip
-	}
-	
-	// If not present, use the request's remote address
 	return c.ClientIP()
-}
\ No newline at end of file
+}
+
+// rateLimitBucket is a token bucket that applies to a request
+type rateLimitBucket struct {
+	scope string
+	key   string
+	limit models.RateLimit
+}
+
+// RateLimit creates a Gin middleware that throttles authenticated requests with the token buckets
+// configured for their route, their user or API key, and their tenant. It must run after
+// authentication. Requests are throttled when any bucket is empty, with a 429 response whose
+// Retry-After header tells when to try again. When the buckets cannot be reached, requests are let
+// through rather than failing the API.
+func RateLimit(cfg config.RateLimiterConfig, repository repositories.RateLimitRepository) gin.HandlerFunc {
+	if !cfg.Enabled {
+		return func(c *gin.Context) {
+			c.Next()
+		}
+	}
+
+	// Index route limits by method and path pattern
+	routeLimits := make(map[string]models.RateLimit, len(cfg.Routes))
+	for _, route := range cfg.Routes {
+		routeLimits[strings.ToUpper(route.Method)+" "+route.Path] = toRateLimit(route.RequestsPerMinute, route.BurstSize)
+	}
+	tenantLimit := toRateLimit(cfg.Tenant.RequestsPerMinute, cfg.Tenant.BurstSize)
+	userLimit := toRateLimit(cfg.User.RequestsPerMinute, cfg.User.BurstSize)
+
+	return func(c *gin.Context) {
+		route := c.Request.Method + " " + c.FullPath()
+		buckets := rateLimitBuckets(c, route, routeLimits[route], userLimit, tenantLimit)
+
+		var tightest *models.RateLimitDecision
+		for _, bucket := range buckets {
+			decision, err := repository.Take(c.Request.Context(), bucket.key, bucket.limit)
+			if err != nil {
+				logger.ErrorContext(c.Request.Context(), "Failed to take rate limit token", "error", err.Error(), "scope", bucket.scope)
+				continue
+			}
+
+			if !decision.Allowed {
+				logger.WarnContext(c.Request.Context(), "Rate limit exceeded",
+					"scope", bucket.scope,
+					"route", route,
+					"tenant_id", GetTenantID(c),
+					"user_id", GetUserID(c),
+					"retry_after", decision.RetryAfter.String())
+				metrics.IncHTTPRequestsThrottled(bucket.scope, route)
+
+				retryAfter := int(math.Ceil(decision.RetryAfter.Seconds()))
+				if retryAfter < 1 {
+					retryAfter = 1
+				}
+				c.Header(headerRetryAfter, strconv.Itoa(retryAfter))
+				setTokenBucketHeaders(c, decision)
+				c.AbortWithStatusJSON(http.StatusTooManyRequests, errordto.NewErrorResponse(
+					c.Request.Context(),
+					errors.WithMessageKey(
+						errors.WithCode(errors.NewQuotaExceededError(rateLimitExceededMessage), errors.CodeRateLimitExceeded),
+						"rate_limit_exceeded_retry",
+						map[string]string{"seconds": strconv.Itoa(retryAfter)},
+					)))
+				return
+			}
+
+			if tightest == nil || decision.Remaining < tightest.Remaining {
+				tightest = decision
+			}
+		}
+
+		// Report the bucket closest to running out
+		if tightest != nil {
+			setTokenBucketHeaders(c, tightest)
+		}
+
+		c.Next()
+	}
+}
+
+// rateLimitBuckets returns the buckets that apply to a request, from the most to the least specific.
+// Requests authenticated with an API key are limited per key rather than per principal, so that the
+// keys of an integration do not share a bucket.
+func rateLimitBuckets(c *gin.Context, route string, routeLimit, userLimit, tenantLimit models.RateLimit) []rateLimitBucket {
+	tenantID := GetTenantID(c)
+	callerScope, callerID := models.RateLimitScopeUser, GetUserID(c)
+	if apiKeyID := GetAPIKeyID(c); apiKeyID != "" {
+		callerScope, callerID = models.RateLimitScopeAPIKey, apiKeyID
+	}
+	if callerID == "" {
+		callerScope, callerID = "ip", getClientIP(c)
+	}
+	caller := tenantID + ":" + callerScope + ":" + callerID
+
+	var buckets []rateLimitBucket
+	if routeLimit.Enabled() {
+		buckets = append(buckets, rateLimitBucket{scope: models.RateLimitScopeRoute, key: models.RateLimitScopeRoute + ":" + route + ":" + caller, limit: routeLimit})
+	}
+	if userLimit.Enabled() {
+		buckets = append(buckets, rateLimitBucket{scope: callerScope, key: caller, limit: userLimit})
+	}
+	if tenantLimit.Enabled() && tenantID != "" {
+		buckets = append(buckets, rateLimitBucket{scope: models.RateLimitScopeTenant, key: models.RateLimitScopeTenant + ":" + tenantID, limit: tenantLimit})
+	}
+	return buckets
+}
+
+// toRateLimit converts a configured rate and burst size to a token bucket limit
+func toRateLimit(requestsPerMinute, burstSize int) models.RateLimit {
+	return models.RateLimit{RequestsPerMinute: requestsPerMinute, Burst: burstSize}
+}
+
+// setTokenBucketHeaders sets rate limit headers describing a token bucket in the response
+func setTokenBucketHeaders(c *gin.Context, decision *models.RateLimitDecision) {
+	c.Header(headerRateLimit, strconv.Itoa(decision.Limit))
+	c.Header(headerRateRemaining, strconv.Itoa(decision.Remaining))
+}
@@ -36,7 +36,7 @@ func RecoveryMiddleware() gin.HandlerFunc {
 				// Abort the request chain with HTTP 500 status code
 				c.AbortWithStatusJSON(
 					http.StatusInternalServerError,
-					errordto.NewInternalErrorResponse(err),
+					errordto.NewInternalErrorResponse(c.Request.Context(), err),
 				)
 			}
 		}()
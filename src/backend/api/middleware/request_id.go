@@ -0,0 +1,90 @@
+// Package middleware provides HTTP middleware components for the Document Management Platform API.
+package middleware
+
+import (
+	"github.com/gin-gonic/gin" // v1.9.0+
+	"github.com/google/uuid"   // v1.3.0+
+
+	"../../pkg/logger"
+)
+
+// Context and header constants for request identification
+const (
+	// contextKeyRequestID is the key used to store request ID in the context
+	contextKeyRequestID = "request_id"
+
+	// headerRequestID is the HTTP header name for the request ID
+	headerRequestID = "X-Request-ID"
+
+	// headerCorrelationID is the HTTP header some callers send their correlation ID in instead
+	headerCorrelationID = "X-Correlation-ID"
+
+	// maxRequestIDLength is the length past which an incoming request ID is replaced
+	maxRequestIDLength = 128
+)
+
+// RequestID creates a Gin middleware that identifies each request. The caller's X-Request-ID, or
+// X-Correlation-ID, is kept so a request can be followed from the client through the API, the
+// queues and the webhooks it triggers; a new ID is generated when the caller sends none or one
+// that is too long or contains characters other than letters, digits and "-_.:". The ID is stored
+// in the request context, where logger.WithContext adds it to log entries, and returned in the
+// X-Request-ID response header.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(headerRequestID)
+		if requestID == "" {
+			requestID = c.GetHeader(headerCorrelationID)
+		}
+		if !validRequestID(requestID) {
+			requestID = generateRequestID()
+		}
+
+		setRequestIDInContext(c, requestID)
+		c.Header(headerRequestID, requestID)
+
+		c.Next()
+	}
+}
+
+// GetRequestID extracts the request ID from the context.
+// Returns an empty string if the request ID is not found.
+//
+// This function is useful for other middleware or handlers that need
+// to access the request ID for correlation or logging purposes.
+func GetRequestID(c *gin.Context) string {
+	return c.GetString(contextKeyRequestID)
+}
+
+// validRequestID reports whether an incoming request ID can be kept. IDs are written to logs,
+// headers and message attributes, so only short IDs of unreserved characters are accepted.
+func validRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+	for _, r := range requestID {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-', r == '_', r == '.', r == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// generateRequestID creates a new unique request ID using UUID.
+// This ensures that each request can be uniquely identified across the system.
+func generateRequestID() string {
+	return uuid.New().String()
+}
+
+// setRequestIDInContext sets the request ID in both the Gin context and request context.
+// This ensures the request ID is available both to Gin middleware/handlers and through
+// the standard context mechanism for use with other packages.
+func setRequestIDInContext(c *gin.Context, requestID string) {
+	// Set in Gin context
+	c.Set(contextKeyRequestID, requestID)
+
+	// Set in request context
+	c.Request = c.Request.WithContext(logger.ContextWithRequestID(c.Request.Context(), requestID))
+}
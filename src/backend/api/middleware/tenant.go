@@ -30,7 +30,8 @@ func RequireTenantContext() gin.HandlerFunc {
 		if tenantID == "" {
 			logger.ErrorContext(c.Request.Context(), "Tenant context missing in request")
 			c.JSON(http.StatusUnauthorized, errordto.NewAuthenticationErrorResponse(
-				errors.NewAuthenticationError("Tenant context required"),
+				c.Request.Context(),
+				errordto.ErrTenantContextRequired,
 			))
 			c.Abort()
 			return
@@ -47,7 +48,8 @@ func RequireSameTenant(authService auth.AuthService) gin.HandlerFunc {
 		if !exists {
 			logger.ErrorContext(c.Request.Context(), "User ID missing in request context")
 			c.JSON(http.StatusUnauthorized, errordto.NewAuthenticationErrorResponse(
-				errors.NewAuthenticationError("User not authenticated"),
+				c.Request.Context(),
+				errors.WithCode(errors.NewAuthenticationError("User not authenticated"), errors.CodeAuthenticationRequired),
 			))
 			c.Abort()
 			return
@@ -57,7 +59,8 @@ func RequireSameTenant(authService auth.AuthService) gin.HandlerFunc {
 		if userTenantID == "" {
 			logger.ErrorContext(c.Request.Context(), "Tenant ID missing in request context")
 			c.JSON(http.StatusUnauthorized, errordto.NewAuthenticationErrorResponse(
-				errors.NewAuthenticationError("Tenant context required"),
+				c.Request.Context(),
+				errordto.ErrTenantContextRequired,
 			))
 			c.Abort()
 			return
@@ -68,6 +71,7 @@ func RequireSameTenant(authService auth.AuthService) gin.HandlerFunc {
 		if targetTenantID == "" {
 			logger.WarnContext(c.Request.Context(), "Target tenant ID missing in request path")
 			c.JSON(http.StatusBadRequest, errordto.NewValidationErrorResponse(
+				c.Request.Context(),
 				errors.NewValidationError("Target tenant ID required"),
 				map[string]string{"tenantId": "Required parameter missing"},
 			))
@@ -82,7 +86,8 @@ func RequireSameTenant(authService auth.AuthService) gin.HandlerFunc {
 				"target_tenant_id", targetTenantID,
 			)
 			c.JSON(http.StatusForbidden, errordto.NewAuthorizationErrorResponse(
-				errors.NewAuthorizationError("Access to the specified tenant is not authorized"),
+				c.Request.Context(),
+				errors.WithCode(errors.NewAuthorizationError("Access to the specified tenant is not authorized"), errors.CodeTenantAccessDenied),
 			))
 			c.Abort()
 			return
@@ -100,7 +105,8 @@ func VerifyTenantResourceAccess(authService auth.AuthService, resourceType, acce
 		if !exists {
 			logger.ErrorContext(c.Request.Context(), "User ID missing in request context")
 			c.JSON(http.StatusUnauthorized, errordto.NewAuthenticationErrorResponse(
-				errors.NewAuthenticationError("User not authenticated"),
+				c.Request.Context(),
+				errors.WithCode(errors.NewAuthenticationError("User not authenticated"), errors.CodeAuthenticationRequired),
 			))
 			c.Abort()
 			return
@@ -110,7 +116,8 @@ func VerifyTenantResourceAccess(authService auth.AuthService, resourceType, acce
 		if tenantID == "" {
 			logger.ErrorContext(c.Request.Context(), "Tenant ID missing in request context")
 			c.JSON(http.StatusUnauthorized, errordto.NewAuthenticationErrorResponse(
-				errors.NewAuthenticationError("Tenant context required"),
+				c.Request.Context(),
+				errordto.ErrTenantContextRequired,
 			))
 			c.Abort()
 			return
@@ -121,6 +128,7 @@ func VerifyTenantResourceAccess(authService auth.AuthService, resourceType, acce
 		if resourceID == "" {
 			logger.WarnContext(c.Request.Context(), "Resource ID missing in request path")
 			c.JSON(http.StatusBadRequest, errordto.NewValidationErrorResponse(
+				c.Request.Context(),
 				errors.NewValidationError("Resource ID required"),
 				map[string]string{"id": "Required parameter missing"},
 			))
@@ -147,7 +155,7 @@ func VerifyTenantResourceAccess(authService auth.AuthService, resourceType, acce
 				"resource_id", resourceID,
 				"access_type", accessType,
 			)
-			c.JSON(http.StatusInternalServerError, errordto.NewInternalErrorResponse(err))
+			c.JSON(http.StatusInternalServerError, errordto.NewInternalErrorResponse(c.Request.Context(), err))
 			c.Abort()
 			return
 		}
@@ -161,7 +169,8 @@ func VerifyTenantResourceAccess(authService auth.AuthService, resourceType, acce
 				"access_type", accessType,
 			)
 			c.JSON(http.StatusForbidden, errordto.NewAuthorizationErrorResponse(
-				errors.NewAuthorizationError("Access to the specified resource is not authorized"),
+				c.Request.Context(),
+				errors.WithCode(errors.NewAuthorizationError("Access to the specified resource is not authorized"), errors.CodeResourceAccessDenied),
 			))
 			c.Abort()
 			return
@@ -218,7 +227,7 @@ func ValidateTenantAccess(authService auth.AuthService, userID, tenantID string)
 			"user_id", userID,
 			"tenant_id", tenantID,
 		)
-		return errors.NewAuthorizationError("Access to the specified tenant is not authorized")
+		return errors.WithCode(errors.NewAuthorizationError("Access to the specified tenant is not authorized"), errors.CodeTenantAccessDenied)
 	}
 
 	return nil
@@ -0,0 +1,62 @@
+// Package middleware provides HTTP middleware components for the Document Management Platform API.
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"       // v1.9.0+
+	"go.opentelemetry.io/otel/trace" // v1.11.0+
+
+	"../../pkg/tracing"
+)
+
+// headerTraceID is the HTTP header the trace ID of a request is returned in
+const headerTraceID = "X-Trace-ID"
+
+// unmatchedRoute names the spans of requests that match no route
+const unmatchedRoute = "unmatched route"
+
+// Tracing creates a Gin middleware that records a server span for each request, continuing the trace
+// of the caller when the request carries W3C trace context headers. Spans are named after the route
+// rather than the path, so the requests of a route are grouped whatever their IDs. The trace ID is
+// returned in the X-Trace-ID header, so a slow request can be looked up in the tracing backend.
+func Tracing() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+
+		ctx := tracing.ExtractHTTP(c.Request.Context(), c.Request.Header)
+		ctx, span := tracing.StartSpan(ctx, fmt.Sprintf("%s %s", c.Request.Method, route), trace.WithSpanKind(trace.SpanKindServer))
+		c.Request = c.Request.WithContext(ctx)
+
+		if traceID := tracing.TraceIDFromContext(ctx); traceID != "" {
+			c.Header(headerTraceID, traceID)
+		}
+
+		c.Next()
+
+		status := c.Writer.Status()
+		tracing.AddAttribute(span, "http.method", c.Request.Method)
+		tracing.AddAttribute(span, "http.route", route)
+		tracing.AddAttribute(span, "http.status_code", status)
+		if requestID := GetRequestID(c); requestID != "" {
+			tracing.AddAttribute(span, "http.request_id", requestID)
+		}
+		if tenantID, exists := c.Get(contextKeyTenantID); exists {
+			tracing.AddAttribute(span, "tenant.id", tenantID)
+		}
+
+		// Only server errors fail the span; client errors are the caller's
+		var err error
+		if status >= http.StatusInternalServerError {
+			err = fmt.Errorf("request failed with status %d", status)
+			if last := c.Errors.Last(); last != nil {
+				err = last.Err
+			}
+		}
+		tracing.EndSpan(span, err)
+	}
+}
@@ -0,0 +1,530 @@
+// Package openapi generates the OpenAPI 3 document of the Document Management Platform API from
+// the routes registered with the router and the DTOs of their requests and responses.
+// This file generates the operations of the document and checks the annotations of routes
+// against the routes of the router.
+package openapi
+
+import (
+	"fmt"      // standard library
+	"net/http" // standard library
+	"reflect"  // standard library
+	"sort"     // standard library
+	"strings"  // standard library
+	"unicode"  // standard library
+
+	"github.com/gin-gonic/gin" // v1.9.0+
+
+	"../dto"
+)
+
+// jsonContentType is the media type of request and response bodies unless annotated otherwise
+const jsonContentType = "application/json"
+
+// Names of the shared problem responses of operations
+const (
+	problemResponse           = "Problem"
+	validationProblemResponse = "ValidationProblem"
+)
+
+// Envelope is the body handlers wrap the response DTO of an operation in
+type Envelope int
+
+const (
+	// EnvelopeData returns the response DTO as the data member of a dto.DataResponse
+	EnvelopeData Envelope = iota
+	// EnvelopePaginated returns a page of response DTOs as the items member of a dto.PaginatedResponse
+	EnvelopePaginated
+	// EnvelopeNone returns the response DTO as is
+	EnvelopeNone
+)
+
+// Route annotates a route with what the router cannot tell about it: the DTOs of its request and
+// response, and a summary when the name of its handler does not say what it does. Routes without
+// annotation are documented from their path and handler alone.
+type Route struct {
+	Method      string // HTTP method of the route
+	Path        string // Path of the route as registered with gin, e.g. /api/v1/documents/:id
+	Summary     string
+	Description string
+	Tags        []string
+
+	Query              interface{} // Struct bound from the query string with form tags
+	Request            interface{} // Struct bound from the request body
+	RequestContentType string      // Media type of the request body, application/json by default
+
+	Status              int         // Status of successful responses, 200 by default
+	Response            interface{} // DTO of successful responses
+	ResponseContentType string      // Media type of successful responses, application/json by default
+	Envelope            Envelope
+}
+
+// SecurityRule sets the security schemes authenticating the operations under a path prefix. The
+// rule with the longest matching prefix applies; a rule without schemes makes operations public.
+type SecurityRule struct {
+	Prefix  string
+	Schemes []string
+}
+
+// Spec configures the OpenAPI document generated from the routes of a router
+type Spec struct {
+	Info            Info
+	SecuritySchemes map[string]*SecurityScheme
+	Security        []SecurityRule
+	Exclude         []string // Path prefixes of routes that are not part of the documented API
+	Routes          []Route  // Annotations of routes
+}
+
+// Generate generates the OpenAPI document of the given routes, typically those of router.Routes()
+func (s *Spec) Generate(routes gin.RoutesInfo) *Document {
+	registry := newSchemaRegistry()
+	annotations := s.annotations()
+
+	document := &Document{
+		OpenAPI: Version,
+		Info:    s.Info,
+		Paths:   make(map[string]*PathItem),
+	}
+
+	operationIDs := make(map[string]bool)
+	tags := make(map[string]bool)
+	for _, route := range s.documented(routes) {
+		operation := s.operation(registry, route, annotations[routeKey(route.Method, route.Path)])
+		operation.OperationID = uniqueOperationID(operationIDs, route)
+		for _, tag := range operation.Tags {
+			tags[tag] = true
+		}
+
+		path := openAPIPath(route.Path)
+		item, ok := document.Paths[path]
+		if !ok {
+			item = &PathItem{}
+			document.Paths[path] = item
+		}
+		item.setOperation(route.Method, operation)
+	}
+
+	for tag := range tags {
+		document.Tags = append(document.Tags, Tag{Name: tag})
+	}
+	sort.Slice(document.Tags, func(i, j int) bool { return document.Tags[i].Name < document.Tags[j].Name })
+
+	document.Components = Components{
+		Responses: map[string]*Response{
+			problemResponse: {
+				Description: "Problem details of the error (RFC 7807)",
+				Content:     problemContent(registry.schemaOf(reflect.TypeOf(dto.ErrorResponse{}))),
+			},
+			validationProblemResponse: {
+				Description: "Problem details of an invalid request, with every field violation",
+				Content:     problemContent(registry.schemaOf(reflect.TypeOf(dto.ValidationErrorResponse{}))),
+			},
+		},
+		SecuritySchemes: s.SecuritySchemes,
+		Schemas:         registry.schemas,
+	}
+	return document
+}
+
+// Check reports where the annotations of routes and the routes themselves disagree: annotations
+// of routes that are not registered, or no longer, routes annotated more than once, annotations
+// whose DTOs cannot be bound, and routes served by functions whose name does not describe them.
+// The router calls it on startup so that the document cannot silently drift from the handlers.
+func (s *Spec) Check(routes gin.RoutesInfo) []string {
+	var problems []string
+
+	routed := make(map[string]bool)
+	for _, route := range s.documented(routes) {
+		routed[routeKey(route.Method, route.Path)] = true
+	}
+
+	annotated := make(map[string]bool)
+	for _, annotation := range s.Routes {
+		key := routeKey(annotation.Method, annotation.Path)
+		if annotated[key] {
+			problems = append(problems, fmt.Sprintf("%s is annotated more than once", key))
+		}
+		annotated[key] = true
+
+		if !routed[key] {
+			problems = append(problems, fmt.Sprintf("%s is annotated but no handler serves it", key))
+		}
+		if annotation.Query != nil && !isStruct(annotation.Query) {
+			problems = append(problems, fmt.Sprintf("%s has a query DTO that is not a struct", key))
+		}
+		if annotation.Request != nil && !isStruct(annotation.Request) {
+			problems = append(problems, fmt.Sprintf("%s has a request DTO that is not a struct", key))
+		}
+	}
+
+	annotations := s.annotations()
+	for _, route := range s.documented(routes) {
+		key := routeKey(route.Method, route.Path)
+		if _, method := handlerName(route.Handler); method == "" && annotations[key].Summary == "" {
+			problems = append(problems, fmt.Sprintf("%s is served by %s, which needs an annotation with a summary", key, route.Handler))
+		}
+	}
+	return problems
+}
+
+// annotations returns the annotations of routes by route key
+func (s *Spec) annotations() map[string]Route {
+	annotations := make(map[string]Route, len(s.Routes))
+	for _, annotation := range s.Routes {
+		annotations[routeKey(annotation.Method, annotation.Path)] = annotation
+	}
+	return annotations
+}
+
+// documented returns the routes that are part of the documented API, sorted by path and method
+func (s *Spec) documented(routes gin.RoutesInfo) gin.RoutesInfo {
+	documented := make(gin.RoutesInfo, 0, len(routes))
+	for _, route := range routes {
+		if methodField(route.Method) == "" || s.excluded(route.Path) {
+			continue
+		}
+		documented = append(documented, route)
+	}
+	sort.SliceStable(documented, func(i, j int) bool {
+		if documented[i].Path != documented[j].Path {
+			return documented[i].Path < documented[j].Path
+		}
+		return documented[i].Method < documented[j].Method
+	})
+	return documented
+}
+
+// excluded reports whether a path is excluded from the document
+func (s *Spec) excluded(path string) bool {
+	for _, prefix := range s.Exclude {
+		if hasPathPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// operation builds the operation of a route from its handler and annotation
+func (s *Spec) operation(registry *schemaRegistry, route gin.RouteInfo, annotation Route) *Operation {
+	receiver, method := handlerName(route.Handler)
+
+	operation := &Operation{
+		Summary:     annotation.Summary,
+		Description: annotation.Description,
+		Tags:        annotation.Tags,
+		Responses:   make(map[string]*Response),
+		Security:    s.security(route.Path),
+	}
+	if operation.Summary == "" && method != "" {
+		operation.Summary = sentence(method)
+	}
+	if len(operation.Tags) == 0 && receiver != "" {
+		operation.Tags = []string{sentence(strings.TrimSuffix(receiver, "Handler"))}
+	}
+
+	operation.Parameters = pathParameters(route.Path)
+	if annotation.Query != nil {
+		operation.Parameters = append(operation.Parameters, registry.queryParameters(annotation.Query)...)
+	}
+	if annotation.Request != nil {
+		operation.RequestBody = requestBody(registry, annotation)
+	}
+
+	status := annotation.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	operation.Responses[fmt.Sprint(status)] = successResponse(registry, status, annotation)
+
+	// Every operation can fail; the responses clients usually handle are listed explicitly
+	operation.Responses["default"] = &Response{Ref: "#/components/responses/" + problemResponse}
+	if annotation.Query != nil || annotation.Request != nil {
+		operation.Responses["400"] = &Response{Ref: "#/components/responses/" + validationProblemResponse}
+	}
+	if len(operation.Security) > 0 {
+		operation.Responses["401"] = &Response{Ref: "#/components/responses/" + problemResponse}
+		operation.Responses["403"] = &Response{Ref: "#/components/responses/" + problemResponse}
+	}
+	if len(operation.Parameters) > 0 && operation.Parameters[0].In == "path" {
+		operation.Responses["404"] = &Response{Ref: "#/components/responses/" + problemResponse}
+	}
+	return operation
+}
+
+// security returns the security requirements of the operations of a path: any one of the schemes
+// of the security rule with the longest prefix of the path
+func (s *Spec) security(path string) []SecurityRequirement {
+	var rule *SecurityRule
+	for i := range s.Security {
+		if hasPathPrefix(path, s.Security[i].Prefix) && (rule == nil || len(s.Security[i].Prefix) > len(rule.Prefix)) {
+			rule = &s.Security[i]
+		}
+	}
+	if rule == nil {
+		return nil
+	}
+
+	requirements := make([]SecurityRequirement, 0, len(rule.Schemes))
+	for _, scheme := range rule.Schemes {
+		requirements = append(requirements, SecurityRequirement{scheme: []string{}})
+	}
+	return requirements
+}
+
+// requestBody builds the request body of an annotated route. Multipart forms are bound with form
+// tags, other bodies are decoded with json tags.
+func requestBody(registry *schemaRegistry, annotation Route) *RequestBody {
+	contentType := annotation.RequestContentType
+	if contentType == "" {
+		contentType = jsonContentType
+	}
+
+	var schema *Schema
+	if strings.HasPrefix(contentType, "multipart/") {
+		schema = registry.structSchema(indirect(reflect.TypeOf(annotation.Request)), "form")
+	} else {
+		schema = registry.schemaOf(reflect.TypeOf(annotation.Request))
+	}
+	return &RequestBody{Required: true, Content: map[string]*MediaType{contentType: {Schema: schema}}}
+}
+
+// successResponse builds the successful response of an annotated route, wrapped in its envelope
+func successResponse(registry *schemaRegistry, status int, annotation Route) *Response {
+	response := &Response{Description: http.StatusText(status)}
+	if status == http.StatusNoContent || (status >= 300 && status < 400) {
+		return response
+	}
+
+	if annotation.ResponseContentType != "" && annotation.ResponseContentType != jsonContentType {
+		response.Content = map[string]*MediaType{
+			annotation.ResponseContentType: {Schema: &Schema{Type: "string", Format: "binary"}},
+		}
+		return response
+	}
+
+	var schema *Schema
+	switch annotation.Envelope {
+	case EnvelopeNone:
+		schema = &Schema{}
+		if annotation.Response != nil {
+			schema = registry.schemaOf(reflect.TypeOf(annotation.Response))
+		}
+	case EnvelopePaginated:
+		schema = registry.structSchema(reflect.TypeOf(dto.PaginatedResponse{}), "json")
+		if annotation.Response != nil {
+			schema.Properties["items"] = &Schema{Type: "array", Items: registry.schemaOf(reflect.TypeOf(annotation.Response))}
+		}
+	default:
+		schema = registry.structSchema(reflect.TypeOf(dto.DataResponse{}), "json")
+		if annotation.Response != nil {
+			schema.Properties["data"] = registry.schemaOf(reflect.TypeOf(annotation.Response))
+		}
+	}
+	response.Content = map[string]*MediaType{jsonContentType: {Schema: schema}}
+	return response
+}
+
+// problemContent returns the content of problem responses with the given schema
+func problemContent(schema *Schema) map[string]*MediaType {
+	return map[string]*MediaType{dto.ProblemContentType: {Schema: schema}}
+}
+
+// setOperation sets the operation of an HTTP method of the path
+func (p *PathItem) setOperation(method string, operation *Operation) {
+	switch methodField(method) {
+	case "get":
+		p.Get = operation
+	case "put":
+		p.Put = operation
+	case "post":
+		p.Post = operation
+	case "delete":
+		p.Delete = operation
+	case "options":
+		p.Options = operation
+	case "head":
+		p.Head = operation
+	case "patch":
+		p.Patch = operation
+	}
+}
+
+// methodField returns the name of the path item field of an HTTP method, or an empty string for
+// methods OpenAPI cannot describe, such as those of WebDAV
+func methodField(method string) string {
+	switch method {
+	case http.MethodGet, http.MethodPut, http.MethodPost, http.MethodDelete, http.MethodOptions, http.MethodHead, http.MethodPatch:
+		return strings.ToLower(method)
+	default:
+		return ""
+	}
+}
+
+// routeKey identifies a route by its method and gin path
+func routeKey(method, path string) string {
+	return method + " " + path
+}
+
+// openAPIPath converts a gin path to an OpenAPI path template: /documents/:id becomes
+// /documents/{id} and /files/*path becomes /files/{path}
+func openAPIPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// pathParameters returns the parameters of the path segments of a gin path
+func pathParameters(path string) []Parameter {
+	var parameters []Parameter
+	for _, segment := range strings.Split(path, "/") {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			parameters = append(parameters, Parameter{
+				Name:     segment[1:],
+				In:       "path",
+				Required: true,
+				Schema:   &Schema{Type: "string"},
+			})
+		}
+	}
+	return parameters
+}
+
+// hasPathPrefix reports whether a path is, or is under, a path prefix
+func hasPathPrefix(path, prefix string) bool {
+	if !strings.HasPrefix(path, prefix) {
+		return false
+	}
+	return len(path) == len(prefix) || strings.HasSuffix(prefix, "/") || path[len(prefix)] == '/'
+}
+
+// handlerName returns the receiver type and method of a handler gin names like
+// github.com/project/api/handlers.(*DocumentHandler).GetDocument-fm, or empty strings for handlers
+// that are not method values, such as closures returned by constructors
+func handlerName(name string) (string, string) {
+	if !strings.HasSuffix(name, "-fm") {
+		return "", ""
+	}
+	name = strings.TrimSuffix(name[strings.LastIndex(name, "/")+1:], "-fm")
+
+	parts := strings.Split(name, ".")
+	if len(parts) != 3 {
+		return "", ""
+	}
+	return strings.Trim(parts[1], "(*)"), parts[2]
+}
+
+// uniqueOperationID returns the operation ID of a route: the handler method in lower camel case,
+// prefixed with its receiver or suffixed with the HTTP method when another operation has it
+func uniqueOperationID(taken map[string]bool, route gin.RouteInfo) string {
+	receiver, method := handlerName(route.Handler)
+
+	var candidates []string
+	if method != "" {
+		resource := strings.TrimSuffix(receiver, "Handler")
+		candidates = append(candidates,
+			lowerFirst(method),
+			lowerFirst(resource)+upperFirst(method),
+			lowerFirst(method)+upperFirst(strings.ToLower(route.Method)),
+		)
+	} else {
+		// Handlers without a name are identified by their method and the static segments of their path
+		id := strings.ToLower(route.Method)
+		for _, segment := range strings.Split(route.Path, "/") {
+			if segment != "" && !strings.HasPrefix(segment, ":") && !strings.HasPrefix(segment, "*") {
+				id += upperFirst(identifier(segment))
+			}
+		}
+		candidates = append(candidates, id)
+	}
+
+	for _, candidate := range candidates {
+		if !taken[candidate] {
+			taken[candidate] = true
+			return candidate
+		}
+	}
+	for i := 2; ; i++ {
+		candidate := fmt.Sprintf("%s%d", candidates[0], i)
+		if !taken[candidate] {
+			taken[candidate] = true
+			return candidate
+		}
+	}
+}
+
+// sentence turns an identifier into a sentence: GetDocumentURL becomes "Get document URL"
+func sentence(identifier string) string {
+	words := splitWords(identifier)
+	for i, word := range words {
+		if i == 0 {
+			words[i] = upperFirst(word)
+		} else if strings.ToUpper(word) != word || len(word) == 1 {
+			words[i] = strings.ToLower(word)
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// splitWords splits a camel case identifier into its words, keeping acronyms together
+func splitWords(identifier string) []string {
+	runes := []rune(identifier)
+	var words []string
+	start := 0
+	for i := 1; i < len(runes); i++ {
+		lowerBefore := unicode.IsLower(runes[i-1])
+		lowerAfter := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+		if unicode.IsUpper(runes[i]) && (lowerBefore || (unicode.IsUpper(runes[i-1]) && lowerAfter)) {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	if start < len(runes) {
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
+
+// identifier turns a path segment such as signature-callbacks into an identifier such as signatureCallbacks
+func identifier(segment string) string {
+	parts := strings.FieldsFunc(segment, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i := 1; i < len(parts); i++ {
+		parts[i] = upperFirst(parts[i])
+	}
+	return strings.Join(parts, "")
+}
+
+// upperFirst returns a string with its first letter in upper case
+func upperFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// lowerFirst returns an identifier with its first word in lower case: APIKey becomes apiKey
+func lowerFirst(s string) string {
+	words := splitWords(s)
+	if len(words) == 0 {
+		return s
+	}
+	words[0] = strings.ToLower(words[0])
+	return strings.Join(words, "")
+}
+
+// isStruct reports whether a value is a struct or a pointer to one
+func isStruct(value interface{}) bool {
+	return indirect(reflect.TypeOf(value)).Kind() == reflect.Struct
+}
+
+// indirect returns the type pointers of a type point to
+func indirect(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
@@ -0,0 +1,259 @@
+// Package openapi provides tests for generating the OpenAPI document from the routes of a router
+package openapi
+
+import (
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"  // v1.8.0+
+	"github.com/stretchr/testify/require" // v1.8.0+
+)
+
+// documentHandler serves the document routes of the test router
+type documentHandler struct{}
+
+func (h *documentHandler) GetDocument(c *gin.Context)      {}
+func (h *documentHandler) UploadDocument(c *gin.Context)   {}
+func (h *documentHandler) ListDocuments(c *gin.Context)    {}
+func (h *documentHandler) GetDocumentURL(c *gin.Context)   {}
+func (h *documentHandler) DeleteDocument(c *gin.Context)   {}
+func (h *documentHandler) ServeDocuments(c *gin.Context)   {}
+func (h *documentHandler) DescribeErrors(c *gin.Context)   {}
+func (h *documentHandler) DownloadDocument(c *gin.Context) {}
+
+// guestHandler serves the guest routes of the test router
+type guestHandler struct{}
+
+func (h *guestHandler) GetDocument(c *gin.Context) {}
+
+// documentDTO is a response DTO referencing itself
+type documentDTO struct {
+	ID        string       `json:"id"`
+	Name      string       `json:"name"`
+	CreatedAt time.Time    `json:"created_at"`
+	PublishAt *time.Time   `json:"publish_at"`
+	Parent    *documentDTO `json:"parent,omitempty"`
+	Internal  string       `json:"-"`
+}
+
+// uploadRequest is a multipart request DTO
+type uploadRequest struct {
+	Name     string                `form:"name" binding:"required"`
+	File     *multipart.FileHeader `form:"file" binding:"required"`
+	Priority string                `form:"priority" binding:"omitempty,oneof=high normal low"`
+}
+
+// listQuery is a query DTO
+type listQuery struct {
+	Page   int    `form:"page" binding:"gte=1"`
+	Status string `form:"status" binding:"required,oneof=ready failed"`
+}
+
+// newTestRouter registers routes like those of the API
+func newTestRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	documents := &documentHandler{}
+	router.GET("/api/v1/errors", documents.DescribeErrors)
+	router.GET("/api/v1/documents", documents.ListDocuments)
+	router.POST("/api/v1/documents", documents.UploadDocument)
+	router.GET("/api/v1/documents/:id", documents.GetDocument)
+	router.DELETE("/api/v1/documents/:id", documents.DeleteDocument)
+	router.GET("/api/v1/documents/:id/content", documents.DownloadDocument)
+	router.GET("/api/v1/documents/:id/content/url", documents.GetDocumentURL)
+	router.GET("/api/v1/guest/documents/:id", (&guestHandler{}).GetDocument)
+	router.POST("/api/v1/graphql", func(c *gin.Context) {})
+	router.Handle("PROPFIND", "/dav/:tenantId/*path", documents.ServeDocuments)
+	router.GET("/dav/:tenantId/*path", documents.ServeDocuments)
+	return router
+}
+
+// newTestSpec annotates the routes of the test router
+func newTestSpec() *Spec {
+	return &Spec{
+		Info: Info{Title: "Test API", Version: "1.0.0"},
+		SecuritySchemes: map[string]*SecurityScheme{
+			"bearerAuth": {Type: "http", Scheme: "bearer"},
+			"apiKeyAuth": {Type: "apiKey", In: "header", Name: "X-API-Key"},
+			"guestAuth":  {Type: "http", Scheme: "bearer"},
+		},
+		Security: []SecurityRule{
+			{Prefix: "/api/v1", Schemes: []string{"bearerAuth", "apiKeyAuth"}},
+			{Prefix: "/api/v1/guest", Schemes: []string{"guestAuth"}},
+			{Prefix: "/api/v1/errors"},
+		},
+		Exclude: []string{"/dav"},
+		Routes: []Route{
+			{Method: http.MethodGet, Path: "/api/v1/documents", Query: listQuery{}, Response: documentDTO{}, Envelope: EnvelopePaginated},
+			{Method: http.MethodPost, Path: "/api/v1/documents", Request: uploadRequest{}, RequestContentType: "multipart/form-data", Status: http.StatusCreated, Response: documentDTO{}},
+			{Method: http.MethodGet, Path: "/api/v1/documents/:id", Response: &documentDTO{}},
+			{Method: http.MethodDelete, Path: "/api/v1/documents/:id", Status: http.StatusNoContent},
+			{Method: http.MethodGet, Path: "/api/v1/documents/:id/content", ResponseContentType: "application/octet-stream"},
+			{Method: http.MethodPost, Path: "/api/v1/graphql", Summary: "Query documents and folders with GraphQL", Tags: []string{"GraphQL"}},
+		},
+	}
+}
+
+func TestGenerate_Operations(t *testing.T) {
+	document := newTestSpec().Generate(newTestRouter().Routes())
+
+	assert.Equal(t, Version, document.OpenAPI)
+	assert.Equal(t, "Test API", document.Info.Title)
+
+	// WebDAV routes are excluded, paths use OpenAPI templates
+	assert.ElementsMatch(t, []string{
+		"/api/v1/errors",
+		"/api/v1/documents",
+		"/api/v1/documents/{id}",
+		"/api/v1/documents/{id}/content",
+		"/api/v1/documents/{id}/content/url",
+		"/api/v1/guest/documents/{id}",
+		"/api/v1/graphql",
+	}, pathKeys(document))
+
+	getDocument := document.Paths["/api/v1/documents/{id}"].Get
+	require.NotNil(t, getDocument)
+	assert.Equal(t, "getDocument", getDocument.OperationID)
+	assert.Equal(t, "Get document", getDocument.Summary)
+	assert.Equal(t, []string{"Document"}, getDocument.Tags)
+	assert.Equal(t, []Parameter{{Name: "id", In: "path", Required: true, Schema: &Schema{Type: "string"}}}, getDocument.Parameters)
+	assert.Equal(t, []SecurityRequirement{{"bearerAuth": {}}, {"apiKeyAuth": {}}}, getDocument.Security)
+	assert.Equal(t, &Schema{Ref: "#/components/schemas/documentDTO"}, getDocument.Responses["200"].Content["application/json"].Schema.Properties["data"])
+	for _, status := range []string{"default", "401", "403", "404"} {
+		assert.Contains(t, getDocument.Responses, status)
+	}
+	assert.NotContains(t, getDocument.Responses, "400")
+
+	// Operations sharing a handler method name are prefixed with their resource
+	assert.Equal(t, "guestGetDocument", document.Paths["/api/v1/guest/documents/{id}"].Get.OperationID)
+	assert.Equal(t, []SecurityRequirement{{"guestAuth": {}}}, document.Paths["/api/v1/guest/documents/{id}"].Get.Security)
+
+	// Acronyms are kept in summaries
+	assert.Equal(t, "Get document URL", document.Paths["/api/v1/documents/{id}/content/url"].Get.Summary)
+
+	// Public operations have no security and cannot fail authentication
+	errors := document.Paths["/api/v1/errors"].Get
+	assert.Empty(t, errors.Security)
+	assert.NotContains(t, errors.Responses, "401")
+
+	// Anonymous handlers are described by their annotation
+	graphql := document.Paths["/api/v1/graphql"].Post
+	assert.Equal(t, "postApiV1Graphql", graphql.OperationID)
+	assert.Equal(t, "Query documents and folders with GraphQL", graphql.Summary)
+	assert.Equal(t, []string{"GraphQL"}, graphql.Tags)
+
+	assert.Equal(t, []Tag{{Name: "Document"}, {Name: "GraphQL"}, {Name: "Guest"}}, document.Tags)
+}
+
+func TestGenerate_RequestsAndResponses(t *testing.T) {
+	document := newTestSpec().Generate(newTestRouter().Routes())
+
+	// Query DTOs become query parameters
+	list := document.Paths["/api/v1/documents"].Get
+	assert.Equal(t, []Parameter{
+		{Name: "page", In: "query", Schema: &Schema{Type: "integer"}},
+		{Name: "status", In: "query", Required: true, Schema: &Schema{Type: "string", Enum: []string{"ready", "failed"}}},
+	}, list.Parameters)
+	assert.Contains(t, list.Responses, "400")
+	items := list.Responses["200"].Content["application/json"].Schema.Properties["items"]
+	assert.Equal(t, &Schema{Type: "array", Items: &Schema{Ref: "#/components/schemas/documentDTO"}}, items)
+
+	// Multipart requests are described from their form tags
+	upload := document.Paths["/api/v1/documents"].Post
+	require.NotNil(t, upload.RequestBody)
+	form := upload.RequestBody.Content["multipart/form-data"].Schema
+	assert.Equal(t, []string{"name", "file"}, form.Required)
+	assert.Equal(t, &Schema{Type: "string", Format: "binary"}, form.Properties["file"])
+	assert.Equal(t, []string{"high", "normal", "low"}, form.Properties["priority"].Enum)
+	assert.Contains(t, upload.Responses, "201")
+	assert.Equal(t, validationProblemRef(), upload.Responses["400"])
+
+	// Responses without content
+	assert.Empty(t, document.Paths["/api/v1/documents/{id}"].Delete.Responses["204"].Content)
+	assert.Equal(t, &Schema{Type: "string", Format: "binary"},
+		document.Paths["/api/v1/documents/{id}/content"].Get.Responses["200"].Content["application/octet-stream"].Schema)
+
+	// Named structs are component schemas, which may reference themselves
+	schema := document.Components.Schemas["documentDTO"]
+	require.NotNil(t, schema)
+	assert.Equal(t, &Schema{Type: "string", Format: "date-time"}, schema.Properties["created_at"])
+	assert.Equal(t, &Schema{Type: "string", Format: "date-time", Nullable: true}, schema.Properties["publish_at"])
+	assert.Equal(t, &Schema{Ref: "#/components/schemas/documentDTO"}, schema.Properties["parent"])
+	assert.NotContains(t, schema.Properties, "Internal")
+
+	// Error responses are problem details
+	assert.Contains(t, document.Components.Responses[problemResponse].Content, "application/problem+json")
+	validation := document.Components.Schemas["ValidationErrorResponse"]
+	require.NotNil(t, validation)
+	for _, member := range []string{"type", "title", "status", "detail", "violations", "validation_errors"} {
+		assert.Contains(t, validation.Properties, member)
+	}
+
+	// The document is valid JSON
+	_, err := json.Marshal(document)
+	assert.NoError(t, err)
+}
+
+func TestCheck(t *testing.T) {
+	spec := newTestSpec()
+	assert.Empty(t, spec.Check(newTestRouter().Routes()))
+
+	spec.Routes = append(spec.Routes,
+		Route{Method: http.MethodGet, Path: "/api/v1/documents/:id/preview"},
+		Route{Method: http.MethodGet, Path: "/dav/:tenantId/*path"},
+		Route{Method: http.MethodGet, Path: "/api/v1/documents/:id", Request: "body"},
+	)
+	spec.Routes[5].Summary = ""
+
+	problems := spec.Check(newTestRouter().Routes())
+	require.Len(t, problems, 5)
+	assert.Equal(t, []string{
+		"GET /api/v1/documents/:id/preview is annotated but no handler serves it",
+		"GET /dav/:tenantId/*path is annotated but no handler serves it",
+		"GET /api/v1/documents/:id is annotated more than once",
+		"GET /api/v1/documents/:id has a request DTO that is not a struct",
+	}, problems[:4])
+	assert.Contains(t, problems[4], "POST /api/v1/graphql is served by ")
+}
+
+func TestSentence(t *testing.T) {
+	for identifier, expected := range map[string]string{
+		"GetDocument":     "Get document",
+		"GetDocumentURL":  "Get document URL",
+		"CreateAPIKey":    "Create API key",
+		"SSO":             "SSO",
+		"ListDocumentsV2": "List documents V2",
+		"document":        "Document",
+	} {
+		assert.Equal(t, expected, sentence(identifier), identifier)
+	}
+}
+
+func TestHandlerName(t *testing.T) {
+	receiver, method := handlerName("github.com/project/api/handlers.(*DocumentHandler).GetDocument-fm")
+	assert.Equal(t, "DocumentHandler", receiver)
+	assert.Equal(t, "GetDocument", method)
+
+	receiver, method = handlerName("github.com/project/api/graphql.NewHandler.func1")
+	assert.Empty(t, receiver)
+	assert.Empty(t, method)
+}
+
+// pathKeys returns the paths of a document
+func pathKeys(document *Document) []string {
+	paths := make([]string, 0, len(document.Paths))
+	for path := range document.Paths {
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+// validationProblemRef returns the reference to the shared validation problem response
+func validationProblemRef() *Response {
+	return &Response{Ref: "#/components/responses/" + validationProblemResponse}
+}
@@ -0,0 +1,244 @@
+// Package openapi generates the OpenAPI 3 document of the Document Management Platform API from
+// the routes registered with the router and the DTOs of their requests and responses.
+// This file builds the schemas of DTOs by reflection, from the tags gin binds and encodes them with.
+package openapi
+
+import (
+	"encoding/json"  // standard library
+	"mime/multipart" // standard library
+	"path"           // standard library
+	"reflect"        // standard library
+	"sort"           // standard library
+	"strconv"        // standard library
+	"strings"        // standard library
+	"time"           // standard library
+)
+
+// schemaRefPrefix is the prefix of references to component schemas
+const schemaRefPrefix = "#/components/schemas/"
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+	fileHeaderType = reflect.TypeOf(multipart.FileHeader{})
+)
+
+// schemaRegistry builds the schemas of Go types. Named struct types are registered once as
+// component schemas, which the schemas of other types reference.
+type schemaRegistry struct {
+	schemas map[string]*Schema
+	names   map[reflect.Type]string
+	types   map[string]reflect.Type
+}
+
+// newSchemaRegistry creates an empty schema registry
+func newSchemaRegistry() *schemaRegistry {
+	return &schemaRegistry{
+		schemas: make(map[string]*Schema),
+		names:   make(map[reflect.Type]string),
+		types:   make(map[string]reflect.Type),
+	}
+}
+
+// schemaOf returns the schema of the JSON encoding of values of type t
+func (r *schemaRegistry) schemaOf(t reflect.Type) *Schema {
+	t = indirect(t)
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case rawMessageType:
+		return &Schema{}
+	case fileHeaderType:
+		return &Schema{Type: "string", Format: "binary"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Uint, reflect.Uint8, reflect.Uint16:
+		return &Schema{Type: "integer"}
+	case reflect.Int32, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		// Byte slices are encoded as base64 strings
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: r.schemaOf(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: r.schemaOf(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return r.structSchema(t, "json")
+		}
+		return r.ref(t)
+	default:
+		// Interfaces can hold any value
+		return &Schema{}
+	}
+}
+
+// ref returns a reference to the component schema of a named struct type, registering it first
+func (r *schemaRegistry) ref(t reflect.Type) *Schema {
+	name, ok := r.names[t]
+	if !ok {
+		name = r.componentName(t)
+		r.names[t] = name
+		r.types[name] = t
+
+		// The schema is registered before its fields are built, so recursive types reference it
+		schema := &Schema{}
+		r.schemas[name] = schema
+		*schema = *r.structSchema(t, "json")
+	}
+	return &Schema{Ref: schemaRefPrefix + name}
+}
+
+// componentName returns a component schema name for a named type: its name, prefixed with its
+// package when another type already has it
+func (r *schemaRegistry) componentName(t reflect.Type) string {
+	name := t.Name()
+	// The names of instantiated generic types include their type arguments
+	if i := strings.Index(name, "["); i >= 0 {
+		name = name[:i]
+	}
+	if _, taken := r.types[name]; !taken {
+		return name
+	}
+
+	pkg := path.Base(t.PkgPath())
+	qualified := strings.ToUpper(pkg[:1]) + pkg[1:] + name
+	candidate := qualified
+	for i := 2; ; i++ {
+		if _, taken := r.types[candidate]; !taken {
+			return candidate
+		}
+		candidate = qualified + strconv.Itoa(i)
+	}
+}
+
+// structSchema builds the object schema of a struct type from its fields bound or encoded with the
+// given tag, json for bodies and form for query parameters and multipart forms
+func (r *schemaRegistry) structSchema(t reflect.Type, tag string) *Schema {
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	r.addFields(schema, t, tag)
+	return schema
+}
+
+// addFields adds the fields of a struct type to an object schema, including the fields of embedded
+// structs as encoding/json promotes them
+func (r *schemaRegistry) addFields(schema *Schema, t reflect.Type, tag string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, options := parseTag(field.Tag.Get(tag))
+		if name == "-" && options == "" {
+			continue
+		}
+
+		fieldType := indirect(field.Type)
+		if field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+			r.addFields(schema, fieldType, tag)
+			continue
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		fieldSchema := r.schemaOf(field.Type)
+		if tag == "json" && hasOption(options, "string") {
+			fieldSchema = &Schema{Type: "string"}
+		}
+		if fieldSchema.Ref == "" {
+			if tag == "json" && field.Type.Kind() == reflect.Ptr && !hasOption(options, "omitempty") {
+				fieldSchema.Nullable = true
+			}
+			fieldSchema.Enum = bindingEnum(field.Tag.Get("binding"))
+		}
+		if hasBindingRule(field.Tag.Get("binding"), "required") {
+			schema.Required = append(schema.Required, name)
+		}
+		schema.Properties[name] = fieldSchema
+	}
+}
+
+// queryParameters returns the query parameters bound from the form tags of a struct, sorted by name
+func (r *schemaRegistry) queryParameters(value interface{}) []Parameter {
+	schema := r.structSchema(indirect(reflect.TypeOf(value)), "form")
+	names := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parameters := make([]Parameter, 0, len(names))
+	for _, name := range names {
+		parameters = append(parameters, Parameter{
+			Name:     name,
+			In:       "query",
+			Required: containsString(schema.Required, name),
+			Schema:   schema.Properties[name],
+		})
+	}
+	return parameters
+}
+
+// parseTag splits a struct tag value into the field name and its options
+func parseTag(tag string) (string, string) {
+	if i := strings.Index(tag, ","); i >= 0 {
+		return tag[:i], tag[i+1:]
+	}
+	return tag, ""
+}
+
+// hasOption reports whether comma separated tag options contain an option
+func hasOption(options, option string) bool {
+	return containsString(strings.Split(options, ","), option)
+}
+
+// bindingRules returns the rules of a binding tag that apply to the field itself, rather than to
+// its elements after dive
+func bindingRules(tag string) []string {
+	rules := strings.Split(tag, ",")
+	for i, rule := range rules {
+		if rule == "dive" {
+			return rules[:i]
+		}
+	}
+	return rules
+}
+
+// hasBindingRule reports whether a binding tag applies a rule to the field
+func hasBindingRule(tag, rule string) bool {
+	return containsString(bindingRules(tag), rule)
+}
+
+// bindingEnum returns the values a binding tag restricts the field to with the oneof rule
+func bindingEnum(tag string) []string {
+	for _, rule := range bindingRules(tag) {
+		if strings.HasPrefix(rule, "oneof=") {
+			return strings.Fields(strings.TrimPrefix(rule, "oneof="))
+		}
+	}
+	return nil
+}
+
+// containsString reports whether a slice contains a string
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,157 @@
+// Package api provides the HTTP API layer for the Document Management Platform.
+// This file configures the OpenAPI document generated from the routes of the router, and annotates
+// the routes whose handlers do not tell the DTOs of their requests and responses.
+package api
+
+import (
+	"net/http" // standard library
+
+	"github.com/project/dav"      // latest
+	"github.com/project/dto"      // latest
+	"github.com/project/handlers" // latest
+	"github.com/project/openapi"  // latest
+)
+
+// Security schemes of the OpenAPI document
+const (
+	bearerAuth           = "bearerAuth"
+	apiKeyAuth           = "apiKeyAuth"
+	guestAuth            = "guestAuth"
+	platformOperatorAuth = "platformOperatorAuth"
+)
+
+// graphQLRequest is the body of GraphQL queries
+type graphQLRequest struct {
+	Query         string                 `json:"query" binding:"required"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+// graphQLResponse is the body of GraphQL responses
+type graphQLResponse struct {
+	Data   interface{}   `json:"data"`
+	Errors []interface{} `json:"errors,omitempty"`
+}
+
+// newOpenAPISpec returns the configuration of the OpenAPI document of the API, with the platform
+// operator routes when the platform API is enabled, and its scan dead letter routes when the scan
+// queue keeps dead letters
+func newOpenAPISpec(platformAPI, scanDeadLetters bool) *openapi.Spec {
+	return &openapi.Spec{
+		Info: openapi.Info{
+			Title:       "Document Management Platform API",
+			Description: "API for the Document Management Platform that enables customers to upload, search, and download documents through API integration. Errors are problem details (RFC 7807) whose codes are described at " + apiVersionPrefix + "/errors.",
+			Version:     "1.0.0",
+		},
+		SecuritySchemes: map[string]*openapi.SecurityScheme{
+			bearerAuth: {
+				Type:         "http",
+				Scheme:       "bearer",
+				BearerFormat: "JWT",
+				Description:  "JWT token for authentication. The token must include tenant context and user roles.",
+			},
+			apiKeyAuth: {
+				Type:        "apiKey",
+				In:          "header",
+				Name:        "X-API-Key",
+				Description: "API key of a service-to-service integration, created by tenant administrators",
+			},
+			guestAuth: {
+				Type:        "http",
+				Scheme:      "bearer",
+				Description: "Guest token emailed to external guests invited to a document or folder",
+			},
+			platformOperatorAuth: {
+				Type:        "http",
+				Scheme:      "bearer",
+				Description: "Operator token read from the file set in platform.operator_token_file. Only accepted by the platform API.",
+			},
+		},
+		// Routes outside of these prefixes, such as health checks and share links, are public
+		Security: []openapi.SecurityRule{
+			{Prefix: apiVersionPrefix, Schemes: []string{bearerAuth, apiKeyAuth}},
+			{Prefix: apiVersionPrefix + "/guest", Schemes: []string{guestAuth}},
+			{Prefix: platformVersionPrefix, Schemes: []string{platformOperatorAuth}},
+			{Prefix: apiVersionPrefix + "/errors"},
+			{Prefix: apiVersionPrefix + "/sso"},
+			{Prefix: apiVersionPrefix + "/signature-callbacks"},
+		},
+		// WebDAV is described by RFC 4918 rather than OpenAPI
+		Exclude: []string{dav.PathPrefix, handlers.OpenAPIPath, handlers.SwaggerUIPath},
+		Routes:  openAPIRoutes(platformAPI, scanDeadLetters),
+	}
+}
+
+// openAPIRoutes annotates the routes of the API with the DTOs of their requests and responses.
+// Routes are keyed by their method and gin path; an annotation of a route that is no longer
+// registered is reported on startup.
+func openAPIRoutes(platformAPI, scanDeadLetters bool) []openapi.Route {
+	routes := []openapi.Route{
+		// Documents
+		{Method: http.MethodPost, Path: apiVersionPrefix + "/documents", Request: dto.CreateDocumentRequest{}, RequestContentType: "multipart/form-data", Status: http.StatusAccepted, Response: dto.DocumentUploadResponse{}},
+		{Method: http.MethodPost, Path: apiVersionPrefix + "/documents/batch", Request: dto.BatchUploadRequest{}, RequestContentType: "multipart/form-data", Status: http.StatusAccepted, Response: dto.BatchUploadResponse{},
+			Description: "Responds 207 Multi-Status with the same body when some of the files could not be uploaded."},
+		{Method: http.MethodGet, Path: apiVersionPrefix + "/documents/:id", Response: dto.DocumentDTO{}},
+		{Method: http.MethodPut, Path: apiVersionPrefix + "/documents/:id", Request: dto.UpdateDocumentRequest{}, Response: dto.DocumentDTO{}},
+		{Method: http.MethodGet, Path: apiVersionPrefix + "/documents/:id/content", ResponseContentType: "application/octet-stream"},
+		{Method: http.MethodGet, Path: apiVersionPrefix + "/documents/:id/content/url", Response: dto.DocumentDownloadResponse{}},
+		{Method: http.MethodGet, Path: apiVersionPrefix + "/documents/:id/versions", Response: []dto.DocumentVersionDTO{}},
+		{Method: http.MethodGet, Path: apiVersionPrefix + "/documents/:id/versions/:versionId/content", ResponseContentType: "application/octet-stream"},
+		{Method: http.MethodPost, Path: apiVersionPrefix + "/documents/:id/versions/delta", Request: dto.UploadVersionDeltaRequest{}, RequestContentType: "multipart/form-data", Status: http.StatusAccepted, Response: dto.DocumentVersionDTO{}},
+		{Method: http.MethodGet, Path: apiVersionPrefix + "/documents/:id/status", Response: dto.DocumentStatusResponse{}},
+		{Method: http.MethodGet, Path: apiVersionPrefix + "/documents/:id/thumbnail", ResponseContentType: "image/png"},
+		{Method: http.MethodGet, Path: apiVersionPrefix + "/documents/:id/thumbnail/url", Response: dto.DocumentDownloadResponse{}},
+		{Method: http.MethodPut, Path: apiVersionPrefix + "/documents/:id/schedule", Request: dto.ScheduleDocumentRequest{}, Response: dto.DocumentDTO{}},
+		{Method: http.MethodPost, Path: apiVersionPrefix + "/documents/:id/verify-integrity", Response: dto.IntegrityCheckResponse{}},
+		{Method: http.MethodPost, Path: apiVersionPrefix + "/documents/batch/download", Request: dto.BatchDownloadRequest{}, ResponseContentType: "application/zip"},
+		{Method: http.MethodPost, Path: apiVersionPrefix + "/documents/batch/download/url", Request: dto.BatchDownloadRequest{}, Response: dto.BatchDownloadResponse{}},
+
+		// Folders
+		{Method: http.MethodPost, Path: apiVersionPrefix + "/folders", Request: dto.FolderCreateRequest{}, Status: http.StatusCreated, Response: dto.FolderDTO{}},
+		{Method: http.MethodGet, Path: apiVersionPrefix + "/folders", Query: dto.FolderListRequest{}, Response: dto.FolderDTO{}, Envelope: openapi.EnvelopePaginated},
+		{Method: http.MethodGet, Path: apiVersionPrefix + "/folders/:id", Response: dto.FolderDTO{}},
+		{Method: http.MethodPut, Path: apiVersionPrefix + "/folders/:id", Request: dto.FolderUpdateRequest{}, Response: dto.FolderDTO{}},
+		{Method: http.MethodDelete, Path: apiVersionPrefix + "/folders/:id", Response: dto.MessageResponse{}, Envelope: openapi.EnvelopeNone},
+		{Method: http.MethodPut, Path: apiVersionPrefix + "/folders/:id/move", Request: dto.FolderMoveRequest{}, Response: dto.FolderDTO{}},
+		{Method: http.MethodPut, Path: apiVersionPrefix + "/folders/:id/watermark", Request: dto.FolderWatermarkRequest{}, Response: dto.FolderDTO{}},
+		{Method: http.MethodPut, Path: apiVersionPrefix + "/folders/:id/download-policy", Request: dto.FolderDownloadPolicyDTO{}, Response: dto.FolderDTO{}},
+
+		// Guests
+		{Method: http.MethodPost, Path: apiVersionPrefix + "/guest-invitations", Request: dto.InviteGuestRequest{}, Status: http.StatusCreated, Response: dto.GuestInvitationDTO{}},
+		{Method: http.MethodGet, Path: apiVersionPrefix + "/guest/documents", Response: dto.DocumentDTO{}, Envelope: openapi.EnvelopePaginated},
+		{Method: http.MethodGet, Path: apiVersionPrefix + "/guest/documents/:id", Response: dto.DocumentDTO{}},
+		{Method: http.MethodGet, Path: apiVersionPrefix + "/guest/documents/:id/download", Status: http.StatusFound,
+			Description: "Redirects to a short-lived presigned download URL."},
+
+		// Share links
+		{Method: http.MethodGet, Path: "/share/:token", Status: http.StatusFound,
+			Description: "Redirects to a short-lived presigned download URL of the shared document."},
+
+		// Single sign-on
+		{Method: http.MethodPost, Path: apiVersionPrefix + "/sso/token", Request: dto.SSOTokenRequest{}, Response: dto.SSOTokenDTO{}},
+
+		// Error catalog
+		{Method: http.MethodGet, Path: apiVersionPrefix + "/errors", Response: []dto.ErrorCodeDTO{}},
+		{Method: http.MethodGet, Path: apiVersionPrefix + "/errors/:code", Response: dto.ErrorCodeDTO{}},
+
+		// GraphQL, whose handler is built by gqlgen
+		{Method: http.MethodPost, Path: apiVersionPrefix + "/graphql", Summary: "Query documents and folders with GraphQL", Tags: []string{"GraphQL"},
+			Description: "Read queries over documents and folders, described by the GraphQL schema of the API.",
+			Request:     graphQLRequest{}, Response: graphQLResponse{}, Envelope: openapi.EnvelopeNone},
+	}
+
+	if platformAPI {
+		routes = append(routes, openapi.Route{
+			Method: http.MethodPost, Path: platformVersionPrefix + "/tenants", Request: dto.ProvisionTenantRequest{}, Status: http.StatusCreated, Response: dto.ProvisionedTenantDTO{},
+		})
+		if scanDeadLetters {
+			routes = append(routes,
+				openapi.Route{Method: http.MethodGet, Path: platformVersionPrefix + "/scan-dead-letters", Response: []dto.ScanDeadLetterDTO{},
+					Description: "Lists up to limit (1 to 1000, 100 by default) dead-lettered scan tasks without removing them from the queue."},
+				openapi.Route{Method: http.MethodPost, Path: platformVersionPrefix + "/scan-dead-letters/replay", Request: dto.ReplayScanDeadLettersRequest{}, Response: dto.ReplayedScanDeadLettersDTO{},
+					Description: "Selects the tasks to replay by message_ids, tenant_id or all, exactly one of them."},
+			)
+		}
+	}
+	return routes
+}
@@ -3,18 +3,29 @@ package api
 
 import (
 	"github.com/gin-gonic/gin" // v1.9.0+
+	"github.com/gin-gonic/gin/binding" // v1.9.0+
 	"net/http" // standard library
 	"github.com/project/handlers" // latest
+	"github.com/project/graphql" // latest
+	"github.com/project/dav" // latest
 	"github.com/project/middleware" // latest
 	"github.com/project/config" // latest
 	"github.com/sirupsen/logrus" // v1.9.0+
 	"github.com/project/application/usecases" // latest
 	"github.com/project/domain/services/auth" // latest
+	"github.com/project/domain/services" // latest
+	"github.com/project/domain/repositories" // latest
+	"github.com/project/pkg/validator" // latest
+	"github.com/project/openapi" // latest
 )
 
 // apiVersionPrefix defines the API version prefix for all routes
 const apiVersionPrefix = "/api/v1"
 
+// platformVersionPrefix defines the prefix of the platform operator routes, which act on tenants
+// rather than within one
+const platformVersionPrefix = "/platform/v1"
+
 // SetupRouter sets up the main router for the Document Management Platform API
 // It configures all routes, middleware, and connects API endpoints to the appropriate use cases
 func SetupRouter(
@@ -23,7 +34,41 @@ func SetupRouter(
 	folderUseCase usecases.FolderUseCase,
 	searchUseCase usecases.SearchUseCase,
 	webhookUseCase usecases.WebhookUseCase,
+	auditUseCase usecases.AuditUseCase,
+	roleUseCase usecases.RoleUseCase,
+	policyUseCase usecases.PolicyUseCase,
+	groupUseCase usecases.GroupUseCase,
+	shareLinkUseCase usecases.ShareLinkUseCase,
+	apiKeyUseCase usecases.APIKeyUseCase,
+	ssoUseCase usecases.SSOUseCase,
+	sessionUseCase usecases.SessionUseCase,
+	tenantUseCase usecases.TenantUseCase,
+	guestUseCase usecases.GuestUseCase,
+	exportUseCase usecases.ExportUseCase,
+	quarantineUseCase usecases.QuarantineUseCase,
+	reindexUseCase usecases.ReindexUseCase,
+	subjectAccessUseCase usecases.SubjectAccessUseCase,
+	metadataSchemaUseCase usecases.MetadataSchemaUseCase,
+	metadataTemplateUseCase usecases.MetadataTemplateUseCase,
+	folderTemplateUseCase usecases.FolderTemplateUseCase,
+	favoriteUseCase usecases.FavoriteUseCase,
+	commentUseCase usecases.CommentUseCase,
+	approvalUseCase usecases.ApprovalUseCase,
+	signatureUseCase usecases.SignatureUseCase,
+	documentLinkUseCase usecases.DocumentLinkUseCase,
+	sequenceUseCase usecases.SequenceUseCase,
+	activityUseCase usecases.ActivityUseCase,
+	tenantProvisioningUseCase usecases.TenantProvisioningUseCase,
+	healthCheckers map[string]handlers.HealthChecker,
+	guestInviter handlers.GuestInviter,
 	authService auth.AuthService,
+	networkPolicyService services.NetworkPolicyService,
+	tenantSettingsService services.TenantSettingsService,
+	rateLimitRepo repositories.RateLimitRepository,
+	idempotencyRepo repositories.IdempotencyRepository,
+	davAuthenticator dav.Authenticator,
+	scanDeadLetters services.ScanDeadLetterQueue,
+	platformOperatorToken string,
 ) *gin.Engine {
 	// Set Gin to release mode in production
 	if cfg.Environment == "production" {
@@ -33,44 +78,148 @@ func SetupRouter(
 	// Create a new Gin router
 	router := gin.New()
 
+	// Report every field violation of requests at once, with fields named as clients send them
+	binding.Validator = validator.StructValidator{}
+
+	// Only take client addresses from X-Forwarded-For when set by our own proxies, since network
+	// policies, rate limits and audit logs rely on them. Without valid proxies, no proxy is trusted.
+	if err := router.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		logrus.WithError(err).Error("Invalid trusted proxies, client addresses are taken from connections")
+		router.SetTrustedProxies(nil)
+	}
+
 	// Apply global middleware
 	router.Use(gin.Recovery())                             // Recover from panics
-	router.Use(middleware.Logger(cfg.LogLevel))            // Request logging
+	router.Use(middleware.RequestID())                     // Accept or assign the request ID returned in X-Request-ID
+	router.Use(middleware.ProblemDetails())                // Error responses as application/problem+json
+	router.Use(middleware.Tracing())                       // Request spans, before logging so logs carry the trace ID
+	router.Use(middleware.Metrics())                       // Request rate, errors and duration
+	router.Use(middleware.Logger(cfg.Log.Access))          // Access log with redacted credentials
+	router.Use(middleware.Localization(nil))               // Language of error messages from Accept-Language
 	router.Use(middleware.CORS(cfg.CORSAllowOrigins))      // CORS handling
 	router.Use(middleware.RateLimiter(cfg.GlobalRateLimit)) // Global rate limiting
 
 	// Create handler instances
-	healthHandler := handlers.NewHealthHandler(cfg)
+	healthHandler := handlers.NewHealthHandler(healthCheckers)
 	documentHandler := handlers.NewDocumentHandler(documentUseCase)
 	folderHandler := handlers.NewFolderHandler(folderUseCase)
 	searchHandler := handlers.NewSearchHandler(searchUseCase)
 	webhookHandler := handlers.NewWebhookHandler(webhookUseCase)
+	auditHandler := handlers.NewAuditHandler(auditUseCase)
+	roleHandler := handlers.NewRoleHandler(roleUseCase)
+	policyHandler := handlers.NewPolicyHandler(policyUseCase)
+	groupHandler := handlers.NewGroupHandler(groupUseCase)
+	shareLinkHandler := handlers.NewShareLinkHandler(shareLinkUseCase, cfg.Server.PublicURL)
+	apiKeyHandler := handlers.NewAPIKeyHandler(apiKeyUseCase)
+	ssoHandler := handlers.NewSSOHandler(ssoUseCase)
+	sessionHandler := handlers.NewSessionHandler(sessionUseCase)
+	tenantHandler := handlers.NewTenantHandler(tenantUseCase)
+	guestHandler := handlers.NewGuestHandler(guestUseCase, guestInviter)
+	exportHandler := handlers.NewExportHandler(exportUseCase)
+	quarantineHandler := handlers.NewQuarantineHandler(quarantineUseCase)
+	reindexHandler := handlers.NewReindexHandler(reindexUseCase)
+	subjectAccessHandler := handlers.NewSubjectAccessHandler(subjectAccessUseCase)
+	metadataSchemaHandler := handlers.NewMetadataSchemaHandler(metadataSchemaUseCase)
+	metadataTemplateHandler := handlers.NewMetadataTemplateHandler(metadataTemplateUseCase)
+	folderTemplateHandler := handlers.NewFolderTemplateHandler(folderTemplateUseCase)
+	favoriteHandler := handlers.NewFavoriteHandler(favoriteUseCase)
+	commentHandler := handlers.NewCommentHandler(commentUseCase)
+	approvalHandler := handlers.NewApprovalHandler(approvalUseCase)
+	signatureHandler := handlers.NewSignatureHandler(signatureUseCase)
+	documentLinkHandler := handlers.NewDocumentLinkHandler(documentLinkUseCase)
+	sequenceHandler := handlers.NewSequenceHandler(sequenceUseCase)
+	activityHandler := handlers.NewActivityHandler(activityUseCase)
+	errorCatalogHandler := handlers.NewErrorCatalogHandler()
 
 	// Set up health check endpoints (no auth required)
 	setupHealthRoutes(router, healthHandler)
 
+	// Set up the error catalog (no auth required, problem type URIs point to it)
+	setupErrorCatalogRoutes(router, errorCatalogHandler)
+
+	// Set up public share link downloads (no auth required, the link token grants access)
+	setupPublicShareLinkRoutes(router, shareLinkHandler)
+
+	// Set up single sign-on (no auth required, the IdP ID token is exchanged for platform tokens)
+	setupSSORoutes(router, ssoHandler)
+
+	// Set up signature provider callbacks (no auth required, each provider verifies its own updates)
+	setupSignatureCallbackRoutes(router, signatureHandler)
+
+	// Set up read-only routes for external guests (guest token required, user tokens are rejected)
+	setupGuestAccessRoutes(router, guestHandler, authService, networkPolicyService, tenantSettingsService)
+
+	// Set up WebDAV for mounting tenant folders as a drive (basic auth, exchanged for a platform token)
+	setupDAVRoutes(router, dav.NewHandler(davAuthenticator, networkPolicyService, folderUseCase, documentUseCase))
+
+	// Set up the platform operator routes (operator token required), when the platform API is enabled
+	if tenantProvisioningUseCase != nil {
+		setupPlatformRoutes(router, handlers.NewPlatformHandler(tenantProvisioningUseCase, scanDeadLetters), platformOperatorToken)
+	}
+
 	// Create API v1 route group with authentication middleware
 	api := router.Group(apiVersionPrefix)
-	api.Use(middleware.Authentication(authService)) // JWT validation
+	api.Use(middleware.APIKeyAuthentication(apiKeyUseCase, middleware.Authentication(authService))) // API key or JWT validation
+	api.Use(middleware.Localization(tenantSettingsService))                                        // Tenant default language when Accept-Language has none
+	api.Use(middleware.AuditContext())                                                             // Actor details for audit logging
+	api.Use(middleware.NetworkPolicy(networkPolicyService))                                        // Tenant IP allowlists and blocked countries
+	api.Use(middleware.RateLimit(cfg.RateLimiter, rateLimitRepo))                                 // Per-tenant, per-user and per-route rate limiting, replays included
+	api.Use(middleware.Idempotency(cfg.Idempotency, idempotencyRepo))                             // Replay responses of retried requests with an Idempotency-Key
 
 	// Set up resource-specific routes
 	setupDocumentRoutes(api, documentHandler, cfg)
 	setupFolderRoutes(api, folderHandler, documentHandler, cfg)
 	setupSearchRoutes(api, searchHandler, cfg)
+	setupReindexRoutes(api, reindexHandler)
 	setupWebhookRoutes(api, webhookHandler, cfg)
+	setupAuditRoutes(api, auditHandler)
+	setupRoleRoutes(api, roleHandler)
+	setupPolicyRoutes(api, policyHandler)
+	setupMetadataSchemaRoutes(api, metadataSchemaHandler)
+	setupMetadataTemplateRoutes(api, metadataTemplateHandler)
+	setupFolderTemplateRoutes(api, folderTemplateHandler)
+	setupFavoriteRoutes(api, favoriteHandler)
+	setupCommentRoutes(api, commentHandler)
+	setupApprovalRoutes(api, approvalHandler)
+	setupSignatureRoutes(api, signatureHandler)
+	setupDocumentLinkRoutes(api, documentLinkHandler)
+	setupSequenceRoutes(api, sequenceHandler)
+	setupActivityRoutes(api, activityHandler)
+	setupGroupRoutes(api, groupHandler)
+	setupShareLinkRoutes(api, shareLinkHandler)
+	setupAPIKeyRoutes(api, apiKeyHandler)
+	setupSessionRoutes(api, sessionHandler)
+	setupTenantRoutes(api, tenantHandler)
+	setupSubjectAccessRoutes(api, subjectAccessHandler)
+	setupGuestInvitationRoutes(api, guestHandler)
+	setupExportRoutes(api, exportHandler)
+	setupQuarantineRoutes(api, quarantineHandler)
+	setupGraphQLRoutes(api, graphql.NewResolver(documentUseCase, folderUseCase, searchUseCase))
+
+	// Set up the OpenAPI document of the routes above and Swagger UI (no auth required)
+	setupOpenAPIRoutes(router, newOpenAPISpec(tenantProvisioningUseCase != nil, scanDeadLetters != nil))
 
 	return router
 }
 
-// setupHealthRoutes sets up health check endpoints for the API
+// setupHealthRoutes sets up health check endpoints for the API: /healthz and /readyz for the Kubernetes
+// probes, and the liveness, readiness and deep checks under /health
 func setupHealthRoutes(router *gin.Engine, healthHandler *handlers.HealthHandler) {
-	health := router.Group("/health")
-	// Simple liveness check to indicate the service is running
-	health.GET("/liveness", healthHandler.Liveness)
-	// Readiness check to verify the service can handle requests
-	health.GET("/readiness", healthHandler.Readiness)
-	// Deep health check to verify connections to dependencies
-	health.GET("/deep", healthHandler.DeepCheck)
+	healthHandler.RegisterRoutes(router)
+}
+
+// setupDAVRoutes sets up the WebDAV endpoint for every WebDAV method, as /dav/<tenant ID>/<folder path>
+func setupDAVRoutes(router *gin.Engine, davHandler *dav.Handler) {
+	davGroup := router.Group(dav.PathPrefix)
+	for _, method := range dav.Methods {
+		davGroup.Handle(method, "/:tenantId", davHandler.ServeDAV)
+		davGroup.Handle(method, "/:tenantId/*path", davHandler.ServeDAV)
+	}
+}
+
+// setupGraphQLRoutes sets up the GraphQL endpoint, which serves read queries over documents and folders
+func setupGraphQLRoutes(api *gin.RouterGroup, resolver *graphql.Resolver) {
+	api.POST("/graphql", middleware.Authorization("reader"), graphql.NewHandler(resolver))
 }
 
 // setupDocumentRoutes sets up document-related API routes
@@ -78,16 +227,23 @@ func setupDocumentRoutes(api *gin.RouterGroup, documentHandler *handlers.Documen
 	// Document routes with authentication
 	documents := api.Group("/documents")
 	
-	// Apply upload rate limiting middleware to upload endpoint
-	uploadLimiter := middleware.UploadRateLimiter(cfg.UploadRateLimit)
-	
 	// Document operations
 	// Upload a new document
-	documents.POST("", uploadLimiter, middleware.Authorization("contributor"), documentHandler.UploadDocument)
+	documents.POST("", middleware.Authorization("contributor"), documentHandler.UploadDocument)
+	// Upload several documents in one request
+	documents.POST("/batch", middleware.Authorization("contributor"), documentHandler.UploadDocuments)
 	// Get document metadata
 	documents.GET("/:id", middleware.Authorization("reader"), documentHandler.GetDocument)
 	// Download document content
 	documents.GET("/:id/content", middleware.Authorization("reader"), documentHandler.DownloadDocument)
+	// List the versions of a document
+	documents.GET("/:id/versions", middleware.Authorization("reader"), documentHandler.ListDocumentVersions)
+	// Download a version of a document
+	documents.GET("/:id/versions/:versionId/content", middleware.Authorization("reader"), documentHandler.DownloadDocumentVersion)
+	// Get the block signature of a version, which delta patches are built against
+	documents.GET("/:id/versions/:versionId/signature", middleware.Authorization("contributor"), documentHandler.GetVersionSignature)
+	// Upload a new version of a document as a delta patch against one of its versions
+	documents.POST("/:id/versions/delta", middleware.Authorization("contributor"), documentHandler.UploadVersionDelta)
 	// Get a presigned URL for document download
 	documents.GET("/:id/content/url", middleware.Authorization("reader"), documentHandler.GetDocumentURL)
 	// Download multiple documents as a zip archive
@@ -100,6 +256,10 @@ func setupDocumentRoutes(api *gin.RouterGroup, documentHandler *handlers.Documen
 	documents.GET("/:id/thumbnail", middleware.Authorization("reader"), documentHandler.GetDocumentThumbnail)
 	// Get a presigned URL for document thumbnail
 	documents.GET("/:id/thumbnail/url", middleware.Authorization("reader"), documentHandler.GetDocumentThumbnailURL)
+	// Set when a document is published to readers and when it expires
+	documents.PUT("/:id/schedule", middleware.Authorization("contributor"), documentHandler.ScheduleDocument)
+	// Read the stored content of a document back and check it against its hash
+	documents.POST("/:id/verify-integrity", middleware.Authorization("reader"), documentHandler.VerifyIntegrity)
 	// Update document metadata
 	documents.PUT("/:id", middleware.Authorization("contributor"), documentHandler.UpdateDocument)
 	// Delete a document
@@ -124,6 +284,10 @@ func setupFolderRoutes(api *gin.RouterGroup, folderHandler *handlers.FolderHandl
 	folders.GET("", middleware.Authorization("reader"), folderHandler.ListFolders)
 	// Move a folder to a different parent
 	folders.PUT("/:id/move", middleware.Authorization("contributor"), folderHandler.MoveFolder)
+	// Turn watermarking of documents downloaded from a folder on or off
+	folders.PUT("/:id/watermark", middleware.Authorization("administrator"), folderHandler.SetDownloadWatermark)
+	// Set how documents of a folder can be downloaded: view-only, daily limits or recent sign-in
+	folders.PUT("/:id/download-policy", middleware.Authorization("administrator"), folderHandler.SetDownloadPolicy)
 	// Search for folders by name or metadata
 	folders.GET("/search", middleware.Authorization("reader"), folderHandler.SearchFolders)
 	// Get a folder by its path
@@ -134,9 +298,8 @@ func setupFolderRoutes(api *gin.RouterGroup, folderHandler *handlers.FolderHandl
 
 // setupSearchRoutes sets up search-related API routes
 func setupSearchRoutes(api *gin.RouterGroup, searchHandler *handlers.SearchHandler, cfg config.Config) {
-	// Search routes with authentication and search rate limiting
+	// Search routes with authentication; search rate limits are configured per route
 	search := api.Group("/search")
-	search.Use(middleware.SearchRateLimiter(cfg.SearchRateLimit))
 	
 	// Search operations
 	// Search documents by content
@@ -149,6 +312,15 @@ func setupSearchRoutes(api *gin.RouterGroup, searchHandler *handlers.SearchHandl
 	search.POST("/folder", middleware.Authorization("reader"), searchHandler.SearchInFolder)
 }
 
+// setupReindexRoutes sets up routes for administrators to rebuild the search index of their tenant
+func setupReindexRoutes(api *gin.RouterGroup, reindexHandler *handlers.ReindexHandler) {
+	// Reindex operations
+	// Schedule a rebuild of the search index, e.g. after a mapping change or index corruption
+	api.POST("/search/reindex", middleware.Authorization("administrator"), reindexHandler.ReindexTenant)
+	// Get the status and progress of a rebuild
+	api.GET("/search/reindex/:id", middleware.Authorization("administrator"), reindexHandler.GetReindexJob)
+}
+
 // setupWebhookRoutes sets up webhook-related API routes
 func setupWebhookRoutes(api *gin.RouterGroup, webhookHandler *handlers.WebhookHandler, cfg config.Config) {
 	// Webhook routes with authentication
@@ -165,12 +337,503 @@ func setupWebhookRoutes(api *gin.RouterGroup, webhookHandler *handlers.WebhookHa
 	webhooks.PUT("/:id", middleware.Authorization("administrator"), webhookHandler.UpdateWebhook)
 	// Delete a webhook
 	webhooks.DELETE("/:id", middleware.Authorization("administrator"), webhookHandler.DeleteWebhook)
+	// Rotate the webhook signing secret
+	webhooks.POST("/:id/rotate-secret", middleware.Authorization("administrator"), webhookHandler.RotateWebhookSecret)
+	// Send a sample payload to the webhook
+	webhooks.POST("/:id/test", middleware.Authorization("administrator"), webhookHandler.SendTestDelivery)
+	// Add an event filter subscription to a webhook
+	webhooks.POST("/:id/subscriptions", middleware.Authorization("administrator"), webhookHandler.CreateSubscription)
+	// List the subscriptions of a webhook
+	webhooks.GET("/:id/subscriptions", middleware.Authorization("reader"), webhookHandler.ListSubscriptions)
+	// Get a webhook subscription
+	webhooks.GET("/:id/subscriptions/:subscriptionId", middleware.Authorization("reader"), webhookHandler.GetSubscription)
+	// Update a webhook subscription
+	webhooks.PUT("/:id/subscriptions/:subscriptionId", middleware.Authorization("administrator"), webhookHandler.UpdateSubscription)
+	// Delete a webhook subscription
+	webhooks.DELETE("/:id/subscriptions/:subscriptionId", middleware.Authorization("administrator"), webhookHandler.DeleteSubscription)
 	// Get all supported event types
 	webhooks.GET("/event-types", middleware.Authorization("reader"), webhookHandler.GetEventTypes)
 	// List delivery attempts for a webhook
 	webhooks.GET("/:id/deliveries", middleware.Authorization("reader"), webhookHandler.ListWebhookDeliveries)
+	// Get a delivery of a webhook with its attempt history
+	webhooks.GET("/:id/deliveries/:deliveryId", middleware.Authorization("reader"), webhookHandler.GetWebhookDelivery)
+	// Query the tenant-wide delivery log by webhook, status and time range
+	webhooks.GET("/deliveries", middleware.Authorization("administrator"), webhookHandler.ListDeliveryLog)
+	// List deliveries that exhausted their retries
+	webhooks.GET("/deliveries/dead-letter", middleware.Authorization("administrator"), webhookHandler.ListDeadLetteredDeliveries)
 	// Get details of a specific delivery attempt
 	webhooks.GET("/deliveries/:id", middleware.Authorization("reader"), webhookHandler.GetDeliveryStatus)
+	// Get the attempt history of a delivery
+	webhooks.GET("/deliveries/:id/attempts", middleware.Authorization("reader"), webhookHandler.ListDeliveryAttempts)
 	// Retry a failed webhook delivery
 	webhooks.POST("/deliveries/:id/retry", middleware.Authorization("administrator"), webhookHandler.RetryDelivery)
-}
\ No newline at end of file
+}
+
+// setupAuditRoutes sets up audit log API routes
+func setupAuditRoutes(api *gin.RouterGroup, auditHandler *handlers.AuditHandler) {
+	// Audit routes with authentication
+	audit := api.Group("/audit")
+
+	// Audit log operations
+	// Query the audit log by actor, action, resource and time range
+	audit.GET("", middleware.Authorization("administrator"), auditHandler.ListAuditLogs)
+	// Export the audit log as CSV or JSON Lines
+	audit.GET("/export", middleware.Authorization("administrator"), auditHandler.ExportAuditLogs)
+	// Get a single audit log entry
+	audit.GET("/:id", middleware.Authorization("administrator"), auditHandler.GetAuditLog)
+}
+
+// setupRoleRoutes sets up tenant role management API routes
+func setupRoleRoutes(api *gin.RouterGroup, roleHandler *handlers.RoleHandler) {
+	// Role routes with authentication
+	roles := api.Group("/roles")
+
+	// Role operations
+	// Create a custom role with a set of permissions
+	roles.POST("", middleware.Authorization("administrator"), roleHandler.CreateRole)
+	// List the tenant's roles including the predefined system roles
+	roles.GET("", middleware.Authorization("administrator"), roleHandler.ListRoles)
+	// Get a role and the permissions it grants
+	roles.GET("/:id", middleware.Authorization("administrator"), roleHandler.GetRole)
+	// Update the description and permissions of a custom role
+	roles.PUT("/:id", middleware.Authorization("administrator"), roleHandler.UpdateRole)
+	// Delete a custom role
+	roles.DELETE("/:id", middleware.Authorization("administrator"), roleHandler.DeleteRole)
+}
+
+// setupPolicyRoutes sets up attribute-based access policy API routes
+func setupPolicyRoutes(api *gin.RouterGroup, policyHandler *handlers.PolicyHandler) {
+	// Policy routes with authentication
+	policies := api.Group("/policies")
+
+	// Access policy operations
+	// Create a policy restricting document access by attribute
+	policies.POST("", middleware.Authorization("administrator"), policyHandler.CreatePolicy)
+	// List the tenant's access policies
+	policies.GET("", middleware.Authorization("administrator"), policyHandler.ListPolicies)
+	// Get an access policy
+	policies.GET("/:id", middleware.Authorization("administrator"), policyHandler.GetPolicy)
+	// Replace an access policy's rule or enable/disable it
+	policies.PUT("/:id", middleware.Authorization("administrator"), policyHandler.UpdatePolicy)
+	// Delete an access policy
+	policies.DELETE("/:id", middleware.Authorization("administrator"), policyHandler.DeletePolicy)
+}
+
+// setupMetadataSchemaRoutes sets up the routes managing the metadata fields of the tenant's schema
+func setupMetadataSchemaRoutes(api *gin.RouterGroup, metadataSchemaHandler *handlers.MetadataSchemaHandler) {
+	// Metadata schema routes with authentication
+	fields := api.Group("/metadata-schema/fields")
+
+	// Metadata field operations
+	// Define a typed, optionally required metadata field
+	fields.POST("", middleware.Authorization("administrator"), metadataSchemaHandler.CreateField)
+	// List the tenant's metadata fields; any user may read the schema to fill in metadata
+	fields.GET("", metadataSchemaHandler.GetSchema)
+	// Get a metadata field
+	fields.GET("/:id", metadataSchemaHandler.GetField)
+	// Replace a metadata field's definition
+	fields.PUT("/:id", middleware.Authorization("administrator"), metadataSchemaHandler.UpdateField)
+	// Delete a metadata field
+	fields.DELETE("/:id", middleware.Authorization("administrator"), metadataSchemaHandler.DeleteField)
+}
+
+// setupMetadataTemplateRoutes sets up the routes managing the metadata templates of folders
+func setupMetadataTemplateRoutes(api *gin.RouterGroup, metadataTemplateHandler *handlers.MetadataTemplateHandler) {
+	// Metadata template routes with authentication
+	templates := api.Group("/metadata-templates")
+
+	// Metadata template operations
+	// Require metadata of the documents uploaded to a folder, enforced or prompted for
+	templates.POST("", middleware.Authorization("administrator"), metadataTemplateHandler.CreateTemplate)
+	// List the tenant's metadata templates
+	templates.GET("", middleware.Authorization("administrator"), metadataTemplateHandler.ListTemplates)
+	// Get a metadata template
+	templates.GET("/:id", middleware.Authorization("administrator"), metadataTemplateHandler.GetTemplate)
+	// Replace a metadata template's fields and mode
+	templates.PUT("/:id", middleware.Authorization("administrator"), metadataTemplateHandler.UpdateTemplate)
+	// Delete a metadata template
+	templates.DELETE("/:id", middleware.Authorization("administrator"), metadataTemplateHandler.DeleteTemplate)
+
+	// Get the metadata template of a folder, so uploaders can be prompted for its fields
+	api.GET("/folders/:id/metadata-template", middleware.Authorization("reader"), metadataTemplateHandler.GetFolderTemplate)
+}
+
+// setupFolderTemplateRoutes sets up the routes managing folder templates and applying them to set
+// up projects and tenants
+func setupFolderTemplateRoutes(api *gin.RouterGroup, folderTemplateHandler *handlers.FolderTemplateHandler) {
+	templates := api.Group("/folder-templates")
+
+	// Folder template operations
+	// Define a folder tree with its permissions and metadata templates
+	templates.POST("", middleware.Authorization("administrator"), folderTemplateHandler.CreateTemplate)
+	// List the tenant's folder templates
+	templates.GET("", middleware.Authorization("administrator"), folderTemplateHandler.ListTemplates)
+	// Get a folder template
+	templates.GET("/:id", middleware.Authorization("administrator"), folderTemplateHandler.GetTemplate)
+	// Replace a folder template; folders already created from it are not changed
+	templates.PUT("/:id", middleware.Authorization("administrator"), folderTemplateHandler.UpdateTemplate)
+	// Delete a folder template
+	templates.DELETE("/:id", middleware.Authorization("administrator"), folderTemplateHandler.DeleteTemplate)
+	// Create a folder holding the template's folders, permissions and metadata templates in one call
+	templates.POST("/:id/apply", middleware.Authorization("administrator"), folderTemplateHandler.ApplyTemplate)
+}
+
+// setupFavoriteRoutes sets up the routes of the Starred and Recents views of the requesting user;
+// the use case checks the user can read the starred documents and folders
+func setupFavoriteRoutes(api *gin.RouterGroup, favoriteHandler *handlers.FavoriteHandler) {
+	// Starring operations
+	// Star a document
+	api.PUT("/documents/:id/star", middleware.Authorization("reader"), favoriteHandler.StarDocument)
+	// Unstar a document
+	api.DELETE("/documents/:id/star", middleware.Authorization("reader"), favoriteHandler.UnstarDocument)
+	// Star a folder
+	api.PUT("/folders/:id/star", middleware.Authorization("reader"), favoriteHandler.StarFolder)
+	// Unstar a folder
+	api.DELETE("/folders/:id/star", middleware.Authorization("reader"), favoriteHandler.UnstarFolder)
+
+	// Views of the requesting user
+	// List the documents and folders the user starred, most recently starred first
+	api.GET("/me/starred", middleware.Authorization("reader"), favoriteHandler.ListStarred)
+	// List the documents the user most recently downloaded, uploaded or edited
+	api.GET("/me/recent", middleware.Authorization("reader"), favoriteHandler.ListRecent)
+}
+
+// setupCommentRoutes sets up the comment thread routes of documents; the use case checks the user
+// can read the document and that only authors change or delete their comments
+func setupCommentRoutes(api *gin.RouterGroup, commentHandler *handlers.CommentHandler) {
+	// Comment operations
+	// Comment on a document, optionally anchored to a page or region, or reply to a thread
+	api.POST("/documents/:id/comments", middleware.Authorization("reader"), commentHandler.CreateComment)
+	// List the comments of a document, oldest first
+	api.GET("/documents/:id/comments", middleware.Authorization("reader"), commentHandler.ListComments)
+	// Get a comment
+	api.GET("/documents/:id/comments/:commentId", middleware.Authorization("reader"), commentHandler.GetComment)
+	// Edit a comment
+	api.PUT("/documents/:id/comments/:commentId", middleware.Authorization("reader"), commentHandler.UpdateComment)
+	// Delete a comment together with its replies
+	api.DELETE("/documents/:id/comments/:commentId", middleware.Authorization("reader"), commentHandler.DeleteComment)
+
+	// Thread operations
+	// Resolve a thread
+	api.POST("/documents/:id/comments/:commentId/resolve", middleware.Authorization("reader"), commentHandler.ResolveComment)
+	// Reopen a resolved thread
+	api.POST("/documents/:id/comments/:commentId/reopen", middleware.Authorization("reader"), commentHandler.ReopenComment)
+}
+
+// setupDocumentLinkRoutes sets up the routes linking documents to one another; the use case checks
+// the user can write the source of a link to change it and read a document to see its links
+func setupDocumentLinkRoutes(api *gin.RouterGroup, documentLinkHandler *handlers.DocumentLinkHandler) {
+	// Document link operations
+	// Link a document to a target document as relates-to, supersedes or attachment-of
+	api.POST("/documents/:id/links", middleware.Authorization("contributor"), documentLinkHandler.CreateLink)
+	// List the links from and to a document, oldest first
+	api.GET("/documents/:id/links", middleware.Authorization("reader"), documentLinkHandler.ListLinks)
+	// Get a link of a document
+	api.GET("/documents/:id/links/:linkId", middleware.Authorization("reader"), documentLinkHandler.GetLink)
+	// Change the type of a link
+	api.PUT("/documents/:id/links/:linkId", middleware.Authorization("contributor"), documentLinkHandler.UpdateLink)
+	// Delete a link
+	api.DELETE("/documents/:id/links/:linkId", middleware.Authorization("contributor"), documentLinkHandler.DeleteLink)
+}
+
+// setupSequenceRoutes sets up the routes managing the sequences numbering the documents uploaded to folders
+func setupSequenceRoutes(api *gin.RouterGroup, sequenceHandler *handlers.SequenceHandler) {
+	sequences := api.Group("/numbering-sequences")
+
+	// Numbering sequence operations
+	// Number the documents uploaded to a folder, or to any folder of the tenant without a sequence
+	sequences.POST("", middleware.Authorization("administrator"), sequenceHandler.CreateSequence)
+	// List the tenant's numbering sequences
+	sequences.GET("", middleware.Authorization("administrator"), sequenceHandler.ListSequences)
+	// Get a numbering sequence
+	sequences.GET("/:id", middleware.Authorization("administrator"), sequenceHandler.GetSequence)
+	// Replace a numbering sequence's format, padding, mode and reset
+	sequences.PUT("/:id", middleware.Authorization("administrator"), sequenceHandler.UpdateSequence)
+	// Delete a numbering sequence; documents keep the numbers it assigned
+	sequences.DELETE("/:id", middleware.Authorization("administrator"), sequenceHandler.DeleteSequence)
+}
+
+// setupActivityRoutes sets up the activity timeline routes of documents and folders; the use case
+// checks the user can read the document or folder
+func setupActivityRoutes(api *gin.RouterGroup, activityHandler *handlers.ActivityHandler) {
+	// Activity timelines
+	// List the uploads, renames, moves and shares of a document, newest first
+	api.GET("/documents/:id/activity", middleware.Authorization("reader"), activityHandler.ListDocumentActivity)
+	// List the activity of a folder and of the documents in it, newest first
+	api.GET("/folders/:id/activity", middleware.Authorization("reader"), activityHandler.ListFolderActivity)
+}
+
+// setupApprovalRoutes sets up the approval workflow routes; administrators attach approval chains
+// to folders, and the use case checks who can submit, decide on and cancel approval requests
+func setupApprovalRoutes(api *gin.RouterGroup, approvalHandler *handlers.ApprovalHandler) {
+	workflows := api.Group("/approval-workflows")
+
+	// Approval workflow operations
+	// Attach an approval chain of sequential or parallel approvers to a folder
+	workflows.POST("", middleware.Authorization("administrator"), approvalHandler.CreateWorkflow)
+	// List the tenant's approval workflows
+	workflows.GET("", middleware.Authorization("administrator"), approvalHandler.ListWorkflows)
+	// Get an approval workflow
+	workflows.GET("/:id", middleware.Authorization("administrator"), approvalHandler.GetWorkflow)
+	// Replace an approval workflow's name, mode and approvers
+	workflows.PUT("/:id", middleware.Authorization("administrator"), approvalHandler.UpdateWorkflow)
+	// Delete an approval workflow
+	workflows.DELETE("/:id", middleware.Authorization("administrator"), approvalHandler.DeleteWorkflow)
+
+	// Get the approval workflow of a folder, so submitters can see who approves its documents
+	api.GET("/folders/:id/approval-workflow", middleware.Authorization("reader"), approvalHandler.GetFolderWorkflow)
+
+	// Approval request operations
+	// Submit a document for approval, locking it until the request is decided or cancelled
+	api.POST("/documents/:id/approval-requests", middleware.Authorization("contributor"), approvalHandler.SubmitForApproval)
+	// List the approval requests of a document, newest first
+	api.GET("/documents/:id/approval-requests", middleware.Authorization("reader"), approvalHandler.ListDocumentRequests)
+	// List the approval requests waiting for a decision of the requesting user
+	api.GET("/me/approvals", middleware.Authorization("reader"), approvalHandler.ListAwaitingApproval)
+	// Get an approval request with the decisions of its approvers
+	api.GET("/approval-requests/:id", middleware.Authorization("reader"), approvalHandler.GetRequest)
+	// Approve an approval request, with an optional comment
+	api.POST("/approval-requests/:id/approve", middleware.Authorization("reader"), approvalHandler.Approve)
+	// Reject an approval request, with an optional comment
+	api.POST("/approval-requests/:id/reject", middleware.Authorization("reader"), approvalHandler.Reject)
+	// Withdraw a pending approval request, unlocking its document
+	api.POST("/approval-requests/:id/cancel", middleware.Authorization("reader"), approvalHandler.CancelRequest)
+}
+
+// setupSignatureRoutes sets up the electronic signature routes; the use case checks who can send,
+// view and cancel the signature requests of a document
+func setupSignatureRoutes(api *gin.RouterGroup, signatureHandler *handlers.SignatureHandler) {
+	// List the signature providers documents can be sent with
+	api.GET("/signature-providers", middleware.Authorization("reader"), signatureHandler.ListProviders)
+
+	// Signature request operations
+	// Send the latest version of a document to signers through a signature provider
+	api.POST("/documents/:id/signature-requests", middleware.Authorization("contributor"), signatureHandler.RequestSignature)
+	// List the signature requests of a document, newest first
+	api.GET("/documents/:id/signature-requests", middleware.Authorization("reader"), signatureHandler.ListDocumentRequests)
+	// Get a signature request with the status of its signers
+	api.GET("/signature-requests/:id", middleware.Authorization("reader"), signatureHandler.GetRequest)
+	// Void the envelope of a signature request that is out for signature, with an optional reason
+	api.POST("/signature-requests/:id/cancel", middleware.Authorization("contributor"), signatureHandler.CancelRequest)
+}
+
+// setupGroupRoutes sets up user group management API routes
+func setupGroupRoutes(api *gin.RouterGroup, groupHandler *handlers.GroupHandler) {
+	// Group routes with authentication
+	groups := api.Group("/groups")
+
+	// Group operations
+	// Create a group
+	groups.POST("", middleware.Authorization("administrator"), groupHandler.CreateGroup)
+	// List the tenant's groups
+	groups.GET("", middleware.Authorization("administrator"), groupHandler.ListGroups)
+	// Get a group
+	groups.GET("/:id", middleware.Authorization("administrator"), groupHandler.GetGroup)
+	// Rename a group or change its description
+	groups.PUT("/:id", middleware.Authorization("administrator"), groupHandler.UpdateGroup)
+	// Delete a group together with its memberships and grants
+	groups.DELETE("/:id", middleware.Authorization("administrator"), groupHandler.DeleteGroup)
+
+	// Group membership operations
+	// List the members of a group
+	groups.GET("/:id/members", middleware.Authorization("administrator"), groupHandler.ListMembers)
+	// Add a user to a group
+	groups.POST("/:id/members", middleware.Authorization("administrator"), groupHandler.AddMember)
+	// Remove a user from a group
+	groups.DELETE("/:id/members/:userId", middleware.Authorization("administrator"), groupHandler.RemoveMember)
+
+	// Grant every member of a group a permission on a folder
+	groups.POST("/:id/folder-permissions", middleware.Authorization("administrator"), groupHandler.GrantFolderPermission)
+}
+
+// setupShareLinkRoutes sets up authenticated document share link API routes
+func setupShareLinkRoutes(api *gin.RouterGroup, shareLinkHandler *handlers.ShareLinkHandler) {
+	// Share link operations
+	// Create a public link to a document
+	api.POST("/documents/:id/share-links", shareLinkHandler.CreateShareLink)
+	// List the share links of a document
+	api.GET("/documents/:id/share-links", shareLinkHandler.ListShareLinks)
+	// Revoke a share link
+	api.DELETE("/share-links/:id", shareLinkHandler.RevokeShareLink)
+}
+
+// setupExportRoutes sets up background folder export routes
+func setupExportRoutes(api *gin.RouterGroup, exportHandler *handlers.ExportHandler) {
+	// Export operations
+	// Schedule an export of a folder tree into a ZIP archive
+	api.POST("/folders/:id/export", exportHandler.ExportFolder)
+	// Get the status and progress of an export, with the download URL once it is ready
+	api.GET("/exports/:id", exportHandler.GetExportJob)
+}
+
+// setupQuarantineRoutes sets up quarantine management routes for security administrators
+func setupQuarantineRoutes(api *gin.RouterGroup, quarantineHandler *handlers.QuarantineHandler) {
+	// Quarantine routes with authentication
+	quarantine := api.Group("/quarantine")
+
+	// Quarantine operations
+	// List the documents virus scans found infected
+	quarantine.GET("", middleware.Authorization("administrator"), quarantineHandler.ListQuarantinedItems)
+	// Get a quarantined document with its scan verdict
+	quarantine.GET("/:id", middleware.Authorization("administrator"), quarantineHandler.GetQuarantinedItem)
+	// Release a quarantined document to scan it again or override the verdict
+	quarantine.POST("/:id/release", middleware.Authorization("administrator"), quarantineHandler.ReleaseQuarantinedItem)
+	// Permanently destroy a quarantined document
+	quarantine.POST("/:id/destroy", middleware.Authorization("administrator"), quarantineHandler.DestroyQuarantinedItem)
+}
+
+// setupAPIKeyRoutes sets up API key management routes for service-to-service integrations
+func setupAPIKeyRoutes(api *gin.RouterGroup, apiKeyHandler *handlers.APIKeyHandler) {
+	// API key routes with authentication
+	keys := api.Group("/api-keys")
+
+	// API key operations
+	// Create an API key acting with one of the tenant's roles; the secret is only returned once
+	keys.POST("", middleware.Authorization("administrator"), apiKeyHandler.CreateAPIKey)
+	// List the tenant's API keys
+	keys.GET("", middleware.Authorization("administrator"), apiKeyHandler.ListAPIKeys)
+	// Get an API key
+	keys.GET("/:id", middleware.Authorization("administrator"), apiKeyHandler.GetAPIKey)
+	// Replace an API key's secret, invalidating the old one
+	keys.POST("/:id/rotate", middleware.Authorization("administrator"), apiKeyHandler.RotateAPIKey)
+	// Revoke an API key
+	keys.DELETE("/:id", middleware.Authorization("administrator"), apiKeyHandler.RevokeAPIKey)
+}
+
+// setupSessionRoutes sets up routes for users to see and terminate their active sessions; administrators
+// can manage any user's sessions, which is checked by the use case
+func setupSessionRoutes(api *gin.RouterGroup, sessionHandler *handlers.SessionHandler) {
+	// Session routes with authentication
+	sessions := api.Group("/sessions")
+
+	// Session operations
+	// List a user's active sessions, the requesting user's by default
+	sessions.GET("", sessionHandler.ListSessions)
+	// Revoke all active sessions of a user, forcing them to sign in again everywhere
+	sessions.DELETE("", sessionHandler.RevokeUserSessions)
+	// Revoke a session
+	sessions.DELETE("/:id", sessionHandler.RevokeSession)
+}
+
+// setupTenantRoutes sets up routes for tenant administrators to manage their users and settings
+func setupTenantRoutes(api *gin.RouterGroup, tenantHandler *handlers.TenantHandler) {
+	// Tenant administration routes with authentication
+	tenant := api.Group("/tenant")
+
+	// User operations
+	// List the tenant's users
+	tenant.GET("/users", middleware.Authorization("administrator"), tenantHandler.ListUsers)
+	// Invite a user; the temporary password is returned unless it is emailed to the user
+	tenant.POST("/users", middleware.Authorization("administrator"), tenantHandler.InviteUser)
+	// Deactivate a user
+	tenant.POST("/users/:id/deactivate", middleware.Authorization("administrator"), tenantHandler.DeactivateUser)
+	// Reactivate a user
+	tenant.POST("/users/:id/activate", middleware.Authorization("administrator"), tenantHandler.ActivateUser)
+	// Replace a user's password with a temporary one and unlock the account
+	tenant.POST("/users/:id/reset-password", middleware.Authorization("administrator"), tenantHandler.ResetUserPassword)
+	// Replace a user's roles
+	tenant.PUT("/users/:id/roles", middleware.Authorization("administrator"), tenantHandler.AssignRoles)
+
+	// Tenant operations
+	// Get the users, documents and storage the tenant consumes
+	tenant.GET("/usage", middleware.Authorization("administrator"), tenantHandler.GetUsage)
+	// Get the tenant's settings
+	tenant.GET("/settings", middleware.Authorization("administrator"), tenantHandler.GetSettings)
+	// Change tenant settings such as the password policy and MFA enforcement
+	tenant.PATCH("/settings", middleware.Authorization("administrator"), tenantHandler.UpdateSettings)
+
+	// Upload limit operations
+	// List the upload limits of the tenant's users and roles
+	tenant.GET("/upload-limits", middleware.Authorization("administrator"), tenantHandler.ListUploadLimits)
+	// Set the maximum file size and daily upload volume of a user or role
+	tenant.PUT("/upload-limits/:scope/:id", middleware.Authorization("administrator"), tenantHandler.SetUploadLimit)
+	// Remove the upload limits of a user or role
+	tenant.DELETE("/upload-limits/:scope/:id", middleware.Authorization("administrator"), tenantHandler.DeleteUploadLimit)
+}
+
+// setupSubjectAccessRoutes sets up routes for administrators to answer GDPR subject access requests
+// of their tenant's users
+func setupSubjectAccessRoutes(api *gin.RouterGroup, subjectAccessHandler *handlers.SubjectAccessHandler) {
+	// Subject access operations
+	// Schedule an export of the profile, documents and audit events of a user into a ZIP archive
+	api.POST("/tenant/users/:id/data-export", middleware.Authorization("administrator"), subjectAccessHandler.RequestExport)
+	// Get the status of an export, with the download URL once it is ready
+	api.GET("/tenant/data-exports/:id", middleware.Authorization("administrator"), subjectAccessHandler.GetExport)
+}
+
+// setupErrorCatalogRoutes sets up the unauthenticated routes describing the codes of error responses
+func setupErrorCatalogRoutes(router *gin.Engine, errorCatalogHandler *handlers.ErrorCatalogHandler) {
+	errorCatalog := router.Group(apiVersionPrefix)
+
+	// List the error codes, or describe one, in the language of the request
+	errorCatalogHandler.RegisterRoutes(errorCatalog)
+}
+
+// setupOpenAPIRoutes sets up the unauthenticated OpenAPI document generated from the routes
+// registered so far, and Swagger UI browsing it. Annotations disagreeing with the routes are logged
+// on startup, so that the document cannot drift from the handlers unnoticed.
+func setupOpenAPIRoutes(router *gin.Engine, spec *openapi.Spec) {
+	routes := router.Routes()
+	for _, problem := range spec.Check(routes) {
+		logrus.WithField("problem", problem).Warn("OpenAPI document out of sync with the routes")
+	}
+
+	openAPIHandler := handlers.NewOpenAPIHandler(spec.Generate(routes))
+	openAPIHandler.RegisterRoutes(router)
+}
+
+// setupPublicShareLinkRoutes sets up the unauthenticated share link download route
+func setupPublicShareLinkRoutes(router *gin.Engine, shareLinkHandler *handlers.ShareLinkHandler) {
+	public := router.Group("")
+	public.Use(middleware.AuditContext()) // Client IP and user agent for the download audit entry
+
+	// Redirect to a short-lived presigned download URL for the shared document
+	shareLinkHandler.RegisterPublicRoutes(public)
+}
+
+// setupSignatureCallbackRoutes sets up the unauthenticated routes signature providers post
+// envelope status updates to
+func setupSignatureCallbackRoutes(router *gin.Engine, signatureHandler *handlers.SignatureHandler) {
+	callbacks := router.Group(apiVersionPrefix)
+
+	// Verify and apply a status update of a provider; GET answers webhook URL verification
+	signatureHandler.RegisterCallbackRoutes(callbacks)
+}
+
+// setupSSORoutes sets up the unauthenticated single sign-on route
+func setupSSORoutes(router *gin.Engine, ssoHandler *handlers.SSOHandler) {
+	sso := router.Group(apiVersionPrefix)
+	sso.Use(middleware.AuditContext()) // Client IP and user agent for the provisioning audit entry
+
+	// Exchange an ID token issued by the tenant's identity provider for an access and refresh token
+	ssoHandler.RegisterRoutes(sso)
+}
+
+// setupPlatformRoutes sets up the platform operator routes, authenticated with the operator token
+// instead of user tokens
+func setupPlatformRoutes(router *gin.Engine, platformHandler *handlers.PlatformHandler, operatorToken string) {
+	platform := router.Group(platformVersionPrefix)
+	platform.Use(middleware.PlatformOperatorAuthentication(operatorToken))
+	platform.Use(middleware.AuditContext()) // Client IP and user agent for the provisioning audit entry
+
+	// Provision a tenant with its default roles, initial administrator and root folders
+	platformHandler.RegisterRoutes(platform)
+}
+
+// setupGuestInvitationRoutes sets up the authenticated guest invitation route
+func setupGuestInvitationRoutes(api *gin.RouterGroup, guestHandler *handlers.GuestHandler) {
+	// Invite an external guest to a document or folder; the guest token is emailed to the guest
+	api.POST("/guest-invitations", guestHandler.InviteGuest)
+}
+
+// setupGuestAccessRoutes sets up the read-only routes available to guest tokens
+func setupGuestAccessRoutes(router *gin.Engine, guestHandler *handlers.GuestHandler, authService auth.AuthService, networkPolicyService services.NetworkPolicyService, tenantSettingsService services.TenantSettingsService) {
+	guest := router.Group(apiVersionPrefix + "/guest")
+	guest.Use(middleware.GuestAuthentication(authService))    // Guest token validation
+	guest.Use(middleware.Localization(tenantSettingsService)) // Tenant default language when Accept-Language has none
+	guest.Use(middleware.AuditContext())                      // Client IP and user agent for the download audit entry
+	guest.Use(middleware.NetworkPolicy(networkPolicyService)) // Tenant IP allowlists and blocked countries
+
+	// List, view and download the shared document or the documents of the shared folder
+	guestHandler.RegisterGuestRoutes(guest)
+}
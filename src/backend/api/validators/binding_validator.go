@@ -0,0 +1,40 @@
+// Package validators provides validation functions for API requests.
+// This file converts the errors of binding requests to validation errors reporting every field
+// violation of the request at once.
+package validators
+
+import (
+	"encoding/json"    // standard library
+	stderrors "errors" // standard library
+	"fmt"              // standard library
+
+	"../../pkg/errors"
+	"../../pkg/validator"
+	"../dto"
+)
+
+// BindingError converts an error of binding a request, such as one of c.ShouldBindJSON, to the
+// validation error returned to the client: every field violation when the request breaks the
+// binding tags of its DTO, the field of the wrong type when a JSON value cannot be decoded, and
+// dto.ErrInvalidRequestFormat when the request cannot be read at all.
+func BindingError(err error) error {
+	if len(errors.GetViolations(err)) > 0 {
+		return err
+	}
+
+	// Gin's own validator reports violations in the errors of the validator library
+	if violations := validator.FieldViolations(err); len(violations) > 0 {
+		return errors.NewFieldValidationError(violations)
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if stderrors.As(err, &typeErr) && typeErr.Field != "" {
+		return errors.NewFieldValidationError([]errors.FieldViolation{{
+			Field:   typeErr.Field,
+			Rule:    "type",
+			Message: fmt.Sprintf("%s must be of type %s", typeErr.Field, typeErr.Type),
+		}})
+	}
+
+	return dto.ErrInvalidRequestFormat
+}
@@ -372,41 +372,7 @@ func validateMetadata(metadata map[string]string) error {
 
 // validateSortParameters validates sorting parameters for document listing
 func validateSortParameters(sortBy, sortOrder string) error {
-	// If sortBy is empty, return nil (default sorting will be used)
-	if sortBy == "" {
-		return nil
-	}
-
-	// Check if sortBy is in the list of ValidSortFields
-	validField := false
-	for _, field := range ValidSortFields {
-		if sortBy == field {
-			validField = true
-			break
-		}
-	}
-
-	if !validField {
-		return errors.NewValidationError(fmt.Sprintf("invalid sort field: %s", sortBy))
-	}
-
-	// If sortOrder is empty, return nil (default order will be used)
-	if sortOrder == "" {
-		return nil
-	}
-
-	// Check if sortOrder is in the list of ValidSortOrders
-	validOrder := false
-	for _, order := range ValidSortOrders {
-		if sortOrder == order {
-			validOrder = true
-			break
-		}
-	}
-
-	if !validOrder {
-		return errors.NewValidationError(fmt.Sprintf("invalid sort order: %s", sortOrder))
-	}
-
-	return nil
-}
\ No newline at end of file
+	// sortBy lists the sort fields, each with an optional direction; sortOrder is the direction of
+	// the fields without one. If sortBy is empty, the default sorting will be used.
+	return dto.ValidateSort(sortBy, sortOrder, ValidSortFields)
+}
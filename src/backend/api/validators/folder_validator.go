@@ -24,200 +24,143 @@ var ValidSortFields = []string{"name", "created_at", "updated_at"}
 // ValidSortOrders defines the allowed sort orders
 var ValidSortOrders = []string{"asc", "desc"}
 
-// ValidateCreateFolderRequest validates a folder creation request
+// ValidateCreateFolderRequest validates a folder creation request, reporting every violation
 func ValidateCreateFolderRequest(request *dto.FolderCreateRequest) error {
 	if request == nil {
 		return errors.NewValidationError("create folder request cannot be nil")
 	}
 
-	// Validate the request struct using the validator package
-	if err := validator.Validate(request); err != nil {
-		return err
-	}
+	// Validate the request struct using the validator package; its violations are reported with
+	// those of the checks below
+	var violations validator.Violations
+	violations.Merge(validator.Validate(request))
 
 	// Validate folder name
-	if err := validateFolderName(request.Name); err != nil {
-		return err
+	if request.Name != "" {
+		validateFolderName(&violations, request.Name)
 	}
 
 	// Validate parent folder ID if provided
 	if request.ParentID != "" {
 		if err := validator.ValidateUUID(request.ParentID); err != nil {
-			return errors.NewValidationError(fmt.Sprintf("invalid parent folder ID: %s", err.Error()))
+			violations.Add("parentId", "uuid", fmt.Sprintf("invalid parent folder ID: %s", err.Error()))
 		}
 	}
 
-	return nil
+	return violations.Err()
 }
 
-// ValidateUpdateFolderRequest validates a folder update request
+// ValidateUpdateFolderRequest validates a folder update request, reporting every violation
 func ValidateUpdateFolderRequest(request *dto.FolderUpdateRequest) error {
 	if request == nil {
 		return errors.NewValidationError("update folder request cannot be nil")
 	}
 
 	// Validate the request struct using the validator package
-	if err := validator.Validate(request); err != nil {
-		return err
-	}
+	var violations validator.Violations
+	violations.Merge(validator.Validate(request))
 
 	// Validate folder name
-	if err := validateFolderName(request.Name); err != nil {
-		return err
+	if request.Name != "" {
+		validateFolderName(&violations, request.Name)
 	}
 
-	return nil
+	return violations.Err()
 }
 
-// ValidateMoveFolderRequest validates a folder move request
+// ValidateMoveFolderRequest validates a folder move request, reporting every violation
 func ValidateMoveFolderRequest(request *dto.FolderMoveRequest) error {
 	if request == nil {
 		return errors.NewValidationError("move folder request cannot be nil")
 	}
 
 	// Validate the request struct using the validator package
-	if err := validator.Validate(request); err != nil {
-		return err
-	}
+	var violations validator.Violations
+	violations.Merge(validator.Validate(request))
 
 	// Validate new parent folder ID
-	if err := validator.ValidateUUID(request.NewParentID); err != nil {
-		return errors.NewValidationError(fmt.Sprintf("invalid new parent folder ID: %s", err.Error()))
+	if request.NewParentID != "" {
+		if err := validator.ValidateUUID(request.NewParentID); err != nil {
+			violations.Add("newParentId", "uuid", fmt.Sprintf("invalid new parent folder ID: %s", err.Error()))
+		}
 	}
 
-	return nil
+	return violations.Err()
 }
 
-// ValidateFolderListRequest validates a folder listing request
+// ValidateFolderListRequest validates a folder listing request, reporting every violation
 func ValidateFolderListRequest(request *dto.FolderListRequest) error {
 	if request == nil {
 		return errors.NewValidationError("folder list request cannot be nil")
 	}
 
 	// Validate the request struct using the validator package
-	if err := validator.Validate(request); err != nil {
-		return err
-	}
+	var violations validator.Violations
+	violations.Merge(validator.Validate(request))
 
 	// Validate parent folder ID if provided
 	if request.ParentID != "" {
 		if err := validator.ValidateUUID(request.ParentID); err != nil {
-			return errors.NewValidationError(fmt.Sprintf("invalid parent folder ID: %s", err.Error()))
+			violations.Add("parentId", "uuid", fmt.Sprintf("invalid parent folder ID: %s", err.Error()))
 		}
 	}
 
 	// Validate pagination parameters
-	if request.Page <= 0 {
-		return errors.NewValidationError("page number must be greater than 0")
-	}
-	
-	if request.PageSize <= 0 {
-		return errors.NewValidationError("page size must be greater than 0")
-	}
-	
-	if request.PageSize > 100 {
-		return errors.NewValidationError("page size cannot exceed 100")
-	}
+	validateFolderPagination(&violations, request.Page, request.PageSize)
 
 	// Validate sort parameters if provided
-	if err := validateSortParameters(request.SortBy, request.SortOrder); err != nil {
-		return err
-	}
+	violations.Check("sortBy", "sort", validateSortParameters(request.SortBy, request.SortOrder))
 
-	return nil
+	return violations.Err()
 }
 
-// ValidateFolderSearchRequest validates a folder search request
+// ValidateFolderSearchRequest validates a folder search request, reporting every violation
 func ValidateFolderSearchRequest(request *dto.FolderSearchRequest) error {
 	if request == nil {
 		return errors.NewValidationError("folder search request cannot be nil")
 	}
 
-	// Validate the request struct using the validator package
-	if err := validator.Validate(request); err != nil {
-		return err
-	}
+	// Validate the request struct using the validator package, which requires the query
+	var violations validator.Violations
+	violations.Merge(validator.Validate(request))
 
 	// Validate search query
-	if err := validator.ValidateRequired(request.Query, "query"); err != nil {
-		return err
-	}
-	
-	if err := validator.ValidateMaxLength(request.Query, 100, "query"); err != nil {
-		return err
-	}
+	violations.Check("query", "max", validator.ValidateMaxLength(request.Query, 100, "query"))
 
 	// Validate pagination parameters
-	if request.Page <= 0 {
-		return errors.NewValidationError("page number must be greater than 0")
-	}
-	
-	if request.PageSize <= 0 {
-		return errors.NewValidationError("page size must be greater than 0")
-	}
-	
-	if request.PageSize > 100 {
-		return errors.NewValidationError("page size cannot exceed 100")
-	}
+	validateFolderPagination(&violations, request.Page, request.PageSize)
 
-	return nil
+	return violations.Err()
 }
 
 // validateFolderName validates a folder name against naming rules
-func validateFolderName(name string) error {
-	// Check if name is empty
-	if err := validator.ValidateRequired(name, "name"); err != nil {
-		return err
-	}
-
+func validateFolderName(violations *validator.Violations, name string) {
 	// Check if name length is within allowed limits
-	if err := validator.ValidateMinLength(name, MinFolderNameLength, "name"); err != nil {
-		return err
-	}
-	
-	if err := validator.ValidateMaxLength(name, MaxFolderNameLength, "name"); err != nil {
-		return err
-	}
+	violations.Check("name", "min", validator.ValidateMinLength(name, MinFolderNameLength, "name"))
+	violations.Check("name", "max", validator.ValidateMaxLength(name, MaxFolderNameLength, "name"))
 
 	// Check for invalid characters in folder name
 	if strings.ContainsAny(name, "/\\:*?\"<>|") {
-		return errors.NewValidationError("folder name contains invalid characters")
+		violations.Add("name", "characters", "folder name contains invalid characters")
 	}
-
-	return nil
 }
 
-// validateSortParameters validates sorting parameters for folder listing
-func validateSortParameters(sortBy, sortOrder string) error {
-	// If sortBy is empty, return nil (default sorting will be used)
-	if sortBy == "" {
-		return nil
+// validateFolderPagination validates the page number and page size of folder listings and searches
+func validateFolderPagination(violations *validator.Violations, page, pageSize int) {
+	if page <= 0 {
+		violations.Add("page", "gt", "page number must be greater than 0")
 	}
 
-	// Convert string slice to interface slice for ValidateOneOf
-	sortByValues := make([]interface{}, len(ValidSortFields))
-	for i, v := range ValidSortFields {
-		sortByValues[i] = v
-	}
-	
-	if err := validator.ValidateOneOf(sortBy, sortByValues, "sort field"); err != nil {
-		return err
-	}
-
-	// If sortOrder is empty, return nil (default order will be used)
-	if sortOrder == "" {
-		return nil
-	}
-
-	// Convert string slice to interface slice for ValidateOneOf
-	sortOrderValues := make([]interface{}, len(ValidSortOrders))
-	for i, v := range ValidSortOrders {
-		sortOrderValues[i] = v
-	}
-	
-	if err := validator.ValidateOneOf(sortOrder, sortOrderValues, "sort order"); err != nil {
-		return err
+	if pageSize <= 0 {
+		violations.Add("pageSize", "gt", "page size must be greater than 0")
+	} else if pageSize > 100 {
+		violations.Add("pageSize", "lte", "page size cannot exceed 100")
 	}
+}
 
-	return nil
-}
\ No newline at end of file
+// validateSortParameters validates sorting parameters for folder listing
+func validateSortParameters(sortBy, sortOrder string) error {
+	// sortBy lists the sort fields, each with an optional direction; sortOrder is the direction of
+	// the fields without one. If sortBy is empty, the default sorting will be used.
+	return dto.ValidateSort(sortBy, sortOrder, ValidSortFields)
+}
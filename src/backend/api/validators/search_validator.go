@@ -4,10 +4,10 @@
 package validators
 
 import (
-	"fmt"    // standard library
-	"strings" // standard library
+	"fmt" // standard library
 
 	"../dto"
+	"../../domain/models"
 	"../../pkg/errors"
 	"../../pkg/validator"
 )
@@ -73,8 +73,15 @@ func ValidateMetadataSearchRequest(request *dto.MetadataSearchRequest) error {
 		return err
 	}
 
-	// Validate metadata
-	if err := validateMetadata(request.Metadata); err != nil {
+	// Validate metadata, which may be omitted when filters are provided
+	if len(request.Metadata) > 0 || len(request.Filters) == 0 {
+		if err := validateMetadata(request.Metadata); err != nil {
+			return err
+		}
+	}
+
+	// Validate metadata filters
+	if err := validateMetadataFilters(request.Filters); err != nil {
 		return err
 	}
 
@@ -103,9 +110,9 @@ func ValidateCombinedSearchRequest(request *dto.CombinedSearchRequest) error {
 		return err
 	}
 
-	// Validate that at least one of query or metadata is provided
-	if request.Query == "" && (request.Metadata == nil || len(request.Metadata) == 0) {
-		return errors.NewValidationError("either query or metadata must be provided")
+	// Validate that at least one of query, metadata or filters is provided
+	if request.Query == "" && len(request.Metadata) == 0 && len(request.Filters) == 0 {
+		return errors.NewValidationError("either query, metadata or filters must be provided")
 	}
 
 	// If metadata is provided, validate it
@@ -115,6 +122,11 @@ func ValidateCombinedSearchRequest(request *dto.CombinedSearchRequest) error {
 		}
 	}
 
+	// Validate metadata filters
+	if err := validateMetadataFilters(request.Filters); err != nil {
+		return err
+	}
+
 	// Validate pagination parameters
 	if err := validatePagination(request.Page, request.PageSize); err != nil {
 		return err
@@ -181,41 +193,9 @@ func validatePagination(page, pageSize int) error {
 
 // validateSortParameters validates sorting parameters
 func validateSortParameters(sortBy, sortOrder string) error {
-	// If sortBy is empty, return nil (default sorting will be used)
-	if sortBy == "" {
-		return nil
-	}
-
-	// Validate sortBy is one of the valid options
-	validSort := false
-	for _, validField := range ValidSortFields {
-		if sortBy == validField {
-			validSort = true
-			break
-		}
-	}
-	if !validSort {
-		return errors.NewValidationError(fmt.Sprintf("sort_by must be one of: %s", strings.Join(ValidSortFields, ", ")))
-	}
-
-	// If sortOrder is empty, return nil (default order will be used)
-	if sortOrder == "" {
-		return nil
-	}
-
-	// Validate sortOrder is one of the valid options
-	validOrder := false
-	for _, validOrderOption := range ValidSortOrders {
-		if sortOrder == validOrderOption {
-			validOrder = true
-			break
-		}
-	}
-	if !validOrder {
-		return errors.NewValidationError(fmt.Sprintf("sort_order must be one of: %s", strings.Join(ValidSortOrders, ", ")))
-	}
-
-	return nil
+	// sortBy lists the sort fields, each with an optional direction; sortOrder is the direction of
+	// the fields without one. If sortBy is empty, the default sorting will be used.
+	return dto.ValidateSort(sortBy, sortOrder, ValidSortFields)
 }
 
 // validateMetadata validates search metadata
@@ -246,5 +226,29 @@ func validateMetadata(metadata map[string]string) error {
 		}
 	}
 
+	return nil
+}
+
+// validateMetadataFilters validates the metadata filters of a search request. Whether a filter
+// fits the type of its key's field is checked against the tenant's schema by the search service.
+func validateMetadataFilters(filters []models.MetadataFilter) error {
+	if len(filters) > models.MaxMetadataFilters {
+		return errors.NewValidationError(models.ErrMetadataFilterTooMany.Error())
+	}
+
+	for _, filter := range filters {
+		if err := filter.Validate(); err != nil {
+			return errors.NewValidationError(err.Error())
+		}
+		if len(filter.Key) > MaxMetadataKeyLength {
+			return errors.NewValidationError(fmt.Sprintf("metadata key length cannot exceed %d characters", MaxMetadataKeyLength))
+		}
+		for _, value := range filter.Bounds() {
+			if len(value) > MaxMetadataValueLength {
+				return errors.NewValidationError(fmt.Sprintf("metadata value length cannot exceed %d characters", MaxMetadataValueLength))
+			}
+		}
+	}
+
 	return nil
 }
\ No newline at end of file
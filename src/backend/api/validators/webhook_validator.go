@@ -20,50 +20,43 @@ const (
 	MaxWebhookDescriptionLength = 1024
 	MaxEventTypesCount          = 20
 	MaxSecretKeyLength          = 256
+	MaxSubscriptionFolderIDs    = 100
+	MaxSubscriptionMetadataKeys = 20
 )
 
 // ValidWebhookStatuses contains all valid webhook statuses
 var ValidWebhookStatuses = []string{models.WebhookStatusActive, models.WebhookStatusInactive}
 
-// ValidateCreateWebhookRequest validates a webhook creation request
+// ValidateCreateWebhookRequest validates a webhook creation request, reporting every invalid field
 func ValidateCreateWebhookRequest(request *dto.CreateWebhookRequest) error {
 	if request == nil {
 		return errors.NewValidationError("webhook creation request cannot be nil")
 	}
 
-	// Validate the request struct
-	if err := validator.Validate(request); err != nil {
-		return err
-	}
+	// Validate the request struct; its violations are reported with those of the checks below
+	var violations validator.Violations
+	violations.Merge(validator.Validate(request))
 
 	// Validate URL
-	if err := validateWebhookURL(request.URL); err != nil {
-		return err
-	}
+	violations.Check("url", "url", validateWebhookURL(request.URL))
 
 	// Validate event types
-	if err := validateEventTypes(request.EventTypes); err != nil {
-		return err
-	}
+	violations.Check("event_types", "oneof", validateEventTypes(request.EventTypes))
 
 	// Validate description length if provided
 	if request.Description != "" {
-		if err := validator.ValidateMaxLength(request.Description, MaxWebhookDescriptionLength, "description"); err != nil {
-			return err
-		}
+		violations.Check("description", "max", validator.ValidateMaxLength(request.Description, MaxWebhookDescriptionLength, "description"))
 	}
 
 	// Validate secret key length if provided
 	if request.SecretKey != "" {
-		if err := validator.ValidateMaxLength(request.SecretKey, MaxSecretKeyLength, "secret_key"); err != nil {
-			return err
-		}
+		violations.Check("secret_key", "max", validator.ValidateMaxLength(request.SecretKey, MaxSecretKeyLength, "secret_key"))
 	}
 
-	return nil
+	return violations.Err()
 }
 
-// ValidateUpdateWebhookRequest validates a webhook update request
+// ValidateUpdateWebhookRequest validates a webhook update request, reporting every invalid field
 func ValidateUpdateWebhookRequest(request *dto.UpdateWebhookRequest) error {
 	if request == nil {
 		return errors.NewValidationError("webhook update request cannot be nil")
@@ -78,25 +71,21 @@ func ValidateUpdateWebhookRequest(request *dto.UpdateWebhookRequest) error {
 		return errors.NewValidationError("at least one field must be provided for update")
 	}
 
+	var violations validator.Violations
+
 	// Validate URL if provided
 	if request.URL != "" {
-		if err := validateWebhookURL(request.URL); err != nil {
-			return err
-		}
+		violations.Check("url", "url", validateWebhookURL(request.URL))
 	}
 
 	// Validate event types if provided
 	if request.EventTypes != nil && len(request.EventTypes) > 0 {
-		if err := validateEventTypes(request.EventTypes); err != nil {
-			return err
-		}
+		violations.Check("event_types", "oneof", validateEventTypes(request.EventTypes))
 	}
 
 	// Validate description if provided
 	if request.Description != nil {
-		if err := validator.ValidateMaxLength(*request.Description, MaxWebhookDescriptionLength, "description"); err != nil {
-			return err
-		}
+		violations.Check("description", "max", validator.ValidateMaxLength(*request.Description, MaxWebhookDescriptionLength, "description"))
 	}
 
 	// Validate status if provided
@@ -109,18 +98,95 @@ func ValidateUpdateWebhookRequest(request *dto.UpdateWebhookRequest) error {
 			}
 		}
 		if !found {
-			return errors.NewValidationError(fmt.Sprintf("status '%s' is not valid, must be one of: %s", 
+			violations.Add("status", "oneof", fmt.Sprintf("status '%s' is not valid, must be one of: %s", 
 				request.Status, strings.Join(ValidWebhookStatuses, ", ")))
 		}
 	}
 
 	// Validate secret key if provided
 	if request.SecretKey != "" {
-		if err := validator.ValidateMaxLength(request.SecretKey, MaxSecretKeyLength, "secret_key"); err != nil {
+		violations.Check("secret_key", "max", validator.ValidateMaxLength(request.SecretKey, MaxSecretKeyLength, "secret_key"))
+	}
+
+	return violations.Err()
+}
+
+// ValidateCreateWebhookSubscriptionRequest validates a webhook subscription creation request
+func ValidateCreateWebhookSubscriptionRequest(request *dto.CreateWebhookSubscriptionRequest) error {
+	if request == nil {
+		return errors.NewValidationError("webhook subscription request cannot be nil")
+	}
+
+	// A subscription without any filter would match every event and is redundant
+	if len(request.EventTypes) == 0 && len(request.FolderIDs) == 0 && len(request.MetadataFilters) == 0 {
+		return errors.NewValidationError("at least one of event_types, folder_ids or metadata_filters must be provided")
+	}
+
+	return validateSubscriptionFilters(request.EventTypes, request.FolderIDs, request.MetadataFilters)
+}
+
+// ValidateUpdateWebhookSubscriptionRequest validates a webhook subscription update request
+func ValidateUpdateWebhookSubscriptionRequest(request *dto.UpdateWebhookSubscriptionRequest) error {
+	if request == nil {
+		return errors.NewValidationError("webhook subscription request cannot be nil")
+	}
+
+	if request.EventTypes == nil && request.FolderIDs == nil && request.MetadataFilters == nil {
+		return errors.NewValidationError("at least one field must be provided for update")
+	}
+
+	var eventTypes, folderIDs []string
+	var metadataFilters map[string]string
+	if request.EventTypes != nil {
+		eventTypes = *request.EventTypes
+	}
+	if request.FolderIDs != nil {
+		folderIDs = *request.FolderIDs
+	}
+	if request.MetadataFilters != nil {
+		metadataFilters = *request.MetadataFilters
+	}
+
+	return validateSubscriptionFilters(eventTypes, folderIDs, metadataFilters)
+}
+
+// ValidateTestWebhookDeliveryRequest validates a webhook test delivery request
+func ValidateTestWebhookDeliveryRequest(request *dto.TestWebhookDeliveryRequest) error {
+	if request == nil {
+		return errors.NewValidationError("webhook test delivery request cannot be nil")
+	}
+
+	return validateEventTypes([]string{request.EventType})
+}
+
+// validateSubscriptionFilters validates the filters of a webhook subscription
+func validateSubscriptionFilters(eventTypes []string, folderIDs []string, metadataFilters map[string]string) error {
+	if len(eventTypes) > 0 {
+		if err := validateEventTypes(eventTypes); err != nil {
 			return err
 		}
 	}
 
+	if len(folderIDs) > MaxSubscriptionFolderIDs {
+		return errors.NewValidationError(fmt.Sprintf("maximum of %d folder IDs allowed", MaxSubscriptionFolderIDs))
+	}
+
+	for _, folderID := range folderIDs {
+		if strings.TrimSpace(folderID) == "" {
+			return errors.NewValidationError("folder_ids cannot contain empty values")
+		}
+	}
+
+	if len(metadataFilters) > MaxSubscriptionMetadataKeys {
+		return errors.NewValidationError(fmt.Sprintf("maximum of %d metadata filters allowed", MaxSubscriptionMetadataKeys))
+	}
+
+	for key := range metadataFilters {
+		if strings.TrimSpace(key) == "" {
+			return errors.NewValidationError("metadata_filters cannot contain empty keys")
+		}
+	}
+
 	return nil
 }
 
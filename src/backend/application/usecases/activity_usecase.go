@@ -0,0 +1,115 @@
+// Package usecases implements the application layer of the Document Management Platform.
+// It contains use case implementations that orchestrate domain models and services.
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"../../domain/models"
+	"../../domain/repositories"
+	"../../pkg/errors"
+	"../../pkg/logger"
+	"../../pkg/utils"
+)
+
+// ActivityUseCase defines the contract for the activity timelines of documents and folders, such as
+// uploads, renames, moves and shares, read from the audit log for display next to the resource
+type ActivityUseCase interface {
+	// ListDocumentActivity lists the activity of a document the user can read, newest first, with pagination
+	ListDocumentActivity(ctx context.Context, id, tenantID, userID string, page int, pageSize int) (utils.PaginatedResult[models.Activity], error)
+
+	// ListFolderActivity lists the activity of a folder the user can read and of the documents in it,
+	// newest first, with pagination
+	ListFolderActivity(ctx context.Context, id, tenantID, userID string, page int, pageSize int) (utils.PaginatedResult[models.Activity], error)
+}
+
+// activityUseCase implements the ActivityUseCase interface
+type activityUseCase struct {
+	auditLogRepo repositories.AuditLogRepository
+	documents    DocumentReader
+	folders      FolderReader
+}
+
+// NewActivityUseCase creates a new ActivityUseCase instance
+func NewActivityUseCase(auditLogRepo repositories.AuditLogRepository, documents DocumentReader, folders FolderReader) (ActivityUseCase, error) {
+	if auditLogRepo == nil {
+		return nil, fmt.Errorf("audit log repository cannot be nil")
+	}
+	if documents == nil {
+		return nil, fmt.Errorf("document reader cannot be nil")
+	}
+	if folders == nil {
+		return nil, fmt.Errorf("folder reader cannot be nil")
+	}
+
+	return &activityUseCase{
+		auditLogRepo: auditLogRepo,
+		documents:    documents,
+		folders:      folders,
+	}, nil
+}
+
+// ListDocumentActivity lists the activity of a document the user can read
+func (u *activityUseCase) ListDocumentActivity(ctx context.Context, id, tenantID, userID string, page int, pageSize int) (utils.PaginatedResult[models.Activity], error) {
+	if err := u.validateInput(map[string]string{
+		"document ID": id,
+		"tenant ID":   tenantID,
+		"user ID":     userID,
+	}); err != nil {
+		return utils.PaginatedResult[models.Activity]{}, err
+	}
+
+	// The document reader checks the user can read the document
+	if _, err := u.documents.GetDocument(ctx, id, tenantID, userID); err != nil {
+		return utils.PaginatedResult[models.Activity]{}, err
+	}
+
+	return u.listActivity(ctx, models.ResourceTypeDocument, id, tenantID, page, pageSize)
+}
+
+// ListFolderActivity lists the activity of a folder the user can read and of the documents in it
+func (u *activityUseCase) ListFolderActivity(ctx context.Context, id, tenantID, userID string, page int, pageSize int) (utils.PaginatedResult[models.Activity], error) {
+	if err := u.validateInput(map[string]string{
+		"folder ID": id,
+		"tenant ID": tenantID,
+		"user ID":   userID,
+	}); err != nil {
+		return utils.PaginatedResult[models.Activity]{}, err
+	}
+
+	// The folder reader checks the user can read the folder
+	if _, err := u.folders.GetFolder(ctx, id, tenantID, userID); err != nil {
+		return utils.PaginatedResult[models.Activity]{}, err
+	}
+
+	return u.listActivity(ctx, models.ResourceTypeFolder, id, tenantID, page, pageSize)
+}
+
+// listActivity reads the activity of a document or folder from the audit log
+func (u *activityUseCase) listActivity(ctx context.Context, resourceType, id, tenantID string, page int, pageSize int) (utils.PaginatedResult[models.Activity], error) {
+	pagination := utils.NewPagination(page, pageSize)
+
+	entries, err := u.auditLogRepo.ListActivity(ctx, tenantID, resourceType, id, pagination)
+	if err != nil {
+		logger.WithContext(ctx).WithError(err).Error("failed to list activity", "resourceType", resourceType, "resourceID", id, "tenantID", tenantID)
+		return utils.PaginatedResult[models.Activity]{}, errors.Wrap(err, "failed to list activity")
+	}
+
+	activities := make([]models.Activity, len(entries.Items))
+	for i, entry := range entries.Items {
+		activities[i] = models.NewActivity(entry)
+	}
+
+	return utils.PaginatedResult[models.Activity]{Items: activities, Pagination: entries.Pagination}, nil
+}
+
+// validateInput validates that required input parameters are not empty
+func (u *activityUseCase) validateInput(params map[string]string) error {
+	for name, value := range params {
+		if value == "" {
+			return errors.NewValidationError(fmt.Sprintf("%s cannot be empty", name))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert" // v1.8.0+
+	"github.com/stretchr/testify/mock"   // v1.8.0+
+	"github.com/stretchr/testify/suite"  // v1.8.0+
+
+	"../../domain/models"
+	pkgErrors "../../pkg/errors"
+	"../../pkg/utils"
+)
+
+// ActivityUseCaseTestSuite defines a test suite for ActivityUseCase
+type ActivityUseCaseTestSuite struct {
+	suite.Suite
+	mockAuditLogRepo *MockAuditLogRepository
+	mockDocuments    *MockDocumentReader
+	mockFolders      *MockFolderReader
+	activityUseCase  ActivityUseCase
+}
+
+// SetupTest sets up the test environment before each test
+func (s *ActivityUseCaseTestSuite) SetupTest() {
+	s.mockAuditLogRepo = new(MockAuditLogRepository)
+	s.mockDocuments = new(MockDocumentReader)
+	s.mockFolders = new(MockFolderReader)
+
+	var err error
+	s.activityUseCase, err = NewActivityUseCase(s.mockAuditLogRepo, s.mockDocuments, s.mockFolders)
+	assert.Nil(s.T(), err)
+	assert.NotNil(s.T(), s.activityUseCase)
+}
+
+// TestListDocumentActivity_Timeline tests that audit log entries are described as a timeline
+func (s *ActivityUseCaseTestSuite) TestListDocumentActivity_Timeline() {
+	occurredAt := time.Now().UTC()
+	entries := []models.AuditLog{
+		{
+			ID: "log3", ActorID: "user2", Action: models.AuditActionGrant, ResourceType: models.AuditResourcePermission, ResourceID: "perm1",
+			After:      json.RawMessage(`{"resourceType":"document","resourceID":"doc1","granteeType":"group","granteeID":"legal","permissionType":"read"}`),
+			OccurredAt: occurredAt,
+		},
+		{
+			ID: "log2", ActorID: "user1", Action: models.AuditActionUpdate, ResourceType: models.ResourceTypeDocument, ResourceID: "doc1",
+			Before:     json.RawMessage(`{"name":"Q1.pdf"}`),
+			After:      json.RawMessage(`{"name":"Q1 report.pdf"}`),
+			OccurredAt: occurredAt.Add(-time.Hour),
+		},
+		{
+			ID: "log1", ActorID: "user1", Action: models.AuditActionUpload, ResourceType: models.ResourceTypeDocument, ResourceID: "doc1",
+			After:      json.RawMessage(`{"name":"Q1.pdf","folderID":"folder1","size":1024}`),
+			OccurredAt: occurredAt.Add(-2 * time.Hour),
+		},
+	}
+	s.mockDocuments.On("GetDocument", mock.Anything, "doc1", "tenant123", "user123").Return(&models.Document{ID: "doc1"}, nil)
+	s.mockAuditLogRepo.On("ListActivity", mock.Anything, "tenant123", models.ResourceTypeDocument, "doc1", mock.Anything).
+		Return(utils.NewPaginatedResult(entries, utils.NewPagination(1, 20), 3), nil)
+
+	result, err := s.activityUseCase.ListDocumentActivity(context.Background(), "doc1", "tenant123", "user123", 1, 20)
+
+	assert.Nil(s.T(), err)
+	assert.Len(s.T(), result.Items, 3)
+	assert.Equal(s.T(), models.ActivityKindShared, result.Items[0].Kind)
+	assert.Equal(s.T(), "doc1", result.Items[0].ResourceID)
+	assert.Equal(s.T(), "shared with group legal (read)", result.Items[0].Description)
+	assert.Equal(s.T(), models.ActivityKindRenamed, result.Items[1].Kind)
+	assert.Equal(s.T(), "renamed Q1.pdf to Q1 report.pdf", result.Items[1].Description)
+	assert.Equal(s.T(), models.ActivityKindUploaded, result.Items[2].Kind)
+	assert.Equal(s.T(), "uploaded Q1.pdf", result.Items[2].Description)
+	assert.Equal(s.T(), int64(3), result.Pagination.TotalItems)
+	s.mockAuditLogRepo.AssertExpectations(s.T())
+}
+
+// TestListDocumentActivity_AccessDenied tests that the activity of a document the user cannot read is not listed
+func (s *ActivityUseCaseTestSuite) TestListDocumentActivity_AccessDenied() {
+	s.mockDocuments.On("GetDocument", mock.Anything, "doc1", "tenant123", "user123").Return(nil, pkgErrors.NewAuthorizationError("permission denied"))
+
+	_, err := s.activityUseCase.ListDocumentActivity(context.Background(), "doc1", "tenant123", "user123", 1, 20)
+
+	assert.True(s.T(), pkgErrors.IsAuthorizationError(err))
+	s.mockAuditLogRepo.AssertNotCalled(s.T(), "ListActivity", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestListFolderActivity_ShareLinkAndMove tests the activity of a folder with a share link of one of its documents
+func (s *ActivityUseCaseTestSuite) TestListFolderActivity_ShareLinkAndMove() {
+	entries := []models.AuditLog{
+		{
+			ID: "log2", ActorID: "user1", Action: models.AuditActionCreate, ResourceType: models.AuditResourceShareLink, ResourceID: "link1",
+			After: json.RawMessage(`{"document_id":"doc1","expires_at":null,"max_downloads":0,"password_protected":false}`),
+		},
+		{
+			ID: "log1", ActorID: "user1", Action: models.AuditActionMove, ResourceType: models.ResourceTypeFolder, ResourceID: "folder1",
+			Before: json.RawMessage(`{"parentID":"root","path":"/Marketing"}`),
+			After:  json.RawMessage(`{"parentID":"archive"}`),
+		},
+	}
+	s.mockFolders.On("GetFolder", mock.Anything, "folder1", "tenant123", "user123").Return(&models.Folder{ID: "folder1"}, nil)
+	s.mockAuditLogRepo.On("ListActivity", mock.Anything, "tenant123", models.ResourceTypeFolder, "folder1", mock.Anything).
+		Return(utils.NewPaginatedResult(entries, utils.NewPagination(1, 20), 2), nil)
+
+	result, err := s.activityUseCase.ListFolderActivity(context.Background(), "folder1", "tenant123", "user123", 1, 20)
+
+	assert.Nil(s.T(), err)
+	assert.Len(s.T(), result.Items, 2)
+	assert.Equal(s.T(), models.ActivityKindShared, result.Items[0].Kind)
+	assert.Equal(s.T(), models.ResourceTypeDocument, result.Items[0].ResourceType)
+	assert.Equal(s.T(), "doc1", result.Items[0].ResourceID)
+	assert.Equal(s.T(), "created a share link", result.Items[0].Description)
+	assert.Equal(s.T(), models.ActivityKindMoved, result.Items[1].Kind)
+	assert.Equal(s.T(), "moved the folder from /Marketing", result.Items[1].Description)
+}
+
+// TestListFolderActivity_EmptyFolderID tests that listing the activity requires a folder ID
+func (s *ActivityUseCaseTestSuite) TestListFolderActivity_EmptyFolderID() {
+	_, err := s.activityUseCase.ListFolderActivity(context.Background(), "", "tenant123", "user123", 1, 20)
+
+	assert.True(s.T(), pkgErrors.IsValidationError(err))
+	s.mockFolders.AssertNotCalled(s.T(), "GetFolder", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestActivityUseCaseSuite runs the ActivityUseCase test suite
+func TestActivityUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(ActivityUseCaseTestSuite))
+}
@@ -0,0 +1,296 @@
+// Package usecases implements the application layer of the Document Management Platform.
+// It contains use case implementations that orchestrate domain models and services.
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"../../domain/models"
+	"../../domain/repositories"
+	"../../domain/services"
+	"../../pkg/errors"
+	"../../pkg/logger"
+	"../../pkg/utils"
+)
+
+// apiKeyUsageInterval is how often the last used time of an API key is written, so that every
+// authenticated request does not cause a database write
+const apiKeyUsageInterval = 5 * time.Minute
+
+// ErrInvalidAPIKey is returned for unknown, expired and revoked API keys, and keys of tenants that are not active, alike
+var ErrInvalidAPIKey = errors.NewAuthenticationError("invalid API key")
+
+// APIKeyUseCase defines the contract for managing and authenticating API keys
+type APIKeyUseCase interface {
+	// CreateAPIKey creates an API key that acts in the tenant with the given role. It returns the key
+	// and its secret, which is only available at creation time. A zero expiresIn creates a key that
+	// does not expire.
+	CreateAPIKey(ctx context.Context, tenantID, userID, name, role string, expiresIn time.Duration) (*models.APIKey, string, error)
+
+	// GetAPIKey retrieves an API key
+	GetAPIKey(ctx context.Context, id, tenantID string) (*models.APIKey, error)
+
+	// ListAPIKeys lists a tenant's API keys with pagination
+	ListAPIKeys(ctx context.Context, tenantID string, page, pageSize int) (utils.PaginatedResult[models.APIKey], error)
+
+	// RotateAPIKey replaces an API key's secret and returns the key with its new secret. The old secret stops working immediately.
+	RotateAPIKey(ctx context.Context, id, tenantID, userID string) (*models.APIKey, string, error)
+
+	// RevokeAPIKey revokes an API key so it can no longer be used
+	RevokeAPIKey(ctx context.Context, id, tenantID, userID string) error
+
+	// AuthenticateAPIKey resolves an API key secret to the key it belongs to
+	AuthenticateAPIKey(ctx context.Context, secret string) (*models.APIKey, error)
+}
+
+// apiKeyUseCase implements the APIKeyUseCase interface
+type apiKeyUseCase struct {
+	apiKeyRepo   repositories.APIKeyRepository
+	roleRepo     repositories.RoleRepository
+	tenantRepo   repositories.TenantRepository
+	auditService services.AuditService
+}
+
+// NewAPIKeyUseCase creates a new APIKeyUseCase instance
+func NewAPIKeyUseCase(
+	apiKeyRepo repositories.APIKeyRepository,
+	roleRepo repositories.RoleRepository,
+	tenantRepo repositories.TenantRepository,
+	auditService services.AuditService,
+) (APIKeyUseCase, error) {
+	if apiKeyRepo == nil {
+		return nil, fmt.Errorf("API key repository cannot be nil")
+	}
+	if roleRepo == nil {
+		return nil, fmt.Errorf("role repository cannot be nil")
+	}
+	if tenantRepo == nil {
+		return nil, fmt.Errorf("tenant repository cannot be nil")
+	}
+	if auditService == nil {
+		return nil, fmt.Errorf("audit service cannot be nil")
+	}
+
+	return &apiKeyUseCase{
+		apiKeyRepo:   apiKeyRepo,
+		roleRepo:     roleRepo,
+		tenantRepo:   tenantRepo,
+		auditService: auditService,
+	}, nil
+}
+
+// CreateAPIKey creates an API key that acts in the tenant with the given role
+func (u *apiKeyUseCase) CreateAPIKey(ctx context.Context, tenantID, userID, name, role string, expiresIn time.Duration) (*models.APIKey, string, error) {
+	log := logger.WithContext(ctx)
+
+	if err := u.validateInput(map[string]string{
+		"tenant ID": tenantID,
+		"user ID":   userID,
+		"name":      strings.TrimSpace(name),
+		"role":      role,
+	}); err != nil {
+		return nil, "", err
+	}
+	if expiresIn < 0 {
+		return nil, "", errors.NewValidationError(models.ErrAPIKeyExpiryInvalid.Error())
+	}
+
+	// Keys can only act with a role that exists in the tenant
+	if role == models.RoleSystem {
+		return nil, "", errors.NewValidationError(models.ErrAPIKeyRoleInvalid.Error())
+	}
+	if _, err := u.roleRepo.GetByName(ctx, role, tenantID); err != nil {
+		if errors.IsResourceNotFoundError(err) {
+			return nil, "", errors.NewValidationError("role not found: " + role)
+		}
+		log.WithError(err).Error("failed to get role", "role", role)
+		return nil, "", errors.Wrap(err, "failed to get role")
+	}
+
+	var expiresAt *time.Time
+	if expiresIn > 0 {
+		t := time.Now().Add(expiresIn)
+		expiresAt = &t
+	}
+
+	key, secret, err := models.NewAPIKey(tenantID, name, role, userID, expiresAt)
+	if err != nil {
+		log.WithError(err).Error("failed to generate API key")
+		return nil, "", errors.Wrap(err, "failed to generate API key")
+	}
+
+	if _, err := u.apiKeyRepo.Create(ctx, key); err != nil {
+		log.WithError(err).Error("failed to create API key", "tenantID", tenantID)
+		return nil, "", err
+	}
+
+	err = u.auditService.RecordAction(ctx, tenantID, userID, models.AuditActionCreate, models.AuditResourceAPIKey, key.ID, nil, map[string]interface{}{
+		"name":       key.Name,
+		"role":       key.Role,
+		"key_prefix": key.KeyPrefix,
+		"expires_at": key.ExpiresAt,
+	})
+	if err != nil {
+		log.WithError(err).Error("failed to record API key creation in audit log")
+		// Do not return error, the key has already been created
+	}
+
+	log.Info("API key created", "apiKeyID", key.ID, "tenantID", tenantID, "role", role)
+	return key, secret, nil
+}
+
+// GetAPIKey retrieves an API key
+func (u *apiKeyUseCase) GetAPIKey(ctx context.Context, id, tenantID string) (*models.APIKey, error) {
+	if err := u.validateInput(map[string]string{
+		"API key ID": id,
+		"tenant ID":  tenantID,
+	}); err != nil {
+		return nil, err
+	}
+
+	return u.apiKeyRepo.GetByID(ctx, id, tenantID)
+}
+
+// ListAPIKeys lists a tenant's API keys with pagination
+func (u *apiKeyUseCase) ListAPIKeys(ctx context.Context, tenantID string, page, pageSize int) (utils.PaginatedResult[models.APIKey], error) {
+	if err := u.validateInput(map[string]string{
+		"tenant ID": tenantID,
+	}); err != nil {
+		return utils.PaginatedResult[models.APIKey]{}, err
+	}
+
+	pagination := utils.NewPagination(page, pageSize)
+	return u.apiKeyRepo.List(ctx, tenantID, pagination)
+}
+
+// RotateAPIKey replaces an API key's secret and returns the key with its new secret
+func (u *apiKeyUseCase) RotateAPIKey(ctx context.Context, id, tenantID, userID string) (*models.APIKey, string, error) {
+	log := logger.WithContext(ctx)
+
+	if err := u.validateInput(map[string]string{
+		"API key ID": id,
+		"tenant ID":  tenantID,
+		"user ID":    userID,
+	}); err != nil {
+		return nil, "", err
+	}
+
+	key, err := u.apiKeyRepo.GetByID(ctx, id, tenantID)
+	if err != nil {
+		return nil, "", err
+	}
+	if !key.IsUsable(time.Now()) {
+		return nil, "", errors.NewValidationError("revoked or expired API keys cannot be rotated")
+	}
+
+	secret, err := key.Rotate()
+	if err != nil {
+		log.WithError(err).Error("failed to generate API key", "apiKeyID", id)
+		return nil, "", errors.Wrap(err, "failed to generate API key")
+	}
+
+	if err := u.apiKeyRepo.UpdateSecret(ctx, key); err != nil {
+		log.WithError(err).Error("failed to rotate API key", "apiKeyID", id)
+		return nil, "", err
+	}
+
+	err = u.auditService.RecordAction(ctx, tenantID, userID, models.AuditActionUpdate, models.AuditResourceAPIKey, key.ID, nil, map[string]interface{}{
+		"rotated":    true,
+		"key_prefix": key.KeyPrefix,
+	})
+	if err != nil {
+		log.WithError(err).Error("failed to record API key rotation in audit log")
+		// Do not return error, the key has already been rotated
+	}
+
+	log.Info("API key rotated", "apiKeyID", key.ID, "tenantID", tenantID)
+	return key, secret, nil
+}
+
+// RevokeAPIKey revokes an API key so it can no longer be used
+func (u *apiKeyUseCase) RevokeAPIKey(ctx context.Context, id, tenantID, userID string) error {
+	log := logger.WithContext(ctx)
+
+	if err := u.validateInput(map[string]string{
+		"API key ID": id,
+		"tenant ID":  tenantID,
+		"user ID":    userID,
+	}); err != nil {
+		return err
+	}
+
+	if err := u.apiKeyRepo.Revoke(ctx, id, tenantID); err != nil {
+		return err
+	}
+
+	err := u.auditService.RecordAction(ctx, tenantID, userID, models.AuditActionRevoke, models.AuditResourceAPIKey, id, nil, nil)
+	if err != nil {
+		log.WithError(err).Error("failed to record API key revocation in audit log")
+		// Do not return error, the key has already been revoked
+	}
+
+	log.Info("API key revoked", "apiKeyID", id, "tenantID", tenantID)
+	return nil
+}
+
+// AuthenticateAPIKey resolves an API key secret to the key it belongs to
+func (u *apiKeyUseCase) AuthenticateAPIKey(ctx context.Context, secret string) (*models.APIKey, error) {
+	log := logger.WithContext(ctx)
+
+	if !strings.HasPrefix(secret, models.APIKeyPrefix) {
+		return nil, ErrInvalidAPIKey
+	}
+
+	key, err := u.apiKeyRepo.GetByKeyHash(ctx, models.HashAPIKey(secret))
+	if err != nil {
+		if errors.IsResourceNotFoundError(err) {
+			return nil, ErrInvalidAPIKey
+		}
+		log.WithError(err).Error("failed to get API key")
+		return nil, errors.Wrap(err, "failed to get API key")
+	}
+
+	now := time.Now()
+	if !key.IsUsable(now) {
+		log.Info("API key is no longer usable", "apiKeyID", key.ID)
+		return nil, ErrInvalidAPIKey
+	}
+
+	// Keys of suspended tenants, such as tenants frozen for deletion, stop working with the tenant
+	tenant, err := u.tenantRepo.GetByID(ctx, key.TenantID)
+	if err != nil {
+		if errors.IsResourceNotFoundError(err) {
+			return nil, ErrInvalidAPIKey
+		}
+		log.WithError(err).Error("failed to get tenant of API key", "apiKeyID", key.ID)
+		return nil, errors.Wrap(err, "failed to get tenant")
+	}
+	if !tenant.IsActive() {
+		log.Info("API key tenant is not active", "apiKeyID", key.ID, "tenantID", key.TenantID, "status", tenant.Status)
+		return nil, ErrInvalidAPIKey
+	}
+
+	// Usage is informational, so a failure to record it does not fail authentication
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= apiKeyUsageInterval {
+		if err := u.apiKeyRepo.RecordUsage(ctx, key.ID, now); err != nil {
+			log.WithError(err).Error("failed to record API key usage", "apiKeyID", key.ID)
+		} else {
+			key.LastUsedAt = &now
+		}
+	}
+
+	return key, nil
+}
+
+// validateInput validates that required input parameters are not empty
+func (u *apiKeyUseCase) validateInput(params map[string]string) error {
+	for name, value := range params {
+		if value == "" {
+			return errors.NewValidationError(fmt.Sprintf("%s cannot be empty", name))
+		}
+	}
+	return nil
+}
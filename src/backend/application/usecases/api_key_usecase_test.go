@@ -0,0 +1,246 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert" // v1.8.0+
+	"github.com/stretchr/testify/mock"   // v1.8.0+
+	"github.com/stretchr/testify/suite"  // v1.8.0+
+
+	"../../domain/models"
+	"../../domain/repositories"
+	pkgErrors "../../pkg/errors"
+	"../../pkg/utils"
+)
+
+// MockAPIKeyRepository is a mock implementation of the APIKeyRepository interface for testing
+type MockAPIKeyRepository struct {
+	mock.Mock
+}
+
+func (m *MockAPIKeyRepository) Create(ctx context.Context, key *models.APIKey) (string, error) {
+	args := m.Called(ctx, key)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) GetByID(ctx context.Context, id string, tenantID string) (*models.APIKey, error) {
+	args := m.Called(ctx, id, tenantID)
+	if key := args.Get(0); key != nil {
+		return key.(*models.APIKey), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) GetByKeyHash(ctx context.Context, keyHash string) (*models.APIKey, error) {
+	args := m.Called(ctx, keyHash)
+	if key := args.Get(0); key != nil {
+		return key.(*models.APIKey), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) List(ctx context.Context, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.APIKey], error) {
+	args := m.Called(ctx, tenantID, pagination)
+	return args.Get(0).(utils.PaginatedResult[models.APIKey]), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) UpdateSecret(ctx context.Context, key *models.APIKey) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepository) Revoke(ctx context.Context, id string, tenantID string) error {
+	args := m.Called(ctx, id, tenantID)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepository) RecordUsage(ctx context.Context, id string, usedAt time.Time) error {
+	args := m.Called(ctx, id, usedAt)
+	return args.Error(0)
+}
+
+// mockAPIKeyRoleRepository mocks the RoleRepository methods used by API keys
+type mockAPIKeyRoleRepository struct {
+	repositories.RoleRepository
+	mock.Mock
+}
+
+func (m *mockAPIKeyRoleRepository) GetByName(ctx context.Context, name string, tenantID string) (*models.Role, error) {
+	args := m.Called(ctx, name, tenantID)
+	if role := args.Get(0); role != nil {
+		return role.(*models.Role), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// mockAPIKeyTenantRepository mocks the TenantRepository methods used by API keys
+type mockAPIKeyTenantRepository struct {
+	repositories.TenantRepository
+	mock.Mock
+}
+
+func (m *mockAPIKeyTenantRepository) GetByID(ctx context.Context, id string) (*models.Tenant, error) {
+	args := m.Called(ctx, id)
+	if tenant := args.Get(0); tenant != nil {
+		return tenant.(*models.Tenant), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// APIKeyUseCaseTestSuite defines a test suite for APIKeyUseCase
+type APIKeyUseCaseTestSuite struct {
+	suite.Suite
+	mockAPIKeyRepo   *MockAPIKeyRepository
+	mockRoleRepo     *mockAPIKeyRoleRepository
+	mockTenantRepo   *mockAPIKeyTenantRepository
+	mockAuditService *MockAuditService
+	apiKeyUseCase    APIKeyUseCase
+}
+
+// SetupTest sets up the test environment before each test
+func (s *APIKeyUseCaseTestSuite) SetupTest() {
+	s.mockAPIKeyRepo = new(MockAPIKeyRepository)
+	s.mockRoleRepo = new(mockAPIKeyRoleRepository)
+	s.mockTenantRepo = new(mockAPIKeyTenantRepository)
+	s.mockAuditService = new(MockAuditService)
+	s.mockAuditService.On("RecordAction", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	var err error
+	s.apiKeyUseCase, err = NewAPIKeyUseCase(s.mockAPIKeyRepo, s.mockRoleRepo, s.mockTenantRepo, s.mockAuditService)
+	assert.Nil(s.T(), err)
+}
+
+// createTestKey returns an active API key together with its secret
+func (s *APIKeyUseCaseTestSuite) createTestKey() (*models.APIKey, string) {
+	key, secret, err := models.NewAPIKey("tenant123", "CI pipeline", models.RoleContributor, "user123", nil)
+	s.Require().NoError(err)
+	key.ID = "key123"
+	return key, secret
+}
+
+// TestCreateAPIKey tests creating an API key stores only the hash of the returned secret
+func (s *APIKeyUseCaseTestSuite) TestCreateAPIKey() {
+	ctx := context.Background()
+
+	s.mockRoleRepo.On("GetByName", ctx, models.RoleContributor, "tenant123").Return(&models.Role{Name: models.RoleContributor}, nil)
+	s.mockAPIKeyRepo.On("Create", ctx, mock.AnythingOfType("*models.APIKey")).Return("key123", nil)
+
+	key, secret, err := s.apiKeyUseCase.CreateAPIKey(ctx, "tenant123", "user123", "CI pipeline", models.RoleContributor, 24*time.Hour)
+
+	s.NoError(err)
+	s.True(len(secret) > len(models.APIKeyPrefix))
+	s.Equal(models.HashAPIKey(secret), key.KeyHash)
+	s.Equal(secret[:models.APIKeyDisplayPrefixLength], key.KeyPrefix)
+	s.NotNil(key.ExpiresAt)
+	s.mockAuditService.AssertCalled(s.T(), "RecordAction", ctx, "tenant123", "user123", models.AuditActionCreate, models.AuditResourceAPIKey, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestCreateAPIKey_UnknownRole tests creating an API key for a role the tenant does not have
+func (s *APIKeyUseCaseTestSuite) TestCreateAPIKey_UnknownRole() {
+	ctx := context.Background()
+
+	s.mockRoleRepo.On("GetByName", ctx, "auditor", "tenant123").Return(nil, pkgErrors.NewResourceNotFoundError("role not found"))
+
+	key, secret, err := s.apiKeyUseCase.CreateAPIKey(ctx, "tenant123", "user123", "CI pipeline", "auditor", 0)
+
+	s.Nil(key)
+	s.Empty(secret)
+	s.True(pkgErrors.IsValidationError(err))
+	s.mockAPIKeyRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+}
+
+// TestCreateAPIKey_SystemRole tests that keys cannot act with the system role
+func (s *APIKeyUseCaseTestSuite) TestCreateAPIKey_SystemRole() {
+	key, _, err := s.apiKeyUseCase.CreateAPIKey(context.Background(), "tenant123", "user123", "CI pipeline", models.RoleSystem, 0)
+
+	s.Nil(key)
+	s.True(pkgErrors.IsValidationError(err))
+	s.mockRoleRepo.AssertNotCalled(s.T(), "GetByName", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestRotateAPIKey tests that rotation replaces the stored hash
+func (s *APIKeyUseCaseTestSuite) TestRotateAPIKey() {
+	ctx := context.Background()
+	key, oldSecret := s.createTestKey()
+
+	s.mockAPIKeyRepo.On("GetByID", ctx, "key123", "tenant123").Return(key, nil)
+	s.mockAPIKeyRepo.On("UpdateSecret", ctx, key).Return(nil)
+
+	rotated, secret, err := s.apiKeyUseCase.RotateAPIKey(ctx, "key123", "tenant123", "user123")
+
+	s.NoError(err)
+	s.NotEqual(oldSecret, secret)
+	s.Equal(models.HashAPIKey(secret), rotated.KeyHash)
+	s.NotNil(rotated.RotatedAt)
+}
+
+// TestRotateAPIKey_Revoked tests that revoked keys cannot be rotated back into use
+func (s *APIKeyUseCaseTestSuite) TestRotateAPIKey_Revoked() {
+	ctx := context.Background()
+	key, _ := s.createTestKey()
+	key.Revoke()
+
+	s.mockAPIKeyRepo.On("GetByID", ctx, "key123", "tenant123").Return(key, nil)
+
+	_, _, err := s.apiKeyUseCase.RotateAPIKey(ctx, "key123", "tenant123", "user123")
+
+	s.True(pkgErrors.IsValidationError(err))
+	s.mockAPIKeyRepo.AssertNotCalled(s.T(), "UpdateSecret", mock.Anything, mock.Anything)
+}
+
+// TestAuthenticateAPIKey tests authenticating with a valid key records its usage
+func (s *APIKeyUseCaseTestSuite) TestAuthenticateAPIKey() {
+	ctx := context.Background()
+	key, secret := s.createTestKey()
+
+	s.mockAPIKeyRepo.On("GetByKeyHash", ctx, models.HashAPIKey(secret)).Return(key, nil)
+	s.mockTenantRepo.On("GetByID", ctx, "tenant123").Return(&models.Tenant{ID: "tenant123", Status: models.TenantStatusActive}, nil)
+	s.mockAPIKeyRepo.On("RecordUsage", ctx, "key123", mock.AnythingOfType("time.Time")).Return(nil)
+
+	authenticated, err := s.apiKeyUseCase.AuthenticateAPIKey(ctx, secret)
+
+	s.NoError(err)
+	s.Equal("key123", authenticated.ID)
+	s.NotNil(authenticated.LastUsedAt)
+	s.mockAPIKeyRepo.AssertExpectations(s.T())
+}
+
+// TestAuthenticateAPIKey_Invalid tests that unknown, malformed and revoked keys are rejected alike
+func (s *APIKeyUseCaseTestSuite) TestAuthenticateAPIKey_Invalid() {
+	ctx := context.Background()
+	key, secret := s.createTestKey()
+	key.Revoke()
+
+	s.mockAPIKeyRepo.On("GetByKeyHash", ctx, models.HashAPIKey(secret)).Return(key, nil)
+	s.mockAPIKeyRepo.On("GetByKeyHash", ctx, models.HashAPIKey("dmk_unknown")).Return(nil, pkgErrors.NewResourceNotFoundError("API key not found"))
+
+	for _, candidate := range []string{secret, "dmk_unknown", "not-an-api-key"} {
+		authenticated, err := s.apiKeyUseCase.AuthenticateAPIKey(ctx, candidate)
+		s.Nil(authenticated)
+		s.Equal(ErrInvalidAPIKey, err)
+	}
+	s.mockAPIKeyRepo.AssertNotCalled(s.T(), "RecordUsage", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestAuthenticateAPIKey_SuspendedTenant tests that the keys of a suspended tenant, such as a tenant
+// frozen for deletion, are rejected like invalid keys
+func (s *APIKeyUseCaseTestSuite) TestAuthenticateAPIKey_SuspendedTenant() {
+	ctx := context.Background()
+	key, secret := s.createTestKey()
+
+	s.mockAPIKeyRepo.On("GetByKeyHash", ctx, models.HashAPIKey(secret)).Return(key, nil)
+	s.mockTenantRepo.On("GetByID", ctx, "tenant123").Return(&models.Tenant{ID: "tenant123", Status: models.TenantStatusSuspended}, nil)
+
+	authenticated, err := s.apiKeyUseCase.AuthenticateAPIKey(ctx, secret)
+
+	s.Nil(authenticated)
+	s.Equal(ErrInvalidAPIKey, err)
+	s.mockAPIKeyRepo.AssertNotCalled(s.T(), "RecordUsage", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestAPIKeyUseCaseSuite runs the API key use case test suite
+func TestAPIKeyUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(APIKeyUseCaseTestSuite))
+}
@@ -0,0 +1,511 @@
+// Package usecases implements the application layer of the Document Management Platform.
+// It contains use case implementations that orchestrate domain models and services.
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid" // v1.3.0+ - For generating unique IDs for approval requests and events
+
+	"../../domain/models"
+	"../../domain/repositories"
+	"../../domain/services"
+	"../../pkg/errors"
+	"../../pkg/logger"
+	"../../pkg/utils"
+)
+
+// Approval errors
+var (
+	ErrApprovalNoWorkflow   = errors.NewValidationError("the folder of the document has no approval workflow")
+	ErrApprovalNotSubmitter = errors.NewAuthorizationError("only the submitter of an approval request can cancel it")
+)
+
+// ApprovalUseCase defines the contract for approval workflows. Administrators attach an approval
+// chain to a folder; users who can write a document of the folder submit it for approval, which
+// locks the document until the approvers approve or reject it, or the submitter cancels the request.
+type ApprovalUseCase interface {
+	// CreateWorkflow attaches an approval chain to a folder
+	CreateWorkflow(ctx context.Context, workflow *models.ApprovalWorkflow) (*models.ApprovalWorkflow, error)
+
+	// GetWorkflow retrieves an approval workflow
+	GetWorkflow(ctx context.Context, id, tenantID string) (*models.ApprovalWorkflow, error)
+
+	// GetFolderWorkflow retrieves the approval workflow of a folder
+	GetFolderWorkflow(ctx context.Context, folderID, tenantID string) (*models.ApprovalWorkflow, error)
+
+	// ListWorkflows lists the approval workflows of a tenant ordered by name
+	ListWorkflows(ctx context.Context, tenantID string) ([]*models.ApprovalWorkflow, error)
+
+	// UpdateWorkflow replaces the name, mode and approvers of an approval workflow; requests already
+	// submitted keep the chain they were submitted with
+	UpdateWorkflow(ctx context.Context, workflow *models.ApprovalWorkflow) (*models.ApprovalWorkflow, error)
+
+	// DeleteWorkflow deletes an approval workflow; requests already submitted are not affected
+	DeleteWorkflow(ctx context.Context, id, tenantID string) error
+
+	// SubmitForApproval submits a document for approval through the workflow of its folder and
+	// locks the document until the request is approved, rejected or cancelled
+	SubmitForApproval(ctx context.Context, documentID, comment, tenantID, userID string) (*models.ApprovalRequest, error)
+
+	// GetRequest retrieves an approval request
+	GetRequest(ctx context.Context, id, tenantID, userID string) (*models.ApprovalRequest, error)
+
+	// ListDocumentRequests lists the approval requests of a document newest first, with pagination
+	ListDocumentRequests(ctx context.Context, documentID, tenantID, userID string, page int, pageSize int) (utils.PaginatedResult[models.ApprovalRequest], error)
+
+	// ListAwaitingApproval lists the approval requests waiting for a decision of the user, oldest first
+	ListAwaitingApproval(ctx context.Context, tenantID, userID string, page int, pageSize int) (utils.PaginatedResult[models.ApprovalRequest], error)
+
+	// Approve records the approval of the user on a request, approving the request once every approver approved it
+	Approve(ctx context.Context, id, comment, tenantID, userID string) (*models.ApprovalRequest, error)
+
+	// Reject records the rejection of the user on a request, which rejects the request
+	Reject(ctx context.Context, id, comment, tenantID, userID string) (*models.ApprovalRequest, error)
+
+	// CancelRequest withdraws a pending request submitted by the user
+	CancelRequest(ctx context.Context, id, tenantID, userID string) (*models.ApprovalRequest, error)
+}
+
+// approvalUseCase implements the ApprovalUseCase interface
+type approvalUseCase struct {
+	workflowRepo repositories.ApprovalWorkflowRepository
+	requestRepo  repositories.ApprovalRequestRepository
+	documentRepo repositories.DocumentRepository
+	folderRepo   repositories.FolderRepository
+	authService  services.AuthService
+	outboxRepo   repositories.OutboxRepository
+	txManager    repositories.TransactionManager
+}
+
+// NewApprovalUseCase creates a new ApprovalUseCase instance
+func NewApprovalUseCase(
+	workflowRepo repositories.ApprovalWorkflowRepository,
+	requestRepo repositories.ApprovalRequestRepository,
+	documentRepo repositories.DocumentRepository,
+	folderRepo repositories.FolderRepository,
+	authService services.AuthService,
+	outboxRepo repositories.OutboxRepository,
+	txManager repositories.TransactionManager,
+) (ApprovalUseCase, error) {
+	if workflowRepo == nil {
+		return nil, fmt.Errorf("approval workflow repository cannot be nil")
+	}
+	if requestRepo == nil {
+		return nil, fmt.Errorf("approval request repository cannot be nil")
+	}
+	if documentRepo == nil {
+		return nil, fmt.Errorf("document repository cannot be nil")
+	}
+	if folderRepo == nil {
+		return nil, fmt.Errorf("folder repository cannot be nil")
+	}
+	if authService == nil {
+		return nil, fmt.Errorf("auth service cannot be nil")
+	}
+	if outboxRepo == nil {
+		return nil, fmt.Errorf("outbox repository cannot be nil")
+	}
+	if txManager == nil {
+		return nil, fmt.Errorf("transaction manager cannot be nil")
+	}
+
+	return &approvalUseCase{
+		workflowRepo: workflowRepo,
+		requestRepo:  requestRepo,
+		documentRepo: documentRepo,
+		folderRepo:   folderRepo,
+		authService:  authService,
+		outboxRepo:   outboxRepo,
+		txManager:    txManager,
+	}, nil
+}
+
+// CreateWorkflow attaches an approval chain to a folder of the tenant
+func (u *approvalUseCase) CreateWorkflow(ctx context.Context, workflow *models.ApprovalWorkflow) (*models.ApprovalWorkflow, error) {
+	log := logger.WithContext(ctx)
+
+	if workflow == nil {
+		return nil, errors.NewValidationError("approval workflow cannot be nil")
+	}
+	if err := workflow.Validate(); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	// The folder must belong to the tenant
+	if _, err := u.folderRepo.GetByID(ctx, workflow.FolderID, workflow.TenantID); err != nil {
+		return nil, errors.Wrap(err, "failed to create approval workflow")
+	}
+
+	if _, err := u.workflowRepo.Create(ctx, workflow); err != nil {
+		log.WithError(err).Error("failed to create approval workflow", "folderID", workflow.FolderID, "tenantID", workflow.TenantID)
+		return nil, errors.Wrap(err, "failed to create approval workflow")
+	}
+
+	log.Info("approval workflow created successfully", "workflowID", workflow.ID, "folderID", workflow.FolderID, "tenantID", workflow.TenantID)
+	return workflow, nil
+}
+
+// GetWorkflow retrieves an approval workflow of the tenant
+func (u *approvalUseCase) GetWorkflow(ctx context.Context, id, tenantID string) (*models.ApprovalWorkflow, error) {
+	if err := u.validateInput(map[string]string{
+		"workflow ID": id,
+		"tenant ID":   tenantID,
+	}); err != nil {
+		return nil, err
+	}
+
+	return u.workflowRepo.GetByID(ctx, id, tenantID)
+}
+
+// GetFolderWorkflow retrieves the approval workflow of a folder of the tenant
+func (u *approvalUseCase) GetFolderWorkflow(ctx context.Context, folderID, tenantID string) (*models.ApprovalWorkflow, error) {
+	if err := u.validateInput(map[string]string{
+		"folder ID": folderID,
+		"tenant ID": tenantID,
+	}); err != nil {
+		return nil, err
+	}
+
+	return u.workflowRepo.GetByFolder(ctx, folderID, tenantID)
+}
+
+// ListWorkflows lists the approval workflows of the tenant
+func (u *approvalUseCase) ListWorkflows(ctx context.Context, tenantID string) ([]*models.ApprovalWorkflow, error) {
+	if err := u.validateInput(map[string]string{"tenant ID": tenantID}); err != nil {
+		return nil, err
+	}
+
+	return u.workflowRepo.ListByTenant(ctx, tenantID)
+}
+
+// UpdateWorkflow replaces the name, mode and approvers of an approval workflow of the tenant
+func (u *approvalUseCase) UpdateWorkflow(ctx context.Context, workflow *models.ApprovalWorkflow) (*models.ApprovalWorkflow, error) {
+	log := logger.WithContext(ctx)
+
+	if workflow == nil {
+		return nil, errors.NewValidationError("approval workflow cannot be nil")
+	}
+
+	existing, err := u.GetWorkflow(ctx, workflow.ID, workflow.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing.Name = workflow.Name
+	existing.Mode = workflow.Mode
+	existing.Approvers = workflow.Approvers
+	if err := existing.Validate(); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	if err := u.workflowRepo.Update(ctx, existing); err != nil {
+		log.WithError(err).Error("failed to update approval workflow", "workflowID", existing.ID, "tenantID", existing.TenantID)
+		return nil, errors.Wrap(err, "failed to update approval workflow")
+	}
+
+	log.Info("approval workflow updated successfully", "workflowID", existing.ID, "tenantID", existing.TenantID)
+	return existing, nil
+}
+
+// DeleteWorkflow deletes an approval workflow of the tenant
+func (u *approvalUseCase) DeleteWorkflow(ctx context.Context, id, tenantID string) error {
+	log := logger.WithContext(ctx)
+
+	if err := u.validateInput(map[string]string{
+		"workflow ID": id,
+		"tenant ID":   tenantID,
+	}); err != nil {
+		return err
+	}
+
+	if err := u.workflowRepo.Delete(ctx, id, tenantID); err != nil {
+		log.WithError(err).Error("failed to delete approval workflow", "workflowID", id, "tenantID", tenantID)
+		return errors.Wrap(err, "failed to delete approval workflow")
+	}
+
+	log.Info("approval workflow deleted successfully", "workflowID", id, "tenantID", tenantID)
+	return nil
+}
+
+// SubmitForApproval submits a document the user can write for approval. The request, the lock of
+// the document and the approval.submitted event are written in one transaction.
+func (u *approvalUseCase) SubmitForApproval(ctx context.Context, documentID, comment, tenantID, userID string) (*models.ApprovalRequest, error) {
+	log := logger.WithContext(ctx)
+
+	if err := u.validateInput(map[string]string{
+		"document ID": documentID,
+		"tenant ID":   tenantID,
+		"user ID":     userID,
+	}); err != nil {
+		return nil, err
+	}
+
+	document, err := u.documentRepo.GetByID(ctx, documentID, tenantID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to submit document for approval")
+	}
+	if err := u.verifyDocumentAccess(ctx, documentID, tenantID, userID, services.PermissionWrite); err != nil {
+		return nil, err
+	}
+	if document.IsLocked() {
+		return nil, ErrDocumentLocked
+	}
+
+	workflow, err := u.workflowRepo.GetByFolder(ctx, document.FolderID, tenantID)
+	if err != nil {
+		if errors.IsResourceNotFoundError(err) {
+			return nil, ErrApprovalNoWorkflow
+		}
+		return nil, errors.Wrap(err, "failed to submit document for approval")
+	}
+
+	request := models.NewApprovalRequest(workflow, documentID, userID, comment)
+	if err := request.Validate(); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+	request.ID = uuid.New().String()
+
+	err = u.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if _, err := u.requestRepo.Create(txCtx, request); err != nil {
+			return err
+		}
+		if err := u.documentRepo.SetLock(txCtx, documentID, tenantID, userID, &request.CreatedAt); err != nil {
+			return err
+		}
+		return u.publish(txCtx, models.EventTypeApprovalSubmitted, request, userID, comment)
+	})
+	if err != nil {
+		log.WithError(err).Error("failed to submit document for approval", "documentID", documentID, "tenantID", tenantID)
+		return nil, errors.Wrap(err, "failed to submit document for approval")
+	}
+
+	log.Info("document submitted for approval", "requestID", request.ID, "documentID", documentID, "tenantID", tenantID)
+	return request, nil
+}
+
+// GetRequest retrieves an approval request the user submitted, has to decide on, or whose document
+// the user can read
+func (u *approvalUseCase) GetRequest(ctx context.Context, id, tenantID, userID string) (*models.ApprovalRequest, error) {
+	if err := u.validateInput(map[string]string{
+		"request ID": id,
+		"tenant ID":  tenantID,
+		"user ID":    userID,
+	}); err != nil {
+		return nil, err
+	}
+
+	request, err := u.requestRepo.GetByID(ctx, id, tenantID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get approval request")
+	}
+	if request.SubmittedBy == userID || isApprover(request, userID) {
+		return request, nil
+	}
+	if err := u.verifyDocumentAccess(ctx, request.DocumentID, tenantID, userID, services.PermissionRead); err != nil {
+		return nil, err
+	}
+
+	return request, nil
+}
+
+// ListDocumentRequests lists the approval requests of a document the user can read
+func (u *approvalUseCase) ListDocumentRequests(ctx context.Context, documentID, tenantID, userID string, page int, pageSize int) (utils.PaginatedResult[models.ApprovalRequest], error) {
+	log := logger.WithContext(ctx)
+
+	if err := u.validateInput(map[string]string{
+		"document ID": documentID,
+		"tenant ID":   tenantID,
+		"user ID":     userID,
+	}); err != nil {
+		return utils.PaginatedResult[models.ApprovalRequest]{}, err
+	}
+	if err := u.verifyDocumentAccess(ctx, documentID, tenantID, userID, services.PermissionRead); err != nil {
+		return utils.PaginatedResult[models.ApprovalRequest]{}, err
+	}
+
+	result, err := u.requestRepo.ListByDocument(ctx, documentID, tenantID, utils.NewPagination(page, pageSize))
+	if err != nil {
+		log.WithError(err).Error("failed to list approval requests", "documentID", documentID, "tenantID", tenantID)
+		return utils.PaginatedResult[models.ApprovalRequest]{}, errors.Wrap(err, "failed to list approval requests")
+	}
+
+	return result, nil
+}
+
+// ListAwaitingApproval lists the approval requests waiting for a decision of the user
+func (u *approvalUseCase) ListAwaitingApproval(ctx context.Context, tenantID, userID string, page int, pageSize int) (utils.PaginatedResult[models.ApprovalRequest], error) {
+	log := logger.WithContext(ctx)
+
+	if err := u.validateInput(map[string]string{
+		"tenant ID": tenantID,
+		"user ID":   userID,
+	}); err != nil {
+		return utils.PaginatedResult[models.ApprovalRequest]{}, err
+	}
+
+	result, err := u.requestRepo.ListAwaitingApprover(ctx, userID, tenantID, utils.NewPagination(page, pageSize))
+	if err != nil {
+		log.WithError(err).Error("failed to list approval requests awaiting the user", "userID", userID, "tenantID", tenantID)
+		return utils.PaginatedResult[models.ApprovalRequest]{}, errors.Wrap(err, "failed to list approval requests")
+	}
+
+	return result, nil
+}
+
+// Approve records the approval of the user on a request
+func (u *approvalUseCase) Approve(ctx context.Context, id, comment, tenantID, userID string) (*models.ApprovalRequest, error) {
+	return u.decide(ctx, id, true, comment, tenantID, userID)
+}
+
+// Reject records the rejection of the user on a request
+func (u *approvalUseCase) Reject(ctx context.Context, id, comment, tenantID, userID string) (*models.ApprovalRequest, error) {
+	return u.decide(ctx, id, false, comment, tenantID, userID)
+}
+
+// decide records the decision of an approver on a request. The decision, the release of the
+// document once the request is closed and the event of the transition are written in one transaction.
+func (u *approvalUseCase) decide(ctx context.Context, id string, approve bool, comment, tenantID, userID string) (*models.ApprovalRequest, error) {
+	log := logger.WithContext(ctx)
+
+	if err := u.validateInput(map[string]string{
+		"request ID": id,
+		"tenant ID":  tenantID,
+		"user ID":    userID,
+	}); err != nil {
+		return nil, err
+	}
+
+	request, err := u.requestRepo.GetByID(ctx, id, tenantID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decide on approval request")
+	}
+
+	if _, err := request.Decide(userID, approve, comment, time.Now()); err != nil {
+		return nil, approvalError(err)
+	}
+
+	eventType := models.EventTypeApprovalStepApproved
+	switch request.Status {
+	case models.ApprovalStatusApproved:
+		eventType = models.EventTypeApprovalApproved
+	case models.ApprovalStatusRejected:
+		eventType = models.EventTypeApprovalRejected
+	}
+
+	if err := u.transition(ctx, request, eventType, userID, comment); err != nil {
+		log.WithError(err).Error("failed to decide on approval request", "requestID", id, "tenantID", tenantID)
+		return nil, errors.Wrap(err, "failed to decide on approval request")
+	}
+
+	log.Info("approval decision recorded", "requestID", id, "approved", approve, "status", request.Status, "tenantID", tenantID)
+	return request, nil
+}
+
+// CancelRequest withdraws a pending request submitted by the user and releases its document
+func (u *approvalUseCase) CancelRequest(ctx context.Context, id, tenantID, userID string) (*models.ApprovalRequest, error) {
+	log := logger.WithContext(ctx)
+
+	if err := u.validateInput(map[string]string{
+		"request ID": id,
+		"tenant ID":  tenantID,
+		"user ID":    userID,
+	}); err != nil {
+		return nil, err
+	}
+
+	request, err := u.requestRepo.GetByID(ctx, id, tenantID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to cancel approval request")
+	}
+	if request.SubmittedBy != userID {
+		return nil, ErrApprovalNotSubmitter
+	}
+	if err := request.Cancel(time.Now()); err != nil {
+		return nil, approvalError(err)
+	}
+
+	if err := u.transition(ctx, request, models.EventTypeApprovalCancelled, userID, ""); err != nil {
+		log.WithError(err).Error("failed to cancel approval request", "requestID", id, "tenantID", tenantID)
+		return nil, errors.Wrap(err, "failed to cancel approval request")
+	}
+
+	log.Info("approval request cancelled", "requestID", id, "tenantID", tenantID)
+	return request, nil
+}
+
+// transition persists a transition of a request, unlocks its document once the request is closed
+// and writes the event of the transition to the outbox, in one transaction
+func (u *approvalUseCase) transition(ctx context.Context, request *models.ApprovalRequest, eventType, actorID, comment string) error {
+	return u.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := u.requestRepo.Update(txCtx, request); err != nil {
+			return err
+		}
+		if !request.IsPending() {
+			if err := u.documentRepo.SetLock(txCtx, request.DocumentID, request.TenantID, "", nil); err != nil {
+				return err
+			}
+		}
+		return u.publish(txCtx, eventType, request, actorID, comment)
+	})
+}
+
+// publish writes an approval event to the outbox within the transaction carried by ctx
+func (u *approvalUseCase) publish(ctx context.Context, eventType string, request *models.ApprovalRequest, actorID, comment string) error {
+	event, err := models.NewApprovalEvent(eventType, request, actorID, comment)
+	if err != nil {
+		return errors.Wrap(err, "failed to create approval event")
+	}
+	event.ID = uuid.New().String()
+
+	message, err := models.NewOutboxMessage(event)
+	if err != nil {
+		return errors.Wrap(err, "invalid outbox message")
+	}
+
+	_, err = u.outboxRepo.Create(ctx, message)
+	return err
+}
+
+// verifyDocumentAccess checks that the user has the given permission on a document
+func (u *approvalUseCase) verifyDocumentAccess(ctx context.Context, documentID, tenantID, userID, permission string) error {
+	hasAccess, err := u.authService.VerifyResourceAccess(ctx, userID, tenantID, services.ResourceTypeDocument, documentID, permission)
+	if err != nil {
+		logger.WithContext(ctx).WithError(err).Error("failed to verify document access", "documentID", documentID, "userID", userID)
+		return errors.Wrap(err, "failed to verify document access")
+	}
+	if !hasAccess {
+		return ErrPermissionDenied
+	}
+	return nil
+}
+
+// validateInput validates that required input parameters are not empty
+func (u *approvalUseCase) validateInput(params map[string]string) error {
+	for name, value := range params {
+		if value == "" {
+			return errors.NewValidationError(fmt.Sprintf("%s cannot be empty", name))
+		}
+	}
+	return nil
+}
+
+// isApprover returns whether the user is an approver of the request
+func isApprover(request *models.ApprovalRequest, userID string) bool {
+	for _, decision := range request.Decisions {
+		if decision.ApproverID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// approvalError converts an error of an approval request transition to an application error
+func approvalError(err error) error {
+	if err == models.ErrApprovalNotApprover {
+		return errors.NewAuthorizationError(err.Error())
+	}
+	return errors.NewValidationError(err.Error())
+}
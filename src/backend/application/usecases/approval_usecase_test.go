@@ -0,0 +1,330 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert" // v1.8.0+
+	"github.com/stretchr/testify/mock"   // v1.8.0+
+	"github.com/stretchr/testify/suite"  // v1.8.0+
+
+	"../../domain/models"
+	"../../domain/repositories"
+	"../../domain/services"
+	pkgErrors "../../pkg/errors"
+	"../../pkg/utils"
+)
+
+// MockApprovalWorkflowRepository is a mock implementation of the ApprovalWorkflowRepository interface for testing
+type MockApprovalWorkflowRepository struct {
+	mock.Mock
+}
+
+func (m *MockApprovalWorkflowRepository) Create(ctx context.Context, workflow *models.ApprovalWorkflow) (string, error) {
+	args := m.Called(ctx, workflow)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockApprovalWorkflowRepository) GetByID(ctx context.Context, id string, tenantID string) (*models.ApprovalWorkflow, error) {
+	args := m.Called(ctx, id, tenantID)
+	if workflow := args.Get(0); workflow != nil {
+		return workflow.(*models.ApprovalWorkflow), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockApprovalWorkflowRepository) GetByFolder(ctx context.Context, folderID string, tenantID string) (*models.ApprovalWorkflow, error) {
+	args := m.Called(ctx, folderID, tenantID)
+	if workflow := args.Get(0); workflow != nil {
+		return workflow.(*models.ApprovalWorkflow), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockApprovalWorkflowRepository) Update(ctx context.Context, workflow *models.ApprovalWorkflow) error {
+	args := m.Called(ctx, workflow)
+	return args.Error(0)
+}
+
+func (m *MockApprovalWorkflowRepository) Delete(ctx context.Context, id string, tenantID string) error {
+	args := m.Called(ctx, id, tenantID)
+	return args.Error(0)
+}
+
+func (m *MockApprovalWorkflowRepository) ListByTenant(ctx context.Context, tenantID string) ([]*models.ApprovalWorkflow, error) {
+	args := m.Called(ctx, tenantID)
+	return args.Get(0).([]*models.ApprovalWorkflow), args.Error(1)
+}
+
+// MockApprovalRequestRepository is a mock implementation of the ApprovalRequestRepository interface for testing
+type MockApprovalRequestRepository struct {
+	mock.Mock
+}
+
+func (m *MockApprovalRequestRepository) Create(ctx context.Context, request *models.ApprovalRequest) (string, error) {
+	args := m.Called(ctx, request)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockApprovalRequestRepository) GetByID(ctx context.Context, id string, tenantID string) (*models.ApprovalRequest, error) {
+	args := m.Called(ctx, id, tenantID)
+	if request := args.Get(0); request != nil {
+		return request.(*models.ApprovalRequest), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockApprovalRequestRepository) Update(ctx context.Context, request *models.ApprovalRequest) error {
+	args := m.Called(ctx, request)
+	return args.Error(0)
+}
+
+func (m *MockApprovalRequestRepository) ListByDocument(ctx context.Context, documentID string, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.ApprovalRequest], error) {
+	args := m.Called(ctx, documentID, tenantID, pagination)
+	return args.Get(0).(utils.PaginatedResult[models.ApprovalRequest]), args.Error(1)
+}
+
+func (m *MockApprovalRequestRepository) ListAwaitingApprover(ctx context.Context, approverID string, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.ApprovalRequest], error) {
+	args := m.Called(ctx, approverID, tenantID, pagination)
+	return args.Get(0).(utils.PaginatedResult[models.ApprovalRequest]), args.Error(1)
+}
+
+// mockApprovalDocumentRepository mocks the DocumentRepository methods used by approvals
+type mockApprovalDocumentRepository struct {
+	repositories.DocumentRepository
+	mock.Mock
+}
+
+func (m *mockApprovalDocumentRepository) GetByID(ctx context.Context, id string, tenantID string) (*models.Document, error) {
+	args := m.Called(ctx, id, tenantID)
+	if document := args.Get(0); document != nil {
+		return document.(*models.Document), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *mockApprovalDocumentRepository) SetLock(ctx context.Context, id string, tenantID string, lockedBy string, lockedAt *time.Time) error {
+	args := m.Called(ctx, id, tenantID, lockedBy, lockedAt)
+	return args.Error(0)
+}
+
+// mockApprovalFolderRepository mocks the FolderRepository methods used by approvals
+type mockApprovalFolderRepository struct {
+	repositories.FolderRepository
+	mock.Mock
+}
+
+func (m *mockApprovalFolderRepository) GetByID(ctx context.Context, id string, tenantID string) (*models.Folder, error) {
+	args := m.Called(ctx, id, tenantID)
+	if folder := args.Get(0); folder != nil {
+		return folder.(*models.Folder), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// mockApprovalAuthService mocks the AuthService methods used by approvals
+type mockApprovalAuthService struct {
+	services.AuthService
+	mock.Mock
+}
+
+func (m *mockApprovalAuthService) VerifyResourceAccess(ctx context.Context, userID, tenantID, resourceType, resourceID, accessType string) (bool, error) {
+	args := m.Called(ctx, userID, tenantID, resourceType, resourceID, accessType)
+	return args.Bool(0), args.Error(1)
+}
+
+// ApprovalUseCaseTestSuite defines a test suite for ApprovalUseCase
+type ApprovalUseCaseTestSuite struct {
+	suite.Suite
+	mockWorkflowRepo *MockApprovalWorkflowRepository
+	mockRequestRepo  *MockApprovalRequestRepository
+	mockDocumentRepo *mockApprovalDocumentRepository
+	mockFolderRepo   *mockApprovalFolderRepository
+	mockAuthService  *mockApprovalAuthService
+	mockOutboxRepo   *MockOutboxRepository
+	approvalUseCase  ApprovalUseCase
+}
+
+// SetupTest sets up the test environment before each test
+func (s *ApprovalUseCaseTestSuite) SetupTest() {
+	s.mockWorkflowRepo = new(MockApprovalWorkflowRepository)
+	s.mockRequestRepo = new(MockApprovalRequestRepository)
+	s.mockDocumentRepo = new(mockApprovalDocumentRepository)
+	s.mockFolderRepo = new(mockApprovalFolderRepository)
+	s.mockAuthService = new(mockApprovalAuthService)
+	s.mockOutboxRepo = new(MockOutboxRepository)
+
+	var err error
+	s.approvalUseCase, err = NewApprovalUseCase(s.mockWorkflowRepo, s.mockRequestRepo, s.mockDocumentRepo, s.mockFolderRepo, s.mockAuthService, s.mockOutboxRepo, &passthroughTransactionManager{})
+	assert.Nil(s.T(), err)
+	assert.NotNil(s.T(), s.approvalUseCase)
+}
+
+// pendingRequest returns a pending request of doc123 submitted by user123 to approvers user456 and user789
+func (s *ApprovalUseCaseTestSuite) pendingRequest(mode string) *models.ApprovalRequest {
+	workflow := models.NewApprovalWorkflow("tenant123", "folder123", "Contracts", mode, []string{"user456", "user789"}, "admin123")
+	workflow.ID = "workflow123"
+	request := models.NewApprovalRequest(workflow, "doc123", "user123", "")
+	request.ID = "request123"
+	return request
+}
+
+// expectEvent expects an event of the given type to be written to the outbox
+func (s *ApprovalUseCaseTestSuite) expectEvent(eventType string) {
+	s.mockOutboxRepo.On("Create", mock.Anything, mock.MatchedBy(func(m *models.OutboxMessage) bool {
+		return m.EventType == eventType
+	})).Return("message123", nil).Once()
+}
+
+// TestNewApprovalUseCase tests the creation of a new ApprovalUseCase
+func (s *ApprovalUseCaseTestSuite) TestNewApprovalUseCase() {
+	useCase, err := NewApprovalUseCase(s.mockWorkflowRepo, s.mockRequestRepo, s.mockDocumentRepo, s.mockFolderRepo, s.mockAuthService, s.mockOutboxRepo, nil)
+	assert.NotNil(s.T(), err)
+	assert.Nil(s.T(), useCase)
+}
+
+// TestCreateWorkflow_InvalidMode tests attaching a chain with an unknown mode
+func (s *ApprovalUseCaseTestSuite) TestCreateWorkflow_InvalidMode() {
+	workflow := models.NewApprovalWorkflow("tenant123", "folder123", "Contracts", "random", []string{"user456"}, "admin123")
+
+	created, err := s.approvalUseCase.CreateWorkflow(context.Background(), workflow)
+
+	assert.Nil(s.T(), created)
+	assert.True(s.T(), pkgErrors.IsValidationError(err))
+	s.mockWorkflowRepo.AssertNotCalled(s.T(), "Create")
+}
+
+// TestSubmitForApproval_Success tests submitting a document, which locks it and publishes approval.submitted
+func (s *ApprovalUseCaseTestSuite) TestSubmitForApproval_Success() {
+	workflow := models.NewApprovalWorkflow("tenant123", "folder123", "Contracts", models.ApprovalModeSequential, []string{"user456", "user789"}, "admin123")
+	workflow.ID = "workflow123"
+	s.mockDocumentRepo.On("GetByID", mock.Anything, "doc123", "tenant123").Return(&models.Document{ID: "doc123", FolderID: "folder123", TenantID: "tenant123"}, nil)
+	s.mockAuthService.On("VerifyResourceAccess", mock.Anything, "user123", "tenant123", services.ResourceTypeDocument, "doc123", services.PermissionWrite).Return(true, nil)
+	s.mockWorkflowRepo.On("GetByFolder", mock.Anything, "folder123", "tenant123").Return(workflow, nil)
+	s.mockRequestRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.ApprovalRequest")).Return("request123", nil)
+	s.mockDocumentRepo.On("SetLock", mock.Anything, "doc123", "tenant123", "user123", mock.MatchedBy(func(t *time.Time) bool { return t != nil })).Return(nil)
+	s.expectEvent(models.EventTypeApprovalSubmitted)
+
+	request, err := s.approvalUseCase.SubmitForApproval(context.Background(), "doc123", "Ready for signature", "tenant123", "user123")
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), models.ApprovalStatusPending, request.Status)
+	assert.Equal(s.T(), []string{"user456"}, request.PendingApprovers())
+	s.mockRequestRepo.AssertExpectations(s.T())
+	s.mockDocumentRepo.AssertExpectations(s.T())
+	s.mockOutboxRepo.AssertExpectations(s.T())
+}
+
+// TestSubmitForApproval_NoWorkflow tests submitting a document of a folder without an approval chain
+func (s *ApprovalUseCaseTestSuite) TestSubmitForApproval_NoWorkflow() {
+	s.mockDocumentRepo.On("GetByID", mock.Anything, "doc123", "tenant123").Return(&models.Document{ID: "doc123", FolderID: "folder123", TenantID: "tenant123"}, nil)
+	s.mockAuthService.On("VerifyResourceAccess", mock.Anything, "user123", "tenant123", services.ResourceTypeDocument, "doc123", services.PermissionWrite).Return(true, nil)
+	s.mockWorkflowRepo.On("GetByFolder", mock.Anything, "folder123", "tenant123").Return(nil, pkgErrors.NewResourceNotFoundError("Approval workflow not found"))
+
+	request, err := s.approvalUseCase.SubmitForApproval(context.Background(), "doc123", "", "tenant123", "user123")
+
+	assert.Nil(s.T(), request)
+	assert.Equal(s.T(), ErrApprovalNoWorkflow, err)
+	s.mockRequestRepo.AssertNotCalled(s.T(), "Create")
+}
+
+// TestSubmitForApproval_Locked tests submitting a document that already awaits approval
+func (s *ApprovalUseCaseTestSuite) TestSubmitForApproval_Locked() {
+	document := &models.Document{ID: "doc123", FolderID: "folder123", TenantID: "tenant123"}
+	document.Lock("user456", time.Now())
+	s.mockDocumentRepo.On("GetByID", mock.Anything, "doc123", "tenant123").Return(document, nil)
+	s.mockAuthService.On("VerifyResourceAccess", mock.Anything, "user123", "tenant123", services.ResourceTypeDocument, "doc123", services.PermissionWrite).Return(true, nil)
+
+	request, err := s.approvalUseCase.SubmitForApproval(context.Background(), "doc123", "", "tenant123", "user123")
+
+	assert.Nil(s.T(), request)
+	assert.Equal(s.T(), ErrDocumentLocked, err)
+	s.mockWorkflowRepo.AssertNotCalled(s.T(), "GetByFolder")
+}
+
+// TestApprove_Step tests the first approver of a sequential chain approving, which keeps the document locked
+func (s *ApprovalUseCaseTestSuite) TestApprove_Step() {
+	s.mockRequestRepo.On("GetByID", mock.Anything, "request123", "tenant123").Return(s.pendingRequest(models.ApprovalModeSequential), nil)
+	s.mockRequestRepo.On("Update", mock.Anything, mock.AnythingOfType("*models.ApprovalRequest")).Return(nil)
+	s.expectEvent(models.EventTypeApprovalStepApproved)
+
+	request, err := s.approvalUseCase.Approve(context.Background(), "request123", "Looks good", "tenant123", "user456")
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), models.ApprovalStatusPending, request.Status)
+	assert.Equal(s.T(), []string{"user789"}, request.PendingApprovers())
+	s.mockDocumentRepo.AssertNotCalled(s.T(), "SetLock")
+	s.mockOutboxRepo.AssertExpectations(s.T())
+}
+
+// TestApprove_OutOfTurn tests the second approver of a sequential chain approving before the first
+func (s *ApprovalUseCaseTestSuite) TestApprove_OutOfTurn() {
+	s.mockRequestRepo.On("GetByID", mock.Anything, "request123", "tenant123").Return(s.pendingRequest(models.ApprovalModeSequential), nil)
+
+	request, err := s.approvalUseCase.Approve(context.Background(), "request123", "", "tenant123", "user789")
+
+	assert.Nil(s.T(), request)
+	assert.True(s.T(), pkgErrors.IsValidationError(err))
+	s.mockRequestRepo.AssertNotCalled(s.T(), "Update")
+}
+
+// TestApprove_LastParallelApprover tests the last approver of a parallel chain approving, which unlocks the document
+func (s *ApprovalUseCaseTestSuite) TestApprove_LastParallelApprover() {
+	pending := s.pendingRequest(models.ApprovalModeParallel)
+	_, err := pending.Decide("user789", true, "", time.Now())
+	s.Require().NoError(err)
+	s.mockRequestRepo.On("GetByID", mock.Anything, "request123", "tenant123").Return(pending, nil)
+	s.mockRequestRepo.On("Update", mock.Anything, pending).Return(nil)
+	s.mockDocumentRepo.On("SetLock", mock.Anything, "doc123", "tenant123", "", (*time.Time)(nil)).Return(nil)
+	s.expectEvent(models.EventTypeApprovalApproved)
+
+	request, err := s.approvalUseCase.Approve(context.Background(), "request123", "", "tenant123", "user456")
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), models.ApprovalStatusApproved, request.Status)
+	assert.NotNil(s.T(), request.CompletedAt)
+	s.mockDocumentRepo.AssertExpectations(s.T())
+	s.mockOutboxRepo.AssertExpectations(s.T())
+}
+
+// TestReject_Success tests an approver rejecting a request with a comment, which unlocks the document
+func (s *ApprovalUseCaseTestSuite) TestReject_Success() {
+	s.mockRequestRepo.On("GetByID", mock.Anything, "request123", "tenant123").Return(s.pendingRequest(models.ApprovalModeSequential), nil)
+	s.mockRequestRepo.On("Update", mock.Anything, mock.AnythingOfType("*models.ApprovalRequest")).Return(nil)
+	s.mockDocumentRepo.On("SetLock", mock.Anything, "doc123", "tenant123", "", (*time.Time)(nil)).Return(nil)
+	s.expectEvent(models.EventTypeApprovalRejected)
+
+	request, err := s.approvalUseCase.Reject(context.Background(), "request123", "Clause 4 is missing", "tenant123", "user456")
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), models.ApprovalStatusRejected, request.Status)
+	assert.Equal(s.T(), "Clause 4 is missing", request.Decisions[0].Comment)
+	s.mockDocumentRepo.AssertExpectations(s.T())
+}
+
+// TestApprove_NotApprover tests a user outside the chain approving
+func (s *ApprovalUseCaseTestSuite) TestApprove_NotApprover() {
+	s.mockRequestRepo.On("GetByID", mock.Anything, "request123", "tenant123").Return(s.pendingRequest(models.ApprovalModeParallel), nil)
+
+	request, err := s.approvalUseCase.Approve(context.Background(), "request123", "", "tenant123", "user999")
+
+	assert.Nil(s.T(), request)
+	assert.True(s.T(), pkgErrors.IsAuthorizationError(err))
+}
+
+// TestCancelRequest_NotSubmitter tests an approver cancelling a request
+func (s *ApprovalUseCaseTestSuite) TestCancelRequest_NotSubmitter() {
+	s.mockRequestRepo.On("GetByID", mock.Anything, "request123", "tenant123").Return(s.pendingRequest(models.ApprovalModeParallel), nil)
+
+	request, err := s.approvalUseCase.CancelRequest(context.Background(), "request123", "tenant123", "user456")
+
+	assert.Nil(s.T(), request)
+	assert.Equal(s.T(), ErrApprovalNotSubmitter, err)
+	s.mockRequestRepo.AssertNotCalled(s.T(), "Update")
+}
+
+// TestApprovalUseCaseSuite runs the ApprovalUseCase test suite
+func TestApprovalUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(ApprovalUseCaseTestSuite))
+}
@@ -0,0 +1,118 @@
+// Package usecases implements the application layer of the Document Management Platform.
+// It contains use case implementations that orchestrate domain models and services.
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"../../domain/models"
+	"../../domain/services"
+	"../../pkg/errors"
+	"../../pkg/logger"
+	"../../pkg/utils"
+)
+
+// AuditUseCase defines the contract for audit log application use cases
+type AuditUseCase interface {
+	// ListAuditLogs lists a tenant's audit log entries matching the filter with pagination
+	ListAuditLogs(ctx context.Context, tenantID string, filter models.AuditLogFilter, page int, pageSize int) (utils.PaginatedResult[models.AuditLog], error)
+
+	// GetAuditLog retrieves an audit log entry by its ID
+	GetAuditLog(ctx context.Context, id string, tenantID string) (*models.AuditLog, error)
+
+	// ExportAuditLogs writes a tenant's audit log entries matching the filter to w in the given format
+	ExportAuditLogs(ctx context.Context, tenantID string, filter models.AuditLogFilter, format string, w io.Writer) (int, error)
+}
+
+// auditUseCase implements the AuditUseCase interface
+type auditUseCase struct {
+	auditService services.AuditService
+}
+
+// NewAuditUseCase creates a new AuditUseCase instance
+func NewAuditUseCase(auditService services.AuditService) (AuditUseCase, error) {
+	if auditService == nil {
+		return nil, fmt.Errorf("audit service cannot be nil")
+	}
+
+	return &auditUseCase{
+		auditService: auditService,
+	}, nil
+}
+
+// ListAuditLogs lists a tenant's audit log entries matching the filter with pagination
+func (u *auditUseCase) ListAuditLogs(ctx context.Context, tenantID string, filter models.AuditLogFilter, page int, pageSize int) (utils.PaginatedResult[models.AuditLog], error) {
+	log := logger.WithContext(ctx)
+
+	if tenantID == "" {
+		log.Error("tenant ID cannot be empty")
+		return utils.PaginatedResult[models.AuditLog]{}, errors.NewValidationError("tenant ID is required")
+	}
+
+	pagination := utils.NewPagination(page, pageSize)
+
+	result, err := u.auditService.ListAuditLogs(ctx, tenantID, filter, pagination)
+	if err != nil {
+		log.WithError(err).Error("failed to list audit logs", "tenantID", tenantID, "action", filter.Action, "resourceType", filter.ResourceType)
+		return utils.PaginatedResult[models.AuditLog]{}, errors.Wrap(err, "failed to list audit logs")
+	}
+
+	log.Info("audit logs listed successfully", "tenantID", tenantID, "count", len(result.Items))
+	return result, nil
+}
+
+// GetAuditLog retrieves an audit log entry by its ID
+func (u *auditUseCase) GetAuditLog(ctx context.Context, id string, tenantID string) (*models.AuditLog, error) {
+	log := logger.WithContext(ctx)
+
+	if err := u.validateInput(map[string]string{
+		"audit log ID": id,
+		"tenant ID":    tenantID,
+	}); err != nil {
+		return nil, err
+	}
+
+	entry, err := u.auditService.GetAuditLog(ctx, id, tenantID)
+	if err != nil {
+		log.WithError(err).Error("failed to get audit log", "id", id, "tenantID", tenantID)
+		return nil, errors.Wrap(err, "failed to get audit log")
+	}
+
+	return entry, nil
+}
+
+// ExportAuditLogs writes a tenant's audit log entries matching the filter to w in the given format
+func (u *auditUseCase) ExportAuditLogs(ctx context.Context, tenantID string, filter models.AuditLogFilter, format string, w io.Writer) (int, error) {
+	log := logger.WithContext(ctx)
+
+	if tenantID == "" {
+		log.Error("tenant ID cannot be empty")
+		return 0, errors.NewValidationError("tenant ID is required")
+	}
+
+	if w == nil {
+		log.Error("export writer cannot be nil")
+		return 0, errors.NewValidationError("export writer cannot be nil")
+	}
+
+	count, err := u.auditService.ExportAuditLogs(ctx, tenantID, filter, format, w)
+	if err != nil {
+		log.WithError(err).Error("failed to export audit logs", "tenantID", tenantID, "format", format)
+		return count, errors.Wrap(err, "failed to export audit logs")
+	}
+
+	log.Info("audit logs exported successfully", "tenantID", tenantID, "format", format, "count", count)
+	return count, nil
+}
+
+// validateInput validates that required input parameters are not empty
+func (u *auditUseCase) validateInput(params map[string]string) error {
+	for name, value := range params {
+		if value == "" {
+			return errors.NewValidationError(fmt.Sprintf("%s cannot be empty", name))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert" // v1.8.0+
+	"github.com/stretchr/testify/mock"   // v1.8.0+
+	"github.com/stretchr/testify/suite"  // v1.8.0+
+
+	"../../domain/models"
+	pkgErrors "../../pkg/errors"
+	"../../pkg/utils"
+)
+
+// MockAuditService is a mock implementation of the AuditService interface for testing
+type MockAuditService struct {
+	mock.Mock
+}
+
+// Record mock implementation for recording an audit log entry
+func (m *MockAuditService) Record(ctx context.Context, entry *models.AuditLog) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+// RecordAction mock implementation for recording an operation
+func (m *MockAuditService) RecordAction(ctx context.Context, tenantID, actorID, action, resourceType, resourceID string, before, after map[string]interface{}) error {
+	args := m.Called(ctx, tenantID, actorID, action, resourceType, resourceID, before, after)
+	return args.Error(0)
+}
+
+// GetAuditLog mock implementation for retrieving an audit log entry
+func (m *MockAuditService) GetAuditLog(ctx context.Context, id string, tenantID string) (*models.AuditLog, error) {
+	args := m.Called(ctx, id, tenantID)
+	if entry := args.Get(0); entry != nil {
+		return entry.(*models.AuditLog), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// ListAuditLogs mock implementation for listing audit log entries
+func (m *MockAuditService) ListAuditLogs(ctx context.Context, tenantID string, filter models.AuditLogFilter, pagination *utils.Pagination) (utils.PaginatedResult[models.AuditLog], error) {
+	args := m.Called(ctx, tenantID, filter, pagination)
+	return args.Get(0).(utils.PaginatedResult[models.AuditLog]), args.Error(1)
+}
+
+// ExportAuditLogs mock implementation for exporting audit log entries
+func (m *MockAuditService) ExportAuditLogs(ctx context.Context, tenantID string, filter models.AuditLogFilter, format string, w io.Writer) (int, error) {
+	args := m.Called(ctx, tenantID, filter, format, w)
+	return args.Int(0), args.Error(1)
+}
+
+// EnsurePartitions mock implementation for audit log partition maintenance
+func (m *MockAuditService) EnsurePartitions(ctx context.Context, now time.Time) error {
+	args := m.Called(ctx, now)
+	return args.Error(0)
+}
+
+// AuditUseCaseTestSuite defines a test suite for AuditUseCase
+type AuditUseCaseTestSuite struct {
+	suite.Suite
+	mockAuditService *MockAuditService
+	auditUseCase     AuditUseCase
+}
+
+// SetupTest sets up the test environment before each test
+func (s *AuditUseCaseTestSuite) SetupTest() {
+	s.mockAuditService = new(MockAuditService)
+
+	var err error
+	s.auditUseCase, err = NewAuditUseCase(s.mockAuditService)
+	assert.Nil(s.T(), err)
+	assert.NotNil(s.T(), s.auditUseCase)
+}
+
+// TestNewAuditUseCase tests the creation of a new AuditUseCase
+func (s *AuditUseCaseTestSuite) TestNewAuditUseCase() {
+	useCase, err := NewAuditUseCase(nil)
+	assert.NotNil(s.T(), err)
+	assert.Nil(s.T(), useCase)
+}
+
+// TestListAuditLogs_Success tests successful audit log listing with a filter
+func (s *AuditUseCaseTestSuite) TestListAuditLogs_Success() {
+	filter := models.AuditLogFilter{ActorID: "user123", ResourceType: models.ResourceTypeFolder}
+	expected := utils.PaginatedResult[models.AuditLog]{
+		Items: []models.AuditLog{{ID: "audit1", TenantID: "tenant123", ActorID: "user123", Action: models.AuditActionCreate}},
+	}
+	s.mockAuditService.On("ListAuditLogs", mock.Anything, "tenant123", filter, mock.AnythingOfType("*utils.Pagination")).Return(expected, nil)
+
+	result, err := s.auditUseCase.ListAuditLogs(context.Background(), "tenant123", filter, 1, 20)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), expected, result)
+	s.mockAuditService.AssertExpectations(s.T())
+}
+
+// TestListAuditLogs_ValidationError tests audit log listing without a tenant
+func (s *AuditUseCaseTestSuite) TestListAuditLogs_ValidationError() {
+	_, err := s.auditUseCase.ListAuditLogs(context.Background(), "", models.AuditLogFilter{}, 1, 20)
+	assert.NotNil(s.T(), err)
+	assert.True(s.T(), pkgErrors.IsValidationError(err))
+
+	s.mockAuditService.AssertNotCalled(s.T(), "ListAuditLogs")
+}
+
+// TestGetAuditLog_Success tests successful audit log entry retrieval
+func (s *AuditUseCaseTestSuite) TestGetAuditLog_Success() {
+	entry := &models.AuditLog{ID: "audit123", TenantID: "tenant123"}
+	s.mockAuditService.On("GetAuditLog", mock.Anything, "audit123", "tenant123").Return(entry, nil)
+
+	result, err := s.auditUseCase.GetAuditLog(context.Background(), "audit123", "tenant123")
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), entry, result)
+	s.mockAuditService.AssertExpectations(s.T())
+}
+
+// TestGetAuditLog_ValidationError tests audit log entry retrieval without an ID
+func (s *AuditUseCaseTestSuite) TestGetAuditLog_ValidationError() {
+	result, err := s.auditUseCase.GetAuditLog(context.Background(), "", "tenant123")
+	assert.Nil(s.T(), result)
+	assert.True(s.T(), pkgErrors.IsValidationError(err))
+
+	s.mockAuditService.AssertNotCalled(s.T(), "GetAuditLog")
+}
+
+// TestExportAuditLogs_Success tests a successful audit log export
+func (s *AuditUseCaseTestSuite) TestExportAuditLogs_Success() {
+	var buf bytes.Buffer
+	filter := models.AuditLogFilter{Action: models.AuditActionDelete}
+	s.mockAuditService.On("ExportAuditLogs", mock.Anything, "tenant123", filter, models.AuditExportFormatJSONL, &buf).Return(3, nil)
+
+	count, err := s.auditUseCase.ExportAuditLogs(context.Background(), "tenant123", filter, models.AuditExportFormatJSONL, &buf)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), 3, count)
+	s.mockAuditService.AssertExpectations(s.T())
+}
+
+// TestExportAuditLogs_ServiceError tests an audit log export that fails part way
+func (s *AuditUseCaseTestSuite) TestExportAuditLogs_ServiceError() {
+	var buf bytes.Buffer
+	s.mockAuditService.On("ExportAuditLogs", mock.Anything, "tenant123", models.AuditLogFilter{}, models.AuditExportFormatCSV, &buf).Return(1000, errors.New("service error"))
+
+	count, err := s.auditUseCase.ExportAuditLogs(context.Background(), "tenant123", models.AuditLogFilter{}, models.AuditExportFormatCSV, &buf)
+
+	assert.NotNil(s.T(), err)
+	assert.Equal(s.T(), 1000, count)
+	s.mockAuditService.AssertExpectations(s.T())
+}
+
+// TestAuditUseCaseSuite entry point for running the AuditUseCase test suite
+func TestAuditUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(AuditUseCaseTestSuite))
+}
@@ -3,6 +3,8 @@ package usecases
 
 import (
 	"context"
+	"fmt"
+	"net/url"
 	"strings"
 	"time"
 
@@ -12,6 +14,7 @@ import (
 	"../../domain/repositories"
 	"../../domain/services"
 	"../../pkg/errors"
+	"../../pkg/logger"
 )
 
 // Default token expiration durations
@@ -20,17 +23,46 @@ var (
 	defaultRefreshTokenExpiration = time.Hour * 24 * 7
 )
 
+// mfaIssuer names the platform in users' authenticator apps
+const mfaIssuer = "Document Management Platform"
+
+// LoginResult is the outcome of a sign-in. Users who have to verify a second factor receive only
+// a partial-auth MFA token, which VerifyMFA exchanges for the access and refresh tokens.
+// PasswordExpired is set when the password is older than the tenant's policy allows, in which
+// case clients have to make the user change it before continuing.
+type LoginResult struct {
+	AccessToken           string
+	RefreshToken          string
+	MFAToken              string
+	MFARequired           bool
+	MFAEnrollmentRequired bool
+	PasswordExpired       bool
+}
+
+// MFASetup holds what a user needs to configure their authenticator app. The secret and backup
+// codes are only available at enrollment time.
+type MFASetup struct {
+	Secret          string
+	ProvisioningURI string
+	BackupCodes     []string
+}
+
 // AuthUseCase provides authentication and authorization functionality for the application
 type AuthUseCase struct {
 	authService           services.AuthService
 	userRepo              repositories.UserRepository
 	tenantRepo            repositories.TenantRepository
+	mfaRepo               repositories.MFARepository
+	auditService          services.AuditService
 	tokenExpiration       time.Duration
 	refreshTokenExpiration time.Duration
+	emailSender           services.EmailSender
+	guestInviteURL        string
+	tenantSettings        services.TenantSettingsService
 }
 
 // NewAuthUseCase creates a new authentication use case with the given dependencies
-func NewAuthUseCase(authService services.AuthService, userRepo repositories.UserRepository, tenantRepo repositories.TenantRepository) (*AuthUseCase, error) {
+func NewAuthUseCase(authService services.AuthService, userRepo repositories.UserRepository, tenantRepo repositories.TenantRepository, mfaRepo repositories.MFARepository, auditService services.AuditService) (*AuthUseCase, error) {
 	// Validate input parameters
 	if authService == nil {
 		return nil, errors.NewValidationError("auth service is required")
@@ -41,50 +73,60 @@ func NewAuthUseCase(authService services.AuthService, userRepo repositories.User
 	if tenantRepo == nil {
 		return nil, errors.NewValidationError("tenant repository is required")
 	}
+	if mfaRepo == nil {
+		return nil, errors.NewValidationError("MFA repository is required")
+	}
+	if auditService == nil {
+		return nil, errors.NewValidationError("audit service is required")
+	}
 
 	// Create a new AuthUseCase instance with the provided dependencies
 	return &AuthUseCase{
 		authService:           authService,
 		userRepo:              userRepo,
 		tenantRepo:            tenantRepo,
+		mfaRepo:               mfaRepo,
+		auditService:          auditService,
 		tokenExpiration:       defaultTokenExpiration,
 		refreshTokenExpiration: defaultRefreshTokenExpiration,
 	}, nil
 }
 
-// Login authenticates a user with username/email and password
-func (a *AuthUseCase) Login(ctx context.Context, tenantID, usernameOrEmail, password string) (string, error) {
+// Login authenticates a user with username/email and password. Users with multi-factor authentication
+// enabled, and users of tenants that require it, receive a partial-auth token instead of full tokens.
+// Too many failed attempts lock the account for the time the tenant's password policy sets.
+func (a *AuthUseCase) Login(ctx context.Context, tenantID, usernameOrEmail, password string) (*LoginResult, error) {
 	// Validate input parameters
 	if tenantID == "" {
-		return "", errors.NewValidationError("tenant ID is required")
+		return nil, errors.NewValidationError("tenant ID is required")
 	}
 	if usernameOrEmail == "" {
-		return "", errors.NewValidationError("username or email is required")
+		return nil, errors.NewValidationError("username or email is required")
 	}
 	if password == "" {
-		return "", errors.NewValidationError("password is required")
+		return nil, errors.NewValidationError("password is required")
 	}
 
 	// Check if tenant exists and is active
 	tenant, err := a.tenantRepo.GetByID(ctx, tenantID)
 	if err != nil {
 		if errors.IsResourceNotFoundError(err) {
-			return "", errors.NewAuthenticationError("invalid tenant ID")
+			return nil, errors.NewAuthenticationError("invalid tenant ID")
 		}
-		return "", errors.Wrap(err, "failed to retrieve tenant")
+		return nil, errors.Wrap(err, "failed to retrieve tenant")
 	}
 
 	// We need to verify tenant is active
 	// Assuming Tenant has an IsActive method similar to User
 	if !strings.EqualFold(tenant.Status, "active") {
-		return "", errors.NewAuthenticationError("tenant is not active")
+		return nil, errors.NewAuthenticationError("tenant is not active")
 	}
 
 	// Try to get user by username
 	var user *models.User
 	user, err = a.userRepo.GetByUsername(ctx, usernameOrEmail, tenantID)
 	if err != nil && !errors.IsResourceNotFoundError(err) {
-		return "", errors.Wrap(err, "failed to retrieve user by username")
+		return nil, errors.Wrap(err, "failed to retrieve user by username")
 	}
 
 	// If not found by username, try by email
@@ -92,44 +134,105 @@ func (a *AuthUseCase) Login(ctx context.Context, tenantID, usernameOrEmail, pass
 		user, err = a.userRepo.GetByEmail(ctx, usernameOrEmail, tenantID)
 		if err != nil {
 			if errors.IsResourceNotFoundError(err) {
-				return "", errors.NewAuthenticationError("invalid credentials")
+				return nil, errors.NewAuthenticationError("invalid credentials")
 			}
-			return "", errors.Wrap(err, "failed to retrieve user by email")
+			return nil, errors.Wrap(err, "failed to retrieve user by email")
 		}
 	}
 
 	// Verify user belongs to the specified tenant
 	if user.TenantID != tenantID {
-		return "", errors.NewAuthenticationError("invalid credentials")
+		return nil, errors.NewAuthenticationError("invalid credentials")
 	}
 
 	// Verify user is active
 	if !user.IsActive() {
-		return "", errors.NewAuthenticationError("user account is not active")
+		return nil, errors.NewAuthenticationError("user account is not active")
+	}
+
+	// Refuse locked accounts without checking the password, so guessing cannot continue during the lockout
+	policy := tenant.PasswordPolicy()
+	now := time.Now()
+	if user.IsLocked(now) {
+		return nil, errors.NewAuthenticationError("account is temporarily locked")
 	}
 
 	// Verify password
 	match, err := user.VerifyPassword(password)
 	if err != nil {
-		return "", errors.Wrap(err, "password verification failed")
+		return nil, errors.Wrap(err, "password verification failed")
 	}
 	if !match {
-		return "", errors.NewAuthenticationError("invalid credentials")
+		if err := a.recordFailedLogin(ctx, user, policy, now); err != nil {
+			return nil, err
+		}
+		return nil, errors.NewAuthenticationError("invalid credentials")
 	}
 
-	// Generate access token with user ID, tenant ID, and roles
-	token, err := a.authService.GenerateToken(ctx, user.ID, user.TenantID, user.Roles, a.tokenExpiration)
+	passwordExpired := user.IsPasswordExpired(policy, now)
+
+	// Users with an enabled second factor, and all users of tenants that require one, have to
+	// verify it before they get access
+	enrollment, err := a.mfaRepo.GetByUserID(ctx, user.ID, user.TenantID)
+	if err != nil && !errors.IsResourceNotFoundError(err) {
+		return nil, errors.Wrap(err, "failed to retrieve MFA enrollment")
+	}
+	mfaEnabled := err == nil && enrollment.IsEnabled()
+
+	if mfaEnabled || tenant.RequiresMFA() {
+		mfaToken, err := a.authService.GenerateMFAToken(ctx, user.ID, user.TenantID)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate MFA token")
+		}
+		return &LoginResult{
+			MFAToken:              mfaToken,
+			MFARequired:           true,
+			MFAEnrollmentRequired: !mfaEnabled,
+			PasswordExpired:       passwordExpired,
+		}, nil
+	}
+
+	// A successful sign-in clears the failed attempts counted so far. When a second factor follows,
+	// they are only cleared once it is verified, so that invalid codes keep counting towards the lockout.
+	if user.ResetFailedLogins() {
+		if err := a.userRepo.Update(ctx, user); err != nil {
+			return nil, errors.Wrap(err, "failed to update user")
+		}
+	}
+
+	result, err := a.issueTokens(ctx, user)
 	if err != nil {
-		return "", errors.Wrap(err, "failed to generate access token")
+		return nil, err
 	}
+	result.PasswordExpired = passwordExpired
+	return result, nil
+}
+
+// recordFailedLogin counts a failed sign-in and locks the account once the tenant's threshold is reached.
+// A lockout is recorded in the audit log as a security event.
+func (a *AuthUseCase) recordFailedLogin(ctx context.Context, user *models.User, policy models.PasswordPolicy, now time.Time) error {
+	log := logger.WithContext(ctx)
 
-	// Generate refresh token
-	refreshToken, err := a.authService.GenerateRefreshToken(ctx, user.ID, user.TenantID, a.refreshTokenExpiration)
+	locked := user.RecordFailedLogin(policy, now)
+	if err := a.userRepo.Update(ctx, user); err != nil {
+		return errors.Wrap(err, "failed to record failed sign-in")
+	}
+	if !locked {
+		return nil
+	}
+
+	log.Warn("account locked after too many failed sign-ins", "userID", user.ID, "tenantID", user.TenantID)
+	err := a.auditService.RecordAction(ctx, user.TenantID, user.ID, models.AuditActionLock, models.AuditResourceUser, user.ID, nil, map[string]interface{}{
+		"reason":       "too many failed sign-ins",
+		"attempts":     policy.LockoutThreshold,
+		"locked_until": user.LockedUntil,
+	})
 	if err != nil {
-		return "", errors.Wrap(err, "failed to generate refresh token")
+		log.WithError(err).Error("failed to record account lockout in audit log")
+		// Do not return error, the account has already been locked
 	}
 
-	return refreshToken, nil
+	return nil
 }
 
 // Register registers a new user in the system
@@ -183,10 +286,9 @@ func (a *AuthUseCase) Register(ctx context.Context, tenantID, username, email, p
 	// Create a new User instance
 	user := models.NewUser(username, email, tenantID)
 
-	// Set password using user.SetPassword
-	err = user.SetPassword(password)
-	if err != nil {
-		return "", errors.Wrap(err, "failed to set password")
+	// Set a password that meets the tenant's password policy
+	if err := user.ChangePassword(password, tenant.PasswordPolicy()); err != nil {
+		return "", errors.NewValidationError(err.Error())
 	}
 
 	// Add roles to the user
@@ -266,8 +368,11 @@ func (a *AuthUseCase) ChangePassword(ctx context.Context, userID, tenantID, curr
 	if newPassword == "" {
 		return errors.NewValidationError("new password is required")
 	}
-	if len(newPassword) < 8 {
-		return errors.NewValidationError("new password must be at least 8 characters long")
+
+	// The tenant's password policy applies to the new password
+	tenant, err := a.tenantRepo.GetByID(ctx, tenantID)
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve tenant")
 	}
 
 	// Get user from repository
@@ -294,9 +399,8 @@ func (a *AuthUseCase) ChangePassword(ctx context.Context, userID, tenantID, curr
 	}
 
 	// Set new password
-	err = user.SetPassword(newPassword)
-	if err != nil {
-		return errors.Wrap(err, "failed to set new password")
+	if err := user.ChangePassword(newPassword, tenant.PasswordPolicy()); err != nil {
+		return errors.NewValidationError(err.Error())
 	}
 
 	// Update user in repository
@@ -308,7 +412,8 @@ func (a *AuthUseCase) ChangePassword(ctx context.Context, userID, tenantID, curr
 	return nil
 }
 
-// ResetPassword resets a user's password (admin function)
+// ResetPassword resets a user's password (admin function). The new password has to meet the tenant's
+// password policy, and the reset unlocks an account locked by failed sign-ins.
 func (a *AuthUseCase) ResetPassword(ctx context.Context, adminUserID, userID, tenantID, newPassword string) error {
 	// Validate input parameters
 	if adminUserID == "" {
@@ -323,8 +428,11 @@ func (a *AuthUseCase) ResetPassword(ctx context.Context, adminUserID, userID, te
 	if newPassword == "" {
 		return errors.NewValidationError("new password is required")
 	}
-	if len(newPassword) < 8 {
-		return errors.NewValidationError("new password must be at least 8 characters long")
+
+	// The tenant's password policy applies to the new password
+	tenant, err := a.tenantRepo.GetByID(ctx, tenantID)
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve tenant")
 	}
 
 	// Get admin user from repository
@@ -360,11 +468,11 @@ func (a *AuthUseCase) ResetPassword(ctx context.Context, adminUserID, userID, te
 		return errors.NewAuthorizationError("user does not belong to the specified tenant")
 	}
 
-	// Set new password
-	err = user.SetPassword(newPassword)
-	if err != nil {
-		return errors.Wrap(err, "failed to set new password")
+	// Set new password and unlock the account
+	if err := user.ChangePassword(newPassword, tenant.PasswordPolicy()); err != nil {
+		return errors.NewValidationError(err.Error())
 	}
+	user.ResetFailedLogins()
 
 	// Update user in repository
 	err = a.userRepo.Update(ctx, user)
@@ -594,4 +702,325 @@ func (a *AuthUseCase) SetRefreshTokenExpiration(expiration time.Duration) {
 
 	// Update the authService refresh token expiration
 	a.authService.SetRefreshTokenExpiration(expiration)
-}
\ No newline at end of file
+}
+
+// SetGuestInvitations enables guest invitations, which are emailed through the sender as links
+// to the invite URL carrying the guest token, under the notification sender name of the tenant
+// when tenantSettings is set
+func (a *AuthUseCase) SetGuestInvitations(emailSender services.EmailSender, inviteURL string, tenantSettings services.TenantSettingsService) {
+	a.emailSender = emailSender
+	a.guestInviteURL = strings.TrimRight(inviteURL, "/")
+	a.tenantSettings = tenantSettings
+}
+
+// InviteGuest grants an external guest read-only, time-boxed access to a document or folder and
+// emails them a link containing a scoped guest token. The inviter must be able to read the resource.
+func (a *AuthUseCase) InviteGuest(ctx context.Context, inviterID, tenantID, guestEmail, resourceType, resourceID string, expiresIn time.Duration) (*models.GuestScope, error) {
+	// Validate input parameters
+	if inviterID == "" {
+		return nil, errors.NewValidationError("inviter ID is required")
+	}
+	if tenantID == "" {
+		return nil, errors.NewValidationError("tenant ID is required")
+	}
+	if guestEmail == "" {
+		return nil, errors.NewValidationError("guest email is required")
+	}
+	if resourceType == "" {
+		return nil, errors.NewValidationError("resource type is required")
+	}
+	if resourceID == "" {
+		return nil, errors.NewValidationError("resource ID is required")
+	}
+	if a.emailSender == nil || a.guestInviteURL == "" {
+		return nil, errors.NewValidationError("guest invitations are not enabled")
+	}
+
+	scope := models.NewGuestScope(tenantID, guestEmail, resourceType, resourceID, inviterID, time.Now().Add(expiresIn))
+	if err := scope.Validate(); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	// A user can only share what they can read themselves
+	hasAccess, err := a.authService.VerifyResourceAccess(ctx, inviterID, tenantID, resourceType, resourceID, models.PermissionTypeRead)
+	if err != nil {
+		return nil, errors.Wrap(err, "resource access verification failed")
+	}
+	if !hasAccess {
+		return nil, errors.NewAuthorizationError("user does not have access to the resource")
+	}
+
+	token, err := a.authService.GenerateGuestToken(ctx, scope)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate guest token")
+	}
+
+	// Email the invitation link to the guest
+	link := a.guestInviteURL + "?token=" + url.QueryEscape(token)
+	subject := "You have been invited to view a shared " + resourceType
+	body := fmt.Sprintf("You have been given read-only access to a shared %s.\n\nOpen it here: %s\n\nThis link expires on %s.\n",
+		resourceType, link, scope.ExpiresAt.UTC().Format(time.RFC1123))
+	var preferences models.TenantPreferences
+	if a.tenantSettings != nil {
+		// The invitation is still sent under the platform's name when the tenant cannot be read
+		if preferences, err = a.tenantSettings.Preferences(ctx, tenantID); err != nil {
+			logger.WithContext(ctx).WithError(err).Error("failed to get tenant preferences for guest invitation", "tenantID", tenantID)
+		}
+	}
+	if err := services.SendTenantEmail(ctx, a.emailSender, preferences, scope.GuestEmail, subject, body); err != nil {
+		return nil, errors.Wrap(err, "failed to send guest invitation")
+	}
+
+	return scope, nil
+}
+
+// ValidateGuestToken validates a guest token and returns the scope the guest is limited to
+func (a *AuthUseCase) ValidateGuestToken(ctx context.Context, token string) (*models.GuestScope, error) {
+	// Validate input parameters
+	if token == "" {
+		return nil, errors.NewValidationError("token is required")
+	}
+
+	// Call authService.ValidateGuestToken to validate the token
+	scope, err := a.authService.ValidateGuestToken(ctx, token)
+	if err != nil {
+		return nil, errors.Wrap(err, "guest token validation failed")
+	}
+
+	return scope, nil
+}
+
+// ValidateMFAToken validates a partial-auth token and returns the user and tenant it was issued to.
+// Users of tenants that require MFA use it to enroll before they have full tokens.
+func (a *AuthUseCase) ValidateMFAToken(ctx context.Context, mfaToken string) (string, string, error) {
+	// Validate input parameters
+	if mfaToken == "" {
+		return "", "", errors.NewValidationError("MFA token is required")
+	}
+
+	// Call authService.ValidateMFAToken to validate the token
+	userID, tenantID, err := a.authService.ValidateMFAToken(ctx, mfaToken)
+	if err != nil {
+		return "", "", errors.Wrap(err, "MFA token validation failed")
+	}
+
+	return userID, tenantID, nil
+}
+
+// EnrollMFA starts TOTP enrollment for a user and returns the secret, the provisioning URI for
+// their authenticator app and their backup codes. The enrollment stays pending, and replaces any
+// pending enrollment, until the user verifies a first code with VerifyMFA or ConfirmMFA.
+func (a *AuthUseCase) EnrollMFA(ctx context.Context, userID, tenantID string) (*MFASetup, error) {
+	// Validate input parameters
+	if userID == "" {
+		return nil, errors.NewValidationError("user ID is required")
+	}
+	if tenantID == "" {
+		return nil, errors.NewValidationError("tenant ID is required")
+	}
+
+	user, err := a.getActiveUser(ctx, userID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	// An enabled second factor cannot be replaced by someone who only knows the password
+	existing, err := a.mfaRepo.GetByUserID(ctx, userID, tenantID)
+	if err != nil && !errors.IsResourceNotFoundError(err) {
+		return nil, errors.Wrap(err, "failed to retrieve MFA enrollment")
+	}
+	if err == nil && existing.IsEnabled() {
+		return nil, errors.NewValidationError("multi-factor authentication is already enabled")
+	}
+
+	enrollment, backupCodes, err := models.NewMFAEnrollment(userID, tenantID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate MFA secret")
+	}
+
+	if _, err := a.mfaRepo.Create(ctx, enrollment); err != nil {
+		return nil, errors.Wrap(err, "failed to create MFA enrollment")
+	}
+
+	return &MFASetup{
+		Secret:          enrollment.Secret,
+		ProvisioningURI: enrollment.ProvisioningURI(mfaIssuer, user.Email),
+		BackupCodes:     backupCodes,
+	}, nil
+}
+
+// ConfirmMFA enables a signed-in user's pending enrollment once they verify a first TOTP code
+func (a *AuthUseCase) ConfirmMFA(ctx context.Context, userID, tenantID, code string) error {
+	// Validate input parameters
+	if userID == "" {
+		return errors.NewValidationError("user ID is required")
+	}
+	if tenantID == "" {
+		return errors.NewValidationError("tenant ID is required")
+	}
+	if code == "" {
+		return errors.NewValidationError("code is required")
+	}
+
+	enrollment, err := a.getMFAEnrollment(ctx, userID, tenantID)
+	if err != nil {
+		return err
+	}
+	if enrollment.IsEnabled() {
+		return errors.NewValidationError("multi-factor authentication is already enabled")
+	}
+
+	ok, _, err := a.verifyMFACode(ctx, enrollment, code)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.NewAuthenticationError("invalid verification code")
+	}
+
+	return nil
+}
+
+// VerifyMFA completes a sign-in by verifying the second factor of the user a partial-auth token
+// was issued to, and returns their access and refresh tokens. Enabled enrollments accept a TOTP
+// code or a backup code; a pending enrollment is enabled by its first valid TOTP code.
+// After too many invalid codes the partial-auth token is revoked. Invalid codes also count as failed
+// sign-ins, so that repeated rounds of guessing lock the account like wrong passwords do.
+func (a *AuthUseCase) VerifyMFA(ctx context.Context, mfaToken, code string) (*LoginResult, error) {
+	// Validate input parameters
+	if mfaToken == "" {
+		return nil, errors.NewValidationError("MFA token is required")
+	}
+	if code == "" {
+		return nil, errors.NewValidationError("code is required")
+	}
+
+	userID, tenantID, err := a.authService.ValidateMFAToken(ctx, mfaToken)
+	if err != nil {
+		return nil, errors.Wrap(err, "MFA token validation failed")
+	}
+
+	user, err := a.getActiveUser(ctx, userID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	tenant, err := a.tenantRepo.GetByID(ctx, tenantID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve tenant")
+	}
+	policy := tenant.PasswordPolicy()
+	now := time.Now()
+	if user.IsLocked(now) {
+		return nil, errors.NewAuthenticationError("account is temporarily locked")
+	}
+
+	enrollment, err := a.getMFAEnrollment(ctx, userID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	ok, limitReached, err := a.verifyMFACode(ctx, enrollment, code)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		if err := a.recordFailedLogin(ctx, user, policy, now); err != nil {
+			return nil, err
+		}
+		if limitReached || user.IsLocked(now) {
+			// The user has to enter their password again before trying more codes
+			if err := a.authService.InvalidateToken(ctx, mfaToken); err != nil {
+				return nil, errors.Wrap(err, "failed to revoke MFA token")
+			}
+			return nil, errors.NewAuthenticationError("too many invalid verification codes, sign in again")
+		}
+		return nil, errors.NewAuthenticationError("invalid verification code")
+	}
+
+	// The completed sign-in clears the failed attempts counted so far
+	if user.ResetFailedLogins() {
+		if err := a.userRepo.Update(ctx, user); err != nil {
+			return nil, errors.Wrap(err, "failed to update user")
+		}
+	}
+
+	// A partial-auth token is exchanged for full tokens only once
+	if err := a.authService.InvalidateToken(ctx, mfaToken); err != nil {
+		return nil, errors.Wrap(err, "failed to revoke MFA token")
+	}
+
+	return a.issueTokens(ctx, user)
+}
+
+// issueTokens starts a session for a signed-in user and returns its access and refresh tokens
+func (a *AuthUseCase) issueTokens(ctx context.Context, user *models.User) (*LoginResult, error) {
+	token, refreshToken, err := a.authService.CreateSession(ctx, user.ID, user.TenantID, user.Roles)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create session")
+	}
+
+	return &LoginResult{
+		AccessToken:  token,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+// getActiveUser retrieves a user of the tenant and checks that their account is active
+func (a *AuthUseCase) getActiveUser(ctx context.Context, userID, tenantID string) (*models.User, error) {
+	user, err := a.userRepo.GetByID(ctx, userID, tenantID)
+	if err != nil {
+		if errors.IsResourceNotFoundError(err) {
+			return nil, errors.NewAuthenticationError("user not found")
+		}
+		return nil, errors.Wrap(err, "failed to retrieve user")
+	}
+
+	if user.TenantID != tenantID {
+		return nil, errors.NewAuthenticationError("user does not belong to the specified tenant")
+	}
+	if !user.IsActive() {
+		return nil, errors.NewAuthenticationError("user account is not active")
+	}
+
+	return user, nil
+}
+
+// getMFAEnrollment retrieves a user's MFA enrollment, which must exist
+func (a *AuthUseCase) getMFAEnrollment(ctx context.Context, userID, tenantID string) (*models.MFAEnrollment, error) {
+	enrollment, err := a.mfaRepo.GetByUserID(ctx, userID, tenantID)
+	if err != nil {
+		if errors.IsResourceNotFoundError(err) {
+			return nil, errors.NewAuthenticationError("multi-factor authentication is not enrolled")
+		}
+		return nil, errors.Wrap(err, "failed to retrieve MFA enrollment")
+	}
+
+	return enrollment, nil
+}
+
+// verifyMFACode checks a code against an enrollment and stores the outcome. Backup codes are only
+// accepted once the enrollment is enabled, and a valid code enables a pending enrollment.
+// It reports whether the code is valid and, if not, whether the attempt limit has been reached.
+func (a *AuthUseCase) verifyMFACode(ctx context.Context, enrollment *models.MFAEnrollment, code string) (bool, bool, error) {
+	ok := enrollment.VerifyTOTP(code, time.Now())
+	if !ok && enrollment.IsEnabled() {
+		ok = enrollment.UseBackupCode(code)
+	}
+
+	limitReached := false
+	if ok {
+		enrollment.FailedAttempts = 0
+		if !enrollment.IsEnabled() {
+			enrollment.Enable()
+		}
+	} else {
+		limitReached = enrollment.RecordFailedAttempt()
+	}
+
+	if err := a.mfaRepo.Update(ctx, enrollment); err != nil {
+		return false, false, errors.Wrap(err, "failed to update MFA enrollment")
+	}
+
+	return ok, limitReached, nil
+}
@@ -19,14 +19,28 @@ import (
 
 // Helper function to set up an AuthUseCase instance with mocked dependencies
 func setupAuthUseCase(t *testing.T) (*mocks.AuthService, *mocks.UserRepository, *mocks.TenantRepository, *AuthUseCase) {
+	mockAuthService, mockUserRepo, mockTenantRepo, mockMFARepo, useCase := setupAuthUseCaseWithMFA(t)
+
+	// Users are not enrolled in multi-factor authentication unless a test says otherwise
+	mockMFARepo.On("GetByUserID", mock.Anything, mock.Anything, mock.Anything).Return(nil, apperrors.NewResourceNotFoundError("MFA enrollment not found")).Maybe()
+
+	return mockAuthService, mockUserRepo, mockTenantRepo, useCase
+}
+
+// Helper function to set up an AuthUseCase instance that also exposes the mocked MFA repository
+func setupAuthUseCaseWithMFA(t *testing.T) (*mocks.AuthService, *mocks.UserRepository, *mocks.TenantRepository, *mocks.MFARepository, *AuthUseCase) {
 	mockAuthService := new(mocks.AuthService)
 	mockUserRepo := new(mocks.UserRepository)
 	mockTenantRepo := new(mocks.TenantRepository)
-	
-	useCase := NewAuthUseCase(mockAuthService, mockUserRepo, mockTenantRepo)
+	mockMFARepo := new(mocks.MFARepository)
+	mockAuditService := new(MockAuditService)
+	mockAuditService.On("RecordAction", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	useCase, err := NewAuthUseCase(mockAuthService, mockUserRepo, mockTenantRepo, mockMFARepo, mockAuditService)
+	require.NoError(t, err)
 	require.NotNil(t, useCase)
-	
-	return mockAuthService, mockUserRepo, mockTenantRepo, useCase
+
+	return mockAuthService, mockUserRepo, mockTenantRepo, mockMFARepo, useCase
 }
 
 // Helper function to create a test user with specified parameters
@@ -51,13 +65,25 @@ func TestNewAuthUseCase(t *testing.T) {
 	mockAuthService := new(mocks.AuthService)
 	mockUserRepo := new(mocks.UserRepository)
 	mockTenantRepo := new(mocks.TenantRepository)
+	mockMFARepo := new(mocks.MFARepository)
+	mockAuditService := new(MockAuditService)
 	
-	useCase := NewAuthUseCase(mockAuthService, mockUserRepo, mockTenantRepo)
+	useCase, err := NewAuthUseCase(mockAuthService, mockUserRepo, mockTenantRepo, mockMFARepo, mockAuditService)
 	
+	assert.NoError(t, err)
 	assert.NotNil(t, useCase)
 	assert.Equal(t, mockAuthService, useCase.authService)
 	assert.Equal(t, mockUserRepo, useCase.userRepo)
 	assert.Equal(t, mockTenantRepo, useCase.tenantRepo)
+	assert.Equal(t, mockMFARepo, useCase.mfaRepo)
+	assert.Equal(t, mockAuditService, useCase.auditService)
+	
+	// Test that the audit service is required
+	useCase, err = NewAuthUseCase(mockAuthService, mockUserRepo, mockTenantRepo, mockMFARepo, nil)
+	
+	assert.Error(t, err)
+	assert.Nil(t, useCase)
+	assert.True(t, apperrors.IsValidationError(err))
 }
 
 // Tests successful login with valid credentials
@@ -85,16 +111,16 @@ func TestLogin_Success(t *testing.T) {
 	// Mock token generation
 	accessToken := "access_token"
 	refreshToken := "refresh_token"
-	mockAuthService.On("GenerateToken", mock.Anything, userID, tenantID, roles, mock.Anything).Return(accessToken, nil)
-	mockAuthService.On("GenerateRefreshToken", mock.Anything, userID, tenantID, mock.Anything).Return(refreshToken, nil)
+	mockAuthService.On("CreateSession", mock.Anything, userID, tenantID, roles).Return(accessToken, refreshToken, nil)
 	
 	// Call the method being tested
-	resultAccess, resultRefresh, err := useCase.Login(context.Background(), tenantID, username, password)
+	result, err := useCase.Login(context.Background(), tenantID, username, password)
 	
 	// Assert results
 	assert.NoError(t, err)
-	assert.Equal(t, accessToken, resultAccess)
-	assert.Equal(t, refreshToken, resultRefresh)
+	assert.Equal(t, accessToken, result.AccessToken)
+	assert.Equal(t, refreshToken, result.RefreshToken)
+	assert.False(t, result.MFARequired)
 	
 	// Verify expectations
 	mockTenantRepo.AssertExpectations(t)
@@ -111,12 +137,11 @@ func TestLogin_InvalidTenant(t *testing.T) {
 	mockTenantRepo.On("GetByID", mock.Anything, tenantID).Return(nil, errors.New("tenant not found"))
 	
 	// Call the method being tested
-	resultAccess, resultRefresh, err := useCase.Login(context.Background(), tenantID, "username", "password")
+	result, err := useCase.Login(context.Background(), tenantID, "username", "password")
 	
 	// Assert results
 	assert.Error(t, err)
-	assert.Empty(t, resultAccess)
-	assert.Empty(t, resultRefresh)
+	assert.Nil(t, result)
 	assert.True(t, apperrors.IsAuthenticationError(err))
 	
 	// Verify user repository was not called
@@ -136,12 +161,11 @@ func TestLogin_InactiveTenant(t *testing.T) {
 	mockTenantRepo.On("GetByID", mock.Anything, tenantID).Return(tenant, nil)
 	
 	// Call the method being tested
-	resultAccess, resultRefresh, err := useCase.Login(context.Background(), tenantID, "username", "password")
+	result, err := useCase.Login(context.Background(), tenantID, "username", "password")
 	
 	// Assert results
 	assert.Error(t, err)
-	assert.Empty(t, resultAccess)
-	assert.Empty(t, resultRefresh)
+	assert.Nil(t, result)
 	assert.True(t, apperrors.IsAuthenticationError(err))
 	
 	// Verify user repository was not called
@@ -163,16 +187,15 @@ func TestLogin_UserNotFound(t *testing.T) {
 	mockUserRepo.On("GetByEmail", mock.Anything, username, tenantID).Return(nil, errors.New("user not found"))
 	
 	// Call the method being tested
-	resultAccess, resultRefresh, err := useCase.Login(context.Background(), tenantID, username, "password")
+	result, err := useCase.Login(context.Background(), tenantID, username, "password")
 	
 	// Assert results
 	assert.Error(t, err)
-	assert.Empty(t, resultAccess)
-	assert.Empty(t, resultRefresh)
+	assert.Nil(t, result)
 	assert.True(t, apperrors.IsAuthenticationError(err))
 	
 	// Verify auth service was not called
-	mockAuthService.AssertNotCalled(t, "GenerateToken")
+	mockAuthService.AssertNotCalled(t, "CreateSession")
 }
 
 // Tests login with user from different tenant
@@ -194,16 +217,15 @@ func TestLogin_WrongTenant(t *testing.T) {
 	mockUserRepo.On("GetByUsername", mock.Anything, username, tenantID).Return(user, nil)
 	
 	// Call the method being tested
-	resultAccess, resultRefresh, err := useCase.Login(context.Background(), tenantID, username, "password")
+	result, err := useCase.Login(context.Background(), tenantID, username, "password")
 	
 	// Assert results
 	assert.Error(t, err)
-	assert.Empty(t, resultAccess)
-	assert.Empty(t, resultRefresh)
+	assert.Nil(t, result)
 	assert.True(t, apperrors.IsAuthenticationError(err))
 	
 	// Verify auth service was not called
-	mockAuthService.AssertNotCalled(t, "GenerateToken")
+	mockAuthService.AssertNotCalled(t, "CreateSession")
 }
 
 // Tests login with inactive user
@@ -225,16 +247,15 @@ func TestLogin_InactiveUser(t *testing.T) {
 	mockUserRepo.On("GetByUsername", mock.Anything, username, tenantID).Return(user, nil)
 	
 	// Call the method being tested
-	resultAccess, resultRefresh, err := useCase.Login(context.Background(), tenantID, username, "password")
+	result, err := useCase.Login(context.Background(), tenantID, username, "password")
 	
 	// Assert results
 	assert.Error(t, err)
-	assert.Empty(t, resultAccess)
-	assert.Empty(t, resultRefresh)
+	assert.Nil(t, result)
 	assert.True(t, apperrors.IsAuthenticationError(err))
 	
 	// Verify auth service was not called
-	mockAuthService.AssertNotCalled(t, "GenerateToken")
+	mockAuthService.AssertNotCalled(t, "CreateSession")
 }
 
 // Tests login with invalid password
@@ -255,21 +276,21 @@ func TestLogin_InvalidPassword(t *testing.T) {
 	// Set up expectations
 	mockTenantRepo.On("GetByID", mock.Anything, tenantID).Return(tenant, nil)
 	mockUserRepo.On("GetByUsername", mock.Anything, username, tenantID).Return(user, nil)
+	mockUserRepo.On("Update", mock.Anything, user).Return(nil)
 	
 	// Set up correct password for the user
 	user.SetPassword(correctPassword)
 	
 	// Call the method being tested
-	resultAccess, resultRefresh, err := useCase.Login(context.Background(), tenantID, username, wrongPassword)
+	result, err := useCase.Login(context.Background(), tenantID, username, wrongPassword)
 	
 	// Assert results
 	assert.Error(t, err)
-	assert.Empty(t, resultAccess)
-	assert.Empty(t, resultRefresh)
+	assert.Nil(t, result)
 	assert.True(t, apperrors.IsAuthenticationError(err))
 	
 	// Verify auth service was not called
-	mockAuthService.AssertNotCalled(t, "GenerateToken")
+	mockAuthService.AssertNotCalled(t, "CreateSession")
 }
 
 // Tests successful user registration
@@ -1148,4 +1169,443 @@ func TestSetRefreshTokenExpiration(t *testing.T) {
 	
 	// Verify auth service was called
 	mockAuthService.AssertExpectations(t)
-}
\ No newline at end of file
+}
+
+// Helper function to create an enabled MFA enrollment and its backup codes for a user
+func createTestMFAEnrollment(t *testing.T, userID, tenantID string) (*models.MFAEnrollment, []string) {
+	enrollment, backupCodes, err := models.NewMFAEnrollment(userID, tenantID)
+	require.NoError(t, err)
+	enrollment.ID = "mfa-123"
+	enrollment.Enable()
+	return enrollment, backupCodes
+}
+
+// Tests that users with MFA enabled only receive a partial-auth token after their password
+func TestLogin_MFAEnabled(t *testing.T) {
+	mockAuthService, mockUserRepo, mockTenantRepo, mockMFARepo, useCase := setupAuthUseCaseWithMFA(t)
+	
+	// Create test data
+	tenantID := "tenant-123"
+	tenant := createTestTenant(tenantID, "Test Tenant")
+	user := createTestUser("user-123", "testuser", "test@example.com", tenantID, []string{"reader"})
+	user.SetPassword("password123")
+	enrollment, _ := createTestMFAEnrollment(t, user.ID, tenantID)
+	
+	// Set up expectations
+	mockTenantRepo.On("GetByID", mock.Anything, tenantID).Return(tenant, nil)
+	mockUserRepo.On("GetByUsername", mock.Anything, "testuser", tenantID).Return(user, nil)
+	mockMFARepo.On("GetByUserID", mock.Anything, user.ID, tenantID).Return(enrollment, nil)
+	mockAuthService.On("GenerateMFAToken", mock.Anything, user.ID, tenantID).Return("mfa_token", nil)
+	
+	// Call the method being tested
+	result, err := useCase.Login(context.Background(), tenantID, "testuser", "password123")
+	
+	// Assert results
+	assert.NoError(t, err)
+	assert.True(t, result.MFARequired)
+	assert.False(t, result.MFAEnrollmentRequired)
+	assert.Equal(t, "mfa_token", result.MFAToken)
+	assert.Empty(t, result.AccessToken)
+	assert.Empty(t, result.RefreshToken)
+	
+	// Verify no full tokens were issued
+	mockAuthService.AssertNotCalled(t, "CreateSession", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// Tests that users of tenants requiring MFA must enroll before receiving full tokens
+func TestLogin_TenantRequiresMFA(t *testing.T) {
+	mockAuthService, mockUserRepo, mockTenantRepo, mockMFARepo, useCase := setupAuthUseCaseWithMFA(t)
+	
+	// Create test data
+	tenantID := "tenant-123"
+	tenant := createTestTenant(tenantID, "Test Tenant")
+	tenant.SetSetting(models.TenantSettingMFARequired, "true")
+	user := createTestUser("user-123", "testuser", "test@example.com", tenantID, []string{"reader"})
+	user.SetPassword("password123")
+	
+	// Set up expectations
+	mockTenantRepo.On("GetByID", mock.Anything, tenantID).Return(tenant, nil)
+	mockUserRepo.On("GetByUsername", mock.Anything, "testuser", tenantID).Return(user, nil)
+	mockMFARepo.On("GetByUserID", mock.Anything, user.ID, tenantID).Return(nil, apperrors.NewResourceNotFoundError("MFA enrollment not found"))
+	mockAuthService.On("GenerateMFAToken", mock.Anything, user.ID, tenantID).Return("mfa_token", nil)
+	
+	// Call the method being tested
+	result, err := useCase.Login(context.Background(), tenantID, "testuser", "password123")
+	
+	// Assert results
+	assert.NoError(t, err)
+	assert.True(t, result.MFARequired)
+	assert.True(t, result.MFAEnrollmentRequired)
+	assert.Equal(t, "mfa_token", result.MFAToken)
+	mockAuthService.AssertNotCalled(t, "CreateSession", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// Tests enrolling a user in MFA
+func TestEnrollMFA_Success(t *testing.T) {
+	_, mockUserRepo, _, mockMFARepo, useCase := setupAuthUseCaseWithMFA(t)
+	
+	// Create test data
+	tenantID := "tenant-123"
+	user := createTestUser("user-123", "testuser", "test@example.com", tenantID, []string{"reader"})
+	
+	// Set up expectations
+	mockUserRepo.On("GetByID", mock.Anything, user.ID, tenantID).Return(user, nil)
+	mockMFARepo.On("GetByUserID", mock.Anything, user.ID, tenantID).Return(nil, apperrors.NewResourceNotFoundError("MFA enrollment not found"))
+	mockMFARepo.On("Create", mock.Anything, mock.MatchedBy(func(enrollment *models.MFAEnrollment) bool {
+		return enrollment.UserID == user.ID && !enrollment.IsEnabled() && len(enrollment.BackupCodeHashes) == models.MFABackupCodeCount
+	})).Return("mfa-123", nil)
+	
+	// Call the method being tested
+	setup, err := useCase.EnrollMFA(context.Background(), user.ID, tenantID)
+	
+	// Assert results
+	assert.NoError(t, err)
+	assert.NotEmpty(t, setup.Secret)
+	assert.Contains(t, setup.ProvisioningURI, "otpauth://totp/")
+	assert.Contains(t, setup.ProvisioningURI, "secret="+setup.Secret)
+	assert.Len(t, setup.BackupCodes, models.MFABackupCodeCount)
+	mockMFARepo.AssertExpectations(t)
+}
+
+// Tests that an enabled second factor cannot be replaced by enrolling again
+func TestEnrollMFA_AlreadyEnabled(t *testing.T) {
+	_, mockUserRepo, _, mockMFARepo, useCase := setupAuthUseCaseWithMFA(t)
+	
+	// Create test data
+	tenantID := "tenant-123"
+	user := createTestUser("user-123", "testuser", "test@example.com", tenantID, []string{"reader"})
+	enrollment, _ := createTestMFAEnrollment(t, user.ID, tenantID)
+	
+	// Set up expectations
+	mockUserRepo.On("GetByID", mock.Anything, user.ID, tenantID).Return(user, nil)
+	mockMFARepo.On("GetByUserID", mock.Anything, user.ID, tenantID).Return(enrollment, nil)
+	
+	// Call the method being tested
+	setup, err := useCase.EnrollMFA(context.Background(), user.ID, tenantID)
+	
+	// Assert results
+	assert.Error(t, err)
+	assert.Nil(t, setup)
+	assert.True(t, apperrors.IsValidationError(err))
+	mockMFARepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+// Tests that a valid TOTP code exchanges a partial-auth token for full tokens
+func TestVerifyMFA_Success(t *testing.T) {
+	mockAuthService, mockUserRepo, mockTenantRepo, mockMFARepo, useCase := setupAuthUseCaseWithMFA(t)
+	
+	// Create test data
+	tenantID := "tenant-123"
+	tenant := createTestTenant(tenantID, "Test Tenant")
+	user := createTestUser("user-123", "testuser", "test@example.com", tenantID, []string{"reader"})
+	enrollment, _ := createTestMFAEnrollment(t, user.ID, tenantID)
+	code, err := models.GenerateTOTP(enrollment.Secret, time.Now())
+	require.NoError(t, err)
+	
+	// Set up expectations
+	mockAuthService.On("ValidateMFAToken", mock.Anything, "mfa_token").Return(user.ID, tenantID, nil)
+	mockUserRepo.On("GetByID", mock.Anything, user.ID, tenantID).Return(user, nil)
+	mockTenantRepo.On("GetByID", mock.Anything, tenantID).Return(tenant, nil)
+	mockMFARepo.On("GetByUserID", mock.Anything, user.ID, tenantID).Return(enrollment, nil)
+	mockMFARepo.On("Update", mock.Anything, enrollment).Return(nil)
+	mockAuthService.On("InvalidateToken", mock.Anything, "mfa_token").Return(nil)
+	mockAuthService.On("CreateSession", mock.Anything, user.ID, tenantID, user.Roles).Return("access_token", "refresh_token", nil)
+	
+	// Call the method being tested
+	result, err := useCase.VerifyMFA(context.Background(), "mfa_token", code)
+	
+	// Assert results
+	assert.NoError(t, err)
+	assert.Equal(t, "access_token", result.AccessToken)
+	assert.Equal(t, "refresh_token", result.RefreshToken)
+	assert.NotZero(t, enrollment.LastUsedStep)
+	mockAuthService.AssertExpectations(t)
+	
+	// The same code cannot be used twice
+	assert.False(t, enrollment.VerifyTOTP(code, time.Now()))
+}
+
+// Tests that a backup code works once
+func TestVerifyMFA_BackupCode(t *testing.T) {
+	mockAuthService, mockUserRepo, mockTenantRepo, mockMFARepo, useCase := setupAuthUseCaseWithMFA(t)
+	
+	// Create test data
+	tenantID := "tenant-123"
+	tenant := createTestTenant(tenantID, "Test Tenant")
+	user := createTestUser("user-123", "testuser", "test@example.com", tenantID, []string{"reader"})
+	enrollment, backupCodes := createTestMFAEnrollment(t, user.ID, tenantID)
+	
+	// Set up expectations
+	mockAuthService.On("ValidateMFAToken", mock.Anything, "mfa_token").Return(user.ID, tenantID, nil)
+	mockUserRepo.On("GetByID", mock.Anything, user.ID, tenantID).Return(user, nil)
+	mockTenantRepo.On("GetByID", mock.Anything, tenantID).Return(tenant, nil)
+	mockMFARepo.On("GetByUserID", mock.Anything, user.ID, tenantID).Return(enrollment, nil)
+	mockMFARepo.On("Update", mock.Anything, enrollment).Return(nil)
+	mockAuthService.On("InvalidateToken", mock.Anything, "mfa_token").Return(nil)
+	mockAuthService.On("CreateSession", mock.Anything, user.ID, tenantID, user.Roles).Return("access_token", "refresh_token", nil)
+	
+	// Call the method being tested
+	result, err := useCase.VerifyMFA(context.Background(), "mfa_token", backupCodes[0])
+	
+	// Assert results
+	assert.NoError(t, err)
+	assert.Equal(t, "access_token", result.AccessToken)
+	assert.Len(t, enrollment.BackupCodeHashes, models.MFABackupCodeCount-1)
+	
+	// The backup code is consumed
+	assert.False(t, enrollment.UseBackupCode(backupCodes[0]))
+}
+
+// Tests that repeated invalid codes revoke the partial-auth token
+func TestVerifyMFA_TooManyInvalidCodes(t *testing.T) {
+	mockAuthService, mockUserRepo, mockTenantRepo, mockMFARepo, useCase := setupAuthUseCaseWithMFA(t)
+	
+	// Create test data
+	tenantID := "tenant-123"
+	tenant := createTestTenant(tenantID, "Test Tenant")
+	user := createTestUser("user-123", "testuser", "test@example.com", tenantID, []string{"reader"})
+	enrollment, _ := createTestMFAEnrollment(t, user.ID, tenantID)
+	enrollment.FailedAttempts = models.MFAMaxFailedAttempts - 2
+	
+	// Set up expectations
+	mockAuthService.On("ValidateMFAToken", mock.Anything, "mfa_token").Return(user.ID, tenantID, nil)
+	mockUserRepo.On("GetByID", mock.Anything, user.ID, tenantID).Return(user, nil)
+	mockTenantRepo.On("GetByID", mock.Anything, tenantID).Return(tenant, nil)
+	mockUserRepo.On("Update", mock.Anything, user).Return(nil)
+	mockMFARepo.On("GetByUserID", mock.Anything, user.ID, tenantID).Return(enrollment, nil)
+	mockMFARepo.On("Update", mock.Anything, enrollment).Return(nil)
+	mockAuthService.On("InvalidateToken", mock.Anything, "mfa_token").Return(nil)
+	
+	// An invalid code below the limit is rejected
+	result, err := useCase.VerifyMFA(context.Background(), "mfa_token", "not-a-code")
+	assert.Nil(t, result)
+	assert.True(t, apperrors.IsAuthenticationError(err))
+	mockAuthService.AssertNotCalled(t, "InvalidateToken", mock.Anything, "mfa_token")
+	
+	// The invalid code that reaches the limit revokes the token
+	result, err = useCase.VerifyMFA(context.Background(), "mfa_token", "not-a-code")
+	assert.Nil(t, result)
+	assert.True(t, apperrors.IsAuthenticationError(err))
+	mockAuthService.AssertCalled(t, "InvalidateToken", mock.Anything, "mfa_token")
+	mockAuthService.AssertNotCalled(t, "CreateSession", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// Tests that invalid codes count towards the account lockout across sign-ins, so that signing in
+// again with the password does not give unlimited rounds of guessing
+func TestVerifyMFA_InvalidCodesLockAccount(t *testing.T) {
+	mockAuthService, mockUserRepo, mockTenantRepo, mockMFARepo, useCase := setupAuthUseCaseWithMFA(t)
+	
+	// Create test data
+	tenantID := "tenant-123"
+	tenant := createTestTenant(tenantID, "Test Tenant")
+	tenant.SetSetting(models.TenantSettingLockoutThreshold, "3")
+	user := createTestUser("user-123", "testuser", "test@example.com", tenantID, []string{"reader"})
+	user.SetPassword("password123")
+	enrollment, _ := createTestMFAEnrollment(t, user.ID, tenantID)
+	
+	// Set up expectations
+	mockTenantRepo.On("GetByID", mock.Anything, tenantID).Return(tenant, nil)
+	mockUserRepo.On("GetByUsername", mock.Anything, "testuser", tenantID).Return(user, nil)
+	mockUserRepo.On("GetByID", mock.Anything, user.ID, tenantID).Return(user, nil)
+	mockUserRepo.On("Update", mock.Anything, user).Return(nil)
+	mockMFARepo.On("GetByUserID", mock.Anything, user.ID, tenantID).Return(enrollment, nil)
+	mockMFARepo.On("Update", mock.Anything, enrollment).Return(nil)
+	mockAuthService.On("GenerateMFAToken", mock.Anything, user.ID, tenantID).Return("mfa_token", nil)
+	mockAuthService.On("ValidateMFAToken", mock.Anything, "mfa_token").Return(user.ID, tenantID, nil)
+	mockAuthService.On("InvalidateToken", mock.Anything, "mfa_token").Return(nil)
+	
+	// Each round of invalid codes starts with a correct password, which does not clear the count
+	for i := 0; i < 3; i++ {
+		result, err := useCase.Login(context.Background(), tenantID, "testuser", "password123")
+		require.NoError(t, err)
+		assert.True(t, result.MFARequired)
+	
+		_, err = useCase.VerifyMFA(context.Background(), "mfa_token", "not-a-code")
+		assert.True(t, apperrors.IsAuthenticationError(err))
+	}
+	assert.True(t, user.IsLocked(time.Now()))
+	mockAuthService.AssertCalled(t, "InvalidateToken", mock.Anything, "mfa_token")
+	
+	// The locked account can neither sign in nor verify codes
+	_, err := useCase.Login(context.Background(), tenantID, "testuser", "password123")
+	assert.True(t, apperrors.IsAuthenticationError(err))
+	code, err := models.GenerateTOTP(enrollment.Secret, time.Now())
+	require.NoError(t, err)
+	_, err = useCase.VerifyMFA(context.Background(), "mfa_token", code)
+	assert.True(t, apperrors.IsAuthenticationError(err))
+	mockAuthService.AssertNotCalled(t, "CreateSession", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// Tests that a pending enrollment is enabled by its first code and does not accept backup codes
+func TestConfirmMFA_PendingEnrollment(t *testing.T) {
+	_, _, _, mockMFARepo, useCase := setupAuthUseCaseWithMFA(t)
+	
+	// Create test data
+	tenantID := "tenant-123"
+	enrollment, backupCodes, err := models.NewMFAEnrollment("user-123", tenantID)
+	require.NoError(t, err)
+	
+	// Set up expectations
+	mockMFARepo.On("GetByUserID", mock.Anything, "user-123", tenantID).Return(enrollment, nil)
+	mockMFARepo.On("Update", mock.Anything, enrollment).Return(nil)
+	
+	// Backup codes cannot confirm an enrollment
+	err = useCase.ConfirmMFA(context.Background(), "user-123", tenantID, backupCodes[0])
+	assert.True(t, apperrors.IsAuthenticationError(err))
+	assert.False(t, enrollment.IsEnabled())
+	
+	// A TOTP code confirms it
+	code, err := models.GenerateTOTP(enrollment.Secret, time.Now())
+	require.NoError(t, err)
+	err = useCase.ConfirmMFA(context.Background(), "user-123", tenantID, code)
+	assert.NoError(t, err)
+	assert.True(t, enrollment.IsEnabled())
+}
+
+// Tests that an account is locked after too many failed sign-ins within the window and that the lockout is audited
+func TestLogin_LockoutAfterFailedAttempts(t *testing.T) {
+	mockAuthService := new(mocks.AuthService)
+	mockUserRepo := new(mocks.UserRepository)
+	mockTenantRepo := new(mocks.TenantRepository)
+	mockMFARepo := new(mocks.MFARepository)
+	mockAuditService := new(MockAuditService)
+	useCase, err := NewAuthUseCase(mockAuthService, mockUserRepo, mockTenantRepo, mockMFARepo, mockAuditService)
+	require.NoError(t, err)
+
+	// Create test data
+	tenantID := "tenant-123"
+	tenant := createTestTenant(tenantID, "Test Tenant")
+	tenant.SetSetting(models.TenantSettingLockoutThreshold, "3")
+	user := createTestUser("user-123", "testuser", "test@example.com", tenantID, []string{"reader"})
+	user.SetPassword("password123")
+
+	// Set up expectations
+	mockTenantRepo.On("GetByID", mock.Anything, tenantID).Return(tenant, nil)
+	mockUserRepo.On("GetByUsername", mock.Anything, "testuser", tenantID).Return(user, nil)
+	mockUserRepo.On("Update", mock.Anything, user).Return(nil)
+	mockAuditService.On("RecordAction", mock.Anything, tenantID, user.ID, models.AuditActionLock, models.AuditResourceUser, user.ID, mock.Anything, mock.Anything).Return(nil).Once()
+
+	// Failed attempts below the threshold do not lock the account
+	for i := 0; i < 2; i++ {
+		_, err := useCase.Login(context.Background(), tenantID, "testuser", "wrongpassword")
+		assert.True(t, apperrors.IsAuthenticationError(err))
+	}
+	assert.False(t, user.IsLocked(time.Now()))
+	mockAuditService.AssertNotCalled(t, "RecordAction", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	// The attempt reaching the threshold locks it
+	_, err = useCase.Login(context.Background(), tenantID, "testuser", "wrongpassword")
+	assert.True(t, apperrors.IsAuthenticationError(err))
+	assert.True(t, user.IsLocked(time.Now()))
+	mockAuditService.AssertExpectations(t)
+
+	// The correct password is refused while the account is locked
+	result, err := useCase.Login(context.Background(), tenantID, "testuser", "password123")
+	assert.Nil(t, result)
+	assert.True(t, apperrors.IsAuthenticationError(err))
+	mockAuthService.AssertNotCalled(t, "CreateSession", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// Tests that a sign-in after the lockout has passed succeeds and clears the failed attempts
+func TestLogin_LockoutExpired(t *testing.T) {
+	mockAuthService, mockUserRepo, mockTenantRepo, useCase := setupAuthUseCase(t)
+
+	// Create test data
+	tenantID := "tenant-123"
+	tenant := createTestTenant(tenantID, "Test Tenant")
+	user := createTestUser("user-123", "testuser", "test@example.com", tenantID, []string{"reader"})
+	user.SetPassword("password123")
+	lockedUntil := time.Now().Add(-time.Minute)
+	user.LockedUntil = &lockedUntil
+
+	// Set up expectations
+	mockTenantRepo.On("GetByID", mock.Anything, tenantID).Return(tenant, nil)
+	mockUserRepo.On("GetByUsername", mock.Anything, "testuser", tenantID).Return(user, nil)
+	mockUserRepo.On("Update", mock.Anything, user).Return(nil)
+	mockAuthService.On("CreateSession", mock.Anything, user.ID, tenantID, user.Roles).Return("access_token", "refresh_token", nil)
+
+	// Call the method being tested
+	result, err := useCase.Login(context.Background(), tenantID, "testuser", "password123")
+
+	// Assert results
+	assert.NoError(t, err)
+	assert.Equal(t, "access_token", result.AccessToken)
+	assert.Nil(t, user.LockedUntil)
+	mockUserRepo.AssertCalled(t, "Update", mock.Anything, user)
+}
+
+// Tests that sign-in reports a password older than the tenant's maximum age
+func TestLogin_PasswordExpired(t *testing.T) {
+	mockAuthService, mockUserRepo, mockTenantRepo, useCase := setupAuthUseCase(t)
+
+	// Create test data
+	tenantID := "tenant-123"
+	tenant := createTestTenant(tenantID, "Test Tenant")
+	tenant.SetSetting(models.TenantSettingPasswordMaxAgeDays, "90")
+	user := createTestUser("user-123", "testuser", "test@example.com", tenantID, []string{"reader"})
+	user.SetPassword("password123")
+	user.PasswordChangedAt = time.Now().AddDate(0, 0, -91)
+
+	// Set up expectations
+	mockTenantRepo.On("GetByID", mock.Anything, tenantID).Return(tenant, nil)
+	mockUserRepo.On("GetByUsername", mock.Anything, "testuser", tenantID).Return(user, nil)
+	mockAuthService.On("CreateSession", mock.Anything, user.ID, tenantID, user.Roles).Return("access_token", "refresh_token", nil)
+
+	// Call the method being tested
+	result, err := useCase.Login(context.Background(), tenantID, "testuser", "password123")
+
+	// Assert results
+	assert.NoError(t, err)
+	assert.True(t, result.PasswordExpired)
+}
+
+// Tests that a new password has to meet the tenant's complexity requirements
+func TestChangePassword_PolicyViolation(t *testing.T) {
+	_, mockUserRepo, mockTenantRepo, useCase := setupAuthUseCase(t)
+
+	// Create test data
+	tenantID := "tenant-123"
+	tenant := createTestTenant(tenantID, "Test Tenant")
+	tenant.SetSetting(models.TenantSettingPasswordMinLength, "12")
+	tenant.SetSetting(models.TenantSettingPasswordRequireDigit, "true")
+	user := createTestUser("user-123", "username", "email@example.com", tenantID, []string{"reader"})
+	user.SetPassword("current-password-1")
+
+	// Set up expectations
+	mockTenantRepo.On("GetByID", mock.Anything, tenantID).Return(tenant, nil)
+	mockUserRepo.On("GetByID", mock.Anything, user.ID, tenantID).Return(user, nil)
+
+	// Call the method being tested
+	err := useCase.ChangePassword(context.Background(), user.ID, tenantID, "current-password-1", "no-digits-here")
+
+	// Assert results
+	assert.True(t, apperrors.IsValidationError(err))
+	assert.Contains(t, err.Error(), "contain a digit")
+	mockUserRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+// Tests that a recently used password cannot be chosen again
+func TestChangePassword_ReusedPassword(t *testing.T) {
+	_, mockUserRepo, mockTenantRepo, useCase := setupAuthUseCase(t)
+
+	// Create test data
+	tenantID := "tenant-123"
+	tenant := createTestTenant(tenantID, "Test Tenant")
+	tenant.SetSetting(models.TenantSettingPasswordHistory, "2")
+	user := createTestUser("user-123", "username", "email@example.com", tenantID, []string{"reader"})
+	user.SetPassword("first-password")
+
+	// Set up expectations
+	mockTenantRepo.On("GetByID", mock.Anything, tenantID).Return(tenant, nil)
+	mockUserRepo.On("GetByID", mock.Anything, user.ID, tenantID).Return(user, nil)
+	mockUserRepo.On("Update", mock.Anything, user).Return(nil)
+
+	// Change the password once, keeping the first one in the history
+	err := useCase.ChangePassword(context.Background(), user.ID, tenantID, "first-password", "second-password")
+	require.NoError(t, err)
+	assert.Len(t, user.PasswordHistory, 1)
+
+	// Changing back to the first password is refused
+	err = useCase.ChangePassword(context.Background(), user.ID, tenantID, "second-password", "first-password")
+	assert.True(t, apperrors.IsValidationError(err))
+}
@@ -0,0 +1,849 @@
+package usecases
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"mime"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"../../domain/models"
+	"../../domain/repositories"
+	"../../domain/services"
+	"../../pkg/errors"
+	"../../pkg/logger"
+	"../../pkg/utils"
+)
+
+// Columns of the manifest of a bulk import with a meaning of their own. Every other column is a
+// metadata key, whose values are the metadata of the files.
+const (
+	BulkImportManifestColumnPath   = "path"
+	BulkImportManifestColumnName   = "name"
+	BulkImportManifestColumnSHA256 = "sha256"
+)
+
+// DefaultBulkImportConcurrency is the number of files of an import uploaded at the same time when
+// the configuration leaves it unset
+const DefaultBulkImportConcurrency = 4
+
+// maxBulkImportConcurrency bounds the number of files of an import uploaded at the same time
+const maxBulkImportConcurrency = 32
+
+// bulkImportJobStaleAfter is how long a running import may go without a progress update before
+// another worker assumes it was abandoned and resumes it
+const bulkImportJobStaleAfter = 15 * time.Minute
+
+// bulkImportProgressInterval is the number of files handled between progress updates
+const bulkImportProgressInterval = 100
+
+// bulkImportProgressMaxDelay is the longest time between progress updates, so that an import of
+// large files is not taken for abandoned
+const bulkImportProgressMaxDelay = time.Minute
+
+// bulkImportItemPageSize is the number of file outcomes read at a time
+const bulkImportItemPageSize = 1000
+
+// defaultBulkImportContentType is the content type of files whose extension has none
+const defaultBulkImportContentType = "application/octet-stream"
+
+// ErrBulkImportJobNotFound is returned for unknown bulk import jobs
+var ErrBulkImportJobNotFound = errors.NewResourceNotFoundError("bulk import job not found")
+
+// errBulkImportSourceProbed stops the walk checking that a source can be listed
+var errBulkImportSourceProbed = fmt.Errorf("import source probed")
+
+// BulkImportRequest describes a legacy archive to import into a tenant
+type BulkImportRequest struct {
+	TenantID string // Tenant the archive is imported into
+	UserID   string // User the documents are uploaded as, who must be allowed to write
+	Source   string // Absolute path of a directory or s3://bucket/prefix URL
+	Manifest string // Key of the manifest CSV file in the source; optional
+	FolderID string // Folder the archive is imported into; the root of the tenant when empty
+	DryRun   bool   // Only report what would be imported
+}
+
+// BulkImportUseCase defines the contract for importing legacy archives into tenants
+type BulkImportUseCase interface {
+	// StartImport checks the request, that the source can be read and that its manifest is valid,
+	// and schedules the import, which the worker runs
+	StartImport(ctx context.Context, request BulkImportRequest) (*models.BulkImportJob, error)
+
+	// GetImport retrieves a bulk import job
+	GetImport(ctx context.Context, id string) (*models.BulkImportJob, error)
+
+	// ListImports lists bulk import jobs, most recent first
+	ListImports(ctx context.Context, pagination *utils.Pagination) (utils.PaginatedResult[models.BulkImportJob], error)
+
+	// RetryImport schedules a failed import again. It continues after the last file it handled.
+	RetryImport(ctx context.Context, id string) (*models.BulkImportJob, error)
+
+	// ImportNext claims the next bulk import job and runs it. Returns false if there was no job to run.
+	ImportNext(ctx context.Context) (bool, error)
+
+	// WriteReport writes the reconciliation report of an import as CSV: the outcome of each file
+	// found in the source, and each file listed in the manifest but missing from the source
+	WriteReport(ctx context.Context, id string, w io.Writer) error
+}
+
+// bulkImportFolderUseCase is the part of the folder use case that bulk imports need
+type bulkImportFolderUseCase interface {
+	CreateFolder(ctx context.Context, name, parentID, tenantID, userID string) (string, error)
+	GetFolder(ctx context.Context, id, tenantID, userID string) (*models.Folder, error)
+	GetFolderByPath(ctx context.Context, path, tenantID, userID string) (*models.Folder, error)
+}
+
+// bulkImportManifest holds the lines of the manifest of an import by the key of their file
+type bulkImportManifest struct {
+	metadataKeys []string
+	entries      map[string]*bulkImportManifestEntry
+}
+
+// bulkImportManifestEntry is a line of the manifest of an import
+type bulkImportManifestEntry struct {
+	name     string
+	sha256   string
+	metadata []string // Values of the metadata columns, in the order of metadataKeys
+	matched  bool     // Whether the file was found in the source
+}
+
+// bulkImportRun is the state of an import while a worker runs it
+type bulkImportRun struct {
+	job          *models.BulkImportJob
+	source       services.ImportSource
+	manifest     *bulkImportManifest
+	targetPath   string
+	folders      map[string]string // Folder IDs by directory, empty for folders a dry run would create
+	folderErrors map[string]error  // Errors creating the folder of a directory
+	recorded     map[string]*models.BulkImportItem
+	handled      int // Files handled since the last progress update
+}
+
+// bulkImportUseCase implements the BulkImportUseCase interface
+type bulkImportUseCase struct {
+	importJobRepo   repositories.BulkImportJobRepository
+	tenantRepo      repositories.TenantRepository
+	userRepo        repositories.UserRepository
+	folderUseCase   bulkImportFolderUseCase
+	documentUseCase DocumentUseCase
+	sourceResolver  services.ImportSourceResolver
+	concurrency     int
+}
+
+// NewBulkImportUseCase creates a new BulkImportUseCase. Files are uploaded through the document use
+// case like uploads through the API, so they are checked against the tenant's upload policy and
+// quota, and their scans are queued at low priority so they do not hold up interactive uploads.
+// Concurrency is the number of files uploaded at the same time.
+func NewBulkImportUseCase(
+	importJobRepo repositories.BulkImportJobRepository,
+	tenantRepo repositories.TenantRepository,
+	userRepo repositories.UserRepository,
+	folderUseCase bulkImportFolderUseCase,
+	documentUseCase DocumentUseCase,
+	sourceResolver services.ImportSourceResolver,
+	concurrency int,
+) (BulkImportUseCase, error) {
+	if importJobRepo == nil {
+		return nil, fmt.Errorf("bulk import job repository cannot be nil")
+	}
+	if tenantRepo == nil {
+		return nil, fmt.Errorf("tenant repository cannot be nil")
+	}
+	if userRepo == nil {
+		return nil, fmt.Errorf("user repository cannot be nil")
+	}
+	if folderUseCase == nil {
+		return nil, fmt.Errorf("folder use case cannot be nil")
+	}
+	if documentUseCase == nil {
+		return nil, fmt.Errorf("document use case cannot be nil")
+	}
+	if sourceResolver == nil {
+		return nil, fmt.Errorf("import source resolver cannot be nil")
+	}
+	if concurrency <= 0 {
+		concurrency = DefaultBulkImportConcurrency
+	}
+	if concurrency > maxBulkImportConcurrency {
+		concurrency = maxBulkImportConcurrency
+	}
+
+	return &bulkImportUseCase{
+		importJobRepo:   importJobRepo,
+		tenantRepo:      tenantRepo,
+		userRepo:        userRepo,
+		folderUseCase:   folderUseCase,
+		documentUseCase: documentUseCase,
+		sourceResolver:  sourceResolver,
+		concurrency:     concurrency,
+	}, nil
+}
+
+// StartImport schedules an import of a legacy archive. Problems that would stop the import as a
+// whole, such as a source that cannot be listed or a malformed manifest, are reported now rather
+// than once the worker runs it.
+func (u *bulkImportUseCase) StartImport(ctx context.Context, request BulkImportRequest) (*models.BulkImportJob, error) {
+	if request.TenantID == "" {
+		return nil, errors.NewValidationError("tenant ID cannot be empty")
+	}
+	if request.UserID == "" {
+		return nil, errors.NewValidationError("user ID cannot be empty")
+	}
+	if request.Source == "" {
+		return nil, errors.NewValidationError("import source cannot be empty")
+	}
+
+	tenant, err := u.tenantRepo.GetByID(ctx, request.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if !tenant.IsActive() {
+		return nil, errors.NewValidationError("archives can only be imported into active tenants")
+	}
+	user, err := u.userRepo.GetByID(ctx, request.UserID, request.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsActive() || !user.CanWrite() {
+		return nil, errors.NewAuthorizationError("user is not allowed to upload documents")
+	}
+	if request.FolderID != "" {
+		if _, err := u.folderUseCase.GetFolder(ctx, request.FolderID, request.TenantID, request.UserID); err != nil {
+			return nil, err
+		}
+	}
+
+	source, err := u.sourceResolver.Resolve(ctx, request.Source)
+	if err != nil {
+		return nil, err
+	}
+	if err := source.Walk(ctx, "", func(services.ImportSourceFile) error { return errBulkImportSourceProbed }); err != nil && err != errBulkImportSourceProbed {
+		return nil, errors.Wrap(err, "failed to list import source")
+	}
+
+	manifest := ""
+	if request.Manifest != "" {
+		manifest, err = normalizeBulkImportKey(request.Manifest)
+		if err != nil {
+			return nil, errors.NewValidationError("manifest " + err.Error())
+		}
+		if _, err := readBulkImportManifest(ctx, source, manifest); err != nil {
+			return nil, err
+		}
+	}
+
+	job := models.NewBulkImportJob(request.TenantID, request.UserID, request.Source, manifest, request.FolderID, request.DryRun)
+	if _, err := u.importJobRepo.Create(ctx, job); err != nil {
+		logger.ErrorContext(ctx, "Failed to create bulk import job", "error", err, "tenant_id", request.TenantID)
+		return nil, errors.Wrap(err, "failed to create bulk import job")
+	}
+
+	logger.InfoContext(ctx, "Bulk import scheduled", "import_id", job.ID, "tenant_id", job.TenantID, "source", job.Source)
+	return job, nil
+}
+
+// GetImport retrieves a bulk import job
+func (u *bulkImportUseCase) GetImport(ctx context.Context, id string) (*models.BulkImportJob, error) {
+	if id == "" {
+		return nil, errors.NewValidationError("bulk import ID cannot be empty")
+	}
+
+	job, err := u.importJobRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.IsResourceNotFoundError(err) {
+			return nil, ErrBulkImportJobNotFound
+		}
+		return nil, errors.Wrap(err, "failed to get bulk import job")
+	}
+	return job, nil
+}
+
+// ListImports lists bulk import jobs, most recent first
+func (u *bulkImportUseCase) ListImports(ctx context.Context, pagination *utils.Pagination) (utils.PaginatedResult[models.BulkImportJob], error) {
+	return u.importJobRepo.List(ctx, pagination)
+}
+
+// RetryImport schedules a failed import again
+func (u *bulkImportUseCase) RetryImport(ctx context.Context, id string) (*models.BulkImportJob, error) {
+	job, err := u.GetImport(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if job.Status != models.BulkImportJobStatusFailed {
+		return nil, errors.NewValidationError(fmt.Sprintf("bulk import is %s; only failed imports can be retried", job.Status))
+	}
+
+	job.Retry(time.Now())
+	if err := u.importJobRepo.Update(ctx, job); err != nil {
+		return nil, errors.Wrap(err, "failed to update bulk import job")
+	}
+	return job, nil
+}
+
+// ImportNext claims and runs the next bulk import job. Files are handled in batches of as many
+// files as are uploaded at the same time, and the checkpoint of the job moves past a batch once
+// the outcome of each of its files is recorded. A job resumed after its worker stopped continues
+// after the checkpoint, and files of the interrupted batch whose outcome was recorded are not
+// imported again. A file uploaded just before its worker stopped, and not recorded yet, is
+// imported again.
+func (u *bulkImportUseCase) ImportNext(ctx context.Context) (bool, error) {
+	job, err := u.importJobRepo.ClaimNext(ctx, time.Now().Add(-bulkImportJobStaleAfter))
+	if err != nil {
+		return false, errors.Wrap(err, "failed to claim bulk import job")
+	}
+	if job == nil {
+		return false, nil
+	}
+
+	logger.InfoContext(ctx, "Running bulk import", "import_id", job.ID, "tenant_id", job.TenantID, "source", job.Source,
+		"dry_run", job.DryRun, "checkpoint", job.Checkpoint)
+
+	reason, err := u.runImport(ctx, job)
+	if err != nil {
+		if ctx.Err() != nil {
+			// Shutting down; the next worker continues after the checkpoint of the job
+			job.Requeue(time.Now())
+			if err := u.importJobRepo.Update(context.WithoutCancel(ctx), job); err != nil {
+				logger.ErrorContext(ctx, "Failed to release bulk import job", "error", err, "import_id", job.ID)
+			}
+			return true, ctx.Err()
+		}
+		return true, u.fail(ctx, job, reason, err)
+	}
+
+	job.Complete(time.Now())
+	if err := u.importJobRepo.Update(ctx, job); err != nil {
+		return true, errors.Wrap(err, "failed to update bulk import job")
+	}
+
+	logger.InfoContext(ctx, "Bulk import completed", "import_id", job.ID, "tenant_id", job.TenantID, "found", job.FoundFiles,
+		"imported", job.ImportedFiles, "skipped", job.SkippedFiles, "failed", job.FailedFiles, "missing", job.MissingFiles)
+	return true, nil
+}
+
+// runImport imports the files of the source of a job after its checkpoint. On failure it returns
+// a reason that can be shown to the operator along with the error.
+func (u *bulkImportUseCase) runImport(ctx context.Context, job *models.BulkImportJob) (string, error) {
+	source, err := u.sourceResolver.Resolve(ctx, job.Source)
+	if err != nil {
+		return "failed to open the source", err
+	}
+
+	run := &bulkImportRun{
+		job:          job,
+		source:       source,
+		folders:      map[string]string{},
+		folderErrors: map[string]error{},
+		recorded:     map[string]*models.BulkImportItem{},
+	}
+	if job.Manifest != "" {
+		if run.manifest, err = readBulkImportManifest(ctx, source, job.Manifest); err != nil {
+			return "failed to read the manifest", err
+		}
+	}
+	if job.FolderID != "" {
+		folder, err := u.folderUseCase.GetFolder(ctx, job.FolderID, job.TenantID, job.UserID)
+		if err != nil {
+			return "failed to get the target folder", err
+		}
+		run.targetPath = folder.Path
+	}
+	if err := u.loadRecorded(ctx, run); err != nil {
+		return "failed to read the outcomes recorded so far", err
+	}
+
+	var reason string
+	batch := make([]services.ImportSourceFile, 0, u.concurrency)
+	err = source.Walk(ctx, job.Checkpoint, func(file services.ImportSourceFile) error {
+		if file.Key == job.Manifest {
+			return nil
+		}
+		batch = append(batch, file)
+		if len(batch) < u.concurrency {
+			return nil
+		}
+
+		var err error
+		reason, err = u.importBatch(ctx, run, batch)
+		batch = batch[:0]
+		return err
+	})
+	if err == nil && len(batch) > 0 {
+		reason, err = u.importBatch(ctx, run, batch)
+	}
+	if err != nil {
+		if reason == "" {
+			reason = "failed to list the source"
+		}
+		return reason, err
+	}
+
+	if err := u.recordMissing(ctx, run); err != nil {
+		return "failed to record the files missing from the source", err
+	}
+	return "", nil
+}
+
+// loadRecorded reads the outcomes recorded by earlier runs of the job. Files of the manifest with
+// an outcome were found in the source, and outcomes after the checkpoint belong to files of a batch
+// that was interrupted, which are not imported again.
+func (u *bulkImportUseCase) loadRecorded(ctx context.Context, run *bulkImportRun) error {
+	after := ""
+	if run.manifest == nil {
+		after = run.job.Checkpoint
+	}
+
+	for {
+		items, err := u.importJobRepo.ListItems(ctx, run.job.ID, after, bulkImportItemPageSize)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range items {
+			if item.Status == models.BulkImportItemStatusMissing {
+				continue
+			}
+			if entry := run.manifest.entry(item.SourceKey); entry != nil {
+				entry.matched = true
+			}
+			if item.SourceKey > run.job.Checkpoint {
+				run.recorded[item.SourceKey] = item
+			}
+		}
+
+		if len(items) < bulkImportItemPageSize {
+			return nil
+		}
+		after = items[len(items)-1].SourceKey
+	}
+}
+
+// importBatch imports a batch of files concurrently and records their outcomes. Files the import
+// refuses, such as files of a type the tenant does not accept, are recorded as failed and the
+// import goes on; any other error, such as the quota of the tenant running out or storage being
+// unavailable, stops it so that it can be retried once the cause is fixed.
+func (u *bulkImportUseCase) importBatch(ctx context.Context, run *bulkImportRun, batch []services.ImportSourceFile) (string, error) {
+	items := make([]*models.BulkImportItem, len(batch))
+	folderIDs := make([]string, len(batch))
+	var uploads []int
+
+	for i, file := range batch {
+		if item, ok := run.recorded[file.Key]; ok {
+			items[i] = item
+			continue
+		}
+
+		entry := run.manifest.entry(file.Key)
+		if entry != nil {
+			entry.matched = true
+		}
+		item := &models.BulkImportItem{JobID: run.job.ID, SourceKey: file.Key, Size: file.Size}
+		items[i] = item
+
+		dir := path.Dir(file.Key)
+		if dir == "." {
+			dir = ""
+		}
+		if file.Size == 0 {
+			item.Status = models.BulkImportItemStatusSkipped
+			item.Reason = "the file is empty"
+			continue
+		}
+		if dir == "" && run.job.FolderID == "" {
+			item.Status = models.BulkImportItemStatusSkipped
+			item.Reason = "documents cannot be stored in the root of the tenant; import into a target folder"
+			continue
+		}
+
+		folderID, err := u.ensureFolder(ctx, run, dir)
+		if err != nil {
+			if !isBulkImportFileError(err) {
+				return fmt.Sprintf("failed to create the folder of %s", file.Key), err
+			}
+			item.Status = models.BulkImportItemStatusFailed
+			item.Reason = fmt.Sprintf("failed to create folder %s: %v", dir, err)
+			continue
+		}
+
+		if run.manifest != nil && entry == nil {
+			item.Reason = "not listed in the manifest"
+		}
+		if run.job.DryRun {
+			item.Status = models.BulkImportItemStatusPlanned
+			continue
+		}
+		folderIDs[i] = folderID
+		uploads = append(uploads, i)
+	}
+
+	errs := make([]error, len(batch))
+	var wg sync.WaitGroup
+	for _, i := range uploads {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = u.importFile(ctx, run, batch[i], folderIDs[i], items[i])
+		}(i)
+	}
+	wg.Wait()
+
+	// Outcomes are recorded even when the worker is shutting down, so that the uploads of the batch
+	// are not repeated when the job is resumed
+	recordCtx := context.WithoutCancel(ctx)
+	var failure error
+	var failedKey string
+	for i, item := range items {
+		if errs[i] != nil {
+			if failure == nil {
+				failure, failedKey = errs[i], batch[i].Key
+			}
+			continue
+		}
+		if _, ok := run.recorded[item.SourceKey]; ok {
+			continue
+		}
+		if err := u.importJobRepo.SaveItem(recordCtx, item); err != nil {
+			return fmt.Sprintf("failed to record the outcome of %s", item.SourceKey), err
+		}
+	}
+	if failure != nil {
+		return fmt.Sprintf("failed to import %s", failedKey), failure
+	}
+
+	for _, item := range items {
+		run.job.Count(item)
+		delete(run.recorded, item.SourceKey)
+	}
+	run.job.Checkpoint = batch[len(batch)-1].Key
+	run.handled += len(batch)
+
+	if run.handled >= bulkImportProgressInterval || time.Since(run.job.UpdatedAt) >= bulkImportProgressMaxDelay {
+		run.handled = 0
+		run.job.UpdatedAt = time.Now()
+		if err := u.importJobRepo.Update(ctx, run.job); err != nil {
+			return "failed to update the import progress", err
+		}
+	}
+	return "", nil
+}
+
+// importFile uploads a file of the source as a document, with the name, metadata and checksum of
+// its manifest line, and sets the outcome of the item. Returns an error only for failures that
+// stop the import.
+func (u *bulkImportUseCase) importFile(ctx context.Context, run *bulkImportRun, file services.ImportSourceFile, folderID string, item *models.BulkImportItem) error {
+	content, err := run.source.Open(ctx, file.Key)
+	if err != nil {
+		if ctx.Err() != nil || errors.IsDependencyError(err) {
+			return err
+		}
+		item.Status = models.BulkImportItemStatusFailed
+		item.Reason = fmt.Sprintf("failed to read the file: %v", err)
+		return nil
+	}
+	defer content.Close()
+
+	name := path.Base(file.Key)
+	var metadata map[string]string
+	var checksum string
+	if entry := run.manifest.entry(file.Key); entry != nil {
+		if entry.name != "" {
+			name = entry.name
+		}
+		metadata = run.manifest.metadata(entry)
+		checksum = entry.sha256
+	}
+	contentType := mime.TypeByExtension(path.Ext(name))
+	if contentType == "" {
+		contentType = defaultBulkImportContentType
+	}
+
+	documentID, err := u.documentUseCase.UploadDocument(ctx, name, contentType, file.Size, folderID, run.job.TenantID,
+		run.job.UserID, content, metadata, services.ScanPriorityLow, checksum)
+	if err != nil {
+		if ctx.Err() != nil || !isBulkImportFileError(err) {
+			return err
+		}
+		item.Status = models.BulkImportItemStatusFailed
+		item.Reason = err.Error()
+		return nil
+	}
+
+	item.Status = models.BulkImportItemStatusImported
+	item.DocumentID = documentID
+	return nil
+}
+
+// ensureFolder returns the ID of the folder a directory of the source is imported into, creating
+// it and its parents when they do not exist yet. A dry run counts the folders it would create and
+// returns an empty ID for them.
+func (u *bulkImportUseCase) ensureFolder(ctx context.Context, run *bulkImportRun, dir string) (string, error) {
+	if dir == "" {
+		return run.job.FolderID, nil
+	}
+	if id, ok := run.folders[dir]; ok {
+		return id, nil
+	}
+	if err, ok := run.folderErrors[dir]; ok {
+		return "", err
+	}
+
+	parent := path.Dir(dir)
+	if parent == "." {
+		parent = ""
+	}
+	parentID, err := u.ensureFolder(ctx, run, parent)
+	if err != nil {
+		return "", err
+	}
+
+	// Folders created by an earlier run of the job, or by hand, are reused
+	if parent == "" || parentID != "" {
+		folderPath := strings.TrimSuffix(run.targetPath, models.PathSeparator) + models.PathSeparator + dir
+		folder, err := u.folderUseCase.GetFolderByPath(ctx, folderPath, run.job.TenantID, run.job.UserID)
+		if err == nil {
+			run.folders[dir] = folder.ID
+			return folder.ID, nil
+		}
+		if !errors.IsResourceNotFoundError(err) {
+			return "", err
+		}
+	}
+
+	id := ""
+	if !run.job.DryRun {
+		id, err = u.folderUseCase.CreateFolder(ctx, path.Base(dir), parentID, run.job.TenantID, run.job.UserID)
+		if err != nil {
+			if isBulkImportFileError(err) {
+				run.folderErrors[dir] = err
+			}
+			return "", err
+		}
+	}
+	run.job.CreatedFolders++
+	run.folders[dir] = id
+	return id, nil
+}
+
+// recordMissing records the files listed in the manifest that were not found in the source
+func (u *bulkImportUseCase) recordMissing(ctx context.Context, run *bulkImportRun) error {
+	if run.manifest == nil {
+		return nil
+	}
+
+	var keys []string
+	for key, entry := range run.manifest.entries {
+		if !entry.matched {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	run.job.MissingFiles = 0
+	for _, key := range keys {
+		item := &models.BulkImportItem{
+			JobID:     run.job.ID,
+			SourceKey: key,
+			Status:    models.BulkImportItemStatusMissing,
+			Reason:    "listed in the manifest but not found in the source",
+		}
+		if err := u.importJobRepo.SaveItem(ctx, item); err != nil {
+			return err
+		}
+		run.job.Count(item)
+	}
+	return nil
+}
+
+// WriteReport writes the outcomes of the files of an import as CSV, in the order of their keys. The
+// report of a running import holds the files handled so far.
+func (u *bulkImportUseCase) WriteReport(ctx context.Context, id string, w io.Writer) error {
+	job, err := u.GetImport(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	report := csv.NewWriter(w)
+	if err := report.Write([]string{"path", "status", "document_id", "size", "reason"}); err != nil {
+		return err
+	}
+
+	after := ""
+	for {
+		items, err := u.importJobRepo.ListItems(ctx, job.ID, after, bulkImportItemPageSize)
+		if err != nil {
+			return errors.Wrap(err, "failed to list bulk import items")
+		}
+		for _, item := range items {
+			record := []string{item.SourceKey, item.Status, item.DocumentID, strconv.FormatInt(item.Size, 10), item.Reason}
+			if err := report.Write(record); err != nil {
+				return err
+			}
+		}
+		if len(items) < bulkImportItemPageSize {
+			break
+		}
+		after = items[len(items)-1].SourceKey
+	}
+
+	report.Flush()
+	return report.Error()
+}
+
+// fail marks the job as failed with the reason
+func (u *bulkImportUseCase) fail(ctx context.Context, job *models.BulkImportJob, reason string, cause error) error {
+	logger.ErrorContext(ctx, "Bulk import failed", "error", cause, "import_id", job.ID, "reason", reason)
+
+	job.Fail(fmt.Sprintf("%s: %v", reason, cause), time.Now())
+	if err := u.importJobRepo.Update(ctx, job); err != nil {
+		return errors.Wrap(err, "failed to update bulk import job")
+	}
+	return nil
+}
+
+// isBulkImportFileError reports whether an error concerns a single file, which is recorded as
+// failed, rather than the import as a whole
+func isBulkImportFileError(err error) bool {
+	return errors.IsValidationError(err) || errors.IsAuthorizationError(err) || errors.IsSecurityError(err) ||
+		errors.IsResourceNotFoundError(err)
+}
+
+// readBulkImportManifest reads the manifest with a key from the source
+func readBulkImportManifest(ctx context.Context, source services.ImportSource, key string) (*bulkImportManifest, error) {
+	content, err := source.Open(ctx, key)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open manifest")
+	}
+	defer content.Close()
+
+	return parseBulkImportManifest(content)
+}
+
+// parseBulkImportManifest parses a manifest: a CSV file with a header line, a path column holding
+// the keys of the files, optional name and sha256 columns, and a column for each metadata key. The
+// manifest is held in memory while the import runs.
+func parseBulkImportManifest(r io.Reader) (*bulkImportManifest, error) {
+	reader := csv.NewReader(r)
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.NewValidationError("manifest is empty")
+	}
+	if err != nil {
+		return nil, errors.NewValidationError(fmt.Sprintf("manifest is not valid CSV: %v", err))
+	}
+
+	manifest := &bulkImportManifest{entries: map[string]*bulkImportManifestEntry{}}
+	pathColumn, nameColumn, sha256Column := -1, -1, -1
+	var metadataColumns []int
+	seen := map[string]bool{}
+	for i, column := range header {
+		column = strings.TrimSpace(column)
+		if i == 0 {
+			// Spreadsheets often save CSV files with a byte order mark
+			column = strings.TrimPrefix(column, "\ufeff")
+		}
+		if column == "" {
+			return nil, errors.NewValidationError(fmt.Sprintf("manifest column %d has no name", i+1))
+		}
+		if seen[strings.ToLower(column)] {
+			return nil, errors.NewValidationError(fmt.Sprintf("manifest column %s appears twice", column))
+		}
+		seen[strings.ToLower(column)] = true
+
+		switch strings.ToLower(column) {
+		case BulkImportManifestColumnPath:
+			pathColumn = i
+		case BulkImportManifestColumnName:
+			nameColumn = i
+		case BulkImportManifestColumnSHA256:
+			sha256Column = i
+		default:
+			if models.IsReservedMetadataKey(column) {
+				return nil, errors.NewValidationError(fmt.Sprintf("manifest column %s is reserved metadata", column))
+			}
+			metadataColumns = append(metadataColumns, i)
+			manifest.metadataKeys = append(manifest.metadataKeys, column)
+		}
+	}
+	if pathColumn < 0 {
+		return nil, errors.NewValidationError("manifest has no path column")
+	}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return manifest, nil
+		}
+		if err != nil {
+			return nil, errors.NewValidationError(fmt.Sprintf("manifest is not valid CSV: %v", err))
+		}
+		line, _ := reader.FieldPos(0)
+
+		key, err := normalizeBulkImportKey(record[pathColumn])
+		if err != nil {
+			return nil, errors.NewValidationError(fmt.Sprintf("manifest line %d: path %v", line, err))
+		}
+		if _, ok := manifest.entries[key]; ok {
+			return nil, errors.NewValidationError(fmt.Sprintf("manifest line %d: %s is listed twice", line, key))
+		}
+
+		entry := &bulkImportManifestEntry{metadata: make([]string, len(metadataColumns))}
+		if nameColumn >= 0 {
+			entry.name = strings.TrimSpace(record[nameColumn])
+		}
+		if sha256Column >= 0 {
+			entry.sha256 = strings.ToLower(strings.TrimSpace(record[sha256Column]))
+			if entry.sha256 != "" && !utils.IsValidHash(entry.sha256, utils.HashAlgorithmSHA256) {
+				return nil, errors.NewValidationError(fmt.Sprintf("manifest line %d: sha256 is not a hex encoded SHA-256 hash", line))
+			}
+		}
+		for j, column := range metadataColumns {
+			entry.metadata[j] = strings.TrimSpace(record[column])
+		}
+		manifest.entries[key] = entry
+	}
+}
+
+// entry returns the manifest line of the file with a key, or nil if it has none or there is no
+// manifest
+func (m *bulkImportManifest) entry(key string) *bulkImportManifestEntry {
+	if m == nil {
+		return nil
+	}
+	return m.entries[key]
+}
+
+// metadata returns the metadata of a manifest line, leaving out empty values
+func (m *bulkImportManifest) metadata(entry *bulkImportManifestEntry) map[string]string {
+	var metadata map[string]string
+	for i, key := range m.metadataKeys {
+		if entry.metadata[i] == "" {
+			continue
+		}
+		if metadata == nil {
+			metadata = map[string]string{}
+		}
+		metadata[key] = entry.metadata[i]
+	}
+	return metadata
+}
+
+// normalizeBulkImportKey turns a path of a manifest into the key of a file in the source, which is
+// relative to the root of the source and uses slashes as separators
+func normalizeBulkImportKey(key string) (string, error) {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return "", fmt.Errorf("is empty")
+	}
+
+	key = path.Clean(strings.TrimLeft(key, "/"))
+	if key == "." || key == ".." || strings.HasPrefix(key, "../") {
+		return "", fmt.Errorf("%s is outside the source", key)
+	}
+	return key, nil
+}
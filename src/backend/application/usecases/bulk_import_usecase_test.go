@@ -0,0 +1,444 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert" // v1.8.0+
+	"github.com/stretchr/testify/suite"  // v1.8.0+
+
+	"../../domain/models"
+	"../../domain/repositories"
+	"../../domain/services"
+	pkgErrors "../../pkg/errors"
+)
+
+// fakeBulkImportJobRepository keeps bulk import jobs and their items in memory
+type fakeBulkImportJobRepository struct {
+	repositories.BulkImportJobRepository
+	jobs  map[string]*models.BulkImportJob
+	items map[string]*models.BulkImportItem
+}
+
+func (r *fakeBulkImportJobRepository) Create(ctx context.Context, job *models.BulkImportJob) (string, error) {
+	job.ID = fmt.Sprintf("import%d", len(r.jobs)+1)
+	r.jobs[job.ID] = job
+	return job.ID, nil
+}
+
+func (r *fakeBulkImportJobRepository) GetByID(ctx context.Context, id string) (*models.BulkImportJob, error) {
+	if job, ok := r.jobs[id]; ok {
+		return job, nil
+	}
+	return nil, pkgErrors.NewResourceNotFoundError("bulk import job not found")
+}
+
+func (r *fakeBulkImportJobRepository) ClaimNext(ctx context.Context, staleBefore time.Time) (*models.BulkImportJob, error) {
+	for _, job := range r.jobs {
+		if job.Status == models.BulkImportJobStatusPending {
+			job.Resume(time.Now())
+			return job, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeBulkImportJobRepository) Update(ctx context.Context, job *models.BulkImportJob) error {
+	r.jobs[job.ID] = job
+	return nil
+}
+
+func (r *fakeBulkImportJobRepository) SaveItem(ctx context.Context, item *models.BulkImportItem) error {
+	saved := *item
+	r.items[item.JobID+"|"+item.SourceKey] = &saved
+	return nil
+}
+
+func (r *fakeBulkImportJobRepository) ListItems(ctx context.Context, jobID string, afterKey string, limit int) ([]*models.BulkImportItem, error) {
+	var items []*models.BulkImportItem
+	for _, item := range r.items {
+		if item.JobID == jobID && item.SourceKey > afterKey {
+			items = append(items, item)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].SourceKey < items[j].SourceKey })
+	if len(items) > limit {
+		items = items[:limit]
+	}
+	return items, nil
+}
+
+// fakeImportSource is an import source holding its files in memory
+type fakeImportSource struct {
+	mu    sync.Mutex
+	files map[string]string
+}
+
+func (s *fakeImportSource) Walk(ctx context.Context, after string, fn func(file services.ImportSourceFile) error) error {
+	var keys []string
+	for key := range s.files {
+		if key > after {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := fn(services.ImportSourceFile{Key: key, Size: int64(len(s.files[key]))}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *fakeImportSource) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	content, ok := s.files[key]
+	if !ok {
+		return nil, pkgErrors.NewResourceNotFoundError("file not found")
+	}
+	return io.NopCloser(strings.NewReader(content)), nil
+}
+
+// fakeImportSourceResolver resolves every location to the same source
+type fakeImportSourceResolver struct {
+	source *fakeImportSource
+}
+
+func (r *fakeImportSourceResolver) Resolve(ctx context.Context, location string) (services.ImportSource, error) {
+	if !strings.HasPrefix(location, "/") {
+		return nil, pkgErrors.NewValidationError("import source must be an absolute path")
+	}
+	return r.source, nil
+}
+
+// fakeBulkImportFolderUseCase keeps the folders of tenant123 in memory by path
+type fakeBulkImportFolderUseCase struct {
+	folders map[string]*models.Folder
+	created []string
+}
+
+func (f *fakeBulkImportFolderUseCase) CreateFolder(ctx context.Context, name, parentID, tenantID, userID string) (string, error) {
+	parentPath := ""
+	for folderPath, folder := range f.folders {
+		if folder.ID == parentID {
+			parentPath = folderPath
+		}
+	}
+	folder := &models.Folder{ID: fmt.Sprintf("folder%d", len(f.folders)+1), Name: name, ParentID: parentID, Path: parentPath + "/" + name}
+	f.folders[folder.Path] = folder
+	f.created = append(f.created, folder.Path)
+	return folder.ID, nil
+}
+
+func (f *fakeBulkImportFolderUseCase) GetFolder(ctx context.Context, id, tenantID, userID string) (*models.Folder, error) {
+	for _, folder := range f.folders {
+		if folder.ID == id {
+			return folder, nil
+		}
+	}
+	return nil, pkgErrors.NewResourceNotFoundError("folder not found")
+}
+
+func (f *fakeBulkImportFolderUseCase) GetFolderByPath(ctx context.Context, path, tenantID, userID string) (*models.Folder, error) {
+	if folder, ok := f.folders[path]; ok {
+		return folder, nil
+	}
+	return nil, pkgErrors.NewResourceNotFoundError("folder not found")
+}
+
+// bulkImportUpload is a document uploaded by a bulk import
+type bulkImportUpload struct {
+	name        string
+	contentType string
+	folderID    string
+	content     string
+	metadata    map[string]string
+	priority    string
+	checksum    string
+}
+
+// fakeBulkImportDocumentUseCase records uploads, refusing files whose name is in refused and
+// running out of quota after quota uploads
+type fakeBulkImportDocumentUseCase struct {
+	DocumentUseCase
+	mu      sync.Mutex
+	uploads map[string]bulkImportUpload
+	refused map[string]bool
+	quota   int
+}
+
+func (d *fakeBulkImportDocumentUseCase) UploadDocument(ctx context.Context, name string, contentType string, size int64, folderID string, tenantID string, userID string, content io.Reader, metadata map[string]string, priority string, checksum string) (string, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.refused[name] {
+		return "", pkgErrors.NewValidationError("file type is not allowed")
+	}
+	if len(d.uploads) >= d.quota {
+		return "", pkgErrors.NewQuotaExceededError("storage quota exceeded")
+	}
+	data, _ := io.ReadAll(content)
+	id := fmt.Sprintf("doc%d", len(d.uploads)+1)
+	d.uploads[id] = bulkImportUpload{name, contentType, folderID, string(data), metadata, priority, checksum}
+	return id, nil
+}
+
+// testBulkImportChecksum is the SHA-256 hash of "report"
+const testBulkImportChecksum = "845e91831319e89c4d656bdb80c278ac09a7230d61e5dfd2e1b1fbb436ac8917"
+
+// BulkImportUseCaseTestSuite defines a test suite for BulkImportUseCase
+type BulkImportUseCaseTestSuite struct {
+	suite.Suite
+	importJobRepo   *fakeBulkImportJobRepository
+	source          *fakeImportSource
+	folderUseCase   *fakeBulkImportFolderUseCase
+	documentUseCase *fakeBulkImportDocumentUseCase
+	mockTenantRepo  *mockEmailTenantRepository
+	mockUserRepo    *mockBulkImportUserRepository
+	bulkImport      BulkImportUseCase
+}
+
+// mockBulkImportUserRepository returns alice, a contributor of tenant123
+type mockBulkImportUserRepository struct {
+	repositories.UserRepository
+}
+
+func (m *mockBulkImportUserRepository) GetByID(ctx context.Context, id string, tenantID string) (*models.User, error) {
+	if id != "user123" || tenantID != "tenant123" {
+		return nil, pkgErrors.NewResourceNotFoundError("user not found")
+	}
+	return &models.User{ID: "user123", TenantID: "tenant123", Status: models.UserStatusActive, Roles: []string{models.RoleContributor}}, nil
+}
+
+// SetupTest sets up the test environment before each test
+func (s *BulkImportUseCaseTestSuite) SetupTest() {
+	s.importJobRepo = &fakeBulkImportJobRepository{jobs: map[string]*models.BulkImportJob{}, items: map[string]*models.BulkImportItem{}}
+	s.source = &fakeImportSource{files: map[string]string{
+		"manifest.csv":              "path,name,sha256,Department\nlegal/2019/contract.pdf,Contract 2019.pdf,,Legal\nlegal/report.txt,,\"" + testBulkImportChecksum + "\",\nlegal/lost.pdf,,,Legal\n",
+		"legal/2019/contract.pdf":   "%PDF-1.4",
+		"legal/report.txt":          "report",
+		"legal/notes/unlisted.docx": "notes",
+		"legal/empty.txt":           "",
+	}}
+	s.folderUseCase = &fakeBulkImportFolderUseCase{folders: map[string]*models.Folder{
+		"/Archive": {ID: "archive", Name: "Archive", Path: "/Archive"},
+	}}
+	s.documentUseCase = &fakeBulkImportDocumentUseCase{uploads: map[string]bulkImportUpload{}, refused: map[string]bool{}, quota: 100}
+	s.mockTenantRepo = new(mockEmailTenantRepository)
+	s.mockUserRepo = new(mockBulkImportUserRepository)
+
+	var err error
+	s.bulkImport, err = NewBulkImportUseCase(s.importJobRepo, s.mockTenantRepo, s.mockUserRepo, s.folderUseCase, s.documentUseCase,
+		&fakeImportSourceResolver{source: s.source}, 2)
+	assert.Nil(s.T(), err)
+}
+
+// startImport schedules an import of the source into the Archive folder
+func (s *BulkImportUseCaseTestSuite) startImport(dryRun bool) *models.BulkImportJob {
+	ctx := context.Background()
+	s.mockTenantRepo.On("GetByID", ctx, "tenant123").Return(&models.Tenant{ID: "tenant123", Status: models.TenantStatusActive}, nil)
+
+	job, err := s.bulkImport.StartImport(ctx, BulkImportRequest{
+		TenantID: "tenant123", UserID: "user123", Source: "/mnt/legacy", Manifest: "/manifest.csv", FolderID: "archive", DryRun: dryRun,
+	})
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "manifest.csv", job.Manifest)
+	return job
+}
+
+// uploadByName returns the upload of the document with a name
+func (s *BulkImportUseCaseTestSuite) uploadByName(name string) bulkImportUpload {
+	for _, upload := range s.documentUseCase.uploads {
+		if upload.name == name {
+			return upload
+		}
+	}
+	s.T().Fatalf("%s was not uploaded", name)
+	return bulkImportUpload{}
+}
+
+// TestImportNext_ImportsTreeWithManifest tests that the tree is recreated as folders, files are
+// uploaded with the name, metadata and checksum of the manifest, and the report accounts for
+// every file
+func (s *BulkImportUseCaseTestSuite) TestImportNext_ImportsTreeWithManifest() {
+	ctx := context.Background()
+	job := s.startImport(false)
+
+	ran, err := s.bulkImport.ImportNext(ctx)
+
+	assert.NoError(s.T(), err)
+	assert.True(s.T(), ran)
+	assert.Equal(s.T(), models.BulkImportJobStatusCompleted, job.Status)
+	assert.Equal(s.T(), []string{"/Archive/legal", "/Archive/legal/2019", "/Archive/legal/notes"}, s.folderUseCase.created)
+	assert.Equal(s.T(), 3, job.CreatedFolders)
+	assert.Equal(s.T(), 4, job.FoundFiles)
+	assert.Equal(s.T(), 3, job.ImportedFiles)
+	assert.Equal(s.T(), int64(19), job.ImportedBytes)
+	assert.Equal(s.T(), 1, job.SkippedFiles)
+	assert.Equal(s.T(), 1, job.MissingFiles)
+	assert.Equal(s.T(), "legal/report.txt", job.Checkpoint)
+
+	contract := s.uploadByName("Contract 2019.pdf")
+	assert.Equal(s.T(), "application/pdf", contract.contentType)
+	assert.Equal(s.T(), s.folderUseCase.folders["/Archive/legal/2019"].ID, contract.folderID)
+	assert.Equal(s.T(), map[string]string{"Department": "Legal"}, contract.metadata)
+	assert.Equal(s.T(), services.ScanPriorityLow, contract.priority)
+	report := s.uploadByName("report.txt")
+	assert.Nil(s.T(), report.metadata)
+	assert.Equal(s.T(), testBulkImportChecksum, report.checksum)
+
+	var out bytes.Buffer
+	assert.NoError(s.T(), s.bulkImport.WriteReport(ctx, job.ID, &out))
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	assert.Equal(s.T(), "path,status,document_id,size,reason", lines[0])
+	assert.Len(s.T(), lines, 6)
+	assert.Contains(s.T(), lines[2], "legal/empty.txt,skipped,,0,the file is empty")
+	assert.Contains(s.T(), lines[3], "legal/lost.pdf,missing,,0,")
+	assert.Contains(s.T(), lines[4], ",not listed in the manifest")
+}
+
+// TestImportNext_DryRunUploadsNothing tests that a dry run reports what would be imported without
+// creating folders or documents
+func (s *BulkImportUseCaseTestSuite) TestImportNext_DryRunUploadsNothing() {
+	job := s.startImport(true)
+
+	_, err := s.bulkImport.ImportNext(context.Background())
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), models.BulkImportJobStatusCompleted, job.Status)
+	assert.Empty(s.T(), s.folderUseCase.created)
+	assert.Empty(s.T(), s.documentUseCase.uploads)
+	assert.Equal(s.T(), 3, job.CreatedFolders)
+	assert.Equal(s.T(), 3, job.ImportedFiles)
+	assert.Equal(s.T(), models.BulkImportItemStatusPlanned, s.importJobRepo.items[job.ID+"|legal/report.txt"].Status)
+}
+
+// TestImportNext_RecordsRefusedFiles tests that files the upload refuses are reported as failed
+// without stopping the import
+func (s *BulkImportUseCaseTestSuite) TestImportNext_RecordsRefusedFiles() {
+	job := s.startImport(false)
+	s.documentUseCase.refused["unlisted.docx"] = true
+
+	_, err := s.bulkImport.ImportNext(context.Background())
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), models.BulkImportJobStatusCompleted, job.Status)
+	assert.Equal(s.T(), 1, job.FailedFiles)
+	assert.Equal(s.T(), 2, job.ImportedFiles)
+	item := s.importJobRepo.items[job.ID+"|legal/notes/unlisted.docx"]
+	assert.Equal(s.T(), models.BulkImportItemStatusFailed, item.Status)
+	assert.Equal(s.T(), "file type is not allowed", item.Reason)
+}
+
+// TestImportNext_FailsAndResumesAfterQuotaExceeded tests that running out of quota fails the import,
+// and that a retry imports only the files that were not imported yet
+func (s *BulkImportUseCaseTestSuite) TestImportNext_FailsAndResumesAfterQuotaExceeded() {
+	ctx := context.Background()
+	job := s.startImport(false)
+	s.documentUseCase.quota = 1
+
+	_, err := s.bulkImport.ImportNext(ctx)
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), models.BulkImportJobStatusFailed, job.Status)
+	assert.Contains(s.T(), job.Error, "storage quota exceeded")
+	assert.Equal(s.T(), "legal/empty.txt", job.Checkpoint)
+	assert.Len(s.T(), s.documentUseCase.uploads, 1)
+
+	s.documentUseCase.quota = 100
+	_, err = s.bulkImport.RetryImport(ctx, job.ID)
+	assert.NoError(s.T(), err)
+	_, err = s.bulkImport.ImportNext(ctx)
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), models.BulkImportJobStatusCompleted, job.Status)
+	assert.Len(s.T(), s.documentUseCase.uploads, 3)
+	assert.Equal(s.T(), 3, job.ImportedFiles)
+	assert.Equal(s.T(), 1, job.MissingFiles)
+	assert.Equal(s.T(), []string{"/Archive/legal", "/Archive/legal/2019", "/Archive/legal/notes"}, s.folderUseCase.created)
+}
+
+// TestImportNext_ResumesInterruptedImport tests that a resumed import continues after its
+// checkpoint, reuses the folders it created and does not upload files of the interrupted batch
+// again
+func (s *BulkImportUseCaseTestSuite) TestImportNext_ResumesInterruptedImport() {
+	ctx := context.Background()
+	job := s.startImport(false)
+	job.Checkpoint = "legal/empty.txt"
+	job.FoundFiles, job.ImportedFiles, job.ImportedBytes, job.SkippedFiles = 2, 1, 8, 1
+	s.folderUseCase.folders["/Archive/legal"] = &models.Folder{ID: "legal", Name: "legal", ParentID: "archive", Path: "/Archive/legal"}
+	s.folderUseCase.folders["/Archive/legal/notes"] = &models.Folder{ID: "notes", Name: "notes", ParentID: "legal", Path: "/Archive/legal/notes"}
+	for _, item := range []*models.BulkImportItem{
+		{JobID: job.ID, SourceKey: "legal/2019/contract.pdf", Status: models.BulkImportItemStatusImported, DocumentID: "doc-a", Size: 8},
+		{JobID: job.ID, SourceKey: "legal/empty.txt", Status: models.BulkImportItemStatusSkipped},
+		{JobID: job.ID, SourceKey: "legal/notes/unlisted.docx", Status: models.BulkImportItemStatusImported, DocumentID: "doc-b", Size: 5},
+	} {
+		assert.NoError(s.T(), s.importJobRepo.SaveItem(ctx, item))
+	}
+
+	_, err := s.bulkImport.ImportNext(ctx)
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), models.BulkImportJobStatusCompleted, job.Status)
+	assert.Len(s.T(), s.documentUseCase.uploads, 1)
+	assert.Equal(s.T(), "legal", s.uploadByName("report.txt").folderID)
+	assert.Empty(s.T(), s.folderUseCase.created)
+	assert.Equal(s.T(), 4, job.FoundFiles)
+	assert.Equal(s.T(), 3, job.ImportedFiles)
+	assert.Equal(s.T(), int64(19), job.ImportedBytes)
+	assert.Equal(s.T(), 1, job.MissingFiles)
+	assert.Equal(s.T(), models.BulkImportItemStatusMissing, s.importJobRepo.items[job.ID+"|legal/lost.pdf"].Status)
+}
+
+// TestRetryImport_OnlyFailedImports tests that completed imports cannot be retried
+func (s *BulkImportUseCaseTestSuite) TestRetryImport_OnlyFailedImports() {
+	job := s.startImport(false)
+	job.Status = models.BulkImportJobStatusCompleted
+
+	_, err := s.bulkImport.RetryImport(context.Background(), job.ID)
+
+	assert.True(s.T(), pkgErrors.IsValidationError(err))
+}
+
+// TestStartImport_RejectsInvalidManifest tests that imports with a malformed manifest are not scheduled
+func (s *BulkImportUseCaseTestSuite) TestStartImport_RejectsInvalidManifest() {
+	ctx := context.Background()
+	s.mockTenantRepo.On("GetByID", ctx, "tenant123").Return(&models.Tenant{ID: "tenant123", Status: models.TenantStatusActive}, nil)
+	manifests := map[string]string{
+		"no path column":  "name,Department\ncontract.pdf,Legal\n",
+		"duplicate path":  "path\na.pdf\n/a.pdf\n",
+		"outside source":  "path\n../a.pdf\n",
+		"invalid sha256":  "path,sha256\na.pdf,abc\n",
+		"reserved column": "path," + models.DocumentNumberMetadataKey + "\na.pdf,INV-1\n",
+	}
+
+	for name, manifest := range manifests {
+		s.source.files["manifest.csv"] = manifest
+		_, err := s.bulkImport.StartImport(ctx, BulkImportRequest{
+			TenantID: "tenant123", UserID: "user123", Source: "/mnt/legacy", Manifest: "manifest.csv", FolderID: "archive",
+		})
+		assert.True(s.T(), pkgErrors.IsValidationError(err), name)
+	}
+	assert.Empty(s.T(), s.importJobRepo.jobs)
+}
+
+// TestGetImport_NotFound tests that unknown imports are reported as not found
+func (s *BulkImportUseCaseTestSuite) TestGetImport_NotFound() {
+	_, err := s.bulkImport.GetImport(context.Background(), "missing")
+
+	assert.Equal(s.T(), ErrBulkImportJobNotFound, err)
+}
+
+// TestBulkImportUseCaseSuite runs the BulkImportUseCase test suite
+func TestBulkImportUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(BulkImportUseCaseTestSuite))
+}
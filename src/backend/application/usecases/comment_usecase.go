@@ -0,0 +1,277 @@
+// Package usecases implements the application layer of the Document Management Platform.
+// It contains use case implementations that orchestrate domain models and services.
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid" // v1.3.0+ - For generating unique IDs for comment events
+
+	"../../domain/models"
+	"../../domain/repositories"
+	"../../pkg/errors"
+	"../../pkg/logger"
+	"../../pkg/utils"
+)
+
+// Comment errors
+var (
+	ErrCommentNotAuthor     = errors.NewAuthorizationError("only the author of a comment can change or delete it")
+	ErrCommentNestedReply   = errors.NewValidationError("replies cannot be replied to; reply to the comment starting the thread")
+	ErrCommentResolveReply  = errors.NewValidationError("only the comment starting a thread can be resolved or reopened")
+	ErrCommentParentMissing = errors.NewValidationError("the comment replied to does not belong to the document")
+)
+
+// CommentUseCase defines the contract for commenting on documents. Users comment on the documents
+// they can read; only the author of a comment can edit or delete it, while any reader can resolve
+// or reopen a thread.
+type CommentUseCase interface {
+	// CreateComment adds a comment to a document, starting a thread or replying to one, and
+	// publishes a comment.created event
+	CreateComment(ctx context.Context, comment *models.Comment) (*models.Comment, error)
+
+	// GetComment retrieves a comment of a document
+	GetComment(ctx context.Context, documentID, id, tenantID, userID string) (*models.Comment, error)
+
+	// ListComments lists the comments of a document oldest first, with pagination
+	ListComments(ctx context.Context, documentID, tenantID, userID string, page int, pageSize int) (utils.PaginatedResult[models.Comment], error)
+
+	// UpdateComment replaces the body of a comment
+	UpdateComment(ctx context.Context, documentID, id, body, tenantID, userID string) (*models.Comment, error)
+
+	// ResolveComment resolves the thread a comment starts, or reopens it when resolved is false
+	ResolveComment(ctx context.Context, documentID, id string, resolved bool, tenantID, userID string) (*models.Comment, error)
+
+	// DeleteComment deletes a comment together with its replies
+	DeleteComment(ctx context.Context, documentID, id, tenantID, userID string) error
+}
+
+// commentUseCase implements the CommentUseCase interface
+type commentUseCase struct {
+	commentRepo repositories.CommentRepository
+	outboxRepo  repositories.OutboxRepository
+	txManager   repositories.TransactionManager
+	documents   DocumentReader
+}
+
+// NewCommentUseCase creates a new CommentUseCase instance
+func NewCommentUseCase(commentRepo repositories.CommentRepository, outboxRepo repositories.OutboxRepository, txManager repositories.TransactionManager, documents DocumentReader) (CommentUseCase, error) {
+	if commentRepo == nil {
+		return nil, fmt.Errorf("comment repository cannot be nil")
+	}
+	if outboxRepo == nil {
+		return nil, fmt.Errorf("outbox repository cannot be nil")
+	}
+	if txManager == nil {
+		return nil, fmt.Errorf("transaction manager cannot be nil")
+	}
+	if documents == nil {
+		return nil, fmt.Errorf("document reader cannot be nil")
+	}
+
+	return &commentUseCase{
+		commentRepo: commentRepo,
+		outboxRepo:  outboxRepo,
+		txManager:   txManager,
+		documents:   documents,
+	}, nil
+}
+
+// CreateComment adds a comment to a document the author can read and writes its comment.created
+// event to the outbox in the same transaction
+func (u *commentUseCase) CreateComment(ctx context.Context, comment *models.Comment) (*models.Comment, error) {
+	log := logger.WithContext(ctx)
+
+	if comment == nil {
+		log.Error("comment cannot be nil")
+		return nil, errors.NewValidationError("comment cannot be nil")
+	}
+	if err := comment.Validate(); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	document, err := u.documents.GetDocument(ctx, comment.DocumentID, comment.TenantID, comment.AuthorID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create comment")
+	}
+
+	// Replies join the thread of a comment of the same document; threads are one level deep
+	if comment.IsReply() {
+		parent, err := u.commentRepo.GetByID(ctx, comment.ParentID, comment.TenantID)
+		if err != nil {
+			if errors.IsResourceNotFoundError(err) {
+				return nil, ErrCommentParentMissing
+			}
+			return nil, errors.Wrap(err, "failed to create comment")
+		}
+		if parent.DocumentID != comment.DocumentID {
+			return nil, ErrCommentParentMissing
+		}
+		if parent.IsReply() {
+			return nil, ErrCommentNestedReply
+		}
+	}
+
+	comment.ID = uuid.New().String()
+	event, err := models.NewCommentCreatedEvent(comment, document.FolderID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create comment event")
+	}
+	event.ID = uuid.New().String()
+	message, err := models.NewOutboxMessage(event)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid outbox message")
+	}
+
+	err = u.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if _, err := u.commentRepo.Create(txCtx, comment); err != nil {
+			return err
+		}
+		_, err := u.outboxRepo.Create(txCtx, message)
+		return err
+	})
+	if err != nil {
+		log.WithError(err).Error("failed to create comment", "documentID", comment.DocumentID, "tenantID", comment.TenantID)
+		return nil, errors.Wrap(err, "failed to create comment")
+	}
+
+	log.Info("comment created successfully", "commentID", comment.ID, "documentID", comment.DocumentID, "tenantID", comment.TenantID)
+	return comment, nil
+}
+
+// GetComment retrieves a comment of a document the user can read
+func (u *commentUseCase) GetComment(ctx context.Context, documentID, id, tenantID, userID string) (*models.Comment, error) {
+	return u.getComment(ctx, documentID, id, tenantID, userID)
+}
+
+// ListComments lists the comments of a document the user can read
+func (u *commentUseCase) ListComments(ctx context.Context, documentID, tenantID, userID string, page int, pageSize int) (utils.PaginatedResult[models.Comment], error) {
+	log := logger.WithContext(ctx)
+
+	if _, err := u.documents.GetDocument(ctx, documentID, tenantID, userID); err != nil {
+		return utils.PaginatedResult[models.Comment]{}, errors.Wrap(err, "failed to list comments")
+	}
+
+	result, err := u.commentRepo.ListByDocument(ctx, documentID, tenantID, utils.NewPagination(page, pageSize))
+	if err != nil {
+		log.WithError(err).Error("failed to list comments", "documentID", documentID, "tenantID", tenantID)
+		return utils.PaginatedResult[models.Comment]{}, errors.Wrap(err, "failed to list comments")
+	}
+
+	return result, nil
+}
+
+// UpdateComment replaces the body of a comment written by the user
+func (u *commentUseCase) UpdateComment(ctx context.Context, documentID, id, body, tenantID, userID string) (*models.Comment, error) {
+	log := logger.WithContext(ctx)
+
+	comment, err := u.getComment(ctx, documentID, id, tenantID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if comment.AuthorID != userID {
+		return nil, ErrCommentNotAuthor
+	}
+
+	comment.Edit(body)
+	if err := comment.Validate(); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+	if err := u.commentRepo.Update(ctx, comment); err != nil {
+		log.WithError(err).Error("failed to update comment", "commentID", id, "tenantID", tenantID)
+		return nil, errors.Wrap(err, "failed to update comment")
+	}
+
+	log.Info("comment updated successfully", "commentID", id, "tenantID", tenantID)
+	return comment, nil
+}
+
+// ResolveComment resolves or reopens the thread a comment starts
+func (u *commentUseCase) ResolveComment(ctx context.Context, documentID, id string, resolved bool, tenantID, userID string) (*models.Comment, error) {
+	log := logger.WithContext(ctx)
+
+	comment, err := u.getComment(ctx, documentID, id, tenantID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if comment.IsReply() {
+		return nil, ErrCommentResolveReply
+	}
+
+	// Resolving a resolved thread or reopening an open one changes nothing
+	if comment.IsResolved() == resolved {
+		return comment, nil
+	}
+	if resolved {
+		comment.Resolve(userID, time.Now())
+	} else {
+		comment.Reopen(time.Now())
+	}
+
+	if err := u.commentRepo.Update(ctx, comment); err != nil {
+		log.WithError(err).Error("failed to resolve comment", "commentID", id, "tenantID", tenantID)
+		return nil, errors.Wrap(err, "failed to resolve comment")
+	}
+
+	log.Info("comment thread resolution changed", "commentID", id, "resolved", resolved, "tenantID", tenantID)
+	return comment, nil
+}
+
+// DeleteComment deletes a comment written by the user together with its replies
+func (u *commentUseCase) DeleteComment(ctx context.Context, documentID, id, tenantID, userID string) error {
+	log := logger.WithContext(ctx)
+
+	comment, err := u.getComment(ctx, documentID, id, tenantID, userID)
+	if err != nil {
+		return err
+	}
+	if comment.AuthorID != userID {
+		return ErrCommentNotAuthor
+	}
+
+	if err := u.commentRepo.Delete(ctx, id, tenantID); err != nil {
+		log.WithError(err).Error("failed to delete comment", "commentID", id, "tenantID", tenantID)
+		return errors.Wrap(err, "failed to delete comment")
+	}
+
+	log.Info("comment deleted successfully", "commentID", id, "tenantID", tenantID)
+	return nil
+}
+
+// getComment retrieves a comment of a document after checking the user can read the document
+func (u *commentUseCase) getComment(ctx context.Context, documentID, id, tenantID, userID string) (*models.Comment, error) {
+	if err := u.validateInput(map[string]string{
+		"document ID": documentID,
+		"comment ID":  id,
+		"tenant ID":   tenantID,
+		"user ID":     userID,
+	}); err != nil {
+		return nil, err
+	}
+
+	if _, err := u.documents.GetDocument(ctx, documentID, tenantID, userID); err != nil {
+		return nil, errors.Wrap(err, "failed to get comment")
+	}
+
+	comment, err := u.commentRepo.GetByID(ctx, id, tenantID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get comment")
+	}
+	if comment.DocumentID != documentID {
+		return nil, errors.NewResourceNotFoundError("Comment not found")
+	}
+
+	return comment, nil
+}
+
+// validateInput validates that required input parameters are not empty
+func (u *commentUseCase) validateInput(params map[string]string) error {
+	for name, value := range params {
+		if value == "" {
+			return errors.NewValidationError(fmt.Sprintf("%s cannot be empty", name))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,229 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert" // v1.8.0+
+	"github.com/stretchr/testify/mock"   // v1.8.0+
+	"github.com/stretchr/testify/suite"  // v1.8.0+
+
+	"../../domain/models"
+	pkgErrors "../../pkg/errors"
+	"../../pkg/utils"
+)
+
+// MockCommentRepository is a mock implementation of the CommentRepository interface for testing
+type MockCommentRepository struct {
+	mock.Mock
+}
+
+// Create mock implementation for persisting a comment
+func (m *MockCommentRepository) Create(ctx context.Context, comment *models.Comment) (string, error) {
+	args := m.Called(ctx, comment)
+	return args.String(0), args.Error(1)
+}
+
+// GetByID mock implementation for retrieving a comment
+func (m *MockCommentRepository) GetByID(ctx context.Context, id string, tenantID string) (*models.Comment, error) {
+	args := m.Called(ctx, id, tenantID)
+	if comment := args.Get(0); comment != nil {
+		return comment.(*models.Comment), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// Update mock implementation for updating a comment
+func (m *MockCommentRepository) Update(ctx context.Context, comment *models.Comment) error {
+	args := m.Called(ctx, comment)
+	return args.Error(0)
+}
+
+// Delete mock implementation for deleting a comment
+func (m *MockCommentRepository) Delete(ctx context.Context, id string, tenantID string) error {
+	args := m.Called(ctx, id, tenantID)
+	return args.Error(0)
+}
+
+// ListByDocument mock implementation for listing the comments of a document
+func (m *MockCommentRepository) ListByDocument(ctx context.Context, documentID string, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Comment], error) {
+	args := m.Called(ctx, documentID, tenantID, pagination)
+	return args.Get(0).(utils.PaginatedResult[models.Comment]), args.Error(1)
+}
+
+// MockOutboxRepository is a mock implementation of the OutboxRepository interface for testing
+type MockOutboxRepository struct {
+	mock.Mock
+}
+
+// Create mock implementation for persisting an outbox message
+func (m *MockOutboxRepository) Create(ctx context.Context, message *models.OutboxMessage) (string, error) {
+	args := m.Called(ctx, message)
+	return args.String(0), args.Error(1)
+}
+
+// ListDue mock implementation for listing the messages due for publishing
+func (m *MockOutboxRepository) ListDue(ctx context.Context, now time.Time, limit int) ([]*models.OutboxMessage, error) {
+	args := m.Called(ctx, now, limit)
+	if messages := args.Get(0); messages != nil {
+		return messages.([]*models.OutboxMessage), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// Update mock implementation for updating an outbox message
+func (m *MockOutboxRepository) Update(ctx context.Context, message *models.OutboxMessage) error {
+	args := m.Called(ctx, message)
+	return args.Error(0)
+}
+
+// DeleteSentBefore mock implementation for removing published messages
+func (m *MockOutboxRepository) DeleteSentBefore(ctx context.Context, before time.Time) (int, error) {
+	args := m.Called(ctx, before)
+	return args.Int(0), args.Error(1)
+}
+
+// CommentUseCaseTestSuite defines a test suite for CommentUseCase
+type CommentUseCaseTestSuite struct {
+	suite.Suite
+	mockCommentRepo *MockCommentRepository
+	mockOutboxRepo  *MockOutboxRepository
+	mockDocuments   *MockDocumentReader
+	commentUseCase  CommentUseCase
+}
+
+// SetupTest sets up the test environment before each test
+func (s *CommentUseCaseTestSuite) SetupTest() {
+	s.mockCommentRepo = new(MockCommentRepository)
+	s.mockOutboxRepo = new(MockOutboxRepository)
+	s.mockDocuments = new(MockDocumentReader)
+
+	var err error
+	s.commentUseCase, err = NewCommentUseCase(s.mockCommentRepo, s.mockOutboxRepo, &passthroughTransactionManager{}, s.mockDocuments)
+	assert.Nil(s.T(), err)
+	assert.NotNil(s.T(), s.commentUseCase)
+}
+
+// TestNewCommentUseCase tests the creation of a new CommentUseCase
+func (s *CommentUseCaseTestSuite) TestNewCommentUseCase() {
+	useCase, err := NewCommentUseCase(s.mockCommentRepo, nil, &passthroughTransactionManager{}, s.mockDocuments)
+	assert.NotNil(s.T(), err)
+	assert.Nil(s.T(), useCase)
+}
+
+// TestCreateComment_Success tests commenting on a region of a page, with the event written to the outbox
+func (s *CommentUseCaseTestSuite) TestCreateComment_Success() {
+	anchor := models.CommentAnchor{Page: 2, X: 0.1, Y: 0.2, Width: 0.3, Height: 0.1}
+	comment := models.NewComment("tenant123", "doc123", "", "user123", "Please check this total", anchor)
+	s.mockDocuments.On("GetDocument", mock.Anything, "doc123", "tenant123", "user123").Return(&models.Document{ID: "doc123", FolderID: "folder123"}, nil)
+	s.mockCommentRepo.On("Create", mock.Anything, comment).Return("", nil)
+	s.mockOutboxRepo.On("Create", mock.Anything, mock.MatchedBy(func(m *models.OutboxMessage) bool {
+		var payload map[string]interface{}
+		_ = json.Unmarshal(m.Payload, &payload)
+		return m.EventType == models.EventTypeCommentCreated && payload["folderID"] == "folder123" && payload["commentID"] == comment.ID
+	})).Return("message123", nil)
+
+	created, err := s.commentUseCase.CreateComment(context.Background(), comment)
+
+	assert.Nil(s.T(), err)
+	assert.NotEmpty(s.T(), created.ID)
+	s.mockCommentRepo.AssertExpectations(s.T())
+	s.mockOutboxRepo.AssertExpectations(s.T())
+}
+
+// TestCreateComment_InvalidAnchor tests commenting on a region outside of the page
+func (s *CommentUseCaseTestSuite) TestCreateComment_InvalidAnchor() {
+	comment := models.NewComment("tenant123", "doc123", "", "user123", "Off the page", models.CommentAnchor{Page: 1, X: 0.8, Y: 0.1, Width: 0.5, Height: 0.1})
+
+	created, err := s.commentUseCase.CreateComment(context.Background(), comment)
+
+	assert.Nil(s.T(), created)
+	assert.True(s.T(), pkgErrors.IsValidationError(err))
+	s.mockDocuments.AssertNotCalled(s.T(), "GetDocument")
+}
+
+// TestCreateComment_NestedReply tests replying to a reply
+func (s *CommentUseCaseTestSuite) TestCreateComment_NestedReply() {
+	comment := models.NewComment("tenant123", "doc123", "reply123", "user123", "Agreed", models.CommentAnchor{})
+	s.mockDocuments.On("GetDocument", mock.Anything, "doc123", "tenant123", "user123").Return(&models.Document{ID: "doc123"}, nil)
+	s.mockCommentRepo.On("GetByID", mock.Anything, "reply123", "tenant123").
+		Return(&models.Comment{ID: "reply123", DocumentID: "doc123", ParentID: "thread123"}, nil)
+
+	created, err := s.commentUseCase.CreateComment(context.Background(), comment)
+
+	assert.Nil(s.T(), created)
+	assert.Equal(s.T(), ErrCommentNestedReply, err)
+	s.mockCommentRepo.AssertNotCalled(s.T(), "Create")
+	s.mockOutboxRepo.AssertNotCalled(s.T(), "Create")
+}
+
+// TestUpdateComment_NotAuthor tests editing a comment of another user
+func (s *CommentUseCaseTestSuite) TestUpdateComment_NotAuthor() {
+	s.mockDocuments.On("GetDocument", mock.Anything, "doc123", "tenant123", "user456").Return(&models.Document{ID: "doc123"}, nil)
+	s.mockCommentRepo.On("GetByID", mock.Anything, "comment123", "tenant123").
+		Return(&models.Comment{ID: "comment123", DocumentID: "doc123", AuthorID: "user123", Body: "Original"}, nil)
+
+	updated, err := s.commentUseCase.UpdateComment(context.Background(), "doc123", "comment123", "Changed", "tenant123", "user456")
+
+	assert.Nil(s.T(), updated)
+	assert.True(s.T(), pkgErrors.IsAuthorizationError(err))
+	s.mockCommentRepo.AssertNotCalled(s.T(), "Update")
+}
+
+// TestGetComment_OtherDocument tests retrieving a comment through a document it does not belong to
+func (s *CommentUseCaseTestSuite) TestGetComment_OtherDocument() {
+	s.mockDocuments.On("GetDocument", mock.Anything, "doc123", "tenant123", "user123").Return(&models.Document{ID: "doc123"}, nil)
+	s.mockCommentRepo.On("GetByID", mock.Anything, "comment123", "tenant123").
+		Return(&models.Comment{ID: "comment123", DocumentID: "doc456", AuthorID: "user123", Body: "Elsewhere"}, nil)
+
+	comment, err := s.commentUseCase.GetComment(context.Background(), "doc123", "comment123", "tenant123", "user123")
+
+	assert.Nil(s.T(), comment)
+	assert.True(s.T(), pkgErrors.IsResourceNotFoundError(err))
+}
+
+// TestResolveComment_Success tests resolving a thread started by another user
+func (s *CommentUseCaseTestSuite) TestResolveComment_Success() {
+	s.mockDocuments.On("GetDocument", mock.Anything, "doc123", "tenant123", "user456").Return(&models.Document{ID: "doc123"}, nil)
+	s.mockCommentRepo.On("GetByID", mock.Anything, "comment123", "tenant123").
+		Return(&models.Comment{ID: "comment123", TenantID: "tenant123", DocumentID: "doc123", AuthorID: "user123", Body: "Typo on page 2"}, nil)
+	s.mockCommentRepo.On("Update", mock.Anything, mock.MatchedBy(func(c *models.Comment) bool {
+		return c.IsResolved() && c.ResolvedBy == "user456"
+	})).Return(nil)
+
+	comment, err := s.commentUseCase.ResolveComment(context.Background(), "doc123", "comment123", true, "tenant123", "user456")
+
+	assert.Nil(s.T(), err)
+	assert.True(s.T(), comment.IsResolved())
+	s.mockCommentRepo.AssertExpectations(s.T())
+}
+
+// TestResolveComment_Reply tests resolving a reply rather than its thread
+func (s *CommentUseCaseTestSuite) TestResolveComment_Reply() {
+	s.mockDocuments.On("GetDocument", mock.Anything, "doc123", "tenant123", "user123").Return(&models.Document{ID: "doc123"}, nil)
+	s.mockCommentRepo.On("GetByID", mock.Anything, "reply123", "tenant123").
+		Return(&models.Comment{ID: "reply123", DocumentID: "doc123", ParentID: "comment123", AuthorID: "user123", Body: "Fixed"}, nil)
+
+	comment, err := s.commentUseCase.ResolveComment(context.Background(), "doc123", "reply123", true, "tenant123", "user123")
+
+	assert.Nil(s.T(), comment)
+	assert.Equal(s.T(), ErrCommentResolveReply, err)
+	s.mockCommentRepo.AssertNotCalled(s.T(), "Update")
+}
+
+// TestListComments_AccessDenied tests listing the comments of a document the user cannot read
+func (s *CommentUseCaseTestSuite) TestListComments_AccessDenied() {
+	s.mockDocuments.On("GetDocument", mock.Anything, "doc123", "tenant123", "user123").Return(nil, pkgErrors.NewAuthorizationError("permission denied"))
+
+	_, err := s.commentUseCase.ListComments(context.Background(), "doc123", "tenant123", "user123", 1, 20)
+
+	assert.True(s.T(), pkgErrors.IsAuthorizationError(err))
+	s.mockCommentRepo.AssertNotCalled(s.T(), "ListByDocument")
+}
+
+// TestCommentUseCaseSuite runs the CommentUseCase test suite
+func TestCommentUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(CommentUseCaseTestSuite))
+}
@@ -0,0 +1,231 @@
+// Package usecases implements the application layer of the Document Management Platform.
+// It contains use case implementations that orchestrate domain models and services.
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"../../domain/models"
+	"../../domain/repositories"
+	"../../domain/services"
+	"../../pkg/errors"
+	"../../pkg/logger"
+)
+
+// Document link errors
+var (
+	ErrDocumentLinkNotFound = errors.NewResourceNotFoundError("Document link not found")
+)
+
+// DocumentLinkUseCase defines the contract for linking documents to one another, such as a contract
+// to its amendments. A link belongs to its source document: users who can write the source link it
+// to documents they can read, and change or remove its links. Users who can read either document
+// see the link.
+type DocumentLinkUseCase interface {
+	// CreateLink links a source document to a target document with the given type
+	CreateLink(ctx context.Context, sourceID, targetID, linkType, tenantID, userID string) (*models.DocumentLink, error)
+
+	// GetLink retrieves a link the document is the source or target of
+	GetLink(ctx context.Context, documentID, id, tenantID, userID string) (*models.DocumentLink, error)
+
+	// ListLinks lists the links the document is the source or target of, oldest first
+	ListLinks(ctx context.Context, documentID, tenantID, userID string) ([]models.DocumentLink, error)
+
+	// UpdateLink changes the type of a link of the document
+	UpdateLink(ctx context.Context, documentID, id, linkType, tenantID, userID string) (*models.DocumentLink, error)
+
+	// DeleteLink deletes a link of the document
+	DeleteLink(ctx context.Context, documentID, id, tenantID, userID string) error
+}
+
+// documentLinkUseCase implements the DocumentLinkUseCase interface
+type documentLinkUseCase struct {
+	linkRepo     repositories.DocumentLinkRepository
+	documentRepo repositories.DocumentRepository
+	authService  services.AuthService
+}
+
+// NewDocumentLinkUseCase creates a new DocumentLinkUseCase instance
+func NewDocumentLinkUseCase(linkRepo repositories.DocumentLinkRepository, documentRepo repositories.DocumentRepository, authService services.AuthService) (DocumentLinkUseCase, error) {
+	if linkRepo == nil {
+		return nil, fmt.Errorf("document link repository cannot be nil")
+	}
+	if documentRepo == nil {
+		return nil, fmt.Errorf("document repository cannot be nil")
+	}
+	if authService == nil {
+		return nil, fmt.Errorf("auth service cannot be nil")
+	}
+
+	return &documentLinkUseCase{
+		linkRepo:     linkRepo,
+		documentRepo: documentRepo,
+		authService:  authService,
+	}, nil
+}
+
+// CreateLink links a source document the user can write to a target document of the same tenant the
+// user can read
+func (u *documentLinkUseCase) CreateLink(ctx context.Context, sourceID, targetID, linkType, tenantID, userID string) (*models.DocumentLink, error) {
+	log := logger.WithContext(ctx)
+
+	link := models.NewDocumentLink(tenantID, sourceID, targetID, linkType, userID)
+	if err := u.validateInput(map[string]string{"user ID": userID}); err != nil {
+		return nil, err
+	}
+	if err := link.Validate(); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	// Both documents must exist in the tenant; looking them up keeps links from crossing tenants
+	if _, err := u.documentRepo.GetByID(ctx, sourceID, tenantID); err != nil {
+		return nil, errors.Wrap(err, "failed to create document link")
+	}
+	if _, err := u.documentRepo.GetByID(ctx, targetID, tenantID); err != nil {
+		return nil, errors.Wrap(err, "failed to create document link")
+	}
+	if err := u.verifyDocumentAccess(ctx, sourceID, tenantID, userID, services.PermissionWrite); err != nil {
+		return nil, err
+	}
+	if err := u.verifyDocumentAccess(ctx, targetID, tenantID, userID, services.PermissionRead); err != nil {
+		return nil, err
+	}
+
+	if _, err := u.linkRepo.Create(ctx, link); err != nil {
+		log.WithError(err).Error("failed to create document link", "sourceID", sourceID, "targetID", targetID, "tenantID", tenantID)
+		return nil, errors.Wrap(err, "failed to create document link")
+	}
+
+	log.Info("document link created successfully", "linkID", link.ID, "sourceID", sourceID, "targetID", targetID, "type", linkType, "tenantID", tenantID)
+	return link, nil
+}
+
+// GetLink retrieves a link of a document the user can read
+func (u *documentLinkUseCase) GetLink(ctx context.Context, documentID, id, tenantID, userID string) (*models.DocumentLink, error) {
+	return u.getLink(ctx, documentID, id, tenantID, userID, services.PermissionRead)
+}
+
+// ListLinks lists the links of a document the user can read
+func (u *documentLinkUseCase) ListLinks(ctx context.Context, documentID, tenantID, userID string) ([]models.DocumentLink, error) {
+	log := logger.WithContext(ctx)
+
+	if err := u.validateInput(map[string]string{
+		"document ID": documentID,
+		"tenant ID":   tenantID,
+		"user ID":     userID,
+	}); err != nil {
+		return nil, err
+	}
+	if err := u.verifyDocumentAccess(ctx, documentID, tenantID, userID, services.PermissionRead); err != nil {
+		return nil, err
+	}
+
+	links, err := u.linkRepo.ListByDocument(ctx, documentID, tenantID)
+	if err != nil {
+		log.WithError(err).Error("failed to list document links", "documentID", documentID, "tenantID", tenantID)
+		return nil, errors.Wrap(err, "failed to list document links")
+	}
+
+	return links, nil
+}
+
+// UpdateLink changes the type of a link whose source document the user can write
+func (u *documentLinkUseCase) UpdateLink(ctx context.Context, documentID, id, linkType, tenantID, userID string) (*models.DocumentLink, error) {
+	log := logger.WithContext(ctx)
+
+	link, err := u.getLink(ctx, documentID, id, tenantID, userID, services.PermissionRead)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.verifyDocumentAccess(ctx, link.SourceID, tenantID, userID, services.PermissionWrite); err != nil {
+		return nil, err
+	}
+
+	// Changing a link to the type it has changes nothing
+	if link.Type == linkType {
+		return link, nil
+	}
+	link.ChangeType(linkType)
+	if err := link.Validate(); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	if err := u.linkRepo.Update(ctx, link); err != nil {
+		log.WithError(err).Error("failed to update document link", "linkID", id, "tenantID", tenantID)
+		return nil, errors.Wrap(err, "failed to update document link")
+	}
+
+	log.Info("document link updated successfully", "linkID", id, "type", linkType, "tenantID", tenantID)
+	return link, nil
+}
+
+// DeleteLink deletes a link whose source document the user can write
+func (u *documentLinkUseCase) DeleteLink(ctx context.Context, documentID, id, tenantID, userID string) error {
+	log := logger.WithContext(ctx)
+
+	link, err := u.getLink(ctx, documentID, id, tenantID, userID, services.PermissionRead)
+	if err != nil {
+		return err
+	}
+	if err := u.verifyDocumentAccess(ctx, link.SourceID, tenantID, userID, services.PermissionWrite); err != nil {
+		return err
+	}
+
+	if err := u.linkRepo.Delete(ctx, id, tenantID); err != nil {
+		log.WithError(err).Error("failed to delete document link", "linkID", id, "tenantID", tenantID)
+		return errors.Wrap(err, "failed to delete document link")
+	}
+
+	log.Info("document link deleted successfully", "linkID", id, "tenantID", tenantID)
+	return nil
+}
+
+// getLink retrieves a link the document is the source or target of, after checking the user has the
+// permission on the document
+func (u *documentLinkUseCase) getLink(ctx context.Context, documentID, id, tenantID, userID, permission string) (*models.DocumentLink, error) {
+	if err := u.validateInput(map[string]string{
+		"document ID": documentID,
+		"link ID":     id,
+		"tenant ID":   tenantID,
+		"user ID":     userID,
+	}); err != nil {
+		return nil, err
+	}
+	if err := u.verifyDocumentAccess(ctx, documentID, tenantID, userID, permission); err != nil {
+		return nil, err
+	}
+
+	link, err := u.linkRepo.GetByID(ctx, id, tenantID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get document link")
+	}
+	if !link.Involves(documentID) {
+		return nil, ErrDocumentLinkNotFound
+	}
+
+	return link, nil
+}
+
+// verifyDocumentAccess checks that the user has the permission on the document
+func (u *documentLinkUseCase) verifyDocumentAccess(ctx context.Context, documentID, tenantID, userID, permission string) error {
+	hasAccess, err := u.authService.VerifyResourceAccess(ctx, userID, tenantID, services.ResourceTypeDocument, documentID, permission)
+	if err != nil {
+		logger.WithContext(ctx).WithError(err).Error("failed to verify document access", "documentID", documentID, "userID", userID)
+		return errors.Wrap(err, "failed to verify document access")
+	}
+	if !hasAccess {
+		return ErrPermissionDenied
+	}
+	return nil
+}
+
+// validateInput validates that required input parameters are not empty
+func (u *documentLinkUseCase) validateInput(params map[string]string) error {
+	for name, value := range params {
+		if value == "" {
+			return errors.NewValidationError(fmt.Sprintf("%s cannot be empty", name))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,253 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert" // v1.8.0+
+	"github.com/stretchr/testify/mock"   // v1.8.0+
+	"github.com/stretchr/testify/suite"  // v1.8.0+
+
+	"../../domain/models"
+	"../../domain/repositories"
+	"../../domain/services"
+	pkgErrors "../../pkg/errors"
+)
+
+// MockDocumentLinkRepository is a mock implementation of the DocumentLinkRepository interface for testing
+type MockDocumentLinkRepository struct {
+	mock.Mock
+}
+
+// Create mock implementation for persisting a document link
+func (m *MockDocumentLinkRepository) Create(ctx context.Context, link *models.DocumentLink) (string, error) {
+	args := m.Called(ctx, link)
+	return args.String(0), args.Error(1)
+}
+
+// GetByID mock implementation for retrieving a document link
+func (m *MockDocumentLinkRepository) GetByID(ctx context.Context, id string, tenantID string) (*models.DocumentLink, error) {
+	args := m.Called(ctx, id, tenantID)
+	if link := args.Get(0); link != nil {
+		return link.(*models.DocumentLink), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// Update mock implementation for updating a document link
+func (m *MockDocumentLinkRepository) Update(ctx context.Context, link *models.DocumentLink) error {
+	args := m.Called(ctx, link)
+	return args.Error(0)
+}
+
+// Delete mock implementation for deleting a document link
+func (m *MockDocumentLinkRepository) Delete(ctx context.Context, id string, tenantID string) error {
+	args := m.Called(ctx, id, tenantID)
+	return args.Error(0)
+}
+
+// ListByDocument mock implementation for listing the links of a document
+func (m *MockDocumentLinkRepository) ListByDocument(ctx context.Context, documentID string, tenantID string) ([]models.DocumentLink, error) {
+	args := m.Called(ctx, documentID, tenantID)
+	if links := args.Get(0); links != nil {
+		return links.([]models.DocumentLink), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// mockLinkDocumentRepository mocks the DocumentRepository methods used by document links
+type mockLinkDocumentRepository struct {
+	repositories.DocumentRepository
+	mock.Mock
+}
+
+func (m *mockLinkDocumentRepository) GetByID(ctx context.Context, id string, tenantID string) (*models.Document, error) {
+	args := m.Called(ctx, id, tenantID)
+	if document := args.Get(0); document != nil {
+		return document.(*models.Document), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// mockLinkAuthService mocks the AuthService methods used by document links
+type mockLinkAuthService struct {
+	services.AuthService
+	mock.Mock
+}
+
+func (m *mockLinkAuthService) VerifyResourceAccess(ctx context.Context, userID, tenantID, resourceType, resourceID, accessType string) (bool, error) {
+	args := m.Called(ctx, userID, tenantID, resourceType, resourceID, accessType)
+	return args.Bool(0), args.Error(1)
+}
+
+// DocumentLinkUseCaseTestSuite defines a test suite for DocumentLinkUseCase
+type DocumentLinkUseCaseTestSuite struct {
+	suite.Suite
+	mockLinkRepo     *MockDocumentLinkRepository
+	mockDocumentRepo *mockLinkDocumentRepository
+	mockAuthService  *mockLinkAuthService
+	linkUseCase      DocumentLinkUseCase
+}
+
+// SetupTest sets up the test environment before each test
+func (s *DocumentLinkUseCaseTestSuite) SetupTest() {
+	s.mockLinkRepo = new(MockDocumentLinkRepository)
+	s.mockDocumentRepo = new(mockLinkDocumentRepository)
+	s.mockAuthService = new(mockLinkAuthService)
+
+	var err error
+	s.linkUseCase, err = NewDocumentLinkUseCase(s.mockLinkRepo, s.mockDocumentRepo, s.mockAuthService)
+	assert.Nil(s.T(), err)
+	assert.NotNil(s.T(), s.linkUseCase)
+}
+
+// allow grants or denies the user a permission on a document
+func (s *DocumentLinkUseCaseTestSuite) allow(documentID, permission string, allowed bool) {
+	s.mockAuthService.On("VerifyResourceAccess", mock.Anything, "user123", "tenant123", services.ResourceTypeDocument, documentID, permission).Return(allowed, nil)
+}
+
+// link returns link123 by which amendment123 supersedes contract123
+func (s *DocumentLinkUseCaseTestSuite) link() *models.DocumentLink {
+	link := models.NewDocumentLink("tenant123", "amendment123", "contract123", models.DocumentLinkSupersedes, "user123")
+	link.ID = "link123"
+	return link
+}
+
+// TestNewDocumentLinkUseCase tests the creation of a new DocumentLinkUseCase
+func (s *DocumentLinkUseCaseTestSuite) TestNewDocumentLinkUseCase() {
+	useCase, err := NewDocumentLinkUseCase(s.mockLinkRepo, nil, s.mockAuthService)
+	assert.NotNil(s.T(), err)
+	assert.Nil(s.T(), useCase)
+}
+
+// TestCreateLink_Success tests linking an amendment to the contract it supersedes
+func (s *DocumentLinkUseCaseTestSuite) TestCreateLink_Success() {
+	s.mockDocumentRepo.On("GetByID", mock.Anything, "amendment123", "tenant123").Return(&models.Document{ID: "amendment123", TenantID: "tenant123"}, nil)
+	s.mockDocumentRepo.On("GetByID", mock.Anything, "contract123", "tenant123").Return(&models.Document{ID: "contract123", TenantID: "tenant123"}, nil)
+	s.allow("amendment123", services.PermissionWrite, true)
+	s.allow("contract123", services.PermissionRead, true)
+	s.mockLinkRepo.On("Create", mock.Anything, mock.MatchedBy(func(link *models.DocumentLink) bool {
+		return link.SourceID == "amendment123" && link.TargetID == "contract123" && link.Type == models.DocumentLinkSupersedes && link.CreatedBy == "user123"
+	})).Return("link123", nil)
+
+	link, err := s.linkUseCase.CreateLink(context.Background(), "amendment123", "contract123", models.DocumentLinkSupersedes, "tenant123", "user123")
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), "contract123", link.TargetID)
+	s.mockLinkRepo.AssertExpectations(s.T())
+}
+
+// TestCreateLink_InvalidType tests that unknown link types are rejected before any lookup
+func (s *DocumentLinkUseCaseTestSuite) TestCreateLink_InvalidType() {
+	_, err := s.linkUseCase.CreateLink(context.Background(), "amendment123", "contract123", "replaces", "tenant123", "user123")
+
+	assert.True(s.T(), pkgErrors.IsValidationError(err))
+	s.mockDocumentRepo.AssertNotCalled(s.T(), "GetByID", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestCreateLink_SelfLink tests that a document cannot be linked to itself
+func (s *DocumentLinkUseCaseTestSuite) TestCreateLink_SelfLink() {
+	_, err := s.linkUseCase.CreateLink(context.Background(), "contract123", "contract123", models.DocumentLinkRelatesTo, "tenant123", "user123")
+
+	assert.True(s.T(), pkgErrors.IsValidationError(err))
+}
+
+// TestCreateLink_TargetNotFound tests that links cannot point to documents outside the tenant
+func (s *DocumentLinkUseCaseTestSuite) TestCreateLink_TargetNotFound() {
+	s.mockDocumentRepo.On("GetByID", mock.Anything, "amendment123", "tenant123").Return(&models.Document{ID: "amendment123", TenantID: "tenant123"}, nil)
+	s.mockDocumentRepo.On("GetByID", mock.Anything, "contract123", "tenant123").Return(nil, pkgErrors.NewResourceNotFoundError("document not found"))
+
+	_, err := s.linkUseCase.CreateLink(context.Background(), "amendment123", "contract123", models.DocumentLinkSupersedes, "tenant123", "user123")
+
+	assert.True(s.T(), pkgErrors.IsResourceNotFoundError(err))
+	s.mockLinkRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+}
+
+// TestCreateLink_SourceNotWritable tests that readers of the source cannot link it
+func (s *DocumentLinkUseCaseTestSuite) TestCreateLink_SourceNotWritable() {
+	s.mockDocumentRepo.On("GetByID", mock.Anything, mock.Anything, "tenant123").Return(&models.Document{TenantID: "tenant123"}, nil)
+	s.allow("amendment123", services.PermissionWrite, false)
+
+	_, err := s.linkUseCase.CreateLink(context.Background(), "amendment123", "contract123", models.DocumentLinkSupersedes, "tenant123", "user123")
+
+	assert.Equal(s.T(), ErrPermissionDenied, err)
+	s.mockLinkRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+}
+
+// TestGetLink_FromTarget tests that a link is retrieved through the document it points to
+func (s *DocumentLinkUseCaseTestSuite) TestGetLink_FromTarget() {
+	s.allow("contract123", services.PermissionRead, true)
+	s.mockLinkRepo.On("GetByID", mock.Anything, "link123", "tenant123").Return(s.link(), nil)
+
+	link, err := s.linkUseCase.GetLink(context.Background(), "contract123", "link123", "tenant123", "user123")
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), "amendment123", link.OtherEnd("contract123"))
+}
+
+// TestGetLink_OtherDocument tests that links of other documents are not found through a document
+func (s *DocumentLinkUseCaseTestSuite) TestGetLink_OtherDocument() {
+	s.allow("other123", services.PermissionRead, true)
+	s.mockLinkRepo.On("GetByID", mock.Anything, "link123", "tenant123").Return(s.link(), nil)
+
+	_, err := s.linkUseCase.GetLink(context.Background(), "other123", "link123", "tenant123", "user123")
+
+	assert.True(s.T(), pkgErrors.IsResourceNotFoundError(err))
+}
+
+// TestListLinks_Success tests listing the links of a document
+func (s *DocumentLinkUseCaseTestSuite) TestListLinks_Success() {
+	s.allow("contract123", services.PermissionRead, true)
+	s.mockLinkRepo.On("ListByDocument", mock.Anything, "contract123", "tenant123").Return([]models.DocumentLink{*s.link()}, nil)
+
+	links, err := s.linkUseCase.ListLinks(context.Background(), "contract123", "tenant123", "user123")
+
+	assert.Nil(s.T(), err)
+	assert.Len(s.T(), links, 1)
+}
+
+// TestUpdateLink_Success tests changing the type of a link
+func (s *DocumentLinkUseCaseTestSuite) TestUpdateLink_Success() {
+	s.allow("amendment123", services.PermissionRead, true)
+	s.allow("amendment123", services.PermissionWrite, true)
+	s.mockLinkRepo.On("GetByID", mock.Anything, "link123", "tenant123").Return(s.link(), nil)
+	s.mockLinkRepo.On("Update", mock.Anything, mock.MatchedBy(func(link *models.DocumentLink) bool {
+		return link.Type == models.DocumentLinkRelatesTo
+	})).Return(nil)
+
+	link, err := s.linkUseCase.UpdateLink(context.Background(), "amendment123", "link123", models.DocumentLinkRelatesTo, "tenant123", "user123")
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), models.DocumentLinkRelatesTo, link.Type)
+	s.mockLinkRepo.AssertExpectations(s.T())
+}
+
+// TestDeleteLink_TargetWriterDenied tests that writing the target of a link does not allow deleting it
+func (s *DocumentLinkUseCaseTestSuite) TestDeleteLink_TargetWriterDenied() {
+	s.allow("contract123", services.PermissionRead, true)
+	s.allow("amendment123", services.PermissionWrite, false)
+	s.mockLinkRepo.On("GetByID", mock.Anything, "link123", "tenant123").Return(s.link(), nil)
+
+	err := s.linkUseCase.DeleteLink(context.Background(), "contract123", "link123", "tenant123", "user123")
+
+	assert.Equal(s.T(), ErrPermissionDenied, err)
+	s.mockLinkRepo.AssertNotCalled(s.T(), "Delete", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestDeleteLink_Success tests deleting a link of a document the user can write
+func (s *DocumentLinkUseCaseTestSuite) TestDeleteLink_Success() {
+	s.allow("amendment123", services.PermissionRead, true)
+	s.allow("amendment123", services.PermissionWrite, true)
+	s.mockLinkRepo.On("GetByID", mock.Anything, "link123", "tenant123").Return(s.link(), nil)
+	s.mockLinkRepo.On("Delete", mock.Anything, "link123", "tenant123").Return(nil)
+
+	err := s.linkUseCase.DeleteLink(context.Background(), "amendment123", "link123", "tenant123", "user123")
+
+	assert.Nil(s.T(), err)
+	s.mockLinkRepo.AssertExpectations(s.T())
+}
+
+// TestDocumentLinkUseCaseSuite runs the test suite
+func TestDocumentLinkUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(DocumentLinkUseCaseTestSuite))
+}
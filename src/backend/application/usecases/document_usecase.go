@@ -2,10 +2,14 @@
 package usecases
 
 import (
+	"bytes"   // standard library
 	"context" // standard library
+	stderrors "errors" // standard library
 	"fmt"    // standard library
 	"io"      // standard library
+	"sort"    // standard library
 	"strings" // standard library
+	"sync"    // standard library
 
 	"time"
 
@@ -14,8 +18,10 @@ import (
 	"../../domain/models"
 	"../../domain/repositories"
 	"../../domain/services"
+	"../../pkg/delta"
 	"../../pkg/errors"
 	"../../pkg/logger"
+	"../../pkg/metrics"
 	"../../pkg/utils"
 )
 
@@ -29,6 +35,12 @@ var (
 	ErrEmptyContent         = errors.NewValidationError("document content cannot be empty")
 	ErrDocumentNotAvailable = errors.NewValidationError("document is not available for download")
 	ErrPermissionDenied     = errors.NewAuthorizationError("permission denied for document operation")
+	ErrRangeNotSatisfiable  = errors.NewValidationError("requested range not satisfiable")
+	ErrBulkUpdateAborted    = errors.NewValidationError("document not updated because other documents of the bulk update failed")
+	ErrDocumentLocked       = errors.NewValidationError("document is locked while it awaits approval")
+	ErrWatermarkRequired    = errors.NewValidationError("document is watermarked on download and has no direct download link")
+	ErrVersionNotFound      = errors.NewResourceNotFoundError("document version not found")
+	ErrVersionNotAvailable  = errors.NewValidationError("document version is not available for download")
 )
 
 // Global event type constants for document events
@@ -38,12 +50,69 @@ const (
 	DocumentEventDownloaded  = "document.downloaded"
 	DocumentEventDeleted     = "document.deleted"
 	DocumentEventQuarantined = "document.quarantined"
+
+	// DocumentEventMetadataUpdated is published once for all documents of a bulk metadata update
+	DocumentEventMetadataUpdated = "document.metadata_updated"
 )
 
+// MaxBatchUploadSize is the maximum number of files uploaded in one batch
+const MaxBatchUploadSize = 20
+
+// batchUploadConcurrency bounds the files of a batch that are uploaded at the same time
+const batchUploadConcurrency = 4
+
+// MaxBulkMetadataUpdateSize is the maximum number of documents whose metadata is updated at once
+const MaxBulkMetadataUpdateSize = 1000
+
+// DocumentUpload describes a file of a batch upload
+type DocumentUpload struct {
+	Name        string
+	ContentType string
+	Size        int64
+	Content     io.Reader
+	Metadata    map[string]string
+	Priority    string // Scan priority hint, see UploadDocument
+	Checksum    string // SHA-256 checksum sent by the client, see UploadDocument
+}
+
+// DocumentUploadResult is the outcome of uploading a file of a batch: the ID of the new document, or
+// the error that rejected the file
+type DocumentUploadResult struct {
+	Name       string
+	DocumentID string
+	Err        error
+}
+
+// BulkMetadataResult is the outcome of a bulk metadata update for a document: nil Err when the
+// document was updated
+type BulkMetadataResult struct {
+	DocumentID string
+	Err        error
+}
+
+// DocumentDownload is the content of a document's latest version sent to a client, with what the
+// client needs to validate and resume a partial download
+type DocumentDownload struct {
+	Content      io.ReadCloser
+	FileName     string
+	ContentType  string
+	Size         int64            // Size of the whole version in bytes
+	ETag         string           // Strong entity tag of the version, derived from its content hash
+	LastModified time.Time        // Creation time of the version
+	Range        *utils.ByteRange // Part of the version in Content, nil for the whole version
+	Watermarked  bool             // Content is stamped for this download, so it has no entity tag and is never a range
+}
+
 // DocumentUseCase defines the contract for document use cases
 type DocumentUseCase interface {
-	// UploadDocument uploads a new document to the system
-	UploadDocument(ctx context.Context, name string, contentType string, size int64, folderID string, tenantID string, userID string, content io.Reader, metadata map[string]string) (string, error)
+	// UploadDocument uploads a new document to the system. The priority hint is a scan priority
+	// constant of services, such as low for bulk imports; empty scans the document with normal priority.
+	// The checksum is the hex encoded SHA-256 hash the client calculated for the content; when it is
+	// not empty, uploads whose content or stored copy do not match it are rejected.
+	UploadDocument(ctx context.Context, name string, contentType string, size int64, folderID string, tenantID string, userID string, content io.Reader, metadata map[string]string, priority string, checksum string) (string, error)
+
+	// UploadDocuments uploads several documents into a folder, returning the outcome of each file in order
+	UploadDocuments(ctx context.Context, uploads []DocumentUpload, folderID string, tenantID string, userID string) ([]DocumentUploadResult, error)
 
 	// GetDocument retrieves a document by its ID with tenant isolation and permission checks
 	GetDocument(ctx context.Context, id string, tenantID string, userID string) (*models.Document, error)
@@ -51,6 +120,26 @@ type DocumentUseCase interface {
 	// DownloadDocument downloads a document by its ID with tenant isolation and permission checks
 	DownloadDocument(ctx context.Context, id string, tenantID string, userID string) (io.ReadCloser, string, error)
 
+	// DownloadDocumentRange downloads the part of a document selected by the Range and If-Range header values.
+	// The whole document is returned when no range is requested or the client's copy is outdated.
+	DownloadDocumentRange(ctx context.Context, id string, tenantID string, userID string, rangeHeader string, ifRange string) (*DocumentDownload, error)
+
+	// ListDocumentVersions lists the versions of a document, newest first, with tenant isolation and permission checks
+	ListDocumentVersions(ctx context.Context, id string, tenantID string, userID string) ([]models.DocumentVersion, error)
+
+	// DownloadDocumentVersion downloads a version of a document, or the part of it selected by the
+	// Range and If-Range header values, with the same checks as the latest version
+	DownloadDocumentVersion(ctx context.Context, id string, versionID string, tenantID string, userID string, rangeHeader string, ifRange string) (*DocumentDownload, error)
+
+	// GetVersionSignature calculates the block signature of a version of a document the user can
+	// write, which clients build delta patches against. A block size of 0 uses delta.DefaultBlockSize.
+	GetVersionSignature(ctx context.Context, id string, versionID string, blockSize int, tenantID string, userID string) (*delta.Signature, error)
+
+	// UploadVersionDelta creates a new version of a document from a delta patch against one of its
+	// versions, with tenant isolation and permission checks. The rebuilt content must have the
+	// declared size and match the checksum, the hex encoded SHA-256 hash the client calculated for it.
+	UploadVersionDelta(ctx context.Context, id string, baseVersionID string, size int64, checksum string, patch io.Reader, tenantID string, userID string) (*models.DocumentVersion, error)
+
 	// GetDocumentPresignedURL generates a presigned URL for document download with tenant isolation and permission checks
 	GetDocumentPresignedURL(ctx context.Context, id string, tenantID string, userID string, expirationSeconds int) (string, error)
 
@@ -81,6 +170,10 @@ type DocumentUseCase interface {
 	// DeleteDocumentMetadata deletes document metadata with tenant isolation and permission checks
 	DeleteDocumentMetadata(ctx context.Context, id string, key string, tenantID string, userID string) error
 
+	// BulkUpdateMetadata applies a metadata patch to many documents, returning the outcome of each
+	// document in order. With atomic, no document is updated unless all of them can be.
+	BulkUpdateMetadata(ctx context.Context, documentIDs []string, patch models.MetadataPatch, atomic bool, tenantID string, userID string) ([]BulkMetadataResult, error)
+
 	// GetDocumentThumbnail retrieves a document thumbnail with tenant isolation and permission checks
 	GetDocumentThumbnail(ctx context.Context, id string, tenantID string, userID string) (io.ReadCloser, error)
 
@@ -89,6 +182,17 @@ type DocumentUseCase interface {
 
 	// GetDocumentStatus gets the current status of a document with tenant isolation and permission checks
 	GetDocumentStatus(ctx context.Context, id string, tenantID string, userID string) (string, error)
+
+	// GetQuota gets the tenant's storage quota and the usage counted against it
+	GetQuota(ctx context.Context, tenantID string) (*models.TenantQuota, error)
+
+	// ScheduleDocument sets when a document is published to readers and when it expires, with tenant
+	// isolation and permission checks. Nil times publish the document right away and never expire it.
+	ScheduleDocument(ctx context.Context, id string, publishAt, expireAt *time.Time, tenantID string, userID string) (*models.Document, error)
+
+	// VerifyIntegrity reads the stored content of a document's latest version back and hashes it again,
+	// with tenant isolation and permission checks, finding content corrupted in storage or on upload
+	VerifyIntegrity(ctx context.Context, id string, tenantID string, userID string) (*models.IntegrityCheck, error)
 }
 
 // documentUseCase implements the DocumentUseCase interface
@@ -101,6 +205,18 @@ type documentUseCase struct {
 	eventService      services.EventServiceInterface
 	authService       services.AuthService
 	thumbnailService  services.ThumbnailService
+	txManager         repositories.TransactionManager
+	auditService      services.AuditService
+	policyEngine      services.PolicyEngine
+	quotaService      services.QuotaService
+	uploadLimitService services.UploadLimitService
+	metadataTemplateService services.MetadataTemplateService
+	metadataSchemaService services.MetadataSchemaService
+	sequenceService   services.SequenceService
+	watermarkService  services.WatermarkService
+	downloadPolicyService services.DownloadPolicyService
+	uploadPolicyService services.UploadPolicyService
+	inlineScanService services.InlineScanService // Scans small uploads while they are uploaded; nil to queue every upload
 	logger            *logger.Logger
 }
 
@@ -114,6 +230,18 @@ func NewDocumentUseCase(
 	eventService services.EventServiceInterface,
 	authService services.AuthService,
 	thumbnailService services.ThumbnailService,
+	txManager repositories.TransactionManager,
+	auditService services.AuditService,
+	policyEngine services.PolicyEngine,
+	quotaService services.QuotaService,
+	uploadLimitService services.UploadLimitService,
+	metadataTemplateService services.MetadataTemplateService,
+	metadataSchemaService services.MetadataSchemaService,
+	sequenceService services.SequenceService,
+	watermarkService services.WatermarkService,
+	downloadPolicyService services.DownloadPolicyService,
+	uploadPolicyService services.UploadPolicyService,
+	inlineScanService services.InlineScanService,
 ) (DocumentUseCase, error) {
 	// Validate that documentRepo is not nil
 	if documentRepo == nil {
@@ -153,6 +281,50 @@ func NewDocumentUseCase(
 		return nil, fmt.Errorf("thumbnailService cannot be nil")
 	}
 
+	if txManager == nil {
+		return nil, fmt.Errorf("txManager cannot be nil")
+	}
+
+	if auditService == nil {
+		return nil, fmt.Errorf("auditService cannot be nil")
+	}
+
+	if policyEngine == nil {
+		return nil, fmt.Errorf("policyEngine cannot be nil")
+	}
+
+	if quotaService == nil {
+		return nil, fmt.Errorf("quotaService cannot be nil")
+	}
+
+	if uploadLimitService == nil {
+		return nil, fmt.Errorf("uploadLimitService cannot be nil")
+	}
+
+	if metadataTemplateService == nil {
+		return nil, fmt.Errorf("metadataTemplateService cannot be nil")
+	}
+
+	if metadataSchemaService == nil {
+		return nil, fmt.Errorf("metadataSchemaService cannot be nil")
+	}
+
+	if sequenceService == nil {
+		return nil, fmt.Errorf("sequenceService cannot be nil")
+	}
+
+	if watermarkService == nil {
+		return nil, fmt.Errorf("watermarkService cannot be nil")
+	}
+
+	if downloadPolicyService == nil {
+		return nil, fmt.Errorf("downloadPolicyService cannot be nil")
+	}
+
+	if uploadPolicyService == nil {
+		return nil, fmt.Errorf("uploadPolicyService cannot be nil")
+	}
+
 	// Create and return a new documentUseCase with the provided dependencies
 	return &documentUseCase{
 		documentRepo:      documentRepo,
@@ -163,12 +335,24 @@ func NewDocumentUseCase(
 		eventService:      eventService,
 		authService:       authService,
 		thumbnailService:  thumbnailService,
+		txManager:         txManager,
+		auditService:      auditService,
+		policyEngine:      policyEngine,
+		quotaService:      quotaService,
+		uploadLimitService: uploadLimitService,
+		metadataTemplateService: metadataTemplateService,
+		metadataSchemaService: metadataSchemaService,
+		sequenceService:   sequenceService,
+		watermarkService:  watermarkService,
+		downloadPolicyService: downloadPolicyService,
+		uploadPolicyService: uploadPolicyService,
+		inlineScanService: inlineScanService,
 		logger:            logger.WithField("usecase", "document"),
 	}, nil
 }
 
 // UploadDocument uploads a new document to the system
-func (uc *documentUseCase) UploadDocument(ctx context.Context, name string, contentType string, size int64, folderID string, tenantID string, userID string, content io.Reader, metadata map[string]string) (string, error) {
+func (uc *documentUseCase) UploadDocument(ctx context.Context, name string, contentType string, size int64, folderID string, tenantID string, userID string, content io.Reader, metadata map[string]string, priority string, checksum string) (string, error) {
 	// Get logger with context
 	log := uc.logger.WithContext(ctx)
 
@@ -190,6 +374,19 @@ func (uc *documentUseCase) UploadDocument(ctx context.Context, name string, cont
 		return "", errors.NewValidationError("document size must be greater than 0")
 	}
 
+	// Validate the priority hint, if any
+	if priority != "" && !services.IsValidScanPriority(priority) {
+		log.Error("Invalid scan priority", "priority", priority)
+		return "", errors.NewValidationError(fmt.Sprintf("invalid priority: %s", priority))
+	}
+
+	// Validate the client's checksum, if any
+	checksum = strings.ToLower(strings.TrimSpace(checksum))
+	if checksum != "" && !utils.IsValidHash(checksum, utils.HashAlgorithmSHA256) {
+		log.Error("Invalid document checksum", "checksum", checksum)
+		return "", errors.NewValidationError("checksum must be a hex encoded SHA-256 hash")
+	}
+
 	// Validate folderID is not empty
 	if strings.TrimSpace(folderID) == "" {
 		log.Error("Folder ID cannot be empty")
@@ -221,17 +418,34 @@ func (uc *documentUseCase) UploadDocument(ctx context.Context, name string, cont
 		return "", errors.Wrap(err, "failed to get folder or verify permissions")
 	}
 
-	// Create a new document using models.NewDocument
-	document := models.NewDocument(name, contentType, size, folderID, tenantID, userID)
-	document.ID = uuid.New().String()
+	// The document number is assigned by the folder's numbering sequence and personal data tags by
+	// classification, never by the uploader
+	for key := range metadata {
+		if models.IsReservedMetadataKey(key) {
+			log.Error("Document upload sets reserved metadata", "key", key)
+			return "", errors.NewValidationError(fmt.Sprintf("metadata %s is reserved", key))
+		}
+	}
 
-	// Store document content in temporary storage using storageService.StoreTemporary
-	tempPath, err := uc.storageService.StoreTemporary(ctx, tenantID, document.ID, content, size, contentType)
+	// Reject uploads missing the metadata the folder's template enforces
+	if err := uc.metadataTemplateService.CheckUpload(ctx, tenantID, folderID, metadata); err != nil {
+		log.WithError(err).Error("Document upload rejected by folder metadata template", "folderID", folderID)
+		return "", err
+	}
+
+	// Reject files of types, extensions and sizes the tenant does not accept. The content type is
+	// sniffed from the first bytes of the content rather than trusted from the client, and the
+	// content cannot be longer than its declared size.
+	contentType, content, err = uc.uploadPolicyService.CheckUpload(ctx, tenantID, name, contentType, size, content)
 	if err != nil {
-		log.WithError(err).Error("Failed to store document in temporary storage")
-		return "", errors.Wrap(err, "failed to store document in temporary storage")
+		log.WithError(err).Error("Document upload rejected by upload policy", "tenantID", tenantID, "name", name, "contentType", contentType)
+		return "", err
 	}
 
+	// Create a new document using models.NewDocument
+	document := models.NewDocument(name, contentType, size, folderID, tenantID, userID)
+	document.ID = uuid.New().String()
+
 	// Add metadata to the document if provided
 	if metadata != nil {
 		for key, value := range metadata {
@@ -239,55 +453,184 @@ func (uc *documentUseCase) UploadDocument(ctx context.Context, name string, cont
 		}
 	}
 
-	// Persist the document to the repository using documentRepo.Create
-	documentID, err := uc.documentRepo.Create(ctx, &document)
+	// Evaluate the tenant's attribute-based access policies against the new document's attributes
+	if err := uc.policyEngine.Enforce(ctx, tenantID, userID, models.PolicyActionWrite, &document); err != nil {
+		log.WithError(err).Error("Document upload denied by policy", "tenantID", tenantID, "userID", userID)
+		return "", err
+	}
+
+	// Reject files larger than the user may upload, or beyond the user's daily upload volume
+	if err := uc.uploadLimitService.CheckUpload(ctx, tenantID, userID, size); err != nil {
+		log.WithError(err).Error("Document upload rejected by upload limits", "userID", userID, "size", size)
+		return "", err
+	}
+
+	// Reject uploads exceeding the tenant's quota before storing their content
+	if _, err := uc.quotaService.CheckUpload(ctx, tenantID, userID, size); err != nil {
+		log.WithError(err).Error("Document upload rejected by quota", "tenantID", tenantID, "size", size)
+		return "", err
+	}
+
+	// Find the sequence numbering the folder's uploads. Gap-tolerant sequences assign the number
+	// now, so concurrent uploads do not wait on each other; gap-free ones assign it in the upload
+	// transaction below, so a failed upload gives its number back.
+	sequence, err := uc.sequenceService.GetUploadSequence(ctx, tenantID, folderID)
 	if err != nil {
-		log.WithError(err).Error("Failed to persist document to repository")
-		return "", errors.Wrap(err, "failed to persist document to repository")
+		log.WithError(err).Error("Failed to load folder numbering sequence", "folderID", folderID)
+		return "", err
+	}
+	if sequence != nil && !sequence.IsGapFree() {
+		number, err := uc.sequenceService.NextNumber(ctx, sequence)
+		if err != nil {
+			return "", err
+		}
+		document.AddMetadata(models.DocumentNumberMetadataKey, number)
 	}
 
-	// Create initial document version
-	versionID := uuid.New().String()
-	version := models.DocumentVersion{
-		ID:            versionID,
-		DocumentID:    documentID,
-		VersionNumber: 1, // Initial version
-		Size:          size,
-		ContentHash:   "N/A", // TODO: Calculate content hash
-		Status:        models.VersionStatusProcessing,
-		StoragePath:   tempPath,
-		CreatedAt:     time.Now(),
-		CreatedBy:     userID,
+	// Hash the content while it is stored, so identical content can be deduplicated once it is processed
+	hashingReader, err := utils.NewHashingReader(content, utils.HashAlgorithmSHA256)
+	if err != nil {
+		log.WithError(err).Error("Failed to create content hasher")
+		return "", errors.Wrap(err, "failed to create content hasher")
 	}
 
-	_, err = uc.documentRepo.AddVersion(ctx, &version)
+	// Resolve the key the content is encrypted with, so key rotation can find versions encrypted with old keys
+	encryptionKeyID, err := uc.storageService.GetEncryptionKeyID(ctx, tenantID)
 	if err != nil {
-		log.WithError(err).Error("Failed to create initial document version")
-		return "", errors.Wrap(err, "failed to create initial document version")
+		log.WithError(err).Error("Failed to resolve document encryption key")
+		return "", errors.Wrap(err, "failed to resolve document encryption key")
 	}
 
-	// Queue document for virus scanning using virusScanningService.QueueForScanning
-	err = uc.virusScanningService.QueueForScanning(ctx, documentID, versionID, tenantID, tempPath)
+	// Small files are buffered while they are stored, so they can be scanned inline from memory.
+	// Content longer than its declared size is stored as is and queued for scanning.
+	var storedContent io.Reader = hashingReader
+	var inlineContent []byte
+	if uc.inlineScanService != nil && uc.inlineScanService.Applies(size) {
+		inlineContent, err = io.ReadAll(io.LimitReader(hashingReader, size+1))
+		if err != nil {
+			log.WithError(err).Error("Failed to read document content")
+			return "", errors.Wrap(err, "failed to read document content")
+		}
+		storedContent = io.MultiReader(bytes.NewReader(inlineContent), hashingReader)
+		if int64(len(inlineContent)) > size {
+			inlineContent = nil
+		}
+	}
+
+	// Store document content in temporary storage using storageService.StoreTemporary
+	tempPath, err := uc.storageService.StoreTemporary(ctx, tenantID, document.ID, storedContent, size, contentType)
 	if err != nil {
-		log.WithError(err).Error("Failed to queue document for virus scanning")
-		return "", errors.Wrap(err, "failed to queue document for virus scanning")
+		log.WithError(err).Error("Failed to store document in temporary storage")
+		return "", errors.Wrap(err, "failed to store document in temporary storage")
 	}
 
-	// Publish document.uploaded event using eventService
-	additionalData := map[string]interface{}{
-		"name":      name,
-		"folderID":  folderID,
-		"size":      size,
-		"contentType": contentType,
-		"userID":    userID,
+	// Content the client sent a checksum for is checked as received and read back once it is
+	// stored, so neither a buggy client nor a faulty write silently corrupts it
+	var integrityVerifiedAt *time.Time
+	if checksum != "" {
+		if err := uc.verifyUploadChecksum(ctx, tempPath, hashingReader.Sum(), checksum); err != nil {
+			if deleteErr := uc.storageService.DeleteDocument(ctx, tempPath); deleteErr != nil {
+				log.WithError(deleteErr).Error("Failed to delete document failing checksum verification", "tempPath", tempPath)
+			}
+			log.WithError(err).Error("Document upload failed checksum verification", "name", name, "checksum", checksum)
+			return "", err
+		}
+		verifiedAt := time.Now()
+		integrityVerifiedAt = &verifiedAt
 	}
 
-	_, err = uc.eventService.CreateAndPublishDocumentEvent(ctx, DocumentEventUploaded, tenantID, documentID, additionalData)
+	// Persist the document, its initial version and the document.uploaded event in a single transaction
+	var documentID string
+	var version models.DocumentVersion
+	versionID := uuid.New().String()
+	err = uc.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		// Count the document against the tenant's quota; concurrent uploads may have used it up since the check
+		if _, err := uc.quotaService.ReserveUpload(txCtx, tenantID, size); err != nil {
+			log.WithError(err).Error("Failed to reserve tenant quota", "tenantID", tenantID, "size", size)
+			return err
+		}
+
+		// Assign the number of a gap-free sequence, which stays locked until the upload commits
+		if sequence != nil && sequence.IsGapFree() {
+			number, err := uc.sequenceService.NextNumber(txCtx, sequence)
+			if err != nil {
+				return err
+			}
+			document.AddMetadata(models.DocumentNumberMetadataKey, number)
+		}
+
+		// Persist the document to the repository using documentRepo.Create
+		id, err := uc.documentRepo.Create(txCtx, &document)
+		if err != nil {
+			log.WithError(err).Error("Failed to persist document to repository")
+			return errors.Wrap(err, "failed to persist document to repository")
+		}
+		documentID = id
+
+		// Create initial document version
+		version = models.DocumentVersion{
+			ID:              versionID,
+			DocumentID:      documentID,
+			VersionNumber:   1, // Initial version
+			Size:            size,
+			ContentHash:         hashingReader.Sum(),
+			EncryptionKeyID:     encryptionKeyID,
+			ClientChecksum:      checksum,
+			IntegrityVerifiedAt: integrityVerifiedAt,
+			Status:              models.VersionStatusProcessing,
+			StoragePath:         tempPath,
+			CreatedAt:           time.Now(),
+			CreatedBy:           userID,
+		}
+
+		_, err = uc.documentRepo.AddVersion(txCtx, &version)
+		if err != nil {
+			log.WithError(err).Error("Failed to create initial document version")
+			return errors.Wrap(err, "failed to create initial document version")
+		}
+
+		// Enqueue document.uploaded event using eventService
+		additionalData := map[string]interface{}{
+			"name":        name,
+			"folderID":    folderID,
+			"size":        size,
+			"contentType": contentType,
+			"userID":      userID,
+		}
+		if number := document.GetMetadata(models.DocumentNumberMetadataKey); number != "" {
+			additionalData["documentNumber"] = number
+		}
+
+		_, err = uc.eventService.CreateAndPublishDocumentEvent(txCtx, DocumentEventUploaded, tenantID, documentID, additionalData)
+		if err != nil {
+			log.WithError(err).Error("Failed to enqueue document.uploaded event")
+			return errors.Wrap(err, "failed to enqueue document.uploaded event")
+		}
+
+		return uc.auditService.RecordAction(txCtx, tenantID, userID, models.AuditActionUpload, models.ResourceTypeDocument, documentID, nil, map[string]interface{}{
+			"name":        name,
+			"folderID":    folderID,
+			"size":        size,
+			"contentType": contentType,
+		})
+	})
 	if err != nil {
-		log.WithError(err).Error("Failed to publish document.uploaded event")
-		// Do not return error, continue processing even if event publishing fails
+		return "", err
+	}
+
+	// Small files are scanned inline and available right away; other files, and small files the
+	// inline scan did not find clean, are queued for virus scanning. Content recently scanned clean
+	// for the tenant is not scanned again.
+	if inlineContent == nil || !uc.inlineScanService.ScanUpload(ctx, &document, &version, bytes.NewReader(inlineContent)) {
+		err = uc.virusScanningService.QueueForScanning(ctx, documentID, versionID, tenantID, tempPath, hashingReader.Sum(), priority)
+		if err != nil {
+			log.WithError(err).Error("Failed to queue document for virus scanning")
+			return "", errors.Wrap(err, "failed to queue document for virus scanning")
+		}
 	}
 
+	metrics.IncDocumentUploads(tenantID, contentType)
+
 	// Log successful document upload
 	log.Info("Document uploaded successfully", "documentID", documentID, "name", name, "size", size, "contentType", contentType)
 
@@ -295,6 +638,79 @@ func (uc *documentUseCase) UploadDocument(ctx context.Context, name string, cont
 	return documentID, nil
 }
 
+// verifyUploadChecksum checks the hash of the content as it was received against the client's
+// checksum, then reads the stored copy back and checks it against the same hash
+func (uc *documentUseCase) verifyUploadChecksum(ctx context.Context, tempPath string, contentHash string, checksum string) error {
+	if contentHash != checksum {
+		return errors.NewValidationError(fmt.Sprintf("document content has SHA-256 hash %s, which does not match its checksum %s", contentHash, checksum))
+	}
+	if err := services.VerifyStoredContent(ctx, uc.storageService, tempPath, checksum); err != nil {
+		return errors.Wrap(err, "stored document content does not match its checksum")
+	}
+	return nil
+}
+
+// UploadDocuments uploads the files of a batch concurrently. Each file is validated and uploaded
+// like a single upload, so one rejected file does not fail the others; the returned error only
+// reports a batch that cannot be started at all.
+func (uc *documentUseCase) UploadDocuments(ctx context.Context, uploads []DocumentUpload, folderID string, tenantID string, userID string) ([]DocumentUploadResult, error) {
+	// Get logger with context
+	log := uc.logger.WithContext(ctx)
+
+	if len(uploads) == 0 {
+		log.Error("Batch upload contains no files")
+		return nil, errors.NewValidationError("at least one file is required")
+	}
+	if len(uploads) > MaxBatchUploadSize {
+		log.Error("Batch upload contains too many files", "count", len(uploads))
+		return nil, errors.NewValidationError(fmt.Sprintf("at most %d files can be uploaded in a batch", MaxBatchUploadSize))
+	}
+	if strings.TrimSpace(folderID) == "" {
+		return nil, ErrInvalidFolderID
+	}
+	if strings.TrimSpace(tenantID) == "" {
+		return nil, ErrInvalidTenantID
+	}
+	if strings.TrimSpace(userID) == "" {
+		return nil, ErrInvalidUserID
+	}
+
+	results := make([]DocumentUploadResult, len(uploads))
+	slots := make(chan struct{}, batchUploadConcurrency)
+	var wg sync.WaitGroup
+	for i, upload := range uploads {
+		results[i].Name = upload.Name
+
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			results[i].Err = errors.Wrap(ctx.Err(), "batch upload cancelled")
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, upload DocumentUpload) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			documentID, err := uc.UploadDocument(ctx, upload.Name, upload.ContentType, upload.Size, folderID, tenantID, userID, upload.Content, upload.Metadata, upload.Priority, upload.Checksum)
+			results[i].DocumentID = documentID
+			results[i].Err = err
+		}(i, upload)
+	}
+	wg.Wait()
+
+	failed := 0
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
+		}
+	}
+	log.Info("Batch upload completed", "folderID", folderID, "files", len(uploads), "failed", failed)
+
+	return results, nil
+}
+
 // GetDocument retrieves a document by its ID with tenant isolation and permission checks
 func (uc *documentUseCase) GetDocument(ctx context.Context, id string, tenantID string, userID string) (*models.Document, error) {
 	// Get logger with context
@@ -349,6 +765,17 @@ func (uc *documentUseCase) GetDocument(ctx context.Context, id string, tenantID
 		return nil, ErrPermissionDenied
 	}
 
+	// Evaluate the tenant's attribute-based access policies
+	if err := uc.policyEngine.Enforce(ctx, tenantID, userID, models.PolicyActionRead, document); err != nil {
+		log.WithError(err).Error("Document access denied by policy", "documentID", id, "tenantID", tenantID, "userID", userID)
+		return nil, err
+	}
+
+	// Documents that are scheduled or expired are only seen by the users staging them
+	if err := uc.checkVisibility(ctx, document, tenantID, userID); err != nil {
+		return nil, err
+	}
+
 	// Log successful document retrieval
 	log.Info("Document retrieved successfully", "documentID", id, "tenantID", tenantID)
 
@@ -358,181 +785,208 @@ func (uc *documentUseCase) GetDocument(ctx context.Context, id string, tenantID
 
 // DownloadDocument downloads a document by its ID with tenant isolation and permission checks
 func (uc *documentUseCase) DownloadDocument(ctx context.Context, id string, tenantID string, userID string) (io.ReadCloser, string, error) {
+	download, err := uc.DownloadDocumentRange(ctx, id, tenantID, userID, "", "")
+	if err != nil {
+		return nil, "", err
+	}
+
+	return download.Content, download.FileName, nil
+}
+
+// DownloadDocumentRange downloads a document, or the requested range of it, with tenant isolation and permission checks
+func (uc *documentUseCase) DownloadDocumentRange(ctx context.Context, id string, tenantID string, userID string, rangeHeader string, ifRange string) (*DocumentDownload, error) {
+	return uc.downloadVersion(ctx, id, "", tenantID, userID, rangeHeader, ifRange)
+}
+
+// DownloadDocumentVersion downloads a version of a document, or the requested range of it, with
+// tenant isolation and permission checks
+func (uc *documentUseCase) DownloadDocumentVersion(ctx context.Context, id string, versionID string, tenantID string, userID string, rangeHeader string, ifRange string) (*DocumentDownload, error) {
+	if strings.TrimSpace(versionID) == "" {
+		uc.logger.WithContext(ctx).Error("Version ID cannot be empty")
+		return nil, errors.NewValidationError("invalid version ID")
+	}
+	return uc.downloadVersion(ctx, id, versionID, tenantID, userID, rangeHeader, ifRange)
+}
+
+// getDownloadableDocument retrieves a document the user is allowed to download, checking the
+// tenant, the user's permissions, the tenant's access policies and the document's visibility
+func (uc *documentUseCase) getDownloadableDocument(ctx context.Context, id string, tenantID string, userID string) (*models.Document, error) {
 	// Get logger with context
 	log := uc.logger.WithContext(ctx)
 
 	// Validate document ID is not empty, return ErrInvalidDocumentID if empty
 	if strings.TrimSpace(id) == "" {
 		log.Error("Document ID cannot be empty")
-		return nil, "", ErrInvalidDocumentID
+		return nil, ErrInvalidDocumentID
 	}
 
 	// Validate tenant ID is not empty, return ErrInvalidTenantID if empty
 	if strings.TrimSpace(tenantID) == "" {
 		log.Error("Tenant ID cannot be empty")
-		return nil, "", ErrInvalidTenantID
+		return nil, ErrInvalidTenantID
 	}
 
 	// Validate user ID is not empty, return ErrInvalidUserID if empty
 	if strings.TrimSpace(userID) == "" {
 		log.Error("User ID cannot be empty")
-		return nil, "", ErrInvalidUserID
+		return nil, ErrInvalidUserID
 	}
 
 	// Retrieve the document from the repository using documentRepo.GetByID
 	document, err := uc.documentRepo.GetByID(ctx, id, tenantID)
 	if err != nil {
 		log.WithError(err).Error("Failed to get document", "documentID", id, "tenantID", tenantID)
-		return nil, "", errors.Wrap(err, "failed to get document")
+		return nil, errors.Wrap(err, "failed to get document")
 	}
 
 	// If document not found, return ErrDocumentNotFound
 	if document == nil {
 		log.Error("Document not found", "documentID", id, "tenantID", tenantID)
-		return nil, "", ErrDocumentNotFound
+		return nil, ErrDocumentNotFound
 	}
 
 	// Verify the document belongs to the specified tenant
 	if document.TenantID != tenantID {
 		log.Error("Document tenant mismatch", "documentID", id, "documentTenantID", document.TenantID, "requestTenantID", tenantID)
-		return nil, "", ErrDocumentNotFound
+		return nil, ErrDocumentNotFound
 	}
 
 	// Check if user has read permission for the document using authService.VerifyResourceAccess
 	hasAccess, err := uc.authService.VerifyResourceAccess(ctx, userID, tenantID, services.ResourceTypeDocument, id, services.PermissionRead)
 	if err != nil {
 		log.WithError(err).Error("Failed to verify document access", "documentID", id, "tenantID", tenantID, "userID", userID)
-		return nil, "", errors.Wrap(err, "failed to verify document access")
+		return nil, errors.Wrap(err, "failed to verify document access")
 	}
 
 	if !hasAccess {
 		log.Error("User does not have read permission for document", "documentID", id, "tenantID", tenantID, "userID", userID)
-		return nil, "", ErrPermissionDenied
-	}
-
-	// Check if document is available for download (status is DocumentStatusAvailable)
-	if !document.IsAvailable() {
-		log.Error("Document is not available for download", "documentID", id, "status", document.Status)
-		return nil, "", ErrDocumentNotAvailable
-	}
-
-	// Get the latest document version
-	latestVersion := document.GetLatestVersion()
-	if latestVersion == nil {
-		log.Error("No versions found for document", "documentID", id)
-		return nil, "", errors.NewResourceNotFoundError("no versions found for document")
-	}
-
-	// Retrieve document content from storage using storageService.GetDocument
-	contentStream, err := uc.storageService.GetDocument(ctx, latestVersion.StoragePath)
-	if err != nil {
-		log.WithError(err).Error("Failed to retrieve document content from storage", "documentID", id, "storagePath", latestVersion.StoragePath)
-		return nil, "", errors.Wrap(err, "failed to retrieve document content from storage")
+		return nil, ErrPermissionDenied
 	}
 
-	// Publish document.downloaded event using eventService
-	additionalData := map[string]interface{}{
-		"name":   document.Name,
-		"userID": userID,
+	// Evaluate the tenant's attribute-based access policies
+	if err := uc.policyEngine.Enforce(ctx, tenantID, userID, models.PolicyActionRead, document); err != nil {
+		log.WithError(err).Error("Document access denied by policy", "documentID", id, "tenantID", tenantID, "userID", userID)
+		return nil, err
 	}
 
-	_, err = uc.eventService.CreateAndPublishDocumentEvent(ctx, DocumentEventDownloaded, tenantID, id, additionalData)
-	if err != nil {
-		log.WithError(err).Error("Failed to publish document.downloaded event")
-		// Do not return error, continue processing even if event publishing fails
+	// Documents that are scheduled or expired are only seen by the users staging them
+	if err := uc.checkVisibility(ctx, document, tenantID, userID); err != nil {
+		return nil, err
 	}
 
-	// Log successful document download
-	log.Info("Document downloaded successfully", "documentID", id, "tenantID", tenantID)
-
-	// Return document content stream, content type, file name, or wrap error with context
-	return contentStream, document.Name, nil
+	return document, nil
 }
 
-// GetDocumentPresignedURL generates a presigned URL for document download with tenant isolation and permission checks
-func (uc *documentUseCase) GetDocumentPresignedURL(ctx context.Context, id string, tenantID string, userID string, expirationSeconds int) (string, error) {
+// downloadVersion downloads a version of a document, the latest one when versionID is empty, or
+// the requested range of it. Every version is protected by the permissions, policies and download
+// policy of its document.
+func (uc *documentUseCase) downloadVersion(ctx context.Context, id string, versionID string, tenantID string, userID string, rangeHeader string, ifRange string) (*DocumentDownload, error) {
 	// Get logger with context
 	log := uc.logger.WithContext(ctx)
 
-	// Validate document ID is not empty, return ErrInvalidDocumentID if empty
-	if strings.TrimSpace(id) == "" {
-		log.Error("Document ID cannot be empty")
-		return "", ErrInvalidDocumentID
-	}
-
-	// Validate tenant ID is not empty, return ErrInvalidTenantID if empty
-	if strings.TrimSpace(tenantID) == "" {
-		log.Error("Tenant ID cannot be empty")
-		return "", ErrInvalidTenantID
+	document, err := uc.getDownloadableDocument(ctx, id, tenantID, userID)
+	if err != nil {
+		return nil, err
 	}
 
-	// Validate user ID is not empty, return ErrInvalidUserID if empty
-	if strings.TrimSpace(userID) == "" {
-		log.Error("User ID cannot be empty")
-		return "", ErrInvalidUserID
-	}
+	// Find the downloaded version. The latest version is available with its document; older
+	// versions may still be available when a newer one is processed or was quarantined.
+	var version *models.DocumentVersion
+	if versionID == "" {
+		// Check if document is available for download (status is DocumentStatusAvailable)
+		if !document.IsAvailable() {
+			log.Error("Document is not available for download", "documentID", id, "status", document.Status)
+			return nil, ErrDocumentNotAvailable
+		}
 
-	// Validate expirationSeconds is greater than 0
-	if expirationSeconds <= 0 {
-		log.Error("Expiration seconds must be greater than 0")
-		return "", errors.NewValidationError("expiration seconds must be greater than 0")
+		// Get the latest document version
+		version = document.GetLatestVersion()
+		if version == nil {
+			log.Error("No versions found for document", "documentID", id)
+			return nil, errors.NewResourceNotFoundError("no versions found for document")
+		}
+	} else {
+		version = document.GetVersion(versionID)
+		if version == nil {
+			log.Error("Document version not found", "documentID", id, "versionID", versionID)
+			return nil, ErrVersionNotFound
+		}
+		if !version.IsAvailable() {
+			log.Error("Document version is not available for download", "documentID", id, "versionID", versionID, "status", version.Status)
+			return nil, ErrVersionNotAvailable
+		}
 	}
 
-	// Retrieve the document from the repository using documentRepo.GetByID
-	document, err := uc.documentRepo.GetByID(ctx, id, tenantID)
+	// Downloads from folders that watermark them are stamped with the downloading user
+	watermarked, err := uc.watermarkService.RequiresWatermark(ctx, document, nil)
 	if err != nil {
-		log.WithError(err).Error("Failed to get document", "documentID", id, "tenantID", tenantID)
-		return "", errors.Wrap(err, "failed to get document")
+		log.WithError(err).Error("Failed to check if document is watermarked", "documentID", id)
+		return nil, errors.Wrap(err, "failed to check if document is watermarked")
 	}
 
-	// If document not found, return ErrDocumentNotFound
-	if document == nil {
-		log.Error("Document not found", "documentID", id, "tenantID", tenantID)
-		return "", ErrDocumentNotFound
+	download := &DocumentDownload{
+		FileName:     document.Name,
+		ContentType:  document.ContentType,
+		Size:         version.Size,
+		ETag:         `"` + version.ContentHash + `"`,
+		LastModified: version.CreatedAt,
+		Watermarked:  watermarked,
 	}
 
-	// Verify the document belongs to the specified tenant
-	if document.TenantID != tenantID {
-		log.Error("Document tenant mismatch", "documentID", id, "documentTenantID", document.TenantID, "requestTenantID", tenantID)
-		return "", ErrDocumentNotFound
+	// A range is only served if the client's copy is still the downloaded version. Watermarked copies
+	// differ with every download, so they are always served whole.
+	if rangeHeader != "" && !watermarked && utils.IfRangeMatches(ifRange, download.ETag, download.LastModified) {
+		download.Range, err = utils.ParseRange(rangeHeader, version.Size)
+		if err != nil {
+			log.Error("Requested range not satisfiable", "documentID", id, "range", rangeHeader, "size", version.Size)
+			return nil, ErrRangeNotSatisfiable
+		}
 	}
 
-	// Check if user has read permission for the document using authService.VerifyResourceAccess
-	hasAccess, err := uc.authService.VerifyResourceAccess(ctx, userID, tenantID, services.ResourceTypeDocument, id, services.PermissionRead)
+	// The folder's download policy may only allow previews, limit daily downloads or require a
+	// recent sign-in. Like the audit entry below, only the request for the beginning counts.
+	policy, err := uc.downloadPolicyService.CheckDownload(ctx, document, userID, download.Range == nil || download.Range.Start == 0)
 	if err != nil {
-		log.WithError(err).Error("Failed to verify document access", "documentID", id, "tenantID", tenantID, "userID", userID)
-		return "", errors.Wrap(err, "failed to verify document access")
+		// Refused downloads are logged and audited by the service
+		if policy == nil {
+			log.WithError(err).Error("Failed to check folder download policy", "documentID", id)
+		}
+		return nil, err
 	}
 
-	if !hasAccess {
-		log.Error("User does not have read permission for document", "documentID", id, "tenantID", tenantID, "userID", userID)
-		return "", ErrPermissionDenied
+	// Retrieve document content from storage, transferring only the requested range
+	if download.Range != nil {
+		download.Content, err = uc.storageService.GetDocumentRange(ctx, version.StoragePath, download.Range.Start, download.Range.Length)
+	} else {
+		download.Content, err = uc.storageService.GetDocument(ctx, version.StoragePath)
 	}
-
-	// Check if document is available for download (status is DocumentStatusAvailable)
-	if !document.IsAvailable() {
-		log.Error("Document is not available for download", "documentID", id, "status", document.Status)
-		return "", ErrDocumentNotAvailable
+	if err != nil {
+		log.WithError(err).Error("Failed to retrieve document content from storage", "documentID", id, "storagePath", version.StoragePath)
+		return nil, errors.Wrap(err, "failed to retrieve document content from storage")
 	}
 
-	// Get the latest document version
-	latestVersion := document.GetLatestVersion()
-	if latestVersion == nil {
-		log.Error("No versions found for document", "documentID", id)
-		return "", errors.NewResourceNotFoundError("no versions found for document")
+	if watermarked {
+		content := download.Content
+		download.Content, download.Size, err = uc.watermarkService.WatermarkForUser(ctx, content, document, userID)
+		content.Close()
+		if err != nil {
+			log.WithError(err).Error("Failed to watermark document", "documentID", id, "userID", userID)
+			return nil, err
+		}
+		download.ETag = ""
 	}
 
-	// Generate presigned URL for document content using storageService.GetPresignedURL
-	presignedURL, err := uc.storageService.GetPresignedURL(ctx, latestVersion.StoragePath, document.Name, expirationSeconds)
-	if err != nil {
-		log.WithError(err).Error("Failed to generate presigned URL", "documentID", id, "storagePath", latestVersion.StoragePath)
-		return "", errors.Wrap(err, "failed to generate presigned URL")
+	// Players and download managers fetch a document in many ranges, so only the request for
+	// its beginning counts as a download
+	if download.Range != nil && download.Range.Start > 0 {
+		return download, nil
 	}
 
 	// Publish document.downloaded event using eventService
 	additionalData := map[string]interface{}{
-		"name":   document.Name,
-		"userID": userID,
+		"name":      document.Name,
+		"userID":    userID,
+		"versionID": version.ID,
 	}
 
 	_, err = uc.eventService.CreateAndPublishDocumentEvent(ctx, DocumentEventDownloaded, tenantID, id, additionalData)
@@ -541,16 +995,565 @@ func (uc *documentUseCase) GetDocumentPresignedURL(ctx context.Context, id strin
 		// Do not return error, continue processing even if event publishing fails
 	}
 
-	// Log successful presigned URL generation
-	log.Info("Presigned URL generated successfully", "documentID", id, "tenantID", tenantID)
+	// Record the download in the audit log, with the version and the download policy that allowed it
+	auditDetails := map[string]interface{}{
+		"name":          document.Name,
+		"watermarked":   watermarked,
+		"versionId":     version.ID,
+		"versionNumber": version.VersionNumber,
+	}
+	if !policy.Policy.IsEmpty() {
+		auditDetails["policy"] = policy.AuditDetails()
+	}
+	err = uc.auditService.RecordAction(ctx, tenantID, userID, models.AuditActionDownload, models.ResourceTypeDocument, id, nil, auditDetails)
+	if err != nil {
+		log.WithError(err).Error("Failed to record document download in audit log")
+		// Do not return error, the content has already been retrieved
+	}
 
-	// Return presigned URL or wrap error with context
-	return presignedURL, nil
+	// Log successful document download
+	log.Info("Document downloaded successfully", "documentID", id, "versionID", version.ID, "tenantID", tenantID)
+
+	return download, nil
 }
 
-// BatchDownloadDocuments downloads multiple documents as a compressed archive with tenant isolation and permission checks
-func (uc *documentUseCase) BatchDownloadDocuments(ctx context.Context, ids []string, tenantID string, userID string) (io.ReadCloser, error) {
-	panic("implement me")
+// ListDocumentVersions lists the versions of a document the user can read, newest first. Versions
+// that are processing, quarantined or failed are listed with their status.
+func (uc *documentUseCase) ListDocumentVersions(ctx context.Context, id string, tenantID string, userID string) ([]models.DocumentVersion, error) {
+	// Validate the IDs and check that the user can read the document
+	document, err := uc.GetDocument(ctx, id, tenantID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]models.DocumentVersion, len(document.Versions))
+	copy(versions, document.Versions)
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].VersionNumber > versions[j].VersionNumber
+	})
+
+	uc.logger.WithContext(ctx).Info("Document versions listed successfully", "documentID", id, "tenantID", tenantID, "count", len(versions))
+	return versions, nil
+}
+
+// GetVersionSignature reads an available version of a document the user can write and calculates
+// its block signature. Signatures are only of use to clients uploading a new version, so they take
+// write permission.
+func (uc *documentUseCase) GetVersionSignature(ctx context.Context, id string, versionID string, blockSize int, tenantID string, userID string) (*delta.Signature, error) {
+	// Get logger with context
+	log := uc.logger.WithContext(ctx)
+
+	if blockSize == 0 {
+		blockSize = delta.DefaultBlockSize
+	}
+	if !delta.ValidBlockSize(blockSize) {
+		log.Error("Invalid signature block size", "blockSize", blockSize)
+		return nil, errors.NewValidationError(delta.ErrInvalidBlockSize.Error())
+	}
+
+	document, err := uc.getWritableDocument(ctx, id, tenantID, userID)
+	if err != nil {
+		return nil, err
+	}
+	version, err := uc.getAvailableVersion(ctx, document, versionID)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := uc.storageService.GetDocument(ctx, version.StoragePath)
+	if err != nil {
+		log.WithError(err).Error("Failed to retrieve document content from storage", "documentID", id, "storagePath", version.StoragePath)
+		return nil, errors.Wrap(err, "failed to retrieve document content from storage")
+	}
+	defer content.Close()
+
+	signature, err := delta.ComputeSignature(content, blockSize)
+	if err != nil {
+		log.WithError(err).Error("Failed to calculate version signature", "documentID", id, "versionID", versionID)
+		return nil, errors.Wrap(err, "failed to calculate version signature")
+	}
+
+	log.Info("Version signature calculated", "documentID", id, "versionID", versionID, "blockSize", blockSize, "blocks", len(signature.Blocks))
+	return signature, nil
+}
+
+// UploadVersionDelta rebuilds a new version of a document the user can write from a patch against
+// an available version, streaming it into temporary storage without buffering it. The new version
+// goes through the upload policy, upload limits, quota and checksum verification of an upload and
+// is then scanned like one before it becomes available.
+func (uc *documentUseCase) UploadVersionDelta(ctx context.Context, id string, baseVersionID string, size int64, checksum string, patch io.Reader, tenantID string, userID string) (*models.DocumentVersion, error) {
+	// Get logger with context
+	log := uc.logger.WithContext(ctx)
+
+	if strings.TrimSpace(baseVersionID) == "" {
+		log.Error("Base version ID cannot be empty")
+		return nil, errors.NewValidationError("base version ID is required")
+	}
+	if size <= 0 {
+		log.Error("Document size must be greater than 0")
+		return nil, errors.NewValidationError("document size must be greater than 0")
+	}
+
+	// The rebuilt content is only as good as the base version and the patch, so it is always verified
+	checksum = strings.ToLower(strings.TrimSpace(checksum))
+	if !utils.IsValidHash(checksum, utils.HashAlgorithmSHA256) {
+		log.Error("Invalid document checksum", "checksum", checksum)
+		return nil, errors.NewValidationError("checksum must be a hex encoded SHA-256 hash")
+	}
+	if patch == nil {
+		log.Error("Delta patch cannot be nil")
+		return nil, errors.NewValidationError("delta patch is required")
+	}
+
+	document, err := uc.getWritableDocument(ctx, id, tenantID, userID)
+	if err != nil {
+		return nil, err
+	}
+	baseVersion, err := uc.getAvailableVersion(ctx, document, baseVersionID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reject versions larger than the user may upload, or beyond the tenant's storage quota
+	if err := uc.uploadLimitService.CheckUpload(ctx, tenantID, userID, size); err != nil {
+		log.WithError(err).Error("Delta upload rejected by upload limits", "userID", userID, "size", size)
+		return nil, err
+	}
+	if _, err := uc.quotaService.CheckVersion(ctx, tenantID, userID, size); err != nil {
+		log.WithError(err).Error("Delta upload rejected by quota", "tenantID", tenantID, "size", size)
+		return nil, err
+	}
+
+	encryptionKeyID, err := uc.storageService.GetEncryptionKeyID(ctx, tenantID)
+	if err != nil {
+		log.WithError(err).Error("Failed to resolve document encryption key")
+		return nil, errors.Wrap(err, "failed to resolve document encryption key")
+	}
+
+	tempPath, contentHash, stats, err := uc.storeRebuiltVersion(ctx, document, baseVersion, size, patch)
+	if err != nil {
+		log.WithError(err).Error("Failed to rebuild document version from delta patch", "documentID", id, "baseVersionID", baseVersionID)
+		return nil, err
+	}
+
+	if err := uc.verifyUploadChecksum(ctx, tempPath, contentHash, checksum); err != nil {
+		if deleteErr := uc.storageService.DeleteDocument(ctx, tempPath); deleteErr != nil {
+			log.WithError(deleteErr).Error("Failed to delete document failing checksum verification", "tempPath", tempPath)
+		}
+		log.WithError(err).Error("Delta upload failed checksum verification", "documentID", id, "checksum", checksum)
+		return nil, err
+	}
+	verifiedAt := time.Now()
+
+	versionNumber := 1
+	if latest := document.GetLatestVersion(); latest != nil {
+		versionNumber = latest.VersionNumber + 1
+	}
+	version := models.NewDocumentVersion(id, versionNumber, size, contentHash, tempPath, userID)
+	version.ID = uuid.New().String()
+	version.EncryptionKeyID = encryptionKeyID
+	version.ClientChecksum = checksum
+	version.IntegrityVerifiedAt = &verifiedAt
+
+	// Persist the version with its quota reservation and audit entry in a single transaction
+	err = uc.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if _, err := uc.quotaService.ReserveVersion(txCtx, tenantID, size); err != nil {
+			log.WithError(err).Error("Failed to reserve tenant quota", "tenantID", tenantID, "size", size)
+			return err
+		}
+
+		if _, err := uc.documentRepo.AddVersion(txCtx, &version); err != nil {
+			log.WithError(err).Error("Failed to create document version")
+			return errors.Wrap(err, "failed to create document version")
+		}
+
+		return uc.auditService.RecordAction(txCtx, tenantID, userID, models.AuditActionUpload, models.ResourceTypeDocument, id, nil, map[string]interface{}{
+			"versionId":     version.ID,
+			"versionNumber": version.VersionNumber,
+			"baseVersionId": baseVersion.ID,
+			"size":          size,
+			"copiedBytes":   stats.CopiedBytes,
+			"patchBytes":    stats.DataBytes,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The version is scanned like an upload before it becomes available
+	err = uc.virusScanningService.QueueForScanning(ctx, id, version.ID, tenantID, tempPath, contentHash, "")
+	if err != nil {
+		log.WithError(err).Error("Failed to queue document version for virus scanning")
+		return nil, errors.Wrap(err, "failed to queue document version for virus scanning")
+	}
+
+	metrics.IncDocumentUploads(tenantID, document.ContentType)
+
+	log.Info("Document version uploaded as delta", "documentID", id, "versionID", version.ID, "baseVersionID", baseVersion.ID,
+		"size", size, "copiedBytes", stats.CopiedBytes, "patchBytes", stats.DataBytes)
+	return &version, nil
+}
+
+// storeRebuiltVersion applies a patch to the base version while the rebuilt content is checked
+// against the tenant's upload policy and stored in temporary storage, returning its path and
+// SHA-256 hash. Malformed patches are validation errors.
+func (uc *documentUseCase) storeRebuiltVersion(ctx context.Context, document *models.Document, baseVersion *models.DocumentVersion, size int64, patch io.Reader) (string, string, delta.Stats, error) {
+	rebuilt, writer := io.Pipe()
+	applied := make(chan struct{})
+	var stats delta.Stats
+	var applyErr error
+	go func() {
+		defer close(applied)
+		base := delta.BaseFunc(func(offset int64, length int64) (io.ReadCloser, error) {
+			return uc.storageService.GetDocumentRange(ctx, baseVersion.StoragePath, offset, length)
+		})
+		stats, applyErr = delta.Apply(base, baseVersion.Size, patch, writer)
+		writer.CloseWithError(applyErr)
+	}()
+
+	tempPath, contentHash, err := uc.storeVersionContent(ctx, document, size, rebuilt)
+
+	// Stop applying the patch when the content was refused, and wait until the patch is no longer read
+	rebuilt.Close()
+	<-applied
+
+	// The patch failing makes storing fail too, but the client is told why. A patch ending early
+	// may also leave content of the declared size stored.
+	if stderrors.Is(applyErr, delta.ErrInvalidPatch) {
+		err = errors.NewValidationError(applyErr.Error())
+	} else if err == nil && applyErr != nil {
+		err = errors.Wrap(applyErr, "failed to apply delta patch")
+	}
+	if err != nil {
+		if tempPath != "" {
+			if deleteErr := uc.storageService.DeleteDocument(ctx, tempPath); deleteErr != nil {
+				uc.logger.WithContext(ctx).WithError(deleteErr).Error("Failed to delete partially rebuilt document version", "tempPath", tempPath)
+			}
+		}
+		return "", "", stats, err
+	}
+	return tempPath, contentHash, stats, nil
+}
+
+// storeVersionContent checks the content of a new version against the tenant's upload policy,
+// which cannot be longer than its declared size, and stores it in temporary storage. The version
+// keeps the content type of its document.
+func (uc *documentUseCase) storeVersionContent(ctx context.Context, document *models.Document, size int64, content io.Reader) (string, string, error) {
+	_, content, err := uc.uploadPolicyService.CheckUpload(ctx, document.TenantID, document.Name, document.ContentType, size, content)
+	if err != nil {
+		return "", "", err
+	}
+
+	hashingReader, err := utils.NewHashingReader(content, utils.HashAlgorithmSHA256)
+	if err != nil {
+		return "", "", errors.Wrap(err, "failed to create content hasher")
+	}
+	tempPath, err := uc.storageService.StoreTemporary(ctx, document.TenantID, document.ID, hashingReader, size, document.ContentType)
+	if err != nil {
+		return "", "", errors.Wrap(err, "failed to store document in temporary storage")
+	}
+	return tempPath, hashingReader.Sum(), nil
+}
+
+// getWritableDocument retrieves a document the user can write, refusing documents locked while
+// they await approval
+func (uc *documentUseCase) getWritableDocument(ctx context.Context, id string, tenantID string, userID string) (*models.Document, error) {
+	// Get logger with context
+	log := uc.logger.WithContext(ctx)
+
+	if strings.TrimSpace(id) == "" {
+		log.Error("Document ID cannot be empty")
+		return nil, ErrInvalidDocumentID
+	}
+	if strings.TrimSpace(tenantID) == "" {
+		log.Error("Tenant ID cannot be empty")
+		return nil, ErrInvalidTenantID
+	}
+	if strings.TrimSpace(userID) == "" {
+		log.Error("User ID cannot be empty")
+		return nil, ErrInvalidUserID
+	}
+
+	document, err := uc.documentRepo.GetByID(ctx, id, tenantID)
+	if err != nil {
+		log.WithError(err).Error("Failed to get document", "documentID", id, "tenantID", tenantID)
+		return nil, errors.Wrap(err, "failed to get document")
+	}
+	if document == nil || document.TenantID != tenantID {
+		log.Error("Document not found", "documentID", id, "tenantID", tenantID)
+		return nil, ErrDocumentNotFound
+	}
+
+	hasAccess, err := uc.authService.VerifyResourceAccess(ctx, userID, tenantID, services.ResourceTypeDocument, id, services.PermissionWrite)
+	if err != nil {
+		log.WithError(err).Error("Failed to verify document access", "documentID", id, "tenantID", tenantID, "userID", userID)
+		return nil, errors.Wrap(err, "failed to verify document access")
+	}
+	if !hasAccess {
+		log.Error("User does not have write permission for document", "documentID", id, "tenantID", tenantID, "userID", userID)
+		return nil, ErrPermissionDenied
+	}
+	if err := uc.policyEngine.Enforce(ctx, tenantID, userID, models.PolicyActionWrite, document); err != nil {
+		log.WithError(err).Error("Document access denied by policy", "documentID", id, "tenantID", tenantID, "userID", userID)
+		return nil, err
+	}
+	if document.IsLocked() {
+		log.Error("Document is locked", "documentID", id, "tenantID", tenantID)
+		return nil, ErrDocumentLocked
+	}
+	return document, nil
+}
+
+// getAvailableVersion finds a version of a document that is available, so its content can be read
+func (uc *documentUseCase) getAvailableVersion(ctx context.Context, document *models.Document, versionID string) (*models.DocumentVersion, error) {
+	version := document.GetVersion(versionID)
+	if version == nil {
+		uc.logger.WithContext(ctx).Error("Document version not found", "documentID", document.ID, "versionID", versionID)
+		return nil, ErrVersionNotFound
+	}
+	if !version.IsAvailable() {
+		uc.logger.WithContext(ctx).Error("Document version is not available", "documentID", document.ID, "versionID", versionID, "status", version.Status)
+		return nil, ErrVersionNotAvailable
+	}
+	return version, nil
+}
+
+// GetDocumentPresignedURL generates a presigned URL for document download with tenant isolation and permission checks
+func (uc *documentUseCase) GetDocumentPresignedURL(ctx context.Context, id string, tenantID string, userID string, expirationSeconds int) (string, error) {
+	// Get logger with context
+	log := uc.logger.WithContext(ctx)
+
+	// Validate document ID is not empty, return ErrInvalidDocumentID if empty
+	if strings.TrimSpace(id) == "" {
+		log.Error("Document ID cannot be empty")
+		return "", ErrInvalidDocumentID
+	}
+
+	// Validate tenant ID is not empty, return ErrInvalidTenantID if empty
+	if strings.TrimSpace(tenantID) == "" {
+		log.Error("Tenant ID cannot be empty")
+		return "", ErrInvalidTenantID
+	}
+
+	// Validate user ID is not empty, return ErrInvalidUserID if empty
+	if strings.TrimSpace(userID) == "" {
+		log.Error("User ID cannot be empty")
+		return "", ErrInvalidUserID
+	}
+
+	// Validate expirationSeconds is greater than 0
+	if expirationSeconds <= 0 {
+		log.Error("Expiration seconds must be greater than 0")
+		return "", errors.NewValidationError("expiration seconds must be greater than 0")
+	}
+
+	// Retrieve the document from the repository using documentRepo.GetByID
+	document, err := uc.documentRepo.GetByID(ctx, id, tenantID)
+	if err != nil {
+		log.WithError(err).Error("Failed to get document", "documentID", id, "tenantID", tenantID)
+		return "", errors.Wrap(err, "failed to get document")
+	}
+
+	// If document not found, return ErrDocumentNotFound
+	if document == nil {
+		log.Error("Document not found", "documentID", id, "tenantID", tenantID)
+		return "", ErrDocumentNotFound
+	}
+
+	// Verify the document belongs to the specified tenant
+	if document.TenantID != tenantID {
+		log.Error("Document tenant mismatch", "documentID", id, "documentTenantID", document.TenantID, "requestTenantID", tenantID)
+		return "", ErrDocumentNotFound
+	}
+
+	// Check if user has read permission for the document using authService.VerifyResourceAccess
+	hasAccess, err := uc.authService.VerifyResourceAccess(ctx, userID, tenantID, services.ResourceTypeDocument, id, services.PermissionRead)
+	if err != nil {
+		log.WithError(err).Error("Failed to verify document access", "documentID", id, "tenantID", tenantID, "userID", userID)
+		return "", errors.Wrap(err, "failed to verify document access")
+	}
+
+	if !hasAccess {
+		log.Error("User does not have read permission for document", "documentID", id, "tenantID", tenantID, "userID", userID)
+		return "", ErrPermissionDenied
+	}
+
+	// Evaluate the tenant's attribute-based access policies
+	if err := uc.policyEngine.Enforce(ctx, tenantID, userID, models.PolicyActionRead, document); err != nil {
+		log.WithError(err).Error("Document access denied by policy", "documentID", id, "tenantID", tenantID, "userID", userID)
+		return "", err
+	}
+
+	// Documents that are scheduled or expired are only seen by the users staging them
+	if err := uc.checkVisibility(ctx, document, tenantID, userID); err != nil {
+		return "", err
+	}
+
+	// Check if document is available for download (status is DocumentStatusAvailable)
+	if !document.IsAvailable() {
+		log.Error("Document is not available for download", "documentID", id, "status", document.Status)
+		return "", ErrDocumentNotAvailable
+	}
+
+	// Get the latest document version
+	latestVersion := document.GetLatestVersion()
+	if latestVersion == nil {
+		log.Error("No versions found for document", "documentID", id)
+		return "", errors.NewResourceNotFoundError("no versions found for document")
+	}
+
+	// A presigned URL would hand out the content unstamped
+	watermarked, err := uc.watermarkService.RequiresWatermark(ctx, document, nil)
+	if err != nil {
+		log.WithError(err).Error("Failed to check if document is watermarked", "documentID", id)
+		return "", errors.Wrap(err, "failed to check if document is watermarked")
+	}
+	if watermarked {
+		log.Info("Presigned URL refused for watermarked document", "documentID", id)
+		return "", ErrWatermarkRequired
+	}
+
+	// The folder's download policy may only allow previews, limit daily downloads or require a
+	// recent sign-in; a presigned URL counts as a download
+	policy, err := uc.downloadPolicyService.CheckDownload(ctx, document, userID, true)
+	if err != nil {
+		// Refused downloads are logged and audited by the service
+		if policy == nil {
+			log.WithError(err).Error("Failed to check folder download policy", "documentID", id)
+		}
+		return "", err
+	}
+
+	// Generate presigned URL for document content using storageService.GetPresignedURL
+	presignedURL, err := uc.storageService.GetPresignedURL(ctx, latestVersion.StoragePath, document.Name, expirationSeconds)
+	if err != nil {
+		log.WithError(err).Error("Failed to generate presigned URL", "documentID", id, "storagePath", latestVersion.StoragePath)
+		return "", errors.Wrap(err, "failed to generate presigned URL")
+	}
+
+	// Publish document.downloaded event using eventService
+	additionalData := map[string]interface{}{
+		"name":   document.Name,
+		"userID": userID,
+	}
+
+	_, err = uc.eventService.CreateAndPublishDocumentEvent(ctx, DocumentEventDownloaded, tenantID, id, additionalData)
+	if err != nil {
+		log.WithError(err).Error("Failed to publish document.downloaded event")
+		// Do not return error, continue processing even if event publishing fails
+	}
+
+	// Record the download in the audit log, with the download policy that allowed it
+	auditDetails := map[string]interface{}{
+		"name": document.Name,
+	}
+	if !policy.Policy.IsEmpty() {
+		auditDetails["policy"] = policy.AuditDetails()
+	}
+	err = uc.auditService.RecordAction(ctx, tenantID, userID, models.AuditActionDownload, models.ResourceTypeDocument, id, nil, auditDetails)
+	if err != nil {
+		log.WithError(err).Error("Failed to record document download in audit log")
+		// Do not return error, the content has already been retrieved
+	}
+
+	// Log successful presigned URL generation
+	log.Info("Presigned URL generated successfully", "documentID", id, "tenantID", tenantID)
+
+	// Return presigned URL or wrap error with context
+	return presignedURL, nil
+}
+
+// BatchDownloadDocuments downloads multiple documents as a compressed archive with tenant isolation and permission checks
+func (uc *documentUseCase) BatchDownloadDocuments(ctx context.Context, ids []string, tenantID string, userID string) (io.ReadCloser, error) {
+	// Get logger with context
+	log := uc.logger.WithContext(ctx)
+
+	if len(ids) == 0 {
+		log.Error("Document IDs cannot be empty")
+		return nil, errors.NewValidationError("document IDs cannot be empty")
+	}
+
+	// Every document is checked like a download of its own, so that one refused document refuses
+	// the whole archive rather than leaving it out silently
+	documents := make([]*models.Document, 0, len(ids))
+	versions := make([]*models.DocumentVersion, 0, len(ids))
+	policies := make([]*models.DownloadPolicyEvaluation, 0, len(ids))
+	for _, id := range ids {
+		document, err := uc.getDownloadableDocument(ctx, id, tenantID, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		if !document.IsAvailable() {
+			log.Error("Document is not available for download", "documentID", id, "status", document.Status)
+			return nil, ErrDocumentNotAvailable
+		}
+		version := document.GetLatestVersion()
+		if version == nil {
+			log.Error("No versions found for document", "documentID", id)
+			return nil, errors.NewResourceNotFoundError("no versions found for document")
+		}
+
+		// Archives hold the stored content, which cannot be stamped with the downloading user
+		watermarked, err := uc.watermarkService.RequiresWatermark(ctx, document, nil)
+		if err != nil {
+			log.WithError(err).Error("Failed to check if document is watermarked", "documentID", id)
+			return nil, errors.Wrap(err, "failed to check if document is watermarked")
+		}
+		if watermarked {
+			log.Error("Watermarked document cannot be downloaded in a batch", "documentID", id)
+			return nil, errors.NewValidationError("document " + document.Name + " is watermarked and has to be downloaded on its own")
+		}
+
+		// The folder's download policy may only allow previews, limit daily downloads or require a recent sign-in
+		policy, err := uc.downloadPolicyService.CheckDownload(ctx, document, userID, true)
+		if err != nil {
+			// Refused downloads are logged and audited by the service
+			if policy == nil {
+				log.WithError(err).Error("Failed to check folder download policy", "documentID", id)
+			}
+			return nil, err
+		}
+
+		documents = append(documents, document)
+		versions = append(versions, version)
+		policies = append(policies, policy)
+	}
+
+	storagePaths := make([]string, len(documents))
+	filenames := make([]string, len(documents))
+	for i, document := range documents {
+		storagePaths[i] = versions[i].StoragePath
+		filenames[i] = document.Name
+	}
+
+	archive, err := uc.storageService.CreateBatchArchive(ctx, storagePaths, filenames)
+	if err != nil {
+		log.WithError(err).Error("Failed to create batch archive", "documentCount", len(documents), "tenantID", tenantID)
+		return nil, errors.Wrap(err, "failed to create batch archive")
+	}
+
+	// Record each download in the audit log, with the version and the download policy that allowed it
+	for i, document := range documents {
+		auditDetails := map[string]interface{}{
+			"name":          document.Name,
+			"versionId":     versions[i].ID,
+			"versionNumber": versions[i].VersionNumber,
+			"batch":         true,
+		}
+		if !policies[i].Policy.IsEmpty() {
+			auditDetails["policy"] = policies[i].AuditDetails()
+		}
+		err = uc.auditService.RecordAction(ctx, tenantID, userID, models.AuditActionDownload, models.ResourceTypeDocument, document.ID, nil, auditDetails)
+		if err != nil {
+			log.WithError(err).Error("Failed to record document download in audit log", "documentID", document.ID)
+			// Do not return error, the archive has already been created
+		}
+	}
+
+	log.Info("Documents downloaded as a batch", "documentCount", len(documents), "tenantID", tenantID, "userID", userID)
+
+	return archive, nil
 }
 
 // GetBatchDownloadPresignedURL generates a presigned URL for batch document download with tenant isolation and permission checks
@@ -558,9 +1561,98 @@ func (uc *documentUseCase) GetBatchDownloadPresignedURL(ctx context.Context, ids
 	panic("implement me")
 }
 
-// DeleteDocument deletes a document by its ID with tenant isolation and permission checks
+// DeleteDocument deletes a document the user may delete, with the content of its versions, and
+// gives its storage back to the tenant's quota. Documents locked while they await approval cannot
+// be deleted.
 func (uc *documentUseCase) DeleteDocument(ctx context.Context, id string, tenantID string, userID string) error {
-	panic("implement me")
+	// Get logger with context
+	log := uc.logger.WithContext(ctx)
+
+	if strings.TrimSpace(id) == "" {
+		log.Error("Document ID cannot be empty")
+		return ErrInvalidDocumentID
+	}
+	if strings.TrimSpace(tenantID) == "" {
+		log.Error("Tenant ID cannot be empty")
+		return ErrInvalidTenantID
+	}
+	if strings.TrimSpace(userID) == "" {
+		log.Error("User ID cannot be empty")
+		return ErrInvalidUserID
+	}
+
+	document, err := uc.documentRepo.GetByID(ctx, id, tenantID)
+	if err != nil {
+		log.WithError(err).Error("Failed to get document", "documentID", id, "tenantID", tenantID)
+		return errors.Wrap(err, "failed to get document")
+	}
+	if document == nil || document.TenantID != tenantID {
+		log.Error("Document not found", "documentID", id, "tenantID", tenantID)
+		return ErrDocumentNotFound
+	}
+
+	hasAccess, err := uc.authService.VerifyResourceAccess(ctx, userID, tenantID, services.ResourceTypeDocument, id, services.PermissionDelete)
+	if err != nil {
+		log.WithError(err).Error("Failed to verify document access", "documentID", id, "tenantID", tenantID, "userID", userID)
+		return errors.Wrap(err, "failed to verify document access")
+	}
+	if !hasAccess {
+		log.Error("User does not have delete permission for document", "documentID", id, "tenantID", tenantID, "userID", userID)
+		return ErrPermissionDenied
+	}
+
+	// Deleting changes the document, so the policies for writing apply
+	if err := uc.policyEngine.Enforce(ctx, tenantID, userID, models.PolicyActionWrite, document); err != nil {
+		log.WithError(err).Error("Document access denied by policy", "documentID", id, "tenantID", tenantID, "userID", userID)
+		return err
+	}
+	if document.IsLocked() {
+		log.Error("Document is locked", "documentID", id, "tenantID", tenantID)
+		return ErrDocumentLocked
+	}
+
+	// Delete the document and enqueue its event in one transaction
+	err = uc.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := uc.documentRepo.Delete(txCtx, id, tenantID); err != nil {
+			log.WithError(err).Error("Failed to delete document", "documentID", id, "tenantID", tenantID)
+			return errors.Wrap(err, "failed to delete document")
+		}
+
+		_, err := uc.eventService.CreateAndPublishDocumentEvent(txCtx, DocumentEventDeleted, tenantID, id, map[string]interface{}{
+			"name":   document.Name,
+			"userID": userID,
+		})
+		if err != nil {
+			log.WithError(err).Error("Failed to enqueue document.deleted event")
+			return errors.Wrap(err, "failed to enqueue document.deleted event")
+		}
+
+		return uc.auditService.RecordAction(txCtx, tenantID, userID, models.AuditActionDelete, models.ResourceTypeDocument, id, nil, map[string]interface{}{
+			"name": document.Name,
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	// The document is gone, so failures below only leave content, quota usage or search entries
+	// behind and do not fail the delete. Deduplicated content is released by reference.
+	var size int64
+	for _, version := range document.Versions {
+		size += version.Size
+		if err := uc.storageService.DeleteDocument(ctx, version.StoragePath); err != nil {
+			log.WithError(err).Error("Failed to delete document content", "documentID", id, "versionID", version.ID, "storagePath", version.StoragePath)
+		}
+	}
+	if err := uc.quotaService.ReleaseDocument(ctx, tenantID, size); err != nil {
+		log.WithError(err).Error("Failed to release tenant quota", "documentID", id, "tenantID", tenantID)
+	}
+	if err := uc.searchService.RemoveDocumentFromIndex(ctx, id, tenantID); err != nil {
+		log.WithError(err).Error("Failed to remove document from search index", "documentID", id)
+	}
+
+	log.Info("Document deleted successfully", "documentID", id, "tenantID", tenantID)
+	return nil
 }
 
 // ListDocumentsByFolder lists documents in a folder with pagination, tenant isolation, and permission checks
@@ -593,6 +1685,175 @@ func (uc *documentUseCase) DeleteDocumentMetadata(ctx context.Context, id string
 	panic("implement me")
 }
 
+// BulkUpdateMetadata applies a metadata patch to many documents. Every document is checked like a
+// single metadata update: it must exist, the user must be allowed to write it, and its patched
+// metadata must satisfy the tenant's schema. The documents that pass are updated in one transaction
+// with a single aggregated event, then their search index entries are updated in bulk. With atomic,
+// nothing is updated unless every document passes, and the documents that did pass report
+// ErrBulkUpdateAborted. The returned error only reports an update that failed as a whole.
+func (uc *documentUseCase) BulkUpdateMetadata(ctx context.Context, documentIDs []string, patch models.MetadataPatch, atomic bool, tenantID string, userID string) ([]BulkMetadataResult, error) {
+	// Get logger with context
+	log := uc.logger.WithContext(ctx)
+
+	if len(documentIDs) == 0 {
+		log.Error("Bulk metadata update contains no documents")
+		return nil, errors.NewValidationError("at least one document ID is required")
+	}
+	if len(documentIDs) > MaxBulkMetadataUpdateSize {
+		log.Error("Bulk metadata update contains too many documents", "count", len(documentIDs))
+		return nil, errors.NewValidationError(fmt.Sprintf("at most %d documents can be updated at once", MaxBulkMetadataUpdateSize))
+	}
+	if strings.TrimSpace(tenantID) == "" {
+		return nil, ErrInvalidTenantID
+	}
+	if strings.TrimSpace(userID) == "" {
+		return nil, ErrInvalidUserID
+	}
+	if err := patch.Validate(); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	// Load all documents with one query, ignoring repeated IDs
+	ids := make([]string, 0, len(documentIDs))
+	seen := make(map[string]bool, len(documentIDs))
+	for _, id := range documentIDs {
+		if strings.TrimSpace(id) != "" && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	documents := make(map[string]*models.Document, len(ids))
+	if len(ids) > 0 {
+		loaded, err := uc.documentRepo.GetDocumentsByIDs(ctx, ids, tenantID)
+		if err != nil {
+			log.WithError(err).Error("Failed to get documents for bulk metadata update", "tenantID", tenantID)
+			return nil, errors.Wrap(err, "failed to get documents")
+		}
+		for _, document := range loaded {
+			documents[document.ID] = document
+		}
+	}
+
+	schema, err := uc.metadataSchemaService.GetSchema(ctx, tenantID)
+	if err != nil {
+		log.WithError(err).Error("Failed to load metadata schema", "tenantID", tenantID)
+		return nil, errors.Wrap(err, "failed to load metadata schema")
+	}
+
+	// Check every document, keeping the outcome of repeated IDs
+	results := make([]BulkMetadataResult, len(documentIDs))
+	outcomes := make(map[string]error, len(ids))
+	var passed []*models.Document
+	failed := 0
+	for i, id := range documentIDs {
+		results[i].DocumentID = id
+
+		checkErr, checked := outcomes[id]
+		if !checked {
+			checkErr = uc.checkMetadataPatch(ctx, id, documents[id], patch, schema, tenantID, userID)
+			outcomes[id] = checkErr
+			if checkErr == nil {
+				passed = append(passed, documents[id])
+			}
+		}
+		if checkErr != nil {
+			results[i].Err = checkErr
+			failed++
+		}
+	}
+
+	if len(passed) == 0 || (atomic && failed > 0) {
+		for i := range results {
+			if results[i].Err == nil {
+				results[i].Err = ErrBulkUpdateAborted
+			}
+		}
+		log.Info("Bulk metadata update applied to no documents", "tenantID", tenantID, "documents", len(documentIDs), "failed", failed)
+		return results, nil
+	}
+
+	passedIDs := make([]string, len(passed))
+	for i, document := range passed {
+		passedIDs[i] = document.ID
+	}
+
+	// Update the metadata of all documents and enqueue a single event in one transaction
+	err = uc.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := uc.documentRepo.BulkUpdateMetadata(txCtx, passedIDs, patch.Set, patch.Remove, tenantID); err != nil {
+			log.WithError(err).Error("Failed to update document metadata", "tenantID", tenantID, "documents", len(passedIDs))
+			return errors.Wrap(err, "failed to update document metadata")
+		}
+
+		_, err := uc.eventService.CreateAndPublishDocumentsEvent(txCtx, DocumentEventMetadataUpdated, tenantID, passedIDs, map[string]interface{}{
+			"set":    patch.Set,
+			"remove": patch.Remove,
+			"userID": userID,
+		})
+		if err != nil {
+			log.WithError(err).Error("Failed to enqueue document.metadata_updated event")
+			return errors.Wrap(err, "failed to enqueue document.metadata_updated event")
+		}
+
+		for _, id := range passedIDs {
+			if err := uc.auditService.RecordAction(txCtx, tenantID, userID, models.AuditActionUpdate, models.ResourceTypeDocument, id, nil, map[string]interface{}{
+				"metadataSet":    patch.Set,
+				"metadataRemove": patch.Remove,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Update the search index in bulk; the metadata is committed, so a failure only delays searches
+	// finding the new values until the documents are indexed again
+	for _, document := range passed {
+		patch.ApplyTo(document)
+	}
+	if err := uc.searchService.UpdateIndexedMetadata(ctx, passed, tenantID); err != nil {
+		log.WithError(err).Error("Failed to update indexed metadata after bulk metadata update", "tenantID", tenantID, "documents", len(passed))
+	}
+
+	log.Info("Bulk metadata update completed", "tenantID", tenantID, "documents", len(documentIDs), "updated", len(passed), "failed", failed)
+	return results, nil
+}
+
+// checkMetadataPatch checks that a document of a bulk metadata update exists, that the user may
+// write it, that it is not locked, and that its metadata satisfies the tenant's schema once patched
+func (uc *documentUseCase) checkMetadataPatch(ctx context.Context, id string, document *models.Document, patch models.MetadataPatch, schema models.MetadataSchema, tenantID string, userID string) error {
+	if strings.TrimSpace(id) == "" {
+		return ErrInvalidDocumentID
+	}
+	if document == nil || document.TenantID != tenantID {
+		return ErrDocumentNotFound
+	}
+
+	hasAccess, err := uc.authService.VerifyResourceAccess(ctx, userID, tenantID, services.ResourceTypeDocument, id, services.PermissionWrite)
+	if err != nil {
+		return errors.Wrap(err, "failed to verify document access")
+	}
+	if !hasAccess {
+		return ErrPermissionDenied
+	}
+
+	if err := uc.policyEngine.Enforce(ctx, tenantID, userID, models.PolicyActionWrite, document); err != nil {
+		return err
+	}
+
+	// Documents awaiting approval cannot change until the request is closed
+	if document.IsLocked() {
+		return ErrDocumentLocked
+	}
+
+	if err := schema.Validate(patch.Apply(document.Metadata)); err != nil {
+		return errors.NewValidationError(err.Error())
+	}
+	return nil
+}
+
 // GetDocumentThumbnail retrieves a document thumbnail with tenant isolation and permission checks
 func (uc *documentUseCase) GetDocumentThumbnail(ctx context.Context, id string, tenantID string, userID string) (io.ReadCloser, error) {
 	panic("implement me")
@@ -606,4 +1867,163 @@ func (uc *documentUseCase) GetDocumentThumbnailURL(ctx context.Context, id strin
 // GetDocumentStatus gets the current status of a document with tenant isolation and permission checks
 func (uc *documentUseCase) GetDocumentStatus(ctx context.Context, id string, tenantID string, userID string) (string, error) {
 	panic("implement me")
-}
\ No newline at end of file
+}
+
+// GetQuota gets the tenant's storage quota and the usage counted against it
+func (uc *documentUseCase) GetQuota(ctx context.Context, tenantID string) (*models.TenantQuota, error) {
+	if strings.TrimSpace(tenantID) == "" {
+		return nil, errors.NewValidationError("tenant ID is required")
+	}
+
+	return uc.quotaService.GetQuota(ctx, tenantID)
+}
+
+// ScheduleDocument sets when a document the user can write is published to readers and when it
+// expires. The publication scheduler of the worker changes the document's visibility when the
+// times come, publishing document.published and document.expired events.
+func (uc *documentUseCase) ScheduleDocument(ctx context.Context, id string, publishAt, expireAt *time.Time, tenantID string, userID string) (*models.Document, error) {
+	// Get logger with context
+	log := uc.logger.WithContext(ctx)
+
+	if strings.TrimSpace(id) == "" {
+		log.Error("Document ID cannot be empty")
+		return nil, ErrInvalidDocumentID
+	}
+	if strings.TrimSpace(tenantID) == "" {
+		log.Error("Tenant ID cannot be empty")
+		return nil, ErrInvalidTenantID
+	}
+	if strings.TrimSpace(userID) == "" {
+		log.Error("User ID cannot be empty")
+		return nil, ErrInvalidUserID
+	}
+
+	document, err := uc.documentRepo.GetByID(ctx, id, tenantID)
+	if err != nil {
+		log.WithError(err).Error("Failed to get document", "documentID", id, "tenantID", tenantID)
+		return nil, errors.Wrap(err, "failed to get document")
+	}
+	if document == nil || document.TenantID != tenantID {
+		log.Error("Document not found", "documentID", id, "tenantID", tenantID)
+		return nil, ErrDocumentNotFound
+	}
+
+	// Scheduling a document changes what readers see, so it takes write permission
+	hasAccess, err := uc.authService.VerifyResourceAccess(ctx, userID, tenantID, services.ResourceTypeDocument, id, services.PermissionWrite)
+	if err != nil {
+		log.WithError(err).Error("Failed to verify document access", "documentID", id, "tenantID", tenantID, "userID", userID)
+		return nil, errors.Wrap(err, "failed to verify document access")
+	}
+	if !hasAccess {
+		log.Error("User does not have write permission for document", "documentID", id, "tenantID", tenantID, "userID", userID)
+		return nil, ErrPermissionDenied
+	}
+	if err := uc.policyEngine.Enforce(ctx, tenantID, userID, models.PolicyActionWrite, document); err != nil {
+		log.WithError(err).Error("Document access denied by policy", "documentID", id, "tenantID", tenantID, "userID", userID)
+		return nil, err
+	}
+
+	before := map[string]interface{}{
+		"visibility": document.Visibility,
+		"publishAt":  document.PublishAt,
+		"expireAt":   document.ExpireAt,
+	}
+	if err := document.Schedule(publishAt, expireAt, time.Now()); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	if err := uc.documentRepo.SetSchedule(ctx, id, tenantID, document.PublishAt, document.ExpireAt, document.Visibility); err != nil {
+		log.WithError(err).Error("Failed to schedule document", "documentID", id, "tenantID", tenantID)
+		return nil, errors.Wrap(err, "failed to schedule document")
+	}
+
+	// Record the schedule change in the audit log
+	err = uc.auditService.RecordAction(ctx, tenantID, userID, models.AuditActionUpdate, models.ResourceTypeDocument, id, before, map[string]interface{}{
+		"visibility": document.Visibility,
+		"publishAt":  document.PublishAt,
+		"expireAt":   document.ExpireAt,
+	})
+	if err != nil {
+		log.WithError(err).Error("Failed to record document schedule in audit log")
+		// Do not return error, the schedule has already been stored
+	}
+
+	log.Info("Document scheduled successfully", "documentID", id, "tenantID", tenantID, "visibility", document.Visibility)
+	return document, nil
+}
+
+// VerifyIntegrity hashes the stored content of the latest version of a document the user can read,
+// compares it with the hash calculated when the content was uploaded and records the check in the
+// audit log. Corrupted content is reported in the check rather than as an error.
+func (uc *documentUseCase) VerifyIntegrity(ctx context.Context, id string, tenantID string, userID string) (*models.IntegrityCheck, error) {
+	// Get logger with context
+	log := uc.logger.WithContext(ctx)
+
+	// Validate the IDs and check that the user can read the document
+	document, err := uc.GetDocument(ctx, id, tenantID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the latest document version
+	latestVersion := document.GetLatestVersion()
+	if latestVersion == nil {
+		log.Error("No versions found for document", "documentID", id)
+		return nil, errors.NewResourceNotFoundError("no versions found for document")
+	}
+	if !utils.IsValidHash(latestVersion.ContentHash, utils.HashAlgorithmSHA256) {
+		log.Error("Document version has no content hash to verify", "documentID", id, "versionID", latestVersion.ID)
+		return nil, errors.NewValidationError(models.ErrNoContentHash.Error())
+	}
+
+	check := &models.IntegrityCheck{
+		DocumentID:   id,
+		VersionID:    latestVersion.ID,
+		ExpectedHash: latestVersion.ContentHash,
+		CheckedAt:    time.Now(),
+	}
+	check.ActualHash, err = uc.storageService.HashDocument(ctx, latestVersion.StoragePath)
+	if err != nil {
+		log.WithError(err).Error("Failed to read document content back from storage", "documentID", id, "storagePath", latestVersion.StoragePath)
+		return nil, errors.Wrap(err, "failed to read document content back from storage")
+	}
+	check.Intact = check.ActualHash == check.ExpectedHash
+
+	if check.Intact {
+		if err := uc.documentRepo.MarkVersionIntegrityVerified(ctx, latestVersion.ID, check.CheckedAt, tenantID); err != nil {
+			log.WithError(err).Error("Failed to record document integrity verification", "documentID", id, "versionID", latestVersion.ID)
+			// Do not return error, the content has already been verified
+		}
+	} else {
+		log.Error("Stored document content does not match its hash", "documentID", id, "versionID", latestVersion.ID,
+			"expectedHash", check.ExpectedHash, "actualHash", check.ActualHash)
+	}
+
+	// Record the check in the audit log
+	err = uc.auditService.RecordAction(ctx, tenantID, userID, models.AuditActionVerify, models.ResourceTypeDocument, id, nil, check.AuditDetails())
+	if err != nil {
+		log.WithError(err).Error("Failed to record document integrity check in audit log")
+		// Do not return error, the content has already been verified
+	}
+
+	log.Info("Document integrity verified", "documentID", id, "tenantID", tenantID, "intact", check.Intact)
+	return check, nil
+}
+
+// checkVisibility hides documents that are scheduled or expired from users who cannot write them,
+// reporting them as not found so that readers cannot tell staged documents exist
+func (uc *documentUseCase) checkVisibility(ctx context.Context, document *models.Document, tenantID string, userID string) error {
+	if document.IsPublished() {
+		return nil
+	}
+
+	canWrite, err := uc.authService.VerifyResourceAccess(ctx, userID, tenantID, services.ResourceTypeDocument, document.ID, services.PermissionWrite)
+	if err != nil {
+		return errors.Wrap(err, "failed to verify document access")
+	}
+	if !canWrite {
+		uc.logger.WithContext(ctx).Info("Document is not published", "documentID", document.ID, "visibility", document.Visibility, "userID", userID)
+		return ErrDocumentNotFound
+	}
+	return nil
+}
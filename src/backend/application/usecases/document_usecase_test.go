@@ -4,7 +4,10 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"io"
+	"net/http"
+	"strings"
 	"testing"
 	"time"
 
@@ -14,6 +17,8 @@ import (
 
 	"github.com/org/project/test/mocks"
 	"github.com/org/project/domain/models"
+	"github.com/org/project/domain/services"
+	"github.com/org/project/pkg/delta"
 	"github.com/org/project/pkg/utils"
 	apperrors "github.com/org/project/pkg/errors"
 )
@@ -29,6 +34,16 @@ type DocumentUseCaseTestSuite struct {
 	mockEventService     *mocks.EventServiceInterface
 	mockAuthService      *mocks.AuthService
 	mockThumbnailService *mocks.ThumbnailService
+	policyEngine         *stubPolicyEngine
+	quotaService         *stubQuotaService
+	uploadLimitService   *stubUploadLimitService
+	templateService      *stubMetadataTemplateService
+	schemaService        *stubMetadataSchemaService
+	sequenceService      *stubSequenceService
+	watermarkService     *stubWatermarkService
+	downloadPolicyService *stubDownloadPolicyService
+	uploadPolicyService  *stubUploadPolicyService
+	inlineScanService    *stubInlineScanService
 	useCase              DocumentUseCase
 	ctx                  context.Context
 }
@@ -46,6 +61,16 @@ func (s *DocumentUseCaseTestSuite) SetupTest() {
 	s.mockEventService = new(mocks.EventServiceInterface)
 	s.mockAuthService = new(mocks.AuthService)
 	s.mockThumbnailService = new(mocks.ThumbnailService)
+	s.policyEngine = &stubPolicyEngine{}
+	s.quotaService = &stubQuotaService{}
+	s.uploadLimitService = &stubUploadLimitService{}
+	s.templateService = &stubMetadataTemplateService{}
+	s.schemaService = &stubMetadataSchemaService{}
+	s.sequenceService = &stubSequenceService{}
+	s.watermarkService = &stubWatermarkService{}
+	s.downloadPolicyService = &stubDownloadPolicyService{}
+	s.uploadPolicyService = &stubUploadPolicyService{}
+	s.inlineScanService = &stubInlineScanService{maxBytes: 64}
 	
 	// Initialize the use case with mocks
 	s.useCase = NewDocumentUseCase(
@@ -57,9 +82,309 @@ func (s *DocumentUseCaseTestSuite) SetupTest() {
 		s.mockEventService,
 		s.mockAuthService,
 		s.mockThumbnailService,
+		&passthroughTransactionManager{},
+		&noopAuditService{},
+		s.policyEngine,
+		s.quotaService,
+		s.uploadLimitService,
+		s.templateService,
+		s.schemaService,
+		s.sequenceService,
+		s.watermarkService,
+		s.downloadPolicyService,
+		s.uploadPolicyService,
+		s.inlineScanService,
 	)
 }
 
+// passthroughTransactionManager runs units of work directly with the caller's context
+type passthroughTransactionManager struct{}
+
+func (m *passthroughTransactionManager) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// noopAuditService accepts audit entries without recording them
+type noopAuditService struct{}
+
+func (m *noopAuditService) Record(ctx context.Context, entry *models.AuditLog) error {
+	return nil
+}
+
+func (m *noopAuditService) RecordAction(ctx context.Context, tenantID, actorID, action, resourceType, resourceID string, before, after map[string]interface{}) error {
+	return nil
+}
+
+func (m *noopAuditService) GetAuditLog(ctx context.Context, id string, tenantID string) (*models.AuditLog, error) {
+	return nil, nil
+}
+
+func (m *noopAuditService) ListAuditLogs(ctx context.Context, tenantID string, filter models.AuditLogFilter, pagination *utils.Pagination) (utils.PaginatedResult[models.AuditLog], error) {
+	return utils.PaginatedResult[models.AuditLog]{}, nil
+}
+
+func (m *noopAuditService) ExportAuditLogs(ctx context.Context, tenantID string, filter models.AuditLogFilter, format string, w io.Writer) (int, error) {
+	return 0, nil
+}
+
+func (m *noopAuditService) EnsurePartitions(ctx context.Context, now time.Time) error {
+	return nil
+}
+
+// stubPolicyEngine allows all document access unless a denial is configured
+type stubPolicyEngine struct {
+	denyErr error
+}
+
+func (m *stubPolicyEngine) CreatePolicy(ctx context.Context, policy *models.AccessPolicy) (string, error) {
+	return "", nil
+}
+
+func (m *stubPolicyEngine) GetPolicy(ctx context.Context, id string, tenantID string) (*models.AccessPolicy, error) {
+	return nil, nil
+}
+
+func (m *stubPolicyEngine) ListPolicies(ctx context.Context, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.AccessPolicy], error) {
+	return utils.PaginatedResult[models.AccessPolicy]{}, nil
+}
+
+func (m *stubPolicyEngine) UpdatePolicy(ctx context.Context, policy *models.AccessPolicy) error {
+	return nil
+}
+
+func (m *stubPolicyEngine) DeletePolicy(ctx context.Context, id string, tenantID string) error {
+	return nil
+}
+
+func (m *stubPolicyEngine) Enforce(ctx context.Context, tenantID, userID, action string, document *models.Document) error {
+	return m.denyErr
+}
+
+// stubQuotaService accepts all uploads unless a quota error is configured
+type stubQuotaService struct {
+	exceededErr error
+}
+
+func (m *stubQuotaService) GetQuota(ctx context.Context, tenantID string) (*models.TenantQuota, error) {
+	return models.NewTenantQuota(tenantID, 0, 0), nil
+}
+
+func (m *stubQuotaService) CheckUpload(ctx context.Context, tenantID, userID string, size int64) (*models.TenantQuota, error) {
+	return models.NewTenantQuota(tenantID, 0, 0), m.exceededErr
+}
+
+func (m *stubQuotaService) ReserveUpload(ctx context.Context, tenantID string, size int64) (*models.TenantQuota, error) {
+	return models.NewTenantQuota(tenantID, 0, 0), m.exceededErr
+}
+
+func (m *stubQuotaService) CheckVersion(ctx context.Context, tenantID, userID string, size int64) (*models.TenantQuota, error) {
+	return models.NewTenantQuota(tenantID, 0, 0), m.exceededErr
+}
+
+func (m *stubQuotaService) ReserveVersion(ctx context.Context, tenantID string, size int64) (*models.TenantQuota, error) {
+	return models.NewTenantQuota(tenantID, 0, 0), m.exceededErr
+}
+
+func (m *stubQuotaService) ReleaseDocument(ctx context.Context, tenantID string, size int64) error {
+	return nil
+}
+
+func (m *stubQuotaService) SetLimits(ctx context.Context, tenantID string, maxStorageBytes, maxDocuments int64) (*models.TenantQuota, error) {
+	return models.NewTenantQuota(tenantID, maxStorageBytes, maxDocuments), nil
+}
+
+// stubUploadLimitService accepts all uploads unless a limit error is configured
+type stubUploadLimitService struct {
+	limitErr error
+}
+
+func (m *stubUploadLimitService) CheckUpload(ctx context.Context, tenantID, userID string, size int64) error {
+	return m.limitErr
+}
+
+func (m *stubUploadLimitService) GetEffectiveLimit(ctx context.Context, tenantID, userID string) (*models.UploadLimit, error) {
+	return models.NewUploadLimit(tenantID, models.UploadLimitScopeUser, userID, 0, 0), nil
+}
+
+func (m *stubUploadLimitService) ListLimits(ctx context.Context, tenantID string) ([]*models.UploadLimit, error) {
+	return nil, nil
+}
+
+func (m *stubUploadLimitService) SetLimit(ctx context.Context, tenantID, scope, scopeID string, maxFileSizeBytes, maxDailyUploadBytes int64) (*models.UploadLimit, error) {
+	return models.NewUploadLimit(tenantID, scope, scopeID, maxFileSizeBytes, maxDailyUploadBytes), nil
+}
+
+func (m *stubUploadLimitService) DeleteLimit(ctx context.Context, tenantID, scope, scopeID string) error {
+	return nil
+}
+
+// stubMetadataTemplateService accepts all uploads unless a template error is configured
+type stubMetadataTemplateService struct {
+	missingErr error
+}
+
+func (m *stubMetadataTemplateService) CreateTemplate(ctx context.Context, template *models.MetadataTemplate) (string, error) {
+	return "", nil
+}
+
+func (m *stubMetadataTemplateService) GetTemplate(ctx context.Context, id string, tenantID string) (*models.MetadataTemplate, error) {
+	return nil, nil
+}
+
+func (m *stubMetadataTemplateService) GetFolderTemplate(ctx context.Context, folderID string, tenantID string) (*models.MetadataTemplate, error) {
+	return nil, apperrors.NewResourceNotFoundError("Metadata template not found")
+}
+
+func (m *stubMetadataTemplateService) ListTemplates(ctx context.Context, tenantID string) ([]*models.MetadataTemplate, error) {
+	return nil, nil
+}
+
+func (m *stubMetadataTemplateService) UpdateTemplate(ctx context.Context, template *models.MetadataTemplate) error {
+	return nil
+}
+
+func (m *stubMetadataTemplateService) DeleteTemplate(ctx context.Context, id string, tenantID string) error {
+	return nil
+}
+
+func (m *stubMetadataTemplateService) CheckUpload(ctx context.Context, tenantID string, folderID string, metadata map[string]string) error {
+	return m.missingErr
+}
+
+// stubMetadataSchemaService returns a configured schema, empty by default
+type stubMetadataSchemaService struct {
+	schema models.MetadataSchema
+}
+
+func (m *stubMetadataSchemaService) CreateField(ctx context.Context, field *models.MetadataField) (string, error) {
+	return "", nil
+}
+
+func (m *stubMetadataSchemaService) GetField(ctx context.Context, id string, tenantID string) (*models.MetadataField, error) {
+	return nil, nil
+}
+
+func (m *stubMetadataSchemaService) UpdateField(ctx context.Context, field *models.MetadataField) error {
+	return nil
+}
+
+func (m *stubMetadataSchemaService) DeleteField(ctx context.Context, id string, tenantID string) error {
+	return nil
+}
+
+func (m *stubMetadataSchemaService) GetSchema(ctx context.Context, tenantID string) (models.MetadataSchema, error) {
+	return m.schema, nil
+}
+
+func (m *stubMetadataSchemaService) ValidateMetadata(ctx context.Context, tenantID string, metadata map[string]string) error {
+	if err := m.schema.Validate(metadata); err != nil {
+		return apperrors.NewValidationError(err.Error())
+	}
+	return nil
+}
+
+// stubSequenceService leaves uploads unnumbered unless a sequence is configured
+type stubSequenceService struct {
+	sequence *models.NumberingSequence
+}
+
+func (m *stubSequenceService) CreateSequence(ctx context.Context, sequence *models.NumberingSequence) (string, error) {
+	return "", nil
+}
+
+func (m *stubSequenceService) GetSequence(ctx context.Context, id string, tenantID string) (*models.NumberingSequence, error) {
+	return m.sequence, nil
+}
+
+func (m *stubSequenceService) ListSequences(ctx context.Context, tenantID string) ([]*models.NumberingSequence, error) {
+	return nil, nil
+}
+
+func (m *stubSequenceService) UpdateSequence(ctx context.Context, sequence *models.NumberingSequence) error {
+	return nil
+}
+
+func (m *stubSequenceService) DeleteSequence(ctx context.Context, id string, tenantID string) error {
+	return nil
+}
+
+func (m *stubSequenceService) GetUploadSequence(ctx context.Context, tenantID string, folderID string) (*models.NumberingSequence, error) {
+	return m.sequence, nil
+}
+
+func (m *stubSequenceService) NextNumber(ctx context.Context, sequence *models.NumberingSequence) (string, error) {
+	return sequence.Next(time.Now()), nil
+}
+
+// stubWatermarkService leaves downloads unstamped unless watermarking is required
+type stubWatermarkService struct {
+	required bool
+}
+
+func (m *stubWatermarkService) RequiresWatermark(ctx context.Context, document *models.Document, link *models.ShareLink) (bool, error) {
+	return m.required, nil
+}
+
+func (m *stubWatermarkService) WatermarkForUser(ctx context.Context, content io.Reader, document *models.Document, userID string) (io.ReadCloser, int64, error) {
+	stamped := "stamped for " + userID
+	return io.NopCloser(strings.NewReader(stamped)), int64(len(stamped)), nil
+}
+
+func (m *stubWatermarkService) WatermarkForShareLink(ctx context.Context, content io.Reader, document *models.Document, link *models.ShareLink) (io.ReadCloser, int64, error) {
+	stamped := "stamped for " + link.ID
+	return io.NopCloser(strings.NewReader(stamped)), int64(len(stamped)), nil
+}
+
+// stubDownloadPolicyService allows downloads unless a refusal is set, remembering which were counted
+type stubDownloadPolicyService struct {
+	err     error
+	counted []bool
+}
+
+func (m *stubDownloadPolicyService) CheckDownload(ctx context.Context, document *models.Document, userID string, counted bool) (*models.DownloadPolicyEvaluation, error) {
+	m.counted = append(m.counted, counted)
+	return &models.DownloadPolicyEvaluation{FolderID: document.FolderID, Allowed: m.err == nil}, m.err
+}
+
+func (m *stubDownloadPolicyService) CheckExternalDownload(ctx context.Context, document *models.Document, details map[string]interface{}) (*models.DownloadPolicyEvaluation, error) {
+	return &models.DownloadPolicyEvaluation{FolderID: document.FolderID, Allowed: m.err == nil}, m.err
+}
+
+// stubUploadPolicyService accepts uploads with their declared or sniffed content type unless a
+// refusal is set
+type stubUploadPolicyService struct {
+	sniffedType string
+	err         error
+}
+
+func (m *stubUploadPolicyService) CheckUpload(ctx context.Context, tenantID, name, contentType string, size int64, content io.Reader) (string, io.Reader, error) {
+	if m.err != nil {
+		return "", nil, m.err
+	}
+	if m.sniffedType != "" {
+		return m.sniffedType, content, nil
+	}
+	return contentType, content, nil
+}
+
+// stubInlineScanService scans uploads up to maxBytes inline with the given verdict, remembering the
+// scanned content
+type stubInlineScanService struct {
+	maxBytes int64
+	clean    bool
+	scanned  []string
+}
+
+func (m *stubInlineScanService) Applies(size int64) bool {
+	return size <= m.maxBytes
+}
+
+func (m *stubInlineScanService) ScanUpload(ctx context.Context, document *models.Document, version *models.DocumentVersion, content io.Reader) bool {
+	data, _ := io.ReadAll(content)
+	m.scanned = append(m.scanned, string(data))
+	return m.clean
+}
+
 // TestUploadDocument_Success tests successful document upload
 func (s *DocumentUseCaseTestSuite) TestUploadDocument_Success() {
 	// Test data
@@ -108,6 +433,176 @@ func (s *DocumentUseCaseTestSuite) TestUploadDocument_Success() {
 	s.mockEventService.AssertExpectations(s.T())
 }
 
+// TestUploadDocument_RejectedByUploadPolicy tests that files the tenant's upload policy refuses are
+// rejected before their content is stored
+func (s *DocumentUseCaseTestSuite) TestUploadDocument_RejectedByUploadPolicy() {
+	// Test data
+	folderID := "folder-123"
+	tenantID := "tenant-123"
+	userID := "user-123"
+	content := bytes.NewReader([]byte("MZ executable"))
+
+	// Mock folder permission check
+	s.mockFolderService.On("CheckFolderPermission", s.ctx, folderID, tenantID, userID, "write").Return(nil)
+
+	// Reject the upload through the upload policy
+	s.uploadPolicyService.err = apperrors.NewValidationError("files with the extension .exe cannot be uploaded")
+
+	// Call the use case method
+	_, err := s.useCase.UploadDocument(s.ctx, "setup.exe", "application/pdf", int64(13), folderID, tenantID, userID, content, nil, "", "")
+
+	// Assert expectations
+	s.True(apperrors.IsValidationError(err))
+	s.Contains(err.Error(), ".exe")
+
+	// Verify the content was not stored
+	s.mockStorageService.AssertNotCalled(s.T(), "StoreTemporary", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	s.mockDocRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+}
+
+// TestUploadDocument_SniffedContentType tests that documents are stored with the content type
+// sniffed from their content rather than the one the client declared
+func (s *DocumentUseCaseTestSuite) TestUploadDocument_SniffedContentType() {
+	// Test data
+	folderID := "folder-123"
+	tenantID := "tenant-123"
+	userID := "user-123"
+	content := []byte("%PDF-1.7 test content")
+	s.uploadPolicyService.sniffedType = "application/pdf"
+
+	// Mock folder permission check, storage, persistence and the scan queue
+	s.mockFolderService.On("CheckFolderPermission", s.ctx, folderID, tenantID, userID, "write").Return(nil)
+	s.mockStorageService.On("GetEncryptionKeyID", mock.Anything, tenantID).Return("key-1", nil)
+	s.mockStorageService.On("StoreTemporary", mock.Anything, tenantID, mock.Anything, mock.Anything, int64(len(content)), "application/pdf").Return("temp/location/path", nil)
+	s.mockDocRepo.On("Create", mock.Anything, mock.MatchedBy(func(doc *models.Document) bool {
+		return doc.ContentType == "application/pdf"
+	})).Return("doc-123", nil)
+	s.mockDocRepo.On("AddVersion", mock.Anything, mock.AnythingOfType("*models.DocumentVersion")).Return("version-1", nil)
+	s.mockEventService.On("CreateAndPublishDocumentEvent", mock.Anything, DocumentEventUploaded, tenantID, "doc-123", mock.Anything).Return("event-1", nil)
+	s.mockVirusScanService.On("QueueForScanning", mock.Anything, "doc-123", mock.Anything, tenantID, "temp/location/path", mock.Anything, mock.Anything).Return(nil)
+
+	// Call the use case method with a content type contradicting the content
+	_, err := s.useCase.UploadDocument(s.ctx, "report.pdf", "image/png", int64(len(content)), folderID, tenantID, userID, bytes.NewReader(content), nil, "", "")
+
+	// Assert expectations
+	s.NoError(err)
+	s.mockStorageService.AssertExpectations(s.T())
+	s.mockDocRepo.AssertExpectations(s.T())
+}
+
+// TestUploadDocument_InlineScanClean tests that small uploads scanned clean inline are not queued
+// for scanning
+func (s *DocumentUseCaseTestSuite) TestUploadDocument_InlineScanClean() {
+	// Test data
+	folderID := "folder-123"
+	tenantID := "tenant-123"
+	userID := "user-123"
+	content := []byte("test content")
+	s.inlineScanService.clean = true
+
+	// Mock folder permission check, storage and persistence
+	s.mockFolderService.On("CheckFolderPermission", s.ctx, folderID, tenantID, userID, "write").Return(nil)
+	s.mockStorageService.On("GetEncryptionKeyID", mock.Anything, tenantID).Return("key-1", nil)
+	s.mockStorageService.On("StoreTemporary", mock.Anything, tenantID, mock.Anything, mock.Anything, int64(len(content)), "text/plain").Return("temp/location/path", nil)
+	s.mockDocRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.Document")).Return("doc-123", nil)
+	s.mockDocRepo.On("AddVersion", mock.Anything, mock.AnythingOfType("*models.DocumentVersion")).Return("version-1", nil)
+	s.mockEventService.On("CreateAndPublishDocumentEvent", mock.Anything, DocumentEventUploaded, tenantID, "doc-123", mock.Anything).Return("event-1", nil)
+
+	// Call the use case method
+	docID, err := s.useCase.UploadDocument(s.ctx, "notes.txt", "text/plain", int64(len(content)), folderID, tenantID, userID, bytes.NewReader(content), nil, "", "")
+
+	// Assert expectations
+	s.NoError(err)
+	s.Equal("doc-123", docID)
+	s.Equal([]string{"test content"}, s.inlineScanService.scanned)
+	s.mockVirusScanService.AssertNotCalled(s.T(), "QueueForScanning", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestUploadDocument_InlineScanNotClean tests that small uploads the inline scan did not find clean
+// are queued for scanning
+func (s *DocumentUseCaseTestSuite) TestUploadDocument_InlineScanNotClean() {
+	// Test data
+	folderID := "folder-123"
+	tenantID := "tenant-123"
+	userID := "user-123"
+	content := []byte("test content")
+
+	// Mock folder permission check, storage, persistence and the scan queue
+	s.mockFolderService.On("CheckFolderPermission", s.ctx, folderID, tenantID, userID, "write").Return(nil)
+	s.mockStorageService.On("GetEncryptionKeyID", mock.Anything, tenantID).Return("key-1", nil)
+	s.mockStorageService.On("StoreTemporary", mock.Anything, tenantID, mock.Anything, mock.Anything, int64(len(content)), "text/plain").Return("temp/location/path", nil)
+	s.mockDocRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.Document")).Return("doc-123", nil)
+	s.mockDocRepo.On("AddVersion", mock.Anything, mock.AnythingOfType("*models.DocumentVersion")).Return("version-1", nil)
+	s.mockEventService.On("CreateAndPublishDocumentEvent", mock.Anything, DocumentEventUploaded, tenantID, "doc-123", mock.Anything).Return("event-1", nil)
+	s.mockVirusScanService.On("QueueForScanning", mock.Anything, "doc-123", mock.Anything, tenantID, "temp/location/path", mock.Anything, mock.Anything).Return(nil)
+
+	// Call the use case method
+	_, err := s.useCase.UploadDocument(s.ctx, "notes.txt", "text/plain", int64(len(content)), folderID, tenantID, userID, bytes.NewReader(content), nil, "", "")
+
+	// Assert expectations
+	s.NoError(err)
+	s.Len(s.inlineScanService.scanned, 1)
+	s.mockVirusScanService.AssertExpectations(s.T())
+}
+
+// TestUploadDocument_ChecksumMismatch tests that uploads whose content does not match the client's
+// checksum are rejected and their stored copy deleted
+func (s *DocumentUseCaseTestSuite) TestUploadDocument_ChecksumMismatch() {
+	// Test data
+	folderID := "folder-123"
+	tenantID := "tenant-123"
+	userID := "user-123"
+	content := []byte("test content")
+	checksum := "923b805711041e23a99f07e146591c500261d1c289f62a9d39f8581ceb8a10ca" // SHA-256 of "other content"
+
+	// Mock folder permission check and storage
+	s.mockFolderService.On("CheckFolderPermission", s.ctx, folderID, tenantID, userID, "write").Return(nil)
+	s.mockStorageService.On("GetEncryptionKeyID", mock.Anything, tenantID).Return("key-1", nil)
+	s.mockStorageService.On("StoreTemporary", mock.Anything, tenantID, mock.Anything, mock.Anything, int64(len(content)), "text/plain").Return("temp/location/path", nil)
+	s.mockStorageService.On("DeleteDocument", mock.Anything, "temp/location/path").Return(nil)
+
+	// Call the use case method
+	_, err := s.useCase.UploadDocument(s.ctx, "notes.txt", "text/plain", int64(len(content)), folderID, tenantID, userID, bytes.NewReader(content), nil, "", checksum)
+
+	// Assert expectations
+	s.True(apperrors.IsValidationError(err))
+	s.Contains(err.Error(), checksum)
+	s.mockStorageService.AssertExpectations(s.T())
+	s.mockDocRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+}
+
+// TestUploadDocument_ChecksumVerified tests that the client's checksum is checked against the stored
+// copy and recorded on the version
+func (s *DocumentUseCaseTestSuite) TestUploadDocument_ChecksumVerified() {
+	// Test data
+	folderID := "folder-123"
+	tenantID := "tenant-123"
+	userID := "user-123"
+	content := []byte("test content")
+	checksum := "6ae8a75555209fd6c44157c0aed8016e763ff435a19cf186f76863140143ff72" // SHA-256 of "test content"
+	s.inlineScanService.clean = true
+
+	// Mock folder permission check, storage and persistence
+	s.mockFolderService.On("CheckFolderPermission", s.ctx, folderID, tenantID, userID, "write").Return(nil)
+	s.mockStorageService.On("GetEncryptionKeyID", mock.Anything, tenantID).Return("key-1", nil)
+	s.mockStorageService.On("StoreTemporary", mock.Anything, tenantID, mock.Anything, mock.Anything, int64(len(content)), "text/plain").Return("temp/location/path", nil)
+	s.mockStorageService.On("HashDocument", mock.Anything, "temp/location/path").Return(checksum, nil)
+	s.mockDocRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.Document")).Return("doc-123", nil)
+	s.mockDocRepo.On("AddVersion", mock.Anything, mock.MatchedBy(func(version *models.DocumentVersion) bool {
+		return version.ClientChecksum == checksum && version.ContentHash == checksum && version.IntegrityVerifiedAt != nil
+	})).Return("version-1", nil)
+	s.mockEventService.On("CreateAndPublishDocumentEvent", mock.Anything, DocumentEventUploaded, tenantID, "doc-123", mock.Anything).Return("event-1", nil)
+
+	// Call the use case method with the checksum in upper case
+	docID, err := s.useCase.UploadDocument(s.ctx, "notes.txt", "text/plain", int64(len(content)), folderID, tenantID, userID, bytes.NewReader(content), nil, "", strings.ToUpper(checksum))
+
+	// Assert expectations
+	s.NoError(err)
+	s.Equal("doc-123", docID)
+	s.mockStorageService.AssertExpectations(s.T())
+	s.mockDocRepo.AssertExpectations(s.T())
+}
+
 // TestUploadDocument_ValidationError tests document upload with validation errors
 func (s *DocumentUseCaseTestSuite) TestUploadDocument_ValidationError() {
 	// Test cases for validation errors
@@ -259,6 +754,111 @@ func (s *DocumentUseCaseTestSuite) TestUploadDocument_StorageError() {
 	s.mockStorageService.AssertExpectations(s.T())
 }
 
+// TestUploadDocument_QuotaExceeded tests that uploads exceeding the tenant's quota are rejected before their content is stored
+func (s *DocumentUseCaseTestSuite) TestUploadDocument_QuotaExceeded() {
+	// Test data
+	folderID := "folder-123"
+	tenantID := "tenant-123"
+	userID := "user-123"
+	content := bytes.NewReader([]byte("test content"))
+
+	// Mock folder permission check
+	s.mockFolderService.On("CheckFolderPermission", s.ctx, folderID, tenantID, userID, "write").Return(nil)
+
+	// Reject the upload through the quota service
+	s.quotaService.exceededErr = apperrors.NewStorageQuotaExceededError(models.ErrStorageQuotaExceeded.Error())
+
+	// Call the use case method
+	_, err := s.useCase.UploadDocument(s.ctx, "test.pdf", "application/pdf", int64(1024), folderID, tenantID, userID, content, nil, "", "")
+
+	// Assert expectations
+	s.True(apperrors.IsQuotaExceededError(err))
+	s.Equal(http.StatusRequestEntityTooLarge, apperrors.GetStatusCode(err))
+
+	// Verify the content was not stored
+	s.mockStorageService.AssertNotCalled(s.T(), "StoreTemporary", mock.Anything, mock.Anything, mock.Anything)
+	s.mockDocRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+}
+
+// TestUploadDocument_UploadLimitExceeded tests that files beyond the user's upload limits are rejected
+// before the tenant's quota is checked
+func (s *DocumentUseCaseTestSuite) TestUploadDocument_UploadLimitExceeded() {
+	// Test data
+	folderID := "folder-123"
+	tenantID := "tenant-123"
+	userID := "user-123"
+	content := bytes.NewReader([]byte("test content"))
+
+	// Mock folder permission check
+	s.mockFolderService.On("CheckFolderPermission", s.ctx, folderID, tenantID, userID, "write").Return(nil)
+
+	// Reject the upload through the upload limits and the quota
+	s.uploadLimitService.limitErr = apperrors.NewValidationError("file size of 1024 bytes exceeds your maximum file size of 512 bytes")
+	s.quotaService.exceededErr = apperrors.NewStorageQuotaExceededError(models.ErrStorageQuotaExceeded.Error())
+
+	// Call the use case method
+	_, err := s.useCase.UploadDocument(s.ctx, "test.pdf", "application/pdf", int64(1024), folderID, tenantID, userID, content, nil, "", "")
+
+	// Assert expectations
+	s.True(apperrors.IsValidationError(err))
+	s.Contains(err.Error(), "maximum file size of 512 bytes")
+
+	// Verify the content was not stored
+	s.mockStorageService.AssertNotCalled(s.T(), "StoreTemporary", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestUploadDocument_MissingTemplateMetadata tests that uploads missing metadata enforced by the
+// folder's template are rejected before their content is stored
+func (s *DocumentUseCaseTestSuite) TestUploadDocument_MissingTemplateMetadata() {
+	// Test data
+	folderID := "folder-123"
+	tenantID := "tenant-123"
+	userID := "user-123"
+	content := bytes.NewReader([]byte("test content"))
+
+	// Mock folder permission check
+	s.mockFolderService.On("CheckFolderPermission", s.ctx, folderID, tenantID, userID, "write").Return(nil)
+
+	// Reject the upload through the folder's metadata template
+	s.templateService.missingErr = apperrors.NewValidationError("metadata invoice_number, vendor is required by the Invoices template of the folder")
+
+	// Call the use case method
+	_, err := s.useCase.UploadDocument(s.ctx, "test.pdf", "application/pdf", int64(1024), folderID, tenantID, userID, content, nil, "", "")
+
+	// Assert expectations
+	s.True(apperrors.IsValidationError(err))
+	s.Contains(err.Error(), "invoice_number, vendor")
+
+	// Verify the content was not stored
+	s.mockStorageService.AssertNotCalled(s.T(), "StoreTemporary", mock.Anything, mock.Anything, mock.Anything)
+	s.mockDocRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+}
+
+// TestUploadDocument_ReservedMetadata tests that uploads cannot set the document number their
+// folder's numbering sequence assigns
+func (s *DocumentUseCaseTestSuite) TestUploadDocument_ReservedMetadata() {
+	// Test data
+	folderID := "folder-123"
+	tenantID := "tenant-123"
+	userID := "user-123"
+	content := bytes.NewReader([]byte("test content"))
+	metadata := map[string]string{models.DocumentNumberMetadataKey: "INV-2024-00042"}
+
+	// Mock folder permission check
+	s.mockFolderService.On("CheckFolderPermission", s.ctx, folderID, tenantID, userID, "write").Return(nil)
+
+	// Call the use case method
+	_, err := s.useCase.UploadDocument(s.ctx, "test.pdf", "application/pdf", int64(1024), folderID, tenantID, userID, content, metadata, "", "")
+
+	// Assert expectations
+	s.True(apperrors.IsValidationError(err))
+	s.Contains(err.Error(), models.DocumentNumberMetadataKey)
+
+	// Verify the content was not stored
+	s.mockStorageService.AssertNotCalled(s.T(), "StoreTemporary", mock.Anything, mock.Anything, mock.Anything)
+	s.mockDocRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+}
+
 // TestUploadDocument_RepositoryError tests document upload with repository error
 func (s *DocumentUseCaseTestSuite) TestUploadDocument_RepositoryError() {
 	// Test data
@@ -294,6 +894,102 @@ func (s *DocumentUseCaseTestSuite) TestUploadDocument_RepositoryError() {
 	s.mockDocRepo.AssertExpectations(s.T())
 }
 
+// TestUploadDocuments_TooManyFiles tests that batches beyond the maximum size are rejected as a whole
+func (s *DocumentUseCaseTestSuite) TestUploadDocuments_TooManyFiles() {
+	uploads := make([]DocumentUpload, MaxBatchUploadSize+1)
+	for i := range uploads {
+		uploads[i] = DocumentUpload{Name: "test.pdf", ContentType: "application/pdf", Size: 12, Content: bytes.NewReader([]byte("test content"))}
+	}
+
+	results, err := s.useCase.UploadDocuments(s.ctx, uploads, "folder-123", "tenant-123", "user-123")
+
+	s.Error(err)
+	s.True(apperrors.IsValidationError(err))
+	s.Nil(results)
+}
+
+// TestUploadDocuments_ReportsPerFileErrors tests that invalid files are reported in their results
+// without failing the batch
+func (s *DocumentUseCaseTestSuite) TestUploadDocuments_ReportsPerFileErrors() {
+	uploads := []DocumentUpload{
+		{Name: "", ContentType: "application/pdf", Size: 12, Content: bytes.NewReader([]byte("test content"))},
+		{Name: "empty.pdf", ContentType: "application/pdf", Size: 0, Content: bytes.NewReader(nil)},
+	}
+
+	results, err := s.useCase.UploadDocuments(s.ctx, uploads, "folder-123", "tenant-123", "user-123")
+
+	s.NoError(err)
+	s.Len(results, 2)
+	s.Equal("empty.pdf", results[1].Name)
+	for _, result := range results {
+		s.Empty(result.DocumentID)
+		s.True(apperrors.IsValidationError(result.Err))
+	}
+}
+
+// TestBulkUpdateMetadata_TooManyDocuments tests that bulk updates beyond the maximum size are rejected
+// as a whole
+func (s *DocumentUseCaseTestSuite) TestBulkUpdateMetadata_TooManyDocuments() {
+	documentIDs := make([]string, MaxBulkMetadataUpdateSize+1)
+	for i := range documentIDs {
+		documentIDs[i] = fmt.Sprintf("doc-%d", i)
+	}
+	patch := models.MetadataPatch{Set: map[string]string{"status": "reviewed"}}
+
+	results, err := s.useCase.BulkUpdateMetadata(s.ctx, documentIDs, patch, false, "tenant-123", "user-123")
+
+	s.True(apperrors.IsValidationError(err))
+	s.Nil(results)
+	s.mockDocRepo.AssertNotCalled(s.T(), "GetDocumentsByIDs")
+}
+
+// TestBulkUpdateMetadata_AtomicRejectsAll tests that an atomic bulk update applies nothing when a
+// document fails the schema, reporting the documents that passed as aborted
+func (s *DocumentUseCaseTestSuite) TestBulkUpdateMetadata_AtomicRejectsAll() {
+	tenantID := "tenant-123"
+	userID := "user-123"
+	valid := s.createTestDocument("doc-1", "a.pdf", "application/pdf", tenantID, "folder-123", models.DocumentStatusAvailable)
+	invalid := s.createTestDocument("doc-2", "b.pdf", "application/pdf", tenantID, "folder-123", models.DocumentStatusAvailable)
+	s.schemaService.schema = models.MetadataSchema{
+		models.NewMetadataField(tenantID, "amount", models.MetadataFieldTypeNumber, true, nil, userID),
+	}
+
+	s.mockDocRepo.On("GetDocumentsByIDs", s.ctx, []string{"doc-1", "doc-2"}, tenantID).Return([]*models.Document{valid, invalid}, nil)
+	s.mockAuthService.On("VerifyResourceAccess", s.ctx, userID, tenantID, services.ResourceTypeDocument, mock.Anything, services.PermissionWrite).Return(true, nil)
+
+	valid.AddMetadata("amount", "7")
+	patch := models.MetadataPatch{Set: map[string]string{"status": "reviewed"}}
+	results, err := s.useCase.BulkUpdateMetadata(s.ctx, []string{"doc-1", "doc-2"}, patch, true, tenantID, userID)
+
+	s.NoError(err)
+	s.Len(results, 2)
+	for _, result := range results {
+		s.True(apperrors.IsValidationError(result.Err))
+	}
+	s.Equal(ErrBulkUpdateAborted, results[0].Err)
+	s.mockDocRepo.AssertNotCalled(s.T(), "BulkUpdateMetadata")
+	s.mockEventService.AssertNotCalled(s.T(), "CreateAndPublishDocumentsEvent")
+}
+
+// TestBulkUpdateMetadata_LockedDocument tests that documents awaiting approval are not updated
+func (s *DocumentUseCaseTestSuite) TestBulkUpdateMetadata_LockedDocument() {
+	tenantID := "tenant-123"
+	userID := "user-123"
+	locked := s.createTestDocument("doc-1", "a.pdf", "application/pdf", tenantID, "folder-123", models.DocumentStatusAvailable)
+	locked.Lock("user-456", time.Now())
+
+	s.mockDocRepo.On("GetDocumentsByIDs", s.ctx, []string{"doc-1"}, tenantID).Return([]*models.Document{locked}, nil)
+	s.mockAuthService.On("VerifyResourceAccess", s.ctx, userID, tenantID, services.ResourceTypeDocument, "doc-1", services.PermissionWrite).Return(true, nil)
+
+	patch := models.MetadataPatch{Set: map[string]string{"status": "reviewed"}}
+	results, err := s.useCase.BulkUpdateMetadata(s.ctx, []string{"doc-1"}, patch, false, tenantID, userID)
+
+	s.NoError(err)
+	s.Len(results, 1)
+	s.Equal(ErrDocumentLocked, results[0].Err)
+	s.mockDocRepo.AssertNotCalled(s.T(), "BulkUpdateMetadata")
+}
+
 // TestGetDocument_Success tests successful document retrieval
 func (s *DocumentUseCaseTestSuite) TestGetDocument_Success() {
 	// Test data
@@ -394,7 +1090,36 @@ func (s *DocumentUseCaseTestSuite) TestGetDocument_PermissionDenied() {
 	
 	// Verify mocks
 	s.mockDocRepo.AssertExpectations(s.T())
-	s.mockAuthService.AssertExpectations(s.T())
+	s.mockAuthService.AssertExpectations(s.T())
+}
+
+// TestGetDocument_DeniedByPolicy tests document retrieval denied by an attribute-based access policy
+func (s *DocumentUseCaseTestSuite) TestGetDocument_DeniedByPolicy() {
+	// Test data
+	documentID := "doc-123"
+	tenantID := "tenant-123"
+	userID := "user-123"
+	
+	// Create a confidential test document to be returned by the repository
+	testDoc := s.createTestDocument(documentID, "test.pdf", "application/pdf", tenantID, "folder-123", models.DocumentStatusAvailable)
+	testDoc.AddMetadata("classification", "confidential")
+	
+	// Mock document retrieval and a passing role permission check
+	s.mockDocRepo.On("GetByID", s.ctx, documentID).Return(testDoc, nil)
+	s.mockAuthService.On("CheckDocumentPermission", s.ctx, testDoc, userID, "read").Return(nil)
+	
+	// Deny access through the policy engine
+	s.policyEngine.denyErr = apperrors.NewAuthorizationError("access denied by policy confidential-legal-only")
+	
+	// Call the use case method
+	_, err := s.useCase.GetDocument(s.ctx, documentID, tenantID, userID)
+	
+	// Assert expectations
+	s.True(apperrors.IsAuthorizationError(err))
+	s.Contains(err.Error(), "confidential-legal-only")
+	
+	// Verify mocks
+	s.mockDocRepo.AssertExpectations(s.T())
 }
 
 // TestDownloadDocument_Success tests successful document download
@@ -471,6 +1196,230 @@ func (s *DocumentUseCaseTestSuite) TestDownloadDocument_NotAvailable() {
 	s.mockAuthService.AssertExpectations(s.T())
 }
 
+// TestDownloadDocument_Watermarked tests that downloads from watermarked folders are stamped for the
+// user and served whole, without an entity tag
+func (s *DocumentUseCaseTestSuite) TestDownloadDocument_Watermarked() {
+	// Test data
+	documentID := "doc-123"
+	tenantID := "tenant-123"
+	userID := "user-123"
+
+	// Create an available test document with a version
+	testDoc := s.createTestDocument(documentID, "test.pdf", "application/pdf", tenantID, "folder-123", models.DocumentStatusAvailable)
+	testVersion := s.createTestDocumentVersion("ver-123", documentID, 1, models.VersionStatusAvailable, "storage/path")
+	testDoc.Versions = append(testDoc.Versions, testVersion)
+
+	// Mock document retrieval, permission check and the whole content, although a range is requested
+	s.mockDocRepo.On("GetByID", s.ctx, documentID).Return(testDoc, nil)
+	s.mockAuthService.On("CheckDocumentPermission", s.ctx, testDoc, userID, "read").Return(nil)
+	s.mockStorageService.On("GetContent", s.ctx, testVersion.StoragePath).Return(io.NopCloser(bytes.NewReader([]byte("document content"))), nil)
+	s.watermarkService.required = true
+
+	// Call the use case method
+	download, err := s.useCase.DownloadDocumentRange(s.ctx, documentID, tenantID, userID, "bytes=0-3", "")
+
+	// Assert expectations
+	s.NoError(err)
+	s.True(download.Watermarked)
+	s.Nil(download.Range)
+	s.Empty(download.ETag)
+	contentBytes, err := io.ReadAll(download.Content)
+	s.NoError(err)
+	s.Equal("stamped for user-123", string(contentBytes))
+	s.Equal(int64(len(contentBytes)), download.Size)
+}
+
+// TestGetDocumentPresignedURL_Watermarked tests that watermarked documents have no presigned URL,
+// which would hand out their content unstamped
+func (s *DocumentUseCaseTestSuite) TestGetDocumentPresignedURL_Watermarked() {
+	// Test data
+	documentID := "doc-123"
+	tenantID := "tenant-123"
+	userID := "user-123"
+
+	// Create an available test document with a version
+	testDoc := s.createTestDocument(documentID, "test.pdf", "application/pdf", tenantID, "folder-123", models.DocumentStatusAvailable)
+	testDoc.Versions = append(testDoc.Versions, s.createTestDocumentVersion("ver-123", documentID, 1, models.VersionStatusAvailable, "storage/path"))
+
+	// Mock document retrieval and permission check
+	s.mockDocRepo.On("GetByID", s.ctx, documentID).Return(testDoc, nil)
+	s.mockAuthService.On("CheckDocumentPermission", s.ctx, testDoc, userID, "read").Return(nil)
+	s.watermarkService.required = true
+
+	// Call the use case method
+	url, err := s.useCase.GetDocumentPresignedURL(s.ctx, documentID, tenantID, userID, 3600)
+
+	// Assert expectations
+	s.Empty(url)
+	s.Equal(ErrWatermarkRequired, err)
+	s.mockStorageService.AssertNotCalled(s.T(), "GetPresignedURL", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestDownloadDocument_ViewOnly tests that documents in view-only folders are not read from storage
+func (s *DocumentUseCaseTestSuite) TestDownloadDocument_ViewOnly() {
+	// Test data
+	documentID := "doc-123"
+	tenantID := "tenant-123"
+	userID := "user-123"
+
+	// Create an available test document with a version
+	testDoc := s.createTestDocument(documentID, "test.pdf", "application/pdf", tenantID, "folder-123", models.DocumentStatusAvailable)
+	testDoc.Versions = append(testDoc.Versions, s.createTestDocumentVersion("ver-123", documentID, 1, models.VersionStatusAvailable, "storage/path"))
+
+	// Mock document retrieval and permission check
+	s.mockDocRepo.On("GetByID", s.ctx, documentID).Return(testDoc, nil)
+	s.mockAuthService.On("CheckDocumentPermission", s.ctx, testDoc, userID, "read").Return(nil)
+	s.downloadPolicyService.err = services.ErrDownloadViewOnly
+
+	// Call the use case method
+	_, _, err := s.useCase.DownloadDocument(s.ctx, documentID, tenantID, userID)
+
+	// Assert expectations
+	s.Equal(services.ErrDownloadViewOnly, err)
+	s.mockStorageService.AssertNotCalled(s.T(), "GetDocument", mock.Anything, mock.Anything)
+	s.mockEventService.AssertNotCalled(s.T(), "CreateAndPublishDocumentEvent", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestDownloadDocumentRange_ContinuationNotCounted tests that only the request for the beginning of
+// a document counts towards daily download limits
+func (s *DocumentUseCaseTestSuite) TestDownloadDocumentRange_ContinuationNotCounted() {
+	// Test data
+	documentID := "doc-123"
+	tenantID := "tenant-123"
+	userID := "user-123"
+
+	// Create an available test document with a version
+	testDoc := s.createTestDocument(documentID, "test.pdf", "application/pdf", tenantID, "folder-123", models.DocumentStatusAvailable)
+	testVersion := s.createTestDocumentVersion("ver-123", documentID, 1, models.VersionStatusAvailable, "storage/path")
+	testVersion.Size = 16
+	testDoc.Versions = append(testDoc.Versions, testVersion)
+
+	// Mock document retrieval, permission check and both ranges
+	s.mockDocRepo.On("GetByID", s.ctx, documentID).Return(testDoc, nil)
+	s.mockAuthService.On("CheckDocumentPermission", s.ctx, testDoc, userID, "read").Return(nil)
+	s.mockStorageService.On("GetDocumentRange", s.ctx, testVersion.StoragePath, mock.Anything, mock.Anything).Return(io.NopCloser(bytes.NewReader([]byte("document"))), nil)
+
+	// Call the use case method for the beginning and the rest of the document
+	_, err := s.useCase.DownloadDocumentRange(s.ctx, documentID, tenantID, userID, "bytes=0-7", "")
+	s.NoError(err)
+	_, err = s.useCase.DownloadDocumentRange(s.ctx, documentID, tenantID, userID, "bytes=8-15", "")
+	s.NoError(err)
+
+	// Assert expectations
+	s.Equal([]bool{true, false}, s.downloadPolicyService.counted)
+}
+
+// TestDownloadDocumentVersion_PreviousVersion tests that an available older version can be
+// downloaded while the latest version is still being processed
+func (s *DocumentUseCaseTestSuite) TestDownloadDocumentVersion_PreviousVersion() {
+	// Test data
+	documentID := "doc-123"
+	tenantID := "tenant-123"
+	userID := "user-123"
+
+	// Create a test document whose latest version is still processing
+	testDoc := s.createTestDocument(documentID, "test.pdf", "application/pdf", tenantID, "folder-123", models.DocumentStatusProcessing)
+	firstVersion := s.createTestDocumentVersion("ver-1", documentID, 1, models.VersionStatusAvailable, "storage/path/v1")
+	testDoc.Versions = append(testDoc.Versions, firstVersion,
+		s.createTestDocumentVersion("ver-2", documentID, 2, models.VersionStatusProcessing, "temp/path/v2"))
+
+	// Mock document retrieval, permission check and the content of the first version
+	s.mockDocRepo.On("GetByID", s.ctx, documentID, tenantID).Return(testDoc, nil)
+	s.mockAuthService.On("VerifyResourceAccess", s.ctx, userID, tenantID, services.ResourceTypeDocument, documentID, services.PermissionRead).Return(true, nil)
+	s.mockStorageService.On("GetDocument", s.ctx, firstVersion.StoragePath).Return(io.NopCloser(bytes.NewReader([]byte("first version"))), nil)
+	s.mockEventService.On("CreateAndPublishDocumentEvent", s.ctx, DocumentEventDownloaded, tenantID, documentID, mock.Anything).Return("event-1", nil)
+
+	// Call the use case method
+	download, err := s.useCase.DownloadDocumentVersion(s.ctx, documentID, "ver-1", tenantID, userID, "", "")
+
+	// Assert expectations
+	s.NoError(err)
+	s.Equal(`"hash123"`, download.ETag)
+	s.Equal(firstVersion.Size, download.Size)
+	s.mockStorageService.AssertExpectations(s.T())
+}
+
+// TestDownloadDocumentVersion_NotAvailable tests that versions being processed cannot be downloaded
+func (s *DocumentUseCaseTestSuite) TestDownloadDocumentVersion_NotAvailable() {
+	// Test data
+	documentID := "doc-123"
+	tenantID := "tenant-123"
+	userID := "user-123"
+
+	// Create a test document whose latest version is still processing
+	testDoc := s.createTestDocument(documentID, "test.pdf", "application/pdf", tenantID, "folder-123", models.DocumentStatusProcessing)
+	testDoc.Versions = append(testDoc.Versions,
+		s.createTestDocumentVersion("ver-1", documentID, 1, models.VersionStatusAvailable, "storage/path/v1"),
+		s.createTestDocumentVersion("ver-2", documentID, 2, models.VersionStatusProcessing, "temp/path/v2"))
+
+	// Mock document retrieval and permission check
+	s.mockDocRepo.On("GetByID", s.ctx, documentID, tenantID).Return(testDoc, nil)
+	s.mockAuthService.On("VerifyResourceAccess", s.ctx, userID, tenantID, services.ResourceTypeDocument, documentID, services.PermissionRead).Return(true, nil)
+
+	// Call the use case method for the processing version and for a version of another document
+	_, err := s.useCase.DownloadDocumentVersion(s.ctx, documentID, "ver-2", tenantID, userID, "", "")
+	s.Equal(ErrVersionNotAvailable, err)
+	_, err = s.useCase.DownloadDocumentVersion(s.ctx, documentID, "ver-other", tenantID, userID, "", "")
+	s.Equal(ErrVersionNotFound, err)
+
+	// Verify no content was read
+	s.mockStorageService.AssertNotCalled(s.T(), "GetDocument", mock.Anything, mock.Anything)
+}
+
+// TestListDocumentVersions_NewestFirst tests that versions are listed newest first
+func (s *DocumentUseCaseTestSuite) TestListDocumentVersions_NewestFirst() {
+	// Test data
+	documentID := "doc-123"
+	tenantID := "tenant-123"
+	userID := "user-123"
+
+	// Create a test document with versions loaded out of order
+	testDoc := s.createTestDocument(documentID, "test.pdf", "application/pdf", tenantID, "folder-123", models.DocumentStatusAvailable)
+	testDoc.Versions = append(testDoc.Versions,
+		s.createTestDocumentVersion("ver-2", documentID, 2, models.VersionStatusAvailable, "storage/path/v2"),
+		s.createTestDocumentVersion("ver-3", documentID, 3, models.VersionStatusAvailable, "storage/path/v3"),
+		s.createTestDocumentVersion("ver-1", documentID, 1, models.VersionStatusAvailable, "storage/path/v1"))
+
+	// Mock document retrieval and permission check
+	s.mockDocRepo.On("GetByID", s.ctx, documentID, tenantID).Return(testDoc, nil)
+	s.mockAuthService.On("VerifyResourceAccess", s.ctx, userID, tenantID, services.ResourceTypeDocument, documentID, services.PermissionRead).Return(true, nil)
+
+	// Call the use case method
+	versions, err := s.useCase.ListDocumentVersions(s.ctx, documentID, tenantID, userID)
+
+	// Assert expectations
+	s.NoError(err)
+	s.Require().Len(versions, 3)
+	s.Equal([]string{"ver-3", "ver-2", "ver-1"}, []string{versions[0].ID, versions[1].ID, versions[2].ID})
+}
+
+// TestGetDocumentPresignedURL_DailyLimitReached tests that no presigned URL is generated once the
+// folder's daily download limit is reached
+func (s *DocumentUseCaseTestSuite) TestGetDocumentPresignedURL_DailyLimitReached() {
+	// Test data
+	documentID := "doc-123"
+	tenantID := "tenant-123"
+	userID := "user-123"
+
+	// Create an available test document with a version
+	testDoc := s.createTestDocument(documentID, "test.pdf", "application/pdf", tenantID, "folder-123", models.DocumentStatusAvailable)
+	testDoc.Versions = append(testDoc.Versions, s.createTestDocumentVersion("ver-123", documentID, 1, models.VersionStatusAvailable, "storage/path"))
+
+	// Mock document retrieval and permission check
+	s.mockDocRepo.On("GetByID", s.ctx, documentID).Return(testDoc, nil)
+	s.mockAuthService.On("CheckDocumentPermission", s.ctx, testDoc, userID, "read").Return(nil)
+	s.downloadPolicyService.err = services.ErrDownloadLimitReached
+
+	// Call the use case method
+	url, err := s.useCase.GetDocumentPresignedURL(s.ctx, documentID, tenantID, userID, 3600)
+
+	// Assert expectations
+	s.Empty(url)
+	s.True(apperrors.IsQuotaExceededError(err))
+	s.Equal([]bool{true}, s.downloadPolicyService.counted)
+	s.mockStorageService.AssertNotCalled(s.T(), "GetPresignedURL", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
 // TestGetDocumentPresignedURL_Success tests successful generation of presigned URL for document download
 func (s *DocumentUseCaseTestSuite) TestGetDocumentPresignedURL_Success() {
 	// Test data
@@ -606,6 +1555,64 @@ func (s *DocumentUseCaseTestSuite) TestBatchDownloadDocuments_PermissionDenied()
 	s.mockAuthService.AssertExpectations(s.T())
 }
 
+// TestBatchDownloadDocuments_ViewOnly tests that a document in a view-only folder refuses the whole
+// archive before any content is read from storage
+func (s *DocumentUseCaseTestSuite) TestBatchDownloadDocuments_ViewOnly() {
+	// Test data
+	documentIDs := []string{"doc-123", "doc-456"}
+	tenantID := "tenant-123"
+	userID := "user-123"
+
+	// Create available test documents with a version each
+	testDoc1 := s.createTestDocument(documentIDs[0], "test1.pdf", "application/pdf", tenantID, "folder-123", models.DocumentStatusAvailable)
+	testDoc1.Versions = append(testDoc1.Versions, s.createTestDocumentVersion("ver-123", documentIDs[0], 1, models.VersionStatusAvailable, "storage/path1"))
+	testDoc2 := s.createTestDocument(documentIDs[1], "test2.pdf", "application/pdf", tenantID, "folder-456", models.DocumentStatusAvailable)
+	testDoc2.Versions = append(testDoc2.Versions, s.createTestDocumentVersion("ver-456", documentIDs[1], 1, models.VersionStatusAvailable, "storage/path2"))
+
+	// Mock document retrieval and permission checks
+	s.mockDocRepo.On("GetByID", s.ctx, documentIDs[0]).Return(testDoc1, nil)
+	s.mockDocRepo.On("GetByID", s.ctx, documentIDs[1]).Return(testDoc2, nil)
+	s.mockAuthService.On("CheckDocumentPermission", s.ctx, testDoc1, userID, "read").Return(nil)
+	s.mockAuthService.On("CheckDocumentPermission", s.ctx, testDoc2, userID, "read").Return(nil)
+	s.downloadPolicyService.err = services.ErrDownloadViewOnly
+
+	// Call the use case method
+	archive, err := s.useCase.BatchDownloadDocuments(s.ctx, documentIDs, tenantID, userID)
+
+	// Assert expectations
+	s.Nil(archive)
+	s.Equal(services.ErrDownloadViewOnly, err)
+	s.Equal([]bool{true}, s.downloadPolicyService.counted)
+	s.mockStorageService.AssertNotCalled(s.T(), "CreateBatchArchive", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestBatchDownloadDocuments_Watermarked tests that documents which have to be stamped for the user
+// are not handed out unstamped in an archive
+func (s *DocumentUseCaseTestSuite) TestBatchDownloadDocuments_Watermarked() {
+	// Test data
+	documentIDs := []string{"doc-123"}
+	tenantID := "tenant-123"
+	userID := "user-123"
+
+	// Create an available test document with a version
+	testDoc := s.createTestDocument(documentIDs[0], "test1.pdf", "application/pdf", tenantID, "folder-123", models.DocumentStatusAvailable)
+	testDoc.Versions = append(testDoc.Versions, s.createTestDocumentVersion("ver-123", documentIDs[0], 1, models.VersionStatusAvailable, "storage/path1"))
+
+	// Mock document retrieval and permission check
+	s.mockDocRepo.On("GetByID", s.ctx, documentIDs[0]).Return(testDoc, nil)
+	s.mockAuthService.On("CheckDocumentPermission", s.ctx, testDoc, userID, "read").Return(nil)
+	s.watermarkService.required = true
+
+	// Call the use case method
+	archive, err := s.useCase.BatchDownloadDocuments(s.ctx, documentIDs, tenantID, userID)
+
+	// Assert expectations
+	s.Nil(archive)
+	s.True(apperrors.IsValidationError(err))
+	s.Empty(s.downloadPolicyService.counted)
+	s.mockStorageService.AssertNotCalled(s.T(), "CreateBatchArchive", mock.Anything, mock.Anything, mock.Anything)
+}
+
 // TestGetBatchDownloadPresignedURL_Success tests successful generation of presigned URL for batch document download
 func (s *DocumentUseCaseTestSuite) TestGetBatchDownloadPresignedURL_Success() {
 	// Test data
@@ -672,25 +1679,20 @@ func (s *DocumentUseCaseTestSuite) TestDeleteDocument_Success() {
 	testDoc.Versions = append(testDoc.Versions, testVersion)
 	
 	// Mock document retrieval from repository
-	s.mockDocRepo.On("GetByID", s.ctx, documentID).Return(testDoc, nil)
+	s.mockDocRepo.On("GetByID", s.ctx, documentID, tenantID).Return(testDoc, nil)
 	
 	// Mock permission check for delete permission
-	s.mockAuthService.On("CheckDocumentPermission", s.ctx, testDoc, userID, "delete").Return(nil)
+	s.mockAuthService.On("VerifyResourceAccess", s.ctx, userID, tenantID, services.ResourceTypeDocument, documentID, services.PermissionDelete).Return(true, nil)
+	
+	// Mock document deletion from repository and the event enqueued with it
+	s.mockDocRepo.On("Delete", s.ctx, documentID, tenantID).Return(nil)
+	s.mockEventService.On("CreateAndPublishDocumentEvent", s.ctx, DocumentEventDeleted, tenantID, documentID, mock.Anything).Return("event-1", nil)
 	
 	// Mock document content deletion from storage for each version
-	s.mockStorageService.On("DeleteContent", s.ctx, testVersion.StoragePath).Return(nil)
+	s.mockStorageService.On("DeleteDocument", s.ctx, testVersion.StoragePath).Return(nil)
 	
 	// Mock document removal from search index
-	s.mockSearchService.On("RemoveDocumentFromIndex", s.ctx, documentID).Return(nil)
-	
-	// Mock thumbnail deletion
-	s.mockThumbnailService.On("DeleteThumbnail", s.ctx, documentID).Return(nil)
-	
-	// Mock document deletion from repository
-	s.mockDocRepo.On("Delete", s.ctx, documentID).Return(nil)
-	
-	// Mock event publishing
-	s.mockEventService.On("PublishDocumentDeletedEvent", s.ctx, testDoc, userID).Return(nil)
+	s.mockSearchService.On("RemoveDocumentFromIndex", s.ctx, documentID, tenantID).Return(nil)
 	
 	// Call the use case method
 	err := s.useCase.DeleteDocument(s.ctx, documentID, tenantID, userID)
@@ -703,7 +1705,6 @@ func (s *DocumentUseCaseTestSuite) TestDeleteDocument_Success() {
 	s.mockAuthService.AssertExpectations(s.T())
 	s.mockStorageService.AssertExpectations(s.T())
 	s.mockSearchService.AssertExpectations(s.T())
-	s.mockThumbnailService.AssertExpectations(s.T())
 	s.mockEventService.AssertExpectations(s.T())
 }
 
@@ -718,11 +1719,10 @@ func (s *DocumentUseCaseTestSuite) TestDeleteDocument_PermissionDenied() {
 	testDoc := s.createTestDocument(documentID, "test.pdf", "application/pdf", tenantID, "folder-123", models.DocumentStatusAvailable)
 	
 	// Mock document retrieval from repository
-	s.mockDocRepo.On("GetByID", s.ctx, documentID).Return(testDoc, nil)
+	s.mockDocRepo.On("GetByID", s.ctx, documentID, tenantID).Return(testDoc, nil)
 	
-	// Mock permission check to return permission denied
-	permError := apperrors.NewAuthorizationError("permission denied to delete document")
-	s.mockAuthService.On("CheckDocumentPermission", s.ctx, testDoc, userID, "delete").Return(permError)
+	// Mock permission check to deny the delete permission
+	s.mockAuthService.On("VerifyResourceAccess", s.ctx, userID, tenantID, services.ResourceTypeDocument, documentID, services.PermissionDelete).Return(false, nil)
 	
 	// Call the use case method
 	err := s.useCase.DeleteDocument(s.ctx, documentID, tenantID, userID)
@@ -734,6 +1734,30 @@ func (s *DocumentUseCaseTestSuite) TestDeleteDocument_PermissionDenied() {
 	// Verify mocks
 	s.mockDocRepo.AssertExpectations(s.T())
 	s.mockAuthService.AssertExpectations(s.T())
+	s.mockDocRepo.AssertNotCalled(s.T(), "Delete", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestDeleteDocument_Locked tests that documents awaiting approval cannot be deleted
+func (s *DocumentUseCaseTestSuite) TestDeleteDocument_Locked() {
+	// Test data
+	documentID := "doc-123"
+	tenantID := "tenant-123"
+	userID := "user-123"
+	
+	// Create a test document locked by an approval request
+	testDoc := s.createTestDocument(documentID, "test.pdf", "application/pdf", tenantID, "folder-123", models.DocumentStatusAvailable)
+	testDoc.Lock("user-456", time.Now())
+	
+	s.mockDocRepo.On("GetByID", s.ctx, documentID, tenantID).Return(testDoc, nil)
+	s.mockAuthService.On("VerifyResourceAccess", s.ctx, userID, tenantID, services.ResourceTypeDocument, documentID, services.PermissionDelete).Return(true, nil)
+	
+	// Call the use case method
+	err := s.useCase.DeleteDocument(s.ctx, documentID, tenantID, userID)
+	
+	// Assert expectations
+	s.Equal(ErrDocumentLocked, err)
+	s.mockDocRepo.AssertNotCalled(s.T(), "Delete", mock.Anything, mock.Anything, mock.Anything)
+	s.mockStorageService.AssertNotCalled(s.T(), "DeleteDocument", mock.Anything, mock.Anything)
 }
 
 // TestListDocumentsByFolder_Success tests successful listing of documents by folder
@@ -1072,6 +2096,185 @@ func (s *DocumentUseCaseTestSuite) TestGetDocumentStatus_Success() {
 	s.mockAuthService.AssertExpectations(s.T())
 }
 
+// TestVerifyIntegrity_Corrupted tests that stored content not matching its hash is reported as not
+// intact without recording a verification
+func (s *DocumentUseCaseTestSuite) TestVerifyIntegrity_Corrupted() {
+	// Test data
+	documentID := "doc-123"
+	tenantID := "tenant-123"
+	userID := "user-123"
+	expectedHash := "6ae8a75555209fd6c44157c0aed8016e763ff435a19cf186f76863140143ff72"
+	actualHash := "923b805711041e23a99f07e146591c500261d1c289f62a9d39f8581ceb8a10ca"
+
+	// Create a test document whose latest version has a SHA-256 hash
+	testDoc := s.createTestDocument(documentID, "test.pdf", "application/pdf", tenantID, "folder-123", models.DocumentStatusAvailable)
+	version := s.createTestDocumentVersion("version-1", documentID, 1, models.VersionStatusAvailable, "tenant-123/folder-123/doc-123/version-1")
+	version.ContentHash = expectedHash
+	testDoc.AddVersion(version)
+
+	// Mock document retrieval, permission check and the stored content
+	s.mockDocRepo.On("GetByID", s.ctx, documentID, tenantID).Return(testDoc, nil)
+	s.mockAuthService.On("VerifyResourceAccess", s.ctx, userID, tenantID, services.ResourceTypeDocument, documentID, services.PermissionRead).Return(true, nil)
+	s.mockStorageService.On("HashDocument", s.ctx, version.StoragePath).Return(actualHash, nil)
+
+	// Call the use case method
+	check, err := s.useCase.VerifyIntegrity(s.ctx, documentID, tenantID, userID)
+
+	// Assert expectations
+	s.NoError(err)
+	s.False(check.Intact)
+	s.Equal("version-1", check.VersionID)
+	s.Equal(expectedHash, check.ExpectedHash)
+	s.Equal(actualHash, check.ActualHash)
+	s.mockDocRepo.AssertNotCalled(s.T(), "MarkVersionIntegrityVerified", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestVerifyIntegrity_NoContentHash tests that versions without a SHA-256 hash cannot be verified
+func (s *DocumentUseCaseTestSuite) TestVerifyIntegrity_NoContentHash() {
+	// Test data
+	documentID := "doc-123"
+	tenantID := "tenant-123"
+	userID := "user-123"
+
+	// Create a test document whose latest version has no SHA-256 hash
+	testDoc := s.createTestDocument(documentID, "test.pdf", "application/pdf", tenantID, "folder-123", models.DocumentStatusAvailable)
+	testDoc.AddVersion(s.createTestDocumentVersion("version-1", documentID, 1, models.VersionStatusAvailable, "tenant-123/folder-123/doc-123/version-1"))
+
+	// Mock document retrieval and permission check
+	s.mockDocRepo.On("GetByID", s.ctx, documentID, tenantID).Return(testDoc, nil)
+	s.mockAuthService.On("VerifyResourceAccess", s.ctx, userID, tenantID, services.ResourceTypeDocument, documentID, services.PermissionRead).Return(true, nil)
+
+	// Call the use case method
+	_, err := s.useCase.VerifyIntegrity(s.ctx, documentID, tenantID, userID)
+
+	// Assert expectations
+	s.True(apperrors.IsValidationError(err))
+	s.mockStorageService.AssertNotCalled(s.T(), "HashDocument", mock.Anything, mock.Anything)
+}
+
+// TestGetVersionSignature_Success tests that the signature of a version is calculated from its stored content
+func (s *DocumentUseCaseTestSuite) TestGetVersionSignature_Success() {
+	// Test data
+	documentID := "doc-123"
+	tenantID := "tenant-123"
+	userID := "user-123"
+	content := bytes.Repeat([]byte("version content "), 100)
+
+	// Create a test document with an available version
+	testDoc := s.createTestDocument(documentID, "test.pdf", "application/pdf", tenantID, "folder-123", models.DocumentStatusAvailable)
+	version := s.createTestDocumentVersion("ver-1", documentID, 1, models.VersionStatusAvailable, "storage/path/v1")
+	testDoc.AddVersion(version)
+
+	// Mock document retrieval, write permission check and the stored content
+	s.mockDocRepo.On("GetByID", s.ctx, documentID, tenantID).Return(testDoc, nil)
+	s.mockAuthService.On("VerifyResourceAccess", s.ctx, userID, tenantID, services.ResourceTypeDocument, documentID, services.PermissionWrite).Return(true, nil)
+	s.mockStorageService.On("GetDocument", s.ctx, version.StoragePath).Return(io.NopCloser(bytes.NewReader(content)), nil)
+
+	// Call the use case method
+	signature, err := s.useCase.GetVersionSignature(s.ctx, documentID, "ver-1", delta.MinBlockSize, tenantID, userID)
+
+	// Assert expectations
+	s.NoError(err)
+	s.Equal(int64(len(content)), signature.Size)
+	s.Len(signature.Blocks, 4)
+
+	// Test an invalid block size
+	_, err = s.useCase.GetVersionSignature(s.ctx, documentID, "ver-1", 100, tenantID, userID)
+	s.True(apperrors.IsValidationError(err))
+}
+
+// TestUploadVersionDelta_Success tests that a new version is rebuilt from a patch against the base
+// version, verified against its checksum and queued for scanning
+func (s *DocumentUseCaseTestSuite) TestUploadVersionDelta_Success() {
+	// Test data
+	documentID := "doc-123"
+	tenantID := "tenant-123"
+	userID := "user-123"
+	var lines bytes.Buffer
+	for i := 0; lines.Len() < 4*delta.MinBlockSize; i++ {
+		fmt.Fprintf(&lines, "line %d of the base version\n", i)
+	}
+	base := lines.Bytes()[:4*delta.MinBlockSize]
+	target := append(append([]byte(nil), base...), []byte("appended to the new version")...)
+	targetHash, _ := utils.HashBytes(target, utils.HashAlgorithmSHA256)
+
+	// Build the patch against the signature of the base version
+	signature, err := delta.ComputeSignature(bytes.NewReader(base), delta.MinBlockSize)
+	s.Require().NoError(err)
+	var patch bytes.Buffer
+	s.Require().NoError(delta.Diff(signature, bytes.NewReader(target), &patch))
+
+	// Create a test document with an available version
+	testDoc := s.createTestDocument(documentID, "test.pdf", "application/pdf", tenantID, "folder-123", models.DocumentStatusAvailable)
+	baseVersion := s.createTestDocumentVersion("ver-1", documentID, 1, models.VersionStatusAvailable, "storage/path/v1")
+	baseVersion.Size = int64(len(base))
+	testDoc.AddVersion(baseVersion)
+
+	// Mock document retrieval, write permission check, the blocks of the base version, storage and persistence
+	s.mockDocRepo.On("GetByID", s.ctx, documentID, tenantID).Return(testDoc, nil)
+	s.mockAuthService.On("VerifyResourceAccess", s.ctx, userID, tenantID, services.ResourceTypeDocument, documentID, services.PermissionWrite).Return(true, nil)
+	s.mockStorageService.On("GetEncryptionKeyID", mock.Anything, tenantID).Return("key-1", nil)
+	s.mockStorageService.On("GetDocumentRange", mock.Anything, baseVersion.StoragePath, int64(0), int64(len(base))).Return(io.NopCloser(bytes.NewReader(base)), nil)
+	s.mockStorageService.On("StoreTemporary", mock.Anything, tenantID, documentID, mock.Anything, int64(len(target)), "application/pdf").
+		Run(func(args mock.Arguments) { io.Copy(io.Discard, args.Get(3).(io.Reader)) }).Return("temp/location/path", nil)
+	s.mockStorageService.On("HashDocument", mock.Anything, "temp/location/path").Return(targetHash, nil)
+	s.mockDocRepo.On("AddVersion", mock.Anything, mock.MatchedBy(func(version *models.DocumentVersion) bool {
+		return version.VersionNumber == 2 && version.ContentHash == targetHash && version.IntegrityVerifiedAt != nil
+	})).Return("ver-2", nil)
+	s.mockVirusScanService.On("QueueForScanning", mock.Anything, documentID, mock.Anything, tenantID, "temp/location/path", targetHash, "").Return(nil)
+
+	// Call the use case method
+	version, err := s.useCase.UploadVersionDelta(s.ctx, documentID, "ver-1", int64(len(target)), targetHash, &patch, tenantID, userID)
+
+	// Assert expectations
+	s.NoError(err)
+	s.Equal(2, version.VersionNumber)
+	s.Equal(models.VersionStatusProcessing, version.Status)
+	s.mockStorageService.AssertExpectations(s.T())
+	s.mockDocRepo.AssertExpectations(s.T())
+	s.mockVirusScanService.AssertExpectations(s.T())
+}
+
+// TestUploadVersionDelta_InvalidPatch tests that a malformed patch is refused and its partly stored
+// content deleted
+func (s *DocumentUseCaseTestSuite) TestUploadVersionDelta_InvalidPatch() {
+	// Test data
+	documentID := "doc-123"
+	tenantID := "tenant-123"
+	userID := "user-123"
+	checksum := "6ae8a75555209fd6c44157c0aed8016e763ff435a19cf186f76863140143ff72"
+
+	// Create a test document with an available version
+	testDoc := s.createTestDocument(documentID, "test.pdf", "application/pdf", tenantID, "folder-123", models.DocumentStatusAvailable)
+	testDoc.AddVersion(s.createTestDocumentVersion("ver-1", documentID, 1, models.VersionStatusAvailable, "storage/path/v1"))
+
+	// Mock document retrieval, write permission check and storage
+	s.mockDocRepo.On("GetByID", s.ctx, documentID, tenantID).Return(testDoc, nil)
+	s.mockAuthService.On("VerifyResourceAccess", s.ctx, userID, tenantID, services.ResourceTypeDocument, documentID, services.PermissionWrite).Return(true, nil)
+	s.mockStorageService.On("GetEncryptionKeyID", mock.Anything, tenantID).Return("key-1", nil)
+	s.mockStorageService.On("StoreTemporary", mock.Anything, tenantID, documentID, mock.Anything, int64(12), "application/pdf").
+		Run(func(args mock.Arguments) { io.Copy(io.Discard, args.Get(3).(io.Reader)) }).Return("temp/location/path", nil)
+	s.mockStorageService.On("DeleteDocument", mock.Anything, "temp/location/path").Return(nil)
+
+	// Call the use case method with something else than a patch
+	_, err := s.useCase.UploadVersionDelta(s.ctx, documentID, "ver-1", 12, checksum, strings.NewReader("not a patch at all"), tenantID, userID)
+
+	// Assert expectations
+	s.True(apperrors.IsValidationError(err))
+	s.Contains(err.Error(), "delta patch is malformed")
+	s.mockStorageService.AssertExpectations(s.T())
+	s.mockDocRepo.AssertNotCalled(s.T(), "AddVersion", mock.Anything, mock.Anything)
+}
+
+// TestUploadVersionDelta_RequiresChecksum tests that delta uploads without a checksum are refused
+// before the document is read
+func (s *DocumentUseCaseTestSuite) TestUploadVersionDelta_RequiresChecksum() {
+	_, err := s.useCase.UploadVersionDelta(s.ctx, "doc-123", "ver-1", 12, "", strings.NewReader("patch"), "tenant-123", "user-123")
+
+	s.True(apperrors.IsValidationError(err))
+	s.mockDocRepo.AssertNotCalled(s.T(), "GetByID", mock.Anything, mock.Anything, mock.Anything)
+}
+
 // Helper function to create a test document
 func (s *DocumentUseCaseTestSuite) createTestDocument(id, name, contentType, tenantID, folderID, status string) *models.Document {
 	doc := models.NewDocument(name, contentType, 1024, folderID, tenantID, "user-123")
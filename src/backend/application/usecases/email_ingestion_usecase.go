@@ -0,0 +1,395 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"path"
+	"strings"
+
+	"../../domain/models"
+	"../../domain/repositories"
+	"../../domain/services"
+	"../../pkg/errors"
+	"../../pkg/logger"
+)
+
+// EmailInboxFolderName is the name of the root folder that emailed attachments are stored in
+const EmailInboxFolderName = "Email Inbox"
+
+// Metadata keys of documents created from email attachments
+const (
+	EmailMetadataSender    = "email_sender"
+	EmailMetadataSubject   = "email_subject"
+	EmailMetadataMessageID = "email_message_id"
+)
+
+// maxEmailSize bounds the size of a message that is read, which matches the SES limit of 40 MB
+const maxEmailSize = 40 << 20
+
+// maxMIMEDepth bounds the nesting of multipart bodies that is followed
+const maxMIMEDepth = 10
+
+// defaultAttachmentContentType is the content type of attachments that do not declare one
+const defaultAttachmentContentType = "application/octet-stream"
+
+// EmailIngestionUseCase defines the contract for creating documents from emailed attachments
+type EmailIngestionUseCase interface {
+	// IngestPending handles up to batchSize received emails and returns the number handled
+	IngestPending(ctx context.Context, batchSize int) (int, error)
+}
+
+// emailFolderUseCase is the part of the folder use case that email ingestion needs
+type emailFolderUseCase interface {
+	CreateFolder(ctx context.Context, name, parentID, tenantID, userID string) (string, error)
+	GetFolderByPath(ctx context.Context, path, tenantID, userID string) (*models.Folder, error)
+}
+
+// emailAttachment is an attachment extracted from an email
+type emailAttachment struct {
+	name        string
+	contentType string
+	content     []byte
+}
+
+// emailIngestionUseCase implements the EmailIngestionUseCase interface
+type emailIngestionUseCase struct {
+	mailbox         services.InboundMailbox
+	tenantRepo      repositories.TenantRepository
+	userRepo        repositories.UserRepository
+	folderUseCase   emailFolderUseCase
+	documentUseCase DocumentUseCase
+	domain          string
+}
+
+// NewEmailIngestionUseCase creates a new EmailIngestionUseCase. Emails are addressed to
+// <tenant ID>@<domain>; the sender must be a user of that tenant, identified by their email address,
+// and the attachments are uploaded as that user into the tenant's Email Inbox folder.
+func NewEmailIngestionUseCase(
+	mailbox services.InboundMailbox,
+	tenantRepo repositories.TenantRepository,
+	userRepo repositories.UserRepository,
+	folderUseCase emailFolderUseCase,
+	documentUseCase DocumentUseCase,
+	domain string,
+) (EmailIngestionUseCase, error) {
+	if mailbox == nil {
+		return nil, fmt.Errorf("inbound mailbox cannot be nil")
+	}
+	if tenantRepo == nil {
+		return nil, fmt.Errorf("tenant repository cannot be nil")
+	}
+	if userRepo == nil {
+		return nil, fmt.Errorf("user repository cannot be nil")
+	}
+	if folderUseCase == nil {
+		return nil, fmt.Errorf("folder use case cannot be nil")
+	}
+	if documentUseCase == nil {
+		return nil, fmt.Errorf("document use case cannot be nil")
+	}
+	if domain == "" {
+		return nil, fmt.Errorf("inbound email domain cannot be empty")
+	}
+
+	return &emailIngestionUseCase{
+		mailbox:         mailbox,
+		tenantRepo:      tenantRepo,
+		userRepo:        userRepo,
+		folderUseCase:   folderUseCase,
+		documentUseCase: documentUseCase,
+		domain:          strings.ToLower(domain),
+	}, nil
+}
+
+// IngestPending handles the next batch of emails. Emails that cannot be ingested, such as emails
+// from unknown senders, are logged and discarded. Emails that fail on an unavailable dependency are
+// kept and retried by the next run, which can create their earlier attachments a second time.
+func (u *emailIngestionUseCase) IngestPending(ctx context.Context, batchSize int) (int, error) {
+	if batchSize <= 0 {
+		return 0, errors.NewValidationError("batch size must be greater than zero")
+	}
+
+	keys, err := u.mailbox.ListMessages(ctx, batchSize)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to list inbound emails")
+	}
+
+	handled := 0
+	for _, key := range keys {
+		if err := u.ingestMessage(ctx, key); err != nil {
+			if errors.IsDependencyError(err) || errors.GetErrorType(err) == errors.ErrorTypeInternal {
+				logger.ErrorContext(ctx, "Failed to ingest email, retrying later", "key", key, "error", err.Error())
+				continue
+			}
+			logger.WarnContext(ctx, "Discarding email that cannot be ingested", "key", key, "error", err.Error())
+		}
+
+		if err := u.mailbox.DeleteMessage(ctx, key); err != nil {
+			return handled, errors.Wrap(err, "failed to delete inbound email")
+		}
+		handled++
+	}
+
+	return handled, nil
+}
+
+// ingestMessage creates documents from the attachments of a stored email
+func (u *emailIngestionUseCase) ingestMessage(ctx context.Context, key string) error {
+	body, err := u.mailbox.GetMessage(ctx, key)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	message, err := mail.ReadMessage(io.LimitReader(body, maxEmailSize))
+	if err != nil {
+		return errors.NewValidationError("email is not a valid MIME message")
+	}
+	if err := checkEmailVerdicts(message.Header); err != nil {
+		return err
+	}
+
+	sender, err := mail.ParseAddress(message.Header.Get("From"))
+	if err != nil {
+		return errors.NewValidationError("email has no valid sender")
+	}
+	tenantID := u.recipientTenantID(message.Header)
+	if tenantID == "" {
+		return errors.NewValidationError("email is not addressed to a tenant inbox")
+	}
+	user, err := u.resolveSender(ctx, tenantID, sender.Address)
+	if err != nil {
+		return err
+	}
+
+	attachments, err := extractAttachments(message)
+	if err != nil {
+		return err
+	}
+	if len(attachments) == 0 {
+		logger.InfoContext(ctx, "Email has no attachments", "key", key, "tenant_id", tenantID)
+		return nil
+	}
+
+	folderID, err := u.inboxFolderID(ctx, tenantID, user.ID)
+	if err != nil {
+		return err
+	}
+
+	wordDecoder := new(mime.WordDecoder)
+	subject, err := wordDecoder.DecodeHeader(message.Header.Get("Subject"))
+	if err != nil {
+		subject = message.Header.Get("Subject")
+	}
+	metadata := map[string]string{
+		EmailMetadataSender:    sender.Address,
+		EmailMetadataSubject:   subject,
+		EmailMetadataMessageID: strings.Trim(message.Header.Get("Message-Id"), "<>"),
+	}
+
+	for _, attachment := range attachments {
+		documentID, err := u.documentUseCase.UploadDocument(ctx, attachment.name, attachment.contentType, int64(len(attachment.content)),
+			folderID, tenantID, user.ID, bytes.NewReader(attachment.content), metadata, "", "")
+		if err != nil {
+			return errors.Wrap(err, "failed to upload email attachment")
+		}
+		logger.InfoContext(ctx, "Created document from email attachment",
+			"document_id", documentID,
+			"tenant_id", tenantID,
+			"user_id", user.ID,
+			"key", key)
+	}
+
+	return nil
+}
+
+// recipientTenantID returns the tenant ID of the first recipient at the inbound domain
+func (u *emailIngestionUseCase) recipientTenantID(header mail.Header) string {
+	for _, field := range []string{"To", "Cc", "Delivered-To"} {
+		addresses, err := header.AddressList(field)
+		if err != nil {
+			continue
+		}
+		for _, address := range addresses {
+			separator := strings.LastIndex(address.Address, "@")
+			if separator <= 0 {
+				continue
+			}
+			if strings.ToLower(address.Address[separator+1:]) == u.domain {
+				return address.Address[:separator]
+			}
+		}
+	}
+	return ""
+}
+
+// resolveSender returns the active user of the tenant with the sender's email address, who must be
+// allowed to upload documents
+func (u *emailIngestionUseCase) resolveSender(ctx context.Context, tenantID, address string) (*models.User, error) {
+	tenant, err := u.tenantRepo.GetByID(ctx, tenantID)
+	if err != nil {
+		if errors.IsResourceNotFoundError(err) {
+			return nil, errors.NewValidationError("email is addressed to an unknown tenant")
+		}
+		return nil, errors.Wrap(err, "failed to retrieve tenant")
+	}
+	if !tenant.IsActive() {
+		return nil, errors.NewValidationError("email is addressed to an inactive tenant")
+	}
+
+	user, err := u.userRepo.GetByEmail(ctx, address, tenantID)
+	if err != nil {
+		if errors.IsResourceNotFoundError(err) {
+			return nil, errors.NewAuthorizationError("email sender is not a user of the tenant")
+		}
+		return nil, errors.Wrap(err, "failed to retrieve sender")
+	}
+	if !user.IsActive() || !user.CanWrite() {
+		return nil, errors.NewAuthorizationError("email sender is not allowed to upload documents")
+	}
+	return user, nil
+}
+
+// inboxFolderID returns the ID of the tenant's Email Inbox folder, creating it on first use
+func (u *emailIngestionUseCase) inboxFolderID(ctx context.Context, tenantID, userID string) (string, error) {
+	folderPath := models.PathSeparator + EmailInboxFolderName
+	folder, err := u.folderUseCase.GetFolderByPath(ctx, folderPath, tenantID, userID)
+	if err == nil {
+		return folder.ID, nil
+	}
+	if !errors.IsResourceNotFoundError(err) {
+		return "", err
+	}
+
+	folderID, err := u.folderUseCase.CreateFolder(ctx, EmailInboxFolderName, "", tenantID, userID)
+	if err != nil {
+		// Another email may have created the folder in the meantime
+		folder, getErr := u.folderUseCase.GetFolderByPath(ctx, folderPath, tenantID, userID)
+		if getErr != nil {
+			return "", errors.Wrap(err, "failed to create email inbox folder")
+		}
+		return folder.ID, nil
+	}
+	return folderID, nil
+}
+
+// checkEmailVerdicts rejects emails that SES flagged as spam or a virus, or whose sender could not
+// be authenticated, since documents are created on behalf of the sender
+func checkEmailVerdicts(header mail.Header) error {
+	for _, field := range []string{"X-SES-Spam-Verdict", "X-SES-Virus-Verdict"} {
+		if verdict := header.Get(field); verdict != "" && !strings.EqualFold(verdict, "PASS") {
+			return errors.NewSecurityError(fmt.Sprintf("email failed the %s check", field))
+		}
+	}
+
+	results := strings.ToLower(strings.Join(header["Authentication-Results"], ";"))
+	if !strings.Contains(results, "spf=pass") && !strings.Contains(results, "dkim=pass") {
+		return errors.NewSecurityError("email sender could not be authenticated")
+	}
+	return nil
+}
+
+// extractAttachments returns the attachments of an email
+func extractAttachments(message *mail.Message) ([]emailAttachment, error) {
+	var attachments []emailAttachment
+	err := walkMIMEPart(message.Header, message.Body, 0, &attachments)
+	return attachments, err
+}
+
+// walkMIMEPart collects the attachments of a MIME part and its nested parts
+func walkMIMEPart(header mimeHeader, body io.Reader, depth int, attachments *[]emailAttachment) error {
+	if depth > maxMIMEDepth {
+		return errors.NewValidationError("email is nested too deeply")
+	}
+
+	mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		mediaType = "text/plain"
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		reader := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := reader.NextRawPart()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return errors.NewValidationError("email has a malformed multipart body")
+			}
+			if err := walkMIMEPart(part.Header, part, depth+1, attachments); err != nil {
+				return err
+			}
+		}
+	}
+
+	name := attachmentName(header, params)
+	if name == "" {
+		// Message text and inline parts without a file name are not stored
+		return nil
+	}
+
+	content, err := io.ReadAll(decodeTransferEncoding(header.Get("Content-Transfer-Encoding"), body))
+	if err != nil {
+		return errors.NewValidationError("email has a malformed attachment")
+	}
+	if len(content) == 0 {
+		return nil
+	}
+
+	if mediaType == "" {
+		mediaType = defaultAttachmentContentType
+	}
+	*attachments = append(*attachments, emailAttachment{name: name, contentType: mediaType, content: content})
+	return nil
+}
+
+// attachmentName returns the file name of a MIME part, or an empty string if it is not an attachment
+func attachmentName(header mimeHeader, contentTypeParams map[string]string) string {
+	disposition, dispositionParams, err := mime.ParseMediaType(header.Get("Content-Disposition"))
+	name := ""
+	if err == nil {
+		name = dispositionParams["filename"]
+	}
+	if name == "" {
+		name = contentTypeParams["name"]
+	}
+	if name == "" && disposition != "attachment" {
+		return ""
+	}
+
+	wordDecoder := new(mime.WordDecoder)
+	if decoded, err := wordDecoder.DecodeHeader(name); err == nil {
+		name = decoded
+	}
+	// File names are chosen by the sender; only the base name is kept
+	name = path.Base(strings.ReplaceAll(name, "\\", "/"))
+	if name == "." || name == "/" || name == "" {
+		name = "attachment"
+	}
+	return name
+}
+
+// decodeTransferEncoding decodes the body of a MIME part
+func decodeTransferEncoding(encoding string, body io.Reader) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, body)
+	case "quoted-printable":
+		return quotedprintable.NewReader(body)
+	default:
+		return body
+	}
+}
+
+// mimeHeader is the header of a message or of a MIME part
+type mimeHeader interface {
+	Get(key string) string
+}
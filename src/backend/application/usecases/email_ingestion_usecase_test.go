@@ -0,0 +1,231 @@
+package usecases
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert" // v1.8.0+
+	"github.com/stretchr/testify/mock"   // v1.8.0+
+	"github.com/stretchr/testify/suite"  // v1.8.0+
+
+	"../../domain/models"
+	"../../domain/repositories"
+	pkgErrors "../../pkg/errors"
+)
+
+// mockEmailMailbox mocks the InboundMailbox
+type mockEmailMailbox struct {
+	mock.Mock
+}
+
+func (m *mockEmailMailbox) ListMessages(ctx context.Context, limit int) ([]string, error) {
+	args := m.Called(ctx, limit)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *mockEmailMailbox) GetMessage(ctx context.Context, key string) (io.ReadCloser, error) {
+	args := m.Called(ctx, key)
+	if message := args.Get(0); message != nil {
+		return io.NopCloser(strings.NewReader(message.(string))), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *mockEmailMailbox) DeleteMessage(ctx context.Context, key string) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+// mockEmailTenantRepository mocks the TenantRepository methods used by email ingestion
+type mockEmailTenantRepository struct {
+	repositories.TenantRepository
+	mock.Mock
+}
+
+func (m *mockEmailTenantRepository) GetByID(ctx context.Context, id string) (*models.Tenant, error) {
+	args := m.Called(ctx, id)
+	if tenant := args.Get(0); tenant != nil {
+		return tenant.(*models.Tenant), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// mockEmailUserRepository mocks the UserRepository methods used by email ingestion
+type mockEmailUserRepository struct {
+	repositories.UserRepository
+	mock.Mock
+}
+
+func (m *mockEmailUserRepository) GetByEmail(ctx context.Context, email string, tenantID string) (*models.User, error) {
+	args := m.Called(ctx, email, tenantID)
+	if user := args.Get(0); user != nil {
+		return user.(*models.User), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// mockEmailFolderUseCase mocks the folder use case methods used by email ingestion
+type mockEmailFolderUseCase struct {
+	mock.Mock
+}
+
+func (m *mockEmailFolderUseCase) CreateFolder(ctx context.Context, name, parentID, tenantID, userID string) (string, error) {
+	args := m.Called(ctx, name, parentID, tenantID, userID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *mockEmailFolderUseCase) GetFolderByPath(ctx context.Context, path, tenantID, userID string) (*models.Folder, error) {
+	args := m.Called(ctx, path, tenantID, userID)
+	if folder := args.Get(0); folder != nil {
+		return folder.(*models.Folder), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// mockEmailDocumentUseCase mocks the DocumentUseCase methods used by email ingestion
+type mockEmailDocumentUseCase struct {
+	DocumentUseCase
+	mock.Mock
+}
+
+func (m *mockEmailDocumentUseCase) UploadDocument(ctx context.Context, name string, contentType string, size int64, folderID string, tenantID string, userID string, content io.Reader, metadata map[string]string, priority string, checksum string) (string, error) {
+	data, _ := io.ReadAll(content)
+	args := m.Called(ctx, name, contentType, size, folderID, tenantID, userID, string(data), metadata, priority, checksum)
+	return args.String(0), args.Error(1)
+}
+
+// testEmail is an authenticated email to tenant123 with a PDF attachment and a text body
+const testEmail = "From: Alice <alice@example.com>\r\n" +
+	"To: tenant123@inbox.example.com\r\n" +
+	"Subject: =?UTF-8?Q?Invoice_f=C3=BCr_March?=\r\n" +
+	"Message-ID: <msg-1@example.com>\r\n" +
+	"Authentication-Results: amazonses.com; spf=pass smtp.mailfrom=example.com; dkim=pass header.i=@example.com\r\n" +
+	"X-SES-Spam-Verdict: PASS\r\n" +
+	"X-SES-Virus-Verdict: PASS\r\n" +
+	"MIME-Version: 1.0\r\n" +
+	"Content-Type: multipart/mixed; boundary=\"b1\"\r\n" +
+	"\r\n" +
+	"--b1\r\n" +
+	"Content-Type: text/plain; charset=utf-8\r\n" +
+	"\r\n" +
+	"Please find the invoice attached.\r\n" +
+	"--b1\r\n" +
+	"Content-Type: application/pdf; name=\"invoice.pdf\"\r\n" +
+	"Content-Disposition: attachment; filename=\"invoice.pdf\"\r\n" +
+	"Content-Transfer-Encoding: base64\r\n" +
+	"\r\n" +
+	"JVBERi0x\r\nLjQK\r\n" +
+	"--b1--\r\n"
+
+// EmailIngestionUseCaseTestSuite defines a test suite for EmailIngestionUseCase
+type EmailIngestionUseCaseTestSuite struct {
+	suite.Suite
+	mockMailbox         *mockEmailMailbox
+	mockTenantRepo      *mockEmailTenantRepository
+	mockUserRepo        *mockEmailUserRepository
+	mockFolderUseCase   *mockEmailFolderUseCase
+	mockDocumentUseCase *mockEmailDocumentUseCase
+	emailIngestion      EmailIngestionUseCase
+}
+
+// SetupTest sets up the test environment before each test
+func (s *EmailIngestionUseCaseTestSuite) SetupTest() {
+	s.mockMailbox = new(mockEmailMailbox)
+	s.mockTenantRepo = new(mockEmailTenantRepository)
+	s.mockUserRepo = new(mockEmailUserRepository)
+	s.mockFolderUseCase = new(mockEmailFolderUseCase)
+	s.mockDocumentUseCase = new(mockEmailDocumentUseCase)
+
+	var err error
+	s.emailIngestion, err = NewEmailIngestionUseCase(s.mockMailbox, s.mockTenantRepo, s.mockUserRepo, s.mockFolderUseCase, s.mockDocumentUseCase, "Inbox.example.com")
+	assert.Nil(s.T(), err)
+}
+
+// expectSender sets up tenant123 with alice as a contributor
+func (s *EmailIngestionUseCaseTestSuite) expectSender(ctx context.Context) {
+	s.mockTenantRepo.On("GetByID", ctx, "tenant123").Return(&models.Tenant{ID: "tenant123", Status: models.TenantStatusActive}, nil)
+	s.mockUserRepo.On("GetByEmail", ctx, "alice@example.com", "tenant123").Return(&models.User{
+		ID: "user123", TenantID: "tenant123", Status: models.UserStatusActive, Roles: []string{models.RoleContributor},
+	}, nil)
+}
+
+// TestIngestPending_CreatesDocumentsInInbox tests that attachments are uploaded into a new Email Inbox folder
+func (s *EmailIngestionUseCaseTestSuite) TestIngestPending_CreatesDocumentsInInbox() {
+	ctx := context.Background()
+	s.mockMailbox.On("ListMessages", ctx, 10).Return([]string{"msg-1"}, nil)
+	s.mockMailbox.On("GetMessage", ctx, "msg-1").Return(testEmail, nil)
+	s.mockMailbox.On("DeleteMessage", ctx, "msg-1").Return(nil)
+	s.expectSender(ctx)
+	s.mockFolderUseCase.On("GetFolderByPath", ctx, "/Email Inbox", "tenant123", "user123").Return(nil, pkgErrors.NewResourceNotFoundError("folder not found"))
+	s.mockFolderUseCase.On("CreateFolder", ctx, EmailInboxFolderName, "", "tenant123", "user123").Return("folder123", nil)
+	s.mockDocumentUseCase.On("UploadDocument", ctx, "invoice.pdf", "application/pdf", int64(9), "folder123", "tenant123", "user123", "%PDF-1.4\n", map[string]string{
+		EmailMetadataSender:    "alice@example.com",
+		EmailMetadataSubject:   "Invoice für March",
+		EmailMetadataMessageID: "msg-1@example.com",
+	}, "", "").Return("doc123", nil)
+
+	count, err := s.emailIngestion.IngestPending(ctx, 10)
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), 1, count)
+	s.mockDocumentUseCase.AssertExpectations(s.T())
+	s.mockMailbox.AssertExpectations(s.T())
+}
+
+// TestIngestPending_DiscardsUnknownSender tests that emails from non-users are deleted without creating documents
+func (s *EmailIngestionUseCaseTestSuite) TestIngestPending_DiscardsUnknownSender() {
+	ctx := context.Background()
+	s.mockMailbox.On("ListMessages", ctx, 10).Return([]string{"msg-1"}, nil)
+	s.mockMailbox.On("GetMessage", ctx, "msg-1").Return(testEmail, nil)
+	s.mockMailbox.On("DeleteMessage", ctx, "msg-1").Return(nil)
+	s.mockTenantRepo.On("GetByID", ctx, "tenant123").Return(&models.Tenant{ID: "tenant123", Status: models.TenantStatusActive}, nil)
+	s.mockUserRepo.On("GetByEmail", ctx, "alice@example.com", "tenant123").Return(nil, pkgErrors.NewResourceNotFoundError("user not found"))
+
+	count, err := s.emailIngestion.IngestPending(ctx, 10)
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), 1, count)
+	s.mockDocumentUseCase.AssertNotCalled(s.T(), "UploadDocument", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	s.mockMailbox.AssertExpectations(s.T())
+}
+
+// TestIngestPending_DiscardsUnauthenticatedSender tests that emails failing SPF and DKIM are not ingested
+func (s *EmailIngestionUseCaseTestSuite) TestIngestPending_DiscardsUnauthenticatedSender() {
+	ctx := context.Background()
+	spoofed := strings.Replace(testEmail, "spf=pass", "spf=fail", 1)
+	spoofed = strings.Replace(spoofed, "dkim=pass", "dkim=fail", 1)
+	s.mockMailbox.On("ListMessages", ctx, 10).Return([]string{"msg-1"}, nil)
+	s.mockMailbox.On("GetMessage", ctx, "msg-1").Return(spoofed, nil)
+	s.mockMailbox.On("DeleteMessage", ctx, "msg-1").Return(nil)
+
+	count, err := s.emailIngestion.IngestPending(ctx, 10)
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), 1, count)
+	s.mockUserRepo.AssertNotCalled(s.T(), "GetByEmail", mock.Anything, mock.Anything, mock.Anything)
+	s.mockMailbox.AssertExpectations(s.T())
+}
+
+// TestIngestPending_KeepsEmailOnDependencyError tests that emails are retried when a dependency is unavailable
+func (s *EmailIngestionUseCaseTestSuite) TestIngestPending_KeepsEmailOnDependencyError() {
+	ctx := context.Background()
+	s.mockMailbox.On("ListMessages", ctx, 10).Return([]string{"msg-1"}, nil)
+	s.mockMailbox.On("GetMessage", ctx, "msg-1").Return(testEmail, nil)
+	s.expectSender(ctx)
+	s.mockFolderUseCase.On("GetFolderByPath", ctx, "/Email Inbox", "tenant123", "user123").Return(&models.Folder{ID: "folder123"}, nil)
+	s.mockDocumentUseCase.On("UploadDocument", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return("", pkgErrors.NewDependencyError("storage unavailable"))
+
+	count, err := s.emailIngestion.IngestPending(ctx, 10)
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), 0, count)
+	s.mockMailbox.AssertNotCalled(s.T(), "DeleteMessage", mock.Anything, mock.Anything)
+}
+
+// TestEmailIngestionUseCaseSuite runs the EmailIngestionUseCase test suite
+func TestEmailIngestionUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(EmailIngestionUseCaseTestSuite))
+}
@@ -0,0 +1,171 @@
+// Package usecases implements the application layer of the Document Management Platform.
+// It contains use case implementations that orchestrate domain models and services.
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"../../domain/models"
+	"../../domain/repositories"
+	"../../domain/services"
+	"../../pkg/errors"
+	"../../pkg/logger"
+)
+
+// ErrExportJobNotFound is returned for unknown export jobs and for jobs requested by another user alike
+var ErrExportJobNotFound = errors.NewResourceNotFoundError("export job not found")
+
+// ExportUseCase defines the contract for background folder exports
+type ExportUseCase interface {
+	// ExportFolder schedules an export of the folder tree into a ZIP archive, which the worker builds
+	ExportFolder(ctx context.Context, folderID, tenantID, userID string) (*models.ExportJob, error)
+
+	// GetExportJob retrieves an export job of the user. Once the job has completed, it also returns a
+	// presigned URL to download the archive.
+	GetExportJob(ctx context.Context, id, tenantID, userID string) (*models.ExportJob, string, error)
+}
+
+// exportUseCase implements the ExportUseCase interface
+type exportUseCase struct {
+	exportJobRepo  repositories.ExportJobRepository
+	folderRepo     repositories.FolderRepository
+	storageService services.StorageService
+	authService    services.AuthService
+	auditService   services.AuditService
+}
+
+// NewExportUseCase creates a new ExportUseCase instance
+func NewExportUseCase(
+	exportJobRepo repositories.ExportJobRepository,
+	folderRepo repositories.FolderRepository,
+	storageService services.StorageService,
+	authService services.AuthService,
+	auditService services.AuditService,
+) (ExportUseCase, error) {
+	if exportJobRepo == nil {
+		return nil, fmt.Errorf("export job repository cannot be nil")
+	}
+	if folderRepo == nil {
+		return nil, fmt.Errorf("folder repository cannot be nil")
+	}
+	if storageService == nil {
+		return nil, fmt.Errorf("storage service cannot be nil")
+	}
+	if authService == nil {
+		return nil, fmt.Errorf("auth service cannot be nil")
+	}
+	if auditService == nil {
+		return nil, fmt.Errorf("audit service cannot be nil")
+	}
+
+	return &exportUseCase{
+		exportJobRepo:  exportJobRepo,
+		folderRepo:     folderRepo,
+		storageService: storageService,
+		authService:    authService,
+		auditService:   auditService,
+	}, nil
+}
+
+// ExportFolder schedules an export of a folder the user can read
+func (u *exportUseCase) ExportFolder(ctx context.Context, folderID, tenantID, userID string) (*models.ExportJob, error) {
+	log := logger.WithContext(ctx)
+
+	if err := u.validateInput(map[string]string{
+		"folder ID": folderID,
+		"tenant ID": tenantID,
+		"user ID":   userID,
+	}); err != nil {
+		return nil, err
+	}
+
+	if _, err := u.folderRepo.GetByID(ctx, folderID, tenantID); err != nil {
+		log.WithError(err).Error("failed to get folder", "folderID", folderID, "tenantID", tenantID)
+		return nil, errors.Wrap(err, "failed to get folder")
+	}
+
+	hasAccess, err := u.authService.VerifyResourceAccess(ctx, userID, tenantID, services.ResourceTypeFolder, folderID, services.PermissionRead)
+	if err != nil {
+		log.WithError(err).Error("failed to verify folder access", "folderID", folderID, "userID", userID)
+		return nil, errors.Wrap(err, "failed to verify folder access")
+	}
+	if !hasAccess {
+		log.Error("user does not have read permission for folder", "folderID", folderID, "userID", userID)
+		return nil, ErrPermissionDenied
+	}
+
+	job := models.NewExportJob(tenantID, folderID, userID)
+	if _, err := u.exportJobRepo.Create(ctx, job); err != nil {
+		log.WithError(err).Error("failed to create export job", "folderID", folderID, "tenantID", tenantID)
+		return nil, errors.Wrap(err, "failed to create export job")
+	}
+
+	err = u.auditService.RecordAction(ctx, tenantID, userID, models.AuditActionCreate, models.AuditResourceExportJob, job.ID, nil, map[string]interface{}{
+		"folder_id": folderID,
+	})
+	if err != nil {
+		log.WithError(err).Error("failed to record export job creation in audit log")
+		// Do not return error, the export has already been scheduled
+	}
+
+	log.Info("folder export scheduled", "exportID", job.ID, "folderID", folderID, "tenantID", tenantID)
+	return job, nil
+}
+
+// GetExportJob retrieves an export job requested by the user
+func (u *exportUseCase) GetExportJob(ctx context.Context, id, tenantID, userID string) (*models.ExportJob, string, error) {
+	log := logger.WithContext(ctx)
+
+	if err := u.validateInput(map[string]string{
+		"export ID": id,
+		"tenant ID": tenantID,
+		"user ID":   userID,
+	}); err != nil {
+		return nil, "", err
+	}
+
+	job, err := u.exportJobRepo.GetByID(ctx, id, tenantID)
+	if err != nil {
+		if errors.IsResourceNotFoundError(err) {
+			return nil, "", ErrExportJobNotFound
+		}
+		log.WithError(err).Error("failed to get export job", "exportID", id, "tenantID", tenantID)
+		return nil, "", errors.Wrap(err, "failed to get export job")
+	}
+
+	// The archive contains everything the requesting user could read, so only they may see it
+	if job.UserID != userID {
+		return nil, "", ErrExportJobNotFound
+	}
+
+	if job.Status != models.ExportJobStatusCompleted {
+		return job, "", nil
+	}
+
+	downloadURL, err := u.storageService.GetPresignedURL(ctx, job.ArchivePath, job.ArchiveFileName(), services.ExportDownloadURLExpirySeconds)
+	if err != nil {
+		log.WithError(err).Error("failed to generate export download URL", "exportID", id)
+		return nil, "", errors.Wrap(err, "failed to generate export download URL")
+	}
+
+	err = u.auditService.RecordAction(ctx, tenantID, userID, models.AuditActionDownload, models.AuditResourceExportJob, job.ID, nil, map[string]interface{}{
+		"folder_id": job.FolderID,
+	})
+	if err != nil {
+		log.WithError(err).Error("failed to record export download in audit log")
+		// Do not return error, the download URL has already been generated
+	}
+
+	return job, downloadURL, nil
+}
+
+// validateInput validates that required input parameters are not empty
+func (u *exportUseCase) validateInput(params map[string]string) error {
+	for name, value := range params {
+		if value == "" {
+			return errors.NewValidationError(fmt.Sprintf("%s cannot be empty", name))
+		}
+	}
+	return nil
+}
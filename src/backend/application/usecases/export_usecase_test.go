@@ -0,0 +1,191 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert" // v1.8.0+
+	"github.com/stretchr/testify/mock"   // v1.8.0+
+	"github.com/stretchr/testify/suite"  // v1.8.0+
+
+	"../../domain/models"
+	"../../domain/repositories"
+	"../../domain/services"
+	pkgErrors "../../pkg/errors"
+)
+
+// MockExportJobRepository is a mock implementation of the ExportJobRepository interface for testing
+type MockExportJobRepository struct {
+	mock.Mock
+}
+
+func (m *MockExportJobRepository) Create(ctx context.Context, job *models.ExportJob) (string, error) {
+	args := m.Called(ctx, job)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockExportJobRepository) GetByID(ctx context.Context, id string, tenantID string) (*models.ExportJob, error) {
+	args := m.Called(ctx, id, tenantID)
+	if job := args.Get(0); job != nil {
+		return job.(*models.ExportJob), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockExportJobRepository) ClaimNext(ctx context.Context, staleBefore time.Time) (*models.ExportJob, error) {
+	args := m.Called(ctx, staleBefore)
+	if job := args.Get(0); job != nil {
+		return job.(*models.ExportJob), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockExportJobRepository) Update(ctx context.Context, job *models.ExportJob) error {
+	args := m.Called(ctx, job)
+	return args.Error(0)
+}
+
+// mockExportFolderRepository mocks the FolderRepository methods used by exports
+type mockExportFolderRepository struct {
+	repositories.FolderRepository
+	mock.Mock
+}
+
+func (m *mockExportFolderRepository) GetByID(ctx context.Context, id string, tenantID string) (*models.Folder, error) {
+	args := m.Called(ctx, id, tenantID)
+	if folder := args.Get(0); folder != nil {
+		return folder.(*models.Folder), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// mockExportStorageService mocks the StorageService methods used by exports
+type mockExportStorageService struct {
+	services.StorageService
+	mock.Mock
+}
+
+func (m *mockExportStorageService) GetPresignedURL(ctx context.Context, storagePath string, fileName string, expirationSeconds int) (string, error) {
+	args := m.Called(ctx, storagePath, fileName, expirationSeconds)
+	return args.String(0), args.Error(1)
+}
+
+// mockExportAuthService mocks the AuthService methods used by exports
+type mockExportAuthService struct {
+	services.AuthService
+	mock.Mock
+}
+
+func (m *mockExportAuthService) VerifyResourceAccess(ctx context.Context, userID, tenantID, resourceType, resourceID, accessType string) (bool, error) {
+	args := m.Called(ctx, userID, tenantID, resourceType, resourceID, accessType)
+	return args.Bool(0), args.Error(1)
+}
+
+// ExportUseCaseTestSuite defines a test suite for ExportUseCase
+type ExportUseCaseTestSuite struct {
+	suite.Suite
+	mockExportJobRepo  *MockExportJobRepository
+	mockFolderRepo     *mockExportFolderRepository
+	mockStorageService *mockExportStorageService
+	mockAuthService    *mockExportAuthService
+	mockAuditService   *MockAuditService
+	exportUseCase      ExportUseCase
+}
+
+// SetupTest sets up the test environment before each test
+func (s *ExportUseCaseTestSuite) SetupTest() {
+	s.mockExportJobRepo = new(MockExportJobRepository)
+	s.mockFolderRepo = new(mockExportFolderRepository)
+	s.mockStorageService = new(mockExportStorageService)
+	s.mockAuthService = new(mockExportAuthService)
+	s.mockAuditService = new(MockAuditService)
+	s.mockAuditService.On("RecordAction", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	var err error
+	s.exportUseCase, err = NewExportUseCase(s.mockExportJobRepo, s.mockFolderRepo, s.mockStorageService, s.mockAuthService, s.mockAuditService)
+	assert.Nil(s.T(), err)
+}
+
+// createTestJob returns an export job of user123 with the given status
+func (s *ExportUseCaseTestSuite) createTestJob(status string) *models.ExportJob {
+	job := models.NewExportJob("tenant123", "folder123", "user123")
+	job.ID = "export123"
+	job.Status = status
+	return job
+}
+
+// TestExportFolder_CreatesPendingJob tests that exporting a readable folder schedules a pending job
+func (s *ExportUseCaseTestSuite) TestExportFolder_CreatesPendingJob() {
+	ctx := context.Background()
+	s.mockFolderRepo.On("GetByID", ctx, "folder123", "tenant123").Return(&models.Folder{ID: "folder123", TenantID: "tenant123"}, nil)
+	s.mockAuthService.On("VerifyResourceAccess", ctx, "user123", "tenant123", services.ResourceTypeFolder, "folder123", services.PermissionRead).Return(true, nil)
+	s.mockExportJobRepo.On("Create", ctx, mock.MatchedBy(func(job *models.ExportJob) bool {
+		return job.FolderID == "folder123" && job.UserID == "user123" && job.Status == models.ExportJobStatusPending
+	})).Run(func(args mock.Arguments) {
+		args.Get(1).(*models.ExportJob).ID = "export123"
+	}).Return("export123", nil)
+
+	job, err := s.exportUseCase.ExportFolder(ctx, "folder123", "tenant123", "user123")
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "export123", job.ID)
+	s.mockExportJobRepo.AssertExpectations(s.T())
+}
+
+// TestExportFolder_PermissionDenied tests that a folder the user cannot read is not exported
+func (s *ExportUseCaseTestSuite) TestExportFolder_PermissionDenied() {
+	ctx := context.Background()
+	s.mockFolderRepo.On("GetByID", ctx, "folder123", "tenant123").Return(&models.Folder{ID: "folder123", TenantID: "tenant123"}, nil)
+	s.mockAuthService.On("VerifyResourceAccess", ctx, "user123", "tenant123", services.ResourceTypeFolder, "folder123", services.PermissionRead).Return(false, nil)
+
+	job, err := s.exportUseCase.ExportFolder(ctx, "folder123", "tenant123", "user123")
+
+	assert.Nil(s.T(), job)
+	assert.True(s.T(), pkgErrors.IsAuthorizationError(err))
+	s.mockExportJobRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+}
+
+// TestGetExportJob_CompletedReturnsDownloadURL tests that a completed export comes with a presigned URL
+func (s *ExportUseCaseTestSuite) TestGetExportJob_CompletedReturnsDownloadURL() {
+	ctx := context.Background()
+	job := s.createTestJob(models.ExportJobStatusCompleted)
+	job.ArchivePath = "temp/exports/tenant123/export123.zip"
+	s.mockExportJobRepo.On("GetByID", ctx, "export123", "tenant123").Return(job, nil)
+	s.mockStorageService.On("GetPresignedURL", ctx, job.ArchivePath, "export-export123.zip", services.ExportDownloadURLExpirySeconds).Return("https://s3/export123.zip", nil)
+
+	result, downloadURL, err := s.exportUseCase.GetExportJob(ctx, "export123", "tenant123", "user123")
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), job, result)
+	assert.Equal(s.T(), "https://s3/export123.zip", downloadURL)
+}
+
+// TestGetExportJob_RunningHasNoDownloadURL tests that no URL is issued before the archive is complete
+func (s *ExportUseCaseTestSuite) TestGetExportJob_RunningHasNoDownloadURL() {
+	ctx := context.Background()
+	s.mockExportJobRepo.On("GetByID", ctx, "export123", "tenant123").Return(s.createTestJob(models.ExportJobStatusRunning), nil)
+
+	result, downloadURL, err := s.exportUseCase.GetExportJob(ctx, "export123", "tenant123", "user123")
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), models.ExportJobStatusRunning, result.Status)
+	assert.Empty(s.T(), downloadURL)
+	s.mockStorageService.AssertNotCalled(s.T(), "GetPresignedURL", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestGetExportJob_OtherUserNotFound tests that users cannot see exports requested by someone else
+func (s *ExportUseCaseTestSuite) TestGetExportJob_OtherUserNotFound() {
+	ctx := context.Background()
+	s.mockExportJobRepo.On("GetByID", ctx, "export123", "tenant123").Return(s.createTestJob(models.ExportJobStatusCompleted), nil)
+
+	result, _, err := s.exportUseCase.GetExportJob(ctx, "export123", "tenant123", "user456")
+
+	assert.Nil(s.T(), result)
+	assert.True(s.T(), pkgErrors.IsResourceNotFoundError(err))
+}
+
+// TestExportUseCaseSuite runs the ExportUseCase test suite
+func TestExportUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(ExportUseCaseTestSuite))
+}
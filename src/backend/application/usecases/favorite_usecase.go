@@ -0,0 +1,246 @@
+// Package usecases implements the application layer of the Document Management Platform.
+// It contains use case implementations that orchestrate domain models and services.
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"../../domain/models"
+	"../../domain/repositories"
+	"../../pkg/errors"
+	"../../pkg/logger"
+	"../../pkg/utils"
+)
+
+// Recent documents limits
+const (
+	// DefaultRecentDocuments is the number of recent documents listed when no limit is given
+	DefaultRecentDocuments = 20
+	// MaxRecentDocuments caps the number of recent documents listed
+	MaxRecentDocuments = 50
+	// recentDocumentsWindow is how far back the audit log is read for recent documents
+	recentDocumentsWindow = 90 * 24 * time.Hour
+)
+
+// recentDocumentActions are the audited actions that count as accessing a document
+var recentDocumentActions = []string{models.AuditActionDownload, models.AuditActionUpload, models.AuditActionUpdate}
+
+// DocumentReader retrieves a document a user may read. It is implemented by DocumentUseCase.
+type DocumentReader interface {
+	GetDocument(ctx context.Context, id string, tenantID string, userID string) (*models.Document, error)
+}
+
+// FolderReader retrieves a folder a user may read. It is implemented by FolderUseCase.
+type FolderReader interface {
+	GetFolder(ctx context.Context, id, tenantID, userID string) (*models.Folder, error)
+}
+
+// StarredItem is a document or folder a user starred; exactly one of Document and Folder is set
+type StarredItem struct {
+	Favorite models.Favorite
+	Document *models.Document
+	Folder   *models.Folder
+}
+
+// RecentDocument is a document a user recently downloaded, uploaded or edited
+type RecentDocument struct {
+	Document   *models.Document
+	Action     string
+	AccessedAt time.Time
+}
+
+// FavoriteUseCase defines the contract for the Starred and Recents views of users
+type FavoriteUseCase interface {
+	// Star stars a document or folder the user can read. Starring it again is a no-op.
+	Star(ctx context.Context, resourceType, resourceID, tenantID, userID string) error
+
+	// Unstar unstars a document or folder
+	Unstar(ctx context.Context, resourceType, resourceID, tenantID, userID string) error
+
+	// ListStarred lists the documents and folders a user starred, most recently starred first.
+	// An empty resourceType lists both; resources the user can no longer read are left out.
+	ListStarred(ctx context.Context, resourceType, tenantID, userID string, page int, pageSize int) (utils.PaginatedResult[StarredItem], error)
+
+	// ListRecent lists the documents a user most recently downloaded, uploaded or edited, according
+	// to the audit log; documents the user can no longer read are left out
+	ListRecent(ctx context.Context, tenantID, userID string, limit int) ([]RecentDocument, error)
+}
+
+// favoriteUseCase implements the FavoriteUseCase interface
+type favoriteUseCase struct {
+	favoriteRepo repositories.FavoriteRepository
+	auditLogRepo repositories.AuditLogRepository
+	documents    DocumentReader
+	folders      FolderReader
+}
+
+// NewFavoriteUseCase creates a new FavoriteUseCase instance
+func NewFavoriteUseCase(favoriteRepo repositories.FavoriteRepository, auditLogRepo repositories.AuditLogRepository, documents DocumentReader, folders FolderReader) (FavoriteUseCase, error) {
+	if favoriteRepo == nil {
+		return nil, fmt.Errorf("favorite repository cannot be nil")
+	}
+	if auditLogRepo == nil {
+		return nil, fmt.Errorf("audit log repository cannot be nil")
+	}
+	if documents == nil {
+		return nil, fmt.Errorf("document reader cannot be nil")
+	}
+	if folders == nil {
+		return nil, fmt.Errorf("folder reader cannot be nil")
+	}
+
+	return &favoriteUseCase{
+		favoriteRepo: favoriteRepo,
+		auditLogRepo: auditLogRepo,
+		documents:    documents,
+		folders:      folders,
+	}, nil
+}
+
+// Star stars a document or folder the user can read
+func (u *favoriteUseCase) Star(ctx context.Context, resourceType, resourceID, tenantID, userID string) error {
+	log := logger.WithContext(ctx)
+
+	favorite := models.NewFavorite(tenantID, userID, resourceType, resourceID)
+	if err := favorite.Validate(); err != nil {
+		return errors.NewValidationError(err.Error())
+	}
+
+	// Only resources the user can read can be starred
+	if _, _, err := u.getResource(ctx, resourceType, resourceID, tenantID, userID); err != nil {
+		return errors.Wrap(err, "failed to star "+resourceType)
+	}
+
+	if err := u.favoriteRepo.Add(ctx, favorite); err != nil {
+		log.WithError(err).Error("failed to add favorite", "resourceType", resourceType, "resourceID", resourceID, "tenantID", tenantID)
+		return errors.Wrap(err, "failed to star "+resourceType)
+	}
+
+	log.Info("resource starred", "resourceType", resourceType, "resourceID", resourceID, "tenantID", tenantID, "userID", userID)
+	return nil
+}
+
+// Unstar unstars a document or folder
+func (u *favoriteUseCase) Unstar(ctx context.Context, resourceType, resourceID, tenantID, userID string) error {
+	log := logger.WithContext(ctx)
+
+	favorite := models.NewFavorite(tenantID, userID, resourceType, resourceID)
+	if err := favorite.Validate(); err != nil {
+		return errors.NewValidationError(err.Error())
+	}
+
+	if err := u.favoriteRepo.Remove(ctx, tenantID, userID, resourceType, resourceID); err != nil {
+		if !errors.IsResourceNotFoundError(err) {
+			log.WithError(err).Error("failed to remove favorite", "resourceType", resourceType, "resourceID", resourceID, "tenantID", tenantID)
+		}
+		return errors.Wrap(err, "failed to unstar "+resourceType)
+	}
+
+	log.Info("resource unstarred", "resourceType", resourceType, "resourceID", resourceID, "tenantID", tenantID, "userID", userID)
+	return nil
+}
+
+// ListStarred lists the documents and folders a user starred, most recently starred first
+func (u *favoriteUseCase) ListStarred(ctx context.Context, resourceType, tenantID, userID string, page int, pageSize int) (utils.PaginatedResult[StarredItem], error) {
+	log := logger.WithContext(ctx)
+
+	if err := u.validateInput(map[string]string{
+		"tenant ID": tenantID,
+		"user ID":   userID,
+	}); err != nil {
+		return utils.PaginatedResult[StarredItem]{}, err
+	}
+	if resourceType != "" && resourceType != models.ResourceTypeDocument && resourceType != models.ResourceTypeFolder {
+		return utils.PaginatedResult[StarredItem]{}, errors.NewValidationError(models.ErrFavoriteInvalidResourceType.Error())
+	}
+
+	pagination := utils.NewPagination(page, pageSize)
+	favorites, err := u.favoriteRepo.List(ctx, tenantID, userID, resourceType, pagination)
+	if err != nil {
+		log.WithError(err).Error("failed to list favorites", "tenantID", tenantID, "userID", userID)
+		return utils.PaginatedResult[StarredItem]{}, errors.Wrap(err, "failed to list starred items")
+	}
+
+	items := make([]StarredItem, 0, len(favorites.Items))
+	removed := int64(0)
+	for _, favorite := range favorites.Items {
+		document, folder, err := u.getResource(ctx, favorite.ResourceType, favorite.ResourceID, tenantID, userID)
+		switch {
+		case err == nil:
+			items = append(items, StarredItem{Favorite: favorite, Document: document, Folder: folder})
+		case errors.IsResourceNotFoundError(err):
+			// The resource was deleted; drop its favorite so it stops counting towards the total
+			if err := u.favoriteRepo.Remove(ctx, tenantID, userID, favorite.ResourceType, favorite.ResourceID); err != nil && !errors.IsResourceNotFoundError(err) {
+				log.WithError(err).Warn("failed to remove favorite of deleted resource", "resourceType", favorite.ResourceType, "resourceID", favorite.ResourceID)
+				continue
+			}
+			removed++
+		case errors.IsAuthorizationError(err):
+			// Access may be granted again, so the favorite is kept but not listed
+		default:
+			return utils.PaginatedResult[StarredItem]{}, errors.Wrap(err, "failed to list starred items")
+		}
+	}
+
+	return utils.NewPaginatedResult(items, pagination, favorites.Pagination.TotalItems-removed), nil
+}
+
+// ListRecent lists the documents a user most recently downloaded, uploaded or edited
+func (u *favoriteUseCase) ListRecent(ctx context.Context, tenantID, userID string, limit int) ([]RecentDocument, error) {
+	log := logger.WithContext(ctx)
+
+	if err := u.validateInput(map[string]string{
+		"tenant ID": tenantID,
+		"user ID":   userID,
+	}); err != nil {
+		return nil, err
+	}
+	if limit < 1 {
+		limit = DefaultRecentDocuments
+	} else if limit > MaxRecentDocuments {
+		limit = MaxRecentDocuments
+	}
+
+	since := time.Now().UTC().Add(-recentDocumentsWindow)
+	resources, err := u.auditLogRepo.ListRecentResources(ctx, tenantID, userID, models.ResourceTypeDocument, recentDocumentActions, since, limit)
+	if err != nil {
+		log.WithError(err).Error("failed to list recent documents", "tenantID", tenantID, "userID", userID)
+		return nil, errors.Wrap(err, "failed to list recent documents")
+	}
+
+	recent := make([]RecentDocument, 0, len(resources))
+	for _, resource := range resources {
+		document, err := u.documents.GetDocument(ctx, resource.ResourceID, tenantID, userID)
+		if err != nil {
+			if errors.IsResourceNotFoundError(err) || errors.IsAuthorizationError(err) {
+				continue
+			}
+			return nil, errors.Wrap(err, "failed to list recent documents")
+		}
+		recent = append(recent, RecentDocument{Document: document, Action: resource.Action, AccessedAt: resource.AccessedAt})
+	}
+
+	return recent, nil
+}
+
+// getResource retrieves the starred document or folder, checking the user can read it
+func (u *favoriteUseCase) getResource(ctx context.Context, resourceType, resourceID, tenantID, userID string) (*models.Document, *models.Folder, error) {
+	if resourceType == models.ResourceTypeFolder {
+		folder, err := u.folders.GetFolder(ctx, resourceID, tenantID, userID)
+		return nil, folder, err
+	}
+	document, err := u.documents.GetDocument(ctx, resourceID, tenantID, userID)
+	return document, nil, err
+}
+
+// validateInput validates that required input parameters are not empty
+func (u *favoriteUseCase) validateInput(params map[string]string) error {
+	for name, value := range params {
+		if value == "" {
+			return errors.NewValidationError(fmt.Sprintf("%s cannot be empty", name))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,237 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert" // v1.8.0+
+	"github.com/stretchr/testify/mock"   // v1.8.0+
+	"github.com/stretchr/testify/suite"  // v1.8.0+
+
+	"../../domain/models"
+	pkgErrors "../../pkg/errors"
+	"../../pkg/utils"
+)
+
+// MockFavoriteRepository is a mock implementation of the FavoriteRepository interface for testing
+type MockFavoriteRepository struct {
+	mock.Mock
+}
+
+// Add mock implementation for starring a resource
+func (m *MockFavoriteRepository) Add(ctx context.Context, favorite *models.Favorite) error {
+	args := m.Called(ctx, favorite)
+	return args.Error(0)
+}
+
+// Remove mock implementation for unstarring a resource
+func (m *MockFavoriteRepository) Remove(ctx context.Context, tenantID, userID, resourceType, resourceID string) error {
+	args := m.Called(ctx, tenantID, userID, resourceType, resourceID)
+	return args.Error(0)
+}
+
+// List mock implementation for listing the favorites of a user
+func (m *MockFavoriteRepository) List(ctx context.Context, tenantID, userID, resourceType string, pagination *utils.Pagination) (utils.PaginatedResult[models.Favorite], error) {
+	args := m.Called(ctx, tenantID, userID, resourceType, pagination)
+	return args.Get(0).(utils.PaginatedResult[models.Favorite]), args.Error(1)
+}
+
+// MockAuditLogRepository is a mock implementation of the AuditLogRepository interface for testing
+type MockAuditLogRepository struct {
+	mock.Mock
+}
+
+// Create mock implementation for persisting an audit log entry
+func (m *MockAuditLogRepository) Create(ctx context.Context, entry *models.AuditLog) (string, error) {
+	args := m.Called(ctx, entry)
+	return args.String(0), args.Error(1)
+}
+
+// GetByID mock implementation for retrieving an audit log entry
+func (m *MockAuditLogRepository) GetByID(ctx context.Context, id string, tenantID string) (*models.AuditLog, error) {
+	args := m.Called(ctx, id, tenantID)
+	if entry := args.Get(0); entry != nil {
+		return entry.(*models.AuditLog), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// List mock implementation for listing audit log entries
+func (m *MockAuditLogRepository) List(ctx context.Context, tenantID string, filter models.AuditLogFilter, pagination *utils.Pagination) (utils.PaginatedResult[models.AuditLog], error) {
+	args := m.Called(ctx, tenantID, filter, pagination)
+	return args.Get(0).(utils.PaginatedResult[models.AuditLog]), args.Error(1)
+}
+
+// ListAfter mock implementation for listing audit log entries to forward
+func (m *MockAuditLogRepository) ListAfter(ctx context.Context, checkpoint *models.AuditExportCheckpoint, until time.Time, limit int) ([]*models.AuditLog, error) {
+	args := m.Called(ctx, checkpoint, until, limit)
+	if entries := args.Get(0); entries != nil {
+		return entries.([]*models.AuditLog), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// ListRecentResources mock implementation for listing the resources an actor recently accessed
+func (m *MockAuditLogRepository) ListRecentResources(ctx context.Context, tenantID, actorID, resourceType string, actions []string, since time.Time, limit int) ([]models.RecentResource, error) {
+	args := m.Called(ctx, tenantID, actorID, resourceType, actions, since, limit)
+	if resources := args.Get(0); resources != nil {
+		return resources.([]models.RecentResource), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// ListActivity mock implementation for listing the activity of a document or folder
+func (m *MockAuditLogRepository) ListActivity(ctx context.Context, tenantID, resourceType, resourceID string, pagination *utils.Pagination) (utils.PaginatedResult[models.AuditLog], error) {
+	args := m.Called(ctx, tenantID, resourceType, resourceID, pagination)
+	return args.Get(0).(utils.PaginatedResult[models.AuditLog]), args.Error(1)
+}
+
+// EnsurePartition mock implementation for audit log partition maintenance
+func (m *MockAuditLogRepository) EnsurePartition(ctx context.Context, month time.Time) error {
+	args := m.Called(ctx, month)
+	return args.Error(0)
+}
+
+// MockDocumentReader is a mock implementation of the DocumentReader interface for testing
+type MockDocumentReader struct {
+	mock.Mock
+}
+
+// GetDocument mock implementation for retrieving a document the user may read
+func (m *MockDocumentReader) GetDocument(ctx context.Context, id string, tenantID string, userID string) (*models.Document, error) {
+	args := m.Called(ctx, id, tenantID, userID)
+	if document := args.Get(0); document != nil {
+		return document.(*models.Document), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// MockFolderReader is a mock implementation of the FolderReader interface for testing
+type MockFolderReader struct {
+	mock.Mock
+}
+
+// GetFolder mock implementation for retrieving a folder the user may read
+func (m *MockFolderReader) GetFolder(ctx context.Context, id, tenantID, userID string) (*models.Folder, error) {
+	args := m.Called(ctx, id, tenantID, userID)
+	if folder := args.Get(0); folder != nil {
+		return folder.(*models.Folder), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// FavoriteUseCaseTestSuite defines a test suite for FavoriteUseCase
+type FavoriteUseCaseTestSuite struct {
+	suite.Suite
+	mockFavoriteRepo *MockFavoriteRepository
+	mockAuditLogRepo *MockAuditLogRepository
+	mockDocuments    *MockDocumentReader
+	mockFolders      *MockFolderReader
+	favoriteUseCase  FavoriteUseCase
+}
+
+// SetupTest sets up the test environment before each test
+func (s *FavoriteUseCaseTestSuite) SetupTest() {
+	s.mockFavoriteRepo = new(MockFavoriteRepository)
+	s.mockAuditLogRepo = new(MockAuditLogRepository)
+	s.mockDocuments = new(MockDocumentReader)
+	s.mockFolders = new(MockFolderReader)
+
+	var err error
+	s.favoriteUseCase, err = NewFavoriteUseCase(s.mockFavoriteRepo, s.mockAuditLogRepo, s.mockDocuments, s.mockFolders)
+	assert.Nil(s.T(), err)
+	assert.NotNil(s.T(), s.favoriteUseCase)
+}
+
+// TestNewFavoriteUseCase tests the creation of a new FavoriteUseCase
+func (s *FavoriteUseCaseTestSuite) TestNewFavoriteUseCase() {
+	useCase, err := NewFavoriteUseCase(s.mockFavoriteRepo, nil, s.mockDocuments, s.mockFolders)
+	assert.NotNil(s.T(), err)
+	assert.Nil(s.T(), useCase)
+}
+
+// TestStar_Document tests starring a document the user can read
+func (s *FavoriteUseCaseTestSuite) TestStar_Document() {
+	s.mockDocuments.On("GetDocument", mock.Anything, "doc123", "tenant123", "user123").Return(&models.Document{ID: "doc123"}, nil)
+	s.mockFavoriteRepo.On("Add", mock.Anything, mock.MatchedBy(func(f *models.Favorite) bool {
+		return f.ResourceType == models.ResourceTypeDocument && f.ResourceID == "doc123" && f.UserID == "user123"
+	})).Return(nil)
+
+	err := s.favoriteUseCase.Star(context.Background(), models.ResourceTypeDocument, "doc123", "tenant123", "user123")
+
+	assert.Nil(s.T(), err)
+	s.mockDocuments.AssertExpectations(s.T())
+	s.mockFavoriteRepo.AssertExpectations(s.T())
+}
+
+// TestStar_FolderAccessDenied tests starring a folder the user cannot read
+func (s *FavoriteUseCaseTestSuite) TestStar_FolderAccessDenied() {
+	s.mockFolders.On("GetFolder", mock.Anything, "folder123", "tenant123", "user123").Return(nil, pkgErrors.NewAuthorizationError("permission denied"))
+
+	err := s.favoriteUseCase.Star(context.Background(), models.ResourceTypeFolder, "folder123", "tenant123", "user123")
+
+	assert.True(s.T(), pkgErrors.IsAuthorizationError(err))
+	s.mockFavoriteRepo.AssertNotCalled(s.T(), "Add")
+}
+
+// TestUnstar_InvalidResourceType tests unstarring a resource that is neither a document nor a folder
+func (s *FavoriteUseCaseTestSuite) TestUnstar_InvalidResourceType() {
+	err := s.favoriteUseCase.Unstar(context.Background(), "tag", "tag123", "tenant123", "user123")
+
+	assert.True(s.T(), pkgErrors.IsValidationError(err))
+	s.mockFavoriteRepo.AssertNotCalled(s.T(), "Remove")
+}
+
+// TestListStarred_SkipsUnreadable tests that deleted resources are unstarred and inaccessible ones left out
+func (s *FavoriteUseCaseTestSuite) TestListStarred_SkipsUnreadable() {
+	favorites := []models.Favorite{
+		{ID: "fav1", TenantID: "tenant123", UserID: "user123", ResourceType: models.ResourceTypeDocument, ResourceID: "doc1"},
+		{ID: "fav2", TenantID: "tenant123", UserID: "user123", ResourceType: models.ResourceTypeFolder, ResourceID: "folder1"},
+		{ID: "fav3", TenantID: "tenant123", UserID: "user123", ResourceType: models.ResourceTypeDocument, ResourceID: "doc2"},
+		{ID: "fav4", TenantID: "tenant123", UserID: "user123", ResourceType: models.ResourceTypeDocument, ResourceID: "doc3"},
+	}
+	s.mockFavoriteRepo.On("List", mock.Anything, "tenant123", "user123", "", mock.Anything).
+		Return(utils.NewPaginatedResult(favorites, utils.NewPagination(1, 20), 4), nil)
+	s.mockDocuments.On("GetDocument", mock.Anything, "doc1", "tenant123", "user123").Return(&models.Document{ID: "doc1"}, nil)
+	s.mockFolders.On("GetFolder", mock.Anything, "folder1", "tenant123", "user123").Return(&models.Folder{ID: "folder1"}, nil)
+	s.mockDocuments.On("GetDocument", mock.Anything, "doc2", "tenant123", "user123").Return(nil, pkgErrors.NewResourceNotFoundError("document not found"))
+	s.mockDocuments.On("GetDocument", mock.Anything, "doc3", "tenant123", "user123").Return(nil, pkgErrors.NewAuthorizationError("permission denied"))
+	s.mockFavoriteRepo.On("Remove", mock.Anything, "tenant123", "user123", models.ResourceTypeDocument, "doc2").Return(nil)
+
+	result, err := s.favoriteUseCase.ListStarred(context.Background(), "", "tenant123", "user123", 1, 20)
+
+	assert.Nil(s.T(), err)
+	assert.Len(s.T(), result.Items, 2)
+	assert.Equal(s.T(), "doc1", result.Items[0].Document.ID)
+	assert.Equal(s.T(), "folder1", result.Items[1].Folder.ID)
+	assert.Equal(s.T(), int64(3), result.Pagination.TotalItems)
+	s.mockFavoriteRepo.AssertExpectations(s.T())
+	s.mockFavoriteRepo.AssertNotCalled(s.T(), "Remove", mock.Anything, "tenant123", "user123", models.ResourceTypeDocument, "doc3")
+}
+
+// TestListRecent_FiltersInaccessible tests recent documents read from the audit log with a clamped limit
+func (s *FavoriteUseCaseTestSuite) TestListRecent_FiltersInaccessible() {
+	accessedAt := time.Now().UTC()
+	resources := []models.RecentResource{
+		{ResourceID: "doc1", Action: models.AuditActionDownload, AccessedAt: accessedAt},
+		{ResourceID: "doc2", Action: models.AuditActionUpload, AccessedAt: accessedAt.Add(-time.Hour)},
+	}
+	s.mockAuditLogRepo.On("ListRecentResources", mock.Anything, "tenant123", "user123", models.ResourceTypeDocument, recentDocumentActions, mock.Anything, MaxRecentDocuments).Return(resources, nil)
+	s.mockDocuments.On("GetDocument", mock.Anything, "doc1", "tenant123", "user123").Return(&models.Document{ID: "doc1"}, nil)
+	s.mockDocuments.On("GetDocument", mock.Anything, "doc2", "tenant123", "user123").Return(nil, pkgErrors.NewAuthorizationError("permission denied"))
+
+	recent, err := s.favoriteUseCase.ListRecent(context.Background(), "tenant123", "user123", 500)
+
+	assert.Nil(s.T(), err)
+	assert.Len(s.T(), recent, 1)
+	assert.Equal(s.T(), "doc1", recent[0].Document.ID)
+	assert.Equal(s.T(), models.AuditActionDownload, recent[0].Action)
+	assert.Equal(s.T(), accessedAt, recent[0].AccessedAt)
+	s.mockAuditLogRepo.AssertExpectations(s.T())
+}
+
+// TestFavoriteUseCaseSuite runs the FavoriteUseCase test suite
+func TestFavoriteUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(FavoriteUseCaseTestSuite))
+}
@@ -0,0 +1,393 @@
+// Package usecases implements the application layer of the Document Management Platform.
+// It contains use case implementations that orchestrate domain models and services.
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid" // v1.3.0+ - For generating unique IDs for folder events
+
+	"../../domain/models"
+	"../../domain/repositories"
+	"../../domain/services"
+	"../../pkg/errors"
+	"../../pkg/logger"
+)
+
+// Folder template errors
+var (
+	ErrFolderTemplateFolderExists = errors.NewValidationError("a folder with this name already exists in the parent folder")
+)
+
+// FolderTemplateUseCase defines the contract for folder templates. Administrators define named
+// folder trees with their permissions and metadata templates, and apply them to set up a new
+// project or tenant in one call.
+type FolderTemplateUseCase interface {
+	// CreateTemplate creates a new folder template
+	CreateTemplate(ctx context.Context, template *models.FolderTemplate) (*models.FolderTemplate, error)
+
+	// GetTemplate retrieves a folder template by its ID
+	GetTemplate(ctx context.Context, id, tenantID string) (*models.FolderTemplate, error)
+
+	// ListTemplates lists the folder templates of a tenant ordered by name
+	ListTemplates(ctx context.Context, tenantID string) ([]*models.FolderTemplate, error)
+
+	// UpdateTemplate replaces the name, description, permissions and folders of a folder template;
+	// folders already created from it are not changed
+	UpdateTemplate(ctx context.Context, template *models.FolderTemplate) (*models.FolderTemplate, error)
+
+	// DeleteTemplate deletes a folder template; folders already created from it are kept
+	DeleteTemplate(ctx context.Context, id, tenantID string) error
+
+	// ApplyTemplate creates a folder named name in parentID, or at the root when parentID is empty,
+	// holding the template's folders with their permissions and metadata templates. The user gets
+	// admin permission on the created folder. Everything is created in one transaction.
+	ApplyTemplate(ctx context.Context, id, name, parentID, tenantID, userID string) (*models.Folder, error)
+}
+
+// folderTemplateUseCase implements the FolderTemplateUseCase interface
+type folderTemplateUseCase struct {
+	templateRepo         repositories.FolderTemplateRepository
+	folderRepo           repositories.FolderRepository
+	permissionRepo       repositories.PermissionRepository
+	metadataTemplateRepo repositories.MetadataTemplateRepository
+	authService          services.AuthService
+	outboxRepo           repositories.OutboxRepository
+	auditService         services.AuditService
+	txManager            repositories.TransactionManager
+}
+
+// NewFolderTemplateUseCase creates a new FolderTemplateUseCase instance
+func NewFolderTemplateUseCase(
+	templateRepo repositories.FolderTemplateRepository,
+	folderRepo repositories.FolderRepository,
+	permissionRepo repositories.PermissionRepository,
+	metadataTemplateRepo repositories.MetadataTemplateRepository,
+	authService services.AuthService,
+	outboxRepo repositories.OutboxRepository,
+	auditService services.AuditService,
+	txManager repositories.TransactionManager,
+) (FolderTemplateUseCase, error) {
+	if templateRepo == nil {
+		return nil, fmt.Errorf("folder template repository cannot be nil")
+	}
+	if folderRepo == nil {
+		return nil, fmt.Errorf("folder repository cannot be nil")
+	}
+	if permissionRepo == nil {
+		return nil, fmt.Errorf("permission repository cannot be nil")
+	}
+	if metadataTemplateRepo == nil {
+		return nil, fmt.Errorf("metadata template repository cannot be nil")
+	}
+	if authService == nil {
+		return nil, fmt.Errorf("auth service cannot be nil")
+	}
+	if outboxRepo == nil {
+		return nil, fmt.Errorf("outbox repository cannot be nil")
+	}
+	if auditService == nil {
+		return nil, fmt.Errorf("audit service cannot be nil")
+	}
+	if txManager == nil {
+		return nil, fmt.Errorf("transaction manager cannot be nil")
+	}
+
+	return &folderTemplateUseCase{
+		templateRepo:         templateRepo,
+		folderRepo:           folderRepo,
+		permissionRepo:       permissionRepo,
+		metadataTemplateRepo: metadataTemplateRepo,
+		authService:          authService,
+		outboxRepo:           outboxRepo,
+		auditService:         auditService,
+		txManager:            txManager,
+	}, nil
+}
+
+// CreateTemplate creates a new folder template
+func (u *folderTemplateUseCase) CreateTemplate(ctx context.Context, template *models.FolderTemplate) (*models.FolderTemplate, error) {
+	log := logger.WithContext(ctx)
+
+	if template == nil {
+		return nil, errors.NewValidationError("folder template cannot be nil")
+	}
+	if err := template.Validate(); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	id, err := u.templateRepo.Create(ctx, template)
+	if err != nil {
+		log.WithError(err).Error("failed to create folder template", "tenantID", template.TenantID)
+		return nil, errors.Wrap(err, "failed to create folder template")
+	}
+	template.ID = id
+
+	log.Info("folder template created successfully", "templateID", id, "tenantID", template.TenantID, "folders", template.FolderCount())
+	return template, nil
+}
+
+// GetTemplate retrieves a folder template by its ID
+func (u *folderTemplateUseCase) GetTemplate(ctx context.Context, id, tenantID string) (*models.FolderTemplate, error) {
+	if id == "" || tenantID == "" {
+		return nil, errors.NewValidationError("folder template ID and tenant ID are required")
+	}
+
+	template, err := u.templateRepo.GetByID(ctx, id, tenantID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get folder template")
+	}
+	return template, nil
+}
+
+// ListTemplates lists the folder templates of a tenant ordered by name
+func (u *folderTemplateUseCase) ListTemplates(ctx context.Context, tenantID string) ([]*models.FolderTemplate, error) {
+	if tenantID == "" {
+		return nil, errors.NewValidationError("tenant ID is required")
+	}
+
+	templates, err := u.templateRepo.ListByTenant(ctx, tenantID)
+	if err != nil {
+		logger.WithContext(ctx).WithError(err).Error("failed to list folder templates", "tenantID", tenantID)
+		return nil, errors.Wrap(err, "failed to list folder templates")
+	}
+	return templates, nil
+}
+
+// UpdateTemplate replaces the name, description, permissions and folders of a folder template,
+// keeping its creator and creation time
+func (u *folderTemplateUseCase) UpdateTemplate(ctx context.Context, template *models.FolderTemplate) (*models.FolderTemplate, error) {
+	log := logger.WithContext(ctx)
+
+	if template == nil {
+		return nil, errors.NewValidationError("folder template cannot be nil")
+	}
+
+	existing, err := u.GetTemplate(ctx, template.ID, template.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	template.CreatedBy = existing.CreatedBy
+	template.CreatedAt = existing.CreatedAt
+
+	if err := template.Validate(); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	if err := u.templateRepo.Update(ctx, template); err != nil {
+		log.WithError(err).Error("failed to update folder template", "templateID", template.ID, "tenantID", template.TenantID)
+		return nil, errors.Wrap(err, "failed to update folder template")
+	}
+
+	log.Info("folder template updated successfully", "templateID", template.ID, "tenantID", template.TenantID)
+	return template, nil
+}
+
+// DeleteTemplate deletes a folder template
+func (u *folderTemplateUseCase) DeleteTemplate(ctx context.Context, id, tenantID string) error {
+	if id == "" || tenantID == "" {
+		return errors.NewValidationError("folder template ID and tenant ID are required")
+	}
+
+	if err := u.templateRepo.Delete(ctx, id, tenantID); err != nil {
+		logger.WithContext(ctx).WithError(err).Error("failed to delete folder template", "templateID", id, "tenantID", tenantID)
+		return errors.Wrap(err, "failed to delete folder template")
+	}
+
+	logger.WithContext(ctx).Info("folder template deleted successfully", "templateID", id, "tenantID", tenantID)
+	return nil
+}
+
+// ApplyTemplate creates a folder holding the template's folders, with their permissions and
+// metadata templates, in one transaction
+func (u *folderTemplateUseCase) ApplyTemplate(ctx context.Context, id, name, parentID, tenantID, userID string) (*models.Folder, error) {
+	log := logger.WithContext(ctx)
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.NewValidationError("folder name is required")
+	}
+	if strings.Contains(name, models.PathSeparator) {
+		return nil, errors.NewValidationError(models.ErrFolderTemplateFolderNameInvalid.Error())
+	}
+	if userID == "" {
+		return nil, errors.NewValidationError("user ID is required")
+	}
+
+	template, err := u.GetTemplate(ctx, id, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Creating the folder needs the same permissions as creating it by hand
+	root := models.NewFolder(name, parentID, tenantID, userID)
+	if parentID != "" {
+		parent, err := u.folderRepo.GetByID(ctx, parentID, tenantID)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get parent folder")
+		}
+		if err := u.verifyAccess(ctx, tenantID, userID, parentID); err != nil {
+			return nil, err
+		}
+		root.SetPath(root.BuildPath(parent.Path))
+	} else {
+		hasPermission, err := u.authService.VerifyPermission(ctx, userID, tenantID, services.PermissionManageFolders)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to verify user permission")
+		}
+		if !hasPermission {
+			return nil, ErrPermissionDenied
+		}
+		root.SetPath(root.BuildPath(""))
+	}
+
+	if existing, err := u.folderRepo.GetByPath(ctx, root.Path, tenantID); err == nil && existing != nil {
+		return nil, ErrFolderTemplateFolderExists
+	}
+
+	// The creator gets admin permission on the folder the template is applied as
+	owner := models.NewPermission("", models.ResourceTypeFolder, "", models.PermissionTypeAdmin, tenantID, userID)
+	owner.UserID = userID
+
+	apply := &templateApplication{
+		folderRepo:           u.folderRepo,
+		metadataTemplateRepo: u.metadataTemplateRepo,
+		outboxRepo:           u.outboxRepo,
+		tenantID:             tenantID,
+		userID:               userID,
+	}
+	err = u.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := apply.createFolder(txCtx, root, template.Permissions, nil); err != nil {
+			return err
+		}
+		owner.ResourceID = root.ID
+		apply.permissions = append(apply.permissions, owner)
+
+		if err := apply.createFolders(txCtx, root, template.Folders); err != nil {
+			return err
+		}
+
+		if err := u.auditService.RecordAction(txCtx, tenantID, userID, models.AuditActionCreate, models.ResourceTypeFolder, root.ID, nil, map[string]interface{}{
+			"name":             root.Name,
+			"parentID":         parentID,
+			"path":             root.Path,
+			"folderTemplateID": template.ID,
+			"folders":          len(apply.folders),
+		}); err != nil {
+			return err
+		}
+
+		// Permissions are written last so a failure rolls the folders back with them
+		if _, err := u.permissionRepo.CreateBulk(txCtx, apply.permissions); err != nil {
+			return errors.Wrap(err, "failed to create folder permissions")
+		}
+		return nil
+	})
+	if err != nil {
+		log.WithError(err).Error("failed to apply folder template", "templateID", template.ID, "tenantID", tenantID, "name", name)
+		return nil, errors.Wrap(err, "failed to apply folder template")
+	}
+
+	// Let each folder's permissions reach its subfolders, parents first
+	for _, folder := range apply.folders {
+		if err := u.permissionRepo.PropagatePermissions(ctx, folder.ID, tenantID); err != nil {
+			log.WithError(err).Error("failed to propagate permissions", "folderID", folder.ID)
+			// We don't return error here as the folders were already created
+		}
+	}
+
+	log.Info("folder template applied successfully", "templateID", template.ID, "folderID", root.ID, "tenantID", tenantID, "folders", len(apply.folders))
+	return root, nil
+}
+
+// verifyAccess checks that the user can write to a folder
+func (u *folderTemplateUseCase) verifyAccess(ctx context.Context, tenantID, userID, folderID string) error {
+	hasAccess, err := u.authService.VerifyResourceAccess(ctx, userID, tenantID, services.ResourceTypeFolder, folderID, services.PermissionWrite)
+	if err != nil {
+		return errors.Wrap(err, "failed to verify folder access")
+	}
+	if !hasAccess {
+		return ErrPermissionDenied
+	}
+	return nil
+}
+
+// templateApplication collects the folders and permissions created while a template is applied
+type templateApplication struct {
+	folderRepo           repositories.FolderRepository
+	metadataTemplateRepo repositories.MetadataTemplateRepository
+	outboxRepo           repositories.OutboxRepository
+	tenantID             string
+	userID               string
+	folders              []*models.Folder
+	permissions          []*models.Permission
+}
+
+// createFolders creates the folders of a level of the template below parent, or at the root when
+// parent is nil, and their subfolders
+func (a *templateApplication) createFolders(ctx context.Context, parent *models.Folder, folders []models.FolderTemplateFolder) error {
+	parentID, parentPath := "", ""
+	if parent != nil {
+		parentID, parentPath = parent.ID, parent.Path
+	}
+
+	for i := range folders {
+		node := &folders[i]
+		folder := models.NewFolder(strings.TrimSpace(node.Name), parentID, a.tenantID, a.userID)
+		folder.SetPath(folder.BuildPath(parentPath))
+		if err := a.createFolder(ctx, folder, node.Permissions, node); err != nil {
+			return err
+		}
+		if err := a.createFolders(ctx, folder, node.Folders); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createFolder creates a folder with its metadata template and folder.created event within the
+// transaction carried by ctx, and collects its permissions
+func (a *templateApplication) createFolder(ctx context.Context, folder *models.Folder, grants []models.FolderTemplateGrant, node *models.FolderTemplateFolder) error {
+	id, err := a.folderRepo.Create(ctx, folder)
+	if err != nil {
+		return errors.Wrap(err, "failed to create folder")
+	}
+	folder.ID = id
+	a.folders = append(a.folders, folder)
+
+	for _, grant := range grants {
+		permission, err := models.NewGrant(grant.GranteeType, grant.GranteeID, models.ResourceTypeFolder, id, grant.PermissionType, a.tenantID, a.userID)
+		if err != nil {
+			return errors.NewValidationError(err.Error())
+		}
+		a.permissions = append(a.permissions, permission)
+	}
+
+	if node != nil {
+		if metadataTemplate := node.MetadataTemplate(a.tenantID, id, a.userID); metadataTemplate != nil {
+			if _, err := a.metadataTemplateRepo.Create(ctx, metadataTemplate); err != nil {
+				return errors.Wrap(err, "failed to create metadata template")
+			}
+		}
+	}
+
+	event, err := models.NewFolderCreatedEvent(a.tenantID, id, map[string]interface{}{
+		"name":      folder.Name,
+		"parentID":  folder.ParentID,
+		"path":      folder.Path,
+		"createdBy": a.userID,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to create folder created event")
+	}
+	event.ID = uuid.New().String()
+
+	message, err := models.NewOutboxMessage(event)
+	if err != nil {
+		return errors.Wrap(err, "invalid outbox message")
+	}
+	_, err = a.outboxRepo.Create(ctx, message)
+	return err
+}
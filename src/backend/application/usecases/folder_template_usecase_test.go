@@ -0,0 +1,243 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert" // v1.8.0+
+	"github.com/stretchr/testify/mock"   // v1.8.0+
+	"github.com/stretchr/testify/suite"  // v1.8.0+
+
+	"../../domain/models"
+	"../../domain/repositories"
+	"../../domain/services"
+	pkgErrors "../../pkg/errors"
+)
+
+// MockFolderTemplateRepository is a mock implementation of the FolderTemplateRepository interface for testing
+type MockFolderTemplateRepository struct {
+	mock.Mock
+}
+
+func (m *MockFolderTemplateRepository) Create(ctx context.Context, template *models.FolderTemplate) (string, error) {
+	args := m.Called(ctx, template)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockFolderTemplateRepository) GetByID(ctx context.Context, id string, tenantID string) (*models.FolderTemplate, error) {
+	args := m.Called(ctx, id, tenantID)
+	if template := args.Get(0); template != nil {
+		return template.(*models.FolderTemplate), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockFolderTemplateRepository) Update(ctx context.Context, template *models.FolderTemplate) error {
+	args := m.Called(ctx, template)
+	return args.Error(0)
+}
+
+func (m *MockFolderTemplateRepository) Delete(ctx context.Context, id string, tenantID string) error {
+	args := m.Called(ctx, id, tenantID)
+	return args.Error(0)
+}
+
+func (m *MockFolderTemplateRepository) ListByTenant(ctx context.Context, tenantID string) ([]*models.FolderTemplate, error) {
+	args := m.Called(ctx, tenantID)
+	return args.Get(0).([]*models.FolderTemplate), args.Error(1)
+}
+
+// fakeTemplateFolderRepository records the folders created from templates, giving them sequential IDs
+type fakeTemplateFolderRepository struct {
+	repositories.FolderRepository
+	folders []*models.Folder
+}
+
+func (r *fakeTemplateFolderRepository) Create(ctx context.Context, folder *models.Folder) (string, error) {
+	r.folders = append(r.folders, folder)
+	return fmt.Sprintf("folder%d", len(r.folders)), nil
+}
+
+func (r *fakeTemplateFolderRepository) GetByID(ctx context.Context, id string, tenantID string) (*models.Folder, error) {
+	if id != "parent123" {
+		return nil, pkgErrors.NewResourceNotFoundError("folder not found")
+	}
+	return &models.Folder{ID: id, Name: "Projects", Path: "/Projects", TenantID: tenantID}, nil
+}
+
+func (r *fakeTemplateFolderRepository) GetByPath(ctx context.Context, path string, tenantID string) (*models.Folder, error) {
+	if path == "/Projects/Existing" {
+		return &models.Folder{ID: "existing123", Path: path, TenantID: tenantID}, nil
+	}
+	return nil, pkgErrors.NewResourceNotFoundError("folder not found")
+}
+
+// mockTemplatePermissionRepository mocks the PermissionRepository methods used by folder templates
+type mockTemplatePermissionRepository struct {
+	repositories.PermissionRepository
+	mock.Mock
+}
+
+func (m *mockTemplatePermissionRepository) CreateBulk(ctx context.Context, permissions []*models.Permission) ([]string, error) {
+	args := m.Called(ctx, permissions)
+	return nil, args.Error(0)
+}
+
+func (m *mockTemplatePermissionRepository) PropagatePermissions(ctx context.Context, folderID, tenantID string) error {
+	args := m.Called(ctx, folderID, tenantID)
+	return args.Error(0)
+}
+
+// mockTemplateMetadataTemplateRepository mocks the MetadataTemplateRepository methods used by folder templates
+type mockTemplateMetadataTemplateRepository struct {
+	repositories.MetadataTemplateRepository
+	mock.Mock
+}
+
+func (m *mockTemplateMetadataTemplateRepository) Create(ctx context.Context, template *models.MetadataTemplate) (string, error) {
+	args := m.Called(ctx, template)
+	return args.String(0), args.Error(1)
+}
+
+// mockTemplateAuthService mocks the AuthService methods used by folder templates
+type mockTemplateAuthService struct {
+	services.AuthService
+	mock.Mock
+}
+
+func (m *mockTemplateAuthService) VerifyPermission(ctx context.Context, userID, tenantID, permission string) (bool, error) {
+	args := m.Called(ctx, userID, tenantID, permission)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockTemplateAuthService) VerifyResourceAccess(ctx context.Context, userID, tenantID, resourceType, resourceID, accessType string) (bool, error) {
+	args := m.Called(ctx, userID, tenantID, resourceType, resourceID, accessType)
+	return args.Bool(0), args.Error(1)
+}
+
+// FolderTemplateUseCaseTestSuite defines a test suite for FolderTemplateUseCase
+type FolderTemplateUseCaseTestSuite struct {
+	suite.Suite
+	mockTemplateRepo         *MockFolderTemplateRepository
+	folderRepo               *fakeTemplateFolderRepository
+	mockPermissionRepo       *mockTemplatePermissionRepository
+	mockMetadataTemplateRepo *mockTemplateMetadataTemplateRepository
+	mockAuthService          *mockTemplateAuthService
+	mockOutboxRepo           *MockOutboxRepository
+	folderTemplateUseCase    FolderTemplateUseCase
+}
+
+// SetupTest sets up the test environment before each test
+func (s *FolderTemplateUseCaseTestSuite) SetupTest() {
+	s.mockTemplateRepo = new(MockFolderTemplateRepository)
+	s.folderRepo = new(fakeTemplateFolderRepository)
+	s.mockPermissionRepo = new(mockTemplatePermissionRepository)
+	s.mockMetadataTemplateRepo = new(mockTemplateMetadataTemplateRepository)
+	s.mockAuthService = new(mockTemplateAuthService)
+	s.mockOutboxRepo = new(MockOutboxRepository)
+
+	var err error
+	s.folderTemplateUseCase, err = NewFolderTemplateUseCase(s.mockTemplateRepo, s.folderRepo, s.mockPermissionRepo, s.mockMetadataTemplateRepo, s.mockAuthService, s.mockOutboxRepo, &noopAuditService{}, &passthroughTransactionManager{})
+	assert.Nil(s.T(), err)
+	assert.NotNil(s.T(), s.folderTemplateUseCase)
+}
+
+// projectTemplate returns a template with Contracts and Invoices folders, Invoices holding an Archive folder
+func (s *FolderTemplateUseCaseTestSuite) projectTemplate() *models.FolderTemplate {
+	template := models.NewFolderTemplate("tenant123", "Project", []models.FolderTemplateFolder{
+		{
+			Name:        "Contracts",
+			Permissions: []models.FolderTemplateGrant{{GranteeType: models.GranteeTypeGroup, GranteeID: "legal", PermissionType: models.PermissionTypeWrite}},
+		},
+		{
+			Name:           "Invoices",
+			MetadataFields: []string{"invoice_number", "vendor"},
+			Folders:        []models.FolderTemplateFolder{{Name: "Archive"}},
+		},
+	}, "admin123")
+	template.ID = "template123"
+	template.Permissions = []models.FolderTemplateGrant{{GranteeType: models.GranteeTypeRole, GranteeID: "reader", PermissionType: models.PermissionTypeRead}}
+	return template
+}
+
+// TestNewFolderTemplateUseCase tests the creation of a new FolderTemplateUseCase
+func (s *FolderTemplateUseCaseTestSuite) TestNewFolderTemplateUseCase() {
+	useCase, err := NewFolderTemplateUseCase(s.mockTemplateRepo, s.folderRepo, s.mockPermissionRepo, s.mockMetadataTemplateRepo, s.mockAuthService, s.mockOutboxRepo, &noopAuditService{}, nil)
+	assert.NotNil(s.T(), err)
+	assert.Nil(s.T(), useCase)
+}
+
+// TestCreateTemplate_DuplicateFolder tests defining a template with two folders of the same name in a parent
+func (s *FolderTemplateUseCaseTestSuite) TestCreateTemplate_DuplicateFolder() {
+	template := models.NewFolderTemplate("tenant123", "Project", []models.FolderTemplateFolder{{Name: "Contracts"}, {Name: "Contracts"}}, "admin123")
+
+	created, err := s.folderTemplateUseCase.CreateTemplate(context.Background(), template)
+
+	assert.Nil(s.T(), created)
+	assert.True(s.T(), pkgErrors.IsValidationError(err))
+	s.mockTemplateRepo.AssertNotCalled(s.T(), "Create")
+}
+
+// TestApplyTemplate_Success tests applying a template, which creates the folder tree with its
+// permissions, metadata templates and events
+func (s *FolderTemplateUseCaseTestSuite) TestApplyTemplate_Success() {
+	s.mockTemplateRepo.On("GetByID", mock.Anything, "template123", "tenant123").Return(s.projectTemplate(), nil)
+	s.mockAuthService.On("VerifyResourceAccess", mock.Anything, "user123", "tenant123", services.ResourceTypeFolder, "parent123", services.PermissionWrite).Return(true, nil)
+	s.mockMetadataTemplateRepo.On("Create", mock.Anything, mock.MatchedBy(func(t *models.MetadataTemplate) bool {
+		return t.FolderID == "folder3" && t.IsEnforced() && len(t.Fields) == 2
+	})).Return("metadata123", nil).Once()
+	s.mockOutboxRepo.On("Create", mock.Anything, mock.MatchedBy(func(m *models.OutboxMessage) bool {
+		return m.EventType == models.EventTypeFolderCreated
+	})).Return("message123", nil).Times(4)
+	s.mockPermissionRepo.On("CreateBulk", mock.Anything, mock.MatchedBy(func(permissions []*models.Permission) bool {
+		// The template's read grant and the user's admin permission on the project, the write grant on Contracts
+		return len(permissions) == 3 &&
+			permissions[0].ResourceID == "folder1" && permissions[0].RoleID == "reader" &&
+			permissions[1].ResourceID == "folder1" && permissions[1].UserID == "user123" && permissions[1].PermissionType == models.PermissionTypeAdmin &&
+			permissions[2].ResourceID == "folder2" && permissions[2].GroupID == "legal"
+	})).Return(nil)
+	s.mockPermissionRepo.On("PropagatePermissions", mock.Anything, mock.Anything, "tenant123").Return(nil).Times(4)
+
+	folder, err := s.folderTemplateUseCase.ApplyTemplate(context.Background(), "template123", "Apollo", "parent123", "tenant123", "user123")
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), "folder1", folder.ID)
+	assert.Equal(s.T(), "/Projects/Apollo", folder.Path)
+	if assert.Len(s.T(), s.folderRepo.folders, 4) {
+		assert.Equal(s.T(), "/Projects/Apollo/Invoices/Archive", s.folderRepo.folders[3].Path)
+		assert.Equal(s.T(), "folder3", s.folderRepo.folders[3].ParentID)
+	}
+	s.mockMetadataTemplateRepo.AssertExpectations(s.T())
+	s.mockOutboxRepo.AssertExpectations(s.T())
+	s.mockPermissionRepo.AssertExpectations(s.T())
+}
+
+// TestApplyTemplate_FolderExists tests applying a template as a folder that already exists
+func (s *FolderTemplateUseCaseTestSuite) TestApplyTemplate_FolderExists() {
+	s.mockTemplateRepo.On("GetByID", mock.Anything, "template123", "tenant123").Return(s.projectTemplate(), nil)
+	s.mockAuthService.On("VerifyResourceAccess", mock.Anything, "user123", "tenant123", services.ResourceTypeFolder, "parent123", services.PermissionWrite).Return(true, nil)
+
+	folder, err := s.folderTemplateUseCase.ApplyTemplate(context.Background(), "template123", "Existing", "parent123", "tenant123", "user123")
+
+	assert.Nil(s.T(), folder)
+	assert.Equal(s.T(), ErrFolderTemplateFolderExists, err)
+	assert.Empty(s.T(), s.folderRepo.folders)
+}
+
+// TestApplyTemplate_AccessDenied tests applying a template at the root without permission to manage folders
+func (s *FolderTemplateUseCaseTestSuite) TestApplyTemplate_AccessDenied() {
+	s.mockTemplateRepo.On("GetByID", mock.Anything, "template123", "tenant123").Return(s.projectTemplate(), nil)
+	s.mockAuthService.On("VerifyPermission", mock.Anything, "user123", "tenant123", services.PermissionManageFolders).Return(false, nil)
+
+	folder, err := s.folderTemplateUseCase.ApplyTemplate(context.Background(), "template123", "Apollo", "", "tenant123", "user123")
+
+	assert.Nil(s.T(), folder)
+	assert.True(s.T(), pkgErrors.IsAuthorizationError(err))
+	assert.Empty(s.T(), s.folderRepo.folders)
+}
+
+// TestFolderTemplateUseCaseSuite runs the FolderTemplateUseCase test suite
+func TestFolderTemplateUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(FolderTemplateUseCaseTestSuite))
+}
@@ -243,21 +243,22 @@ func (uc *FolderUseCase) GetFolderByPath(ctx context.Context, path, tenantID, us
 	return folder, nil
 }
 
-// CreateFolderPermission creates a permission for a folder with tenant isolation and permission checks
-func (uc *FolderUseCase) CreateFolderPermission(ctx context.Context, folderID, roleID, permissionType, tenantID, userID string) (string, error) {
+// CreateFolderPermission grants a role, user or group a permission on a folder with tenant isolation and permission checks
+func (uc *FolderUseCase) CreateFolderPermission(ctx context.Context, folderID, granteeType, granteeID, permissionType, tenantID, userID string) (string, error) {
 	// Get logger with context
 	log := logger.WithContext(ctx)
 	
 	// Log folder permission creation attempt
 	log.Info("Creating folder permission", 
 		"folderID", folderID, 
-		"roleID", roleID, 
+		"granteeType", granteeType, 
+		"granteeID", granteeID, 
 		"permissionType", permissionType, 
 		"tenantID", tenantID, 
 		"userID", userID)
 	
 	// Call folderService.CreateFolderPermission with the provided parameters
-	permissionID, err := uc.folderService.CreateFolderPermission(ctx, folderID, roleID, permissionType, tenantID, userID)
+	permissionID, err := uc.folderService.CreateFolderPermission(ctx, folderID, granteeType, granteeID, permissionType, tenantID, userID)
 	if err != nil {
 		// If error occurs, log error and wrap it with context
 		log.WithError(err).Error("Failed to create folder permission", "folderID", folderID)
@@ -312,4 +313,40 @@ func (uc *FolderUseCase) GetFolderPermissions(ctx context.Context, folderID, ten
 	log.Info("Folder permissions retrieved successfully", "folderID", folderID, "count", len(permissions))
 	
 	return permissions, nil
+}
+
+// SetDownloadWatermark turns watermarking of documents downloaded from a folder on or off
+func (uc *FolderUseCase) SetDownloadWatermark(ctx context.Context, id string, enabled bool, tenantID, userID string) error {
+	// Get logger with context
+	log := logger.WithContext(ctx)
+	
+	log.Info("Setting folder download watermarking", "folderID", id, "enabled", enabled, "tenantID", tenantID, "userID", userID)
+	
+	err := uc.folderService.SetDownloadWatermark(ctx, id, enabled, tenantID, userID)
+	if err != nil {
+		log.WithError(err).Error("Failed to set folder download watermarking", "folderID", id)
+		return errors.Wrap(err, "failed to set folder download watermarking")
+	}
+	
+	log.Info("Folder download watermarking set successfully", "folderID", id, "enabled", enabled)
+	
+	return nil
+}
+
+// SetDownloadPolicy sets the download policy of the documents directly in a folder
+func (uc *FolderUseCase) SetDownloadPolicy(ctx context.Context, id string, policy models.DownloadPolicy, tenantID, userID string) error {
+	// Get logger with context
+	log := logger.WithContext(ctx)
+	
+	log.Info("Setting folder download policy", "folderID", id, "tenantID", tenantID, "userID", userID)
+	
+	err := uc.folderService.SetDownloadPolicy(ctx, id, policy, tenantID, userID)
+	if err != nil {
+		log.WithError(err).Error("Failed to set folder download policy", "folderID", id)
+		return errors.Wrap(err, "failed to set folder download policy")
+	}
+	
+	log.Info("Folder download policy set successfully", "folderID", id, "viewOnly", policy.ViewOnly, "dailyLimit", policy.DailyLimit, "reauthenticationMinutes", policy.ReauthenticationMinutes)
+	
+	return nil
 }
\ No newline at end of file
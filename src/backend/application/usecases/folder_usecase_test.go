@@ -797,10 +797,32 @@ func (s *FolderUseCaseTestSuite) TestCreateFolderPermission_Success() {
 	permissionID := "perm-123"
 
 	// Setup mock expectations
-	s.mockFolderService.On("CreateFolderPermission", mock.Anything, folderID, roleID, permissionType, tenantID, userID).Return(permissionID, nil)
+	s.mockFolderService.On("CreateFolderPermission", mock.Anything, folderID, models.GranteeTypeRole, roleID, permissionType, tenantID, userID).Return(permissionID, nil)
 
 	// Call the method under test
-	result, err := s.useCase.CreateFolderPermission(s.ctx, folderID, roleID, permissionType, tenantID, userID)
+	result, err := s.useCase.CreateFolderPermission(s.ctx, folderID, models.GranteeTypeRole, roleID, permissionType, tenantID, userID)
+
+	// Assertions
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), permissionID, result)
+	s.mockFolderService.AssertExpectations(s.T())
+}
+
+// TestCreateFolderPermission_UserGrant tests granting a folder permission directly to a user
+func (s *FolderUseCaseTestSuite) TestCreateFolderPermission_UserGrant() {
+	// Test data
+	folderID := "folder-123"
+	granteeID := "user-456"
+	permissionType := "write"
+	tenantID := "tenant-123"
+	userID := "user-123"
+	permissionID := "perm-456"
+
+	// Setup mock expectations
+	s.mockFolderService.On("CreateFolderPermission", mock.Anything, folderID, models.GranteeTypeUser, granteeID, permissionType, tenantID, userID).Return(permissionID, nil)
+
+	// Call the method under test
+	result, err := s.useCase.CreateFolderPermission(s.ctx, folderID, models.GranteeTypeUser, granteeID, permissionType, tenantID, userID)
 
 	// Assertions
 	assert.NoError(s.T(), err)
@@ -851,6 +873,67 @@ func (s *FolderUseCaseTestSuite) TestGetFolderPermissions_Success() {
 	s.mockFolderService.AssertExpectations(s.T())
 }
 
+// TestSetDownloadWatermark_Success tests turning on download watermarking of a folder
+func (s *FolderUseCaseTestSuite) TestSetDownloadWatermark_Success() {
+	// Setup mock expectations
+	s.mockFolderService.On("SetDownloadWatermark", mock.Anything, "folder-123", true, "tenant-123", "user-123").Return(nil)
+
+	// Call the method under test
+	err := s.useCase.SetDownloadWatermark(s.ctx, "folder-123", true, "tenant-123", "user-123")
+
+	// Assertions
+	assert.NoError(s.T(), err)
+	s.mockFolderService.AssertExpectations(s.T())
+}
+
+// TestSetDownloadWatermark_PermissionDenied tests that only folder admins can change download watermarking
+func (s *FolderUseCaseTestSuite) TestSetDownloadWatermark_PermissionDenied() {
+	permDeniedErr := errors.NewPermissionDeniedError("permission denied for folder operation")
+
+	// Setup mock expectations
+	s.mockFolderService.On("SetDownloadWatermark", mock.Anything, "folder-123", false, "tenant-123", "user-123").Return(permDeniedErr)
+
+	// Call the method under test
+	err := s.useCase.SetDownloadWatermark(s.ctx, "folder-123", false, "tenant-123", "user-123")
+
+	// Assertions
+	assert.Error(s.T(), err)
+	assert.True(s.T(), errors.IsPermissionDeniedError(err), "Expected permission denied error")
+	s.mockFolderService.AssertExpectations(s.T())
+}
+
+// TestSetDownloadPolicy_Success tests making a folder view-only
+func (s *FolderUseCaseTestSuite) TestSetDownloadPolicy_Success() {
+	policy := models.DownloadPolicy{ViewOnly: true}
+
+	// Setup mock expectations
+	s.mockFolderService.On("SetDownloadPolicy", mock.Anything, "folder-123", policy, "tenant-123", "user-123").Return(nil)
+
+	// Call the method under test
+	err := s.useCase.SetDownloadPolicy(s.ctx, "folder-123", policy, "tenant-123", "user-123")
+
+	// Assertions
+	assert.NoError(s.T(), err)
+	s.mockFolderService.AssertExpectations(s.T())
+}
+
+// TestSetDownloadPolicy_ValidationError tests that limits out of range are rejected
+func (s *FolderUseCaseTestSuite) TestSetDownloadPolicy_ValidationError() {
+	policy := models.DownloadPolicy{DailyLimit: models.MaxDailyDownloadLimit + 1}
+	validationErr := errors.NewValidationError(models.ErrDownloadLimitInvalid.Error())
+
+	// Setup mock expectations
+	s.mockFolderService.On("SetDownloadPolicy", mock.Anything, "folder-123", policy, "tenant-123", "user-123").Return(validationErr)
+
+	// Call the method under test
+	err := s.useCase.SetDownloadPolicy(s.ctx, "folder-123", policy, "tenant-123", "user-123")
+
+	// Assertions
+	assert.Error(s.T(), err)
+	assert.True(s.T(), errors.IsValidationError(err), "Expected validation error")
+	s.mockFolderService.AssertExpectations(s.T())
+}
+
 // Helper function to create a test folder
 func (s *FolderUseCaseTestSuite) createTestFolder(id, name, parentID, path, tenantID, ownerID string) *models.Folder {
 	folder := models.NewFolder(name, parentID, tenantID, ownerID)
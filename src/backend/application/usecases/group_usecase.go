@@ -0,0 +1,280 @@
+// Package usecases implements the application layer of the Document Management Platform.
+// It contains use case implementations that orchestrate domain models and services.
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"../../domain/models"
+	"../../domain/services"
+	"../../pkg/errors"
+	"../../pkg/logger"
+	"../../pkg/utils"
+)
+
+// FolderPermissionGranter grants folder permissions to a role, user or group. It is implemented by FolderUseCase.
+type FolderPermissionGranter interface {
+	CreateFolderPermission(ctx context.Context, folderID, granteeType, granteeID, permissionType, tenantID, userID string) (string, error)
+}
+
+// GroupUseCase defines the contract for user group management application use cases
+type GroupUseCase interface {
+	// CreateGroup creates a group in a tenant
+	CreateGroup(ctx context.Context, tenantID, name, description, userID string) (*models.Group, error)
+
+	// GetGroup retrieves a group by its ID
+	GetGroup(ctx context.Context, id string, tenantID string) (*models.Group, error)
+
+	// ListGroups lists a tenant's groups with pagination
+	ListGroups(ctx context.Context, tenantID string, page int, pageSize int) (utils.PaginatedResult[models.Group], error)
+
+	// UpdateGroup updates the name and description of a group
+	UpdateGroup(ctx context.Context, id, tenantID, name, description string) (*models.Group, error)
+
+	// DeleteGroup deletes a group
+	DeleteGroup(ctx context.Context, id string, tenantID string) error
+
+	// AddMember adds a user to a group
+	AddMember(ctx context.Context, groupID, memberID, tenantID, userID string) error
+
+	// RemoveMember removes a user from a group
+	RemoveMember(ctx context.Context, groupID, memberID, tenantID string) error
+
+	// ListMembers lists the members of a group with pagination
+	ListMembers(ctx context.Context, groupID, tenantID string, page int, pageSize int) (utils.PaginatedResult[models.GroupMembership], error)
+
+	// GrantFolderPermission grants every member of a group a permission on a folder
+	GrantFolderPermission(ctx context.Context, groupID, folderID, permissionType, tenantID, userID string) (string, error)
+}
+
+// groupUseCase implements the GroupUseCase interface
+type groupUseCase struct {
+	groupService      services.GroupService
+	folderPermissions FolderPermissionGranter
+}
+
+// NewGroupUseCase creates a new GroupUseCase instance
+func NewGroupUseCase(groupService services.GroupService, folderPermissions FolderPermissionGranter) (GroupUseCase, error) {
+	if groupService == nil {
+		return nil, fmt.Errorf("group service cannot be nil")
+	}
+	if folderPermissions == nil {
+		return nil, fmt.Errorf("folder permission granter cannot be nil")
+	}
+
+	return &groupUseCase{
+		groupService:      groupService,
+		folderPermissions: folderPermissions,
+	}, nil
+}
+
+// CreateGroup creates a group in a tenant
+func (u *groupUseCase) CreateGroup(ctx context.Context, tenantID, name, description, userID string) (*models.Group, error) {
+	log := logger.WithContext(ctx)
+
+	if err := u.validateInput(map[string]string{
+		"tenant ID":  tenantID,
+		"group name": name,
+		"user ID":    userID,
+	}); err != nil {
+		return nil, err
+	}
+
+	group, err := u.groupService.CreateGroup(ctx, tenantID, name, description, userID)
+	if err != nil {
+		log.WithError(err).Error("failed to create group", "tenantID", tenantID, "name", name)
+		return nil, errors.Wrap(err, "failed to create group")
+	}
+
+	log.Info("group created successfully", "groupID", group.ID, "tenantID", tenantID)
+	return group, nil
+}
+
+// GetGroup retrieves a group by its ID
+func (u *groupUseCase) GetGroup(ctx context.Context, id string, tenantID string) (*models.Group, error) {
+	log := logger.WithContext(ctx)
+
+	if err := u.validateInput(map[string]string{
+		"group ID":  id,
+		"tenant ID": tenantID,
+	}); err != nil {
+		return nil, err
+	}
+
+	group, err := u.groupService.GetGroup(ctx, id, tenantID)
+	if err != nil {
+		log.WithError(err).Error("failed to get group", "id", id, "tenantID", tenantID)
+		return nil, errors.Wrap(err, "failed to get group")
+	}
+
+	return group, nil
+}
+
+// ListGroups lists a tenant's groups with pagination
+func (u *groupUseCase) ListGroups(ctx context.Context, tenantID string, page int, pageSize int) (utils.PaginatedResult[models.Group], error) {
+	log := logger.WithContext(ctx)
+
+	if tenantID == "" {
+		log.Error("tenant ID cannot be empty")
+		return utils.PaginatedResult[models.Group]{}, errors.NewValidationError("tenant ID is required")
+	}
+
+	pagination := utils.NewPagination(page, pageSize)
+
+	result, err := u.groupService.ListGroups(ctx, tenantID, pagination)
+	if err != nil {
+		log.WithError(err).Error("failed to list groups", "tenantID", tenantID)
+		return utils.PaginatedResult[models.Group]{}, errors.Wrap(err, "failed to list groups")
+	}
+
+	log.Info("groups listed successfully", "tenantID", tenantID, "count", len(result.Items))
+	return result, nil
+}
+
+// UpdateGroup updates the name and description of a group
+func (u *groupUseCase) UpdateGroup(ctx context.Context, id, tenantID, name, description string) (*models.Group, error) {
+	log := logger.WithContext(ctx)
+
+	if err := u.validateInput(map[string]string{
+		"group ID":  id,
+		"tenant ID": tenantID,
+	}); err != nil {
+		return nil, err
+	}
+
+	group, err := u.groupService.UpdateGroup(ctx, id, tenantID, name, description)
+	if err != nil {
+		log.WithError(err).Error("failed to update group", "id", id, "tenantID", tenantID)
+		return nil, errors.Wrap(err, "failed to update group")
+	}
+
+	log.Info("group updated successfully", "groupID", id, "tenantID", tenantID)
+	return group, nil
+}
+
+// DeleteGroup deletes a group
+func (u *groupUseCase) DeleteGroup(ctx context.Context, id string, tenantID string) error {
+	log := logger.WithContext(ctx)
+
+	if err := u.validateInput(map[string]string{
+		"group ID":  id,
+		"tenant ID": tenantID,
+	}); err != nil {
+		return err
+	}
+
+	if err := u.groupService.DeleteGroup(ctx, id, tenantID); err != nil {
+		log.WithError(err).Error("failed to delete group", "id", id, "tenantID", tenantID)
+		return errors.Wrap(err, "failed to delete group")
+	}
+
+	log.Info("group deleted successfully", "groupID", id, "tenantID", tenantID)
+	return nil
+}
+
+// AddMember adds a user to a group
+func (u *groupUseCase) AddMember(ctx context.Context, groupID, memberID, tenantID, userID string) error {
+	log := logger.WithContext(ctx)
+
+	if err := u.validateInput(map[string]string{
+		"group ID":  groupID,
+		"member ID": memberID,
+		"tenant ID": tenantID,
+		"user ID":   userID,
+	}); err != nil {
+		return err
+	}
+
+	if err := u.groupService.AddMember(ctx, groupID, memberID, tenantID, userID); err != nil {
+		log.WithError(err).Error("failed to add group member", "groupID", groupID, "memberID", memberID, "tenantID", tenantID)
+		return errors.Wrap(err, "failed to add group member")
+	}
+
+	log.Info("group member added successfully", "groupID", groupID, "memberID", memberID, "tenantID", tenantID)
+	return nil
+}
+
+// RemoveMember removes a user from a group
+func (u *groupUseCase) RemoveMember(ctx context.Context, groupID, memberID, tenantID string) error {
+	log := logger.WithContext(ctx)
+
+	if err := u.validateInput(map[string]string{
+		"group ID":  groupID,
+		"member ID": memberID,
+		"tenant ID": tenantID,
+	}); err != nil {
+		return err
+	}
+
+	if err := u.groupService.RemoveMember(ctx, groupID, memberID, tenantID); err != nil {
+		log.WithError(err).Error("failed to remove group member", "groupID", groupID, "memberID", memberID, "tenantID", tenantID)
+		return errors.Wrap(err, "failed to remove group member")
+	}
+
+	log.Info("group member removed successfully", "groupID", groupID, "memberID", memberID, "tenantID", tenantID)
+	return nil
+}
+
+// ListMembers lists the members of a group with pagination
+func (u *groupUseCase) ListMembers(ctx context.Context, groupID, tenantID string, page int, pageSize int) (utils.PaginatedResult[models.GroupMembership], error) {
+	log := logger.WithContext(ctx)
+
+	if err := u.validateInput(map[string]string{
+		"group ID":  groupID,
+		"tenant ID": tenantID,
+	}); err != nil {
+		return utils.PaginatedResult[models.GroupMembership]{}, err
+	}
+
+	pagination := utils.NewPagination(page, pageSize)
+
+	result, err := u.groupService.ListMembers(ctx, groupID, tenantID, pagination)
+	if err != nil {
+		log.WithError(err).Error("failed to list group members", "groupID", groupID, "tenantID", tenantID)
+		return utils.PaginatedResult[models.GroupMembership]{}, errors.Wrap(err, "failed to list group members")
+	}
+
+	return result, nil
+}
+
+// GrantFolderPermission grants every member of a group a permission on a folder.
+// The folder permission checks apply: the caller needs admin access to the folder.
+func (u *groupUseCase) GrantFolderPermission(ctx context.Context, groupID, folderID, permissionType, tenantID, userID string) (string, error) {
+	log := logger.WithContext(ctx)
+
+	if err := u.validateInput(map[string]string{
+		"group ID":        groupID,
+		"folder ID":       folderID,
+		"permission type": permissionType,
+		"tenant ID":       tenantID,
+		"user ID":         userID,
+	}); err != nil {
+		return "", err
+	}
+
+	// Make sure the group exists in the tenant before granting it access
+	if _, err := u.groupService.GetGroup(ctx, groupID, tenantID); err != nil {
+		log.WithError(err).Error("failed to get group", "groupID", groupID, "tenantID", tenantID)
+		return "", errors.Wrap(err, "failed to get group")
+	}
+
+	permissionID, err := u.folderPermissions.CreateFolderPermission(ctx, folderID, models.GranteeTypeGroup, groupID, permissionType, tenantID, userID)
+	if err != nil {
+		log.WithError(err).Error("failed to grant folder permission to group", "groupID", groupID, "folderID", folderID)
+		return "", errors.Wrap(err, "failed to grant folder permission to group")
+	}
+
+	log.Info("folder permission granted to group", "groupID", groupID, "folderID", folderID, "permissionID", permissionID)
+	return permissionID, nil
+}
+
+// validateInput validates that required input parameters are not empty
+func (u *groupUseCase) validateInput(params map[string]string) error {
+	for name, value := range params {
+		if value == "" {
+			return errors.NewValidationError(fmt.Sprintf("%s cannot be empty", name))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert" // v1.8.0+
+	"github.com/stretchr/testify/mock"   // v1.8.0+
+	"github.com/stretchr/testify/suite"  // v1.8.0+
+
+	"../../domain/models"
+	pkgErrors "../../pkg/errors"
+	"../../pkg/utils"
+)
+
+// MockGroupService is a mock implementation of the GroupService interface for testing
+type MockGroupService struct {
+	mock.Mock
+}
+
+// CreateGroup mock implementation for creating a group
+func (m *MockGroupService) CreateGroup(ctx context.Context, tenantID, name, description, createdBy string) (*models.Group, error) {
+	args := m.Called(ctx, tenantID, name, description, createdBy)
+	if group := args.Get(0); group != nil {
+		return group.(*models.Group), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// GetGroup mock implementation for retrieving a group
+func (m *MockGroupService) GetGroup(ctx context.Context, id string, tenantID string) (*models.Group, error) {
+	args := m.Called(ctx, id, tenantID)
+	if group := args.Get(0); group != nil {
+		return group.(*models.Group), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// ListGroups mock implementation for listing groups
+func (m *MockGroupService) ListGroups(ctx context.Context, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Group], error) {
+	args := m.Called(ctx, tenantID, pagination)
+	return args.Get(0).(utils.PaginatedResult[models.Group]), args.Error(1)
+}
+
+// UpdateGroup mock implementation for updating a group
+func (m *MockGroupService) UpdateGroup(ctx context.Context, id, tenantID, name, description string) (*models.Group, error) {
+	args := m.Called(ctx, id, tenantID, name, description)
+	if group := args.Get(0); group != nil {
+		return group.(*models.Group), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// DeleteGroup mock implementation for deleting a group
+func (m *MockGroupService) DeleteGroup(ctx context.Context, id string, tenantID string) error {
+	args := m.Called(ctx, id, tenantID)
+	return args.Error(0)
+}
+
+// AddMember mock implementation for adding a group member
+func (m *MockGroupService) AddMember(ctx context.Context, groupID, userID, tenantID, addedBy string) error {
+	args := m.Called(ctx, groupID, userID, tenantID, addedBy)
+	return args.Error(0)
+}
+
+// RemoveMember mock implementation for removing a group member
+func (m *MockGroupService) RemoveMember(ctx context.Context, groupID, userID, tenantID string) error {
+	args := m.Called(ctx, groupID, userID, tenantID)
+	return args.Error(0)
+}
+
+// ListMembers mock implementation for listing group members
+func (m *MockGroupService) ListMembers(ctx context.Context, groupID, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.GroupMembership], error) {
+	args := m.Called(ctx, groupID, tenantID, pagination)
+	return args.Get(0).(utils.PaginatedResult[models.GroupMembership]), args.Error(1)
+}
+
+// MockFolderPermissionGranter is a mock implementation of the FolderPermissionGranter interface for testing
+type MockFolderPermissionGranter struct {
+	mock.Mock
+}
+
+// CreateFolderPermission mock implementation for granting a folder permission
+func (m *MockFolderPermissionGranter) CreateFolderPermission(ctx context.Context, folderID, granteeType, granteeID, permissionType, tenantID, userID string) (string, error) {
+	args := m.Called(ctx, folderID, granteeType, granteeID, permissionType, tenantID, userID)
+	return args.String(0), args.Error(1)
+}
+
+// GroupUseCaseTestSuite defines a test suite for GroupUseCase
+type GroupUseCaseTestSuite struct {
+	suite.Suite
+	mockGroupService      *MockGroupService
+	mockFolderPermissions *MockFolderPermissionGranter
+	groupUseCase          GroupUseCase
+}
+
+// SetupTest sets up the test environment before each test
+func (s *GroupUseCaseTestSuite) SetupTest() {
+	s.mockGroupService = new(MockGroupService)
+	s.mockFolderPermissions = new(MockFolderPermissionGranter)
+
+	var err error
+	s.groupUseCase, err = NewGroupUseCase(s.mockGroupService, s.mockFolderPermissions)
+	assert.Nil(s.T(), err)
+	assert.NotNil(s.T(), s.groupUseCase)
+}
+
+// TestNewGroupUseCase tests the creation of a new GroupUseCase
+func (s *GroupUseCaseTestSuite) TestNewGroupUseCase() {
+	useCase, err := NewGroupUseCase(nil, s.mockFolderPermissions)
+	assert.NotNil(s.T(), err)
+	assert.Nil(s.T(), useCase)
+
+	useCase, err = NewGroupUseCase(s.mockGroupService, nil)
+	assert.NotNil(s.T(), err)
+	assert.Nil(s.T(), useCase)
+}
+
+// TestCreateGroup_Success tests successful group creation
+func (s *GroupUseCaseTestSuite) TestCreateGroup_Success() {
+	group := &models.Group{ID: "group123", TenantID: "tenant123", Name: "legal", Description: "Legal team", CreatedBy: "user123"}
+	s.mockGroupService.On("CreateGroup", mock.Anything, "tenant123", "legal", "Legal team", "user123").Return(group, nil)
+
+	result, err := s.groupUseCase.CreateGroup(context.Background(), "tenant123", "legal", "Legal team", "user123")
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), group, result)
+	s.mockGroupService.AssertExpectations(s.T())
+}
+
+// TestCreateGroup_ValidationError tests group creation without a name
+func (s *GroupUseCaseTestSuite) TestCreateGroup_ValidationError() {
+	result, err := s.groupUseCase.CreateGroup(context.Background(), "tenant123", "", "Legal team", "user123")
+	assert.Nil(s.T(), result)
+	assert.True(s.T(), pkgErrors.IsValidationError(err))
+
+	s.mockGroupService.AssertNotCalled(s.T(), "CreateGroup")
+}
+
+// TestAddMember_Success tests adding a user to a group
+func (s *GroupUseCaseTestSuite) TestAddMember_Success() {
+	s.mockGroupService.On("AddMember", mock.Anything, "group123", "member123", "tenant123", "user123").Return(nil)
+
+	err := s.groupUseCase.AddMember(context.Background(), "group123", "member123", "tenant123", "user123")
+
+	assert.Nil(s.T(), err)
+	s.mockGroupService.AssertExpectations(s.T())
+}
+
+// TestRemoveMember_NotFound tests removing a user who is not a member
+func (s *GroupUseCaseTestSuite) TestRemoveMember_NotFound() {
+	s.mockGroupService.On("RemoveMember", mock.Anything, "group123", "member123", "tenant123").
+		Return(pkgErrors.NewResourceNotFoundError("Group member not found"))
+
+	err := s.groupUseCase.RemoveMember(context.Background(), "group123", "member123", "tenant123")
+
+	assert.True(s.T(), pkgErrors.IsResourceNotFoundError(err))
+	s.mockGroupService.AssertExpectations(s.T())
+}
+
+// TestGrantFolderPermission_Success tests granting a folder permission to a group
+func (s *GroupUseCaseTestSuite) TestGrantFolderPermission_Success() {
+	group := &models.Group{ID: "group123", TenantID: "tenant123", Name: "legal"}
+	s.mockGroupService.On("GetGroup", mock.Anything, "group123", "tenant123").Return(group, nil)
+	s.mockFolderPermissions.On("CreateFolderPermission", mock.Anything, "folder123", models.GranteeTypeGroup, "group123", models.PermissionTypeWrite, "tenant123", "user123").Return("perm123", nil)
+
+	permissionID, err := s.groupUseCase.GrantFolderPermission(context.Background(), "group123", "folder123", models.PermissionTypeWrite, "tenant123", "user123")
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), "perm123", permissionID)
+	s.mockGroupService.AssertExpectations(s.T())
+	s.mockFolderPermissions.AssertExpectations(s.T())
+}
+
+// TestGrantFolderPermission_GroupNotFound tests granting a folder permission to a missing group
+func (s *GroupUseCaseTestSuite) TestGrantFolderPermission_GroupNotFound() {
+	s.mockGroupService.On("GetGroup", mock.Anything, "missing", "tenant123").Return(nil, pkgErrors.NewResourceNotFoundError("Group not found"))
+
+	permissionID, err := s.groupUseCase.GrantFolderPermission(context.Background(), "missing", "folder123", models.PermissionTypeRead, "tenant123", "user123")
+
+	assert.Empty(s.T(), permissionID)
+	assert.True(s.T(), pkgErrors.IsResourceNotFoundError(err))
+	s.mockFolderPermissions.AssertNotCalled(s.T(), "CreateFolderPermission")
+}
+
+// TestGroupUseCaseSuite entry point for running the GroupUseCase test suite
+func TestGroupUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(GroupUseCaseTestSuite))
+}
@@ -0,0 +1,219 @@
+// Package usecases implements the application layer of the Document Management Platform.
+// It contains use case implementations that orchestrate domain models and services.
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"../../domain/models"
+	"../../domain/repositories"
+	"../../domain/services"
+	"../../pkg/errors"
+	"../../pkg/logger"
+	"../../pkg/utils"
+)
+
+// guestDownloadURLExpiry is the lifetime in seconds of the presigned URLs handed out to guests
+const guestDownloadURLExpiry = 300
+
+// ErrGuestDocumentNotFound is returned for documents outside the guest's scope as well as missing
+// documents, so that a guest cannot probe for other documents of the tenant
+var ErrGuestDocumentNotFound = errors.NewResourceNotFoundError("document not found")
+
+// GuestUseCase defines the contract for read-only access by external guests holding a guest token
+type GuestUseCase interface {
+	// ListDocuments lists the documents covered by the guest scope: the shared document, or the
+	// documents directly in the shared folder
+	ListDocuments(ctx context.Context, scope *models.GuestScope, page, pageSize int) (utils.PaginatedResult[models.Document], error)
+
+	// GetDocument retrieves a document covered by the guest scope
+	GetDocument(ctx context.Context, scope *models.GuestScope, documentID string) (*models.Document, error)
+
+	// GetDocumentDownloadURL generates a short-lived presigned download URL for a document covered by the guest scope.
+	// Documents watermarked on download have no presigned URL and return ErrWatermarkRequired. Documents
+	// in folders that are view-only or require a recent sign-in cannot be downloaded by guests.
+	GetDocumentDownloadURL(ctx context.Context, scope *models.GuestScope, documentID string) (string, error)
+}
+
+// guestUseCase implements the GuestUseCase interface
+type guestUseCase struct {
+	documentRepo          repositories.DocumentRepository
+	storageService        services.StorageService
+	watermarkService      services.WatermarkService
+	downloadPolicyService services.DownloadPolicyService
+	auditService          services.AuditService
+}
+
+// NewGuestUseCase creates a new GuestUseCase instance
+func NewGuestUseCase(
+	documentRepo repositories.DocumentRepository,
+	storageService services.StorageService,
+	watermarkService services.WatermarkService,
+	downloadPolicyService services.DownloadPolicyService,
+	auditService services.AuditService,
+) (GuestUseCase, error) {
+	if documentRepo == nil {
+		return nil, fmt.Errorf("document repository cannot be nil")
+	}
+	if storageService == nil {
+		return nil, fmt.Errorf("storage service cannot be nil")
+	}
+	if watermarkService == nil {
+		return nil, fmt.Errorf("watermark service cannot be nil")
+	}
+	if downloadPolicyService == nil {
+		return nil, fmt.Errorf("download policy service cannot be nil")
+	}
+	if auditService == nil {
+		return nil, fmt.Errorf("audit service cannot be nil")
+	}
+
+	return &guestUseCase{
+		documentRepo:          documentRepo,
+		storageService:        storageService,
+		watermarkService:      watermarkService,
+		downloadPolicyService: downloadPolicyService,
+		auditService:          auditService,
+	}, nil
+}
+
+// ListDocuments lists the documents covered by the guest scope
+func (u *guestUseCase) ListDocuments(ctx context.Context, scope *models.GuestScope, page, pageSize int) (utils.PaginatedResult[models.Document], error) {
+	log := logger.WithContext(ctx)
+
+	if scope == nil {
+		return utils.PaginatedResult[models.Document]{}, errors.NewAuthenticationError("guest scope required")
+	}
+
+	pagination := utils.NewPagination(page, pageSize)
+
+	if scope.ResourceType == models.ResourceTypeDocument {
+		document, err := u.GetDocument(ctx, scope, scope.ResourceID)
+		if err != nil {
+			if errors.IsResourceNotFoundError(err) {
+				return utils.NewPaginatedResult([]models.Document{}, pagination, 0), nil
+			}
+			return utils.PaginatedResult[models.Document]{}, err
+		}
+		return utils.NewPaginatedResult([]models.Document{*document}, pagination, 1), nil
+	}
+
+	result, err := u.documentRepo.ListByFolder(ctx, scope.ResourceID, scope.TenantID, pagination)
+	if err != nil {
+		log.WithError(err).Error("failed to list shared folder documents", "folderID", scope.ResourceID)
+		return utils.PaginatedResult[models.Document]{}, errors.Wrap(err, "failed to list documents")
+	}
+
+	// Guests only see documents that are published
+	published := result.Items[:0]
+	for _, document := range result.Items {
+		if document.IsPublished() {
+			published = append(published, document)
+		}
+	}
+	result.Items = published
+
+	return result, nil
+}
+
+// GetDocument retrieves a document covered by the guest scope
+func (u *guestUseCase) GetDocument(ctx context.Context, scope *models.GuestScope, documentID string) (*models.Document, error) {
+	log := logger.WithContext(ctx)
+
+	if scope == nil {
+		return nil, errors.NewAuthenticationError("guest scope required")
+	}
+	if documentID == "" {
+		return nil, errors.NewValidationError("document ID cannot be empty")
+	}
+
+	document, err := u.documentRepo.GetByID(ctx, documentID, scope.TenantID)
+	if err != nil {
+		if errors.IsResourceNotFoundError(err) {
+			return nil, ErrGuestDocumentNotFound
+		}
+		log.WithError(err).Error("failed to get document", "documentID", documentID)
+		return nil, errors.Wrap(err, "failed to get document")
+	}
+
+	if !scope.AllowsDocument(document, models.PermissionTypeRead) {
+		log.Info("document is outside the guest scope", "documentID", documentID, "guestEmail", scope.GuestEmail)
+		return nil, ErrGuestDocumentNotFound
+	}
+
+	// Guests only see documents that are published
+	if !document.IsPublished() {
+		log.Info("shared document is not published", "documentID", documentID, "visibility", document.Visibility)
+		return nil, ErrGuestDocumentNotFound
+	}
+
+	return document, nil
+}
+
+// GetDocumentDownloadURL generates a short-lived presigned download URL for a document covered by the guest scope
+func (u *guestUseCase) GetDocumentDownloadURL(ctx context.Context, scope *models.GuestScope, documentID string) (string, error) {
+	log := logger.WithContext(ctx)
+
+	document, err := u.GetDocument(ctx, scope, documentID)
+	if err != nil {
+		return "", err
+	}
+
+	if !document.IsAvailable() {
+		log.Error("shared document is not available for download", "documentID", document.ID, "status", document.Status)
+		return "", ErrDocumentNotAvailable
+	}
+
+	// Guests only get presigned URLs, which cannot be stamped
+	watermarked, err := u.watermarkService.RequiresWatermark(ctx, document, nil)
+	if err != nil {
+		log.WithError(err).Error("failed to check if shared document is watermarked", "documentID", document.ID)
+		return "", errors.Wrap(err, "failed to check if document is watermarked")
+	}
+	if watermarked {
+		log.Info("guest download refused for watermarked document", "documentID", document.ID)
+		return "", ErrWatermarkRequired
+	}
+
+	policy, err := u.downloadPolicyService.CheckExternalDownload(ctx, document, map[string]interface{}{
+		"guest_email": scope.GuestEmail,
+		"invited_by":  scope.InvitedBy,
+	})
+	if err != nil {
+		if policy == nil {
+			log.WithError(err).Error("failed to check folder download policy", "documentID", document.ID)
+		}
+		return "", err
+	}
+
+	latestVersion := document.GetLatestVersion()
+	if latestVersion == nil {
+		log.Error("no versions found for shared document", "documentID", document.ID)
+		return "", errors.NewResourceNotFoundError("no versions found for document")
+	}
+
+	presignedURL, err := u.storageService.GetPresignedURL(ctx, latestVersion.StoragePath, document.Name, guestDownloadURLExpiry)
+	if err != nil {
+		log.WithError(err).Error("failed to generate presigned URL", "documentID", document.ID)
+		return "", errors.Wrap(err, "failed to generate presigned URL")
+	}
+
+	// Guests have no user account, so the audit entry has no actor and records the guest instead
+	auditDetails := map[string]interface{}{
+		"name":        document.Name,
+		"guest_email": scope.GuestEmail,
+		"invited_by":  scope.InvitedBy,
+	}
+	if !policy.Policy.IsEmpty() {
+		auditDetails["policy"] = policy.AuditDetails()
+	}
+	err = u.auditService.RecordAction(ctx, scope.TenantID, "", models.AuditActionDownload, models.ResourceTypeDocument, document.ID, nil, auditDetails)
+	if err != nil {
+		log.WithError(err).Error("failed to record guest download in audit log")
+		// Do not return error, the download has already been granted
+	}
+
+	log.Info("guest downloaded document", "documentID", document.ID, "tenantID", scope.TenantID)
+	return presignedURL, nil
+}
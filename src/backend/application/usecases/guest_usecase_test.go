@@ -0,0 +1,201 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert" // v1.8.0+
+	"github.com/stretchr/testify/mock"   // v1.8.0+
+	"github.com/stretchr/testify/suite"  // v1.8.0+
+
+	"../../domain/models"
+	"../../domain/repositories"
+	"../../domain/services"
+	pkgErrors "../../pkg/errors"
+	"../../pkg/utils"
+)
+
+// mockGuestDocumentRepository mocks the DocumentRepository methods used by guest access
+type mockGuestDocumentRepository struct {
+	repositories.DocumentRepository
+	mock.Mock
+}
+
+func (m *mockGuestDocumentRepository) GetByID(ctx context.Context, id string, tenantID string) (*models.Document, error) {
+	args := m.Called(ctx, id, tenantID)
+	if document := args.Get(0); document != nil {
+		return document.(*models.Document), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *mockGuestDocumentRepository) ListByFolder(ctx context.Context, folderID string, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Document], error) {
+	args := m.Called(ctx, folderID, tenantID, pagination)
+	return args.Get(0).(utils.PaginatedResult[models.Document]), args.Error(1)
+}
+
+// GuestUseCaseTestSuite defines a test suite for GuestUseCase
+type GuestUseCaseTestSuite struct {
+	suite.Suite
+	mockDocumentRepo   *mockGuestDocumentRepository
+	mockStorageService *mockShareStorageService
+	watermarkService   *stubWatermarkService
+	downloadPolicy     *stubDownloadPolicyService
+	mockAuditService   *MockAuditService
+	guestUseCase       GuestUseCase
+}
+
+// SetupTest sets up the test environment before each test
+func (s *GuestUseCaseTestSuite) SetupTest() {
+	s.mockDocumentRepo = new(mockGuestDocumentRepository)
+	s.mockStorageService = new(mockShareStorageService)
+	s.watermarkService = &stubWatermarkService{}
+	s.downloadPolicy = &stubDownloadPolicyService{}
+	s.mockAuditService = new(MockAuditService)
+	s.mockAuditService.On("RecordAction", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	var err error
+	s.guestUseCase, err = NewGuestUseCase(s.mockDocumentRepo, s.mockStorageService, s.watermarkService, s.downloadPolicy, s.mockAuditService)
+	assert.Nil(s.T(), err)
+}
+
+// createTestDocument returns an available document in folder123 with a single version
+func (s *GuestUseCaseTestSuite) createTestDocument(id string) *models.Document {
+	return &models.Document{
+		ID:       id,
+		Name:     "contract.pdf",
+		TenantID: "tenant123",
+		FolderID: "folder123",
+		Status:   models.DocumentStatusAvailable,
+		Versions: []models.DocumentVersion{{ID: "v1", DocumentID: id, VersionNumber: 1, StoragePath: "tenant123/" + id + "/v1"}},
+	}
+}
+
+// createTestScope returns a guest scope for the resource
+func (s *GuestUseCaseTestSuite) createTestScope(resourceType, resourceID string) *models.GuestScope {
+	return models.NewGuestScope("tenant123", "reviewer@example.com", resourceType, resourceID, "user123", time.Now().Add(time.Hour))
+}
+
+// TestGetDocumentDownloadURL_DocumentScope tests downloading the shared document
+func (s *GuestUseCaseTestSuite) TestGetDocumentDownloadURL_DocumentScope() {
+	ctx := context.Background()
+	scope := s.createTestScope(models.ResourceTypeDocument, "doc123")
+
+	s.mockDocumentRepo.On("GetByID", ctx, "doc123", "tenant123").Return(s.createTestDocument("doc123"), nil)
+	s.mockStorageService.On("GetPresignedURL", ctx, "tenant123/doc123/v1", "contract.pdf", guestDownloadURLExpiry).Return("https://s3.example.com/signed", nil)
+
+	url, err := s.guestUseCase.GetDocumentDownloadURL(ctx, scope, "doc123")
+
+	s.NoError(err)
+	s.Equal("https://s3.example.com/signed", url)
+	s.mockAuditService.AssertCalled(s.T(), "RecordAction", ctx, "tenant123", "", models.AuditActionDownload, models.ResourceTypeDocument, "doc123", mock.Anything, mock.Anything)
+}
+
+// TestGetDocumentDownloadURL_FolderScope tests downloading a document in the shared folder
+func (s *GuestUseCaseTestSuite) TestGetDocumentDownloadURL_FolderScope() {
+	ctx := context.Background()
+	scope := s.createTestScope(models.ResourceTypeFolder, "folder123")
+
+	s.mockDocumentRepo.On("GetByID", ctx, "doc456", "tenant123").Return(s.createTestDocument("doc456"), nil)
+	s.mockStorageService.On("GetPresignedURL", ctx, "tenant123/doc456/v1", "contract.pdf", guestDownloadURLExpiry).Return("https://s3.example.com/signed", nil)
+
+	url, err := s.guestUseCase.GetDocumentDownloadURL(ctx, scope, "doc456")
+
+	s.NoError(err)
+	s.Equal("https://s3.example.com/signed", url)
+}
+
+// TestGetDocumentDownloadURL_Watermarked tests that guests cannot download documents that must be stamped
+func (s *GuestUseCaseTestSuite) TestGetDocumentDownloadURL_Watermarked() {
+	ctx := context.Background()
+	scope := s.createTestScope(models.ResourceTypeFolder, "folder123")
+	s.watermarkService.required = true
+
+	s.mockDocumentRepo.On("GetByID", ctx, "doc456", "tenant123").Return(s.createTestDocument("doc456"), nil)
+
+	url, err := s.guestUseCase.GetDocumentDownloadURL(ctx, scope, "doc456")
+
+	s.Empty(url)
+	s.Equal(ErrWatermarkRequired, err)
+	s.mockStorageService.AssertNotCalled(s.T(), "GetPresignedURL", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestGetDocumentDownloadURL_SignInRequired tests that guests cannot download documents of folders
+// requiring a recent sign-in
+func (s *GuestUseCaseTestSuite) TestGetDocumentDownloadURL_SignInRequired() {
+	ctx := context.Background()
+	scope := s.createTestScope(models.ResourceTypeFolder, "folder123")
+	s.downloadPolicy.err = services.ErrDownloadSignInRequired
+
+	s.mockDocumentRepo.On("GetByID", ctx, "doc456", "tenant123").Return(s.createTestDocument("doc456"), nil)
+
+	url, err := s.guestUseCase.GetDocumentDownloadURL(ctx, scope, "doc456")
+
+	s.Empty(url)
+	s.Equal(services.ErrDownloadSignInRequired, err)
+	s.mockStorageService.AssertNotCalled(s.T(), "GetPresignedURL", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestGetDocument_OutsideScope tests that documents outside the scope are reported as not found
+func (s *GuestUseCaseTestSuite) TestGetDocument_OutsideScope() {
+	ctx := context.Background()
+	scope := s.createTestScope(models.ResourceTypeDocument, "doc123")
+
+	other := s.createTestDocument("doc999")
+	s.mockDocumentRepo.On("GetByID", ctx, "doc999", "tenant123").Return(other, nil)
+
+	document, err := s.guestUseCase.GetDocument(ctx, scope, "doc999")
+
+	s.Nil(document)
+	s.True(pkgErrors.IsResourceNotFoundError(err))
+	s.mockStorageService.AssertNotCalled(s.T(), "GetPresignedURL", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestGetDocument_OtherFolder tests that a folder scope does not cover documents in other folders
+func (s *GuestUseCaseTestSuite) TestGetDocument_OtherFolder() {
+	ctx := context.Background()
+	scope := s.createTestScope(models.ResourceTypeFolder, "folder456")
+
+	s.mockDocumentRepo.On("GetByID", ctx, "doc123", "tenant123").Return(s.createTestDocument("doc123"), nil)
+
+	document, err := s.guestUseCase.GetDocument(ctx, scope, "doc123")
+
+	s.Nil(document)
+	s.True(pkgErrors.IsResourceNotFoundError(err))
+}
+
+// TestListDocuments_FolderScope tests listing the documents of the shared folder
+func (s *GuestUseCaseTestSuite) TestListDocuments_FolderScope() {
+	ctx := context.Background()
+	scope := s.createTestScope(models.ResourceTypeFolder, "folder123")
+
+	pagination := utils.NewPagination(1, 10)
+	expected := utils.NewPaginatedResult([]models.Document{*s.createTestDocument("doc123")}, pagination, 1)
+	s.mockDocumentRepo.On("ListByFolder", ctx, "folder123", "tenant123", pagination).Return(expected, nil)
+
+	result, err := s.guestUseCase.ListDocuments(ctx, scope, 1, 10)
+
+	s.NoError(err)
+	s.Len(result.Items, 1)
+	s.Equal("doc123", result.Items[0].ID)
+}
+
+// TestListDocuments_DocumentScope tests that a document scope lists only the shared document
+func (s *GuestUseCaseTestSuite) TestListDocuments_DocumentScope() {
+	ctx := context.Background()
+	scope := s.createTestScope(models.ResourceTypeDocument, "doc123")
+
+	s.mockDocumentRepo.On("GetByID", ctx, "doc123", "tenant123").Return(s.createTestDocument("doc123"), nil)
+
+	result, err := s.guestUseCase.ListDocuments(ctx, scope, 1, 10)
+
+	s.NoError(err)
+	s.Len(result.Items, 1)
+	s.mockDocumentRepo.AssertNotCalled(s.T(), "ListByFolder", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestGuestUseCaseSuite runs the guest use case test suite
+func TestGuestUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(GuestUseCaseTestSuite))
+}
@@ -0,0 +1,159 @@
+// Package usecases implements the application layer of the Document Management Platform.
+// It contains use case implementations that orchestrate domain models and services.
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"../../domain/models"
+	"../../domain/services"
+	"../../pkg/errors"
+	"../../pkg/logger"
+)
+
+// MetadataSchemaUseCase defines the contract for managing the metadata fields of a tenant's schema
+type MetadataSchemaUseCase interface {
+	// CreateField creates a new metadata field for a tenant
+	CreateField(ctx context.Context, field *models.MetadataField) (string, error)
+
+	// GetField retrieves a metadata field by its ID
+	GetField(ctx context.Context, id string, tenantID string) (*models.MetadataField, error)
+
+	// GetSchema retrieves all metadata fields of a tenant
+	GetSchema(ctx context.Context, tenantID string) (models.MetadataSchema, error)
+
+	// UpdateField replaces the definition of an existing metadata field
+	UpdateField(ctx context.Context, field *models.MetadataField) error
+
+	// DeleteField deletes a metadata field
+	DeleteField(ctx context.Context, id string, tenantID string) error
+}
+
+// metadataSchemaUseCase implements the MetadataSchemaUseCase interface
+type metadataSchemaUseCase struct {
+	schemaService services.MetadataSchemaService
+}
+
+// NewMetadataSchemaUseCase creates a new MetadataSchemaUseCase instance
+func NewMetadataSchemaUseCase(schemaService services.MetadataSchemaService) (MetadataSchemaUseCase, error) {
+	if schemaService == nil {
+		return nil, fmt.Errorf("metadata schema service cannot be nil")
+	}
+
+	return &metadataSchemaUseCase{
+		schemaService: schemaService,
+	}, nil
+}
+
+// CreateField creates a new metadata field for a tenant
+func (u *metadataSchemaUseCase) CreateField(ctx context.Context, field *models.MetadataField) (string, error) {
+	log := logger.WithContext(ctx)
+
+	if field == nil {
+		log.Error("metadata field cannot be nil")
+		return "", errors.NewValidationError("metadata field cannot be nil")
+	}
+
+	id, err := u.schemaService.CreateField(ctx, field)
+	if err != nil {
+		log.WithError(err).Error("failed to create metadata field", "tenantID", field.TenantID, "name", field.Name)
+		return "", errors.Wrap(err, "failed to create metadata field")
+	}
+
+	log.Info("metadata field created successfully", "fieldID", id, "tenantID", field.TenantID)
+	return id, nil
+}
+
+// GetField retrieves a metadata field by its ID
+func (u *metadataSchemaUseCase) GetField(ctx context.Context, id string, tenantID string) (*models.MetadataField, error) {
+	log := logger.WithContext(ctx)
+
+	if err := u.validateInput(map[string]string{
+		"metadata field ID": id,
+		"tenant ID":         tenantID,
+	}); err != nil {
+		return nil, err
+	}
+
+	field, err := u.schemaService.GetField(ctx, id, tenantID)
+	if err != nil {
+		log.WithError(err).Error("failed to get metadata field", "id", id, "tenantID", tenantID)
+		return nil, errors.Wrap(err, "failed to get metadata field")
+	}
+
+	return field, nil
+}
+
+// GetSchema retrieves all metadata fields of a tenant
+func (u *metadataSchemaUseCase) GetSchema(ctx context.Context, tenantID string) (models.MetadataSchema, error) {
+	log := logger.WithContext(ctx)
+
+	if tenantID == "" {
+		log.Error("tenant ID cannot be empty")
+		return nil, errors.NewValidationError("tenant ID is required")
+	}
+
+	schema, err := u.schemaService.GetSchema(ctx, tenantID)
+	if err != nil {
+		log.WithError(err).Error("failed to get metadata schema", "tenantID", tenantID)
+		return nil, errors.Wrap(err, "failed to get metadata schema")
+	}
+
+	return schema, nil
+}
+
+// UpdateField replaces the definition of an existing metadata field
+func (u *metadataSchemaUseCase) UpdateField(ctx context.Context, field *models.MetadataField) error {
+	log := logger.WithContext(ctx)
+
+	if field == nil {
+		log.Error("metadata field cannot be nil")
+		return errors.NewValidationError("metadata field cannot be nil")
+	}
+
+	if err := u.validateInput(map[string]string{
+		"metadata field ID": field.ID,
+		"tenant ID":         field.TenantID,
+	}); err != nil {
+		return err
+	}
+
+	if err := u.schemaService.UpdateField(ctx, field); err != nil {
+		log.WithError(err).Error("failed to update metadata field", "id", field.ID, "tenantID", field.TenantID)
+		return errors.Wrap(err, "failed to update metadata field")
+	}
+
+	log.Info("metadata field updated successfully", "fieldID", field.ID, "tenantID", field.TenantID)
+	return nil
+}
+
+// DeleteField deletes a metadata field
+func (u *metadataSchemaUseCase) DeleteField(ctx context.Context, id string, tenantID string) error {
+	log := logger.WithContext(ctx)
+
+	if err := u.validateInput(map[string]string{
+		"metadata field ID": id,
+		"tenant ID":         tenantID,
+	}); err != nil {
+		return err
+	}
+
+	if err := u.schemaService.DeleteField(ctx, id, tenantID); err != nil {
+		log.WithError(err).Error("failed to delete metadata field", "id", id, "tenantID", tenantID)
+		return errors.Wrap(err, "failed to delete metadata field")
+	}
+
+	log.Info("metadata field deleted successfully", "fieldID", id, "tenantID", tenantID)
+	return nil
+}
+
+// validateInput validates that required input parameters are not empty
+func (u *metadataSchemaUseCase) validateInput(params map[string]string) error {
+	for name, value := range params {
+		if value == "" {
+			return errors.NewValidationError(fmt.Sprintf("%s cannot be empty", name))
+		}
+	}
+	return nil
+}
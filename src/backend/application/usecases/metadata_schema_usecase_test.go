@@ -0,0 +1,146 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert" // v1.8.0+
+	"github.com/stretchr/testify/mock"   // v1.8.0+
+	"github.com/stretchr/testify/suite"  // v1.8.0+
+
+	"../../domain/models"
+	pkgErrors "../../pkg/errors"
+)
+
+// MockMetadataSchemaService is a mock implementation of the MetadataSchemaService interface for testing
+type MockMetadataSchemaService struct {
+	mock.Mock
+}
+
+// CreateField mock implementation for creating a metadata field
+func (m *MockMetadataSchemaService) CreateField(ctx context.Context, field *models.MetadataField) (string, error) {
+	args := m.Called(ctx, field)
+	return args.String(0), args.Error(1)
+}
+
+// GetField mock implementation for retrieving a metadata field
+func (m *MockMetadataSchemaService) GetField(ctx context.Context, id string, tenantID string) (*models.MetadataField, error) {
+	args := m.Called(ctx, id, tenantID)
+	if field := args.Get(0); field != nil {
+		return field.(*models.MetadataField), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// UpdateField mock implementation for updating a metadata field
+func (m *MockMetadataSchemaService) UpdateField(ctx context.Context, field *models.MetadataField) error {
+	args := m.Called(ctx, field)
+	return args.Error(0)
+}
+
+// DeleteField mock implementation for deleting a metadata field
+func (m *MockMetadataSchemaService) DeleteField(ctx context.Context, id string, tenantID string) error {
+	args := m.Called(ctx, id, tenantID)
+	return args.Error(0)
+}
+
+// GetSchema mock implementation for retrieving a tenant's metadata schema
+func (m *MockMetadataSchemaService) GetSchema(ctx context.Context, tenantID string) (models.MetadataSchema, error) {
+	args := m.Called(ctx, tenantID)
+	if schema := args.Get(0); schema != nil {
+		return schema.(models.MetadataSchema), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// ValidateMetadata mock implementation for validating document metadata
+func (m *MockMetadataSchemaService) ValidateMetadata(ctx context.Context, tenantID string, metadata map[string]string) error {
+	args := m.Called(ctx, tenantID, metadata)
+	return args.Error(0)
+}
+
+// MetadataSchemaUseCaseTestSuite defines a test suite for MetadataSchemaUseCase
+type MetadataSchemaUseCaseTestSuite struct {
+	suite.Suite
+	mockSchemaService     *MockMetadataSchemaService
+	metadataSchemaUseCase MetadataSchemaUseCase
+}
+
+// SetupTest sets up the test environment before each test
+func (s *MetadataSchemaUseCaseTestSuite) SetupTest() {
+	s.mockSchemaService = new(MockMetadataSchemaService)
+
+	var err error
+	s.metadataSchemaUseCase, err = NewMetadataSchemaUseCase(s.mockSchemaService)
+	assert.Nil(s.T(), err)
+	assert.NotNil(s.T(), s.metadataSchemaUseCase)
+}
+
+// TestNewMetadataSchemaUseCase tests the creation of a new MetadataSchemaUseCase
+func (s *MetadataSchemaUseCaseTestSuite) TestNewMetadataSchemaUseCase() {
+	useCase, err := NewMetadataSchemaUseCase(nil)
+	assert.NotNil(s.T(), err)
+	assert.Nil(s.T(), useCase)
+}
+
+// TestCreateField_Success tests successful metadata field creation
+func (s *MetadataSchemaUseCaseTestSuite) TestCreateField_Success() {
+	field := models.NewMetadataField("tenant123", "contract-value", models.MetadataFieldTypeNumber, true, nil, "user123")
+	s.mockSchemaService.On("CreateField", mock.Anything, field).Return("field123", nil)
+
+	id, err := s.metadataSchemaUseCase.CreateField(context.Background(), field)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), "field123", id)
+	s.mockSchemaService.AssertExpectations(s.T())
+}
+
+// TestCreateField_ServiceError tests metadata field creation rejected by the service
+func (s *MetadataSchemaUseCaseTestSuite) TestCreateField_ServiceError() {
+	field := models.NewMetadataField("tenant123", "status", models.MetadataFieldTypeEnum, false, nil, "user123")
+	s.mockSchemaService.On("CreateField", mock.Anything, field).Return("", pkgErrors.NewValidationError(models.ErrMetadataFieldValuesEmpty.Error()))
+
+	id, err := s.metadataSchemaUseCase.CreateField(context.Background(), field)
+
+	assert.Empty(s.T(), id)
+	assert.True(s.T(), pkgErrors.IsValidationError(err))
+	s.mockSchemaService.AssertExpectations(s.T())
+}
+
+// TestGetSchema_Success tests successful metadata schema retrieval
+func (s *MetadataSchemaUseCaseTestSuite) TestGetSchema_Success() {
+	expected := models.MetadataSchema{
+		{ID: "field1", TenantID: "tenant123", Name: "due-date", Type: models.MetadataFieldTypeDate},
+	}
+	s.mockSchemaService.On("GetSchema", mock.Anything, "tenant123").Return(expected, nil)
+
+	schema, err := s.metadataSchemaUseCase.GetSchema(context.Background(), "tenant123")
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), expected, schema)
+	s.mockSchemaService.AssertExpectations(s.T())
+}
+
+// TestUpdateField_ValidationError tests metadata field update without an ID
+func (s *MetadataSchemaUseCaseTestSuite) TestUpdateField_ValidationError() {
+	err := s.metadataSchemaUseCase.UpdateField(context.Background(), &models.MetadataField{TenantID: "tenant123"})
+	assert.True(s.T(), pkgErrors.IsValidationError(err))
+
+	s.mockSchemaService.AssertNotCalled(s.T(), "UpdateField")
+}
+
+// TestDeleteField_ServiceError tests metadata field deletion failure
+func (s *MetadataSchemaUseCaseTestSuite) TestDeleteField_ServiceError() {
+	s.mockSchemaService.On("DeleteField", mock.Anything, "field123", "tenant123").Return(errors.New("service error"))
+
+	err := s.metadataSchemaUseCase.DeleteField(context.Background(), "field123", "tenant123")
+
+	assert.NotNil(s.T(), err)
+	s.mockSchemaService.AssertExpectations(s.T())
+}
+
+// TestMetadataSchemaUseCaseSuite entry point for running the MetadataSchemaUseCase test suite
+func TestMetadataSchemaUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(MetadataSchemaUseCaseTestSuite))
+}
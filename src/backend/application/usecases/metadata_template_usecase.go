@@ -0,0 +1,185 @@
+// Package usecases implements the application layer of the Document Management Platform.
+// It contains use case implementations that orchestrate domain models and services.
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"../../domain/models"
+	"../../domain/services"
+	"../../pkg/errors"
+	"../../pkg/logger"
+)
+
+// MetadataTemplateUseCase defines the contract for managing the metadata templates of folders
+type MetadataTemplateUseCase interface {
+	// CreateTemplate creates a new metadata template for a folder
+	CreateTemplate(ctx context.Context, template *models.MetadataTemplate) (string, error)
+
+	// GetTemplate retrieves a metadata template by its ID
+	GetTemplate(ctx context.Context, id string, tenantID string) (*models.MetadataTemplate, error)
+
+	// GetFolderTemplate retrieves the metadata template of a folder, so clients can prompt for its
+	// fields before uploading
+	GetFolderTemplate(ctx context.Context, folderID string, tenantID string) (*models.MetadataTemplate, error)
+
+	// ListTemplates lists the metadata templates of a tenant
+	ListTemplates(ctx context.Context, tenantID string) ([]*models.MetadataTemplate, error)
+
+	// UpdateTemplate replaces the fields and mode of an existing metadata template
+	UpdateTemplate(ctx context.Context, template *models.MetadataTemplate) error
+
+	// DeleteTemplate deletes a metadata template
+	DeleteTemplate(ctx context.Context, id string, tenantID string) error
+}
+
+// metadataTemplateUseCase implements the MetadataTemplateUseCase interface
+type metadataTemplateUseCase struct {
+	templateService services.MetadataTemplateService
+}
+
+// NewMetadataTemplateUseCase creates a new MetadataTemplateUseCase instance
+func NewMetadataTemplateUseCase(templateService services.MetadataTemplateService) (MetadataTemplateUseCase, error) {
+	if templateService == nil {
+		return nil, fmt.Errorf("metadata template service cannot be nil")
+	}
+
+	return &metadataTemplateUseCase{
+		templateService: templateService,
+	}, nil
+}
+
+// CreateTemplate creates a new metadata template for a folder
+func (u *metadataTemplateUseCase) CreateTemplate(ctx context.Context, template *models.MetadataTemplate) (string, error) {
+	log := logger.WithContext(ctx)
+
+	if template == nil {
+		log.Error("metadata template cannot be nil")
+		return "", errors.NewValidationError("metadata template cannot be nil")
+	}
+
+	id, err := u.templateService.CreateTemplate(ctx, template)
+	if err != nil {
+		log.WithError(err).Error("failed to create metadata template", "tenantID", template.TenantID, "folderID", template.FolderID)
+		return "", errors.Wrap(err, "failed to create metadata template")
+	}
+
+	log.Info("metadata template created successfully", "templateID", id, "tenantID", template.TenantID)
+	return id, nil
+}
+
+// GetTemplate retrieves a metadata template by its ID
+func (u *metadataTemplateUseCase) GetTemplate(ctx context.Context, id string, tenantID string) (*models.MetadataTemplate, error) {
+	log := logger.WithContext(ctx)
+
+	if err := u.validateInput(map[string]string{
+		"metadata template ID": id,
+		"tenant ID":            tenantID,
+	}); err != nil {
+		return nil, err
+	}
+
+	template, err := u.templateService.GetTemplate(ctx, id, tenantID)
+	if err != nil {
+		log.WithError(err).Error("failed to get metadata template", "id", id, "tenantID", tenantID)
+		return nil, errors.Wrap(err, "failed to get metadata template")
+	}
+
+	return template, nil
+}
+
+// GetFolderTemplate retrieves the metadata template of a folder
+func (u *metadataTemplateUseCase) GetFolderTemplate(ctx context.Context, folderID string, tenantID string) (*models.MetadataTemplate, error) {
+	log := logger.WithContext(ctx)
+
+	if err := u.validateInput(map[string]string{
+		"folder ID": folderID,
+		"tenant ID": tenantID,
+	}); err != nil {
+		return nil, err
+	}
+
+	template, err := u.templateService.GetFolderTemplate(ctx, folderID, tenantID)
+	if err != nil {
+		if !errors.IsResourceNotFoundError(err) {
+			log.WithError(err).Error("failed to get folder metadata template", "folderID", folderID, "tenantID", tenantID)
+		}
+		return nil, errors.Wrap(err, "failed to get folder metadata template")
+	}
+
+	return template, nil
+}
+
+// ListTemplates lists the metadata templates of a tenant
+func (u *metadataTemplateUseCase) ListTemplates(ctx context.Context, tenantID string) ([]*models.MetadataTemplate, error) {
+	log := logger.WithContext(ctx)
+
+	if tenantID == "" {
+		log.Error("tenant ID cannot be empty")
+		return nil, errors.NewValidationError("tenant ID is required")
+	}
+
+	templates, err := u.templateService.ListTemplates(ctx, tenantID)
+	if err != nil {
+		log.WithError(err).Error("failed to list metadata templates", "tenantID", tenantID)
+		return nil, errors.Wrap(err, "failed to list metadata templates")
+	}
+
+	return templates, nil
+}
+
+// UpdateTemplate replaces the fields and mode of an existing metadata template
+func (u *metadataTemplateUseCase) UpdateTemplate(ctx context.Context, template *models.MetadataTemplate) error {
+	log := logger.WithContext(ctx)
+
+	if template == nil {
+		log.Error("metadata template cannot be nil")
+		return errors.NewValidationError("metadata template cannot be nil")
+	}
+
+	if err := u.validateInput(map[string]string{
+		"metadata template ID": template.ID,
+		"tenant ID":            template.TenantID,
+	}); err != nil {
+		return err
+	}
+
+	if err := u.templateService.UpdateTemplate(ctx, template); err != nil {
+		log.WithError(err).Error("failed to update metadata template", "id", template.ID, "tenantID", template.TenantID)
+		return errors.Wrap(err, "failed to update metadata template")
+	}
+
+	log.Info("metadata template updated successfully", "templateID", template.ID, "tenantID", template.TenantID)
+	return nil
+}
+
+// DeleteTemplate deletes a metadata template
+func (u *metadataTemplateUseCase) DeleteTemplate(ctx context.Context, id string, tenantID string) error {
+	log := logger.WithContext(ctx)
+
+	if err := u.validateInput(map[string]string{
+		"metadata template ID": id,
+		"tenant ID":            tenantID,
+	}); err != nil {
+		return err
+	}
+
+	if err := u.templateService.DeleteTemplate(ctx, id, tenantID); err != nil {
+		log.WithError(err).Error("failed to delete metadata template", "id", id, "tenantID", tenantID)
+		return errors.Wrap(err, "failed to delete metadata template")
+	}
+
+	log.Info("metadata template deleted successfully", "templateID", id, "tenantID", tenantID)
+	return nil
+}
+
+// validateInput validates that required input parameters are not empty
+func (u *metadataTemplateUseCase) validateInput(params map[string]string) error {
+	for name, value := range params {
+		if value == "" {
+			return errors.NewValidationError(fmt.Sprintf("%s cannot be empty", name))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert" // v1.8.0+
+	"github.com/stretchr/testify/mock"   // v1.8.0+
+	"github.com/stretchr/testify/suite"  // v1.8.0+
+
+	"../../domain/models"
+	pkgErrors "../../pkg/errors"
+)
+
+// MockMetadataTemplateService is a mock implementation of the MetadataTemplateService interface for testing
+type MockMetadataTemplateService struct {
+	mock.Mock
+}
+
+// CreateTemplate mock implementation for creating a metadata template
+func (m *MockMetadataTemplateService) CreateTemplate(ctx context.Context, template *models.MetadataTemplate) (string, error) {
+	args := m.Called(ctx, template)
+	return args.String(0), args.Error(1)
+}
+
+// GetTemplate mock implementation for retrieving a metadata template
+func (m *MockMetadataTemplateService) GetTemplate(ctx context.Context, id string, tenantID string) (*models.MetadataTemplate, error) {
+	args := m.Called(ctx, id, tenantID)
+	if template := args.Get(0); template != nil {
+		return template.(*models.MetadataTemplate), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// GetFolderTemplate mock implementation for retrieving the metadata template of a folder
+func (m *MockMetadataTemplateService) GetFolderTemplate(ctx context.Context, folderID string, tenantID string) (*models.MetadataTemplate, error) {
+	args := m.Called(ctx, folderID, tenantID)
+	if template := args.Get(0); template != nil {
+		return template.(*models.MetadataTemplate), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// ListTemplates mock implementation for listing metadata templates
+func (m *MockMetadataTemplateService) ListTemplates(ctx context.Context, tenantID string) ([]*models.MetadataTemplate, error) {
+	args := m.Called(ctx, tenantID)
+	if templates := args.Get(0); templates != nil {
+		return templates.([]*models.MetadataTemplate), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// UpdateTemplate mock implementation for updating a metadata template
+func (m *MockMetadataTemplateService) UpdateTemplate(ctx context.Context, template *models.MetadataTemplate) error {
+	args := m.Called(ctx, template)
+	return args.Error(0)
+}
+
+// DeleteTemplate mock implementation for deleting a metadata template
+func (m *MockMetadataTemplateService) DeleteTemplate(ctx context.Context, id string, tenantID string) error {
+	args := m.Called(ctx, id, tenantID)
+	return args.Error(0)
+}
+
+// CheckUpload mock implementation for checking an upload against a folder's template
+func (m *MockMetadataTemplateService) CheckUpload(ctx context.Context, tenantID string, folderID string, metadata map[string]string) error {
+	args := m.Called(ctx, tenantID, folderID, metadata)
+	return args.Error(0)
+}
+
+// MetadataTemplateUseCaseTestSuite defines a test suite for MetadataTemplateUseCase
+type MetadataTemplateUseCaseTestSuite struct {
+	suite.Suite
+	mockTemplateService     *MockMetadataTemplateService
+	metadataTemplateUseCase MetadataTemplateUseCase
+}
+
+// SetupTest sets up the test environment before each test
+func (s *MetadataTemplateUseCaseTestSuite) SetupTest() {
+	s.mockTemplateService = new(MockMetadataTemplateService)
+
+	var err error
+	s.metadataTemplateUseCase, err = NewMetadataTemplateUseCase(s.mockTemplateService)
+	assert.Nil(s.T(), err)
+	assert.NotNil(s.T(), s.metadataTemplateUseCase)
+}
+
+// TestNewMetadataTemplateUseCase tests the creation of a new MetadataTemplateUseCase
+func (s *MetadataTemplateUseCaseTestSuite) TestNewMetadataTemplateUseCase() {
+	useCase, err := NewMetadataTemplateUseCase(nil)
+	assert.NotNil(s.T(), err)
+	assert.Nil(s.T(), useCase)
+}
+
+// TestCreateTemplate_Success tests successful metadata template creation
+func (s *MetadataTemplateUseCaseTestSuite) TestCreateTemplate_Success() {
+	template := models.NewMetadataTemplate("tenant123", "folder123", "Invoices", []string{"invoice_number", "vendor"}, "user123")
+	s.mockTemplateService.On("CreateTemplate", mock.Anything, template).Return("template123", nil)
+
+	id, err := s.metadataTemplateUseCase.CreateTemplate(context.Background(), template)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), "template123", id)
+	s.mockTemplateService.AssertExpectations(s.T())
+}
+
+// TestCreateTemplate_ServiceError tests metadata template creation rejected by the service
+func (s *MetadataTemplateUseCaseTestSuite) TestCreateTemplate_ServiceError() {
+	template := models.NewMetadataTemplate("tenant123", "folder123", "Invoices", nil, "user123")
+	s.mockTemplateService.On("CreateTemplate", mock.Anything, template).Return("", pkgErrors.NewValidationError(models.ErrMetadataTemplateFieldsEmpty.Error()))
+
+	id, err := s.metadataTemplateUseCase.CreateTemplate(context.Background(), template)
+
+	assert.Empty(s.T(), id)
+	assert.True(s.T(), pkgErrors.IsValidationError(err))
+	s.mockTemplateService.AssertExpectations(s.T())
+}
+
+// TestGetFolderTemplate_NotFound tests retrieving the template of a folder without one
+func (s *MetadataTemplateUseCaseTestSuite) TestGetFolderTemplate_NotFound() {
+	s.mockTemplateService.On("GetFolderTemplate", mock.Anything, "folder123", "tenant123").Return(nil, pkgErrors.NewResourceNotFoundError("Metadata template not found"))
+
+	template, err := s.metadataTemplateUseCase.GetFolderTemplate(context.Background(), "folder123", "tenant123")
+
+	assert.Nil(s.T(), template)
+	assert.True(s.T(), pkgErrors.IsResourceNotFoundError(err))
+	s.mockTemplateService.AssertExpectations(s.T())
+}
+
+// TestUpdateTemplate_ValidationError tests metadata template update without an ID
+func (s *MetadataTemplateUseCaseTestSuite) TestUpdateTemplate_ValidationError() {
+	err := s.metadataTemplateUseCase.UpdateTemplate(context.Background(), &models.MetadataTemplate{TenantID: "tenant123"})
+	assert.True(s.T(), pkgErrors.IsValidationError(err))
+
+	s.mockTemplateService.AssertNotCalled(s.T(), "UpdateTemplate")
+}
+
+// TestDeleteTemplate_ServiceError tests metadata template deletion failure
+func (s *MetadataTemplateUseCaseTestSuite) TestDeleteTemplate_ServiceError() {
+	s.mockTemplateService.On("DeleteTemplate", mock.Anything, "template123", "tenant123").Return(errors.New("service error"))
+
+	err := s.metadataTemplateUseCase.DeleteTemplate(context.Background(), "template123", "tenant123")
+
+	assert.NotNil(s.T(), err)
+	s.mockTemplateService.AssertExpectations(s.T())
+}
+
+// TestMetadataTemplateUseCaseSuite entry point for running the MetadataTemplateUseCase test suite
+func TestMetadataTemplateUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(MetadataTemplateUseCaseTestSuite))
+}
@@ -0,0 +1,163 @@
+// Package usecases implements the application layer of the Document Management Platform.
+// It contains use case implementations that orchestrate domain models and services.
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"../../domain/models"
+	"../../domain/services"
+	"../../pkg/errors"
+	"../../pkg/logger"
+	"../../pkg/utils"
+)
+
+// PolicyUseCase defines the contract for managing a tenant's attribute-based access policies
+type PolicyUseCase interface {
+	// CreatePolicy creates a new access policy for a tenant
+	CreatePolicy(ctx context.Context, policy *models.AccessPolicy) (string, error)
+
+	// GetPolicy retrieves an access policy by its ID
+	GetPolicy(ctx context.Context, id string, tenantID string) (*models.AccessPolicy, error)
+
+	// ListPolicies lists a tenant's access policies with pagination
+	ListPolicies(ctx context.Context, tenantID string, page int, pageSize int) (utils.PaginatedResult[models.AccessPolicy], error)
+
+	// UpdatePolicy replaces the rule of an existing access policy
+	UpdatePolicy(ctx context.Context, policy *models.AccessPolicy) error
+
+	// DeletePolicy deletes an access policy
+	DeletePolicy(ctx context.Context, id string, tenantID string) error
+}
+
+// policyUseCase implements the PolicyUseCase interface
+type policyUseCase struct {
+	policyEngine services.PolicyEngine
+}
+
+// NewPolicyUseCase creates a new PolicyUseCase instance
+func NewPolicyUseCase(policyEngine services.PolicyEngine) (PolicyUseCase, error) {
+	if policyEngine == nil {
+		return nil, fmt.Errorf("policy engine cannot be nil")
+	}
+
+	return &policyUseCase{
+		policyEngine: policyEngine,
+	}, nil
+}
+
+// CreatePolicy creates a new access policy for a tenant
+func (u *policyUseCase) CreatePolicy(ctx context.Context, policy *models.AccessPolicy) (string, error) {
+	log := logger.WithContext(ctx)
+
+	if policy == nil {
+		log.Error("access policy cannot be nil")
+		return "", errors.NewValidationError("access policy cannot be nil")
+	}
+
+	id, err := u.policyEngine.CreatePolicy(ctx, policy)
+	if err != nil {
+		log.WithError(err).Error("failed to create access policy", "tenantID", policy.TenantID, "name", policy.Name)
+		return "", errors.Wrap(err, "failed to create access policy")
+	}
+
+	log.Info("access policy created successfully", "policyID", id, "tenantID", policy.TenantID)
+	return id, nil
+}
+
+// GetPolicy retrieves an access policy by its ID
+func (u *policyUseCase) GetPolicy(ctx context.Context, id string, tenantID string) (*models.AccessPolicy, error) {
+	log := logger.WithContext(ctx)
+
+	if err := u.validateInput(map[string]string{
+		"policy ID": id,
+		"tenant ID": tenantID,
+	}); err != nil {
+		return nil, err
+	}
+
+	policy, err := u.policyEngine.GetPolicy(ctx, id, tenantID)
+	if err != nil {
+		log.WithError(err).Error("failed to get access policy", "id", id, "tenantID", tenantID)
+		return nil, errors.Wrap(err, "failed to get access policy")
+	}
+
+	return policy, nil
+}
+
+// ListPolicies lists a tenant's access policies with pagination
+func (u *policyUseCase) ListPolicies(ctx context.Context, tenantID string, page int, pageSize int) (utils.PaginatedResult[models.AccessPolicy], error) {
+	log := logger.WithContext(ctx)
+
+	if tenantID == "" {
+		log.Error("tenant ID cannot be empty")
+		return utils.PaginatedResult[models.AccessPolicy]{}, errors.NewValidationError("tenant ID is required")
+	}
+
+	pagination := utils.NewPagination(page, pageSize)
+
+	result, err := u.policyEngine.ListPolicies(ctx, tenantID, pagination)
+	if err != nil {
+		log.WithError(err).Error("failed to list access policies", "tenantID", tenantID)
+		return utils.PaginatedResult[models.AccessPolicy]{}, errors.Wrap(err, "failed to list access policies")
+	}
+
+	log.Info("access policies listed successfully", "tenantID", tenantID, "count", len(result.Items))
+	return result, nil
+}
+
+// UpdatePolicy replaces the rule of an existing access policy
+func (u *policyUseCase) UpdatePolicy(ctx context.Context, policy *models.AccessPolicy) error {
+	log := logger.WithContext(ctx)
+
+	if policy == nil {
+		log.Error("access policy cannot be nil")
+		return errors.NewValidationError("access policy cannot be nil")
+	}
+
+	if err := u.validateInput(map[string]string{
+		"policy ID": policy.ID,
+		"tenant ID": policy.TenantID,
+	}); err != nil {
+		return err
+	}
+
+	if err := u.policyEngine.UpdatePolicy(ctx, policy); err != nil {
+		log.WithError(err).Error("failed to update access policy", "id", policy.ID, "tenantID", policy.TenantID)
+		return errors.Wrap(err, "failed to update access policy")
+	}
+
+	log.Info("access policy updated successfully", "policyID", policy.ID, "tenantID", policy.TenantID)
+	return nil
+}
+
+// DeletePolicy deletes an access policy
+func (u *policyUseCase) DeletePolicy(ctx context.Context, id string, tenantID string) error {
+	log := logger.WithContext(ctx)
+
+	if err := u.validateInput(map[string]string{
+		"policy ID": id,
+		"tenant ID": tenantID,
+	}); err != nil {
+		return err
+	}
+
+	if err := u.policyEngine.DeletePolicy(ctx, id, tenantID); err != nil {
+		log.WithError(err).Error("failed to delete access policy", "id", id, "tenantID", tenantID)
+		return errors.Wrap(err, "failed to delete access policy")
+	}
+
+	log.Info("access policy deleted successfully", "policyID", id, "tenantID", tenantID)
+	return nil
+}
+
+// validateInput validates that required input parameters are not empty
+func (u *policyUseCase) validateInput(params map[string]string) error {
+	for name, value := range params {
+		if value == "" {
+			return errors.NewValidationError(fmt.Sprintf("%s cannot be empty", name))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert" // v1.8.0+
+	"github.com/stretchr/testify/mock"   // v1.8.0+
+	"github.com/stretchr/testify/suite"  // v1.8.0+
+
+	"../../domain/models"
+	pkgErrors "../../pkg/errors"
+	"../../pkg/utils"
+)
+
+// MockPolicyEngine is a mock implementation of the PolicyEngine interface for testing
+type MockPolicyEngine struct {
+	mock.Mock
+}
+
+// CreatePolicy mock implementation for creating an access policy
+func (m *MockPolicyEngine) CreatePolicy(ctx context.Context, policy *models.AccessPolicy) (string, error) {
+	args := m.Called(ctx, policy)
+	return args.String(0), args.Error(1)
+}
+
+// GetPolicy mock implementation for retrieving an access policy
+func (m *MockPolicyEngine) GetPolicy(ctx context.Context, id string, tenantID string) (*models.AccessPolicy, error) {
+	args := m.Called(ctx, id, tenantID)
+	if policy := args.Get(0); policy != nil {
+		return policy.(*models.AccessPolicy), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// ListPolicies mock implementation for listing access policies
+func (m *MockPolicyEngine) ListPolicies(ctx context.Context, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.AccessPolicy], error) {
+	args := m.Called(ctx, tenantID, pagination)
+	return args.Get(0).(utils.PaginatedResult[models.AccessPolicy]), args.Error(1)
+}
+
+// UpdatePolicy mock implementation for updating an access policy
+func (m *MockPolicyEngine) UpdatePolicy(ctx context.Context, policy *models.AccessPolicy) error {
+	args := m.Called(ctx, policy)
+	return args.Error(0)
+}
+
+// DeletePolicy mock implementation for deleting an access policy
+func (m *MockPolicyEngine) DeletePolicy(ctx context.Context, id string, tenantID string) error {
+	args := m.Called(ctx, id, tenantID)
+	return args.Error(0)
+}
+
+// Enforce mock implementation for evaluating access policies
+func (m *MockPolicyEngine) Enforce(ctx context.Context, tenantID, userID, action string, document *models.Document) error {
+	args := m.Called(ctx, tenantID, userID, action, document)
+	return args.Error(0)
+}
+
+// PolicyUseCaseTestSuite defines a test suite for PolicyUseCase
+type PolicyUseCaseTestSuite struct {
+	suite.Suite
+	mockPolicyEngine *MockPolicyEngine
+	policyUseCase    PolicyUseCase
+}
+
+// SetupTest sets up the test environment before each test
+func (s *PolicyUseCaseTestSuite) SetupTest() {
+	s.mockPolicyEngine = new(MockPolicyEngine)
+
+	var err error
+	s.policyUseCase, err = NewPolicyUseCase(s.mockPolicyEngine)
+	assert.Nil(s.T(), err)
+	assert.NotNil(s.T(), s.policyUseCase)
+}
+
+// TestNewPolicyUseCase tests the creation of a new PolicyUseCase
+func (s *PolicyUseCaseTestSuite) TestNewPolicyUseCase() {
+	useCase, err := NewPolicyUseCase(nil)
+	assert.NotNil(s.T(), err)
+	assert.Nil(s.T(), useCase)
+}
+
+// TestCreatePolicy_Success tests successful access policy creation
+func (s *PolicyUseCaseTestSuite) TestCreatePolicy_Success() {
+	policy := models.NewAccessPolicy("tenant123", "confidential-legal-only", "metadata.classification",
+		models.PolicyOperatorEquals, []string{"confidential"}, []string{models.PolicyActionRead}, []string{"legal"}, "user123")
+	s.mockPolicyEngine.On("CreatePolicy", mock.Anything, policy).Return("policy123", nil)
+
+	id, err := s.policyUseCase.CreatePolicy(context.Background(), policy)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), "policy123", id)
+	s.mockPolicyEngine.AssertExpectations(s.T())
+}
+
+// TestCreatePolicy_ServiceError tests access policy creation rejected by the engine
+func (s *PolicyUseCaseTestSuite) TestCreatePolicy_ServiceError() {
+	policy := &models.AccessPolicy{TenantID: "tenant123", Name: "invalid"}
+	s.mockPolicyEngine.On("CreatePolicy", mock.Anything, policy).Return("", pkgErrors.NewValidationError(models.ErrPolicyInvalidAttribute.Error()))
+
+	id, err := s.policyUseCase.CreatePolicy(context.Background(), policy)
+
+	assert.Empty(s.T(), id)
+	assert.True(s.T(), pkgErrors.IsValidationError(err))
+	s.mockPolicyEngine.AssertExpectations(s.T())
+}
+
+// TestListPolicies_Success tests successful access policy listing
+func (s *PolicyUseCaseTestSuite) TestListPolicies_Success() {
+	expected := utils.PaginatedResult[models.AccessPolicy]{
+		Items: []models.AccessPolicy{{ID: "policy1", TenantID: "tenant123", Name: "confidential-legal-only"}},
+	}
+	s.mockPolicyEngine.On("ListPolicies", mock.Anything, "tenant123", mock.AnythingOfType("*utils.Pagination")).Return(expected, nil)
+
+	result, err := s.policyUseCase.ListPolicies(context.Background(), "tenant123", 1, 20)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), expected, result)
+	s.mockPolicyEngine.AssertExpectations(s.T())
+}
+
+// TestUpdatePolicy_ValidationError tests access policy update without an ID
+func (s *PolicyUseCaseTestSuite) TestUpdatePolicy_ValidationError() {
+	err := s.policyUseCase.UpdatePolicy(context.Background(), &models.AccessPolicy{TenantID: "tenant123"})
+	assert.True(s.T(), pkgErrors.IsValidationError(err))
+
+	s.mockPolicyEngine.AssertNotCalled(s.T(), "UpdatePolicy")
+}
+
+// TestDeletePolicy_ServiceError tests access policy deletion failure
+func (s *PolicyUseCaseTestSuite) TestDeletePolicy_ServiceError() {
+	s.mockPolicyEngine.On("DeletePolicy", mock.Anything, "policy123", "tenant123").Return(errors.New("service error"))
+
+	err := s.policyUseCase.DeletePolicy(context.Background(), "policy123", "tenant123")
+
+	assert.NotNil(s.T(), err)
+	s.mockPolicyEngine.AssertExpectations(s.T())
+}
+
+// TestPolicyUseCaseSuite entry point for running the PolicyUseCase test suite
+func TestPolicyUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(PolicyUseCaseTestSuite))
+}
@@ -0,0 +1,247 @@
+// Package usecases implements the application layer of the Document Management Platform.
+// It contains use case implementations that orchestrate domain models and services.
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"../../domain/models"
+	"../../domain/repositories"
+	"../../domain/services"
+	"../../pkg/errors"
+	"../../pkg/logger"
+	"../../pkg/utils"
+)
+
+// ErrQuarantinedItemResolved is returned when releasing or destroying an item that has already been resolved
+var ErrQuarantinedItemResolved = errors.NewValidationError("quarantined item has already been released or destroyed")
+
+// QuarantineUseCase defines the contract for security administrators reviewing the document
+// versions virus scans found infected
+type QuarantineUseCase interface {
+	// ListQuarantinedItems lists the quarantined items of a tenant, newest first. An empty status
+	// lists items in any status.
+	ListQuarantinedItems(ctx context.Context, tenantID, status string, page, pageSize int) (utils.PaginatedResult[models.QuarantinedItem], error)
+
+	// GetQuarantinedItem retrieves a quarantined item with the verdict of the scanning engines
+	GetQuarantinedItem(ctx context.Context, id, tenantID string) (*models.QuarantinedItem, error)
+
+	// ReleaseQuarantinedItem moves the content out of quarantine and queues it for the scanning
+	// worker. In rescan mode the document is scanned again and only becomes available if it is now
+	// clean; in override mode it is processed as clean without scanning, so a reason is required.
+	ReleaseQuarantinedItem(ctx context.Context, id, tenantID, userID, mode, reason string) (*models.QuarantinedItem, error)
+
+	// DestroyQuarantinedItem permanently deletes the quarantined content
+	DestroyQuarantinedItem(ctx context.Context, id, tenantID, userID, reason string) (*models.QuarantinedItem, error)
+}
+
+// quarantineUseCase implements the QuarantineUseCase interface
+type quarantineUseCase struct {
+	quarantineRepo repositories.QuarantineRepository
+	documentRepo   repositories.DocumentRepository
+	storageService services.StorageService
+	scanQueue      services.ScanQueue
+	auditService   services.AuditService
+}
+
+// NewQuarantineUseCase creates a new QuarantineUseCase instance
+func NewQuarantineUseCase(
+	quarantineRepo repositories.QuarantineRepository,
+	documentRepo repositories.DocumentRepository,
+	storageService services.StorageService,
+	scanQueue services.ScanQueue,
+	auditService services.AuditService,
+) (QuarantineUseCase, error) {
+	if quarantineRepo == nil {
+		return nil, fmt.Errorf("quarantine repository cannot be nil")
+	}
+	if documentRepo == nil {
+		return nil, fmt.Errorf("document repository cannot be nil")
+	}
+	if storageService == nil {
+		return nil, fmt.Errorf("storage service cannot be nil")
+	}
+	if scanQueue == nil {
+		return nil, fmt.Errorf("scan queue cannot be nil")
+	}
+	if auditService == nil {
+		return nil, fmt.Errorf("audit service cannot be nil")
+	}
+
+	return &quarantineUseCase{
+		quarantineRepo: quarantineRepo,
+		documentRepo:   documentRepo,
+		storageService: storageService,
+		scanQueue:      scanQueue,
+		auditService:   auditService,
+	}, nil
+}
+
+// ListQuarantinedItems lists the quarantined items of a tenant with pagination
+func (u *quarantineUseCase) ListQuarantinedItems(ctx context.Context, tenantID, status string, page, pageSize int) (utils.PaginatedResult[models.QuarantinedItem], error) {
+	log := logger.WithContext(ctx)
+
+	if tenantID == "" {
+		return utils.PaginatedResult[models.QuarantinedItem]{}, errors.NewValidationError("tenant ID cannot be empty")
+	}
+	if status != "" && !models.IsValidQuarantineStatus(status) {
+		return utils.PaginatedResult[models.QuarantinedItem]{}, errors.NewValidationError(fmt.Sprintf("invalid quarantine status: %s", status))
+	}
+
+	result, err := u.quarantineRepo.List(ctx, tenantID, status, utils.NewPagination(page, pageSize))
+	if err != nil {
+		log.WithError(err).Error("failed to list quarantined items", "tenantID", tenantID)
+		return utils.PaginatedResult[models.QuarantinedItem]{}, errors.Wrap(err, "failed to list quarantined items")
+	}
+
+	return result, nil
+}
+
+// GetQuarantinedItem retrieves a quarantined item of the tenant
+func (u *quarantineUseCase) GetQuarantinedItem(ctx context.Context, id, tenantID string) (*models.QuarantinedItem, error) {
+	if err := u.validateInput(map[string]string{
+		"quarantined item ID": id,
+		"tenant ID":           tenantID,
+	}); err != nil {
+		return nil, err
+	}
+
+	item, err := u.quarantineRepo.GetByID(ctx, id, tenantID)
+	if err != nil {
+		if !errors.IsResourceNotFoundError(err) {
+			logger.WithContext(ctx).WithError(err).Error("failed to get quarantined item", "itemID", id, "tenantID", tenantID)
+		}
+		return nil, errors.Wrap(err, "failed to get quarantined item")
+	}
+
+	return item, nil
+}
+
+// ReleaseQuarantinedItem moves a quarantined document back to temporary storage and queues it for
+// the scanning worker, which scans it again or, when the verdict is overridden, processes it as clean
+func (u *quarantineUseCase) ReleaseQuarantinedItem(ctx context.Context, id, tenantID, userID, mode, reason string) (*models.QuarantinedItem, error) {
+	log := logger.WithContext(ctx)
+
+	if err := u.validateInput(map[string]string{
+		"quarantined item ID": id,
+		"tenant ID":           tenantID,
+		"user ID":             userID,
+	}); err != nil {
+		return nil, err
+	}
+	if !models.IsValidQuarantineReleaseMode(mode) {
+		return nil, errors.NewValidationError(fmt.Sprintf("release mode must be %s or %s", models.QuarantineReleaseRescan, models.QuarantineReleaseOverride))
+	}
+	// Overriding a verdict makes infected content available, so it must be justified
+	if mode == models.QuarantineReleaseOverride && reason == "" {
+		return nil, errors.NewValidationError("reason is required to override a scan verdict")
+	}
+
+	item, err := u.GetQuarantinedItem(ctx, id, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if item.IsResolved() {
+		return nil, ErrQuarantinedItemResolved
+	}
+
+	tempPath, err := u.storageService.ReleaseFromQuarantine(ctx, tenantID, item.DocumentID, item.StoragePath)
+	if err != nil {
+		log.WithError(err).Error("failed to release document from quarantine storage", "itemID", id, "documentID", item.DocumentID)
+		return nil, errors.Wrap(err, "failed to release document from quarantine storage")
+	}
+
+	if err := u.documentRepo.UpdateVersionStatus(ctx, item.VersionID, models.VersionStatusProcessing, tenantID); err != nil {
+		log.WithError(err).Error("failed to mark version as processing", "versionID", item.VersionID)
+		return nil, errors.Wrap(err, "failed to update version status")
+	}
+
+	task := services.ScanTask{
+		DocumentID:  item.DocumentID,
+		VersionID:   item.VersionID,
+		TenantID:    tenantID,
+		StoragePath: tempPath,
+	}
+	if mode == models.QuarantineReleaseOverride {
+		task.OverriddenBy = userID
+	}
+	if err := u.scanQueue.Enqueue(ctx, task); err != nil {
+		log.WithError(err).Error("failed to queue released document", "documentID", item.DocumentID)
+		return nil, errors.Wrap(err, "failed to queue released document")
+	}
+
+	item.Release(mode, reason, userID, time.Now())
+	if err := u.quarantineRepo.Resolve(ctx, item); err != nil {
+		log.WithError(err).Error("failed to record quarantine release", "itemID", id)
+		return nil, errors.Wrap(err, "failed to record quarantine release")
+	}
+
+	u.recordAudit(ctx, models.AuditActionRelease, item)
+
+	log.Info("quarantined document released", "itemID", id, "documentID", item.DocumentID, "mode", mode, "userID", userID)
+	return item, nil
+}
+
+// DestroyQuarantinedItem deletes the quarantined content, which cannot be recovered afterwards
+func (u *quarantineUseCase) DestroyQuarantinedItem(ctx context.Context, id, tenantID, userID, reason string) (*models.QuarantinedItem, error) {
+	log := logger.WithContext(ctx)
+
+	if err := u.validateInput(map[string]string{
+		"quarantined item ID": id,
+		"tenant ID":           tenantID,
+		"user ID":             userID,
+	}); err != nil {
+		return nil, err
+	}
+
+	item, err := u.GetQuarantinedItem(ctx, id, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if item.IsResolved() {
+		return nil, ErrQuarantinedItemResolved
+	}
+
+	if err := u.storageService.DeleteDocument(ctx, item.StoragePath); err != nil {
+		log.WithError(err).Error("failed to delete quarantined document", "itemID", id, "storagePath", item.StoragePath)
+		return nil, errors.Wrap(err, "failed to delete quarantined document")
+	}
+
+	item.Destroy(reason, userID, time.Now())
+	if err := u.quarantineRepo.Resolve(ctx, item); err != nil {
+		log.WithError(err).Error("failed to record quarantine destruction", "itemID", id)
+		return nil, errors.Wrap(err, "failed to record quarantine destruction")
+	}
+
+	u.recordAudit(ctx, models.AuditActionDestroy, item)
+
+	log.Info("quarantined document destroyed", "itemID", id, "documentID", item.DocumentID, "userID", userID)
+	return item, nil
+}
+
+// recordAudit records the resolution of a quarantined item in the audit log. Failures are logged
+// rather than returned, as the item has already been resolved.
+func (u *quarantineUseCase) recordAudit(ctx context.Context, action string, item *models.QuarantinedItem) {
+	err := u.auditService.RecordAction(ctx, item.TenantID, item.ResolvedBy, action, models.AuditResourceQuarantinedItem, item.ID, nil, map[string]interface{}{
+		"document_id":  item.DocumentID,
+		"version_id":   item.VersionID,
+		"scan_details": item.ScanDetails,
+		"resolution":   item.Resolution,
+		"reason":       item.Reason,
+	})
+	if err != nil {
+		logger.WithContext(ctx).WithError(err).Error("failed to record quarantine resolution in audit log", "itemID", item.ID, "action", action)
+	}
+}
+
+// validateInput validates that required input parameters are not empty
+func (u *quarantineUseCase) validateInput(params map[string]string) error {
+	for name, value := range params {
+		if value == "" {
+			return errors.NewValidationError(fmt.Sprintf("%s cannot be empty", name))
+		}
+	}
+	return nil
+}
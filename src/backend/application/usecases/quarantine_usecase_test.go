@@ -0,0 +1,234 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert" // v1.8.0+
+	"github.com/stretchr/testify/mock"   // v1.8.0+
+	"github.com/stretchr/testify/suite"  // v1.8.0+
+
+	"../../domain/models"
+	"../../domain/repositories"
+	"../../domain/services"
+	pkgErrors "../../pkg/errors"
+	"../../pkg/utils"
+)
+
+// MockQuarantineRepository is a mock implementation of the QuarantineRepository interface for testing
+type MockQuarantineRepository struct {
+	mock.Mock
+}
+
+func (m *MockQuarantineRepository) Create(ctx context.Context, item *models.QuarantinedItem) (string, error) {
+	args := m.Called(ctx, item)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockQuarantineRepository) GetByID(ctx context.Context, id string, tenantID string) (*models.QuarantinedItem, error) {
+	args := m.Called(ctx, id, tenantID)
+	if item := args.Get(0); item != nil {
+		return item.(*models.QuarantinedItem), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockQuarantineRepository) List(ctx context.Context, tenantID string, status string, pagination *utils.Pagination) (utils.PaginatedResult[models.QuarantinedItem], error) {
+	args := m.Called(ctx, tenantID, status, pagination)
+	return args.Get(0).(utils.PaginatedResult[models.QuarantinedItem]), args.Error(1)
+}
+
+func (m *MockQuarantineRepository) Resolve(ctx context.Context, item *models.QuarantinedItem) error {
+	args := m.Called(ctx, item)
+	return args.Error(0)
+}
+
+// mockQuarantineDocumentRepository mocks the DocumentRepository methods used by quarantine management
+type mockQuarantineDocumentRepository struct {
+	repositories.DocumentRepository
+	mock.Mock
+}
+
+func (m *mockQuarantineDocumentRepository) UpdateVersionStatus(ctx context.Context, versionID string, status string, tenantID string) error {
+	args := m.Called(ctx, versionID, status, tenantID)
+	return args.Error(0)
+}
+
+// mockQuarantineStorageService mocks the StorageService methods used by quarantine management
+type mockQuarantineStorageService struct {
+	services.StorageService
+	mock.Mock
+}
+
+func (m *mockQuarantineStorageService) ReleaseFromQuarantine(ctx context.Context, tenantID string, documentID string, quarantinePath string) (string, error) {
+	args := m.Called(ctx, tenantID, documentID, quarantinePath)
+	return args.String(0), args.Error(1)
+}
+
+func (m *mockQuarantineStorageService) DeleteDocument(ctx context.Context, storagePath string) error {
+	args := m.Called(ctx, storagePath)
+	return args.Error(0)
+}
+
+// mockQuarantineScanQueue mocks the ScanQueue methods used by quarantine management
+type mockQuarantineScanQueue struct {
+	services.ScanQueue
+	mock.Mock
+}
+
+func (m *mockQuarantineScanQueue) Enqueue(ctx context.Context, task services.ScanTask) error {
+	args := m.Called(ctx, task)
+	return args.Error(0)
+}
+
+// QuarantineUseCaseTestSuite defines a test suite for QuarantineUseCase
+type QuarantineUseCaseTestSuite struct {
+	suite.Suite
+	mockQuarantineRepo *MockQuarantineRepository
+	mockDocumentRepo   *mockQuarantineDocumentRepository
+	mockStorageService *mockQuarantineStorageService
+	mockScanQueue      *mockQuarantineScanQueue
+	mockAuditService   *MockAuditService
+	quarantineUseCase  QuarantineUseCase
+}
+
+// SetupTest sets up the test environment before each test
+func (s *QuarantineUseCaseTestSuite) SetupTest() {
+	s.mockQuarantineRepo = new(MockQuarantineRepository)
+	s.mockDocumentRepo = new(mockQuarantineDocumentRepository)
+	s.mockStorageService = new(mockQuarantineStorageService)
+	s.mockScanQueue = new(mockQuarantineScanQueue)
+	s.mockAuditService = new(MockAuditService)
+
+	var err error
+	s.quarantineUseCase, err = NewQuarantineUseCase(s.mockQuarantineRepo, s.mockDocumentRepo, s.mockStorageService, s.mockScanQueue, s.mockAuditService)
+	assert.Nil(s.T(), err)
+}
+
+// createTestItem returns a quarantined item of tenant123
+func (s *QuarantineUseCaseTestSuite) createTestItem() *models.QuarantinedItem {
+	item := models.NewQuarantinedItem("tenant123", "doc123", "ver123", "quarantine/tenant123/doc123", "EICAR-Test-File")
+	item.ID = "item123"
+	return item
+}
+
+// TestListQuarantinedItems_InvalidStatus tests that unknown statuses are rejected
+func (s *QuarantineUseCaseTestSuite) TestListQuarantinedItems_InvalidStatus() {
+	_, err := s.quarantineUseCase.ListQuarantinedItems(context.Background(), "tenant123", "infected", 1, 20)
+
+	assert.True(s.T(), pkgErrors.IsValidationError(err))
+	s.mockQuarantineRepo.AssertNotCalled(s.T(), "List", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestGetQuarantinedItem_NotFound tests that items of other tenants are not found
+func (s *QuarantineUseCaseTestSuite) TestGetQuarantinedItem_NotFound() {
+	ctx := context.Background()
+	s.mockQuarantineRepo.On("GetByID", ctx, "item123", "tenant123").Return(nil, pkgErrors.NewResourceNotFoundError("Quarantined item not found"))
+
+	_, err := s.quarantineUseCase.GetQuarantinedItem(ctx, "item123", "tenant123")
+
+	assert.True(s.T(), pkgErrors.IsResourceNotFoundError(err))
+}
+
+// TestReleaseQuarantinedItem_Rescan tests that a released document is scanned again from temporary storage
+func (s *QuarantineUseCaseTestSuite) TestReleaseQuarantinedItem_Rescan() {
+	ctx := context.Background()
+	s.mockQuarantineRepo.On("GetByID", ctx, "item123", "tenant123").Return(s.createTestItem(), nil)
+	s.mockStorageService.On("ReleaseFromQuarantine", ctx, "tenant123", "doc123", "quarantine/tenant123/doc123").Return("temp/tenant123/doc123", nil)
+	s.mockDocumentRepo.On("UpdateVersionStatus", ctx, "ver123", models.VersionStatusProcessing, "tenant123").Return(nil)
+	s.mockScanQueue.On("Enqueue", ctx, services.ScanTask{
+		DocumentID:  "doc123",
+		VersionID:   "ver123",
+		TenantID:    "tenant123",
+		StoragePath: "temp/tenant123/doc123",
+	}).Return(nil)
+	s.mockQuarantineRepo.On("Resolve", ctx, mock.MatchedBy(func(item *models.QuarantinedItem) bool {
+		return item.Status == models.QuarantineStatusReleased && item.Resolution == models.QuarantineReleaseRescan && item.ResolvedBy == "admin123"
+	})).Return(nil)
+	s.mockAuditService.On("RecordAction", ctx, "tenant123", "admin123", models.AuditActionRelease, models.AuditResourceQuarantinedItem, "item123", mock.Anything, mock.Anything).Return(nil)
+
+	item, err := s.quarantineUseCase.ReleaseQuarantinedItem(ctx, "item123", "tenant123", "admin123", models.QuarantineReleaseRescan, "")
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), models.QuarantineStatusReleased, item.Status)
+	s.mockScanQueue.AssertExpectations(s.T())
+	s.mockAuditService.AssertExpectations(s.T())
+}
+
+// TestReleaseQuarantinedItem_Override tests that an overridden document is queued to be processed as clean without scanning
+func (s *QuarantineUseCaseTestSuite) TestReleaseQuarantinedItem_Override() {
+	ctx := context.Background()
+	s.mockQuarantineRepo.On("GetByID", ctx, "item123", "tenant123").Return(s.createTestItem(), nil)
+	s.mockStorageService.On("ReleaseFromQuarantine", ctx, "tenant123", "doc123", "quarantine/tenant123/doc123").Return("temp/tenant123/doc123", nil)
+	s.mockDocumentRepo.On("UpdateVersionStatus", ctx, "ver123", models.VersionStatusProcessing, "tenant123").Return(nil)
+	s.mockScanQueue.On("Enqueue", ctx, mock.MatchedBy(func(task services.ScanTask) bool {
+		return task.VersionID == "ver123" && task.StoragePath == "temp/tenant123/doc123" && task.OverriddenBy == "admin123"
+	})).Return(nil)
+	s.mockQuarantineRepo.On("Resolve", ctx, mock.Anything).Return(nil)
+	s.mockAuditService.On("RecordAction", ctx, "tenant123", "admin123", models.AuditActionRelease, models.AuditResourceQuarantinedItem, "item123", mock.Anything, mock.Anything).Return(nil)
+
+	item, err := s.quarantineUseCase.ReleaseQuarantinedItem(ctx, "item123", "tenant123", "admin123", models.QuarantineReleaseOverride, "false positive")
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), models.QuarantineReleaseOverride, item.Resolution)
+	assert.Equal(s.T(), "false positive", item.Reason)
+	s.mockScanQueue.AssertExpectations(s.T())
+}
+
+// TestReleaseQuarantinedItem_OverrideRequiresReason tests that a scan verdict cannot be overridden without a reason
+func (s *QuarantineUseCaseTestSuite) TestReleaseQuarantinedItem_OverrideRequiresReason() {
+	_, err := s.quarantineUseCase.ReleaseQuarantinedItem(context.Background(), "item123", "tenant123", "admin123", models.QuarantineReleaseOverride, "")
+
+	assert.True(s.T(), pkgErrors.IsValidationError(err))
+	s.mockStorageService.AssertNotCalled(s.T(), "ReleaseFromQuarantine", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestReleaseQuarantinedItem_AlreadyResolved tests that destroyed items cannot be released
+func (s *QuarantineUseCaseTestSuite) TestReleaseQuarantinedItem_AlreadyResolved() {
+	ctx := context.Background()
+	item := s.createTestItem()
+	item.Status = models.QuarantineStatusDestroyed
+	s.mockQuarantineRepo.On("GetByID", ctx, "item123", "tenant123").Return(item, nil)
+
+	_, err := s.quarantineUseCase.ReleaseQuarantinedItem(ctx, "item123", "tenant123", "admin123", models.QuarantineReleaseRescan, "")
+
+	assert.Equal(s.T(), ErrQuarantinedItemResolved, err)
+	s.mockStorageService.AssertNotCalled(s.T(), "ReleaseFromQuarantine", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestDestroyQuarantinedItem tests that destroying an item deletes the quarantined content
+func (s *QuarantineUseCaseTestSuite) TestDestroyQuarantinedItem() {
+	ctx := context.Background()
+	s.mockQuarantineRepo.On("GetByID", ctx, "item123", "tenant123").Return(s.createTestItem(), nil)
+	s.mockStorageService.On("DeleteDocument", ctx, "quarantine/tenant123/doc123").Return(nil)
+	s.mockQuarantineRepo.On("Resolve", ctx, mock.MatchedBy(func(item *models.QuarantinedItem) bool {
+		return item.Status == models.QuarantineStatusDestroyed && item.ResolvedAt != nil
+	})).Return(nil)
+	// An audit failure does not fail the destruction
+	s.mockAuditService.On("RecordAction", ctx, "tenant123", "admin123", models.AuditActionDestroy, models.AuditResourceQuarantinedItem, "item123", mock.Anything, mock.Anything).Return(errors.New("audit unavailable"))
+
+	item, err := s.quarantineUseCase.DestroyQuarantinedItem(ctx, "item123", "tenant123", "admin123", "confirmed malware")
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), models.QuarantineStatusDestroyed, item.Status)
+	s.mockStorageService.AssertExpectations(s.T())
+	s.mockAuditService.AssertExpectations(s.T())
+}
+
+// TestDestroyQuarantinedItem_DeleteFails tests that the item stays quarantined when its content cannot be deleted
+func (s *QuarantineUseCaseTestSuite) TestDestroyQuarantinedItem_DeleteFails() {
+	ctx := context.Background()
+	s.mockQuarantineRepo.On("GetByID", ctx, "item123", "tenant123").Return(s.createTestItem(), nil)
+	s.mockStorageService.On("DeleteDocument", ctx, "quarantine/tenant123/doc123").Return(errors.New("storage unavailable"))
+
+	_, err := s.quarantineUseCase.DestroyQuarantinedItem(ctx, "item123", "tenant123", "admin123", "")
+
+	assert.Error(s.T(), err)
+	s.mockQuarantineRepo.AssertNotCalled(s.T(), "Resolve", mock.Anything, mock.Anything)
+}
+
+// TestQuarantineUseCaseSuite runs the QuarantineUseCase test suite
+func TestQuarantineUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(QuarantineUseCaseTestSuite))
+}
@@ -0,0 +1,121 @@
+// Package usecases implements the application layer of the Document Management Platform.
+// It contains use case implementations that orchestrate domain models and services.
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"../../domain/models"
+	"../../domain/repositories"
+	"../../domain/services"
+	"../../pkg/errors"
+	"../../pkg/logger"
+)
+
+// ErrReindexJobNotFound is returned for unknown reindex jobs
+var ErrReindexJobNotFound = errors.NewResourceNotFoundError("reindex job not found")
+
+// ReindexUseCase defines the contract for administrator-triggered rebuilds of the search index
+type ReindexUseCase interface {
+	// ReindexTenant schedules a rebuild of the search index of the tenant, which the worker runs.
+	// If a rebuild is already scheduled or running, that job is returned instead.
+	ReindexTenant(ctx context.Context, tenantID, userID string) (*models.ReindexJob, error)
+
+	// GetReindexJob retrieves a reindex job of the tenant
+	GetReindexJob(ctx context.Context, id, tenantID string) (*models.ReindexJob, error)
+}
+
+// reindexUseCase implements the ReindexUseCase interface
+type reindexUseCase struct {
+	reindexJobRepo   repositories.ReindexJobRepository
+	auditService     services.AuditService
+	rebuildSupported bool
+}
+
+// NewReindexUseCase creates a new ReindexUseCase instance. Rebuilds are rejected when the indexer
+// of the configured search provider cannot rebuild the index.
+func NewReindexUseCase(
+	reindexJobRepo repositories.ReindexJobRepository,
+	indexer services.SearchIndexer,
+	auditService services.AuditService,
+) (ReindexUseCase, error) {
+	if reindexJobRepo == nil {
+		return nil, fmt.Errorf("reindex job repository cannot be nil")
+	}
+	if indexer == nil {
+		return nil, fmt.Errorf("search indexer cannot be nil")
+	}
+	if auditService == nil {
+		return nil, fmt.Errorf("audit service cannot be nil")
+	}
+
+	_, rebuildSupported := indexer.(services.SearchIndexRebuilder)
+
+	return &reindexUseCase{
+		reindexJobRepo:   reindexJobRepo,
+		auditService:     auditService,
+		rebuildSupported: rebuildSupported,
+	}, nil
+}
+
+// ReindexTenant schedules a rebuild of the search index of the tenant
+func (u *reindexUseCase) ReindexTenant(ctx context.Context, tenantID, userID string) (*models.ReindexJob, error) {
+	log := logger.WithContext(ctx)
+
+	if tenantID == "" {
+		return nil, errors.NewValidationError("tenant ID cannot be empty")
+	}
+	if userID == "" {
+		return nil, errors.NewValidationError("user ID cannot be empty")
+	}
+	if !u.rebuildSupported {
+		return nil, services.ErrSearchIndexRebuildUnsupported
+	}
+
+	// Concurrent rebuilds would replace each other's index
+	job, err := u.reindexJobRepo.GetUnfinished(ctx, tenantID)
+	if err != nil {
+		log.WithError(err).Error("failed to get unfinished reindex job", "tenantID", tenantID)
+		return nil, errors.Wrap(err, "failed to get unfinished reindex job")
+	}
+	if job != nil {
+		return job, nil
+	}
+
+	job = models.NewReindexJob(tenantID, userID)
+	if _, err := u.reindexJobRepo.Create(ctx, job); err != nil {
+		log.WithError(err).Error("failed to create reindex job", "tenantID", tenantID)
+		return nil, errors.Wrap(err, "failed to create reindex job")
+	}
+
+	err = u.auditService.RecordAction(ctx, tenantID, userID, models.AuditActionCreate, models.AuditResourceReindexJob, job.ID, nil, nil)
+	if err != nil {
+		log.WithError(err).Error("failed to record reindex job creation in audit log")
+		// Do not return error, the rebuild has already been scheduled
+	}
+
+	log.Info("search index rebuild scheduled", "reindexID", job.ID, "tenantID", tenantID)
+	return job, nil
+}
+
+// GetReindexJob retrieves a reindex job of the tenant
+func (u *reindexUseCase) GetReindexJob(ctx context.Context, id, tenantID string) (*models.ReindexJob, error) {
+	if id == "" {
+		return nil, errors.NewValidationError("reindex ID cannot be empty")
+	}
+	if tenantID == "" {
+		return nil, errors.NewValidationError("tenant ID cannot be empty")
+	}
+
+	job, err := u.reindexJobRepo.GetByID(ctx, id, tenantID)
+	if err != nil {
+		if errors.IsResourceNotFoundError(err) {
+			return nil, ErrReindexJobNotFound
+		}
+		logger.WithContext(ctx).WithError(err).Error("failed to get reindex job", "reindexID", id, "tenantID", tenantID)
+		return nil, errors.Wrap(err, "failed to get reindex job")
+	}
+
+	return job, nil
+}
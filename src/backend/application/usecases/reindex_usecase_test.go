@@ -0,0 +1,136 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert" // v1.8.0+
+	"github.com/stretchr/testify/mock"   // v1.8.0+
+	"github.com/stretchr/testify/suite"  // v1.8.0+
+
+	"../../domain/models"
+	"../../domain/services"
+)
+
+// MockReindexJobRepository is a mock implementation of the ReindexJobRepository interface for testing
+type MockReindexJobRepository struct {
+	mock.Mock
+}
+
+func (m *MockReindexJobRepository) Create(ctx context.Context, job *models.ReindexJob) (string, error) {
+	args := m.Called(ctx, job)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockReindexJobRepository) GetByID(ctx context.Context, id string, tenantID string) (*models.ReindexJob, error) {
+	args := m.Called(ctx, id, tenantID)
+	if job := args.Get(0); job != nil {
+		return job.(*models.ReindexJob), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockReindexJobRepository) GetUnfinished(ctx context.Context, tenantID string) (*models.ReindexJob, error) {
+	args := m.Called(ctx, tenantID)
+	if job := args.Get(0); job != nil {
+		return job.(*models.ReindexJob), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockReindexJobRepository) ClaimNext(ctx context.Context, staleBefore time.Time) (*models.ReindexJob, error) {
+	args := m.Called(ctx, staleBefore)
+	if job := args.Get(0); job != nil {
+		return job.(*models.ReindexJob), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockReindexJobRepository) Update(ctx context.Context, job *models.ReindexJob) error {
+	args := m.Called(ctx, job)
+	return args.Error(0)
+}
+
+// mockReindexSearchIndexer is a search indexer that cannot rebuild its index
+type mockReindexSearchIndexer struct {
+	services.SearchIndexer
+}
+
+// mockRebuildingSearchIndexer is a search indexer that can rebuild its index
+type mockRebuildingSearchIndexer struct {
+	services.SearchIndexer
+}
+
+func (m *mockRebuildingSearchIndexer) BeginRebuild(ctx context.Context, tenantID string, rebuildID string) (services.SearchIndexRebuild, error) {
+	return nil, nil
+}
+
+// ReindexUseCaseTestSuite defines a test suite for ReindexUseCase
+type ReindexUseCaseTestSuite struct {
+	suite.Suite
+	mockReindexJobRepo *MockReindexJobRepository
+	mockAuditService   *MockAuditService
+	reindexUseCase     ReindexUseCase
+}
+
+// SetupTest sets up the test environment before each test
+func (s *ReindexUseCaseTestSuite) SetupTest() {
+	s.mockReindexJobRepo = new(MockReindexJobRepository)
+	s.mockAuditService = new(MockAuditService)
+	s.mockAuditService.On("RecordAction", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	var err error
+	s.reindexUseCase, err = NewReindexUseCase(s.mockReindexJobRepo, &mockRebuildingSearchIndexer{}, s.mockAuditService)
+	assert.Nil(s.T(), err)
+}
+
+// TestReindexTenant_CreatesPendingJob tests that reindexing schedules a pending job
+func (s *ReindexUseCaseTestSuite) TestReindexTenant_CreatesPendingJob() {
+	ctx := context.Background()
+	s.mockReindexJobRepo.On("GetUnfinished", ctx, "tenant123").Return(nil, nil)
+	s.mockReindexJobRepo.On("Create", ctx, mock.MatchedBy(func(job *models.ReindexJob) bool {
+		return job.TenantID == "tenant123" && job.UserID == "admin123" && job.Status == models.ReindexJobStatusPending
+	})).Run(func(args mock.Arguments) {
+		args.Get(1).(*models.ReindexJob).ID = "reindex123"
+	}).Return("reindex123", nil)
+
+	job, err := s.reindexUseCase.ReindexTenant(ctx, "tenant123", "admin123")
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "reindex123", job.ID)
+	s.mockReindexJobRepo.AssertExpectations(s.T())
+}
+
+// TestReindexTenant_ReturnsUnfinishedJob tests that a rebuild in progress is returned instead of
+// scheduling another one
+func (s *ReindexUseCaseTestSuite) TestReindexTenant_ReturnsUnfinishedJob() {
+	ctx := context.Background()
+	running := models.NewReindexJob("tenant123", "admin456")
+	running.ID = "reindex456"
+	running.Status = models.ReindexJobStatusRunning
+	s.mockReindexJobRepo.On("GetUnfinished", ctx, "tenant123").Return(running, nil)
+
+	job, err := s.reindexUseCase.ReindexTenant(ctx, "tenant123", "admin123")
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), running, job)
+	s.mockReindexJobRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+}
+
+// TestReindexTenant_Unsupported tests that rebuilds are rejected when the search provider cannot rebuild
+func (s *ReindexUseCaseTestSuite) TestReindexTenant_Unsupported() {
+	reindexUseCase, err := NewReindexUseCase(s.mockReindexJobRepo, &mockReindexSearchIndexer{}, s.mockAuditService)
+	assert.NoError(s.T(), err)
+
+	job, err := reindexUseCase.ReindexTenant(context.Background(), "tenant123", "admin123")
+
+	assert.Nil(s.T(), job)
+	assert.Equal(s.T(), services.ErrSearchIndexRebuildUnsupported, err)
+	s.mockReindexJobRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+}
+
+// TestReindexUseCaseSuite runs the ReindexUseCase test suite
+func TestReindexUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(ReindexUseCaseTestSuite))
+}
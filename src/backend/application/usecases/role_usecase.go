@@ -0,0 +1,161 @@
+// Package usecases implements the application layer of the Document Management Platform.
+// It contains use case implementations that orchestrate domain models and services.
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"../../domain/models"
+	"../../domain/services"
+	"../../pkg/errors"
+	"../../pkg/logger"
+	"../../pkg/utils"
+)
+
+// RoleUseCase defines the contract for role management application use cases
+type RoleUseCase interface {
+	// CreateRole creates a custom role in a tenant granting the given permissions
+	CreateRole(ctx context.Context, tenantID, name, description string, permissions []string) (*models.Role, error)
+
+	// GetRole retrieves a role by its ID
+	GetRole(ctx context.Context, id string, tenantID string) (*models.Role, error)
+
+	// ListRoles lists a tenant's roles with pagination
+	ListRoles(ctx context.Context, tenantID string, page int, pageSize int) (utils.PaginatedResult[models.Role], error)
+
+	// UpdateRole updates the description and permissions of a custom role
+	UpdateRole(ctx context.Context, id, tenantID, description string, permissions []string) (*models.Role, error)
+
+	// DeleteRole deletes a custom role
+	DeleteRole(ctx context.Context, id string, tenantID string) error
+}
+
+// roleUseCase implements the RoleUseCase interface
+type roleUseCase struct {
+	roleService services.RoleService
+}
+
+// NewRoleUseCase creates a new RoleUseCase instance
+func NewRoleUseCase(roleService services.RoleService) (RoleUseCase, error) {
+	if roleService == nil {
+		return nil, fmt.Errorf("role service cannot be nil")
+	}
+
+	return &roleUseCase{
+		roleService: roleService,
+	}, nil
+}
+
+// CreateRole creates a custom role in a tenant granting the given permissions
+func (u *roleUseCase) CreateRole(ctx context.Context, tenantID, name, description string, permissions []string) (*models.Role, error) {
+	log := logger.WithContext(ctx)
+
+	if err := u.validateInput(map[string]string{
+		"tenant ID": tenantID,
+		"role name": name,
+	}); err != nil {
+		return nil, err
+	}
+
+	role, err := u.roleService.CreateRole(ctx, tenantID, name, description, permissions)
+	if err != nil {
+		log.WithError(err).Error("failed to create role", "tenantID", tenantID, "name", name)
+		return nil, errors.Wrap(err, "failed to create role")
+	}
+
+	log.Info("role created successfully", "roleID", role.ID, "tenantID", tenantID)
+	return role, nil
+}
+
+// GetRole retrieves a role by its ID
+func (u *roleUseCase) GetRole(ctx context.Context, id string, tenantID string) (*models.Role, error) {
+	log := logger.WithContext(ctx)
+
+	if err := u.validateInput(map[string]string{
+		"role ID":   id,
+		"tenant ID": tenantID,
+	}); err != nil {
+		return nil, err
+	}
+
+	role, err := u.roleService.GetRole(ctx, id, tenantID)
+	if err != nil {
+		log.WithError(err).Error("failed to get role", "id", id, "tenantID", tenantID)
+		return nil, errors.Wrap(err, "failed to get role")
+	}
+
+	return role, nil
+}
+
+// ListRoles lists a tenant's roles with pagination
+func (u *roleUseCase) ListRoles(ctx context.Context, tenantID string, page int, pageSize int) (utils.PaginatedResult[models.Role], error) {
+	log := logger.WithContext(ctx)
+
+	if tenantID == "" {
+		log.Error("tenant ID cannot be empty")
+		return utils.PaginatedResult[models.Role]{}, errors.NewValidationError("tenant ID is required")
+	}
+
+	pagination := utils.NewPagination(page, pageSize)
+
+	result, err := u.roleService.ListRoles(ctx, tenantID, pagination)
+	if err != nil {
+		log.WithError(err).Error("failed to list roles", "tenantID", tenantID)
+		return utils.PaginatedResult[models.Role]{}, errors.Wrap(err, "failed to list roles")
+	}
+
+	log.Info("roles listed successfully", "tenantID", tenantID, "count", len(result.Items))
+	return result, nil
+}
+
+// UpdateRole updates the description and permissions of a custom role
+func (u *roleUseCase) UpdateRole(ctx context.Context, id, tenantID, description string, permissions []string) (*models.Role, error) {
+	log := logger.WithContext(ctx)
+
+	if err := u.validateInput(map[string]string{
+		"role ID":   id,
+		"tenant ID": tenantID,
+	}); err != nil {
+		return nil, err
+	}
+
+	role, err := u.roleService.UpdateRole(ctx, id, tenantID, description, permissions)
+	if err != nil {
+		log.WithError(err).Error("failed to update role", "id", id, "tenantID", tenantID)
+		return nil, errors.Wrap(err, "failed to update role")
+	}
+
+	log.Info("role updated successfully", "roleID", id, "tenantID", tenantID)
+	return role, nil
+}
+
+// DeleteRole deletes a custom role
+func (u *roleUseCase) DeleteRole(ctx context.Context, id string, tenantID string) error {
+	log := logger.WithContext(ctx)
+
+	if err := u.validateInput(map[string]string{
+		"role ID":   id,
+		"tenant ID": tenantID,
+	}); err != nil {
+		return err
+	}
+
+	if err := u.roleService.DeleteRole(ctx, id, tenantID); err != nil {
+		log.WithError(err).Error("failed to delete role", "id", id, "tenantID", tenantID)
+		return errors.Wrap(err, "failed to delete role")
+	}
+
+	log.Info("role deleted successfully", "roleID", id, "tenantID", tenantID)
+	return nil
+}
+
+// validateInput validates that required input parameters are not empty
+func (u *roleUseCase) validateInput(params map[string]string) error {
+	for name, value := range params {
+		if value == "" {
+			return errors.NewValidationError(fmt.Sprintf("%s cannot be empty", name))
+		}
+	}
+	return nil
+}
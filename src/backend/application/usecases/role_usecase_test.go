@@ -0,0 +1,145 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert" // v1.8.0+
+	"github.com/stretchr/testify/mock"   // v1.8.0+
+	"github.com/stretchr/testify/suite"  // v1.8.0+
+
+	"../../domain/models"
+	pkgErrors "../../pkg/errors"
+	"../../pkg/utils"
+)
+
+// MockRoleService is a mock implementation of the RoleService interface for testing
+type MockRoleService struct {
+	mock.Mock
+}
+
+// CreateRole mock implementation for creating a role
+func (m *MockRoleService) CreateRole(ctx context.Context, tenantID, name, description string, permissions []string) (*models.Role, error) {
+	args := m.Called(ctx, tenantID, name, description, permissions)
+	if role := args.Get(0); role != nil {
+		return role.(*models.Role), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// GetRole mock implementation for retrieving a role
+func (m *MockRoleService) GetRole(ctx context.Context, id string, tenantID string) (*models.Role, error) {
+	args := m.Called(ctx, id, tenantID)
+	if role := args.Get(0); role != nil {
+		return role.(*models.Role), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// ListRoles mock implementation for listing roles
+func (m *MockRoleService) ListRoles(ctx context.Context, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Role], error) {
+	args := m.Called(ctx, tenantID, pagination)
+	return args.Get(0).(utils.PaginatedResult[models.Role]), args.Error(1)
+}
+
+// UpdateRole mock implementation for updating a role
+func (m *MockRoleService) UpdateRole(ctx context.Context, id, tenantID, description string, permissions []string) (*models.Role, error) {
+	args := m.Called(ctx, id, tenantID, description, permissions)
+	if role := args.Get(0); role != nil {
+		return role.(*models.Role), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// DeleteRole mock implementation for deleting a role
+func (m *MockRoleService) DeleteRole(ctx context.Context, id string, tenantID string) error {
+	args := m.Called(ctx, id, tenantID)
+	return args.Error(0)
+}
+
+// RoleUseCaseTestSuite defines a test suite for RoleUseCase
+type RoleUseCaseTestSuite struct {
+	suite.Suite
+	mockRoleService *MockRoleService
+	roleUseCase     RoleUseCase
+}
+
+// SetupTest sets up the test environment before each test
+func (s *RoleUseCaseTestSuite) SetupTest() {
+	s.mockRoleService = new(MockRoleService)
+
+	var err error
+	s.roleUseCase, err = NewRoleUseCase(s.mockRoleService)
+	assert.Nil(s.T(), err)
+	assert.NotNil(s.T(), s.roleUseCase)
+}
+
+// TestNewRoleUseCase tests the creation of a new RoleUseCase
+func (s *RoleUseCaseTestSuite) TestNewRoleUseCase() {
+	useCase, err := NewRoleUseCase(nil)
+	assert.NotNil(s.T(), err)
+	assert.Nil(s.T(), useCase)
+}
+
+// TestCreateRole_Success tests successful custom role creation
+func (s *RoleUseCaseTestSuite) TestCreateRole_Success() {
+	permissions := []string{models.RolePermissionRead, models.RolePermissionWrite}
+	role := &models.Role{ID: "role123", TenantID: "tenant123", Name: "reviewer", Description: "Reviews documents", Permissions: permissions}
+	s.mockRoleService.On("CreateRole", mock.Anything, "tenant123", "reviewer", "Reviews documents", permissions).Return(role, nil)
+
+	result, err := s.roleUseCase.CreateRole(context.Background(), "tenant123", "reviewer", "Reviews documents", permissions)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), role, result)
+	s.mockRoleService.AssertExpectations(s.T())
+}
+
+// TestCreateRole_ValidationError tests role creation without a name
+func (s *RoleUseCaseTestSuite) TestCreateRole_ValidationError() {
+	result, err := s.roleUseCase.CreateRole(context.Background(), "tenant123", "", "Reviews documents", nil)
+	assert.Nil(s.T(), result)
+	assert.True(s.T(), pkgErrors.IsValidationError(err))
+
+	s.mockRoleService.AssertNotCalled(s.T(), "CreateRole")
+}
+
+// TestListRoles_Success tests successful role listing
+func (s *RoleUseCaseTestSuite) TestListRoles_Success() {
+	expected := utils.PaginatedResult[models.Role]{
+		Items: []models.Role{{ID: "role1", TenantID: "tenant123", Name: models.RoleReader}},
+	}
+	s.mockRoleService.On("ListRoles", mock.Anything, "tenant123", mock.AnythingOfType("*utils.Pagination")).Return(expected, nil)
+
+	result, err := s.roleUseCase.ListRoles(context.Background(), "tenant123", 1, 20)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), expected, result)
+	s.mockRoleService.AssertExpectations(s.T())
+}
+
+// TestUpdateRole_ServiceError tests a role update rejected by the service
+func (s *RoleUseCaseTestSuite) TestUpdateRole_ServiceError() {
+	s.mockRoleService.On("UpdateRole", mock.Anything, "role123", "tenant123", "", []string{models.RolePermissionRead}).Return(nil, errors.New("service error"))
+
+	result, err := s.roleUseCase.UpdateRole(context.Background(), "role123", "tenant123", "", []string{models.RolePermissionRead})
+
+	assert.Nil(s.T(), result)
+	assert.NotNil(s.T(), err)
+	s.mockRoleService.AssertExpectations(s.T())
+}
+
+// TestDeleteRole_Success tests successful role deletion
+func (s *RoleUseCaseTestSuite) TestDeleteRole_Success() {
+	s.mockRoleService.On("DeleteRole", mock.Anything, "role123", "tenant123").Return(nil)
+
+	err := s.roleUseCase.DeleteRole(context.Background(), "role123", "tenant123")
+
+	assert.Nil(s.T(), err)
+	s.mockRoleService.AssertExpectations(s.T())
+}
+
+// TestRoleUseCaseSuite entry point for running the RoleUseCase test suite
+func TestRoleUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(RoleUseCaseTestSuite))
+}
@@ -5,12 +5,14 @@ import (
 	"context" // standard library
 	"fmt"     // standard library
 	"strings" // standard library
+	"time"    // standard library
 
 	"../../domain/models"
 	"../../domain/repositories"
 	"../../domain/services"
 	"../../pkg/errors"
 	"../../pkg/logger"
+	"../../pkg/metrics"
 	"../../pkg/utils"
 )
 
@@ -26,11 +28,11 @@ type SearchUseCase interface {
 	// SearchByContent searches documents by their content
 	SearchByContent(ctx context.Context, query string, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Document], error)
 
-	// SearchByMetadata searches documents by their metadata
-	SearchByMetadata(ctx context.Context, metadata map[string]string, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Document], error)
+	// SearchByMetadata searches documents by their metadata, matching values and filters
+	SearchByMetadata(ctx context.Context, metadata map[string]string, filters []models.MetadataFilter, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Document], error)
 
 	// CombinedSearch performs a search using both content and metadata criteria
-	CombinedSearch(ctx context.Context, contentQuery string, metadata map[string]string, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Document], error)
+	CombinedSearch(ctx context.Context, contentQuery string, metadata map[string]string, filters []models.MetadataFilter, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Document], error)
 
 	// SearchInFolder searches documents within a specific folder
 	SearchInFolder(ctx context.Context, folderID string, query string, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Document], error)
@@ -78,7 +80,9 @@ func (u *searchUseCaseImpl) SearchByContent(ctx context.Context, query string, t
 	}
 
 	// Call the domain service to perform the search
+	start := time.Now()
 	result, err := u.searchService.SearchByContent(ctx, query, tenantID, pagination)
+	metrics.ObserveDocumentSearch("content", time.Since(start), err)
 	if err != nil {
 		logger.ErrorContext(ctx, "Failed to perform content search", "error", err, "query", query, "tenantID", tenantID)
 		return utils.PaginatedResult[models.Document]{}, errors.Wrap(err, "failed to perform content search")
@@ -87,12 +91,12 @@ func (u *searchUseCaseImpl) SearchByContent(ctx context.Context, query string, t
 	return result, nil
 }
 
-// SearchByMetadata searches documents by their metadata.
-func (u *searchUseCaseImpl) SearchByMetadata(ctx context.Context, metadata map[string]string, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Document], error) {
-	logger.InfoContext(ctx, "SearchByMetadata request", "metadata", metadata, "tenantID", tenantID)
+// SearchByMetadata searches documents by their metadata, matching values and filters.
+func (u *searchUseCaseImpl) SearchByMetadata(ctx context.Context, metadata map[string]string, filters []models.MetadataFilter, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Document], error) {
+	logger.InfoContext(ctx, "SearchByMetadata request", "metadata", metadata, "filters", filters, "tenantID", tenantID)
 
 	// Validate metadata
-	if metadata == nil || len(metadata) == 0 {
+	if len(metadata) == 0 && len(filters) == 0 {
 		return utils.PaginatedResult[models.Document]{}, ErrEmptyMetadataQuery
 	}
 
@@ -107,7 +111,9 @@ func (u *searchUseCaseImpl) SearchByMetadata(ctx context.Context, metadata map[s
 	}
 
 	// Call the domain service to perform the search
-	result, err := u.searchService.SearchByMetadata(ctx, metadata, tenantID, pagination)
+	start := time.Now()
+	result, err := u.searchService.SearchByMetadata(ctx, metadata, filters, tenantID, pagination)
+	metrics.ObserveDocumentSearch("metadata", time.Since(start), err)
 	if err != nil {
 		logger.ErrorContext(ctx, "Failed to perform metadata search", "error", err, "metadata", metadata, "tenantID", tenantID)
 		return utils.PaginatedResult[models.Document]{}, errors.Wrap(err, "failed to perform metadata search")
@@ -117,12 +123,12 @@ func (u *searchUseCaseImpl) SearchByMetadata(ctx context.Context, metadata map[s
 }
 
 // CombinedSearch performs a search using both content and metadata criteria.
-func (u *searchUseCaseImpl) CombinedSearch(ctx context.Context, contentQuery string, metadata map[string]string, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Document], error) {
-	logger.InfoContext(ctx, "CombinedSearch request", "contentQuery", contentQuery, "metadata", metadata, "tenantID", tenantID)
+func (u *searchUseCaseImpl) CombinedSearch(ctx context.Context, contentQuery string, metadata map[string]string, filters []models.MetadataFilter, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Document], error) {
+	logger.InfoContext(ctx, "CombinedSearch request", "contentQuery", contentQuery, "metadata", metadata, "filters", filters, "tenantID", tenantID)
 
 	// Validate that at least one search criterion is provided
 	contentQueryEmpty := strings.TrimSpace(contentQuery) == ""
-	metadataEmpty := metadata == nil || len(metadata) == 0
+	metadataEmpty := len(metadata) == 0 && len(filters) == 0
 
 	if contentQueryEmpty && metadataEmpty {
 		return utils.PaginatedResult[models.Document]{}, ErrNoSearchCriteria
@@ -139,7 +145,9 @@ func (u *searchUseCaseImpl) CombinedSearch(ctx context.Context, contentQuery str
 	}
 
 	// Call the domain service to perform the search
-	result, err := u.searchService.CombinedSearch(ctx, contentQuery, metadata, tenantID, pagination)
+	start := time.Now()
+	result, err := u.searchService.CombinedSearch(ctx, contentQuery, metadata, filters, tenantID, pagination)
+	metrics.ObserveDocumentSearch("combined", time.Since(start), err)
 	if err != nil {
 		logger.ErrorContext(ctx, "Failed to perform combined search",
 			"error", err,
@@ -177,7 +185,9 @@ func (u *searchUseCaseImpl) SearchInFolder(ctx context.Context, folderID string,
 	}
 
 	// Call the domain service to perform the search
+	start := time.Now()
 	result, err := u.searchService.SearchInFolder(ctx, folderID, query, tenantID, pagination)
+	metrics.ObserveDocumentSearch("folder", time.Since(start), err)
 	if err != nil {
 		logger.ErrorContext(ctx, "Failed to perform folder search",
 			"error", err,
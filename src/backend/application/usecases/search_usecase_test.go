@@ -27,13 +27,13 @@ func (m *MockSearchService) SearchByContent(ctx context.Context, query string, t
 	return args.Get(0).(utils.PaginatedResult[models.Document]), args.Error(1)
 }
 
-func (m *MockSearchService) SearchByMetadata(ctx context.Context, metadata map[string]string, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Document], error) {
-	args := m.Called(ctx, metadata, tenantID, pagination)
+func (m *MockSearchService) SearchByMetadata(ctx context.Context, metadata map[string]string, filters []models.MetadataFilter, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Document], error) {
+	args := m.Called(ctx, metadata, filters, tenantID, pagination)
 	return args.Get(0).(utils.PaginatedResult[models.Document]), args.Error(1)
 }
 
-func (m *MockSearchService) CombinedSearch(ctx context.Context, contentQuery string, metadata map[string]string, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Document], error) {
-	args := m.Called(ctx, contentQuery, metadata, tenantID, pagination)
+func (m *MockSearchService) CombinedSearch(ctx context.Context, contentQuery string, metadata map[string]string, filters []models.MetadataFilter, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Document], error) {
+	args := m.Called(ctx, contentQuery, metadata, filters, tenantID, pagination)
 	return args.Get(0).(utils.PaginatedResult[models.Document]), args.Error(1)
 }
 
@@ -52,6 +52,11 @@ func (m *MockSearchService) RemoveDocumentFromIndex(ctx context.Context, documen
 	return args.Error(0)
 }
 
+func (m *MockSearchService) UpdateIndexedMetadata(ctx context.Context, documents []*models.Document, tenantID string) error {
+	args := m.Called(ctx, documents, tenantID)
+	return args.Error(0)
+}
+
 // SearchUseCaseTestSuite defines the test suite for the search use case
 type SearchUseCaseTestSuite struct {
 	suite.Suite
@@ -195,11 +200,11 @@ func (s *SearchUseCaseTestSuite) TestSearchByMetadata_Success() {
 	}
 	
 	// Set up mock search service to return expected result
-	s.mockSearchService.On("SearchByMetadata", ctx, metadata, tenantID, pagination).
+	s.mockSearchService.On("SearchByMetadata", ctx, metadata, []models.MetadataFilter(nil), tenantID, pagination).
 		Return(expectedResult, nil)
 	
 	// Call searchUseCase.SearchByMetadata with test data
-	result, err := s.searchUseCase.SearchByMetadata(ctx, metadata, tenantID, pagination)
+	result, err := s.searchUseCase.SearchByMetadata(ctx, metadata, nil, tenantID, pagination)
 	
 	// Assert that the returned result matches expected result
 	assert.NoError(s.T(), err)
@@ -212,7 +217,7 @@ func (s *SearchUseCaseTestSuite) TestSearchByMetadata_Success() {
 // TestSearchByMetadata_EmptyMetadata tests that metadata search with empty metadata returns an error
 func (s *SearchUseCaseTestSuite) TestSearchByMetadata_EmptyMetadata() {
 	// Call searchUseCase.SearchByMetadata with empty metadata map
-	_, err := s.searchUseCase.SearchByMetadata(context.Background(), nil, "tenant-123", utils.NewPagination(1, 10))
+	_, err := s.searchUseCase.SearchByMetadata(context.Background(), nil, nil, "tenant-123", utils.NewPagination(1, 10))
 	
 	// Assert that an error is returned
 	assert.Error(s.T(), err)
@@ -223,11 +228,28 @@ func (s *SearchUseCaseTestSuite) TestSearchByMetadata_EmptyMetadata() {
 	s.mockSearchService.AssertNotCalled(s.T(), "SearchByMetadata")
 }
 
+// TestSearchByMetadata_FiltersOnly tests that metadata search accepts filters without metadata values
+func (s *SearchUseCaseTestSuite) TestSearchByMetadata_FiltersOnly() {
+	ctx := context.Background()
+	filters := []models.MetadataFilter{{Key: "amount", Operator: models.MetadataFilterGte, Value: "1000"}}
+	pagination := utils.NewPagination(1, 10)
+	expectedResult := utils.PaginatedResult[models.Document]{Items: []*models.Document{}}
+
+	s.mockSearchService.On("SearchByMetadata", ctx, map[string]string(nil), filters, "tenant-123", pagination).
+		Return(expectedResult, nil)
+
+	result, err := s.searchUseCase.SearchByMetadata(ctx, nil, filters, "tenant-123", pagination)
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), expectedResult, result)
+	s.mockSearchService.AssertExpectations(s.T())
+}
+
 // TestSearchByMetadata_EmptyTenantID tests that metadata search with empty tenant ID returns an error
 func (s *SearchUseCaseTestSuite) TestSearchByMetadata_EmptyTenantID() {
 	// Call searchUseCase.SearchByMetadata with empty tenant ID
 	metadata := map[string]string{"key": "value"}
-	_, err := s.searchUseCase.SearchByMetadata(context.Background(), metadata, "", utils.NewPagination(1, 10))
+	_, err := s.searchUseCase.SearchByMetadata(context.Background(), metadata, nil, "", utils.NewPagination(1, 10))
 	
 	// Assert that an error is returned
 	assert.Error(s.T(), err)
@@ -248,11 +270,11 @@ func (s *SearchUseCaseTestSuite) TestSearchByMetadata_ServiceError() {
 	
 	// Set up mock search service to return an error
 	expectedError := errors.New("service error")
-	s.mockSearchService.On("SearchByMetadata", ctx, metadata, tenantID, pagination).
+	s.mockSearchService.On("SearchByMetadata", ctx, metadata, []models.MetadataFilter(nil), tenantID, pagination).
 		Return(utils.PaginatedResult[models.Document]{}, expectedError)
 	
 	// Call searchUseCase.SearchByMetadata with test data
-	_, err := s.searchUseCase.SearchByMetadata(ctx, metadata, tenantID, pagination)
+	_, err := s.searchUseCase.SearchByMetadata(ctx, metadata, nil, tenantID, pagination)
 	
 	// Assert that an error is returned
 	assert.Error(s.T(), err)
@@ -287,11 +309,11 @@ func (s *SearchUseCaseTestSuite) TestCombinedSearch_Success() {
 	}
 	
 	// Set up mock search service to return expected result
-	s.mockSearchService.On("CombinedSearch", ctx, contentQuery, metadata, tenantID, pagination).
+	s.mockSearchService.On("CombinedSearch", ctx, contentQuery, metadata, []models.MetadataFilter(nil), tenantID, pagination).
 		Return(expectedResult, nil)
 	
 	// Call searchUseCase.CombinedSearch with test data
-	result, err := s.searchUseCase.CombinedSearch(ctx, contentQuery, metadata, tenantID, pagination)
+	result, err := s.searchUseCase.CombinedSearch(ctx, contentQuery, metadata, nil, tenantID, pagination)
 	
 	// Assert that the returned result matches expected result
 	assert.NoError(s.T(), err)
@@ -304,7 +326,7 @@ func (s *SearchUseCaseTestSuite) TestCombinedSearch_Success() {
 // TestCombinedSearch_NoSearchCriteria tests that combined search with no search criteria returns an error
 func (s *SearchUseCaseTestSuite) TestCombinedSearch_NoSearchCriteria() {
 	// Call searchUseCase.CombinedSearch with empty content query and empty metadata
-	_, err := s.searchUseCase.CombinedSearch(context.Background(), "", nil, "tenant-123", utils.NewPagination(1, 10))
+	_, err := s.searchUseCase.CombinedSearch(context.Background(), "", nil, nil, "tenant-123", utils.NewPagination(1, 10))
 	
 	// Assert that an error is returned
 	assert.Error(s.T(), err)
@@ -320,7 +342,7 @@ func (s *SearchUseCaseTestSuite) TestCombinedSearch_EmptyTenantID() {
 	// Call searchUseCase.CombinedSearch with empty tenant ID
 	contentQuery := "test query"
 	metadata := map[string]string{"key": "value"}
-	_, err := s.searchUseCase.CombinedSearch(context.Background(), contentQuery, metadata, "", utils.NewPagination(1, 10))
+	_, err := s.searchUseCase.CombinedSearch(context.Background(), contentQuery, metadata, nil, "", utils.NewPagination(1, 10))
 	
 	// Assert that an error is returned
 	assert.Error(s.T(), err)
@@ -342,11 +364,11 @@ func (s *SearchUseCaseTestSuite) TestCombinedSearch_ServiceError() {
 	
 	// Set up mock search service to return an error
 	expectedError := errors.New("service error")
-	s.mockSearchService.On("CombinedSearch", ctx, contentQuery, metadata, tenantID, pagination).
+	s.mockSearchService.On("CombinedSearch", ctx, contentQuery, metadata, []models.MetadataFilter(nil), tenantID, pagination).
 		Return(utils.PaginatedResult[models.Document]{}, expectedError)
 	
 	// Call searchUseCase.CombinedSearch with test data
-	_, err := s.searchUseCase.CombinedSearch(ctx, contentQuery, metadata, tenantID, pagination)
+	_, err := s.searchUseCase.CombinedSearch(ctx, contentQuery, metadata, nil, tenantID, pagination)
 	
 	// Assert that an error is returned
 	assert.Error(s.T(), err)
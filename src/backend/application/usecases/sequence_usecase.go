@@ -0,0 +1,160 @@
+// Package usecases implements the application layer of the Document Management Platform.
+// It contains use case implementations that orchestrate domain models and services.
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"../../domain/models"
+	"../../domain/services"
+	"../../pkg/errors"
+	"../../pkg/logger"
+)
+
+// SequenceUseCase defines the contract for managing the numbering sequences documents are
+// numbered from when they are uploaded
+type SequenceUseCase interface {
+	// CreateSequence creates a new numbering sequence for a folder, or the tenant's default
+	CreateSequence(ctx context.Context, sequence *models.NumberingSequence) (string, error)
+
+	// GetSequence retrieves a numbering sequence by its ID
+	GetSequence(ctx context.Context, id string, tenantID string) (*models.NumberingSequence, error)
+
+	// ListSequences lists the numbering sequences of a tenant
+	ListSequences(ctx context.Context, tenantID string) ([]*models.NumberingSequence, error)
+
+	// UpdateSequence replaces the name, format, padding, mode and reset of an existing numbering sequence
+	UpdateSequence(ctx context.Context, sequence *models.NumberingSequence) error
+
+	// DeleteSequence deletes a numbering sequence
+	DeleteSequence(ctx context.Context, id string, tenantID string) error
+}
+
+// sequenceUseCase implements the SequenceUseCase interface
+type sequenceUseCase struct {
+	sequenceService services.SequenceService
+}
+
+// NewSequenceUseCase creates a new SequenceUseCase instance
+func NewSequenceUseCase(sequenceService services.SequenceService) (SequenceUseCase, error) {
+	if sequenceService == nil {
+		return nil, fmt.Errorf("sequence service cannot be nil")
+	}
+
+	return &sequenceUseCase{
+		sequenceService: sequenceService,
+	}, nil
+}
+
+// CreateSequence creates a new numbering sequence for a folder, or the tenant's default
+func (u *sequenceUseCase) CreateSequence(ctx context.Context, sequence *models.NumberingSequence) (string, error) {
+	log := logger.WithContext(ctx)
+
+	if sequence == nil {
+		log.Error("numbering sequence cannot be nil")
+		return "", errors.NewValidationError("numbering sequence cannot be nil")
+	}
+
+	id, err := u.sequenceService.CreateSequence(ctx, sequence)
+	if err != nil {
+		log.WithError(err).Error("failed to create numbering sequence", "tenantID", sequence.TenantID, "folderID", sequence.FolderID)
+		return "", errors.Wrap(err, "failed to create numbering sequence")
+	}
+
+	log.Info("numbering sequence created successfully", "sequenceID", id, "tenantID", sequence.TenantID)
+	return id, nil
+}
+
+// GetSequence retrieves a numbering sequence by its ID
+func (u *sequenceUseCase) GetSequence(ctx context.Context, id string, tenantID string) (*models.NumberingSequence, error) {
+	log := logger.WithContext(ctx)
+
+	if err := u.validateInput(map[string]string{
+		"numbering sequence ID": id,
+		"tenant ID":             tenantID,
+	}); err != nil {
+		return nil, err
+	}
+
+	sequence, err := u.sequenceService.GetSequence(ctx, id, tenantID)
+	if err != nil {
+		log.WithError(err).Error("failed to get numbering sequence", "id", id, "tenantID", tenantID)
+		return nil, errors.Wrap(err, "failed to get numbering sequence")
+	}
+
+	return sequence, nil
+}
+
+// ListSequences lists the numbering sequences of a tenant
+func (u *sequenceUseCase) ListSequences(ctx context.Context, tenantID string) ([]*models.NumberingSequence, error) {
+	log := logger.WithContext(ctx)
+
+	if tenantID == "" {
+		log.Error("tenant ID cannot be empty")
+		return nil, errors.NewValidationError("tenant ID is required")
+	}
+
+	sequences, err := u.sequenceService.ListSequences(ctx, tenantID)
+	if err != nil {
+		log.WithError(err).Error("failed to list numbering sequences", "tenantID", tenantID)
+		return nil, errors.Wrap(err, "failed to list numbering sequences")
+	}
+
+	return sequences, nil
+}
+
+// UpdateSequence replaces the name, format, padding, mode and reset of an existing numbering sequence
+func (u *sequenceUseCase) UpdateSequence(ctx context.Context, sequence *models.NumberingSequence) error {
+	log := logger.WithContext(ctx)
+
+	if sequence == nil {
+		log.Error("numbering sequence cannot be nil")
+		return errors.NewValidationError("numbering sequence cannot be nil")
+	}
+
+	if err := u.validateInput(map[string]string{
+		"numbering sequence ID": sequence.ID,
+		"tenant ID":             sequence.TenantID,
+	}); err != nil {
+		return err
+	}
+
+	if err := u.sequenceService.UpdateSequence(ctx, sequence); err != nil {
+		log.WithError(err).Error("failed to update numbering sequence", "id", sequence.ID, "tenantID", sequence.TenantID)
+		return errors.Wrap(err, "failed to update numbering sequence")
+	}
+
+	log.Info("numbering sequence updated successfully", "sequenceID", sequence.ID, "tenantID", sequence.TenantID)
+	return nil
+}
+
+// DeleteSequence deletes a numbering sequence
+func (u *sequenceUseCase) DeleteSequence(ctx context.Context, id string, tenantID string) error {
+	log := logger.WithContext(ctx)
+
+	if err := u.validateInput(map[string]string{
+		"numbering sequence ID": id,
+		"tenant ID":             tenantID,
+	}); err != nil {
+		return err
+	}
+
+	if err := u.sequenceService.DeleteSequence(ctx, id, tenantID); err != nil {
+		log.WithError(err).Error("failed to delete numbering sequence", "id", id, "tenantID", tenantID)
+		return errors.Wrap(err, "failed to delete numbering sequence")
+	}
+
+	log.Info("numbering sequence deleted successfully", "sequenceID", id, "tenantID", tenantID)
+	return nil
+}
+
+// validateInput validates that required input parameters are not empty
+func (u *sequenceUseCase) validateInput(params map[string]string) error {
+	for name, value := range params {
+		if value == "" {
+			return errors.NewValidationError(fmt.Sprintf("%s cannot be empty", name))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert" // v1.8.0+
+	"github.com/stretchr/testify/mock"   // v1.8.0+
+	"github.com/stretchr/testify/suite"  // v1.8.0+
+
+	"../../domain/models"
+	pkgErrors "../../pkg/errors"
+)
+
+// MockSequenceService is a mock implementation of the SequenceService interface for testing
+type MockSequenceService struct {
+	mock.Mock
+}
+
+// CreateSequence mock implementation for creating a numbering sequence
+func (m *MockSequenceService) CreateSequence(ctx context.Context, sequence *models.NumberingSequence) (string, error) {
+	args := m.Called(ctx, sequence)
+	return args.String(0), args.Error(1)
+}
+
+// GetSequence mock implementation for retrieving a numbering sequence
+func (m *MockSequenceService) GetSequence(ctx context.Context, id string, tenantID string) (*models.NumberingSequence, error) {
+	args := m.Called(ctx, id, tenantID)
+	if sequence := args.Get(0); sequence != nil {
+		return sequence.(*models.NumberingSequence), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// ListSequences mock implementation for listing numbering sequences
+func (m *MockSequenceService) ListSequences(ctx context.Context, tenantID string) ([]*models.NumberingSequence, error) {
+	args := m.Called(ctx, tenantID)
+	if sequences := args.Get(0); sequences != nil {
+		return sequences.([]*models.NumberingSequence), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// UpdateSequence mock implementation for updating a numbering sequence
+func (m *MockSequenceService) UpdateSequence(ctx context.Context, sequence *models.NumberingSequence) error {
+	args := m.Called(ctx, sequence)
+	return args.Error(0)
+}
+
+// DeleteSequence mock implementation for deleting a numbering sequence
+func (m *MockSequenceService) DeleteSequence(ctx context.Context, id string, tenantID string) error {
+	args := m.Called(ctx, id, tenantID)
+	return args.Error(0)
+}
+
+// GetUploadSequence mock implementation for finding the sequence numbering a folder's uploads
+func (m *MockSequenceService) GetUploadSequence(ctx context.Context, tenantID string, folderID string) (*models.NumberingSequence, error) {
+	args := m.Called(ctx, tenantID, folderID)
+	if sequence := args.Get(0); sequence != nil {
+		return sequence.(*models.NumberingSequence), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// NextNumber mock implementation for assigning the next number of a sequence
+func (m *MockSequenceService) NextNumber(ctx context.Context, sequence *models.NumberingSequence) (string, error) {
+	args := m.Called(ctx, sequence)
+	return args.String(0), args.Error(1)
+}
+
+// SequenceUseCaseTestSuite defines a test suite for SequenceUseCase
+type SequenceUseCaseTestSuite struct {
+	suite.Suite
+	mockSequenceService *MockSequenceService
+	sequenceUseCase     SequenceUseCase
+}
+
+// SetupTest sets up the test environment before each test
+func (s *SequenceUseCaseTestSuite) SetupTest() {
+	s.mockSequenceService = new(MockSequenceService)
+
+	var err error
+	s.sequenceUseCase, err = NewSequenceUseCase(s.mockSequenceService)
+	assert.Nil(s.T(), err)
+	assert.NotNil(s.T(), s.sequenceUseCase)
+}
+
+// TestNewSequenceUseCase tests the creation of a new SequenceUseCase
+func (s *SequenceUseCaseTestSuite) TestNewSequenceUseCase() {
+	useCase, err := NewSequenceUseCase(nil)
+	assert.NotNil(s.T(), err)
+	assert.Nil(s.T(), useCase)
+}
+
+// TestCreateSequence_Success tests successful numbering sequence creation
+func (s *SequenceUseCaseTestSuite) TestCreateSequence_Success() {
+	sequence := models.NewNumberingSequence("tenant123", "folder123", "Invoices", "INV-{YYYY}-{SEQ}", "user123")
+	s.mockSequenceService.On("CreateSequence", mock.Anything, sequence).Return("sequence123", nil)
+
+	id, err := s.sequenceUseCase.CreateSequence(context.Background(), sequence)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), "sequence123", id)
+	s.mockSequenceService.AssertExpectations(s.T())
+}
+
+// TestCreateSequence_ServiceError tests numbering sequence creation rejected by the service
+func (s *SequenceUseCaseTestSuite) TestCreateSequence_ServiceError() {
+	sequence := models.NewNumberingSequence("tenant123", "", "Default", "DOC-{SEQ}-{SEQ}", "user123")
+	s.mockSequenceService.On("CreateSequence", mock.Anything, sequence).Return("", pkgErrors.NewValidationError(models.ErrNumberingSequenceFormatInvalid.Error()))
+
+	id, err := s.sequenceUseCase.CreateSequence(context.Background(), sequence)
+
+	assert.Empty(s.T(), id)
+	assert.True(s.T(), pkgErrors.IsValidationError(err))
+	s.mockSequenceService.AssertExpectations(s.T())
+}
+
+// TestGetSequence_NotFound tests retrieving a numbering sequence of another tenant
+func (s *SequenceUseCaseTestSuite) TestGetSequence_NotFound() {
+	s.mockSequenceService.On("GetSequence", mock.Anything, "sequence123", "tenant123").Return(nil, pkgErrors.NewResourceNotFoundError("Numbering sequence not found"))
+
+	sequence, err := s.sequenceUseCase.GetSequence(context.Background(), "sequence123", "tenant123")
+
+	assert.Nil(s.T(), sequence)
+	assert.True(s.T(), pkgErrors.IsResourceNotFoundError(err))
+	s.mockSequenceService.AssertExpectations(s.T())
+}
+
+// TestUpdateSequence_ValidationError tests numbering sequence update without an ID
+func (s *SequenceUseCaseTestSuite) TestUpdateSequence_ValidationError() {
+	err := s.sequenceUseCase.UpdateSequence(context.Background(), &models.NumberingSequence{TenantID: "tenant123"})
+	assert.True(s.T(), pkgErrors.IsValidationError(err))
+
+	s.mockSequenceService.AssertNotCalled(s.T(), "UpdateSequence")
+}
+
+// TestDeleteSequence_ServiceError tests numbering sequence deletion failure
+func (s *SequenceUseCaseTestSuite) TestDeleteSequence_ServiceError() {
+	s.mockSequenceService.On("DeleteSequence", mock.Anything, "sequence123", "tenant123").Return(errors.New("service error"))
+
+	err := s.sequenceUseCase.DeleteSequence(context.Background(), "sequence123", "tenant123")
+
+	assert.NotNil(s.T(), err)
+	s.mockSequenceService.AssertExpectations(s.T())
+}
+
+// TestSequenceUseCaseSuite entry point for running the SequenceUseCase test suite
+func TestSequenceUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(SequenceUseCaseTestSuite))
+}
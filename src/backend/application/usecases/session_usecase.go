@@ -0,0 +1,193 @@
+// Package usecases implements the application layer of the Document Management Platform.
+// It contains use case implementations that orchestrate domain models and services.
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"../../domain/models"
+	"../../domain/repositories"
+	"../../domain/services"
+	"../../pkg/errors"
+	"../../pkg/logger"
+)
+
+// SessionUseCase defines the contract for listing and terminating users' active sessions.
+// Users manage their own sessions; administrators manage the sessions of any user of their tenant.
+type SessionUseCase interface {
+	// ListSessions lists the active sessions of a user, most recently used first
+	ListSessions(ctx context.Context, tenantID, actorID, userID string) ([]models.Session, error)
+
+	// RevokeSession terminates a session; its access and refresh tokens stop working immediately
+	RevokeSession(ctx context.Context, tenantID, actorID, sessionID string) error
+
+	// RevokeUserSessions terminates all active sessions of a user, forcing them to sign in again
+	// on every device. It returns the number of sessions revoked.
+	RevokeUserSessions(ctx context.Context, tenantID, actorID, userID string) (int, error)
+}
+
+// sessionUseCase implements the SessionUseCase interface
+type sessionUseCase struct {
+	sessionRepo  repositories.SessionRepository
+	authService  services.AuthService
+	auditService services.AuditService
+}
+
+// NewSessionUseCase creates a new SessionUseCase instance
+func NewSessionUseCase(
+	sessionRepo repositories.SessionRepository,
+	authService services.AuthService,
+	auditService services.AuditService,
+) (SessionUseCase, error) {
+	if sessionRepo == nil {
+		return nil, fmt.Errorf("session repository cannot be nil")
+	}
+	if authService == nil {
+		return nil, fmt.Errorf("auth service cannot be nil")
+	}
+	if auditService == nil {
+		return nil, fmt.Errorf("audit service cannot be nil")
+	}
+
+	return &sessionUseCase{
+		sessionRepo:  sessionRepo,
+		authService:  authService,
+		auditService: auditService,
+	}, nil
+}
+
+// ListSessions lists the active sessions of a user, most recently used first
+func (u *sessionUseCase) ListSessions(ctx context.Context, tenantID, actorID, userID string) ([]models.Session, error) {
+	log := logger.WithContext(ctx)
+
+	if err := u.validateInput(map[string]string{
+		"tenant ID": tenantID,
+		"actor ID":  actorID,
+		"user ID":   userID,
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := u.authorize(ctx, tenantID, actorID, userID); err != nil {
+		return nil, err
+	}
+
+	sessions, err := u.sessionRepo.ListActiveByUser(ctx, userID, tenantID)
+	if err != nil {
+		log.WithError(err).Error("failed to list sessions", "userID", userID, "tenantID", tenantID)
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+// RevokeSession terminates a session; its access and refresh tokens stop working immediately
+func (u *sessionUseCase) RevokeSession(ctx context.Context, tenantID, actorID, sessionID string) error {
+	log := logger.WithContext(ctx)
+
+	if err := u.validateInput(map[string]string{
+		"tenant ID":  tenantID,
+		"actor ID":   actorID,
+		"session ID": sessionID,
+	}); err != nil {
+		return err
+	}
+
+	session, err := u.sessionRepo.GetByID(ctx, sessionID, tenantID)
+	if err != nil {
+		return err
+	}
+
+	if err := u.authorize(ctx, tenantID, actorID, session.UserID); err != nil {
+		return err
+	}
+
+	if err := u.revoke(ctx, actorID, session); err != nil {
+		return err
+	}
+
+	log.Info("session revoked", "sessionID", sessionID, "userID", session.UserID, "tenantID", tenantID)
+	return nil
+}
+
+// RevokeUserSessions terminates all active sessions of a user
+func (u *sessionUseCase) RevokeUserSessions(ctx context.Context, tenantID, actorID, userID string) (int, error) {
+	log := logger.WithContext(ctx)
+
+	if err := u.validateInput(map[string]string{
+		"tenant ID": tenantID,
+		"actor ID":  actorID,
+		"user ID":   userID,
+	}); err != nil {
+		return 0, err
+	}
+
+	if err := u.authorize(ctx, tenantID, actorID, userID); err != nil {
+		return 0, err
+	}
+
+	sessions, err := u.sessionRepo.ListActiveByUser(ctx, userID, tenantID)
+	if err != nil {
+		log.WithError(err).Error("failed to list sessions", "userID", userID, "tenantID", tenantID)
+		return 0, err
+	}
+
+	for i := range sessions {
+		if err := u.revoke(ctx, actorID, &sessions[i]); err != nil {
+			return i, err
+		}
+	}
+
+	log.Info("user sessions revoked", "userID", userID, "tenantID", tenantID, "count", len(sessions))
+	return len(sessions), nil
+}
+
+// revoke revokes a session and records it in the audit log
+func (u *sessionUseCase) revoke(ctx context.Context, actorID string, session *models.Session) error {
+	log := logger.WithContext(ctx)
+
+	if err := u.authService.RevokeSession(ctx, session.ID, session.TenantID); err != nil {
+		log.WithError(err).Error("failed to revoke session", "sessionID", session.ID)
+		return err
+	}
+
+	err := u.auditService.RecordAction(ctx, session.TenantID, actorID, models.AuditActionRevoke, models.AuditResourceSession, session.ID, nil, map[string]interface{}{
+		"user_id":    session.UserID,
+		"ip_address": session.IPAddress,
+		"user_agent": session.UserAgent,
+	})
+	if err != nil {
+		log.WithError(err).Error("failed to record session revocation in audit log")
+		// Do not return error, the session has already been revoked
+	}
+
+	return nil
+}
+
+// authorize checks that the actor may manage the user's sessions: their own, or any as an administrator
+func (u *sessionUseCase) authorize(ctx context.Context, tenantID, actorID, userID string) error {
+	if actorID == userID {
+		return nil
+	}
+
+	isAdmin, err := u.authService.VerifyPermission(ctx, actorID, tenantID, services.PermissionAdmin)
+	if err != nil {
+		return errors.Wrap(err, "failed to verify permission")
+	}
+	if !isAdmin {
+		return errors.NewAuthorizationError("only administrators can manage other users' sessions")
+	}
+
+	return nil
+}
+
+// validateInput validates that required input parameters are not empty
+func (u *sessionUseCase) validateInput(params map[string]string) error {
+	for name, value := range params {
+		if value == "" {
+			return errors.NewValidationError(fmt.Sprintf("%s cannot be empty", name))
+		}
+	}
+	return nil
+}
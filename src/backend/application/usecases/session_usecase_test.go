@@ -0,0 +1,176 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert" // v1.8.0+
+	"github.com/stretchr/testify/mock"   // v1.8.0+
+	"github.com/stretchr/testify/suite"  // v1.8.0+
+
+	"../../domain/models"
+	"../../domain/services"
+	pkgErrors "../../pkg/errors"
+)
+
+// MockSessionRepository is a mock implementation of the SessionRepository interface for testing
+type MockSessionRepository struct {
+	mock.Mock
+}
+
+func (m *MockSessionRepository) Create(ctx context.Context, session *models.Session) (string, error) {
+	args := m.Called(ctx, session)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockSessionRepository) GetByID(ctx context.Context, id string, tenantID string) (*models.Session, error) {
+	args := m.Called(ctx, id, tenantID)
+	if session := args.Get(0); session != nil {
+		return session.(*models.Session), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockSessionRepository) ListActiveByUser(ctx context.Context, userID string, tenantID string) ([]models.Session, error) {
+	args := m.Called(ctx, userID, tenantID)
+	if sessions := args.Get(0); sessions != nil {
+		return sessions.([]models.Session), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockSessionRepository) Extend(ctx context.Context, id string, tenantID string, lastUsedAt, expiresAt time.Time) error {
+	args := m.Called(ctx, id, tenantID, lastUsedAt, expiresAt)
+	return args.Error(0)
+}
+
+func (m *MockSessionRepository) Revoke(ctx context.Context, id string, tenantID string) error {
+	args := m.Called(ctx, id, tenantID)
+	return args.Error(0)
+}
+
+// mockSessionAuthService mocks the AuthService methods used by session management
+type mockSessionAuthService struct {
+	services.AuthService
+	mock.Mock
+}
+
+func (m *mockSessionAuthService) RevokeSession(ctx context.Context, sessionID, tenantID string) error {
+	args := m.Called(ctx, sessionID, tenantID)
+	return args.Error(0)
+}
+
+func (m *mockSessionAuthService) VerifyPermission(ctx context.Context, userID, tenantID, permission string) (bool, error) {
+	args := m.Called(ctx, userID, tenantID, permission)
+	return args.Bool(0), args.Error(1)
+}
+
+// SessionUseCaseTestSuite defines a test suite for SessionUseCase
+type SessionUseCaseTestSuite struct {
+	suite.Suite
+	mockSessionRepo  *MockSessionRepository
+	mockAuthService  *mockSessionAuthService
+	mockAuditService *MockAuditService
+	sessionUseCase   SessionUseCase
+}
+
+// SetupTest sets up the test environment before each test
+func (s *SessionUseCaseTestSuite) SetupTest() {
+	s.mockSessionRepo = new(MockSessionRepository)
+	s.mockAuthService = new(mockSessionAuthService)
+	s.mockAuditService = new(MockAuditService)
+	s.mockAuditService.On("RecordAction", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	s.mockAuthService.On("VerifyPermission", mock.Anything, "admin123", "tenant123", services.PermissionAdmin).Return(true, nil).Maybe()
+	s.mockAuthService.On("VerifyPermission", mock.Anything, "user456", "tenant123", services.PermissionAdmin).Return(false, nil).Maybe()
+
+	var err error
+	s.sessionUseCase, err = NewSessionUseCase(s.mockSessionRepo, s.mockAuthService, s.mockAuditService)
+	assert.Nil(s.T(), err)
+}
+
+// createTestSession returns an active session of user123
+func (s *SessionUseCaseTestSuite) createTestSession(id string) *models.Session {
+	session := models.NewSession("user123", "tenant123", "203.0.113.7", "Mozilla/5.0", time.Now().Add(24*time.Hour))
+	session.ID = id
+	return session
+}
+
+// TestListSessions_Own tests that users can list their own sessions
+func (s *SessionUseCaseTestSuite) TestListSessions_Own() {
+	ctx := context.Background()
+	sessions := []models.Session{*s.createTestSession("session1"), *s.createTestSession("session2")}
+	s.mockSessionRepo.On("ListActiveByUser", ctx, "user123", "tenant123").Return(sessions, nil)
+
+	result, err := s.sessionUseCase.ListSessions(ctx, "tenant123", "user123", "user123")
+
+	s.NoError(err)
+	s.Len(result, 2)
+	s.mockAuthService.AssertNotCalled(s.T(), "VerifyPermission", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestListSessions_OtherUserForbidden tests that non-administrators cannot list other users' sessions
+func (s *SessionUseCaseTestSuite) TestListSessions_OtherUserForbidden() {
+	ctx := context.Background()
+
+	result, err := s.sessionUseCase.ListSessions(ctx, "tenant123", "user456", "user123")
+
+	s.Nil(result)
+	s.True(pkgErrors.IsAuthorizationError(err))
+	s.mockSessionRepo.AssertNotCalled(s.T(), "ListActiveByUser", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestRevokeSession_Admin tests that administrators can revoke other users' sessions
+func (s *SessionUseCaseTestSuite) TestRevokeSession_Admin() {
+	ctx := context.Background()
+	s.mockSessionRepo.On("GetByID", ctx, "session1", "tenant123").Return(s.createTestSession("session1"), nil)
+	s.mockAuthService.On("RevokeSession", ctx, "session1", "tenant123").Return(nil)
+
+	err := s.sessionUseCase.RevokeSession(ctx, "tenant123", "admin123", "session1")
+
+	s.NoError(err)
+	s.mockAuthService.AssertCalled(s.T(), "RevokeSession", ctx, "session1", "tenant123")
+	s.mockAuditService.AssertCalled(s.T(), "RecordAction", ctx, "tenant123", "admin123", models.AuditActionRevoke, models.AuditResourceSession, "session1", mock.Anything, mock.Anything)
+}
+
+// TestRevokeSession_OtherUserForbidden tests that users cannot revoke other users' sessions
+func (s *SessionUseCaseTestSuite) TestRevokeSession_OtherUserForbidden() {
+	ctx := context.Background()
+	s.mockSessionRepo.On("GetByID", ctx, "session1", "tenant123").Return(s.createTestSession("session1"), nil)
+
+	err := s.sessionUseCase.RevokeSession(ctx, "tenant123", "user456", "session1")
+
+	s.True(pkgErrors.IsAuthorizationError(err))
+	s.mockAuthService.AssertNotCalled(s.T(), "RevokeSession", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestRevokeSession_NotFound tests revoking a session of another tenant
+func (s *SessionUseCaseTestSuite) TestRevokeSession_NotFound() {
+	ctx := context.Background()
+	s.mockSessionRepo.On("GetByID", ctx, "session9", "tenant123").Return(nil, pkgErrors.NewResourceNotFoundError("session not found"))
+
+	err := s.sessionUseCase.RevokeSession(ctx, "tenant123", "user123", "session9")
+
+	s.True(pkgErrors.IsResourceNotFoundError(err))
+}
+
+// TestRevokeUserSessions tests forcing a user to sign in again on every device
+func (s *SessionUseCaseTestSuite) TestRevokeUserSessions() {
+	ctx := context.Background()
+	sessions := []models.Session{*s.createTestSession("session1"), *s.createTestSession("session2")}
+	s.mockSessionRepo.On("ListActiveByUser", ctx, "user123", "tenant123").Return(sessions, nil)
+	s.mockAuthService.On("RevokeSession", ctx, mock.Anything, "tenant123").Return(nil)
+
+	count, err := s.sessionUseCase.RevokeUserSessions(ctx, "tenant123", "admin123", "user123")
+
+	s.NoError(err)
+	s.Equal(2, count)
+	s.mockAuthService.AssertCalled(s.T(), "RevokeSession", ctx, "session1", "tenant123")
+	s.mockAuthService.AssertCalled(s.T(), "RevokeSession", ctx, "session2", "tenant123")
+}
+
+// TestSessionUseCaseSuite runs the session use case test suite
+func TestSessionUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(SessionUseCaseTestSuite))
+}
@@ -0,0 +1,584 @@
+// Package usecases implements the application layer of the Document Management Platform.
+// It contains use case implementations that orchestrate domain models and services.
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"../../domain/models"
+	"../../domain/repositories"
+	"../../domain/services"
+	"../../pkg/errors"
+	"../../pkg/logger"
+)
+
+// shareLinkDownloadURLExpiry is the lifetime in seconds of the presigned URL a share link redirects to
+const shareLinkDownloadURLExpiry = 300
+
+// ErrShareLinkUnavailable is returned for unknown, expired, exhausted and revoked share links alike,
+// so that a caller cannot tell which tokens exist
+var ErrShareLinkUnavailable = errors.WithCode(errors.NewResourceNotFoundError("share link not found or no longer available"), errors.CodeShareLinkUnavailable)
+
+// ShareLinkDownload is the outcome of a share link request: a presigned URL to redirect to, or the
+// content itself when the link watermarks downloads
+type ShareLinkDownload struct {
+	URL         string        // Short-lived presigned download URL, empty when Content is set
+	Content     io.ReadCloser // Content stamped for this download, nil when URL is set
+	FileName    string
+	ContentType string
+	Size        int64 // Size of Content in bytes
+}
+
+// ShareLinkPage is what the page of a share link shows before the document is downloaded: the
+// branding of the tenant that shared it and, once the password of a protected link is given, the
+// document
+type ShareLinkPage struct {
+	Branding         models.TenantPreferences
+	PasswordRequired bool // The link is protected and no password was given; the document is not described
+	FileName         string
+	ContentType      string
+	Size             int64 // Size of the latest version in bytes
+	ExpiresAt        time.Time
+}
+
+// ShareLinkUseCase defines the contract for public document share links
+type ShareLinkUseCase interface {
+	// CreateShareLink creates a public link to a document. It returns the link and its token,
+	// which is only available at creation time. An expiresIn of 0 uses the share link expiry of the
+	// tenant, a maxDownloads of 0 means unlimited, an empty password leaves the link unprotected,
+	// and watermark stamps PDFs downloaded through the link.
+	CreateShareLink(ctx context.Context, documentID, tenantID, userID string, expiresIn time.Duration, maxDownloads int, password string, watermark bool) (*models.ShareLink, string, error)
+
+	// ListShareLinks lists the share links of a document
+	ListShareLinks(ctx context.Context, documentID, tenantID, userID string) ([]*models.ShareLink, error)
+
+	// RevokeShareLink revokes a share link so it can no longer be used
+	RevokeShareLink(ctx context.Context, id, tenantID, userID string) error
+
+	// AccessShareLink resolves an unauthenticated share link request to a short-lived presigned
+	// download URL, or to the stamped content for downloads that are watermarked
+	AccessShareLink(ctx context.Context, token, password string) (*ShareLinkDownload, error)
+
+	// DescribeShareLink resolves an unauthenticated share link request to what the page of the link
+	// shows. It does not count as a download.
+	DescribeShareLink(ctx context.Context, token, password string) (*ShareLinkPage, error)
+}
+
+// shareLinkUseCase implements the ShareLinkUseCase interface
+type shareLinkUseCase struct {
+	shareLinkRepo         repositories.ShareLinkRepository
+	documentRepo          repositories.DocumentRepository
+	tenantRepo            repositories.TenantRepository
+	tenantSettings        services.TenantSettingsService
+	storageService        services.StorageService
+	watermarkService      services.WatermarkService
+	downloadPolicyService services.DownloadPolicyService
+	authService           services.AuthService
+	policyEngine          services.PolicyEngine
+	auditService          services.AuditService
+}
+
+// NewShareLinkUseCase creates a new ShareLinkUseCase instance
+func NewShareLinkUseCase(
+	shareLinkRepo repositories.ShareLinkRepository,
+	documentRepo repositories.DocumentRepository,
+	tenantRepo repositories.TenantRepository,
+	tenantSettings services.TenantSettingsService,
+	storageService services.StorageService,
+	watermarkService services.WatermarkService,
+	downloadPolicyService services.DownloadPolicyService,
+	authService services.AuthService,
+	policyEngine services.PolicyEngine,
+	auditService services.AuditService,
+) (ShareLinkUseCase, error) {
+	if shareLinkRepo == nil {
+		return nil, fmt.Errorf("share link repository cannot be nil")
+	}
+	if documentRepo == nil {
+		return nil, fmt.Errorf("document repository cannot be nil")
+	}
+	if tenantRepo == nil {
+		return nil, fmt.Errorf("tenant repository cannot be nil")
+	}
+	if tenantSettings == nil {
+		return nil, fmt.Errorf("tenant settings service cannot be nil")
+	}
+	if storageService == nil {
+		return nil, fmt.Errorf("storage service cannot be nil")
+	}
+	if watermarkService == nil {
+		return nil, fmt.Errorf("watermark service cannot be nil")
+	}
+	if downloadPolicyService == nil {
+		return nil, fmt.Errorf("download policy service cannot be nil")
+	}
+	if authService == nil {
+		return nil, fmt.Errorf("auth service cannot be nil")
+	}
+	if policyEngine == nil {
+		return nil, fmt.Errorf("policy engine cannot be nil")
+	}
+	if auditService == nil {
+		return nil, fmt.Errorf("audit service cannot be nil")
+	}
+
+	return &shareLinkUseCase{
+		shareLinkRepo:         shareLinkRepo,
+		documentRepo:          documentRepo,
+		tenantRepo:            tenantRepo,
+		tenantSettings:        tenantSettings,
+		storageService:        storageService,
+		watermarkService:      watermarkService,
+		downloadPolicyService: downloadPolicyService,
+		authService:           authService,
+		policyEngine:          policyEngine,
+		auditService:          auditService,
+	}, nil
+}
+
+// CreateShareLink creates a public link to a document the user can read
+func (u *shareLinkUseCase) CreateShareLink(ctx context.Context, documentID, tenantID, userID string, expiresIn time.Duration, maxDownloads int, password string, watermark bool) (*models.ShareLink, string, error) {
+	log := logger.WithContext(ctx)
+
+	if err := u.validateInput(map[string]string{
+		"document ID": documentID,
+		"tenant ID":   tenantID,
+		"user ID":     userID,
+	}); err != nil {
+		return nil, "", err
+	}
+
+	// Only users who can read the document may share it
+	document, err := u.getReadableDocument(ctx, documentID, tenantID, userID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	restricted, err := u.isSharingRestricted(ctx, document)
+	if err != nil {
+		return nil, "", err
+	}
+	if restricted {
+		log.Info("share link refused for document with personal data", "documentID", documentID)
+		return nil, "", errors.WithCode(errors.NewAuthorizationError("documents containing personal data cannot be shared through public links"), errors.CodeShareLinkPersonalData)
+	}
+
+	if expiresIn <= 0 {
+		preferences, err := u.tenantSettings.Preferences(ctx, tenantID)
+		if err != nil {
+			log.WithError(err).Error("failed to get share link expiry of tenant", "tenantID", tenantID)
+			return nil, "", errors.Wrap(err, "failed to get tenant settings")
+		}
+		expiresIn = preferences.ShareLinkExpiry
+	}
+
+	link, token, err := models.NewShareLink(tenantID, documentID, time.Now().Add(expiresIn), maxDownloads, userID)
+	if err != nil {
+		log.WithError(err).Error("failed to generate share link token", "documentID", documentID)
+		return nil, "", errors.Wrap(err, "failed to generate share link token")
+	}
+	link.Watermark = watermark
+
+	if password != "" {
+		if err := link.SetPassword(password); err != nil {
+			if err == models.ErrShareLinkPasswordTooShort {
+				return nil, "", errors.NewValidationError(err.Error())
+			}
+			log.WithError(err).Error("failed to hash share link password", "documentID", documentID)
+			return nil, "", errors.Wrap(err, "failed to hash share link password")
+		}
+	}
+
+	if err := link.Validate(); err != nil {
+		return nil, "", errors.NewValidationError(err.Error())
+	}
+
+	if _, err := u.shareLinkRepo.Create(ctx, link); err != nil {
+		log.WithError(err).Error("failed to create share link", "documentID", documentID, "tenantID", tenantID)
+		return nil, "", errors.Wrap(err, "failed to create share link")
+	}
+
+	err = u.auditService.RecordAction(ctx, tenantID, userID, models.AuditActionCreate, models.AuditResourceShareLink, link.ID, nil, map[string]interface{}{
+		"document_id":        documentID,
+		"expires_at":         link.ExpiresAt,
+		"max_downloads":      link.MaxDownloads,
+		"password_protected": link.HasPassword(),
+	})
+	if err != nil {
+		log.WithError(err).Error("failed to record share link creation in audit log")
+		// Do not return error, the link has already been created
+	}
+
+	log.Info("share link created successfully", "shareLinkID", link.ID, "documentID", documentID, "tenantID", tenantID)
+	return link, token, nil
+}
+
+// ListShareLinks lists the share links of a document the user can read
+func (u *shareLinkUseCase) ListShareLinks(ctx context.Context, documentID, tenantID, userID string) ([]*models.ShareLink, error) {
+	log := logger.WithContext(ctx)
+
+	if err := u.validateInput(map[string]string{
+		"document ID": documentID,
+		"tenant ID":   tenantID,
+		"user ID":     userID,
+	}); err != nil {
+		return nil, err
+	}
+
+	if _, err := u.getReadableDocument(ctx, documentID, tenantID, userID); err != nil {
+		return nil, err
+	}
+
+	links, err := u.shareLinkRepo.ListByDocument(ctx, documentID, tenantID)
+	if err != nil {
+		log.WithError(err).Error("failed to list share links", "documentID", documentID, "tenantID", tenantID)
+		return nil, errors.Wrap(err, "failed to list share links")
+	}
+
+	return links, nil
+}
+
+// RevokeShareLink revokes a share link. The link's creator and users with admin access to the document may revoke it.
+func (u *shareLinkUseCase) RevokeShareLink(ctx context.Context, id, tenantID, userID string) error {
+	log := logger.WithContext(ctx)
+
+	if err := u.validateInput(map[string]string{
+		"share link ID": id,
+		"tenant ID":     tenantID,
+		"user ID":       userID,
+	}); err != nil {
+		return err
+	}
+
+	link, err := u.shareLinkRepo.GetByID(ctx, id, tenantID)
+	if err != nil {
+		log.WithError(err).Error("failed to get share link", "id", id, "tenantID", tenantID)
+		return errors.Wrap(err, "failed to get share link")
+	}
+
+	if link.CreatedBy != userID {
+		hasAccess, err := u.authService.VerifyResourceAccess(ctx, userID, tenantID, services.ResourceTypeDocument, link.DocumentID, services.PermissionAdmin)
+		if err != nil {
+			log.WithError(err).Error("failed to verify document access", "documentID", link.DocumentID, "userID", userID)
+			return errors.Wrap(err, "failed to verify document access")
+		}
+		if !hasAccess {
+			log.Error("user cannot revoke share link", "id", id, "userID", userID)
+			return errors.NewAuthorizationError("only the link creator or a document administrator can revoke a share link")
+		}
+	}
+
+	if err := u.shareLinkRepo.Revoke(ctx, id, tenantID); err != nil {
+		log.WithError(err).Error("failed to revoke share link", "id", id, "tenantID", tenantID)
+		return errors.Wrap(err, "failed to revoke share link")
+	}
+
+	err = u.auditService.RecordAction(ctx, tenantID, userID, models.AuditActionRevoke, models.AuditResourceShareLink, id, nil, map[string]interface{}{
+		"document_id": link.DocumentID,
+	})
+	if err != nil {
+		log.WithError(err).Error("failed to record share link revocation in audit log")
+		// Do not return error, the link has already been revoked
+	}
+
+	log.Info("share link revoked successfully", "shareLinkID", id, "tenantID", tenantID)
+	return nil
+}
+
+// AccessShareLink resolves a share link token to a short-lived presigned download URL, or to the content
+// stamped for the download when it is watermarked, and counts the download
+func (u *shareLinkUseCase) AccessShareLink(ctx context.Context, token, password string) (*ShareLinkDownload, error) {
+	log := logger.WithContext(ctx)
+
+	link, err := u.getUsableLink(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.verifyPassword(ctx, link, password); err != nil {
+		return nil, err
+	}
+
+	document, err := u.getSharedDocument(ctx, link)
+	if err != nil {
+		return nil, err
+	}
+
+	if !document.IsAvailable() {
+		log.Error("shared document is not available for download", "documentID", document.ID, "status", document.Status)
+		return nil, ErrDocumentNotAvailable
+	}
+
+	// Links cannot download documents of folders that are view-only or require a recent sign-in
+	policy, err := u.downloadPolicyService.CheckExternalDownload(ctx, document, map[string]interface{}{"share_link_id": link.ID})
+	if err != nil {
+		if policy == nil {
+			log.WithError(err).Error("failed to check folder download policy", "documentID", document.ID)
+		}
+		return nil, err
+	}
+
+	latestVersion := document.GetLatestVersion()
+	if latestVersion == nil {
+		log.Error("no versions found for shared document", "documentID", document.ID)
+		return nil, errors.NewResourceNotFoundError("no versions found for document")
+	}
+
+	watermarked, err := u.watermarkService.RequiresWatermark(ctx, document, link)
+	if err != nil {
+		log.WithError(err).Error("failed to check if shared document is watermarked", "documentID", document.ID)
+		return nil, errors.Wrap(err, "failed to check if document is watermarked")
+	}
+
+	// Watermarked downloads are served by the API, since a presigned URL would hand out the content unstamped
+	var download *ShareLinkDownload
+	if watermarked {
+		download, err = u.watermark(ctx, document, latestVersion, link)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		presignedURL, err := u.storageService.GetPresignedURL(ctx, latestVersion.StoragePath, document.Name, shareLinkDownloadURLExpiry)
+		if err != nil {
+			log.WithError(err).Error("failed to generate presigned URL", "documentID", document.ID)
+			return nil, errors.Wrap(err, "failed to generate presigned URL")
+		}
+		download = &ShareLinkDownload{URL: presignedURL}
+	}
+
+	// Count the download last; the conditional update rejects links that were exhausted or revoked concurrently
+	counted, err := u.shareLinkRepo.RecordDownload(ctx, link.ID)
+	if err != nil {
+		log.WithError(err).Error("failed to record share link download", "shareLinkID", link.ID)
+		download.close()
+		return nil, errors.Wrap(err, "failed to record share link download")
+	}
+	if !counted {
+		download.close()
+		return nil, ErrShareLinkUnavailable
+	}
+
+	// The request is unauthenticated, so the audit entry has no actor and is attributed to the link
+	auditDetails := map[string]interface{}{
+		"name":          document.Name,
+		"share_link_id": link.ID,
+		"watermarked":   watermarked,
+	}
+	if !policy.Policy.IsEmpty() {
+		auditDetails["policy"] = policy.AuditDetails()
+	}
+	err = u.auditService.RecordAction(ctx, link.TenantID, "", models.AuditActionDownload, models.ResourceTypeDocument, document.ID, nil, auditDetails)
+	if err != nil {
+		log.WithError(err).Error("failed to record share link download in audit log")
+		// Do not return error, the download has already been granted
+	}
+
+	log.Info("share link accessed", "shareLinkID", link.ID, "documentID", document.ID, "tenantID", link.TenantID)
+	return download, nil
+}
+
+// DescribeShareLink returns the branding of the tenant that shared the document and, unless the link
+// is protected and no password was given, the name, type and size of the document
+func (u *shareLinkUseCase) DescribeShareLink(ctx context.Context, token, password string) (*ShareLinkPage, error) {
+	log := logger.WithContext(ctx)
+
+	link, err := u.getUsableLink(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	preferences, err := u.tenantSettings.Preferences(ctx, link.TenantID)
+	if err != nil {
+		log.WithError(err).Error("failed to get branding of tenant", "tenantID", link.TenantID)
+		return nil, errors.Wrap(err, "failed to get tenant settings")
+	}
+	page := &ShareLinkPage{
+		Branding:  preferences,
+		ExpiresAt: link.ExpiresAt,
+	}
+
+	// The page of a protected link asks for the password before telling anything about the document
+	if link.HasPassword() && password == "" {
+		page.PasswordRequired = true
+		return page, nil
+	}
+	if err := u.verifyPassword(ctx, link, password); err != nil {
+		return nil, err
+	}
+
+	document, err := u.getSharedDocument(ctx, link)
+	if err != nil {
+		return nil, err
+	}
+	page.FileName = document.Name
+	page.ContentType = document.ContentType
+	if latestVersion := document.GetLatestVersion(); latestVersion != nil {
+		page.Size = latestVersion.Size
+	}
+
+	return page, nil
+}
+
+// getUsableLink returns the share link of a token, or ErrShareLinkUnavailable when there is no such
+// link or it can no longer be used
+func (u *shareLinkUseCase) getUsableLink(ctx context.Context, token string) (*models.ShareLink, error) {
+	log := logger.WithContext(ctx)
+
+	if token == "" {
+		return nil, ErrShareLinkUnavailable
+	}
+
+	link, err := u.shareLinkRepo.GetByTokenHash(ctx, models.HashShareLinkToken(token))
+	if err != nil {
+		if errors.IsResourceNotFoundError(err) {
+			return nil, ErrShareLinkUnavailable
+		}
+		log.WithError(err).Error("failed to get share link")
+		return nil, errors.Wrap(err, "failed to get share link")
+	}
+
+	if !link.IsUsable(time.Now()) {
+		log.Info("share link is no longer usable", "shareLinkID", link.ID)
+		return nil, ErrShareLinkUnavailable
+	}
+
+	return link, nil
+}
+
+// verifyPassword checks the password given for a share link; links without a password accept any
+// input
+func (u *shareLinkUseCase) verifyPassword(ctx context.Context, link *models.ShareLink, password string) error {
+	log := logger.WithContext(ctx)
+
+	ok, err := link.VerifyPassword(password)
+	if err != nil {
+		log.WithError(err).Error("failed to verify share link password", "shareLinkID", link.ID)
+		return errors.Wrap(err, "failed to verify share link password")
+	}
+	if !ok {
+		log.Info("invalid share link password", "shareLinkID", link.ID)
+		return errors.WithCode(errors.NewAuthenticationError("share link password is missing or incorrect"), errors.CodeShareLinkPasswordInvalid)
+	}
+	return nil
+}
+
+// getSharedDocument returns the document of a share link, or ErrShareLinkUnavailable when the link no
+// longer gives access to it
+func (u *shareLinkUseCase) getSharedDocument(ctx context.Context, link *models.ShareLink) (*models.Document, error) {
+	log := logger.WithContext(ctx)
+
+	document, err := u.documentRepo.GetByID(ctx, link.DocumentID, link.TenantID)
+	if err != nil {
+		if errors.IsResourceNotFoundError(err) {
+			return nil, ErrShareLinkUnavailable
+		}
+		log.WithError(err).Error("failed to get shared document", "documentID", link.DocumentID)
+		return nil, errors.Wrap(err, "failed to get document")
+	}
+
+	// Share links only give access to documents that are published
+	if !document.IsPublished() {
+		log.Info("shared document is not published", "documentID", document.ID, "visibility", document.Visibility)
+		return nil, ErrShareLinkUnavailable
+	}
+
+	// Links created before personal data was detected in the document stop working
+	restricted, err := u.isSharingRestricted(ctx, document)
+	if err != nil {
+		return nil, err
+	}
+	if restricted {
+		log.Info("shared document contains personal data", "documentID", document.ID)
+		return nil, ErrShareLinkUnavailable
+	}
+
+	return document, nil
+}
+
+// watermark reads the content of the version and stamps it for a download through the link
+func (u *shareLinkUseCase) watermark(ctx context.Context, document *models.Document, version *models.DocumentVersion, link *models.ShareLink) (*ShareLinkDownload, error) {
+	log := logger.WithContext(ctx)
+
+	content, err := u.storageService.GetDocument(ctx, version.StoragePath)
+	if err != nil {
+		log.WithError(err).Error("failed to retrieve shared document content", "documentID", document.ID)
+		return nil, errors.Wrap(err, "failed to retrieve document content")
+	}
+	defer content.Close()
+
+	stamped, size, err := u.watermarkService.WatermarkForShareLink(ctx, content, document, link)
+	if err != nil {
+		log.WithError(err).Error("failed to watermark shared document", "documentID", document.ID, "shareLinkID", link.ID)
+		return nil, err
+	}
+
+	return &ShareLinkDownload{
+		Content:     stamped,
+		FileName:    document.Name,
+		ContentType: document.ContentType,
+		Size:        size,
+	}, nil
+}
+
+// close releases the content of a download that is not handed out
+func (d *ShareLinkDownload) close() {
+	if d.Content != nil {
+		d.Content.Close()
+	}
+}
+
+// getReadableDocument loads a document and checks that the user may read it
+func (u *shareLinkUseCase) getReadableDocument(ctx context.Context, documentID, tenantID, userID string) (*models.Document, error) {
+	log := logger.WithContext(ctx)
+
+	document, err := u.documentRepo.GetByID(ctx, documentID, tenantID)
+	if err != nil {
+		log.WithError(err).Error("failed to get document", "documentID", documentID, "tenantID", tenantID)
+		return nil, errors.Wrap(err, "failed to get document")
+	}
+
+	hasAccess, err := u.authService.VerifyResourceAccess(ctx, userID, tenantID, services.ResourceTypeDocument, documentID, services.PermissionRead)
+	if err != nil {
+		log.WithError(err).Error("failed to verify document access", "documentID", documentID, "userID", userID)
+		return nil, errors.Wrap(err, "failed to verify document access")
+	}
+	if !hasAccess {
+		log.Error("user does not have read permission for document", "documentID", documentID, "userID", userID)
+		return nil, ErrPermissionDenied
+	}
+
+	// Attribute-based policies restricting reads also restrict sharing
+	if err := u.policyEngine.Enforce(ctx, tenantID, userID, models.PolicyActionRead, document); err != nil {
+		log.WithError(err).Error("document sharing denied by policy", "documentID", documentID, "userID", userID)
+		return nil, err
+	}
+
+	return document, nil
+}
+
+// isSharingRestricted checks if personal data was detected in the document and its tenant prevents
+// such documents from being shared through public links
+func (u *shareLinkUseCase) isSharingRestricted(ctx context.Context, document *models.Document) (bool, error) {
+	if len(document.DetectedPIICategories()) == 0 {
+		return false, nil
+	}
+
+	tenant, err := u.tenantRepo.GetByID(ctx, document.TenantID)
+	if err != nil {
+		logger.WithContext(ctx).WithError(err).Error("failed to get tenant", "tenantID", document.TenantID)
+		return false, errors.Wrap(err, "failed to get tenant")
+	}
+	return tenant.RestrictsPIISharing(), nil
+}
+
+// validateInput validates that required input parameters are not empty
+func (u *shareLinkUseCase) validateInput(params map[string]string) error {
+	for name, value := range params {
+		if value == "" {
+			return errors.NewValidationError(fmt.Sprintf("%s cannot be empty", name))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,450 @@
+package usecases
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert" // v1.8.0+
+	"github.com/stretchr/testify/mock"   // v1.8.0+
+	"github.com/stretchr/testify/suite"  // v1.8.0+
+
+	"../../domain/models"
+	"../../domain/repositories"
+	"../../domain/services"
+	pkgErrors "../../pkg/errors"
+)
+
+// MockShareLinkRepository is a mock implementation of the ShareLinkRepository interface for testing
+type MockShareLinkRepository struct {
+	mock.Mock
+}
+
+func (m *MockShareLinkRepository) Create(ctx context.Context, link *models.ShareLink) (string, error) {
+	args := m.Called(ctx, link)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockShareLinkRepository) GetByID(ctx context.Context, id string, tenantID string) (*models.ShareLink, error) {
+	args := m.Called(ctx, id, tenantID)
+	if link := args.Get(0); link != nil {
+		return link.(*models.ShareLink), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockShareLinkRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*models.ShareLink, error) {
+	args := m.Called(ctx, tokenHash)
+	if link := args.Get(0); link != nil {
+		return link.(*models.ShareLink), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockShareLinkRepository) ListByDocument(ctx context.Context, documentID string, tenantID string) ([]*models.ShareLink, error) {
+	args := m.Called(ctx, documentID, tenantID)
+	return args.Get(0).([]*models.ShareLink), args.Error(1)
+}
+
+func (m *MockShareLinkRepository) Revoke(ctx context.Context, id string, tenantID string) error {
+	args := m.Called(ctx, id, tenantID)
+	return args.Error(0)
+}
+
+func (m *MockShareLinkRepository) RecordDownload(ctx context.Context, id string) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
+// mockShareDocumentRepository mocks the DocumentRepository methods used by share links
+type mockShareDocumentRepository struct {
+	repositories.DocumentRepository
+	mock.Mock
+}
+
+func (m *mockShareDocumentRepository) GetByID(ctx context.Context, id string, tenantID string) (*models.Document, error) {
+	args := m.Called(ctx, id, tenantID)
+	if document := args.Get(0); document != nil {
+		return document.(*models.Document), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// mockShareTenantRepository mocks the TenantRepository methods used by share links
+type mockShareTenantRepository struct {
+	repositories.TenantRepository
+	mock.Mock
+}
+
+func (m *mockShareTenantRepository) GetByID(ctx context.Context, id string) (*models.Tenant, error) {
+	args := m.Called(ctx, id)
+	if tenant := args.Get(0); tenant != nil {
+		return tenant.(*models.Tenant), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// mockShareTenantSettings mocks the TenantSettingsService used by share links
+type mockShareTenantSettings struct {
+	mock.Mock
+}
+
+func (m *mockShareTenantSettings) Preferences(ctx context.Context, tenantID string) (models.TenantPreferences, error) {
+	args := m.Called(ctx, tenantID)
+	return args.Get(0).(models.TenantPreferences), args.Error(1)
+}
+
+// mockShareStorageService mocks the StorageService methods used by share links
+type mockShareStorageService struct {
+	services.StorageService
+	mock.Mock
+}
+
+func (m *mockShareStorageService) GetPresignedURL(ctx context.Context, storagePath string, fileName string, expirationSeconds int) (string, error) {
+	args := m.Called(ctx, storagePath, fileName, expirationSeconds)
+	return args.String(0), args.Error(1)
+}
+
+func (m *mockShareStorageService) GetDocument(ctx context.Context, storagePath string) (io.ReadCloser, error) {
+	args := m.Called(ctx, storagePath)
+	if content := args.Get(0); content != nil {
+		return content.(io.ReadCloser), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// mockShareAuthService mocks the AuthService methods used by share links
+type mockShareAuthService struct {
+	services.AuthService
+	mock.Mock
+}
+
+func (m *mockShareAuthService) VerifyResourceAccess(ctx context.Context, userID, tenantID, resourceType, resourceID, accessType string) (bool, error) {
+	args := m.Called(ctx, userID, tenantID, resourceType, resourceID, accessType)
+	return args.Bool(0), args.Error(1)
+}
+
+// ShareLinkUseCaseTestSuite defines a test suite for ShareLinkUseCase
+type ShareLinkUseCaseTestSuite struct {
+	suite.Suite
+	mockShareLinkRepo  *MockShareLinkRepository
+	mockDocumentRepo   *mockShareDocumentRepository
+	mockTenantRepo     *mockShareTenantRepository
+	mockTenantSettings *mockShareTenantSettings
+	mockStorageService *mockShareStorageService
+	watermarkService   *stubWatermarkService
+	downloadPolicy     *stubDownloadPolicyService
+	mockAuthService    *mockShareAuthService
+	mockPolicyEngine   *MockPolicyEngine
+	mockAuditService   *MockAuditService
+	shareLinkUseCase   ShareLinkUseCase
+}
+
+// SetupTest sets up the test environment before each test
+func (s *ShareLinkUseCaseTestSuite) SetupTest() {
+	s.mockShareLinkRepo = new(MockShareLinkRepository)
+	s.mockDocumentRepo = new(mockShareDocumentRepository)
+	s.mockTenantRepo = new(mockShareTenantRepository)
+	s.mockTenantSettings = new(mockShareTenantSettings)
+	s.mockStorageService = new(mockShareStorageService)
+	s.watermarkService = &stubWatermarkService{}
+	s.downloadPolicy = &stubDownloadPolicyService{}
+	s.mockAuthService = new(mockShareAuthService)
+	s.mockPolicyEngine = new(MockPolicyEngine)
+	s.mockAuditService = new(MockAuditService)
+	s.mockAuditService.On("RecordAction", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	var err error
+	s.shareLinkUseCase, err = NewShareLinkUseCase(s.mockShareLinkRepo, s.mockDocumentRepo, s.mockTenantRepo, s.mockTenantSettings, s.mockStorageService, s.watermarkService, s.downloadPolicy, s.mockAuthService, s.mockPolicyEngine, s.mockAuditService)
+	assert.Nil(s.T(), err)
+}
+
+// createTestDocument returns an available document with a single version
+func (s *ShareLinkUseCaseTestSuite) createTestDocument() *models.Document {
+	return &models.Document{
+		ID:       "doc123",
+		Name:     "contract.pdf",
+		TenantID: "tenant123",
+		Status:   models.DocumentStatusAvailable,
+		Versions: []models.DocumentVersion{{ID: "v1", DocumentID: "doc123", VersionNumber: 1, StoragePath: "tenant123/doc123/v1"}},
+	}
+}
+
+// createRestrictingTenant returns a tenant preventing documents with personal data from being shared
+func (s *ShareLinkUseCaseTestSuite) createRestrictingTenant() *models.Tenant {
+	tenant := &models.Tenant{ID: "tenant123", Status: models.TenantStatusActive}
+	tenant.SetSetting(models.TenantSettingPIIRestrictSharing, "true")
+	return tenant
+}
+
+// createTestLink returns an active share link for the test document together with its token
+func (s *ShareLinkUseCaseTestSuite) createTestLink(maxDownloads int) (*models.ShareLink, string) {
+	link, token, err := models.NewShareLink("tenant123", "doc123", time.Now().Add(time.Hour), maxDownloads, "user123")
+	s.Require().NoError(err)
+	link.ID = "link123"
+	return link, token
+}
+
+// TestCreateShareLink_Success tests creating a password protected share link
+func (s *ShareLinkUseCaseTestSuite) TestCreateShareLink_Success() {
+	document := s.createTestDocument()
+	s.mockDocumentRepo.On("GetByID", mock.Anything, "doc123", "tenant123").Return(document, nil)
+	s.mockAuthService.On("VerifyResourceAccess", mock.Anything, "user123", "tenant123", services.ResourceTypeDocument, "doc123", services.PermissionRead).Return(true, nil)
+	s.mockPolicyEngine.On("Enforce", mock.Anything, "tenant123", "user123", models.PolicyActionRead, document).Return(nil)
+	s.mockShareLinkRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.ShareLink")).Return("link123", nil)
+
+	link, token, err := s.shareLinkUseCase.CreateShareLink(context.Background(), "doc123", "tenant123", "user123", 24*time.Hour, 5, "s3cret-pass", false)
+
+	assert.Nil(s.T(), err)
+	assert.NotEmpty(s.T(), token)
+	assert.Equal(s.T(), models.HashShareLinkToken(token), link.TokenHash)
+	assert.True(s.T(), link.HasPassword())
+	assert.Equal(s.T(), 5, link.MaxDownloads)
+	s.mockShareLinkRepo.AssertExpectations(s.T())
+}
+
+// TestCreateShareLink_PermissionDenied tests that users without read access cannot share a document
+func (s *ShareLinkUseCaseTestSuite) TestCreateShareLink_PermissionDenied() {
+	s.mockDocumentRepo.On("GetByID", mock.Anything, "doc123", "tenant123").Return(s.createTestDocument(), nil)
+	s.mockAuthService.On("VerifyResourceAccess", mock.Anything, "user123", "tenant123", services.ResourceTypeDocument, "doc123", services.PermissionRead).Return(false, nil)
+
+	link, token, err := s.shareLinkUseCase.CreateShareLink(context.Background(), "doc123", "tenant123", "user123", time.Hour, 0, "", false)
+
+	assert.Nil(s.T(), link)
+	assert.Empty(s.T(), token)
+	assert.True(s.T(), pkgErrors.IsAuthorizationError(err))
+	s.mockShareLinkRepo.AssertNotCalled(s.T(), "Create")
+}
+
+// TestCreateShareLink_InvalidExpiry tests that links cannot outlive the maximum expiry
+func (s *ShareLinkUseCaseTestSuite) TestCreateShareLink_InvalidExpiry() {
+	document := s.createTestDocument()
+	s.mockDocumentRepo.On("GetByID", mock.Anything, "doc123", "tenant123").Return(document, nil)
+	s.mockAuthService.On("VerifyResourceAccess", mock.Anything, "user123", "tenant123", services.ResourceTypeDocument, "doc123", services.PermissionRead).Return(true, nil)
+	s.mockPolicyEngine.On("Enforce", mock.Anything, "tenant123", "user123", models.PolicyActionRead, document).Return(nil)
+
+	_, _, err := s.shareLinkUseCase.CreateShareLink(context.Background(), "doc123", "tenant123", "user123", models.ShareLinkMaxExpiry+time.Hour, 0, "", false)
+
+	assert.True(s.T(), pkgErrors.IsValidationError(err))
+	s.mockShareLinkRepo.AssertNotCalled(s.T(), "Create")
+}
+
+// TestCreateShareLink_TenantExpiry tests that links created without an expiry get the share link expiry of the tenant
+func (s *ShareLinkUseCaseTestSuite) TestCreateShareLink_TenantExpiry() {
+	document := s.createTestDocument()
+	s.mockDocumentRepo.On("GetByID", mock.Anything, "doc123", "tenant123").Return(document, nil)
+	s.mockAuthService.On("VerifyResourceAccess", mock.Anything, "user123", "tenant123", services.ResourceTypeDocument, "doc123", services.PermissionRead).Return(true, nil)
+	s.mockPolicyEngine.On("Enforce", mock.Anything, "tenant123", "user123", models.PolicyActionRead, document).Return(nil)
+	s.mockTenantSettings.On("Preferences", mock.Anything, "tenant123").Return(models.TenantPreferences{ShareLinkExpiry: 48 * time.Hour}, nil)
+	s.mockShareLinkRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.ShareLink")).Return("link123", nil)
+
+	link, _, err := s.shareLinkUseCase.CreateShareLink(context.Background(), "doc123", "tenant123", "user123", 0, 0, "", false)
+
+	assert.Nil(s.T(), err)
+	assert.WithinDuration(s.T(), time.Now().Add(48*time.Hour), link.ExpiresAt, time.Minute)
+}
+
+// TestCreateShareLink_PersonalDataRestricted tests that documents with personal data cannot be shared
+// when the tenant restricts it
+func (s *ShareLinkUseCaseTestSuite) TestCreateShareLink_PersonalDataRestricted() {
+	document := s.createTestDocument()
+	document.AddMetadata(models.PIIMetadataKey(models.PIICategorySSN), models.PIIMetadataValue)
+	s.mockDocumentRepo.On("GetByID", mock.Anything, "doc123", "tenant123").Return(document, nil)
+	s.mockAuthService.On("VerifyResourceAccess", mock.Anything, "user123", "tenant123", services.ResourceTypeDocument, "doc123", services.PermissionRead).Return(true, nil)
+	s.mockPolicyEngine.On("Enforce", mock.Anything, "tenant123", "user123", models.PolicyActionRead, document).Return(nil)
+	s.mockTenantRepo.On("GetByID", mock.Anything, "tenant123").Return(s.createRestrictingTenant(), nil)
+
+	link, _, err := s.shareLinkUseCase.CreateShareLink(context.Background(), "doc123", "tenant123", "user123", time.Hour, 0, "", false)
+
+	assert.Nil(s.T(), link)
+	assert.True(s.T(), pkgErrors.IsAuthorizationError(err))
+	s.mockShareLinkRepo.AssertNotCalled(s.T(), "Create")
+}
+
+// TestCreateShareLink_PersonalDataAllowed tests that documents with personal data can be shared when
+// the tenant does not restrict it
+func (s *ShareLinkUseCaseTestSuite) TestCreateShareLink_PersonalDataAllowed() {
+	document := s.createTestDocument()
+	document.AddMetadata(models.PIIMetadataKey(models.PIICategoryEmail), models.PIIMetadataValue)
+	s.mockDocumentRepo.On("GetByID", mock.Anything, "doc123", "tenant123").Return(document, nil)
+	s.mockAuthService.On("VerifyResourceAccess", mock.Anything, "user123", "tenant123", services.ResourceTypeDocument, "doc123", services.PermissionRead).Return(true, nil)
+	s.mockPolicyEngine.On("Enforce", mock.Anything, "tenant123", "user123", models.PolicyActionRead, document).Return(nil)
+	s.mockTenantRepo.On("GetByID", mock.Anything, "tenant123").Return(&models.Tenant{ID: "tenant123", Status: models.TenantStatusActive}, nil)
+	s.mockShareLinkRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.ShareLink")).Return("link123", nil)
+
+	link, _, err := s.shareLinkUseCase.CreateShareLink(context.Background(), "doc123", "tenant123", "user123", time.Hour, 0, "", false)
+
+	assert.Nil(s.T(), err)
+	assert.NotNil(s.T(), link)
+}
+
+// TestAccessShareLink_Success tests resolving a share link to a presigned download URL
+func (s *ShareLinkUseCaseTestSuite) TestAccessShareLink_Success() {
+	link, token := s.createTestLink(0)
+	s.mockShareLinkRepo.On("GetByTokenHash", mock.Anything, models.HashShareLinkToken(token)).Return(link, nil)
+	s.mockDocumentRepo.On("GetByID", mock.Anything, "doc123", "tenant123").Return(s.createTestDocument(), nil)
+	s.mockStorageService.On("GetPresignedURL", mock.Anything, "tenant123/doc123/v1", "contract.pdf", shareLinkDownloadURLExpiry).Return("https://s3/presigned", nil)
+	s.mockShareLinkRepo.On("RecordDownload", mock.Anything, "link123").Return(true, nil)
+
+	download, err := s.shareLinkUseCase.AccessShareLink(context.Background(), token, "")
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), "https://s3/presigned", download.URL)
+	assert.Nil(s.T(), download.Content)
+	s.mockAuditService.AssertCalled(s.T(), "RecordAction", mock.Anything, "tenant123", "", models.AuditActionDownload, models.ResourceTypeDocument, "doc123", mock.Anything, mock.Anything)
+}
+
+// TestAccessShareLink_Watermarked tests that watermarked downloads are stamped for the link and served
+// without a presigned URL
+func (s *ShareLinkUseCaseTestSuite) TestAccessShareLink_Watermarked() {
+	link, token := s.createTestLink(0)
+	link.Watermark = true
+	s.watermarkService.required = true
+	s.mockShareLinkRepo.On("GetByTokenHash", mock.Anything, models.HashShareLinkToken(token)).Return(link, nil)
+	s.mockDocumentRepo.On("GetByID", mock.Anything, "doc123", "tenant123").Return(s.createTestDocument(), nil)
+	s.mockStorageService.On("GetDocument", mock.Anything, "tenant123/doc123/v1").Return(io.NopCloser(strings.NewReader("%PDF-1.4")), nil)
+	s.mockShareLinkRepo.On("RecordDownload", mock.Anything, "link123").Return(true, nil)
+
+	download, err := s.shareLinkUseCase.AccessShareLink(context.Background(), token, "")
+
+	assert.Nil(s.T(), err)
+	assert.Empty(s.T(), download.URL)
+	content, err := io.ReadAll(download.Content)
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), "stamped for link123", string(content))
+	assert.Equal(s.T(), int64(len(content)), download.Size)
+	assert.Equal(s.T(), "contract.pdf", download.FileName)
+	s.mockStorageService.AssertNotCalled(s.T(), "GetPresignedURL", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestAccessShareLink_ViewOnly tests that links cannot download documents of view-only folders
+func (s *ShareLinkUseCaseTestSuite) TestAccessShareLink_ViewOnly() {
+	link, token := s.createTestLink(0)
+	s.downloadPolicy.err = services.ErrDownloadViewOnly
+	s.mockShareLinkRepo.On("GetByTokenHash", mock.Anything, models.HashShareLinkToken(token)).Return(link, nil)
+	s.mockDocumentRepo.On("GetByID", mock.Anything, "doc123", "tenant123").Return(s.createTestDocument(), nil)
+
+	download, err := s.shareLinkUseCase.AccessShareLink(context.Background(), token, "")
+
+	assert.Nil(s.T(), download)
+	assert.Equal(s.T(), services.ErrDownloadViewOnly, err)
+	s.mockStorageService.AssertNotCalled(s.T(), "GetPresignedURL", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	s.mockShareLinkRepo.AssertNotCalled(s.T(), "RecordDownload", mock.Anything, mock.Anything)
+}
+
+// TestAccessShareLink_WrongPassword tests that protected links reject a wrong password
+func (s *ShareLinkUseCaseTestSuite) TestAccessShareLink_WrongPassword() {
+	link, token := s.createTestLink(0)
+	s.Require().NoError(link.SetPassword("s3cret-pass"))
+	s.mockShareLinkRepo.On("GetByTokenHash", mock.Anything, models.HashShareLinkToken(token)).Return(link, nil)
+
+	download, err := s.shareLinkUseCase.AccessShareLink(context.Background(), token, "guess")
+
+	assert.Nil(s.T(), download)
+	assert.True(s.T(), pkgErrors.IsAuthenticationError(err))
+	s.mockShareLinkRepo.AssertNotCalled(s.T(), "RecordDownload", mock.Anything, mock.Anything)
+}
+
+// TestAccessShareLink_Revoked tests that revoked links are reported as unavailable
+func (s *ShareLinkUseCaseTestSuite) TestAccessShareLink_Revoked() {
+	link, token := s.createTestLink(0)
+	link.Revoke()
+	s.mockShareLinkRepo.On("GetByTokenHash", mock.Anything, models.HashShareLinkToken(token)).Return(link, nil)
+
+	_, err := s.shareLinkUseCase.AccessShareLink(context.Background(), token, "")
+
+	assert.True(s.T(), pkgErrors.IsResourceNotFoundError(err))
+}
+
+// TestAccessShareLink_Exhausted tests a download racing past the link's download limit
+func (s *ShareLinkUseCaseTestSuite) TestAccessShareLink_Exhausted() {
+	link, token := s.createTestLink(1)
+	s.mockShareLinkRepo.On("GetByTokenHash", mock.Anything, models.HashShareLinkToken(token)).Return(link, nil)
+	s.mockDocumentRepo.On("GetByID", mock.Anything, "doc123", "tenant123").Return(s.createTestDocument(), nil)
+	s.mockStorageService.On("GetPresignedURL", mock.Anything, "tenant123/doc123/v1", "contract.pdf", shareLinkDownloadURLExpiry).Return("https://s3/presigned", nil)
+	s.mockShareLinkRepo.On("RecordDownload", mock.Anything, "link123").Return(false, nil)
+
+	download, err := s.shareLinkUseCase.AccessShareLink(context.Background(), token, "")
+
+	assert.Nil(s.T(), download)
+	assert.True(s.T(), pkgErrors.IsResourceNotFoundError(err))
+}
+
+// TestAccessShareLink_PersonalDataRestricted tests that existing links stop working once personal
+// data is detected in a document of a tenant that restricts sharing it
+func (s *ShareLinkUseCaseTestSuite) TestAccessShareLink_PersonalDataRestricted() {
+	link, token := s.createTestLink(0)
+	document := s.createTestDocument()
+	document.AddMetadata(models.PIIMetadataKey(models.PIICategoryCreditCard), models.PIIMetadataValue)
+	s.mockShareLinkRepo.On("GetByTokenHash", mock.Anything, models.HashShareLinkToken(token)).Return(link, nil)
+	s.mockDocumentRepo.On("GetByID", mock.Anything, "doc123", "tenant123").Return(document, nil)
+	s.mockTenantRepo.On("GetByID", mock.Anything, "tenant123").Return(s.createRestrictingTenant(), nil)
+
+	download, err := s.shareLinkUseCase.AccessShareLink(context.Background(), token, "")
+
+	assert.Nil(s.T(), download)
+	assert.Equal(s.T(), ErrShareLinkUnavailable, err)
+	s.mockStorageService.AssertNotCalled(s.T(), "GetPresignedURL", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	s.mockShareLinkRepo.AssertNotCalled(s.T(), "RecordDownload", mock.Anything, mock.Anything)
+}
+
+// TestDescribeShareLink_Success tests that the page of a link shows the branding of the tenant and the
+// document without counting a download
+func (s *ShareLinkUseCaseTestSuite) TestDescribeShareLink_Success() {
+	link, token := s.createTestLink(0)
+	document := s.createTestDocument()
+	document.ContentType = "application/pdf"
+	document.Versions[0].Size = 2048
+	s.mockShareLinkRepo.On("GetByTokenHash", mock.Anything, models.HashShareLinkToken(token)).Return(link, nil)
+	s.mockTenantSettings.On("Preferences", mock.Anything, "tenant123").Return(models.TenantPreferences{TenantName: "Acme", LogoURL: "https://acme.example/logo.png", Language: "de"}, nil)
+	s.mockDocumentRepo.On("GetByID", mock.Anything, "doc123", "tenant123").Return(document, nil)
+
+	page, err := s.shareLinkUseCase.DescribeShareLink(context.Background(), token, "")
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), "Acme", page.Branding.TenantName)
+	assert.Equal(s.T(), "https://acme.example/logo.png", page.Branding.LogoURL)
+	assert.False(s.T(), page.PasswordRequired)
+	assert.Equal(s.T(), "contract.pdf", page.FileName)
+	assert.Equal(s.T(), int64(2048), page.Size)
+	s.mockShareLinkRepo.AssertNotCalled(s.T(), "RecordDownload", mock.Anything, mock.Anything)
+}
+
+// TestDescribeShareLink_PasswordRequired tests that the page of a protected link does not describe the
+// document until the password is given
+func (s *ShareLinkUseCaseTestSuite) TestDescribeShareLink_PasswordRequired() {
+	link, token := s.createTestLink(0)
+	s.Require().NoError(link.SetPassword("s3cret-pass"))
+	s.mockShareLinkRepo.On("GetByTokenHash", mock.Anything, models.HashShareLinkToken(token)).Return(link, nil)
+	s.mockTenantSettings.On("Preferences", mock.Anything, "tenant123").Return(models.TenantPreferences{TenantName: "Acme"}, nil)
+
+	page, err := s.shareLinkUseCase.DescribeShareLink(context.Background(), token, "")
+
+	assert.Nil(s.T(), err)
+	assert.True(s.T(), page.PasswordRequired)
+	assert.Equal(s.T(), "Acme", page.Branding.TenantName)
+	assert.Empty(s.T(), page.FileName)
+	s.mockDocumentRepo.AssertNotCalled(s.T(), "GetByID", mock.Anything, mock.Anything, mock.Anything)
+
+	_, err = s.shareLinkUseCase.DescribeShareLink(context.Background(), token, "guess")
+	assert.True(s.T(), pkgErrors.IsAuthenticationError(err))
+}
+
+// TestRevokeShareLink_NotCreator tests that other users need admin access to revoke a link
+func (s *ShareLinkUseCaseTestSuite) TestRevokeShareLink_NotCreator() {
+	link, _ := s.createTestLink(0)
+	s.mockShareLinkRepo.On("GetByID", mock.Anything, "link123", "tenant123").Return(link, nil)
+	s.mockAuthService.On("VerifyResourceAccess", mock.Anything, "user456", "tenant123", services.ResourceTypeDocument, "doc123", services.PermissionAdmin).Return(false, nil)
+
+	err := s.shareLinkUseCase.RevokeShareLink(context.Background(), "link123", "tenant123", "user456")
+
+	assert.True(s.T(), pkgErrors.IsAuthorizationError(err))
+	s.mockShareLinkRepo.AssertNotCalled(s.T(), "Revoke", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestShareLinkUseCaseSuite entry point for running the ShareLinkUseCase test suite
+func TestShareLinkUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(ShareLinkUseCaseTestSuite))
+}
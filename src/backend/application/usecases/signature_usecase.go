@@ -0,0 +1,515 @@
+// Package usecases implements the application layer of the Document Management Platform.
+// It contains use case implementations that orchestrate domain models and services.
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid" // v1.3.0+ - For generating unique IDs for signature requests, versions and events
+
+	"../../domain/models"
+	"../../domain/repositories"
+	"../../domain/services"
+	"../../pkg/errors"
+	"../../pkg/logger"
+	"../../pkg/utils"
+)
+
+// SignatureCallbackPath is the path under the API prefix signature providers post status updates
+// to, followed by the name of the provider
+const SignatureCallbackPath = "/api/v1/signature-callbacks/"
+
+// MaxSignedDocumentSize is the largest signed document accepted from a provider, in bytes
+const MaxSignedDocumentSize = 100 * 1024 * 1024
+
+// signedContentType is the content type of the signed documents providers return
+const signedContentType = "application/pdf"
+
+// Signature errors
+var (
+	ErrSignatureUnknownProvider   = errors.NewValidationError("the signature provider is not configured")
+	ErrSignatureVersionNotReady   = errors.NewValidationError("the latest version of the document is not available for signature")
+	ErrSignatureDocumentTooLarge  = errors.NewValidationError("the signed document exceeds the maximum size")
+	ErrSignatureCallbackNoRequest = errors.NewResourceNotFoundError("no signature request matches the envelope")
+)
+
+// SignatureUseCase defines the contract for electronic signatures. Users who can write a document
+// send its latest version to a signature provider; the provider calls back as the signers sign,
+// and the signed document is stored as a new version of the document once every signer signed.
+type SignatureUseCase interface {
+	// ListProviders lists the names of the configured signature providers
+	ListProviders() []string
+
+	// RequestSignature sends the latest version of a document to the signers through a provider
+	RequestSignature(ctx context.Context, documentID, provider, subject, message string, signers []models.Signer, tenantID, userID string) (*models.SignatureRequest, error)
+
+	// GetRequest retrieves a signature request of a document the user can read
+	GetRequest(ctx context.Context, id, tenantID, userID string) (*models.SignatureRequest, error)
+
+	// ListDocumentRequests lists the signature requests of a document newest first, with pagination
+	ListDocumentRequests(ctx context.Context, documentID, tenantID, userID string, page int, pageSize int) (utils.PaginatedResult[models.SignatureRequest], error)
+
+	// CancelRequest voids the envelope of a request that is out for signature
+	CancelRequest(ctx context.Context, id, reason, tenantID, userID string) (*models.SignatureRequest, error)
+
+	// HandleProviderCallback verifies and applies a status update posted by a provider. It returns
+	// the headers the provider expects in the response.
+	HandleProviderCallback(ctx context.Context, provider string, header http.Header, body []byte) (map[string]string, error)
+}
+
+// signatureUseCase implements the SignatureUseCase interface
+type signatureUseCase struct {
+	signatureRepo  repositories.SignatureRequestRepository
+	documentRepo   repositories.DocumentRepository
+	storageService services.StorageService
+	scanQueue      services.ScanQueue
+	authService    services.AuthService
+	providers      map[string]services.SignatureProvider
+	outboxRepo     repositories.OutboxRepository
+	txManager      repositories.TransactionManager
+	publicURL      string
+}
+
+// NewSignatureUseCase creates a new SignatureUseCase instance. Providers post status updates to
+// SignatureCallbackPath under publicURL; with no providers, signature requests are rejected.
+func NewSignatureUseCase(
+	signatureRepo repositories.SignatureRequestRepository,
+	documentRepo repositories.DocumentRepository,
+	storageService services.StorageService,
+	scanQueue services.ScanQueue,
+	authService services.AuthService,
+	providers []services.SignatureProvider,
+	outboxRepo repositories.OutboxRepository,
+	txManager repositories.TransactionManager,
+	publicURL string,
+) (SignatureUseCase, error) {
+	if signatureRepo == nil {
+		return nil, fmt.Errorf("signature request repository cannot be nil")
+	}
+	if documentRepo == nil {
+		return nil, fmt.Errorf("document repository cannot be nil")
+	}
+	if storageService == nil {
+		return nil, fmt.Errorf("storage service cannot be nil")
+	}
+	if scanQueue == nil {
+		return nil, fmt.Errorf("scan queue cannot be nil")
+	}
+	if authService == nil {
+		return nil, fmt.Errorf("auth service cannot be nil")
+	}
+	if outboxRepo == nil {
+		return nil, fmt.Errorf("outbox repository cannot be nil")
+	}
+	if txManager == nil {
+		return nil, fmt.Errorf("transaction manager cannot be nil")
+	}
+
+	byName := make(map[string]services.SignatureProvider, len(providers))
+	for _, provider := range providers {
+		if provider == nil {
+			return nil, fmt.Errorf("signature provider cannot be nil")
+		}
+		byName[provider.Name()] = provider
+	}
+
+	return &signatureUseCase{
+		signatureRepo:  signatureRepo,
+		documentRepo:   documentRepo,
+		storageService: storageService,
+		scanQueue:      scanQueue,
+		authService:    authService,
+		providers:      byName,
+		outboxRepo:     outboxRepo,
+		txManager:      txManager,
+		publicURL:      strings.TrimRight(publicURL, "/"),
+	}, nil
+}
+
+// ListProviders lists the names of the configured signature providers in alphabetical order
+func (u *signatureUseCase) ListProviders() []string {
+	names := make([]string, 0, len(u.providers))
+	for name := range u.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// RequestSignature sends the latest version of a document the user can write to the signers. The
+// envelope is sent first, so the request is only persisted once the provider accepted it; if the
+// request cannot be persisted, the envelope is voided again.
+func (u *signatureUseCase) RequestSignature(ctx context.Context, documentID, providerName, subject, message string, signers []models.Signer, tenantID, userID string) (*models.SignatureRequest, error) {
+	log := logger.WithContext(ctx)
+
+	if err := u.validateInput(map[string]string{
+		"document ID": documentID,
+		"tenant ID":   tenantID,
+		"user ID":     userID,
+	}); err != nil {
+		return nil, err
+	}
+
+	provider, ok := u.providers[providerName]
+	if !ok {
+		return nil, ErrSignatureUnknownProvider
+	}
+
+	document, err := u.documentRepo.GetByID(ctx, documentID, tenantID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to request signature")
+	}
+	if err := u.verifyDocumentAccess(ctx, documentID, tenantID, userID, services.PermissionWrite); err != nil {
+		return nil, err
+	}
+
+	version := document.GetLatestVersion()
+	if version == nil || !version.IsAvailable() {
+		return nil, ErrSignatureVersionNotReady
+	}
+
+	request := models.NewSignatureRequest(tenantID, documentID, providerName, subject, message, signers, userID)
+	request.FolderID = document.FolderID
+	request.VersionID = version.ID
+	if err := request.Validate(); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+	request.ID = uuid.New().String()
+
+	content, err := u.storageService.GetDocument(ctx, version.StoragePath)
+	if err != nil {
+		log.WithError(err).Error("failed to read document for signature", "documentID", documentID, "versionID", version.ID)
+		return nil, errors.Wrap(err, "failed to read document for signature")
+	}
+	defer content.Close()
+
+	envelopeID, err := provider.SendEnvelope(ctx, &services.SignatureEnvelope{
+		DocumentName: document.Name,
+		ContentType:  document.ContentType,
+		Content:      content,
+		Subject:      request.Subject,
+		Message:      request.Message,
+		Signers:      request.Signers,
+		CallbackURL:  u.publicURL + SignatureCallbackPath + providerName,
+	})
+	if err != nil {
+		log.WithError(err).Error("failed to send envelope", "documentID", documentID, "provider", providerName)
+		return nil, errors.Wrap(err, "failed to send document for signature")
+	}
+	if err := request.MarkSent(envelopeID, time.Now()); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	err = u.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if _, err := u.signatureRepo.Create(txCtx, request); err != nil {
+			return err
+		}
+		return u.publish(txCtx, models.EventTypeSignatureSent, request)
+	})
+	if err != nil {
+		log.WithError(err).Error("failed to persist signature request", "documentID", documentID, "envelopeID", envelopeID)
+		// Signers must not be asked to sign an envelope the platform does not track
+		if voidErr := provider.VoidEnvelope(ctx, envelopeID, "The signature request could not be recorded"); voidErr != nil {
+			log.WithError(voidErr).Error("failed to void untracked envelope", "provider", providerName, "envelopeID", envelopeID)
+		}
+		return nil, errors.Wrap(err, "failed to request signature")
+	}
+
+	log.Info("document sent for signature", "requestID", request.ID, "documentID", documentID, "provider", providerName, "tenantID", tenantID)
+	return request, nil
+}
+
+// GetRequest retrieves a signature request the user made, or of a document the user can read
+func (u *signatureUseCase) GetRequest(ctx context.Context, id, tenantID, userID string) (*models.SignatureRequest, error) {
+	if err := u.validateInput(map[string]string{
+		"request ID": id,
+		"tenant ID":  tenantID,
+		"user ID":    userID,
+	}); err != nil {
+		return nil, err
+	}
+
+	request, err := u.signatureRepo.GetByID(ctx, id, tenantID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get signature request")
+	}
+	if request.RequestedBy == userID {
+		return request, nil
+	}
+	if err := u.verifyDocumentAccess(ctx, request.DocumentID, tenantID, userID, services.PermissionRead); err != nil {
+		return nil, err
+	}
+
+	return request, nil
+}
+
+// ListDocumentRequests lists the signature requests of a document the user can read
+func (u *signatureUseCase) ListDocumentRequests(ctx context.Context, documentID, tenantID, userID string, page int, pageSize int) (utils.PaginatedResult[models.SignatureRequest], error) {
+	log := logger.WithContext(ctx)
+
+	if err := u.validateInput(map[string]string{
+		"document ID": documentID,
+		"tenant ID":   tenantID,
+		"user ID":     userID,
+	}); err != nil {
+		return utils.PaginatedResult[models.SignatureRequest]{}, err
+	}
+	if err := u.verifyDocumentAccess(ctx, documentID, tenantID, userID, services.PermissionRead); err != nil {
+		return utils.PaginatedResult[models.SignatureRequest]{}, err
+	}
+
+	result, err := u.signatureRepo.ListByDocument(ctx, documentID, tenantID, utils.NewPagination(page, pageSize))
+	if err != nil {
+		log.WithError(err).Error("failed to list signature requests", "documentID", documentID, "tenantID", tenantID)
+		return utils.PaginatedResult[models.SignatureRequest]{}, errors.Wrap(err, "failed to list signature requests")
+	}
+
+	return result, nil
+}
+
+// CancelRequest voids the envelope of a request the user made, or of a document the user can write
+func (u *signatureUseCase) CancelRequest(ctx context.Context, id, reason, tenantID, userID string) (*models.SignatureRequest, error) {
+	log := logger.WithContext(ctx)
+
+	if err := u.validateInput(map[string]string{
+		"request ID": id,
+		"tenant ID":  tenantID,
+		"user ID":    userID,
+	}); err != nil {
+		return nil, err
+	}
+
+	request, err := u.signatureRepo.GetByID(ctx, id, tenantID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to cancel signature request")
+	}
+	if request.RequestedBy != userID {
+		if err := u.verifyDocumentAccess(ctx, request.DocumentID, tenantID, userID, services.PermissionWrite); err != nil {
+			return nil, err
+		}
+	}
+	if !request.IsOpen() {
+		return nil, errors.NewValidationError(models.ErrSignatureRequestClosed.Error())
+	}
+
+	provider, ok := u.providers[request.Provider]
+	if !ok {
+		return nil, ErrSignatureUnknownProvider
+	}
+	if reason == "" {
+		reason = "The signature request was cancelled"
+	}
+	if err := provider.VoidEnvelope(ctx, request.EnvelopeID, reason); err != nil {
+		log.WithError(err).Error("failed to void envelope", "requestID", id, "envelopeID", request.EnvelopeID)
+		return nil, errors.Wrap(err, "failed to cancel signature request")
+	}
+
+	if err := request.Void(reason, time.Now()); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+	if err := u.transition(ctx, request, models.EventTypeSignatureVoided); err != nil {
+		log.WithError(err).Error("failed to cancel signature request", "requestID", id, "tenantID", tenantID)
+		return nil, errors.Wrap(err, "failed to cancel signature request")
+	}
+
+	log.Info("signature request cancelled", "requestID", id, "tenantID", tenantID)
+	return request, nil
+}
+
+// HandleProviderCallback verifies a status update with the provider it claims to come from and
+// applies it to the request of the envelope. Updates for requests that are already closed are
+// acknowledged without changes, since providers retry and may deliver updates more than once.
+func (u *signatureUseCase) HandleProviderCallback(ctx context.Context, providerName string, header http.Header, body []byte) (map[string]string, error) {
+	log := logger.WithContext(ctx)
+
+	provider, ok := u.providers[providerName]
+	if !ok {
+		return nil, errors.NewResourceNotFoundError("signature provider not found")
+	}
+
+	callback, err := provider.ParseCallback(ctx, header, body)
+	if err != nil {
+		return nil, err
+	}
+	if callback.EnvelopeID == "" {
+		return callback.ResponseHeaders, nil
+	}
+
+	request, err := u.signatureRepo.GetByEnvelope(ctx, providerName, callback.EnvelopeID)
+	if err != nil {
+		if errors.IsResourceNotFoundError(err) {
+			// The provider retries, which covers updates arriving before the request is committed
+			return nil, ErrSignatureCallbackNoRequest
+		}
+		return nil, errors.Wrap(err, "failed to handle signature callback")
+	}
+	if !request.IsOpen() {
+		return callback.ResponseHeaders, nil
+	}
+
+	now := time.Now()
+	request.UpdateSigners(callback.Signers)
+
+	switch callback.Status {
+	case models.SignatureStatusCompleted:
+		err = u.complete(ctx, provider, request, now)
+	case models.SignatureStatusDeclined:
+		if err = request.Decline(callback.Reason, now); err == nil {
+			err = u.transition(ctx, request, models.EventTypeSignatureDeclined)
+		}
+	case models.SignatureStatusVoided:
+		if err = request.Void(callback.Reason, now); err == nil {
+			err = u.transition(ctx, request, models.EventTypeSignatureVoided)
+		}
+	default:
+		request.UpdatedAt = now
+		err = u.signatureRepo.Update(ctx, request)
+	}
+	if err != nil {
+		log.WithError(err).Error("failed to apply signature callback", "requestID", request.ID, "provider", providerName, "status", callback.Status)
+		return nil, errors.Wrap(err, "failed to handle signature callback")
+	}
+
+	log.Info("signature callback applied", "requestID", request.ID, "provider", providerName, "status", request.Status, "tenantID", request.TenantID)
+	return callback.ResponseHeaders, nil
+}
+
+// complete stores the signed document of a completed envelope as a new version of the document
+// and completes the request. The version, the request and the signature.completed event are
+// written in one transaction; the version is then scanned like an upload before it becomes available.
+func (u *signatureUseCase) complete(ctx context.Context, provider services.SignatureProvider, request *models.SignatureRequest, now time.Time) error {
+	content, err := u.downloadSignedDocument(ctx, provider, request.EnvelopeID)
+	if err != nil {
+		return err
+	}
+
+	hashingReader, err := utils.NewHashingReader(bytes.NewReader(content), utils.HashAlgorithmSHA256)
+	if err != nil {
+		return errors.Wrap(err, "failed to create content hasher")
+	}
+	encryptionKeyID, err := u.storageService.GetEncryptionKeyID(ctx, request.TenantID)
+	if err != nil {
+		return errors.Wrap(err, "failed to resolve document encryption key")
+	}
+	size := int64(len(content))
+	tempPath, err := u.storageService.StoreTemporary(ctx, request.TenantID, request.DocumentID, hashingReader, size, signedContentType)
+	if err != nil {
+		return errors.Wrap(err, "failed to store signed document")
+	}
+
+	document, err := u.documentRepo.GetByID(ctx, request.DocumentID, request.TenantID)
+	if err != nil {
+		return err
+	}
+	versionNumber := 1
+	if latest := document.GetLatestVersion(); latest != nil {
+		versionNumber = latest.VersionNumber + 1
+	}
+
+	version := models.NewDocumentVersion(request.DocumentID, versionNumber, size, hashingReader.Sum(), tempPath, request.RequestedBy)
+	version.ID = uuid.New().String()
+	version.EncryptionKeyID = encryptionKeyID
+	if err := request.Complete(version.ID, now); err != nil {
+		return errors.NewValidationError(err.Error())
+	}
+
+	err = u.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if _, err := u.documentRepo.AddVersion(txCtx, &version); err != nil {
+			return err
+		}
+		if err := u.signatureRepo.Update(txCtx, request); err != nil {
+			return err
+		}
+		return u.publish(txCtx, models.EventTypeSignatureCompleted, request)
+	})
+	if err != nil {
+		return err
+	}
+
+	return u.scanQueue.Enqueue(ctx, services.ScanTask{
+		DocumentID:  request.DocumentID,
+		VersionID:   version.ID,
+		TenantID:    request.TenantID,
+		StoragePath: tempPath,
+		Priority:    services.ScanPriorityNormal,
+	})
+}
+
+// downloadSignedDocument reads the signed document of an envelope, up to MaxSignedDocumentSize
+func (u *signatureUseCase) downloadSignedDocument(ctx context.Context, provider services.SignatureProvider, envelopeID string) ([]byte, error) {
+	reader, err := provider.DownloadSignedDocument(ctx, envelopeID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to download signed document")
+	}
+	defer reader.Close()
+
+	content, err := ioutil.ReadAll(io.LimitReader(reader, MaxSignedDocumentSize+1))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to download signed document")
+	}
+	if len(content) > MaxSignedDocumentSize {
+		return nil, ErrSignatureDocumentTooLarge
+	}
+	if len(content) == 0 {
+		return nil, errors.NewDependencyError("the provider returned an empty signed document")
+	}
+	return content, nil
+}
+
+// transition persists a transition of a request and writes its event to the outbox, in one transaction
+func (u *signatureUseCase) transition(ctx context.Context, request *models.SignatureRequest, eventType string) error {
+	return u.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := u.signatureRepo.Update(txCtx, request); err != nil {
+			return err
+		}
+		return u.publish(txCtx, eventType, request)
+	})
+}
+
+// publish writes a signature event to the outbox within the transaction carried by ctx
+func (u *signatureUseCase) publish(ctx context.Context, eventType string, request *models.SignatureRequest) error {
+	event, err := models.NewSignatureEvent(eventType, request)
+	if err != nil {
+		return errors.Wrap(err, "failed to create signature event")
+	}
+	event.ID = uuid.New().String()
+
+	message, err := models.NewOutboxMessage(event)
+	if err != nil {
+		return errors.Wrap(err, "invalid outbox message")
+	}
+
+	_, err = u.outboxRepo.Create(ctx, message)
+	return err
+}
+
+// verifyDocumentAccess checks that the user has the given permission on a document
+func (u *signatureUseCase) verifyDocumentAccess(ctx context.Context, documentID, tenantID, userID, permission string) error {
+	hasAccess, err := u.authService.VerifyResourceAccess(ctx, userID, tenantID, services.ResourceTypeDocument, documentID, permission)
+	if err != nil {
+		logger.WithContext(ctx).WithError(err).Error("failed to verify document access", "documentID", documentID, "userID", userID)
+		return errors.Wrap(err, "failed to verify document access")
+	}
+	if !hasAccess {
+		return ErrPermissionDenied
+	}
+	return nil
+}
+
+// validateInput validates that required input parameters are not empty
+func (u *signatureUseCase) validateInput(params map[string]string) error {
+	for name, value := range params {
+		if value == "" {
+			return errors.NewValidationError(fmt.Sprintf("%s cannot be empty", name))
+		}
+	}
+	return nil
+}
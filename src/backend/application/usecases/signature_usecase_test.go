@@ -0,0 +1,429 @@
+package usecases
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert" // v1.8.0+
+	"github.com/stretchr/testify/mock"   // v1.8.0+
+	"github.com/stretchr/testify/suite"  // v1.8.0+
+
+	"../../domain/models"
+	"../../domain/repositories"
+	"../../domain/services"
+	pkgErrors "../../pkg/errors"
+	"../../pkg/utils"
+)
+
+// MockSignatureRequestRepository is a mock implementation of the SignatureRequestRepository interface for testing
+type MockSignatureRequestRepository struct {
+	mock.Mock
+}
+
+func (m *MockSignatureRequestRepository) Create(ctx context.Context, request *models.SignatureRequest) (string, error) {
+	args := m.Called(ctx, request)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockSignatureRequestRepository) GetByID(ctx context.Context, id string, tenantID string) (*models.SignatureRequest, error) {
+	args := m.Called(ctx, id, tenantID)
+	if request := args.Get(0); request != nil {
+		return request.(*models.SignatureRequest), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockSignatureRequestRepository) GetByEnvelope(ctx context.Context, provider string, envelopeID string) (*models.SignatureRequest, error) {
+	args := m.Called(ctx, provider, envelopeID)
+	if request := args.Get(0); request != nil {
+		return request.(*models.SignatureRequest), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockSignatureRequestRepository) Update(ctx context.Context, request *models.SignatureRequest) error {
+	args := m.Called(ctx, request)
+	return args.Error(0)
+}
+
+func (m *MockSignatureRequestRepository) ListByDocument(ctx context.Context, documentID string, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.SignatureRequest], error) {
+	args := m.Called(ctx, documentID, tenantID, pagination)
+	return args.Get(0).(utils.PaginatedResult[models.SignatureRequest]), args.Error(1)
+}
+
+// MockSignatureProvider is a mock implementation of the SignatureProvider interface for testing
+type MockSignatureProvider struct {
+	mock.Mock
+}
+
+func (m *MockSignatureProvider) Name() string {
+	return models.SignatureProviderDocuSign
+}
+
+func (m *MockSignatureProvider) SendEnvelope(ctx context.Context, envelope *services.SignatureEnvelope) (string, error) {
+	args := m.Called(ctx, envelope)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockSignatureProvider) VoidEnvelope(ctx context.Context, envelopeID, reason string) error {
+	args := m.Called(ctx, envelopeID, reason)
+	return args.Error(0)
+}
+
+func (m *MockSignatureProvider) DownloadSignedDocument(ctx context.Context, envelopeID string) (io.ReadCloser, error) {
+	args := m.Called(ctx, envelopeID)
+	if reader := args.Get(0); reader != nil {
+		return reader.(io.ReadCloser), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockSignatureProvider) ParseCallback(ctx context.Context, header http.Header, body []byte) (*services.SignatureCallback, error) {
+	args := m.Called(ctx, header, body)
+	if callback := args.Get(0); callback != nil {
+		return callback.(*services.SignatureCallback), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// mockSignatureDocumentRepository mocks the DocumentRepository methods used by signatures
+type mockSignatureDocumentRepository struct {
+	repositories.DocumentRepository
+	mock.Mock
+}
+
+func (m *mockSignatureDocumentRepository) GetByID(ctx context.Context, id string, tenantID string) (*models.Document, error) {
+	args := m.Called(ctx, id, tenantID)
+	if document := args.Get(0); document != nil {
+		return document.(*models.Document), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *mockSignatureDocumentRepository) AddVersion(ctx context.Context, version *models.DocumentVersion) (string, error) {
+	args := m.Called(ctx, version)
+	return args.String(0), args.Error(1)
+}
+
+// mockSignatureStorageService mocks the StorageService methods used by signatures
+type mockSignatureStorageService struct {
+	services.StorageService
+	mock.Mock
+}
+
+func (m *mockSignatureStorageService) GetDocument(ctx context.Context, storagePath string) (io.ReadCloser, error) {
+	args := m.Called(ctx, storagePath)
+	if reader := args.Get(0); reader != nil {
+		return reader.(io.ReadCloser), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *mockSignatureStorageService) StoreTemporary(ctx context.Context, tenantID string, documentID string, content io.Reader, size int64, contentType string) (string, error) {
+	ioutil.ReadAll(content)
+	args := m.Called(ctx, tenantID, documentID, content, size, contentType)
+	return args.String(0), args.Error(1)
+}
+
+func (m *mockSignatureStorageService) GetEncryptionKeyID(ctx context.Context, tenantID string) (string, error) {
+	args := m.Called(ctx, tenantID)
+	return args.String(0), args.Error(1)
+}
+
+// mockSignatureScanQueue mocks the ScanQueue methods used by signatures
+type mockSignatureScanQueue struct {
+	services.ScanQueue
+	mock.Mock
+}
+
+func (m *mockSignatureScanQueue) Enqueue(ctx context.Context, task services.ScanTask) error {
+	args := m.Called(ctx, task)
+	return args.Error(0)
+}
+
+// mockSignatureAuthService mocks the AuthService methods used by signatures
+type mockSignatureAuthService struct {
+	services.AuthService
+	mock.Mock
+}
+
+func (m *mockSignatureAuthService) VerifyResourceAccess(ctx context.Context, userID, tenantID, resourceType, resourceID, accessType string) (bool, error) {
+	args := m.Called(ctx, userID, tenantID, resourceType, resourceID, accessType)
+	return args.Bool(0), args.Error(1)
+}
+
+// SignatureUseCaseTestSuite defines a test suite for SignatureUseCase
+type SignatureUseCaseTestSuite struct {
+	suite.Suite
+	mockSignatureRepo *MockSignatureRequestRepository
+	mockDocumentRepo  *mockSignatureDocumentRepository
+	mockStorage       *mockSignatureStorageService
+	mockScanQueue     *mockSignatureScanQueue
+	mockAuthService   *mockSignatureAuthService
+	mockProvider      *MockSignatureProvider
+	mockOutboxRepo    *MockOutboxRepository
+	signatureUseCase  SignatureUseCase
+}
+
+// SetupTest sets up the test environment before each test
+func (s *SignatureUseCaseTestSuite) SetupTest() {
+	s.mockSignatureRepo = new(MockSignatureRequestRepository)
+	s.mockDocumentRepo = new(mockSignatureDocumentRepository)
+	s.mockStorage = new(mockSignatureStorageService)
+	s.mockScanQueue = new(mockSignatureScanQueue)
+	s.mockAuthService = new(mockSignatureAuthService)
+	s.mockProvider = new(MockSignatureProvider)
+	s.mockOutboxRepo = new(MockOutboxRepository)
+
+	var err error
+	s.signatureUseCase, err = NewSignatureUseCase(s.mockSignatureRepo, s.mockDocumentRepo, s.mockStorage, s.mockScanQueue, s.mockAuthService,
+		[]services.SignatureProvider{s.mockProvider}, s.mockOutboxRepo, &passthroughTransactionManager{}, "https://dms.example.com/")
+	assert.Nil(s.T(), err)
+	assert.NotNil(s.T(), s.signatureUseCase)
+}
+
+// document returns document doc123 of folder123 with an available first version
+func (s *SignatureUseCaseTestSuite) document() *models.Document {
+	document := models.NewDocument("contract.pdf", "application/pdf", 7, "folder123", "tenant123", "user123")
+	document.ID = "doc123"
+	version := models.NewDocumentVersion("doc123", 1, 7, "hash", "tenant123/doc123/v1", "user123")
+	version.ID = "version1"
+	version.MarkAsAvailable()
+	document.AddVersion(version)
+	return &document
+}
+
+// sentRequest returns request123 of doc123, out for signature with envelope env123 to two signers
+func (s *SignatureUseCaseTestSuite) sentRequest() *models.SignatureRequest {
+	request := models.NewSignatureRequest("tenant123", "doc123", models.SignatureProviderDocuSign, "Contract", "", []models.Signer{
+		{ID: "signer1", Name: "Jane", Email: "jane@example.com"},
+		{ID: "signer2", Name: "John", Email: "john@example.com", RoutingOrder: 2},
+	}, "user123")
+	request.ID = "request123"
+	request.FolderID = "folder123"
+	request.VersionID = "version1"
+	request.MarkSent("env123", request.CreatedAt)
+	return request
+}
+
+// expectEvent expects an event of the given type to be written to the outbox
+func (s *SignatureUseCaseTestSuite) expectEvent(eventType string) {
+	s.mockOutboxRepo.On("Create", mock.Anything, mock.MatchedBy(func(m *models.OutboxMessage) bool {
+		return m.EventType == eventType
+	})).Return("message123", nil).Once()
+}
+
+// TestNewSignatureUseCase tests the creation of a new SignatureUseCase
+func (s *SignatureUseCaseTestSuite) TestNewSignatureUseCase() {
+	useCase, err := NewSignatureUseCase(s.mockSignatureRepo, s.mockDocumentRepo, s.mockStorage, s.mockScanQueue, s.mockAuthService, nil, s.mockOutboxRepo, nil, "")
+	assert.NotNil(s.T(), err)
+	assert.Nil(s.T(), useCase)
+
+	assert.Equal(s.T(), []string{models.SignatureProviderDocuSign}, s.signatureUseCase.ListProviders())
+}
+
+// TestRequestSignature_Success tests sending the latest version, which persists the request and publishes signature.sent
+func (s *SignatureUseCaseTestSuite) TestRequestSignature_Success() {
+	s.mockDocumentRepo.On("GetByID", mock.Anything, "doc123", "tenant123").Return(s.document(), nil)
+	s.mockAuthService.On("VerifyResourceAccess", mock.Anything, "user123", "tenant123", services.ResourceTypeDocument, "doc123", services.PermissionWrite).Return(true, nil)
+	s.mockStorage.On("GetDocument", mock.Anything, "tenant123/doc123/v1").Return(ioutil.NopCloser(strings.NewReader("content")), nil)
+	s.mockProvider.On("SendEnvelope", mock.Anything, mock.MatchedBy(func(e *services.SignatureEnvelope) bool {
+		return e.DocumentName == "contract.pdf" && e.CallbackURL == "https://dms.example.com/api/v1/signature-callbacks/docusign" && len(e.Signers) == 1
+	})).Return("env123", nil)
+	s.mockSignatureRepo.On("Create", mock.Anything, mock.MatchedBy(func(r *models.SignatureRequest) bool {
+		return r.Status == models.SignatureStatusSent && r.EnvelopeID == "env123" && r.VersionID == "version1" && r.FolderID == "folder123"
+	})).Return("request123", nil)
+	s.expectEvent(models.EventTypeSignatureSent)
+
+	request, err := s.signatureUseCase.RequestSignature(context.Background(), "doc123", models.SignatureProviderDocuSign, "Contract", "Please sign",
+		[]models.Signer{{Name: "Jane", Email: "jane@example.com"}}, "tenant123", "user123")
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), models.SignatureStatusSent, request.Status)
+	assert.Equal(s.T(), 1, request.Signers[0].RoutingOrder)
+	s.mockOutboxRepo.AssertExpectations(s.T())
+}
+
+// TestRequestSignature_UnknownProvider tests requesting a signature through a provider that is not configured
+func (s *SignatureUseCaseTestSuite) TestRequestSignature_UnknownProvider() {
+	request, err := s.signatureUseCase.RequestSignature(context.Background(), "doc123", models.SignatureProviderAdobeSign, "Contract", "",
+		[]models.Signer{{Name: "Jane", Email: "jane@example.com"}}, "tenant123", "user123")
+
+	assert.Nil(s.T(), request)
+	assert.Equal(s.T(), ErrSignatureUnknownProvider, err)
+	s.mockDocumentRepo.AssertNotCalled(s.T(), "GetByID")
+}
+
+// TestRequestSignature_PersistFails tests that the envelope is voided when the request cannot be persisted
+func (s *SignatureUseCaseTestSuite) TestRequestSignature_PersistFails() {
+	s.mockDocumentRepo.On("GetByID", mock.Anything, "doc123", "tenant123").Return(s.document(), nil)
+	s.mockAuthService.On("VerifyResourceAccess", mock.Anything, "user123", "tenant123", services.ResourceTypeDocument, "doc123", services.PermissionWrite).Return(true, nil)
+	s.mockStorage.On("GetDocument", mock.Anything, "tenant123/doc123/v1").Return(ioutil.NopCloser(strings.NewReader("content")), nil)
+	s.mockProvider.On("SendEnvelope", mock.Anything, mock.Anything).Return("env123", nil)
+	s.mockSignatureRepo.On("Create", mock.Anything, mock.Anything).Return("", pkgErrors.NewInternalError("database unavailable"))
+	s.mockProvider.On("VoidEnvelope", mock.Anything, "env123", mock.Anything).Return(nil)
+
+	request, err := s.signatureUseCase.RequestSignature(context.Background(), "doc123", models.SignatureProviderDocuSign, "Contract", "",
+		[]models.Signer{{Name: "Jane", Email: "jane@example.com"}}, "tenant123", "user123")
+
+	assert.Nil(s.T(), request)
+	assert.NotNil(s.T(), err)
+	s.mockProvider.AssertCalled(s.T(), "VoidEnvelope", mock.Anything, "env123", mock.Anything)
+}
+
+// TestRequestSignature_PermissionDenied tests requesting a signature on a document the user cannot write
+func (s *SignatureUseCaseTestSuite) TestRequestSignature_PermissionDenied() {
+	s.mockDocumentRepo.On("GetByID", mock.Anything, "doc123", "tenant123").Return(s.document(), nil)
+	s.mockAuthService.On("VerifyResourceAccess", mock.Anything, "user456", "tenant123", services.ResourceTypeDocument, "doc123", services.PermissionWrite).Return(false, nil)
+
+	request, err := s.signatureUseCase.RequestSignature(context.Background(), "doc123", models.SignatureProviderDocuSign, "Contract", "",
+		[]models.Signer{{Name: "Jane", Email: "jane@example.com"}}, "tenant123", "user456")
+
+	assert.Nil(s.T(), request)
+	assert.Equal(s.T(), ErrPermissionDenied, err)
+	s.mockProvider.AssertNotCalled(s.T(), "SendEnvelope")
+}
+
+// TestHandleProviderCallback_SignerSigned tests that signer updates are recorded without closing the request
+func (s *SignatureUseCaseTestSuite) TestHandleProviderCallback_SignerSigned() {
+	request := s.sentRequest()
+	header := http.Header{}
+	body := []byte("update")
+	s.mockProvider.On("ParseCallback", mock.Anything, header, body).Return(&services.SignatureCallback{
+		EnvelopeID: "env123",
+		Signers:    []models.SignerStatusUpdate{{Email: "JANE@example.com", Status: models.SignerStatusSigned, IPAddress: "10.0.0.1"}},
+	}, nil)
+	s.mockSignatureRepo.On("GetByEnvelope", mock.Anything, models.SignatureProviderDocuSign, "env123").Return(request, nil)
+	s.mockSignatureRepo.On("Update", mock.Anything, request).Return(nil)
+
+	_, err := s.signatureUseCase.HandleProviderCallback(context.Background(), models.SignatureProviderDocuSign, header, body)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), models.SignatureStatusSent, request.Status)
+	assert.Equal(s.T(), models.SignerStatusSigned, request.Signers[0].Status)
+	assert.Equal(s.T(), "10.0.0.1", request.Signers[0].IPAddress)
+	s.mockOutboxRepo.AssertNotCalled(s.T(), "Create")
+}
+
+// TestHandleProviderCallback_Completed tests that the signed document is stored as a new version and scanned
+func (s *SignatureUseCaseTestSuite) TestHandleProviderCallback_Completed() {
+	request := s.sentRequest()
+	header := http.Header{}
+	body := []byte("completed")
+	s.mockProvider.On("ParseCallback", mock.Anything, header, body).Return(&services.SignatureCallback{
+		EnvelopeID: "env123",
+		Status:     models.SignatureStatusCompleted,
+	}, nil)
+	s.mockSignatureRepo.On("GetByEnvelope", mock.Anything, models.SignatureProviderDocuSign, "env123").Return(request, nil)
+	s.mockProvider.On("DownloadSignedDocument", mock.Anything, "env123").Return(ioutil.NopCloser(strings.NewReader("signed")), nil)
+	s.mockStorage.On("GetEncryptionKeyID", mock.Anything, "tenant123").Return("", nil)
+	s.mockStorage.On("StoreTemporary", mock.Anything, "tenant123", "doc123", mock.Anything, int64(6), "application/pdf").Return("temp/signed", nil)
+	s.mockDocumentRepo.On("GetByID", mock.Anything, "doc123", "tenant123").Return(s.document(), nil)
+	s.mockDocumentRepo.On("AddVersion", mock.Anything, mock.MatchedBy(func(v *models.DocumentVersion) bool {
+		return v.VersionNumber == 2 && v.Status == models.VersionStatusProcessing && v.StoragePath == "temp/signed" && v.ContentHash != ""
+	})).Return("version2", nil)
+	s.mockSignatureRepo.On("Update", mock.Anything, request).Return(nil)
+	s.expectEvent(models.EventTypeSignatureCompleted)
+	s.mockScanQueue.On("Enqueue", mock.Anything, mock.MatchedBy(func(t services.ScanTask) bool {
+		return t.DocumentID == "doc123" && t.TenantID == "tenant123" && t.StoragePath == "temp/signed" && t.VersionID == request.SignedVersionID
+	})).Return(nil)
+
+	_, err := s.signatureUseCase.HandleProviderCallback(context.Background(), models.SignatureProviderDocuSign, header, body)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), models.SignatureStatusCompleted, request.Status)
+	assert.NotEmpty(s.T(), request.SignedVersionID)
+	s.mockScanQueue.AssertExpectations(s.T())
+	s.mockOutboxRepo.AssertExpectations(s.T())
+}
+
+// TestHandleProviderCallback_Declined tests that a declined envelope closes the request and publishes signature.declined
+func (s *SignatureUseCaseTestSuite) TestHandleProviderCallback_Declined() {
+	request := s.sentRequest()
+	header := http.Header{}
+	body := []byte("declined")
+	s.mockProvider.On("ParseCallback", mock.Anything, header, body).Return(&services.SignatureCallback{
+		EnvelopeID: "env123",
+		Status:     models.SignatureStatusDeclined,
+		Reason:     "wrong amount",
+	}, nil)
+	s.mockSignatureRepo.On("GetByEnvelope", mock.Anything, models.SignatureProviderDocuSign, "env123").Return(request, nil)
+	s.mockSignatureRepo.On("Update", mock.Anything, request).Return(nil)
+	s.expectEvent(models.EventTypeSignatureDeclined)
+
+	_, err := s.signatureUseCase.HandleProviderCallback(context.Background(), models.SignatureProviderDocuSign, header, body)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), models.SignatureStatusDeclined, request.Status)
+	assert.Equal(s.T(), "wrong amount", request.StatusReason)
+	s.mockOutboxRepo.AssertExpectations(s.T())
+}
+
+// TestHandleProviderCallback_Closed tests that updates for closed requests are acknowledged without changes
+func (s *SignatureUseCaseTestSuite) TestHandleProviderCallback_Closed() {
+	request := s.sentRequest()
+	request.Decline("", request.CreatedAt)
+	header := http.Header{}
+	body := []byte("completed")
+	s.mockProvider.On("ParseCallback", mock.Anything, header, body).Return(&services.SignatureCallback{
+		EnvelopeID: "env123",
+		Status:     models.SignatureStatusCompleted,
+	}, nil)
+	s.mockSignatureRepo.On("GetByEnvelope", mock.Anything, models.SignatureProviderDocuSign, "env123").Return(request, nil)
+
+	_, err := s.signatureUseCase.HandleProviderCallback(context.Background(), models.SignatureProviderDocuSign, header, body)
+
+	assert.Nil(s.T(), err)
+	s.mockSignatureRepo.AssertNotCalled(s.T(), "Update")
+	s.mockProvider.AssertNotCalled(s.T(), "DownloadSignedDocument")
+}
+
+// TestHandleProviderCallback_Unverified tests that callbacks the provider cannot verify are rejected
+func (s *SignatureUseCaseTestSuite) TestHandleProviderCallback_Unverified() {
+	header := http.Header{}
+	body := []byte("forged")
+	s.mockProvider.On("ParseCallback", mock.Anything, header, body).Return(nil, pkgErrors.NewAuthenticationError("invalid signature"))
+
+	_, err := s.signatureUseCase.HandleProviderCallback(context.Background(), models.SignatureProviderDocuSign, header, body)
+
+	assert.True(s.T(), pkgErrors.IsAuthenticationError(err))
+	s.mockSignatureRepo.AssertNotCalled(s.T(), "GetByEnvelope")
+}
+
+// TestCancelRequest_Success tests that cancelling voids the envelope and publishes signature.voided
+func (s *SignatureUseCaseTestSuite) TestCancelRequest_Success() {
+	request := s.sentRequest()
+	s.mockSignatureRepo.On("GetByID", mock.Anything, "request123", "tenant123").Return(request, nil)
+	s.mockProvider.On("VoidEnvelope", mock.Anything, "env123", "no longer needed").Return(nil)
+	s.mockSignatureRepo.On("Update", mock.Anything, request).Return(nil)
+	s.expectEvent(models.EventTypeSignatureVoided)
+
+	cancelled, err := s.signatureUseCase.CancelRequest(context.Background(), "request123", "no longer needed", "tenant123", "user123")
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), models.SignatureStatusVoided, cancelled.Status)
+	s.mockOutboxRepo.AssertExpectations(s.T())
+}
+
+// TestCancelRequest_ProviderFails tests that the request stays open when the provider cannot void the envelope
+func (s *SignatureUseCaseTestSuite) TestCancelRequest_ProviderFails() {
+	request := s.sentRequest()
+	s.mockSignatureRepo.On("GetByID", mock.Anything, "request123", "tenant123").Return(request, nil)
+	s.mockProvider.On("VoidEnvelope", mock.Anything, "env123", mock.Anything).Return(pkgErrors.NewDependencyError("provider unavailable"))
+
+	cancelled, err := s.signatureUseCase.CancelRequest(context.Background(), "request123", "", "tenant123", "user123")
+
+	assert.Nil(s.T(), cancelled)
+	assert.NotNil(s.T(), err)
+	assert.Equal(s.T(), models.SignatureStatusSent, request.Status)
+	s.mockSignatureRepo.AssertNotCalled(s.T(), "Update")
+}
+
+// TestSignatureUseCaseSuite runs the SignatureUseCase test suite
+func TestSignatureUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(SignatureUseCaseTestSuite))
+}
@@ -0,0 +1,343 @@
+// Package usecases implements the application layer of the Document Management Platform.
+// It contains use case implementations that orchestrate domain models and services.
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"../../domain/models"
+	"../../domain/repositories"
+	"../../domain/services"
+	"../../pkg/errors"
+	"../../pkg/logger"
+)
+
+// SSOUseCase defines the contract for signing users in through their tenant's external identity provider
+type SSOUseCase interface {
+	// LoginWithSSO exchanges an ID token issued by the tenant's identity provider for an access
+	// token and a refresh token. The user is matched by the issuer and subject they were linked to
+	// on their first sign-in. A first sign-in links the account with the IdP's verified email or,
+	// when the tenant allows it, creates one. When the user's IdP groups map to tenant roles, those
+	// roles replace the user's roles. Users who have to verify a second factor receive only an MFA
+	// token, as with a password sign-in.
+	LoginWithSSO(ctx context.Context, tenantID, idToken string) (*LoginResult, error)
+}
+
+// ssoUseCase implements the SSOUseCase interface
+type ssoUseCase struct {
+	identityProvider services.IdentityProvider
+	authService      services.AuthService
+	userRepo         repositories.UserRepository
+	tenantRepo       repositories.TenantRepository
+	linkRepo         repositories.ExternalIdentityLinkRepository
+	mfaRepo          repositories.MFARepository
+	auditService     services.AuditService
+}
+
+// NewSSOUseCase creates a new SSOUseCase instance
+func NewSSOUseCase(
+	identityProvider services.IdentityProvider,
+	authService services.AuthService,
+	userRepo repositories.UserRepository,
+	tenantRepo repositories.TenantRepository,
+	linkRepo repositories.ExternalIdentityLinkRepository,
+	mfaRepo repositories.MFARepository,
+	auditService services.AuditService,
+) (SSOUseCase, error) {
+	if identityProvider == nil {
+		return nil, fmt.Errorf("identity provider cannot be nil")
+	}
+	if authService == nil {
+		return nil, fmt.Errorf("auth service cannot be nil")
+	}
+	if userRepo == nil {
+		return nil, fmt.Errorf("user repository cannot be nil")
+	}
+	if tenantRepo == nil {
+		return nil, fmt.Errorf("tenant repository cannot be nil")
+	}
+	if linkRepo == nil {
+		return nil, fmt.Errorf("external identity link repository cannot be nil")
+	}
+	if mfaRepo == nil {
+		return nil, fmt.Errorf("MFA repository cannot be nil")
+	}
+	if auditService == nil {
+		return nil, fmt.Errorf("audit service cannot be nil")
+	}
+
+	return &ssoUseCase{
+		identityProvider: identityProvider,
+		authService:      authService,
+		userRepo:         userRepo,
+		tenantRepo:       tenantRepo,
+		linkRepo:         linkRepo,
+		mfaRepo:          mfaRepo,
+		auditService:     auditService,
+	}, nil
+}
+
+// LoginWithSSO exchanges an ID token issued by the tenant's identity provider for platform tokens
+func (u *ssoUseCase) LoginWithSSO(ctx context.Context, tenantID, idToken string) (*LoginResult, error) {
+	log := logger.WithContext(ctx)
+
+	if err := u.validateInput(map[string]string{
+		"tenant ID": tenantID,
+		"ID token":  idToken,
+	}); err != nil {
+		return nil, err
+	}
+
+	// Check if tenant exists and is active
+	tenant, err := u.tenantRepo.GetByID(ctx, tenantID)
+	if err != nil {
+		if errors.IsResourceNotFoundError(err) {
+			return nil, errors.NewAuthenticationError("invalid tenant ID")
+		}
+		return nil, errors.Wrap(err, "failed to retrieve tenant")
+	}
+	if !strings.EqualFold(tenant.Status, models.TenantStatusActive) {
+		return nil, errors.NewAuthenticationError("tenant is not active")
+	}
+
+	identity, err := u.identityProvider.VerifyIDToken(ctx, tenantID, idToken)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := u.findUser(ctx, identity)
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsActive() {
+		return nil, errors.NewAuthenticationError("user account is not active")
+	}
+	if err := u.syncRoles(ctx, user, identity); err != nil {
+		return nil, err
+	}
+
+	// Users with an enabled second factor, and all users of tenants that require one, have to
+	// verify it before they get access, whichever way they signed in
+	enrollment, err := u.mfaRepo.GetByUserID(ctx, user.ID, user.TenantID)
+	if err != nil && !errors.IsResourceNotFoundError(err) {
+		return nil, errors.Wrap(err, "failed to retrieve MFA enrollment")
+	}
+	mfaEnabled := err == nil && enrollment.IsEnabled()
+
+	if mfaEnabled || tenant.RequiresMFA() {
+		mfaToken, err := u.authService.GenerateMFAToken(ctx, user.ID, user.TenantID)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate MFA token")
+		}
+		log.Info("user signed in through SSO, second factor required", "userID", user.ID, "tenantID", tenantID, "issuer", identity.Issuer)
+		return &LoginResult{
+			MFAToken:              mfaToken,
+			MFARequired:           true,
+			MFAEnrollmentRequired: !mfaEnabled,
+		}, nil
+	}
+
+	// Start a session with an access token carrying the user's roles
+	token, refreshToken, err := u.authService.CreateSession(ctx, user.ID, user.TenantID, user.Roles)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create session")
+	}
+
+	log.Info("user signed in through SSO", "userID", user.ID, "tenantID", tenantID, "issuer", identity.Issuer)
+	return &LoginResult{
+		AccessToken:  token,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+// findUser returns the account linked to the identity's issuer and subject. On the first sign-in
+// of a subject, the account with the same email is linked only if the IdP verified the email and
+// the account is not linked to another subject of the issuer; without such an account, one is
+// provisioned if the tenant allows it.
+func (u *ssoUseCase) findUser(ctx context.Context, identity *models.ExternalIdentity) (*models.User, error) {
+	log := logger.WithContext(ctx)
+
+	link, err := u.linkRepo.GetBySubject(ctx, identity.TenantID, identity.Issuer, identity.Subject)
+	if err == nil {
+		user, err := u.userRepo.GetByID(ctx, link.UserID, identity.TenantID)
+		if err != nil {
+			if errors.IsResourceNotFoundError(err) {
+				return nil, errors.NewAuthenticationError("no account exists for this identity")
+			}
+			return nil, errors.Wrap(err, "failed to retrieve user")
+		}
+		return user, nil
+	}
+	if !errors.IsResourceNotFoundError(err) {
+		log.WithError(err).Error("failed to get external identity link", "tenantID", identity.TenantID)
+		return nil, errors.Wrap(err, "failed to retrieve external identity link")
+	}
+
+	user, err := u.userRepo.GetByEmail(ctx, identity.Email, identity.TenantID)
+	if err != nil && !errors.IsResourceNotFoundError(err) {
+		log.WithError(err).Error("failed to get user by email", "tenantID", identity.TenantID)
+		return nil, errors.Wrap(err, "failed to retrieve user by email")
+	}
+
+	if user == nil || errors.IsResourceNotFoundError(err) {
+		user, err = u.provisionUser(ctx, identity)
+		if err != nil {
+			return nil, err
+		}
+		if err := u.link(ctx, user, identity); err != nil {
+			return nil, err
+		}
+		return user, nil
+	}
+
+	// Anyone able to set an email at the IdP would otherwise take over the account using it
+	if !identity.EmailVerified {
+		return nil, errors.NewAuthenticationError("the identity provider has not verified the email of this identity")
+	}
+	_, err = u.linkRepo.GetByUserID(ctx, user.ID, user.TenantID, identity.Issuer)
+	if err == nil {
+		return nil, errors.NewAuthenticationError("the account is linked to another identity")
+	}
+	if !errors.IsResourceNotFoundError(err) {
+		return nil, errors.Wrap(err, "failed to retrieve external identity link")
+	}
+
+	if err := u.link(ctx, user, identity); err != nil {
+		return nil, err
+	}
+
+	err = u.auditService.RecordAction(ctx, user.TenantID, user.ID, models.AuditActionUpdate, models.AuditResourceUser, user.ID, nil, map[string]interface{}{
+		"issuer":  identity.Issuer,
+		"subject": identity.Subject,
+	})
+	if err != nil {
+		log.WithError(err).Error("failed to record identity link in audit log")
+		// Do not return error, the account has already been linked
+	}
+
+	log.Info("account linked to external identity", "userID", user.ID, "tenantID", identity.TenantID, "issuer", identity.Issuer)
+	return user, nil
+}
+
+// link ties a user's account to the identity's issuer and subject, so that later sign-ins no
+// longer depend on the email
+func (u *ssoUseCase) link(ctx context.Context, user *models.User, identity *models.ExternalIdentity) error {
+	if _, err := u.linkRepo.Create(ctx, models.NewExternalIdentityLink(user.ID, identity)); err != nil {
+		if errors.IsValidationError(err) {
+			return errors.NewAuthenticationError("the account is linked to another identity")
+		}
+		logger.WithContext(ctx).WithError(err).Error("failed to link external identity", "userID", user.ID)
+		return errors.Wrap(err, "failed to link external identity")
+	}
+	return nil
+}
+
+// provisionUser creates the account of a user signing in for the first time, if the tenant allows it.
+// Provisioned users have no password and can only sign in through the identity provider.
+func (u *ssoUseCase) provisionUser(ctx context.Context, identity *models.ExternalIdentity) (*models.User, error) {
+	log := logger.WithContext(ctx)
+
+	enabled, defaultRole := u.identityProvider.ProvisioningPolicy(identity.TenantID)
+	if !enabled {
+		return nil, errors.NewAuthenticationError("no account exists for this identity")
+	}
+
+	// Fall back to the email address when the IdP username is taken by another account
+	username := identity.Username
+	exists, err := u.userRepo.ExistsByUsername(ctx, username, identity.TenantID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to check username")
+	}
+	if exists {
+		username = identity.Email
+	}
+
+	user := models.NewUser(username, identity.Email, identity.TenantID)
+	switch {
+	case len(identity.Roles) > 0:
+		user.Roles = identity.Roles
+	case defaultRole != "":
+		user.Roles = []string{defaultRole}
+	}
+	if err := user.Validate(); err != nil {
+		return nil, errors.NewAuthenticationError("cannot provision user: " + err.Error())
+	}
+
+	if _, err := u.userRepo.Create(ctx, user); err != nil {
+		log.WithError(err).Error("failed to provision user", "tenantID", identity.TenantID)
+		return nil, err
+	}
+
+	err = u.auditService.RecordAction(ctx, identity.TenantID, user.ID, models.AuditActionCreate, models.AuditResourceUser, user.ID, nil, map[string]interface{}{
+		"username": user.Username,
+		"email":    user.Email,
+		"roles":    user.Roles,
+		"issuer":   identity.Issuer,
+		"subject":  identity.Subject,
+	})
+	if err != nil {
+		log.WithError(err).Error("failed to record user provisioning in audit log")
+		// Do not return error, the user has already been created
+	}
+
+	log.Info("user provisioned through SSO", "userID", user.ID, "tenantID", identity.TenantID)
+	return user, nil
+}
+
+// syncRoles replaces the roles of an existing user with the roles their IdP groups map to.
+// Users none of whose groups are mapped keep the roles assigned on the platform.
+func (u *ssoUseCase) syncRoles(ctx context.Context, user *models.User, identity *models.ExternalIdentity) error {
+	log := logger.WithContext(ctx)
+
+	if len(identity.Roles) == 0 || sameRoles(user.Roles, identity.Roles) {
+		return nil
+	}
+
+	before := map[string]interface{}{"roles": user.Roles}
+	user.Roles = identity.Roles
+	if err := u.userRepo.Update(ctx, user); err != nil {
+		log.WithError(err).Error("failed to update user roles", "userID", user.ID)
+		return err
+	}
+
+	err := u.auditService.RecordAction(ctx, user.TenantID, user.ID, models.AuditActionUpdate, models.AuditResourceUser, user.ID, before, map[string]interface{}{
+		"roles":  user.Roles,
+		"issuer": identity.Issuer,
+	})
+	if err != nil {
+		log.WithError(err).Error("failed to record role change in audit log")
+		// Do not return error, the roles have already been updated
+	}
+
+	return nil
+}
+
+// sameRoles reports whether two role lists hold the same roles in any order
+func sameRoles(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	sortedA := append([]string(nil), a...)
+	sortedB := append([]string(nil), b...)
+	sort.Strings(sortedA)
+	sort.Strings(sortedB)
+	for i := range sortedA {
+		if sortedA[i] != sortedB[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// validateInput validates that required input parameters are not empty
+func (u *ssoUseCase) validateInput(params map[string]string) error {
+	for name, value := range params {
+		if value == "" {
+			return errors.NewValidationError(fmt.Sprintf("%s cannot be empty", name))
+		}
+	}
+	return nil
+}
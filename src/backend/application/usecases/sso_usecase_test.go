@@ -0,0 +1,400 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert" // v1.8.0+
+	"github.com/stretchr/testify/mock"   // v1.8.0+
+	"github.com/stretchr/testify/suite"  // v1.8.0+
+
+	"../../domain/models"
+	"../../domain/repositories"
+	"../../domain/services"
+	pkgErrors "../../pkg/errors"
+)
+
+// MockIdentityProvider is a mock implementation of the IdentityProvider interface for testing
+type MockIdentityProvider struct {
+	mock.Mock
+}
+
+func (m *MockIdentityProvider) VerifyIDToken(ctx context.Context, tenantID, rawIDToken string) (*models.ExternalIdentity, error) {
+	args := m.Called(ctx, tenantID, rawIDToken)
+	if identity := args.Get(0); identity != nil {
+		return identity.(*models.ExternalIdentity), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockIdentityProvider) ProvisioningPolicy(tenantID string) (bool, string) {
+	args := m.Called(tenantID)
+	return args.Bool(0), args.String(1)
+}
+
+// mockSSOAuthService mocks the AuthService methods used by SSO
+type mockSSOAuthService struct {
+	services.AuthService
+	mock.Mock
+}
+
+func (m *mockSSOAuthService) CreateSession(ctx context.Context, userID, tenantID string, roles []string) (string, string, error) {
+	args := m.Called(ctx, userID, tenantID, roles)
+	return args.String(0), args.String(1), args.Error(2)
+}
+
+func (m *mockSSOAuthService) GenerateMFAToken(ctx context.Context, userID, tenantID string) (string, error) {
+	args := m.Called(ctx, userID, tenantID)
+	return args.String(0), args.Error(1)
+}
+
+// mockSSOUserRepository mocks the UserRepository methods used by SSO
+type mockSSOUserRepository struct {
+	repositories.UserRepository
+	mock.Mock
+}
+
+func (m *mockSSOUserRepository) GetByID(ctx context.Context, id string, tenantID string) (*models.User, error) {
+	args := m.Called(ctx, id, tenantID)
+	if user := args.Get(0); user != nil {
+		return user.(*models.User), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *mockSSOUserRepository) GetByEmail(ctx context.Context, email string, tenantID string) (*models.User, error) {
+	args := m.Called(ctx, email, tenantID)
+	if user := args.Get(0); user != nil {
+		return user.(*models.User), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *mockSSOUserRepository) ExistsByUsername(ctx context.Context, username string, tenantID string) (bool, error) {
+	args := m.Called(ctx, username, tenantID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockSSOUserRepository) Create(ctx context.Context, user *models.User) (string, error) {
+	args := m.Called(ctx, user)
+	user.ID = args.String(0)
+	return args.String(0), args.Error(1)
+}
+
+func (m *mockSSOUserRepository) Update(ctx context.Context, user *models.User) error {
+	args := m.Called(ctx, user)
+	return args.Error(0)
+}
+
+// mockSSOTenantRepository mocks the TenantRepository methods used by SSO
+type mockSSOTenantRepository struct {
+	repositories.TenantRepository
+	mock.Mock
+}
+
+func (m *mockSSOTenantRepository) GetByID(ctx context.Context, id string) (*models.Tenant, error) {
+	args := m.Called(ctx, id)
+	if tenant := args.Get(0); tenant != nil {
+		return tenant.(*models.Tenant), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// fakeIdentityLinkRepository keeps external identity links in memory and rejects duplicates like the unique indexes
+type fakeIdentityLinkRepository struct {
+	links []*models.ExternalIdentityLink
+}
+
+func (r *fakeIdentityLinkRepository) Create(ctx context.Context, link *models.ExternalIdentityLink) (string, error) {
+	for _, existing := range r.links {
+		sameSubject := existing.TenantID == link.TenantID && existing.Issuer == link.Issuer && existing.Subject == link.Subject
+		sameUser := existing.UserID == link.UserID && existing.Issuer == link.Issuer
+		if sameSubject || sameUser {
+			return "", pkgErrors.NewValidationError("the external identity or the user is already linked")
+		}
+	}
+	link.ID = "link-" + link.UserID
+	r.links = append(r.links, link)
+	return link.ID, nil
+}
+
+func (r *fakeIdentityLinkRepository) GetBySubject(ctx context.Context, tenantID string, issuer string, subject string) (*models.ExternalIdentityLink, error) {
+	for _, link := range r.links {
+		if link.TenantID == tenantID && link.Issuer == issuer && link.Subject == subject {
+			return link, nil
+		}
+	}
+	return nil, pkgErrors.NewResourceNotFoundError("external identity link not found")
+}
+
+func (r *fakeIdentityLinkRepository) GetByUserID(ctx context.Context, userID string, tenantID string, issuer string) (*models.ExternalIdentityLink, error) {
+	for _, link := range r.links {
+		if link.UserID == userID && link.TenantID == tenantID && link.Issuer == issuer {
+			return link, nil
+		}
+	}
+	return nil, pkgErrors.NewResourceNotFoundError("external identity link not found")
+}
+
+// fakeSSOMFARepository returns the enrollment it holds for any user, or none
+type fakeSSOMFARepository struct {
+	repositories.MFARepository
+	enrollment *models.MFAEnrollment
+}
+
+func (r *fakeSSOMFARepository) GetByUserID(ctx context.Context, userID string, tenantID string) (*models.MFAEnrollment, error) {
+	if r.enrollment == nil {
+		return nil, pkgErrors.NewResourceNotFoundError("MFA enrollment not found")
+	}
+	return r.enrollment, nil
+}
+
+// SSOUseCaseTestSuite defines a test suite for SSOUseCase
+type SSOUseCaseTestSuite struct {
+	suite.Suite
+	mockIdentityProvider *MockIdentityProvider
+	mockAuthService      *mockSSOAuthService
+	mockUserRepo         *mockSSOUserRepository
+	mockTenantRepo       *mockSSOTenantRepository
+	linkRepo             *fakeIdentityLinkRepository
+	mfaRepo              *fakeSSOMFARepository
+	mockAuditService     *MockAuditService
+	tenant               *models.Tenant
+	ssoUseCase           SSOUseCase
+}
+
+// SetupTest sets up the test environment before each test
+func (s *SSOUseCaseTestSuite) SetupTest() {
+	s.mockIdentityProvider = new(MockIdentityProvider)
+	s.mockAuthService = new(mockSSOAuthService)
+	s.mockUserRepo = new(mockSSOUserRepository)
+	s.mockTenantRepo = new(mockSSOTenantRepository)
+	s.linkRepo = &fakeIdentityLinkRepository{}
+	s.mfaRepo = &fakeSSOMFARepository{}
+	s.mockAuditService = new(MockAuditService)
+	s.mockAuditService.On("RecordAction", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	s.tenant = &models.Tenant{ID: "tenant123", Status: models.TenantStatusActive}
+	s.mockTenantRepo.On("GetByID", mock.Anything, "tenant123").Return(s.tenant, nil).Maybe()
+	s.mockAuthService.On("CreateSession", mock.Anything, mock.Anything, "tenant123", mock.Anything).Return("access-token", "refresh-token", nil).Maybe()
+
+	var err error
+	s.ssoUseCase, err = NewSSOUseCase(s.mockIdentityProvider, s.mockAuthService, s.mockUserRepo, s.mockTenantRepo, s.linkRepo, s.mfaRepo, s.mockAuditService)
+	assert.Nil(s.T(), err)
+}
+
+// createTestIdentity returns an identity asserted by the tenant's IdP with the given mapped roles
+func (s *SSOUseCaseTestSuite) createTestIdentity(roles ...string) *models.ExternalIdentity {
+	return &models.ExternalIdentity{
+		TenantID:      "tenant123",
+		Issuer:        "https://idp.example.com",
+		Subject:       "00u1abcd",
+		Email:         "jane.doe@example.com",
+		EmailVerified: true,
+		Username:      "jane.doe",
+		Groups:        []string{"dms-editors"},
+		Roles:         roles,
+	}
+}
+
+// TestLoginWithSSO_ExistingUser tests that existing users sign in with their platform roles when no groups are mapped
+func (s *SSOUseCaseTestSuite) TestLoginWithSSO_ExistingUser() {
+	ctx := context.Background()
+	user := &models.User{ID: "user123", TenantID: "tenant123", Email: "jane.doe@example.com", Status: models.UserStatusActive, Roles: []string{models.RoleAdministrator}}
+
+	s.mockIdentityProvider.On("VerifyIDToken", ctx, "tenant123", "id-token").Return(s.createTestIdentity(), nil)
+	s.mockUserRepo.On("GetByEmail", ctx, "jane.doe@example.com", "tenant123").Return(user, nil)
+
+	result, err := s.ssoUseCase.LoginWithSSO(ctx, "tenant123", "id-token")
+
+	s.NoError(err)
+	s.Equal("access-token", result.AccessToken)
+	s.Equal("refresh-token", result.RefreshToken)
+	s.False(result.MFARequired)
+	s.mockAuthService.AssertCalled(s.T(), "CreateSession", ctx, "user123", "tenant123", []string{models.RoleAdministrator})
+	s.mockUserRepo.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything)
+
+	// The first sign-in links the account to the IdP subject
+	s.Require().Len(s.linkRepo.links, 1)
+	s.Equal("user123", s.linkRepo.links[0].UserID)
+	s.Equal("00u1abcd", s.linkRepo.links[0].Subject)
+}
+
+// TestLoginWithSSO_SyncsMappedRoles tests that mapped IdP groups replace the user's roles
+func (s *SSOUseCaseTestSuite) TestLoginWithSSO_SyncsMappedRoles() {
+	ctx := context.Background()
+	user := &models.User{ID: "user123", TenantID: "tenant123", Email: "jane.doe@example.com", Status: models.UserStatusActive, Roles: []string{models.RoleReader}}
+
+	s.mockIdentityProvider.On("VerifyIDToken", ctx, "tenant123", "id-token").Return(s.createTestIdentity(models.RoleEditor), nil)
+	s.mockUserRepo.On("GetByEmail", ctx, "jane.doe@example.com", "tenant123").Return(user, nil)
+	s.mockUserRepo.On("Update", ctx, user).Return(nil)
+
+	_, err := s.ssoUseCase.LoginWithSSO(ctx, "tenant123", "id-token")
+
+	s.NoError(err)
+	s.Equal([]string{models.RoleEditor}, user.Roles)
+	s.mockAuthService.AssertCalled(s.T(), "CreateSession", ctx, "user123", "tenant123", []string{models.RoleEditor})
+	s.mockAuditService.AssertCalled(s.T(), "RecordAction", ctx, "tenant123", "user123", models.AuditActionUpdate, models.AuditResourceUser, "user123", mock.Anything, mock.Anything)
+}
+
+// TestLoginWithSSO_ProvisionsUser tests just-in-time provisioning with the tenant's default role
+func (s *SSOUseCaseTestSuite) TestLoginWithSSO_ProvisionsUser() {
+	ctx := context.Background()
+
+	s.mockIdentityProvider.On("VerifyIDToken", ctx, "tenant123", "id-token").Return(s.createTestIdentity(), nil)
+	s.mockIdentityProvider.On("ProvisioningPolicy", "tenant123").Return(true, models.RoleReader)
+	s.mockUserRepo.On("GetByEmail", ctx, "jane.doe@example.com", "tenant123").Return(nil, pkgErrors.NewResourceNotFoundError("user not found"))
+	s.mockUserRepo.On("ExistsByUsername", ctx, "jane.doe", "tenant123").Return(false, nil)
+	s.mockUserRepo.On("Create", ctx, mock.MatchedBy(func(user *models.User) bool {
+		return user.Username == "jane.doe" && user.PasswordHash == "" && len(user.Roles) == 1 && user.Roles[0] == models.RoleReader
+	})).Return("user456", nil)
+
+	result, err := s.ssoUseCase.LoginWithSSO(ctx, "tenant123", "id-token")
+
+	s.NoError(err)
+	s.Equal("access-token", result.AccessToken)
+	s.Require().Len(s.linkRepo.links, 1)
+	s.Equal("user456", s.linkRepo.links[0].UserID)
+	s.mockAuditService.AssertCalled(s.T(), "RecordAction", ctx, "tenant123", "user456", models.AuditActionCreate, models.AuditResourceUser, "user456", mock.Anything, mock.Anything)
+}
+
+// TestLoginWithSSO_ProvisioningDisabled tests that unknown users are rejected when provisioning is off
+func (s *SSOUseCaseTestSuite) TestLoginWithSSO_ProvisioningDisabled() {
+	ctx := context.Background()
+
+	s.mockIdentityProvider.On("VerifyIDToken", ctx, "tenant123", "id-token").Return(s.createTestIdentity(), nil)
+	s.mockIdentityProvider.On("ProvisioningPolicy", "tenant123").Return(false, "")
+	s.mockUserRepo.On("GetByEmail", ctx, "jane.doe@example.com", "tenant123").Return(nil, pkgErrors.NewResourceNotFoundError("user not found"))
+
+	_, err := s.ssoUseCase.LoginWithSSO(ctx, "tenant123", "id-token")
+
+	s.True(pkgErrors.IsAuthenticationError(err))
+	s.mockUserRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+}
+
+// TestLoginWithSSO_InvalidToken tests that tokens rejected by the IdP are not exchanged
+func (s *SSOUseCaseTestSuite) TestLoginWithSSO_InvalidToken() {
+	ctx := context.Background()
+
+	s.mockIdentityProvider.On("VerifyIDToken", ctx, "tenant123", "forged").Return(nil, pkgErrors.NewAuthenticationError("invalid ID token"))
+
+	result, err := s.ssoUseCase.LoginWithSSO(ctx, "tenant123", "forged")
+
+	s.Nil(result)
+	s.True(pkgErrors.IsAuthenticationError(err))
+	s.mockAuthService.AssertNotCalled(s.T(), "CreateSession", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestLoginWithSSO_InactiveUser tests that suspended users cannot sign in through SSO
+func (s *SSOUseCaseTestSuite) TestLoginWithSSO_InactiveUser() {
+	ctx := context.Background()
+	user := &models.User{ID: "user123", TenantID: "tenant123", Email: "jane.doe@example.com", Status: models.UserStatusSuspended}
+
+	s.mockIdentityProvider.On("VerifyIDToken", ctx, "tenant123", "id-token").Return(s.createTestIdentity(models.RoleEditor), nil)
+	s.mockUserRepo.On("GetByEmail", ctx, "jane.doe@example.com", "tenant123").Return(user, nil)
+
+	_, err := s.ssoUseCase.LoginWithSSO(ctx, "tenant123", "id-token")
+
+	s.True(pkgErrors.IsAuthenticationError(err))
+	s.mockUserRepo.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything)
+}
+
+// TestLoginWithSSO_LinkedSubject tests that a linked subject signs in to its account even after its email changed
+func (s *SSOUseCaseTestSuite) TestLoginWithSSO_LinkedSubject() {
+	ctx := context.Background()
+	user := &models.User{ID: "user123", TenantID: "tenant123", Email: "jane.smith@example.com", Status: models.UserStatusActive, Roles: []string{models.RoleReader}}
+	s.linkRepo.links = []*models.ExternalIdentityLink{{TenantID: "tenant123", UserID: "user123", Issuer: "https://idp.example.com", Subject: "00u1abcd"}}
+
+	identity := s.createTestIdentity()
+	identity.EmailVerified = false
+	s.mockIdentityProvider.On("VerifyIDToken", ctx, "tenant123", "id-token").Return(identity, nil)
+	s.mockUserRepo.On("GetByID", ctx, "user123", "tenant123").Return(user, nil)
+
+	result, err := s.ssoUseCase.LoginWithSSO(ctx, "tenant123", "id-token")
+
+	s.NoError(err)
+	s.Equal("access-token", result.AccessToken)
+	s.mockUserRepo.AssertNotCalled(s.T(), "GetByEmail", mock.Anything, mock.Anything, mock.Anything)
+	s.Len(s.linkRepo.links, 1)
+}
+
+// TestLoginWithSSO_UnverifiedEmail tests that an account is not linked through an email the IdP has not verified
+func (s *SSOUseCaseTestSuite) TestLoginWithSSO_UnverifiedEmail() {
+	ctx := context.Background()
+	user := &models.User{ID: "user123", TenantID: "tenant123", Email: "jane.doe@example.com", Status: models.UserStatusActive, Roles: []string{models.RoleAdministrator}}
+
+	identity := s.createTestIdentity()
+	identity.EmailVerified = false
+	s.mockIdentityProvider.On("VerifyIDToken", ctx, "tenant123", "id-token").Return(identity, nil)
+	s.mockUserRepo.On("GetByEmail", ctx, "jane.doe@example.com", "tenant123").Return(user, nil)
+
+	result, err := s.ssoUseCase.LoginWithSSO(ctx, "tenant123", "id-token")
+
+	s.Nil(result)
+	s.True(pkgErrors.IsAuthenticationError(err))
+	s.Empty(s.linkRepo.links)
+	s.mockAuthService.AssertNotCalled(s.T(), "CreateSession", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestLoginWithSSO_AccountLinkedToAnotherSubject tests that another subject with the same email cannot take over a linked account
+func (s *SSOUseCaseTestSuite) TestLoginWithSSO_AccountLinkedToAnotherSubject() {
+	ctx := context.Background()
+	user := &models.User{ID: "user123", TenantID: "tenant123", Email: "jane.doe@example.com", Status: models.UserStatusActive}
+	s.linkRepo.links = []*models.ExternalIdentityLink{{TenantID: "tenant123", UserID: "user123", Issuer: "https://idp.example.com", Subject: "00u9wxyz"}}
+
+	s.mockIdentityProvider.On("VerifyIDToken", ctx, "tenant123", "id-token").Return(s.createTestIdentity(), nil)
+	s.mockUserRepo.On("GetByEmail", ctx, "jane.doe@example.com", "tenant123").Return(user, nil)
+
+	result, err := s.ssoUseCase.LoginWithSSO(ctx, "tenant123", "id-token")
+
+	s.Nil(result)
+	s.True(pkgErrors.IsAuthenticationError(err))
+	s.Len(s.linkRepo.links, 1)
+	s.mockAuthService.AssertNotCalled(s.T(), "CreateSession", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestLoginWithSSO_MFAEnabled tests that users with an enabled second factor only get an MFA token
+func (s *SSOUseCaseTestSuite) TestLoginWithSSO_MFAEnabled() {
+	ctx := context.Background()
+	user := &models.User{ID: "user123", TenantID: "tenant123", Email: "jane.doe@example.com", Status: models.UserStatusActive}
+	enabledAt := time.Now()
+	s.mfaRepo.enrollment = &models.MFAEnrollment{UserID: "user123", TenantID: "tenant123", EnabledAt: &enabledAt}
+
+	s.mockIdentityProvider.On("VerifyIDToken", ctx, "tenant123", "id-token").Return(s.createTestIdentity(), nil)
+	s.mockUserRepo.On("GetByEmail", ctx, "jane.doe@example.com", "tenant123").Return(user, nil)
+	s.mockAuthService.On("GenerateMFAToken", ctx, "user123", "tenant123").Return("mfa-token", nil)
+
+	result, err := s.ssoUseCase.LoginWithSSO(ctx, "tenant123", "id-token")
+
+	s.NoError(err)
+	s.True(result.MFARequired)
+	s.False(result.MFAEnrollmentRequired)
+	s.Equal("mfa-token", result.MFAToken)
+	s.Empty(result.AccessToken)
+	s.mockAuthService.AssertNotCalled(s.T(), "CreateSession", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestLoginWithSSO_TenantRequiresMFA tests that users of tenants requiring a second factor have to enroll one first
+func (s *SSOUseCaseTestSuite) TestLoginWithSSO_TenantRequiresMFA() {
+	ctx := context.Background()
+	user := &models.User{ID: "user123", TenantID: "tenant123", Email: "jane.doe@example.com", Status: models.UserStatusActive}
+	s.tenant.SetSetting(models.TenantSettingMFARequired, "true")
+
+	s.mockIdentityProvider.On("VerifyIDToken", ctx, "tenant123", "id-token").Return(s.createTestIdentity(), nil)
+	s.mockUserRepo.On("GetByEmail", ctx, "jane.doe@example.com", "tenant123").Return(user, nil)
+	s.mockAuthService.On("GenerateMFAToken", ctx, "user123", "tenant123").Return("mfa-token", nil)
+
+	result, err := s.ssoUseCase.LoginWithSSO(ctx, "tenant123", "id-token")
+
+	s.NoError(err)
+	s.True(result.MFARequired)
+	s.True(result.MFAEnrollmentRequired)
+	s.Equal("mfa-token", result.MFAToken)
+	s.mockAuthService.AssertNotCalled(s.T(), "CreateSession", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestSSOUseCaseSuite runs the SSO use case test suite
+func TestSSOUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(SSOUseCaseTestSuite))
+}
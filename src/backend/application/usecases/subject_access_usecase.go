@@ -0,0 +1,159 @@
+// Package usecases implements the application layer of the Document Management Platform.
+// It contains use case implementations that orchestrate domain models and services.
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"../../domain/models"
+	"../../domain/repositories"
+	"../../domain/services"
+	"../../pkg/errors"
+	"../../pkg/logger"
+)
+
+// ErrSubjectAccessExportNotFound is returned for unknown subject access exports
+var ErrSubjectAccessExportNotFound = errors.NewResourceNotFoundError("subject access export not found")
+
+// ErrSubjectNotFound is returned when the data subject of a subject access request is not a user of the tenant
+var ErrSubjectNotFound = errors.NewResourceNotFoundError("user not found")
+
+// SubjectAccessUseCase defines the contract for GDPR subject access request exports. Administrators
+// request them on behalf of a user of their tenant.
+type SubjectAccessUseCase interface {
+	// RequestExport schedules an export of the personal data held about the subject, which the
+	// worker compiles into a ZIP archive
+	RequestExport(ctx context.Context, subjectID, tenantID, userID string) (*models.SubjectAccessExport, error)
+
+	// GetExport retrieves a subject access export of the tenant. Once the export has completed, it
+	// also returns a presigned URL to download the archive.
+	GetExport(ctx context.Context, id, tenantID, userID string) (*models.SubjectAccessExport, string, error)
+}
+
+// subjectAccessUseCase implements the SubjectAccessUseCase interface
+type subjectAccessUseCase struct {
+	exportRepo     repositories.SubjectAccessExportRepository
+	userRepo       repositories.UserRepository
+	storageService services.StorageService
+	auditService   services.AuditService
+}
+
+// NewSubjectAccessUseCase creates a new SubjectAccessUseCase instance
+func NewSubjectAccessUseCase(
+	exportRepo repositories.SubjectAccessExportRepository,
+	userRepo repositories.UserRepository,
+	storageService services.StorageService,
+	auditService services.AuditService,
+) (SubjectAccessUseCase, error) {
+	if exportRepo == nil {
+		return nil, fmt.Errorf("subject access export repository cannot be nil")
+	}
+	if userRepo == nil {
+		return nil, fmt.Errorf("user repository cannot be nil")
+	}
+	if storageService == nil {
+		return nil, fmt.Errorf("storage service cannot be nil")
+	}
+	if auditService == nil {
+		return nil, fmt.Errorf("audit service cannot be nil")
+	}
+
+	return &subjectAccessUseCase{
+		exportRepo:     exportRepo,
+		userRepo:       userRepo,
+		storageService: storageService,
+		auditService:   auditService,
+	}, nil
+}
+
+// RequestExport schedules an export of the personal data of a user of the tenant
+func (u *subjectAccessUseCase) RequestExport(ctx context.Context, subjectID, tenantID, userID string) (*models.SubjectAccessExport, error) {
+	log := logger.WithContext(ctx)
+
+	if err := u.validateInput(map[string]string{
+		"subject ID": subjectID,
+		"tenant ID":  tenantID,
+		"user ID":    userID,
+	}); err != nil {
+		return nil, err
+	}
+
+	if _, err := u.userRepo.GetByID(ctx, subjectID, tenantID); err != nil {
+		if errors.IsResourceNotFoundError(err) {
+			return nil, ErrSubjectNotFound
+		}
+		log.WithError(err).Error("failed to get data subject", "subjectID", subjectID, "tenantID", tenantID)
+		return nil, errors.Wrap(err, "failed to get data subject")
+	}
+
+	export := models.NewSubjectAccessExport(tenantID, subjectID, userID)
+	if _, err := u.exportRepo.Create(ctx, export); err != nil {
+		log.WithError(err).Error("failed to create subject access export", "subjectID", subjectID, "tenantID", tenantID)
+		return nil, errors.Wrap(err, "failed to create subject access export")
+	}
+
+	err := u.auditService.RecordAction(ctx, tenantID, userID, models.AuditActionCreate, models.AuditResourceSubjectAccessExport, export.ID, nil, map[string]interface{}{
+		"subject_id": subjectID,
+	})
+	if err != nil {
+		log.WithError(err).Error("failed to record subject access export creation in audit log")
+		// Do not return error, the export has already been scheduled
+	}
+
+	log.Info("subject access export scheduled", "exportID", export.ID, "subjectID", subjectID, "tenantID", tenantID)
+	return export, nil
+}
+
+// GetExport retrieves a subject access export of the tenant, recording each download URL handed
+// out in the audit log
+func (u *subjectAccessUseCase) GetExport(ctx context.Context, id, tenantID, userID string) (*models.SubjectAccessExport, string, error) {
+	log := logger.WithContext(ctx)
+
+	if err := u.validateInput(map[string]string{
+		"export ID": id,
+		"tenant ID": tenantID,
+		"user ID":   userID,
+	}); err != nil {
+		return nil, "", err
+	}
+
+	export, err := u.exportRepo.GetByID(ctx, id, tenantID)
+	if err != nil {
+		if errors.IsResourceNotFoundError(err) {
+			return nil, "", ErrSubjectAccessExportNotFound
+		}
+		log.WithError(err).Error("failed to get subject access export", "exportID", id, "tenantID", tenantID)
+		return nil, "", errors.Wrap(err, "failed to get subject access export")
+	}
+
+	if export.Status != models.SubjectAccessExportStatusCompleted {
+		return export, "", nil
+	}
+
+	downloadURL, err := u.storageService.GetPresignedURL(ctx, export.ArchivePath, export.ArchiveFileName(), services.ExportDownloadURLExpirySeconds)
+	if err != nil {
+		log.WithError(err).Error("failed to generate subject access download URL", "exportID", id)
+		return nil, "", errors.Wrap(err, "failed to generate subject access download URL")
+	}
+
+	err = u.auditService.RecordAction(ctx, tenantID, userID, models.AuditActionDownload, models.AuditResourceSubjectAccessExport, export.ID, nil, map[string]interface{}{
+		"subject_id": export.SubjectID,
+	})
+	if err != nil {
+		log.WithError(err).Error("failed to record subject access download in audit log")
+		// Do not return error, the download URL has already been generated
+	}
+
+	return export, downloadURL, nil
+}
+
+// validateInput validates that required input parameters are not empty
+func (u *subjectAccessUseCase) validateInput(params map[string]string) error {
+	for name, value := range params {
+		if value == "" {
+			return errors.NewValidationError(fmt.Sprintf("%s cannot be empty", name))
+		}
+	}
+	return nil
+}
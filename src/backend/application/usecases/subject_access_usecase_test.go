@@ -0,0 +1,170 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert" // v1.8.0+
+	"github.com/stretchr/testify/mock"   // v1.8.0+
+	"github.com/stretchr/testify/suite"  // v1.8.0+
+
+	"../../domain/models"
+	"../../domain/repositories"
+	"../../domain/services"
+	pkgErrors "../../pkg/errors"
+)
+
+// mockSubjectAccessExportRepository is a mock implementation of the SubjectAccessExportRepository interface for testing
+type mockSubjectAccessExportRepository struct {
+	mock.Mock
+}
+
+func (m *mockSubjectAccessExportRepository) Create(ctx context.Context, export *models.SubjectAccessExport) (string, error) {
+	args := m.Called(ctx, export)
+	return args.String(0), args.Error(1)
+}
+
+func (m *mockSubjectAccessExportRepository) GetByID(ctx context.Context, id string, tenantID string) (*models.SubjectAccessExport, error) {
+	args := m.Called(ctx, id, tenantID)
+	if export := args.Get(0); export != nil {
+		return export.(*models.SubjectAccessExport), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *mockSubjectAccessExportRepository) ClaimNext(ctx context.Context, staleBefore time.Time) (*models.SubjectAccessExport, error) {
+	args := m.Called(ctx, staleBefore)
+	if export := args.Get(0); export != nil {
+		return export.(*models.SubjectAccessExport), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *mockSubjectAccessExportRepository) Update(ctx context.Context, export *models.SubjectAccessExport) error {
+	args := m.Called(ctx, export)
+	return args.Error(0)
+}
+
+// mockSubjectAccessUserRepository mocks the UserRepository methods used by subject access exports
+type mockSubjectAccessUserRepository struct {
+	repositories.UserRepository
+	mock.Mock
+}
+
+func (m *mockSubjectAccessUserRepository) GetByID(ctx context.Context, id string, tenantID string) (*models.User, error) {
+	args := m.Called(ctx, id, tenantID)
+	if user := args.Get(0); user != nil {
+		return user.(*models.User), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// SubjectAccessUseCaseTestSuite defines a test suite for SubjectAccessUseCase
+type SubjectAccessUseCaseTestSuite struct {
+	suite.Suite
+	mockExportRepo       *mockSubjectAccessExportRepository
+	mockUserRepo         *mockSubjectAccessUserRepository
+	mockStorageService   *mockExportStorageService
+	mockAuditService     *MockAuditService
+	subjectAccessUseCase SubjectAccessUseCase
+}
+
+// SetupTest sets up the test environment before each test
+func (s *SubjectAccessUseCaseTestSuite) SetupTest() {
+	s.mockExportRepo = new(mockSubjectAccessExportRepository)
+	s.mockUserRepo = new(mockSubjectAccessUserRepository)
+	s.mockStorageService = new(mockExportStorageService)
+	s.mockAuditService = new(MockAuditService)
+	s.mockAuditService.On("RecordAction", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	var err error
+	s.subjectAccessUseCase, err = NewSubjectAccessUseCase(s.mockExportRepo, s.mockUserRepo, s.mockStorageService, s.mockAuditService)
+	assert.Nil(s.T(), err)
+}
+
+// createTestExport returns an export of user456's data requested by admin123 with the given status
+func (s *SubjectAccessUseCaseTestSuite) createTestExport(status string) *models.SubjectAccessExport {
+	export := models.NewSubjectAccessExport("tenant123", "user456", "admin123")
+	export.ID = "sar123"
+	export.Status = status
+	return export
+}
+
+// TestRequestExport_CreatesPendingExport tests that requesting an export of a user schedules a pending export
+func (s *SubjectAccessUseCaseTestSuite) TestRequestExport_CreatesPendingExport() {
+	ctx := context.Background()
+	s.mockUserRepo.On("GetByID", ctx, "user456", "tenant123").Return(&models.User{ID: "user456", TenantID: "tenant123"}, nil)
+	s.mockExportRepo.On("Create", ctx, mock.MatchedBy(func(export *models.SubjectAccessExport) bool {
+		return export.SubjectID == "user456" && export.RequestedBy == "admin123" && export.Status == models.SubjectAccessExportStatusPending
+	})).Run(func(args mock.Arguments) {
+		args.Get(1).(*models.SubjectAccessExport).ID = "sar123"
+	}).Return("sar123", nil)
+
+	export, err := s.subjectAccessUseCase.RequestExport(ctx, "user456", "tenant123", "admin123")
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "sar123", export.ID)
+	s.mockExportRepo.AssertExpectations(s.T())
+	s.mockAuditService.AssertCalled(s.T(), "RecordAction", ctx, "tenant123", "admin123", models.AuditActionCreate,
+		models.AuditResourceSubjectAccessExport, "sar123", mock.Anything, mock.Anything)
+}
+
+// TestRequestExport_UnknownSubject tests that no export is scheduled for users outside the tenant
+func (s *SubjectAccessUseCaseTestSuite) TestRequestExport_UnknownSubject() {
+	ctx := context.Background()
+	s.mockUserRepo.On("GetByID", ctx, "user456", "tenant123").Return(nil, pkgErrors.NewResourceNotFoundError("user not found"))
+
+	export, err := s.subjectAccessUseCase.RequestExport(ctx, "user456", "tenant123", "admin123")
+
+	assert.Nil(s.T(), export)
+	assert.True(s.T(), pkgErrors.IsResourceNotFoundError(err))
+	s.mockExportRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+}
+
+// TestGetExport_CompletedReturnsDownloadURL tests that a completed export comes with a presigned URL
+// and that handing it out is audited
+func (s *SubjectAccessUseCaseTestSuite) TestGetExport_CompletedReturnsDownloadURL() {
+	ctx := context.Background()
+	export := s.createTestExport(models.SubjectAccessExportStatusCompleted)
+	export.ArchivePath = "temp/exports/tenant123/sar123.zip"
+	s.mockExportRepo.On("GetByID", ctx, "sar123", "tenant123").Return(export, nil)
+	s.mockStorageService.On("GetPresignedURL", ctx, export.ArchivePath, "subject-access-sar123.zip", services.ExportDownloadURLExpirySeconds).Return("https://s3/sar123.zip", nil)
+
+	result, downloadURL, err := s.subjectAccessUseCase.GetExport(ctx, "sar123", "tenant123", "admin789")
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), export, result)
+	assert.Equal(s.T(), "https://s3/sar123.zip", downloadURL)
+	s.mockAuditService.AssertCalled(s.T(), "RecordAction", ctx, "tenant123", "admin789", models.AuditActionDownload,
+		models.AuditResourceSubjectAccessExport, "sar123", mock.Anything, mock.Anything)
+}
+
+// TestGetExport_RunningHasNoDownloadURL tests that no URL is issued before the archive is complete
+func (s *SubjectAccessUseCaseTestSuite) TestGetExport_RunningHasNoDownloadURL() {
+	ctx := context.Background()
+	s.mockExportRepo.On("GetByID", ctx, "sar123", "tenant123").Return(s.createTestExport(models.SubjectAccessExportStatusRunning), nil)
+
+	result, downloadURL, err := s.subjectAccessUseCase.GetExport(ctx, "sar123", "tenant123", "admin123")
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), models.SubjectAccessExportStatusRunning, result.Status)
+	assert.Empty(s.T(), downloadURL)
+	s.mockStorageService.AssertNotCalled(s.T(), "GetPresignedURL", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestGetExport_NotFound tests that unknown exports are reported as not found
+func (s *SubjectAccessUseCaseTestSuite) TestGetExport_NotFound() {
+	ctx := context.Background()
+	s.mockExportRepo.On("GetByID", ctx, "sar123", "tenant123").Return(nil, pkgErrors.NewResourceNotFoundError("subject access export not found"))
+
+	result, _, err := s.subjectAccessUseCase.GetExport(ctx, "sar123", "tenant123", "admin123")
+
+	assert.Nil(s.T(), result)
+	assert.True(s.T(), pkgErrors.IsResourceNotFoundError(err))
+}
+
+// TestSubjectAccessUseCaseSuite runs the SubjectAccessUseCase test suite
+func TestSubjectAccessUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(SubjectAccessUseCaseTestSuite))
+}
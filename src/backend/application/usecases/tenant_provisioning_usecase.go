@@ -0,0 +1,400 @@
+// Package usecases implements the application layer of the Document Management Platform.
+// It contains use case implementations that orchestrate domain models and services.
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid" // v1.3.0+ - For the IDs of provisioned tenants, roles and events
+
+	"../../domain/models"
+	"../../domain/repositories"
+	"../../domain/services"
+	"../../pkg/errors"
+	"../../pkg/logger"
+)
+
+// TenantProvisioningRequest describes a tenant to provision
+type TenantProvisioningRequest struct {
+	Name             string // Name of the tenant
+	AdminUsername    string // Username of the initial administrator
+	AdminEmail       string // Email address of the initial administrator
+	FolderTemplateID string // Folder template of the template tenant the root folders are created from; none when empty
+	RequestedBy      string // Platform operator provisioning the tenant, recorded in the audit log
+}
+
+// TenantProvisioning reports what provisioning a tenant created
+type TenantProvisioning struct {
+	Tenant        *models.Tenant
+	Roles         []*models.Role
+	Admin         *models.User
+	AdminPassword string // Temporary password of the administrator, empty when it was emailed
+	Folders       []*models.Folder
+	StoragePrefix string                        // Prefix the documents of the tenant are stored under
+	Search        *services.SearchTenantRouting // Where the documents of the tenant are indexed, nil when the search backend does not keep tenants in indices
+}
+
+// TenantProvisioningUseCase defines the contract for provisioning tenants. It is used by platform
+// operators to onboard customers in one call instead of setting each system up by hand.
+type TenantProvisioningUseCase interface {
+	// ProvisionTenant creates an active tenant with the default roles, an administrator with a
+	// temporary password that has to be changed at the first sign-in, and the root folders of a
+	// folder template, in one transaction, and enqueues a tenant.created event with them. The index
+	// of the tenant is created in the search backend first.
+	ProvisionTenant(ctx context.Context, request TenantProvisioningRequest) (*TenantProvisioning, error)
+}
+
+// tenantProvisioningUseCase implements the TenantProvisioningUseCase interface
+type tenantProvisioningUseCase struct {
+	tenantRepo           repositories.TenantRepository
+	userRepo             repositories.UserRepository
+	roleRepo             repositories.RoleRepository
+	templateRepo         repositories.FolderTemplateRepository
+	folderRepo           repositories.FolderRepository
+	permissionRepo       repositories.PermissionRepository
+	metadataTemplateRepo repositories.MetadataTemplateRepository
+	outboxRepo           repositories.OutboxRepository
+	auditService         services.AuditService
+	txManager            repositories.TransactionManager
+	searchPreparer       services.SearchTenantPreparer
+	emailSender          services.EmailSender
+	signInURL            string
+	templateTenantID     string
+}
+
+// NewTenantProvisioningUseCase creates a new TenantProvisioningUseCase instance. The search preparer
+// is optional: without it, tenants get their index on their first upload. The email sender is
+// optional: without it, the temporary password of the administrator is returned to the operator
+// instead of being emailed. Tenants can only be provisioned with folders from a template when the
+// template tenant, the tenant holding the folder templates, is set.
+func NewTenantProvisioningUseCase(
+	tenantRepo repositories.TenantRepository,
+	userRepo repositories.UserRepository,
+	roleRepo repositories.RoleRepository,
+	templateRepo repositories.FolderTemplateRepository,
+	folderRepo repositories.FolderRepository,
+	permissionRepo repositories.PermissionRepository,
+	metadataTemplateRepo repositories.MetadataTemplateRepository,
+	outboxRepo repositories.OutboxRepository,
+	auditService services.AuditService,
+	txManager repositories.TransactionManager,
+	searchPreparer services.SearchTenantPreparer,
+	emailSender services.EmailSender,
+	signInURL string,
+	templateTenantID string,
+) (TenantProvisioningUseCase, error) {
+	if tenantRepo == nil {
+		return nil, fmt.Errorf("tenant repository cannot be nil")
+	}
+	if userRepo == nil {
+		return nil, fmt.Errorf("user repository cannot be nil")
+	}
+	if roleRepo == nil {
+		return nil, fmt.Errorf("role repository cannot be nil")
+	}
+	if templateRepo == nil {
+		return nil, fmt.Errorf("folder template repository cannot be nil")
+	}
+	if folderRepo == nil {
+		return nil, fmt.Errorf("folder repository cannot be nil")
+	}
+	if permissionRepo == nil {
+		return nil, fmt.Errorf("permission repository cannot be nil")
+	}
+	if metadataTemplateRepo == nil {
+		return nil, fmt.Errorf("metadata template repository cannot be nil")
+	}
+	if outboxRepo == nil {
+		return nil, fmt.Errorf("outbox repository cannot be nil")
+	}
+	if auditService == nil {
+		return nil, fmt.Errorf("audit service cannot be nil")
+	}
+	if txManager == nil {
+		return nil, fmt.Errorf("transaction manager cannot be nil")
+	}
+
+	return &tenantProvisioningUseCase{
+		tenantRepo:           tenantRepo,
+		userRepo:             userRepo,
+		roleRepo:             roleRepo,
+		templateRepo:         templateRepo,
+		folderRepo:           folderRepo,
+		permissionRepo:       permissionRepo,
+		metadataTemplateRepo: metadataTemplateRepo,
+		outboxRepo:           outboxRepo,
+		auditService:         auditService,
+		txManager:            txManager,
+		searchPreparer:       searchPreparer,
+		emailSender:          emailSender,
+		signInURL:            signInURL,
+		templateTenantID:     templateTenantID,
+	}, nil
+}
+
+// ProvisionTenant creates a tenant with its roles, administrator and folders in one transaction
+func (u *tenantProvisioningUseCase) ProvisionTenant(ctx context.Context, request TenantProvisioningRequest) (*TenantProvisioning, error) {
+	log := logger.WithContext(ctx)
+
+	request.Name = strings.TrimSpace(request.Name)
+	for field, value := range map[string]string{
+		"tenant name":    request.Name,
+		"admin username": strings.TrimSpace(request.AdminUsername),
+		"admin email":    strings.TrimSpace(request.AdminEmail),
+		"requester":      strings.TrimSpace(request.RequestedBy),
+	} {
+		if value == "" {
+			return nil, errors.NewValidationError(field + " is required")
+		}
+	}
+
+	exists, err := u.tenantRepo.ExistsByName(ctx, request.Name)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to check tenant name availability")
+	}
+	if exists {
+		return nil, errors.NewValidationError("tenant name already exists")
+	}
+
+	tenant := models.NewTenant(request.Name)
+	tenant.ID = uuid.New().String()
+
+	// Roles get their IDs up front so the permissions of the template can refer to them
+	roles := models.NewDefaultRoles(tenant.ID)
+	roleIDs := make(map[string]string, len(roles))
+	for _, role := range roles {
+		role.ID = uuid.New().String()
+		roleIDs[role.Name] = role.ID
+	}
+
+	admin := models.NewUser(request.AdminUsername, request.AdminEmail, tenant.ID)
+	admin.ID = uuid.New().String()
+	admin.Roles = append(admin.Roles, models.RoleAdministrator)
+	if err := admin.Validate(); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+	password, err := setTemporaryPassword(admin, tenant.PasswordPolicy())
+	if err != nil {
+		return nil, err
+	}
+
+	var folders []models.FolderTemplateFolder
+	var templateID string
+	if request.FolderTemplateID != "" {
+		if u.templateTenantID == "" {
+			return nil, errors.NewValidationError("tenants cannot be provisioned from folder templates: no template tenant is configured")
+		}
+		template, err := u.templateRepo.GetByID(ctx, request.FolderTemplateID, u.templateTenantID)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get folder template")
+		}
+		convert := &provisioningTemplate{
+			roleRepo:         u.roleRepo,
+			templateTenantID: u.templateTenantID,
+			roleIDs:          roleIDs,
+			roleNames:        make(map[string]string),
+		}
+		if folders, err = convert.folders(ctx, template); err != nil {
+			return nil, err
+		}
+		templateID = template.ID
+	}
+
+	// The search backend is not transactional: the index is created first, so that a tenant is
+	// never created without it, and removed again if the tenant cannot be created
+	var search *services.SearchTenantRouting
+	if u.searchPreparer != nil {
+		if search, err = u.searchPreparer.PrepareTenant(ctx, tenant.ID); err != nil {
+			log.WithError(err).Error("failed to prepare search index", "tenantID", tenant.ID)
+			return nil, errors.Wrap(err, "failed to prepare search index")
+		}
+	}
+
+	apply := &templateApplication{
+		folderRepo:           u.folderRepo,
+		metadataTemplateRepo: u.metadataTemplateRepo,
+		outboxRepo:           u.outboxRepo,
+		tenantID:             tenant.ID,
+		userID:               admin.ID,
+	}
+	err = u.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if _, err := u.tenantRepo.Create(txCtx, tenant); err != nil {
+			return errors.Wrap(err, "failed to create tenant")
+		}
+		for _, role := range roles {
+			if _, err := u.roleRepo.Create(txCtx, role); err != nil {
+				return errors.Wrap(err, "failed to create role")
+			}
+		}
+		if _, err := u.userRepo.Create(txCtx, admin); err != nil {
+			return errors.Wrap(err, "failed to create administrator")
+		}
+
+		if err := apply.createFolders(txCtx, nil, folders); err != nil {
+			return err
+		}
+
+		var searchIndex, searchRouting string
+		if search != nil {
+			searchIndex, searchRouting = search.Index, search.Routing
+		}
+		event, err := models.NewTenantCreatedEvent(tenant, admin.ID, tenant.StoragePrefix(), searchIndex, searchRouting)
+		if err != nil {
+			return errors.Wrap(err, "failed to create tenant created event")
+		}
+		event.ID = uuid.New().String()
+		message, err := models.NewOutboxMessage(event)
+		if err != nil {
+			return errors.Wrap(err, "invalid outbox message")
+		}
+		if _, err := u.outboxRepo.Create(txCtx, message); err != nil {
+			return err
+		}
+
+		if err := u.auditService.RecordAction(txCtx, tenant.ID, "", models.AuditActionCreate, models.AuditResourceTenant, tenant.ID, nil, map[string]interface{}{
+			"name":             tenant.Name,
+			"adminID":          admin.ID,
+			"folderTemplateID": templateID,
+			"folders":          len(apply.folders),
+			"requestedBy":      request.RequestedBy,
+		}); err != nil {
+			return err
+		}
+
+		// Permissions are written last so a failure rolls the tenant back with them
+		if _, err := u.permissionRepo.CreateBulk(txCtx, apply.permissions); err != nil {
+			return errors.Wrap(err, "failed to create folder permissions")
+		}
+		return nil
+	})
+	if err != nil {
+		log.WithError(err).Error("failed to provision tenant", "tenantID", tenant.ID, "name", tenant.Name)
+		u.removeSearchIndex(ctx, tenant.ID)
+		return nil, errors.Wrap(err, "failed to provision tenant")
+	}
+
+	// Let each folder's permissions reach its subfolders, parents first
+	for _, folder := range apply.folders {
+		if err := u.permissionRepo.PropagatePermissions(ctx, folder.ID, tenant.ID); err != nil {
+			log.WithError(err).Error("failed to propagate permissions", "folderID", folder.ID)
+			// We don't return error here as the tenant was already created
+		}
+	}
+
+	// Email the temporary password when invitations are emailed. The tenant exists by now, so the
+	// password is returned to the operator instead if it cannot be sent.
+	if u.emailSender != nil {
+		subject := "Your " + tenant.Name + " account is ready"
+		body := fmt.Sprintf("An administrator account has been created for you.\n\nSign in at %s with the username %s and this temporary password, which you will be asked to change:\n\n%s\n",
+			u.signInURL, admin.Username, password)
+		if err := u.emailSender.SendEmail(ctx, admin.Email, subject, body); err != nil {
+			log.WithError(err).Error("failed to email administrator, returning the temporary password", "userID", admin.ID)
+		} else {
+			password = ""
+		}
+	}
+
+	log.Info("tenant provisioned successfully", "tenantID", tenant.ID, "adminID", admin.ID, "folders", len(apply.folders), "requestedBy", request.RequestedBy)
+	return &TenantProvisioning{
+		Tenant:        tenant,
+		Roles:         roles,
+		Admin:         admin,
+		AdminPassword: password,
+		Folders:       apply.folders,
+		StoragePrefix: tenant.StoragePrefix(),
+		Search:        search,
+	}, nil
+}
+
+// removeSearchIndex removes the index prepared for a tenant that could not be created, if the search
+// backend can remove tenants; a leftover index is empty and only takes up space
+func (u *tenantProvisioningUseCase) removeSearchIndex(ctx context.Context, tenantID string) {
+	remover, ok := u.searchPreparer.(services.SearchTenantRemover)
+	if !ok {
+		return
+	}
+	if err := remover.RemoveTenant(ctx, tenantID); err != nil {
+		logger.WithContext(ctx).WithError(err).Error("failed to remove search index of tenant not provisioned", "tenantID", tenantID)
+	}
+}
+
+// provisioningTemplate converts a folder template of the template tenant into the root folders of a
+// new tenant
+type provisioningTemplate struct {
+	roleRepo         repositories.RoleRepository
+	templateTenantID string
+	roleIDs          map[string]string // IDs of the roles of the new tenant by name
+	roleNames        map[string]string // Names of the roles of the template tenant by ID
+}
+
+// folders returns the folders of the template with their permissions granted in the new tenant. The
+// permissions of the template itself are granted on each root folder, as they would have been on the
+// folder the template is applied as.
+func (p *provisioningTemplate) folders(ctx context.Context, template *models.FolderTemplate) ([]models.FolderTemplateFolder, error) {
+	inherited, err := p.grants(ctx, template.Permissions)
+	if err != nil {
+		return nil, err
+	}
+	folders, err := p.convert(ctx, template.Folders)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range folders {
+		folders[i].Permissions = append(append([]models.FolderTemplateGrant{}, inherited...), folders[i].Permissions...)
+	}
+	return folders, nil
+}
+
+// convert copies folders and their subfolders with their permissions granted in the new tenant
+func (p *provisioningTemplate) convert(ctx context.Context, folders []models.FolderTemplateFolder) ([]models.FolderTemplateFolder, error) {
+	converted := make([]models.FolderTemplateFolder, len(folders))
+	for i, folder := range folders {
+		grants, err := p.grants(ctx, folder.Permissions)
+		if err != nil {
+			return nil, err
+		}
+		subfolders, err := p.convert(ctx, folder.Folders)
+		if err != nil {
+			return nil, err
+		}
+		folder.Permissions = grants
+		folder.Folders = subfolders
+		converted[i] = folder
+	}
+	return converted, nil
+}
+
+// grants moves role grants to the role of the same name in the new tenant. User and group grants
+// are left out, since the users and groups of the template tenant do not exist in the new tenant.
+func (p *provisioningTemplate) grants(ctx context.Context, grants []models.FolderTemplateGrant) ([]models.FolderTemplateGrant, error) {
+	converted := make([]models.FolderTemplateGrant, 0, len(grants))
+	for _, grant := range grants {
+		if grant.GranteeType != models.GranteeTypeRole {
+			continue
+		}
+
+		name, ok := p.roleNames[grant.GranteeID]
+		if !ok {
+			role, err := p.roleRepo.GetByID(ctx, grant.GranteeID, p.templateTenantID)
+			if err != nil {
+				if errors.IsResourceNotFoundError(err) {
+					return nil, errors.NewValidationError("folder template grants a role that does not exist: " + grant.GranteeID)
+				}
+				return nil, errors.Wrap(err, "failed to get folder template role")
+			}
+			name = role.Name
+			p.roleNames[grant.GranteeID] = name
+		}
+
+		roleID, ok := p.roleIDs[name]
+		if !ok {
+			return nil, errors.NewValidationError("folder template grants a role new tenants do not have: " + name)
+		}
+		grant.GranteeID = roleID
+		converted = append(converted, grant)
+	}
+	return converted, nil
+}
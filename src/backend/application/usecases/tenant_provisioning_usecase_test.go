@@ -0,0 +1,266 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert" // v1.8.0+
+	"github.com/stretchr/testify/mock"   // v1.8.0+
+	"github.com/stretchr/testify/suite"  // v1.8.0+
+
+	"../../domain/models"
+	"../../domain/repositories"
+	"../../domain/services"
+	pkgErrors "../../pkg/errors"
+)
+
+// mockProvisioningTenantRepository mocks the TenantRepository methods used by tenant provisioning
+type mockProvisioningTenantRepository struct {
+	repositories.TenantRepository
+	mock.Mock
+}
+
+func (m *mockProvisioningTenantRepository) ExistsByName(ctx context.Context, name string) (bool, error) {
+	args := m.Called(ctx, name)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockProvisioningTenantRepository) Create(ctx context.Context, tenant *models.Tenant) (string, error) {
+	args := m.Called(ctx, tenant)
+	return tenant.ID, args.Error(0)
+}
+
+// mockProvisioningUserRepository mocks the UserRepository methods used by tenant provisioning
+type mockProvisioningUserRepository struct {
+	repositories.UserRepository
+	mock.Mock
+}
+
+func (m *mockProvisioningUserRepository) Create(ctx context.Context, user *models.User) (string, error) {
+	args := m.Called(ctx, user)
+	return user.ID, args.Error(0)
+}
+
+// fakeProvisioningRoleRepository records the roles created for new tenants and holds the roles of
+// the template tenant
+type fakeProvisioningRoleRepository struct {
+	repositories.RoleRepository
+	templateRoles map[string]*models.Role
+	created       []*models.Role
+}
+
+func (r *fakeProvisioningRoleRepository) Create(ctx context.Context, role *models.Role) (string, error) {
+	r.created = append(r.created, role)
+	return role.ID, nil
+}
+
+func (r *fakeProvisioningRoleRepository) GetByID(ctx context.Context, id string, tenantID string) (*models.Role, error) {
+	if role, ok := r.templateRoles[id]; ok && tenantID == "templates" {
+		return role, nil
+	}
+	return nil, pkgErrors.NewResourceNotFoundError("role not found")
+}
+
+// mockProvisioningSearchIndex mocks a search indexer that prepares and removes tenant indices
+type mockProvisioningSearchIndex struct {
+	mock.Mock
+}
+
+func (m *mockProvisioningSearchIndex) PrepareTenant(ctx context.Context, tenantID string) (*services.SearchTenantRouting, error) {
+	args := m.Called(ctx, tenantID)
+	if routing := args.Get(0); routing != nil {
+		return routing.(*services.SearchTenantRouting), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *mockProvisioningSearchIndex) RemoveTenant(ctx context.Context, tenantID string) error {
+	args := m.Called(ctx, tenantID)
+	return args.Error(0)
+}
+
+// TenantProvisioningUseCaseTestSuite defines a test suite for TenantProvisioningUseCase
+type TenantProvisioningUseCaseTestSuite struct {
+	suite.Suite
+	mockTenantRepo           *mockProvisioningTenantRepository
+	mockUserRepo             *mockProvisioningUserRepository
+	roleRepo                 *fakeProvisioningRoleRepository
+	mockTemplateRepo         *MockFolderTemplateRepository
+	folderRepo               *fakeTemplateFolderRepository
+	mockPermissionRepo       *mockTemplatePermissionRepository
+	mockMetadataTemplateRepo *mockTemplateMetadataTemplateRepository
+	mockOutboxRepo           *MockOutboxRepository
+	mockSearchIndex          *mockProvisioningSearchIndex
+	provisioningUseCase      TenantProvisioningUseCase
+}
+
+// SetupTest sets up the test environment before each test
+func (s *TenantProvisioningUseCaseTestSuite) SetupTest() {
+	s.mockTenantRepo = new(mockProvisioningTenantRepository)
+	s.mockUserRepo = new(mockProvisioningUserRepository)
+	s.roleRepo = &fakeProvisioningRoleRepository{templateRoles: map[string]*models.Role{
+		"template-reader":   {ID: "template-reader", Name: models.RoleReader, TenantID: "templates"},
+		"template-editor":   {ID: "template-editor", Name: models.RoleEditor, TenantID: "templates"},
+		"template-auditors": {ID: "template-auditors", Name: "auditors", TenantID: "templates"},
+	}}
+	s.mockTemplateRepo = new(MockFolderTemplateRepository)
+	s.folderRepo = new(fakeTemplateFolderRepository)
+	s.mockPermissionRepo = new(mockTemplatePermissionRepository)
+	s.mockMetadataTemplateRepo = new(mockTemplateMetadataTemplateRepository)
+	s.mockOutboxRepo = new(MockOutboxRepository)
+	s.mockSearchIndex = new(mockProvisioningSearchIndex)
+
+	var err error
+	s.provisioningUseCase, err = NewTenantProvisioningUseCase(s.mockTenantRepo, s.mockUserRepo, s.roleRepo, s.mockTemplateRepo, s.folderRepo, s.mockPermissionRepo,
+		s.mockMetadataTemplateRepo, s.mockOutboxRepo, &noopAuditService{}, &passthroughTransactionManager{}, s.mockSearchIndex, nil, "", "templates")
+	assert.Nil(s.T(), err)
+	assert.NotNil(s.T(), s.provisioningUseCase)
+}
+
+// onboardingTemplate returns a template of the template tenant with a Contracts folder holding a
+// Signed folder, granting editors write on the template and readers read on Contracts
+func (s *TenantProvisioningUseCaseTestSuite) onboardingTemplate() *models.FolderTemplate {
+	template := models.NewFolderTemplate("templates", "Onboarding", []models.FolderTemplateFolder{
+		{
+			Name: "Contracts",
+			Permissions: []models.FolderTemplateGrant{
+				{GranteeType: models.GranteeTypeRole, GranteeID: "template-reader", PermissionType: models.PermissionTypeRead},
+				{GranteeType: models.GranteeTypeGroup, GranteeID: "legal", PermissionType: models.PermissionTypeWrite},
+			},
+			Folders: []models.FolderTemplateFolder{{Name: "Signed"}},
+		},
+	}, "operator")
+	template.ID = "template123"
+	template.Permissions = []models.FolderTemplateGrant{{GranteeType: models.GranteeTypeRole, GranteeID: "template-editor", PermissionType: models.PermissionTypeWrite}}
+	return template
+}
+
+// provisioningRequest returns a request provisioning Acme from the onboarding template
+func (s *TenantProvisioningUseCaseTestSuite) provisioningRequest() TenantProvisioningRequest {
+	return TenantProvisioningRequest{
+		Name:             "Acme",
+		AdminUsername:    "jane",
+		AdminEmail:       "jane@acme.example",
+		FolderTemplateID: "template123",
+		RequestedBy:      "ops@platform.example",
+	}
+}
+
+// TestNewTenantProvisioningUseCase tests the creation of a new TenantProvisioningUseCase
+func (s *TenantProvisioningUseCaseTestSuite) TestNewTenantProvisioningUseCase() {
+	useCase, err := NewTenantProvisioningUseCase(s.mockTenantRepo, s.mockUserRepo, s.roleRepo, s.mockTemplateRepo, s.folderRepo, s.mockPermissionRepo,
+		s.mockMetadataTemplateRepo, s.mockOutboxRepo, &noopAuditService{}, nil, nil, nil, "", "")
+	assert.NotNil(s.T(), err)
+	assert.Nil(s.T(), useCase)
+}
+
+// TestProvisionTenant_Success tests provisioning a tenant, which creates the default roles, the
+// administrator and the template's folders with their role grants moved to the new roles
+func (s *TenantProvisioningUseCaseTestSuite) TestProvisionTenant_Success() {
+	s.mockTenantRepo.On("ExistsByName", mock.Anything, "Acme").Return(false, nil)
+	s.mockTemplateRepo.On("GetByID", mock.Anything, "template123", "templates").Return(s.onboardingTemplate(), nil)
+	s.mockSearchIndex.On("PrepareTenant", mock.Anything, mock.Anything).Return(&services.SearchTenantRouting{Index: "documents", Routing: "tenant"}, nil)
+	s.mockTenantRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	s.mockUserRepo.On("Create", mock.Anything, mock.MatchedBy(func(user *models.User) bool {
+		return user.Username == "jane" && user.HasRole(models.RoleAdministrator)
+	})).Return(nil)
+	s.mockOutboxRepo.On("Create", mock.Anything, mock.MatchedBy(func(m *models.OutboxMessage) bool {
+		return m.EventType == models.EventTypeFolderCreated
+	})).Return("message123", nil).Times(2)
+	s.mockOutboxRepo.On("Create", mock.Anything, mock.MatchedBy(func(m *models.OutboxMessage) bool {
+		return m.EventType == models.EventTypeTenantCreated
+	})).Return("message456", nil).Once()
+	var permissions []*models.Permission
+	s.mockPermissionRepo.On("CreateBulk", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		permissions = args.Get(1).([]*models.Permission)
+	}).Return(nil)
+	s.mockPermissionRepo.On("PropagatePermissions", mock.Anything, mock.Anything, mock.Anything).Return(nil).Times(2)
+
+	provisioning, err := s.provisioningUseCase.ProvisionTenant(context.Background(), s.provisioningRequest())
+
+	assert.Nil(s.T(), err)
+	if !assert.NotNil(s.T(), provisioning) {
+		return
+	}
+	tenantID := provisioning.Tenant.ID
+	assert.NotEmpty(s.T(), tenantID)
+	assert.Equal(s.T(), tenantID+"/", provisioning.StoragePrefix)
+	assert.Equal(s.T(), "documents", provisioning.Search.Index)
+	assert.NotEmpty(s.T(), provisioning.AdminPassword)
+	assert.True(s.T(), provisioning.Admin.IsPasswordExpired(provisioning.Tenant.PasswordPolicy(), time.Now()))
+	assert.Len(s.T(), s.roleRepo.created, 5)
+
+	roleIDs := make(map[string]string)
+	for _, role := range s.roleRepo.created {
+		assert.Equal(s.T(), tenantID, role.TenantID)
+		roleIDs[role.Name] = role.ID
+	}
+
+	if assert.Len(s.T(), s.folderRepo.folders, 2) {
+		assert.Equal(s.T(), "/Contracts", s.folderRepo.folders[0].Path)
+		assert.Equal(s.T(), "", s.folderRepo.folders[0].ParentID)
+		assert.Equal(s.T(), "/Contracts/Signed", s.folderRepo.folders[1].Path)
+		assert.Equal(s.T(), provisioning.Admin.ID, s.folderRepo.folders[0].OwnerID)
+	}
+
+	// The template's grant to editors and the grant to readers on Contracts; the group grant is left out
+	if assert.Len(s.T(), permissions, 2) {
+		assert.Equal(s.T(), roleIDs[models.RoleEditor], permissions[0].RoleID)
+		assert.Equal(s.T(), roleIDs[models.RoleReader], permissions[1].RoleID)
+		assert.Equal(s.T(), tenantID, permissions[1].TenantID)
+	}
+	s.mockOutboxRepo.AssertExpectations(s.T())
+	s.mockPermissionRepo.AssertExpectations(s.T())
+}
+
+// TestProvisionTenant_NameTaken tests provisioning a tenant under the name of an existing tenant
+func (s *TenantProvisioningUseCaseTestSuite) TestProvisionTenant_NameTaken() {
+	s.mockTenantRepo.On("ExistsByName", mock.Anything, "Acme").Return(true, nil)
+
+	provisioning, err := s.provisioningUseCase.ProvisionTenant(context.Background(), s.provisioningRequest())
+
+	assert.Nil(s.T(), provisioning)
+	assert.True(s.T(), pkgErrors.IsValidationError(err))
+	s.mockSearchIndex.AssertNotCalled(s.T(), "PrepareTenant", mock.Anything, mock.Anything)
+	s.mockTenantRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+}
+
+// TestProvisionTenant_UnknownRole tests provisioning from a template granting a role new tenants do not have
+func (s *TenantProvisioningUseCaseTestSuite) TestProvisionTenant_UnknownRole() {
+	template := s.onboardingTemplate()
+	template.Permissions[0].GranteeID = "template-auditors"
+	s.mockTenantRepo.On("ExistsByName", mock.Anything, "Acme").Return(false, nil)
+	s.mockTemplateRepo.On("GetByID", mock.Anything, "template123", "templates").Return(template, nil)
+
+	provisioning, err := s.provisioningUseCase.ProvisionTenant(context.Background(), s.provisioningRequest())
+
+	assert.Nil(s.T(), provisioning)
+	assert.True(s.T(), pkgErrors.IsValidationError(err))
+	s.mockSearchIndex.AssertNotCalled(s.T(), "PrepareTenant", mock.Anything, mock.Anything)
+	assert.Empty(s.T(), s.roleRepo.created)
+}
+
+// TestProvisionTenant_RollsBackSearchIndex tests that the index prepared for a tenant that cannot be
+// created is removed
+func (s *TenantProvisioningUseCaseTestSuite) TestProvisionTenant_RollsBackSearchIndex() {
+	request := s.provisioningRequest()
+	request.FolderTemplateID = ""
+	s.mockTenantRepo.On("ExistsByName", mock.Anything, "Acme").Return(false, nil)
+	s.mockSearchIndex.On("PrepareTenant", mock.Anything, mock.Anything).Return(&services.SearchTenantRouting{Index: "documents-acme"}, nil)
+	s.mockTenantRepo.On("Create", mock.Anything, mock.Anything).Return(fmt.Errorf("connection lost"))
+	s.mockSearchIndex.On("RemoveTenant", mock.Anything, mock.Anything).Return(nil).Once()
+
+	provisioning, err := s.provisioningUseCase.ProvisionTenant(context.Background(), request)
+
+	assert.Nil(s.T(), provisioning)
+	assert.NotNil(s.T(), err)
+	s.mockSearchIndex.AssertExpectations(s.T())
+	s.mockUserRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+}
+
+// TestTenantProvisioningUseCaseSuite runs the TenantProvisioningUseCase test suite
+func TestTenantProvisioningUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(TenantProvisioningUseCaseTestSuite))
+}
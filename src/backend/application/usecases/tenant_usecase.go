@@ -0,0 +1,662 @@
+// Package usecases implements the application layer of the Document Management Platform.
+// It contains use case implementations that orchestrate domain models and services.
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"../../domain/models"
+	"../../domain/repositories"
+	"../../domain/services"
+	"../../pkg/errors"
+	"../../pkg/logger"
+	"../../pkg/utils"
+)
+
+// TenantUseCase defines the contract for tenant self-service administration. Every operation
+// requires the actor to be an administrator of the tenant.
+type TenantUseCase interface {
+	// ListUsers lists the tenant's users with pagination
+	ListUsers(ctx context.Context, tenantID, actorID string, page, pageSize int) (utils.PaginatedResult[models.User], error)
+
+	// InviteUser creates a user account with a temporary password that has to be changed at the first
+	// sign-in. When invitations are emailed the password is sent to the user and an empty string is
+	// returned; otherwise it is returned for the administrator to hand over.
+	InviteUser(ctx context.Context, tenantID, actorID, username, email string, roles []string) (*models.User, string, error)
+
+	// DeactivateUser deactivates a user; their tokens stop working and they can no longer sign in
+	DeactivateUser(ctx context.Context, tenantID, actorID, userID string) error
+
+	// ActivateUser reactivates a deactivated or suspended user
+	ActivateUser(ctx context.Context, tenantID, actorID, userID string) error
+
+	// ResetUserPassword replaces a user's password with a temporary one that has to be changed at the
+	// next sign-in, unlocks the account and returns the temporary password
+	ResetUserPassword(ctx context.Context, tenantID, actorID, userID string) (string, error)
+
+	// AssignRoles replaces a user's roles
+	AssignRoles(ctx context.Context, tenantID, actorID, userID string, roles []string) (*models.User, error)
+
+	// GetUsage returns the users, documents and storage the tenant consumes
+	GetUsage(ctx context.Context, tenantID, actorID string) (*models.TenantUsage, error)
+
+	// GetSettings returns the tenant's settings
+	GetSettings(ctx context.Context, tenantID, actorID string) (map[string]string, error)
+
+	// UpdateSettings changes the given tenant settings and returns all settings. An empty value
+	// removes a setting, restoring its default.
+	UpdateSettings(ctx context.Context, tenantID, actorID string, settings map[string]string) (map[string]string, error)
+
+	// ListUploadLimits lists the upload limits configured for the tenant's users and roles
+	ListUploadLimits(ctx context.Context, tenantID, actorID string) ([]*models.UploadLimit, error)
+
+	// SetUploadLimit sets the maximum file size and daily upload volume of a user or of every user
+	// holding a role. A zero limit means unlimited.
+	SetUploadLimit(ctx context.Context, tenantID, actorID, scope, scopeID string, maxFileSizeBytes, maxDailyUploadBytes int64) (*models.UploadLimit, error)
+
+	// DeleteUploadLimit removes the upload limits of a user or role, so that role or default limits apply
+	DeleteUploadLimit(ctx context.Context, tenantID, actorID, scope, scopeID string) error
+}
+
+// tenantUseCase implements the TenantUseCase interface
+type tenantUseCase struct {
+	tenantRepo   repositories.TenantRepository
+	userRepo     repositories.UserRepository
+	roleRepo     repositories.RoleRepository
+	quotaService services.QuotaService
+	uploadLimits services.UploadLimitService
+	authService  services.AuthService
+	auditService services.AuditService
+	keyService   services.EncryptionKeyService
+	emailSender  services.EmailSender
+	signInURL    string
+}
+
+// NewTenantUseCase creates a new TenantUseCase instance. The email sender is optional: without it,
+// temporary passwords of invited users are returned to the administrator instead of being emailed.
+func NewTenantUseCase(
+	tenantRepo repositories.TenantRepository,
+	userRepo repositories.UserRepository,
+	roleRepo repositories.RoleRepository,
+	quotaService services.QuotaService,
+	uploadLimits services.UploadLimitService,
+	authService services.AuthService,
+	auditService services.AuditService,
+	keyService services.EncryptionKeyService,
+	emailSender services.EmailSender,
+	signInURL string,
+) (TenantUseCase, error) {
+	if tenantRepo == nil {
+		return nil, fmt.Errorf("tenant repository cannot be nil")
+	}
+	if userRepo == nil {
+		return nil, fmt.Errorf("user repository cannot be nil")
+	}
+	if roleRepo == nil {
+		return nil, fmt.Errorf("role repository cannot be nil")
+	}
+	if quotaService == nil {
+		return nil, fmt.Errorf("quota service cannot be nil")
+	}
+	if uploadLimits == nil {
+		return nil, fmt.Errorf("upload limit service cannot be nil")
+	}
+	if authService == nil {
+		return nil, fmt.Errorf("auth service cannot be nil")
+	}
+	if auditService == nil {
+		return nil, fmt.Errorf("audit service cannot be nil")
+	}
+	if keyService == nil {
+		return nil, fmt.Errorf("encryption key service cannot be nil")
+	}
+
+	return &tenantUseCase{
+		tenantRepo:   tenantRepo,
+		userRepo:     userRepo,
+		roleRepo:     roleRepo,
+		quotaService: quotaService,
+		uploadLimits: uploadLimits,
+		authService:  authService,
+		auditService: auditService,
+		keyService:   keyService,
+		emailSender:  emailSender,
+		signInURL:    signInURL,
+	}, nil
+}
+
+// ListUsers lists the tenant's users with pagination
+func (u *tenantUseCase) ListUsers(ctx context.Context, tenantID, actorID string, page, pageSize int) (utils.PaginatedResult[models.User], error) {
+	log := logger.WithContext(ctx)
+
+	if err := u.validateInput(map[string]string{
+		"tenant ID": tenantID,
+		"actor ID":  actorID,
+	}); err != nil {
+		return utils.PaginatedResult[models.User]{}, err
+	}
+
+	if err := u.authorize(ctx, tenantID, actorID); err != nil {
+		return utils.PaginatedResult[models.User]{}, err
+	}
+
+	result, err := u.userRepo.ListByTenant(ctx, tenantID, utils.NewPagination(page, pageSize))
+	if err != nil {
+		log.WithError(err).Error("failed to list users", "tenantID", tenantID)
+		return utils.PaginatedResult[models.User]{}, errors.Wrap(err, "failed to list users")
+	}
+
+	return result, nil
+}
+
+// InviteUser creates a user account with a temporary password
+func (u *tenantUseCase) InviteUser(ctx context.Context, tenantID, actorID, username, email string, roles []string) (*models.User, string, error) {
+	log := logger.WithContext(ctx)
+
+	if err := u.validateInput(map[string]string{
+		"tenant ID": tenantID,
+		"actor ID":  actorID,
+		"username":  strings.TrimSpace(username),
+		"email":     strings.TrimSpace(email),
+	}); err != nil {
+		return nil, "", err
+	}
+
+	if err := u.authorize(ctx, tenantID, actorID); err != nil {
+		return nil, "", err
+	}
+
+	tenant, err := u.tenantRepo.GetByID(ctx, tenantID)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "failed to retrieve tenant")
+	}
+
+	if err := u.validateRoles(ctx, tenantID, roles); err != nil {
+		return nil, "", err
+	}
+
+	// Usernames and email addresses are unique within a tenant
+	exists, err := u.userRepo.ExistsByUsername(ctx, username, tenantID)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "failed to check username availability")
+	}
+	if exists {
+		return nil, "", errors.NewValidationError("username already exists")
+	}
+	exists, err = u.userRepo.ExistsByEmail(ctx, email, tenantID)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "failed to check email availability")
+	}
+	if exists {
+		return nil, "", errors.NewValidationError("email already exists")
+	}
+
+	user := models.NewUser(username, email, tenantID)
+	user.Roles = append(user.Roles, roles...)
+	if err := user.Validate(); err != nil {
+		return nil, "", errors.NewValidationError(err.Error())
+	}
+
+	password, err := setTemporaryPassword(user, tenant.PasswordPolicy())
+	if err != nil {
+		return nil, "", err
+	}
+
+	if _, err := u.userRepo.Create(ctx, user); err != nil {
+		log.WithError(err).Error("failed to create invited user", "tenantID", tenantID)
+		return nil, "", errors.Wrap(err, "failed to create user")
+	}
+
+	u.recordAction(ctx, tenantID, actorID, models.AuditActionCreate, models.AuditResourceUser, user.ID, nil, map[string]interface{}{
+		"username": user.Username,
+		"email":    user.Email,
+		"roles":    user.Roles,
+	})
+
+	// Email the temporary password when invitations are emailed
+	if u.emailSender != nil {
+		subject := "You have been invited to " + tenant.Name
+		body := fmt.Sprintf("An account has been created for you.\n\nSign in at %s with the username %s and this temporary password, which you will be asked to change:\n\n%s\n",
+			u.signInURL, user.Username, password)
+		if err := services.SendTenantEmail(ctx, u.emailSender, tenant.Preferences(), user.Email, subject, body); err != nil {
+			log.WithError(err).Error("failed to send invitation email", "userID", user.ID)
+			return nil, "", errors.Wrap(err, "failed to send invitation")
+		}
+		password = ""
+	}
+
+	log.Info("user invited", "userID", user.ID, "tenantID", tenantID, "actorID", actorID)
+	return user, password, nil
+}
+
+// DeactivateUser deactivates a user
+func (u *tenantUseCase) DeactivateUser(ctx context.Context, tenantID, actorID, userID string) error {
+	if actorID == userID {
+		return errors.NewValidationError("administrators cannot deactivate themselves")
+	}
+	return u.setUserStatus(ctx, tenantID, actorID, userID, models.UserStatusInactive)
+}
+
+// ActivateUser reactivates a deactivated or suspended user
+func (u *tenantUseCase) ActivateUser(ctx context.Context, tenantID, actorID, userID string) error {
+	return u.setUserStatus(ctx, tenantID, actorID, userID, models.UserStatusActive)
+}
+
+// ResetUserPassword replaces a user's password with a temporary one and unlocks the account
+func (u *tenantUseCase) ResetUserPassword(ctx context.Context, tenantID, actorID, userID string) (string, error) {
+	log := logger.WithContext(ctx)
+
+	if err := u.validateInput(map[string]string{
+		"tenant ID": tenantID,
+		"actor ID":  actorID,
+		"user ID":   userID,
+	}); err != nil {
+		return "", err
+	}
+
+	if err := u.authorize(ctx, tenantID, actorID); err != nil {
+		return "", err
+	}
+
+	tenant, err := u.tenantRepo.GetByID(ctx, tenantID)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to retrieve tenant")
+	}
+
+	user, err := u.userRepo.GetByID(ctx, userID, tenantID)
+	if err != nil {
+		return "", err
+	}
+
+	password, err := setTemporaryPassword(user, tenant.PasswordPolicy())
+	if err != nil {
+		return "", err
+	}
+	user.ResetFailedLogins()
+
+	if err := u.userRepo.Update(ctx, user); err != nil {
+		log.WithError(err).Error("failed to reset password", "userID", userID)
+		return "", errors.Wrap(err, "failed to update user")
+	}
+
+	u.recordAction(ctx, tenantID, actorID, models.AuditActionUpdate, models.AuditResourceUser, user.ID, nil, map[string]interface{}{
+		"password": "reset",
+	})
+
+	log.Info("user password reset", "userID", userID, "tenantID", tenantID, "actorID", actorID)
+	return password, nil
+}
+
+// AssignRoles replaces a user's roles
+func (u *tenantUseCase) AssignRoles(ctx context.Context, tenantID, actorID, userID string, roles []string) (*models.User, error) {
+	log := logger.WithContext(ctx)
+
+	if err := u.validateInput(map[string]string{
+		"tenant ID": tenantID,
+		"actor ID":  actorID,
+		"user ID":   userID,
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := u.authorize(ctx, tenantID, actorID); err != nil {
+		return nil, err
+	}
+
+	if err := u.validateRoles(ctx, tenantID, roles); err != nil {
+		return nil, err
+	}
+
+	// Keep at least the acting administrator able to administer the tenant
+	if actorID == userID && !containsString(roles, models.RoleAdministrator) {
+		return nil, errors.NewValidationError("administrators cannot remove their own administrator role")
+	}
+
+	user, err := u.userRepo.GetByID(ctx, userID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	before := map[string]interface{}{"roles": user.Roles}
+	user.Roles = append([]string{}, roles...)
+	if err := u.userRepo.Update(ctx, user); err != nil {
+		log.WithError(err).Error("failed to assign roles", "userID", userID)
+		return nil, errors.Wrap(err, "failed to update user")
+	}
+
+	u.recordAction(ctx, tenantID, actorID, models.AuditActionUpdate, models.AuditResourceUser, user.ID, before, map[string]interface{}{
+		"roles": user.Roles,
+	})
+
+	log.Info("user roles assigned", "userID", userID, "tenantID", tenantID, "actorID", actorID)
+	return user, nil
+}
+
+// GetUsage returns the users, documents and storage the tenant consumes along with its quota
+func (u *tenantUseCase) GetUsage(ctx context.Context, tenantID, actorID string) (*models.TenantUsage, error) {
+	log := logger.WithContext(ctx)
+
+	if err := u.validateInput(map[string]string{
+		"tenant ID": tenantID,
+		"actor ID":  actorID,
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := u.authorize(ctx, tenantID, actorID); err != nil {
+		return nil, err
+	}
+
+	users, err := u.userRepo.Count(ctx, tenantID)
+	if err != nil {
+		log.WithError(err).Error("failed to count users", "tenantID", tenantID)
+		return nil, errors.Wrap(err, "failed to count users")
+	}
+	activeUsers, err := u.userRepo.CountByStatus(ctx, models.UserStatusActive, tenantID)
+	if err != nil {
+		log.WithError(err).Error("failed to count active users", "tenantID", tenantID)
+		return nil, errors.Wrap(err, "failed to count active users")
+	}
+	quota, err := u.quotaService.GetQuota(ctx, tenantID)
+	if err != nil {
+		log.WithError(err).Error("failed to get tenant quota", "tenantID", tenantID)
+		return nil, errors.Wrap(err, "failed to get tenant quota")
+	}
+
+	return &models.TenantUsage{
+		Users:           users,
+		ActiveUsers:     activeUsers,
+		Documents:       quota.UsedDocuments,
+		StorageBytes:    quota.UsedStorageBytes,
+		MaxDocuments:    quota.MaxDocuments,
+		MaxStorageBytes: quota.MaxStorageBytes,
+	}, nil
+}
+
+// GetSettings returns the tenant's settings
+func (u *tenantUseCase) GetSettings(ctx context.Context, tenantID, actorID string) (map[string]string, error) {
+	if err := u.validateInput(map[string]string{
+		"tenant ID": tenantID,
+		"actor ID":  actorID,
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := u.authorize(ctx, tenantID, actorID); err != nil {
+		return nil, err
+	}
+
+	tenant, err := u.tenantRepo.GetByID(ctx, tenantID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve tenant")
+	}
+
+	if tenant.Settings == nil {
+		return map[string]string{}, nil
+	}
+	return tenant.Settings, nil
+}
+
+// UpdateSettings changes the given tenant settings and returns all settings
+func (u *tenantUseCase) UpdateSettings(ctx context.Context, tenantID, actorID string, settings map[string]string) (map[string]string, error) {
+	log := logger.WithContext(ctx)
+
+	if err := u.validateInput(map[string]string{
+		"tenant ID": tenantID,
+		"actor ID":  actorID,
+	}); err != nil {
+		return nil, err
+	}
+	if len(settings) == 0 {
+		return nil, errors.NewValidationError("settings cannot be empty")
+	}
+
+	// Reject the whole update if any setting is invalid
+	for key, value := range settings {
+		if err := models.ValidateTenantSetting(key, value); err != nil {
+			return nil, errors.NewValidationError(fmt.Sprintf("%s: %s", key, err.Error()))
+		}
+	}
+
+	// An allowlist without the address the change is made from would lock the administrator out
+	if allowlist := settings[models.TenantSettingIPAllowlist]; allowlist != "" {
+		if actor, ok := services.AuditActorFromContext(ctx); ok {
+			if ip := net.ParseIP(actor.IPAddress); ip != nil && !models.NewNetworkPolicy(allowlist, "").AllowsIP(ip) {
+				return nil, errors.NewValidationError(fmt.Sprintf("%s: must include the address the change is made from (%s)", models.TenantSettingIPAllowlist, ip))
+			}
+		}
+	}
+
+	if err := u.authorize(ctx, tenantID, actorID); err != nil {
+		return nil, err
+	}
+
+	tenant, err := u.tenantRepo.GetByID(ctx, tenantID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve tenant")
+	}
+
+	// A new encryption key must be usable by the platform before any content is written with it
+	if keyID := settings[models.TenantSettingEncryptionKeyID]; keyID != "" && keyID != tenant.EncryptionKeyID() {
+		if err := u.keyService.VerifyKey(ctx, keyID); err != nil {
+			return nil, err
+		}
+	}
+
+	before := make(map[string]interface{}, len(settings))
+	after := make(map[string]interface{}, len(settings))
+	for key, value := range settings {
+		before[key] = tenant.GetSetting(key)
+		after[key] = value
+		if value == "" {
+			tenant.DeleteSetting(key)
+		} else {
+			tenant.SetSetting(key, value)
+		}
+	}
+
+	if err := u.tenantRepo.Update(ctx, tenant); err != nil {
+		log.WithError(err).Error("failed to update tenant settings", "tenantID", tenantID)
+		return nil, errors.Wrap(err, "failed to update tenant")
+	}
+
+	u.recordAction(ctx, tenantID, actorID, models.AuditActionUpdate, models.AuditResourceTenant, tenantID, before, after)
+
+	log.Info("tenant settings updated", "tenantID", tenantID, "actorID", actorID)
+	return tenant.Settings, nil
+}
+
+// ListUploadLimits lists the upload limits configured for the tenant's users and roles
+func (u *tenantUseCase) ListUploadLimits(ctx context.Context, tenantID, actorID string) ([]*models.UploadLimit, error) {
+	if err := u.validateInput(map[string]string{
+		"tenant ID": tenantID,
+		"actor ID":  actorID,
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := u.authorize(ctx, tenantID, actorID); err != nil {
+		return nil, err
+	}
+
+	limits, err := u.uploadLimits.ListLimits(ctx, tenantID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list upload limits")
+	}
+	return limits, nil
+}
+
+// SetUploadLimit sets the upload limits of a user or role
+func (u *tenantUseCase) SetUploadLimit(ctx context.Context, tenantID, actorID, scope, scopeID string, maxFileSizeBytes, maxDailyUploadBytes int64) (*models.UploadLimit, error) {
+	log := logger.WithContext(ctx)
+
+	if err := u.validateInput(map[string]string{
+		"tenant ID": tenantID,
+		"actor ID":  actorID,
+		"scope ID":  scopeID,
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := u.authorize(ctx, tenantID, actorID); err != nil {
+		return nil, err
+	}
+
+	if scope == models.UploadLimitScopeRole {
+		if err := u.validateRoles(ctx, tenantID, []string{scopeID}); err != nil {
+			return nil, err
+		}
+	}
+
+	limit, err := u.uploadLimits.SetLimit(ctx, tenantID, scope, scopeID, maxFileSizeBytes, maxDailyUploadBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	u.recordAction(ctx, tenantID, actorID, models.AuditActionUpdate, models.AuditResourceUploadLimit, scope+":"+scopeID, nil,
+		map[string]interface{}{
+			"max_file_size_bytes":    maxFileSizeBytes,
+			"max_daily_upload_bytes": maxDailyUploadBytes,
+		},
+	)
+
+	log.Info("upload limit set", "scope", scope, "scopeID", scopeID, "tenantID", tenantID, "actorID", actorID)
+	return limit, nil
+}
+
+// DeleteUploadLimit removes the upload limits of a user or role
+func (u *tenantUseCase) DeleteUploadLimit(ctx context.Context, tenantID, actorID, scope, scopeID string) error {
+	log := logger.WithContext(ctx)
+
+	if err := u.validateInput(map[string]string{
+		"tenant ID": tenantID,
+		"actor ID":  actorID,
+		"scope ID":  scopeID,
+	}); err != nil {
+		return err
+	}
+
+	if err := u.authorize(ctx, tenantID, actorID); err != nil {
+		return err
+	}
+
+	if err := u.uploadLimits.DeleteLimit(ctx, tenantID, scope, scopeID); err != nil {
+		return err
+	}
+
+	u.recordAction(ctx, tenantID, actorID, models.AuditActionDelete, models.AuditResourceUploadLimit, scope+":"+scopeID, nil, nil)
+
+	log.Info("upload limit deleted", "scope", scope, "scopeID", scopeID, "tenantID", tenantID, "actorID", actorID)
+	return nil
+}
+
+// setUserStatus changes a user's status and records it in the audit log
+func (u *tenantUseCase) setUserStatus(ctx context.Context, tenantID, actorID, userID, status string) error {
+	log := logger.WithContext(ctx)
+
+	if err := u.validateInput(map[string]string{
+		"tenant ID": tenantID,
+		"actor ID":  actorID,
+		"user ID":   userID,
+	}); err != nil {
+		return err
+	}
+
+	if err := u.authorize(ctx, tenantID, actorID); err != nil {
+		return err
+	}
+
+	user, err := u.userRepo.GetByID(ctx, userID, tenantID)
+	if err != nil {
+		return err
+	}
+	if user.Status == status {
+		return nil
+	}
+
+	if err := u.userRepo.UpdateStatus(ctx, userID, status, tenantID); err != nil {
+		log.WithError(err).Error("failed to update user status", "userID", userID)
+		return errors.Wrap(err, "failed to update user status")
+	}
+
+	u.recordAction(ctx, tenantID, actorID, models.AuditActionUpdate, models.AuditResourceUser, userID,
+		map[string]interface{}{"status": user.Status},
+		map[string]interface{}{"status": status},
+	)
+
+	log.Info("user status updated", "userID", userID, "status", status, "tenantID", tenantID, "actorID", actorID)
+	return nil
+}
+
+// setTemporaryPassword sets a generated password that has to be changed at the next sign-in
+func setTemporaryPassword(user *models.User, policy models.PasswordPolicy) (string, error) {
+	password, err := models.GenerateTemporaryPassword(policy)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to generate temporary password")
+	}
+	if err := user.ChangePassword(password, policy); err != nil {
+		return "", errors.Wrap(err, "failed to set temporary password")
+	}
+	user.ExpirePassword()
+	return password, nil
+}
+
+// validateRoles checks that every role exists in the tenant and can be assigned to users
+func (u *tenantUseCase) validateRoles(ctx context.Context, tenantID string, roles []string) error {
+	for _, role := range roles {
+		if role == models.RoleSystem {
+			return errors.NewValidationError("role cannot be assigned: " + role)
+		}
+		if _, err := u.roleRepo.GetByName(ctx, role, tenantID); err != nil {
+			if errors.IsResourceNotFoundError(err) {
+				return errors.NewValidationError("role not found: " + role)
+			}
+			return errors.Wrap(err, "failed to get role")
+		}
+	}
+	return nil
+}
+
+// authorize checks that the actor is an administrator of the tenant
+func (u *tenantUseCase) authorize(ctx context.Context, tenantID, actorID string) error {
+	isAdmin, err := u.authService.VerifyPermission(ctx, actorID, tenantID, services.PermissionAdmin)
+	if err != nil {
+		return errors.Wrap(err, "failed to verify permission")
+	}
+	if !isAdmin {
+		return errors.NewAuthorizationError("only administrators can administer the tenant")
+	}
+	return nil
+}
+
+// recordAction records an administrative change in the audit log
+func (u *tenantUseCase) recordAction(ctx context.Context, tenantID, actorID, action, resourceType, resourceID string, before, after map[string]interface{}) {
+	err := u.auditService.RecordAction(ctx, tenantID, actorID, action, resourceType, resourceID, before, after)
+	if err != nil {
+		logger.WithContext(ctx).WithError(err).Error("failed to record tenant administration in audit log")
+		// Do not return error, the change has already been made
+	}
+}
+
+// containsString reports whether a list holds a value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// validateInput validates that required input parameters are not empty
+func (u *tenantUseCase) validateInput(params map[string]string) error {
+	for name, value := range params {
+		if value == "" {
+			return errors.NewValidationError(fmt.Sprintf("%s cannot be empty", name))
+		}
+	}
+	return nil
+}
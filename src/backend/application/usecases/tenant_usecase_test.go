@@ -0,0 +1,456 @@
+package usecases
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert" // v1.8.0+
+	"github.com/stretchr/testify/mock"   // v1.8.0+
+	"github.com/stretchr/testify/suite"  // v1.8.0+
+
+	"../../domain/models"
+	"../../domain/repositories"
+	"../../domain/services"
+	pkgErrors "../../pkg/errors"
+)
+
+// mockTenantTenantRepository mocks the TenantRepository methods used by tenant administration
+type mockTenantTenantRepository struct {
+	repositories.TenantRepository
+	mock.Mock
+}
+
+func (m *mockTenantTenantRepository) GetByID(ctx context.Context, id string) (*models.Tenant, error) {
+	args := m.Called(ctx, id)
+	if tenant := args.Get(0); tenant != nil {
+		return tenant.(*models.Tenant), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *mockTenantTenantRepository) Update(ctx context.Context, tenant *models.Tenant) error {
+	args := m.Called(ctx, tenant)
+	return args.Error(0)
+}
+
+// mockTenantUserRepository mocks the UserRepository methods used by tenant administration
+type mockTenantUserRepository struct {
+	repositories.UserRepository
+	mock.Mock
+}
+
+func (m *mockTenantUserRepository) GetByID(ctx context.Context, id string, tenantID string) (*models.User, error) {
+	args := m.Called(ctx, id, tenantID)
+	if user := args.Get(0); user != nil {
+		return user.(*models.User), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *mockTenantUserRepository) ExistsByUsername(ctx context.Context, username string, tenantID string) (bool, error) {
+	args := m.Called(ctx, username, tenantID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockTenantUserRepository) ExistsByEmail(ctx context.Context, email string, tenantID string) (bool, error) {
+	args := m.Called(ctx, email, tenantID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockTenantUserRepository) Create(ctx context.Context, user *models.User) (string, error) {
+	args := m.Called(ctx, user)
+	user.ID = args.String(0)
+	return args.String(0), args.Error(1)
+}
+
+func (m *mockTenantUserRepository) Update(ctx context.Context, user *models.User) error {
+	args := m.Called(ctx, user)
+	return args.Error(0)
+}
+
+func (m *mockTenantUserRepository) UpdateStatus(ctx context.Context, id string, status string, tenantID string) error {
+	args := m.Called(ctx, id, status, tenantID)
+	return args.Error(0)
+}
+
+func (m *mockTenantUserRepository) Count(ctx context.Context, tenantID string) (int64, error) {
+	args := m.Called(ctx, tenantID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *mockTenantUserRepository) CountByStatus(ctx context.Context, status string, tenantID string) (int64, error) {
+	args := m.Called(ctx, status, tenantID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// mockTenantRoleRepository mocks the RoleRepository methods used by tenant administration
+type mockTenantRoleRepository struct {
+	repositories.RoleRepository
+	mock.Mock
+}
+
+func (m *mockTenantRoleRepository) GetByName(ctx context.Context, name string, tenantID string) (*models.Role, error) {
+	args := m.Called(ctx, name, tenantID)
+	if role := args.Get(0); role != nil {
+		return role.(*models.Role), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// mockTenantQuotaService mocks the QuotaService methods used by tenant administration
+type mockTenantQuotaService struct {
+	services.QuotaService
+	mock.Mock
+}
+
+func (m *mockTenantQuotaService) GetQuota(ctx context.Context, tenantID string) (*models.TenantQuota, error) {
+	args := m.Called(ctx, tenantID)
+	if quota := args.Get(0); quota != nil {
+		return quota.(*models.TenantQuota), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// mockTenantUploadLimitService mocks the UploadLimitService methods used by tenant administration
+type mockTenantUploadLimitService struct {
+	services.UploadLimitService
+	mock.Mock
+}
+
+func (m *mockTenantUploadLimitService) SetLimit(ctx context.Context, tenantID, scope, scopeID string, maxFileSizeBytes, maxDailyUploadBytes int64) (*models.UploadLimit, error) {
+	args := m.Called(ctx, tenantID, scope, scopeID, maxFileSizeBytes, maxDailyUploadBytes)
+	if limit := args.Get(0); limit != nil {
+		return limit.(*models.UploadLimit), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// mockTenantAuthService mocks the AuthService methods used by tenant administration
+type mockTenantAuthService struct {
+	services.AuthService
+	mock.Mock
+}
+
+func (m *mockTenantAuthService) VerifyPermission(ctx context.Context, userID, tenantID, permission string) (bool, error) {
+	args := m.Called(ctx, userID, tenantID, permission)
+	return args.Bool(0), args.Error(1)
+}
+
+// mockTenantKeyService mocks the EncryptionKeyService methods used by tenant administration
+type mockTenantKeyService struct {
+	services.EncryptionKeyService
+	mock.Mock
+}
+
+func (m *mockTenantKeyService) VerifyKey(ctx context.Context, keyID string) error {
+	args := m.Called(ctx, keyID)
+	return args.Error(0)
+}
+
+// MockEmailSender is a mock implementation of the EmailSender interface for testing
+type MockEmailSender struct {
+	mock.Mock
+}
+
+func (m *MockEmailSender) SendEmail(ctx context.Context, to, subject, body string) error {
+	args := m.Called(ctx, to, subject, body)
+	return args.Error(0)
+}
+
+// MockNamedEmailSender is a mock implementation of the EmailSender interface that can send under other names
+type MockNamedEmailSender struct {
+	MockEmailSender
+}
+
+func (m *MockNamedEmailSender) SendEmailAs(ctx context.Context, senderName, to, subject, body string) error {
+	args := m.Called(ctx, senderName, to, subject, body)
+	return args.Error(0)
+}
+
+// TenantUseCaseTestSuite defines a test suite for TenantUseCase
+type TenantUseCaseTestSuite struct {
+	suite.Suite
+	mockTenantRepo   *mockTenantTenantRepository
+	mockUserRepo     *mockTenantUserRepository
+	mockRoleRepo     *mockTenantRoleRepository
+	mockQuotaService *mockTenantQuotaService
+	mockUploadLimits *mockTenantUploadLimitService
+	mockAuthService  *mockTenantAuthService
+	mockAuditService *MockAuditService
+	mockKeyService   *mockTenantKeyService
+	tenantUseCase    TenantUseCase
+	tenant           *models.Tenant
+}
+
+// SetupTest sets up the test environment before each test
+func (s *TenantUseCaseTestSuite) SetupTest() {
+	s.mockTenantRepo = new(mockTenantTenantRepository)
+	s.mockUserRepo = new(mockTenantUserRepository)
+	s.mockRoleRepo = new(mockTenantRoleRepository)
+	s.mockQuotaService = new(mockTenantQuotaService)
+	s.mockUploadLimits = new(mockTenantUploadLimitService)
+	s.mockAuthService = new(mockTenantAuthService)
+	s.mockAuditService = new(MockAuditService)
+	s.mockKeyService = new(mockTenantKeyService)
+	s.mockAuditService.On("RecordAction", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	s.tenant = &models.Tenant{ID: "tenant123", Name: "Acme", Status: models.TenantStatusActive, Settings: map[string]string{}}
+	s.mockTenantRepo.On("GetByID", mock.Anything, "tenant123").Return(s.tenant, nil).Maybe()
+	s.mockAuthService.On("VerifyPermission", mock.Anything, "admin123", "tenant123", services.PermissionAdmin).Return(true, nil).Maybe()
+	s.mockAuthService.On("VerifyPermission", mock.Anything, "user123", "tenant123", services.PermissionAdmin).Return(false, nil).Maybe()
+	s.mockRoleRepo.On("GetByName", mock.Anything, models.RoleReader, "tenant123").Return(&models.Role{Name: models.RoleReader}, nil).Maybe()
+	s.mockRoleRepo.On("GetByName", mock.Anything, models.RoleAdministrator, "tenant123").Return(&models.Role{Name: models.RoleAdministrator}, nil).Maybe()
+
+	var err error
+	s.tenantUseCase, err = s.newTenantUseCase(nil, "")
+	assert.Nil(s.T(), err)
+}
+
+// newTenantUseCase builds a TenantUseCase from the suite's mocks with the given email sender and sign-in URL
+func (s *TenantUseCaseTestSuite) newTenantUseCase(emailSender services.EmailSender, signInURL string) (TenantUseCase, error) {
+	return NewTenantUseCase(s.mockTenantRepo, s.mockUserRepo, s.mockRoleRepo, s.mockQuotaService, s.mockUploadLimits, s.mockAuthService, s.mockAuditService, s.mockKeyService, emailSender, signInURL)
+}
+
+// TestInviteUser_ReturnsTemporaryPassword tests that invited users get an expired temporary password that meets the policy
+func (s *TenantUseCaseTestSuite) TestInviteUser_ReturnsTemporaryPassword() {
+	ctx := context.Background()
+	s.tenant.SetSetting(models.TenantSettingPasswordMinLength, "20")
+
+	s.mockUserRepo.On("ExistsByUsername", ctx, "jane", "tenant123").Return(false, nil)
+	s.mockUserRepo.On("ExistsByEmail", ctx, "jane@example.com", "tenant123").Return(false, nil)
+	s.mockUserRepo.On("Create", ctx, mock.AnythingOfType("*models.User")).Return("user456", nil)
+
+	user, password, err := s.tenantUseCase.InviteUser(ctx, "tenant123", "admin123", "jane", "jane@example.com", []string{models.RoleReader})
+
+	s.NoError(err)
+	s.Equal("user456", user.ID)
+	s.Equal([]string{models.RoleReader}, user.Roles)
+	s.Len(password, 20)
+	match, _ := user.VerifyPassword(password)
+	s.True(match)
+	s.True(user.IsPasswordExpired(s.tenant.PasswordPolicy(), time.Now()))
+	s.mockAuditService.AssertCalled(s.T(), "RecordAction", ctx, "tenant123", "admin123", models.AuditActionCreate, models.AuditResourceUser, "user456", mock.Anything, mock.Anything)
+}
+
+// TestInviteUser_EmailsTemporaryPassword tests that the temporary password is emailed rather than returned when invitations are emailed
+func (s *TenantUseCaseTestSuite) TestInviteUser_EmailsTemporaryPassword() {
+	ctx := context.Background()
+	emailSender := new(MockEmailSender)
+	tenantUseCase, err := s.newTenantUseCase(emailSender, "https://dms.example.com/login")
+	s.Require().NoError(err)
+
+	s.mockUserRepo.On("ExistsByUsername", ctx, "jane", "tenant123").Return(false, nil)
+	s.mockUserRepo.On("ExistsByEmail", ctx, "jane@example.com", "tenant123").Return(false, nil)
+	s.mockUserRepo.On("Create", ctx, mock.AnythingOfType("*models.User")).Return("user456", nil)
+	emailSender.On("SendEmail", ctx, "jane@example.com", mock.Anything, mock.MatchedBy(func(body string) bool {
+		return strings.Contains(body, "https://dms.example.com/login")
+	})).Return(nil)
+
+	_, password, err := tenantUseCase.InviteUser(ctx, "tenant123", "admin123", "jane", "jane@example.com", nil)
+
+	s.NoError(err)
+	s.Empty(password)
+	emailSender.AssertExpectations(s.T())
+}
+
+// TestInviteUser_EmailsUnderNotificationSender tests that invitations are sent under the tenant's notification sender name
+func (s *TenantUseCaseTestSuite) TestInviteUser_EmailsUnderNotificationSender() {
+	ctx := context.Background()
+	s.tenant.SetSetting(models.TenantSettingNotificationSender, "Acme Documents")
+	emailSender := new(MockNamedEmailSender)
+	tenantUseCase, err := s.newTenantUseCase(emailSender, "https://dms.example.com/login")
+	s.Require().NoError(err)
+
+	s.mockUserRepo.On("ExistsByUsername", ctx, "jane", "tenant123").Return(false, nil)
+	s.mockUserRepo.On("ExistsByEmail", ctx, "jane@example.com", "tenant123").Return(false, nil)
+	s.mockUserRepo.On("Create", ctx, mock.AnythingOfType("*models.User")).Return("user456", nil)
+	emailSender.On("SendEmailAs", ctx, "Acme Documents", "jane@example.com", mock.Anything, mock.Anything).Return(nil)
+
+	_, password, err := tenantUseCase.InviteUser(ctx, "tenant123", "admin123", "jane", "jane@example.com", nil)
+
+	s.NoError(err)
+	s.Empty(password)
+	emailSender.AssertExpectations(s.T())
+	emailSender.AssertNotCalled(s.T(), "SendEmail", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestInviteUser_UnknownRole tests that users cannot be invited with roles the tenant does not have
+func (s *TenantUseCaseTestSuite) TestInviteUser_UnknownRole() {
+	ctx := context.Background()
+	s.mockRoleRepo.On("GetByName", ctx, "owner", "tenant123").Return(nil, pkgErrors.NewResourceNotFoundError("role not found"))
+
+	_, _, err := s.tenantUseCase.InviteUser(ctx, "tenant123", "admin123", "jane", "jane@example.com", []string{"owner"})
+
+	s.True(pkgErrors.IsValidationError(err))
+	s.mockUserRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+}
+
+// TestInviteUser_NotAdmin tests that only administrators can invite users
+func (s *TenantUseCaseTestSuite) TestInviteUser_NotAdmin() {
+	_, _, err := s.tenantUseCase.InviteUser(context.Background(), "tenant123", "user123", "jane", "jane@example.com", nil)
+
+	s.True(pkgErrors.IsAuthorizationError(err))
+	s.mockUserRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+}
+
+// TestDeactivateUser tests that a user is deactivated and the change is audited
+func (s *TenantUseCaseTestSuite) TestDeactivateUser() {
+	ctx := context.Background()
+	user := &models.User{ID: "user456", TenantID: "tenant123", Status: models.UserStatusActive}
+
+	s.mockUserRepo.On("GetByID", ctx, "user456", "tenant123").Return(user, nil)
+	s.mockUserRepo.On("UpdateStatus", ctx, "user456", models.UserStatusInactive, "tenant123").Return(nil)
+
+	err := s.tenantUseCase.DeactivateUser(ctx, "tenant123", "admin123", "user456")
+
+	s.NoError(err)
+	s.mockAuditService.AssertCalled(s.T(), "RecordAction", ctx, "tenant123", "admin123", models.AuditActionUpdate, models.AuditResourceUser, "user456", mock.Anything, mock.Anything)
+}
+
+// TestDeactivateUser_Self tests that administrators cannot deactivate themselves
+func (s *TenantUseCaseTestSuite) TestDeactivateUser_Self() {
+	err := s.tenantUseCase.DeactivateUser(context.Background(), "tenant123", "admin123", "admin123")
+
+	s.True(pkgErrors.IsValidationError(err))
+	s.mockUserRepo.AssertNotCalled(s.T(), "UpdateStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestResetUserPassword tests that a reset sets an expired temporary password and unlocks the account
+func (s *TenantUseCaseTestSuite) TestResetUserPassword() {
+	ctx := context.Background()
+	user := models.NewUser("jane", "jane@example.com", "tenant123")
+	user.ID = "user456"
+	lockedUntil := time.Now().Add(time.Hour)
+	user.LockedUntil = &lockedUntil
+
+	s.mockUserRepo.On("GetByID", ctx, "user456", "tenant123").Return(user, nil)
+	s.mockUserRepo.On("Update", ctx, user).Return(nil)
+
+	password, err := s.tenantUseCase.ResetUserPassword(ctx, "tenant123", "admin123", "user456")
+
+	s.NoError(err)
+	match, _ := user.VerifyPassword(password)
+	s.True(match)
+	s.False(user.IsLocked(time.Now()))
+	s.True(user.IsPasswordExpired(s.tenant.PasswordPolicy(), time.Now()))
+}
+
+// TestAssignRoles_OwnAdministratorRole tests that administrators cannot remove their own administrator role
+func (s *TenantUseCaseTestSuite) TestAssignRoles_OwnAdministratorRole() {
+	_, err := s.tenantUseCase.AssignRoles(context.Background(), "tenant123", "admin123", "admin123", []string{models.RoleReader})
+
+	s.True(pkgErrors.IsValidationError(err))
+	s.mockUserRepo.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything)
+}
+
+// TestGetUsage tests that usage combines user counts with the tenant's quota
+func (s *TenantUseCaseTestSuite) TestGetUsage() {
+	ctx := context.Background()
+	quota := models.NewTenantQuota("tenant123", 10<<30, 0)
+	quota.AddUsage(5<<30, 340)
+	s.mockUserRepo.On("Count", ctx, "tenant123").Return(int64(12), nil)
+	s.mockUserRepo.On("CountByStatus", ctx, models.UserStatusActive, "tenant123").Return(int64(10), nil)
+	s.mockQuotaService.On("GetQuota", ctx, "tenant123").Return(quota, nil)
+
+	usage, err := s.tenantUseCase.GetUsage(ctx, "tenant123", "admin123")
+
+	s.NoError(err)
+	s.Equal(&models.TenantUsage{Users: 12, ActiveUsers: 10, Documents: 340, StorageBytes: 5 << 30, MaxStorageBytes: 10 << 30}, usage)
+}
+
+// TestSetUploadLimit_Role tests that role limits are set and audited
+func (s *TenantUseCaseTestSuite) TestSetUploadLimit_Role() {
+	ctx := context.Background()
+	limit := models.NewUploadLimit("tenant123", models.UploadLimitScopeRole, models.RoleReader, 10<<20, 100<<20)
+	s.mockUploadLimits.On("SetLimit", ctx, "tenant123", models.UploadLimitScopeRole, models.RoleReader, int64(10<<20), int64(100<<20)).Return(limit, nil)
+
+	result, err := s.tenantUseCase.SetUploadLimit(ctx, "tenant123", "admin123", models.UploadLimitScopeRole, models.RoleReader, 10<<20, 100<<20)
+
+	s.NoError(err)
+	s.Equal(limit, result)
+	s.mockAuditService.AssertCalled(s.T(), "RecordAction", ctx, "tenant123", "admin123", models.AuditActionUpdate, models.AuditResourceUploadLimit, "role:reader", mock.Anything, mock.Anything)
+}
+
+// TestSetUploadLimit_UnknownRole tests that limits of roles that do not exist are rejected
+func (s *TenantUseCaseTestSuite) TestSetUploadLimit_UnknownRole() {
+	ctx := context.Background()
+	s.mockRoleRepo.On("GetByName", ctx, "intern", "tenant123").Return(nil, pkgErrors.NewResourceNotFoundError("role not found"))
+
+	_, err := s.tenantUseCase.SetUploadLimit(ctx, "tenant123", "admin123", models.UploadLimitScopeRole, "intern", 1<<20, 0)
+
+	s.True(pkgErrors.IsValidationError(err))
+	s.mockUploadLimits.AssertNotCalled(s.T(), "SetLimit", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestUpdateSettings tests that settings are set, removed and audited
+func (s *TenantUseCaseTestSuite) TestUpdateSettings() {
+	ctx := context.Background()
+	s.tenant.SetSetting(models.TenantSettingPasswordHistory, "5")
+	s.mockTenantRepo.On("Update", ctx, s.tenant).Return(nil)
+
+	settings, err := s.tenantUseCase.UpdateSettings(ctx, "tenant123", "admin123", map[string]string{
+		models.TenantSettingMFARequired:     "true",
+		models.TenantSettingPasswordHistory: "",
+	})
+
+	s.NoError(err)
+	s.Equal(map[string]string{models.TenantSettingMFARequired: "true"}, settings)
+	s.mockAuditService.AssertCalled(s.T(), "RecordAction", ctx, "tenant123", "admin123", models.AuditActionUpdate, models.AuditResourceTenant, "tenant123", mock.Anything, mock.Anything)
+}
+
+// TestUpdateSettings_Invalid tests that unknown settings and invalid values are rejected
+func (s *TenantUseCaseTestSuite) TestUpdateSettings_Invalid() {
+	for _, settings := range []map[string]string{
+		{"storage_backend": "s3"},
+		{models.TenantSettingMFARequired: "yes"},
+		{models.TenantSettingLockoutThreshold: "-1"},
+		{models.TenantSettingLogoURL: "http://acme.example/logo.png"},
+		{models.TenantSettingDefaultLanguage: "english"},
+		{models.TenantSettingNotificationSender: "Acme\r\nBcc: everyone@example.com"},
+		{models.TenantSettingShareLinkExpiryHours: "2161"},
+	} {
+		_, err := s.tenantUseCase.UpdateSettings(context.Background(), "tenant123", "admin123", settings)
+		s.True(pkgErrors.IsValidationError(err))
+	}
+	s.mockTenantRepo.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything)
+}
+
+// TestUpdateSettings_EncryptionKey tests that tenants can only bring KMS keys the platform can use
+func (s *TenantUseCaseTestSuite) TestUpdateSettings_EncryptionKey() {
+	ctx := context.Background()
+	keyID := "arn:aws:kms:us-east-1:222222222222:key/tenant"
+	s.mockKeyService.On("VerifyKey", ctx, keyID).Return(pkgErrors.NewValidationError("KMS key cannot be used to encrypt documents"))
+
+	_, err := s.tenantUseCase.UpdateSettings(ctx, "tenant123", "admin123", map[string]string{
+		models.TenantSettingEncryptionKeyID: keyID,
+	})
+
+	s.True(pkgErrors.IsValidationError(err))
+	s.mockTenantRepo.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything)
+}
+
+// TestUpdateSettings_IPAllowlist tests that administrators cannot save an allowlist that leaves out
+// their own address
+func (s *TenantUseCaseTestSuite) TestUpdateSettings_IPAllowlist() {
+	ctx := services.ContextWithAuditActor(context.Background(), services.AuditActor{UserID: "admin123", IPAddress: "198.51.100.7"})
+
+	_, err := s.tenantUseCase.UpdateSettings(ctx, "tenant123", "admin123", map[string]string{
+		models.TenantSettingIPAllowlist: "203.0.113.0/24",
+	})
+	s.True(pkgErrors.IsValidationError(err))
+	s.mockTenantRepo.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything)
+
+	s.mockTenantRepo.On("Update", ctx, s.tenant).Return(nil)
+	settings, err := s.tenantUseCase.UpdateSettings(ctx, "tenant123", "admin123", map[string]string{
+		models.TenantSettingIPAllowlist: "203.0.113.0/24, 198.51.100.7",
+	})
+	s.NoError(err)
+	s.Equal("203.0.113.0/24, 198.51.100.7", settings[models.TenantSettingIPAllowlist])
+}
+
+// TestTenantUseCaseSuite runs the tenant use case test suite
+func TestTenantUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(TenantUseCaseTestSuite))
+}
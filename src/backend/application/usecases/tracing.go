@@ -0,0 +1,324 @@
+// Package usecases implements the business logic for the Document Management Platform.
+package usecases
+
+import (
+	"context" // standard library
+	"io"      // standard library
+	"time"    // standard library
+
+	"go.opentelemetry.io/otel/trace" // v1.11.0+
+
+	"../../domain/models"
+	"../../pkg/delta"
+	"../../pkg/tracing"
+	"../../pkg/utils"
+)
+
+// startUseCaseSpan starts the span of a use case operation of a tenant
+func startUseCaseSpan(ctx context.Context, name string, tenantID string) (context.Context, trace.Span) {
+	ctx, span := tracing.StartSpan(ctx, name, trace.WithSpanKind(trace.SpanKindInternal))
+	tracing.AddAttribute(span, "tenant.id", tenantID)
+	return ctx, span
+}
+
+// tracedDocumentUseCase is a DocumentUseCase recording a span for each operation of the wrapped use
+// case, so the time of a request is split between the use case and the dependencies it calls
+type tracedDocumentUseCase struct {
+	inner DocumentUseCase
+}
+
+// NewTracedDocumentUseCase wraps a DocumentUseCase to trace its operations
+func NewTracedDocumentUseCase(inner DocumentUseCase) DocumentUseCase {
+	return &tracedDocumentUseCase{inner: inner}
+}
+
+// UploadDocument traces UploadDocument of the wrapped use case
+func (u *tracedDocumentUseCase) UploadDocument(ctx context.Context, name string, contentType string, size int64, folderID string, tenantID string, userID string, content io.Reader, metadata map[string]string, priority string, checksum string) (string, error) {
+	ctx, span := startUseCaseSpan(ctx, "DocumentUseCase.UploadDocument", tenantID)
+	tracing.AddAttribute(span, "folder.id", folderID)
+	result, err := u.inner.UploadDocument(ctx, name, contentType, size, folderID, tenantID, userID, content, metadata, priority, checksum)
+	tracing.EndSpan(span, err)
+	return result, err
+}
+
+// UploadDocuments traces UploadDocuments of the wrapped use case
+func (u *tracedDocumentUseCase) UploadDocuments(ctx context.Context, uploads []DocumentUpload, folderID string, tenantID string, userID string) ([]DocumentUploadResult, error) {
+	ctx, span := startUseCaseSpan(ctx, "DocumentUseCase.UploadDocuments", tenantID)
+	tracing.AddAttribute(span, "folder.id", folderID)
+	result, err := u.inner.UploadDocuments(ctx, uploads, folderID, tenantID, userID)
+	tracing.EndSpan(span, err)
+	return result, err
+}
+
+// GetDocument traces GetDocument of the wrapped use case
+func (u *tracedDocumentUseCase) GetDocument(ctx context.Context, id string, tenantID string, userID string) (*models.Document, error) {
+	ctx, span := startUseCaseSpan(ctx, "DocumentUseCase.GetDocument", tenantID)
+	tracing.AddAttribute(span, "document.id", id)
+	result, err := u.inner.GetDocument(ctx, id, tenantID, userID)
+	tracing.EndSpan(span, err)
+	return result, err
+}
+
+// DownloadDocument traces DownloadDocument of the wrapped use case
+func (u *tracedDocumentUseCase) DownloadDocument(ctx context.Context, id string, tenantID string, userID string) (io.ReadCloser, string, error) {
+	ctx, span := startUseCaseSpan(ctx, "DocumentUseCase.DownloadDocument", tenantID)
+	tracing.AddAttribute(span, "document.id", id)
+	result, contentType, err := u.inner.DownloadDocument(ctx, id, tenantID, userID)
+	tracing.EndSpan(span, err)
+	return result, contentType, err
+}
+
+// DownloadDocumentRange traces DownloadDocumentRange of the wrapped use case
+func (u *tracedDocumentUseCase) DownloadDocumentRange(ctx context.Context, id string, tenantID string, userID string, rangeHeader string, ifRange string) (*DocumentDownload, error) {
+	ctx, span := startUseCaseSpan(ctx, "DocumentUseCase.DownloadDocumentRange", tenantID)
+	tracing.AddAttribute(span, "document.id", id)
+	result, err := u.inner.DownloadDocumentRange(ctx, id, tenantID, userID, rangeHeader, ifRange)
+	tracing.EndSpan(span, err)
+	return result, err
+}
+
+// ListDocumentVersions traces ListDocumentVersions of the wrapped use case
+func (u *tracedDocumentUseCase) ListDocumentVersions(ctx context.Context, id string, tenantID string, userID string) ([]models.DocumentVersion, error) {
+	ctx, span := startUseCaseSpan(ctx, "DocumentUseCase.ListDocumentVersions", tenantID)
+	tracing.AddAttribute(span, "document.id", id)
+	result, err := u.inner.ListDocumentVersions(ctx, id, tenantID, userID)
+	tracing.EndSpan(span, err)
+	return result, err
+}
+
+// DownloadDocumentVersion traces DownloadDocumentVersion of the wrapped use case
+func (u *tracedDocumentUseCase) DownloadDocumentVersion(ctx context.Context, id string, versionID string, tenantID string, userID string, rangeHeader string, ifRange string) (*DocumentDownload, error) {
+	ctx, span := startUseCaseSpan(ctx, "DocumentUseCase.DownloadDocumentVersion", tenantID)
+	tracing.AddAttribute(span, "document.id", id)
+	tracing.AddAttribute(span, "version.id", versionID)
+	result, err := u.inner.DownloadDocumentVersion(ctx, id, versionID, tenantID, userID, rangeHeader, ifRange)
+	tracing.EndSpan(span, err)
+	return result, err
+}
+
+// GetVersionSignature traces GetVersionSignature of the wrapped use case
+func (u *tracedDocumentUseCase) GetVersionSignature(ctx context.Context, id string, versionID string, blockSize int, tenantID string, userID string) (*delta.Signature, error) {
+	ctx, span := startUseCaseSpan(ctx, "DocumentUseCase.GetVersionSignature", tenantID)
+	tracing.AddAttribute(span, "document.id", id)
+	tracing.AddAttribute(span, "version.id", versionID)
+	result, err := u.inner.GetVersionSignature(ctx, id, versionID, blockSize, tenantID, userID)
+	tracing.EndSpan(span, err)
+	return result, err
+}
+
+// UploadVersionDelta traces UploadVersionDelta of the wrapped use case
+func (u *tracedDocumentUseCase) UploadVersionDelta(ctx context.Context, id string, baseVersionID string, size int64, checksum string, patch io.Reader, tenantID string, userID string) (*models.DocumentVersion, error) {
+	ctx, span := startUseCaseSpan(ctx, "DocumentUseCase.UploadVersionDelta", tenantID)
+	tracing.AddAttribute(span, "document.id", id)
+	tracing.AddAttribute(span, "version.base_id", baseVersionID)
+	result, err := u.inner.UploadVersionDelta(ctx, id, baseVersionID, size, checksum, patch, tenantID, userID)
+	tracing.EndSpan(span, err)
+	return result, err
+}
+
+// GetDocumentPresignedURL traces GetDocumentPresignedURL of the wrapped use case
+func (u *tracedDocumentUseCase) GetDocumentPresignedURL(ctx context.Context, id string, tenantID string, userID string, expirationSeconds int) (string, error) {
+	ctx, span := startUseCaseSpan(ctx, "DocumentUseCase.GetDocumentPresignedURL", tenantID)
+	tracing.AddAttribute(span, "document.id", id)
+	result, err := u.inner.GetDocumentPresignedURL(ctx, id, tenantID, userID, expirationSeconds)
+	tracing.EndSpan(span, err)
+	return result, err
+}
+
+// BatchDownloadDocuments traces BatchDownloadDocuments of the wrapped use case
+func (u *tracedDocumentUseCase) BatchDownloadDocuments(ctx context.Context, ids []string, tenantID string, userID string) (io.ReadCloser, error) {
+	ctx, span := startUseCaseSpan(ctx, "DocumentUseCase.BatchDownloadDocuments", tenantID)
+	result, err := u.inner.BatchDownloadDocuments(ctx, ids, tenantID, userID)
+	tracing.EndSpan(span, err)
+	return result, err
+}
+
+// GetBatchDownloadPresignedURL traces GetBatchDownloadPresignedURL of the wrapped use case
+func (u *tracedDocumentUseCase) GetBatchDownloadPresignedURL(ctx context.Context, ids []string, tenantID string, userID string, expirationSeconds int) (string, error) {
+	ctx, span := startUseCaseSpan(ctx, "DocumentUseCase.GetBatchDownloadPresignedURL", tenantID)
+	result, err := u.inner.GetBatchDownloadPresignedURL(ctx, ids, tenantID, userID, expirationSeconds)
+	tracing.EndSpan(span, err)
+	return result, err
+}
+
+// DeleteDocument traces DeleteDocument of the wrapped use case
+func (u *tracedDocumentUseCase) DeleteDocument(ctx context.Context, id string, tenantID string, userID string) error {
+	ctx, span := startUseCaseSpan(ctx, "DocumentUseCase.DeleteDocument", tenantID)
+	tracing.AddAttribute(span, "document.id", id)
+	err := u.inner.DeleteDocument(ctx, id, tenantID, userID)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+// ListDocumentsByFolder traces ListDocumentsByFolder of the wrapped use case
+func (u *tracedDocumentUseCase) ListDocumentsByFolder(ctx context.Context, folderID string, tenantID string, userID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Document], error) {
+	ctx, span := startUseCaseSpan(ctx, "DocumentUseCase.ListDocumentsByFolder", tenantID)
+	tracing.AddAttribute(span, "folder.id", folderID)
+	result, err := u.inner.ListDocumentsByFolder(ctx, folderID, tenantID, userID, pagination)
+	tracing.EndSpan(span, err)
+	return result, err
+}
+
+// SearchDocumentsByContent traces SearchDocumentsByContent of the wrapped use case
+func (u *tracedDocumentUseCase) SearchDocumentsByContent(ctx context.Context, query string, tenantID string, userID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Document], error) {
+	ctx, span := startUseCaseSpan(ctx, "DocumentUseCase.SearchDocumentsByContent", tenantID)
+	result, err := u.inner.SearchDocumentsByContent(ctx, query, tenantID, userID, pagination)
+	tracing.EndSpan(span, err)
+	return result, err
+}
+
+// SearchDocumentsByMetadata traces SearchDocumentsByMetadata of the wrapped use case
+func (u *tracedDocumentUseCase) SearchDocumentsByMetadata(ctx context.Context, metadata map[string]string, tenantID string, userID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Document], error) {
+	ctx, span := startUseCaseSpan(ctx, "DocumentUseCase.SearchDocumentsByMetadata", tenantID)
+	result, err := u.inner.SearchDocumentsByMetadata(ctx, metadata, tenantID, userID, pagination)
+	tracing.EndSpan(span, err)
+	return result, err
+}
+
+// CombinedSearch traces CombinedSearch of the wrapped use case
+func (u *tracedDocumentUseCase) CombinedSearch(ctx context.Context, contentQuery string, metadata map[string]string, tenantID string, userID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Document], error) {
+	ctx, span := startUseCaseSpan(ctx, "DocumentUseCase.CombinedSearch", tenantID)
+	result, err := u.inner.CombinedSearch(ctx, contentQuery, metadata, tenantID, userID, pagination)
+	tracing.EndSpan(span, err)
+	return result, err
+}
+
+// UpdateDocumentMetadata traces UpdateDocumentMetadata of the wrapped use case
+func (u *tracedDocumentUseCase) UpdateDocumentMetadata(ctx context.Context, id string, key string, value string, tenantID string, userID string) error {
+	ctx, span := startUseCaseSpan(ctx, "DocumentUseCase.UpdateDocumentMetadata", tenantID)
+	tracing.AddAttribute(span, "document.id", id)
+	err := u.inner.UpdateDocumentMetadata(ctx, id, key, value, tenantID, userID)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+// DeleteDocumentMetadata traces DeleteDocumentMetadata of the wrapped use case
+func (u *tracedDocumentUseCase) DeleteDocumentMetadata(ctx context.Context, id string, key string, tenantID string, userID string) error {
+	ctx, span := startUseCaseSpan(ctx, "DocumentUseCase.DeleteDocumentMetadata", tenantID)
+	tracing.AddAttribute(span, "document.id", id)
+	err := u.inner.DeleteDocumentMetadata(ctx, id, key, tenantID, userID)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+// BulkUpdateMetadata traces BulkUpdateMetadata of the wrapped use case
+func (u *tracedDocumentUseCase) BulkUpdateMetadata(ctx context.Context, documentIDs []string, patch models.MetadataPatch, atomic bool, tenantID string, userID string) ([]BulkMetadataResult, error) {
+	ctx, span := startUseCaseSpan(ctx, "DocumentUseCase.BulkUpdateMetadata", tenantID)
+	result, err := u.inner.BulkUpdateMetadata(ctx, documentIDs, patch, atomic, tenantID, userID)
+	tracing.EndSpan(span, err)
+	return result, err
+}
+
+// GetDocumentThumbnail traces GetDocumentThumbnail of the wrapped use case
+func (u *tracedDocumentUseCase) GetDocumentThumbnail(ctx context.Context, id string, tenantID string, userID string) (io.ReadCloser, error) {
+	ctx, span := startUseCaseSpan(ctx, "DocumentUseCase.GetDocumentThumbnail", tenantID)
+	tracing.AddAttribute(span, "document.id", id)
+	result, err := u.inner.GetDocumentThumbnail(ctx, id, tenantID, userID)
+	tracing.EndSpan(span, err)
+	return result, err
+}
+
+// GetDocumentThumbnailURL traces GetDocumentThumbnailURL of the wrapped use case
+func (u *tracedDocumentUseCase) GetDocumentThumbnailURL(ctx context.Context, id string, tenantID string, userID string, expirationSeconds int) (string, error) {
+	ctx, span := startUseCaseSpan(ctx, "DocumentUseCase.GetDocumentThumbnailURL", tenantID)
+	tracing.AddAttribute(span, "document.id", id)
+	result, err := u.inner.GetDocumentThumbnailURL(ctx, id, tenantID, userID, expirationSeconds)
+	tracing.EndSpan(span, err)
+	return result, err
+}
+
+// GetDocumentStatus traces GetDocumentStatus of the wrapped use case
+func (u *tracedDocumentUseCase) GetDocumentStatus(ctx context.Context, id string, tenantID string, userID string) (string, error) {
+	ctx, span := startUseCaseSpan(ctx, "DocumentUseCase.GetDocumentStatus", tenantID)
+	tracing.AddAttribute(span, "document.id", id)
+	result, err := u.inner.GetDocumentStatus(ctx, id, tenantID, userID)
+	tracing.EndSpan(span, err)
+	return result, err
+}
+
+// GetQuota traces GetQuota of the wrapped use case
+func (u *tracedDocumentUseCase) GetQuota(ctx context.Context, tenantID string) (*models.TenantQuota, error) {
+	ctx, span := startUseCaseSpan(ctx, "DocumentUseCase.GetQuota", tenantID)
+	result, err := u.inner.GetQuota(ctx, tenantID)
+	tracing.EndSpan(span, err)
+	return result, err
+}
+
+// ScheduleDocument traces ScheduleDocument of the wrapped use case
+func (u *tracedDocumentUseCase) ScheduleDocument(ctx context.Context, id string, publishAt, expireAt *time.Time, tenantID string, userID string) (*models.Document, error) {
+	ctx, span := startUseCaseSpan(ctx, "DocumentUseCase.ScheduleDocument", tenantID)
+	tracing.AddAttribute(span, "document.id", id)
+	result, err := u.inner.ScheduleDocument(ctx, id, publishAt, expireAt, tenantID, userID)
+	tracing.EndSpan(span, err)
+	return result, err
+}
+
+// VerifyIntegrity traces VerifyIntegrity of the wrapped use case
+func (u *tracedDocumentUseCase) VerifyIntegrity(ctx context.Context, id string, tenantID string, userID string) (*models.IntegrityCheck, error) {
+	ctx, span := startUseCaseSpan(ctx, "DocumentUseCase.VerifyIntegrity", tenantID)
+	tracing.AddAttribute(span, "document.id", id)
+	result, err := u.inner.VerifyIntegrity(ctx, id, tenantID, userID)
+	tracing.EndSpan(span, err)
+	return result, err
+}
+
+// tracedSearchUseCase is a SearchUseCase recording a span for each operation of the wrapped use case
+type tracedSearchUseCase struct {
+	inner SearchUseCase
+}
+
+// NewTracedSearchUseCase wraps a SearchUseCase to trace its operations
+func NewTracedSearchUseCase(inner SearchUseCase) SearchUseCase {
+	return &tracedSearchUseCase{inner: inner}
+}
+
+// SearchByContent traces SearchByContent of the wrapped use case
+func (u *tracedSearchUseCase) SearchByContent(ctx context.Context, query string, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Document], error) {
+	ctx, span := startUseCaseSpan(ctx, "SearchUseCase.SearchByContent", tenantID)
+	result, err := u.inner.SearchByContent(ctx, query, tenantID, pagination)
+	tracing.EndSpan(span, err)
+	return result, err
+}
+
+// SearchByMetadata traces SearchByMetadata of the wrapped use case
+func (u *tracedSearchUseCase) SearchByMetadata(ctx context.Context, metadata map[string]string, filters []models.MetadataFilter, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Document], error) {
+	ctx, span := startUseCaseSpan(ctx, "SearchUseCase.SearchByMetadata", tenantID)
+	result, err := u.inner.SearchByMetadata(ctx, metadata, filters, tenantID, pagination)
+	tracing.EndSpan(span, err)
+	return result, err
+}
+
+// CombinedSearch traces CombinedSearch of the wrapped use case
+func (u *tracedSearchUseCase) CombinedSearch(ctx context.Context, contentQuery string, metadata map[string]string, filters []models.MetadataFilter, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Document], error) {
+	ctx, span := startUseCaseSpan(ctx, "SearchUseCase.CombinedSearch", tenantID)
+	result, err := u.inner.CombinedSearch(ctx, contentQuery, metadata, filters, tenantID, pagination)
+	tracing.EndSpan(span, err)
+	return result, err
+}
+
+// SearchInFolder traces SearchInFolder of the wrapped use case
+func (u *tracedSearchUseCase) SearchInFolder(ctx context.Context, folderID string, query string, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Document], error) {
+	ctx, span := startUseCaseSpan(ctx, "SearchUseCase.SearchInFolder", tenantID)
+	tracing.AddAttribute(span, "folder.id", folderID)
+	result, err := u.inner.SearchInFolder(ctx, folderID, query, tenantID, pagination)
+	tracing.EndSpan(span, err)
+	return result, err
+}
+
+// IndexDocument traces IndexDocument of the wrapped use case
+func (u *tracedSearchUseCase) IndexDocument(ctx context.Context, documentID string, tenantID string, content []byte) error {
+	ctx, span := startUseCaseSpan(ctx, "SearchUseCase.IndexDocument", tenantID)
+	tracing.AddAttribute(span, "document.id", documentID)
+	err := u.inner.IndexDocument(ctx, documentID, tenantID, content)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+// RemoveDocumentFromIndex traces RemoveDocumentFromIndex of the wrapped use case
+func (u *tracedSearchUseCase) RemoveDocumentFromIndex(ctx context.Context, documentID string, tenantID string) error {
+	ctx, span := startUseCaseSpan(ctx, "SearchUseCase.RemoveDocumentFromIndex", tenantID)
+	tracing.AddAttribute(span, "document.id", documentID)
+	err := u.inner.RemoveDocumentFromIndex(ctx, documentID, tenantID)
+	tracing.EndSpan(span, err)
+	return err
+}
@@ -6,6 +6,7 @@ import (
 	"context" // standard library
 	"fmt"     // standard library
 
+	"../../domain/models"
 	"../../domain/services"
 	"../../pkg/errors"
 	"../../pkg/logger"
@@ -21,8 +22,8 @@ var (
 
 // Event type constants
 const (
-	DocumentEventScannedClean    = "document.scanned.clean"
-	DocumentEventScannedInfected = "document.scanned.infected"
+	DocumentEventScannedClean    = models.EventTypeDocumentClean
+	DocumentEventScannedInfected = models.EventTypeDocumentQuarantined
 )
 
 // VirusScanningUseCaseInterface defines the contract for virus scanning use cases.
@@ -110,8 +111,9 @@ func (uc *virusScanningUseCase) QueueDocumentForScanning(
 		return ErrInvalidStoragePath
 	}
 
-	// Call virusScanningService.QueueForScanning with the provided parameters
-	err := uc.virusScanningService.QueueForScanning(ctx, documentID, versionID, tenantID, storagePath)
+	// Call virusScanningService.QueueForScanning with the provided parameters and normal priority.
+	// The content hash is not known, so the document is always scanned.
+	err := uc.virusScanningService.QueueForScanning(ctx, documentID, versionID, tenantID, storagePath, "", services.ScanPriorityNormal)
 	if err != nil {
 		logger.WithContext(ctx).WithError(err).Error("Failed to queue document for virus scanning",
 			"document_id", documentID,
@@ -18,8 +18,8 @@ type MockVirusScanningService struct {
 	mock.Mock
 }
 
-func (m *MockVirusScanningService) QueueForScanning(ctx context.Context, documentID, versionID, tenantID, storagePath string) error {
-	args := m.Called(ctx, documentID, versionID, tenantID, storagePath)
+func (m *MockVirusScanningService) QueueForScanning(ctx context.Context, documentID, versionID, tenantID, storagePath, contentHash, priority string) error {
+	args := m.Called(ctx, documentID, versionID, tenantID, storagePath, contentHash, priority)
 	return args.Error(0)
 }
 
@@ -28,6 +28,11 @@ func (m *MockVirusScanningService) ProcessScanQueue(ctx context.Context, batchSi
 	return args.Int(0), args.Error(1)
 }
 
+func (m *MockVirusScanningService) ProcessScanTask(ctx context.Context, task services.ScanTask) error {
+	args := m.Called(ctx, task)
+	return args.Error(0)
+}
+
 func (m *MockVirusScanningService) ScanDocument(ctx context.Context, storagePath string) (string, string, error) {
 	args := m.Called(ctx, storagePath)
 	return args.String(0), args.String(1), args.Error(2)
@@ -138,7 +143,7 @@ func TestVirusScanningUseCase_QueueDocumentForScanning(t *testing.T) {
 	storagePath := "path/to/document"
 
 	ctx := context.Background()
-	mockVirusScanningService.On("QueueForScanning", ctx, documentID, versionID, tenantID, storagePath).Return(nil)
+	mockVirusScanningService.On("QueueForScanning", ctx, documentID, versionID, tenantID, storagePath, "", services.ScanPriorityNormal).Return(nil)
 
 	// Act
 	err := useCase.QueueDocumentForScanning(ctx, documentID, versionID, tenantID, storagePath)
@@ -228,7 +233,7 @@ func TestVirusScanningUseCase_QueueDocumentForScanning_ServiceError(t *testing.T
 	serviceError := errors.New("queue error")
 
 	ctx := context.Background()
-	mockVirusScanningService.On("QueueForScanning", ctx, documentID, versionID, tenantID, storagePath).Return(serviceError)
+	mockVirusScanningService.On("QueueForScanning", ctx, documentID, versionID, tenantID, storagePath, "", services.ScanPriorityNormal).Return(serviceError)
 
 	// Act
 	err := useCase.QueueDocumentForScanning(ctx, documentID, versionID, tenantID, storagePath)
@@ -457,7 +462,7 @@ func TestVirusScanningUseCase_ProcessScanResult_Clean(t *testing.T) {
 
 	ctx := context.Background()
 	mockDocumentService.On("ProcessDocumentScanResult", ctx, documentID, versionID, tenantID, isClean, scanDetails).Return(nil)
-	mockEventService.On("PublishEvent", ctx, "document.scanned.clean", mock.Anything).Return(nil)
+	mockEventService.On("PublishEvent", ctx, "document.clean", mock.Anything).Return(nil)
 
 	// Act
 	err := useCase.ProcessScanResult(ctx, documentID, versionID, tenantID, storagePath, isClean, scanDetails)
@@ -486,7 +491,7 @@ func TestVirusScanningUseCase_ProcessScanResult_Infected(t *testing.T) {
 
 	ctx := context.Background()
 	mockDocumentService.On("ProcessDocumentScanResult", ctx, documentID, versionID, tenantID, isClean, scanDetails).Return(nil)
-	mockEventService.On("PublishEvent", ctx, "document.scanned.infected", mock.Anything).Return(nil)
+	mockEventService.On("PublishEvent", ctx, "document.quarantined", mock.Anything).Return(nil)
 
 	// Act
 	err := useCase.ProcessScanResult(ctx, documentID, versionID, tenantID, storagePath, isClean, scanDetails)
@@ -613,7 +618,7 @@ func TestVirusScanningUseCase_ProcessScanResult_EventServiceError(t *testing.T)
 
 	ctx := context.Background()
 	mockDocumentService.On("ProcessDocumentScanResult", ctx, documentID, versionID, tenantID, isClean, scanDetails).Return(nil)
-	mockEventService.On("PublishEvent", ctx, "document.scanned.clean", mock.Anything).Return(serviceError)
+	mockEventService.On("PublishEvent", ctx, "document.clean", mock.Anything).Return(serviceError)
 
 	// Act
 	err := useCase.ProcessScanResult(ctx, documentID, versionID, tenantID, storagePath, isClean, scanDetails)
@@ -39,8 +39,41 @@ type WebhookUseCase interface {
 	// ListDeliveries lists delivery attempts for a webhook with pagination
 	ListDeliveries(ctx context.Context, webhookID string, tenantID string, page int, pageSize int) (utils.PaginatedResult[models.WebhookDelivery], error)
 	
+	// ListWebhookDeliveries lists a tenant's deliveries matching the filter with pagination
+	ListWebhookDeliveries(ctx context.Context, tenantID string, filter models.WebhookDeliveryFilter, page int, pageSize int) (utils.PaginatedResult[models.WebhookDelivery], error)
+	
+	// GetWebhookDelivery gets a delivery together with its attempt history
+	GetWebhookDelivery(ctx context.Context, deliveryID string, tenantID string) (*models.WebhookDelivery, []*models.WebhookDeliveryAttempt, error)
+	
 	// RetryDelivery retries a failed webhook delivery
 	RetryDelivery(ctx context.Context, deliveryID string, tenantID string) error
+	
+	// ListDeadLetteredDeliveries lists deliveries that exhausted their retries with pagination
+	ListDeadLetteredDeliveries(ctx context.Context, tenantID string, page int, pageSize int) (utils.PaginatedResult[models.WebhookDelivery], error)
+	
+	// ListDeliveryAttempts lists the attempt history for a delivery
+	ListDeliveryAttempts(ctx context.Context, deliveryID string, tenantID string) ([]*models.WebhookDeliveryAttempt, error)
+	
+	// RotateWebhookSecret replaces a webhook's signing secret and returns the new secret
+	RotateWebhookSecret(ctx context.Context, id string, tenantID string) (string, error)
+	
+	// CreateSubscription adds an event filter subscription to a webhook
+	CreateSubscription(ctx context.Context, subscription *models.WebhookSubscription) (string, error)
+	
+	// GetSubscription retrieves a subscription belonging to a webhook
+	GetSubscription(ctx context.Context, webhookID string, id string, tenantID string) (*models.WebhookSubscription, error)
+	
+	// UpdateSubscription updates an existing webhook subscription
+	UpdateSubscription(ctx context.Context, subscription *models.WebhookSubscription) error
+	
+	// DeleteSubscription deletes a subscription belonging to a webhook
+	DeleteSubscription(ctx context.Context, webhookID string, id string, tenantID string) error
+	
+	// ListSubscriptions lists the subscriptions of a webhook
+	ListSubscriptions(ctx context.Context, webhookID string, tenantID string) ([]*models.WebhookSubscription, error)
+	
+	// SendTestDelivery sends a sample payload for the event type to a webhook and returns the outcome
+	SendTestDelivery(ctx context.Context, webhookID string, tenantID string, eventType string) (*models.WebhookDeliveryAttempt, error)
 }
 
 // webhookUseCase implements the WebhookUseCase interface
@@ -229,6 +262,48 @@ func (u *webhookUseCase) ListDeliveries(ctx context.Context, webhookID string, t
 	return result, nil
 }
 
+// ListWebhookDeliveries lists a tenant's deliveries matching the filter with pagination
+func (u *webhookUseCase) ListWebhookDeliveries(ctx context.Context, tenantID string, filter models.WebhookDeliveryFilter, page int, pageSize int) (utils.PaginatedResult[models.WebhookDelivery], error) {
+	log := logger.WithContext(ctx)
+	
+	if tenantID == "" {
+		log.Error("tenant ID cannot be empty")
+		return utils.PaginatedResult[models.WebhookDelivery]{}, errors.NewValidationError("tenant ID is required")
+	}
+	
+	pagination := utils.NewPagination(page, pageSize)
+	
+	result, err := u.webhookService.ListWebhookDeliveries(ctx, tenantID, filter, pagination)
+	if err != nil {
+		log.WithError(err).Error("failed to list webhook deliveries", "tenantID", tenantID, "webhookID", filter.WebhookID, "status", filter.Status)
+		return utils.PaginatedResult[models.WebhookDelivery]{}, errors.Wrap(err, "failed to list webhook deliveries")
+	}
+	
+	log.Info("webhook deliveries listed successfully", "tenantID", tenantID, "count", len(result.Items))
+	return result, nil
+}
+
+// GetWebhookDelivery gets a delivery together with its attempt history
+func (u *webhookUseCase) GetWebhookDelivery(ctx context.Context, deliveryID string, tenantID string) (*models.WebhookDelivery, []*models.WebhookDeliveryAttempt, error) {
+	log := logger.WithContext(ctx)
+	
+	if err := u.validateInput(map[string]string{
+		"delivery ID": deliveryID,
+		"tenant ID": tenantID,
+	}); err != nil {
+		return nil, nil, err
+	}
+	
+	delivery, attempts, err := u.webhookService.GetWebhookDelivery(ctx, deliveryID, tenantID)
+	if err != nil {
+		log.WithError(err).Error("failed to get webhook delivery", "deliveryID", deliveryID, "tenantID", tenantID)
+		return nil, nil, errors.Wrap(err, "failed to get webhook delivery")
+	}
+	
+	log.Info("webhook delivery retrieved successfully", "deliveryID", deliveryID, "attempts", len(attempts))
+	return delivery, attempts, nil
+}
+
 // RetryDelivery retries a failed webhook delivery
 func (u *webhookUseCase) RetryDelivery(ctx context.Context, deliveryID string, tenantID string) error {
 	log := logger.WithContext(ctx)
@@ -250,6 +325,196 @@ func (u *webhookUseCase) RetryDelivery(ctx context.Context, deliveryID string, t
 	return nil
 }
 
+// ListDeadLetteredDeliveries lists deliveries that exhausted their retries with pagination
+func (u *webhookUseCase) ListDeadLetteredDeliveries(ctx context.Context, tenantID string, page int, pageSize int) (utils.PaginatedResult[models.WebhookDelivery], error) {
+	log := logger.WithContext(ctx)
+	
+	if tenantID == "" {
+		log.Error("tenant ID cannot be empty")
+		return utils.PaginatedResult[models.WebhookDelivery]{}, errors.NewValidationError("tenant ID is required")
+	}
+	
+	pagination := utils.NewPagination(page, pageSize)
+	
+	result, err := u.webhookService.ListDeadLetteredDeliveries(ctx, tenantID, pagination)
+	if err != nil {
+		log.WithError(err).Error("failed to list dead-lettered deliveries", "tenantID", tenantID)
+		return utils.PaginatedResult[models.WebhookDelivery]{}, errors.Wrap(err, "failed to list dead-lettered deliveries")
+	}
+	
+	log.Info("dead-lettered deliveries listed successfully", "tenantID", tenantID, "count", len(result.Items))
+	return result, nil
+}
+
+// ListDeliveryAttempts lists the attempt history for a delivery
+func (u *webhookUseCase) ListDeliveryAttempts(ctx context.Context, deliveryID string, tenantID string) ([]*models.WebhookDeliveryAttempt, error) {
+	log := logger.WithContext(ctx)
+	
+	if err := u.validateInput(map[string]string{
+		"delivery ID": deliveryID,
+		"tenant ID": tenantID,
+	}); err != nil {
+		return nil, err
+	}
+	
+	attempts, err := u.webhookService.ListDeliveryAttempts(ctx, deliveryID, tenantID)
+	if err != nil {
+		log.WithError(err).Error("failed to list delivery attempts", "deliveryID", deliveryID, "tenantID", tenantID)
+		return nil, errors.Wrap(err, "failed to list delivery attempts")
+	}
+	
+	log.Info("delivery attempts listed successfully", "deliveryID", deliveryID, "count", len(attempts))
+	return attempts, nil
+}
+
+// RotateWebhookSecret replaces a webhook's signing secret and returns the new secret
+func (u *webhookUseCase) RotateWebhookSecret(ctx context.Context, id string, tenantID string) (string, error) {
+	log := logger.WithContext(ctx)
+	
+	if err := u.validateInput(map[string]string{
+		"webhook ID": id,
+		"tenant ID": tenantID,
+	}); err != nil {
+		return "", err
+	}
+	
+	secret, err := u.webhookService.RotateWebhookSecret(ctx, id, tenantID)
+	if err != nil {
+		log.WithError(err).Error("failed to rotate webhook secret", "id", id, "tenantID", tenantID)
+		return "", errors.Wrap(err, "failed to rotate webhook secret")
+	}
+	
+	log.Info("webhook secret rotated successfully", "id", id)
+	return secret, nil
+}
+
+// CreateSubscription adds an event filter subscription to a webhook
+func (u *webhookUseCase) CreateSubscription(ctx context.Context, subscription *models.WebhookSubscription) (string, error) {
+	log := logger.WithContext(ctx)
+	
+	if subscription == nil {
+		log.Error("subscription cannot be nil")
+		return "", errors.NewValidationError("subscription cannot be nil")
+	}
+	
+	id, err := u.webhookService.CreateSubscription(ctx, subscription)
+	if err != nil {
+		log.WithError(err).Error("failed to create webhook subscription", "webhookID", subscription.WebhookID)
+		return "", errors.Wrap(err, "failed to create webhook subscription")
+	}
+	
+	log.Info("webhook subscription created successfully", "id", id, "webhookID", subscription.WebhookID)
+	return id, nil
+}
+
+// GetSubscription retrieves a subscription belonging to a webhook
+func (u *webhookUseCase) GetSubscription(ctx context.Context, webhookID string, id string, tenantID string) (*models.WebhookSubscription, error) {
+	log := logger.WithContext(ctx)
+	
+	if err := u.validateInput(map[string]string{
+		"webhook ID": webhookID,
+		"subscription ID": id,
+		"tenant ID": tenantID,
+	}); err != nil {
+		return nil, err
+	}
+	
+	subscription, err := u.webhookService.GetSubscription(ctx, id, tenantID)
+	if err != nil {
+		log.WithError(err).Error("failed to get webhook subscription", "id", id, "tenantID", tenantID)
+		return nil, errors.Wrap(err, "failed to get webhook subscription")
+	}
+	
+	// Subscriptions are addressed through their webhook, so a mismatch is reported as not found
+	if subscription.WebhookID != webhookID {
+		log.Error("webhook subscription does not belong to webhook", "id", id, "webhookID", webhookID)
+		return nil, errors.NewResourceNotFoundError("webhook subscription not found")
+	}
+	
+	log.Info("webhook subscription retrieved successfully", "id", id)
+	return subscription, nil
+}
+
+// UpdateSubscription updates an existing webhook subscription
+func (u *webhookUseCase) UpdateSubscription(ctx context.Context, subscription *models.WebhookSubscription) error {
+	log := logger.WithContext(ctx)
+	
+	if subscription == nil {
+		log.Error("subscription cannot be nil")
+		return errors.NewValidationError("subscription cannot be nil")
+	}
+	
+	err := u.webhookService.UpdateSubscription(ctx, subscription)
+	if err != nil {
+		log.WithError(err).Error("failed to update webhook subscription", "id", subscription.ID)
+		return errors.Wrap(err, "failed to update webhook subscription")
+	}
+	
+	log.Info("webhook subscription updated successfully", "id", subscription.ID)
+	return nil
+}
+
+// DeleteSubscription deletes a subscription belonging to a webhook
+func (u *webhookUseCase) DeleteSubscription(ctx context.Context, webhookID string, id string, tenantID string) error {
+	log := logger.WithContext(ctx)
+	
+	if _, err := u.GetSubscription(ctx, webhookID, id, tenantID); err != nil {
+		return err
+	}
+	
+	err := u.webhookService.DeleteSubscription(ctx, id, tenantID)
+	if err != nil {
+		log.WithError(err).Error("failed to delete webhook subscription", "id", id, "tenantID", tenantID)
+		return errors.Wrap(err, "failed to delete webhook subscription")
+	}
+	
+	log.Info("webhook subscription deleted successfully", "id", id)
+	return nil
+}
+
+// ListSubscriptions lists the subscriptions of a webhook
+func (u *webhookUseCase) ListSubscriptions(ctx context.Context, webhookID string, tenantID string) ([]*models.WebhookSubscription, error) {
+	log := logger.WithContext(ctx)
+	
+	if err := u.validateInput(map[string]string{
+		"webhook ID": webhookID,
+		"tenant ID": tenantID,
+	}); err != nil {
+		return nil, err
+	}
+	
+	subscriptions, err := u.webhookService.ListSubscriptions(ctx, webhookID, tenantID)
+	if err != nil {
+		log.WithError(err).Error("failed to list webhook subscriptions", "webhookID", webhookID, "tenantID", tenantID)
+		return nil, errors.Wrap(err, "failed to list webhook subscriptions")
+	}
+	
+	log.Info("webhook subscriptions listed successfully", "webhookID", webhookID, "count", len(subscriptions))
+	return subscriptions, nil
+}
+
+// SendTestDelivery sends a sample payload for the event type to a webhook and returns the outcome
+func (u *webhookUseCase) SendTestDelivery(ctx context.Context, webhookID string, tenantID string, eventType string) (*models.WebhookDeliveryAttempt, error) {
+	log := logger.WithContext(ctx)
+	
+	if err := u.validateInput(map[string]string{
+		"webhook ID": webhookID,
+		"tenant ID": tenantID,
+		"event type": eventType,
+	}); err != nil {
+		return nil, err
+	}
+	
+	attempt, err := u.webhookService.SendTestDelivery(ctx, webhookID, tenantID, eventType)
+	if err != nil {
+		log.WithError(err).Error("failed to send webhook test delivery", "webhookID", webhookID, "eventType", eventType)
+		return nil, errors.Wrap(err, "failed to send webhook test delivery")
+	}
+	
+	log.Info("webhook test delivery sent", "webhookID", webhookID, "status", attempt.ResponseStatus)
+	return attempt, nil
+}
+
 // validateInput validates input parameters
 func (u *webhookUseCase) validateInput(params map[string]string) error {
 	for name, value := range params {
@@ -10,6 +10,8 @@ import (
 	"github.com/stretchr/testify/suite"  // v1.8.0+
 
 	"../../domain/models"
+	"../../domain/repositories"
+	"../../domain/services"
 	pkgErrors "../../pkg/errors"
 	"../../pkg/utils"
 )
@@ -96,6 +98,87 @@ func (m *MockWebhookService) RetryFailedDeliveries(ctx context.Context, batchSiz
 	return args.Int(0), args.Error(1)
 }
 
+// ListDeadLetteredDeliveries mock implementation for listing dead-lettered deliveries
+func (m *MockWebhookService) ListDeadLetteredDeliveries(ctx context.Context, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.WebhookDelivery], error) {
+	args := m.Called(ctx, tenantID, pagination)
+	return args.Get(0).(utils.PaginatedResult[models.WebhookDelivery]), args.Error(1)
+}
+
+// ListDeliveryAttempts mock implementation for listing delivery attempts
+func (m *MockWebhookService) ListDeliveryAttempts(ctx context.Context, deliveryID string, tenantID string) ([]*models.WebhookDeliveryAttempt, error) {
+	args := m.Called(ctx, deliveryID, tenantID)
+	if attempts := args.Get(0); attempts != nil {
+		return attempts.([]*models.WebhookDeliveryAttempt), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// RotateWebhookSecret mock implementation for rotating a webhook secret
+func (m *MockWebhookService) RotateWebhookSecret(ctx context.Context, id string, tenantID string) (string, error) {
+	args := m.Called(ctx, id, tenantID)
+	return args.String(0), args.Error(1)
+}
+
+// ListWebhookDeliveries mock implementation for listing deliveries by filter
+func (m *MockWebhookService) ListWebhookDeliveries(ctx context.Context, tenantID string, filter models.WebhookDeliveryFilter, pagination *utils.Pagination) (utils.PaginatedResult[models.WebhookDelivery], error) {
+	args := m.Called(ctx, tenantID, filter, pagination)
+	return args.Get(0).(utils.PaginatedResult[models.WebhookDelivery]), args.Error(1)
+}
+
+// GetWebhookDelivery mock implementation for retrieving a delivery with its attempts
+func (m *MockWebhookService) GetWebhookDelivery(ctx context.Context, deliveryID string, tenantID string) (*models.WebhookDelivery, []*models.WebhookDeliveryAttempt, error) {
+	args := m.Called(ctx, deliveryID, tenantID)
+	if delivery := args.Get(0); delivery != nil {
+		return delivery.(*models.WebhookDelivery), args.Get(1).([]*models.WebhookDeliveryAttempt), args.Error(2)
+	}
+	return nil, nil, args.Error(2)
+}
+
+// CreateSubscription mock implementation for creating a webhook subscription
+func (m *MockWebhookService) CreateSubscription(ctx context.Context, subscription *models.WebhookSubscription) (string, error) {
+	args := m.Called(ctx, subscription)
+	return args.String(0), args.Error(1)
+}
+
+// GetSubscription mock implementation for retrieving a webhook subscription
+func (m *MockWebhookService) GetSubscription(ctx context.Context, id string, tenantID string) (*models.WebhookSubscription, error) {
+	args := m.Called(ctx, id, tenantID)
+	if subscription := args.Get(0); subscription != nil {
+		return subscription.(*models.WebhookSubscription), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// UpdateSubscription mock implementation for updating a webhook subscription
+func (m *MockWebhookService) UpdateSubscription(ctx context.Context, subscription *models.WebhookSubscription) error {
+	args := m.Called(ctx, subscription)
+	return args.Error(0)
+}
+
+// DeleteSubscription mock implementation for deleting a webhook subscription
+func (m *MockWebhookService) DeleteSubscription(ctx context.Context, id string, tenantID string) error {
+	args := m.Called(ctx, id, tenantID)
+	return args.Error(0)
+}
+
+// ListSubscriptions mock implementation for listing webhook subscriptions
+func (m *MockWebhookService) ListSubscriptions(ctx context.Context, webhookID string, tenantID string) ([]*models.WebhookSubscription, error) {
+	args := m.Called(ctx, webhookID, tenantID)
+	if subscriptions := args.Get(0); subscriptions != nil {
+		return subscriptions.([]*models.WebhookSubscription), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// SendTestDelivery mock implementation for sending a test delivery
+func (m *MockWebhookService) SendTestDelivery(ctx context.Context, webhookID string, tenantID string, eventType string) (*models.WebhookDeliveryAttempt, error) {
+	args := m.Called(ctx, webhookID, tenantID, eventType)
+	if attempt := args.Get(0); attempt != nil {
+		return attempt.(*models.WebhookDeliveryAttempt), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
 // MockEventService is a mock implementation of the EventServiceInterface for testing
 type MockEventService struct {
 	mock.Mock
@@ -706,6 +789,245 @@ func (s *WebhookUseCaseTestSuite) TestRetryDelivery_ServiceError() {
 	s.mockWebhookService.AssertExpectations(s.T())
 }
 
+// TestListDeadLetteredDeliveries_Success tests successful dead-lettered delivery listing
+func (s *WebhookUseCaseTestSuite) TestListDeadLetteredDeliveries_Success() {
+	deliveries := []models.WebhookDelivery{
+		{
+			ID:           "delivery123",
+			WebhookID:    "webhook123",
+			EventID:      "event123",
+			Status:       models.WebhookDeliveryStatusDeadLettered,
+			AttemptCount: 5,
+		},
+	}
+	expectedResult := utils.PaginatedResult[models.WebhookDelivery]{Items: deliveries}
+
+	s.mockWebhookService.On("ListDeadLetteredDeliveries", mock.Anything, "tenant123", mock.AnythingOfType("*utils.Pagination")).Return(expectedResult, nil)
+
+	result, err := s.webhookUseCase.ListDeadLetteredDeliveries(context.Background(), "tenant123", 1, 10)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), expectedResult, result)
+	s.mockWebhookService.AssertExpectations(s.T())
+}
+
+// TestListDeadLetteredDeliveries_ValidationError tests dead-lettered delivery listing with validation error
+func (s *WebhookUseCaseTestSuite) TestListDeadLetteredDeliveries_ValidationError() {
+	result, err := s.webhookUseCase.ListDeadLetteredDeliveries(context.Background(), "", 1, 10)
+
+	assert.Equal(s.T(), utils.PaginatedResult[models.WebhookDelivery]{}, result)
+	assert.NotNil(s.T(), err)
+	assert.True(s.T(), pkgErrors.IsValidationError(err))
+	s.mockWebhookService.AssertNotCalled(s.T(), "ListDeadLetteredDeliveries")
+}
+
+// TestListDeliveryAttempts_Success tests successful delivery attempt listing
+func (s *WebhookUseCaseTestSuite) TestListDeliveryAttempts_Success() {
+	attempts := []*models.WebhookDeliveryAttempt{
+		{ID: "attempt1", DeliveryID: "delivery123", AttemptNumber: 1, ResponseStatus: 500},
+		{ID: "attempt2", DeliveryID: "delivery123", AttemptNumber: 2, ResponseStatus: 200},
+	}
+
+	s.mockWebhookService.On("ListDeliveryAttempts", mock.Anything, "delivery123", "tenant123").Return(attempts, nil)
+
+	result, err := s.webhookUseCase.ListDeliveryAttempts(context.Background(), "delivery123", "tenant123")
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), attempts, result)
+	s.mockWebhookService.AssertExpectations(s.T())
+}
+
+// TestListDeliveryAttempts_ValidationError tests delivery attempt listing with validation error
+func (s *WebhookUseCaseTestSuite) TestListDeliveryAttempts_ValidationError() {
+	result, err := s.webhookUseCase.ListDeliveryAttempts(context.Background(), "", "tenant123")
+	assert.Nil(s.T(), result)
+	assert.NotNil(s.T(), err)
+	assert.True(s.T(), pkgErrors.IsValidationError(err))
+
+	s.mockWebhookService.AssertNotCalled(s.T(), "ListDeliveryAttempts")
+}
+
+// TestRotateWebhookSecret_Success tests successful webhook secret rotation
+func (s *WebhookUseCaseTestSuite) TestRotateWebhookSecret_Success() {
+	s.mockWebhookService.On("RotateWebhookSecret", mock.Anything, "webhook123", "tenant123").Return("whsec_new", nil)
+
+	secret, err := s.webhookUseCase.RotateWebhookSecret(context.Background(), "webhook123", "tenant123")
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), "whsec_new", secret)
+	s.mockWebhookService.AssertExpectations(s.T())
+}
+
+// TestRotateWebhookSecret_ValidationError tests webhook secret rotation with validation error
+func (s *WebhookUseCaseTestSuite) TestRotateWebhookSecret_ValidationError() {
+	secret, err := s.webhookUseCase.RotateWebhookSecret(context.Background(), "webhook123", "")
+	assert.Empty(s.T(), secret)
+	assert.NotNil(s.T(), err)
+	assert.True(s.T(), pkgErrors.IsValidationError(err))
+
+	s.mockWebhookService.AssertNotCalled(s.T(), "RotateWebhookSecret")
+}
+
+// TestListWebhookDeliveries_Success tests successful delivery log listing with a filter
+func (s *WebhookUseCaseTestSuite) TestListWebhookDeliveries_Success() {
+	filter := models.WebhookDeliveryFilter{WebhookID: "webhook123", Status: models.WebhookDeliveryStatusFailed}
+	expected := utils.PaginatedResult[models.WebhookDelivery]{
+		Items: []models.WebhookDelivery{{ID: "delivery1", WebhookID: "webhook123", Status: models.WebhookDeliveryStatusFailed}},
+	}
+	s.mockWebhookService.On("ListWebhookDeliveries", mock.Anything, "tenant123", filter, mock.AnythingOfType("*utils.Pagination")).Return(expected, nil)
+
+	result, err := s.webhookUseCase.ListWebhookDeliveries(context.Background(), "tenant123", filter, 1, 20)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), expected, result)
+	s.mockWebhookService.AssertExpectations(s.T())
+}
+
+// TestListWebhookDeliveries_ValidationError tests delivery log listing without a tenant
+func (s *WebhookUseCaseTestSuite) TestListWebhookDeliveries_ValidationError() {
+	_, err := s.webhookUseCase.ListWebhookDeliveries(context.Background(), "", models.WebhookDeliveryFilter{}, 1, 20)
+	assert.NotNil(s.T(), err)
+	assert.True(s.T(), pkgErrors.IsValidationError(err))
+
+	s.mockWebhookService.AssertNotCalled(s.T(), "ListWebhookDeliveries")
+}
+
+// TestGetWebhookDelivery_Success tests successful retrieval of a delivery with its attempts
+func (s *WebhookUseCaseTestSuite) TestGetWebhookDelivery_Success() {
+	delivery := &models.WebhookDelivery{ID: "delivery123", WebhookID: "webhook123"}
+	attempts := []*models.WebhookDeliveryAttempt{{ID: "attempt1", DeliveryID: "delivery123", AttemptNumber: 1}}
+	s.mockWebhookService.On("GetWebhookDelivery", mock.Anything, "delivery123", "tenant123").Return(delivery, attempts, nil)
+
+	resultDelivery, resultAttempts, err := s.webhookUseCase.GetWebhookDelivery(context.Background(), "delivery123", "tenant123")
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), delivery, resultDelivery)
+	assert.Equal(s.T(), attempts, resultAttempts)
+	s.mockWebhookService.AssertExpectations(s.T())
+}
+
+// TestGetSubscription_Success tests successful webhook subscription retrieval
+func (s *WebhookUseCaseTestSuite) TestGetSubscription_Success() {
+	subscription := &models.WebhookSubscription{ID: "sub123", WebhookID: "webhook123", TenantID: "tenant123"}
+	s.mockWebhookService.On("GetSubscription", mock.Anything, "sub123", "tenant123").Return(subscription, nil)
+
+	result, err := s.webhookUseCase.GetSubscription(context.Background(), "webhook123", "sub123", "tenant123")
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), subscription, result)
+	s.mockWebhookService.AssertExpectations(s.T())
+}
+
+// TestGetSubscription_WrongWebhook tests that a subscription of another webhook is reported as not found
+func (s *WebhookUseCaseTestSuite) TestGetSubscription_WrongWebhook() {
+	subscription := &models.WebhookSubscription{ID: "sub123", WebhookID: "webhook456", TenantID: "tenant123"}
+	s.mockWebhookService.On("GetSubscription", mock.Anything, "sub123", "tenant123").Return(subscription, nil)
+
+	result, err := s.webhookUseCase.GetSubscription(context.Background(), "webhook123", "sub123", "tenant123")
+
+	assert.Nil(s.T(), result)
+	assert.NotNil(s.T(), err)
+	assert.True(s.T(), pkgErrors.IsResourceNotFoundError(err))
+}
+
+// TestDeleteSubscription_WrongWebhook tests that a subscription of another webhook is not deleted
+func (s *WebhookUseCaseTestSuite) TestDeleteSubscription_WrongWebhook() {
+	subscription := &models.WebhookSubscription{ID: "sub123", WebhookID: "webhook456", TenantID: "tenant123"}
+	s.mockWebhookService.On("GetSubscription", mock.Anything, "sub123", "tenant123").Return(subscription, nil)
+
+	err := s.webhookUseCase.DeleteSubscription(context.Background(), "webhook123", "sub123", "tenant123")
+
+	assert.NotNil(s.T(), err)
+	assert.True(s.T(), pkgErrors.IsResourceNotFoundError(err))
+	s.mockWebhookService.AssertNotCalled(s.T(), "DeleteSubscription")
+}
+
+// TestListSubscriptions_Success tests successful webhook subscription listing
+func (s *WebhookUseCaseTestSuite) TestListSubscriptions_Success() {
+	subscriptions := []*models.WebhookSubscription{
+		{ID: "sub1", WebhookID: "webhook123", TenantID: "tenant123", FolderIDs: []string{"folder1"}},
+	}
+	s.mockWebhookService.On("ListSubscriptions", mock.Anything, "webhook123", "tenant123").Return(subscriptions, nil)
+
+	result, err := s.webhookUseCase.ListSubscriptions(context.Background(), "webhook123", "tenant123")
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), subscriptions, result)
+	s.mockWebhookService.AssertExpectations(s.T())
+}
+
+// TestSendTestDelivery_Success tests successful webhook test delivery
+func (s *WebhookUseCaseTestSuite) TestSendTestDelivery_Success() {
+	attempt := &models.WebhookDeliveryAttempt{AttemptNumber: 1, ResponseStatus: 200}
+	s.mockWebhookService.On("SendTestDelivery", mock.Anything, "webhook123", "tenant123", models.EventTypeDocumentUploaded).Return(attempt, nil)
+
+	result, err := s.webhookUseCase.SendTestDelivery(context.Background(), "webhook123", "tenant123", models.EventTypeDocumentUploaded)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), attempt, result)
+	s.mockWebhookService.AssertExpectations(s.T())
+}
+
+// TestSendTestDelivery_ValidationError tests webhook test delivery with validation error
+func (s *WebhookUseCaseTestSuite) TestSendTestDelivery_ValidationError() {
+	result, err := s.webhookUseCase.SendTestDelivery(context.Background(), "webhook123", "tenant123", "")
+	assert.Nil(s.T(), result)
+	assert.NotNil(s.T(), err)
+	assert.True(s.T(), pkgErrors.IsValidationError(err))
+
+	s.mockWebhookService.AssertNotCalled(s.T(), "SendTestDelivery")
+}
+
+// fakeDeliveryLogRepository stores a webhook and its deliveries for the webhook service
+type fakeDeliveryLogRepository struct {
+	repositories.WebhookRepository
+	webhook    *models.Webhook
+	deliveries []models.WebhookDelivery
+}
+
+func (r *fakeDeliveryLogRepository) GetByID(ctx context.Context, id string, tenantID string) (*models.Webhook, error) {
+	if id != r.webhook.ID || tenantID != r.webhook.TenantID {
+		return nil, pkgErrors.NewResourceNotFoundError("webhook not found")
+	}
+	return r.webhook, nil
+}
+
+func (r *fakeDeliveryLogRepository) GetDeliveryByID(ctx context.Context, id string, tenantID string) (*models.WebhookDelivery, error) {
+	for i := range r.deliveries {
+		if r.deliveries[i].ID == id {
+			return &r.deliveries[i], nil
+		}
+	}
+	return nil, pkgErrors.NewResourceNotFoundError("delivery not found")
+}
+
+func (r *fakeDeliveryLogRepository) ListDeliveriesByFilter(ctx context.Context, tenantID string, filter models.WebhookDeliveryFilter, pagination *utils.Pagination) (utils.PaginatedResult[models.WebhookDelivery], error) {
+	return utils.NewPaginatedResult(r.deliveries, pagination, int64(len(r.deliveries))), nil
+}
+
+// TestDeliveryLogOnWebhookService tests the delivery log and redelivery through the webhook service
+// the API builds the use case on
+func (s *WebhookUseCaseTestSuite) TestDeliveryLogOnWebhookService() {
+	repo := &fakeDeliveryLogRepository{
+		webhook: &models.Webhook{ID: "webhook123", TenantID: "tenant123"},
+		deliveries: []models.WebhookDelivery{
+			{ID: "delivery123", WebhookID: "webhook123", Status: models.WebhookDeliveryStatusPending},
+		},
+	}
+	webhookService, err := services.NewWebhookService(repo, nil)
+	assert.Nil(s.T(), err)
+	useCase, err := NewWebhookUseCase(webhookService, s.mockEventService)
+	assert.Nil(s.T(), err)
+
+	result, err := useCase.ListWebhookDeliveries(context.Background(), "tenant123", models.WebhookDeliveryFilter{WebhookID: "webhook123"}, 1, 10)
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), repo.deliveries, result.Items)
+
+	// Pending deliveries are still being retried and cannot be redelivered by hand
+	err = useCase.RetryDelivery(context.Background(), "delivery123", "tenant123")
+	assert.True(s.T(), pkgErrors.IsValidationError(err))
+}
+
 // TestWebhookUseCaseSuite entry point for running the WebhookUseCase test suite
 func TestWebhookUseCaseSuite(t *testing.T) {
 	suite.Run(t, new(WebhookUseCaseTestSuite))
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt" // standard library
+
+	"src/backend/api/handlers"                         // For the health checkers of the health endpoints
+	"src/backend/domain/services"                      // For the dependency probes of the infrastructure clients
+	"src/backend/infrastructure/persistence/postgres"  // For pinging the database
+	"src/backend/infrastructure/virus_scanning/clamav" // For pinging the ClamAV daemon
+	"src/backend/pkg/config"                           // For the ClamAV address
+)
+
+// newHealthCheckers creates the health checkers of the dependencies probed by /healthz and /readyz.
+// The storage provider, search indexer and scan queue are probed when their implementation supports
+// it, such as S3, Elasticsearch and SQS; ClamAV is probed when an address is configured.
+func newHealthCheckers(cfg config.Config, storageProvider, searchIndexer, scanQueue interface{}) (map[string]handlers.HealthChecker, error) {
+	checkers := map[string]handlers.HealthChecker{
+		"database": handlers.NewProbeHealthChecker(services.DependencyProbeFunc(postgres.Ping)),
+	}
+
+	for name, dependency := range map[string]interface{}{
+		"storage": storageProvider,
+		"search":  searchIndexer,
+		"queue":   scanQueue,
+	} {
+		if probe, ok := dependency.(services.DependencyProbe); ok {
+			checkers[name] = handlers.NewProbeHealthChecker(probe)
+		}
+	}
+
+	if cfg.ClamAV.Host != "" {
+		clamAVClient, err := clamav.NewClamAVClient(fmt.Sprintf("%s:%d", cfg.ClamAV.Host, cfg.ClamAV.Port))
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize ClamAV client: %w", err)
+		}
+		checkers["virus_scanner"] = handlers.NewProbeHealthChecker(clamAVClient)
+	}
+
+	return checkers, nil
+}
@@ -5,21 +5,38 @@ import (
 	"context" // standard library
 	"fmt"     // standard library
 	"log"     // standard library
+	"net"      // standard library
 	"net/http" // standard library
 	"os"      // standard library
 	"os/signal" // standard library
 	"syscall"   // standard library
 	"time"      // standard library
 
+	"google.golang.org/grpc" // v1.53.0+
+
+	"src/backend/api/grpcapi" // For the gRPC API served next to the REST API
 	"src/backend/api/router" // For setting up API routes
 	"src/backend/application/usecases" // For document use case implementation
+	"src/backend/domain/repositories" // For the shared state repositories of the API instances
+	"src/backend/domain/services" // For audit service
 	"src/backend/infrastructure/auth/jwt" // For JWT authentication
+	"src/backend/infrastructure/auth/oidc" // For single sign-on through external identity providers
+	memorycache "src/backend/infrastructure/cache/memory" // For the shared state of a single development API instance
+	"src/backend/infrastructure/cache/redis" // For the token revocation list, rate limit buckets, idempotency keys and scan verdicts
+	"src/backend/infrastructure/email/smtp" // For guest and user invitation emails
+	"src/backend/infrastructure/encryption/kms" // For per-tenant KMS keys encrypting document content
+	"src/backend/infrastructure/geoip/maxmind" // For the countries of client addresses in tenant network policies
+	messaging "src/backend/infrastructure/messaging/providers" // For the scan queue of the configured message bus
 	"src/backend/infrastructure/persistence/postgres" // For database connection and management
-	"src/backend/infrastructure/search/elasticsearch" // For Elasticsearch connection and search functionality
-	"src/backend/infrastructure/storage/s3" // For S3 document storage
+	"src/backend/infrastructure/rendering/pdf" // For watermarking downloaded PDFs
+	searchproviders "src/backend/infrastructure/search/providers" // For the configured search index
+	"src/backend/infrastructure/storage" // For document storage
+	"src/backend/infrastructure/storage/providers" // For the configured storage provider
+	"src/backend/infrastructure/virus_scanning/clamav" // For the scan workers of the in-process scan queue
 	"src/backend/pkg/config" // For loading and accessing application configuration
 	"src/backend/pkg/logger" // For application logging
 	"src/backend/pkg/metrics" // For application metrics collection
+	"src/backend/pkg/tracing" // For distributed tracing of requests
 	documentrepo "src/backend/infrastructure/persistence/postgres"
 	folderrepo "src/backend/infrastructure/persistence/postgres"
 	searchusecase "src/backend/application/usecases"
@@ -45,10 +62,16 @@ func main() {
 	defer logger.Shutdown()
 
 	// Initialize metrics collection using metrics.Init
-	if err := metrics.Init(cfg.Metrics); err != nil {
+	if err := metrics.Init(metrics.NewMetricsConfigFromConfig(cfg.Metrics)); err != nil {
 		logger.Error("Failed to initialize metrics", "error", err)
 	}
 
+	// Initialize tracing of requests through the API, exported to the configured collector
+	if err := tracing.Init(tracing.NewTracingConfigFromConfig(cfg.Tracing, cfg.Env, "api")); err != nil {
+		logger.Error("Failed to initialize tracing", "error", err)
+	}
+	defer tracing.Shutdown()
+
 	// Initialize database connection using db.Init
 	if err := postgres.Init(cfg.Database); err != nil {
 		logger.Error("Failed to initialize database", "error", err)
@@ -56,40 +79,26 @@ func main() {
 	}
 	defer postgres.Close()
 
-	// Run database migrations using db.Migrate for all domain models
-	if err := postgres.Migrate(
-		&models.Document{},
-		&models.DocumentMetadata{},
-		&models.DocumentVersion{},
-		&models.Folder{},
-		&models.Permission{},
-		&models.Tag{},
-		&models.Tenant{},
-		&models.User{},
-		&models.Webhook{},
-		&models.WebhookDelivery{},
-	); err != nil {
-		logger.Error("Failed to run database migrations", "error", err)
+	// Check the schema is at the version of the embedded migrations, applying them when configured
+	if err := postgres.CheckMigrations(context.Background(), cfg.Database.MigrateOnStart); err != nil {
+		logger.Error("Failed to check database migrations", "error", err)
 		os.Exit(1)
 	}
 
-	// Initialize Elasticsearch client using es.NewElasticsearchClient
-	esClient, err := elasticsearch.NewElasticsearchClient(cfg.Elasticsearch)
+	// Initialize metadata schema service typing and validating the metadata fields tenants define
+	metadataSchemaService, err := services.NewMetadataSchemaService(postgres.NewMetadataFieldRepository())
 	if err != nil {
-		logger.Error("Failed to initialize Elasticsearch client", "error", err)
+		logger.Error("Failed to initialize metadata schema service", "error", err)
 		os.Exit(1)
 	}
 
-	// Initialize document index using es.NewDocumentIndex
-	docIndex, err := elasticsearch.NewDocumentIndex(esClient, cfg.Elasticsearch)
+	// Initialize the search index of the configured provider, indexing metadata fields with their type
+	searchIndexer, searchQueryExecutor, err := searchproviders.New(context.Background(), cfg, metadataSchemaService)
 	if err != nil {
-		logger.Error("Failed to initialize Elasticsearch document index", "error", err)
+		logger.Error("Failed to initialize search index", "error", err, "provider", cfg.Search.Provider)
 		os.Exit(1)
 	}
 
-	// Initialize S3 storage service using s3storage.NewS3Storage
-	s3StorageService := s3storage.NewS3Storage(cfg.S3)
-
 	// Initialize repositories (document, folder, user, tenant, webhook)
 	documentRepo, err := documentrepo.NewDocumentRepository(postgres.GetDB())
 	if err != nil {
@@ -97,6 +106,13 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Initialize search service querying the search index and loading the documents it finds
+	searchService, err := services.NewSearchService(searchIndexer, searchQueryExecutor, documentRepo, metadataSchemaService)
+	if err != nil {
+		logger.Error("Failed to initialize search service", "error", err)
+		os.Exit(1)
+	}
+
 	folderRepo := folderrepo.NewFolderRepository(postgres.GetDB())
 	userRepo, err := userrepo.NewUserRepository(postgres.GetDB())
 	if err != nil {
@@ -106,34 +122,461 @@ func main() {
 
 	tenantRepo := tenantrepo.NewTenantRepository(postgres.GetDB())
 	webhookRepo := webhookrepo.NewWebhookRepository()
+	roleRepo := postgres.NewRoleRepository()
+	permissionRepo, err := postgres.NewPermissionRepository(postgres.GetDB())
+	if err != nil {
+		logger.Error("Failed to initialize permission repository", "error", err)
+		os.Exit(1)
+	}
+
+	// Initialize KMS key service resolving the key each tenant's documents are encrypted with
+	keyService, err := kms.NewKeyService(cfg.S3, tenantRepo)
+	if err != nil {
+		logger.Error("Failed to initialize KMS key service", "error", err)
+		os.Exit(1)
+	}
+
+	// Initialize storage service on the configured provider, encrypting content with tenant keys and storing identical content of a tenant once
+	storageProvider, err := providers.New(context.Background(), cfg.Storage, cfg.S3)
+	if err != nil {
+		logger.Error("Failed to initialize storage provider", "error", err)
+		os.Exit(1)
+	}
+	storageService, err := storage.NewTenantStorageService(storageProvider, keyService, postgres.NewContentBlobRepository(), postgres.NewTransactionManager())
+	if err != nil {
+		logger.Error("Failed to initialize storage service", "error", err)
+		os.Exit(1)
+	}
+
+	// Initialize transaction manager used to write domain changes and outbox events atomically
+	txManager := postgres.NewTransactionManager()
+
+	// Initialize audit service used to record who changed what
+	auditService, err := services.NewAuditService(postgres.NewAuditLogRepository())
+	if err != nil {
+		logger.Error("Failed to initialize audit service", "error", err)
+		os.Exit(1)
+	}
+
+	// Initialize API key repository; API keys authenticate service-to-service integrations
+	apiKeyRepo := postgres.NewAPIKeyRepository()
+
+	// Initialize the token revocation list, so logged out and compromised tokens are rejected until
+	// they expire, the rate limit buckets, the idempotency keys and the clean scan verdicts. They are
+	// shared by all API instances through Redis, or kept in this process for development.
+	var tokenRevocationRepo repositories.TokenRevocationRepository
+	var rateLimitRepo repositories.RateLimitRepository
+	var idempotencyRepo repositories.IdempotencyRepository
+	var scanVerdictRepo repositories.ScanVerdictRepository
+	if cfg.Redis.InMemory {
+		logger.Info("Keeping shared state in memory; run a single API instance")
+		tokenRevocationRepo = memorycache.NewTokenRevocationRepository()
+		rateLimitRepo = memorycache.NewRateLimitRepository()
+		idempotencyRepo = memorycache.NewIdempotencyRepository()
+		scanVerdictRepo = memorycache.NewScanVerdictRepository()
+	} else {
+		redisClient, err := redis.NewRedisClient(map[string]interface{}{
+			"address":   cfg.Redis.Address,
+			"password":  cfg.Redis.Password,
+			"db":        cfg.Redis.DB,
+			"pool_size": cfg.Redis.PoolSize,
+		})
+		if err != nil {
+			logger.Error("Failed to connect to Redis", "error", err)
+			os.Exit(1)
+		}
+		defer redisClient.Close()
+
+		tokenRevocationRepo = redis.NewTokenRevocationRepository(redisClient)
+		rateLimitRepo = redis.NewRateLimitRepository(redisClient)
+		idempotencyRepo = redis.NewIdempotencyRepository(redisClient)
+		scanVerdictRepo = redis.NewScanVerdictRepository(redisClient)
+	}
+
+	// Initialize session repository tracking the token families issued at each sign-in
+	sessionRepo := postgres.NewSessionRepository()
 
 	// Initialize JWT authentication service using jwtauth.NewJWTService
-	jwtService, err := jwt.NewJWTService(userRepo, tenantRepo, cfg.JWT)
+	jwtService, err := jwt.NewJWTService(userRepo, tenantRepo, roleRepo, permissionRepo, apiKeyRepo, tokenRevocationRepo, sessionRepo, cfg.JWT)
 	if err != nil {
 		logger.Error("Failed to initialize JWT service", "error", err)
 		os.Exit(1)
 	}
 
+	// Initialize policy engine evaluating attribute-based access policies on documents
+	policyEngine, err := services.NewPolicyEngine(postgres.NewAccessPolicyRepository(), userRepo)
+	if err != nil {
+		logger.Error("Failed to initialize policy engine", "error", err)
+		os.Exit(1)
+	}
+
+	// Initialize quota service tracking per-tenant storage and document counts
+	quotaService, err := services.NewQuotaService(postgres.NewQuotaRepository(), documentRepo, postgres.NewOutboxRepository(), cfg.Quota.DefaultMaxStorageBytes, cfg.Quota.DefaultMaxDocuments)
+	if err != nil {
+		logger.Error("Failed to initialize quota service", "error", err)
+		os.Exit(1)
+	}
+
+	// Initialize upload limit service enforcing per-user and per-role file size and daily volume limits
+	uploadLimitService, err := services.NewUploadLimitService(postgres.NewUploadLimitRepository(), userRepo, documentRepo, cfg.Quota.DefaultMaxFileSizeBytes, cfg.Quota.DefaultMaxDailyUploadBytes)
+	if err != nil {
+		logger.Error("Failed to initialize upload limit service", "error", err)
+		os.Exit(1)
+	}
+
+	// Initialize metadata template service enforcing the metadata folders require of uploaded documents
+	metadataTemplateService, err := services.NewMetadataTemplateService(postgres.NewMetadataTemplateRepository(), folderRepo)
+	if err != nil {
+		logger.Error("Failed to initialize metadata template service", "error", err)
+		os.Exit(1)
+	}
+
+	// Initialize sequence service numbering the documents uploaded to folders
+	sequenceService, err := services.NewSequenceService(postgres.NewNumberingSequenceRepository(), folderRepo)
+	if err != nil {
+		logger.Error("Failed to initialize sequence service", "error", err)
+		os.Exit(1)
+	}
+
+	// Initialize watermark service stamping downloads from folders and share links that watermark them
+	watermarkService, err := services.NewWatermarkService(folderRepo, userRepo, tenantRepo, pdf.NewWatermarkRenderer())
+	if err != nil {
+		logger.Error("Failed to initialize watermark service", "error", err)
+		os.Exit(1)
+	}
+
+	// Initialize download policy service enforcing the view-only, daily limit and sign-in policies of folders
+	downloadPolicyService, err := services.NewDownloadPolicyService(folderRepo, postgres.NewDownloadCounterRepository(), auditService)
+	if err != nil {
+		logger.Error("Failed to initialize download policy service", "error", err)
+		os.Exit(1)
+	}
+
+	// Initialize upload policy service refusing files of types, extensions and sizes tenants do not accept
+	uploadPolicyService, err := services.NewUploadPolicyService(tenantRepo)
+	if err != nil {
+		logger.Error("Failed to initialize upload policy service", "error", err)
+		os.Exit(1)
+	}
+
+	// Initialize tenant settings service caching the branding and defaults of tenants for
+	// notifications and share link pages
+	tenantSettingsService, err := services.NewTenantSettingsService(tenantRepo)
+	if err != nil {
+		logger.Error("Failed to initialize tenant settings service", "error", err)
+		os.Exit(1)
+	}
+
+	// Initialize network policy service refusing requests from networks and countries tenants do not
+	// allow. Without a GeoIP database, tenants blocking countries only accept private networks.
+	var geoIP services.GeoIPResolver
+	if cfg.NetworkPolicy.GeoIPDatabase != "" {
+		geoIP, err = maxmind.NewMaxMindResolver(cfg.NetworkPolicy.GeoIPDatabase)
+		if err != nil {
+			logger.Error("Failed to open GeoIP database", "path", cfg.NetworkPolicy.GeoIPDatabase, "error", err)
+			os.Exit(1)
+		}
+	}
+	networkPolicyService, err := services.NewNetworkPolicyService(tenantRepo, geoIP, auditService)
+	if err != nil {
+		logger.Error("Failed to initialize network policy service", "error", err)
+		os.Exit(1)
+	}
+
+	messageBus, err := messaging.New(context.Background(), cfg)
+	if err != nil {
+		logger.Error("Failed to initialize message bus", "error", err, "provider", cfg.Messaging.Provider)
+		os.Exit(1)
+	}
+	defer messageBus.Close()
+	scanQueue := messageBus.ScanQueue()
+
+	// Initialize the scanning engines of the API and the cache of clean scan verdicts
+	engineSelector, err := newScanningEngineSelector(cfg, tenantRepo)
+	if err != nil {
+		logger.Error("Failed to initialize scanning engines", "error", err)
+		os.Exit(1)
+	}
+	verdictCache, err := newScanVerdictCache(cfg, tenantRepo, scanVerdictRepo)
+	if err != nil {
+		logger.Error("Failed to initialize scan verdict cache", "error", err)
+		os.Exit(1)
+	}
+
+	// The memory message bus only reaches this process, so the API scans the documents it queues itself
+	var inProcessScanWorkers *clamav.ScanWorkerPool
+	if cfg.Messaging.Provider == services.MessagingProviderMemory {
+		inProcessScanWorkers, err = newInProcessScanWorkerPool(cfg, engineSelector, verdictCache, scanQueue, storageService, messageBus.Events())
+		if err != nil {
+			logger.Error("Failed to initialize in-process scan workers", "error", err)
+			os.Exit(1)
+		}
+	}
+
+	// Initialize inline scanning of small uploads, which are available as soon as they are uploaded
+	inlineScanService, err := newInlineScanService(cfg, engineSelector, storageService, documentRepo, messageBus.Events(), verdictCache)
+	if err != nil {
+		logger.Error("Failed to initialize inline scanning", "error", err)
+		os.Exit(1)
+	}
+
 	// Initialize use cases (document, folder, search, webhook)
-	documentUseCase, err := documentusecase.NewDocumentUseCase(documentRepo, s3StorageService, nil, nil, folderRepo, nil, jwtService, nil)
+	documentUseCase, err := documentusecase.NewDocumentUseCase(documentRepo, storageService, nil, searchService, folderRepo, nil, jwtService, nil, txManager, auditService, policyEngine, quotaService, uploadLimitService, metadataTemplateService, metadataSchemaService, sequenceService, watermarkService, downloadPolicyService, uploadPolicyService, inlineScanService)
 	if err != nil {
 		logger.Error("Failed to initialize document use case", "error", err)
 		os.Exit(1)
 	}
+	documentUseCase = documentusecase.NewTracedDocumentUseCase(documentUseCase)
 
 	folderUseCase := folderusecase.NewFolderUseCase(folderRepo, nil, nil, jwtService, nil)
-	searchUseCase, err := searchusecase.NewSearchUseCase(nil, nil, documentRepo)
+	searchUseCase, err := searchusecase.NewSearchUseCase(searchService)
 	if err != nil {
 		logger.Error("Failed to initialize search use case", "error", err)
 		os.Exit(1)
 	}
+	searchUseCase = searchusecase.NewTracedSearchUseCase(searchUseCase)
 
-	webhookUseCase, err := webhookusecase.NewWebhookUseCase(nil, nil)
+	// Initialize webhook service managing the webhooks and delivery logs of tenants, and the event
+	// service that records events and enqueues them in the outbox for the worker to publish
+	webhookService, err := services.NewWebhookService(webhookRepo, nil)
+	if err != nil {
+		logger.Error("Failed to initialize webhook service", "error", err)
+		os.Exit(1)
+	}
+	eventService := services.NewEventService(postgres.NewEventRepository(), postgres.NewOutboxRepository(), messageBus.Events())
+	webhookUseCase, err := webhookusecase.NewWebhookUseCase(webhookService, eventService)
 	if err != nil {
 		logger.Error("Failed to initialize webhook use case", "error", err)
 		os.Exit(1)
 	}
 
+	auditUseCase, err := usecases.NewAuditUseCase(auditService)
+	if err != nil {
+		logger.Error("Failed to initialize audit use case", "error", err)
+		os.Exit(1)
+	}
+
+	roleService, err := services.NewRoleService(roleRepo)
+	if err != nil {
+		logger.Error("Failed to initialize role service", "error", err)
+		os.Exit(1)
+	}
+
+	roleUseCase, err := usecases.NewRoleUseCase(roleService)
+	if err != nil {
+		logger.Error("Failed to initialize role use case", "error", err)
+		os.Exit(1)
+	}
+
+	policyUseCase, err := usecases.NewPolicyUseCase(policyEngine)
+	if err != nil {
+		logger.Error("Failed to initialize policy use case", "error", err)
+		os.Exit(1)
+	}
+
+	groupService, err := services.NewGroupService(postgres.NewGroupRepository(), userRepo)
+	if err != nil {
+		logger.Error("Failed to initialize group service", "error", err)
+		os.Exit(1)
+	}
+
+	groupUseCase, err := usecases.NewGroupUseCase(groupService, folderUseCase)
+	if err != nil {
+		logger.Error("Failed to initialize group use case", "error", err)
+		os.Exit(1)
+	}
+
+	shareLinkUseCase, err := usecases.NewShareLinkUseCase(postgres.NewShareLinkRepository(), documentRepo, tenantRepo, tenantSettingsService, storageService, watermarkService, downloadPolicyService, jwtService, policyEngine, auditService)
+	if err != nil {
+		logger.Error("Failed to initialize share link use case", "error", err)
+		os.Exit(1)
+	}
+
+	apiKeyUseCase, err := usecases.NewAPIKeyUseCase(apiKeyRepo, roleRepo, tenantRepo, auditService)
+	if err != nil {
+		logger.Error("Failed to initialize API key use case", "error", err)
+		os.Exit(1)
+	}
+
+	// Initialize identity provider verifying ID tokens of the tenants that sign in through SSO
+	identityProvider, err := oidc.NewOIDCProvider(cfg.OIDC)
+	if err != nil {
+		logger.Error("Failed to initialize OIDC provider", "error", err)
+		os.Exit(1)
+	}
+
+	mfaRepo := postgres.NewMFARepository()
+	ssoUseCase, err := usecases.NewSSOUseCase(identityProvider, jwtService, userRepo, tenantRepo, postgres.NewExternalIdentityLinkRepository(), mfaRepo, auditService)
+	if err != nil {
+		logger.Error("Failed to initialize SSO use case", "error", err)
+		os.Exit(1)
+	}
+
+	sessionUseCase, err := usecases.NewSessionUseCase(sessionRepo, jwtService, auditService)
+	if err != nil {
+		logger.Error("Failed to initialize session use case", "error", err)
+		os.Exit(1)
+	}
+
+	authUseCase, err := usecases.NewAuthUseCase(jwtService, userRepo, tenantRepo, mfaRepo, auditService)
+	if err != nil {
+		logger.Error("Failed to initialize auth use case", "error", err)
+		os.Exit(1)
+	}
+
+	// Guest invitations are emailed, so they are only enabled when an SMTP relay is configured
+	var emailSender services.EmailSender
+	if cfg.SMTP.Host != "" {
+		emailSender, err = smtp.NewSMTPSender(cfg.SMTP)
+		if err != nil {
+			logger.Error("Failed to initialize SMTP sender", "error", err)
+			os.Exit(1)
+		}
+		authUseCase.SetGuestInvitations(emailSender, cfg.Server.PublicURL+"/guest", tenantSettingsService)
+	}
+
+	// Without an SMTP relay, temporary passwords of invited users are returned to the administrator
+	tenantUseCase, err := usecases.NewTenantUseCase(tenantRepo, userRepo, roleRepo, quotaService, uploadLimitService, jwtService, auditService, keyService, emailSender, cfg.Server.PublicURL)
+	if err != nil {
+		logger.Error("Failed to initialize tenant use case", "error", err)
+		os.Exit(1)
+	}
+
+	guestUseCase, err := usecases.NewGuestUseCase(documentRepo, storageService, watermarkService, downloadPolicyService, auditService)
+	if err != nil {
+		logger.Error("Failed to initialize guest use case", "error", err)
+		os.Exit(1)
+	}
+
+	exportUseCase, err := usecases.NewExportUseCase(postgres.NewExportJobRepository(), folderRepo, storageService, jwtService, auditService)
+	if err != nil {
+		logger.Error("Failed to initialize export use case", "error", err)
+		os.Exit(1)
+	}
+
+	reindexUseCase, err := usecases.NewReindexUseCase(postgres.NewReindexJobRepository(), searchIndexer, auditService)
+	if err != nil {
+		logger.Error("Failed to initialize reindex use case", "error", err)
+		os.Exit(1)
+	}
+
+	subjectAccessUseCase, err := usecases.NewSubjectAccessUseCase(postgres.NewSubjectAccessExportRepository(), userRepo, storageService, auditService)
+	if err != nil {
+		logger.Error("Failed to initialize subject access use case", "error", err)
+		os.Exit(1)
+	}
+
+	metadataSchemaUseCase, err := usecases.NewMetadataSchemaUseCase(metadataSchemaService)
+	if err != nil {
+		logger.Error("Failed to initialize metadata schema use case", "error", err)
+		os.Exit(1)
+	}
+
+	metadataTemplateUseCase, err := usecases.NewMetadataTemplateUseCase(metadataTemplateService)
+	if err != nil {
+		logger.Error("Failed to initialize metadata template use case", "error", err)
+		os.Exit(1)
+	}
+
+	// Initialize folder template use case; applying a template creates its folders, permissions,
+	// metadata templates and folder.created events in one transaction
+	folderTemplateUseCase, err := usecases.NewFolderTemplateUseCase(postgres.NewFolderTemplateRepository(), folderRepo, permissionRepo, postgres.NewMetadataTemplateRepository(), jwtService, postgres.NewOutboxRepository(), auditService, txManager)
+	if err != nil {
+		logger.Error("Failed to initialize folder template use case", "error", err)
+		os.Exit(1)
+	}
+
+	// Initialize favorite use case for the Starred view and the Recents view, read from the audit log
+	favoriteUseCase, err := usecases.NewFavoriteUseCase(postgres.NewFavoriteRepository(), postgres.NewAuditLogRepository(), documentUseCase, folderUseCase)
+	if err != nil {
+		logger.Error("Failed to initialize favorite use case", "error", err)
+		os.Exit(1)
+	}
+
+	// Initialize comment use case; comment.created events go through the outbox with the comment
+	commentUseCase, err := usecases.NewCommentUseCase(postgres.NewCommentRepository(), postgres.NewOutboxRepository(), txManager, documentUseCase)
+	if err != nil {
+		logger.Error("Failed to initialize comment use case", "error", err)
+		os.Exit(1)
+	}
+
+	// Initialize approval use case; submissions lock the document and each transition is published
+	// through the outbox in the same transaction
+	approvalUseCase, err := usecases.NewApprovalUseCase(postgres.NewApprovalWorkflowRepository(), postgres.NewApprovalRequestRepository(), documentRepo, folderRepo, jwtService, postgres.NewOutboxRepository(), txManager)
+	if err != nil {
+		logger.Error("Failed to initialize approval use case", "error", err)
+		os.Exit(1)
+	}
+
+	// Initialize signature use case; providers post envelope status updates to the public URL and
+	// signed documents are scanned like uploads before their version becomes available
+	signatureProviders, err := newSignatureProviders(cfg.Signature)
+	if err != nil {
+		logger.Error("Failed to initialize signature providers", "error", err)
+		os.Exit(1)
+	}
+	signatureUseCase, err := usecases.NewSignatureUseCase(postgres.NewSignatureRequestRepository(), documentRepo, storageService, scanQueue, jwtService, signatureProviders, postgres.NewOutboxRepository(), txManager, cfg.Server.PublicURL)
+	if err != nil {
+		logger.Error("Failed to initialize signature use case", "error", err)
+		os.Exit(1)
+	}
+
+	// Initialize document link use case; links belong to their source document
+	documentLinkUseCase, err := usecases.NewDocumentLinkUseCase(postgres.NewDocumentLinkRepository(), documentRepo, jwtService)
+	if err != nil {
+		logger.Error("Failed to initialize document link use case", "error", err)
+		os.Exit(1)
+	}
+
+	sequenceUseCase, err := usecases.NewSequenceUseCase(sequenceService)
+	if err != nil {
+		logger.Error("Failed to initialize sequence use case", "error", err)
+		os.Exit(1)
+	}
+
+	// Initialize activity use case for the timelines of documents and folders, read from the audit log
+	activityUseCase, err := usecases.NewActivityUseCase(postgres.NewAuditLogRepository(), documentUseCase, folderUseCase)
+	if err != nil {
+		logger.Error("Failed to initialize activity use case", "error", err)
+		os.Exit(1)
+	}
+
+	quarantineUseCase, err := usecases.NewQuarantineUseCase(postgres.NewQuarantineRepository(), documentRepo, storageService, scanQueue, auditService)
+	if err != nil {
+		logger.Error("Failed to initialize quarantine use case", "error", err)
+		os.Exit(1)
+	}
+
+	// Initialize tenant provisioning for the platform API, which is only served when an operator
+	// token is configured; new tenants get their search index up front when the search provider
+	// keeps tenants in indices
+	platformOperatorToken, err := loadPlatformOperatorToken(cfg.Platform)
+	if err != nil {
+		logger.Error("Failed to load platform operator token", "error", err)
+		os.Exit(1)
+	}
+	var tenantProvisioningUseCase usecases.TenantProvisioningUseCase
+	var scanDeadLetters services.ScanDeadLetterQueue
+	if platformOperatorToken != "" {
+		searchPreparer, _ := searchIndexer.(services.SearchTenantPreparer)
+		tenantProvisioningUseCase, err = usecases.NewTenantProvisioningUseCase(tenantRepo, userRepo, roleRepo, postgres.NewFolderTemplateRepository(), folderRepo, permissionRepo,
+			postgres.NewMetadataTemplateRepository(), postgres.NewOutboxRepository(), auditService, txManager, searchPreparer, emailSender, cfg.Server.PublicURL, cfg.Platform.TemplateTenantID)
+		if err != nil {
+			logger.Error("Failed to initialize tenant provisioning use case", "error", err)
+			os.Exit(1)
+		}
+		// Operators re-drive dead-lettered scan tasks through the platform API, when the message bus
+		// keeps them
+		scanDeadLetters, _ = scanQueue.(services.ScanDeadLetterQueue)
+	}
+
+	// Dependencies probed by the /healthz and /readyz endpoints of the Kubernetes probes
+	healthCheckers, err := newHealthCheckers(cfg, storageProvider, searchIndexer, scanQueue)
+	if err != nil {
+		logger.Error("Failed to initialize health checkers", "error", err)
+		os.Exit(1)
+	}
+
 	// Set up API router with all routes and middleware using router.SetupRouter
 	apiRouter := router.SetupRouter(
 		cfg,
@@ -141,7 +584,41 @@ func main() {
 		folderUseCase,
 		searchUseCase,
 		webhookUseCase,
+		auditUseCase,
+		roleUseCase,
+		policyUseCase,
+		groupUseCase,
+		shareLinkUseCase,
+		apiKeyUseCase,
+		ssoUseCase,
+		sessionUseCase,
+		tenantUseCase,
+		guestUseCase,
+		exportUseCase,
+		quarantineUseCase,
+		reindexUseCase,
+		subjectAccessUseCase,
+		metadataSchemaUseCase,
+		metadataTemplateUseCase,
+		folderTemplateUseCase,
+		favoriteUseCase,
+		commentUseCase,
+		approvalUseCase,
+		signatureUseCase,
+		documentLinkUseCase,
+		sequenceUseCase,
+		activityUseCase,
+		tenantProvisioningUseCase,
+		healthCheckers,
+		authUseCase,
 		jwtService,
+		networkPolicyService,
+		tenantSettingsService,
+		rateLimitRepo,
+		idempotencyRepo,
+		authUseCase,
+		scanDeadLetters,
+		platformOperatorToken,
 	)
 
 	// Create HTTP server with configured timeouts and address
@@ -159,6 +636,44 @@ func main() {
 		}
 	}()
 
+	// Start the in-process scan workers; they drain the scans in progress once scanCtx is cancelled
+	scanCtx, stopScanning := context.WithCancel(context.Background())
+	scanWorkersDone := make(chan struct{})
+	if inProcessScanWorkers != nil {
+		logger.Info("Starting in-process scan workers", "concurrency", cfg.Scanning.Workers.Concurrency)
+		go func() {
+			defer close(scanWorkersDone)
+			inProcessScanWorkers.Run(scanCtx)
+		}()
+	} else {
+		close(scanWorkersDone)
+	}
+
+	// Start the gRPC server for internal services when enabled
+	var grpcServer *grpc.Server
+	if cfg.GRPC.Enabled {
+		grpcServer, err = grpcapi.NewServer(jwtService, networkPolicyService, documentUseCase, folderUseCase, searchUseCase)
+		if err != nil {
+			logger.Error("Failed to initialize gRPC server", "error", err)
+			os.Exit(1)
+		}
+
+		grpcAddress := fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.GRPC.Port)
+		listener, err := net.Listen("tcp", grpcAddress)
+		if err != nil {
+			logger.Error("Failed to listen for gRPC", "address", grpcAddress, "error", err)
+			os.Exit(1)
+		}
+
+		go func() {
+			logger.Info("Starting gRPC server", "address", grpcAddress)
+			if err := grpcServer.Serve(listener); err != nil {
+				logger.Error("gRPC server Serve error", "error", err)
+				os.Exit(1)
+			}
+		}()
+	}
+
 	// Wait for shutdown signal
 	<-shutdownSignal
 
@@ -171,6 +686,16 @@ func main() {
 		logger.Error("HTTP server shutdown error", "error", err)
 	}
 
+	// Let in-flight gRPC calls, such as streaming downloads, finish
+	if grpcServer != nil {
+		logger.Info("Shutting down gRPC server...")
+		grpcServer.GracefulStop()
+	}
+
+	// Wait for the in-process scans to finish before closing their dependencies
+	stopScanning()
+	<-scanWorkersDone
+
 	// Close database connection
 	if err := postgres.Close(); err != nil {
 		logger.Error("Database close error", "error", err)
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"fmt"     // standard library
+	"os"      // standard library
+	"strings" // standard library
+
+	"src/backend/pkg/config" // For the platform API configuration
+)
+
+// loadPlatformOperatorToken reads the bearer token platform operators authenticate with. An empty
+// token means the platform API is not enabled.
+func loadPlatformOperatorToken(cfg config.PlatformConfig) (string, error) {
+	if cfg.OperatorTokenFile == "" {
+		return "", nil
+	}
+
+	data, err := os.ReadFile(cfg.OperatorTokenFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read operator token file: %w", err)
+	}
+	token := strings.TrimSpace(string(data))
+	if len(token) < 32 {
+		return "", fmt.Errorf("operator token must be at least 32 characters long")
+	}
+	return token, nil
+}
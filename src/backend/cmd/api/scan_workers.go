@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"  // standard library
+	"time" // standard library
+
+	"src/backend/domain/models"                        // For the scanning engine names
+	"src/backend/domain/repositories"                  // For the tenant repository selecting the engines of each tenant and the scan verdicts
+	"src/backend/domain/services"                      // For the scanning engine selector
+	"src/backend/infrastructure/persistence/postgres"  // For the quarantine repository
+	"src/backend/infrastructure/virus_scanning/clamav" // For the virus scanner and its worker pool
+	"src/backend/infrastructure/virus_scanning/noop"   // For the no-op engine of the dev profile
+	"src/backend/pkg/config"                           // For the scanning configuration
+)
+
+// defaultScanVerdictCacheTTL is how long clean scan verdicts are reused for identical uploads when
+// config.Scanning leaves it unset
+const defaultScanVerdictCacheTTL = 24 * time.Hour
+
+// defaultInlineScanTimeout is how long an inline scan of an upload may take when config.Scanning
+// leaves it unset
+const defaultInlineScanTimeout = 5 * time.Second
+
+// newScanningEngineSelector creates the scanning engines of the API. It offers ClamAV and the no-op
+// engine; the ICAP and external verdict engines are only run by the worker.
+func newScanningEngineSelector(cfg config.Config, tenantRepo repositories.TenantRepository) (services.ScanningEngineSelector, error) {
+	clamAVClient, err := clamav.NewClamAVClient(fmt.Sprintf("%s:%d", cfg.ClamAV.Host, cfg.ClamAV.Port))
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize ClamAV client: %w", err)
+	}
+	engines := []services.ScanningEngine{clamAVClient, noop.NewNoopEngine()}
+
+	defaultEngines := cfg.Scanning.DefaultEngines
+	if len(defaultEngines) == 0 {
+		defaultEngines = []string{models.ScanEngineClamAV}
+	}
+	engineSelector, err := services.NewScanningEngineSelector(tenantRepo, engines, defaultEngines)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize scanning engine selector: %w", err)
+	}
+	return engineSelector, nil
+}
+
+// newScanVerdictCache creates the cache of clean scan verdicts, or nil when the configured TTL is zero
+func newScanVerdictCache(cfg config.Config, tenantRepo repositories.TenantRepository, scanVerdictRepo repositories.ScanVerdictRepository) (services.ScanVerdictCache, error) {
+	// Identical uploads of a tenant reuse its clean verdicts for the configured TTL; zero scans every upload
+	verdictCacheTTL := defaultScanVerdictCacheTTL
+	if cfg.Scanning.VerdictCacheTTL != "" {
+		var err error
+		verdictCacheTTL, err = time.ParseDuration(cfg.Scanning.VerdictCacheTTL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid scan verdict cache TTL: %w", err)
+		}
+	}
+	if verdictCacheTTL <= 0 {
+		return nil, nil
+	}
+
+	verdictCache, err := services.NewScanVerdictCache(scanVerdictRepo, tenantRepo, verdictCacheTTL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize scan verdict cache: %w", err)
+	}
+	return verdictCache, nil
+}
+
+// newInlineScanService creates the service scanning small uploads while they are uploaded, or nil
+// when config.Scanning.Inline disables it
+func newInlineScanService(cfg config.Config, engineSelector services.ScanningEngineSelector, storageService services.StorageService,
+	documentRepo repositories.DocumentRepository, events services.EventBus, verdictCache services.ScanVerdictCache) (services.InlineScanService, error) {
+	if cfg.Scanning.Inline.MaxBytes <= 0 {
+		return nil, nil
+	}
+
+	timeout := defaultInlineScanTimeout
+	if cfg.Scanning.Inline.Timeout != "" {
+		var err error
+		timeout, err = time.ParseDuration(cfg.Scanning.Inline.Timeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid inline scan timeout: %w", err)
+		}
+	}
+
+	inlineScanService, err := services.NewInlineScanService(engineSelector, storageService, documentRepo, events, verdictCache, cfg.Scanning.Inline.MaxBytes, timeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize inline scan service: %w", err)
+	}
+	return inlineScanService, nil
+}
+
+// newInProcessScanWorkerPool creates the scan workers of the API when the message bus only reaches
+// this process
+func newInProcessScanWorkerPool(cfg config.Config, engineSelector services.ScanningEngineSelector, verdictCache services.ScanVerdictCache,
+	scanQueue services.ScanQueue, storageService services.StorageService, events services.EventBus) (*clamav.ScanWorkerPool, error) {
+	virusScanner, err := clamav.NewMultiEngineVirusScanner(engineSelector, postgres.NewQuarantineRepository(), verdictCache, scanQueue, storageService, events, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize virus scanner service: %w", err)
+	}
+
+	return clamav.NewScanWorkerPool(virusScanner, scanQueue, cfg.Scanning.Workers)
+}
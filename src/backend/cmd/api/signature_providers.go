@@ -0,0 +1,34 @@
+package main
+
+import (
+	"fmt" // standard library
+
+	"src/backend/domain/services"                    // For the signature provider interface
+	"src/backend/infrastructure/signature/adobesign" // For the Adobe Sign provider
+	"src/backend/infrastructure/signature/docusign"  // For the DocuSign provider
+	"src/backend/pkg/config"                         // For the signature provider configuration
+)
+
+// newSignatureProviders creates the electronic signature providers that are configured; with none,
+// documents cannot be sent for signature
+func newSignatureProviders(cfg config.SignatureConfig) ([]services.SignatureProvider, error) {
+	var providers []services.SignatureProvider
+
+	if cfg.DocuSign.AccountID != "" {
+		provider, err := docusign.NewDocuSignProvider(cfg.DocuSign, cfg.Timeout)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize DocuSign provider: %w", err)
+		}
+		providers = append(providers, provider)
+	}
+
+	if cfg.AdobeSign.BaseURL != "" {
+		provider, err := adobesign.NewAdobeSignProvider(cfg.AdobeSign, cfg.Timeout)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize Adobe Sign provider: %w", err)
+		}
+		providers = append(providers, provider)
+	}
+
+	return providers, nil
+}
@@ -0,0 +1,287 @@
+package main
+
+import (
+	"bufio"         // standard library
+	"encoding/json" // standard library
+	"fmt"           // standard library
+	"io"            // standard library
+	"net/http"      // standard library
+	"net/url"       // standard library
+	"os"            // standard library
+	"strings"       // standard library
+
+	"github.com/spf13/cobra" // v1.8.0+
+)
+
+// loginOptions holds the flags of the login command. Secrets are read from stdin or files rather
+// than flags, so that they do not end up in shell history or process listings.
+type loginOptions struct {
+	tenantID          string
+	apiKeyStdin       bool
+	tokenStdin        bool
+	ssoIDTokenFile    string
+	operatorTokenFile string
+}
+
+// newLoginCommand creates the login command, which stores credentials in a profile
+func newLoginCommand(opts *globalOptions) *cobra.Command {
+	login := &loginOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "login",
+		Short: "Store the API URL and credentials of a profile",
+		Long: `Store the API URL and credentials of a profile, and make it the current profile.
+
+Tenant API credentials are one of:
+  --api-key-stdin          an API key created by a tenant administrator, read from stdin
+  --token-stdin            an access token, read from stdin
+  --sso-id-token-file F    an ID token of the tenant's identity provider, exchanged for an
+                           access token (requires --tenant)
+
+The platform API is called with the operator token given with --operator-token-file.
+Credentials given to an existing profile replace those of the same kind.`,
+		Example: `  dmsctl login --api-url https://dms.example.com --api-key-stdin < api-key.txt
+  dmsctl login -p prod-ops --api-url https://dms.example.com --operator-token-file /run/secrets/operator-token`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runLogin(cmd, opts, login)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&login.tenantID, "tenant", "", "tenant ID, required to exchange an SSO ID token")
+	flags.BoolVar(&login.apiKeyStdin, "api-key-stdin", false, "read an API key from stdin")
+	flags.BoolVar(&login.tokenStdin, "token-stdin", false, "read an access token from stdin")
+	flags.StringVar(&login.ssoIDTokenFile, "sso-id-token-file", "", "file holding an ID token of the tenant's identity provider")
+	flags.StringVar(&login.operatorTokenFile, "operator-token-file", "", "file holding the platform operator token")
+	cmd.MarkFlagsMutuallyExclusive("api-key-stdin", "token-stdin", "sso-id-token-file")
+	return cmd
+}
+
+// runLogin stores the credentials of the login flags in the selected profile, once the API accepts
+// them
+func runLogin(cmd *cobra.Command, opts *globalOptions, login *loginOptions) error {
+	if !login.apiKeyStdin && !login.tokenStdin && login.ssoIDTokenFile == "" && login.operatorTokenFile == "" {
+		return newUsageError("give credentials with --api-key-stdin, --token-stdin, --sso-id-token-file or --operator-token-file")
+	}
+	if login.ssoIDTokenFile != "" && login.tenantID == "" {
+		return newUsageError("--sso-id-token-file requires --tenant")
+	}
+
+	config, err := LoadConfig(opts.configPath)
+	if err != nil {
+		return err
+	}
+	name := opts.selectedProfile(config)
+	profile := &Profile{}
+	if stored, ok := config.Profiles[name]; ok {
+		profile = stored
+	}
+	if opts.apiURL != "" {
+		profile.APIURL = strings.TrimRight(opts.apiURL, "/")
+	}
+	if profile.APIURL == "" {
+		return newUsageError("profile %q has no API URL yet; give it with --api-url", name)
+	}
+	if login.tenantID != "" {
+		profile.TenantID = login.tenantID
+	}
+
+	if login.operatorTokenFile != "" {
+		if profile.OperatorToken, err = readSecretFile(login.operatorTokenFile); err != nil {
+			return err
+		}
+	}
+
+	ctx := cmd.Context()
+	client := NewClient(profile)
+	switch {
+	case login.apiKeyStdin:
+		if profile.APIKey, err = readSecret(cmd.InOrStdin(), "API key"); err != nil {
+			return err
+		}
+		profile.AccessToken = ""
+	case login.tokenStdin:
+		if profile.AccessToken, err = readSecret(cmd.InOrStdin(), "access token"); err != nil {
+			return err
+		}
+		profile.APIKey = ""
+	case login.ssoIDTokenFile != "":
+		idToken, err := readSecretFile(login.ssoIDTokenFile)
+		if err != nil {
+			return err
+		}
+		result, err := client.Call(ctx, http.MethodPost, apiPrefix+"/sso/token", nil, map[string]string{
+			"tenant_id": login.tenantID,
+			"id_token":  idToken,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to exchange the ID token: %w", err)
+		}
+		var token struct {
+			AccessToken string `json:"access_token"`
+			MFARequired bool   `json:"mfa_required"`
+		}
+		if err := decodePayload(result, &token); err != nil {
+			return err
+		}
+		if token.MFARequired {
+			return fmt.Errorf("the account requires a second factor, which dmsctl cannot verify; sign in with an API key instead")
+		}
+		profile.AccessToken = token.AccessToken
+		profile.APIKey = ""
+	}
+
+	// Check the tenant credentials before storing them, so that a typo is reported now rather than
+	// by the next command
+	if profile.APIKey != "" || profile.AccessToken != "" {
+		if err := client.Get(ctx, apiPrefix+"/folders", url.Values{"pageSize": {"1"}}, &json.RawMessage{}); err != nil {
+			return fmt.Errorf("the API rejected the credentials: %w", err)
+		}
+	}
+
+	config.Profiles[name] = profile
+	config.CurrentProfile = name
+	if err := config.Save(opts.configPath); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "Logged in to %s with profile %q\n", profile.APIURL, name)
+	return nil
+}
+
+// newLogoutCommand creates the logout command, which removes a profile and its credentials
+func newLogoutCommand(opts *globalOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "logout",
+		Short: "Remove the selected profile and its credentials",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, err := LoadConfig(opts.configPath)
+			if err != nil {
+				return err
+			}
+			name := opts.selectedProfile(config)
+			if _, ok := config.Profiles[name]; !ok {
+				return fmt.Errorf("profile %q does not exist", name)
+			}
+
+			delete(config.Profiles, name)
+			if config.CurrentProfile == name {
+				config.CurrentProfile = ""
+			}
+			if err := config.Save(opts.configPath); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "Removed profile %q\n", name)
+			return nil
+		},
+	}
+}
+
+// newProfileCommand creates the profile command, which lists profiles and switches between them
+func newProfileCommand(opts *globalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "profile",
+		Short: "List profiles and choose the current one",
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "list",
+		Short: "List the profiles, without their credentials",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out, err := opts.printer(cmd.OutOrStdout())
+			if err != nil {
+				return err
+			}
+			config, err := LoadConfig(opts.configPath)
+			if err != nil {
+				return err
+			}
+
+			type profileSummary struct {
+				Name     string `json:"name"`
+				Current  bool   `json:"current"`
+				APIURL   string `json:"api_url"`
+				TenantID string `json:"tenant_id,omitempty"`
+				Auth     string `json:"auth"`
+				Operator bool   `json:"operator"`
+			}
+			profiles := make([]profileSummary, 0, len(config.Profiles))
+			for _, name := range config.ProfileNames() {
+				profile := config.Profiles[name]
+				profiles = append(profiles, profileSummary{
+					Name:     name,
+					Current:  name == config.CurrentProfile,
+					APIURL:   profile.APIURL,
+					TenantID: profile.TenantID,
+					Auth:     authKind(profile),
+					Operator: profile.OperatorToken != "",
+				})
+			}
+			data, err := json.Marshal(profiles)
+			if err != nil {
+				return err
+			}
+			return out.Print(data, []column{
+				{"NAME", "name"}, {"CURRENT", "current"}, {"API URL", "api_url"}, {"TENANT", "tenant_id"}, {"AUTH", "auth"}, {"OPERATOR", "operator"},
+			})
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "use NAME",
+		Short: "Make a profile the current one",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, err := LoadConfig(opts.configPath)
+			if err != nil {
+				return err
+			}
+			if _, ok := config.Profiles[args[0]]; !ok {
+				return fmt.Errorf("profile %q does not exist", args[0])
+			}
+			config.CurrentProfile = args[0]
+			return config.Save(opts.configPath)
+		},
+	})
+	return cmd
+}
+
+// authKind describes the tenant API credentials of a profile
+func authKind(profile *Profile) string {
+	switch {
+	case profile.AccessToken != "":
+		return "token"
+	case profile.APIKey != "":
+		return "api-key"
+	default:
+		return "none"
+	}
+}
+
+// readSecret reads a secret from the first line of r
+func readSecret(r io.Reader, what string) (string, error) {
+	line, err := bufio.NewReader(r).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", fmt.Errorf("failed to read the %s: %w", what, err)
+	}
+	secret := strings.TrimSpace(line)
+	if secret == "" {
+		return "", newUsageError("no %s was given on stdin", what)
+	}
+	return secret, nil
+}
+
+// readSecretFile reads a secret from a file, such as a mounted Kubernetes secret
+func readSecretFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret: %w", err)
+	}
+	secret := strings.TrimSpace(string(data))
+	if secret == "" {
+		return "", newUsageError("%s is empty", path)
+	}
+	return secret, nil
+}
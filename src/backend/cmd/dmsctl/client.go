@@ -0,0 +1,357 @@
+package main
+
+import (
+	"bytes"          // standard library
+	"context"        // standard library
+	"encoding/json"  // standard library
+	"fmt"            // standard library
+	"io"             // standard library
+	"mime"           // standard library
+	"mime/multipart" // standard library
+	"net/http"       // standard library
+	"net/url"        // standard library
+	"os"             // standard library
+	"path/filepath"  // standard library
+	"strconv"        // standard library
+	"strings"        // standard library
+	"time"           // standard library
+)
+
+// API path prefixes of the tenant API and of the platform operator API
+const (
+	apiPrefix      = "/api/v1"
+	platformPrefix = "/platform/v1"
+)
+
+// requestTimeout bounds requests that do not transfer document content; uploads and downloads are
+// only bounded by the context, since large files take as long as they take
+const requestTimeout = 60 * time.Second
+
+// userAgent identifies dmsctl in the access logs of the API
+const userAgent = "dmsctl/1.0"
+
+// Violation is a field of a request breaking a validation rule, as reported by the API
+type Violation struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// APIError is an error response of the API, decoded from its problem details
+type APIError struct {
+	Status     int         `json:"status"`
+	Title      string      `json:"title"`
+	Detail     string      `json:"detail"`
+	Code       string      `json:"code"`
+	Violations []Violation `json:"violations,omitempty"`
+}
+
+// Error returns the detail of the problem with its code, followed by its field violations
+func (e *APIError) Error() string {
+	message := e.Detail
+	if message == "" {
+		message = e.Title
+	}
+	if message == "" {
+		message = http.StatusText(e.Status)
+	}
+	if e.Code != "" {
+		message = fmt.Sprintf("%s (%s, HTTP %d)", message, e.Code, e.Status)
+	} else {
+		message = fmt.Sprintf("%s (HTTP %d)", message, e.Status)
+	}
+	for _, violation := range e.Violations {
+		message += fmt.Sprintf("\n  %s: %s", violation.Field, violation.Message)
+	}
+	return message
+}
+
+// PageInfo is the pagination of list responses
+type PageInfo struct {
+	Page       int    `json:"page"`
+	PageSize   int    `json:"pageSize"`
+	TotalPages int    `json:"totalPages"`
+	TotalItems int64  `json:"totalItems"`
+	HasNext    bool   `json:"hasNext"`
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// envelope is the body of successful responses. Single resources are in data, lists in items next
+// to their pagination, and search results in results.
+type envelope struct {
+	Data       json.RawMessage `json:"data"`
+	Items      json.RawMessage `json:"items"`
+	Results    json.RawMessage `json:"results"`
+	Message    string          `json:"message"`
+	Pagination *PageInfo       `json:"pagination"`
+}
+
+// isMessage reports whether the envelope only carries a message, like the responses of deletions
+func (e *envelope) isMessage() bool {
+	return (len(e.Data) == 0 || string(e.Data) == "null") && len(e.Items) == 0 && len(e.Results) == 0
+}
+
+// payload returns the resource or list carried by the envelope, or its message when it carries
+// neither
+func (e *envelope) payload() json.RawMessage {
+	switch {
+	case e.isMessage():
+	case len(e.Items) > 0:
+		return e.Items
+	case len(e.Results) > 0:
+		return e.Results
+	default:
+		return e.Data
+	}
+	message, _ := json.Marshal(map[string]string{"message": e.Message})
+	return message
+}
+
+// Client calls the API with the credentials of a profile
+type Client struct {
+	baseURL    string
+	profile    *Profile
+	httpClient *http.Client
+}
+
+// NewClient creates a new Client for the API and credentials of the profile
+func NewClient(profile *Profile) *Client {
+	return &Client{
+		baseURL:    strings.TrimRight(profile.APIURL, "/"),
+		profile:    profile,
+		httpClient: &http.Client{},
+	}
+}
+
+// authorize signs a request. The platform API only accepts the operator token; the tenant API
+// accepts an API key or an access token.
+func (c *Client) authorize(req *http.Request, path string) error {
+	if strings.HasPrefix(path, platformPrefix+"/") {
+		if c.profile.OperatorToken == "" {
+			return fmt.Errorf("the profile has no operator token; sign in with \"dmsctl login --operator-token-file\"")
+		}
+		req.Header.Set("Authorization", "Bearer "+c.profile.OperatorToken)
+		return nil
+	}
+
+	switch {
+	case c.profile.AccessToken != "":
+		req.Header.Set("Authorization", "Bearer "+c.profile.AccessToken)
+	case c.profile.APIKey != "":
+		req.Header.Set("X-API-Key", c.profile.APIKey)
+	}
+	return nil
+}
+
+// Do sends a request to the API path and returns the response when it is successful. Error
+// responses are returned as an *APIError.
+func (c *Client) Do(ctx context.Context, method, path string, query url.Values, body io.Reader, contentType string) (*http.Response, error) {
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Accept", "application/json")
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if err := c.authorize(req, path); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s failed: %w", method, path, err)
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		defer resp.Body.Close()
+		return nil, decodeError(resp)
+	}
+	return resp, nil
+}
+
+// Call sends a request with in encoded as its JSON body, when not nil, and returns the envelope of
+// the response
+func (c *Client) Call(ctx context.Context, method, path string, query url.Values, in interface{}) (*envelope, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	var body io.Reader
+	contentType := ""
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request: %w", err)
+		}
+		body = bytes.NewReader(data)
+		contentType = "application/json"
+	}
+
+	resp, err := c.Do(ctx, method, path, query, body, contentType)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return decodeEnvelope(resp)
+}
+
+// Get sends a GET request and decodes the payload of the response into out
+func (c *Client) Get(ctx context.Context, path string, query url.Values, out interface{}) error {
+	result, err := c.Call(ctx, http.MethodGet, path, query, nil)
+	if err != nil {
+		return err
+	}
+	return decodePayload(result, out)
+}
+
+// List sends GET requests for a list and returns its items. Every page is fetched when all is set,
+// following the cursor of keyset pages or else the page numbers; otherwise only the first page is.
+func (c *Client) List(ctx context.Context, path string, query url.Values, all bool) (json.RawMessage, error) {
+	query = cloneValues(query)
+	var items []json.RawMessage
+	for {
+		result, err := c.Call(ctx, http.MethodGet, path, query, nil)
+		if err != nil {
+			return nil, err
+		}
+		var pageItems []json.RawMessage
+		if err := decodePayload(result, &pageItems); err != nil {
+			return nil, err
+		}
+		items = append(items, pageItems...)
+
+		next := result.Pagination
+		if !all || next == nil || !next.HasNext || len(pageItems) == 0 {
+			break
+		}
+		if next.NextCursor != "" {
+			query.Set("cursor", next.NextCursor)
+		} else {
+			query.Set("page", strconv.Itoa(next.Page+1))
+		}
+	}
+
+	if items == nil {
+		items = []json.RawMessage{}
+	}
+	return json.Marshal(items)
+}
+
+// Upload streams a file to the API path as the file field of a multipart form, with the other
+// fields of the form. The file is not read into memory, so files of any size can be uploaded.
+func (c *Client) Upload(ctx context.Context, path, filePath string, fields url.Values) (*envelope, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader, writer := io.Pipe()
+	form := multipart.NewWriter(writer)
+	go func() {
+		writer.CloseWithError(writeUploadForm(form, file, filepath.Base(filePath), fields))
+	}()
+
+	resp, err := c.Do(ctx, http.MethodPost, path, nil, reader, form.FormDataContentType())
+	// Unblock the form writer when the request failed before the body was read
+	reader.CloseWithError(io.ErrClosedPipe)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return decodeEnvelope(resp)
+}
+
+// writeUploadForm writes the fields and the file of an upload form
+func writeUploadForm(form *multipart.Writer, file io.Reader, fileName string, fields url.Values) error {
+	for name, values := range fields {
+		for _, value := range values {
+			if err := form.WriteField(name, value); err != nil {
+				return err
+			}
+		}
+	}
+	part, err := form.CreateFormFile("file", fileName)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(part, file); err != nil {
+		return err
+	}
+	return form.Close()
+}
+
+// Download sends a GET request for content and returns its body with the file name the API
+// suggests. Redirects to presigned storage URLs are followed.
+func (c *Client) Download(ctx context.Context, path string) (io.ReadCloser, string, error) {
+	resp, err := c.Do(ctx, http.MethodGet, path, nil, nil, "")
+	if err != nil {
+		return nil, "", err
+	}
+
+	fileName := ""
+	if _, params, err := mime.ParseMediaType(resp.Header.Get("Content-Disposition")); err == nil {
+		fileName = filepath.Base(params["filename"])
+	}
+	return resp.Body, fileName, nil
+}
+
+// decodeEnvelope decodes the envelope of a successful response. Responses without a body, such as
+// 204 No Content, give an empty envelope.
+func decodeEnvelope(resp *http.Response) (*envelope, error) {
+	result := &envelope{}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return result, nil
+	}
+	if err := json.Unmarshal(data, result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return result, nil
+}
+
+// decodePayload decodes the payload of an envelope into out
+func decodePayload(result *envelope, out interface{}) error {
+	if err := json.Unmarshal(result.payload(), out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// decodeError decodes an error response into an *APIError. Responses that are not problem details,
+// such as those of a proxy in front of the API, keep their status and the start of their body.
+func decodeError(resp *http.Response) error {
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+
+	apiErr := &APIError{}
+	if err := json.Unmarshal(data, apiErr); err != nil || (apiErr.Detail == "" && apiErr.Title == "") {
+		apiErr = &APIError{Detail: strings.TrimSpace(truncate(string(data), 200))}
+	}
+	apiErr.Status = resp.StatusCode
+	return apiErr
+}
+
+// cloneValues returns a copy of query values, which List changes as it pages
+func cloneValues(values url.Values) url.Values {
+	clone := url.Values{}
+	for name, value := range values {
+		clone[name] = append([]string(nil), value...)
+	}
+	return clone
+}
+
+// truncate shortens s to at most n bytes
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"  // v1.8.0+
+	"github.com/stretchr/testify/require" // v1.8.0+
+)
+
+// TestClientAuthorize tests that the platform API gets the operator token and the tenant API the
+// credentials of the profile
+func TestClientAuthorize(t *testing.T) {
+	var headers []http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = append(headers, r.Header.Clone())
+		w.Write([]byte(`{"success":true,"data":{}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(&Profile{APIURL: server.URL, APIKey: "key-1", OperatorToken: "operator-1"})
+	_, err := client.Call(context.Background(), http.MethodGet, apiPrefix+"/folders", nil, nil)
+	require.NoError(t, err)
+	_, err = client.Call(context.Background(), http.MethodGet, platformPrefix+"/scan-dead-letters", nil, nil)
+	require.NoError(t, err)
+
+	require.Len(t, headers, 2)
+	assert.Equal(t, "key-1", headers[0].Get("X-API-Key"))
+	assert.Empty(t, headers[0].Get("Authorization"))
+	assert.Equal(t, "Bearer operator-1", headers[1].Get("Authorization"))
+	assert.Empty(t, headers[1].Get("X-API-Key"))
+
+	// Without an operator token, the platform API is not called at all
+	client = NewClient(&Profile{APIURL: server.URL, AccessToken: "token-1"})
+	_, err = client.Call(context.Background(), http.MethodGet, platformPrefix+"/scan-dead-letters", nil, nil)
+	assert.Error(t, err)
+	assert.Len(t, headers, 2)
+}
+
+// TestClientDecodesProblemDetails tests that error responses are returned with their code and
+// field violations
+func TestClientDecodesProblemDetails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"type":"/api/v1/errors/VALIDATION_FAILED","title":"Validation failed","status":400,
+			"detail":"name is required","code":"VALIDATION_FAILED",
+			"violations":[{"field":"name","rule":"required","message":"name is required"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(&Profile{APIURL: server.URL, APIKey: "key-1"})
+	_, err := client.Call(context.Background(), http.MethodPost, apiPrefix+"/folders", nil, map[string]string{})
+
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusBadRequest, apiErr.Status)
+	assert.Equal(t, "VALIDATION_FAILED", apiErr.Code)
+	require.Len(t, apiErr.Violations, 1)
+	assert.Equal(t, "name", apiErr.Violations[0].Field)
+	assert.Contains(t, err.Error(), "name is required (VALIDATION_FAILED, HTTP 400)")
+}
+
+// TestClientListFollowsPages tests that every page of a list is fetched with --all
+func TestClientListFollowsPages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("cursor") == "" {
+			w.Write([]byte(`{"items":[{"id":"f1"},{"id":"f2"}],"pagination":{"page":1,"hasNext":true,"nextCursor":"c2"}}`))
+			return
+		}
+		assert.Equal(t, "c2", r.URL.Query().Get("cursor"))
+		w.Write([]byte(`{"items":[{"id":"f3"}],"pagination":{"page":2,"hasNext":false}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(&Profile{APIURL: server.URL, APIKey: "key-1"})
+	items, err := client.List(context.Background(), apiPrefix+"/folders", nil, true)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"id":"f1"},{"id":"f2"},{"id":"f3"}]`, string(items))
+
+	items, err = client.List(context.Background(), apiPrefix+"/folders", nil, false)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"id":"f1"},{"id":"f2"}]`, string(items))
+}
+
+// TestConfigSave tests that profiles survive a round trip and that the file is private
+func TestConfigSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dmsctl", "config.json")
+
+	config, err := LoadConfig(path)
+	require.NoError(t, err)
+	assert.Empty(t, config.Profiles)
+
+	config.CurrentProfile = "prod"
+	config.Profiles["prod"] = &Profile{APIURL: "https://dms.example.com", APIKey: "key-1"}
+	require.NoError(t, config.Save(path))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	loaded, err := LoadConfig(path)
+	require.NoError(t, err)
+	assert.Equal(t, config, loaded)
+}
+
+// TestFoldersListCommand tests a command end to end, in both output formats
+func TestFoldersListCommand(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, apiPrefix+"/folders", r.URL.Path)
+		assert.Equal(t, "p1", r.URL.Query().Get("parentId"))
+		w.Write([]byte(`{"success":true,"items":[{"id":"f1","name":"Invoices","parentId":"p1","path":"/finance/invoices"}],"pagination":{"page":1}}`))
+	}))
+	defer server.Close()
+
+	run := func(args ...string) string {
+		var out bytes.Buffer
+		root := newRootCommand()
+		root.SetOut(&out)
+		root.SetArgs(append([]string{"--config", filepath.Join(t.TempDir(), "config.json"), "--api-url", server.URL, "--token", "token-1"}, args...))
+		require.NoError(t, root.Execute())
+		return out.String()
+	}
+
+	table := run("folders", "list", "--parent", "p1")
+	assert.Contains(t, table, "ID  NAME      PARENT  PATH")
+	assert.Contains(t, table, "f1  Invoices  p1      /finance/invoices")
+
+	var folders []map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(run("folders", "list", "--parent", "p1", "-o", "json")), &folders))
+	require.Len(t, folders, 1)
+	assert.Equal(t, "Invoices", folders[0]["name"])
+}
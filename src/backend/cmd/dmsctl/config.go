@@ -0,0 +1,180 @@
+package main
+
+import (
+	"encoding/json" // standard library
+	"fmt"           // standard library
+	"io"            // standard library
+	"os"            // standard library
+	"path/filepath" // standard library
+	"sort"          // standard library
+	"strings"       // standard library
+)
+
+// defaultProfileName is the profile created by the first login when none is named
+const defaultProfileName = "default"
+
+// Profile holds the API endpoint and credentials dmsctl signs requests with. A profile carries an
+// API key or an access token for the tenant API, and optionally the operator token of the platform
+// API.
+type Profile struct {
+	APIURL        string `json:"api_url"`
+	TenantID      string `json:"tenant_id,omitempty"`
+	APIKey        string `json:"api_key,omitempty"`
+	AccessToken   string `json:"access_token,omitempty"`
+	OperatorToken string `json:"operator_token,omitempty"`
+}
+
+// Config is the configuration file of dmsctl, holding its profiles by name
+type Config struct {
+	CurrentProfile string              `json:"current_profile"`
+	Profiles       map[string]*Profile `json:"profiles"`
+}
+
+// defaultConfigPath returns the path of the configuration file in the user's configuration
+// directory, such as ~/.config/dmsctl/config.json on Linux
+func defaultConfigPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return filepath.Join(".dmsctl", "config.json")
+	}
+	return filepath.Join(dir, "dmsctl", "config.json")
+}
+
+// LoadConfig reads the configuration file at path. A missing file is an empty configuration, so
+// that the first login can create it.
+func LoadConfig(path string) (*Config, error) {
+	config := &Config{Profiles: map[string]*Profile{}}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return config, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read configuration: %w", err)
+	}
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("failed to parse configuration %s: %w", path, err)
+	}
+	if config.Profiles == nil {
+		config.Profiles = map[string]*Profile{}
+	}
+	return config, nil
+}
+
+// Save writes the configuration to path. The file holds credentials, so it is only readable by its
+// owner, and it is replaced atomically so that an interrupted write cannot lose the profiles.
+func (c *Config) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create configuration directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode configuration: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".config-*.json")
+	if err != nil {
+		return fmt.Errorf("failed to write configuration: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write configuration: %w", err)
+	}
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write configuration: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write configuration: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write configuration: %w", err)
+	}
+	return nil
+}
+
+// ProfileNames returns the names of the profiles in alphabetical order
+func (c *Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// globalOptions holds the global flags of dmsctl
+type globalOptions struct {
+	configPath  string
+	profileName string
+	output      string
+	apiURL      string
+	token       string
+}
+
+// selectedProfile returns the name of the profile commands run against: the one given with
+// --profile, else the current profile of the configuration
+func (o *globalOptions) selectedProfile(config *Config) string {
+	if o.profileName != "" {
+		return o.profileName
+	}
+	if config.CurrentProfile != "" {
+		return config.CurrentProfile
+	}
+	return defaultProfileName
+}
+
+// profile returns the selected profile with the --api-url and --token flags, and the operator token
+// of $DMSCTL_OPERATOR_TOKEN, applied. They are enough to call the API without a profile, so that
+// scripts can run without a configuration file.
+func (o *globalOptions) profile() (*Profile, error) {
+	config, err := LoadConfig(o.configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	name := o.selectedProfile(config)
+	operatorToken := os.Getenv("DMSCTL_OPERATOR_TOKEN")
+	profile := Profile{}
+	if stored, ok := config.Profiles[name]; ok {
+		profile = *stored
+	} else if o.apiURL == "" || (o.token == "" && operatorToken == "") {
+		return nil, fmt.Errorf("profile %q does not exist; sign in with \"dmsctl login\"", name)
+	}
+
+	if o.apiURL != "" {
+		profile.APIURL = o.apiURL
+	}
+	if o.token != "" {
+		profile.AccessToken = o.token
+		profile.APIKey = ""
+	}
+	if operatorToken != "" {
+		profile.OperatorToken = operatorToken
+	}
+	if profile.APIURL == "" {
+		return nil, fmt.Errorf("profile %q has no API URL; sign in again with \"dmsctl login --api-url\"", name)
+	}
+	return &profile, nil
+}
+
+// client returns an API client signing requests with the credentials of the selected profile
+func (o *globalOptions) client() (*Client, error) {
+	profile, err := o.profile()
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(profile), nil
+}
+
+// printer returns the printer of the output format given with --output
+func (o *globalOptions) printer(out io.Writer) (*printer, error) {
+	format := strings.ToLower(o.output)
+	if format != outputTable && format != outputJSON {
+		return nil, newUsageError("unknown output format %q; use %s or %s", o.output, outputTable, outputJSON)
+	}
+	return &printer{out: out, format: format}, nil
+}
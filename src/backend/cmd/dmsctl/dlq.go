@@ -0,0 +1,188 @@
+package main
+
+import (
+	"encoding/json" // standard library
+	"fmt"           // standard library
+	"net/http"      // standard library
+	"net/url"       // standard library
+	"strconv"       // standard library
+
+	"github.com/spf13/cobra" // v1.8.0+
+)
+
+// newDLQCommand creates the dlq command, which inspects and replays dead letters once the cause of
+// their failure is fixed
+func newDLQCommand(opts *globalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dlq",
+		Short: "Inspect and replay the scan tasks and webhook deliveries that exhausted their retries",
+	}
+	cmd.AddCommand(newDLQScanCommand(opts), newDLQWebhooksCommand(opts))
+	return cmd
+}
+
+// newDLQScanCommand creates the dlq scan command. The scan dead letter queue spans tenants, so it is
+// only reachable through the platform API with the operator token.
+func newDLQScanCommand(opts *globalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "scan",
+		Short: "Dead-lettered virus scan tasks (platform operators)",
+	}
+
+	var limit int
+	list := &cobra.Command{
+		Use:   "list",
+		Short: "List the dead-lettered scan tasks with the reason they failed",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCall(cmd, opts, []column{
+				{"MESSAGE", "message_id"}, {"TENANT", "tenant_id"}, {"DOCUMENT", "document_id"}, {"VERSION", "version_id"},
+				{"PRIORITY", "priority"}, {"DEAD-LETTERED", "dead_lettered_at"}, {"REASON", "reason"},
+			}, func(client *Client) (*envelope, error) {
+				return client.Call(cmd.Context(), http.MethodGet, platformPrefix+"/scan-dead-letters", url.Values{"limit": {strconv.Itoa(limit)}}, nil)
+			})
+		},
+	}
+	list.Flags().IntVar(&limit, "limit", 100, "maximum number of tasks to list")
+
+	var (
+		ids      []string
+		tenantID string
+		all      bool
+	)
+	replay := &cobra.Command{
+		Use:   "replay (--id MESSAGE_ID... | --tenant TENANT_ID | --all)",
+		Short: "Move the selected scan tasks back to the queue of their priority with their retries reset",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(ids) == 0 && tenantID == "" && !all {
+				return newUsageError("select the tasks to replay with --id, --tenant or --all")
+			}
+			return runCall(cmd, opts, []column{{"REPLAYED", "replayed"}}, func(client *Client) (*envelope, error) {
+				return client.Call(cmd.Context(), http.MethodPost, platformPrefix+"/scan-dead-letters/replay", nil, map[string]interface{}{
+					"message_ids": ids,
+					"tenant_id":   tenantID,
+					"all":         all,
+				})
+			})
+		},
+	}
+	replay.Flags().StringSliceVar(&ids, "id", nil, "message ID of a task to replay, repeatable or comma separated")
+	replay.Flags().StringVar(&tenantID, "tenant", "", "replay the tasks of a tenant")
+	replay.Flags().BoolVar(&all, "all", false, "replay every task")
+	replay.MarkFlagsMutuallyExclusive("id", "tenant", "all")
+
+	cmd.AddCommand(list, replay)
+	return cmd
+}
+
+// webhookDeliveryColumns are the columns of webhook deliveries in table output
+var webhookDeliveryColumns = []column{
+	{"ID", "id"}, {"WEBHOOK", "webhook_id"}, {"EVENT", "event_type"}, {"ATTEMPTS", "attempt_count"},
+	{"RESPONSE", "response_status"}, {"ERROR", "error_message"}, {"UPDATED", "updated_at"},
+}
+
+// newDLQWebhooksCommand creates the dlq webhooks command, for the dead-lettered deliveries of the
+// tenant of the profile
+func newDLQWebhooksCommand(opts *globalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "webhooks",
+		Short: "Dead-lettered webhook deliveries of the tenant",
+	}
+
+	var (
+		pageSize int
+		listAll  bool
+	)
+	list := &cobra.Command{
+		Use:   "list",
+		Short: "List the webhook deliveries that exhausted their retries",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCall(cmd, opts, webhookDeliveryColumns, func(client *Client) (*envelope, error) {
+				deliveries, err := client.List(cmd.Context(), apiPrefix+"/webhooks/deliveries/dead-letter", url.Values{"pageSize": {strconv.Itoa(pageSize)}}, listAll)
+				if err != nil {
+					return nil, err
+				}
+				return &envelope{Items: deliveries}, nil
+			})
+		},
+	}
+	list.Flags().IntVar(&pageSize, "page-size", 100, "number of deliveries fetched per request")
+	list.Flags().BoolVar(&listAll, "all", false, "list every page, not only the first")
+
+	var (
+		ids []string
+		all bool
+	)
+	replay := &cobra.Command{
+		Use:   "replay (--id DELIVERY_ID... | --all)",
+		Short: "Retry the selected webhook deliveries",
+		Long: `Retry the selected webhook deliveries. Every delivery is retried even when some of them
+cannot be; the command fails if any could not be retried.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(ids) == 0 && !all {
+				return newUsageError("select the deliveries to replay with --id or --all")
+			}
+			out, err := opts.printer(cmd.OutOrStdout())
+			if err != nil {
+				return err
+			}
+			client, err := opts.client()
+			if err != nil {
+				return err
+			}
+
+			ctx := cmd.Context()
+			if all {
+				deliveries, err := client.List(ctx, apiPrefix+"/webhooks/deliveries/dead-letter", url.Values{"pageSize": {"100"}}, true)
+				if err != nil {
+					return err
+				}
+				var listed []struct {
+					ID string `json:"id"`
+				}
+				if err := json.Unmarshal(deliveries, &listed); err != nil {
+					return fmt.Errorf("failed to decode response: %w", err)
+				}
+				for _, delivery := range listed {
+					ids = append(ids, delivery.ID)
+				}
+			}
+
+			type retried struct {
+				ID    string `json:"id"`
+				Error string `json:"error,omitempty"`
+			}
+			results := make([]retried, 0, len(ids))
+			failed := 0
+			for _, id := range ids {
+				result := retried{ID: id}
+				if _, err := client.Call(ctx, http.MethodPost, apiPrefix+"/webhooks/deliveries/"+url.PathEscape(id)+"/retry", nil, nil); err != nil {
+					result.Error = err.Error()
+					failed++
+				}
+				results = append(results, result)
+			}
+
+			data, err := json.Marshal(results)
+			if err != nil {
+				return err
+			}
+			if err := out.Print(data, []column{{"DELIVERY", "id"}, {"ERROR", "error"}}); err != nil {
+				return err
+			}
+			if failed > 0 {
+				return fmt.Errorf("%d of %d deliveries could not be retried", failed, len(results))
+			}
+			return nil
+		},
+	}
+	replay.Flags().StringSliceVar(&ids, "id", nil, "ID of a delivery to retry, repeatable or comma separated")
+	replay.Flags().BoolVar(&all, "all", false, "retry every dead-lettered delivery")
+	replay.MarkFlagsMutuallyExclusive("id", "all")
+
+	cmd.AddCommand(list, replay)
+	return cmd
+}
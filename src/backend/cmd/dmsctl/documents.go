@@ -0,0 +1,322 @@
+package main
+
+import (
+	"context"       // standard library
+	"crypto/sha256" // standard library
+	"encoding/hex"  // standard library
+	"encoding/json" // standard library
+	"fmt"           // standard library
+	"io"            // standard library
+	"net/http"      // standard library
+	"net/url"       // standard library
+	"os"            // standard library
+	"path/filepath" // standard library
+	"strings"       // standard library
+	"time"          // standard library
+
+	"github.com/spf13/cobra" // v1.8.0+
+)
+
+// Statuses of documents, as reported by the API
+const (
+	documentStatusProcessing = "processing"
+	documentStatusAvailable  = "available"
+)
+
+// pollInterval is how often --wait checks the status of a document or reindex job
+const pollInterval = 2 * time.Second
+
+// documentColumns are the columns of documents in table output
+var documentColumns = []column{
+	{"ID", "id"}, {"NAME", "name"}, {"FOLDER", "folder_id"}, {"SIZE", "size"}, {"STATUS", "status"}, {"UPDATED", "updated_at"},
+}
+
+// newUploadCommand creates the upload command
+func newUploadCommand(opts *globalOptions) *cobra.Command {
+	var (
+		folderID string
+		name     string
+		metadata []string
+		tags     []string
+		priority string
+		checksum bool
+		wait     bool
+		timeout  time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "upload FILE",
+		Short: "Upload a file as a new document",
+		Long: `Upload a file as a new document. The file is streamed, so files of any size can be
+uploaded. Its SHA-256 hash is sent along, and the API rejects the upload if the stored
+file does not match it.
+
+The document is virus scanned before it is available. With --wait, dmsctl waits for
+the scan and fails unless the document becomes available.`,
+		Example: `  dmsctl upload invoice.pdf --folder 3f1c... --metadata customer=acme --tag invoice --wait`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out, err := opts.printer(cmd.OutOrStdout())
+			if err != nil {
+				return err
+			}
+			client, err := opts.client()
+			if err != nil {
+				return err
+			}
+
+			fields := url.Values{"folder_id": {folderID}}
+			if name == "" {
+				name = filepath.Base(args[0])
+			}
+			fields.Set("name", name)
+			// The API reads the metadata field as a JSON object
+			if len(metadata) > 0 {
+				values := make(map[string]string, len(metadata))
+				for _, pair := range metadata {
+					key, value, ok := strings.Cut(pair, "=")
+					if !ok || key == "" {
+						return newUsageError("metadata %q is not KEY=VALUE", pair)
+					}
+					values[key] = value
+				}
+				encoded, err := json.Marshal(values)
+				if err != nil {
+					return err
+				}
+				fields.Set("metadata", string(encoded))
+			}
+			for _, tag := range tags {
+				fields.Add("tags", tag)
+			}
+			if priority != "" {
+				fields.Set("priority", priority)
+			}
+			if checksum {
+				sum, err := fileSHA256(args[0])
+				if err != nil {
+					return err
+				}
+				fields.Set("sha256", sum)
+			}
+
+			ctx := cmd.Context()
+			result, err := client.Upload(ctx, apiPrefix+"/documents", args[0], fields)
+			if err != nil {
+				return err
+			}
+			if !wait {
+				return out.PrintEnvelope(result, []column{{"DOCUMENT", "document_id"}, {"STATUS", "status"}, {"MESSAGE", "message"}})
+			}
+
+			var upload struct {
+				DocumentID string `json:"document_id"`
+			}
+			if err := decodePayload(result, &upload); err != nil {
+				return err
+			}
+			return waitForDocument(ctx, client, out, upload.DocumentID, timeout)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&folderID, "folder", "", "ID of the folder to upload the document to")
+	flags.StringVar(&name, "name", "", "name of the document, the file name by default")
+	flags.StringArrayVar(&metadata, "metadata", nil, "metadata of the document as KEY=VALUE, repeatable")
+	flags.StringArrayVar(&tags, "tag", nil, "tag of the document, repeatable")
+	flags.StringVar(&priority, "priority", "", "scan priority: high, normal or low")
+	flags.BoolVar(&checksum, "checksum", true, "send the SHA-256 hash of the file so the API verifies the stored copy")
+	flags.BoolVar(&wait, "wait", false, "wait until the document is scanned, and fail unless it becomes available")
+	flags.DurationVar(&timeout, "timeout", 10*time.Minute, "how long --wait waits")
+	_ = cmd.MarkFlagRequired("folder")
+	return cmd
+}
+
+// newDownloadCommand creates the download command
+func newDownloadCommand(opts *globalOptions) *cobra.Command {
+	var (
+		dest      string
+		versionID string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "download DOCUMENT_ID",
+		Short: "Download the content of a document",
+		Long: `Download the content of a document, or of one of its versions, to a file. The file is
+named after the document unless --dest is given; "--dest -" writes to stdout.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := opts.client()
+			if err != nil {
+				return err
+			}
+
+			path := apiPrefix + "/documents/" + url.PathEscape(args[0]) + "/content"
+			if versionID != "" {
+				path = apiPrefix + "/documents/" + url.PathEscape(args[0]) + "/versions/" + url.PathEscape(versionID) + "/content"
+			}
+			body, fileName, err := client.Download(cmd.Context(), path)
+			if err != nil {
+				return err
+			}
+			defer body.Close()
+
+			if dest == "-" {
+				_, err := io.Copy(cmd.OutOrStdout(), body)
+				return err
+			}
+			if dest == "" {
+				dest = fileName
+			}
+			if dest == "" || dest == "." || dest == string(filepath.Separator) {
+				dest = args[0]
+			}
+			written, err := writeFile(dest, body)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "Downloaded %d bytes to %s\n", written, dest)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&dest, "dest", "d", "", "file to write the content to, - for stdout")
+	cmd.Flags().StringVar(&versionID, "version", "", "ID of the version to download, the latest by default")
+	return cmd
+}
+
+// newGetCommand creates the get command, which shows the metadata of a document
+func newGetCommand(opts *globalOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get DOCUMENT_ID",
+		Short: "Show the metadata of a document",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out, err := opts.printer(cmd.OutOrStdout())
+			if err != nil {
+				return err
+			}
+			client, err := opts.client()
+			if err != nil {
+				return err
+			}
+
+			result, err := client.Call(cmd.Context(), http.MethodGet, apiPrefix+"/documents/"+url.PathEscape(args[0]), nil, nil)
+			if err != nil {
+				return err
+			}
+			return out.PrintEnvelope(result, append(documentColumns, column{"VERSION", "latest_version.id"}))
+		},
+	}
+}
+
+// newStatusCommand creates the status command, which shows the processing status of a document
+func newStatusCommand(opts *globalOptions) *cobra.Command {
+	var (
+		wait    bool
+		timeout time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "status DOCUMENT_ID",
+		Short: "Show the processing status of a document",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out, err := opts.printer(cmd.OutOrStdout())
+			if err != nil {
+				return err
+			}
+			client, err := opts.client()
+			if err != nil {
+				return err
+			}
+
+			if wait {
+				return waitForDocument(cmd.Context(), client, out, args[0], timeout)
+			}
+			result, err := client.Call(cmd.Context(), http.MethodGet, apiPrefix+"/documents/"+url.PathEscape(args[0])+"/status", nil, nil)
+			if err != nil {
+				return err
+			}
+			return out.PrintEnvelope(result, documentStatusColumns)
+		},
+	}
+
+	cmd.Flags().BoolVar(&wait, "wait", false, "wait until the document is processed, and fail unless it becomes available")
+	cmd.Flags().DurationVar(&timeout, "timeout", 10*time.Minute, "how long --wait waits")
+	return cmd
+}
+
+// documentStatusColumns are the columns of document statuses in table output
+var documentStatusColumns = []column{{"DOCUMENT", "document_id"}, {"STATUS", "status"}, {"PROGRESS", "processing_progress"}, {"MESSAGE", "message"}}
+
+// waitForDocument polls the status of a document until it is processed and prints it. Documents
+// that end up quarantined or failed are reported as an error, so that scripts stop.
+func waitForDocument(ctx context.Context, client *Client, out *printer, documentID string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	path := apiPrefix + "/documents/" + url.PathEscape(documentID) + "/status"
+	for {
+		result, err := client.Call(ctx, http.MethodGet, path, nil, nil)
+		if err != nil {
+			return err
+		}
+		var status struct {
+			Status string `json:"status"`
+		}
+		if err := decodePayload(result, &status); err != nil {
+			return err
+		}
+
+		if status.Status != documentStatusProcessing {
+			if err := out.PrintEnvelope(result, documentStatusColumns); err != nil {
+				return err
+			}
+			if status.Status != documentStatusAvailable {
+				return fmt.Errorf("document %s is %s", documentID, status.Status)
+			}
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("document %s is still processing after %s", documentID, timeout)
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// fileSHA256 returns the hex encoded SHA-256 hash of a file
+func fileSHA256(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", fmt.Errorf("failed to hash %s: %w", path, err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// writeFile writes r to a new file at path. A partial file is removed when the write fails, so that
+// an interrupted download is not mistaken for a complete one.
+func writeFile(path string, r io.Reader) (int64, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return 0, err
+	}
+
+	written, err := io.Copy(file, r)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+		return written, fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return written, nil
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"net/http" // standard library
+	"net/url"  // standard library
+	"strconv"  // standard library
+
+	"github.com/spf13/cobra" // v1.8.0+
+)
+
+// folderColumns are the columns of folders in table output
+var folderColumns = []column{{"ID", "id"}, {"NAME", "name"}, {"PARENT", "parentId"}, {"PATH", "path"}, {"UPDATED", "updatedAt"}}
+
+// newFoldersCommand creates the folders command and its subcommands
+func newFoldersCommand(opts *globalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "folders",
+		Aliases: []string{"folder"},
+		Short:   "Manage folders",
+	}
+	create := newFolderMutationCommand(opts, "create NAME", "Create a folder", cobra.ExactArgs(1), folderCreate)
+	create.Flags().String("parent", "", "ID of the parent folder, none for a top-level folder")
+
+	cmd.AddCommand(
+		newFoldersListCommand(opts),
+		newFoldersGetCommand(opts),
+		create,
+		newFolderMutationCommand(opts, "rename FOLDER_ID NAME", "Rename a folder", cobra.ExactArgs(2), folderRename),
+		newFolderMutationCommand(opts, "move FOLDER_ID NEW_PARENT_ID", "Move a folder under another folder", cobra.ExactArgs(2), folderMove),
+		newFolderMutationCommand(opts, "delete FOLDER_ID", "Delete a folder", cobra.ExactArgs(1), folderDelete),
+	)
+	return cmd
+}
+
+// newFoldersListCommand creates the folders list command
+func newFoldersListCommand(opts *globalOptions) *cobra.Command {
+	var (
+		parentID string
+		pageSize int
+		all      bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the top-level folders, or the subfolders of a folder",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out, err := opts.printer(cmd.OutOrStdout())
+			if err != nil {
+				return err
+			}
+			client, err := opts.client()
+			if err != nil {
+				return err
+			}
+
+			query := url.Values{"pageSize": {strconv.Itoa(pageSize)}}
+			if parentID != "" {
+				query.Set("parentId", parentID)
+			}
+			folders, err := client.List(cmd.Context(), apiPrefix+"/folders", query, all)
+			if err != nil {
+				return err
+			}
+			return out.Print(folders, folderColumns)
+		},
+	}
+
+	cmd.Flags().StringVar(&parentID, "parent", "", "ID of the parent folder")
+	cmd.Flags().IntVar(&pageSize, "page-size", 100, "number of folders fetched per request")
+	cmd.Flags().BoolVar(&all, "all", false, "list every page, not only the first")
+	return cmd
+}
+
+// newFoldersGetCommand creates the folders get command
+func newFoldersGetCommand(opts *globalOptions) *cobra.Command {
+	var path string
+
+	cmd := &cobra.Command{
+		Use:   "get [FOLDER_ID]",
+		Short: "Show a folder, by ID or by path",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if (len(args) == 1) == (path != "") {
+				return newUsageError("give either a folder ID or --path")
+			}
+			out, err := opts.printer(cmd.OutOrStdout())
+			if err != nil {
+				return err
+			}
+			client, err := opts.client()
+			if err != nil {
+				return err
+			}
+
+			var result *envelope
+			if path != "" {
+				result, err = client.Call(cmd.Context(), http.MethodGet, apiPrefix+"/folders/path", url.Values{"path": {path}}, nil)
+			} else {
+				result, err = client.Call(cmd.Context(), http.MethodGet, apiPrefix+"/folders/"+url.PathEscape(args[0]), nil, nil)
+			}
+			if err != nil {
+				return err
+			}
+			return out.PrintEnvelope(result, folderColumns)
+		},
+	}
+
+	cmd.Flags().StringVar(&path, "path", "", "path of the folder, such as /finance/invoices")
+	return cmd
+}
+
+// folderMutation sends the request of a folders subcommand changing a folder
+type folderMutation func(cmd *cobra.Command, client *Client, args []string) (*envelope, error)
+
+// newFolderMutationCommand creates a folders subcommand changing a folder, which prints the folder
+// as the API returns it, or its message for deletions
+func newFolderMutationCommand(opts *globalOptions, use, short string, args cobra.PositionalArgs, mutate folderMutation) *cobra.Command {
+	return &cobra.Command{
+		Use:   use,
+		Short: short,
+		Args:  args,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out, err := opts.printer(cmd.OutOrStdout())
+			if err != nil {
+				return err
+			}
+			client, err := opts.client()
+			if err != nil {
+				return err
+			}
+
+			result, err := mutate(cmd, client, args)
+			if err != nil {
+				return err
+			}
+			return out.PrintEnvelope(result, folderColumns)
+		},
+	}
+}
+
+// folderCreate creates a folder named by the first argument
+func folderCreate(cmd *cobra.Command, client *Client, args []string) (*envelope, error) {
+	parentID, _ := cmd.Flags().GetString("parent")
+	return client.Call(cmd.Context(), http.MethodPost, apiPrefix+"/folders", nil, map[string]string{
+		"name":     args[0],
+		"parentId": parentID,
+	})
+}
+
+// folderRename renames a folder
+func folderRename(cmd *cobra.Command, client *Client, args []string) (*envelope, error) {
+	return client.Call(cmd.Context(), http.MethodPut, apiPrefix+"/folders/"+url.PathEscape(args[0]), nil, map[string]string{
+		"name": args[1],
+	})
+}
+
+// folderMove moves a folder under another folder
+func folderMove(cmd *cobra.Command, client *Client, args []string) (*envelope, error) {
+	return client.Call(cmd.Context(), http.MethodPut, apiPrefix+"/folders/"+url.PathEscape(args[0])+"/move", nil, map[string]string{
+		"newParentId": args[1],
+	})
+}
+
+// folderDelete deletes a folder
+func folderDelete(cmd *cobra.Command, client *Client, args []string) (*envelope, error) {
+	return client.Call(cmd.Context(), http.MethodDelete, apiPrefix+"/folders/"+url.PathEscape(args[0]), nil, nil)
+}
@@ -0,0 +1,115 @@
+// Package main is dmsctl, the command-line client of the Document Management Platform API. It lets
+// administrators and SREs script uploads, downloads, folder and tenant administration, reindexing
+// and dead letter replay against the API, with named profiles and JSON output, instead of crafting
+// curl requests by hand.
+package main
+
+import (
+	"errors" // standard library
+	"fmt"    // standard library
+	"os"     // standard library
+
+	"github.com/spf13/cobra" // v1.8.0+
+)
+
+// Exit codes of dmsctl, so that scripts can tell usage mistakes from failed requests
+const (
+	exitFailure = 1
+	exitUsage   = 2
+)
+
+// usageError is returned by commands given invalid arguments or flags
+type usageError struct {
+	message string
+}
+
+func (e *usageError) Error() string {
+	return e.message
+}
+
+// newUsageError creates a usageError with a formatted message
+func newUsageError(format string, args ...interface{}) error {
+	return &usageError{message: fmt.Sprintf(format, args...)}
+}
+
+func main() {
+	if err := newRootCommand().Execute(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		var usage *usageError
+		if errors.As(err, &usage) {
+			os.Exit(exitUsage)
+		}
+		os.Exit(exitFailure)
+	}
+}
+
+// newRootCommand creates the dmsctl command with its subcommands and global flags
+func newRootCommand() *cobra.Command {
+	opts := &globalOptions{}
+
+	root := &cobra.Command{
+		Use:   "dmsctl",
+		Short: "Command-line client of the Document Management Platform API",
+		Long: `dmsctl calls the Document Management Platform API with the credentials of a profile.
+
+Sign in once with "dmsctl login", then run commands against the current profile or
+the one given with --profile. Every command prints a table, or the data of the API
+response as JSON with --output json.`,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+	// Errors of flag parsing are usage errors too
+	root.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return &usageError{message: err.Error()}
+	})
+
+	flags := root.PersistentFlags()
+	flags.StringVar(&opts.configPath, "config", envOr("DMSCTL_CONFIG", defaultConfigPath()), "path of the configuration file holding the profiles")
+	flags.StringVarP(&opts.profileName, "profile", "p", os.Getenv("DMSCTL_PROFILE"), "profile to use instead of the current one")
+	flags.StringVarP(&opts.output, "output", "o", envOr("DMSCTL_OUTPUT", outputTable), "output format: table or json")
+	flags.StringVar(&opts.apiURL, "api-url", os.Getenv("DMSCTL_API_URL"), "base URL of the API, overriding the profile")
+	flags.StringVar(&opts.token, "token", os.Getenv("DMSCTL_TOKEN"), "access token, overriding the credentials of the profile")
+
+	root.AddCommand(
+		newLoginCommand(opts),
+		newLogoutCommand(opts),
+		newProfileCommand(opts),
+		newUploadCommand(opts),
+		newDownloadCommand(opts),
+		newGetCommand(opts),
+		newStatusCommand(opts),
+		newFoldersCommand(opts),
+		newSearchCommand(opts),
+		newTenantCommand(opts),
+		newReindexCommand(opts),
+		newDLQCommand(opts),
+	)
+	return root
+}
+
+// envOr returns the value of an environment variable, or fallback when it is not set
+func envOr(name, fallback string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// runCall runs a command sending one request, and prints its response with the given columns, or
+// every field when there are none
+func runCall(cmd *cobra.Command, opts *globalOptions, columns []column, call func(client *Client) (*envelope, error)) error {
+	out, err := opts.printer(cmd.OutOrStdout())
+	if err != nil {
+		return err
+	}
+	client, err := opts.client()
+	if err != nil {
+		return err
+	}
+
+	result, err := call(client)
+	if err != nil {
+		return err
+	}
+	return out.PrintEnvelope(result, columns)
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"bytes"          // standard library
+	"encoding/json"  // standard library
+	"fmt"            // standard library
+	"io"             // standard library
+	"sort"           // standard library
+	"strings"        // standard library
+	"text/tabwriter" // standard library
+)
+
+// Output formats of dmsctl
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+// column is a column of table output, holding the field of the JSON payload it shows. Fields of
+// nested objects are named by their path, such as latest_version.id.
+type column struct {
+	header string
+	field  string
+}
+
+// printer prints API payloads as a table for people, or as JSON for scripts
+type printer struct {
+	out    io.Writer
+	format string
+}
+
+// Print prints a payload. In JSON the payload is printed as the API returned it, so that scripts
+// can rely on the documented schema; tables only show the given columns, and every field of
+// objects when no column is given.
+func (p *printer) Print(payload json.RawMessage, columns []column) error {
+	if p.format == outputJSON {
+		var indented bytes.Buffer
+		if err := json.Indent(&indented, payload, "", "  "); err != nil {
+			return fmt.Errorf("failed to format response: %w", err)
+		}
+		indented.WriteByte('\n')
+		_, err := indented.WriteTo(p.out)
+		return err
+	}
+
+	var value interface{}
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	switch value := value.(type) {
+	case []interface{}:
+		return p.printRows(value, columns)
+	case map[string]interface{}:
+		if len(columns) == 0 {
+			return p.printFields(value)
+		}
+		return p.printRows([]interface{}{value}, columns)
+	default:
+		_, err := fmt.Fprintln(p.out, formatValue(value))
+		return err
+	}
+}
+
+// PrintEnvelope prints the payload of a response envelope, or its message when it has no payload
+func (p *printer) PrintEnvelope(result *envelope, columns []column) error {
+	if result.isMessage() {
+		columns = []column{{"MESSAGE", "message"}}
+	}
+	return p.Print(result.payload(), columns)
+}
+
+// printRows prints objects as the rows of a table with the given columns
+func (p *printer) printRows(rows []interface{}, columns []column) error {
+	w := tabwriter.NewWriter(p.out, 0, 0, 2, ' ', 0)
+	headers := make([]string, len(columns))
+	for i, col := range columns {
+		headers[i] = col.header
+	}
+	fmt.Fprintln(w, strings.Join(headers, "\t"))
+
+	for _, row := range rows {
+		object, _ := row.(map[string]interface{})
+		cells := make([]string, len(columns))
+		for i, col := range columns {
+			cells[i] = formatValue(lookup(object, col.field))
+		}
+		fmt.Fprintln(w, strings.Join(cells, "\t"))
+	}
+	return w.Flush()
+}
+
+// printFields prints the fields of an object as name and value pairs, in alphabetical order
+func (p *printer) printFields(object map[string]interface{}) error {
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	w := tabwriter.NewWriter(p.out, 0, 0, 2, ' ', 0)
+	for _, name := range names {
+		fmt.Fprintf(w, "%s:\t%s\n", name, formatValue(object[name]))
+	}
+	return w.Flush()
+}
+
+// lookup returns the value of a field of an object, following the dots of nested fields
+func lookup(object map[string]interface{}, field string) interface{} {
+	var value interface{} = object
+	for _, name := range strings.Split(field, ".") {
+		nested, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = nested[name]
+	}
+	return value
+}
+
+// formatValue formats a JSON value for a table cell. Lists of scalars are joined with commas;
+// other lists and objects are shown as compact JSON.
+func formatValue(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case json.Number:
+		return value.String()
+	case bool:
+		return fmt.Sprint(value)
+	case []interface{}:
+		items := make([]string, 0, len(value))
+		for _, item := range value {
+			switch item.(type) {
+			case map[string]interface{}, []interface{}:
+				data, _ := json.Marshal(value)
+				return string(data)
+			}
+			items = append(items, formatValue(item))
+		}
+		return strings.Join(items, ",")
+	default:
+		data, _ := json.Marshal(value)
+		return string(data)
+	}
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"  // standard library
+	"fmt"      // standard library
+	"net/http" // standard library
+	"net/url"  // standard library
+	"time"     // standard library
+
+	"github.com/spf13/cobra" // v1.8.0+
+)
+
+// Statuses of reindex jobs that are over, as reported by the API
+const (
+	reindexStatusCompleted = "completed"
+	reindexStatusFailed    = "failed"
+)
+
+// reindexColumns are the columns of reindex jobs in table output
+var reindexColumns = []column{
+	{"ID", "id"}, {"STATUS", "status"}, {"PROGRESS", "progress"}, {"INDEXED", "indexed_documents"}, {"TOTAL", "total_documents"}, {"ERROR", "error"},
+}
+
+// newReindexCommand creates the reindex command, which rebuilds the search index of the tenant
+func newReindexCommand(opts *globalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reindex",
+		Short: "Rebuild the search index of the tenant",
+	}
+
+	var (
+		wait    bool
+		timeout time.Duration
+	)
+	start := &cobra.Command{
+		Use:   "start",
+		Short: "Schedule a rebuild of the search index, such as after a mapping change",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out, err := opts.printer(cmd.OutOrStdout())
+			if err != nil {
+				return err
+			}
+			client, err := opts.client()
+			if err != nil {
+				return err
+			}
+
+			result, err := client.Call(cmd.Context(), http.MethodPost, apiPrefix+"/search/reindex", nil, nil)
+			if err != nil {
+				return err
+			}
+			if !wait {
+				return out.PrintEnvelope(result, reindexColumns)
+			}
+			var job struct {
+				ID string `json:"id"`
+			}
+			if err := decodePayload(result, &job); err != nil {
+				return err
+			}
+			return waitForReindex(cmd.Context(), client, out, job.ID, timeout)
+		},
+	}
+
+	status := &cobra.Command{
+		Use:   "status JOB_ID",
+		Short: "Show the status and progress of a rebuild",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out, err := opts.printer(cmd.OutOrStdout())
+			if err != nil {
+				return err
+			}
+			client, err := opts.client()
+			if err != nil {
+				return err
+			}
+
+			if wait {
+				return waitForReindex(cmd.Context(), client, out, args[0], timeout)
+			}
+			result, err := client.Call(cmd.Context(), http.MethodGet, apiPrefix+"/search/reindex/"+url.PathEscape(args[0]), nil, nil)
+			if err != nil {
+				return err
+			}
+			return out.PrintEnvelope(result, reindexColumns)
+		},
+	}
+
+	for _, sub := range []*cobra.Command{start, status} {
+		sub.Flags().BoolVar(&wait, "wait", false, "wait until the rebuild is over, and fail unless it completes")
+		sub.Flags().DurationVar(&timeout, "timeout", time.Hour, "how long --wait waits")
+	}
+	cmd.AddCommand(start, status)
+	return cmd
+}
+
+// waitForReindex polls a reindex job until it is over and prints it. Failed jobs are reported as an
+// error, so that scripts stop.
+func waitForReindex(ctx context.Context, client *Client, out *printer, jobID string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	path := apiPrefix + "/search/reindex/" + url.PathEscape(jobID)
+	for {
+		result, err := client.Call(ctx, http.MethodGet, path, nil, nil)
+		if err != nil {
+			return err
+		}
+		var job struct {
+			Status string `json:"status"`
+			Error  string `json:"error"`
+		}
+		if err := decodePayload(result, &job); err != nil {
+			return err
+		}
+
+		if job.Status == reindexStatusCompleted || job.Status == reindexStatusFailed {
+			if err := out.PrintEnvelope(result, reindexColumns); err != nil {
+				return err
+			}
+			if job.Status == reindexStatusFailed {
+				return fmt.Errorf("reindex job %s failed: %s", jobID, job.Error)
+			}
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("reindex job %s is still %s after %s", jobID, job.Status, timeout)
+		case <-time.After(pollInterval):
+		}
+	}
+}
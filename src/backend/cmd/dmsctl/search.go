@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"      // standard library
+	"net/http" // standard library
+
+	"github.com/spf13/cobra" // v1.8.0+
+)
+
+// newSearchCommand creates the search command, which searches the content of documents
+func newSearchCommand(opts *globalOptions) *cobra.Command {
+	var (
+		folderID string
+		page     int
+		pageSize int
+		cursor   string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "search QUERY",
+		Short: "Search the content of documents, in the whole tenant or in a folder",
+		Long: `Search the content of documents, in the whole tenant or in a folder. One page of results
+is printed, and the flag giving the next page is printed to stderr when there is one.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out, err := opts.printer(cmd.OutOrStdout())
+			if err != nil {
+				return err
+			}
+			client, err := opts.client()
+			if err != nil {
+				return err
+			}
+
+			request := map[string]interface{}{
+				"query":     args[0],
+				"page":      page,
+				"page_size": pageSize,
+			}
+			if cursor != "" {
+				request["cursor"] = cursor
+			}
+			path := apiPrefix + "/search/content"
+			if folderID != "" {
+				request["folder_id"] = folderID
+				path = apiPrefix + "/search/folder"
+			}
+
+			result, err := client.Call(cmd.Context(), http.MethodPost, path, nil, request)
+			if err != nil {
+				return err
+			}
+			if err := out.PrintEnvelope(result, []column{
+				{"ID", "id"}, {"NAME", "name"}, {"FOLDER", "folder_id"}, {"SIZE", "size"}, {"STATUS", "status"}, {"RELEVANCE", "relevance"},
+			}); err != nil {
+				return err
+			}
+
+			if next := result.Pagination; next != nil && next.HasNext {
+				if next.NextCursor != "" {
+					fmt.Fprintf(cmd.ErrOrStderr(), "Next page: --cursor %s\n", next.NextCursor)
+				} else {
+					fmt.Fprintf(cmd.ErrOrStderr(), "Next page: --page %d\n", next.Page+1)
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&folderID, "folder", "", "ID of the folder to search in")
+	cmd.Flags().IntVar(&page, "page", 1, "page of results")
+	cmd.Flags().IntVar(&pageSize, "page-size", 20, "number of results per page")
+	cmd.Flags().StringVar(&cursor, "cursor", "", "continuation token of the next page, from a previous search")
+	return cmd
+}
@@ -0,0 +1,248 @@
+package main
+
+import (
+	"encoding/json" // standard library
+	"net/http"      // standard library
+	"net/url"       // standard library
+	"strconv"       // standard library
+	"strings"       // standard library
+
+	"github.com/spf13/cobra" // v1.8.0+
+)
+
+// userColumns are the columns of users in table output
+var userColumns = []column{{"ID", "id"}, {"USERNAME", "username"}, {"EMAIL", "email"}, {"STATUS", "status"}, {"ROLES", "roles"}, {"LOCKED UNTIL", "locked_until"}}
+
+// newTenantCommand creates the tenant command, for tenant administrators and platform operators
+func newTenantCommand(opts *globalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tenant",
+		Short: "Administer the tenant of the profile, or provision tenants as a platform operator",
+	}
+	cmd.AddCommand(
+		newTenantUsersCommand(opts),
+		newTenantUsageCommand(opts),
+		newTenantSettingsCommand(opts),
+		newTenantProvisionCommand(opts),
+	)
+	return cmd
+}
+
+// newTenantUsersCommand creates the tenant users command and its subcommands
+func newTenantUsersCommand(opts *globalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "users",
+		Short: "Manage the users of the tenant",
+	}
+
+	var (
+		pageSize int
+		all      bool
+	)
+	list := &cobra.Command{
+		Use:   "list",
+		Short: "List the users of the tenant",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCall(cmd, opts, userColumns, func(client *Client) (*envelope, error) {
+				users, err := client.List(cmd.Context(), apiPrefix+"/tenant/users", url.Values{"pageSize": {strconv.Itoa(pageSize)}}, all)
+				if err != nil {
+					return nil, err
+				}
+				return &envelope{Items: users}, nil
+			})
+		},
+	}
+	list.Flags().IntVar(&pageSize, "page-size", 100, "number of users fetched per request")
+	list.Flags().BoolVar(&all, "all", false, "list every page, not only the first")
+
+	var roles []string
+	invite := &cobra.Command{
+		Use:   "invite USERNAME EMAIL",
+		Short: "Invite a user to the tenant",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCall(cmd, opts, nil, func(client *Client) (*envelope, error) {
+				return client.Call(cmd.Context(), http.MethodPost, apiPrefix+"/tenant/users", nil, map[string]interface{}{
+					"username": args[0],
+					"email":    args[1],
+					"roles":    roles,
+				})
+			})
+		},
+	}
+	invite.Flags().StringSliceVar(&roles, "role", nil, "role of the user, repeatable or comma separated")
+
+	var assigned []string
+	assign := &cobra.Command{
+		Use:   "roles USER_ID",
+		Short: "Replace the roles of a user",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCall(cmd, opts, userColumns, func(client *Client) (*envelope, error) {
+				return client.Call(cmd.Context(), http.MethodPut, apiPrefix+"/tenant/users/"+url.PathEscape(args[0])+"/roles", nil, map[string]interface{}{
+					"roles": assigned,
+				})
+			})
+		},
+	}
+	assign.Flags().StringSliceVar(&assigned, "role", nil, "role of the user, repeatable or comma separated")
+	_ = assign.MarkFlagRequired("role")
+
+	cmd.AddCommand(
+		list,
+		invite,
+		newUserActionCommand(opts, "activate", "Activate a deactivated user"),
+		newUserActionCommand(opts, "deactivate", "Deactivate a user, revoking their sessions"),
+		newUserActionCommand(opts, "reset-password", "Reset the password of a user to a temporary one"),
+		assign,
+	)
+	return cmd
+}
+
+// newUserActionCommand creates a tenant users subcommand posting an action on a user
+func newUserActionCommand(opts *globalOptions, action, short string) *cobra.Command {
+	return &cobra.Command{
+		Use:   action + " USER_ID",
+		Short: short,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCall(cmd, opts, nil, func(client *Client) (*envelope, error) {
+				return client.Call(cmd.Context(), http.MethodPost, apiPrefix+"/tenant/users/"+url.PathEscape(args[0])+"/"+action, nil, nil)
+			})
+		},
+	}
+}
+
+// newTenantUsageCommand creates the tenant usage command
+func newTenantUsageCommand(opts *globalOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "usage",
+		Short: "Show the users, documents and storage of the tenant against its quotas",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCall(cmd, opts, nil, func(client *Client) (*envelope, error) {
+				return client.Call(cmd.Context(), http.MethodGet, apiPrefix+"/tenant/usage", nil, nil)
+			})
+		},
+	}
+}
+
+// newTenantSettingsCommand creates the tenant settings command and its subcommands
+func newTenantSettingsCommand(opts *globalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "settings",
+		Short: "Show and change the settings of the tenant",
+	}
+
+	get := &cobra.Command{
+		Use:   "get",
+		Short: "Show the settings of the tenant",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTenantSettings(cmd, opts, func(client *Client) (*envelope, error) {
+				return client.Call(cmd.Context(), http.MethodGet, apiPrefix+"/tenant/settings", nil, nil)
+			})
+		},
+	}
+
+	var unset []string
+	set := &cobra.Command{
+		Use:     "set [KEY=VALUE...]",
+		Short:   "Change settings of the tenant",
+		Example: `  dmsctl tenant settings set branding.primary_color=#00529b --unset branding.logo_url`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			settings := map[string]string{}
+			for _, pair := range args {
+				key, value, ok := strings.Cut(pair, "=")
+				if !ok || key == "" {
+					return newUsageError("setting %q is not KEY=VALUE", pair)
+				}
+				settings[key] = value
+			}
+			// An empty value restores the default of a setting
+			for _, key := range unset {
+				settings[key] = ""
+			}
+			if len(settings) == 0 {
+				return newUsageError("give settings as KEY=VALUE or with --unset")
+			}
+
+			return runTenantSettings(cmd, opts, func(client *Client) (*envelope, error) {
+				return client.Call(cmd.Context(), http.MethodPatch, apiPrefix+"/tenant/settings", nil, map[string]interface{}{
+					"settings": settings,
+				})
+			})
+		},
+	}
+	set.Flags().StringArrayVar(&unset, "unset", nil, "setting to restore to its default, repeatable")
+
+	cmd.AddCommand(get, set)
+	return cmd
+}
+
+// runTenantSettings runs a tenant settings subcommand. Tables list the settings one per line.
+func runTenantSettings(cmd *cobra.Command, opts *globalOptions, call func(client *Client) (*envelope, error)) error {
+	out, err := opts.printer(cmd.OutOrStdout())
+	if err != nil {
+		return err
+	}
+	client, err := opts.client()
+	if err != nil {
+		return err
+	}
+
+	result, err := call(client)
+	if err != nil {
+		return err
+	}
+	if out.format == outputJSON {
+		return out.PrintEnvelope(result, nil)
+	}
+	var settings struct {
+		Settings json.RawMessage `json:"settings"`
+	}
+	if err := decodePayload(result, &settings); err != nil {
+		return err
+	}
+	return out.Print(settings.Settings, nil)
+}
+
+// newTenantProvisionCommand creates the tenant provision command, which calls the platform API
+func newTenantProvisionCommand(opts *globalOptions) *cobra.Command {
+	var (
+		adminUsername    string
+		adminEmail       string
+		folderTemplateID string
+		requestedBy      string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "provision NAME",
+		Short: "Provision a tenant with its administrator (platform operators)",
+		Long: `Provision a tenant with its default roles, its administrator and its folders. The
+platform API is called with the operator token of the profile. The temporary password of the
+administrator is printed unless it is emailed.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCall(cmd, opts, nil, func(client *Client) (*envelope, error) {
+				return client.Call(cmd.Context(), http.MethodPost, platformPrefix+"/tenants", nil, map[string]string{
+					"name":               args[0],
+					"admin_username":     adminUsername,
+					"admin_email":        adminEmail,
+					"folder_template_id": folderTemplateID,
+					"requested_by":       requestedBy,
+				})
+			})
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&adminUsername, "admin-username", "", "username of the tenant administrator")
+	flags.StringVar(&adminEmail, "admin-email", "", "email address of the tenant administrator")
+	flags.StringVar(&folderTemplateID, "folder-template", "", "ID of the folder template to create the folders of the tenant from")
+	flags.StringVar(&requestedBy, "requested-by", envOr("USER", ""), "who requested the tenant, recorded in the audit log")
+	_ = cmd.MarkFlagRequired("admin-username")
+	_ = cmd.MarkFlagRequired("admin-email")
+	return cmd
+}
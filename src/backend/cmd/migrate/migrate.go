@@ -0,0 +1,188 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"../../infrastructure/persistence/postgres"
+	"../../pkg/config"
+)
+
+// migrateUsage describes the migrate service
+const migrateUsage = `Usage:
+  migrate up [N]
+      Apply all pending migrations, or the next N.
+  migrate down N
+      Revert the N most recently applied migrations.
+  migrate status
+      List the migrations, when each was applied and whether it was edited since.
+  migrate verify
+      Check the applied migrations against the checksums they were applied with.
+  migrate baseline VERSION
+      Record the migrations up to VERSION as applied without running them, for a database whose
+      schema was created before the migration history.
+`
+
+// Run runs the migrate service with its arguments and returns the exit code. The migrations are
+// the SQL files built into the binary, so a release migrates the schema to exactly the version its
+// code expects, and each applied migration is checked against its checksum before any other runs.
+func Run(cfg config.Config, args []string) int {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, migrateUsage)
+		return 2
+	}
+
+	migrations, err := postgres.EmbeddedMigrations()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load migrations: %v\n", err)
+		return 1
+	}
+	if err := postgres.Init(cfg.Database); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize database: %v\n", err)
+		return 1
+	}
+	defer postgres.Close()
+	db, err := postgres.GetDB()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to get database: %v\n", err)
+		return 1
+	}
+
+	ctx := context.Background()
+	migrator := postgres.NewMigrator(db, migrations)
+	switch args[0] {
+	case "up":
+		return migrateUp(ctx, migrator, args[1:], os.Stdout)
+	case "down":
+		return migrateDown(ctx, migrator, args[1:], os.Stdout)
+	case "status":
+		return migrationStatus(ctx, migrator, os.Stdout)
+	case "verify":
+		return verifyMigrations(ctx, migrator, os.Stdout)
+	case "baseline":
+		return baselineMigrations(ctx, migrator, args[1:], os.Stdout)
+	default:
+		fmt.Fprint(os.Stderr, migrateUsage)
+		return 2
+	}
+}
+
+// migrateUp applies the pending migrations, or the next N
+func migrateUp(ctx context.Context, migrator *postgres.Migrator, args []string, out io.Writer) int {
+	steps := 0
+	if len(args) > 0 {
+		parsed, err := strconv.Atoi(args[0])
+		if err != nil || parsed <= 0 {
+			fmt.Fprint(os.Stderr, migrateUsage)
+			return 2
+		}
+		steps = parsed
+	}
+
+	done, err := migrator.Up(ctx, steps)
+	printMigrations(out, "Applied", done)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to apply migrations: %v\n", err)
+		return 1
+	}
+	fmt.Fprintf(out, "%d migration(s) applied\n", len(done))
+	return 0
+}
+
+// migrateDown reverts the N most recently applied migrations
+func migrateDown(ctx context.Context, migrator *postgres.Migrator, args []string, out io.Writer) int {
+	if len(args) != 1 {
+		fmt.Fprint(os.Stderr, migrateUsage)
+		return 2
+	}
+	steps, err := strconv.Atoi(args[0])
+	if err != nil || steps <= 0 {
+		fmt.Fprint(os.Stderr, migrateUsage)
+		return 2
+	}
+
+	done, err := migrator.Down(ctx, steps)
+	printMigrations(out, "Reverted", done)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to revert migrations: %v\n", err)
+		return 1
+	}
+	fmt.Fprintf(out, "%d migration(s) reverted\n", len(done))
+	return 0
+}
+
+// migrationStatus prints the migrations as a table
+func migrationStatus(ctx context.Context, migrator *postgres.Migrator, out io.Writer) int {
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to read migration status: %v\n", err)
+		return 1
+	}
+
+	pending := 0
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED\tCHECKSUM")
+	for _, status := range statuses {
+		applied, checksum := "pending", "-"
+		if status.AppliedAt != nil {
+			applied, checksum = status.AppliedAt.Format(time.RFC3339), "ok"
+			if status.Modified {
+				checksum = "modified"
+			}
+		} else {
+			pending++
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", status.Version, status.Name, applied, checksum)
+	}
+	w.Flush()
+	fmt.Fprintf(out, "%d migration(s), %d pending\n", len(statuses), pending)
+	return 0
+}
+
+// verifyMigrations checks the applied migrations against their checksums
+func verifyMigrations(ctx context.Context, migrator *postgres.Migrator, out io.Writer) int {
+	if err := migrator.Verify(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return 1
+	}
+	pending, err := migrator.Pending(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to read pending migrations: %v\n", err)
+		return 1
+	}
+	fmt.Fprintf(out, "Applied migrations match their checksums, %d pending\n", len(pending))
+	return 0
+}
+
+// baselineMigrations records the migrations up to a version as applied without running them
+func baselineMigrations(ctx context.Context, migrator *postgres.Migrator, args []string, out io.Writer) int {
+	if len(args) != 1 {
+		fmt.Fprint(os.Stderr, migrateUsage)
+		return 2
+	}
+	version, err := strconv.Atoi(args[0])
+	if err != nil {
+		fmt.Fprint(os.Stderr, migrateUsage)
+		return 2
+	}
+
+	done, err := migrator.Baseline(ctx, version)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to baseline migrations: %v\n", err)
+		return 1
+	}
+	fmt.Fprintf(out, "%d migration(s) recorded as applied\n", len(done))
+	return 0
+}
+
+// printMigrations prints a line per migration prefixed with what was done to it
+func printMigrations(out io.Writer, action string, migrations []postgres.Migration) {
+	for _, migration := range migrations {
+		fmt.Fprintf(out, "%s %d_%s\n", action, migration.Version, migration.Name)
+	}
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context" // standard library
+	"net"     // standard library
+	"strings" // standard library
+
+	"golang.org/x/crypto/ssh" // v0.14.0+
+
+	"src/backend/application/usecases" // For the sign-in result
+	"src/backend/domain/models"        // For users
+	"src/backend/domain/repositories"  // For looking up users
+	"src/backend/domain/services"      // For the network policies of tenants
+	"src/backend/pkg/errors"           // For typed authentication errors
+	"src/backend/pkg/logger"           // For logging sign-in attempts
+)
+
+// Permission extensions carrying the authenticated user through the SSH connection
+const (
+	extensionTenantID = "tenant_id"
+	extensionUserID   = "user_id"
+)
+
+// Authenticator signs users in with a password. It is implemented by usecases.AuthUseCase.
+type Authenticator interface {
+	Login(ctx context.Context, tenantID, usernameOrEmail, password string) (*usecases.LoginResult, error)
+}
+
+// passwordAuthenticator verifies SFTP passwords through the same sign-in as the API. SSH user names
+// have the form <username or email>@<tenant ID>. Failed attempts count towards the account lockout,
+// and accounts that need a second factor or a new password cannot use password-only SFTP.
+// Connections from networks the tenant's network policy does not allow are refused.
+type passwordAuthenticator struct {
+	authenticator        Authenticator
+	userRepo             repositories.UserRepository
+	networkPolicyService services.NetworkPolicyService
+}
+
+// newPasswordAuthenticator creates a new passwordAuthenticator
+func newPasswordAuthenticator(authenticator Authenticator, userRepo repositories.UserRepository, networkPolicyService services.NetworkPolicyService) *passwordAuthenticator {
+	return &passwordAuthenticator{
+		authenticator:        authenticator,
+		userRepo:             userRepo,
+		networkPolicyService: networkPolicyService,
+	}
+}
+
+// PasswordCallback implements ssh.ServerConfig.PasswordCallback
+func (a *passwordAuthenticator) PasswordCallback(conn ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+	ctx := context.Background()
+	user, err := a.authenticate(ctx, conn.User(), string(password))
+	if err != nil {
+		logger.Info("SFTP authentication failed", "user", conn.User(), "remote_addr", conn.RemoteAddr().String(), "error", err.Error())
+		return nil, err
+	}
+	if err := a.checkNetworkPolicy(ctx, user, conn.RemoteAddr()); err != nil {
+		logger.Info("SFTP connection refused by tenant network policy", "user_id", user.ID, "tenant_id", user.TenantID, "remote_addr", conn.RemoteAddr().String())
+		return nil, err
+	}
+
+	logger.Info("SFTP authentication successful", "user_id", user.ID, "tenant_id", user.TenantID, "remote_addr", conn.RemoteAddr().String())
+	return &ssh.Permissions{Extensions: map[string]string{
+		extensionTenantID: user.TenantID,
+		extensionUserID:   user.ID,
+	}}, nil
+}
+
+// authenticate returns the user a user name and password belong to
+func (a *passwordAuthenticator) authenticate(ctx context.Context, sshUser, password string) (*models.User, error) {
+	separator := strings.LastIndex(sshUser, "@")
+	if separator <= 0 || separator == len(sshUser)-1 {
+		return nil, errors.NewAuthenticationError("user name must have the form <username>@<tenant ID>")
+	}
+	usernameOrEmail, tenantID := sshUser[:separator], sshUser[separator+1:]
+
+	result, err := a.authenticator.Login(ctx, tenantID, usernameOrEmail, password)
+	if err != nil {
+		return nil, err
+	}
+	if result.MFARequired || result.AccessToken == "" {
+		// A second factor cannot be entered over password-only SFTP
+		return nil, errors.NewAuthenticationError("accounts with a second factor cannot use SFTP")
+	}
+	if result.PasswordExpired {
+		return nil, errors.NewAuthenticationError("password has expired")
+	}
+
+	user, err := a.userRepo.GetByUsername(ctx, usernameOrEmail, tenantID)
+	if errors.IsResourceNotFoundError(err) {
+		user, err = a.userRepo.GetByEmail(ctx, usernameOrEmail, tenantID)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve user")
+	}
+
+	// The gateway only ingests documents
+	if !user.CanWrite() {
+		return nil, errors.NewAuthorizationError("uploading documents is not allowed")
+	}
+
+	return user, nil
+}
+
+// checkNetworkPolicy checks that the network policy of the user's tenant allows the remote address
+// of the connection
+func (a *passwordAuthenticator) checkNetworkPolicy(ctx context.Context, user *models.User, remoteAddr net.Addr) error {
+	var ip net.IP
+	if addr, ok := remoteAddr.(*net.TCPAddr); ok {
+		ip = addr.IP
+	}
+	return a.networkPolicyService.CheckAccess(ctx, user.TenantID, user.ID, ip)
+}
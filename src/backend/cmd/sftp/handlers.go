@@ -0,0 +1,396 @@
+package main
+
+import (
+	"context" // standard library
+	"io"      // standard library
+	"mime"    // standard library
+	"os"      // standard library
+	"path"    // standard library
+	"sync"    // standard library
+	"time"    // standard library
+
+	"github.com/pkg/sftp" // v1.13.0+
+
+	"src/backend/application/usecases" // For uploading documents and managing folders
+	"src/backend/domain/models"        // For folders and documents
+	"src/backend/domain/services"      // For the scan priority of ingested files and upload limits
+	"src/backend/pkg/errors"           // For checking error types
+	"src/backend/pkg/logger"           // For logging ingestion
+	"src/backend/pkg/utils"            // For paging through folder contents
+)
+
+// defaultContentType is the content type of uploads whose extension has no known type
+const defaultContentType = "application/octet-stream"
+
+// FolderUseCase is the part of the folder use case the SFTP gateway needs
+type FolderUseCase interface {
+	CreateFolder(ctx context.Context, name, parentID, tenantID, userID string) (string, error)
+	GetFolderByPath(ctx context.Context, path, tenantID, userID string) (*models.Folder, error)
+	ListFolderContents(ctx context.Context, id, tenantID, userID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Folder], utils.PaginatedResult[models.Document], error)
+	ListRootFolders(ctx context.Context, tenantID, userID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Folder], error)
+}
+
+// uploadSizeLimiter caps how large a file a user can push, so that uploads are refused while they
+// are written rather than after they filled the disk. The limit is the smaller of the user's maximum
+// file size and the tenant's remaining storage quota.
+type uploadSizeLimiter struct {
+	quotaService       services.QuotaService
+	uploadLimitService services.UploadLimitService
+}
+
+// MaxUploadSize returns the largest file the user may upload, or -1 when there is no limit
+func (l *uploadSizeLimiter) MaxUploadSize(ctx context.Context, tenantID, userID string) (int64, error) {
+	maxSize := int64(-1)
+
+	limit, err := l.uploadLimitService.GetEffectiveLimit(ctx, tenantID, userID)
+	if err != nil {
+		return 0, err
+	}
+	if limit.MaxFileSizeBytes > 0 {
+		maxSize = limit.MaxFileSizeBytes
+	}
+
+	quota, err := l.quotaService.GetQuota(ctx, tenantID)
+	if err != nil {
+		return 0, err
+	}
+	if remaining := quota.RemainingStorageBytes(); remaining >= 0 && (maxSize < 0 || remaining < maxSize) {
+		maxSize = remaining
+	}
+
+	return maxSize, nil
+}
+
+// ingestHandlers implements the SFTP request handlers of a session. Directories map to folders by
+// their path. Uploaded files are ingested through UploadDocument, so they are scanned and indexed
+// like any other upload. The gateway accepts uploads only: files cannot be read, replaced, renamed
+// or removed.
+type ingestHandlers struct {
+	ctx             context.Context
+	folderUseCase   FolderUseCase
+	documentUseCase usecases.DocumentUseCase
+	sizeLimiter     *uploadSizeLimiter
+	tenantID        string
+	userID          string
+}
+
+// newHandlers creates the SFTP request handlers of a session of a user
+func newHandlers(ctx context.Context, folderUseCase FolderUseCase, documentUseCase usecases.DocumentUseCase, sizeLimiter *uploadSizeLimiter, tenantID, userID string) sftp.Handlers {
+	h := &ingestHandlers{
+		ctx:             ctx,
+		folderUseCase:   folderUseCase,
+		documentUseCase: documentUseCase,
+		sizeLimiter:     sizeLimiter,
+		tenantID:        tenantID,
+		userID:          userID,
+	}
+	return sftp.Handlers{FileGet: h, FilePut: h, FileCmd: h, FileList: h}
+}
+
+// Fileread refuses downloads; the gateway only ingests documents
+func (h *ingestHandlers) Fileread(r *sftp.Request) (io.ReaderAt, error) {
+	return nil, sftp.ErrSSHFxPermissionDenied
+}
+
+// Filewrite opens a new document in an existing folder for writing
+func (h *ingestHandlers) Filewrite(r *sftp.Request) (io.WriterAt, error) {
+	parent, name := path.Split(cleanPath(r.Filepath))
+	if name == "" || cleanPath(parent) == models.PathSeparator {
+		// Documents are always stored in a folder
+		return nil, sftp.ErrSSHFxPermissionDenied
+	}
+
+	folder, err := h.folderUseCase.GetFolderByPath(h.ctx, cleanPath(parent), h.tenantID, h.userID)
+	if err != nil {
+		return nil, toSFTPError(err)
+	}
+	existing, err := h.findDocument(folder.ID, name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		// Documents cannot be replaced
+		return nil, sftp.ErrSSHFxPermissionDenied
+	}
+
+	maxSize, err := h.sizeLimiter.MaxUploadSize(h.ctx, h.tenantID, h.userID)
+	if err != nil {
+		return nil, toSFTPError(err)
+	}
+	if maxSize == 0 {
+		// The tenant's storage quota is used up
+		return nil, sftp.ErrSSHFxFailure
+	}
+
+	buffer, err := os.CreateTemp("", "sftp-upload-*")
+	if err != nil {
+		return nil, err
+	}
+	return &upload{handlers: h, name: name, folderID: folder.ID, maxSize: maxSize, buffer: buffer}, nil
+}
+
+// Filecmd creates folders. Setting attributes is accepted and ignored, since clients set times
+// after uploads; other commands are refused.
+func (h *ingestHandlers) Filecmd(r *sftp.Request) error {
+	switch r.Method {
+	case "Mkdir":
+		parent, name := path.Split(cleanPath(r.Filepath))
+		if name == "" {
+			return sftp.ErrSSHFxFailure
+		}
+		parentID := ""
+		if cleanPath(parent) != models.PathSeparator {
+			folder, err := h.folderUseCase.GetFolderByPath(h.ctx, cleanPath(parent), h.tenantID, h.userID)
+			if err != nil {
+				return toSFTPError(err)
+			}
+			parentID = folder.ID
+		}
+		_, err := h.folderUseCase.CreateFolder(h.ctx, name, parentID, h.tenantID, h.userID)
+		return toSFTPError(err)
+	case "Setstat":
+		return nil
+	default:
+		return sftp.ErrSSHFxPermissionDenied
+	}
+}
+
+// Filelist lists folders and describes folders and documents
+func (h *ingestHandlers) Filelist(r *sftp.Request) (sftp.ListerAt, error) {
+	name := cleanPath(r.Filepath)
+	switch r.Method {
+	case "List":
+		infos, err := h.list(name)
+		if err != nil {
+			return nil, err
+		}
+		return listerAt(infos), nil
+	case "Stat":
+		info, err := h.stat(name)
+		if err != nil {
+			return nil, err
+		}
+		return listerAt{info}, nil
+	default:
+		return nil, sftp.ErrSSHFxOpUnsupported
+	}
+}
+
+// stat describes the folder or document at a path
+func (h *ingestHandlers) stat(name string) (os.FileInfo, error) {
+	if name == models.PathSeparator {
+		return &fileInfo{name: models.PathSeparator, dir: true}, nil
+	}
+
+	folder, err := h.folderUseCase.GetFolderByPath(h.ctx, name, h.tenantID, h.userID)
+	if err == nil {
+		return &fileInfo{name: folder.Name, modTime: folder.UpdatedAt, dir: true}, nil
+	}
+	if !errors.IsResourceNotFoundError(err) {
+		return nil, toSFTPError(err)
+	}
+
+	parent, base := path.Split(name)
+	if cleanPath(parent) == models.PathSeparator {
+		return nil, os.ErrNotExist
+	}
+	parentFolder, err := h.folderUseCase.GetFolderByPath(h.ctx, cleanPath(parent), h.tenantID, h.userID)
+	if err != nil {
+		return nil, toSFTPError(err)
+	}
+	document, err := h.findDocument(parentFolder.ID, base)
+	if err != nil {
+		return nil, err
+	}
+	if document == nil {
+		return nil, os.ErrNotExist
+	}
+	return &fileInfo{name: document.Name, size: document.Size, modTime: document.UpdatedAt}, nil
+}
+
+// list lists the subfolders and documents of the folder at a path, or the root folders
+func (h *ingestHandlers) list(name string) ([]os.FileInfo, error) {
+	var folderID string
+	if name != models.PathSeparator {
+		folder, err := h.folderUseCase.GetFolderByPath(h.ctx, name, h.tenantID, h.userID)
+		if err != nil {
+			return nil, toSFTPError(err)
+		}
+		folderID = folder.ID
+	}
+
+	var infos []os.FileInfo
+	for page := 1; ; page++ {
+		pagination := utils.NewPagination(page, utils.MaxPageSize)
+		var folders utils.PaginatedResult[models.Folder]
+		var documents utils.PaginatedResult[models.Document]
+		var err error
+		if folderID == "" {
+			folders, err = h.folderUseCase.ListRootFolders(h.ctx, h.tenantID, h.userID, pagination)
+		} else {
+			folders, documents, err = h.folderUseCase.ListFolderContents(h.ctx, folderID, h.tenantID, h.userID, pagination)
+		}
+		if err != nil {
+			return nil, toSFTPError(err)
+		}
+
+		for _, folder := range folders.Items {
+			infos = append(infos, &fileInfo{name: folder.Name, modTime: folder.UpdatedAt, dir: true})
+		}
+		for _, document := range documents.Items {
+			infos = append(infos, &fileInfo{name: document.Name, size: document.Size, modTime: document.UpdatedAt})
+		}
+		if !folders.Pagination.HasNext && !documents.Pagination.HasNext {
+			return infos, nil
+		}
+	}
+}
+
+// findDocument finds the document with a name in a folder, returning nil if there is none
+func (h *ingestHandlers) findDocument(folderID, name string) (*models.Document, error) {
+	for page := 1; ; page++ {
+		folders, documents, err := h.folderUseCase.ListFolderContents(h.ctx, folderID, h.tenantID, h.userID, utils.NewPagination(page, utils.MaxPageSize))
+		if err != nil {
+			return nil, toSFTPError(err)
+		}
+		for i := range documents.Items {
+			if documents.Items[i].Name == name {
+				return &documents.Items[i], nil
+			}
+		}
+		if !folders.Pagination.HasNext && !documents.Pagination.HasNext {
+			return nil, nil
+		}
+	}
+}
+
+// upload buffers an uploaded file in a temporary file, since clients may write out of order and
+// the document's size must be known before it is uploaded. It is ingested when it is closed. Writes
+// past maxSize are refused, and a file that outgrew it is not ingested.
+type upload struct {
+	handlers *ingestHandlers
+	name     string
+	folderID string
+	maxSize  int64 // -1 when the file size is not limited
+
+	mu       sync.Mutex
+	buffer   *os.File
+	size     int64
+	tooLarge bool
+}
+
+// WriteAt buffers a part of the file
+func (u *upload) WriteAt(p []byte, offset int64) (int, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if u.maxSize >= 0 && offset+int64(len(p)) > u.maxSize {
+		u.tooLarge = true
+		return 0, sftp.ErrSSHFxFailure
+	}
+
+	n, err := u.buffer.WriteAt(p, offset)
+	if end := offset + int64(n); end > u.size {
+		u.size = end
+	}
+	return n, err
+}
+
+// Close ingests the buffered file as a document and removes the buffer
+func (u *upload) Close() error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	defer os.Remove(u.buffer.Name())
+	defer u.buffer.Close()
+
+	h := u.handlers
+	if u.tooLarge {
+		logger.InfoContext(h.ctx, "SFTP upload refused for exceeding the upload limit",
+			"name", u.name,
+			"folder_id", u.folderID,
+			"max_size", u.maxSize)
+		return sftp.ErrSSHFxFailure
+	}
+
+	if _, err := u.buffer.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	contentType := mime.TypeByExtension(path.Ext(u.name))
+	if contentType == "" {
+		contentType = defaultContentType
+	}
+
+	// Files pushed over SFTP come from automated systems, so interactive uploads are scanned first
+	documentID, err := h.documentUseCase.UploadDocument(h.ctx, u.name, contentType, u.size, u.folderID, h.tenantID, h.userID, u.buffer, nil, services.ScanPriorityLow, "")
+	if err != nil {
+		logger.ErrorContext(h.ctx, "SFTP ingestion failed", "name", u.name, "folder_id", u.folderID, "error", err.Error())
+		return toSFTPError(err)
+	}
+
+	logger.InfoContext(h.ctx, "SFTP file ingested",
+		"document_id", documentID,
+		"folder_id", u.folderID,
+		"tenant_id", h.tenantID,
+		"size", u.size)
+	return nil
+}
+
+// listerAt serves a fixed list of file infos
+type listerAt []os.FileInfo
+
+// ListAt copies the file infos from an offset
+func (l listerAt) ListAt(infos []os.FileInfo, offset int64) (int, error) {
+	if offset >= int64(len(l)) {
+		return 0, io.EOF
+	}
+	n := copy(infos, l[offset:])
+	if n < len(infos) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// fileInfo describes a folder or a document
+type fileInfo struct {
+	name    string
+	size    int64
+	modTime time.Time
+	dir     bool
+}
+
+func (i *fileInfo) Name() string       { return i.name }
+func (i *fileInfo) Size() int64        { return i.size }
+func (i *fileInfo) ModTime() time.Time { return i.modTime }
+func (i *fileInfo) IsDir() bool        { return i.dir }
+func (i *fileInfo) Sys() interface{}   { return nil }
+
+// Mode returns the permission bits of a folder or a document; access is enforced by the use cases
+func (i *fileInfo) Mode() os.FileMode {
+	if i.dir {
+		return os.ModeDir | 0755
+	}
+	return 0644
+}
+
+// cleanPath returns the canonical form of an SFTP path
+func cleanPath(name string) string {
+	if name == "" {
+		return models.PathSeparator
+	}
+	return path.Clean(models.PathSeparator + name)
+}
+
+// toSFTPError converts an application error into an SFTP status
+func toSFTPError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.IsResourceNotFoundError(err):
+		return os.ErrNotExist
+	case errors.IsAuthorizationError(err), errors.IsAuthenticationError(err), errors.IsSecurityError(err):
+		return sftp.ErrSSHFxPermissionDenied
+	default:
+		return sftp.ErrSSHFxFailure
+	}
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net"
+	"os"
+	"testing"
+
+	"github.com/pkg/sftp"                // v1.13.0+
+	"github.com/stretchr/testify/assert" // v1.8.0+
+
+	"src/backend/application/usecases" // For sign-in results
+	"src/backend/domain/models"        // For the authenticated user
+	"src/backend/domain/services"      // For the folder service behind the folder use case
+	"src/backend/pkg/errors"           // For typed errors
+	"src/backend/pkg/utils"            // For paged folder contents
+)
+
+// TestCleanPath tests the canonical form of SFTP paths
+func TestCleanPath(t *testing.T) {
+	assert.Equal(t, "/", cleanPath(""))
+	assert.Equal(t, "/", cleanPath("/"))
+	assert.Equal(t, "/inbox/invoices", cleanPath("inbox/invoices/"))
+	assert.Equal(t, "/inbox", cleanPath("/inbox/invoices/.."))
+}
+
+// TestListerAt tests that file infos are listed in pages and the end of the list is reported
+func TestListerAt(t *testing.T) {
+	lister := listerAt{&fileInfo{name: "a"}, &fileInfo{name: "b"}, &fileInfo{name: "c"}}
+
+	page := make([]os.FileInfo, 2)
+	n, err := lister.ListAt(page, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, "b", page[1].Name())
+
+	n, err = lister.ListAt(page, 2)
+	assert.Equal(t, io.EOF, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, "c", page[0].Name())
+
+	n, err = lister.ListAt(page, 3)
+	assert.Equal(t, io.EOF, err)
+	assert.Equal(t, 0, n)
+}
+
+// TestToSFTPError tests the mapping of application errors to SFTP statuses
+func TestToSFTPError(t *testing.T) {
+	assert.NoError(t, toSFTPError(nil))
+	assert.Equal(t, os.ErrNotExist, toSFTPError(errors.NewResourceNotFoundError("folder not found")))
+	assert.Equal(t, sftp.ErrSSHFxPermissionDenied, toSFTPError(errors.NewAuthorizationError("denied")))
+	assert.Equal(t, sftp.ErrSSHFxFailure, toSFTPError(errors.NewInternalError("failed")))
+}
+
+// TestUploadRefusesWritesPastMaxSize tests that writes beyond the upload limit are refused and the
+// file is not ingested
+func TestUploadRefusesWritesPastMaxSize(t *testing.T) {
+	buffer, err := os.CreateTemp("", "sftp-upload-test-*")
+	assert.NoError(t, err)
+	u := &upload{handlers: &ingestHandlers{ctx: context.Background()}, name: "report.pdf", maxSize: 10, buffer: buffer}
+
+	n, err := u.WriteAt([]byte("12345678"), 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 8, n)
+
+	n, err = u.WriteAt([]byte("9012"), 8)
+	assert.Equal(t, sftp.ErrSSHFxFailure, err)
+	assert.Equal(t, 0, n)
+	assert.Equal(t, int64(8), u.size)
+
+	assert.Equal(t, sftp.ErrSSHFxFailure, u.Close())
+	_, err = os.Stat(buffer.Name())
+	assert.True(t, os.IsNotExist(err))
+}
+
+// TestAuthenticateRejectsUserNameWithoutTenant tests that SSH user names must name the tenant
+func TestAuthenticateRejectsUserNameWithoutTenant(t *testing.T) {
+	authenticator := newPasswordAuthenticator(nil, nil, nil)
+
+	for _, sshUser := range []string{"alice", "alice@", "@tenant-1"} {
+		_, err := authenticator.authenticate(context.Background(), sshUser, "secret")
+		assert.True(t, errors.IsAuthenticationError(err), sshUser)
+	}
+}
+
+// fakeAuthenticator returns the same sign-in result for every password
+type fakeAuthenticator struct {
+	result *usecases.LoginResult
+	err    error
+}
+
+func (f *fakeAuthenticator) Login(ctx context.Context, tenantID, usernameOrEmail, password string) (*usecases.LoginResult, error) {
+	return f.result, f.err
+}
+
+// TestAuthenticateRejectsInteractiveSignIn tests that sign-ins needing a second factor or a new
+// password are refused, and that sign-in errors such as lockouts are passed on
+func TestAuthenticateRejectsInteractiveSignIn(t *testing.T) {
+	results := []*usecases.LoginResult{
+		{MFAToken: "mfa-token", MFARequired: true},
+		{MFAToken: "mfa-token", MFARequired: true, MFAEnrollmentRequired: true},
+		{AccessToken: "access-token", PasswordExpired: true},
+	}
+	for _, result := range results {
+		authenticator := newPasswordAuthenticator(&fakeAuthenticator{result: result}, nil, nil)
+		_, err := authenticator.authenticate(context.Background(), "alice@tenant-1", "secret")
+		assert.True(t, errors.IsAuthenticationError(err))
+	}
+
+	locked := errors.NewAuthenticationError("account is temporarily locked")
+	authenticator := newPasswordAuthenticator(&fakeAuthenticator{err: locked}, nil, nil)
+	_, err := authenticator.authenticate(context.Background(), "alice@tenant-1", "secret")
+	assert.Equal(t, locked, err)
+}
+
+// fakeNetworkPolicyService records the address it is asked about and refuses it
+type fakeNetworkPolicyService struct {
+	checked net.IP
+}
+
+func (f *fakeNetworkPolicyService) CheckAccess(ctx context.Context, tenantID, userID string, ip net.IP) error {
+	f.checked = ip
+	return errors.NewAuthorizationError("access from this network is not allowed by the tenant")
+}
+
+// TestCheckNetworkPolicy tests that connections are checked with their remote address
+func TestCheckNetworkPolicy(t *testing.T) {
+	networkPolicyService := &fakeNetworkPolicyService{}
+	authenticator := newPasswordAuthenticator(nil, nil, networkPolicyService)
+	user := &models.User{ID: "user-1", TenantID: "tenant-1"}
+
+	err := authenticator.checkNetworkPolicy(context.Background(), user, &net.TCPAddr{IP: net.ParseIP("203.0.113.7"), Port: 52000})
+
+	assert.True(t, errors.IsAuthorizationError(err))
+	assert.Equal(t, "203.0.113.7", networkPolicyService.checked.String())
+}
+
+// fakeFolderService serves a single folder, /inbox, whose documents are split over two pages
+type fakeFolderService struct {
+	services.FolderService
+	pages [][]models.Document
+}
+
+func (f *fakeFolderService) GetFolderByPath(ctx context.Context, path, tenantID, userID string) (*models.Folder, error) {
+	if path != "/inbox" {
+		return nil, errors.NewResourceNotFoundError("folder not found")
+	}
+	return &models.Folder{ID: "folder-1", Name: "inbox", TenantID: tenantID}, nil
+}
+
+func (f *fakeFolderService) ListFolderContents(ctx context.Context, id, tenantID, userID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Folder], utils.PaginatedResult[models.Document], error) {
+	documents := utils.PaginatedResult[models.Document]{
+		Items:      f.pages[pagination.Page-1],
+		Pagination: utils.PageInfo{Page: pagination.Page, HasNext: pagination.Page < len(f.pages)},
+	}
+	return utils.PaginatedResult[models.Folder]{}, documents, nil
+}
+
+// fakeEventService satisfies the folder use case, which publishes no events when listing
+type fakeEventService struct {
+	services.EventServiceInterface
+}
+
+// TestFindDocumentThroughFolderContents tests that documents are looked up through the folder
+// use case on every page of the folder, so that existing documents are found and not replaced
+func TestFindDocumentThroughFolderContents(t *testing.T) {
+	folderService := &fakeFolderService{pages: [][]models.Document{
+		{{ID: "doc-1", Name: "invoice.pdf", Size: 10}},
+		{{ID: "doc-2", Name: "report.pdf", Size: 20}},
+	}}
+	folderUseCase := usecases.NewFolderUseCase(folderService, &fakeEventService{})
+	h := &ingestHandlers{ctx: context.Background(), folderUseCase: folderUseCase, tenantID: "tenant-1", userID: "user-1"}
+
+	info, err := h.stat("/inbox/report.pdf")
+	assert.NoError(t, err)
+	assert.Equal(t, "report.pdf", info.Name())
+	assert.Equal(t, int64(20), info.Size())
+
+	_, err = h.stat("/inbox/missing.pdf")
+	assert.Equal(t, os.ErrNotExist, err)
+
+	_, err = h.Filewrite(sftp.NewRequest("Put", "/inbox/report.pdf"))
+	assert.Equal(t, sftp.ErrSSHFxPermissionDenied, err)
+}
@@ -0,0 +1,294 @@
+// Package main is the entry point for the SFTP ingestion gateway of the Document Management
+// Platform. It lets legacy systems push files over SFTP; every file is ingested through the normal
+// document upload pipeline, so it is virus scanned and indexed like any other upload.
+package main
+
+import (
+	"context"   // standard library
+	"fmt"       // standard library
+	"io"        // standard library
+	"log"       // standard library
+	"net"       // standard library
+	"os"        // standard library
+	"os/signal" // standard library
+	"syscall"   // standard library
+
+	"github.com/pkg/sftp"     // v1.13.0+
+	"golang.org/x/crypto/ssh" // v0.14.0+
+
+	"src/backend/application/usecases"                // For the sign-in, document and folder use cases
+	"src/backend/domain/services"                     // For audit, policy, quota and upload limit services
+	"src/backend/infrastructure/auth/jwt"             // For the authentication service used by the use cases
+	"src/backend/infrastructure/cache/redis"          // For the token revocation list
+	"src/backend/infrastructure/geoip/maxmind"        // For the countries of client addresses in tenant network policies
+	"src/backend/infrastructure/persistence/postgres" // For database connection and repositories
+	"src/backend/infrastructure/rendering/pdf"        // For the watermark renderer of the document use case
+	"src/backend/infrastructure/storage"              // For document storage
+	"src/backend/infrastructure/storage/providers"    // For the configured storage provider
+	"src/backend/pkg/config"                          // For loading application configuration
+	"src/backend/pkg/logger"                          // For application logging
+)
+
+func main() {
+	// Load application configuration
+	var cfg config.Config
+	if err := config.Load(&cfg); err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	// Initialize logger with configuration
+	if err := logger.Init(cfg.Log); err != nil {
+		log.Fatalf("Failed to initialize logger: %v", err)
+	}
+	defer logger.Shutdown()
+
+	// Initialize database connection; migrations are run by the API service
+	if err := postgres.Init(cfg.Database); err != nil {
+		logger.Error("Failed to initialize database", "error", err)
+		os.Exit(1)
+	}
+	defer postgres.Close()
+
+	// Initialize repositories
+	documentRepo, err := postgres.NewDocumentRepository(postgres.GetDB())
+	if err != nil {
+		logger.Error("Failed to initialize document repository", "error", err)
+		os.Exit(1)
+	}
+	folderRepo := postgres.NewFolderRepository(postgres.GetDB())
+	userRepo, err := postgres.NewUserRepository(postgres.GetDB())
+	if err != nil {
+		logger.Error("Failed to initialize user repository", "error", err)
+		os.Exit(1)
+	}
+	tenantRepo := postgres.NewTenantRepository(postgres.GetDB())
+	roleRepo := postgres.NewRoleRepository()
+	permissionRepo, err := postgres.NewPermissionRepository(postgres.GetDB())
+	if err != nil {
+		logger.Error("Failed to initialize permission repository", "error", err)
+		os.Exit(1)
+	}
+
+	// Initialize Redis client holding the token revocation list
+	redisClient, err := redis.NewRedisClient(map[string]interface{}{
+		"address":   cfg.Redis.Address,
+		"password":  cfg.Redis.Password,
+		"db":        cfg.Redis.DB,
+		"pool_size": cfg.Redis.PoolSize,
+	})
+	if err != nil {
+		logger.Error("Failed to connect to Redis", "error", err)
+		os.Exit(1)
+	}
+	defer redisClient.Close()
+
+	jwtService, err := jwt.NewJWTService(userRepo, tenantRepo, roleRepo, permissionRepo, postgres.NewAPIKeyRepository(),
+		redis.NewTokenRevocationRepository(redisClient), postgres.NewSessionRepository(), cfg.JWT)
+	if err != nil {
+		logger.Error("Failed to initialize JWT service", "error", err)
+		os.Exit(1)
+	}
+
+	// Initialize the services of the upload pipeline
+	auditService, err := services.NewAuditService(postgres.NewAuditLogRepository())
+	if err != nil {
+		logger.Error("Failed to initialize audit service", "error", err)
+		os.Exit(1)
+	}
+	policyEngine, err := services.NewPolicyEngine(postgres.NewAccessPolicyRepository(), userRepo)
+	if err != nil {
+		logger.Error("Failed to initialize policy engine", "error", err)
+		os.Exit(1)
+	}
+	quotaService, err := services.NewQuotaService(postgres.NewQuotaRepository(), documentRepo, postgres.NewOutboxRepository(), cfg.Quota.DefaultMaxStorageBytes, cfg.Quota.DefaultMaxDocuments)
+	if err != nil {
+		logger.Error("Failed to initialize quota service", "error", err)
+		os.Exit(1)
+	}
+	uploadLimitService, err := services.NewUploadLimitService(postgres.NewUploadLimitRepository(), userRepo, documentRepo, cfg.Quota.DefaultMaxFileSizeBytes, cfg.Quota.DefaultMaxDailyUploadBytes)
+	if err != nil {
+		logger.Error("Failed to initialize upload limit service", "error", err)
+		os.Exit(1)
+	}
+	metadataTemplateService, err := services.NewMetadataTemplateService(postgres.NewMetadataTemplateRepository(), folderRepo)
+	if err != nil {
+		logger.Error("Failed to initialize metadata template service", "error", err)
+		os.Exit(1)
+	}
+	metadataSchemaService, err := services.NewMetadataSchemaService(postgres.NewMetadataFieldRepository())
+	if err != nil {
+		logger.Error("Failed to initialize metadata schema service", "error", err)
+		os.Exit(1)
+	}
+	sequenceService, err := services.NewSequenceService(postgres.NewNumberingSequenceRepository(), folderRepo)
+	if err != nil {
+		logger.Error("Failed to initialize sequence service", "error", err)
+		os.Exit(1)
+	}
+
+	// Initialize the storage service on the configured provider
+	storageProvider, err := providers.New(context.Background(), cfg.Storage, cfg.S3)
+	if err != nil {
+		logger.Error("Failed to initialize storage provider", "error", err)
+		os.Exit(1)
+	}
+	storageService, err := storage.NewStorageService(storageProvider)
+	if err != nil {
+		logger.Error("Failed to initialize storage service", "error", err)
+		os.Exit(1)
+	}
+
+	// Initialize watermark and download policy services; the gateway serves no downloads, but the
+	// document use case requires them
+	watermarkService, err := services.NewWatermarkService(folderRepo, userRepo, tenantRepo, pdf.NewWatermarkRenderer())
+	if err != nil {
+		logger.Error("Failed to initialize watermark service", "error", err)
+		os.Exit(1)
+	}
+	downloadPolicyService, err := services.NewDownloadPolicyService(folderRepo, postgres.NewDownloadCounterRepository(), auditService)
+	if err != nil {
+		logger.Error("Failed to initialize download policy service", "error", err)
+		os.Exit(1)
+	}
+
+	// Initialize upload policy service refusing files of types, extensions and sizes tenants do not accept
+	uploadPolicyService, err := services.NewUploadPolicyService(tenantRepo)
+	if err != nil {
+		logger.Error("Failed to initialize upload policy service", "error", err)
+		os.Exit(1)
+	}
+
+	// Initialize the use cases files are ingested through
+	documentUseCase, err := usecases.NewDocumentUseCase(documentRepo, storageService, nil, nil, folderRepo, nil, jwtService, nil,
+		postgres.NewTransactionManager(), auditService, policyEngine, quotaService, uploadLimitService, metadataTemplateService, metadataSchemaService, sequenceService, watermarkService, downloadPolicyService, uploadPolicyService, nil)
+	if err != nil {
+		logger.Error("Failed to initialize document use case", "error", err)
+		os.Exit(1)
+	}
+	folderUseCase := usecases.NewFolderUseCase(folderRepo, nil, nil, jwtService, nil)
+	sizeLimiter := &uploadSizeLimiter{quotaService: quotaService, uploadLimitService: uploadLimitService}
+
+	// Initialize network policy service refusing connections from networks and countries tenants do not allow
+	var geoIP services.GeoIPResolver
+	if cfg.NetworkPolicy.GeoIPDatabase != "" {
+		geoIP, err = maxmind.NewMaxMindResolver(cfg.NetworkPolicy.GeoIPDatabase)
+		if err != nil {
+			logger.Error("Failed to open GeoIP database", "path", cfg.NetworkPolicy.GeoIPDatabase, "error", err)
+			os.Exit(1)
+		}
+	}
+	networkPolicyService, err := services.NewNetworkPolicyService(tenantRepo, geoIP, auditService)
+	if err != nil {
+		logger.Error("Failed to initialize network policy service", "error", err)
+		os.Exit(1)
+	}
+
+	// Configure SSH with password authentication through the API's sign-in
+	hostKey, err := loadHostKey(cfg.SFTP.HostKeyFile)
+	if err != nil {
+		logger.Error("Failed to load SFTP host key", "error", err)
+		os.Exit(1)
+	}
+	authUseCase, err := usecases.NewAuthUseCase(jwtService, userRepo, tenantRepo, postgres.NewMFARepository(), auditService)
+	if err != nil {
+		logger.Error("Failed to initialize auth use case", "error", err)
+		os.Exit(1)
+	}
+	authenticator := newPasswordAuthenticator(authUseCase, userRepo, networkPolicyService)
+	sshConfig := &ssh.ServerConfig{PasswordCallback: authenticator.PasswordCallback}
+	sshConfig.AddHostKey(hostKey)
+
+	address := fmt.Sprintf("%s:%d", cfg.SFTP.Host, cfg.SFTP.Port)
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		logger.Error("Failed to listen for SFTP", "address", address, "error", err)
+		os.Exit(1)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		logger.Info("Starting SFTP gateway", "address", address)
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				logger.Error("Failed to accept SFTP connection", "error", err)
+				continue
+			}
+			go serveConnection(ctx, conn, sshConfig, folderUseCase, documentUseCase, sizeLimiter)
+		}
+	}()
+
+	// Wait for shutdown signal; uploads in progress are abandoned, and clients retry them
+	shutdownSignal := make(chan os.Signal, 1)
+	signal.Notify(shutdownSignal, syscall.SIGINT, syscall.SIGTERM)
+	sig := <-shutdownSignal
+	logger.Info("Shutdown signal received", "signal", sig)
+
+	cancel()
+	listener.Close()
+	logger.Info("SFTP gateway shutdown complete")
+}
+
+// loadHostKey reads the SSH host key of the gateway
+func loadHostKey(file string) (ssh.Signer, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read host key file: %w", err)
+	}
+	signer, err := ssh.ParsePrivateKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse host key: %w", err)
+	}
+	return signer, nil
+}
+
+// serveConnection authenticates an SSH connection and serves the SFTP subsystem on its sessions
+func serveConnection(ctx context.Context, conn net.Conn, sshConfig *ssh.ServerConfig, folderUseCase FolderUseCase, documentUseCase usecases.DocumentUseCase, sizeLimiter *uploadSizeLimiter) {
+	defer conn.Close()
+
+	serverConn, channels, requests, err := ssh.NewServerConn(conn, sshConfig)
+	if err != nil {
+		logger.Info("SFTP handshake failed", "remote_addr", conn.RemoteAddr().String(), "error", err.Error())
+		return
+	}
+	defer serverConn.Close()
+	go ssh.DiscardRequests(requests)
+
+	tenantID := serverConn.Permissions.Extensions[extensionTenantID]
+	userID := serverConn.Permissions.Extensions[extensionUserID]
+
+	for newChannel := range channels {
+		if newChannel.ChannelType() != "session" {
+			newChannel.Reject(ssh.UnknownChannelType, "only session channels are supported")
+			continue
+		}
+		channel, channelRequests, err := newChannel.Accept()
+		if err != nil {
+			logger.Error("Failed to accept SFTP channel", "error", err)
+			continue
+		}
+
+		go func() {
+			// Only the sftp subsystem is offered; shells and commands are refused
+			for req := range channelRequests {
+				ok := req.Type == "subsystem" && len(req.Payload) > 4 && string(req.Payload[4:]) == "sftp"
+				req.Reply(ok, nil)
+				if !ok {
+					continue
+				}
+
+				server := sftp.NewRequestServer(channel, newHandlers(ctx, folderUseCase, documentUseCase, sizeLimiter, tenantID, userID))
+				if err := server.Serve(); err != nil && err != io.EOF {
+					logger.Error("SFTP session failed", "tenant_id", tenantID, "user_id", userID, "error", err)
+				}
+				server.Close()
+				return
+			}
+		}()
+	}
+}
@@ -0,0 +1,337 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"../../application/usecases"
+	"../../domain/models"
+	"../../domain/services"
+	"../../infrastructure/encryption/kms"
+	"../../infrastructure/importsource"
+	"../../infrastructure/persistence/postgres"
+	"../../infrastructure/storage"
+	"../../infrastructure/storage/providers"
+	"../../pkg/config"
+	"../../pkg/errors"
+	"../../pkg/logger"
+	"../../pkg/utils"
+)
+
+// bulkImportCommand is the worker subcommand scheduling and inspecting bulk imports
+const bulkImportCommand = "bulk-import"
+
+// bulkImportUsage describes the bulk-import subcommand
+const bulkImportUsage = `Usage:
+  bulk-import start -tenant TENANT_ID -user USER_ID -source SOURCE [-manifest KEY] [-folder FOLDER_ID] [-dry-run]
+      Import the directory at the absolute path SOURCE, mounted on the workers, or the objects under
+      an s3://bucket/prefix URL into the tenant, as the user. Directories become folders below the
+      folder, or below the root of the tenant. KEY is the path of the manifest CSV file in the
+      source. A dry run reports what would be imported without importing anything.
+  bulk-import status -id IMPORT_ID
+      Print the progress of an import.
+  bulk-import list [-page N]
+      List the imports, most recent first.
+  bulk-import retry -id IMPORT_ID
+      Resume a failed import after the last file it handled.
+  bulk-import report -id IMPORT_ID [-out FILE]
+      Write the reconciliation report of an import as CSV: the outcome of each file found in the
+      source, and each file listed in the manifest but missing from the source.
+`
+
+// BulkImport runs the bulk-import subcommand with its arguments and returns the exit code. The
+// worker runs the imports; this command schedules them, follows their progress and hands out
+// their reports.
+func BulkImport(cfg config.Config, args []string) int {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, bulkImportUsage)
+		return 2
+	}
+
+	if err := postgres.Init(cfg.Database); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize database: %v\n", err)
+		return 1
+	}
+	defer postgres.Close()
+	bulkImport, closeBulkImport, err := newBulkImportCommandUseCase(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize bulk import: %v\n", err)
+		return 1
+	}
+	defer closeBulkImport()
+
+	ctx := context.Background()
+	switch args[0] {
+	case "start":
+		return startBulkImport(ctx, bulkImport, args[1:], os.Stdout)
+	case "status":
+		return printBulkImportStatus(ctx, bulkImport, args[1:], os.Stdout)
+	case "list":
+		return listBulkImports(ctx, bulkImport, args[1:], os.Stdout)
+	case "retry":
+		return retryBulkImport(ctx, bulkImport, args[1:], os.Stdout)
+	case "report":
+		return writeBulkImportReport(ctx, bulkImport, args[1:], os.Stdout)
+	default:
+		fmt.Fprint(os.Stderr, bulkImportUsage)
+		return 2
+	}
+}
+
+// newBulkImportCommandUseCase creates the bulk import use case on the configured database and storage
+func newBulkImportCommandUseCase(cfg config.Config) (usecases.BulkImportUseCase, func(), error) {
+	keyService, err := kms.NewKeyService(cfg.S3, postgres.NewTenantRepository(postgres.GetDB()))
+	if err != nil {
+		return nil, nil, err
+	}
+	storageProvider, err := providers.New(context.Background(), cfg.Storage, cfg.S3)
+	if err != nil {
+		return nil, nil, err
+	}
+	storageService, err := storage.NewTenantStorageService(storageProvider, keyService, postgres.NewContentBlobRepository(), postgres.NewTransactionManager())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return newBulkImport(cfg, storageService)
+}
+
+// newBulkImport creates the bulk import use case, which uploads the files of the imported archives
+// through the same document pipeline as the API. The returned function releases the connections it
+// opened.
+func newBulkImport(cfg config.Config, storageService services.StorageService) (usecases.BulkImportUseCase, func(), error) {
+	userRepo, err := postgres.NewUserRepository(postgres.GetDB())
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to initialize user repository")
+	}
+	tenantRepo := postgres.NewTenantRepository(postgres.GetDB())
+
+	documentUseCase, folderUseCase, closeDocumentUseCases, err := newDocumentUseCases(cfg, storageService)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	bulkImport, err := usecases.NewBulkImportUseCase(postgres.NewBulkImportJobRepository(), tenantRepo, userRepo, folderUseCase,
+		documentUseCase, importsource.NewResolver(cfg.BulkImport, cfg.S3), cfg.BulkImport.Concurrency)
+	if err != nil {
+		closeDocumentUseCases()
+		return nil, nil, errors.Wrap(err, "failed to initialize bulk import")
+	}
+
+	return bulkImport, closeDocumentUseCases, nil
+}
+
+// startBulkImport schedules an import and prints its ID
+func startBulkImport(ctx context.Context, bulkImport usecases.BulkImportUseCase, args []string, out io.Writer) int {
+	flags := flag.NewFlagSet("bulk-import start", flag.ContinueOnError)
+	tenantID := flags.String("tenant", "", "ID of the tenant to import into")
+	userID := flags.String("user", "", "ID of the user the documents are uploaded as")
+	source := flags.String("source", "", "absolute path of a directory mounted on the workers, or s3://bucket/prefix")
+	manifest := flags.String("manifest", "", "path of the manifest CSV file in the source")
+	folderID := flags.String("folder", "", "ID of the folder to import into, by default the root of the tenant")
+	dryRun := flags.Bool("dry-run", false, "only report what would be imported")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *tenantID == "" || *userID == "" || *source == "" {
+		fmt.Fprint(os.Stderr, bulkImportUsage)
+		return 2
+	}
+
+	job, err := bulkImport.StartImport(ctx, usecases.BulkImportRequest{
+		TenantID: *tenantID,
+		UserID:   *userID,
+		Source:   *source,
+		Manifest: *manifest,
+		FolderID: *folderID,
+		DryRun:   *dryRun,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to start bulk import: %v\n", err)
+		return 1
+	}
+
+	kind := "Import"
+	if job.DryRun {
+		kind = "Dry run"
+	}
+	fmt.Fprintf(out, "%s %s of %s scheduled; follow it with bulk-import status -id %s\n", kind, job.ID, job.Source, job.ID)
+	return 0
+}
+
+// printBulkImportStatus prints the progress of an import
+func printBulkImportStatus(ctx context.Context, bulkImport usecases.BulkImportUseCase, args []string, out io.Writer) int {
+	flags := flag.NewFlagSet("bulk-import status", flag.ContinueOnError)
+	id := flags.String("id", "", "ID of the import")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *id == "" {
+		fmt.Fprint(os.Stderr, bulkImportUsage)
+		return 2
+	}
+
+	job, err := bulkImport.GetImport(ctx, *id)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to get bulk import: %v\n", err)
+		return 1
+	}
+
+	imported := "Imported"
+	if job.DryRun {
+		imported = "Importable"
+	}
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Import\t%s\n", job.ID)
+	fmt.Fprintf(w, "Tenant\t%s\n", job.TenantID)
+	fmt.Fprintf(w, "Source\t%s\n", job.Source)
+	fmt.Fprintf(w, "Manifest\t%s\n", job.Manifest)
+	fmt.Fprintf(w, "Dry run\t%t\n", job.DryRun)
+	fmt.Fprintf(w, "Status\t%s\n", job.Status)
+	fmt.Fprintf(w, "Last file\t%s\n", job.Checkpoint)
+	fmt.Fprintf(w, "Found\t%d\n", job.FoundFiles)
+	fmt.Fprintf(w, "%s\t%d (%d bytes)\n", imported, job.ImportedFiles, job.ImportedBytes)
+	fmt.Fprintf(w, "Skipped\t%d\n", job.SkippedFiles)
+	fmt.Fprintf(w, "Failed\t%d\n", job.FailedFiles)
+	fmt.Fprintf(w, "Missing\t%d\n", job.MissingFiles)
+	fmt.Fprintf(w, "Folders created\t%d\n", job.CreatedFolders)
+	if job.StartedAt != nil {
+		fmt.Fprintf(w, "Started\t%s\n", job.StartedAt.Format(time.RFC3339))
+	}
+	if job.CompletedAt != nil {
+		fmt.Fprintf(w, "Completed\t%s\n", job.CompletedAt.Format(time.RFC3339))
+	}
+	if job.Error != "" {
+		fmt.Fprintf(w, "Error\t%s\n", job.Error)
+	}
+	w.Flush()
+	return 0
+}
+
+// listBulkImports prints a page of imports as a table
+func listBulkImports(ctx context.Context, bulkImport usecases.BulkImportUseCase, args []string, out io.Writer) int {
+	flags := flag.NewFlagSet("bulk-import list", flag.ContinueOnError)
+	page := flags.Int("page", 1, "page of imports to list")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	result, err := bulkImport.ListImports(ctx, utils.NewPagination(*page, utils.DefaultPageSize))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to list bulk imports: %v\n", err)
+		return 1
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tTENANT\tSOURCE\tSTATUS\tDRY RUN\tFOUND\tIMPORTED\tFAILED\tMISSING\tCREATED AT")
+	for _, job := range result.Items {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%t\t%d\t%d\t%d\t%d\t%s\n", job.ID, job.TenantID, job.Source, job.Status, job.DryRun,
+			job.FoundFiles, job.ImportedFiles, job.FailedFiles, job.MissingFiles, job.CreatedAt.Format(time.RFC3339))
+	}
+	w.Flush()
+	fmt.Fprintf(out, "Page %d of %d, %d import(s)\n", result.Pagination.Page, result.Pagination.TotalPages, result.Pagination.TotalItems)
+	return 0
+}
+
+// retryBulkImport schedules a failed import again
+func retryBulkImport(ctx context.Context, bulkImport usecases.BulkImportUseCase, args []string, out io.Writer) int {
+	flags := flag.NewFlagSet("bulk-import retry", flag.ContinueOnError)
+	id := flags.String("id", "", "ID of the failed import")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *id == "" {
+		fmt.Fprint(os.Stderr, bulkImportUsage)
+		return 2
+	}
+
+	job, err := bulkImport.RetryImport(ctx, *id)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to retry bulk import: %v\n", err)
+		return 1
+	}
+
+	if job.Checkpoint == "" {
+		fmt.Fprintf(out, "Import %s scheduled again from the start\n", job.ID)
+	} else {
+		fmt.Fprintf(out, "Import %s scheduled again after %s\n", job.ID, job.Checkpoint)
+	}
+	return 0
+}
+
+// writeBulkImportReport prints the reconciliation report of an import, or writes it to a file
+func writeBulkImportReport(ctx context.Context, bulkImport usecases.BulkImportUseCase, args []string, out io.Writer) int {
+	flags := flag.NewFlagSet("bulk-import report", flag.ContinueOnError)
+	id := flags.String("id", "", "ID of the import")
+	file := flags.String("out", "", "file to write the report to")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *id == "" {
+		fmt.Fprint(os.Stderr, bulkImportUsage)
+		return 2
+	}
+
+	if *file == "" {
+		if err := bulkImport.WriteReport(ctx, *id, out); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to write bulk import report: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+
+	f, err := os.Create(*file)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to create report file: %v\n", err)
+		return 1
+	}
+	if err := bulkImport.WriteReport(ctx, *id, f); err != nil {
+		f.Close()
+		fmt.Fprintf(os.Stderr, "Failed to write bulk import report: %v\n", err)
+		return 1
+	}
+	if err := f.Close(); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to write report file: %v\n", err)
+		return 1
+	}
+
+	job, err := bulkImport.GetImport(ctx, *id)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to get bulk import: %v\n", err)
+		return 1
+	}
+	if job.Status != models.BulkImportJobStatusCompleted {
+		fmt.Fprintf(out, "Import %s is %s; the report holds the files handled so far\n", job.ID, job.Status)
+	}
+	fmt.Fprintf(out, "Report of import %s written to %s\n", job.ID, *file)
+	return 0
+}
+
+// importArchives runs bulk imports one at a time. After finishing an import it immediately looks
+// for the next one, so queued imports do not wait for the interval.
+func importArchives(ctx context.Context, bulkImport usecases.BulkImportUseCase) {
+	for {
+		imported, err := bulkImport.ImportNext(ctx)
+		if err != nil && ctx.Err() == nil {
+			logger.Error("Error running bulk import", "error", err)
+		}
+
+		wait := bulkImportPollInterval
+		if err == nil && imported {
+			wait = 0
+		}
+
+		select {
+		case <-time.After(wait):
+			// Continue importing after interval
+		case <-ctx.Done():
+			logger.Info("Stopping bulk importer")
+			return
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"../../domain/services"
+	messaging "../../infrastructure/messaging/providers"
+	"../../pkg/config"
+)
+
+// dlqReplayCommand is the worker subcommand inspecting and re-driving the scan dead letter queue
+const dlqReplayCommand = "dlq-replay"
+
+// dlqReplayUsage describes the dlq-replay subcommand
+const dlqReplayUsage = `Usage:
+  dlq-replay list [-limit N]
+      List the dead-lettered scan tasks with the reason they failed.
+  dlq-replay replay (-id MESSAGE_ID ... | -tenant TENANT_ID | -all)
+      Move the selected scan tasks back to the queue of their priority with their retries reset.
+`
+
+// stringList is a flag that can be given several times
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// DLQReplay runs the dlq-replay subcommand with its arguments and returns the exit code. It lets
+// operators re-drive scan tasks after fixing the cause of their failure, such as an unavailable
+// scanning engine, without editing queues by hand.
+func DLQReplay(cfg config.Config, args []string) int {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, dlqReplayUsage)
+		return 2
+	}
+
+	ctx := context.Background()
+	messageBus, err := messaging.New(ctx, cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize message bus: %v\n", err)
+		return 1
+	}
+	defer messageBus.Close()
+	deadLetters, ok := messageBus.ScanQueue().(services.ScanDeadLetterQueue)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "The scan queue of the %s messaging provider does not support replaying dead letters\n", cfg.Messaging.Provider)
+		return 1
+	}
+
+	switch args[0] {
+	case "list":
+		return listDeadLetters(ctx, deadLetters, args[1:], os.Stdout)
+	case "replay":
+		return replayDeadLetters(ctx, deadLetters, args[1:], os.Stdout)
+	default:
+		fmt.Fprint(os.Stderr, dlqReplayUsage)
+		return 2
+	}
+}
+
+// listDeadLetters prints the dead-lettered scan tasks as a table
+func listDeadLetters(ctx context.Context, deadLetters services.ScanDeadLetterQueue, args []string, out io.Writer) int {
+	flags := flag.NewFlagSet("dlq-replay list", flag.ContinueOnError)
+	limit := flags.Int("limit", 100, "maximum number of tasks to list")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	tasks, err := deadLetters.ListDeadLetters(ctx, *limit)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to list dead-lettered scan tasks: %v\n", err)
+		return 1
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "MESSAGE ID\tTENANT\tDOCUMENT\tVERSION\tPRIORITY\tDEAD-LETTERED\tREASON")
+	for _, task := range tasks {
+		deadLetteredAt := ""
+		if !task.DeadLetteredAt.IsZero() {
+			deadLetteredAt = task.DeadLetteredAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", task.MessageID, task.Task.TenantID, task.Task.DocumentID,
+			task.Task.VersionID, task.Task.Priority, deadLetteredAt, task.Reason)
+	}
+	w.Flush()
+	fmt.Fprintf(out, "%d dead-lettered scan task(s)\n", len(tasks))
+
+	return 0
+}
+
+// replayDeadLetters re-drives the scan tasks selected by message ID or tenant, or all of them
+func replayDeadLetters(ctx context.Context, deadLetters services.ScanDeadLetterQueue, args []string, out io.Writer) int {
+	flags := flag.NewFlagSet("dlq-replay replay", flag.ContinueOnError)
+	var ids stringList
+	flags.Var(&ids, "id", "message ID of a task to replay, can be given several times")
+	tenantID := flags.String("tenant", "", "replay the tasks of a tenant")
+	all := flags.Bool("all", false, "replay all tasks")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	var selected func(services.DeadLetteredScanTask) bool
+	switch {
+	case len(ids) > 0 && *tenantID == "" && !*all:
+		selectedIDs := make(map[string]bool, len(ids))
+		for _, id := range ids {
+			selectedIDs[id] = true
+		}
+		selected = func(task services.DeadLetteredScanTask) bool { return selectedIDs[task.MessageID] }
+	case *tenantID != "" && len(ids) == 0 && !*all:
+		selected = func(task services.DeadLetteredScanTask) bool { return task.Task.TenantID == *tenantID }
+	case *all && len(ids) == 0 && *tenantID == "":
+		selected = func(task services.DeadLetteredScanTask) bool { return true }
+	default:
+		fmt.Fprintln(os.Stderr, "Select the tasks to replay with either -id, -tenant or -all")
+		fmt.Fprint(os.Stderr, dlqReplayUsage)
+		return 2
+	}
+
+	replayed, err := deadLetters.ReplayDeadLetters(ctx, selected)
+	fmt.Fprintf(out, "%d scan task(s) replayed\n", replayed)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to replay dead-lettered scan tasks: %v\n", err)
+		return 1
+	}
+
+	return 0
+}
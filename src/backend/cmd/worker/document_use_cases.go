@@ -0,0 +1,117 @@
+package main
+
+import (
+	"../../application/usecases"
+	"../../domain/services"
+	"../../infrastructure/auth/jwt"
+	"../../infrastructure/cache/redis"
+	"../../infrastructure/persistence/postgres"
+	"../../infrastructure/rendering/pdf"
+	"../../pkg/config"
+	"../../pkg/errors"
+)
+
+// newDocumentUseCases creates the document and folder use cases of the worker jobs that create
+// documents, such as email ingestion and bulk imports, on the same document pipeline as the API:
+// uploads are checked against the upload policy, limits and quota of their tenant. The returned
+// function releases the connections it opened.
+func newDocumentUseCases(cfg config.Config, storageService services.StorageService) (usecases.DocumentUseCase, *usecases.FolderUseCase, func(), error) {
+	documentRepo, err := postgres.NewDocumentRepository(postgres.GetDB())
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "failed to initialize document repository")
+	}
+	folderRepo := postgres.NewFolderRepository(postgres.GetDB())
+	userRepo, err := postgres.NewUserRepository(postgres.GetDB())
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "failed to initialize user repository")
+	}
+	tenantRepo := postgres.NewTenantRepository(postgres.GetDB())
+	roleRepo := postgres.NewRoleRepository()
+	permissionRepo, err := postgres.NewPermissionRepository(postgres.GetDB())
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "failed to initialize permission repository")
+	}
+
+	// The authentication service checks the uploader's permissions and needs the token revocation list
+	redisClient, err := redis.NewRedisClient(map[string]interface{}{
+		"address":   cfg.Redis.Address,
+		"password":  cfg.Redis.Password,
+		"db":        cfg.Redis.DB,
+		"pool_size": cfg.Redis.PoolSize,
+	})
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "failed to connect to Redis")
+	}
+	closeRedis := func() { redisClient.Close() }
+
+	jwtService, err := jwt.NewJWTService(userRepo, tenantRepo, roleRepo, permissionRepo, postgres.NewAPIKeyRepository(),
+		redis.NewTokenRevocationRepository(redisClient), postgres.NewSessionRepository(), cfg.JWT)
+	if err != nil {
+		closeRedis()
+		return nil, nil, nil, errors.Wrap(err, "failed to initialize JWT service")
+	}
+
+	auditService, err := services.NewAuditService(postgres.NewAuditLogRepository())
+	if err != nil {
+		closeRedis()
+		return nil, nil, nil, errors.Wrap(err, "failed to initialize audit service")
+	}
+	policyEngine, err := services.NewPolicyEngine(postgres.NewAccessPolicyRepository(), userRepo)
+	if err != nil {
+		closeRedis()
+		return nil, nil, nil, errors.Wrap(err, "failed to initialize policy engine")
+	}
+	quotaService, err := services.NewQuotaService(postgres.NewQuotaRepository(), documentRepo, postgres.NewOutboxRepository(), cfg.Quota.DefaultMaxStorageBytes, cfg.Quota.DefaultMaxDocuments)
+	if err != nil {
+		closeRedis()
+		return nil, nil, nil, errors.Wrap(err, "failed to initialize quota service")
+	}
+	uploadLimitService, err := services.NewUploadLimitService(postgres.NewUploadLimitRepository(), userRepo, documentRepo, cfg.Quota.DefaultMaxFileSizeBytes, cfg.Quota.DefaultMaxDailyUploadBytes)
+	if err != nil {
+		closeRedis()
+		return nil, nil, nil, errors.Wrap(err, "failed to initialize upload limit service")
+	}
+	metadataTemplateService, err := services.NewMetadataTemplateService(postgres.NewMetadataTemplateRepository(), folderRepo)
+	if err != nil {
+		closeRedis()
+		return nil, nil, nil, errors.Wrap(err, "failed to initialize metadata template service")
+	}
+	metadataSchemaService, err := services.NewMetadataSchemaService(postgres.NewMetadataFieldRepository())
+	if err != nil {
+		closeRedis()
+		return nil, nil, nil, errors.Wrap(err, "failed to initialize metadata schema service")
+	}
+	sequenceService, err := services.NewSequenceService(postgres.NewNumberingSequenceRepository(), folderRepo)
+	if err != nil {
+		closeRedis()
+		return nil, nil, nil, errors.Wrap(err, "failed to initialize sequence service")
+	}
+
+	watermarkService, err := services.NewWatermarkService(folderRepo, userRepo, tenantRepo, pdf.NewWatermarkRenderer())
+	if err != nil {
+		closeRedis()
+		return nil, nil, nil, errors.Wrap(err, "failed to initialize watermark service")
+	}
+	downloadPolicyService, err := services.NewDownloadPolicyService(folderRepo, postgres.NewDownloadCounterRepository(), auditService)
+	if err != nil {
+		closeRedis()
+		return nil, nil, nil, errors.Wrap(err, "failed to initialize download policy service")
+	}
+
+	// Initialize upload policy service refusing files of types, extensions and sizes tenants do not accept
+	uploadPolicyService, err := services.NewUploadPolicyService(tenantRepo)
+	if err != nil {
+		closeRedis()
+		return nil, nil, nil, errors.Wrap(err, "failed to initialize upload policy service")
+	}
+
+	documentUseCase, err := usecases.NewDocumentUseCase(documentRepo, storageService, nil, nil, folderRepo, nil, jwtService, nil,
+		postgres.NewTransactionManager(), auditService, policyEngine, quotaService, uploadLimitService, metadataTemplateService, metadataSchemaService, sequenceService, watermarkService, downloadPolicyService, uploadPolicyService, nil)
+	if err != nil {
+		closeRedis()
+		return nil, nil, nil, errors.Wrap(err, "failed to initialize document use case")
+	}
+	folderUseCase := usecases.NewFolderUseCase(folderRepo, nil, nil, jwtService, nil)
+
+	return documentUseCase, folderUseCase, closeRedis, nil
+}
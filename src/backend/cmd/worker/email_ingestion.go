@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"../../application/usecases"
+	"../../domain/services"
+	"../../infrastructure/email/ses"
+	"../../infrastructure/persistence/postgres"
+	"../../pkg/config"
+	"../../pkg/errors"
+	"../../pkg/logger"
+)
+
+// Defaults for email ingestion when config.EmailIn leaves them unset
+const (
+	defaultEmailIngestionBatchSize = 10
+	defaultEmailIngestionInterval  = 30 * time.Second
+)
+
+// newEmailIngestion creates the email ingestion use case, which uploads attachments through the same
+// document pipeline as the API. The returned function releases the connections it opened.
+func newEmailIngestion(cfg config.Config, storageService services.StorageService) (usecases.EmailIngestionUseCase, func(), error) {
+	mailbox, err := ses.NewS3Mailbox(cfg.EmailIn)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to initialize inbound mailbox")
+	}
+
+	userRepo, err := postgres.NewUserRepository(postgres.GetDB())
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to initialize user repository")
+	}
+	tenantRepo := postgres.NewTenantRepository(postgres.GetDB())
+
+	documentUseCase, folderUseCase, closeDocumentUseCases, err := newDocumentUseCases(cfg, storageService)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	emailIngestion, err := usecases.NewEmailIngestionUseCase(mailbox, tenantRepo, userRepo, folderUseCase, documentUseCase, cfg.EmailIn.Domain)
+	if err != nil {
+		closeDocumentUseCases()
+		return nil, nil, errors.Wrap(err, "failed to initialize email ingestion")
+	}
+
+	return emailIngestion, closeDocumentUseCases, nil
+}
+
+// ingestEmails creates documents from the attachments of received emails. Full batches are
+// followed immediately by another run so that a backlog drains without waiting for the interval.
+func ingestEmails(ctx context.Context, emailIngestion usecases.EmailIngestionUseCase, cfg config.EmailInConfig) {
+	batchSize := cfg.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultEmailIngestionBatchSize
+	}
+	interval := parseDurationOrDefault(cfg.Interval, defaultEmailIngestionInterval)
+
+	for {
+		count, err := emailIngestion.IngestPending(ctx, batchSize)
+		if err != nil {
+			logger.Error("Error ingesting emails", "error", err)
+		} else if count > 0 {
+			logger.Info("Ingested emails", "count", count)
+		}
+
+		wait := interval
+		if err == nil && count == batchSize {
+			wait = 0
+		}
+
+		select {
+		case <-time.After(wait):
+			// Continue ingesting after interval
+		case <-ctx.Done():
+			logger.Info("Stopping email ingestion")
+			return
+		}
+	}
+}
@@ -8,26 +8,107 @@ import (
 	"syscall"
 	"time"
 
+	"../../application/usecases"
+	"../../domain/models"
+	"../../domain/repositories"
+	"../../domain/services"
 	"../../pkg/config"
 	"../../pkg/logger"
 	"../../pkg/metrics"
-	"../../infrastructure/messaging/sqs/sqsclient"
-	"../../infrastructure/messaging/sqs/documentqueue"
+	"../../pkg/tracing"
+	"../../infrastructure/persistence/postgres"
+	memorycache "../../infrastructure/cache/memory"
+	"../../infrastructure/cache/redis"
+	messaging "../../infrastructure/messaging/providers"
 	"../../infrastructure/virus_scanning/clamav"
 	"../../infrastructure/virus_scanning/clamav/virusscanner"
-	"../../infrastructure/storage/s3/s3storage"
-	"../../infrastructure/messaging/sns/eventpublisher"
+	"../../infrastructure/virus_scanning/external"
+	"../../infrastructure/virus_scanning/icap"
+	"../../infrastructure/virus_scanning/noop"
+	"../../infrastructure/storage"
+	"../../infrastructure/storage/providers"
+	searchproviders "../../infrastructure/search/providers"
+	"../../infrastructure/encryption/kms"
+	audits3 "../../infrastructure/audit/s3"
+	auditsyslog "../../infrastructure/audit/syslog"
+	"../../infrastructure/classification/comprehend"
+	"../../infrastructure/classification/regex"
 )
 
-// Number of documents to process in a batch
-const batchSize = 10
-
-// Time to wait between processing batches
-const processingInterval = 5 * time.Second
-
 // Timeout duration for graceful shutdown
 const shutdownTimeout = 30 * time.Second
 
+// Number of webhook deliveries to retry in a batch
+const webhookRetryBatchSize = 50
+
+// Time to wait between webhook retry sweeps
+const webhookRetryInterval = 15 * time.Second
+
+// Number of outbox messages to publish in a batch
+const outboxRelayBatchSize = 100
+
+// Time to wait between outbox relay sweeps when the outbox is drained
+const outboxRelayInterval = 2 * time.Second
+
+// How long published outbox messages are kept before being purged
+const outboxRetention = 7 * 24 * time.Hour
+
+// Time to wait between purges of published outbox messages
+const outboxPurgeInterval = time.Hour
+
+// Time to wait between polls for folder export jobs when none are pending
+const exportPollInterval = 5 * time.Second
+
+// Time to wait between polls for subject access exports when none are pending
+const subjectAccessPollInterval = 30 * time.Second
+
+// Time to wait between polls for search index rebuilds when none are pending
+const reindexPollInterval = 10 * time.Second
+
+// Time to wait between polls for tenant deletions whose grace period is over
+const tenantDeletionPollInterval = time.Minute
+
+// Time to wait between polls for bulk imports when none are pending
+const bulkImportPollInterval = 30 * time.Second
+
+// Number of documents published or expired in a batch
+const publicationBatchSize = 100
+
+// Time to wait between checks for documents due to be published or to expire
+const publicationInterval = 15 * time.Second
+
+// Time to wait between checks for content to re-encrypt with a tenant's current key
+const keyRotationInterval = 10 * time.Minute
+
+// Time to wait between checks for document versions to classify for personal data
+const classificationInterval = 5 * time.Minute
+
+// Time to wait between audit log partition maintenance runs
+const auditPartitionInterval = 24 * time.Hour
+
+// Time to wait between checks of the ClamAV signature database version
+const signatureCheckInterval = 5 * time.Minute
+
+// Time to wait between reports of the number of scan tasks waiting in the scan queue
+const scanQueueDepthInterval = 30 * time.Second
+
+// How far back uploads are rescanned after a signature update when config.ClamAV leaves it unset
+const defaultSignatureRescanWindow = 24 * time.Hour
+
+// How long clean scan verdicts are reused for identical uploads when config.Scanning leaves it unset
+const defaultScanVerdictCacheTTL = 24 * time.Hour
+
+// Age documents must have before the replication consistency check expects them in the replica
+// when config.S3.Replica leaves it unset
+const defaultReplicationSettleTime = 15 * time.Minute
+
+// Defaults for audit log forwarding when config.Audit leaves them unset
+const (
+	defaultAuditForwardBatchSize   = 500
+	defaultAuditForwardInterval    = 10 * time.Second
+	defaultAuditForwardSettleDelay = 30 * time.Second
+)
+
 func main() {
 	// Load application configuration
 	var cfg config.Config
@@ -52,78 +133,421 @@ func main() {
 	}
 	defer logger.Shutdown()
 
+	// The dlq-replay subcommand inspects and re-drives the scan dead letter queue instead of scanning
+	if len(os.Args) > 1 && os.Args[1] == dlqReplayCommand {
+		os.Exit(DLQReplay(cfg, os.Args[2:]))
+	}
+
+	// The tenant-transfer subcommand exports or imports a whole tenant instead of scanning
+	if len(os.Args) > 1 && os.Args[1] == tenantTransferCommand {
+		os.Exit(TenantTransfer(cfg, os.Args[2:]))
+	}
+
+	// The tenant-offboard subcommand schedules and inspects tenant deletions instead of scanning
+	if len(os.Args) > 1 && os.Args[1] == tenantOffboardCommand {
+		os.Exit(TenantOffboard(cfg, os.Args[2:]))
+	}
+
+	// The bulk-import subcommand schedules and inspects imports of legacy archives instead of scanning
+	if len(os.Args) > 1 && os.Args[1] == bulkImportCommand {
+		os.Exit(BulkImport(cfg, os.Args[2:]))
+	}
+
 	// Initialize metrics collection
-	err = metrics.Init(metrics.MetricsConfig{
-		Enabled:         true,
-		EnableEndpoint:  true,
-		EndpointAddress: "0.0.0.0",
-		EndpointPort:    9090,
-		Namespace:       "document_mgmt",
-	})
+	err = metrics.Init(metrics.NewMetricsConfigFromConfig(cfg.Metrics))
 	if err != nil {
 		logger.Error("Failed to initialize metrics", "error", err)
 		os.Exit(1)
 	}
 	defer metrics.Shutdown()
 
+	// Initialize tracing, continuing the traces of the uploads that queued the scan tasks
+	if err := tracing.Init(tracing.NewTracingConfigFromConfig(cfg.Tracing, cfg.Env, "worker")); err != nil {
+		logger.Error("Failed to initialize tracing", "error", err)
+	}
+	defer tracing.Shutdown()
+
 	// Log worker startup
 	logger.Info("Document scanning worker starting up", "version", "1.0.0")
 
-	// Initialize SQS client
-	sqsClient, err := sqsclient.NewSQSClient(context.Background(), cfg.SQS)
+	// Initialize the message bus of the configured provider, carrying the scan queue and events
+	messageBus, err := messaging.New(context.Background(), cfg)
 	if err != nil {
-		logger.Error("Failed to initialize SQS client", "error", err)
+		logger.Error("Failed to initialize message bus", "error", err, "provider", cfg.Messaging.Provider)
 		os.Exit(1)
 	}
+	defer messageBus.Close()
+	scanQueue := messageBus.ScanQueue()
 
-	// Initialize document scan queue
-	scanQueue, err := documentqueue.NewDocumentScanQueue(context.Background(), sqsClient, cfg)
+	// Initialize ClamAV client
+	clamAVClient, err := clamav.NewClamAVClient(fmt.Sprintf("%s:%d", cfg.ClamAV.Host, cfg.ClamAV.Port))
 	if err != nil {
-		logger.Error("Failed to initialize document scan queue", "error", err)
+		logger.Error("Failed to initialize ClamAV client", "error", err)
 		os.Exit(1)
 	}
 
-	// Initialize ClamAV client
-	clamAVClient, err := clamav.NewClamAVClient(fmt.Sprintf("%s:%d", cfg.ClamAV.Host, cfg.ClamAV.Port))
+	// Initialize database connection for webhook delivery state, tenant keys and content references
+	if err := postgres.Init(cfg.Database); err != nil {
+		logger.Error("Failed to initialize database", "error", err)
+		os.Exit(1)
+	}
+
+	// Initialize KMS key service resolving the key each tenant's documents are encrypted with
+	tenantRepo := postgres.NewTenantRepository(postgres.GetDB())
+	keyService, err := kms.NewKeyService(cfg.S3, tenantRepo)
 	if err != nil {
-		logger.Error("Failed to initialize ClamAV client", "error", err)
+		logger.Error("Failed to initialize KMS key service", "error", err)
 		os.Exit(1)
 	}
 
-	// Initialize S3 storage service
-	storageService := s3storage.NewS3Storage(cfg.S3)
-	if storageService == nil {
-		logger.Error("Failed to initialize S3 storage service")
+	// Initialize storage service on the configured provider, encrypting content with tenant keys and storing identical content of a tenant once
+	storageProvider, err := providers.New(context.Background(), cfg.Storage, cfg.S3)
+	if err != nil {
+		logger.Error("Failed to initialize storage provider", "error", err)
+		os.Exit(1)
+	}
+	storageService, err := storage.NewTenantStorageService(storageProvider, keyService, postgres.NewContentBlobRepository(), postgres.NewTransactionManager())
+	if err != nil {
+		logger.Error("Failed to initialize storage service", "error", err)
 		os.Exit(1)
 	}
 
-	// Initialize event publisher
-	eventPublisher, err := eventpublisher.NewEventPublisher(context.Background(), cfg.SNS)
+	// Events are published to the message bus
+	eventPublisher := messageBus.Events()
+
+	// Initialize the scanning engines and the selection of engines per tenant
+	scanningEngines, err := newScanningEngines(cfg.Scanning, clamAVClient)
+	if err != nil {
+		logger.Error("Failed to initialize scanning engines", "error", err)
+		os.Exit(1)
+	}
+	defaultEngines := cfg.Scanning.DefaultEngines
+	if len(defaultEngines) == 0 {
+		defaultEngines = []string{models.ScanEngineClamAV}
+	}
+	engineSelector, err := services.NewScanningEngineSelector(tenantRepo, scanningEngines, defaultEngines)
 	if err != nil {
-		logger.Error("Failed to initialize event publisher", "error", err)
+		logger.Error("Failed to initialize scanning engine selector", "error", err)
 		os.Exit(1)
 	}
 
+	// Initialize the cache of clean scan verdicts, so identical uploads of a tenant are not scanned
+	// again. It is shared by all workers through Redis, or kept in this process for development.
+	var verdictCache services.ScanVerdictCache
+	if ttl := parseDurationOrDefault(cfg.Scanning.VerdictCacheTTL, defaultScanVerdictCacheTTL); ttl > 0 {
+		var verdictRepo repositories.ScanVerdictRepository
+		if cfg.Redis.InMemory {
+			verdictRepo = memorycache.NewScanVerdictRepository()
+		} else {
+			redisClient, err := redis.NewRedisClient(map[string]interface{}{
+				"address":   cfg.Redis.Address,
+				"password":  cfg.Redis.Password,
+				"db":        cfg.Redis.DB,
+				"pool_size": cfg.Redis.PoolSize,
+			})
+			if err != nil {
+				logger.Error("Failed to connect to Redis", "error", err)
+				os.Exit(1)
+			}
+			defer redisClient.Close()
+			verdictRepo = redis.NewScanVerdictRepository(redisClient)
+		}
+		verdictCache, err = services.NewScanVerdictCache(verdictRepo, tenantRepo, ttl)
+		if err != nil {
+			logger.Error("Failed to initialize scan verdict cache", "error", err)
+			os.Exit(1)
+		}
+	}
+
 	// Initialize virus scanner service
-	virusScanner, err := virusscanner.NewVirusScanner(clamAVClient, scanQueue, storageService, eventPublisher, cfg)
+	virusScanner, err := virusscanner.NewMultiEngineVirusScanner(engineSelector, postgres.NewQuarantineRepository(), verdictCache, scanQueue, storageService, eventPublisher, cfg)
 	if err != nil {
 		logger.Error("Failed to initialize virus scanner service", "error", err)
 		os.Exit(1)
 	}
 
+	// Initialize the worker pool processing the scan queue
+	scanWorkerPool, err := virusscanner.NewScanWorkerPool(virusScanner, scanQueue, cfg.Scanning.Workers)
+	if err != nil {
+		logger.Error("Failed to initialize scan worker pool", "error", err)
+		os.Exit(1)
+	}
+
+	// Initialize webhook service used by the retry scheduler
+	webhookService, err := services.NewWebhookService(postgres.NewWebhookRepository(), nil)
+	if err != nil {
+		logger.Error("Failed to initialize webhook service", "error", err)
+		os.Exit(1)
+	}
+
+	// Initialize outbox relay that publishes events written by the API
+	outboxRelay, err := services.NewOutboxRelay(postgres.NewOutboxRepository(), postgres.NewTransactionManager(), eventPublisher)
+	if err != nil {
+		logger.Error("Failed to initialize outbox relay", "error", err)
+		os.Exit(1)
+	}
+
+	// Initialize audit service used to maintain the monthly audit log partitions and to record
+	// exported documents refused by download policies
+	auditService, err := services.NewAuditService(postgres.NewAuditLogRepository())
+	if err != nil {
+		logger.Error("Failed to initialize audit service", "error", err)
+		os.Exit(1)
+	}
+
+	// Initialize folder exporter that builds the archives of export jobs created by the API, checking
+	// each document against the access and download policies of its folder and tenant
+	documentRepo, err := postgres.NewDocumentRepository(postgres.GetDB())
+	if err != nil {
+		logger.Error("Failed to initialize document repository", "error", err)
+		os.Exit(1)
+	}
+	userRepo, err := postgres.NewUserRepository(postgres.GetDB())
+	if err != nil {
+		logger.Error("Failed to initialize user repository", "error", err)
+		os.Exit(1)
+	}
+	policyEngine, err := services.NewPolicyEngine(postgres.NewAccessPolicyRepository(), userRepo)
+	if err != nil {
+		logger.Error("Failed to initialize policy engine", "error", err)
+		os.Exit(1)
+	}
+	folderRepo := postgres.NewFolderRepository(postgres.GetDB())
+	downloadPolicyService, err := services.NewDownloadPolicyService(folderRepo, postgres.NewDownloadCounterRepository(), auditService)
+	if err != nil {
+		logger.Error("Failed to initialize download policy service", "error", err)
+		os.Exit(1)
+	}
+	folderExporter, err := services.NewFolderExporter(postgres.NewExportJobRepository(), folderRepo, documentRepo,
+		postgres.NewOutboxRepository(), postgres.NewTransactionManager(), storageService, policyEngine, downloadPolicyService)
+	if err != nil {
+		logger.Error("Failed to initialize folder exporter", "error", err)
+		os.Exit(1)
+	}
+
+	// Initialize subject access exporter that compiles the personal data exports requested by administrators
+	subjectAccessExporter, err := services.NewSubjectAccessExporter(postgres.NewSubjectAccessExportRepository(), userRepo, documentRepo,
+		postgres.NewAuditLogRepository(), postgres.NewOutboxRepository(), postgres.NewTransactionManager(), storageService)
+	if err != nil {
+		logger.Error("Failed to initialize subject access exporter", "error", err)
+		os.Exit(1)
+	}
+
+	// Initialize publication scheduler that publishes and expires documents at their scheduled times
+	publicationScheduler, err := services.NewPublicationScheduler(documentRepo, postgres.NewOutboxRepository(), postgres.NewTransactionManager())
+	if err != nil {
+		logger.Error("Failed to initialize publication scheduler", "error", err)
+		os.Exit(1)
+	}
+
+	// Initialize search reindexer that runs the index rebuilds requested through the API, unless the
+	// search provider cannot rebuild its index
+	metadataSchemaService, err := services.NewMetadataSchemaService(postgres.NewMetadataFieldRepository())
+	if err != nil {
+		logger.Error("Failed to initialize metadata schema service", "error", err)
+		os.Exit(1)
+	}
+	searchIndexer, _, err := searchproviders.New(context.Background(), cfg, metadataSchemaService)
+	if err != nil {
+		logger.Error("Failed to initialize search index", "error", err, "provider", cfg.Search.Provider)
+		os.Exit(1)
+	}
+	searchReindexer, err := services.NewSearchReindexer(postgres.NewReindexJobRepository(), documentRepo, storageService, searchIndexer)
+	if err == services.ErrSearchIndexRebuildUnsupported {
+		logger.Info("Search provider cannot rebuild its index, not running reindex jobs", "provider", cfg.Search.Provider)
+	} else if err != nil {
+		logger.Error("Failed to initialize search reindexer", "error", err)
+		os.Exit(1)
+	}
+
+	// Initialize tenant offboarding that erases deleted tenants once their grace period is over,
+	// when a key to sign their destruction reports is configured
+	var tenantOffboarding services.TenantOffboardingService
+	if cfg.Offboarding.ReportSigningKeyFile != "" {
+		signingKey, err := loadReportSigningKey(cfg.Offboarding.ReportSigningKeyFile)
+		if err != nil {
+			logger.Error("Failed to load report signing key", "error", err)
+			os.Exit(1)
+		}
+		tenantOffboarding, err = newTenantOffboardingService(tenantRepo, storageProvider, searchIndexer, scanQueue, signingKey)
+		if err != nil {
+			logger.Error("Failed to initialize tenant offboarding", "error", err)
+			os.Exit(1)
+		}
+	}
+
+	// Initialize key rotator that re-encrypts content when a tenant's key changes
+	keyRotator, err := services.NewKeyRotator(tenantRepo, documentRepo, keyService, storageService)
+	if err != nil {
+		logger.Error("Failed to initialize key rotator", "error", err)
+		os.Exit(1)
+	}
+
+	// Initialize document classifier that tags documents with the personal data found in their content
+	piiDetectors, err := newPIIDetectors(cfg.Classification)
+	if err != nil {
+		logger.Error("Failed to initialize PII detectors", "error", err)
+		os.Exit(1)
+	}
+	documentClassifier, err := services.NewDocumentClassifier(tenantRepo, documentRepo, storageService, piiDetectors, cfg.Classification.MaxContentBytes)
+	if err != nil {
+		logger.Error("Failed to initialize document classifier", "error", err)
+		os.Exit(1)
+	}
+
+	// Initialize signature rescanner that scans recent uploads again when ClamAV signatures are updated
+	var signatureRescanner services.SignatureRescanner
+	if rescanWindow := parseDurationOrDefault(cfg.ClamAV.RescanWindow, defaultSignatureRescanWindow); rescanWindow > 0 {
+		signatureRescanner, err = services.NewSignatureRescanner(clamAVClient, tenantRepo, documentRepo, scanQueue, verdictCache, rescanWindow)
+		if err != nil {
+			logger.Error("Failed to initialize signature rescanner", "error", err)
+			os.Exit(1)
+		}
+	}
+
+	// Initialize audit forwarder if an exporter is configured
+	auditExporter, err := newAuditExporter(cfg.Audit)
+	if err != nil {
+		logger.Error("Failed to initialize audit exporter", "error", err, "exporter", cfg.Audit.Exporter)
+		os.Exit(1)
+	}
+	var auditForwarder services.AuditForwarder
+	if auditExporter != nil {
+		defer auditExporter.Close()
+		auditForwarder, err = services.NewAuditForwarder(postgres.NewAuditLogRepository(), postgres.NewAuditExportCheckpointRepository(), auditExporter)
+		if err != nil {
+			logger.Error("Failed to initialize audit forwarder", "error", err)
+			os.Exit(1)
+		}
+	}
+
+	// Initialize email ingestion if inbound email is enabled
+	var emailIngestion usecases.EmailIngestionUseCase
+	if cfg.EmailIn.Enabled {
+		var closeEmailIngestion func()
+		emailIngestion, closeEmailIngestion, err = newEmailIngestion(cfg, storageService)
+		if err != nil {
+			logger.Error("Failed to initialize email ingestion", "error", err)
+			os.Exit(1)
+		}
+		defer closeEmailIngestion()
+	}
+
+	// Initialize bulk import if the worker runs the imports of legacy archives
+	var bulkImport usecases.BulkImportUseCase
+	if cfg.BulkImport.Enabled {
+		var closeBulkImport func()
+		bulkImport, closeBulkImport, err = newBulkImport(cfg, storageService)
+		if err != nil {
+			logger.Error("Failed to initialize bulk import", "error", err)
+			os.Exit(1)
+		}
+		defer closeBulkImport()
+	}
+
 	// Create context with cancellation for graceful shutdown
 	ctx, cancel := context.WithCancel(context.Background())
 
 	// Set up signal handling for graceful shutdown
 	setupSignalHandling(cancel)
 
-	// Start the main processing loop
-	logger.Info("Starting document processing loop", "batch_size", batchSize)
-	go processDocuments(ctx, virusScanner)
+	// Start the scan workers; they drain the scans in progress once ctx is cancelled
+	logger.Info("Starting scan workers", "concurrency", cfg.Scanning.Workers.Concurrency)
+	scanWorkersDone := make(chan struct{})
+	go func() {
+		defer close(scanWorkersDone)
+		scanWorkerPool.Run(ctx)
+	}()
+
+	// Start reporting the scan queue depth when the queue can count its tasks
+	if queueDepth, ok := scanQueue.(services.ScanQueueDepth); ok {
+		logger.Info("Starting scan queue depth reporter", "interval", scanQueueDepthInterval)
+		go reportScanQueueDepth(ctx, queueDepth)
+	}
+
+	// Start the webhook retry scheduler
+	logger.Info("Starting webhook retry scheduler", "batch_size", webhookRetryBatchSize)
+	go retryWebhookDeliveries(ctx, webhookService)
+
+	// Start the outbox relay
+	logger.Info("Starting outbox relay", "batch_size", outboxRelayBatchSize)
+	go relayOutboxEvents(ctx, outboxRelay)
+
+	// Start the folder exporter
+	logger.Info("Starting folder exporter")
+	go exportFolders(ctx, folderExporter)
+
+	// Start the subject access exporter
+	logger.Info("Starting subject access exporter")
+	go exportSubjectAccess(ctx, subjectAccessExporter)
+
+	// Start the publication scheduler
+	logger.Info("Starting publication scheduler", "batch_size", publicationBatchSize)
+	go publishScheduledDocuments(ctx, publicationScheduler)
+
+	// Start the search reindexer
+	if searchReindexer != nil {
+		logger.Info("Starting search reindexer", "provider", cfg.Search.Provider)
+		go reindexTenants(ctx, searchReindexer)
+	}
+
+	// Start the tenant offboarding
+	if tenantOffboarding != nil {
+		logger.Info("Starting tenant offboarding", "grace_period", cfg.Offboarding.GracePeriod)
+		go eraseTenants(ctx, tenantOffboarding)
+	}
+
+	// Start the key rotator
+	logger.Info("Starting encryption key rotator")
+	go rotateEncryptionKeys(ctx, keyRotator)
+
+	// Start the document classifier
+	logger.Info("Starting document classifier", "detectors", len(piiDetectors))
+	go classifyDocuments(ctx, documentClassifier)
+
+	// Start the signature rescanner
+	if signatureRescanner != nil {
+		logger.Info("Starting signature update rescanner", "window", cfg.ClamAV.RescanWindow)
+		go rescanOnSignatureUpdates(ctx, signatureRescanner)
+	}
+
+	// Start the audit log partition maintenance
+	logger.Info("Starting audit log partition maintenance")
+	go maintainAuditPartitions(ctx, auditService)
+
+	// Start the audit log forwarder
+	if auditForwarder != nil {
+		logger.Info("Starting audit log forwarder", "exporter", auditExporter.Name())
+		go forwardAuditLogs(ctx, auditForwarder, cfg.Audit)
+	}
+
+	// Start the storage replication consistency check when the document storage has a replica
+	if checker, ok := storageProvider.(storage.ReplicationChecker); ok && cfg.S3.Replica.Bucket != "" {
+		if interval := parseDurationOrDefault(cfg.S3.Replica.ConsistencyCheckInterval, 0); interval > 0 {
+			settle := parseDurationOrDefault(cfg.S3.Replica.ReplicationSettleTime, defaultReplicationSettleTime)
+			logger.Info("Starting storage replication consistency check", "replica", cfg.S3.Replica.Bucket, "interval", interval.String())
+			go checkStorageReplication(ctx, checker, interval, settle)
+		}
+	}
+
+	// Start the email ingestion
+	if emailIngestion != nil {
+		logger.Info("Starting email ingestion", "domain", cfg.EmailIn.Domain)
+		go ingestEmails(ctx, emailIngestion, cfg.EmailIn)
+	}
+
+	// Start the bulk importer
+	if bulkImport != nil {
+		logger.Info("Starting bulk importer", "concurrency", cfg.BulkImport.Concurrency)
+		go importArchives(ctx, bulkImport)
+	}
 
 	// Wait for shutdown signal
 	<-ctx.Done()
 
+	// Wait for the scans in progress to finish before closing their dependencies
+	<-scanWorkersDone
+
 	// Perform graceful shutdown
 	gracefulShutdown(context.Background())
 }
@@ -140,29 +564,427 @@ func setupSignalHandling(cancel context.CancelFunc) {
 	}()
 }
 
-// processDocuments is the main processing loop for scanning documents
-func processDocuments(ctx context.Context, scanner virusscanner.VirusScanningService) {
+// retryWebhookDeliveries periodically re-attempts failed webhook deliveries whose backoff has elapsed.
+// Deliveries that exhaust the retry policy are moved to the dead-letter state by the webhook service.
+func retryWebhookDeliveries(ctx context.Context, webhookService services.WebhookService) {
 	for {
-		// Process the scan queue with the specified batch size
-		count, err := scanner.ProcessScanQueue(ctx, batchSize)
+		count, err := webhookService.RetryFailedDeliveries(ctx, webhookRetryBatchSize)
 		if err != nil {
-			logger.Error("Error processing scan queue", "error", err)
+			logger.Error("Error retrying webhook deliveries", "error", err)
+		} else if count > 0 {
+			logger.Info("Retried webhook deliveries", "count", count)
+		}
+
+		select {
+		case <-time.After(webhookRetryInterval):
+			// Continue retrying after interval
+		case <-ctx.Done():
+			logger.Info("Stopping webhook retry scheduler")
+			return
+		}
+	}
+}
+
+// relayOutboxEvents publishes events from the transactional outbox. Full batches are followed
+// immediately by another sweep so that a backlog drains without waiting for the interval.
+func relayOutboxEvents(ctx context.Context, relay services.OutboxRelay) {
+	lastPurge := time.Time{}
+
+	for {
+		count, err := relay.RelayPending(ctx, outboxRelayBatchSize)
+		if err != nil {
+			logger.Error("Error relaying outbox events", "error", err)
+		} else if count > 0 {
+			logger.Info("Relayed outbox events", "count", count)
+		}
+
+		if time.Since(lastPurge) >= outboxPurgeInterval {
+			purged, err := relay.PurgeSent(ctx, outboxRetention)
+			if err != nil {
+				logger.Error("Error purging sent outbox events", "error", err)
+			} else if purged > 0 {
+				logger.Info("Purged sent outbox events", "count", purged)
+			}
+			lastPurge = time.Now()
+		}
+
+		wait := outboxRelayInterval
+		if err == nil && count == outboxRelayBatchSize {
+			wait = 0
+		}
+
+		select {
+		case <-time.After(wait):
+			// Continue relaying after interval
+		case <-ctx.Done():
+			logger.Info("Stopping outbox relay")
+			return
+		}
+	}
+}
+
+// exportFolders runs folder export jobs one at a time. After finishing a job it immediately looks
+// for the next one, so queued exports do not wait for the interval.
+func exportFolders(ctx context.Context, exporter services.FolderExporter) {
+	for {
+		exported, err := exporter.ExportNext(ctx)
+		if err != nil {
+			logger.Error("Error exporting folder", "error", err)
+		}
+
+		wait := exportPollInterval
+		if err == nil && exported {
+			wait = 0
+		}
+
+		select {
+		case <-time.After(wait):
+			// Continue exporting after interval
+		case <-ctx.Done():
+			logger.Info("Stopping folder exporter")
+			return
+		}
+	}
+}
+
+// exportSubjectAccess runs subject access exports one at a time, immediately looking for the next
+// one after finishing an export
+func exportSubjectAccess(ctx context.Context, exporter services.SubjectAccessExporter) {
+	for {
+		exported, err := exporter.ExportNext(ctx)
+		if err != nil {
+			logger.Error("Error exporting personal data", "error", err)
+		}
+
+		wait := subjectAccessPollInterval
+		if err == nil && exported {
+			wait = 0
+		}
+
+		select {
+		case <-time.After(wait):
+			// Continue exporting after interval
+		case <-ctx.Done():
+			logger.Info("Stopping subject access exporter")
+			return
+		}
+	}
+}
+
+// publishScheduledDocuments publishes and expires documents whose scheduled time has come. Full
+// batches are followed immediately by another run so that a backlog drains without waiting for the interval.
+func publishScheduledDocuments(ctx context.Context, scheduler services.PublicationScheduler) {
+	for {
+		count, err := scheduler.PublishDue(ctx, time.Now().UTC(), publicationBatchSize)
+		if err != nil {
+			logger.Error("Error publishing scheduled documents", "error", err)
+		} else if count > 0 {
+			logger.Info("Published or expired scheduled documents", "count", count)
+		}
+
+		wait := publicationInterval
+		if err == nil && count == publicationBatchSize {
+			wait = 0
+		}
+
+		select {
+		case <-time.After(wait):
+			// Continue publishing after interval
+		case <-ctx.Done():
+			logger.Info("Stopping publication scheduler")
+			return
+		}
+	}
+}
+
+// reindexTenants runs search index rebuilds one at a time. After finishing a rebuild it immediately
+// looks for the next one, so queued rebuilds do not wait for the interval.
+func reindexTenants(ctx context.Context, reindexer services.SearchReindexer) {
+	for {
+		reindexed, err := reindexer.ReindexNext(ctx)
+		if err != nil {
+			logger.Error("Error rebuilding search index", "error", err)
+		}
+
+		wait := reindexPollInterval
+		if err == nil && reindexed {
+			wait = 0
+		}
+
+		select {
+		case <-time.After(wait):
+			// Continue reindexing after interval
+		case <-ctx.Done():
+			logger.Info("Stopping search reindexer")
+			return
+		}
+	}
+}
+
+// eraseTenants erases the tenants whose deletion is due. While there are deletions left to run it
+// continues right away, otherwise it checks again after an interval.
+func eraseTenants(ctx context.Context, offboarding services.TenantOffboardingService) {
+	for {
+		erased, err := offboarding.DeleteNext(ctx)
+		if err != nil {
+			logger.Error("Error erasing tenant", "error", err)
+		}
+
+		wait := tenantDeletionPollInterval
+		if err == nil && erased {
+			wait = 0
+		}
+
+		select {
+		case <-time.After(wait):
+			// Continue erasing after interval
+		case <-ctx.Done():
+			logger.Info("Stopping tenant offboarding")
+			return
+		}
+	}
+}
+
+// rotateEncryptionKeys re-encrypts content whose tenant's key has changed. While there is content
+// left to re-encrypt it continues right away, otherwise it checks again after an interval.
+func rotateEncryptionKeys(ctx context.Context, rotator services.KeyRotator) {
+	for {
+		rotated, err := rotator.RotateNext(ctx)
+		if err != nil {
+			logger.Error("Error rotating encryption keys", "error", err)
+		}
+
+		wait := keyRotationInterval
+		if err == nil && rotated > 0 {
+			wait = 0
+		}
+
+		select {
+		case <-time.After(wait):
+			// Continue rotating after interval
+		case <-ctx.Done():
+			logger.Info("Stopping encryption key rotator")
+			return
+		}
+	}
+}
+
+// classifyDocuments tags documents with the categories of personal data detected in their content.
+// While there are versions left to classify it continues right away, otherwise it checks again after
+// an interval.
+func classifyDocuments(ctx context.Context, classifier services.DocumentClassifier) {
+	for {
+		classified, err := classifier.ClassifyNext(ctx)
+		if err != nil {
+			logger.Error("Error classifying documents", "error", err)
+		}
+
+		wait := classificationInterval
+		if err == nil && classified > 0 {
+			wait = 0
+		}
+
+		select {
+		case <-time.After(wait):
+			// Continue classifying after interval
+		case <-ctx.Done():
+			logger.Info("Stopping document classifier")
+			return
+		}
+	}
+}
+
+// maintainAuditPartitions creates the audit log partitions for the current and next month ahead
+// of time so that entries never fall through to the default partition at a month boundary.
+func maintainAuditPartitions(ctx context.Context, auditService services.AuditService) {
+	for {
+		if err := auditService.EnsurePartitions(ctx, time.Now().UTC()); err != nil {
+			logger.Error("Error ensuring audit log partitions", "error", err)
+		}
+
+		select {
+		case <-time.After(auditPartitionInterval):
+			// Continue maintenance after interval
+		case <-ctx.Done():
+			logger.Info("Stopping audit log partition maintenance")
+			return
+		}
+	}
+}
+
+// checkStorageReplication periodically compares the replica of the document storage with it,
+// recording the documents missing from or differing in the replica, so that replication that
+// stopped is noticed before the replica is needed
+func checkStorageReplication(ctx context.Context, checker storage.ReplicationChecker, interval time.Duration, settle time.Duration) {
+	for {
+		report, err := checker.CheckReplication(ctx, settle)
+		if err != nil {
+			logger.Error("Error checking storage replication", "error", err)
 		} else {
-			logger.Info("Processed documents from queue", "count", count)
+			metrics.SetStorageReplicationInconsistencies(report.Missing, report.Mismatched)
+			if report.Missing > 0 || report.Mismatched > 0 {
+				logger.Warn("Storage replica is inconsistent with the document storage", "checked", report.Checked,
+					"missing", report.Missing, "mismatched", report.Mismatched, "samples", report.Samples)
+			} else {
+				logger.Info("Storage replica is consistent with the document storage", "checked", report.Checked)
+			}
+		}
+
+		select {
+		case <-time.After(interval):
+			// Continue checking after interval
+		case <-ctx.Done():
+			logger.Info("Stopping storage replication consistency check")
+			return
+		}
+	}
+}
+
+// reportScanQueueDepth periodically records the number of scan tasks waiting in the queue of each
+// priority, so that a growing backlog of scans can be alerted on
+func reportScanQueueDepth(ctx context.Context, queueDepth services.ScanQueueDepth) {
+	for {
+		depth, err := queueDepth.Depth(ctx)
+		if err != nil {
+			logger.Error("Error getting scan queue depth", "error", err)
+		}
+		for priority, count := range depth {
+			metrics.SetScanQueueDepth(priority, count)
+		}
+
+		select {
+		case <-time.After(scanQueueDepthInterval):
+			// Continue reporting after interval
+		case <-ctx.Done():
+			logger.Info("Stopping scan queue depth reporter")
+			return
+		}
+	}
+}
+
+// rescanOnSignatureUpdates periodically checks the ClamAV signature database version and queues
+// recent uploads for another scan whenever it changes
+func rescanOnSignatureUpdates(ctx context.Context, rescanner services.SignatureRescanner) {
+	for {
+		count, err := rescanner.RescanIfUpdated(ctx)
+		if err != nil {
+			logger.Error("Error rescanning after signature update", "error", err)
+		} else if count > 0 {
+			logger.Info("Queued documents for rescanning after signature update", "count", count)
 		}
 
-		// Sleep for the processing interval
 		select {
-		case <-time.After(processingInterval):
-			// Continue processing after interval
+		case <-time.After(signatureCheckInterval):
+			// Continue checking after interval
 		case <-ctx.Done():
-			// Context is cancelled, exit the loop
-			logger.Info("Stopping document processing")
+			logger.Info("Stopping signature update rescanner")
 			return
 		}
 	}
 }
 
+// newScanningEngines creates the scanning engines available to tenants: ClamAV, the ICAP and
+// external verdict engines when they are configured, and the no-op engine when it is a default
+func newScanningEngines(cfg config.ScanningConfig, clamAVClient services.ScanningEngine) ([]services.ScanningEngine, error) {
+	engines := []services.ScanningEngine{clamAVClient}
+	for _, name := range cfg.DefaultEngines {
+		if name == models.ScanEngineNone {
+			engines = append(engines, noop.NewNoopEngine())
+			break
+		}
+	}
+	if cfg.ICAP.URL != "" {
+		engine, err := icap.NewICAPEngine(cfg.ICAP)
+		if err != nil {
+			return nil, err
+		}
+		engines = append(engines, engine)
+	}
+	if cfg.External.URL != "" {
+		engine, err := external.NewVerdictEngine(cfg.External)
+		if err != nil {
+			return nil, err
+		}
+		engines = append(engines, engine)
+	}
+	return engines, nil
+}
+
+// newPIIDetectors creates the PII detectors available to tenants: the regex detector, and AWS
+// Comprehend when it is configured
+func newPIIDetectors(cfg config.ClassificationConfig) ([]services.PIIDetector, error) {
+	detectors := []services.PIIDetector{regex.NewRegexDetector()}
+	if cfg.Comprehend.Region != "" {
+		detector, err := comprehend.NewComprehendDetector(cfg.Comprehend)
+		if err != nil {
+			return nil, err
+		}
+		detectors = append(detectors, detector)
+	}
+	return detectors, nil
+}
+
+// newAuditExporter creates the audit exporter selected by the configuration.
+// It returns nil when audit log forwarding is disabled.
+func newAuditExporter(cfg config.AuditConfig) (services.AuditExporter, error) {
+	switch cfg.Exporter {
+	case "", models.AuditExporterNone:
+		return nil, nil
+	case models.AuditExporterSyslog:
+		return auditsyslog.NewCEFExporter(cfg.Syslog)
+	case models.AuditExporterS3:
+		return audits3.NewJSONLExporter(cfg.S3)
+	default:
+		return nil, fmt.Errorf("unsupported audit exporter: %s", cfg.Exporter)
+	}
+}
+
+// forwardAuditLogs forwards the audit log to the configured exporter. Full batches are followed
+// immediately by another run so that a backlog drains without waiting for the interval.
+func forwardAuditLogs(ctx context.Context, forwarder services.AuditForwarder, cfg config.AuditConfig) {
+	batchSize := cfg.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultAuditForwardBatchSize
+	}
+	interval := parseDurationOrDefault(cfg.Interval, defaultAuditForwardInterval)
+	settleDelay := parseDurationOrDefault(cfg.SettleDelay, defaultAuditForwardSettleDelay)
+
+	for {
+		count, err := forwarder.ForwardPending(ctx, batchSize, settleDelay)
+		if err != nil {
+			logger.Error("Error forwarding audit logs", "error", err)
+		} else if count > 0 {
+			logger.Info("Forwarded audit logs", "count", count)
+		}
+
+		wait := interval
+		if err == nil && count == batchSize {
+			wait = 0
+		}
+
+		select {
+		case <-time.After(wait):
+			// Continue forwarding after interval
+		case <-ctx.Done():
+			logger.Info("Stopping audit log forwarder")
+			return
+		}
+	}
+}
+
+// parseDurationOrDefault parses a duration setting, falling back to the default when it is unset or invalid
+func parseDurationOrDefault(value string, defaultValue time.Duration) time.Duration {
+	if value == "" {
+		return defaultValue
+	}
+	parsed, err := time.ParseDuration(value)
+	if err != nil || parsed < 0 {
+		logger.Error("Invalid duration setting, using default", "value", value, "default", defaultValue)
+		return defaultValue
+	}
+	return parsed
+}
+
 // gracefulShutdown performs graceful shutdown of worker components
 func gracefulShutdown(ctx context.Context) {
 	// Create a context with timeout for shutdown operations
@@ -171,6 +993,11 @@ func gracefulShutdown(ctx context.Context) {
 
 	logger.Info("Shutting down worker", "timeout", shutdownTimeout)
 
+	// Close database connection
+	if err := postgres.Close(); err != nil {
+		logger.Error("Error closing database connection", "error", err)
+	}
+
 	// Shutdown metrics collection
 	if err := metrics.Shutdown(); err != nil {
 		logger.Error("Error shutting down metrics", "error", err)
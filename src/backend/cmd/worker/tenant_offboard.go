@@ -0,0 +1,295 @@
+package main
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"../../domain/repositories"
+	"../../domain/services"
+	messaging "../../infrastructure/messaging/providers"
+	"../../infrastructure/persistence/postgres"
+	searchproviders "../../infrastructure/search/providers"
+	"../../infrastructure/storage"
+	"../../infrastructure/storage/providers"
+	"../../pkg/config"
+	"../../pkg/utils"
+)
+
+// tenantOffboardCommand is the worker subcommand scheduling and inspecting tenant deletions
+const tenantOffboardCommand = "tenant-offboard"
+
+// tenantOffboardUsage describes the tenant-offboard subcommand
+const tenantOffboardUsage = `Usage:
+  tenant-offboard schedule -tenant TENANT_ID -requested-by NAME -reason REASON [-grace DURATION]
+      Freeze the tenant and erase it once the grace period is over, by default offboarding.grace_period.
+  tenant-offboard cancel -id DELETION_ID
+      Cancel a deletion that has not erased anything yet and restore the tenant.
+  tenant-offboard list [-page N]
+      List the tenant deletions, most recent first.
+  tenant-offboard report -id DELETION_ID [-out FILE]
+      Verify the destruction report of a completed deletion, and write it to FILE with its
+      signature to FILE.sig.
+  tenant-offboard public-key
+      Print the public key destruction reports are verified with.
+`
+
+// TenantOffboard runs the tenant-offboard subcommand with its arguments and returns the exit code.
+// The worker erases the tenants whose grace period is over; this command schedules and cancels
+// their deletion, and hands out the signed destruction reports.
+func TenantOffboard(cfg config.Config, args []string) int {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, tenantOffboardUsage)
+		return 2
+	}
+
+	signingKey, err := loadReportSigningKey(cfg.Offboarding.ReportSigningKeyFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load report signing key: %v\n", err)
+		return 1
+	}
+	if args[0] == "public-key" {
+		return printReportPublicKey(signingKey, os.Stdout)
+	}
+
+	ctx := context.Background()
+	if err := postgres.Init(cfg.Database); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize database: %v\n", err)
+		return 1
+	}
+	defer postgres.Close()
+	messageBus, err := messaging.New(ctx, cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize message bus: %v\n", err)
+		return 1
+	}
+	defer messageBus.Close()
+	offboarding, err := newTenantOffboardingCommandService(ctx, cfg, messageBus.ScanQueue(), signingKey)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize tenant offboarding: %v\n", err)
+		return 1
+	}
+
+	switch args[0] {
+	case "schedule":
+		return scheduleTenantDeletion(ctx, offboarding, cfg.Offboarding, args[1:], os.Stdout)
+	case "cancel":
+		return cancelTenantDeletion(ctx, offboarding, args[1:], os.Stdout)
+	case "list":
+		return listTenantDeletions(ctx, offboarding, args[1:], os.Stdout)
+	case "report":
+		return writeTenantDestructionReport(ctx, offboarding, args[1:], os.Stdout)
+	default:
+		fmt.Fprint(os.Stderr, tenantOffboardUsage)
+		return 2
+	}
+}
+
+// newTenantOffboardingCommandService creates the tenant offboarding service on the configured
+// database, storage and search index
+func newTenantOffboardingCommandService(ctx context.Context, cfg config.Config, scanQueue services.ScanQueue, signingKey ed25519.PrivateKey) (services.TenantOffboardingService, error) {
+	db, err := postgres.GetDB()
+	if err != nil {
+		return nil, err
+	}
+	storageProvider, err := providers.New(ctx, cfg.Storage, cfg.S3)
+	if err != nil {
+		return nil, err
+	}
+	metadataSchemaService, err := services.NewMetadataSchemaService(postgres.NewMetadataFieldRepository())
+	if err != nil {
+		return nil, err
+	}
+	searchIndexer, _, err := searchproviders.New(ctx, cfg, metadataSchemaService)
+	if err != nil {
+		return nil, err
+	}
+
+	return newTenantOffboardingService(postgres.NewTenantRepository(db), storageProvider, searchIndexer, scanQueue, signingKey)
+}
+
+// newTenantOffboardingService creates the tenant offboarding service erasing tenants from the
+// storage provider, the search index and the dead letters of the scan queue, when it keeps any
+func newTenantOffboardingService(tenantRepo repositories.TenantRepository, storageProvider storage.StorageProvider,
+	searchIndexer services.SearchIndexer, scanQueue services.ScanQueue, signingKey ed25519.PrivateKey) (services.TenantOffboardingService, error) {
+	storageEraser, err := storage.NewTenantStorageEraser(storageProvider)
+	if err != nil {
+		return nil, err
+	}
+	searchRemover, ok := searchIndexer.(services.SearchTenantRemover)
+	if !ok {
+		return nil, fmt.Errorf("search indexer %T cannot remove tenants", searchIndexer)
+	}
+	deadLetters, _ := scanQueue.(services.ScanDeadLetterQueue)
+
+	return services.NewTenantOffboardingService(postgres.NewTenantDeletionRepository(), tenantRepo, storageEraser,
+		searchRemover, deadLetters, signingKey)
+}
+
+// loadReportSigningKey reads the PKCS #8 Ed25519 private key destruction reports are signed with
+func loadReportSigningKey(file string) (ed25519.PrivateKey, error) {
+	if file == "" {
+		return nil, fmt.Errorf("offboarding.report_signing_key_file is not set")
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signing key file: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("signing key file is not PEM encoded")
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signing key: %w", err)
+	}
+	signingKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("signing key is a %T rather than an Ed25519 key", key)
+	}
+	return signingKey, nil
+}
+
+// printReportPublicKey prints the public key of the signing key as a PEM block, as read by
+// openssl pkeyutl -verify -pubin
+func printReportPublicKey(signingKey ed25519.PrivateKey, out io.Writer) int {
+	publicKey := signingKey.Public().(ed25519.PublicKey)
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to encode public key: %v\n", err)
+		return 1
+	}
+
+	fmt.Fprintf(out, "# Key ID %s\n", services.TenantReportSigningKeyID(publicKey))
+	pem.Encode(out, &pem.Block{Type: "PUBLIC KEY", Bytes: der})
+	return 0
+}
+
+// scheduleTenantDeletion freezes a tenant and prints when it will be erased
+func scheduleTenantDeletion(ctx context.Context, offboarding services.TenantOffboardingService, cfg config.OffboardingConfig, args []string, out io.Writer) int {
+	flags := flag.NewFlagSet("tenant-offboard schedule", flag.ContinueOnError)
+	tenantID := flags.String("tenant", "", "ID of the tenant to delete")
+	requestedBy := flags.String("requested-by", "", "who requested the deletion, recorded in the destruction report")
+	reason := flags.String("reason", "", "why the tenant is deleted, such as the contract ending, recorded in the destruction report")
+	grace := flags.Duration("grace", parseDurationOrDefault(cfg.GracePeriod, services.DefaultTenantDeletionGracePeriod), "how long the tenant stays frozen before it is erased")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *tenantID == "" || *requestedBy == "" || *reason == "" {
+		fmt.Fprint(os.Stderr, tenantOffboardUsage)
+		return 2
+	}
+
+	deletion, err := offboarding.ScheduleDeletion(ctx, *tenantID, *requestedBy, *reason, *grace)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to schedule tenant deletion: %v\n", err)
+		return 1
+	}
+
+	fmt.Fprintf(out, "Tenant %s frozen; deletion %s erases it after %s\n", deletion.TenantName, deletion.ID,
+		deletion.ScheduledFor.Format(time.RFC3339))
+	return 0
+}
+
+// cancelTenantDeletion cancels a deletion and prints the status the tenant is restored to
+func cancelTenantDeletion(ctx context.Context, offboarding services.TenantOffboardingService, args []string, out io.Writer) int {
+	flags := flag.NewFlagSet("tenant-offboard cancel", flag.ContinueOnError)
+	id := flags.String("id", "", "ID of the deletion to cancel")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *id == "" {
+		fmt.Fprint(os.Stderr, tenantOffboardUsage)
+		return 2
+	}
+
+	deletion, err := offboarding.CancelDeletion(ctx, *id)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to cancel tenant deletion: %v\n", err)
+		return 1
+	}
+
+	fmt.Fprintf(out, "Deletion %s cancelled; tenant %s is %s again\n", deletion.ID, deletion.TenantName, deletion.PreviousStatus)
+	return 0
+}
+
+// listTenantDeletions prints a page of tenant deletions as a table
+func listTenantDeletions(ctx context.Context, offboarding services.TenantOffboardingService, args []string, out io.Writer) int {
+	flags := flag.NewFlagSet("tenant-offboard list", flag.ContinueOnError)
+	page := flags.Int("page", 1, "page of deletions to list")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	result, err := offboarding.ListDeletions(ctx, utils.NewPagination(*page, utils.DefaultPageSize))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to list tenant deletions: %v\n", err)
+		return 1
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tTENANT\tSTATUS\tSCHEDULED FOR\tREQUESTED BY\tERROR")
+	for _, deletion := range result.Items {
+		fmt.Fprintf(w, "%s\t%s (%s)\t%s\t%s\t%s\t%s\n", deletion.ID, deletion.TenantName, deletion.TenantID, deletion.Status,
+			deletion.ScheduledFor.Format(time.RFC3339), deletion.RequestedBy, deletion.Error)
+	}
+	w.Flush()
+	fmt.Fprintf(out, "Page %d of %d, %d deletion(s)\n", result.Pagination.Page, result.Pagination.TotalPages, result.Pagination.TotalItems)
+	return 0
+}
+
+// writeTenantDestructionReport verifies the destruction report of a deletion, then prints it, or
+// writes it with its raw signature so that it can be checked with openssl
+func writeTenantDestructionReport(ctx context.Context, offboarding services.TenantOffboardingService, args []string, out io.Writer) int {
+	flags := flag.NewFlagSet("tenant-offboard report", flag.ContinueOnError)
+	id := flags.String("id", "", "ID of the completed deletion")
+	file := flags.String("out", "", "file to write the report to, with its signature next to it in FILE.sig")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *id == "" {
+		fmt.Fprint(os.Stderr, tenantOffboardUsage)
+		return 2
+	}
+
+	deletion, err := offboarding.GetDeletion(ctx, *id)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to get tenant deletion: %v\n", err)
+		return 1
+	}
+	if err := offboarding.VerifyReport(deletion); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to verify destruction report: %v\n", err)
+		return 1
+	}
+
+	if *file == "" {
+		fmt.Fprintln(out, deletion.Report)
+		fmt.Fprintf(out, "Signature (Ed25519, key ID %s): %s\n", deletion.SigningKeyID, deletion.ReportSignature)
+		return 0
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(deletion.ReportSignature)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to decode report signature: %v\n", err)
+		return 1
+	}
+	if err := os.WriteFile(*file, []byte(deletion.Report), 0o644); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to write destruction report: %v\n", err)
+		return 1
+	}
+	if err := os.WriteFile(*file+".sig", signature, 0o644); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to write report signature: %v\n", err)
+		return 1
+	}
+
+	fmt.Fprintf(out, "Destruction report of tenant %s written to %s, signed with key ID %s\n", deletion.TenantName, *file, deletion.SigningKeyID)
+	return 0
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"../../domain/services"
+	"../../infrastructure/encryption/kms"
+	"../../infrastructure/persistence/postgres"
+	"../../infrastructure/storage"
+	"../../infrastructure/storage/providers"
+	"../../pkg/config"
+)
+
+// tenantTransferCommand is the worker subcommand exporting and importing whole tenants
+const tenantTransferCommand = "tenant-transfer"
+
+// tenantTransferUsage describes the tenant-transfer subcommand
+const tenantTransferUsage = `Usage:
+  tenant-transfer export -tenant TENANT_ID
+      Write the tenant with its users, folders, documents, metadata and permissions to a new bundle.
+  tenant-transfer import -bundle BUNDLE [-name NAME]
+      Restore a bundle as a new tenant, named as the exported tenant unless NAME is given.
+`
+
+// TenantTransfer runs the tenant-transfer subcommand with its arguments and returns the exit code.
+// It moves tenants between environments, and restores them into a fresh environment in disaster
+// recovery drills. Bundles are kept in document storage, so an environment imports the bundles
+// exported to its own storage or copied there.
+func TenantTransfer(cfg config.Config, args []string) int {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, tenantTransferUsage)
+		return 2
+	}
+
+	if err := postgres.Init(cfg.Database); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize database: %v\n", err)
+		return 1
+	}
+	defer postgres.Close()
+	transfer, err := newTenantTransferService(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize tenant transfer: %v\n", err)
+		return 1
+	}
+
+	ctx := context.Background()
+	switch args[0] {
+	case "export":
+		return exportTenant(ctx, transfer, args[1:], os.Stdout)
+	case "import":
+		return importTenant(ctx, transfer, args[1:], os.Stdout)
+	default:
+		fmt.Fprint(os.Stderr, tenantTransferUsage)
+		return 2
+	}
+}
+
+// newTenantTransferService creates the tenant transfer service on the configured database and storage
+func newTenantTransferService(cfg config.Config) (services.TenantTransferService, error) {
+	db, err := postgres.GetDB()
+	if err != nil {
+		return nil, err
+	}
+	userRepo, err := postgres.NewUserRepository(db)
+	if err != nil {
+		return nil, err
+	}
+	tagRepo, err := postgres.NewTagRepository(db)
+	if err != nil {
+		return nil, err
+	}
+	permissionRepo, err := postgres.NewPermissionRepository(db)
+	if err != nil {
+		return nil, err
+	}
+	tenantRepo := postgres.NewTenantRepository(db)
+
+	keyService, err := kms.NewKeyService(cfg.S3, tenantRepo)
+	if err != nil {
+		return nil, err
+	}
+	storageProvider, err := providers.New(context.Background(), cfg.Storage, cfg.S3)
+	if err != nil {
+		return nil, err
+	}
+	storageService, err := storage.NewTenantStorageService(storageProvider, keyService, postgres.NewContentBlobRepository(), postgres.NewTransactionManager())
+	if err != nil {
+		return nil, err
+	}
+	bundleStore, err := storage.NewTenantBundleStore(storageProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	return services.NewTenantTransferService(tenantRepo, userRepo, postgres.NewRoleRepository(), postgres.NewGroupRepository(),
+		postgres.NewFolderRepository(db), tagRepo, postgres.NewDocumentRepository(db), permissionRepo, storageService, bundleStore)
+}
+
+// exportTenant exports a tenant and prints the files of its bundle as a table
+func exportTenant(ctx context.Context, transfer services.TenantTransferService, args []string, out io.Writer) int {
+	flags := flag.NewFlagSet("tenant-transfer export", flag.ContinueOnError)
+	tenantID := flags.String("tenant", "", "ID of the tenant to export")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *tenantID == "" {
+		fmt.Fprint(os.Stderr, tenantTransferUsage)
+		return 2
+	}
+
+	manifest, err := transfer.ExportTenant(ctx, *tenantID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to export tenant: %v\n", err)
+		return 1
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "FILE\tRECORDS\tSIZE\tSHA256")
+	for _, file := range manifest.Files {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%s\n", file.Name, file.Records, file.Size, file.SHA256)
+	}
+	w.Flush()
+	if manifest.SkippedDocuments > 0 {
+		fmt.Fprintf(out, "%d document(s) without an available version skipped\n", manifest.SkippedDocuments)
+	}
+	fmt.Fprintf(out, "Tenant %s exported to bundle %s\n", manifest.TenantName, manifest.Bundle)
+	return 0
+}
+
+// importTenant imports a bundle and prints the records imported and skipped as a table
+func importTenant(ctx context.Context, transfer services.TenantTransferService, args []string, out io.Writer) int {
+	flags := flag.NewFlagSet("tenant-transfer import", flag.ContinueOnError)
+	bundle := flags.String("bundle", "", "bundle printed by the export")
+	name := flags.String("name", "", "name of the new tenant, by default the name of the exported tenant")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *bundle == "" {
+		fmt.Fprint(os.Stderr, tenantTransferUsage)
+		return 2
+	}
+
+	result, err := transfer.ImportTenant(ctx, *bundle, *name)
+	if result != nil {
+		printImportResult(out, result)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to import tenant: %v\n", err)
+		if result != nil {
+			fmt.Fprintf(os.Stderr, "The partially imported tenant %s is left suspended\n", result.TenantID)
+		}
+		return 1
+	}
+
+	fmt.Fprintf(out, "Bundle %s imported as tenant %s\n", *bundle, result.TenantID)
+	return 0
+}
+
+// printImportResult prints the records imported and skipped of each bundle file
+func printImportResult(out io.Writer, result *services.TenantImportResult) {
+	names := make([]string, 0, len(result.Imported))
+	for name := range result.Imported {
+		names = append(names, name)
+	}
+	for name := range result.Skipped {
+		if _, ok := result.Imported[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "FILE\tIMPORTED\tSKIPPED")
+	for _, name := range names {
+		fmt.Fprintf(w, "%s\t%d\t%d\n", name, result.Imported[name], result.Skipped[name])
+	}
+	w.Flush()
+}
@@ -0,0 +1,194 @@
+// Package models defines the core domain models for the document management platform
+package models
+
+import (
+	"errors"  // standard library - For error handling in validation methods
+	"strings" // standard library - For attribute parsing
+	"time"    // standard library - For timestamp fields
+)
+
+// Access policy action constants identify the document operations a policy restricts
+const (
+	PolicyActionRead  = "read"
+	PolicyActionWrite = "write"
+)
+
+// Access policy operator constants define how a document attribute is compared with the policy values
+const (
+	PolicyOperatorEquals    = "equals"
+	PolicyOperatorNotEquals = "not_equals"
+	PolicyOperatorIn        = "in"
+	PolicyOperatorExists    = "exists"
+)
+
+// Access policy attribute constants name the document attributes a policy can match on.
+// Custom metadata is addressed with the metadata. prefix, e.g. metadata.classification.
+const (
+	PolicyAttributeContentType    = "content_type"
+	PolicyAttributeFolderID       = "folder_id"
+	PolicyAttributeOwnerID        = "owner_id"
+	PolicyAttributeStatus         = "status"
+	PolicyAttributeMetadataPrefix = "metadata."
+)
+
+// Error variables for access policy validation
+var (
+	ErrPolicyNameEmpty          = errors.New("policy name cannot be empty")
+	ErrPolicyTenantIDEmpty      = errors.New("policy tenant ID cannot be empty")
+	ErrPolicyInvalidAttribute   = errors.New("policy attribute must be content_type, folder_id, owner_id, status or metadata.<key>")
+	ErrPolicyInvalidOperator    = errors.New("policy operator must be equals, not_equals, in or exists")
+	ErrPolicyValuesEmpty        = errors.New("policy must have at least one value for this operator")
+	ErrPolicyActionsEmpty       = errors.New("policy must apply to at least one action")
+	ErrPolicyInvalidAction      = errors.New("policy action must be read or write")
+	ErrPolicyRequiredRolesEmpty = errors.New("policy must require at least one role")
+)
+
+// AccessPolicy is an attribute-based rule evaluated on document access in addition to role permissions.
+// When a document matches the policy's condition, the listed actions are only allowed for users
+// holding at least one of the required roles. For example: metadata.classification equals
+// "confidential" requires the "legal" role to read.
+type AccessPolicy struct {
+	ID            string    `json:"id"`
+	TenantID      string    `json:"tenant_id"`
+	Name          string    `json:"name"`
+	Description   string    `json:"description"`
+	Attribute     string    `json:"attribute"`
+	Operator      string    `json:"operator"`
+	MatchValues   []string  `json:"match_values"`
+	Actions       []string  `json:"actions"`
+	RequiredRoles []string  `json:"required_roles"`
+	Enabled       bool      `json:"enabled"`
+	CreatedBy     string    `json:"created_by"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// NewAccessPolicy creates a new enabled AccessPolicy
+func NewAccessPolicy(tenantID, name, attribute, operator string, matchValues, actions, requiredRoles []string, createdBy string) *AccessPolicy {
+	now := time.Now()
+	return &AccessPolicy{
+		TenantID:      tenantID,
+		Name:          name,
+		Attribute:     attribute,
+		Operator:      operator,
+		MatchValues:   matchValues,
+		Actions:       actions,
+		RequiredRoles: requiredRoles,
+		Enabled:       true,
+		CreatedBy:     createdBy,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
+
+// Validate checks that the policy is complete and uses supported attributes, operators and actions
+func (p *AccessPolicy) Validate() error {
+	if p.Name == "" {
+		return ErrPolicyNameEmpty
+	}
+	if p.TenantID == "" {
+		return ErrPolicyTenantIDEmpty
+	}
+	if !IsValidPolicyAttribute(p.Attribute) {
+		return ErrPolicyInvalidAttribute
+	}
+	switch p.Operator {
+	case PolicyOperatorEquals, PolicyOperatorNotEquals, PolicyOperatorIn:
+		if len(p.MatchValues) == 0 {
+			return ErrPolicyValuesEmpty
+		}
+	case PolicyOperatorExists:
+	default:
+		return ErrPolicyInvalidOperator
+	}
+	if len(p.Actions) == 0 {
+		return ErrPolicyActionsEmpty
+	}
+	for _, action := range p.Actions {
+		if action != PolicyActionRead && action != PolicyActionWrite {
+			return ErrPolicyInvalidAction
+		}
+	}
+	if len(p.RequiredRoles) == 0 {
+		return ErrPolicyRequiredRolesEmpty
+	}
+	return nil
+}
+
+// IsValidPolicyAttribute checks whether the attribute names a document attribute policies can match on
+func IsValidPolicyAttribute(attribute string) bool {
+	switch attribute {
+	case PolicyAttributeContentType, PolicyAttributeFolderID, PolicyAttributeOwnerID, PolicyAttributeStatus:
+		return true
+	}
+	return strings.HasPrefix(attribute, PolicyAttributeMetadataPrefix) &&
+		len(attribute) > len(PolicyAttributeMetadataPrefix)
+}
+
+// AppliesTo checks whether the policy restricts the given action
+func (p *AccessPolicy) AppliesTo(action string) bool {
+	for _, a := range p.Actions {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
+// Matches checks whether the document satisfies the policy's attribute condition
+func (p *AccessPolicy) Matches(document *Document) bool {
+	value, present := documentAttribute(document, p.Attribute)
+
+	switch p.Operator {
+	case PolicyOperatorExists:
+		return present
+	case PolicyOperatorEquals:
+		return present && value == p.MatchValues[0]
+	case PolicyOperatorNotEquals:
+		return !present || value != p.MatchValues[0]
+	case PolicyOperatorIn:
+		if !present {
+			return false
+		}
+		for _, v := range p.MatchValues {
+			if v == value {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// IsSatisfiedBy checks whether a user holding the given roles meets the policy's role requirement
+func (p *AccessPolicy) IsSatisfiedBy(roles []string) bool {
+	for _, required := range p.RequiredRoles {
+		for _, role := range roles {
+			if role == required {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// documentAttribute resolves a policy attribute on a document, reporting whether it is set
+func documentAttribute(document *Document, attribute string) (string, bool) {
+	switch attribute {
+	case PolicyAttributeContentType:
+		return document.ContentType, document.ContentType != ""
+	case PolicyAttributeFolderID:
+		return document.FolderID, document.FolderID != ""
+	case PolicyAttributeOwnerID:
+		return document.OwnerID, document.OwnerID != ""
+	case PolicyAttributeStatus:
+		return document.Status, document.Status != ""
+	}
+
+	key := strings.TrimPrefix(attribute, PolicyAttributeMetadataPrefix)
+	for _, m := range document.Metadata {
+		if m.Key == key {
+			return m.Value, true
+		}
+	}
+	return "", false
+}
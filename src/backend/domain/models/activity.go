@@ -0,0 +1,123 @@
+// Package models defines the core domain models for the document management platform
+package models
+
+import (
+	"encoding/json" // standard library - For reading the change summaries of audit log entries
+	"fmt"           // standard library - For building activity descriptions
+	"time"          // standard library - For timestamp fields
+)
+
+// Activity kind constants define the kinds of entries in the activity timeline of documents and folders
+const (
+	ActivityKindCreated  = "created"
+	ActivityKindUploaded = "uploaded"
+	ActivityKindRenamed  = "renamed"
+	ActivityKindMoved    = "moved"
+	ActivityKindUpdated  = "updated"
+	ActivityKindShared   = "shared"
+	ActivityKindUnshared = "unshared"
+	ActivityKindDeleted  = "deleted"
+)
+
+// Activity is an entry of the activity timeline of a document or folder, derived from an audit log
+// entry. ResourceType and ResourceID name the document or folder the activity happened to, also for
+// permissions and share links, and Description says what happened in words for display after the
+// name of the actor, such as "renamed Q1.pdf to Q1 report.pdf".
+type Activity struct {
+	ID           string    `json:"id"`
+	Kind         string    `json:"kind"`
+	ActorID      string    `json:"actor_id"`
+	ResourceType string    `json:"resource_type"`
+	ResourceID   string    `json:"resource_id"`
+	Description  string    `json:"description"`
+	OccurredAt   time.Time `json:"occurred_at"`
+}
+
+// NewActivity derives the activity timeline entry of an audit log entry
+func NewActivity(entry AuditLog) Activity {
+	before := decodeAuditSummary(entry.Before)
+	after := decodeAuditSummary(entry.After)
+
+	activity := Activity{
+		ID:           entry.ID,
+		Kind:         ActivityKindUpdated,
+		ActorID:      entry.ActorID,
+		ResourceType: entry.ResourceType,
+		ResourceID:   entry.ResourceID,
+		OccurredAt:   entry.OccurredAt,
+	}
+
+	switch entry.ResourceType {
+	case AuditResourcePermission:
+		summary, kind, verb := after, ActivityKindShared, "shared with"
+		if entry.Action == AuditActionRevoke {
+			summary, kind, verb = before, ActivityKindUnshared, "stopped sharing with"
+		}
+		activity.Kind = kind
+		activity.ResourceType = summary["resourceType"]
+		activity.ResourceID = summary["resourceID"]
+		activity.Description = fmt.Sprintf("%s %s %s (%s)", verb, summary["granteeType"], summary["granteeID"], summary["permissionType"])
+	case AuditResourceShareLink:
+		activity.Kind = ActivityKindShared
+		activity.Description = "created a share link"
+		if entry.Action == AuditActionRevoke {
+			activity.Kind = ActivityKindUnshared
+			activity.Description = "revoked a share link"
+		}
+		activity.ResourceType = ResourceTypeDocument
+		activity.ResourceID = after["document_id"]
+	default:
+		activity.Kind, activity.Description = describeResourceChange(entry.Action, entry.ResourceType, before, after)
+	}
+
+	return activity
+}
+
+// describeResourceChange returns the activity kind and description of an operation on a document or folder
+func describeResourceChange(action, resourceType string, before, after map[string]string) (string, string) {
+	switch action {
+	case AuditActionUpload:
+		return ActivityKindUploaded, fmt.Sprintf("uploaded %s", after["name"])
+	case AuditActionCreate:
+		return ActivityKindCreated, fmt.Sprintf("created the %s %s", resourceType, after["name"])
+	case AuditActionMove:
+		if before["path"] != "" {
+			return ActivityKindMoved, fmt.Sprintf("moved the %s from %s", resourceType, before["path"])
+		}
+		return ActivityKindMoved, fmt.Sprintf("moved the %s", resourceType)
+	case AuditActionDelete, AuditActionDestroy:
+		return ActivityKindDeleted, fmt.Sprintf("deleted the %s", resourceType)
+	}
+
+	switch {
+	case after["name"] != "" && after["name"] != before["name"]:
+		return ActivityKindRenamed, fmt.Sprintf("renamed %s to %s", before["name"], after["name"])
+	case after["folderID"] != "" && after["folderID"] != before["folderID"]:
+		return ActivityKindMoved, fmt.Sprintf("moved the %s", resourceType)
+	case after["metadataSet"] != "" || after["metadataRemove"] != "":
+		return ActivityKindUpdated, "updated the metadata"
+	case after["visibility"] != "":
+		return ActivityKindUpdated, "changed the publication schedule"
+	}
+	return ActivityKindUpdated, fmt.Sprintf("updated the %s", resourceType)
+}
+
+// decodeAuditSummary decodes a before or after change summary, keeping its fields as text. Fields
+// that are not strings keep their JSON encoding and null fields are left out.
+func decodeAuditSummary(data json.RawMessage) map[string]string {
+	var fields map[string]json.RawMessage
+	if len(data) == 0 || json.Unmarshal(data, &fields) != nil {
+		return map[string]string{}
+	}
+
+	summary := make(map[string]string, len(fields))
+	for key, value := range fields {
+		var text string
+		if json.Unmarshal(value, &text) == nil {
+			summary[key] = text
+		} else if string(value) != "null" {
+			summary[key] = string(value)
+		}
+	}
+	return summary
+}
@@ -0,0 +1,167 @@
+// Package models defines the core domain models for the document management platform
+package models
+
+import (
+	"crypto/rand"   // standard library - For generating API keys
+	"crypto/sha256" // standard library - For hashing API keys at rest
+	"encoding/hex"  // standard library - For encoding keys and hashes
+	"errors"        // standard library - For error handling in validation methods
+	"strings"       // standard library - For principal ID handling
+	"time"          // standard library - For timestamp fields
+)
+
+// APIKeyPrefix identifies API keys
+const APIKeyPrefix = "dmk_"
+
+// APIKeyDisplayPrefixLength is the number of leading key characters stored to help users recognize a key
+const APIKeyDisplayPrefixLength = len(APIKeyPrefix) + 8
+
+// APIKeyPrincipalPrefix prefixes the ID of an API key where a user ID is expected, so that
+// requests authenticated with an API key are attributed to the key
+const APIKeyPrincipalPrefix = "apikey:"
+
+// AuditResourceAPIKey is the resource type recorded for API key operations
+const AuditResourceAPIKey = "api_key"
+
+// Error variables for API key validation
+var (
+	ErrAPIKeyTenantIDEmpty = errors.New("API key tenant ID cannot be empty")
+	ErrAPIKeyNameEmpty     = errors.New("API key name cannot be empty")
+	ErrAPIKeyNameTooLong   = errors.New("API key name cannot exceed 100 characters")
+	ErrAPIKeyRoleInvalid   = errors.New("API key role must be a tenant role other than system")
+	ErrAPIKeyHashEmpty     = errors.New("API key hash cannot be empty")
+	ErrAPIKeyExpiryInvalid = errors.New("API key expiry must be in the future")
+)
+
+// APIKey is a long-lived credential for service-to-service integrations. A key belongs to a
+// tenant and acts with a single role. Only a hash of the key is stored.
+type APIKey struct {
+	ID         string     `json:"id"`
+	TenantID   string     `json:"tenant_id"`
+	Name       string     `json:"name"`
+	Role       string     `json:"role"`
+	KeyPrefix  string     `json:"key_prefix"`
+	KeyHash    string     `json:"-"`
+	CreatedBy  string     `json:"created_by"`
+	CreatedAt  time.Time  `json:"created_at"`
+	RotatedAt  *time.Time `json:"rotated_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+}
+
+// NewAPIKey creates a new APIKey and returns it together with the plaintext key, which is only
+// available at creation time. A nil expiresAt creates a key that does not expire.
+func NewAPIKey(tenantID, name, role, createdBy string, expiresAt *time.Time) (*APIKey, string, error) {
+	key := &APIKey{
+		TenantID:  tenantID,
+		Name:      strings.TrimSpace(name),
+		Role:      role,
+		CreatedBy: createdBy,
+		CreatedAt: time.Now(),
+		ExpiresAt: expiresAt,
+	}
+
+	plaintext, err := key.setSecret()
+	if err != nil {
+		return nil, "", err
+	}
+
+	return key, plaintext, nil
+}
+
+// Validate checks that the API key has all required fields
+func (k *APIKey) Validate() error {
+	if k.TenantID == "" {
+		return ErrAPIKeyTenantIDEmpty
+	}
+	if k.Name == "" {
+		return ErrAPIKeyNameEmpty
+	}
+	if len(k.Name) > 100 {
+		return ErrAPIKeyNameTooLong
+	}
+	if k.Role == "" || k.Role == RoleSystem {
+		return ErrAPIKeyRoleInvalid
+	}
+	if k.KeyHash == "" {
+		return ErrAPIKeyHashEmpty
+	}
+	if k.ExpiresAt != nil && !k.ExpiresAt.After(k.CreatedAt) {
+		return ErrAPIKeyExpiryInvalid
+	}
+	return nil
+}
+
+// Rotate replaces the key's secret and returns the new plaintext key. The old key stops working.
+func (k *APIKey) Rotate() (string, error) {
+	plaintext, err := k.setSecret()
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	k.RotatedAt = &now
+	return plaintext, nil
+}
+
+// IsRevoked checks if the API key has been revoked
+func (k *APIKey) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
+
+// IsExpired checks if the API key has expired at the given time
+func (k *APIKey) IsExpired(now time.Time) bool {
+	return k.ExpiresAt != nil && !now.Before(*k.ExpiresAt)
+}
+
+// IsUsable checks if the API key can still be used to authenticate
+func (k *APIKey) IsUsable(now time.Time) bool {
+	return !k.IsRevoked() && !k.IsExpired(now)
+}
+
+// Revoke marks the API key as revoked
+func (k *APIKey) Revoke() {
+	now := time.Now()
+	k.RevokedAt = &now
+}
+
+// PrincipalID returns the ID under which requests authenticated with the key are attributed
+func (k *APIKey) PrincipalID() string {
+	return APIKeyPrincipalPrefix + k.ID
+}
+
+// setSecret generates a new key secret, stores its hash and display prefix, and returns the plaintext key
+func (k *APIKey) setSecret() (string, error) {
+	plaintext, err := GenerateAPIKey()
+	if err != nil {
+		return "", err
+	}
+
+	k.KeyHash = HashAPIKey(plaintext)
+	k.KeyPrefix = plaintext[:APIKeyDisplayPrefixLength]
+	return plaintext, nil
+}
+
+// GenerateAPIKey generates a new random API key
+func GenerateAPIKey() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return APIKeyPrefix + hex.EncodeToString(buf), nil
+}
+
+// HashAPIKey returns the SHA-256 hash under which an API key is stored
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsAPIKeyPrincipal checks if a user ID refers to an API key, and returns the key's ID if so
+func IsAPIKeyPrincipal(userID string) (string, bool) {
+	if !strings.HasPrefix(userID, APIKeyPrincipalPrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(userID, APIKeyPrincipalPrefix), true
+}
@@ -0,0 +1,292 @@
+// Package models defines the core domain models for the document management platform
+package models
+
+import (
+	"errors"  // standard library - For error handling in validation methods
+	"sort"    // standard library - For ordering decisions by position
+	"strings" // standard library - For trimming names and approver IDs
+	"time"    // standard library - For timestamp fields
+)
+
+// Approval mode constants define in which order the approvers of a chain decide
+const (
+	// ApprovalModeSequential asks the approvers one after the other, in the order of the chain
+	ApprovalModeSequential = "sequential"
+	// ApprovalModeParallel asks all approvers at once
+	ApprovalModeParallel = "parallel"
+)
+
+// Approval request status constants define the states of an approval request. A request is pending
+// until every approver approved it, an approver rejected it or its submitter cancelled it.
+const (
+	ApprovalStatusPending   = "pending"
+	ApprovalStatusApproved  = "approved"
+	ApprovalStatusRejected  = "rejected"
+	ApprovalStatusCancelled = "cancelled"
+)
+
+// Approval decision constants define the decision of an approver on a request
+const (
+	ApprovalDecisionPending  = "pending"
+	ApprovalDecisionApproved = "approved"
+	ApprovalDecisionRejected = "rejected"
+)
+
+// MaxApprovers is the maximum number of approvers of an approval chain
+const MaxApprovers = 20
+
+// MaxApprovalCommentLength is the maximum number of characters of a submission or decision comment
+const MaxApprovalCommentLength = 2000
+
+// Error variables for approval workflow validation and transitions
+var (
+	ErrApprovalWorkflowTenantIDEmpty     = errors.New("approval workflow tenant ID cannot be empty")
+	ErrApprovalWorkflowFolderIDEmpty     = errors.New("approval workflow folder ID cannot be empty")
+	ErrApprovalWorkflowNameEmpty         = errors.New("approval workflow name cannot be empty")
+	ErrApprovalWorkflowInvalidMode       = errors.New("approval workflow mode must be sequential or parallel")
+	ErrApprovalWorkflowApproversEmpty    = errors.New("approval workflow must list at least one approver")
+	ErrApprovalWorkflowApproverEmpty     = errors.New("approval workflow approver IDs cannot be empty")
+	ErrApprovalWorkflowDuplicateApprover = errors.New("approval workflow lists an approver more than once")
+	ErrApprovalWorkflowTooManyApprovers  = errors.New("approval workflow cannot list more than 20 approvers")
+	ErrApprovalRequestDocumentIDEmpty    = errors.New("approval request document ID cannot be empty")
+	ErrApprovalCommentTooLong            = errors.New("approval comment cannot exceed 2000 characters")
+	ErrApprovalRequestClosed             = errors.New("approval request is no longer pending")
+	ErrApprovalNotApprover               = errors.New("user is not an approver of the approval request")
+	ErrApprovalAlreadyDecided            = errors.New("approver has already decided on the approval request")
+	ErrApprovalNotYourTurn               = errors.New("approval request is waiting for an earlier approver of the chain")
+)
+
+// ApprovalWorkflow is an approval chain attached to a folder: documents of the folder submitted for
+// approval must be approved by the listed approvers, one after the other or all at once. A folder
+// has at most one approval workflow.
+type ApprovalWorkflow struct {
+	ID        string    `json:"id"`
+	TenantID  string    `json:"tenant_id"`
+	FolderID  string    `json:"folder_id"`
+	Name      string    `json:"name"`
+	Mode      string    `json:"mode"`
+	Approvers []string  `json:"approvers"` // User IDs in the order they are asked
+	CreatedBy string    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NewApprovalWorkflow creates a new ApprovalWorkflow for a folder
+func NewApprovalWorkflow(tenantID, folderID, name, mode string, approvers []string, createdBy string) *ApprovalWorkflow {
+	now := time.Now()
+	return &ApprovalWorkflow{
+		TenantID:  tenantID,
+		FolderID:  folderID,
+		Name:      name,
+		Mode:      mode,
+		Approvers: approvers,
+		CreatedBy: createdBy,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// Validate checks that the workflow belongs to a folder and lists at most MaxApprovers distinct approvers
+func (w *ApprovalWorkflow) Validate() error {
+	if w.TenantID == "" {
+		return ErrApprovalWorkflowTenantIDEmpty
+	}
+	if w.FolderID == "" {
+		return ErrApprovalWorkflowFolderIDEmpty
+	}
+	if strings.TrimSpace(w.Name) == "" {
+		return ErrApprovalWorkflowNameEmpty
+	}
+	if w.Mode != ApprovalModeSequential && w.Mode != ApprovalModeParallel {
+		return ErrApprovalWorkflowInvalidMode
+	}
+	if len(w.Approvers) == 0 {
+		return ErrApprovalWorkflowApproversEmpty
+	}
+	if len(w.Approvers) > MaxApprovers {
+		return ErrApprovalWorkflowTooManyApprovers
+	}
+
+	seen := make(map[string]bool, len(w.Approvers))
+	for _, approver := range w.Approvers {
+		if strings.TrimSpace(approver) == "" {
+			return ErrApprovalWorkflowApproverEmpty
+		}
+		if seen[approver] {
+			return ErrApprovalWorkflowDuplicateApprover
+		}
+		seen[approver] = true
+	}
+	return nil
+}
+
+// ApprovalDecision is the decision of one approver of an approval request
+type ApprovalDecision struct {
+	ID         string     `json:"id"`
+	RequestID  string     `json:"request_id"`
+	ApproverID string     `json:"approver_id"`
+	Position   int        `json:"position"` // Order of the approver in the chain, from 0
+	Decision   string     `json:"decision"`
+	Comment    string     `json:"comment"`
+	DecidedAt  *time.Time `json:"decided_at"`
+}
+
+// ApprovalRequest is a document submitted for approval through the workflow of its folder. The
+// request copies the mode and approvers of the workflow, so changing the workflow does not affect
+// requests already submitted. The document stays locked while the request is pending.
+type ApprovalRequest struct {
+	ID          string             `json:"id"`
+	TenantID    string             `json:"tenant_id"`
+	DocumentID  string             `json:"document_id"`
+	FolderID    string             `json:"folder_id"`
+	WorkflowID  string             `json:"workflow_id"`
+	Mode        string             `json:"mode"`
+	Status      string             `json:"status"`
+	SubmittedBy string             `json:"submitted_by"`
+	Comment     string             `json:"comment"`
+	Decisions   []ApprovalDecision `json:"decisions" gorm:"foreignKey:RequestID"`
+	CreatedAt   time.Time          `json:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at"`
+	CompletedAt *time.Time         `json:"completed_at"`
+}
+
+// NewApprovalRequest creates a pending ApprovalRequest for a document of the workflow's folder,
+// with a pending decision for every approver of the workflow
+func NewApprovalRequest(workflow *ApprovalWorkflow, documentID, submittedBy, comment string) *ApprovalRequest {
+	now := time.Now()
+	decisions := make([]ApprovalDecision, len(workflow.Approvers))
+	for i, approver := range workflow.Approvers {
+		decisions[i] = ApprovalDecision{
+			ApproverID: approver,
+			Position:   i,
+			Decision:   ApprovalDecisionPending,
+		}
+	}
+	return &ApprovalRequest{
+		TenantID:    workflow.TenantID,
+		DocumentID:  documentID,
+		FolderID:    workflow.FolderID,
+		WorkflowID:  workflow.ID,
+		Mode:        workflow.Mode,
+		Status:      ApprovalStatusPending,
+		SubmittedBy: submittedBy,
+		Comment:     comment,
+		Decisions:   decisions,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+// Validate checks that the request is for a document and that its comment is not too long
+func (r *ApprovalRequest) Validate() error {
+	if r.TenantID == "" {
+		return ErrApprovalWorkflowTenantIDEmpty
+	}
+	if r.DocumentID == "" {
+		return ErrApprovalRequestDocumentIDEmpty
+	}
+	if len([]rune(r.Comment)) > MaxApprovalCommentLength {
+		return ErrApprovalCommentTooLong
+	}
+	if len(r.Decisions) == 0 {
+		return ErrApprovalWorkflowApproversEmpty
+	}
+	return nil
+}
+
+// IsPending returns whether the request still awaits decisions
+func (r *ApprovalRequest) IsPending() bool {
+	return r.Status == ApprovalStatusPending
+}
+
+// PendingApprovers returns the approvers the request is waiting for: the next approver of a
+// sequential chain, or every approver of a parallel chain who has not decided yet
+func (r *ApprovalRequest) PendingApprovers() []string {
+	if !r.IsPending() {
+		return []string{}
+	}
+
+	approvers := []string{}
+	for _, decision := range r.orderedDecisions() {
+		if decision.Decision != ApprovalDecisionPending {
+			continue
+		}
+		approvers = append(approvers, decision.ApproverID)
+		if r.Mode == ApprovalModeSequential {
+			break
+		}
+	}
+	return approvers
+}
+
+// Decide records the decision of an approver. A rejection rejects the request; the request is
+// approved once every approver approved it. In a sequential chain, approvers decide in order.
+// It returns the recorded decision.
+func (r *ApprovalRequest) Decide(approverID string, approve bool, comment string, now time.Time) (*ApprovalDecision, error) {
+	if !r.IsPending() {
+		return nil, ErrApprovalRequestClosed
+	}
+	if len([]rune(comment)) > MaxApprovalCommentLength {
+		return nil, ErrApprovalCommentTooLong
+	}
+
+	var decision *ApprovalDecision
+	for i := range r.Decisions {
+		if r.Decisions[i].ApproverID == approverID {
+			decision = &r.Decisions[i]
+			break
+		}
+	}
+	if decision == nil {
+		return nil, ErrApprovalNotApprover
+	}
+	if decision.Decision != ApprovalDecisionPending {
+		return nil, ErrApprovalAlreadyDecided
+	}
+	if r.Mode == ApprovalModeSequential {
+		if pending := r.PendingApprovers(); len(pending) == 0 || pending[0] != approverID {
+			return nil, ErrApprovalNotYourTurn
+		}
+	}
+
+	decision.Decision = ApprovalDecisionRejected
+	if approve {
+		decision.Decision = ApprovalDecisionApproved
+	}
+	decision.Comment = comment
+	decision.DecidedAt = &now
+	r.UpdatedAt = now
+
+	if !approve {
+		r.complete(ApprovalStatusRejected, now)
+	} else if len(r.PendingApprovers()) == 0 {
+		r.complete(ApprovalStatusApproved, now)
+	}
+	return decision, nil
+}
+
+// Cancel withdraws a pending request
+func (r *ApprovalRequest) Cancel(now time.Time) error {
+	if !r.IsPending() {
+		return ErrApprovalRequestClosed
+	}
+	r.complete(ApprovalStatusCancelled, now)
+	return nil
+}
+
+// complete closes the request with a final status
+func (r *ApprovalRequest) complete(status string, now time.Time) {
+	r.Status = status
+	r.CompletedAt = &now
+	r.UpdatedAt = now
+}
+
+// orderedDecisions returns the decisions of the request in the order of the chain
+func (r *ApprovalRequest) orderedDecisions() []ApprovalDecision {
+	decisions := make([]ApprovalDecision, len(r.Decisions))
+	copy(decisions, r.Decisions)
+	sort.Slice(decisions, func(i, j int) bool {
+		return decisions[i].Position < decisions[j].Position
+	})
+	return decisions
+}
@@ -0,0 +1,34 @@
+// Package models defines the core domain models for the document management platform
+package models
+
+import (
+	"time" // standard library - For checkpoint timestamps
+)
+
+// Audit exporter name constants, matching the values accepted by config.Audit.Exporter
+const (
+	AuditExporterNone   = "none"
+	AuditExporterSyslog = "syslog"
+	AuditExporterS3     = "s3"
+)
+
+// AuditExportCheckpoint records how far an exporter has forwarded the audit log.
+// Entries are forwarded in (OccurredAt, ID) order, so the checkpoint is the last entry exported.
+type AuditExportCheckpoint struct {
+	Exporter       string    `json:"exporter"`
+	LastOccurredAt time.Time `json:"last_occurred_at"`
+	LastID         string    `json:"last_id"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// IsEmpty reports whether the exporter has not forwarded any entries yet
+func (c *AuditExportCheckpoint) IsEmpty() bool {
+	return c.LastID == ""
+}
+
+// Advance moves the checkpoint to the given entry
+func (c *AuditExportCheckpoint) Advance(entry *AuditLog) {
+	c.LastOccurredAt = entry.OccurredAt
+	c.LastID = entry.ID
+	c.UpdatedAt = time.Now().UTC()
+}
@@ -0,0 +1,136 @@
+// Package models defines the core domain models for the document management platform
+package models
+
+import (
+	"encoding/json" // standard library - For before/after change summaries
+	"errors"        // standard library - For error handling in validation methods
+	"time"          // standard library - For timestamp fields
+)
+
+// Audit action constants
+const (
+	AuditActionCreate   = "create"
+	AuditActionUpdate   = "update"
+	AuditActionDelete   = "delete"
+	AuditActionMove     = "move"
+	AuditActionUpload   = "upload"
+	AuditActionDownload = "download"
+	AuditActionGrant    = "grant"
+	AuditActionRevoke   = "revoke"
+	AuditActionLock     = "lock"
+	AuditActionRelease  = "release"
+	AuditActionDestroy  = "destroy"
+	AuditActionDeny     = "deny"
+	AuditActionVerify   = "verify"
+)
+
+// AuditResourcePermission is the resource type recorded for permission operations.
+// Documents and folders use ResourceTypeDocument and ResourceTypeFolder.
+const AuditResourcePermission = "permission"
+
+// Audit export format constants
+const (
+	AuditExportFormatCSV   = "csv"
+	AuditExportFormatJSONL = "jsonl"
+)
+
+// Error variables for audit log validation
+var (
+	ErrAuditTenantIDEmpty     = errors.New("audit tenant ID cannot be empty")
+	ErrAuditActionEmpty       = errors.New("audit action cannot be empty")
+	ErrAuditResourceTypeEmpty = errors.New("audit resource type cannot be empty")
+	ErrAuditResourceIDEmpty   = errors.New("audit resource ID cannot be empty")
+	ErrAuditInvalidRange      = errors.New("audit log filter 'from' must be before 'to'")
+	ErrAuditInvalidFormat     = errors.New("audit export format must be csv or jsonl")
+)
+
+// AuditLog records who did what to which resource, from where and when.
+// Before and After hold a JSON summary of the fields that the operation changed.
+type AuditLog struct {
+	ID           string          `json:"id"`
+	TenantID     string          `json:"tenant_id"`
+	ActorID      string          `json:"actor_id"`
+	ActorIP      string          `json:"actor_ip"`
+	UserAgent    string          `json:"user_agent"`
+	RequestID    string          `json:"request_id"`
+	Action       string          `json:"action"`
+	ResourceType string          `json:"resource_type"`
+	ResourceID   string          `json:"resource_id"`
+	Before       json.RawMessage `json:"before"`
+	After        json.RawMessage `json:"after"`
+	OccurredAt   time.Time       `json:"occurred_at"`
+}
+
+// NewAuditLog creates a new AuditLog entry with before/after change summaries
+func NewAuditLog(tenantID, actorID, action, resourceType, resourceID string, before, after map[string]interface{}) (*AuditLog, error) {
+	entry := &AuditLog{
+		TenantID:     tenantID,
+		ActorID:      actorID,
+		Action:       action,
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+		OccurredAt:   time.Now().UTC(),
+	}
+
+	if before != nil {
+		data, err := json.Marshal(before)
+		if err != nil {
+			return nil, err
+		}
+		entry.Before = data
+	}
+
+	if after != nil {
+		data, err := json.Marshal(after)
+		if err != nil {
+			return nil, err
+		}
+		entry.After = data
+	}
+
+	if err := entry.Validate(); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// Validate validates that the audit log entry has all required fields
+func (a *AuditLog) Validate() error {
+	if a.TenantID == "" {
+		return ErrAuditTenantIDEmpty
+	}
+	if a.Action == "" {
+		return ErrAuditActionEmpty
+	}
+	if a.ResourceType == "" {
+		return ErrAuditResourceTypeEmpty
+	}
+	if a.ResourceID == "" {
+		return ErrAuditResourceIDEmpty
+	}
+	return nil
+}
+
+// AuditLogFilter narrows an audit log query. Empty fields are not filtered on.
+type AuditLogFilter struct {
+	ActorID      string
+	Action       string
+	ResourceType string
+	ResourceID   string
+	From         time.Time
+	To           time.Time
+}
+
+// Validate checks that the filter's time range is consistent
+func (f AuditLogFilter) Validate() error {
+	if !f.From.IsZero() && !f.To.IsZero() && f.From.After(f.To) {
+		return ErrAuditInvalidRange
+	}
+	return nil
+}
+
+// IsValidAuditExportFormat checks whether the format is a supported export format
+func IsValidAuditExportFormat(format string) bool {
+	return format == AuditExportFormatCSV || format == AuditExportFormatJSONL
+}
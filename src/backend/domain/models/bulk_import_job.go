@@ -0,0 +1,177 @@
+// Package models defines the core domain models for the document management platform
+package models
+
+import (
+	"errors" // standard library - For bulk import job validation
+	"time"   // standard library - For timestamp fields
+)
+
+// BulkImportJob status constants
+const (
+	BulkImportJobStatusPending   = "pending"
+	BulkImportJobStatusRunning   = "running"
+	BulkImportJobStatusCompleted = "completed"
+	BulkImportJobStatusFailed    = "failed"
+)
+
+// BulkImportItem status constants
+const (
+	// BulkImportItemStatusImported marks files imported as documents
+	BulkImportItemStatusImported = "imported"
+
+	// BulkImportItemStatusPlanned marks files a dry run found importable
+	BulkImportItemStatusPlanned = "planned"
+
+	// BulkImportItemStatusSkipped marks files left out on purpose, such as empty files
+	BulkImportItemStatusSkipped = "skipped"
+
+	// BulkImportItemStatusFailed marks files the import refused or could not read
+	BulkImportItemStatusFailed = "failed"
+
+	// BulkImportItemStatusMissing marks files listed in the manifest but not found in the source
+	BulkImportItemStatusMissing = "missing"
+)
+
+// Errors returned when validating bulk import jobs
+var (
+	ErrBulkImportJobUserIDEmpty = errors.New("bulk import user ID cannot be empty")
+	ErrBulkImportJobSourceEmpty = errors.New("bulk import source cannot be empty")
+)
+
+// BulkImportJob is a background import of a legacy archive, a directory tree or the objects under
+// an S3 prefix, into a tenant. Directories become folders below the target folder and files become
+// documents uploaded as the user. The worker that runs it handles the files in the order of their
+// keys and records the outcome of each, so that a job can be resumed after the last file it
+// handled and reconciled with the source once it is over.
+type BulkImportJob struct {
+	ID             string     `json:"id"`
+	TenantID       string     `json:"tenant_id"`
+	UserID         string     `json:"user_id"`
+	Source         string     `json:"source"`
+	Manifest       string     `json:"manifest"`
+	FolderID       string     `json:"folder_id"`
+	DryRun         bool       `json:"dry_run"`
+	Status         string     `json:"status"`
+	Checkpoint     string     `json:"checkpoint"`
+	FoundFiles     int        `json:"found_files"`
+	ImportedFiles  int        `json:"imported_files"`
+	ImportedBytes  int64      `json:"imported_bytes"`
+	SkippedFiles   int        `json:"skipped_files"`
+	FailedFiles    int        `json:"failed_files"`
+	MissingFiles   int        `json:"missing_files"`
+	CreatedFolders int        `json:"created_folders"`
+	Error          string     `json:"error"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	StartedAt      *time.Time `json:"started_at"`
+	CompletedAt    *time.Time `json:"completed_at"`
+}
+
+// BulkImportItem is the outcome of importing one file of a bulk import job, a line of its
+// reconciliation report
+type BulkImportItem struct {
+	JobID      string    `json:"job_id" gorm:"primaryKey"`
+	SourceKey  string    `json:"source_key" gorm:"primaryKey"`
+	Status     string    `json:"status"`
+	DocumentID string    `json:"document_id"`
+	Size       int64     `json:"size"`
+	Reason     string    `json:"reason"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// NewBulkImportJob creates a pending import of the files at source into the folder of a tenant, or
+// into its root when folderID is empty. The manifest is the key of a CSV file in the source holding
+// the metadata of the files; it is optional.
+func NewBulkImportJob(tenantID, userID, source, manifest, folderID string, dryRun bool) *BulkImportJob {
+	now := time.Now()
+	return &BulkImportJob{
+		TenantID:  tenantID,
+		UserID:    userID,
+		Source:    source,
+		Manifest:  manifest,
+		FolderID:  folderID,
+		DryRun:    dryRun,
+		Status:    BulkImportJobStatusPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// Validate checks that the bulk import job names a tenant, user and source
+func (j *BulkImportJob) Validate() error {
+	if j.TenantID == "" {
+		return ErrTenantIDEmpty
+	}
+	if j.UserID == "" {
+		return ErrBulkImportJobUserIDEmpty
+	}
+	if j.Source == "" {
+		return ErrBulkImportJobSourceEmpty
+	}
+	return nil
+}
+
+// IsFinished checks if the bulk import job has completed or failed
+func (j *BulkImportJob) IsFinished() bool {
+	return j.Status == BulkImportJobStatusCompleted || j.Status == BulkImportJobStatusFailed
+}
+
+// Resume marks the bulk import job as running, keeping its checkpoint and counts so that it
+// continues after the last file it handled
+func (j *BulkImportJob) Resume(now time.Time) {
+	j.Status = BulkImportJobStatusRunning
+	j.Error = ""
+	if j.StartedAt == nil {
+		j.StartedAt = &now
+	}
+	j.UpdatedAt = now
+}
+
+// Requeue marks a running bulk import job as pending again, for a worker that stops before the
+// job is over
+func (j *BulkImportJob) Requeue(now time.Time) {
+	j.Status = BulkImportJobStatusPending
+	j.UpdatedAt = now
+}
+
+// Retry marks a failed bulk import job as pending again, to be resumed by a worker
+func (j *BulkImportJob) Retry(now time.Time) {
+	j.Status = BulkImportJobStatusPending
+	j.Error = ""
+	j.CompletedAt = nil
+	j.UpdatedAt = now
+}
+
+// Complete marks the bulk import job as completed
+func (j *BulkImportJob) Complete(now time.Time) {
+	j.Status = BulkImportJobStatusCompleted
+	j.CompletedAt = &now
+	j.UpdatedAt = now
+}
+
+// Fail marks the bulk import job as failed with the reason
+func (j *BulkImportJob) Fail(reason string, now time.Time) {
+	j.Status = BulkImportJobStatusFailed
+	j.Error = reason
+	j.CompletedAt = &now
+	j.UpdatedAt = now
+}
+
+// Count adds the outcome of a file to the counts of the bulk import job. Files a dry run found
+// importable are counted as imported.
+func (j *BulkImportJob) Count(item *BulkImportItem) {
+	switch item.Status {
+	case BulkImportItemStatusImported, BulkImportItemStatusPlanned:
+		j.FoundFiles++
+		j.ImportedFiles++
+		j.ImportedBytes += item.Size
+	case BulkImportItemStatusSkipped:
+		j.FoundFiles++
+		j.SkippedFiles++
+	case BulkImportItemStatusFailed:
+		j.FoundFiles++
+		j.FailedFiles++
+	case BulkImportItemStatusMissing:
+		j.MissingFiles++
+	}
+}
@@ -0,0 +1,116 @@
+// Package models defines the core domain models for the document management platform
+package models
+
+import (
+	"strings" // standard library - For parsing detector lists
+)
+
+// Categories of personal data the classification stage detects in document content
+const (
+	PIICategorySSN         = "ssn"          // US social security numbers
+	PIICategoryCreditCard  = "credit_card"  // Payment card numbers
+	PIICategoryEmail       = "email"        // Email addresses
+	PIICategoryPhone       = "phone"        // Phone numbers
+	PIICategoryBankAccount = "bank_account" // Bank account numbers and IBANs
+)
+
+// PIICategories lists every category of personal data, in the order they are reported
+var PIICategories = []string{
+	PIICategorySSN,
+	PIICategoryCreditCard,
+	PIICategoryEmail,
+	PIICategoryPhone,
+	PIICategoryBankAccount,
+}
+
+// Detectors the content of documents can be classified with
+const (
+	PIIDetectorRegex      = "regex"      // Built-in patterns, run in the worker
+	PIIDetectorComprehend = "comprehend" // AWS Comprehend, sending the text to AWS
+)
+
+// TenantSettingPIIDetectors lists the detectors the content of a tenant's documents is classified
+// with, separated by commas. Documents are tagged with the categories any of them detects. Tenants
+// without detectors are not classified.
+const TenantSettingPIIDetectors = "pii_detectors"
+
+// TenantSettingPIIRestrictSharing is the tenant setting that, when "true", prevents documents in
+// which personal data was detected from being shared through public links
+const TenantSettingPIIRestrictSharing = "pii_restrict_sharing"
+
+// piiMetadataKeyPrefix starts the metadata keys documents are tagged with detected categories under
+const piiMetadataKeyPrefix = "pii_"
+
+// PIIMetadataValue is the value of the metadata key of each category detected in a document
+const PIIMetadataValue = "detected"
+
+// PIIMetadataKey returns the metadata key a document is tagged with when the category is detected
+// in its content, such as pii_ssn. The keys are reserved: uploads and metadata updates cannot set or
+// remove them, and access policies can match on them as metadata.pii_ssn.
+func PIIMetadataKey(category string) string {
+	return piiMetadataKeyPrefix + category
+}
+
+// IsPIIMetadataKey checks if key is the metadata key of a category of personal data
+func IsPIIMetadataKey(key string) bool {
+	for _, category := range PIICategories {
+		if key == PIIMetadataKey(category) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParsePIIDetectors splits a comma separated list of detectors, dropping blanks and duplicates
+func ParsePIIDetectors(value string) []string {
+	var detectors []string
+	seen := make(map[string]bool)
+	for _, detector := range strings.Split(value, ",") {
+		detector = strings.TrimSpace(detector)
+		if detector == "" || seen[detector] {
+			continue
+		}
+		seen[detector] = true
+		detectors = append(detectors, detector)
+	}
+	return detectors
+}
+
+// IsPIIDetectorList checks if value is a comma separated list of known detectors
+func IsPIIDetectorList(value string) bool {
+	detectors := ParsePIIDetectors(value)
+	if len(detectors) == 0 {
+		return false
+	}
+	for _, detector := range detectors {
+		switch detector {
+		case PIIDetectorRegex, PIIDetectorComprehend:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// PIIDetectors returns the detectors the tenant's documents are classified with, or nil if the
+// tenant does not classify its documents
+func (t *Tenant) PIIDetectors() []string {
+	return ParsePIIDetectors(t.GetSetting(TenantSettingPIIDetectors))
+}
+
+// RestrictsPIISharing checks if the tenant prevents documents with personal data from being shared
+// through public links
+func (t *Tenant) RestrictsPIISharing() bool {
+	return t.GetSetting(TenantSettingPIIRestrictSharing) == "true"
+}
+
+// DetectedPIICategories returns the categories of personal data detected in the document
+func (d *Document) DetectedPIICategories() []string {
+	var categories []string
+	for _, category := range PIICategories {
+		if d.GetMetadata(PIIMetadataKey(category)) != "" {
+			categories = append(categories, category)
+		}
+	}
+	return categories
+}
@@ -0,0 +1,145 @@
+// Package models defines the core domain models for the document management platform
+package models
+
+import (
+	"errors"  // standard library - For error handling in validation methods
+	"strings" // standard library - For checking empty bodies
+	"time"    // standard library - For timestamp fields
+)
+
+// MaxCommentLength is the maximum number of characters of a comment body
+const MaxCommentLength = 10000
+
+// Error variables for comment validation
+var (
+	ErrCommentTenantIDEmpty   = errors.New("comment tenant ID cannot be empty")
+	ErrCommentDocumentIDEmpty = errors.New("comment document ID cannot be empty")
+	ErrCommentAuthorIDEmpty   = errors.New("comment author ID cannot be empty")
+	ErrCommentBodyEmpty       = errors.New("comment body cannot be empty")
+	ErrCommentBodyTooLong     = errors.New("comment body cannot exceed 10000 characters")
+	ErrCommentInvalidAnchor   = errors.New("comment anchor must be a page, optionally with a region within the page")
+	ErrCommentReplyAnchor     = errors.New("replies cannot be anchored; they belong to the thread of their parent")
+)
+
+// CommentAnchor places a comment on a page of a document and optionally on a region of the page.
+// The region is relative to the page, from 0 to 1, so it does not depend on how the page is rendered.
+type CommentAnchor struct {
+	Page   int     `json:"page,omitempty"`   // 1-based page number, 0 for the whole document
+	X      float64 `json:"x,omitempty"`      // Left edge of the region
+	Y      float64 `json:"y,omitempty"`      // Top edge of the region
+	Width  float64 `json:"width,omitempty"`  // Width of the region, 0 for the whole page
+	Height float64 `json:"height,omitempty"` // Height of the region, 0 for the whole page
+}
+
+// IsZero returns whether the anchor does not place the comment anywhere, i.e. it is on the whole document
+func (a CommentAnchor) IsZero() bool {
+	return a == CommentAnchor{}
+}
+
+// HasRegion returns whether the anchor places the comment on a region of its page
+func (a CommentAnchor) HasRegion() bool {
+	return a.Width > 0 || a.Height > 0
+}
+
+// Validate checks that the anchor is on a page and that its region lies within the page
+func (a CommentAnchor) Validate() error {
+	if a.Page < 0 {
+		return ErrCommentInvalidAnchor
+	}
+	if !a.HasRegion() {
+		if a.X != 0 || a.Y != 0 {
+			return ErrCommentInvalidAnchor
+		}
+		return nil
+	}
+	if a.Page == 0 || a.Width <= 0 || a.Height <= 0 || a.X < 0 || a.Y < 0 || a.X+a.Width > 1 || a.Y+a.Height > 1 {
+		return ErrCommentInvalidAnchor
+	}
+	return nil
+}
+
+// Comment is a note a user left on a document, optionally anchored to a page or region of it.
+// A comment without a parent starts a thread; replies name the comment that started their thread.
+// Threads are resolved once the discussion is over and can be reopened.
+type Comment struct {
+	ID         string        `json:"id"`
+	TenantID   string        `json:"tenant_id"`
+	DocumentID string        `json:"document_id"`
+	ParentID   string        `json:"parent_id"` // Comment starting the thread, empty for the thread itself
+	AuthorID   string        `json:"author_id"`
+	Body       string        `json:"body"`
+	Anchor     CommentAnchor `json:"anchor" gorm:"embedded;embeddedPrefix:anchor_"`
+	ResolvedBy string        `json:"resolved_by"`
+	ResolvedAt *time.Time    `json:"resolved_at"`
+	CreatedAt  time.Time     `json:"created_at"`
+	UpdatedAt  time.Time     `json:"updated_at"`
+}
+
+// NewComment creates a new Comment by a user on a document; parentID is empty to start a thread
+func NewComment(tenantID, documentID, parentID, authorID, body string, anchor CommentAnchor) *Comment {
+	now := time.Now()
+	return &Comment{
+		TenantID:   tenantID,
+		DocumentID: documentID,
+		ParentID:   parentID,
+		AuthorID:   authorID,
+		Body:       body,
+		Anchor:     anchor,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+}
+
+// Validate checks that the comment has an author, a body of at most MaxCommentLength characters and
+// a valid anchor; replies cannot be anchored
+func (c *Comment) Validate() error {
+	if c.TenantID == "" {
+		return ErrCommentTenantIDEmpty
+	}
+	if c.DocumentID == "" {
+		return ErrCommentDocumentIDEmpty
+	}
+	if c.AuthorID == "" {
+		return ErrCommentAuthorIDEmpty
+	}
+	if strings.TrimSpace(c.Body) == "" {
+		return ErrCommentBodyEmpty
+	}
+	if len([]rune(c.Body)) > MaxCommentLength {
+		return ErrCommentBodyTooLong
+	}
+	if c.IsReply() && !c.Anchor.IsZero() {
+		return ErrCommentReplyAnchor
+	}
+	return c.Anchor.Validate()
+}
+
+// IsReply returns whether the comment replies to a thread rather than starting one
+func (c *Comment) IsReply() bool {
+	return c.ParentID != ""
+}
+
+// IsResolved returns whether the thread the comment starts is resolved
+func (c *Comment) IsResolved() bool {
+	return c.ResolvedAt != nil
+}
+
+// Edit replaces the body of the comment
+func (c *Comment) Edit(body string) {
+	c.Body = body
+	c.UpdatedAt = time.Now()
+}
+
+// Resolve marks the thread the comment starts as resolved by a user
+func (c *Comment) Resolve(userID string, now time.Time) {
+	c.ResolvedBy = userID
+	c.ResolvedAt = &now
+	c.UpdatedAt = now
+}
+
+// Reopen marks the thread the comment starts as open again
+func (c *Comment) Reopen(now time.Time) {
+	c.ResolvedBy = ""
+	c.ResolvedAt = nil
+	c.UpdatedAt = now
+}
@@ -0,0 +1,23 @@
+// Package models defines the core domain models for the document management platform
+package models
+
+import (
+	"time" // standard library - For timestamp fields
+)
+
+// ContentBlob is content stored once in content-addressed storage and shared by every document
+// version of a tenant with the same SHA-256 hash. RefCount is the number of versions referencing
+// the blob; the stored content is deleted when the last reference is released.
+type ContentBlob struct {
+	TenantID    string    `json:"tenant_id" gorm:"primaryKey"`
+	ContentHash string    `json:"content_hash" gorm:"primaryKey"`
+	Size        int64     `json:"size"`
+	RefCount    int64     `json:"ref_count"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// IsShared checks if more than one document version references the blob
+func (b *ContentBlob) IsShared() bool {
+	return b.RefCount > 1
+}
@@ -21,6 +21,25 @@ const (
 	DocumentStatusFailed = "failed"
 )
 
+// Document visibility constants define whether readers see a document. Users who can write a
+// document always see it, so they can stage it before it is published.
+const (
+	// DocumentVisibilityPublished represents a document readers see
+	DocumentVisibilityPublished = "published"
+
+	// DocumentVisibilityScheduled represents a document hidden from readers until its publication time
+	DocumentVisibilityScheduled = "scheduled"
+
+	// DocumentVisibilityExpired represents a document hidden from readers since its expiry time
+	DocumentVisibilityExpired = "expired"
+)
+
+// Error variables for document publication schedule validation
+var (
+	ErrDocumentExpiryBeforePublication = errors.New("document expiry must be after its publication")
+	ErrDocumentExpiryInPast            = errors.New("document expiry must be in the future")
+)
+
 // Document represents a document in the system with its metadata and relationships.
 // This is a core entity that encapsulates document metadata, status, and relationships
 // to other entities like folders, versions, and tags.
@@ -38,6 +57,13 @@ type Document struct {
 	Metadata    []DocumentMetadata  // Associated metadata key-value pairs
 	Versions    []DocumentVersion   // Document versions history
 	Tags        []Tag               // Associated tags for categorization
+	LockedBy    string              // User who locked the document, such as by submitting it for approval
+	LockedAt    *time.Time          // Time the document was locked, nil when it is not locked
+	Visibility  string              // Whether readers see the document (published, scheduled, expired)
+	PublishAt   *time.Time          // Time a scheduled document is published, nil to publish it right away
+	ExpireAt    *time.Time          // Time the document expires and is hidden from readers, nil when it does not expire
+	Links       []DocumentLink      `gorm:"foreignKey:SourceID"` // Links from this document to others
+	LinkedFrom  []DocumentLink      `gorm:"foreignKey:TargetID"` // Links from other documents to this one
 }
 
 // NewDocument creates a new Document instance with the given parameters.
@@ -52,6 +78,7 @@ func NewDocument(name, contentType string, size int64, folderID, tenantID, owner
 		TenantID:    tenantID,
 		OwnerID:     ownerID,
 		Status:      DocumentStatusProcessing,
+		Visibility:  DocumentVisibilityPublished,
 		CreatedAt:   now,
 		UpdatedAt:   now,
 		Metadata:    []DocumentMetadata{},
@@ -104,6 +131,61 @@ func (d *Document) IsFailed() bool {
 	return d.Status == DocumentStatusFailed
 }
 
+// IsLocked checks if the document is locked against changes, such as while it awaits approval
+func (d *Document) IsLocked() bool {
+	return d.LockedAt != nil
+}
+
+// Lock locks the document against changes on behalf of a user
+func (d *Document) Lock(userID string, now time.Time) {
+	d.LockedBy = userID
+	d.LockedAt = &now
+}
+
+// Unlock releases the lock on the document
+func (d *Document) Unlock() {
+	d.LockedBy = ""
+	d.LockedAt = nil
+}
+
+// IsPublished checks if readers see the document, rather than it being scheduled or expired.
+// Documents without a visibility are published, like the documents uploaded before schedules existed.
+func (d *Document) IsPublished() bool {
+	return d.Visibility != DocumentVisibilityScheduled && d.Visibility != DocumentVisibilityExpired
+}
+
+// Schedule sets when the document is published and when it expires; nil times publish it right away
+// and never expire it. A document published in the future is hidden until then. Any other document
+// that is not published is left scheduled, for the publication scheduler to publish on its next run.
+func (d *Document) Schedule(publishAt, expireAt *time.Time, now time.Time) error {
+	if expireAt != nil && !expireAt.After(now) {
+		return ErrDocumentExpiryInPast
+	}
+	if publishAt != nil && expireAt != nil && !expireAt.After(*publishAt) {
+		return ErrDocumentExpiryBeforePublication
+	}
+
+	d.PublishAt = publishAt
+	d.ExpireAt = expireAt
+	if (publishAt != nil && publishAt.After(now)) || !d.IsPublished() {
+		d.Visibility = DocumentVisibilityScheduled
+	}
+	d.UpdatedAt = now
+	return nil
+}
+
+// NextVisibility returns the visibility the document's schedule gives it at now, which differs from
+// its current visibility when the document is due to be published or to expire
+func (d *Document) NextVisibility(now time.Time) string {
+	switch {
+	case d.Visibility == DocumentVisibilityScheduled && (d.PublishAt == nil || !d.PublishAt.After(now)):
+		return DocumentVisibilityPublished
+	case d.IsPublished() && d.ExpireAt != nil && !d.ExpireAt.After(now):
+		return DocumentVisibilityExpired
+	}
+	return d.Visibility
+}
+
 // MarkAsAvailable updates the status of the document to available
 func (d *Document) MarkAsAvailable() {
 	d.Status = DocumentStatusAvailable
@@ -164,6 +246,16 @@ func (d *Document) GetLatestVersion() *DocumentVersion {
 	return latest
 }
 
+// GetVersion gets the version of the document with the ID, nil when the document has no such version
+func (d *Document) GetVersion(versionID string) *DocumentVersion {
+	for i := range d.Versions {
+		if d.Versions[i].ID == versionID {
+			return &d.Versions[i]
+		}
+	}
+	return nil
+}
+
 // AddTag adds a tag to the document
 func (d *Document) AddTag(tag Tag) {
 	d.Tags = append(d.Tags, tag)
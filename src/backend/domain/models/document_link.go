@@ -0,0 +1,104 @@
+// Package models defines the core domain models for the document management platform
+package models
+
+import (
+	"errors" // standard library - For error handling in validation methods
+	"time"   // standard library - For timestamp fields
+)
+
+// Document link type constants define how the source of a link relates to its target
+const (
+	// DocumentLinkRelatesTo links documents that are related without one depending on the other
+	DocumentLinkRelatesTo = "relates-to"
+
+	// DocumentLinkSupersedes links a document to an earlier document it replaces, such as an amendment to its contract
+	DocumentLinkSupersedes = "supersedes"
+
+	// DocumentLinkAttachmentOf links a document to the document it is attached to
+	DocumentLinkAttachmentOf = "attachment-of"
+)
+
+// Error variables for document link validation
+var (
+	ErrDocumentLinkTenantIDEmpty = errors.New("document link tenant ID cannot be empty")
+	ErrDocumentLinkSourceIDEmpty = errors.New("document link source ID cannot be empty")
+	ErrDocumentLinkTargetIDEmpty = errors.New("document link target ID cannot be empty")
+	ErrDocumentLinkSelf          = errors.New("a document cannot be linked to itself")
+	ErrDocumentLinkInvalidType   = errors.New("document link type must be relates-to, supersedes or attachment-of")
+)
+
+// DocumentLink is a directed relation from a source document to a target document of the same
+// tenant, read as "source <type> target": an amendment supersedes its contract.
+// A pair of documents has at most one link of each type in each direction.
+type DocumentLink struct {
+	ID        string    `json:"id"`
+	TenantID  string    `json:"tenant_id"`
+	SourceID  string    `json:"source_id"`
+	TargetID  string    `json:"target_id"`
+	Type      string    `json:"type"`
+	CreatedBy string    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NewDocumentLink creates a new DocumentLink of the given type from a source document to a target document
+func NewDocumentLink(tenantID, sourceID, targetID, linkType, createdBy string) *DocumentLink {
+	now := time.Now()
+	return &DocumentLink{
+		TenantID:  tenantID,
+		SourceID:  sourceID,
+		TargetID:  targetID,
+		Type:      linkType,
+		CreatedBy: createdBy,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// Validate checks that the link joins two different documents of a tenant with a known type
+func (l *DocumentLink) Validate() error {
+	if l.TenantID == "" {
+		return ErrDocumentLinkTenantIDEmpty
+	}
+	if l.SourceID == "" {
+		return ErrDocumentLinkSourceIDEmpty
+	}
+	if l.TargetID == "" {
+		return ErrDocumentLinkTargetIDEmpty
+	}
+	if l.SourceID == l.TargetID {
+		return ErrDocumentLinkSelf
+	}
+	if !IsValidDocumentLinkType(l.Type) {
+		return ErrDocumentLinkInvalidType
+	}
+	return nil
+}
+
+// Involves returns whether the document is the source or the target of the link
+func (l *DocumentLink) Involves(documentID string) bool {
+	return l.SourceID == documentID || l.TargetID == documentID
+}
+
+// OtherEnd returns the ID of the document at the other end of the link from the given document
+func (l *DocumentLink) OtherEnd(documentID string) string {
+	if l.SourceID == documentID {
+		return l.TargetID
+	}
+	return l.SourceID
+}
+
+// ChangeType changes how the source of the link relates to its target
+func (l *DocumentLink) ChangeType(linkType string) {
+	l.Type = linkType
+	l.UpdatedAt = time.Now()
+}
+
+// IsValidDocumentLinkType returns whether the type is a known document link type
+func IsValidDocumentLinkType(linkType string) bool {
+	switch linkType {
+	case DocumentLinkRelatesTo, DocumentLinkSupersedes, DocumentLinkAttachmentOf:
+		return true
+	}
+	return false
+}
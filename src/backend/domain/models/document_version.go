@@ -25,15 +25,19 @@ const (
 // It tracks version-specific information such as version number, size, content hash,
 // status, and storage location.
 type DocumentVersion struct {
-	ID            string    // Unique identifier for the version
-	DocumentID    string    // Reference to the parent document
-	VersionNumber int       // Sequential version number
-	Size          int64     // Size in bytes
-	ContentHash   string    // SHA-256 hash of content
-	Status        string    // Current status of the version
-	StoragePath   string    // S3 storage path
-	CreatedAt     time.Time // Creation timestamp
-	CreatedBy     string    // User who created this version
+	ID                  string     // Unique identifier for the version
+	DocumentID          string     // Reference to the parent document
+	VersionNumber       int        // Sequential version number
+	Size                int64      // Size in bytes
+	ContentHash         string     // SHA-256 hash of content
+	Status              string     // Current status of the version
+	StoragePath         string     // S3 storage path
+	EncryptionKeyID     string     // KMS key the content is encrypted with, empty for provider managed keys
+	ClassifiedAt        *time.Time // When the content was classified for personal data, nil until classified
+	ClientChecksum      string     // SHA-256 hash the uploading client sent for the content, empty when it sent none
+	IntegrityVerifiedAt *time.Time // When the stored content was last read back and matched ContentHash, nil until verified
+	CreatedAt           time.Time  // Creation timestamp
+	CreatedBy           string     // User who created this version
 }
 
 // NewDocumentVersion creates a new DocumentVersion instance with the given parameters.
@@ -0,0 +1,103 @@
+// Package models defines the core domain models for the document management platform
+package models
+
+import (
+	"errors" // standard library - For error handling in validation methods
+	"time"   // standard library - For the day downloads are counted in and sign-in ages
+)
+
+// Limits of download policies
+const (
+	// MaxDailyDownloadLimit is the largest number of downloads per user and day a policy can allow
+	MaxDailyDownloadLimit = 10000
+
+	// MaxReauthenticationMinutes is the longest time since signing in a policy can require, a day
+	MaxReauthenticationMinutes = 24 * 60
+)
+
+// Reasons a download is refused by a download policy, recorded in the audit log
+const (
+	DownloadDenialViewOnly         = "view_only"
+	DownloadDenialDailyLimit       = "daily_limit_reached"
+	DownloadDenialReauthentication = "reauthentication_required"
+)
+
+// Error variables for download policy validation
+var (
+	ErrDownloadLimitInvalid           = errors.New("daily download limit must be between 0 and 10000")
+	ErrReauthenticationMinutesInvalid = errors.New("reauthentication minutes must be between 0 and 1440")
+)
+
+// DownloadPolicy controls how the documents directly in a folder can be downloaded. Previews, such
+// as thumbnails and document details, are not affected.
+type DownloadPolicy struct {
+	ViewOnly                bool // Documents can be previewed but not downloaded
+	DailyLimit              int  // Downloads per user and day, unlimited when 0
+	ReauthenticationMinutes int  // Minutes since signing in after which users sign in again to download, not required when 0
+}
+
+// Validate checks that the limits of the policy are in range
+func (p DownloadPolicy) Validate() error {
+	if p.DailyLimit < 0 || p.DailyLimit > MaxDailyDownloadLimit {
+		return ErrDownloadLimitInvalid
+	}
+	if p.ReauthenticationMinutes < 0 || p.ReauthenticationMinutes > MaxReauthenticationMinutes {
+		return ErrReauthenticationMinutesInvalid
+	}
+	return nil
+}
+
+// IsEmpty checks if the policy lets documents be downloaded without restrictions
+func (p DownloadPolicy) IsEmpty() bool {
+	return !p.ViewOnly && p.DailyLimit == 0 && p.ReauthenticationMinutes == 0
+}
+
+// RequiresReauthentication checks if users who signed in at authenticatedAt have to sign in again
+// to download at now. Users whose sign-in time is unknown have to.
+func (p DownloadPolicy) RequiresReauthentication(authenticatedAt time.Time, now time.Time) bool {
+	if p.ReauthenticationMinutes == 0 {
+		return false
+	}
+	if authenticatedAt.IsZero() {
+		return true
+	}
+	return now.Sub(authenticatedAt) > time.Duration(p.ReauthenticationMinutes)*time.Minute
+}
+
+// AuditDetails returns the policy as recorded in the audit log
+func (p DownloadPolicy) AuditDetails() map[string]interface{} {
+	return map[string]interface{}{
+		"viewOnly":                p.ViewOnly,
+		"dailyLimit":              p.DailyLimit,
+		"reauthenticationMinutes": p.ReauthenticationMinutes,
+	}
+}
+
+// DownloadDayStart returns the start of the UTC day daily downloads are counted in
+func DownloadDayStart(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
+
+// DownloadPolicyEvaluation is the result of evaluating the download policy of a document's folder
+// for a download, recorded in the audit log
+type DownloadPolicyEvaluation struct {
+	FolderID       string         // Folder whose policy was evaluated
+	Policy         DownloadPolicy // The evaluated policy
+	Allowed        bool           // Whether the download is allowed
+	Reason         string         // Why the download was refused, empty when allowed
+	DownloadsToday int            // Downloads of the user from the folder today, counting this one, when they are limited
+}
+
+// AuditDetails returns the evaluation as recorded in the audit log
+func (e *DownloadPolicyEvaluation) AuditDetails() map[string]interface{} {
+	details := e.Policy.AuditDetails()
+	details["folderId"] = e.FolderID
+	details["allowed"] = e.Allowed
+	if e.Reason != "" {
+		details["reason"] = e.Reason
+	}
+	if e.Policy.DailyLimit > 0 && e.DownloadsToday > 0 {
+		details["downloadsToday"] = e.DownloadsToday
+	}
+	return details
+}
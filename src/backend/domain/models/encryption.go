@@ -0,0 +1,26 @@
+// Package models defines the core domain models for the document management platform
+package models
+
+import (
+	"strings" // standard library - For recognizing KMS key ARNs
+)
+
+// TenantSettingEncryptionKeyID is the ARN of the AWS KMS key a tenant brings to encrypt its
+// documents with. Tenants without a key of their own use the platform's default key.
+const TenantSettingEncryptionKeyID = "encryption_key_id"
+
+// kmsKeyARNPrefix starts the ARN of every AWS KMS key and alias
+const kmsKeyARNPrefix = "arn:aws:kms:"
+
+// IsKMSKeyARN checks if value is the ARN of an AWS KMS key or alias. Tenants have to name their
+// key by ARN, since their key lives in their own AWS account.
+func IsKMSKeyARN(value string) bool {
+	return strings.HasPrefix(value, kmsKeyARNPrefix) &&
+		(strings.Contains(value, ":key/") || strings.Contains(value, ":alias/"))
+}
+
+// EncryptionKeyID returns the ARN of the KMS key the tenant brings to encrypt its documents with,
+// or an empty string if the tenant uses the platform's default key
+func (t *Tenant) EncryptionKeyID() string {
+	return t.GetSetting(TenantSettingEncryptionKeyID)
+}
@@ -14,8 +14,77 @@ const (
 	EventTypeDocumentProcessed   = "document.processed"
 	EventTypeDocumentQuarantined = "document.quarantined"
 	EventTypeDocumentDownloaded  = "document.downloaded"
+	EventTypeDocumentDeleted     = "document.deleted"
 	EventTypeFolderCreated       = "folder.created"
 	EventTypeFolderUpdated       = "folder.updated"
+	EventTypeFolderMoved         = "folder.moved"
+	EventTypeFolderDeleted       = "folder.deleted"
+	EventTypeTenantQuotaExceeded = "tenant.quota_exceeded"
+	EventTypeExportCompleted     = "export.completed"
+	EventTypeExportFailed        = "export.failed"
+)
+
+// Document lifecycle event types, published as a document moves through scanning. Together with
+// document.quarantined they let consumers follow a document end to end.
+const (
+	// EventTypeDocumentScanning is published when the worker starts scanning a document version
+	EventTypeDocumentScanning = "document.scanning"
+	// EventTypeDocumentClean is published when a scan finds a document version clean
+	EventTypeDocumentClean = "document.clean"
+	// EventTypeDocumentScanFailed is published when a document version could not be scanned
+	EventTypeDocumentScanFailed = "document.scan_failed"
+)
+
+// Document publication event types, published when the schedule of a document changes whether
+// readers see it
+const (
+	// EventTypeDocumentPublished is published when a scheduled document becomes visible to readers
+	EventTypeDocumentPublished = "document.published"
+	// EventTypeDocumentExpired is published when a document expires and is hidden from readers
+	EventTypeDocumentExpired = "document.expired"
+)
+
+// Subject access export event types, published when the archive of the personal data of a user is
+// ready to download or could not be compiled
+const (
+	// EventTypeSubjectAccessExportCompleted is published when a subject access archive can be downloaded
+	EventTypeSubjectAccessExportCompleted = "subject_access_export.completed"
+	// EventTypeSubjectAccessExportFailed is published when a subject access export failed
+	EventTypeSubjectAccessExportFailed = "subject_access_export.failed"
+)
+
+// EventTypeTenantCreated is published when a platform operator provisions a new tenant, so that
+// systems outside the platform such as billing can set the tenant up
+const EventTypeTenantCreated = "tenant.created"
+
+// EventTypeCommentCreated is published when a user comments on a document or replies to a comment
+const EventTypeCommentCreated = "comment.created"
+
+// Approval event types, published at each transition of an approval request so that consumers can
+// notify approvers and submitters
+const (
+	// EventTypeApprovalSubmitted is published when a document is submitted for approval
+	EventTypeApprovalSubmitted = "approval.submitted"
+	// EventTypeApprovalStepApproved is published when an approver approves a request others still have to decide on
+	EventTypeApprovalStepApproved = "approval.step_approved"
+	// EventTypeApprovalApproved is published when the last approver approves a request
+	EventTypeApprovalApproved = "approval.approved"
+	// EventTypeApprovalRejected is published when an approver rejects a request
+	EventTypeApprovalRejected = "approval.rejected"
+	// EventTypeApprovalCancelled is published when the submitter withdraws a request
+	EventTypeApprovalCancelled = "approval.cancelled"
+)
+
+// Signature event types, published as the envelope of a signature request progresses at its provider
+const (
+	// EventTypeSignatureSent is published when a document is sent to a provider for signature
+	EventTypeSignatureSent = "signature.sent"
+	// EventTypeSignatureCompleted is published when every signer signed and the signed version is stored
+	EventTypeSignatureCompleted = "signature.completed"
+	// EventTypeSignatureDeclined is published when a signer declines to sign
+	EventTypeSignatureDeclined = "signature.declined"
+	// EventTypeSignatureVoided is published when a signature request is withdrawn
+	EventTypeSignatureVoided = "signature.voided"
 )
 
 // Event represents a domain event in the system for document and folder operations
@@ -26,6 +95,9 @@ type Event struct {
 	Payload    json.RawMessage `json:"payload"`
 	OccurredAt time.Time       `json:"occurred_at"`
 	CreatedAt  time.Time       `json:"created_at"`
+	// RequestID identifies the API request that raised the event, so that its publication and
+	// webhook deliveries can be correlated with the request
+	RequestID string `json:"request_id,omitempty"`
 }
 
 // Validate ensures that the event has all required fields
@@ -303,4 +375,315 @@ func NewFolderUpdatedEvent(tenantID string, folderID string, changes map[string]
 	}
 	
 	return event, nil
-}
\ No newline at end of file
+}
+
+// NewTenantQuotaExceededEvent creates a new tenant.quota_exceeded event for an upload rejected by
+// the given limit, carrying the tenant's quota at the time
+func NewTenantQuotaExceededEvent(tenantID string, limit string, quota *TenantQuota, userID string, size int64) (*Event, error) {
+	if tenantID == "" {
+		return nil, errors.New("tenant ID is required")
+	}
+	if quota == nil {
+		return nil, errors.New("quota is required")
+	}
+
+	// Create a payload map with the exceeded limit and the quota
+	payload := map[string]interface{}{
+		"limit":            limit,
+		"userID":           userID,
+		"size":             size,
+		"maxStorageBytes":  quota.MaxStorageBytes,
+		"usedStorageBytes": quota.UsedStorageBytes,
+		"maxDocuments":     quota.MaxDocuments,
+		"usedDocuments":    quota.UsedDocuments,
+	}
+
+	// Marshal the payload to JSON
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	// Call NewEvent with EventTypeTenantQuotaExceeded, tenantID, and the JSON payload
+	event := NewEvent(EventTypeTenantQuotaExceeded, tenantID, jsonPayload)
+	if event == nil {
+		return nil, errors.New("failed to create event")
+	}
+
+	return event, nil
+}
+
+// NewTenantCreatedEvent creates a new tenant.created event for a provisioned tenant, carrying its
+// initial administrator and where its documents are stored and indexed. The search index and
+// routing are empty when the search backend does not keep tenants in indices.
+func NewTenantCreatedEvent(tenant *Tenant, adminID string, storagePrefix string, searchIndex string, searchRouting string) (*Event, error) {
+	if tenant == nil {
+		return nil, errors.New("tenant is required")
+	}
+
+	payload := map[string]interface{}{
+		"name":          tenant.Name,
+		"status":        tenant.Status,
+		"adminID":       adminID,
+		"storagePrefix": storagePrefix,
+		"searchIndex":   searchIndex,
+		"searchRouting": searchRouting,
+	}
+
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	event := NewEvent(EventTypeTenantCreated, tenant.ID, jsonPayload)
+	if event == nil {
+		return nil, errors.New("failed to create event")
+	}
+
+	return event, nil
+}
+
+// NewCommentCreatedEvent creates a new comment.created event for a comment on a document of a folder
+func NewCommentCreatedEvent(comment *Comment, folderID string) (*Event, error) {
+	if comment == nil {
+		return nil, errors.New("comment is required")
+	}
+
+	payload := map[string]interface{}{
+		"commentID":  comment.ID,
+		"documentID": comment.DocumentID,
+		"folderID":   folderID,
+		"parentID":   comment.ParentID,
+		"authorID":   comment.AuthorID,
+		"body":       comment.Body,
+		"anchor":     comment.Anchor,
+	}
+
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	event := NewEvent(EventTypeCommentCreated, comment.TenantID, jsonPayload)
+	if event == nil {
+		return nil, errors.New("failed to create event")
+	}
+
+	return event, nil
+}
+
+// NewApprovalEvent creates a new approval event of the given type for a transition of an approval
+// request made by a user, with the comment the user left and the approvers the request now waits for
+func NewApprovalEvent(eventType string, request *ApprovalRequest, actorID string, comment string) (*Event, error) {
+	if request == nil {
+		return nil, errors.New("approval request is required")
+	}
+
+	payload := map[string]interface{}{
+		"requestID":        request.ID,
+		"documentID":       request.DocumentID,
+		"folderID":         request.FolderID,
+		"workflowID":       request.WorkflowID,
+		"status":           request.Status,
+		"submittedBy":      request.SubmittedBy,
+		"actorID":          actorID,
+		"comment":          comment,
+		"pendingApprovers": request.PendingApprovers(),
+	}
+
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	event := NewEvent(eventType, request.TenantID, jsonPayload)
+	if event == nil {
+		return nil, errors.New("failed to create event")
+	}
+
+	return event, nil
+}
+
+// NewSignatureEvent creates a new signature event of the given type for a signature request, with
+// the status of its signers and, once completed, the version holding the signed document
+func NewSignatureEvent(eventType string, request *SignatureRequest) (*Event, error) {
+	if request == nil {
+		return nil, errors.New("signature request is required")
+	}
+
+	signers := make([]map[string]interface{}, len(request.Signers))
+	for i, signer := range request.Signers {
+		signers[i] = map[string]interface{}{
+			"name":     signer.Name,
+			"email":    signer.Email,
+			"status":   signer.Status,
+			"signedAt": signer.SignedAt,
+		}
+	}
+
+	payload := map[string]interface{}{
+		"requestID":       request.ID,
+		"documentID":      request.DocumentID,
+		"folderID":        request.FolderID,
+		"provider":        request.Provider,
+		"envelopeID":      request.EnvelopeID,
+		"status":          request.Status,
+		"statusReason":    request.StatusReason,
+		"requestedBy":     request.RequestedBy,
+		"signedVersionID": request.SignedVersionID,
+		"signers":         signers,
+	}
+
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	event := NewEvent(eventType, request.TenantID, jsonPayload)
+	if event == nil {
+		return nil, errors.New("failed to create event")
+	}
+
+	return event, nil
+}
+
+// NewDocumentVisibilityEvent creates a new document.published or document.expired event for a
+// document whose schedule changed whether readers see it
+func NewDocumentVisibilityEvent(eventType string, document *Document) (*Event, error) {
+	if document == nil {
+		return nil, errors.New("document is required")
+	}
+
+	payload := map[string]interface{}{
+		"documentID": document.ID,
+		"folderID":   document.FolderID,
+		"name":       document.Name,
+		"publishAt":  document.PublishAt,
+		"expireAt":   document.ExpireAt,
+	}
+
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	event := NewEvent(eventType, document.TenantID, jsonPayload)
+	if event == nil {
+		return nil, errors.New("failed to create event")
+	}
+
+	return event, nil
+}
+
+// NewExportCompletedEvent creates a new export.completed event announcing that the archive of an
+// export job can be downloaded from the presigned URL until it expires
+func NewExportCompletedEvent(job *ExportJob, downloadURL string) (*Event, error) {
+	if job == nil {
+		return nil, errors.New("export job is required")
+	}
+
+	payload := map[string]interface{}{
+		"exportID":      job.ID,
+		"folderID":      job.FolderID,
+		"userID":        job.UserID,
+		"documentCount": job.ExportedDocuments,
+		"archiveSize":   job.ArchiveSize,
+		"downloadURL":   downloadURL,
+	}
+
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	event := NewEvent(EventTypeExportCompleted, job.TenantID, jsonPayload)
+	if event == nil {
+		return nil, errors.New("failed to create event")
+	}
+
+	return event, nil
+}
+
+// NewExportFailedEvent creates a new export.failed event carrying the reason the export failed
+func NewExportFailedEvent(job *ExportJob) (*Event, error) {
+	if job == nil {
+		return nil, errors.New("export job is required")
+	}
+
+	payload := map[string]interface{}{
+		"exportID": job.ID,
+		"folderID": job.FolderID,
+		"userID":   job.UserID,
+		"error":    job.Error,
+	}
+
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	event := NewEvent(EventTypeExportFailed, job.TenantID, jsonPayload)
+	if event == nil {
+		return nil, errors.New("failed to create event")
+	}
+
+	return event, nil
+}
+
+// NewSubjectAccessExportCompletedEvent creates a new subject_access_export.completed event announcing
+// that the archive of the personal data of a user can be downloaded from the presigned URL until it
+// expires
+func NewSubjectAccessExportCompletedEvent(export *SubjectAccessExport, downloadURL string) (*Event, error) {
+	if export == nil {
+		return nil, errors.New("subject access export is required")
+	}
+
+	payload := map[string]interface{}{
+		"exportID":        export.ID,
+		"subjectID":       export.SubjectID,
+		"requestedBy":     export.RequestedBy,
+		"documentCount":   export.DocumentCount,
+		"auditEventCount": export.AuditEventCount,
+		"archiveSize":     export.ArchiveSize,
+		"downloadURL":     downloadURL,
+	}
+
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	event := NewEvent(EventTypeSubjectAccessExportCompleted, export.TenantID, jsonPayload)
+	if event == nil {
+		return nil, errors.New("failed to create event")
+	}
+
+	return event, nil
+}
+
+// NewSubjectAccessExportFailedEvent creates a new subject_access_export.failed event carrying the
+// reason the export failed
+func NewSubjectAccessExportFailedEvent(export *SubjectAccessExport) (*Event, error) {
+	if export == nil {
+		return nil, errors.New("subject access export is required")
+	}
+
+	payload := map[string]interface{}{
+		"exportID":    export.ID,
+		"subjectID":   export.SubjectID,
+		"requestedBy": export.RequestedBy,
+		"error":       export.Error,
+	}
+
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	event := NewEvent(EventTypeSubjectAccessExportFailed, export.TenantID, jsonPayload)
+	if event == nil {
+		return nil, errors.New("failed to create event")
+	}
+
+	return event, nil
+}
@@ -0,0 +1,119 @@
+// Package models defines the core domain models for the document management platform
+package models
+
+import (
+	"errors" // standard library - For export job validation
+	"time"   // standard library - For timestamp fields
+)
+
+// ExportJob status constants
+const (
+	ExportJobStatusPending   = "pending"
+	ExportJobStatusRunning   = "running"
+	ExportJobStatusCompleted = "completed"
+	ExportJobStatusFailed    = "failed"
+)
+
+// AuditResourceExportJob is the resource type recorded for folder exports
+const AuditResourceExportJob = "export_job"
+
+// Error variables for export job validation
+var (
+	ErrExportJobFolderIDEmpty = errors.New("export folder ID cannot be empty")
+	ErrExportJobUserIDEmpty   = errors.New("export user ID cannot be empty")
+)
+
+// ExportJob is a background export of a folder tree into a ZIP archive. The worker that runs it
+// reports progress by counting the exported documents; once completed, the archive can be
+// downloaded by the user who requested it.
+type ExportJob struct {
+	ID                string     `json:"id"`
+	TenantID          string     `json:"tenant_id"`
+	FolderID          string     `json:"folder_id"`
+	UserID            string     `json:"user_id"`
+	Status            string     `json:"status"`
+	TotalDocuments    int        `json:"total_documents"`
+	ExportedDocuments int        `json:"exported_documents"`
+	ArchivePath       string     `json:"archive_path"`
+	ArchiveSize       int64      `json:"archive_size"`
+	Error             string     `json:"error"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+	StartedAt         *time.Time `json:"started_at"`
+	CompletedAt       *time.Time `json:"completed_at"`
+}
+
+// NewExportJob creates a pending export of a folder for a user
+func NewExportJob(tenantID, folderID, userID string) *ExportJob {
+	now := time.Now()
+	return &ExportJob{
+		TenantID:  tenantID,
+		FolderID:  folderID,
+		UserID:    userID,
+		Status:    ExportJobStatusPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// Validate checks that the export job names a tenant, folder and user
+func (j *ExportJob) Validate() error {
+	if j.TenantID == "" {
+		return ErrTenantIDEmpty
+	}
+	if j.FolderID == "" {
+		return ErrExportJobFolderIDEmpty
+	}
+	if j.UserID == "" {
+		return ErrExportJobUserIDEmpty
+	}
+	return nil
+}
+
+// IsFinished checks if the export job has completed or failed
+func (j *ExportJob) IsFinished() bool {
+	return j.Status == ExportJobStatusCompleted || j.Status == ExportJobStatusFailed
+}
+
+// Progress returns the percentage of documents exported so far
+func (j *ExportJob) Progress() int {
+	if j.Status == ExportJobStatusCompleted {
+		return 100
+	}
+	if j.TotalDocuments == 0 {
+		return 0
+	}
+	return j.ExportedDocuments * 100 / j.TotalDocuments
+}
+
+// ArchiveFileName returns the file name offered when downloading the archive
+func (j *ExportJob) ArchiveFileName() string {
+	return "export-" + j.ID + ".zip"
+}
+
+// Start marks the export job as running from scratch
+func (j *ExportJob) Start(now time.Time) {
+	j.Status = ExportJobStatusRunning
+	j.TotalDocuments = 0
+	j.ExportedDocuments = 0
+	j.Error = ""
+	j.StartedAt = &now
+	j.UpdatedAt = now
+}
+
+// Complete marks the export job as completed with the stored archive
+func (j *ExportJob) Complete(archivePath string, archiveSize int64, now time.Time) {
+	j.Status = ExportJobStatusCompleted
+	j.ArchivePath = archivePath
+	j.ArchiveSize = archiveSize
+	j.CompletedAt = &now
+	j.UpdatedAt = now
+}
+
+// Fail marks the export job as failed with the reason
+func (j *ExportJob) Fail(reason string, now time.Time) {
+	j.Status = ExportJobStatusFailed
+	j.Error = reason
+	j.CompletedAt = &now
+	j.UpdatedAt = now
+}
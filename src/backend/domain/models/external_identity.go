@@ -0,0 +1,87 @@
+// Package models defines the core domain models for the document management platform
+package models
+
+import (
+	"errors"  // standard library - For error handling in validation methods
+	"strings" // standard library - For email validation
+	"time"    // standard library - For timestamp fields
+)
+
+// Error variables for external identity validation
+var (
+	ErrExternalIdentityTenantIDEmpty = errors.New("external identity tenant ID cannot be empty")
+	ErrExternalIdentitySubjectEmpty  = errors.New("external identity subject cannot be empty")
+	ErrExternalIdentityEmailInvalid  = errors.New("external identity email is invalid")
+
+	ErrExternalIdentityLinkUserIDEmpty  = errors.New("external identity link user ID cannot be empty")
+	ErrExternalIdentityLinkIssuerEmpty  = errors.New("external identity link issuer cannot be empty")
+	ErrExternalIdentityLinkSubjectEmpty = errors.New("external identity link subject cannot be empty")
+)
+
+// ExternalIdentity is a user as asserted by a tenant's external identity provider in a verified
+// ID token. Users are matched to platform accounts by issuer and subject once linked, and by
+// email only on their first sign-in and only when the IdP verified the email. Roles holds the
+// tenant roles the user's IdP groups map to and is empty when none of the groups are mapped.
+type ExternalIdentity struct {
+	TenantID      string   `json:"tenant_id"`
+	Issuer        string   `json:"issuer"`
+	Subject       string   `json:"subject"`
+	Email         string   `json:"email"`
+	EmailVerified bool     `json:"email_verified"`
+	Username      string   `json:"username"`
+	Groups        []string `json:"groups"`
+	Roles         []string `json:"roles"`
+}
+
+// Validate checks that the identity can be matched to a platform account
+func (e *ExternalIdentity) Validate() error {
+	if e.TenantID == "" {
+		return ErrExternalIdentityTenantIDEmpty
+	}
+	if e.Subject == "" {
+		return ErrExternalIdentitySubjectEmpty
+	}
+	if at := strings.Index(e.Email, "@"); at < 1 || at == len(e.Email)-1 {
+		return ErrExternalIdentityEmailInvalid
+	}
+	return nil
+}
+
+// ExternalIdentityLink ties a platform account to the subject an identity provider knows it by.
+// A subject is linked to at most one account, and an account to at most one subject per issuer.
+type ExternalIdentityLink struct {
+	ID        string    `json:"id"`
+	TenantID  string    `json:"tenant_id"`
+	UserID    string    `json:"user_id"`
+	Issuer    string    `json:"issuer"`
+	Subject   string    `json:"subject"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NewExternalIdentityLink creates a new ExternalIdentityLink of a user to an external identity
+func NewExternalIdentityLink(userID string, identity *ExternalIdentity) *ExternalIdentityLink {
+	return &ExternalIdentityLink{
+		TenantID:  identity.TenantID,
+		UserID:    userID,
+		Issuer:    identity.Issuer,
+		Subject:   identity.Subject,
+		CreatedAt: time.Now(),
+	}
+}
+
+// Validate checks that the link ties a user to an issuer and subject
+func (l *ExternalIdentityLink) Validate() error {
+	if l.TenantID == "" {
+		return ErrExternalIdentityTenantIDEmpty
+	}
+	if l.UserID == "" {
+		return ErrExternalIdentityLinkUserIDEmpty
+	}
+	if l.Issuer == "" {
+		return ErrExternalIdentityLinkIssuerEmpty
+	}
+	if l.Subject == "" {
+		return ErrExternalIdentityLinkSubjectEmpty
+	}
+	return nil
+}
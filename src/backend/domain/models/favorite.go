@@ -0,0 +1,66 @@
+// Package models defines the core domain models for the document management platform
+package models
+
+import (
+	"errors" // standard library - For error handling in validation methods
+	"time"   // standard library - For timestamp fields
+)
+
+// Error variables for favorite validation
+var (
+	ErrFavoriteTenantIDEmpty       = errors.New("favorite tenant ID cannot be empty")
+	ErrFavoriteUserIDEmpty         = errors.New("favorite user ID cannot be empty")
+	ErrFavoriteResourceIDEmpty     = errors.New("favorite resource ID cannot be empty")
+	ErrFavoriteInvalidResourceType = errors.New("favorite resource type must be document or folder")
+)
+
+// Favorite records that a user starred a document or folder, to find it again in their Starred view.
+// A user stars a resource at most once.
+type Favorite struct {
+	ID           string    `json:"id"`
+	TenantID     string    `json:"tenant_id"`
+	UserID       string    `json:"user_id"`
+	ResourceType string    `json:"resource_type"`
+	ResourceID   string    `json:"resource_id"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// TableName returns the table favorites are stored in
+func (Favorite) TableName() string {
+	return "user_favorites"
+}
+
+// NewFavorite creates a new Favorite of a user for a document or folder
+func NewFavorite(tenantID, userID, resourceType, resourceID string) *Favorite {
+	return &Favorite{
+		TenantID:     tenantID,
+		UserID:       userID,
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+		CreatedAt:    time.Now(),
+	}
+}
+
+// Validate checks that the favorite belongs to a user and points to a document or folder
+func (f *Favorite) Validate() error {
+	if f.TenantID == "" {
+		return ErrFavoriteTenantIDEmpty
+	}
+	if f.UserID == "" {
+		return ErrFavoriteUserIDEmpty
+	}
+	if f.ResourceType != ResourceTypeDocument && f.ResourceType != ResourceTypeFolder {
+		return ErrFavoriteInvalidResourceType
+	}
+	if f.ResourceID == "" {
+		return ErrFavoriteResourceIDEmpty
+	}
+	return nil
+}
+
+// RecentResource is a resource a user recently accessed, with the last access found in the audit log
+type RecentResource struct {
+	ResourceID string    `json:"resource_id"`
+	Action     string    `json:"action"`
+	AccessedAt time.Time `json:"accessed_at"`
+}
@@ -29,6 +29,20 @@ type Folder struct {
 	OwnerID   string    // ID of the user who created the folder
 	CreatedAt time.Time // Timestamp when the folder was created
 	UpdatedAt time.Time // Timestamp when the folder was last updated
+
+	// WatermarkDownloads stamps PDFs of the documents directly in the folder with who downloaded them
+	WatermarkDownloads bool
+
+	// Download policy of the documents directly in the folder, see DownloadPolicy
+	DownloadViewOnly        bool // Documents can be previewed but not downloaded
+	DailyDownloadLimit      int  // Downloads per user and day, unlimited when 0
+	ReauthenticationMinutes int  // Minutes since signing in after which users sign in again to download, not required when 0
+
+	// Rollups of the documents in the folder and its subfolders. The database maintains them as
+	// documents are added, changed, moved and removed, so they are read-only.
+	DocumentCount  int64      // Number of documents
+	TotalSize      int64      // Total size of the documents in bytes
+	LastModifiedAt *time.Time // When a document was last added, changed or removed; nil if none ever was
 }
 
 // NewFolder creates a new Folder instance with the given parameters
@@ -137,4 +151,27 @@ func (f *Folder) Update(name string) {
 func (f *Folder) SetParent(parentID string) {
 	f.ParentID = parentID
 	f.UpdatedAt = time.Now()
+}
+
+// DownloadPolicy returns the download policy of the documents directly in the folder
+func (f *Folder) DownloadPolicy() DownloadPolicy {
+	return DownloadPolicy{
+		ViewOnly:                f.DownloadViewOnly,
+		DailyLimit:              f.DailyDownloadLimit,
+		ReauthenticationMinutes: f.ReauthenticationMinutes,
+	}
+}
+
+// SetDownloadPolicy sets the download policy of the documents directly in the folder
+func (f *Folder) SetDownloadPolicy(policy DownloadPolicy) {
+	f.DownloadViewOnly = policy.ViewOnly
+	f.DailyDownloadLimit = policy.DailyLimit
+	f.ReauthenticationMinutes = policy.ReauthenticationMinutes
+	f.UpdatedAt = time.Now()
+}
+
+// SetWatermarkDownloads turns watermarking of documents downloaded from the folder on or off
+func (f *Folder) SetWatermarkDownloads(enabled bool) {
+	f.WatermarkDownloads = enabled
+	f.UpdatedAt = time.Now()
 }
\ No newline at end of file
@@ -0,0 +1,177 @@
+// Package models defines the core domain models for the document management platform
+package models
+
+import (
+	"errors"  // standard library - For error handling in validation methods
+	"strings" // standard library - For trimming names and IDs
+	"time"    // standard library - For timestamp fields
+)
+
+// MaxFolderTemplateFolders is the maximum number of folders a folder template creates below the folder it is applied as
+const MaxFolderTemplateFolders = 200
+
+// MaxFolderTemplateDepth is the maximum number of levels of folders below the folder a template is applied as
+const MaxFolderTemplateDepth = 10
+
+// Error variables for folder template validation
+var (
+	ErrFolderTemplateTenantIDEmpty       = errors.New("folder template tenant ID cannot be empty")
+	ErrFolderTemplateNameEmpty           = errors.New("folder template name cannot be empty")
+	ErrFolderTemplateFolderNameEmpty     = errors.New("folder template folder names cannot be empty")
+	ErrFolderTemplateFolderNameInvalid   = errors.New("folder template folder names cannot contain a slash")
+	ErrFolderTemplateDuplicateFolder     = errors.New("folder template lists a folder more than once in the same parent")
+	ErrFolderTemplateTooManyFolders      = errors.New("folder template cannot create more than 200 folders")
+	ErrFolderTemplateTooDeep             = errors.New("folder template cannot nest folders more than 10 levels deep")
+	ErrFolderTemplateGranteeIDEmpty      = errors.New("folder template grantee IDs cannot be empty")
+	ErrFolderTemplateInvalidMetadataMode = errors.New("folder template metadata mode must be enforce or prompt")
+)
+
+// FolderTemplateGrant is a permission granted on a folder created from a template
+type FolderTemplateGrant struct {
+	GranteeType    string `json:"grantee_type"` // role, user or group
+	GranteeID      string `json:"grantee_id"`
+	PermissionType string `json:"permission_type"` // read, write, delete or admin
+}
+
+// FolderTemplateFolder is a folder of a folder template, with the permissions granted on it, the
+// metadata documents uploaded to it must carry and its subfolders
+type FolderTemplateFolder struct {
+	Name           string                 `json:"name"`
+	Permissions    []FolderTemplateGrant  `json:"permissions"`
+	MetadataFields []string               `json:"metadata_fields"` // No metadata template is created when empty
+	MetadataMode   string                 `json:"metadata_mode"`   // enforce or prompt, enforce when empty
+	Folders        []FolderTemplateFolder `json:"folders"`
+}
+
+// FolderTemplate is a named folder tree used to set up new projects and tenants in one call, such as
+// Contracts, Invoices and Correspondence folders with their permissions and metadata templates.
+// Applying a template creates a folder with the template's permissions holding the template's folders.
+type FolderTemplate struct {
+	ID          string                 `json:"id"`
+	TenantID    string                 `json:"tenant_id"`
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	Permissions []FolderTemplateGrant  `json:"permissions" gorm:"serializer:json"` // Granted on the folder the template is applied as
+	Folders     []FolderTemplateFolder `json:"folders" gorm:"serializer:json"`
+	CreatedBy   string                 `json:"created_by"`
+	CreatedAt   time.Time              `json:"created_at"`
+	UpdatedAt   time.Time              `json:"updated_at"`
+}
+
+// NewFolderTemplate creates a new FolderTemplate
+func NewFolderTemplate(tenantID, name string, folders []FolderTemplateFolder, createdBy string) *FolderTemplate {
+	now := time.Now()
+	return &FolderTemplate{
+		TenantID:  tenantID,
+		Name:      name,
+		Folders:   folders,
+		CreatedBy: createdBy,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// Validate checks that the template has a name and a valid folder tree of at most
+// MaxFolderTemplateFolders folders and MaxFolderTemplateDepth levels
+func (t *FolderTemplate) Validate() error {
+	if t.TenantID == "" {
+		return ErrFolderTemplateTenantIDEmpty
+	}
+	if strings.TrimSpace(t.Name) == "" {
+		return ErrFolderTemplateNameEmpty
+	}
+	if err := validateFolderTemplateGrants(t.Permissions); err != nil {
+		return err
+	}
+	if t.FolderCount() > MaxFolderTemplateFolders {
+		return ErrFolderTemplateTooManyFolders
+	}
+	return validateFolderTemplateFolders(t.Folders, 1)
+}
+
+// FolderCount returns the number of folders the template creates below the folder it is applied as
+func (t *FolderTemplate) FolderCount() int {
+	return countFolderTemplateFolders(t.Folders)
+}
+
+// MetadataTemplate returns the metadata template of the folder created from f, or nil if f lists no
+// metadata fields
+func (f *FolderTemplateFolder) MetadataTemplate(tenantID, folderID, createdBy string) *MetadataTemplate {
+	if len(f.MetadataFields) == 0 {
+		return nil
+	}
+	template := NewMetadataTemplate(tenantID, folderID, f.Name, f.MetadataFields, createdBy)
+	if f.MetadataMode != "" {
+		template.Mode = f.MetadataMode
+	}
+	return template
+}
+
+// countFolderTemplateFolders counts folders and their subfolders
+func countFolderTemplateFolders(folders []FolderTemplateFolder) int {
+	count := len(folders)
+	for _, folder := range folders {
+		count += countFolderTemplateFolders(folder.Folders)
+	}
+	return count
+}
+
+// validateFolderTemplateFolders validates the folders of a level of the tree and their subfolders
+func validateFolderTemplateFolders(folders []FolderTemplateFolder, depth int) error {
+	if len(folders) > 0 && depth > MaxFolderTemplateDepth {
+		return ErrFolderTemplateTooDeep
+	}
+
+	seen := make(map[string]bool, len(folders))
+	for _, folder := range folders {
+		name := strings.TrimSpace(folder.Name)
+		if name == "" {
+			return ErrFolderTemplateFolderNameEmpty
+		}
+		if strings.Contains(name, PathSeparator) {
+			return ErrFolderTemplateFolderNameInvalid
+		}
+		if seen[name] {
+			return ErrFolderTemplateDuplicateFolder
+		}
+		seen[name] = true
+
+		if err := validateFolderTemplateGrants(folder.Permissions); err != nil {
+			return err
+		}
+		if folder.MetadataMode != "" && folder.MetadataMode != MetadataTemplateModeEnforce && folder.MetadataMode != MetadataTemplateModePrompt {
+			return ErrFolderTemplateInvalidMetadataMode
+		}
+		fields := make(map[string]bool, len(folder.MetadataFields))
+		for _, field := range folder.MetadataFields {
+			if strings.TrimSpace(field) == "" {
+				return ErrMetadataTemplateFieldEmpty
+			}
+			if fields[field] {
+				return ErrMetadataTemplateDuplicateField
+			}
+			fields[field] = true
+		}
+
+		if err := validateFolderTemplateFolders(folder.Folders, depth+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateFolderTemplateGrants checks that permissions name a valid grantee and permission type
+func validateFolderTemplateGrants(grants []FolderTemplateGrant) error {
+	for _, grant := range grants {
+		if !IsValidGranteeType(grant.GranteeType) {
+			return ErrInvalidGranteeType
+		}
+		if strings.TrimSpace(grant.GranteeID) == "" {
+			return ErrFolderTemplateGranteeIDEmpty
+		}
+		if !IsValidPermissionType(grant.PermissionType) {
+			return ErrInvalidPermissionType
+		}
+	}
+	return nil
+}
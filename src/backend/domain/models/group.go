@@ -0,0 +1,85 @@
+// Package models defines the core domain models for the document management platform
+package models
+
+import (
+	"errors" // standard library
+	"time"   // standard library
+)
+
+// Error constants for group validation
+var (
+	ErrGroupNameEmpty     = errors.New("group name cannot be empty")
+	ErrGroupTenantIDEmpty = errors.New("group tenant ID cannot be empty")
+	ErrGroupIDEmpty       = errors.New("group ID cannot be empty")
+	ErrGroupUserIDEmpty   = errors.New("group member user ID cannot be empty")
+)
+
+// Group is a named set of users within a tenant. Permissions granted to a group
+// apply to every member, so access can be managed once rather than per user.
+type Group struct {
+	ID          string    `json:"id"`
+	TenantID    string    `json:"tenant_id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	CreatedBy   string    `json:"created_by"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// NewGroup creates a new Group with the given name, description, and tenant ID
+func NewGroup(name, description, tenantID, createdBy string) *Group {
+	now := time.Now()
+	return &Group{
+		Name:        name,
+		Description: description,
+		TenantID:    tenantID,
+		CreatedBy:   createdBy,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+// Validate checks that the group has all required fields
+func (g *Group) Validate() error {
+	if g.Name == "" {
+		return ErrGroupNameEmpty
+	}
+	if g.TenantID == "" {
+		return ErrGroupTenantIDEmpty
+	}
+	return nil
+}
+
+// GroupMembership records that a user is a member of a group
+type GroupMembership struct {
+	GroupID   string    `json:"group_id"`
+	UserID    string    `json:"user_id"`
+	TenantID  string    `json:"tenant_id"`
+	AddedBy   string    `json:"added_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NewGroupMembership creates a new GroupMembership adding the user to the group
+func NewGroupMembership(groupID, userID, tenantID, addedBy string) *GroupMembership {
+	return &GroupMembership{
+		GroupID:   groupID,
+		UserID:    userID,
+		TenantID:  tenantID,
+		AddedBy:   addedBy,
+		CreatedAt: time.Now(),
+	}
+}
+
+// Validate checks that the membership has all required fields
+func (m *GroupMembership) Validate() error {
+	if m.GroupID == "" {
+		return ErrGroupIDEmpty
+	}
+	if m.UserID == "" {
+		return ErrGroupUserIDEmpty
+	}
+	if m.TenantID == "" {
+		return ErrGroupTenantIDEmpty
+	}
+	return nil
+}
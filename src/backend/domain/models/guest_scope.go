@@ -0,0 +1,102 @@
+// Package models defines the core domain models for the document management platform
+package models
+
+import (
+	"errors"  // standard library - For error handling in validation methods
+	"strings" // standard library - For email validation
+	"time"    // standard library - For timestamp fields
+)
+
+// GuestTokenMaxExpiry is the longest a guest token can stay valid
+const GuestTokenMaxExpiry = 30 * 24 * time.Hour
+
+// Error variables for guest scope validation
+var (
+	ErrGuestTenantIDEmpty       = errors.New("guest tenant ID cannot be empty")
+	ErrGuestEmailInvalid        = errors.New("guest email is invalid")
+	ErrGuestResourceTypeInvalid = errors.New("guest resource type must be document or folder")
+	ErrGuestResourceIDEmpty     = errors.New("guest resource ID cannot be empty")
+	ErrGuestPermissionsInvalid  = errors.New("guest permissions must be read-only")
+	ErrGuestExpiryInvalid       = errors.New("guest access must expire in the future and within 30 days")
+)
+
+// GuestScope describes what an external guest may access without a user account: a single
+// document or folder of a tenant, with a read-only permission set, until ExpiresAt.
+// A folder scope covers the documents directly in that folder.
+type GuestScope struct {
+	TenantID     string    `json:"tenant_id"`
+	GuestEmail   string    `json:"guest_email"`
+	ResourceType string    `json:"resource_type"`
+	ResourceID   string    `json:"resource_id"`
+	Permissions  []string  `json:"permissions"`
+	InvitedBy    string    `json:"invited_by"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+// NewGuestScope creates a read-only GuestScope for a document or folder
+func NewGuestScope(tenantID, guestEmail, resourceType, resourceID, invitedBy string, expiresAt time.Time) *GuestScope {
+	return &GuestScope{
+		TenantID:     tenantID,
+		GuestEmail:   strings.ToLower(strings.TrimSpace(guestEmail)),
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+		Permissions:  []string{PermissionTypeRead},
+		InvitedBy:    invitedBy,
+		ExpiresAt:    expiresAt,
+	}
+}
+
+// Validate checks that the guest scope is limited to a single resource, read-only and time-boxed
+func (g *GuestScope) Validate() error {
+	if g.TenantID == "" {
+		return ErrGuestTenantIDEmpty
+	}
+	if at := strings.Index(g.GuestEmail, "@"); at < 1 || at == len(g.GuestEmail)-1 {
+		return ErrGuestEmailInvalid
+	}
+	if g.ResourceType != ResourceTypeDocument && g.ResourceType != ResourceTypeFolder {
+		return ErrGuestResourceTypeInvalid
+	}
+	if g.ResourceID == "" {
+		return ErrGuestResourceIDEmpty
+	}
+	if len(g.Permissions) == 0 {
+		return ErrGuestPermissionsInvalid
+	}
+	for _, permission := range g.Permissions {
+		if permission != PermissionTypeRead {
+			return ErrGuestPermissionsInvalid
+		}
+	}
+	now := time.Now()
+	if !g.ExpiresAt.After(now) || g.ExpiresAt.Sub(now) > GuestTokenMaxExpiry {
+		return ErrGuestExpiryInvalid
+	}
+	return nil
+}
+
+// HasPermission checks if the guest scope grants the permission
+func (g *GuestScope) HasPermission(permission string) bool {
+	for _, p := range g.Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsDocument checks if the guest may access a document with the permission.
+// The document must be the shared document or lie directly in the shared folder.
+func (g *GuestScope) AllowsDocument(document *Document, permission string) bool {
+	if document == nil || document.TenantID != g.TenantID || !g.HasPermission(permission) {
+		return false
+	}
+
+	switch g.ResourceType {
+	case ResourceTypeDocument:
+		return document.ID == g.ResourceID
+	case ResourceTypeFolder:
+		return document.FolderID == g.ResourceID
+	}
+	return false
+}
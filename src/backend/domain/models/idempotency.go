@@ -0,0 +1,86 @@
+// Package models defines the core domain models for the document management platform
+package models
+
+import (
+	"crypto/sha256" // standard library - For fingerprinting requests
+	"encoding/hex"  // standard library - For encoding request fingerprints
+	"errors"        // standard library - For idempotency key validation
+	"net/http"      // standard library - For response headers
+	"strings"       // standard library - For building request fingerprints
+	"time"          // standard library - For timestamp fields
+)
+
+// Idempotency record states
+const (
+	IdempotencyStateInProgress = "in_progress"
+	IdempotencyStateCompleted  = "completed"
+)
+
+// MaxIdempotencyKeyLength is the longest idempotency key clients may send
+const MaxIdempotencyKeyLength = 255
+
+// Error variables for idempotency key validation
+var (
+	ErrIdempotencyKeyEmpty   = errors.New("idempotency key cannot be empty")
+	ErrIdempotencyKeyTooLong = errors.New("idempotency key cannot be longer than 255 characters")
+)
+
+// IdempotencyRecord remembers a request made with an idempotency key and, once it completed, its
+// response, so that retries of the request get the original response instead of repeating it
+type IdempotencyRecord struct {
+	Key         string      `json:"key"`
+	TenantID    string      `json:"tenant_id"`
+	UserID      string      `json:"user_id"`
+	Fingerprint string      `json:"fingerprint"` // Identifies the request the key was first used for
+	State       string      `json:"state"`
+	StatusCode  int         `json:"status_code,omitempty"`
+	Header      http.Header `json:"header,omitempty"`
+	Body        []byte      `json:"body,omitempty"`
+	CreatedAt   time.Time   `json:"created_at"`
+	CompletedAt *time.Time  `json:"completed_at,omitempty"`
+}
+
+// NewIdempotencyRecord creates a new in-progress IdempotencyRecord for a request
+func NewIdempotencyRecord(key, tenantID, userID, fingerprint string) *IdempotencyRecord {
+	return &IdempotencyRecord{
+		Key:         key,
+		TenantID:    tenantID,
+		UserID:      userID,
+		Fingerprint: fingerprint,
+		State:       IdempotencyStateInProgress,
+		CreatedAt:   time.Now(),
+	}
+}
+
+// ValidateIdempotencyKey checks that a client-supplied idempotency key is usable
+func ValidateIdempotencyKey(key string) error {
+	if strings.TrimSpace(key) == "" {
+		return ErrIdempotencyKeyEmpty
+	}
+	if len(key) > MaxIdempotencyKeyLength {
+		return ErrIdempotencyKeyTooLong
+	}
+	return nil
+}
+
+// IdempotencyFingerprint identifies a request by its method, its URI and the SHA-256 digest of its
+// body. A key reused for a different request is a client error rather than a retry.
+func IdempotencyFingerprint(method, requestURI string, bodyDigest []byte) string {
+	sum := sha256.Sum256([]byte(strings.ToUpper(method) + " " + requestURI + " " + hex.EncodeToString(bodyDigest)))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsCompleted reports whether the request finished and its response can be replayed
+func (r *IdempotencyRecord) IsCompleted() bool {
+	return r.State == IdempotencyStateCompleted
+}
+
+// Complete stores the response of the request
+func (r *IdempotencyRecord) Complete(statusCode int, header http.Header, body []byte) {
+	now := time.Now()
+	r.State = IdempotencyStateCompleted
+	r.StatusCode = statusCode
+	r.Header = header
+	r.Body = body
+	r.CompletedAt = &now
+}
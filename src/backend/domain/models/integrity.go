@@ -0,0 +1,34 @@
+// Package models defines the core domain models for the document management platform
+package models
+
+import (
+	"errors" // standard library - For integrity violations
+	"time"   // standard library - For the time content was checked
+)
+
+// Error variables for content integrity violations
+var (
+	ErrChecksumMismatch = errors.New("content does not match its SHA-256 checksum")
+	ErrNoContentHash    = errors.New("document version has no SHA-256 hash to verify its content against")
+)
+
+// IntegrityCheck is the result of reading the stored content of a document version back and
+// hashing it again, which finds content corrupted in storage or by the client that uploaded it
+type IntegrityCheck struct {
+	DocumentID   string    // Document the version belongs to
+	VersionID    string    // Checked version
+	ExpectedHash string    // SHA-256 hash the content was uploaded with
+	ActualHash   string    // SHA-256 hash of the stored content
+	Intact       bool      // Whether the hashes match
+	CheckedAt    time.Time // When the content was read back
+}
+
+// AuditDetails returns the check as recorded in the audit log
+func (c *IntegrityCheck) AuditDetails() map[string]interface{} {
+	return map[string]interface{}{
+		"versionId":    c.VersionID,
+		"expectedHash": c.ExpectedHash,
+		"actualHash":   c.ActualHash,
+		"intact":       c.Intact,
+	}
+}
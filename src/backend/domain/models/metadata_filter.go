@@ -0,0 +1,202 @@
+// Package models defines the core domain models for the document management platform
+package models
+
+import (
+	"errors"  // standard library - For error handling in validation methods
+	"fmt"     // standard library - For filter validation messages
+	"strings" // standard library - For prefix and string comparisons
+	"time"    // standard library - For date comparisons
+)
+
+// Metadata filter operator constants define how a filter compares the value of a metadata key
+const (
+	MetadataFilterEq      = "eq"      // Value equals Value
+	MetadataFilterGt      = "gt"      // Value is greater than Value
+	MetadataFilterGte     = "gte"     // Value is greater than or equal to Value
+	MetadataFilterLt      = "lt"      // Value is less than Value
+	MetadataFilterLte     = "lte"     // Value is less than or equal to Value
+	MetadataFilterBetween = "between" // Value is within Values, bounds included
+	MetadataFilterExists  = "exists"  // The document has the key, whatever its value
+	MetadataFilterPrefix  = "prefix"  // Value starts with Value
+)
+
+// MaxMetadataFilters is the maximum number of metadata filters of a search
+const MaxMetadataFilters = 20
+
+// Error variables for metadata filter validation
+var (
+	ErrMetadataFilterKeyEmpty        = errors.New("metadata filter key cannot be empty")
+	ErrMetadataFilterInvalidOperator = errors.New("metadata filter operator must be eq, gt, gte, lt, lte, between, exists or prefix")
+	ErrMetadataFilterTooMany         = fmt.Errorf("at most %d metadata filters are allowed", MaxMetadataFilters)
+)
+
+// MetadataFilter restricts a search to the documents whose value of a metadata key satisfies an
+// operator. Range operators compare numbers and dates by value, so they apply to the keys defined
+// as number or date fields in the tenant's schema; the other operators apply to any key.
+type MetadataFilter struct {
+	Key      string   `json:"key"`
+	Operator string   `json:"op"`
+	Value    string   `json:"value,omitempty"`
+	Values   []string `json:"values,omitempty"` // Lower and upper bounds of between
+
+	// Type is the type of the key's field in the tenant's schema, string for keys without a field.
+	// It is set by MetadataSchema.ResolveFilters, which search indexes rely on to compare values.
+	Type string `json:"-"`
+}
+
+// Validate checks that the filter has a key, a supported operator and the values the operator needs
+func (f *MetadataFilter) Validate() error {
+	if strings.TrimSpace(f.Key) == "" {
+		return ErrMetadataFilterKeyEmpty
+	}
+
+	switch f.Operator {
+	case MetadataFilterExists:
+		return nil
+	case MetadataFilterBetween:
+		if len(f.Values) != 2 || f.Values[0] == "" || f.Values[1] == "" {
+			return fmt.Errorf("metadata filter between on %s needs a lower and an upper bound", f.Key)
+		}
+	case MetadataFilterEq, MetadataFilterGt, MetadataFilterGte, MetadataFilterLt, MetadataFilterLte, MetadataFilterPrefix:
+		if f.Value == "" {
+			return fmt.Errorf("metadata filter %s on %s needs a value", f.Operator, f.Key)
+		}
+	default:
+		return ErrMetadataFilterInvalidOperator
+	}
+	return nil
+}
+
+// IsRange returns whether the filter compares values by order
+func (f *MetadataFilter) IsRange() bool {
+	switch f.Operator {
+	case MetadataFilterGt, MetadataFilterGte, MetadataFilterLt, MetadataFilterLte, MetadataFilterBetween:
+		return true
+	}
+	return false
+}
+
+// Bounds returns the values the filter compares to: the two bounds of between, the value otherwise
+func (f *MetadataFilter) Bounds() []string {
+	if f.Operator == MetadataFilterBetween {
+		return f.Values
+	}
+	if f.Operator == MetadataFilterExists {
+		return nil
+	}
+	return []string{f.Value}
+}
+
+// Matches checks whether the value of the filter's key satisfies the filter; ok reports whether
+// the document has the key. Values that do not have the filter's type never match.
+func (f *MetadataFilter) Matches(value string, ok bool) bool {
+	if !ok {
+		return false
+	}
+
+	switch f.Operator {
+	case MetadataFilterExists:
+		return true
+	case MetadataFilterPrefix:
+		return strings.HasPrefix(value, f.Value)
+	case MetadataFilterBetween:
+		low, lowOK := compareMetadataValues(f.Type, value, f.Values[0])
+		high, highOK := compareMetadataValues(f.Type, value, f.Values[1])
+		return lowOK && highOK && low >= 0 && high <= 0
+	}
+
+	cmp, comparable := compareMetadataValues(f.Type, value, f.Value)
+	if !comparable {
+		return false
+	}
+	switch f.Operator {
+	case MetadataFilterEq:
+		return cmp == 0
+	case MetadataFilterGt:
+		return cmp > 0
+	case MetadataFilterGte:
+		return cmp >= 0
+	case MetadataFilterLt:
+		return cmp < 0
+	case MetadataFilterLte:
+		return cmp <= 0
+	}
+	return false
+}
+
+// compareMetadataValues compares two metadata values of a field type, returning -1, 0 or 1, and
+// false when either is not of the type
+func compareMetadataValues(fieldType, a, b string) (int, bool) {
+	switch fieldType {
+	case MetadataFieldTypeNumber:
+		x, errA := ParseMetadataNumber(a)
+		y, errB := ParseMetadataNumber(b)
+		if errA != nil || errB != nil {
+			return 0, false
+		}
+		switch {
+		case x < y:
+			return -1, true
+		case x > y:
+			return 1, true
+		}
+		return 0, true
+	case MetadataFieldTypeDate:
+		x, errA := ParseMetadataDate(a)
+		y, errB := ParseMetadataDate(b)
+		if errA != nil || errB != nil {
+			return 0, false
+		}
+		return compareTimes(x, y), true
+	default:
+		return strings.Compare(a, b), true
+	}
+}
+
+// compareTimes compares two instants, returning -1, 0 or 1
+func compareTimes(x, y time.Time) int {
+	switch {
+	case x.Before(y):
+		return -1
+	case x.After(y):
+		return 1
+	}
+	return 0
+}
+
+// ResolveFilters validates metadata filters against the schema and sets their Type. Range
+// operators need a number or date field and prefix a string or enum field or a key without a
+// field; the values compared to must have the field's type.
+func (s MetadataSchema) ResolveFilters(filters []MetadataFilter) error {
+	if len(filters) > MaxMetadataFilters {
+		return ErrMetadataFilterTooMany
+	}
+
+	for i := range filters {
+		filter := &filters[i]
+		if err := filter.Validate(); err != nil {
+			return err
+		}
+
+		filter.Type = MetadataFieldTypeString
+		field := s.Field(filter.Key)
+		if field != nil && (field.Type == MetadataFieldTypeNumber || field.Type == MetadataFieldTypeDate) {
+			filter.Type = field.Type
+		}
+
+		if filter.IsRange() && filter.Type == MetadataFieldTypeString {
+			return fmt.Errorf("metadata filter %s on %s needs a number or date field", filter.Operator, filter.Key)
+		}
+		if filter.Operator == MetadataFilterPrefix && filter.Type != MetadataFieldTypeString {
+			return fmt.Errorf("metadata filter prefix on %s needs a string field", filter.Key)
+		}
+		if filter.Type != MetadataFieldTypeString {
+			for _, bound := range filter.Bounds() {
+				if err := field.ValidateValue(bound); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+// Package models defines the core domain models for the document management platform
+package models
+
+import (
+	"errors"  // standard library - For error handling in validation methods
+	"sort"    // standard library - For adding new keys in a stable order
+	"strings" // standard library - For checking empty keys
+	"time"    // standard library - For the update timestamp
+)
+
+// Error variables for metadata patch validation
+var (
+	ErrMetadataPatchEmpty       = errors.New("metadata patch must set or remove at least one key")
+	ErrMetadataPatchKeyEmpty    = errors.New("metadata patch keys cannot be empty")
+	ErrMetadataPatchKeyConflict = errors.New("metadata patch cannot both set and remove a key")
+	ErrMetadataPatchKeyReserved = errors.New("metadata patch cannot change reserved keys such as document_number")
+)
+
+// MetadataPatch is a change applied to the metadata of many documents at once: the keys of Set are
+// added or overwritten with their values, and the keys of Remove are deleted. Keys of a document
+// the patch does not mention are left as they are.
+type MetadataPatch struct {
+	Set    map[string]string `json:"set"`
+	Remove []string          `json:"remove"`
+}
+
+// Validate checks that the patch changes at least one key, does not both set and remove a key, and
+// leaves reserved keys alone
+func (p *MetadataPatch) Validate() error {
+	if len(p.Set) == 0 && len(p.Remove) == 0 {
+		return ErrMetadataPatchEmpty
+	}
+	for key := range p.Set {
+		if strings.TrimSpace(key) == "" {
+			return ErrMetadataPatchKeyEmpty
+		}
+		if IsReservedMetadataKey(key) {
+			return ErrMetadataPatchKeyReserved
+		}
+	}
+	for _, key := range p.Remove {
+		if strings.TrimSpace(key) == "" {
+			return ErrMetadataPatchKeyEmpty
+		}
+		if IsReservedMetadataKey(key) {
+			return ErrMetadataPatchKeyReserved
+		}
+		if _, ok := p.Set[key]; ok {
+			return ErrMetadataPatchKeyConflict
+		}
+	}
+	return nil
+}
+
+// Apply returns the metadata of a document with the patch applied, as a map of values by key
+func (p *MetadataPatch) Apply(metadata []DocumentMetadata) map[string]string {
+	patched := make(map[string]string, len(metadata)+len(p.Set))
+	for _, m := range metadata {
+		patched[m.Key] = m.Value
+	}
+	for _, key := range p.Remove {
+		delete(patched, key)
+	}
+	for key, value := range p.Set {
+		patched[key] = value
+	}
+	return patched
+}
+
+// ApplyTo applies the patch to the metadata of a document in place, adding new keys in key order
+func (p *MetadataPatch) ApplyTo(document *Document) {
+	removed := make(map[string]bool, len(p.Remove))
+	for _, key := range p.Remove {
+		removed[key] = true
+	}
+
+	metadata := make([]DocumentMetadata, 0, len(document.Metadata)+len(p.Set))
+	existing := make(map[string]bool, len(document.Metadata))
+	for _, m := range document.Metadata {
+		if removed[m.Key] {
+			continue
+		}
+		if value, ok := p.Set[m.Key]; ok {
+			m.Update(value)
+		}
+		existing[m.Key] = true
+		metadata = append(metadata, m)
+	}
+
+	added := make([]string, 0, len(p.Set))
+	for key := range p.Set {
+		if !existing[key] {
+			added = append(added, key)
+		}
+	}
+	sort.Strings(added)
+	for _, key := range added {
+		metadata = append(metadata, NewDocumentMetadata(document.ID, key, p.Set[key]))
+	}
+
+	document.Metadata = metadata
+	document.UpdatedAt = time.Now()
+}
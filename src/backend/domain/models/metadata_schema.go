@@ -0,0 +1,183 @@
+// Package models defines the core domain models for the document management platform
+package models
+
+import (
+	"errors"  // standard library - For error handling in validation methods
+	"fmt"     // standard library - For metadata validation messages
+	"math"    // standard library - For rejecting non-finite numbers
+	"regexp"  // standard library - For field name validation
+	"sort"    // standard library - For listing missing required fields in a stable order
+	"strconv" // standard library - For number field validation
+	"strings" // standard library - For missing required field messages
+	"time"    // standard library - For timestamp fields and date field validation
+)
+
+// Metadata field type constants define the values a metadata field accepts
+const (
+	MetadataFieldTypeString = "string"
+	MetadataFieldTypeNumber = "number"
+	MetadataFieldTypeDate   = "date"
+	MetadataFieldTypeEnum   = "enum"
+)
+
+// MetadataDateLayouts are the layouts values of date metadata fields are accepted in: a date, or
+// a date and time with time zone
+var MetadataDateLayouts = []string{"2006-01-02", time.RFC3339}
+
+// metadataFieldNamePattern restricts field names to what can be used as a search field name
+var metadataFieldNamePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_-]{0,99}$`)
+
+// Error variables for metadata field validation
+var (
+	ErrMetadataFieldTenantIDEmpty    = errors.New("metadata field tenant ID cannot be empty")
+	ErrMetadataFieldInvalidName      = errors.New("metadata field name must start with a letter and contain only letters, digits, '_' and '-', at most 100 characters")
+	ErrMetadataFieldInvalidType      = errors.New("metadata field type must be string, number, date or enum")
+	ErrMetadataFieldValuesEmpty      = errors.New("enum metadata field must allow at least one value")
+	ErrMetadataFieldValuesNotAllowed = errors.New("only enum metadata fields have allowed values")
+	ErrMetadataFieldDuplicateValue   = errors.New("enum metadata field allows a value more than once")
+)
+
+// MetadataField defines a metadata key of a tenant's documents: the type its values must have and
+// whether documents must have it. Metadata keys without a field remain freeform strings.
+type MetadataField struct {
+	ID            string    `json:"id"`
+	TenantID      string    `json:"tenant_id"`
+	Name          string    `json:"name"`
+	Description   string    `json:"description"`
+	Type          string    `json:"type"`
+	Required      bool      `json:"required"`
+	AllowedValues []string  `json:"allowed_values"`
+	CreatedBy     string    `json:"created_by"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// NewMetadataField creates a new MetadataField
+func NewMetadataField(tenantID, name, fieldType string, required bool, allowedValues []string, createdBy string) *MetadataField {
+	now := time.Now()
+	return &MetadataField{
+		TenantID:      tenantID,
+		Name:          name,
+		Type:          fieldType,
+		Required:      required,
+		AllowedValues: allowedValues,
+		CreatedBy:     createdBy,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
+
+// Validate checks that the field has a usable name and a supported type
+func (f *MetadataField) Validate() error {
+	if f.TenantID == "" {
+		return ErrMetadataFieldTenantIDEmpty
+	}
+	if !metadataFieldNamePattern.MatchString(f.Name) {
+		return ErrMetadataFieldInvalidName
+	}
+
+	switch f.Type {
+	case MetadataFieldTypeString, MetadataFieldTypeNumber, MetadataFieldTypeDate:
+		if len(f.AllowedValues) > 0 {
+			return ErrMetadataFieldValuesNotAllowed
+		}
+	case MetadataFieldTypeEnum:
+		if len(f.AllowedValues) == 0 {
+			return ErrMetadataFieldValuesEmpty
+		}
+		seen := make(map[string]bool, len(f.AllowedValues))
+		for _, value := range f.AllowedValues {
+			if seen[value] {
+				return ErrMetadataFieldDuplicateValue
+			}
+			seen[value] = true
+		}
+	default:
+		return ErrMetadataFieldInvalidType
+	}
+	return nil
+}
+
+// ValidateValue checks that a metadata value has the type of the field
+func (f *MetadataField) ValidateValue(value string) error {
+	switch f.Type {
+	case MetadataFieldTypeNumber:
+		if _, err := ParseMetadataNumber(value); err != nil {
+			return fmt.Errorf("metadata %s must be a number", f.Name)
+		}
+	case MetadataFieldTypeDate:
+		if _, err := ParseMetadataDate(value); err != nil {
+			return fmt.Errorf("metadata %s must be a date such as 2024-01-31 or 2024-01-31T12:00:00Z", f.Name)
+		}
+	case MetadataFieldTypeEnum:
+		for _, allowed := range f.AllowedValues {
+			if value == allowed {
+				return nil
+			}
+		}
+		return fmt.Errorf("metadata %s must be one of %s", f.Name, strings.Join(f.AllowedValues, ", "))
+	}
+	return nil
+}
+
+// ParseMetadataNumber parses the value of a number metadata field, which must be finite
+func ParseMetadataNumber(value string) (float64, error) {
+	number, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return 0, err
+	}
+	if math.IsNaN(number) || math.IsInf(number, 0) {
+		return 0, errors.New("number must be finite")
+	}
+	return number, nil
+}
+
+// ParseMetadataDate parses the value of a date metadata field in one of MetadataDateLayouts
+func ParseMetadataDate(value string) (time.Time, error) {
+	var err error
+	for _, layout := range MetadataDateLayouts {
+		var parsed time.Time
+		if parsed, err = time.Parse(layout, strings.TrimSpace(value)); err == nil {
+			return parsed, nil
+		}
+	}
+	return time.Time{}, err
+}
+
+// MetadataSchema is the set of metadata fields defined by a tenant
+type MetadataSchema []*MetadataField
+
+// Field returns the field with the given name, nil if the schema does not define it
+func (s MetadataSchema) Field(name string) *MetadataField {
+	for _, field := range s {
+		if field.Name == name {
+			return field
+		}
+	}
+	return nil
+}
+
+// Validate checks the metadata of a document against the schema: every value of a defined field
+// must have the field's type and every required field must be present. Keys without a field are
+// accepted as they are.
+func (s MetadataSchema) Validate(metadata map[string]string) error {
+	var missing []string
+	for _, field := range s {
+		value, ok := metadata[field.Name]
+		if !ok || value == "" {
+			if field.Required {
+				missing = append(missing, field.Name)
+			}
+			continue
+		}
+		if err := field.ValidateValue(value); err != nil {
+			return err
+		}
+	}
+
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("metadata %s is required", strings.Join(missing, ", "))
+	}
+	return nil
+}
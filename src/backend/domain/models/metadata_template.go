@@ -0,0 +1,107 @@
+// Package models defines the core domain models for the document management platform
+package models
+
+import (
+	"errors"  // standard library - For error handling in validation methods
+	"sort"    // standard library - For listing missing fields in a stable order
+	"strings" // standard library - For trimming field names
+	"time"    // standard library - For timestamp fields
+)
+
+// Metadata template mode constants define what happens to uploads missing the template's fields
+const (
+	// MetadataTemplateModeEnforce rejects uploads missing any of the template's fields
+	MetadataTemplateModeEnforce = "enforce"
+	// MetadataTemplateModePrompt accepts such uploads; clients read the template to prompt for its
+	// fields before uploading
+	MetadataTemplateModePrompt = "prompt"
+)
+
+// Error variables for metadata template validation
+var (
+	ErrMetadataTemplateTenantIDEmpty  = errors.New("metadata template tenant ID cannot be empty")
+	ErrMetadataTemplateFolderIDEmpty  = errors.New("metadata template folder ID cannot be empty")
+	ErrMetadataTemplateNameEmpty      = errors.New("metadata template name cannot be empty")
+	ErrMetadataTemplateFieldsEmpty    = errors.New("metadata template must list at least one field")
+	ErrMetadataTemplateFieldEmpty     = errors.New("metadata template field names cannot be empty")
+	ErrMetadataTemplateDuplicateField = errors.New("metadata template lists a field more than once")
+	ErrMetadataTemplateInvalidMode    = errors.New("metadata template mode must be enforce or prompt")
+)
+
+// MetadataTemplate lists the metadata keys every document uploaded to a folder must carry, such as
+// invoice_number and vendor for an Invoices folder. A folder has at most one template.
+type MetadataTemplate struct {
+	ID          string    `json:"id"`
+	TenantID    string    `json:"tenant_id"`
+	FolderID    string    `json:"folder_id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Fields      []string  `json:"fields"`
+	Mode        string    `json:"mode"`
+	CreatedBy   string    `json:"created_by"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// NewMetadataTemplate creates a new MetadataTemplate enforced at upload
+func NewMetadataTemplate(tenantID, folderID, name string, fields []string, createdBy string) *MetadataTemplate {
+	now := time.Now()
+	return &MetadataTemplate{
+		TenantID:  tenantID,
+		FolderID:  folderID,
+		Name:      name,
+		Fields:    fields,
+		Mode:      MetadataTemplateModeEnforce,
+		CreatedBy: createdBy,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// Validate checks that the template belongs to a folder and lists distinct, non-empty fields
+func (t *MetadataTemplate) Validate() error {
+	if t.TenantID == "" {
+		return ErrMetadataTemplateTenantIDEmpty
+	}
+	if t.FolderID == "" {
+		return ErrMetadataTemplateFolderIDEmpty
+	}
+	if strings.TrimSpace(t.Name) == "" {
+		return ErrMetadataTemplateNameEmpty
+	}
+	if t.Mode != MetadataTemplateModeEnforce && t.Mode != MetadataTemplateModePrompt {
+		return ErrMetadataTemplateInvalidMode
+	}
+	if len(t.Fields) == 0 {
+		return ErrMetadataTemplateFieldsEmpty
+	}
+
+	seen := make(map[string]bool, len(t.Fields))
+	for _, field := range t.Fields {
+		if strings.TrimSpace(field) == "" {
+			return ErrMetadataTemplateFieldEmpty
+		}
+		if seen[field] {
+			return ErrMetadataTemplateDuplicateField
+		}
+		seen[field] = true
+	}
+	return nil
+}
+
+// IsEnforced returns whether uploads missing the template's fields are rejected
+func (t *MetadataTemplate) IsEnforced() bool {
+	return t.Mode == MetadataTemplateModeEnforce
+}
+
+// MissingFields returns the fields of the template metadata has no value for, sorted by name
+func (t *MetadataTemplate) MissingFields(metadata map[string]string) []string {
+	var missing []string
+	for _, field := range t.Fields {
+		if strings.TrimSpace(metadata[field]) == "" {
+			missing = append(missing, field)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
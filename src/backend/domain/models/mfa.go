@@ -0,0 +1,245 @@
+// Package models defines the core domain models for the document management platform
+package models
+
+import (
+	"crypto/hmac"     // standard library - For computing TOTP codes
+	"crypto/rand"     // standard library - For generating secrets and backup codes
+	"crypto/sha1"     // standard library - For the RFC 6238 default TOTP hash
+	"crypto/sha256"   // standard library - For hashing backup codes at rest
+	"crypto/subtle"   // standard library - For constant time code comparison
+	"encoding/base32" // standard library - For encoding TOTP secrets
+	"encoding/binary" // standard library - For encoding TOTP counters
+	"encoding/hex"    // standard library - For encoding backup codes and hashes
+	"errors"          // standard library - For error handling in validation methods
+	"fmt"             // standard library - For formatting TOTP codes
+	"net/url"         // standard library - For building provisioning URIs
+	"strings"         // standard library - For code normalization
+	"time"            // standard library - For timestamp fields and TOTP time steps
+)
+
+// TOTP parameters. These are the RFC 6238 defaults that authenticator apps assume.
+const (
+	TOTPDigits = 6
+	TOTPPeriod = 30 * time.Second
+
+	// totpSkew is the number of time steps before and after the current one that are accepted,
+	// to tolerate clock drift between the server and the user's device
+	totpSkew = 1
+)
+
+// MFASecretLength is the length in bytes of generated TOTP secrets
+const MFASecretLength = 20
+
+// MFABackupCodeCount is the number of single-use backup codes generated on enrollment
+const MFABackupCodeCount = 10
+
+// MFAMaxFailedAttempts is the number of consecutive invalid codes after which the partial-auth
+// token is revoked and the user has to sign in with their password again
+const MFAMaxFailedAttempts = 5
+
+// TenantSettingMFARequired is the tenant setting that, when "true", requires every user of the
+// tenant to sign in with a second factor
+const TenantSettingMFARequired = "mfa_required"
+
+// Error variables for MFA enrollment validation
+var (
+	ErrMFAUserIDEmpty   = errors.New("MFA enrollment user ID cannot be empty")
+	ErrMFATenantIDEmpty = errors.New("MFA enrollment tenant ID cannot be empty")
+	ErrMFASecretInvalid = errors.New("MFA enrollment secret is invalid")
+)
+
+// MFAEnrollment holds a user's TOTP second factor. An enrollment is pending until the user
+// proves they have configured their authenticator app by verifying a first code.
+// Backup codes are single use and only a hash of each is stored.
+type MFAEnrollment struct {
+	ID               string     `json:"id"`
+	UserID           string     `json:"user_id"`
+	TenantID         string     `json:"tenant_id"`
+	Secret           string     `json:"-"`
+	BackupCodeHashes []string   `json:"-"`
+	LastUsedStep     int64      `json:"-"`
+	FailedAttempts   int        `json:"-"`
+	CreatedAt        time.Time  `json:"created_at"`
+	EnabledAt        *time.Time `json:"enabled_at"`
+}
+
+// NewMFAEnrollment creates a pending MFAEnrollment with a new TOTP secret and returns it together
+// with the plaintext backup codes, which are only available at enrollment time
+func NewMFAEnrollment(userID, tenantID string) (*MFAEnrollment, []string, error) {
+	secret := make([]byte, MFASecretLength)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, nil, err
+	}
+
+	enrollment := &MFAEnrollment{
+		UserID:    userID,
+		TenantID:  tenantID,
+		Secret:    base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(secret),
+		CreatedAt: time.Now(),
+	}
+
+	codes, err := enrollment.RegenerateBackupCodes()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return enrollment, codes, nil
+}
+
+// Validate checks that the MFA enrollment has all required fields
+func (e *MFAEnrollment) Validate() error {
+	if e.UserID == "" {
+		return ErrMFAUserIDEmpty
+	}
+	if e.TenantID == "" {
+		return ErrMFATenantIDEmpty
+	}
+	if _, err := decodeTOTPSecret(e.Secret); err != nil {
+		return ErrMFASecretInvalid
+	}
+	return nil
+}
+
+// IsEnabled checks if the enrollment has been confirmed and is required at sign-in
+func (e *MFAEnrollment) IsEnabled() bool {
+	return e.EnabledAt != nil
+}
+
+// Enable confirms a pending enrollment
+func (e *MFAEnrollment) Enable() {
+	now := time.Now()
+	e.EnabledAt = &now
+}
+
+// VerifyTOTP checks a TOTP code against the secret at the given time. A code is accepted once:
+// codes of the time step of the last accepted code, or of an earlier one, are rejected.
+func (e *MFAEnrollment) VerifyTOTP(code string, now time.Time) bool {
+	code = normalizeMFACode(code)
+	if len(code) != TOTPDigits {
+		return false
+	}
+
+	current := now.Unix() / int64(TOTPPeriod/time.Second)
+	for step := current - totpSkew; step <= current+totpSkew; step++ {
+		if step <= e.LastUsedStep {
+			continue
+		}
+		expected, err := totpCode(e.Secret, step)
+		if err != nil {
+			return false
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			e.LastUsedStep = step
+			return true
+		}
+	}
+	return false
+}
+
+// UseBackupCode checks a backup code and, if it is valid, removes it so it cannot be used again
+func (e *MFAEnrollment) UseBackupCode(code string) bool {
+	hash := hashBackupCode(code)
+	for i, stored := range e.BackupCodeHashes {
+		if subtle.ConstantTimeCompare([]byte(stored), []byte(hash)) == 1 {
+			e.BackupCodeHashes = append(e.BackupCodeHashes[:i:i], e.BackupCodeHashes[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// RegenerateBackupCodes replaces the backup codes and returns the new plaintext codes
+func (e *MFAEnrollment) RegenerateBackupCodes() ([]string, error) {
+	codes := make([]string, MFABackupCodeCount)
+	hashes := make([]string, MFABackupCodeCount)
+	for i := range codes {
+		b := make([]byte, 5)
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+		code := hex.EncodeToString(b)
+		codes[i] = code[:5] + "-" + code[5:]
+		hashes[i] = hashBackupCode(code)
+	}
+
+	e.BackupCodeHashes = hashes
+	return codes, nil
+}
+
+// RecordFailedAttempt counts an invalid code and reports whether the limit has been reached,
+// in which case the counter starts over
+func (e *MFAEnrollment) RecordFailedAttempt() bool {
+	e.FailedAttempts++
+	if e.FailedAttempts >= MFAMaxFailedAttempts {
+		e.FailedAttempts = 0
+		return true
+	}
+	return false
+}
+
+// ProvisioningURI returns the otpauth:// URI authenticator apps scan, usually as a QR code
+func (e *MFAEnrollment) ProvisioningURI(issuer, accountName string) string {
+	params := url.Values{}
+	params.Set("secret", e.Secret)
+	params.Set("issuer", issuer)
+	params.Set("algorithm", "SHA1")
+	params.Set("digits", fmt.Sprint(TOTPDigits))
+	params.Set("period", fmt.Sprint(int(TOTPPeriod/time.Second)))
+
+	label := url.PathEscape(issuer + ":" + accountName)
+	return "otpauth://totp/" + label + "?" + params.Encode()
+}
+
+// GenerateTOTP returns the TOTP code of a base32 secret at the given time
+func GenerateTOTP(secret string, at time.Time) (string, error) {
+	return totpCode(secret, at.Unix()/int64(TOTPPeriod/time.Second))
+}
+
+// totpCode computes the RFC 6238 code of a base32 secret for a time step
+func totpCode(secret string, step int64) (string, error) {
+	key, err := decodeTOTPSecret(secret)
+	if err != nil {
+		return "", err
+	}
+
+	counter := make([]byte, 8)
+	binary.BigEndian.PutUint64(counter, uint64(step))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter)
+	sum := mac.Sum(nil)
+
+	// Dynamic truncation as defined by RFC 4226
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < TOTPDigits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", TOTPDigits, value%mod), nil
+}
+
+// decodeTOTPSecret decodes a base32 secret, with or without padding
+func decodeTOTPSecret(secret string) ([]byte, error) {
+	secret = strings.TrimRight(strings.ToUpper(secret), "=")
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+	if err != nil {
+		return nil, err
+	}
+	if len(key) == 0 {
+		return nil, ErrMFASecretInvalid
+	}
+	return key, nil
+}
+
+// hashBackupCode returns the SHA-256 hash of a normalized backup code
+func hashBackupCode(code string) string {
+	sum := sha256.Sum256([]byte(normalizeMFACode(code)))
+	return hex.EncodeToString(sum[:])
+}
+
+// normalizeMFACode removes the separators users may type and lowercases the code
+func normalizeMFACode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
@@ -0,0 +1,150 @@
+// Package models defines the core domain models for the document management platform
+package models
+
+import (
+	"net"     // standard library - For parsing allowed networks and client addresses
+	"strings" // standard library - For splitting setting lists
+)
+
+// Tenant settings restricting the networks a tenant's users can reach the platform from
+const (
+	// TenantSettingIPAllowlist is the tenant setting listing, separated by commas, the networks in
+	// CIDR notation or single addresses requests must come from. Requests may come from anywhere
+	// when it is empty.
+	TenantSettingIPAllowlist = "ip_allowlist"
+
+	// TenantSettingBlockedCountries is the tenant setting listing, separated by commas, the ISO 3166-1
+	// alpha-2 codes of the countries requests are refused from
+	TenantSettingBlockedCountries = "blocked_countries"
+)
+
+// Reasons a request is refused by a tenant's network policy, recorded in the audit log
+const (
+	NetworkDenialIPNotAllowed       = "ip_not_allowed"
+	NetworkDenialCountryBlocked     = "country_blocked"
+	NetworkDenialCountryUnavailable = "country_unavailable"
+)
+
+// NetworkPolicy restricts the networks a tenant's users can reach the platform from
+type NetworkPolicy struct {
+	AllowedNetworks  []*net.IPNet // Networks requests must come from, any network when empty
+	BlockedCountries []string     // Upper case country codes requests are refused from
+}
+
+// NetworkPolicy returns the tenant's network policy
+func (t *Tenant) NetworkPolicy() NetworkPolicy {
+	return NewNetworkPolicy(t.GetSetting(TenantSettingIPAllowlist), t.GetSetting(TenantSettingBlockedCountries))
+}
+
+// NewNetworkPolicy creates a network policy from the values of the allowlist and blocked countries
+// settings. Invalid entries, which settings validation keeps out, are skipped.
+func NewNetworkPolicy(allowlist, blockedCountries string) NetworkPolicy {
+	var policy NetworkPolicy
+	for _, entry := range splitSettingList(allowlist) {
+		if network, err := parseNetwork(entry); err == nil {
+			policy.AllowedNetworks = append(policy.AllowedNetworks, network)
+		}
+	}
+	for _, entry := range splitSettingList(blockedCountries) {
+		if isCountryCode(entry) {
+			policy.BlockedCountries = append(policy.BlockedCountries, strings.ToUpper(entry))
+		}
+	}
+	return policy
+}
+
+// IsEmpty checks if the policy lets requests come from anywhere
+func (p NetworkPolicy) IsEmpty() bool {
+	return len(p.AllowedNetworks) == 0 && len(p.BlockedCountries) == 0
+}
+
+// AllowsIP checks if the address is in one of the allowed networks, or if the policy has none
+func (p NetworkPolicy) AllowsIP(ip net.IP) bool {
+	if len(p.AllowedNetworks) == 0 {
+		return true
+	}
+	for _, network := range p.AllowedNetworks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// BlocksCountry checks if requests from the country with the ISO 3166-1 alpha-2 code are refused
+func (p NetworkPolicy) BlocksCountry(code string) bool {
+	for _, blocked := range p.BlockedCountries {
+		if strings.EqualFold(blocked, code) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsNetworkList checks if a value is a comma separated list of networks in CIDR notation or single addresses
+func IsNetworkList(value string) bool {
+	entries := splitSettingList(value)
+	if len(entries) == 0 {
+		return false
+	}
+	for _, entry := range entries {
+		if _, err := parseNetwork(entry); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// IsCountryCodeList checks if a value is a comma separated list of ISO 3166-1 alpha-2 country codes
+func IsCountryCodeList(value string) bool {
+	entries := splitSettingList(value)
+	if len(entries) == 0 {
+		return false
+	}
+	for _, entry := range entries {
+		if !isCountryCode(entry) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseNetwork parses a network in CIDR notation, or a single address as the network of just that address
+func parseNetwork(entry string) (*net.IPNet, error) {
+	if !strings.Contains(entry, "/") {
+		ip := net.ParseIP(entry)
+		if ip == nil {
+			return nil, &net.ParseError{Type: "IP address", Text: entry}
+		}
+		if ip4 := ip.To4(); ip4 != nil {
+			return &net.IPNet{IP: ip4, Mask: net.CIDRMask(32, 32)}, nil
+		}
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}, nil
+	}
+	_, network, err := net.ParseCIDR(entry)
+	return network, err
+}
+
+// isCountryCode checks if a value is made of two ASCII letters, like ISO 3166-1 alpha-2 codes
+func isCountryCode(value string) bool {
+	if len(value) != 2 {
+		return false
+	}
+	for _, r := range value {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+// splitSettingList splits a comma separated setting into its trimmed, non-empty entries
+func splitSettingList(value string) []string {
+	var entries []string
+	for _, entry := range strings.Split(value, ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
@@ -0,0 +1,143 @@
+// Package models defines the core domain models for the document management platform
+package models
+
+import (
+	"errors"  // standard library - For error handling in validation methods
+	"fmt"     // standard library - For zero-padding sequence values
+	"strings" // standard library - For expanding the tokens of number formats
+	"time"    // standard library - For timestamp fields and date tokens
+)
+
+// DocumentNumberMetadataKey is the metadata key documents carry the number assigned to them at
+// upload under. It is reserved: uploads and metadata updates cannot set or remove it.
+const DocumentNumberMetadataKey = "document_number"
+
+// Numbering sequence mode constants define whether uploads that fail leave gaps in the numbers
+const (
+	// NumberingModeGapFree assigns numbers in the upload transaction, so a failed upload gives its
+	// number back; uploads numbered from the same sequence wait on each other
+	NumberingModeGapFree = "gap-free"
+	// NumberingModeGapTolerant assigns numbers before the upload is persisted, so concurrent uploads
+	// do not wait on each other but failed uploads leave gaps
+	NumberingModeGapTolerant = "gap-tolerant"
+)
+
+// Number format tokens expanded by NumberingSequence.FormatNumber
+const (
+	NumberTokenSequence  = "{SEQ}"  // The sequence value, zero-padded to the sequence's padding
+	NumberTokenYear      = "{YYYY}" // The four-digit year the number is assigned in
+	NumberTokenYearShort = "{YY}"   // The two-digit year the number is assigned in
+	NumberTokenMonth     = "{MM}"   // The two-digit month the number is assigned in
+)
+
+// Limits of numbering sequence formats
+const (
+	MaxNumberFormatLength = 64
+	MaxNumberPadding      = 18
+	DefaultNumberPadding  = 5
+)
+
+// Error variables for numbering sequence validation
+var (
+	ErrNumberingSequenceTenantIDEmpty  = errors.New("numbering sequence tenant ID cannot be empty")
+	ErrNumberingSequenceNameEmpty      = errors.New("numbering sequence name cannot be empty")
+	ErrNumberingSequenceFormatInvalid  = errors.New("numbering sequence format must contain {SEQ} exactly once and be at most 64 characters")
+	ErrNumberingSequencePaddingInvalid = errors.New("numbering sequence padding must be between 1 and 18 digits")
+	ErrNumberingSequenceInvalidMode    = errors.New("numbering sequence mode must be gap-free or gap-tolerant")
+)
+
+// NumberingSequence numbers the documents uploaded to a folder, or to any folder of the tenant
+// without a sequence of its own when FolderID is empty. Numbers follow the sequence's format, such
+// as INV-{YYYY}-{SEQ} for INV-2024-00042; sequences reset yearly start again at 1 every January.
+type NumberingSequence struct {
+	ID          string    `json:"id"`
+	TenantID    string    `json:"tenant_id"`
+	FolderID    string    `json:"folder_id"` // Folder numbered by the sequence, empty for the tenant's default
+	Name        string    `json:"name"`
+	Format      string    `json:"format"`
+	Padding     int       `json:"padding"`
+	Mode        string    `json:"mode"`
+	ResetYearly bool      `json:"reset_yearly"`
+	LastValue   int64     `json:"last_value"`  // Value of the last number assigned, 0 before the first
+	LastPeriod  string    `json:"last_period"` // Year of the last number assigned by a sequence reset yearly
+	CreatedBy   string    `json:"created_by"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// NewNumberingSequence creates a new gap-free NumberingSequence with the default padding
+func NewNumberingSequence(tenantID, folderID, name, format string, createdBy string) *NumberingSequence {
+	now := time.Now()
+	return &NumberingSequence{
+		TenantID:  tenantID,
+		FolderID:  folderID,
+		Name:      name,
+		Format:    format,
+		Padding:   DefaultNumberPadding,
+		Mode:      NumberingModeGapFree,
+		CreatedBy: createdBy,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// Validate checks that the sequence has a name, a valid mode and a format containing the sequence value
+func (s *NumberingSequence) Validate() error {
+	if s.TenantID == "" {
+		return ErrNumberingSequenceTenantIDEmpty
+	}
+	if strings.TrimSpace(s.Name) == "" {
+		return ErrNumberingSequenceNameEmpty
+	}
+	if len(s.Format) > MaxNumberFormatLength || strings.Count(s.Format, NumberTokenSequence) != 1 {
+		return ErrNumberingSequenceFormatInvalid
+	}
+	if s.Padding < 1 || s.Padding > MaxNumberPadding {
+		return ErrNumberingSequencePaddingInvalid
+	}
+	if s.Mode != NumberingModeGapFree && s.Mode != NumberingModeGapTolerant {
+		return ErrNumberingSequenceInvalidMode
+	}
+	return nil
+}
+
+// IsGapFree returns whether failed uploads give their number back
+func (s *NumberingSequence) IsGapFree() bool {
+	return s.Mode == NumberingModeGapFree
+}
+
+// Next advances the sequence and returns the number assigned at now, restarting the values of a
+// sequence reset yearly when the year changed since its last number
+func (s *NumberingSequence) Next(now time.Time) string {
+	now = now.UTC()
+
+	period := ""
+	if s.ResetYearly {
+		period = now.Format("2006")
+	}
+	if period != s.LastPeriod {
+		s.LastValue = 0
+		s.LastPeriod = period
+	}
+
+	s.LastValue++
+	s.UpdatedAt = now
+	return s.FormatNumber(s.LastValue, now)
+}
+
+// FormatNumber formats a value of the sequence assigned at now, expanding the tokens of its format
+func (s *NumberingSequence) FormatNumber(value int64, now time.Time) string {
+	now = now.UTC()
+	replacer := strings.NewReplacer(
+		NumberTokenSequence, fmt.Sprintf("%0*d", s.Padding, value),
+		NumberTokenYear, now.Format("2006"),
+		NumberTokenYearShort, now.Format("06"),
+		NumberTokenMonth, now.Format("01"),
+	)
+	return replacer.Replace(s.Format)
+}
+
+// IsReservedMetadataKey returns whether a metadata key is maintained by the platform rather than users
+func IsReservedMetadataKey(key string) bool {
+	return key == DocumentNumberMetadataKey || IsPIIMetadataKey(key)
+}
@@ -0,0 +1,137 @@
+// Package models defines the core domain models for the document management platform
+package models
+
+import (
+	"encoding/json" // standard library - For storing the event payload
+	"errors"        // standard library - For error handling in validation methods
+	"time"          // standard library - For scheduling and timestamp fields
+)
+
+// Outbox message status constants
+const (
+	OutboxStatusPending = "pending"
+	OutboxStatusSent    = "sent"
+)
+
+// Outbox relay retry policy
+const (
+	// OutboxInitialBackoff is the delay before the first retry of a failed publish
+	OutboxInitialBackoff = 5 * time.Second
+	// OutboxMaxBackoff caps the delay between publish retries
+	OutboxMaxBackoff = 10 * time.Minute
+)
+
+// Error variables for outbox message validation
+var (
+	ErrOutboxEventIDEmpty   = errors.New("outbox event ID cannot be empty")
+	ErrOutboxEventTypeEmpty = errors.New("outbox event type cannot be empty")
+	ErrOutboxTenantIDEmpty  = errors.New("outbox tenant ID cannot be empty")
+	ErrOutboxPayloadEmpty   = errors.New("outbox payload cannot be empty")
+)
+
+// OutboxMessage is an event waiting to be published to the messaging system. It is written in
+// the same database transaction as the domain change that raised the event and is relayed by
+// the worker, guaranteeing at-least-once delivery even if the publisher is unavailable.
+type OutboxMessage struct {
+	ID            string          `json:"id"`
+	EventID       string          `json:"event_id"`
+	EventType     string          `json:"event_type"`
+	TenantID      string          `json:"tenant_id"`
+	Payload       json.RawMessage `json:"payload"`
+	Status        string          `json:"status"`
+	Attempts      int             `json:"attempts"`
+	LastError     string          `json:"last_error"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+	NextAttemptAt time.Time       `json:"next_attempt_at"`
+	CreatedAt     time.Time       `json:"created_at"`
+	SentAt        *time.Time      `json:"sent_at"`
+	RequestID     string          `json:"request_id"`
+}
+
+// NewOutboxMessage creates a pending outbox message for the given event
+func NewOutboxMessage(event *Event) (*OutboxMessage, error) {
+	if event == nil {
+		return nil, errors.New("event cannot be nil")
+	}
+
+	now := time.Now().UTC()
+	message := &OutboxMessage{
+		EventID:       event.ID,
+		EventType:     event.Type,
+		TenantID:      event.TenantID,
+		Payload:       event.Payload,
+		RequestID:     event.RequestID,
+		Status:        OutboxStatusPending,
+		OccurredAt:    event.OccurredAt,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}
+
+	if err := message.Validate(); err != nil {
+		return nil, err
+	}
+
+	return message, nil
+}
+
+// Validate validates that the outbox message has all required fields
+func (m *OutboxMessage) Validate() error {
+	if m.EventID == "" {
+		return ErrOutboxEventIDEmpty
+	}
+	if m.EventType == "" {
+		return ErrOutboxEventTypeEmpty
+	}
+	if m.TenantID == "" {
+		return ErrOutboxTenantIDEmpty
+	}
+	if len(m.Payload) == 0 {
+		return ErrOutboxPayloadEmpty
+	}
+	return nil
+}
+
+// ToEvent rebuilds the domain event carried by the outbox message
+func (m *OutboxMessage) ToEvent() *Event {
+	return &Event{
+		ID:         m.EventID,
+		Type:       m.EventType,
+		TenantID:   m.TenantID,
+		Payload:    m.Payload,
+		OccurredAt: m.OccurredAt,
+		CreatedAt:  m.CreatedAt,
+		RequestID:  m.RequestID,
+	}
+}
+
+// MarkSent marks the message as published
+func (m *OutboxMessage) MarkSent() {
+	now := time.Now().UTC()
+	m.Status = OutboxStatusSent
+	m.SentAt = &now
+	m.LastError = ""
+}
+
+// MarkFailed records a failed publish attempt and schedules the next one with exponential backoff.
+// The message stays pending; outbox messages are retried until they are published.
+func (m *OutboxMessage) MarkFailed(errorMessage string) {
+	m.Attempts++
+	m.LastError = errorMessage
+	m.NextAttemptAt = time.Now().UTC().Add(OutboxBackoff(m.Attempts))
+}
+
+// OutboxBackoff returns the delay before the next publish attempt after the given number of failures
+func OutboxBackoff(attempts int) time.Duration {
+	if attempts < 1 {
+		return OutboxInitialBackoff
+	}
+
+	backoff := OutboxInitialBackoff
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= OutboxMaxBackoff {
+			return OutboxMaxBackoff
+		}
+	}
+	return backoff
+}
@@ -0,0 +1,186 @@
+// Package models defines the core domain models for the document management platform
+package models
+
+import (
+	"crypto/rand" // standard library - For generating temporary passwords
+	"errors"      // standard library - For password policy violations
+	"strconv"     // standard library - For parsing policy settings
+	"strings"     // standard library - For describing unmet requirements
+	"time"        // standard library - For password expiry and lockout windows
+	"unicode"     // standard library - For character class checks
+)
+
+// Tenant settings configuring the password policy and the account lockout. Settings that are
+// not set, or not valid, fall back to the default policy.
+const (
+	TenantSettingPasswordMinLength        = "password_min_length"
+	TenantSettingPasswordRequireUppercase = "password_require_uppercase"
+	TenantSettingPasswordRequireLowercase = "password_require_lowercase"
+	TenantSettingPasswordRequireDigit     = "password_require_digit"
+	TenantSettingPasswordRequireSymbol    = "password_require_symbol"
+	TenantSettingPasswordHistory          = "password_history"
+	TenantSettingPasswordMaxAgeDays       = "password_max_age_days"
+	TenantSettingLockoutThreshold         = "lockout_threshold"
+	TenantSettingLockoutWindowMinutes     = "lockout_window_minutes"
+	TenantSettingLockoutDurationMinutes   = "lockout_duration_minutes"
+)
+
+// Password policy bounds. Tenants can make the policy stricter than the minimum length, and
+// remember at most MaxPasswordHistory previous passwords.
+const (
+	MinPasswordLength  = 8
+	MaxPasswordHistory = 24
+)
+
+// temporaryPasswordLength is the minimum length of generated temporary passwords
+const temporaryPasswordLength = 16
+
+// temporaryPasswordAlphabet holds 64 characters, so that random bytes map to them without bias.
+// Characters that are easily confused, like I, l, O and 0, are left out.
+const temporaryPasswordAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnpqrstuvwxyz23456789!@#$%*-+"
+
+// ErrPasswordReused is returned when a new password matches the current or a recent password
+var ErrPasswordReused = errors.New("password must not match a recently used password")
+
+// PasswordPolicy holds a tenant's password requirements and its brute-force protection.
+// A zero HistorySize disables the reuse check, a zero MaxAge disables expiry and a zero
+// LockoutThreshold disables the account lockout.
+type PasswordPolicy struct {
+	MinLength        int
+	RequireUppercase bool
+	RequireLowercase bool
+	RequireDigit     bool
+	RequireSymbol    bool
+	HistorySize      int
+	MaxAge           time.Duration
+	LockoutThreshold int
+	LockoutWindow    time.Duration
+	LockoutDuration  time.Duration
+}
+
+// DefaultPasswordPolicy returns the policy of tenants that do not configure their own: passwords of at
+// least 8 characters without expiry, and a 15 minute lockout after 5 failed sign-ins within 15 minutes
+func DefaultPasswordPolicy() PasswordPolicy {
+	return PasswordPolicy{
+		MinLength:        MinPasswordLength,
+		LockoutThreshold: 5,
+		LockoutWindow:    15 * time.Minute,
+		LockoutDuration:  15 * time.Minute,
+	}
+}
+
+// PasswordPolicy returns the tenant's password policy, built from its settings over the default policy
+func (t *Tenant) PasswordPolicy() PasswordPolicy {
+	policy := DefaultPasswordPolicy()
+
+	if n, ok := t.intSetting(TenantSettingPasswordMinLength); ok && n >= MinPasswordLength {
+		policy.MinLength = n
+	}
+	policy.RequireUppercase = t.GetSetting(TenantSettingPasswordRequireUppercase) == "true"
+	policy.RequireLowercase = t.GetSetting(TenantSettingPasswordRequireLowercase) == "true"
+	policy.RequireDigit = t.GetSetting(TenantSettingPasswordRequireDigit) == "true"
+	policy.RequireSymbol = t.GetSetting(TenantSettingPasswordRequireSymbol) == "true"
+	if n, ok := t.intSetting(TenantSettingPasswordHistory); ok && n >= 0 {
+		if n > MaxPasswordHistory {
+			n = MaxPasswordHistory
+		}
+		policy.HistorySize = n
+	}
+	if n, ok := t.intSetting(TenantSettingPasswordMaxAgeDays); ok && n >= 0 {
+		policy.MaxAge = time.Duration(n) * 24 * time.Hour
+	}
+	if n, ok := t.intSetting(TenantSettingLockoutThreshold); ok && n >= 0 {
+		policy.LockoutThreshold = n
+	}
+	if n, ok := t.intSetting(TenantSettingLockoutWindowMinutes); ok && n > 0 {
+		policy.LockoutWindow = time.Duration(n) * time.Minute
+	}
+	if n, ok := t.intSetting(TenantSettingLockoutDurationMinutes); ok && n > 0 {
+		policy.LockoutDuration = time.Duration(n) * time.Minute
+	}
+
+	return policy
+}
+
+// intSetting parses an integer tenant setting
+func (t *Tenant) intSetting(key string) (int, bool) {
+	value := t.GetSetting(key)
+	if value == "" {
+		return 0, false
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
+// Check verifies that a password meets the policy's length and complexity requirements.
+// The error lists every requirement the password does not meet.
+func (p PasswordPolicy) Check(password string) error {
+	var upper, lower, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			symbol = true
+		}
+	}
+
+	var unmet []string
+	if len([]rune(password)) < p.MinLength {
+		unmet = append(unmet, "be at least "+strconv.Itoa(p.MinLength)+" characters long")
+	}
+	if p.RequireUppercase && !upper {
+		unmet = append(unmet, "contain an uppercase letter")
+	}
+	if p.RequireLowercase && !lower {
+		unmet = append(unmet, "contain a lowercase letter")
+	}
+	if p.RequireDigit && !digit {
+		unmet = append(unmet, "contain a digit")
+	}
+	if p.RequireSymbol && !symbol {
+		unmet = append(unmet, "contain a symbol")
+	}
+
+	if len(unmet) > 0 {
+		return errors.New("password must " + strings.Join(unmet, ", "))
+	}
+	return nil
+}
+
+// GenerateTemporaryPassword generates a random password that meets the policy and contains every
+// character class, for accounts whose password is set by an administrator
+func GenerateTemporaryPassword(policy PasswordPolicy) (string, error) {
+	length := policy.MinLength
+	if length < temporaryPasswordLength {
+		length = temporaryPasswordLength
+	}
+	complete := PasswordPolicy{
+		MinLength:        length,
+		RequireUppercase: true,
+		RequireLowercase: true,
+		RequireDigit:     true,
+		RequireSymbol:    true,
+	}
+
+	// Draw again in the rare case a character class is missing
+	b := make([]byte, length)
+	for {
+		if _, err := rand.Read(b); err != nil {
+			return "", err
+		}
+		for i := range b {
+			b[i] = temporaryPasswordAlphabet[b[i]%byte(len(temporaryPasswordAlphabet))]
+		}
+		if password := string(b); complete.Check(password) == nil {
+			return password, nil
+		}
+	}
+}
@@ -20,23 +20,36 @@ const (
 	PermissionTypeAdmin  = "admin"
 )
 
+// Grantee types identify who a permission is granted to
+const (
+	GranteeTypeRole  = "role"
+	GranteeTypeUser  = "user"
+	GranteeTypeGroup = "group"
+)
+
 // Error definitions
 var (
 	ErrResourceTypeEmpty     = errors.New("resource type cannot be empty")
 	ErrResourceIDEmpty       = errors.New("resource ID cannot be empty")
 	ErrRoleIDEmpty           = errors.New("role ID cannot be empty")
+	ErrGranteeEmpty          = errors.New("permission must be granted to a role, user or group")
+	ErrMultipleGrantees      = errors.New("permission must be granted to exactly one role, user or group")
+	ErrInvalidGranteeType    = errors.New("invalid grantee type")
 	ErrTenantIDEmpty         = errors.New("tenant ID cannot be empty")
 	ErrPermissionTypeEmpty   = errors.New("permission type cannot be empty")
 	ErrInvalidResourceType   = errors.New("invalid resource type")
 	ErrInvalidPermissionType = errors.New("invalid permission type")
 )
 
-// Permission represents a permission in the system that grants a role specific access to a resource.
+// Permission represents a permission in the system that grants a role, a user or a group specific access
+// to a resource. Exactly one of RoleID, UserID and GroupID is set.
 // Permissions are used to implement the role-based access control system and support tenant isolation.
 type Permission struct {
 	ID             string    // Unique identifier for the permission
 	TenantID       string    // ID of the tenant this permission belongs to for isolation
 	RoleID         string    // ID of the role this permission is assigned to
+	UserID         string    // ID of the user this permission is granted to directly
+	GroupID        string    // ID of the group this permission is granted to
 	ResourceType   string    // Type of resource (document or folder)
 	ResourceID     string    // ID of the resource this permission applies to
 	PermissionType string    // Type of permission (read, write, delete, admin)
@@ -62,6 +75,29 @@ func NewPermission(roleID, resourceType, resourceID, permissionType, tenantID, c
 	}
 }
 
+// NewGrant creates a new Permission granting access to the role, user or group identified by granteeType and granteeID.
+func NewGrant(granteeType, granteeID, resourceType, resourceID, permissionType, tenantID, createdBy string) (*Permission, error) {
+	permission := NewPermission("", resourceType, resourceID, permissionType, tenantID, createdBy)
+	switch granteeType {
+	case GranteeTypeRole:
+		permission.RoleID = granteeID
+	case GranteeTypeUser:
+		permission.UserID = granteeID
+	case GranteeTypeGroup:
+		permission.GroupID = granteeID
+	default:
+		return nil, ErrInvalidGranteeType
+	}
+	return permission, nil
+}
+
+// IsValidGranteeType validates if a given grantee type is one of the predefined valid types.
+func IsValidGranteeType(granteeType string) bool {
+	return granteeType == GranteeTypeRole ||
+		granteeType == GranteeTypeUser ||
+		granteeType == GranteeTypeGroup
+}
+
 // IsValidResourceType validates if a given resource type is one of the predefined valid types.
 func IsValidResourceType(resourceType string) bool {
 	return resourceType == ResourceTypeDocument || resourceType == ResourceTypeFolder
@@ -83,8 +119,8 @@ func (p *Permission) Validate() error {
 	if p.ResourceID == "" {
 		return ErrResourceIDEmpty
 	}
-	if p.RoleID == "" {
-		return ErrRoleIDEmpty
+	if err := p.validateGrantee(); err != nil {
+		return err
 	}
 	if p.TenantID == "" {
 		return ErrTenantIDEmpty
@@ -101,6 +137,47 @@ func (p *Permission) Validate() error {
 	return nil
 }
 
+// validateGrantee ensures that exactly one of RoleID, UserID and GroupID is set.
+func (p *Permission) validateGrantee() error {
+	grantees := 0
+	for _, id := range []string{p.RoleID, p.UserID, p.GroupID} {
+		if id != "" {
+			grantees++
+		}
+	}
+	if grantees == 0 {
+		return ErrGranteeEmpty
+	}
+	if grantees > 1 {
+		return ErrMultipleGrantees
+	}
+	return nil
+}
+
+// GranteeType returns whether this permission is granted to a role, a user or a group.
+func (p *Permission) GranteeType() string {
+	switch {
+	case p.UserID != "":
+		return GranteeTypeUser
+	case p.GroupID != "":
+		return GranteeTypeGroup
+	default:
+		return GranteeTypeRole
+	}
+}
+
+// GranteeID returns the ID of the role, user or group this permission is granted to.
+func (p *Permission) GranteeID() string {
+	switch p.GranteeType() {
+	case GranteeTypeUser:
+		return p.UserID
+	case GranteeTypeGroup:
+		return p.GroupID
+	default:
+		return p.RoleID
+	}
+}
+
 // IsForDocument checks if this permission is for a document resource.
 func (p *Permission) IsForDocument() bool {
 	return p.ResourceType == ResourceTypeDocument
@@ -149,6 +226,8 @@ func (p *Permission) Clone(newResourceID string) *Permission {
 	return &Permission{
 		TenantID:       p.TenantID,
 		RoleID:         p.RoleID,
+		UserID:         p.UserID,
+		GroupID:        p.GroupID,
 		ResourceType:   p.ResourceType,
 		ResourceID:     newResourceID,
 		PermissionType: p.PermissionType,
@@ -0,0 +1,115 @@
+// Package models defines the core domain models for the document management platform
+package models
+
+import (
+	"errors" // standard library - For quarantined item validation
+	"time"   // standard library - For timestamp fields
+)
+
+// QuarantinedItem status constants
+const (
+	QuarantineStatusQuarantined = "quarantined"
+	QuarantineStatusReleased    = "released"
+	QuarantineStatusDestroyed   = "destroyed"
+)
+
+// Quarantine release mode constants
+const (
+	// QuarantineReleaseRescan scans the document again and makes it available only if it is now clean
+	QuarantineReleaseRescan = "rescan"
+	// QuarantineReleaseOverride makes the document available without scanning it again
+	QuarantineReleaseOverride = "override"
+)
+
+// AuditResourceQuarantinedItem is the resource type recorded for quarantine management
+const AuditResourceQuarantinedItem = "quarantined_item"
+
+// Error variables for quarantined item validation
+var (
+	ErrQuarantineDocumentIDEmpty  = errors.New("quarantined document ID cannot be empty")
+	ErrQuarantineVersionIDEmpty   = errors.New("quarantined version ID cannot be empty")
+	ErrQuarantineStoragePathEmpty = errors.New("quarantine storage path cannot be empty")
+)
+
+// QuarantinedItem is a document version a virus scan found infected. The content stays in
+// quarantine storage until a security administrator releases or destroys it.
+type QuarantinedItem struct {
+	ID            string     `json:"id"`
+	TenantID      string     `json:"tenant_id"`
+	DocumentID    string     `json:"document_id"`
+	VersionID     string     `json:"version_id"`
+	StoragePath   string     `json:"storage_path"`
+	ScanDetails   string     `json:"scan_details"` // Verdict of the scanning engines, such as the virus name
+	Status        string     `json:"status"`
+	Resolution    string     `json:"resolution"` // Release mode, or empty while quarantined and once destroyed
+	Reason        string     `json:"reason"`     // Why the item was released or destroyed
+	ResolvedBy    string     `json:"resolved_by"`
+	QuarantinedAt time.Time  `json:"quarantined_at"`
+	ResolvedAt    *time.Time `json:"resolved_at"`
+}
+
+// NewQuarantinedItem creates a quarantined item for a version found infected
+func NewQuarantinedItem(tenantID, documentID, versionID, storagePath, scanDetails string) *QuarantinedItem {
+	return &QuarantinedItem{
+		TenantID:      tenantID,
+		DocumentID:    documentID,
+		VersionID:     versionID,
+		StoragePath:   storagePath,
+		ScanDetails:   scanDetails,
+		Status:        QuarantineStatusQuarantined,
+		QuarantinedAt: time.Now(),
+	}
+}
+
+// Validate checks that the quarantined item names a tenant, document version and storage path
+func (q *QuarantinedItem) Validate() error {
+	if q.TenantID == "" {
+		return ErrTenantIDEmpty
+	}
+	if q.DocumentID == "" {
+		return ErrQuarantineDocumentIDEmpty
+	}
+	if q.VersionID == "" {
+		return ErrQuarantineVersionIDEmpty
+	}
+	if q.StoragePath == "" {
+		return ErrQuarantineStoragePathEmpty
+	}
+	return nil
+}
+
+// IsResolved checks if the item has been released or destroyed
+func (q *QuarantinedItem) IsResolved() bool {
+	return q.Status != QuarantineStatusQuarantined
+}
+
+// Release marks the item as released with the given mode by a user
+func (q *QuarantinedItem) Release(mode, reason, userID string, now time.Time) {
+	q.Status = QuarantineStatusReleased
+	q.Resolution = mode
+	q.Reason = reason
+	q.ResolvedBy = userID
+	q.ResolvedAt = &now
+}
+
+// Destroy marks the item as permanently destroyed by a user
+func (q *QuarantinedItem) Destroy(reason, userID string, now time.Time) {
+	q.Status = QuarantineStatusDestroyed
+	q.Reason = reason
+	q.ResolvedBy = userID
+	q.ResolvedAt = &now
+}
+
+// IsValidQuarantineReleaseMode checks if mode is a supported release mode
+func IsValidQuarantineReleaseMode(mode string) bool {
+	return mode == QuarantineReleaseRescan || mode == QuarantineReleaseOverride
+}
+
+// IsValidQuarantineStatus checks if status is a quarantined item status
+func IsValidQuarantineStatus(status string) bool {
+	switch status {
+	case QuarantineStatusQuarantined, QuarantineStatusReleased, QuarantineStatusDestroyed:
+		return true
+	}
+	return false
+}
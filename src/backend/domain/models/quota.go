@@ -0,0 +1,104 @@
+// Package models defines the core domain models for the document management platform
+package models
+
+import (
+	"errors" // standard library - For quota violations
+	"time"   // standard library - For timestamp fields
+)
+
+// Quota limits an upload can exceed
+const (
+	QuotaLimitStorage   = "storage"
+	QuotaLimitDocuments = "documents"
+)
+
+// Error variables for quota violations
+var (
+	ErrStorageQuotaExceeded  = errors.New("tenant storage quota exceeded")
+	ErrDocumentQuotaExceeded = errors.New("tenant document quota exceeded")
+)
+
+// TenantQuota holds a tenant's storage limits and the usage counted against them. Usage is
+// tracked incrementally as documents are uploaded and deleted. A zero limit means unlimited.
+type TenantQuota struct {
+	TenantID         string    `json:"tenant_id" gorm:"primaryKey"`
+	MaxStorageBytes  int64     `json:"max_storage_bytes"`
+	MaxDocuments     int64     `json:"max_documents"`
+	UsedStorageBytes int64     `json:"used_storage_bytes"`
+	UsedDocuments    int64     `json:"used_documents"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// NewTenantQuota creates a new TenantQuota with the given limits and no usage
+func NewTenantQuota(tenantID string, maxStorageBytes, maxDocuments int64) *TenantQuota {
+	return &TenantQuota{
+		TenantID:        tenantID,
+		MaxStorageBytes: maxStorageBytes,
+		MaxDocuments:    maxDocuments,
+		UpdatedAt:       time.Now(),
+	}
+}
+
+// RemainingStorageBytes returns the storage left before the limit, or -1 when storage is unlimited
+func (q *TenantQuota) RemainingStorageBytes() int64 {
+	if q.MaxStorageBytes <= 0 {
+		return -1
+	}
+	return remaining(q.MaxStorageBytes, q.UsedStorageBytes)
+}
+
+// RemainingDocuments returns the documents left before the limit, or -1 when documents are unlimited
+func (q *TenantQuota) RemainingDocuments() int64 {
+	if q.MaxDocuments <= 0 {
+		return -1
+	}
+	return remaining(q.MaxDocuments, q.UsedDocuments)
+}
+
+// TableName returns the table tenant quotas are stored in, which GORM would not pluralize
+func (TenantQuota) TableName() string {
+	return "tenant_quotas"
+}
+
+// CheckUpload checks that a new document of the given size fits the quota
+func (q *TenantQuota) CheckUpload(size int64) error {
+	return q.CheckUsage(size, 1)
+}
+
+// CheckVersion checks that a new version of the given size of an existing document fits the quota
+func (q *TenantQuota) CheckVersion(size int64) error {
+	return q.CheckUsage(size, 0)
+}
+
+// CheckUsage checks that additional usage fits the quota. It returns ErrDocumentQuotaExceeded
+// or ErrStorageQuotaExceeded otherwise.
+func (q *TenantQuota) CheckUsage(storageBytes, documents int64) error {
+	if q.MaxDocuments > 0 && documents > 0 && q.UsedDocuments+documents > q.MaxDocuments {
+		return ErrDocumentQuotaExceeded
+	}
+	if q.MaxStorageBytes > 0 && storageBytes > 0 && q.UsedStorageBytes+storageBytes > q.MaxStorageBytes {
+		return ErrStorageQuotaExceeded
+	}
+	return nil
+}
+
+// AddUsage adds to the tracked usage; negative values release usage, which never drops below zero
+func (q *TenantQuota) AddUsage(storageBytes, documents int64) {
+	q.UsedStorageBytes += storageBytes
+	if q.UsedStorageBytes < 0 {
+		q.UsedStorageBytes = 0
+	}
+	q.UsedDocuments += documents
+	if q.UsedDocuments < 0 {
+		q.UsedDocuments = 0
+	}
+	q.UpdatedAt = time.Now()
+}
+
+// remaining returns how much of a limit is left, never less than zero
+func remaining(limit, used int64) int64 {
+	if used >= limit {
+		return 0
+	}
+	return limit - used
+}
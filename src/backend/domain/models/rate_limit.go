@@ -0,0 +1,43 @@
+// Package models defines the core domain models for the document management platform
+package models
+
+import (
+	"time" // standard library - For retry delays
+)
+
+// Scopes requests are rate limited in
+const (
+	RateLimitScopeTenant = "tenant"
+	RateLimitScopeUser   = "user"
+	RateLimitScopeAPIKey = "api_key"
+	RateLimitScopeRoute  = "route"
+)
+
+// RateLimit configures a token bucket: it refills at RequestsPerMinute and holds at most Burst
+// tokens, so that short bursts above the sustained rate are allowed. A bucket without a burst
+// size holds one minute's worth of requests.
+type RateLimit struct {
+	RequestsPerMinute int
+	Burst             int
+}
+
+// Enabled reports whether the rate limit restricts requests at all
+func (l RateLimit) Enabled() bool {
+	return l.RequestsPerMinute > 0
+}
+
+// Capacity returns the number of tokens a full bucket holds
+func (l RateLimit) Capacity() int {
+	if l.Burst > 0 {
+		return l.Burst
+	}
+	return l.RequestsPerMinute
+}
+
+// RateLimitDecision is the outcome of taking a token from a bucket
+type RateLimitDecision struct {
+	Allowed    bool          // Whether a token was available
+	Limit      int           // Capacity of the bucket
+	Remaining  int           // Whole tokens left in the bucket
+	RetryAfter time.Duration // Time until the next token is available, when not allowed
+}
@@ -0,0 +1,107 @@
+// Package models defines the core domain models for the document management platform
+package models
+
+import (
+	"errors" // standard library - For reindex job validation
+	"time"   // standard library - For timestamp fields
+)
+
+// ReindexJob status constants
+const (
+	ReindexJobStatusPending   = "pending"
+	ReindexJobStatusRunning   = "running"
+	ReindexJobStatusCompleted = "completed"
+	ReindexJobStatusFailed    = "failed"
+)
+
+// AuditResourceReindexJob is the resource type recorded for search index rebuilds
+const AuditResourceReindexJob = "reindex_job"
+
+// ErrReindexJobUserIDEmpty is returned for reindex jobs without the administrator who requested them
+var ErrReindexJobUserIDEmpty = errors.New("reindex user ID cannot be empty")
+
+// ReindexJob is a background rebuild of the search index of a tenant from the documents in the
+// database and their content in storage. The worker that runs it reports progress by counting the
+// indexed documents; searches keep using the current index until the rebuilt one replaces it.
+type ReindexJob struct {
+	ID               string     `json:"id"`
+	TenantID         string     `json:"tenant_id"`
+	UserID           string     `json:"user_id"`
+	Status           string     `json:"status"`
+	TotalDocuments   int        `json:"total_documents"`
+	IndexedDocuments int        `json:"indexed_documents"`
+	Error            string     `json:"error"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+	StartedAt        *time.Time `json:"started_at"`
+	CompletedAt      *time.Time `json:"completed_at"`
+}
+
+// NewReindexJob creates a pending rebuild of the search index of a tenant
+func NewReindexJob(tenantID, userID string) *ReindexJob {
+	now := time.Now()
+	return &ReindexJob{
+		TenantID:  tenantID,
+		UserID:    userID,
+		Status:    ReindexJobStatusPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// Validate checks that the reindex job names a tenant and user
+func (j *ReindexJob) Validate() error {
+	if j.TenantID == "" {
+		return ErrTenantIDEmpty
+	}
+	if j.UserID == "" {
+		return ErrReindexJobUserIDEmpty
+	}
+	return nil
+}
+
+// IsFinished checks if the reindex job has completed or failed
+func (j *ReindexJob) IsFinished() bool {
+	return j.Status == ReindexJobStatusCompleted || j.Status == ReindexJobStatusFailed
+}
+
+// Progress returns the percentage of documents indexed so far
+func (j *ReindexJob) Progress() int {
+	if j.Status == ReindexJobStatusCompleted {
+		return 100
+	}
+	if j.TotalDocuments == 0 {
+		return 0
+	}
+	progress := j.IndexedDocuments * 100 / j.TotalDocuments
+	if progress > 99 {
+		// Documents uploaded during the rebuild may outnumber the total counted at the start
+		return 99
+	}
+	return progress
+}
+
+// Start marks the reindex job as running from scratch
+func (j *ReindexJob) Start(now time.Time) {
+	j.Status = ReindexJobStatusRunning
+	j.TotalDocuments = 0
+	j.IndexedDocuments = 0
+	j.Error = ""
+	j.StartedAt = &now
+	j.UpdatedAt = now
+}
+
+// Complete marks the reindex job as completed
+func (j *ReindexJob) Complete(now time.Time) {
+	j.Status = ReindexJobStatusCompleted
+	j.CompletedAt = &now
+	j.UpdatedAt = now
+}
+
+// Fail marks the reindex job as failed with the reason
+func (j *ReindexJob) Fail(reason string, now time.Time) {
+	j.Status = ReindexJobStatusFailed
+	j.Error = reason
+	j.CompletedAt = &now
+	j.UpdatedAt = now
+}
@@ -14,11 +14,40 @@ const (
 	RoleSystem        = "system"
 )
 
+// Role permission constants define the permissions that can be granted to a role.
+// They match the permission names checked by the authentication service.
+const (
+	RolePermissionRead          = "read"
+	RolePermissionWrite         = "write"
+	RolePermissionDelete        = "delete"
+	RolePermissionManageFolders = "manage_folders"
+	RolePermissionAdmin         = "admin"
+)
+
+// DefaultRolePermissions maps each predefined system role to the permissions it is seeded with
+var DefaultRolePermissions = map[string][]string{
+	RoleReader:        {RolePermissionRead},
+	RoleContributor:   {RolePermissionRead, RolePermissionWrite},
+	RoleEditor:        {RolePermissionRead, RolePermissionWrite, RolePermissionDelete},
+	RoleAdministrator: {RolePermissionRead, RolePermissionWrite, RolePermissionDelete, RolePermissionManageFolders, RolePermissionAdmin},
+	RoleSystem:        {RolePermissionRead, RolePermissionWrite, RolePermissionDelete, RolePermissionManageFolders, RolePermissionAdmin},
+}
+
+// defaultRoleDescriptions holds the description each predefined system role is seeded with
+var defaultRoleDescriptions = map[string]string{
+	RoleReader:        "Can view documents and folders",
+	RoleContributor:   "Can view, upload, and update documents",
+	RoleEditor:        "Can view, upload, update, and delete documents",
+	RoleAdministrator: "Can perform all operations including folder management",
+	RoleSystem:        "Special role for system operations",
+}
+
 // Error constants for role validation
 var (
-	ErrNameEmpty        = errors.New("role name cannot be empty")
-	ErrTenantIDEmpty    = errors.New("tenant ID cannot be empty")
-	ErrDescriptionEmpty = errors.New("role description cannot be empty")
+	ErrNameEmpty             = errors.New("role name cannot be empty")
+	ErrTenantIDEmpty         = errors.New("tenant ID cannot be empty")
+	ErrDescriptionEmpty      = errors.New("role description cannot be empty")
+	ErrRolePermissionInvalid = errors.New("invalid role permission")
 )
 
 // Role represents a role in the document management platform that defines a set of permissions
@@ -27,6 +56,7 @@ type Role struct {
 	TenantID    string    `json:"tenant_id"`
 	Name        string    `json:"name"`
 	Description string    `json:"description"`
+	Permissions []string  `json:"permissions"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 }
@@ -37,11 +67,25 @@ func NewRole(name, description, tenantID string) *Role {
 		Name:        name,
 		Description: description,
 		TenantID:    tenantID,
+		Permissions: []string{},
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
 }
 
+// NewDefaultRoles creates the predefined system roles of a new tenant, with the permissions and
+// descriptions existing tenants were seeded with
+func NewDefaultRoles(tenantID string) []*Role {
+	names := []string{RoleReader, RoleContributor, RoleEditor, RoleAdministrator, RoleSystem}
+	roles := make([]*Role, 0, len(names))
+	for _, name := range names {
+		role := NewRole(name, defaultRoleDescriptions[name], tenantID)
+		role.SetPermissions(DefaultRolePermissions[name])
+		roles = append(roles, role)
+	}
+	return roles
+}
+
 // Validate checks if the role has all required fields
 func (r *Role) Validate() error {
 	if r.Name == "" {
@@ -53,9 +97,47 @@ func (r *Role) Validate() error {
 	if r.Description == "" {
 		return ErrDescriptionEmpty
 	}
+	for _, permission := range r.Permissions {
+		if !IsValidRolePermission(permission) {
+			return ErrRolePermissionInvalid
+		}
+	}
 	return nil
 }
 
+// IsValidRolePermission checks if a permission is one that can be granted to a role
+func IsValidRolePermission(permission string) bool {
+	return permission == RolePermissionRead ||
+		permission == RolePermissionWrite ||
+		permission == RolePermissionDelete ||
+		permission == RolePermissionManageFolders ||
+		permission == RolePermissionAdmin
+}
+
+// HasPermission checks if the role grants the given permission
+func (r *Role) HasPermission(permission string) bool {
+	for _, p := range r.Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// SetPermissions replaces the role's permissions, dropping duplicates
+func (r *Role) SetPermissions(permissions []string) {
+	seen := make(map[string]bool, len(permissions))
+	r.Permissions = make([]string, 0, len(permissions))
+	for _, p := range permissions {
+		if seen[p] {
+			continue
+		}
+		seen[p] = true
+		r.Permissions = append(r.Permissions, p)
+	}
+	r.UpdatedAt = time.Now()
+}
+
 // IsReader checks if this role is the Reader role
 func (r *Role) IsReader() bool {
 	return r.Name == RoleReader
@@ -117,4 +199,4 @@ func (r *Role) CanDelete() bool {
 func (r *Role) CanManageFolders() bool {
 	// Only Administrator and System roles can manage folders
 	return r.IsAdministrator() || r.IsSystem()
-}
\ No newline at end of file
+}
@@ -0,0 +1,67 @@
+// Package models defines the core domain models for the document management platform
+package models
+
+import (
+	"strings" // standard library - For parsing engine lists
+)
+
+// Virus scanning engines documents can be scanned with
+const (
+	ScanEngineClamAV   = "clamav"   // ClamAV daemon
+	ScanEngineICAP     = "icap"     // Commercial scanner behind an ICAP server
+	ScanEngineExternal = "external" // External service returning a verdict for the content
+	ScanEngineNone     = "none"     // No-op engine finding every document clean, for development
+)
+
+// TenantSettingScanEngines lists the virus scanning engines a tenant's documents are scanned with,
+// separated by commas. Several engines scan in parallel and a document is infected when any of them
+// finds it infected. Tenants without a selection use the platform's default engines.
+const TenantSettingScanEngines = "scan_engines"
+
+// TenantSettingScanVerdictCacheDisabled stops uploads of a tenant whose content was recently scanned
+// clean from skipping the scan, so every upload is scanned
+const TenantSettingScanVerdictCacheDisabled = "scan_verdict_cache_disabled"
+
+// ParseScanEngines splits a comma separated list of scanning engines, dropping blanks and duplicates
+func ParseScanEngines(value string) []string {
+	var engines []string
+	seen := make(map[string]bool)
+	for _, engine := range strings.Split(value, ",") {
+		engine = strings.TrimSpace(engine)
+		if engine == "" || seen[engine] {
+			continue
+		}
+		seen[engine] = true
+		engines = append(engines, engine)
+	}
+	return engines
+}
+
+// IsScanEngineList checks if value is a comma separated list of known scanning engines. The no-op
+// engine is not one of them, so tenants cannot switch off scanning.
+func IsScanEngineList(value string) bool {
+	engines := ParseScanEngines(value)
+	if len(engines) == 0 {
+		return false
+	}
+	for _, engine := range engines {
+		switch engine {
+		case ScanEngineClamAV, ScanEngineICAP, ScanEngineExternal:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// ScanEngines returns the scanning engines the tenant selected, or nil if the tenant uses the
+// platform's default engines
+func (t *Tenant) ScanEngines() []string {
+	return ParseScanEngines(t.GetSetting(TenantSettingScanEngines))
+}
+
+// CachesScanVerdicts checks if uploads of content the tenant recently uploaded and was scanned clean
+// can skip the scan
+func (t *Tenant) CachesScanVerdicts() bool {
+	return t.GetSetting(TenantSettingScanVerdictCacheDisabled) != "true"
+}
@@ -0,0 +1,77 @@
+// Package models defines the core domain models for the document management platform
+package models
+
+import (
+	"errors" // standard library - For error handling in validation methods
+	"time"   // standard library - For timestamp fields
+)
+
+// AuditResourceSession is the resource type recorded for session operations
+const AuditResourceSession = "session"
+
+// Error variables for session validation
+var (
+	ErrSessionUserIDEmpty   = errors.New("session user ID cannot be empty")
+	ErrSessionTenantIDEmpty = errors.New("session tenant ID cannot be empty")
+	ErrSessionExpiryInvalid = errors.New("session expiry must be after its creation")
+	ErrSessionUserAgentLong = errors.New("session user agent cannot exceed 512 characters")
+)
+
+// Session is a signed-in user on a device. It starts when the user signs in and groups the access
+// and refresh tokens issued to that device, which carry the session ID. Revoking a session
+// revokes every token of the family. A session expires with its latest refresh token.
+type Session struct {
+	ID         string     `json:"id"`
+	UserID     string     `json:"user_id"`
+	TenantID   string     `json:"tenant_id"`
+	IPAddress  string     `json:"ip_address"`
+	UserAgent  string     `json:"user_agent"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt time.Time  `json:"last_used_at"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+}
+
+// NewSession creates a new Session for a user signing in from the given client
+func NewSession(userID, tenantID, ipAddress, userAgent string, expiresAt time.Time) *Session {
+	now := time.Now()
+	if len(userAgent) > 512 {
+		userAgent = userAgent[:512]
+	}
+	return &Session{
+		UserID:     userID,
+		TenantID:   tenantID,
+		IPAddress:  ipAddress,
+		UserAgent:  userAgent,
+		CreatedAt:  now,
+		LastUsedAt: now,
+		ExpiresAt:  expiresAt,
+	}
+}
+
+// Validate checks that the session has all required fields
+func (s *Session) Validate() error {
+	if s.UserID == "" {
+		return ErrSessionUserIDEmpty
+	}
+	if s.TenantID == "" {
+		return ErrSessionTenantIDEmpty
+	}
+	if !s.ExpiresAt.After(s.CreatedAt) {
+		return ErrSessionExpiryInvalid
+	}
+	if len(s.UserAgent) > 512 {
+		return ErrSessionUserAgentLong
+	}
+	return nil
+}
+
+// IsRevoked checks if the session has been revoked
+func (s *Session) IsRevoked() bool {
+	return s.RevokedAt != nil
+}
+
+// IsActive checks if the session can still be used at the given time
+func (s *Session) IsActive(now time.Time) bool {
+	return !s.IsRevoked() && now.Before(s.ExpiresAt)
+}
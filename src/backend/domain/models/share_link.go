@@ -0,0 +1,171 @@
+// Package models defines the core domain models for the document management platform
+package models
+
+import (
+	"crypto/rand"   // standard library - For generating share link tokens
+	"crypto/sha256" // standard library - For hashing share link tokens at rest
+	"encoding/hex"  // standard library - For encoding tokens and hashes
+	"errors"        // standard library - For error handling in validation methods
+	"time"          // standard library - For timestamp fields
+
+	"golang.org/x/crypto/bcrypt" // v0.0.0-20220622213112-05595931fe9d
+)
+
+// ShareLinkTokenPrefix identifies share link tokens
+const ShareLinkTokenPrefix = "shl_"
+
+// ShareLinkMaxExpiry is the longest a share link can stay valid
+const ShareLinkMaxExpiry = 90 * 24 * time.Hour
+
+// ShareLinkDefaultExpiry is how long share links stay valid when neither their creator nor their
+// tenant says
+const ShareLinkDefaultExpiry = 7 * 24 * time.Hour
+
+// AuditResourceShareLink is the resource type recorded for share link operations
+const AuditResourceShareLink = "share_link"
+
+// Error variables for share link validation
+var (
+	ErrShareLinkTenantIDEmpty    = errors.New("share link tenant ID cannot be empty")
+	ErrShareLinkDocumentIDEmpty  = errors.New("share link document ID cannot be empty")
+	ErrShareLinkTokenHashEmpty   = errors.New("share link token hash cannot be empty")
+	ErrShareLinkExpiryInvalid    = errors.New("share link expiry must be in the future and within 90 days")
+	ErrShareLinkMaxDownloads     = errors.New("share link max downloads cannot be negative")
+	ErrShareLinkPasswordTooShort = errors.New("share link password must be at least 8 characters long")
+)
+
+// ShareLink is a tokenized public link to a document. Anyone holding the token can download
+// the document until the link expires, reaches its download limit or is revoked. A link can
+// additionally be protected by a password. Only a hash of the token is stored.
+type ShareLink struct {
+	ID            string     `json:"id"`
+	TenantID      string     `json:"tenant_id"`
+	DocumentID    string     `json:"document_id"`
+	TokenHash     string     `json:"-"`
+	PasswordHash  string     `json:"-"`
+	ExpiresAt     time.Time  `json:"expires_at"`
+	MaxDownloads  int        `json:"max_downloads"`  // 0 means unlimited
+	DownloadCount int        `json:"download_count"`
+	Watermark     bool       `json:"watermark"` // Stamp downloaded PDFs with the link they were downloaded through
+	CreatedBy     string     `json:"created_by"`
+	CreatedAt     time.Time  `json:"created_at"`
+	RevokedAt     *time.Time `json:"revoked_at"`
+}
+
+// NewShareLink creates a new ShareLink for the document and returns it together with the
+// plaintext token, which is only available at creation time
+func NewShareLink(tenantID, documentID string, expiresAt time.Time, maxDownloads int, createdBy string) (*ShareLink, string, error) {
+	token, err := GenerateShareLinkToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	link := &ShareLink{
+		TenantID:     tenantID,
+		DocumentID:   documentID,
+		TokenHash:    HashShareLinkToken(token),
+		ExpiresAt:    expiresAt,
+		MaxDownloads: maxDownloads,
+		CreatedBy:    createdBy,
+		CreatedAt:    time.Now(),
+	}
+
+	return link, token, nil
+}
+
+// Validate checks that the share link has all required fields and sensible limits
+func (l *ShareLink) Validate() error {
+	if l.TenantID == "" {
+		return ErrShareLinkTenantIDEmpty
+	}
+	if l.DocumentID == "" {
+		return ErrShareLinkDocumentIDEmpty
+	}
+	if l.TokenHash == "" {
+		return ErrShareLinkTokenHashEmpty
+	}
+	if !l.ExpiresAt.After(l.CreatedAt) || l.ExpiresAt.Sub(l.CreatedAt) > ShareLinkMaxExpiry {
+		return ErrShareLinkExpiryInvalid
+	}
+	if l.MaxDownloads < 0 {
+		return ErrShareLinkMaxDownloads
+	}
+	return nil
+}
+
+// SetPassword protects the share link with a password
+func (l *ShareLink) SetPassword(password string) error {
+	if len(password) < 8 {
+		return ErrShareLinkPasswordTooShort
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), DefaultBcryptCost)
+	if err != nil {
+		return err
+	}
+
+	l.PasswordHash = string(hash)
+	return nil
+}
+
+// HasPassword checks if the share link is password protected
+func (l *ShareLink) HasPassword() bool {
+	return l.PasswordHash != ""
+}
+
+// VerifyPassword verifies the password of a protected share link. Links without a password accept any input.
+func (l *ShareLink) VerifyPassword(password string) (bool, error) {
+	if !l.HasPassword() {
+		return true, nil
+	}
+
+	err := bcrypt.CompareHashAndPassword([]byte(l.PasswordHash), []byte(password))
+	if err != nil {
+		if err == bcrypt.ErrMismatchedHashAndPassword {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// IsRevoked checks if the share link has been revoked
+func (l *ShareLink) IsRevoked() bool {
+	return l.RevokedAt != nil
+}
+
+// IsExpired checks if the share link has expired at the given time
+func (l *ShareLink) IsExpired(now time.Time) bool {
+	return !now.Before(l.ExpiresAt)
+}
+
+// IsExhausted checks if the share link has reached its download limit
+func (l *ShareLink) IsExhausted() bool {
+	return l.MaxDownloads > 0 && l.DownloadCount >= l.MaxDownloads
+}
+
+// IsUsable checks if the share link can still be used to download the document
+func (l *ShareLink) IsUsable(now time.Time) bool {
+	return !l.IsRevoked() && !l.IsExpired(now) && !l.IsExhausted()
+}
+
+// Revoke marks the share link as revoked
+func (l *ShareLink) Revoke() {
+	now := time.Now()
+	l.RevokedAt = &now
+}
+
+// GenerateShareLinkToken generates a new random share link token
+func GenerateShareLinkToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return ShareLinkTokenPrefix + hex.EncodeToString(buf), nil
+}
+
+// HashShareLinkToken returns the SHA-256 hash under which a share link token is stored
+func HashShareLinkToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,256 @@
+// Package models defines the core domain models for the document management platform
+package models
+
+import (
+	"errors"  // standard library - For error handling in validation methods
+	"strings" // standard library - For matching signer emails
+	"time"    // standard library - For timestamp fields
+)
+
+// Electronic signature providers documents can be sent for signature with
+const (
+	SignatureProviderDocuSign  = "docusign"  // DocuSign eSignature
+	SignatureProviderAdobeSign = "adobesign" // Adobe Acrobat Sign
+)
+
+// Signature request status constants
+const (
+	// SignatureStatusPending is a signature request whose envelope has not been sent yet
+	SignatureStatusPending = "pending"
+
+	// SignatureStatusSent is a signature request whose envelope is out for signature
+	SignatureStatusSent = "sent"
+
+	// SignatureStatusCompleted is a signature request every signer signed; the signed document
+	// is stored as a new version of the document
+	SignatureStatusCompleted = "completed"
+
+	// SignatureStatusDeclined is a signature request a signer declined to sign
+	SignatureStatusDeclined = "declined"
+
+	// SignatureStatusVoided is a signature request withdrawn by the platform or at the provider
+	SignatureStatusVoided = "voided"
+)
+
+// Signer status constants
+const (
+	SignerStatusPending  = "pending"
+	SignerStatusSigned   = "signed"
+	SignerStatusDeclined = "declined"
+)
+
+// MaxSigners is the maximum number of signers of a signature request
+const MaxSigners = 20
+
+// MaxSignatureMessageLength is the maximum number of characters of the message sent to signers
+const MaxSignatureMessageLength = 2000
+
+// Error variables for signature request validation and transitions
+var (
+	ErrSignatureTenantIDEmpty      = errors.New("signature request tenant ID cannot be empty")
+	ErrSignatureDocumentIDEmpty    = errors.New("signature request document ID cannot be empty")
+	ErrSignatureProviderEmpty      = errors.New("signature request provider cannot be empty")
+	ErrSignatureSubjectEmpty       = errors.New("signature request subject cannot be empty")
+	ErrSignatureMessageTooLong     = errors.New("signature request message cannot exceed 2000 characters")
+	ErrSignatureSignersEmpty       = errors.New("signature request must list at least one signer")
+	ErrSignatureTooManySigners     = errors.New("signature request cannot list more than 20 signers")
+	ErrSignatureSignerInvalid      = errors.New("signers must have a name and an email address")
+	ErrSignatureDuplicateSigner    = errors.New("signature request lists a signer email more than once")
+	ErrSignatureInvalidOrder       = errors.New("signer routing orders must be 1 or greater")
+	ErrSignatureRequestClosed      = errors.New("signature request is no longer out for signature")
+	ErrSignatureInvalidTransition  = errors.New("signature request cannot move to this status")
+	ErrSignatureSignedVersionEmpty = errors.New("completed signature requests must reference the signed version")
+)
+
+// Signer is a person asked to sign the document of a signature request. The status, signing time
+// and IP address are reported by the provider and kept as the signer metadata of the signed version.
+type Signer struct {
+	ID           string     `json:"id"`
+	RequestID    string     `json:"request_id"`
+	Name         string     `json:"name"`
+	Email        string     `json:"email"`
+	RoutingOrder int        `json:"routing_order"` // Signers with the same order sign in parallel, lower orders sign first
+	Status       string     `json:"status"`
+	SignedAt     *time.Time `json:"signed_at"`
+	IPAddress    string     `json:"ip_address"`
+}
+
+// TableName keeps the signers of signature requests apart from other kinds of signers
+func (Signer) TableName() string {
+	return "signature_signers"
+}
+
+// SignerStatusUpdate is the status of a signer reported by a provider, matched to a signer by email
+type SignerStatusUpdate struct {
+	Email     string
+	Status    string
+	SignedAt  *time.Time
+	IPAddress string
+}
+
+// SignatureRequest tracks a document sent to an electronic signature provider. The provider calls
+// back as the envelope progresses; once every signer signed, the signed document is stored as a
+// new version of the document.
+type SignatureRequest struct {
+	ID              string     `json:"id"`
+	TenantID        string     `json:"tenant_id"`
+	DocumentID      string     `json:"document_id"`
+	FolderID        string     `json:"folder_id"`
+	VersionID       string     `json:"version_id"` // Version of the document sent for signature
+	Provider        string     `json:"provider"`
+	EnvelopeID      string     `json:"envelope_id"` // ID of the envelope or agreement at the provider
+	Status          string     `json:"status"`
+	Subject         string     `json:"subject"`
+	Message         string     `json:"message"`
+	RequestedBy     string     `json:"requested_by"`
+	Signers         []Signer   `json:"signers" gorm:"foreignKey:RequestID"`
+	SignedVersionID string     `json:"signed_version_id"` // Version holding the signed document, once completed
+	StatusReason    string     `json:"status_reason"`     // Why the request was declined or voided
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	CompletedAt     *time.Time `json:"completed_at"`
+}
+
+// NewSignatureRequest creates a new pending SignatureRequest for a document; signers without a
+// routing order sign first
+func NewSignatureRequest(tenantID, documentID, provider, subject, message string, signers []Signer, requestedBy string) *SignatureRequest {
+	now := time.Now()
+	request := &SignatureRequest{
+		TenantID:    tenantID,
+		DocumentID:  documentID,
+		Provider:    provider,
+		Status:      SignatureStatusPending,
+		Subject:     subject,
+		Message:     message,
+		RequestedBy: requestedBy,
+		Signers:     make([]Signer, len(signers)),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	for i, signer := range signers {
+		signer.Status = SignerStatusPending
+		if signer.RoutingOrder == 0 {
+			signer.RoutingOrder = 1
+		}
+		request.Signers[i] = signer
+	}
+	return request
+}
+
+// Validate checks that the request names a document, a provider, a subject and between one and
+// MaxSigners distinct signers
+func (r *SignatureRequest) Validate() error {
+	if r.TenantID == "" {
+		return ErrSignatureTenantIDEmpty
+	}
+	if r.DocumentID == "" {
+		return ErrSignatureDocumentIDEmpty
+	}
+	if r.Provider == "" {
+		return ErrSignatureProviderEmpty
+	}
+	if strings.TrimSpace(r.Subject) == "" {
+		return ErrSignatureSubjectEmpty
+	}
+	if len([]rune(r.Message)) > MaxSignatureMessageLength {
+		return ErrSignatureMessageTooLong
+	}
+	if len(r.Signers) == 0 {
+		return ErrSignatureSignersEmpty
+	}
+	if len(r.Signers) > MaxSigners {
+		return ErrSignatureTooManySigners
+	}
+
+	seen := make(map[string]bool, len(r.Signers))
+	for _, signer := range r.Signers {
+		if strings.TrimSpace(signer.Name) == "" || !strings.Contains(signer.Email, "@") {
+			return ErrSignatureSignerInvalid
+		}
+		if signer.RoutingOrder < 1 {
+			return ErrSignatureInvalidOrder
+		}
+		email := strings.ToLower(signer.Email)
+		if seen[email] {
+			return ErrSignatureDuplicateSigner
+		}
+		seen[email] = true
+	}
+	return nil
+}
+
+// IsOpen returns whether the envelope of the request is out for signature
+func (r *SignatureRequest) IsOpen() bool {
+	return r.Status == SignatureStatusSent
+}
+
+// MarkSent records the envelope the provider created for the request
+func (r *SignatureRequest) MarkSent(envelopeID string, now time.Time) error {
+	if r.Status != SignatureStatusPending {
+		return ErrSignatureInvalidTransition
+	}
+	r.EnvelopeID = envelopeID
+	r.Status = SignatureStatusSent
+	r.UpdatedAt = now
+	return nil
+}
+
+// UpdateSigners applies the signer statuses reported by the provider; updates for emails that are
+// not signers of the request are ignored
+func (r *SignatureRequest) UpdateSigners(updates []SignerStatusUpdate) {
+	for _, update := range updates {
+		for i := range r.Signers {
+			if !strings.EqualFold(r.Signers[i].Email, update.Email) {
+				continue
+			}
+			if update.Status != "" {
+				r.Signers[i].Status = update.Status
+			}
+			if update.SignedAt != nil {
+				r.Signers[i].SignedAt = update.SignedAt
+			}
+			if update.IPAddress != "" {
+				r.Signers[i].IPAddress = update.IPAddress
+			}
+		}
+	}
+}
+
+// Complete marks the request completed with the version holding the signed document
+func (r *SignatureRequest) Complete(signedVersionID string, now time.Time) error {
+	if !r.IsOpen() {
+		return ErrSignatureRequestClosed
+	}
+	if signedVersionID == "" {
+		return ErrSignatureSignedVersionEmpty
+	}
+	r.SignedVersionID = signedVersionID
+	r.close(SignatureStatusCompleted, "", now)
+	return nil
+}
+
+// Decline marks the request declined by a signer
+func (r *SignatureRequest) Decline(reason string, now time.Time) error {
+	if !r.IsOpen() {
+		return ErrSignatureRequestClosed
+	}
+	r.close(SignatureStatusDeclined, reason, now)
+	return nil
+}
+
+// Void marks the request withdrawn, by the requester or at the provider
+func (r *SignatureRequest) Void(reason string, now time.Time) error {
+	if !r.IsOpen() {
+		return ErrSignatureRequestClosed
+	}
+	r.close(SignatureStatusVoided, reason, now)
+	return nil
+}
+
+// close moves the request to a final status
+func (r *SignatureRequest) close(status, reason string, now time.Time) {
+	r.Status = status
+	r.StatusReason = reason
+	r.UpdatedAt = now
+	r.CompletedAt = &now
+}
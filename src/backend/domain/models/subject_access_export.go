@@ -0,0 +1,109 @@
+// Package models defines the core domain models for the document management platform
+package models
+
+import (
+	"errors" // standard library - For subject access export validation
+	"time"   // standard library - For timestamp fields
+)
+
+// SubjectAccessExport status constants
+const (
+	SubjectAccessExportStatusPending   = "pending"
+	SubjectAccessExportStatusRunning   = "running"
+	SubjectAccessExportStatusCompleted = "completed"
+	SubjectAccessExportStatusFailed    = "failed"
+)
+
+// AuditResourceSubjectAccessExport is the resource type recorded for subject access exports
+const AuditResourceSubjectAccessExport = "subject_access_export"
+
+// Error variables for subject access export validation
+var (
+	ErrSubjectAccessExportSubjectIDEmpty = errors.New("subject access export subject ID cannot be empty")
+	ErrSubjectAccessExportRequesterEmpty = errors.New("subject access export requester cannot be empty")
+)
+
+// SubjectAccessExport is a background export of the personal data held about a user, compiled into
+// a ZIP archive in answer to a GDPR subject access request: the user's profile, the documents they
+// own with their metadata, and the audit events of their actions and account. Tenant administrators
+// request it on behalf of the data subject and download the archive once completed.
+type SubjectAccessExport struct {
+	ID              string     `json:"id"`
+	TenantID        string     `json:"tenant_id"`
+	SubjectID       string     `json:"subject_id"`
+	RequestedBy     string     `json:"requested_by"`
+	Status          string     `json:"status"`
+	DocumentCount   int        `json:"document_count"`
+	AuditEventCount int        `json:"audit_event_count"`
+	ArchivePath     string     `json:"archive_path"`
+	ArchiveSize     int64      `json:"archive_size"`
+	Error           string     `json:"error"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	StartedAt       *time.Time `json:"started_at"`
+	CompletedAt     *time.Time `json:"completed_at"`
+}
+
+// NewSubjectAccessExport creates a pending export of the personal data of a user
+func NewSubjectAccessExport(tenantID, subjectID, requestedBy string) *SubjectAccessExport {
+	now := time.Now()
+	return &SubjectAccessExport{
+		TenantID:    tenantID,
+		SubjectID:   subjectID,
+		RequestedBy: requestedBy,
+		Status:      SubjectAccessExportStatusPending,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+// Validate checks that the export names a tenant, the data subject and who requested it
+func (e *SubjectAccessExport) Validate() error {
+	if e.TenantID == "" {
+		return ErrTenantIDEmpty
+	}
+	if e.SubjectID == "" {
+		return ErrSubjectAccessExportSubjectIDEmpty
+	}
+	if e.RequestedBy == "" {
+		return ErrSubjectAccessExportRequesterEmpty
+	}
+	return nil
+}
+
+// IsFinished checks if the export has completed or failed
+func (e *SubjectAccessExport) IsFinished() bool {
+	return e.Status == SubjectAccessExportStatusCompleted || e.Status == SubjectAccessExportStatusFailed
+}
+
+// ArchiveFileName returns the file name offered when downloading the archive
+func (e *SubjectAccessExport) ArchiveFileName() string {
+	return "subject-access-" + e.ID + ".zip"
+}
+
+// Start marks the export as running from scratch
+func (e *SubjectAccessExport) Start(now time.Time) {
+	e.Status = SubjectAccessExportStatusRunning
+	e.DocumentCount = 0
+	e.AuditEventCount = 0
+	e.Error = ""
+	e.StartedAt = &now
+	e.UpdatedAt = now
+}
+
+// Complete marks the export as completed with the stored archive
+func (e *SubjectAccessExport) Complete(archivePath string, archiveSize int64, now time.Time) {
+	e.Status = SubjectAccessExportStatusCompleted
+	e.ArchivePath = archivePath
+	e.ArchiveSize = archiveSize
+	e.CompletedAt = &now
+	e.UpdatedAt = now
+}
+
+// Fail marks the export as failed with the reason
+func (e *SubjectAccessExport) Fail(reason string, now time.Time) {
+	e.Status = SubjectAccessExportStatusFailed
+	e.Error = reason
+	e.CompletedAt = &now
+	e.UpdatedAt = now
+}
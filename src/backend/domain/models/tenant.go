@@ -2,8 +2,9 @@
 package models
 
 import (
-	"errors" // standard library - For error handling in validation methods
-	"time"   // standard library - For timestamp fields like CreatedAt and UpdatedAt
+	"errors"  // standard library - For error handling in validation methods
+	"strconv" // standard library - For validating numeric settings
+	"time"    // standard library - For timestamp fields like CreatedAt and UpdatedAt
 )
 
 // Tenant status constants
@@ -13,11 +14,74 @@ const (
 	TenantStatusInactive  = "inactive"
 )
 
+// AuditResourceTenant is the resource type recorded for tenant configuration changes
+const AuditResourceTenant = "tenant"
+
 // Error constants for tenant-related validation errors
 var (
-	ErrTenantNameEmpty = errors.New("tenant name cannot be empty")
+	ErrTenantNameEmpty              = errors.New("tenant name cannot be empty")
+	ErrTenantSettingNotConfigurable = errors.New("tenant setting cannot be configured")
+	ErrTenantSettingInvalid         = errors.New("tenant setting value is invalid")
 )
 
+// Kinds of values configurable tenant settings take
+const (
+	tenantSettingBool       = "bool"
+	tenantSettingInt        = "int"
+	tenantSettingKMSKeyARN  = "kms_key_arn"
+	tenantSettingEngines    = "scan_engines"
+	tenantSettingDetectors  = "pii_detectors"
+	tenantSettingNetworks   = "networks"
+	tenantSettingCountries  = "countries"
+	tenantSettingMIMETypes  = "mime_types"
+	tenantSettingExtensions = "extensions"
+	tenantSettingLogoURL    = "logo_url"
+	tenantSettingLanguage   = "language"
+	tenantSettingSender     = "sender"
+	tenantSettingLinkExpiry = "link_expiry"
+)
+
+// configurableTenantSettings lists the settings tenant administrators can change and the kind of value of each
+var configurableTenantSettings = map[string]string{
+	TenantSettingMFARequired:              tenantSettingBool,
+	TenantSettingPasswordMinLength:        tenantSettingInt,
+	TenantSettingPasswordRequireUppercase: tenantSettingBool,
+	TenantSettingPasswordRequireLowercase: tenantSettingBool,
+	TenantSettingPasswordRequireDigit:     tenantSettingBool,
+	TenantSettingPasswordRequireSymbol:    tenantSettingBool,
+	TenantSettingPasswordHistory:          tenantSettingInt,
+	TenantSettingPasswordMaxAgeDays:       tenantSettingInt,
+	TenantSettingLockoutThreshold:         tenantSettingInt,
+	TenantSettingLockoutWindowMinutes:     tenantSettingInt,
+	TenantSettingLockoutDurationMinutes:   tenantSettingInt,
+	TenantSettingEncryptionKeyID:          tenantSettingKMSKeyARN,
+	TenantSettingScanEngines:              tenantSettingEngines,
+	TenantSettingScanVerdictCacheDisabled: tenantSettingBool,
+	TenantSettingPIIDetectors:             tenantSettingDetectors,
+	TenantSettingPIIRestrictSharing:       tenantSettingBool,
+	TenantSettingIPAllowlist:              tenantSettingNetworks,
+	TenantSettingBlockedCountries:         tenantSettingCountries,
+	TenantSettingAllowedMIMETypes:         tenantSettingMIMETypes,
+	TenantSettingBlockedMIMETypes:         tenantSettingMIMETypes,
+	TenantSettingAllowedExtensions:        tenantSettingExtensions,
+	TenantSettingBlockedExtensions:        tenantSettingExtensions,
+	TenantSettingMaxFileSizeBytes:         tenantSettingInt,
+	TenantSettingLogoURL:                  tenantSettingLogoURL,
+	TenantSettingDefaultLanguage:          tenantSettingLanguage,
+	TenantSettingNotificationSender:       tenantSettingSender,
+	TenantSettingShareLinkExpiryHours:     tenantSettingLinkExpiry,
+}
+
+// TenantUsage summarizes the resources a tenant consumes
+type TenantUsage struct {
+	Users           int64 // Number of user accounts
+	ActiveUsers     int64 // Number of active user accounts
+	Documents       int64 // Number of documents
+	StorageBytes    int64 // Storage taken up by all document versions, in bytes
+	MaxDocuments    int64 // Document quota, 0 when unlimited
+	MaxStorageBytes int64 // Storage quota in bytes, 0 when unlimited
+}
+
 // Tenant represents a customer organization in the document management platform.
 // It serves as the foundation for multi-tenancy, ensuring complete data isolation
 // between different customer organizations.
@@ -50,6 +114,13 @@ func (t *Tenant) Validate() error {
 	return nil
 }
 
+// StoragePrefix returns the prefix the documents of the tenant are stored under in the documents
+// bucket. Objects are stored under prefixes derived from the tenant ID, so there is nothing to
+// create in storage for a new tenant.
+func (t *Tenant) StoragePrefix() string {
+	return t.ID + "/"
+}
+
 // IsActive checks if the tenant is in active status
 func (t *Tenant) IsActive() bool {
 	return t.Status == TenantStatusActive
@@ -122,4 +193,77 @@ func (t *Tenant) HasSetting(key string) bool {
 	}
 	_, exists := t.Settings[key]
 	return exists
-}
\ No newline at end of file
+}
+
+// ValidateTenantSetting checks that a setting can be configured by tenant administrators and that
+// its value is valid. An empty value is valid for every configurable setting and restores its default.
+func ValidateTenantSetting(key, value string) error {
+	kind, ok := configurableTenantSettings[key]
+	if !ok {
+		return ErrTenantSettingNotConfigurable
+	}
+	if value == "" {
+		return nil
+	}
+
+	switch kind {
+	case tenantSettingBool:
+		if value != "true" && value != "false" {
+			return ErrTenantSettingInvalid
+		}
+	case tenantSettingInt:
+		if n, err := strconv.Atoi(value); err != nil || n < 0 {
+			return ErrTenantSettingInvalid
+		}
+	case tenantSettingKMSKeyARN:
+		if !IsKMSKeyARN(value) {
+			return ErrTenantSettingInvalid
+		}
+	case tenantSettingEngines:
+		if !IsScanEngineList(value) {
+			return ErrTenantSettingInvalid
+		}
+	case tenantSettingDetectors:
+		if !IsPIIDetectorList(value) {
+			return ErrTenantSettingInvalid
+		}
+	case tenantSettingNetworks:
+		if !IsNetworkList(value) {
+			return ErrTenantSettingInvalid
+		}
+	case tenantSettingCountries:
+		if !IsCountryCodeList(value) {
+			return ErrTenantSettingInvalid
+		}
+	case tenantSettingMIMETypes:
+		if !IsMIMETypeList(value) {
+			return ErrTenantSettingInvalid
+		}
+	case tenantSettingExtensions:
+		if !IsExtensionList(value) {
+			return ErrTenantSettingInvalid
+		}
+	case tenantSettingLogoURL:
+		if !IsLogoURL(value) {
+			return ErrTenantSettingInvalid
+		}
+	case tenantSettingLanguage:
+		if !IsLanguageTag(value) {
+			return ErrTenantSettingInvalid
+		}
+	case tenantSettingSender:
+		if !IsNotificationSender(value) {
+			return ErrTenantSettingInvalid
+		}
+	case tenantSettingLinkExpiry:
+		if hours, err := strconv.Atoi(value); err != nil || !IsShareLinkExpiryHours(hours) {
+			return ErrTenantSettingInvalid
+		}
+	}
+	return nil
+}
+
+// RequiresMFA checks if the tenant requires every user to sign in with a second factor
+func (t *Tenant) RequiresMFA() bool {
+	return t.GetSetting(TenantSettingMFARequired) == "true"
+}
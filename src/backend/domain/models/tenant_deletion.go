@@ -0,0 +1,174 @@
+// Package models defines the core domain models for the document management platform
+package models
+
+import (
+	"errors" // standard library - For tenant deletion validation
+	"time"   // standard library - For timestamp fields
+)
+
+// TenantDeletion status constants
+const (
+	TenantDeletionStatusScheduled = "scheduled"
+	TenantDeletionStatusRunning   = "running"
+	TenantDeletionStatusCompleted = "completed"
+	TenantDeletionStatusFailed    = "failed"
+	TenantDeletionStatusCancelled = "cancelled"
+)
+
+// Error variables for tenant deletion validation
+var (
+	ErrTenantDeletionRequesterEmpty = errors.New("tenant deletion requester cannot be empty")
+	ErrTenantDeletionReasonEmpty    = errors.New("tenant deletion reason cannot be empty")
+	ErrTenantDeletionNotCancellable = errors.New("only tenant deletions that have not erased anything yet can be cancelled")
+)
+
+// TenantDeletion is the offboarding of a tenant. The tenant is frozen when the deletion is
+// scheduled and erased by the worker once the grace period is over, unless the deletion is
+// cancelled before. The deletion outlives the tenant, keeping the signed destruction report as
+// evidence of the erasure.
+type TenantDeletion struct {
+	ID              string     `json:"id"`
+	TenantID        string     `json:"tenant_id"`
+	TenantName      string     `json:"tenant_name"`
+	PreviousStatus  string     `json:"previous_status"`
+	RequestedBy     string     `json:"requested_by"`
+	Reason          string     `json:"reason"`
+	Status          string     `json:"status"`
+	ScheduledFor    time.Time  `json:"scheduled_for"`
+	Report          string     `json:"report"`
+	ReportSignature string     `json:"report_signature"`
+	SigningKeyID    string     `json:"signing_key_id"`
+	Error           string     `json:"error"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	StartedAt       *time.Time `json:"started_at"`
+	CompletedAt     *time.Time `json:"completed_at"`
+}
+
+// NewTenantDeletion creates a deletion of a tenant scheduled once its grace period is over
+func NewTenantDeletion(tenant *Tenant, requestedBy, reason string, gracePeriod time.Duration) *TenantDeletion {
+	now := time.Now()
+	return &TenantDeletion{
+		TenantID:       tenant.ID,
+		TenantName:     tenant.Name,
+		PreviousStatus: tenant.Status,
+		RequestedBy:    requestedBy,
+		Reason:         reason,
+		Status:         TenantDeletionStatusScheduled,
+		ScheduledFor:   now.Add(gracePeriod),
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+}
+
+// Validate checks that the deletion names a tenant, who requested it and why
+func (d *TenantDeletion) Validate() error {
+	if d.TenantID == "" {
+		return ErrTenantIDEmpty
+	}
+	if d.RequestedBy == "" {
+		return ErrTenantDeletionRequesterEmpty
+	}
+	if d.Reason == "" {
+		return ErrTenantDeletionReasonEmpty
+	}
+	return nil
+}
+
+// IsFinished checks if the deletion has completed or was cancelled. Failed deletions are retried.
+func (d *TenantDeletion) IsFinished() bool {
+	return d.Status == TenantDeletionStatusCompleted || d.Status == TenantDeletionStatusCancelled
+}
+
+// Start marks the deletion as running. The report of an earlier attempt is kept, so that the
+// objects and rows it erased are still accounted for once a retry completes.
+func (d *TenantDeletion) Start(now time.Time) {
+	d.Status = TenantDeletionStatusRunning
+	d.Error = ""
+	if d.StartedAt == nil {
+		d.StartedAt = &now
+	}
+	d.UpdatedAt = now
+}
+
+// Complete marks the deletion as completed with its signed destruction report
+func (d *TenantDeletion) Complete(report, signature, signingKeyID string, now time.Time) {
+	d.Status = TenantDeletionStatusCompleted
+	d.Report = report
+	d.ReportSignature = signature
+	d.SigningKeyID = signingKeyID
+	d.CompletedAt = &now
+	d.UpdatedAt = now
+}
+
+// Fail marks the deletion as failed with the reason, to be retried
+func (d *TenantDeletion) Fail(reason string, now time.Time) {
+	d.Status = TenantDeletionStatusFailed
+	d.Error = reason
+	d.UpdatedAt = now
+}
+
+// IsCancellable checks if the deletion has not erased anything of the tenant yet: it is still
+// scheduled, or it failed before its first step was recorded in the report
+func (d *TenantDeletion) IsCancellable() bool {
+	return d.Status == TenantDeletionStatusScheduled || (d.Status == TenantDeletionStatusFailed && d.Report == "")
+}
+
+// Cancel marks a deletion that has not erased anything yet as cancelled with the reason
+func (d *TenantDeletion) Cancel(reason string, now time.Time) error {
+	if !d.IsCancellable() {
+		return ErrTenantDeletionNotCancellable
+	}
+	d.Status = TenantDeletionStatusCancelled
+	d.Error = reason
+	d.CompletedAt = &now
+	d.UpdatedAt = now
+	return nil
+}
+
+// TenantDestructionReport is the evidence of the erasure of a tenant. It is signed once the
+// erasure is verified, so that it can be handed to the customer as proof of the erasure.
+type TenantDestructionReport struct {
+	DeletionID  string    `json:"deletion_id"`
+	TenantID    string    `json:"tenant_id"`
+	TenantName  string    `json:"tenant_name"`
+	RequestedBy string    `json:"requested_by"`
+	Reason      string    `json:"reason"`
+	RequestedAt time.Time `json:"requested_at"`
+	StartedAt   time.Time `json:"started_at"`
+	CompletedAt time.Time `json:"completed_at"`
+
+	// StorageObjects is the number of objects deleted under each storage prefix, counting earlier
+	// versions and replicas
+	StorageObjects map[string]int `json:"storage_objects"`
+
+	// SearchIndexRemoved reports the documents of the tenant were removed from the search index
+	SearchIndexRemoved bool `json:"search_index_removed"`
+
+	// DeadLetters is the number of dead-lettered scan tasks of the tenant discarded
+	DeadLetters int `json:"dead_letters"`
+
+	// DatabaseRows is the number of rows deleted from each table
+	DatabaseRows map[string]int64 `json:"database_rows"`
+
+	// Verification holds what was left of the tenant once it was erased
+	Verification TenantDestructionVerification `json:"verification"`
+
+	// SigningKeyID identifies the key the report is signed with
+	SigningKeyID string `json:"signing_key_id"`
+}
+
+// TenantDestructionVerification is the outcome of looking for the data of a tenant after erasing it
+type TenantDestructionVerification struct {
+	// StorageObjects is the number of objects left under each storage prefix
+	StorageObjects map[string]int `json:"storage_objects"`
+
+	// DeadLetters is the number of dead-lettered scan tasks of the tenant left
+	DeadLetters int `json:"dead_letters"`
+
+	// DatabaseRows is the number of rows of the tenant left in each table keyed by tenant
+	DatabaseRows map[string]int64 `json:"database_rows"`
+
+	// Verified reports that nothing was left
+	Verified bool `json:"verified"`
+}
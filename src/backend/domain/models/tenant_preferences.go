@@ -0,0 +1,123 @@
+// Package models defines the core domain models for the document management platform
+package models
+
+import (
+	"net/url" // standard library - For validating logo URLs
+	"regexp"  // standard library - For recognizing language tags
+	"strconv" // standard library - For the share link expiry setting
+	"strings" // standard library - For normalizing language tags
+	"time"    // standard library - For the share link expiry
+	"unicode" // standard library - For refusing control characters in sender names
+)
+
+// Tenant settings branding what a tenant's users and the people they share with see, and the
+// defaults applied to what they create
+const (
+	// TenantSettingLogoURL is the tenant setting holding the HTTPS URL of the logo shown on pages
+	// of the tenant that are reached without signing in, such as share link pages
+	TenantSettingLogoURL = "logo_url"
+
+	// TenantSettingDefaultLanguage is the tenant setting holding the BCP 47 tag of the language, such
+	// as en or pt-BR, pages and messages are shown in when the user has not chosen one. English when
+	// it is empty.
+	TenantSettingDefaultLanguage = "default_language"
+
+	// TenantSettingNotificationSender is the tenant setting holding the name emails of the tenant are
+	// sent under, such as "Acme Documents". They are sent from the platform's address either way,
+	// so that they pass the recipients' sender checks.
+	TenantSettingNotificationSender = "notification_sender"
+
+	// TenantSettingShareLinkExpiryHours is the tenant setting holding how long share links stay valid
+	// when their creator does not say, in hours. ShareLinkDefaultExpiry when it is empty.
+	TenantSettingShareLinkExpiryHours = "share_link_expiry_hours"
+)
+
+// DefaultTenantLanguage is the language of tenants that have not chosen one
+const DefaultTenantLanguage = "en"
+
+// maxLogoURLLength and maxNotificationSenderLength bound the branding settings
+const (
+	maxLogoURLLength            = 2048
+	maxNotificationSenderLength = 100
+)
+
+// languageTagPattern matches BCP 47 language tags made of a language, an optional script and an
+// optional region, such as en, zh-Hant or es-419
+var languageTagPattern = regexp.MustCompile(`^[a-zA-Z]{2,3}(-[a-zA-Z]{4})?(-([a-zA-Z]{2}|[0-9]{3}))?$`)
+
+// TenantPreferences are the branding of a tenant and the defaults applied to what its users create
+type TenantPreferences struct {
+	TenantName         string        // Name of the tenant, shown next to its logo
+	LogoURL            string        // HTTPS URL of the logo, no logo when empty
+	Language           string        // Language tag pages and messages are shown in by default
+	NotificationSender string        // Name emails are sent under, the platform's when empty
+	ShareLinkExpiry    time.Duration // How long share links stay valid when their creator does not say
+}
+
+// Preferences returns the tenant's branding and defaults, with the platform's defaults for the
+// settings the tenant has not set
+func (t *Tenant) Preferences() TenantPreferences {
+	preferences := TenantPreferences{
+		TenantName:         t.Name,
+		LogoURL:            t.GetSetting(TenantSettingLogoURL),
+		Language:           DefaultTenantLanguage,
+		NotificationSender: t.GetSetting(TenantSettingNotificationSender),
+		ShareLinkExpiry:    ShareLinkDefaultExpiry,
+	}
+	if language := t.GetSetting(TenantSettingDefaultLanguage); IsLanguageTag(language) {
+		preferences.Language = normalizeLanguageTag(language)
+	}
+	if hours, err := strconv.Atoi(t.GetSetting(TenantSettingShareLinkExpiryHours)); err == nil && IsShareLinkExpiryHours(hours) {
+		preferences.ShareLinkExpiry = time.Duration(hours) * time.Hour
+	}
+	return preferences
+}
+
+// IsLogoURL checks if value is an absolute HTTPS URL short enough to be a logo URL. Logos are shown
+// on pages served over HTTPS, where images from plain HTTP URLs would be blocked.
+func IsLogoURL(value string) bool {
+	if len(value) > maxLogoURLLength {
+		return false
+	}
+	parsed, err := url.Parse(value)
+	return err == nil && parsed.Scheme == "https" && parsed.Host != "" && parsed.User == nil
+}
+
+// IsLanguageTag checks if value is a BCP 47 language tag of a language, an optional script and an
+// optional region
+func IsLanguageTag(value string) bool {
+	return languageTagPattern.MatchString(value)
+}
+
+// IsNotificationSender checks if value can be the name emails are sent under. Line breaks and other
+// control characters are refused, since the name ends up in an email header.
+func IsNotificationSender(value string) bool {
+	if strings.TrimSpace(value) == "" || len(value) > maxNotificationSenderLength {
+		return false
+	}
+	for _, r := range value {
+		if unicode.IsControl(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsShareLinkExpiryHours checks if share links can stay valid for the number of hours
+func IsShareLinkExpiryHours(hours int) bool {
+	return hours > 0 && time.Duration(hours)*time.Hour <= ShareLinkMaxExpiry
+}
+
+// normalizeLanguageTag writes a language tag with the casing BCP 47 recommends, such as zh-Hant-TW
+func normalizeLanguageTag(tag string) string {
+	parts := strings.Split(tag, "-")
+	parts[0] = strings.ToLower(parts[0])
+	for i := 1; i < len(parts); i++ {
+		if len(parts[i]) == 4 {
+			parts[i] = strings.ToUpper(parts[i][:1]) + strings.ToLower(parts[i][1:])
+		} else {
+			parts[i] = strings.ToUpper(parts[i])
+		}
+	}
+	return strings.Join(parts, "-")
+}
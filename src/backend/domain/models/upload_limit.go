@@ -0,0 +1,126 @@
+// Package models defines the core domain models for the document management platform
+package models
+
+import (
+	"errors" // standard library - For upload limit violations
+	"time"   // standard library - For timestamp fields and the daily window
+)
+
+// Scopes an upload limit can apply to
+const (
+	UploadLimitScopeUser = "user"
+	UploadLimitScopeRole = "role"
+)
+
+// AuditResourceUploadLimit is the resource type recorded for upload limit changes
+const AuditResourceUploadLimit = "upload_limit"
+
+// Error variables for upload limit validation and violations
+var (
+	ErrUploadLimitScopeInvalid  = errors.New("upload limit scope must be user or role")
+	ErrUploadLimitScopeIDEmpty  = errors.New("upload limit scope ID cannot be empty")
+	ErrUploadLimitNegative      = errors.New("upload limits cannot be negative")
+	ErrFileTooLarge             = errors.New("file exceeds the maximum file size")
+	ErrDailyUploadLimitExceeded = errors.New("upload exceeds the daily upload volume")
+)
+
+// UploadLimit caps the size of single files and the volume a user uploads per day. A limit applies
+// either to one user or to every user holding a role. A zero limit means unlimited.
+type UploadLimit struct {
+	ID                  string    `json:"id"`
+	TenantID            string    `json:"tenant_id"`
+	Scope               string    `json:"scope"`
+	ScopeID             string    `json:"scope_id"` // User ID or role name
+	MaxFileSizeBytes    int64     `json:"max_file_size_bytes"`
+	MaxDailyUploadBytes int64     `json:"max_daily_upload_bytes"`
+	CreatedAt           time.Time `json:"created_at"`
+	UpdatedAt           time.Time `json:"updated_at"`
+}
+
+// NewUploadLimit creates a new UploadLimit for a user or a role
+func NewUploadLimit(tenantID, scope, scopeID string, maxFileSizeBytes, maxDailyUploadBytes int64) *UploadLimit {
+	now := time.Now()
+	return &UploadLimit{
+		TenantID:            tenantID,
+		Scope:               scope,
+		ScopeID:             scopeID,
+		MaxFileSizeBytes:    maxFileSizeBytes,
+		MaxDailyUploadBytes: maxDailyUploadBytes,
+		CreatedAt:           now,
+		UpdatedAt:           now,
+	}
+}
+
+// Validate checks that the upload limit has a valid scope and non-negative limits
+func (l *UploadLimit) Validate() error {
+	if l.TenantID == "" {
+		return ErrTenantIDEmpty
+	}
+	if l.Scope != UploadLimitScopeUser && l.Scope != UploadLimitScopeRole {
+		return ErrUploadLimitScopeInvalid
+	}
+	if l.ScopeID == "" {
+		return ErrUploadLimitScopeIDEmpty
+	}
+	if l.MaxFileSizeBytes < 0 || l.MaxDailyUploadBytes < 0 {
+		return ErrUploadLimitNegative
+	}
+	return nil
+}
+
+// CheckFileSize checks that a file of the given size is within the limit
+func (l *UploadLimit) CheckFileSize(size int64) error {
+	if l.MaxFileSizeBytes > 0 && size > l.MaxFileSizeBytes {
+		return ErrFileTooLarge
+	}
+	return nil
+}
+
+// CheckDailyUpload checks that a file of the given size fits the volume left after the bytes
+// already uploaded today
+func (l *UploadLimit) CheckDailyUpload(uploadedToday, size int64) error {
+	if l.MaxDailyUploadBytes > 0 && uploadedToday+size > l.MaxDailyUploadBytes {
+		return ErrDailyUploadLimitExceeded
+	}
+	return nil
+}
+
+// EffectiveUploadLimit combines the limits that apply to a user. A limit set for the user
+// overrides those of their roles; across roles, the most generous limit applies, so that a
+// restrictive role does not take away what another role allows. It returns nil when no
+// limit applies.
+func EffectiveUploadLimit(limits []*UploadLimit, userID string) *UploadLimit {
+	var effective *UploadLimit
+	for _, limit := range limits {
+		if limit.Scope == UploadLimitScopeUser && limit.ScopeID == userID {
+			return limit
+		}
+		if limit.Scope != UploadLimitScopeRole {
+			continue
+		}
+		if effective == nil {
+			combined := *limit
+			effective = &combined
+			continue
+		}
+		effective.MaxFileSizeBytes = mostGenerousLimit(effective.MaxFileSizeBytes, limit.MaxFileSizeBytes)
+		effective.MaxDailyUploadBytes = mostGenerousLimit(effective.MaxDailyUploadBytes, limit.MaxDailyUploadBytes)
+	}
+	return effective
+}
+
+// UploadDayStart returns the start of the UTC day daily upload volumes are counted from
+func UploadDayStart(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
+
+// mostGenerousLimit returns the larger of two limits, where zero means unlimited
+func mostGenerousLimit(a, b int64) int64 {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,195 @@
+// Package models defines the core domain models for the document management platform
+package models
+
+import (
+	"mime"          // standard library - For dropping the parameters of content types
+	"path/filepath" // standard library - For the extensions of file names
+	"strconv"       // standard library - For the size limit setting
+	"strings"       // standard library - For comparing types and extensions
+)
+
+// Tenant settings restricting the files a tenant's users can upload
+const (
+	// TenantSettingAllowedMIMETypes is the tenant setting listing, separated by commas, the content
+	// types uploads must have, such as application/pdf or image/*. Any type is allowed when it is empty.
+	TenantSettingAllowedMIMETypes = "allowed_mime_types"
+
+	// TenantSettingBlockedMIMETypes is the tenant setting listing, separated by commas, the content
+	// types uploads are refused with. It wins over the allowed types.
+	TenantSettingBlockedMIMETypes = "blocked_mime_types"
+
+	// TenantSettingAllowedExtensions is the tenant setting listing, separated by commas, the file
+	// extensions uploads must have, such as pdf or docx. Any extension is allowed when it is empty.
+	TenantSettingAllowedExtensions = "allowed_extensions"
+
+	// TenantSettingBlockedExtensions is the tenant setting listing, separated by commas, the file
+	// extensions uploads are refused with. It wins over the allowed extensions.
+	TenantSettingBlockedExtensions = "blocked_extensions"
+
+	// TenantSettingMaxFileSizeBytes is the tenant setting holding the size of the largest file any
+	// user of the tenant can upload, in bytes. Files of any size are allowed when it is empty or 0.
+	TenantSettingMaxFileSizeBytes = "max_file_size_bytes"
+)
+
+// UploadPolicy restricts the content types, extensions and sizes of the files a tenant's users
+// can upload, whatever their own upload limits are
+type UploadPolicy struct {
+	AllowedMIMETypes  []string // Lower case types or type/* wildcards uploads must have, any type when empty
+	BlockedMIMETypes  []string // Lower case types or type/* wildcards uploads are refused with
+	AllowedExtensions []string // Lower case extensions without dot uploads must have, any extension when empty
+	BlockedExtensions []string // Lower case extensions without dot uploads are refused with
+	MaxFileSizeBytes  int64    // Size of the largest file, unlimited when 0
+}
+
+// UploadPolicy returns the tenant's upload policy
+func (t *Tenant) UploadPolicy() UploadPolicy {
+	policy := UploadPolicy{
+		AllowedMIMETypes:  parseMIMETypeList(t.GetSetting(TenantSettingAllowedMIMETypes)),
+		BlockedMIMETypes:  parseMIMETypeList(t.GetSetting(TenantSettingBlockedMIMETypes)),
+		AllowedExtensions: parseExtensionList(t.GetSetting(TenantSettingAllowedExtensions)),
+		BlockedExtensions: parseExtensionList(t.GetSetting(TenantSettingBlockedExtensions)),
+	}
+	if maxBytes, err := strconv.ParseInt(t.GetSetting(TenantSettingMaxFileSizeBytes), 10, 64); err == nil && maxBytes > 0 {
+		policy.MaxFileSizeBytes = maxBytes
+	}
+	return policy
+}
+
+// IsEmpty checks if the policy lets any file be uploaded
+func (p UploadPolicy) IsEmpty() bool {
+	return len(p.AllowedMIMETypes) == 0 && len(p.BlockedMIMETypes) == 0 &&
+		len(p.AllowedExtensions) == 0 && len(p.BlockedExtensions) == 0 && p.MaxFileSizeBytes == 0
+}
+
+// AllowsMIMEType checks if files of the content type can be uploaded. Parameters of the type, such
+// as its charset, are ignored.
+func (p UploadPolicy) AllowsMIMEType(contentType string) bool {
+	mimeType := NormalizeMIMEType(contentType)
+	if matchesMIMEType(p.BlockedMIMETypes, mimeType) {
+		return false
+	}
+	return len(p.AllowedMIMETypes) == 0 || matchesMIMEType(p.AllowedMIMETypes, mimeType)
+}
+
+// AllowsExtension checks if files with the name can be uploaded based on its extension. Names
+// without an extension are refused when the policy allows only some extensions.
+func (p UploadPolicy) AllowsExtension(name string) bool {
+	extension := FileExtension(name)
+	if extension != "" && containsString(p.BlockedExtensions, extension) {
+		return false
+	}
+	return len(p.AllowedExtensions) == 0 || containsString(p.AllowedExtensions, extension)
+}
+
+// AllowsSize checks if files of the size in bytes can be uploaded
+func (p UploadPolicy) AllowsSize(size int64) bool {
+	return p.MaxFileSizeBytes == 0 || size <= p.MaxFileSizeBytes
+}
+
+// NormalizeMIMEType returns the lower case type and subtype of a content type without its
+// parameters, such as text/plain for "text/plain; charset=utf-8"
+func NormalizeMIMEType(contentType string) string {
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		return mediaType
+	}
+	return strings.ToLower(strings.TrimSpace(strings.Split(contentType, ";")[0]))
+}
+
+// FileExtension returns the lower case extension of a file name without its dot, empty when the
+// name has none
+func FileExtension(name string) string {
+	return strings.ToLower(strings.TrimPrefix(filepath.Ext(name), "."))
+}
+
+// IsMIMETypeList checks if a value is a comma separated list of content types, such as
+// application/pdf, or wildcards matching every subtype of a type, such as image/*
+func IsMIMETypeList(value string) bool {
+	entries := splitSettingList(value)
+	if len(entries) == 0 {
+		return false
+	}
+	for _, entry := range entries {
+		if !isMIMETypePattern(entry) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsExtensionList checks if a value is a comma separated list of file extensions made of letters
+// and digits, with or without their leading dot
+func IsExtensionList(value string) bool {
+	entries := splitSettingList(value)
+	if len(entries) == 0 {
+		return false
+	}
+	for _, entry := range entries {
+		if !isExtension(strings.TrimPrefix(entry, ".")) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseMIMETypeList splits a list of content types into their normalized form, skipping invalid
+// entries, which settings validation keeps out
+func parseMIMETypeList(value string) []string {
+	var types []string
+	for _, entry := range splitSettingList(value) {
+		if isMIMETypePattern(entry) {
+			types = append(types, strings.ToLower(entry))
+		}
+	}
+	return types
+}
+
+// parseExtensionList splits a list of file extensions into lower case extensions without dot,
+// skipping invalid entries, which settings validation keeps out
+func parseExtensionList(value string) []string {
+	var extensions []string
+	for _, entry := range splitSettingList(value) {
+		if extension := strings.TrimPrefix(entry, "."); isExtension(extension) {
+			extensions = append(extensions, strings.ToLower(extension))
+		}
+	}
+	return extensions
+}
+
+// matchesMIMEType checks if a normalized content type matches one of the types or wildcards
+func matchesMIMEType(patterns []string, mimeType string) bool {
+	for _, pattern := range patterns {
+		if pattern == mimeType {
+			return true
+		}
+		if strings.HasSuffix(pattern, "/*") && strings.HasPrefix(mimeType, strings.TrimSuffix(pattern, "*")) {
+			return true
+		}
+	}
+	return false
+}
+
+// isMIMETypePattern checks if a value is a type and subtype, or a type and the * wildcard, without parameters
+func isMIMETypePattern(value string) bool {
+	mainType, subtype, ok := strings.Cut(value, "/")
+	if !ok || mainType == "" || subtype == "" || strings.ContainsAny(value, "; \t") {
+		return false
+	}
+	if subtype == "*" {
+		return mainType != "*"
+	}
+	_, _, err := mime.ParseMediaType(value)
+	return err == nil
+}
+
+// isExtension checks if a value is a file extension without dot, made of letters and digits
+func isExtension(value string) bool {
+	if value == "" || len(value) > 16 {
+		return false
+	}
+	for _, r := range value {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
+}
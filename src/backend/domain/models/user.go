@@ -16,6 +16,9 @@ const (
 	UserStatusSuspended = "suspended"
 )
 
+// AuditResourceUser is the resource type recorded for user account operations
+const AuditResourceUser = "user"
+
 // Error constants for user validation
 var (
 	ErrUsernameTooShort = errors.New("username must be at least 3 characters long")
@@ -37,9 +40,16 @@ type User struct {
 	PasswordHash string            // Bcrypt hash of the user's password
 	Status       string            // User status: active, inactive, suspended
 	Roles        []string          // User's assigned roles
+	Groups       []string          // IDs of the groups the user is a member of
 	CreatedAt    time.Time         // When the user was created
 	UpdatedAt    time.Time         // When the user was last updated
 	Settings     map[string]string // User-specific settings
+
+	PasswordChangedAt      time.Time  // When the password was last set
+	PasswordHistory        []string   // Bcrypt hashes of previous passwords, most recent first
+	FailedLoginCount       int        // Failed sign-ins within the current lockout window
+	FailedLoginWindowStart *time.Time // When the first failed sign-in of the current lockout window happened
+	LockedUntil            *time.Time // Until when sign-in is refused after too many failed attempts
 }
 
 // NewUser creates a new User with the given username, email, and tenant ID
@@ -52,6 +62,7 @@ func NewUser(username, email, tenantID string) *User {
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 		Roles:     []string{},
+		Groups:    []string{},
 		Settings:  make(map[string]string),
 	}
 }
@@ -86,9 +97,106 @@ func (u *User) SetPassword(password string) error {
 
 	u.PasswordHash = string(hash)
 	u.UpdatedAt = time.Now()
+	u.PasswordChangedAt = u.UpdatedAt
+	return nil
+}
+
+// ChangePassword sets a new password that meets the policy. The new password must not match the current
+// password or any of the policy's number of previous passwords, which are kept as history.
+func (u *User) ChangePassword(password string, policy PasswordPolicy) error {
+	if err := policy.Check(password); err != nil {
+		return err
+	}
+
+	previous := u.PasswordHash
+	if previous != "" {
+		for _, hash := range append([]string{previous}, u.PasswordHistory...) {
+			if bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil {
+				return ErrPasswordReused
+			}
+		}
+	}
+
+	if err := u.SetPassword(password); err != nil {
+		return err
+	}
+
+	// Keep as many previous passwords as the policy checks
+	history := u.PasswordHistory
+	if previous != "" {
+		history = append([]string{previous}, history...)
+	}
+	if len(history) > policy.HistorySize {
+		history = history[:policy.HistorySize]
+	}
+	u.PasswordHistory = history
 	return nil
 }
 
+// ExpirePassword requires the user to change their password at the next sign-in, for passwords
+// set by an administrator
+func (u *User) ExpirePassword() {
+	u.PasswordChangedAt = time.Time{}
+	u.UpdatedAt = time.Now()
+}
+
+// IsPasswordExpired checks if the password has to be changed, because it was expired or is older
+// than the policy allows
+func (u *User) IsPasswordExpired(policy PasswordPolicy, now time.Time) bool {
+	if u.PasswordChangedAt.IsZero() {
+		return u.PasswordHash != ""
+	}
+	if policy.MaxAge <= 0 {
+		return false
+	}
+	return now.After(u.PasswordChangedAt.Add(policy.MaxAge))
+}
+
+// IsLocked checks if sign-in is refused because of too many failed attempts
+func (u *User) IsLocked(now time.Time) bool {
+	return u.LockedUntil != nil && now.Before(*u.LockedUntil)
+}
+
+// RecordFailedLogin counts a failed sign-in and locks the account when the policy's threshold is reached
+// within its window. Returns true if the account was locked by this attempt.
+func (u *User) RecordFailedLogin(policy PasswordPolicy, now time.Time) bool {
+	if policy.LockoutThreshold <= 0 {
+		return false
+	}
+
+	// Attempts outside the window start a new one
+	if u.FailedLoginWindowStart == nil || now.Sub(*u.FailedLoginWindowStart) > policy.LockoutWindow {
+		u.FailedLoginCount = 0
+		u.FailedLoginWindowStart = &now
+	}
+	u.FailedLoginCount++
+	u.UpdatedAt = now
+
+	if u.FailedLoginCount < policy.LockoutThreshold {
+		return false
+	}
+
+	lockedUntil := now.Add(policy.LockoutDuration)
+	u.LockedUntil = &lockedUntil
+	u.FailedLoginCount = 0
+	u.FailedLoginWindowStart = nil
+	return true
+}
+
+// ResetFailedLogins clears the failed sign-in count and any lockout.
+// Returns true if there was anything to clear.
+func (u *User) ResetFailedLogins() bool {
+	if u.FailedLoginCount == 0 && u.FailedLoginWindowStart == nil && u.LockedUntil == nil {
+		return false
+	}
+
+	u.FailedLoginCount = 0
+	u.FailedLoginWindowStart = nil
+	u.LockedUntil = nil
+	u.UpdatedAt = time.Now()
+	return true
+}
+
 // VerifyPassword verifies if the provided password matches the stored hash
 func (u *User) VerifyPassword(password string) (bool, error) {
 	if u.PasswordHash == "" {
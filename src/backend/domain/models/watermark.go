@@ -0,0 +1,39 @@
+// Package models defines the core domain models for the document management platform
+package models
+
+import (
+	"fmt"  // standard library - For formatting the stamped text
+	"time" // standard library - For the download timestamp
+)
+
+// Watermark is the stamp put on a document as it is downloaded, so that a leaked copy can be traced
+// back to the download it came from
+type Watermark struct {
+	Recipient    string    // Who downloaded the document: a user's name and email, or the share link
+	TenantName   string    // Name of the tenant the document was downloaded from
+	DownloadedAt time.Time // When the document was downloaded
+}
+
+// NewUserWatermark creates the watermark of a download by a user
+func NewUserWatermark(user *User, tenant *Tenant, downloadedAt time.Time) Watermark {
+	return Watermark{
+		Recipient:    fmt.Sprintf("%s <%s>", user.Username, user.Email),
+		TenantName:   tenant.Name,
+		DownloadedAt: downloadedAt,
+	}
+}
+
+// NewShareLinkWatermark creates the watermark of a download through a share link, whose recipient
+// is anonymous
+func NewShareLinkWatermark(link *ShareLink, tenant *Tenant, downloadedAt time.Time) Watermark {
+	return Watermark{
+		Recipient:    "share link " + link.ID,
+		TenantName:   tenant.Name,
+		DownloadedAt: downloadedAt,
+	}
+}
+
+// Text returns the line stamped onto every page
+func (w Watermark) Text() string {
+	return fmt.Sprintf("Downloaded by %s - %s - %s", w.Recipient, w.TenantName, w.DownloadedAt.UTC().Format("2006-01-02 15:04 UTC"))
+}
@@ -1,12 +1,23 @@
 package models
 
 import (
-	"crypto/hmac"     // v1.0.0+ - For generating HMAC signatures for webhook payloads
-	"crypto/sha256"   // v1.0.0+ - For SHA-256 hashing in signature generation
+	"crypto/rand"     // v1.0.0+ - For generating webhook signing secrets
 	"encoding/hex"    // v1.0.0+ - For encoding binary signatures to hexadecimal strings
+	"encoding/json"   // v1.0.0+ - For storing event payload snapshots on deliveries
 	"errors"          // v1.0.0+ - For error handling in validation methods
 	"strings"         // v1.0.0+ - For string manipulation operations
 	"time"            // v1.0.0+ - For timestamp fields like CreatedAt and UpdatedAt
+
+	"../../pkg/webhook" // For the X-Signature header format shared with integrators
+)
+
+// Webhook secret constants
+const (
+	// WebhookSecretPrefix makes webhook secrets recognizable in logs and secret scanners
+	WebhookSecretPrefix = "whsec_"
+
+	// WebhookSecretRotationGracePeriod is how long the previous secret keeps signing deliveries after rotation
+	WebhookSecretRotationGracePeriod = 24 * time.Hour
 )
 
 // Webhook status constants
@@ -17,9 +28,10 @@ const (
 
 // WebhookDelivery status constants
 const (
-	WebhookDeliveryStatusPending = "pending"
-	WebhookDeliveryStatusSuccess = "success"
-	WebhookDeliveryStatusFailed  = "failed"
+	WebhookDeliveryStatusPending      = "pending"
+	WebhookDeliveryStatusSuccess      = "success"
+	WebhookDeliveryStatusFailed       = "failed"
+	WebhookDeliveryStatusDeadLettered = "dead_lettered" // retries exhausted, kept for inspection
 )
 
 // Error variables for webhook validation
@@ -27,21 +39,26 @@ var (
 	ErrWebhookURLEmpty         = errors.New("webhook URL cannot be empty")
 	ErrWebhookNoEventTypes     = errors.New("webhook must subscribe to at least one event type")
 	ErrWebhookInvalidEventType = errors.New("webhook contains invalid event type")
+
+	ErrWebhookDeliveryInvalidStatus = errors.New("delivery status filter must be one of pending, success, failed, dead_lettered")
+	ErrWebhookDeliveryInvalidRange  = errors.New("delivery time range end must not be before its start")
 )
 
 // Webhook represents a webhook subscription for receiving event notifications
 type Webhook struct {
-	ID             string     `json:"id"`
-	TenantID       string     `json:"tenant_id"`
-	URL            string     `json:"url"`
-	EventTypes     []string   `json:"event_types"`
-	SecretKey      string     `json:"secret_key"`
-	Description    string     `json:"description"`
-	Status         string     `json:"status"`
-	CreatedAt      time.Time  `json:"created_at"`
-	UpdatedAt      time.Time  `json:"updated_at"`
-	FailureCount   int        `json:"failure_count"`
-	LastFailureTime time.Time `json:"last_failure_time"`
+	ID                string    `json:"id"`
+	TenantID          string    `json:"tenant_id"`
+	URL               string    `json:"url"`
+	EventTypes        []string  `json:"event_types"`
+	SecretKey         string    `json:"secret_key"`
+	PreviousSecretKey string    `json:"previous_secret_key"`
+	SecretRotatedAt   time.Time `json:"secret_rotated_at"`
+	Description       string    `json:"description"`
+	Status            string    `json:"status"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+	FailureCount      int       `json:"failure_count"`
+	LastFailureTime   time.Time `json:"last_failure_time"`
 }
 
 // Validate validates that the webhook has all required fields
@@ -96,11 +113,45 @@ func (w *Webhook) ShouldProcessEvent(eventType string) bool {
 	return false
 }
 
-// GenerateSignatureForPayload generates an HMAC-SHA256 signature for a payload
-func (w *Webhook) GenerateSignatureForPayload(payload []byte) string {
-	h := hmac.New(sha256.New, []byte(w.SecretKey))
-	h.Write(payload)
-	return hex.EncodeToString(h.Sum(nil))
+// SigningSecrets returns the secrets that should sign a delivery at the given time:
+// the current secret, plus the previous one while the rotation grace period is running
+func (w *Webhook) SigningSecrets(now time.Time) []string {
+	secrets := []string{w.SecretKey}
+	if w.PreviousSecretKey != "" && now.Before(w.SecretRotatedAt.Add(WebhookSecretRotationGracePeriod)) {
+		secrets = append(secrets, w.PreviousSecretKey)
+	}
+	return secrets
+}
+
+// SignatureHeaderForPayload builds the X-Signature header value for a payload
+func (w *Webhook) SignatureHeaderForPayload(payload []byte, timestamp time.Time) string {
+	return webhook.BuildSignatureHeader(payload, timestamp, w.SigningSecrets(timestamp)...)
+}
+
+// RotateSecret replaces the signing secret with a newly generated one and keeps the
+// old secret as the previous secret for the rotation grace period
+func (w *Webhook) RotateSecret() (string, error) {
+	secret, err := GenerateWebhookSecret()
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	w.PreviousSecretKey = w.SecretKey
+	w.SecretKey = secret
+	w.SecretRotatedAt = now
+	w.UpdatedAt = now
+
+	return secret, nil
+}
+
+// GenerateWebhookSecret generates a new random webhook signing secret
+func GenerateWebhookSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return WebhookSecretPrefix + hex.EncodeToString(buf), nil
 }
 
 // RecordDeliverySuccess records a successful delivery attempt
@@ -124,17 +175,62 @@ func (w *Webhook) RecordDeliveryFailure() {
 
 // WebhookDelivery represents a webhook delivery attempt for an event
 type WebhookDelivery struct {
+	ID             string          `json:"id"`
+	WebhookID      string          `json:"webhook_id"`
+	EventID        string          `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	AttemptCount   int             `json:"attempt_count"`
+	ResponseStatus int             `json:"response_status"`
+	ResponseBody   string          `json:"response_body"`
+	ErrorMessage   string          `json:"error_message"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+	CompletedAt    time.Time       `json:"completed_at"`
+	RequestID      string          `json:"request_id"`
+}
+
+// WebhookDeliveryAttempt records the outcome of a single HTTP attempt for a delivery
+type WebhookDeliveryAttempt struct {
 	ID             string    `json:"id"`
-	WebhookID      string    `json:"webhook_id"`
-	EventID        string    `json:"event_id"`
-	Status         string    `json:"status"`
-	AttemptCount   int       `json:"attempt_count"`
+	DeliveryID     string    `json:"delivery_id"`
+	AttemptNumber  int       `json:"attempt_number"`
 	ResponseStatus int       `json:"response_status"`
 	ResponseBody   string    `json:"response_body"`
 	ErrorMessage   string    `json:"error_message"`
-	CreatedAt      time.Time `json:"created_at"`
-	UpdatedAt      time.Time `json:"updated_at"`
-	CompletedAt    time.Time `json:"completed_at"`
+	DurationMs     int64     `json:"duration_ms"`
+	AttemptedAt    time.Time `json:"attempted_at"`
+}
+
+// Succeeded checks if the attempt received a 2xx response
+func (a *WebhookDeliveryAttempt) Succeeded() bool {
+	return a.ErrorMessage == "" && a.ResponseStatus >= 200 && a.ResponseStatus < 300
+}
+
+// WebhookDeliveryFilter narrows a delivery log query. Zero values are not applied.
+type WebhookDeliveryFilter struct {
+	WebhookID string
+	Status    string
+	From      time.Time
+	To        time.Time
+}
+
+// Validate validates the delivery filter
+func (f WebhookDeliveryFilter) Validate() error {
+	switch f.Status {
+	case "", WebhookDeliveryStatusPending, WebhookDeliveryStatusSuccess,
+		WebhookDeliveryStatusFailed, WebhookDeliveryStatusDeadLettered:
+	default:
+		return ErrWebhookDeliveryInvalidStatus
+	}
+
+	if !f.From.IsZero() && !f.To.IsZero() && f.To.Before(f.From) {
+		return ErrWebhookDeliveryInvalidRange
+	}
+
+	return nil
 }
 
 // MarkAsSuccess marks the delivery as successful
@@ -156,15 +252,43 @@ func (d *WebhookDelivery) MarkAsFailed(statusCode int, responseBody, errorMessag
 	d.UpdatedAt = time.Now()
 }
 
+// ScheduleRetry marks the delivery as failed and records when it should be attempted again
+func (d *WebhookDelivery) ScheduleRetry(statusCode int, responseBody, errorMessage string, nextAttemptAt time.Time) {
+	d.Status = WebhookDeliveryStatusFailed
+	d.ResponseStatus = statusCode
+	d.ResponseBody = responseBody
+	d.ErrorMessage = errorMessage
+	d.NextAttemptAt = nextAttemptAt
+	d.UpdatedAt = time.Now()
+}
+
+// MarkAsDeadLettered moves the delivery to the dead-letter state after retries are exhausted
+func (d *WebhookDelivery) MarkAsDeadLettered(statusCode int, responseBody, errorMessage string) {
+	d.Status = WebhookDeliveryStatusDeadLettered
+	d.ResponseStatus = statusCode
+	d.ResponseBody = responseBody
+	d.ErrorMessage = errorMessage
+	d.NextAttemptAt = time.Time{}
+	d.CompletedAt = time.Now()
+	d.UpdatedAt = time.Now()
+}
+
+// IsDueForRetry checks if a failed delivery has reached its scheduled retry time
+func (d *WebhookDelivery) IsDueForRetry(now time.Time) bool {
+	return d.IsFailed() && !d.NextAttemptAt.After(now)
+}
+
 // IncrementAttempt increments the attempt count for retries
 func (d *WebhookDelivery) IncrementAttempt() {
 	d.AttemptCount++
 	d.UpdatedAt = time.Now()
 }
 
-// IsCompleted checks if the delivery is completed (success or failure)
+// IsCompleted checks if the delivery reached a final state: delivered, or dead-lettered after its
+// retries were exhausted. Failed deliveries are still retried.
 func (d *WebhookDelivery) IsCompleted() bool {
-	return d.Status == WebhookDeliveryStatusSuccess || d.Status == WebhookDeliveryStatusFailed
+	return d.Status == WebhookDeliveryStatusSuccess ||
+		d.Status == WebhookDeliveryStatusDeadLettered
 }
 
 // IsPending checks if the delivery is still pending
@@ -182,6 +306,11 @@ func (d *WebhookDelivery) IsFailed() bool {
 	return d.Status == WebhookDeliveryStatusFailed
 }
 
+// IsDeadLettered checks if the delivery was moved to the dead-letter state
+func (d *WebhookDelivery) IsDeadLettered() bool {
+	return d.Status == WebhookDeliveryStatusDeadLettered
+}
+
 // NewWebhook creates a new Webhook instance with the given parameters
 func NewWebhook(url, tenantID string, eventTypes []string) (*Webhook, error) {
 	if strings.TrimSpace(url) == "" {
@@ -199,9 +328,10 @@ func NewWebhook(url, tenantID string, eventTypes []string) (*Webhook, error) {
 	// In a real implementation, we would validate event types against a list of known types
 	// and return ErrWebhookInvalidEventType if any are invalid
 	
-	// In a real implementation, this would generate a secure random key
-	// For example, using crypto/rand to generate a random string
-	secretKey := "secure-random-key" // Placeholder for demonstration
+	secretKey, err := GenerateWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
 	
 	now := time.Now()
 	
@@ -228,4 +358,25 @@ func NewWebhookDelivery(webhookID, eventID string) *WebhookDelivery {
 		CreatedAt:    now,
 		UpdatedAt:    now,
 	}
+}
+
+// NewWebhookDeliveryForEvent creates a delivery that carries a snapshot of the event,
+// so the retry scheduler can re-send it without looking the event up again
+func NewWebhookDeliveryForEvent(webhookID string, event *Event) *WebhookDelivery {
+	delivery := NewWebhookDelivery(webhookID, event.ID)
+	delivery.EventType = event.Type
+	delivery.Payload = event.Payload
+	delivery.RequestID = event.RequestID
+	return delivery
+}
+
+// ToEvent rebuilds the event that this delivery carries
+func (d *WebhookDelivery) ToEvent(tenantID string) *Event {
+	return &Event{
+		ID:        d.EventID,
+		Type:      d.EventType,
+		TenantID:  tenantID,
+		Payload:   d.Payload,
+		RequestID: d.RequestID,
+	}
 }
\ No newline at end of file
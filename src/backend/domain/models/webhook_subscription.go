@@ -0,0 +1,155 @@
+// Package models defines the core domain models for the document management platform
+package models
+
+import (
+	"encoding/json" // v1.0.0+ - For building sample event payloads
+	"errors"        // v1.0.0+ - For error handling in validation methods
+	"fmt"           // v1.0.0+ - For comparing metadata values of arbitrary type
+	"strings"       // v1.0.0+ - For string manipulation operations
+	"time"          // v1.0.0+ - For timestamp fields like CreatedAt and UpdatedAt
+)
+
+// Error variables for webhook subscription validation
+var (
+	ErrSubscriptionWebhookIDEmpty = errors.New("subscription webhook ID cannot be empty")
+	ErrSubscriptionTenantIDEmpty  = errors.New("subscription tenant ID cannot be empty")
+)
+
+// WebhookSubscription narrows the events a webhook receives to specific event types,
+// folder scopes and metadata values. A webhook without subscriptions receives every
+// event it is registered for; a webhook with subscriptions only receives events that
+// match at least one of them.
+type WebhookSubscription struct {
+	ID              string            `json:"id"`
+	WebhookID       string            `json:"webhook_id"`
+	TenantID        string            `json:"tenant_id"`
+	EventTypes      []string          `json:"event_types"`
+	FolderIDs       []string          `json:"folder_ids"`
+	MetadataFilters map[string]string `json:"metadata_filters"`
+	CreatedAt       time.Time         `json:"created_at"`
+	UpdatedAt       time.Time         `json:"updated_at"`
+}
+
+// NewWebhookSubscription creates a new WebhookSubscription instance for a webhook
+func NewWebhookSubscription(webhookID, tenantID string, eventTypes, folderIDs []string, metadataFilters map[string]string) (*WebhookSubscription, error) {
+	now := time.Now()
+
+	subscription := &WebhookSubscription{
+		WebhookID:       webhookID,
+		TenantID:        tenantID,
+		EventTypes:      eventTypes,
+		FolderIDs:       folderIDs,
+		MetadataFilters: metadataFilters,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+
+	if err := subscription.Validate(); err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+// Validate validates that the subscription has all required fields
+func (s *WebhookSubscription) Validate() error {
+	if strings.TrimSpace(s.WebhookID) == "" {
+		return ErrSubscriptionWebhookIDEmpty
+	}
+
+	if strings.TrimSpace(s.TenantID) == "" {
+		return ErrSubscriptionTenantIDEmpty
+	}
+
+	return nil
+}
+
+// Matches checks whether an event satisfies every filter on the subscription.
+// Empty filters match everything.
+func (s *WebhookSubscription) Matches(event *Event) bool {
+	if event == nil {
+		return false
+	}
+
+	if len(s.EventTypes) > 0 && !containsString(s.EventTypes, event.Type) {
+		return false
+	}
+
+	if len(s.FolderIDs) == 0 && len(s.MetadataFilters) == 0 {
+		return true
+	}
+
+	payload, err := event.GetPayloadAsMap()
+	if err != nil {
+		return false
+	}
+
+	if len(s.FolderIDs) > 0 {
+		folderID, _ := payload["folderID"].(string)
+		if folderID == "" || !containsString(s.FolderIDs, folderID) {
+			return false
+		}
+	}
+
+	if len(s.MetadataFilters) > 0 {
+		metadata, _ := payload["metadata"].(map[string]interface{})
+		for key, expected := range s.MetadataFilters {
+			value, ok := metadata[key]
+			if !ok || fmt.Sprint(value) != expected {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// MatchesAnySubscription checks whether an event should be delivered given a webhook's
+// subscriptions. An empty subscription list matches every event.
+func MatchesAnySubscription(subscriptions []*WebhookSubscription, event *Event) bool {
+	if len(subscriptions) == 0 {
+		return true
+	}
+
+	for _, subscription := range subscriptions {
+		if subscription.Matches(event) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NewSampleEvent creates an event with a representative payload for test deliveries
+func NewSampleEvent(eventType string, tenantID string) (*Event, error) {
+	payload := map[string]interface{}{
+		"test":       true,
+		"documentID": "00000000-0000-0000-0000-000000000001",
+		"folderID":   "00000000-0000-0000-0000-000000000002",
+		"metadata": map[string]interface{}{
+			"sample": "true",
+		},
+	}
+
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	event := NewEvent(eventType, tenantID, jsonPayload)
+	if event == nil {
+		return nil, errors.New("failed to create sample event")
+	}
+
+	return event, nil
+}
+
+// containsString checks whether a slice contains a value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert" // v1.8.0+
+)
+
+// TestWebhookDeliveryIsCompleted tests that only delivered and dead-lettered deliveries are final
+func TestWebhookDeliveryIsCompleted(t *testing.T) {
+	tests := []struct {
+		name     string
+		update   func(d *WebhookDelivery)
+		expected bool
+	}{
+		{"Pending", func(d *WebhookDelivery) {}, false},
+		{"Delivered", func(d *WebhookDelivery) { d.MarkAsSuccess(200, "ok") }, true},
+		{"Failed", func(d *WebhookDelivery) { d.MarkAsFailed(500, "error", "server error") }, false},
+		{"Retry scheduled", func(d *WebhookDelivery) {
+			d.ScheduleRetry(503, "unavailable", "service unavailable", time.Now().Add(time.Minute))
+		}, false},
+		{"Dead-lettered", func(d *WebhookDelivery) { d.MarkAsDeadLettered(500, "error", "retries exhausted") }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delivery := &WebhookDelivery{Status: WebhookDeliveryStatusPending}
+			tt.update(delivery)
+
+			assert.Equal(t, tt.expected, delivery.IsCompleted())
+		})
+	}
+}
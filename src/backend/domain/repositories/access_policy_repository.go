@@ -0,0 +1,30 @@
+// Package repositories defines the repository interfaces for domain persistence operations
+package repositories
+
+import (
+	"context" // standard library - For context propagation in repository operations
+
+	"../models"       // To reference the AccessPolicy domain model
+	"../../pkg/utils" // For pagination support in repository methods
+)
+
+// AccessPolicyRepository defines the contract for persisting a tenant's attribute-based access policies
+type AccessPolicyRepository interface {
+	// Create persists a new access policy
+	Create(ctx context.Context, policy *models.AccessPolicy) (string, error)
+
+	// GetByID retrieves an access policy by its ID with tenant isolation
+	GetByID(ctx context.Context, id string, tenantID string) (*models.AccessPolicy, error)
+
+	// Update updates an existing access policy
+	Update(ctx context.Context, policy *models.AccessPolicy) error
+
+	// Delete deletes an access policy with tenant isolation
+	Delete(ctx context.Context, id string, tenantID string) error
+
+	// List lists a tenant's access policies with pagination
+	List(ctx context.Context, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.AccessPolicy], error)
+
+	// ListEnabled lists all enabled access policies of a tenant for evaluation
+	ListEnabled(ctx context.Context, tenantID string) ([]*models.AccessPolicy, error)
+}
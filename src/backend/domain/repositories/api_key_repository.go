@@ -0,0 +1,36 @@
+// Package repositories defines the repository interfaces for domain persistence operations
+package repositories
+
+import (
+	"context" // standard library - For context propagation in repository operations
+	"time"    // standard library - For last used timestamps
+
+	"../models"       // To reference the APIKey domain model
+	"../../pkg/utils" // For pagination support in repository methods
+)
+
+// APIKeyRepository defines the contract for persisting API keys
+type APIKeyRepository interface {
+	// Create persists a new API key
+	Create(ctx context.Context, key *models.APIKey) (string, error)
+
+	// GetByID retrieves an API key by its ID with tenant isolation
+	GetByID(ctx context.Context, id string, tenantID string) (*models.APIKey, error)
+
+	// GetByKeyHash retrieves an API key by the hash of its secret. This lookup is not tenant
+	// scoped because it authenticates requests; the key identifies the tenant.
+	GetByKeyHash(ctx context.Context, keyHash string) (*models.APIKey, error)
+
+	// List lists a tenant's API keys with pagination, newest first
+	List(ctx context.Context, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.APIKey], error)
+
+	// UpdateSecret stores a rotated key's new hash, display prefix and rotation time with tenant isolation.
+	// Revoked keys cannot be rotated.
+	UpdateSecret(ctx context.Context, key *models.APIKey) error
+
+	// Revoke marks an API key as revoked with tenant isolation
+	Revoke(ctx context.Context, id string, tenantID string) error
+
+	// RecordUsage stores when an API key last authenticated a request
+	RecordUsage(ctx context.Context, id string, usedAt time.Time) error
+}
@@ -0,0 +1,54 @@
+// Package repositories defines the repository interfaces for domain persistence operations
+package repositories
+
+import (
+	"context" // standard library - For context propagation in repository operations
+
+	"../models"       // To reference the approval domain models
+	"../../pkg/utils" // For pagination support in repository methods
+)
+
+// ApprovalWorkflowRepository defines the contract for persisting the approval workflows of folders
+type ApprovalWorkflowRepository interface {
+	// Create persists a new approval workflow
+	Create(ctx context.Context, workflow *models.ApprovalWorkflow) (string, error)
+
+	// GetByID retrieves an approval workflow by its ID with tenant isolation
+	GetByID(ctx context.Context, id string, tenantID string) (*models.ApprovalWorkflow, error)
+
+	// GetByFolder retrieves the approval workflow of a folder, returning a not found error if the
+	// folder has none
+	GetByFolder(ctx context.Context, folderID string, tenantID string) (*models.ApprovalWorkflow, error)
+
+	// Update updates the name, mode and approvers of an existing approval workflow
+	Update(ctx context.Context, workflow *models.ApprovalWorkflow) error
+
+	// Delete deletes an approval workflow with tenant isolation; requests already submitted through
+	// it are kept
+	Delete(ctx context.Context, id string, tenantID string) error
+
+	// ListByTenant lists all approval workflows of a tenant ordered by name
+	ListByTenant(ctx context.Context, tenantID string) ([]*models.ApprovalWorkflow, error)
+}
+
+// ApprovalRequestRepository defines the contract for persisting approval requests and the decisions
+// of their approvers. Writes join the transaction carried by ctx, if any, so that a transition,
+// the lock of its document and its event commit together.
+type ApprovalRequestRepository interface {
+	// Create persists a new approval request together with its pending decisions
+	Create(ctx context.Context, request *models.ApprovalRequest) (string, error)
+
+	// GetByID retrieves an approval request with its decisions by its ID with tenant isolation
+	GetByID(ctx context.Context, id string, tenantID string) (*models.ApprovalRequest, error)
+
+	// Update persists the status of an approval request and the decisions of its approvers
+	Update(ctx context.Context, request *models.ApprovalRequest) error
+
+	// ListByDocument lists the approval requests of a document with their decisions, newest first
+	ListByDocument(ctx context.Context, documentID string, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.ApprovalRequest], error)
+
+	// ListAwaitingApprover lists the pending approval requests waiting for a decision of the
+	// approver, oldest first: every request of a parallel chain the approver has not decided on, and
+	// the requests of sequential chains where it is the approver's turn
+	ListAwaitingApprover(ctx context.Context, approverID string, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.ApprovalRequest], error)
+}
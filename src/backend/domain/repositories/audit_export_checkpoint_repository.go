@@ -0,0 +1,18 @@
+// Package repositories defines the repository interfaces for domain persistence operations
+package repositories
+
+import (
+	"context" // standard library - For context propagation in repository operations
+
+	"../models" // To reference the AuditExportCheckpoint domain model
+)
+
+// AuditExportCheckpointRepository defines the contract for persisting audit exporter progress
+type AuditExportCheckpointRepository interface {
+	// Get retrieves the checkpoint of an exporter. An empty checkpoint is returned when
+	// the exporter has not forwarded any entries yet.
+	Get(ctx context.Context, exporter string) (*models.AuditExportCheckpoint, error)
+
+	// Save creates or updates the checkpoint of an exporter
+	Save(ctx context.Context, checkpoint *models.AuditExportCheckpoint) error
+}
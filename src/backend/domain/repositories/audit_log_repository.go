@@ -0,0 +1,39 @@
+// Package repositories defines the repository interfaces for domain persistence operations
+package repositories
+
+import (
+	"context" // standard library - For context propagation in repository operations
+	"time"    // standard library - For partition maintenance
+
+	"../models"       // To reference the AuditLog domain model
+	"../../pkg/utils" // For pagination support in repository methods
+)
+
+// AuditLogRepository defines the contract for audit log persistence and retrieval.
+// Audit logs are append-only; there are no update or delete operations.
+type AuditLogRepository interface {
+	// Create persists a new audit log entry, joining the transaction carried by ctx if any
+	Create(ctx context.Context, entry *models.AuditLog) (string, error)
+
+	// GetByID retrieves an audit log entry by its ID with tenant isolation
+	GetByID(ctx context.Context, id string, tenantID string) (*models.AuditLog, error)
+
+	// List lists a tenant's audit log entries matching the filter, newest first, with pagination
+	List(ctx context.Context, tenantID string, filter models.AuditLogFilter, pagination *utils.Pagination) (utils.PaginatedResult[models.AuditLog], error)
+
+	// ListAfter lists entries of all tenants positioned after the checkpoint in (occurred_at, id) order
+	// that occurred before until. It is used to forward the audit log to external systems.
+	ListAfter(ctx context.Context, checkpoint *models.AuditExportCheckpoint, until time.Time, limit int) ([]*models.AuditLog, error)
+
+	// ListRecentResources lists the distinct resources of a type an actor performed any of actions on
+	// since the given time, most recently accessed first, with the last action on each
+	ListRecentResources(ctx context.Context, tenantID, actorID, resourceType string, actions []string, since time.Time, limit int) ([]models.RecentResource, error)
+
+	// ListActivity lists the entries forming the activity timeline of a document or folder, newest
+	// first, with pagination: the operations on the resource, its permissions and its share links,
+	// and for a folder also those on the documents uploaded to it or in it. Downloads are left out.
+	ListActivity(ctx context.Context, tenantID, resourceType, resourceID string, pagination *utils.Pagination) (utils.PaginatedResult[models.AuditLog], error)
+
+	// EnsurePartition creates the storage partition covering the month containing the given time
+	EnsurePartition(ctx context.Context, month time.Time) error
+}
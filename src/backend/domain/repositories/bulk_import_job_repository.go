@@ -0,0 +1,38 @@
+// Package repositories defines the repository interfaces for domain persistence operations
+package repositories
+
+import (
+	"context" // standard library - For context propagation in repository operations
+	"time"    // standard library - For detecting abandoned bulk import jobs
+
+	"../../pkg/utils" // For pagination utilities
+	"../models"       // To reference the BulkImportJob domain model
+)
+
+// BulkImportJobRepository defines the contract for persisting bulk imports of legacy archives and
+// the outcome of each of their files
+type BulkImportJobRepository interface {
+	// Create persists a new bulk import job
+	Create(ctx context.Context, job *models.BulkImportJob) (string, error)
+
+	// GetByID retrieves a bulk import job by its ID
+	GetByID(ctx context.Context, id string) (*models.BulkImportJob, error)
+
+	// List lists bulk import jobs, most recent first
+	List(ctx context.Context, pagination *utils.Pagination) (utils.PaginatedResult[models.BulkImportJob], error)
+
+	// ClaimNext marks the oldest pending bulk import job as running and returns it, or returns nil
+	// if there is none. Running jobs not updated since staleBefore were abandoned by a worker and
+	// are claimed again. Jobs claimed concurrently by other workers are skipped.
+	ClaimNext(ctx context.Context, staleBefore time.Time) (*models.BulkImportJob, error)
+
+	// Update persists the status, checkpoint and counts of a bulk import job
+	Update(ctx context.Context, job *models.BulkImportJob) error
+
+	// SaveItem persists the outcome of a file, replacing the outcome recorded for it before
+	SaveItem(ctx context.Context, item *models.BulkImportItem) error
+
+	// ListItems retrieves up to limit outcomes of the files of a job with a key after afterKey, in
+	// the order of their keys
+	ListItems(ctx context.Context, jobID string, afterKey string, limit int) ([]*models.BulkImportItem, error)
+}
@@ -0,0 +1,27 @@
+// Package repositories defines the repository interfaces for domain persistence operations
+package repositories
+
+import (
+	"context" // standard library - For context propagation in repository operations
+
+	"../models"       // To reference the Comment domain model
+	"../../pkg/utils" // For pagination support in repository methods
+)
+
+// CommentRepository defines the contract for persisting the comments of documents
+type CommentRepository interface {
+	// Create persists a new comment, joining the transaction carried by ctx if any
+	Create(ctx context.Context, comment *models.Comment) (string, error)
+
+	// GetByID retrieves a comment by its ID with tenant isolation
+	GetByID(ctx context.Context, id string, tenantID string) (*models.Comment, error)
+
+	// Update persists the body and resolution of an existing comment
+	Update(ctx context.Context, comment *models.Comment) error
+
+	// Delete deletes a comment with tenant isolation, together with its replies
+	Delete(ctx context.Context, id string, tenantID string) error
+
+	// ListByDocument lists the comments of a document oldest first, threads and replies alike, with pagination
+	ListByDocument(ctx context.Context, documentID string, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Comment], error)
+}
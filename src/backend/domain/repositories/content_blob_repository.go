@@ -0,0 +1,22 @@
+// Package repositories defines the repository interfaces for domain persistence operations
+package repositories
+
+import (
+	"context" // standard library - For context propagation in repository operations
+
+	"../models" // To reference the ContentBlob domain model
+)
+
+// ContentBlobRepository defines the contract for reference counting content shared through
+// content-addressed storage. Both methods lock the blob until the surrounding transaction ends,
+// so the stored content can be written or deleted before other references see the change.
+type ContentBlobRepository interface {
+	// Acquire adds a reference to the tenant's blob with the given hash, creating the blob with a
+	// single reference if it does not exist yet. A returned RefCount of 1 means the content still
+	// has to be stored.
+	Acquire(ctx context.Context, tenantID, contentHash string, size int64) (*models.ContentBlob, error)
+
+	// Release removes a reference to the tenant's blob and returns it with the remaining RefCount.
+	// The blob is deleted when no references remain. Returns a not found error for unknown blobs.
+	Release(ctx context.Context, tenantID, contentHash string) (*models.ContentBlob, error)
+}
@@ -0,0 +1,26 @@
+// Package repositories defines the repository interfaces for domain persistence operations
+package repositories
+
+import (
+	"context" // standard library - For context propagation in repository operations
+
+	"../models" // To reference the DocumentLink domain model
+)
+
+// DocumentLinkRepository defines the contract for persisting the links between documents
+type DocumentLinkRepository interface {
+	// Create persists a new link; a link of the same type between the same documents already existing is a validation error
+	Create(ctx context.Context, link *models.DocumentLink) (string, error)
+
+	// GetByID retrieves a link by its ID with tenant isolation
+	GetByID(ctx context.Context, id string, tenantID string) (*models.DocumentLink, error)
+
+	// Update persists the type of an existing link
+	Update(ctx context.Context, link *models.DocumentLink) error
+
+	// Delete deletes a link with tenant isolation
+	Delete(ctx context.Context, id string, tenantID string) error
+
+	// ListByDocument lists the links a document is the source or target of, oldest first
+	ListByDocument(ctx context.Context, documentID string, tenantID string) ([]models.DocumentLink, error)
+}
@@ -3,6 +3,7 @@ package repositories
 
 import (
 	"context" // standard library
+	"time"    // standard library
 
 	"../models"
 	"../../pkg/utils"
@@ -29,12 +30,16 @@ type DocumentRepository interface {
 	Delete(ctx context.Context, id string, tenantID string) error
 
 	// ListByFolder retrieves documents in a specific folder with pagination and tenant isolation.
-	// Only returns documents that belong to the specified tenant.
+	// Only returns documents that belong to the specified tenant. The result holds the cursor of
+	// the next page, which the pagination's Cursor continues from.
 	ListByFolder(ctx context.Context, folderID string, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Document], error)
 
 	// ListByTenant lists all documents for a tenant with pagination.
 	ListByTenant(ctx context.Context, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Document], error)
 
+	// ListByOwner lists the documents a user owns with pagination and tenant isolation.
+	ListByOwner(ctx context.Context, ownerID string, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Document], error)
+
 	// SearchByContent searches documents by their content with tenant isolation.
 	// Only returns documents that belong to the specified tenant.
 	SearchByContent(ctx context.Context, query string, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Document], error)
@@ -53,6 +58,35 @@ type DocumentRepository interface {
 	// UpdateVersionStatus updates the status of a document version with tenant isolation.
 	UpdateVersionStatus(ctx context.Context, versionID string, status string, tenantID string) error
 
+	// UpdateVersionStoragePath records where the content of a document version is stored once it was
+	// moved out of temporary storage, with tenant isolation.
+	UpdateVersionStoragePath(ctx context.Context, versionID string, storagePath string, tenantID string) error
+
+	// ListVersionsToReencrypt lists up to limit document versions of a tenant whose content is not
+	// encrypted with the given key. Versions still being processed are left out, since their content
+	// is about to be moved and encrypted again anyway.
+	ListVersionsToReencrypt(ctx context.Context, tenantID string, keyID string, limit int) ([]*models.DocumentVersion, error)
+
+	// UpdateVersionEncryptionKey records the key the content of a document version is encrypted with,
+	// with tenant isolation.
+	UpdateVersionEncryptionKey(ctx context.Context, versionID string, keyID string, tenantID string) error
+
+	// ListRecentVersions lists up to limit document versions of a tenant in the given status that
+	// were created at or after since, newest first.
+	ListRecentVersions(ctx context.Context, tenantID string, status string, since time.Time, limit int) ([]*models.DocumentVersion, error)
+
+	// ListVersionsToClassify lists up to limit available document versions of a tenant whose content
+	// has not been classified for personal data yet, oldest first.
+	ListVersionsToClassify(ctx context.Context, tenantID string, limit int) ([]*models.DocumentVersion, error)
+
+	// MarkVersionClassified records when the content of a document version was classified for
+	// personal data, with tenant isolation.
+	MarkVersionClassified(ctx context.Context, versionID string, classifiedAt time.Time, tenantID string) error
+
+	// MarkVersionIntegrityVerified records when the stored content of a document version was read
+	// back and matched its content hash, with tenant isolation.
+	MarkVersionIntegrityVerified(ctx context.Context, versionID string, verifiedAt time.Time, tenantID string) error
+
 	// AddMetadata adds metadata to a document with tenant isolation.
 	// Validates that the document exists and belongs to the specified tenant.
 	AddMetadata(ctx context.Context, documentID string, key string, value string, tenantID string) (string, error)
@@ -65,7 +99,36 @@ type DocumentRepository interface {
 	// Validates that the document exists and belongs to the specified tenant.
 	DeleteMetadata(ctx context.Context, documentID string, key string, tenantID string) error
 
+	// BulkUpdateMetadata sets and removes metadata keys of many documents with tenant isolation.
+	// Joins the caller's transaction when ctx carries one, so the documents change together.
+	BulkUpdateMetadata(ctx context.Context, documentIDs []string, set map[string]string, remove []string, tenantID string) error
+
 	// GetDocumentsByIDs retrieves multiple documents by their IDs with tenant isolation.
 	// Only returns documents that belong to the specified tenant.
 	GetDocumentsByIDs(ctx context.Context, ids []string, tenantID string) ([]*models.Document, error)
+
+	// GetUsage returns the number of documents of a tenant and the storage, in bytes,
+	// taken up by all their versions.
+	GetUsage(ctx context.Context, tenantID string) (int64, int64, error)
+
+	// SetLock locks a document on behalf of a user, or unlocks it when lockedAt is nil, with tenant
+	// isolation. Joins the caller's transaction when ctx carries one.
+	SetLock(ctx context.Context, id string, tenantID string, lockedBy string, lockedAt *time.Time) error
+
+	// SetSchedule sets when a document is published and when it expires, together with the visibility
+	// the schedule gives it now, with tenant isolation.
+	SetSchedule(ctx context.Context, id string, tenantID string, publishAt, expireAt *time.Time, visibility string) error
+
+	// ListDueVisibilityChanges lists up to limit documents of any tenant that are due to be published
+	// or to expire at now, the longest overdue first.
+	ListDueVisibilityChanges(ctx context.Context, now time.Time, limit int) ([]*models.Document, error)
+
+	// ApplyDueVisibility publishes a scheduled document or expires a published one, changing its
+	// visibility from one value to the other with tenant isolation. Returns false when the change is
+	// no longer due at now. Joins the caller's transaction when ctx carries one.
+	ApplyDueVisibility(ctx context.Context, id string, tenantID string, from string, to string, now time.Time) (bool, error)
+
+	// GetUploadVolume returns the bytes a user uploaded to a tenant since the given time,
+	// summed over the document versions the user created.
+	GetUploadVolume(ctx context.Context, tenantID, userID string, since time.Time) (int64, error)
 }
\ No newline at end of file
@@ -0,0 +1,16 @@
+// Package repositories defines the repository interfaces for domain persistence operations
+package repositories
+
+import (
+	"context" // standard library - For context propagation in repository operations
+	"time"    // standard library - For the day downloads are counted in
+)
+
+// DownloadCounterRepository defines the contract for counting the daily downloads of users from
+// folders that limit them
+type DownloadCounterRepository interface {
+	// Increment counts a download of the user from the folder on the day, unless the user already
+	// downloaded limit documents from it that day. It returns the downloads of that day, counting
+	// this one, and whether it was counted. Concurrent downloads never exceed the limit.
+	Increment(ctx context.Context, tenantID, userID, folderID string, day time.Time, limit int) (int, bool, error)
+}
@@ -0,0 +1,26 @@
+// Package repositories defines the repository interfaces for domain persistence operations
+package repositories
+
+import (
+	"context" // standard library - For context propagation in repository operations
+	"time"    // standard library - For detecting abandoned export jobs
+
+	"../models" // To reference the ExportJob domain model
+)
+
+// ExportJobRepository defines the contract for persisting background folder exports
+type ExportJobRepository interface {
+	// Create persists a new export job
+	Create(ctx context.Context, job *models.ExportJob) (string, error)
+
+	// GetByID retrieves an export job by its ID with tenant isolation
+	GetByID(ctx context.Context, id string, tenantID string) (*models.ExportJob, error)
+
+	// ClaimNext marks the oldest pending export job as running and returns it, or returns nil if
+	// there is none. Running jobs not updated since staleBefore were abandoned by a worker and are
+	// claimed again. Jobs claimed concurrently by other workers are skipped.
+	ClaimNext(ctx context.Context, staleBefore time.Time) (*models.ExportJob, error)
+
+	// Update persists the status, progress and result of an export job
+	Update(ctx context.Context, job *models.ExportJob) error
+}
@@ -0,0 +1,21 @@
+// Package repositories defines the repository interfaces for domain persistence operations
+package repositories
+
+import (
+	"context" // standard library - For context propagation in repository operations
+
+	"../models" // To reference the ExternalIdentityLink domain model
+)
+
+// ExternalIdentityLinkRepository defines the contract for persisting the links between platform
+// accounts and the subjects of the tenant's identity provider
+type ExternalIdentityLinkRepository interface {
+	// Create persists a new link, failing when the subject or the user's issuer is already linked
+	Create(ctx context.Context, link *models.ExternalIdentityLink) (string, error)
+
+	// GetBySubject retrieves the link of an issuer's subject with tenant isolation
+	GetBySubject(ctx context.Context, tenantID string, issuer string, subject string) (*models.ExternalIdentityLink, error)
+
+	// GetByUserID retrieves a user's link to an issuer with tenant isolation
+	GetByUserID(ctx context.Context, userID string, tenantID string, issuer string) (*models.ExternalIdentityLink, error)
+}
@@ -0,0 +1,22 @@
+// Package repositories defines the repository interfaces for domain persistence operations
+package repositories
+
+import (
+	"context" // standard library - For context propagation in repository operations
+
+	"../models"       // To reference the Favorite domain model
+	"../../pkg/utils" // For pagination support in repository methods
+)
+
+// FavoriteRepository defines the contract for persisting the documents and folders users starred
+type FavoriteRepository interface {
+	// Add stars a resource for a user. Starring a resource the user already starred is a no-op.
+	Add(ctx context.Context, favorite *models.Favorite) error
+
+	// Remove unstars a resource for a user, returning a not found error if the user had not starred it
+	Remove(ctx context.Context, tenantID, userID, resourceType, resourceID string) error
+
+	// List lists the favorites of a user, most recently starred first, with pagination.
+	// An empty resourceType lists both documents and folders.
+	List(ctx context.Context, tenantID, userID, resourceType string, pagination *utils.Pagination) (utils.PaginatedResult[models.Favorite], error)
+}
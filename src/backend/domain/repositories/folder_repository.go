@@ -29,11 +29,13 @@ type FolderRepository interface {
 	Delete(ctx context.Context, id string, tenantID string) error
 
 	// GetChildren lists child folders of a parent folder with pagination and tenant isolation.
-	// It returns a paginated list of child folders or an error if the operation fails.
+	// It returns a paginated list of child folders or an error if the operation fails. The result
+	// holds the cursor of the next page, which the pagination's Cursor continues from.
 	GetChildren(ctx context.Context, parentID string, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Folder], error)
 
 	// GetRootFolders lists root folders for a tenant with pagination.
-	// It returns a paginated list of root folders or an error if the operation fails.
+	// It returns a paginated list of root folders or an error if the operation fails, with the
+	// cursor of the next page like GetChildren.
 	GetRootFolders(ctx context.Context, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Folder], error)
 
 	// GetFolderPath retrieves the full path of a folder by its ID with tenant isolation.
@@ -0,0 +1,26 @@
+// Package repositories defines the repository interfaces for domain persistence operations
+package repositories
+
+import (
+	"context" // standard library - For context propagation in repository operations
+
+	"../models" // To reference the FolderTemplate domain model
+)
+
+// FolderTemplateRepository defines the contract for persisting folder templates
+type FolderTemplateRepository interface {
+	// Create persists a new folder template
+	Create(ctx context.Context, template *models.FolderTemplate) (string, error)
+
+	// GetByID retrieves a folder template by its ID with tenant isolation
+	GetByID(ctx context.Context, id string, tenantID string) (*models.FolderTemplate, error)
+
+	// Update updates the name, description, permissions and folders of an existing folder template
+	Update(ctx context.Context, template *models.FolderTemplate) error
+
+	// Delete deletes a folder template with tenant isolation; folders already created from it are kept
+	Delete(ctx context.Context, id string, tenantID string) error
+
+	// ListByTenant lists all folder templates of a tenant ordered by name
+	ListByTenant(ctx context.Context, tenantID string) ([]*models.FolderTemplate, error)
+}
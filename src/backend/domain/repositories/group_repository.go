@@ -0,0 +1,40 @@
+// Package repositories defines the repository interfaces for domain persistence operations
+package repositories
+
+import (
+	"context" // standard library - For context propagation in repository operations
+
+	"../models"       // To reference the Group domain models
+	"../../pkg/utils" // For pagination support in repository methods
+)
+
+// GroupRepository defines the contract for group and group membership persistence.
+// Groups and their memberships are scoped to a tenant.
+type GroupRepository interface {
+	// Create persists a new group
+	Create(ctx context.Context, group *models.Group) (string, error)
+
+	// GetByID retrieves a group by its ID with tenant isolation
+	GetByID(ctx context.Context, id string, tenantID string) (*models.Group, error)
+
+	// Update updates an existing group's name and description
+	Update(ctx context.Context, group *models.Group) error
+
+	// Delete deletes a group, its memberships and the permissions granted to it
+	Delete(ctx context.Context, id string, tenantID string) error
+
+	// List lists a tenant's groups with pagination
+	List(ctx context.Context, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Group], error)
+
+	// AddMember adds a user to a group. Adding an existing member is a no-op.
+	AddMember(ctx context.Context, membership *models.GroupMembership) error
+
+	// RemoveMember removes a user from a group with tenant isolation
+	RemoveMember(ctx context.Context, groupID, userID, tenantID string) error
+
+	// ListMembers lists the members of a group with pagination
+	ListMembers(ctx context.Context, groupID, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.GroupMembership], error)
+
+	// GetGroupIDsForUser returns the IDs of the groups a user is a member of
+	GetGroupIDsForUser(ctx context.Context, userID, tenantID string) ([]string, error)
+}
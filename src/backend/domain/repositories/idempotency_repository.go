@@ -0,0 +1,23 @@
+// Package repositories defines the repository interfaces for domain persistence operations
+package repositories
+
+import (
+	"context" // standard library - For context propagation in repository operations
+	"time"    // standard library - For record expiry
+
+	"../models" // To reference the IdempotencyRecord domain model
+)
+
+// IdempotencyRepository defines the contract for remembering requests made with idempotency keys.
+// Keys are scoped to the tenant and user that sent them and are forgotten after the given TTL.
+type IdempotencyRepository interface {
+	// Reserve stores an in-progress record for a key unless one exists. It returns true when the
+	// record was stored, and the existing record otherwise.
+	Reserve(ctx context.Context, record *models.IdempotencyRecord, ttl time.Duration) (bool, *models.IdempotencyRecord, error)
+
+	// Save replaces the record for a key, such as when its request completed
+	Save(ctx context.Context, record *models.IdempotencyRecord, ttl time.Duration) error
+
+	// Release forgets a key, so that the request can be retried
+	Release(ctx context.Context, tenantID, userID, key string) error
+}
@@ -0,0 +1,26 @@
+// Package repositories defines the repository interfaces for domain persistence operations
+package repositories
+
+import (
+	"context" // standard library - For context propagation in repository operations
+
+	"../models" // To reference the MetadataField domain model
+)
+
+// MetadataFieldRepository defines the contract for persisting the metadata fields of a tenant's schema
+type MetadataFieldRepository interface {
+	// Create persists a new metadata field
+	Create(ctx context.Context, field *models.MetadataField) (string, error)
+
+	// GetByID retrieves a metadata field by its ID with tenant isolation
+	GetByID(ctx context.Context, id string, tenantID string) (*models.MetadataField, error)
+
+	// Update updates an existing metadata field
+	Update(ctx context.Context, field *models.MetadataField) error
+
+	// Delete deletes a metadata field with tenant isolation
+	Delete(ctx context.Context, id string, tenantID string) error
+
+	// ListByTenant lists all metadata fields of a tenant ordered by name
+	ListByTenant(ctx context.Context, tenantID string) ([]*models.MetadataField, error)
+}
@@ -0,0 +1,30 @@
+// Package repositories defines the repository interfaces for domain persistence operations
+package repositories
+
+import (
+	"context" // standard library - For context propagation in repository operations
+
+	"../models" // To reference the MetadataTemplate domain model
+)
+
+// MetadataTemplateRepository defines the contract for persisting the metadata templates of folders
+type MetadataTemplateRepository interface {
+	// Create persists a new metadata template
+	Create(ctx context.Context, template *models.MetadataTemplate) (string, error)
+
+	// GetByID retrieves a metadata template by its ID with tenant isolation
+	GetByID(ctx context.Context, id string, tenantID string) (*models.MetadataTemplate, error)
+
+	// GetByFolder retrieves the metadata template of a folder, returning a not found error if the
+	// folder has none
+	GetByFolder(ctx context.Context, folderID string, tenantID string) (*models.MetadataTemplate, error)
+
+	// Update updates an existing metadata template
+	Update(ctx context.Context, template *models.MetadataTemplate) error
+
+	// Delete deletes a metadata template with tenant isolation
+	Delete(ctx context.Context, id string, tenantID string) error
+
+	// ListByTenant lists all metadata templates of a tenant ordered by name
+	ListByTenant(ctx context.Context, tenantID string) ([]*models.MetadataTemplate, error)
+}
@@ -0,0 +1,24 @@
+// Package repositories defines the repository interfaces for domain persistence operations
+package repositories
+
+import (
+	"context" // standard library - For context propagation in repository operations
+
+	"../models" // To reference the MFAEnrollment domain model
+)
+
+// MFARepository defines the contract for persisting users' second factor enrollments.
+// A user has at most one enrollment.
+type MFARepository interface {
+	// Create persists a new MFA enrollment, replacing any pending enrollment of the user
+	Create(ctx context.Context, enrollment *models.MFAEnrollment) (string, error)
+
+	// GetByUserID retrieves a user's MFA enrollment with tenant isolation
+	GetByUserID(ctx context.Context, userID string, tenantID string) (*models.MFAEnrollment, error)
+
+	// Update stores an enrollment's enabled time, backup codes, last used time step and failed attempts
+	Update(ctx context.Context, enrollment *models.MFAEnrollment) error
+
+	// Delete removes a user's MFA enrollment with tenant isolation
+	Delete(ctx context.Context, userID string, tenantID string) error
+}
@@ -0,0 +1,35 @@
+// Package repositories defines the repository interfaces for domain persistence operations
+package repositories
+
+import (
+	"context" // standard library - For context propagation in repository operations
+	"time"    // standard library - For the time numbers are assigned at
+
+	"../models" // To reference the NumberingSequence domain model
+)
+
+// NumberingSequenceRepository defines the contract for persisting the numbering sequences of tenants and folders
+type NumberingSequenceRepository interface {
+	// Create persists a new numbering sequence; a folder, or a tenant's default, has at most one
+	Create(ctx context.Context, sequence *models.NumberingSequence) (string, error)
+
+	// GetByID retrieves a numbering sequence by its ID with tenant isolation
+	GetByID(ctx context.Context, id string, tenantID string) (*models.NumberingSequence, error)
+
+	// GetByFolder retrieves the numbering sequence of a folder, or the tenant's default when folderID
+	// is empty, returning a not found error if there is none
+	GetByFolder(ctx context.Context, folderID string, tenantID string) (*models.NumberingSequence, error)
+
+	// Update persists the name, format, padding, mode and reset of an existing numbering sequence
+	Update(ctx context.Context, sequence *models.NumberingSequence) error
+
+	// Delete deletes a numbering sequence with tenant isolation
+	Delete(ctx context.Context, id string, tenantID string) error
+
+	// ListByTenant lists the numbering sequences of a tenant ordered by name
+	ListByTenant(ctx context.Context, tenantID string) ([]*models.NumberingSequence, error)
+
+	// Advance locks a numbering sequence, advances it and returns the number assigned at now. The
+	// lock is held until the transaction carried by ctx ends, or until Advance returns without one.
+	Advance(ctx context.Context, id string, tenantID string, now time.Time) (string, error)
+}
@@ -0,0 +1,28 @@
+// Package repositories defines the repository interfaces for domain persistence operations
+package repositories
+
+import (
+	"context" // standard library - For context propagation in repository operations
+	"time"    // standard library - For selecting messages that are due for publishing
+
+	"../models" // To reference the OutboxMessage domain model
+)
+
+// OutboxRepository defines the contract for the transactional event outbox. Implementations
+// must write through the transaction carried by ctx, if any, so that outbox messages commit
+// or roll back together with the domain change that raised them.
+type OutboxRepository interface {
+	// Create persists a new pending outbox message
+	Create(ctx context.Context, message *models.OutboxMessage) (string, error)
+
+	// ListDue lists pending messages whose next attempt time is at or before the given time,
+	// oldest first. Rows are locked for the surrounding transaction and rows locked by other
+	// relays are skipped.
+	ListDue(ctx context.Context, now time.Time, limit int) ([]*models.OutboxMessage, error)
+
+	// Update persists the status, attempt count and schedule of an outbox message
+	Update(ctx context.Context, message *models.OutboxMessage) error
+
+	// DeleteSentBefore removes messages that were published before the given time
+	DeleteSentBefore(ctx context.Context, before time.Time) (int, error)
+}
@@ -59,6 +59,11 @@ type PermissionRepository interface {
 	// It returns true if the permission exists, false otherwise, or an error if the operation fails.
 	CheckPermission(ctx context.Context, roleID, resourceType, resourceID, permissionType, tenantID string) (bool, error)
 
+	// CheckGranteePermission checks if any of the given grantees of granteeType (role, user or group IDs) has
+	// a specific permission on a resource with tenant isolation, including admin and inherited folder grants.
+	// It returns true if such a grant exists, false otherwise, or an error if the operation fails.
+	CheckGranteePermission(ctx context.Context, granteeType string, granteeIDs []string, resourceType, resourceID, permissionType, tenantID string) (bool, error)
+
 	// GetInheritedPermissions retrieves inherited permissions for a folder with tenant isolation.
 	// It returns a list of inherited permissions for the folder or an error if the operation fails.
 	GetInheritedPermissions(ctx context.Context, folderID, tenantID string) ([]*models.Permission, error)
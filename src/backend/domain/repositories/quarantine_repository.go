@@ -0,0 +1,25 @@
+// Package repositories defines the repository interfaces for domain persistence operations
+package repositories
+
+import (
+	"context" // standard library - For context propagation in repository operations
+
+	"../models"       // To reference the QuarantinedItem domain model
+	"../../pkg/utils" // For pagination support in repository methods
+)
+
+// QuarantineRepository defines the contract for persisting document versions held in quarantine
+type QuarantineRepository interface {
+	// Create persists a new quarantined item
+	Create(ctx context.Context, item *models.QuarantinedItem) (string, error)
+
+	// GetByID retrieves a quarantined item by its ID with tenant isolation
+	GetByID(ctx context.Context, id string, tenantID string) (*models.QuarantinedItem, error)
+
+	// List retrieves the quarantined items of a tenant, newest first. An empty status lists items in any status.
+	List(ctx context.Context, tenantID string, status string, pagination *utils.Pagination) (utils.PaginatedResult[models.QuarantinedItem], error)
+
+	// Resolve persists the release or destruction of an item. It fails with a validation error
+	// if the item was resolved concurrently, so an item is only ever resolved once.
+	Resolve(ctx context.Context, item *models.QuarantinedItem) error
+}
@@ -0,0 +1,27 @@
+// Package repositories defines the repository interfaces for domain persistence operations
+package repositories
+
+import (
+	"context" // standard library - For context propagation in repository operations
+
+	"../models" // To reference the TenantQuota domain model
+)
+
+// QuotaRepository defines the contract for persisting tenant quotas and their tracked usage
+type QuotaRepository interface {
+	// GetByTenantID retrieves a tenant's quota; it returns a not found error when none has been created
+	GetByTenantID(ctx context.Context, tenantID string) (*models.TenantQuota, error)
+
+	// Create persists a new tenant quota. Creating a quota that already exists is not an error
+	// and leaves the existing quota unchanged.
+	Create(ctx context.Context, quota *models.TenantQuota) error
+
+	// SetLimits changes a tenant's limits without touching its usage
+	SetLimits(ctx context.Context, tenantID string, maxStorageBytes, maxDocuments int64) error
+
+	// AddUsage atomically adds to a tenant's usage if the result stays within its limits, and returns
+	// the quota with the new usage. When the usage does not fit, the quota is returned unchanged along
+	// with models.ErrStorageQuotaExceeded or models.ErrDocumentQuotaExceeded. Negative values release
+	// usage and always succeed.
+	AddUsage(ctx context.Context, tenantID string, storageBytes, documents int64) (*models.TenantQuota, error)
+}
@@ -0,0 +1,16 @@
+// Package repositories defines the repository interfaces for domain persistence operations
+package repositories
+
+import (
+	"context" // standard library - For context propagation in repository operations
+
+	"../models" // To reference the RateLimit domain model
+)
+
+// RateLimitRepository defines the contract for the token buckets requests are rate limited with.
+// Buckets are shared by all API instances, so that limits hold however requests are balanced.
+type RateLimitRepository interface {
+	// Take takes a token from the bucket under key, creating a full bucket on first use, and
+	// reports whether one was available
+	Take(ctx context.Context, key string, limit models.RateLimit) (*models.RateLimitDecision, error)
+}
@@ -0,0 +1,30 @@
+// Package repositories defines the repository interfaces for domain persistence operations
+package repositories
+
+import (
+	"context" // standard library - For context propagation in repository operations
+	"time"    // standard library - For detecting abandoned reindex jobs
+
+	"../models" // To reference the ReindexJob domain model
+)
+
+// ReindexJobRepository defines the contract for persisting background search index rebuilds
+type ReindexJobRepository interface {
+	// Create persists a new reindex job
+	Create(ctx context.Context, job *models.ReindexJob) (string, error)
+
+	// GetByID retrieves a reindex job by its ID with tenant isolation
+	GetByID(ctx context.Context, id string, tenantID string) (*models.ReindexJob, error)
+
+	// GetUnfinished retrieves the pending or running reindex job of a tenant, or returns nil if
+	// there is none
+	GetUnfinished(ctx context.Context, tenantID string) (*models.ReindexJob, error)
+
+	// ClaimNext marks the oldest pending reindex job as running and returns it, or returns nil if
+	// there is none. Running jobs not updated since staleBefore were abandoned by a worker and are
+	// claimed again. Jobs claimed concurrently by other workers are skipped.
+	ClaimNext(ctx context.Context, staleBefore time.Time) (*models.ReindexJob, error)
+
+	// Update persists the status and progress of a reindex job
+	Update(ctx context.Context, job *models.ReindexJob) error
+}
@@ -0,0 +1,37 @@
+// Package repositories defines the repository interfaces for domain persistence operations
+package repositories
+
+import (
+	"context" // standard library - For context propagation in repository operations
+
+	"../models"       // To reference the Role domain model
+	"../../pkg/utils" // For pagination support in repository methods
+)
+
+// RoleRepository defines the contract for role persistence and retrieval.
+// Roles are scoped to a tenant; the predefined system roles are seeded for every tenant.
+type RoleRepository interface {
+	// Create persists a new role
+	Create(ctx context.Context, role *models.Role) (string, error)
+
+	// GetByID retrieves a role by its ID with tenant isolation
+	GetByID(ctx context.Context, id string, tenantID string) (*models.Role, error)
+
+	// GetByName retrieves a role by its name with tenant isolation
+	GetByName(ctx context.Context, name string, tenantID string) (*models.Role, error)
+
+	// Update updates an existing role's description and permissions
+	Update(ctx context.Context, role *models.Role) error
+
+	// Delete deletes a role with tenant isolation
+	Delete(ctx context.Context, id string, tenantID string) error
+
+	// List lists a tenant's roles with pagination
+	List(ctx context.Context, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Role], error)
+
+	// GetPermissionsForRoles returns the union of the permissions granted by the named roles in a tenant
+	GetPermissionsForRoles(ctx context.Context, tenantID string, roleNames []string) ([]string, error)
+
+	// GetIDsByNames returns the IDs of the named roles in a tenant
+	GetIDsByNames(ctx context.Context, tenantID string, roleNames []string) ([]string, error)
+}
@@ -0,0 +1,22 @@
+// Package repositories defines the repository interfaces for domain persistence operations
+package repositories
+
+import (
+	"context" // standard library - For context propagation in repository operations
+	"time"    // standard library - For verdict expiry
+)
+
+// ScanVerdictRepository defines the contract for remembering which content of each tenant was
+// recently scanned clean, keyed by its content hash, so identical uploads do not need to be
+// scanned again
+type ScanVerdictRepository interface {
+	// StoreClean records that the tenant's content with the hash was scanned clean, for ttl
+	StoreClean(ctx context.Context, tenantID, contentHash string, ttl time.Duration) error
+
+	// IsClean checks if the tenant's content with the hash was scanned clean and the verdict has
+	// not expired
+	IsClean(ctx context.Context, tenantID, contentHash string) (bool, error)
+
+	// DeleteAll forgets the verdicts of all tenants
+	DeleteAll(ctx context.Context) error
+}
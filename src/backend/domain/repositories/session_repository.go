@@ -0,0 +1,28 @@
+// Package repositories defines the repository interfaces for domain persistence operations
+package repositories
+
+import (
+	"context" // standard library - For context propagation in repository operations
+	"time"    // standard library - For session usage and expiry timestamps
+
+	"../models" // To reference the Session domain model
+)
+
+// SessionRepository defines the contract for persisting user sessions
+type SessionRepository interface {
+	// Create persists a new session
+	Create(ctx context.Context, session *models.Session) (string, error)
+
+	// GetByID retrieves a session by its ID with tenant isolation
+	GetByID(ctx context.Context, id string, tenantID string) (*models.Session, error)
+
+	// ListActiveByUser lists a user's sessions that are neither revoked nor expired, most recently used first
+	ListActiveByUser(ctx context.Context, userID string, tenantID string) ([]models.Session, error)
+
+	// Extend records that a session's tokens were refreshed and moves its expiry to that of the new refresh token.
+	// Revoked sessions cannot be extended.
+	Extend(ctx context.Context, id string, tenantID string, lastUsedAt, expiresAt time.Time) error
+
+	// Revoke marks a session as revoked with tenant isolation
+	Revoke(ctx context.Context, id string, tenantID string) error
+}
@@ -0,0 +1,31 @@
+// Package repositories defines the repository interfaces for domain persistence operations
+package repositories
+
+import (
+	"context" // standard library - For context propagation in repository operations
+
+	"../models" // To reference the ShareLink domain model
+)
+
+// ShareLinkRepository defines the contract for persisting public document share links
+type ShareLinkRepository interface {
+	// Create persists a new share link
+	Create(ctx context.Context, link *models.ShareLink) (string, error)
+
+	// GetByID retrieves a share link by its ID with tenant isolation
+	GetByID(ctx context.Context, id string, tenantID string) (*models.ShareLink, error)
+
+	// GetByTokenHash retrieves a share link by the hash of its token. This lookup is not
+	// tenant scoped because it serves unauthenticated requests; the token identifies the tenant.
+	GetByTokenHash(ctx context.Context, tokenHash string) (*models.ShareLink, error)
+
+	// ListByDocument lists the share links of a document with tenant isolation
+	ListByDocument(ctx context.Context, documentID string, tenantID string) ([]*models.ShareLink, error)
+
+	// Revoke marks a share link as revoked with tenant isolation
+	Revoke(ctx context.Context, id string, tenantID string) error
+
+	// RecordDownload atomically counts a download against the share link. It returns false
+	// without counting when the link is revoked, expired or has reached its download limit.
+	RecordDownload(ctx context.Context, id string) (bool, error)
+}
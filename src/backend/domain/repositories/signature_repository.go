@@ -0,0 +1,29 @@
+// Package repositories defines the repository interfaces for domain persistence operations
+package repositories
+
+import (
+	"context" // standard library - For context propagation in repository operations
+
+	"../models"       // To reference the SignatureRequest domain model
+	"../../pkg/utils" // For pagination support in repository methods
+)
+
+// SignatureRequestRepository defines the contract for persisting signature requests and their
+// signers. Writes join the transaction carried by ctx, if any.
+type SignatureRequestRepository interface {
+	// Create persists a new signature request together with its signers
+	Create(ctx context.Context, request *models.SignatureRequest) (string, error)
+
+	// GetByID retrieves a signature request with its signers by its ID with tenant isolation
+	GetByID(ctx context.Context, id string, tenantID string) (*models.SignatureRequest, error)
+
+	// GetByEnvelope retrieves the signature request of an envelope of a provider, across tenants,
+	// since provider callbacks only identify the envelope
+	GetByEnvelope(ctx context.Context, provider string, envelopeID string) (*models.SignatureRequest, error)
+
+	// Update persists the status of a signature request and the statuses of its signers
+	Update(ctx context.Context, request *models.SignatureRequest) error
+
+	// ListByDocument lists the signature requests of a document with their signers, newest first
+	ListByDocument(ctx context.Context, documentID string, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.SignatureRequest], error)
+}
@@ -0,0 +1,27 @@
+// Package repositories defines the repository interfaces for domain persistence operations
+package repositories
+
+import (
+	"context" // standard library - For context propagation in repository operations
+	"time"    // standard library - For detecting abandoned subject access exports
+
+	"../models" // To reference the SubjectAccessExport domain model
+)
+
+// SubjectAccessExportRepository defines the contract for persisting background exports of the
+// personal data of users
+type SubjectAccessExportRepository interface {
+	// Create persists a new subject access export
+	Create(ctx context.Context, export *models.SubjectAccessExport) (string, error)
+
+	// GetByID retrieves a subject access export by its ID with tenant isolation
+	GetByID(ctx context.Context, id string, tenantID string) (*models.SubjectAccessExport, error)
+
+	// ClaimNext marks the oldest pending export as running and returns it, or returns nil if there
+	// is none. Running exports not updated since staleBefore were abandoned by a worker and are
+	// claimed again. Exports claimed concurrently by other workers are skipped.
+	ClaimNext(ctx context.Context, staleBefore time.Time) (*models.SubjectAccessExport, error)
+
+	// Update persists the status, counts and result of a subject access export
+	Update(ctx context.Context, export *models.SubjectAccessExport) error
+}
@@ -0,0 +1,43 @@
+// Package repositories defines the repository interfaces for domain persistence operations
+package repositories
+
+import (
+	"context" // standard library - For context propagation in repository operations
+	"time"    // standard library - For selecting deletions that are due
+
+	"../models"       // To reference the TenantDeletion domain model
+	"../../pkg/utils" // For pagination utilities
+)
+
+// TenantDeletionRepository defines the contract for persisting tenant offboarding. Deletions are
+// not removed along with their tenant, so that their destruction reports are kept.
+type TenantDeletionRepository interface {
+	// Create persists a new tenant deletion. Creating a deletion for a tenant that already has an
+	// unfinished one fails with a validation error.
+	Create(ctx context.Context, deletion *models.TenantDeletion) (string, error)
+
+	// GetByID retrieves a tenant deletion by its ID
+	GetByID(ctx context.Context, id string) (*models.TenantDeletion, error)
+
+	// GetActiveByTenant retrieves the scheduled, running or failed deletion of a tenant
+	GetActiveByTenant(ctx context.Context, tenantID string) (*models.TenantDeletion, error)
+
+	// List lists tenant deletions, most recent first
+	List(ctx context.Context, pagination *utils.Pagination) (utils.PaginatedResult[models.TenantDeletion], error)
+
+	// ClaimNext marks the oldest deletion scheduled for now or earlier as running and returns it,
+	// or returns nil if there is none. Running and failed deletions not updated since retryBefore
+	// were abandoned by a worker or failed, and are claimed again. Deletions claimed concurrently by
+	// other workers are skipped.
+	ClaimNext(ctx context.Context, now time.Time, retryBefore time.Time) (*models.TenantDeletion, error)
+
+	// Touch records that a running deletion is still in progress, so that it is not claimed again
+	Touch(ctx context.Context, id string, now time.Time) error
+
+	// Cancel persists the cancellation of a deletion that has not erased anything yet. It fails
+	// with a validation error when a worker started the deletion meanwhile.
+	Cancel(ctx context.Context, deletion *models.TenantDeletion) error
+
+	// Update persists the status and report of a tenant deletion
+	Update(ctx context.Context, deletion *models.TenantDeletion) error
+}
@@ -75,4 +75,14 @@ type TenantRepository interface {
 	// CountByStatus counts the number of tenants with a specific status
 	// It returns the count or an error if counting fails
 	CountByStatus(ctx context.Context, status string) (int64, error)
+
+	// PurgeData permanently deletes a tenant with every row of it, including the rows of tables
+	// without a foreign key to the tenant. Purging a tenant that no longer exists deletes what is
+	// left of it. It returns the number of rows deleted from each table keyed by tenant.
+	PurgeData(ctx context.Context, id string) (map[string]int64, error)
+
+	// CountData counts the rows of a tenant in each table keyed by tenant, so that its erasure
+	// can be verified
+	// It returns the count of every table, including the tables without rows of the tenant
+	CountData(ctx context.Context, id string) (map[string]int64, error)
 }
\ No newline at end of file
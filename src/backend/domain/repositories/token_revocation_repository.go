@@ -0,0 +1,17 @@
+// Package repositories defines the repository interfaces for domain persistence operations
+package repositories
+
+import (
+	"context" // standard library - For context propagation in repository operations
+	"time"    // standard library - For revocation expiry
+)
+
+// TokenRevocationRepository defines the contract for the list of revoked tokens. Tokens are keyed
+// by their ID (the jti claim) and only need to be remembered until they would have expired anyway.
+type TokenRevocationRepository interface {
+	// Revoke adds a token ID to the revocation list until expiresAt
+	Revoke(ctx context.Context, tokenID string, expiresAt time.Time) error
+
+	// IsRevoked checks if a token ID is on the revocation list
+	IsRevoked(ctx context.Context, tokenID string) (bool, error)
+}
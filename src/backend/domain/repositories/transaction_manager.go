@@ -0,0 +1,14 @@
+// Package repositories defines the repository interfaces for domain persistence operations
+package repositories
+
+import (
+	"context" // standard library - For carrying the active transaction to repositories
+)
+
+// TransactionManager runs units of work atomically across repositories. The context passed to
+// fn carries the transaction; repositories invoked with it participate in the same commit.
+type TransactionManager interface {
+	// WithTransaction executes fn within a transaction, committing if fn returns nil and
+	// rolling back otherwise. Nested calls join the outer transaction.
+	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,23 @@
+// Package repositories defines the repository interfaces for domain persistence operations
+package repositories
+
+import (
+	"context" // standard library - For context propagation in repository operations
+
+	"../models" // To reference the UploadLimit domain model
+)
+
+// UploadLimitRepository defines the contract for persisting per-user and per-role upload limits
+type UploadLimitRepository interface {
+	// Save creates the limit for its scope, or replaces the limits of an existing one
+	Save(ctx context.Context, limit *models.UploadLimit) error
+
+	// Delete removes the limit of a user or role with tenant isolation
+	Delete(ctx context.Context, tenantID, scope, scopeID string) error
+
+	// ListByTenant lists all upload limits of a tenant
+	ListByTenant(ctx context.Context, tenantID string) ([]*models.UploadLimit, error)
+
+	// ListForUser lists the limits that apply to a user: their own and those of the given roles
+	ListForUser(ctx context.Context, tenantID, userID string, roles []string) ([]*models.UploadLimit, error)
+}
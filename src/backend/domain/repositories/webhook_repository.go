@@ -4,6 +4,7 @@ package repositories
 
 import (
 	"context" // standard library
+	"time"    // standard library
 
 	"../models"
 	"../../pkg/utils"
@@ -42,9 +43,44 @@ type WebhookRepository interface {
 	// ListDeliveriesByWebhook lists delivery records for a specific webhook with pagination
 	ListDeliveriesByWebhook(ctx context.Context, webhookID string, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.WebhookDelivery], error)
 
+	// ListDeliveriesByFilter lists delivery records for a tenant matching the filter with pagination
+	ListDeliveriesByFilter(ctx context.Context, tenantID string, filter models.WebhookDeliveryFilter, pagination *utils.Pagination) (utils.PaginatedResult[models.WebhookDelivery], error)
+
 	// ListPendingDeliveries lists pending delivery records for processing
 	ListPendingDeliveries(ctx context.Context, limit int) ([]*models.WebhookDelivery, error)
 
 	// ListFailedDeliveries lists failed delivery records for retry
 	ListFailedDeliveries(ctx context.Context, limit int, maxAttempts int) ([]*models.WebhookDelivery, error)
+
+	// ListDueDeliveries claims failed delivery records whose next retry time is at or before the given
+	// time, so that other workers do not retry them at the same time
+	ListDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*models.WebhookDelivery, error)
+
+	// ListDeadLetteredDeliveries lists deliveries that exhausted their retries for a tenant with pagination
+	ListDeadLetteredDeliveries(ctx context.Context, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.WebhookDelivery], error)
+
+	// GetDeliveryWebhook retrieves the webhook a delivery belongs to, for background processing
+	// that runs outside of a tenant-scoped request
+	GetDeliveryWebhook(ctx context.Context, deliveryID string) (*models.Webhook, error)
+
+	// CreateDeliveryAttempt records the outcome of a single delivery attempt
+	CreateDeliveryAttempt(ctx context.Context, attempt *models.WebhookDeliveryAttempt) (string, error)
+
+	// ListDeliveryAttempts lists the attempt history for a delivery, oldest first
+	ListDeliveryAttempts(ctx context.Context, deliveryID string, tenantID string) ([]*models.WebhookDeliveryAttempt, error)
+
+	// CreateSubscription persists a new subscription filter for a webhook
+	CreateSubscription(ctx context.Context, subscription *models.WebhookSubscription) (string, error)
+
+	// GetSubscription retrieves a subscription by its ID
+	GetSubscription(ctx context.Context, id string, tenantID string) (*models.WebhookSubscription, error)
+
+	// UpdateSubscription updates an existing subscription
+	UpdateSubscription(ctx context.Context, subscription *models.WebhookSubscription) error
+
+	// DeleteSubscription deletes a subscription
+	DeleteSubscription(ctx context.Context, id string, tenantID string) error
+
+	// ListSubscriptionsByWebhook lists all subscriptions of a webhook
+	ListSubscriptionsByWebhook(ctx context.Context, webhookID string, tenantID string) ([]*models.WebhookSubscription, error)
 }
\ No newline at end of file
@@ -0,0 +1,105 @@
+// Package services provides domain-level services for the Document Management Platform
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"../models"
+	"../repositories"
+	"../../pkg/errors"
+	"../../pkg/logger"
+)
+
+// AuditExporter delivers audit log entries to an external system such as a SIEM
+type AuditExporter interface {
+	// Name identifies the exporter; it is used as the key of the exporter's checkpoint
+	Name() string
+
+	// Export delivers a batch of entries, oldest first. A batch is either fully accepted or
+	// the call fails, in which case it is retried from the same position.
+	Export(ctx context.Context, entries []*models.AuditLog) error
+
+	// Close releases any connection held by the exporter
+	Close() error
+}
+
+// AuditForwarder forwards the audit log of all tenants to an AuditExporter
+type AuditForwarder interface {
+	// ForwardPending exports up to batchSize entries that occurred at least settleDelay ago and have
+	// not been forwarded yet, then advances the checkpoint. Returns the number of entries forwarded.
+	ForwardPending(ctx context.Context, batchSize int, settleDelay time.Duration) (int, error)
+}
+
+// auditForwarder implements the AuditForwarder interface
+type auditForwarder struct {
+	auditRepo      repositories.AuditLogRepository
+	checkpointRepo repositories.AuditExportCheckpointRepository
+	exporter       AuditExporter
+}
+
+// NewAuditForwarder creates a new AuditForwarder instance
+func NewAuditForwarder(auditRepo repositories.AuditLogRepository, checkpointRepo repositories.AuditExportCheckpointRepository, exporter AuditExporter) (AuditForwarder, error) {
+	if auditRepo == nil {
+		return nil, fmt.Errorf("audit log repository cannot be nil")
+	}
+	if checkpointRepo == nil {
+		return nil, fmt.Errorf("audit export checkpoint repository cannot be nil")
+	}
+	if exporter == nil {
+		return nil, fmt.Errorf("audit exporter cannot be nil")
+	}
+
+	return &auditForwarder{
+		auditRepo:      auditRepo,
+		checkpointRepo: checkpointRepo,
+		exporter:       exporter,
+	}, nil
+}
+
+// ForwardPending exports the next batch of entries after the exporter's checkpoint.
+// The checkpoint is only advanced after the exporter accepts the batch, so delivery is
+// at-least-once: a crash between the two steps re-exports the batch. Entries younger than
+// settleDelay are held back because a transaction that commits late can insert an entry
+// with an occurrence time before entries that are already visible.
+func (s *auditForwarder) ForwardPending(ctx context.Context, batchSize int, settleDelay time.Duration) (int, error) {
+	ctxLogger := logger.WithContext(ctx)
+
+	if batchSize <= 0 {
+		return 0, errors.NewValidationError("batch size must be greater than zero")
+	}
+
+	exporterName := s.exporter.Name()
+
+	checkpoint, err := s.checkpointRepo.Get(ctx, exporterName)
+	if err != nil {
+		ctxLogger.Error("Failed to get audit export checkpoint", "error", err, "exporter", exporterName)
+		return 0, errors.Wrap(err, "failed to get audit export checkpoint")
+	}
+
+	until := time.Now().UTC().Add(-settleDelay)
+	entries, err := s.auditRepo.ListAfter(ctx, checkpoint, until, batchSize)
+	if err != nil {
+		ctxLogger.Error("Failed to list audit logs to forward", "error", err, "exporter", exporterName)
+		return 0, errors.Wrap(err, "failed to list audit logs to forward")
+	}
+
+	if len(entries) == 0 {
+		return 0, nil
+	}
+
+	if err := s.exporter.Export(ctx, entries); err != nil {
+		ctxLogger.Error("Failed to export audit logs", "error", err, "exporter", exporterName, "count", len(entries))
+		return 0, errors.Wrap(err, "failed to export audit logs")
+	}
+
+	checkpoint.Exporter = exporterName
+	checkpoint.Advance(entries[len(entries)-1])
+	if err := s.checkpointRepo.Save(ctx, checkpoint); err != nil {
+		ctxLogger.Error("Failed to save audit export checkpoint", "error", err, "exporter", exporterName)
+		return len(entries), errors.Wrap(err, "failed to save audit export checkpoint")
+	}
+
+	return len(entries), nil
+}
@@ -0,0 +1,308 @@
+// Package services provides domain-level services for the Document Management Platform
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"../models"
+	"../repositories"
+	"../../pkg/errors"
+	"../../pkg/logger"
+	"../../pkg/utils"
+)
+
+// Audit export limits
+const (
+	// auditExportPageSize is the number of entries read from the repository per export page
+	auditExportPageSize = 1000
+	// MaxAuditExportRows caps the number of entries written by a single export
+	MaxAuditExportRows = 100000
+)
+
+// auditExportColumns is the CSV header for audit exports
+var auditExportColumns = []string{
+	"id", "occurred_at", "tenant_id", "actor_id", "actor_ip", "user_agent", "request_id",
+	"action", "resource_type", "resource_id", "before", "after",
+}
+
+// AuditActor describes who issued the current request. It is attached to the request context
+// by the API layer so that services can record it without threading it through every call.
+type AuditActor struct {
+	UserID    string
+	IPAddress string
+	UserAgent string
+	RequestID string
+}
+
+// auditActorKey is the context key under which the AuditActor is stored
+type auditActorKey struct{}
+
+// ContextWithAuditActor returns a copy of ctx carrying the given actor
+func ContextWithAuditActor(ctx context.Context, actor AuditActor) context.Context {
+	return context.WithValue(ctx, auditActorKey{}, actor)
+}
+
+// AuditActorFromContext returns the actor carried by ctx, if any
+func AuditActorFromContext(ctx context.Context) (AuditActor, bool) {
+	actor, ok := ctx.Value(auditActorKey{}).(AuditActor)
+	return actor, ok
+}
+
+// AuditService defines the contract for recording and querying audit logs
+type AuditService interface {
+	// Record persists an audit log entry, filling request details from the actor in ctx.
+	// When ctx carries a transaction the entry commits together with the audited change.
+	Record(ctx context.Context, entry *models.AuditLog) error
+
+	// RecordAction builds and records an audit log entry for an operation
+	RecordAction(ctx context.Context, tenantID, actorID, action, resourceType, resourceID string, before, after map[string]interface{}) error
+
+	// GetAuditLog retrieves an audit log entry by its ID with tenant isolation
+	GetAuditLog(ctx context.Context, id string, tenantID string) (*models.AuditLog, error)
+
+	// ListAuditLogs lists a tenant's audit log entries matching the filter with pagination
+	ListAuditLogs(ctx context.Context, tenantID string, filter models.AuditLogFilter, pagination *utils.Pagination) (utils.PaginatedResult[models.AuditLog], error)
+
+	// ExportAuditLogs writes a tenant's audit log entries matching the filter to w in the given
+	// format (csv or jsonl), newest first. Returns the number of entries written.
+	ExportAuditLogs(ctx context.Context, tenantID string, filter models.AuditLogFilter, format string, w io.Writer) (int, error)
+
+	// EnsurePartitions creates the audit log partitions for the month containing now and the following month
+	EnsurePartitions(ctx context.Context, now time.Time) error
+}
+
+// auditService implements the AuditService interface
+type auditService struct {
+	auditRepo repositories.AuditLogRepository
+	logger    *logger.Logger
+}
+
+// NewAuditService creates a new AuditService instance
+func NewAuditService(auditRepo repositories.AuditLogRepository) (AuditService, error) {
+	if auditRepo == nil {
+		return nil, fmt.Errorf("audit log repository cannot be nil")
+	}
+
+	return &auditService{
+		auditRepo: auditRepo,
+		logger:    logger.WithField("service", "audit"),
+	}, nil
+}
+
+// Record persists an audit log entry, filling request details from the actor in ctx
+func (s *auditService) Record(ctx context.Context, entry *models.AuditLog) error {
+	ctxLogger := logger.WithContext(ctx)
+
+	if entry == nil {
+		return errors.NewValidationError("audit log entry cannot be nil")
+	}
+
+	if actor, ok := AuditActorFromContext(ctx); ok {
+		if entry.ActorID == "" {
+			entry.ActorID = actor.UserID
+		}
+		if entry.ActorIP == "" {
+			entry.ActorIP = actor.IPAddress
+		}
+		if entry.UserAgent == "" {
+			entry.UserAgent = actor.UserAgent
+		}
+		if entry.RequestID == "" {
+			entry.RequestID = actor.RequestID
+		}
+	}
+
+	if err := entry.Validate(); err != nil {
+		return errors.NewValidationError(err.Error())
+	}
+
+	if _, err := s.auditRepo.Create(ctx, entry); err != nil {
+		ctxLogger.Error("Failed to record audit log", "error", err,
+			"tenant_id", entry.TenantID,
+			"action", entry.Action,
+			"resource_type", entry.ResourceType,
+			"resource_id", entry.ResourceID)
+		return errors.Wrap(err, "failed to record audit log")
+	}
+
+	return nil
+}
+
+// RecordAction builds and records an audit log entry for an operation
+func (s *auditService) RecordAction(ctx context.Context, tenantID, actorID, action, resourceType, resourceID string, before, after map[string]interface{}) error {
+	entry, err := models.NewAuditLog(tenantID, actorID, action, resourceType, resourceID, before, after)
+	if err != nil {
+		return errors.NewValidationError(err.Error())
+	}
+
+	return s.Record(ctx, entry)
+}
+
+// GetAuditLog retrieves an audit log entry by its ID with tenant isolation
+func (s *auditService) GetAuditLog(ctx context.Context, id string, tenantID string) (*models.AuditLog, error) {
+	ctxLogger := logger.WithContext(ctx)
+
+	if err := s.validateInput(map[string]string{
+		"audit log ID": id,
+		"tenant ID":    tenantID,
+	}); err != nil {
+		return nil, err
+	}
+
+	entry, err := s.auditRepo.GetByID(ctx, id, tenantID)
+	if err != nil {
+		ctxLogger.Error("Failed to get audit log", "error", err, "id", id, "tenant_id", tenantID)
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// ListAuditLogs lists a tenant's audit log entries matching the filter with pagination
+func (s *auditService) ListAuditLogs(ctx context.Context, tenantID string, filter models.AuditLogFilter, pagination *utils.Pagination) (utils.PaginatedResult[models.AuditLog], error) {
+	ctxLogger := logger.WithContext(ctx)
+
+	if err := s.validateInput(map[string]string{
+		"tenant ID": tenantID,
+	}); err != nil {
+		return utils.PaginatedResult[models.AuditLog]{}, err
+	}
+
+	if err := filter.Validate(); err != nil {
+		return utils.PaginatedResult[models.AuditLog]{}, errors.NewValidationError(err.Error())
+	}
+
+	if pagination == nil {
+		pagination = utils.NewPagination(utils.DefaultPage, utils.DefaultPageSize)
+	}
+
+	result, err := s.auditRepo.List(ctx, tenantID, filter, pagination)
+	if err != nil {
+		ctxLogger.Error("Failed to list audit logs", "error", err, "tenant_id", tenantID)
+		return utils.PaginatedResult[models.AuditLog]{}, err
+	}
+
+	return result, nil
+}
+
+// ExportAuditLogs writes a tenant's audit log entries matching the filter to w.
+// The upper time bound is pinned to the start of the export so that entries recorded
+// while the export runs do not shift the pages being read.
+func (s *auditService) ExportAuditLogs(ctx context.Context, tenantID string, filter models.AuditLogFilter, format string, w io.Writer) (int, error) {
+	ctxLogger := logger.WithContext(ctx)
+
+	if err := s.validateInput(map[string]string{
+		"tenant ID": tenantID,
+	}); err != nil {
+		return 0, err
+	}
+
+	if !models.IsValidAuditExportFormat(format) {
+		return 0, errors.NewValidationError(models.ErrAuditInvalidFormat.Error())
+	}
+
+	if err := filter.Validate(); err != nil {
+		return 0, errors.NewValidationError(err.Error())
+	}
+
+	if filter.To.IsZero() {
+		filter.To = time.Now().UTC()
+	}
+
+	var csvWriter *csv.Writer
+	if format == models.AuditExportFormatCSV {
+		csvWriter = csv.NewWriter(w)
+		if err := csvWriter.Write(auditExportColumns); err != nil {
+			return 0, errors.Wrap(err, "failed to write audit export header")
+		}
+	}
+	jsonEncoder := json.NewEncoder(w)
+
+	written := 0
+	for page := 1; written < MaxAuditExportRows; page++ {
+		result, err := s.auditRepo.List(ctx, tenantID, filter, utils.NewPagination(page, auditExportPageSize))
+		if err != nil {
+			ctxLogger.Error("Failed to read audit logs for export", "error", err, "tenant_id", tenantID, "page", page)
+			return written, err
+		}
+
+		for i := range result.Items {
+			if written >= MaxAuditExportRows {
+				break
+			}
+
+			entry := result.Items[i]
+			if csvWriter != nil {
+				err = csvWriter.Write(auditLogCSVRecord(&entry))
+			} else {
+				err = jsonEncoder.Encode(&entry)
+			}
+			if err != nil {
+				return written, errors.Wrap(err, "failed to write audit export")
+			}
+			written++
+		}
+
+		if len(result.Items) < auditExportPageSize {
+			break
+		}
+	}
+
+	if csvWriter != nil {
+		csvWriter.Flush()
+		if err := csvWriter.Error(); err != nil {
+			return written, errors.Wrap(err, "failed to write audit export")
+		}
+	}
+
+	ctxLogger.Info("Exported audit logs", "tenant_id", tenantID, "format", format, "count", written)
+	return written, nil
+}
+
+// EnsurePartitions creates the audit log partitions for the current and following month
+func (s *auditService) EnsurePartitions(ctx context.Context, now time.Time) error {
+	ctxLogger := logger.WithContext(ctx)
+
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	for _, month := range []time.Time{monthStart, monthStart.AddDate(0, 1, 0)} {
+		if err := s.auditRepo.EnsurePartition(ctx, month); err != nil {
+			ctxLogger.Error("Failed to ensure audit log partition", "error", err, "month", month.Format("2006-01"))
+			return errors.Wrap(err, "failed to ensure audit log partition")
+		}
+	}
+
+	return nil
+}
+
+// auditLogCSVRecord converts an audit log entry to a CSV record matching auditExportColumns
+func auditLogCSVRecord(entry *models.AuditLog) []string {
+	return []string{
+		entry.ID,
+		entry.OccurredAt.UTC().Format(time.RFC3339),
+		entry.TenantID,
+		entry.ActorID,
+		entry.ActorIP,
+		entry.UserAgent,
+		entry.RequestID,
+		entry.Action,
+		entry.ResourceType,
+		entry.ResourceID,
+		string(entry.Before),
+		string(entry.After),
+	}
+}
+
+// validateInput validates that required input parameters are not empty
+func (s *auditService) validateInput(params map[string]string) error {
+	for name, value := range params {
+		if value == "" {
+			return errors.NewValidationError(fmt.Sprintf("%s cannot be empty", name))
+		}
+	}
+	return nil
+}
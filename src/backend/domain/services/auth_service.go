@@ -4,6 +4,8 @@ package services
 import (
 	"context"
 	"time"
+
+	"../models"
 )
 
 // Permission constants define the available permission types in the system
@@ -12,6 +14,7 @@ const (
 	PermissionWrite         = "write"
 	PermissionDelete        = "delete"
 	PermissionManageFolders = "manage_folders"
+	PermissionAdmin         = "admin"
 )
 
 // Resource type constants define the types of resources that can be accessed
@@ -55,7 +58,7 @@ type AuthService interface {
 	//   - error: Error if refresh fails
 	RefreshToken(ctx context.Context, refreshToken string) (string, error)
 
-	// InvalidateToken invalidates a token (for logout).
+	// InvalidateToken invalidates a token (for logout). Invalidating a token of a session revokes the session.
 	// Parameters:
 	//   - ctx: Context for the operation
 	//   - token: The token to invalidate
@@ -75,6 +78,8 @@ type AuthService interface {
 	VerifyPermission(ctx context.Context, userID, tenantID, permission string) (bool, error)
 
 	// VerifyResourceAccess checks if a user has access to a specific resource.
+	// Grants on the resource are resolved from the user's direct grants, then group grants,
+	// then role grants, before falling back to the permissions of the user's roles.
 	// Parameters:
 	//   - ctx: Context for the operation
 	//   - userID: The ID of the user
@@ -120,6 +125,70 @@ type AuthService interface {
 	//   - error: Error if generation fails
 	GenerateRefreshToken(ctx context.Context, userID, tenantID string, expiration time.Duration) (string, error)
 
+	// GenerateGuestToken creates a read-only, time-boxed token for an external guest that
+	// grants access only to the document or folder of the scope.
+	// Parameters:
+	//   - ctx: Context for the operation
+	//   - scope: The resource, permissions and expiry the token is limited to
+	// Returns:
+	//   - string: Generated guest token
+	//   - error: Error if the scope is invalid or generation fails
+	GenerateGuestToken(ctx context.Context, scope *models.GuestScope) (string, error)
+
+	// ValidateGuestToken validates a guest token and extracts its scope.
+	// User tokens are rejected, and guest tokens are rejected by ValidateToken.
+	// Parameters:
+	//   - ctx: Context for the operation
+	//   - token: The guest token to validate
+	// Returns:
+	//   - *models.GuestScope: The scope the guest is limited to
+	//   - error: Error if the token is invalid
+	ValidateGuestToken(ctx context.Context, token string) (*models.GuestScope, error)
+
+	// CreateSession starts a session for a signed-in user on the client of the request and issues
+	// the session's access and refresh tokens. The client is taken from the audit actor of the context.
+	// Parameters:
+	//   - ctx: Context for the operation
+	//   - userID: The ID of the user
+	//   - tenantID: The ID of the tenant
+	//   - roles: The roles of the user
+	// Returns:
+	//   - string: Generated access token
+	//   - string: Generated refresh token
+	//   - error: Error if the session cannot be created
+	CreateSession(ctx context.Context, userID, tenantID string, roles []string) (string, string, error)
+
+	// RevokeSession revokes a session so that none of its access and refresh tokens are accepted anymore.
+	// Parameters:
+	//   - ctx: Context for the operation
+	//   - sessionID: The ID of the session
+	//   - tenantID: The ID of the tenant
+	// Returns:
+	//   - error: Error if the session does not exist or revocation fails
+	RevokeSession(ctx context.Context, sessionID, tenantID string) error
+
+	// GenerateMFAToken creates a short-lived partial-auth token for a user who has verified their
+	// password but not yet their second factor. It only grants access to MFA verification and enrollment.
+	// Parameters:
+	//   - ctx: Context for the operation
+	//   - userID: The ID of the user
+	//   - tenantID: The ID of the tenant
+	// Returns:
+	//   - string: Generated partial-auth token
+	//   - error: Error if generation fails
+	GenerateMFAToken(ctx context.Context, userID, tenantID string) (string, error)
+
+	// ValidateMFAToken validates a partial-auth token and extracts the user it was issued to.
+	// Other tokens are rejected, and partial-auth tokens are rejected by ValidateToken.
+	// Parameters:
+	//   - ctx: Context for the operation
+	//   - token: The partial-auth token to validate
+	// Returns:
+	//   - string: User ID extracted from the token
+	//   - string: Tenant ID extracted from the token
+	//   - error: Error if the token is invalid
+	ValidateMFAToken(ctx context.Context, token string) (string, string, error)
+
 	// SetTokenExpiration sets the default token expiration duration.
 	// Parameters:
 	//   - expiration: The token expiration duration
@@ -129,4 +198,20 @@ type AuthService interface {
 	// Parameters:
 	//   - expiration: The refresh token expiration duration
 	SetRefreshTokenExpiration(expiration time.Duration)
-}
\ No newline at end of file
+}
+
+// authenticatedAtKey is the context key under which the time the caller signed in is stored
+type authenticatedAtKey struct{}
+
+// ContextWithAuthenticatedAt returns a copy of ctx carrying when the caller last presented their
+// credentials, such as the sign-in time of the session of their access token. It is attached by
+// the API layer for policies that require a recent sign-in.
+func ContextWithAuthenticatedAt(ctx context.Context, authenticatedAt time.Time) context.Context {
+	return context.WithValue(ctx, authenticatedAtKey{}, authenticatedAt)
+}
+
+// AuthenticatedAtFromContext returns when the caller carried by ctx signed in, if known
+func AuthenticatedAtFromContext(ctx context.Context) (time.Time, bool) {
+	authenticatedAt, ok := ctx.Value(authenticatedAtKey{}).(time.Time)
+	return authenticatedAt, ok
+}
@@ -0,0 +1,30 @@
+// Package services provides domain-level services for the Document Management Platform
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"../models"
+	"../../pkg/errors"
+)
+
+// ContentHasher reads stored content back and calculates its SHA-256 hash, as both storage service
+// contracts do
+type ContentHasher interface {
+	HashDocument(ctx context.Context, storagePath string) (string, error)
+}
+
+// VerifyStoredContent reads the content at storagePath back from storage and checks that its SHA-256
+// hash is expectedHash, which finds content corrupted while it was written or copied. A mismatch
+// returns an error wrapping models.ErrChecksumMismatch.
+func VerifyStoredContent(ctx context.Context, hasher ContentHasher, storagePath string, expectedHash string) error {
+	actualHash, err := hasher.HashDocument(ctx, storagePath)
+	if err != nil {
+		return errors.Wrap(err, "failed to read stored content back")
+	}
+	if actualHash != expectedHash {
+		return fmt.Errorf("%w: stored content at %s has hash %s instead of %s", models.ErrChecksumMismatch, storagePath, actualHash, expectedHash)
+	}
+	return nil
+}
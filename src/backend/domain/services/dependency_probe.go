@@ -0,0 +1,22 @@
+// Package services provides domain-level services for the Document Management Platform
+package services
+
+import (
+	"context"
+)
+
+// DependencyProbe is implemented by the drivers of external dependencies that can check the
+// dependency is reachable, such as the database, search index, storage, scan queue and virus
+// scanner, so that readiness checks stop traffic to instances that cannot serve it
+type DependencyProbe interface {
+	// Ping checks the dependency is reachable and serving, returning an error when it is not
+	Ping(ctx context.Context) error
+}
+
+// DependencyProbeFunc adapts a function to the DependencyProbe interface
+type DependencyProbeFunc func(ctx context.Context) error
+
+// Ping calls f(ctx)
+func (f DependencyProbeFunc) Ping(ctx context.Context) error {
+	return f(ctx)
+}
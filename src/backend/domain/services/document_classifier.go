@@ -0,0 +1,220 @@
+// Package services provides domain-level services for the Document Management Platform
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"../models"
+	"../repositories"
+	"../../pkg/errors"
+	"../../pkg/logger"
+	"../../pkg/utils"
+)
+
+// classificationBatchSize is the number of versions of a tenant classified per classification pass
+const classificationBatchSize = 50
+
+// DefaultClassificationMaxContentBytes is how much of the content of each version is classified
+// when no limit is configured
+const DefaultClassificationMaxContentBytes = 1 << 20
+
+// PIIDetector finds personal data in the text of documents, such as with built-in patterns or a
+// machine learning service like AWS Comprehend.
+type PIIDetector interface {
+	// Name returns the name tenants select the detector by.
+	Name() string
+
+	// Detect returns the categories of personal data found in text, out of models.PIICategories.
+	Detect(ctx context.Context, text string) ([]string, error)
+}
+
+// DocumentClassifier tags documents with the categories of personal data detected in their content,
+// for the tenants that selected detectors
+type DocumentClassifier interface {
+	// ClassifyNext classifies a batch of the available document versions of every tenant that were
+	// not classified yet. Returns the number of versions classified, which is zero once all versions
+	// are classified.
+	ClassifyNext(ctx context.Context) (int, error)
+}
+
+// documentClassifier implements the DocumentClassifier interface
+type documentClassifier struct {
+	tenantRepo      repositories.TenantRepository
+	documentRepo    repositories.DocumentRepository
+	storageService  StorageService
+	detectors       map[string]PIIDetector
+	maxContentBytes int64
+}
+
+// NewDocumentClassifier creates a DocumentClassifier choosing among detectors. At most
+// maxContentBytes of the content of each version are classified, or
+// DefaultClassificationMaxContentBytes when it is not positive.
+func NewDocumentClassifier(tenantRepo repositories.TenantRepository, documentRepo repositories.DocumentRepository,
+	storageService StorageService, detectors []PIIDetector, maxContentBytes int64) (DocumentClassifier, error) {
+	if tenantRepo == nil {
+		return nil, fmt.Errorf("tenant repository cannot be nil")
+	}
+	if documentRepo == nil {
+		return nil, fmt.Errorf("document repository cannot be nil")
+	}
+	if storageService == nil {
+		return nil, fmt.Errorf("storage service cannot be nil")
+	}
+
+	byName := make(map[string]PIIDetector, len(detectors))
+	for _, detector := range detectors {
+		if detector == nil {
+			return nil, fmt.Errorf("PII detector cannot be nil")
+		}
+		byName[detector.Name()] = detector
+	}
+	if maxContentBytes <= 0 {
+		maxContentBytes = DefaultClassificationMaxContentBytes
+	}
+
+	return &documentClassifier{
+		tenantRepo:      tenantRepo,
+		documentRepo:    documentRepo,
+		storageService:  storageService,
+		detectors:       byName,
+		maxContentBytes: maxContentBytes,
+	}, nil
+}
+
+// ClassifyNext classifies the next batch of versions of each tenant that selected detectors
+func (c *documentClassifier) ClassifyNext(ctx context.Context) (int, error) {
+	ctxLogger := logger.WithContext(ctx)
+
+	classified := 0
+	for page := 1; ; page++ {
+		result, err := c.tenantRepo.List(ctx, utils.NewPagination(page, utils.MaxPageSize))
+		if err != nil {
+			return classified, errors.Wrap(err, "failed to list tenants")
+		}
+
+		for _, tenant := range result.Items {
+			names := tenant.PIIDetectors()
+			if len(names) == 0 || !tenant.IsActive() {
+				continue
+			}
+
+			detectors, err := c.tenantDetectors(names)
+			if err != nil {
+				ctxLogger.Error("Cannot classify tenant documents", "error", err, "tenant_id", tenant.ID)
+				continue
+			}
+
+			n, err := c.classifyTenant(ctx, tenant.ID, detectors)
+			classified += n
+			if err != nil {
+				return classified, err
+			}
+		}
+
+		if !result.Pagination.HasNext {
+			return classified, nil
+		}
+	}
+}
+
+// tenantDetectors returns the detectors a tenant selected. A tenant selecting a detector this
+// deployment does not run is not classified, rather than classified by fewer detectors than it
+// asked for.
+func (c *documentClassifier) tenantDetectors(names []string) ([]PIIDetector, error) {
+	detectors := make([]PIIDetector, 0, len(names))
+	for _, name := range names {
+		detector, ok := c.detectors[name]
+		if !ok {
+			return nil, errors.NewValidationError(fmt.Sprintf("PII detector %s is not available", name))
+		}
+		detectors = append(detectors, detector)
+	}
+	return detectors, nil
+}
+
+// classifyTenant classifies a batch of the tenant's versions. A version that cannot be classified is
+// logged and skipped, so one broken object does not hold up the rest of the tenant.
+func (c *documentClassifier) classifyTenant(ctx context.Context, tenantID string, detectors []PIIDetector) (int, error) {
+	ctxLogger := logger.WithContext(ctx)
+
+	versions, err := c.documentRepo.ListVersionsToClassify(ctx, tenantID, classificationBatchSize)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to list document versions to classify")
+	}
+
+	classified := 0
+	for _, version := range versions {
+		if err := ctx.Err(); err != nil {
+			return classified, err
+		}
+
+		categories, err := c.detect(ctx, version.StoragePath, detectors)
+		if err != nil {
+			ctxLogger.Error("Failed to classify document version", "error", err, "version_id", version.ID, "tenant_id", tenantID)
+			continue
+		}
+
+		// Tags are only ever added, since earlier versions with the personal data remain available
+		if len(categories) > 0 {
+			tags := make(map[string]string, len(categories))
+			for _, category := range categories {
+				tags[models.PIIMetadataKey(category)] = models.PIIMetadataValue
+			}
+			if err := c.documentRepo.BulkUpdateMetadata(ctx, []string{version.DocumentID}, tags, nil, tenantID); err != nil {
+				return classified, errors.Wrap(err, "failed to tag document with personal data")
+			}
+			ctxLogger.Info("Personal data detected in document", "document_id", version.DocumentID,
+				"version_id", version.ID, "tenant_id", tenantID, "categories", strings.Join(categories, ","))
+		}
+
+		if err := c.documentRepo.MarkVersionClassified(ctx, version.ID, time.Now(), tenantID); err != nil {
+			return classified, errors.Wrap(err, "failed to record version classification")
+		}
+		classified++
+	}
+
+	if classified > 0 {
+		ctxLogger.Info("Classified document versions", "tenant_id", tenantID, "count", classified)
+	}
+	return classified, nil
+}
+
+// detect reads the beginning of the content of a version and returns the categories of personal
+// data any of the detectors finds in it, in the order of models.PIICategories
+func (c *documentClassifier) detect(ctx context.Context, storagePath string, detectors []PIIDetector) ([]string, error) {
+	content, err := c.storageService.GetDocument(ctx, storagePath)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read document content")
+	}
+	defer content.Close()
+
+	data, err := ioutil.ReadAll(io.LimitReader(content, c.maxContentBytes))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read document content")
+	}
+	text := strings.ToValidUTF8(string(data), " ")
+
+	found := make(map[string]bool)
+	for _, detector := range detectors {
+		categories, err := detector.Detect(ctx, text)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("PII detector %s failed", detector.Name()))
+		}
+		for _, category := range categories {
+			found[category] = true
+		}
+	}
+
+	var categories []string
+	for _, category := range models.PIICategories {
+		if found[category] {
+			categories = append(categories, category)
+		}
+	}
+	return categories, nil
+}
@@ -22,9 +22,16 @@ type StorageService interface {
 	// StorePermanent moves a document from temporary storage to permanent storage
 	StorePermanent(ctx context.Context, tempLocation string, documentID string, versionID string, tenantID string) (string, error)
 	
+	// StoreDeduplicated moves a document from temporary storage to content-addressed storage,
+	// sharing the stored content with every version of the tenant with the same content hash
+	StoreDeduplicated(ctx context.Context, tenantID string, contentHash string, size int64, tempLocation string) (string, error)
+	
 	// GetDocument retrieves document content from storage
 	GetDocument(ctx context.Context, storagePath string) (io.ReadCloser, error)
 	
+	// HashDocument reads document content back from storage and returns its SHA-256 hash
+	HashDocument(ctx context.Context, storagePath string) (string, error)
+	
 	// GetPresignedURL generates a presigned URL for direct document download
 	GetPresignedURL(ctx context.Context, storagePath string, expirationSeconds int) (string, error)
 	
@@ -96,6 +103,8 @@ type documentService struct {
 	virusScanningService VirusScanningService
 	searchService        SearchService
 	eventService         EventServiceInterface
+	quotaService         QuotaService
+	metadataSchemaService MetadataSchemaService
 	logger               *logger.Logger
 }
 
@@ -106,6 +115,8 @@ func NewDocumentService(
 	virusScanningService VirusScanningService,
 	searchService SearchService,
 	eventService EventServiceInterface,
+	quotaService QuotaService,
+	metadataSchemaService MetadataSchemaService,
 ) DocumentService {
 	// Validate dependencies
 	if documentRepo == nil {
@@ -123,6 +134,12 @@ func NewDocumentService(
 	if eventService == nil {
 		panic("eventService is required")
 	}
+	if quotaService == nil {
+		panic("quotaService is required")
+	}
+	if metadataSchemaService == nil {
+		panic("metadataSchemaService is required")
+	}
 
 	return &documentService{
 		documentRepo:         documentRepo,
@@ -130,6 +147,8 @@ func NewDocumentService(
 		virusScanningService: virusScanningService,
 		searchService:        searchService,
 		eventService:         eventService,
+		quotaService:         quotaService,
+		metadataSchemaService: metadataSchemaService,
 		logger:               &logger.Logger{},
 	}
 }
@@ -254,7 +273,7 @@ func (s *documentService) DownloadDocument(ctx context.Context, id string, tenan
 		if document.IsProcessing() {
 			return nil, "", errors.NewValidationError("document is still being processed")
 		} else if document.IsQuarantined() {
-			return nil, "", errors.NewSecurityError("document has been quarantined due to security concerns")
+			return nil, "", errors.WithCode(errors.NewSecurityError("document has been quarantined due to security concerns"), errors.CodeDocumentQuarantined)
 		} else {
 			return nil, "", errors.NewValidationError("document is not available for download")
 		}
@@ -315,7 +334,7 @@ func (s *documentService) GetDocumentPresignedURL(ctx context.Context, id string
 		if document.IsProcessing() {
 			return "", errors.NewValidationError("document is still being processed")
 		} else if document.IsQuarantined() {
-			return "", errors.NewSecurityError("document has been quarantined due to security concerns")
+			return "", errors.WithCode(errors.NewSecurityError("document has been quarantined due to security concerns"), errors.CodeDocumentQuarantined)
 		} else {
 			return "", errors.NewValidationError("document is not available for download")
 		}
@@ -383,6 +402,17 @@ func (s *documentService) DeleteDocument(ctx context.Context, id string, tenantI
 		return errors.Wrap(err, "failed to delete document metadata")
 	}
 	
+	// Give the document's storage back to the tenant's quota
+	var size int64
+	for _, version := range document.Versions {
+		size += version.Size
+	}
+	err = s.quotaService.ReleaseDocument(ctx, tenantID, size)
+	if err != nil {
+		log.Warn("failed to release tenant quota", "document_id", id, "error", err.Error())
+		// Continue rather than failing the delete operation
+	}
+	
 	// Remove document from search index
 	err = s.searchService.RemoveDocumentFromIndex(ctx, id, tenantID)
 	if err != nil {
@@ -421,12 +451,31 @@ func (s *documentService) UpdateDocumentMetadata(ctx context.Context, id string,
 		return errors.NewValidationError("metadata cannot be empty")
 	}
 	
+	// Reserved keys are maintained by the platform, such as the tags of detected personal data
+	for key := range metadata {
+		if models.IsReservedMetadataKey(key) {
+			return errors.NewValidationError(fmt.Sprintf("metadata %s is reserved", key))
+		}
+	}
+	
 	// Retrieve document from repository
 	document, err := s.documentRepo.GetByID(ctx, id, tenantID)
 	if err != nil {
 		return errors.Wrap(err, "failed to retrieve document")
 	}
 	
+	// Validate the metadata the document will have against the tenant's schema before writing any of it
+	merged := make(map[string]string, len(document.Metadata)+len(metadata))
+	for _, m := range document.Metadata {
+		merged[m.Key] = m.Value
+	}
+	for key, value := range metadata {
+		merged[key] = value
+	}
+	if err := s.metadataSchemaService.ValidateMetadata(ctx, tenantID, merged); err != nil {
+		return err
+	}
+	
 	// Update each metadata field
 	for key, value := range metadata {
 		// Check if metadata already exists
@@ -631,12 +680,34 @@ func (s *documentService) ProcessDocumentScanResult(
 	
 	// Process scan result
 	if isClean {
-		// Move document from temporary to permanent storage
-		permanentPath, err := s.storageService.StorePermanent(ctx, version.StoragePath, documentID, versionID, tenantID)
+		// Move document from temporary to permanent storage. Versions with a SHA-256 content hash
+		// share the stored content with identical versions instead of storing another copy.
+		var permanentPath string
+		if utils.IsValidHash(version.ContentHash, utils.HashAlgorithmSHA256) {
+			permanentPath, err = s.storageService.StoreDeduplicated(ctx, tenantID, version.ContentHash, version.Size, version.StoragePath)
+		} else {
+			permanentPath, err = s.storageService.StorePermanent(ctx, version.StoragePath, documentID, versionID, tenantID)
+		}
 		if err != nil {
 			return errors.Wrap(err, "failed to move document to permanent storage")
 		}
 		
+		// Read the permanent copy back, so content corrupted while it was copied is never made available
+		if utils.IsValidHash(version.ContentHash, utils.HashAlgorithmSHA256) {
+			if err := VerifyStoredContent(ctx, s.storageService, permanentPath, version.ContentHash); err != nil {
+				log.Error("document in permanent storage failed verification", "document_id", documentID, "version_id", versionID, "error", err.Error())
+				version.MarkAsFailed()
+				document.MarkAsFailed()
+				if statusErr := s.documentRepo.UpdateVersionStatus(ctx, versionID, models.VersionStatusFailed, tenantID); statusErr != nil {
+					log.Error("failed to mark corrupted version failed", "version_id", versionID, "error", statusErr.Error())
+				}
+				return errors.Wrap(err, "failed to verify document in permanent storage")
+			}
+			if err := s.documentRepo.MarkVersionIntegrityVerified(ctx, versionID, time.Now(), tenantID); err != nil {
+				log.Warn("failed to record document integrity verification", "version_id", versionID, "error", err.Error())
+			}
+		}
+		
 		// Update document status and storage path
 		version.StoragePath = permanentPath
 		version.MarkAsAvailable()
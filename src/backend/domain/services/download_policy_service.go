@@ -0,0 +1,154 @@
+// Package services provides domain-level services for the Document Management Platform
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"../models"
+	"../repositories"
+	"../../pkg/errors"
+	"../../pkg/logger"
+)
+
+// Errors returned for downloads refused by the download policy of the document's folder
+var (
+	ErrDownloadViewOnly         = errors.WithCode(errors.NewAuthorizationError("documents in this folder can be viewed but not downloaded"), errors.CodeDownloadViewOnly)
+	ErrDownloadLimitReached     = errors.WithCode(errors.NewQuotaExceededError("daily download limit of this folder reached, try again tomorrow"), errors.CodeDownloadLimitReached)
+	ErrReauthenticationRequired = errors.WithCode(errors.NewAuthenticationError("sign in again to download documents from this folder"), errors.CodeReauthenticationRequired)
+	ErrDownloadSignInRequired   = errors.WithCode(errors.NewAuthorizationError("documents in this folder can only be downloaded by signed-in users"), errors.CodeDownloadSignInRequired)
+)
+
+// DownloadPolicyService enforces the download policies of folders: documents that can be previewed
+// but not downloaded, daily download limits per user, and downloads that need a recent sign-in
+type DownloadPolicyService interface {
+	// CheckDownload evaluates the download policy of the document's folder for a download by the
+	// user. The download is counted towards the user's daily limit when counted is set; continued
+	// range requests are not. Refused downloads are recorded in the audit log and return one of
+	// ErrDownloadViewOnly, ErrDownloadLimitReached and ErrReauthenticationRequired together with
+	// the evaluation.
+	CheckDownload(ctx context.Context, document *models.Document, userID string, counted bool) (*models.DownloadPolicyEvaluation, error)
+
+	// CheckExternalDownload evaluates the download policy of the document's folder for a download by
+	// a guest or through a share link. As they do not sign in, they cannot download documents that
+	// are view-only, which returns ErrDownloadViewOnly, or need a recent sign-in, which returns
+	// ErrDownloadSignInRequired; daily limits do not apply to them. Refusals are audited without an
+	// actor, with details identifying the guest or link.
+	CheckExternalDownload(ctx context.Context, document *models.Document, details map[string]interface{}) (*models.DownloadPolicyEvaluation, error)
+}
+
+// downloadPolicyService implements the DownloadPolicyService interface
+type downloadPolicyService struct {
+	folderRepo          repositories.FolderRepository
+	downloadCounterRepo repositories.DownloadCounterRepository
+	auditService        AuditService
+}
+
+// NewDownloadPolicyService creates a new DownloadPolicyService
+func NewDownloadPolicyService(folderRepo repositories.FolderRepository, downloadCounterRepo repositories.DownloadCounterRepository, auditService AuditService) (DownloadPolicyService, error) {
+	if folderRepo == nil {
+		return nil, fmt.Errorf("folder repository cannot be nil")
+	}
+	if downloadCounterRepo == nil {
+		return nil, fmt.Errorf("download counter repository cannot be nil")
+	}
+	if auditService == nil {
+		return nil, fmt.Errorf("audit service cannot be nil")
+	}
+
+	return &downloadPolicyService{
+		folderRepo:          folderRepo,
+		downloadCounterRepo: downloadCounterRepo,
+		auditService:        auditService,
+	}, nil
+}
+
+// CheckDownload checks the view-only flag, then the sign-in time, then counts the download
+func (s *downloadPolicyService) CheckDownload(ctx context.Context, document *models.Document, userID string, counted bool) (*models.DownloadPolicyEvaluation, error) {
+	evaluation, err := s.evaluate(ctx, document)
+	if err != nil || evaluation.Policy.IsEmpty() {
+		return evaluation, err
+	}
+	policy := evaluation.Policy
+
+	if policy.ViewOnly {
+		return s.deny(ctx, document, userID, nil, evaluation, models.DownloadDenialViewOnly, ErrDownloadViewOnly)
+	}
+
+	now := time.Now()
+	authenticatedAt, _ := AuthenticatedAtFromContext(ctx)
+	if policy.RequiresReauthentication(authenticatedAt, now) {
+		return s.deny(ctx, document, userID, nil, evaluation, models.DownloadDenialReauthentication, ErrReauthenticationRequired)
+	}
+
+	if policy.DailyLimit > 0 && counted {
+		count, ok, err := s.downloadCounterRepo.Increment(ctx, document.TenantID, userID, evaluation.FolderID, models.DownloadDayStart(now), policy.DailyLimit)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to count download")
+		}
+		evaluation.DownloadsToday = count
+		if !ok {
+			return s.deny(ctx, document, userID, nil, evaluation, models.DownloadDenialDailyLimit, ErrDownloadLimitReached)
+		}
+	}
+
+	return evaluation, nil
+}
+
+// CheckExternalDownload refuses documents that are view-only or need a recent sign-in
+func (s *downloadPolicyService) CheckExternalDownload(ctx context.Context, document *models.Document, details map[string]interface{}) (*models.DownloadPolicyEvaluation, error) {
+	evaluation, err := s.evaluate(ctx, document)
+	if err != nil || evaluation.Policy.IsEmpty() {
+		return evaluation, err
+	}
+
+	if evaluation.Policy.ViewOnly {
+		return s.deny(ctx, document, "", details, evaluation, models.DownloadDenialViewOnly, ErrDownloadViewOnly)
+	}
+	if evaluation.Policy.ReauthenticationMinutes > 0 {
+		return s.deny(ctx, document, "", details, evaluation, models.DownloadDenialReauthentication, ErrDownloadSignInRequired)
+	}
+	return evaluation, nil
+}
+
+// evaluate returns an allowing evaluation of the policy of the document's folder
+func (s *downloadPolicyService) evaluate(ctx context.Context, document *models.Document) (*models.DownloadPolicyEvaluation, error) {
+	evaluation := &models.DownloadPolicyEvaluation{FolderID: document.FolderID, Allowed: true}
+	if document.FolderID == "" {
+		return evaluation, nil
+	}
+
+	folder, err := s.folderRepo.GetByID(ctx, document.FolderID, document.TenantID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get document folder")
+	}
+	evaluation.Policy = folder.DownloadPolicy()
+	return evaluation, nil
+}
+
+// deny records a refused download in the audit log, with the given details, and returns the
+// evaluation with err
+func (s *downloadPolicyService) deny(ctx context.Context, document *models.Document, actorID string, details map[string]interface{}, evaluation *models.DownloadPolicyEvaluation, reason string, err error) (*models.DownloadPolicyEvaluation, error) {
+	evaluation.Allowed = false
+	evaluation.Reason = reason
+
+	logger.InfoContext(ctx, "Download refused by folder download policy",
+		"document_id", document.ID,
+		"folder_id", evaluation.FolderID,
+		"tenant_id", document.TenantID,
+		"actor_id", actorID,
+		"reason", reason)
+
+	after := map[string]interface{}{
+		"name":   document.Name,
+		"policy": evaluation.AuditDetails(),
+	}
+	for key, value := range details {
+		after[key] = value
+	}
+	if auditErr := s.auditService.RecordAction(ctx, document.TenantID, actorID, models.AuditActionDeny, models.ResourceTypeDocument, document.ID, nil, after); auditErr != nil {
+		logger.ErrorContext(ctx, "Failed to record refused download in audit log", "document_id", document.ID, "error", auditErr.Error())
+	}
+	return evaluation, err
+}
@@ -0,0 +1,31 @@
+package services
+
+import (
+	"context"
+
+	"../models"
+)
+
+// EmailSender defines the interface for sending transactional emails such as guest invitations
+type EmailSender interface {
+	// SendEmail sends a plain-text email
+	// It takes the recipient address, subject, and body
+	// Returns any error encountered while sending
+	SendEmail(ctx context.Context, to, subject, body string) error
+}
+
+// NamedEmailSender is implemented by email senders that can send under another name than the one
+// they are configured with, while keeping the address
+type NamedEmailSender interface {
+	// SendEmailAs sends a plain-text email like SendEmail, with senderName as the name of the sender
+	SendEmailAs(ctx context.Context, senderName, to, subject, body string) error
+}
+
+// SendTenantEmail sends an email on behalf of a tenant, under the tenant's notification sender name
+// when it has one and the sender supports it
+func SendTenantEmail(ctx context.Context, sender EmailSender, preferences models.TenantPreferences, to, subject, body string) error {
+	if named, ok := sender.(NamedEmailSender); ok && preferences.NotificationSender != "" {
+		return named.SendEmailAs(ctx, preferences.NotificationSender, to, subject, body)
+	}
+	return sender.SendEmail(ctx, to, subject, body)
+}
@@ -0,0 +1,21 @@
+// Package services defines domain service interfaces for the document management platform.
+package services
+
+import (
+	"context" // standard library
+)
+
+// EncryptionKeyService defines the contract for resolving the AWS KMS keys document content is
+// encrypted with. S3 requests a data key from KMS for every object it encrypts, so the content of
+// a tenant that brings its own key can only be read while the tenant grants access to that key.
+type EncryptionKeyService interface {
+	// GetTenantKeyID returns the KMS key new content of the tenant is encrypted with: the tenant's
+	// own key if it brings one, otherwise the platform's default key.
+	// An empty key ID means content is encrypted with S3 managed keys.
+	GetTenantKeyID(ctx context.Context, tenantID string) (string, error)
+
+	// VerifyKey checks that the platform can generate and decrypt data keys with the KMS key, as S3
+	// needs to when storing and reading content encrypted with it.
+	// Returns an error if the key cannot be used.
+	VerifyKey(ctx context.Context, keyID string) error
+}
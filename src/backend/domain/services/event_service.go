@@ -9,7 +9,6 @@ import (
 
 	"../models"
 	"../repositories"
-	"../../infrastructure/messaging/sns"
 	"../../pkg/logger"
 	"../../pkg/errors"
 	"../../pkg/utils"
@@ -32,27 +31,37 @@ type EventServiceInterface interface {
 	// PublishEvent publishes an event to the messaging system
 	PublishEvent(ctx context.Context, event *models.Event) error
 
-	// CreateAndPublishDocumentEvent creates and publishes a document-related event
+	// CreateAndPublishDocumentEvent creates a document-related event and enqueues it in the outbox for publishing
 	CreateAndPublishDocumentEvent(ctx context.Context, eventType string, tenantID string, documentID string, additionalData map[string]interface{}) (string, error)
 
-	// CreateAndPublishFolderEvent creates and publishes a folder-related event
+	// CreateAndPublishDocumentsEvent creates one event about many documents, such as a bulk change, and
+	// enqueues it in the outbox for publishing
+	CreateAndPublishDocumentsEvent(ctx context.Context, eventType string, tenantID string, documentIDs []string, additionalData map[string]interface{}) (string, error)
+
+	// CreateAndPublishFolderEvent creates a folder-related event and enqueues it in the outbox for publishing
 	CreateAndPublishFolderEvent(ctx context.Context, eventType string, tenantID string, folderID string, additionalData map[string]interface{}) (string, error)
 }
 
 // eventService implements the EventServiceInterface
 type eventService struct {
 	eventRepo      repositories.EventRepository
-	eventPublisher sns.EventPublisherInterface
+	outboxRepo     repositories.OutboxRepository
+	eventPublisher EventBus
 	logger         *logger.Logger
 }
 
 // NewEventService creates a new EventService instance
-func NewEventService(eventRepo repositories.EventRepository, eventPublisher sns.EventPublisherInterface) EventServiceInterface {
+func NewEventService(eventRepo repositories.EventRepository, outboxRepo repositories.OutboxRepository, eventPublisher EventBus) EventServiceInterface {
 	// Validate that eventRepo is not nil
 	if eventRepo == nil {
 		panic("eventRepo cannot be nil")
 	}
 
+	// Validate that outboxRepo is not nil
+	if outboxRepo == nil {
+		panic("outboxRepo cannot be nil")
+	}
+
 	// Validate that eventPublisher is not nil
 	if eventPublisher == nil {
 		panic("eventPublisher cannot be nil")
@@ -61,6 +70,7 @@ func NewEventService(eventRepo repositories.EventRepository, eventPublisher sns.
 	// Initialize logger
 	return &eventService{
 		eventRepo:      eventRepo,
+		outboxRepo:     outboxRepo,
 		eventPublisher: eventPublisher,
 		logger:         logger.WithField("service", "event_service"),
 	}
@@ -219,7 +229,10 @@ func (s *eventService) PublishEvent(ctx context.Context, event *models.Event) er
 		event.ID = eventID
 	}
 
-	// Call eventPublisher.PublishEvent to publish the event
+	// Publish the event with the request that raised it
+	if event.RequestID == "" {
+		event.RequestID = logger.RequestIDFromContext(ctx)
+	}
 	err := s.eventPublisher.PublishEvent(ctx, event)
 	if err != nil {
 		log.WithError(err).Error("Failed to publish event", "eventID", event.ID, "eventType", event.Type)
@@ -231,7 +244,9 @@ func (s *eventService) PublishEvent(ctx context.Context, event *models.Event) er
 	return nil
 }
 
-// CreateAndPublishDocumentEvent creates and publishes a document-related event
+// CreateAndPublishDocumentEvent creates a document-related event and enqueues it in the outbox.
+// When ctx carries a transaction the event is committed atomically with the caller's domain change;
+// the outbox relay publishes it afterwards.
 func (s *eventService) CreateAndPublishDocumentEvent(ctx context.Context, eventType string, tenantID string, documentID string, additionalData map[string]interface{}) (string, error) {
 	// Get logger with context
 	log := logger.WithContext(ctx)
@@ -280,22 +295,76 @@ func (s *eventService) CreateAndPublishDocumentEvent(ctx context.Context, eventT
 		return "", errors.NewInternalError("failed to create event")
 	}
 
-	// Call PublishEvent to persist and publish the event
-	err = s.PublishEvent(ctx, event)
+	// Persist the event and enqueue it in the outbox
+	err = s.enqueueEvent(ctx, event)
 	if err != nil {
-		log.WithError(err).Error("Failed to publish document event")
-		return "", errors.Wrap(err, "failed to publish document event")
+		log.WithError(err).Error("Failed to enqueue document event")
+		return "", errors.Wrap(err, "failed to enqueue document event")
 	}
 
-	// Log successful event creation and publishing
-	log.Info("Document event created and published successfully", 
+	// Log successful event creation and enqueueing
+	log.Info("Document event created and enqueued successfully", 
 		"eventID", event.ID, 
 		"eventType", eventType, 
 		"documentID", documentID)
 	return event.ID, nil
 }
 
-// CreateAndPublishFolderEvent creates and publishes a folder-related event
+// CreateAndPublishDocumentsEvent creates an event about many documents and enqueues it in the
+// outbox, with the IDs of the documents under documentIDs in its payload. Like
+// CreateAndPublishDocumentEvent, it joins the transaction carried by ctx.
+func (s *eventService) CreateAndPublishDocumentsEvent(ctx context.Context, eventType string, tenantID string, documentIDs []string, additionalData map[string]interface{}) (string, error) {
+	// Get logger with context
+	log := logger.WithContext(ctx)
+
+	if err := s.validateInput(map[string]string{
+		"event type": eventType,
+		"tenant ID":  tenantID,
+	}); err != nil {
+		log.WithError(err).Error("Invalid documents event")
+		return "", err
+	}
+	if len(documentIDs) == 0 {
+		log.Error("Document IDs cannot be empty")
+		return "", errors.NewValidationError("document IDs are required")
+	}
+
+	// Create payload map with documentIDs
+	payload := map[string]interface{}{
+		"documentIDs": documentIDs,
+	}
+	for k, v := range additionalData {
+		payload[k] = v
+	}
+
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		log.WithError(err).Error("Failed to marshal payload")
+		return "", errors.Wrap(err, "failed to marshal payload")
+	}
+
+	event := models.NewEvent(eventType, tenantID, payloadJSON)
+	if event == nil {
+		log.Error("Failed to create event")
+		return "", errors.NewInternalError("failed to create event")
+	}
+
+	// Persist the event and enqueue it in the outbox
+	if err := s.enqueueEvent(ctx, event); err != nil {
+		log.WithError(err).Error("Failed to enqueue documents event")
+		return "", errors.Wrap(err, "failed to enqueue documents event")
+	}
+
+	log.Info("Documents event created and enqueued successfully",
+		"eventID", event.ID,
+		"eventType", eventType,
+		"documents", len(documentIDs))
+	return event.ID, nil
+}
+
+// CreateAndPublishFolderEvent creates a folder-related event and enqueues it in the outbox.
+// When ctx carries a transaction the event is committed atomically with the caller's domain change;
+// the outbox relay publishes it afterwards.
 func (s *eventService) CreateAndPublishFolderEvent(ctx context.Context, eventType string, tenantID string, folderID string, additionalData map[string]interface{}) (string, error) {
 	// Get logger with context
 	log := logger.WithContext(ctx)
@@ -344,21 +413,47 @@ func (s *eventService) CreateAndPublishFolderEvent(ctx context.Context, eventTyp
 		return "", errors.NewInternalError("failed to create event")
 	}
 
-	// Call PublishEvent to persist and publish the event
-	err = s.PublishEvent(ctx, event)
+	// Persist the event and enqueue it in the outbox
+	err = s.enqueueEvent(ctx, event)
 	if err != nil {
-		log.WithError(err).Error("Failed to publish folder event")
-		return "", errors.Wrap(err, "failed to publish folder event")
+		log.WithError(err).Error("Failed to enqueue folder event")
+		return "", errors.Wrap(err, "failed to enqueue folder event")
 	}
 
-	// Log successful event creation and publishing
-	log.Info("Folder event created and published successfully", 
+	// Log successful event creation and enqueueing
+	log.Info("Folder event created and enqueued successfully", 
 		"eventID", event.ID, 
 		"eventType", eventType, 
 		"folderID", folderID)
 	return event.ID, nil
 }
 
+// enqueueEvent persists the event and writes an outbox message for it using the caller's context,
+// so both writes join any transaction the context carries
+func (s *eventService) enqueueEvent(ctx context.Context, event *models.Event) error {
+	// Persist the event to obtain its ID
+	if event.ID == "" {
+		eventID, err := s.CreateEvent(ctx, event)
+		if err != nil {
+			return err
+		}
+		event.ID = eventID
+	}
+
+	// Build the outbox message from the event
+	message, err := models.NewOutboxMessage(event)
+	if err != nil {
+		return errors.Wrap(err, "invalid outbox message")
+	}
+
+	// Write the outbox message for the relay to publish
+	if _, err := s.outboxRepo.Create(ctx, message); err != nil {
+		return errors.Wrap(err, "failed to write outbox message")
+	}
+
+	return nil
+}
+
 // validateInput validates input parameters
 func (s *eventService) validateInput(params map[string]string) error {
 	// Check each parameter in the map
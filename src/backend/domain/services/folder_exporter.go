@@ -0,0 +1,373 @@
+// Package services provides domain-level services for the Document Management Platform
+package services
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/uuid" // v1.3.0+ - For identifying export events
+
+	"../models"
+	"../repositories"
+	"../../pkg/errors"
+	"../../pkg/logger"
+	"../../pkg/utils"
+)
+
+// ExportDownloadURLExpirySeconds is how long the presigned URL of a completed export stays valid
+const ExportDownloadURLExpirySeconds = 24 * 60 * 60
+
+// exportJobStaleAfter is how long a running export may go without a progress update before
+// another worker assumes it was abandoned and runs it again
+const exportJobStaleAfter = 30 * time.Minute
+
+// exportProgressInterval is the number of documents written between progress updates
+const exportProgressInterval = 25
+
+// FolderExporter runs background exports of folder trees into ZIP archives
+type FolderExporter interface {
+	// ExportNext claims the next export job and streams its folder tree into an archive in storage.
+	// When the job finishes, an export.completed or export.failed event is enqueued with the job update.
+	// Returns false if there was no job to run.
+	ExportNext(ctx context.Context) (bool, error)
+}
+
+// folderExporter implements the FolderExporter interface
+type folderExporter struct {
+	exportJobRepo         repositories.ExportJobRepository
+	folderRepo            repositories.FolderRepository
+	documentRepo          repositories.DocumentRepository
+	outboxRepo            repositories.OutboxRepository
+	txManager             repositories.TransactionManager
+	storageService        StorageService
+	policyEngine          PolicyEngine
+	downloadPolicyService DownloadPolicyService
+}
+
+// exportEntry is a document to write to the archive under its path relative to the exported folder
+type exportEntry struct {
+	name        string
+	storagePath string
+	modified    time.Time
+}
+
+// NewFolderExporter creates a new FolderExporter instance
+func NewFolderExporter(exportJobRepo repositories.ExportJobRepository, folderRepo repositories.FolderRepository, documentRepo repositories.DocumentRepository,
+	outboxRepo repositories.OutboxRepository, txManager repositories.TransactionManager, storageService StorageService, policyEngine PolicyEngine,
+	downloadPolicyService DownloadPolicyService) (FolderExporter, error) {
+	if exportJobRepo == nil {
+		return nil, fmt.Errorf("export job repository cannot be nil")
+	}
+	if folderRepo == nil {
+		return nil, fmt.Errorf("folder repository cannot be nil")
+	}
+	if documentRepo == nil {
+		return nil, fmt.Errorf("document repository cannot be nil")
+	}
+	if outboxRepo == nil {
+		return nil, fmt.Errorf("outbox repository cannot be nil")
+	}
+	if txManager == nil {
+		return nil, fmt.Errorf("transaction manager cannot be nil")
+	}
+	if storageService == nil {
+		return nil, fmt.Errorf("storage service cannot be nil")
+	}
+	if policyEngine == nil {
+		return nil, fmt.Errorf("policy engine cannot be nil")
+	}
+	if downloadPolicyService == nil {
+		return nil, fmt.Errorf("download policy service cannot be nil")
+	}
+
+	return &folderExporter{
+		exportJobRepo:         exportJobRepo,
+		folderRepo:            folderRepo,
+		documentRepo:          documentRepo,
+		outboxRepo:            outboxRepo,
+		txManager:             txManager,
+		storageService:        storageService,
+		policyEngine:          policyEngine,
+		downloadPolicyService: downloadPolicyService,
+	}, nil
+}
+
+// ExportNext claims and runs the next export job. The archive is streamed into storage while it is
+// written, so exports of large folders never have to fit in memory. If the worker stops mid-export
+// the job stays running and is claimed again once it is stale.
+func (s *folderExporter) ExportNext(ctx context.Context) (bool, error) {
+	ctxLogger := logger.WithContext(ctx)
+
+	job, err := s.exportJobRepo.ClaimNext(ctx, time.Now().Add(-exportJobStaleAfter))
+	if err != nil {
+		return false, errors.Wrap(err, "failed to claim export job")
+	}
+	if job == nil {
+		return false, nil
+	}
+
+	ctxLogger.Info("Exporting folder", "export_id", job.ID, "folder_id", job.FolderID, "tenant_id", job.TenantID)
+
+	if _, err := s.folderRepo.GetByID(ctx, job.FolderID, job.TenantID); err != nil {
+		if errors.IsResourceNotFoundError(err) {
+			return true, s.fail(ctx, job, "folder not found")
+		}
+		return true, errors.Wrap(err, "failed to get exported folder")
+	}
+
+	entries, err := s.collectEntries(ctx, job)
+	if err != nil {
+		return true, errors.Wrap(err, "failed to list exported documents")
+	}
+
+	job.TotalDocuments = len(entries)
+	job.UpdatedAt = time.Now()
+	if err := s.exportJobRepo.Update(ctx, job); err != nil {
+		return true, errors.Wrap(err, "failed to update export job")
+	}
+
+	archivePath, archiveSize, reason, err := s.writeArchive(ctx, job, entries)
+	if err != nil {
+		if ctx.Err() != nil {
+			// Shutting down; the job is reclaimed by the next worker
+			return true, ctx.Err()
+		}
+		ctxLogger.Error("Failed to export folder", "error", err, "export_id", job.ID)
+		return true, s.fail(ctx, job, reason)
+	}
+
+	job.Complete(archivePath, archiveSize, time.Now())
+
+	downloadURL, err := s.storageService.GetPresignedURL(ctx, archivePath, job.ArchiveFileName(), ExportDownloadURLExpirySeconds)
+	if err != nil {
+		return true, errors.Wrap(err, "failed to generate export download URL")
+	}
+	event, err := models.NewExportCompletedEvent(job, downloadURL)
+	if err != nil {
+		return true, errors.Wrap(err, "failed to create export event")
+	}
+	if err := s.finish(ctx, job, event); err != nil {
+		return true, err
+	}
+
+	ctxLogger.Info("Folder exported", "export_id", job.ID, "document_count", job.ExportedDocuments, "archive_size", archiveSize)
+	return true, nil
+}
+
+// collectEntries lists the available documents of the folder tree with their paths in the archive.
+// Documents the tenant's access policies do not let the requesting user read, or whose folder's
+// download policy refuses the download, are left out, as they would be refused had the user
+// downloaded them one by one. Each exported document counts towards the daily download limit of its
+// folder. Exports run without the user's sign-in, so folders requiring a recent sign-in are left out.
+func (s *folderExporter) collectEntries(ctx context.Context, job *models.ExportJob) ([]exportEntry, error) {
+	type pendingFolder struct {
+		id   string
+		path string
+	}
+
+	var entries []exportEntry
+	queue := []pendingFolder{{id: job.FolderID}}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		usedNames := make(map[string]bool)
+		for page := 1; ; page++ {
+			result, err := s.documentRepo.ListByFolder(ctx, current.id, job.TenantID, utils.NewPagination(page, utils.MaxPageSize))
+			if err != nil {
+				return nil, err
+			}
+			for i := range result.Items {
+				document := &result.Items[i]
+				version := document.GetLatestVersion()
+				if !document.IsAvailable() || version == nil {
+					continue
+				}
+				if err := s.policyEngine.Enforce(ctx, job.TenantID, job.UserID, models.PolicyActionRead, document); err != nil {
+					if !errors.IsAuthorizationError(err) {
+						return nil, err
+					}
+					logger.WithContext(ctx).Info("Document left out of export by access policy", "export_id", job.ID, "document_id", document.ID)
+					continue
+				}
+				if evaluation, err := s.downloadPolicyService.CheckDownload(ctx, document, job.UserID, true); err != nil {
+					if evaluation == nil || evaluation.Allowed {
+						return nil, err
+					}
+					// The refusal is recorded in the audit log by the download policy service
+					continue
+				}
+				entries = append(entries, exportEntry{
+					name:        path.Join(current.path, uniqueEntryName(document.Name, usedNames)),
+					storagePath: version.StoragePath,
+					modified:    document.UpdatedAt,
+				})
+			}
+			if !result.Pagination.HasNext {
+				break
+			}
+		}
+
+		for page := 1; ; page++ {
+			result, err := s.folderRepo.GetChildren(ctx, current.id, job.TenantID, utils.NewPagination(page, utils.MaxPageSize))
+			if err != nil {
+				return nil, err
+			}
+			for _, child := range result.Items {
+				queue = append(queue, pendingFolder{id: child.ID, path: path.Join(current.path, uniqueEntryName(child.Name, usedNames))})
+			}
+			if !result.Pagination.HasNext {
+				break
+			}
+		}
+	}
+
+	return entries, nil
+}
+
+// writeArchive streams the entries into a ZIP archive in storage, updating the job's progress as it
+// goes. On failure it returns a reason that can be shown to the user along with the error.
+func (s *folderExporter) writeArchive(ctx context.Context, job *models.ExportJob, entries []exportEntry) (string, int64, string, error) {
+	reader, writer := io.Pipe()
+	counter := &countingWriter{writer: writer}
+
+	type writeResult struct {
+		reason string
+		err    error
+	}
+	done := make(chan writeResult, 1)
+
+	go func() {
+		reason, err := s.writeEntries(ctx, job, entries, counter)
+		writer.CloseWithError(err)
+		done <- writeResult{reason: reason, err: err}
+	}()
+
+	archivePath, storeErr := s.storageService.StoreArchive(ctx, job.TenantID, job.ID, reader)
+	// Unblock the writer if storage gave up before reading the whole archive
+	reader.CloseWithError(io.ErrClosedPipe)
+	result := <-done
+
+	if result.err != nil {
+		return "", 0, result.reason, result.err
+	}
+	if storeErr != nil {
+		return "", 0, "failed to store archive", storeErr
+	}
+
+	return archivePath, counter.written, "", nil
+}
+
+// writeEntries writes the documents into a ZIP archive
+func (s *folderExporter) writeEntries(ctx context.Context, job *models.ExportJob, entries []exportEntry, w io.Writer) (string, error) {
+	zipWriter := zip.NewWriter(w)
+
+	for i, entry := range entries {
+		if err := s.writeEntry(ctx, zipWriter, entry); err != nil {
+			return fmt.Sprintf("failed to export %s", entry.name), err
+		}
+
+		job.ExportedDocuments = i + 1
+		if job.ExportedDocuments%exportProgressInterval == 0 {
+			job.UpdatedAt = time.Now()
+			if err := s.exportJobRepo.Update(ctx, job); err != nil {
+				return "failed to update export progress", err
+			}
+		}
+	}
+
+	if err := zipWriter.Close(); err != nil {
+		return "failed to finish archive", err
+	}
+	return "", nil
+}
+
+// writeEntry copies a document from storage into the archive
+func (s *folderExporter) writeEntry(ctx context.Context, zipWriter *zip.Writer, entry exportEntry) error {
+	content, err := s.storageService.GetDocument(ctx, entry.storagePath)
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+
+	fileWriter, err := zipWriter.CreateHeader(&zip.FileHeader{
+		Name:     entry.name,
+		Method:   zip.Deflate,
+		Modified: entry.modified,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(fileWriter, content)
+	return err
+}
+
+// fail marks the job as failed and enqueues an export.failed event
+func (s *folderExporter) fail(ctx context.Context, job *models.ExportJob, reason string) error {
+	job.Fail(reason, time.Now())
+
+	event, err := models.NewExportFailedEvent(job)
+	if err != nil {
+		return errors.Wrap(err, "failed to create export event")
+	}
+	return s.finish(ctx, job, event)
+}
+
+// finish saves the finished job and writes its event to the outbox in one transaction, so the
+// event is published if and only if the job's final state is stored
+func (s *folderExporter) finish(ctx context.Context, job *models.ExportJob, event *models.Event) error {
+	event.ID = uuid.New().String()
+
+	message, err := models.NewOutboxMessage(event)
+	if err != nil {
+		return errors.Wrap(err, "invalid outbox message")
+	}
+
+	err = s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.exportJobRepo.Update(txCtx, job); err != nil {
+			return err
+		}
+		_, err := s.outboxRepo.Create(txCtx, message)
+		return err
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to finish export job")
+	}
+
+	return nil
+}
+
+// uniqueEntryName returns name, or name with a counter before its extension if it is already used
+// in the same folder of the archive. Names that would escape their folder are replaced.
+func uniqueEntryName(name string, used map[string]bool) string {
+	name = strings.ReplaceAll(name, "/", "_")
+	if name == "" || name == "." || name == ".." {
+		name = "_"
+	}
+	candidate := name
+	ext := path.Ext(name)
+	for n := 2; used[candidate]; n++ {
+		candidate = fmt.Sprintf("%s (%d)%s", strings.TrimSuffix(name, ext), n, ext)
+	}
+	used[candidate] = true
+	return candidate
+}
+
+// countingWriter counts the bytes written through it
+type countingWriter struct {
+	writer  io.Writer
+	written int64
+}
+
+// Write writes p to the underlying writer and counts the bytes written
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.writer.Write(p)
+	w.written += int64(n)
+	return n, err
+}
@@ -0,0 +1,123 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert" // v1.8.0+
+
+	"../models"
+	"../repositories"
+	"../../pkg/errors"
+	"../../pkg/utils"
+)
+
+// fakeExportDocumentRepo lists the documents of a single folder
+type fakeExportDocumentRepo struct {
+	repositories.DocumentRepository
+	documents []models.Document
+}
+
+func (r *fakeExportDocumentRepo) ListByFolder(ctx context.Context, folderID string, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Document], error) {
+	return utils.PaginatedResult[models.Document]{Items: r.documents}, nil
+}
+
+// fakeExportFolderRepo has no subfolders
+type fakeExportFolderRepo struct {
+	repositories.FolderRepository
+}
+
+func (r *fakeExportFolderRepo) GetChildren(ctx context.Context, parentID string, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Folder], error) {
+	return utils.PaginatedResult[models.Folder]{}, nil
+}
+
+// fakeExportPolicyEngine denies reading the listed documents
+type fakeExportPolicyEngine struct {
+	PolicyEngine
+	denied map[string]bool
+}
+
+func (e *fakeExportPolicyEngine) Enforce(ctx context.Context, tenantID, userID, action string, document *models.Document) error {
+	if e.denied[document.ID] {
+		return errors.NewAuthorizationError("access denied by policy")
+	}
+	return nil
+}
+
+// fakeExportDownloadPolicyService refuses downloads of the listed documents as view-only
+type fakeExportDownloadPolicyService struct {
+	DownloadPolicyService
+	viewOnly map[string]bool
+	checked  []string
+}
+
+func (s *fakeExportDownloadPolicyService) CheckDownload(ctx context.Context, document *models.Document, userID string, counted bool) (*models.DownloadPolicyEvaluation, error) {
+	s.checked = append(s.checked, document.ID)
+	if s.viewOnly[document.ID] {
+		return &models.DownloadPolicyEvaluation{FolderID: document.FolderID, Reason: models.DownloadDenialViewOnly}, ErrDownloadViewOnly
+	}
+	return &models.DownloadPolicyEvaluation{FolderID: document.FolderID, Allowed: true}, nil
+}
+
+// newExportableDocument creates an available document with a single version
+func newExportableDocument(id, name string) models.Document {
+	return models.Document{
+		ID:       id,
+		Name:     name,
+		TenantID: "tenant-1",
+		FolderID: "folder-1",
+		Status:   models.DocumentStatusAvailable,
+		Versions: []models.DocumentVersion{{ID: id + "-v1", VersionNumber: 1, StoragePath: "tenant-1/" + id}},
+	}
+}
+
+// entryNames returns the archive names of export entries
+func entryNames(entries []exportEntry) []string {
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.name)
+	}
+	return names
+}
+
+// TestCollectEntriesLeavesOutDocumentsDeniedByPolicy tests that exports only contain the documents
+// the tenant's access policies let the requesting user read
+func TestCollectEntriesLeavesOutDocumentsDeniedByPolicy(t *testing.T) {
+	exporter := &folderExporter{
+		documentRepo: &fakeExportDocumentRepo{documents: []models.Document{
+			newExportableDocument("doc-1", "public.pdf"),
+			newExportableDocument("doc-2", "restricted.pdf"),
+		}},
+		folderRepo:            &fakeExportFolderRepo{},
+		policyEngine:          &fakeExportPolicyEngine{denied: map[string]bool{"doc-2": true}},
+		downloadPolicyService: &fakeExportDownloadPolicyService{},
+	}
+	job := models.NewExportJob("tenant-1", "folder-1", "user-1")
+
+	entries, err := exporter.collectEntries(context.Background(), job)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"public.pdf"}, entryNames(entries))
+}
+
+// TestCollectEntriesLeavesOutDocumentsRefusedByDownloadPolicy tests that exports leave out the
+// documents whose folder's download policy refuses them, and check every other document
+func TestCollectEntriesLeavesOutDocumentsRefusedByDownloadPolicy(t *testing.T) {
+	downloadPolicyService := &fakeExportDownloadPolicyService{viewOnly: map[string]bool{"doc-2": true}}
+	exporter := &folderExporter{
+		documentRepo: &fakeExportDocumentRepo{documents: []models.Document{
+			newExportableDocument("doc-1", "report.pdf"),
+			newExportableDocument("doc-2", "contract.pdf"),
+		}},
+		folderRepo:            &fakeExportFolderRepo{},
+		policyEngine:          &fakeExportPolicyEngine{},
+		downloadPolicyService: downloadPolicyService,
+	}
+	job := models.NewExportJob("tenant-1", "folder-1", "user-1")
+
+	entries, err := exporter.collectEntries(context.Background(), job)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"report.pdf"}, entryNames(entries))
+	assert.Equal(t, []string{"doc-1", "doc-2"}, downloadPolicyService.checked)
+}
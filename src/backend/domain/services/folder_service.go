@@ -60,14 +60,20 @@ type FolderService interface {
 	// GetFolderByPath retrieves a folder by its path with tenant isolation and permission checks
 	GetFolderByPath(ctx context.Context, path, tenantID, userID string) (*models.Folder, error)
 	
-	// CreateFolderPermission creates a permission for a folder with tenant isolation and permission checks
-	CreateFolderPermission(ctx context.Context, folderID, roleID, permissionType, tenantID, userID string) (string, error)
+	// CreateFolderPermission grants a role, user or group (granteeType) a permission on a folder with tenant isolation and permission checks
+	CreateFolderPermission(ctx context.Context, folderID, granteeType, granteeID, permissionType, tenantID, userID string) (string, error)
 	
 	// DeleteFolderPermission deletes a permission for a folder with tenant isolation and permission checks
 	DeleteFolderPermission(ctx context.Context, permissionID, tenantID, userID string) error
 	
 	// GetFolderPermissions retrieves permissions for a folder with tenant isolation and permission checks
 	GetFolderPermissions(ctx context.Context, folderID, tenantID, userID string) ([]*models.Permission, error)
+	
+	// SetDownloadWatermark turns watermarking of documents downloaded from a folder on or off; it requires admin permission on the folder
+	SetDownloadWatermark(ctx context.Context, id string, enabled bool, tenantID, userID string) error
+	
+	// SetDownloadPolicy sets the download policy of the documents directly in a folder; it requires admin permission on the folder
+	SetDownloadPolicy(ctx context.Context, id string, policy models.DownloadPolicy, tenantID, userID string) error
 }
 
 // folderService implements the FolderService interface
@@ -77,6 +83,8 @@ type folderService struct {
 	permissionRepo  repositories.PermissionRepository
 	authService     AuthService
 	eventService    EventServiceInterface
+	txManager       repositories.TransactionManager
+	auditService    AuditService
 	logger          *logger.Logger
 }
 
@@ -87,6 +95,8 @@ func NewFolderService(
 	permissionRepo repositories.PermissionRepository,
 	authService AuthService,
 	eventService EventServiceInterface,
+	txManager repositories.TransactionManager,
+	auditService AuditService,
 ) FolderService {
 	// Validate required dependencies
 	if folderRepo == nil {
@@ -104,6 +114,12 @@ func NewFolderService(
 	if eventService == nil {
 		panic("eventService cannot be nil")
 	}
+	if txManager == nil {
+		panic("txManager cannot be nil")
+	}
+	if auditService == nil {
+		panic("auditService cannot be nil")
+	}
 	
 	return &folderService{
 		folderRepo:      folderRepo,
@@ -111,6 +127,8 @@ func NewFolderService(
 		permissionRepo:  permissionRepo,
 		authService:     authService,
 		eventService:    eventService,
+		txManager:       txManager,
+		auditService:    auditService,
 		logger:          logger.WithField("service", "folder_service"),
 	}
 }
@@ -200,22 +218,49 @@ func (s *folderService) CreateFolder(ctx context.Context, name, parentID, tenant
 		folder.SetPath(folder.BuildPath(""))
 	}
 	
-	// Save folder to repository
-	folderID, err := s.folderRepo.Create(ctx, folder)
+	// Save folder and enqueue the folder created event in a single transaction
+	var folderID string
+	err = s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		id, err := s.folderRepo.Create(txCtx, folder)
+		if err != nil {
+			log.WithError(err).Error("Failed to create folder", "name", name)
+			return errors.Wrap(err, "failed to create folder")
+		}
+		folderID = id
+
+		additionalData := map[string]interface{}{
+			"name":      name,
+			"parentID":  parentID,
+			"path":      folder.Path,
+			"createdBy": userID,
+		}
+
+		_, err = s.eventService.CreateAndPublishFolderEvent(txCtx, FolderEventCreated, tenantID, folderID, additionalData)
+		if err != nil {
+			log.WithError(err).Error("Failed to enqueue folder created event", "folderID", folderID)
+			return errors.Wrap(err, "failed to enqueue folder created event")
+		}
+
+		return s.auditService.RecordAction(txCtx, tenantID, userID, models.AuditActionCreate, models.ResourceTypeFolder, folderID, nil, map[string]interface{}{
+			"name":     name,
+			"parentID": parentID,
+			"path":     folder.Path,
+		})
+	})
 	if err != nil {
-		log.WithError(err).Error("Failed to create folder", "name", name)
-		return "", errors.Wrap(err, "failed to create folder")
+		return "", err
 	}
 	
-	// Create default permissions for the folder
+	// Create default permissions for the folder, granting the creator admin access directly
 	ownerPermission := models.NewPermission(
-		"owner", // This should be a role ID for the owner
+		"",
 		models.ResourceTypeFolder,
 		folderID,
 		models.PermissionTypeAdmin,
 		tenantID,
 		userID,
 	)
+	ownerPermission.UserID = userID
 	
 	_, err = s.permissionRepo.Create(ctx, ownerPermission)
 	if err != nil {
@@ -232,20 +277,6 @@ func (s *folderService) CreateFolder(ctx context.Context, name, parentID, tenant
 		}
 	}
 	
-	// Publish folder created event
-	additionalData := map[string]interface{}{
-		"name":      name,
-		"parentID":  parentID,
-		"path":      folder.Path,
-		"createdBy": userID,
-	}
-	
-	_, err = s.eventService.CreateAndPublishFolderEvent(ctx, FolderEventCreated, tenantID, folderID, additionalData)
-	if err != nil {
-		log.WithError(err).Error("Failed to publish folder created event", "folderID", folderID)
-		// We don't return error here as the folder was already created
-	}
-	
 	log.Info("Folder created successfully", "folderID", folderID, "name", name, "parentID", parentID)
 	return folderID, nil
 }
@@ -374,28 +405,151 @@ func (s *folderService) UpdateFolder(ctx context.Context, id, name, tenantID, us
 	}
 	
 	// Update folder
+	oldName := folder.Name
 	folder.Update(name)
 	
-	// Save changes to repository
-	err = s.folderRepo.Update(ctx, folder)
+	// Save changes and enqueue the folder updated event in a single transaction
+	err = s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.folderRepo.Update(txCtx, folder); err != nil {
+			log.WithError(err).Error("Failed to update folder", "folderID", id)
+			return errors.Wrap(err, "failed to update folder")
+		}
+
+		additionalData := map[string]interface{}{
+			"name":      name,
+			"updatedBy": userID,
+		}
+
+		if _, err := s.eventService.CreateAndPublishFolderEvent(txCtx, FolderEventUpdated, tenantID, id, additionalData); err != nil {
+			log.WithError(err).Error("Failed to enqueue folder updated event", "folderID", id)
+			return errors.Wrap(err, "failed to enqueue folder updated event")
+		}
+
+		return s.auditService.RecordAction(txCtx, tenantID, userID, models.AuditActionUpdate, models.ResourceTypeFolder, id,
+			map[string]interface{}{"name": oldName},
+			map[string]interface{}{"name": name})
+	})
 	if err != nil {
-		log.WithError(err).Error("Failed to update folder", "folderID", id)
-		return errors.Wrap(err, "failed to update folder")
+		return err
 	}
 	
-	// Publish folder updated event
-	additionalData := map[string]interface{}{
-		"name":      name,
-		"updatedBy": userID,
+	log.Info("Folder updated successfully", "folderID", id, "name", name)
+	return nil
+}
+
+// SetDownloadWatermark turns watermarking of documents downloaded from a folder on or off; it requires admin permission on the folder
+func (s *folderService) SetDownloadWatermark(ctx context.Context, id string, enabled bool, tenantID, userID string) error {
+	log := logger.WithContext(ctx)
+	
+	if strings.TrimSpace(id) == "" {
+		return errors.NewValidationError("folder ID is required")
+	}
+	if strings.TrimSpace(tenantID) == "" {
+		return errors.NewValidationError("tenant ID is required")
+	}
+	if strings.TrimSpace(userID) == "" {
+		return errors.NewValidationError("user ID is required")
 	}
 	
-	_, err = s.eventService.CreateAndPublishFolderEvent(ctx, FolderEventUpdated, tenantID, id, additionalData)
+	folder, err := s.folderRepo.GetByID(ctx, id, tenantID)
 	if err != nil {
-		log.WithError(err).Error("Failed to publish folder updated event", "folderID", id)
-		// We don't return error here as the folder was already updated
+		log.WithError(err).Error("Failed to get folder", "folderID", id)
+		return errors.Wrap(err, "failed to get folder")
+	}
+	if folder == nil || folder.TenantID != tenantID {
+		return ErrFolderNotFound
 	}
 	
-	log.Info("Folder updated successfully", "folderID", id, "name", name)
+	hasAccess, err := s.authService.VerifyResourceAccess(ctx, userID, tenantID, ResourceTypeFolder, id, PermissionAdmin)
+	if err != nil {
+		log.WithError(err).Error("Failed to verify folder access", "folderID", id)
+		return errors.Wrap(err, "failed to verify folder access")
+	}
+	if !hasAccess {
+		log.Error("User does not have admin permission for folder", "userID", userID, "folderID", id)
+		return ErrPermissionDenied
+	}
+	
+	if folder.WatermarkDownloads == enabled {
+		return nil
+	}
+	folder.SetWatermarkDownloads(enabled)
+	
+	err = s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.folderRepo.Update(txCtx, folder); err != nil {
+			log.WithError(err).Error("Failed to update folder", "folderID", id)
+			return errors.Wrap(err, "failed to update folder")
+		}
+		
+		return s.auditService.RecordAction(txCtx, tenantID, userID, models.AuditActionUpdate, models.ResourceTypeFolder, id,
+			map[string]interface{}{"watermarkDownloads": !enabled},
+			map[string]interface{}{"watermarkDownloads": enabled})
+	})
+	if err != nil {
+		return err
+	}
+	
+	log.Info("Folder download watermarking updated", "folderID", id, "enabled", enabled)
+	return nil
+}
+
+// SetDownloadPolicy sets the download policy of the documents directly in a folder; it requires admin permission on the folder
+func (s *folderService) SetDownloadPolicy(ctx context.Context, id string, policy models.DownloadPolicy, tenantID, userID string) error {
+	log := logger.WithContext(ctx)
+	
+	if strings.TrimSpace(id) == "" {
+		return errors.NewValidationError("folder ID is required")
+	}
+	if strings.TrimSpace(tenantID) == "" {
+		return errors.NewValidationError("tenant ID is required")
+	}
+	if strings.TrimSpace(userID) == "" {
+		return errors.NewValidationError("user ID is required")
+	}
+	if err := policy.Validate(); err != nil {
+		return errors.NewValidationError(err.Error())
+	}
+	
+	folder, err := s.folderRepo.GetByID(ctx, id, tenantID)
+	if err != nil {
+		log.WithError(err).Error("Failed to get folder", "folderID", id)
+		return errors.Wrap(err, "failed to get folder")
+	}
+	if folder == nil || folder.TenantID != tenantID {
+		return ErrFolderNotFound
+	}
+	
+	hasAccess, err := s.authService.VerifyResourceAccess(ctx, userID, tenantID, ResourceTypeFolder, id, PermissionAdmin)
+	if err != nil {
+		log.WithError(err).Error("Failed to verify folder access", "folderID", id)
+		return errors.Wrap(err, "failed to verify folder access")
+	}
+	if !hasAccess {
+		log.Error("User does not have admin permission for folder", "userID", userID, "folderID", id)
+		return ErrPermissionDenied
+	}
+	
+	previous := folder.DownloadPolicy()
+	if previous == policy {
+		return nil
+	}
+	folder.SetDownloadPolicy(policy)
+	
+	err = s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.folderRepo.Update(txCtx, folder); err != nil {
+			log.WithError(err).Error("Failed to update folder", "folderID", id)
+			return errors.Wrap(err, "failed to update folder")
+		}
+		
+		return s.auditService.RecordAction(txCtx, tenantID, userID, models.AuditActionUpdate, models.ResourceTypeFolder, id,
+			map[string]interface{}{"downloadPolicy": previous.AuditDetails()},
+			map[string]interface{}{"downloadPolicy": policy.AuditDetails()})
+	})
+	if err != nil {
+		return err
+	}
+	
+	log.Info("Folder download policy updated", "folderID", id, "viewOnly", policy.ViewOnly, "dailyLimit", policy.DailyLimit, "reauthenticationMinutes", policy.ReauthenticationMinutes)
 	return nil
 }
 
@@ -473,11 +627,32 @@ func (s *folderService) DeleteFolder(ctx context.Context, id, tenantID, userID s
 		return ErrCannotDeleteNonEmptyFolder
 	}
 	
-	// Delete folder from repository
-	err = s.folderRepo.Delete(ctx, id, tenantID)
+	// Delete folder and enqueue the folder deleted event in a single transaction
+	err = s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.folderRepo.Delete(txCtx, id, tenantID); err != nil {
+			log.WithError(err).Error("Failed to delete folder", "folderID", id)
+			return errors.Wrap(err, "failed to delete folder")
+		}
+
+		additionalData := map[string]interface{}{
+			"name":      folder.Name,
+			"parentID":  folder.ParentID,
+			"deletedBy": userID,
+		}
+
+		if _, err := s.eventService.CreateAndPublishFolderEvent(txCtx, FolderEventDeleted, tenantID, id, additionalData); err != nil {
+			log.WithError(err).Error("Failed to enqueue folder deleted event", "folderID", id)
+			return errors.Wrap(err, "failed to enqueue folder deleted event")
+		}
+
+		return s.auditService.RecordAction(txCtx, tenantID, userID, models.AuditActionDelete, models.ResourceTypeFolder, id, map[string]interface{}{
+			"name":     folder.Name,
+			"parentID": folder.ParentID,
+			"path":     folder.Path,
+		}, nil)
+	})
 	if err != nil {
-		log.WithError(err).Error("Failed to delete folder", "folderID", id)
-		return errors.Wrap(err, "failed to delete folder")
+		return err
 	}
 	
 	// Delete folder permissions
@@ -487,19 +662,6 @@ func (s *folderService) DeleteFolder(ctx context.Context, id, tenantID, userID s
 		// We don't return error here as the folder was already deleted
 	}
 	
-	// Publish folder deleted event
-	additionalData := map[string]interface{}{
-		"name":      folder.Name,
-		"parentID":  folder.ParentID,
-		"deletedBy": userID,
-	}
-	
-	_, err = s.eventService.CreateAndPublishFolderEvent(ctx, FolderEventDeleted, tenantID, id, additionalData)
-	if err != nil {
-		log.WithError(err).Error("Failed to publish folder deleted event", "folderID", id)
-		// We don't return error here as the folder was already deleted
-	}
-	
 	log.Info("Folder deleted successfully", "folderID", id)
 	return nil
 }
@@ -566,8 +728,11 @@ func (s *folderService) ListFolderContents(ctx context.Context, id, tenantID, us
 		return utils.PaginatedResult[models.Folder]{}, utils.PaginatedResult[models.Document]{}, errors.Wrap(err, "failed to get child folders")
 	}
 	
-	// Get documents in folder
-	documents, err := s.documentRepo.ListByFolder(ctx, id, tenantID, pagination)
+	// Get documents in folder. A cursor continues the child folders, so documents are paged by
+	// page number alone.
+	documentPagination := *pagination
+	documentPagination.Cursor = ""
+	documents, err := s.documentRepo.ListByFolder(ctx, id, tenantID, &documentPagination)
 	if err != nil {
 		log.WithError(err).Error("Failed to get documents in folder", "folderID", id)
 		return utils.PaginatedResult[models.Folder]{}, utils.PaginatedResult[models.Document]{}, errors.Wrap(err, "failed to get documents in folder")
@@ -726,25 +891,31 @@ func (s *folderService) MoveFolder(ctx context.Context, id, newParentID, tenantI
 		}
 	}
 	
-	// Move folder
-	err = s.folderRepo.Move(ctx, id, newParentID, tenantID)
-	if err != nil {
-		log.WithError(err).Error("Failed to move folder", "folderID", id, "newParentID", newParentID)
-		return errors.Wrap(err, "failed to move folder")
-	}
-	
-	// Publish folder moved event
-	additionalData := map[string]interface{}{
-		"name":        folder.Name,
-		"oldParentID": folder.ParentID,
-		"newParentID": newParentID,
-		"movedBy":     userID,
-	}
-	
-	_, err = s.eventService.CreateAndPublishFolderEvent(ctx, FolderEventMoved, tenantID, id, additionalData)
+	// Move folder and enqueue the folder moved event in a single transaction
+	err = s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.folderRepo.Move(txCtx, id, newParentID, tenantID); err != nil {
+			log.WithError(err).Error("Failed to move folder", "folderID", id, "newParentID", newParentID)
+			return errors.Wrap(err, "failed to move folder")
+		}
+
+		additionalData := map[string]interface{}{
+			"name":        folder.Name,
+			"oldParentID": folder.ParentID,
+			"newParentID": newParentID,
+			"movedBy":     userID,
+		}
+
+		if _, err := s.eventService.CreateAndPublishFolderEvent(txCtx, FolderEventMoved, tenantID, id, additionalData); err != nil {
+			log.WithError(err).Error("Failed to enqueue folder moved event", "folderID", id)
+			return errors.Wrap(err, "failed to enqueue folder moved event")
+		}
+
+		return s.auditService.RecordAction(txCtx, tenantID, userID, models.AuditActionMove, models.ResourceTypeFolder, id,
+			map[string]interface{}{"parentID": folder.ParentID, "path": folder.Path},
+			map[string]interface{}{"parentID": newParentID})
+	})
 	if err != nil {
-		log.WithError(err).Error("Failed to publish folder moved event", "folderID", id)
-		// We don't return error here as the folder was already moved
+		return err
 	}
 	
 	log.Info("Folder moved successfully", "folderID", id, "oldParentID", folder.ParentID, "newParentID", newParentID)
@@ -856,8 +1027,8 @@ func (s *folderService) GetFolderByPath(ctx context.Context, path, tenantID, use
 	return folder, nil
 }
 
-// CreateFolderPermission creates a permission for a folder with tenant isolation and permission checks
-func (s *folderService) CreateFolderPermission(ctx context.Context, folderID, roleID, permissionType, tenantID, userID string) (string, error) {
+// CreateFolderPermission grants a role, user or group a permission on a folder with tenant isolation and permission checks
+func (s *folderService) CreateFolderPermission(ctx context.Context, folderID, granteeType, granteeID, permissionType, tenantID, userID string) (string, error) {
 	log := logger.WithContext(ctx)
 	
 	// Validate input
@@ -866,9 +1037,14 @@ func (s *folderService) CreateFolderPermission(ctx context.Context, folderID, ro
 		return "", errors.NewValidationError("folder ID is required")
 	}
 	
-	if strings.TrimSpace(roleID) == "" {
-		log.Error("Role ID cannot be empty")
-		return "", errors.NewValidationError("role ID is required")
+	if !models.IsValidGranteeType(granteeType) {
+		log.Error("Invalid grantee type", "granteeType", granteeType)
+		return "", errors.NewValidationError(models.ErrInvalidGranteeType.Error())
+	}
+	
+	if strings.TrimSpace(granteeID) == "" {
+		log.Error("Grantee ID cannot be empty")
+		return "", errors.NewValidationError("grantee ID is required")
 	}
 	
 	if strings.TrimSpace(permissionType) == "" {
@@ -917,12 +1093,15 @@ func (s *folderService) CreateFolderPermission(ctx context.Context, folderID, ro
 	}
 	
 	// Create permission
-	permission := models.NewPermission(roleID, models.ResourceTypeFolder, folderID, permissionType, tenantID, userID)
+	permission, err := models.NewGrant(granteeType, granteeID, models.ResourceTypeFolder, folderID, permissionType, tenantID, userID)
+	if err != nil {
+		return "", errors.NewValidationError(err.Error())
+	}
 	
 	// Save permission to repository
 	permissionID, err := s.permissionRepo.Create(ctx, permission)
 	if err != nil {
-		log.WithError(err).Error("Failed to create folder permission", "folderID", folderID, "roleID", roleID)
+		log.WithError(err).Error("Failed to create folder permission", "folderID", folderID, "granteeType", granteeType, "granteeID", granteeID)
 		return "", errors.Wrap(err, "failed to create folder permission")
 	}
 	
@@ -933,7 +1112,20 @@ func (s *folderService) CreateFolderPermission(ctx context.Context, folderID, ro
 		// We don't return error here as the permission was already created
 	}
 	
-	log.Info("Folder permission created successfully", "folderID", folderID, "roleID", roleID, "permissionType", permissionType)
+	// Record the grant in the audit log
+	err = s.auditService.RecordAction(ctx, tenantID, userID, models.AuditActionGrant, models.AuditResourcePermission, permissionID, nil, map[string]interface{}{
+		"resourceType":   models.ResourceTypeFolder,
+		"resourceID":     folderID,
+		"granteeType":    granteeType,
+		"granteeID":      granteeID,
+		"permissionType": permissionType,
+	})
+	if err != nil {
+		log.WithError(err).Error("Failed to record folder permission grant", "permissionID", permissionID)
+		// We don't return error here as the permission was already created
+	}
+	
+	log.Info("Folder permission created successfully", "folderID", folderID, "granteeType", granteeType, "granteeID", granteeID, "permissionType", permissionType)
 	return permissionID, nil
 }
 
@@ -1012,6 +1204,19 @@ func (s *folderService) DeleteFolderPermission(ctx context.Context, permissionID
 		return errors.Wrap(err, "failed to delete folder permission")
 	}
 	
+	// Record the revocation in the audit log
+	err = s.auditService.RecordAction(ctx, tenantID, userID, models.AuditActionRevoke, models.AuditResourcePermission, permissionID, map[string]interface{}{
+		"resourceType":   permission.ResourceType,
+		"resourceID":     permission.ResourceID,
+		"granteeType":    permission.GranteeType(),
+		"granteeID":      permission.GranteeID(),
+		"permissionType": permission.PermissionType,
+	}, nil)
+	if err != nil {
+		log.WithError(err).Error("Failed to record folder permission revocation", "permissionID", permissionID)
+		// We don't return error here as the permission was already deleted
+	}
+	
 	log.Info("Folder permission deleted successfully", "permissionID", permissionID, "folderID", folder.ID)
 	return nil
 }
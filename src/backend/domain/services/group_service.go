@@ -0,0 +1,236 @@
+// Package services provides domain-level services for the Document Management Platform
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"../models"
+	"../repositories"
+	"../../pkg/errors"
+	"../../pkg/logger"
+	"../../pkg/utils"
+)
+
+// GroupService defines the contract for managing a tenant's user groups and their members
+type GroupService interface {
+	// CreateGroup creates a group in a tenant
+	CreateGroup(ctx context.Context, tenantID, name, description, createdBy string) (*models.Group, error)
+
+	// GetGroup retrieves a group by its ID with tenant isolation
+	GetGroup(ctx context.Context, id string, tenantID string) (*models.Group, error)
+
+	// ListGroups lists a tenant's groups with pagination
+	ListGroups(ctx context.Context, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Group], error)
+
+	// UpdateGroup updates the name and description of a group
+	UpdateGroup(ctx context.Context, id, tenantID, name, description string) (*models.Group, error)
+
+	// DeleteGroup deletes a group. Its members lose the permissions granted to the group.
+	DeleteGroup(ctx context.Context, id string, tenantID string) error
+
+	// AddMember adds a user of the tenant to a group
+	AddMember(ctx context.Context, groupID, userID, tenantID, addedBy string) error
+
+	// RemoveMember removes a user from a group
+	RemoveMember(ctx context.Context, groupID, userID, tenantID string) error
+
+	// ListMembers lists the members of a group with pagination
+	ListMembers(ctx context.Context, groupID, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.GroupMembership], error)
+}
+
+// groupService implements the GroupService interface
+type groupService struct {
+	groupRepo repositories.GroupRepository
+	userRepo  repositories.UserRepository
+}
+
+// NewGroupService creates a new GroupService instance
+func NewGroupService(groupRepo repositories.GroupRepository, userRepo repositories.UserRepository) (GroupService, error) {
+	if groupRepo == nil {
+		return nil, fmt.Errorf("group repository cannot be nil")
+	}
+	if userRepo == nil {
+		return nil, fmt.Errorf("user repository cannot be nil")
+	}
+
+	return &groupService{
+		groupRepo: groupRepo,
+		userRepo:  userRepo,
+	}, nil
+}
+
+// CreateGroup creates a group in a tenant
+func (s *groupService) CreateGroup(ctx context.Context, tenantID, name, description, createdBy string) (*models.Group, error) {
+	ctxLogger := logger.WithContext(ctx)
+
+	name = strings.TrimSpace(name)
+	if err := s.validateInput(map[string]string{
+		"tenant ID":  tenantID,
+		"group name": name,
+		"creator ID": createdBy,
+	}); err != nil {
+		return nil, err
+	}
+
+	group := models.NewGroup(name, description, tenantID, createdBy)
+	if err := group.Validate(); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	if _, err := s.groupRepo.Create(ctx, group); err != nil {
+		ctxLogger.Error("Failed to create group", "error", err, "tenant_id", tenantID, "name", name)
+		return nil, err
+	}
+
+	ctxLogger.Info("Group created", "group_id", group.ID, "tenant_id", tenantID, "name", name)
+	return group, nil
+}
+
+// GetGroup retrieves a group by its ID with tenant isolation
+func (s *groupService) GetGroup(ctx context.Context, id string, tenantID string) (*models.Group, error) {
+	if err := s.validateInput(map[string]string{
+		"group ID":  id,
+		"tenant ID": tenantID,
+	}); err != nil {
+		return nil, err
+	}
+
+	return s.groupRepo.GetByID(ctx, id, tenantID)
+}
+
+// ListGroups lists a tenant's groups with pagination
+func (s *groupService) ListGroups(ctx context.Context, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Group], error) {
+	if err := s.validateInput(map[string]string{
+		"tenant ID": tenantID,
+	}); err != nil {
+		return utils.PaginatedResult[models.Group]{}, err
+	}
+
+	if pagination == nil {
+		pagination = utils.NewPagination(utils.DefaultPage, utils.DefaultPageSize)
+	}
+
+	return s.groupRepo.List(ctx, tenantID, pagination)
+}
+
+// UpdateGroup updates the name and description of a group. An empty name or description is left unchanged.
+func (s *groupService) UpdateGroup(ctx context.Context, id, tenantID, name, description string) (*models.Group, error) {
+	ctxLogger := logger.WithContext(ctx)
+
+	group, err := s.GetGroup(ctx, id, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if name = strings.TrimSpace(name); name != "" {
+		group.Name = name
+	}
+	if description != "" {
+		group.Description = description
+	}
+
+	if err := s.groupRepo.Update(ctx, group); err != nil {
+		ctxLogger.Error("Failed to update group", "error", err, "group_id", id, "tenant_id", tenantID)
+		return nil, err
+	}
+
+	ctxLogger.Info("Group updated", "group_id", id, "tenant_id", tenantID)
+	return group, nil
+}
+
+// DeleteGroup deletes a group together with its memberships and the permissions granted to it
+func (s *groupService) DeleteGroup(ctx context.Context, id string, tenantID string) error {
+	ctxLogger := logger.WithContext(ctx)
+
+	if err := s.validateInput(map[string]string{
+		"group ID":  id,
+		"tenant ID": tenantID,
+	}); err != nil {
+		return err
+	}
+
+	if err := s.groupRepo.Delete(ctx, id, tenantID); err != nil {
+		ctxLogger.Error("Failed to delete group", "error", err, "group_id", id, "tenant_id", tenantID)
+		return err
+	}
+
+	ctxLogger.Info("Group deleted", "group_id", id, "tenant_id", tenantID)
+	return nil
+}
+
+// AddMember adds a user of the tenant to a group. Adding an existing member is a no-op.
+func (s *groupService) AddMember(ctx context.Context, groupID, userID, tenantID, addedBy string) error {
+	ctxLogger := logger.WithContext(ctx)
+
+	if err := s.validateInput(map[string]string{
+		"group ID":  groupID,
+		"user ID":   userID,
+		"tenant ID": tenantID,
+		"actor ID":  addedBy,
+	}); err != nil {
+		return err
+	}
+
+	// Both the group and the user must belong to the tenant
+	if _, err := s.groupRepo.GetByID(ctx, groupID, tenantID); err != nil {
+		return err
+	}
+	if _, err := s.userRepo.GetByID(ctx, userID, tenantID); err != nil {
+		return err
+	}
+
+	membership := models.NewGroupMembership(groupID, userID, tenantID, addedBy)
+	if err := s.groupRepo.AddMember(ctx, membership); err != nil {
+		ctxLogger.Error("Failed to add group member", "error", err, "group_id", groupID, "user_id", userID, "tenant_id", tenantID)
+		return err
+	}
+
+	ctxLogger.Info("Group member added", "group_id", groupID, "user_id", userID, "tenant_id", tenantID)
+	return nil
+}
+
+// RemoveMember removes a user from a group
+func (s *groupService) RemoveMember(ctx context.Context, groupID, userID, tenantID string) error {
+	ctxLogger := logger.WithContext(ctx)
+
+	if err := s.validateInput(map[string]string{
+		"group ID":  groupID,
+		"user ID":   userID,
+		"tenant ID": tenantID,
+	}); err != nil {
+		return err
+	}
+
+	if err := s.groupRepo.RemoveMember(ctx, groupID, userID, tenantID); err != nil {
+		ctxLogger.Error("Failed to remove group member", "error", err, "group_id", groupID, "user_id", userID, "tenant_id", tenantID)
+		return err
+	}
+
+	ctxLogger.Info("Group member removed", "group_id", groupID, "user_id", userID, "tenant_id", tenantID)
+	return nil
+}
+
+// ListMembers lists the members of a group with pagination
+func (s *groupService) ListMembers(ctx context.Context, groupID, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.GroupMembership], error) {
+	if _, err := s.GetGroup(ctx, groupID, tenantID); err != nil {
+		return utils.PaginatedResult[models.GroupMembership]{}, err
+	}
+
+	if pagination == nil {
+		pagination = utils.NewPagination(utils.DefaultPage, utils.DefaultPageSize)
+	}
+
+	return s.groupRepo.ListMembers(ctx, groupID, tenantID, pagination)
+}
+
+// validateInput validates that required input parameters are not empty
+func (s *groupService) validateInput(params map[string]string) error {
+	for name, value := range params {
+		if value == "" {
+			return errors.NewValidationError(fmt.Sprintf("%s cannot be empty", name))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package services
+
+import (
+	"context"
+
+	"../models"
+)
+
+// IdentityProvider defines the interface for verifying ID tokens issued by a tenant's external
+// OpenID Connect identity provider, such as Okta or Azure AD
+type IdentityProvider interface {
+	// VerifyIDToken verifies the signature, issuer, audience and expiry of an ID token
+	// It takes the tenant ID and the raw ID token issued by the tenant's provider
+	// Returns the asserted identity with its IdP groups mapped to tenant roles, or an
+	// authentication error if the token is invalid or the tenant does not use single sign-on
+	VerifyIDToken(ctx context.Context, tenantID, rawIDToken string) (*models.ExternalIdentity, error)
+
+	// ProvisioningPolicy reports how unknown users of a tenant are handled on first sign-in
+	// It takes the tenant ID
+	// Returns whether users are created just in time, and the role given to a created user
+	// none of whose groups are mapped
+	ProvisioningPolicy(tenantID string) (bool, string)
+}
@@ -0,0 +1,34 @@
+package services
+
+import (
+	"context"
+	"io"
+)
+
+// ImportSourceFile is a file of an import source
+type ImportSourceFile struct {
+	// Key is the path of the file relative to the root of the source, with slashes as separators
+	Key string
+
+	// Size is the size of the file in bytes
+	Size int64
+}
+
+// ImportSource defines the interface for reading the files of a legacy archive that is imported
+// into a tenant, such as a directory tree or the objects under an S3 prefix
+type ImportSource interface {
+	// Walk calls fn for each file whose key sorts after the given key, in byte order of the keys,
+	// so that an interrupted import can continue after the last file it handled. An empty key
+	// walks every file. Walk stops at the first error fn returns and returns it.
+	Walk(ctx context.Context, after string, fn func(file ImportSourceFile) error) error
+
+	// Open opens the content of the file with a key
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+}
+
+// ImportSourceResolver defines the interface for opening import sources by their location
+type ImportSourceResolver interface {
+	// Resolve returns the source at a location, which is the absolute path of a directory or an
+	// s3://bucket/prefix URL
+	Resolve(ctx context.Context, location string) (ImportSource, error)
+}
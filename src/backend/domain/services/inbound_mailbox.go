@@ -0,0 +1,19 @@
+package services
+
+import (
+	"context"
+	"io"
+)
+
+// InboundMailbox defines the interface for reading received emails, such as the raw MIME messages
+// that Amazon SES stores in an S3 bucket
+type InboundMailbox interface {
+	// ListMessages lists the keys of up to limit received messages
+	ListMessages(ctx context.Context, limit int) ([]string, error)
+
+	// GetMessage opens the raw MIME message with a key
+	GetMessage(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// DeleteMessage removes a message once it has been handled
+	DeleteMessage(ctx context.Context, key string) error
+}
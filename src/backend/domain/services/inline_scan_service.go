@@ -0,0 +1,188 @@
+// Package services provides domain-level services for the Document Management Platform
+package services
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"time"
+
+	"../models"
+	"../repositories"
+	"../../pkg/logger"
+	"../../pkg/utils"
+)
+
+// InlineScanService scans small uploads while they are uploaded, streaming their content to the
+// scanning engines of the tenant, such as a ClamAV INSTREAM call, so they are available as soon as
+// the upload completes instead of waiting for the scan queue
+type InlineScanService interface {
+	// Applies checks if uploads of size bytes are small enough to be scanned inline
+	Applies(size int64) bool
+
+	// ScanUpload scans content, the content of a new version already stored temporarily, within the
+	// inline scan timeout. A clean version is moved to permanent storage and made available, with its
+	// document, and true is returned. Otherwise false is returned and the version must be queued for
+	// scanning: infected content is quarantined by the scan queue, and failures and timeouts are
+	// retried there. Content corrupted while it was moved to permanent storage fails the version,
+	// which is not queued either, and true is returned.
+	ScanUpload(ctx context.Context, document *models.Document, version *models.DocumentVersion, content io.Reader) bool
+}
+
+// inlineScanService implements the InlineScanService interface
+type inlineScanService struct {
+	engineSelector ScanningEngineSelector
+	storageService StorageService
+	documentRepo   repositories.DocumentRepository
+	eventService   EventServiceInterface
+	verdictCache   ScanVerdictCache // Records clean verdicts; nil when they are not cached
+	maxBytes       int64
+	timeout        time.Duration
+}
+
+// NewInlineScanService creates an InlineScanService scanning uploads of at most maxBytes, giving up
+// on scans taking longer than timeout. verdictCache may be nil when clean verdicts are not cached.
+func NewInlineScanService(engineSelector ScanningEngineSelector, storageService StorageService, documentRepo repositories.DocumentRepository,
+	eventService EventServiceInterface, verdictCache ScanVerdictCache, maxBytes int64, timeout time.Duration) (InlineScanService, error) {
+	if engineSelector == nil {
+		return nil, fmt.Errorf("scanning engine selector cannot be nil")
+	}
+	if storageService == nil {
+		return nil, fmt.Errorf("storage service cannot be nil")
+	}
+	if documentRepo == nil {
+		return nil, fmt.Errorf("document repository cannot be nil")
+	}
+	if eventService == nil {
+		return nil, fmt.Errorf("event service cannot be nil")
+	}
+	if maxBytes <= 0 {
+		return nil, fmt.Errorf("inline scan size limit must be positive")
+	}
+	if timeout <= 0 {
+		return nil, fmt.Errorf("inline scan timeout must be positive")
+	}
+
+	return &inlineScanService{
+		engineSelector: engineSelector,
+		storageService: storageService,
+		documentRepo:   documentRepo,
+		eventService:   eventService,
+		verdictCache:   verdictCache,
+		maxBytes:       maxBytes,
+		timeout:        timeout,
+	}, nil
+}
+
+// Applies checks the size against the inline scan limit
+func (s *inlineScanService) Applies(size int64) bool {
+	return size >= 0 && size <= s.maxBytes
+}
+
+// ScanUpload scans the content and completes clean versions like the scan queue does
+func (s *inlineScanService) ScanUpload(ctx context.Context, document *models.Document, version *models.DocumentVersion, content io.Reader) bool {
+	scanner, err := s.engineSelector.EngineForTenant(ctx, document.TenantID)
+	if err != nil {
+		logger.WarnContext(ctx, "Failed to select scanning engines for inline scan, queueing the upload",
+			"document_id", document.ID, "tenant_id", document.TenantID, "error", err.Error())
+		return false
+	}
+
+	scanCtx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	start := time.Now()
+	result, _, err := scanner.ScanStream(scanCtx, content)
+	if err != nil {
+		logger.WarnContext(ctx, "Inline scan failed, queueing the upload",
+			"document_id", document.ID, "tenant_id", document.TenantID, "duration", time.Since(start), "error", err.Error())
+		return false
+	}
+	if result != ScanResultClean {
+		logger.WarnContext(ctx, "Inline scan did not find the upload clean, queueing it for quarantine",
+			"document_id", document.ID, "tenant_id", document.TenantID, "result", result)
+		return false
+	}
+
+	if err := s.makeAvailable(ctx, document, version); err != nil {
+		if stderrors.Is(err, models.ErrChecksumMismatch) {
+			logger.ErrorContext(ctx, "Upload scanned inline was corrupted in permanent storage, failing it",
+				"document_id", document.ID, "version_id", version.ID, "tenant_id", document.TenantID, "error", err.Error())
+			s.failVersion(ctx, document, version)
+			return true
+		}
+		logger.ErrorContext(ctx, "Failed to make upload scanned inline available, queueing it",
+			"document_id", document.ID, "version_id", version.ID, "tenant_id", document.TenantID, "error", err.Error())
+		return false
+	}
+
+	if s.verdictCache != nil {
+		s.verdictCache.RecordClean(ctx, document.TenantID, version.ContentHash)
+	}
+
+	// Consumers following the scan pipeline, such as the search indexer and webhooks, see the same
+	// clean event as for queued scans
+	if _, err := s.eventService.CreateAndPublishDocumentEvent(ctx, models.EventTypeDocumentClean, document.TenantID, document.ID, map[string]interface{}{
+		"versionID": version.ID,
+		"inline":    true,
+	}); err != nil {
+		logger.ErrorContext(ctx, "Failed to publish document lifecycle event", "event_type", models.EventTypeDocumentClean, "document_id", document.ID, "error", err.Error())
+	}
+
+	logger.InfoContext(ctx, "Upload scanned inline and available",
+		"document_id", document.ID, "version_id", version.ID, "tenant_id", document.TenantID, "duration", time.Since(start))
+	return true
+}
+
+// makeAvailable moves the clean content of the version from temporary to permanent storage, sharing
+// it with identical versions, and marks the version and its document available
+func (s *inlineScanService) makeAvailable(ctx context.Context, document *models.Document, version *models.DocumentVersion) error {
+	var permanentPath string
+	var err error
+	if utils.IsValidHash(version.ContentHash, utils.HashAlgorithmSHA256) {
+		permanentPath, err = s.storageService.StoreDeduplicated(ctx, document.TenantID, version.ContentHash, version.Size, version.StoragePath)
+	} else {
+		permanentPath, err = s.storageService.StorePermanent(ctx, document.TenantID, document.ID, version.ID, document.FolderID, version.StoragePath)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := s.documentRepo.UpdateVersionStoragePath(ctx, version.ID, permanentPath, document.TenantID); err != nil {
+		return err
+	}
+	version.StoragePath = permanentPath
+
+	// Read the permanent copy back, so content corrupted while it was copied is never made available
+	if utils.IsValidHash(version.ContentHash, utils.HashAlgorithmSHA256) {
+		if err := VerifyStoredContent(ctx, s.storageService, permanentPath, version.ContentHash); err != nil {
+			return err
+		}
+		verifiedAt := time.Now()
+		if err := s.documentRepo.MarkVersionIntegrityVerified(ctx, version.ID, verifiedAt, document.TenantID); err != nil {
+			logger.WarnContext(ctx, "Failed to record integrity verification of upload scanned inline",
+				"version_id", version.ID, "tenant_id", document.TenantID, "error", err.Error())
+		} else {
+			version.IntegrityVerifiedAt = &verifiedAt
+		}
+	}
+
+	if err := s.documentRepo.UpdateVersionStatus(ctx, version.ID, models.VersionStatusAvailable, document.TenantID); err != nil {
+		return err
+	}
+
+	version.MarkAsAvailable()
+	document.MarkAsAvailable()
+	return nil
+}
+
+// failVersion marks a version whose stored content is corrupted failed, with its document
+func (s *inlineScanService) failVersion(ctx context.Context, document *models.Document, version *models.DocumentVersion) {
+	if err := s.documentRepo.UpdateVersionStatus(ctx, version.ID, models.VersionStatusFailed, document.TenantID); err != nil {
+		logger.ErrorContext(ctx, "Failed to mark corrupted upload failed",
+			"version_id", version.ID, "tenant_id", document.TenantID, "error", err.Error())
+	}
+	version.MarkAsFailed()
+	document.MarkAsFailed()
+}
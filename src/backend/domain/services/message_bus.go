@@ -0,0 +1,37 @@
+// Package services provides domain-level services for the Document Management Platform
+package services
+
+import (
+	"context"
+
+	"../models"
+)
+
+// Messaging providers selectable with config.Messaging.Provider
+const (
+	MessagingProviderAWS      = "aws"
+	MessagingProviderKafka    = "kafka"
+	MessagingProviderRabbitMQ = "rabbitmq"
+	MessagingProviderNATS     = "nats"
+	MessagingProviderMemory   = "memory"
+)
+
+// EventBus publishes domain events to the topics other services consume them from
+type EventBus interface {
+	// PublishEvent publishes a domain event to the topic of its type
+	PublishEvent(ctx context.Context, event *models.Event) error
+}
+
+// MessageBus is the messaging a deployment runs on: the event bus domain events are published to
+// and the queue scan tasks are consumed from. It is implemented with AWS SNS and SQS, and with
+// Kafka, RabbitMQ and NATS JetStream for deployments without AWS messaging.
+type MessageBus interface {
+	// Events returns the bus domain events are published to
+	Events() EventBus
+
+	// ScanQueue returns the queue of virus scanning tasks
+	ScanQueue() ScanQueue
+
+	// Close releases the connections of the message bus
+	Close() error
+}
@@ -0,0 +1,172 @@
+// Package services provides domain-level services for the Document Management Platform
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"../../pkg/errors"
+	"../../pkg/logger"
+	"../models"
+	"../repositories"
+)
+
+// MetadataSchemaService manages the metadata fields of a tenant's schema and validates document
+// metadata against them
+type MetadataSchemaService interface {
+	// CreateField validates and persists a new metadata field
+	CreateField(ctx context.Context, field *models.MetadataField) (string, error)
+
+	// GetField retrieves a metadata field by its ID with tenant isolation
+	GetField(ctx context.Context, id string, tenantID string) (*models.MetadataField, error)
+
+	// UpdateField replaces the definition of an existing metadata field
+	UpdateField(ctx context.Context, field *models.MetadataField) error
+
+	// DeleteField deletes a metadata field; the metadata key becomes freeform again
+	DeleteField(ctx context.Context, id string, tenantID string) error
+
+	// GetSchema returns the metadata fields of a tenant ordered by name
+	GetSchema(ctx context.Context, tenantID string) (models.MetadataSchema, error)
+
+	// ValidateMetadata checks the complete metadata of a document against the tenant's schema,
+	// returning a validation error describing the first violation
+	ValidateMetadata(ctx context.Context, tenantID string, metadata map[string]string) error
+}
+
+// metadataSchemaService implements the MetadataSchemaService interface
+type metadataSchemaService struct {
+	fieldRepo repositories.MetadataFieldRepository
+}
+
+// NewMetadataSchemaService creates a new MetadataSchemaService instance
+func NewMetadataSchemaService(fieldRepo repositories.MetadataFieldRepository) (MetadataSchemaService, error) {
+	if fieldRepo == nil {
+		return nil, fmt.Errorf("metadata field repository cannot be nil")
+	}
+
+	return &metadataSchemaService{
+		fieldRepo: fieldRepo,
+	}, nil
+}
+
+// CreateField validates and persists a new metadata field
+func (s *metadataSchemaService) CreateField(ctx context.Context, field *models.MetadataField) (string, error) {
+	ctxLogger := logger.WithContext(ctx)
+
+	if field == nil {
+		return "", errors.NewValidationError("metadata field cannot be nil")
+	}
+	if err := field.Validate(); err != nil {
+		return "", errors.NewValidationError(err.Error())
+	}
+
+	id, err := s.fieldRepo.Create(ctx, field)
+	if err != nil {
+		ctxLogger.Error("Failed to create metadata field", "error", err, "tenant_id", field.TenantID, "name", field.Name)
+		return "", err
+	}
+
+	ctxLogger.Info("Metadata field created", "field_id", id, "tenant_id", field.TenantID, "name", field.Name, "type", field.Type)
+	return id, nil
+}
+
+// GetField retrieves a metadata field by its ID with tenant isolation
+func (s *metadataSchemaService) GetField(ctx context.Context, id string, tenantID string) (*models.MetadataField, error) {
+	if err := s.validateInput(map[string]string{
+		"metadata field ID": id,
+		"tenant ID":         tenantID,
+	}); err != nil {
+		return nil, err
+	}
+
+	return s.fieldRepo.GetByID(ctx, id, tenantID)
+}
+
+// UpdateField replaces the definition of an existing metadata field, keeping its creator and
+// creation time. Metadata already stored is not checked against the new definition; it is
+// validated the next time the metadata of its document is updated.
+func (s *metadataSchemaService) UpdateField(ctx context.Context, field *models.MetadataField) error {
+	ctxLogger := logger.WithContext(ctx)
+
+	if field == nil {
+		return errors.NewValidationError("metadata field cannot be nil")
+	}
+
+	existing, err := s.GetField(ctx, field.ID, field.TenantID)
+	if err != nil {
+		return err
+	}
+	field.CreatedBy = existing.CreatedBy
+	field.CreatedAt = existing.CreatedAt
+
+	if err := field.Validate(); err != nil {
+		return errors.NewValidationError(err.Error())
+	}
+
+	if err := s.fieldRepo.Update(ctx, field); err != nil {
+		ctxLogger.Error("Failed to update metadata field", "error", err, "field_id", field.ID, "tenant_id", field.TenantID)
+		return err
+	}
+
+	ctxLogger.Info("Metadata field updated", "field_id", field.ID, "tenant_id", field.TenantID, "type", field.Type)
+	return nil
+}
+
+// DeleteField deletes a metadata field
+func (s *metadataSchemaService) DeleteField(ctx context.Context, id string, tenantID string) error {
+	ctxLogger := logger.WithContext(ctx)
+
+	if err := s.validateInput(map[string]string{
+		"metadata field ID": id,
+		"tenant ID":         tenantID,
+	}); err != nil {
+		return err
+	}
+
+	if err := s.fieldRepo.Delete(ctx, id, tenantID); err != nil {
+		ctxLogger.Error("Failed to delete metadata field", "error", err, "field_id", id, "tenant_id", tenantID)
+		return err
+	}
+
+	ctxLogger.Info("Metadata field deleted", "field_id", id, "tenant_id", tenantID)
+	return nil
+}
+
+// GetSchema returns the metadata fields of a tenant ordered by name
+func (s *metadataSchemaService) GetSchema(ctx context.Context, tenantID string) (models.MetadataSchema, error) {
+	if err := s.validateInput(map[string]string{
+		"tenant ID": tenantID,
+	}); err != nil {
+		return nil, err
+	}
+
+	fields, err := s.fieldRepo.ListByTenant(ctx, tenantID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load metadata schema")
+	}
+	return models.MetadataSchema(fields), nil
+}
+
+// ValidateMetadata checks the complete metadata of a document against the tenant's schema
+func (s *metadataSchemaService) ValidateMetadata(ctx context.Context, tenantID string, metadata map[string]string) error {
+	schema, err := s.GetSchema(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+
+	if err := schema.Validate(metadata); err != nil {
+		return errors.NewValidationError(err.Error())
+	}
+	return nil
+}
+
+// validateInput validates that required input parameters are not empty
+func (s *metadataSchemaService) validateInput(params map[string]string) error {
+	for name, value := range params {
+		if value == "" {
+			return errors.NewValidationError(fmt.Sprintf("%s cannot be empty", name))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+// Package services provides domain-level services for the Document Management Platform
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"../../pkg/errors"
+	"../../pkg/logger"
+	"../models"
+	"../repositories"
+)
+
+// MetadataTemplateService manages the metadata templates of folders and checks uploads against them
+type MetadataTemplateService interface {
+	// CreateTemplate validates and persists a new metadata template for a folder
+	CreateTemplate(ctx context.Context, template *models.MetadataTemplate) (string, error)
+
+	// GetTemplate retrieves a metadata template by its ID with tenant isolation
+	GetTemplate(ctx context.Context, id string, tenantID string) (*models.MetadataTemplate, error)
+
+	// GetFolderTemplate retrieves the metadata template of a folder, returning a not found error if
+	// the folder has none
+	GetFolderTemplate(ctx context.Context, folderID string, tenantID string) (*models.MetadataTemplate, error)
+
+	// ListTemplates lists the metadata templates of a tenant ordered by name
+	ListTemplates(ctx context.Context, tenantID string) ([]*models.MetadataTemplate, error)
+
+	// UpdateTemplate replaces the fields and mode of an existing metadata template
+	UpdateTemplate(ctx context.Context, template *models.MetadataTemplate) error
+
+	// DeleteTemplate deletes a metadata template
+	DeleteTemplate(ctx context.Context, id string, tenantID string) error
+
+	// CheckUpload checks the metadata of a document uploaded to a folder against the folder's
+	// template, returning a validation error if the template is enforced and fields are missing
+	CheckUpload(ctx context.Context, tenantID string, folderID string, metadata map[string]string) error
+}
+
+// metadataTemplateService implements the MetadataTemplateService interface
+type metadataTemplateService struct {
+	templateRepo repositories.MetadataTemplateRepository
+	folderRepo   repositories.FolderRepository
+}
+
+// NewMetadataTemplateService creates a new MetadataTemplateService instance
+func NewMetadataTemplateService(templateRepo repositories.MetadataTemplateRepository, folderRepo repositories.FolderRepository) (MetadataTemplateService, error) {
+	if templateRepo == nil {
+		return nil, fmt.Errorf("metadata template repository cannot be nil")
+	}
+	if folderRepo == nil {
+		return nil, fmt.Errorf("folder repository cannot be nil")
+	}
+
+	return &metadataTemplateService{
+		templateRepo: templateRepo,
+		folderRepo:   folderRepo,
+	}, nil
+}
+
+// CreateTemplate validates and persists a new metadata template for a folder of the tenant
+func (s *metadataTemplateService) CreateTemplate(ctx context.Context, template *models.MetadataTemplate) (string, error) {
+	ctxLogger := logger.WithContext(ctx)
+
+	if template == nil {
+		return "", errors.NewValidationError("metadata template cannot be nil")
+	}
+	if err := template.Validate(); err != nil {
+		return "", errors.NewValidationError(err.Error())
+	}
+
+	// The folder must belong to the tenant
+	if _, err := s.folderRepo.GetByID(ctx, template.FolderID, template.TenantID); err != nil {
+		return "", err
+	}
+
+	id, err := s.templateRepo.Create(ctx, template)
+	if err != nil {
+		ctxLogger.Error("Failed to create metadata template", "error", err, "tenant_id", template.TenantID, "folder_id", template.FolderID)
+		return "", err
+	}
+
+	ctxLogger.Info("Metadata template created", "template_id", id, "tenant_id", template.TenantID, "folder_id", template.FolderID, "mode", template.Mode)
+	return id, nil
+}
+
+// GetTemplate retrieves a metadata template by its ID with tenant isolation
+func (s *metadataTemplateService) GetTemplate(ctx context.Context, id string, tenantID string) (*models.MetadataTemplate, error) {
+	if err := s.validateInput(map[string]string{
+		"metadata template ID": id,
+		"tenant ID":            tenantID,
+	}); err != nil {
+		return nil, err
+	}
+
+	return s.templateRepo.GetByID(ctx, id, tenantID)
+}
+
+// GetFolderTemplate retrieves the metadata template of a folder
+func (s *metadataTemplateService) GetFolderTemplate(ctx context.Context, folderID string, tenantID string) (*models.MetadataTemplate, error) {
+	if err := s.validateInput(map[string]string{
+		"folder ID": folderID,
+		"tenant ID": tenantID,
+	}); err != nil {
+		return nil, err
+	}
+
+	return s.templateRepo.GetByFolder(ctx, folderID, tenantID)
+}
+
+// ListTemplates lists the metadata templates of a tenant ordered by name
+func (s *metadataTemplateService) ListTemplates(ctx context.Context, tenantID string) ([]*models.MetadataTemplate, error) {
+	if err := s.validateInput(map[string]string{
+		"tenant ID": tenantID,
+	}); err != nil {
+		return nil, err
+	}
+
+	return s.templateRepo.ListByTenant(ctx, tenantID)
+}
+
+// UpdateTemplate replaces the fields and mode of an existing metadata template, keeping its folder,
+// creator and creation time. Documents already in the folder are not checked again.
+func (s *metadataTemplateService) UpdateTemplate(ctx context.Context, template *models.MetadataTemplate) error {
+	ctxLogger := logger.WithContext(ctx)
+
+	if template == nil {
+		return errors.NewValidationError("metadata template cannot be nil")
+	}
+
+	existing, err := s.GetTemplate(ctx, template.ID, template.TenantID)
+	if err != nil {
+		return err
+	}
+	template.FolderID = existing.FolderID
+	template.CreatedBy = existing.CreatedBy
+	template.CreatedAt = existing.CreatedAt
+
+	if err := template.Validate(); err != nil {
+		return errors.NewValidationError(err.Error())
+	}
+
+	if err := s.templateRepo.Update(ctx, template); err != nil {
+		ctxLogger.Error("Failed to update metadata template", "error", err, "template_id", template.ID, "tenant_id", template.TenantID)
+		return err
+	}
+
+	ctxLogger.Info("Metadata template updated", "template_id", template.ID, "tenant_id", template.TenantID, "mode", template.Mode)
+	return nil
+}
+
+// DeleteTemplate deletes a metadata template
+func (s *metadataTemplateService) DeleteTemplate(ctx context.Context, id string, tenantID string) error {
+	ctxLogger := logger.WithContext(ctx)
+
+	if err := s.validateInput(map[string]string{
+		"metadata template ID": id,
+		"tenant ID":            tenantID,
+	}); err != nil {
+		return err
+	}
+
+	if err := s.templateRepo.Delete(ctx, id, tenantID); err != nil {
+		ctxLogger.Error("Failed to delete metadata template", "error", err, "template_id", id, "tenant_id", tenantID)
+		return err
+	}
+
+	ctxLogger.Info("Metadata template deleted", "template_id", id, "tenant_id", tenantID)
+	return nil
+}
+
+// CheckUpload checks the metadata of a document uploaded to a folder against the folder's template.
+// Uploads to folders without a template, or with a template only prompting for its fields, pass.
+func (s *metadataTemplateService) CheckUpload(ctx context.Context, tenantID string, folderID string, metadata map[string]string) error {
+	template, err := s.GetFolderTemplate(ctx, folderID, tenantID)
+	if err != nil {
+		if errors.IsResourceNotFoundError(err) {
+			return nil
+		}
+		return errors.Wrap(err, "failed to load folder metadata template")
+	}
+
+	missing := template.MissingFields(metadata)
+	if len(missing) == 0 {
+		return nil
+	}
+
+	if !template.IsEnforced() {
+		logger.WithContext(ctx).Info("Document uploaded without metadata prompted for by folder template",
+			"tenant_id", tenantID, "folder_id", folderID, "template_id", template.ID, "missing", missing)
+		return nil
+	}
+
+	return errors.NewValidationError(fmt.Sprintf("metadata %s is required by the %s template of the folder",
+		strings.Join(missing, ", "), template.Name))
+}
+
+// validateInput validates that required input parameters are not empty
+func (s *metadataTemplateService) validateInput(params map[string]string) error {
+	for name, value := range params {
+		if value == "" {
+			return errors.NewValidationError(fmt.Sprintf("%s cannot be empty", name))
+		}
+	}
+	return nil
+}
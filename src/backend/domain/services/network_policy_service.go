@@ -0,0 +1,193 @@
+// Package services provides domain-level services for the Document Management Platform
+package services
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"../models"
+	"../repositories"
+	"../../pkg/errors"
+	"../../pkg/logger"
+)
+
+// networkPolicyCacheTTL is how long a tenant's network policy is used before it is read again, so
+// that changes to it take effect within this time
+const networkPolicyCacheTTL = time.Minute
+
+// networkDenialAuditInterval is how often refused requests of the same user from the same address
+// are recorded in the audit log, so that a client retrying in a loop does not flood it
+const networkDenialAuditInterval = time.Minute
+
+// maxTrackedNetworkDenials bounds the number of recently audited refusals kept in memory
+const maxTrackedNetworkDenials = 10000
+
+// ErrNetworkAccessDenied is returned for requests from a network the tenant's network policy does not allow
+var ErrNetworkAccessDenied = errors.WithCode(errors.NewAuthorizationError("access from this network is not allowed by the tenant"), errors.CodeNetworkAccessDenied)
+
+// GeoIPResolver looks up the country of client addresses
+type GeoIPResolver interface {
+	// Country returns the ISO 3166-1 alpha-2 code of the country of the address, or an empty code
+	// when the address has no known country
+	Country(ip net.IP) (string, error)
+}
+
+// NetworkPolicyService enforces the network policies of tenants: the networks their users may
+// reach the platform from and the countries they may not
+type NetworkPolicyService interface {
+	// CheckAccess checks that the tenant's network policy allows requests from the client address.
+	// Refused requests are recorded in the audit log and return ErrNetworkAccessDenied.
+	CheckAccess(ctx context.Context, tenantID, userID string, ip net.IP) error
+}
+
+// cachedNetworkPolicy is a tenant's network policy and when it must be read again
+type cachedNetworkPolicy struct {
+	policy    models.NetworkPolicy
+	expiresAt time.Time
+}
+
+// networkPolicyService implements the NetworkPolicyService interface
+type networkPolicyService struct {
+	tenantRepo   repositories.TenantRepository
+	geoIP        GeoIPResolver
+	auditService AuditService
+
+	mu       sync.Mutex
+	policies map[string]cachedNetworkPolicy
+	denials  map[string]time.Time
+}
+
+// NewNetworkPolicyService creates a NetworkPolicyService. geoIP may be nil when no GeoIP database is
+// available, in which case requests of tenants blocking countries are refused unless they come from
+// a private network.
+func NewNetworkPolicyService(tenantRepo repositories.TenantRepository, geoIP GeoIPResolver, auditService AuditService) (NetworkPolicyService, error) {
+	if tenantRepo == nil {
+		return nil, fmt.Errorf("tenant repository cannot be nil")
+	}
+	if auditService == nil {
+		return nil, fmt.Errorf("audit service cannot be nil")
+	}
+
+	return &networkPolicyService{
+		tenantRepo:   tenantRepo,
+		geoIP:        geoIP,
+		auditService: auditService,
+		policies:     make(map[string]cachedNetworkPolicy),
+		denials:      make(map[string]time.Time),
+	}, nil
+}
+
+// CheckAccess checks the client address against the tenant's allowed networks, then its country
+// against the tenant's blocked countries
+func (s *networkPolicyService) CheckAccess(ctx context.Context, tenantID, userID string, ip net.IP) error {
+	policy, err := s.policy(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+	if policy.IsEmpty() {
+		return nil
+	}
+
+	if ip == nil || !policy.AllowsIP(ip) {
+		return s.deny(ctx, tenantID, userID, ip, "", models.NetworkDenialIPNotAllowed)
+	}
+
+	// Addresses of private networks have no country and are not refused for it
+	if len(policy.BlockedCountries) == 0 || ip.IsPrivate() || ip.IsLoopback() {
+		return nil
+	}
+
+	// Countries that cannot be looked up are refused, rather than letting blocked countries through
+	if s.geoIP == nil {
+		logger.ErrorContext(ctx, "Tenant blocks countries but no GeoIP database is configured", "tenant_id", tenantID)
+		return s.deny(ctx, tenantID, userID, ip, "", models.NetworkDenialCountryUnavailable)
+	}
+	country, err := s.geoIP.Country(ip)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to look up the country of a client address", "tenant_id", tenantID, "error", err.Error())
+		return s.deny(ctx, tenantID, userID, ip, "", models.NetworkDenialCountryUnavailable)
+	}
+	if policy.BlocksCountry(country) {
+		return s.deny(ctx, tenantID, userID, ip, country, models.NetworkDenialCountryBlocked)
+	}
+	return nil
+}
+
+// policy returns the tenant's network policy, reading the tenant when its cached policy expired
+func (s *networkPolicyService) policy(ctx context.Context, tenantID string) (models.NetworkPolicy, error) {
+	now := time.Now()
+
+	s.mu.Lock()
+	cached, ok := s.policies[tenantID]
+	s.mu.Unlock()
+	if ok && now.Before(cached.expiresAt) {
+		return cached.policy, nil
+	}
+
+	tenant, err := s.tenantRepo.GetByID(ctx, tenantID)
+	if err != nil {
+		return models.NetworkPolicy{}, errors.Wrap(err, "failed to get tenant network policy")
+	}
+	policy := tenant.NetworkPolicy()
+
+	s.mu.Lock()
+	s.policies[tenantID] = cachedNetworkPolicy{policy: policy, expiresAt: now.Add(networkPolicyCacheTTL)}
+	s.mu.Unlock()
+
+	return policy, nil
+}
+
+// deny records a refused request in the audit log, unless the same user was refused from the same
+// address recently, and returns ErrNetworkAccessDenied
+func (s *networkPolicyService) deny(ctx context.Context, tenantID, userID string, ip net.IP, country, reason string) error {
+	address := ""
+	if ip != nil {
+		address = ip.String()
+	}
+	logger.WarnContext(ctx, "Request refused by tenant network policy",
+		"tenant_id", tenantID,
+		"user_id", userID,
+		"client_ip", address,
+		"country", country,
+		"reason", reason)
+
+	if !s.shouldAuditDenial(tenantID + "\x00" + userID + "\x00" + address) {
+		return ErrNetworkAccessDenied
+	}
+
+	after := map[string]interface{}{
+		"ip":     address,
+		"reason": reason,
+	}
+	if country != "" {
+		after["country"] = country
+	}
+	if err := s.auditService.RecordAction(ctx, tenantID, userID, models.AuditActionDeny, models.AuditResourceTenant, tenantID, nil, after); err != nil {
+		logger.ErrorContext(ctx, "Failed to record refused request in audit log", "tenant_id", tenantID, "error", err.Error())
+	}
+	return ErrNetworkAccessDenied
+}
+
+// shouldAuditDenial checks if a refusal with the key was not audited recently, and remembers it
+func (s *networkPolicyService) shouldAuditDenial(key string) bool {
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if last, ok := s.denials[key]; ok && now.Sub(last) < networkDenialAuditInterval {
+		return false
+	}
+	if len(s.denials) >= maxTrackedNetworkDenials {
+		for tracked, last := range s.denials {
+			if now.Sub(last) >= networkDenialAuditInterval {
+				delete(s.denials, tracked)
+			}
+		}
+	}
+	s.denials[key] = now
+	return true
+}
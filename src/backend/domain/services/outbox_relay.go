@@ -0,0 +1,108 @@
+// Package services provides domain-level services for the Document Management Platform
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"../repositories"
+	"../../pkg/errors"
+	"../../pkg/logger"
+)
+
+// OutboxRelay publishes events written to the transactional outbox
+type OutboxRelay interface {
+	// RelayPending publishes up to batchSize due outbox messages and marks them sent.
+	// Failed messages stay pending and are retried with backoff. Returns the number of messages published.
+	RelayPending(ctx context.Context, batchSize int) (int, error)
+
+	// PurgeSent removes messages that were published more than retention ago. Returns the number removed.
+	PurgeSent(ctx context.Context, retention time.Duration) (int, error)
+}
+
+// outboxRelay implements the OutboxRelay interface
+type outboxRelay struct {
+	outboxRepo     repositories.OutboxRepository
+	txManager      repositories.TransactionManager
+	eventPublisher EventBus
+}
+
+// NewOutboxRelay creates a new OutboxRelay instance
+func NewOutboxRelay(outboxRepo repositories.OutboxRepository, txManager repositories.TransactionManager, eventPublisher EventBus) (OutboxRelay, error) {
+	if outboxRepo == nil {
+		return nil, fmt.Errorf("outbox repository cannot be nil")
+	}
+	if txManager == nil {
+		return nil, fmt.Errorf("transaction manager cannot be nil")
+	}
+	if eventPublisher == nil {
+		return nil, fmt.Errorf("event publisher cannot be nil")
+	}
+
+	return &outboxRelay{
+		outboxRepo:     outboxRepo,
+		txManager:      txManager,
+		eventPublisher: eventPublisher,
+	}, nil
+}
+
+// RelayPending publishes due outbox messages. The batch is claimed inside a transaction so that
+// concurrent relays skip each other's rows. A message is only marked sent after the publisher
+// accepts it, so a crash between the two steps results in a redelivery rather than a lost event.
+func (s *outboxRelay) RelayPending(ctx context.Context, batchSize int) (int, error) {
+	ctxLogger := logger.WithContext(ctx)
+
+	if batchSize <= 0 {
+		return 0, errors.NewValidationError("batch size must be greater than zero")
+	}
+
+	published := 0
+	err := s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		messages, err := s.outboxRepo.ListDue(txCtx, time.Now().UTC(), batchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, message := range messages {
+			// Publish in the context of the request that raised the event, so the publisher's logs correlate with it
+			pubCtx := logger.ContextWithRequestID(txCtx, message.RequestID)
+			if pubErr := s.eventPublisher.PublishEvent(pubCtx, message.ToEvent()); pubErr != nil {
+				ctxLogger.Error("Failed to publish outbox message", "error", pubErr, "message_id", message.ID, "event_id", message.EventID, "attempts", message.Attempts+1)
+				message.MarkFailed(pubErr.Error())
+			} else {
+				message.MarkSent()
+				published++
+			}
+
+			if err := s.outboxRepo.Update(txCtx, message); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		ctxLogger.Error("Failed to relay outbox messages", "error", err)
+		return published, errors.Wrap(err, "failed to relay outbox messages")
+	}
+
+	return published, nil
+}
+
+// PurgeSent removes messages that were published more than retention ago
+func (s *outboxRelay) PurgeSent(ctx context.Context, retention time.Duration) (int, error) {
+	ctxLogger := logger.WithContext(ctx)
+
+	if retention <= 0 {
+		return 0, errors.NewValidationError("retention must be greater than zero")
+	}
+
+	count, err := s.outboxRepo.DeleteSentBefore(ctx, time.Now().UTC().Add(-retention))
+	if err != nil {
+		ctxLogger.Error("Failed to purge sent outbox messages", "error", err)
+		return 0, errors.Wrap(err, "failed to purge sent outbox messages")
+	}
+
+	return count, nil
+}
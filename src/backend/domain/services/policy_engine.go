@@ -0,0 +1,218 @@
+// Package services provides domain-level services for the Document Management Platform
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"../models"
+	"../repositories"
+	"../../pkg/errors"
+	"../../pkg/logger"
+	"../../pkg/utils"
+)
+
+// PolicyEngine manages a tenant's attribute-based access policies and evaluates them on document access.
+// Policies only ever restrict access: they are checked after, not instead of, role permissions.
+type PolicyEngine interface {
+	// CreatePolicy validates and persists a new access policy
+	CreatePolicy(ctx context.Context, policy *models.AccessPolicy) (string, error)
+
+	// GetPolicy retrieves an access policy by its ID with tenant isolation
+	GetPolicy(ctx context.Context, id string, tenantID string) (*models.AccessPolicy, error)
+
+	// ListPolicies lists a tenant's access policies with pagination
+	ListPolicies(ctx context.Context, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.AccessPolicy], error)
+
+	// UpdatePolicy replaces the rule of an existing access policy
+	UpdatePolicy(ctx context.Context, policy *models.AccessPolicy) error
+
+	// DeletePolicy deletes an access policy
+	DeletePolicy(ctx context.Context, id string, tenantID string) error
+
+	// Enforce evaluates the tenant's enabled policies for a user performing action on document.
+	// It returns an authorization error naming the first policy that denies access.
+	Enforce(ctx context.Context, tenantID, userID, action string, document *models.Document) error
+}
+
+// policyEngine implements the PolicyEngine interface
+type policyEngine struct {
+	policyRepo repositories.AccessPolicyRepository
+	userRepo   repositories.UserRepository
+}
+
+// NewPolicyEngine creates a new PolicyEngine instance
+func NewPolicyEngine(policyRepo repositories.AccessPolicyRepository, userRepo repositories.UserRepository) (PolicyEngine, error) {
+	if policyRepo == nil {
+		return nil, fmt.Errorf("access policy repository cannot be nil")
+	}
+	if userRepo == nil {
+		return nil, fmt.Errorf("user repository cannot be nil")
+	}
+
+	return &policyEngine{
+		policyRepo: policyRepo,
+		userRepo:   userRepo,
+	}, nil
+}
+
+// CreatePolicy validates and persists a new access policy
+func (e *policyEngine) CreatePolicy(ctx context.Context, policy *models.AccessPolicy) (string, error) {
+	ctxLogger := logger.WithContext(ctx)
+
+	if policy == nil {
+		return "", errors.NewValidationError("access policy cannot be nil")
+	}
+	if err := policy.Validate(); err != nil {
+		return "", errors.NewValidationError(err.Error())
+	}
+
+	id, err := e.policyRepo.Create(ctx, policy)
+	if err != nil {
+		ctxLogger.Error("Failed to create access policy", "error", err, "tenant_id", policy.TenantID, "name", policy.Name)
+		return "", err
+	}
+
+	ctxLogger.Info("Access policy created", "policy_id", id, "tenant_id", policy.TenantID, "attribute", policy.Attribute)
+	return id, nil
+}
+
+// GetPolicy retrieves an access policy by its ID with tenant isolation
+func (e *policyEngine) GetPolicy(ctx context.Context, id string, tenantID string) (*models.AccessPolicy, error) {
+	if err := e.validateInput(map[string]string{
+		"policy ID": id,
+		"tenant ID": tenantID,
+	}); err != nil {
+		return nil, err
+	}
+
+	return e.policyRepo.GetByID(ctx, id, tenantID)
+}
+
+// ListPolicies lists a tenant's access policies with pagination
+func (e *policyEngine) ListPolicies(ctx context.Context, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.AccessPolicy], error) {
+	if err := e.validateInput(map[string]string{
+		"tenant ID": tenantID,
+	}); err != nil {
+		return utils.PaginatedResult[models.AccessPolicy]{}, err
+	}
+
+	if pagination == nil {
+		pagination = utils.NewPagination(utils.DefaultPage, utils.DefaultPageSize)
+	}
+
+	return e.policyRepo.List(ctx, tenantID, pagination)
+}
+
+// UpdatePolicy replaces the rule of an existing access policy, keeping its creator and creation time
+func (e *policyEngine) UpdatePolicy(ctx context.Context, policy *models.AccessPolicy) error {
+	ctxLogger := logger.WithContext(ctx)
+
+	if policy == nil {
+		return errors.NewValidationError("access policy cannot be nil")
+	}
+
+	existing, err := e.GetPolicy(ctx, policy.ID, policy.TenantID)
+	if err != nil {
+		return err
+	}
+	policy.CreatedBy = existing.CreatedBy
+	policy.CreatedAt = existing.CreatedAt
+
+	if err := policy.Validate(); err != nil {
+		return errors.NewValidationError(err.Error())
+	}
+
+	if err := e.policyRepo.Update(ctx, policy); err != nil {
+		ctxLogger.Error("Failed to update access policy", "error", err, "policy_id", policy.ID, "tenant_id", policy.TenantID)
+		return err
+	}
+
+	ctxLogger.Info("Access policy updated", "policy_id", policy.ID, "tenant_id", policy.TenantID, "enabled", policy.Enabled)
+	return nil
+}
+
+// DeletePolicy deletes an access policy
+func (e *policyEngine) DeletePolicy(ctx context.Context, id string, tenantID string) error {
+	ctxLogger := logger.WithContext(ctx)
+
+	if err := e.validateInput(map[string]string{
+		"policy ID": id,
+		"tenant ID": tenantID,
+	}); err != nil {
+		return err
+	}
+
+	if err := e.policyRepo.Delete(ctx, id, tenantID); err != nil {
+		ctxLogger.Error("Failed to delete access policy", "error", err, "policy_id", id, "tenant_id", tenantID)
+		return err
+	}
+
+	ctxLogger.Info("Access policy deleted", "policy_id", id, "tenant_id", tenantID)
+	return nil
+}
+
+// Enforce evaluates the tenant's enabled policies for a user performing action on document.
+// The user's roles are only loaded when at least one policy matches the document.
+func (e *policyEngine) Enforce(ctx context.Context, tenantID, userID, action string, document *models.Document) error {
+	ctxLogger := logger.WithContext(ctx)
+
+	if err := e.validateInput(map[string]string{
+		"tenant ID": tenantID,
+		"user ID":   userID,
+		"action":    action,
+	}); err != nil {
+		return err
+	}
+	if document == nil {
+		return errors.NewValidationError("document cannot be nil")
+	}
+
+	policies, err := e.policyRepo.ListEnabled(ctx, tenantID)
+	if err != nil {
+		ctxLogger.Error("Failed to load access policies", "error", err, "tenant_id", tenantID)
+		return errors.Wrap(err, "failed to load access policies")
+	}
+
+	var roles []string
+	rolesLoaded := false
+	for _, policy := range policies {
+		if !policy.AppliesTo(action) || !policy.Matches(document) {
+			continue
+		}
+
+		if !rolesLoaded {
+			user, err := e.userRepo.GetByID(ctx, userID, tenantID)
+			if err != nil {
+				if errors.IsResourceNotFoundError(err) {
+					return errors.NewAuthorizationError(fmt.Sprintf("access denied by policy %s", policy.Name))
+				}
+				return errors.Wrap(err, "failed to get user")
+			}
+			roles = user.Roles
+			rolesLoaded = true
+		}
+
+		if !policy.IsSatisfiedBy(roles) {
+			ctxLogger.Info("Document access denied by policy",
+				"policy_id", policy.ID,
+				"tenant_id", tenantID,
+				"user_id", userID,
+				"document_id", document.ID,
+				"action", action)
+			return errors.NewAuthorizationError(fmt.Sprintf("access denied by policy %s", policy.Name))
+		}
+	}
+
+	return nil
+}
+
+// validateInput validates that required input parameters are not empty
+func (e *policyEngine) validateInput(params map[string]string) error {
+	for name, value := range params {
+		if value == "" {
+			return errors.NewValidationError(fmt.Sprintf("%s cannot be empty", name))
+		}
+	}
+	return nil
+}
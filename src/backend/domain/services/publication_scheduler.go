@@ -0,0 +1,120 @@
+// Package services provides domain-level services for the Document Management Platform
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid" // v1.3.0+ - For identifying publication events
+
+	"../../pkg/errors"
+	"../../pkg/logger"
+	"../models"
+	"../repositories"
+)
+
+// PublicationScheduler publishes scheduled documents and expires published ones when their
+// publication and expiry times come, so that documents can be staged to go live at a given time
+type PublicationScheduler interface {
+	// PublishDue publishes or expires up to limit documents that are due at now. Each change is
+	// stored together with its document.published or document.expired event in the outbox.
+	// Returns the number of documents changed.
+	PublishDue(ctx context.Context, now time.Time, limit int) (int, error)
+}
+
+// publicationScheduler implements the PublicationScheduler interface
+type publicationScheduler struct {
+	documentRepo repositories.DocumentRepository
+	outboxRepo   repositories.OutboxRepository
+	txManager    repositories.TransactionManager
+}
+
+// NewPublicationScheduler creates a new PublicationScheduler instance
+func NewPublicationScheduler(documentRepo repositories.DocumentRepository, outboxRepo repositories.OutboxRepository,
+	txManager repositories.TransactionManager) (PublicationScheduler, error) {
+	if documentRepo == nil {
+		return nil, fmt.Errorf("document repository cannot be nil")
+	}
+	if outboxRepo == nil {
+		return nil, fmt.Errorf("outbox repository cannot be nil")
+	}
+	if txManager == nil {
+		return nil, fmt.Errorf("transaction manager cannot be nil")
+	}
+
+	return &publicationScheduler{
+		documentRepo: documentRepo,
+		outboxRepo:   outboxRepo,
+		txManager:    txManager,
+	}, nil
+}
+
+// PublishDue publishes or expires the documents due at now. A document whose change another worker
+// already made, or whose schedule changed meanwhile, is skipped without an event. A document both
+// due to be published and past its expiry is published now and expired on the next run, so that
+// consumers see both events.
+func (s *publicationScheduler) PublishDue(ctx context.Context, now time.Time, limit int) (int, error) {
+	documents, err := s.documentRepo.ListDueVisibilityChanges(ctx, now, limit)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to list documents due for publication or expiry")
+	}
+
+	changed := 0
+	for _, document := range documents {
+		ok, err := s.apply(ctx, document, now)
+		if err != nil {
+			return changed, err
+		}
+		if ok {
+			changed++
+		}
+	}
+
+	return changed, nil
+}
+
+// apply changes the visibility of a due document and writes its event to the outbox in one
+// transaction, so the event is published if and only if the change is stored
+func (s *publicationScheduler) apply(ctx context.Context, document *models.Document, now time.Time) (bool, error) {
+	from := document.Visibility
+	to := document.NextVisibility(now)
+
+	eventType := models.EventTypeDocumentPublished
+	switch to {
+	case from:
+		return false, nil
+	case models.DocumentVisibilityExpired:
+		eventType = models.EventTypeDocumentExpired
+	}
+
+	event, err := models.NewDocumentVisibilityEvent(eventType, document)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to create document visibility event")
+	}
+	event.ID = uuid.New().String()
+
+	message, err := models.NewOutboxMessage(event)
+	if err != nil {
+		return false, errors.Wrap(err, "invalid outbox message")
+	}
+
+	applied := false
+	err = s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		applied, err = s.documentRepo.ApplyDueVisibility(txCtx, document.ID, document.TenantID, from, to, now)
+		if err != nil || !applied {
+			return err
+		}
+		_, err = s.outboxRepo.Create(txCtx, message)
+		return err
+	})
+	if err != nil {
+		return false, errors.Wrap(err, fmt.Sprintf("failed to change visibility of document %s", document.ID))
+	}
+
+	if applied {
+		logger.InfoContext(ctx, "Document visibility changed by its schedule", "document_id", document.ID,
+			"tenant_id", document.TenantID, "visibility", to)
+	}
+	return applied, nil
+}
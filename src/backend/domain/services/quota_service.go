@@ -0,0 +1,259 @@
+// Package services provides domain-level services for the Document Management Platform
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid" // v1.3.0+ - For identifying quota events
+
+	"../models"
+	"../repositories"
+	"../../pkg/errors"
+	"../../pkg/logger"
+)
+
+// QuotaService defines the contract for enforcing per-tenant storage quotas
+type QuotaService interface {
+	// GetQuota returns a tenant's quota. A tenant's quota is created on first use with the default
+	// limits and the tenant's current usage, so that tracking also covers documents stored before.
+	GetQuota(ctx context.Context, tenantID string) (*models.TenantQuota, error)
+
+	// CheckUpload checks that a new document of the given size fits the tenant's quota without
+	// counting it, so that uploads can be rejected before their content is stored. A rejected
+	// upload stores a tenant.quota_exceeded event in the outbox.
+	CheckUpload(ctx context.Context, tenantID, userID string, size int64) (*models.TenantQuota, error)
+
+	// ReserveUpload counts a new document of the given size against the tenant's quota. When ctx
+	// carries a transaction the reservation commits or rolls back with it.
+	ReserveUpload(ctx context.Context, tenantID string, size int64) (*models.TenantQuota, error)
+
+	// CheckVersion checks that a new version of the given size of an existing document fits the
+	// tenant's storage quota without counting it. New versions do not count against the document limit.
+	CheckVersion(ctx context.Context, tenantID, userID string, size int64) (*models.TenantQuota, error)
+
+	// ReserveVersion counts a new version of the given size of an existing document against the
+	// tenant's storage quota, with the same transaction semantics as ReserveUpload
+	ReserveVersion(ctx context.Context, tenantID string, size int64) (*models.TenantQuota, error)
+
+	// ReleaseDocument gives back the storage of a deleted document, summed over its versions
+	ReleaseDocument(ctx context.Context, tenantID string, size int64) error
+
+	// SetLimits changes a tenant's limits. A zero limit means unlimited.
+	SetLimits(ctx context.Context, tenantID string, maxStorageBytes, maxDocuments int64) (*models.TenantQuota, error)
+}
+
+// quotaService implements the QuotaService interface
+type quotaService struct {
+	quotaRepo              repositories.QuotaRepository
+	documentRepo           repositories.DocumentRepository
+	outboxRepo             repositories.OutboxRepository
+	defaultMaxStorageBytes int64
+	defaultMaxDocuments    int64
+}
+
+// NewQuotaService creates a new QuotaService instance. The default limits apply to tenants whose
+// quota has not been configured; zero means unlimited.
+func NewQuotaService(
+	quotaRepo repositories.QuotaRepository,
+	documentRepo repositories.DocumentRepository,
+	outboxRepo repositories.OutboxRepository,
+	defaultMaxStorageBytes int64,
+	defaultMaxDocuments int64,
+) (QuotaService, error) {
+	if quotaRepo == nil {
+		return nil, fmt.Errorf("quota repository cannot be nil")
+	}
+	if documentRepo == nil {
+		return nil, fmt.Errorf("document repository cannot be nil")
+	}
+	if outboxRepo == nil {
+		return nil, fmt.Errorf("outbox repository cannot be nil")
+	}
+	if defaultMaxStorageBytes < 0 || defaultMaxDocuments < 0 {
+		return nil, fmt.Errorf("default quota limits cannot be negative")
+	}
+
+	return &quotaService{
+		quotaRepo:              quotaRepo,
+		documentRepo:           documentRepo,
+		outboxRepo:             outboxRepo,
+		defaultMaxStorageBytes: defaultMaxStorageBytes,
+		defaultMaxDocuments:    defaultMaxDocuments,
+	}, nil
+}
+
+// GetQuota returns a tenant's quota, creating it on first use
+func (s *quotaService) GetQuota(ctx context.Context, tenantID string) (*models.TenantQuota, error) {
+	if tenantID == "" {
+		return nil, errors.NewValidationError("tenant ID cannot be empty")
+	}
+
+	quota, err := s.quotaRepo.GetByTenantID(ctx, tenantID)
+	if err == nil {
+		return quota, nil
+	}
+	if !errors.IsResourceNotFoundError(err) {
+		return nil, errors.Wrap(err, "failed to get tenant quota")
+	}
+
+	// Start tracking from the tenant's current usage
+	documents, storageBytes, err := s.documentRepo.GetUsage(ctx, tenantID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get document usage")
+	}
+
+	quota = models.NewTenantQuota(tenantID, s.defaultMaxStorageBytes, s.defaultMaxDocuments)
+	quota.UsedStorageBytes = storageBytes
+	quota.UsedDocuments = documents
+	if err := s.quotaRepo.Create(ctx, quota); err != nil {
+		return nil, errors.Wrap(err, "failed to create tenant quota")
+	}
+
+	// Read the quota back in case a concurrent request created it first
+	quota, err = s.quotaRepo.GetByTenantID(ctx, tenantID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get tenant quota")
+	}
+
+	logger.WithContext(ctx).Info("Tenant quota created", "tenant_id", tenantID, "used_storage_bytes", quota.UsedStorageBytes, "used_documents", quota.UsedDocuments)
+	return quota, nil
+}
+
+// CheckUpload checks that a new document of the given size fits the tenant's quota
+func (s *quotaService) CheckUpload(ctx context.Context, tenantID, userID string, size int64) (*models.TenantQuota, error) {
+	quota, err := s.GetQuota(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := quota.CheckUpload(size); err != nil {
+		s.publishQuotaExceeded(ctx, quota, err, userID, size)
+		return quota, quotaError(err)
+	}
+
+	return quota, nil
+}
+
+// ReserveUpload counts a new document of the given size against the tenant's quota
+func (s *quotaService) ReserveUpload(ctx context.Context, tenantID string, size int64) (*models.TenantQuota, error) {
+	// Make sure the tenant's quota exists before updating it
+	if _, err := s.GetQuota(ctx, tenantID); err != nil {
+		return nil, err
+	}
+
+	quota, err := s.quotaRepo.AddUsage(ctx, tenantID, size, 1)
+	if err == models.ErrStorageQuotaExceeded || err == models.ErrDocumentQuotaExceeded {
+		return quota, quotaError(err)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to reserve tenant quota")
+	}
+
+	return quota, nil
+}
+
+// CheckVersion checks that a new version of the given size fits the tenant's storage quota
+func (s *quotaService) CheckVersion(ctx context.Context, tenantID, userID string, size int64) (*models.TenantQuota, error) {
+	quota, err := s.GetQuota(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := quota.CheckVersion(size); err != nil {
+		s.publishQuotaExceeded(ctx, quota, err, userID, size)
+		return quota, quotaError(err)
+	}
+
+	return quota, nil
+}
+
+// ReserveVersion counts a new version of the given size against the tenant's storage quota
+func (s *quotaService) ReserveVersion(ctx context.Context, tenantID string, size int64) (*models.TenantQuota, error) {
+	if _, err := s.GetQuota(ctx, tenantID); err != nil {
+		return nil, err
+	}
+
+	quota, err := s.quotaRepo.AddUsage(ctx, tenantID, size, 0)
+	if err == models.ErrStorageQuotaExceeded {
+		return quota, quotaError(err)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to reserve tenant quota")
+	}
+
+	return quota, nil
+}
+
+// ReleaseDocument gives back the storage of a deleted document
+func (s *quotaService) ReleaseDocument(ctx context.Context, tenantID string, size int64) error {
+	if tenantID == "" {
+		return errors.NewValidationError("tenant ID cannot be empty")
+	}
+
+	_, err := s.quotaRepo.AddUsage(ctx, tenantID, -size, -1)
+	if err != nil {
+		// Tenants without a quota start tracking from their usage at that time
+		if errors.IsResourceNotFoundError(err) {
+			return nil
+		}
+		return errors.Wrap(err, "failed to release tenant quota")
+	}
+
+	return nil
+}
+
+// SetLimits changes a tenant's limits
+func (s *quotaService) SetLimits(ctx context.Context, tenantID string, maxStorageBytes, maxDocuments int64) (*models.TenantQuota, error) {
+	if maxStorageBytes < 0 || maxDocuments < 0 {
+		return nil, errors.NewValidationError("quota limits cannot be negative")
+	}
+
+	if _, err := s.GetQuota(ctx, tenantID); err != nil {
+		return nil, err
+	}
+
+	if err := s.quotaRepo.SetLimits(ctx, tenantID, maxStorageBytes, maxDocuments); err != nil {
+		return nil, errors.Wrap(err, "failed to set tenant quota limits")
+	}
+
+	logger.WithContext(ctx).Info("Tenant quota limits set", "tenant_id", tenantID, "max_storage_bytes", maxStorageBytes, "max_documents", maxDocuments)
+	return s.quotaRepo.GetByTenantID(ctx, tenantID)
+}
+
+// publishQuotaExceeded stores a tenant.quota_exceeded event for a rejected upload in the outbox,
+// from which the outbox relay publishes it
+func (s *quotaService) publishQuotaExceeded(ctx context.Context, quota *models.TenantQuota, quotaErr error, userID string, size int64) {
+	log := logger.WithContext(ctx)
+
+	limit := models.QuotaLimitStorage
+	if quotaErr == models.ErrDocumentQuotaExceeded {
+		limit = models.QuotaLimitDocuments
+	}
+
+	event, err := models.NewTenantQuotaExceededEvent(quota.TenantID, limit, quota, userID, size)
+	if err != nil {
+		log.Warn("failed to create tenant.quota_exceeded event", "error", err.Error())
+		return
+	}
+	event.ID = uuid.New().String()
+
+	message, err := models.NewOutboxMessage(event)
+	if err != nil {
+		log.Warn("failed to create outbox message for tenant.quota_exceeded event", "error", err.Error())
+		return
+	}
+	if err := s.outboxRepo.Create(ctx, message); err != nil {
+		log.Warn("failed to publish tenant.quota_exceeded event", "error", err.Error())
+		// Do not return error, the upload is rejected either way
+	}
+}
+
+// quotaError converts a quota violation into an application error. Uploads that do not fit the
+// remaining storage are too large, while uploads beyond the document count are too many.
+func quotaError(err error) error {
+	if err == models.ErrStorageQuotaExceeded {
+		return errors.WithCode(errors.NewStorageQuotaExceededError(err.Error()), errors.CodeStorageQuotaExceeded)
+	}
+	return errors.WithCode(errors.NewQuotaExceededError(err.Error()), errors.CodeDocumentQuotaExceeded)
+}
@@ -0,0 +1,184 @@
+// Package services provides domain-level services for the Document Management Platform
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"../models"
+	"../repositories"
+	"../../pkg/errors"
+	"../../pkg/logger"
+	"../../pkg/utils"
+)
+
+// RoleService defines the contract for managing a tenant's roles and the permissions they grant
+type RoleService interface {
+	// CreateRole creates a custom role in a tenant granting the given permissions
+	CreateRole(ctx context.Context, tenantID, name, description string, permissions []string) (*models.Role, error)
+
+	// GetRole retrieves a role by its ID with tenant isolation
+	GetRole(ctx context.Context, id string, tenantID string) (*models.Role, error)
+
+	// ListRoles lists a tenant's roles, including the predefined system roles, with pagination
+	ListRoles(ctx context.Context, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Role], error)
+
+	// UpdateRole updates the description and permissions of a custom role
+	UpdateRole(ctx context.Context, id, tenantID, description string, permissions []string) (*models.Role, error)
+
+	// DeleteRole deletes a custom role. Users assigned the role lose the permissions it granted.
+	DeleteRole(ctx context.Context, id string, tenantID string) error
+}
+
+// roleService implements the RoleService interface
+type roleService struct {
+	roleRepo repositories.RoleRepository
+}
+
+// NewRoleService creates a new RoleService instance
+func NewRoleService(roleRepo repositories.RoleRepository) (RoleService, error) {
+	if roleRepo == nil {
+		return nil, fmt.Errorf("role repository cannot be nil")
+	}
+
+	return &roleService{
+		roleRepo: roleRepo,
+	}, nil
+}
+
+// CreateRole creates a custom role in a tenant granting the given permissions
+func (s *roleService) CreateRole(ctx context.Context, tenantID, name, description string, permissions []string) (*models.Role, error) {
+	ctxLogger := logger.WithContext(ctx)
+
+	name = strings.TrimSpace(name)
+	if err := s.validateInput(map[string]string{
+		"tenant ID": tenantID,
+		"role name": name,
+	}); err != nil {
+		return nil, err
+	}
+
+	// The predefined role names are reserved so that custom roles cannot shadow them
+	if models.IsSystemRole(name) {
+		return nil, errors.NewValidationError(fmt.Sprintf("role name %s is reserved", name))
+	}
+
+	role := models.NewRole(name, description, tenantID)
+	role.SetPermissions(permissions)
+	if err := role.Validate(); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	if _, err := s.roleRepo.Create(ctx, role); err != nil {
+		ctxLogger.Error("Failed to create role", "error", err, "tenant_id", tenantID, "name", name)
+		return nil, err
+	}
+
+	ctxLogger.Info("Role created", "role_id", role.ID, "tenant_id", tenantID, "name", name)
+	return role, nil
+}
+
+// GetRole retrieves a role by its ID with tenant isolation
+func (s *roleService) GetRole(ctx context.Context, id string, tenantID string) (*models.Role, error) {
+	if err := s.validateInput(map[string]string{
+		"role ID":   id,
+		"tenant ID": tenantID,
+	}); err != nil {
+		return nil, err
+	}
+
+	return s.roleRepo.GetByID(ctx, id, tenantID)
+}
+
+// ListRoles lists a tenant's roles with pagination
+func (s *roleService) ListRoles(ctx context.Context, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Role], error) {
+	if err := s.validateInput(map[string]string{
+		"tenant ID": tenantID,
+	}); err != nil {
+		return utils.PaginatedResult[models.Role]{}, err
+	}
+
+	if pagination == nil {
+		pagination = utils.NewPagination(utils.DefaultPage, utils.DefaultPageSize)
+	}
+
+	return s.roleRepo.List(ctx, tenantID, pagination)
+}
+
+// UpdateRole updates the description and permissions of a custom role.
+// The predefined system roles cannot be modified.
+func (s *roleService) UpdateRole(ctx context.Context, id, tenantID, description string, permissions []string) (*models.Role, error) {
+	ctxLogger := logger.WithContext(ctx)
+
+	if err := s.validateInput(map[string]string{
+		"role ID":   id,
+		"tenant ID": tenantID,
+	}); err != nil {
+		return nil, err
+	}
+
+	role, err := s.roleRepo.GetByID(ctx, id, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if role.IsSystemRole() {
+		return nil, errors.NewValidationError(fmt.Sprintf("system role %s cannot be modified", role.Name))
+	}
+
+	if description != "" {
+		role.Description = description
+	}
+	role.SetPermissions(permissions)
+	if err := role.Validate(); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	if err := s.roleRepo.Update(ctx, role); err != nil {
+		ctxLogger.Error("Failed to update role", "error", err, "role_id", id, "tenant_id", tenantID)
+		return nil, err
+	}
+
+	ctxLogger.Info("Role updated", "role_id", id, "tenant_id", tenantID)
+	return role, nil
+}
+
+// DeleteRole deletes a custom role. The predefined system roles cannot be deleted.
+func (s *roleService) DeleteRole(ctx context.Context, id string, tenantID string) error {
+	ctxLogger := logger.WithContext(ctx)
+
+	if err := s.validateInput(map[string]string{
+		"role ID":   id,
+		"tenant ID": tenantID,
+	}); err != nil {
+		return err
+	}
+
+	role, err := s.roleRepo.GetByID(ctx, id, tenantID)
+	if err != nil {
+		return err
+	}
+
+	if role.IsSystemRole() {
+		return errors.NewValidationError(fmt.Sprintf("system role %s cannot be deleted", role.Name))
+	}
+
+	if err := s.roleRepo.Delete(ctx, id, tenantID); err != nil {
+		ctxLogger.Error("Failed to delete role", "error", err, "role_id", id, "tenant_id", tenantID)
+		return err
+	}
+
+	ctxLogger.Info("Role deleted", "role_id", id, "tenant_id", tenantID, "name", role.Name)
+	return nil
+}
+
+// validateInput validates that required input parameters are not empty
+func (s *roleService) validateInput(params map[string]string) error {
+	for name, value := range params {
+		if value == "" {
+			return errors.NewValidationError(fmt.Sprintf("%s cannot be empty", name))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+// Package services provides domain-level services for the Document Management Platform
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"../repositories"
+	"../../pkg/errors"
+	"../../pkg/logger"
+)
+
+// ScanVerdictCache remembers the content hashes of each tenant that were recently scanned clean, so
+// identical uploads are marked clean without being scanned again. Infected verdicts are never
+// cached, and tenants can opt out with the scan_verdict_cache_disabled setting.
+type ScanVerdictCache interface {
+	// IsKnownClean checks if the tenant's content with the hash was scanned clean within the TTL of
+	// the cache. Tenants that opted out and content without a hash are never known clean. Failures
+	// are logged and report the content as unknown, so it is scanned.
+	IsKnownClean(ctx context.Context, tenantID, contentHash string) bool
+
+	// RecordClean records that the tenant's content with the hash was scanned clean. Failures are
+	// logged, as the content is only scanned again.
+	RecordClean(ctx context.Context, tenantID, contentHash string)
+
+	// Invalidate forgets every verdict, such as when the signature database is updated and content
+	// scanned clean before may be found infected now
+	Invalidate(ctx context.Context) error
+}
+
+// scanVerdictCache implements the ScanVerdictCache interface
+type scanVerdictCache struct {
+	verdictRepo repositories.ScanVerdictRepository
+	tenantRepo  repositories.TenantRepository
+	ttl         time.Duration
+}
+
+// NewScanVerdictCache creates a ScanVerdictCache remembering clean verdicts for ttl
+func NewScanVerdictCache(verdictRepo repositories.ScanVerdictRepository, tenantRepo repositories.TenantRepository, ttl time.Duration) (ScanVerdictCache, error) {
+	if verdictRepo == nil {
+		return nil, fmt.Errorf("scan verdict repository cannot be nil")
+	}
+	if tenantRepo == nil {
+		return nil, fmt.Errorf("tenant repository cannot be nil")
+	}
+	if ttl <= 0 {
+		return nil, fmt.Errorf("scan verdict TTL must be positive")
+	}
+
+	return &scanVerdictCache{
+		verdictRepo: verdictRepo,
+		tenantRepo:  tenantRepo,
+		ttl:         ttl,
+	}, nil
+}
+
+// IsKnownClean checks the tenant's opt-out, then the verdict of the content
+func (c *scanVerdictCache) IsKnownClean(ctx context.Context, tenantID, contentHash string) bool {
+	if tenantID == "" || contentHash == "" || !c.tenantCaches(ctx, tenantID) {
+		return false
+	}
+
+	clean, err := c.verdictRepo.IsClean(ctx, tenantID, contentHash)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to look up cached scan verdict", "tenant_id", tenantID, "error", err.Error())
+		return false
+	}
+	return clean
+}
+
+// RecordClean stores the verdict of the content unless the tenant opted out
+func (c *scanVerdictCache) RecordClean(ctx context.Context, tenantID, contentHash string) {
+	if tenantID == "" || contentHash == "" || !c.tenantCaches(ctx, tenantID) {
+		return
+	}
+
+	if err := c.verdictRepo.StoreClean(ctx, tenantID, contentHash, c.ttl); err != nil {
+		logger.ErrorContext(ctx, "Failed to cache scan verdict", "tenant_id", tenantID, "error", err.Error())
+	}
+}
+
+// Invalidate forgets the verdicts of all tenants
+func (c *scanVerdictCache) Invalidate(ctx context.Context) error {
+	if err := c.verdictRepo.DeleteAll(ctx); err != nil {
+		return errors.Wrap(err, "failed to invalidate cached scan verdicts")
+	}
+	return nil
+}
+
+// tenantCaches checks if the tenant lets its uploads reuse clean verdicts. Tenants that cannot be
+// read are treated as opted out.
+func (c *scanVerdictCache) tenantCaches(ctx context.Context, tenantID string) bool {
+	tenant, err := c.tenantRepo.GetByID(ctx, tenantID)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to get tenant for scan verdict cache", "tenant_id", tenantID, "error", err.Error())
+		return false
+	}
+	return tenant != nil && tenant.CachesScanVerdicts()
+}
@@ -0,0 +1,158 @@
+// Package services provides domain-level services for the Document Management Platform
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"sync"
+
+	"../repositories"
+	"../../pkg/errors"
+)
+
+// scanningEngineSelector implements the ScanningEngineSelector interface
+type scanningEngineSelector struct {
+	tenantRepo     repositories.TenantRepository
+	engines        map[string]ScanningEngine
+	defaultEngines []string
+}
+
+// NewScanningEngineSelector creates a ScanningEngineSelector choosing among engines. Documents of
+// tenants that select no engines are scanned with defaultEngines.
+func NewScanningEngineSelector(tenantRepo repositories.TenantRepository, engines []ScanningEngine, defaultEngines []string) (ScanningEngineSelector, error) {
+	if tenantRepo == nil {
+		return nil, fmt.Errorf("tenant repository cannot be nil")
+	}
+	if len(defaultEngines) == 0 {
+		return nil, fmt.Errorf("default scanning engines cannot be empty")
+	}
+
+	byName := make(map[string]ScanningEngine, len(engines))
+	for _, engine := range engines {
+		if engine == nil {
+			return nil, fmt.Errorf("scanning engine cannot be nil")
+		}
+		byName[engine.Name()] = engine
+	}
+	for _, name := range defaultEngines {
+		if _, ok := byName[name]; !ok {
+			return nil, fmt.Errorf("default scanning engine %s is not available", name)
+		}
+	}
+
+	return &scanningEngineSelector{
+		tenantRepo:     tenantRepo,
+		engines:        byName,
+		defaultEngines: defaultEngines,
+	}, nil
+}
+
+// EngineForTenant returns the scanner for the engines the tenant selected, or the default engines
+func (s *scanningEngineSelector) EngineForTenant(ctx context.Context, tenantID string) (ScannerClient, error) {
+	names := s.defaultEngines
+	if tenantID != "" {
+		tenant, err := s.tenantRepo.GetByID(ctx, tenantID)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get tenant")
+		}
+		if tenant == nil {
+			return nil, errors.NewResourceNotFoundError(fmt.Sprintf("tenant %s not found", tenantID))
+		}
+		if selected := tenant.ScanEngines(); len(selected) > 0 {
+			names = selected
+		}
+	}
+
+	// A tenant selecting an engine this deployment does not run fails its scans rather than
+	// having its documents scanned by fewer engines than it asked for
+	engines := make([]ScanningEngine, 0, len(names))
+	for _, name := range names {
+		engine, ok := s.engines[name]
+		if !ok {
+			return nil, errors.NewValidationError(fmt.Sprintf("scanning engine %s is not available", name))
+		}
+		engines = append(engines, engine)
+	}
+
+	if len(engines) == 1 {
+		return engines[0], nil
+	}
+	return &multiEngineScanner{engines: engines}, nil
+}
+
+// multiEngineScanner scans content with several engines in parallel. The content is streamed to
+// all engines at once, so it is read from storage a single time.
+type multiEngineScanner struct {
+	engines []ScanningEngine
+}
+
+// engineVerdict is the outcome of scanning content with one engine
+type engineVerdict struct {
+	result  string
+	details string
+	err     error
+}
+
+// ScanStream scans content with every engine. The content is infected when any engine finds it
+// infected, and clean only when every engine finds it clean.
+func (m *multiEngineScanner) ScanStream(ctx context.Context, content io.Reader) (string, string, error) {
+	verdicts := make([]engineVerdict, len(m.engines))
+	pipes := make([]*io.PipeWriter, len(m.engines))
+	writers := make([]io.Writer, len(m.engines))
+
+	var wg sync.WaitGroup
+	for i, engine := range m.engines {
+		reader, writer := io.Pipe()
+		pipes[i] = writer
+		writers[i] = writer
+
+		wg.Add(1)
+		go func(i int, engine ScanningEngine, reader *io.PipeReader) {
+			defer wg.Done()
+			result, details, err := engine.ScanStream(ctx, reader)
+			verdicts[i] = engineVerdict{result: result, details: details, err: err}
+			// Keep consuming content an engine stopped reading early, so the other engines are not blocked
+			io.Copy(ioutil.Discard, reader)
+		}(i, engine, reader)
+	}
+
+	_, copyErr := io.Copy(io.MultiWriter(writers...), content)
+	for _, pipe := range pipes {
+		pipe.CloseWithError(copyErr)
+	}
+	wg.Wait()
+
+	if copyErr != nil {
+		return ScanResultError, "", errors.Wrap(copyErr, "failed to read content")
+	}
+
+	var infections []string
+	var scanErr error
+	for i, verdict := range verdicts {
+		name := m.engines[i].Name()
+		switch {
+		case verdict.err != nil:
+			if scanErr == nil {
+				scanErr = errors.Wrap(verdict.err, fmt.Sprintf("scanning engine %s failed", name))
+			}
+		case verdict.result == ScanResultInfected:
+			infections = append(infections, fmt.Sprintf("%s: %s", name, verdict.details))
+		case verdict.result != ScanResultClean:
+			if scanErr == nil {
+				scanErr = fmt.Errorf("scanning engine %s returned %s", name, verdict.result)
+			}
+		}
+	}
+
+	// An infection found by one engine stands even if another engine failed
+	if len(infections) > 0 {
+		return ScanResultInfected, strings.Join(infections, "; "), nil
+	}
+	if scanErr != nil {
+		return ScanResultError, "", scanErr
+	}
+	return ScanResultClean, "", nil
+}
@@ -0,0 +1,231 @@
+// Package services provides domain-level services for the Document Management Platform
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"../models"
+	"../repositories"
+	"../../pkg/errors"
+	"../../pkg/logger"
+	"../../pkg/utils"
+)
+
+// reindexJobStaleAfter is how long a running rebuild may go without a progress update before
+// another worker assumes it was abandoned and runs it again
+const reindexJobStaleAfter = 30 * time.Minute
+
+// reindexProgressInterval is the number of documents indexed between progress updates
+const reindexProgressInterval = 100
+
+// maxReindexedContentBytes limits the content read from storage to index a document, so a huge
+// file cannot exhaust the memory of the worker
+const maxReindexedContentBytes = 10 * 1024 * 1024
+
+// ErrSearchIndexRebuildUnsupported is returned when the configured search provider cannot rebuild
+// the index of a tenant
+var ErrSearchIndexRebuildUnsupported = errors.NewValidationError("the configured search provider does not support rebuilding the index")
+
+// SearchReindexer runs background rebuilds of the search index of tenants
+type SearchReindexer interface {
+	// ReindexNext claims the next reindex job and indexes every available document of its tenant
+	// into a fresh index, which then replaces the tenant's index. Returns false if there was no
+	// job to run.
+	ReindexNext(ctx context.Context) (bool, error)
+}
+
+// searchReindexer implements the SearchReindexer interface
+type searchReindexer struct {
+	reindexJobRepo repositories.ReindexJobRepository
+	documentRepo   repositories.DocumentRepository
+	storageService StorageService
+	indexer        SearchIndexer
+	rebuilder      SearchIndexRebuilder
+}
+
+// NewSearchReindexer creates a new SearchReindexer instance. The indexer must also implement
+// SearchIndexRebuilder.
+func NewSearchReindexer(reindexJobRepo repositories.ReindexJobRepository, documentRepo repositories.DocumentRepository,
+	storageService StorageService, indexer SearchIndexer) (SearchReindexer, error) {
+	if reindexJobRepo == nil {
+		return nil, fmt.Errorf("reindex job repository cannot be nil")
+	}
+	if documentRepo == nil {
+		return nil, fmt.Errorf("document repository cannot be nil")
+	}
+	if storageService == nil {
+		return nil, fmt.Errorf("storage service cannot be nil")
+	}
+	if indexer == nil {
+		return nil, fmt.Errorf("indexer cannot be nil")
+	}
+	rebuilder, ok := indexer.(SearchIndexRebuilder)
+	if !ok {
+		return nil, ErrSearchIndexRebuildUnsupported
+	}
+
+	return &searchReindexer{
+		reindexJobRepo: reindexJobRepo,
+		documentRepo:   documentRepo,
+		storageService: storageService,
+		indexer:        indexer,
+		rebuilder:      rebuilder,
+	}, nil
+}
+
+// ReindexNext claims and runs the next reindex job. Documents are read a page at a time, so
+// rebuilding large tenants never has to fit in memory. Documents changed while the rebuild runs
+// are indexed in the live index by the API, so once the rebuilt index is live they are indexed
+// again from the database. If the worker stops mid-rebuild the job stays running and is claimed
+// again once it is stale, discarding what was indexed so far.
+func (s *searchReindexer) ReindexNext(ctx context.Context) (bool, error) {
+	ctxLogger := logger.WithContext(ctx)
+
+	job, err := s.reindexJobRepo.ClaimNext(ctx, time.Now().Add(-reindexJobStaleAfter))
+	if err != nil {
+		return false, errors.Wrap(err, "failed to claim reindex job")
+	}
+	if job == nil {
+		return false, nil
+	}
+
+	ctxLogger.Info("Rebuilding search index", "reindex_id", job.ID, "tenant_id", job.TenantID)
+
+	rebuild, err := s.rebuilder.BeginRebuild(ctx, job.TenantID, job.ID)
+	if err != nil {
+		return true, s.fail(ctx, job, "failed to create the new index", err)
+	}
+
+	reason, err := s.indexDocuments(ctx, job, rebuild)
+	if err == nil {
+		reason = "failed to replace the index"
+		err = rebuild.Commit(ctx)
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			// Shutting down; the job is reclaimed by the next worker, which begins a new rebuild
+			return true, ctx.Err()
+		}
+		if abortErr := rebuild.Abort(ctx); abortErr != nil {
+			ctxLogger.Error("Failed to discard rebuilt index", "error", abortErr, "reindex_id", job.ID)
+		}
+		return true, s.fail(ctx, job, reason, err)
+	}
+
+	if err := s.catchUp(ctx, job); err != nil {
+		// The rebuilt index is live; the documents missed here are indexed on their next change
+		ctxLogger.Error("Failed to index documents changed during rebuild", "error", err, "reindex_id", job.ID)
+	}
+
+	job.Complete(time.Now())
+	if err := s.reindexJobRepo.Update(ctx, job); err != nil {
+		return true, errors.Wrap(err, "failed to update reindex job")
+	}
+
+	ctxLogger.Info("Search index rebuilt", "reindex_id", job.ID, "tenant_id", job.TenantID, "document_count", job.IndexedDocuments)
+	return true, nil
+}
+
+// indexDocuments indexes the available documents of the tenant into the rebuilt index, updating
+// the job's progress as it goes. On failure it returns a reason that can be shown to the
+// administrator along with the error.
+func (s *searchReindexer) indexDocuments(ctx context.Context, job *models.ReindexJob, rebuild SearchIndexRebuild) (string, error) {
+	for page := 1; ; page++ {
+		result, err := s.documentRepo.ListByTenant(ctx, job.TenantID, utils.NewPagination(page, utils.MaxPageSize))
+		if err != nil {
+			return "failed to list documents", err
+		}
+		if page == 1 {
+			job.TotalDocuments = int(result.Pagination.TotalItems)
+		}
+
+		for i := range result.Items {
+			document := &result.Items[i]
+			indexed, err := s.indexDocument(ctx, document, rebuild.IndexDocument)
+			if err != nil {
+				return fmt.Sprintf("failed to index document %s", document.ID), err
+			}
+			if !indexed {
+				continue
+			}
+
+			job.IndexedDocuments++
+			if job.IndexedDocuments%reindexProgressInterval == 0 {
+				job.UpdatedAt = time.Now()
+				if err := s.reindexJobRepo.Update(ctx, job); err != nil {
+					return "failed to update reindex progress", err
+				}
+			}
+		}
+
+		if !result.Pagination.HasNext {
+			return "", nil
+		}
+	}
+}
+
+// catchUp indexes the documents changed since the job started in the live index, which is now
+// the rebuilt one. Documents deleted during the rebuild may linger in the index, but searches only
+// return documents that still exist.
+func (s *searchReindexer) catchUp(ctx context.Context, job *models.ReindexJob) error {
+	for page := 1; ; page++ {
+		result, err := s.documentRepo.ListByTenant(ctx, job.TenantID, utils.NewPagination(page, utils.MaxPageSize))
+		if err != nil {
+			return err
+		}
+
+		for i := range result.Items {
+			document := &result.Items[i]
+			if document.UpdatedAt.Before(*job.StartedAt) {
+				continue
+			}
+			if _, err := s.indexDocument(ctx, document, s.indexer.IndexDocument); err != nil {
+				return err
+			}
+		}
+
+		if !result.Pagination.HasNext {
+			return nil
+		}
+	}
+}
+
+// indexDocument reads the content of the latest version of a document from storage and indexes
+// it. Returns false without indexing documents that are not available or have no content.
+func (s *searchReindexer) indexDocument(ctx context.Context, document *models.Document,
+	index func(ctx context.Context, document *models.Document, content []byte) error) (bool, error) {
+	version := document.GetLatestVersion()
+	if !document.IsAvailable() || version == nil {
+		return false, nil
+	}
+
+	reader, err := s.storageService.GetDocument(ctx, version.StoragePath)
+	if err != nil {
+		return false, err
+	}
+	defer reader.Close()
+
+	content, err := io.ReadAll(io.LimitReader(reader, maxReindexedContentBytes))
+	if err != nil {
+		return false, err
+	}
+	if len(content) == 0 {
+		return false, nil
+	}
+
+	return true, index(ctx, document, content)
+}
+
+// fail marks the job as failed with the reason
+func (s *searchReindexer) fail(ctx context.Context, job *models.ReindexJob, reason string, cause error) error {
+	logger.WithContext(ctx).Error("Failed to rebuild search index", "error", cause, "reindex_id", job.ID, "reason", reason)
+
+	job.Fail(reason, time.Now())
+	if err := s.reindexJobRepo.Update(ctx, job); err != nil {
+		return errors.Wrap(err, "failed to update reindex job")
+	}
+	return nil
+}
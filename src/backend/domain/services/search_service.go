@@ -13,6 +13,60 @@ import (
 	"../../pkg/utils"
 )
 
+// Search providers selectable with config.Search.Provider
+const (
+	SearchProviderElasticsearch  = "elasticsearch"
+	SearchProviderElasticsearch7 = "elasticsearch7"
+	SearchProviderOpenSearch     = "opensearch"
+	SearchProviderPostgres       = "postgres"
+	SearchProviderMemory         = "memory"
+)
+
+// IndexRefresh selects when a document indexed or removed by a request is reflected in searches.
+// Indexes whose changes are searchable as soon as they are written ignore it.
+type IndexRefresh string
+
+const (
+	// IndexRefreshNone returns as soon as the change is written; searches reflect it after the
+	// next periodic refresh of the index, usually within a second
+	IndexRefreshNone IndexRefresh = "none"
+
+	// IndexRefreshWaitFor waits until the next periodic refresh has made the change searchable
+	IndexRefreshWaitFor IndexRefresh = "wait_for"
+
+	// IndexRefreshImmediate refreshes the index right away, which makes the change searchable
+	// sooner than waiting but is costly when many documents are indexed
+	IndexRefreshImmediate IndexRefresh = "immediate"
+)
+
+// ParseIndexRefresh parses an IndexRefresh, "" being IndexRefreshNone
+func ParseIndexRefresh(value string) (IndexRefresh, error) {
+	switch refresh := IndexRefresh(value); refresh {
+	case "":
+		return IndexRefreshNone, nil
+	case IndexRefreshNone, IndexRefreshWaitFor, IndexRefreshImmediate:
+		return refresh, nil
+	default:
+		return "", errors.NewValidationError(fmt.Sprintf("invalid index refresh: %s", value))
+	}
+}
+
+// indexRefreshKey is the context key under which the IndexRefresh is stored
+type indexRefreshKey struct{}
+
+// ContextWithIndexRefresh returns a copy of ctx carrying the refresh search indexes apply to the
+// documents indexed and removed with it, instead of their default. Callers that search right after
+// changing a document, like tests, use it to read their own writes.
+func ContextWithIndexRefresh(ctx context.Context, refresh IndexRefresh) context.Context {
+	return context.WithValue(ctx, indexRefreshKey{}, refresh)
+}
+
+// IndexRefreshFromContext returns the refresh carried by ctx, if any
+func IndexRefreshFromContext(ctx context.Context) (IndexRefresh, bool) {
+	refresh, ok := ctx.Value(indexRefreshKey{}).(IndexRefresh)
+	return refresh, ok
+}
+
 // Error variables for search-related operations
 var ErrEmptySearchQuery = errors.NewValidationError("search query cannot be empty")
 var ErrEmptyMetadataQuery = errors.NewValidationError("metadata search criteria cannot be empty")
@@ -31,16 +85,67 @@ type SearchIndexer interface {
 	RemoveDocument(ctx context.Context, documentID string, tenantID string) error
 }
 
+// SearchTenantRemover is implemented by search indexers that can remove all documents of a tenant
+// at once, so no searchable content of a deleted tenant is left behind
+type SearchTenantRemover interface {
+	// RemoveTenant removes all documents of a tenant from the search index
+	RemoveTenant(ctx context.Context, tenantID string) error
+}
+
+// SearchTenantRouting tells where the documents of a tenant are indexed
+type SearchTenantRouting struct {
+	Index   string `json:"index"`             // Index holding the documents of the tenant
+	Routing string `json:"routing,omitempty"` // Routing of the documents in a shared index, empty for an index of their own
+}
+
+// SearchTenantPreparer is implemented by search indexers that keep tenants apart in indices, so
+// that a new tenant gets its index before its first document is uploaded
+type SearchTenantPreparer interface {
+	// PrepareTenant creates the index of a tenant if it does not exist yet and returns where the
+	// documents of the tenant are indexed
+	PrepareTenant(ctx context.Context, tenantID string) (*SearchTenantRouting, error)
+}
+
+// SearchMetadataUpdater is implemented by search indexers that can replace the metadata of indexed
+// documents without their content, so metadata changed in bulk is searchable without reindexing
+type SearchMetadataUpdater interface {
+	// UpdateMetadata replaces the indexed metadata of documents of a tenant. Documents not indexed
+	// yet are skipped; they are indexed with their current metadata once processed.
+	UpdateMetadata(ctx context.Context, tenantID string, documents []*models.Document) error
+}
+
+// SearchIndexRebuilder is implemented by search indexers that can rebuild the index of a tenant
+// from scratch while searches keep using the current index
+type SearchIndexRebuilder interface {
+	// BeginRebuild starts rebuilding the index of a tenant. Beginning a rebuild again with the same
+	// ID discards whatever an abandoned attempt indexed.
+	BeginRebuild(ctx context.Context, tenantID string, rebuildID string) (SearchIndexRebuild, error)
+}
+
+// SearchIndexRebuild is a rebuild of the index of a tenant in progress
+type SearchIndexRebuild interface {
+	// IndexDocument indexes a document into the rebuilt index
+	IndexDocument(ctx context.Context, document *models.Document, content []byte) error
+
+	// Commit replaces the index of the tenant with the rebuilt index
+	Commit(ctx context.Context) error
+
+	// Abort discards the rebuilt index, leaving the index of the tenant as it was
+	Abort(ctx context.Context) error
+}
+
 // SearchQueryExecutor defines operations for executing search queries
 type SearchQueryExecutor interface {
 	// ExecuteContentSearch executes a content-based search query
 	ExecuteContentSearch(ctx context.Context, query string, tenantID string, pagination *utils.Pagination) ([]string, int64, error)
 	
-	// ExecuteMetadataSearch executes a metadata-based search query
-	ExecuteMetadataSearch(ctx context.Context, metadata map[string]string, tenantID string, pagination *utils.Pagination) ([]string, int64, error)
+	// ExecuteMetadataSearch executes a metadata-based search query. The filters have been resolved
+	// against the tenant's metadata schema, so their Type is set.
+	ExecuteMetadataSearch(ctx context.Context, metadata map[string]string, filters []models.MetadataFilter, tenantID string, pagination *utils.Pagination) ([]string, int64, error)
 	
-	// ExecuteCombinedSearch executes a combined content and metadata search query
-	ExecuteCombinedSearch(ctx context.Context, contentQuery string, metadata map[string]string, tenantID string, pagination *utils.Pagination) ([]string, int64, error)
+	// ExecuteCombinedSearch executes a combined content and metadata search query, with filters
+	// resolved like ExecuteMetadataSearch
+	ExecuteCombinedSearch(ctx context.Context, contentQuery string, metadata map[string]string, filters []models.MetadataFilter, tenantID string, pagination *utils.Pagination) ([]string, int64, error)
 	
 	// ExecuteFolderSearch executes a search query within a specific folder
 	ExecuteFolderSearch(ctx context.Context, folderID string, query string, tenantID string, pagination *utils.Pagination) ([]string, int64, error)
@@ -51,11 +156,12 @@ type SearchService interface {
 	// SearchByContent searches documents by their content
 	SearchByContent(ctx context.Context, query string, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Document], error)
 	
-	// SearchByMetadata searches documents by their metadata
-	SearchByMetadata(ctx context.Context, metadata map[string]string, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Document], error)
+	// SearchByMetadata searches documents by their metadata: the words of the values of metadata
+	// and the operators of filters, all of which must match
+	SearchByMetadata(ctx context.Context, metadata map[string]string, filters []models.MetadataFilter, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Document], error)
 	
 	// CombinedSearch performs a search using both content and metadata criteria
-	CombinedSearch(ctx context.Context, contentQuery string, metadata map[string]string, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Document], error)
+	CombinedSearch(ctx context.Context, contentQuery string, metadata map[string]string, filters []models.MetadataFilter, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Document], error)
 	
 	// SearchInFolder searches documents within a specific folder
 	SearchInFolder(ctx context.Context, folderID string, query string, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Document], error)
@@ -65,10 +171,14 @@ type SearchService interface {
 	
 	// RemoveDocumentFromIndex removes a document from the search index
 	RemoveDocumentFromIndex(ctx context.Context, documentID string, tenantID string) error
+
+	// UpdateIndexedMetadata replaces the indexed metadata of documents whose metadata changed
+	UpdateIndexedMetadata(ctx context.Context, documents []*models.Document, tenantID string) error
 }
 
-// NewSearchService creates a new SearchService instance with the provided dependencies
-func NewSearchService(indexer SearchIndexer, queryExecutor SearchQueryExecutor, documentRepo repositories.DocumentRepository) (SearchService, error) {
+// NewSearchService creates a new SearchService instance with the provided dependencies. Metadata
+// filters are checked against the schemas of metadataSchemas.
+func NewSearchService(indexer SearchIndexer, queryExecutor SearchQueryExecutor, documentRepo repositories.DocumentRepository, metadataSchemas MetadataSchemaService) (SearchService, error) {
 	if indexer == nil {
 		return nil, fmt.Errorf("indexer cannot be nil")
 	}
@@ -78,21 +188,26 @@ func NewSearchService(indexer SearchIndexer, queryExecutor SearchQueryExecutor,
 	if documentRepo == nil {
 		return nil, fmt.Errorf("documentRepo cannot be nil")
 	}
+	if metadataSchemas == nil {
+		return nil, fmt.Errorf("metadataSchemas cannot be nil")
+	}
 
 	return &searchServiceImpl{
-		indexer:       indexer,
-		queryExecutor: queryExecutor,
-		documentRepo:  documentRepo,
-		logger:        logger.WithField("service", "search"),
+		indexer:         indexer,
+		queryExecutor:   queryExecutor,
+		documentRepo:    documentRepo,
+		metadataSchemas: metadataSchemas,
+		logger:          logger.WithField("service", "search"),
 	}, nil
 }
 
 // searchServiceImpl implements the SearchService interface
 type searchServiceImpl struct {
-	indexer       SearchIndexer
-	queryExecutor SearchQueryExecutor
-	documentRepo  repositories.DocumentRepository
-	logger        *logger.Logger
+	indexer         SearchIndexer
+	queryExecutor   SearchQueryExecutor
+	documentRepo    repositories.DocumentRepository
+	metadataSchemas MetadataSchemaService
+	logger          *logger.Logger
 }
 
 // SearchByContent searches documents by their content
@@ -133,11 +248,11 @@ func (s *searchServiceImpl) SearchByContent(ctx context.Context, query string, t
 }
 
 // SearchByMetadata searches documents by their metadata
-func (s *searchServiceImpl) SearchByMetadata(ctx context.Context, metadata map[string]string, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Document], error) {
-	logger.InfoContext(ctx, "SearchByMetadata request", "metadata", metadata, "tenantID", tenantID)
+func (s *searchServiceImpl) SearchByMetadata(ctx context.Context, metadata map[string]string, filters []models.MetadataFilter, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Document], error) {
+	logger.InfoContext(ctx, "SearchByMetadata request", "metadata", metadata, "filters", filters, "tenantID", tenantID)
 	
 	// Validate metadata
-	if metadata == nil || len(metadata) == 0 {
+	if len(metadata) == 0 && len(filters) == 0 {
 		return utils.PaginatedResult[models.Document]{}, ErrEmptyMetadataQuery
 	}
 	
@@ -146,13 +261,19 @@ func (s *searchServiceImpl) SearchByMetadata(ctx context.Context, metadata map[s
 		return utils.PaginatedResult[models.Document]{}, ErrEmptyTenantID
 	}
 	
+	// Resolve filters against the tenant's metadata schema
+	filters, err := s.resolveFilters(ctx, filters, tenantID)
+	if err != nil {
+		return utils.PaginatedResult[models.Document]{}, err
+	}
+	
 	// Set default pagination if not provided
 	if pagination == nil {
 		pagination = utils.NewPagination(utils.DefaultPage, utils.DefaultPageSize)
 	}
 	
 	// Execute metadata search query
-	docIDs, totalCount, err := s.queryExecutor.ExecuteMetadataSearch(ctx, metadata, tenantID, pagination)
+	docIDs, totalCount, err := s.queryExecutor.ExecuteMetadataSearch(ctx, metadata, filters, tenantID, pagination)
 	if err != nil {
 		logger.ErrorContext(ctx, "Failed to execute metadata search", "error", err, "metadata", metadata, "tenantID", tenantID)
 		return utils.PaginatedResult[models.Document]{}, err
@@ -170,12 +291,12 @@ func (s *searchServiceImpl) SearchByMetadata(ctx context.Context, metadata map[s
 }
 
 // CombinedSearch performs a search using both content and metadata criteria
-func (s *searchServiceImpl) CombinedSearch(ctx context.Context, contentQuery string, metadata map[string]string, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Document], error) {
-	logger.InfoContext(ctx, "CombinedSearch request", "contentQuery", contentQuery, "metadata", metadata, "tenantID", tenantID)
+func (s *searchServiceImpl) CombinedSearch(ctx context.Context, contentQuery string, metadata map[string]string, filters []models.MetadataFilter, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Document], error) {
+	logger.InfoContext(ctx, "CombinedSearch request", "contentQuery", contentQuery, "metadata", metadata, "filters", filters, "tenantID", tenantID)
 	
 	// Validate that at least one search criterion is provided
 	contentQueryEmpty := strings.TrimSpace(contentQuery) == ""
-	metadataEmpty := metadata == nil || len(metadata) == 0
+	metadataEmpty := len(metadata) == 0 && len(filters) == 0
 	
 	if contentQueryEmpty && metadataEmpty {
 		return utils.PaginatedResult[models.Document]{}, ErrNoSearchCriteria
@@ -186,13 +307,19 @@ func (s *searchServiceImpl) CombinedSearch(ctx context.Context, contentQuery str
 		return utils.PaginatedResult[models.Document]{}, ErrEmptyTenantID
 	}
 	
+	// Resolve filters against the tenant's metadata schema
+	filters, err := s.resolveFilters(ctx, filters, tenantID)
+	if err != nil {
+		return utils.PaginatedResult[models.Document]{}, err
+	}
+	
 	// Set default pagination if not provided
 	if pagination == nil {
 		pagination = utils.NewPagination(utils.DefaultPage, utils.DefaultPageSize)
 	}
 	
 	// Execute combined search query
-	docIDs, totalCount, err := s.queryExecutor.ExecuteCombinedSearch(ctx, contentQuery, metadata, tenantID, pagination)
+	docIDs, totalCount, err := s.queryExecutor.ExecuteCombinedSearch(ctx, contentQuery, metadata, filters, tenantID, pagination)
 	if err != nil {
 		logger.ErrorContext(ctx, "Failed to execute combined search", 
 			"error", err, 
@@ -327,7 +454,64 @@ func (s *searchServiceImpl) RemoveDocumentFromIndex(ctx context.Context, documen
 	return nil
 }
 
-// getDocumentsByIDs retrieves documents by their IDs with tenant isolation
+// UpdateIndexedMetadata replaces the indexed metadata of documents whose metadata changed, with
+// one request for all of them when the indexer supports it
+func (s *searchServiceImpl) UpdateIndexedMetadata(ctx context.Context, documents []*models.Document, tenantID string) error {
+	logger.InfoContext(ctx, "UpdateIndexedMetadata request", "documents", len(documents), "tenantID", tenantID)
+
+	// Validate tenant ID
+	if tenantID == "" {
+		return ErrEmptyTenantID
+	}
+	if len(documents) == 0 {
+		return nil
+	}
+
+	// Verify documents belong to tenant
+	for _, document := range documents {
+		if document.TenantID != tenantID {
+			logger.WarnContext(ctx, "Document does not belong to tenant", "documentID", document.ID, "tenantID", tenantID, "documentTenantID", document.TenantID)
+			return errors.NewAuthorizationError("document does not belong to tenant")
+		}
+	}
+
+	updater, ok := s.indexer.(SearchMetadataUpdater)
+	if !ok {
+		logger.WarnContext(ctx, "Search index cannot update metadata, documents are found by their new metadata after the next reindex", "documents", len(documents), "tenantID", tenantID)
+		return nil
+	}
+
+	if err := updater.UpdateMetadata(ctx, tenantID, documents); err != nil {
+		logger.ErrorContext(ctx, "Failed to update indexed metadata", "error", err, "documents", len(documents), "tenantID", tenantID)
+		return err
+	}
+
+	logger.InfoContext(ctx, "Indexed metadata updated successfully", "documents", len(documents), "tenantID", tenantID)
+	return nil
+}
+
+// resolveFilters returns a copy of the metadata filters resolved against the tenant's metadata
+// schema, so the query executor knows how to compare the values of each key
+func (s *searchServiceImpl) resolveFilters(ctx context.Context, filters []models.MetadataFilter, tenantID string) ([]models.MetadataFilter, error) {
+	if len(filters) == 0 {
+		return nil, nil
+	}
+
+	schema, err := s.metadataSchemas.GetSchema(ctx, tenantID)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to load metadata schema for search filters", "error", err, "tenantID", tenantID)
+		return nil, err
+	}
+
+	resolved := append([]models.MetadataFilter(nil), filters...)
+	if err := schema.ResolveFilters(resolved); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+	return resolved, nil
+}
+
+// getDocumentsByIDs retrieves documents by their IDs with tenant isolation, in the order of the
+// IDs, which is the order of the search results
 func (s *searchServiceImpl) getDocumentsByIDs(ctx context.Context, documentIDs []string, tenantID string) ([]*models.Document, error) {
 	if len(documentIDs) == 0 {
 		return []*models.Document{}, nil
@@ -338,5 +522,15 @@ func (s *searchServiceImpl) getDocumentsByIDs(ctx context.Context, documentIDs [
 		return nil, err
 	}
 	
-	return documents, nil
+	byID := make(map[string]*models.Document, len(documents))
+	for _, document := range documents {
+		byID[document.ID] = document
+	}
+	ordered := make([]*models.Document, 0, len(documents))
+	for _, id := range documentIDs {
+		if document, ok := byID[id]; ok {
+			ordered = append(ordered, document)
+		}
+	}
+	return ordered, nil
 }
\ No newline at end of file
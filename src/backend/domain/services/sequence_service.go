@@ -0,0 +1,203 @@
+// Package services provides domain-level services for the Document Management Platform
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"../../pkg/errors"
+	"../../pkg/logger"
+	"../models"
+	"../repositories"
+)
+
+// SequenceService manages the numbering sequences of tenants and folders and assigns the numbers
+// of uploaded documents
+type SequenceService interface {
+	// CreateSequence validates and persists a new numbering sequence for a folder, or the tenant's
+	// default sequence when the folder is empty
+	CreateSequence(ctx context.Context, sequence *models.NumberingSequence) (string, error)
+
+	// GetSequence retrieves a numbering sequence by its ID with tenant isolation
+	GetSequence(ctx context.Context, id string, tenantID string) (*models.NumberingSequence, error)
+
+	// ListSequences lists the numbering sequences of a tenant ordered by name
+	ListSequences(ctx context.Context, tenantID string) ([]*models.NumberingSequence, error)
+
+	// UpdateSequence replaces the name, format, padding, mode and reset of an existing numbering sequence
+	UpdateSequence(ctx context.Context, sequence *models.NumberingSequence) error
+
+	// DeleteSequence deletes a numbering sequence
+	DeleteSequence(ctx context.Context, id string, tenantID string) error
+
+	// GetUploadSequence returns the sequence numbering the documents uploaded to a folder: the
+	// folder's own, else the tenant's default, else nil when uploads to the folder are not numbered
+	GetUploadSequence(ctx context.Context, tenantID string, folderID string) (*models.NumberingSequence, error)
+
+	// NextNumber advances a sequence and returns the number it assigns. It joins the transaction
+	// carried by ctx, so that a rollback gives the number back.
+	NextNumber(ctx context.Context, sequence *models.NumberingSequence) (string, error)
+}
+
+// sequenceService implements the SequenceService interface
+type sequenceService struct {
+	sequenceRepo repositories.NumberingSequenceRepository
+	folderRepo   repositories.FolderRepository
+}
+
+// NewSequenceService creates a new SequenceService instance
+func NewSequenceService(sequenceRepo repositories.NumberingSequenceRepository, folderRepo repositories.FolderRepository) (SequenceService, error) {
+	if sequenceRepo == nil {
+		return nil, fmt.Errorf("numbering sequence repository cannot be nil")
+	}
+	if folderRepo == nil {
+		return nil, fmt.Errorf("folder repository cannot be nil")
+	}
+
+	return &sequenceService{
+		sequenceRepo: sequenceRepo,
+		folderRepo:   folderRepo,
+	}, nil
+}
+
+// CreateSequence validates and persists a new numbering sequence, for a folder of the tenant or as
+// the tenant's default. Its numbers start at 1.
+func (s *sequenceService) CreateSequence(ctx context.Context, sequence *models.NumberingSequence) (string, error) {
+	ctxLogger := logger.WithContext(ctx)
+
+	if sequence == nil {
+		return "", errors.NewValidationError("numbering sequence cannot be nil")
+	}
+	sequence.LastValue = 0
+	sequence.LastPeriod = ""
+	if err := sequence.Validate(); err != nil {
+		return "", errors.NewValidationError(err.Error())
+	}
+
+	// The folder, if any, must belong to the tenant
+	if sequence.FolderID != "" {
+		if _, err := s.folderRepo.GetByID(ctx, sequence.FolderID, sequence.TenantID); err != nil {
+			return "", err
+		}
+	}
+
+	id, err := s.sequenceRepo.Create(ctx, sequence)
+	if err != nil {
+		ctxLogger.Error("Failed to create numbering sequence", "error", err, "tenant_id", sequence.TenantID, "folder_id", sequence.FolderID)
+		return "", err
+	}
+
+	ctxLogger.Info("Numbering sequence created", "sequence_id", id, "tenant_id", sequence.TenantID, "folder_id", sequence.FolderID, "mode", sequence.Mode)
+	return id, nil
+}
+
+// GetSequence retrieves a numbering sequence by its ID with tenant isolation
+func (s *sequenceService) GetSequence(ctx context.Context, id string, tenantID string) (*models.NumberingSequence, error) {
+	if err := s.validateInput(map[string]string{
+		"numbering sequence ID": id,
+		"tenant ID":             tenantID,
+	}); err != nil {
+		return nil, err
+	}
+
+	return s.sequenceRepo.GetByID(ctx, id, tenantID)
+}
+
+// ListSequences lists the numbering sequences of a tenant ordered by name
+func (s *sequenceService) ListSequences(ctx context.Context, tenantID string) ([]*models.NumberingSequence, error) {
+	if err := s.validateInput(map[string]string{
+		"tenant ID": tenantID,
+	}); err != nil {
+		return nil, err
+	}
+
+	return s.sequenceRepo.ListByTenant(ctx, tenantID)
+}
+
+// UpdateSequence replaces the name, format, padding, mode and reset of an existing numbering
+// sequence, keeping its folder, values, creator and creation time. Numbers already assigned keep
+// the format they were assigned with.
+func (s *sequenceService) UpdateSequence(ctx context.Context, sequence *models.NumberingSequence) error {
+	ctxLogger := logger.WithContext(ctx)
+
+	if sequence == nil {
+		return errors.NewValidationError("numbering sequence cannot be nil")
+	}
+
+	existing, err := s.GetSequence(ctx, sequence.ID, sequence.TenantID)
+	if err != nil {
+		return err
+	}
+	sequence.FolderID = existing.FolderID
+	sequence.LastValue = existing.LastValue
+	sequence.LastPeriod = existing.LastPeriod
+	sequence.CreatedBy = existing.CreatedBy
+	sequence.CreatedAt = existing.CreatedAt
+
+	if err := sequence.Validate(); err != nil {
+		return errors.NewValidationError(err.Error())
+	}
+
+	if err := s.sequenceRepo.Update(ctx, sequence); err != nil {
+		ctxLogger.Error("Failed to update numbering sequence", "error", err, "sequence_id", sequence.ID, "tenant_id", sequence.TenantID)
+		return err
+	}
+
+	ctxLogger.Info("Numbering sequence updated", "sequence_id", sequence.ID, "tenant_id", sequence.TenantID, "mode", sequence.Mode)
+	return nil
+}
+
+// DeleteSequence deletes a numbering sequence; documents keep the numbers it assigned
+func (s *sequenceService) DeleteSequence(ctx context.Context, id string, tenantID string) error {
+	ctxLogger := logger.WithContext(ctx)
+
+	if err := s.validateInput(map[string]string{
+		"numbering sequence ID": id,
+		"tenant ID":             tenantID,
+	}); err != nil {
+		return err
+	}
+
+	if err := s.sequenceRepo.Delete(ctx, id, tenantID); err != nil {
+		ctxLogger.Error("Failed to delete numbering sequence", "error", err, "sequence_id", id, "tenant_id", tenantID)
+		return err
+	}
+
+	ctxLogger.Info("Numbering sequence deleted", "sequence_id", id, "tenant_id", tenantID)
+	return nil
+}
+
+// GetUploadSequence returns the folder's own sequence, else the tenant's default, else nil
+func (s *sequenceService) GetUploadSequence(ctx context.Context, tenantID string, folderID string) (*models.NumberingSequence, error) {
+	for _, id := range []string{folderID, ""} {
+		sequence, err := s.sequenceRepo.GetByFolder(ctx, id, tenantID)
+		if err == nil {
+			return sequence, nil
+		}
+		if !errors.IsResourceNotFoundError(err) {
+			return nil, errors.Wrap(err, "failed to load numbering sequence")
+		}
+	}
+	return nil, nil
+}
+
+// NextNumber advances a sequence and returns the number it assigns now
+func (s *sequenceService) NextNumber(ctx context.Context, sequence *models.NumberingSequence) (string, error) {
+	number, err := s.sequenceRepo.Advance(ctx, sequence.ID, sequence.TenantID, time.Now())
+	if err != nil {
+		logger.WithContext(ctx).Error("Failed to assign document number", "error", err, "sequence_id", sequence.ID, "tenant_id", sequence.TenantID)
+		return "", errors.Wrap(err, "failed to assign document number")
+	}
+	return number, nil
+}
+
+// validateInput validates that required input parameters are not empty
+func (s *sequenceService) validateInput(params map[string]string) error {
+	for name, value := range params {
+		if value == "" {
+			return errors.NewValidationError(fmt.Sprintf("%s cannot be empty", name))
+		}
+	}
+	return nil
+}
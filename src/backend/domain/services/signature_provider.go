@@ -0,0 +1,62 @@
+package services
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"../models"
+)
+
+// SignatureEnvelope is a document sent to an electronic signature provider together with the people
+// asked to sign it
+type SignatureEnvelope struct {
+	DocumentName string
+	ContentType  string
+	Content      io.Reader
+	Subject      string
+	Message      string
+	Signers      []models.Signer
+	CallbackURL  string // URL the provider posts the status updates of the envelope to
+}
+
+// SignatureCallback is a status update of an envelope that a provider posted to the platform
+type SignatureCallback struct {
+	// EnvelopeID is the envelope the update is about, empty for callbacks that carry no update such
+	// as verifications of the callback URL
+	EnvelopeID string
+
+	// Status is the models.SignatureStatus* constant the envelope moved to, empty while it is
+	// still out for signature
+	Status string
+
+	// Reason is why the envelope was declined or voided, if the provider reports it
+	Reason string
+
+	// Signers are the statuses of the signers the update reports
+	Signers []models.SignerStatusUpdate
+
+	// ResponseHeaders are headers the provider expects in the response to the callback
+	ResponseHeaders map[string]string
+}
+
+// SignatureProvider defines the interface of an electronic signature service, such as DocuSign or
+// Adobe Sign, that documents are sent to for signature
+type SignatureProvider interface {
+	// Name returns the models.SignatureProvider* constant requests select the provider by
+	Name() string
+
+	// SendEnvelope sends the document of the envelope to its signers
+	// Returns the ID of the envelope at the provider
+	SendEnvelope(ctx context.Context, envelope *SignatureEnvelope) (string, error)
+
+	// VoidEnvelope withdraws an envelope that is out for signature, notifying its signers
+	VoidEnvelope(ctx context.Context, envelopeID, reason string) error
+
+	// DownloadSignedDocument opens the signed document of a completed envelope
+	DownloadSignedDocument(ctx context.Context, envelopeID string) (io.ReadCloser, error)
+
+	// ParseCallback verifies that a callback was sent by the provider and parses its status update
+	// Returns an authentication error if the callback cannot be verified
+	ParseCallback(ctx context.Context, header http.Header, body []byte) (*SignatureCallback, error)
+}
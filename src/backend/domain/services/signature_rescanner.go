@@ -0,0 +1,161 @@
+// Package services provides domain-level services for the Document Management Platform
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"../models"
+	"../repositories"
+	"../../pkg/errors"
+	"../../pkg/logger"
+	"../../pkg/utils"
+)
+
+// signatureRescanLimit is the maximum number of versions of a tenant queued for rescanning per
+// signature update. The newest uploads are queued first.
+const signatureRescanLimit = 10000
+
+// SignatureRescanner queues recently uploaded documents for another scan when the signature
+// database of the scanning engine is updated, so that files uploaded shortly before signatures for
+// a new threat were released are checked against them
+type SignatureRescanner interface {
+	// RescanIfUpdated checks the version of the signature database and, when it changed since the
+	// previous check, forgets the cached clean verdicts and queues the available versions created
+	// within the rescan window for scanning. Returns the number of versions queued.
+	RescanIfUpdated(ctx context.Context) (int, error)
+}
+
+// signatureRescanner implements the SignatureRescanner interface
+type signatureRescanner struct {
+	signatureSource SignatureSource
+	tenantRepo      repositories.TenantRepository
+	documentRepo    repositories.DocumentRepository
+	scanQueue       ScanQueue
+	verdictCache    ScanVerdictCache // Clean verdicts forgotten on updates; nil when they are not cached
+	window          time.Duration
+
+	// lastVersion is the signature database version all recent uploads were queued against. It is
+	// empty until the first check, which only records the version.
+	lastVersion string
+}
+
+// NewSignatureRescanner creates a new SignatureRescanner instance rescanning the uploads of the
+// given window. verdictCache may be nil when clean verdicts are not cached.
+func NewSignatureRescanner(signatureSource SignatureSource, tenantRepo repositories.TenantRepository,
+	documentRepo repositories.DocumentRepository, scanQueue ScanQueue, verdictCache ScanVerdictCache, window time.Duration) (SignatureRescanner, error) {
+	if signatureSource == nil {
+		return nil, fmt.Errorf("signature source cannot be nil")
+	}
+	if tenantRepo == nil {
+		return nil, fmt.Errorf("tenant repository cannot be nil")
+	}
+	if documentRepo == nil {
+		return nil, fmt.Errorf("document repository cannot be nil")
+	}
+	if scanQueue == nil {
+		return nil, fmt.Errorf("scan queue cannot be nil")
+	}
+	if window <= 0 {
+		return nil, fmt.Errorf("rescan window must be positive")
+	}
+
+	return &signatureRescanner{
+		signatureSource: signatureSource,
+		tenantRepo:      tenantRepo,
+		documentRepo:    documentRepo,
+		scanQueue:       scanQueue,
+		verdictCache:    verdictCache,
+		window:          window,
+	}, nil
+}
+
+// RescanIfUpdated queues the recent uploads of every tenant when the signature database changed.
+// The first check only records the version, so restarting the worker does not rescan. The new
+// version is only recorded once all tenants were queued, so a failure is retried on the next check.
+func (r *signatureRescanner) RescanIfUpdated(ctx context.Context) (int, error) {
+	version, err := r.signatureSource.SignatureVersion(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get signature database version")
+	}
+
+	if r.lastVersion == "" {
+		logger.InfoContext(ctx, "Recorded signature database version", "version", version)
+		r.lastVersion = version
+		return 0, nil
+	}
+	if version == r.lastVersion {
+		return 0, nil
+	}
+
+	logger.InfoContext(ctx, "Signature database updated, queueing recent uploads for rescanning",
+		"previous_version", r.lastVersion, "version", version, "window", r.window)
+
+	// Content scanned clean against the old signatures must be scanned again when uploaded again
+	if r.verdictCache != nil {
+		if err := r.verdictCache.Invalidate(ctx); err != nil {
+			return 0, err
+		}
+	}
+
+	since := time.Now().Add(-r.window)
+	queued := 0
+	for page := 1; ; page++ {
+		result, err := r.tenantRepo.List(ctx, utils.NewPagination(page, utils.MaxPageSize))
+		if err != nil {
+			return queued, errors.Wrap(err, "failed to list tenants")
+		}
+
+		for _, tenant := range result.Items {
+			n, err := r.rescanTenant(ctx, tenant.ID, since)
+			queued += n
+			if err != nil {
+				return queued, err
+			}
+		}
+
+		if !result.Pagination.HasNext {
+			break
+		}
+	}
+
+	r.lastVersion = version
+	return queued, nil
+}
+
+// rescanTenant queues the tenant's available versions created at or after since for scanning
+func (r *signatureRescanner) rescanTenant(ctx context.Context, tenantID string, since time.Time) (int, error) {
+	versions, err := r.documentRepo.ListRecentVersions(ctx, tenantID, models.VersionStatusAvailable, since, signatureRescanLimit)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to list recent document versions")
+	}
+
+	queued := 0
+	for _, version := range versions {
+		if err := ctx.Err(); err != nil {
+			return queued, err
+		}
+
+		// Rescans must not delay the scans of new uploads, so they are queued with low priority
+		task := ScanTask{
+			DocumentID:  version.DocumentID,
+			VersionID:   version.ID,
+			TenantID:    tenantID,
+			StoragePath: version.StoragePath,
+			Priority:    ScanPriorityLow,
+		}
+		if err := r.scanQueue.Enqueue(ctx, task); err != nil {
+			return queued, errors.Wrap(err, "failed to queue document version for rescanning")
+		}
+		queued++
+	}
+
+	if queued > 0 {
+		logger.InfoContext(ctx, "Queued document versions for rescanning", "tenant_id", tenantID, "count", queued)
+	}
+	if len(versions) == signatureRescanLimit {
+		logger.WarnContext(ctx, "Rescan limit reached, older uploads are not rescanned", "tenant_id", tenantID, "limit", signatureRescanLimit)
+	}
+	return queued, nil
+}
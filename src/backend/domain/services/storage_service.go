@@ -20,24 +20,57 @@ type StorageService interface {
 	// Returns the permanent storage path or an error if the move fails.
 	StorePermanent(ctx context.Context, tenantID string, documentID string, versionID string, folderID string, tempPath string) (string, error)
 
+	// StoreDeduplicated moves a document from temporary to content-addressed storage after processing.
+	// Content with the same SHA-256 hash is stored once per tenant and shared by reference count.
+	// Returns the storage path of the shared content or an error if the move fails.
+	StoreDeduplicated(ctx context.Context, tenantID string, contentHash string, size int64, tempPath string) (string, error)
+
 	// MoveToQuarantine moves a document from temporary to quarantine storage when a virus is detected.
 	// It ensures tenant isolation by using tenantID in the storage path.
 	// Returns the quarantine storage path or an error if the move fails.
 	MoveToQuarantine(ctx context.Context, tenantID string, documentID string, tempPath string) (string, error)
 
+	// ReleaseFromQuarantine moves a quarantined document back to temporary storage, at the path it was
+	// uploaded to, so it can be scanned again or processed as clean.
+	// Returns the temporary storage path or an error if the move fails.
+	ReleaseFromQuarantine(ctx context.Context, tenantID string, documentID string, quarantinePath string) (string, error)
+
 	// GetDocument retrieves a document from storage.
 	// Returns a content stream or an error if retrieval fails.
 	GetDocument(ctx context.Context, storagePath string) (io.ReadCloser, error)
 
+	// GetDocumentRange retrieves length bytes of a document starting at offset.
+	// Returns a content stream of the range or an error if retrieval fails.
+	GetDocumentRange(ctx context.Context, storagePath string, offset int64, length int64) (io.ReadCloser, error)
+
+	// HashDocument reads a document back from storage and calculates the SHA-256 hash of its content,
+	// so the stored bytes can be checked against the hash calculated while they were uploaded.
+	// Returns the hex encoded hash or an error if retrieval fails.
+	HashDocument(ctx context.Context, storagePath string) (string, error)
+
 	// GetPresignedURL generates a presigned URL for direct document download.
 	// Returns a presigned URL or an error if URL generation fails.
 	GetPresignedURL(ctx context.Context, storagePath string, fileName string, expirationSeconds int) (string, error)
 
-	// DeleteDocument deletes a document from storage.
+	// DeleteDocument deletes a document from storage. For content-addressed storage it releases
+	// one reference, and the content is only deleted with its last reference.
 	// Returns an error if deletion fails.
 	DeleteDocument(ctx context.Context, storagePath string) error
 
+	// GetEncryptionKeyID returns the KMS key new content of the tenant is encrypted with.
+	// Returns an empty key ID for storage provider managed keys, or an error if the key cannot be resolved.
+	GetEncryptionKeyID(ctx context.Context, tenantID string) (string, error)
+
+	// ReencryptDocument encrypts a stored document again with the given KMS key, or with storage
+	// provider managed keys for an empty key ID. The document stays at its storage path.
+	// Returns an error if re-encryption fails, including when the provider does not support KMS keys.
+	ReencryptDocument(ctx context.Context, storagePath string, keyID string) error
+
 	// CreateBatchArchive creates a compressed archive of multiple documents.
-	// Returns an archive stream or an error if archive creation fails.
+	// Returns an archive stream that is built while it is read; errors fetching documents are returned by Read.
 	CreateBatchArchive(ctx context.Context, storagePaths []string, filenames []string) (io.ReadCloser, error)
+
+	// StoreArchive stores an archive of unknown length, such as a folder export streamed while it is built.
+	// Returns the storage path of the archive or an error if storage fails.
+	StoreArchive(ctx context.Context, tenantID string, archiveID string, content io.Reader) (string, error)
 }
\ No newline at end of file
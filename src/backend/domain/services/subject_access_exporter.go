@@ -0,0 +1,446 @@
+// Package services provides domain-level services for the Document Management Platform
+package services
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid" // v1.3.0+ - For identifying subject access export events
+
+	"../models"
+	"../repositories"
+	"../../pkg/errors"
+	"../../pkg/logger"
+	"../../pkg/utils"
+)
+
+// subjectAccessExportStaleAfter is how long a running subject access export may go without a
+// progress update before another worker assumes it was abandoned and runs it again
+const subjectAccessExportStaleAfter = 30 * time.Minute
+
+// subjectAccessDocumentsPath is the folder of the archive holding the content of the documents
+const subjectAccessDocumentsPath = "documents/"
+
+// SubjectAccessExporter runs background exports of the personal data of users into ZIP archives
+type SubjectAccessExporter interface {
+	// ExportNext claims the next subject access export and streams the personal data of its subject
+	// into an archive in storage. When the export finishes, a subject_access_export.completed or
+	// subject_access_export.failed event is enqueued with the export update. Returns false if there
+	// was no export to run.
+	ExportNext(ctx context.Context) (bool, error)
+}
+
+// subjectAccessExporter implements the SubjectAccessExporter interface
+type subjectAccessExporter struct {
+	exportRepo     repositories.SubjectAccessExportRepository
+	userRepo       repositories.UserRepository
+	documentRepo   repositories.DocumentRepository
+	auditLogRepo   repositories.AuditLogRepository
+	outboxRepo     repositories.OutboxRepository
+	txManager      repositories.TransactionManager
+	storageService StorageService
+}
+
+// subjectProfile is the profile of the data subject as written to profile.json. Credentials, such
+// as password hashes, are left out.
+type subjectProfile struct {
+	ID                string            `json:"id"`
+	Username          string            `json:"username"`
+	Email             string            `json:"email"`
+	Status            string            `json:"status"`
+	Roles             []string          `json:"roles"`
+	Groups            []string          `json:"groups"`
+	Settings          map[string]string `json:"settings"`
+	CreatedAt         time.Time         `json:"created_at"`
+	UpdatedAt         time.Time         `json:"updated_at"`
+	PasswordChangedAt time.Time         `json:"password_changed_at"`
+}
+
+// subjectDocument is a document owned by the data subject as written to documents.json. File is
+// the path of its content in the archive, empty when the document has no available content.
+type subjectDocument struct {
+	ID          string                   `json:"id"`
+	Name        string                   `json:"name"`
+	ContentType string                   `json:"content_type"`
+	Size        int64                    `json:"size"`
+	FolderID    string                   `json:"folder_id"`
+	Status      string                   `json:"status"`
+	Metadata    map[string]string        `json:"metadata"`
+	Tags        []string                 `json:"tags"`
+	Versions    []subjectDocumentVersion `json:"versions"`
+	File        string                   `json:"file,omitempty"`
+	CreatedAt   time.Time                `json:"created_at"`
+	UpdatedAt   time.Time                `json:"updated_at"`
+
+	storagePath string
+}
+
+// subjectDocumentVersion is a version of a document owned by the data subject
+type subjectDocumentVersion struct {
+	VersionNumber int       `json:"version_number"`
+	Size          int64     `json:"size"`
+	ContentHash   string    `json:"content_hash"`
+	Status        string    `json:"status"`
+	CreatedBy     string    `json:"created_by"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// NewSubjectAccessExporter creates a new SubjectAccessExporter instance
+func NewSubjectAccessExporter(exportRepo repositories.SubjectAccessExportRepository, userRepo repositories.UserRepository,
+	documentRepo repositories.DocumentRepository, auditLogRepo repositories.AuditLogRepository, outboxRepo repositories.OutboxRepository,
+	txManager repositories.TransactionManager, storageService StorageService) (SubjectAccessExporter, error) {
+	if exportRepo == nil {
+		return nil, fmt.Errorf("subject access export repository cannot be nil")
+	}
+	if userRepo == nil {
+		return nil, fmt.Errorf("user repository cannot be nil")
+	}
+	if documentRepo == nil {
+		return nil, fmt.Errorf("document repository cannot be nil")
+	}
+	if auditLogRepo == nil {
+		return nil, fmt.Errorf("audit log repository cannot be nil")
+	}
+	if outboxRepo == nil {
+		return nil, fmt.Errorf("outbox repository cannot be nil")
+	}
+	if txManager == nil {
+		return nil, fmt.Errorf("transaction manager cannot be nil")
+	}
+	if storageService == nil {
+		return nil, fmt.Errorf("storage service cannot be nil")
+	}
+
+	return &subjectAccessExporter{
+		exportRepo:     exportRepo,
+		userRepo:       userRepo,
+		documentRepo:   documentRepo,
+		auditLogRepo:   auditLogRepo,
+		outboxRepo:     outboxRepo,
+		txManager:      txManager,
+		storageService: storageService,
+	}, nil
+}
+
+// ExportNext claims and runs the next subject access export. The archive holds profile.json,
+// documents.json with the documents the subject owns, their metadata and versions, the content of
+// their latest versions under documents/, and audit_events.jsonl with the audit events of the
+// subject's actions and of changes to their account. The archive is streamed into storage while it
+// is written; if the worker stops mid-export the export stays running and is claimed again once it
+// is stale.
+func (s *subjectAccessExporter) ExportNext(ctx context.Context) (bool, error) {
+	ctxLogger := logger.WithContext(ctx)
+
+	export, err := s.exportRepo.ClaimNext(ctx, time.Now().Add(-subjectAccessExportStaleAfter))
+	if err != nil {
+		return false, errors.Wrap(err, "failed to claim subject access export")
+	}
+	if export == nil {
+		return false, nil
+	}
+
+	ctxLogger.Info("Exporting personal data", "export_id", export.ID, "subject_id", export.SubjectID, "tenant_id", export.TenantID)
+
+	subject, err := s.userRepo.GetByID(ctx, export.SubjectID, export.TenantID)
+	if err != nil {
+		if errors.IsResourceNotFoundError(err) {
+			return true, s.fail(ctx, export, "user not found")
+		}
+		return true, errors.Wrap(err, "failed to get data subject")
+	}
+
+	documents, err := s.collectDocuments(ctx, export)
+	if err != nil {
+		return true, errors.Wrap(err, "failed to list documents of data subject")
+	}
+
+	export.DocumentCount = len(documents)
+	export.UpdatedAt = time.Now()
+	if err := s.exportRepo.Update(ctx, export); err != nil {
+		return true, errors.Wrap(err, "failed to update subject access export")
+	}
+
+	archivePath, archiveSize, reason, err := s.writeArchive(ctx, export, subject, documents)
+	if err != nil {
+		if ctx.Err() != nil {
+			// Shutting down; the export is reclaimed by the next worker
+			return true, ctx.Err()
+		}
+		ctxLogger.Error("Failed to export personal data", "error", err, "export_id", export.ID)
+		return true, s.fail(ctx, export, reason)
+	}
+
+	export.Complete(archivePath, archiveSize, time.Now())
+
+	downloadURL, err := s.storageService.GetPresignedURL(ctx, archivePath, export.ArchiveFileName(), ExportDownloadURLExpirySeconds)
+	if err != nil {
+		return true, errors.Wrap(err, "failed to generate subject access download URL")
+	}
+	event, err := models.NewSubjectAccessExportCompletedEvent(export, downloadURL)
+	if err != nil {
+		return true, errors.Wrap(err, "failed to create subject access export event")
+	}
+	if err := s.finish(ctx, export, event); err != nil {
+		return true, err
+	}
+
+	ctxLogger.Info("Personal data exported", "export_id", export.ID, "document_count", export.DocumentCount,
+		"audit_event_count", export.AuditEventCount, "archive_size", archiveSize)
+	return true, nil
+}
+
+// collectDocuments lists the documents the subject owns, naming the content of the available ones
+// in the archive
+func (s *subjectAccessExporter) collectDocuments(ctx context.Context, export *models.SubjectAccessExport) ([]subjectDocument, error) {
+	var documents []subjectDocument
+	usedNames := make(map[string]bool)
+
+	err := forEachPage(func(pagination *utils.Pagination) (utils.PaginatedResult[models.Document], error) {
+		return s.documentRepo.ListByOwner(ctx, export.SubjectID, export.TenantID, pagination)
+	}, func(document *models.Document) error {
+		entry := subjectDocument{
+			ID:          document.ID,
+			Name:        document.Name,
+			ContentType: document.ContentType,
+			Size:        document.Size,
+			FolderID:    document.FolderID,
+			Status:      document.Status,
+			Metadata:    make(map[string]string, len(document.Metadata)),
+			Tags:        make([]string, 0, len(document.Tags)),
+			Versions:    make([]subjectDocumentVersion, 0, len(document.Versions)),
+			CreatedAt:   document.CreatedAt,
+			UpdatedAt:   document.UpdatedAt,
+		}
+		for _, metadata := range document.Metadata {
+			entry.Metadata[metadata.Key] = metadata.Value
+		}
+		for _, tag := range document.Tags {
+			entry.Tags = append(entry.Tags, tag.Name)
+		}
+		for _, version := range document.Versions {
+			entry.Versions = append(entry.Versions, subjectDocumentVersion{
+				VersionNumber: version.VersionNumber,
+				Size:          version.Size,
+				ContentHash:   version.ContentHash,
+				Status:        version.Status,
+				CreatedBy:     version.CreatedBy,
+				CreatedAt:     version.CreatedAt,
+			})
+		}
+		if version := document.GetLatestVersion(); document.IsAvailable() && version != nil {
+			entry.File = subjectAccessDocumentsPath + uniqueEntryName(document.Name, usedNames)
+			entry.storagePath = version.StoragePath
+		}
+
+		documents = append(documents, entry)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return documents, nil
+}
+
+// writeArchive streams the personal data into a ZIP archive in storage. On failure it returns a
+// reason that can be shown to the requester along with the error.
+func (s *subjectAccessExporter) writeArchive(ctx context.Context, export *models.SubjectAccessExport, subject *models.User, documents []subjectDocument) (string, int64, string, error) {
+	reader, writer := io.Pipe()
+	counter := &countingWriter{writer: writer}
+
+	type writeResult struct {
+		reason string
+		err    error
+	}
+	done := make(chan writeResult, 1)
+
+	go func() {
+		reason, err := s.writeEntries(ctx, export, subject, documents, counter)
+		writer.CloseWithError(err)
+		done <- writeResult{reason: reason, err: err}
+	}()
+
+	archivePath, storeErr := s.storageService.StoreArchive(ctx, export.TenantID, export.ID, reader)
+	// Unblock the writer if storage gave up before reading the whole archive
+	reader.CloseWithError(io.ErrClosedPipe)
+	result := <-done
+
+	if result.err != nil {
+		return "", 0, result.reason, result.err
+	}
+	if storeErr != nil {
+		return "", 0, "failed to store archive", storeErr
+	}
+
+	return archivePath, counter.written, "", nil
+}
+
+// writeEntries writes the profile, documents and audit events of the subject into a ZIP archive
+func (s *subjectAccessExporter) writeEntries(ctx context.Context, export *models.SubjectAccessExport, subject *models.User, documents []subjectDocument, w io.Writer) (string, error) {
+	zipWriter := zip.NewWriter(w)
+
+	profile := subjectProfile{
+		ID:                subject.ID,
+		Username:          subject.Username,
+		Email:             subject.Email,
+		Status:            subject.Status,
+		Roles:             subject.Roles,
+		Groups:            subject.Groups,
+		Settings:          subject.Settings,
+		CreatedAt:         subject.CreatedAt,
+		UpdatedAt:         subject.UpdatedAt,
+		PasswordChangedAt: subject.PasswordChangedAt,
+	}
+	if err := writeJSONEntry(zipWriter, "profile.json", profile); err != nil {
+		return "failed to export profile", err
+	}
+	if err := writeJSONEntry(zipWriter, "documents.json", documents); err != nil {
+		return "failed to export document list", err
+	}
+
+	copied := 0
+	for _, document := range documents {
+		if document.File == "" {
+			continue
+		}
+		if err := s.writeContent(ctx, zipWriter, document); err != nil {
+			return fmt.Sprintf("failed to export %s", document.Name), err
+		}
+
+		copied++
+		if copied%exportProgressInterval == 0 {
+			export.UpdatedAt = time.Now()
+			if err := s.exportRepo.Update(ctx, export); err != nil {
+				return "failed to update export progress", err
+			}
+		}
+	}
+
+	if err := s.writeAuditEvents(ctx, zipWriter, export); err != nil {
+		return "failed to export audit events", err
+	}
+
+	if err := zipWriter.Close(); err != nil {
+		return "failed to finish archive", err
+	}
+	return "", nil
+}
+
+// writeContent copies the content of a document from storage into the archive
+func (s *subjectAccessExporter) writeContent(ctx context.Context, zipWriter *zip.Writer, document subjectDocument) error {
+	content, err := s.storageService.GetDocument(ctx, document.storagePath)
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+
+	fileWriter, err := zipWriter.CreateHeader(&zip.FileHeader{
+		Name:     document.File,
+		Method:   zip.Deflate,
+		Modified: document.UpdatedAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(fileWriter, content)
+	return err
+}
+
+// writeAuditEvents writes the audit events of the subject's actions, followed by those of changes
+// others made to their account, as JSON lines. Events recorded after the export started are left
+// out, so that they do not shift the pages being read.
+func (s *subjectAccessExporter) writeAuditEvents(ctx context.Context, zipWriter *zip.Writer, export *models.SubjectAccessExport) error {
+	fileWriter, err := zipWriter.CreateHeader(&zip.FileHeader{
+		Name:     "audit_events.jsonl",
+		Method:   zip.Deflate,
+		Modified: time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(fileWriter)
+
+	until := time.Now()
+	if export.StartedAt != nil {
+		until = *export.StartedAt
+	}
+	filters := []models.AuditLogFilter{
+		{ActorID: export.SubjectID, To: until},
+		{ResourceType: models.AuditResourceUser, ResourceID: export.SubjectID, To: until},
+	}
+
+	export.AuditEventCount = 0
+	for i, filter := range filters {
+		err := forEachPage(func(pagination *utils.Pagination) (utils.PaginatedResult[models.AuditLog], error) {
+			return s.auditLogRepo.List(ctx, export.TenantID, filter, pagination)
+		}, func(entry *models.AuditLog) error {
+			// The subject's own changes to their account were written with their actions
+			if i > 0 && entry.ActorID == export.SubjectID {
+				return nil
+			}
+			export.AuditEventCount++
+			return encoder.Encode(entry)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeJSONEntry writes value into the archive as an indented JSON file
+func writeJSONEntry(zipWriter *zip.Writer, name string, value interface{}) error {
+	fileWriter, err := zipWriter.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(fileWriter)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
+
+// fail marks the export as failed and enqueues a subject_access_export.failed event
+func (s *subjectAccessExporter) fail(ctx context.Context, export *models.SubjectAccessExport, reason string) error {
+	export.Fail(reason, time.Now())
+
+	event, err := models.NewSubjectAccessExportFailedEvent(export)
+	if err != nil {
+		return errors.Wrap(err, "failed to create subject access export event")
+	}
+	return s.finish(ctx, export, event)
+}
+
+// finish saves the finished export and writes its event to the outbox in one transaction, so the
+// event is published if and only if the export's final state is stored
+func (s *subjectAccessExporter) finish(ctx context.Context, export *models.SubjectAccessExport, event *models.Event) error {
+	event.ID = uuid.New().String()
+
+	message, err := models.NewOutboxMessage(event)
+	if err != nil {
+		return errors.Wrap(err, "invalid outbox message")
+	}
+
+	err = s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.exportRepo.Update(txCtx, export); err != nil {
+			return err
+		}
+		_, err := s.outboxRepo.Create(txCtx, message)
+		return err
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to finish subject access export")
+	}
+
+	return nil
+}
@@ -0,0 +1,441 @@
+// Package services provides domain-level services for the Document Management Platform
+package services
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"../models"
+	"../repositories"
+	"../../pkg/errors"
+	"../../pkg/logger"
+	"../../pkg/utils"
+)
+
+// DefaultTenantDeletionGracePeriod is how long a tenant stays frozen before it is erased when no
+// grace period is configured
+const DefaultTenantDeletionGracePeriod = 30 * 24 * time.Hour
+
+// tenantDeletionRetryAfter is how long a failed deletion waits before it is retried, and how long a
+// running deletion may go without a heartbeat before another worker assumes it was abandoned
+const tenantDeletionRetryAfter = 30 * time.Minute
+
+// tenantDeletionHeartbeatInterval is how often a running deletion records that it is in progress
+const tenantDeletionHeartbeatInterval = time.Minute
+
+// TenantStorageEraser erases the objects a tenant keeps in storage
+type TenantStorageEraser interface {
+	// EraseTenant permanently deletes the objects of a tenant in every container, with their
+	// earlier versions and replicas. It returns the number of objects deleted under each storage
+	// prefix, including the prefixes erased before a failure.
+	EraseTenant(ctx context.Context, tenantID string) (map[string]int, error)
+
+	// CountTenant returns the number of objects of a tenant left under each storage prefix
+	CountTenant(ctx context.Context, tenantID string) (map[string]int, error)
+}
+
+// TenantOffboardingService deletes tenants that leave the platform. A tenant is frozen when its
+// deletion is scheduled, and erased once the grace period is over: its stored objects, search
+// index entries, dead-lettered scan tasks and database rows are deleted, the erasure is verified,
+// and a destruction report signed with the platform's key is kept as evidence for the customer.
+type TenantOffboardingService interface {
+	// ScheduleDeletion freezes a tenant by suspending it and schedules its erasure once the grace
+	// period is over
+	ScheduleDeletion(ctx context.Context, tenantID, requestedBy, reason string, gracePeriod time.Duration) (*models.TenantDeletion, error)
+
+	// CancelDeletion cancels a deletion that has not erased anything yet, and restores the status
+	// the tenant had before it was frozen
+	CancelDeletion(ctx context.Context, id string) (*models.TenantDeletion, error)
+
+	// GetDeletion retrieves a tenant deletion with its destruction report
+	GetDeletion(ctx context.Context, id string) (*models.TenantDeletion, error)
+
+	// ListDeletions lists tenant deletions, most recent first
+	ListDeletions(ctx context.Context, pagination *utils.Pagination) (utils.PaginatedResult[models.TenantDeletion], error)
+
+	// DeleteNext claims the next deletion whose grace period is over, or that failed and is due
+	// for a retry, and erases its tenant. Returns false if there was no deletion to run.
+	DeleteNext(ctx context.Context) (bool, error)
+
+	// VerifyReport checks the destruction report of a completed deletion against its signature
+	VerifyReport(deletion *models.TenantDeletion) error
+}
+
+// tenantOffboardingService implements the TenantOffboardingService interface
+type tenantOffboardingService struct {
+	deletionRepo  repositories.TenantDeletionRepository
+	tenantRepo    repositories.TenantRepository
+	storageEraser TenantStorageEraser
+	searchRemover SearchTenantRemover
+	deadLetters   ScanDeadLetterQueue
+	signingKey    ed25519.PrivateKey
+	signingKeyID  string
+}
+
+// NewTenantOffboardingService creates a new TenantOffboardingService instance. deadLetters is nil
+// when the scan queue keeps no dead letters. The signing key is required even to schedule
+// deletions, so that no tenant is frozen in an environment that cannot sign its destruction report.
+func NewTenantOffboardingService(deletionRepo repositories.TenantDeletionRepository, tenantRepo repositories.TenantRepository,
+	storageEraser TenantStorageEraser, searchRemover SearchTenantRemover, deadLetters ScanDeadLetterQueue, signingKey ed25519.PrivateKey) (TenantOffboardingService, error) {
+	if deletionRepo == nil {
+		return nil, fmt.Errorf("tenant deletion repository cannot be nil")
+	}
+	if tenantRepo == nil {
+		return nil, fmt.Errorf("tenant repository cannot be nil")
+	}
+	if storageEraser == nil {
+		return nil, fmt.Errorf("storage eraser cannot be nil")
+	}
+	if searchRemover == nil {
+		return nil, fmt.Errorf("search tenant remover cannot be nil")
+	}
+	if len(signingKey) != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("report signing key must be an Ed25519 private key")
+	}
+
+	return &tenantOffboardingService{
+		deletionRepo:  deletionRepo,
+		tenantRepo:    tenantRepo,
+		storageEraser: storageEraser,
+		searchRemover: searchRemover,
+		deadLetters:   deadLetters,
+		signingKey:    signingKey,
+		signingKeyID:  TenantReportSigningKeyID(signingKey.Public().(ed25519.PublicKey)),
+	}, nil
+}
+
+// TenantReportSigningKeyID returns the identifier of the key destruction reports are signed with:
+// the start of the SHA-256 hash of its public key
+func TenantReportSigningKeyID(publicKey ed25519.PublicKey) string {
+	hash := sha256.Sum256(publicKey)
+	return hex.EncodeToString(hash[:8])
+}
+
+// ScheduleDeletion records the deletion before freezing the tenant, so that a tenant is never
+// frozen without a deletion to cancel. Suspended tenants are refused at sign-in, token refresh and
+// API key authentication.
+func (s *tenantOffboardingService) ScheduleDeletion(ctx context.Context, tenantID, requestedBy, reason string, gracePeriod time.Duration) (*models.TenantDeletion, error) {
+	ctxLogger := logger.WithContext(ctx)
+
+	if gracePeriod < 0 {
+		return nil, errors.NewValidationError("grace period cannot be negative")
+	}
+	tenant, err := s.tenantRepo.GetByID(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	deletion := models.NewTenantDeletion(tenant, requestedBy, reason, gracePeriod)
+	if _, err := s.deletionRepo.Create(ctx, deletion); err != nil {
+		return nil, err
+	}
+
+	if !tenant.IsSuspended() {
+		if err := s.tenantRepo.UpdateStatus(ctx, tenant.ID, models.TenantStatusSuspended); err != nil {
+			if cancelErr := deletion.Cancel("failed to freeze tenant: "+err.Error(), time.Now()); cancelErr == nil {
+				if cancelErr := s.deletionRepo.Cancel(ctx, deletion); cancelErr != nil {
+					ctxLogger.Error("Failed to cancel tenant deletion", "error", cancelErr, "deletion_id", deletion.ID)
+				}
+			}
+			return nil, errors.Wrap(err, "failed to freeze tenant")
+		}
+	}
+
+	ctxLogger.Info("Tenant deletion scheduled", "deletion_id", deletion.ID, "tenant_id", tenant.ID,
+		"requested_by", requestedBy, "scheduled_for", deletion.ScheduledFor)
+	return deletion, nil
+}
+
+// CancelDeletion cancels the deletion before restoring the tenant, so that a worker cannot start
+// erasing a tenant that is in use again
+func (s *tenantOffboardingService) CancelDeletion(ctx context.Context, id string) (*models.TenantDeletion, error) {
+	deletion, err := s.deletionRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := deletion.Cancel("cancelled before erasure", time.Now()); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+	if err := s.deletionRepo.Cancel(ctx, deletion); err != nil {
+		return nil, err
+	}
+
+	if err := s.tenantRepo.UpdateStatus(ctx, deletion.TenantID, deletion.PreviousStatus); err != nil {
+		return deletion, errors.Wrap(err, "failed to restore tenant status")
+	}
+
+	logger.WithContext(ctx).Info("Tenant deletion cancelled", "deletion_id", deletion.ID, "tenant_id", deletion.TenantID,
+		"status", deletion.PreviousStatus)
+	return deletion, nil
+}
+
+// GetDeletion retrieves a tenant deletion by its ID
+func (s *tenantOffboardingService) GetDeletion(ctx context.Context, id string) (*models.TenantDeletion, error) {
+	return s.deletionRepo.GetByID(ctx, id)
+}
+
+// ListDeletions lists tenant deletions with pagination
+func (s *tenantOffboardingService) ListDeletions(ctx context.Context, pagination *utils.Pagination) (utils.PaginatedResult[models.TenantDeletion], error) {
+	return s.deletionRepo.List(ctx, pagination)
+}
+
+// DeleteNext claims and runs the next due deletion. A heartbeat keeps the deletion claimed while
+// large tenants are erased. Every step can be repeated, so a deletion that fails, or is abandoned
+// by a worker that stops, is run again from the start, adding to the counts of its report.
+func (s *tenantOffboardingService) DeleteNext(ctx context.Context) (bool, error) {
+	ctxLogger := logger.WithContext(ctx)
+
+	now := time.Now()
+	deletion, err := s.deletionRepo.ClaimNext(ctx, now, now.Add(-tenantDeletionRetryAfter))
+	if err != nil {
+		return false, errors.Wrap(err, "failed to claim tenant deletion")
+	}
+	if deletion == nil {
+		return false, nil
+	}
+
+	ctxLogger.Info("Erasing tenant", "deletion_id", deletion.ID, "tenant_id", deletion.TenantID)
+
+	heartbeatCtx, stopHeartbeat := context.WithCancel(ctx)
+	defer stopHeartbeat()
+	go s.heartbeat(heartbeatCtx, deletion.ID)
+
+	if err := s.erase(ctx, deletion); err != nil {
+		if ctx.Err() != nil {
+			// Shutting down; the deletion is reclaimed by the next worker
+			return true, ctx.Err()
+		}
+		ctxLogger.Error("Failed to erase tenant", "error", err, "deletion_id", deletion.ID, "tenant_id", deletion.TenantID)
+		deletion.Fail(err.Error(), time.Now())
+		if err := s.deletionRepo.Update(ctx, deletion); err != nil {
+			return true, errors.Wrap(err, "failed to update tenant deletion")
+		}
+		return true, err
+	}
+
+	ctxLogger.Info("Tenant erased", "deletion_id", deletion.ID, "tenant_id", deletion.TenantID, "signing_key_id", deletion.SigningKeyID)
+	return true, nil
+}
+
+// heartbeat touches a running deletion until ctx is cancelled
+func (s *tenantOffboardingService) heartbeat(ctx context.Context, id string) {
+	ticker := time.NewTicker(tenantDeletionHeartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.deletionRepo.Touch(ctx, id, time.Now()); err != nil && ctx.Err() == nil {
+				logger.WithContext(ctx).Error("Failed to touch tenant deletion", "error", err, "deletion_id", id)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// erase deletes the data of the tenant step by step, recording the progress of each step in the
+// report, then verifies nothing is left and signs the report. The database rows are deleted last,
+// so that objects written while the tenant was being erased are found by the verification.
+func (s *tenantOffboardingService) erase(ctx context.Context, deletion *models.TenantDeletion) error {
+	report, err := s.progress(deletion)
+	if err != nil {
+		return err
+	}
+
+	// Nothing is erased of a tenant brought back into use during the grace period
+	if deletion.Report == "" {
+		tenant, err := s.tenantRepo.GetByID(ctx, deletion.TenantID)
+		if err != nil && !errors.IsResourceNotFoundError(err) {
+			return errors.Wrap(err, "failed to get tenant")
+		}
+		if tenant != nil && !tenant.IsSuspended() {
+			return fmt.Errorf("tenant is %s rather than suspended; suspend it again or cancel the deletion", tenant.Status)
+		}
+	}
+
+	if err := s.searchRemover.RemoveTenant(ctx, deletion.TenantID); err != nil {
+		return errors.Wrap(err, "failed to remove tenant from search index")
+	}
+	report.SearchIndexRemoved = true
+	if err := s.saveProgress(ctx, deletion, report); err != nil {
+		return err
+	}
+
+	discarded, err := s.discardDeadLetters(ctx, deletion.TenantID)
+	report.DeadLetters += discarded
+	if err := s.recordProgress(deletion, report, err); err != nil {
+		return errors.Wrap(err, "failed to discard dead-lettered scan tasks")
+	}
+	if err := s.saveProgress(ctx, deletion, report); err != nil {
+		return err
+	}
+
+	erased, err := s.storageEraser.EraseTenant(ctx, deletion.TenantID)
+	addCounts(report.StorageObjects, erased)
+	if err := s.recordProgress(deletion, report, err); err != nil {
+		return errors.Wrap(err, "failed to erase tenant storage")
+	}
+	if err := s.saveProgress(ctx, deletion, report); err != nil {
+		return err
+	}
+
+	rows, err := s.tenantRepo.PurgeData(ctx, deletion.TenantID)
+	addCounts(report.DatabaseRows, rows)
+	if err := s.recordProgress(deletion, report, err); err != nil {
+		return errors.Wrap(err, "failed to purge tenant data")
+	}
+	if err := s.saveProgress(ctx, deletion, report); err != nil {
+		return err
+	}
+
+	report.Verification, err = s.verify(ctx, deletion.TenantID)
+	if err != nil {
+		return errors.Wrap(err, "failed to verify tenant erasure")
+	}
+	if !report.Verification.Verified {
+		objects, rows := sumCounts(report.Verification.StorageObjects), sumCounts(report.Verification.DatabaseRows)
+		err := fmt.Errorf("erasure left %d storage objects, %d database rows and %d dead-lettered scan tasks of the tenant",
+			objects, rows, report.Verification.DeadLetters)
+		return s.recordProgress(deletion, report, err)
+	}
+
+	now := time.Now()
+	report.CompletedAt = now
+	report.SigningKeyID = s.signingKeyID
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to encode destruction report")
+	}
+	signature := base64.StdEncoding.EncodeToString(ed25519.Sign(s.signingKey, data))
+
+	deletion.Complete(string(data), signature, s.signingKeyID, now)
+	if err := s.deletionRepo.Update(ctx, deletion); err != nil {
+		return errors.Wrap(err, "failed to update tenant deletion")
+	}
+	return nil
+}
+
+// progress returns the report of the earlier attempts of a deletion, or a new report
+func (s *tenantOffboardingService) progress(deletion *models.TenantDeletion) (*models.TenantDestructionReport, error) {
+	report := &models.TenantDestructionReport{}
+	if deletion.Report != "" {
+		if err := json.Unmarshal([]byte(deletion.Report), report); err != nil {
+			return nil, errors.Wrap(err, "failed to decode destruction report")
+		}
+	}
+
+	report.DeletionID = deletion.ID
+	report.TenantID = deletion.TenantID
+	report.TenantName = deletion.TenantName
+	report.RequestedBy = deletion.RequestedBy
+	report.Reason = deletion.Reason
+	report.RequestedAt = deletion.CreatedAt
+	if deletion.StartedAt != nil {
+		report.StartedAt = *deletion.StartedAt
+	}
+	if report.StorageObjects == nil {
+		report.StorageObjects = make(map[string]int)
+	}
+	if report.DatabaseRows == nil {
+		report.DatabaseRows = make(map[string]int64)
+	}
+	return report, nil
+}
+
+// recordProgress records the report in the deletion, so that the progress of a step that failed
+// part way is kept when the failure is persisted. It returns err.
+func (s *tenantOffboardingService) recordProgress(deletion *models.TenantDeletion, report *models.TenantDestructionReport, err error) error {
+	if data, encodeErr := json.Marshal(report); encodeErr == nil {
+		deletion.Report = string(data)
+	}
+	return err
+}
+
+// saveProgress records the report in the deletion and persists it
+func (s *tenantOffboardingService) saveProgress(ctx context.Context, deletion *models.TenantDeletion, report *models.TenantDestructionReport) error {
+	_ = s.recordProgress(deletion, report, nil)
+	deletion.UpdatedAt = time.Now()
+	if err := s.deletionRepo.Update(ctx, deletion); err != nil {
+		return errors.Wrap(err, "failed to update tenant deletion")
+	}
+	return nil
+}
+
+// discardDeadLetters discards the dead-lettered scan tasks of a tenant, when the scan queue keeps
+// dead letters. Unreadable dead letters cannot be attributed to a tenant and are left alone.
+func (s *tenantOffboardingService) discardDeadLetters(ctx context.Context, tenantID string) (int, error) {
+	if s.deadLetters == nil {
+		return 0, nil
+	}
+	return s.deadLetters.DiscardDeadLetters(ctx, func(deadLetter DeadLetteredScanTask) bool {
+		return deadLetter.Readable && deadLetter.Task.TenantID == tenantID
+	})
+}
+
+// verify looks for what is left of the tenant in storage, the database and the dead letter queue.
+// Dead letters cannot be counted without reading the whole queue, so a second pass discards those
+// added since the first, and reports them as left.
+func (s *tenantOffboardingService) verify(ctx context.Context, tenantID string) (models.TenantDestructionVerification, error) {
+	var verification models.TenantDestructionVerification
+
+	objects, err := s.storageEraser.CountTenant(ctx, tenantID)
+	if err != nil {
+		return verification, err
+	}
+	rows, err := s.tenantRepo.CountData(ctx, tenantID)
+	if err != nil {
+		return verification, err
+	}
+	deadLetters, err := s.discardDeadLetters(ctx, tenantID)
+	if err != nil {
+		return verification, err
+	}
+
+	verification.StorageObjects = objects
+	verification.DatabaseRows = rows
+	verification.DeadLetters = deadLetters
+	verification.Verified = sumCounts(objects) == 0 && sumCounts(rows) == 0 && deadLetters == 0
+	return verification, nil
+}
+
+// VerifyReport checks the signature of the report with the public half of the signing key
+func (s *tenantOffboardingService) VerifyReport(deletion *models.TenantDeletion) error {
+	if deletion.Status != models.TenantDeletionStatusCompleted {
+		return errors.NewValidationError("the tenant deletion has not completed")
+	}
+	if deletion.SigningKeyID != s.signingKeyID {
+		return fmt.Errorf("report is signed with key %s rather than the configured key %s", deletion.SigningKeyID, s.signingKeyID)
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(deletion.ReportSignature)
+	if err != nil {
+		return errors.Wrap(err, "failed to decode report signature")
+	}
+	if !ed25519.Verify(s.signingKey.Public().(ed25519.PublicKey), []byte(deletion.Report), signature) {
+		return fmt.Errorf("report signature is invalid")
+	}
+	return nil
+}
+
+// addCounts adds counts to the totals of the same keys
+func addCounts[T int | int64](totals map[string]T, counts map[string]T) {
+	for key, count := range counts {
+		totals[key] += count
+	}
+}
+
+// sumCounts returns the sum of counts
+func sumCounts[T int | int64](counts map[string]T) T {
+	var sum T
+	for _, count := range counts {
+		sum += count
+	}
+	return sum
+}
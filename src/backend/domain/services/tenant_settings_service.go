@@ -0,0 +1,88 @@
+// Package services provides domain-level services for the Document Management Platform
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"../models"
+	"../repositories"
+	"../../pkg/errors"
+)
+
+// tenantSettingsCacheTTL is how long a tenant's preferences are used before they are read again, so
+// that changes to its settings take effect within this time
+const tenantSettingsCacheTTL = time.Minute
+
+// maxCachedTenantSettings bounds the number of tenants whose preferences are kept in memory
+const maxCachedTenantSettings = 10000
+
+// TenantSettingsService gives typed access to the settings of tenants that are read on every
+// notification and public page, such as their branding, without reading the tenant each time
+type TenantSettingsService interface {
+	// Preferences returns the branding and defaults of the tenant. They are cached for up to a
+	// minute, so changes to the tenant's settings can take that long to show.
+	Preferences(ctx context.Context, tenantID string) (models.TenantPreferences, error)
+}
+
+// cachedTenantPreferences is a tenant's preferences and when they must be read again
+type cachedTenantPreferences struct {
+	preferences models.TenantPreferences
+	expiresAt   time.Time
+}
+
+// tenantSettingsService implements the TenantSettingsService interface
+type tenantSettingsService struct {
+	tenantRepo repositories.TenantRepository
+
+	mu          sync.Mutex
+	preferences map[string]cachedTenantPreferences
+}
+
+// NewTenantSettingsService creates a TenantSettingsService
+func NewTenantSettingsService(tenantRepo repositories.TenantRepository) (TenantSettingsService, error) {
+	if tenantRepo == nil {
+		return nil, fmt.Errorf("tenant repository cannot be nil")
+	}
+
+	return &tenantSettingsService{
+		tenantRepo:  tenantRepo,
+		preferences: make(map[string]cachedTenantPreferences),
+	}, nil
+}
+
+// Preferences returns the tenant's preferences, reading the tenant when its cached preferences expired
+func (s *tenantSettingsService) Preferences(ctx context.Context, tenantID string) (models.TenantPreferences, error) {
+	now := time.Now()
+
+	s.mu.Lock()
+	cached, ok := s.preferences[tenantID]
+	s.mu.Unlock()
+	if ok && now.Before(cached.expiresAt) {
+		return cached.preferences, nil
+	}
+
+	tenant, err := s.tenantRepo.GetByID(ctx, tenantID)
+	if err != nil {
+		return models.TenantPreferences{}, errors.Wrap(err, "failed to get tenant settings")
+	}
+	if tenant == nil {
+		return models.TenantPreferences{}, errors.NewResourceNotFoundError("tenant not found")
+	}
+	preferences := tenant.Preferences()
+
+	s.mu.Lock()
+	if len(s.preferences) >= maxCachedTenantSettings {
+		for id, entry := range s.preferences {
+			if !now.Before(entry.expiresAt) {
+				delete(s.preferences, id)
+			}
+		}
+	}
+	s.preferences[tenantID] = cachedTenantPreferences{preferences: preferences, expiresAt: now.Add(tenantSettingsCacheTTL)}
+	s.mu.Unlock()
+
+	return preferences, nil
+}
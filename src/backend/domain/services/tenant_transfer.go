@@ -0,0 +1,855 @@
+// Package services provides domain-level services for the Document Management Platform
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid" // v1.3.0+ - For the IDs of imported records
+
+	"../models"
+	"../repositories"
+	"../../pkg/errors"
+	"../../pkg/logger"
+	"../../pkg/utils"
+)
+
+// TenantBundleFormatVersion is the version of the bundle format written by ExportTenant. Imports
+// refuse bundles of other versions.
+const TenantBundleFormatVersion = 1
+
+// Files of a tenant bundle. The record files hold one JSON record per line and are listed in the
+// manifest in the order they are imported, so everything a record refers to is imported before it.
+const (
+	tenantBundleManifest    = "manifest.json"
+	tenantBundleTenant      = "tenant.jsonl"
+	tenantBundleRoles       = "roles.jsonl"
+	tenantBundleUsers       = "users.jsonl"
+	tenantBundleGroups      = "groups.jsonl"
+	tenantBundleMemberships = "group_memberships.jsonl"
+	tenantBundleFolders     = "folders.jsonl"
+	tenantBundleTags        = "tags.jsonl"
+	tenantBundleDocuments   = "documents.jsonl"
+	tenantBundlePermissions = "permissions.jsonl"
+
+	// tenantBundleContentDir holds the content of each exported document version
+	tenantBundleContentDir = "content/"
+)
+
+// tenantTransferProgressInterval is the number of documents transferred between progress logs
+const tenantTransferProgressInterval = 500
+
+// TenantBundleStore keeps the files of tenant bundles. A bundle is a directory of files named by
+// the bundle path returned from an export.
+type TenantBundleStore interface {
+	// PutBundleFile stores a file of a bundle, uploading content of unknown length as it is read.
+	PutBundleFile(ctx context.Context, bundle string, name string, content io.Reader) error
+
+	// GetBundleFile retrieves a file of a bundle.
+	GetBundleFile(ctx context.Context, bundle string, name string) (io.ReadCloser, error)
+}
+
+// TenantBundleFile describes a file of a tenant bundle
+type TenantBundleFile struct {
+	Name    string `json:"name"`
+	Records int    `json:"records,omitempty"`
+	Size    int64  `json:"size"`
+	SHA256  string `json:"sha256"`
+}
+
+// TenantBundleManifest describes a tenant bundle. It is written once all other files are, so a
+// bundle without a manifest is incomplete. It lists the record files; the content files are listed
+// with their checksums in the records of their documents.
+type TenantBundleManifest struct {
+	FormatVersion    int                `json:"format_version"`
+	Bundle           string             `json:"bundle"`
+	TenantID         string             `json:"tenant_id"`
+	TenantName       string             `json:"tenant_name"`
+	CreatedAt        time.Time          `json:"created_at"`
+	Files            []TenantBundleFile `json:"files"`
+	SkippedDocuments int                `json:"skipped_documents"`
+}
+
+// TenantImportResult reports what an import restored
+type TenantImportResult struct {
+	TenantID string         `json:"tenant_id"`
+	Imported map[string]int `json:"imported"`
+	Skipped  map[string]int `json:"skipped"`
+}
+
+// TenantTransferService copies whole tenants between environments, for migrations and disaster
+// recovery drills.
+type TenantTransferService interface {
+	// ExportTenant writes the tenant with its users, roles, groups, folders, tags, documents with
+	// their metadata and content, and permissions to a new bundle.
+	// Documents without an available version, such as those still being scanned, are skipped.
+	// Returns the manifest of the bundle.
+	ExportTenant(ctx context.Context, tenantID string) (*TenantBundleManifest, error)
+
+	// ImportTenant restores a bundle as a new tenant named tenantName, or named as the exported
+	// tenant if tenantName is empty. Every record gets a new ID, so a bundle can be imported next
+	// to the tenant it was exported from. The tenant stays suspended until the import completes.
+	// Returns a ValidationError if the bundle is incomplete or corrupt, or the name is taken.
+	ImportTenant(ctx context.Context, bundle string, tenantName string) (*TenantImportResult, error)
+}
+
+// tenantTransferService implements the TenantTransferService interface
+type tenantTransferService struct {
+	tenantRepo     repositories.TenantRepository
+	userRepo       repositories.UserRepository
+	roleRepo       repositories.RoleRepository
+	groupRepo      repositories.GroupRepository
+	folderRepo     repositories.FolderRepository
+	tagRepo        repositories.TagRepository
+	documentRepo   repositories.DocumentRepository
+	permissionRepo repositories.PermissionRepository
+	storageService StorageService
+	bundleStore    TenantBundleStore
+}
+
+// bundledDocument is a record of the documents file: a document with the bundle files holding the
+// content of its versions, by version ID
+type bundledDocument struct {
+	Document models.Document
+	Content  map[string]TenantBundleFile
+}
+
+// tenantImport holds the state of an import: the new IDs of the records imported so far, by the
+// ID they were exported with
+type tenantImport struct {
+	bundle   string
+	tenantID string
+	keyID    string
+	result   *TenantImportResult
+
+	users     map[string]string
+	roles     map[string]string
+	groups    map[string]string
+	folders   map[string]string
+	tags      map[string]string
+	documents map[string]string
+}
+
+// NewTenantTransferService creates a new TenantTransferService instance
+func NewTenantTransferService(tenantRepo repositories.TenantRepository, userRepo repositories.UserRepository, roleRepo repositories.RoleRepository,
+	groupRepo repositories.GroupRepository, folderRepo repositories.FolderRepository, tagRepo repositories.TagRepository,
+	documentRepo repositories.DocumentRepository, permissionRepo repositories.PermissionRepository, storageService StorageService,
+	bundleStore TenantBundleStore) (TenantTransferService, error) {
+	if tenantRepo == nil {
+		return nil, fmt.Errorf("tenant repository cannot be nil")
+	}
+	if userRepo == nil {
+		return nil, fmt.Errorf("user repository cannot be nil")
+	}
+	if roleRepo == nil {
+		return nil, fmt.Errorf("role repository cannot be nil")
+	}
+	if groupRepo == nil {
+		return nil, fmt.Errorf("group repository cannot be nil")
+	}
+	if folderRepo == nil {
+		return nil, fmt.Errorf("folder repository cannot be nil")
+	}
+	if tagRepo == nil {
+		return nil, fmt.Errorf("tag repository cannot be nil")
+	}
+	if documentRepo == nil {
+		return nil, fmt.Errorf("document repository cannot be nil")
+	}
+	if permissionRepo == nil {
+		return nil, fmt.Errorf("permission repository cannot be nil")
+	}
+	if storageService == nil {
+		return nil, fmt.Errorf("storage service cannot be nil")
+	}
+	if bundleStore == nil {
+		return nil, fmt.Errorf("bundle store cannot be nil")
+	}
+
+	return &tenantTransferService{
+		tenantRepo:     tenantRepo,
+		userRepo:       userRepo,
+		roleRepo:       roleRepo,
+		groupRepo:      groupRepo,
+		folderRepo:     folderRepo,
+		tagRepo:        tagRepo,
+		documentRepo:   documentRepo,
+		permissionRepo: permissionRepo,
+		storageService: storageService,
+		bundleStore:    bundleStore,
+	}, nil
+}
+
+// ExportTenant writes the tenant to a new bundle. Every file is streamed into the bundle store
+// while it is written, so tenants of any size export without having to fit in memory. Records are
+// read while the tenant is in use, so for an exact copy the tenant should be suspended first.
+func (s *tenantTransferService) ExportTenant(ctx context.Context, tenantID string) (*TenantBundleManifest, error) {
+	ctxLogger := logger.WithContext(ctx)
+
+	tenant, err := s.tenantRepo.GetByID(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	manifest := &TenantBundleManifest{
+		FormatVersion: TenantBundleFormatVersion,
+		Bundle:        fmt.Sprintf("%s/%s", tenant.ID, now.Format("20060102T150405Z")),
+		TenantID:      tenant.ID,
+		TenantName:    tenant.Name,
+		CreatedAt:     now,
+	}
+	ctxLogger.Info("Exporting tenant", "tenant_id", tenant.ID, "bundle", manifest.Bundle)
+
+	exports := []struct {
+		name  string
+		write func(emit func(record interface{}) error) error
+	}{
+		{tenantBundleTenant, func(emit func(record interface{}) error) error {
+			return emit(tenant)
+		}},
+		{tenantBundleRoles, func(emit func(record interface{}) error) error {
+			return forEachPage(func(pagination *utils.Pagination) (utils.PaginatedResult[models.Role], error) {
+				return s.roleRepo.List(ctx, tenant.ID, pagination)
+			}, func(role *models.Role) error { return emit(role) })
+		}},
+		{tenantBundleUsers, func(emit func(record interface{}) error) error {
+			return forEachPage(func(pagination *utils.Pagination) (utils.PaginatedResult[models.User], error) {
+				return s.userRepo.ListByTenant(ctx, tenant.ID, pagination)
+			}, func(user *models.User) error { return emit(user) })
+		}},
+		{tenantBundleGroups, func(emit func(record interface{}) error) error {
+			return forEachPage(func(pagination *utils.Pagination) (utils.PaginatedResult[models.Group], error) {
+				return s.groupRepo.List(ctx, tenant.ID, pagination)
+			}, func(group *models.Group) error { return emit(group) })
+		}},
+		{tenantBundleMemberships, func(emit func(record interface{}) error) error {
+			return s.exportMemberships(ctx, tenant.ID, emit)
+		}},
+		{tenantBundleFolders, func(emit func(record interface{}) error) error {
+			return s.exportFolders(ctx, tenant.ID, emit)
+		}},
+		{tenantBundleTags, func(emit func(record interface{}) error) error {
+			return forEachPage(func(pagination *utils.Pagination) (utils.PaginatedResult[models.Tag], error) {
+				return s.tagRepo.ListByTenant(ctx, tenant.ID, pagination)
+			}, func(tag *models.Tag) error { return emit(tag) })
+		}},
+		{tenantBundleDocuments, func(emit func(record interface{}) error) error {
+			skipped, err := s.exportDocuments(ctx, manifest.Bundle, tenant.ID, emit)
+			manifest.SkippedDocuments = skipped
+			return err
+		}},
+		{tenantBundlePermissions, func(emit func(record interface{}) error) error {
+			return forEachPage(func(pagination *utils.Pagination) (utils.PaginatedResult[models.Permission], error) {
+				return s.permissionRepo.GetByTenant(ctx, tenant.ID, pagination)
+			}, func(permission *models.Permission) error { return emit(permission) })
+		}},
+	}
+
+	for _, export := range exports {
+		file, err := s.writeRecords(ctx, manifest.Bundle, export.name, export.write)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to export %s", export.name))
+		}
+		manifest.Files = append(manifest.Files, file)
+	}
+
+	encoded, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode bundle manifest")
+	}
+	if err := s.bundleStore.PutBundleFile(ctx, manifest.Bundle, tenantBundleManifest, bytes.NewReader(encoded)); err != nil {
+		return nil, errors.Wrap(err, "failed to store bundle manifest")
+	}
+
+	ctxLogger.Info("Tenant exported", "tenant_id", tenant.ID, "bundle", manifest.Bundle, "skipped_documents", manifest.SkippedDocuments)
+	return manifest, nil
+}
+
+// exportMemberships emits the members of every group of the tenant
+func (s *tenantTransferService) exportMemberships(ctx context.Context, tenantID string, emit func(record interface{}) error) error {
+	return forEachPage(func(pagination *utils.Pagination) (utils.PaginatedResult[models.Group], error) {
+		return s.groupRepo.List(ctx, tenantID, pagination)
+	}, func(group *models.Group) error {
+		return forEachPage(func(pagination *utils.Pagination) (utils.PaginatedResult[models.GroupMembership], error) {
+			return s.groupRepo.ListMembers(ctx, group.ID, tenantID, pagination)
+		}, func(membership *models.GroupMembership) error { return emit(membership) })
+	})
+}
+
+// exportFolders emits the folders of the tenant breadth first from the root folders, so every
+// folder comes after its parent
+func (s *tenantTransferService) exportFolders(ctx context.Context, tenantID string, emit func(record interface{}) error) error {
+	var queue []string
+	err := forEachPage(func(pagination *utils.Pagination) (utils.PaginatedResult[models.Folder], error) {
+		return s.folderRepo.GetRootFolders(ctx, tenantID, pagination)
+	}, func(folder *models.Folder) error {
+		queue = append(queue, folder.ID)
+		return emit(folder)
+	})
+	if err != nil {
+		return err
+	}
+
+	for len(queue) > 0 {
+		parentID := queue[0]
+		queue = queue[1:]
+
+		err := forEachPage(func(pagination *utils.Pagination) (utils.PaginatedResult[models.Folder], error) {
+			return s.folderRepo.GetChildren(ctx, parentID, tenantID, pagination)
+		}, func(folder *models.Folder) error {
+			queue = append(queue, folder.ID)
+			return emit(folder)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// exportDocuments emits the documents of the tenant, copying the content of their available
+// versions into the bundle as it goes. Returns the number of documents skipped for having no
+// available version.
+func (s *tenantTransferService) exportDocuments(ctx context.Context, bundle string, tenantID string, emit func(record interface{}) error) (int, error) {
+	exported, skipped := 0, 0
+	err := forEachPage(func(pagination *utils.Pagination) (utils.PaginatedResult[models.Document], error) {
+		return s.documentRepo.ListByTenant(ctx, tenantID, pagination)
+	}, func(document *models.Document) error {
+		record := bundledDocument{Document: *document, Content: make(map[string]TenantBundleFile)}
+		record.Document.Versions = nil
+		for _, version := range document.Versions {
+			if version.Status != models.VersionStatusAvailable {
+				continue
+			}
+			file, err := s.copyContent(ctx, bundle, &version)
+			if err != nil {
+				return errors.Wrap(err, fmt.Sprintf("failed to export content of document %s", document.ID))
+			}
+			record.Document.Versions = append(record.Document.Versions, version)
+			record.Content[version.ID] = file
+		}
+		if len(record.Document.Versions) == 0 {
+			skipped++
+			return nil
+		}
+
+		exported++
+		if exported%tenantTransferProgressInterval == 0 {
+			logger.WithContext(ctx).Info("Exporting tenant documents", "tenant_id", tenantID, "documents", exported)
+		}
+		return emit(record)
+	})
+	return skipped, err
+}
+
+// copyContent copies the content of a document version into the bundle
+func (s *tenantTransferService) copyContent(ctx context.Context, bundle string, version *models.DocumentVersion) (TenantBundleFile, error) {
+	file := TenantBundleFile{Name: tenantBundleContentDir + version.ID}
+
+	content, err := s.storageService.GetDocument(ctx, version.StoragePath)
+	if err != nil {
+		return file, err
+	}
+	defer content.Close()
+
+	hasher := sha256.New()
+	counter := &countingWriter{writer: hasher}
+	if err := s.bundleStore.PutBundleFile(ctx, bundle, file.Name, io.TeeReader(content, counter)); err != nil {
+		return file, err
+	}
+
+	file.Size = counter.written
+	file.SHA256 = hex.EncodeToString(hasher.Sum(nil))
+	return file, nil
+}
+
+// writeRecords streams the records emitted by write into a bundle file as JSON lines, and returns
+// the file with its size and checksum
+func (s *tenantTransferService) writeRecords(ctx context.Context, bundle string, name string, write func(emit func(record interface{}) error) error) (TenantBundleFile, error) {
+	file := TenantBundleFile{Name: name}
+	reader, writer := io.Pipe()
+	hasher := sha256.New()
+	counter := &countingWriter{writer: io.MultiWriter(writer, hasher)}
+	encoder := json.NewEncoder(counter)
+
+	done := make(chan error, 1)
+	go func() {
+		err := write(func(record interface{}) error {
+			file.Records++
+			return encoder.Encode(record)
+		})
+		writer.CloseWithError(err)
+		done <- err
+	}()
+
+	storeErr := s.bundleStore.PutBundleFile(ctx, bundle, name, reader)
+	// Unblock the writer if the store gave up before reading the whole file
+	reader.CloseWithError(io.ErrClosedPipe)
+	if err := <-done; err != nil {
+		return file, err
+	}
+	if storeErr != nil {
+		return file, storeErr
+	}
+
+	file.Size = counter.written
+	file.SHA256 = hex.EncodeToString(hasher.Sum(nil))
+	return file, nil
+}
+
+// ImportTenant restores a bundle as a new tenant. The checksums of all record files are verified
+// before anything is written, and document content is verified as it is copied. The tenant is
+// created suspended and only given the status it was exported with once everything is restored,
+// so a failed import never serves a partial tenant; it can be deleted and the import run again.
+func (s *tenantTransferService) ImportTenant(ctx context.Context, bundle string, tenantName string) (*TenantImportResult, error) {
+	ctxLogger := logger.WithContext(ctx)
+
+	manifest, err := s.readManifest(ctx, bundle)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range manifest.Files {
+		if err := s.verifyFile(ctx, bundle, file); err != nil {
+			return nil, err
+		}
+	}
+
+	var source *models.Tenant
+	err = readRecords(ctx, s.bundleStore, bundle, tenantBundleTenant, func(tenant *models.Tenant) error {
+		source = tenant
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if source == nil {
+		return nil, errors.NewValidationError(fmt.Sprintf("bundle %s holds no tenant", bundle))
+	}
+
+	if tenantName == "" {
+		tenantName = source.Name
+	}
+	exists, err := s.tenantRepo.ExistsByName(ctx, tenantName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to check tenant name")
+	}
+	if exists {
+		return nil, errors.NewValidationError(fmt.Sprintf("a tenant named %s already exists", tenantName))
+	}
+
+	tenant := models.NewTenant(tenantName)
+	tenant.Status = models.TenantStatusSuspended
+	tenant.Settings = source.Settings
+	if _, err := s.tenantRepo.Create(ctx, tenant); err != nil {
+		return nil, errors.Wrap(err, "failed to create tenant")
+	}
+	ctxLogger.Info("Importing tenant", "bundle", bundle, "source_tenant_id", source.ID, "tenant_id", tenant.ID)
+
+	keyID, err := s.storageService.GetEncryptionKeyID(ctx, tenant.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to resolve tenant encryption key")
+	}
+
+	state := &tenantImport{
+		bundle:   bundle,
+		tenantID: tenant.ID,
+		keyID:    keyID,
+		result: &TenantImportResult{
+			TenantID: tenant.ID,
+			Imported: make(map[string]int),
+			Skipped:  make(map[string]int),
+		},
+		users:     make(map[string]string),
+		roles:     make(map[string]string),
+		groups:    make(map[string]string),
+		folders:   make(map[string]string),
+		tags:      make(map[string]string),
+		documents: make(map[string]string),
+	}
+
+	steps := []struct {
+		name string
+		run  func() error
+	}{
+		{tenantBundleRoles, func() error { return s.importRoles(ctx, state) }},
+		{tenantBundleUsers, func() error { return s.importUsers(ctx, state) }},
+		{tenantBundleGroups, func() error { return s.importGroups(ctx, state) }},
+		{tenantBundleMemberships, func() error { return s.importMemberships(ctx, state) }},
+		{tenantBundleFolders, func() error { return s.importFolders(ctx, state) }},
+		{tenantBundleTags, func() error { return s.importTags(ctx, state) }},
+		{tenantBundleDocuments, func() error { return s.importDocuments(ctx, state) }},
+		{tenantBundlePermissions, func() error { return s.importPermissions(ctx, state) }},
+	}
+	for _, step := range steps {
+		if err := step.run(); err != nil {
+			return state.result, errors.Wrap(err, fmt.Sprintf("failed to import %s into tenant %s", step.name, tenant.ID))
+		}
+	}
+
+	if err := s.tenantRepo.UpdateStatus(ctx, tenant.ID, source.Status); err != nil {
+		return state.result, errors.Wrap(err, "failed to activate imported tenant")
+	}
+
+	ctxLogger.Info("Tenant imported", "bundle", bundle, "tenant_id", tenant.ID, "imported", state.result.Imported, "skipped", state.result.Skipped)
+	return state.result, nil
+}
+
+// readManifest reads the manifest of a bundle and checks that it lists every record file
+func (s *tenantTransferService) readManifest(ctx context.Context, bundle string) (*TenantBundleManifest, error) {
+	content, err := s.bundleStore.GetBundleFile(ctx, bundle, tenantBundleManifest)
+	if err != nil {
+		if errors.IsResourceNotFoundError(err) {
+			return nil, errors.NewValidationError(fmt.Sprintf("bundle %s has no manifest; it does not exist or its export did not complete", bundle))
+		}
+		return nil, errors.Wrap(err, "failed to read bundle manifest")
+	}
+	defer content.Close()
+
+	var manifest TenantBundleManifest
+	if err := json.NewDecoder(content).Decode(&manifest); err != nil {
+		return nil, errors.NewValidationError(fmt.Sprintf("invalid manifest in bundle %s: %v", bundle, err))
+	}
+	if manifest.FormatVersion != TenantBundleFormatVersion {
+		return nil, errors.NewValidationError(fmt.Sprintf("bundle %s has format version %d, expected %d", bundle, manifest.FormatVersion, TenantBundleFormatVersion))
+	}
+
+	listed := make(map[string]bool, len(manifest.Files))
+	for _, file := range manifest.Files {
+		listed[file.Name] = true
+	}
+	for _, name := range []string{tenantBundleTenant, tenantBundleRoles, tenantBundleUsers, tenantBundleGroups, tenantBundleMemberships,
+		tenantBundleFolders, tenantBundleTags, tenantBundleDocuments, tenantBundlePermissions} {
+		if !listed[name] {
+			return nil, errors.NewValidationError(fmt.Sprintf("manifest of bundle %s does not list %s", bundle, name))
+		}
+	}
+	return &manifest, nil
+}
+
+// verifyFile checks a bundle file against the size and checksum it was exported with
+func (s *tenantTransferService) verifyFile(ctx context.Context, bundle string, file TenantBundleFile) error {
+	content, err := s.bundleStore.GetBundleFile(ctx, bundle, file.Name)
+	if err != nil {
+		if errors.IsResourceNotFoundError(err) {
+			return errors.NewValidationError(fmt.Sprintf("bundle %s is missing %s", bundle, file.Name))
+		}
+		return errors.Wrap(err, fmt.Sprintf("failed to read bundle file %s", file.Name))
+	}
+	defer content.Close()
+
+	hasher := sha256.New()
+	counter := &countingWriter{writer: hasher}
+	if _, err := io.Copy(counter, content); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to read bundle file %s", file.Name))
+	}
+	if counter.written != file.Size || hex.EncodeToString(hasher.Sum(nil)) != file.SHA256 {
+		return errors.NewValidationError(fmt.Sprintf("%s of bundle %s does not match its checksum", file.Name, bundle))
+	}
+	return nil
+}
+
+// importRoles creates the roles of the bundle
+func (s *tenantTransferService) importRoles(ctx context.Context, state *tenantImport) error {
+	return readRecords(ctx, s.bundleStore, state.bundle, tenantBundleRoles, func(role *models.Role) error {
+		sourceID := role.ID
+		role.ID = ""
+		role.TenantID = state.tenantID
+		if _, err := s.roleRepo.Create(ctx, role); err != nil {
+			return err
+		}
+		state.roles[sourceID] = role.ID
+		state.result.Imported[tenantBundleRoles]++
+		return nil
+	})
+}
+
+// importUsers creates the users of the bundle with their password hashes, so they sign in as
+// before. Group memberships are imported separately.
+func (s *tenantTransferService) importUsers(ctx context.Context, state *tenantImport) error {
+	return readRecords(ctx, s.bundleStore, state.bundle, tenantBundleUsers, func(user *models.User) error {
+		sourceID := user.ID
+		user.ID = ""
+		user.TenantID = state.tenantID
+		user.Groups = []string{}
+		if _, err := s.userRepo.Create(ctx, user); err != nil {
+			return err
+		}
+		state.users[sourceID] = user.ID
+		state.result.Imported[tenantBundleUsers]++
+		return nil
+	})
+}
+
+// importGroups creates the groups of the bundle
+func (s *tenantTransferService) importGroups(ctx context.Context, state *tenantImport) error {
+	return readRecords(ctx, s.bundleStore, state.bundle, tenantBundleGroups, func(group *models.Group) error {
+		sourceID := group.ID
+		group.ID = ""
+		group.TenantID = state.tenantID
+		group.CreatedBy = remapOrKeep(state.users, group.CreatedBy)
+		if _, err := s.groupRepo.Create(ctx, group); err != nil {
+			return err
+		}
+		state.groups[sourceID] = group.ID
+		state.result.Imported[tenantBundleGroups]++
+		return nil
+	})
+}
+
+// importMemberships adds the imported users to the imported groups
+func (s *tenantTransferService) importMemberships(ctx context.Context, state *tenantImport) error {
+	return readRecords(ctx, s.bundleStore, state.bundle, tenantBundleMemberships, func(membership *models.GroupMembership) error {
+		groupID, groupOK := state.groups[membership.GroupID]
+		userID, userOK := state.users[membership.UserID]
+		if !groupOK || !userOK {
+			state.result.Skipped[tenantBundleMemberships]++
+			return nil
+		}
+		membership.GroupID = groupID
+		membership.UserID = userID
+		membership.TenantID = state.tenantID
+		membership.AddedBy = remapOrKeep(state.users, membership.AddedBy)
+		if err := s.groupRepo.AddMember(ctx, membership); err != nil {
+			return err
+		}
+		state.result.Imported[tenantBundleMemberships]++
+		return nil
+	})
+}
+
+// importFolders creates the folders of the bundle. They were exported parents first, so the
+// parent of each folder is already imported.
+func (s *tenantTransferService) importFolders(ctx context.Context, state *tenantImport) error {
+	return readRecords(ctx, s.bundleStore, state.bundle, tenantBundleFolders, func(folder *models.Folder) error {
+		imported := models.NewFolder(folder.Name, "", state.tenantID, "")
+		if folder.ParentID != "" {
+			parentID, ok := state.folders[folder.ParentID]
+			if !ok {
+				return errors.NewValidationError(fmt.Sprintf("parent of folder %s is not in the bundle", folder.ID))
+			}
+			imported.ParentID = parentID
+		}
+		ownerID, ok := state.users[folder.OwnerID]
+		if !ok {
+			return errors.NewValidationError(fmt.Sprintf("owner of folder %s is not in the bundle", folder.ID))
+		}
+		imported.OwnerID = ownerID
+		imported.CreatedAt = folder.CreatedAt
+		imported.UpdatedAt = folder.UpdatedAt
+
+		if _, err := s.folderRepo.Create(ctx, imported); err != nil {
+			return err
+		}
+		state.folders[folder.ID] = imported.ID
+		state.result.Imported[tenantBundleFolders]++
+		return nil
+	})
+}
+
+// importTags creates the tags of the bundle
+func (s *tenantTransferService) importTags(ctx context.Context, state *tenantImport) error {
+	return readRecords(ctx, s.bundleStore, state.bundle, tenantBundleTags, func(tag *models.Tag) error {
+		sourceID := tag.ID
+		tag.ID = ""
+		tag.TenantID = state.tenantID
+		if _, err := s.tagRepo.Create(ctx, tag); err != nil {
+			return err
+		}
+		state.tags[sourceID] = tag.ID
+		state.result.Imported[tenantBundleTags]++
+		return nil
+	})
+}
+
+// importDocuments creates the documents of the bundle with their metadata and tags, copying the
+// content of each version into the tenant's storage. Locks and links to other documents are not
+// carried over.
+func (s *tenantTransferService) importDocuments(ctx context.Context, state *tenantImport) error {
+	return readRecords(ctx, s.bundleStore, state.bundle, tenantBundleDocuments, func(record *bundledDocument) error {
+		document := record.Document
+		sourceID := document.ID
+
+		folderID, folderOK := state.folders[document.FolderID]
+		ownerID, ownerOK := state.users[document.OwnerID]
+		if !folderOK || !ownerOK {
+			state.result.Skipped[tenantBundleDocuments]++
+			return nil
+		}
+
+		document.ID = uuid.New().String()
+		document.TenantID = state.tenantID
+		document.FolderID = folderID
+		document.OwnerID = ownerID
+		document.LockedBy = ""
+		document.LockedAt = nil
+		document.Links = nil
+		document.LinkedFrom = nil
+
+		for i := range document.Metadata {
+			document.Metadata[i].ID = ""
+			document.Metadata[i].DocumentID = document.ID
+		}
+
+		tags := document.Tags[:0]
+		for _, tag := range document.Tags {
+			if tagID, ok := state.tags[tag.ID]; ok {
+				tag.ID = tagID
+				tag.TenantID = state.tenantID
+				tags = append(tags, tag)
+			}
+		}
+		document.Tags = tags
+
+		for i := range document.Versions {
+			version := &document.Versions[i]
+			file, ok := record.Content[version.ID]
+			if !ok {
+				return errors.NewValidationError(fmt.Sprintf("content of version %s of document %s is not in the bundle", version.ID, sourceID))
+			}
+			version.ID = uuid.New().String()
+			version.DocumentID = document.ID
+			version.CreatedBy = remapOrKeep(state.users, version.CreatedBy)
+			version.EncryptionKeyID = state.keyID
+
+			storagePath, err := s.restoreContent(ctx, state, &document, version, file)
+			if err != nil {
+				return errors.Wrap(err, fmt.Sprintf("failed to import content of document %s", sourceID))
+			}
+			version.StoragePath = storagePath
+		}
+
+		if _, err := s.documentRepo.Create(ctx, &document); err != nil {
+			return err
+		}
+		state.documents[sourceID] = document.ID
+		state.result.Imported[tenantBundleDocuments]++
+		if state.result.Imported[tenantBundleDocuments]%tenantTransferProgressInterval == 0 {
+			logger.WithContext(ctx).Info("Importing tenant documents", "tenant_id", state.tenantID, "documents", state.result.Imported[tenantBundleDocuments])
+		}
+		return nil
+	})
+}
+
+// restoreContent copies the content of a version from the bundle into the tenant's storage,
+// verifying it against its checksum on the way, and returns its storage path
+func (s *tenantTransferService) restoreContent(ctx context.Context, state *tenantImport, document *models.Document, version *models.DocumentVersion, file TenantBundleFile) (string, error) {
+	content, err := s.bundleStore.GetBundleFile(ctx, state.bundle, file.Name)
+	if err != nil {
+		return "", err
+	}
+	defer content.Close()
+
+	hasher := sha256.New()
+	tempPath, err := s.storageService.StoreTemporary(ctx, state.tenantID, version.ID, io.TeeReader(content, hasher), file.Size, document.ContentType)
+	if err != nil {
+		return "", err
+	}
+	if hex.EncodeToString(hasher.Sum(nil)) != file.SHA256 {
+		return "", errors.NewValidationError(fmt.Sprintf("%s of bundle %s does not match its checksum", file.Name, state.bundle))
+	}
+
+	if utils.IsValidHash(version.ContentHash, utils.HashAlgorithmSHA256) {
+		return s.storageService.StoreDeduplicated(ctx, state.tenantID, version.ContentHash, version.Size, tempPath)
+	}
+	return s.storageService.StorePermanent(ctx, state.tenantID, document.ID, version.ID, document.FolderID, tempPath)
+}
+
+// importPermissions creates the permissions of the bundle. Permissions whose resource or grantee
+// was not imported are skipped.
+func (s *tenantTransferService) importPermissions(ctx context.Context, state *tenantImport) error {
+	return readRecords(ctx, s.bundleStore, state.bundle, tenantBundlePermissions, func(permission *models.Permission) error {
+		resources := state.documents
+		if permission.ResourceType == models.ResourceTypeFolder {
+			resources = state.folders
+		}
+		resourceID, ok := resources[permission.ResourceID]
+		if !ok {
+			state.result.Skipped[tenantBundlePermissions]++
+			return nil
+		}
+
+		grantees := []struct {
+			id  *string
+			ids map[string]string
+		}{
+			{&permission.RoleID, state.roles},
+			{&permission.UserID, state.users},
+			{&permission.GroupID, state.groups},
+		}
+		for _, grantee := range grantees {
+			if *grantee.id == "" {
+				continue
+			}
+			if *grantee.id, ok = grantee.ids[*grantee.id]; !ok {
+				state.result.Skipped[tenantBundlePermissions]++
+				return nil
+			}
+		}
+
+		permission.ID = ""
+		permission.TenantID = state.tenantID
+		permission.ResourceID = resourceID
+		permission.CreatedBy = remapOrKeep(state.users, permission.CreatedBy)
+		if _, err := s.permissionRepo.Create(ctx, permission); err != nil {
+			return err
+		}
+		state.result.Imported[tenantBundlePermissions]++
+		return nil
+	})
+}
+
+// readRecords calls fn with each record of a bundle file in turn
+func readRecords[T any](ctx context.Context, store TenantBundleStore, bundle string, name string, fn func(record *T) error) error {
+	content, err := store.GetBundleFile(ctx, bundle, name)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to read bundle file %s", name))
+	}
+	defer content.Close()
+
+	decoder := json.NewDecoder(content)
+	for {
+		var record T
+		if err := decoder.Decode(&record); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return errors.NewValidationError(fmt.Sprintf("invalid record in %s of bundle %s: %v", name, bundle, err))
+		}
+		if err := fn(&record); err != nil {
+			return err
+		}
+	}
+}
+
+// forEachPage calls fn with each item of a paginated listing in turn
+func forEachPage[T any](list func(pagination *utils.Pagination) (utils.PaginatedResult[T], error), fn func(item *T) error) error {
+	for page := 1; ; page++ {
+		result, err := list(utils.NewPagination(page, utils.MaxPageSize))
+		if err != nil {
+			return err
+		}
+		for i := range result.Items {
+			if err := fn(&result.Items[i]); err != nil {
+				return err
+			}
+		}
+		if !result.Pagination.HasNext {
+			return nil
+		}
+	}
+}
+
+// remapOrKeep returns the imported ID of a record referenced for information only, such as the
+// user who created another record, or the exported ID if that record was not imported
+func remapOrKeep(ids map[string]string, id string) string {
+	if imported, ok := ids[id]; ok {
+		return imported
+	}
+	return id
+}
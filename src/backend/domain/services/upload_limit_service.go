@@ -0,0 +1,171 @@
+// Package services provides domain-level services for the Document Management Platform
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"../models"
+	"../repositories"
+	"../../pkg/errors"
+	"../../pkg/logger"
+)
+
+// UploadLimitService defines the contract for enforcing per-user and per-role upload limits,
+// which keep single users from using up their tenant's quota
+type UploadLimitService interface {
+	// CheckUpload checks that a file of the given size is within the uploading user's maximum file
+	// size and fits the volume the user has left today. Violations are validation errors that
+	// state the limit.
+	CheckUpload(ctx context.Context, tenantID, userID string, size int64) error
+
+	// GetEffectiveLimit returns the limit that applies to a user: their own limit, the most
+	// generous limit of their roles, or the default limit
+	GetEffectiveLimit(ctx context.Context, tenantID, userID string) (*models.UploadLimit, error)
+
+	// ListLimits lists the upload limits configured for a tenant's users and roles
+	ListLimits(ctx context.Context, tenantID string) ([]*models.UploadLimit, error)
+
+	// SetLimit sets the upload limits of a user or role. A zero limit means unlimited.
+	SetLimit(ctx context.Context, tenantID, scope, scopeID string, maxFileSizeBytes, maxDailyUploadBytes int64) (*models.UploadLimit, error)
+
+	// DeleteLimit removes the upload limits of a user or role
+	DeleteLimit(ctx context.Context, tenantID, scope, scopeID string) error
+}
+
+// uploadLimitService implements the UploadLimitService interface
+type uploadLimitService struct {
+	uploadLimitRepo            repositories.UploadLimitRepository
+	userRepo                   repositories.UserRepository
+	documentRepo               repositories.DocumentRepository
+	defaultMaxFileSizeBytes    int64
+	defaultMaxDailyUploadBytes int64
+}
+
+// NewUploadLimitService creates a new UploadLimitService instance. The default limits apply to
+// users without a limit of their own or of one of their roles; zero means unlimited.
+func NewUploadLimitService(
+	uploadLimitRepo repositories.UploadLimitRepository,
+	userRepo repositories.UserRepository,
+	documentRepo repositories.DocumentRepository,
+	defaultMaxFileSizeBytes int64,
+	defaultMaxDailyUploadBytes int64,
+) (UploadLimitService, error) {
+	if uploadLimitRepo == nil {
+		return nil, fmt.Errorf("upload limit repository cannot be nil")
+	}
+	if userRepo == nil {
+		return nil, fmt.Errorf("user repository cannot be nil")
+	}
+	if documentRepo == nil {
+		return nil, fmt.Errorf("document repository cannot be nil")
+	}
+	if defaultMaxFileSizeBytes < 0 || defaultMaxDailyUploadBytes < 0 {
+		return nil, fmt.Errorf("default upload limits cannot be negative")
+	}
+
+	return &uploadLimitService{
+		uploadLimitRepo:            uploadLimitRepo,
+		userRepo:                   userRepo,
+		documentRepo:               documentRepo,
+		defaultMaxFileSizeBytes:    defaultMaxFileSizeBytes,
+		defaultMaxDailyUploadBytes: defaultMaxDailyUploadBytes,
+	}, nil
+}
+
+// CheckUpload checks a file of the given size against the uploading user's limits
+func (s *uploadLimitService) CheckUpload(ctx context.Context, tenantID, userID string, size int64) error {
+	limit, err := s.GetEffectiveLimit(ctx, tenantID, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := limit.CheckFileSize(size); err != nil {
+		return errors.NewValidationError(fmt.Sprintf("file size of %d bytes exceeds your maximum file size of %d bytes", size, limit.MaxFileSizeBytes))
+	}
+
+	if limit.MaxDailyUploadBytes == 0 {
+		return nil
+	}
+
+	uploadedToday, err := s.documentRepo.GetUploadVolume(ctx, tenantID, userID, models.UploadDayStart(time.Now()))
+	if err != nil {
+		return errors.Wrap(err, "failed to get upload volume")
+	}
+	if err := limit.CheckDailyUpload(uploadedToday, size); err != nil {
+		remaining := limit.MaxDailyUploadBytes - uploadedToday
+		if remaining < 0 {
+			remaining = 0
+		}
+		return errors.NewValidationError(fmt.Sprintf("file size of %d bytes exceeds your remaining daily upload volume of %d bytes (limit %d bytes per day)", size, remaining, limit.MaxDailyUploadBytes))
+	}
+
+	return nil
+}
+
+// GetEffectiveLimit returns the limit that applies to a user
+func (s *uploadLimitService) GetEffectiveLimit(ctx context.Context, tenantID, userID string) (*models.UploadLimit, error) {
+	if tenantID == "" {
+		return nil, errors.NewValidationError("tenant ID cannot be empty")
+	}
+	if userID == "" {
+		return nil, errors.NewValidationError("user ID cannot be empty")
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID, tenantID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get user")
+	}
+
+	limits, err := s.uploadLimitRepo.ListForUser(ctx, tenantID, userID, user.Roles)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list upload limits")
+	}
+
+	if limit := models.EffectiveUploadLimit(limits, userID); limit != nil {
+		return limit, nil
+	}
+	return models.NewUploadLimit(tenantID, models.UploadLimitScopeUser, userID, s.defaultMaxFileSizeBytes, s.defaultMaxDailyUploadBytes), nil
+}
+
+// ListLimits lists the upload limits configured for a tenant
+func (s *uploadLimitService) ListLimits(ctx context.Context, tenantID string) ([]*models.UploadLimit, error) {
+	if tenantID == "" {
+		return nil, errors.NewValidationError("tenant ID cannot be empty")
+	}
+
+	return s.uploadLimitRepo.ListByTenant(ctx, tenantID)
+}
+
+// SetLimit sets the upload limits of a user or role
+func (s *uploadLimitService) SetLimit(ctx context.Context, tenantID, scope, scopeID string, maxFileSizeBytes, maxDailyUploadBytes int64) (*models.UploadLimit, error) {
+	limit := models.NewUploadLimit(tenantID, scope, scopeID, maxFileSizeBytes, maxDailyUploadBytes)
+	if err := limit.Validate(); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	// Limits of users outside the tenant would never apply
+	if scope == models.UploadLimitScopeUser {
+		if _, err := s.userRepo.GetByID(ctx, scopeID, tenantID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.uploadLimitRepo.Save(ctx, limit); err != nil {
+		return nil, errors.Wrap(err, "failed to save upload limit")
+	}
+
+	logger.WithContext(ctx).Info("Upload limit set", "tenant_id", tenantID, "scope", scope, "scope_id", scopeID,
+		"max_file_size_bytes", maxFileSizeBytes, "max_daily_upload_bytes", maxDailyUploadBytes)
+	return limit, nil
+}
+
+// DeleteLimit removes the upload limits of a user or role
+func (s *uploadLimitService) DeleteLimit(ctx context.Context, tenantID, scope, scopeID string) error {
+	if scope != models.UploadLimitScopeUser && scope != models.UploadLimitScopeRole {
+		return errors.NewValidationError(models.ErrUploadLimitScopeInvalid.Error())
+	}
+
+	return s.uploadLimitRepo.Delete(ctx, tenantID, scope, scopeID)
+}
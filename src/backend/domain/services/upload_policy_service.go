@@ -0,0 +1,166 @@
+// Package services provides domain-level services for the Document Management Platform
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"../models"
+	"../repositories"
+	"../../pkg/errors"
+)
+
+// sniffLength is the number of leading bytes the content type of uploads is sniffed from
+const sniffLength = 512
+
+// executableSignatures maps the leading bytes of executables, which http.DetectContentType does not
+// recognize, to their content type
+var executableSignatures = []struct {
+	prefix      []byte
+	contentType string
+}{
+	{[]byte("MZ"), "application/x-msdownload"},
+	{[]byte("\x7fELF"), "application/x-executable"},
+	{[]byte("\xfe\xed\xfa\xce"), "application/x-mach-binary"},
+	{[]byte("\xfe\xed\xfa\xcf"), "application/x-mach-binary"},
+	{[]byte("\xce\xfa\xed\xfe"), "application/x-mach-binary"},
+	{[]byte("\xcf\xfa\xed\xfe"), "application/x-mach-binary"},
+}
+
+// UploadPolicyService defines the contract for checking uploads against the upload policy of their
+// tenant before their content is stored, so files of types, extensions and sizes the tenant does
+// not accept never reach storage
+type UploadPolicyService interface {
+	// CheckUpload checks a file's name and declared size against the tenant's policy, and sniffs its
+	// content type from its first bytes instead of trusting the declared one. It returns the content
+	// type the document is stored with and a reader of the whole content, which fails when the
+	// content is larger than its declared size. Violations are validation errors.
+	CheckUpload(ctx context.Context, tenantID, name, contentType string, size int64, content io.Reader) (string, io.Reader, error)
+}
+
+// uploadPolicyService implements the UploadPolicyService interface
+type uploadPolicyService struct {
+	tenantRepo repositories.TenantRepository
+}
+
+// NewUploadPolicyService creates a new UploadPolicyService instance
+func NewUploadPolicyService(tenantRepo repositories.TenantRepository) (UploadPolicyService, error) {
+	if tenantRepo == nil {
+		return nil, fmt.Errorf("tenant repository cannot be nil")
+	}
+
+	return &uploadPolicyService{tenantRepo: tenantRepo}, nil
+}
+
+// CheckUpload checks the size and extension first, then sniffs the content and checks its type
+func (s *uploadPolicyService) CheckUpload(ctx context.Context, tenantID, name, contentType string, size int64, content io.Reader) (string, io.Reader, error) {
+	tenant, err := s.tenantRepo.GetByID(ctx, tenantID)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "failed to get tenant")
+	}
+	if tenant == nil {
+		return "", nil, errors.NewResourceNotFoundError("tenant not found")
+	}
+	policy := tenant.UploadPolicy()
+
+	if !policy.AllowsSize(size) {
+		return "", nil, errors.NewValidationError(fmt.Sprintf("file size of %d bytes exceeds the maximum file size of %d bytes of your organization", size, policy.MaxFileSizeBytes))
+	}
+	if !policy.AllowsExtension(name) {
+		if extension := models.FileExtension(name); extension != "" {
+			return "", nil, errors.NewValidationError(fmt.Sprintf("files with the extension .%s cannot be uploaded", extension))
+		}
+		return "", nil, errors.NewValidationError("files without an extension cannot be uploaded")
+	}
+
+	head := make([]byte, sniffLength)
+	n, err := io.ReadFull(content, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", nil, errors.Wrap(err, "failed to read document content")
+	}
+	head = head[:n]
+
+	sniffedType := sniffContentType(head)
+	resolvedType := resolveContentType(contentType, sniffedType)
+	if !policy.AllowsMIMEType(resolvedType) {
+		return "", nil, errors.NewValidationError(fmt.Sprintf("files of type %s cannot be uploaded", models.NormalizeMIMEType(resolvedType)))
+	}
+
+	return resolvedType, &declaredSizeReader{reader: io.MultiReader(bytes.NewReader(head), content), remaining: size}, nil
+}
+
+// sniffContentType determines the content type of content from its first bytes
+func sniffContentType(head []byte) string {
+	for _, signature := range executableSignatures {
+		if bytes.HasPrefix(head, signature.prefix) {
+			return signature.contentType
+		}
+	}
+	return http.DetectContentType(head)
+}
+
+// resolveContentType returns the content type a document is stored with. Sniffing only tells
+// generic types apart, such as a ZIP archive for Office documents or plain text for CSV files, so
+// the declared type is kept when it refines the sniffed one. A declared type contradicting the
+// content is replaced by the sniffed type.
+func resolveContentType(declaredType, sniffedType string) string {
+	declared := models.NormalizeMIMEType(declaredType)
+	sniffed := models.NormalizeMIMEType(sniffedType)
+	if declared == sniffed {
+		return declaredType
+	}
+
+	switch sniffed {
+	case "application/octet-stream":
+		// Binary content of an unknown type cannot be text
+		if !strings.HasPrefix(declared, "text/") {
+			return declaredType
+		}
+	case "application/zip":
+		// Office documents, OpenDocument files, Java archives and EPUB books are ZIP archives
+		if strings.HasPrefix(declared, "application/") {
+			return declaredType
+		}
+	case "text/xml":
+		if declared == "application/xml" || strings.HasSuffix(declared, "+xml") {
+			return declaredType
+		}
+	case "text/plain":
+		if strings.HasPrefix(declared, "text/") || declared == "application/json" || declared == "application/xml" ||
+			declared == "application/x-yaml" || strings.HasSuffix(declared, "+json") || strings.HasSuffix(declared, "+xml") {
+			return declaredType
+		}
+	}
+	return sniffedType
+}
+
+// declaredSizeReader reads content, failing when it is larger than its declared size
+type declaredSizeReader struct {
+	reader    io.Reader
+	remaining int64
+}
+
+// Read reads the content up to its declared size, and then checks that nothing is left
+func (r *declaredSizeReader) Read(p []byte) (int, error) {
+	if r.remaining <= 0 {
+		var extra [1]byte
+		if n, _ := io.ReadFull(r.reader, extra[:]); n > 0 {
+			return 0, errors.NewValidationError("document content is larger than its declared size")
+		}
+		return 0, io.EOF
+	}
+
+	if int64(len(p)) > r.remaining {
+		p = p[:r.remaining]
+	}
+	n, err := r.reader.Read(p)
+	r.remaining -= int64(n)
+	if err == io.EOF && r.remaining <= 0 {
+		err = nil
+	}
+	return n, err
+}
@@ -4,6 +4,7 @@ package services
 import (
 	"context" // v1.6.0
 	"io"      // v1.0.0
+	"time"    // standard library
 )
 
 // Scan result constants
@@ -13,6 +14,23 @@ const (
 	ScanResultError    = "error"    // Error during scanning
 )
 
+// Scan priority constants. Each priority has its own queue, so bulk imports do not delay the scans
+// of interactive uploads users are waiting for.
+const (
+	ScanPriorityHigh   = "high"   // Interactive uploads a user is waiting for
+	ScanPriorityNormal = "normal" // Uploads without a priority hint
+	ScanPriorityLow    = "low"    // Bulk imports, migrations and rescans
+)
+
+// IsValidScanPriority checks whether priority is one of the scan priority constants
+func IsValidScanPriority(priority string) bool {
+	switch priority {
+	case ScanPriorityHigh, ScanPriorityNormal, ScanPriorityLow:
+		return true
+	}
+	return false
+}
+
 // ScanTask represents a document scanning task in the queue.
 type ScanTask struct {
 	DocumentID  string // Unique identifier of the document
@@ -20,6 +38,59 @@ type ScanTask struct {
 	TenantID    string // Tenant identifier
 	StoragePath string // Path to the document in storage
 	RetryCount  int    // Number of retry attempts
+	
+	// Priority of the task, one of the scan priority constants. Tasks without a priority are normal.
+	Priority string
+	
+	// ContentHash is the hash of the uploaded content. Content recently scanned clean for the tenant
+	// is marked clean without scanning it again; tasks without a hash are always scanned.
+	ContentHash string
+	
+	// OverriddenBy is the security administrator who released the document from quarantine
+	// overriding the scan verdict. Such documents are processed as clean without scanning them again.
+	OverriddenBy string
+	
+	// ReceiptHandle identifies the delivery of a dequeued task, so the queue can complete it or keep
+	// it hidden from other workers. It is set by Dequeue and is not part of the queued message.
+	ReceiptHandle string `json:"-"`
+	
+	// TraceContext carries the trace of the request that queued the task, as W3C trace context
+	// fields, and its request ID, so its scan is traced and logged as part of the upload. It is set
+	// by Dequeue from the message attributes the queue carries it in and is not part of the queued
+	// message.
+	TraceContext map[string]string `json:"-"`
+}
+
+// DeadLetteredScanTask is a scan task in the dead letter queue, with the reason it was dead-lettered.
+type DeadLetteredScanTask struct {
+	MessageID      string    // Identifier of the dead letter message, used to select it for replay
+	Task           ScanTask  // The task as it was dead-lettered
+	Reason         string    // Why the task failed
+	DeadLetteredAt time.Time // When the task was moved to the dead letter queue
+	Readable       bool      // False when the message is not a scan task and cannot be replayed
+}
+
+// ScanDeadLetterQueue gives operators access to the scan tasks that exhausted their retries, so they
+// can be inspected and re-driven once the cause of the failure is fixed.
+type ScanDeadLetterQueue interface {
+	// ListDeadLetters returns up to limit dead-lettered tasks without removing them from the queue.
+	ListDeadLetters(ctx context.Context, limit int) ([]DeadLetteredScanTask, error)
+	
+	// ReplayDeadLetters moves the dead-lettered tasks selected by selected back to the queue of their
+	// priority with their retry count reset. Returns the number of tasks replayed.
+	ReplayDeadLetters(ctx context.Context, selected func(DeadLetteredScanTask) bool) (int, error)
+
+	// DiscardDeadLetters deletes the dead-lettered tasks selected by selected without replaying
+	// them, such as the tasks of a tenant being erased. Returns the number of tasks discarded.
+	DiscardDeadLetters(ctx context.Context, selected func(DeadLetteredScanTask) bool) (int, error)
+}
+
+// ScanQueueDepth is implemented by scan queues that can report how many tasks wait to be scanned, so
+// that a backlog of scans can be monitored and alerted on.
+type ScanQueueDepth interface {
+	// Depth returns the number of tasks waiting in the queue of each scan priority, not counting the
+	// tasks being scanned. Queues with an approximate count, such as SQS, return the approximation.
+	Depth(ctx context.Context) (map[string]int, error)
 }
 
 // ScannerClient is an interface for virus scanning implementations.
@@ -29,13 +100,38 @@ type ScannerClient interface {
 	ScanStream(ctx context.Context, content io.Reader) (string, string, error)
 }
 
+// ScanningEngine is a virus scanning engine, such as ClamAV or a commercial scanner behind ICAP,
+// that documents can be scanned with.
+type ScanningEngine interface {
+	ScannerClient
+	
+	// Name returns the name tenants select the engine by.
+	Name() string
+}
+
+// ScanningEngineSelector selects the engines the documents of each tenant are scanned with.
+type ScanningEngineSelector interface {
+	// EngineForTenant returns the scanner for a tenant's documents: the engine the tenant selected,
+	// or one scanning with all of them in parallel when it selected several. An empty tenant ID
+	// returns the default engines.
+	EngineForTenant(ctx context.Context, tenantID string) (ScannerClient, error)
+}
+
+// SignatureSource reports the version of the signature database a scanning engine detects viruses
+// with, such as the daily ClamAV database.
+type SignatureSource interface {
+	// SignatureVersion returns the version of the signature database currently loaded by the engine.
+	SignatureVersion(ctx context.Context) (string, error)
+}
+
 // ScanQueue is an interface for managing the document scanning queue.
 type ScanQueue interface {
 	// Enqueue adds a document to the scanning queue.
 	Enqueue(ctx context.Context, task ScanTask) error
 	
-	// Dequeue retrieves the next document to scan from the queue.
-	// Returns the next scan task or nil if queue is empty.
+	// Dequeue retrieves the next document to scan from the queue, waiting for one with long polling.
+	// Returns the next scan task or nil if queue is empty. The task stays in the queue, hidden from
+	// other workers, until it is completed, retried or dead-lettered.
 	Dequeue(ctx context.Context) (*ScanTask, error)
 	
 	// Complete marks a scan task as completed and removes it from the queue.
@@ -46,17 +142,27 @@ type ScanQueue interface {
 	
 	// DeadLetter moves a scan task to the dead letter queue after maximum retries.
 	DeadLetter(ctx context.Context, task ScanTask, reason string) error
+	
+	// ExtendVisibility keeps a dequeued task hidden from other workers for timeout from now, so that
+	// scans of large files are not picked up a second time while they are still running.
+	ExtendVisibility(ctx context.Context, task ScanTask, timeout time.Duration) error
 }
 
 // VirusScanningService is an interface for virus scanning service operations.
 type VirusScanningService interface {
-	// QueueForScanning queues a document for virus scanning.
-	QueueForScanning(ctx context.Context, documentID, versionID, tenantID, storagePath string) error
+	// QueueForScanning queues a document for virus scanning with a scan priority constant.
+	// An empty priority queues the document with normal priority. The scan is skipped when content
+	// with the same contentHash was recently scanned clean for the tenant; an empty hash always scans.
+	QueueForScanning(ctx context.Context, documentID, versionID, tenantID, storagePath, contentHash, priority string) error
 	
 	// ProcessScanQueue processes the virus scanning queue.
 	// Returns the number of documents processed and error if processing fails.
 	ProcessScanQueue(ctx context.Context, batchSize int) (int, error)
 	
+	// ProcessScanTask scans the document of a dequeued task and completes, retries or dead-letters
+	// the task depending on the outcome.
+	ProcessScanTask(ctx context.Context, task ScanTask) error
+	
 	// ScanDocument scans a document for viruses.
 	// Returns scan result constant, additional details (virus name if infected), and error if scanning fails.
 	ScanDocument(ctx context.Context, storagePath string) (string, string, error)
@@ -0,0 +1,141 @@
+// Package services provides domain-level services for the Document Management Platform
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"time"
+
+	"../models"
+	"../repositories"
+	"../../pkg/errors"
+)
+
+// MaxWatermarkSizeBytes is the size of the largest document watermarked on download. Documents are
+// held in memory while they are stamped.
+const MaxWatermarkSizeBytes = 100 << 20
+
+// ErrDocumentTooLargeToWatermark is returned when a document that must be watermarked is too large
+// to be stamped; it is not handed out unstamped instead
+var ErrDocumentTooLargeToWatermark = errors.NewValidationError(fmt.Sprintf("document must be watermarked but is larger than %d bytes", MaxWatermarkSizeBytes))
+
+// WatermarkRenderer stamps text onto documents of the content types it supports, such as PDFs
+type WatermarkRenderer interface {
+	// Supports checks if the renderer can stamp documents of the content type.
+	Supports(contentType string) bool
+
+	// Render writes the document read from content to w with text stamped onto every page.
+	Render(ctx context.Context, content io.ReadSeeker, w io.Writer, text string) error
+}
+
+// WatermarkService stamps documents as they are downloaded with who downloaded them, when, and from
+// which tenant, so that leaked copies can be traced
+type WatermarkService interface {
+	// RequiresWatermark checks if a download of the document is stamped: its content type can be
+	// stamped, and its folder watermarks downloads or it is downloaded through a share link that
+	// does. link is nil for downloads by users.
+	RequiresWatermark(ctx context.Context, document *models.Document, link *models.ShareLink) (bool, error)
+
+	// WatermarkForUser reads the document's content and returns it stamped for the user downloading
+	// it, together with its new size.
+	WatermarkForUser(ctx context.Context, content io.Reader, document *models.Document, userID string) (io.ReadCloser, int64, error)
+
+	// WatermarkForShareLink reads the document's content and returns it stamped for a download
+	// through the share link, together with its new size.
+	WatermarkForShareLink(ctx context.Context, content io.Reader, document *models.Document, link *models.ShareLink) (io.ReadCloser, int64, error)
+}
+
+// watermarkService implements the WatermarkService interface
+type watermarkService struct {
+	folderRepo repositories.FolderRepository
+	userRepo   repositories.UserRepository
+	tenantRepo repositories.TenantRepository
+	renderer   WatermarkRenderer
+}
+
+// NewWatermarkService creates a WatermarkService stamping documents with renderer
+func NewWatermarkService(folderRepo repositories.FolderRepository, userRepo repositories.UserRepository,
+	tenantRepo repositories.TenantRepository, renderer WatermarkRenderer) (WatermarkService, error) {
+	if folderRepo == nil {
+		return nil, fmt.Errorf("folder repository cannot be nil")
+	}
+	if userRepo == nil {
+		return nil, fmt.Errorf("user repository cannot be nil")
+	}
+	if tenantRepo == nil {
+		return nil, fmt.Errorf("tenant repository cannot be nil")
+	}
+	if renderer == nil {
+		return nil, fmt.Errorf("watermark renderer cannot be nil")
+	}
+
+	return &watermarkService{
+		folderRepo: folderRepo,
+		userRepo:   userRepo,
+		tenantRepo: tenantRepo,
+		renderer:   renderer,
+	}, nil
+}
+
+// RequiresWatermark checks the share link, then the folder of the document
+func (s *watermarkService) RequiresWatermark(ctx context.Context, document *models.Document, link *models.ShareLink) (bool, error) {
+	if !s.renderer.Supports(document.ContentType) {
+		return false, nil
+	}
+	if link != nil && link.Watermark {
+		return true, nil
+	}
+	if document.FolderID == "" {
+		return false, nil
+	}
+
+	folder, err := s.folderRepo.GetByID(ctx, document.FolderID, document.TenantID)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get document folder")
+	}
+	return folder.WatermarkDownloads, nil
+}
+
+// WatermarkForUser stamps the content with the user's name and email
+func (s *watermarkService) WatermarkForUser(ctx context.Context, content io.Reader, document *models.Document, userID string) (io.ReadCloser, int64, error) {
+	user, err := s.userRepo.GetByID(ctx, userID, document.TenantID)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "failed to get downloading user")
+	}
+	tenant, err := s.tenantRepo.GetByID(ctx, document.TenantID)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "failed to get tenant")
+	}
+
+	return s.render(ctx, content, models.NewUserWatermark(user, tenant, time.Now()))
+}
+
+// WatermarkForShareLink stamps the content with the ID of the share link
+func (s *watermarkService) WatermarkForShareLink(ctx context.Context, content io.Reader, document *models.Document, link *models.ShareLink) (io.ReadCloser, int64, error) {
+	tenant, err := s.tenantRepo.GetByID(ctx, document.TenantID)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "failed to get tenant")
+	}
+
+	return s.render(ctx, content, models.NewShareLinkWatermark(link, tenant, time.Now()))
+}
+
+// render reads the content into memory, since renderers need to seek, and stamps it
+func (s *watermarkService) render(ctx context.Context, content io.Reader, watermark models.Watermark) (io.ReadCloser, int64, error) {
+	data, err := ioutil.ReadAll(io.LimitReader(content, MaxWatermarkSizeBytes+1))
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "failed to read document content")
+	}
+	if int64(len(data)) > MaxWatermarkSizeBytes {
+		return nil, 0, ErrDocumentTooLargeToWatermark
+	}
+
+	var stamped bytes.Buffer
+	if err := s.renderer.Render(ctx, bytes.NewReader(data), &stamped, watermark.Text()); err != nil {
+		return nil, 0, errors.Wrap(err, "failed to watermark document")
+	}
+	return ioutil.NopCloser(&stamped), int64(stamped.Len()), nil
+}
@@ -6,7 +6,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"net"
 	"net/http"
+	"syscall"
 	"time"
 
 	"../models"
@@ -14,16 +19,61 @@ import (
 	"../../pkg/errors"
 	"../../pkg/logger"
 	"../../pkg/utils"
+	webhookpkg "../../pkg/webhook"
 )
 
 const (
-	maxRetryAttempts = 5
-	defaultTimeout   = 10 * time.Second
-	headerSignature  = "X-Webhook-Signature"
-	headerEventType  = "X-Webhook-Event-Type"
-	headerEventID    = "X-Webhook-Event-ID"
+	maxRetryAttempts    = 5
+	defaultTimeout      = 10 * time.Second
+	maxResponseBodySize = 1024
+	headerEventType     = "X-Webhook-Event-Type"
+	headerEventID       = "X-Webhook-Event-ID"
+	headerDeliveryID    = "X-Webhook-Delivery-ID"
+	headerAttempt       = "X-Webhook-Attempt"
+	headerRequestID     = "X-Request-ID"
 )
 
+// WebhookRetryPolicy controls how failed webhook deliveries are rescheduled
+type WebhookRetryPolicy struct {
+	// MaxAttempts is the total number of attempts before a delivery is dead-lettered
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry
+	BaseDelay time.Duration
+	// MaxDelay caps the exponential growth of the delay
+	MaxDelay time.Duration
+	// JitterFraction randomizes each delay by up to this fraction in either direction
+	JitterFraction float64
+}
+
+// DefaultWebhookRetryPolicy returns the retry policy used when none is configured
+func DefaultWebhookRetryPolicy() WebhookRetryPolicy {
+	return WebhookRetryPolicy{
+		MaxAttempts:    maxRetryAttempts,
+		BaseDelay:      30 * time.Second,
+		MaxDelay:       1 * time.Hour,
+		JitterFraction: 0.2,
+	}
+}
+
+// NextDelay returns the delay to wait after the given (1-based) failed attempt
+func (p WebhookRetryPolicy) NextDelay(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+
+	delay := float64(p.BaseDelay) * math.Pow(2, float64(attempt-1))
+	if delay > float64(p.MaxDelay) {
+		delay = float64(p.MaxDelay)
+	}
+
+	if p.JitterFraction > 0 {
+		jitter := delay * p.JitterFraction
+		delay = delay - jitter + rand.Float64()*2*jitter
+	}
+
+	return time.Duration(delay)
+}
+
 // WebhookService defines the contract for webhook management operations
 type WebhookService interface {
 	// CreateWebhook creates a new webhook subscription
@@ -53,20 +103,54 @@ type WebhookService interface {
 	// ListDeliveries lists delivery attempts for a webhook with pagination
 	ListDeliveries(ctx context.Context, webhookID string, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.WebhookDelivery], error)
 	
+	// ListWebhookDeliveries lists a tenant's deliveries matching the filter with pagination
+	ListWebhookDeliveries(ctx context.Context, tenantID string, filter models.WebhookDeliveryFilter, pagination *utils.Pagination) (utils.PaginatedResult[models.WebhookDelivery], error)
+	
+	// GetWebhookDelivery gets a delivery together with its attempt history
+	GetWebhookDelivery(ctx context.Context, deliveryID string, tenantID string) (*models.WebhookDelivery, []*models.WebhookDeliveryAttempt, error)
+	
 	// RetryDelivery retries a failed webhook delivery
 	RetryDelivery(ctx context.Context, deliveryID string, tenantID string) error
 	
 	// ProcessPendingDeliveries processes pending webhook deliveries
 	ProcessPendingDeliveries(ctx context.Context, batchSize int) (int, error)
 	
-	// RetryFailedDeliveries retries failed webhook deliveries
+	// RetryFailedDeliveries retries failed webhook deliveries whose backoff has elapsed
 	RetryFailedDeliveries(ctx context.Context, batchSize int) (int, error)
+	
+	// ListDeadLetteredDeliveries lists deliveries that exhausted their retries for a tenant
+	ListDeadLetteredDeliveries(ctx context.Context, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.WebhookDelivery], error)
+	
+	// ListDeliveryAttempts lists the attempt history for a delivery
+	ListDeliveryAttempts(ctx context.Context, deliveryID string, tenantID string) ([]*models.WebhookDeliveryAttempt, error)
+	
+	// RotateWebhookSecret replaces a webhook's signing secret and returns the new secret
+	RotateWebhookSecret(ctx context.Context, id string, tenantID string) (string, error)
+	
+	// CreateSubscription adds an event filter subscription to a webhook
+	CreateSubscription(ctx context.Context, subscription *models.WebhookSubscription) (string, error)
+	
+	// GetSubscription retrieves a webhook subscription by its ID
+	GetSubscription(ctx context.Context, id string, tenantID string) (*models.WebhookSubscription, error)
+	
+	// UpdateSubscription updates an existing webhook subscription
+	UpdateSubscription(ctx context.Context, subscription *models.WebhookSubscription) error
+	
+	// DeleteSubscription deletes a webhook subscription
+	DeleteSubscription(ctx context.Context, id string, tenantID string) error
+	
+	// ListSubscriptions lists the subscriptions of a webhook
+	ListSubscriptions(ctx context.Context, webhookID string, tenantID string) ([]*models.WebhookSubscription, error)
+	
+	// SendTestDelivery sends a sample payload for the event type to a webhook and returns the outcome
+	SendTestDelivery(ctx context.Context, webhookID string, tenantID string, eventType string) (*models.WebhookDeliveryAttempt, error)
 }
 
 // webhookService implements the WebhookService interface
 type webhookService struct {
 	webhookRepo repositories.WebhookRepository
 	httpClient  *http.Client
+	retryPolicy WebhookRetryPolicy
 	logger      logger.Logger
 }
 
@@ -77,18 +161,68 @@ func NewWebhookService(webhookRepo repositories.WebhookRepository, httpClient *h
 	}
 	
 	if httpClient == nil {
-		httpClient = &http.Client{
-			Timeout: defaultTimeout,
-		}
+		httpClient = newRestrictedHTTPClient()
 	}
 	
 	return &webhookService{
 		webhookRepo: webhookRepo,
 		httpClient:  httpClient,
+		retryPolicy: DefaultWebhookRetryPolicy(),
 		logger:      logger.WithField("service", "webhook"),
 	}, nil
 }
 
+// newRestrictedHTTPClient returns the default client for webhook deliveries. Webhook URLs are
+// tenant-supplied, so the dialer refuses internal addresses; checking at dial time rather than
+// on the URL also covers redirects and DNS names that resolve to internal addresses
+func newRestrictedHTTPClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: defaultTimeout,
+		Control: restrictWebhookDial,
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	
+	return &http.Client{
+		Timeout:   defaultTimeout,
+		Transport: transport,
+	}
+}
+
+// restrictWebhookDial rejects connections to loopback, private, link-local and unspecified
+// addresses, which would otherwise expose internal services and cloud metadata endpoints
+func restrictWebhookDial(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return fmt.Errorf("webhook destination %q is not an IP address", host)
+	}
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return fmt.Errorf("webhook destination %s is not a public address", ip)
+	}
+	return nil
+}
+
+// NewWebhookServiceWithRetryPolicy creates a new WebhookService instance with a custom retry policy
+func NewWebhookServiceWithRetryPolicy(webhookRepo repositories.WebhookRepository, httpClient *http.Client, retryPolicy WebhookRetryPolicy) (WebhookService, error) {
+	if retryPolicy.MaxAttempts <= 0 {
+		return nil, fmt.Errorf("retry policy max attempts must be positive")
+	}
+	
+	service, err := NewWebhookService(webhookRepo, httpClient)
+	if err != nil {
+		return nil, err
+	}
+	
+	service.(*webhookService).retryPolicy = retryPolicy
+	return service, nil
+}
+
 // CreateWebhook creates a new webhook subscription
 func (s *webhookService) CreateWebhook(ctx context.Context, webhook *models.Webhook) (string, error) {
 	ctxLogger := logger.WithContext(ctx)
@@ -101,6 +235,15 @@ func (s *webhookService) CreateWebhook(ctx context.Context, webhook *models.Webh
 		return "", errors.NewValidationError(err.Error())
 	}
 	
+	// Generate a signing secret if the caller did not supply one
+	if webhook.SecretKey == "" {
+		secret, err := models.GenerateWebhookSecret()
+		if err != nil {
+			return "", errors.NewInternalError("failed to generate webhook secret")
+		}
+		webhook.SecretKey = secret
+	}
+	
 	id, err := s.webhookRepo.Create(ctx, webhook)
 	if err != nil {
 		return "", errors.Wrap(err, "failed to create webhook")
@@ -219,12 +362,14 @@ func (s *webhookService) ListWebhooks(ctx context.Context, tenantID string, pagi
 
 // ProcessEvent processes an event and delivers it to relevant webhooks
 func (s *webhookService) ProcessEvent(ctx context.Context, event *models.Event) error {
-	ctxLogger := logger.WithContext(ctx)
-	
 	if event == nil {
 		return errors.NewValidationError("event cannot be nil")
 	}
 	
+	// Log in the context of the request that raised the event
+	ctx = logger.ContextWithRequestID(ctx, event.RequestID)
+	ctxLogger := logger.WithContext(ctx)
+	
 	if err := event.Validate(); err != nil {
 		return errors.NewValidationError(err.Error())
 	}
@@ -241,8 +386,22 @@ func (s *webhookService) ProcessEvent(ctx context.Context, event *models.Event)
 			continue
 		}
 		
-		// Create a delivery record
-		delivery := models.NewWebhookDelivery(webhook.ID, event.ID)
+		// Apply the webhook's folder scope and metadata subscription filters
+		subscriptions, err := s.webhookRepo.ListSubscriptionsByWebhook(ctx, webhook.ID, event.TenantID)
+		if err != nil {
+			ctxLogger.Error("failed to list webhook subscriptions", 
+				"webhook_id", webhook.ID, 
+				"event_id", event.ID, 
+				"error", err)
+			continue
+		}
+		
+		if !models.MatchesAnySubscription(subscriptions, event) {
+			continue
+		}
+		
+		// Create a delivery record carrying the event snapshot so it can be retried later
+		delivery := models.NewWebhookDeliveryForEvent(webhook.ID, event)
 		deliveryID, err := s.webhookRepo.CreateDelivery(ctx, delivery)
 		if err != nil {
 			ctxLogger.Error("failed to create delivery record", 
@@ -256,7 +415,7 @@ func (s *webhookService) ProcessEvent(ctx context.Context, event *models.Event)
 		
 		// Deliver event asynchronously
 		go func(w *models.Webhook, e *models.Event, d *models.WebhookDelivery) {
-			deliveryCtx := context.Background()
+			deliveryCtx := logger.ContextWithRequestID(context.Background(), e.RequestID)
 			if err := s.DeliverEvent(deliveryCtx, w, e, d); err != nil {
 				logger.WithContext(deliveryCtx).Error("failed to deliver event", 
 					"webhook_id", w.ID, 
@@ -291,6 +450,59 @@ func (s *webhookService) DeliverEvent(ctx context.Context, webhook *models.Webho
 		return errors.NewValidationError("delivery cannot be nil")
 	}
 	
+	// Send the signed request
+	statusCode, respBody, duration, err := s.sendRequest(ctx, webhook, event, delivery.ID, delivery.AttemptCount)
+	
+	// Handle network errors
+	if err != nil {
+		s.recordAttempt(ctx, delivery, 0, "", err.Error(), duration)
+		s.handleFailedAttempt(ctx, webhook, delivery, 0, "", err.Error())
+		return errors.Wrap(err, "failed to execute HTTP request")
+	}
+	
+	// Check response status
+	if statusCode >= 200 && statusCode < 300 {
+		s.recordAttempt(ctx, delivery, statusCode, respBody, "", duration)
+		
+		delivery.MarkAsSuccess(statusCode, respBody)
+		webhook.RecordDeliverySuccess()
+		
+		ctxLogger.Info("event delivered successfully", 
+			"webhook_id", webhook.ID, 
+			"event_id", event.ID, 
+			"delivery_id", delivery.ID, 
+			"status", statusCode)
+		
+		// Update delivery in repository
+		if err := s.webhookRepo.UpdateDelivery(ctx, delivery); err != nil {
+			return errors.Wrap(err, "failed to update delivery record")
+		}
+		
+		// Update webhook stats in repository
+		if err := s.webhookRepo.Update(ctx, webhook); err != nil {
+			return errors.Wrap(err, "failed to update webhook stats")
+		}
+		
+		return nil
+	}
+	
+	errorMessage := fmt.Sprintf("HTTP error: %d", statusCode)
+	s.recordAttempt(ctx, delivery, statusCode, respBody, errorMessage, duration)
+	s.handleFailedAttempt(ctx, webhook, delivery, statusCode, respBody, errorMessage)
+	
+	ctxLogger.Error("event delivery failed", 
+		"webhook_id", webhook.ID, 
+		"event_id", event.ID, 
+		"delivery_id", delivery.ID, 
+		"status", statusCode, 
+		"attempt", delivery.AttemptCount)
+	
+	return nil
+}
+
+// sendRequest posts a signed event payload to the webhook URL and returns the response status,
+// the truncated response body and the request duration
+func (s *webhookService) sendRequest(ctx context.Context, webhook *models.Webhook, event *models.Event, deliveryID string, attempt int) (int, string, time.Duration, error) {
 	// Create request context with timeout
 	reqCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
 	defer cancel()
@@ -298,82 +510,83 @@ func (s *webhookService) DeliverEvent(ctx context.Context, webhook *models.Webho
 	// Create HTTP request
 	req, err := http.NewRequestWithContext(reqCtx, "POST", webhook.URL, bytes.NewReader(event.Payload))
 	if err != nil {
-		return errors.Wrap(err, "failed to create HTTP request")
+		return 0, "", 0, err
 	}
 	
 	// Add headers
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set(headerSignature, webhook.GenerateSignatureForPayload(event.Payload))
+	req.Header.Set(webhookpkg.SignatureHeader, webhook.SignatureHeaderForPayload(event.Payload, time.Now()))
 	req.Header.Set(headerEventType, event.Type)
 	req.Header.Set(headerEventID, event.ID)
+	req.Header.Set(headerDeliveryID, deliveryID)
+	req.Header.Set(headerAttempt, fmt.Sprintf("%d", attempt))
+	if event.RequestID != "" {
+		req.Header.Set(headerRequestID, event.RequestID)
+	}
 	
 	// Execute request
+	startedAt := time.Now()
 	resp, err := s.httpClient.Do(req)
-	
-	// Handle network errors
+	duration := time.Since(startedAt)
 	if err != nil {
-		// Update delivery status
-		delivery.MarkAsFailed(0, "", err.Error())
-		if updateErr := s.webhookRepo.UpdateDelivery(ctx, delivery); updateErr != nil {
-			ctxLogger.Error("failed to update delivery status", 
-				"delivery_id", delivery.ID, 
-				"error", updateErr)
-		}
-		
-		// Update webhook stats
-		webhook.RecordDeliveryFailure()
-		if updateErr := s.webhookRepo.Update(ctx, webhook); updateErr != nil {
-			ctxLogger.Error("failed to update webhook stats", 
-				"webhook_id", webhook.ID, 
-				"error", updateErr)
-		}
-		
-		return errors.Wrap(err, "failed to execute HTTP request")
+		return 0, "", duration, err
 	}
 	defer resp.Body.Close()
 	
 	// Read response body (limited to prevent memory issues)
-	respBodyBytes := make([]byte, 1024)
-	if resp.Body != nil {
-		resp.Body.Read(respBodyBytes)
+	respBodyBytes, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBodySize))
+	
+	return resp.StatusCode, string(respBodyBytes), duration, nil
+}
+
+// recordAttempt stores the outcome of a single HTTP attempt in the delivery's history
+func (s *webhookService) recordAttempt(ctx context.Context, delivery *models.WebhookDelivery, statusCode int, responseBody, errorMessage string, duration time.Duration) {
+	attempt := &models.WebhookDeliveryAttempt{
+		DeliveryID:     delivery.ID,
+		AttemptNumber:  delivery.AttemptCount,
+		ResponseStatus: statusCode,
+		ResponseBody:   responseBody,
+		ErrorMessage:   errorMessage,
+		DurationMs:     duration.Milliseconds(),
+		AttemptedAt:    time.Now(),
 	}
 	
-	respBody := string(respBodyBytes)
+	if _, err := s.webhookRepo.CreateDeliveryAttempt(ctx, attempt); err != nil {
+		logger.WithContext(ctx).Error("failed to record delivery attempt", 
+			"delivery_id", delivery.ID, 
+			"attempt", delivery.AttemptCount, 
+			"error", err)
+	}
+}
+
+// handleFailedAttempt either schedules the next retry with backoff or dead-letters the delivery
+// once the retry policy is exhausted, and persists the delivery and webhook state
+func (s *webhookService) handleFailedAttempt(ctx context.Context, webhook *models.Webhook, delivery *models.WebhookDelivery, statusCode int, responseBody, errorMessage string) {
+	ctxLogger := logger.WithContext(ctx)
 	
-	// Check response status
-	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
-		// Success
-		delivery.MarkAsSuccess(resp.StatusCode, respBody)
-		webhook.RecordDeliverySuccess()
-		
-		ctxLogger.Info("event delivered successfully", 
+	if delivery.AttemptCount >= s.retryPolicy.MaxAttempts {
+		delivery.MarkAsDeadLettered(statusCode, responseBody, errorMessage)
+		ctxLogger.Warn("webhook delivery moved to dead-letter state", 
 			"webhook_id", webhook.ID, 
-			"event_id", event.ID, 
 			"delivery_id", delivery.ID, 
-			"status", resp.StatusCode)
+			"attempts", delivery.AttemptCount)
 	} else {
-		// Failure
-		delivery.MarkAsFailed(resp.StatusCode, respBody, fmt.Sprintf("HTTP error: %d", resp.StatusCode))
-		webhook.RecordDeliveryFailure()
-		
-		ctxLogger.Error("event delivery failed", 
-			"webhook_id", webhook.ID, 
-			"event_id", event.ID, 
-			"delivery_id", delivery.ID, 
-			"status", resp.StatusCode)
+		nextAttemptAt := time.Now().Add(s.retryPolicy.NextDelay(delivery.AttemptCount))
+		delivery.ScheduleRetry(statusCode, responseBody, errorMessage, nextAttemptAt)
 	}
 	
-	// Update delivery in repository
 	if err := s.webhookRepo.UpdateDelivery(ctx, delivery); err != nil {
-		return errors.Wrap(err, "failed to update delivery record")
+		ctxLogger.Error("failed to update delivery status", 
+			"delivery_id", delivery.ID, 
+			"error", err)
 	}
 	
-	// Update webhook stats in repository
+	webhook.RecordDeliveryFailure()
 	if err := s.webhookRepo.Update(ctx, webhook); err != nil {
-		return errors.Wrap(err, "failed to update webhook stats")
+		ctxLogger.Error("failed to update webhook stats", 
+			"webhook_id", webhook.ID, 
+			"error", err)
 	}
-	
-	return nil
 }
 
 // GetDeliveryStatus gets the status of a webhook delivery
@@ -443,7 +656,56 @@ func (s *webhookService) ListDeliveries(ctx context.Context, webhookID string, t
 	return result, nil
 }
 
-// RetryDelivery retries a failed webhook delivery
+// ListWebhookDeliveries lists a tenant's deliveries matching the filter with pagination
+func (s *webhookService) ListWebhookDeliveries(ctx context.Context, tenantID string, filter models.WebhookDeliveryFilter, pagination *utils.Pagination) (utils.PaginatedResult[models.WebhookDelivery], error) {
+	ctxLogger := logger.WithContext(ctx)
+	
+	if err := s.validateInput(map[string]string{
+		"tenant ID": tenantID,
+	}); err != nil {
+		return utils.PaginatedResult[models.WebhookDelivery]{}, err
+	}
+	
+	if err := filter.Validate(); err != nil {
+		return utils.PaginatedResult[models.WebhookDelivery]{}, errors.NewValidationError(err.Error())
+	}
+	
+	// Verify the webhook belongs to the tenant so unknown IDs return not found rather than an empty page
+	if filter.WebhookID != "" {
+		if _, err := s.webhookRepo.GetByID(ctx, filter.WebhookID, tenantID); err != nil {
+			return utils.PaginatedResult[models.WebhookDelivery]{}, errors.Wrap(err, "failed to verify webhook existence")
+		}
+	}
+	
+	if pagination == nil {
+		pagination = utils.NewPagination(utils.DefaultPage, utils.DefaultPageSize)
+	}
+	
+	result, err := s.webhookRepo.ListDeliveriesByFilter(ctx, tenantID, filter, pagination)
+	if err != nil {
+		return utils.PaginatedResult[models.WebhookDelivery]{}, errors.Wrap(err, "failed to list deliveries")
+	}
+	
+	ctxLogger.Info("deliveries listed successfully", "tenant_id", tenantID, "count", len(result.Items))
+	return result, nil
+}
+
+// GetWebhookDelivery gets a delivery together with its attempt history
+func (s *webhookService) GetWebhookDelivery(ctx context.Context, deliveryID string, tenantID string) (*models.WebhookDelivery, []*models.WebhookDeliveryAttempt, error) {
+	delivery, err := s.GetDeliveryStatus(ctx, deliveryID, tenantID)
+	if err != nil {
+		return nil, nil, err
+	}
+	
+	attempts, err := s.webhookRepo.ListDeliveryAttempts(ctx, deliveryID, tenantID)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to list delivery attempts")
+	}
+	
+	return delivery, attempts, nil
+}
+
+// RetryDelivery retries a failed or dead-lettered webhook delivery immediately
 func (s *webhookService) RetryDelivery(ctx context.Context, deliveryID string, tenantID string) error {
 	ctxLogger := logger.WithContext(ctx)
 	
@@ -460,14 +722,9 @@ func (s *webhookService) RetryDelivery(ctx context.Context, deliveryID string, t
 		return errors.Wrap(err, "failed to get delivery")
 	}
 	
-	// Verify delivery is in failed state
-	if !delivery.IsFailed() {
-		return errors.NewValidationError("only failed deliveries can be retried")
-	}
-	
-	// Verify delivery attempt count is under maximum
-	if delivery.AttemptCount >= maxRetryAttempts {
-		return errors.NewValidationError(fmt.Sprintf("maximum retry attempts (%d) reached", maxRetryAttempts))
+	// Verify delivery is in a retryable state
+	if !delivery.IsFailed() && !delivery.IsDeadLettered() {
+		return errors.NewValidationError("only failed or dead-lettered deliveries can be retried")
 	}
 	
 	// Get webhook
@@ -481,30 +738,17 @@ func (s *webhookService) RetryDelivery(ctx context.Context, deliveryID string, t
 		return errors.NewAuthorizationError("delivery does not belong to the specified tenant")
 	}
 	
-	// Get event - this would typically come from an EventRepository
-	// For this implementation, we'll need to reconstruct the event from data
-	// that would be available in the system. In a real implementation,
-	// this would likely use an EventRepository to fetch the complete event.
-	event := &models.Event{
-		ID:       delivery.EventID,
-		TenantID: tenantID,
-		// Note: In a real implementation, we would need to retrieve the full
-		// event data including Type and Payload from a repository or other source
+	// A manual retry of a dead-lettered delivery grants it one more attempt
+	// without resetting its attempt history
+	if delivery.IsDeadLettered() && delivery.AttemptCount >= s.retryPolicy.MaxAttempts {
+		delivery.AttemptCount = s.retryPolicy.MaxAttempts - 1
 	}
 	
-	// Increment attempt count
 	delivery.IncrementAttempt()
-	if err := s.webhookRepo.UpdateDelivery(ctx, delivery); err != nil {
-		return errors.Wrap(err, "failed to update delivery attempt count")
-	}
 	
 	ctxLogger.Info("retrying webhook delivery", "delivery_id", deliveryID, "attempt", delivery.AttemptCount)
 	
-	// Note: A real implementation would use DeliverEvent here with the complete event data
-	// For this demonstration, we acknowledge the limitation that we can't fully
-	// retry the delivery without access to the event data
-	
-	return nil
+	return s.DeliverEvent(ctx, webhook, delivery.ToEvent(tenantID), delivery)
 }
 
 // ProcessPendingDeliveries processes pending webhook deliveries
@@ -524,8 +768,7 @@ func (s *webhookService) ProcessPendingDeliveries(ctx context.Context, batchSize
 	processed := 0
 	
 	for _, delivery := range deliveries {
-		// Get the webhook for this delivery
-		webhook, err := s.webhookRepo.GetByID(ctx, delivery.WebhookID, "")
+		webhook, err := s.webhookRepo.GetDeliveryWebhook(ctx, delivery.ID)
 		if err != nil {
 			ctxLogger.Error("failed to get webhook for delivery", 
 				"delivery_id", delivery.ID, 
@@ -534,17 +777,18 @@ func (s *webhookService) ProcessPendingDeliveries(ctx context.Context, batchSize
 			continue
 		}
 		
-		// In a real implementation, we would get the event data here
-		// and call DeliverEvent with the webhook, event, and delivery
-		
 		ctxLogger.Info("processing pending delivery", 
 			"delivery_id", delivery.ID, 
 			"webhook_id", delivery.WebhookID, 
 			"tenant_id", webhook.TenantID, 
 			"attempt", delivery.AttemptCount)
 		
-		// Count as processed even if we can't complete the delivery
-		// in this demonstration implementation
+		if err := s.DeliverEvent(ctx, webhook, delivery.ToEvent(webhook.TenantID), delivery); err != nil {
+			ctxLogger.Error("failed to deliver pending delivery", 
+				"delivery_id", delivery.ID, 
+				"error", err)
+		}
+		
 		processed++
 	}
 	
@@ -552,7 +796,7 @@ func (s *webhookService) ProcessPendingDeliveries(ctx context.Context, batchSize
 	return processed, nil
 }
 
-// RetryFailedDeliveries retries failed webhook deliveries
+// RetryFailedDeliveries retries failed webhook deliveries whose backoff has elapsed
 func (s *webhookService) RetryFailedDeliveries(ctx context.Context, batchSize int) (int, error) {
 	ctxLogger := logger.WithContext(ctx)
 	
@@ -560,22 +804,16 @@ func (s *webhookService) RetryFailedDeliveries(ctx context.Context, batchSize in
 		return 0, errors.NewValidationError("batch size must be positive")
 	}
 	
-	// Get failed deliveries
-	deliveries, err := s.webhookRepo.ListFailedDeliveries(ctx, batchSize, maxRetryAttempts)
+	// Get failed deliveries whose next attempt time has passed
+	deliveries, err := s.webhookRepo.ListDueDeliveries(ctx, time.Now(), batchSize)
 	if err != nil {
-		return 0, errors.Wrap(err, "failed to list failed deliveries")
+		return 0, errors.Wrap(err, "failed to list due deliveries")
 	}
 	
 	retried := 0
 	
 	for _, delivery := range deliveries {
-		// Skip deliveries that have reached the max retry attempts
-		if delivery.AttemptCount >= maxRetryAttempts {
-			continue
-		}
-		
-		// Get the webhook for this delivery
-		webhook, err := s.webhookRepo.GetByID(ctx, delivery.WebhookID, "")
+		webhook, err := s.webhookRepo.GetDeliveryWebhook(ctx, delivery.ID)
 		if err != nil {
 			ctxLogger.Error("failed to get webhook for delivery", 
 				"delivery_id", delivery.ID, 
@@ -584,17 +822,18 @@ func (s *webhookService) RetryFailedDeliveries(ctx context.Context, batchSize in
 			continue
 		}
 		
-		// Increment attempt count
-		delivery.IncrementAttempt()
-		if err := s.webhookRepo.UpdateDelivery(ctx, delivery); err != nil {
-			ctxLogger.Error("failed to update delivery attempt count", 
-				"delivery_id", delivery.ID, 
-				"error", err)
+		// Deliveries to webhooks that were deactivated are dead-lettered rather than retried
+		if !webhook.IsActive() {
+			delivery.MarkAsDeadLettered(delivery.ResponseStatus, delivery.ResponseBody, "webhook is inactive")
+			if err := s.webhookRepo.UpdateDelivery(ctx, delivery); err != nil {
+				ctxLogger.Error("failed to dead-letter delivery", 
+					"delivery_id", delivery.ID, 
+					"error", err)
+			}
 			continue
 		}
 		
-		// In a real implementation, we would get the event data here
-		// and call DeliverEvent with the webhook, event, and delivery
+		delivery.IncrementAttempt()
 		
 		ctxLogger.Info("retrying failed delivery", 
 			"delivery_id", delivery.ID, 
@@ -602,8 +841,12 @@ func (s *webhookService) RetryFailedDeliveries(ctx context.Context, batchSize in
 			"tenant_id", webhook.TenantID, 
 			"attempt", delivery.AttemptCount)
 		
-		// Count as retried even if we can't complete the delivery
-		// in this demonstration implementation
+		if err := s.DeliverEvent(ctx, webhook, delivery.ToEvent(webhook.TenantID), delivery); err != nil {
+			ctxLogger.Error("failed to retry delivery", 
+				"delivery_id", delivery.ID, 
+				"error", err)
+		}
+		
 		retried++
 	}
 	
@@ -611,6 +854,261 @@ func (s *webhookService) RetryFailedDeliveries(ctx context.Context, batchSize in
 	return retried, nil
 }
 
+// ListDeadLetteredDeliveries lists deliveries that exhausted their retries for a tenant
+func (s *webhookService) ListDeadLetteredDeliveries(ctx context.Context, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.WebhookDelivery], error) {
+	ctxLogger := logger.WithContext(ctx)
+	
+	if tenantID == "" {
+		return utils.PaginatedResult[models.WebhookDelivery]{}, errors.NewValidationError("tenant ID cannot be empty")
+	}
+	
+	if pagination == nil {
+		pagination = utils.NewPagination(utils.DefaultPage, utils.DefaultPageSize)
+	}
+	
+	result, err := s.webhookRepo.ListDeadLetteredDeliveries(ctx, tenantID, pagination)
+	if err != nil {
+		return utils.PaginatedResult[models.WebhookDelivery]{}, errors.Wrap(err, "failed to list dead-lettered deliveries")
+	}
+	
+	ctxLogger.Info("dead-lettered deliveries listed successfully", "tenant_id", tenantID, "count", len(result.Items))
+	return result, nil
+}
+
+// ListDeliveryAttempts lists the attempt history for a delivery
+func (s *webhookService) ListDeliveryAttempts(ctx context.Context, deliveryID string, tenantID string) ([]*models.WebhookDeliveryAttempt, error) {
+	ctxLogger := logger.WithContext(ctx)
+	
+	if err := s.validateInput(map[string]string{
+		"delivery ID": deliveryID,
+		"tenant ID":   tenantID,
+	}); err != nil {
+		return nil, err
+	}
+	
+	// Verify the delivery exists and belongs to the tenant
+	if _, err := s.webhookRepo.GetDeliveryByID(ctx, deliveryID, tenantID); err != nil {
+		return nil, errors.Wrap(err, "failed to get delivery")
+	}
+	
+	attempts, err := s.webhookRepo.ListDeliveryAttempts(ctx, deliveryID, tenantID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list delivery attempts")
+	}
+	
+	ctxLogger.Info("delivery attempts listed successfully", "delivery_id", deliveryID, "count", len(attempts))
+	return attempts, nil
+}
+
+// RotateWebhookSecret replaces a webhook's signing secret and returns the new secret.
+// The previous secret keeps signing deliveries for the rotation grace period so receivers can switch over.
+func (s *webhookService) RotateWebhookSecret(ctx context.Context, id string, tenantID string) (string, error) {
+	ctxLogger := logger.WithContext(ctx)
+	
+	if err := s.validateInput(map[string]string{
+		"webhook ID": id,
+		"tenant ID":  tenantID,
+	}); err != nil {
+		return "", err
+	}
+	
+	webhook, err := s.webhookRepo.GetByID(ctx, id, tenantID)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get webhook")
+	}
+	
+	secret, err := webhook.RotateSecret()
+	if err != nil {
+		return "", errors.NewInternalError("failed to generate webhook secret")
+	}
+	
+	if err := s.webhookRepo.Update(ctx, webhook); err != nil {
+		return "", errors.Wrap(err, "failed to update webhook")
+	}
+	
+	ctxLogger.Info("webhook secret rotated successfully", "webhook_id", id)
+	return secret, nil
+}
+
+// CreateSubscription adds an event filter subscription to a webhook
+func (s *webhookService) CreateSubscription(ctx context.Context, subscription *models.WebhookSubscription) (string, error) {
+	ctxLogger := logger.WithContext(ctx)
+	
+	if subscription == nil {
+		return "", errors.NewValidationError("subscription cannot be nil")
+	}
+	
+	if err := subscription.Validate(); err != nil {
+		return "", errors.NewValidationError(err.Error())
+	}
+	
+	if err := s.validateSubscriptionEventTypes(ctx, subscription); err != nil {
+		return "", err
+	}
+	
+	id, err := s.webhookRepo.CreateSubscription(ctx, subscription)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create webhook subscription")
+	}
+	
+	ctxLogger.Info("webhook subscription created successfully", "subscription_id", id, "webhook_id", subscription.WebhookID)
+	return id, nil
+}
+
+// GetSubscription retrieves a webhook subscription by its ID
+func (s *webhookService) GetSubscription(ctx context.Context, id string, tenantID string) (*models.WebhookSubscription, error) {
+	if err := s.validateInput(map[string]string{
+		"subscription ID": id,
+		"tenant ID":       tenantID,
+	}); err != nil {
+		return nil, err
+	}
+	
+	subscription, err := s.webhookRepo.GetSubscription(ctx, id, tenantID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get webhook subscription")
+	}
+	
+	return subscription, nil
+}
+
+// UpdateSubscription updates an existing webhook subscription
+func (s *webhookService) UpdateSubscription(ctx context.Context, subscription *models.WebhookSubscription) error {
+	ctxLogger := logger.WithContext(ctx)
+	
+	if subscription == nil {
+		return errors.NewValidationError("subscription cannot be nil")
+	}
+	
+	if err := subscription.Validate(); err != nil {
+		return errors.NewValidationError(err.Error())
+	}
+	
+	if err := s.validateSubscriptionEventTypes(ctx, subscription); err != nil {
+		return err
+	}
+	
+	if err := s.webhookRepo.UpdateSubscription(ctx, subscription); err != nil {
+		return errors.Wrap(err, "failed to update webhook subscription")
+	}
+	
+	ctxLogger.Info("webhook subscription updated successfully", "subscription_id", subscription.ID)
+	return nil
+}
+
+// DeleteSubscription deletes a webhook subscription
+func (s *webhookService) DeleteSubscription(ctx context.Context, id string, tenantID string) error {
+	ctxLogger := logger.WithContext(ctx)
+	
+	if err := s.validateInput(map[string]string{
+		"subscription ID": id,
+		"tenant ID":       tenantID,
+	}); err != nil {
+		return err
+	}
+	
+	if err := s.webhookRepo.DeleteSubscription(ctx, id, tenantID); err != nil {
+		return errors.Wrap(err, "failed to delete webhook subscription")
+	}
+	
+	ctxLogger.Info("webhook subscription deleted successfully", "subscription_id", id)
+	return nil
+}
+
+// ListSubscriptions lists the subscriptions of a webhook
+func (s *webhookService) ListSubscriptions(ctx context.Context, webhookID string, tenantID string) ([]*models.WebhookSubscription, error) {
+	if err := s.validateInput(map[string]string{
+		"webhook ID": webhookID,
+		"tenant ID":  tenantID,
+	}); err != nil {
+		return nil, err
+	}
+	
+	// Verify the webhook exists for the tenant so unknown IDs return not found rather than an empty list
+	if _, err := s.webhookRepo.GetByID(ctx, webhookID, tenantID); err != nil {
+		return nil, errors.Wrap(err, "failed to get webhook")
+	}
+	
+	subscriptions, err := s.webhookRepo.ListSubscriptionsByWebhook(ctx, webhookID, tenantID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list webhook subscriptions")
+	}
+	
+	return subscriptions, nil
+}
+
+// SendTestDelivery sends a sample payload for the event type to a webhook and returns the outcome.
+// Test deliveries are not persisted, retried or counted against the webhook's failure count.
+func (s *webhookService) SendTestDelivery(ctx context.Context, webhookID string, tenantID string, eventType string) (*models.WebhookDeliveryAttempt, error) {
+	ctxLogger := logger.WithContext(ctx)
+	
+	if err := s.validateInput(map[string]string{
+		"webhook ID": webhookID,
+		"tenant ID":  tenantID,
+		"event type": eventType,
+	}); err != nil {
+		return nil, err
+	}
+	
+	webhook, err := s.webhookRepo.GetByID(ctx, webhookID, tenantID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get webhook")
+	}
+	
+	event, err := models.NewSampleEvent(eventType, tenantID)
+	if err != nil {
+		return nil, errors.NewInternalError("failed to build sample event")
+	}
+	event.ID = "test"
+	event.RequestID = logger.RequestIDFromContext(ctx)
+	
+	attempt := &models.WebhookDeliveryAttempt{
+		AttemptNumber: 1,
+		AttemptedAt:   time.Now(),
+	}
+	
+	// The response body is deliberately not returned: the caller chooses the URL, so echoing the
+	// body back would turn the test endpoint into a way to read arbitrary HTTP responses
+	statusCode, _, duration, err := s.sendRequest(ctx, webhook, event, "test", attempt.AttemptNumber)
+	attempt.ResponseStatus = statusCode
+	attempt.DurationMs = duration.Milliseconds()
+	if err != nil {
+		attempt.ErrorMessage = err.Error()
+	} else if !attempt.Succeeded() {
+		attempt.ErrorMessage = fmt.Sprintf("HTTP error: %d", statusCode)
+	}
+	
+	ctxLogger.Info("webhook test delivery sent", 
+		"webhook_id", webhookID, 
+		"event_type", eventType, 
+		"status", statusCode)
+	return attempt, nil
+}
+
+// validateSubscriptionEventTypes ensures the webhook exists and that the subscription only filters
+// on event types the webhook is registered for, since other types would never be delivered
+func (s *webhookService) validateSubscriptionEventTypes(ctx context.Context, subscription *models.WebhookSubscription) error {
+	webhook, err := s.webhookRepo.GetByID(ctx, subscription.WebhookID, subscription.TenantID)
+	if err != nil {
+		return errors.Wrap(err, "failed to get webhook")
+	}
+	
+	for _, eventType := range subscription.EventTypes {
+		registered := false
+		for _, et := range webhook.EventTypes {
+			if et == eventType {
+				registered = true
+				break
+			}
+		}
+		if !registered {
+			return errors.NewValidationError(fmt.Sprintf("webhook is not registered for event type '%s'", eventType))
+		}
+	}
+	
+	return nil
+}
+
 // validateInput validates input parameters
 func (s *webhookService) validateInput(params map[string]string) error {
 	for param, value := range params {
@@ -0,0 +1,132 @@
+// Package s3 provides an AuditExporter that batches audit logs to an S3 bucket as JSON Lines,
+// laid out by date and hour so that SIEM S3 inputs (for example Splunk) can ingest them incrementally.
+package s3
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"             // v1.44.0+
+	"github.com/aws/aws-sdk-go/aws/credentials" // v1.44.0+
+	"github.com/aws/aws-sdk-go/aws/request"     // v1.44.0+
+	"github.com/aws/aws-sdk-go/aws/session"     // v1.44.0+
+	"github.com/aws/aws-sdk-go/service/s3"      // v1.44.0+
+
+	"../../../domain/models"
+	"../../../domain/services"
+	"../../../pkg/config"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+)
+
+// jsonlContentType is the content type of the uploaded batches
+const jsonlContentType = "application/x-ndjson"
+
+// S3PutObjectAPI is the subset of the S3 client used by the exporter
+type S3PutObjectAPI interface {
+	PutObjectWithContext(ctx aws.Context, input *s3.PutObjectInput, opts ...request.Option) (*s3.PutObjectOutput, error)
+}
+
+// jsonlExporter implements services.AuditExporter by writing each batch as one S3 object
+type jsonlExporter struct {
+	client S3PutObjectAPI
+	bucket string
+	prefix string
+}
+
+// NewJSONLExporter creates an AuditExporter that uploads audit log batches to the configured bucket
+func NewJSONLExporter(cfg config.AuditS3Config) (services.AuditExporter, error) {
+	if cfg.Bucket == "" {
+		return nil, errors.NewValidationError("audit S3 bucket cannot be empty")
+	}
+
+	awsConfig := &aws.Config{
+		Region:           aws.String(cfg.Region),
+		S3ForcePathStyle: aws.Bool(cfg.ForcePathStyle),
+		DisableSSL:       aws.Bool(!cfg.UseSSL),
+	}
+	if cfg.Endpoint != "" {
+		awsConfig.Endpoint = aws.String(cfg.Endpoint)
+	}
+	if cfg.AccessKey != "" {
+		awsConfig.Credentials = credentials.NewStaticCredentials(cfg.AccessKey, cfg.SecretKey, "")
+	}
+
+	sess, err := session.NewSession(awsConfig)
+	if err != nil {
+		logger.Error("Failed to create AWS session for audit export", "error", err)
+		return nil, errors.Wrap(err, "failed to create AWS session for audit export")
+	}
+
+	return NewJSONLExporterWithClient(s3.New(sess), cfg.Bucket, cfg.Prefix)
+}
+
+// NewJSONLExporterWithClient creates an AuditExporter that uploads through the given S3 client
+func NewJSONLExporterWithClient(client S3PutObjectAPI, bucket string, prefix string) (services.AuditExporter, error) {
+	if client == nil {
+		return nil, errors.NewValidationError("S3 client cannot be nil")
+	}
+	if bucket == "" {
+		return nil, errors.NewValidationError("audit S3 bucket cannot be empty")
+	}
+
+	return &jsonlExporter{
+		client: client,
+		bucket: bucket,
+		prefix: strings.Trim(prefix, "/"),
+	}, nil
+}
+
+// Name identifies the exporter
+func (e *jsonlExporter) Name() string {
+	return models.AuditExporterS3
+}
+
+// Export uploads the batch as a single JSON Lines object. The key is derived from the first
+// entry of the batch, so re-exporting the same batch after a failure overwrites the object
+// instead of creating a duplicate.
+func (e *jsonlExporter) Export(ctx context.Context, entries []*models.AuditLog) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	var body bytes.Buffer
+	encoder := json.NewEncoder(&body)
+	for _, entry := range entries {
+		if err := encoder.Encode(entry); err != nil {
+			return errors.Wrap(err, "failed to encode audit log")
+		}
+	}
+
+	key := e.objectKey(entries[0])
+	_, err := e.client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket:               aws.String(e.bucket),
+		Key:                  aws.String(key),
+		Body:                 bytes.NewReader(body.Bytes()),
+		ContentType:          aws.String(jsonlContentType),
+		ServerSideEncryption: aws.String("AES256"),
+	})
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to upload audit log batch", "error", err, "bucket", e.bucket, "key", key, "count", len(entries))
+		return errors.Wrap(err, "failed to upload audit log batch")
+	}
+
+	logger.InfoContext(ctx, "Uploaded audit log batch", "bucket", e.bucket, "key", key, "count", len(entries))
+	return nil
+}
+
+// Close is a no-op; the S3 client holds no persistent connection
+func (e *jsonlExporter) Close() error {
+	return nil
+}
+
+// objectKey builds the key of a batch: <prefix>/dt=YYYY-MM-DD/hour=HH/<first occurred_at>_<first id>.jsonl
+func (e *jsonlExporter) objectKey(first *models.AuditLog) string {
+	occurredAt := first.OccurredAt.UTC()
+	name := fmt.Sprintf("%s_%s.jsonl", occurredAt.Format("20060102T150405.000000000Z"), first.ID)
+	return path.Join(e.prefix, "dt="+occurredAt.Format("2006-01-02"), "hour="+occurredAt.Format("15"), name)
+}
@@ -0,0 +1,89 @@
+package s3
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"         // v1.44.0+
+	"github.com/aws/aws-sdk-go/aws/request" // v1.44.0+
+	"github.com/aws/aws-sdk-go/service/s3"  // v1.44.0+
+	"github.com/stretchr/testify/assert"    // v1.8.0+
+	"github.com/stretchr/testify/mock"      // v1.8.0+
+	"github.com/stretchr/testify/require"   // v1.8.0+
+
+	"../../../domain/models"
+)
+
+// mockS3Client is a mock implementation of S3PutObjectAPI
+type mockS3Client struct {
+	mock.Mock
+}
+
+func (m *mockS3Client) PutObjectWithContext(ctx aws.Context, input *s3.PutObjectInput, opts ...request.Option) (*s3.PutObjectOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*s3.PutObjectOutput), args.Error(1)
+}
+
+// createTestAuditLogs creates a batch of audit log entries for testing
+func createTestAuditLogs() []*models.AuditLog {
+	occurredAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	return []*models.AuditLog{
+		{ID: "audit-1", TenantID: "tenant-123", Action: models.AuditActionCreate, ResourceType: models.ResourceTypeFolder, ResourceID: "folder-1", OccurredAt: occurredAt},
+		{ID: "audit-2", TenantID: "tenant-456", Action: models.AuditActionDownload, ResourceType: models.ResourceTypeDocument, ResourceID: "doc-1", OccurredAt: occurredAt.Add(time.Second)},
+	}
+}
+
+func TestJSONLExporter_Export(t *testing.T) {
+	client := new(mockS3Client)
+	exporter, err := NewJSONLExporterWithClient(client, "audit-bucket", "/audit/")
+	require.NoError(t, err)
+
+	var body string
+	client.On("PutObjectWithContext", mock.Anything, mock.MatchedBy(func(input *s3.PutObjectInput) bool {
+		return *input.Bucket == "audit-bucket" &&
+			*input.Key == "audit/dt=2024-01-02/hour=03/20240102T030405.000000000Z_audit-1.jsonl" &&
+			*input.ContentType == "application/x-ndjson"
+	})).Run(func(args mock.Arguments) {
+		data, _ := io.ReadAll(args.Get(1).(*s3.PutObjectInput).Body)
+		body = string(data)
+	}).Return(&s3.PutObjectOutput{}, nil)
+
+	err = exporter.Export(context.Background(), createTestAuditLogs())
+	require.NoError(t, err)
+	client.AssertExpectations(t)
+
+	// One JSON object per line, in batch order
+	lines := strings.Split(strings.TrimSpace(body), "\n")
+	require.Len(t, lines, 2)
+	var first models.AuditLog
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+	assert.Equal(t, "audit-1", first.ID)
+}
+
+func TestJSONLExporter_ExportError(t *testing.T) {
+	client := new(mockS3Client)
+	exporter, err := NewJSONLExporterWithClient(client, "audit-bucket", "")
+	require.NoError(t, err)
+
+	client.On("PutObjectWithContext", mock.Anything, mock.Anything).Return(nil, errors.New("access denied"))
+
+	err = exporter.Export(context.Background(), createTestAuditLogs())
+	assert.Error(t, err)
+}
+
+func TestJSONLExporter_EmptyBatch(t *testing.T) {
+	client := new(mockS3Client)
+	exporter, err := NewJSONLExporterWithClient(client, "audit-bucket", "")
+	require.NoError(t, err)
+
+	assert.NoError(t, exporter.Export(context.Background(), nil))
+	client.AssertNotCalled(t, "PutObjectWithContext")
+}
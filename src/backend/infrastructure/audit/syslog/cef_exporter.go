@@ -0,0 +1,279 @@
+// Package syslog provides an AuditExporter that forwards audit logs to a syslog endpoint
+// as ArcSight Common Event Format (CEF) messages, for ingestion by SIEMs such as Splunk.
+package syslog
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"../../../domain/models"
+	"../../../domain/services"
+	"../../../pkg/config"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+)
+
+// CEF header values identifying the platform as the event source
+const (
+	cefVendor  = "DocumentManagementPlatform"
+	cefProduct = "DocumentManagement"
+	cefVersion = "1.0"
+)
+
+// Syslog defaults
+const (
+	defaultFacility = 13 // log audit
+	defaultAppName  = "document-mgmt"
+	defaultTimeout  = 5 * time.Second
+
+	// severityNotice is the syslog severity used for all audit messages
+	severityNotice = 5
+)
+
+// Supported transports
+const (
+	networkUDP = "udp"
+	networkTCP = "tcp"
+	networkTLS = "tls"
+)
+
+// cefExporter implements services.AuditExporter by writing RFC 5424 syslog messages with a CEF payload
+type cefExporter struct {
+	network  string
+	address  string
+	facility int
+	appName  string
+	hostname string
+	timeout  time.Duration
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+// NewCEFExporter creates an AuditExporter that sends audit logs to the configured syslog endpoint.
+// The connection is established lazily and re-established after a write failure.
+func NewCEFExporter(cfg config.AuditSyslogConfig) (services.AuditExporter, error) {
+	if cfg.Address == "" {
+		return nil, errors.NewValidationError("syslog address cannot be empty")
+	}
+
+	network := strings.ToLower(cfg.Network)
+	if network == "" {
+		network = networkTCP
+	}
+	if network != networkUDP && network != networkTCP && network != networkTLS {
+		return nil, errors.NewValidationError(fmt.Sprintf("unsupported syslog network: %s", cfg.Network))
+	}
+
+	facility := cfg.Facility
+	if facility == 0 {
+		facility = defaultFacility
+	}
+	if facility < 0 || facility > 23 {
+		return nil, errors.NewValidationError(fmt.Sprintf("invalid syslog facility: %d", cfg.Facility))
+	}
+
+	appName := cfg.AppName
+	if appName == "" {
+		appName = defaultAppName
+	}
+
+	timeout := defaultTimeout
+	if cfg.Timeout != "" {
+		parsed, err := time.ParseDuration(cfg.Timeout)
+		if err != nil {
+			return nil, errors.NewValidationError(fmt.Sprintf("invalid syslog timeout: %s", cfg.Timeout))
+		}
+		timeout = parsed
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "-"
+	}
+
+	return &cefExporter{
+		network:  network,
+		address:  cfg.Address,
+		facility: facility,
+		appName:  appName,
+		hostname: hostname,
+		timeout:  timeout,
+	}, nil
+}
+
+// Name identifies the exporter
+func (e *cefExporter) Name() string {
+	return models.AuditExporterSyslog
+}
+
+// Export writes one syslog message per entry. Stream transports use octet-counting framing
+// (RFC 6587) so that messages containing newlines are delimited correctly.
+func (e *cefExporter) Export(ctx context.Context, entries []*models.AuditLog) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	conn, err := e.connect(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		message := e.formatMessage(entry)
+		if e.network != networkUDP {
+			message = strconv.Itoa(len(message)) + " " + message
+		}
+
+		if err := conn.SetWriteDeadline(time.Now().Add(e.timeout)); err != nil {
+			e.reset()
+			return errors.Wrap(err, "failed to set syslog write deadline")
+		}
+		if _, err := conn.Write([]byte(message)); err != nil {
+			logger.Error("Failed to write audit log to syslog", "error", err, "address", e.address, "audit_id", entry.ID)
+			e.reset()
+			return errors.Wrap(err, "failed to write audit log to syslog")
+		}
+	}
+
+	return nil
+}
+
+// Close closes the connection to the syslog endpoint
+func (e *cefExporter) Close() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.conn == nil {
+		return nil
+	}
+	err := e.conn.Close()
+	e.conn = nil
+	return err
+}
+
+// connect returns the current connection, dialing the endpoint if there is none
+func (e *cefExporter) connect(ctx context.Context) (net.Conn, error) {
+	if e.conn != nil {
+		return e.conn, nil
+	}
+
+	dialer := &net.Dialer{Timeout: e.timeout}
+
+	var conn net.Conn
+	var err error
+	if e.network == networkTLS {
+		tlsDialer := &tls.Dialer{NetDialer: dialer}
+		conn, err = tlsDialer.DialContext(ctx, networkTCP, e.address)
+	} else {
+		conn, err = dialer.DialContext(ctx, e.network, e.address)
+	}
+	if err != nil {
+		logger.Error("Failed to connect to syslog endpoint", "error", err, "network", e.network, "address", e.address)
+		return nil, errors.Wrap(err, "failed to connect to syslog endpoint")
+	}
+
+	e.conn = conn
+	return conn, nil
+}
+
+// reset drops the current connection so the next export reconnects
+func (e *cefExporter) reset() {
+	if e.conn != nil {
+		e.conn.Close()
+		e.conn = nil
+	}
+}
+
+// formatMessage builds an RFC 5424 syslog message carrying the entry as a CEF event
+func (e *cefExporter) formatMessage(entry *models.AuditLog) string {
+	priority := e.facility*8 + severityNotice
+	return fmt.Sprintf("<%d>1 %s %s %s - - - %s",
+		priority,
+		entry.OccurredAt.UTC().Format(time.RFC3339Nano),
+		e.hostname,
+		e.appName,
+		FormatCEF(entry),
+	)
+}
+
+// FormatCEF renders an audit log entry as a CEF:0 event
+func FormatCEF(entry *models.AuditLog) string {
+	signatureID := entry.ResourceType + "." + entry.Action
+	name := entry.ResourceType + " " + entry.Action
+
+	// Custom string fields carry their label in the matching csNLabel key
+	extension := []struct {
+		key   string
+		label string
+		value string
+	}{
+		{"rt", "", strconv.FormatInt(entry.OccurredAt.UnixMilli(), 10)},
+		{"externalId", "", entry.ID},
+		{"act", "", entry.Action},
+		{"suser", "", entry.ActorID},
+		{"src", "", entry.ActorIP},
+		{"requestClientApplication", "", entry.UserAgent},
+		{"cs1", "tenantId", entry.TenantID},
+		{"cs2", "resourceType", entry.ResourceType},
+		{"cs3", "resourceId", entry.ResourceID},
+		{"cs4", "requestId", entry.RequestID},
+		{"cs5", "before", string(entry.Before)},
+		{"cs6", "after", string(entry.After)},
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "CEF:0|%s|%s|%s|%s|%s|%d|",
+		escapeCEFHeader(cefVendor),
+		escapeCEFHeader(cefProduct),
+		escapeCEFHeader(cefVersion),
+		escapeCEFHeader(signatureID),
+		escapeCEFHeader(name),
+		cefSeverity(entry.Action),
+	)
+
+	pairs := make([]string, 0, len(extension)*2)
+	for _, field := range extension {
+		if field.value == "" {
+			continue
+		}
+		if field.label != "" {
+			pairs = append(pairs, field.key+"Label="+escapeCEFExtension(field.label))
+		}
+		pairs = append(pairs, field.key+"="+escapeCEFExtension(field.value))
+	}
+	b.WriteString(strings.Join(pairs, " "))
+
+	return b.String()
+}
+
+// cefSeverity maps an audit action to a CEF severity (0-10). Destructive and
+// access-changing actions are reported with a higher severity.
+func cefSeverity(action string) int {
+	switch action {
+	case models.AuditActionDelete, models.AuditActionGrant, models.AuditActionRevoke:
+		return 5
+	default:
+		return 3
+	}
+}
+
+// escapeCEFHeader escapes backslashes and pipes in CEF header fields
+func escapeCEFHeader(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	return strings.ReplaceAll(value, "|", `\|`)
+}
+
+// escapeCEFExtension escapes backslashes, equals signs and line breaks in CEF extension values
+func escapeCEFExtension(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, "=", `\=`)
+	value = strings.ReplaceAll(value, "\r", `\r`)
+	return strings.ReplaceAll(value, "\n", `\n`)
+}
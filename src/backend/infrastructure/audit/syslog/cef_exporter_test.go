@@ -0,0 +1,123 @@
+package syslog
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"  // v1.8.0+
+	"github.com/stretchr/testify/require" // v1.8.0+
+
+	"../../../domain/models"
+	"../../../pkg/config"
+)
+
+// createTestAuditLog creates an audit log entry for testing
+func createTestAuditLog() *models.AuditLog {
+	return &models.AuditLog{
+		ID:           "5f0c2a6e-3d1b-4c4e-9a57-1f2d3c4b5a69",
+		TenantID:     "tenant-123",
+		ActorID:      "user-123",
+		ActorIP:      "10.0.0.1",
+		UserAgent:    "curl/8.0",
+		RequestID:    "req-123",
+		Action:       models.AuditActionUpdate,
+		ResourceType: models.ResourceTypeFolder,
+		ResourceID:   "folder-123",
+		Before:       []byte(`{"name":"a=b"}`),
+		After:        []byte(`{"name":"c|d"}`),
+		OccurredAt:   time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+}
+
+func TestFormatCEF(t *testing.T) {
+	cef := FormatCEF(createTestAuditLog())
+
+	assert.True(t, strings.HasPrefix(cef, "CEF:0|DocumentManagementPlatform|DocumentManagement|1.0|folder.update|folder update|3|"))
+	assert.Contains(t, cef, "rt=1704164645000")
+	assert.Contains(t, cef, "suser=user-123")
+	assert.Contains(t, cef, "src=10.0.0.1")
+	assert.Contains(t, cef, "cs1Label=tenantId cs1=tenant-123")
+	// Equals signs in extension values are escaped, pipes are not
+	assert.Contains(t, cef, `cs5={"name":"a\=b"}`)
+	assert.Contains(t, cef, `cs6={"name":"c|d"}`)
+}
+
+func TestFormatCEF_OmitsEmptyFields(t *testing.T) {
+	entry := createTestAuditLog()
+	entry.Action = models.AuditActionDelete
+	entry.Before = nil
+	entry.After = nil
+	entry.RequestID = ""
+
+	cef := FormatCEF(entry)
+
+	assert.Contains(t, cef, "|folder.delete|folder delete|5|")
+	assert.NotContains(t, cef, "cs4Label")
+	assert.NotContains(t, cef, "cs5Label")
+	assert.NotContains(t, cef, "cs6Label")
+}
+
+func TestEscapeCEF(t *testing.T) {
+	assert.Equal(t, `a\|b\\c`, escapeCEFHeader(`a|b\c`))
+	assert.Equal(t, `a\=b\nc\\d`, escapeCEFExtension("a=b\nc\\d"))
+}
+
+func TestNewCEFExporter_Validation(t *testing.T) {
+	_, err := NewCEFExporter(config.AuditSyslogConfig{})
+	assert.Error(t, err)
+
+	_, err = NewCEFExporter(config.AuditSyslogConfig{Address: "localhost:514", Network: "http"})
+	assert.Error(t, err)
+
+	_, err = NewCEFExporter(config.AuditSyslogConfig{Address: "localhost:514", Facility: 24})
+	assert.Error(t, err)
+}
+
+func TestCEFExporter_ExportTCP(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	received := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		// Read one octet-counted frame
+		reader := bufio.NewReader(conn)
+		length, err := reader.ReadString(' ')
+		if err != nil {
+			return
+		}
+		n, _ := strconv.Atoi(strings.TrimSpace(length))
+		frame := make([]byte, n)
+		if _, err := io.ReadFull(reader, frame); err != nil {
+			return
+		}
+		received <- string(frame)
+	}()
+
+	exporter, err := NewCEFExporter(config.AuditSyslogConfig{Network: "tcp", Address: listener.Addr().String(), AppName: "dmp"})
+	require.NoError(t, err)
+	defer exporter.Close()
+
+	require.NoError(t, exporter.Export(context.Background(), []*models.AuditLog{createTestAuditLog()}))
+
+	select {
+	case message := <-received:
+		// Facility 13 (log audit) with severity notice gives priority 109
+		assert.True(t, strings.HasPrefix(message, "<109>1 2024-01-02T03:04:05Z "))
+		assert.Contains(t, message, " dmp - - - CEF:0|")
+	case <-time.After(2 * time.Second):
+		t.Fatal("syslog message not received")
+	}
+}
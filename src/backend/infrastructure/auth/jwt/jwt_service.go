@@ -6,9 +6,12 @@ import (
 	"crypto/rsa"
 	"crypto/x509"
 	"encoding/pem"
+	stderrors "errors"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5" // v5.0.0+
+	"github.com/google/uuid"       // v1.3.0+
 
 	"../../../domain/models"
 	"../../../domain/repositories"
@@ -23,10 +26,18 @@ var (
 	defaultRefreshTokenExpiration = time.Hour * 24 * 7
 )
 
+// mfaTokenExpiration is how long a user has to verify their second factor after their password
+const mfaTokenExpiration = 5 * time.Minute
+
 // jwtService implements the auth.AuthService interface using JWT
 type jwtService struct {
 	userRepo               repositories.UserRepository
 	tenantRepo             repositories.TenantRepository
+	roleRepo               repositories.RoleRepository
+	permissionRepo         repositories.PermissionRepository
+	apiKeyRepo             repositories.APIKeyRepository
+	revocationRepo         repositories.TokenRevocationRepository
+	sessionRepo            repositories.SessionRepository
 	privateKey             *rsa.PrivateKey
 	publicKey              *rsa.PublicKey
 	issuer                 string
@@ -34,16 +45,41 @@ type jwtService struct {
 	refreshTokenExpiration time.Duration
 }
 
+// Token type claim values. Access tokens have no type claim.
+const (
+	refreshTokenType = "refresh"
+	guestTokenType   = "guest"
+	mfaTokenType     = "mfa"
+)
+
+// guestSubjectPrefix prefixes the guest's email in the subject claim of guest tokens
+const guestSubjectPrefix = "guest:"
+
 // customClaims defines the JWT claims structure
 type customClaims struct {
 	jwt.RegisteredClaims
-	TenantID string   `json:"tenant_id"`
-	Roles    []string `json:"roles,omitempty"`
-	Type     string   `json:"type,omitempty"`
+	TenantID  string       `json:"tenant_id"`
+	Roles     []string     `json:"roles,omitempty"`
+	Type      string       `json:"type,omitempty"`
+	SessionID string       `json:"sid,omitempty"`
+	Guest     *guestClaims `json:"guest,omitempty"`
+
+	// AuthTime is when the user signed in to the session, kept across refreshes. Folders whose
+	// download policy requires a recent sign-in compare it with their limit.
+	AuthTime *jwt.NumericDate `json:"auth_time,omitempty"`
+}
+
+// guestClaims limits a guest token to a single resource and permission set
+type guestClaims struct {
+	Email        string   `json:"email"`
+	ResourceType string   `json:"resource_type"`
+	ResourceID   string   `json:"resource_id"`
+	Permissions  []string `json:"permissions"`
+	InvitedBy    string   `json:"invited_by"`
 }
 
 // NewJWTService creates a new JWT authentication service
-func NewJWTService(userRepo repositories.UserRepository, tenantRepo repositories.TenantRepository, cfg config.JWTConfig) (services.AuthService, error) {
+func NewJWTService(userRepo repositories.UserRepository, tenantRepo repositories.TenantRepository, roleRepo repositories.RoleRepository, permissionRepo repositories.PermissionRepository, apiKeyRepo repositories.APIKeyRepository, revocationRepo repositories.TokenRevocationRepository, sessionRepo repositories.SessionRepository, cfg config.JWTConfig) (services.AuthService, error) {
 	// Validate input parameters
 	if userRepo == nil {
 		return nil, errors.NewValidationError("user repository is required")
@@ -51,6 +87,21 @@ func NewJWTService(userRepo repositories.UserRepository, tenantRepo repositories
 	if tenantRepo == nil {
 		return nil, errors.NewValidationError("tenant repository is required")
 	}
+	if roleRepo == nil {
+		return nil, errors.NewValidationError("role repository is required")
+	}
+	if permissionRepo == nil {
+		return nil, errors.NewValidationError("permission repository is required")
+	}
+	if apiKeyRepo == nil {
+		return nil, errors.NewValidationError("API key repository is required")
+	}
+	if revocationRepo == nil {
+		return nil, errors.NewValidationError("token revocation repository is required")
+	}
+	if sessionRepo == nil {
+		return nil, errors.NewValidationError("session repository is required")
+	}
 
 	// Parse private key from PEM format
 	privateKeyBlock, _ := pem.Decode([]byte(cfg.PrivateKey))
@@ -76,6 +127,11 @@ func NewJWTService(userRepo repositories.UserRepository, tenantRepo repositories
 	service := &jwtService{
 		userRepo:               userRepo,
 		tenantRepo:             tenantRepo,
+		roleRepo:               roleRepo,
+		permissionRepo:         permissionRepo,
+		apiKeyRepo:             apiKeyRepo,
+		revocationRepo:         revocationRepo,
+		sessionRepo:            sessionRepo,
 		privateKey:             privateKey,
 		publicKey:              publicKey,
 		issuer:                 cfg.Issuer,
@@ -186,6 +242,23 @@ func (s *jwtService) ValidateToken(ctx context.Context, token string) (string, [
 		return "", nil, err
 	}
 
+	// Reject tokens that were revoked before they expired, individually or with their session
+	tokenID, _ := claims["jti"].(string)
+	if err := s.checkRevoked(ctx, tokenID); err != nil {
+		return "", nil, err
+	}
+	if sessionID, _ := claims["sid"].(string); sessionID != "" {
+		if err := s.checkRevoked(ctx, sessionID); err != nil {
+			return "", nil, err
+		}
+	}
+
+	// Guest tokens only grant access to their shared resource, and partial-auth tokens only to
+	// second factor verification, never to the API as a user
+	if tokenType, _ := claims["type"].(string); tokenType == guestTokenType || tokenType == mfaTokenType {
+		return "", nil, errors.NewAuthenticationError("invalid token type")
+	}
+
 	// Extract user ID, tenant ID, and roles
 	userID, ok := claims["sub"].(string)
 	if !ok || userID == "" {
@@ -258,9 +331,15 @@ func (s *jwtService) RefreshToken(ctx context.Context, refreshToken string) (str
 		return "", err
 	}
 
+	// Reject refresh tokens that were revoked before they expired
+	tokenID, _ := claims["jti"].(string)
+	if err := s.checkRevoked(ctx, tokenID); err != nil {
+		return "", err
+	}
+
 	// Check token type is refresh
 	tokenType, ok := claims["type"].(string)
-	if !ok || tokenType != "refresh" {
+	if !ok || tokenType != refreshTokenType {
 		return "", errors.NewAuthenticationError("invalid token type")
 	}
 
@@ -301,27 +380,74 @@ func (s *jwtService) RefreshToken(ctx context.Context, refreshToken string) (str
 		return "", errors.NewAuthenticationError("tenant is not active")
 	}
 
-	// Generate new tokens
-	accessToken, err := s.GenerateToken(ctx, user.ID, user.TenantID, user.Roles, s.tokenExpiration)
+	// Refresh tokens issued before sessions existed start a session
+	sessionID, _ := claims["sid"].(string)
+	if sessionID == "" {
+		_, newRefreshToken, err := s.CreateSession(ctx, user.ID, user.TenantID, user.Roles)
+		if err != nil {
+			return "", err
+		}
+		return newRefreshToken, nil
+	}
+
+	// Verify the session is still active
+	if err := s.checkRevoked(ctx, sessionID); err != nil {
+		return "", err
+	}
+	session, err := s.sessionRepo.GetByID(ctx, sessionID, tenantID)
 	if err != nil {
-		return "", errors.Wrap(err, "failed to generate access token")
+		if errors.IsResourceNotFoundError(err) {
+			return "", errors.NewAuthenticationError("session not found")
+		}
+		return "", errors.Wrap(err, "failed to get session")
+	}
+	if !session.IsActive(time.Now()) {
+		return "", errors.NewAuthenticationError("session is no longer active")
 	}
 
-	newRefreshToken, err := s.GenerateRefreshToken(ctx, user.ID, user.TenantID, s.refreshTokenExpiration)
+	// Generate new tokens within the session
+	_, newRefreshToken, err := s.issueSessionTokens(session, user.Roles)
 	if err != nil {
-		return "", errors.Wrap(err, "failed to generate refresh token")
+		return "", err
+	}
+
+	if err := s.sessionRepo.Extend(ctx, session.ID, session.TenantID, time.Now(), session.ExpiresAt); err != nil {
+		return "", errors.Wrap(err, "failed to extend session")
 	}
 
 	// Return new refresh token as specified in the interface
 	return newRefreshToken, nil
 }
 
-// InvalidateToken invalidates a token (logout)
+// InvalidateToken invalidates a token (logout) by adding its ID to the revocation list until it expires.
+// Invalidating a token of a session revokes the session, so its other tokens stop working too.
 func (s *jwtService) InvalidateToken(ctx context.Context, token string) error {
-	// JWT is stateless by design and cannot be invalidated without maintaining a blacklist
-	// In a production system, you would typically implement a token blacklist using Redis
-	// or another data store to track invalidated tokens until they expire
-	// For now, this is essentially a no-op since the interface expects it
+	// Parse and validate token
+	claims := &customClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, s.keyFunc, jwt.WithIssuer(s.issuer), jwt.WithExpirationRequired())
+	if err != nil {
+		// An expired token is rejected anyway, so there is nothing left to revoke
+		if stderrors.Is(err, jwt.ErrTokenExpired) {
+			return nil
+		}
+		return errors.NewAuthenticationError("invalid token: " + err.Error())
+	}
+
+	if claims.ID == "" {
+		return errors.NewAuthenticationError("invalid token: missing token ID")
+	}
+
+	if err := s.revocationRepo.Revoke(ctx, claims.ID, claims.ExpiresAt.Time); err != nil {
+		return errors.Wrap(err, "failed to revoke token")
+	}
+
+	if claims.SessionID != "" {
+		err := s.RevokeSession(ctx, claims.SessionID, claims.TenantID)
+		if err != nil && !errors.IsResourceNotFoundError(err) {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -337,34 +463,30 @@ func (s *jwtService) VerifyPermission(ctx context.Context, userID, tenantID, per
 	if permission == "" {
 		return false, errors.NewValidationError("permission is required")
 	}
+	if !models.IsValidRolePermission(permission) {
+		return false, errors.NewValidationError("invalid permission: " + permission)
+	}
 
-	// Get user from repository
-	user, err := s.userRepo.GetByID(ctx, userID, tenantID)
+	// Resolve the user or API key the check is for
+	p, err := s.getPrincipal(ctx, userID, tenantID)
 	if err != nil {
-		if errors.IsResourceNotFoundError(err) {
-			return false, nil // User not found, no permission
-		}
-		return false, errors.Wrap(err, "failed to get user")
+		return false, err
+	}
+	if p == nil {
+		return false, nil // Unknown principal or not in the tenant, no permission
 	}
 
-	// Verify user belongs to the specified tenant
-	if user.TenantID != tenantID {
-		return false, nil // User doesn't belong to the tenant, no permission
-	}
-
-	// Check permission based on user roles
-	switch permission {
-	case services.PermissionRead:
-		return user.CanRead(), nil
-	case services.PermissionWrite:
-		return user.CanWrite(), nil
-	case services.PermissionDelete:
-		return user.CanDelete(), nil
-	case services.PermissionManageFolders:
-		return user.CanManageFolders(), nil
-	default:
-		return false, errors.NewValidationError("invalid permission: " + permission)
+	// Evaluate the permissions granted by the principal's roles in the tenant
+	permissions, err := s.roleRepo.GetPermissionsForRoles(ctx, tenantID, p.roles)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get role permissions")
 	}
+	for _, granted := range permissions {
+		if granted == permission {
+			return true, nil
+		}
+	}
+	return false, nil
 }
 
 // VerifyResourceAccess verifies if a user has access to a specific resource
@@ -386,32 +508,45 @@ func (s *jwtService) VerifyResourceAccess(ctx context.Context, userID, tenantID,
 		return false, errors.NewValidationError("access type is required")
 	}
 
-	// First, verify tenant context (user belongs to the tenant)
-	hasTenantAccess, err := s.VerifyTenantAccess(ctx, userID, tenantID)
+	// Access types are role permission names
+	if !models.IsValidRolePermission(accessType) {
+		return false, errors.NewValidationError("invalid access type: " + accessType)
+	}
+
+	// First, verify tenant context (user or API key belongs to the tenant)
+	p, err := s.getPrincipal(ctx, userID, tenantID)
 	if err != nil {
 		return false, err
 	}
-	if !hasTenantAccess {
-		return false, nil // User doesn't belong to the tenant, no access
-	}
-
-	// Map access type to permission
-	var permission string
-	switch accessType {
-	case "read":
-		permission = services.PermissionRead
-	case "write":
-		permission = services.PermissionWrite
-	case "delete":
-		permission = services.PermissionDelete
-	case "manage_folders":
-		permission = services.PermissionManageFolders
-	default:
-		return false, errors.NewValidationError("invalid access type: " + accessType)
+	if p == nil {
+		return false, nil // Unknown principal or not in the tenant, no access
 	}
 
-	// Check if user has the required permission
-	return s.VerifyPermission(ctx, userID, tenantID, permission)
+	// Resolve grants on the resource: direct user grants, then group grants, then role grants
+	roleIDs, err := s.roleRepo.GetIDsByNames(ctx, tenantID, p.roles)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get role IDs")
+	}
+	grantees := []struct {
+		granteeType string
+		granteeIDs  []string
+	}{
+		{models.GranteeTypeUser, p.userIDs},
+		{models.GranteeTypeGroup, p.groups},
+		{models.GranteeTypeRole, roleIDs},
+	}
+	for _, grantee := range grantees {
+		granted, err := s.permissionRepo.CheckGranteePermission(ctx, grantee.granteeType, grantee.granteeIDs, resourceType, resourceID, accessType, tenantID)
+		if err != nil {
+			return false, errors.Wrap(err, "failed to check "+grantee.granteeType+" grants")
+		}
+		if granted {
+			return true, nil
+		}
+	}
+
+	// Fall back to the permissions the user's roles grant across the tenant
+	return s.VerifyPermission(ctx, userID, tenantID, accessType)
 }
 
 // VerifyTenantAccess verifies if a user belongs to a specific tenant
@@ -424,17 +559,12 @@ func (s *jwtService) VerifyTenantAccess(ctx context.Context, userID, tenantID st
 		return false, errors.NewValidationError("tenant ID is required")
 	}
 
-	// Get user from repository
-	user, err := s.userRepo.GetByID(ctx, userID, tenantID)
+	// Resolve the user or API key; unknown principals and principals of other tenants have no access
+	p, err := s.getPrincipal(ctx, userID, tenantID)
 	if err != nil {
-		if errors.IsResourceNotFoundError(err) {
-			return false, nil // User not found, no access
-		}
-		return false, errors.Wrap(err, "failed to get user")
+		return false, err
 	}
-
-	// Check if user belongs to the tenant
-	return user.TenantID == tenantID, nil
+	return p != nil, nil
 }
 
 // GenerateToken generates a new access token for a user
@@ -457,6 +587,7 @@ func (s *jwtService) GenerateToken(ctx context.Context, userID, tenantID string,
 	now := time.Now()
 	claims := customClaims{
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.New().String(),
 			Subject:   userID,
 			IssuedAt:  jwt.NewNumericDate(now),
 			ExpiresAt: jwt.NewNumericDate(now.Add(expiration)),
@@ -493,13 +624,14 @@ func (s *jwtService) GenerateRefreshToken(ctx context.Context, userID, tenantID
 	now := time.Now()
 	claims := customClaims{
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.New().String(),
 			Subject:   userID,
 			IssuedAt:  jwt.NewNumericDate(now),
 			ExpiresAt: jwt.NewNumericDate(now.Add(expiration)),
 			Issuer:    s.issuer,
 		},
 		TenantID: tenantID,
-		Type:     "refresh", // Mark as refresh token
+		Type:     refreshTokenType, // Mark as refresh token
 	}
 
 	// Create and sign the token
@@ -512,6 +644,190 @@ func (s *jwtService) GenerateRefreshToken(ctx context.Context, userID, tenantID
 	return signedToken, nil
 }
 
+// GenerateGuestToken generates a read-only, time-boxed token limited to the scope's document or folder
+func (s *jwtService) GenerateGuestToken(ctx context.Context, scope *models.GuestScope) (string, error) {
+	if scope == nil {
+		return "", errors.NewValidationError("guest scope is required")
+	}
+	if err := scope.Validate(); err != nil {
+		return "", errors.NewValidationError(err.Error())
+	}
+
+	// Create token with claims; the guest has no user ID, so the subject identifies the guest by email
+	claims := customClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.New().String(),
+			Subject:   guestSubjectPrefix + scope.GuestEmail,
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(scope.ExpiresAt),
+			Issuer:    s.issuer,
+		},
+		TenantID: scope.TenantID,
+		Type:     guestTokenType,
+		Guest: &guestClaims{
+			Email:        scope.GuestEmail,
+			ResourceType: scope.ResourceType,
+			ResourceID:   scope.ResourceID,
+			Permissions:  scope.Permissions,
+			InvitedBy:    scope.InvitedBy,
+		},
+	}
+
+	// Create and sign the token
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	signedToken, err := token.SignedString(s.privateKey)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to sign guest token")
+	}
+
+	return signedToken, nil
+}
+
+// ValidateGuestToken validates a guest token and extracts the scope it is limited to
+func (s *jwtService) ValidateGuestToken(ctx context.Context, token string) (*models.GuestScope, error) {
+	// Parse and validate token
+	claims := &customClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, s.keyFunc, jwt.WithIssuer(s.issuer), jwt.WithExpirationRequired(), jwt.WithIssuedAt())
+	if err != nil {
+		return nil, errors.NewAuthenticationError("invalid guest token: " + err.Error())
+	}
+
+	// Check token type is guest and the scope is present
+	if claims.Type != guestTokenType || claims.Guest == nil || !strings.HasPrefix(claims.Subject, guestSubjectPrefix) {
+		return nil, errors.NewAuthenticationError("invalid token type")
+	}
+
+	scope := &models.GuestScope{
+		TenantID:     claims.TenantID,
+		GuestEmail:   claims.Guest.Email,
+		ResourceType: claims.Guest.ResourceType,
+		ResourceID:   claims.Guest.ResourceID,
+		Permissions:  claims.Guest.Permissions,
+		InvitedBy:    claims.Guest.InvitedBy,
+		ExpiresAt:    claims.ExpiresAt.Time,
+	}
+	if err := scope.Validate(); err != nil {
+		return nil, errors.NewAuthenticationError("invalid guest token: " + err.Error())
+	}
+
+	// Reject guest tokens that were revoked before they expired
+	if err := s.checkRevoked(ctx, claims.ID); err != nil {
+		return nil, err
+	}
+
+	// Verify tenant exists and is active
+	tenant, err := s.tenantRepo.GetByID(ctx, scope.TenantID)
+	if err != nil {
+		if errors.IsResourceNotFoundError(err) {
+			return nil, errors.NewAuthenticationError("tenant not found")
+		}
+		return nil, errors.Wrap(err, "failed to get tenant")
+	}
+
+	if tenant.Status != "active" {
+		return nil, errors.NewAuthenticationError("tenant is not active")
+	}
+
+	return scope, nil
+}
+
+// CreateSession starts a session for a signed-in user on the client of the request and issues its tokens
+func (s *jwtService) CreateSession(ctx context.Context, userID, tenantID string, roles []string) (string, string, error) {
+	// Validate inputs
+	if userID == "" {
+		return "", "", errors.NewValidationError("user ID is required")
+	}
+	if tenantID == "" {
+		return "", "", errors.NewValidationError("tenant ID is required")
+	}
+
+	// The client is only known on requests that went through the audit context middleware
+	actor, _ := services.AuditActorFromContext(ctx)
+	session := models.NewSession(userID, tenantID, actor.IPAddress, actor.UserAgent, time.Now().Add(s.refreshTokenExpiration))
+	if _, err := s.sessionRepo.Create(ctx, session); err != nil {
+		return "", "", errors.Wrap(err, "failed to create session")
+	}
+
+	return s.issueSessionTokens(session, roles)
+}
+
+// RevokeSession revokes a session so that none of its tokens are accepted anymore
+func (s *jwtService) RevokeSession(ctx context.Context, sessionID, tenantID string) error {
+	session, err := s.sessionRepo.GetByID(ctx, sessionID, tenantID)
+	if err != nil {
+		return err
+	}
+
+	// The revocation list is what token validation checks; the session's tokens expire by its expiry
+	if session.ExpiresAt.After(time.Now()) {
+		if err := s.revocationRepo.Revoke(ctx, session.ID, session.ExpiresAt); err != nil {
+			return errors.Wrap(err, "failed to revoke session")
+		}
+	}
+
+	if err := s.sessionRepo.Revoke(ctx, session.ID, session.TenantID); err != nil {
+		return errors.Wrap(err, "failed to revoke session")
+	}
+
+	return nil
+}
+
+// GenerateMFAToken generates a short-lived partial-auth token for a user who still has to verify their second factor
+func (s *jwtService) GenerateMFAToken(ctx context.Context, userID, tenantID string) (string, error) {
+	// Validate inputs
+	if userID == "" {
+		return "", errors.NewValidationError("user ID is required")
+	}
+	if tenantID == "" {
+		return "", errors.NewValidationError("tenant ID is required")
+	}
+
+	// Create token with claims; the token carries no roles
+	now := time.Now()
+	claims := customClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.New().String(),
+			Subject:   userID,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(mfaTokenExpiration)),
+			Issuer:    s.issuer,
+		},
+		TenantID: tenantID,
+		Type:     mfaTokenType,
+	}
+
+	// Create and sign the token
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	signedToken, err := token.SignedString(s.privateKey)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to sign MFA token")
+	}
+
+	return signedToken, nil
+}
+
+// ValidateMFAToken validates a partial-auth token and extracts the user it was issued to
+func (s *jwtService) ValidateMFAToken(ctx context.Context, token string) (string, string, error) {
+	// Parse and validate token
+	claims := &customClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, s.keyFunc, jwt.WithIssuer(s.issuer), jwt.WithExpirationRequired(), jwt.WithIssuedAt())
+	if err != nil {
+		return "", "", errors.NewAuthenticationError("invalid MFA token: " + err.Error())
+	}
+
+	// Check token type is mfa and the user is identified
+	if claims.Type != mfaTokenType || claims.Subject == "" || claims.TenantID == "" {
+		return "", "", errors.NewAuthenticationError("invalid token type")
+	}
+
+	// Reject partial-auth tokens that were already used or revoked
+	if err := s.checkRevoked(ctx, claims.ID); err != nil {
+		return "", "", err
+	}
+
+	return claims.Subject, claims.TenantID, nil
+}
+
 // SetTokenExpiration sets the token expiration duration
 func (s *jwtService) SetTokenExpiration(expiration time.Duration) {
 	if expiration > 0 {
@@ -526,16 +842,47 @@ func (s *jwtService) SetRefreshTokenExpiration(expiration time.Duration) {
 	}
 }
 
+// principal is the identity a permission check is evaluated for: a user, or an API key acting with its role
+type principal struct {
+	userIDs []string // IDs matched against direct user grants; empty for API keys
+	groups  []string
+	roles   []string
+}
+
+// getPrincipal resolves a user ID, or an API key principal ID, to the identity's grants in the tenant.
+// It returns nil when the principal does not exist, belongs to another tenant or is an unusable API key.
+func (s *jwtService) getPrincipal(ctx context.Context, userID, tenantID string) (*principal, error) {
+	if keyID, ok := models.IsAPIKeyPrincipal(userID); ok {
+		key, err := s.apiKeyRepo.GetByID(ctx, keyID, tenantID)
+		if err != nil {
+			if errors.IsResourceNotFoundError(err) {
+				return nil, nil
+			}
+			return nil, errors.Wrap(err, "failed to get API key")
+		}
+		if key.TenantID != tenantID || !key.IsUsable(time.Now()) {
+			return nil, nil
+		}
+		return &principal{roles: []string{key.Role}}, nil
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID, tenantID)
+	if err != nil {
+		if errors.IsResourceNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to get user")
+	}
+	if user.TenantID != tenantID {
+		return nil, nil
+	}
+	return &principal{userIDs: []string{user.ID}, groups: user.Groups, roles: user.Roles}, nil
+}
+
 // parseToken is an internal helper to parse and validate a JWT token
 func (s *jwtService) parseToken(tokenString string) (*jwt.Token, error) {
 	// Parse the token with the public key
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		// Validate the signing method is RS256
-		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
-			return nil, errors.NewAuthenticationError("unexpected signing method: " + token.Method.Alg())
-		}
-		return s.publicKey, nil
-	})
+	token, err := jwt.Parse(tokenString, s.keyFunc)
 
 	if err != nil {
 		return nil, err
@@ -544,6 +891,83 @@ func (s *jwtService) parseToken(tokenString string) (*jwt.Token, error) {
 	return token, nil
 }
 
+// keyFunc is an internal helper that returns the public key tokens are verified with
+func (s *jwtService) keyFunc(token *jwt.Token) (interface{}, error) {
+	// Validate the signing method is RS256
+	if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
+		return nil, errors.NewAuthenticationError("unexpected signing method: " + token.Method.Alg())
+	}
+	return s.publicKey, nil
+}
+
+// issueSessionTokens is an internal helper that generates an access token and a refresh token in a
+// session. The refresh token expires with the session.
+func (s *jwtService) issueSessionTokens(session *models.Session, roles []string) (string, string, error) {
+	if roles == nil {
+		roles = []string{} // Default to empty array if not provided
+	}
+
+	now := time.Now()
+	accessToken, err := s.signClaims(customClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.New().String(),
+			Subject:   session.UserID,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(s.tokenExpiration)),
+			Issuer:    s.issuer,
+		},
+		TenantID:  session.TenantID,
+		Roles:     roles,
+		SessionID: session.ID,
+		AuthTime:  jwt.NewNumericDate(session.CreatedAt),
+	})
+	if err != nil {
+		return "", "", errors.Wrap(err, "failed to sign token")
+	}
+
+	session.ExpiresAt = now.Add(s.refreshTokenExpiration)
+	refreshToken, err := s.signClaims(customClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.New().String(),
+			Subject:   session.UserID,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(session.ExpiresAt),
+			Issuer:    s.issuer,
+		},
+		TenantID:  session.TenantID,
+		Type:      refreshTokenType,
+		SessionID: session.ID,
+	})
+	if err != nil {
+		return "", "", errors.Wrap(err, "failed to sign refresh token")
+	}
+
+	return accessToken, refreshToken, nil
+}
+
+// signClaims is an internal helper that creates and signs a token with the given claims
+func (s *jwtService) signClaims(claims customClaims) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	return token.SignedString(s.privateKey)
+}
+
+// checkRevoked is an internal helper that rejects tokens without an ID and tokens on the revocation list
+func (s *jwtService) checkRevoked(ctx context.Context, tokenID string) error {
+	if tokenID == "" {
+		return errors.NewAuthenticationError("invalid token: missing token ID")
+	}
+
+	revoked, err := s.revocationRepo.IsRevoked(ctx, tokenID)
+	if err != nil {
+		return errors.Wrap(err, "failed to check token revocation")
+	}
+	if revoked {
+		return errors.NewAuthenticationError("token has been revoked")
+	}
+
+	return nil
+}
+
 // validateClaims is an internal helper to validate token claims
 func (s *jwtService) validateClaims(claims jwt.MapClaims) error {
 	// Check required claims are present
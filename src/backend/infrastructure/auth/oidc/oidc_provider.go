@@ -0,0 +1,213 @@
+// Package oidc provides an IdentityProvider that verifies ID tokens issued by external
+// OpenID Connect identity providers such as Okta and Azure AD.
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc" // v3.6.0+
+
+	"../../../domain/models"
+	"../../../domain/services"
+	"../../../pkg/config"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+)
+
+// Default claim names, matching the standard OIDC claims and the Okta and Azure AD groups claim
+const (
+	defaultUsernameClaim = "preferred_username"
+	defaultEmailClaim    = "email"
+	defaultGroupsClaim   = "groups"
+)
+
+// discoveryTimeout bounds provider discovery and signing key retrieval
+const discoveryTimeout = 10 * time.Second
+
+// oidcProvider implements services.IdentityProvider for the providers configured per tenant
+type oidcProvider struct {
+	providers map[string]config.OIDCProviderConfig
+
+	// verifiers are created on first use, so an unreachable IdP does not prevent startup
+	mu        sync.Mutex
+	verifiers map[string]*oidc.IDTokenVerifier
+}
+
+// NewOIDCProvider creates an IdentityProvider for the configured tenant identity providers
+func NewOIDCProvider(cfg config.OIDCConfig) (services.IdentityProvider, error) {
+	providers := make(map[string]config.OIDCProviderConfig, len(cfg.Providers))
+	for _, provider := range cfg.Providers {
+		if provider.TenantID == "" {
+			return nil, errors.NewValidationError("oidc provider tenant ID cannot be empty")
+		}
+		if provider.Issuer == "" {
+			return nil, errors.NewValidationError("oidc provider issuer cannot be empty for tenant " + provider.TenantID)
+		}
+		if provider.ClientID == "" {
+			return nil, errors.NewValidationError("oidc provider client ID cannot be empty for tenant " + provider.TenantID)
+		}
+		if _, exists := providers[provider.TenantID]; exists {
+			return nil, errors.NewValidationError("tenant " + provider.TenantID + " has more than one oidc provider")
+		}
+
+		// The system role is reserved for internal services and cannot be obtained through an IdP
+		for group, role := range provider.RoleMappings {
+			if role == models.RoleSystem {
+				return nil, errors.NewValidationError("oidc group " + group + " cannot map to the system role")
+			}
+		}
+		if provider.DefaultRole == models.RoleSystem {
+			return nil, errors.NewValidationError("oidc default role cannot be the system role")
+		}
+
+		if provider.UsernameClaim == "" {
+			provider.UsernameClaim = defaultUsernameClaim
+		}
+		if provider.EmailClaim == "" {
+			provider.EmailClaim = defaultEmailClaim
+		}
+		if provider.GroupsClaim == "" {
+			provider.GroupsClaim = defaultGroupsClaim
+		}
+		providers[provider.TenantID] = provider
+	}
+
+	return &oidcProvider{
+		providers: providers,
+		verifiers: make(map[string]*oidc.IDTokenVerifier),
+	}, nil
+}
+
+// VerifyIDToken verifies an ID token issued by the tenant's provider and maps its claims
+func (p *oidcProvider) VerifyIDToken(ctx context.Context, tenantID, rawIDToken string) (*models.ExternalIdentity, error) {
+	provider, ok := p.providers[tenantID]
+	if !ok {
+		return nil, errors.NewAuthenticationError("single sign-on is not configured for this tenant")
+	}
+
+	verifier, err := p.verifier(provider)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify signature, issuer, audience and expiry
+	idToken, err := verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		logger.WarnContext(ctx, "ID token verification failed", "tenant_id", tenantID, "error", err.Error())
+		return nil, errors.NewAuthenticationError("invalid ID token")
+	}
+
+	var claims map[string]interface{}
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, errors.NewAuthenticationError("invalid ID token claims")
+	}
+
+	// An email the IdP explicitly marks as unverified cannot be used to match an account
+	verified, ok := claims["email_verified"].(bool)
+	if ok && !verified {
+		return nil, errors.NewAuthenticationError("ID token email is not verified")
+	}
+
+	identity := &models.ExternalIdentity{
+		TenantID:      tenantID,
+		Issuer:        idToken.Issuer,
+		Subject:       idToken.Subject,
+		Email:         strings.ToLower(stringClaim(claims, provider.EmailClaim)),
+		EmailVerified: verified,
+		Username:      stringClaim(claims, provider.UsernameClaim),
+		Groups:        stringsClaim(claims, provider.GroupsClaim),
+	}
+
+	// Azure AD omits the email claim for some accounts; its preferred_username is then the UPN,
+	// which email_verified does not cover
+	if identity.Email == "" && strings.Contains(identity.Username, "@") {
+		identity.Email = strings.ToLower(identity.Username)
+		identity.EmailVerified = false
+	}
+	if identity.Username == "" {
+		identity.Username = identity.Email
+	}
+
+	identity.Roles = mapRoles(identity.Groups, provider.RoleMappings)
+
+	if err := identity.Validate(); err != nil {
+		return nil, errors.NewAuthenticationError("ID token does not identify a user: " + err.Error())
+	}
+
+	return identity, nil
+}
+
+// ProvisioningPolicy reports whether the tenant's provider creates users on first sign-in
+func (p *oidcProvider) ProvisioningPolicy(tenantID string) (bool, string) {
+	provider, ok := p.providers[tenantID]
+	if !ok {
+		return false, ""
+	}
+	return provider.JITProvisioning, provider.DefaultRole
+}
+
+// verifier returns the ID token verifier of a provider, discovering the provider on first use
+func (p *oidcProvider) verifier(provider config.OIDCProviderConfig) (*oidc.IDTokenVerifier, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if verifier, ok := p.verifiers[provider.TenantID]; ok {
+		return verifier, nil
+	}
+
+	// Discovery runs outside any request context because the provider keeps using its context
+	// to refresh signing keys after key rotation
+	discoveryCtx := oidc.ClientContext(context.Background(), &http.Client{Timeout: discoveryTimeout})
+	discovered, err := oidc.NewProvider(discoveryCtx, provider.Issuer)
+	if err != nil {
+		logger.Error("Failed to discover OIDC provider", "issuer", provider.Issuer, "error", err.Error())
+		return nil, errors.NewDependencyError("identity provider is unavailable")
+	}
+
+	verifier := discovered.Verifier(&oidc.Config{ClientID: provider.ClientID})
+	p.verifiers[provider.TenantID] = verifier
+	return verifier, nil
+}
+
+// mapRoles maps IdP groups to tenant roles, ignoring unmapped groups and duplicate roles
+func mapRoles(groups []string, mappings map[string]string) []string {
+	roles := []string{}
+	seen := make(map[string]bool)
+	for _, group := range groups {
+		role, ok := mappings[group]
+		if !ok || seen[role] {
+			continue
+		}
+		seen[role] = true
+		roles = append(roles, role)
+	}
+	return roles
+}
+
+// stringClaim returns a string claim, or an empty string when absent
+func stringClaim(claims map[string]interface{}, name string) string {
+	value, _ := claims[name].(string)
+	return strings.TrimSpace(value)
+}
+
+// stringsClaim returns a claim holding a list of strings; a single string is a list of one
+func stringsClaim(claims map[string]interface{}, name string) []string {
+	switch value := claims[name].(type) {
+	case string:
+		return []string{value}
+	case []interface{}:
+		values := make([]string, 0, len(value))
+		for _, item := range value {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	default:
+		return []string{}
+	}
+}
@@ -0,0 +1,171 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/suite"
+
+	"../../../domain/models"
+	"../../../pkg/config"
+	"../../../pkg/errors"
+)
+
+const (
+	testIssuer   = "https://idp.example.com"
+	testClientID = "document-mgmt"
+	testTenantID = "tenant-123"
+)
+
+// OIDCProviderTestSuite tests ID token verification and claim mapping against a static signing key
+type OIDCProviderTestSuite struct {
+	suite.Suite
+	signingKey *rsa.PrivateKey
+	provider   *oidcProvider
+}
+
+// SetupSuite generates the IdP signing key
+func (s *OIDCProviderTestSuite) SetupSuite() {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	s.Require().NoError(err)
+	s.signingKey = key
+}
+
+// SetupTest creates a provider whose verifier trusts the test signing key instead of discovering the IdP
+func (s *OIDCProviderTestSuite) SetupTest() {
+	provider, err := NewOIDCProvider(config.OIDCConfig{
+		Providers: []config.OIDCProviderConfig{{
+			TenantID: testTenantID,
+			Issuer:   testIssuer,
+			ClientID: testClientID,
+			RoleMappings: map[string]string{
+				"dms-admins":  models.RoleAdministrator,
+				"dms-editors": models.RoleEditor,
+			},
+			DefaultRole:     models.RoleReader,
+			JITProvisioning: true,
+		}},
+	})
+	s.Require().NoError(err)
+
+	s.provider = provider.(*oidcProvider)
+	s.provider.verifiers[testTenantID] = oidc.NewVerifier(testIssuer,
+		&oidc.StaticKeySet{PublicKeys: []crypto.PublicKey{&s.signingKey.PublicKey}},
+		&oidc.Config{ClientID: testClientID})
+}
+
+// signIDToken signs an ID token with the given claims on top of valid registered claims
+func (s *OIDCProviderTestSuite) signIDToken(claims jwt.MapClaims) string {
+	token := jwt.MapClaims{
+		"iss": testIssuer,
+		"aud": testClientID,
+		"sub": "00u1abcd",
+		"iat": time.Now().Unix(),
+		"exp": time.Now().Add(5 * time.Minute).Unix(),
+	}
+	for name, value := range claims {
+		token[name] = value
+	}
+
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodRS256, token).SignedString(s.signingKey)
+	s.Require().NoError(err)
+	return signed
+}
+
+// TestVerifyIDToken_MapsGroupsToRoles tests that mapped groups become roles and unmapped ones are ignored
+func (s *OIDCProviderTestSuite) TestVerifyIDToken_MapsGroupsToRoles() {
+	rawToken := s.signIDToken(jwt.MapClaims{
+		"email":              "Jane.Doe@Example.com",
+		"email_verified":     true,
+		"preferred_username": "jane.doe",
+		"groups":             []string{"dms-editors", "everyone", "dms-admins"},
+	})
+
+	identity, err := s.provider.VerifyIDToken(context.Background(), testTenantID, rawToken)
+
+	s.Require().NoError(err)
+	s.Equal("00u1abcd", identity.Subject)
+	s.Equal("jane.doe@example.com", identity.Email)
+	s.True(identity.EmailVerified)
+	s.Equal("jane.doe", identity.Username)
+	s.Equal([]string{"dms-editors", "everyone", "dms-admins"}, identity.Groups)
+	s.Equal([]string{models.RoleEditor, models.RoleAdministrator}, identity.Roles)
+}
+
+// TestVerifyIDToken_UPNFallback tests that an Azure AD UPN is used when the token has no email claim
+func (s *OIDCProviderTestSuite) TestVerifyIDToken_UPNFallback() {
+	rawToken := s.signIDToken(jwt.MapClaims{"preferred_username": "jane.doe@example.com"})
+
+	identity, err := s.provider.VerifyIDToken(context.Background(), testTenantID, rawToken)
+
+	s.Require().NoError(err)
+	s.Equal("jane.doe@example.com", identity.Email)
+	s.False(identity.EmailVerified)
+	s.Empty(identity.Roles)
+}
+
+// TestVerifyIDToken_EmailWithoutVerifiedClaim tests that an email is not taken as verified without the claim
+func (s *OIDCProviderTestSuite) TestVerifyIDToken_EmailWithoutVerifiedClaim() {
+	rawToken := s.signIDToken(jwt.MapClaims{"email": "jane.doe@example.com"})
+
+	identity, err := s.provider.VerifyIDToken(context.Background(), testTenantID, rawToken)
+
+	s.Require().NoError(err)
+	s.Equal("jane.doe@example.com", identity.Email)
+	s.False(identity.EmailVerified)
+}
+
+// TestVerifyIDToken_WrongAudience tests that tokens issued to another client are rejected
+func (s *OIDCProviderTestSuite) TestVerifyIDToken_WrongAudience() {
+	rawToken := s.signIDToken(jwt.MapClaims{"aud": "another-app", "email": "jane.doe@example.com"})
+
+	identity, err := s.provider.VerifyIDToken(context.Background(), testTenantID, rawToken)
+
+	s.Nil(identity)
+	s.True(errors.IsAuthenticationError(err))
+}
+
+// TestVerifyIDToken_UnverifiedEmail tests that an email the IdP has not verified is rejected
+func (s *OIDCProviderTestSuite) TestVerifyIDToken_UnverifiedEmail() {
+	rawToken := s.signIDToken(jwt.MapClaims{"email": "jane.doe@example.com", "email_verified": false})
+
+	identity, err := s.provider.VerifyIDToken(context.Background(), testTenantID, rawToken)
+
+	s.Nil(identity)
+	s.True(errors.IsAuthenticationError(err))
+}
+
+// TestVerifyIDToken_TenantWithoutSSO tests that tenants without a provider cannot sign in through SSO
+func (s *OIDCProviderTestSuite) TestVerifyIDToken_TenantWithoutSSO() {
+	rawToken := s.signIDToken(jwt.MapClaims{"email": "jane.doe@example.com"})
+
+	identity, err := s.provider.VerifyIDToken(context.Background(), "tenant-456", rawToken)
+
+	s.Nil(identity)
+	s.True(errors.IsAuthenticationError(err))
+}
+
+// TestNewOIDCProvider_SystemRoleMapping tests that IdP groups cannot grant the system role
+func (s *OIDCProviderTestSuite) TestNewOIDCProvider_SystemRoleMapping() {
+	_, err := NewOIDCProvider(config.OIDCConfig{
+		Providers: []config.OIDCProviderConfig{{
+			TenantID:     testTenantID,
+			Issuer:       testIssuer,
+			ClientID:     testClientID,
+			RoleMappings: map[string]string{"dms-robots": models.RoleSystem},
+		}},
+	})
+
+	s.True(errors.IsValidationError(err))
+}
+
+// TestOIDCProviderSuite runs the OIDC provider test suite
+func TestOIDCProviderSuite(t *testing.T) {
+	suite.Run(t, new(OIDCProviderTestSuite))
+}
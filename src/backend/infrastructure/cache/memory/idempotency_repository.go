@@ -0,0 +1,80 @@
+package memory
+
+import (
+	"context" // standard library
+	"sync"    // standard library
+	"time"    // standard library
+
+	"../../../domain/models"
+	"../../../domain/repositories"
+)
+
+// idempotencyEntry is a stored idempotency record and when it expires
+type idempotencyEntry struct {
+	record    models.IdempotencyRecord
+	expiresAt time.Time
+}
+
+// idempotencyRepository implements the IdempotencyRepository interface with a map of records that
+// expire like the Redis keys
+type idempotencyRepository struct {
+	mu      sync.Mutex
+	records map[string]idempotencyEntry
+}
+
+// NewIdempotencyRepository creates a new in-memory IdempotencyRepository
+func NewIdempotencyRepository() repositories.IdempotencyRepository {
+	return &idempotencyRepository{
+		records: make(map[string]idempotencyEntry),
+	}
+}
+
+// Reserve stores an in-progress record for a key unless one exists
+func (r *idempotencyRepository) Reserve(ctx context.Context, record *models.IdempotencyRecord, ttl time.Duration) (bool, *models.IdempotencyRecord, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	r.purgeExpired(now)
+
+	key := idempotencyKey(record.TenantID, record.UserID, record.Key)
+	if existing, ok := r.records[key]; ok {
+		existingRecord := existing.record
+		return false, &existingRecord, nil
+	}
+
+	r.records[key] = idempotencyEntry{record: *record, expiresAt: now.Add(ttl)}
+	return true, nil, nil
+}
+
+// Save replaces the record for a key
+func (r *idempotencyRepository) Save(ctx context.Context, record *models.IdempotencyRecord, ttl time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.records[idempotencyKey(record.TenantID, record.UserID, record.Key)] = idempotencyEntry{record: *record, expiresAt: time.Now().Add(ttl)}
+	return nil
+}
+
+// Release forgets a key
+func (r *idempotencyRepository) Release(ctx context.Context, tenantID, userID, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.records, idempotencyKey(tenantID, userID, key))
+	return nil
+}
+
+// purgeExpired forgets the records that expired. It must be called with mu held.
+func (r *idempotencyRepository) purgeExpired(now time.Time) {
+	for key, entry := range r.records {
+		if !entry.expiresAt.After(now) {
+			delete(r.records, key)
+		}
+	}
+}
+
+// idempotencyKey scopes an idempotency key to the tenant and user that sent it
+func idempotencyKey(tenantID, userID, key string) string {
+	return tenantID + ":" + userID + ":" + key
+}
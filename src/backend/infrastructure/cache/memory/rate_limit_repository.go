@@ -0,0 +1,92 @@
+package memory
+
+import (
+	"context" // standard library
+	"math"    // standard library
+	"sync"    // standard library
+	"time"    // standard library
+
+	"../../../domain/models"
+	"../../../domain/repositories"
+	"../../../pkg/errors"
+)
+
+// rateLimitSweepInterval is how often buckets that are full again are forgotten
+const rateLimitSweepInterval = time.Minute
+
+// tokenBucket is the state of a rate limit bucket
+type tokenBucket struct {
+	tokens    float64
+	updatedAt time.Time
+	fullAt    time.Time // When the bucket is full again and can be forgotten
+}
+
+// rateLimitRepository implements the RateLimitRepository interface with token buckets kept in
+// memory, refilled like the Redis buckets
+type rateLimitRepository struct {
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewRateLimitRepository creates a new in-memory RateLimitRepository
+func NewRateLimitRepository() repositories.RateLimitRepository {
+	return &rateLimitRepository{
+		buckets: make(map[string]*tokenBucket),
+		now:     time.Now,
+	}
+}
+
+// Take takes a token from the bucket under key
+func (r *rateLimitRepository) Take(ctx context.Context, key string, limit models.RateLimit) (*models.RateLimitDecision, error) {
+	if key == "" {
+		return nil, errors.NewValidationError("rate limit key cannot be empty")
+	}
+	if !limit.Enabled() {
+		return &models.RateLimitDecision{Allowed: true}, nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	r.sweep(now)
+
+	capacity := float64(limit.Capacity())
+	ratePerSecond := float64(limit.RequestsPerMinute) / time.Minute.Seconds()
+
+	bucket, ok := r.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: capacity, updatedAt: now}
+		r.buckets[key] = bucket
+	}
+	bucket.tokens = math.Min(capacity, bucket.tokens+math.Max(0, now.Sub(bucket.updatedAt).Seconds())*ratePerSecond)
+	bucket.updatedAt = now
+
+	decision := &models.RateLimitDecision{Limit: limit.Capacity()}
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		decision.Allowed = true
+	} else {
+		decision.RetryAfter = time.Duration(math.Ceil((1 - bucket.tokens) / ratePerSecond * float64(time.Second)))
+	}
+	decision.Remaining = int(math.Floor(bucket.tokens))
+	bucket.fullAt = now.Add(time.Duration((capacity - bucket.tokens) / ratePerSecond * float64(time.Second)))
+
+	return decision, nil
+}
+
+// sweep forgets the buckets that are full again, at most once per sweep interval. It must be called
+// with mu held.
+func (r *rateLimitRepository) sweep(now time.Time) {
+	if now.Sub(r.lastSweep) < rateLimitSweepInterval {
+		return
+	}
+	for key, bucket := range r.buckets {
+		if !bucket.fullAt.After(now) {
+			delete(r.buckets, key)
+		}
+	}
+	r.lastSweep = now
+}
@@ -0,0 +1,71 @@
+package memory
+
+import (
+	"context" // standard library
+	"sync"    // standard library
+	"time"    // standard library
+
+	"../../../domain/repositories"
+	"../../../pkg/errors"
+)
+
+// scanVerdictKey identifies the content of a tenant scanned clean
+type scanVerdictKey struct {
+	tenantID    string
+	contentHash string
+}
+
+// scanVerdictRepository implements the ScanVerdictRepository interface with a map of the content
+// scanned clean to the time its verdict expires
+type scanVerdictRepository struct {
+	mu       sync.Mutex
+	verdicts map[scanVerdictKey]time.Time
+}
+
+// NewScanVerdictRepository creates a new in-memory ScanVerdictRepository
+func NewScanVerdictRepository() repositories.ScanVerdictRepository {
+	return &scanVerdictRepository{
+		verdicts: make(map[scanVerdictKey]time.Time),
+	}
+}
+
+// StoreClean records that the tenant's content with the hash was scanned clean, for ttl
+func (r *scanVerdictRepository) StoreClean(ctx context.Context, tenantID, contentHash string, ttl time.Duration) error {
+	if tenantID == "" || contentHash == "" {
+		return errors.NewValidationError("tenant ID and content hash cannot be empty")
+	}
+	if ttl <= 0 {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Forget the verdicts that expired, so the map never outgrows the live verdicts
+	now := time.Now()
+	for key, expiresAt := range r.verdicts {
+		if !expiresAt.After(now) {
+			delete(r.verdicts, key)
+		}
+	}
+	r.verdicts[scanVerdictKey{tenantID: tenantID, contentHash: contentHash}] = now.Add(ttl)
+	return nil
+}
+
+// IsClean checks if the tenant's content with the hash was scanned clean
+func (r *scanVerdictRepository) IsClean(ctx context.Context, tenantID, contentHash string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	expiresAt, ok := r.verdicts[scanVerdictKey{tenantID: tenantID, contentHash: contentHash}]
+	return ok && expiresAt.After(time.Now()), nil
+}
+
+// DeleteAll forgets the verdicts of all tenants
+func (r *scanVerdictRepository) DeleteAll(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.verdicts = make(map[scanVerdictKey]time.Time)
+	return nil
+}
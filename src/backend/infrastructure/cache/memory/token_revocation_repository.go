@@ -0,0 +1,61 @@
+// Package memory implements the repositories of the state the API instances share through Redis
+// in the memory of a single API process, for development without Redis. The state is lost on
+// restart and not shared with other instances.
+package memory
+
+import (
+	"context" // standard library
+	"sync"    // standard library
+	"time"    // standard library
+
+	"../../../domain/repositories"
+	"../../../pkg/errors"
+)
+
+// tokenRevocationRepository implements the TokenRevocationRepository interface with a map of revoked
+// token IDs to the time their token expires
+type tokenRevocationRepository struct {
+	mu      sync.Mutex
+	revoked map[string]time.Time
+}
+
+// NewTokenRevocationRepository creates a new in-memory TokenRevocationRepository
+func NewTokenRevocationRepository() repositories.TokenRevocationRepository {
+	return &tokenRevocationRepository{
+		revoked: make(map[string]time.Time),
+	}
+}
+
+// Revoke adds a token ID to the revocation list until the token expires
+func (r *tokenRevocationRepository) Revoke(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	if tokenID == "" {
+		return errors.NewValidationError("token ID cannot be empty")
+	}
+
+	// An expired token is rejected anyway and does not need to be remembered
+	now := time.Now()
+	if !expiresAt.After(now) {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Forget the tokens that expired, so the list never outgrows the live tokens
+	for id, tokenExpiresAt := range r.revoked {
+		if !tokenExpiresAt.After(now) {
+			delete(r.revoked, id)
+		}
+	}
+	r.revoked[tokenID] = expiresAt
+	return nil
+}
+
+// IsRevoked checks if a token ID is on the revocation list
+func (r *tokenRevocationRepository) IsRevoked(ctx context.Context, tokenID string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	expiresAt, ok := r.revoked[tokenID]
+	return ok && expiresAt.After(time.Now()), nil
+}
@@ -206,6 +206,12 @@ func (c *DocumentCache) ListByTenant(ctx context.Context, tenantID string, pagin
 	return result, nil
 }
 
+// ListByOwner lists the documents a user owns with pagination, without caching, since it only
+// serves infrequent exports that must not see stale lists
+func (c *DocumentCache) ListByOwner(ctx context.Context, ownerID string, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Document], error) {
+	return c.repository.ListByOwner(ctx, ownerID, tenantID, pagination)
+}
+
 // SearchByContent searches documents by content with pagination, using cache when available
 func (c *DocumentCache) SearchByContent(ctx context.Context, query string, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Document], error) {
 	// Generate cache key using search query, tenant ID, and pagination parameters
@@ -351,6 +357,76 @@ func (c *DocumentCache) UpdateVersionStatus(ctx context.Context, versionID strin
 	return nil
 }
 
+// ListVersionsToReencrypt lists the versions to re-encrypt with a tenant's key, without caching,
+// since the list shrinks with every re-encrypted version
+func (c *DocumentCache) ListVersionsToReencrypt(ctx context.Context, tenantID string, keyID string, limit int) ([]*models.DocumentVersion, error) {
+	return c.repository.ListVersionsToReencrypt(ctx, tenantID, keyID, limit)
+}
+
+// ListRecentVersions lists the recent versions of a tenant in a status, without caching, since the
+// list changes with every upload
+func (c *DocumentCache) ListRecentVersions(ctx context.Context, tenantID string, status string, since time.Time, limit int) ([]*models.DocumentVersion, error) {
+	return c.repository.ListRecentVersions(ctx, tenantID, status, since, limit)
+}
+
+// ListVersionsToClassify lists the versions of a tenant to classify for personal data, without
+// caching, since the list shrinks with every classified version
+func (c *DocumentCache) ListVersionsToClassify(ctx context.Context, tenantID string, limit int) ([]*models.DocumentVersion, error) {
+	return c.repository.ListVersionsToClassify(ctx, tenantID, limit)
+}
+
+// UpdateVersionStoragePath records the storage path of a document version and invalidates its cache entry
+func (c *DocumentCache) UpdateVersionStoragePath(ctx context.Context, versionID string, storagePath string, tenantID string) error {
+	if err := c.repository.UpdateVersionStoragePath(ctx, versionID, storagePath, tenantID); err != nil {
+		return err
+	}
+
+	if err := c.invalidateVersionCache(ctx, versionID, tenantID); err != nil {
+		logger.Error("Failed to invalidate version cache", "error", err, "version_id", versionID)
+	}
+
+	return nil
+}
+
+// UpdateVersionEncryptionKey records the encryption key of a document version and invalidates its cache entry
+func (c *DocumentCache) UpdateVersionEncryptionKey(ctx context.Context, versionID string, keyID string, tenantID string) error {
+	if err := c.repository.UpdateVersionEncryptionKey(ctx, versionID, keyID, tenantID); err != nil {
+		return err
+	}
+
+	if err := c.invalidateVersionCache(ctx, versionID, tenantID); err != nil {
+		logger.Error("Failed to invalidate version cache", "error", err, "version_id", versionID)
+	}
+
+	return nil
+}
+
+// MarkVersionClassified records when a document version was classified and invalidates its cache entry
+func (c *DocumentCache) MarkVersionClassified(ctx context.Context, versionID string, classifiedAt time.Time, tenantID string) error {
+	if err := c.repository.MarkVersionClassified(ctx, versionID, classifiedAt, tenantID); err != nil {
+		return err
+	}
+
+	if err := c.invalidateVersionCache(ctx, versionID, tenantID); err != nil {
+		logger.Error("Failed to invalidate version cache", "error", err, "version_id", versionID)
+	}
+
+	return nil
+}
+
+// MarkVersionIntegrityVerified records when a document version's content was verified and invalidates its cache entry
+func (c *DocumentCache) MarkVersionIntegrityVerified(ctx context.Context, versionID string, verifiedAt time.Time, tenantID string) error {
+	if err := c.repository.MarkVersionIntegrityVerified(ctx, versionID, verifiedAt, tenantID); err != nil {
+		return err
+	}
+
+	if err := c.invalidateVersionCache(ctx, versionID, tenantID); err != nil {
+		logger.Error("Failed to invalidate version cache", "error", err, "version_id", versionID)
+	}
+
+	return nil
+}
+
 // AddMetadata adds metadata to a document and invalidates related cache entries
 func (c *DocumentCache) AddMetadata(ctx context.Context, documentID string, key string, value string, tenantID string) (string, error) {
 	// Delegate metadata creation to the underlying repository
@@ -412,6 +488,28 @@ func (c *DocumentCache) DeleteMetadata(ctx context.Context, documentID string, k
 	return nil
 }
 
+// BulkUpdateMetadata updates the metadata of many documents and invalidates related cache entries
+func (c *DocumentCache) BulkUpdateMetadata(ctx context.Context, documentIDs []string, set map[string]string, remove []string, tenantID string) error {
+	// Delegate the metadata update to the underlying repository
+	if err := c.repository.BulkUpdateMetadata(ctx, documentIDs, set, remove, tenantID); err != nil {
+		return err
+	}
+
+	// If successful, invalidate the cache of every document
+	for _, documentID := range documentIDs {
+		if err := c.invalidateDocumentCache(ctx, documentID, tenantID); err != nil {
+			logger.Error("Failed to invalidate document cache", "error", err, "document_id", documentID)
+		}
+	}
+
+	// Invalidate search cache
+	if err := c.invalidateSearchCache(ctx, tenantID); err != nil {
+		logger.Error("Failed to invalidate search cache", "error", err, "tenant_id", tenantID)
+	}
+
+	return nil
+}
+
 // GetDocumentsByIDs retrieves multiple documents by their IDs, using cache when available
 func (c *DocumentCache) GetDocumentsByIDs(ctx context.Context, ids []string, tenantID string) ([]*models.Document, error) {
 	// Initialize result slice
@@ -457,6 +555,70 @@ func (c *DocumentCache) GetDocumentsByIDs(ctx context.Context, ids []string, ten
 	return result, nil
 }
 
+// GetUsage returns the number of documents of a tenant and their storage, without caching,
+// since usage changes with every upload
+func (c *DocumentCache) GetUsage(ctx context.Context, tenantID string) (int64, int64, error) {
+	return c.repository.GetUsage(ctx, tenantID)
+}
+
+// GetUploadVolume returns the bytes a user uploaded since the given time, without caching,
+// since the volume changes with every upload
+func (c *DocumentCache) GetUploadVolume(ctx context.Context, tenantID, userID string, since time.Time) (int64, error) {
+	return c.repository.GetUploadVolume(ctx, tenantID, userID, since)
+}
+
+// SetLock locks or unlocks a document and invalidates its cache entry
+func (c *DocumentCache) SetLock(ctx context.Context, id string, tenantID string, lockedBy string, lockedAt *time.Time) error {
+	// Delegate the lock change to the underlying repository
+	if err := c.repository.SetLock(ctx, id, tenantID, lockedBy, lockedAt); err != nil {
+		return err
+	}
+
+	// If successful, invalidate document cache
+	if err := c.invalidateDocumentCache(ctx, id, tenantID); err != nil {
+		logger.Error("Failed to invalidate document cache", "error", err, "document_id", id)
+	}
+
+	return nil
+}
+
+// SetSchedule sets the publication schedule of a document and invalidates its cache entry
+func (c *DocumentCache) SetSchedule(ctx context.Context, id string, tenantID string, publishAt, expireAt *time.Time, visibility string) error {
+	// Delegate the schedule change to the underlying repository
+	if err := c.repository.SetSchedule(ctx, id, tenantID, publishAt, expireAt, visibility); err != nil {
+		return err
+	}
+
+	// If successful, invalidate document cache
+	if err := c.invalidateDocumentCache(ctx, id, tenantID); err != nil {
+		logger.Error("Failed to invalidate document cache", "error", err, "document_id", id)
+	}
+
+	return nil
+}
+
+// ListDueVisibilityChanges lists documents due to be published or to expire, without caching,
+// since the result depends on the current time
+func (c *DocumentCache) ListDueVisibilityChanges(ctx context.Context, now time.Time, limit int) ([]*models.Document, error) {
+	return c.repository.ListDueVisibilityChanges(ctx, now, limit)
+}
+
+// ApplyDueVisibility publishes or expires a scheduled document and invalidates its cache entry
+func (c *DocumentCache) ApplyDueVisibility(ctx context.Context, id string, tenantID string, from string, to string, now time.Time) (bool, error) {
+	// Delegate the visibility change to the underlying repository
+	applied, err := c.repository.ApplyDueVisibility(ctx, id, tenantID, from, to, now)
+	if err != nil || !applied {
+		return applied, err
+	}
+
+	// If the visibility changed, invalidate document cache
+	if err := c.invalidateDocumentCache(ctx, id, tenantID); err != nil {
+		logger.Error("Failed to invalidate document cache", "error", err, "document_id", id)
+	}
+
+	return true, nil
+}
+
 // generateDocumentKey generates a cache key for a document
 func (c *DocumentCache) generateDocumentKey(id string, tenantID string) string {
 	return fmt.Sprintf("%s%s:tenant:%s", documentKeyPrefix, id, tenantID)
@@ -467,10 +629,10 @@ func (c *DocumentCache) generateVersionKey(versionID string, tenantID string) st
 	return fmt.Sprintf("%s%s:tenant:%s", documentVersionKeyPrefix, versionID, tenantID)
 }
 
-// generateListKey generates a cache key for a document list
+// generateListKey generates a cache key for a document list, including its sort order and cursor
 func (c *DocumentCache) generateListKey(folderID string, tenantID string, pagination *utils.Pagination) string {
-	return fmt.Sprintf("%sfolder:%s:tenant:%s:page:%d:pageSize:%d", 
-		documentListKeyPrefix, folderID, tenantID, pagination.Page, pagination.PageSize)
+	return fmt.Sprintf("%sfolder:%s:tenant:%s:page:%d:pageSize:%d:sort:%s:cursor:%s", 
+		documentListKeyPrefix, folderID, tenantID, pagination.Page, pagination.PageSize, utils.FormatSort(pagination.Sort), pagination.Cursor)
 }
 
 // generateTenantListKey generates a cache key for a tenant document list
@@ -0,0 +1,77 @@
+// Package redis implements Redis-based cache providers for the Document Management Platform.
+package redis
+
+import (
+	"context"       // standard library
+	"encoding/json" // standard library
+	"time"          // standard library
+
+	"github.com/go-redis/redis/v8" // v8.11.5
+
+	"../../../domain/models"
+	"../../../domain/repositories"
+	"../../../pkg/errors"
+)
+
+// idempotencyKeyPrefix prefixes the keys of idempotency records
+const idempotencyKeyPrefix = "idempotency:"
+
+// idempotencyRepository implements the IdempotencyRepository interface with Redis keys that expire
+// with the record
+type idempotencyRepository struct {
+	redisClient *RedisClient
+}
+
+// NewIdempotencyRepository creates a new Redis-backed IdempotencyRepository
+func NewIdempotencyRepository(redisClient *RedisClient) repositories.IdempotencyRepository {
+	return &idempotencyRepository{
+		redisClient: redisClient,
+	}
+}
+
+// Reserve stores an in-progress record for a key unless one exists
+func (r *idempotencyRepository) Reserve(ctx context.Context, record *models.IdempotencyRecord, ttl time.Duration) (bool, *models.IdempotencyRecord, error) {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return false, nil, errors.Wrap(err, "failed to marshal idempotency record")
+	}
+
+	key := idempotencyRedisKey(record.TenantID, record.UserID, record.Key)
+	reserved, err := r.redisClient.client.SetNX(ctx, key, data, ttl).Result()
+	if err != nil {
+		return false, nil, errors.Wrap(err, "failed to reserve idempotency key in Redis")
+	}
+	if reserved {
+		return true, nil, nil
+	}
+
+	existing, err := r.redisClient.client.Get(ctx, key).Bytes()
+	if err == redis.Nil {
+		// The record expired in between; the caller can simply retry
+		return false, nil, errors.NewDependencyError("idempotency record expired while reading it")
+	}
+	if err != nil {
+		return false, nil, errors.Wrap(err, "failed to get idempotency record from Redis")
+	}
+
+	var existingRecord models.IdempotencyRecord
+	if err := json.Unmarshal(existing, &existingRecord); err != nil {
+		return false, nil, errors.Wrap(err, "failed to unmarshal idempotency record")
+	}
+	return false, &existingRecord, nil
+}
+
+// Save replaces the record for a key
+func (r *idempotencyRepository) Save(ctx context.Context, record *models.IdempotencyRecord, ttl time.Duration) error {
+	return r.redisClient.Set(ctx, idempotencyRedisKey(record.TenantID, record.UserID, record.Key), record, ttl)
+}
+
+// Release forgets a key
+func (r *idempotencyRepository) Release(ctx context.Context, tenantID, userID, key string) error {
+	return r.redisClient.Delete(ctx, idempotencyRedisKey(tenantID, userID, key))
+}
+
+// idempotencyRedisKey scopes an idempotency key to the tenant and user that sent it
+func idempotencyRedisKey(tenantID, userID, key string) string {
+	return idempotencyKeyPrefix + tenantID + ":" + userID + ":" + key
+}
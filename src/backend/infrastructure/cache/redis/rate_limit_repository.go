@@ -0,0 +1,86 @@
+// Package redis implements Redis-based cache providers for the Document Management Platform.
+package redis
+
+import (
+	"context" // standard library
+	"time"    // standard library
+
+	"github.com/go-redis/redis/v8" // v8.11.5
+
+	"../../../domain/models"
+	"../../../domain/repositories"
+	"../../../pkg/errors"
+)
+
+// rateLimitKeyPrefix prefixes the keys of rate limit token buckets
+const rateLimitKeyPrefix = "rate_limit:"
+
+// takeTokenScript refills a token bucket for the time passed since it was last used and takes a
+// token from it, atomically. Redis' clock is used so that API instances with skewed clocks share
+// buckets correctly. Buckets expire once they would be full again.
+//
+// KEYS[1] bucket key; ARGV[1] refill rate in tokens per millisecond; ARGV[2] capacity.
+// Returns {allowed (0 or 1), whole tokens remaining, milliseconds until the next token}.
+var takeTokenScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local capacity = tonumber(ARGV[2])
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+
+local bucket = redis.call('HMGET', KEYS[1], 'tokens', 'updated_at')
+local tokens = tonumber(bucket[1])
+local updatedAt = tonumber(bucket[2])
+if tokens == nil or updatedAt == nil then
+	tokens = capacity
+	updatedAt = now
+end
+tokens = math.min(capacity, tokens + math.max(0, now - updatedAt) * rate)
+
+local allowed = 0
+local retryAfter = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	retryAfter = math.ceil((1 - tokens) / rate)
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'updated_at', now)
+redis.call('PEXPIRE', KEYS[1], math.ceil(capacity / rate))
+return {allowed, math.floor(tokens), retryAfter}
+`)
+
+// rateLimitRepository implements the RateLimitRepository interface with token buckets kept in Redis
+type rateLimitRepository struct {
+	redisClient *RedisClient
+}
+
+// NewRateLimitRepository creates a new Redis-backed RateLimitRepository
+func NewRateLimitRepository(redisClient *RedisClient) repositories.RateLimitRepository {
+	return &rateLimitRepository{
+		redisClient: redisClient,
+	}
+}
+
+// Take takes a token from the bucket under key
+func (r *rateLimitRepository) Take(ctx context.Context, key string, limit models.RateLimit) (*models.RateLimitDecision, error) {
+	if key == "" {
+		return nil, errors.NewValidationError("rate limit key cannot be empty")
+	}
+	if !limit.Enabled() {
+		return &models.RateLimitDecision{Allowed: true}, nil
+	}
+
+	ratePerMillisecond := float64(limit.RequestsPerMinute) / float64(time.Minute/time.Millisecond)
+	result, err := takeTokenScript.Run(ctx, r.redisClient.client, []string{rateLimitKeyPrefix + key}, ratePerMillisecond, limit.Capacity()).Int64Slice()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to take rate limit token from Redis")
+	}
+
+	return &models.RateLimitDecision{
+		Allowed:    result[0] == 1,
+		Limit:      limit.Capacity(),
+		Remaining:  int(result[1]),
+		RetryAfter: time.Duration(result[2]) * time.Millisecond,
+	}, nil
+}
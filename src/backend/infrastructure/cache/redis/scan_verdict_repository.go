@@ -0,0 +1,59 @@
+// Package redis implements Redis-based cache providers for the Document Management Platform.
+package redis
+
+import (
+	"context" // standard library
+	"time"    // standard library
+
+	"../../../domain/repositories"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+)
+
+// scanVerdictKeyPrefix prefixes the keys of the content hashes scanned clean, followed by the
+// tenant ID and the hash
+const scanVerdictKeyPrefix = "scan_verdict:"
+
+// scanVerdictRepository implements the ScanVerdictRepository interface with Redis keys that expire
+// together with the verdict
+type scanVerdictRepository struct {
+	redisClient *RedisClient
+}
+
+// NewScanVerdictRepository creates a new Redis-backed ScanVerdictRepository
+func NewScanVerdictRepository(redisClient *RedisClient) repositories.ScanVerdictRepository {
+	return &scanVerdictRepository{
+		redisClient: redisClient,
+	}
+}
+
+// StoreClean records that the tenant's content with the hash was scanned clean, for ttl
+func (r *scanVerdictRepository) StoreClean(ctx context.Context, tenantID, contentHash string, ttl time.Duration) error {
+	if tenantID == "" || contentHash == "" {
+		return errors.NewValidationError("tenant ID and content hash cannot be empty")
+	}
+	if ttl <= 0 {
+		return nil
+	}
+
+	if err := r.redisClient.Set(ctx, scanVerdictKey(tenantID, contentHash), true, ttl); err != nil {
+		logger.Error("Failed to store scan verdict", "error", err, "tenant_id", tenantID)
+		return err
+	}
+	return nil
+}
+
+// IsClean checks if the tenant's content with the hash was scanned clean
+func (r *scanVerdictRepository) IsClean(ctx context.Context, tenantID, contentHash string) (bool, error) {
+	return r.redisClient.Exists(ctx, scanVerdictKey(tenantID, contentHash))
+}
+
+// DeleteAll forgets the verdicts of all tenants
+func (r *scanVerdictRepository) DeleteAll(ctx context.Context) error {
+	return r.redisClient.DeletePattern(ctx, scanVerdictKeyPrefix+"*")
+}
+
+// scanVerdictKey returns the key of the verdict of the tenant's content with the hash
+func scanVerdictKey(tenantID, contentHash string) string {
+	return scanVerdictKeyPrefix + tenantID + ":" + contentHash
+}
@@ -77,9 +77,9 @@ func (c *SearchCache) SearchByContent(ctx context.Context, query string, tenantI
 }
 
 // SearchByMetadata searches documents by their metadata, using cache when available.
-func (c *SearchCache) SearchByMetadata(ctx context.Context, metadata map[string]string, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Document], error) {
+func (c *SearchCache) SearchByMetadata(ctx context.Context, metadata map[string]string, filters []models.MetadataFilter, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Document], error) {
 	// Generate cache key
-	cacheKey := c.generateMetadataSearchKey(metadata, tenantID, pagination)
+	cacheKey := c.generateMetadataSearchKey(metadata, filters, tenantID, pagination)
 
 	// Try to get from cache
 	var result utils.PaginatedResult[models.Document]
@@ -95,7 +95,7 @@ func (c *SearchCache) SearchByMetadata(ctx context.Context, metadata map[string]
 	}
 
 	// Cache miss or error, call the search service
-	result, err = c.searchService.SearchByMetadata(ctx, metadata, tenantID, pagination)
+	result, err = c.searchService.SearchByMetadata(ctx, metadata, filters, tenantID, pagination)
 	if err != nil {
 		return utils.PaginatedResult[models.Document]{}, err
 	}
@@ -113,9 +113,9 @@ func (c *SearchCache) SearchByMetadata(ctx context.Context, metadata map[string]
 }
 
 // CombinedSearch performs a search using both content and metadata criteria, using cache when available.
-func (c *SearchCache) CombinedSearch(ctx context.Context, contentQuery string, metadata map[string]string, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Document], error) {
+func (c *SearchCache) CombinedSearch(ctx context.Context, contentQuery string, metadata map[string]string, filters []models.MetadataFilter, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Document], error) {
 	// Generate cache key
-	cacheKey := c.generateCombinedSearchKey(contentQuery, metadata, tenantID, pagination)
+	cacheKey := c.generateCombinedSearchKey(contentQuery, metadata, filters, tenantID, pagination)
 
 	// Try to get from cache
 	var result utils.PaginatedResult[models.Document]
@@ -131,7 +131,7 @@ func (c *SearchCache) CombinedSearch(ctx context.Context, contentQuery string, m
 	}
 
 	// Cache miss or error, call the search service
-	result, err = c.searchService.CombinedSearch(ctx, contentQuery, metadata, tenantID, pagination)
+	result, err = c.searchService.CombinedSearch(ctx, contentQuery, metadata, filters, tenantID, pagination)
 	if err != nil {
 		return utils.PaginatedResult[models.Document]{}, err
 	}
@@ -216,26 +216,42 @@ func (c *SearchCache) RemoveDocumentFromIndex(ctx context.Context, documentID st
 	return nil
 }
 
+// UpdateIndexedMetadata updates the indexed metadata of documents and invalidates related cache entries.
+func (c *SearchCache) UpdateIndexedMetadata(ctx context.Context, documents []*models.Document, tenantID string) error {
+	// Call the underlying service
+	err := c.searchService.UpdateIndexedMetadata(ctx, documents, tenantID)
+	if err != nil {
+		return err
+	}
+
+	// Invalidate cache for the tenant to maintain consistency
+	if invalidateErr := c.invalidateSearchCache(ctx, tenantID); invalidateErr != nil {
+		logger.Error("Failed to invalidate search cache after updating metadata", "error", invalidateErr, "documents", len(documents), "tenantID", tenantID)
+	}
+
+	return nil
+}
+
 // generateContentSearchKey generates a cache key for content search results.
 func (c *SearchCache) generateContentSearchKey(query string, tenantID string, pagination *utils.Pagination) string {
-	return fmt.Sprintf("%s%s:%s:p%d:s%d", contentSearchKeyPrefix, tenantID, query, pagination.Page, pagination.PageSize)
+	return fmt.Sprintf("%s%s:%s:p%d:s%d:o%s:c%s", contentSearchKeyPrefix, tenantID, query, pagination.Page, pagination.PageSize, utils.FormatSort(pagination.Sort), pagination.Cursor)
 }
 
 // generateMetadataSearchKey generates a cache key for metadata search results.
-func (c *SearchCache) generateMetadataSearchKey(metadata map[string]string, tenantID string, pagination *utils.Pagination) string {
-	metadataHash := c.hashMetadata(metadata)
-	return fmt.Sprintf("%s%s:%s:p%d:s%d", metadataSearchKeyPrefix, tenantID, metadataHash, pagination.Page, pagination.PageSize)
+func (c *SearchCache) generateMetadataSearchKey(metadata map[string]string, filters []models.MetadataFilter, tenantID string, pagination *utils.Pagination) string {
+	metadataHash := c.hashMetadata(metadata, filters)
+	return fmt.Sprintf("%s%s:%s:p%d:s%d:o%s:c%s", metadataSearchKeyPrefix, tenantID, metadataHash, pagination.Page, pagination.PageSize, utils.FormatSort(pagination.Sort), pagination.Cursor)
 }
 
 // generateCombinedSearchKey generates a cache key for combined search results.
-func (c *SearchCache) generateCombinedSearchKey(contentQuery string, metadata map[string]string, tenantID string, pagination *utils.Pagination) string {
-	metadataHash := c.hashMetadata(metadata)
-	return fmt.Sprintf("%s%s:%s:%s:p%d:s%d", combinedSearchKeyPrefix, tenantID, contentQuery, metadataHash, pagination.Page, pagination.PageSize)
+func (c *SearchCache) generateCombinedSearchKey(contentQuery string, metadata map[string]string, filters []models.MetadataFilter, tenantID string, pagination *utils.Pagination) string {
+	metadataHash := c.hashMetadata(metadata, filters)
+	return fmt.Sprintf("%s%s:%s:%s:p%d:s%d:o%s:c%s", combinedSearchKeyPrefix, tenantID, contentQuery, metadataHash, pagination.Page, pagination.PageSize, utils.FormatSort(pagination.Sort), pagination.Cursor)
 }
 
 // generateFolderSearchKey generates a cache key for folder search results.
 func (c *SearchCache) generateFolderSearchKey(folderID string, query string, tenantID string, pagination *utils.Pagination) string {
-	return fmt.Sprintf("%s%s:%s:%s:p%d:s%d", folderSearchKeyPrefix, tenantID, folderID, query, pagination.Page, pagination.PageSize)
+	return fmt.Sprintf("%s%s:%s:%s:p%d:s%d:o%s:c%s", folderSearchKeyPrefix, tenantID, folderID, query, pagination.Page, pagination.PageSize, utils.FormatSort(pagination.Sort), pagination.Cursor)
 }
 
 // invalidateSearchCache invalidates all search cache entries for a tenant.
@@ -264,9 +280,9 @@ func (c *SearchCache) invalidateSearchCache(ctx context.Context, tenantID string
 	return err
 }
 
-// hashMetadata creates a hash of the metadata map for consistent cache keys.
-func (c *SearchCache) hashMetadata(metadata map[string]string) string {
-	if metadata == nil || len(metadata) == 0 {
+// hashMetadata creates a hash of the metadata map and filters for consistent cache keys.
+func (c *SearchCache) hashMetadata(metadata map[string]string, filters []models.MetadataFilter) string {
+	if len(metadata) == 0 && len(filters) == 0 {
 		return "empty"
 	}
 
@@ -282,6 +298,10 @@ func (c *SearchCache) hashMetadata(metadata map[string]string) string {
 	for _, k := range keys {
 		str += k + ":" + metadata[k] + ","
 	}
+	if len(filters) > 0 {
+		filtersData, _ := json.Marshal(filters)
+		str += string(filtersData)
+	}
 
 	// Generate MD5 hash
 	hash := md5.Sum([]byte(str))
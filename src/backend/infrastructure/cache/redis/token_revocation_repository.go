@@ -0,0 +1,51 @@
+// Package redis implements Redis-based cache providers for the Document Management Platform.
+package redis
+
+import (
+	"context" // standard library
+	"time"    // standard library
+
+	"../../../domain/repositories"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+)
+
+// revokedTokenKeyPrefix prefixes the keys of revoked token IDs
+const revokedTokenKeyPrefix = "revoked_token:"
+
+// tokenRevocationRepository implements the TokenRevocationRepository interface with Redis keys
+// that expire together with the revoked token, so the list never outgrows the live tokens
+type tokenRevocationRepository struct {
+	redisClient *RedisClient
+}
+
+// NewTokenRevocationRepository creates a new Redis-backed TokenRevocationRepository
+func NewTokenRevocationRepository(redisClient *RedisClient) repositories.TokenRevocationRepository {
+	return &tokenRevocationRepository{
+		redisClient: redisClient,
+	}
+}
+
+// Revoke adds a token ID to the revocation list until the token expires
+func (r *tokenRevocationRepository) Revoke(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	if tokenID == "" {
+		return errors.NewValidationError("token ID cannot be empty")
+	}
+
+	// An expired token is rejected anyway and does not need to be remembered
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+
+	if err := r.redisClient.Set(ctx, revokedTokenKeyPrefix+tokenID, true, ttl); err != nil {
+		logger.Error("Failed to revoke token", "error", err)
+		return err
+	}
+	return nil
+}
+
+// IsRevoked checks if a token ID is on the revocation list
+func (r *tokenRevocationRepository) IsRevoked(ctx context.Context, tokenID string) (bool, error) {
+	return r.redisClient.Exists(ctx, revokedTokenKeyPrefix+tokenID)
+}
@@ -0,0 +1,156 @@
+// Package comprehend provides a PII detector over AWS Comprehend, which recognizes personal data
+// from its context, such as phone numbers and bank account numbers the built-in patterns miss.
+// The text of classified documents is sent to AWS.
+package comprehend
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/aws/aws-sdk-go/aws"                 // v1.44.0+
+	"github.com/aws/aws-sdk-go/aws/credentials"     // v1.44.0+
+	"github.com/aws/aws-sdk-go/aws/request"         // v1.44.0+
+	"github.com/aws/aws-sdk-go/aws/session"         // v1.44.0+
+	"github.com/aws/aws-sdk-go/service/comprehend" // v1.44.0+
+
+	"../../../domain/models"
+	"../../../domain/services"
+	"../../../pkg/config"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+)
+
+// Default values and constants
+const (
+	defaultLanguageCode = "en"
+	defaultMinScore     = 0.8
+
+	// maxChunkBytes keeps each request below the 100 KB Comprehend accepts for PII detection
+	maxChunkBytes = 99 * 1000
+)
+
+// entityCategories maps the PII entity types of Comprehend to the categories documents are tagged
+// with. Other entity types, such as names and addresses, are not tagged.
+var entityCategories = map[string]string{
+	comprehend.PiiEntityTypeSsn:                            models.PIICategorySSN,
+	comprehend.PiiEntityTypeCreditDebitNumber:              models.PIICategoryCreditCard,
+	comprehend.PiiEntityTypeEmail:                          models.PIICategoryEmail,
+	comprehend.PiiEntityTypePhone:                          models.PIICategoryPhone,
+	comprehend.PiiEntityTypeBankAccountNumber:              models.PIICategoryBankAccount,
+	comprehend.PiiEntityTypeInternationalBankAccountNumber: models.PIICategoryBankAccount,
+}
+
+// ComprehendAPI is the subset of the Comprehend client used by the detector
+type ComprehendAPI interface {
+	DetectPiiEntitiesWithContext(ctx aws.Context, input *comprehend.DetectPiiEntitiesInput, opts ...request.Option) (*comprehend.DetectPiiEntitiesOutput, error)
+}
+
+// comprehendDetector implements the PIIDetector interface with AWS Comprehend
+type comprehendDetector struct {
+	client       ComprehendAPI
+	languageCode string
+	minScore     float64
+}
+
+// NewComprehendDetector creates a PII detector using Comprehend in cfg.Region
+func NewComprehendDetector(cfg config.ComprehendConfig) (services.PIIDetector, error) {
+	if cfg.Region == "" {
+		return nil, errors.NewValidationError("Comprehend region cannot be empty")
+	}
+
+	awsConfig := &aws.Config{
+		Region: aws.String(cfg.Region),
+	}
+	if cfg.Endpoint != "" {
+		awsConfig.Endpoint = aws.String(cfg.Endpoint)
+	}
+	if cfg.AccessKey != "" {
+		awsConfig.Credentials = credentials.NewStaticCredentials(cfg.AccessKey, cfg.SecretKey, "")
+	}
+
+	sess, err := session.NewSession(awsConfig)
+	if err != nil {
+		logger.Error("Failed to create AWS session for Comprehend", "error", err)
+		return nil, errors.Wrap(err, "failed to create AWS session for Comprehend")
+	}
+
+	return NewComprehendDetectorWithClient(comprehend.New(sess), cfg.LanguageCode, cfg.MinScore)
+}
+
+// NewComprehendDetectorWithClient creates a PII detector using the given Comprehend client. Entities
+// are detected in text of languageCode, "en" when empty, and count when Comprehend is at least
+// minScore confident of them, 0.8 when not positive.
+func NewComprehendDetectorWithClient(client ComprehendAPI, languageCode string, minScore float64) (services.PIIDetector, error) {
+	if client == nil {
+		return nil, errors.NewValidationError("Comprehend client cannot be nil")
+	}
+	if languageCode == "" {
+		languageCode = defaultLanguageCode
+	}
+	if minScore <= 0 {
+		minScore = defaultMinScore
+	}
+
+	return &comprehendDetector{
+		client:       client,
+		languageCode: languageCode,
+		minScore:     minScore,
+	}, nil
+}
+
+// Name returns the name tenants select the detector by
+func (d *comprehendDetector) Name() string {
+	return models.PIIDetectorComprehend
+}
+
+// Detect sends the text to Comprehend in chunks and returns the categories of the entities found
+func (d *comprehendDetector) Detect(ctx context.Context, text string) ([]string, error) {
+	found := make(map[string]bool)
+	for _, chunk := range splitText(text, maxChunkBytes) {
+		output, err := d.client.DetectPiiEntitiesWithContext(ctx, &comprehend.DetectPiiEntitiesInput{
+			LanguageCode: aws.String(d.languageCode),
+			Text:         aws.String(chunk),
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to detect PII entities")
+		}
+
+		for _, entity := range output.Entities {
+			if aws.Float64Value(entity.Score) < d.minScore {
+				continue
+			}
+			if category, ok := entityCategories[aws.StringValue(entity.Type)]; ok {
+				found[category] = true
+			}
+		}
+	}
+
+	var categories []string
+	for _, category := range models.PIICategories {
+		if found[category] {
+			categories = append(categories, category)
+		}
+	}
+	return categories, nil
+}
+
+// splitText splits text into chunks of at most maxBytes, without cutting characters in half.
+// Blank chunks are left out, since Comprehend rejects them.
+func splitText(text string, maxBytes int) []string {
+	var chunks []string
+	for len(text) > 0 {
+		end := len(text)
+		if end > maxBytes {
+			end = maxBytes
+			for end > 0 && !utf8.RuneStart(text[end]) {
+				end--
+			}
+		}
+		if strings.TrimSpace(text[:end]) != "" {
+			chunks = append(chunks, text[:end])
+		}
+		text = text[end:]
+	}
+	return chunks
+}
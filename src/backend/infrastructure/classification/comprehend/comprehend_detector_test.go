@@ -0,0 +1,92 @@
+package comprehend
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/aws/aws-sdk-go/aws"                 // v1.44.0+
+	"github.com/aws/aws-sdk-go/aws/request"         // v1.44.0+
+	"github.com/aws/aws-sdk-go/service/comprehend" // v1.44.0+
+	"github.com/stretchr/testify/assert"            // v1.8.0+
+	"github.com/stretchr/testify/require"           // v1.8.0+
+
+	"../../../domain/models"
+)
+
+// fakeComprehend returns the same entities for every request and records the text of each
+type fakeComprehend struct {
+	entities []*comprehend.PiiEntity
+	texts    []string
+	err      error
+}
+
+func (f *fakeComprehend) DetectPiiEntitiesWithContext(ctx aws.Context, input *comprehend.DetectPiiEntitiesInput, opts ...request.Option) (*comprehend.DetectPiiEntitiesOutput, error) {
+	f.texts = append(f.texts, aws.StringValue(input.Text))
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &comprehend.DetectPiiEntitiesOutput{Entities: f.entities}, nil
+}
+
+// entity returns a PII entity of the given type and confidence
+func entity(entityType string, score float64) *comprehend.PiiEntity {
+	return &comprehend.PiiEntity{Type: aws.String(entityType), Score: aws.Float64(score)}
+}
+
+func TestDetect_MapsConfidentEntities(t *testing.T) {
+	client := &fakeComprehend{entities: []*comprehend.PiiEntity{
+		entity(comprehend.PiiEntityTypePhone, 0.99),
+		entity(comprehend.PiiEntityTypeSsn, 0.95),
+		entity(comprehend.PiiEntityTypeEmail, 0.4),
+		entity(comprehend.PiiEntityTypeName, 0.99),
+	}}
+	detector, err := NewComprehendDetectorWithClient(client, "", 0)
+	require.NoError(t, err)
+
+	categories, err := detector.Detect(context.Background(), "Call John at 555-0100, SSN 123-45-6789")
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{models.PIICategorySSN, models.PIICategoryPhone}, categories)
+}
+
+func TestDetect_SplitsLongText(t *testing.T) {
+	client := &fakeComprehend{}
+	detector, err := NewComprehendDetectorWithClient(client, "en", 0.8)
+	require.NoError(t, err)
+
+	// Two-byte characters straddle the chunk boundary
+	text := "a" + strings.Repeat("é", maxChunkBytes)
+	_, err = detector.Detect(context.Background(), text)
+
+	require.NoError(t, err)
+	require.Len(t, client.texts, 3)
+	for _, chunk := range client.texts {
+		assert.LessOrEqual(t, len(chunk), maxChunkBytes)
+		assert.True(t, utf8.ValidString(chunk))
+	}
+	assert.Equal(t, text, strings.Join(client.texts, ""))
+}
+
+func TestDetect_SkipsBlankText(t *testing.T) {
+	client := &fakeComprehend{}
+	detector, err := NewComprehendDetectorWithClient(client, "en", 0.8)
+	require.NoError(t, err)
+
+	categories, err := detector.Detect(context.Background(), " \n\t")
+
+	require.NoError(t, err)
+	assert.Empty(t, categories)
+	assert.Empty(t, client.texts)
+}
+
+func TestDetect_ReturnsClientErrors(t *testing.T) {
+	client := &fakeComprehend{err: assert.AnError}
+	detector, err := NewComprehendDetectorWithClient(client, "en", 0.8)
+	require.NoError(t, err)
+
+	_, err = detector.Detect(context.Background(), "some text")
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,129 @@
+// Package regex provides a PII detector finding personal data in document text with built-in
+// patterns. Candidates are checked beyond the pattern where the format allows it, such as card
+// numbers with the Luhn checksum, to keep false positives down.
+package regex
+
+import (
+	"context"
+	"math/big"
+	"regexp"
+	"strings"
+
+	"../../../domain/models"
+	"../../../domain/services"
+)
+
+// Patterns of the personal data the detector finds. Social security numbers are only recognized in
+// their dashed form, since any nine digits would match otherwise.
+var (
+	ssnPattern        = regexp.MustCompile(`\b(\d{3})-(\d{2})-(\d{4})\b`)
+	creditCardPattern = regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`)
+	emailPattern      = regexp.MustCompile(`\b[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}\b`)
+	ibanPattern       = regexp.MustCompile(`\b[A-Z]{2}\d{2}(?: ?[A-Z0-9]{4}){2,7}(?: ?[A-Z0-9]{1,3})?\b`)
+)
+
+// regexDetector implements the PIIDetector interface with regular expressions
+type regexDetector struct{}
+
+// NewRegexDetector creates a PII detector finding social security numbers, payment card numbers,
+// email addresses and IBANs. Phone numbers are left to detectors that understand context.
+func NewRegexDetector() services.PIIDetector {
+	return &regexDetector{}
+}
+
+// Name returns the name tenants select the detector by
+func (d *regexDetector) Name() string {
+	return models.PIIDetectorRegex
+}
+
+// Detect returns the categories of personal data found in text
+func (d *regexDetector) Detect(ctx context.Context, text string) ([]string, error) {
+	var categories []string
+	if containsSSN(text) {
+		categories = append(categories, models.PIICategorySSN)
+	}
+	if containsCardNumber(text) {
+		categories = append(categories, models.PIICategoryCreditCard)
+	}
+	if emailPattern.MatchString(text) {
+		categories = append(categories, models.PIICategoryEmail)
+	}
+	if containsIBAN(text) {
+		categories = append(categories, models.PIICategoryBankAccount)
+	}
+	return categories, nil
+}
+
+// containsSSN checks if text contains a social security number. Area numbers 000, 666 and 900-999,
+// group 00 and serial 0000 are never issued.
+func containsSSN(text string) bool {
+	for _, match := range ssnPattern.FindAllStringSubmatch(text, -1) {
+		area, group, serial := match[1], match[2], match[3]
+		if area == "000" || area == "666" || area[0] == '9' || group == "00" || serial == "0000" {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// containsCardNumber checks if text contains a payment card number passing the Luhn checksum
+func containsCardNumber(text string) bool {
+	for _, match := range creditCardPattern.FindAllString(text, -1) {
+		digits := strings.NewReplacer(" ", "", "-", "").Replace(match)
+		if luhnValid(digits) {
+			return true
+		}
+	}
+	return false
+}
+
+// luhnValid checks the Luhn checksum of a string of digits
+func luhnValid(digits string) bool {
+	sum := 0
+	double := false
+	for i := len(digits) - 1; i >= 0; i-- {
+		n := int(digits[i] - '0')
+		if double {
+			n *= 2
+			if n > 9 {
+				n -= 9
+			}
+		}
+		sum += n
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// containsIBAN checks if text contains an IBAN passing the ISO 13616 mod-97 check
+func containsIBAN(text string) bool {
+	for _, match := range ibanPattern.FindAllString(text, -1) {
+		iban := strings.ReplaceAll(match, " ", "")
+		if len(iban) >= 15 && ibanValid(iban) {
+			return true
+		}
+	}
+	return false
+}
+
+// ibanValid moves the country code and check digits of an IBAN to the end, replaces letters with
+// numbers (A = 10 ... Z = 35), and checks that the number leaves a remainder of 1 divided by 97
+func ibanValid(iban string) bool {
+	rearranged := iban[4:] + iban[:4]
+
+	var numeric strings.Builder
+	for _, r := range rearranged {
+		if r >= 'A' && r <= 'Z' {
+			numeric.WriteString(big.NewInt(int64(r-'A') + 10).String())
+		} else {
+			numeric.WriteRune(r)
+		}
+	}
+
+	n, ok := new(big.Int).SetString(numeric.String(), 10)
+	if !ok {
+		return false
+	}
+	return new(big.Int).Mod(n, big.NewInt(97)).Int64() == 1
+}
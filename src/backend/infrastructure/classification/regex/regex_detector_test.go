@@ -0,0 +1,43 @@
+package regex
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"  // v1.8.0+
+	"github.com/stretchr/testify/require" // v1.8.0+
+
+	"../../../domain/models"
+)
+
+// detect runs the detector on text
+func detect(t *testing.T, text string) []string {
+	categories, err := NewRegexDetector().Detect(context.Background(), text)
+	require.NoError(t, err)
+	return categories
+}
+
+func TestDetect_FindsEachCategory(t *testing.T) {
+	assert.Equal(t, []string{models.PIICategorySSN}, detect(t, "Employee SSN: 123-45-6789"))
+	assert.Equal(t, []string{models.PIICategoryCreditCard}, detect(t, "Card 4111 1111 1111 1111 exp 12/30"))
+	assert.Equal(t, []string{models.PIICategoryEmail}, detect(t, "Contact jane.doe@example.com for details"))
+	assert.Equal(t, []string{models.PIICategoryBankAccount}, detect(t, "Pay to GB82 WEST 1234 5698 7654 32"))
+}
+
+func TestDetect_FindsSeveralCategories(t *testing.T) {
+	categories := detect(t, "jane.doe@example.com, 123-45-6789, 5500-0000-0000-0004")
+
+	assert.Equal(t, []string{models.PIICategorySSN, models.PIICategoryCreditCard, models.PIICategoryEmail}, categories)
+}
+
+func TestDetect_IgnoresLookalikes(t *testing.T) {
+	// Never issued SSN, card number failing the Luhn checksum, IBAN failing the mod-97 check
+	assert.Empty(t, detect(t, "Ref 000-12-3456"))
+	assert.Empty(t, detect(t, "Order 4111 1111 1111 1112"))
+	assert.Empty(t, detect(t, "Code GB82 WEST 1234 5698 7654 33"))
+	assert.Empty(t, detect(t, "Quarterly report 2024, revenue grew 12%"))
+}
+
+func TestName(t *testing.T) {
+	assert.Equal(t, models.PIIDetectorRegex, NewRegexDetector().Name())
+}
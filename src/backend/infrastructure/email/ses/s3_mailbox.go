@@ -0,0 +1,135 @@
+// Package ses provides an InboundMailbox over the S3 bucket that an Amazon SES receipt rule stores
+// received emails in, one raw MIME message per object.
+package ses
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"             // v1.44.0+
+	"github.com/aws/aws-sdk-go/aws/credentials" // v1.44.0+
+	"github.com/aws/aws-sdk-go/aws/request"     // v1.44.0+
+	"github.com/aws/aws-sdk-go/aws/session"     // v1.44.0+
+	"github.com/aws/aws-sdk-go/service/s3"      // v1.44.0+
+
+	"../../../domain/services"
+	"../../../pkg/config"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+)
+
+// S3MailboxAPI is the subset of the S3 client used by the mailbox
+type S3MailboxAPI interface {
+	ListObjectsV2WithContext(ctx aws.Context, input *s3.ListObjectsV2Input, opts ...request.Option) (*s3.ListObjectsV2Output, error)
+	GetObjectWithContext(ctx aws.Context, input *s3.GetObjectInput, opts ...request.Option) (*s3.GetObjectOutput, error)
+	DeleteObjectWithContext(ctx aws.Context, input *s3.DeleteObjectInput, opts ...request.Option) (*s3.DeleteObjectOutput, error)
+}
+
+// s3Mailbox implements services.InboundMailbox over the objects under a prefix of a bucket
+type s3Mailbox struct {
+	client S3MailboxAPI
+	bucket string
+	prefix string
+}
+
+// NewS3Mailbox creates an InboundMailbox reading the emails stored in the configured bucket
+func NewS3Mailbox(cfg config.EmailInConfig) (services.InboundMailbox, error) {
+	if cfg.Bucket == "" {
+		return nil, errors.NewValidationError("inbound email bucket cannot be empty")
+	}
+
+	awsConfig := &aws.Config{
+		Region:           aws.String(cfg.Region),
+		S3ForcePathStyle: aws.Bool(cfg.ForcePathStyle),
+		DisableSSL:       aws.Bool(!cfg.UseSSL),
+	}
+	if cfg.Endpoint != "" {
+		awsConfig.Endpoint = aws.String(cfg.Endpoint)
+	}
+	if cfg.AccessKey != "" {
+		awsConfig.Credentials = credentials.NewStaticCredentials(cfg.AccessKey, cfg.SecretKey, "")
+	}
+
+	sess, err := session.NewSession(awsConfig)
+	if err != nil {
+		logger.Error("Failed to create AWS session for inbound email", "error", err)
+		return nil, errors.Wrap(err, "failed to create AWS session for inbound email")
+	}
+
+	return NewS3MailboxWithClient(s3.New(sess), cfg.Bucket, cfg.Prefix)
+}
+
+// NewS3MailboxWithClient creates an InboundMailbox reading through the given S3 client
+func NewS3MailboxWithClient(client S3MailboxAPI, bucket string, prefix string) (services.InboundMailbox, error) {
+	if client == nil {
+		return nil, errors.NewValidationError("S3 client cannot be nil")
+	}
+	if bucket == "" {
+		return nil, errors.NewValidationError("inbound email bucket cannot be empty")
+	}
+
+	prefix = strings.Trim(prefix, "/")
+	if prefix != "" {
+		prefix += "/"
+	}
+
+	return &s3Mailbox{
+		client: client,
+		bucket: bucket,
+		prefix: prefix,
+	}, nil
+}
+
+// ListMessages lists the keys of up to limit stored messages. Folder placeholder objects are skipped.
+func (m *s3Mailbox) ListMessages(ctx context.Context, limit int) ([]string, error) {
+	if limit <= 0 {
+		return nil, errors.NewValidationError("limit must be greater than zero")
+	}
+
+	output, err := m.client.ListObjectsV2WithContext(ctx, &s3.ListObjectsV2Input{
+		Bucket:  aws.String(m.bucket),
+		Prefix:  aws.String(m.prefix),
+		MaxKeys: aws.Int64(int64(limit)),
+	})
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to list inbound emails", "error", err, "bucket", m.bucket)
+		return nil, errors.NewDependencyError("failed to list inbound emails")
+	}
+
+	keys := make([]string, 0, len(output.Contents))
+	for _, object := range output.Contents {
+		key := aws.StringValue(object.Key)
+		if key == "" || strings.HasSuffix(key, "/") {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// GetMessage opens the stored message with a key
+func (m *s3Mailbox) GetMessage(ctx context.Context, key string) (io.ReadCloser, error) {
+	output, err := m.client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(m.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to get inbound email", "error", err, "bucket", m.bucket, "key", key)
+		return nil, errors.NewDependencyError("failed to get inbound email")
+	}
+	return output.Body, nil
+}
+
+// DeleteMessage deletes the stored message with a key
+func (m *s3Mailbox) DeleteMessage(ctx context.Context, key string) error {
+	_, err := m.client.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(m.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to delete inbound email", "error", err, "bucket", m.bucket, "key", key)
+		return errors.NewDependencyError("failed to delete inbound email")
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+// Package smtp provides an EmailSender that delivers email through an SMTP relay.
+package smtp
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"../../../domain/services"
+	"../../../pkg/config"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+)
+
+// defaultPort is the SMTP submission port used when none is configured
+const defaultPort = 587
+
+// smtpSender implements services.EmailSender and services.NamedEmailSender using net/smtp
+type smtpSender struct {
+	address string
+	auth    smtp.Auth
+	from    string
+}
+
+// Compile-time assertion that smtpSender can send under the names of tenants
+var _ services.NamedEmailSender = (*smtpSender)(nil)
+
+// NewSMTPSender creates an EmailSender that sends plain-text email through the configured relay
+func NewSMTPSender(cfg config.SMTPConfig) (services.EmailSender, error) {
+	if cfg.Host == "" {
+		return nil, errors.NewValidationError("smtp host cannot be empty")
+	}
+	if cfg.From == "" {
+		return nil, errors.NewValidationError("smtp from address cannot be empty")
+	}
+
+	port := cfg.Port
+	if port == 0 {
+		port = defaultPort
+	}
+
+	var auth smtp.Auth
+	if cfg.Username != "" {
+		auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+	}
+
+	return &smtpSender{
+		address: net.JoinHostPort(cfg.Host, strconv.Itoa(port)),
+		auth:    auth,
+		from:    cfg.From,
+	}, nil
+}
+
+// SendEmail sends a plain-text email to a single recipient
+func (s *smtpSender) SendEmail(ctx context.Context, to, subject, body string) error {
+	return s.send(ctx, s.from, to, subject, body)
+}
+
+// SendEmailAs sends a plain-text email to a single recipient from the configured address under
+// senderName. The envelope sender stays the configured one.
+func (s *smtpSender) SendEmailAs(ctx context.Context, senderName, to, subject, body string) error {
+	if strings.ContainsAny(senderName, "\r\n") {
+		return errors.NewValidationError("email sender name cannot contain line breaks")
+	}
+	from, err := mail.ParseAddress(s.from)
+	if err != nil {
+		return errors.Wrap(err, "invalid smtp from address")
+	}
+	from.Name = senderName
+	return s.send(ctx, from.String(), to, subject, body)
+}
+
+// send sends a plain-text email to a single recipient with the From header set to from
+func (s *smtpSender) send(ctx context.Context, from, to, subject, body string) error {
+	// Header values must not contain line breaks, which would allow header injection
+	if to == "" || strings.ContainsAny(to, "\r\n") {
+		return errors.NewValidationError("invalid email recipient")
+	}
+	if strings.ContainsAny(subject, "\r\n") {
+		return errors.NewValidationError("email subject cannot contain line breaks")
+	}
+
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\n", from)
+	fmt.Fprintf(&msg, "To: %s\r\n", to)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", subject)
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+
+	if err := smtp.SendMail(s.address, s.auth, s.from, []string{to}, []byte(msg.String())); err != nil {
+		logger.ErrorContext(ctx, "Failed to send email", "error", err.Error())
+		return errors.Wrap(err, "failed to send email")
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+// Package kms provides an EncryptionKeyService over AWS KMS, resolving the key each tenant's
+// documents are encrypted with and verifying keys tenants bring before they are used.
+package kms
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"             // v1.44.0+
+	"github.com/aws/aws-sdk-go/aws/credentials" // v1.44.0+
+	"github.com/aws/aws-sdk-go/aws/request"     // v1.44.0+
+	"github.com/aws/aws-sdk-go/aws/session"     // v1.44.0+
+	"github.com/aws/aws-sdk-go/service/kms"     // v1.44.0+
+
+	"../../../domain/repositories"
+	"../../../domain/services"
+	"../../../pkg/config"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+)
+
+// KMSAPI is the subset of the KMS client used by the key service
+type KMSAPI interface {
+	GenerateDataKeyWithContext(ctx aws.Context, input *kms.GenerateDataKeyInput, opts ...request.Option) (*kms.GenerateDataKeyOutput, error)
+	DecryptWithContext(ctx aws.Context, input *kms.DecryptInput, opts ...request.Option) (*kms.DecryptOutput, error)
+}
+
+// keyService implements services.EncryptionKeyService with tenant keys stored in tenant settings
+type keyService struct {
+	client       KMSAPI
+	tenantRepo   repositories.TenantRepository
+	defaultKeyID string
+}
+
+// NewKeyService creates an EncryptionKeyService using the region and credentials of the document
+// storage, with the storage's KMS key as the default key
+func NewKeyService(cfg config.S3Config, tenantRepo repositories.TenantRepository) (services.EncryptionKeyService, error) {
+	awsConfig := &aws.Config{
+		Region: aws.String(cfg.Region),
+	}
+	// UseSSL describes the storage endpoint, so it only applies to a custom KMS endpoint alongside it
+	if cfg.KMSEndpoint != "" {
+		awsConfig.Endpoint = aws.String(cfg.KMSEndpoint)
+		awsConfig.DisableSSL = aws.Bool(!cfg.UseSSL)
+	}
+	if cfg.AccessKey != "" {
+		awsConfig.Credentials = credentials.NewStaticCredentials(cfg.AccessKey, cfg.SecretKey, "")
+	}
+
+	sess, err := session.NewSession(awsConfig)
+	if err != nil {
+		logger.Error("Failed to create AWS session for KMS", "error", err)
+		return nil, errors.Wrap(err, "failed to create AWS session for KMS")
+	}
+
+	return NewKeyServiceWithClient(kms.New(sess), tenantRepo, cfg.KMSKeyID)
+}
+
+// NewKeyServiceWithClient creates an EncryptionKeyService verifying keys through the given KMS client
+func NewKeyServiceWithClient(client KMSAPI, tenantRepo repositories.TenantRepository, defaultKeyID string) (services.EncryptionKeyService, error) {
+	if client == nil {
+		return nil, errors.NewValidationError("KMS client cannot be nil")
+	}
+	if tenantRepo == nil {
+		return nil, errors.NewValidationError("tenant repository cannot be nil")
+	}
+
+	return &keyService{
+		client:       client,
+		tenantRepo:   tenantRepo,
+		defaultKeyID: defaultKeyID,
+	}, nil
+}
+
+// GetTenantKeyID returns the tenant's own key, or the default key if the tenant brings none
+func (s *keyService) GetTenantKeyID(ctx context.Context, tenantID string) (string, error) {
+	if tenantID == "" {
+		return "", errors.NewValidationError("tenant ID cannot be empty")
+	}
+
+	tenant, err := s.tenantRepo.GetByID(ctx, tenantID)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get tenant")
+	}
+
+	if keyID := tenant.EncryptionKeyID(); keyID != "" {
+		return keyID, nil
+	}
+	return s.defaultKeyID, nil
+}
+
+// VerifyKey generates a data key with the KMS key and decrypts it again, which takes the same
+// permissions S3 needs to encrypt and decrypt objects with the key
+func (s *keyService) VerifyKey(ctx context.Context, keyID string) error {
+	if keyID == "" {
+		return errors.NewValidationError("key ID cannot be empty")
+	}
+
+	dataKey, err := s.client.GenerateDataKeyWithContext(ctx, &kms.GenerateDataKeyInput{
+		KeyId:   aws.String(keyID),
+		KeySpec: aws.String(kms.DataKeySpecAes256),
+	})
+	if err != nil {
+		logger.WarnContext(ctx, "KMS key cannot generate data keys", "key_id", keyID, "error", err.Error())
+		return errors.NewValidationError("KMS key cannot be used to encrypt documents: " + err.Error())
+	}
+
+	_, err = s.client.DecryptWithContext(ctx, &kms.DecryptInput{
+		KeyId:          aws.String(keyID),
+		CiphertextBlob: dataKey.CiphertextBlob,
+	})
+	if err != nil {
+		logger.WarnContext(ctx, "KMS key cannot decrypt data keys", "key_id", keyID, "error", err.Error())
+		return errors.NewValidationError("KMS key cannot be used to decrypt documents: " + err.Error())
+	}
+
+	return nil
+}
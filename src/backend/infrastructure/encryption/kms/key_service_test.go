@@ -0,0 +1,112 @@
+package kms
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"         // v1.44.0+
+	"github.com/aws/aws-sdk-go/aws/request" // v1.44.0+
+	"github.com/aws/aws-sdk-go/service/kms" // v1.44.0+
+	"github.com/stretchr/testify/assert"    // v1.8.0+
+	"github.com/stretchr/testify/mock"      // v1.8.0+
+	"github.com/stretchr/testify/require"   // v1.8.0+
+
+	"../../../domain/models"
+	"../../../domain/repositories"
+	pkgErrors "../../../pkg/errors"
+)
+
+const (
+	testDefaultKeyID = "arn:aws:kms:us-east-1:111111111111:key/platform"
+	testTenantKeyID  = "arn:aws:kms:us-east-1:222222222222:key/tenant"
+)
+
+// mockKMSClient is a mock implementation of the KMSAPI interface for testing
+type mockKMSClient struct {
+	mock.Mock
+}
+
+func (m *mockKMSClient) GenerateDataKeyWithContext(ctx aws.Context, input *kms.GenerateDataKeyInput, opts ...request.Option) (*kms.GenerateDataKeyOutput, error) {
+	args := m.Called(ctx, input)
+	if output := args.Get(0); output != nil {
+		return output.(*kms.GenerateDataKeyOutput), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *mockKMSClient) DecryptWithContext(ctx aws.Context, input *kms.DecryptInput, opts ...request.Option) (*kms.DecryptOutput, error) {
+	args := m.Called(ctx, input)
+	if output := args.Get(0); output != nil {
+		return output.(*kms.DecryptOutput), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// mockTenantRepository mocks the TenantRepository methods used by the key service
+type mockTenantRepository struct {
+	repositories.TenantRepository
+	mock.Mock
+}
+
+func (m *mockTenantRepository) GetByID(ctx context.Context, id string) (*models.Tenant, error) {
+	args := m.Called(ctx, id)
+	if tenant := args.Get(0); tenant != nil {
+		return tenant.(*models.Tenant), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// TestGetTenantKeyID tests that tenants use their own key and fall back to the default key
+func TestGetTenantKeyID(t *testing.T) {
+	ctx := context.Background()
+	tenantRepo := new(mockTenantRepository)
+	service, err := NewKeyServiceWithClient(new(mockKMSClient), tenantRepo, testDefaultKeyID)
+	require.NoError(t, err)
+
+	byok := models.NewTenant("byok")
+	byok.SetSetting(models.TenantSettingEncryptionKeyID, testTenantKeyID)
+	tenantRepo.On("GetByID", ctx, "tenant-byok").Return(byok, nil)
+	tenantRepo.On("GetByID", ctx, "tenant-default").Return(models.NewTenant("default"), nil)
+
+	keyID, err := service.GetTenantKeyID(ctx, "tenant-byok")
+	assert.NoError(t, err)
+	assert.Equal(t, testTenantKeyID, keyID)
+
+	keyID, err = service.GetTenantKeyID(ctx, "tenant-default")
+	assert.NoError(t, err)
+	assert.Equal(t, testDefaultKeyID, keyID)
+}
+
+// TestVerifyKey tests that a key is usable when data keys can be generated and decrypted with it
+func TestVerifyKey(t *testing.T) {
+	ctx := context.Background()
+	client := new(mockKMSClient)
+	service, err := NewKeyServiceWithClient(client, new(mockTenantRepository), testDefaultKeyID)
+	require.NoError(t, err)
+
+	client.On("GenerateDataKeyWithContext", ctx, mock.MatchedBy(func(input *kms.GenerateDataKeyInput) bool {
+		return aws.StringValue(input.KeyId) == testTenantKeyID
+	})).Return(&kms.GenerateDataKeyOutput{CiphertextBlob: []byte("encrypted")}, nil)
+	client.On("DecryptWithContext", ctx, mock.MatchedBy(func(input *kms.DecryptInput) bool {
+		return string(input.CiphertextBlob) == "encrypted"
+	})).Return(&kms.DecryptOutput{}, nil)
+
+	assert.NoError(t, service.VerifyKey(ctx, testTenantKeyID))
+	client.AssertExpectations(t)
+}
+
+// TestVerifyKey_AccessDenied tests that keys the platform may not use are rejected
+func TestVerifyKey_AccessDenied(t *testing.T) {
+	ctx := context.Background()
+	client := new(mockKMSClient)
+	service, err := NewKeyServiceWithClient(client, new(mockTenantRepository), testDefaultKeyID)
+	require.NoError(t, err)
+
+	client.On("GenerateDataKeyWithContext", ctx, mock.Anything).Return(nil, errors.New("AccessDeniedException"))
+
+	err = service.VerifyKey(ctx, testTenantKeyID)
+
+	assert.True(t, pkgErrors.IsValidationError(err))
+	client.AssertNotCalled(t, "DecryptWithContext", mock.Anything, mock.Anything)
+}
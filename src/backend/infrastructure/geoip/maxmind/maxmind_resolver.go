@@ -0,0 +1,57 @@
+// Package maxmind provides a GeoIP resolver over MaxMind country databases, such as GeoLite2-Country
+// or GeoIP2-Country, read from a local file. Addresses are looked up in memory; nothing is sent to MaxMind.
+package maxmind
+
+import (
+	"net"
+
+	"github.com/oschwald/geoip2-golang" // v1.9.0+
+
+	"../../../domain/services"
+	"../../../pkg/errors"
+)
+
+// CountryReader is the subset of the MaxMind database reader used by the resolver
+type CountryReader interface {
+	Country(ip net.IP) (*geoip2.Country, error)
+}
+
+// maxMindResolver implements the GeoIPResolver interface with a MaxMind database
+type maxMindResolver struct {
+	reader CountryReader
+}
+
+// NewMaxMindResolver creates a GeoIP resolver reading the MaxMind database at path. The database is
+// read once; the process must be restarted to pick up a newer one.
+func NewMaxMindResolver(path string) (services.GeoIPResolver, error) {
+	if path == "" {
+		return nil, errors.NewValidationError("GeoIP database path cannot be empty")
+	}
+
+	reader, err := geoip2.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open GeoIP database")
+	}
+	return NewMaxMindResolverWithReader(reader)
+}
+
+// NewMaxMindResolverWithReader creates a GeoIP resolver over an open database reader
+func NewMaxMindResolverWithReader(reader CountryReader) (services.GeoIPResolver, error) {
+	if reader == nil {
+		return nil, errors.NewValidationError("GeoIP database reader cannot be nil")
+	}
+	return &maxMindResolver{reader: reader}, nil
+}
+
+// Country returns the country the address is located in, or else the country the address is
+// registered in, such as for addresses of mobile and satellite networks
+func (r *maxMindResolver) Country(ip net.IP) (string, error) {
+	record, err := r.reader.Country(ip)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to look up country of address")
+	}
+	if record.Country.IsoCode != "" {
+		return record.Country.IsoCode, nil
+	}
+	return record.RegisteredCountry.IsoCode, nil
+}
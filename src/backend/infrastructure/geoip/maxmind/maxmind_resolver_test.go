@@ -0,0 +1,76 @@
+package maxmind
+
+import (
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/oschwald/geoip2-golang"   // v1.9.0+
+	"github.com/stretchr/testify/assert"  // v1.8.0+
+	"github.com/stretchr/testify/require" // v1.8.0+
+)
+
+// fakeCountryReader returns the records it holds by address
+type fakeCountryReader struct {
+	records map[string]*geoip2.Country
+	err     error
+}
+
+func (f *fakeCountryReader) Country(ip net.IP) (*geoip2.Country, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	if record, ok := f.records[ip.String()]; ok {
+		return record, nil
+	}
+	return &geoip2.Country{}, nil
+}
+
+// countryRecord returns a record located in country and registered in registered
+func countryRecord(country, registered string) *geoip2.Country {
+	record := &geoip2.Country{}
+	record.Country.IsoCode = country
+	record.RegisteredCountry.IsoCode = registered
+	return record
+}
+
+func TestCountry_PrefersLocation(t *testing.T) {
+	resolver, err := NewMaxMindResolverWithReader(&fakeCountryReader{records: map[string]*geoip2.Country{
+		"203.0.113.7":  countryRecord("DE", "NL"),
+		"198.51.100.9": countryRecord("", "US"),
+	}})
+	require.NoError(t, err)
+
+	country, err := resolver.Country(net.ParseIP("203.0.113.7"))
+	require.NoError(t, err)
+	assert.Equal(t, "DE", country)
+
+	country, err = resolver.Country(net.ParseIP("198.51.100.9"))
+	require.NoError(t, err)
+	assert.Equal(t, "US", country)
+}
+
+func TestCountry_UnknownAddress(t *testing.T) {
+	resolver, err := NewMaxMindResolverWithReader(&fakeCountryReader{})
+	require.NoError(t, err)
+
+	country, err := resolver.Country(net.ParseIP("192.0.2.1"))
+
+	require.NoError(t, err)
+	assert.Empty(t, country)
+}
+
+func TestCountry_LookupError(t *testing.T) {
+	resolver, err := NewMaxMindResolverWithReader(&fakeCountryReader{err: fmt.Errorf("corrupt database")})
+	require.NoError(t, err)
+
+	_, err = resolver.Country(net.ParseIP("192.0.2.1"))
+
+	assert.Error(t, err)
+}
+
+func TestNewMaxMindResolver_MissingDatabase(t *testing.T) {
+	_, err := NewMaxMindResolver(t.TempDir() + "/missing.mmdb")
+
+	assert.Error(t, err)
+}
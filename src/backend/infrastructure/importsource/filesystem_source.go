@@ -0,0 +1,112 @@
+// Package importsource provides the sources bulk imports read legacy archives from: directory trees
+// mounted on the worker and the objects under a prefix of an S3 bucket.
+package importsource
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"../../domain/services"
+	"../../pkg/errors"
+)
+
+// filesystemSource implements services.ImportSource over the files of a directory tree
+type filesystemSource struct {
+	root string
+}
+
+// NewFilesystemSource creates an ImportSource reading the files below the directory at root.
+// Symbolic links and special files are not imported, so a link cannot lead the import out of the
+// tree or into a loop.
+func NewFilesystemSource(root string) (services.ImportSource, error) {
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, errors.NewValidationError("import source directory cannot be read: " + err.Error())
+	}
+	if !info.IsDir() {
+		return nil, errors.NewValidationError("import source " + root + " is not a directory")
+	}
+
+	return &filesystemSource{root: root}, nil
+}
+
+// Walk walks the tree in byte order of the keys. Directories sort as their name followed by a
+// slash, which is how their name appears in the keys of their files, and directories whose files
+// all sort before the given key are not read at all.
+func (s *filesystemSource) Walk(ctx context.Context, after string, fn func(file services.ImportSourceFile) error) error {
+	return s.walkDir(ctx, "", after, fn)
+}
+
+// walkDir walks the directory with a key, which is empty for the root
+func (s *filesystemSource) walkDir(ctx context.Context, dir string, after string, fn func(file services.ImportSourceFile) error) error {
+	entries, err := os.ReadDir(filepath.Join(s.root, filepath.FromSlash(dir)))
+	if err != nil {
+		return errors.Wrap(err, "failed to read directory "+dir)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return sortName(entries[i]) < sortName(entries[j])
+	})
+
+	for _, entry := range entries {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		key := entry.Name()
+		if dir != "" {
+			key = dir + "/" + key
+		}
+
+		if entry.IsDir() {
+			prefix := key + "/"
+			if prefix <= after && !strings.HasPrefix(after, prefix) {
+				continue
+			}
+			if err := s.walkDir(ctx, key, after, fn); err != nil {
+				return err
+			}
+			continue
+		}
+		if !entry.Type().IsRegular() || key <= after {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return errors.Wrap(err, "failed to read file "+key)
+		}
+		if err := fn(services.ImportSourceFile{Key: key, Size: info.Size()}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Open opens the file with a key
+func (s *filesystemSource) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	name := filepath.FromSlash(key)
+	if !filepath.IsLocal(name) {
+		return nil, errors.NewValidationError("import source key " + key + " is outside the source")
+	}
+
+	file, err := os.Open(filepath.Join(s.root, name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.NewResourceNotFoundError("file " + key + " not found in import source")
+		}
+		return nil, errors.Wrap(err, "failed to open file "+key)
+	}
+	return file, nil
+}
+
+// sortName returns the name an entry sorts by in a walk
+func sortName(entry os.DirEntry) string {
+	if entry.IsDir() {
+		return entry.Name() + "/"
+	}
+	return entry.Name()
+}
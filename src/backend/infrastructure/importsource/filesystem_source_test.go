@@ -0,0 +1,87 @@
+package importsource
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"  // v1.8.0+
+	"github.com/stretchr/testify/require" // v1.8.0+
+
+	"../../domain/services"
+	"../../pkg/errors"
+)
+
+// createTestTree creates a directory tree whose byte order differs from the order of a naive
+// recursive walk: "a-b.txt" sorts before "a/", and "a.txt" after it
+func createTestTree(t *testing.T) string {
+	root := t.TempDir()
+	for name, content := range map[string]string{
+		"a-b.txt":     "1",
+		"a/z.txt":     "22",
+		"a/b/c.txt":   "333",
+		"a.txt":       "4444",
+		"b/empty.txt": "",
+	} {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+	require.NoError(t, os.Symlink(filepath.Join(root, "a.txt"), filepath.Join(root, "link.txt")))
+	return root
+}
+
+// walkKeys returns the keys of the files a walk after a key visits
+func walkKeys(t *testing.T, source services.ImportSource, after string) []string {
+	var keys []string
+	err := source.Walk(context.Background(), after, func(file services.ImportSourceFile) error {
+		keys = append(keys, file.Key)
+		return nil
+	})
+	require.NoError(t, err)
+	return keys
+}
+
+func TestFilesystemSource_WalkInByteOrder(t *testing.T) {
+	source, err := NewFilesystemSource(createTestTree(t))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"a-b.txt", "a.txt", "a/b/c.txt", "a/z.txt", "b/empty.txt"}, walkKeys(t, source, ""))
+}
+
+func TestFilesystemSource_WalkAfterKey(t *testing.T) {
+	source, err := NewFilesystemSource(createTestTree(t))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"a/z.txt", "b/empty.txt"}, walkKeys(t, source, "a/b/c.txt"))
+	assert.Equal(t, []string{"a/b/c.txt", "a/z.txt", "b/empty.txt"}, walkKeys(t, source, "a.txt"))
+	assert.Equal(t, []string{"b/empty.txt"}, walkKeys(t, source, "a/z.txt"))
+	assert.Empty(t, walkKeys(t, source, "b/empty.txt"))
+}
+
+func TestFilesystemSource_Open(t *testing.T) {
+	source, err := NewFilesystemSource(createTestTree(t))
+	require.NoError(t, err)
+
+	file, err := source.Open(context.Background(), "a/b/c.txt")
+	require.NoError(t, err)
+	content, err := io.ReadAll(file)
+	require.NoError(t, err)
+	assert.NoError(t, file.Close())
+	assert.Equal(t, "333", string(content))
+
+	_, err = source.Open(context.Background(), "a/missing.txt")
+	assert.True(t, errors.IsResourceNotFoundError(err))
+
+	_, err = source.Open(context.Background(), "../a.txt")
+	assert.True(t, errors.IsValidationError(err))
+}
+
+func TestNewFilesystemSource_NotADirectory(t *testing.T) {
+	root := createTestTree(t)
+
+	_, err := NewFilesystemSource(filepath.Join(root, "a.txt"))
+	assert.True(t, errors.IsValidationError(err))
+}
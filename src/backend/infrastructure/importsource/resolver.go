@@ -0,0 +1,81 @@
+package importsource
+
+import (
+	"context"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"             // v1.44.0+
+	"github.com/aws/aws-sdk-go/aws/credentials" // v1.44.0+
+	"github.com/aws/aws-sdk-go/aws/session"     // v1.44.0+
+	"github.com/aws/aws-sdk-go/service/s3"      // v1.44.0+
+
+	"../../domain/services"
+	"../../pkg/config"
+	"../../pkg/errors"
+)
+
+// s3Scheme is the scheme of the locations of S3 prefixes
+const s3Scheme = "s3://"
+
+// resolver implements services.ImportSourceResolver
+type resolver struct {
+	cfg config.BulkImportConfig
+}
+
+// NewResolver creates an ImportSourceResolver opening directories on the local filesystem, and S3
+// prefixes with the S3 settings of cfg. When cfg names no region, the region, endpoint and
+// credentials of the document storage are used.
+func NewResolver(cfg config.BulkImportConfig, storage config.S3Config) services.ImportSourceResolver {
+	if cfg.Region == "" {
+		cfg.Region = storage.Region
+		cfg.Endpoint = storage.Endpoint
+		cfg.AccessKey = storage.AccessKey
+		cfg.SecretKey = storage.SecretKey
+		cfg.UseSSL = storage.UseSSL
+		cfg.ForcePathStyle = storage.ForcePathStyle
+	}
+	return &resolver{cfg: cfg}
+}
+
+// Resolve returns the source at a location
+func (r *resolver) Resolve(ctx context.Context, location string) (services.ImportSource, error) {
+	if strings.HasPrefix(location, s3Scheme) {
+		parsed, err := url.Parse(location)
+		if err != nil || parsed.Host == "" {
+			return nil, errors.NewValidationError("import source " + location + " is not an s3://bucket/prefix URL")
+		}
+		client, err := r.newS3Client()
+		if err != nil {
+			return nil, err
+		}
+		return NewS3Source(client, parsed.Host, parsed.Path)
+	}
+
+	if !filepath.IsAbs(location) {
+		return nil, errors.NewValidationError("import source must be the absolute path of a directory or an s3://bucket/prefix URL")
+	}
+	return NewFilesystemSource(location)
+}
+
+// newS3Client creates an S3 client with the settings of the resolver
+func (r *resolver) newS3Client() (*s3.S3, error) {
+	awsConfig := &aws.Config{
+		Region:           aws.String(r.cfg.Region),
+		S3ForcePathStyle: aws.Bool(r.cfg.ForcePathStyle),
+		DisableSSL:       aws.Bool(!r.cfg.UseSSL),
+	}
+	if r.cfg.Endpoint != "" {
+		awsConfig.Endpoint = aws.String(r.cfg.Endpoint)
+	}
+	if r.cfg.AccessKey != "" {
+		awsConfig.Credentials = credentials.NewStaticCredentials(r.cfg.AccessKey, r.cfg.SecretKey, "")
+	}
+
+	sess, err := session.NewSession(awsConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create AWS session for import source")
+	}
+	return s3.New(sess), nil
+}
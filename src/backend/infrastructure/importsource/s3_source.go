@@ -0,0 +1,107 @@
+package importsource
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"         // v1.44.0+
+	"github.com/aws/aws-sdk-go/aws/awserr"  // v1.44.0+
+	"github.com/aws/aws-sdk-go/aws/request" // v1.44.0+
+	"github.com/aws/aws-sdk-go/service/s3"  // v1.44.0+
+
+	"../../domain/services"
+	"../../pkg/errors"
+	"../../pkg/logger"
+)
+
+// S3SourceAPI is the subset of the S3 client used by the import source
+type S3SourceAPI interface {
+	ListObjectsV2WithContext(ctx aws.Context, input *s3.ListObjectsV2Input, opts ...request.Option) (*s3.ListObjectsV2Output, error)
+	GetObjectWithContext(ctx aws.Context, input *s3.GetObjectInput, opts ...request.Option) (*s3.GetObjectOutput, error)
+}
+
+// s3Source implements services.ImportSource over the objects under a prefix of a bucket
+type s3Source struct {
+	client S3SourceAPI
+	bucket string
+	prefix string
+}
+
+// NewS3Source creates an ImportSource reading the objects under a prefix of a bucket through the
+// given S3 client. The keys of the files are the keys of the objects without the prefix.
+func NewS3Source(client S3SourceAPI, bucket string, prefix string) (services.ImportSource, error) {
+	if client == nil {
+		return nil, errors.NewValidationError("S3 client cannot be nil")
+	}
+	if bucket == "" {
+		return nil, errors.NewValidationError("import source bucket cannot be empty")
+	}
+
+	prefix = strings.Trim(prefix, "/")
+	if prefix != "" {
+		prefix += "/"
+	}
+
+	return &s3Source{
+		client: client,
+		bucket: bucket,
+		prefix: prefix,
+	}, nil
+}
+
+// Walk lists the objects a page at a time. S3 lists keys in byte order and starts the listing
+// after the given key itself, so a resumed import does not list the objects it already handled.
+// Folder placeholder objects are skipped.
+func (s *s3Source) Walk(ctx context.Context, after string, fn func(file services.ImportSourceFile) error) error {
+	input := &s3.ListObjectsV2Input{
+		Bucket: aws.String(s.bucket),
+		Prefix: aws.String(s.prefix),
+	}
+	if after != "" {
+		input.StartAfter = aws.String(s.prefix + after)
+	}
+
+	for {
+		output, err := s.client.ListObjectsV2WithContext(ctx, input)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			logger.ErrorContext(ctx, "Failed to list import source", "error", err, "bucket", s.bucket, "prefix", s.prefix)
+			return errors.NewDependencyError("failed to list import source")
+		}
+
+		for _, object := range output.Contents {
+			key := strings.TrimPrefix(aws.StringValue(object.Key), s.prefix)
+			if key == "" || strings.HasSuffix(key, "/") {
+				continue
+			}
+			if err := fn(services.ImportSourceFile{Key: key, Size: aws.Int64Value(object.Size)}); err != nil {
+				return err
+			}
+		}
+
+		if !aws.BoolValue(output.IsTruncated) {
+			return nil
+		}
+		input.ContinuationToken = output.NextContinuationToken
+		input.StartAfter = nil
+	}
+}
+
+// Open opens the object of the file with a key
+func (s *s3Source) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	output, err := s.client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.prefix + key),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == s3.ErrCodeNoSuchKey {
+			return nil, errors.NewResourceNotFoundError("file " + key + " not found in import source")
+		}
+		logger.ErrorContext(ctx, "Failed to get import source object", "error", err, "bucket", s.bucket, "key", s.prefix+key)
+		return nil, errors.NewDependencyError("failed to get import source object")
+	}
+	return output.Body, nil
+}
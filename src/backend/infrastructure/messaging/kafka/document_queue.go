@@ -0,0 +1,568 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kgo" // v1.17.0
+
+	"../../../domain/services"
+	"../../../pkg/config"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+)
+
+// Topics of the scan queue, named like the SQS queues
+const (
+	scanTasksTopic     = "document-scan-tasks"
+	highScanTasksTopic = "document-scan-tasks-high"
+	lowScanTasksTopic  = "document-scan-tasks-low"
+	dlqScanTasksTopic  = "document-scan-tasks-dlq"
+)
+
+// maxBufferedTasks is the number of fetched tasks waiting for a worker. It is kept small so that
+// high priority tasks do not queue up behind a large batch of fetched low priority ones.
+const maxBufferedTasks = 10
+
+// defaultScanWaitTime is how long Dequeue waits for a task when config.Scanning.Workers leaves it unset
+const defaultScanWaitTime = 20 * time.Second
+
+// scanPriorities are the priorities with a topic of their own, from highest to lowest
+var scanPriorities = []string{services.ScanPriorityHigh, services.ScanPriorityNormal, services.ScanPriorityLow}
+
+// defaultPriorityWeights are the weights the priority topics are consumed with when
+// config.Scanning.Workers leaves them unset
+var defaultPriorityWeights = map[string]int{
+	services.ScanPriorityHigh:   6,
+	services.ScanPriorityNormal: 3,
+	services.ScanPriorityLow:    1,
+}
+
+// DocumentScanQueue implements the services.ScanQueue interface with Kafka topics. Tasks are
+// produced to a topic per priority and consumed by the scan workers as a consumer group, taking
+// fetched tasks by priority weight like the SQS queue does.
+//
+// Kafka acknowledges by offset rather than by message, so the offset of a partition is only
+// committed up to the first task that is still being processed. Tasks of a partition that is
+// reassigned before they were committed are delivered again; scanning a version twice has the same
+// outcome.
+type DocumentScanQueue struct {
+	producer     *kgo.Client
+	consumerOpts []kgo.Opt
+	topics       map[string]string // Topic of each priority
+	priorities   map[string]string // Priority of each topic
+	dlqTopic     string
+	waitTime     time.Duration
+
+	// Dequeue is serialized; the tasks fetched by one poll are taken by the calls that follow
+	pollMu sync.Mutex
+
+	// The consumer joins the group on the first Dequeue, so that processes only queueing tasks are
+	// not assigned partitions. It is set with both pollMu and mu held.
+	mu       sync.Mutex
+	consumer *kgo.Client
+	buffered map[string][]*kgo.Record // Fetched records not dequeued yet, by priority
+	offsets  map[string]*partitionOffsets
+	weights  map[string]int
+	credits  map[string]int
+
+	commitMu  sync.Mutex
+	committed map[string]int64 // Last committed offset of each partition
+}
+
+// NewDocumentScanQueue creates a DocumentScanQueue producing with producer. The scan workers consume
+// with a client of their own created from opts.
+func NewDocumentScanQueue(producer *kgo.Client, opts []kgo.Opt, cfg config.Config) (*DocumentScanQueue, error) {
+	if producer == nil {
+		return nil, errors.NewValidationError("kafka client cannot be nil")
+	}
+
+	prefix := topicPrefix(cfg)
+	group := cfg.Messaging.Kafka.ConsumerGroup
+	if group == "" {
+		group = topicName(prefix, "scan-workers")
+	}
+
+	waitTime := defaultScanWaitTime
+	if cfg.Scanning.Workers.WaitTime != "" {
+		parsed, err := time.ParseDuration(cfg.Scanning.Workers.WaitTime)
+		if err != nil || parsed < 0 {
+			return nil, errors.NewValidationError(fmt.Sprintf("invalid scan queue wait time: %s", cfg.Scanning.Workers.WaitTime))
+		}
+		waitTime = parsed
+	}
+
+	weights, err := priorityWeights(cfg.Scanning.Workers.PriorityWeights)
+	if err != nil {
+		return nil, err
+	}
+
+	q := &DocumentScanQueue{
+		producer: producer,
+		topics: map[string]string{
+			services.ScanPriorityHigh:   topicName(prefix, highScanTasksTopic),
+			services.ScanPriorityNormal: topicName(prefix, scanTasksTopic),
+			services.ScanPriorityLow:    topicName(prefix, lowScanTasksTopic),
+		},
+		priorities: make(map[string]string),
+		dlqTopic:   topicName(prefix, dlqScanTasksTopic),
+		waitTime:   waitTime,
+		buffered:   make(map[string][]*kgo.Record),
+		offsets:    make(map[string]*partitionOffsets),
+		weights:    weights,
+		credits:    make(map[string]int),
+		committed:  make(map[string]int64),
+	}
+	for priority, topic := range q.topics {
+		q.priorities[topic] = priority
+	}
+
+	q.consumerOpts = append(append([]kgo.Opt{}, opts...),
+		kgo.ConsumerGroup(group),
+		kgo.ConsumeTopics(q.topics[services.ScanPriorityHigh], q.topics[services.ScanPriorityNormal], q.topics[services.ScanPriorityLow]),
+		// Tasks queued before the group first joined are scanned too
+		kgo.ConsumeResetOffset(kgo.NewOffset().AtStart()),
+		kgo.DisableAutoCommit(),
+		kgo.OnPartitionsRevoked(q.forgetPartitions),
+		kgo.OnPartitionsLost(q.forgetPartitions),
+	)
+
+	return q, nil
+}
+
+// Ensure DocumentScanQueue implements services.ScanQueue
+var _ services.ScanQueue = (*DocumentScanQueue)(nil)
+
+// priorityWeights returns the weights of the priority topics, or the defaults when none is set
+func priorityWeights(cfg config.ScanPriorityWeights) (map[string]int, error) {
+	if cfg.High < 0 || cfg.Normal < 0 || cfg.Low < 0 {
+		return nil, errors.NewValidationError("scan queue priority weights cannot be negative")
+	}
+	if cfg.High == 0 && cfg.Normal == 0 && cfg.Low == 0 {
+		return defaultPriorityWeights, nil
+	}
+	return map[string]int{
+		services.ScanPriorityHigh:   cfg.High,
+		services.ScanPriorityNormal: cfg.Normal,
+		services.ScanPriorityLow:    cfg.Low,
+	}, nil
+}
+
+// topicFor returns the topic of a priority. Tasks without a priority go to the normal priority topic.
+func (q *DocumentScanQueue) topicFor(priority string) string {
+	if topic, ok := q.topics[priority]; ok {
+		return topic
+	}
+	return q.topics[services.ScanPriorityNormal]
+}
+
+// Enqueue adds a document to the scanning queue
+func (q *DocumentScanQueue) Enqueue(ctx context.Context, task services.ScanTask) error {
+	if err := q.produce(ctx, q.topicFor(task.Priority), task.DocumentID, task); err != nil {
+		return errors.NewDependencyError(fmt.Sprintf("failed to enqueue scan task: %v", err))
+	}
+
+	logger.InfoContext(ctx, "Document scan task enqueued successfully",
+		"document_id", task.DocumentID,
+		"tenant_id", task.TenantID,
+		"priority", task.Priority)
+
+	return nil
+}
+
+// Dequeue retrieves the next document to scan, taking it from the fetched tasks by priority weight.
+// When no task was fetched, it waits for up to the wait time for one to arrive.
+func (q *DocumentScanQueue) Dequeue(ctx context.Context) (*services.ScanTask, error) {
+	q.pollMu.Lock()
+	defer q.pollMu.Unlock()
+
+	consumer, err := q.consumerClient()
+	if err != nil {
+		return nil, err
+	}
+
+	// Pick up what was fetched in the background, so that a newly arrived high priority task can
+	// be taken before the tasks buffered earlier. Fetch errors are reported by the poll below.
+	if q.bufferedCount() < maxBufferedTasks {
+		_ = q.buffer(ctx, consumer.PollRecords(nil, maxBufferedTasks-q.bufferedCount()))
+	}
+	if task := q.next(ctx); task != nil {
+		return task, nil
+	}
+
+	pollCtx, cancel := context.WithTimeout(ctx, q.waitTime)
+	defer cancel()
+	if err := q.buffer(ctx, consumer.PollRecords(pollCtx, maxBufferedTasks)); err != nil {
+		return nil, errors.NewDependencyError(fmt.Sprintf("failed to dequeue scan task: %v", err))
+	}
+
+	return q.next(ctx), nil
+}
+
+// consumerClient returns the consumer of the scan workers, joining the group on first use
+func (q *DocumentScanQueue) consumerClient() (*kgo.Client, error) {
+	if q.consumer != nil {
+		return q.consumer, nil
+	}
+
+	consumer, err := kgo.NewClient(q.consumerOpts...)
+	if err != nil {
+		return nil, errors.NewDependencyError(fmt.Sprintf("failed to create kafka consumer: %v", err))
+	}
+	q.mu.Lock()
+	q.consumer = consumer
+	q.mu.Unlock()
+	return consumer, nil
+}
+
+// buffer adds fetched records to the buffers of their priority and tracks their offsets. Fetch
+// errors are returned unless the poll merely timed out or records were fetched anyway.
+func (q *DocumentScanQueue) buffer(ctx context.Context, fetches kgo.Fetches) error {
+	var fetchErr error
+	fetches.EachError(func(topic string, partition int32, err error) {
+		if stderrors.Is(err, context.DeadlineExceeded) || stderrors.Is(err, context.Canceled) {
+			return
+		}
+		logger.ErrorContext(ctx, "Failed to fetch scan tasks", "error", err, "topic", topic, "partition", partition)
+		fetchErr = err
+	})
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	fetched := 0
+	fetches.EachRecord(func(record *kgo.Record) {
+		key := partitionKey(record.Topic, record.Partition)
+		if q.offsets[key] == nil {
+			q.offsets[key] = &partitionOffsets{}
+		}
+		q.offsets[key].add(record)
+
+		priority := q.priorities[record.Topic]
+		q.buffered[priority] = append(q.buffered[priority], record)
+		fetched++
+	})
+
+	if fetched == 0 {
+		return fetchErr
+	}
+	return nil
+}
+
+// bufferedCount returns the number of fetched tasks waiting for a worker
+func (q *DocumentScanQueue) bufferedCount() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	count := 0
+	for _, records := range q.buffered {
+		count += len(records)
+	}
+	return count
+}
+
+// next takes the next fetched task in poll order, or returns nil when none was fetched. Records
+// that are not scan tasks are logged and skipped.
+func (q *DocumentScanQueue) next(ctx context.Context) *services.ScanTask {
+	for {
+		record := q.nextRecord()
+		if record == nil {
+			return nil
+		}
+
+		var task services.ScanTask
+		if err := json.Unmarshal(record.Value, &task); err != nil {
+			logger.ErrorContext(ctx, "Failed to unmarshal scan task from JSON",
+				"error", err,
+				"topic", record.Topic,
+				"offset", record.Offset)
+			// The record can never be processed, so it does not hold back the commits of the partition
+			_ = q.done(ctx, record.Topic, record.Partition, record.Offset)
+			continue
+		}
+
+		// The task is acknowledged by the offset it was received at
+		task.Priority = q.priorities[record.Topic]
+		task.ReceiptHandle = receiptHandle(record)
+
+		logger.InfoContext(ctx, "Document scan task dequeued successfully",
+			"document_id", task.DocumentID,
+			"tenant_id", task.TenantID,
+			"priority", task.Priority)
+		return &task
+	}
+}
+
+// nextRecord takes the first buffered record of the first priority in poll order that has one
+func (q *DocumentScanQueue) nextRecord() *kgo.Record {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for _, priority := range q.pollOrder() {
+		if records := q.buffered[priority]; len(records) > 0 {
+			q.buffered[priority] = records[1:]
+			return records[0]
+		}
+	}
+	return nil
+}
+
+// pollOrder returns the priorities in the order their buffers are taken from: the priority whose
+// turn it is by weight first, then the others from highest to lowest. It must be called with mu held.
+func (q *DocumentScanQueue) pollOrder() []string {
+	first := ""
+	total := 0
+	for _, priority := range scanPriorities {
+		weight := q.weights[priority]
+		q.credits[priority] += weight
+		total += weight
+		if first == "" || q.credits[priority] > q.credits[first] {
+			first = priority
+		}
+	}
+	q.credits[first] -= total
+
+	order := make([]string, 0, len(scanPriorities))
+	order = append(order, first)
+	for _, priority := range scanPriorities {
+		if priority != first {
+			order = append(order, priority)
+		}
+	}
+	return order
+}
+
+// Complete marks a scan task as completed, committing its offset once the tasks before it are done
+func (q *DocumentScanQueue) Complete(ctx context.Context, task services.ScanTask) error {
+	return q.acknowledge(ctx, task)
+}
+
+// Retry requeues a scan task for retry after a failure
+func (q *DocumentScanQueue) Retry(ctx context.Context, task services.ScanTask) error {
+	task.RetryCount++
+
+	if err := q.produce(ctx, q.topicFor(task.Priority), task.DocumentID, task); err != nil {
+		return errors.NewDependencyError(fmt.Sprintf("failed to requeue scan task for retry: %v", err))
+	}
+
+	// The retry is a new record, so the record of the failed attempt is done
+	if err := q.acknowledge(ctx, task); err != nil {
+		return err
+	}
+
+	logger.InfoContext(ctx, "Document scan task requeued for retry",
+		"document_id", task.DocumentID,
+		"tenant_id", task.TenantID,
+		"retry_count", task.RetryCount)
+
+	return nil
+}
+
+// DeadLetter moves a scan task to the dead letter topic after maximum retries
+func (q *DocumentScanQueue) DeadLetter(ctx context.Context, task services.ScanTask, reason string) error {
+	message := struct {
+		Task   services.ScanTask `json:"task"`
+		Reason string            `json:"reason"`
+	}{
+		Task:   task,
+		Reason: reason,
+	}
+
+	if err := q.produce(ctx, q.dlqTopic, task.DocumentID, message); err != nil {
+		return errors.NewDependencyError(fmt.Sprintf("failed to move scan task to dead letter queue: %v", err))
+	}
+
+	if err := q.acknowledge(ctx, task); err != nil {
+		return err
+	}
+
+	logger.InfoContext(ctx, "Document scan task moved to dead letter queue",
+		"document_id", task.DocumentID,
+		"tenant_id", task.TenantID,
+		"reason", reason)
+
+	return nil
+}
+
+// ExtendVisibility is a no-op for Kafka. Records are not redelivered on a timeout but only when
+// their partition is assigned to another worker, and the consumer heartbeats the group in the
+// background while a scan runs.
+func (q *DocumentScanQueue) ExtendVisibility(ctx context.Context, task services.ScanTask, timeout time.Duration) error {
+	if task.ReceiptHandle == "" {
+		return errors.NewValidationError("scan task was not dequeued")
+	}
+	return nil
+}
+
+// Close leaves the consumer group. The producer is closed by its owner.
+func (q *DocumentScanQueue) Close() {
+	q.pollMu.Lock()
+	defer q.pollMu.Unlock()
+
+	q.mu.Lock()
+	consumer := q.consumer
+	q.consumer = nil
+	q.mu.Unlock()
+
+	if consumer != nil {
+		consumer.Close()
+	}
+}
+
+// produce produces a JSON value to a topic, keyed by document so that the tasks of a document stay
+// in order
+func (q *DocumentScanQueue) produce(ctx context.Context, topic, key string, value interface{}) error {
+	valueJSON, err := json.Marshal(value)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal scan task to JSON")
+	}
+
+	return q.producer.ProduceSync(ctx, &kgo.Record{Topic: topic, Key: []byte(key), Value: valueJSON}).FirstErr()
+}
+
+// acknowledge marks the record a dequeued task was received in as done. Tasks that were not
+// dequeued have no record.
+func (q *DocumentScanQueue) acknowledge(ctx context.Context, task services.ScanTask) error {
+	if task.ReceiptHandle == "" {
+		return nil
+	}
+
+	topic, partition, offset, err := parseReceiptHandle(task.ReceiptHandle)
+	if err != nil {
+		return errors.NewValidationError(err.Error())
+	}
+	return q.done(ctx, topic, partition, offset)
+}
+
+// done marks an offset as done and commits the offset of its partition when it advanced
+func (q *DocumentScanQueue) done(ctx context.Context, topic string, partition int32, offset int64) error {
+	key := partitionKey(topic, partition)
+
+	q.mu.Lock()
+	var commit *kgo.Record
+	if offsets := q.offsets[key]; offsets != nil {
+		commit = offsets.done(offset)
+	}
+	consumer := q.consumer
+	q.mu.Unlock()
+
+	// The partition was reassigned since the task was dequeued, and the new owner delivers it again
+	if commit == nil || consumer == nil {
+		return nil
+	}
+
+	// Commits are serialized, so that a slow commit never rewinds a later one
+	q.commitMu.Lock()
+	defer q.commitMu.Unlock()
+
+	if committed, ok := q.committed[key]; ok && committed >= commit.Offset {
+		return nil
+	}
+	if err := consumer.CommitRecords(ctx, commit); err != nil {
+		return errors.NewDependencyError(fmt.Sprintf("failed to commit scan task offset: %v", err))
+	}
+	q.committed[key] = commit.Offset
+
+	return nil
+}
+
+// forgetPartitions drops the buffered records and tracked offsets of partitions that were revoked
+// or lost. Their uncommitted tasks are delivered to the worker the partitions were assigned to.
+func (q *DocumentScanQueue) forgetPartitions(ctx context.Context, client *kgo.Client, partitions map[string][]int32) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	revoked := make(map[string]bool)
+	for topic, topicPartitions := range partitions {
+		for _, partition := range topicPartitions {
+			revoked[partitionKey(topic, partition)] = true
+		}
+	}
+
+	for priority, records := range q.buffered {
+		kept := records[:0]
+		for _, record := range records {
+			if !revoked[partitionKey(record.Topic, record.Partition)] {
+				kept = append(kept, record)
+			}
+		}
+		q.buffered[priority] = kept
+	}
+
+	q.commitMu.Lock()
+	defer q.commitMu.Unlock()
+	for key := range revoked {
+		delete(q.offsets, key)
+		delete(q.committed, key)
+	}
+}
+
+// partitionOffsets tracks the records fetched from a partition until they are done, so that the
+// partition is only committed up to the first record still being processed
+type partitionOffsets struct {
+	records  []*kgo.Record // Fetched records not committed yet, in offset order
+	finished map[int64]bool
+}
+
+// add tracks a fetched record
+func (p *partitionOffsets) add(record *kgo.Record) {
+	p.records = append(p.records, record)
+}
+
+// done marks an offset as done and returns the last record of the completed run at the start of
+// the partition, which is the record to commit, or nil when the first record is still in progress
+func (p *partitionOffsets) done(offset int64) *kgo.Record {
+	if p.finished == nil {
+		p.finished = make(map[int64]bool)
+	}
+	p.finished[offset] = true
+
+	var commit *kgo.Record
+	for len(p.records) > 0 && p.finished[p.records[0].Offset] {
+		commit = p.records[0]
+		delete(p.finished, commit.Offset)
+		p.records = p.records[1:]
+	}
+
+	// Offsets before the tracked records were fetched before the partition was reassigned
+	if len(p.records) == 0 || offset < p.records[0].Offset {
+		delete(p.finished, offset)
+	}
+	return commit
+}
+
+// partitionKey identifies a partition of a topic
+func partitionKey(topic string, partition int32) string {
+	return topic + "/" + strconv.FormatInt(int64(partition), 10)
+}
+
+// receiptHandle identifies the record a task was received in
+func receiptHandle(record *kgo.Record) string {
+	return partitionKey(record.Topic, record.Partition) + "/" + strconv.FormatInt(record.Offset, 10)
+}
+
+// parseReceiptHandle returns the topic, partition and offset of a receipt handle. Topic names
+// cannot contain a slash.
+func parseReceiptHandle(handle string) (string, int32, int64, error) {
+	parts := strings.Split(handle, "/")
+	if len(parts) != 3 || parts[0] == "" {
+		return "", 0, 0, fmt.Errorf("invalid receipt handle: %s", handle)
+	}
+
+	partition, err := strconv.ParseInt(parts[1], 10, 32)
+	if err != nil {
+		return "", 0, 0, fmt.Errorf("invalid receipt handle: %s", handle)
+	}
+	offset, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return "", 0, 0, fmt.Errorf("invalid receipt handle: %s", handle)
+	}
+
+	return parts[0], int32(partition), offset, nil
+}
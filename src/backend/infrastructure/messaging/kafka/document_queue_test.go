@@ -0,0 +1,131 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kgo"
+
+	"../../../domain/services"
+	"../../../pkg/config"
+)
+
+// TestPartitionOffsets tests that a partition is only committed up to the first record in progress
+func TestPartitionOffsets(t *testing.T) {
+	offsets := &partitionOffsets{}
+	for offset := int64(10); offset < 14; offset++ {
+		offsets.add(&kgo.Record{Topic: "scan", Offset: offset})
+	}
+
+	// Records finishing out of order hold the commit back until the earlier ones are done
+	assert.Nil(t, offsets.done(11))
+	assert.Nil(t, offsets.done(13))
+
+	commit := offsets.done(10)
+	require.NotNil(t, commit)
+	assert.Equal(t, int64(11), commit.Offset)
+
+	commit = offsets.done(12)
+	require.NotNil(t, commit)
+	assert.Equal(t, int64(13), commit.Offset)
+	assert.Empty(t, offsets.records)
+	assert.Empty(t, offsets.finished)
+
+	// Offsets fetched before a reassignment are not tracked
+	assert.Nil(t, offsets.done(5))
+	assert.Empty(t, offsets.finished)
+}
+
+// TestReceiptHandle tests that a receipt handle identifies the record a task was received in
+func TestReceiptHandle(t *testing.T) {
+	handle := receiptHandle(&kgo.Record{Topic: "prod-document-scan-tasks-high", Partition: 3, Offset: 42})
+
+	topic, partition, offset, err := parseReceiptHandle(handle)
+	require.NoError(t, err)
+	assert.Equal(t, "prod-document-scan-tasks-high", topic)
+	assert.Equal(t, int32(3), partition)
+	assert.Equal(t, int64(42), offset)
+
+	for _, invalid := range []string{"", "topic", "topic/1", "topic/one/2", "topic/1/two", "/1/2"} {
+		_, _, _, err := parseReceiptHandle(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+// TestDocumentScanQueue_Topics tests the topic names of the priorities
+func TestDocumentScanQueue_Topics(t *testing.T) {
+	producer, err := kgo.NewClient(kgo.SeedBrokers("localhost:9092"))
+	require.NoError(t, err)
+	defer producer.Close()
+
+	q, err := NewDocumentScanQueue(producer, nil, config.Config{Env: "prod"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "prod-document-scan-tasks-high", q.topicFor(services.ScanPriorityHigh))
+	assert.Equal(t, "prod-document-scan-tasks", q.topicFor(services.ScanPriorityNormal))
+	assert.Equal(t, "prod-document-scan-tasks-low", q.topicFor(services.ScanPriorityLow))
+	assert.Equal(t, "prod-document-scan-tasks", q.topicFor(""))
+	assert.Equal(t, "prod-document-scan-tasks-dlq", q.dlqTopic)
+	assert.Equal(t, services.ScanPriorityLow, q.priorities["prod-document-scan-tasks-low"])
+}
+
+// TestDocumentScanQueue_next tests that fetched tasks are taken by priority weight and that records
+// that are not scan tasks are skipped
+func TestDocumentScanQueue_next(t *testing.T) {
+	producer, err := kgo.NewClient(kgo.SeedBrokers("localhost:9092"))
+	require.NoError(t, err)
+	defer producer.Close()
+
+	cfg := config.Config{Env: "prod"}
+	cfg.Scanning.Workers.PriorityWeights = config.ScanPriorityWeights{High: 1, Normal: 1, Low: 1}
+	q, err := NewDocumentScanQueue(producer, nil, cfg)
+	require.NoError(t, err)
+
+	var fetches kgo.Fetches
+	fetches = append(fetches, kgo.Fetch{Topics: []kgo.FetchTopic{
+		{Topic: "prod-document-scan-tasks-low", Partitions: []kgo.FetchPartition{{Partition: 0, Records: []*kgo.Record{
+			{Topic: "prod-document-scan-tasks-low", Offset: 0, Value: []byte(`{"DocumentID":"low-1"}`)},
+		}}}},
+		{Topic: "prod-document-scan-tasks-high", Partitions: []kgo.FetchPartition{{Partition: 0, Records: []*kgo.Record{
+			{Topic: "prod-document-scan-tasks-high", Offset: 0, Value: []byte(`not json`)},
+			{Topic: "prod-document-scan-tasks-high", Offset: 1, Value: []byte(`{"DocumentID":"high-1"}`)},
+			{Topic: "prod-document-scan-tasks-high", Offset: 2, Value: []byte(`{"DocumentID":"high-2"}`)},
+		}}}},
+	}})
+	require.NoError(t, q.buffer(context.Background(), fetches))
+	assert.Equal(t, 4, q.bufferedCount())
+
+	var dequeued []string
+	for task := q.next(context.Background()); task != nil; task = q.next(context.Background()) {
+		dequeued = append(dequeued, task.DocumentID)
+		if task.DocumentID == "high-1" {
+			assert.Equal(t, services.ScanPriorityHigh, task.Priority)
+			assert.Equal(t, "prod-document-scan-tasks-high/0/1", task.ReceiptHandle)
+		}
+	}
+
+	// Equal weights take turns, so the low priority task is not starved by the high priority ones
+	assert.Equal(t, []string{"high-1", "low-1", "high-2"}, dequeued)
+}
+
+// TestTopicForEventType tests that events are published to the topic of their type
+func TestTopicForEventType(t *testing.T) {
+	assert.Equal(t, documentEventsTopic, topicForEventType("document.uploaded"))
+	assert.Equal(t, folderEventsTopic, topicForEventType("folder.created"))
+	assert.Equal(t, defaultEventsTopic, topicForEventType("tenant.created"))
+}
+
+// TestClientOptions tests the validation of the Kafka configuration
+func TestClientOptions(t *testing.T) {
+	_, err := clientOptions(config.KafkaConfig{})
+	assert.Error(t, err)
+
+	_, err = clientOptions(config.KafkaConfig{Brokers: []string{"localhost:9092"}, SASLMechanism: "gssapi"})
+	assert.Error(t, err)
+
+	opts, err := clientOptions(config.KafkaConfig{Brokers: []string{"localhost:9092"}, TLS: true, SASLMechanism: "SCRAM-SHA-512"})
+	require.NoError(t, err)
+	assert.Len(t, opts, 3)
+}
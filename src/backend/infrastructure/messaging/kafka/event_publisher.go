@@ -0,0 +1,88 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/twmb/franz-go/pkg/kgo" // v1.17.0
+
+	"../../../domain/models"
+	"../../../domain/services"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+)
+
+// Topics events are published to, named like the SNS topics
+const (
+	documentEventsTopic = "document-events"
+	folderEventsTopic   = "folder-events"
+	defaultEventsTopic  = "general-events"
+)
+
+// eventTypeHeader is the record header holding the event type, so that consumers can filter
+// events without decoding them
+const eventTypeHeader = "event-type"
+
+// EventPublisher implements services.EventBus by producing events to Kafka topics. Events are keyed
+// by tenant, so the events of a tenant are consumed in the order they were published.
+type EventPublisher struct {
+	client *kgo.Client
+	prefix string
+}
+
+// NewEventPublisher creates an EventPublisher producing to the topics with the given prefix
+func NewEventPublisher(client *kgo.Client, prefix string) (*EventPublisher, error) {
+	if client == nil {
+		return nil, errors.NewValidationError("kafka client cannot be nil")
+	}
+
+	return &EventPublisher{
+		client: client,
+		prefix: prefix,
+	}, nil
+}
+
+// Ensure EventPublisher implements services.EventBus
+var _ services.EventBus = (*EventPublisher)(nil)
+
+// PublishEvent publishes a domain event to the topic of its type
+func (p *EventPublisher) PublishEvent(ctx context.Context, event *models.Event) error {
+	if event == nil {
+		return errors.NewValidationError("event cannot be nil")
+	}
+	if err := event.Validate(); err != nil {
+		return errors.Wrap(err, "invalid event")
+	}
+
+	eventJSON, err := json.Marshal(event)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal event to JSON")
+	}
+
+	record := &kgo.Record{
+		Topic:   topicName(p.prefix, topicForEventType(event.Type)),
+		Key:     []byte(event.TenantID),
+		Value:   eventJSON,
+		Headers: []kgo.RecordHeader{{Key: eventTypeHeader, Value: []byte(event.Type)}},
+	}
+	if err := p.client.ProduceSync(ctx, record).FirstErr(); err != nil {
+		logger.ErrorContext(ctx, "Failed to publish event to Kafka", "error", err, "eventType", event.Type, "topic", record.Topic)
+		return errors.NewDependencyError("failed to publish event to Kafka: " + err.Error())
+	}
+
+	logger.InfoContext(ctx, "Successfully published event to Kafka", "eventType", event.Type, "tenantID", event.TenantID, "topic", record.Topic)
+	return nil
+}
+
+// topicForEventType maps event types to topics the same way the SNS publisher does
+func topicForEventType(eventType string) string {
+	switch {
+	case strings.HasPrefix(eventType, "document."):
+		return documentEventsTopic
+	case strings.HasPrefix(eventType, "folder."):
+		return folderEventsTopic
+	default:
+		return defaultEventsTopic
+	}
+}
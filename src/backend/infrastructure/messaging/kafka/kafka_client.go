@@ -0,0 +1,75 @@
+// Package kafka provides Kafka implementations of the event bus and the scan queue for
+// deployments of the Document Management Platform without AWS messaging.
+package kafka
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kgo"         // v1.17.0
+	"github.com/twmb/franz-go/pkg/sasl/plain" // v1.17.0
+	"github.com/twmb/franz-go/pkg/sasl/scram" // v1.17.0
+
+	"../../../pkg/config"
+	"../../../pkg/errors"
+)
+
+// dialTimeout is how long connecting to a broker may take
+const dialTimeout = 10 * time.Second
+
+// clientOptions returns the options connecting a client to the brokers of cfg
+func clientOptions(cfg config.KafkaConfig) ([]kgo.Opt, error) {
+	if len(cfg.Brokers) == 0 {
+		return nil, errors.NewValidationError("kafka brokers are required")
+	}
+
+	opts := []kgo.Opt{kgo.SeedBrokers(cfg.Brokers...)}
+	if cfg.ClientID != "" {
+		opts = append(opts, kgo.ClientID(cfg.ClientID))
+	}
+
+	if cfg.TLS {
+		dialer := &tls.Dialer{
+			NetDialer: &net.Dialer{Timeout: dialTimeout},
+			Config: &tls.Config{
+				MinVersion:         tls.VersionTLS12,
+				InsecureSkipVerify: cfg.SkipTLSVerify,
+			},
+		}
+		opts = append(opts, kgo.Dialer(dialer.DialContext))
+	}
+
+	switch strings.ToLower(cfg.SASLMechanism) {
+	case "":
+	case "plain":
+		opts = append(opts, kgo.SASL(plain.Auth{User: cfg.Username, Pass: cfg.Password}.AsMechanism()))
+	case "scram-sha-256":
+		opts = append(opts, kgo.SASL(scram.Auth{User: cfg.Username, Pass: cfg.Password}.AsSha256Mechanism()))
+	case "scram-sha-512":
+		opts = append(opts, kgo.SASL(scram.Auth{User: cfg.Username, Pass: cfg.Password}.AsSha512Mechanism()))
+	default:
+		return nil, errors.NewValidationError(fmt.Sprintf("unsupported kafka SASL mechanism: %s", cfg.SASLMechanism))
+	}
+
+	return opts, nil
+}
+
+// topicPrefix returns the prefix of the topic names, defaulting to the environment like the SQS
+// queue names
+func topicPrefix(cfg config.Config) string {
+	if cfg.Messaging.Kafka.TopicPrefix != "" {
+		return cfg.Messaging.Kafka.TopicPrefix
+	}
+	return cfg.Env
+}
+
+// topicName returns the name of a topic with the prefix of the deployment
+func topicName(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "-" + name
+}
@@ -0,0 +1,75 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/twmb/franz-go/pkg/kgo" // v1.17.0
+
+	"../../../domain/services"
+	"../../../pkg/config"
+	"../../../pkg/errors"
+)
+
+// MessageBus implements services.MessageBus with Kafka. Events and scan tasks are produced with a
+// shared client; the scan workers consume with a client of their own.
+type MessageBus struct {
+	producer  *kgo.Client
+	events    *EventPublisher
+	scanQueue *DocumentScanQueue
+}
+
+// NewMessageBus connects to the brokers of cfg.Messaging.Kafka and creates the message bus
+func NewMessageBus(ctx context.Context, cfg config.Config) (*MessageBus, error) {
+	opts, err := clientOptions(cfg.Messaging.Kafka)
+	if err != nil {
+		return nil, err
+	}
+
+	producer, err := kgo.NewClient(opts...)
+	if err != nil {
+		return nil, errors.NewValidationError(fmt.Sprintf("invalid kafka configuration: %v", err))
+	}
+	if err := producer.Ping(ctx); err != nil {
+		producer.Close()
+		return nil, errors.NewDependencyError(fmt.Sprintf("failed to connect to kafka: %v", err))
+	}
+
+	events, err := NewEventPublisher(producer, topicPrefix(cfg))
+	if err != nil {
+		producer.Close()
+		return nil, err
+	}
+	scanQueue, err := NewDocumentScanQueue(producer, opts, cfg)
+	if err != nil {
+		producer.Close()
+		return nil, err
+	}
+
+	return &MessageBus{
+		producer:  producer,
+		events:    events,
+		scanQueue: scanQueue,
+	}, nil
+}
+
+// Ensure MessageBus implements services.MessageBus
+var _ services.MessageBus = (*MessageBus)(nil)
+
+// Events returns the bus domain events are published to
+func (b *MessageBus) Events() services.EventBus {
+	return b.events
+}
+
+// ScanQueue returns the queue of virus scanning tasks
+func (b *MessageBus) ScanQueue() services.ScanQueue {
+	return b.scanQueue
+}
+
+// Close leaves the consumer group of the scan workers and closes the producer. Records are produced
+// synchronously, so none is pending.
+func (b *MessageBus) Close() error {
+	b.scanQueue.Close()
+	b.producer.Close()
+	return nil
+}
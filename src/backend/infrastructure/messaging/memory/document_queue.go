@@ -0,0 +1,264 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"../../../domain/services"
+	"../../../pkg/config"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+	"../../../pkg/tracing"
+)
+
+// scanQueueCapacity is the number of scan tasks each priority queue holds
+const scanQueueCapacity = 1000
+
+// defaultScanWaitTime is how long Dequeue waits for a task when config.Scanning.Workers leaves it unset
+const defaultScanWaitTime = 20 * time.Second
+
+// scanPriorities are the priorities with a queue of their own, from highest to lowest
+var scanPriorities = []string{services.ScanPriorityHigh, services.ScanPriorityNormal, services.ScanPriorityLow}
+
+// defaultPriorityWeights are the weights the priority queues are consumed with when
+// config.Scanning.Workers leaves them unset
+var defaultPriorityWeights = map[string]int{
+	services.ScanPriorityHigh:   6,
+	services.ScanPriorityNormal: 3,
+	services.ScanPriorityLow:    1,
+}
+
+// DocumentScanQueue implements the services.ScanQueue interface with a buffered channel per
+// priority, taken from by priority weight like the SQS queue does. A task leaves the queue when it
+// is dequeued, so a task being scanned when the process stops is lost; development deployments
+// upload it again.
+type DocumentScanQueue struct {
+	queues   map[string]chan services.ScanTask // Queue of each priority
+	waitTime time.Duration
+	handles  uint64
+
+	mu      sync.Mutex
+	weights map[string]int
+	credits map[string]int
+}
+
+// NewDocumentScanQueue creates an empty DocumentScanQueue
+func NewDocumentScanQueue(cfg config.Config) (*DocumentScanQueue, error) {
+	waitTime := defaultScanWaitTime
+	if cfg.Scanning.Workers.WaitTime != "" {
+		parsed, err := time.ParseDuration(cfg.Scanning.Workers.WaitTime)
+		if err != nil || parsed < 0 {
+			return nil, errors.NewValidationError(fmt.Sprintf("invalid scan queue wait time: %s", cfg.Scanning.Workers.WaitTime))
+		}
+		waitTime = parsed
+	}
+
+	weights, err := priorityWeights(cfg.Scanning.Workers.PriorityWeights)
+	if err != nil {
+		return nil, err
+	}
+
+	queues := make(map[string]chan services.ScanTask, len(scanPriorities))
+	for _, priority := range scanPriorities {
+		queues[priority] = make(chan services.ScanTask, scanQueueCapacity)
+	}
+
+	return &DocumentScanQueue{
+		queues:   queues,
+		waitTime: waitTime,
+		weights:  weights,
+		credits:  make(map[string]int),
+	}, nil
+}
+
+// Ensure DocumentScanQueue implements services.ScanQueue
+var _ services.ScanQueue = (*DocumentScanQueue)(nil)
+
+// Ensure DocumentScanQueue reports its depth
+var _ services.ScanQueueDepth = (*DocumentScanQueue)(nil)
+
+// priorityWeights returns the weights of the priority queues, or the defaults when none is set
+func priorityWeights(cfg config.ScanPriorityWeights) (map[string]int, error) {
+	if cfg.High < 0 || cfg.Normal < 0 || cfg.Low < 0 {
+		return nil, errors.NewValidationError("scan queue priority weights cannot be negative")
+	}
+	if cfg.High == 0 && cfg.Normal == 0 && cfg.Low == 0 {
+		return defaultPriorityWeights, nil
+	}
+	return map[string]int{
+		services.ScanPriorityHigh:   cfg.High,
+		services.ScanPriorityNormal: cfg.Normal,
+		services.ScanPriorityLow:    cfg.Low,
+	}, nil
+}
+
+// queueFor returns the queue of a priority. Tasks without a priority go to the normal priority queue.
+func (q *DocumentScanQueue) queueFor(priority string) (chan services.ScanTask, string) {
+	if queue, ok := q.queues[priority]; ok {
+		return queue, priority
+	}
+	return q.queues[services.ScanPriorityNormal], services.ScanPriorityNormal
+}
+
+// Enqueue adds a document to the scanning queue
+func (q *DocumentScanQueue) Enqueue(ctx context.Context, task services.ScanTask) error {
+	// The task stays in this process, so it carries the trace and request ID of the request that queued it itself
+	task.TraceContext = tracing.Inject(ctx)
+	if requestID := logger.RequestIDFromContext(ctx); requestID != "" {
+		if task.TraceContext == nil {
+			task.TraceContext = make(map[string]string, 1)
+		}
+		task.TraceContext[logger.RequestIDKey] = requestID
+	}
+	if err := q.push(task); err != nil {
+		return errors.NewDependencyError(fmt.Sprintf("failed to enqueue scan task: %v", err))
+	}
+
+	logger.InfoContext(ctx, "Document scan task enqueued successfully",
+		"document_id", task.DocumentID,
+		"tenant_id", task.TenantID,
+		"priority", task.Priority)
+
+	return nil
+}
+
+// push adds a task to the queue of its priority. It fails rather than blocks when the queue is
+// full, so that workers retrying a task never wait for themselves.
+func (q *DocumentScanQueue) push(task services.ScanTask) error {
+	queue, priority := q.queueFor(task.Priority)
+	task.Priority = priority
+	task.ReceiptHandle = ""
+
+	select {
+	case queue <- task:
+		return nil
+	default:
+		return fmt.Errorf("%s priority scan queue is full", priority)
+	}
+}
+
+// Dequeue retrieves the next document to scan, taking it from the priority queues by weight. When
+// no task is waiting, it waits for up to the wait time for one to arrive.
+func (q *DocumentScanQueue) Dequeue(ctx context.Context) (*services.ScanTask, error) {
+	task, ok := q.receive(ctx)
+	if !ok {
+		return nil, nil
+	}
+
+	task.ReceiptHandle = strconv.FormatUint(atomic.AddUint64(&q.handles, 1), 10)
+
+	logger.InfoContext(ctx, "Document scan task dequeued successfully",
+		"document_id", task.DocumentID,
+		"tenant_id", task.TenantID,
+		"priority", task.Priority)
+	return &task, nil
+}
+
+// receive takes a waiting task of the first priority in poll order that has one, or waits for the
+// first task of any priority for up to the wait time
+func (q *DocumentScanQueue) receive(ctx context.Context) (services.ScanTask, bool) {
+	for _, priority := range q.pollOrder() {
+		select {
+		case task := <-q.queues[priority]:
+			return task, true
+		default:
+		}
+	}
+
+	timer := time.NewTimer(q.waitTime)
+	defer timer.Stop()
+
+	select {
+	case task := <-q.queues[services.ScanPriorityHigh]:
+		return task, true
+	case task := <-q.queues[services.ScanPriorityNormal]:
+		return task, true
+	case task := <-q.queues[services.ScanPriorityLow]:
+		return task, true
+	case <-timer.C:
+		return services.ScanTask{}, false
+	case <-ctx.Done():
+		return services.ScanTask{}, false
+	}
+}
+
+// pollOrder returns the priorities in the order their queues are taken from: the priority whose
+// turn it is by weight first, then the others from highest to lowest
+func (q *DocumentScanQueue) pollOrder() []string {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	first := ""
+	total := 0
+	for _, priority := range scanPriorities {
+		weight := q.weights[priority]
+		q.credits[priority] += weight
+		total += weight
+		if first == "" || q.credits[priority] > q.credits[first] {
+			first = priority
+		}
+	}
+	q.credits[first] -= total
+
+	order := make([]string, 0, len(scanPriorities))
+	order = append(order, first)
+	for _, priority := range scanPriorities {
+		if priority != first {
+			order = append(order, priority)
+		}
+	}
+	return order
+}
+
+// Complete marks a scan task as completed. The task left the queue when it was dequeued.
+func (q *DocumentScanQueue) Complete(ctx context.Context, task services.ScanTask) error {
+	return nil
+}
+
+// Retry requeues a scan task for retry after a failure
+func (q *DocumentScanQueue) Retry(ctx context.Context, task services.ScanTask) error {
+	task.RetryCount++
+
+	if err := q.push(task); err != nil {
+		return errors.NewDependencyError(fmt.Sprintf("failed to requeue scan task for retry: %v", err))
+	}
+
+	logger.InfoContext(ctx, "Document scan task requeued for retry",
+		"document_id", task.DocumentID,
+		"tenant_id", task.TenantID,
+		"retry_count", task.RetryCount)
+
+	return nil
+}
+
+// DeadLetter drops a scan task after maximum retries. There is no dead letter queue in memory, so
+// the task is only logged.
+func (q *DocumentScanQueue) DeadLetter(ctx context.Context, task services.ScanTask, reason string) error {
+	logger.ErrorContext(ctx, "Document scan task dropped after maximum retries",
+		"document_id", task.DocumentID,
+		"tenant_id", task.TenantID,
+		"reason", reason)
+
+	return nil
+}
+
+// Depth returns the number of tasks waiting in the queue of each priority
+func (q *DocumentScanQueue) Depth(ctx context.Context) (map[string]int, error) {
+	depth := make(map[string]int, len(q.queues))
+	for priority, queue := range q.queues {
+		depth[priority] = len(queue)
+	}
+	return depth, nil
+}
+
+// ExtendVisibility is a no-op in memory. Dequeued tasks are never delivered again.
+func (q *DocumentScanQueue) ExtendVisibility(ctx context.Context, task services.ScanTask, timeout time.Duration) error {
+	if task.ReceiptHandle == "" {
+		return errors.NewValidationError("scan task was not dequeued")
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"../../../domain/services"
+	"../../../pkg/config"
+)
+
+// newTestQueue creates a queue taking turns between high and low priority tasks
+func newTestQueue(t *testing.T) *DocumentScanQueue {
+	var cfg config.Config
+	cfg.Scanning.Workers.WaitTime = "50ms"
+	cfg.Scanning.Workers.PriorityWeights = config.ScanPriorityWeights{High: 1, Normal: 0, Low: 1}
+
+	queue, err := NewDocumentScanQueue(cfg)
+	require.NoError(t, err)
+	return queue
+}
+
+// TestDocumentScanQueue_Dequeue tests that tasks are taken by priority weight and that an empty
+// queue waits for the wait time
+func TestDocumentScanQueue_Dequeue(t *testing.T) {
+	ctx := context.Background()
+	queue := newTestQueue(t)
+
+	require.NoError(t, queue.Enqueue(ctx, services.ScanTask{DocumentID: "high-1", Priority: services.ScanPriorityHigh}))
+	require.NoError(t, queue.Enqueue(ctx, services.ScanTask{DocumentID: "high-2", Priority: services.ScanPriorityHigh}))
+	require.NoError(t, queue.Enqueue(ctx, services.ScanTask{DocumentID: "low-1", Priority: services.ScanPriorityLow}))
+
+	var received []string
+	for i := 0; i < 3; i++ {
+		task, err := queue.Dequeue(ctx)
+		require.NoError(t, err)
+		require.NotNil(t, task)
+		assert.NotEmpty(t, task.ReceiptHandle)
+		received = append(received, task.DocumentID)
+	}
+
+	// Equal weights of high and low take turns, so the low priority task is not starved
+	assert.Equal(t, []string{"high-1", "low-1", "high-2"}, received)
+
+	start := time.Now()
+	task, err := queue.Dequeue(ctx)
+	assert.NoError(t, err)
+	assert.Nil(t, task)
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+}
+
+// TestDocumentScanQueue_Retry tests that a retried task is queued again with its retry count and
+// that tasks without a priority go to the normal priority queue
+func TestDocumentScanQueue_Retry(t *testing.T) {
+	ctx := context.Background()
+	queue := newTestQueue(t)
+
+	require.NoError(t, queue.Enqueue(ctx, services.ScanTask{DocumentID: "doc-1"}))
+	task, err := queue.Dequeue(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, task)
+	assert.Equal(t, services.ScanPriorityNormal, task.Priority)
+
+	require.NoError(t, queue.Retry(ctx, *task))
+	retried, err := queue.Dequeue(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, retried)
+	assert.Equal(t, "doc-1", retried.DocumentID)
+	assert.Equal(t, 1, retried.RetryCount)
+	assert.NotEqual(t, task.ReceiptHandle, retried.ReceiptHandle)
+}
+
+// TestDocumentScanQueue_Enqueue_full tests that a full queue fails rather than blocks
+func TestDocumentScanQueue_Enqueue_full(t *testing.T) {
+	ctx := context.Background()
+	queue := newTestQueue(t)
+
+	for i := 0; i < scanQueueCapacity; i++ {
+		require.NoError(t, queue.Enqueue(ctx, services.ScanTask{DocumentID: "doc", Priority: services.ScanPriorityLow}))
+	}
+	assert.Error(t, queue.Enqueue(ctx, services.ScanTask{DocumentID: "doc", Priority: services.ScanPriorityLow}))
+	assert.NoError(t, queue.Enqueue(ctx, services.ScanTask{DocumentID: "doc", Priority: services.ScanPriorityHigh}))
+}
+
+// TestDocumentScanQueue_Depth tests that the depth counts the tasks waiting in each priority queue
+func TestDocumentScanQueue_Depth(t *testing.T) {
+	ctx := context.Background()
+	queue := newTestQueue(t)
+
+	require.NoError(t, queue.Enqueue(ctx, services.ScanTask{DocumentID: "high-1", Priority: services.ScanPriorityHigh}))
+	require.NoError(t, queue.Enqueue(ctx, services.ScanTask{DocumentID: "low-1", Priority: services.ScanPriorityLow}))
+	require.NoError(t, queue.Enqueue(ctx, services.ScanTask{DocumentID: "low-2", Priority: services.ScanPriorityLow}))
+
+	task, err := queue.Dequeue(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, task)
+
+	depth, err := queue.Depth(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{
+		services.ScanPriorityHigh:   0,
+		services.ScanPriorityNormal: 0,
+		services.ScanPriorityLow:    2,
+	}, depth)
+}
@@ -0,0 +1,64 @@
+// Package memory provides in-process implementations of the event bus and the scan queue, so the
+// platform runs in development without a message broker. Messages only reach the process that
+// sent them and are lost on restart.
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"../../../domain/models"
+	"../../../domain/services"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+)
+
+// EventPublisher implements services.EventBus by passing events to the channels of the subscribers
+// in the same process
+type EventPublisher struct {
+	mu          sync.RWMutex
+	subscribers []chan *models.Event
+}
+
+// NewEventPublisher creates an EventPublisher without subscribers
+func NewEventPublisher() *EventPublisher {
+	return &EventPublisher{}
+}
+
+// Ensure EventPublisher implements services.EventBus
+var _ services.EventBus = (*EventPublisher)(nil)
+
+// Subscribe returns a channel receiving the events published from now on. A subscriber that falls
+// more than buffer events behind misses events rather than blocking the publishers.
+func (p *EventPublisher) Subscribe(buffer int) <-chan *models.Event {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	events := make(chan *models.Event, buffer)
+	p.subscribers = append(p.subscribers, events)
+	return events
+}
+
+// PublishEvent passes a domain event to the subscribers
+func (p *EventPublisher) PublishEvent(ctx context.Context, event *models.Event) error {
+	if event == nil {
+		return errors.NewValidationError("event cannot be nil")
+	}
+	if err := event.Validate(); err != nil {
+		return errors.Wrap(err, "invalid event")
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	for _, subscriber := range p.subscribers {
+		select {
+		case subscriber <- event:
+		default:
+			logger.ErrorContext(ctx, "Event subscriber is full, dropping event", "eventType", event.Type)
+		}
+	}
+
+	logger.InfoContext(ctx, "Successfully published event in memory", "eventType", event.Type, "tenantID", event.TenantID)
+	return nil
+}
@@ -0,0 +1,44 @@
+package memory
+
+import (
+	"../../../domain/services"
+	"../../../pkg/config"
+)
+
+// MessageBus implements services.MessageBus in memory. Only the process that created it can consume
+// its scan queue, so processes running the scan workers on it must also be the ones queueing scans.
+type MessageBus struct {
+	events    *EventPublisher
+	scanQueue *DocumentScanQueue
+}
+
+// NewMessageBus creates an in-memory message bus
+func NewMessageBus(cfg config.Config) (*MessageBus, error) {
+	scanQueue, err := NewDocumentScanQueue(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MessageBus{
+		events:    NewEventPublisher(),
+		scanQueue: scanQueue,
+	}, nil
+}
+
+// Ensure MessageBus implements services.MessageBus
+var _ services.MessageBus = (*MessageBus)(nil)
+
+// Events returns the bus domain events are published to
+func (b *MessageBus) Events() services.EventBus {
+	return b.events
+}
+
+// ScanQueue returns the queue of virus scanning tasks
+func (b *MessageBus) ScanQueue() services.ScanQueue {
+	return b.scanQueue
+}
+
+// Close is a no-op; tasks still queued are dropped with the process
+func (b *MessageBus) Close() error {
+	return nil
+}
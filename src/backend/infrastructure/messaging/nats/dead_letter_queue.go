@@ -0,0 +1,159 @@
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"strconv"
+
+	"github.com/nats-io/nats.go/jetstream" // v1.37.0
+
+	"../../../domain/services"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+)
+
+// defaultDeadLetterListLimit is the number of dead-lettered tasks listed when no limit is given
+const defaultDeadLetterListLimit = 100
+
+// deadLetterMessage is the message DeadLetter publishes to the dead letter stream, in the format of
+// the SQS dead letter messages
+type deadLetterMessage struct {
+	Task   *services.ScanTask `json:"task"`
+	Reason string             `json:"reason"`
+}
+
+// ListDeadLetters returns up to limit dead-lettered scan tasks. The messages stay in the dead letter stream.
+func (q *DocumentScanQueue) ListDeadLetters(ctx context.Context, limit int) ([]services.DeadLetteredScanTask, error) {
+	if limit <= 0 {
+		limit = defaultDeadLetterListLimit
+	}
+
+	deadLetters := make([]services.DeadLetteredScanTask, 0)
+	err := q.visitDeadLetters(ctx, limit, func(msg *jetstream.RawStreamMsg, deadLetter services.DeadLetteredScanTask) error {
+		deadLetters = append(deadLetters, deadLetter)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return deadLetters, nil
+}
+
+// ReplayDeadLetters moves the selected dead-lettered scan tasks back to the subject of their
+// priority. Each task is queued before its dead letter message is deleted, so a failure never loses
+// a task but may queue it twice; scanning a version twice has the same outcome.
+func (q *DocumentScanQueue) ReplayDeadLetters(ctx context.Context, selected func(services.DeadLetteredScanTask) bool) (int, error) {
+	replayed := 0
+	err := q.visitDeadLetters(ctx, 0, func(msg *jetstream.RawStreamMsg, deadLetter services.DeadLetteredScanTask) error {
+		if !deadLetter.Readable || !selected(deadLetter) {
+			return nil
+		}
+
+		// The task gets a fresh set of retries
+		task := deadLetter.Task
+		task.RetryCount = 0
+		if err := q.Enqueue(ctx, task); err != nil {
+			return err
+		}
+
+		if err := q.dlqStream.DeleteMsg(ctx, msg.Sequence); err != nil {
+			return errors.NewDependencyError(fmt.Sprintf("failed to remove replayed message from dead letter queue: %v", err))
+		}
+		replayed++
+
+		logger.InfoContext(ctx, "Dead-lettered scan task replayed",
+			"message_id", deadLetter.MessageID,
+			"document_id", task.DocumentID,
+			"tenant_id", task.TenantID,
+			"priority", task.Priority)
+		return nil
+	})
+
+	return replayed, err
+}
+
+// DiscardDeadLetters deletes the selected messages from the dead letter stream. Unreadable
+// messages are passed to selected too, so they can be discarded by message ID.
+func (q *DocumentScanQueue) DiscardDeadLetters(ctx context.Context, selected func(services.DeadLetteredScanTask) bool) (int, error) {
+	discarded := 0
+	err := q.visitDeadLetters(ctx, 0, func(msg *jetstream.RawStreamMsg, deadLetter services.DeadLetteredScanTask) error {
+		if !selected(deadLetter) {
+			return nil
+		}
+
+		if err := q.dlqStream.DeleteMsg(ctx, msg.Sequence); err != nil {
+			return errors.NewDependencyError(fmt.Sprintf("failed to remove discarded message from dead letter queue: %v", err))
+		}
+		discarded++
+
+		logger.InfoContext(ctx, "Dead-lettered scan task discarded",
+			"message_id", deadLetter.MessageID,
+			"document_id", deadLetter.Task.DocumentID,
+			"tenant_id", deadLetter.Task.TenantID)
+		return nil
+	})
+
+	return discarded, err
+}
+
+// visitDeadLetters passes up to limit messages of the dead letter stream to visit in the order they
+// were stored, or all of them when limit is 0, stopping at the first error. Messages are read by
+// sequence, so reading them does not remove them.
+func (q *DocumentScanQueue) visitDeadLetters(ctx context.Context, limit int, visit func(*jetstream.RawStreamMsg, services.DeadLetteredScanTask) error) error {
+	info, err := q.dlqStream.Info(ctx)
+	if err != nil {
+		return errors.NewDependencyError(fmt.Sprintf("failed to read dead letter queue: %v", err))
+	}
+	if info.State.Msgs == 0 {
+		return nil
+	}
+
+	visited := 0
+	for seq := info.State.FirstSeq; seq <= info.State.LastSeq && (limit == 0 || visited < limit); seq++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		msg, err := q.dlqStream.GetMsg(ctx, seq)
+		if stderrors.Is(err, jetstream.ErrMsgNotFound) {
+			// Deleted when it was replayed
+			continue
+		}
+		if err != nil {
+			return errors.NewDependencyError(fmt.Sprintf("failed to receive messages from dead letter queue: %v", err))
+		}
+
+		if err := visit(msg, parseDeadLetter(msg)); err != nil {
+			return err
+		}
+		visited++
+	}
+
+	return nil
+}
+
+// parseDeadLetter reads a dead letter message. Its message ID is its sequence in the stream.
+func parseDeadLetter(msg *jetstream.RawStreamMsg) services.DeadLetteredScanTask {
+	deadLetter := services.DeadLetteredScanTask{
+		MessageID:      strconv.FormatUint(msg.Sequence, 10),
+		DeadLetteredAt: msg.Time,
+	}
+
+	var body deadLetterMessage
+	if err := json.Unmarshal(msg.Data, &body); err != nil {
+		deadLetter.Reason = fmt.Sprintf("unreadable message: %v", err)
+		return deadLetter
+	}
+	if body.Task == nil {
+		deadLetter.Reason = "message is not a scan task"
+		return deadLetter
+	}
+
+	deadLetter.Task = *body.Task
+	deadLetter.Reason = body.Reason
+	deadLetter.Readable = deadLetter.Task.DocumentID != ""
+	return deadLetter
+}
@@ -0,0 +1,435 @@
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"               // v1.3.0+
+	"github.com/nats-io/nats.go"           // v1.37.0
+	"github.com/nats-io/nats.go/jetstream" // v1.37.0
+
+	"../../../domain/services"
+	"../../../pkg/config"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+)
+
+// Streams of the scan queue, named like the SQS queues
+const (
+	scanTasksStream    = "document-scan-tasks"
+	dlqScanTasksStream = "document-scan-tasks-dlq"
+	scanWorkersName    = "scan-workers"
+)
+
+// Defaults for consuming the scan queue when config.Scanning.Workers leaves them unset
+const (
+	defaultScanWaitTime          = 20 * time.Second
+	defaultScanVisibilityTimeout = 5 * time.Minute
+)
+
+// scanPollInterval is how often Dequeue polls the priority consumers while waiting for a task
+const scanPollInterval = time.Second
+
+// scanPriorities are the priorities with a consumer of their own, from highest to lowest
+var scanPriorities = []string{services.ScanPriorityHigh, services.ScanPriorityNormal, services.ScanPriorityLow}
+
+// defaultPriorityWeights are the weights the priority consumers are polled with when
+// config.Scanning.Workers leaves them unset
+var defaultPriorityWeights = map[string]int{
+	services.ScanPriorityHigh:   6,
+	services.ScanPriorityNormal: 3,
+	services.ScanPriorityLow:    1,
+}
+
+// DocumentScanQueue implements the services.ScanQueue interface with a JetStream work queue stream.
+// Tasks are published to a subject per priority and fetched from a durable pull consumer per
+// priority, which the scan workers share, by priority weight like the SQS queue does. A task is
+// removed from the stream once it is acknowledged; the tasks that failed their retries move to a
+// dead letter stream.
+//
+// The ack wait of the consumers is the visibility timeout: a task that is not acknowledged in time
+// is delivered again, and ExtendVisibility restarts the ack wait of a task being scanned.
+type DocumentScanQueue struct {
+	client       *Client
+	subjects     map[string]string             // Subject of each priority
+	consumers    map[string]jetstream.Consumer // Consumer of each priority
+	dlqSubject   string
+	dlqStream    jetstream.Stream
+	waitTime     time.Duration
+	pollInterval time.Duration
+
+	mu       sync.Mutex
+	inFlight map[string]jetstream.Msg // Unacknowledged messages by receipt handle
+	weights  map[string]int
+	credits  map[string]int
+}
+
+// NewDocumentScanQueue creates a DocumentScanQueue, creating its streams and consumers
+func NewDocumentScanQueue(ctx context.Context, client *Client, cfg config.Config) (*DocumentScanQueue, error) {
+	if client == nil {
+		return nil, errors.NewValidationError("nats client cannot be nil")
+	}
+
+	waitTime := defaultScanWaitTime
+	if cfg.Scanning.Workers.WaitTime != "" {
+		parsed, err := time.ParseDuration(cfg.Scanning.Workers.WaitTime)
+		if err != nil || parsed < 0 {
+			return nil, errors.NewValidationError(fmt.Sprintf("invalid scan queue wait time: %s", cfg.Scanning.Workers.WaitTime))
+		}
+		waitTime = parsed
+	}
+
+	visibilityTimeout := defaultScanVisibilityTimeout
+	if cfg.Scanning.Workers.VisibilityTimeout != "" {
+		parsed, err := time.ParseDuration(cfg.Scanning.Workers.VisibilityTimeout)
+		if err != nil || parsed < time.Second {
+			return nil, errors.NewValidationError(fmt.Sprintf("invalid scan queue visibility timeout: %s", cfg.Scanning.Workers.VisibilityTimeout))
+		}
+		visibilityTimeout = parsed
+	}
+
+	weights, err := priorityWeights(cfg.Scanning.Workers.PriorityWeights)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := namePrefix(cfg)
+	subject := subjectName(prefix, scanTasksStream)
+	q := &DocumentScanQueue{
+		client: client,
+		subjects: map[string]string{
+			services.ScanPriorityHigh:   subject + "." + services.ScanPriorityHigh,
+			services.ScanPriorityNormal: subject + "." + services.ScanPriorityNormal,
+			services.ScanPriorityLow:    subject + "." + services.ScanPriorityLow,
+		},
+		consumers:    make(map[string]jetstream.Consumer, len(scanPriorities)),
+		dlqSubject:   subjectName(prefix, dlqScanTasksStream),
+		waitTime:     waitTime,
+		pollInterval: scanPollInterval,
+		inFlight:     make(map[string]jetstream.Msg),
+		weights:      weights,
+		credits:      make(map[string]int),
+	}
+
+	stream, err := client.CreateStream(ctx, jetstream.StreamConfig{
+		Name:      resourceName(prefix, scanTasksStream),
+		Subjects:  []string{subject + ".*"},
+		Retention: jetstream.WorkQueuePolicy,
+	})
+	if err != nil {
+		return nil, err
+	}
+	q.dlqStream, err = client.CreateStream(ctx, jetstream.StreamConfig{
+		Name:     resourceName(prefix, dlqScanTasksStream),
+		Subjects: []string{q.dlqSubject},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Pull consumers only deliver the tasks that are fetched, so processes only queueing tasks can
+	// create them as well
+	for _, priority := range scanPriorities {
+		name := resourceName(prefix, scanWorkersName+"-"+priority)
+		consumer, err := stream.CreateOrUpdateConsumer(ctx, jetstream.ConsumerConfig{
+			Durable:       name,
+			FilterSubject: q.subjects[priority],
+			AckPolicy:     jetstream.AckExplicitPolicy,
+			AckWait:       visibilityTimeout,
+		})
+		if err != nil {
+			return nil, errors.NewDependencyError(fmt.Sprintf("failed to create consumer %s: %v", name, err))
+		}
+		q.consumers[priority] = consumer
+	}
+
+	return q, nil
+}
+
+// Ensure DocumentScanQueue implements services.ScanQueue and services.ScanDeadLetterQueue
+var (
+	_ services.ScanQueue           = (*DocumentScanQueue)(nil)
+	_ services.ScanDeadLetterQueue = (*DocumentScanQueue)(nil)
+)
+
+// priorityWeights returns the weights of the priority consumers, or the defaults when none is set
+func priorityWeights(cfg config.ScanPriorityWeights) (map[string]int, error) {
+	if cfg.High < 0 || cfg.Normal < 0 || cfg.Low < 0 {
+		return nil, errors.NewValidationError("scan queue priority weights cannot be negative")
+	}
+	if cfg.High == 0 && cfg.Normal == 0 && cfg.Low == 0 {
+		return defaultPriorityWeights, nil
+	}
+	return map[string]int{
+		services.ScanPriorityHigh:   cfg.High,
+		services.ScanPriorityNormal: cfg.Normal,
+		services.ScanPriorityLow:    cfg.Low,
+	}, nil
+}
+
+// subjectFor returns the subject of a priority. Tasks without a priority go to the normal priority subject.
+func (q *DocumentScanQueue) subjectFor(priority string) string {
+	if subject, ok := q.subjects[priority]; ok {
+		return subject
+	}
+	return q.subjects[services.ScanPriorityNormal]
+}
+
+// Enqueue adds a document to the scanning queue
+func (q *DocumentScanQueue) Enqueue(ctx context.Context, task services.ScanTask) error {
+	if err := q.publish(ctx, q.subjectFor(task.Priority), task); err != nil {
+		return errors.NewDependencyError(fmt.Sprintf("failed to enqueue scan task: %v", err))
+	}
+
+	logger.InfoContext(ctx, "Document scan task enqueued successfully",
+		"document_id", task.DocumentID,
+		"tenant_id", task.TenantID,
+		"priority", task.Priority)
+
+	return nil
+}
+
+// Dequeue retrieves the next document to scan, fetching it from the priority consumers by weight.
+// When no task is waiting, it polls the consumers for up to the wait time.
+func (q *DocumentScanQueue) Dequeue(ctx context.Context) (*services.ScanTask, error) {
+	for {
+		msg, priority, err := q.receive(ctx, q.consumers)
+		if err != nil {
+			return nil, errors.NewDependencyError(fmt.Sprintf("failed to dequeue scan task: %v", err))
+		}
+		if msg == nil {
+			return nil, nil
+		}
+
+		var task services.ScanTask
+		if err := json.Unmarshal(msg.Data(), &task); err != nil {
+			logger.ErrorContext(ctx, "Failed to unmarshal scan task from JSON, moving it to the dead letter queue",
+				"error", err,
+				"subject", msg.Subject())
+			q.deadLetterUnreadable(ctx, msg)
+			continue
+		}
+
+		// The task is acknowledged with the message it was received in
+		task.Priority = priority
+		task.ReceiptHandle = q.track(msg)
+
+		logger.InfoContext(ctx, "Document scan task dequeued successfully",
+			"document_id", task.DocumentID,
+			"tenant_id", task.TenantID,
+			"priority", task.Priority)
+		return &task, nil
+	}
+}
+
+// receive fetches a waiting message of the first priority in poll order that has one, polling the
+// consumers again every poll interval for up to the wait time. It returns nil when none arrived.
+func (q *DocumentScanQueue) receive(ctx context.Context, consumers map[string]jetstream.Consumer) (jetstream.Msg, string, error) {
+	deadline := time.Now().Add(q.waitTime)
+	for {
+		for _, priority := range q.pollOrder() {
+			msg, err := fetchOne(consumers[priority])
+			if err != nil {
+				return nil, "", err
+			}
+			if msg != nil {
+				return msg, priority, nil
+			}
+		}
+
+		wait := time.Until(deadline)
+		if wait <= 0 {
+			return nil, "", nil
+		}
+		if wait > q.pollInterval {
+			wait = q.pollInterval
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, "", nil
+		}
+	}
+}
+
+// fetchOne fetches a waiting message of a consumer, returning nil when none is waiting
+func fetchOne(consumer jetstream.Consumer) (jetstream.Msg, error) {
+	batch, err := consumer.FetchNoWait(1)
+	if err != nil {
+		return nil, err
+	}
+
+	var msg jetstream.Msg
+	for received := range batch.Messages() {
+		msg = received
+	}
+	if msg == nil {
+		return nil, batch.Error()
+	}
+	return msg, nil
+}
+
+// pollOrder returns the priorities in the order their consumers are polled: the priority whose
+// turn it is by weight first, then the others from highest to lowest
+func (q *DocumentScanQueue) pollOrder() []string {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	first := ""
+	total := 0
+	for _, priority := range scanPriorities {
+		weight := q.weights[priority]
+		q.credits[priority] += weight
+		total += weight
+		if first == "" || q.credits[priority] > q.credits[first] {
+			first = priority
+		}
+	}
+	q.credits[first] -= total
+
+	order := make([]string, 0, len(scanPriorities))
+	order = append(order, first)
+	for _, priority := range scanPriorities {
+		if priority != first {
+			order = append(order, priority)
+		}
+	}
+	return order
+}
+
+// track remembers an unacknowledged message and returns its receipt handle, the subject its
+// acknowledgement is sent to
+func (q *DocumentScanQueue) track(msg jetstream.Msg) string {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	handle := msg.Reply()
+	q.inFlight[handle] = msg
+	return handle
+}
+
+// untrack forgets an unacknowledged message, returning it when it was tracked
+func (q *DocumentScanQueue) untrack(handle string) (jetstream.Msg, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	msg, ok := q.inFlight[handle]
+	delete(q.inFlight, handle)
+	return msg, ok
+}
+
+// Complete marks a scan task as completed and removes it from the queue
+func (q *DocumentScanQueue) Complete(ctx context.Context, task services.ScanTask) error {
+	return q.acknowledge(ctx, task)
+}
+
+// Retry requeues a scan task for retry after a failure
+func (q *DocumentScanQueue) Retry(ctx context.Context, task services.ScanTask) error {
+	task.RetryCount++
+
+	if err := q.publish(ctx, q.subjectFor(task.Priority), task); err != nil {
+		return errors.NewDependencyError(fmt.Sprintf("failed to requeue scan task for retry: %v", err))
+	}
+
+	// The retry is a new message, so the message of the failed attempt is acknowledged
+	if err := q.acknowledge(ctx, task); err != nil {
+		return err
+	}
+
+	logger.InfoContext(ctx, "Document scan task requeued for retry",
+		"document_id", task.DocumentID,
+		"tenant_id", task.TenantID,
+		"retry_count", task.RetryCount)
+
+	return nil
+}
+
+// DeadLetter moves a scan task to the dead letter queue after maximum retries
+func (q *DocumentScanQueue) DeadLetter(ctx context.Context, task services.ScanTask, reason string) error {
+	message := deadLetterMessage{Task: &task, Reason: reason}
+	if err := q.publish(ctx, q.dlqSubject, message); err != nil {
+		return errors.NewDependencyError(fmt.Sprintf("failed to move scan task to dead letter queue: %v", err))
+	}
+
+	if err := q.acknowledge(ctx, task); err != nil {
+		return err
+	}
+
+	logger.InfoContext(ctx, "Document scan task moved to dead letter queue",
+		"document_id", task.DocumentID,
+		"tenant_id", task.TenantID,
+		"reason", reason)
+
+	return nil
+}
+
+// ExtendVisibility restarts the ack wait of a dequeued task, keeping it from being delivered to
+// another worker. JetStream restarts the ack wait of the consumer, which is the configured
+// visibility timeout, whatever the timeout passed.
+func (q *DocumentScanQueue) ExtendVisibility(ctx context.Context, task services.ScanTask, timeout time.Duration) error {
+	if task.ReceiptHandle == "" {
+		return errors.NewValidationError("scan task was not dequeued")
+	}
+
+	q.mu.Lock()
+	msg, ok := q.inFlight[task.ReceiptHandle]
+	q.mu.Unlock()
+	if !ok {
+		return nil
+	}
+
+	if err := msg.InProgress(); err != nil {
+		return errors.NewDependencyError(fmt.Sprintf("failed to extend scan task visibility: %v", err))
+	}
+	return nil
+}
+
+// publish publishes a JSON message to a subject of the scan queue
+func (q *DocumentScanQueue) publish(ctx context.Context, subject string, value interface{}) error {
+	body, err := json.Marshal(value)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal scan task to JSON")
+	}
+
+	msg := nats.NewMsg(subject)
+	msg.Header.Set("Content-Type", "application/json")
+	msg.Data = body
+	return q.client.Publish(ctx, msg, uuid.New().String())
+}
+
+// deadLetterUnreadable moves a message that is not a scan task to the dead letter stream as it is,
+// and removes it from the scan queue
+func (q *DocumentScanQueue) deadLetterUnreadable(ctx context.Context, msg jetstream.Msg) {
+	deadLetter := nats.NewMsg(q.dlqSubject)
+	deadLetter.Data = msg.Data()
+	if err := q.client.Publish(ctx, deadLetter, uuid.New().String()); err != nil {
+		logger.ErrorContext(ctx, "Failed to move unreadable message to the dead letter queue", "error", err)
+		return
+	}
+	_ = msg.Ack()
+}
+
+// acknowledge acknowledges the message a dequeued task was received in and waits for the server
+// to confirm it, which removes the message from the stream. Tasks that were not dequeued have no
+// message.
+func (q *DocumentScanQueue) acknowledge(ctx context.Context, task services.ScanTask) error {
+	if task.ReceiptHandle == "" {
+		return nil
+	}
+
+	msg, ok := q.untrack(task.ReceiptHandle)
+	if !ok {
+		return nil
+	}
+
+	if err := msg.DoubleAck(ctx); err != nil {
+		return errors.NewDependencyError(fmt.Sprintf("failed to acknowledge scan task: %v", err))
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package nats
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"../../../domain/services"
+)
+
+// fakeMsg is a fetched message carrying only its data
+type fakeMsg struct {
+	jetstream.Msg
+	data string
+}
+
+func (m *fakeMsg) Data() []byte {
+	return []byte(m.data)
+}
+
+// fakeBatch is the result of a fetch
+type fakeBatch struct {
+	msgs chan jetstream.Msg
+}
+
+func (b *fakeBatch) Messages() <-chan jetstream.Msg {
+	return b.msgs
+}
+
+func (b *fakeBatch) Error() error {
+	return nil
+}
+
+// fakeConsumer is a pull consumer with the messages waiting on it
+type fakeConsumer struct {
+	jetstream.Consumer
+	waiting []string
+}
+
+func (c *fakeConsumer) FetchNoWait(batch int) (jetstream.MessageBatch, error) {
+	msgs := make(chan jetstream.Msg, 1)
+	if len(c.waiting) > 0 {
+		msgs <- &fakeMsg{data: c.waiting[0]}
+		c.waiting = c.waiting[1:]
+	}
+	close(msgs)
+	return &fakeBatch{msgs: msgs}, nil
+}
+
+// TestDocumentScanQueue_receive tests that waiting messages are fetched by priority weight and that
+// an empty queue is polled for the wait time
+func TestDocumentScanQueue_receive(t *testing.T) {
+	queue := &DocumentScanQueue{
+		waitTime:     50 * time.Millisecond,
+		pollInterval: 10 * time.Millisecond,
+		weights:      map[string]int{services.ScanPriorityHigh: 1, services.ScanPriorityNormal: 0, services.ScanPriorityLow: 1},
+		credits:      make(map[string]int),
+	}
+
+	consumers := map[string]jetstream.Consumer{
+		services.ScanPriorityHigh:   &fakeConsumer{waiting: []string{"high-1", "high-2"}},
+		services.ScanPriorityNormal: &fakeConsumer{},
+		services.ScanPriorityLow:    &fakeConsumer{waiting: []string{"low-1"}},
+	}
+
+	var received []string
+	for i := 0; i < 3; i++ {
+		msg, _, err := queue.receive(context.Background(), consumers)
+		require.NoError(t, err)
+		require.NotNil(t, msg)
+		received = append(received, string(msg.Data()))
+	}
+
+	// Equal weights of high and low take turns, so the low priority task is not starved
+	assert.Equal(t, []string{"high-1", "low-1", "high-2"}, received)
+
+	start := time.Now()
+	msg, _, err := queue.receive(context.Background(), consumers)
+	assert.NoError(t, err)
+	assert.Nil(t, msg)
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+
+	// A priority without weight is still received when the others have no task waiting
+	consumers[services.ScanPriorityNormal] = &fakeConsumer{waiting: []string{"normal-1"}}
+	msg, priority, err := queue.receive(context.Background(), consumers)
+	require.NoError(t, err)
+	require.NotNil(t, msg)
+	assert.Equal(t, services.ScanPriorityNormal, priority)
+}
+
+// TestParseDeadLetter tests reading the messages of the dead letter stream
+func TestParseDeadLetter(t *testing.T) {
+	deadLetteredAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("dead-lettered after retries", func(t *testing.T) {
+		deadLetter := parseDeadLetter(&jetstream.RawStreamMsg{
+			Sequence: 42,
+			Time:     deadLetteredAt,
+			Data:     []byte(`{"task":{"DocumentID":"doc-1","TenantID":"tenant-1","Priority":"high","RetryCount":3},"reason":"scan failed"}`),
+		})
+
+		assert.Equal(t, "42", deadLetter.MessageID)
+		assert.Equal(t, "doc-1", deadLetter.Task.DocumentID)
+		assert.Equal(t, services.ScanPriorityHigh, deadLetter.Task.Priority)
+		assert.Equal(t, "scan failed", deadLetter.Reason)
+		assert.Equal(t, deadLetteredAt, deadLetter.DeadLetteredAt)
+		assert.True(t, deadLetter.Readable)
+	})
+
+	t.Run("not a scan task", func(t *testing.T) {
+		deadLetter := parseDeadLetter(&jetstream.RawStreamMsg{Sequence: 43, Data: []byte(`not json`)})
+
+		assert.Equal(t, "43", deadLetter.MessageID)
+		assert.Contains(t, deadLetter.Reason, "unreadable message")
+		assert.False(t, deadLetter.Readable)
+	})
+}
+
+// TestResourceName tests that stream and consumer names carry the prefix without dots
+func TestResourceName(t *testing.T) {
+	assert.Equal(t, "prod-document-scan-tasks", resourceName("prod", scanTasksStream))
+	assert.Equal(t, "eu-prod-events", resourceName("eu.prod", eventsStream))
+	assert.Equal(t, "events", resourceName("", eventsStream))
+	assert.Equal(t, "eu.prod.document-scan-tasks", subjectName("eu.prod", scanTasksStream))
+}
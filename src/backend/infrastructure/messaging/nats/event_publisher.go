@@ -0,0 +1,84 @@
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/nats-io/nats.go"           // v1.37.0
+	"github.com/nats-io/nats.go/jetstream" // v1.37.0
+
+	"../../../domain/models"
+	"../../../domain/services"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+)
+
+// Stream and subject events are published to
+const (
+	eventsStream  = "events"
+	eventsSubject = "events"
+)
+
+// eventsMaxAge is how long the events stream keeps events for consumers to catch up
+const eventsMaxAge = 7 * 24 * time.Hour
+
+// EventPublisher implements services.EventBus by publishing events to a stream. The subject is
+// <prefix>.events.<event type>, so consumers filter on subjects such as <prefix>.events.document.>
+type EventPublisher struct {
+	client  *Client
+	subject string
+}
+
+// NewEventPublisher creates an EventPublisher publishing to the events stream, creating the stream
+func NewEventPublisher(ctx context.Context, client *Client, prefix string) (*EventPublisher, error) {
+	if client == nil {
+		return nil, errors.NewValidationError("nats client cannot be nil")
+	}
+
+	subject := subjectName(prefix, eventsSubject)
+	_, err := client.CreateStream(ctx, jetstream.StreamConfig{
+		Name:     resourceName(prefix, eventsStream),
+		Subjects: []string{subject + ".>"},
+		MaxAge:   eventsMaxAge,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &EventPublisher{
+		client:  client,
+		subject: subject,
+	}, nil
+}
+
+// Ensure EventPublisher implements services.EventBus
+var _ services.EventBus = (*EventPublisher)(nil)
+
+// PublishEvent publishes a domain event to the subject of its type. The event ID is the message
+// ID, so an event the outbox relay publishes again is stored once.
+func (p *EventPublisher) PublishEvent(ctx context.Context, event *models.Event) error {
+	if event == nil {
+		return errors.NewValidationError("event cannot be nil")
+	}
+	if err := event.Validate(); err != nil {
+		return errors.Wrap(err, "invalid event")
+	}
+
+	eventJSON, err := json.Marshal(event)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal event to JSON")
+	}
+
+	msg := nats.NewMsg(p.subject + "." + event.Type)
+	msg.Header.Set("Content-Type", "application/json")
+	msg.Data = eventJSON
+
+	if err := p.client.Publish(ctx, msg, event.ID); err != nil {
+		logger.ErrorContext(ctx, "Failed to publish event to NATS", "error", err, "eventType", event.Type)
+		return err
+	}
+
+	logger.InfoContext(ctx, "Successfully published event to NATS", "eventType", event.Type, "tenantID", event.TenantID)
+	return nil
+}
@@ -0,0 +1,86 @@
+package nats
+
+import (
+	"context"
+	"strings"
+
+	"../../../domain/services"
+	"../../../pkg/config"
+)
+
+// MessageBus implements services.MessageBus with NATS JetStream over a single connection
+type MessageBus struct {
+	client    *Client
+	events    *EventPublisher
+	scanQueue *DocumentScanQueue
+}
+
+// NewMessageBus connects to the server of cfg.Messaging.NATS, creates the streams and consumers,
+// and creates the message bus
+func NewMessageBus(ctx context.Context, cfg config.Config) (*MessageBus, error) {
+	client, err := NewClient(cfg.Messaging.NATS)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := NewEventPublisher(ctx, client, namePrefix(cfg))
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
+	scanQueue, err := NewDocumentScanQueue(ctx, client, cfg)
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
+
+	return &MessageBus{
+		client:    client,
+		events:    events,
+		scanQueue: scanQueue,
+	}, nil
+}
+
+// Ensure MessageBus implements services.MessageBus
+var _ services.MessageBus = (*MessageBus)(nil)
+
+// Events returns the bus domain events are published to
+func (b *MessageBus) Events() services.EventBus {
+	return b.events
+}
+
+// ScanQueue returns the queue of virus scanning tasks
+func (b *MessageBus) ScanQueue() services.ScanQueue {
+	return b.scanQueue
+}
+
+// Close closes the connection. Unacknowledged tasks are delivered again once their ack wait expires.
+func (b *MessageBus) Close() error {
+	return b.client.Close()
+}
+
+// namePrefix returns the prefix of the stream, consumer and subject names, defaulting to the
+// environment like the SQS queue names
+func namePrefix(cfg config.Config) string {
+	if cfg.Messaging.NATS.NamePrefix != "" {
+		return cfg.Messaging.NATS.NamePrefix
+	}
+	return cfg.Env
+}
+
+// resourceName returns the name of a stream or consumer with the prefix of the deployment. Stream
+// and consumer names cannot contain dots.
+func resourceName(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return strings.ReplaceAll(prefix, ".", "-") + "-" + name
+}
+
+// subjectName returns a subject with the prefix of the deployment as its first token
+func subjectName(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
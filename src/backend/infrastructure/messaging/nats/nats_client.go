@@ -0,0 +1,93 @@
+// Package nats provides NATS JetStream implementations of the event bus and the scan queue for
+// single node and development deployments of the Document Management Platform.
+package nats
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nats-io/nats.go"           // v1.37.0
+	"github.com/nats-io/nats.go/jetstream" // v1.37.0
+
+	"../../../pkg/config"
+	"../../../pkg/errors"
+)
+
+// clientName identifies the platform's connections in the server monitoring
+const clientName = "document-mgmt"
+
+// Client is a connection to a NATS server with its JetStream context. The connection reconnects
+// on its own after the server was restarted.
+type Client struct {
+	conn     *nats.Conn
+	js       jetstream.JetStream
+	replicas int
+}
+
+// NewClient connects to the server of cfg
+func NewClient(cfg config.NATSConfig) (*Client, error) {
+	if cfg.URL == "" {
+		return nil, errors.NewValidationError("nats URL is required")
+	}
+	if cfg.Replicas < 0 {
+		return nil, errors.NewValidationError("nats stream replicas cannot be negative")
+	}
+
+	opts := []nats.Option{
+		nats.Name(clientName),
+		nats.MaxReconnects(-1),
+	}
+	if cfg.CredentialsFile != "" {
+		opts = append(opts, nats.UserCredentials(cfg.CredentialsFile))
+	}
+
+	conn, err := nats.Connect(cfg.URL, opts...)
+	if err != nil {
+		return nil, errors.NewDependencyError(fmt.Sprintf("failed to connect to nats: %v", err))
+	}
+	js, err := jetstream.New(conn)
+	if err != nil {
+		conn.Close()
+		return nil, errors.NewDependencyError(fmt.Sprintf("failed to create jetstream context: %v", err))
+	}
+
+	replicas := cfg.Replicas
+	if replicas == 0 {
+		replicas = 1
+	}
+
+	return &Client{
+		conn:     conn,
+		js:       js,
+		replicas: replicas,
+	}, nil
+}
+
+// CreateStream creates a file-backed stream with the replicas of the deployment, or updates the
+// stream when it exists
+func (c *Client) CreateStream(ctx context.Context, cfg jetstream.StreamConfig) (jetstream.Stream, error) {
+	cfg.Storage = jetstream.FileStorage
+	cfg.Replicas = c.replicas
+
+	stream, err := c.js.CreateOrUpdateStream(ctx, cfg)
+	if err != nil {
+		return nil, errors.NewDependencyError(fmt.Sprintf("failed to create stream %s: %v", cfg.Name, err))
+	}
+	return stream, nil
+}
+
+// Publish publishes a message with a message ID and waits for the server to acknowledge it, which
+// it does once a stream has stored the message. Messages published again with the same ID within
+// the duplicate window of the stream are stored once.
+func (c *Client) Publish(ctx context.Context, msg *nats.Msg, msgID string) error {
+	if _, err := c.js.PublishMsg(ctx, msg, jetstream.WithMsgID(msgID)); err != nil {
+		return errors.NewDependencyError(fmt.Sprintf("failed to publish message: %v", err))
+	}
+	return nil
+}
+
+// Close closes the connection to the server
+func (c *Client) Close() error {
+	c.conn.Close()
+	return nil
+}
@@ -0,0 +1,83 @@
+// Package providers creates the message bus selected by the configuration. It is separate from
+// the drivers so that each deployment only configures the messaging it runs on.
+package providers
+
+import (
+	"context"
+	"fmt"
+
+	"../../../domain/services"
+	"../../../pkg/config"
+	"../../../pkg/logger"
+	"../kafka"
+	"../memory"
+	"../nats"
+	"../rabbitmq"
+	"../sns"
+	"../sqs"
+)
+
+// New creates the message bus selected by cfg.Messaging.Provider, defaulting to AWS SNS and SQS
+func New(ctx context.Context, cfg config.Config) (services.MessageBus, error) {
+	switch cfg.Messaging.Provider {
+	case "", services.MessagingProviderAWS:
+		return newAWSMessageBus(ctx, cfg)
+	case services.MessagingProviderKafka:
+		return kafka.NewMessageBus(ctx, cfg)
+	case services.MessagingProviderRabbitMQ:
+		return rabbitmq.NewMessageBus(ctx, cfg)
+	case services.MessagingProviderNATS:
+		return nats.NewMessageBus(ctx, cfg)
+	case services.MessagingProviderMemory:
+		return memory.NewMessageBus(cfg)
+	default:
+		return nil, fmt.Errorf("unsupported messaging provider: %s", cfg.Messaging.Provider)
+	}
+}
+
+// awsMessageBus publishes events to SNS and queues scan tasks on SQS
+type awsMessageBus struct {
+	events    services.EventBus
+	scanQueue services.ScanQueue
+}
+
+// newAWSMessageBus creates the message bus of AWS deployments
+func newAWSMessageBus(ctx context.Context, cfg config.Config) (services.MessageBus, error) {
+	sqsClient, err := sqs.NewSQSClient(ctx, cfg.SQS)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize SQS client: %w", err)
+	}
+	scanQueue, err := sqs.NewDocumentScanQueue(ctx, sqsClient, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize document scan queue: %w", err)
+	}
+
+	snsClient, err := sns.NewSNSClient(&cfg.SNS)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize SNS client: %w", err)
+	}
+	events := sns.NewEventPublisher(snsClient, logger.WithField("component", "EventPublisher"))
+	if events == nil {
+		return nil, fmt.Errorf("failed to initialize event publisher")
+	}
+
+	return &awsMessageBus{
+		events:    events,
+		scanQueue: scanQueue,
+	}, nil
+}
+
+// Events returns the SNS event publisher
+func (b *awsMessageBus) Events() services.EventBus {
+	return b.events
+}
+
+// ScanQueue returns the SQS scan queue
+func (b *awsMessageBus) ScanQueue() services.ScanQueue {
+	return b.scanQueue
+}
+
+// Close is a no-op; the AWS clients hold no connections that need closing
+func (b *awsMessageBus) Close() error {
+	return nil
+}
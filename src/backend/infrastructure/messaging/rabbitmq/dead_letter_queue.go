@@ -0,0 +1,176 @@
+package rabbitmq
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go" // v1.10.0
+
+	"../../../domain/services"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+)
+
+// defaultDeadLetterListLimit is the number of dead-lettered tasks listed when no limit is given
+const defaultDeadLetterListLimit = 100
+
+// deadLetterMessage is the message DeadLetter publishes to the dead letter queue, in the format of
+// the SQS dead letter messages
+type deadLetterMessage struct {
+	Task   *services.ScanTask `json:"task"`
+	Reason string             `json:"reason"`
+}
+
+// ListDeadLetters returns up to limit dead-lettered scan tasks. The messages stay in the dead letter queue.
+func (q *DocumentScanQueue) ListDeadLetters(ctx context.Context, limit int) ([]services.DeadLetteredScanTask, error) {
+	if limit <= 0 {
+		limit = defaultDeadLetterListLimit
+	}
+
+	deadLetters := make([]services.DeadLetteredScanTask, 0)
+	err := q.visitDeadLetters(ctx, limit, func(delivery amqp.Delivery, deadLetter services.DeadLetteredScanTask) error {
+		deadLetters = append(deadLetters, deadLetter)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return deadLetters, nil
+}
+
+// ReplayDeadLetters moves the selected dead-lettered scan tasks back to the queue of their priority.
+// Each task is queued before its dead letter message is acknowledged, so a failure never loses a
+// task but may queue it twice; scanning a version twice has the same outcome.
+func (q *DocumentScanQueue) ReplayDeadLetters(ctx context.Context, selected func(services.DeadLetteredScanTask) bool) (int, error) {
+	replayed := 0
+	err := q.visitDeadLetters(ctx, 0, func(delivery amqp.Delivery, deadLetter services.DeadLetteredScanTask) error {
+		if !deadLetter.Readable || !selected(deadLetter) {
+			return nil
+		}
+
+		// The task gets a fresh set of retries
+		task := deadLetter.Task
+		task.RetryCount = 0
+		if err := q.Enqueue(ctx, task); err != nil {
+			return err
+		}
+
+		if err := delivery.Ack(false); err != nil {
+			return errors.NewDependencyError(fmt.Sprintf("failed to remove replayed message from dead letter queue: %v", err))
+		}
+		replayed++
+
+		logger.InfoContext(ctx, "Dead-lettered scan task replayed",
+			"message_id", deadLetter.MessageID,
+			"document_id", task.DocumentID,
+			"tenant_id", task.TenantID,
+			"priority", task.Priority)
+		return nil
+	})
+
+	return replayed, err
+}
+
+// DiscardDeadLetters acknowledges the selected dead letter messages, removing them from the queue.
+// Unreadable messages are passed to selected too, so they can be discarded by message ID.
+func (q *DocumentScanQueue) DiscardDeadLetters(ctx context.Context, selected func(services.DeadLetteredScanTask) bool) (int, error) {
+	discarded := 0
+	err := q.visitDeadLetters(ctx, 0, func(delivery amqp.Delivery, deadLetter services.DeadLetteredScanTask) error {
+		if !selected(deadLetter) {
+			return nil
+		}
+
+		if err := delivery.Ack(false); err != nil {
+			return errors.NewDependencyError(fmt.Sprintf("failed to remove discarded message from dead letter queue: %v", err))
+		}
+		discarded++
+
+		logger.InfoContext(ctx, "Dead-lettered scan task discarded",
+			"message_id", deadLetter.MessageID,
+			"document_id", deadLetter.Task.DocumentID,
+			"tenant_id", deadLetter.Task.TenantID)
+		return nil
+	})
+
+	return discarded, err
+}
+
+// visitDeadLetters passes up to limit messages of the dead letter queue to visit, or all of them when
+// limit is 0, stopping at the first error. The messages are received on a channel of their own
+// without acknowledging them, so the messages visit does not acknowledge return to the queue when
+// the channel is closed.
+func (q *DocumentScanQueue) visitDeadLetters(ctx context.Context, limit int, visit func(amqp.Delivery, services.DeadLetteredScanTask) error) error {
+	ch, err := q.client.Channel()
+	if err != nil {
+		return err
+	}
+	defer ch.Close()
+
+	for visited := 0; limit == 0 || visited < limit; visited++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		delivery, ok, err := ch.Get(q.dlqQueue, false)
+		if err != nil {
+			return errors.NewDependencyError(fmt.Sprintf("failed to receive messages from dead letter queue: %v", err))
+		}
+		if !ok {
+			return nil
+		}
+
+		if err := visit(delivery, parseDeadLetter(delivery)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parseDeadLetter reads a dead letter message. Besides the messages of DeadLetter, it reads scan
+// tasks the priority queues dead-lettered after they were rejected.
+func parseDeadLetter(delivery amqp.Delivery) services.DeadLetteredScanTask {
+	deadLetter := services.DeadLetteredScanTask{
+		MessageID:      delivery.MessageId,
+		DeadLetteredAt: delivery.Timestamp,
+	}
+
+	var body deadLetterMessage
+	if err := json.Unmarshal(delivery.Body, &body); err != nil {
+		deadLetter.Reason = fmt.Sprintf("unreadable message: %v", err)
+		return deadLetter
+	}
+	if body.Task != nil {
+		deadLetter.Task = *body.Task
+		deadLetter.Reason = body.Reason
+		deadLetter.Readable = deadLetter.Task.DocumentID != ""
+		return deadLetter
+	}
+
+	// A scan task dead-lettered by its queue, which records why in the x-death header
+	reason := "dead-lettered by the scan queue"
+	if deaths, ok := delivery.Headers["x-death"].([]interface{}); ok && len(deaths) > 0 {
+		if death, ok := deaths[0].(amqp.Table); ok {
+			if deathReason, ok := death["reason"].(string); ok {
+				reason = "message " + deathReason
+			}
+			if deathTime, ok := death["time"].(time.Time); ok {
+				deadLetter.DeadLetteredAt = deathTime
+			}
+		}
+	}
+
+	var task services.ScanTask
+	if err := json.Unmarshal(delivery.Body, &task); err == nil && task.DocumentID != "" {
+		deadLetter.Task = task
+		deadLetter.Reason = reason
+		deadLetter.Readable = true
+		return deadLetter
+	}
+
+	deadLetter.Reason = "message is not a scan task"
+	return deadLetter
+}
@@ -0,0 +1,451 @@
+package rabbitmq
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"               // v1.3.0+
+	amqp "github.com/rabbitmq/amqp091-go" // v1.10.0
+
+	"../../../domain/services"
+	"../../../pkg/config"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+)
+
+// Queues of the scan queue, named like the SQS queues
+const (
+	scanTasksQueue     = "document-scan-tasks"
+	highScanTasksQueue = "document-scan-tasks-high"
+	lowScanTasksQueue  = "document-scan-tasks-low"
+	dlqScanTasksQueue  = "document-scan-tasks-dlq"
+)
+
+// Defaults for consuming the scan queue when config.Scanning.Workers leaves them unset
+const (
+	defaultScanWaitTime = 20 * time.Second
+	defaultPrefetch     = 4
+)
+
+// scanPriorities are the priorities with a queue of their own, from highest to lowest
+var scanPriorities = []string{services.ScanPriorityHigh, services.ScanPriorityNormal, services.ScanPriorityLow}
+
+// defaultPriorityWeights are the weights the priority queues are consumed with when
+// config.Scanning.Workers leaves them unset
+var defaultPriorityWeights = map[string]int{
+	services.ScanPriorityHigh:   6,
+	services.ScanPriorityNormal: 3,
+	services.ScanPriorityLow:    1,
+}
+
+// DocumentScanQueue implements the services.ScanQueue interface with RabbitMQ queues. Tasks are
+// published with confirms to a queue per priority and taken from the deliveries of the queues by
+// priority weight like the SQS queue does. The priority queues dead-letter rejected messages to the
+// dead letter queue, which also receives the tasks that failed their retries.
+//
+// A delivered task stays unacknowledged until it is completed, retried or dead-lettered. RabbitMQ
+// redelivers it when the worker's connection is lost, so it needs no visibility timeout; scans must
+// finish within the consumer timeout of the broker (30 minutes by default).
+type DocumentScanQueue struct {
+	client   *Client
+	queues   map[string]string // Queue of each priority
+	dlqQueue string
+	waitTime time.Duration
+	prefetch int
+
+	// The consumer starts on the first Dequeue, so that processes only queueing tasks do not
+	// receive deliveries
+	mu         sync.Mutex
+	consumerCh *amqp.Channel
+	deliveries map[string]<-chan amqp.Delivery // Deliveries of each priority
+	inFlight   map[string]amqp.Delivery        // Unacknowledged deliveries by receipt handle
+	weights    map[string]int
+	credits    map[string]int
+}
+
+// NewDocumentScanQueue creates a DocumentScanQueue, declaring its queues
+func NewDocumentScanQueue(client *Client, cfg config.Config) (*DocumentScanQueue, error) {
+	if client == nil {
+		return nil, errors.NewValidationError("rabbitmq client cannot be nil")
+	}
+
+	waitTime := defaultScanWaitTime
+	if cfg.Scanning.Workers.WaitTime != "" {
+		parsed, err := time.ParseDuration(cfg.Scanning.Workers.WaitTime)
+		if err != nil || parsed < 0 {
+			return nil, errors.NewValidationError(fmt.Sprintf("invalid scan queue wait time: %s", cfg.Scanning.Workers.WaitTime))
+		}
+		waitTime = parsed
+	}
+
+	prefetch := cfg.Messaging.RabbitMQ.Prefetch
+	if prefetch < 0 {
+		return nil, errors.NewValidationError("rabbitmq prefetch cannot be negative")
+	}
+	if prefetch == 0 {
+		prefetch = cfg.Scanning.Workers.Concurrency
+	}
+	if prefetch <= 0 {
+		prefetch = defaultPrefetch
+	}
+
+	weights, err := priorityWeights(cfg.Scanning.Workers.PriorityWeights)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := namePrefix(cfg)
+	q := &DocumentScanQueue{
+		client: client,
+		queues: map[string]string{
+			services.ScanPriorityHigh:   resourceName(prefix, highScanTasksQueue),
+			services.ScanPriorityNormal: resourceName(prefix, scanTasksQueue),
+			services.ScanPriorityLow:    resourceName(prefix, lowScanTasksQueue),
+		},
+		dlqQueue: resourceName(prefix, dlqScanTasksQueue),
+		waitTime: waitTime,
+		prefetch: prefetch,
+		inFlight: make(map[string]amqp.Delivery),
+		weights:  weights,
+		credits:  make(map[string]int),
+	}
+	if err := q.declareQueues(); err != nil {
+		return nil, err
+	}
+
+	return q, nil
+}
+
+// Ensure DocumentScanQueue implements services.ScanQueue and services.ScanDeadLetterQueue
+var (
+	_ services.ScanQueue           = (*DocumentScanQueue)(nil)
+	_ services.ScanDeadLetterQueue = (*DocumentScanQueue)(nil)
+)
+
+// priorityWeights returns the weights of the priority queues, or the defaults when none is set
+func priorityWeights(cfg config.ScanPriorityWeights) (map[string]int, error) {
+	if cfg.High < 0 || cfg.Normal < 0 || cfg.Low < 0 {
+		return nil, errors.NewValidationError("scan queue priority weights cannot be negative")
+	}
+	if cfg.High == 0 && cfg.Normal == 0 && cfg.Low == 0 {
+		return defaultPriorityWeights, nil
+	}
+	return map[string]int{
+		services.ScanPriorityHigh:   cfg.High,
+		services.ScanPriorityNormal: cfg.Normal,
+		services.ScanPriorityLow:    cfg.Low,
+	}, nil
+}
+
+// declareQueues declares the dead letter queue and the priority queues dead-lettering to it
+func (q *DocumentScanQueue) declareQueues() error {
+	ch, err := q.client.Channel()
+	if err != nil {
+		return err
+	}
+	defer ch.Close()
+
+	if _, err := ch.QueueDeclare(q.dlqQueue, true, false, false, false, nil); err != nil {
+		return errors.NewDependencyError(fmt.Sprintf("failed to declare queue %s: %v", q.dlqQueue, err))
+	}
+	args := amqp.Table{
+		"x-dead-letter-exchange":    "",
+		"x-dead-letter-routing-key": q.dlqQueue,
+	}
+	for _, priority := range scanPriorities {
+		if _, err := ch.QueueDeclare(q.queues[priority], true, false, false, false, args); err != nil {
+			return errors.NewDependencyError(fmt.Sprintf("failed to declare queue %s: %v", q.queues[priority], err))
+		}
+	}
+
+	return nil
+}
+
+// queueFor returns the queue of a priority. Tasks without a priority go to the normal priority queue.
+func (q *DocumentScanQueue) queueFor(priority string) string {
+	if queue, ok := q.queues[priority]; ok {
+		return queue
+	}
+	return q.queues[services.ScanPriorityNormal]
+}
+
+// Enqueue adds a document to the scanning queue
+func (q *DocumentScanQueue) Enqueue(ctx context.Context, task services.ScanTask) error {
+	if err := q.publish(ctx, q.queueFor(task.Priority), task); err != nil {
+		return errors.NewDependencyError(fmt.Sprintf("failed to enqueue scan task: %v", err))
+	}
+
+	logger.InfoContext(ctx, "Document scan task enqueued successfully",
+		"document_id", task.DocumentID,
+		"tenant_id", task.TenantID,
+		"priority", task.Priority)
+
+	return nil
+}
+
+// Dequeue retrieves the next document to scan, taking it from the deliveries of the priority queues
+// by weight. When no task was delivered, it waits for up to the wait time for one to arrive.
+func (q *DocumentScanQueue) Dequeue(ctx context.Context) (*services.ScanTask, error) {
+	consumerCh, deliveries, err := q.consume()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		delivery, priority, err := q.receive(ctx, deliveries)
+		if err != nil {
+			q.resetConsumer(consumerCh)
+			return nil, errors.NewDependencyError(fmt.Sprintf("failed to dequeue scan task: %v", err))
+		}
+		if delivery == nil {
+			return nil, nil
+		}
+
+		var task services.ScanTask
+		if err := json.Unmarshal(delivery.Body, &task); err != nil {
+			logger.ErrorContext(ctx, "Failed to unmarshal scan task from JSON, moving it to the dead letter queue",
+				"error", err,
+				"message_id", delivery.MessageId)
+			_ = delivery.Reject(false)
+			continue
+		}
+
+		// The task is acknowledged with the delivery it was received in
+		task.Priority = priority
+		task.ReceiptHandle = q.track(*delivery)
+
+		logger.InfoContext(ctx, "Document scan task dequeued successfully",
+			"document_id", task.DocumentID,
+			"tenant_id", task.TenantID,
+			"priority", task.Priority)
+		return &task, nil
+	}
+}
+
+// consume returns the deliveries of the priority queues, starting the consumer on first use or
+// after the connection was lost
+func (q *DocumentScanQueue) consume() (*amqp.Channel, map[string]<-chan amqp.Delivery, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.consumerCh != nil && !q.consumerCh.IsClosed() {
+		return q.consumerCh, q.deliveries, nil
+	}
+
+	ch, err := q.client.Channel()
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := ch.Qos(q.prefetch, 0, false); err != nil {
+		ch.Close()
+		return nil, nil, errors.NewDependencyError(fmt.Sprintf("failed to set prefetch: %v", err))
+	}
+
+	deliveries := make(map[string]<-chan amqp.Delivery, len(scanPriorities))
+	for _, priority := range scanPriorities {
+		priorityDeliveries, err := ch.Consume(q.queues[priority], "", false, false, false, false, nil)
+		if err != nil {
+			ch.Close()
+			return nil, nil, errors.NewDependencyError(fmt.Sprintf("failed to consume queue %s: %v", q.queues[priority], err))
+		}
+		deliveries[priority] = priorityDeliveries
+	}
+
+	// Deliveries of the previous channel were redelivered when it closed
+	q.consumerCh = ch
+	q.deliveries = deliveries
+	q.inFlight = make(map[string]amqp.Delivery)
+
+	return ch, deliveries, nil
+}
+
+// resetConsumer closes the consumer channel after an error, so that the next Dequeue starts a new
+// one. Workers that saw the error on the same channel do not close the channel that replaced it.
+func (q *DocumentScanQueue) resetConsumer(ch *amqp.Channel) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.consumerCh != nil && q.consumerCh == ch {
+		q.consumerCh.Close()
+		q.consumerCh = nil
+	}
+}
+
+// receive takes a waiting delivery of the first priority in poll order that has one, or waits for
+// the first delivery of any priority for up to the wait time. It returns nil when none arrived.
+func (q *DocumentScanQueue) receive(ctx context.Context, deliveries map[string]<-chan amqp.Delivery) (*amqp.Delivery, string, error) {
+	for _, priority := range q.pollOrder() {
+		select {
+		case delivery, ok := <-deliveries[priority]:
+			if !ok {
+				return nil, "", fmt.Errorf("consumer of %s priority tasks was closed", priority)
+			}
+			return &delivery, priority, nil
+		default:
+		}
+	}
+
+	timer := time.NewTimer(q.waitTime)
+	defer timer.Stop()
+
+	var delivery amqp.Delivery
+	var ok bool
+	priority := ""
+	select {
+	case delivery, ok = <-deliveries[services.ScanPriorityHigh]:
+		priority = services.ScanPriorityHigh
+	case delivery, ok = <-deliveries[services.ScanPriorityNormal]:
+		priority = services.ScanPriorityNormal
+	case delivery, ok = <-deliveries[services.ScanPriorityLow]:
+		priority = services.ScanPriorityLow
+	case <-timer.C:
+		return nil, "", nil
+	case <-ctx.Done():
+		return nil, "", nil
+	}
+	if !ok {
+		return nil, "", fmt.Errorf("consumer of %s priority tasks was closed", priority)
+	}
+	return &delivery, priority, nil
+}
+
+// pollOrder returns the priorities in the order their deliveries are taken from: the priority whose
+// turn it is by weight first, then the others from highest to lowest
+func (q *DocumentScanQueue) pollOrder() []string {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	first := ""
+	total := 0
+	for _, priority := range scanPriorities {
+		weight := q.weights[priority]
+		q.credits[priority] += weight
+		total += weight
+		if first == "" || q.credits[priority] > q.credits[first] {
+			first = priority
+		}
+	}
+	q.credits[first] -= total
+
+	order := make([]string, 0, len(scanPriorities))
+	order = append(order, first)
+	for _, priority := range scanPriorities {
+		if priority != first {
+			order = append(order, priority)
+		}
+	}
+	return order
+}
+
+// track remembers an unacknowledged delivery and returns its receipt handle
+func (q *DocumentScanQueue) track(delivery amqp.Delivery) string {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	handle := delivery.ConsumerTag + "/" + strconv.FormatUint(delivery.DeliveryTag, 10)
+	q.inFlight[handle] = delivery
+	return handle
+}
+
+// Complete marks a scan task as completed and removes it from the queue
+func (q *DocumentScanQueue) Complete(ctx context.Context, task services.ScanTask) error {
+	return q.acknowledge(task)
+}
+
+// Retry requeues a scan task for retry after a failure
+func (q *DocumentScanQueue) Retry(ctx context.Context, task services.ScanTask) error {
+	task.RetryCount++
+
+	if err := q.publish(ctx, q.queueFor(task.Priority), task); err != nil {
+		return errors.NewDependencyError(fmt.Sprintf("failed to requeue scan task for retry: %v", err))
+	}
+
+	// The retry is a new message, so the delivery of the failed attempt is acknowledged
+	if err := q.acknowledge(task); err != nil {
+		return err
+	}
+
+	logger.InfoContext(ctx, "Document scan task requeued for retry",
+		"document_id", task.DocumentID,
+		"tenant_id", task.TenantID,
+		"retry_count", task.RetryCount)
+
+	return nil
+}
+
+// DeadLetter moves a scan task to the dead letter queue after maximum retries
+func (q *DocumentScanQueue) DeadLetter(ctx context.Context, task services.ScanTask, reason string) error {
+	message := deadLetterMessage{Task: &task, Reason: reason}
+	if err := q.publish(ctx, q.dlqQueue, message); err != nil {
+		return errors.NewDependencyError(fmt.Sprintf("failed to move scan task to dead letter queue: %v", err))
+	}
+
+	if err := q.acknowledge(task); err != nil {
+		return err
+	}
+
+	logger.InfoContext(ctx, "Document scan task moved to dead letter queue",
+		"document_id", task.DocumentID,
+		"tenant_id", task.TenantID,
+		"reason", reason)
+
+	return nil
+}
+
+// ExtendVisibility is a no-op for RabbitMQ. Unacknowledged deliveries are not redelivered on a
+// timeout but only when the connection of the worker is lost.
+func (q *DocumentScanQueue) ExtendVisibility(ctx context.Context, task services.ScanTask, timeout time.Duration) error {
+	if task.ReceiptHandle == "" {
+		return errors.NewValidationError("scan task was not dequeued")
+	}
+	return nil
+}
+
+// Close stops consuming; unacknowledged deliveries go back to their queues
+func (q *DocumentScanQueue) Close() {
+	q.mu.Lock()
+	ch := q.consumerCh
+	q.mu.Unlock()
+
+	q.resetConsumer(ch)
+}
+
+// publish publishes a JSON message to a queue through the default exchange
+func (q *DocumentScanQueue) publish(ctx context.Context, queue string, value interface{}) error {
+	body, err := json.Marshal(value)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal scan task to JSON")
+	}
+
+	return q.client.Publish(ctx, "", queue, amqp.Publishing{
+		ContentType: "application/json",
+		MessageId:   uuid.New().String(),
+		Timestamp:   time.Now().UTC(),
+		Body:        body,
+	})
+}
+
+// acknowledge acknowledges the delivery a dequeued task was received in. Tasks that were not
+// dequeued have no delivery, and deliveries of a closed consumer were redelivered already.
+func (q *DocumentScanQueue) acknowledge(task services.ScanTask) error {
+	if task.ReceiptHandle == "" {
+		return nil
+	}
+
+	q.mu.Lock()
+	delivery, ok := q.inFlight[task.ReceiptHandle]
+	delete(q.inFlight, task.ReceiptHandle)
+	q.mu.Unlock()
+	if !ok {
+		return nil
+	}
+
+	if err := delivery.Ack(false); err != nil {
+		return errors.NewDependencyError(fmt.Sprintf("failed to acknowledge scan task: %v", err))
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package rabbitmq
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"../../../domain/services"
+)
+
+// TestDocumentScanQueue_receive tests that waiting deliveries are taken by priority weight and that
+// an empty queue waits for the wait time
+func TestDocumentScanQueue_receive(t *testing.T) {
+	queue := &DocumentScanQueue{
+		waitTime: 50 * time.Millisecond,
+		weights:  map[string]int{services.ScanPriorityHigh: 1, services.ScanPriorityNormal: 0, services.ScanPriorityLow: 1},
+		credits:  make(map[string]int),
+	}
+
+	high := make(chan amqp.Delivery, 2)
+	normal := make(chan amqp.Delivery)
+	low := make(chan amqp.Delivery, 1)
+	high <- amqp.Delivery{MessageId: "high-1"}
+	high <- amqp.Delivery{MessageId: "high-2"}
+	low <- amqp.Delivery{MessageId: "low-1"}
+	deliveries := map[string]<-chan amqp.Delivery{
+		services.ScanPriorityHigh:   high,
+		services.ScanPriorityNormal: normal,
+		services.ScanPriorityLow:    low,
+	}
+
+	var received []string
+	for i := 0; i < 3; i++ {
+		delivery, _, err := queue.receive(context.Background(), deliveries)
+		require.NoError(t, err)
+		require.NotNil(t, delivery)
+		received = append(received, delivery.MessageId)
+	}
+
+	// Equal weights of high and low take turns, so the low priority task is not starved
+	assert.Equal(t, []string{"high-1", "low-1", "high-2"}, received)
+
+	start := time.Now()
+	delivery, _, err := queue.receive(context.Background(), deliveries)
+	assert.NoError(t, err)
+	assert.Nil(t, delivery)
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+
+	// A closed consumer is reported, so that the next Dequeue starts a new one
+	close(normal)
+	_, _, err = queue.receive(context.Background(), deliveries)
+	assert.Error(t, err)
+}
+
+// TestParseDeadLetter tests reading the messages of the dead letter queue
+func TestParseDeadLetter(t *testing.T) {
+	deadLetteredAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("dead-lettered after retries", func(t *testing.T) {
+		deadLetter := parseDeadLetter(amqp.Delivery{
+			MessageId: "msg-1",
+			Timestamp: deadLetteredAt,
+			Body:      []byte(`{"task":{"DocumentID":"doc-1","TenantID":"tenant-1","Priority":"high","RetryCount":3},"reason":"scan failed"}`),
+		})
+
+		assert.Equal(t, "msg-1", deadLetter.MessageID)
+		assert.Equal(t, "doc-1", deadLetter.Task.DocumentID)
+		assert.Equal(t, services.ScanPriorityHigh, deadLetter.Task.Priority)
+		assert.Equal(t, "scan failed", deadLetter.Reason)
+		assert.Equal(t, deadLetteredAt, deadLetter.DeadLetteredAt)
+		assert.True(t, deadLetter.Readable)
+	})
+
+	t.Run("dead-lettered by the queue", func(t *testing.T) {
+		deadLetter := parseDeadLetter(amqp.Delivery{
+			MessageId: "msg-2",
+			Headers: amqp.Table{"x-death": []interface{}{
+				amqp.Table{"reason": "expired", "time": deadLetteredAt},
+			}},
+			Body: []byte(`{"DocumentID":"doc-2","TenantID":"tenant-1"}`),
+		})
+
+		assert.Equal(t, "doc-2", deadLetter.Task.DocumentID)
+		assert.Equal(t, "message expired", deadLetter.Reason)
+		assert.Equal(t, deadLetteredAt, deadLetter.DeadLetteredAt)
+		assert.True(t, deadLetter.Readable)
+	})
+
+	t.Run("not a scan task", func(t *testing.T) {
+		deadLetter := parseDeadLetter(amqp.Delivery{MessageId: "msg-3", Body: []byte(`not json`)})
+
+		assert.Equal(t, "msg-3", deadLetter.MessageID)
+		assert.Contains(t, deadLetter.Reason, "unreadable message")
+		assert.False(t, deadLetter.Readable)
+	})
+}
@@ -0,0 +1,79 @@
+package rabbitmq
+
+import (
+	"context"
+	"encoding/json"
+
+	amqp "github.com/rabbitmq/amqp091-go" // v1.10.0
+
+	"../../../domain/models"
+	"../../../domain/services"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+)
+
+// eventsExchange is the topic exchange events are published to, routed by event type
+const eventsExchange = "events"
+
+// EventPublisher implements services.EventBus by publishing events to a topic exchange. The routing
+// key is the event type, so consumers bind their queues to patterns such as document.# or
+// folder.created.
+type EventPublisher struct {
+	client   *Client
+	exchange string
+}
+
+// NewEventPublisher creates an EventPublisher publishing to the events exchange, declaring it
+func NewEventPublisher(client *Client, prefix string) (*EventPublisher, error) {
+	if client == nil {
+		return nil, errors.NewValidationError("rabbitmq client cannot be nil")
+	}
+
+	exchange := resourceName(prefix, eventsExchange)
+	ch, err := client.Channel()
+	if err != nil {
+		return nil, err
+	}
+	defer ch.Close()
+	if err := ch.ExchangeDeclare(exchange, amqp.ExchangeTopic, true, false, false, false, nil); err != nil {
+		return nil, errors.NewDependencyError("failed to declare events exchange: " + err.Error())
+	}
+
+	return &EventPublisher{
+		client:   client,
+		exchange: exchange,
+	}, nil
+}
+
+// Ensure EventPublisher implements services.EventBus
+var _ services.EventBus = (*EventPublisher)(nil)
+
+// PublishEvent publishes a domain event with its type as routing key
+func (p *EventPublisher) PublishEvent(ctx context.Context, event *models.Event) error {
+	if event == nil {
+		return errors.NewValidationError("event cannot be nil")
+	}
+	if err := event.Validate(); err != nil {
+		return errors.Wrap(err, "invalid event")
+	}
+
+	eventJSON, err := json.Marshal(event)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal event to JSON")
+	}
+
+	err = p.client.Publish(ctx, p.exchange, event.Type, amqp.Publishing{
+		ContentType: "application/json",
+		MessageId:   event.ID,
+		Type:        event.Type,
+		Timestamp:   event.OccurredAt,
+		Body:        eventJSON,
+	})
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to publish event to RabbitMQ", "error", err, "eventType", event.Type)
+		return err
+	}
+
+	logger.InfoContext(ctx, "Successfully published event to RabbitMQ", "eventType", event.Type, "tenantID", event.TenantID)
+	return nil
+}
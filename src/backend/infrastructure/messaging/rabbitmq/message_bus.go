@@ -0,0 +1,78 @@
+package rabbitmq
+
+import (
+	"context"
+
+	"../../../domain/services"
+	"../../../pkg/config"
+)
+
+// MessageBus implements services.MessageBus with RabbitMQ over a single connection
+type MessageBus struct {
+	client    *Client
+	events    *EventPublisher
+	scanQueue *DocumentScanQueue
+}
+
+// NewMessageBus connects to the broker of cfg.Messaging.RabbitMQ, declares the exchange and queues,
+// and creates the message bus
+func NewMessageBus(ctx context.Context, cfg config.Config) (*MessageBus, error) {
+	client, err := NewClient(cfg.Messaging.RabbitMQ)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := NewEventPublisher(client, namePrefix(cfg))
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
+	scanQueue, err := NewDocumentScanQueue(client, cfg)
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
+
+	return &MessageBus{
+		client:    client,
+		events:    events,
+		scanQueue: scanQueue,
+	}, nil
+}
+
+// Ensure MessageBus implements services.MessageBus
+var _ services.MessageBus = (*MessageBus)(nil)
+
+// Events returns the bus domain events are published to
+func (b *MessageBus) Events() services.EventBus {
+	return b.events
+}
+
+// ScanQueue returns the queue of virus scanning tasks
+func (b *MessageBus) ScanQueue() services.ScanQueue {
+	return b.scanQueue
+}
+
+// Close stops consuming scan tasks and closes the connection. Unacknowledged tasks return to their
+// queues.
+func (b *MessageBus) Close() error {
+	b.scanQueue.Close()
+	return b.client.Close()
+}
+
+// namePrefix returns the prefix of the exchange and queue names, defaulting to the environment like
+// the SQS queue names
+func namePrefix(cfg config.Config) string {
+	if cfg.Messaging.RabbitMQ.NamePrefix != "" {
+		return cfg.Messaging.RabbitMQ.NamePrefix
+	}
+	return cfg.Env
+}
+
+// resourceName returns the name of an exchange or queue with the prefix of the deployment
+func resourceName(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "-" + name
+}
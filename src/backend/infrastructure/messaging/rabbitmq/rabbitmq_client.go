@@ -0,0 +1,144 @@
+// Package rabbitmq provides RabbitMQ implementations of the event bus and the scan queue for
+// air-gapped deployments of the Document Management Platform.
+package rabbitmq
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"sync"
+
+	amqp "github.com/rabbitmq/amqp091-go" // v1.10.0
+
+	"../../../pkg/config"
+	"../../../pkg/errors"
+)
+
+// Client is a connection to RabbitMQ with a channel in confirm mode for publishing. The connection
+// is re-established on the next use after the broker closed it.
+type Client struct {
+	url       string
+	amqpCfg   amqp.Config
+	mu        sync.Mutex
+	conn      *amqp.Connection
+	publishCh *amqp.Channel
+}
+
+// NewClient connects to the broker of cfg
+func NewClient(cfg config.RabbitMQConfig) (*Client, error) {
+	if cfg.URL == "" {
+		return nil, errors.NewValidationError("rabbitmq URL is required")
+	}
+
+	c := &Client{
+		url: cfg.URL,
+		amqpCfg: amqp.Config{
+			TLSClientConfig: &tls.Config{
+				MinVersion:         tls.VersionTLS12,
+				InsecureSkipVerify: cfg.SkipTLSVerify,
+			},
+		},
+	}
+	if _, err := c.connection(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// connection returns the connection to the broker, dialing it when it is closed. It must be called
+// with mu held, except from NewClient.
+func (c *Client) connection() (*amqp.Connection, error) {
+	if c.conn != nil && !c.conn.IsClosed() {
+		return c.conn, nil
+	}
+
+	conn, err := amqp.DialConfig(c.url, c.amqpCfg)
+	if err != nil {
+		return nil, errors.NewDependencyError(fmt.Sprintf("failed to connect to rabbitmq: %v", err))
+	}
+	c.conn = conn
+	c.publishCh = nil
+
+	return conn, nil
+}
+
+// Channel opens a new channel, for consumers and for declaring the topology
+func (c *Client) Channel() (*amqp.Channel, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	conn, err := c.connection()
+	if err != nil {
+		return nil, err
+	}
+
+	ch, err := conn.Channel()
+	if err != nil {
+		return nil, errors.NewDependencyError(fmt.Sprintf("failed to open rabbitmq channel: %v", err))
+	}
+	return ch, nil
+}
+
+// publishChannel returns the channel messages are published on, opening it in confirm mode when
+// it is closed
+func (c *Client) publishChannel() (*amqp.Channel, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.publishCh != nil && !c.publishCh.IsClosed() {
+		return c.publishCh, nil
+	}
+
+	conn, err := c.connection()
+	if err != nil {
+		return nil, err
+	}
+	ch, err := conn.Channel()
+	if err != nil {
+		return nil, errors.NewDependencyError(fmt.Sprintf("failed to open rabbitmq channel: %v", err))
+	}
+	if err := ch.Confirm(false); err != nil {
+		ch.Close()
+		return nil, errors.NewDependencyError(fmt.Sprintf("failed to enable publisher confirms: %v", err))
+	}
+	c.publishCh = ch
+
+	return ch, nil
+}
+
+// Publish publishes a persistent message and waits for the broker to confirm it, which it does
+// once the message is written to the queues it is routed to
+func (c *Client) Publish(ctx context.Context, exchange, key string, msg amqp.Publishing) error {
+	ch, err := c.publishChannel()
+	if err != nil {
+		return err
+	}
+
+	msg.DeliveryMode = amqp.Persistent
+	confirmation, err := ch.PublishWithDeferredConfirmWithContext(ctx, exchange, key, false, false, msg)
+	if err != nil {
+		return errors.NewDependencyError(fmt.Sprintf("failed to publish message: %v", err))
+	}
+
+	acked, err := confirmation.WaitContext(ctx)
+	if err != nil {
+		return errors.NewDependencyError(fmt.Sprintf("failed to confirm message: %v", err))
+	}
+	if !acked {
+		return errors.NewDependencyError("message was not confirmed by the broker")
+	}
+
+	return nil
+}
+
+// Close closes the connection to the broker
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.conn == nil || c.conn.IsClosed() {
+		return nil
+	}
+	return c.conn.Close()
+}
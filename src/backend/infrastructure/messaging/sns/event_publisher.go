@@ -81,7 +81,8 @@ func (p *EventPublisher) PublishEvent(ctx context.Context, event *models.Event)
 		return errors.Wrap(err, "failed to marshal event to JSON")
 	}
 
-	// Call snsClient.Publish with the topic and JSON payload
+	// Call snsClient.Publish with the topic and JSON payload, carrying the request that raised the event
+	ctx = logger.ContextWithRequestID(ctx, event.RequestID)
 	messageID, err := p.snsClient.Publish(ctx, topic, string(eventJSON))
 	if err != nil {
 		log.WithError(err).Error("Failed to publish event to SNS")
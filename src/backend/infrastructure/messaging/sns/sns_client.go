@@ -11,10 +11,12 @@ import (
 	"github.com/aws/aws-sdk-go-v2/config" // v2.0.0+
 	"github.com/aws/aws-sdk-go-v2/credentials" // v2.0.0+
 	"github.com/aws/aws-sdk-go-v2/service/sns" // v2.0.0+
+	"github.com/aws/aws-sdk-go-v2/service/sns/types" // v2.0.0+
 
 	"../../../pkg/config"
 	"../../../pkg/errors"
 	"../../../pkg/logger"
+	"../../../pkg/tracing"
 )
 
 // SNSClientInterface defines the contract for SNS operations
@@ -104,8 +106,9 @@ func (c *SNSClient) Publish(ctx context.Context, topicName string, message inter
 
 	// Create publish input
 	input := &sns.PublishInput{
-		TopicArn: aws.String(topicARN),
-		Message:  aws.String(string(messageJSON)),
+		TopicArn:          aws.String(topicARN),
+		Message:           aws.String(string(messageJSON)),
+		MessageAttributes: messageAttributes(ctx),
 	}
 
 	// Publish message
@@ -121,6 +124,30 @@ func (c *SNSClient) Publish(ctx context.Context, topicName string, message inter
 	return *result.MessageId, nil
 }
 
+// messageAttributes returns the message attributes carrying the request ID and trace context of
+// the context, which SNS passes on to the subscribed queues, or nil when the context carries neither
+func messageAttributes(ctx context.Context) map[string]types.MessageAttributeValue {
+	carrier := tracing.Inject(ctx)
+	if requestID := logger.RequestIDFromContext(ctx); requestID != "" {
+		if carrier == nil {
+			carrier = make(map[string]string, 1)
+		}
+		carrier[logger.RequestIDKey] = requestID
+	}
+	if len(carrier) == 0 {
+		return nil
+	}
+
+	attributes := make(map[string]types.MessageAttributeValue, len(carrier))
+	for name, value := range carrier {
+		attributes[name] = types.MessageAttributeValue{
+			DataType:    aws.String("String"),
+			StringValue: aws.String(value),
+		}
+	}
+	return attributes
+}
+
 // getTopicARN gets the ARN for a topic name
 func (c *SNSClient) getTopicARN(topicName string) (string, error) {
 	arn, ok := c.topicNameToARNMap[topicName]
@@ -0,0 +1,201 @@
+package sqs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types" // v2.0.0+
+
+	"../../../../domain/services"
+	"../../../../pkg/errors"
+	"../../../../pkg/logger"
+)
+
+// defaultDeadLetterListLimit is the number of dead-lettered tasks listed when no limit is given
+const defaultDeadLetterListLimit = 100
+
+// deadLetterPeekTimeout is how long dead letter messages are hidden while they are inspected. It
+// covers a full pass over the queue; messages that are not replayed are made visible again after it.
+const deadLetterPeekTimeout = 5 * time.Minute
+
+// deadLetterMessage is the message DeadLetter and MoveToDeadLetterQueue send to the dead letter queue
+type deadLetterMessage struct {
+	Task   *services.ScanTask `json:"task"`
+	Reason string             `json:"reason"`
+	Error  string             `json:"error"`
+}
+
+// ListDeadLetters returns up to limit dead-lettered scan tasks. The messages stay in the dead letter queue.
+func (q *DocumentScanQueue) ListDeadLetters(ctx context.Context, limit int) ([]services.DeadLetteredScanTask, error) {
+	if limit <= 0 {
+		limit = defaultDeadLetterListLimit
+	}
+
+	deadLetters := make([]services.DeadLetteredScanTask, 0)
+	err := q.visitDeadLetters(ctx, limit, func(message types.Message, deadLetter services.DeadLetteredScanTask) (bool, error) {
+		deadLetters = append(deadLetters, deadLetter)
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return deadLetters, nil
+}
+
+// ReplayDeadLetters moves the selected dead-lettered scan tasks back to the queue of their priority.
+// Each task is queued before its dead letter message is deleted, so a failure never loses a task but
+// may queue it twice; scanning a version twice has the same outcome.
+func (q *DocumentScanQueue) ReplayDeadLetters(ctx context.Context, selected func(services.DeadLetteredScanTask) bool) (int, error) {
+	log := logger.WithContext(ctx)
+
+	replayed := 0
+	err := q.visitDeadLetters(ctx, 0, func(message types.Message, deadLetter services.DeadLetteredScanTask) (bool, error) {
+		if !deadLetter.Readable || !selected(deadLetter) {
+			return false, nil
+		}
+
+		// The task gets a fresh set of retries
+		task := deadLetter.Task
+		task.RetryCount = 0
+		task.ReceiptHandle = ""
+		if err := q.Enqueue(ctx, task); err != nil {
+			return false, err
+		}
+
+		if err := q.sqsClient.DeleteMessage(ctx, q.dlqURL, *message.ReceiptHandle); err != nil {
+			return false, errors.NewDependencyError(fmt.Sprintf("failed to delete replayed message from dead letter queue: %v", err))
+		}
+		replayed++
+
+		log.Info("Dead-lettered scan task replayed",
+			"message_id", deadLetter.MessageID,
+			"document_id", task.DocumentID,
+			"tenant_id", task.TenantID,
+			"priority", task.Priority)
+		return true, nil
+	})
+
+	return replayed, err
+}
+
+// DiscardDeadLetters deletes the selected dead letter messages. Unreadable messages are passed to
+// selected too, so they can be discarded by message ID.
+func (q *DocumentScanQueue) DiscardDeadLetters(ctx context.Context, selected func(services.DeadLetteredScanTask) bool) (int, error) {
+	log := logger.WithContext(ctx)
+
+	discarded := 0
+	err := q.visitDeadLetters(ctx, 0, func(message types.Message, deadLetter services.DeadLetteredScanTask) (bool, error) {
+		if !selected(deadLetter) {
+			return false, nil
+		}
+
+		if err := q.sqsClient.DeleteMessage(ctx, q.dlqURL, *message.ReceiptHandle); err != nil {
+			return false, errors.NewDependencyError(fmt.Sprintf("failed to delete discarded message from dead letter queue: %v", err))
+		}
+		discarded++
+
+		log.Info("Dead-lettered scan task discarded",
+			"message_id", deadLetter.MessageID,
+			"document_id", deadLetter.Task.DocumentID,
+			"tenant_id", deadLetter.Task.TenantID)
+		return true, nil
+	})
+
+	return discarded, err
+}
+
+// visitDeadLetters passes up to limit messages of the dead letter queue to visit, or all of them when
+// limit is 0, stopping at the first error. visit reports whether it deleted the message; the others
+// are made visible again afterwards.
+func (q *DocumentScanQueue) visitDeadLetters(ctx context.Context, limit int, visit func(types.Message, services.DeadLetteredScanTask) (bool, error)) error {
+	var peeked []types.Message
+	defer func() {
+		// Failures only delay the messages until the peek timeout
+		for _, message := range peeked {
+			_ = q.sqsClient.ChangeMessageVisibility(ctx, q.dlqURL, *message.ReceiptHandle, 0)
+		}
+	}()
+
+	seen := make(map[string]bool)
+	for {
+		messages, err := q.sqsClient.ReceiveMessage(ctx, q.dlqURL, maxBatchSize, deadLetterPeekTimeout, 0)
+		if err != nil {
+			return errors.NewDependencyError(fmt.Sprintf("failed to receive messages from dead letter queue: %v", err))
+		}
+
+		visited := 0
+		for _, message := range messages {
+			deadLetter := parseDeadLetter(message)
+			if seen[deadLetter.MessageID] {
+				continue
+			}
+			seen[deadLetter.MessageID] = true
+
+			if limit > 0 && len(seen) > limit {
+				peeked = append(peeked, message)
+				return nil
+			}
+			visited++
+
+			deleted, err := visit(message, deadLetter)
+			if !deleted {
+				peeked = append(peeked, message)
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		// The queue is exhausted once a receive returns no message that was not visited yet
+		if visited == 0 {
+			return nil
+		}
+	}
+}
+
+// parseDeadLetter reads a dead letter message. Besides the messages of DeadLetter and
+// MoveToDeadLetterQueue, it reads scan tasks moved by the redrive policy of the queue.
+func parseDeadLetter(message types.Message) services.DeadLetteredScanTask {
+	deadLetter := services.DeadLetteredScanTask{}
+	if message.MessageId != nil {
+		deadLetter.MessageID = *message.MessageId
+	}
+	if sent, err := strconv.ParseInt(message.Attributes[string(types.MessageSystemAttributeNameSentTimestamp)], 10, 64); err == nil {
+		deadLetter.DeadLetteredAt = time.UnixMilli(sent).UTC()
+	}
+	if message.Body == nil {
+		deadLetter.Reason = "empty message"
+		return deadLetter
+	}
+
+	var body deadLetterMessage
+	if err := json.Unmarshal([]byte(*message.Body), &body); err != nil {
+		deadLetter.Reason = fmt.Sprintf("unreadable message: %v", err)
+		return deadLetter
+	}
+	if body.Task != nil {
+		deadLetter.Task = *body.Task
+		deadLetter.Reason = body.Reason
+		if deadLetter.Reason == "" {
+			deadLetter.Reason = body.Error
+		}
+		deadLetter.Readable = deadLetter.Task.DocumentID != ""
+		return deadLetter
+	}
+
+	// A scan task moved by the redrive policy after too many receives
+	var task services.ScanTask
+	if err := json.Unmarshal([]byte(*message.Body), &task); err == nil && task.DocumentID != "" {
+		deadLetter.Task = task
+		deadLetter.Reason = "maximum receive count exceeded"
+		deadLetter.Readable = true
+		return deadLetter
+	}
+
+	deadLetter.Reason = "message is not a scan task"
+	return deadLetter
+}
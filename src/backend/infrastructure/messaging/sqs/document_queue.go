@@ -5,6 +5,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/sqs/types" // v2.0.0+
@@ -13,18 +15,52 @@ import (
 	"../../../../pkg/config"
 	"../../../../pkg/errors"
 	"../../../../pkg/logger"
+	"../../../../pkg/tracing"
 )
 
 const queueNameSuffix = "-document-scan-tasks"
+const highQueueNameSuffix = "-document-scan-tasks-high"
+const lowQueueNameSuffix = "-document-scan-tasks-low"
 const dlqNameSuffix = "-document-scan-tasks-dlq"
 const maxBatchSize = 10
 
-// DocumentScanQueue implements the services.ScanQueue interface using AWS SQS
+// scanPriorities are the priorities with a queue of their own, from highest to lowest
+var scanPriorities = []string{services.ScanPriorityHigh, services.ScanPriorityNormal, services.ScanPriorityLow}
+
+// defaultPriorityWeights are the weights the priority queues are consumed with when config.Scanning.Workers
+// leaves them unset
+var defaultPriorityWeights = map[string]int{
+	services.ScanPriorityHigh:   6,
+	services.ScanPriorityNormal: 3,
+	services.ScanPriorityLow:    1,
+}
+
+// Defaults for polling the scan queue when config.Scanning.Workers leaves them unset
+const (
+	defaultScanWaitTime          = 20 * time.Second
+	defaultScanVisibilityTimeout = 5 * time.Minute
+)
+
+// maxWaitTime is the longest SQS supports long polling for
+const maxWaitTime = 20 * time.Second
+
+// DocumentScanQueue implements the services.ScanQueue interface using AWS SQS. Tasks are queued in
+// a queue per priority, and Dequeue takes them from the queues by weight, so bulk imports do not
+// starve interactive uploads while no queue is starved either.
 type DocumentScanQueue struct {
-	sqsClient *SQSClient
-	queueURL  string
-	dlqURL    string
-	logger    logger.Logger
+	sqsClient         *SQSClient
+	queueURL          string // Queue of normal priority tasks
+	highQueueURL      string
+	lowQueueURL       string
+	dlqURL            string
+	waitTime          time.Duration // How long Dequeue long polls for a task
+	visibilityTimeout time.Duration // How long a dequeued task is hidden from other workers
+	logger            logger.Logger
+
+	// Smooth weighted round robin over the priority queues, shared by the workers dequeuing tasks
+	weightMu sync.Mutex
+	weights  map[string]int
+	credits  map[string]int
 }
 
 // NewDocumentScanQueue creates a new DocumentScanQueue instance that implements the ScanQueue interface
@@ -47,21 +83,178 @@ func NewDocumentScanQueue(ctx context.Context, sqsClient *SQSClient, cfg config.
 		return nil, errors.Wrap(err, "failed to get queue URL")
 	}
 
+	// Get the URLs of the high and low priority queues
+	highQueueURL, err := sqsClient.GetQueueURL(ctx, tenantPrefix+highQueueNameSuffix)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get high priority queue URL")
+	}
+	lowQueueURL, err := sqsClient.GetQueueURL(ctx, tenantPrefix+lowQueueNameSuffix)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get low priority queue URL")
+	}
+
 	// Get DLQ URL using GetQueueURL function
 	dlqURL, err := sqsClient.GetQueueURL(ctx, dlqName)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get DLQ URL")
 	}
 
+	waitTime := defaultScanWaitTime
+	if cfg.Scanning.Workers.WaitTime != "" {
+		parsed, err := time.ParseDuration(cfg.Scanning.Workers.WaitTime)
+		if err != nil || parsed < 0 || parsed > maxWaitTime {
+			return nil, errors.NewValidationError(fmt.Sprintf("invalid scan queue wait time: %s", cfg.Scanning.Workers.WaitTime))
+		}
+		waitTime = parsed
+	}
+
+	visibilityTimeout := defaultScanVisibilityTimeout
+	if cfg.Scanning.Workers.VisibilityTimeout != "" {
+		parsed, err := time.ParseDuration(cfg.Scanning.Workers.VisibilityTimeout)
+		if err != nil || parsed < time.Second {
+			return nil, errors.NewValidationError(fmt.Sprintf("invalid scan queue visibility timeout: %s", cfg.Scanning.Workers.VisibilityTimeout))
+		}
+		visibilityTimeout = parsed
+	}
+
+	weights, err := priorityWeights(cfg.Scanning.Workers.PriorityWeights)
+	if err != nil {
+		return nil, err
+	}
+
 	// Initialize and return new DocumentScanQueue with the SQS client and queue URLs
 	return &DocumentScanQueue{
-		sqsClient: sqsClient,
-		queueURL:  queueURL,
-		dlqURL:    dlqURL,
-		logger:    logger.WithField("component", "DocumentScanQueue"),
+		sqsClient:         sqsClient,
+		queueURL:          queueURL,
+		highQueueURL:      highQueueURL,
+		lowQueueURL:       lowQueueURL,
+		dlqURL:            dlqURL,
+		waitTime:          waitTime,
+		visibilityTimeout: visibilityTimeout,
+		logger:            logger.WithField("component", "DocumentScanQueue"),
+		weights:           weights,
+		credits:           make(map[string]int),
+	}, nil
+}
+
+// priorityWeights returns the weights of the priority queues, or the defaults when none is set
+func priorityWeights(cfg config.ScanPriorityWeights) (map[string]int, error) {
+	if cfg.High < 0 || cfg.Normal < 0 || cfg.Low < 0 {
+		return nil, errors.NewValidationError("scan queue priority weights cannot be negative")
+	}
+	if cfg.High == 0 && cfg.Normal == 0 && cfg.Low == 0 {
+		return defaultPriorityWeights, nil
+	}
+	return map[string]int{
+		services.ScanPriorityHigh:   cfg.High,
+		services.ScanPriorityNormal: cfg.Normal,
+		services.ScanPriorityLow:    cfg.Low,
 	}, nil
 }
 
+// queueURLFor returns the URL of the queue holding tasks of a priority. Tasks without a priority,
+// including those queued before priorities were introduced, are in the normal priority queue.
+func (q *DocumentScanQueue) queueURLFor(priority string) string {
+	switch {
+	case priority == services.ScanPriorityHigh && q.highQueueURL != "":
+		return q.highQueueURL
+	case priority == services.ScanPriorityLow && q.lowQueueURL != "":
+		return q.lowQueueURL
+	}
+	return q.queueURL
+}
+
+// pollOrder returns the priorities in the order their queues are polled for the next task: the
+// priority whose turn it is by weight first, then the others from highest to lowest. A weight of
+// 6, 3 and 1 makes the high priority queue first in 6 of 10 polls.
+func (q *DocumentScanQueue) pollOrder() []string {
+	q.weightMu.Lock()
+	defer q.weightMu.Unlock()
+
+	if q.credits == nil {
+		q.credits = make(map[string]int)
+	}
+
+	first := ""
+	total := 0
+	for _, priority := range scanPriorities {
+		weight := q.weights[priority]
+		q.credits[priority] += weight
+		total += weight
+		if first == "" || q.credits[priority] > q.credits[first] {
+			first = priority
+		}
+	}
+	q.credits[first] -= total
+
+	order := make([]string, 0, len(scanPriorities))
+	order = append(order, first)
+	for _, priority := range scanPriorities {
+		if priority != first {
+			order = append(order, priority)
+		}
+	}
+	return order
+}
+
+// receiveTasks receives up to maxMessages tasks from the queue of a priority, waiting for up to
+// waitTime for one to arrive. Messages that are not scan tasks are logged and skipped; an error is
+// only returned when none of the messages was a scan task.
+func (q *DocumentScanQueue) receiveTasks(ctx context.Context, priority string, maxMessages int32, waitTime time.Duration) ([]services.ScanTask, error) {
+	messages, err := q.sqsClient.ReceiveMessage(ctx, q.queueURLFor(priority), maxMessages, q.visibilityTimeout, waitTime)
+	if err != nil {
+		return nil, err
+	}
+
+	var unmarshalErr error
+	tasks := make([]services.ScanTask, 0, len(messages))
+	for _, message := range messages {
+		var task services.ScanTask
+		if err := json.Unmarshal([]byte(*message.Body), &task); err != nil {
+			logger.WithContext(ctx).Error("Failed to unmarshal scan task from JSON",
+				"error", err,
+				"message_body", *message.Body)
+			unmarshalErr = err
+			continue
+		}
+
+		// The task is completed in the queue it was received from
+		task.Priority = priority
+		task.ReceiptHandle = *message.ReceiptHandle
+		task.TraceContext = traceContext(message.MessageAttributes)
+		tasks = append(tasks, task)
+	}
+	if len(tasks) == 0 && unmarshalErr != nil {
+		return nil, errors.Wrap(unmarshalErr, "failed to unmarshal scan task from JSON")
+	}
+	return tasks, nil
+}
+
+// traceContext returns the trace context carried in the string attributes of a message, so the
+// processing of a task continues the trace of the request that queued it
+func traceContext(attributes map[string]types.MessageAttributeValue) map[string]string {
+	carrier := make(map[string]string, len(attributes))
+	for name, value := range attributes {
+		if value.StringValue != nil {
+			carrier[name] = *value.StringValue
+		}
+	}
+	return carrier
+}
+
+// messageAttributes returns the message attributes carrying the trace context and request ID of
+// the context, so the task is scanned in the trace and log context of the upload that queued it
+func messageAttributes(ctx context.Context) map[string]string {
+	attributes := tracing.Inject(ctx)
+	if requestID := logger.RequestIDFromContext(ctx); requestID != "" {
+		if attributes == nil {
+			attributes = make(map[string]string, 1)
+		}
+		attributes[logger.RequestIDKey] = requestID
+	}
+	return attributes
+}
+
 // Enqueue adds a document to the scanning queue
 func (q *DocumentScanQueue) Enqueue(ctx context.Context, task services.ScanTask) error {
 	log := logger.WithContext(ctx)
@@ -72,111 +265,146 @@ func (q *DocumentScanQueue) Enqueue(ctx context.Context, task services.ScanTask)
 		return errors.Wrap(err, "failed to marshal scan task to JSON")
 	}
 	
-	// Send the JSON message to the SQS queue using sqsClient.SendMessage
-	err = q.sqsClient.SendMessage(ctx, q.queueURL, string(taskJSON))
+	// Send the JSON message to the queue of the task's priority using sqsClient.SendMessage
+	_, err = q.sqsClient.SendMessage(ctx, q.queueURLFor(task.Priority), string(taskJSON), messageAttributes(ctx))
 	if err != nil {
 		return errors.NewDependencyError(fmt.Sprintf("failed to enqueue scan task: %v", err))
 	}
 	
 	log.Info("Document scan task enqueued successfully", 
 		"document_id", task.DocumentID,
-		"tenant_id", task.TenantID)
+		"tenant_id", task.TenantID,
+		"priority", task.Priority)
 	
 	return nil
 }
 
-// Dequeue retrieves the next document to scan from the queue
+// Dequeue retrieves the next document to scan, taking it from the priority queues by weight. When
+// all queues are empty, it long polls the high priority queue for up to the wait time, so that
+// interactive uploads are picked up as soon as they arrive. The message stays in the queue, hidden
+// for the visibility timeout, until the task is completed.
 func (q *DocumentScanQueue) Dequeue(ctx context.Context) (*services.ScanTask, error) {
-	log := logger.WithContext(ctx)
-	
-	// Receive a single message from the SQS queue using sqsClient.ReceiveMessage
-	messages, err := q.sqsClient.ReceiveMessage(ctx, q.queueURL, 1)
+	tasks, err := q.dequeue(ctx, 1)
 	if err != nil {
 		return nil, errors.NewDependencyError(fmt.Sprintf("failed to dequeue scan task: %v", err))
 	}
 	
 	// If no messages are received, return nil, nil
-	if len(messages) == 0 {
+	if len(tasks) == 0 {
 		return nil, nil
 	}
 	
-	// Unmarshal the message body to a ScanTask
-	var task services.ScanTask
-	err = json.Unmarshal([]byte(*messages[0].Body), &task)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to unmarshal scan task from JSON")
-	}
+	logger.WithContext(ctx).Info("Document scan task dequeued successfully", 
+		"document_id", tasks[0].DocumentID,
+		"tenant_id", tasks[0].TenantID,
+		"priority", tasks[0].Priority)
 	
-	// Delete the message from the queue using sqsClient.DeleteMessage
-	err = q.sqsClient.DeleteMessage(ctx, q.queueURL, *messages[0].ReceiptHandle)
-	if err != nil {
-		return nil, errors.NewDependencyError(fmt.Sprintf("failed to delete message from queue: %v", err))
-	}
-	
-	log.Info("Document scan task dequeued successfully", 
-		"document_id", task.DocumentID,
-		"tenant_id", task.TenantID)
-	
-	return &task, nil
+	return &tasks[0], nil
 }
 
-// DequeueBatch retrieves multiple documents to scan from the queue
+// DequeueBatch retrieves multiple documents to scan from the priority queues
 func (q *DocumentScanQueue) DequeueBatch(ctx context.Context, batchSize int) ([]services.ScanTask, error) {
-	log := logger.WithContext(ctx)
-	
 	// If batchSize > maxBatchSize, limit to maxBatchSize
 	if batchSize > maxBatchSize {
 		batchSize = maxBatchSize
 	}
 	
-	// Receive messages from the SQS queue using sqsClient.ReceiveMessage
-	messages, err := q.sqsClient.ReceiveMessage(ctx, q.queueURL, batchSize)
+	tasks, err := q.dequeue(ctx, batchSize)
 	if err != nil {
 		return nil, errors.NewDependencyError(fmt.Sprintf("failed to dequeue scan tasks batch: %v", err))
 	}
 	
-	// If no messages are received, return empty slice, nil
-	if len(messages) == 0 {
-		return []services.ScanTask{}, nil
-	}
-	
-	// Initialize slice to hold scan tasks
-	tasks := make([]services.ScanTask, 0, len(messages))
+	logger.WithContext(ctx).Info("Document scan tasks batch dequeued successfully", 
+		"count", len(tasks))
 	
-	// For each message:
-	for _, message := range messages {
-		// Unmarshal the message body to a ScanTask
-		var task services.ScanTask
-		err = json.Unmarshal([]byte(*message.Body), &task)
+	return tasks, nil
+}
+
+// dequeue receives up to count tasks from the priority queues in poll order without waiting, then
+// long polls the high priority queue when none of them had a task
+func (q *DocumentScanQueue) dequeue(ctx context.Context, count int) ([]services.ScanTask, error) {
+	tasks := make([]services.ScanTask, 0, count)
+	for _, priority := range q.pollOrder() {
+		received, err := q.receiveTasks(ctx, priority, int32(count-len(tasks)), 0)
 		if err != nil {
-			log.Error("Failed to unmarshal scan task from JSON", 
-				"error", err,
-				"message_body", *message.Body)
+			return nil, err
+		}
+		tasks = append(tasks, received...)
+		if len(tasks) == count {
+			return tasks, nil
+		}
+	}
+	if len(tasks) > 0 || q.waitTime == 0 {
+		return tasks, nil
+	}
+	
+	return q.receiveTasks(ctx, services.ScanPriorityHigh, int32(count), q.waitTime)
+}
+
+// Ping checks the scan queues can be reached, so the readiness of the service can be reported
+func (q *DocumentScanQueue) Ping(ctx context.Context) error {
+	for _, queueURL := range []string{q.highQueueURL, q.queueURL, q.lowQueueURL} {
+		if _, err := q.sqsClient.GetQueueAttributes(ctx, queueURL, []string{"QueueArn"}); err != nil {
+			return errors.NewDependencyError(fmt.Sprintf("scan queue %s is unreachable: %s", queueURL, err.Error()))
+		}
+	}
+	return nil
+}
+
+// Depth returns the approximate number of tasks waiting in the queue of each priority. Priorities
+// without a queue of their own share the normal priority queue and are not reported separately.
+func (q *DocumentScanQueue) Depth(ctx context.Context) (map[string]int, error) {
+	depth := make(map[string]int, len(scanPriorities))
+	for _, priority := range scanPriorities {
+		queueURL := q.queueURLFor(priority)
+		if priority != services.ScanPriorityNormal && queueURL == q.queueURL {
 			continue
 		}
 		
-		// Delete the message from the queue using sqsClient.DeleteMessage
-		err = q.sqsClient.DeleteMessage(ctx, q.queueURL, *message.ReceiptHandle)
+		attributes, err := q.sqsClient.GetQueueAttributes(ctx, queueURL, []string{"ApproximateNumberOfMessages"})
 		if err != nil {
-			log.Error("Failed to delete message from queue", 
-				"error", err,
-				"receipt_handle", *message.ReceiptHandle)
-			continue
+			return nil, errors.NewDependencyError(fmt.Sprintf("failed to get depth of scan queue %s: %s", queueURL, err.Error()))
 		}
-		
-		// Add the task to the slice
-		tasks = append(tasks, task)
+		count, err := strconv.Atoi(attributes["ApproximateNumberOfMessages"])
+		if err != nil {
+			return nil, errors.NewDependencyError(fmt.Sprintf("invalid depth of scan queue %s: %s", queueURL, err.Error()))
+		}
+		depth[priority] = count
 	}
-	
-	log.Info("Document scan tasks batch dequeued successfully", 
-		"count", len(tasks))
-	
-	return tasks, nil
+	return depth, nil
 }
 
 // Complete marks a scan task as completed and removes it from the queue
 func (q *DocumentScanQueue) Complete(ctx context.Context, task services.ScanTask) error {
-	// This is a no-op in the SQS implementation as messages are deleted when dequeued
+	return q.deleteDelivery(ctx, task)
+}
+
+// ExtendVisibility keeps a dequeued task hidden from other workers for timeout from now
+func (q *DocumentScanQueue) ExtendVisibility(ctx context.Context, task services.ScanTask, timeout time.Duration) error {
+	if task.ReceiptHandle == "" {
+		return errors.NewValidationError("scan task was not dequeued")
+	}
+	
+	err := q.sqsClient.ChangeMessageVisibility(ctx, q.queueURLFor(task.Priority), task.ReceiptHandle, int32(timeout.Seconds()))
+	if err != nil {
+		return errors.NewDependencyError(fmt.Sprintf("failed to extend scan task visibility: %v", err))
+	}
+	
+	return nil
+}
+
+// deleteDelivery deletes the message a dequeued task was delivered in, once the task has been
+// completed or handed over to another message. Tasks that were not dequeued have no message.
+func (q *DocumentScanQueue) deleteDelivery(ctx context.Context, task services.ScanTask) error {
+	if task.ReceiptHandle == "" {
+		return nil
+	}
+	
+	err := q.sqsClient.DeleteMessage(ctx, q.queueURLFor(task.Priority), task.ReceiptHandle)
+	if err != nil {
+		return errors.NewDependencyError(fmt.Sprintf("failed to delete message from queue: %v", err))
+	}
+	
 	return nil
 }
 
@@ -193,12 +421,17 @@ func (q *DocumentScanQueue) Retry(ctx context.Context, task services.ScanTask) e
 		return errors.Wrap(err, "failed to marshal scan task to JSON")
 	}
 	
-	// Send the JSON message to the SQS queue using sqsClient.SendMessage
-	err = q.sqsClient.SendMessage(ctx, q.queueURL, string(taskJSON))
+	// Send the JSON message to the queue of the task's priority using sqsClient.SendMessage
+	_, err = q.sqsClient.SendMessage(ctx, q.queueURLFor(task.Priority), string(taskJSON), messageAttributes(ctx))
 	if err != nil {
 		return errors.NewDependencyError(fmt.Sprintf("failed to requeue scan task for retry: %v", err))
 	}
 	
+	// The retry is a new message, so the delivery of the failed attempt is removed
+	if err := q.deleteDelivery(ctx, task); err != nil {
+		return err
+	}
+	
 	log.Info("Document scan task requeued for retry", 
 		"document_id", task.DocumentID,
 		"tenant_id", task.TenantID,
@@ -227,11 +460,15 @@ func (q *DocumentScanQueue) DeadLetter(ctx context.Context, task services.ScanTa
 	}
 	
 	// Send the JSON message to the DLQ using sqsClient.SendMessage
-	err = q.sqsClient.SendMessage(ctx, q.dlqURL, string(messageJSON))
+	_, err = q.sqsClient.SendMessage(ctx, q.dlqURL, string(messageJSON), messageAttributes(ctx))
 	if err != nil {
 		return errors.NewDependencyError(fmt.Sprintf("failed to move scan task to dead letter queue: %v", err))
 	}
 	
+	if err := q.deleteDelivery(ctx, task); err != nil {
+		return err
+	}
+	
 	log.Info("Document scan task moved to dead letter queue", 
 		"document_id", task.DocumentID,
 		"tenant_id", task.TenantID,
@@ -260,7 +497,7 @@ func (q *DocumentScanQueue) MoveToDeadLetterQueue(ctx context.Context, task serv
 	}
 	
 	// Send the JSON message to the DLQ using sqsClient.SendMessage
-	err = q.sqsClient.SendMessage(ctx, q.dlqURL, string(messageJSON))
+	_, err = q.sqsClient.SendMessage(ctx, q.dlqURL, string(messageJSON), messageAttributes(ctx))
 	if err != nil {
 		return errors.NewDependencyError(fmt.Sprintf("failed to move scan task to dead letter queue: %v", err))
 	}
@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws" // v2.0.0+
 	"github.com/aws/aws-sdk-go-v2/service/sqs/types" // v2.0.0+
 	"github.com/stretchr/testify/assert" // v1.8.0+
 	"github.com/stretchr/testify/mock" // v1.8.0+
@@ -15,6 +16,7 @@ import (
 	"../../../../domain/services"
 	"../../../../pkg/config"
 	pkgErrors "../../../../pkg/errors"
+	"../../../../pkg/logger"
 )
 
 // mockSQSClient is a mock implementation of the SQSClient for testing
@@ -28,8 +30,8 @@ func (m *mockSQSClient) SendMessage(ctx context.Context, queueURL string, messag
 }
 
 // ReceiveMessage mock implementation of ReceiveMessage
-func (m *mockSQSClient) ReceiveMessage(ctx context.Context, queueURL string, maxMessages int32, visibilityTimeout time.Duration) ([]types.Message, error) {
-	return m.Called(ctx, queueURL, maxMessages, visibilityTimeout).Get(0).([]types.Message), m.Called(ctx, queueURL, maxMessages, visibilityTimeout).Error(1)
+func (m *mockSQSClient) ReceiveMessage(ctx context.Context, queueURL string, maxMessages int32, visibilityTimeout time.Duration, waitTime time.Duration) ([]types.Message, error) {
+	return m.Called(ctx, queueURL, maxMessages, visibilityTimeout, waitTime).Get(0).([]types.Message), m.Called(ctx, queueURL, maxMessages, visibilityTimeout, waitTime).Error(1)
 }
 
 // DeleteMessage mock implementation of DeleteMessage
@@ -37,6 +39,11 @@ func (m *mockSQSClient) DeleteMessage(ctx context.Context, queueURL string, rece
 	return m.Called(ctx, queueURL, receiptHandle).Error(0)
 }
 
+// ChangeMessageVisibility mock implementation of ChangeMessageVisibility
+func (m *mockSQSClient) ChangeMessageVisibility(ctx context.Context, queueURL string, receiptHandle string, visibilityTimeout int32) error {
+	return m.Called(ctx, queueURL, receiptHandle, visibilityTimeout).Error(0)
+}
+
 const testQueueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue"
 const testDLQURL = "https://sqs.us-east-1.amazonaws.com/123456789012/test-dlq"
 
@@ -176,16 +183,13 @@ func TestDocumentScanQueue_Dequeue(t *testing.T) {
 	receiptStr := "receipt-handle-123"
 	
 	// Set up expectations for ReceiveMessage to return a message with the task
-	mockClient.On("ReceiveMessage", mock.Anything, testQueueURL, int32(1), mock.Anything).Return([]types.Message{
+	mockClient.On("ReceiveMessage", mock.Anything, testQueueURL, int32(1), mock.Anything, mock.Anything).Return([]types.Message{
 		{
 			Body:          &bodyStr,
 			ReceiptHandle: &receiptStr,
 		},
 	}, nil)
 	
-	// Set up expectations for DeleteMessage
-	mockClient.On("DeleteMessage", mock.Anything, testQueueURL, receiptStr).Return(nil)
-	
 	// Create a DocumentScanQueue with the mock client
 	queue := &DocumentScanQueue{
 		client:   mockClient,
@@ -198,6 +202,9 @@ func TestDocumentScanQueue_Dequeue(t *testing.T) {
 	
 	// Assert that the returned task is not nil
 	assert.NotNil(t, task)
+	// Assert that the message stays in the queue until the task is completed
+	assert.Equal(t, receiptStr, task.ReceiptHandle)
+	mockClient.AssertNotCalled(t, "DeleteMessage", mock.Anything, mock.Anything, mock.Anything)
 	// Assert that the task properties match the test task
 	assert.Equal(t, testTask.DocumentID, task.DocumentID)
 	assert.Equal(t, testTask.VersionID, task.VersionID)
@@ -214,7 +221,7 @@ func TestDocumentScanQueue_Dequeue_NoMessages(t *testing.T) {
 	mockClient := new(mockSQSClient)
 	
 	// Set up expectations for ReceiveMessage to return empty messages
-	mockClient.On("ReceiveMessage", mock.Anything, testQueueURL, int32(1), mock.Anything).Return([]types.Message{}, nil)
+	mockClient.On("ReceiveMessage", mock.Anything, testQueueURL, int32(1), mock.Anything, mock.Anything).Return([]types.Message{}, nil)
 	
 	// Create a DocumentScanQueue with the mock client
 	queue := &DocumentScanQueue{
@@ -240,7 +247,7 @@ func TestDocumentScanQueue_Dequeue_ReceiveError(t *testing.T) {
 	mockClient := new(mockSQSClient)
 	
 	// Set up expectations for ReceiveMessage to return an error
-	mockClient.On("ReceiveMessage", mock.Anything, testQueueURL, int32(1), mock.Anything).Return([]types.Message{}, errors.New("receive error"))
+	mockClient.On("ReceiveMessage", mock.Anything, testQueueURL, int32(1), mock.Anything, mock.Anything).Return([]types.Message{}, errors.New("receive error"))
 	
 	// Create a DocumentScanQueue with the mock client
 	queue := &DocumentScanQueue{
@@ -270,64 +277,13 @@ func TestDocumentScanQueue_Dequeue_UnmarshalError(t *testing.T) {
 	// Set up expectations for ReceiveMessage to return a message with invalid JSON
 	bodyStr := "invalid-json"
 	receiptStr := "receipt-handle-123"
-	mockClient.On("ReceiveMessage", mock.Anything, testQueueURL, int32(1), mock.Anything).Return([]types.Message{
-		{
-			Body:          &bodyStr,
-			ReceiptHandle: &receiptStr,
-		},
-	}, nil)
-	
-	// Set up expectations for DeleteMessage
-	mockClient.On("DeleteMessage", mock.Anything, testQueueURL, receiptStr).Return(nil)
-	
-	// Create a DocumentScanQueue with the mock client
-	queue := &DocumentScanQueue{
-		client:   mockClient,
-		queueURL: testQueueURL,
-		dlqURL:   testDLQURL,
-	}
-	
-	// Call Dequeue
-	task, err := queue.Dequeue(context.Background())
-	
-	// Assert that the returned task is nil
-	assert.Nil(t, task)
-	// Assert that an error is returned
-	assert.Error(t, err)
-	// Verify that all expectations were met
-	mockClient.AssertExpectations(t)
-}
-
-// TestDocumentScanQueue_Dequeue_DeleteError tests error handling when deleting a message fails
-func TestDocumentScanQueue_Dequeue_DeleteError(t *testing.T) {
-	// Create a mock SQS client
-	mockClient := new(mockSQSClient)
-	
-	// Create a test ScanTask and marshal it to JSON
-	testTask := services.ScanTask{
-		DocumentID:  "doc-123",
-		VersionID:   "ver-123",
-		TenantID:    "tenant-123",
-		StoragePath: "path/to/document",
-		RetryCount:  0,
-	}
-	
-	taskJSON, err := json.Marshal(testTask)
-	require.NoError(t, err)
-	
-	// Set up expectations for ReceiveMessage to return a message with the task
-	bodyStr := string(taskJSON)
-	receiptStr := "receipt-handle-123"
-	mockClient.On("ReceiveMessage", mock.Anything, testQueueURL, int32(1), mock.Anything).Return([]types.Message{
+	mockClient.On("ReceiveMessage", mock.Anything, testQueueURL, int32(1), mock.Anything, mock.Anything).Return([]types.Message{
 		{
 			Body:          &bodyStr,
 			ReceiptHandle: &receiptStr,
 		},
 	}, nil)
 	
-	// Set up expectations for DeleteMessage to return an error
-	mockClient.On("DeleteMessage", mock.Anything, testQueueURL, receiptStr).Return(errors.New("delete error"))
-	
 	// Create a DocumentScanQueue with the mock client
 	queue := &DocumentScanQueue{
 		client:   mockClient,
@@ -342,8 +298,6 @@ func TestDocumentScanQueue_Dequeue_DeleteError(t *testing.T) {
 	assert.Nil(t, task)
 	// Assert that an error is returned
 	assert.Error(t, err)
-	// Assert that the error is a dependency error
-	assert.True(t, pkgErrors.IsDependencyError(err))
 	// Verify that all expectations were met
 	mockClient.AssertExpectations(t)
 }
@@ -384,13 +338,10 @@ func TestDocumentScanQueue_DequeueBatch(t *testing.T) {
 			Body:          &bodyStr,
 			ReceiptHandle: &receiptStr,
 		}
-		
-		// Set up expectations for DeleteMessage for each message
-		mockClient.On("DeleteMessage", mock.Anything, testQueueURL, receiptStr).Return(nil)
 	}
 	
 	// Set up expectations for ReceiveMessage to return messages with the tasks
-	mockClient.On("ReceiveMessage", mock.Anything, testQueueURL, int32(2), mock.Anything).Return(messages, nil)
+	mockClient.On("ReceiveMessage", mock.Anything, testQueueURL, int32(2), mock.Anything, mock.Anything).Return(messages, nil)
 	
 	// Create a DocumentScanQueue with the mock client
 	queue := &DocumentScanQueue{
@@ -413,6 +364,7 @@ func TestDocumentScanQueue_DequeueBatch(t *testing.T) {
 		assert.Equal(t, testTasks[i].TenantID, task.TenantID)
 		assert.Equal(t, testTasks[i].StoragePath, task.StoragePath)
 		assert.Equal(t, testTasks[i].RetryCount, task.RetryCount)
+		assert.Equal(t, "receipt-handle-"+testTasks[i].DocumentID, task.ReceiptHandle)
 	}
 	// Verify that all expectations were met
 	mockClient.AssertExpectations(t)
@@ -425,7 +377,7 @@ func TestDocumentScanQueue_DequeueBatch_MaxBatchSize(t *testing.T) {
 	
 	// Set up expectations for ReceiveMessage with maxBatchSize
 	const maxBatchSize = 10
-	mockClient.On("ReceiveMessage", mock.Anything, testQueueURL, int32(maxBatchSize), mock.Anything).Return([]types.Message{}, nil)
+	mockClient.On("ReceiveMessage", mock.Anything, testQueueURL, int32(maxBatchSize), mock.Anything, mock.Anything).Return([]types.Message{}, nil)
 	
 	// Create a DocumentScanQueue with the mock client
 	queue := &DocumentScanQueue{
@@ -468,12 +420,120 @@ func TestDocumentScanQueue_Complete(t *testing.T) {
 	// Call Complete with the test task
 	err := queue.Complete(context.Background(), task)
 	
-	// Assert that no error is returned (Complete is a no-op in SQS implementation)
+	// Assert that no error is returned (Complete is a no-op for tasks that were not dequeued)
+	assert.NoError(t, err)
+	// Verify that all expectations were met
+	mockClient.AssertExpectations(t)
+}
+
+// TestDocumentScanQueue_Complete_DeletesDelivery tests that completing a dequeued task removes its message
+func TestDocumentScanQueue_Complete_DeletesDelivery(t *testing.T) {
+	// Create a mock SQS client
+	mockClient := new(mockSQSClient)
+	
+	// Set up expectations for DeleteMessage
+	receiptStr := "receipt-handle-123"
+	mockClient.On("DeleteMessage", mock.Anything, testQueueURL, receiptStr).Return(nil)
+	
+	// Create a DocumentScanQueue with the mock client
+	queue := &DocumentScanQueue{
+		client:   mockClient,
+		queueURL: testQueueURL,
+		dlqURL:   testDLQURL,
+	}
+	
+	// Create a dequeued test ScanTask
+	task := services.ScanTask{
+		DocumentID:    "doc-123",
+		VersionID:     "ver-123",
+		TenantID:      "tenant-123",
+		StoragePath:   "path/to/document",
+		ReceiptHandle: receiptStr,
+	}
+	
+	// Call Complete with the test task
+	err := queue.Complete(context.Background(), task)
+	
+	// Assert that no error is returned
+	assert.NoError(t, err)
+	// Verify that all expectations were met
+	mockClient.AssertExpectations(t)
+}
+
+// TestDocumentScanQueue_Complete_DeleteError tests error handling when deleting a message fails
+func TestDocumentScanQueue_Complete_DeleteError(t *testing.T) {
+	// Create a mock SQS client
+	mockClient := new(mockSQSClient)
+	
+	// Set up expectations for DeleteMessage to return an error
+	receiptStr := "receipt-handle-123"
+	mockClient.On("DeleteMessage", mock.Anything, testQueueURL, receiptStr).Return(errors.New("delete error"))
+	
+	// Create a DocumentScanQueue with the mock client
+	queue := &DocumentScanQueue{
+		client:   mockClient,
+		queueURL: testQueueURL,
+		dlqURL:   testDLQURL,
+	}
+	
+	// Call Complete with a dequeued task
+	err := queue.Complete(context.Background(), services.ScanTask{DocumentID: "doc-123", ReceiptHandle: receiptStr})
+	
+	// Assert that an error is returned
+	assert.Error(t, err)
+	// Assert that the error is a dependency error
+	assert.True(t, pkgErrors.IsDependencyError(err))
+	// Verify that all expectations were met
+	mockClient.AssertExpectations(t)
+}
+
+// TestDocumentScanQueue_ExtendVisibility tests extending the visibility timeout of a dequeued task
+func TestDocumentScanQueue_ExtendVisibility(t *testing.T) {
+	// Create a mock SQS client
+	mockClient := new(mockSQSClient)
+	
+	// Set up expectations for ChangeMessageVisibility
+	receiptStr := "receipt-handle-123"
+	mockClient.On("ChangeMessageVisibility", mock.Anything, testQueueURL, receiptStr, int32(300)).Return(nil)
+	
+	// Create a DocumentScanQueue with the mock client
+	queue := &DocumentScanQueue{
+		client:   mockClient,
+		queueURL: testQueueURL,
+		dlqURL:   testDLQURL,
+	}
+	
+	// Call ExtendVisibility with a dequeued task
+	err := queue.ExtendVisibility(context.Background(), services.ScanTask{DocumentID: "doc-123", ReceiptHandle: receiptStr}, 5*time.Minute)
+	
+	// Assert that no error is returned
 	assert.NoError(t, err)
 	// Verify that all expectations were met
 	mockClient.AssertExpectations(t)
 }
 
+// TestDocumentScanQueue_ExtendVisibility_NotDequeued tests that tasks that were not dequeued are rejected
+func TestDocumentScanQueue_ExtendVisibility_NotDequeued(t *testing.T) {
+	// Create a mock SQS client
+	mockClient := new(mockSQSClient)
+	
+	// Create a DocumentScanQueue with the mock client
+	queue := &DocumentScanQueue{
+		client:   mockClient,
+		queueURL: testQueueURL,
+		dlqURL:   testDLQURL,
+	}
+	
+	// Call ExtendVisibility with a task without receipt handle
+	err := queue.ExtendVisibility(context.Background(), services.ScanTask{DocumentID: "doc-123"}, 5*time.Minute)
+	
+	// Assert that a validation error is returned
+	assert.Error(t, err)
+	assert.True(t, pkgErrors.IsValidationError(err))
+	// Verify that ChangeMessageVisibility was not called
+	mockClient.AssertNotCalled(t, "ChangeMessageVisibility", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
 // TestDocumentScanQueue_Retry tests retrying a document scan task
 func TestDocumentScanQueue_Retry(t *testing.T) {
 	// Create a mock SQS client
@@ -646,4 +706,120 @@ func TestDocumentScanQueue_MoveToDeadLetterQueue(t *testing.T) {
 	assert.NoError(t, err)
 	// Verify that all expectations were met
 	mockClient.AssertExpectations(t)
-}
\ No newline at end of file
+}
+
+// TestDocumentScanQueue_pollOrder tests that the priority queues are polled first by weight
+func TestDocumentScanQueue_pollOrder(t *testing.T) {
+	// Create a DocumentScanQueue with the default weights
+	queue := &DocumentScanQueue{weights: defaultPriorityWeights}
+	
+	// Count which queue is polled first over a full round of weights
+	first := map[string]int{}
+	for i := 0; i < 10; i++ {
+		order := queue.pollOrder()
+		// Assert that every queue is polled, so none is starved
+		assert.ElementsMatch(t, scanPriorities, order)
+		first[order[0]]++
+	}
+	
+	// Assert that each queue was polled first as often as its weight
+	assert.Equal(t, map[string]int{
+		services.ScanPriorityHigh:   6,
+		services.ScanPriorityNormal: 3,
+		services.ScanPriorityLow:    1,
+	}, first)
+}
+
+// TestDocumentScanQueue_Enqueue_Priority tests that tasks are sent to the queue of their priority
+func TestDocumentScanQueue_Enqueue_Priority(t *testing.T) {
+	// Create a DocumentScanQueue with a queue per priority
+	queue := &DocumentScanQueue{
+		queueURL:     testQueueURL,
+		highQueueURL: testQueueURL + "-high",
+		lowQueueURL:  testQueueURL + "-low",
+		dlqURL:       testDLQURL,
+	}
+	
+	// Assert that each priority maps to its queue, and tasks without priority to the normal queue
+	assert.Equal(t, testQueueURL+"-high", queue.queueURLFor(services.ScanPriorityHigh))
+	assert.Equal(t, testQueueURL, queue.queueURLFor(services.ScanPriorityNormal))
+	assert.Equal(t, testQueueURL+"-low", queue.queueURLFor(services.ScanPriorityLow))
+	assert.Equal(t, testQueueURL, queue.queueURLFor(""))
+}
+
+// TestParseDeadLetter tests reading the dead letter messages of DeadLetter and of the redrive policy
+func TestParseDeadLetter(t *testing.T) {
+	messageID := "message-123"
+	sentTimestamp := "1700000000000"
+	
+	testCases := []struct {
+		name     string
+		body     string
+		reason   string
+		readable bool
+	}{
+		{
+			name:     "DeadLetter message",
+			body:     `{"task":{"DocumentID":"doc-123","TenantID":"tenant-123","Priority":"low"},"reason":"Max retries exceeded: timeout"}`,
+			reason:   "Max retries exceeded: timeout",
+			readable: true,
+		},
+		{
+			name:     "MoveToDeadLetterQueue message",
+			body:     `{"task":{"DocumentID":"doc-123","TenantID":"tenant-123","Priority":"low"},"error":"storage unavailable"}`,
+			reason:   "storage unavailable",
+			readable: true,
+		},
+		{
+			name:     "Scan task moved by the redrive policy",
+			body:     `{"DocumentID":"doc-123","TenantID":"tenant-123","Priority":"low"}`,
+			reason:   "maximum receive count exceeded",
+			readable: true,
+		},
+		{
+			name:     "Unreadable message",
+			body:     "invalid-json",
+			readable: false,
+		},
+	}
+	
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			body := tc.body
+			deadLetter := parseDeadLetter(types.Message{
+				MessageId:  &messageID,
+				Body:       &body,
+				Attributes: map[string]string{"SentTimestamp": sentTimestamp},
+			})
+			
+			assert.Equal(t, messageID, deadLetter.MessageID)
+			assert.Equal(t, time.UnixMilli(1700000000000).UTC(), deadLetter.DeadLetteredAt)
+			assert.Equal(t, tc.readable, deadLetter.Readable)
+			if tc.readable {
+				assert.Equal(t, tc.reason, deadLetter.Reason)
+				assert.Equal(t, "doc-123", deadLetter.Task.DocumentID)
+				assert.Equal(t, services.ScanPriorityLow, deadLetter.Task.Priority)
+			}
+		})
+	}
+}
+
+// TestTraceContext tests reading the trace context of a scan task from its message attributes
+func TestTraceContext(t *testing.T) {
+	traceparent := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	
+	carrier := traceContext(map[string]types.MessageAttributeValue{
+		"traceparent": {DataType: aws.String("String"), StringValue: aws.String(traceparent)},
+		"payload":     {DataType: aws.String("Binary"), BinaryValue: []byte("binary")},
+	})
+	
+	assert.Equal(t, map[string]string{"traceparent": traceparent}, carrier)
+}
+
+// TestMessageAttributes tests that the request ID of the context is sent with a scan task
+func TestMessageAttributes(t *testing.T) {
+	assert.Nil(t, messageAttributes(context.Background()))
+	
+	ctx := logger.ContextWithRequestID(context.Background(), "req-1")
+	assert.Equal(t, map[string]string{"request_id": "req-1"}, messageAttributes(ctx))
+}
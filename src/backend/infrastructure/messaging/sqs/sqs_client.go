@@ -6,14 +6,18 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws" // v2.0.0+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware" // v2.0.0+
 	awsConfig "github.com/aws/aws-sdk-go-v2/config" // v2.0.0+
 	"github.com/aws/aws-sdk-go-v2/credentials" // v2.0.0+
 	"github.com/aws/aws-sdk-go-v2/service/sqs" // v2.0.0+
 	"github.com/aws/aws-sdk-go-v2/service/sqs/types" // v2.0.0+
+	"github.com/aws/smithy-go/middleware" // v1.13.0+
+	"go.opentelemetry.io/otel/trace" // v1.11.0+
 
 	"../../../../pkg/config"
 	"../../../../pkg/errors"
 	"../../../../pkg/logger"
+	"../../../../pkg/tracing"
 )
 
 // Default constants for SQS operations
@@ -68,6 +72,7 @@ func NewSQSClient(ctx context.Context, cfg config.SQSConfig) (*SQSClient, error)
 		if cfg.Region != "" {
 			o.Region = cfg.Region
 		}
+		o.APIOptions = append(o.APIOptions, traceRequests)
 	})
 
 	// Return new SQSClient
@@ -76,6 +81,22 @@ func NewSQSClient(ctx context.Context, cfg config.SQSConfig) (*SQSClient, error)
 	}, nil
 }
 
+// traceRequests adds a middleware recording a client span for each SQS request, retries included
+func traceRequests(stack *middleware.Stack) error {
+	return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("Tracing", func(
+		ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler,
+	) (middleware.InitializeOutput, middleware.Metadata, error) {
+		operation := awsmiddleware.GetOperationName(ctx)
+		ctx, span := tracing.StartSpan(ctx, "SQS "+operation, trace.WithSpanKind(trace.SpanKindClient))
+		tracing.AddAttribute(span, "aws.service", "sqs")
+		tracing.AddAttribute(span, "aws.operation", operation)
+
+		out, metadata, err := next.HandleInitialize(ctx, in)
+		tracing.EndSpan(span, err)
+		return out, metadata, err
+	}), middleware.After)
+}
+
 // GetQueueURL gets the URL for a queue by name
 func GetQueueURL(ctx context.Context, client *SQSClient, queueName string) (string, error) {
 	input := &sqs.GetQueueUrlInput{
@@ -123,8 +144,9 @@ func (c *SQSClient) SendMessage(ctx context.Context, queueURL string, messageBod
 	return *result.MessageId, nil
 }
 
-// ReceiveMessage receives messages from an SQS queue
-func (c *SQSClient) ReceiveMessage(ctx context.Context, queueURL string, maxMessages int32, visibilityTimeout time.Duration) ([]types.Message, error) {
+// ReceiveMessage receives messages from an SQS queue, long polling for up to waitTime when the
+// queue is empty. A waitTime of zero returns immediately.
+func (c *SQSClient) ReceiveMessage(ctx context.Context, queueURL string, maxMessages int32, visibilityTimeout time.Duration, waitTime time.Duration) ([]types.Message, error) {
 	log := logger.WithContext(ctx)
 
 	// Set default values if not provided
@@ -134,12 +156,15 @@ func (c *SQSClient) ReceiveMessage(ctx context.Context, queueURL string, maxMess
 	if visibilityTimeout <= 0 {
 		visibilityTimeout = defaultVisibilityTimeout
 	}
+	if waitTime < 0 || waitTime.Seconds() > defaultWaitTimeSeconds {
+		waitTime = defaultWaitTimeSeconds * time.Second
+	}
 
 	input := &sqs.ReceiveMessageInput{
 		QueueUrl:              aws.String(queueURL),
 		MaxNumberOfMessages:   maxMessages,
 		VisibilityTimeout:     int32(visibilityTimeout.Seconds()),
-		WaitTimeSeconds:       defaultWaitTimeSeconds,
+		WaitTimeSeconds:       int32(waitTime.Seconds()),
 		MessageAttributeNames: []string{"All"},
 		AttributeNames:        []string{"All"},
 	}
@@ -0,0 +1,175 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid" // v1.3.0+ - For generating unique IDs for access policies
+	"gorm.io/gorm"           // v1.25.0+ - ORM library for database operations
+
+	"../../../domain/models"
+	"../../../domain/repositories"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+	"../../../pkg/utils"
+)
+
+// accessPolicyRepository implements the AccessPolicyRepository interface using PostgreSQL
+type accessPolicyRepository struct{}
+
+// NewAccessPolicyRepository creates a new instance of the PostgreSQL implementation of AccessPolicyRepository
+func NewAccessPolicyRepository() repositories.AccessPolicyRepository {
+	return &accessPolicyRepository{}
+}
+
+// Create persists a new access policy to the database
+func (r *accessPolicyRepository) Create(ctx context.Context, policy *models.AccessPolicy) (string, error) {
+	if err := policy.Validate(); err != nil {
+		return "", errors.NewValidationError(err.Error())
+	}
+
+	if policy.ID == "" {
+		policy.ID = uuid.New().String()
+	}
+
+	now := time.Now()
+	if policy.CreatedAt.IsZero() {
+		policy.CreatedAt = now
+	}
+	if policy.UpdatedAt.IsZero() {
+		policy.UpdatedAt = now
+	}
+
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if err := db.Create(policy).Error; err != nil {
+		if strings.Contains(err.Error(), "access_policies_tenant_name_idx") {
+			return "", errors.NewValidationError("a policy named " + policy.Name + " already exists")
+		}
+		logger.Error("Failed to create access policy", "error", err, "policy_id", policy.ID, "tenant_id", policy.TenantID)
+		return "", errors.NewInternalError("Failed to create access policy: " + err.Error())
+	}
+
+	return policy.ID, nil
+}
+
+// GetByID retrieves an access policy by its ID with tenant isolation
+func (r *accessPolicyRepository) GetByID(ctx context.Context, id string, tenantID string) (*models.AccessPolicy, error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var policy models.AccessPolicy
+	if err := db.Where("id = ? AND tenant_id = ?", id, tenantID).First(&policy).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewResourceNotFoundError("Access policy not found")
+		}
+		logger.Error("Failed to get access policy", "error", err, "id", id, "tenant_id", tenantID)
+		return nil, errors.NewInternalError("Failed to get access policy: " + err.Error())
+	}
+
+	return &policy, nil
+}
+
+// Update updates an existing access policy in the database
+func (r *accessPolicyRepository) Update(ctx context.Context, policy *models.AccessPolicy) error {
+	if err := policy.Validate(); err != nil {
+		return errors.NewValidationError(err.Error())
+	}
+
+	policy.UpdatedAt = time.Now()
+
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Select the mutable columns explicitly so that disabling a policy is persisted
+	result := db.Model(&models.AccessPolicy{}).
+		Where("id = ? AND tenant_id = ?", policy.ID, policy.TenantID).
+		Select("name", "description", "attribute", "operator", "match_values", "actions", "required_roles", "enabled", "updated_at").
+		Updates(policy)
+
+	if result.Error != nil {
+		logger.Error("Failed to update access policy", "error", result.Error, "id", policy.ID, "tenant_id", policy.TenantID)
+		return errors.NewInternalError("Failed to update access policy: " + result.Error.Error())
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.NewResourceNotFoundError("Access policy not found")
+	}
+
+	return nil
+}
+
+// Delete deletes an access policy with tenant isolation
+func (r *accessPolicyRepository) Delete(ctx context.Context, id string, tenantID string) error {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	result := db.Where("id = ? AND tenant_id = ?", id, tenantID).Delete(&models.AccessPolicy{})
+
+	if result.Error != nil {
+		logger.Error("Failed to delete access policy", "error", result.Error, "id", id, "tenant_id", tenantID)
+		return errors.NewInternalError("Failed to delete access policy: " + result.Error.Error())
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.NewResourceNotFoundError("Access policy not found")
+	}
+
+	return nil
+}
+
+// List lists a tenant's access policies with pagination
+func (r *accessPolicyRepository) List(ctx context.Context, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.AccessPolicy], error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return utils.PaginatedResult[models.AccessPolicy]{}, err
+	}
+
+	var policies []models.AccessPolicy
+	var totalItems int64
+
+	if err := db.Model(&models.AccessPolicy{}).
+		Where("tenant_id = ?", tenantID).
+		Count(&totalItems).Error; err != nil {
+		logger.Error("Failed to count access policies", "error", err, "tenant_id", tenantID)
+		return utils.PaginatedResult[models.AccessPolicy]{}, errors.NewInternalError("Failed to count access policies: " + err.Error())
+	}
+
+	if err := db.
+		Where("tenant_id = ?", tenantID).
+		Offset(pagination.GetOffset()).
+		Limit(pagination.GetLimit()).
+		Order("name ASC").
+		Find(&policies).Error; err != nil {
+		logger.Error("Failed to list access policies", "error", err, "tenant_id", tenantID)
+		return utils.PaginatedResult[models.AccessPolicy]{}, errors.NewInternalError("Failed to list access policies: " + err.Error())
+	}
+
+	return utils.NewPaginatedResult(policies, pagination, totalItems), nil
+}
+
+// ListEnabled lists all enabled access policies of a tenant for evaluation
+func (r *accessPolicyRepository) ListEnabled(ctx context.Context, tenantID string) ([]*models.AccessPolicy, error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var policies []*models.AccessPolicy
+	if err := db.Where("tenant_id = ? AND enabled = ?", tenantID, true).Find(&policies).Error; err != nil {
+		logger.Error("Failed to list enabled access policies", "error", err, "tenant_id", tenantID)
+		return nil, errors.NewInternalError("Failed to list enabled access policies: " + err.Error())
+	}
+
+	return policies, nil
+}
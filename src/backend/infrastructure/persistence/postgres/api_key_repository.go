@@ -0,0 +1,185 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid" // v1.3.0+ - For generating unique IDs for API keys
+	"gorm.io/gorm"           // v1.25.0+ - ORM library for database operations
+
+	"../../../domain/models"
+	"../../../domain/repositories"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+	"../../../pkg/utils"
+)
+
+// apiKeyRepository implements the APIKeyRepository interface using PostgreSQL
+type apiKeyRepository struct{}
+
+// NewAPIKeyRepository creates a new instance of the PostgreSQL implementation of APIKeyRepository
+func NewAPIKeyRepository() repositories.APIKeyRepository {
+	return &apiKeyRepository{}
+}
+
+// Create persists a new API key to the database
+func (r *apiKeyRepository) Create(ctx context.Context, key *models.APIKey) (string, error) {
+	if key.CreatedAt.IsZero() {
+		key.CreatedAt = time.Now()
+	}
+
+	if err := key.Validate(); err != nil {
+		return "", errors.NewValidationError(err.Error())
+	}
+
+	if key.ID == "" {
+		key.ID = uuid.New().String()
+	}
+
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if err := db.Create(key).Error; err != nil {
+		logger.Error("Failed to create API key", "error", err, "tenant_id", key.TenantID)
+		return "", errors.NewInternalError("Failed to create API key: " + err.Error())
+	}
+
+	return key.ID, nil
+}
+
+// GetByID retrieves an API key by its ID with tenant isolation
+func (r *apiKeyRepository) GetByID(ctx context.Context, id string, tenantID string) (*models.APIKey, error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var key models.APIKey
+	if err := db.Where("id = ? AND tenant_id = ?", id, tenantID).First(&key).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewResourceNotFoundError("API key not found")
+		}
+		logger.Error("Failed to get API key", "error", err, "id", id, "tenant_id", tenantID)
+		return nil, errors.NewInternalError("Failed to get API key: " + err.Error())
+	}
+
+	return &key, nil
+}
+
+// GetByKeyHash retrieves an API key by the hash of its secret
+func (r *apiKeyRepository) GetByKeyHash(ctx context.Context, keyHash string) (*models.APIKey, error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var key models.APIKey
+	if err := db.Where("key_hash = ?", keyHash).First(&key).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewResourceNotFoundError("API key not found")
+		}
+		logger.Error("Failed to get API key by hash", "error", err)
+		return nil, errors.NewInternalError("Failed to get API key: " + err.Error())
+	}
+
+	return &key, nil
+}
+
+// List lists a tenant's API keys with pagination, newest first
+func (r *apiKeyRepository) List(ctx context.Context, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.APIKey], error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return utils.PaginatedResult[models.APIKey]{}, err
+	}
+
+	var keys []models.APIKey
+	var totalItems int64
+
+	if err := db.Model(&models.APIKey{}).
+		Where("tenant_id = ?", tenantID).
+		Count(&totalItems).Error; err != nil {
+		logger.Error("Failed to count API keys", "error", err, "tenant_id", tenantID)
+		return utils.PaginatedResult[models.APIKey]{}, errors.NewInternalError("Failed to count API keys: " + err.Error())
+	}
+
+	if err := db.
+		Where("tenant_id = ?", tenantID).
+		Offset(pagination.GetOffset()).
+		Limit(pagination.GetLimit()).
+		Order("created_at DESC").
+		Find(&keys).Error; err != nil {
+		logger.Error("Failed to list API keys", "error", err, "tenant_id", tenantID)
+		return utils.PaginatedResult[models.APIKey]{}, errors.NewInternalError("Failed to list API keys: " + err.Error())
+	}
+
+	return utils.NewPaginatedResult(keys, pagination, totalItems), nil
+}
+
+// UpdateSecret stores a rotated key's new hash, display prefix and rotation time with tenant isolation
+func (r *apiKeyRepository) UpdateSecret(ctx context.Context, key *models.APIKey) error {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	result := db.Model(&models.APIKey{}).
+		Where("id = ? AND tenant_id = ? AND revoked_at IS NULL", key.ID, key.TenantID).
+		Updates(map[string]interface{}{
+			"key_hash":   key.KeyHash,
+			"key_prefix": key.KeyPrefix,
+			"rotated_at": key.RotatedAt,
+		})
+
+	if result.Error != nil {
+		logger.Error("Failed to rotate API key", "error", result.Error, "id", key.ID, "tenant_id", key.TenantID)
+		return errors.NewInternalError("Failed to rotate API key: " + result.Error.Error())
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.NewResourceNotFoundError("API key not found")
+	}
+
+	return nil
+}
+
+// Revoke marks an API key as revoked with tenant isolation. Revoking a revoked key is a no-op.
+func (r *apiKeyRepository) Revoke(ctx context.Context, id string, tenantID string) error {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	result := db.Model(&models.APIKey{}).
+		Where("id = ? AND tenant_id = ?", id, tenantID).
+		Update("revoked_at", gorm.Expr("COALESCE(revoked_at, ?)", time.Now()))
+
+	if result.Error != nil {
+		logger.Error("Failed to revoke API key", "error", result.Error, "id", id, "tenant_id", tenantID)
+		return errors.NewInternalError("Failed to revoke API key: " + result.Error.Error())
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.NewResourceNotFoundError("API key not found")
+	}
+
+	return nil
+}
+
+// RecordUsage stores when an API key last authenticated a request
+func (r *apiKeyRepository) RecordUsage(ctx context.Context, id string, usedAt time.Time) error {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := db.Model(&models.APIKey{}).
+		Where("id = ?", id).
+		Update("last_used_at", usedAt).Error; err != nil {
+		logger.Error("Failed to record API key usage", "error", err, "id", id)
+		return errors.NewInternalError("Failed to record API key usage: " + err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,323 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid" // v1.3.0+ - For generating unique IDs for approval workflows and requests
+	"gorm.io/gorm"           // v1.25.0+ - ORM library for database operations
+
+	"../../../domain/models"
+	"../../../domain/repositories"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+	"../../../pkg/utils"
+)
+
+// approvalWorkflowRepository implements the ApprovalWorkflowRepository interface using PostgreSQL
+type approvalWorkflowRepository struct{}
+
+// NewApprovalWorkflowRepository creates a new instance of the PostgreSQL implementation of ApprovalWorkflowRepository
+func NewApprovalWorkflowRepository() repositories.ApprovalWorkflowRepository {
+	return &approvalWorkflowRepository{}
+}
+
+// Create persists a new approval workflow to the database
+func (r *approvalWorkflowRepository) Create(ctx context.Context, workflow *models.ApprovalWorkflow) (string, error) {
+	if err := workflow.Validate(); err != nil {
+		return "", errors.NewValidationError(err.Error())
+	}
+
+	if workflow.ID == "" {
+		workflow.ID = uuid.New().String()
+	}
+
+	now := time.Now()
+	if workflow.CreatedAt.IsZero() {
+		workflow.CreatedAt = now
+	}
+	if workflow.UpdatedAt.IsZero() {
+		workflow.UpdatedAt = now
+	}
+
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if err := db.Create(workflow).Error; err != nil {
+		if strings.Contains(err.Error(), "approval_workflows_tenant_folder_idx") {
+			return "", errors.NewValidationError("the folder already has an approval workflow")
+		}
+		logger.Error("Failed to create approval workflow", "error", err, "workflow_id", workflow.ID, "tenant_id", workflow.TenantID)
+		return "", errors.NewInternalError("Failed to create approval workflow: " + err.Error())
+	}
+
+	return workflow.ID, nil
+}
+
+// GetByID retrieves an approval workflow by its ID with tenant isolation
+func (r *approvalWorkflowRepository) GetByID(ctx context.Context, id string, tenantID string) (*models.ApprovalWorkflow, error) {
+	return r.getWhere(ctx, "id = ? AND tenant_id = ?", id, tenantID)
+}
+
+// GetByFolder retrieves the approval workflow of a folder with tenant isolation
+func (r *approvalWorkflowRepository) GetByFolder(ctx context.Context, folderID string, tenantID string) (*models.ApprovalWorkflow, error) {
+	return r.getWhere(ctx, "folder_id = ? AND tenant_id = ?", folderID, tenantID)
+}
+
+// getWhere retrieves the approval workflow matching a condition on an ID and the tenant ID
+func (r *approvalWorkflowRepository) getWhere(ctx context.Context, condition string, id string, tenantID string) (*models.ApprovalWorkflow, error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var workflow models.ApprovalWorkflow
+	if err := db.Where(condition, id, tenantID).First(&workflow).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewResourceNotFoundError("Approval workflow not found")
+		}
+		logger.Error("Failed to get approval workflow", "error", err, "id", id, "tenant_id", tenantID)
+		return nil, errors.NewInternalError("Failed to get approval workflow: " + err.Error())
+	}
+
+	return &workflow, nil
+}
+
+// Update updates an existing approval workflow in the database
+func (r *approvalWorkflowRepository) Update(ctx context.Context, workflow *models.ApprovalWorkflow) error {
+	if err := workflow.Validate(); err != nil {
+		return errors.NewValidationError(err.Error())
+	}
+
+	workflow.UpdatedAt = time.Now()
+
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Select the mutable columns explicitly; the folder of a workflow cannot be changed
+	result := db.Model(&models.ApprovalWorkflow{}).
+		Where("id = ? AND tenant_id = ?", workflow.ID, workflow.TenantID).
+		Select("name", "mode", "approvers", "updated_at").
+		Updates(workflow)
+
+	if result.Error != nil {
+		logger.Error("Failed to update approval workflow", "error", result.Error, "id", workflow.ID, "tenant_id", workflow.TenantID)
+		return errors.NewInternalError("Failed to update approval workflow: " + result.Error.Error())
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.NewResourceNotFoundError("Approval workflow not found")
+	}
+
+	return nil
+}
+
+// Delete deletes an approval workflow with tenant isolation
+func (r *approvalWorkflowRepository) Delete(ctx context.Context, id string, tenantID string) error {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	result := db.Where("id = ? AND tenant_id = ?", id, tenantID).Delete(&models.ApprovalWorkflow{})
+
+	if result.Error != nil {
+		logger.Error("Failed to delete approval workflow", "error", result.Error, "id", id, "tenant_id", tenantID)
+		return errors.NewInternalError("Failed to delete approval workflow: " + result.Error.Error())
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.NewResourceNotFoundError("Approval workflow not found")
+	}
+
+	return nil
+}
+
+// ListByTenant lists all approval workflows of a tenant ordered by name
+func (r *approvalWorkflowRepository) ListByTenant(ctx context.Context, tenantID string) ([]*models.ApprovalWorkflow, error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var workflows []*models.ApprovalWorkflow
+	if err := db.Where("tenant_id = ?", tenantID).Order("name ASC").Find(&workflows).Error; err != nil {
+		logger.Error("Failed to list approval workflows", "error", err, "tenant_id", tenantID)
+		return nil, errors.NewInternalError("Failed to list approval workflows: " + err.Error())
+	}
+
+	return workflows, nil
+}
+
+// approvalRequestRepository implements the ApprovalRequestRepository interface using PostgreSQL
+type approvalRequestRepository struct{}
+
+// NewApprovalRequestRepository creates a new instance of the PostgreSQL implementation of ApprovalRequestRepository
+func NewApprovalRequestRepository() repositories.ApprovalRequestRepository {
+	return &approvalRequestRepository{}
+}
+
+// Create persists a new approval request and its decisions, joining the transaction carried by ctx if any
+func (r *approvalRequestRepository) Create(ctx context.Context, request *models.ApprovalRequest) (string, error) {
+	if err := request.Validate(); err != nil {
+		return "", errors.NewValidationError(err.Error())
+	}
+
+	if request.ID == "" {
+		request.ID = uuid.New().String()
+	}
+	for i := range request.Decisions {
+		request.Decisions[i].RequestID = request.ID
+		if request.Decisions[i].ID == "" {
+			request.Decisions[i].ID = uuid.New().String()
+		}
+	}
+
+	now := time.Now()
+	if request.CreatedAt.IsZero() {
+		request.CreatedAt = now
+	}
+	if request.UpdatedAt.IsZero() {
+		request.UpdatedAt = now
+	}
+
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	// The decisions are created with the request through its association
+	if err := db.Create(request).Error; err != nil {
+		if strings.Contains(err.Error(), "approval_requests_pending_document_idx") {
+			return "", errors.NewValidationError("the document is already awaiting approval")
+		}
+		logger.Error("Failed to create approval request", "error", err, "document_id", request.DocumentID, "tenant_id", request.TenantID)
+		return "", errors.NewInternalError("Failed to create approval request: " + err.Error())
+	}
+
+	return request.ID, nil
+}
+
+// GetByID retrieves an approval request with its decisions by its ID with tenant isolation
+func (r *approvalRequestRepository) GetByID(ctx context.Context, id string, tenantID string) (*models.ApprovalRequest, error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var request models.ApprovalRequest
+	if err := db.Preload("Decisions", orderDecisions).
+		Where("id = ? AND tenant_id = ?", id, tenantID).
+		First(&request).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewResourceNotFoundError("Approval request not found")
+		}
+		logger.Error("Failed to get approval request", "error", err, "id", id, "tenant_id", tenantID)
+		return nil, errors.NewInternalError("Failed to get approval request: " + err.Error())
+	}
+
+	return &request, nil
+}
+
+// Update persists the status of an approval request and the decisions of its approvers
+func (r *approvalRequestRepository) Update(ctx context.Context, request *models.ApprovalRequest) error {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.ApprovalRequest{}).
+			Where("id = ? AND tenant_id = ?", request.ID, request.TenantID).
+			Updates(map[string]interface{}{
+				"status":       request.Status,
+				"updated_at":   request.UpdatedAt,
+				"completed_at": request.CompletedAt,
+			})
+		if result.Error != nil {
+			logger.Error("Failed to update approval request", "error", result.Error, "id", request.ID, "tenant_id", request.TenantID)
+			return errors.NewInternalError("Failed to update approval request: " + result.Error.Error())
+		}
+		if result.RowsAffected == 0 {
+			return errors.NewResourceNotFoundError("Approval request not found")
+		}
+
+		for _, decision := range request.Decisions {
+			if err := tx.Model(&models.ApprovalDecision{}).
+				Where("id = ? AND request_id = ?", decision.ID, request.ID).
+				Updates(map[string]interface{}{
+					"decision":   decision.Decision,
+					"comment":    decision.Comment,
+					"decided_at": decision.DecidedAt,
+				}).Error; err != nil {
+				logger.Error("Failed to update approval decision", "error", err, "id", decision.ID, "request_id", request.ID)
+				return errors.NewInternalError("Failed to update approval decision: " + err.Error())
+			}
+		}
+		return nil
+	})
+}
+
+// ListByDocument lists the approval requests of a document with their decisions, newest first
+func (r *approvalRequestRepository) ListByDocument(ctx context.Context, documentID string, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.ApprovalRequest], error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return utils.PaginatedResult[models.ApprovalRequest]{}, err
+	}
+
+	query := db.Model(&models.ApprovalRequest{}).Where("document_id = ? AND tenant_id = ?", documentID, tenantID)
+	return r.list(query, "created_at DESC, id DESC", pagination)
+}
+
+// ListAwaitingApprover lists the pending approval requests waiting for a decision of the approver, oldest first
+func (r *approvalRequestRepository) ListAwaitingApprover(ctx context.Context, approverID string, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.ApprovalRequest], error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return utils.PaginatedResult[models.ApprovalRequest]{}, err
+	}
+
+	// The approver has not decided yet and, in a sequential chain, no earlier approver is pending
+	query := db.Model(&models.ApprovalRequest{}).
+		Select("approval_requests.*").
+		Joins("JOIN approval_decisions d ON d.request_id = approval_requests.id").
+		Where("approval_requests.tenant_id = ? AND approval_requests.status = ?", tenantID, models.ApprovalStatusPending).
+		Where("d.approver_id = ? AND d.decision = ?", approverID, models.ApprovalDecisionPending).
+		Where(`approval_requests.mode = ? OR NOT EXISTS (
+			SELECT 1 FROM approval_decisions e
+			WHERE e.request_id = approval_requests.id AND e.decision = ? AND e.position < d.position
+		)`, models.ApprovalModeParallel, models.ApprovalDecisionPending)
+	return r.list(query, "approval_requests.created_at ASC, approval_requests.id ASC", pagination)
+}
+
+// list counts and pages the approval requests matched by query, loading their decisions
+func (r *approvalRequestRepository) list(query *gorm.DB, order string, pagination *utils.Pagination) (utils.PaginatedResult[models.ApprovalRequest], error) {
+	var requests []models.ApprovalRequest
+	var totalItems int64
+
+	if err := query.Count(&totalItems).Error; err != nil {
+		logger.Error("Failed to count approval requests", "error", err)
+		return utils.PaginatedResult[models.ApprovalRequest]{}, errors.NewInternalError("Failed to count approval requests: " + err.Error())
+	}
+
+	if err := query.
+		Preload("Decisions", orderDecisions).
+		Offset(pagination.GetOffset()).
+		Limit(pagination.GetLimit()).
+		Order(order).
+		Find(&requests).Error; err != nil {
+		logger.Error("Failed to list approval requests", "error", err)
+		return utils.PaginatedResult[models.ApprovalRequest]{}, errors.NewInternalError("Failed to list approval requests: " + err.Error())
+	}
+
+	return utils.NewPaginatedResult(requests, pagination, totalItems), nil
+}
+
+// orderDecisions loads the decisions of approval requests in the order of their chain
+func orderDecisions(db *gorm.DB) *gorm.DB {
+	return db.Order("position ASC")
+}
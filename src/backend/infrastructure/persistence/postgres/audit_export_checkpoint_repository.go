@@ -0,0 +1,67 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"        // v1.25.0+ - ORM library for database operations
+	"gorm.io/gorm/clause" // v1.25.0+ - For upserting checkpoints
+
+	"../../../domain/models"
+	"../../../domain/repositories"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+)
+
+// auditExportCheckpointRepository implements the AuditExportCheckpointRepository interface using PostgreSQL
+type auditExportCheckpointRepository struct{}
+
+// NewAuditExportCheckpointRepository creates a new instance of the PostgreSQL implementation of AuditExportCheckpointRepository
+func NewAuditExportCheckpointRepository() repositories.AuditExportCheckpointRepository {
+	return &auditExportCheckpointRepository{}
+}
+
+// Get retrieves the checkpoint of an exporter, returning an empty checkpoint if none has been saved
+func (r *auditExportCheckpointRepository) Get(ctx context.Context, exporter string) (*models.AuditExportCheckpoint, error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var checkpoint models.AuditExportCheckpoint
+	if err := db.Where("exporter = ?", exporter).First(&checkpoint).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return &models.AuditExportCheckpoint{Exporter: exporter}, nil
+		}
+		logger.Error("Failed to get audit export checkpoint", "error", err, "exporter", exporter)
+		return nil, errors.NewInternalError("Failed to get audit export checkpoint: " + err.Error())
+	}
+
+	return &checkpoint, nil
+}
+
+// Save creates or updates the checkpoint of an exporter
+func (r *auditExportCheckpointRepository) Save(ctx context.Context, checkpoint *models.AuditExportCheckpoint) error {
+	if checkpoint.Exporter == "" {
+		return errors.NewValidationError("audit exporter name cannot be empty")
+	}
+
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if checkpoint.UpdatedAt.IsZero() {
+		checkpoint.UpdatedAt = time.Now().UTC()
+	}
+
+	if err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "exporter"}},
+		DoUpdates: clause.AssignmentColumns([]string{"last_occurred_at", "last_id", "updated_at"}),
+	}).Create(checkpoint).Error; err != nil {
+		logger.Error("Failed to save audit export checkpoint", "error", err, "exporter", checkpoint.Exporter)
+		return errors.NewInternalError("Failed to save audit export checkpoint: " + err.Error())
+	}
+
+	return nil
+}
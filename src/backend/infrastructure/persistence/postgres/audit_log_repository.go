@@ -0,0 +1,234 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid" // v1.3.0+ - For generating unique IDs for audit log entries
+	"gorm.io/gorm"           // v1.25.0+ - ORM library for database operations
+
+	"../../../domain/models"
+	"../../../domain/repositories"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+	"../../../pkg/utils"
+)
+
+// auditLogRepository implements the AuditLogRepository interface using PostgreSQL.
+// The audit_logs table is range partitioned by month on occurred_at.
+type auditLogRepository struct{}
+
+// NewAuditLogRepository creates a new instance of the PostgreSQL implementation of AuditLogRepository
+func NewAuditLogRepository() repositories.AuditLogRepository {
+	return &auditLogRepository{}
+}
+
+// Create persists a new audit log entry, joining the transaction carried by ctx if any
+func (r *auditLogRepository) Create(ctx context.Context, entry *models.AuditLog) (string, error) {
+	if err := entry.Validate(); err != nil {
+		return "", errors.NewValidationError(err.Error())
+	}
+
+	if entry.ID == "" {
+		entry.ID = uuid.New().String()
+	}
+	if entry.OccurredAt.IsZero() {
+		entry.OccurredAt = time.Now().UTC()
+	}
+
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if err := db.Create(entry).Error; err != nil {
+		logger.Error("Failed to create audit log", "error", err, "tenant_id", entry.TenantID, "action", entry.Action, "resource_id", entry.ResourceID)
+		return "", errors.NewInternalError("Failed to create audit log: " + err.Error())
+	}
+
+	return entry.ID, nil
+}
+
+// GetByID retrieves an audit log entry by its ID with tenant isolation
+func (r *auditLogRepository) GetByID(ctx context.Context, id string, tenantID string) (*models.AuditLog, error) {
+	db, err := GetReadDBFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var entry models.AuditLog
+	if err := db.Where("id = ? AND tenant_id = ?", id, tenantID).First(&entry).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewResourceNotFoundError("Audit log not found")
+		}
+		logger.Error("Failed to get audit log", "error", err, "id", id, "tenant_id", tenantID)
+		return nil, errors.NewInternalError("Failed to get audit log: " + err.Error())
+	}
+
+	return &entry, nil
+}
+
+// List lists a tenant's audit log entries matching the filter, newest first, with pagination
+func (r *auditLogRepository) List(ctx context.Context, tenantID string, filter models.AuditLogFilter, pagination *utils.Pagination) (utils.PaginatedResult[models.AuditLog], error) {
+	db, err := GetReadDBFromContext(ctx)
+	if err != nil {
+		return utils.PaginatedResult[models.AuditLog]{}, err
+	}
+
+	var entries []models.AuditLog
+	var totalItems int64
+
+	baseQuery := db.Model(&models.AuditLog{}).Where("tenant_id = ?", tenantID)
+
+	// Apply optional filters; time bounds allow the planner to prune partitions
+	if filter.ActorID != "" {
+		baseQuery = baseQuery.Where("actor_id = ?", filter.ActorID)
+	}
+	if filter.Action != "" {
+		baseQuery = baseQuery.Where("action = ?", filter.Action)
+	}
+	if filter.ResourceType != "" {
+		baseQuery = baseQuery.Where("resource_type = ?", filter.ResourceType)
+	}
+	if filter.ResourceID != "" {
+		baseQuery = baseQuery.Where("resource_id = ?", filter.ResourceID)
+	}
+	if !filter.From.IsZero() {
+		baseQuery = baseQuery.Where("occurred_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		baseQuery = baseQuery.Where("occurred_at <= ?", filter.To)
+	}
+
+	// Count total items for pagination
+	if err := baseQuery.Count(&totalItems).Error; err != nil {
+		logger.Error("Failed to count audit logs", "error", err, "tenant_id", tenantID)
+		return utils.PaginatedResult[models.AuditLog]{},
+			errors.NewInternalError("Failed to count audit logs: " + err.Error())
+	}
+
+	// Get paginated results
+	if err := baseQuery.
+		Offset(pagination.GetOffset()).
+		Limit(pagination.GetLimit()).
+		Order("occurred_at DESC, id DESC").
+		Find(&entries).Error; err != nil {
+		logger.Error("Failed to list audit logs", "error", err, "tenant_id", tenantID)
+		return utils.PaginatedResult[models.AuditLog]{},
+			errors.NewInternalError("Failed to list audit logs: " + err.Error())
+	}
+
+	return utils.NewPaginatedResult(entries, pagination, totalItems), nil
+}
+
+// ListAfter lists entries of all tenants positioned after the checkpoint in (occurred_at, id) order
+// that occurred before until
+func (r *auditLogRepository) ListAfter(ctx context.Context, checkpoint *models.AuditExportCheckpoint, until time.Time, limit int) ([]*models.AuditLog, error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := db.Model(&models.AuditLog{}).Where("occurred_at < ?", until)
+	if checkpoint != nil && !checkpoint.IsEmpty() {
+		query = query.Where("(occurred_at, id) > (?, ?)", checkpoint.LastOccurredAt, checkpoint.LastID)
+	}
+
+	var entries []*models.AuditLog
+	if err := query.Order("occurred_at ASC, id ASC").Limit(limit).Find(&entries).Error; err != nil {
+		logger.Error("Failed to list audit logs for export", "error", err)
+		return nil, errors.NewInternalError("Failed to list audit logs for export: " + err.Error())
+	}
+
+	return entries, nil
+}
+
+// ListRecentResources lists the distinct resources of a type an actor performed any of actions on
+// since the given time, most recently accessed first. The time bound lets the planner prune partitions.
+func (r *auditLogRepository) ListRecentResources(ctx context.Context, tenantID, actorID, resourceType string, actions []string, since time.Time, limit int) ([]models.RecentResource, error) {
+	db, err := GetReadDBFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep the latest entry of each resource, then order the resources by that entry
+	latest := db.Model(&models.AuditLog{}).
+		Select("DISTINCT ON (resource_id) resource_id, action, occurred_at AS accessed_at").
+		Where("tenant_id = ? AND actor_id = ? AND resource_type = ? AND action IN ? AND occurred_at >= ?",
+			tenantID, actorID, resourceType, actions, since).
+		Order("resource_id, occurred_at DESC")
+
+	var resources []models.RecentResource
+	if err := db.Table("(?) AS latest", latest).
+		Order("accessed_at DESC").
+		Limit(limit).
+		Scan(&resources).Error; err != nil {
+		logger.Error("Failed to list recent resources", "error", err, "tenant_id", tenantID, "actor_id", actorID)
+		return nil, errors.NewInternalError("Failed to list recent resources: " + err.Error())
+	}
+
+	return resources, nil
+}
+
+// ListActivity lists the entries forming the activity timeline of a document or folder, newest first.
+// Permission and share link entries are matched on the resource their change summary names.
+func (r *auditLogRepository) ListActivity(ctx context.Context, tenantID, resourceType, resourceID string, pagination *utils.Pagination) (utils.PaginatedResult[models.AuditLog], error) {
+	db, err := GetReadDBFromContext(ctx)
+	if err != nil {
+		return utils.PaginatedResult[models.AuditLog]{}, err
+	}
+
+	related := db.Where("resource_type = ? AND resource_id = ?", resourceType, resourceID).
+		Or("resource_type = ? AND COALESCE(after, before)->>'resourceID' = ?", models.AuditResourcePermission, resourceID).
+		Or("resource_type = ? AND after->>'document_id' = ?", models.AuditResourceShareLink, resourceID)
+	if resourceType == models.ResourceTypeFolder {
+		// Documents moved out of the folder keep their upload in its timeline
+		documents := db.Model(&models.Document{}).Select("id").Where("tenant_id = ? AND folder_id = ?", tenantID, resourceID)
+		related = related.
+			Or("resource_type = ? AND resource_id IN (?)", models.ResourceTypeDocument, documents).
+			Or("resource_type = ? AND action = ? AND after->>'folderID' = ?", models.ResourceTypeDocument, models.AuditActionUpload, resourceID)
+	}
+
+	baseQuery := db.Model(&models.AuditLog{}).
+		Where("tenant_id = ? AND action <> ?", tenantID, models.AuditActionDownload).
+		Where(related)
+
+	var entries []models.AuditLog
+	var totalItems int64
+
+	// Count total items for pagination
+	if err := baseQuery.Count(&totalItems).Error; err != nil {
+		logger.Error("Failed to count activity", "error", err, "tenant_id", tenantID, "resource_id", resourceID)
+		return utils.PaginatedResult[models.AuditLog]{},
+			errors.NewInternalError("Failed to count activity: " + err.Error())
+	}
+
+	// Get paginated results
+	if err := baseQuery.
+		Offset(pagination.GetOffset()).
+		Limit(pagination.GetLimit()).
+		Order("occurred_at DESC, id DESC").
+		Find(&entries).Error; err != nil {
+		logger.Error("Failed to list activity", "error", err, "tenant_id", tenantID, "resource_id", resourceID)
+		return utils.PaginatedResult[models.AuditLog]{},
+			errors.NewInternalError("Failed to list activity: " + err.Error())
+	}
+
+	return utils.NewPaginatedResult(entries, pagination, totalItems), nil
+}
+
+// EnsurePartition creates the monthly partition covering the given time if it does not exist
+func (r *auditLogRepository) EnsurePartition(ctx context.Context, month time.Time) error {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	monthStart := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if err := db.Exec("SELECT create_audit_log_partition(?)", monthStart.Format("2006-01-02")).Error; err != nil {
+		logger.Error("Failed to create audit log partition", "error", err, "month", monthStart.Format("2006-01"))
+		return errors.NewInternalError("Failed to create audit log partition: " + err.Error())
+	}
+
+	return nil
+}
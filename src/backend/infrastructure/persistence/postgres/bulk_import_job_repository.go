@@ -0,0 +1,208 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid" // v1.3.0+ - For generating unique IDs for bulk import jobs
+	"gorm.io/gorm"           // v1.25.0+ - For claiming jobs in a transaction
+	"gorm.io/gorm/clause"    // v1.25.0+ - For row locking when claiming jobs and replacing item outcomes
+
+	"../../../domain/models"
+	"../../../domain/repositories"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+	"../../../pkg/utils"
+)
+
+// bulkImportJobRepository implements the BulkImportJobRepository interface using PostgreSQL
+type bulkImportJobRepository struct{}
+
+// NewBulkImportJobRepository creates a new instance of the PostgreSQL implementation of BulkImportJobRepository
+func NewBulkImportJobRepository() repositories.BulkImportJobRepository {
+	return &bulkImportJobRepository{}
+}
+
+// Create persists a new bulk import job
+func (r *bulkImportJobRepository) Create(ctx context.Context, job *models.BulkImportJob) (string, error) {
+	if err := job.Validate(); err != nil {
+		return "", errors.NewValidationError(err.Error())
+	}
+
+	if job.ID == "" {
+		job.ID = uuid.New().String()
+	}
+
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if err := db.Create(job).Error; err != nil {
+		logger.Error("Failed to create bulk import job", "error", err, "tenant_id", job.TenantID)
+		return "", errors.NewInternalError("Failed to create bulk import job: " + err.Error())
+	}
+
+	return job.ID, nil
+}
+
+// GetByID retrieves a bulk import job by its ID
+func (r *bulkImportJobRepository) GetByID(ctx context.Context, id string) (*models.BulkImportJob, error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var job models.BulkImportJob
+	if err := db.Where("id = ?", id).First(&job).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewResourceNotFoundError("Bulk import job not found")
+		}
+		logger.Error("Failed to get bulk import job", "error", err, "id", id)
+		return nil, errors.NewInternalError("Failed to get bulk import job: " + err.Error())
+	}
+
+	return &job, nil
+}
+
+// List lists bulk import jobs with pagination, most recent first
+func (r *bulkImportJobRepository) List(ctx context.Context, pagination *utils.Pagination) (utils.PaginatedResult[models.BulkImportJob], error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return utils.PaginatedResult[models.BulkImportJob]{}, err
+	}
+
+	var jobs []models.BulkImportJob
+	var totalItems int64
+
+	if err := db.Model(&models.BulkImportJob{}).Count(&totalItems).Error; err != nil {
+		logger.Error("Failed to count bulk import jobs", "error", err)
+		return utils.PaginatedResult[models.BulkImportJob]{}, errors.NewInternalError("Failed to count bulk import jobs: " + err.Error())
+	}
+
+	if err := db.
+		Offset(pagination.GetOffset()).
+		Limit(pagination.GetLimit()).
+		Order("created_at DESC").
+		Find(&jobs).Error; err != nil {
+		logger.Error("Failed to list bulk import jobs", "error", err)
+		return utils.PaginatedResult[models.BulkImportJob]{}, errors.NewInternalError("Failed to list bulk import jobs: " + err.Error())
+	}
+
+	return utils.NewPaginatedResult(jobs, pagination, totalItems), nil
+}
+
+// ClaimNext locks the oldest claimable bulk import job, skipping jobs locked by other workers, and marks it as running
+func (r *bulkImportJobRepository) ClaimNext(ctx context.Context, staleBefore time.Time) (*models.BulkImportJob, error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var claimed *models.BulkImportJob
+	err = db.Transaction(func(tx *gorm.DB) error {
+		var jobs []*models.BulkImportJob
+		if err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? OR (status = ? AND updated_at < ?)", models.BulkImportJobStatusPending, models.BulkImportJobStatusRunning, staleBefore).
+			Order("created_at ASC").
+			Limit(1).
+			Find(&jobs).Error; err != nil {
+			return err
+		}
+		if len(jobs) == 0 {
+			return nil
+		}
+
+		job := jobs[0]
+		job.Resume(time.Now())
+		if err := tx.Save(job).Error; err != nil {
+			return err
+		}
+		claimed = job
+		return nil
+	})
+	if err != nil {
+		logger.Error("Failed to claim bulk import job", "error", err)
+		return nil, errors.NewInternalError("Failed to claim bulk import job: " + err.Error())
+	}
+
+	return claimed, nil
+}
+
+// Update persists the status, checkpoint and counts of a bulk import job
+func (r *bulkImportJobRepository) Update(ctx context.Context, job *models.BulkImportJob) error {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	result := db.Model(&models.BulkImportJob{}).
+		Where("id = ?", job.ID).
+		Updates(map[string]interface{}{
+			"status":          job.Status,
+			"checkpoint":      job.Checkpoint,
+			"found_files":     job.FoundFiles,
+			"imported_files":  job.ImportedFiles,
+			"imported_bytes":  job.ImportedBytes,
+			"skipped_files":   job.SkippedFiles,
+			"failed_files":    job.FailedFiles,
+			"missing_files":   job.MissingFiles,
+			"created_folders": job.CreatedFolders,
+			"error":           job.Error,
+			"updated_at":      job.UpdatedAt,
+			"started_at":      job.StartedAt,
+			"completed_at":    job.CompletedAt,
+		})
+	if result.Error != nil {
+		logger.Error("Failed to update bulk import job", "error", result.Error, "id", job.ID)
+		return errors.NewInternalError("Failed to update bulk import job: " + result.Error.Error())
+	}
+	if result.RowsAffected == 0 {
+		return errors.NewResourceNotFoundError("Bulk import job not found")
+	}
+
+	return nil
+}
+
+// SaveItem persists the outcome of a file, replacing the outcome recorded for it before
+func (r *bulkImportJobRepository) SaveItem(ctx context.Context, item *models.BulkImportItem) error {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if item.CreatedAt.IsZero() {
+		item.CreatedAt = time.Now()
+	}
+
+	if err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "job_id"}, {Name: "source_key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"status", "document_id", "size", "reason", "created_at"}),
+	}).Create(item).Error; err != nil {
+		logger.Error("Failed to save bulk import item", "error", err, "job_id", item.JobID, "source_key", item.SourceKey)
+		return errors.NewInternalError("Failed to save bulk import item: " + err.Error())
+	}
+
+	return nil
+}
+
+// ListItems retrieves up to limit outcomes of the files of a job after a key, in the order of their keys
+func (r *bulkImportJobRepository) ListItems(ctx context.Context, jobID string, afterKey string, limit int) ([]*models.BulkImportItem, error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []*models.BulkImportItem
+	if err := db.
+		Where("job_id = ? AND source_key > ?", jobID, afterKey).
+		Order("source_key ASC").
+		Limit(limit).
+		Find(&items).Error; err != nil {
+		logger.Error("Failed to list bulk import items", "error", err, "job_id", jobID)
+		return nil, errors.NewInternalError("Failed to list bulk import items: " + err.Error())
+	}
+
+	return items, nil
+}
@@ -0,0 +1,167 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid" // v1.3.0+ - For generating unique IDs for comments
+	"gorm.io/gorm"           // v1.25.0+ - ORM library for database operations
+
+	"../../../domain/models"
+	"../../../domain/repositories"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+	"../../../pkg/utils"
+)
+
+// commentRepository implements the CommentRepository interface using PostgreSQL
+type commentRepository struct{}
+
+// NewCommentRepository creates a new instance of the PostgreSQL implementation of CommentRepository
+func NewCommentRepository() repositories.CommentRepository {
+	return &commentRepository{}
+}
+
+// Create persists a new comment to the database, joining the transaction carried by ctx if any
+func (r *commentRepository) Create(ctx context.Context, comment *models.Comment) (string, error) {
+	if err := comment.Validate(); err != nil {
+		return "", errors.NewValidationError(err.Error())
+	}
+
+	if comment.ID == "" {
+		comment.ID = uuid.New().String()
+	}
+
+	now := time.Now()
+	if comment.CreatedAt.IsZero() {
+		comment.CreatedAt = now
+	}
+	if comment.UpdatedAt.IsZero() {
+		comment.UpdatedAt = now
+	}
+
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	// Threads have no parent and new comments no resolver; store them as NULL
+	omit := []string{"resolved_by"}
+	if !comment.IsReply() {
+		omit = append(omit, "parent_id")
+	}
+
+	if err := db.Omit(omit...).Create(comment).Error; err != nil {
+		logger.Error("Failed to create comment", "error", err, "document_id", comment.DocumentID, "tenant_id", comment.TenantID)
+		return "", errors.NewInternalError("Failed to create comment: " + err.Error())
+	}
+
+	return comment.ID, nil
+}
+
+// GetByID retrieves a comment by its ID with tenant isolation
+func (r *commentRepository) GetByID(ctx context.Context, id string, tenantID string) (*models.Comment, error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var comment models.Comment
+	if err := db.Where("id = ? AND tenant_id = ?", id, tenantID).First(&comment).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewResourceNotFoundError("Comment not found")
+		}
+		logger.Error("Failed to get comment", "error", err, "id", id, "tenant_id", tenantID)
+		return nil, errors.NewInternalError("Failed to get comment: " + err.Error())
+	}
+
+	return &comment, nil
+}
+
+// Update persists the body and resolution of an existing comment
+func (r *commentRepository) Update(ctx context.Context, comment *models.Comment) error {
+	if err := comment.Validate(); err != nil {
+		return errors.NewValidationError(err.Error())
+	}
+
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Update through a map so that reopening a thread clears its resolver
+	changes := map[string]interface{}{
+		"body":        comment.Body,
+		"resolved_by": nil,
+		"resolved_at": comment.ResolvedAt,
+		"updated_at":  comment.UpdatedAt,
+	}
+	if comment.ResolvedBy != "" {
+		changes["resolved_by"] = comment.ResolvedBy
+	}
+
+	result := db.Model(&models.Comment{}).
+		Where("id = ? AND tenant_id = ?", comment.ID, comment.TenantID).
+		Updates(changes)
+
+	if result.Error != nil {
+		logger.Error("Failed to update comment", "error", result.Error, "id", comment.ID, "tenant_id", comment.TenantID)
+		return errors.NewInternalError("Failed to update comment: " + result.Error.Error())
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.NewResourceNotFoundError("Comment not found")
+	}
+
+	return nil
+}
+
+// Delete deletes a comment with tenant isolation; replies are deleted by the parent_id foreign key
+func (r *commentRepository) Delete(ctx context.Context, id string, tenantID string) error {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	result := db.Where("id = ? AND tenant_id = ?", id, tenantID).Delete(&models.Comment{})
+
+	if result.Error != nil {
+		logger.Error("Failed to delete comment", "error", result.Error, "id", id, "tenant_id", tenantID)
+		return errors.NewInternalError("Failed to delete comment: " + result.Error.Error())
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.NewResourceNotFoundError("Comment not found")
+	}
+
+	return nil
+}
+
+// ListByDocument lists the comments of a document oldest first with pagination
+func (r *commentRepository) ListByDocument(ctx context.Context, documentID string, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Comment], error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return utils.PaginatedResult[models.Comment]{}, err
+	}
+
+	var comments []models.Comment
+	var totalItems int64
+
+	baseQuery := db.Model(&models.Comment{}).Where("document_id = ? AND tenant_id = ?", documentID, tenantID)
+
+	if err := baseQuery.Count(&totalItems).Error; err != nil {
+		logger.Error("Failed to count comments", "error", err, "document_id", documentID, "tenant_id", tenantID)
+		return utils.PaginatedResult[models.Comment]{}, errors.NewInternalError("Failed to count comments: " + err.Error())
+	}
+
+	if err := baseQuery.
+		Offset(pagination.GetOffset()).
+		Limit(pagination.GetLimit()).
+		Order("created_at ASC, id ASC").
+		Find(&comments).Error; err != nil {
+		logger.Error("Failed to list comments", "error", err, "document_id", documentID, "tenant_id", tenantID)
+		return utils.PaginatedResult[models.Comment]{}, errors.NewInternalError("Failed to list comments: " + err.Error())
+	}
+
+	return utils.NewPaginatedResult(comments, pagination, totalItems), nil
+}
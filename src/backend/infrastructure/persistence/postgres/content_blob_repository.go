@@ -0,0 +1,96 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"        // v1.25.0+ - ORM library for database operations
+	"gorm.io/gorm/clause" // v1.25.0+ - For counting references to existing blobs and locking blob rows
+
+	"../../../domain/models"
+	"../../../domain/repositories"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+)
+
+// contentBlobRepository implements the ContentBlobRepository interface using PostgreSQL
+type contentBlobRepository struct{}
+
+// NewContentBlobRepository creates a new instance of the PostgreSQL implementation of ContentBlobRepository
+func NewContentBlobRepository() repositories.ContentBlobRepository {
+	return &contentBlobRepository{}
+}
+
+// Acquire adds a reference to a blob, creating it if needed. The insert or update locks the blob
+// row until the surrounding transaction ends, so concurrent uploads of the same content wait for
+// the first one to store it.
+func (r *contentBlobRepository) Acquire(ctx context.Context, tenantID, contentHash string, size int64) (*models.ContentBlob, error) {
+	if tenantID == "" {
+		return nil, errors.NewValidationError("tenant ID cannot be empty")
+	}
+	if contentHash == "" {
+		return nil, errors.NewValidationError("content hash cannot be empty")
+	}
+
+	now := time.Now()
+	blob := models.ContentBlob{
+		TenantID:    tenantID,
+		ContentHash: contentHash,
+		Size:        size,
+		RefCount:    1,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	err := WithTransaction(ctx, func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "tenant_id"}, {Name: "content_hash"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"ref_count":  gorm.Expr("content_blobs.ref_count + 1"),
+				"updated_at": now,
+			}),
+		}).Create(&blob).Error; err != nil {
+			return err
+		}
+
+		return tx.Where("tenant_id = ? AND content_hash = ?", tenantID, contentHash).First(&blob).Error
+	})
+	if err != nil {
+		logger.Error("Failed to acquire content blob", "error", err, "tenant_id", tenantID, "content_hash", contentHash)
+		return nil, errors.NewInternalError("Failed to acquire content blob: " + err.Error())
+	}
+
+	return &blob, nil
+}
+
+// Release removes a reference to a blob, deleting the blob with its last reference. The blob row
+// stays locked until the surrounding transaction ends.
+func (r *contentBlobRepository) Release(ctx context.Context, tenantID, contentHash string) (*models.ContentBlob, error) {
+	var blob models.ContentBlob
+	err := WithTransaction(ctx, func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("tenant_id = ? AND content_hash = ?", tenantID, contentHash).
+			First(&blob).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return errors.NewResourceNotFoundError("content blob not found")
+			}
+			return err
+		}
+
+		blob.RefCount--
+		blob.UpdatedAt = time.Now()
+		if blob.RefCount > 0 {
+			return tx.Save(&blob).Error
+		}
+		return tx.Delete(&blob).Error
+	})
+	if err != nil {
+		if errors.IsResourceNotFoundError(err) {
+			return nil, err
+		}
+		logger.Error("Failed to release content blob", "error", err, "tenant_id", tenantID, "content_hash", contentHash)
+		return nil, errors.NewInternalError("Failed to release content blob: " + err.Error())
+	}
+
+	return &blob, nil
+}
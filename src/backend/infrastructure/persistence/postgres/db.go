@@ -6,16 +6,19 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus" // v1.14.0+
 	"gorm.io/gorm" // v1.25.0+
-	"gorm.io/driver/postgres" // v1.5.0+
 
 	"../../../pkg/config"  // For database configuration settings
 	"../../../pkg/logger"  // For logging database operations
 	"../../../pkg/metrics" // For tracking database performance
 	"../../../pkg/errors"  // For standardized error handling
+	"../../../pkg/utils"   // For sort keys of paginated listings
 )
 
 var (
@@ -33,6 +36,18 @@ var (
 	
 	// connectionGauge tracks active database connections
 	connectionGauge *prometheus.GaugeVec
+
+	// replicaLagGauge tracks the replication lag of each read replica
+	replicaLagGauge *prometheus.GaugeVec
+
+	// poolSaturationGauge tracks the share of the connections of each pool in use
+	poolSaturationGauge *prometheus.GaugeVec
+
+	// slowQueryCounter counts the queries slower than the slow query threshold
+	slowQueryCounter *prometheus.CounterVec
+
+	// queryTimeoutCounter counts the queries cancelled by the query timeout
+	queryTimeoutCounter *prometheus.CounterVec
 )
 
 // Init initializes the database connection with the provided configuration
@@ -44,45 +59,40 @@ func Init(dbConfig config.DatabaseConfig) error {
 		return nil
 	}
 
-	// Build DSN from config
-	dsn := buildDSN(dbConfig)
-
-	// Connect to database
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
-		Logger: NewGormLogger(),
-	})
+	// Parse the pool sizes and timeouts shared by the primary and the replicas
+	settings, err := newPoolSettings(dbConfig)
 	if err != nil {
-		return errors.NewDependencyError(fmt.Sprintf("failed to connect to database: %v", err))
+		return err
 	}
 
-	// Get underlying SQL DB to configure pool
-	sqlDB, err := db.DB()
+	// Connect to database
+	db, err := openPool(dbConfig, settings, true)
 	if err != nil {
-		return errors.NewDependencyError(fmt.Sprintf("failed to get database connection: %v", err))
+		return err
 	}
 
-	// Configure connection pool
-	sqlDB.SetMaxOpenConns(dbConfig.MaxOpenConns)
-	sqlDB.SetMaxIdleConns(dbConfig.MaxIdleConns)
-	
-	// Parse connection max lifetime from string
-	connMaxLifetime, err := time.ParseDuration(dbConfig.ConnMaxLifetime)
+	// Open the read replicas serving read-only queries
+	replicaSet, err := openReplicas(dbConfig, settings)
 	if err != nil {
-		connMaxLifetime = 1 * time.Hour // Default to 1 hour if parsing fails
+		return err
 	}
-	sqlDB.SetConnMaxLifetime(connMaxLifetime)
 
 	// Register metrics
 	registerMetrics()
 
 	// Set the global instance
 	instance = db
+	replicas = replicaSet
 	initialized = true
+	replicas.start()
 
 	logger.Info("Database initialized successfully", 
 		"host", dbConfig.Host, 
 		"port", dbConfig.Port, 
-		"database", dbConfig.DBName)
+		"database", dbConfig.DBName,
+		"replicas", len(dbConfig.Replicas),
+		"max_open_conns", settings.maxOpenConns,
+		"query_timeout", settings.queryTimeout.String())
 
 	return nil
 }
@@ -95,6 +105,24 @@ func GetDB() (*gorm.DB, error) {
 	return instance, nil
 }
 
+// Ping checks the database accepts connections and answers within the deadline of ctx
+func Ping(ctx context.Context) error {
+	db, err := GetDB()
+	if err != nil {
+		return err
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return errors.NewDependencyError(fmt.Sprintf("failed to get database connection: %v", err))
+	}
+
+	if err := sqlDB.PingContext(ctx); err != nil {
+		return errors.NewDependencyError(fmt.Sprintf("failed to ping database: %v", err))
+	}
+	return nil
+}
+
 // Close closes the database connection and releases resources
 func Close() error {
 	mu.Lock()
@@ -114,13 +142,59 @@ func Close() error {
 		return errors.NewDependencyError(fmt.Sprintf("failed to close database connection: %v", err))
 	}
 
+	if err := replicas.close(); err != nil {
+		return err
+	}
+	replicas = nil
+
 	initialized = false
 	logger.Info("Database connection closed")
 	return nil
 }
 
-// WithTransaction executes a function within a database transaction
+// txContextKey is the context key under which an active transaction is stored
+type txContextKey struct{}
+
+// ContextWithTransaction returns a copy of ctx carrying the given transaction so that
+// repositories invoked with the returned context participate in it
+func ContextWithTransaction(ctx context.Context, tx *gorm.DB) context.Context {
+	return context.WithValue(ctx, txContextKey{}, tx)
+}
+
+// TransactionFromContext returns the transaction carried by ctx, if any
+func TransactionFromContext(ctx context.Context) (*gorm.DB, bool) {
+	tx, ok := ctx.Value(txContextKey{}).(*gorm.DB)
+	return tx, ok && tx != nil
+}
+
+// dbFromContext returns the transaction carried by ctx, falling back to db scoped to ctx
+func dbFromContext(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := TransactionFromContext(ctx); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
+
+// GetDBFromContext returns the transaction carried by ctx, or the shared connection scoped to ctx
+func GetDBFromContext(ctx context.Context) (*gorm.DB, error) {
+	if tx, ok := TransactionFromContext(ctx); ok {
+		return tx.WithContext(ctx), nil
+	}
+
+	db, err := GetDB()
+	if err != nil {
+		return nil, err
+	}
+	return db.WithContext(ctx), nil
+}
+
+// WithTransaction executes a function within a database transaction.
+// If ctx already carries a transaction, fn joins it and the outer caller owns commit and rollback.
 func WithTransaction(ctx context.Context, fn func(tx *gorm.DB) error) error {
+	if tx, ok := TransactionFromContext(ctx); ok {
+		return fn(tx)
+	}
+
 	db, err := GetDB()
 	if err != nil {
 		return err
@@ -151,21 +225,111 @@ func WithTransaction(ctx context.Context, fn func(tx *gorm.DB) error) error {
 	return nil
 }
 
-// Migrate runs database migrations to ensure schema is up to date
-func Migrate(models ...interface{}) error {
-	db, err := GetDB()
-	if err != nil {
-		return err
+// OrderClause returns the ORDER BY clause sorting by the keys whose field is in columns, the
+// column to sort each field by, or an empty string when there is none. Keys on other fields are
+// skipped, so a listing sorts by the fields it has; the clause only holds the given columns.
+func OrderClause(sort []utils.SortKey, columns map[string]string) string {
+	parts := make([]string, 0, len(sort))
+	for _, key := range sort {
+		column, ok := columns[key.Field]
+		if !ok {
+			continue
+		}
+		direction := "ASC"
+		if key.Descending() {
+			direction = "DESC"
+		}
+		parts = append(parts, column+" "+direction)
 	}
+	return strings.Join(parts, ", ")
+}
 
-	logger.Info("Running database migrations")
-	err = db.AutoMigrate(models...)
-	if err != nil {
-		return errors.NewDependencyError(fmt.Sprintf("failed to run migrations: %v", err))
+// sortFieldID sorts rows by ID. Listings sort by it last, so pages neither overlap nor skip rows.
+const sortFieldID = "id"
+
+// sortByID is the last key of every listing
+var sortByID = utils.SortKey{Field: sortFieldID, Direction: utils.SortAscending}
+
+// ListingSort returns the keys a listing is sorted by: the keys whose field is in columns, then
+// the tiebreak keys on fields it does not sort by yet. The last tiebreak key must be unique for
+// the listing to be continued from a cursor.
+func ListingSort(sort []utils.SortKey, columns map[string]string, tiebreak ...utils.SortKey) []utils.SortKey {
+	keys := make([]utils.SortKey, 0, len(sort)+len(tiebreak))
+	sorted := make(map[string]bool, len(sort)+len(tiebreak))
+	for _, key := range append(append([]utils.SortKey{}, sort...), tiebreak...) {
+		if _, ok := columns[key.Field]; !ok || sorted[key.Field] {
+			continue
+		}
+		sorted[key.Field] = true
+		keys = append(keys, key)
 	}
-	
-	logger.Info("Database migrations completed successfully")
-	return nil
+	return keys
+}
+
+// SeekCondition returns the condition selecting the rows after a row in the order of
+// OrderClause(keys, columns), with its arguments, from the row's value for each key. Every key
+// must have a column, as those returned by ListingSort do.
+func SeekCondition(keys []utils.SortKey, columns map[string]string, values []interface{}) (string, []interface{}) {
+	clauses := make([]string, 0, len(keys))
+	var args []interface{}
+	for i, key := range keys {
+		parts := make([]string, 0, i+1)
+		for j := 0; j < i; j++ {
+			parts = append(parts, columns[keys[j].Field]+" = ?")
+			args = append(args, values[j])
+		}
+		operator := " > ?"
+		if key.Descending() {
+			operator = " < ?"
+		}
+		parts = append(parts, columns[key.Field]+operator)
+		args = append(args, values[i])
+		clauses = append(clauses, "("+strings.Join(parts, " AND ")+")")
+	}
+	return "(" + strings.Join(clauses, " OR ") + ")", args
+}
+
+// pageQuery restricts a listing query to a page of rows sorted by keys: the rows following the
+// pagination's cursor when it has one, the rows of its page number otherwise. field returns a
+// pointer to the field of a row a sort field sorts by. One row more than the page size is
+// fetched, to tell whether a page follows; nextPage trims it.
+func pageQuery[T any](query *gorm.DB, pagination *utils.Pagination, keys []utils.SortKey, columns map[string]string, field func(*T, string) interface{}) (*gorm.DB, error) {
+	query = query.Order(OrderClause(keys, columns)).Limit(pagination.GetLimit() + 1)
+	if pagination.Cursor == "" {
+		return query.Offset(pagination.GetOffset()), nil
+	}
+
+	var last T
+	values := sortValues(&last, keys, field)
+	if err := utils.DecodeCursor(pagination.Cursor, keys, values...); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+	for i, value := range values {
+		values[i] = reflect.ValueOf(value).Elem().Interface()
+	}
+	condition, args := SeekCondition(keys, columns, values)
+	return query.Where(condition, args...), nil
+}
+
+// nextPage trims the row pageQuery fetched past the page and sets the pagination's next cursor
+// to follow the last row of the page, or clears it when no page follows.
+func nextPage[T any](rows []T, pagination *utils.Pagination, keys []utils.SortKey, field func(*T, string) interface{}) []T {
+	pagination.NextCursor = ""
+	if len(rows) <= pagination.GetLimit() {
+		return rows
+	}
+	rows = rows[:pagination.GetLimit()]
+	pagination.NextCursor = utils.EncodeCursor(keys, sortValues(&rows[len(rows)-1], keys, field)...)
+	return rows
+}
+
+// sortValues returns pointers to the fields of a row the keys sort by
+func sortValues[T any](row *T, keys []utils.SortKey, field func(*T, string) interface{}) []interface{} {
+	values := make([]interface{}, len(keys))
+	for i, key := range keys {
+		values[i] = field(row, key.Field)
+	}
+	return values
 }
 
 // registerMetrics registers database-related metrics with Prometheus
@@ -182,7 +346,33 @@ func registerMetrics() {
 	connectionGauge = metrics.RegisterCustomGauge(
 		"db_connections",
 		"Database connections",
-		[]string{"state"},
+		[]string{"pool", "state"},
+	)
+
+	// Share of the connections of each pool in use, 1 when queries wait for a connection
+	poolSaturationGauge = metrics.RegisterCustomGauge(
+		"db_pool_saturation",
+		"Ratio of database connections in use to the maximum open connections",
+		[]string{"pool"},
+	)
+
+	// Slow and timed out queries
+	slowQueryCounter = metrics.RegisterCustomCounter(
+		"db_slow_queries_total",
+		"Database queries slower than the slow query threshold",
+		nil,
+	)
+	queryTimeoutCounter = metrics.RegisterCustomCounter(
+		"db_query_timeouts_total",
+		"Database queries cancelled by the query timeout",
+		nil,
+	)
+
+	// Replication lag of the read replicas, -1 while a replica cannot be reached
+	replicaLagGauge = metrics.RegisterCustomGauge(
+		"db_replica_lag_seconds",
+		"Replication lag of database read replicas in seconds",
+		[]string{"replica"},
 	)
 	
 	// Update metrics periodically
@@ -210,17 +400,25 @@ func updateMetrics() {
 		return
 	}
 
-	stats := sqlDB.Stats()
-	connectionGauge.WithLabelValues("open").Set(float64(stats.OpenConnections))
-	connectionGauge.WithLabelValues("idle").Set(float64(stats.Idle))
-	connectionGauge.WithLabelValues("in_use").Set(float64(stats.InUse))
-	connectionGauge.WithLabelValues("wait_count").Set(float64(stats.WaitCount))
-	connectionGauge.WithLabelValues("wait_duration").Set(float64(stats.WaitDuration.Seconds()))
+	if connectionGauge == nil || poolSaturationGauge == nil {
+		return
+	}
+	updatePoolMetrics("primary", sqlDB)
+
+	if replicas == nil {
+		return
+	}
+	for _, r := range replicas.replicas {
+		if replicaDB, err := r.db.DB(); err == nil {
+			updatePoolMetrics(r.name, replicaDB)
+		}
+	}
 }
 
-// buildDSN builds a PostgreSQL connection string from configuration
-func buildDSN(config config.DatabaseConfig) string {
-	return fmt.Sprintf(
+// buildDSN builds a PostgreSQL connection string from configuration. A statement timeout is set
+// on each connection, so the server cancels any statement running longer, writes included.
+func buildDSN(config config.DatabaseConfig, statementTimeout time.Duration) string {
+	dsn := fmt.Sprintf(
 		"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
 		config.Host,
 		config.Port,
@@ -229,6 +427,10 @@ func buildDSN(config config.DatabaseConfig) string {
 		config.DBName,
 		config.SSLMode,
 	)
+	if statementTimeout > 0 {
+		dsn += fmt.Sprintf(" statement_timeout=%d", statementTimeout.Milliseconds())
+	}
+	return dsn
 }
 
 // GormLogger is a custom logger for GORM that integrates with our application logging
@@ -299,8 +501,12 @@ func (l *GormLogger) Trace(ctx context.Context, begin time.Time, fc func() (stri
 		return
 	}
 	
+	if elapsed > l.SlowThreshold && slowQueryCounter != nil {
+		slowQueryCounter.WithLabelValues().Inc()
+	}
 	if elapsed > l.SlowThreshold && l.LogLevel >= gorm.WarnLevel {
 		logger.WarnContext(ctx, "Slow SQL query",
+			"threshold", l.SlowThreshold.String(),
 			"elapsed", elapsed.String(),
 			"rows", rows,
 			"sql", sql,
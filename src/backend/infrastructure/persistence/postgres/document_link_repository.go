@@ -0,0 +1,159 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid" // v1.3.0+ - For generating unique IDs for document links
+	"gorm.io/gorm"           // v1.25.0+ - ORM library for database operations
+	"gorm.io/gorm/clause"    // v1.25.0+ - For skipping links that already exist
+
+	"../../../domain/models"
+	"../../../domain/repositories"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+)
+
+// documentLinkRepository implements the DocumentLinkRepository interface using PostgreSQL
+type documentLinkRepository struct{}
+
+// NewDocumentLinkRepository creates a new instance of the PostgreSQL implementation of DocumentLinkRepository
+func NewDocumentLinkRepository() repositories.DocumentLinkRepository {
+	return &documentLinkRepository{}
+}
+
+// Create persists a new document link to the database; the unique index on the source, target and
+// type rejects a link that already exists
+func (r *documentLinkRepository) Create(ctx context.Context, link *models.DocumentLink) (string, error) {
+	if err := link.Validate(); err != nil {
+		return "", errors.NewValidationError(err.Error())
+	}
+
+	if link.ID == "" {
+		link.ID = uuid.New().String()
+	}
+
+	now := time.Now()
+	if link.CreatedAt.IsZero() {
+		link.CreatedAt = now
+	}
+	if link.UpdatedAt.IsZero() {
+		link.UpdatedAt = now
+	}
+
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(link)
+	if result.Error != nil {
+		logger.Error("Failed to create document link", "error", result.Error, "source_id", link.SourceID, "target_id", link.TargetID, "tenant_id", link.TenantID)
+		return "", errors.NewInternalError("Failed to create document link: " + result.Error.Error())
+	}
+
+	if result.RowsAffected == 0 {
+		return "", errors.NewValidationError("the documents are already linked with type " + link.Type)
+	}
+
+	return link.ID, nil
+}
+
+// GetByID retrieves a document link by its ID with tenant isolation
+func (r *documentLinkRepository) GetByID(ctx context.Context, id string, tenantID string) (*models.DocumentLink, error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var link models.DocumentLink
+	if err := db.Where("id = ? AND tenant_id = ?", id, tenantID).First(&link).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewResourceNotFoundError("Document link not found")
+		}
+		logger.Error("Failed to get document link", "error", err, "id", id, "tenant_id", tenantID)
+		return nil, errors.NewInternalError("Failed to get document link: " + err.Error())
+	}
+
+	return &link, nil
+}
+
+// Update persists the type of an existing document link
+func (r *documentLinkRepository) Update(ctx context.Context, link *models.DocumentLink) error {
+	if err := link.Validate(); err != nil {
+		return errors.NewValidationError(err.Error())
+	}
+
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	var existing int64
+	if err := db.Model(&models.DocumentLink{}).
+		Where("tenant_id = ? AND source_id = ? AND target_id = ? AND type = ? AND id <> ?", link.TenantID, link.SourceID, link.TargetID, link.Type, link.ID).
+		Count(&existing).Error; err != nil {
+		logger.Error("Failed to check document link", "error", err, "id", link.ID, "tenant_id", link.TenantID)
+		return errors.NewInternalError("Failed to update document link: " + err.Error())
+	}
+	if existing > 0 {
+		return errors.NewValidationError("the documents are already linked with type " + link.Type)
+	}
+
+	result := db.Model(&models.DocumentLink{}).
+		Where("id = ? AND tenant_id = ?", link.ID, link.TenantID).
+		Updates(map[string]interface{}{
+			"type":       link.Type,
+			"updated_at": link.UpdatedAt,
+		})
+
+	if result.Error != nil {
+		logger.Error("Failed to update document link", "error", result.Error, "id", link.ID, "tenant_id", link.TenantID)
+		return errors.NewInternalError("Failed to update document link: " + result.Error.Error())
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.NewResourceNotFoundError("Document link not found")
+	}
+
+	return nil
+}
+
+// Delete deletes a document link with tenant isolation
+func (r *documentLinkRepository) Delete(ctx context.Context, id string, tenantID string) error {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	result := db.Where("id = ? AND tenant_id = ?", id, tenantID).Delete(&models.DocumentLink{})
+
+	if result.Error != nil {
+		logger.Error("Failed to delete document link", "error", result.Error, "id", id, "tenant_id", tenantID)
+		return errors.NewInternalError("Failed to delete document link: " + result.Error.Error())
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.NewResourceNotFoundError("Document link not found")
+	}
+
+	return nil
+}
+
+// ListByDocument lists the links a document is the source or target of, oldest first
+func (r *documentLinkRepository) ListByDocument(ctx context.Context, documentID string, tenantID string) ([]models.DocumentLink, error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var links []models.DocumentLink
+	if err := db.Where("tenant_id = ? AND (source_id = ? OR target_id = ?)", tenantID, documentID, documentID).
+		Order("created_at ASC, id ASC").
+		Find(&links).Error; err != nil {
+		logger.Error("Failed to list document links", "error", err, "document_id", documentID, "tenant_id", tenantID)
+		return nil, errors.NewInternalError("Failed to list document links: " + err.Error())
+	}
+
+	return links, nil
+}
@@ -8,6 +8,7 @@ import (
 	"time"    // standard library
 
 	"gorm.io/gorm" // v1.25.0+
+	"gorm.io/gorm/clause" // v1.25.0+
 	"github.com/google/uuid" // v1.3.0+
 
 	"../../../domain/repositories"
@@ -16,6 +17,9 @@ import (
 	"../../../pkg/errors"
 )
 
+// bulkMetadataBatchSize bounds the metadata rows written by one statement of BulkUpdateMetadata
+const bulkMetadataBatchSize = 1000
+
 // documentRepository is a PostgreSQL implementation of the DocumentRepository interface
 type documentRepository struct {
 	db *gorm.DB
@@ -32,6 +36,17 @@ func NewDocumentRepository(db *gorm.DB) repositories.DocumentRepository {
 	}
 }
 
+// conn returns the database handle for ctx, using the caller's transaction when one is carried in ctx
+func (r *documentRepository) conn(ctx context.Context) *gorm.DB {
+	return dbFromContext(ctx, r.db)
+}
+
+// reader returns the database handle for a read-only query on ctx, a read replica unless ctx
+// carries a transaction or requires the primary
+func (r *documentRepository) reader(ctx context.Context) *gorm.DB {
+	return readDBFromContext(ctx, r.db)
+}
+
 // Create stores a new document in the repository and returns its ID.
 func (r *documentRepository) Create(ctx context.Context, document *models.Document) (string, error) {
 	if err := document.Validate(); err != nil {
@@ -43,63 +58,56 @@ func (r *documentRepository) Create(ctx context.Context, document *models.Docume
 		document.ID = uuid.New().String()
 	}
 
-	// Begin a transaction
-	tx := r.db.WithContext(ctx).Begin()
-	if tx.Error != nil {
-		return "", errors.Wrap(tx.Error, "failed to begin transaction")
-	}
-
-	// Create the document
-	if err := tx.Create(document).Error; err != nil {
-		tx.Rollback()
-		return "", errors.Wrap(err, "failed to create document")
-	}
+	// Run the writes in a transaction, joining the caller's transaction if present
+	err := r.conn(ctx).Transaction(func(tx *gorm.DB) error {
+		// Create the document; new documents are not locked, so leave the lock columns NULL
+		if err := tx.Omit("locked_by", "locked_at").Create(document).Error; err != nil {
+			return errors.Wrap(err, "failed to create document")
+		}
 
-	// Create metadata entries if any
-	if len(document.Metadata) > 0 {
-		for i := range document.Metadata {
-			document.Metadata[i].DocumentID = document.ID
-			if document.Metadata[i].ID == "" {
-				document.Metadata[i].ID = uuid.New().String()
-			}
-			if err := tx.Create(&document.Metadata[i]).Error; err != nil {
-				tx.Rollback()
-				return "", errors.Wrap(err, "failed to create document metadata")
+		// Create metadata entries if any
+		if len(document.Metadata) > 0 {
+			for i := range document.Metadata {
+				document.Metadata[i].DocumentID = document.ID
+				if document.Metadata[i].ID == "" {
+					document.Metadata[i].ID = uuid.New().String()
+				}
+				if err := tx.Create(&document.Metadata[i]).Error; err != nil {
+					return errors.Wrap(err, "failed to create document metadata")
+				}
 			}
 		}
-	}
 
-	// Create versions if any
-	if len(document.Versions) > 0 {
-		for i := range document.Versions {
-			document.Versions[i].DocumentID = document.ID
-			if document.Versions[i].ID == "" {
-				document.Versions[i].ID = uuid.New().String()
-			}
-			if err := tx.Create(&document.Versions[i]).Error; err != nil {
-				tx.Rollback()
-				return "", errors.Wrap(err, "failed to create document version")
+		// Create versions if any
+		if len(document.Versions) > 0 {
+			for i := range document.Versions {
+				document.Versions[i].DocumentID = document.ID
+				if document.Versions[i].ID == "" {
+					document.Versions[i].ID = uuid.New().String()
+				}
+				if err := tx.Create(&document.Versions[i]).Error; err != nil {
+					return errors.Wrap(err, "failed to create document version")
+				}
 			}
 		}
-	}
 
-	// Handle tags if any
-	if len(document.Tags) > 0 {
-		for _, tag := range document.Tags {
-			// Associate document with tag (using a join table)
-			if err := tx.Table("document_tags").Create(map[string]interface{}{
-				"document_id": document.ID,
-				"tag_id":      tag.ID,
-			}).Error; err != nil {
-				tx.Rollback()
-				return "", errors.Wrap(err, "failed to associate document with tag")
+		// Handle tags if any
+		if len(document.Tags) > 0 {
+			for _, tag := range document.Tags {
+				// Associate document with tag (using a join table)
+				if err := tx.Table("document_tags").Create(map[string]interface{}{
+					"document_id": document.ID,
+					"tag_id":      tag.ID,
+				}).Error; err != nil {
+					return errors.Wrap(err, "failed to associate document with tag")
+				}
 			}
 		}
-	}
 
-	// Commit the transaction
-	if err := tx.Commit().Error; err != nil {
-		return "", errors.Wrap(err, "failed to commit transaction")
+		return nil
+	})
+	if err != nil {
+		return "", err
 	}
 
 	return document.ID, nil
@@ -117,11 +125,13 @@ func (r *documentRepository) GetByID(ctx context.Context, id string, tenantID st
 	var document models.Document
 
 	// Query with tenant isolation
-	err := r.db.WithContext(ctx).
+	err := r.reader(ctx).
 		Where("id = ? AND tenant_id = ?", id, tenantID).
 		Preload("Metadata").
 		Preload("Versions").
 		Preload("Tags").
+		Preload("Links", func(db *gorm.DB) *gorm.DB { return db.Order("created_at ASC, id ASC") }).
+		Preload("LinkedFrom", func(db *gorm.DB) *gorm.DB { return db.Order("created_at ASC, id ASC") }).
 		First(&document).Error
 
 	if err != nil {
@@ -303,7 +313,33 @@ func (r *documentRepository) Delete(ctx context.Context, id string, tenantID str
 	return nil
 }
 
+// documentSortColumns are the columns documents are sorted by for each sort field
+var documentSortColumns = map[string]string{
+	utils.SortFieldName:      "name",
+	utils.SortFieldCreatedAt: "created_at",
+	utils.SortFieldUpdatedAt: "updated_at",
+	utils.SortFieldSize:      "size",
+	sortFieldID:              "id",
+}
+
+// documentSortField returns a pointer to the field of a document a sort field sorts by
+func documentSortField(document *models.Document, field string) interface{} {
+	switch field {
+	case utils.SortFieldName:
+		return &document.Name
+	case utils.SortFieldCreatedAt:
+		return &document.CreatedAt
+	case utils.SortFieldUpdatedAt:
+		return &document.UpdatedAt
+	case utils.SortFieldSize:
+		return &document.Size
+	}
+	return &document.ID
+}
+
 // ListByFolder retrieves documents in a specific folder with pagination and tenant isolation.
+// Documents are sorted by the pagination's sort keys, then by ID so pages do not overlap, and
+// a page following a cursor is found by seeking past the previous page rather than skipping rows.
 func (r *documentRepository) ListByFolder(ctx context.Context, folderID string, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Document], error) {
 	if folderID == "" {
 		return utils.PaginatedResult[models.Document]{}, errors.NewValidationError("folder ID cannot be empty")
@@ -321,25 +357,29 @@ func (r *documentRepository) ListByFolder(ctx context.Context, folderID string,
 	var totalItems int64
 
 	// Count total matching documents
-	if err := r.db.WithContext(ctx).Model(&models.Document{}).
+	if err := r.reader(ctx).Model(&models.Document{}).
 		Where("folder_id = ? AND tenant_id = ?", folderID, tenantID).
 		Count(&totalItems).Error; err != nil {
 		return utils.PaginatedResult[models.Document]{}, errors.Wrap(err, "failed to count documents")
 	}
 
 	// Query documents with pagination
-	if err := r.db.WithContext(ctx).
-		Where("folder_id = ? AND tenant_id = ?", folderID, tenantID).
+	keys := ListingSort(pagination.Sort, documentSortColumns, sortByID)
+	query, err := pageQuery(r.reader(ctx).
+		Where("folder_id = ? AND tenant_id = ?", folderID, tenantID), pagination, keys, documentSortColumns, documentSortField)
+	if err != nil {
+		return utils.PaginatedResult[models.Document]{}, err
+	}
+	if err := query.
 		Preload("Metadata").
 		Preload("Versions", func(db *gorm.DB) *gorm.DB {
 			return db.Order("version_number DESC") // Latest version first
 		}).
 		Preload("Tags").
-		Limit(pagination.GetLimit()).
-		Offset(pagination.GetOffset()).
 		Find(&documents).Error; err != nil {
 		return utils.PaginatedResult[models.Document]{}, errors.Wrap(err, "failed to list documents")
 	}
+	documents = nextPage(documents, pagination, keys, documentSortField)
 
 	// Create paginated result
 	result := utils.NewPaginatedResult(documents, pagination, totalItems)
@@ -361,20 +401,65 @@ func (r *documentRepository) ListByTenant(ctx context.Context, tenantID string,
 	var totalItems int64
 
 	// Count total matching documents
-	if err := r.db.WithContext(ctx).Model(&models.Document{}).
+	if err := r.reader(ctx).Model(&models.Document{}).
 		Where("tenant_id = ?", tenantID).
 		Count(&totalItems).Error; err != nil {
 		return utils.PaginatedResult[models.Document]{}, errors.Wrap(err, "failed to count documents")
 	}
 
 	// Query documents with pagination
-	if err := r.db.WithContext(ctx).
+	if err := r.reader(ctx).
 		Where("tenant_id = ?", tenantID).
 		Preload("Metadata").
 		Preload("Versions", func(db *gorm.DB) *gorm.DB {
 			return db.Order("version_number DESC") // Latest version first
 		}).
 		Preload("Tags").
+		Order("created_at ASC, id ASC"). // Stable order for paging through all documents of the tenant
+		Limit(pagination.GetLimit()).
+		Offset(pagination.GetOffset()).
+		Find(&documents).Error; err != nil {
+		return utils.PaginatedResult[models.Document]{}, errors.Wrap(err, "failed to list documents")
+	}
+
+	// Create paginated result
+	result := utils.NewPaginatedResult(documents, pagination, totalItems)
+	return result, nil
+}
+
+// ListByOwner lists the documents a user owns with pagination and tenant isolation.
+func (r *documentRepository) ListByOwner(ctx context.Context, ownerID string, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Document], error) {
+	if ownerID == "" {
+		return utils.PaginatedResult[models.Document]{}, errors.NewValidationError("owner ID cannot be empty")
+	}
+	if tenantID == "" {
+		return utils.PaginatedResult[models.Document]{}, errors.NewValidationError("tenant ID cannot be empty")
+	}
+
+	// Set default pagination if not provided
+	if pagination == nil {
+		pagination = utils.NewPagination(utils.DefaultPage, utils.DefaultPageSize)
+	}
+
+	var documents []models.Document
+	var totalItems int64
+
+	// Count total matching documents
+	if err := r.reader(ctx).Model(&models.Document{}).
+		Where("owner_id = ? AND tenant_id = ?", ownerID, tenantID).
+		Count(&totalItems).Error; err != nil {
+		return utils.PaginatedResult[models.Document]{}, errors.Wrap(err, "failed to count documents")
+	}
+
+	// Query documents with pagination
+	if err := r.reader(ctx).
+		Where("owner_id = ? AND tenant_id = ?", ownerID, tenantID).
+		Preload("Metadata").
+		Preload("Versions", func(db *gorm.DB) *gorm.DB {
+			return db.Order("version_number DESC") // Latest version first
+		}).
+		Preload("Tags").
+		Order("created_at ASC, id ASC"). // Stable order for paging through all documents of the owner
 		Limit(pagination.GetLimit()).
 		Offset(pagination.GetOffset()).
 		Find(&documents).Error; err != nil {
@@ -427,7 +512,7 @@ func (r *documentRepository) SearchByMetadata(ctx context.Context, metadata map[
 	var totalItems int64
 
 	// Base query with tenant isolation
-	baseQuery := r.db.WithContext(ctx).Table("documents").
+	baseQuery := r.reader(ctx).Table("documents").
 		Joins("JOIN document_metadata ON documents.id = document_metadata.document_id").
 		Where("documents.tenant_id = ?", tenantID).
 		Group("documents.id")
@@ -461,7 +546,7 @@ func (r *documentRepository) SearchByMetadata(ctx context.Context, metadata map[
 
 	// Retrieve full documents with their relations
 	if len(docIds) > 0 {
-		if err := r.db.WithContext(ctx).
+		if err := r.reader(ctx).
 			Where("id IN ?", docIds).
 			Preload("Metadata").
 			Preload("Versions", func(db *gorm.DB) *gorm.DB {
@@ -489,37 +574,31 @@ func (r *documentRepository) AddVersion(ctx context.Context, version *models.Doc
 		version.ID = uuid.New().String()
 	}
 
-	// Begin a transaction
-	tx := r.db.WithContext(ctx).Begin()
-	if tx.Error != nil {
-		return "", errors.Wrap(tx.Error, "failed to begin transaction")
-	}
-
-	// Check if document exists and get tenant ID
-	var document models.Document
-	if err := tx.Where("id = ?", version.DocumentID).First(&document).Error; err != nil {
-		tx.Rollback()
-		if err == gorm.ErrRecordNotFound {
-			return "", errors.NewResourceNotFoundError(fmt.Sprintf("document with ID %s not found", version.DocumentID))
+	// Run the writes in a transaction, joining the caller's transaction if present
+	err := r.conn(ctx).Transaction(func(tx *gorm.DB) error {
+		// Check if document exists and get tenant ID
+		var document models.Document
+		if err := tx.Where("id = ?", version.DocumentID).First(&document).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return errors.NewResourceNotFoundError(fmt.Sprintf("document with ID %s not found", version.DocumentID))
+			}
+			return errors.Wrap(err, "failed to check document existence")
 		}
-		return "", errors.Wrap(err, "failed to check document existence")
-	}
 
-	// Create the version
-	if err := tx.Create(version).Error; err != nil {
-		tx.Rollback()
-		return "", errors.Wrap(err, "failed to create document version")
-	}
+		// Create the version
+		if err := tx.Create(version).Error; err != nil {
+			return errors.Wrap(err, "failed to create document version")
+		}
 
-	// Update document's updated_at timestamp
-	if err := tx.Model(&document).Update("updated_at", version.CreatedAt).Error; err != nil {
-		tx.Rollback()
-		return "", errors.Wrap(err, "failed to update document timestamp")
-	}
+		// Update document's updated_at timestamp
+		if err := tx.Model(&document).Update("updated_at", version.CreatedAt).Error; err != nil {
+			return errors.Wrap(err, "failed to update document timestamp")
+		}
 
-	// Commit the transaction
-	if err := tx.Commit().Error; err != nil {
-		return "", errors.Wrap(err, "failed to commit transaction")
+		return nil
+	})
+	if err != nil {
+		return "", err
 	}
 
 	return version.ID, nil
@@ -619,6 +698,172 @@ func (r *documentRepository) UpdateVersionStatus(ctx context.Context, versionID
 	return nil
 }
 
+// UpdateVersionStoragePath records where the content of a document version is stored.
+func (r *documentRepository) UpdateVersionStoragePath(ctx context.Context, versionID string, storagePath string, tenantID string) error {
+	if versionID == "" {
+		return errors.NewValidationError("version ID cannot be empty")
+	}
+	if storagePath == "" {
+		return errors.NewValidationError("storage path cannot be empty")
+	}
+	if tenantID == "" {
+		return errors.NewValidationError("tenant ID cannot be empty")
+	}
+
+	result := r.conn(ctx).Model(&models.DocumentVersion{}).
+		Where("id = ? AND document_id IN (?)", versionID,
+			r.conn(ctx).Model(&models.Document{}).Select("id").Where("tenant_id = ?", tenantID)).
+		Update("storage_path", storagePath)
+	if result.Error != nil {
+		return errors.Wrap(result.Error, "failed to update version storage path")
+	}
+	if result.RowsAffected == 0 {
+		return errors.NewResourceNotFoundError(fmt.Sprintf("document version with ID %s not found or does not belong to tenant", versionID))
+	}
+
+	return nil
+}
+
+// ListVersionsToReencrypt lists versions of a tenant whose content is not encrypted with the given key,
+// oldest first.
+func (r *documentRepository) ListVersionsToReencrypt(ctx context.Context, tenantID string, keyID string, limit int) ([]*models.DocumentVersion, error) {
+	if tenantID == "" {
+		return nil, errors.NewValidationError("tenant ID cannot be empty")
+	}
+	if limit <= 0 {
+		return nil, errors.NewValidationError("limit must be positive")
+	}
+
+	var versions []*models.DocumentVersion
+	if err := r.conn(ctx).
+		Joins("JOIN documents ON document_versions.document_id = documents.id").
+		Where("documents.tenant_id = ? AND document_versions.encryption_key_id <> ? AND document_versions.status <> ?",
+			tenantID, keyID, models.VersionStatusProcessing).
+		Order("document_versions.created_at").
+		Limit(limit).
+		Find(&versions).Error; err != nil {
+		return nil, errors.Wrap(err, "failed to list document versions to re-encrypt")
+	}
+
+	return versions, nil
+}
+
+// ListRecentVersions lists the versions of a tenant in a status created at or after since, newest first.
+func (r *documentRepository) ListRecentVersions(ctx context.Context, tenantID string, status string, since time.Time, limit int) ([]*models.DocumentVersion, error) {
+	if tenantID == "" {
+		return nil, errors.NewValidationError("tenant ID cannot be empty")
+	}
+	if limit <= 0 {
+		return nil, errors.NewValidationError("limit must be positive")
+	}
+
+	var versions []*models.DocumentVersion
+	if err := r.conn(ctx).
+		Joins("JOIN documents ON document_versions.document_id = documents.id").
+		Where("documents.tenant_id = ? AND document_versions.status = ? AND document_versions.created_at >= ?",
+			tenantID, status, since).
+		Order("document_versions.created_at DESC").
+		Limit(limit).
+		Find(&versions).Error; err != nil {
+		return nil, errors.Wrap(err, "failed to list recent document versions")
+	}
+
+	return versions, nil
+}
+
+// UpdateVersionEncryptionKey records the key the content of a document version is encrypted with.
+func (r *documentRepository) UpdateVersionEncryptionKey(ctx context.Context, versionID string, keyID string, tenantID string) error {
+	if versionID == "" {
+		return errors.NewValidationError("version ID cannot be empty")
+	}
+	if tenantID == "" {
+		return errors.NewValidationError("tenant ID cannot be empty")
+	}
+
+	result := r.conn(ctx).Model(&models.DocumentVersion{}).
+		Where("id = ? AND document_id IN (?)", versionID,
+			r.conn(ctx).Model(&models.Document{}).Select("id").Where("tenant_id = ?", tenantID)).
+		Update("encryption_key_id", keyID)
+	if result.Error != nil {
+		return errors.Wrap(result.Error, "failed to update version encryption key")
+	}
+	if result.RowsAffected == 0 {
+		return errors.NewResourceNotFoundError(fmt.Sprintf("document version with ID %s not found or does not belong to tenant", versionID))
+	}
+
+	return nil
+}
+
+// ListVersionsToClassify lists available versions of a tenant not classified for personal data yet,
+// oldest first.
+func (r *documentRepository) ListVersionsToClassify(ctx context.Context, tenantID string, limit int) ([]*models.DocumentVersion, error) {
+	if tenantID == "" {
+		return nil, errors.NewValidationError("tenant ID cannot be empty")
+	}
+	if limit <= 0 {
+		return nil, errors.NewValidationError("limit must be positive")
+	}
+
+	var versions []*models.DocumentVersion
+	if err := r.conn(ctx).
+		Joins("JOIN documents ON document_versions.document_id = documents.id").
+		Where("documents.tenant_id = ? AND document_versions.status = ? AND document_versions.classified_at IS NULL",
+			tenantID, models.VersionStatusAvailable).
+		Order("document_versions.created_at").
+		Limit(limit).
+		Find(&versions).Error; err != nil {
+		return nil, errors.Wrap(err, "failed to list document versions to classify")
+	}
+
+	return versions, nil
+}
+
+// MarkVersionClassified records when the content of a document version was classified for personal data.
+func (r *documentRepository) MarkVersionClassified(ctx context.Context, versionID string, classifiedAt time.Time, tenantID string) error {
+	if versionID == "" {
+		return errors.NewValidationError("version ID cannot be empty")
+	}
+	if tenantID == "" {
+		return errors.NewValidationError("tenant ID cannot be empty")
+	}
+
+	result := r.conn(ctx).Model(&models.DocumentVersion{}).
+		Where("id = ? AND document_id IN (?)", versionID,
+			r.conn(ctx).Model(&models.Document{}).Select("id").Where("tenant_id = ?", tenantID)).
+		Update("classified_at", classifiedAt)
+	if result.Error != nil {
+		return errors.Wrap(result.Error, "failed to mark version classified")
+	}
+	if result.RowsAffected == 0 {
+		return errors.NewResourceNotFoundError(fmt.Sprintf("document version with ID %s not found or does not belong to tenant", versionID))
+	}
+
+	return nil
+}
+
+// MarkVersionIntegrityVerified records when the stored content of a document version last matched its hash.
+func (r *documentRepository) MarkVersionIntegrityVerified(ctx context.Context, versionID string, verifiedAt time.Time, tenantID string) error {
+	if versionID == "" {
+		return errors.NewValidationError("version ID cannot be empty")
+	}
+	if tenantID == "" {
+		return errors.NewValidationError("tenant ID cannot be empty")
+	}
+
+	result := r.conn(ctx).Model(&models.DocumentVersion{}).
+		Where("id = ? AND document_id IN (?)", versionID,
+			r.conn(ctx).Model(&models.Document{}).Select("id").Where("tenant_id = ?", tenantID)).
+		Update("integrity_verified_at", verifiedAt)
+	if result.Error != nil {
+		return errors.Wrap(result.Error, "failed to mark version integrity verified")
+	}
+	if result.RowsAffected == 0 {
+		return errors.NewResourceNotFoundError(fmt.Sprintf("document version with ID %s not found or does not belong to tenant", versionID))
+	}
+
+	return nil
+}
+
 // AddMetadata adds metadata to a document with tenant isolation.
 func (r *documentRepository) AddMetadata(ctx context.Context, documentID string, key string, value string, tenantID string) (string, error) {
 	if documentID == "" {
@@ -810,6 +1055,67 @@ func (r *documentRepository) DeleteMetadata(ctx context.Context, documentID stri
 	return nil
 }
 
+// BulkUpdateMetadata sets and removes metadata keys of many documents with tenant isolation. The
+// document IDs must be distinct; if any document is missing or belongs to another tenant, nothing
+// is changed.
+func (r *documentRepository) BulkUpdateMetadata(ctx context.Context, documentIDs []string, set map[string]string, remove []string, tenantID string) error {
+	if len(documentIDs) == 0 {
+		return errors.NewValidationError("document IDs cannot be empty")
+	}
+	if len(set) == 0 && len(remove) == 0 {
+		return errors.NewValidationError("metadata changes cannot be empty")
+	}
+	if tenantID == "" {
+		return errors.NewValidationError("tenant ID cannot be empty")
+	}
+
+	return r.conn(ctx).Transaction(func(tx *gorm.DB) error {
+		// Check that every document exists and belongs to the tenant
+		var count int64
+		if err := tx.Model(&models.Document{}).Where("id IN ? AND tenant_id = ?", documentIDs, tenantID).Count(&count).Error; err != nil {
+			return errors.Wrap(err, "failed to check document existence")
+		}
+		if count != int64(len(documentIDs)) {
+			return errors.NewResourceNotFoundError(fmt.Sprintf("%d of %d documents not found or do not belong to tenant", int64(len(documentIDs))-count, len(documentIDs)))
+		}
+
+		if len(remove) > 0 {
+			if err := tx.Where("document_id IN ? AND key IN ?", documentIDs, remove).Delete(&models.DocumentMetadata{}).Error; err != nil {
+				return errors.Wrap(err, "failed to delete document metadata")
+			}
+		}
+
+		now := time.Now()
+		if len(set) > 0 {
+			rows := make([]models.DocumentMetadata, 0, len(documentIDs)*len(set))
+			for _, documentID := range documentIDs {
+				for key, value := range set {
+					metadata := models.NewDocumentMetadata(documentID, key, value)
+					metadata.ID = uuid.New().String()
+					metadata.CreatedAt = now
+					metadata.UpdatedAt = now
+					rows = append(rows, metadata)
+				}
+			}
+
+			// Overwrite the values of keys the documents already have
+			if err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "document_id"}, {Name: "key"}},
+				DoUpdates: clause.AssignmentColumns([]string{"value", "updated_at"}),
+			}).CreateInBatches(rows, bulkMetadataBatchSize).Error; err != nil {
+				return errors.Wrap(err, "failed to save document metadata")
+			}
+		}
+
+		// Update the documents' updated_at timestamp
+		if err := tx.Model(&models.Document{}).Where("id IN ? AND tenant_id = ?", documentIDs, tenantID).Update("updated_at", now).Error; err != nil {
+			return errors.Wrap(err, "failed to update document timestamps")
+		}
+
+		return nil
+	})
+}
+
 // GetDocumentsByIDs retrieves multiple documents by their IDs with tenant isolation.
 func (r *documentRepository) GetDocumentsByIDs(ctx context.Context, ids []string, tenantID string) ([]*models.Document, error) {
 	if len(ids) == 0 {
@@ -822,7 +1128,7 @@ func (r *documentRepository) GetDocumentsByIDs(ctx context.Context, ids []string
 	var documents []*models.Document
 
 	// Query with tenant isolation
-	if err := r.db.WithContext(ctx).
+	if err := r.reader(ctx).
 		Where("id IN ? AND tenant_id = ?", ids, tenantID).
 		Preload("Metadata").
 		Preload("Versions").
@@ -832,4 +1138,161 @@ func (r *documentRepository) GetDocumentsByIDs(ctx context.Context, ids []string
 	}
 
 	return documents, nil
-}
\ No newline at end of file
+}
+
+// GetUsage returns the number of documents of a tenant and the storage taken up by all their versions.
+func (r *documentRepository) GetUsage(ctx context.Context, tenantID string) (int64, int64, error) {
+	if tenantID == "" {
+		return 0, 0, errors.NewValidationError("tenant ID cannot be empty")
+	}
+
+	var documentCount int64
+	if err := r.conn(ctx).Model(&models.Document{}).
+		Where("tenant_id = ?", tenantID).
+		Count(&documentCount).Error; err != nil {
+		return 0, 0, errors.Wrap(err, "failed to count documents")
+	}
+
+	// Every version is kept in storage, not only the latest
+	var storageBytes int64
+	if err := r.conn(ctx).Table("document_versions").
+		Joins("JOIN documents ON documents.id = document_versions.document_id").
+		Where("documents.tenant_id = ?", tenantID).
+		Select("COALESCE(SUM(document_versions.size), 0)").
+		Scan(&storageBytes).Error; err != nil {
+		return 0, 0, errors.Wrap(err, "failed to sum document storage")
+	}
+
+	return documentCount, storageBytes, nil
+}
+
+// SetLock locks a document on behalf of a user, or unlocks it when lockedAt is nil, with tenant isolation.
+func (r *documentRepository) SetLock(ctx context.Context, id string, tenantID string, lockedBy string, lockedAt *time.Time) error {
+	if id == "" {
+		return errors.NewValidationError("document ID cannot be empty")
+	}
+	if tenantID == "" {
+		return errors.NewValidationError("tenant ID cannot be empty")
+	}
+
+	var lockedByValue interface{}
+	if lockedAt != nil {
+		if lockedBy == "" {
+			return errors.NewValidationError("the user locking the document cannot be empty")
+		}
+		lockedByValue = lockedBy
+	}
+
+	result := r.conn(ctx).Model(&models.Document{}).
+		Where("id = ? AND tenant_id = ?", id, tenantID).
+		Updates(map[string]interface{}{
+			"locked_by": lockedByValue,
+			"locked_at": lockedAt,
+		})
+	if result.Error != nil {
+		return errors.Wrap(result.Error, "failed to update document lock")
+	}
+	if result.RowsAffected == 0 {
+		return errors.NewResourceNotFoundError(fmt.Sprintf("document with ID %s not found or does not belong to tenant", id))
+	}
+
+	return nil
+}
+
+// SetSchedule sets when a document is published and when it expires, together with the visibility
+// the schedule gives it now, with tenant isolation.
+func (r *documentRepository) SetSchedule(ctx context.Context, id string, tenantID string, publishAt, expireAt *time.Time, visibility string) error {
+	if id == "" {
+		return errors.NewValidationError("document ID cannot be empty")
+	}
+	if tenantID == "" {
+		return errors.NewValidationError("tenant ID cannot be empty")
+	}
+
+	result := r.conn(ctx).Model(&models.Document{}).
+		Where("id = ? AND tenant_id = ?", id, tenantID).
+		Updates(map[string]interface{}{
+			"publish_at": publishAt,
+			"expire_at":  expireAt,
+			"visibility": visibility,
+			"updated_at": time.Now(),
+		})
+	if result.Error != nil {
+		return errors.Wrap(result.Error, "failed to update document schedule")
+	}
+	if result.RowsAffected == 0 {
+		return errors.NewResourceNotFoundError(fmt.Sprintf("document with ID %s not found or does not belong to tenant", id))
+	}
+
+	return nil
+}
+
+// ListDueVisibilityChanges lists up to limit documents of any tenant that are due to be published or
+// to expire at now, the longest overdue first
+func (r *documentRepository) ListDueVisibilityChanges(ctx context.Context, now time.Time, limit int) ([]*models.Document, error) {
+	if limit <= 0 {
+		return nil, errors.NewValidationError("limit must be greater than 0")
+	}
+
+	var documents []*models.Document
+	err := r.conn(ctx).
+		Where("(visibility = ? AND (publish_at IS NULL OR publish_at <= ?)) OR (visibility = ? AND expire_at <= ?)",
+			models.DocumentVisibilityScheduled, now, models.DocumentVisibilityPublished, now).
+		Order("CASE WHEN visibility = 'scheduled' THEN publish_at ELSE expire_at END ASC NULLS FIRST").
+		Limit(limit).
+		Find(&documents).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list documents due for publication or expiry")
+	}
+
+	return documents, nil
+}
+
+// ApplyDueVisibility publishes a scheduled document or expires a published one with tenant isolation,
+// returning false when the change is no longer due, such as when another worker already made it or
+// the document's schedule was changed meanwhile
+func (r *documentRepository) ApplyDueVisibility(ctx context.Context, id string, tenantID string, from string, to string, now time.Time) (bool, error) {
+	var due string
+	switch from {
+	case models.DocumentVisibilityScheduled:
+		due = "publish_at IS NULL OR publish_at <= ?"
+	case models.DocumentVisibilityPublished:
+		due = "expire_at <= ?"
+	default:
+		return false, errors.NewValidationError(fmt.Sprintf("document visibility %s has no scheduled change", from))
+	}
+
+	result := r.conn(ctx).Model(&models.Document{}).
+		Where("id = ? AND tenant_id = ? AND visibility = ?", id, tenantID, from).
+		Where(due, now).
+		Updates(map[string]interface{}{
+			"visibility": to,
+			"updated_at": time.Now(),
+		})
+	if result.Error != nil {
+		return false, errors.Wrap(result.Error, "failed to update document visibility")
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// GetUploadVolume returns the bytes a user uploaded to a tenant since the given time
+func (r *documentRepository) GetUploadVolume(ctx context.Context, tenantID, userID string, since time.Time) (int64, error) {
+	if tenantID == "" {
+		return 0, errors.NewValidationError("tenant ID cannot be empty")
+	}
+	if userID == "" {
+		return 0, errors.NewValidationError("user ID cannot be empty")
+	}
+
+	var uploadedBytes int64
+	if err := r.conn(ctx).Table("document_versions").
+		Joins("JOIN documents ON documents.id = document_versions.document_id").
+		Where("documents.tenant_id = ? AND document_versions.created_by = ? AND document_versions.created_at >= ?", tenantID, userID, since).
+		Select("COALESCE(SUM(document_versions.size), 0)").
+		Scan(&uploadedBytes).Error; err != nil {
+		return 0, errors.Wrap(err, "failed to sum uploaded bytes")
+	}
+
+	return uploadedBytes, nil
+}
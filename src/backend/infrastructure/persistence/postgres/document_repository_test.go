@@ -14,6 +14,7 @@ import (
 
 	"../../../domain/models"
 	"../../../domain/repositories"
+	"../../../pkg/errors"
 	"../../../pkg/utils"
 )
 
@@ -239,6 +240,61 @@ func (s *DocumentRepositorySuite) TestListByFolder() {
 	assert.False(s.T(), firstPageResult.Pagination.HasPrevious)
 }
 
+// TestListByFolder_Sort tests listing documents sorted by several keys
+func (s *DocumentRepositorySuite) TestListByFolder_Sort() {
+	for _, d := range []struct {
+		name string
+		size int64
+	}{{"b.pdf", 2048}, {"a.pdf", 2048}, {"c.pdf", 512}} {
+		_, err := s.repo.Create(context.Background(), s.createTestDocument(d.name, "application/pdf", d.size))
+		require.NoError(s.T(), err)
+	}
+
+	pagination := utils.NewPagination(1, 10).WithSort([]utils.SortKey{
+		{Field: utils.SortFieldSize, Direction: utils.SortDescending},
+		{Field: utils.SortFieldName, Direction: utils.SortAscending},
+	})
+	result, err := s.repo.ListByFolder(context.Background(), s.testFolderID, s.testTenantID, pagination)
+	require.NoError(s.T(), err)
+
+	names := make([]string, len(result.Items))
+	for i, doc := range result.Items {
+		names[i] = doc.Name
+	}
+	assert.Equal(s.T(), []string{"a.pdf", "b.pdf", "c.pdf"}, names)
+}
+
+// TestListByFolder_Cursor tests paging through documents with continuation tokens
+func (s *DocumentRepositorySuite) TestListByFolder_Cursor() {
+	for _, name := range []string{"d.pdf", "b.pdf", "e.pdf", "a.pdf", "c.pdf"} {
+		_, err := s.repo.Create(context.Background(), s.createTestDocument(name, "application/pdf", 1024))
+		require.NoError(s.T(), err)
+	}
+
+	sort := []utils.SortKey{{Field: utils.SortFieldName, Direction: utils.SortDescending}}
+	var names []string
+	cursor := ""
+	for page := 0; page < 3; page++ {
+		pagination := utils.NewPagination(1, 2).WithSort(sort).WithCursor(cursor)
+		result, err := s.repo.ListByFolder(context.Background(), s.testFolderID, s.testTenantID, pagination)
+		require.NoError(s.T(), err)
+
+		for _, doc := range result.Items {
+			names = append(names, doc.Name)
+		}
+		cursor = result.Pagination.NextCursor
+		assert.Equal(s.T(), page < 2, result.Pagination.HasNext)
+	}
+	assert.Equal(s.T(), []string{"e.pdf", "d.pdf", "c.pdf", "b.pdf", "a.pdf"}, names)
+	assert.Empty(s.T(), cursor)
+
+	// A cursor issued for another sort is rejected
+	first, err := s.repo.ListByFolder(context.Background(), s.testFolderID, s.testTenantID, utils.NewPagination(1, 2).WithSort(sort))
+	require.NoError(s.T(), err)
+	_, err = s.repo.ListByFolder(context.Background(), s.testFolderID, s.testTenantID, utils.NewPagination(1, 2).WithCursor(first.Pagination.NextCursor))
+	assert.True(s.T(), errors.IsValidationError(err))
+}
+
 // TestListByTenant tests the ListByTenant method of the document repository
 func (s *DocumentRepositorySuite) TestListByTenant() {
 	// Create multiple test documents for the test tenant
@@ -281,6 +337,48 @@ func (s *DocumentRepositorySuite) TestListByTenant() {
 	assert.Equal(s.T(), int64(3), otherResult.Pagination.TotalItems)
 }
 
+// TestListByOwner tests the ListByOwner method of the document repository
+func (s *DocumentRepositorySuite) TestListByOwner() {
+	// Create test documents owned by the test owner
+	for i := 0; i < 3; i++ {
+		doc := s.createTestDocument(
+			fmt.Sprintf("owned%d.pdf", i),
+			"application/pdf",
+			1024,
+		)
+		_, err := s.repo.Create(context.Background(), doc)
+		require.NoError(s.T(), err)
+	}
+
+	// Create documents owned by someone else in the same tenant
+	otherOwnerID := uuid.New().String()
+	for i := 0; i < 2; i++ {
+		doc := models.NewDocument(
+			fmt.Sprintf("other%d.pdf", i),
+			"application/pdf",
+			1024,
+			s.testFolderID,
+			s.testTenantID,
+			otherOwnerID,
+		)
+		_, err := s.repo.Create(context.Background(), &doc)
+		require.NoError(s.T(), err)
+	}
+
+	// Test listing documents by owner, oldest first
+	pagination := utils.NewPagination(1, 10)
+	result, err := s.repo.ListByOwner(context.Background(), s.testOwnerID, s.testTenantID, pagination)
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), result.Items, 3)
+	assert.Equal(s.T(), int64(3), result.Pagination.TotalItems)
+	assert.Equal(s.T(), "owned0.pdf", result.Items[0].Name)
+
+	// Test that documents of the owner are not listed for another tenant
+	otherResult, err := s.repo.ListByOwner(context.Background(), s.testOwnerID, uuid.New().String(), pagination)
+	assert.NoError(s.T(), err)
+	assert.Empty(s.T(), otherResult.Items)
+}
+
 // TestSearchByMetadata tests the SearchByMetadata method of the document repository
 func (s *DocumentRepositorySuite) TestSearchByMetadata() {
 	// Create test documents with different metadata
@@ -452,6 +550,50 @@ func (s *DocumentRepositorySuite) TestUpdateVersionStatus() {
 	assert.Equal(s.T(), models.VersionStatusAvailable, unchangedVersion.Status)
 }
 
+// TestListVersionsToClassify tests that available versions are listed until they are marked classified
+func (s *DocumentRepositorySuite) TestListVersionsToClassify() {
+	// Create a test document with a version
+	doc := s.createTestDocument("test.pdf", "application/pdf", 1024)
+	docID, err := s.repo.Create(context.Background(), doc)
+	require.NoError(s.T(), err)
+
+	version := models.NewDocumentVersion(
+		docID,
+		1,
+		2048,
+		"abcdef123456",
+		"test/path/file.pdf",
+		s.testOwnerID,
+	)
+	versionID, err := s.repo.AddVersion(context.Background(), &version)
+	require.NoError(s.T(), err)
+
+	// Versions are not classified before their content is available
+	versions, err := s.repo.ListVersionsToClassify(context.Background(), s.testTenantID, 10)
+	assert.NoError(s.T(), err)
+	assert.Empty(s.T(), versions)
+
+	err = s.repo.UpdateVersionStatus(context.Background(), versionID, models.VersionStatusAvailable, s.testTenantID)
+	require.NoError(s.T(), err)
+
+	versions, err = s.repo.ListVersionsToClassify(context.Background(), s.testTenantID, 10)
+	assert.NoError(s.T(), err)
+	require.Len(s.T(), versions, 1)
+	assert.Equal(s.T(), versionID, versions[0].ID)
+
+	// Test tenant isolation
+	err = s.repo.MarkVersionClassified(context.Background(), versionID, time.Now(), "wrong-tenant-id")
+	assert.Error(s.T(), err)
+
+	// Classified versions are no longer listed
+	err = s.repo.MarkVersionClassified(context.Background(), versionID, time.Now(), s.testTenantID)
+	assert.NoError(s.T(), err)
+
+	versions, err = s.repo.ListVersionsToClassify(context.Background(), s.testTenantID, 10)
+	assert.NoError(s.T(), err)
+	assert.Empty(s.T(), versions)
+}
+
 // TestAddMetadata tests the AddMetadata method of the document repository
 func (s *DocumentRepositorySuite) TestAddMetadata() {
 	// Create and persist a test document
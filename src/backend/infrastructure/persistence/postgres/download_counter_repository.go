@@ -0,0 +1,51 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"../../../domain/repositories"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+)
+
+// incrementDownloadCounterQuery counts a download, unless the day's counter already reached the
+// limit, in which case no row is returned. The row lock of the upsert serializes concurrent downloads.
+const incrementDownloadCounterQuery = `
+INSERT INTO download_counters (tenant_id, user_id, folder_id, day, count)
+VALUES (?, ?, ?, ?, 1)
+ON CONFLICT (tenant_id, user_id, folder_id, day)
+DO UPDATE SET count = download_counters.count + 1
+WHERE download_counters.count < ?
+RETURNING count`
+
+// downloadCounterRepository implements the DownloadCounterRepository interface using PostgreSQL
+type downloadCounterRepository struct{}
+
+// NewDownloadCounterRepository creates a new instance of the PostgreSQL implementation of DownloadCounterRepository
+func NewDownloadCounterRepository() repositories.DownloadCounterRepository {
+	return &downloadCounterRepository{}
+}
+
+// Increment counts a download within the limit of the day
+func (r *downloadCounterRepository) Increment(ctx context.Context, tenantID, userID, folderID string, day time.Time, limit int) (int, bool, error) {
+	if limit <= 0 {
+		return 0, false, errors.NewValidationError("download limit must be positive")
+	}
+
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return 0, false, err
+	}
+
+	var counts []int
+	if err := db.Raw(incrementDownloadCounterQuery, tenantID, userID, folderID, day.Format("2006-01-02"), limit).Scan(&counts).Error; err != nil {
+		logger.Error("Failed to count download", "error", err, "folder_id", folderID, "user_id", userID, "tenant_id", tenantID)
+		return 0, false, errors.NewInternalError("Failed to count download: " + err.Error())
+	}
+	if len(counts) == 0 {
+		return limit, false, nil
+	}
+
+	return counts[0], true, nil
+}
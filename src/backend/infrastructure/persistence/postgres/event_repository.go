@@ -0,0 +1,134 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid" // v1.3.0+ - For generating unique IDs for events
+	"gorm.io/gorm"           // v1.25.0+ - For distinguishing missing events
+
+	"../../../domain/models"
+	"../../../domain/repositories"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+	"../../../pkg/utils"
+)
+
+// eventRepository implements the EventRepository interface using PostgreSQL.
+// All operations use the transaction carried by the context when present.
+type eventRepository struct{}
+
+// NewEventRepository creates a new instance of the PostgreSQL implementation of EventRepository
+func NewEventRepository() repositories.EventRepository {
+	return &eventRepository{}
+}
+
+// Create persists a new event
+func (r *eventRepository) Create(ctx context.Context, event *models.Event) (string, error) {
+	if err := event.Validate(); err != nil {
+		return "", errors.NewValidationError(err.Error())
+	}
+
+	if event.ID == "" {
+		event.ID = uuid.New().String()
+	}
+	if event.RequestID == "" {
+		event.RequestID = logger.RequestIDFromContext(ctx)
+	}
+
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if err := db.Create(event).Error; err != nil {
+		logger.Error("Failed to create event", "error", err, "event_type", event.Type, "tenant_id", event.TenantID)
+		return "", errors.NewInternalError("Failed to create event: " + err.Error())
+	}
+
+	return event.ID, nil
+}
+
+// GetByID retrieves an event by its ID with tenant isolation
+func (r *eventRepository) GetByID(ctx context.Context, id string, tenantID string) (*models.Event, error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var event models.Event
+	if err := db.Where("id = ? AND tenant_id = ?", id, tenantID).First(&event).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewResourceNotFoundError("Event not found")
+		}
+		logger.Error("Failed to get event", "error", err, "id", id, "tenant_id", tenantID)
+		return nil, errors.NewInternalError("Failed to get event: " + err.Error())
+	}
+
+	return &event, nil
+}
+
+// ListByType lists a tenant's events of a type, the most recent first
+func (r *eventRepository) ListByType(ctx context.Context, eventType string, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Event], error) {
+	return r.list(ctx, pagination, "tenant_id = ? AND type = ?", tenantID, eventType)
+}
+
+// ListByTenant lists a tenant's events, the most recent first
+func (r *eventRepository) ListByTenant(ctx context.Context, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Event], error) {
+	return r.list(ctx, pagination, "tenant_id = ?", tenantID)
+}
+
+// ListDocumentEvents lists the events raised for a document, the most recent first
+func (r *eventRepository) ListDocumentEvents(ctx context.Context, documentID string, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Event], error) {
+	return r.list(ctx, pagination, "tenant_id = ? AND payload->>'documentID' = ?", tenantID, documentID)
+}
+
+// ListFolderEvents lists the events raised for a folder, the most recent first
+func (r *eventRepository) ListFolderEvents(ctx context.Context, folderID string, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Event], error) {
+	return r.list(ctx, pagination, "tenant_id = ? AND payload->>'folderID' = ?", tenantID, folderID)
+}
+
+// DeleteOlderThan deletes a tenant's events that occurred before the given time
+func (r *eventRepository) DeleteOlderThan(ctx context.Context, olderThan time.Time, tenantID string) (int, error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	result := db.Where("tenant_id = ? AND occurred_at < ?", tenantID, olderThan).Delete(&models.Event{})
+	if result.Error != nil {
+		logger.Error("Failed to delete old events", "error", result.Error, "tenant_id", tenantID)
+		return 0, errors.NewInternalError("Failed to delete old events: " + result.Error.Error())
+	}
+
+	return int(result.RowsAffected), nil
+}
+
+// list lists the events matching a condition, the most recent first
+func (r *eventRepository) list(ctx context.Context, pagination *utils.Pagination, query string, args ...interface{}) (utils.PaginatedResult[models.Event], error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return utils.PaginatedResult[models.Event]{}, err
+	}
+
+	var events []models.Event
+	var totalItems int64
+
+	baseQuery := db.Model(&models.Event{}).Where(query, args...)
+
+	if err := baseQuery.Count(&totalItems).Error; err != nil {
+		logger.Error("Failed to count events", "error", err)
+		return utils.PaginatedResult[models.Event]{}, errors.NewInternalError("Failed to count events: " + err.Error())
+	}
+
+	if err := baseQuery.
+		Offset(pagination.GetOffset()).
+		Limit(pagination.GetLimit()).
+		Order("occurred_at DESC, id ASC").
+		Find(&events).Error; err != nil {
+		logger.Error("Failed to list events", "error", err)
+		return utils.PaginatedResult[models.Event]{}, errors.NewInternalError("Failed to list events: " + err.Error())
+	}
+
+	return utils.NewPaginatedResult(events, pagination, totalItems), nil
+}
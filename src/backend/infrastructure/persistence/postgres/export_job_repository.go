@@ -0,0 +1,134 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid" // v1.3.0+ - For generating unique IDs for export jobs
+	"gorm.io/gorm"           // v1.25.0+ - For claiming jobs in a transaction
+	"gorm.io/gorm/clause"    // v1.25.0+ - For row locking when claiming jobs
+
+	"../../../domain/models"
+	"../../../domain/repositories"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+)
+
+// exportJobRepository implements the ExportJobRepository interface using PostgreSQL
+type exportJobRepository struct{}
+
+// NewExportJobRepository creates a new instance of the PostgreSQL implementation of ExportJobRepository
+func NewExportJobRepository() repositories.ExportJobRepository {
+	return &exportJobRepository{}
+}
+
+// Create persists a new export job
+func (r *exportJobRepository) Create(ctx context.Context, job *models.ExportJob) (string, error) {
+	if err := job.Validate(); err != nil {
+		return "", errors.NewValidationError(err.Error())
+	}
+
+	if job.ID == "" {
+		job.ID = uuid.New().String()
+	}
+
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if err := db.Create(job).Error; err != nil {
+		logger.Error("Failed to create export job", "error", err, "folder_id", job.FolderID, "tenant_id", job.TenantID)
+		return "", errors.NewInternalError("Failed to create export job: " + err.Error())
+	}
+
+	return job.ID, nil
+}
+
+// GetByID retrieves an export job by its ID with tenant isolation
+func (r *exportJobRepository) GetByID(ctx context.Context, id string, tenantID string) (*models.ExportJob, error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var job models.ExportJob
+	if err := db.Where("id = ? AND tenant_id = ?", id, tenantID).First(&job).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewResourceNotFoundError("Export job not found")
+		}
+		logger.Error("Failed to get export job", "error", err, "id", id, "tenant_id", tenantID)
+		return nil, errors.NewInternalError("Failed to get export job: " + err.Error())
+	}
+
+	return &job, nil
+}
+
+// ClaimNext locks the oldest claimable export job, skipping jobs locked by other workers, and marks it as running
+func (r *exportJobRepository) ClaimNext(ctx context.Context, staleBefore time.Time) (*models.ExportJob, error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var claimed *models.ExportJob
+	err = db.Transaction(func(tx *gorm.DB) error {
+		var jobs []*models.ExportJob
+		if err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? OR (status = ? AND updated_at < ?)", models.ExportJobStatusPending, models.ExportJobStatusRunning, staleBefore).
+			Order("created_at ASC").
+			Limit(1).
+			Find(&jobs).Error; err != nil {
+			return err
+		}
+		if len(jobs) == 0 {
+			return nil
+		}
+
+		job := jobs[0]
+		job.Start(time.Now())
+		if err := tx.Save(job).Error; err != nil {
+			return err
+		}
+		claimed = job
+		return nil
+	})
+	if err != nil {
+		logger.Error("Failed to claim export job", "error", err)
+		return nil, errors.NewInternalError("Failed to claim export job: " + err.Error())
+	}
+
+	return claimed, nil
+}
+
+// Update persists the status, progress and result of an export job
+func (r *exportJobRepository) Update(ctx context.Context, job *models.ExportJob) error {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	result := db.Model(&models.ExportJob{}).
+		Where("id = ? AND tenant_id = ?", job.ID, job.TenantID).
+		Updates(map[string]interface{}{
+			"status":             job.Status,
+			"total_documents":    job.TotalDocuments,
+			"exported_documents": job.ExportedDocuments,
+			"archive_path":       job.ArchivePath,
+			"archive_size":       job.ArchiveSize,
+			"error":              job.Error,
+			"updated_at":         job.UpdatedAt,
+			"started_at":         job.StartedAt,
+			"completed_at":       job.CompletedAt,
+		})
+	if result.Error != nil {
+		logger.Error("Failed to update export job", "error", result.Error, "id", job.ID, "tenant_id", job.TenantID)
+		return errors.NewInternalError("Failed to update export job: " + result.Error.Error())
+	}
+	if result.RowsAffected == 0 {
+		return errors.NewResourceNotFoundError("Export job not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid" // v1.3.0+ - For generating unique IDs for identity links
+	"gorm.io/gorm"           // v1.25.0+ - ORM library for database operations
+	"gorm.io/gorm/clause"    // v1.25.0+ - For the conflict clause rejecting existing links
+
+	"../../../domain/models"
+	"../../../domain/repositories"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+)
+
+// externalIdentityLinkRepository implements the ExternalIdentityLinkRepository interface using PostgreSQL
+type externalIdentityLinkRepository struct{}
+
+// NewExternalIdentityLinkRepository creates a new instance of the PostgreSQL implementation of ExternalIdentityLinkRepository
+func NewExternalIdentityLinkRepository() repositories.ExternalIdentityLinkRepository {
+	return &externalIdentityLinkRepository{}
+}
+
+// Create persists a new link. The unique indexes reject a subject or a user's issuer that is already linked.
+func (r *externalIdentityLinkRepository) Create(ctx context.Context, link *models.ExternalIdentityLink) (string, error) {
+	if link.CreatedAt.IsZero() {
+		link.CreatedAt = time.Now()
+	}
+
+	if err := link.Validate(); err != nil {
+		return "", errors.NewValidationError(err.Error())
+	}
+
+	if link.ID == "" {
+		link.ID = uuid.New().String()
+	}
+
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(link)
+	if result.Error != nil {
+		logger.Error("Failed to create external identity link", "error", result.Error, "user_id", link.UserID, "tenant_id", link.TenantID)
+		return "", errors.NewInternalError("Failed to create external identity link: " + result.Error.Error())
+	}
+
+	if result.RowsAffected == 0 {
+		return "", errors.NewValidationError("the external identity or the user is already linked")
+	}
+
+	return link.ID, nil
+}
+
+// GetBySubject retrieves the link of an issuer's subject with tenant isolation
+func (r *externalIdentityLinkRepository) GetBySubject(ctx context.Context, tenantID string, issuer string, subject string) (*models.ExternalIdentityLink, error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var link models.ExternalIdentityLink
+	if err := db.Where("tenant_id = ? AND issuer = ? AND subject = ?", tenantID, issuer, subject).First(&link).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewResourceNotFoundError("external identity link not found")
+		}
+		logger.Error("Failed to get external identity link", "error", err, "issuer", issuer, "tenant_id", tenantID)
+		return nil, errors.NewInternalError("Failed to get external identity link: " + err.Error())
+	}
+
+	return &link, nil
+}
+
+// GetByUserID retrieves a user's link to an issuer with tenant isolation
+func (r *externalIdentityLinkRepository) GetByUserID(ctx context.Context, userID string, tenantID string, issuer string) (*models.ExternalIdentityLink, error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var link models.ExternalIdentityLink
+	if err := db.Where("user_id = ? AND tenant_id = ? AND issuer = ?", userID, tenantID, issuer).First(&link).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewResourceNotFoundError("external identity link not found")
+		}
+		logger.Error("Failed to get external identity link", "error", err, "user_id", userID, "tenant_id", tenantID)
+		return nil, errors.NewInternalError("Failed to get external identity link: " + err.Error())
+	}
+
+	return &link, nil
+}
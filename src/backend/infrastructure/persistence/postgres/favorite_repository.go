@@ -0,0 +1,103 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid" // v1.3.0+ - For generating unique IDs for favorites
+	"gorm.io/gorm/clause"    // v1.25.0+ - For ignoring resources starred twice
+
+	"../../../domain/models"
+	"../../../domain/repositories"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+	"../../../pkg/utils"
+)
+
+// favoriteRepository implements the FavoriteRepository interface using PostgreSQL
+type favoriteRepository struct{}
+
+// NewFavoriteRepository creates a new instance of the PostgreSQL implementation of FavoriteRepository
+func NewFavoriteRepository() repositories.FavoriteRepository {
+	return &favoriteRepository{}
+}
+
+// Add stars a resource for a user. Starring a resource the user already starred is a no-op.
+func (r *favoriteRepository) Add(ctx context.Context, favorite *models.Favorite) error {
+	if err := favorite.Validate(); err != nil {
+		return errors.NewValidationError(err.Error())
+	}
+
+	if favorite.ID == "" {
+		favorite.ID = uuid.New().String()
+	}
+	if favorite.CreatedAt.IsZero() {
+		favorite.CreatedAt = time.Now()
+	}
+
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(favorite).Error; err != nil {
+		logger.Error("Failed to add favorite", "error", err, "user_id", favorite.UserID, "resource_id", favorite.ResourceID, "tenant_id", favorite.TenantID)
+		return errors.NewInternalError("Failed to add favorite: " + err.Error())
+	}
+
+	return nil
+}
+
+// Remove unstars a resource for a user with tenant isolation
+func (r *favoriteRepository) Remove(ctx context.Context, tenantID, userID, resourceType, resourceID string) error {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	result := db.Where("tenant_id = ? AND user_id = ? AND resource_type = ? AND resource_id = ?", tenantID, userID, resourceType, resourceID).
+		Delete(&models.Favorite{})
+
+	if result.Error != nil {
+		logger.Error("Failed to remove favorite", "error", result.Error, "user_id", userID, "resource_id", resourceID, "tenant_id", tenantID)
+		return errors.NewInternalError("Failed to remove favorite: " + result.Error.Error())
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.NewResourceNotFoundError("Favorite not found")
+	}
+
+	return nil
+}
+
+// List lists the favorites of a user, most recently starred first, with pagination
+func (r *favoriteRepository) List(ctx context.Context, tenantID, userID, resourceType string, pagination *utils.Pagination) (utils.PaginatedResult[models.Favorite], error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return utils.PaginatedResult[models.Favorite]{}, err
+	}
+
+	var favorites []models.Favorite
+	var totalItems int64
+
+	baseQuery := db.Model(&models.Favorite{}).Where("tenant_id = ? AND user_id = ?", tenantID, userID)
+	if resourceType != "" {
+		baseQuery = baseQuery.Where("resource_type = ?", resourceType)
+	}
+
+	if err := baseQuery.Count(&totalItems).Error; err != nil {
+		logger.Error("Failed to count favorites", "error", err, "user_id", userID, "tenant_id", tenantID)
+		return utils.PaginatedResult[models.Favorite]{}, errors.NewInternalError("Failed to count favorites: " + err.Error())
+	}
+
+	if err := baseQuery.
+		Offset(pagination.GetOffset()).
+		Limit(pagination.GetLimit()).
+		Order("created_at DESC, id ASC").
+		Find(&favorites).Error; err != nil {
+		logger.Error("Failed to list favorites", "error", err, "user_id", userID, "tenant_id", tenantID)
+		return utils.PaginatedResult[models.Favorite]{}, errors.NewInternalError("Failed to list favorites: " + err.Error())
+	}
+
+	return utils.NewPaginatedResult(favorites, pagination, totalItems), nil
+}
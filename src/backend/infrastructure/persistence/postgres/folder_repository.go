@@ -28,6 +28,17 @@ func NewFolderRepository(db *gorm.DB) repositories.FolderRepository {
 	return &postgresqlFolderRepository{db: db}
 }
 
+// conn returns the database handle for ctx, using the caller's transaction when one is carried in ctx
+func (r *postgresqlFolderRepository) conn(ctx context.Context) *gorm.DB {
+	return dbFromContext(ctx, r.db)
+}
+
+// reader returns the database handle for a read-only query on ctx, a read replica unless ctx
+// carries a transaction or requires the primary
+func (r *postgresqlFolderRepository) reader(ctx context.Context) *gorm.DB {
+	return readDBFromContext(ctx, r.db)
+}
+
 // Create creates a new folder in the database
 func (r *postgresqlFolderRepository) Create(ctx context.Context, folder *models.Folder) (string, error) {
 	if err := folder.Validate(); err != nil {
@@ -43,7 +54,7 @@ func (r *postgresqlFolderRepository) Create(ctx context.Context, folder *models.
 	} else {
 		// Get parent folder to build the path
 		var parentFolder models.Folder
-		if err := r.db.WithContext(ctx).Where("id = ? AND tenant_id = ?", folder.ParentID, folder.TenantID).First(&parentFolder).Error; err != nil {
+		if err := r.conn(ctx).Where("id = ? AND tenant_id = ?", folder.ParentID, folder.TenantID).First(&parentFolder).Error; err != nil {
 			if err == gorm.ErrRecordNotFound {
 				return "", errors.NewNotFoundError(fmt.Sprintf("parent folder with ID %s not found", folder.ParentID))
 			}
@@ -52,21 +63,15 @@ func (r *postgresqlFolderRepository) Create(ctx context.Context, folder *models.
 		folder.SetPath(folder.BuildPath(parentFolder.Path))
 	}
 
-	// Begin a transaction
-	tx := r.db.WithContext(ctx).Begin()
-	if tx.Error != nil {
-		return "", errors.NewInternalError(fmt.Sprintf("failed to begin transaction: %v", tx.Error))
-	}
-
-	// Create the folder
-	if err := tx.Create(folder).Error; err != nil {
-		tx.Rollback()
-		return "", errors.NewInternalError(fmt.Sprintf("failed to create folder: %v", err))
-	}
-
-	// Commit the transaction
-	if err := tx.Commit().Error; err != nil {
-		return "", errors.NewInternalError(fmt.Sprintf("failed to commit transaction: %v", err))
+	// Create the folder within a transaction, joining the caller's transaction if present
+	err := r.conn(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(folder).Error; err != nil {
+			return errors.NewInternalError(fmt.Sprintf("failed to create folder: %v", err))
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
 	}
 
 	return folder.ID, nil
@@ -82,7 +87,7 @@ func (r *postgresqlFolderRepository) GetByID(ctx context.Context, id string, ten
 	}
 
 	var folder models.Folder
-	if err := r.db.WithContext(ctx).Where("id = ? AND tenant_id = ?", id, tenantID).First(&folder).Error; err != nil {
+	if err := r.reader(ctx).Where("id = ? AND tenant_id = ?", id, tenantID).First(&folder).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, errors.NewNotFoundError(fmt.Sprintf("folder with ID %s not found", id))
 		}
@@ -100,35 +105,28 @@ func (r *postgresqlFolderRepository) Update(ctx context.Context, folder *models.
 
 	// Check if folder exists
 	var existingFolder models.Folder
-	if err := r.db.WithContext(ctx).Where("id = ? AND tenant_id = ?", folder.ID, folder.TenantID).First(&existingFolder).Error; err != nil {
+	if err := r.conn(ctx).Where("id = ? AND tenant_id = ?", folder.ID, folder.TenantID).First(&existingFolder).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return errors.NewNotFoundError(fmt.Sprintf("folder with ID %s not found", folder.ID))
 		}
 		return errors.NewInternalError(fmt.Sprintf("error fetching folder: %v", err))
 	}
 
-	// Begin a transaction
-	tx := r.db.WithContext(ctx).Begin()
-	if tx.Error != nil {
-		return errors.NewInternalError(fmt.Sprintf("failed to begin transaction: %v", tx.Error))
-	}
-
-	// Update the folder
-	if err := tx.Model(&models.Folder{}).Where("id = ? AND tenant_id = ?", folder.ID, folder.TenantID).
-		Updates(map[string]interface{}{
-			"name":       folder.Name,
-			"updated_at": folder.UpdatedAt,
-		}).Error; err != nil {
-		tx.Rollback()
-		return errors.NewInternalError(fmt.Sprintf("failed to update folder: %v", err))
-	}
-
-	// Commit the transaction
-	if err := tx.Commit().Error; err != nil {
-		return errors.NewInternalError(fmt.Sprintf("failed to commit transaction: %v", err))
-	}
-
-	return nil
+	// Update the folder within a transaction, joining the caller's transaction if present
+	return r.conn(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Folder{}).Where("id = ? AND tenant_id = ?", folder.ID, folder.TenantID).
+			Updates(map[string]interface{}{
+				"name":                     folder.Name,
+				"watermark_downloads":      folder.WatermarkDownloads,
+				"download_view_only":       folder.DownloadViewOnly,
+				"daily_download_limit":     folder.DailyDownloadLimit,
+				"reauthentication_minutes": folder.ReauthenticationMinutes,
+				"updated_at":               folder.UpdatedAt,
+			}).Error; err != nil {
+			return errors.NewInternalError(fmt.Sprintf("failed to update folder: %v", err))
+		}
+		return nil
+	})
 }
 
 // Delete deletes a folder by its ID with tenant isolation
@@ -142,7 +140,7 @@ func (r *postgresqlFolderRepository) Delete(ctx context.Context, id string, tena
 
 	// Check if folder exists
 	var folder models.Folder
-	if err := r.db.WithContext(ctx).Where("id = ? AND tenant_id = ?", id, tenantID).First(&folder).Error; err != nil {
+	if err := r.conn(ctx).Where("id = ? AND tenant_id = ?", id, tenantID).First(&folder).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return errors.NewNotFoundError(fmt.Sprintf("folder with ID %s not found", id))
 		}
@@ -158,24 +156,40 @@ func (r *postgresqlFolderRepository) Delete(ctx context.Context, id string, tena
 		return errors.NewConflictError("cannot delete folder that contains items")
 	}
 
-	// Begin a transaction
-	tx := r.db.WithContext(ctx).Begin()
-	if tx.Error != nil {
-		return errors.NewInternalError(fmt.Sprintf("failed to begin transaction: %v", tx.Error))
-	}
+	// Delete the folder within a transaction, joining the caller's transaction if present
+	return r.conn(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("id = ? AND tenant_id = ?", id, tenantID).Delete(&models.Folder{}).Error; err != nil {
+			return errors.NewInternalError(fmt.Sprintf("failed to delete folder: %v", err))
+		}
+		return nil
+	})
+}
 
-	// Delete the folder
-	if err := tx.Where("id = ? AND tenant_id = ?", id, tenantID).Delete(&models.Folder{}).Error; err != nil {
-		tx.Rollback()
-		return errors.NewInternalError(fmt.Sprintf("failed to delete folder: %v", err))
-	}
+// folderSortColumns are the columns folders are sorted by for each sort field; folders have no size
+var folderSortColumns = map[string]string{
+	utils.SortFieldName:      "name",
+	utils.SortFieldCreatedAt: "created_at",
+	utils.SortFieldUpdatedAt: "updated_at",
+	sortFieldID:              "id",
+}
 
-	// Commit the transaction
-	if err := tx.Commit().Error; err != nil {
-		return errors.NewInternalError(fmt.Sprintf("failed to commit transaction: %v", err))
-	}
+// folderSort returns the keys of a folder listing: the pagination's sort keys, then by name and ID
+func folderSort(pagination *utils.Pagination) []utils.SortKey {
+	return ListingSort(pagination.Sort, folderSortColumns,
+		utils.SortKey{Field: utils.SortFieldName, Direction: utils.SortAscending}, sortByID)
+}
 
-	return nil
+// folderSortField returns a pointer to the field of a folder a sort field sorts by
+func folderSortField(folder *models.Folder, field string) interface{} {
+	switch field {
+	case utils.SortFieldName:
+		return &folder.Name
+	case utils.SortFieldCreatedAt:
+		return &folder.CreatedAt
+	case utils.SortFieldUpdatedAt:
+		return &folder.UpdatedAt
+	}
+	return &folder.ID
 }
 
 // GetChildren lists child folders of a parent folder with pagination and tenant isolation
@@ -187,7 +201,7 @@ func (r *postgresqlFolderRepository) GetChildren(ctx context.Context, parentID s
 	// If parentID is provided, check if it exists
 	if parentID != "" {
 		var parent models.Folder
-		if err := r.db.WithContext(ctx).Where("id = ? AND tenant_id = ?", parentID, tenantID).First(&parent).Error; err != nil {
+		if err := r.reader(ctx).Where("id = ? AND tenant_id = ?", parentID, tenantID).First(&parent).Error; err != nil {
 			if err == gorm.ErrRecordNotFound {
 				return utils.PaginatedResult[models.Folder]{}, errors.NewNotFoundError(fmt.Sprintf("parent folder with ID %s not found", parentID))
 			}
@@ -196,18 +210,20 @@ func (r *postgresqlFolderRepository) GetChildren(ctx context.Context, parentID s
 	}
 
 	var folders []models.Folder
-	query := r.db.WithContext(ctx).Where("parent_id = ? AND tenant_id = ?", parentID, tenantID).
-		Order("name ASC").
-		Offset(pagination.GetOffset()).
-		Limit(pagination.GetLimit())
+	keys := folderSort(pagination)
+	query, err := pageQuery(r.reader(ctx).Where("parent_id = ? AND tenant_id = ?", parentID, tenantID), pagination, keys, folderSortColumns, folderSortField)
+	if err != nil {
+		return utils.PaginatedResult[models.Folder]{}, err
+	}
 
 	if err := query.Find(&folders).Error; err != nil {
 		return utils.PaginatedResult[models.Folder]{}, errors.NewInternalError(fmt.Sprintf("error fetching child folders: %v", err))
 	}
+	folders = nextPage(folders, pagination, keys, folderSortField)
 
 	// Count total items for pagination
 	var totalItems int64
-	if err := r.db.WithContext(ctx).Model(&models.Folder{}).
+	if err := r.reader(ctx).Model(&models.Folder{}).
 		Where("parent_id = ? AND tenant_id = ?", parentID, tenantID).
 		Count(&totalItems).Error; err != nil {
 		return utils.PaginatedResult[models.Folder]{}, errors.NewInternalError(fmt.Sprintf("error counting child folders: %v", err))
@@ -223,18 +239,20 @@ func (r *postgresqlFolderRepository) GetRootFolders(ctx context.Context, tenantI
 	}
 
 	var folders []models.Folder
-	query := r.db.WithContext(ctx).Where("parent_id = '' AND tenant_id = ?", tenantID).
-		Order("name ASC").
-		Offset(pagination.GetOffset()).
-		Limit(pagination.GetLimit())
+	keys := folderSort(pagination)
+	query, err := pageQuery(r.reader(ctx).Where("parent_id = '' AND tenant_id = ?", tenantID), pagination, keys, folderSortColumns, folderSortField)
+	if err != nil {
+		return utils.PaginatedResult[models.Folder]{}, err
+	}
 
 	if err := query.Find(&folders).Error; err != nil {
 		return utils.PaginatedResult[models.Folder]{}, errors.NewInternalError(fmt.Sprintf("error fetching root folders: %v", err))
 	}
+	folders = nextPage(folders, pagination, keys, folderSortField)
 
 	// Count total items for pagination
 	var totalItems int64
-	if err := r.db.WithContext(ctx).Model(&models.Folder{}).
+	if err := r.reader(ctx).Model(&models.Folder{}).
 		Where("parent_id = '' AND tenant_id = ?", tenantID).
 		Count(&totalItems).Error; err != nil {
 		return utils.PaginatedResult[models.Folder]{}, errors.NewInternalError(fmt.Sprintf("error counting root folders: %v", err))
@@ -253,7 +271,7 @@ func (r *postgresqlFolderRepository) GetFolderPath(ctx context.Context, id strin
 	}
 
 	var folder models.Folder
-	if err := r.db.WithContext(ctx).Where("id = ? AND tenant_id = ?", id, tenantID).First(&folder).Error; err != nil {
+	if err := r.conn(ctx).Where("id = ? AND tenant_id = ?", id, tenantID).First(&folder).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return "", errors.NewNotFoundError(fmt.Sprintf("folder with ID %s not found", id))
 		}
@@ -273,7 +291,7 @@ func (r *postgresqlFolderRepository) GetByPath(ctx context.Context, path string,
 	}
 
 	var folder models.Folder
-	if err := r.db.WithContext(ctx).Where("path = ? AND tenant_id = ?", path, tenantID).First(&folder).Error; err != nil {
+	if err := r.reader(ctx).Where("path = ? AND tenant_id = ?", path, tenantID).First(&folder).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, errors.NewNotFoundError(fmt.Sprintf("folder with path %s not found", path))
 		}
@@ -294,7 +312,7 @@ func (r *postgresqlFolderRepository) Move(ctx context.Context, id string, newPar
 
 	// Check if folder exists
 	var folder models.Folder
-	if err := r.db.WithContext(ctx).Where("id = ? AND tenant_id = ?", id, tenantID).First(&folder).Error; err != nil {
+	if err := r.conn(ctx).Where("id = ? AND tenant_id = ?", id, tenantID).First(&folder).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return errors.NewNotFoundError(fmt.Sprintf("folder with ID %s not found", id))
 		}
@@ -305,7 +323,7 @@ func (r *postgresqlFolderRepository) Move(ctx context.Context, id string, newPar
 	var newParentPath string
 	if newParentID != "" {
 		var parentFolder models.Folder
-		if err := r.db.WithContext(ctx).Where("id = ? AND tenant_id = ?", newParentID, tenantID).First(&parentFolder).Error; err != nil {
+		if err := r.conn(ctx).Where("id = ? AND tenant_id = ?", newParentID, tenantID).First(&parentFolder).Error; err != nil {
 			if err == gorm.ErrRecordNotFound {
 				return errors.NewNotFoundError(fmt.Sprintf("parent folder with ID %s not found", newParentID))
 			}
@@ -323,12 +341,6 @@ func (r *postgresqlFolderRepository) Move(ctx context.Context, id string, newPar
 		newParentPath = ""
 	}
 
-	// Begin a transaction
-	tx := r.db.WithContext(ctx).Begin()
-	if tx.Error != nil {
-		return errors.NewInternalError(fmt.Sprintf("failed to begin transaction: %v", tx.Error))
-	}
-
 	// Store the old path for updating descendants
 	oldPath := folder.Path
 
@@ -342,29 +354,20 @@ func (r *postgresqlFolderRepository) Move(ctx context.Context, id string, newPar
 		folder.SetPath(folder.BuildPath(newParentPath))
 	}
 
-	// Update the folder
-	if err := tx.Model(&models.Folder{}).Where("id = ? AND tenant_id = ?", id, tenantID).
-		Updates(map[string]interface{}{
-			"parent_id":  folder.ParentID,
-			"path":       folder.Path,
-			"updated_at": folder.UpdatedAt,
-		}).Error; err != nil {
-		tx.Rollback()
-		return errors.NewInternalError(fmt.Sprintf("failed to update folder: %v", err))
-	}
-
-	// Update all descendant folders' paths
-	if err := r.updateDescendantPaths(tx, id, oldPath, folder.Path, tenantID); err != nil {
-		tx.Rollback()
-		return err
-	}
-
-	// Commit the transaction
-	if err := tx.Commit().Error; err != nil {
-		return errors.NewInternalError(fmt.Sprintf("failed to commit transaction: %v", err))
-	}
+	// Move the folder and its descendants within a transaction, joining the caller's transaction if present
+	return r.conn(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Folder{}).Where("id = ? AND tenant_id = ?", id, tenantID).
+			Updates(map[string]interface{}{
+				"parent_id":  folder.ParentID,
+				"path":       folder.Path,
+				"updated_at": folder.UpdatedAt,
+			}).Error; err != nil {
+			return errors.NewInternalError(fmt.Sprintf("failed to update folder: %v", err))
+		}
 
-	return nil
+		// Update all descendant folders' paths
+		return r.updateDescendantPaths(tx, id, oldPath, folder.Path, tenantID)
+	})
 }
 
 // Exists checks if a folder exists by its ID with tenant isolation
@@ -377,7 +380,7 @@ func (r *postgresqlFolderRepository) Exists(ctx context.Context, id string, tena
 	}
 
 	var count int64
-	if err := r.db.WithContext(ctx).Model(&models.Folder{}).
+	if err := r.conn(ctx).Model(&models.Folder{}).
 		Where("id = ? AND tenant_id = ?", id, tenantID).
 		Count(&count).Error; err != nil {
 		return false, errors.NewInternalError(fmt.Sprintf("error checking folder existence: %v", err))
@@ -406,7 +409,7 @@ func (r *postgresqlFolderRepository) IsEmpty(ctx context.Context, id string, ten
 
 	// Check for child folders
 	var folderCount int64
-	if err := r.db.WithContext(ctx).Model(&models.Folder{}).
+	if err := r.conn(ctx).Model(&models.Folder{}).
 		Where("parent_id = ? AND tenant_id = ?", id, tenantID).
 		Count(&folderCount).Error; err != nil {
 		return false, errors.NewInternalError(fmt.Sprintf("error checking child folders: %v", err))
@@ -414,7 +417,7 @@ func (r *postgresqlFolderRepository) IsEmpty(ctx context.Context, id string, ten
 
 	// Check for documents in the folder
 	var documentCount int64
-	if err := r.db.WithContext(ctx).Table("documents").
+	if err := r.conn(ctx).Table("documents").
 		Where("folder_id = ? AND tenant_id = ?", id, tenantID).
 		Count(&documentCount).Error; err != nil {
 		return false, errors.NewInternalError(fmt.Sprintf("error checking documents in folder: %v", err))
@@ -436,7 +439,7 @@ func (r *postgresqlFolderRepository) Search(ctx context.Context, query string, t
 	searchPattern := "%" + query + "%"
 
 	var folders []models.Folder
-	dbQuery := r.db.WithContext(ctx).Where("name LIKE ? AND tenant_id = ?", searchPattern, tenantID).
+	dbQuery := r.reader(ctx).Where("name LIKE ? AND tenant_id = ?", searchPattern, tenantID).
 		Order("name ASC").
 		Offset(pagination.GetOffset()).
 		Limit(pagination.GetLimit())
@@ -447,7 +450,7 @@ func (r *postgresqlFolderRepository) Search(ctx context.Context, query string, t
 
 	// Count total items for pagination
 	var totalItems int64
-	if err := r.db.WithContext(ctx).Model(&models.Folder{}).
+	if err := r.reader(ctx).Model(&models.Folder{}).
 		Where("name LIKE ? AND tenant_id = ?", searchPattern, tenantID).
 		Count(&totalItems).Error; err != nil {
 		return utils.PaginatedResult[models.Folder]{}, errors.NewInternalError(fmt.Sprintf("error counting search results: %v", err))
@@ -0,0 +1,147 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid" // v1.3.0+ - For generating unique IDs for folder templates
+	"gorm.io/gorm"           // v1.25.0+ - ORM library for database operations
+
+	"../../../domain/models"
+	"../../../domain/repositories"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+)
+
+// folderTemplateRepository implements the FolderTemplateRepository interface using PostgreSQL
+type folderTemplateRepository struct{}
+
+// NewFolderTemplateRepository creates a new instance of the PostgreSQL implementation of FolderTemplateRepository
+func NewFolderTemplateRepository() repositories.FolderTemplateRepository {
+	return &folderTemplateRepository{}
+}
+
+// Create persists a new folder template to the database
+func (r *folderTemplateRepository) Create(ctx context.Context, template *models.FolderTemplate) (string, error) {
+	if err := template.Validate(); err != nil {
+		return "", errors.NewValidationError(err.Error())
+	}
+
+	if template.ID == "" {
+		template.ID = uuid.New().String()
+	}
+
+	now := time.Now()
+	if template.CreatedAt.IsZero() {
+		template.CreatedAt = now
+	}
+	if template.UpdatedAt.IsZero() {
+		template.UpdatedAt = now
+	}
+
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if err := db.Create(template).Error; err != nil {
+		if strings.Contains(err.Error(), "folder_templates_tenant_name_idx") {
+			return "", errors.NewValidationError("a folder template with this name already exists")
+		}
+		logger.Error("Failed to create folder template", "error", err, "template_id", template.ID, "tenant_id", template.TenantID)
+		return "", errors.NewInternalError("Failed to create folder template: " + err.Error())
+	}
+
+	return template.ID, nil
+}
+
+// GetByID retrieves a folder template by its ID with tenant isolation
+func (r *folderTemplateRepository) GetByID(ctx context.Context, id string, tenantID string) (*models.FolderTemplate, error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var template models.FolderTemplate
+	if err := db.Where("id = ? AND tenant_id = ?", id, tenantID).First(&template).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewResourceNotFoundError("Folder template not found")
+		}
+		logger.Error("Failed to get folder template", "error", err, "id", id, "tenant_id", tenantID)
+		return nil, errors.NewInternalError("Failed to get folder template: " + err.Error())
+	}
+
+	return &template, nil
+}
+
+// Update updates an existing folder template in the database
+func (r *folderTemplateRepository) Update(ctx context.Context, template *models.FolderTemplate) error {
+	if err := template.Validate(); err != nil {
+		return errors.NewValidationError(err.Error())
+	}
+
+	template.UpdatedAt = time.Now()
+
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Select the mutable columns explicitly; the creator of a template cannot be changed
+	result := db.Model(&models.FolderTemplate{}).
+		Where("id = ? AND tenant_id = ?", template.ID, template.TenantID).
+		Select("name", "description", "permissions", "folders", "updated_at").
+		Updates(template)
+
+	if result.Error != nil {
+		if strings.Contains(result.Error.Error(), "folder_templates_tenant_name_idx") {
+			return errors.NewValidationError("a folder template with this name already exists")
+		}
+		logger.Error("Failed to update folder template", "error", result.Error, "id", template.ID, "tenant_id", template.TenantID)
+		return errors.NewInternalError("Failed to update folder template: " + result.Error.Error())
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.NewResourceNotFoundError("Folder template not found")
+	}
+
+	return nil
+}
+
+// Delete deletes a folder template with tenant isolation
+func (r *folderTemplateRepository) Delete(ctx context.Context, id string, tenantID string) error {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	result := db.Where("id = ? AND tenant_id = ?", id, tenantID).Delete(&models.FolderTemplate{})
+
+	if result.Error != nil {
+		logger.Error("Failed to delete folder template", "error", result.Error, "id", id, "tenant_id", tenantID)
+		return errors.NewInternalError("Failed to delete folder template: " + result.Error.Error())
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.NewResourceNotFoundError("Folder template not found")
+	}
+
+	return nil
+}
+
+// ListByTenant lists all folder templates of a tenant ordered by name
+func (r *folderTemplateRepository) ListByTenant(ctx context.Context, tenantID string) ([]*models.FolderTemplate, error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var templates []*models.FolderTemplate
+	if err := db.Where("tenant_id = ?", tenantID).Order("name ASC").Find(&templates).Error; err != nil {
+		logger.Error("Failed to list folder templates", "error", err, "tenant_id", tenantID)
+		return nil, errors.NewInternalError("Failed to list folder templates: " + err.Error())
+	}
+
+	return templates, nil
+}
@@ -0,0 +1,255 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid" // v1.3.0+ - For generating unique IDs for groups
+	"gorm.io/gorm"           // v1.25.0+ - ORM library for database operations
+	"gorm.io/gorm/clause"    // v1.25.0+ - For ignoring duplicate memberships
+
+	"../../../domain/models"
+	"../../../domain/repositories"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+	"../../../pkg/utils"
+)
+
+// groupRepository implements the GroupRepository interface using PostgreSQL
+type groupRepository struct{}
+
+// NewGroupRepository creates a new instance of the PostgreSQL implementation of GroupRepository
+func NewGroupRepository() repositories.GroupRepository {
+	return &groupRepository{}
+}
+
+// Create persists a new group to the database
+func (r *groupRepository) Create(ctx context.Context, group *models.Group) (string, error) {
+	if err := group.Validate(); err != nil {
+		return "", errors.NewValidationError(err.Error())
+	}
+
+	if group.ID == "" {
+		group.ID = uuid.New().String()
+	}
+
+	now := time.Now()
+	if group.CreatedAt.IsZero() {
+		group.CreatedAt = now
+	}
+	if group.UpdatedAt.IsZero() {
+		group.UpdatedAt = now
+	}
+
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if err := db.Create(group).Error; err != nil {
+		if strings.Contains(err.Error(), "groups_tenant_name_idx") {
+			return "", errors.NewValidationError("a group named " + group.Name + " already exists")
+		}
+		logger.Error("Failed to create group", "error", err, "group_id", group.ID, "tenant_id", group.TenantID)
+		return "", errors.NewInternalError("Failed to create group: " + err.Error())
+	}
+
+	return group.ID, nil
+}
+
+// GetByID retrieves a group by its ID with tenant isolation
+func (r *groupRepository) GetByID(ctx context.Context, id string, tenantID string) (*models.Group, error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var group models.Group
+	if err := db.Where("id = ? AND tenant_id = ?", id, tenantID).First(&group).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewResourceNotFoundError("Group not found")
+		}
+		logger.Error("Failed to get group", "error", err, "id", id, "tenant_id", tenantID)
+		return nil, errors.NewInternalError("Failed to get group: " + err.Error())
+	}
+
+	return &group, nil
+}
+
+// Update updates an existing group's name and description
+func (r *groupRepository) Update(ctx context.Context, group *models.Group) error {
+	if err := group.Validate(); err != nil {
+		return errors.NewValidationError(err.Error())
+	}
+
+	group.UpdatedAt = time.Now()
+
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Ensure tenant isolation by including tenant_id in the update condition
+	result := db.Model(&models.Group{}).
+		Where("id = ? AND tenant_id = ?", group.ID, group.TenantID).
+		Select("name", "description", "updated_at").
+		Updates(group)
+
+	if result.Error != nil {
+		if strings.Contains(result.Error.Error(), "groups_tenant_name_idx") {
+			return errors.NewValidationError("a group named " + group.Name + " already exists")
+		}
+		logger.Error("Failed to update group", "error", result.Error, "id", group.ID, "tenant_id", group.TenantID)
+		return errors.NewInternalError("Failed to update group: " + result.Error.Error())
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.NewResourceNotFoundError("Group not found")
+	}
+
+	return nil
+}
+
+// Delete deletes a group with tenant isolation. Memberships and group grants are removed by the foreign key cascade.
+func (r *groupRepository) Delete(ctx context.Context, id string, tenantID string) error {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	result := db.Where("id = ? AND tenant_id = ?", id, tenantID).Delete(&models.Group{})
+
+	if result.Error != nil {
+		logger.Error("Failed to delete group", "error", result.Error, "id", id, "tenant_id", tenantID)
+		return errors.NewInternalError("Failed to delete group: " + result.Error.Error())
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.NewResourceNotFoundError("Group not found")
+	}
+
+	return nil
+}
+
+// List lists a tenant's groups with pagination
+func (r *groupRepository) List(ctx context.Context, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Group], error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return utils.PaginatedResult[models.Group]{}, err
+	}
+
+	var groups []models.Group
+	var totalItems int64
+
+	if err := db.Model(&models.Group{}).
+		Where("tenant_id = ?", tenantID).
+		Count(&totalItems).Error; err != nil {
+		logger.Error("Failed to count groups", "error", err, "tenant_id", tenantID)
+		return utils.PaginatedResult[models.Group]{}, errors.NewInternalError("Failed to count groups: " + err.Error())
+	}
+
+	if err := db.
+		Where("tenant_id = ?", tenantID).
+		Offset(pagination.GetOffset()).
+		Limit(pagination.GetLimit()).
+		Order("name ASC").
+		Find(&groups).Error; err != nil {
+		logger.Error("Failed to list groups", "error", err, "tenant_id", tenantID)
+		return utils.PaginatedResult[models.Group]{}, errors.NewInternalError("Failed to list groups: " + err.Error())
+	}
+
+	return utils.NewPaginatedResult(groups, pagination, totalItems), nil
+}
+
+// AddMember adds a user to a group. Adding an existing member is a no-op.
+func (r *groupRepository) AddMember(ctx context.Context, membership *models.GroupMembership) error {
+	if err := membership.Validate(); err != nil {
+		return errors.NewValidationError(err.Error())
+	}
+
+	if membership.CreatedAt.IsZero() {
+		membership.CreatedAt = time.Now()
+	}
+
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(membership).Error; err != nil {
+		logger.Error("Failed to add group member", "error", err, "group_id", membership.GroupID, "user_id", membership.UserID, "tenant_id", membership.TenantID)
+		return errors.NewInternalError("Failed to add group member: " + err.Error())
+	}
+
+	return nil
+}
+
+// RemoveMember removes a user from a group with tenant isolation
+func (r *groupRepository) RemoveMember(ctx context.Context, groupID, userID, tenantID string) error {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	result := db.Where("group_id = ? AND user_id = ? AND tenant_id = ?", groupID, userID, tenantID).Delete(&models.GroupMembership{})
+
+	if result.Error != nil {
+		logger.Error("Failed to remove group member", "error", result.Error, "group_id", groupID, "user_id", userID, "tenant_id", tenantID)
+		return errors.NewInternalError("Failed to remove group member: " + result.Error.Error())
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.NewResourceNotFoundError("Group member not found")
+	}
+
+	return nil
+}
+
+// ListMembers lists the members of a group with pagination
+func (r *groupRepository) ListMembers(ctx context.Context, groupID, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.GroupMembership], error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return utils.PaginatedResult[models.GroupMembership]{}, err
+	}
+
+	var members []models.GroupMembership
+	var totalItems int64
+
+	if err := db.Model(&models.GroupMembership{}).
+		Where("group_id = ? AND tenant_id = ?", groupID, tenantID).
+		Count(&totalItems).Error; err != nil {
+		logger.Error("Failed to count group members", "error", err, "group_id", groupID, "tenant_id", tenantID)
+		return utils.PaginatedResult[models.GroupMembership]{}, errors.NewInternalError("Failed to count group members: " + err.Error())
+	}
+
+	if err := db.
+		Where("group_id = ? AND tenant_id = ?", groupID, tenantID).
+		Offset(pagination.GetOffset()).
+		Limit(pagination.GetLimit()).
+		Order("created_at ASC").
+		Find(&members).Error; err != nil {
+		logger.Error("Failed to list group members", "error", err, "group_id", groupID, "tenant_id", tenantID)
+		return utils.PaginatedResult[models.GroupMembership]{}, errors.NewInternalError("Failed to list group members: " + err.Error())
+	}
+
+	return utils.NewPaginatedResult(members, pagination, totalItems), nil
+}
+
+// GetGroupIDsForUser returns the IDs of the groups a user is a member of
+func (r *groupRepository) GetGroupIDsForUser(ctx context.Context, userID, tenantID string) ([]string, error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var groupIDs []string
+	if err := db.Model(&models.GroupMembership{}).
+		Where("user_id = ? AND tenant_id = ?", userID, tenantID).
+		Pluck("group_id", &groupIDs).Error; err != nil {
+		logger.Error("Failed to get user groups", "error", err, "user_id", userID, "tenant_id", tenantID)
+		return nil, errors.NewInternalError("Failed to get user groups: " + err.Error())
+	}
+
+	return groupIDs, nil
+}
@@ -0,0 +1,147 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid" // v1.3.0+ - For generating unique IDs for metadata fields
+	"gorm.io/gorm"           // v1.25.0+ - ORM library for database operations
+
+	"../../../domain/models"
+	"../../../domain/repositories"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+)
+
+// metadataFieldRepository implements the MetadataFieldRepository interface using PostgreSQL
+type metadataFieldRepository struct{}
+
+// NewMetadataFieldRepository creates a new instance of the PostgreSQL implementation of MetadataFieldRepository
+func NewMetadataFieldRepository() repositories.MetadataFieldRepository {
+	return &metadataFieldRepository{}
+}
+
+// Create persists a new metadata field to the database
+func (r *metadataFieldRepository) Create(ctx context.Context, field *models.MetadataField) (string, error) {
+	if err := field.Validate(); err != nil {
+		return "", errors.NewValidationError(err.Error())
+	}
+
+	if field.ID == "" {
+		field.ID = uuid.New().String()
+	}
+
+	now := time.Now()
+	if field.CreatedAt.IsZero() {
+		field.CreatedAt = now
+	}
+	if field.UpdatedAt.IsZero() {
+		field.UpdatedAt = now
+	}
+
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if err := db.Create(field).Error; err != nil {
+		if strings.Contains(err.Error(), "metadata_fields_tenant_name_idx") {
+			return "", errors.NewValidationError("a metadata field named " + field.Name + " already exists")
+		}
+		logger.Error("Failed to create metadata field", "error", err, "field_id", field.ID, "tenant_id", field.TenantID)
+		return "", errors.NewInternalError("Failed to create metadata field: " + err.Error())
+	}
+
+	return field.ID, nil
+}
+
+// GetByID retrieves a metadata field by its ID with tenant isolation
+func (r *metadataFieldRepository) GetByID(ctx context.Context, id string, tenantID string) (*models.MetadataField, error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var field models.MetadataField
+	if err := db.Where("id = ? AND tenant_id = ?", id, tenantID).First(&field).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewResourceNotFoundError("Metadata field not found")
+		}
+		logger.Error("Failed to get metadata field", "error", err, "id", id, "tenant_id", tenantID)
+		return nil, errors.NewInternalError("Failed to get metadata field: " + err.Error())
+	}
+
+	return &field, nil
+}
+
+// Update updates an existing metadata field in the database
+func (r *metadataFieldRepository) Update(ctx context.Context, field *models.MetadataField) error {
+	if err := field.Validate(); err != nil {
+		return errors.NewValidationError(err.Error())
+	}
+
+	field.UpdatedAt = time.Now()
+
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Select the mutable columns explicitly so that making a field optional is persisted
+	result := db.Model(&models.MetadataField{}).
+		Where("id = ? AND tenant_id = ?", field.ID, field.TenantID).
+		Select("name", "description", "type", "required", "allowed_values", "updated_at").
+		Updates(field)
+
+	if result.Error != nil {
+		if strings.Contains(result.Error.Error(), "metadata_fields_tenant_name_idx") {
+			return errors.NewValidationError("a metadata field named " + field.Name + " already exists")
+		}
+		logger.Error("Failed to update metadata field", "error", result.Error, "id", field.ID, "tenant_id", field.TenantID)
+		return errors.NewInternalError("Failed to update metadata field: " + result.Error.Error())
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.NewResourceNotFoundError("Metadata field not found")
+	}
+
+	return nil
+}
+
+// Delete deletes a metadata field with tenant isolation
+func (r *metadataFieldRepository) Delete(ctx context.Context, id string, tenantID string) error {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	result := db.Where("id = ? AND tenant_id = ?", id, tenantID).Delete(&models.MetadataField{})
+
+	if result.Error != nil {
+		logger.Error("Failed to delete metadata field", "error", result.Error, "id", id, "tenant_id", tenantID)
+		return errors.NewInternalError("Failed to delete metadata field: " + result.Error.Error())
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.NewResourceNotFoundError("Metadata field not found")
+	}
+
+	return nil
+}
+
+// ListByTenant lists all metadata fields of a tenant ordered by name
+func (r *metadataFieldRepository) ListByTenant(ctx context.Context, tenantID string) ([]*models.MetadataField, error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields []*models.MetadataField
+	if err := db.Where("tenant_id = ?", tenantID).Order("name ASC").Find(&fields).Error; err != nil {
+		logger.Error("Failed to list metadata fields", "error", err, "tenant_id", tenantID)
+		return nil, errors.NewInternalError("Failed to list metadata fields: " + err.Error())
+	}
+
+	return fields, nil
+}
@@ -0,0 +1,154 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid" // v1.3.0+ - For generating unique IDs for metadata templates
+	"gorm.io/gorm"           // v1.25.0+ - ORM library for database operations
+
+	"../../../domain/models"
+	"../../../domain/repositories"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+)
+
+// metadataTemplateRepository implements the MetadataTemplateRepository interface using PostgreSQL
+type metadataTemplateRepository struct{}
+
+// NewMetadataTemplateRepository creates a new instance of the PostgreSQL implementation of MetadataTemplateRepository
+func NewMetadataTemplateRepository() repositories.MetadataTemplateRepository {
+	return &metadataTemplateRepository{}
+}
+
+// Create persists a new metadata template to the database
+func (r *metadataTemplateRepository) Create(ctx context.Context, template *models.MetadataTemplate) (string, error) {
+	if err := template.Validate(); err != nil {
+		return "", errors.NewValidationError(err.Error())
+	}
+
+	if template.ID == "" {
+		template.ID = uuid.New().String()
+	}
+
+	now := time.Now()
+	if template.CreatedAt.IsZero() {
+		template.CreatedAt = now
+	}
+	if template.UpdatedAt.IsZero() {
+		template.UpdatedAt = now
+	}
+
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if err := db.Create(template).Error; err != nil {
+		if strings.Contains(err.Error(), "metadata_templates_tenant_folder_idx") {
+			return "", errors.NewValidationError("the folder already has a metadata template")
+		}
+		logger.Error("Failed to create metadata template", "error", err, "template_id", template.ID, "tenant_id", template.TenantID)
+		return "", errors.NewInternalError("Failed to create metadata template: " + err.Error())
+	}
+
+	return template.ID, nil
+}
+
+// GetByID retrieves a metadata template by its ID with tenant isolation
+func (r *metadataTemplateRepository) GetByID(ctx context.Context, id string, tenantID string) (*models.MetadataTemplate, error) {
+	return r.getWhere(ctx, "id = ? AND tenant_id = ?", id, tenantID)
+}
+
+// GetByFolder retrieves the metadata template of a folder with tenant isolation
+func (r *metadataTemplateRepository) GetByFolder(ctx context.Context, folderID string, tenantID string) (*models.MetadataTemplate, error) {
+	return r.getWhere(ctx, "folder_id = ? AND tenant_id = ?", folderID, tenantID)
+}
+
+// getWhere retrieves the metadata template matching a condition on an ID and the tenant ID
+func (r *metadataTemplateRepository) getWhere(ctx context.Context, condition string, id string, tenantID string) (*models.MetadataTemplate, error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var template models.MetadataTemplate
+	if err := db.Where(condition, id, tenantID).First(&template).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewResourceNotFoundError("Metadata template not found")
+		}
+		logger.Error("Failed to get metadata template", "error", err, "id", id, "tenant_id", tenantID)
+		return nil, errors.NewInternalError("Failed to get metadata template: " + err.Error())
+	}
+
+	return &template, nil
+}
+
+// Update updates an existing metadata template in the database
+func (r *metadataTemplateRepository) Update(ctx context.Context, template *models.MetadataTemplate) error {
+	if err := template.Validate(); err != nil {
+		return errors.NewValidationError(err.Error())
+	}
+
+	template.UpdatedAt = time.Now()
+
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Select the mutable columns explicitly; the folder of a template cannot be changed
+	result := db.Model(&models.MetadataTemplate{}).
+		Where("id = ? AND tenant_id = ?", template.ID, template.TenantID).
+		Select("name", "description", "fields", "mode", "updated_at").
+		Updates(template)
+
+	if result.Error != nil {
+		logger.Error("Failed to update metadata template", "error", result.Error, "id", template.ID, "tenant_id", template.TenantID)
+		return errors.NewInternalError("Failed to update metadata template: " + result.Error.Error())
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.NewResourceNotFoundError("Metadata template not found")
+	}
+
+	return nil
+}
+
+// Delete deletes a metadata template with tenant isolation
+func (r *metadataTemplateRepository) Delete(ctx context.Context, id string, tenantID string) error {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	result := db.Where("id = ? AND tenant_id = ?", id, tenantID).Delete(&models.MetadataTemplate{})
+
+	if result.Error != nil {
+		logger.Error("Failed to delete metadata template", "error", result.Error, "id", id, "tenant_id", tenantID)
+		return errors.NewInternalError("Failed to delete metadata template: " + result.Error.Error())
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.NewResourceNotFoundError("Metadata template not found")
+	}
+
+	return nil
+}
+
+// ListByTenant lists all metadata templates of a tenant ordered by name
+func (r *metadataTemplateRepository) ListByTenant(ctx context.Context, tenantID string) ([]*models.MetadataTemplate, error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var templates []*models.MetadataTemplate
+	if err := db.Where("tenant_id = ?", tenantID).Order("name ASC").Find(&templates).Error; err != nil {
+		logger.Error("Failed to list metadata templates", "error", err, "tenant_id", tenantID)
+		return nil, errors.NewInternalError("Failed to list metadata templates: " + err.Error())
+	}
+
+	return templates, nil
+}
@@ -0,0 +1,136 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid" // v1.3.0+ - For generating unique IDs for MFA enrollments
+	"gorm.io/gorm"           // v1.25.0+ - ORM library for database operations
+
+	"../../../domain/models"
+	"../../../domain/repositories"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+)
+
+// mfaRepository implements the MFARepository interface using PostgreSQL
+type mfaRepository struct{}
+
+// NewMFARepository creates a new instance of the PostgreSQL implementation of MFARepository
+func NewMFARepository() repositories.MFARepository {
+	return &mfaRepository{}
+}
+
+// Create persists a new MFA enrollment, replacing any pending enrollment of the user.
+// Enabled enrollments are never replaced.
+func (r *mfaRepository) Create(ctx context.Context, enrollment *models.MFAEnrollment) (string, error) {
+	if enrollment.CreatedAt.IsZero() {
+		enrollment.CreatedAt = time.Now()
+	}
+
+	if err := enrollment.Validate(); err != nil {
+		return "", errors.NewValidationError(err.Error())
+	}
+
+	if enrollment.ID == "" {
+		enrollment.ID = uuid.New().String()
+	}
+
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		var existing models.MFAEnrollment
+		err := tx.Where("user_id = ? AND tenant_id = ?", enrollment.UserID, enrollment.TenantID).First(&existing).Error
+		if err == nil {
+			if existing.IsEnabled() {
+				return errors.NewValidationError("multi-factor authentication is already enabled")
+			}
+			if err := tx.Delete(&existing).Error; err != nil {
+				return err
+			}
+		} else if err != gorm.ErrRecordNotFound {
+			return err
+		}
+
+		return tx.Create(enrollment).Error
+	})
+	if err != nil {
+		if errors.IsValidationError(err) {
+			return "", err
+		}
+		logger.Error("Failed to create MFA enrollment", "error", err, "user_id", enrollment.UserID, "tenant_id", enrollment.TenantID)
+		return "", errors.NewInternalError("Failed to create MFA enrollment: " + err.Error())
+	}
+
+	return enrollment.ID, nil
+}
+
+// GetByUserID retrieves a user's MFA enrollment with tenant isolation
+func (r *mfaRepository) GetByUserID(ctx context.Context, userID string, tenantID string) (*models.MFAEnrollment, error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var enrollment models.MFAEnrollment
+	if err := db.Where("user_id = ? AND tenant_id = ?", userID, tenantID).First(&enrollment).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewResourceNotFoundError("MFA enrollment not found")
+		}
+		logger.Error("Failed to get MFA enrollment", "error", err, "user_id", userID, "tenant_id", tenantID)
+		return nil, errors.NewInternalError("Failed to get MFA enrollment: " + err.Error())
+	}
+
+	return &enrollment, nil
+}
+
+// Update stores an enrollment's enabled time, backup codes, last used time step and failed attempts
+func (r *mfaRepository) Update(ctx context.Context, enrollment *models.MFAEnrollment) error {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	result := db.Model(&models.MFAEnrollment{}).
+		Where("id = ? AND tenant_id = ?", enrollment.ID, enrollment.TenantID).
+		Updates(map[string]interface{}{
+			"enabled_at":         enrollment.EnabledAt,
+			"backup_code_hashes": enrollment.BackupCodeHashes,
+			"last_used_step":     enrollment.LastUsedStep,
+			"failed_attempts":    enrollment.FailedAttempts,
+		})
+
+	if result.Error != nil {
+		logger.Error("Failed to update MFA enrollment", "error", result.Error, "id", enrollment.ID, "tenant_id", enrollment.TenantID)
+		return errors.NewInternalError("Failed to update MFA enrollment: " + result.Error.Error())
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.NewResourceNotFoundError("MFA enrollment not found")
+	}
+
+	return nil
+}
+
+// Delete removes a user's MFA enrollment with tenant isolation
+func (r *mfaRepository) Delete(ctx context.Context, userID string, tenantID string) error {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	result := db.Where("user_id = ? AND tenant_id = ?", userID, tenantID).Delete(&models.MFAEnrollment{})
+	if result.Error != nil {
+		logger.Error("Failed to delete MFA enrollment", "error", result.Error, "user_id", userID, "tenant_id", tenantID)
+		return errors.NewInternalError("Failed to delete MFA enrollment: " + result.Error.Error())
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.NewResourceNotFoundError("MFA enrollment not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,491 @@
+package postgres
+
+import (
+	"context"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm" // v1.25.0+
+
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+)
+
+// migrationFiles are the SQL migrations built into the binary, so the schema a release expects
+// ships with it
+//
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+const (
+	// migrationHistoryTable records the migrations applied to the database with their checksum
+	migrationHistoryTable = "schema_migration_history"
+
+	// legacyMigrationTable is the single-row version table of the golang-migrate CLI the migrations
+	// were applied with before, adopted into the history the first time the migrations run
+	legacyMigrationTable = "schema_migrations"
+
+	// migrationLockID is the key of the advisory lock held while migrating, so instances starting
+	// together apply each migration once
+	migrationLockID = 7261904
+)
+
+// createMigrationHistoryQuery creates the migration history table when it does not exist
+const createMigrationHistoryQuery = `CREATE TABLE IF NOT EXISTS ` + migrationHistoryTable + ` (
+	version BIGINT PRIMARY KEY,
+	name VARCHAR(255) NOT NULL,
+	checksum CHAR(64) NOT NULL,
+	applied_at TIMESTAMP NOT NULL
+)`
+
+// migrationFileName matches the files of a migration, such as 01_initial_schema.up.sql
+var migrationFileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Migration is a versioned schema change with the SQL applying and reverting it
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+
+	// Checksum is the SHA-256 of the up SQL, recorded when the migration is applied so that
+	// editing it afterwards is detected
+	Checksum string
+}
+
+// AppliedMigration is a migration recorded in the migration history
+type AppliedMigration struct {
+	Version   int `gorm:"primaryKey;autoIncrement:false"`
+	Name      string
+	Checksum  string
+	AppliedAt time.Time
+}
+
+// TableName returns the name of the migration history table
+func (AppliedMigration) TableName() string {
+	return migrationHistoryTable
+}
+
+// MigrationStatus is a migration and, when it is applied, when and whether it was edited since
+type MigrationStatus struct {
+	Migration
+	AppliedAt *time.Time
+	Modified  bool
+}
+
+// LoadMigrations reads the migrations of dir in fsys, sorted by version. Each version must have
+// both an up and a down file, so every schema change can be reverted.
+func LoadMigrations(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, errors.NewInternalError(fmt.Sprintf("failed to read migrations: %v", err))
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sql") {
+			continue
+		}
+		match := migrationFileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, errors.NewValidationError(fmt.Sprintf("migration file %s is not named VERSION_name.up.sql or VERSION_name.down.sql", entry.Name()))
+		}
+		version, _ := strconv.Atoi(match[1])
+		content, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, errors.NewInternalError(fmt.Sprintf("failed to read migration %s: %v", entry.Name(), err))
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		} else if migration.Name != match[2] {
+			return nil, errors.NewValidationError(fmt.Sprintf("migrations %s and %s share version %d", migration.Name, match[2], version))
+		}
+		if match[3] == "up" {
+			migration.Up = string(content)
+			migration.Checksum = checksum(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, errors.NewValidationError(fmt.Sprintf("migration %d_%s needs both an up and a down file", migration.Version, migration.Name))
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// EmbeddedMigrations returns the migrations built into the binary
+func EmbeddedMigrations() ([]Migration, error) {
+	return LoadMigrations(migrationFiles, "migrations")
+}
+
+// checksum returns the hex-encoded SHA-256 of content
+func checksum(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// Migrator applies and reverts migrations, recording each one with its checksum in the migration
+// history in the transaction that runs it, so a failed migration leaves no partial schema change.
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+// NewMigrator creates a migrator applying migrations to db
+func NewMigrator(db *gorm.DB, migrations []Migration) *Migrator {
+	return &Migrator{db: db, migrations: migrations}
+}
+
+// Status returns each migration and whether it is applied
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	var applied map[int]AppliedMigration
+	err := m.withLock(ctx, func(db *gorm.DB) (err error) {
+		applied, err = m.applied(db)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := MigrationStatus{Migration: migration}
+		if record, ok := applied[migration.Version]; ok {
+			appliedAt := record.AppliedAt
+			status.AppliedAt = &appliedAt
+			status.Modified = record.Checksum != migration.Checksum
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// Verify checks that every applied migration still has its file, with the checksum it was applied
+// with. A migration edited after it was applied no longer describes the schema of the databases
+// it ran on, and needs a new migration instead.
+func (m *Migrator) Verify(ctx context.Context) error {
+	return m.withLock(ctx, func(db *gorm.DB) error {
+		applied, err := m.applied(db)
+		if err != nil {
+			return err
+		}
+		return m.verify(applied)
+	})
+}
+
+// Pending returns the migrations that are not applied, in the order they would be applied
+func (m *Migrator) Pending(ctx context.Context) ([]Migration, error) {
+	var pending []Migration
+	err := m.withLock(ctx, func(db *gorm.DB) error {
+		applied, err := m.applied(db)
+		if err != nil {
+			return err
+		}
+		pending = m.pending(applied)
+		return nil
+	})
+	return pending, err
+}
+
+// Up applies up to steps pending migrations, or all of them when steps is 0, after verifying the
+// applied ones. It returns the migrations it applied.
+func (m *Migrator) Up(ctx context.Context, steps int) ([]Migration, error) {
+	var done []Migration
+	err := m.withLock(ctx, func(db *gorm.DB) error {
+		applied, err := m.applied(db)
+		if err != nil {
+			return err
+		}
+		if err := m.verify(applied); err != nil {
+			return err
+		}
+
+		pending := m.pending(applied)
+		if steps > 0 && steps < len(pending) {
+			pending = pending[:steps]
+		}
+		for _, migration := range pending {
+			logger.Info("Applying database migration", "version", migration.Version, "name", migration.Name)
+			err := m.run(db, migration.Up, func(tx *gorm.DB) error {
+				return tx.Create(&AppliedMigration{
+					Version:   migration.Version,
+					Name:      migration.Name,
+					Checksum:  migration.Checksum,
+					AppliedAt: time.Now().UTC(),
+				}).Error
+			})
+			if err != nil {
+				return errors.NewDependencyError(fmt.Sprintf("failed to apply migration %d_%s: %v", migration.Version, migration.Name, err))
+			}
+			done = append(done, migration)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Down reverts the steps most recently applied migrations, newest first, and returns them
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	if steps <= 0 {
+		return nil, errors.NewValidationError("the number of migrations to revert must be positive")
+	}
+
+	var done []Migration
+	err := m.withLock(ctx, func(db *gorm.DB) error {
+		applied, err := m.applied(db)
+		if err != nil {
+			return err
+		}
+		if err := m.verify(applied); err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && len(done) < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := applied[migration.Version]; !ok {
+				continue
+			}
+			logger.Info("Reverting database migration", "version", migration.Version, "name", migration.Name)
+			err := m.run(db, migration.Down, func(tx *gorm.DB) error {
+				return tx.Delete(&AppliedMigration{}, "version = ?", migration.Version).Error
+			})
+			if err != nil {
+				return errors.NewDependencyError(fmt.Sprintf("failed to revert migration %d_%s: %v", migration.Version, migration.Name, err))
+			}
+			done = append(done, migration)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Baseline records the migrations up to version as applied without running them, for databases
+// whose schema was created before the migration history existed. It refuses to rewrite a history
+// that already has migrations.
+func (m *Migrator) Baseline(ctx context.Context, version int) ([]Migration, error) {
+	if version <= 0 {
+		return nil, errors.NewValidationError("the baseline version must be positive")
+	}
+
+	var done []Migration
+	err := m.withLock(ctx, func(db *gorm.DB) error {
+		applied, err := m.applied(db)
+		if err != nil {
+			return err
+		}
+		if len(applied) > 0 {
+			return errors.NewValidationError("the migration history already has applied migrations")
+		}
+
+		for _, migration := range m.migrations {
+			if migration.Version <= version {
+				done = append(done, migration)
+			}
+		}
+		return db.Transaction(func(tx *gorm.DB) error {
+			return recordApplied(tx, done)
+		})
+	})
+	return done, err
+}
+
+// applied returns the migrations of the history by version, creating the history on first use
+func (m *Migrator) applied(db *gorm.DB) (map[int]AppliedMigration, error) {
+	if err := db.Exec(createMigrationHistoryQuery).Error; err != nil {
+		return nil, errors.NewDependencyError(fmt.Sprintf("failed to create migration history: %v", err))
+	}
+	if err := m.adoptLegacyVersion(db); err != nil {
+		return nil, err
+	}
+
+	var records []AppliedMigration
+	if err := db.Order("version").Find(&records).Error; err != nil {
+		return nil, errors.NewDependencyError(fmt.Sprintf("failed to read migration history: %v", err))
+	}
+	applied := make(map[int]AppliedMigration, len(records))
+	for _, record := range records {
+		applied[record.Version] = record
+	}
+	return applied, nil
+}
+
+// adoptLegacyVersion records the migrations up to the version of the golang-migrate table as
+// applied when the history is empty, so databases migrated with the CLI carry on from where they
+// were. A dirty version means the CLI failed halfway through a migration, which needs fixing by
+// hand first.
+func (m *Migrator) adoptLegacyVersion(db *gorm.DB) error {
+	if !db.Migrator().HasTable(legacyMigrationTable) {
+		return nil
+	}
+	var count int64
+	if err := db.Model(&AppliedMigration{}).Count(&count).Error; err != nil || count > 0 {
+		return err
+	}
+
+	var legacy struct {
+		Version int
+		Dirty   bool
+	}
+	result := db.Table(legacyMigrationTable).Select("version", "dirty").Limit(1).Find(&legacy)
+	if result.Error != nil {
+		return errors.NewDependencyError(fmt.Sprintf("failed to read %s: %v", legacyMigrationTable, result.Error))
+	}
+	if result.RowsAffected == 0 {
+		return nil
+	}
+	if legacy.Dirty {
+		return errors.NewValidationError(fmt.Sprintf("%s is dirty at version %d; fix the schema and clear the flag before migrating", legacyMigrationTable, legacy.Version))
+	}
+
+	var adopted []Migration
+	for _, migration := range m.migrations {
+		if migration.Version <= legacy.Version {
+			adopted = append(adopted, migration)
+		}
+	}
+	logger.Info("Adopting migrations applied with golang-migrate", "version", legacy.Version, "count", len(adopted))
+	return db.Transaction(func(tx *gorm.DB) error {
+		return recordApplied(tx, adopted)
+	})
+}
+
+// verify returns an error naming the applied migrations that were edited or removed
+func (m *Migrator) verify(applied map[int]AppliedMigration) error {
+	known := make(map[int]Migration, len(m.migrations))
+	for _, migration := range m.migrations {
+		known[migration.Version] = migration
+	}
+
+	var problems []string
+	for _, record := range applied {
+		migration, ok := known[record.Version]
+		switch {
+		case !ok:
+			problems = append(problems, fmt.Sprintf("%d_%s is applied but has no migration file", record.Version, record.Name))
+		case migration.Checksum != record.Checksum:
+			problems = append(problems, fmt.Sprintf("%d_%s was edited after it was applied", record.Version, record.Name))
+		}
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	sort.Strings(problems)
+	return errors.NewValidationError("migration verification failed: " + strings.Join(problems, "; "))
+}
+
+// pending returns the migrations missing from applied, by version
+func (m *Migrator) pending(applied map[int]AppliedMigration) []Migration {
+	var pending []Migration
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; !ok {
+			pending = append(pending, migration)
+		}
+	}
+	return pending
+}
+
+// run executes the SQL of a migration and records it in the history within one transaction. On
+// PostgreSQL the statement timeout of the pool is lifted for the transaction, as rewriting a large
+// table can take longer than any request should.
+func (m *Migrator) run(db *gorm.DB, sql string, record func(tx *gorm.DB) error) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if tx.Dialector.Name() == "postgres" {
+			if err := tx.Exec("SET LOCAL statement_timeout = 0").Error; err != nil {
+				return err
+			}
+		}
+		// The SQL goes to the connection as is, as GORM would read ? and @name in it as parameters
+		if _, err := tx.Statement.ConnPool.ExecContext(tx.Statement.Context, sql); err != nil {
+			return err
+		}
+		return record(tx)
+	})
+}
+
+// withLock runs fn on a single connection holding the migration advisory lock on PostgreSQL, so
+// concurrent migrators wait for each other rather than applying the same migration twice
+func (m *Migrator) withLock(ctx context.Context, fn func(db *gorm.DB) error) error {
+	db := m.db.WithContext(ctx)
+	if db.Dialector.Name() != "postgres" {
+		return fn(db)
+	}
+
+	return db.Connection(func(conn *gorm.DB) error {
+		if err := conn.Exec("SELECT pg_advisory_lock(?)", migrationLockID).Error; err != nil {
+			return errors.NewDependencyError(fmt.Sprintf("failed to acquire migration lock: %v", err))
+		}
+		defer conn.Exec("SELECT pg_advisory_unlock(?)", migrationLockID)
+		return fn(conn)
+	})
+}
+
+// recordApplied records migrations as applied in the history
+func recordApplied(tx *gorm.DB, migrations []Migration) error {
+	now := time.Now().UTC()
+	for _, migration := range migrations {
+		err := tx.Create(&AppliedMigration{
+			Version:   migration.Version,
+			Name:      migration.Name,
+			Checksum:  migration.Checksum,
+			AppliedAt: now,
+		}).Error
+		if err != nil {
+			return errors.NewDependencyError(fmt.Sprintf("failed to record migration %d_%s: %v", migration.Version, migration.Name, err))
+		}
+	}
+	return nil
+}
+
+// CheckMigrations verifies the migrations applied to the database of Init and fails when some
+// are pending, applying them first when apply is set. A service refuses to start on a schema
+// older than its code, or on migrations edited after they were applied.
+func CheckMigrations(ctx context.Context, apply bool) error {
+	db, err := GetDB()
+	if err != nil {
+		return err
+	}
+	migrations, err := EmbeddedMigrations()
+	if err != nil {
+		return err
+	}
+
+	migrator := NewMigrator(db, migrations)
+	if apply {
+		done, err := migrator.Up(ctx, 0)
+		if err != nil {
+			return err
+		}
+		logger.Info("Database migrations completed successfully", "applied", len(done))
+		return nil
+	}
+
+	if err := migrator.Verify(ctx); err != nil {
+		return err
+	}
+	pending, err := migrator.Pending(ctx)
+	if err != nil {
+		return err
+	}
+	if len(pending) > 0 {
+		return errors.NewDependencyError(fmt.Sprintf("%d database migrations are pending, starting with %d_%s; apply them with -service=migrate up", len(pending), pending[0].Version, pending[0].Name))
+	}
+	return nil
+}
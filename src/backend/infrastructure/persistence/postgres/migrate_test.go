@@ -0,0 +1,156 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"  // v1.8.0+
+	"github.com/stretchr/testify/require" // v1.8.0+
+)
+
+// testMigrations returns migrations creating and altering a table
+func testMigrations() fstest.MapFS {
+	return fstest.MapFS{
+		"migrations/01_create_items.up.sql":   {Data: []byte("CREATE TABLE items (id INTEGER PRIMARY KEY);")},
+		"migrations/01_create_items.down.sql": {Data: []byte("DROP TABLE items;")},
+		"migrations/02_add_name.up.sql":       {Data: []byte("ALTER TABLE items ADD COLUMN name TEXT;")},
+		"migrations/02_add_name.down.sql":     {Data: []byte("ALTER TABLE items DROP COLUMN name;")},
+	}
+}
+
+// TestLoadMigrations tests that migrations are sorted by version and need both directions
+func TestLoadMigrations(t *testing.T) {
+	migrations, err := LoadMigrations(testMigrations(), "migrations")
+	require.NoError(t, err)
+	require.Len(t, migrations, 2)
+	assert.Equal(t, 1, migrations[0].Version)
+	assert.Equal(t, "create_items", migrations[0].Name)
+	assert.Equal(t, "DROP TABLE items;", migrations[0].Down)
+	assert.Len(t, migrations[0].Checksum, 64)
+
+	fsys := testMigrations()
+	delete(fsys, "migrations/02_add_name.down.sql")
+	_, err = LoadMigrations(fsys, "migrations")
+	assert.Error(t, err)
+
+	fsys = testMigrations()
+	fsys["migrations/add_tags.up.sql"] = &fstest.MapFile{Data: []byte("SELECT 1;")}
+	_, err = LoadMigrations(fsys, "migrations")
+	assert.Error(t, err)
+}
+
+// TestEmbeddedMigrations tests that the migrations shipped with the binary load
+func TestEmbeddedMigrations(t *testing.T) {
+	migrations, err := EmbeddedMigrations()
+	require.NoError(t, err)
+	assert.NotEmpty(t, migrations)
+}
+
+// TestMigratorUpDown tests that migrations are applied and reverted in order and recorded
+func TestMigratorUpDown(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	migrations, err := LoadMigrations(testMigrations(), "migrations")
+	require.NoError(t, err)
+	migrator := NewMigrator(db, migrations)
+
+	done, err := migrator.Up(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, done, 1)
+	pending, err := migrator.Pending(ctx)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, 2, pending[0].Version)
+
+	done, err = migrator.Up(ctx, 0)
+	require.NoError(t, err)
+	require.Len(t, done, 1)
+	assert.NoError(t, db.Exec("INSERT INTO items (id, name) VALUES (1, 'first')").Error)
+
+	statuses, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	for _, status := range statuses {
+		assert.NotNil(t, status.AppliedAt)
+		assert.False(t, status.Modified)
+	}
+
+	done, err = migrator.Down(ctx, 2)
+	require.NoError(t, err)
+	require.Len(t, done, 2)
+	assert.Equal(t, 2, done[0].Version)
+	assert.False(t, db.Migrator().HasTable("items"))
+
+	_, err = migrator.Down(ctx, 0)
+	assert.Error(t, err)
+}
+
+// TestMigratorVerify tests that editing an applied migration stops further migrations
+func TestMigratorVerify(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	migrations, err := LoadMigrations(testMigrations(), "migrations")
+	require.NoError(t, err)
+	_, err = NewMigrator(db, migrations).Up(ctx, 1)
+	require.NoError(t, err)
+
+	fsys := testMigrations()
+	fsys["migrations/01_create_items.up.sql"] = &fstest.MapFile{Data: []byte("CREATE TABLE items (id BIGINT PRIMARY KEY);")}
+	edited, err := LoadMigrations(fsys, "migrations")
+	require.NoError(t, err)
+	migrator := NewMigrator(db, edited)
+
+	assert.Error(t, migrator.Verify(ctx))
+	_, err = migrator.Up(ctx, 0)
+	assert.Error(t, err)
+
+	statuses, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	assert.True(t, statuses[0].Modified)
+	assert.Nil(t, statuses[1].AppliedAt)
+}
+
+// TestMigratorAdoptsLegacyVersion tests that databases migrated with golang-migrate carry on from
+// their version, and that a dirty version is refused
+func TestMigratorAdoptsLegacyVersion(t *testing.T) {
+	ctx := context.Background()
+	migrations, err := LoadMigrations(testMigrations(), "migrations")
+	require.NoError(t, err)
+
+	db := openTestDB(t)
+	require.NoError(t, db.Exec("CREATE TABLE items (id INTEGER PRIMARY KEY)").Error)
+	require.NoError(t, db.Exec("CREATE TABLE schema_migrations (version BIGINT, dirty BOOLEAN)").Error)
+	require.NoError(t, db.Exec("INSERT INTO schema_migrations VALUES (1, false)").Error)
+
+	done, err := NewMigrator(db, migrations).Up(ctx, 0)
+	require.NoError(t, err)
+	require.Len(t, done, 1)
+	assert.Equal(t, 2, done[0].Version)
+
+	db = openTestDB(t)
+	require.NoError(t, db.Exec("CREATE TABLE schema_migrations (version BIGINT, dirty BOOLEAN)").Error)
+	require.NoError(t, db.Exec("INSERT INTO schema_migrations VALUES (1, true)").Error)
+	_, err = NewMigrator(db, migrations).Up(ctx, 0)
+	assert.Error(t, err)
+}
+
+// TestMigratorBaseline tests that a schema created before the history is recorded without being rerun
+func TestMigratorBaseline(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	require.NoError(t, db.Exec("CREATE TABLE items (id INTEGER PRIMARY KEY)").Error)
+	migrations, err := LoadMigrations(testMigrations(), "migrations")
+	require.NoError(t, err)
+	migrator := NewMigrator(db, migrations)
+
+	done, err := migrator.Baseline(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, done, 1)
+
+	pending, err := migrator.Pending(ctx)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+
+	_, err = migrator.Baseline(ctx, 1)
+	assert.Error(t, err)
+}
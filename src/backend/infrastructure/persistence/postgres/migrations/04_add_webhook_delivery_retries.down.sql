@@ -0,0 +1,11 @@
+-- Drop indexes created for retry scheduling and attempt history
+DROP INDEX webhook_delivery_attempts_delivery_id_idx;
+DROP INDEX webhook_deliveries_next_attempt_at_idx;
+
+-- Drop webhook_delivery_attempts table
+DROP TABLE webhook_delivery_attempts;
+
+-- Drop retry columns from webhook_deliveries
+ALTER TABLE webhook_deliveries DROP COLUMN next_attempt_at;
+ALTER TABLE webhook_deliveries DROP COLUMN payload;
+ALTER TABLE webhook_deliveries DROP COLUMN event_type;
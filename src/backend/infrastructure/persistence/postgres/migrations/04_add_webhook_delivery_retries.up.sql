@@ -0,0 +1,41 @@
+-- Store a snapshot of the event on each delivery so retries do not depend on event lookups
+ALTER TABLE webhook_deliveries ADD COLUMN event_type VARCHAR(100) NULL;
+ALTER TABLE webhook_deliveries ADD COLUMN payload JSONB NULL;
+
+-- Track when a failed delivery becomes eligible for its next retry
+ALTER TABLE webhook_deliveries ADD COLUMN next_attempt_at TIMESTAMP NULL;
+
+-- Create webhook_delivery_attempts table to store the history of each delivery attempt
+CREATE TABLE webhook_delivery_attempts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    delivery_id UUID NOT NULL REFERENCES webhook_deliveries(id) ON DELETE CASCADE,
+    attempt_number INTEGER NOT NULL,
+    response_status INTEGER NULL,
+    response_body TEXT NULL,
+    error_message TEXT NULL,
+    duration_ms BIGINT NOT NULL DEFAULT 0,
+    attempted_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes for retry scheduling and attempt history lookups
+CREATE INDEX webhook_deliveries_next_attempt_at_idx ON webhook_deliveries(next_attempt_at) WHERE status = 'failed';
+CREATE INDEX webhook_delivery_attempts_delivery_id_idx ON webhook_delivery_attempts(delivery_id);
+
+-- Add table comments for documentation
+COMMENT ON TABLE webhook_delivery_attempts IS 'Stores the outcome of every HTTP attempt made for a webhook delivery';
+
+-- Add column comments for new webhook_deliveries columns
+COMMENT ON COLUMN webhook_deliveries.event_type IS 'Type of the event being delivered';
+COMMENT ON COLUMN webhook_deliveries.payload IS 'Snapshot of the event payload used for retries';
+COMMENT ON COLUMN webhook_deliveries.next_attempt_at IS 'Earliest time a failed delivery will be retried';
+COMMENT ON COLUMN webhook_deliveries.status IS 'Current status of the delivery (pending, success, failed, dead_lettered)';
+
+-- Add column comments for webhook_delivery_attempts table
+COMMENT ON COLUMN webhook_delivery_attempts.id IS 'Unique identifier for the attempt';
+COMMENT ON COLUMN webhook_delivery_attempts.delivery_id IS 'Reference to the delivery this attempt belongs to';
+COMMENT ON COLUMN webhook_delivery_attempts.attempt_number IS 'Sequence number of the attempt, starting at 1';
+COMMENT ON COLUMN webhook_delivery_attempts.response_status IS 'HTTP status code returned by the webhook endpoint';
+COMMENT ON COLUMN webhook_delivery_attempts.response_body IS 'Truncated response body returned by the webhook endpoint';
+COMMENT ON COLUMN webhook_delivery_attempts.error_message IS 'Error message if the attempt failed';
+COMMENT ON COLUMN webhook_delivery_attempts.duration_ms IS 'Time taken by the HTTP request in milliseconds';
+COMMENT ON COLUMN webhook_delivery_attempts.attempted_at IS 'Timestamp when the attempt was made';
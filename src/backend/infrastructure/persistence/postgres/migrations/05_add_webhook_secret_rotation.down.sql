@@ -0,0 +1,3 @@
+-- Drop secret rotation columns from webhooks
+ALTER TABLE webhooks DROP COLUMN secret_rotated_at;
+ALTER TABLE webhooks DROP COLUMN previous_secret_key;
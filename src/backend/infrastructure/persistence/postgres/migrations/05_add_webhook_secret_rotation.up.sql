@@ -0,0 +1,7 @@
+-- Keep the previous signing secret during rotation so receivers can switch over without dropping deliveries
+ALTER TABLE webhooks ADD COLUMN previous_secret_key TEXT NULL;
+ALTER TABLE webhooks ADD COLUMN secret_rotated_at TIMESTAMP NULL;
+
+-- Add column comments for secret rotation columns
+COMMENT ON COLUMN webhooks.previous_secret_key IS 'Previous signing secret, still used to sign deliveries during the rotation grace period';
+COMMENT ON COLUMN webhooks.secret_rotated_at IS 'Timestamp when the signing secret was last rotated';
@@ -0,0 +1,6 @@
+-- Drop indexes for webhook_subscriptions table
+DROP INDEX webhook_subscriptions_tenant_id_idx;
+DROP INDEX webhook_subscriptions_webhook_id_idx;
+
+-- Drop webhook_subscriptions table
+DROP TABLE webhook_subscriptions;
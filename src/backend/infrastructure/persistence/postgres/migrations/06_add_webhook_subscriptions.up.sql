@@ -0,0 +1,28 @@
+-- Create webhook_subscriptions table to narrow the events a webhook receives
+CREATE TABLE webhook_subscriptions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    webhook_id UUID NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    event_types TEXT[] NOT NULL DEFAULT '{}',
+    folder_ids TEXT[] NOT NULL DEFAULT '{}',
+    metadata_filters JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes for webhook_subscriptions table
+CREATE INDEX webhook_subscriptions_webhook_id_idx ON webhook_subscriptions(webhook_id);
+CREATE INDEX webhook_subscriptions_tenant_id_idx ON webhook_subscriptions(tenant_id);
+
+-- Add table comments for documentation
+COMMENT ON TABLE webhook_subscriptions IS 'Stores event type, folder scope and metadata filters for webhooks';
+
+-- Add column comments for webhook_subscriptions table
+COMMENT ON COLUMN webhook_subscriptions.id IS 'Unique identifier for the subscription';
+COMMENT ON COLUMN webhook_subscriptions.webhook_id IS 'Reference to the webhook this subscription filters';
+COMMENT ON COLUMN webhook_subscriptions.tenant_id IS 'Reference to the tenant that owns the subscription';
+COMMENT ON COLUMN webhook_subscriptions.event_types IS 'Event types matched by the subscription; empty matches all of the webhook event types';
+COMMENT ON COLUMN webhook_subscriptions.folder_ids IS 'Folders whose events are matched; empty matches all folders';
+COMMENT ON COLUMN webhook_subscriptions.metadata_filters IS 'Metadata key/value pairs that must all be present on the event';
+COMMENT ON COLUMN webhook_subscriptions.created_at IS 'Timestamp when the subscription was created';
+COMMENT ON COLUMN webhook_subscriptions.updated_at IS 'Timestamp when the subscription was last updated';
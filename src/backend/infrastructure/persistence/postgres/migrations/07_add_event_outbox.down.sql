@@ -0,0 +1,7 @@
+-- Drop indexes for outbox_messages table
+DROP INDEX outbox_messages_event_id_idx;
+DROP INDEX outbox_messages_sent_at_idx;
+DROP INDEX outbox_messages_next_attempt_at_idx;
+
+-- Drop outbox_messages table
+DROP TABLE outbox_messages;
@@ -0,0 +1,37 @@
+-- Create outbox_messages table so events are written in the same transaction as the domain change
+CREATE TABLE outbox_messages (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    event_id VARCHAR(255) NOT NULL,
+    event_type VARCHAR(100) NOT NULL,
+    tenant_id UUID NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NULL,
+    occurred_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    sent_at TIMESTAMP NULL
+);
+
+-- Create indexes for relay polling and cleanup of published messages
+CREATE INDEX outbox_messages_next_attempt_at_idx ON outbox_messages(next_attempt_at) WHERE status = 'pending';
+CREATE INDEX outbox_messages_sent_at_idx ON outbox_messages(sent_at) WHERE status = 'sent';
+CREATE INDEX outbox_messages_event_id_idx ON outbox_messages(event_id);
+
+-- Add table comments for documentation
+COMMENT ON TABLE outbox_messages IS 'Transactional outbox of domain events awaiting publication to the messaging system';
+
+-- Add column comments for outbox_messages table
+COMMENT ON COLUMN outbox_messages.id IS 'Unique identifier for the outbox message';
+COMMENT ON COLUMN outbox_messages.event_id IS 'Identifier of the event, used by consumers to deduplicate redeliveries';
+COMMENT ON COLUMN outbox_messages.event_type IS 'Type of the event to publish';
+COMMENT ON COLUMN outbox_messages.tenant_id IS 'Tenant that raised the event';
+COMMENT ON COLUMN outbox_messages.payload IS 'Event payload to publish';
+COMMENT ON COLUMN outbox_messages.status IS 'Publication status of the message (pending, sent)';
+COMMENT ON COLUMN outbox_messages.attempts IS 'Number of failed publish attempts';
+COMMENT ON COLUMN outbox_messages.last_error IS 'Error message from the most recent failed publish attempt';
+COMMENT ON COLUMN outbox_messages.occurred_at IS 'Timestamp when the event occurred';
+COMMENT ON COLUMN outbox_messages.next_attempt_at IS 'Earliest time the relay will attempt to publish the message';
+COMMENT ON COLUMN outbox_messages.created_at IS 'Timestamp when the message was written';
+COMMENT ON COLUMN outbox_messages.sent_at IS 'Timestamp when the message was published';
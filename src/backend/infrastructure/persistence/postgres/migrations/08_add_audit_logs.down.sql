@@ -0,0 +1,5 @@
+-- Drop partition maintenance function
+DROP FUNCTION create_audit_log_partition(DATE);
+
+-- Drop audit_logs table together with all of its partitions and indexes
+DROP TABLE audit_logs CASCADE;
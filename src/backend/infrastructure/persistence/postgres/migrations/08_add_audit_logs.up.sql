@@ -0,0 +1,60 @@
+-- Create audit_logs table, range partitioned by month so old data can be detached or dropped cheaply
+CREATE TABLE audit_logs (
+    id UUID NOT NULL DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL,
+    actor_id VARCHAR(255) NULL,
+    actor_ip VARCHAR(45) NULL,
+    user_agent TEXT NULL,
+    request_id VARCHAR(255) NULL,
+    action VARCHAR(50) NOT NULL,
+    resource_type VARCHAR(50) NOT NULL,
+    resource_id VARCHAR(255) NOT NULL,
+    before JSONB NULL,
+    after JSONB NULL,
+    occurred_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (id, occurred_at)
+) PARTITION BY RANGE (occurred_at);
+
+-- Catch-all partition for rows outside the pre-created monthly ranges
+CREATE TABLE audit_logs_default PARTITION OF audit_logs DEFAULT;
+
+-- Create indexes for audit_logs table; indexes on the parent cascade to every partition
+CREATE INDEX audit_logs_tenant_id_occurred_at_idx ON audit_logs(tenant_id, occurred_at DESC);
+CREATE INDEX audit_logs_tenant_id_resource_idx ON audit_logs(tenant_id, resource_type, resource_id);
+CREATE INDEX audit_logs_tenant_id_actor_id_idx ON audit_logs(tenant_id, actor_id);
+CREATE INDEX audit_logs_id_idx ON audit_logs(id);
+
+-- Create the monthly partition containing month_start if it does not already exist
+CREATE OR REPLACE FUNCTION create_audit_log_partition(month_start DATE) RETURNS void AS $$
+DECLARE
+    range_start DATE := date_trunc('month', month_start)::DATE;
+    range_end DATE := (date_trunc('month', month_start) + INTERVAL '1 month')::DATE;
+    partition_name TEXT := 'audit_logs_' || to_char(range_start, 'YYYY_MM');
+BEGIN
+    EXECUTE format(
+        'CREATE TABLE IF NOT EXISTS %I PARTITION OF audit_logs FOR VALUES FROM (%L) TO (%L)',
+        partition_name, range_start, range_end
+    );
+END;
+$$ LANGUAGE plpgsql;
+
+-- Pre-create partitions for the current and next month
+SELECT create_audit_log_partition(CURRENT_DATE);
+SELECT create_audit_log_partition((CURRENT_DATE + INTERVAL '1 month')::DATE);
+
+-- Add table comments for documentation
+COMMENT ON TABLE audit_logs IS 'Append-only record of document, folder and permission operations for compliance audits';
+
+-- Add column comments for audit_logs table
+COMMENT ON COLUMN audit_logs.id IS 'Unique identifier for the audit log entry';
+COMMENT ON COLUMN audit_logs.tenant_id IS 'Tenant in which the operation was performed';
+COMMENT ON COLUMN audit_logs.actor_id IS 'User who performed the operation';
+COMMENT ON COLUMN audit_logs.actor_ip IS 'Client IP address of the request';
+COMMENT ON COLUMN audit_logs.user_agent IS 'User agent of the request';
+COMMENT ON COLUMN audit_logs.request_id IS 'Request ID for correlating with API logs';
+COMMENT ON COLUMN audit_logs.action IS 'Operation performed (create, update, delete, move, upload, download, grant, revoke)';
+COMMENT ON COLUMN audit_logs.resource_type IS 'Type of the affected resource (document, folder, permission)';
+COMMENT ON COLUMN audit_logs.resource_id IS 'Identifier of the affected resource';
+COMMENT ON COLUMN audit_logs.before IS 'Summary of the changed fields before the operation';
+COMMENT ON COLUMN audit_logs.after IS 'Summary of the changed fields after the operation';
+COMMENT ON COLUMN audit_logs.occurred_at IS 'Timestamp when the operation was performed; partition key';
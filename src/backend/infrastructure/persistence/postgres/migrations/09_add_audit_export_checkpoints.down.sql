@@ -0,0 +1,5 @@
+-- Drop index used to forward audit logs in order
+DROP INDEX audit_logs_occurred_at_id_idx;
+
+-- Drop audit_export_checkpoints table
+DROP TABLE audit_export_checkpoints;
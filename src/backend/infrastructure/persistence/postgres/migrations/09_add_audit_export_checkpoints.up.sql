@@ -0,0 +1,19 @@
+-- Create audit_export_checkpoints table to track how far each SIEM exporter has forwarded the audit log
+CREATE TABLE audit_export_checkpoints (
+    exporter VARCHAR(50) PRIMARY KEY,
+    last_occurred_at TIMESTAMP NOT NULL,
+    last_id UUID NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Support forwarding audit logs of all tenants in (occurred_at, id) order
+CREATE INDEX audit_logs_occurred_at_id_idx ON audit_logs(occurred_at, id);
+
+-- Add table comments for documentation
+COMMENT ON TABLE audit_export_checkpoints IS 'Progress of audit log exporters that forward entries to external systems';
+
+-- Add column comments for audit_export_checkpoints table
+COMMENT ON COLUMN audit_export_checkpoints.exporter IS 'Name of the exporter (syslog, s3)';
+COMMENT ON COLUMN audit_export_checkpoints.last_occurred_at IS 'Occurrence time of the last audit log entry forwarded';
+COMMENT ON COLUMN audit_export_checkpoints.last_id IS 'Identifier of the last audit log entry forwarded';
+COMMENT ON COLUMN audit_export_checkpoints.updated_at IS 'Timestamp when the checkpoint was last advanced';
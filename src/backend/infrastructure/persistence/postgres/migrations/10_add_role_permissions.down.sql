@@ -0,0 +1,2 @@
+-- Drop permissions column from roles
+ALTER TABLE roles DROP COLUMN permissions;
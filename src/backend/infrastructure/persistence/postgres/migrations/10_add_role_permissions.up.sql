@@ -0,0 +1,11 @@
+-- Add permissions column to roles so that tenants can define custom roles
+ALTER TABLE roles ADD COLUMN permissions TEXT[] NOT NULL DEFAULT '{}';
+
+-- Seed the permissions of the predefined system roles
+UPDATE roles SET permissions = ARRAY['read'] WHERE name = 'reader';
+UPDATE roles SET permissions = ARRAY['read', 'write'] WHERE name = 'contributor';
+UPDATE roles SET permissions = ARRAY['read', 'write', 'delete'] WHERE name = 'editor';
+UPDATE roles SET permissions = ARRAY['read', 'write', 'delete', 'manage_folders', 'admin'] WHERE name IN ('administrator', 'system');
+
+-- Add column comments for roles table
+COMMENT ON COLUMN roles.permissions IS 'Permissions granted by the role (read, write, delete, manage_folders, admin)';
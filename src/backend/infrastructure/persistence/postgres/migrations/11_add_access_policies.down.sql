@@ -0,0 +1,6 @@
+-- Drop indexes for access_policies table
+DROP INDEX access_policies_tenant_enabled_idx;
+DROP INDEX access_policies_tenant_name_idx;
+
+-- Drop access_policies table
+DROP TABLE access_policies;
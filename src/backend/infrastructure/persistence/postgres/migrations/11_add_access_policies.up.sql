@@ -0,0 +1,29 @@
+-- Create access_policies table for attribute-based document access rules
+CREATE TABLE access_policies (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    attribute VARCHAR(255) NOT NULL,
+    operator VARCHAR(20) NOT NULL,
+    match_values TEXT[] NOT NULL DEFAULT '{}',
+    actions TEXT[] NOT NULL,
+    required_roles TEXT[] NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+CREATE UNIQUE INDEX access_policies_tenant_name_idx ON access_policies(tenant_id, name);
+CREATE INDEX access_policies_tenant_enabled_idx ON access_policies(tenant_id, enabled);
+
+-- Add table comments for documentation
+COMMENT ON TABLE access_policies IS 'Attribute-based access rules evaluated on document access in addition to role permissions';
+
+-- Add column comments for access_policies table
+COMMENT ON COLUMN access_policies.attribute IS 'Document attribute matched by the policy (content_type, folder_id, owner_id, status or metadata.<key>)';
+COMMENT ON COLUMN access_policies.operator IS 'Comparison operator (equals, not_equals, in, exists)';
+COMMENT ON COLUMN access_policies.match_values IS 'Values the attribute is compared with';
+COMMENT ON COLUMN access_policies.actions IS 'Document actions restricted by the policy (read, write)';
+COMMENT ON COLUMN access_policies.required_roles IS 'Roles of which a user must hold at least one when the policy matches';
+COMMENT ON COLUMN access_policies.enabled IS 'Whether the policy is evaluated';
@@ -0,0 +1,14 @@
+-- Remove user and group grants, which cannot be represented once role_id is required again
+DELETE FROM permissions WHERE role_id IS NULL;
+
+-- Drop indexes for permissions grantees
+DROP INDEX permissions_group_unique_idx;
+DROP INDEX permissions_user_unique_idx;
+DROP INDEX permissions_group_id_idx;
+DROP INDEX permissions_user_id_idx;
+
+-- Drop grantee columns from permissions
+ALTER TABLE permissions DROP CONSTRAINT permissions_single_grantee_check;
+ALTER TABLE permissions DROP COLUMN group_id;
+ALTER TABLE permissions DROP COLUMN user_id;
+ALTER TABLE permissions ALTER COLUMN role_id SET NOT NULL;
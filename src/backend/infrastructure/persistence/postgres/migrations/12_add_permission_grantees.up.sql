@@ -0,0 +1,18 @@
+-- Allow permissions to be granted directly to users and to groups as well as to roles
+ALTER TABLE permissions ALTER COLUMN role_id DROP NOT NULL;
+ALTER TABLE permissions ADD COLUMN user_id UUID REFERENCES users(id) ON DELETE CASCADE;
+ALTER TABLE permissions ADD COLUMN group_id UUID;
+
+-- Each permission is granted to exactly one role, user or group
+ALTER TABLE permissions ADD CONSTRAINT permissions_single_grantee_check CHECK (num_nonnulls(role_id, user_id, group_id) = 1);
+
+-- Create indexes for permissions grantees
+CREATE INDEX permissions_user_id_idx ON permissions(user_id);
+CREATE INDEX permissions_group_id_idx ON permissions(group_id);
+CREATE UNIQUE INDEX permissions_user_unique_idx ON permissions(tenant_id, user_id, resource_type, resource_id, permission_type) WHERE user_id IS NOT NULL;
+CREATE UNIQUE INDEX permissions_group_unique_idx ON permissions(tenant_id, group_id, resource_type, resource_id, permission_type) WHERE group_id IS NOT NULL;
+
+-- Add column comments for permissions table
+COMMENT ON COLUMN permissions.role_id IS 'Role the permission is granted to; exactly one of role_id, user_id and group_id is set';
+COMMENT ON COLUMN permissions.user_id IS 'User the permission is granted to directly';
+COMMENT ON COLUMN permissions.group_id IS 'Group the permission is granted to';
@@ -0,0 +1,10 @@
+-- Drop foreign key from permissions to groups
+ALTER TABLE permissions DROP CONSTRAINT permissions_group_id_fkey;
+
+-- Drop indexes for group tables
+DROP INDEX group_memberships_tenant_user_idx;
+DROP INDEX groups_tenant_name_idx;
+
+-- Drop group tables
+DROP TABLE group_memberships;
+DROP TABLE groups;
@@ -0,0 +1,33 @@
+-- Create groups table for named sets of users within a tenant
+CREATE TABLE groups (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+CREATE UNIQUE INDEX groups_tenant_name_idx ON groups(tenant_id, name);
+
+-- Create group_memberships table linking users to groups
+CREATE TABLE group_memberships (
+    group_id UUID NOT NULL REFERENCES groups(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    added_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT group_memberships_pkey PRIMARY KEY (group_id, user_id)
+);
+CREATE INDEX group_memberships_tenant_user_idx ON group_memberships(tenant_id, user_id);
+
+-- Remove group grants when the group is deleted. Grants made before groups existed cannot refer to a group.
+DELETE FROM permissions WHERE group_id IS NOT NULL;
+ALTER TABLE permissions ADD CONSTRAINT permissions_group_id_fkey FOREIGN KEY (group_id) REFERENCES groups(id) ON DELETE CASCADE;
+
+-- Add table comments for documentation
+COMMENT ON TABLE groups IS 'Named sets of users that permissions can be granted to';
+COMMENT ON TABLE group_memberships IS 'Users that are members of a group';
+
+-- Add column comments for group_memberships table
+COMMENT ON COLUMN group_memberships.added_by IS 'User who added the member to the group';
@@ -0,0 +1,6 @@
+-- Drop indexes for share_links table
+DROP INDEX share_links_tenant_document_idx;
+DROP INDEX share_links_token_hash_idx;
+
+-- Drop share_links table
+DROP TABLE share_links;
@@ -0,0 +1,27 @@
+-- Create share_links table for tokenized public document links
+CREATE TABLE share_links (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    document_id UUID NOT NULL REFERENCES documents(id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) NOT NULL,
+    password_hash VARCHAR(255) NOT NULL DEFAULT '',
+    expires_at TIMESTAMP NOT NULL,
+    max_downloads INTEGER NOT NULL DEFAULT 0,
+    download_count INTEGER NOT NULL DEFAULT 0,
+    created_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    revoked_at TIMESTAMP NULL,
+    CONSTRAINT share_links_max_downloads_check CHECK (max_downloads >= 0)
+);
+CREATE UNIQUE INDEX share_links_token_hash_idx ON share_links(token_hash);
+CREATE INDEX share_links_tenant_document_idx ON share_links(tenant_id, document_id);
+
+-- Add table comments for documentation
+COMMENT ON TABLE share_links IS 'Tokenized public links to documents with expiry, download limit and optional password';
+
+-- Add column comments for share_links table
+COMMENT ON COLUMN share_links.token_hash IS 'SHA-256 hash of the link token; the token itself is only returned on creation';
+COMMENT ON COLUMN share_links.password_hash IS 'Bcrypt hash of the link password, empty when the link is not password protected';
+COMMENT ON COLUMN share_links.max_downloads IS 'Maximum number of downloads, 0 for unlimited';
+COMMENT ON COLUMN share_links.download_count IS 'Number of downloads served through the link';
+COMMENT ON COLUMN share_links.revoked_at IS 'When the link was revoked, NULL while active';
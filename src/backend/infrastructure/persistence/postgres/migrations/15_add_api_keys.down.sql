@@ -0,0 +1,6 @@
+-- Drop indexes for api_keys table
+DROP INDEX api_keys_tenant_id_idx;
+DROP INDEX api_keys_key_hash_idx;
+
+-- Drop api_keys table
+DROP TABLE api_keys;
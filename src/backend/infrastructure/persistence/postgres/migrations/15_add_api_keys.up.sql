@@ -0,0 +1,29 @@
+-- Create api_keys table for service-to-service integration credentials
+CREATE TABLE api_keys (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    role VARCHAR(50) NOT NULL,
+    key_prefix VARCHAR(20) NOT NULL,
+    key_hash VARCHAR(64) NOT NULL,
+    created_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    rotated_at TIMESTAMP NULL,
+    last_used_at TIMESTAMP NULL,
+    expires_at TIMESTAMP NULL,
+    revoked_at TIMESTAMP NULL
+);
+CREATE UNIQUE INDEX api_keys_key_hash_idx ON api_keys(key_hash);
+CREATE INDEX api_keys_tenant_id_idx ON api_keys(tenant_id);
+
+-- Add table comments for documentation
+COMMENT ON TABLE api_keys IS 'API keys that authenticate integrations as a tenant with a single role';
+
+-- Add column comments for api_keys table
+COMMENT ON COLUMN api_keys.role IS 'Name of the tenant role the key acts with';
+COMMENT ON COLUMN api_keys.key_prefix IS 'Leading characters of the key, shown to help users recognize it';
+COMMENT ON COLUMN api_keys.key_hash IS 'SHA-256 hash of the key; the key itself is only returned on creation and rotation';
+COMMENT ON COLUMN api_keys.rotated_at IS 'When the key secret was last replaced, NULL if never rotated';
+COMMENT ON COLUMN api_keys.last_used_at IS 'When the key last authenticated a request, NULL if never used';
+COMMENT ON COLUMN api_keys.expires_at IS 'When the key expires, NULL for keys that do not expire';
+COMMENT ON COLUMN api_keys.revoked_at IS 'When the key was revoked, NULL while active';
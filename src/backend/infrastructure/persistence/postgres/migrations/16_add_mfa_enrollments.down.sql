@@ -0,0 +1,6 @@
+-- Drop indexes for mfa_enrollments table
+DROP INDEX mfa_enrollments_tenant_id_idx;
+DROP INDEX mfa_enrollments_user_id_idx;
+
+-- Drop mfa_enrollments table
+DROP TABLE mfa_enrollments;
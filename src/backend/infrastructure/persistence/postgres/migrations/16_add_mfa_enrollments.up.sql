@@ -0,0 +1,24 @@
+-- Create mfa_enrollments table for users' TOTP second factors
+CREATE TABLE mfa_enrollments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    secret VARCHAR(64) NOT NULL,
+    backup_code_hashes TEXT[] NOT NULL DEFAULT '{}',
+    last_used_step BIGINT NOT NULL DEFAULT 0,
+    failed_attempts INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    enabled_at TIMESTAMP NULL
+);
+CREATE UNIQUE INDEX mfa_enrollments_user_id_idx ON mfa_enrollments(user_id);
+CREATE INDEX mfa_enrollments_tenant_id_idx ON mfa_enrollments(tenant_id);
+
+-- Add table comments for documentation
+COMMENT ON TABLE mfa_enrollments IS 'TOTP second factors; a user has at most one enrollment';
+
+-- Add column comments for mfa_enrollments table
+COMMENT ON COLUMN mfa_enrollments.secret IS 'Base32 TOTP secret shared with the user''s authenticator app';
+COMMENT ON COLUMN mfa_enrollments.backup_code_hashes IS 'SHA-256 hashes of the unused single-use backup codes';
+COMMENT ON COLUMN mfa_enrollments.last_used_step IS 'TOTP time step of the last accepted code, to reject replayed codes';
+COMMENT ON COLUMN mfa_enrollments.failed_attempts IS 'Consecutive invalid codes since the last successful verification';
+COMMENT ON COLUMN mfa_enrollments.enabled_at IS 'When the user confirmed the enrollment with a first code, NULL while pending';
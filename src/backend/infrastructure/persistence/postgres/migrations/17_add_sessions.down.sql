@@ -0,0 +1,6 @@
+-- Drop indexes for sessions table
+DROP INDEX sessions_expires_at_idx;
+DROP INDEX sessions_user_id_idx;
+
+-- Drop sessions table
+DROP TABLE sessions;
@@ -0,0 +1,24 @@
+-- Create sessions table for signed-in users' token families
+CREATE TABLE sessions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    ip_address VARCHAR(45) NOT NULL DEFAULT '',
+    user_agent VARCHAR(512) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_used_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP NOT NULL,
+    revoked_at TIMESTAMP NULL
+);
+CREATE INDEX sessions_user_id_idx ON sessions(user_id, tenant_id);
+CREATE INDEX sessions_expires_at_idx ON sessions(expires_at);
+
+-- Add table comments for documentation
+COMMENT ON TABLE sessions IS 'Signed-in users per device; the access and refresh tokens of a session carry its ID';
+
+-- Add column comments for sessions table
+COMMENT ON COLUMN sessions.ip_address IS 'Client IP address the session was started from';
+COMMENT ON COLUMN sessions.user_agent IS 'User agent of the client the session was started from';
+COMMENT ON COLUMN sessions.last_used_at IS 'When the session''s tokens were last issued or refreshed';
+COMMENT ON COLUMN sessions.expires_at IS 'When the session''s latest refresh token expires';
+COMMENT ON COLUMN sessions.revoked_at IS 'When the session was revoked, NULL while active';
@@ -0,0 +1,6 @@
+-- Drop password policy and lockout columns
+ALTER TABLE users DROP COLUMN locked_until;
+ALTER TABLE users DROP COLUMN failed_login_window_start;
+ALTER TABLE users DROP COLUMN failed_login_count;
+ALTER TABLE users DROP COLUMN password_history;
+ALTER TABLE users DROP COLUMN password_changed_at;
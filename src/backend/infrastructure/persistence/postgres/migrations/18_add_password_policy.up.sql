@@ -0,0 +1,13 @@
+-- Track password age and history for tenant password policies, and failed sign-ins for the account lockout
+ALTER TABLE users ADD COLUMN password_changed_at TIMESTAMP NOT NULL DEFAULT NOW();
+ALTER TABLE users ADD COLUMN password_history TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE users ADD COLUMN failed_login_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN failed_login_window_start TIMESTAMP NULL;
+ALTER TABLE users ADD COLUMN locked_until TIMESTAMP NULL;
+
+-- Add column comments for password policy and lockout columns
+COMMENT ON COLUMN users.password_changed_at IS 'When the password was last set, used for password expiry';
+COMMENT ON COLUMN users.password_history IS 'Bcrypt hashes of previous passwords, most recent first, to prevent reuse';
+COMMENT ON COLUMN users.failed_login_count IS 'Failed sign-ins within the current lockout window';
+COMMENT ON COLUMN users.failed_login_window_start IS 'When the first failed sign-in of the current lockout window happened';
+COMMENT ON COLUMN users.locked_until IS 'Until when sign-in is refused after too many failed attempts, NULL if not locked';
@@ -0,0 +1,2 @@
+-- Drop tenant_quotas table
+DROP TABLE tenant_quotas;
@@ -0,0 +1,20 @@
+-- Create tenant_quotas table for per-tenant storage limits and the usage tracked against them
+CREATE TABLE tenant_quotas (
+    tenant_id UUID PRIMARY KEY REFERENCES tenants(id) ON DELETE CASCADE,
+    max_storage_bytes BIGINT NOT NULL DEFAULT 0,
+    max_documents BIGINT NOT NULL DEFAULT 0,
+    used_storage_bytes BIGINT NOT NULL DEFAULT 0,
+    used_documents BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT tenant_quotas_limits_check CHECK (max_storage_bytes >= 0 AND max_documents >= 0),
+    CONSTRAINT tenant_quotas_usage_check CHECK (used_storage_bytes >= 0 AND used_documents >= 0)
+);
+
+-- Add table comments for documentation
+COMMENT ON TABLE tenant_quotas IS 'Storage limits of each tenant and the usage counted against them on upload and delete';
+
+-- Add column comments for tenant_quotas table
+COMMENT ON COLUMN tenant_quotas.max_storage_bytes IS 'Maximum bytes of document content the tenant may store, 0 for unlimited';
+COMMENT ON COLUMN tenant_quotas.max_documents IS 'Maximum number of documents the tenant may store, 0 for unlimited';
+COMMENT ON COLUMN tenant_quotas.used_storage_bytes IS 'Bytes of document content currently stored, summed over all versions';
+COMMENT ON COLUMN tenant_quotas.used_documents IS 'Number of documents currently stored';
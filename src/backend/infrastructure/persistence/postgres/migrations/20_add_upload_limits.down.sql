@@ -0,0 +1,5 @@
+-- Drop the uploader index on document_versions
+DROP INDEX IF EXISTS document_versions_created_by_idx;
+
+-- Drop upload_limits table
+DROP TABLE upload_limits;
@@ -0,0 +1,27 @@
+-- Create upload_limits table for per-user and per-role file size and daily upload volume limits
+CREATE TABLE upload_limits (
+    id UUID PRIMARY KEY,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    scope VARCHAR(10) NOT NULL,
+    scope_id VARCHAR(255) NOT NULL,
+    max_file_size_bytes BIGINT NOT NULL DEFAULT 0,
+    max_daily_upload_bytes BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT upload_limits_scope_check CHECK (scope IN ('user', 'role')),
+    CONSTRAINT upload_limits_limits_check CHECK (max_file_size_bytes >= 0 AND max_daily_upload_bytes >= 0)
+);
+
+CREATE UNIQUE INDEX upload_limits_tenant_scope_idx ON upload_limits(tenant_id, scope, scope_id);
+
+-- Index versions by uploader, for summing the volume a user uploaded since the start of the day
+CREATE INDEX document_versions_created_by_idx ON document_versions(created_by, created_at);
+
+-- Add table comments for documentation
+COMMENT ON TABLE upload_limits IS 'File size and daily upload volume limits of individual users or of every user holding a role';
+
+-- Add column comments for upload_limits table
+COMMENT ON COLUMN upload_limits.scope IS 'Whether the limit applies to a user or to a role';
+COMMENT ON COLUMN upload_limits.scope_id IS 'ID of the user or name of the role the limit applies to';
+COMMENT ON COLUMN upload_limits.max_file_size_bytes IS 'Maximum size of a single uploaded file, 0 for unlimited';
+COMMENT ON COLUMN upload_limits.max_daily_upload_bytes IS 'Maximum bytes uploaded per UTC day, 0 for unlimited';
@@ -0,0 +1,6 @@
+-- Drop indexes for export_jobs table
+DROP INDEX IF EXISTS export_jobs_tenant_id_idx;
+DROP INDEX IF EXISTS export_jobs_claim_idx;
+
+-- Drop export_jobs table
+DROP TABLE export_jobs;
@@ -0,0 +1,36 @@
+-- Create export_jobs table for background exports of folder trees into ZIP archives
+CREATE TABLE export_jobs (
+    id UUID PRIMARY KEY,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    folder_id UUID NOT NULL REFERENCES folders(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    total_documents INTEGER NOT NULL DEFAULT 0,
+    exported_documents INTEGER NOT NULL DEFAULT 0,
+    archive_path VARCHAR(1000) NOT NULL DEFAULT '',
+    archive_size BIGINT NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    started_at TIMESTAMP NULL,
+    completed_at TIMESTAMP NULL,
+    CONSTRAINT export_jobs_status_check CHECK (status IN ('pending', 'running', 'completed', 'failed'))
+);
+
+-- Create indexes for worker polling and tenant lookups
+CREATE INDEX export_jobs_claim_idx ON export_jobs(created_at) WHERE status IN ('pending', 'running');
+CREATE INDEX export_jobs_tenant_id_idx ON export_jobs(tenant_id);
+
+-- Add table comments for documentation
+COMMENT ON TABLE export_jobs IS 'Background exports of folder trees into ZIP archives, run by the worker';
+
+-- Add column comments for export_jobs table
+COMMENT ON COLUMN export_jobs.folder_id IS 'Root folder of the exported tree';
+COMMENT ON COLUMN export_jobs.user_id IS 'User who requested the export and may download the archive';
+COMMENT ON COLUMN export_jobs.status IS 'Status of the export (pending, running, completed, failed)';
+COMMENT ON COLUMN export_jobs.total_documents IS 'Number of documents found in the folder tree';
+COMMENT ON COLUMN export_jobs.exported_documents IS 'Number of documents written to the archive so far';
+COMMENT ON COLUMN export_jobs.archive_path IS 'Storage path of the completed archive';
+COMMENT ON COLUMN export_jobs.archive_size IS 'Size of the completed archive in bytes';
+COMMENT ON COLUMN export_jobs.error IS 'Reason the export failed';
+COMMENT ON COLUMN export_jobs.updated_at IS 'Timestamp of the last progress update, used to reclaim exports abandoned by a worker';
@@ -0,0 +1,2 @@
+-- Drop content_blobs table
+DROP TABLE content_blobs;
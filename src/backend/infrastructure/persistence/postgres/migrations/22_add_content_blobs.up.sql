@@ -0,0 +1,19 @@
+-- Create content_blobs table for reference counting content stored once per tenant in content-addressed storage
+CREATE TABLE content_blobs (
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    content_hash VARCHAR(64) NOT NULL,
+    size BIGINT NOT NULL DEFAULT 0,
+    ref_count BIGINT NOT NULL DEFAULT 1,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, content_hash),
+    CONSTRAINT content_blobs_ref_count_check CHECK (ref_count > 0)
+);
+
+-- Add table comments for documentation
+COMMENT ON TABLE content_blobs IS 'Content shared by all document versions of a tenant with the same SHA-256 hash, stored once under blobs/<tenant>/<hash>';
+
+-- Add column comments for content_blobs table
+COMMENT ON COLUMN content_blobs.content_hash IS 'Hex encoded SHA-256 hash of the content';
+COMMENT ON COLUMN content_blobs.size IS 'Size of the content in bytes';
+COMMENT ON COLUMN content_blobs.ref_count IS 'Number of document versions referencing the content; the content is deleted with the last reference';
@@ -0,0 +1,5 @@
+-- Drop the encryption key index on document_versions
+DROP INDEX IF EXISTS document_versions_encryption_key_id_idx;
+
+-- Drop the encryption key column from document_versions
+ALTER TABLE document_versions DROP COLUMN encryption_key_id;
@@ -0,0 +1,8 @@
+-- Track the KMS key the content of each document version is encrypted with
+ALTER TABLE document_versions ADD COLUMN encryption_key_id VARCHAR(2048) NOT NULL DEFAULT '';
+
+-- Index versions by key, for finding the versions to re-encrypt when a tenant's key changes
+CREATE INDEX document_versions_encryption_key_id_idx ON document_versions(encryption_key_id);
+
+-- Add column comments for documentation
+COMMENT ON COLUMN document_versions.encryption_key_id IS 'ARN of the KMS key the content is encrypted with, empty for S3 managed keys';
@@ -0,0 +1,6 @@
+-- Drop indexes for quarantined_items table
+DROP INDEX IF EXISTS quarantined_items_version_id_idx;
+DROP INDEX IF EXISTS quarantined_items_tenant_id_idx;
+
+-- Drop quarantined_items table
+DROP TABLE quarantined_items;
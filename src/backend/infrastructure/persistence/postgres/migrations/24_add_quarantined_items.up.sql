@@ -0,0 +1,32 @@
+-- Create quarantined_items table for document versions a virus scan found infected
+CREATE TABLE quarantined_items (
+    id UUID PRIMARY KEY,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    document_id UUID NOT NULL REFERENCES documents(id) ON DELETE CASCADE,
+    version_id UUID NOT NULL REFERENCES document_versions(id) ON DELETE CASCADE,
+    storage_path VARCHAR(1000) NOT NULL,
+    scan_details TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'quarantined',
+    resolution VARCHAR(20) NOT NULL DEFAULT '',
+    reason TEXT NOT NULL DEFAULT '',
+    resolved_by VARCHAR(255) NOT NULL DEFAULT '',
+    quarantined_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    resolved_at TIMESTAMP NULL,
+    CONSTRAINT quarantined_items_status_check CHECK (status IN ('quarantined', 'released', 'destroyed')),
+    CONSTRAINT quarantined_items_resolution_check CHECK (resolution IN ('', 'rescan', 'override'))
+);
+
+-- Create indexes for listing the quarantine of a tenant
+CREATE INDEX quarantined_items_tenant_id_idx ON quarantined_items(tenant_id, quarantined_at DESC);
+CREATE INDEX quarantined_items_version_id_idx ON quarantined_items(version_id);
+
+-- Add table comments for documentation
+COMMENT ON TABLE quarantined_items IS 'Document versions held in quarantine storage after a virus scan found them infected';
+
+-- Add column comments for quarantined_items table
+COMMENT ON COLUMN quarantined_items.storage_path IS 'Path of the infected content in quarantine storage';
+COMMENT ON COLUMN quarantined_items.scan_details IS 'Verdict of the scanning engines, such as the virus name';
+COMMENT ON COLUMN quarantined_items.status IS 'Status of the item (quarantined, released, destroyed)';
+COMMENT ON COLUMN quarantined_items.resolution IS 'How a released item was released (rescan, override)';
+COMMENT ON COLUMN quarantined_items.reason IS 'Reason the security administrator gave for releasing or destroying the item';
+COMMENT ON COLUMN quarantined_items.resolved_by IS 'Security administrator who released or destroyed the item';
@@ -0,0 +1,7 @@
+-- Drop indexes for document_search table
+DROP INDEX IF EXISTS document_search_metadata_terms_idx;
+DROP INDEX IF EXISTS document_search_content_vector_idx;
+DROP INDEX IF EXISTS document_search_tenant_id_idx;
+
+-- Drop document_search table
+DROP TABLE document_search;
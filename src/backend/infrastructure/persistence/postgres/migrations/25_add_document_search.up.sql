@@ -0,0 +1,21 @@
+-- Create document_search table, the index of the postgres search provider
+CREATE TABLE document_search (
+    document_id UUID PRIMARY KEY REFERENCES documents(id) ON DELETE CASCADE,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    folder_id UUID NOT NULL,
+    content_vector TSVECTOR NOT NULL,
+    metadata_terms TEXT[] NOT NULL DEFAULT '{}',
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes for searching the documents of a tenant by content and metadata
+CREATE INDEX document_search_tenant_id_idx ON document_search(tenant_id, folder_id);
+CREATE INDEX document_search_content_vector_idx ON document_search USING GIN(content_vector);
+CREATE INDEX document_search_metadata_terms_idx ON document_search USING GIN(metadata_terms);
+
+-- Add table comments for documentation
+COMMENT ON TABLE document_search IS 'Full-text search index of documents when search runs on PostgreSQL instead of Elasticsearch';
+
+-- Add column comments for document_search table
+COMMENT ON COLUMN document_search.content_vector IS 'Words of the extracted document content with English stemming';
+COMMENT ON COLUMN document_search.metadata_terms IS 'key:word terms for each word of each metadata value';
@@ -0,0 +1,11 @@
+-- Drop indexed_at column of document_search table
+DROP INDEX IF EXISTS document_search_indexed_at_idx;
+ALTER TABLE document_search DROP COLUMN indexed_at;
+
+-- Drop indexes for reindex_jobs table
+DROP INDEX IF EXISTS reindex_jobs_unfinished_idx;
+DROP INDEX IF EXISTS reindex_jobs_tenant_id_idx;
+DROP INDEX IF EXISTS reindex_jobs_claim_idx;
+
+-- Drop reindex_jobs table
+DROP TABLE reindex_jobs;
@@ -0,0 +1,40 @@
+-- Create reindex_jobs table for admin-triggered rebuilds of the search index of a tenant
+CREATE TABLE reindex_jobs (
+    id UUID PRIMARY KEY,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    total_documents INTEGER NOT NULL DEFAULT 0,
+    indexed_documents INTEGER NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    started_at TIMESTAMP NULL,
+    completed_at TIMESTAMP NULL,
+    CONSTRAINT reindex_jobs_status_check CHECK (status IN ('pending', 'running', 'completed', 'failed'))
+);
+
+-- Create indexes for worker polling and tenant lookups
+CREATE INDEX reindex_jobs_claim_idx ON reindex_jobs(created_at) WHERE status IN ('pending', 'running');
+CREATE INDEX reindex_jobs_tenant_id_idx ON reindex_jobs(tenant_id);
+
+-- Allow one unfinished rebuild per tenant, as concurrent rebuilds would replace each other's index
+CREATE UNIQUE INDEX reindex_jobs_unfinished_idx ON reindex_jobs(tenant_id) WHERE status IN ('pending', 'running');
+
+-- Add table comments for documentation
+COMMENT ON TABLE reindex_jobs IS 'Background rebuilds of the search index of a tenant, run by the worker';
+
+-- Add column comments for reindex_jobs table
+COMMENT ON COLUMN reindex_jobs.user_id IS 'Administrator who requested the rebuild';
+COMMENT ON COLUMN reindex_jobs.status IS 'Status of the rebuild (pending, running, completed, failed)';
+COMMENT ON COLUMN reindex_jobs.total_documents IS 'Number of documents of the tenant when the rebuild started';
+COMMENT ON COLUMN reindex_jobs.indexed_documents IS 'Number of documents indexed into the rebuilt index so far';
+COMMENT ON COLUMN reindex_jobs.error IS 'Reason the rebuild failed';
+COMMENT ON COLUMN reindex_jobs.updated_at IS 'Timestamp of the last progress update, used to reclaim rebuilds abandoned by a worker';
+
+-- Record when each document was last indexed, so a rebuild of the postgres search index can drop
+-- the rows it did not index again
+ALTER TABLE document_search ADD COLUMN indexed_at TIMESTAMP NOT NULL DEFAULT NOW();
+CREATE INDEX document_search_indexed_at_idx ON document_search(tenant_id, indexed_at);
+
+COMMENT ON COLUMN document_search.indexed_at IS 'Timestamp the document was last indexed';
@@ -0,0 +1,5 @@
+-- Drop indexes for metadata_fields table
+DROP INDEX metadata_fields_tenant_name_idx;
+
+-- Drop metadata_fields table
+DROP TABLE metadata_fields;
@@ -0,0 +1,23 @@
+-- Create metadata_fields table for the typed metadata schema of each tenant
+CREATE TABLE metadata_fields (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    type VARCHAR(20) NOT NULL,
+    required BOOLEAN NOT NULL DEFAULT FALSE,
+    allowed_values TEXT[] NOT NULL DEFAULT '{}',
+    created_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+CREATE UNIQUE INDEX metadata_fields_tenant_name_idx ON metadata_fields(tenant_id, name);
+
+-- Add table comments for documentation
+COMMENT ON TABLE metadata_fields IS 'Metadata keys of a tenant with the type their values must have';
+
+-- Add column comments for metadata_fields table
+COMMENT ON COLUMN metadata_fields.name IS 'Metadata key the field applies to';
+COMMENT ON COLUMN metadata_fields.type IS 'Type of the values (string, number, date, enum)';
+COMMENT ON COLUMN metadata_fields.required IS 'Whether documents must have a value for the field';
+COMMENT ON COLUMN metadata_fields.allowed_values IS 'Values an enum field accepts';
@@ -0,0 +1,5 @@
+-- Drop indexes for metadata_templates table
+DROP INDEX metadata_templates_tenant_folder_idx;
+
+-- Drop metadata_templates table
+DROP TABLE metadata_templates;
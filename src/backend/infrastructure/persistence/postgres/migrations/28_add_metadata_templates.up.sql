@@ -0,0 +1,22 @@
+-- Create metadata_templates table for the metadata required of documents uploaded to a folder
+CREATE TABLE metadata_templates (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    folder_id UUID NOT NULL REFERENCES folders(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    fields TEXT[] NOT NULL DEFAULT '{}',
+    mode VARCHAR(20) NOT NULL DEFAULT 'enforce',
+    created_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+CREATE UNIQUE INDEX metadata_templates_tenant_folder_idx ON metadata_templates(tenant_id, folder_id);
+
+-- Add table comments for documentation
+COMMENT ON TABLE metadata_templates IS 'Metadata keys every document uploaded to a folder must carry';
+
+-- Add column comments for metadata_templates table
+COMMENT ON COLUMN metadata_templates.folder_id IS 'Folder whose uploads the template applies to; a folder has at most one template';
+COMMENT ON COLUMN metadata_templates.fields IS 'Metadata keys uploads must have a value for';
+COMMENT ON COLUMN metadata_templates.mode IS 'enforce rejects uploads missing fields, prompt only asks clients to fill them in';
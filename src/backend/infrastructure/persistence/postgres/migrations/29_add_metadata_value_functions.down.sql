@@ -0,0 +1,3 @@
+-- Drop metadata value parsing functions
+DROP FUNCTION metadata_date(TEXT);
+DROP FUNCTION metadata_number(TEXT);
@@ -0,0 +1,28 @@
+-- Parse the value of a number metadata field, NULL when it is not a number. Metadata filters of
+-- the postgres search provider compare values with it, skipping values stored before their field
+-- was defined instead of failing the search.
+CREATE OR REPLACE FUNCTION metadata_number(value TEXT) RETURNS DOUBLE PRECISION AS $$
+BEGIN
+    IF value !~ '^\s*[-+]?([0-9]+\.?[0-9]*|\.[0-9]+)([eE][-+]?[0-9]+)?\s*$' THEN
+        RETURN NULL;
+    END IF;
+    RETURN value::DOUBLE PRECISION;
+EXCEPTION WHEN OTHERS THEN
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql IMMUTABLE;
+
+-- Parse the value of a date metadata field, a date at midnight UTC or a date and time with time
+-- zone, NULL when it is not a date
+CREATE OR REPLACE FUNCTION metadata_date(value TEXT) RETURNS TIMESTAMPTZ AS $$
+BEGIN
+    IF value ~ '^\s*[0-9]{4}-[0-9]{2}-[0-9]{2}\s*$' THEN
+        RETURN (trim(value) || 'T00:00:00Z')::TIMESTAMPTZ;
+    ELSIF value ~ '^\s*[0-9]{4}-[0-9]{2}-[0-9]{2}T[0-9:.]+(Z|[-+][0-9]{2}:[0-9]{2})\s*$' THEN
+        RETURN trim(value)::TIMESTAMPTZ;
+    END IF;
+    RETURN NULL;
+EXCEPTION WHEN OTHERS THEN
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql IMMUTABLE;
@@ -0,0 +1,20 @@
+-- Drop the trigger that updates ancestor rollups as folders move or are deleted
+DROP TRIGGER move_folder_stats ON folders;
+
+-- Drop the function that moves folder rollups between ancestors
+DROP FUNCTION move_folder_stats_function();
+
+-- Drop the trigger that updates folder rollups as documents change
+DROP TRIGGER update_folder_stats ON documents;
+
+-- Drop the function that updates the rollups of the folders of a document
+DROP FUNCTION update_folder_stats_function();
+
+-- Drop the function that adds to the rollups of a folder and its ancestors
+DROP FUNCTION add_folder_stats(UUID, BIGINT, BIGINT, TIMESTAMP);
+
+-- Remove rollup columns from the folders table
+ALTER TABLE folders
+DROP COLUMN document_count,
+DROP COLUMN total_size,
+DROP COLUMN last_modified_at;
@@ -0,0 +1,91 @@
+-- Add rollups of the documents in each folder and its subfolders, so folder sizes are shown without
+-- walking the tree
+ALTER TABLE folders
+ADD COLUMN document_count BIGINT NOT NULL DEFAULT 0,
+ADD COLUMN total_size BIGINT NOT NULL DEFAULT 0,
+ADD COLUMN last_modified_at TIMESTAMP NULL;
+
+-- Create a function adding documents and bytes to the rollups of a folder and all its ancestors
+CREATE OR REPLACE FUNCTION add_folder_stats(folder UUID, documents BIGINT, bytes BIGINT, modified TIMESTAMP)
+RETURNS void AS $$
+BEGIN
+    WITH RECURSIVE ancestors(id, parent_id) AS (
+        SELECT id, parent_id FROM folders WHERE id = folder
+        UNION ALL
+        SELECT f.id, f.parent_id FROM folders f JOIN ancestors a ON f.id = a.parent_id
+    )
+    UPDATE folders
+    SET document_count = document_count + documents,
+        total_size = total_size + bytes,
+        last_modified_at = GREATEST(COALESCE(last_modified_at, modified), modified)
+    WHERE id IN (SELECT id FROM ancestors);
+END;
+$$ LANGUAGE plpgsql;
+
+-- Create a function updating the rollups of the folders of a document added, changed, moved or removed
+CREATE OR REPLACE FUNCTION update_folder_stats_function()
+RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        PERFORM add_folder_stats(NEW.folder_id, 1, NEW.size, NEW.updated_at);
+    ELSIF TG_OP = 'DELETE' THEN
+        PERFORM add_folder_stats(OLD.folder_id, -1, -OLD.size, NOW()::TIMESTAMP);
+    ELSIF OLD.folder_id = NEW.folder_id THEN
+        PERFORM add_folder_stats(NEW.folder_id, 0, NEW.size - OLD.size, NEW.updated_at);
+    ELSE
+        PERFORM add_folder_stats(OLD.folder_id, -1, -OLD.size, NEW.updated_at);
+        PERFORM add_folder_stats(NEW.folder_id, 1, NEW.size, NEW.updated_at);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Create a trigger updating folder rollups as documents change
+CREATE TRIGGER update_folder_stats
+AFTER INSERT OR DELETE OR UPDATE OF folder_id, size, updated_at ON documents
+FOR EACH ROW
+EXECUTE FUNCTION update_folder_stats_function();
+
+-- Create a function moving the rollups of a folder between the ancestors it is moved between, and
+-- removing them from its ancestors when it is deleted. Documents deleted along with a folder do not
+-- update the rollups themselves, as their folder is gone by then.
+CREATE OR REPLACE FUNCTION move_folder_stats_function()
+RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        PERFORM add_folder_stats(OLD.parent_id, -OLD.document_count, -OLD.total_size, NOW()::TIMESTAMP);
+    ELSIF OLD.parent_id IS DISTINCT FROM NEW.parent_id THEN
+        PERFORM add_folder_stats(OLD.parent_id, -NEW.document_count, -NEW.total_size, NOW()::TIMESTAMP);
+        PERFORM add_folder_stats(NEW.parent_id, NEW.document_count, NEW.total_size, NOW()::TIMESTAMP);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Create a trigger updating the rollups of ancestors as folders move or are deleted
+CREATE TRIGGER move_folder_stats
+AFTER DELETE OR UPDATE OF parent_id ON folders
+FOR EACH ROW
+EXECUTE FUNCTION move_folder_stats_function();
+
+-- Compute the rollups of the documents stored before
+WITH RECURSIVE tree(ancestor_id, id) AS (
+    SELECT id, id FROM folders
+    UNION ALL
+    SELECT t.ancestor_id, f.id FROM folders f JOIN tree t ON f.parent_id = t.id
+)
+UPDATE folders
+SET document_count = stats.document_count,
+    total_size = stats.total_size,
+    last_modified_at = stats.last_modified_at
+FROM (
+    SELECT t.ancestor_id, COUNT(d.id) AS document_count, SUM(d.size) AS total_size, MAX(d.updated_at) AS last_modified_at
+    FROM tree t JOIN documents d ON d.folder_id = t.id
+    GROUP BY t.ancestor_id
+) stats
+WHERE folders.id = stats.ancestor_id;
+
+-- Add comments to the new columns for documentation
+COMMENT ON COLUMN folders.document_count IS 'Number of documents in the folder and its subfolders, maintained by triggers';
+COMMENT ON COLUMN folders.total_size IS 'Total size in bytes of the documents in the folder and its subfolders, maintained by triggers';
+COMMENT ON COLUMN folders.last_modified_at IS 'When a document in the folder or its subfolders was last added, changed or removed';
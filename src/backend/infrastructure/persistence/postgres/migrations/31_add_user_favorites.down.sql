@@ -0,0 +1,6 @@
+-- Drop indexes for user_favorites table
+DROP INDEX user_favorites_user_created_at_idx;
+DROP INDEX user_favorites_user_resource_idx;
+
+-- Drop user_favorites table
+DROP TABLE user_favorites;
@@ -0,0 +1,18 @@
+-- Create user_favorites table for the documents and folders users starred
+CREATE TABLE user_favorites (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    resource_type VARCHAR(20) NOT NULL,
+    resource_id UUID NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+CREATE UNIQUE INDEX user_favorites_user_resource_idx ON user_favorites(tenant_id, user_id, resource_type, resource_id);
+CREATE INDEX user_favorites_user_created_at_idx ON user_favorites(tenant_id, user_id, created_at DESC);
+
+-- Add table comments for documentation
+COMMENT ON TABLE user_favorites IS 'Documents and folders users starred for their Starred view';
+
+-- Add column comments for user_favorites table
+COMMENT ON COLUMN user_favorites.resource_type IS 'document or folder';
+COMMENT ON COLUMN user_favorites.resource_id IS 'Starred document or folder; favorites of deleted resources are removed when listed';
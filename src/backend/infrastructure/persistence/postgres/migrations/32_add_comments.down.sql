@@ -0,0 +1,6 @@
+-- Drop indexes for comments table
+DROP INDEX comments_parent_id_idx;
+DROP INDEX comments_tenant_document_created_at_idx;
+
+-- Drop comments table
+DROP TABLE comments;
@@ -0,0 +1,32 @@
+-- Create comments table for the comment threads of documents
+CREATE TABLE comments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    document_id UUID NOT NULL REFERENCES documents(id) ON DELETE CASCADE,
+    parent_id UUID REFERENCES comments(id) ON DELETE CASCADE,
+    author_id UUID NOT NULL REFERENCES users(id),
+    body TEXT NOT NULL,
+    anchor_page INTEGER NOT NULL DEFAULT 0,
+    anchor_x DOUBLE PRECISION NOT NULL DEFAULT 0,
+    anchor_y DOUBLE PRECISION NOT NULL DEFAULT 0,
+    anchor_width DOUBLE PRECISION NOT NULL DEFAULT 0,
+    anchor_height DOUBLE PRECISION NOT NULL DEFAULT 0,
+    resolved_by UUID REFERENCES users(id),
+    resolved_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+CREATE INDEX comments_tenant_document_created_at_idx ON comments(tenant_id, document_id, created_at);
+CREATE INDEX comments_parent_id_idx ON comments(parent_id);
+
+-- Add table comments for documentation
+COMMENT ON TABLE comments IS 'Comments users left on documents, in threads optionally anchored to a page or region';
+
+-- Add column comments for comments table
+COMMENT ON COLUMN comments.parent_id IS 'Comment starting the thread a reply belongs to, NULL for the thread itself';
+COMMENT ON COLUMN comments.anchor_page IS '1-based page the thread is anchored to, 0 for the whole document';
+COMMENT ON COLUMN comments.anchor_x IS 'Left edge of the anchored region, relative to the page width';
+COMMENT ON COLUMN comments.anchor_y IS 'Top edge of the anchored region, relative to the page height';
+COMMENT ON COLUMN comments.anchor_width IS 'Width of the anchored region relative to the page width, 0 for the whole page';
+COMMENT ON COLUMN comments.anchor_height IS 'Height of the anchored region relative to the page height, 0 for the whole page';
+COMMENT ON COLUMN comments.resolved_at IS 'When the thread was resolved, NULL while it is open';
@@ -0,0 +1,24 @@
+-- Remove lock columns from the documents table
+ALTER TABLE documents
+DROP COLUMN locked_by,
+DROP COLUMN locked_at;
+
+-- Drop indexes for approval_decisions table
+DROP INDEX approval_decisions_approver_decision_idx;
+DROP INDEX approval_decisions_request_approver_idx;
+
+-- Drop approval_decisions table
+DROP TABLE approval_decisions;
+
+-- Drop indexes for approval_requests table
+DROP INDEX approval_requests_pending_document_idx;
+DROP INDEX approval_requests_tenant_document_created_at_idx;
+
+-- Drop approval_requests table
+DROP TABLE approval_requests;
+
+-- Drop indexes for approval_workflows table
+DROP INDEX approval_workflows_tenant_folder_idx;
+
+-- Drop approval_workflows table
+DROP TABLE approval_workflows;
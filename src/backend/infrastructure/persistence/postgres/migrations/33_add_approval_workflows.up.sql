@@ -0,0 +1,67 @@
+-- Create approval_workflows table for the approval chains attached to folders
+CREATE TABLE approval_workflows (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    folder_id UUID NOT NULL REFERENCES folders(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    mode VARCHAR(20) NOT NULL DEFAULT 'sequential',
+    approvers TEXT[] NOT NULL DEFAULT '{}',
+    created_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+CREATE UNIQUE INDEX approval_workflows_tenant_folder_idx ON approval_workflows(tenant_id, folder_id);
+
+-- Create approval_requests table for the documents submitted for approval
+CREATE TABLE approval_requests (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    document_id UUID NOT NULL REFERENCES documents(id) ON DELETE CASCADE,
+    folder_id UUID NOT NULL,
+    workflow_id UUID REFERENCES approval_workflows(id) ON DELETE SET NULL,
+    mode VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    submitted_by UUID NOT NULL REFERENCES users(id),
+    comment TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMP
+);
+CREATE INDEX approval_requests_tenant_document_created_at_idx ON approval_requests(tenant_id, document_id, created_at);
+-- A document has at most one pending request
+CREATE UNIQUE INDEX approval_requests_pending_document_idx ON approval_requests(document_id) WHERE status = 'pending';
+
+-- Create approval_decisions table for the decisions of the approvers of each request
+CREATE TABLE approval_decisions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    request_id UUID NOT NULL REFERENCES approval_requests(id) ON DELETE CASCADE,
+    approver_id UUID NOT NULL REFERENCES users(id),
+    position INTEGER NOT NULL,
+    decision VARCHAR(20) NOT NULL DEFAULT 'pending',
+    comment TEXT NOT NULL DEFAULT '',
+    decided_at TIMESTAMP
+);
+CREATE UNIQUE INDEX approval_decisions_request_approver_idx ON approval_decisions(request_id, approver_id);
+CREATE INDEX approval_decisions_approver_decision_idx ON approval_decisions(approver_id, decision);
+
+-- Add lock columns to the documents table; documents are locked while they await approval
+ALTER TABLE documents
+ADD COLUMN locked_by UUID REFERENCES users(id),
+ADD COLUMN locked_at TIMESTAMP;
+
+-- Add table comments for documentation
+COMMENT ON TABLE approval_workflows IS 'Approval chains documents of a folder go through when submitted for approval';
+COMMENT ON TABLE approval_requests IS 'Documents submitted for approval and the state of their approval';
+COMMENT ON TABLE approval_decisions IS 'Decisions of the approvers of approval requests';
+
+-- Add column comments
+COMMENT ON COLUMN approval_workflows.folder_id IS 'Folder whose documents the workflow applies to; a folder has at most one workflow';
+COMMENT ON COLUMN approval_workflows.mode IS 'sequential asks the approvers in order, parallel asks them all at once';
+COMMENT ON COLUMN approval_workflows.approvers IS 'User IDs of the approvers in the order they are asked';
+COMMENT ON COLUMN approval_requests.workflow_id IS 'Workflow the request was submitted through; its mode and approvers are copied to the request';
+COMMENT ON COLUMN approval_requests.status IS 'pending, approved, rejected or cancelled';
+COMMENT ON COLUMN approval_requests.completed_at IS 'When the request was approved, rejected or cancelled, NULL while pending';
+COMMENT ON COLUMN approval_decisions.position IS 'Order of the approver in the chain, from 0';
+COMMENT ON COLUMN approval_decisions.decision IS 'pending, approved or rejected';
+COMMENT ON COLUMN documents.locked_by IS 'User who locked the document, such as by submitting it for approval';
+COMMENT ON COLUMN documents.locked_at IS 'When the document was locked, NULL when it is not locked';
@@ -0,0 +1,12 @@
+-- Drop indexes for signature_signers table
+DROP INDEX signature_signers_request_email_idx;
+
+-- Drop signature_signers table
+DROP TABLE signature_signers;
+
+-- Drop indexes for signature_requests table
+DROP INDEX signature_requests_provider_envelope_idx;
+DROP INDEX signature_requests_tenant_document_created_at_idx;
+
+-- Drop signature_requests table
+DROP TABLE signature_requests;
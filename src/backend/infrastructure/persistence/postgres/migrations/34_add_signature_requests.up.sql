@@ -0,0 +1,49 @@
+-- Create signature_requests table for the documents sent to electronic signature providers
+CREATE TABLE signature_requests (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    document_id UUID NOT NULL REFERENCES documents(id) ON DELETE CASCADE,
+    folder_id UUID NOT NULL,
+    version_id UUID NOT NULL REFERENCES document_versions(id) ON DELETE CASCADE,
+    provider VARCHAR(50) NOT NULL,
+    envelope_id VARCHAR(255) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    subject VARCHAR(255) NOT NULL,
+    message TEXT NOT NULL DEFAULT '',
+    requested_by UUID NOT NULL REFERENCES users(id),
+    signed_version_id UUID REFERENCES document_versions(id) ON DELETE SET NULL,
+    status_reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMP
+);
+CREATE INDEX signature_requests_tenant_document_created_at_idx ON signature_requests(tenant_id, document_id, created_at);
+-- Provider callbacks look requests up by their envelope
+CREATE UNIQUE INDEX signature_requests_provider_envelope_idx ON signature_requests(provider, envelope_id);
+
+-- Create signature_signers table for the signers of each request
+CREATE TABLE signature_signers (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    request_id UUID NOT NULL REFERENCES signature_requests(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    email VARCHAR(255) NOT NULL,
+    routing_order INTEGER NOT NULL DEFAULT 1,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    signed_at TIMESTAMP,
+    ip_address VARCHAR(45) NOT NULL DEFAULT ''
+);
+CREATE UNIQUE INDEX signature_signers_request_email_idx ON signature_signers(request_id, lower(email));
+
+-- Add table comments for documentation
+COMMENT ON TABLE signature_requests IS 'Documents sent to electronic signature providers and the state of their envelopes';
+COMMENT ON TABLE signature_signers IS 'Signers of signature requests, with the signing details reported by the provider';
+
+-- Add column comments
+COMMENT ON COLUMN signature_requests.version_id IS 'Version of the document sent for signature';
+COMMENT ON COLUMN signature_requests.provider IS 'docusign or adobesign';
+COMMENT ON COLUMN signature_requests.envelope_id IS 'ID of the envelope or agreement at the provider';
+COMMENT ON COLUMN signature_requests.status IS 'pending, sent, completed, declined or voided';
+COMMENT ON COLUMN signature_requests.signed_version_id IS 'Version holding the signed document, once completed';
+COMMENT ON COLUMN signature_requests.status_reason IS 'Why the request was declined or voided';
+COMMENT ON COLUMN signature_signers.routing_order IS 'Signers with the same order sign in parallel, lower orders sign first';
+COMMENT ON COLUMN signature_signers.ip_address IS 'IP address the signer signed from, when the provider reports it';
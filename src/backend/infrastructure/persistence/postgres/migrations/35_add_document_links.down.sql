@@ -0,0 +1,6 @@
+-- Drop indexes for document_links table
+DROP INDEX document_links_target_id_idx;
+DROP INDEX document_links_source_target_type_idx;
+
+-- Drop document_links table
+DROP TABLE document_links;
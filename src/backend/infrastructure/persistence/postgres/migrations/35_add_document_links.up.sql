@@ -0,0 +1,23 @@
+-- Create document_links table for the relations between documents
+CREATE TABLE document_links (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    source_id UUID NOT NULL REFERENCES documents(id) ON DELETE CASCADE,
+    target_id UUID NOT NULL REFERENCES documents(id) ON DELETE CASCADE,
+    type VARCHAR(32) NOT NULL,
+    created_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT document_links_not_self CHECK (source_id <> target_id),
+    CONSTRAINT document_links_type_check CHECK (type IN ('relates-to', 'supersedes', 'attachment-of'))
+);
+CREATE UNIQUE INDEX document_links_source_target_type_idx ON document_links(source_id, target_id, type);
+CREATE INDEX document_links_target_id_idx ON document_links(target_id);
+
+-- Add table comments for documentation
+COMMENT ON TABLE document_links IS 'Directed relations between documents of a tenant, read as source <type> target';
+
+-- Add column comments for document_links table
+COMMENT ON COLUMN document_links.source_id IS 'Document the link starts from, such as the amendment superseding a contract';
+COMMENT ON COLUMN document_links.target_id IS 'Document the link points to, such as the contract an amendment supersedes';
+COMMENT ON COLUMN document_links.type IS 'How the source relates to the target: relates-to, supersedes or attachment-of';
@@ -0,0 +1,9 @@
+-- Drop the index of document numbers
+DROP INDEX document_metadata_document_number_idx;
+
+-- Drop indexes for numbering_sequences table
+DROP INDEX numbering_sequences_tenant_default_idx;
+DROP INDEX numbering_sequences_tenant_folder_idx;
+
+-- Drop numbering_sequences table
+DROP TABLE numbering_sequences;
@@ -0,0 +1,32 @@
+-- Create numbering_sequences table for the numbers assigned to uploaded documents
+CREATE TABLE numbering_sequences (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    folder_id UUID REFERENCES folders(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    format VARCHAR(64) NOT NULL,
+    padding INTEGER NOT NULL DEFAULT 5,
+    mode VARCHAR(16) NOT NULL DEFAULT 'gap-free',
+    reset_yearly BOOLEAN NOT NULL DEFAULT FALSE,
+    last_value BIGINT NOT NULL DEFAULT 0,
+    last_period VARCHAR(4) NOT NULL DEFAULT '',
+    created_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT numbering_sequences_mode_check CHECK (mode IN ('gap-free', 'gap-tolerant'))
+);
+CREATE UNIQUE INDEX numbering_sequences_tenant_folder_idx ON numbering_sequences(tenant_id, folder_id) WHERE folder_id IS NOT NULL;
+CREATE UNIQUE INDEX numbering_sequences_tenant_default_idx ON numbering_sequences(tenant_id) WHERE folder_id IS NULL;
+
+-- Add table comments for documentation
+COMMENT ON TABLE numbering_sequences IS 'Sequences numbering the documents uploaded to a folder, or to any folder of a tenant without one';
+
+-- Add column comments for numbering_sequences table
+COMMENT ON COLUMN numbering_sequences.folder_id IS 'Folder numbered by the sequence, NULL for the tenant default';
+COMMENT ON COLUMN numbering_sequences.format IS 'Number format with the tokens {SEQ}, {YYYY}, {YY} and {MM}, such as INV-{YYYY}-{SEQ}';
+COMMENT ON COLUMN numbering_sequences.mode IS 'gap-free assigns numbers in the upload transaction, gap-tolerant before it';
+COMMENT ON COLUMN numbering_sequences.last_value IS 'Value of the last number assigned, 0 before the first';
+COMMENT ON COLUMN numbering_sequences.last_period IS 'Year of the last number assigned by a sequence reset yearly';
+
+-- Document numbers are looked up by value, so index the reserved metadata key
+CREATE INDEX document_metadata_document_number_idx ON document_metadata(value) WHERE key = 'document_number';
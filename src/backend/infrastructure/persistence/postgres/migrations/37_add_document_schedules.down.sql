@@ -0,0 +1,9 @@
+-- Drop indexes for document publication schedules
+DROP INDEX documents_published_expire_at_idx;
+DROP INDEX documents_scheduled_publish_at_idx;
+
+-- Remove publication schedule columns from the documents table
+ALTER TABLE documents
+DROP COLUMN expire_at,
+DROP COLUMN publish_at,
+DROP COLUMN visibility;
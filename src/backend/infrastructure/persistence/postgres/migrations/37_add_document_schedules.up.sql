@@ -0,0 +1,14 @@
+-- Add publication schedule columns to the documents table; existing documents stay published
+ALTER TABLE documents
+ADD COLUMN visibility VARCHAR(20) NOT NULL DEFAULT 'published',
+ADD COLUMN publish_at TIMESTAMP,
+ADD COLUMN expire_at TIMESTAMP;
+
+-- Add indexes for the publication scheduler finding the documents due to be published or to expire
+CREATE INDEX documents_scheduled_publish_at_idx ON documents(publish_at) WHERE visibility = 'scheduled';
+CREATE INDEX documents_published_expire_at_idx ON documents(expire_at) WHERE visibility = 'published' AND expire_at IS NOT NULL;
+
+-- Add column comments
+COMMENT ON COLUMN documents.visibility IS 'published, scheduled or expired; only published documents are visible to readers';
+COMMENT ON COLUMN documents.publish_at IS 'When a scheduled document is published, NULL to publish it right away';
+COMMENT ON COLUMN documents.expire_at IS 'When the document expires and is hidden from readers, NULL when it does not expire';
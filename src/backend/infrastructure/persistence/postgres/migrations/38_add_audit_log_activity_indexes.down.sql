@@ -0,0 +1,4 @@
+-- Drop indexes for the activity timelines of documents and folders
+DROP INDEX audit_logs_upload_folder_idx;
+DROP INDEX audit_logs_share_link_document_idx;
+DROP INDEX audit_logs_permission_resource_idx;
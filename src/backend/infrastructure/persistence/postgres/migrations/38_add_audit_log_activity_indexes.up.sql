@@ -0,0 +1,5 @@
+-- Add indexes for the activity timelines of documents and folders finding the permission and share
+-- link entries of a resource and the uploads to a folder; indexes on the parent cascade to every partition
+CREATE INDEX audit_logs_permission_resource_idx ON audit_logs(tenant_id, (COALESCE(after, before)->>'resourceID')) WHERE resource_type = 'permission';
+CREATE INDEX audit_logs_share_link_document_idx ON audit_logs(tenant_id, (after->>'document_id')) WHERE resource_type = 'share_link';
+CREATE INDEX audit_logs_upload_folder_idx ON audit_logs(tenant_id, (after->>'folderID')) WHERE resource_type = 'document' AND action = 'upload';
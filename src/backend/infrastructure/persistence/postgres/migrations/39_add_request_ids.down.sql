@@ -0,0 +1,3 @@
+-- Drop request ID columns
+ALTER TABLE webhook_deliveries DROP COLUMN request_id;
+ALTER TABLE outbox_messages DROP COLUMN request_id;
@@ -0,0 +1,7 @@
+-- Carry the ID of the API request that raised an event through the outbox and its webhook deliveries
+ALTER TABLE outbox_messages ADD COLUMN request_id VARCHAR(128) NULL;
+ALTER TABLE webhook_deliveries ADD COLUMN request_id VARCHAR(128) NULL;
+
+-- Add column comments for request ID columns
+COMMENT ON COLUMN outbox_messages.request_id IS 'ID of the API request that raised the event, published with the event for correlation';
+COMMENT ON COLUMN webhook_deliveries.request_id IS 'ID of the API request that raised the event, sent in the X-Request-ID header of each attempt';
@@ -0,0 +1,6 @@
+-- Drop indexes for tenant_deletions table
+DROP INDEX IF EXISTS tenant_deletions_active_tenant_idx;
+DROP INDEX IF EXISTS tenant_deletions_claim_idx;
+
+-- Drop tenant_deletions table
+DROP TABLE tenant_deletions;
@@ -0,0 +1,41 @@
+-- Create tenant_deletions table for the offboarding of tenants. It has no foreign key to tenants, as
+-- a deletion and its destruction report are kept after the tenant is erased.
+CREATE TABLE tenant_deletions (
+    id UUID PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    tenant_name VARCHAR(255) NOT NULL,
+    previous_status VARCHAR(20) NOT NULL,
+    requested_by VARCHAR(255) NOT NULL,
+    reason TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'scheduled',
+    scheduled_for TIMESTAMP NOT NULL,
+    report TEXT NOT NULL DEFAULT '',
+    report_signature TEXT NOT NULL DEFAULT '',
+    signing_key_id VARCHAR(64) NOT NULL DEFAULT '',
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    started_at TIMESTAMP NULL,
+    completed_at TIMESTAMP NULL,
+    CONSTRAINT tenant_deletions_status_check CHECK (status IN ('scheduled', 'running', 'completed', 'failed', 'cancelled'))
+);
+
+-- Create indexes for worker polling, and allow one unfinished deletion per tenant
+CREATE INDEX tenant_deletions_claim_idx ON tenant_deletions(scheduled_for) WHERE status IN ('scheduled', 'running', 'failed');
+CREATE UNIQUE INDEX tenant_deletions_active_tenant_idx ON tenant_deletions(tenant_id) WHERE status IN ('scheduled', 'running', 'failed');
+
+-- Add table comments for documentation
+COMMENT ON TABLE tenant_deletions IS 'Offboarding of tenants, frozen during a grace period and then erased by the worker';
+
+-- Add column comments for tenant_deletions table
+COMMENT ON COLUMN tenant_deletions.tenant_id IS 'Tenant being deleted; the tenant no longer exists once the deletion completes';
+COMMENT ON COLUMN tenant_deletions.tenant_name IS 'Name of the tenant, kept for the destruction report';
+COMMENT ON COLUMN tenant_deletions.previous_status IS 'Status of the tenant before it was frozen, restored when the deletion is cancelled';
+COMMENT ON COLUMN tenant_deletions.requested_by IS 'Operator who scheduled the deletion';
+COMMENT ON COLUMN tenant_deletions.reason IS 'Why the tenant is deleted, such as the reference of the erasure request';
+COMMENT ON COLUMN tenant_deletions.status IS 'Status of the deletion (scheduled, running, completed, failed, cancelled)';
+COMMENT ON COLUMN tenant_deletions.scheduled_for IS 'End of the grace period, after which the tenant is erased';
+COMMENT ON COLUMN tenant_deletions.report IS 'Destruction report in JSON, accumulated over attempts and signed once the erasure is verified';
+COMMENT ON COLUMN tenant_deletions.report_signature IS 'Base64 Ed25519 signature of the report';
+COMMENT ON COLUMN tenant_deletions.signing_key_id IS 'Identifier of the key the report is signed with';
+COMMENT ON COLUMN tenant_deletions.updated_at IS 'Timestamp of the last progress update, used to retry deletions that failed or were abandoned by a worker';
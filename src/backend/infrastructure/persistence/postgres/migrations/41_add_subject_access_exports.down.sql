@@ -0,0 +1,6 @@
+-- Drop indexes for subject_access_exports table
+DROP INDEX IF EXISTS subject_access_exports_tenant_id_idx;
+DROP INDEX IF EXISTS subject_access_exports_claim_idx;
+
+-- Drop subject_access_exports table
+DROP TABLE subject_access_exports;
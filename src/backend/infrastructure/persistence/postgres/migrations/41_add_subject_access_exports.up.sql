@@ -0,0 +1,36 @@
+-- Create subject_access_exports table for background exports of the personal data of users
+CREATE TABLE subject_access_exports (
+    id UUID PRIMARY KEY,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    subject_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    requested_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    document_count INTEGER NOT NULL DEFAULT 0,
+    audit_event_count INTEGER NOT NULL DEFAULT 0,
+    archive_path VARCHAR(1000) NOT NULL DEFAULT '',
+    archive_size BIGINT NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    started_at TIMESTAMP NULL,
+    completed_at TIMESTAMP NULL,
+    CONSTRAINT subject_access_exports_status_check CHECK (status IN ('pending', 'running', 'completed', 'failed'))
+);
+
+-- Create indexes for worker polling and tenant lookups
+CREATE INDEX subject_access_exports_claim_idx ON subject_access_exports(created_at) WHERE status IN ('pending', 'running');
+CREATE INDEX subject_access_exports_tenant_id_idx ON subject_access_exports(tenant_id);
+
+-- Add table comments for documentation
+COMMENT ON TABLE subject_access_exports IS 'Background exports of the personal data of users for GDPR subject access requests, run by the worker';
+
+-- Add column comments for subject_access_exports table
+COMMENT ON COLUMN subject_access_exports.subject_id IS 'User whose personal data is exported';
+COMMENT ON COLUMN subject_access_exports.requested_by IS 'Administrator who requested the export and may download the archive';
+COMMENT ON COLUMN subject_access_exports.status IS 'Status of the export (pending, running, completed, failed)';
+COMMENT ON COLUMN subject_access_exports.document_count IS 'Number of documents of the subject written to the archive';
+COMMENT ON COLUMN subject_access_exports.audit_event_count IS 'Number of audit events of the subject written to the archive';
+COMMENT ON COLUMN subject_access_exports.archive_path IS 'Storage path of the completed archive';
+COMMENT ON COLUMN subject_access_exports.archive_size IS 'Size of the completed archive in bytes';
+COMMENT ON COLUMN subject_access_exports.error IS 'Reason the export failed';
+COMMENT ON COLUMN subject_access_exports.updated_at IS 'Timestamp of the last progress update, used to reclaim exports abandoned by a worker';
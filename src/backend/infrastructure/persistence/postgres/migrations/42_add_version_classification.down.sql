@@ -0,0 +1,5 @@
+-- Drop the classification index on document_versions
+DROP INDEX IF EXISTS document_versions_unclassified_idx;
+
+-- Drop the classification column from document_versions
+ALTER TABLE document_versions DROP COLUMN classified_at;
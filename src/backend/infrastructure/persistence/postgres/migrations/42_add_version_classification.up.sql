@@ -0,0 +1,9 @@
+-- Track when the content of each document version was classified for personal data
+ALTER TABLE document_versions ADD COLUMN classified_at TIMESTAMP NULL;
+
+-- Index the available versions not classified yet, for the classification stage of the worker
+CREATE INDEX document_versions_unclassified_idx ON document_versions(created_at)
+    WHERE classified_at IS NULL AND status = 'available';
+
+-- Add column comments for documentation
+COMMENT ON COLUMN document_versions.classified_at IS 'When the content was classified for personal data, NULL until classified';
@@ -0,0 +1,5 @@
+-- Drop the watermark column from share_links
+ALTER TABLE share_links DROP COLUMN watermark;
+
+-- Drop the watermark column from folders
+ALTER TABLE folders DROP COLUMN watermark_downloads;
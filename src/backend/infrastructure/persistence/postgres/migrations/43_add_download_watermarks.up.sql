@@ -0,0 +1,9 @@
+-- Stamp PDFs downloaded from a folder with who downloaded them, when, and from which tenant
+ALTER TABLE folders ADD COLUMN watermark_downloads BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Stamp PDFs downloaded through a share link with the link, when, and from which tenant
+ALTER TABLE share_links ADD COLUMN watermark BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Add column comments for documentation
+COMMENT ON COLUMN folders.watermark_downloads IS 'Whether PDFs of the documents directly in the folder are watermarked on download';
+COMMENT ON COLUMN share_links.watermark IS 'Whether PDFs downloaded through the link are watermarked';
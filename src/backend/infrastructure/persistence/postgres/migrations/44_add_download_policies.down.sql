@@ -0,0 +1,12 @@
+-- Drop index for download_counters table
+DROP INDEX IF EXISTS download_counters_day_idx;
+
+-- Drop download_counters table
+DROP TABLE download_counters;
+
+-- Drop the download policy columns from folders
+ALTER TABLE folders DROP CONSTRAINT folders_reauthentication_minutes_check;
+ALTER TABLE folders DROP CONSTRAINT folders_daily_download_limit_check;
+ALTER TABLE folders DROP COLUMN reauthentication_minutes;
+ALTER TABLE folders DROP COLUMN daily_download_limit;
+ALTER TABLE folders DROP COLUMN download_view_only;
@@ -0,0 +1,29 @@
+-- Restrict how the documents directly in a folder can be downloaded
+ALTER TABLE folders ADD COLUMN download_view_only BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE folders ADD COLUMN daily_download_limit INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE folders ADD COLUMN reauthentication_minutes INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE folders ADD CONSTRAINT folders_daily_download_limit_check CHECK (daily_download_limit BETWEEN 0 AND 10000);
+ALTER TABLE folders ADD CONSTRAINT folders_reauthentication_minutes_check CHECK (reauthentication_minutes BETWEEN 0 AND 1440);
+
+-- Create download_counters table counting the daily downloads of users from folders that limit them
+CREATE TABLE download_counters (
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    folder_id UUID NOT NULL REFERENCES folders(id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    count INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (tenant_id, user_id, folder_id, day)
+);
+
+-- Create index for deleting the counters of past days
+CREATE INDEX download_counters_day_idx ON download_counters(day);
+
+-- Add table comments for documentation
+COMMENT ON TABLE download_counters IS 'Daily downloads of users from folders with a daily download limit; only the current UTC day is read';
+
+-- Add column comments for documentation
+COMMENT ON COLUMN folders.download_view_only IS 'Whether the documents directly in the folder can be previewed but not downloaded';
+COMMENT ON COLUMN folders.daily_download_limit IS 'Downloads per user and UTC day from the folder, unlimited when 0';
+COMMENT ON COLUMN folders.reauthentication_minutes IS 'Minutes since signing in after which users sign in again to download from the folder, not required when 0';
+COMMENT ON COLUMN download_counters.day IS 'UTC day the downloads were counted in';
@@ -0,0 +1,3 @@
+-- Drop the checksum columns from document_versions
+ALTER TABLE document_versions DROP COLUMN integrity_verified_at;
+ALTER TABLE document_versions DROP COLUMN client_checksum;
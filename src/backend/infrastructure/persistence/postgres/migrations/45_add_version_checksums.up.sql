@@ -0,0 +1,7 @@
+-- Record the SHA-256 checksum clients send with uploads and when stored content last matched its hash
+ALTER TABLE document_versions ADD COLUMN client_checksum VARCHAR(64) NOT NULL DEFAULT '';
+ALTER TABLE document_versions ADD COLUMN integrity_verified_at TIMESTAMP NULL;
+
+-- Add column comments for documentation
+COMMENT ON COLUMN document_versions.client_checksum IS 'SHA-256 checksum the uploading client sent for the content, empty when it sent none';
+COMMENT ON COLUMN document_versions.integrity_verified_at IS 'When the stored content was last read back and matched content_hash, NULL until verified';
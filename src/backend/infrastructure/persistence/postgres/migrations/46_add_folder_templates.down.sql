@@ -0,0 +1,5 @@
+-- Drop indexes for folder_templates table
+DROP INDEX folder_templates_tenant_name_idx;
+
+-- Drop folder_templates table
+DROP TABLE folder_templates;
@@ -0,0 +1,20 @@
+-- Create folder_templates table for the folder trees new projects and tenants are set up from
+CREATE TABLE folder_templates (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    permissions JSONB NOT NULL DEFAULT '[]',
+    folders JSONB NOT NULL DEFAULT '[]',
+    created_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+CREATE UNIQUE INDEX folder_templates_tenant_name_idx ON folder_templates(tenant_id, name);
+
+-- Add table comments for documentation
+COMMENT ON TABLE folder_templates IS 'Folder trees with their permissions and metadata templates, applied to set up projects and tenants';
+
+-- Add column comments for folder_templates table
+COMMENT ON COLUMN folder_templates.permissions IS 'Grants on the folder the template is applied as: grantee_type, grantee_id and permission_type';
+COMMENT ON COLUMN folder_templates.folders IS 'Folders created below it, each with its name, permissions, metadata_fields, metadata_mode and subfolders';
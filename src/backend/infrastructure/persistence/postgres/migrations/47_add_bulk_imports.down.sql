@@ -0,0 +1,9 @@
+-- Drop bulk_import_items table
+DROP TABLE bulk_import_items;
+
+-- Drop indexes for bulk_import_jobs table
+DROP INDEX IF EXISTS bulk_import_jobs_tenant_id_idx;
+DROP INDEX IF EXISTS bulk_import_jobs_claim_idx;
+
+-- Drop bulk_import_jobs table
+DROP TABLE bulk_import_jobs;
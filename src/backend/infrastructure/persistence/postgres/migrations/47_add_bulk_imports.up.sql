@@ -0,0 +1,60 @@
+-- Create bulk_import_jobs table for imports of legacy archives into a tenant, run by the worker
+CREATE TABLE bulk_import_jobs (
+    id UUID PRIMARY KEY,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    source TEXT NOT NULL,
+    manifest TEXT NOT NULL DEFAULT '',
+    folder_id TEXT NOT NULL DEFAULT '',
+    dry_run BOOLEAN NOT NULL DEFAULT FALSE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    checkpoint TEXT COLLATE "C" NOT NULL DEFAULT '',
+    found_files INTEGER NOT NULL DEFAULT 0,
+    imported_files INTEGER NOT NULL DEFAULT 0,
+    imported_bytes BIGINT NOT NULL DEFAULT 0,
+    skipped_files INTEGER NOT NULL DEFAULT 0,
+    failed_files INTEGER NOT NULL DEFAULT 0,
+    missing_files INTEGER NOT NULL DEFAULT 0,
+    created_folders INTEGER NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    started_at TIMESTAMP NULL,
+    completed_at TIMESTAMP NULL,
+    CONSTRAINT bulk_import_jobs_status_check CHECK (status IN ('pending', 'running', 'completed', 'failed'))
+);
+
+-- Create indexes for worker polling and tenant lookups
+CREATE INDEX bulk_import_jobs_claim_idx ON bulk_import_jobs(created_at) WHERE status IN ('pending', 'running');
+CREATE INDEX bulk_import_jobs_tenant_id_idx ON bulk_import_jobs(tenant_id);
+
+-- Create bulk_import_items table holding the outcome of each file of a bulk import. Keys are
+-- compared byte by byte, in the order the sources list their files.
+CREATE TABLE bulk_import_items (
+    job_id UUID NOT NULL REFERENCES bulk_import_jobs(id) ON DELETE CASCADE,
+    source_key TEXT COLLATE "C" NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    document_id TEXT NOT NULL DEFAULT '',
+    size BIGINT NOT NULL DEFAULT 0,
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (job_id, source_key),
+    CONSTRAINT bulk_import_items_status_check CHECK (status IN ('imported', 'planned', 'skipped', 'failed', 'missing'))
+);
+
+-- Add table comments for documentation
+COMMENT ON TABLE bulk_import_jobs IS 'Imports of directory trees and S3 prefixes into a tenant, run and resumed by the worker';
+COMMENT ON TABLE bulk_import_items IS 'Outcome of each file of a bulk import, the lines of its reconciliation report';
+
+-- Add column comments for bulk_import_jobs table
+COMMENT ON COLUMN bulk_import_jobs.user_id IS 'User the documents are uploaded as';
+COMMENT ON COLUMN bulk_import_jobs.source IS 'Directory path or s3://bucket/prefix URL of the files to import';
+COMMENT ON COLUMN bulk_import_jobs.manifest IS 'Key of the CSV file in the source holding the metadata of the files; empty without a manifest';
+COMMENT ON COLUMN bulk_import_jobs.folder_id IS 'Folder the source is imported into; empty to import into the root of the tenant';
+COMMENT ON COLUMN bulk_import_jobs.dry_run IS 'Whether the job only reports what it would import';
+COMMENT ON COLUMN bulk_import_jobs.status IS 'Status of the import (pending, running, completed, failed)';
+COMMENT ON COLUMN bulk_import_jobs.checkpoint IS 'Key of the last file handled; a resumed job continues after it';
+COMMENT ON COLUMN bulk_import_jobs.found_files IS 'Number of files found in the source up to the checkpoint';
+COMMENT ON COLUMN bulk_import_jobs.missing_files IS 'Number of files listed in the manifest but not found in the source';
+COMMENT ON COLUMN bulk_import_jobs.error IS 'Reason the import failed';
+COMMENT ON COLUMN bulk_import_jobs.updated_at IS 'Timestamp of the last progress update, used to reclaim imports abandoned by a worker';
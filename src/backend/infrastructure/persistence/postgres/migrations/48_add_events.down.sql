@@ -0,0 +1,8 @@
+-- Drop indexes for events table
+DROP INDEX IF EXISTS events_folder_id_idx;
+DROP INDEX IF EXISTS events_document_id_idx;
+DROP INDEX IF EXISTS events_tenant_id_type_idx;
+DROP INDEX IF EXISTS events_tenant_id_occurred_at_idx;
+
+-- Drop events table
+DROP TABLE events;
@@ -0,0 +1,29 @@
+-- Create events table recording the domain events raised by the platform, which the event service
+-- persists before enqueueing them in the outbox
+CREATE TABLE events (
+    id UUID PRIMARY KEY,
+    type VARCHAR(100) NOT NULL,
+    tenant_id UUID NOT NULL,
+    payload JSONB NOT NULL,
+    request_id VARCHAR(255) NOT NULL DEFAULT '',
+    occurred_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes for listing a tenant's events, by type and by document or folder, and for cleanup
+CREATE INDEX events_tenant_id_occurred_at_idx ON events(tenant_id, occurred_at);
+CREATE INDEX events_tenant_id_type_idx ON events(tenant_id, type);
+CREATE INDEX events_document_id_idx ON events((payload->>'documentID')) WHERE payload ? 'documentID';
+CREATE INDEX events_folder_id_idx ON events((payload->>'folderID')) WHERE payload ? 'folderID';
+
+-- Add table comments for documentation
+COMMENT ON TABLE events IS 'Domain events raised by the platform';
+
+-- Add column comments for events table
+COMMENT ON COLUMN events.id IS 'Unique identifier for the event';
+COMMENT ON COLUMN events.type IS 'Type of the event';
+COMMENT ON COLUMN events.tenant_id IS 'Tenant that raised the event';
+COMMENT ON COLUMN events.payload IS 'Event payload';
+COMMENT ON COLUMN events.request_id IS 'API request that raised the event, empty for events raised by the worker';
+COMMENT ON COLUMN events.occurred_at IS 'Timestamp when the event occurred';
+COMMENT ON COLUMN events.created_at IS 'Timestamp when the event was recorded';
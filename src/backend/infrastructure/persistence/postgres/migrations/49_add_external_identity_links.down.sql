@@ -0,0 +1,6 @@
+-- Drop indexes for external_identity_links table
+DROP INDEX external_identity_links_user_id_idx;
+DROP INDEX external_identity_links_subject_idx;
+
+-- Drop external_identity_links table
+DROP TABLE external_identity_links;
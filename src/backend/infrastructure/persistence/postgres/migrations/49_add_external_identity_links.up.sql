@@ -0,0 +1,18 @@
+-- Create external_identity_links table tying accounts to the subjects of the tenant's identity provider
+CREATE TABLE external_identity_links (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    issuer VARCHAR(512) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+CREATE UNIQUE INDEX external_identity_links_subject_idx ON external_identity_links(tenant_id, issuer, subject);
+CREATE UNIQUE INDEX external_identity_links_user_id_idx ON external_identity_links(user_id, issuer);
+
+-- Add table comments for documentation
+COMMENT ON TABLE external_identity_links IS 'Links of accounts to IdP subjects, matched on every SSO sign-in after the first';
+
+-- Add column comments for external_identity_links table
+COMMENT ON COLUMN external_identity_links.issuer IS 'Issuer of the ID tokens the subject was asserted in';
+COMMENT ON COLUMN external_identity_links.subject IS 'sub claim the identity provider knows the user by, which unlike the email never changes';
@@ -0,0 +1,201 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid" // v1.3.0+ - For generating unique IDs for numbering sequences
+	"gorm.io/gorm"           // v1.25.0+ - ORM library for database operations
+	"gorm.io/gorm/clause"    // v1.25.0+ - For locking sequences while they advance
+
+	"../../../domain/models"
+	"../../../domain/repositories"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+)
+
+// numberingSequenceRepository implements the NumberingSequenceRepository interface using PostgreSQL
+type numberingSequenceRepository struct{}
+
+// NewNumberingSequenceRepository creates a new instance of the PostgreSQL implementation of NumberingSequenceRepository
+func NewNumberingSequenceRepository() repositories.NumberingSequenceRepository {
+	return &numberingSequenceRepository{}
+}
+
+// Create persists a new numbering sequence to the database
+func (r *numberingSequenceRepository) Create(ctx context.Context, sequence *models.NumberingSequence) (string, error) {
+	if err := sequence.Validate(); err != nil {
+		return "", errors.NewValidationError(err.Error())
+	}
+
+	if sequence.ID == "" {
+		sequence.ID = uuid.New().String()
+	}
+
+	now := time.Now()
+	if sequence.CreatedAt.IsZero() {
+		sequence.CreatedAt = now
+	}
+	if sequence.UpdatedAt.IsZero() {
+		sequence.UpdatedAt = now
+	}
+
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	// The tenant's default sequence has no folder; store it as NULL
+	query := db
+	if sequence.FolderID == "" {
+		query = query.Omit("folder_id")
+	}
+
+	if err := query.Create(sequence).Error; err != nil {
+		if strings.Contains(err.Error(), "numbering_sequences_tenant_folder_idx") || strings.Contains(err.Error(), "numbering_sequences_tenant_default_idx") {
+			return "", errors.NewValidationError("the folder already has a numbering sequence")
+		}
+		logger.Error("Failed to create numbering sequence", "error", err, "sequence_id", sequence.ID, "tenant_id", sequence.TenantID)
+		return "", errors.NewInternalError("Failed to create numbering sequence: " + err.Error())
+	}
+
+	return sequence.ID, nil
+}
+
+// GetByID retrieves a numbering sequence by its ID with tenant isolation
+func (r *numberingSequenceRepository) GetByID(ctx context.Context, id string, tenantID string) (*models.NumberingSequence, error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.first(db.Where("id = ? AND tenant_id = ?", id, tenantID), id, tenantID)
+}
+
+// GetByFolder retrieves the numbering sequence of a folder, or the tenant's default, with tenant isolation
+func (r *numberingSequenceRepository) GetByFolder(ctx context.Context, folderID string, tenantID string) (*models.NumberingSequence, error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if folderID == "" {
+		return r.first(db.Where("folder_id IS NULL AND tenant_id = ?", tenantID), folderID, tenantID)
+	}
+	return r.first(db.Where("folder_id = ? AND tenant_id = ?", folderID, tenantID), folderID, tenantID)
+}
+
+// first retrieves the first numbering sequence matching a query
+func (r *numberingSequenceRepository) first(query *gorm.DB, id string, tenantID string) (*models.NumberingSequence, error) {
+	var sequence models.NumberingSequence
+	if err := query.First(&sequence).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewResourceNotFoundError("Numbering sequence not found")
+		}
+		logger.Error("Failed to get numbering sequence", "error", err, "id", id, "tenant_id", tenantID)
+		return nil, errors.NewInternalError("Failed to get numbering sequence: " + err.Error())
+	}
+
+	return &sequence, nil
+}
+
+// Update updates an existing numbering sequence in the database, leaving its folder and values alone
+func (r *numberingSequenceRepository) Update(ctx context.Context, sequence *models.NumberingSequence) error {
+	if err := sequence.Validate(); err != nil {
+		return errors.NewValidationError(err.Error())
+	}
+
+	sequence.UpdatedAt = time.Now()
+
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Select the mutable columns explicitly; numbers are only assigned through Advance
+	result := db.Model(&models.NumberingSequence{}).
+		Where("id = ? AND tenant_id = ?", sequence.ID, sequence.TenantID).
+		Select("name", "format", "padding", "mode", "reset_yearly", "updated_at").
+		Updates(sequence)
+
+	if result.Error != nil {
+		logger.Error("Failed to update numbering sequence", "error", result.Error, "id", sequence.ID, "tenant_id", sequence.TenantID)
+		return errors.NewInternalError("Failed to update numbering sequence: " + result.Error.Error())
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.NewResourceNotFoundError("Numbering sequence not found")
+	}
+
+	return nil
+}
+
+// Delete deletes a numbering sequence with tenant isolation
+func (r *numberingSequenceRepository) Delete(ctx context.Context, id string, tenantID string) error {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	result := db.Where("id = ? AND tenant_id = ?", id, tenantID).Delete(&models.NumberingSequence{})
+
+	if result.Error != nil {
+		logger.Error("Failed to delete numbering sequence", "error", result.Error, "id", id, "tenant_id", tenantID)
+		return errors.NewInternalError("Failed to delete numbering sequence: " + result.Error.Error())
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.NewResourceNotFoundError("Numbering sequence not found")
+	}
+
+	return nil
+}
+
+// ListByTenant lists the numbering sequences of a tenant ordered by name
+func (r *numberingSequenceRepository) ListByTenant(ctx context.Context, tenantID string) ([]*models.NumberingSequence, error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var sequences []*models.NumberingSequence
+	if err := db.Where("tenant_id = ?", tenantID).Order("name ASC").Find(&sequences).Error; err != nil {
+		logger.Error("Failed to list numbering sequences", "error", err, "tenant_id", tenantID)
+		return nil, errors.NewInternalError("Failed to list numbering sequences: " + err.Error())
+	}
+
+	return sequences, nil
+}
+
+// Advance locks a numbering sequence with SELECT ... FOR UPDATE, advances it and returns the number
+// assigned at now. It joins the transaction carried by ctx, so the lock is held, and a rollback
+// gives the value back, until that transaction ends.
+func (r *numberingSequenceRepository) Advance(ctx context.Context, id string, tenantID string, now time.Time) (string, error) {
+	var number string
+	err := WithTransaction(ctx, func(tx *gorm.DB) error {
+		tx = tx.WithContext(ctx)
+		sequence, err := r.first(tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ? AND tenant_id = ?", id, tenantID), id, tenantID)
+		if err != nil {
+			return err
+		}
+
+		number = sequence.Next(now)
+		return tx.Model(&models.NumberingSequence{}).
+			Where("id = ? AND tenant_id = ?", id, tenantID).
+			Updates(map[string]interface{}{
+				"last_value":  sequence.LastValue,
+				"last_period": sequence.LastPeriod,
+				"updated_at":  sequence.UpdatedAt,
+			}).Error
+	})
+	if err != nil {
+		if errors.IsResourceNotFoundError(err) {
+			return "", err
+		}
+		logger.Error("Failed to advance numbering sequence", "error", err, "id", id, "tenant_id", tenantID)
+		return "", errors.NewInternalError("Failed to advance numbering sequence: " + err.Error())
+	}
+
+	return number, nil
+}
@@ -0,0 +1,117 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid" // v1.3.0+ - For generating unique IDs for outbox messages
+	"gorm.io/gorm/clause"    // v1.25.0+ - For row locking when claiming messages
+
+	"../../../domain/models"
+	"../../../domain/repositories"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+)
+
+// outboxRepository implements the OutboxRepository interface using PostgreSQL.
+// All operations use the transaction carried by the context when present.
+type outboxRepository struct{}
+
+// NewOutboxRepository creates a new instance of the PostgreSQL implementation of OutboxRepository
+func NewOutboxRepository() repositories.OutboxRepository {
+	return &outboxRepository{}
+}
+
+// Create persists a new pending outbox message
+func (r *outboxRepository) Create(ctx context.Context, message *models.OutboxMessage) (string, error) {
+	if err := message.Validate(); err != nil {
+		return "", errors.NewValidationError(err.Error())
+	}
+
+	if message.ID == "" {
+		message.ID = uuid.New().String()
+	}
+	if message.Status == "" {
+		message.Status = models.OutboxStatusPending
+	}
+	if message.RequestID == "" {
+		message.RequestID = logger.RequestIDFromContext(ctx)
+	}
+
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if err := db.Create(message).Error; err != nil {
+		logger.Error("Failed to create outbox message", "error", err, "event_id", message.EventID, "tenant_id", message.TenantID)
+		return "", errors.NewInternalError("Failed to create outbox message: " + err.Error())
+	}
+
+	return message.ID, nil
+}
+
+// ListDue lists pending messages that are due for publishing, locking them for the surrounding transaction
+func (r *outboxRepository) ListDue(ctx context.Context, now time.Time, limit int) ([]*models.OutboxMessage, error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var messages []*models.OutboxMessage
+
+	if err := db.
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("status = ? AND next_attempt_at <= ?", models.OutboxStatusPending, now).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&messages).Error; err != nil {
+		logger.Error("Failed to list due outbox messages", "error", err)
+		return nil, errors.NewInternalError("Failed to list due outbox messages: " + err.Error())
+	}
+
+	return messages, nil
+}
+
+// Update persists the status, attempt count and schedule of an outbox message
+func (r *outboxRepository) Update(ctx context.Context, message *models.OutboxMessage) error {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	result := db.Model(&models.OutboxMessage{}).
+		Where("id = ?", message.ID).
+		Updates(map[string]interface{}{
+			"status":          message.Status,
+			"attempts":        message.Attempts,
+			"last_error":      message.LastError,
+			"next_attempt_at": message.NextAttemptAt,
+			"sent_at":         message.SentAt,
+		})
+	if result.Error != nil {
+		logger.Error("Failed to update outbox message", "error", result.Error, "message_id", message.ID)
+		return errors.NewInternalError("Failed to update outbox message: " + result.Error.Error())
+	}
+	if result.RowsAffected == 0 {
+		return errors.NewResourceNotFoundError("Outbox message not found")
+	}
+
+	return nil
+}
+
+// DeleteSentBefore removes messages that were published before the given time
+func (r *outboxRepository) DeleteSentBefore(ctx context.Context, before time.Time) (int, error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	result := db.Where("status = ? AND sent_at < ?", models.OutboxStatusSent, before).Delete(&models.OutboxMessage{})
+	if result.Error != nil {
+		logger.Error("Failed to delete sent outbox messages", "error", result.Error)
+		return 0, errors.NewInternalError("Failed to delete sent outbox messages: " + result.Error.Error())
+	}
+
+	return int(result.RowsAffected), nil
+}
@@ -20,6 +20,13 @@ type postgresqlPermissionRepository struct {
 	db *gorm.DB
 }
 
+// granteeColumns maps each grantee type to the permissions column holding the grantee ID
+var granteeColumns = map[string]string{
+	models.GranteeTypeRole:  "role_id",
+	models.GranteeTypeUser:  "user_id",
+	models.GranteeTypeGroup: "group_id",
+}
+
 // NewPermissionRepository creates a new PostgreSQL permission repository instance
 func NewPermissionRepository(db *gorm.DB) (repositories.PermissionRepository, error) {
 	if db == nil {
@@ -48,8 +55,8 @@ func (r *postgresqlPermissionRepository) Create(ctx context.Context, permission
 		return "", errors.NewInternalError(fmt.Sprintf("failed to begin transaction: %v", tx.Error))
 	}
 
-	// Create the permission record
-	if err := tx.Create(permission).Error; err != nil {
+	// Create the permission record, leaving the columns of the other grantee types NULL
+	if err := tx.Omit(unsetGranteeColumns(permission)...).Create(permission).Error; err != nil {
 		tx.Rollback()
 		return "", errors.NewInternalError(fmt.Sprintf("failed to create permission: %v", err))
 	}
@@ -277,23 +284,21 @@ func (r *postgresqlPermissionRepository) CreateBulk(ctx context.Context, permiss
 		ids[i] = permission.ID
 	}
 
-	// Begin transaction
-	tx := r.db.WithContext(ctx).Begin()
-	if tx.Error != nil {
-		return nil, errors.NewInternalError(fmt.Sprintf("failed to begin transaction: %v", tx.Error))
-	}
-
-	// Create all permissions
-	if err := tx.Create(permissions).Error; err != nil {
-		tx.Rollback()
+	// Create all permissions in one transaction, joining the one carried by ctx if any so that
+	// permissions can be created with the folders, roles and tenant they refer to; each is
+	// inserted on its own since grantee types may differ
+	err := dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		for _, permission := range permissions {
+			if err := tx.Omit(unsetGranteeColumns(permission)...).Create(permission).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
 		return nil, errors.NewInternalError(fmt.Sprintf("failed to create permissions in bulk: %v", err))
 	}
 
-	// Commit transaction
-	if err := tx.Commit().Error; err != nil {
-		return nil, errors.NewInternalError(fmt.Sprintf("failed to commit transaction: %v", err))
-	}
-
 	return ids, nil
 }
 
@@ -373,6 +378,16 @@ func (r *postgresqlPermissionRepository) CheckPermission(ctx context.Context, ro
 		return false, errors.NewValidationError("role ID cannot be empty")
 	}
 
+	return r.CheckGranteePermission(ctx, models.GranteeTypeRole, []string{roleID}, resourceType, resourceID, permissionType, tenantID)
+}
+
+// CheckGranteePermission checks if any of the given grantees has a specific permission on a resource with tenant isolation
+func (r *postgresqlPermissionRepository) CheckGranteePermission(ctx context.Context, granteeType string, granteeIDs []string, resourceType, resourceID, permissionType, tenantID string) (bool, error) {
+	column, ok := granteeColumns[granteeType]
+	if !ok {
+		return false, errors.NewValidationError(models.ErrInvalidGranteeType.Error())
+	}
+
 	if resourceType == "" {
 		return false, errors.NewValidationError("resource type cannot be empty")
 	}
@@ -389,11 +404,15 @@ func (r *postgresqlPermissionRepository) CheckPermission(ctx context.Context, ro
 		return false, errors.NewValidationError("tenant ID cannot be empty")
 	}
 
+	if len(granteeIDs) == 0 {
+		return false, nil
+	}
+
 	// Check direct permission
 	var count int64
 	if err := r.db.WithContext(ctx).Model(&models.Permission{}).Where(
-		"role_id = ? AND resource_type = ? AND resource_id = ? AND permission_type = ? AND tenant_id = ?",
-		roleID, resourceType, resourceID, permissionType, tenantID,
+		column+" IN ? AND resource_type = ? AND resource_id = ? AND permission_type = ? AND tenant_id = ?",
+		granteeIDs, resourceType, resourceID, permissionType, tenantID,
 	).Count(&count).Error; err != nil {
 		return false, errors.NewInternalError(fmt.Sprintf("failed to check permission: %v", err))
 	}
@@ -405,8 +424,8 @@ func (r *postgresqlPermissionRepository) CheckPermission(ctx context.Context, ro
 	// If checking for folder permissions, also check for admin permission
 	if permissionType != models.PermissionTypeAdmin && resourceType == models.ResourceTypeFolder {
 		if err := r.db.WithContext(ctx).Model(&models.Permission{}).Where(
-			"role_id = ? AND resource_type = ? AND resource_id = ? AND permission_type = ? AND tenant_id = ?",
-			roleID, resourceType, resourceID, models.PermissionTypeAdmin, tenantID,
+			column+" IN ? AND resource_type = ? AND resource_id = ? AND permission_type = ? AND tenant_id = ?",
+			granteeIDs, resourceType, resourceID, models.PermissionTypeAdmin, tenantID,
 		).Count(&count).Error; err != nil {
 			return false, errors.NewInternalError(fmt.Sprintf("failed to check admin permission: %v", err))
 		}
@@ -422,7 +441,10 @@ func (r *postgresqlPermissionRepository) CheckPermission(ctx context.Context, ro
 		}
 
 		for _, perm := range permissions {
-			if perm.RoleID == roleID && (perm.PermissionType == permissionType || perm.PermissionType == models.PermissionTypeAdmin) {
+			if perm.GranteeType() != granteeType || !containsString(granteeIDs, perm.GranteeID()) {
+				continue
+			}
+			if perm.PermissionType == permissionType || perm.PermissionType == models.PermissionTypeAdmin {
 				return true, nil
 			}
 		}
@@ -571,8 +593,8 @@ func (r *postgresqlPermissionRepository) PropagatePermissions(ctx context.Contex
 			// Check if permission already exists
 			var count int64
 			if err := tx.Model(&models.Permission{}).Where(
-				"role_id = ? AND resource_type = ? AND resource_id = ? AND permission_type = ? AND tenant_id = ?",
-				inherited.RoleID, inherited.ResourceType, inherited.ResourceID, inherited.PermissionType, inherited.TenantID,
+				granteeColumns[inherited.GranteeType()]+" = ? AND resource_type = ? AND resource_id = ? AND permission_type = ? AND tenant_id = ?",
+				inherited.GranteeID(), inherited.ResourceType, inherited.ResourceID, inherited.PermissionType, inherited.TenantID,
 			).Count(&count).Error; err != nil {
 				tx.Rollback()
 				return errors.NewInternalError(fmt.Sprintf("failed to check existing permission: %v", err))
@@ -584,7 +606,7 @@ func (r *postgresqlPermissionRepository) PropagatePermissions(ctx context.Contex
 			}
 
 			// Create the inherited permission
-			if err := tx.Create(inherited).Error; err != nil {
+			if err := tx.Omit(unsetGranteeColumns(inherited)...).Create(inherited).Error; err != nil {
 				tx.Rollback()
 				return errors.NewInternalError(fmt.Sprintf("failed to create inherited permission: %v", err))
 			}
@@ -599,6 +621,27 @@ func (r *postgresqlPermissionRepository) PropagatePermissions(ctx context.Contex
 	return nil
 }
 
+// unsetGranteeColumns returns the grantee columns that do not apply to the permission so that they are stored as NULL
+func unsetGranteeColumns(permission *models.Permission) []string {
+	columns := make([]string, 0, len(granteeColumns)-1)
+	for granteeType, column := range granteeColumns {
+		if granteeType != permission.GranteeType() {
+			columns = append(columns, column)
+		}
+	}
+	return columns
+}
+
+// containsString checks whether values contains value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
 // extractParentPaths extracts parent folder paths from a given path
 // For example, for a path "/tenant1/folder1/folder2/folder3",
 // it would return ["/tenant1", "/tenant1/folder1", "/tenant1/folder1/folder2"]
@@ -0,0 +1,182 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"gorm.io/driver/postgres" // v1.5.0+
+	"gorm.io/gorm"            // v1.25.0+
+
+	"../../../pkg/config"
+	"../../../pkg/errors"
+)
+
+// Pool defaults applied when the configuration leaves a setting empty
+const (
+	defaultMaxOpenConns       = 25
+	defaultConnMaxLifetime    = time.Hour
+	defaultConnMaxIdleTime    = 10 * time.Minute
+	defaultQueryTimeout       = 30 * time.Second
+	defaultSlowQueryThreshold = 200 * time.Millisecond
+)
+
+// queryCancelInstanceKey is the key the cancel function of a query's timeout is kept under while it runs
+const queryCancelInstanceKey = "timeout:cancel"
+
+// poolSettings are the pool sizes, timeouts and slow query threshold of the configuration, shared
+// by the primary and the read replicas
+type poolSettings struct {
+	maxOpenConns       int
+	maxIdleConns       int
+	connMaxLifetime    time.Duration
+	connMaxIdleTime    time.Duration
+	queryTimeout       time.Duration
+	statementTimeout   time.Duration
+	slowQueryThreshold time.Duration
+}
+
+// newPoolSettings parses the pool settings of the configuration. The pool is always bounded, so a
+// burst of slow queries waits for a connection rather than opening connections until the server
+// refuses them, and the idle connections never outnumber the open ones.
+func newPoolSettings(dbConfig config.DatabaseConfig) (poolSettings, error) {
+	settings := poolSettings{
+		maxOpenConns: dbConfig.MaxOpenConns,
+		maxIdleConns: dbConfig.MaxIdleConns,
+	}
+	if settings.maxOpenConns <= 0 {
+		settings.maxOpenConns = defaultMaxOpenConns
+	}
+	if settings.maxIdleConns <= 0 || settings.maxIdleConns > settings.maxOpenConns {
+		settings.maxIdleConns = settings.maxOpenConns
+	}
+
+	durations := []struct {
+		name     string
+		value    string
+		fallback time.Duration
+		target   *time.Duration
+	}{
+		{"conn_max_lifetime", dbConfig.ConnMaxLifetime, defaultConnMaxLifetime, &settings.connMaxLifetime},
+		{"conn_max_idle_time", dbConfig.ConnMaxIdleTime, defaultConnMaxIdleTime, &settings.connMaxIdleTime},
+		{"query_timeout", dbConfig.QueryTimeout, defaultQueryTimeout, &settings.queryTimeout},
+		{"statement_timeout", dbConfig.StatementTimeout, 0, &settings.statementTimeout},
+		{"slow_query_threshold", dbConfig.SlowQueryThreshold, defaultSlowQueryThreshold, &settings.slowQueryThreshold},
+	}
+	for _, d := range durations {
+		*d.target = d.fallback
+		if d.value == "" {
+			continue
+		}
+		parsed, err := time.ParseDuration(d.value)
+		if err != nil || parsed < 0 {
+			return poolSettings{}, errors.NewValidationError(fmt.Sprintf("invalid database %s %q", d.name, d.value))
+		}
+		*d.target = parsed
+	}
+	return settings, nil
+}
+
+// openPool opens a connection pool to the database of the configuration with the pool settings,
+// tracing its operations and bounding its queries by the query timeout. The database is pinged
+// when ping is set, so an unreachable primary fails the start of the service.
+func openPool(dbConfig config.DatabaseConfig, settings poolSettings, ping bool) (*gorm.DB, error) {
+	gormLogger := NewGormLogger()
+	gormLogger.SlowThreshold = settings.slowQueryThreshold
+
+	db, err := gorm.Open(postgres.Open(buildDSN(dbConfig, settings.statementTimeout)), &gorm.Config{
+		Logger:               gormLogger,
+		DisableAutomaticPing: !ping,
+	})
+	if err != nil {
+		return nil, errors.NewDependencyError(fmt.Sprintf("failed to connect to database %s: %v", dbConfig.Host, err))
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, errors.NewDependencyError(fmt.Sprintf("failed to get database connection: %v", err))
+	}
+	sqlDB.SetMaxOpenConns(settings.maxOpenConns)
+	sqlDB.SetMaxIdleConns(settings.maxIdleConns)
+	sqlDB.SetConnMaxLifetime(settings.connMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(settings.connMaxIdleTime)
+
+	// Trace the operations of every repository
+	if err := registerTracing(db); err != nil {
+		return nil, err
+	}
+	if err := registerQueryTimeout(db, settings.queryTimeout); err != nil {
+		return nil, err
+	}
+	return db, nil
+}
+
+// registerQueryTimeout bounds each query run through db by timeout, unless the context of the
+// query has an earlier deadline, so a runaway listing or search gives its connection back instead
+// of holding it until the client gives up. The timeout also bounds the wait for a connection when
+// the pool is exhausted. Writes are bounded by the statement timeout of the server instead, as
+// cancelling the context of a write would roll back the transaction it runs in.
+func registerQueryTimeout(db *gorm.DB, timeout time.Duration) error {
+	if timeout <= 0 {
+		return nil
+	}
+
+	callbacks := db.Callback()
+	registrations := []error{
+		callbacks.Query().Before("gorm:query").Register("timeout:before_query", startQueryTimeout(timeout)),
+		callbacks.Query().After("gorm:after_query").Register("timeout:after_query", endQueryTimeout),
+	}
+	for _, err := range registrations {
+		if err != nil {
+			return fmt.Errorf("failed to register query timeout callbacks: %w", err)
+		}
+	}
+	return nil
+}
+
+// startQueryTimeout returns a callback bounding the context of a query's statement by timeout
+func startQueryTimeout(timeout time.Duration) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		ctx := db.Statement.Context
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= timeout {
+			return
+		}
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		db.Statement.Context = ctx
+		db.InstanceSet(queryCancelInstanceKey, cancel)
+	}
+}
+
+// endQueryTimeout releases the timeout of a query once it and its preloads are complete, counting
+// the queries that ran out of time
+func endQueryTimeout(db *gorm.DB) {
+	value, ok := db.InstanceGet(queryCancelInstanceKey)
+	if !ok {
+		return
+	}
+	if cancel, ok := value.(context.CancelFunc); ok {
+		if db.Statement.Context.Err() == context.DeadlineExceeded && queryTimeoutCounter != nil {
+			queryTimeoutCounter.WithLabelValues().Inc()
+		}
+		cancel()
+	}
+}
+
+// updatePoolMetrics records the connections of a pool and how saturated it is
+func updatePoolMetrics(pool string, sqlDB *sql.DB) {
+	stats := sqlDB.Stats()
+	connectionGauge.WithLabelValues(pool, "open").Set(float64(stats.OpenConnections))
+	connectionGauge.WithLabelValues(pool, "idle").Set(float64(stats.Idle))
+	connectionGauge.WithLabelValues(pool, "in_use").Set(float64(stats.InUse))
+	connectionGauge.WithLabelValues(pool, "max_open").Set(float64(stats.MaxOpenConnections))
+	connectionGauge.WithLabelValues(pool, "wait_count").Set(float64(stats.WaitCount))
+	connectionGauge.WithLabelValues(pool, "wait_duration").Set(stats.WaitDuration.Seconds())
+
+	if stats.MaxOpenConnections > 0 {
+		poolSaturationGauge.WithLabelValues(pool).Set(float64(stats.InUse) / float64(stats.MaxOpenConnections))
+	}
+}
@@ -0,0 +1,61 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"  // v1.8.0+
+	"github.com/stretchr/testify/require" // v1.8.0+
+
+	"../../../pkg/config"
+)
+
+// TestNewPoolSettings tests that the pool is always bounded and that durations fall back to their defaults
+func TestNewPoolSettings(t *testing.T) {
+	settings, err := newPoolSettings(config.DatabaseConfig{MaxIdleConns: 50})
+	require.NoError(t, err)
+	assert.Equal(t, defaultMaxOpenConns, settings.maxOpenConns)
+	assert.Equal(t, defaultMaxOpenConns, settings.maxIdleConns)
+	assert.Equal(t, defaultQueryTimeout, settings.queryTimeout)
+	assert.Equal(t, defaultSlowQueryThreshold, settings.slowQueryThreshold)
+	assert.Zero(t, settings.statementTimeout)
+
+	settings, err = newPoolSettings(config.DatabaseConfig{MaxOpenConns: 10, MaxIdleConns: 5, QueryTimeout: "5s", StatementTimeout: "1m"})
+	require.NoError(t, err)
+	assert.Equal(t, 10, settings.maxOpenConns)
+	assert.Equal(t, 5, settings.maxIdleConns)
+	assert.Equal(t, 5*time.Second, settings.queryTimeout)
+	assert.Equal(t, time.Minute, settings.statementTimeout)
+
+	_, err = newPoolSettings(config.DatabaseConfig{QueryTimeout: "soon"})
+	assert.Error(t, err)
+}
+
+// TestBuildDSN tests that the statement timeout is set on each connection in milliseconds
+func TestBuildDSN(t *testing.T) {
+	dbConfig := config.DatabaseConfig{Host: "db", Port: 5432, User: "app", Password: "secret", DBName: "documents", SSLMode: "disable"}
+
+	assert.Equal(t, "host=db port=5432 user=app password=secret dbname=documents sslmode=disable", buildDSN(dbConfig, 0))
+	assert.Contains(t, buildDSN(dbConfig, 90*time.Second), " statement_timeout=90000")
+}
+
+// TestQueryTimeout tests that queries are cancelled past the query timeout unless the caller's deadline is earlier
+func TestQueryTimeout(t *testing.T) {
+	db := openTestDB(t)
+	require.NoError(t, db.Exec("CREATE TABLE items (id INTEGER)").Error)
+	require.NoError(t, registerQueryTimeout(db, time.Nanosecond))
+
+	var count int64
+	err := db.WithContext(context.Background()).Table("items").Count(&count).Error
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// Test that queries run when the timeout is long enough
+	db = openTestDB(t)
+	require.NoError(t, db.Exec("CREATE TABLE items (id INTEGER)").Error)
+	require.NoError(t, registerQueryTimeout(db, time.Minute))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	assert.NoError(t, db.WithContext(ctx).Table("items").Count(&count).Error)
+}
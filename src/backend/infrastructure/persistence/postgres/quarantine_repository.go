@@ -0,0 +1,123 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid" // v1.3.0+ - For generating unique IDs for quarantined items
+	"gorm.io/gorm"           // v1.25.0+ - For detecting missing records
+
+	"../../../domain/models"
+	"../../../domain/repositories"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+	"../../../pkg/utils"
+)
+
+// quarantineRepository implements the QuarantineRepository interface using PostgreSQL
+type quarantineRepository struct{}
+
+// NewQuarantineRepository creates a new instance of the PostgreSQL implementation of QuarantineRepository
+func NewQuarantineRepository() repositories.QuarantineRepository {
+	return &quarantineRepository{}
+}
+
+// Create persists a new quarantined item
+func (r *quarantineRepository) Create(ctx context.Context, item *models.QuarantinedItem) (string, error) {
+	if err := item.Validate(); err != nil {
+		return "", errors.NewValidationError(err.Error())
+	}
+
+	if item.ID == "" {
+		item.ID = uuid.New().String()
+	}
+
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if err := db.Create(item).Error; err != nil {
+		logger.Error("Failed to create quarantined item", "error", err, "document_id", item.DocumentID, "tenant_id", item.TenantID)
+		return "", errors.NewInternalError("Failed to create quarantined item: " + err.Error())
+	}
+
+	return item.ID, nil
+}
+
+// GetByID retrieves a quarantined item by its ID with tenant isolation
+func (r *quarantineRepository) GetByID(ctx context.Context, id string, tenantID string) (*models.QuarantinedItem, error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var item models.QuarantinedItem
+	if err := db.Where("id = ? AND tenant_id = ?", id, tenantID).First(&item).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewResourceNotFoundError("Quarantined item not found")
+		}
+		logger.Error("Failed to get quarantined item", "error", err, "id", id, "tenant_id", tenantID)
+		return nil, errors.NewInternalError("Failed to get quarantined item: " + err.Error())
+	}
+
+	return &item, nil
+}
+
+// List retrieves the quarantined items of a tenant with pagination, optionally filtered by status
+func (r *quarantineRepository) List(ctx context.Context, tenantID string, status string, pagination *utils.Pagination) (utils.PaginatedResult[models.QuarantinedItem], error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return utils.PaginatedResult[models.QuarantinedItem]{}, err
+	}
+
+	query := db.Model(&models.QuarantinedItem{}).Where("tenant_id = ?", tenantID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var items []models.QuarantinedItem
+	var totalItems int64
+
+	if err := query.Count(&totalItems).Error; err != nil {
+		logger.Error("Failed to count quarantined items", "error", err, "tenant_id", tenantID)
+		return utils.PaginatedResult[models.QuarantinedItem]{}, errors.NewInternalError("Failed to count quarantined items: " + err.Error())
+	}
+
+	if err := query.
+		Offset(pagination.GetOffset()).
+		Limit(pagination.GetLimit()).
+		Order("quarantined_at DESC").
+		Find(&items).Error; err != nil {
+		logger.Error("Failed to list quarantined items", "error", err, "tenant_id", tenantID)
+		return utils.PaginatedResult[models.QuarantinedItem]{}, errors.NewInternalError("Failed to list quarantined items: " + err.Error())
+	}
+
+	return utils.NewPaginatedResult(items, pagination, totalItems), nil
+}
+
+// Resolve persists the release or destruction of an item that is still quarantined
+func (r *quarantineRepository) Resolve(ctx context.Context, item *models.QuarantinedItem) error {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	result := db.Model(&models.QuarantinedItem{}).
+		Where("id = ? AND tenant_id = ? AND status = ?", item.ID, item.TenantID, models.QuarantineStatusQuarantined).
+		Updates(map[string]interface{}{
+			"status":      item.Status,
+			"resolution":  item.Resolution,
+			"reason":      item.Reason,
+			"resolved_by": item.ResolvedBy,
+			"resolved_at": item.ResolvedAt,
+		})
+	if result.Error != nil {
+		logger.Error("Failed to resolve quarantined item", "error", result.Error, "id", item.ID, "tenant_id", item.TenantID)
+		return errors.NewInternalError("Failed to resolve quarantined item: " + result.Error.Error())
+	}
+	if result.RowsAffected == 0 {
+		return errors.NewValidationError("Quarantined item has already been resolved")
+	}
+
+	return nil
+}
@@ -0,0 +1,121 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"        // v1.25.0+ - ORM library for database operations
+	"gorm.io/gorm/clause" // v1.25.0+ - For ignoring existing quotas and locking quota rows
+
+	"../../../domain/models"
+	"../../../domain/repositories"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+)
+
+// quotaRepository implements the QuotaRepository interface using PostgreSQL
+type quotaRepository struct{}
+
+// NewQuotaRepository creates a new instance of the PostgreSQL implementation of QuotaRepository
+func NewQuotaRepository() repositories.QuotaRepository {
+	return &quotaRepository{}
+}
+
+// GetByTenantID retrieves a tenant's quota
+func (r *quotaRepository) GetByTenantID(ctx context.Context, tenantID string) (*models.TenantQuota, error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var quota models.TenantQuota
+	if err := db.Where("tenant_id = ?", tenantID).First(&quota).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewResourceNotFoundError("tenant quota not found")
+		}
+		logger.Error("Failed to get tenant quota", "error", err, "tenant_id", tenantID)
+		return nil, errors.NewInternalError("Failed to get tenant quota: " + err.Error())
+	}
+
+	return &quota, nil
+}
+
+// Create persists a new tenant quota, leaving an existing quota unchanged
+func (r *quotaRepository) Create(ctx context.Context, quota *models.TenantQuota) error {
+	if quota.TenantID == "" {
+		return errors.NewValidationError("tenant ID cannot be empty")
+	}
+	if quota.UpdatedAt.IsZero() {
+		quota.UpdatedAt = time.Now()
+	}
+
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(quota).Error; err != nil {
+		logger.Error("Failed to create tenant quota", "error", err, "tenant_id", quota.TenantID)
+		return errors.NewInternalError("Failed to create tenant quota: " + err.Error())
+	}
+
+	return nil
+}
+
+// SetLimits changes a tenant's limits without touching its usage
+func (r *quotaRepository) SetLimits(ctx context.Context, tenantID string, maxStorageBytes, maxDocuments int64) error {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	result := db.Model(&models.TenantQuota{}).
+		Where("tenant_id = ?", tenantID).
+		Updates(map[string]interface{}{
+			"max_storage_bytes": maxStorageBytes,
+			"max_documents":     maxDocuments,
+			"updated_at":        time.Now(),
+		})
+	if result.Error != nil {
+		logger.Error("Failed to set tenant quota limits", "error", result.Error, "tenant_id", tenantID)
+		return errors.NewInternalError("Failed to set tenant quota limits: " + result.Error.Error())
+	}
+	if result.RowsAffected == 0 {
+		return errors.NewResourceNotFoundError("tenant quota not found")
+	}
+
+	return nil
+}
+
+// AddUsage atomically adds to a tenant's usage if the result stays within its limits. The quota row
+// is locked until the surrounding transaction ends, so concurrent uploads cannot overshoot the limits.
+func (r *quotaRepository) AddUsage(ctx context.Context, tenantID string, storageBytes, documents int64) (*models.TenantQuota, error) {
+	var quota models.TenantQuota
+	err := WithTransaction(ctx, func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("tenant_id = ?", tenantID).First(&quota).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return errors.NewResourceNotFoundError("tenant quota not found")
+			}
+			return err
+		}
+
+		if err := quota.CheckUsage(storageBytes, documents); err != nil {
+			return err
+		}
+
+		quota.AddUsage(storageBytes, documents)
+		return tx.Save(&quota).Error
+	})
+	if err == models.ErrStorageQuotaExceeded || err == models.ErrDocumentQuotaExceeded {
+		return &quota, err
+	}
+	if err != nil {
+		if errors.IsResourceNotFoundError(err) {
+			return nil, err
+		}
+		logger.Error("Failed to update tenant quota usage", "error", err, "tenant_id", tenantID)
+		return nil, errors.NewInternalError("Failed to update tenant quota usage: " + err.Error())
+	}
+
+	return &quota, nil
+}
@@ -0,0 +1,154 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid" // v1.3.0+ - For generating unique IDs for reindex jobs
+	"gorm.io/gorm"           // v1.25.0+ - For claiming jobs in a transaction
+	"gorm.io/gorm/clause"    // v1.25.0+ - For row locking when claiming jobs
+
+	"../../../domain/models"
+	"../../../domain/repositories"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+)
+
+// reindexJobRepository implements the ReindexJobRepository interface using PostgreSQL
+type reindexJobRepository struct{}
+
+// NewReindexJobRepository creates a new instance of the PostgreSQL implementation of ReindexJobRepository
+func NewReindexJobRepository() repositories.ReindexJobRepository {
+	return &reindexJobRepository{}
+}
+
+// Create persists a new reindex job
+func (r *reindexJobRepository) Create(ctx context.Context, job *models.ReindexJob) (string, error) {
+	if err := job.Validate(); err != nil {
+		return "", errors.NewValidationError(err.Error())
+	}
+
+	if job.ID == "" {
+		job.ID = uuid.New().String()
+	}
+
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if err := db.Create(job).Error; err != nil {
+		logger.Error("Failed to create reindex job", "error", err, "tenant_id", job.TenantID)
+		return "", errors.NewInternalError("Failed to create reindex job: " + err.Error())
+	}
+
+	return job.ID, nil
+}
+
+// GetByID retrieves a reindex job by its ID with tenant isolation
+func (r *reindexJobRepository) GetByID(ctx context.Context, id string, tenantID string) (*models.ReindexJob, error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var job models.ReindexJob
+	if err := db.Where("id = ? AND tenant_id = ?", id, tenantID).First(&job).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewResourceNotFoundError("Reindex job not found")
+		}
+		logger.Error("Failed to get reindex job", "error", err, "id", id, "tenant_id", tenantID)
+		return nil, errors.NewInternalError("Failed to get reindex job: " + err.Error())
+	}
+
+	return &job, nil
+}
+
+// GetUnfinished retrieves the pending or running reindex job of a tenant
+func (r *reindexJobRepository) GetUnfinished(ctx context.Context, tenantID string) (*models.ReindexJob, error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var jobs []*models.ReindexJob
+	if err := db.
+		Where("tenant_id = ? AND status IN ?", tenantID, []string{models.ReindexJobStatusPending, models.ReindexJobStatusRunning}).
+		Limit(1).
+		Find(&jobs).Error; err != nil {
+		logger.Error("Failed to get unfinished reindex job", "error", err, "tenant_id", tenantID)
+		return nil, errors.NewInternalError("Failed to get unfinished reindex job: " + err.Error())
+	}
+	if len(jobs) == 0 {
+		return nil, nil
+	}
+
+	return jobs[0], nil
+}
+
+// ClaimNext locks the oldest claimable reindex job, skipping jobs locked by other workers, and marks it as running
+func (r *reindexJobRepository) ClaimNext(ctx context.Context, staleBefore time.Time) (*models.ReindexJob, error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var claimed *models.ReindexJob
+	err = db.Transaction(func(tx *gorm.DB) error {
+		var jobs []*models.ReindexJob
+		if err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? OR (status = ? AND updated_at < ?)", models.ReindexJobStatusPending, models.ReindexJobStatusRunning, staleBefore).
+			Order("created_at ASC").
+			Limit(1).
+			Find(&jobs).Error; err != nil {
+			return err
+		}
+		if len(jobs) == 0 {
+			return nil
+		}
+
+		job := jobs[0]
+		job.Start(time.Now())
+		if err := tx.Save(job).Error; err != nil {
+			return err
+		}
+		claimed = job
+		return nil
+	})
+	if err != nil {
+		logger.Error("Failed to claim reindex job", "error", err)
+		return nil, errors.NewInternalError("Failed to claim reindex job: " + err.Error())
+	}
+
+	return claimed, nil
+}
+
+// Update persists the status and progress of a reindex job
+func (r *reindexJobRepository) Update(ctx context.Context, job *models.ReindexJob) error {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	result := db.Model(&models.ReindexJob{}).
+		Where("id = ? AND tenant_id = ?", job.ID, job.TenantID).
+		Updates(map[string]interface{}{
+			"status":            job.Status,
+			"total_documents":   job.TotalDocuments,
+			"indexed_documents": job.IndexedDocuments,
+			"error":             job.Error,
+			"updated_at":        job.UpdatedAt,
+			"started_at":        job.StartedAt,
+			"completed_at":      job.CompletedAt,
+		})
+	if result.Error != nil {
+		logger.Error("Failed to update reindex job", "error", result.Error, "id", job.ID, "tenant_id", job.TenantID)
+		return errors.NewInternalError("Failed to update reindex job: " + result.Error.Error())
+	}
+	if result.RowsAffected == 0 {
+		return errors.NewResourceNotFoundError("Reindex job not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,209 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gorm.io/gorm" // v1.25.0+
+
+	"../../../pkg/config"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+)
+
+const (
+	// defaultReplicaMaxLag is the replication lag past which a replica stops serving reads when
+	// the configuration sets none
+	defaultReplicaMaxLag = 5 * time.Second
+
+	// replicaLagCheckInterval is the interval at which the lag of the replicas is measured
+	replicaLagCheckInterval = 5 * time.Second
+
+	// replicaLagCheckTimeout bounds the lag query, so an unreachable replica is skipped quickly
+	replicaLagCheckTimeout = 2 * time.Second
+)
+
+// replicaLagQuery measures how far a replica is behind the primary. A replica that has replayed all
+// the WAL it received is up to date however old its last transaction is, as the primary may be idle.
+const replicaLagQuery = `SELECT CASE
+	WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+	ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0)
+END`
+
+// replicas is the set of read replicas opened by Init
+var replicas *replicaSet
+
+// replica is a read replica and whether it is serving reads
+type replica struct {
+	name    string
+	db      *gorm.DB
+	healthy atomic.Bool
+}
+
+// replicaSet routes reads across the replicas that are reachable and within the maximum lag of the
+// primary. The lag is measured in the background, so routing a read never waits on a replica.
+type replicaSet struct {
+	replicas []*replica
+	maxLag   time.Duration
+	next     atomic.Uint64
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// openReplicas opens a connection pool to each replica of the configuration, with the credentials
+// and pool settings of the primary. Replicas are not pinged when opened: one that cannot be reached
+// is skipped by reads until its lag can be measured, rather than failing the start of the service.
+func openReplicas(dbConfig config.DatabaseConfig, settings poolSettings) (*replicaSet, error) {
+	maxLag := defaultReplicaMaxLag
+	if dbConfig.ReplicaMaxLag != "" {
+		parsed, err := time.ParseDuration(dbConfig.ReplicaMaxLag)
+		if err != nil {
+			return nil, errors.NewValidationError(fmt.Sprintf("invalid replica max lag %q: %v", dbConfig.ReplicaMaxLag, err))
+		}
+		maxLag = parsed
+	}
+
+	set := &replicaSet{maxLag: maxLag, stop: make(chan struct{})}
+	for _, replicaConfig := range dbConfig.Replicas {
+		replicaDBConfig := dbConfig
+		replicaDBConfig.Host = replicaConfig.Host
+		replicaDBConfig.Port = replicaConfig.Port
+
+		db, err := openPool(replicaDBConfig, settings, false)
+		if err != nil {
+			set.close()
+			return nil, err
+		}
+
+		set.replicas = append(set.replicas, &replica{
+			name: fmt.Sprintf("%s:%d", replicaConfig.Host, replicaConfig.Port),
+			db:   db,
+		})
+	}
+	return set, nil
+}
+
+// start measures the lag of the replicas, then keeps measuring it in the background until close
+func (s *replicaSet) start() {
+	if s == nil || len(s.replicas) == 0 {
+		return
+	}
+
+	s.checkLag()
+	go func() {
+		ticker := time.NewTicker(replicaLagCheckInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				s.checkLag()
+			case <-s.stop:
+				return
+			}
+		}
+	}()
+}
+
+// checkLag measures the lag of each replica and marks it healthy when it can be reached and is
+// within the maximum lag
+func (s *replicaSet) checkLag() {
+	for _, r := range s.replicas {
+		ctx, cancel := context.WithTimeout(context.Background(), replicaLagCheckTimeout)
+		var lagSeconds float64
+		err := r.db.WithContext(ctx).Raw(replicaLagQuery).Scan(&lagSeconds).Error
+		cancel()
+
+		lag := time.Duration(lagSeconds * float64(time.Second))
+		healthy := err == nil && lag <= s.maxLag
+		if wasHealthy := r.healthy.Swap(healthy); wasHealthy != healthy {
+			if healthy {
+				logger.Info("Database replica serving reads", "replica", r.name, "lag", lag.String())
+			} else {
+				logger.Warn("Database replica skipped for reads", "replica", r.name, "lag", lag.String(), "error", err)
+			}
+		}
+
+		if replicaLagGauge != nil {
+			if err != nil {
+				lagSeconds = -1
+			}
+			replicaLagGauge.WithLabelValues(r.name).Set(lagSeconds)
+		}
+	}
+}
+
+// pick returns a healthy replica in turn, or nil when none is healthy
+func (s *replicaSet) pick() *gorm.DB {
+	if s == nil || len(s.replicas) == 0 {
+		return nil
+	}
+
+	start := s.next.Add(1)
+	for i := range s.replicas {
+		r := s.replicas[(start+uint64(i))%uint64(len(s.replicas))]
+		if r.healthy.Load() {
+			return r.db
+		}
+	}
+	return nil
+}
+
+// close stops the lag checks and closes the connections of the replicas
+func (s *replicaSet) close() error {
+	if s == nil {
+		return nil
+	}
+
+	s.stopOnce.Do(func() { close(s.stop) })
+	for _, r := range s.replicas {
+		sqlDB, err := r.db.DB()
+		if err != nil {
+			return errors.NewDependencyError(fmt.Sprintf("failed to get replica connection: %v", err))
+		}
+		if err := sqlDB.Close(); err != nil {
+			return errors.NewDependencyError(fmt.Sprintf("failed to close replica connection: %v", err))
+		}
+	}
+	return nil
+}
+
+// primaryContextKey is the context key marking reads that must use the primary
+type primaryContextKey struct{}
+
+// ContextWithPrimary returns a copy of ctx whose reads use the primary, for callers that must read
+// their own writes made outside a transaction
+func ContextWithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryContextKey{}, true)
+}
+
+// readDBFromContext returns the handle for a read-only query: the transaction carried by ctx, so a
+// transaction reads its own writes, or a healthy replica, falling back to db when ctx requires the
+// primary or no replica is within the maximum lag
+func readDBFromContext(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := TransactionFromContext(ctx); ok {
+		return tx.WithContext(ctx)
+	}
+	if primary, _ := ctx.Value(primaryContextKey{}).(bool); !primary {
+		if replica := replicas.pick(); replica != nil {
+			return replica.WithContext(ctx)
+		}
+	}
+	return db.WithContext(ctx)
+}
+
+// GetReadDBFromContext returns the handle for a read-only query: the transaction carried by ctx, a
+// healthy replica, or the shared connection scoped to ctx
+func GetReadDBFromContext(ctx context.Context) (*gorm.DB, error) {
+	db, err := GetDB()
+	if err != nil {
+		if tx, ok := TransactionFromContext(ctx); ok {
+			return tx.WithContext(ctx), nil
+		}
+		return nil, err
+	}
+	return readDBFromContext(ctx, db), nil
+}
@@ -0,0 +1,65 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"  // v1.8.0+
+	"github.com/stretchr/testify/require" // v1.8.0+
+	"gorm.io/driver/sqlite"               // v1.5.0+
+	"gorm.io/gorm"                        // v1.25.0+
+)
+
+// openTestDB opens an in-memory SQLite database standing in for a primary or replica
+func openTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	// Each connection to an in-memory database opens a database of its own
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+	return db
+}
+
+// TestReplicaSetPick tests that reads are spread across the healthy replicas only
+func TestReplicaSetPick(t *testing.T) {
+	healthy, lagging := &replica{name: "healthy", db: openTestDB(t)}, &replica{name: "lagging", db: openTestDB(t)}
+	healthy.healthy.Store(true)
+	set := &replicaSet{replicas: []*replica{healthy, lagging}}
+
+	for i := 0; i < 4; i++ {
+		assert.Same(t, healthy.db, set.pick())
+	}
+
+	healthy.healthy.Store(false)
+	assert.Nil(t, set.pick())
+
+	// Test that a service without replicas reads from the primary
+	var none *replicaSet
+	assert.Nil(t, none.pick())
+}
+
+// TestReadDBFromContext tests that reads use a replica unless the context requires the primary
+func TestReadDBFromContext(t *testing.T) {
+	primary := openTestDB(t)
+	r := &replica{name: "replica", db: openTestDB(t)}
+	r.healthy.Store(true)
+
+	previous := replicas
+	replicas = &replicaSet{replicas: []*replica{r}}
+	defer func() { replicas = previous }()
+
+	ctx := context.Background()
+	assert.Same(t, r.db.Statement.ConnPool, readDBFromContext(ctx, primary).Statement.ConnPool)
+	assert.Same(t, primary.Statement.ConnPool, readDBFromContext(ContextWithPrimary(ctx), primary).Statement.ConnPool)
+
+	// Test that a transaction reads its own writes
+	tx := primary.Begin()
+	defer tx.Rollback()
+	assert.Same(t, tx.Statement.ConnPool, readDBFromContext(ContextWithTransaction(ctx, tx), primary).Statement.ConnPool)
+
+	// Test that reads fall back to the primary when the replica lags
+	r.healthy.Store(false)
+	assert.Same(t, primary.Statement.ConnPool, readDBFromContext(ctx, primary).Statement.ConnPool)
+}
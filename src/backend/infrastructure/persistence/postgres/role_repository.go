@@ -0,0 +1,223 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid" // v1.3.0+ - For generating unique IDs for roles
+	"gorm.io/gorm"           // v1.25.0+ - ORM library for database operations
+
+	"../../../domain/models"
+	"../../../domain/repositories"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+	"../../../pkg/utils"
+)
+
+// roleRepository implements the RoleRepository interface using PostgreSQL
+type roleRepository struct{}
+
+// NewRoleRepository creates a new instance of the PostgreSQL implementation of RoleRepository
+func NewRoleRepository() repositories.RoleRepository {
+	return &roleRepository{}
+}
+
+// Create persists a new role to the database
+func (r *roleRepository) Create(ctx context.Context, role *models.Role) (string, error) {
+	if err := role.Validate(); err != nil {
+		return "", errors.NewValidationError(err.Error())
+	}
+
+	if role.ID == "" {
+		role.ID = uuid.New().String()
+	}
+
+	now := time.Now()
+	if role.CreatedAt.IsZero() {
+		role.CreatedAt = now
+	}
+	if role.UpdatedAt.IsZero() {
+		role.UpdatedAt = now
+	}
+
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if err := db.Create(role).Error; err != nil {
+		if strings.Contains(err.Error(), "roles_tenant_name_idx") {
+			return "", errors.NewValidationError("a role named " + role.Name + " already exists")
+		}
+		logger.Error("Failed to create role", "error", err, "role_id", role.ID, "tenant_id", role.TenantID)
+		return "", errors.NewInternalError("Failed to create role: " + err.Error())
+	}
+
+	return role.ID, nil
+}
+
+// GetByID retrieves a role by its ID with tenant isolation
+func (r *roleRepository) GetByID(ctx context.Context, id string, tenantID string) (*models.Role, error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var role models.Role
+	if err := db.Where("id = ? AND tenant_id = ?", id, tenantID).First(&role).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewResourceNotFoundError("Role not found")
+		}
+		logger.Error("Failed to get role", "error", err, "id", id, "tenant_id", tenantID)
+		return nil, errors.NewInternalError("Failed to get role: " + err.Error())
+	}
+
+	return &role, nil
+}
+
+// GetByName retrieves a role by its name with tenant isolation
+func (r *roleRepository) GetByName(ctx context.Context, name string, tenantID string) (*models.Role, error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var role models.Role
+	if err := db.Where("name = ? AND tenant_id = ?", name, tenantID).First(&role).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewResourceNotFoundError("Role not found")
+		}
+		logger.Error("Failed to get role by name", "error", err, "name", name, "tenant_id", tenantID)
+		return nil, errors.NewInternalError("Failed to get role by name: " + err.Error())
+	}
+
+	return &role, nil
+}
+
+// Update updates an existing role's description and permissions
+func (r *roleRepository) Update(ctx context.Context, role *models.Role) error {
+	if err := role.Validate(); err != nil {
+		return errors.NewValidationError(err.Error())
+	}
+
+	role.UpdatedAt = time.Now()
+
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Ensure tenant isolation by including tenant_id in the update condition
+	result := db.Model(&models.Role{}).
+		Where("id = ? AND tenant_id = ?", role.ID, role.TenantID).
+		Select("description", "permissions", "updated_at").
+		Updates(role)
+
+	if result.Error != nil {
+		logger.Error("Failed to update role", "error", result.Error, "id", role.ID, "tenant_id", role.TenantID)
+		return errors.NewInternalError("Failed to update role: " + result.Error.Error())
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.NewResourceNotFoundError("Role not found")
+	}
+
+	return nil
+}
+
+// Delete deletes a role with tenant isolation. User assignments are removed by the foreign key cascade.
+func (r *roleRepository) Delete(ctx context.Context, id string, tenantID string) error {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	result := db.Where("id = ? AND tenant_id = ?", id, tenantID).Delete(&models.Role{})
+
+	if result.Error != nil {
+		logger.Error("Failed to delete role", "error", result.Error, "id", id, "tenant_id", tenantID)
+		return errors.NewInternalError("Failed to delete role: " + result.Error.Error())
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.NewResourceNotFoundError("Role not found")
+	}
+
+	return nil
+}
+
+// List lists a tenant's roles with pagination
+func (r *roleRepository) List(ctx context.Context, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Role], error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return utils.PaginatedResult[models.Role]{}, err
+	}
+
+	var roles []models.Role
+	var totalItems int64
+
+	if err := db.Model(&models.Role{}).
+		Where("tenant_id = ?", tenantID).
+		Count(&totalItems).Error; err != nil {
+		logger.Error("Failed to count roles", "error", err, "tenant_id", tenantID)
+		return utils.PaginatedResult[models.Role]{}, errors.NewInternalError("Failed to count roles: " + err.Error())
+	}
+
+	if err := db.
+		Where("tenant_id = ?", tenantID).
+		Offset(pagination.GetOffset()).
+		Limit(pagination.GetLimit()).
+		Order("name ASC").
+		Find(&roles).Error; err != nil {
+		logger.Error("Failed to list roles", "error", err, "tenant_id", tenantID)
+		return utils.PaginatedResult[models.Role]{}, errors.NewInternalError("Failed to list roles: " + err.Error())
+	}
+
+	return utils.NewPaginatedResult(roles, pagination, totalItems), nil
+}
+
+// GetPermissionsForRoles returns the union of the permissions granted by the named roles in a tenant
+func (r *roleRepository) GetPermissionsForRoles(ctx context.Context, tenantID string, roleNames []string) ([]string, error) {
+	if len(roleNames) == 0 {
+		return []string{}, nil
+	}
+
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var permissions []string
+	if err := db.Model(&models.Role{}).
+		Where("tenant_id = ? AND name IN ?", tenantID, roleNames).
+		Distinct().
+		Pluck("unnest(permissions)", &permissions).Error; err != nil {
+		logger.Error("Failed to get role permissions", "error", err, "tenant_id", tenantID)
+		return nil, errors.NewInternalError("Failed to get role permissions: " + err.Error())
+	}
+
+	return permissions, nil
+}
+
+// GetIDsByNames returns the IDs of the named roles in a tenant
+func (r *roleRepository) GetIDsByNames(ctx context.Context, tenantID string, roleNames []string) ([]string, error) {
+	if len(roleNames) == 0 {
+		return []string{}, nil
+	}
+
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	if err := db.Model(&models.Role{}).
+		Where("tenant_id = ? AND name IN ?", tenantID, roleNames).
+		Pluck("id", &ids).Error; err != nil {
+		logger.Error("Failed to get role IDs", "error", err, "tenant_id", tenantID)
+		return nil, errors.NewInternalError("Failed to get role IDs: " + err.Error())
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,132 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid" // v1.3.0+ - For generating unique IDs for sessions
+	"gorm.io/gorm"           // v1.25.0+ - ORM library for database operations
+
+	"../../../domain/models"
+	"../../../domain/repositories"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+)
+
+// sessionRepository implements the SessionRepository interface using PostgreSQL
+type sessionRepository struct{}
+
+// NewSessionRepository creates a new instance of the PostgreSQL implementation of SessionRepository
+func NewSessionRepository() repositories.SessionRepository {
+	return &sessionRepository{}
+}
+
+// Create persists a new session to the database
+func (r *sessionRepository) Create(ctx context.Context, session *models.Session) (string, error) {
+	if err := session.Validate(); err != nil {
+		return "", errors.NewValidationError(err.Error())
+	}
+
+	if session.ID == "" {
+		session.ID = uuid.New().String()
+	}
+
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if err := db.Create(session).Error; err != nil {
+		logger.Error("Failed to create session", "error", err, "user_id", session.UserID, "tenant_id", session.TenantID)
+		return "", errors.NewInternalError("Failed to create session: " + err.Error())
+	}
+
+	return session.ID, nil
+}
+
+// GetByID retrieves a session by its ID with tenant isolation
+func (r *sessionRepository) GetByID(ctx context.Context, id string, tenantID string) (*models.Session, error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var session models.Session
+	if err := db.Where("id = ? AND tenant_id = ?", id, tenantID).First(&session).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewResourceNotFoundError("session not found")
+		}
+		logger.Error("Failed to get session", "error", err, "id", id, "tenant_id", tenantID)
+		return nil, errors.NewInternalError("Failed to get session: " + err.Error())
+	}
+
+	return &session, nil
+}
+
+// ListActiveByUser lists a user's sessions that are neither revoked nor expired, most recently used first
+func (r *sessionRepository) ListActiveByUser(ctx context.Context, userID string, tenantID string) ([]models.Session, error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var sessions []models.Session
+	if err := db.
+		Where("user_id = ? AND tenant_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, tenantID, time.Now()).
+		Order("last_used_at DESC").
+		Find(&sessions).Error; err != nil {
+		logger.Error("Failed to list sessions", "error", err, "user_id", userID, "tenant_id", tenantID)
+		return nil, errors.NewInternalError("Failed to list sessions: " + err.Error())
+	}
+
+	return sessions, nil
+}
+
+// Extend records that a session's tokens were refreshed and moves its expiry
+func (r *sessionRepository) Extend(ctx context.Context, id string, tenantID string, lastUsedAt, expiresAt time.Time) error {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	result := db.Model(&models.Session{}).
+		Where("id = ? AND tenant_id = ? AND revoked_at IS NULL", id, tenantID).
+		Updates(map[string]interface{}{
+			"last_used_at": lastUsedAt,
+			"expires_at":   expiresAt,
+		})
+
+	if result.Error != nil {
+		logger.Error("Failed to extend session", "error", result.Error, "id", id, "tenant_id", tenantID)
+		return errors.NewInternalError("Failed to extend session: " + result.Error.Error())
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.NewResourceNotFoundError("session not found")
+	}
+
+	return nil
+}
+
+// Revoke marks a session as revoked with tenant isolation. Revoking a revoked session is a no-op.
+func (r *sessionRepository) Revoke(ctx context.Context, id string, tenantID string) error {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	result := db.Model(&models.Session{}).
+		Where("id = ? AND tenant_id = ?", id, tenantID).
+		Update("revoked_at", gorm.Expr("COALESCE(revoked_at, ?)", time.Now()))
+
+	if result.Error != nil {
+		logger.Error("Failed to revoke session", "error", result.Error, "id", id, "tenant_id", tenantID)
+		return errors.NewInternalError("Failed to revoke session: " + result.Error.Error())
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.NewResourceNotFoundError("session not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,150 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid" // v1.3.0+ - For generating unique IDs for share links
+	"gorm.io/gorm"           // v1.25.0+ - ORM library for database operations
+
+	"../../../domain/models"
+	"../../../domain/repositories"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+)
+
+// shareLinkRepository implements the ShareLinkRepository interface using PostgreSQL
+type shareLinkRepository struct{}
+
+// NewShareLinkRepository creates a new instance of the PostgreSQL implementation of ShareLinkRepository
+func NewShareLinkRepository() repositories.ShareLinkRepository {
+	return &shareLinkRepository{}
+}
+
+// Create persists a new share link to the database
+func (r *shareLinkRepository) Create(ctx context.Context, link *models.ShareLink) (string, error) {
+	if link.CreatedAt.IsZero() {
+		link.CreatedAt = time.Now()
+	}
+
+	if err := link.Validate(); err != nil {
+		return "", errors.NewValidationError(err.Error())
+	}
+
+	if link.ID == "" {
+		link.ID = uuid.New().String()
+	}
+
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if err := db.Create(link).Error; err != nil {
+		logger.Error("Failed to create share link", "error", err, "document_id", link.DocumentID, "tenant_id", link.TenantID)
+		return "", errors.NewInternalError("Failed to create share link: " + err.Error())
+	}
+
+	return link.ID, nil
+}
+
+// GetByID retrieves a share link by its ID with tenant isolation
+func (r *shareLinkRepository) GetByID(ctx context.Context, id string, tenantID string) (*models.ShareLink, error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var link models.ShareLink
+	if err := db.Where("id = ? AND tenant_id = ?", id, tenantID).First(&link).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewResourceNotFoundError("Share link not found")
+		}
+		logger.Error("Failed to get share link", "error", err, "id", id, "tenant_id", tenantID)
+		return nil, errors.NewInternalError("Failed to get share link: " + err.Error())
+	}
+
+	return &link, nil
+}
+
+// GetByTokenHash retrieves a share link by the hash of its token
+func (r *shareLinkRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*models.ShareLink, error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var link models.ShareLink
+	if err := db.Where("token_hash = ?", tokenHash).First(&link).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewResourceNotFoundError("Share link not found")
+		}
+		logger.Error("Failed to get share link by token", "error", err)
+		return nil, errors.NewInternalError("Failed to get share link: " + err.Error())
+	}
+
+	return &link, nil
+}
+
+// ListByDocument lists the share links of a document with tenant isolation, newest first
+func (r *shareLinkRepository) ListByDocument(ctx context.Context, documentID string, tenantID string) ([]*models.ShareLink, error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var links []*models.ShareLink
+	if err := db.
+		Where("document_id = ? AND tenant_id = ?", documentID, tenantID).
+		Order("created_at DESC").
+		Find(&links).Error; err != nil {
+		logger.Error("Failed to list share links", "error", err, "document_id", documentID, "tenant_id", tenantID)
+		return nil, errors.NewInternalError("Failed to list share links: " + err.Error())
+	}
+
+	return links, nil
+}
+
+// Revoke marks a share link as revoked with tenant isolation. Revoking a revoked link is a no-op.
+func (r *shareLinkRepository) Revoke(ctx context.Context, id string, tenantID string) error {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	result := db.Model(&models.ShareLink{}).
+		Where("id = ? AND tenant_id = ?", id, tenantID).
+		Update("revoked_at", gorm.Expr("COALESCE(revoked_at, ?)", time.Now()))
+
+	if result.Error != nil {
+		logger.Error("Failed to revoke share link", "error", result.Error, "id", id, "tenant_id", tenantID)
+		return errors.NewInternalError("Failed to revoke share link: " + result.Error.Error())
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.NewResourceNotFoundError("Share link not found")
+	}
+
+	return nil
+}
+
+// RecordDownload atomically counts a download against the share link
+func (r *shareLinkRepository) RecordDownload(ctx context.Context, id string) (bool, error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	// The limits are checked in the update itself so concurrent downloads cannot exceed max_downloads
+	result := db.Model(&models.ShareLink{}).
+		Where("id = ? AND revoked_at IS NULL AND expires_at > ?", id, time.Now()).
+		Where("max_downloads = 0 OR download_count < max_downloads").
+		Update("download_count", gorm.Expr("download_count + 1"))
+
+	if result.Error != nil {
+		logger.Error("Failed to record share link download", "error", result.Error, "id", id)
+		return false, errors.NewInternalError("Failed to record share link download: " + result.Error.Error())
+	}
+
+	return result.RowsAffected > 0, nil
+}
@@ -0,0 +1,176 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid" // v1.3.0+ - For generating unique IDs for signature requests and signers
+	"gorm.io/gorm"           // v1.25.0+ - ORM library for database operations
+
+	"../../../domain/models"
+	"../../../domain/repositories"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+	"../../../pkg/utils"
+)
+
+// signatureRequestRepository implements the SignatureRequestRepository interface using PostgreSQL
+type signatureRequestRepository struct{}
+
+// NewSignatureRequestRepository creates a new instance of the PostgreSQL implementation of SignatureRequestRepository
+func NewSignatureRequestRepository() repositories.SignatureRequestRepository {
+	return &signatureRequestRepository{}
+}
+
+// Create persists a new signature request and its signers, joining the transaction carried by ctx if any
+func (r *signatureRequestRepository) Create(ctx context.Context, request *models.SignatureRequest) (string, error) {
+	if err := request.Validate(); err != nil {
+		return "", errors.NewValidationError(err.Error())
+	}
+
+	if request.ID == "" {
+		request.ID = uuid.New().String()
+	}
+	for i := range request.Signers {
+		request.Signers[i].RequestID = request.ID
+		if request.Signers[i].ID == "" {
+			request.Signers[i].ID = uuid.New().String()
+		}
+	}
+
+	now := time.Now()
+	if request.CreatedAt.IsZero() {
+		request.CreatedAt = now
+	}
+	if request.UpdatedAt.IsZero() {
+		request.UpdatedAt = now
+	}
+
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	// The signers are created with the request through its association; the signed version is
+	// only known once the request completes
+	if err := db.Omit("signed_version_id").Create(request).Error; err != nil {
+		logger.Error("Failed to create signature request", "error", err, "document_id", request.DocumentID, "tenant_id", request.TenantID)
+		return "", errors.NewInternalError("Failed to create signature request: " + err.Error())
+	}
+
+	return request.ID, nil
+}
+
+// GetByID retrieves a signature request with its signers by its ID with tenant isolation
+func (r *signatureRequestRepository) GetByID(ctx context.Context, id string, tenantID string) (*models.SignatureRequest, error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.get(db.Where("id = ? AND tenant_id = ?", id, tenantID))
+}
+
+// GetByEnvelope retrieves the signature request of an envelope of a provider across tenants
+func (r *signatureRequestRepository) GetByEnvelope(ctx context.Context, provider string, envelopeID string) (*models.SignatureRequest, error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.get(db.Where("provider = ? AND envelope_id = ?", provider, envelopeID))
+}
+
+// get retrieves the signature request matched by query with its signers
+func (r *signatureRequestRepository) get(query *gorm.DB) (*models.SignatureRequest, error) {
+	var request models.SignatureRequest
+	if err := query.Preload("Signers", orderSigners).First(&request).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewResourceNotFoundError("Signature request not found")
+		}
+		logger.Error("Failed to get signature request", "error", err)
+		return nil, errors.NewInternalError("Failed to get signature request: " + err.Error())
+	}
+
+	return &request, nil
+}
+
+// Update persists the status of a signature request and the statuses of its signers
+func (r *signatureRequestRepository) Update(ctx context.Context, request *models.SignatureRequest) error {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		updates := map[string]interface{}{
+			"envelope_id":   request.EnvelopeID,
+			"status":        request.Status,
+			"status_reason": request.StatusReason,
+			"updated_at":    request.UpdatedAt,
+			"completed_at":  request.CompletedAt,
+		}
+		if request.SignedVersionID != "" {
+			updates["signed_version_id"] = request.SignedVersionID
+		}
+
+		result := tx.Model(&models.SignatureRequest{}).
+			Where("id = ? AND tenant_id = ?", request.ID, request.TenantID).
+			Updates(updates)
+		if result.Error != nil {
+			logger.Error("Failed to update signature request", "error", result.Error, "id", request.ID, "tenant_id", request.TenantID)
+			return errors.NewInternalError("Failed to update signature request: " + result.Error.Error())
+		}
+		if result.RowsAffected == 0 {
+			return errors.NewResourceNotFoundError("Signature request not found")
+		}
+
+		for _, signer := range request.Signers {
+			if err := tx.Model(&models.Signer{}).
+				Where("id = ? AND request_id = ?", signer.ID, request.ID).
+				Updates(map[string]interface{}{
+					"status":     signer.Status,
+					"signed_at":  signer.SignedAt,
+					"ip_address": signer.IPAddress,
+				}).Error; err != nil {
+				logger.Error("Failed to update signer", "error", err, "id", signer.ID, "request_id", request.ID)
+				return errors.NewInternalError("Failed to update signer: " + err.Error())
+			}
+		}
+		return nil
+	})
+}
+
+// ListByDocument lists the signature requests of a document with their signers, newest first
+func (r *signatureRequestRepository) ListByDocument(ctx context.Context, documentID string, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.SignatureRequest], error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return utils.PaginatedResult[models.SignatureRequest]{}, err
+	}
+
+	var requests []models.SignatureRequest
+	var totalItems int64
+
+	query := db.Model(&models.SignatureRequest{}).Where("document_id = ? AND tenant_id = ?", documentID, tenantID)
+	if err := query.Count(&totalItems).Error; err != nil {
+		logger.Error("Failed to count signature requests", "error", err, "document_id", documentID, "tenant_id", tenantID)
+		return utils.PaginatedResult[models.SignatureRequest]{}, errors.NewInternalError("Failed to count signature requests: " + err.Error())
+	}
+
+	if err := query.
+		Preload("Signers", orderSigners).
+		Offset(pagination.GetOffset()).
+		Limit(pagination.GetLimit()).
+		Order("created_at DESC, id DESC").
+		Find(&requests).Error; err != nil {
+		logger.Error("Failed to list signature requests", "error", err, "document_id", documentID, "tenant_id", tenantID)
+		return utils.PaginatedResult[models.SignatureRequest]{}, errors.NewInternalError("Failed to list signature requests: " + err.Error())
+	}
+
+	return utils.NewPaginatedResult(requests, pagination, totalItems), nil
+}
+
+// orderSigners loads the signers of signature requests in their routing order
+func orderSigners(db *gorm.DB) *gorm.DB {
+	return db.Order("routing_order ASC, email ASC")
+}
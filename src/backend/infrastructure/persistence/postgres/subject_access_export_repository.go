@@ -0,0 +1,135 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid" // v1.3.0+ - For generating unique IDs for subject access exports
+	"gorm.io/gorm"           // v1.25.0+ - For claiming exports in a transaction
+	"gorm.io/gorm/clause"    // v1.25.0+ - For row locking when claiming exports
+
+	"../../../domain/models"
+	"../../../domain/repositories"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+)
+
+// subjectAccessExportRepository implements the SubjectAccessExportRepository interface using PostgreSQL
+type subjectAccessExportRepository struct{}
+
+// NewSubjectAccessExportRepository creates a new instance of the PostgreSQL implementation of SubjectAccessExportRepository
+func NewSubjectAccessExportRepository() repositories.SubjectAccessExportRepository {
+	return &subjectAccessExportRepository{}
+}
+
+// Create persists a new subject access export
+func (r *subjectAccessExportRepository) Create(ctx context.Context, export *models.SubjectAccessExport) (string, error) {
+	if err := export.Validate(); err != nil {
+		return "", errors.NewValidationError(err.Error())
+	}
+
+	if export.ID == "" {
+		export.ID = uuid.New().String()
+	}
+
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if err := db.Create(export).Error; err != nil {
+		logger.Error("Failed to create subject access export", "error", err, "subject_id", export.SubjectID, "tenant_id", export.TenantID)
+		return "", errors.NewInternalError("Failed to create subject access export: " + err.Error())
+	}
+
+	return export.ID, nil
+}
+
+// GetByID retrieves a subject access export by its ID with tenant isolation
+func (r *subjectAccessExportRepository) GetByID(ctx context.Context, id string, tenantID string) (*models.SubjectAccessExport, error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var export models.SubjectAccessExport
+	if err := db.Where("id = ? AND tenant_id = ?", id, tenantID).First(&export).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewResourceNotFoundError("Subject access export not found")
+		}
+		logger.Error("Failed to get subject access export", "error", err, "id", id, "tenant_id", tenantID)
+		return nil, errors.NewInternalError("Failed to get subject access export: " + err.Error())
+	}
+
+	return &export, nil
+}
+
+// ClaimNext locks the oldest claimable export, skipping exports locked by other workers, and marks it as running
+func (r *subjectAccessExportRepository) ClaimNext(ctx context.Context, staleBefore time.Time) (*models.SubjectAccessExport, error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var claimed *models.SubjectAccessExport
+	err = db.Transaction(func(tx *gorm.DB) error {
+		var exports []*models.SubjectAccessExport
+		if err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? OR (status = ? AND updated_at < ?)",
+				models.SubjectAccessExportStatusPending, models.SubjectAccessExportStatusRunning, staleBefore).
+			Order("created_at ASC").
+			Limit(1).
+			Find(&exports).Error; err != nil {
+			return err
+		}
+		if len(exports) == 0 {
+			return nil
+		}
+
+		export := exports[0]
+		export.Start(time.Now())
+		if err := tx.Save(export).Error; err != nil {
+			return err
+		}
+		claimed = export
+		return nil
+	})
+	if err != nil {
+		logger.Error("Failed to claim subject access export", "error", err)
+		return nil, errors.NewInternalError("Failed to claim subject access export: " + err.Error())
+	}
+
+	return claimed, nil
+}
+
+// Update persists the status, counts and result of a subject access export
+func (r *subjectAccessExportRepository) Update(ctx context.Context, export *models.SubjectAccessExport) error {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	result := db.Model(&models.SubjectAccessExport{}).
+		Where("id = ? AND tenant_id = ?", export.ID, export.TenantID).
+		Updates(map[string]interface{}{
+			"status":            export.Status,
+			"document_count":    export.DocumentCount,
+			"audit_event_count": export.AuditEventCount,
+			"archive_path":      export.ArchivePath,
+			"archive_size":      export.ArchiveSize,
+			"error":             export.Error,
+			"updated_at":        export.UpdatedAt,
+			"started_at":        export.StartedAt,
+			"completed_at":      export.CompletedAt,
+		})
+	if result.Error != nil {
+		logger.Error("Failed to update subject access export", "error", result.Error, "id", export.ID, "tenant_id", export.TenantID)
+		return errors.NewInternalError("Failed to update subject access export: " + result.Error.Error())
+	}
+	if result.RowsAffected == 0 {
+		return errors.NewResourceNotFoundError("Subject access export not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,240 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid" // v1.3.0+ - For generating unique IDs for tenant deletions
+	"gorm.io/gorm"           // v1.25.0+ - For claiming deletions in a transaction
+	"gorm.io/gorm/clause"    // v1.25.0+ - For row locking when claiming deletions
+
+	"../../../domain/models"
+	"../../../domain/repositories"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+	"../../../pkg/utils"
+)
+
+// activeTenantDeletionStatuses are the statuses of deletions that are not finished
+var activeTenantDeletionStatuses = []string{
+	models.TenantDeletionStatusScheduled,
+	models.TenantDeletionStatusRunning,
+	models.TenantDeletionStatusFailed,
+}
+
+// tenantDeletionRepository implements the TenantDeletionRepository interface using PostgreSQL
+type tenantDeletionRepository struct{}
+
+// NewTenantDeletionRepository creates a new instance of the PostgreSQL implementation of TenantDeletionRepository
+func NewTenantDeletionRepository() repositories.TenantDeletionRepository {
+	return &tenantDeletionRepository{}
+}
+
+// Create persists a new tenant deletion; the unique index on the tenant of unfinished deletions
+// rejects a second deletion of a tenant
+func (r *tenantDeletionRepository) Create(ctx context.Context, deletion *models.TenantDeletion) (string, error) {
+	if err := deletion.Validate(); err != nil {
+		return "", errors.NewValidationError(err.Error())
+	}
+
+	if deletion.ID == "" {
+		deletion.ID = uuid.New().String()
+	}
+
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(deletion)
+	if result.Error != nil {
+		logger.Error("Failed to create tenant deletion", "error", result.Error, "tenant_id", deletion.TenantID)
+		return "", errors.NewInternalError("Failed to create tenant deletion: " + result.Error.Error())
+	}
+
+	if result.RowsAffected == 0 {
+		return "", errors.NewValidationError("the tenant already has a deletion in progress")
+	}
+
+	return deletion.ID, nil
+}
+
+// GetByID retrieves a tenant deletion by its ID
+func (r *tenantDeletionRepository) GetByID(ctx context.Context, id string) (*models.TenantDeletion, error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var deletion models.TenantDeletion
+	if err := db.Where("id = ?", id).First(&deletion).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewResourceNotFoundError("Tenant deletion not found")
+		}
+		logger.Error("Failed to get tenant deletion", "error", err, "id", id)
+		return nil, errors.NewInternalError("Failed to get tenant deletion: " + err.Error())
+	}
+
+	return &deletion, nil
+}
+
+// GetActiveByTenant retrieves the scheduled, running or failed deletion of a tenant
+func (r *tenantDeletionRepository) GetActiveByTenant(ctx context.Context, tenantID string) (*models.TenantDeletion, error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var deletion models.TenantDeletion
+	if err := db.Where("tenant_id = ? AND status IN ?", tenantID, activeTenantDeletionStatuses).First(&deletion).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewResourceNotFoundError("Tenant deletion not found")
+		}
+		logger.Error("Failed to get tenant deletion", "error", err, "tenant_id", tenantID)
+		return nil, errors.NewInternalError("Failed to get tenant deletion: " + err.Error())
+	}
+
+	return &deletion, nil
+}
+
+// List lists tenant deletions with pagination, most recent first
+func (r *tenantDeletionRepository) List(ctx context.Context, pagination *utils.Pagination) (utils.PaginatedResult[models.TenantDeletion], error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return utils.PaginatedResult[models.TenantDeletion]{}, err
+	}
+
+	var deletions []models.TenantDeletion
+	var totalItems int64
+
+	if err := db.Model(&models.TenantDeletion{}).Count(&totalItems).Error; err != nil {
+		logger.Error("Failed to count tenant deletions", "error", err)
+		return utils.PaginatedResult[models.TenantDeletion]{}, errors.NewInternalError("Failed to count tenant deletions: " + err.Error())
+	}
+
+	if err := db.
+		Offset(pagination.GetOffset()).
+		Limit(pagination.GetLimit()).
+		Order("created_at DESC").
+		Find(&deletions).Error; err != nil {
+		logger.Error("Failed to list tenant deletions", "error", err)
+		return utils.PaginatedResult[models.TenantDeletion]{}, errors.NewInternalError("Failed to list tenant deletions: " + err.Error())
+	}
+
+	return utils.NewPaginatedResult(deletions, pagination, totalItems), nil
+}
+
+// ClaimNext locks the oldest due deletion, skipping deletions locked by other workers, and marks it as running
+func (r *tenantDeletionRepository) ClaimNext(ctx context.Context, now time.Time, retryBefore time.Time) (*models.TenantDeletion, error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var claimed *models.TenantDeletion
+	err = db.Transaction(func(tx *gorm.DB) error {
+		var deletions []*models.TenantDeletion
+		if err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("(status = ? AND scheduled_for <= ?) OR (status IN ? AND updated_at < ?)",
+				models.TenantDeletionStatusScheduled, now,
+				[]string{models.TenantDeletionStatusRunning, models.TenantDeletionStatusFailed}, retryBefore).
+			Order("scheduled_for ASC").
+			Limit(1).
+			Find(&deletions).Error; err != nil {
+			return err
+		}
+		if len(deletions) == 0 {
+			return nil
+		}
+
+		deletion := deletions[0]
+		deletion.Start(time.Now())
+		if err := tx.Save(deletion).Error; err != nil {
+			return err
+		}
+		claimed = deletion
+		return nil
+	})
+	if err != nil {
+		logger.Error("Failed to claim tenant deletion", "error", err)
+		return nil, errors.NewInternalError("Failed to claim tenant deletion: " + err.Error())
+	}
+
+	return claimed, nil
+}
+
+// Touch updates the timestamp of a running deletion
+func (r *tenantDeletionRepository) Touch(ctx context.Context, id string, now time.Time) error {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := db.Model(&models.TenantDeletion{}).
+		Where("id = ? AND status = ?", id, models.TenantDeletionStatusRunning).
+		Update("updated_at", now).Error; err != nil {
+		logger.Error("Failed to touch tenant deletion", "error", err, "id", id)
+		return errors.NewInternalError("Failed to touch tenant deletion: " + err.Error())
+	}
+
+	return nil
+}
+
+// Cancel persists the cancellation of a deletion, provided it is still scheduled or failed
+// without erasing anything
+func (r *tenantDeletionRepository) Cancel(ctx context.Context, deletion *models.TenantDeletion) error {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	result := db.Model(&models.TenantDeletion{}).
+		Where("id = ? AND (status = ? OR (status = ? AND report = ''))",
+			deletion.ID, models.TenantDeletionStatusScheduled, models.TenantDeletionStatusFailed).
+		Updates(map[string]interface{}{
+			"status":       deletion.Status,
+			"error":        deletion.Error,
+			"updated_at":   deletion.UpdatedAt,
+			"completed_at": deletion.CompletedAt,
+		})
+	if result.Error != nil {
+		logger.Error("Failed to cancel tenant deletion", "error", result.Error, "id", deletion.ID, "tenant_id", deletion.TenantID)
+		return errors.NewInternalError("Failed to cancel tenant deletion: " + result.Error.Error())
+	}
+	if result.RowsAffected == 0 {
+		return errors.NewValidationError("the tenant deletion has started erasing the tenant")
+	}
+
+	return nil
+}
+
+// Update persists the status and report of a tenant deletion
+func (r *tenantDeletionRepository) Update(ctx context.Context, deletion *models.TenantDeletion) error {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	result := db.Model(&models.TenantDeletion{}).
+		Where("id = ?", deletion.ID).
+		Updates(map[string]interface{}{
+			"status":           deletion.Status,
+			"report":           deletion.Report,
+			"report_signature": deletion.ReportSignature,
+			"signing_key_id":   deletion.SigningKeyID,
+			"error":            deletion.Error,
+			"updated_at":       deletion.UpdatedAt,
+			"started_at":       deletion.StartedAt,
+			"completed_at":     deletion.CompletedAt,
+		})
+	if result.Error != nil {
+		logger.Error("Failed to update tenant deletion", "error", result.Error, "id", deletion.ID, "tenant_id", deletion.TenantID)
+		return errors.NewInternalError("Failed to update tenant deletion: " + result.Error.Error())
+	}
+	if result.RowsAffected == 0 {
+		return errors.NewResourceNotFoundError("Tenant deletion not found")
+	}
+
+	return nil
+}
@@ -4,6 +4,8 @@ package postgres
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid" // v1.3.0+
@@ -53,8 +55,8 @@ func (r *tenantRepository) Create(ctx context.Context, tenant *models.Tenant) (s
 		tenant.UpdatedAt = now
 	}
 
-	// Create the tenant in the database
-	if err := r.db.WithContext(ctx).Create(tenant).Error; err != nil {
+	// Create the tenant in the database, within the transaction carried by ctx if any
+	if err := dbFromContext(ctx, r.db).Create(tenant).Error; err != nil {
 		logger.ErrorContext(ctx, "failed to create tenant", "error", err, "tenant_name", tenant.Name)
 		return "", errors.NewDatabaseError("failed to create tenant: " + err.Error())
 	}
@@ -418,4 +420,108 @@ func (r *tenantRepository) CountByStatus(ctx context.Context, status string) (in
 	}
 
 	return count, nil
-}
\ No newline at end of file
+}
+
+// tenantTablesQuery lists the tables of the current schema with a tenant_id column. Partitions
+// are left out, as their rows are counted and deleted through the partitioned table.
+const tenantTablesQuery = `SELECT c.relname FROM pg_attribute a
+JOIN pg_class c ON c.oid = a.attrelid
+JOIN pg_namespace n ON n.oid = c.relnamespace
+WHERE a.attname = 'tenant_id' AND NOT a.attisdropped
+AND c.relkind IN ('r', 'p') AND NOT c.relispartition AND n.nspname = current_schema()
+ORDER BY c.relname`
+
+// tenantDataRetainedTables are the tables keyed by tenant whose rows outlive the tenant
+var tenantDataRetainedTables = map[string]bool{
+	"tenant_deletions": true, // Kept as evidence of the erasure
+}
+
+// PurgeData permanently deletes a tenant with all its rows in one transaction. Rows with a foreign
+// key to the tenant are deleted by its cascade; the rows of the tables without one, such as the
+// outbox and the audit log, are deleted table by table.
+func (r *tenantRepository) PurgeData(ctx context.Context, id string) (map[string]int64, error) {
+	if id == "" {
+		return nil, errors.NewValidationError("tenant ID cannot be empty")
+	}
+
+	var deleted map[string]int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Erasing a large tenant can take longer than any request should
+		if err := tx.Exec("SET LOCAL statement_timeout = 0").Error; err != nil {
+			return err
+		}
+
+		// The rows are counted in the transaction deleting them, as cascades do not report their rows
+		var err error
+		if deleted, err = countTenantRows(tx, id); err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM tenants WHERE id = ?", id).Error; err != nil {
+			return err
+		}
+		for table, count := range deleted {
+			if count == 0 || table == "tenants" {
+				continue
+			}
+			if err := tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE tenant_id = ?", quoteIdentifier(table)), id).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to purge tenant data", "error", err, "tenant_id", id)
+		return nil, errors.NewDatabaseError("failed to purge tenant data: " + err.Error())
+	}
+
+	logger.InfoContext(ctx, "tenant data purged successfully", "tenant_id", id)
+	return deleted, nil
+}
+
+// CountData counts the rows of a tenant in each table keyed by tenant.
+func (r *tenantRepository) CountData(ctx context.Context, id string) (map[string]int64, error) {
+	if id == "" {
+		return nil, errors.NewValidationError("tenant ID cannot be empty")
+	}
+
+	counts, err := countTenantRows(r.db.WithContext(ctx), id)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to count tenant data", "error", err, "tenant_id", id)
+		return nil, errors.NewDatabaseError("failed to count tenant data: " + err.Error())
+	}
+
+	return counts, nil
+}
+
+// countTenantRows counts the rows of a tenant in the tenants table and in every table with a
+// tenant_id column, so that tables added later are covered without being listed here
+func countTenantRows(db *gorm.DB, tenantID string) (map[string]int64, error) {
+	var tables []string
+	if err := db.Raw(tenantTablesQuery).Scan(&tables).Error; err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(tables)+1)
+	var count int64
+	if err := db.Raw("SELECT COUNT(*) FROM tenants WHERE id = ?", tenantID).Scan(&count).Error; err != nil {
+		return nil, err
+	}
+	counts["tenants"] = count
+
+	for _, table := range tables {
+		if tenantDataRetainedTables[table] {
+			continue
+		}
+		var count int64
+		if err := db.Raw(fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE tenant_id = ?", quoteIdentifier(table)), tenantID).Scan(&count).Error; err != nil {
+			return nil, err
+		}
+		counts[table] = count
+	}
+	return counts, nil
+}
+
+// quoteIdentifier quotes a table name read from the catalog for use in a statement
+func quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
@@ -0,0 +1,74 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/otel/trace" // v1.11.0+
+	"gorm.io/gorm"                   // v1.25.0+
+
+	"../../../pkg/tracing" // For the spans of database operations
+)
+
+// spanInstanceKey is the key the span of a database operation is kept under while it runs
+const spanInstanceKey = "tracing:span"
+
+// registerTracing records a client span for each database operation run through db, so the
+// queries of every repository are traced without instrumenting each of them
+func registerTracing(db *gorm.DB) error {
+	callbacks := db.Callback()
+	registrations := []error{
+		callbacks.Create().Before("gorm:create").Register("tracing:before_create", startOperationSpan("create")),
+		callbacks.Create().After("gorm:create").Register("tracing:after_create", endOperationSpan),
+		callbacks.Query().Before("gorm:query").Register("tracing:before_query", startOperationSpan("query")),
+		callbacks.Query().After("gorm:query").Register("tracing:after_query", endOperationSpan),
+		callbacks.Update().Before("gorm:update").Register("tracing:before_update", startOperationSpan("update")),
+		callbacks.Update().After("gorm:update").Register("tracing:after_update", endOperationSpan),
+		callbacks.Delete().Before("gorm:delete").Register("tracing:before_delete", startOperationSpan("delete")),
+		callbacks.Delete().After("gorm:delete").Register("tracing:after_delete", endOperationSpan),
+		callbacks.Row().Before("gorm:row").Register("tracing:before_row", startOperationSpan("row")),
+		callbacks.Row().After("gorm:row").Register("tracing:after_row", endOperationSpan),
+		callbacks.Raw().Before("gorm:raw").Register("tracing:before_raw", startOperationSpan("raw")),
+		callbacks.Raw().After("gorm:raw").Register("tracing:after_raw", endOperationSpan),
+	}
+	for _, err := range registrations {
+		if err != nil {
+			return fmt.Errorf("failed to register tracing callbacks: %w", err)
+		}
+	}
+	return nil
+}
+
+// startOperationSpan returns a callback starting the span of a database operation in the context
+// of its statement
+func startOperationSpan(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		ctx, span := tracing.StartSpan(db.Statement.Context, "postgres "+operation, trace.WithSpanKind(trace.SpanKindClient))
+		db.Statement.Context = ctx
+		db.InstanceSet(spanInstanceKey, span)
+	}
+}
+
+// endOperationSpan ends the span of a database operation with its statement and outcome. Records
+// that were not found are an answer rather than a failure of the database.
+func endOperationSpan(db *gorm.DB) {
+	value, ok := db.InstanceGet(spanInstanceKey)
+	if !ok {
+		return
+	}
+	span, ok := value.(trace.Span)
+	if !ok {
+		return
+	}
+
+	tracing.AddAttribute(span, "db.system", "postgresql")
+	tracing.AddAttribute(span, "db.sql.table", db.Statement.Table)
+	tracing.AddAttribute(span, "db.statement", db.Statement.SQL.String())
+	tracing.AddAttribute(span, "db.rows_affected", db.Statement.RowsAffected)
+
+	err := db.Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = nil
+	}
+	tracing.EndSpan(span, err)
+}
@@ -0,0 +1,24 @@
+package postgres
+
+import (
+	"context"
+
+	"gorm.io/gorm" // v1.25.0+ - ORM library for database operations
+
+	"../../../domain/repositories"
+)
+
+// transactionManager implements the TransactionManager interface using PostgreSQL transactions
+type transactionManager struct{}
+
+// NewTransactionManager creates a new instance of the PostgreSQL implementation of TransactionManager
+func NewTransactionManager() repositories.TransactionManager {
+	return &transactionManager{}
+}
+
+// WithTransaction executes fn within a database transaction carried by the context passed to fn
+func (m *transactionManager) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return WithTransaction(ctx, func(tx *gorm.DB) error {
+		return fn(ContextWithTransaction(ctx, tx))
+	})
+}
@@ -0,0 +1,113 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid" // v1.3.0+ - For generating unique IDs for upload limits
+	"gorm.io/gorm/clause"    // v1.25.0+ - For replacing the limits of an existing scope
+
+	"../../../domain/models"
+	"../../../domain/repositories"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+)
+
+// uploadLimitRepository implements the UploadLimitRepository interface using PostgreSQL
+type uploadLimitRepository struct{}
+
+// NewUploadLimitRepository creates a new instance of the PostgreSQL implementation of UploadLimitRepository
+func NewUploadLimitRepository() repositories.UploadLimitRepository {
+	return &uploadLimitRepository{}
+}
+
+// Save creates the limit for its scope, or replaces the limits of an existing one
+func (r *uploadLimitRepository) Save(ctx context.Context, limit *models.UploadLimit) error {
+	if err := limit.Validate(); err != nil {
+		return errors.NewValidationError(err.Error())
+	}
+
+	if limit.ID == "" {
+		limit.ID = uuid.New().String()
+	}
+
+	now := time.Now()
+	if limit.CreatedAt.IsZero() {
+		limit.CreatedAt = now
+	}
+	limit.UpdatedAt = now
+
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "tenant_id"}, {Name: "scope"}, {Name: "scope_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"max_file_size_bytes", "max_daily_upload_bytes", "updated_at"}),
+	}).Create(limit).Error; err != nil {
+		logger.Error("Failed to save upload limit", "error", err, "scope", limit.Scope, "scope_id", limit.ScopeID, "tenant_id", limit.TenantID)
+		return errors.NewInternalError("Failed to save upload limit: " + err.Error())
+	}
+
+	return nil
+}
+
+// Delete removes the limit of a user or role with tenant isolation
+func (r *uploadLimitRepository) Delete(ctx context.Context, tenantID, scope, scopeID string) error {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	result := db.Where("tenant_id = ? AND scope = ? AND scope_id = ?", tenantID, scope, scopeID).Delete(&models.UploadLimit{})
+	if result.Error != nil {
+		logger.Error("Failed to delete upload limit", "error", result.Error, "scope", scope, "scope_id", scopeID, "tenant_id", tenantID)
+		return errors.NewInternalError("Failed to delete upload limit: " + result.Error.Error())
+	}
+	if result.RowsAffected == 0 {
+		return errors.NewResourceNotFoundError("Upload limit not found")
+	}
+
+	return nil
+}
+
+// ListByTenant lists all upload limits of a tenant
+func (r *uploadLimitRepository) ListByTenant(ctx context.Context, tenantID string) ([]*models.UploadLimit, error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var limits []*models.UploadLimit
+	if err := db.Where("tenant_id = ?", tenantID).Order("scope, scope_id").Find(&limits).Error; err != nil {
+		logger.Error("Failed to list upload limits", "error", err, "tenant_id", tenantID)
+		return nil, errors.NewInternalError("Failed to list upload limits: " + err.Error())
+	}
+
+	return limits, nil
+}
+
+// ListForUser lists the limits that apply to a user: their own and those of the given roles
+func (r *uploadLimitRepository) ListForUser(ctx context.Context, tenantID, userID string, roles []string) ([]*models.UploadLimit, error) {
+	db, err := GetDBFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := db.Where("tenant_id = ?", tenantID)
+	if len(roles) > 0 {
+		query = query.Where("(scope = ? AND scope_id = ?) OR (scope = ? AND scope_id IN ?)",
+			models.UploadLimitScopeUser, userID, models.UploadLimitScopeRole, roles)
+	} else {
+		query = query.Where("scope = ? AND scope_id = ?", models.UploadLimitScopeUser, userID)
+	}
+
+	var limits []*models.UploadLimit
+	if err := query.Find(&limits).Error; err != nil {
+		logger.Error("Failed to list upload limits for user", "error", err, "user_id", userID, "tenant_id", tenantID)
+		return nil, errors.NewInternalError("Failed to list upload limits: " + err.Error())
+	}
+
+	return limits, nil
+}
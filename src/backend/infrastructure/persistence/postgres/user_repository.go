@@ -40,20 +40,14 @@ func (r *userRepository) Create(ctx context.Context, user *models.User) (string,
 		user.ID = uuid.New().String()
 	}
 
-	tx := r.db.WithContext(ctx).Begin()
-	if tx.Error != nil {
-		return "", errors.Wrap(tx.Error, "failed to begin transaction")
-	}
-
-	if err := tx.Create(user).Error; err != nil {
-		tx.Rollback()
+	// Join the transaction carried by ctx, so a user can be created with its tenant
+	err := dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		return tx.Create(user).Error
+	})
+	if err != nil {
 		return "", errors.Wrap(err, "failed to create user")
 	}
 
-	if err := tx.Commit().Error; err != nil {
-		return "", errors.Wrap(err, "failed to commit transaction")
-	}
-
 	return user.ID, nil
 }
 
@@ -72,6 +66,13 @@ func (r *userRepository) GetByID(ctx context.Context, id string, tenantID string
 		return nil, errors.Wrap(err, "failed to get user by ID")
 	}
 
+	// Load the groups the user is a member of, used to resolve group permission grants
+	if err := r.db.WithContext(ctx).Table("group_memberships").
+		Where("user_id = ? AND tenant_id = ?", id, tenantID).
+		Pluck("group_id", &user.Groups).Error; err != nil {
+		return nil, errors.Wrap(err, "failed to get user groups")
+	}
+
 	return &user, nil
 }
 
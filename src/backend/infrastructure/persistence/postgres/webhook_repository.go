@@ -7,6 +7,7 @@ import (
 
 	"github.com/google/uuid" // v1.3.0+ - For generating unique IDs for webhooks and deliveries
 	"gorm.io/gorm" // v1.25.0+ - ORM library for database operations
+	"gorm.io/gorm/clause" // v1.25.0+ - For row locking when claiming deliveries
 
 	"../../../domain/models"
 	"../../../domain/repositories"
@@ -15,6 +16,10 @@ import (
 	"../../../pkg/utils"
 )
 
+// webhookDeliveryClaimLease is how long a claimed delivery is hidden from other workers. A delivery
+// whose worker stopped before recording the attempt becomes due again once the lease expires.
+const webhookDeliveryClaimLease = 5 * time.Minute
+
 // webhookRepository implements the WebhookRepository interface using PostgreSQL
 type webhookRepository struct{}
 
@@ -320,6 +325,58 @@ func (r *webhookRepository) ListDeliveriesByWebhook(ctx context.Context, webhook
 	return utils.NewPaginatedResult(deliveries, pagination, totalItems), nil
 }
 
+// ListDeliveriesByFilter lists delivery records for a tenant matching the filter with pagination
+func (r *webhookRepository) ListDeliveriesByFilter(ctx context.Context, tenantID string, filter models.WebhookDeliveryFilter, pagination *utils.Pagination) (utils.PaginatedResult[models.WebhookDelivery], error) {
+	db, err := GetDB()
+	if err != nil {
+		return utils.PaginatedResult[models.WebhookDelivery]{}, err
+	}
+
+	var deliveries []models.WebhookDelivery
+	var totalItems int64
+
+	// Join with webhooks table to ensure tenant isolation
+	baseQuery := db.WithContext(ctx).
+		Table("webhook_deliveries").
+		Joins("JOIN webhooks ON webhook_deliveries.webhook_id = webhooks.id").
+		Where("webhooks.tenant_id = ?", tenantID)
+
+	// Apply optional filters
+	if filter.WebhookID != "" {
+		baseQuery = baseQuery.Where("webhook_deliveries.webhook_id = ?", filter.WebhookID)
+	}
+	if filter.Status != "" {
+		baseQuery = baseQuery.Where("webhook_deliveries.status = ?", filter.Status)
+	}
+	if !filter.From.IsZero() {
+		baseQuery = baseQuery.Where("webhook_deliveries.created_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		baseQuery = baseQuery.Where("webhook_deliveries.created_at <= ?", filter.To)
+	}
+
+	// Count total items for pagination
+	if err := baseQuery.Count(&totalItems).Error; err != nil {
+		logger.Error("Failed to count webhook deliveries", "error", err, "tenant_id", tenantID)
+		return utils.PaginatedResult[models.WebhookDelivery]{}, 
+			errors.NewInternalError("Failed to count webhook deliveries: " + err.Error())
+	}
+
+	// Get paginated results
+	if err := baseQuery.
+		Select("webhook_deliveries.*").
+		Offset(pagination.GetOffset()).
+		Limit(pagination.GetLimit()).
+		Order("webhook_deliveries.created_at DESC").
+		Find(&deliveries).Error; err != nil {
+		logger.Error("Failed to list webhook deliveries", "error", err, "tenant_id", tenantID)
+		return utils.PaginatedResult[models.WebhookDelivery]{}, 
+			errors.NewInternalError("Failed to list webhook deliveries: " + err.Error())
+	}
+
+	return utils.NewPaginatedResult(deliveries, pagination, totalItems), nil
+}
+
 // ListPendingDeliveries lists pending delivery records for processing
 func (r *webhookRepository) ListPendingDeliveries(ctx context.Context, limit int) ([]*models.WebhookDelivery, error) {
 	db, err := GetDB()
@@ -361,4 +418,285 @@ func (r *webhookRepository) ListFailedDeliveries(ctx context.Context, limit int,
 	}
 
 	return deliveries, nil
-}
\ No newline at end of file
+}
+
+// ListDueDeliveries claims failed delivery records whose next retry time has passed. The rows are
+// locked, skipping rows other workers are claiming, and their next retry time is pushed back by
+// webhookDeliveryClaimLease so that concurrent workers and replicas do not send them again.
+func (r *webhookRepository) ListDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*models.WebhookDelivery, error) {
+	db, err := GetDB()
+	if err != nil {
+		return nil, err
+	}
+
+	var deliveries []*models.WebhookDelivery
+
+	err = db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND (next_attempt_at IS NULL OR next_attempt_at <= ?)", models.WebhookDeliveryStatusFailed, now).
+			Order("next_attempt_at ASC").
+			Limit(limit).
+			Find(&deliveries).Error; err != nil {
+			return err
+		}
+		if len(deliveries) == 0 {
+			return nil
+		}
+
+		ids := make([]string, len(deliveries))
+		for i, delivery := range deliveries {
+			ids[i] = delivery.ID
+		}
+		leaseUntil := now.Add(webhookDeliveryClaimLease)
+		if err := tx.Model(&models.WebhookDelivery{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", leaseUntil).Error; err != nil {
+			return err
+		}
+		for _, delivery := range deliveries {
+			delivery.NextAttemptAt = leaseUntil
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Error("Failed to claim due webhook deliveries", "error", err)
+		return nil, errors.NewInternalError("Failed to claim due webhook deliveries: " + err.Error())
+	}
+
+	return deliveries, nil
+}
+
+// ListDeadLetteredDeliveries lists deliveries that exhausted their retries for a tenant with pagination
+func (r *webhookRepository) ListDeadLetteredDeliveries(ctx context.Context, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.WebhookDelivery], error) {
+	db, err := GetDB()
+	if err != nil {
+		return utils.PaginatedResult[models.WebhookDelivery]{}, err
+	}
+
+	var deliveries []models.WebhookDelivery
+	var totalItems int64
+
+	// Join with webhooks table to ensure tenant isolation
+	baseQuery := db.WithContext(ctx).
+		Table("webhook_deliveries").
+		Joins("JOIN webhooks ON webhook_deliveries.webhook_id = webhooks.id").
+		Where("webhook_deliveries.status = ? AND webhooks.tenant_id = ?", models.WebhookDeliveryStatusDeadLettered, tenantID)
+
+	if err := baseQuery.Count(&totalItems).Error; err != nil {
+		logger.Error("Failed to count dead-lettered webhook deliveries", 
+			"error", err, "tenant_id", tenantID)
+		return utils.PaginatedResult[models.WebhookDelivery]{}, 
+			errors.NewInternalError("Failed to count dead-lettered webhook deliveries: " + err.Error())
+	}
+
+	if err := baseQuery.
+		Select("webhook_deliveries.*").
+		Offset(pagination.GetOffset()).
+		Limit(pagination.GetLimit()).
+		Order("webhook_deliveries.completed_at DESC").
+		Find(&deliveries).Error; err != nil {
+		logger.Error("Failed to list dead-lettered webhook deliveries", 
+			"error", err, "tenant_id", tenantID)
+		return utils.PaginatedResult[models.WebhookDelivery]{}, 
+			errors.NewInternalError("Failed to list dead-lettered webhook deliveries: " + err.Error())
+	}
+
+	return utils.NewPaginatedResult(deliveries, pagination, totalItems), nil
+}
+
+// GetDeliveryWebhook retrieves the webhook a delivery belongs to for background processing
+func (r *webhookRepository) GetDeliveryWebhook(ctx context.Context, deliveryID string) (*models.Webhook, error) {
+	db, err := GetDB()
+	if err != nil {
+		return nil, err
+	}
+
+	var webhook models.Webhook
+	if err := db.WithContext(ctx).
+		Joins("JOIN webhook_deliveries ON webhook_deliveries.webhook_id = webhooks.id").
+		Where("webhook_deliveries.id = ?", deliveryID).
+		First(&webhook).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewResourceNotFoundError("Webhook not found")
+		}
+		logger.Error("Failed to get webhook for delivery", "error", err, "delivery_id", deliveryID)
+		return nil, errors.NewInternalError("Failed to get webhook for delivery: " + err.Error())
+	}
+
+	return &webhook, nil
+}
+
+// CreateDeliveryAttempt records the outcome of a single delivery attempt
+func (r *webhookRepository) CreateDeliveryAttempt(ctx context.Context, attempt *models.WebhookDeliveryAttempt) (string, error) {
+	if attempt.ID == "" {
+		attempt.ID = uuid.New().String()
+	}
+
+	if attempt.AttemptedAt.IsZero() {
+		attempt.AttemptedAt = time.Now()
+	}
+
+	db, err := GetDB()
+	if err != nil {
+		return "", err
+	}
+
+	if err := db.WithContext(ctx).Create(attempt).Error; err != nil {
+		logger.Error("Failed to create webhook delivery attempt", 
+			"error", err, "delivery_id", attempt.DeliveryID, "attempt", attempt.AttemptNumber)
+		return "", errors.NewInternalError("Failed to create webhook delivery attempt: " + err.Error())
+	}
+
+	return attempt.ID, nil
+}
+
+// ListDeliveryAttempts lists the attempt history for a delivery, oldest first
+func (r *webhookRepository) ListDeliveryAttempts(ctx context.Context, deliveryID string, tenantID string) ([]*models.WebhookDeliveryAttempt, error) {
+	db, err := GetDB()
+	if err != nil {
+		return nil, err
+	}
+
+	var attempts []*models.WebhookDeliveryAttempt
+
+	// Join through deliveries and webhooks to ensure tenant isolation
+	if err := db.WithContext(ctx).
+		Table("webhook_delivery_attempts").
+		Select("webhook_delivery_attempts.*").
+		Joins("JOIN webhook_deliveries ON webhook_delivery_attempts.delivery_id = webhook_deliveries.id").
+		Joins("JOIN webhooks ON webhook_deliveries.webhook_id = webhooks.id").
+		Where("webhook_delivery_attempts.delivery_id = ? AND webhooks.tenant_id = ?", deliveryID, tenantID).
+		Order("webhook_delivery_attempts.attempt_number ASC").
+		Find(&attempts).Error; err != nil {
+		logger.Error("Failed to list webhook delivery attempts", 
+			"error", err, "delivery_id", deliveryID, "tenant_id", tenantID)
+		return nil, errors.NewInternalError("Failed to list webhook delivery attempts: " + err.Error())
+	}
+
+	return attempts, nil
+}
+
+// CreateSubscription persists a new subscription filter for a webhook
+func (r *webhookRepository) CreateSubscription(ctx context.Context, subscription *models.WebhookSubscription) (string, error) {
+	if err := subscription.Validate(); err != nil {
+		return "", err
+	}
+
+	if subscription.ID == "" {
+		subscription.ID = uuid.New().String()
+	}
+
+	now := time.Now()
+	if subscription.CreatedAt.IsZero() {
+		subscription.CreatedAt = now
+	}
+	if subscription.UpdatedAt.IsZero() {
+		subscription.UpdatedAt = now
+	}
+
+	db, err := GetDB()
+	if err != nil {
+		return "", err
+	}
+
+	if err := db.WithContext(ctx).Create(subscription).Error; err != nil {
+		logger.Error("Failed to create webhook subscription", 
+			"error", err, "webhook_id", subscription.WebhookID, "tenant_id", subscription.TenantID)
+		return "", errors.NewInternalError("Failed to create webhook subscription: " + err.Error())
+	}
+
+	return subscription.ID, nil
+}
+
+// GetSubscription retrieves a subscription by its ID
+func (r *webhookRepository) GetSubscription(ctx context.Context, id string, tenantID string) (*models.WebhookSubscription, error) {
+	db, err := GetDB()
+	if err != nil {
+		return nil, err
+	}
+
+	var subscription models.WebhookSubscription
+	if err := db.WithContext(ctx).Where("id = ? AND tenant_id = ?", id, tenantID).First(&subscription).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewResourceNotFoundError("Webhook subscription not found")
+		}
+		logger.Error("Failed to get webhook subscription", "error", err, "id", id, "tenant_id", tenantID)
+		return nil, errors.NewInternalError("Failed to get webhook subscription: " + err.Error())
+	}
+
+	return &subscription, nil
+}
+
+// UpdateSubscription updates an existing subscription
+func (r *webhookRepository) UpdateSubscription(ctx context.Context, subscription *models.WebhookSubscription) error {
+	if err := subscription.Validate(); err != nil {
+		return err
+	}
+
+	subscription.UpdatedAt = time.Now()
+
+	db, err := GetDB()
+	if err != nil {
+		return err
+	}
+
+	// Select the filter columns explicitly so that cleared filters are persisted as empty values
+	result := db.WithContext(ctx).Model(&models.WebhookSubscription{}).
+		Where("id = ? AND tenant_id = ?", subscription.ID, subscription.TenantID).
+		Select("event_types", "folder_ids", "metadata_filters", "updated_at").
+		Updates(subscription)
+
+	if result.Error != nil {
+		logger.Error("Failed to update webhook subscription", 
+			"error", result.Error, "id", subscription.ID, "tenant_id", subscription.TenantID)
+		return errors.NewInternalError("Failed to update webhook subscription: " + result.Error.Error())
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.NewResourceNotFoundError("Webhook subscription not found")
+	}
+
+	return nil
+}
+
+// DeleteSubscription deletes a subscription
+func (r *webhookRepository) DeleteSubscription(ctx context.Context, id string, tenantID string) error {
+	db, err := GetDB()
+	if err != nil {
+		return err
+	}
+
+	result := db.WithContext(ctx).Where("id = ? AND tenant_id = ?", id, tenantID).Delete(&models.WebhookSubscription{})
+
+	if result.Error != nil {
+		logger.Error("Failed to delete webhook subscription", "error", result.Error, "id", id, "tenant_id", tenantID)
+		return errors.NewInternalError("Failed to delete webhook subscription: " + result.Error.Error())
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.NewResourceNotFoundError("Webhook subscription not found")
+	}
+
+	return nil
+}
+
+// ListSubscriptionsByWebhook lists all subscriptions of a webhook
+func (r *webhookRepository) ListSubscriptionsByWebhook(ctx context.Context, webhookID string, tenantID string) ([]*models.WebhookSubscription, error) {
+	db, err := GetDB()
+	if err != nil {
+		return nil, err
+	}
+
+	var subscriptions []*models.WebhookSubscription
+	if err := db.WithContext(ctx).
+		Where("webhook_id = ? AND tenant_id = ?", webhookID, tenantID).
+		Order("created_at ASC").
+		Find(&subscriptions).Error; err != nil {
+		logger.Error("Failed to list webhook subscriptions", 
+			"error", err, "webhook_id", webhookID, "tenant_id", tenantID)
+		return nil, errors.NewInternalError("Failed to list webhook subscriptions: " + err.Error())
+	}
+
+	return subscriptions, nil
+}
@@ -0,0 +1,46 @@
+// Package pdf provides a watermark renderer stamping text onto every page of PDF documents with
+// pdfcpu. The stamp is a text watermark on top of the page content, so it is visible in print too.
+package pdf
+
+import (
+	"context"
+	"io"
+	"mime"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api" // v0.4.0
+
+	"../../../domain/services"
+)
+
+// contentTypePDF is the content type of the documents the renderer stamps
+const contentTypePDF = "application/pdf"
+
+// watermarkDescription places the stamp in small grey type along the bottom of each page, where it
+// rarely covers content
+const watermarkDescription = "fontname:Helvetica, points:9, position:bc, offset:0 12, scalefactor:1 abs, rotation:0, opacity:0.6, fillcolor:#808080"
+
+// watermarkRenderer implements the WatermarkRenderer interface for PDFs
+type watermarkRenderer struct{}
+
+// NewWatermarkRenderer creates a WatermarkRenderer stamping PDF documents
+func NewWatermarkRenderer() services.WatermarkRenderer {
+	return &watermarkRenderer{}
+}
+
+// Supports checks if the content type is PDF, ignoring parameters and case
+func (r *watermarkRenderer) Supports(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(mediaType, contentTypePDF)
+}
+
+// Render stamps text onto every page of the PDF read from content
+func (r *watermarkRenderer) Render(ctx context.Context, content io.ReadSeeker, w io.Writer, text string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return api.AddTextWatermarks(content, w, nil, true, text, watermarkDescription, nil)
+}
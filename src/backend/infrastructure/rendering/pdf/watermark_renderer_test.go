@@ -0,0 +1,39 @@
+package pdf
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert" // v1.8.0+
+)
+
+func TestSupports_PDFOnly(t *testing.T) {
+	renderer := NewWatermarkRenderer()
+
+	assert.True(t, renderer.Supports("application/pdf"))
+	assert.True(t, renderer.Supports("Application/PDF; charset=binary"))
+	assert.False(t, renderer.Supports("application/vnd.openxmlformats-officedocument.wordprocessingml.document"))
+	assert.False(t, renderer.Supports("image/png"))
+	assert.False(t, renderer.Supports(""))
+}
+
+func TestRender_RejectsInvalidPDF(t *testing.T) {
+	var out bytes.Buffer
+
+	err := NewWatermarkRenderer().Render(context.Background(), strings.NewReader("not a pdf"), &out, "Downloaded by jane")
+
+	assert.Error(t, err)
+}
+
+func TestRender_CanceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var out bytes.Buffer
+
+	err := NewWatermarkRenderer().Render(ctx, strings.NewReader("%PDF-1.4"), &out, "Downloaded by jane")
+
+	assert.Equal(t, context.Canceled, err)
+	assert.Zero(t, out.Len())
+}
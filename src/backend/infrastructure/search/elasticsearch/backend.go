@@ -0,0 +1,176 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"../../../pkg/errors"
+)
+
+// SearchBackend is a search server speaking the Elasticsearch REST API. Elasticsearch 7,
+// Elasticsearch 8 and OpenSearch each need a client of their own, but accept the same index
+// mappings and query DSL, so the document index and query executor work with any of them.
+type SearchBackend interface {
+	// Ping checks the cluster answers and is not red
+	Ping(ctx context.Context) error
+
+	// Search executes a search query against an index. A routing other than "" only searches the
+	// shard documents indexed with that routing are stored in.
+	Search(ctx context.Context, index string, routing string, query map[string]interface{}, from, size int) (map[string]interface{}, error)
+
+	// Index indexes a document with a routing, "" for the default. refresh is the refresh parameter
+	// of the request: "true", "wait_for" or "false".
+	Index(ctx context.Context, index string, id string, routing string, refresh string, document interface{}) error
+
+	// Delete deletes a document indexed with a routing, refreshing like Index; deleting a missing
+	// document is not an error
+	Delete(ctx context.Context, index string, id string, routing string, refresh string) error
+
+	// DeleteByQuery deletes the documents with a routing that match a query
+	DeleteByQuery(ctx context.Context, index string, routing string, query map[string]interface{}) error
+
+	// Bulk sends a bulk request with a body of newline-delimited actions. A request the server
+	// rejects because it is overloaded returns a response with status 429 and no items.
+	Bulk(ctx context.Context, body io.Reader) (*BulkResponse, error)
+
+	// CreateIndex creates an index with the given settings and mappings unless it exists
+	CreateIndex(ctx context.Context, index string, settings map[string]interface{}, mappings map[string]interface{}) error
+
+	// IndexExists checks if an index exists
+	IndexExists(ctx context.Context, index string) (bool, error)
+
+	// DeleteIndex deletes an index; deleting a missing index is not an error
+	DeleteIndex(ctx context.Context, index string) error
+
+	// Refresh refreshes an index to make recent changes available for search
+	Refresh(ctx context.Context, index string) error
+
+	// AliasIndexes returns the indexes an alias points to, none if the alias does not exist
+	AliasIndexes(ctx context.Context, alias string) ([]string, error)
+
+	// SwapAlias points an alias at index and deletes removeIndexes in one atomic request, so
+	// searches of the alias never see a missing or partial index
+	SwapAlias(ctx context.Context, alias string, index string, removeIndexes []string) error
+
+	// BuildContentQuery builds a content search query
+	BuildContentQuery(query string) map[string]interface{}
+
+	// BuildMetadataQuery builds a metadata search query
+	BuildMetadataQuery(metadata map[string]string) map[string]interface{}
+
+	// BuildCombinedQuery builds a combined content and metadata search query
+	BuildCombinedQuery(contentQuery string, metadata map[string]string) map[string]interface{}
+
+	// BuildFolderQuery builds a folder-scoped search query
+	BuildFolderQuery(folderID string, query string) map[string]interface{}
+}
+
+// BulkResponse is the response of a bulk request
+type BulkResponse struct {
+	// StatusCode is the HTTP status of the request
+	StatusCode int
+
+	// Items are the results of the actions, in the order the actions were sent
+	Items []BulkItemResult
+}
+
+// BulkItemResult is the result of one action of a bulk request
+type BulkItemResult struct {
+	ID     string
+	Status int
+
+	// Error describes why the action failed, "" if it succeeded
+	Error string
+}
+
+// queryBuilder builds the queries of the query DSL shared by all backends. Backends embed it, and
+// override the queries their server handles differently.
+type queryBuilder struct{}
+
+// decodeErrorResponse returns the dependency error of a failed request, with the error response
+// of the server when it can be read
+func decodeErrorResponse(body io.Reader, action string) error {
+	var e map[string]interface{}
+	if err := json.NewDecoder(body).Decode(&e); err != nil {
+		return errors.NewDependencyError(fmt.Sprintf("Failed to parse error response: %s", err.Error()))
+	}
+	return errors.NewDependencyError(fmt.Sprintf("%s error: %v", action, e))
+}
+
+// routingValues returns the routing of requests taking a list of routing values, none for the
+// default routing
+func routingValues(routing string) []string {
+	if routing == "" {
+		return nil
+	}
+	return []string{routing}
+}
+
+// decodeAliasIndexes returns the sorted index names of a get alias response
+func decodeAliasIndexes(body io.Reader) ([]string, error) {
+	var result map[string]interface{}
+	if err := json.NewDecoder(body).Decode(&result); err != nil {
+		return nil, errors.NewDependencyError(fmt.Sprintf("Failed to parse alias response: %s", err.Error()))
+	}
+
+	indexes := make([]string, 0, len(result))
+	for index := range result {
+		indexes = append(indexes, index)
+	}
+	sort.Strings(indexes)
+	return indexes, nil
+}
+
+// encodeSwapAlias encodes the update aliases request of SwapAlias. Removing an index also removes
+// its aliases, including the alias being swapped.
+func encodeSwapAlias(alias string, index string, removeIndexes []string) (*bytes.Buffer, error) {
+	actions := make([]map[string]interface{}, 0, len(removeIndexes)+1)
+	for _, removeIndex := range removeIndexes {
+		actions = append(actions, map[string]interface{}{
+			"remove_index": map[string]interface{}{"index": removeIndex},
+		})
+	}
+	actions = append(actions, map[string]interface{}{
+		"add": map[string]interface{}{"index": index, "alias": alias},
+	})
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(map[string]interface{}{"actions": actions}); err != nil {
+		return nil, errors.NewValidationError(fmt.Sprintf("Failed to encode alias actions: %s", err.Error()))
+	}
+	return &buf, nil
+}
+
+// decodeBulkResponse decodes the items of a bulk response
+func decodeBulkResponse(statusCode int, body io.Reader) (*BulkResponse, error) {
+	var result struct {
+		Items []map[string]struct {
+			ID     string `json:"_id"`
+			Status int    `json:"status"`
+			Error  *struct {
+				Type   string `json:"type"`
+				Reason string `json:"reason"`
+			} `json:"error"`
+		} `json:"items"`
+	}
+	if err := json.NewDecoder(body).Decode(&result); err != nil {
+		return nil, errors.NewDependencyError(fmt.Sprintf("Failed to parse bulk response: %s", err.Error()))
+	}
+
+	response := &BulkResponse{StatusCode: statusCode, Items: make([]BulkItemResult, 0, len(result.Items))}
+	for _, item := range result.Items {
+		// Each item holds the result keyed by its action, such as "index"
+		for _, action := range item {
+			itemResult := BulkItemResult{ID: action.ID, Status: action.Status}
+			if action.Error != nil {
+				itemResult.Error = fmt.Sprintf("%s: %s", action.Error.Type, action.Error.Reason)
+			}
+			response.Items = append(response.Items, itemResult)
+		}
+	}
+	return response, nil
+}
@@ -0,0 +1,288 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"../../../pkg/config"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+)
+
+// Defaults for bulk indexing when config.ElasticsearchConfig.Bulk leaves them unset
+const (
+	defaultBulkFlushActions  = 500
+	defaultBulkFlushBytes    = 5 * 1024 * 1024
+	defaultBulkFlushInterval = 5 * time.Second
+	defaultBulkMaxRetries    = 5
+)
+
+// bulkRetryBackoff is the time waited before sending rejected documents again the first time; it
+// doubles with every further attempt
+const bulkRetryBackoff = 500 * time.Millisecond
+
+// BulkIndexerConfig holds the configuration of a BulkIndexer
+type BulkIndexerConfig struct {
+	FlushActions  int
+	FlushBytes    int
+	FlushInterval time.Duration
+	MaxRetries    int
+}
+
+// NewBulkIndexerConfig returns the bulk indexer configuration of bulkConfig, with defaults for the
+// values it leaves unset
+func NewBulkIndexerConfig(bulkConfig config.ElasticsearchBulkConfig) (BulkIndexerConfig, error) {
+	cfg := BulkIndexerConfig{
+		FlushActions:  bulkConfig.FlushActions,
+		FlushBytes:    bulkConfig.FlushBytes,
+		FlushInterval: defaultBulkFlushInterval,
+		MaxRetries:    bulkConfig.MaxRetries,
+	}
+	if cfg.FlushActions <= 0 {
+		cfg.FlushActions = defaultBulkFlushActions
+	}
+	if cfg.FlushBytes <= 0 {
+		cfg.FlushBytes = defaultBulkFlushBytes
+	}
+	if cfg.MaxRetries <= 0 {
+		cfg.MaxRetries = defaultBulkMaxRetries
+	}
+	if bulkConfig.FlushInterval != "" {
+		parsed, err := time.ParseDuration(bulkConfig.FlushInterval)
+		if err != nil || parsed <= 0 {
+			return cfg, errors.NewValidationError(fmt.Sprintf("invalid bulk flush interval: %s", bulkConfig.FlushInterval))
+		}
+		cfg.FlushInterval = parsed
+	}
+	return cfg, nil
+}
+
+// bulkAction is a buffered document with the action line that indexes or updates it
+type bulkAction struct {
+	id   string
+	body []byte
+	// ignoreMissing treats the action as done when the document is not indexed
+	ignoreMissing bool
+}
+
+// BulkIndexer buffers documents and indexes them with bulk requests, sent once enough documents
+// are buffered or the oldest has waited for the flush interval. Adding a document that fills the
+// buffer waits until the buffered documents are indexed, so callers cannot outpace the server.
+// Documents the server rejects because it is overloaded are sent again after a backoff.
+type BulkIndexer struct {
+	client SearchBackend
+	config BulkIndexerConfig
+	logger logger.Logger
+
+	// flushMu serializes bulk requests, so documents are indexed in the order they were added
+	flushMu sync.Mutex
+
+	mu      sync.Mutex
+	actions []bulkAction
+	size    int
+	// err is the error of a background flush, returned by the next call of Add or Flush
+	err error
+
+	stop    chan struct{}
+	stopped chan struct{}
+	once    sync.Once
+}
+
+// NewBulkIndexer creates a new BulkIndexer sending bulk requests to client. Close must be called
+// to index the remaining documents and stop the background flushes.
+func NewBulkIndexer(client SearchBackend, cfg BulkIndexerConfig) (*BulkIndexer, error) {
+	if client == nil {
+		return nil, errors.NewValidationError("Elasticsearch client cannot be nil")
+	}
+	if cfg.FlushActions <= 0 || cfg.FlushBytes <= 0 || cfg.FlushInterval <= 0 || cfg.MaxRetries < 0 {
+		return nil, errors.NewValidationError("invalid bulk indexer configuration")
+	}
+
+	b := &BulkIndexer{
+		client:  client,
+		config:  cfg,
+		logger:  logger.WithField("component", "elasticsearch_bulk_indexer"),
+		stop:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	go b.flushPeriodically()
+	return b, nil
+}
+
+// Add buffers a document to be indexed with a routing, "" for the default. If the buffer is full
+// the buffered documents are indexed before Add returns.
+func (b *BulkIndexer) Add(ctx context.Context, index string, id string, routing string, document interface{}) error {
+	return b.add(ctx, "index", index, id, routing, document, false)
+}
+
+// Update buffers a partial update of an indexed document, update being the body of an update
+// action such as a script. Documents that are not indexed are skipped rather than failing the flush.
+func (b *BulkIndexer) Update(ctx context.Context, index string, id string, routing string, update interface{}) error {
+	return b.add(ctx, "update", index, id, routing, update, true)
+}
+
+// add buffers an action on a document, flushing the buffer if it is full
+func (b *BulkIndexer) add(ctx context.Context, action string, index string, id string, routing string, document interface{}, ignoreMissing bool) error {
+	meta := map[string]interface{}{"_index": index, "_id": id}
+	if routing != "" {
+		meta["routing"] = routing
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	if err := encoder.Encode(map[string]interface{}{action: meta}); err != nil {
+		return errors.NewValidationError(fmt.Sprintf("Failed to encode bulk action: %s", err.Error()))
+	}
+	if err := encoder.Encode(document); err != nil {
+		return errors.NewValidationError(fmt.Sprintf("Failed to encode document: %s", err.Error()))
+	}
+
+	b.mu.Lock()
+	if err := b.err; err != nil {
+		b.mu.Unlock()
+		return err
+	}
+	b.actions = append(b.actions, bulkAction{id: id, body: buf.Bytes(), ignoreMissing: ignoreMissing})
+	b.size += buf.Len()
+	full := len(b.actions) >= b.config.FlushActions || b.size >= b.config.FlushBytes
+	b.mu.Unlock()
+
+	if full {
+		return b.flush(ctx)
+	}
+	return nil
+}
+
+// Flush indexes the buffered documents, returning the error of a failed background flush
+func (b *BulkIndexer) Flush(ctx context.Context) error {
+	if err := b.flush(ctx); err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	err := b.err
+	b.err = nil
+	return err
+}
+
+// Close stops the background flushes and indexes the buffered documents
+func (b *BulkIndexer) Close(ctx context.Context) error {
+	b.stopFlushing()
+	return b.Flush(ctx)
+}
+
+// discard stops the background flushes, dropping the buffered documents
+func (b *BulkIndexer) discard() {
+	b.stopFlushing()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.actions = nil
+	b.size = 0
+}
+
+// stopFlushing stops the background flushes and waits for a running one to finish
+func (b *BulkIndexer) stopFlushing() {
+	b.once.Do(func() {
+		close(b.stop)
+	})
+	<-b.stopped
+}
+
+// flushPeriodically indexes the buffered documents every flush interval until stopped
+func (b *BulkIndexer) flushPeriodically() {
+	defer close(b.stopped)
+
+	ticker := time.NewTicker(b.config.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := b.flush(context.Background()); err != nil {
+				b.logger.Error("Failed to flush bulk indexer", "error", err)
+				b.mu.Lock()
+				if b.err == nil {
+					b.err = err
+				}
+				b.mu.Unlock()
+			}
+		case <-b.stop:
+			return
+		}
+	}
+}
+
+// flush indexes the buffered documents with bulk requests. Documents rejected with 429 Too Many
+// Requests are sent again, waiting longer before every attempt; other rejected documents fail
+// the flush, except missing documents of updates.
+func (b *BulkIndexer) flush(ctx context.Context) error {
+	b.flushMu.Lock()
+	defer b.flushMu.Unlock()
+
+	b.mu.Lock()
+	pending := b.actions
+	b.actions = nil
+	b.size = 0
+	b.mu.Unlock()
+
+	var failures []string
+	for attempt := 0; len(pending) > 0; attempt++ {
+		if attempt > 0 {
+			if attempt > b.config.MaxRetries {
+				for _, action := range pending {
+					failures = append(failures, fmt.Sprintf("%s: rejected after %d retries", action.id, b.config.MaxRetries))
+				}
+				break
+			}
+
+			backoff := bulkRetryBackoff << (attempt - 1)
+			b.logger.InfoContext(ctx, "Retrying documents rejected by bulk request", "documents", len(pending), "attempt", attempt, "backoff", backoff)
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		var body bytes.Buffer
+		for _, action := range pending {
+			body.Write(action.body)
+		}
+
+		response, err := b.client.Bulk(ctx, &body)
+		if err != nil {
+			return err
+		}
+		if response.StatusCode == http.StatusTooManyRequests {
+			continue
+		}
+		if len(response.Items) != len(pending) {
+			return errors.NewDependencyError(fmt.Sprintf("Bulk response has %d results for %d documents", len(response.Items), len(pending)))
+		}
+
+		var retry []bulkAction
+		for i, item := range response.Items {
+			switch {
+			case item.Status == http.StatusTooManyRequests:
+				retry = append(retry, pending[i])
+			case item.Status == http.StatusNotFound && pending[i].ignoreMissing:
+				// The document is not indexed yet
+			case item.Error != "" || item.Status >= http.StatusMultipleChoices:
+				failures = append(failures, fmt.Sprintf("%s: %s", pending[i].id, item.Error))
+			}
+		}
+		pending = retry
+	}
+
+	if len(failures) > 0 {
+		return errors.NewDependencyError(fmt.Sprintf("Failed to index %d documents: %s", len(failures), failures[0]))
+	}
+	return nil
+}
@@ -0,0 +1,206 @@
+package elasticsearch
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"../../../pkg/config"
+)
+
+// bulkBackend is a SearchBackend recording the documents of bulk requests, answering them with
+// the responses of respond
+type bulkBackend struct {
+	SearchBackend
+
+	mu       sync.Mutex
+	requests [][]string
+	respond  func(ids []string) *BulkResponse
+}
+
+// Bulk records the IDs of the documents of the request
+func (b *bulkBackend) Bulk(ctx context.Context, body io.Reader) (*BulkResponse, error) {
+	var ids []string
+	scanner := bufio.NewScanner(body)
+	for scanner.Scan() {
+		var action map[string]map[string]interface{}
+		if err := json.Unmarshal(scanner.Bytes(), &action); err != nil {
+			return nil, err
+		}
+		for _, meta := range action {
+			ids = append(ids, meta["_id"].(string))
+		}
+		scanner.Scan() // Skip the document source
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.requests = append(b.requests, ids)
+	if b.respond != nil {
+		return b.respond(ids), nil
+	}
+	return indexedResponse(ids), nil
+}
+
+// sent returns the IDs of the documents of each request sent so far
+func (b *bulkBackend) sent() [][]string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([][]string(nil), b.requests...)
+}
+
+// indexedResponse returns a bulk response indexing all documents
+func indexedResponse(ids []string) *BulkResponse {
+	response := &BulkResponse{StatusCode: http.StatusOK}
+	for _, id := range ids {
+		response.Items = append(response.Items, BulkItemResult{ID: id, Status: http.StatusCreated})
+	}
+	return response
+}
+
+// newTestBulkIndexer creates a bulk indexer flushing every two documents
+func newTestBulkIndexer(t *testing.T, backend *bulkBackend) *BulkIndexer {
+	indexer, err := NewBulkIndexer(backend, BulkIndexerConfig{FlushActions: 2, FlushBytes: 1 << 20, FlushInterval: time.Hour, MaxRetries: 2})
+	require.NoError(t, err)
+	return indexer
+}
+
+// TestBulkIndexer_flushesFullBuffer tests that documents are sent once the buffer is full and on Close
+func TestBulkIndexer_flushesFullBuffer(t *testing.T) {
+	ctx := context.Background()
+	backend := &bulkBackend{}
+	indexer := newTestBulkIndexer(t, backend)
+
+	require.NoError(t, indexer.Add(ctx, "documents", "doc-1", "tenant-1", map[string]string{"name": "a"}))
+	assert.Empty(t, backend.sent())
+
+	require.NoError(t, indexer.Add(ctx, "documents", "doc-2", "tenant-1", map[string]string{"name": "b"}))
+	assert.Equal(t, [][]string{{"doc-1", "doc-2"}}, backend.sent())
+
+	require.NoError(t, indexer.Add(ctx, "documents", "doc-3", "tenant-1", map[string]string{"name": "c"}))
+	require.NoError(t, indexer.Close(ctx))
+	assert.Equal(t, [][]string{{"doc-1", "doc-2"}, {"doc-3"}}, backend.sent())
+}
+
+// TestBulkIndexer_flushInterval tests that buffered documents are sent after the flush interval
+func TestBulkIndexer_flushInterval(t *testing.T) {
+	backend := &bulkBackend{}
+	indexer, err := NewBulkIndexer(backend, BulkIndexerConfig{FlushActions: 100, FlushBytes: 1 << 20, FlushInterval: 10 * time.Millisecond})
+	require.NoError(t, err)
+	defer indexer.Close(context.Background())
+
+	require.NoError(t, indexer.Add(context.Background(), "documents", "doc-1", "", map[string]string{}))
+	assert.Eventually(t, func() bool { return len(backend.sent()) == 1 }, time.Second, 5*time.Millisecond)
+}
+
+// TestBulkIndexer_retriesRejectedDocuments tests that only the documents rejected with 429 are sent again
+func TestBulkIndexer_retriesRejectedDocuments(t *testing.T) {
+	backend := &bulkBackend{}
+	backend.respond = func(ids []string) *BulkResponse {
+		if len(backend.requests) == 1 {
+			// The whole request is rejected
+			return &BulkResponse{StatusCode: http.StatusTooManyRequests}
+		}
+		response := indexedResponse(ids)
+		if len(backend.requests) == 2 {
+			response.Items[1].Status = http.StatusTooManyRequests
+		}
+		return response
+	}
+	indexer := newTestBulkIndexer(t, backend)
+	defer indexer.Close(context.Background())
+
+	require.NoError(t, indexer.Add(context.Background(), "documents", "doc-1", "", map[string]string{}))
+	require.NoError(t, indexer.Add(context.Background(), "documents", "doc-2", "", map[string]string{}))
+	assert.Equal(t, [][]string{{"doc-1", "doc-2"}, {"doc-1", "doc-2"}, {"doc-2"}}, backend.sent())
+}
+
+// TestBulkIndexer_failures tests that documents rejected for other reasons, or too often, fail the flush
+func TestBulkIndexer_failures(t *testing.T) {
+	backend := &bulkBackend{}
+	backend.respond = func(ids []string) *BulkResponse {
+		response := indexedResponse(ids)
+		response.Items[0].Status = http.StatusBadRequest
+		response.Items[0].Error = "mapper_parsing_exception: failed to parse"
+		return response
+	}
+	indexer := newTestBulkIndexer(t, backend)
+	defer indexer.Close(context.Background())
+
+	require.NoError(t, indexer.Add(context.Background(), "documents", "doc-1", "", map[string]string{}))
+	err := indexer.Add(context.Background(), "documents", "doc-2", "", map[string]string{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "doc-1: mapper_parsing_exception")
+
+	backend.respond = func(ids []string) *BulkResponse {
+		return &BulkResponse{StatusCode: http.StatusTooManyRequests}
+	}
+	require.NoError(t, indexer.Add(context.Background(), "documents", "doc-3", "", map[string]string{}))
+	err = indexer.Flush(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "doc-3: rejected after 2 retries")
+}
+
+// TestBulkIndexer_updateSkipsMissingDocuments tests that updates of documents that are not indexed
+// do not fail the flush, unlike missing documents of other actions
+func TestBulkIndexer_updateSkipsMissingDocuments(t *testing.T) {
+	backend := &bulkBackend{}
+	backend.respond = func(ids []string) *BulkResponse {
+		response := indexedResponse(ids)
+		for i := range response.Items {
+			response.Items[i].Status = http.StatusNotFound
+			response.Items[i].Error = "document_missing_exception: document missing"
+		}
+		return response
+	}
+	indexer := newTestBulkIndexer(t, backend)
+	defer indexer.Close(context.Background())
+
+	require.NoError(t, indexer.Update(context.Background(), "documents", "doc-1", "", map[string]interface{}{"doc": map[string]string{}}))
+	require.NoError(t, indexer.Update(context.Background(), "documents", "doc-2", "", map[string]interface{}{"doc": map[string]string{}}))
+	assert.Equal(t, [][]string{{"doc-1", "doc-2"}}, backend.sent())
+
+	require.NoError(t, indexer.Add(context.Background(), "documents", "doc-3", "", map[string]string{}))
+	err := indexer.Flush(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "doc-3: document_missing_exception")
+}
+
+// TestNewBulkIndexerConfig tests the defaults and validation of the bulk configuration
+func TestNewBulkIndexerConfig(t *testing.T) {
+	cfg, err := NewBulkIndexerConfig(config.ElasticsearchBulkConfig{})
+	require.NoError(t, err)
+	assert.Equal(t, BulkIndexerConfig{FlushActions: defaultBulkFlushActions, FlushBytes: defaultBulkFlushBytes,
+		FlushInterval: defaultBulkFlushInterval, MaxRetries: defaultBulkMaxRetries}, cfg)
+
+	cfg, err = NewBulkIndexerConfig(config.ElasticsearchBulkConfig{FlushActions: 10, FlushInterval: "1s"})
+	require.NoError(t, err)
+	assert.Equal(t, 10, cfg.FlushActions)
+	assert.Equal(t, time.Second, cfg.FlushInterval)
+
+	_, err = NewBulkIndexerConfig(config.ElasticsearchBulkConfig{FlushInterval: "soon"})
+	assert.Error(t, err)
+}
+
+// TestDecodeBulkResponse tests decoding the results of the actions of a bulk response
+func TestDecodeBulkResponse(t *testing.T) {
+	body := `{"errors":true,"items":[
+		{"index":{"_id":"doc-1","status":201}},
+		{"index":{"_id":"doc-2","status":429,"error":{"type":"es_rejected_execution_exception","reason":"queue full"}}}]}`
+
+	response, err := decodeBulkResponse(http.StatusOK, strings.NewReader(body))
+	require.NoError(t, err)
+	assert.Equal(t, []BulkItemResult{
+		{ID: "doc-1", Status: 201},
+		{ID: "doc-2", Status: 429, Error: "es_rejected_execution_exception: queue full"},
+	}, response.Items)
+}
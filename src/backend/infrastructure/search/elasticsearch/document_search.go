@@ -26,15 +26,20 @@ func NewElasticsearchIndexer(documentIndex *DocumentIndex) (services.SearchIndex
 	}, nil
 }
 
-// NewElasticsearchQueryExecutor creates a new ElasticsearchQueryExecutor instance that implements the SearchQueryExecutor interface
-func NewElasticsearchQueryExecutor(client *ElasticsearchClient) (services.SearchQueryExecutor, error) {
+// NewElasticsearchQueryExecutor creates a new ElasticsearchQueryExecutor instance that implements the SearchQueryExecutor interface.
+// Queries are built by client and run against the index of the tenant in documentIndex.
+func NewElasticsearchQueryExecutor(client SearchBackend, documentIndex *DocumentIndex) (services.SearchQueryExecutor, error) {
 	if client == nil {
 		return nil, fmt.Errorf("client cannot be nil")
 	}
+	if documentIndex == nil {
+		return nil, fmt.Errorf("documentIndex cannot be nil")
+	}
 
 	return &elasticsearchQueryExecutor{
-		client: client,
-		logger: logger.WithField("component", "elasticsearch_query_executor"),
+		client:        client,
+		documentIndex: documentIndex,
+		logger:        logger.WithField("component", "elasticsearch_query_executor"),
 	}, nil
 }
 
@@ -44,6 +49,21 @@ type elasticsearchIndexer struct {
 	logger        logger.Logger
 }
 
+// Ensure elasticsearchIndexer can rebuild, prepare and remove tenant indexes and update metadata in bulk
+var (
+	_ services.SearchIndexRebuilder  = (*elasticsearchIndexer)(nil)
+	_ services.SearchIndexRebuild    = (*IndexRebuild)(nil)
+	_ services.SearchTenantRemover   = (*elasticsearchIndexer)(nil)
+	_ services.SearchTenantPreparer  = (*elasticsearchIndexer)(nil)
+	_ services.SearchMetadataUpdater = (*elasticsearchIndexer)(nil)
+	_ services.DependencyProbe       = (*elasticsearchIndexer)(nil)
+)
+
+// Ping checks the Elasticsearch cluster holding the index is reachable
+func (e *elasticsearchIndexer) Ping(ctx context.Context) error {
+	return e.documentIndex.Ping(ctx)
+}
+
 // IndexDocument indexes a document for search in Elasticsearch
 func (e *elasticsearchIndexer) IndexDocument(ctx context.Context, document *models.Document, content []byte) error {
 	e.logger.InfoContext(ctx, "Indexing document", 
@@ -108,10 +128,88 @@ func (e *elasticsearchIndexer) RemoveDocument(ctx context.Context, documentID st
 	return nil
 }
 
+// RemoveTenant removes all documents of a tenant from Elasticsearch
+func (e *elasticsearchIndexer) RemoveTenant(ctx context.Context, tenantID string) error {
+	e.logger.InfoContext(ctx, "Removing tenant from index",
+		"tenantID", tenantID)
+
+	if tenantID == "" {
+		return errors.NewValidationError("tenant ID cannot be empty")
+	}
+
+	if err := e.documentIndex.RemoveTenant(ctx, tenantID); err != nil {
+		e.logger.ErrorContext(ctx, "Failed to remove tenant from index",
+			"error", err,
+			"tenantID", tenantID)
+		return errors.NewDependencyError(fmt.Sprintf("failed to remove tenant from index: %v", err))
+	}
+
+	return nil
+}
+
+// PrepareTenant creates the index of a tenant in Elasticsearch
+func (e *elasticsearchIndexer) PrepareTenant(ctx context.Context, tenantID string) (*services.SearchTenantRouting, error) {
+	e.logger.InfoContext(ctx, "Preparing tenant index",
+		"tenantID", tenantID)
+
+	if tenantID == "" {
+		return nil, errors.NewValidationError("tenant ID cannot be empty")
+	}
+
+	routing, err := e.documentIndex.PrepareTenant(ctx, tenantID)
+	if err != nil {
+		e.logger.ErrorContext(ctx, "Failed to prepare tenant index",
+			"error", err,
+			"tenantID", tenantID)
+		return nil, errors.NewDependencyError(fmt.Sprintf("failed to prepare tenant index: %v", err))
+	}
+
+	return routing, nil
+}
+
+// UpdateMetadata replaces the metadata of indexed documents of a tenant in Elasticsearch
+func (e *elasticsearchIndexer) UpdateMetadata(ctx context.Context, tenantID string, documents []*models.Document) error {
+	e.logger.InfoContext(ctx, "Updating document metadata in index",
+		"documents", len(documents),
+		"tenantID", tenantID)
+
+	if tenantID == "" {
+		return errors.NewValidationError("tenant ID cannot be empty")
+	}
+
+	if err := e.documentIndex.UpdateMetadata(ctx, tenantID, documents); err != nil {
+		e.logger.ErrorContext(ctx, "Failed to update document metadata in index",
+			"error", err,
+			"tenantID", tenantID)
+		return errors.NewDependencyError(fmt.Sprintf("failed to update document metadata in index: %v", err))
+	}
+
+	return nil
+}
+
+// BeginRebuild starts rebuilding the index of a tenant, into a fresh index which replaces the
+// tenant index on commit unless the index is shared by all tenants
+func (e *elasticsearchIndexer) BeginRebuild(ctx context.Context, tenantID string, rebuildID string) (services.SearchIndexRebuild, error) {
+	e.logger.InfoContext(ctx, "Beginning index rebuild",
+		"tenantID", tenantID,
+		"rebuildID", rebuildID)
+
+	rebuild, err := e.documentIndex.BeginRebuild(ctx, tenantID, rebuildID)
+	if err != nil {
+		e.logger.ErrorContext(ctx, "Failed to begin index rebuild",
+			"error", err,
+			"tenantID", tenantID)
+		return nil, errors.NewDependencyError(fmt.Sprintf("failed to begin index rebuild: %v", err))
+	}
+
+	return rebuild, nil
+}
+
 // elasticsearchQueryExecutor implements the SearchQueryExecutor interface using Elasticsearch
 type elasticsearchQueryExecutor struct {
-	client *ElasticsearchClient
-	logger logger.Logger
+	client        SearchBackend
+	documentIndex *DocumentIndex
+	logger        logger.Logger
 }
 
 // ExecuteContentSearch executes a content-based search query in Elasticsearch
@@ -128,9 +226,6 @@ func (e *elasticsearchQueryExecutor) ExecuteContentSearch(ctx context.Context, q
 		return nil, 0, errors.NewValidationError("tenant ID cannot be empty")
 	}
 
-	// Get tenant-specific index name
-	indexName := fmt.Sprintf("documents-%s", tenantID)
-
 	// Build content search query
 	searchQuery := e.client.BuildContentQuery(query)
 
@@ -144,8 +239,14 @@ func (e *elasticsearchQueryExecutor) ExecuteContentSearch(ctx context.Context, q
 		pagination = utils.NewPagination(utils.DefaultPage, utils.DefaultPageSize)
 	}
 
+	// Sort the query, continuing after the previous page when the pagination has a cursor
+	searchQuery, from, err := pageQuery(searchQuery, pagination)
+	if err != nil {
+		return nil, 0, errors.NewValidationError(err.Error())
+	}
+
 	// Execute search against Elasticsearch
-	searchResults, err := e.client.Search(ctx, indexName, searchQuery, from, size)
+	searchResults, err := e.documentIndex.Search(ctx, tenantID, searchQuery, from, size)
 	if err != nil {
 		e.logger.ErrorContext(ctx, "Failed to execute content search",
 			"error", err,
@@ -160,6 +261,7 @@ func (e *elasticsearchQueryExecutor) ExecuteContentSearch(ctx context.Context, q
 		e.logger.ErrorContext(ctx, "Failed to extract document IDs from search results", "error", err)
 		return nil, 0, err
 	}
+	nextPageCursor(searchResults, pagination, size)
 
 	e.logger.InfoContext(ctx, "Content search executed successfully",
 		"query", query,
@@ -171,24 +273,22 @@ func (e *elasticsearchQueryExecutor) ExecuteContentSearch(ctx context.Context, q
 }
 
 // ExecuteMetadataSearch executes a metadata-based search query in Elasticsearch
-func (e *elasticsearchQueryExecutor) ExecuteMetadataSearch(ctx context.Context, metadata map[string]string, tenantID string, pagination *utils.Pagination) ([]string, int64, error) {
+func (e *elasticsearchQueryExecutor) ExecuteMetadataSearch(ctx context.Context, metadata map[string]string, filters []models.MetadataFilter, tenantID string, pagination *utils.Pagination) ([]string, int64, error) {
 	e.logger.InfoContext(ctx, "Executing metadata search",
 		"metadata", metadata,
+		"filters", filters,
 		"tenantID", tenantID)
 
 	// Validate metadata and tenant ID
-	if metadata == nil || len(metadata) == 0 {
+	if len(metadata) == 0 && len(filters) == 0 {
 		return nil, 0, errors.NewValidationError("metadata search criteria cannot be empty")
 	}
 	if tenantID == "" {
 		return nil, 0, errors.NewValidationError("tenant ID cannot be empty")
 	}
 
-	// Get tenant-specific index name
-	indexName := fmt.Sprintf("documents-%s", tenantID)
-
-	// Build metadata search query
-	searchQuery := e.client.BuildMetadataQuery(metadata)
+	// Build metadata search query, restricted by the metadata filters
+	searchQuery := metadataFilterQuery(e.client.BuildMetadataQuery(metadata), filters)
 
 	// Apply pagination parameters
 	from := 0
@@ -200,8 +300,14 @@ func (e *elasticsearchQueryExecutor) ExecuteMetadataSearch(ctx context.Context,
 		pagination = utils.NewPagination(utils.DefaultPage, utils.DefaultPageSize)
 	}
 
+	// Sort the query, continuing after the previous page when the pagination has a cursor
+	searchQuery, from, err := pageQuery(searchQuery, pagination)
+	if err != nil {
+		return nil, 0, errors.NewValidationError(err.Error())
+	}
+
 	// Execute search against Elasticsearch
-	searchResults, err := e.client.Search(ctx, indexName, searchQuery, from, size)
+	searchResults, err := e.documentIndex.Search(ctx, tenantID, searchQuery, from, size)
 	if err != nil {
 		e.logger.ErrorContext(ctx, "Failed to execute metadata search",
 			"error", err,
@@ -216,6 +322,7 @@ func (e *elasticsearchQueryExecutor) ExecuteMetadataSearch(ctx context.Context,
 		e.logger.ErrorContext(ctx, "Failed to extract document IDs from search results", "error", err)
 		return nil, 0, err
 	}
+	nextPageCursor(searchResults, pagination, size)
 
 	e.logger.InfoContext(ctx, "Metadata search executed successfully",
 		"metadata", metadata,
@@ -227,15 +334,16 @@ func (e *elasticsearchQueryExecutor) ExecuteMetadataSearch(ctx context.Context,
 }
 
 // ExecuteCombinedSearch executes a combined content and metadata search query in Elasticsearch
-func (e *elasticsearchQueryExecutor) ExecuteCombinedSearch(ctx context.Context, contentQuery string, metadata map[string]string, tenantID string, pagination *utils.Pagination) ([]string, int64, error) {
+func (e *elasticsearchQueryExecutor) ExecuteCombinedSearch(ctx context.Context, contentQuery string, metadata map[string]string, filters []models.MetadataFilter, tenantID string, pagination *utils.Pagination) ([]string, int64, error) {
 	e.logger.InfoContext(ctx, "Executing combined search",
 		"contentQuery", contentQuery,
 		"metadata", metadata,
+		"filters", filters,
 		"tenantID", tenantID)
 
-	// Validate that at least one of contentQuery or metadata is provided
+	// Validate that at least one of contentQuery, metadata or filters is provided
 	contentQueryEmpty := strings.TrimSpace(contentQuery) == ""
-	metadataEmpty := metadata == nil || len(metadata) == 0
+	metadataEmpty := len(metadata) == 0 && len(filters) == 0
 	
 	if contentQueryEmpty && metadataEmpty {
 		return nil, 0, errors.NewValidationError("at least one search criteria (content or metadata) must be provided")
@@ -246,11 +354,8 @@ func (e *elasticsearchQueryExecutor) ExecuteCombinedSearch(ctx context.Context,
 		return nil, 0, errors.NewValidationError("tenant ID cannot be empty")
 	}
 
-	// Get tenant-specific index name
-	indexName := fmt.Sprintf("documents-%s", tenantID)
-
-	// Build combined search query
-	searchQuery := e.client.BuildCombinedQuery(contentQuery, metadata)
+	// Build combined search query, restricted by the metadata filters
+	searchQuery := metadataFilterQuery(e.client.BuildCombinedQuery(contentQuery, metadata), filters)
 
 	// Apply pagination parameters
 	from := 0
@@ -262,8 +367,14 @@ func (e *elasticsearchQueryExecutor) ExecuteCombinedSearch(ctx context.Context,
 		pagination = utils.NewPagination(utils.DefaultPage, utils.DefaultPageSize)
 	}
 
+	// Sort the query, continuing after the previous page when the pagination has a cursor
+	searchQuery, from, err := pageQuery(searchQuery, pagination)
+	if err != nil {
+		return nil, 0, errors.NewValidationError(err.Error())
+	}
+
 	// Execute search against Elasticsearch
-	searchResults, err := e.client.Search(ctx, indexName, searchQuery, from, size)
+	searchResults, err := e.documentIndex.Search(ctx, tenantID, searchQuery, from, size)
 	if err != nil {
 		e.logger.ErrorContext(ctx, "Failed to execute combined search",
 			"error", err,
@@ -279,6 +390,7 @@ func (e *elasticsearchQueryExecutor) ExecuteCombinedSearch(ctx context.Context,
 		e.logger.ErrorContext(ctx, "Failed to extract document IDs from search results", "error", err)
 		return nil, 0, err
 	}
+	nextPageCursor(searchResults, pagination, size)
 
 	e.logger.InfoContext(ctx, "Combined search executed successfully",
 		"contentQuery", contentQuery,
@@ -308,9 +420,6 @@ func (e *elasticsearchQueryExecutor) ExecuteFolderSearch(ctx context.Context, fo
 		return nil, 0, errors.NewValidationError("tenant ID cannot be empty")
 	}
 
-	// Get tenant-specific index name
-	indexName := fmt.Sprintf("documents-%s", tenantID)
-
 	// Build folder-scoped search query
 	searchQuery := e.client.BuildFolderQuery(folderID, query)
 
@@ -324,8 +433,14 @@ func (e *elasticsearchQueryExecutor) ExecuteFolderSearch(ctx context.Context, fo
 		pagination = utils.NewPagination(utils.DefaultPage, utils.DefaultPageSize)
 	}
 
+	// Sort the query, continuing after the previous page when the pagination has a cursor
+	searchQuery, from, err := pageQuery(searchQuery, pagination)
+	if err != nil {
+		return nil, 0, errors.NewValidationError(err.Error())
+	}
+
 	// Execute search against Elasticsearch
-	searchResults, err := e.client.Search(ctx, indexName, searchQuery, from, size)
+	searchResults, err := e.documentIndex.Search(ctx, tenantID, searchQuery, from, size)
 	if err != nil {
 		e.logger.ErrorContext(ctx, "Failed to execute folder search",
 			"error", err,
@@ -341,6 +456,7 @@ func (e *elasticsearchQueryExecutor) ExecuteFolderSearch(ctx context.Context, fo
 		e.logger.ErrorContext(ctx, "Failed to extract document IDs from search results", "error", err)
 		return nil, 0, err
 	}
+	nextPageCursor(searchResults, pagination, size)
 
 	e.logger.InfoContext(ctx, "Folder search executed successfully",
 		"folderID", folderID,
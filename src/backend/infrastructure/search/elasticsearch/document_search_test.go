@@ -269,7 +269,7 @@ func TestElasticsearchQueryExecutor_ExecuteMetadataSearch(t *testing.T) {
 	require.NoError(t, err)
 	
 	// Call ExecuteMetadataSearch on the executor with test metadata and tenant ID
-	docIDs, total, err := executor.ExecuteMetadataSearch(context.Background(), metadata, testTenantID, utils.NewPagination(1, 20))
+	docIDs, total, err := executor.ExecuteMetadataSearch(context.Background(), metadata, nil, testTenantID, utils.NewPagination(1, 20))
 	
 	// Assert that the returned document IDs match expected values
 	assert.Equal(t, expectedDocIDs, docIDs)
@@ -282,13 +282,13 @@ func TestElasticsearchQueryExecutor_ExecuteMetadataSearch(t *testing.T) {
 	mockClient.AssertExpectations(t)
 	
 	// Test error cases: empty metadata
-	docIDs, total, err = executor.ExecuteMetadataSearch(context.Background(), nil, testTenantID, utils.NewPagination(1, 20))
+	docIDs, total, err = executor.ExecuteMetadataSearch(context.Background(), nil, nil, testTenantID, utils.NewPagination(1, 20))
 	assert.Error(t, err)
 	assert.Empty(t, docIDs)
 	assert.Zero(t, total)
 	
 	// Test error cases: empty tenant ID
-	docIDs, total, err = executor.ExecuteMetadataSearch(context.Background(), metadata, "", utils.NewPagination(1, 20))
+	docIDs, total, err = executor.ExecuteMetadataSearch(context.Background(), metadata, nil, "", utils.NewPagination(1, 20))
 	assert.Error(t, err)
 	assert.Empty(t, docIDs)
 	assert.Zero(t, total)
@@ -299,7 +299,7 @@ func TestElasticsearchQueryExecutor_ExecuteMetadataSearch(t *testing.T) {
 	mockErrorClient.On("Search", mock.Anything, testTenantID+"-documents", mock.Anything, 0, 20).Return(map[string]interface{}{}, assert.AnError)
 	errorExecutor, _ := NewElasticsearchQueryExecutor(mockErrorClient)
 	
-	docIDs, total, err = errorExecutor.ExecuteMetadataSearch(context.Background(), metadata, testTenantID, utils.NewPagination(1, 20))
+	docIDs, total, err = errorExecutor.ExecuteMetadataSearch(context.Background(), metadata, nil, testTenantID, utils.NewPagination(1, 20))
 	assert.Error(t, err)
 	assert.Empty(t, docIDs)
 	assert.Zero(t, total)
@@ -325,7 +325,7 @@ func TestElasticsearchQueryExecutor_ExecuteCombinedSearch(t *testing.T) {
 	require.NoError(t, err)
 	
 	// Call ExecuteCombinedSearch on the executor with test query, metadata, and tenant ID
-	docIDs, total, err := executor.ExecuteCombinedSearch(context.Background(), query, metadata, testTenantID, utils.NewPagination(1, 20))
+	docIDs, total, err := executor.ExecuteCombinedSearch(context.Background(), query, metadata, nil, testTenantID, utils.NewPagination(1, 20))
 	
 	// Assert that the returned document IDs match expected values
 	assert.Equal(t, expectedDocIDs, docIDs)
@@ -338,13 +338,13 @@ func TestElasticsearchQueryExecutor_ExecuteCombinedSearch(t *testing.T) {
 	mockClient.AssertExpectations(t)
 	
 	// Test error cases: empty query and metadata
-	docIDs, total, err = executor.ExecuteCombinedSearch(context.Background(), "", nil, testTenantID, utils.NewPagination(1, 20))
+	docIDs, total, err = executor.ExecuteCombinedSearch(context.Background(), "", nil, nil, testTenantID, utils.NewPagination(1, 20))
 	assert.Error(t, err)
 	assert.Empty(t, docIDs)
 	assert.Zero(t, total)
 	
 	// Test error cases: empty tenant ID
-	docIDs, total, err = executor.ExecuteCombinedSearch(context.Background(), query, metadata, "", utils.NewPagination(1, 20))
+	docIDs, total, err = executor.ExecuteCombinedSearch(context.Background(), query, metadata, nil, "", utils.NewPagination(1, 20))
 	assert.Error(t, err)
 	assert.Empty(t, docIDs)
 	assert.Zero(t, total)
@@ -355,7 +355,7 @@ func TestElasticsearchQueryExecutor_ExecuteCombinedSearch(t *testing.T) {
 	mockErrorClient.On("Search", mock.Anything, testTenantID+"-documents", mock.Anything, 0, 20).Return(map[string]interface{}{}, assert.AnError)
 	errorExecutor, _ := NewElasticsearchQueryExecutor(mockErrorClient)
 	
-	docIDs, total, err = errorExecutor.ExecuteCombinedSearch(context.Background(), query, metadata, testTenantID, utils.NewPagination(1, 20))
+	docIDs, total, err = errorExecutor.ExecuteCombinedSearch(context.Background(), query, metadata, nil, testTenantID, utils.NewPagination(1, 20))
 	assert.Error(t, err)
 	assert.Empty(t, docIDs)
 	assert.Zero(t, total)
@@ -0,0 +1,361 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	elasticsearch7 "github.com/elastic/go-elasticsearch/v7" // v7.17.0+
+
+	"../../../pkg/config"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+	"../../../pkg/tracing"
+)
+
+// Elasticsearch7Client represents a client for interacting with Elasticsearch 7, which rejects the
+// requests of the Elasticsearch 8 client
+type Elasticsearch7Client struct {
+	queryBuilder
+	client *elasticsearch7.Client
+	logger logger.Logger
+}
+
+// Ensure Elasticsearch7Client implements SearchBackend
+var _ SearchBackend = (*Elasticsearch7Client)(nil)
+
+// NewElasticsearch7Client creates a new Elasticsearch7Client instance with the provided configuration
+func NewElasticsearch7Client(esConfig config.ElasticsearchConfig) (*Elasticsearch7Client, error) {
+	if len(esConfig.Addresses) == 0 {
+		return nil, errors.NewValidationError("Elasticsearch addresses cannot be empty")
+	}
+
+	client, err := elasticsearch7.NewClient(elasticsearch7.Config{
+		Addresses: esConfig.Addresses,
+		Username:  esConfig.Username,
+		Password:  esConfig.Password,
+		Transport: tracing.NewTransport(&http.Transport{
+			MaxIdleConnsPerHost:   10,
+			ResponseHeaderTimeout: 10 * time.Second,
+			TLSClientConfig: &tls.Config{
+				MinVersion: tls.VersionTLS12,
+			},
+		}, "Elasticsearch"),
+	})
+	if err != nil {
+		return nil, errors.NewDependencyError(fmt.Sprintf("Failed to create Elasticsearch 7 client: %s", err.Error()))
+	}
+
+	// Verify connection to Elasticsearch
+	resp, err := client.Info()
+	if err != nil {
+		return nil, errors.NewDependencyError(fmt.Sprintf("Failed to connect to Elasticsearch 7: %s", err.Error()))
+	}
+	defer resp.Body.Close()
+
+	if resp.IsError() {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, errors.NewDependencyError(fmt.Sprintf("Elasticsearch 7 info request failed: %s", string(bodyBytes)))
+	}
+
+	logger.Info("Connected to Elasticsearch 7", "addresses", esConfig.Addresses)
+
+	return &Elasticsearch7Client{
+		client: client,
+		logger: logger.WithField("component", "elasticsearch7_client"),
+	}, nil
+}
+
+// Ping checks the Elasticsearch 7 cluster answers and is not red
+func (c *Elasticsearch7Client) Ping(ctx context.Context) error {
+	res, err := c.client.Cluster.Health(c.client.Cluster.Health.WithContext(ctx))
+	if err != nil {
+		return errors.NewDependencyError(fmt.Sprintf("Elasticsearch 7 cluster health request failed: %s", err.Error()))
+	}
+	defer res.Body.Close()
+
+	return checkClusterHealth("Elasticsearch 7", res.IsError(), res.Status(), res.Body)
+}
+
+// Search executes a search query against Elasticsearch 7
+func (c *Elasticsearch7Client) Search(ctx context.Context, index string, routing string, query map[string]interface{}, from, size int) (map[string]interface{}, error) {
+	c.logger.InfoContext(ctx, "Executing Elasticsearch search", "index", index, "from", from, "size", size)
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(query); err != nil {
+		return nil, errors.NewValidationError(fmt.Sprintf("Failed to encode search query: %s", err.Error()))
+	}
+
+	res, err := c.client.Search(
+		c.client.Search.WithContext(ctx),
+		c.client.Search.WithIndex(index),
+		c.client.Search.WithBody(&buf),
+		c.client.Search.WithFrom(from),
+		c.client.Search.WithSize(size),
+		c.client.Search.WithRouting(routingValues(routing)...),
+	)
+	if err != nil {
+		return nil, errors.NewDependencyError(fmt.Sprintf("Elasticsearch search request failed: %s", err.Error()))
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, decodeErrorResponse(res.Body, "Elasticsearch search")
+	}
+
+	var result map[string]interface{}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, errors.NewDependencyError(fmt.Sprintf("Failed to parse search response: %s", err.Error()))
+	}
+
+	return result, nil
+}
+
+// Index indexes a document in Elasticsearch 7
+func (c *Elasticsearch7Client) Index(ctx context.Context, index string, id string, routing string, refresh string, document interface{}) error {
+	c.logger.InfoContext(ctx, "Indexing document in Elasticsearch", "index", index, "id", id)
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(document); err != nil {
+		return errors.NewValidationError(fmt.Sprintf("Failed to encode document: %s", err.Error()))
+	}
+
+	res, err := c.client.Index(
+		index,
+		&buf,
+		c.client.Index.WithContext(ctx),
+		c.client.Index.WithDocumentID(id),
+		c.client.Index.WithRouting(routing),
+		c.client.Index.WithRefresh(refresh),
+	)
+	if err != nil {
+		return errors.NewDependencyError(fmt.Sprintf("Elasticsearch index request failed: %s", err.Error()))
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return decodeErrorResponse(res.Body, "Elasticsearch index")
+	}
+
+	return nil
+}
+
+// Delete deletes a document from Elasticsearch 7
+func (c *Elasticsearch7Client) Delete(ctx context.Context, index string, id string, routing string, refresh string) error {
+	c.logger.InfoContext(ctx, "Deleting document from Elasticsearch", "index", index, "id", id)
+
+	res, err := c.client.Delete(
+		index,
+		id,
+		c.client.Delete.WithContext(ctx),
+		c.client.Delete.WithRouting(routing),
+		c.client.Delete.WithRefresh(refresh),
+	)
+	if err != nil {
+		return errors.NewDependencyError(fmt.Sprintf("Elasticsearch delete request failed: %s", err.Error()))
+	}
+	defer res.Body.Close()
+
+	// 404 is acceptable as it means the document doesn't exist
+	if res.IsError() && res.StatusCode != http.StatusNotFound {
+		return decodeErrorResponse(res.Body, "Elasticsearch delete")
+	}
+
+	return nil
+}
+
+// DeleteByQuery deletes the documents matching a query from an Elasticsearch index
+func (c *Elasticsearch7Client) DeleteByQuery(ctx context.Context, index string, routing string, query map[string]interface{}) error {
+	c.logger.InfoContext(ctx, "Deleting documents by query from Elasticsearch", "index", index, "routing", routing)
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(query); err != nil {
+		return errors.NewValidationError(fmt.Sprintf("Failed to encode delete query: %s", err.Error()))
+	}
+
+	// Documents changed while they are deleted are deleted anyway rather than failing the request
+	res, err := c.client.DeleteByQuery(
+		[]string{index},
+		&buf,
+		c.client.DeleteByQuery.WithContext(ctx),
+		c.client.DeleteByQuery.WithRouting(routingValues(routing)...),
+		c.client.DeleteByQuery.WithConflicts("proceed"),
+		c.client.DeleteByQuery.WithRefresh(true),
+	)
+	if err != nil {
+		return errors.NewDependencyError(fmt.Sprintf("Elasticsearch delete by query request failed: %s", err.Error()))
+	}
+	defer res.Body.Close()
+
+	// 404 is acceptable as it means the index doesn't exist
+	if res.IsError() && res.StatusCode != http.StatusNotFound {
+		return decodeErrorResponse(res.Body, "Elasticsearch delete by query")
+	}
+
+	return nil
+}
+
+// Bulk sends a bulk request to Elasticsearch 7
+func (c *Elasticsearch7Client) Bulk(ctx context.Context, body io.Reader) (*BulkResponse, error) {
+	res, err := c.client.Bulk(
+		body,
+		c.client.Bulk.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, errors.NewDependencyError(fmt.Sprintf("Elasticsearch bulk request failed: %s", err.Error()))
+	}
+	defer res.Body.Close()
+
+	// 429 means the server is overloaded and the request can be sent again later
+	if res.StatusCode == http.StatusTooManyRequests {
+		return &BulkResponse{StatusCode: res.StatusCode}, nil
+	}
+	if res.IsError() {
+		return nil, decodeErrorResponse(res.Body, "Elasticsearch bulk")
+	}
+
+	return decodeBulkResponse(res.StatusCode, res.Body)
+}
+
+// CreateIndex creates an Elasticsearch 7 index with the specified settings and mappings
+func (c *Elasticsearch7Client) CreateIndex(ctx context.Context, index string, settings map[string]interface{}, mappings map[string]interface{}) error {
+	c.logger.InfoContext(ctx, "Creating Elasticsearch index", "index", index)
+
+	exists, err := c.IndexExists(ctx, index)
+	if err != nil {
+		return err
+	}
+	if exists {
+		c.logger.InfoContext(ctx, "Index already exists", "index", index)
+		return nil
+	}
+
+	var buf bytes.Buffer
+	body := map[string]interface{}{
+		"settings": settings,
+		"mappings": mappings,
+	}
+	if err := json.NewEncoder(&buf).Encode(body); err != nil {
+		return errors.NewValidationError(fmt.Sprintf("Failed to encode index body: %s", err.Error()))
+	}
+
+	res, err := c.client.Indices.Create(
+		index,
+		c.client.Indices.Create.WithContext(ctx),
+		c.client.Indices.Create.WithBody(&buf),
+	)
+	if err != nil {
+		return errors.NewDependencyError(fmt.Sprintf("Elasticsearch create index request failed: %s", err.Error()))
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return decodeErrorResponse(res.Body, "Elasticsearch create index")
+	}
+
+	return nil
+}
+
+// IndexExists checks if an Elasticsearch 7 index exists
+func (c *Elasticsearch7Client) IndexExists(ctx context.Context, index string) (bool, error) {
+	res, err := c.client.Indices.Exists(
+		[]string{index},
+		c.client.Indices.Exists.WithContext(ctx),
+	)
+	if err != nil {
+		return false, errors.NewDependencyError(fmt.Sprintf("Elasticsearch index exists request failed: %s", err.Error()))
+	}
+	defer res.Body.Close()
+
+	return res.StatusCode == http.StatusOK, nil
+}
+
+// DeleteIndex deletes an Elasticsearch 7 index
+func (c *Elasticsearch7Client) DeleteIndex(ctx context.Context, index string) error {
+	c.logger.InfoContext(ctx, "Deleting Elasticsearch index", "index", index)
+
+	res, err := c.client.Indices.Delete(
+		[]string{index},
+		c.client.Indices.Delete.WithContext(ctx),
+	)
+	if err != nil {
+		return errors.NewDependencyError(fmt.Sprintf("Elasticsearch delete index request failed: %s", err.Error()))
+	}
+	defer res.Body.Close()
+
+	if res.IsError() && res.StatusCode != http.StatusNotFound {
+		return decodeErrorResponse(res.Body, "Elasticsearch delete index")
+	}
+
+	return nil
+}
+
+// Refresh refreshes an Elasticsearch 7 index to make recent changes available for search
+func (c *Elasticsearch7Client) Refresh(ctx context.Context, index string) error {
+	res, err := c.client.Indices.Refresh(
+		c.client.Indices.Refresh.WithContext(ctx),
+		c.client.Indices.Refresh.WithIndex(index),
+	)
+	if err != nil {
+		return errors.NewDependencyError(fmt.Sprintf("Elasticsearch refresh request failed: %s", err.Error()))
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return decodeErrorResponse(res.Body, "Elasticsearch refresh")
+	}
+
+	return nil
+}
+
+// AliasIndexes returns the indexes an Elasticsearch alias points to
+func (c *Elasticsearch7Client) AliasIndexes(ctx context.Context, alias string) ([]string, error) {
+	res, err := c.client.Indices.GetAlias(
+		c.client.Indices.GetAlias.WithContext(ctx),
+		c.client.Indices.GetAlias.WithName(alias),
+	)
+	if err != nil {
+		return nil, errors.NewDependencyError(fmt.Sprintf("Elasticsearch get alias request failed: %s", err.Error()))
+	}
+	defer res.Body.Close()
+
+	// 404 means the alias doesn't exist
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if res.IsError() {
+		return nil, decodeErrorResponse(res.Body, "Elasticsearch get alias")
+	}
+
+	return decodeAliasIndexes(res.Body)
+}
+
+// SwapAlias atomically points an Elasticsearch alias at index, deleting removeIndexes
+func (c *Elasticsearch7Client) SwapAlias(ctx context.Context, alias string, index string, removeIndexes []string) error {
+	c.logger.InfoContext(ctx, "Swapping Elasticsearch alias", "alias", alias, "index", index, "removed_indexes", removeIndexes)
+
+	body, err := encodeSwapAlias(alias, index, removeIndexes)
+	if err != nil {
+		return err
+	}
+
+	res, err := c.client.Indices.UpdateAliases(
+		body,
+		c.client.Indices.UpdateAliases.WithContext(ctx),
+	)
+	if err != nil {
+		return errors.NewDependencyError(fmt.Sprintf("Elasticsearch update aliases request failed: %s", err.Error()))
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return decodeErrorResponse(res.Body, "Elasticsearch update aliases")
+	}
+
+	return nil
+}
@@ -1,5 +1,6 @@
 // Package elasticsearch provides Elasticsearch client implementation for the Document Management Platform.
 // It enables searching, indexing, and managing documents in Elasticsearch with tenant isolation.
+// Elasticsearch 8, Elasticsearch 7 and OpenSearch are supported through the SearchBackend drivers.
 package elasticsearch
 
 import (
@@ -20,7 +21,10 @@ import (
 	"../../../pkg/config"
 	"../../../pkg/errors"
 	"../../../pkg/logger"
+	"../../../pkg/tracing"
+	"../../../pkg/utils"
 	"../../../domain/models"
+	"../../../domain/services"
 )
 
 // Default index settings for Elasticsearch
@@ -38,8 +42,37 @@ var defaultIndexSettings = map[string]interface{}{
 	},
 }
 
+// Objects of the indexed source holding the values of the metadata fields defined in the tenant's
+// schema, typed for range queries and sorting. Each type has an object of its own, so tenants
+// sharing an index may define fields of the same name with different types.
+const (
+	metadataNumberObject  = "metadata_number"
+	metadataDateObject    = "metadata_date"
+	metadataKeywordObject = "metadata_keyword"
+)
+
 // Default index mappings for Elasticsearch
 var defaultIndexMappings = map[string]interface{}{
+	"dynamic_templates": []interface{}{
+		map[string]interface{}{
+			metadataNumberObject: map[string]interface{}{
+				"path_match": metadataNumberObject + ".*",
+				"mapping":    map[string]interface{}{"type": "double"},
+			},
+		},
+		map[string]interface{}{
+			metadataDateObject: map[string]interface{}{
+				"path_match": metadataDateObject + ".*",
+				"mapping":    map[string]interface{}{"type": "date"},
+			},
+		},
+		map[string]interface{}{
+			metadataKeywordObject: map[string]interface{}{
+				"path_match": metadataKeywordObject + ".*",
+				"mapping":    map[string]interface{}{"type": "keyword", "ignore_above": 256},
+			},
+		},
+	},
 	"properties": map[string]interface{}{
 		"document_id": map[string]interface{}{
 			"type": "keyword",
@@ -80,6 +113,9 @@ var defaultIndexMappings = map[string]interface{}{
 		"updated_at": map[string]interface{}{
 			"type": "date",
 		},
+		"indexed_at": map[string]interface{}{
+			"type": "date",
+		},
 		"metadata": map[string]interface{}{
 			"type": "nested",
 			"properties": map[string]interface{}{
@@ -110,12 +146,16 @@ var defaultBulkIndexerConfig = esutil.BulkIndexerConfig{
 	Timeout:       30 * time.Second,
 }
 
-// ElasticsearchClient represents a client for interacting with Elasticsearch
+// ElasticsearchClient represents a client for interacting with Elasticsearch 8
 type ElasticsearchClient struct {
+	queryBuilder
 	client *elasticsearch.Client
 	logger logger.Logger
 }
 
+// Ensure ElasticsearchClient implements SearchBackend
+var _ SearchBackend = (*ElasticsearchClient)(nil)
+
 // NewElasticsearchClient creates a new ElasticsearchClient instance with the provided configuration
 func NewElasticsearchClient(esConfig config.ElasticsearchConfig) (*ElasticsearchClient, error) {
 	if len(esConfig.Addresses) == 0 {
@@ -127,13 +167,13 @@ func NewElasticsearchClient(esConfig config.ElasticsearchConfig) (*Elasticsearch
 		Addresses: esConfig.Addresses,
 		Username:  esConfig.Username,
 		Password:  esConfig.Password,
-		Transport: &http.Transport{
+		Transport: tracing.NewTransport(&http.Transport{
 			MaxIdleConnsPerHost:   10,
 			ResponseHeaderTimeout: 10 * time.Second,
 			TLSClientConfig: &tls.Config{
 				MinVersion: tls.VersionTLS12,
 			},
-		},
+		}, "Elasticsearch"),
 	}
 
 	// Initialize Elasticsearch client
@@ -162,8 +202,40 @@ func NewElasticsearchClient(esConfig config.ElasticsearchConfig) (*Elasticsearch
 	}, nil
 }
 
+// Ping checks the Elasticsearch cluster answers and is not red, that is, that no primary shard is
+// unassigned and every index can be searched and written
+func (c *ElasticsearchClient) Ping(ctx context.Context) error {
+	res, err := c.client.Cluster.Health(c.client.Cluster.Health.WithContext(ctx))
+	if err != nil {
+		return errors.NewDependencyError(fmt.Sprintf("Elasticsearch cluster health request failed: %s", err.Error()))
+	}
+	defer res.Body.Close()
+
+	return checkClusterHealth("Elasticsearch", res.IsError(), res.Status(), res.Body)
+}
+
+// checkClusterHealth checks the cluster health response of an Elasticsearch compatible backend,
+// failing when the request failed or the cluster is red
+func checkClusterHealth(backend string, isError bool, status string, body io.Reader) error {
+	if isError {
+		return errors.NewDependencyError(fmt.Sprintf("%s cluster health request failed: %s", backend, status))
+	}
+
+	var health struct {
+		Status string `json:"status"`
+	}
+	if err := json.NewDecoder(body).Decode(&health); err != nil {
+		return errors.NewDependencyError(fmt.Sprintf("Failed to parse cluster health response: %s", err.Error()))
+	}
+	if health.Status == "red" {
+		return errors.NewDependencyError(fmt.Sprintf("%s cluster status is red", backend))
+	}
+
+	return nil
+}
+
 // Search executes a search query against Elasticsearch
-func (c *ElasticsearchClient) Search(ctx context.Context, index string, query map[string]interface{}, from, size int) (map[string]interface{}, error) {
+func (c *ElasticsearchClient) Search(ctx context.Context, index string, routing string, query map[string]interface{}, from, size int) (map[string]interface{}, error) {
 	c.logger.InfoContext(ctx, "Executing Elasticsearch search", "index", index, "from", from, "size", size)
 
 	// Marshal query to JSON
@@ -179,6 +251,7 @@ func (c *ElasticsearchClient) Search(ctx context.Context, index string, query ma
 		c.client.Search.WithBody(&buf),
 		c.client.Search.WithFrom(from),
 		c.client.Search.WithSize(size),
+		c.client.Search.WithRouting(routingValues(routing)...),
 	)
 	if err != nil {
 		return nil, errors.NewDependencyError(fmt.Sprintf("Elasticsearch search request failed: %s", err.Error()))
@@ -204,7 +277,7 @@ func (c *ElasticsearchClient) Search(ctx context.Context, index string, query ma
 }
 
 // Index indexes a document in Elasticsearch
-func (c *ElasticsearchClient) Index(ctx context.Context, index string, id string, document interface{}) error {
+func (c *ElasticsearchClient) Index(ctx context.Context, index string, id string, routing string, refresh string, document interface{}) error {
 	c.logger.InfoContext(ctx, "Indexing document in Elasticsearch", "index", index, "id", id)
 
 	// Marshal document to JSON
@@ -219,7 +292,8 @@ func (c *ElasticsearchClient) Index(ctx context.Context, index string, id string
 		&buf,
 		c.client.Index.WithContext(ctx),
 		c.client.Index.WithDocumentID(id),
-		c.client.Index.WithRefresh("true"),
+		c.client.Index.WithRouting(routing),
+		c.client.Index.WithRefresh(refresh),
 	)
 	if err != nil {
 		return errors.NewDependencyError(fmt.Sprintf("Elasticsearch index request failed: %s", err.Error()))
@@ -239,7 +313,7 @@ func (c *ElasticsearchClient) Index(ctx context.Context, index string, id string
 }
 
 // Delete deletes a document from Elasticsearch
-func (c *ElasticsearchClient) Delete(ctx context.Context, index string, id string) error {
+func (c *ElasticsearchClient) Delete(ctx context.Context, index string, id string, routing string, refresh string) error {
 	c.logger.InfoContext(ctx, "Deleting document from Elasticsearch", "index", index, "id", id)
 
 	// Execute delete request
@@ -247,7 +321,8 @@ func (c *ElasticsearchClient) Delete(ctx context.Context, index string, id strin
 		index,
 		id,
 		c.client.Delete.WithContext(ctx),
-		c.client.Delete.WithRefresh("true"),
+		c.client.Delete.WithRouting(routing),
+		c.client.Delete.WithRefresh(refresh),
 	)
 	if err != nil {
 		return errors.NewDependencyError(fmt.Sprintf("Elasticsearch delete request failed: %s", err.Error()))
@@ -266,6 +341,59 @@ func (c *ElasticsearchClient) Delete(ctx context.Context, index string, id strin
 	return nil
 }
 
+// DeleteByQuery deletes the documents matching a query from an Elasticsearch index
+func (c *ElasticsearchClient) DeleteByQuery(ctx context.Context, index string, routing string, query map[string]interface{}) error {
+	c.logger.InfoContext(ctx, "Deleting documents by query from Elasticsearch", "index", index, "routing", routing)
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(query); err != nil {
+		return errors.NewValidationError(fmt.Sprintf("Failed to encode delete query: %s", err.Error()))
+	}
+
+	// Documents changed while they are deleted are deleted anyway rather than failing the request
+	res, err := c.client.DeleteByQuery(
+		[]string{index},
+		&buf,
+		c.client.DeleteByQuery.WithContext(ctx),
+		c.client.DeleteByQuery.WithRouting(routingValues(routing)...),
+		c.client.DeleteByQuery.WithConflicts("proceed"),
+		c.client.DeleteByQuery.WithRefresh(true),
+	)
+	if err != nil {
+		return errors.NewDependencyError(fmt.Sprintf("Elasticsearch delete by query request failed: %s", err.Error()))
+	}
+	defer res.Body.Close()
+
+	// 404 is acceptable as it means the index doesn't exist
+	if res.IsError() && res.StatusCode != http.StatusNotFound {
+		return decodeErrorResponse(res.Body, "Elasticsearch delete by query")
+	}
+
+	return nil
+}
+
+// Bulk sends a bulk request to Elasticsearch
+func (c *ElasticsearchClient) Bulk(ctx context.Context, body io.Reader) (*BulkResponse, error) {
+	res, err := c.client.Bulk(
+		body,
+		c.client.Bulk.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, errors.NewDependencyError(fmt.Sprintf("Elasticsearch bulk request failed: %s", err.Error()))
+	}
+	defer res.Body.Close()
+
+	// 429 means the server is overloaded and the request can be sent again later
+	if res.StatusCode == http.StatusTooManyRequests {
+		return &BulkResponse{StatusCode: res.StatusCode}, nil
+	}
+	if res.IsError() {
+		return nil, decodeErrorResponse(res.Body, "Elasticsearch bulk")
+	}
+
+	return decodeBulkResponse(res.StatusCode, res.Body)
+}
+
 // CreateIndex creates an Elasticsearch index with the specified settings and mappings
 func (c *ElasticsearchClient) CreateIndex(ctx context.Context, index string, settings map[string]interface{}, mappings map[string]interface{}) error {
 	c.logger.InfoContext(ctx, "Creating Elasticsearch index", "index", index)
@@ -381,8 +509,55 @@ func (c *ElasticsearchClient) Refresh(ctx context.Context, index string) error {
 	return nil
 }
 
+// AliasIndexes returns the indexes an Elasticsearch alias points to
+func (c *ElasticsearchClient) AliasIndexes(ctx context.Context, alias string) ([]string, error) {
+	res, err := c.client.Indices.GetAlias(
+		c.client.Indices.GetAlias.WithContext(ctx),
+		c.client.Indices.GetAlias.WithName(alias),
+	)
+	if err != nil {
+		return nil, errors.NewDependencyError(fmt.Sprintf("Elasticsearch get alias request failed: %s", err.Error()))
+	}
+	defer res.Body.Close()
+
+	// 404 means the alias doesn't exist
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if res.IsError() {
+		return nil, decodeErrorResponse(res.Body, "Elasticsearch get alias")
+	}
+
+	return decodeAliasIndexes(res.Body)
+}
+
+// SwapAlias atomically points an Elasticsearch alias at index, deleting removeIndexes
+func (c *ElasticsearchClient) SwapAlias(ctx context.Context, alias string, index string, removeIndexes []string) error {
+	c.logger.InfoContext(ctx, "Swapping Elasticsearch alias", "alias", alias, "index", index, "removed_indexes", removeIndexes)
+
+	body, err := encodeSwapAlias(alias, index, removeIndexes)
+	if err != nil {
+		return err
+	}
+
+	res, err := c.client.Indices.UpdateAliases(
+		body,
+		c.client.Indices.UpdateAliases.WithContext(ctx),
+	)
+	if err != nil {
+		return errors.NewDependencyError(fmt.Sprintf("Elasticsearch update aliases request failed: %s", err.Error()))
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return decodeErrorResponse(res.Body, "Elasticsearch update aliases")
+	}
+
+	return nil
+}
+
 // BuildContentQuery builds a content search query for Elasticsearch
-func (c *ElasticsearchClient) BuildContentQuery(query string) map[string]interface{} {
+func (queryBuilder) BuildContentQuery(query string) map[string]interface{} {
 	return map[string]interface{}{
 		"query": map[string]interface{}{
 			"match": map[string]interface{}{
@@ -393,7 +568,7 @@ func (c *ElasticsearchClient) BuildContentQuery(query string) map[string]interfa
 }
 
 // BuildMetadataQuery builds a metadata search query for Elasticsearch
-func (c *ElasticsearchClient) BuildMetadataQuery(metadata map[string]string) map[string]interface{} {
+func (queryBuilder) BuildMetadataQuery(metadata map[string]string) map[string]interface{} {
 	must := make([]map[string]interface{}, 0, len(metadata))
 
 	for key, value := range metadata {
@@ -430,7 +605,7 @@ func (c *ElasticsearchClient) BuildMetadataQuery(metadata map[string]string) map
 }
 
 // BuildCombinedQuery builds a combined content and metadata search query for Elasticsearch
-func (c *ElasticsearchClient) BuildCombinedQuery(contentQuery string, metadata map[string]string) map[string]interface{} {
+func (queryBuilder) BuildCombinedQuery(contentQuery string, metadata map[string]string) map[string]interface{} {
 	query := map[string]interface{}{
 		"query": map[string]interface{}{
 			"bool": map[string]interface{}{},
@@ -486,7 +661,7 @@ func (c *ElasticsearchClient) BuildCombinedQuery(contentQuery string, metadata m
 }
 
 // BuildFolderQuery builds a folder-scoped search query for Elasticsearch
-func (c *ElasticsearchClient) BuildFolderQuery(folderID string, query string) map[string]interface{} {
+func (queryBuilder) BuildFolderQuery(folderID string, query string) map[string]interface{} {
 	return map[string]interface{}{
 		"query": map[string]interface{}{
 			"bool": map[string]interface{}{
@@ -533,15 +708,33 @@ func (c *ElasticsearchClient) CreateBulkIndexer(config esutil.BulkIndexerConfig)
 	return bulkIndexer, nil
 }
 
+// Index strategies selectable with config.ElasticsearchConfig.IndexStrategy
+const (
+	// IndexStrategyPerTenant gives each tenant an index of its own, so large tenants do not slow
+	// down the others and deleting a tenant deletes its index
+	IndexStrategyPerTenant = "per_tenant"
+
+	// IndexStrategyShared stores all tenants in one index, routing the documents of each tenant to
+	// a single shard, so many small tenants do not each cost an index. Tenants configured as
+	// dedicated still get an index of their own.
+	IndexStrategyShared = "shared"
+)
+
 // DocumentIndex manages document indices in Elasticsearch with tenant isolation
 type DocumentIndex struct {
-	client      *ElasticsearchClient
+	client      SearchBackend
 	indexPrefix string
+	strategy    string
+	ilmPolicy   string
+	dedicated   map[string]bool
+	bulk        BulkIndexerConfig
+	refresh     services.IndexRefresh
+	schemas     services.MetadataSchemaService
 	logger      logger.Logger
 }
 
 // NewDocumentIndex creates a new DocumentIndex instance with the provided client and configuration
-func NewDocumentIndex(client *ElasticsearchClient, esConfig config.ElasticsearchConfig) (*DocumentIndex, error) {
+func NewDocumentIndex(client SearchBackend, esConfig config.ElasticsearchConfig) (*DocumentIndex, error) {
 	if client == nil {
 		return nil, errors.NewValidationError("Elasticsearch client cannot be nil")
 	}
@@ -551,19 +744,124 @@ func NewDocumentIndex(client *ElasticsearchClient, esConfig config.Elasticsearch
 		indexPrefix = "documents"
 	}
 
+	strategy := esConfig.IndexStrategy
+	switch strategy {
+	case "":
+		strategy = IndexStrategyPerTenant
+	case IndexStrategyPerTenant, IndexStrategyShared:
+	default:
+		return nil, errors.NewValidationError(fmt.Sprintf("unsupported index strategy: %s", strategy))
+	}
+
+	bulk, err := NewBulkIndexerConfig(esConfig.Bulk)
+	if err != nil {
+		return nil, err
+	}
+
+	refresh := services.IndexRefreshWaitFor
+	if esConfig.Refresh != "" {
+		if refresh, err = services.ParseIndexRefresh(esConfig.Refresh); err != nil {
+			return nil, err
+		}
+	}
+
+	dedicated := make(map[string]bool, len(esConfig.DedicatedTenants))
+	for _, tenantID := range esConfig.DedicatedTenants {
+		dedicated[tenantID] = true
+	}
+
 	return &DocumentIndex{
 		client:      client,
 		indexPrefix: indexPrefix,
+		strategy:    strategy,
+		ilmPolicy:   esConfig.ILMPolicy,
+		dedicated:   dedicated,
+		bulk:        bulk,
+		refresh:     refresh,
 		logger:      logger.WithField("component", "elasticsearch_document_index"),
 	}, nil
 }
 
-// GetTenantIndex gets the Elasticsearch index name for a tenant
+// Ping checks the search backend holding the index is reachable
+func (di *DocumentIndex) Ping(ctx context.Context) error {
+	return di.client.Ping(ctx)
+}
+
+// SetMetadataSchemas sets the service providing the metadata schemas of tenants; the metadata
+// fields they define are indexed with their type. Without it, metadata is only indexed as text.
+func (di *DocumentIndex) SetMetadataSchemas(schemas services.MetadataSchemaService) {
+	di.schemas = schemas
+}
+
+// metadataSchema returns the metadata schema of a tenant, or none if it cannot be loaded so that
+// documents are still indexed, without typed metadata
+func (di *DocumentIndex) metadataSchema(ctx context.Context, tenantID string) models.MetadataSchema {
+	if di.schemas == nil {
+		return nil
+	}
+
+	schema, err := di.schemas.GetSchema(ctx, tenantID)
+	if err != nil {
+		di.logger.ErrorContext(ctx, "Failed to load metadata schema, indexing metadata as text", "error", err.Error(), "tenant_id", tenantID)
+		return nil
+	}
+	return schema
+}
+
+// sharesIndex returns whether the documents of a tenant are stored in the shared index
+func (di *DocumentIndex) sharesIndex(tenantID string) bool {
+	return di.strategy == IndexStrategyShared && !di.dedicated[tenantID]
+}
+
+// GetTenantIndex gets the Elasticsearch index name the documents of a tenant are stored in
 func (di *DocumentIndex) GetTenantIndex(tenantID string) string {
+	if di.sharesIndex(tenantID) {
+		return di.indexPrefix
+	}
 	return fmt.Sprintf("%s-%s", di.indexPrefix, tenantID)
 }
 
-// EnsureTenantIndex ensures that a tenant-specific index exists, creating it if necessary
+// tenantRouting returns the routing the documents of a tenant are indexed with
+func (di *DocumentIndex) tenantRouting(tenantID string) string {
+	if di.sharesIndex(tenantID) {
+		return tenantID
+	}
+	return ""
+}
+
+// refreshParam returns the refresh parameter of the requests changing documents for ctx, which
+// may select a refresh other than the configured one
+func (di *DocumentIndex) refreshParam(ctx context.Context) string {
+	refresh, ok := services.IndexRefreshFromContext(ctx)
+	if !ok {
+		refresh = di.refresh
+	}
+
+	switch refresh {
+	case services.IndexRefreshImmediate:
+		return "true"
+	case services.IndexRefreshWaitFor:
+		return "wait_for"
+	default:
+		return "false"
+	}
+}
+
+// indexSettings returns the settings of new indices, with the lifecycle policy if one is configured
+func (di *DocumentIndex) indexSettings() map[string]interface{} {
+	if di.ilmPolicy == "" {
+		return defaultIndexSettings
+	}
+
+	settings := make(map[string]interface{}, len(defaultIndexSettings)+1)
+	for key, value := range defaultIndexSettings {
+		settings[key] = value
+	}
+	settings["index.lifecycle.name"] = di.ilmPolicy
+	return settings
+}
+
+// EnsureTenantIndex ensures that the index of a tenant exists, creating it if necessary
 func (di *DocumentIndex) EnsureTenantIndex(ctx context.Context, tenantID string) (string, error) {
 	indexName := di.GetTenantIndex(tenantID)
 
@@ -575,24 +873,38 @@ func (di *DocumentIndex) EnsureTenantIndex(ctx context.Context, tenantID string)
 
 	if !exists {
 		// Create index with default settings and mappings
-		err = di.client.CreateIndex(ctx, indexName, defaultIndexSettings, defaultIndexMappings)
+		err = di.client.CreateIndex(ctx, indexName, di.indexSettings(), defaultIndexMappings)
 		if err != nil {
 			return "", err
 		}
-		di.logger.InfoContext(ctx, "Created tenant index", "index", indexName, "tenant_id", tenantID)
+		di.logger.InfoContext(ctx, "Created tenant index", "index", indexName, "tenant_id", tenantID, "shared", di.sharesIndex(tenantID))
 	}
 
 	return indexName, nil
 }
 
-// IndexDocument indexes a document in the tenant-specific index
-func (di *DocumentIndex) IndexDocument(ctx context.Context, document *models.Document, content []byte) error {
-	di.logger.InfoContext(ctx, "Indexing document", "document_id", document.ID, "tenant_id", document.TenantID)
+// PrepareTenant ensures that the index of a tenant exists and returns where its documents are
+// indexed, so that a new tenant does not wait for its index on its first upload
+func (di *DocumentIndex) PrepareTenant(ctx context.Context, tenantID string) (*services.SearchTenantRouting, error) {
+	if tenantID == "" {
+		return nil, errors.NewValidationError("Tenant ID cannot be empty")
+	}
+
+	indexName, err := di.EnsureTenantIndex(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return &services.SearchTenantRouting{Index: indexName, Routing: di.tenantRouting(tenantID)}, nil
+}
 
+// IndexDocument indexes a document in the index of its tenant
+func (di *DocumentIndex) IndexDocument(ctx context.Context, document *models.Document, content []byte) error {
 	if document == nil {
 		return errors.NewValidationError("Document cannot be nil")
 	}
 
+	di.logger.InfoContext(ctx, "Indexing document", "document_id", document.ID, "tenant_id", document.TenantID)
+
 	if content == nil || len(content) == 0 {
 		return errors.NewValidationError("Document content cannot be empty")
 	}
@@ -603,6 +915,20 @@ func (di *DocumentIndex) IndexDocument(ctx context.Context, document *models.Doc
 		return err
 	}
 
+	// The request returns once the document is searchable if the refresh asks for it
+	source := di.documentSource(ctx, document, content, di.metadataSchema(ctx, document.TenantID))
+	err = di.client.Index(ctx, indexName, document.ID, di.tenantRouting(document.TenantID), di.refreshParam(ctx), source)
+	if err != nil {
+		return err
+	}
+
+	di.logger.InfoContext(ctx, "Document indexed successfully", "document_id", document.ID, "index", indexName)
+	return nil
+}
+
+// documentSource returns the indexed source of a document with its extracted text and the typed
+// values of the metadata fields defined in schema
+func (di *DocumentIndex) documentSource(ctx context.Context, document *models.Document, content []byte, schema models.MetadataSchema) map[string]interface{} {
 	// Extract text from document content
 	textContent, err := di.extractText(content, document.ContentType)
 	if err != nil {
@@ -624,18 +950,16 @@ func (di *DocumentIndex) IndexDocument(ctx context.Context, document *models.Doc
 		"owner_id":     document.OwnerID,
 		"created_at":   document.CreatedAt,
 		"updated_at":   document.UpdatedAt,
+		"indexed_at":   time.Now(),
 	}
 
 	// Add metadata if available
 	if len(document.Metadata) > 0 {
-		metadata := make([]map[string]string, len(document.Metadata))
-		for i, m := range document.Metadata {
-			metadata[i] = map[string]string{
-				"key":   m.Key,
-				"value": m.Value,
-			}
+		docMapping["metadata"] = metadataSource(document.Metadata)
+
+		for object, values := range typedMetadata(schema, document.Metadata) {
+			docMapping[object] = values
 		}
-		docMapping["metadata"] = metadata
 	}
 
 	// Add tags if available
@@ -647,23 +971,97 @@ func (di *DocumentIndex) IndexDocument(ctx context.Context, document *models.Doc
 		docMapping["tags"] = tags
 	}
 
-	// Index document
-	err = di.client.Index(ctx, indexName, document.ID, docMapping)
-	if err != nil {
-		return err
+	return docMapping
+}
+
+// metadataSource returns the indexed key-value pairs of metadata
+func metadataSource(metadata []models.DocumentMetadata) []map[string]string {
+	source := make([]map[string]string, len(metadata))
+	for i, m := range metadata {
+		source[i] = map[string]string{
+			"key":   m.Key,
+			"value": m.Value,
+		}
+	}
+	return source
+}
+
+// metadataUpdateScript sets the fields of an indexed document to params.fields, removing the fields
+// whose value is null. Unlike a partial document, it replaces the typed metadata objects rather
+// than merging them, so removed keys are no longer found.
+const metadataUpdateScript = "for (entry in params.fields.entrySet()) { " +
+	"if (entry.getValue() == null) { ctx._source.remove(entry.getKey()) } " +
+	"else { ctx._source[entry.getKey()] = entry.getValue() } }"
+
+// metadataUpdate returns the update action body replacing the indexed metadata of a document
+func metadataUpdate(document *models.Document, schema models.MetadataSchema) map[string]interface{} {
+	fields := map[string]interface{}{
+		"metadata":            nil,
+		metadataNumberObject:  nil,
+		metadataDateObject:    nil,
+		metadataKeywordObject: nil,
+		"updated_at":          document.UpdatedAt,
+	}
+	if len(document.Metadata) > 0 {
+		fields["metadata"] = metadataSource(document.Metadata)
+		for object, values := range typedMetadata(schema, document.Metadata) {
+			fields[object] = values
+		}
 	}
 
-	// Refresh index to make document searchable immediately
-	err = di.client.Refresh(ctx, indexName)
+	return map[string]interface{}{
+		"script": map[string]interface{}{
+			"source": metadataUpdateScript,
+			"lang":   "painless",
+			"params": map[string]interface{}{"fields": fields},
+		},
+	}
+}
+
+// UpdateMetadata replaces the metadata of indexed documents of a tenant with bulk update requests,
+// keeping their extracted text. Documents not indexed yet are skipped. The index is refreshed once
+// all documents are updated if the refresh asks for it.
+func (di *DocumentIndex) UpdateMetadata(ctx context.Context, tenantID string, documents []*models.Document) error {
+	if tenantID == "" {
+		return errors.NewValidationError("Tenant ID cannot be empty")
+	}
+	if len(documents) == 0 {
+		return nil
+	}
+
+	indexName := di.GetTenantIndex(tenantID)
+	routing := di.tenantRouting(tenantID)
+	schema := di.metadataSchema(ctx, tenantID)
+
+	bulk, err := NewBulkIndexer(di.client, di.bulk)
 	if err != nil {
 		return err
 	}
+	for _, document := range documents {
+		if document.TenantID != tenantID {
+			bulk.discard()
+			return errors.NewValidationError("Document does not belong to the tenant")
+		}
+		if err := bulk.Update(ctx, indexName, document.ID, routing, metadataUpdate(document, schema)); err != nil {
+			bulk.discard()
+			return err
+		}
+	}
+	if err := bulk.Close(ctx); err != nil {
+		return err
+	}
 
-	di.logger.InfoContext(ctx, "Document indexed successfully", "document_id", document.ID, "index", indexName)
+	if di.refreshParam(ctx) != "false" {
+		if err := di.client.Refresh(ctx, indexName); err != nil {
+			return err
+		}
+	}
+
+	di.logger.InfoContext(ctx, "Document metadata updated", "documents", len(documents), "index", indexName)
 	return nil
 }
 
-// RemoveDocument removes a document from the tenant-specific index
+// RemoveDocument removes a document from the index of its tenant
 func (di *DocumentIndex) RemoveDocument(ctx context.Context, documentID string, tenantID string) error {
 	di.logger.InfoContext(ctx, "Removing document", "document_id", documentID, "tenant_id", tenantID)
 
@@ -678,22 +1076,425 @@ func (di *DocumentIndex) RemoveDocument(ctx context.Context, documentID string,
 	// Get tenant index
 	indexName := di.GetTenantIndex(tenantID)
 
-	// Delete document
-	err := di.client.Delete(ctx, indexName, documentID)
+	// Delete document, refreshing like IndexDocument
+	err := di.client.Delete(ctx, indexName, documentID, di.tenantRouting(tenantID), di.refreshParam(ctx))
 	if err != nil {
 		return err
 	}
 
-	// Refresh index to update search results immediately
-	err = di.client.Refresh(ctx, indexName)
+	di.logger.InfoContext(ctx, "Document removed successfully", "document_id", documentID, "index", indexName)
+	return nil
+}
+
+// Search executes a search query against the documents of a tenant
+func (di *DocumentIndex) Search(ctx context.Context, tenantID string, query map[string]interface{}, from, size int) (map[string]interface{}, error) {
+	return di.client.Search(ctx, di.GetTenantIndex(tenantID), di.tenantRouting(tenantID), tenantQuery(tenantID, query), from, size)
+}
+
+// RemoveTenant removes all documents of a tenant from the search index. A tenant with an index of
+// its own has the index deleted, including the indices it was rebuilt into.
+func (di *DocumentIndex) RemoveTenant(ctx context.Context, tenantID string) error {
+	if tenantID == "" {
+		return errors.NewValidationError("Tenant ID cannot be empty")
+	}
+
+	if di.strategy == IndexStrategyShared {
+		// Dedicated tenants may have documents left in the shared index from before they were
+		// given an index of their own
+		if err := di.client.DeleteByQuery(ctx, di.indexPrefix, tenantID, tenantQuery(tenantID, map[string]interface{}{})); err != nil {
+			return err
+		}
+		if di.sharesIndex(tenantID) {
+			return nil
+		}
+	}
+
+	alias := di.GetTenantIndex(tenantID)
+	indexNames, err := di.client.AliasIndexes(ctx, alias)
 	if err != nil {
 		return err
 	}
+	if len(indexNames) == 0 {
+		// Until its first rebuild, the tenant index is a concrete index
+		indexNames = []string{alias}
+	}
 
-	di.logger.InfoContext(ctx, "Document removed successfully", "document_id", documentID, "index", indexName)
+	for _, indexName := range indexNames {
+		if err := di.client.DeleteIndex(ctx, indexName); err != nil {
+			return err
+		}
+	}
+
+	di.logger.InfoContext(ctx, "Tenant removed from search index", "tenant_id", tenantID, "indices", indexNames)
+	return nil
+}
+
+// BeginRebuild starts rebuilding the index of a tenant. For a tenant with its own index, the documents are
+// indexed into a fresh index with the current settings and mappings, named after the tenant index
+// and the rebuild, so beginning a rebuild again replaces the index of an abandoned attempt. In a
+// shared index the documents of the tenant are indexed again in place, which repairs the index but
+// cannot apply mapping changes.
+func (di *DocumentIndex) BeginRebuild(ctx context.Context, tenantID string, rebuildID string) (*IndexRebuild, error) {
+	if tenantID == "" {
+		return nil, errors.NewValidationError("Tenant ID cannot be empty")
+	}
+	if rebuildID == "" {
+		return nil, errors.NewValidationError("Rebuild ID cannot be empty")
+	}
+
+	rebuild := &IndexRebuild{
+		documentIndex: di,
+		tenantID:      tenantID,
+		alias:         di.GetTenantIndex(tenantID),
+		startedAt:     time.Now(),
+	}
+
+	if di.sharesIndex(tenantID) {
+		indexName, err := di.EnsureTenantIndex(ctx, tenantID)
+		if err != nil {
+			return nil, err
+		}
+		rebuild.indexName = indexName
+		rebuild.inPlace = true
+	} else {
+		rebuild.indexName = fmt.Sprintf("%s-%s", rebuild.alias, strings.ToLower(rebuildID))
+
+		if err := di.client.DeleteIndex(ctx, rebuild.indexName); err != nil {
+			return nil, err
+		}
+		if err := di.client.CreateIndex(ctx, rebuild.indexName, di.indexSettings(), defaultIndexMappings); err != nil {
+			return nil, err
+		}
+
+		di.logger.InfoContext(ctx, "Created index to rebuild tenant index", "index", rebuild.indexName, "tenant_id", tenantID)
+	}
+
+	bulk, err := NewBulkIndexer(di.client, di.bulk)
+	if err != nil {
+		return nil, err
+	}
+	rebuild.bulk = bulk
+	rebuild.schema = di.metadataSchema(ctx, tenantID)
+	return rebuild, nil
+}
+
+// IndexRebuild is a rebuild of the index of a tenant in progress. With an index per tenant,
+// searches keep using the tenant index until Commit points its name, an alias from then on, at
+// the rebuilt index.
+type IndexRebuild struct {
+	documentIndex *DocumentIndex
+	tenantID      string
+	alias         string
+	indexName     string
+	inPlace       bool
+	startedAt     time.Time
+	bulk          *BulkIndexer
+	schema        models.MetadataSchema
+}
+
+// IndexDocument indexes a document in the rebuilt index. Documents are sent in bulk requests, and
+// the index is refreshed once on Commit rather than after every document.
+func (r *IndexRebuild) IndexDocument(ctx context.Context, document *models.Document, content []byte) error {
+	if document == nil {
+		return errors.NewValidationError("Document cannot be nil")
+	}
+	if len(content) == 0 {
+		return errors.NewValidationError("Document content cannot be empty")
+	}
+	if document.TenantID != r.tenantID {
+		return errors.NewValidationError("Document does not belong to the rebuilt tenant")
+	}
+
+	di := r.documentIndex
+	return r.bulk.Add(ctx, r.indexName, document.ID, di.tenantRouting(document.TenantID), di.documentSource(ctx, document, content, r.schema))
+}
+
+// Commit makes the rebuilt index the tenant index, deleting the indices it replaces. A rebuild in
+// place deletes the documents of the tenant that were not indexed again.
+func (r *IndexRebuild) Commit(ctx context.Context) error {
+	client := r.documentIndex.client
+
+	if err := r.bulk.Close(ctx); err != nil {
+		return err
+	}
+	if err := client.Refresh(ctx, r.indexName); err != nil {
+		return err
+	}
+
+	if r.inPlace {
+		stale := tenantQuery(r.tenantID, map[string]interface{}{
+			"query": map[string]interface{}{
+				"range": map[string]interface{}{
+					"indexed_at": map[string]interface{}{"lt": r.startedAt},
+				},
+			},
+		})
+		return client.DeleteByQuery(ctx, r.indexName, r.documentIndex.tenantRouting(r.tenantID), stale)
+	}
+
+	removeIndexes, err := client.AliasIndexes(ctx, r.alias)
+	if err != nil {
+		return err
+	}
+	if len(removeIndexes) == 0 {
+		// Until its first rebuild, the tenant index is a concrete index named like the alias
+		exists, err := client.IndexExists(ctx, r.alias)
+		if err != nil {
+			return err
+		}
+		if exists {
+			removeIndexes = []string{r.alias}
+		}
+	}
+
+	if err := client.SwapAlias(ctx, r.alias, r.indexName, removeIndexes); err != nil {
+		return err
+	}
+
+	r.documentIndex.logger.InfoContext(ctx, "Swapped tenant index to rebuilt index", "alias", r.alias, "index", r.indexName, "tenant_id", r.tenantID)
 	return nil
 }
 
+// Abort deletes the rebuilt index, leaving the tenant index as it was. A rebuild in place leaves
+// the documents indexed so far, which are as current as the ones they replaced.
+func (r *IndexRebuild) Abort(ctx context.Context) error {
+	r.bulk.discard()
+	if r.inPlace {
+		return nil
+	}
+	return r.documentIndex.client.DeleteIndex(ctx, r.indexName)
+}
+
+// typedMetadata returns the values of the metadata fields defined in schema, by the object of
+// their type. Values not of their field's type, stored before the field was defined or changed,
+// are left out.
+func typedMetadata(schema models.MetadataSchema, metadata []models.DocumentMetadata) map[string]map[string]interface{} {
+	typed := make(map[string]map[string]interface{})
+	add := func(object, name string, value interface{}) {
+		if typed[object] == nil {
+			typed[object] = make(map[string]interface{})
+		}
+		typed[object][name] = value
+	}
+
+	for _, m := range metadata {
+		field := schema.Field(m.Key)
+		if field == nil {
+			continue
+		}
+
+		switch field.Type {
+		case models.MetadataFieldTypeNumber:
+			if number, err := models.ParseMetadataNumber(m.Value); err == nil {
+				add(metadataNumberObject, field.Name, number)
+			}
+		case models.MetadataFieldTypeDate:
+			if date, err := models.ParseMetadataDate(m.Value); err == nil {
+				add(metadataDateObject, field.Name, date.UTC().Format(time.RFC3339))
+			}
+		case models.MetadataFieldTypeString, models.MetadataFieldTypeEnum:
+			add(metadataKeywordObject, field.Name, m.Value)
+		}
+	}
+	return typed
+}
+
+// sortFieldDocumentID sorts search results by document ID. Searches sort by it last, so pages
+// neither overlap nor skip results.
+const sortFieldDocumentID = "document_id"
+
+// sortFields are the fields of the index search results are sorted by for each sort field
+var sortFields = map[string]string{
+	utils.SortFieldRelevance: "_score",
+	utils.SortFieldName:      "name.keyword",
+	utils.SortFieldCreatedAt: "created_at",
+	utils.SortFieldUpdatedAt: "updated_at",
+	utils.SortFieldSize:      "size",
+	sortFieldDocumentID:      "document_id",
+}
+
+// searchSort returns the keys search results are sorted by: the sort keys on fields of the index,
+// or by relevance when there are none, then by document ID
+func searchSort(sort []utils.SortKey) []utils.SortKey {
+	keys := make([]utils.SortKey, 0, len(sort)+2)
+	for _, key := range sort {
+		if _, ok := sortFields[key.Field]; ok && key.Field != sortFieldDocumentID {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		keys = append(keys, utils.SortKey{Field: utils.SortFieldRelevance, Direction: utils.SortDescending})
+	}
+	return append(keys, utils.SortKey{Field: sortFieldDocumentID, Direction: utils.SortAscending})
+}
+
+// sortQuery sorts the results of a search query by the sort keys, then by document ID so pages do
+// not overlap. Without sort keys the results stay ranked by relevance.
+func sortQuery(query map[string]interface{}, sort []utils.SortKey) map[string]interface{} {
+	keys := searchSort(sort)
+	clauses := make([]interface{}, len(keys))
+	for i, key := range keys {
+		clauses[i] = map[string]interface{}{sortFields[key.Field]: map[string]interface{}{"order": key.Direction}}
+	}
+
+	sorted := make(map[string]interface{}, len(query)+2)
+	for key, value := range query {
+		sorted[key] = value
+	}
+	sorted["sort"] = clauses
+	return sorted
+}
+
+// pageQuery sorts a search query for a page of results and, when the pagination has a cursor,
+// starts the page after the last result of the previous one with search_after. It returns the
+// query and the offset to search from, which is 0 after a cursor.
+func pageQuery(query map[string]interface{}, pagination *utils.Pagination) (map[string]interface{}, int, error) {
+	paged := sortQuery(query, pagination.Sort)
+	if pagination.Cursor == "" {
+		return paged, pagination.GetOffset(), nil
+	}
+
+	keys := searchSort(pagination.Sort)
+	after := make([]interface{}, len(keys))
+	dest := make([]interface{}, len(keys))
+	for i := range after {
+		dest[i] = &after[i]
+	}
+	if err := utils.DecodeCursor(pagination.Cursor, keys, dest...); err != nil {
+		return nil, 0, err
+	}
+	paged["search_after"] = after
+	return paged, 0, nil
+}
+
+// nextPageCursor sets the pagination's next cursor to the sort values of the last hit of a page of
+// search results, which search_after continues from. Only full pages have a next cursor, so the
+// last page of results may be followed by an empty one.
+func nextPageCursor(searchResults map[string]interface{}, pagination *utils.Pagination, size int) {
+	pagination.NextCursor = ""
+	hitsMap, _ := searchResults["hits"].(map[string]interface{})
+	hits, _ := hitsMap["hits"].([]interface{})
+	if len(hits) == 0 || len(hits) < size {
+		return
+	}
+	last, _ := hits[len(hits)-1].(map[string]interface{})
+	values, ok := last["sort"].([]interface{})
+	if !ok {
+		return
+	}
+	pagination.NextCursor = utils.EncodeCursor(searchSort(pagination.Sort), values...)
+}
+
+// metadataFilterQuery restricts a search query to the documents matching the metadata filters.
+// The filters do not score, so the query ranks the documents as it does alone.
+func metadataFilterQuery(query map[string]interface{}, filters []models.MetadataFilter) map[string]interface{} {
+	if len(filters) == 0 {
+		return query
+	}
+
+	clauses := make([]interface{}, len(filters))
+	for i, filter := range filters {
+		clauses[i] = metadataFilterClause(filter)
+	}
+
+	restricted := make(map[string]interface{}, len(query))
+	for key, value := range query {
+		restricted[key] = value
+	}
+
+	boolQuery := map[string]interface{}{"filter": clauses}
+	if q, ok := query["query"]; ok {
+		boolQuery["must"] = []interface{}{q}
+	}
+	restricted["query"] = map[string]interface{}{"bool": boolQuery}
+
+	return restricted
+}
+
+// metadataFilterClause returns the query clause of a metadata filter. Number and date filters
+// compare the typed values of the key's field, which only exist for values of the field's type;
+// string filters compare the exact values of any key.
+func metadataFilterClause(filter models.MetadataFilter) map[string]interface{} {
+	var field string
+	switch filter.Type {
+	case models.MetadataFieldTypeNumber:
+		field = metadataNumberObject + "." + filter.Key
+	case models.MetadataFieldTypeDate:
+		field = metadataDateObject + "." + filter.Key
+	default:
+		clauses := []interface{}{
+			map[string]interface{}{"term": map[string]interface{}{"metadata.key": filter.Key}},
+		}
+		switch filter.Operator {
+		case models.MetadataFilterEq:
+			clauses = append(clauses, map[string]interface{}{"term": map[string]interface{}{"metadata.value.keyword": filter.Value}})
+		case models.MetadataFilterPrefix:
+			clauses = append(clauses, map[string]interface{}{"prefix": map[string]interface{}{"metadata.value.keyword": filter.Value}})
+		}
+		return map[string]interface{}{
+			"nested": map[string]interface{}{
+				"path":  "metadata",
+				"query": map[string]interface{}{"bool": map[string]interface{}{"filter": clauses}},
+			},
+		}
+	}
+
+	switch filter.Operator {
+	case models.MetadataFilterExists:
+		return map[string]interface{}{"exists": map[string]interface{}{"field": field}}
+	case models.MetadataFilterEq:
+		return map[string]interface{}{"term": map[string]interface{}{field: typedFilterValue(filter.Type, filter.Value)}}
+	case models.MetadataFilterBetween:
+		return map[string]interface{}{"range": map[string]interface{}{field: map[string]interface{}{
+			"gte": typedFilterValue(filter.Type, filter.Values[0]),
+			"lte": typedFilterValue(filter.Type, filter.Values[1]),
+		}}}
+	default:
+		return map[string]interface{}{"range": map[string]interface{}{field: map[string]interface{}{
+			filter.Operator: typedFilterValue(filter.Type, filter.Value),
+		}}}
+	}
+}
+
+// typedFilterValue returns a value a filter compares to as it is indexed in the object of its
+// field's type; resolved filters only hold values of their type
+func typedFilterValue(fieldType string, value string) interface{} {
+	switch fieldType {
+	case models.MetadataFieldTypeNumber:
+		number, _ := models.ParseMetadataNumber(value)
+		return number
+	case models.MetadataFieldTypeDate:
+		date, _ := models.ParseMetadataDate(value)
+		return date.UTC().Format(time.RFC3339)
+	}
+	return value
+}
+
+// tenantQuery restricts a search query to the documents of a tenant. A query without a "query"
+// matches all documents of the tenant.
+func tenantQuery(tenantID string, query map[string]interface{}) map[string]interface{} {
+	restricted := make(map[string]interface{}, len(query)+1)
+	for key, value := range query {
+		restricted[key] = value
+	}
+
+	boolQuery := map[string]interface{}{
+		"filter": []interface{}{
+			map[string]interface{}{
+				"term": map[string]interface{}{
+					"tenant_id": tenantID,
+				},
+			},
+		},
+	}
+	if q, ok := query["query"]; ok {
+		boolQuery["must"] = []interface{}{q}
+	}
+	restricted["query"] = map[string]interface{}{"bool": boolQuery}
+
+	return restricted
+}
+
 // extractText extracts searchable text from document content
 func (di *DocumentIndex) extractText(content []byte, contentType string) (string, error) {
 	// For plain text, just return the content as string
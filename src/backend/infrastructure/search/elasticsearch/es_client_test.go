@@ -0,0 +1,236 @@
+package elasticsearch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"../../../domain/models"
+	"../../../domain/services"
+	"../../../pkg/utils"
+)
+
+// TestDocumentIndex_GetTenantIndex tests the index and routing of tenants with each index strategy
+func TestDocumentIndex_GetTenantIndex(t *testing.T) {
+	perTenant := &DocumentIndex{indexPrefix: "documents", strategy: IndexStrategyPerTenant}
+	assert.Equal(t, "documents-tenant-1", perTenant.GetTenantIndex("tenant-1"))
+	assert.Equal(t, "", perTenant.tenantRouting("tenant-1"))
+
+	shared := &DocumentIndex{indexPrefix: "documents", strategy: IndexStrategyShared, dedicated: map[string]bool{"tenant-2": true}}
+	assert.Equal(t, "documents", shared.GetTenantIndex("tenant-1"))
+	assert.Equal(t, "tenant-1", shared.tenantRouting("tenant-1"))
+
+	// Dedicated tenants get an index of their own
+	assert.Equal(t, "documents-tenant-2", shared.GetTenantIndex("tenant-2"))
+	assert.Equal(t, "", shared.tenantRouting("tenant-2"))
+}
+
+// TestDocumentIndex_refreshParam tests that requests refresh as configured unless the context
+// selects otherwise
+func TestDocumentIndex_refreshParam(t *testing.T) {
+	di := &DocumentIndex{refresh: services.IndexRefreshWaitFor}
+	ctx := context.Background()
+
+	assert.Equal(t, "wait_for", di.refreshParam(ctx))
+	assert.Equal(t, "true", di.refreshParam(services.ContextWithIndexRefresh(ctx, services.IndexRefreshImmediate)))
+	assert.Equal(t, "false", di.refreshParam(services.ContextWithIndexRefresh(ctx, services.IndexRefreshNone)))
+}
+
+// TestDocumentIndex_indexSettings tests attaching the lifecycle policy to new indices
+func TestDocumentIndex_indexSettings(t *testing.T) {
+	di := &DocumentIndex{}
+	assert.NotContains(t, di.indexSettings(), "index.lifecycle.name")
+
+	di.ilmPolicy = "documents-policy"
+	assert.Equal(t, "documents-policy", di.indexSettings()["index.lifecycle.name"])
+	assert.NotContains(t, defaultIndexSettings, "index.lifecycle.name")
+}
+
+// TestTypedMetadata tests indexing the metadata fields of the schema with their type
+func TestTypedMetadata(t *testing.T) {
+	schema := models.MetadataSchema{
+		{Name: "amount", Type: models.MetadataFieldTypeNumber},
+		{Name: "due", Type: models.MetadataFieldTypeDate},
+		{Name: "status", Type: models.MetadataFieldTypeEnum, AllowedValues: []string{"draft", "final"}},
+		{Name: "owner", Type: models.MetadataFieldTypeString},
+	}
+	metadata := []models.DocumentMetadata{
+		{Key: "amount", Value: "1250.50"},
+		{Key: "due", Value: "2024-01-31"},
+		{Key: "status", Value: "final"},
+		{Key: "owner", Value: "legal"},
+		{Key: "notes", Value: "not in the schema"},
+	}
+
+	assert.Equal(t, map[string]map[string]interface{}{
+		metadataNumberObject:  {"amount": 1250.50},
+		metadataDateObject:    {"due": "2024-01-31T00:00:00Z"},
+		metadataKeywordObject: {"status": "final", "owner": "legal"},
+	}, typedMetadata(schema, metadata))
+
+	// Values stored before their field was defined are left out rather than failing the document
+	assert.Empty(t, typedMetadata(schema, []models.DocumentMetadata{{Key: "amount", Value: "unknown"}}))
+
+	// Without a schema metadata is only indexed as text
+	assert.Empty(t, typedMetadata(nil, metadata))
+}
+
+// TestMetadataUpdate tests that updating metadata replaces the typed metadata objects and removes
+// those the document no longer has values for
+func TestMetadataUpdate(t *testing.T) {
+	schema := models.MetadataSchema{{Name: "amount", Type: models.MetadataFieldTypeNumber}}
+	document := &models.Document{ID: "doc-1", Metadata: []models.DocumentMetadata{{Key: "amount", Value: "10"}}}
+
+	update := metadataUpdate(document, schema)
+	script := update["script"].(map[string]interface{})
+	fields := script["params"].(map[string]interface{})["fields"].(map[string]interface{})
+	assert.Equal(t, metadataUpdateScript, script["source"])
+	assert.Equal(t, []map[string]string{{"key": "amount", "value": "10"}}, fields["metadata"])
+	assert.Equal(t, map[string]interface{}{"amount": 10.0}, fields[metadataNumberObject])
+	assert.Contains(t, fields, metadataKeywordObject)
+	assert.Nil(t, fields[metadataKeywordObject])
+
+	// A document left without metadata has all metadata fields removed
+	fields = metadataUpdate(&models.Document{ID: "doc-2"}, schema)["script"].(map[string]interface{})["params"].(map[string]interface{})["fields"].(map[string]interface{})
+	assert.Nil(t, fields["metadata"])
+	assert.Nil(t, fields[metadataNumberObject])
+}
+
+// TestTenantQuery tests restricting a search query to the documents of a tenant
+func TestTenantQuery(t *testing.T) {
+	query := map[string]interface{}{
+		"query": map[string]interface{}{"match": map[string]interface{}{"content": "budget"}},
+		"sort":  []interface{}{"_score"},
+	}
+
+	restricted := tenantQuery("tenant-1", query)
+
+	assert.Equal(t, map[string]interface{}{
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{
+				"filter": []interface{}{
+					map[string]interface{}{"term": map[string]interface{}{"tenant_id": "tenant-1"}},
+				},
+				"must": []interface{}{
+					map[string]interface{}{"match": map[string]interface{}{"content": "budget"}},
+				},
+			},
+		},
+		"sort": []interface{}{"_score"},
+	}, restricted)
+
+	// The original query is left unchanged
+	assert.Contains(t, query["query"], "match")
+
+	// Without a query all documents of the tenant match
+	all := tenantQuery("tenant-1", map[string]interface{}{})
+	assert.NotContains(t, all["query"].(map[string]interface{})["bool"], "must")
+}
+
+// TestMetadataFilterQuery tests restricting a search query to the documents matching metadata filters
+func TestMetadataFilterQuery(t *testing.T) {
+	query := map[string]interface{}{
+		"query": map[string]interface{}{"match": map[string]interface{}{"content": "invoice"}},
+	}
+	filters := []models.MetadataFilter{
+		{Key: "amount", Operator: models.MetadataFilterBetween, Values: []string{"100", "250.5"}, Type: models.MetadataFieldTypeNumber},
+		{Key: "due", Operator: models.MetadataFilterLt, Value: "2024-07-01", Type: models.MetadataFieldTypeDate},
+		{Key: "vendor", Operator: models.MetadataFilterPrefix, Value: "Acme", Type: models.MetadataFieldTypeString},
+	}
+
+	restricted := metadataFilterQuery(query, filters)
+
+	assert.Equal(t, map[string]interface{}{
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{
+				"filter": []interface{}{
+					map[string]interface{}{"range": map[string]interface{}{
+						"metadata_number.amount": map[string]interface{}{"gte": float64(100), "lte": 250.5},
+					}},
+					map[string]interface{}{"range": map[string]interface{}{
+						"metadata_date.due": map[string]interface{}{"lt": "2024-07-01T00:00:00Z"},
+					}},
+					map[string]interface{}{"nested": map[string]interface{}{
+						"path": "metadata",
+						"query": map[string]interface{}{"bool": map[string]interface{}{"filter": []interface{}{
+							map[string]interface{}{"term": map[string]interface{}{"metadata.key": "vendor"}},
+							map[string]interface{}{"prefix": map[string]interface{}{"metadata.value.keyword": "Acme"}},
+						}}},
+					}},
+				},
+				"must": []interface{}{
+					map[string]interface{}{"match": map[string]interface{}{"content": "invoice"}},
+				},
+			},
+		},
+	}, restricted)
+
+	// Without filters the query is left as it is
+	assert.Equal(t, query, metadataFilterQuery(query, nil))
+
+	// Typed keys are checked for existence in their typed object
+	exists := metadataFilterClause(models.MetadataFilter{Key: "amount", Operator: models.MetadataFilterExists, Type: models.MetadataFieldTypeNumber})
+	assert.Equal(t, map[string]interface{}{"exists": map[string]interface{}{"field": "metadata_number.amount"}}, exists)
+}
+
+// TestSortQuery tests sorting the results of a search query by sort keys
+func TestSortQuery(t *testing.T) {
+	query := map[string]interface{}{
+		"query": map[string]interface{}{"match": map[string]interface{}{"content": "invoice"}},
+	}
+
+	sorted := sortQuery(query, []utils.SortKey{
+		{Field: utils.SortFieldSize, Direction: utils.SortDescending},
+		{Field: utils.SortFieldName, Direction: utils.SortAscending},
+	})
+
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"size": map[string]interface{}{"order": "desc"}},
+		map[string]interface{}{"name.keyword": map[string]interface{}{"order": "asc"}},
+		map[string]interface{}{"document_id": map[string]interface{}{"order": "asc"}},
+	}, sorted["sort"])
+	assert.Equal(t, query["query"], sorted["query"])
+
+	// The original query is left unchanged, and without sort keys results stay ranked by relevance
+	assert.NotContains(t, query, "sort")
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"_score": map[string]interface{}{"order": "desc"}},
+		map[string]interface{}{"document_id": map[string]interface{}{"order": "asc"}},
+	}, sortQuery(query, nil)["sort"])
+}
+
+// TestPageQuery tests continuing a search after the last hit of a page
+func TestPageQuery(t *testing.T) {
+	query := map[string]interface{}{"query": map[string]interface{}{"match_all": map[string]interface{}{}}}
+	sort := []utils.SortKey{{Field: utils.SortFieldCreatedAt, Direction: utils.SortDescending}}
+
+	// A full page has a cursor following its last hit
+	pagination := utils.NewPagination(3, 2).WithSort(sort)
+	paged, from, err := pageQuery(query, pagination)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, from)
+	assert.NotContains(t, paged, "search_after")
+
+	nextPageCursor(map[string]interface{}{"hits": map[string]interface{}{"hits": []interface{}{
+		map[string]interface{}{"_id": "doc-1", "sort": []interface{}{float64(1700000000000), "doc-1"}},
+		map[string]interface{}{"_id": "doc-2", "sort": []interface{}{float64(1600000000000), "doc-2"}},
+	}}}, pagination, 2)
+	assert.NotEmpty(t, pagination.NextCursor)
+
+	next := utils.NewPagination(1, 2).WithSort(sort).WithCursor(pagination.NextCursor)
+	paged, from, err = pageQuery(query, next)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, from)
+	assert.Equal(t, []interface{}{float64(1600000000000), "doc-2"}, paged["search_after"])
+
+	// A page that is not full is the last one
+	nextPageCursor(map[string]interface{}{"hits": map[string]interface{}{"hits": []interface{}{
+		map[string]interface{}{"_id": "doc-3", "sort": []interface{}{float64(1500000000000), "doc-3"}},
+	}}}, next, 2)
+	assert.Empty(t, next.NextCursor)
+
+	// Cursors of a search sorted differently are rejected
+	_, _, err = pageQuery(query, utils.NewPagination(1, 2).WithCursor(pagination.NextCursor))
+	assert.ErrorIs(t, err, utils.ErrInvalidCursor)
+}
@@ -0,0 +1,391 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	awsConfig "github.com/aws/aws-sdk-go-v2/config"               // v2.0.0+
+	"github.com/opensearch-project/opensearch-go/v2"              // v2.3.0+
+	"github.com/opensearch-project/opensearch-go/v2/signer/awsv2" // v2.3.0+
+
+	"../../../pkg/config"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+	"../../../pkg/tracing"
+)
+
+// defaultOpenSearchSigningService is the service name requests to Amazon OpenSearch Service
+// domains are signed for
+const defaultOpenSearchSigningService = "es"
+
+// OpenSearchClient represents a client for interacting with OpenSearch, self-managed or as an
+// Amazon OpenSearch Service domain authenticating requests with SigV4
+type OpenSearchClient struct {
+	queryBuilder
+	client *opensearch.Client
+	logger logger.Logger
+}
+
+// Ensure OpenSearchClient implements SearchBackend
+var _ SearchBackend = (*OpenSearchClient)(nil)
+
+// NewOpenSearchClient creates a new OpenSearchClient instance for the nodes of esConfig. Requests
+// are signed with SigV4 when osConfig has a region, and use the username and password otherwise.
+func NewOpenSearchClient(ctx context.Context, esConfig config.ElasticsearchConfig, osConfig config.OpenSearchConfig) (*OpenSearchClient, error) {
+	if len(esConfig.Addresses) == 0 {
+		return nil, errors.NewValidationError("OpenSearch addresses cannot be empty")
+	}
+
+	cfg := opensearch.Config{
+		Addresses: esConfig.Addresses,
+		Username:  esConfig.Username,
+		Password:  esConfig.Password,
+		Transport: tracing.NewTransport(&http.Transport{
+			MaxIdleConnsPerHost:   10,
+			ResponseHeaderTimeout: 10 * time.Second,
+			TLSClientConfig: &tls.Config{
+				MinVersion: tls.VersionTLS12,
+			},
+		}, "OpenSearch"),
+	}
+
+	if osConfig.Region != "" {
+		awsCfg, err := awsConfig.LoadDefaultConfig(ctx, awsConfig.WithRegion(osConfig.Region))
+		if err != nil {
+			return nil, errors.NewDependencyError(fmt.Sprintf("failed to load AWS config: %v", err))
+		}
+
+		service := osConfig.Service
+		if service == "" {
+			service = defaultOpenSearchSigningService
+		}
+		signer, err := awsv2.NewSignerWithService(awsCfg, service)
+		if err != nil {
+			return nil, errors.NewDependencyError(fmt.Sprintf("Failed to create OpenSearch request signer: %s", err.Error()))
+		}
+
+		// Signed requests authenticate with the AWS credentials instead of basic auth
+		cfg.Signer = signer
+		cfg.Username = ""
+		cfg.Password = ""
+	}
+
+	client, err := opensearch.NewClient(cfg)
+	if err != nil {
+		return nil, errors.NewDependencyError(fmt.Sprintf("Failed to create OpenSearch client: %s", err.Error()))
+	}
+
+	// Verify connection to OpenSearch
+	resp, err := client.Info(client.Info.WithContext(ctx))
+	if err != nil {
+		return nil, errors.NewDependencyError(fmt.Sprintf("Failed to connect to OpenSearch: %s", err.Error()))
+	}
+	defer resp.Body.Close()
+
+	if resp.IsError() {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, errors.NewDependencyError(fmt.Sprintf("OpenSearch info request failed: %s", string(bodyBytes)))
+	}
+
+	logger.Info("Connected to OpenSearch", "addresses", esConfig.Addresses, "signed", cfg.Signer != nil)
+
+	return &OpenSearchClient{
+		client: client,
+		logger: logger.WithField("component", "opensearch_client"),
+	}, nil
+}
+
+// Ping checks the OpenSearch cluster answers and is not red
+func (c *OpenSearchClient) Ping(ctx context.Context) error {
+	res, err := c.client.Cluster.Health(c.client.Cluster.Health.WithContext(ctx))
+	if err != nil {
+		return errors.NewDependencyError(fmt.Sprintf("OpenSearch cluster health request failed: %s", err.Error()))
+	}
+	defer res.Body.Close()
+
+	return checkClusterHealth("OpenSearch", res.IsError(), res.Status(), res.Body)
+}
+
+// Search executes a search query against OpenSearch
+func (c *OpenSearchClient) Search(ctx context.Context, index string, routing string, query map[string]interface{}, from, size int) (map[string]interface{}, error) {
+	c.logger.InfoContext(ctx, "Executing OpenSearch search", "index", index, "from", from, "size", size)
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(query); err != nil {
+		return nil, errors.NewValidationError(fmt.Sprintf("Failed to encode search query: %s", err.Error()))
+	}
+
+	res, err := c.client.Search(
+		c.client.Search.WithContext(ctx),
+		c.client.Search.WithIndex(index),
+		c.client.Search.WithBody(&buf),
+		c.client.Search.WithFrom(from),
+		c.client.Search.WithSize(size),
+		c.client.Search.WithRouting(routingValues(routing)...),
+	)
+	if err != nil {
+		return nil, errors.NewDependencyError(fmt.Sprintf("OpenSearch search request failed: %s", err.Error()))
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, decodeErrorResponse(res.Body, "OpenSearch search")
+	}
+
+	var result map[string]interface{}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, errors.NewDependencyError(fmt.Sprintf("Failed to parse search response: %s", err.Error()))
+	}
+
+	return result, nil
+}
+
+// Index indexes a document in OpenSearch
+func (c *OpenSearchClient) Index(ctx context.Context, index string, id string, routing string, refresh string, document interface{}) error {
+	c.logger.InfoContext(ctx, "Indexing document in OpenSearch", "index", index, "id", id)
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(document); err != nil {
+		return errors.NewValidationError(fmt.Sprintf("Failed to encode document: %s", err.Error()))
+	}
+
+	res, err := c.client.Index(
+		index,
+		&buf,
+		c.client.Index.WithContext(ctx),
+		c.client.Index.WithDocumentID(id),
+		c.client.Index.WithRouting(routing),
+		c.client.Index.WithRefresh(refresh),
+	)
+	if err != nil {
+		return errors.NewDependencyError(fmt.Sprintf("OpenSearch index request failed: %s", err.Error()))
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return decodeErrorResponse(res.Body, "OpenSearch index")
+	}
+
+	return nil
+}
+
+// Delete deletes a document from OpenSearch
+func (c *OpenSearchClient) Delete(ctx context.Context, index string, id string, routing string, refresh string) error {
+	c.logger.InfoContext(ctx, "Deleting document from OpenSearch", "index", index, "id", id)
+
+	res, err := c.client.Delete(
+		index,
+		id,
+		c.client.Delete.WithContext(ctx),
+		c.client.Delete.WithRouting(routing),
+		c.client.Delete.WithRefresh(refresh),
+	)
+	if err != nil {
+		return errors.NewDependencyError(fmt.Sprintf("OpenSearch delete request failed: %s", err.Error()))
+	}
+	defer res.Body.Close()
+
+	// 404 is acceptable as it means the document doesn't exist
+	if res.IsError() && res.StatusCode != http.StatusNotFound {
+		return decodeErrorResponse(res.Body, "OpenSearch delete")
+	}
+
+	return nil
+}
+
+// DeleteByQuery deletes the documents matching a query from an OpenSearch index
+func (c *OpenSearchClient) DeleteByQuery(ctx context.Context, index string, routing string, query map[string]interface{}) error {
+	c.logger.InfoContext(ctx, "Deleting documents by query from OpenSearch", "index", index, "routing", routing)
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(query); err != nil {
+		return errors.NewValidationError(fmt.Sprintf("Failed to encode delete query: %s", err.Error()))
+	}
+
+	// Documents changed while they are deleted are deleted anyway rather than failing the request
+	res, err := c.client.DeleteByQuery(
+		[]string{index},
+		&buf,
+		c.client.DeleteByQuery.WithContext(ctx),
+		c.client.DeleteByQuery.WithRouting(routingValues(routing)...),
+		c.client.DeleteByQuery.WithConflicts("proceed"),
+		c.client.DeleteByQuery.WithRefresh(true),
+	)
+	if err != nil {
+		return errors.NewDependencyError(fmt.Sprintf("OpenSearch delete by query request failed: %s", err.Error()))
+	}
+	defer res.Body.Close()
+
+	// 404 is acceptable as it means the index doesn't exist
+	if res.IsError() && res.StatusCode != http.StatusNotFound {
+		return decodeErrorResponse(res.Body, "OpenSearch delete by query")
+	}
+
+	return nil
+}
+
+// Bulk sends a bulk request to OpenSearch
+func (c *OpenSearchClient) Bulk(ctx context.Context, body io.Reader) (*BulkResponse, error) {
+	res, err := c.client.Bulk(
+		body,
+		c.client.Bulk.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, errors.NewDependencyError(fmt.Sprintf("OpenSearch bulk request failed: %s", err.Error()))
+	}
+	defer res.Body.Close()
+
+	// 429 means the server is overloaded and the request can be sent again later
+	if res.StatusCode == http.StatusTooManyRequests {
+		return &BulkResponse{StatusCode: res.StatusCode}, nil
+	}
+	if res.IsError() {
+		return nil, decodeErrorResponse(res.Body, "OpenSearch bulk")
+	}
+
+	return decodeBulkResponse(res.StatusCode, res.Body)
+}
+
+// CreateIndex creates an OpenSearch index with the specified settings and mappings
+func (c *OpenSearchClient) CreateIndex(ctx context.Context, index string, settings map[string]interface{}, mappings map[string]interface{}) error {
+	c.logger.InfoContext(ctx, "Creating OpenSearch index", "index", index)
+
+	exists, err := c.IndexExists(ctx, index)
+	if err != nil {
+		return err
+	}
+	if exists {
+		c.logger.InfoContext(ctx, "Index already exists", "index", index)
+		return nil
+	}
+
+	var buf bytes.Buffer
+	body := map[string]interface{}{
+		"settings": settings,
+		"mappings": mappings,
+	}
+	if err := json.NewEncoder(&buf).Encode(body); err != nil {
+		return errors.NewValidationError(fmt.Sprintf("Failed to encode index body: %s", err.Error()))
+	}
+
+	res, err := c.client.Indices.Create(
+		index,
+		c.client.Indices.Create.WithContext(ctx),
+		c.client.Indices.Create.WithBody(&buf),
+	)
+	if err != nil {
+		return errors.NewDependencyError(fmt.Sprintf("OpenSearch create index request failed: %s", err.Error()))
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return decodeErrorResponse(res.Body, "OpenSearch create index")
+	}
+
+	return nil
+}
+
+// IndexExists checks if an OpenSearch index exists
+func (c *OpenSearchClient) IndexExists(ctx context.Context, index string) (bool, error) {
+	res, err := c.client.Indices.Exists(
+		[]string{index},
+		c.client.Indices.Exists.WithContext(ctx),
+	)
+	if err != nil {
+		return false, errors.NewDependencyError(fmt.Sprintf("OpenSearch index exists request failed: %s", err.Error()))
+	}
+	defer res.Body.Close()
+
+	return res.StatusCode == http.StatusOK, nil
+}
+
+// DeleteIndex deletes an OpenSearch index
+func (c *OpenSearchClient) DeleteIndex(ctx context.Context, index string) error {
+	c.logger.InfoContext(ctx, "Deleting OpenSearch index", "index", index)
+
+	res, err := c.client.Indices.Delete(
+		[]string{index},
+		c.client.Indices.Delete.WithContext(ctx),
+	)
+	if err != nil {
+		return errors.NewDependencyError(fmt.Sprintf("OpenSearch delete index request failed: %s", err.Error()))
+	}
+	defer res.Body.Close()
+
+	if res.IsError() && res.StatusCode != http.StatusNotFound {
+		return decodeErrorResponse(res.Body, "OpenSearch delete index")
+	}
+
+	return nil
+}
+
+// Refresh refreshes an OpenSearch index to make recent changes available for search
+func (c *OpenSearchClient) Refresh(ctx context.Context, index string) error {
+	res, err := c.client.Indices.Refresh(
+		c.client.Indices.Refresh.WithContext(ctx),
+		c.client.Indices.Refresh.WithIndex(index),
+	)
+	if err != nil {
+		return errors.NewDependencyError(fmt.Sprintf("OpenSearch refresh request failed: %s", err.Error()))
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return decodeErrorResponse(res.Body, "OpenSearch refresh")
+	}
+
+	return nil
+}
+
+// AliasIndexes returns the indexes an OpenSearch alias points to
+func (c *OpenSearchClient) AliasIndexes(ctx context.Context, alias string) ([]string, error) {
+	res, err := c.client.Indices.GetAlias(
+		c.client.Indices.GetAlias.WithContext(ctx),
+		c.client.Indices.GetAlias.WithName(alias),
+	)
+	if err != nil {
+		return nil, errors.NewDependencyError(fmt.Sprintf("OpenSearch get alias request failed: %s", err.Error()))
+	}
+	defer res.Body.Close()
+
+	// 404 means the alias doesn't exist
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if res.IsError() {
+		return nil, decodeErrorResponse(res.Body, "OpenSearch get alias")
+	}
+
+	return decodeAliasIndexes(res.Body)
+}
+
+// SwapAlias atomically points an OpenSearch alias at index, deleting removeIndexes
+func (c *OpenSearchClient) SwapAlias(ctx context.Context, alias string, index string, removeIndexes []string) error {
+	c.logger.InfoContext(ctx, "Swapping OpenSearch alias", "alias", alias, "index", index, "removed_indexes", removeIndexes)
+
+	body, err := encodeSwapAlias(alias, index, removeIndexes)
+	if err != nil {
+		return err
+	}
+
+	res, err := c.client.Indices.UpdateAliases(
+		body,
+		c.client.Indices.UpdateAliases.WithContext(ctx),
+	)
+	if err != nil {
+		return errors.NewDependencyError(fmt.Sprintf("OpenSearch update aliases request failed: %s", err.Error()))
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return decodeErrorResponse(res.Body, "OpenSearch update aliases")
+	}
+
+	return nil
+}
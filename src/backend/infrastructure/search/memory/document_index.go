@@ -0,0 +1,390 @@
+// Package memory provides an in-memory implementation of the search interfaces, so the platform
+// runs in development without Elasticsearch. It matches whole words like the Elasticsearch match
+// queries, without stemming or fuzziness, and its index is lost on restart.
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"../../../domain/models"
+	"../../../domain/services"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+	"../../../pkg/utils"
+)
+
+// indexedDocument is a document as it is searched: the words of its content and metadata values,
+// the metadata values themselves for filters, and the fields results are sorted by
+type indexedDocument struct {
+	id        string
+	folderID  string
+	content   map[string]int             // Occurrences of each word of the content
+	metadata  map[string]map[string]bool // Words of the value of each metadata key
+	values    map[string]string          // Value of each metadata key
+	name      string
+	size      int64
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+// DocumentIndex implements services.SearchIndexer and services.SearchQueryExecutor with an index per
+// tenant kept in memory
+type DocumentIndex struct {
+	mu        sync.RWMutex
+	documents map[string]map[string]*indexedDocument // Documents of each tenant by ID
+}
+
+// NewDocumentIndex creates an empty DocumentIndex
+func NewDocumentIndex() *DocumentIndex {
+	return &DocumentIndex{
+		documents: make(map[string]map[string]*indexedDocument),
+	}
+}
+
+// Ensure DocumentIndex implements services.SearchIndexer, services.SearchQueryExecutor,
+// services.SearchTenantRemover and services.SearchMetadataUpdater
+var (
+	_ services.SearchIndexer         = (*DocumentIndex)(nil)
+	_ services.SearchQueryExecutor   = (*DocumentIndex)(nil)
+	_ services.SearchTenantRemover   = (*DocumentIndex)(nil)
+	_ services.SearchMetadataUpdater = (*DocumentIndex)(nil)
+)
+
+// IndexDocument indexes a document, replacing the document when it was indexed before
+func (i *DocumentIndex) IndexDocument(ctx context.Context, document *models.Document, content []byte) error {
+	if document == nil {
+		return errors.NewValidationError("Document cannot be nil")
+	}
+	if len(content) == 0 {
+		return errors.NewValidationError("Document content cannot be empty")
+	}
+
+	indexed := &indexedDocument{
+		id:        document.ID,
+		folderID:  document.FolderID,
+		content:   make(map[string]int),
+		name:      document.Name,
+		size:      document.Size,
+		createdAt: document.CreatedAt,
+		updatedAt: document.UpdatedAt,
+	}
+	for _, word := range words(extractText(content, document.ContentType)) {
+		indexed.content[word]++
+	}
+	indexed.metadata = metadataWords(document.Metadata)
+	indexed.values = metadataValues(document.Metadata)
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if i.documents[document.TenantID] == nil {
+		i.documents[document.TenantID] = make(map[string]*indexedDocument)
+	}
+	i.documents[document.TenantID][document.ID] = indexed
+
+	logger.InfoContext(ctx, "Document indexed in memory", "document_id", document.ID, "tenant_id", document.TenantID)
+	return nil
+}
+
+// RemoveDocument removes a document from the index of its tenant
+func (i *DocumentIndex) RemoveDocument(ctx context.Context, documentID string, tenantID string) error {
+	if documentID == "" {
+		return errors.NewValidationError("Document ID cannot be empty")
+	}
+	if tenantID == "" {
+		return errors.NewValidationError("Tenant ID cannot be empty")
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	delete(i.documents[tenantID], documentID)
+	return nil
+}
+
+// UpdateMetadata replaces the metadata of indexed documents of a tenant, keeping their content
+func (i *DocumentIndex) UpdateMetadata(ctx context.Context, tenantID string, documents []*models.Document) error {
+	if tenantID == "" {
+		return errors.NewValidationError("Tenant ID cannot be empty")
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	for _, document := range documents {
+		indexed, ok := i.documents[tenantID][document.ID]
+		if !ok {
+			continue
+		}
+		// Replace the document, so searches running concurrently see either version
+		updated := *indexed
+		updated.metadata = metadataWords(document.Metadata)
+		updated.values = metadataValues(document.Metadata)
+		updated.updatedAt = document.UpdatedAt
+		i.documents[tenantID][document.ID] = &updated
+	}
+	return nil
+}
+
+// RemoveTenant removes the index of a tenant
+func (i *DocumentIndex) RemoveTenant(ctx context.Context, tenantID string) error {
+	if tenantID == "" {
+		return errors.NewValidationError("Tenant ID cannot be empty")
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	delete(i.documents, tenantID)
+	return nil
+}
+
+// ExecuteContentSearch returns the documents whose content contains any word of the query, those
+// with the most occurrences first
+func (i *DocumentIndex) ExecuteContentSearch(ctx context.Context, query string, tenantID string, pagination *utils.Pagination) ([]string, int64, error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, 0, errors.NewValidationError("search query cannot be empty")
+	}
+	if tenantID == "" {
+		return nil, 0, errors.NewValidationError("tenant ID cannot be empty")
+	}
+
+	queryWords := words(query)
+	return i.search(tenantID, pagination, func(doc *indexedDocument) (int, bool) {
+		score := doc.contentScore(queryWords)
+		return score, score > 0
+	})
+}
+
+// ExecuteMetadataSearch returns the documents having every metadata key with a value containing
+// any word of the searched value and matching every filter
+func (i *DocumentIndex) ExecuteMetadataSearch(ctx context.Context, metadata map[string]string, filters []models.MetadataFilter, tenantID string, pagination *utils.Pagination) ([]string, int64, error) {
+	if len(metadata) == 0 && len(filters) == 0 {
+		return nil, 0, errors.NewValidationError("metadata search criteria cannot be empty")
+	}
+	if tenantID == "" {
+		return nil, 0, errors.NewValidationError("tenant ID cannot be empty")
+	}
+
+	return i.search(tenantID, pagination, func(doc *indexedDocument) (int, bool) {
+		return 0, doc.matchesMetadata(metadata) && doc.matchesFilters(filters)
+	})
+}
+
+// ExecuteCombinedSearch returns the documents matching both the content query and the metadata
+// and filters, each of which may be empty
+func (i *DocumentIndex) ExecuteCombinedSearch(ctx context.Context, contentQuery string, metadata map[string]string, filters []models.MetadataFilter, tenantID string, pagination *utils.Pagination) ([]string, int64, error) {
+	if strings.TrimSpace(contentQuery) == "" && len(metadata) == 0 && len(filters) == 0 {
+		return nil, 0, errors.NewValidationError("at least one search criteria (content or metadata) must be provided")
+	}
+	if tenantID == "" {
+		return nil, 0, errors.NewValidationError("tenant ID cannot be empty")
+	}
+
+	queryWords := words(contentQuery)
+	return i.search(tenantID, pagination, func(doc *indexedDocument) (int, bool) {
+		if !doc.matchesMetadata(metadata) || !doc.matchesFilters(filters) {
+			return 0, false
+		}
+		if len(queryWords) == 0 {
+			return 0, true
+		}
+		score := doc.contentScore(queryWords)
+		return score, score > 0
+	})
+}
+
+// ExecuteFolderSearch returns the documents of a folder whose content contains any word of the query
+func (i *DocumentIndex) ExecuteFolderSearch(ctx context.Context, folderID string, query string, tenantID string, pagination *utils.Pagination) ([]string, int64, error) {
+	if folderID == "" {
+		return nil, 0, errors.NewValidationError("folder ID cannot be empty")
+	}
+	if strings.TrimSpace(query) == "" {
+		return nil, 0, errors.NewValidationError("search query cannot be empty")
+	}
+	if tenantID == "" {
+		return nil, 0, errors.NewValidationError("tenant ID cannot be empty")
+	}
+
+	queryWords := words(query)
+	return i.search(tenantID, pagination, func(doc *indexedDocument) (int, bool) {
+		if doc.folderID != folderID {
+			return 0, false
+		}
+		score := doc.contentScore(queryWords)
+		return score, score > 0
+	})
+}
+
+// hit is a document matching a search, and its score
+type hit struct {
+	doc   *indexedDocument
+	score int
+}
+
+// compare compares the values of a sort field of two hits, returning -1, 0 or 1
+func (h hit) compare(other hit, field string) int {
+	switch field {
+	case utils.SortFieldRelevance:
+		return compareInts(int64(h.score), int64(other.score))
+	case utils.SortFieldName:
+		return strings.Compare(h.doc.name, other.doc.name)
+	case utils.SortFieldCreatedAt:
+		return compareInts(h.doc.createdAt.UnixNano(), other.doc.createdAt.UnixNano())
+	case utils.SortFieldUpdatedAt:
+		return compareInts(h.doc.updatedAt.UnixNano(), other.doc.updatedAt.UnixNano())
+	case utils.SortFieldSize:
+		return compareInts(h.doc.size, other.doc.size)
+	}
+	return 0
+}
+
+// compareInts compares two integers, returning -1, 0 or 1
+func compareInts(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// search returns a page of the IDs of the tenant's documents that match, ordered by the
+// pagination's sort keys, then by score, then by the most recently updated, and the number of
+// matching documents
+func (i *DocumentIndex) search(tenantID string, pagination *utils.Pagination, match func(*indexedDocument) (int, bool)) ([]string, int64, error) {
+	if pagination == nil {
+		pagination = utils.NewPagination(utils.DefaultPage, utils.DefaultPageSize)
+	}
+
+	i.mu.RLock()
+	hits := make([]hit, 0)
+	for _, doc := range i.documents[tenantID] {
+		if score, ok := match(doc); ok {
+			hits = append(hits, hit{doc: doc, score: score})
+		}
+	}
+	i.mu.RUnlock()
+
+	sort.Slice(hits, func(a, b int) bool {
+		for _, key := range pagination.Sort {
+			if cmp := hits[a].compare(hits[b], key.Field); cmp != 0 {
+				if key.Descending() {
+					return cmp > 0
+				}
+				return cmp < 0
+			}
+		}
+		if hits[a].score != hits[b].score {
+			return hits[a].score > hits[b].score
+		}
+		if !hits[a].doc.updatedAt.Equal(hits[b].doc.updatedAt) {
+			return hits[a].doc.updatedAt.After(hits[b].doc.updatedAt)
+		}
+		return hits[a].doc.id < hits[b].doc.id
+	})
+
+	// The index holds few documents, so its cursors continue from offsets
+	offset, err := pagination.CursorOffset()
+	if err != nil {
+		return nil, 0, errors.NewValidationError(err.Error())
+	}
+	ids := make([]string, 0, pagination.GetLimit())
+	for n := offset; n >= 0 && n < len(hits) && len(ids) < pagination.GetLimit(); n++ {
+		ids = append(ids, hits[n].doc.id)
+	}
+	pagination.SetNextOffset(offset+len(ids), int64(len(hits)))
+	return ids, int64(len(hits)), nil
+}
+
+// contentScore returns the number of occurrences of the words in the content
+func (d *indexedDocument) contentScore(queryWords []string) int {
+	score := 0
+	for _, word := range queryWords {
+		score += d.content[word]
+	}
+	return score
+}
+
+// matchesMetadata checks that the document has every metadata key with a value containing any word
+// of the searched value
+func (d *indexedDocument) matchesMetadata(metadata map[string]string) bool {
+	for key, value := range metadata {
+		valueWords, ok := d.metadata[key]
+		if !ok {
+			return false
+		}
+		found := false
+		for _, word := range words(value) {
+			if valueWords[word] {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesFilters checks that the document's metadata matches every filter
+func (d *indexedDocument) matchesFilters(filters []models.MetadataFilter) bool {
+	for n := range filters {
+		value, ok := d.values[filters[n].Key]
+		if !filters[n].Matches(value, ok) {
+			return false
+		}
+	}
+	return true
+}
+
+// metadataValues returns the value of each metadata key
+func metadataValues(metadata []models.DocumentMetadata) map[string]string {
+	values := make(map[string]string, len(metadata))
+	for _, m := range metadata {
+		values[m.Key] = m.Value
+	}
+	return values
+}
+
+// metadataWords returns the words of the value of each metadata key
+func metadataWords(metadata []models.DocumentMetadata) map[string]map[string]bool {
+	valueWords := make(map[string]map[string]bool)
+	for _, m := range metadata {
+		if valueWords[m.Key] == nil {
+			valueWords[m.Key] = make(map[string]bool)
+		}
+		for _, word := range words(m.Value) {
+			valueWords[m.Key][word] = true
+		}
+	}
+	return valueWords
+}
+
+// words splits text into lowercase words at anything that is not a letter or a digit
+func words(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// extractText returns the searchable text of content, for the content types Elasticsearch indexes
+// the text of
+func extractText(content []byte, contentType string) string {
+	if strings.HasPrefix(contentType, "text/") ||
+		contentType == "application/pdf" ||
+		strings.Contains(contentType, "office") ||
+		strings.Contains(contentType, "word") ||
+		strings.Contains(contentType, "excel") ||
+		strings.Contains(contentType, "powerpoint") {
+		return string(content)
+	}
+	return ""
+}
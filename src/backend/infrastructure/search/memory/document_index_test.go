@@ -0,0 +1,207 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"../../../domain/models"
+	"../../../pkg/errors"
+	"../../../pkg/utils"
+)
+
+// newTestIndex creates an index with documents of two tenants
+func newTestIndex(t *testing.T) *DocumentIndex {
+	ctx := context.Background()
+	now := time.Now()
+	index := NewDocumentIndex()
+
+	documents := []struct {
+		document models.Document
+		content  string
+	}{
+		{models.Document{ID: "doc-1", TenantID: "tenant-1", FolderID: "folder-1", ContentType: "text/plain", UpdatedAt: now,
+			Metadata: []models.DocumentMetadata{{Key: "department", Value: "Finance"}}}, "Quarterly budget report. Budget approved."},
+		{models.Document{ID: "doc-2", TenantID: "tenant-1", FolderID: "folder-2", ContentType: "text/plain", UpdatedAt: now.Add(time.Minute),
+			Metadata: []models.DocumentMetadata{{Key: "department", Value: "Human Resources"}}}, "Holiday budget"},
+		{models.Document{ID: "doc-3", TenantID: "tenant-1", FolderID: "folder-1", ContentType: "image/png", UpdatedAt: now}, "budget"},
+		{models.Document{ID: "doc-4", TenantID: "tenant-2", FolderID: "folder-9", ContentType: "text/plain", UpdatedAt: now}, "budget"},
+	}
+	for _, d := range documents {
+		document := d.document
+		require.NoError(t, index.IndexDocument(ctx, &document, []byte(d.content)))
+	}
+	return index
+}
+
+// TestDocumentIndex_ExecuteContentSearch tests that documents are matched by word within their tenant
+// and ordered by the occurrences of the words
+func TestDocumentIndex_ExecuteContentSearch(t *testing.T) {
+	ctx := context.Background()
+	index := newTestIndex(t)
+
+	ids, total, err := index.ExecuteContentSearch(ctx, "BUDGET", "tenant-1", nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"doc-1", "doc-2"}, ids)
+	assert.Equal(t, int64(2), total)
+
+	// Pages are taken from the ordered matches, and the total counts all of them
+	ids, total, err = index.ExecuteContentSearch(ctx, "budget", "tenant-1", utils.NewPagination(2, 1))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"doc-2"}, ids)
+	assert.Equal(t, int64(2), total)
+
+	ids, _, err = index.ExecuteFolderSearch(ctx, "folder-2", "budget", "tenant-1", nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"doc-2"}, ids)
+
+	require.NoError(t, index.RemoveDocument(ctx, "doc-1", "tenant-1"))
+	ids, _, err = index.ExecuteContentSearch(ctx, "budget", "tenant-1", nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"doc-2"}, ids)
+
+	_, _, err = index.ExecuteContentSearch(ctx, " ", "tenant-1", nil)
+	assert.Error(t, err)
+}
+
+// TestDocumentIndex_SearchSort tests that matches are ordered by the sort keys of the pagination
+func TestDocumentIndex_SearchSort(t *testing.T) {
+	ctx := context.Background()
+	index := newTestIndex(t)
+
+	// The most recently updated first, whatever the occurrences of the words
+	pagination := utils.NewPagination(1, 10).WithSort([]utils.SortKey{{Field: utils.SortFieldUpdatedAt, Direction: utils.SortDescending}})
+	ids, _, err := index.ExecuteContentSearch(ctx, "budget", "tenant-1", pagination)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"doc-2", "doc-1"}, ids)
+
+	// The fewest occurrences first
+	pagination = utils.NewPagination(1, 10).WithSort([]utils.SortKey{{Field: utils.SortFieldRelevance, Direction: utils.SortAscending}})
+	ids, _, err = index.ExecuteContentSearch(ctx, "budget", "tenant-1", pagination)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"doc-2", "doc-1"}, ids)
+}
+
+// TestDocumentIndex_SearchCursor tests continuing a search from the cursor of the previous page
+func TestDocumentIndex_SearchCursor(t *testing.T) {
+	ctx := context.Background()
+	index := newTestIndex(t)
+
+	pagination := utils.NewPagination(1, 1)
+	ids, _, err := index.ExecuteContentSearch(ctx, "budget", "tenant-1", pagination)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"doc-1"}, ids)
+	require.NotEmpty(t, pagination.NextCursor)
+
+	next := utils.NewPagination(1, 1).WithCursor(pagination.NextCursor)
+	ids, _, err = index.ExecuteContentSearch(ctx, "budget", "tenant-1", next)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"doc-2"}, ids)
+	assert.Empty(t, next.NextCursor)
+
+	_, _, err = index.ExecuteContentSearch(ctx, "budget", "tenant-1", utils.NewPagination(1, 1).WithCursor("not-a-cursor"))
+	assert.True(t, errors.IsValidationError(err))
+}
+
+// TestDocumentIndex_ExecuteMetadataSearch tests matching metadata values by word, alone and
+// combined with a content query
+func TestDocumentIndex_ExecuteMetadataSearch(t *testing.T) {
+	ctx := context.Background()
+	index := newTestIndex(t)
+
+	ids, _, err := index.ExecuteMetadataSearch(ctx, map[string]string{"department": "resources"}, nil, "tenant-1", nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"doc-2"}, ids)
+
+	ids, _, err = index.ExecuteMetadataSearch(ctx, map[string]string{"owner": "finance"}, nil, "tenant-1", nil)
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+
+	ids, _, err = index.ExecuteCombinedSearch(ctx, "report", map[string]string{"department": "finance"}, nil, "tenant-1", nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"doc-1"}, ids)
+
+	ids, _, err = index.ExecuteCombinedSearch(ctx, "holiday", map[string]string{"department": "finance"}, nil, "tenant-1", nil)
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+}
+
+// TestDocumentIndex_ExecuteMetadataSearchFilters tests comparing metadata values by the type of
+// their field, and that values not of that type never match
+func TestDocumentIndex_ExecuteMetadataSearchFilters(t *testing.T) {
+	ctx := context.Background()
+	index := NewDocumentIndex()
+	for id, amount := range map[string]string{"doc-1": "9.5", "doc-2": "120", "doc-3": "n/a"} {
+		document := models.Document{ID: id, TenantID: "tenant-1", ContentType: "text/plain",
+			Metadata: []models.DocumentMetadata{{Key: "amount", Value: amount}, {Key: "vendor", Value: "Acme " + id}}}
+		require.NoError(t, index.IndexDocument(ctx, &document, []byte("invoice")))
+	}
+
+	amountAbove := []models.MetadataFilter{{Key: "amount", Operator: models.MetadataFilterGt, Value: "10", Type: models.MetadataFieldTypeNumber}}
+	ids, _, err := index.ExecuteMetadataSearch(ctx, nil, amountAbove, "tenant-1", nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"doc-2"}, ids)
+
+	amountBetween := []models.MetadataFilter{{Key: "amount", Operator: models.MetadataFilterBetween, Values: []string{"1", "10"}, Type: models.MetadataFieldTypeNumber}}
+	ids, _, err = index.ExecuteCombinedSearch(ctx, "invoice", nil, amountBetween, "tenant-1", nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"doc-1"}, ids)
+
+	vendorPrefix := []models.MetadataFilter{
+		{Key: "vendor", Operator: models.MetadataFilterPrefix, Value: "Acme doc-", Type: models.MetadataFieldTypeString},
+		{Key: "currency", Operator: models.MetadataFilterExists, Type: models.MetadataFieldTypeString},
+	}
+	ids, _, err = index.ExecuteMetadataSearch(ctx, nil, vendorPrefix, "tenant-1", nil)
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+}
+
+// TestDocumentIndex_RemoveTenant tests that removing a tenant leaves the documents of other tenants
+func TestDocumentIndex_RemoveTenant(t *testing.T) {
+	ctx := context.Background()
+	index := newTestIndex(t)
+
+	require.NoError(t, index.RemoveTenant(ctx, "tenant-1"))
+
+	ids, _, err := index.ExecuteContentSearch(ctx, "budget", "tenant-1", nil)
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+
+	ids, _, err = index.ExecuteContentSearch(ctx, "budget", "tenant-2", nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"doc-4"}, ids)
+
+	assert.Error(t, index.RemoveTenant(ctx, ""))
+}
+
+// TestDocumentIndex_UpdateMetadata tests that updated metadata replaces the indexed metadata while
+// the content stays searchable, and that documents not indexed yet are skipped
+func TestDocumentIndex_UpdateMetadata(t *testing.T) {
+	ctx := context.Background()
+	index := newTestIndex(t)
+
+	documents := []*models.Document{
+		{ID: "doc-1", TenantID: "tenant-1", UpdatedAt: time.Now(),
+			Metadata: []models.DocumentMetadata{{Key: "department", Value: "Legal"}}},
+		{ID: "doc-9", TenantID: "tenant-1", UpdatedAt: time.Now(),
+			Metadata: []models.DocumentMetadata{{Key: "department", Value: "Legal"}}},
+	}
+	require.NoError(t, index.UpdateMetadata(ctx, "tenant-1", documents))
+
+	ids, _, err := index.ExecuteMetadataSearch(ctx, map[string]string{"department": "finance"}, nil, "tenant-1", nil)
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+
+	ids, _, err = index.ExecuteMetadataSearch(ctx, map[string]string{"department": "legal"}, nil, "tenant-1", nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"doc-1"}, ids)
+
+	ids, _, err = index.ExecuteContentSearch(ctx, "approved", "tenant-1", nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"doc-1"}, ids)
+
+	assert.Error(t, index.UpdateMetadata(ctx, "", documents))
+}
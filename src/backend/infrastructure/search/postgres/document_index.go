@@ -0,0 +1,509 @@
+// Package postgres provides a PostgreSQL full-text search implementation of the search interfaces,
+// for small deployments that do not want to run Elasticsearch. The words of document content are
+// indexed as a tsvector with English stemming like the Elasticsearch content analyzer, and the
+// words of metadata values as key:word terms; both are searched through GIN indexes.
+package postgres
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode"
+
+	"gorm.io/gorm"
+
+	"../../../domain/models"
+	"../../../domain/services"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+	"../../../pkg/utils"
+	persistence "../../persistence/postgres"
+)
+
+// maxIndexedContentBytes limits the content text indexed per document, keeping its tsvector well
+// under the 1MB PostgreSQL allows
+const maxIndexedContentBytes = 512 * 1024
+
+// DocumentIndex implements services.SearchIndexer and services.SearchQueryExecutor on the
+// document_search table
+type DocumentIndex struct{}
+
+// NewDocumentIndex creates a DocumentIndex using the database of the persistence layer
+func NewDocumentIndex() *DocumentIndex {
+	return &DocumentIndex{}
+}
+
+// Ensure DocumentIndex implements services.SearchIndexer, services.SearchQueryExecutor,
+// services.SearchIndexRebuilder, services.SearchTenantRemover and services.SearchMetadataUpdater
+var (
+	_ services.SearchIndexer         = (*DocumentIndex)(nil)
+	_ services.SearchQueryExecutor   = (*DocumentIndex)(nil)
+	_ services.SearchIndexRebuilder  = (*DocumentIndex)(nil)
+	_ services.SearchTenantRemover   = (*DocumentIndex)(nil)
+	_ services.SearchMetadataUpdater = (*DocumentIndex)(nil)
+)
+
+// IndexDocument indexes a document, replacing the document when it was indexed before
+func (i *DocumentIndex) IndexDocument(ctx context.Context, document *models.Document, content []byte) error {
+	if document == nil {
+		return errors.NewValidationError("Document cannot be nil")
+	}
+	if len(content) == 0 {
+		return errors.NewValidationError("Document content cannot be empty")
+	}
+
+	db, err := persistence.GetDBFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	updatedAt := document.UpdatedAt
+	if updatedAt.IsZero() {
+		updatedAt = time.Now()
+	}
+
+	err = db.Exec(`INSERT INTO document_search (document_id, tenant_id, folder_id, content_vector, metadata_terms, updated_at, indexed_at)
+		VALUES (?, ?, ?, to_tsvector('english', ?), ?, ?, NOW())
+		ON CONFLICT (document_id) DO UPDATE SET
+			tenant_id = EXCLUDED.tenant_id,
+			folder_id = EXCLUDED.folder_id,
+			content_vector = EXCLUDED.content_vector,
+			metadata_terms = EXCLUDED.metadata_terms,
+			updated_at = EXCLUDED.updated_at,
+			indexed_at = EXCLUDED.indexed_at`,
+		document.ID, document.TenantID, document.FolderID,
+		contentText(content, document.ContentType), documentMetadataTerms(document.Metadata), updatedAt).Error
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to index document", "error", err, "document_id", document.ID, "tenant_id", document.TenantID)
+		return errors.NewDependencyError("Failed to index document: " + err.Error())
+	}
+
+	logger.InfoContext(ctx, "Document indexed in PostgreSQL", "document_id", document.ID, "tenant_id", document.TenantID)
+	return nil
+}
+
+// RemoveDocument removes a document from the index
+func (i *DocumentIndex) RemoveDocument(ctx context.Context, documentID string, tenantID string) error {
+	if documentID == "" {
+		return errors.NewValidationError("Document ID cannot be empty")
+	}
+	if tenantID == "" {
+		return errors.NewValidationError("Tenant ID cannot be empty")
+	}
+
+	db, err := persistence.GetDBFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := db.Exec("DELETE FROM document_search WHERE document_id = ? AND tenant_id = ?", documentID, tenantID).Error; err != nil {
+		logger.ErrorContext(ctx, "Failed to remove document from index", "error", err, "document_id", documentID, "tenant_id", tenantID)
+		return errors.NewDependencyError("Failed to remove document from index: " + err.Error())
+	}
+	return nil
+}
+
+// UpdateMetadata replaces the metadata terms of indexed documents of a tenant in one transaction,
+// keeping their content vectors
+func (i *DocumentIndex) UpdateMetadata(ctx context.Context, tenantID string, documents []*models.Document) error {
+	if tenantID == "" {
+		return errors.NewValidationError("Tenant ID cannot be empty")
+	}
+
+	db, err := persistence.GetDBFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		for _, document := range documents {
+			updatedAt := document.UpdatedAt
+			if updatedAt.IsZero() {
+				updatedAt = time.Now()
+			}
+
+			// Documents not indexed yet update no row
+			if err := tx.Exec("UPDATE document_search SET metadata_terms = ?, updated_at = ? WHERE document_id = ? AND tenant_id = ?",
+				documentMetadataTerms(document.Metadata), updatedAt, document.ID, tenantID).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to update indexed metadata", "error", err, "documents", len(documents), "tenant_id", tenantID)
+		return errors.NewDependencyError("Failed to update indexed metadata: " + err.Error())
+	}
+	return nil
+}
+
+// RemoveTenant removes all documents of a tenant from the index
+func (i *DocumentIndex) RemoveTenant(ctx context.Context, tenantID string) error {
+	if tenantID == "" {
+		return errors.NewValidationError("Tenant ID cannot be empty")
+	}
+
+	db, err := persistence.GetDBFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := db.Exec("DELETE FROM document_search WHERE tenant_id = ?", tenantID).Error; err != nil {
+		logger.ErrorContext(ctx, "Failed to remove tenant from index", "error", err, "tenant_id", tenantID)
+		return errors.NewDependencyError("Failed to remove tenant from index: " + err.Error())
+	}
+	return nil
+}
+
+// BeginRebuild starts rebuilding the index of a tenant. The rows of the tenant are replaced in
+// place, so searches keep finding the documents throughout the rebuild.
+func (i *DocumentIndex) BeginRebuild(ctx context.Context, tenantID string, rebuildID string) (services.SearchIndexRebuild, error) {
+	if tenantID == "" {
+		return nil, errors.NewValidationError("Tenant ID cannot be empty")
+	}
+
+	db, err := persistence.GetDBFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Use the clock of the database, which sets indexed_at
+	var startedAt time.Time
+	if err := db.Raw("SELECT NOW()").Scan(&startedAt).Error; err != nil {
+		return nil, errors.NewDependencyError("Failed to begin index rebuild: " + err.Error())
+	}
+
+	return &indexRebuild{index: i, tenantID: tenantID, startedAt: startedAt}, nil
+}
+
+// indexRebuild is a rebuild of the rows of a tenant in the document_search table
+type indexRebuild struct {
+	index     *DocumentIndex
+	tenantID  string
+	startedAt time.Time
+}
+
+// IndexDocument indexes a document, replacing its row
+func (r *indexRebuild) IndexDocument(ctx context.Context, document *models.Document, content []byte) error {
+	if document != nil && document.TenantID != r.tenantID {
+		return errors.NewValidationError("Document does not belong to the rebuilt tenant")
+	}
+	return r.index.IndexDocument(ctx, document, content)
+}
+
+// Commit removes the rows of the tenant that were not indexed since the rebuild began, which
+// belong to documents that no longer exist or have no content to index
+func (r *indexRebuild) Commit(ctx context.Context) error {
+	db, err := persistence.GetDBFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := db.Exec("DELETE FROM document_search WHERE tenant_id = ? AND indexed_at < ?", r.tenantID, r.startedAt).Error; err != nil {
+		logger.ErrorContext(ctx, "Failed to remove stale rows from index", "error", err, "tenant_id", r.tenantID)
+		return errors.NewDependencyError("Failed to commit index rebuild: " + err.Error())
+	}
+	return nil
+}
+
+// Abort leaves the rows as they are; the rows indexed so far are as current as the ones they replaced
+func (r *indexRebuild) Abort(ctx context.Context) error {
+	return nil
+}
+
+// ExecuteContentSearch returns the documents whose content contains any word of the query, the
+// best matches first
+func (i *DocumentIndex) ExecuteContentSearch(ctx context.Context, query string, tenantID string, pagination *utils.Pagination) ([]string, int64, error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, 0, errors.NewValidationError("search query cannot be empty")
+	}
+	if tenantID == "" {
+		return nil, 0, errors.NewValidationError("tenant ID cannot be empty")
+	}
+
+	s := newSearch(tenantID)
+	s.matchContent(query)
+	return s.execute(ctx, pagination)
+}
+
+// ExecuteMetadataSearch returns the documents having every metadata key with a value containing
+// any word of the searched value and matching every filter
+func (i *DocumentIndex) ExecuteMetadataSearch(ctx context.Context, metadata map[string]string, filters []models.MetadataFilter, tenantID string, pagination *utils.Pagination) ([]string, int64, error) {
+	if len(metadata) == 0 && len(filters) == 0 {
+		return nil, 0, errors.NewValidationError("metadata search criteria cannot be empty")
+	}
+	if tenantID == "" {
+		return nil, 0, errors.NewValidationError("tenant ID cannot be empty")
+	}
+
+	s := newSearch(tenantID)
+	s.matchMetadata(metadata)
+	s.matchFilters(filters)
+	return s.execute(ctx, pagination)
+}
+
+// ExecuteCombinedSearch returns the documents matching both the content query and the metadata
+// and filters, each of which may be empty
+func (i *DocumentIndex) ExecuteCombinedSearch(ctx context.Context, contentQuery string, metadata map[string]string, filters []models.MetadataFilter, tenantID string, pagination *utils.Pagination) ([]string, int64, error) {
+	if strings.TrimSpace(contentQuery) == "" && len(metadata) == 0 && len(filters) == 0 {
+		return nil, 0, errors.NewValidationError("at least one search criteria (content or metadata) must be provided")
+	}
+	if tenantID == "" {
+		return nil, 0, errors.NewValidationError("tenant ID cannot be empty")
+	}
+
+	s := newSearch(tenantID)
+	if strings.TrimSpace(contentQuery) != "" {
+		s.matchContent(contentQuery)
+	}
+	s.matchMetadata(metadata)
+	s.matchFilters(filters)
+	return s.execute(ctx, pagination)
+}
+
+// ExecuteFolderSearch returns the documents of a folder whose content contains any word of the query
+func (i *DocumentIndex) ExecuteFolderSearch(ctx context.Context, folderID string, query string, tenantID string, pagination *utils.Pagination) ([]string, int64, error) {
+	if folderID == "" {
+		return nil, 0, errors.NewValidationError("folder ID cannot be empty")
+	}
+	if strings.TrimSpace(query) == "" {
+		return nil, 0, errors.NewValidationError("search query cannot be empty")
+	}
+	if tenantID == "" {
+		return nil, 0, errors.NewValidationError("tenant ID cannot be empty")
+	}
+
+	s := newSearch(tenantID)
+	s.conditions = append(s.conditions, "folder_id = ?")
+	s.args = append(s.args, folderID)
+	s.matchContent(query)
+	return s.execute(ctx, pagination)
+}
+
+// search builds a query of the document_search table of a tenant
+type search struct {
+	conditions []string
+	args       []interface{}
+	tsQuery    string // Content query the results are ranked by; empty when not searching content
+}
+
+// newSearch creates a search of the documents of a tenant
+func newSearch(tenantID string) *search {
+	return &search{
+		conditions: []string{"tenant_id = ?"},
+		args:       []interface{}{tenantID},
+	}
+}
+
+// matchContent restricts the search to documents whose content contains any word of query
+func (s *search) matchContent(query string) {
+	s.tsQuery = contentQuery(query)
+	s.conditions = append(s.conditions, "content_vector @@ to_tsquery('english', ?)")
+	s.args = append(s.args, s.tsQuery)
+}
+
+// matchMetadata restricts the search to documents having every metadata key with a value
+// containing any word of the searched value
+func (s *search) matchMetadata(metadata map[string]string) {
+	for key, value := range metadata {
+		s.conditions = append(s.conditions, "metadata_terms && ?")
+		s.args = append(s.args, metadataTerms(key, value))
+	}
+}
+
+// metadataValueExpressions are the SQL expressions comparing the value of a metadata key by field
+// type. The functions of migration 29 return NULL for values not of the type, which never match.
+var metadataValueExpressions = map[string]string{
+	models.MetadataFieldTypeNumber: "metadata_number(m.value)",
+	models.MetadataFieldTypeDate:   "metadata_date(m.value)",
+	models.MetadataFieldTypeString: "m.value",
+}
+
+// matchFilters restricts the search to documents whose metadata matches every filter. The values
+// are read from the document_metadata table, which the metadata terms only hold the words of.
+func (s *search) matchFilters(filters []models.MetadataFilter) {
+	for _, filter := range filters {
+		value := metadataValueExpressions[filter.Type]
+		if value == "" {
+			value = metadataValueExpressions[models.MetadataFieldTypeString]
+		}
+
+		condition := ""
+		args := []interface{}{filter.Key}
+		switch filter.Operator {
+		case models.MetadataFilterExists:
+		case models.MetadataFilterPrefix:
+			// starts_with needs no escaping of the LIKE wildcards
+			condition = " AND starts_with(m.value, ?)"
+			args = append(args, filter.Value)
+		case models.MetadataFilterBetween:
+			condition = " AND " + value + " BETWEEN ? AND ?"
+			args = append(args, filterValue(filter.Type, filter.Values[0]), filterValue(filter.Type, filter.Values[1]))
+		default:
+			condition = " AND " + value + " " + filterOperators[filter.Operator] + " ?"
+			args = append(args, filterValue(filter.Type, filter.Value))
+		}
+
+		s.conditions = append(s.conditions, "EXISTS (SELECT 1 FROM document_metadata m WHERE m.document_id = document_search.document_id AND m.key = ?"+condition+")")
+		s.args = append(s.args, args...)
+	}
+}
+
+// filterOperators are the SQL operators of the comparison filter operators
+var filterOperators = map[string]string{
+	models.MetadataFilterEq:  "=",
+	models.MetadataFilterGt:  ">",
+	models.MetadataFilterGte: ">=",
+	models.MetadataFilterLt:  "<",
+	models.MetadataFilterLte: "<=",
+}
+
+// filterValue returns a value a filter compares to as the type of its field; resolved filters
+// only hold values of their type
+func filterValue(fieldType string, value string) interface{} {
+	switch fieldType {
+	case models.MetadataFieldTypeNumber:
+		number, _ := models.ParseMetadataNumber(value)
+		return number
+	case models.MetadataFieldTypeDate:
+		date, _ := models.ParseMetadataDate(value)
+		return date
+	}
+	return value
+}
+
+// rankExpression ranks the documents by how well their content matches the content query
+const rankExpression = "ts_rank(content_vector, to_tsquery('english', ?))"
+
+// searchSortColumns are the SQL expressions search results are sorted by for each sort field. The
+// fields the document_search table lacks are read from the documents table.
+var searchSortColumns = map[string]string{
+	utils.SortFieldName:      "(SELECT d.name FROM documents d WHERE d.id = document_search.document_id)",
+	utils.SortFieldCreatedAt: "(SELECT d.created_at FROM documents d WHERE d.id = document_search.document_id)",
+	utils.SortFieldUpdatedAt: "updated_at",
+	utils.SortFieldSize:      "(SELECT d.size FROM documents d WHERE d.id = document_search.document_id)",
+}
+
+// sortColumns returns the SQL expressions the results of the search can be sorted by; results
+// only have a relevance when searching content
+func (s *search) sortColumns() map[string]string {
+	if s.tsQuery == "" {
+		return searchSortColumns
+	}
+	columns := make(map[string]string, len(searchSortColumns)+1)
+	for field, column := range searchSortColumns {
+		columns[field] = column
+	}
+	columns[utils.SortFieldRelevance] = rankExpression
+	return columns
+}
+
+// execute returns a page of the IDs of the matching documents, and the number of matching
+// documents. They are ordered by the pagination's sort keys, then the best content matches
+// first, then the most recently updated.
+func (s *search) execute(ctx context.Context, pagination *utils.Pagination) ([]string, int64, error) {
+	if pagination == nil {
+		pagination = utils.NewPagination(utils.DefaultPage, utils.DefaultPageSize)
+	}
+
+	db, err := persistence.GetDBFromContext(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	where := strings.Join(s.conditions, " AND ")
+
+	var total int64
+	if err := db.Raw("SELECT COUNT(*) FROM document_search WHERE "+where, s.args...).Scan(&total).Error; err != nil {
+		return nil, 0, errors.NewDependencyError("Failed to count search results: " + err.Error())
+	}
+
+	order := "updated_at DESC, document_id"
+	if s.tsQuery != "" {
+		order = rankExpression + " DESC, " + order
+	}
+	if sorted := persistence.OrderClause(pagination.Sort, s.sortColumns()); sorted != "" {
+		order = sorted + ", " + order
+	}
+
+	// The only placeholders of the order are those of the rank expressions
+	args := append([]interface{}{}, s.args...)
+	for n := strings.Count(order, "?"); n > 0; n-- {
+		args = append(args, s.tsQuery)
+	}
+
+	// Results are ranked by expressions seeking cannot use, so cursors continue from offsets
+	offset, err := pagination.CursorOffset()
+	if err != nil {
+		return nil, 0, errors.NewValidationError(err.Error())
+	}
+	args = append(args, pagination.GetLimit(), offset)
+
+	ids := make([]string, 0)
+	if err := db.Raw("SELECT document_id FROM document_search WHERE "+where+" ORDER BY "+order+" LIMIT ? OFFSET ?", args...).Scan(&ids).Error; err != nil {
+		return nil, 0, errors.NewDependencyError("Failed to search documents: " + err.Error())
+	}
+	pagination.SetNextOffset(offset+len(ids), total)
+
+	return ids, total, nil
+}
+
+// contentQuery returns the tsquery matching any word of query. The words hold only letters and
+// digits, so they need no quoting.
+func contentQuery(query string) string {
+	return strings.Join(words(query), " | ")
+}
+
+// contentText returns the words of the searchable text of content, for the content types
+// Elasticsearch indexes the text of. Joining the words drops the NUL bytes and invalid UTF-8
+// PostgreSQL rejects in text.
+func contentText(content []byte, contentType string) string {
+	if !strings.HasPrefix(contentType, "text/") &&
+		contentType != "application/pdf" &&
+		!strings.Contains(contentType, "office") &&
+		!strings.Contains(contentType, "word") &&
+		!strings.Contains(contentType, "excel") &&
+		!strings.Contains(contentType, "powerpoint") {
+		return ""
+	}
+
+	text := strings.Join(words(string(content)), " ")
+	if len(text) > maxIndexedContentBytes {
+		// Cut at a space, so no word or character is split
+		text = text[:strings.LastIndexByte(text[:maxIndexedContentBytes], ' ')+1]
+	}
+	return text
+}
+
+// documentMetadataTerms returns the key:word terms of the metadata of a document
+func documentMetadataTerms(metadata []models.DocumentMetadata) []string {
+	terms := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, m := range metadata {
+		for _, term := range metadataTerms(m.Key, m.Value) {
+			if !seen[term] {
+				seen[term] = true
+				terms = append(terms, term)
+			}
+		}
+	}
+	return terms
+}
+
+// metadataTerms returns a key:word term for each word of value. The words hold no colon, so the
+// key of a term is unambiguous.
+func metadataTerms(key, value string) []string {
+	valueWords := words(value)
+	terms := make([]string, len(valueWords))
+	for n, word := range valueWords {
+		terms[n] = key + ":" + word
+	}
+	return terms
+}
+
+// words splits text into lowercase words at anything that is not a letter or a digit
+func words(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
@@ -0,0 +1,104 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"../../../domain/models"
+	"../../../pkg/utils"
+)
+
+// TestContentQuery tests that the tsquery matches any word of the query, without the punctuation
+// tsquery would parse as operators
+func TestContentQuery(t *testing.T) {
+	assert.Equal(t, "quarterly | report | 2024", contentQuery("Quarterly report: 2024!"))
+	assert.Equal(t, "o | brien", contentQuery("O'Brien & (!"))
+	assert.Equal(t, "", contentQuery("&|!"))
+}
+
+// TestContentText tests extracting the indexed words of document content
+func TestContentText(t *testing.T) {
+	assert.Equal(t, "hello world", contentText([]byte("Hello,\x00 World\xff"), "text/plain"))
+	assert.Equal(t, "", contentText([]byte("PNG image"), "image/png"))
+
+	// Long content is cut at a word boundary
+	long := contentText([]byte(strings.Repeat("word ", maxIndexedContentBytes)), "text/plain")
+	assert.LessOrEqual(t, len(long), maxIndexedContentBytes)
+	assert.True(t, strings.HasSuffix(long, "word "))
+}
+
+// TestDocumentMetadataTerms tests the key:word terms of document metadata
+func TestDocumentMetadataTerms(t *testing.T) {
+	terms := documentMetadataTerms([]models.DocumentMetadata{
+		{Key: "department", Value: "Finance and Legal"},
+		{Key: "project", Value: "finance-2024"},
+		{Key: "department", Value: "finance"},
+	})
+
+	assert.Equal(t, []string{
+		"department:finance", "department:and", "department:legal",
+		"project:finance", "project:2024",
+	}, terms)
+	assert.Empty(t, metadataTerms("department", "--"))
+}
+
+// TestSearch_matchFilters tests that filters compare the metadata values as the type of their field
+func TestSearch_matchFilters(t *testing.T) {
+	s := newSearch("tenant-1")
+	s.matchFilters([]models.MetadataFilter{
+		{Key: "amount", Operator: models.MetadataFilterGte, Value: "10.5", Type: models.MetadataFieldTypeNumber},
+		{Key: "due", Operator: models.MetadataFilterBetween, Values: []string{"2024-01-01", "2024-12-31"}, Type: models.MetadataFieldTypeDate},
+		{Key: "vendor", Operator: models.MetadataFilterExists, Type: models.MetadataFieldTypeString},
+	})
+
+	require.Len(t, s.conditions, 4)
+	assert.Contains(t, s.conditions[1], "metadata_number(m.value) >= ?")
+	assert.Contains(t, s.conditions[2], "metadata_date(m.value) BETWEEN ? AND ?")
+	assert.NotContains(t, s.conditions[3], "m.value")
+	assert.Equal(t, []interface{}{
+		"tenant-1",
+		"amount", 10.5,
+		"due", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC),
+		"vendor",
+	}, s.args)
+}
+
+// TestSearch_sortColumns tests that results can only be sorted by relevance when searching content
+func TestSearch_sortColumns(t *testing.T) {
+	s := newSearch("tenant-1")
+	s.matchMetadata(map[string]string{"department": "finance"})
+	assert.NotContains(t, s.sortColumns(), utils.SortFieldRelevance)
+
+	s.matchContent("budget report")
+	assert.Equal(t, rankExpression, s.sortColumns()[utils.SortFieldRelevance])
+	assert.NotContains(t, searchSortColumns, utils.SortFieldRelevance)
+}
+
+// TestDocumentIndex_validation tests that invalid searches are rejected before querying the database
+func TestDocumentIndex_validation(t *testing.T) {
+	index := NewDocumentIndex()
+	ctx := context.Background()
+
+	_, _, err := index.ExecuteContentSearch(ctx, " ", "tenant-1", nil)
+	assert.Error(t, err)
+	_, _, err = index.ExecuteMetadataSearch(ctx, nil, nil, "tenant-1", nil)
+	assert.Error(t, err)
+	_, _, err = index.ExecuteCombinedSearch(ctx, "", nil, nil, "tenant-1", nil)
+	assert.Error(t, err)
+	_, _, err = index.ExecuteFolderSearch(ctx, "", "report", "tenant-1", nil)
+	assert.Error(t, err)
+	assert.Error(t, index.RemoveDocument(ctx, "doc-1", ""))
+	assert.Error(t, index.RemoveTenant(ctx, ""))
+	assert.Error(t, index.UpdateMetadata(ctx, "", []*models.Document{{ID: "doc-1"}}))
+	assert.Error(t, index.IndexDocument(ctx, &models.Document{ID: "doc-1"}, nil))
+	_, err = index.BeginRebuild(ctx, "", "rebuild-1")
+	assert.Error(t, err)
+
+	rebuild := &indexRebuild{index: index, tenantID: "tenant-1"}
+	assert.Error(t, rebuild.IndexDocument(ctx, &models.Document{ID: "doc-1", TenantID: "tenant-2"}, []byte("content")))
+}
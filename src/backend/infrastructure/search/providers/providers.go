@@ -0,0 +1,73 @@
+// Package providers creates the search index selected by the configuration. It is separate from
+// the drivers so that each deployment only configures the search index it runs on.
+package providers
+
+import (
+	"context"
+	"fmt"
+
+	"../../../domain/services"
+	"../../../pkg/config"
+	"../elasticsearch"
+	"../memory"
+	"../postgres"
+)
+
+// New creates the indexer and query executor of the search index selected by cfg.Search.Provider,
+// defaulting to Elasticsearch 8. Elasticsearch compatible indices type the metadata fields of the
+// schemas of metadataSchemas; it may be nil to index metadata as text only.
+func New(ctx context.Context, cfg config.Config, metadataSchemas services.MetadataSchemaService) (services.SearchIndexer, services.SearchQueryExecutor, error) {
+	switch cfg.Search.Provider {
+	case "", services.SearchProviderElasticsearch:
+		client, err := elasticsearch.NewElasticsearchClient(cfg.Elasticsearch)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to initialize Elasticsearch client: %w", err)
+		}
+		return newElasticsearch(client, cfg.Elasticsearch, metadataSchemas)
+	case services.SearchProviderElasticsearch7:
+		client, err := elasticsearch.NewElasticsearch7Client(cfg.Elasticsearch)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to initialize Elasticsearch 7 client: %w", err)
+		}
+		return newElasticsearch(client, cfg.Elasticsearch, metadataSchemas)
+	case services.SearchProviderOpenSearch:
+		if cfg.Elasticsearch.ILMPolicy != "" {
+			return nil, nil, fmt.Errorf("OpenSearch does not support ILM policies; attach an ISM policy with an ism_template instead")
+		}
+		client, err := elasticsearch.NewOpenSearchClient(ctx, cfg.Elasticsearch, cfg.Search.OpenSearch)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to initialize OpenSearch client: %w", err)
+		}
+		return newElasticsearch(client, cfg.Elasticsearch, metadataSchemas)
+	case services.SearchProviderPostgres:
+		index := postgres.NewDocumentIndex()
+		return index, index, nil
+	case services.SearchProviderMemory:
+		index := memory.NewDocumentIndex()
+		return index, index, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported search provider: %s", cfg.Search.Provider)
+	}
+}
+
+// newElasticsearch creates the indexer and query executor of the Elasticsearch compatible backend
+func newElasticsearch(client elasticsearch.SearchBackend, cfg config.ElasticsearchConfig, metadataSchemas services.MetadataSchemaService) (services.SearchIndexer, services.SearchQueryExecutor, error) {
+	documentIndex, err := elasticsearch.NewDocumentIndex(client, cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to initialize document index: %w", err)
+	}
+	if metadataSchemas != nil {
+		documentIndex.SetMetadataSchemas(metadataSchemas)
+	}
+
+	indexer, err := elasticsearch.NewElasticsearchIndexer(documentIndex)
+	if err != nil {
+		return nil, nil, err
+	}
+	queryExecutor, err := elasticsearch.NewElasticsearchQueryExecutor(client, documentIndex)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return indexer, queryExecutor, nil
+}
@@ -0,0 +1,367 @@
+// Package adobesign provides a SignatureProvider that sends documents for signature with Adobe
+// Acrobat Sign and verifies the webhook notifications Adobe Sign posts back.
+package adobesign
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"../../../domain/models"
+	"../../../domain/services"
+	"../../../pkg/config"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+)
+
+// defaultTimeout is the timeout for Adobe Sign API calls when none is configured
+const defaultTimeout = 30 * time.Second
+
+// maxErrorSize limits the error responses read from the API
+const maxErrorSize = 64 * 1024
+
+// clientIDHeader carries the client ID of the API application with every webhook call; Adobe Sign
+// expects it echoed in the response to accept the webhook
+const clientIDHeader = "X-AdobeSign-ClientId"
+
+// agreementRequest is the body of an agreement creation request
+type agreementRequest struct {
+	FileInfos           []fileInfo       `json:"fileInfos"`
+	Name                string           `json:"name"`
+	Message             string           `json:"message,omitempty"`
+	ParticipantSetsInfo []participantSet `json:"participantSetsInfo"`
+	SignatureType       string           `json:"signatureType"`
+	State               string           `json:"state"`
+}
+
+type fileInfo struct {
+	TransientDocumentID string `json:"transientDocumentId"`
+}
+
+type participantSet struct {
+	MemberInfos []memberInfo `json:"memberInfos"`
+	Order       int          `json:"order"`
+	Role        string       `json:"role"`
+}
+
+type memberInfo struct {
+	Email string `json:"email"`
+	Name  string `json:"name,omitempty"`
+}
+
+// webhookNotification is a notification posted by an Adobe Sign webhook
+type webhookNotification struct {
+	Event                string `json:"event"`
+	EventDate            string `json:"eventDate"`
+	ActingUserEmail      string `json:"actingUserEmail"`
+	ActingUserIPAddress  string `json:"actingUserIpAddress"`
+	ParticipantUserEmail string `json:"participantUserEmail"`
+	Agreement            struct {
+		ID string `json:"id"`
+	} `json:"agreement"`
+}
+
+// agreementStatuses are the statuses an agreement has in the API after the events that complete,
+// decline or void its signature request. Rejected and recalled agreements are both cancelled.
+var agreementStatuses = map[string][]string{
+	"AGREEMENT_WORKFLOW_COMPLETED": {"SIGNED", "APPROVED", "ACCEPTED", "DELIVERED", "FORM_FILLED"},
+	"AGREEMENT_REJECTED":           {"CANCELLED"},
+	"AGREEMENT_RECALLED":           {"CANCELLED"},
+	"AGREEMENT_EXPIRED":            {"EXPIRED"},
+}
+
+// adobeSignProvider implements services.SignatureProvider with the Adobe Sign REST API v6. Status
+// updates are posted by a webhook registered on the account for agreement events.
+type adobeSignProvider struct {
+	client *http.Client
+	config config.AdobeSignConfig
+}
+
+// NewAdobeSignProvider creates a signature provider for the Adobe Sign account of cfg
+func NewAdobeSignProvider(cfg config.AdobeSignConfig, timeoutSeconds int) (services.SignatureProvider, error) {
+	timeout := defaultTimeout
+	if timeoutSeconds > 0 {
+		timeout = time.Duration(timeoutSeconds) * time.Second
+	}
+	return NewAdobeSignProviderWithClient(&http.Client{Timeout: timeout}, cfg)
+}
+
+// NewAdobeSignProviderWithClient creates an Adobe Sign signature provider using the given HTTP client
+func NewAdobeSignProviderWithClient(client *http.Client, cfg config.AdobeSignConfig) (services.SignatureProvider, error) {
+	if client == nil {
+		return nil, errors.NewValidationError("HTTP client cannot be nil")
+	}
+	if cfg.BaseURL == "" {
+		return nil, errors.NewValidationError("Adobe Sign base URL cannot be empty")
+	}
+	// Webhook calls are only accepted from the registered application, identified by its client ID
+	if cfg.ClientID == "" {
+		return nil, errors.NewValidationError("Adobe Sign client ID cannot be empty")
+	}
+
+	cfg.BaseURL = strings.TrimRight(cfg.BaseURL, "/")
+	return &adobeSignProvider{
+		client: client,
+		config: cfg,
+	}, nil
+}
+
+// Name returns the name signature requests select Adobe Sign by
+func (p *adobeSignProvider) Name() string {
+	return models.SignatureProviderAdobeSign
+}
+
+// SendEnvelope uploads the document as a transient document and creates an agreement sending it to
+// the signers. Signers with the same routing order sign in parallel.
+func (p *adobeSignProvider) SendEnvelope(ctx context.Context, envelope *services.SignatureEnvelope) (string, error) {
+	transientDocumentID, err := p.uploadTransientDocument(ctx, envelope)
+	if err != nil {
+		return "", err
+	}
+
+	agreement := agreementRequest{
+		FileInfos:     []fileInfo{{TransientDocumentID: transientDocumentID}},
+		Name:          envelope.Subject,
+		Message:       envelope.Message,
+		SignatureType: "ESIGN",
+		State:         "IN_PROCESS",
+	}
+	for _, signer := range envelope.Signers {
+		agreement.ParticipantSetsInfo = append(agreement.ParticipantSetsInfo, participantSet{
+			MemberInfos: []memberInfo{{Email: signer.Email, Name: signer.Name}},
+			Order:       signer.RoutingOrder,
+			Role:        "SIGNER",
+		})
+	}
+
+	payload, err := json.Marshal(agreement)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to encode Adobe Sign agreement")
+	}
+
+	var created struct {
+		ID string `json:"id"`
+	}
+	if err := p.call(ctx, http.MethodPost, "/agreements", "application/json", bytes.NewReader(payload), http.StatusCreated, &created); err != nil {
+		return "", err
+	}
+	if created.ID == "" {
+		return "", errors.NewDependencyError("Adobe Sign did not return an agreement ID")
+	}
+
+	return created.ID, nil
+}
+
+// uploadTransientDocument uploads the document of the envelope, which Adobe Sign keeps for 7 days
+// for agreements to reference
+func (p *adobeSignProvider) uploadTransientDocument(ctx context.Context, envelope *services.SignatureEnvelope) (string, error) {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	if err := form.WriteField("File-Name", envelope.DocumentName); err != nil {
+		return "", errors.Wrap(err, "failed to encode Adobe Sign transient document")
+	}
+	file, err := form.CreateFormFile("File", envelope.DocumentName)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to encode Adobe Sign transient document")
+	}
+	if _, err := io.Copy(file, envelope.Content); err != nil {
+		return "", errors.Wrap(err, "failed to read document content")
+	}
+	if err := form.Close(); err != nil {
+		return "", errors.Wrap(err, "failed to encode Adobe Sign transient document")
+	}
+
+	var uploaded struct {
+		TransientDocumentID string `json:"transientDocumentId"`
+	}
+	if err := p.call(ctx, http.MethodPost, "/transientDocuments", form.FormDataContentType(), &body, http.StatusCreated, &uploaded); err != nil {
+		return "", err
+	}
+	if uploaded.TransientDocumentID == "" {
+		return "", errors.NewDependencyError("Adobe Sign did not return a transient document ID")
+	}
+
+	return uploaded.TransientDocumentID, nil
+}
+
+// VoidEnvelope cancels an agreement that is out for signature, notifying its participants
+func (p *adobeSignProvider) VoidEnvelope(ctx context.Context, envelopeID, reason string) error {
+	payload, err := json.Marshal(map[string]interface{}{
+		"state": "CANCELLED",
+		"agreementCancellationInfo": map[string]interface{}{
+			"comment":      reason,
+			"notifyOthers": true,
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to encode Adobe Sign cancellation")
+	}
+
+	return p.call(ctx, http.MethodPut, "/agreements/"+url.PathEscape(envelopeID)+"/state", "application/json", bytes.NewReader(payload), http.StatusNoContent, nil)
+}
+
+// DownloadSignedDocument opens the signed documents of a completed agreement combined into one PDF
+func (p *adobeSignProvider) DownloadSignedDocument(ctx context.Context, envelopeID string) (io.ReadCloser, error) {
+	resp, err := p.do(ctx, http.MethodGet, "/agreements/"+url.PathEscape(envelopeID)+"/combinedDocument", "", nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, p.responseError(ctx, resp)
+	}
+
+	return resp.Body, nil
+}
+
+// ParseCallback verifies that a webhook call carries the client ID of the API application and
+// parses the agreement event. Calls without a body verify the intent of the webhook URL and only
+// need the client ID echoed. As the client ID is no secret, events completing, declining or voiding
+// the agreement are only applied once the API confirms the agreement reached that status.
+func (p *adobeSignProvider) ParseCallback(ctx context.Context, header http.Header, body []byte) (*services.SignatureCallback, error) {
+	if subtle.ConstantTimeCompare([]byte(header.Get(clientIDHeader)), []byte(p.config.ClientID)) != 1 {
+		logger.WarnContext(ctx, "Rejected Adobe Sign webhook call with an unknown client ID")
+		return nil, errors.NewAuthenticationError("invalid Adobe Sign client ID")
+	}
+
+	callback := &services.SignatureCallback{
+		ResponseHeaders: map[string]string{clientIDHeader: p.config.ClientID},
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return callback, nil
+	}
+
+	var notification webhookNotification
+	if err := json.Unmarshal(body, &notification); err != nil {
+		return nil, errors.NewValidationError(fmt.Sprintf("invalid Adobe Sign notification: %s", err.Error()))
+	}
+	if notification.Agreement.ID == "" {
+		return nil, errors.NewValidationError("Adobe Sign notification has no agreement ID")
+	}
+	callback.EnvelopeID = notification.Agreement.ID
+
+	var eventDate *time.Time
+	if parsed, err := time.Parse(time.RFC3339, notification.EventDate); err == nil {
+		eventDate = &parsed
+	}
+
+	switch notification.Event {
+	case "AGREEMENT_ACTION_COMPLETED":
+		callback.Signers = append(callback.Signers, models.SignerStatusUpdate{
+			Email:     p.actingEmail(notification),
+			Status:    models.SignerStatusSigned,
+			SignedAt:  eventDate,
+			IPAddress: notification.ActingUserIPAddress,
+		})
+	case "AGREEMENT_WORKFLOW_COMPLETED":
+		callback.Status = models.SignatureStatusCompleted
+	case "AGREEMENT_REJECTED":
+		callback.Status = models.SignatureStatusDeclined
+		callback.Signers = append(callback.Signers, models.SignerStatusUpdate{
+			Email:     p.actingEmail(notification),
+			Status:    models.SignerStatusDeclined,
+			IPAddress: notification.ActingUserIPAddress,
+		})
+	case "AGREEMENT_RECALLED":
+		callback.Status = models.SignatureStatusVoided
+	case "AGREEMENT_EXPIRED":
+		callback.Status = models.SignatureStatusVoided
+		callback.Reason = "the agreement expired"
+	}
+
+	if expected, ok := agreementStatuses[notification.Event]; ok {
+		if err := p.confirmAgreementStatus(ctx, notification.Agreement.ID, expected); err != nil {
+			return nil, err
+		}
+	}
+
+	return callback, nil
+}
+
+// confirmAgreementStatus fetches the agreement from the API and checks that it has one of the
+// expected statuses
+func (p *adobeSignProvider) confirmAgreementStatus(ctx context.Context, agreementID string, expected []string) error {
+	var agreement struct {
+		Status string `json:"status"`
+	}
+	if err := p.call(ctx, http.MethodGet, "/agreements/"+url.PathEscape(agreementID), "", nil, http.StatusOK, &agreement); err != nil {
+		return err
+	}
+
+	for _, status := range expected {
+		if agreement.Status == status {
+			return nil
+		}
+	}
+
+	logger.WarnContext(ctx, "Rejected Adobe Sign webhook call not matching the agreement status", "agreement_id", agreementID, "status", agreement.Status)
+	return errors.NewAuthenticationError("Adobe Sign notification does not match the agreement status")
+}
+
+// actingEmail returns the email of the participant an event is about, who may have delegated the
+// action to another user
+func (p *adobeSignProvider) actingEmail(notification webhookNotification) string {
+	if notification.ParticipantUserEmail != "" {
+		return notification.ParticipantUserEmail
+	}
+	return notification.ActingUserEmail
+}
+
+// call sends a request to the API and decodes the JSON response into out, if not nil
+func (p *adobeSignProvider) call(ctx context.Context, method, path, contentType string, body io.Reader, expectedStatus int, out interface{}) error {
+	resp, err := p.do(ctx, method, path, contentType, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != expectedStatus {
+		return p.responseError(ctx, resp)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return errors.NewDependencyError(fmt.Sprintf("invalid response from Adobe Sign: %s", err.Error()))
+	}
+	return nil
+}
+
+// do sends an authenticated request to the API
+func (p *adobeSignProvider) do(ctx context.Context, method, path, contentType string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, p.config.BaseURL+path, body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create Adobe Sign request")
+	}
+	req.Header.Set("Authorization", "Bearer "+p.config.AccessToken)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to reach Adobe Sign", "error", err.Error())
+		return nil, errors.NewDependencyError(fmt.Sprintf("failed to reach Adobe Sign: %s", err.Error()))
+	}
+	return resp, nil
+}
+
+// responseError converts an error response of the API to a dependency error carrying its message
+func (p *adobeSignProvider) responseError(ctx context.Context, resp *http.Response) error {
+	var apiError struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	}
+	json.NewDecoder(io.LimitReader(resp.Body, maxErrorSize)).Decode(&apiError)
+
+	logger.ErrorContext(ctx, "Adobe Sign request failed", "status", resp.StatusCode, "code", apiError.Code)
+	return errors.NewDependencyError(fmt.Sprintf("Adobe Sign returned status %d: %s %s", resp.StatusCode, apiError.Code, apiError.Message))
+}
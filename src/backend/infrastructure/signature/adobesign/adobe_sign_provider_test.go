@@ -0,0 +1,179 @@
+package adobesign
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"  // v1.8.0+
+	"github.com/stretchr/testify/require" // v1.8.0+
+
+	"../../../domain/models"
+	"../../../domain/services"
+	"../../../pkg/config"
+	"../../../pkg/errors"
+)
+
+const testClientID = "test-client-id"
+
+// createTestProvider creates a provider for an Adobe Sign API served by handler
+func createTestProvider(t *testing.T, handler http.HandlerFunc) services.SignatureProvider {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	provider, err := NewAdobeSignProviderWithClient(server.Client(), config.AdobeSignConfig{
+		BaseURL:     server.URL + "/api/rest/v6/",
+		AccessToken: "test-token",
+		ClientID:    testClientID,
+	})
+	require.NoError(t, err)
+	return provider
+}
+
+// TestSendEnvelope tests that the document is uploaded and an agreement created for the signers
+func TestSendEnvelope(t *testing.T) {
+	provider := createTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+
+		switch r.URL.Path {
+		case "/api/rest/v6/transientDocuments":
+			file, _, err := r.FormFile("File")
+			require.NoError(t, err)
+			content, _ := ioutil.ReadAll(file)
+			assert.Equal(t, "content", string(content))
+			assert.Equal(t, "contract.pdf", r.FormValue("File-Name"))
+
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"transientDocumentId":"transient-1"}`))
+		case "/api/rest/v6/agreements":
+			var agreement agreementRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&agreement))
+			assert.Equal(t, "transient-1", agreement.FileInfos[0].TransientDocumentID)
+			assert.Equal(t, "Contract", agreement.Name)
+			assert.Equal(t, "IN_PROCESS", agreement.State)
+			require.Len(t, agreement.ParticipantSetsInfo, 2)
+			assert.Equal(t, "john@example.com", agreement.ParticipantSetsInfo[1].MemberInfos[0].Email)
+			assert.Equal(t, 2, agreement.ParticipantSetsInfo[1].Order)
+
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":"agreement-1"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	agreementID, err := provider.SendEnvelope(context.Background(), &services.SignatureEnvelope{
+		DocumentName: "contract.pdf",
+		ContentType:  "application/pdf",
+		Content:      strings.NewReader("content"),
+		Subject:      "Contract",
+		Signers: []models.Signer{
+			{Name: "Jane", Email: "jane@example.com", RoutingOrder: 1},
+			{Name: "John", Email: "john@example.com", RoutingOrder: 2},
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "agreement-1", agreementID)
+}
+
+// TestVoidEnvelope tests that the agreement is cancelled with the reason
+func TestVoidEnvelope(t *testing.T) {
+	provider := createTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/api/rest/v6/agreements/agreement-1/state", r.URL.Path)
+		assert.Contains(t, string(body), `"CANCELLED"`)
+		assert.Contains(t, string(body), "no longer needed")
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	err := provider.VoidEnvelope(context.Background(), "agreement-1", "no longer needed")
+
+	assert.NoError(t, err)
+}
+
+// TestParseCallback tests that webhook calls are verified and their events mapped
+func TestParseCallback(t *testing.T) {
+	header := http.Header{}
+	header.Set("X-AdobeSign-ClientId", testClientID)
+
+	tests := []struct {
+		name            string
+		body            string
+		agreementStatus string
+		expectedStatus  string
+		expectedSigner  string
+	}{
+		{"Signer signed", `{"event":"AGREEMENT_ACTION_COMPLETED","eventDate":"2026-01-02T03:04:05Z","participantUserEmail":"jane@example.com","actingUserIpAddress":"10.0.0.1","agreement":{"id":"agreement-1"}}`, "OUT_FOR_SIGNATURE", "", models.SignerStatusSigned},
+		{"Completed", `{"event":"AGREEMENT_WORKFLOW_COMPLETED","agreement":{"id":"agreement-1"}}`, "SIGNED", models.SignatureStatusCompleted, ""},
+		{"Rejected", `{"event":"AGREEMENT_REJECTED","actingUserEmail":"jane@example.com","agreement":{"id":"agreement-1"}}`, "CANCELLED", models.SignatureStatusDeclined, models.SignerStatusDeclined},
+		{"Recalled", `{"event":"AGREEMENT_RECALLED","agreement":{"id":"agreement-1"}}`, "CANCELLED", models.SignatureStatusVoided, ""},
+		{"Expired", `{"event":"AGREEMENT_EXPIRED","agreement":{"id":"agreement-1"}}`, "EXPIRED", models.SignatureStatusVoided, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := createTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodGet, r.Method)
+				assert.Equal(t, "/api/rest/v6/agreements/agreement-1", r.URL.Path)
+				w.Write([]byte(`{"id":"agreement-1","status":"` + tt.agreementStatus + `"}`))
+			})
+
+			callback, err := provider.ParseCallback(context.Background(), header, []byte(tt.body))
+
+			require.NoError(t, err)
+			assert.Equal(t, "agreement-1", callback.EnvelopeID)
+			assert.Equal(t, tt.expectedStatus, callback.Status)
+			assert.Equal(t, testClientID, callback.ResponseHeaders["X-AdobeSign-ClientId"])
+			if tt.expectedSigner != "" {
+				require.Len(t, callback.Signers, 1)
+				assert.Equal(t, "jane@example.com", callback.Signers[0].Email)
+				assert.Equal(t, tt.expectedSigner, callback.Signers[0].Status)
+			}
+		})
+	}
+}
+
+// TestParseCallback_StatusNotConfirmed tests that a rejection the API does not confirm is not applied
+func TestParseCallback_StatusNotConfirmed(t *testing.T) {
+	provider := createTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":"agreement-1","status":"OUT_FOR_SIGNATURE"}`))
+	})
+	header := http.Header{}
+	header.Set("X-AdobeSign-ClientId", testClientID)
+
+	callback, err := provider.ParseCallback(context.Background(), header, []byte(`{"event":"AGREEMENT_REJECTED","actingUserEmail":"jane@example.com","agreement":{"id":"agreement-1"}}`))
+
+	assert.Nil(t, callback)
+	assert.True(t, errors.IsAuthenticationError(err))
+}
+
+// TestParseCallback_Verification tests that intent verification calls only echo the client ID
+func TestParseCallback_Verification(t *testing.T) {
+	provider := createTestProvider(t, func(w http.ResponseWriter, r *http.Request) {})
+	header := http.Header{}
+	header.Set("X-AdobeSign-ClientId", testClientID)
+
+	callback, err := provider.ParseCallback(context.Background(), header, nil)
+
+	require.NoError(t, err)
+	assert.Empty(t, callback.EnvelopeID)
+	assert.Equal(t, testClientID, callback.ResponseHeaders["X-AdobeSign-ClientId"])
+}
+
+// TestParseCallback_UnknownClient tests that calls from other applications are rejected
+func TestParseCallback_UnknownClient(t *testing.T) {
+	provider := createTestProvider(t, func(w http.ResponseWriter, r *http.Request) {})
+	header := http.Header{}
+	header.Set("X-AdobeSign-ClientId", "other-client")
+
+	_, err := provider.ParseCallback(context.Background(), header, []byte(`{"event":"AGREEMENT_WORKFLOW_COMPLETED","agreement":{"id":"agreement-1"}}`))
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,354 @@
+// Package docusign provides a SignatureProvider that sends documents for signature with DocuSign
+// eSignature and verifies the status updates DocuSign Connect posts back.
+package docusign
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"../../../domain/models"
+	"../../../domain/services"
+	"../../../pkg/config"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+)
+
+// defaultTimeout is the timeout for DocuSign API calls when none is configured
+const defaultTimeout = 30 * time.Second
+
+// maxErrorSize limits the error responses read from the API
+const maxErrorSize = 64 * 1024
+
+// signatureHeaderPrefix prefixes the headers carrying the HMAC signatures of Connect messages; one
+// header is sent per active HMAC key, numbered from 1
+const signatureHeaderPrefix = "X-Docusign-Signature-"
+
+// connectEvents are the Connect events envelopes report to the platform
+var connectEvents = []string{
+	"envelope-sent",
+	"envelope-completed",
+	"envelope-declined",
+	"envelope-voided",
+	"recipient-completed",
+	"recipient-declined",
+}
+
+// envelopeDefinition is the body of an envelope creation request
+type envelopeDefinition struct {
+	EmailSubject      string            `json:"emailSubject"`
+	EmailBlurb        string            `json:"emailBlurb,omitempty"`
+	Documents         []document        `json:"documents"`
+	Recipients        recipients        `json:"recipients"`
+	EventNotification eventNotification `json:"eventNotification"`
+	Status            string            `json:"status"`
+}
+
+type document struct {
+	DocumentID     string `json:"documentId"`
+	Name           string `json:"name"`
+	FileExtension  string `json:"fileExtension,omitempty"`
+	DocumentBase64 string `json:"documentBase64"`
+}
+
+type recipients struct {
+	Signers []recipient `json:"signers"`
+}
+
+type recipient struct {
+	RecipientID    string `json:"recipientId,omitempty"`
+	Name           string `json:"name,omitempty"`
+	Email          string `json:"email"`
+	RoutingOrder   string `json:"routingOrder,omitempty"`
+	Status         string `json:"status,omitempty"`
+	SignedDateTime string `json:"signedDateTime,omitempty"`
+	DeclinedReason string `json:"declinedReason,omitempty"`
+}
+
+type eventNotification struct {
+	URL                   string    `json:"url"`
+	RequireAcknowledgment string    `json:"requireAcknowledgment"`
+	IncludeHMAC           string    `json:"includeHMAC"`
+	EventData             eventData `json:"eventData"`
+	Events                []string  `json:"events"`
+}
+
+type eventData struct {
+	Version     string   `json:"version"`
+	Format      string   `json:"format"`
+	IncludeData []string `json:"includeData"`
+}
+
+// connectMessage is a status update posted by DocuSign Connect
+type connectMessage struct {
+	Event string `json:"event"`
+	Data  struct {
+		EnvelopeID      string `json:"envelopeId"`
+		EnvelopeSummary struct {
+			Status       string     `json:"status"`
+			VoidedReason string     `json:"voidedReason"`
+			Recipients   recipients `json:"recipients"`
+		} `json:"envelopeSummary"`
+	} `json:"data"`
+}
+
+// docuSignProvider implements services.SignatureProvider with the DocuSign eSignature REST API
+type docuSignProvider struct {
+	client *http.Client
+	config config.DocuSignConfig
+}
+
+// NewDocuSignProvider creates a signature provider for the DocuSign account of cfg
+func NewDocuSignProvider(cfg config.DocuSignConfig, timeoutSeconds int) (services.SignatureProvider, error) {
+	timeout := defaultTimeout
+	if timeoutSeconds > 0 {
+		timeout = time.Duration(timeoutSeconds) * time.Second
+	}
+	return NewDocuSignProviderWithClient(&http.Client{Timeout: timeout}, cfg)
+}
+
+// NewDocuSignProviderWithClient creates a DocuSign signature provider using the given HTTP client
+func NewDocuSignProviderWithClient(client *http.Client, cfg config.DocuSignConfig) (services.SignatureProvider, error) {
+	if client == nil {
+		return nil, errors.NewValidationError("HTTP client cannot be nil")
+	}
+	if cfg.BaseURL == "" {
+		return nil, errors.NewValidationError("DocuSign base URL cannot be empty")
+	}
+	if cfg.AccountID == "" {
+		return nil, errors.NewValidationError("DocuSign account ID cannot be empty")
+	}
+	// Unsigned status updates could complete requests with forged content, so they are never accepted
+	if cfg.HMACKey == "" {
+		return nil, errors.NewValidationError("DocuSign Connect HMAC key cannot be empty")
+	}
+
+	cfg.BaseURL = strings.TrimRight(cfg.BaseURL, "/")
+	return &docuSignProvider{
+		client: client,
+		config: cfg,
+	}, nil
+}
+
+// Name returns the name signature requests select DocuSign by
+func (p *docuSignProvider) Name() string {
+	return models.SignatureProviderDocuSign
+}
+
+// SendEnvelope creates and sends an envelope with the document, asking Connect to post the status
+// updates of the envelope to its callback URL with an HMAC signature
+func (p *docuSignProvider) SendEnvelope(ctx context.Context, envelope *services.SignatureEnvelope) (string, error) {
+	content, err := ioutil.ReadAll(envelope.Content)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to read document content")
+	}
+
+	definition := envelopeDefinition{
+		EmailSubject: envelope.Subject,
+		EmailBlurb:   envelope.Message,
+		Documents: []document{{
+			DocumentID:     "1",
+			Name:           envelope.DocumentName,
+			FileExtension:  strings.TrimPrefix(filepath.Ext(envelope.DocumentName), "."),
+			DocumentBase64: base64.StdEncoding.EncodeToString(content),
+		}},
+		EventNotification: eventNotification{
+			URL:                   envelope.CallbackURL,
+			RequireAcknowledgment: "true",
+			IncludeHMAC:           "true",
+			EventData: eventData{
+				Version:     "restv2.1",
+				Format:      "json",
+				IncludeData: []string{"recipients"},
+			},
+			Events: connectEvents,
+		},
+		Status: "sent",
+	}
+	for i, signer := range envelope.Signers {
+		definition.Recipients.Signers = append(definition.Recipients.Signers, recipient{
+			RecipientID:  strconv.Itoa(i + 1),
+			Name:         signer.Name,
+			Email:        signer.Email,
+			RoutingOrder: strconv.Itoa(signer.RoutingOrder),
+		})
+	}
+
+	var created struct {
+		EnvelopeID string `json:"envelopeId"`
+	}
+	if err := p.call(ctx, http.MethodPost, p.envelopesURL(), definition, http.StatusCreated, &created); err != nil {
+		return "", err
+	}
+	if created.EnvelopeID == "" {
+		return "", errors.NewDependencyError("DocuSign did not return an envelope ID")
+	}
+
+	return created.EnvelopeID, nil
+}
+
+// VoidEnvelope voids an envelope that is out for signature
+func (p *docuSignProvider) VoidEnvelope(ctx context.Context, envelopeID, reason string) error {
+	body := map[string]string{
+		"status":       "voided",
+		"voidedReason": reason,
+	}
+	return p.call(ctx, http.MethodPut, p.envelopesURL()+"/"+url.PathEscape(envelopeID), body, http.StatusOK, nil)
+}
+
+// DownloadSignedDocument opens the signed documents of a completed envelope combined into one PDF
+func (p *docuSignProvider) DownloadSignedDocument(ctx context.Context, envelopeID string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.envelopesURL()+"/"+url.PathEscape(envelopeID)+"/documents/combined", nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create DocuSign request")
+	}
+	req.Header.Set("Authorization", "Bearer "+p.config.AccessToken)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to reach DocuSign", "error", err.Error())
+		return nil, errors.NewDependencyError(fmt.Sprintf("failed to reach DocuSign: %s", err.Error()))
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, p.responseError(ctx, resp)
+	}
+
+	return resp.Body, nil
+}
+
+// ParseCallback verifies the HMAC signature of a Connect message and parses the status of the
+// envelope and its signers
+func (p *docuSignProvider) ParseCallback(ctx context.Context, header http.Header, body []byte) (*services.SignatureCallback, error) {
+	if !p.verifySignature(header, body) {
+		logger.WarnContext(ctx, "Rejected DocuSign Connect message with an invalid signature")
+		return nil, errors.NewAuthenticationError("invalid DocuSign Connect signature")
+	}
+
+	var message connectMessage
+	if err := json.Unmarshal(body, &message); err != nil {
+		return nil, errors.NewValidationError(fmt.Sprintf("invalid DocuSign Connect message: %s", err.Error()))
+	}
+	if message.Data.EnvelopeID == "" {
+		return nil, errors.NewValidationError("DocuSign Connect message has no envelope ID")
+	}
+
+	callback := &services.SignatureCallback{
+		EnvelopeID: message.Data.EnvelopeID,
+	}
+	summary := message.Data.EnvelopeSummary
+	switch summary.Status {
+	case "completed":
+		callback.Status = models.SignatureStatusCompleted
+	case "declined":
+		callback.Status = models.SignatureStatusDeclined
+	case "voided":
+		callback.Status = models.SignatureStatusVoided
+		callback.Reason = summary.VoidedReason
+	}
+
+	for _, signer := range summary.Recipients.Signers {
+		update := models.SignerStatusUpdate{
+			Email:  signer.Email,
+			Status: models.SignerStatusPending,
+		}
+		switch signer.Status {
+		case "completed":
+			update.Status = models.SignerStatusSigned
+			if signedAt, err := time.Parse(time.RFC3339, signer.SignedDateTime); err == nil {
+				update.SignedAt = &signedAt
+			}
+		case "declined":
+			update.Status = models.SignerStatusDeclined
+			if callback.Reason == "" {
+				callback.Reason = signer.DeclinedReason
+			}
+		}
+		callback.Signers = append(callback.Signers, update)
+	}
+
+	return callback, nil
+}
+
+// verifySignature checks that one of the signature headers is the base64 HMAC-SHA256 of the body
+// with the Connect HMAC key
+func (p *docuSignProvider) verifySignature(header http.Header, body []byte) bool {
+	mac := hmac.New(sha256.New, []byte(p.config.HMACKey))
+	mac.Write(body)
+	expected := []byte(base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+
+	for name, values := range header {
+		if !strings.HasPrefix(http.CanonicalHeaderKey(name), signatureHeaderPrefix) {
+			continue
+		}
+		for _, value := range values {
+			if hmac.Equal([]byte(value), expected) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// envelopesURL returns the URL of the envelopes of the account
+func (p *docuSignProvider) envelopesURL() string {
+	return p.config.BaseURL + "/v2.1/accounts/" + url.PathEscape(p.config.AccountID) + "/envelopes"
+}
+
+// call sends a JSON request to the API and decodes the JSON response into out, if not nil
+func (p *docuSignProvider) call(ctx context.Context, method, endpoint string, body interface{}, expectedStatus int, out interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode DocuSign request")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return errors.Wrap(err, "failed to create DocuSign request")
+	}
+	req.Header.Set("Authorization", "Bearer "+p.config.AccessToken)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to reach DocuSign", "error", err.Error())
+		return errors.NewDependencyError(fmt.Sprintf("failed to reach DocuSign: %s", err.Error()))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != expectedStatus {
+		return p.responseError(ctx, resp)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return errors.NewDependencyError(fmt.Sprintf("invalid response from DocuSign: %s", err.Error()))
+	}
+	return nil
+}
+
+// responseError converts an error response of the API to a dependency error carrying its message
+func (p *docuSignProvider) responseError(ctx context.Context, resp *http.Response) error {
+	var apiError struct {
+		ErrorCode string `json:"errorCode"`
+		Message   string `json:"message"`
+	}
+	json.NewDecoder(io.LimitReader(resp.Body, maxErrorSize)).Decode(&apiError)
+
+	logger.ErrorContext(ctx, "DocuSign request failed", "status", resp.StatusCode, "errorCode", apiError.ErrorCode)
+	return errors.NewDependencyError(fmt.Sprintf("DocuSign returned status %d: %s %s", resp.StatusCode, apiError.ErrorCode, apiError.Message))
+}
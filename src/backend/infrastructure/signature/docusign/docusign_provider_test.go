@@ -0,0 +1,158 @@
+package docusign
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"  // v1.8.0+
+	"github.com/stretchr/testify/require" // v1.8.0+
+
+	"../../../domain/models"
+	"../../../domain/services"
+	"../../../pkg/config"
+)
+
+const testHMACKey = "test-hmac-key"
+
+// createTestProvider creates a provider for a DocuSign API served by handler
+func createTestProvider(t *testing.T, handler http.HandlerFunc) services.SignatureProvider {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	provider, err := NewDocuSignProviderWithClient(server.Client(), config.DocuSignConfig{
+		BaseURL:     server.URL + "/restapi/",
+		AccountID:   "test-account",
+		AccessToken: "test-token",
+		HMACKey:     testHMACKey,
+	})
+	require.NoError(t, err)
+	return provider
+}
+
+// sign returns the Connect signature of body
+func sign(body []byte) string {
+	mac := hmac.New(sha256.New, []byte(testHMACKey))
+	mac.Write(body)
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// TestSendEnvelope tests that the document and signers are sent and the envelope ID returned
+func TestSendEnvelope(t *testing.T) {
+	provider := createTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		var definition envelopeDefinition
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&definition))
+
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/restapi/v2.1/accounts/test-account/envelopes", r.URL.Path)
+		assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+		assert.Equal(t, "Contract", definition.EmailSubject)
+		assert.Equal(t, "pdf", definition.Documents[0].FileExtension)
+		assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("content")), definition.Documents[0].DocumentBase64)
+		assert.Equal(t, "https://dms.example.com/callback", definition.EventNotification.URL)
+		assert.Equal(t, "true", definition.EventNotification.IncludeHMAC)
+		require.Len(t, definition.Recipients.Signers, 2)
+		assert.Equal(t, "jane@example.com", definition.Recipients.Signers[0].Email)
+		assert.Equal(t, "2", definition.Recipients.Signers[1].RoutingOrder)
+
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"envelopeId":"envelope-1","status":"sent"}`))
+	})
+
+	envelopeID, err := provider.SendEnvelope(context.Background(), &services.SignatureEnvelope{
+		DocumentName: "contract.pdf",
+		ContentType:  "application/pdf",
+		Content:      strings.NewReader("content"),
+		Subject:      "Contract",
+		Signers: []models.Signer{
+			{Name: "Jane", Email: "jane@example.com", RoutingOrder: 1},
+			{Name: "John", Email: "john@example.com", RoutingOrder: 2},
+		},
+		CallbackURL: "https://dms.example.com/callback",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "envelope-1", envelopeID)
+}
+
+// TestSendEnvelope_APIError tests that API errors are returned
+func TestSendEnvelope_APIError(t *testing.T) {
+	provider := createTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"errorCode":"INVALID_EMAIL_ADDRESS_FOR_RECIPIENT","message":"invalid email"}`))
+	})
+
+	_, err := provider.SendEnvelope(context.Background(), &services.SignatureEnvelope{
+		DocumentName: "contract.pdf",
+		Content:      strings.NewReader("content"),
+		Subject:      "Contract",
+	})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "INVALID_EMAIL_ADDRESS_FOR_RECIPIENT")
+}
+
+// TestParseCallback tests that signed Connect messages are parsed and others rejected
+func TestParseCallback(t *testing.T) {
+	provider := createTestProvider(t, func(w http.ResponseWriter, r *http.Request) {})
+	body := []byte(`{"event":"envelope-completed","data":{"envelopeId":"envelope-1","envelopeSummary":{"status":"completed","recipients":{"signers":[{"email":"jane@example.com","status":"completed","signedDateTime":"2026-01-02T03:04:05Z"},{"email":"john@example.com","status":"sent"}]}}}}`)
+
+	t.Run("Valid signature", func(t *testing.T) {
+		header := http.Header{}
+		header.Set("X-DocuSign-Signature-2", sign(body))
+
+		callback, err := provider.ParseCallback(context.Background(), header, body)
+
+		require.NoError(t, err)
+		assert.Equal(t, "envelope-1", callback.EnvelopeID)
+		assert.Equal(t, models.SignatureStatusCompleted, callback.Status)
+		require.Len(t, callback.Signers, 2)
+		assert.Equal(t, models.SignerStatusSigned, callback.Signers[0].Status)
+		require.NotNil(t, callback.Signers[0].SignedAt)
+		assert.Equal(t, 2026, callback.Signers[0].SignedAt.Year())
+		assert.Equal(t, models.SignerStatusPending, callback.Signers[1].Status)
+	})
+
+	t.Run("Invalid signature", func(t *testing.T) {
+		header := http.Header{}
+		header.Set("X-DocuSign-Signature-1", sign([]byte("other body")))
+
+		_, err := provider.ParseCallback(context.Background(), header, body)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("Missing signature", func(t *testing.T) {
+		_, err := provider.ParseCallback(context.Background(), http.Header{}, body)
+
+		assert.Error(t, err)
+	})
+}
+
+// TestParseCallback_Declined tests that the decline reason of the signer is reported
+func TestParseCallback_Declined(t *testing.T) {
+	provider := createTestProvider(t, func(w http.ResponseWriter, r *http.Request) {})
+	body := []byte(`{"event":"envelope-declined","data":{"envelopeId":"envelope-1","envelopeSummary":{"status":"declined","recipients":{"signers":[{"email":"jane@example.com","status":"declined","declinedReason":"wrong amount"}]}}}}`)
+	header := http.Header{}
+	header.Set("X-DocuSign-Signature-1", sign(body))
+
+	callback, err := provider.ParseCallback(context.Background(), header, body)
+
+	require.NoError(t, err)
+	assert.Equal(t, models.SignatureStatusDeclined, callback.Status)
+	assert.Equal(t, "wrong amount", callback.Reason)
+	assert.Equal(t, models.SignerStatusDeclined, callback.Signers[0].Status)
+}
+
+// TestNewDocuSignProvider_MissingHMACKey tests that the Connect HMAC key is required
+func TestNewDocuSignProvider_MissingHMACKey(t *testing.T) {
+	_, err := NewDocuSignProvider(config.DocuSignConfig{BaseURL: "https://demo.docusign.net/restapi", AccountID: "account"}, 0)
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,164 @@
+// Package azure implements the StorageProvider interface using Azure Blob Storage for document storage.
+package azure
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"           // v1.2.0+
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blob"      // v1.2.0+
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror" // v1.2.0+
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/sas"       // v1.2.0+
+
+	".."
+	"../../../pkg/config"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+)
+
+// copySourceExpiry is how long the source URL of a server-side copy is valid. Put Blob From URL
+// copies synchronously, so it only has to outlive a single request.
+const copySourceExpiry = 15 * time.Minute
+
+// azureProvider implements the StorageProvider interface using Azure Blob Storage, with one blob
+// container per container
+type azureProvider struct {
+	client     *azblob.Client
+	credential *azblob.SharedKeyCredential
+	config     config.AzureStorageConfig
+}
+
+// NewAzureProvider creates a new Azure Blob Storage provider with the provided configuration.
+// The account key is used both to authenticate and to sign download URLs.
+func NewAzureProvider(cfg config.AzureStorageConfig) (storage.StorageProvider, error) {
+	if cfg.AccountName == "" || cfg.AccountKey == "" {
+		return nil, errors.NewValidationError("Azure storage account name and key cannot be empty")
+	}
+	if cfg.Container == "" || cfg.TempContainer == "" || cfg.QuarantineContainer == "" {
+		return nil, errors.NewValidationError("Azure document, temporary and quarantine containers cannot be empty")
+	}
+
+	credential, err := azblob.NewSharedKeyCredential(cfg.AccountName, cfg.AccountKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid Azure storage account key")
+	}
+
+	serviceURL := cfg.Endpoint
+	if serviceURL == "" {
+		serviceURL = fmt.Sprintf("https://%s.blob.core.windows.net/", cfg.AccountName)
+	}
+
+	client, err := azblob.NewClientWithSharedKeyCredential(serviceURL, credential, nil)
+	if err != nil {
+		logger.Error("Failed to create Azure Blob Storage client", "error", err.Error())
+		return nil, errors.Wrap(err, "failed to create Azure Blob Storage client")
+	}
+
+	return &azureProvider{
+		client:     client,
+		credential: credential,
+		config:     cfg,
+	}, nil
+}
+
+// Put uploads content as a block blob. The content is uploaded in blocks as it is read, so it
+// never has to fit in memory. Blobs are encrypted with the storage account's keys.
+func (p *azureProvider) Put(ctx context.Context, container storage.Container, key string, content io.Reader, size int64, opts storage.ObjectOptions) error {
+	if opts.EncryptionKeyID != "" {
+		return storage.ErrEncryptionKeyNotSupported
+	}
+
+	uploadOptions := &azblob.UploadStreamOptions{}
+	if opts.ContentType != "" {
+		uploadOptions.HTTPHeaders = &blob.HTTPHeaders{BlobContentType: &opts.ContentType}
+	}
+
+	_, err := p.client.UploadStream(ctx, p.containerName(container), key, content, uploadOptions)
+	return err
+}
+
+// Copy copies a blob on the Azure side with Put Blob From URL, authorizing the source with a
+// short-lived SAS. The blob's properties, including its content type, are copied with it.
+func (p *azureProvider) Copy(ctx context.Context, srcContainer storage.Container, srcKey string, dstContainer storage.Container, dstKey string, opts storage.ObjectOptions) error {
+	if opts.EncryptionKeyID != "" {
+		return storage.ErrEncryptionKeyNotSupported
+	}
+	// Blobs are always encrypted with the account's keys, so copying a blob onto itself changes nothing
+	if srcContainer == dstContainer && srcKey == dstKey {
+		return nil
+	}
+
+	sourceURL, err := p.blobClient(srcContainer, srcKey).GetSASURL(sas.BlobPermissions{Read: true}, time.Now().UTC().Add(copySourceExpiry), nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to sign copy source")
+	}
+
+	destination := p.client.ServiceClient().NewContainerClient(p.containerName(dstContainer)).NewBlockBlobClient(dstKey)
+	_, err = destination.UploadBlobFromURL(ctx, sourceURL, nil)
+	return err
+}
+
+// Get downloads a blob
+func (p *azureProvider) Get(ctx context.Context, container storage.Container, key string) (io.ReadCloser, error) {
+	result, err := p.client.DownloadStream(ctx, p.containerName(container), key, nil)
+	if err != nil {
+		return nil, err
+	}
+	return result.Body, nil
+}
+
+// GetRange downloads part of a blob. The range is passed to Azure, so only the requested bytes are transferred.
+func (p *azureProvider) GetRange(ctx context.Context, container storage.Container, key string, offset int64, length int64) (io.ReadCloser, error) {
+	result, err := p.client.DownloadStream(ctx, p.containerName(container), key, &azblob.DownloadStreamOptions{
+		Range: azblob.HTTPRange{Offset: offset, Count: length},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.Body, nil
+}
+
+// Delete deletes a blob. Deleting a blob that does not exist succeeds, as it does in S3.
+func (p *azureProvider) Delete(ctx context.Context, container storage.Container, key string) error {
+	_, err := p.client.DeleteBlob(ctx, p.containerName(container), key, nil)
+	if err != nil && !bloberror.HasCode(err, bloberror.BlobNotFound) {
+		return err
+	}
+	return nil
+}
+
+// PresignGet returns a blob URL with a read-only service SAS that downloads the blob as an attachment
+func (p *azureProvider) PresignGet(ctx context.Context, container storage.Container, key string, fileName string, expiry time.Duration) (string, error) {
+	permissions := sas.BlobPermissions{Read: true}
+	query, err := sas.BlobSignatureValues{
+		ExpiryTime:         time.Now().UTC().Add(expiry),
+		Permissions:        permissions.String(),
+		ContainerName:      p.containerName(container),
+		BlobName:           key,
+		ContentDisposition: fmt.Sprintf("attachment; filename=%s", fileName),
+	}.SignWithSharedKey(p.credential)
+	if err != nil {
+		return "", err
+	}
+
+	return p.blobClient(container, key).URL() + "?" + query.Encode(), nil
+}
+
+// blobClient returns the client of a blob
+func (p *azureProvider) blobClient(container storage.Container, key string) *blob.Client {
+	return p.client.ServiceClient().NewContainerClient(p.containerName(container)).NewBlobClient(key)
+}
+
+// containerName returns the blob container of a container
+func (p *azureProvider) containerName(container storage.Container) string {
+	switch container {
+	case storage.ContainerTemp:
+		return p.config.TempContainer
+	case storage.ContainerQuarantine:
+		return p.config.QuarantineContainer
+	default:
+		return p.config.Container
+	}
+}
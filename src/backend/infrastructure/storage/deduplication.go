@@ -0,0 +1,128 @@
+// Package storage implements the StorageService interface on top of the object storage of a
+// cloud provider, so that documents can be kept in S3, Azure Blob Storage, GCS or on local disk.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"../../pkg/logger"
+	"../../pkg/utils"
+)
+
+// blobPathPrefix is the path prefix of content-addressed storage. Content is stored in the
+// documents container under blobs/<tenant>/<sha256>, so tenants never share objects with each other.
+const blobPathPrefix = "blobs/"
+
+// errDeduplicationDisabled is returned for content-addressed storage operations on a storage
+// service created without a blob repository
+var errDeduplicationDisabled = errors.New("content deduplication is not enabled")
+
+// StoreDeduplicated moves a document from temporary to content-addressed storage after processing.
+// The first version with a hash copies its content; later versions with the same hash only add a
+// reference and drop their temporary copy. The blob stays locked while its content is copied, so
+// concurrent uploads of the same content never see a blob whose content is not stored yet.
+func (s *storageService) StoreDeduplicated(ctx context.Context, tenantID string, contentHash string, size int64, tempPath string) (string, error) {
+	// Validate inputs
+	if s.blobRepo == nil {
+		return "", errDeduplicationDisabled
+	}
+	if tenantID == "" {
+		return "", errors.New("tenant ID cannot be empty")
+	}
+	if !utils.IsValidHash(contentHash, utils.HashAlgorithmSHA256) {
+		return "", errors.New("content hash must be a hex encoded SHA-256 hash")
+	}
+	if tempPath == "" {
+		return "", errors.New("temporary path cannot be empty")
+	}
+
+	contentHash = strings.ToLower(contentHash)
+	blobPath := fmt.Sprintf("%s%s/%s", blobPathPrefix, tenantID, contentHash)
+
+	keyID, err := s.encryptionKeyFor(ctx, tenantID)
+	if err != nil {
+		return "", err
+	}
+
+	logger.InfoContext(ctx, "Moving document from temporary to content-addressed storage",
+		"tenant_id", tenantID,
+		"content_hash", contentHash,
+		"temp_path", tempPath,
+		"blob_path", blobPath)
+
+	var shared bool
+	err = s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		blob, err := s.blobRepo.Acquire(txCtx, tenantID, contentHash, size)
+		if err != nil {
+			return err
+		}
+		if shared = blob.IsShared(); shared {
+			return nil
+		}
+
+		// First reference: store the content
+		return s.provider.Copy(ctx, ContainerTemp, tempPath, ContainerDocuments, blobPath, ObjectOptions{EncryptionKeyID: keyID})
+	})
+
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to move document to content-addressed storage",
+			"tenant_id", tenantID,
+			"content_hash", contentHash,
+			"error", err.Error())
+		return "", err
+	}
+
+	s.deleteTemporary(ctx, tenantID, tempPath)
+
+	logger.InfoContext(ctx, "Document moved to content-addressed storage",
+		"tenant_id", tenantID,
+		"blob_path", blobPath,
+		"deduplicated", shared)
+
+	return blobPath, nil
+}
+
+// releaseBlob releases one reference to content-addressed content, deleting the content with its
+// last reference. The blob stays locked until the content is deleted, so a concurrent upload of
+// the same content waits and then stores it again.
+func (s *storageService) releaseBlob(ctx context.Context, blobPath string) error {
+	if s.blobRepo == nil {
+		return errDeduplicationDisabled
+	}
+
+	parts := strings.Split(strings.TrimPrefix(blobPath, blobPathPrefix), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return fmt.Errorf("invalid content-addressed storage path: %s", blobPath)
+	}
+	tenantID, contentHash := parts[0], parts[1]
+
+	var remaining int64
+	err := s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		blob, err := s.blobRepo.Release(txCtx, tenantID, contentHash)
+		if err != nil {
+			return err
+		}
+		if remaining = blob.RefCount; remaining > 0 {
+			return nil
+		}
+
+		// Last reference: delete the content
+		return s.provider.Delete(ctx, ContainerDocuments, blobPath)
+	})
+
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to release content-addressed document",
+			"storage_path", blobPath,
+			"error", err.Error())
+		return err
+	}
+
+	logger.InfoContext(ctx, "Content-addressed document released",
+		"storage_path", blobPath,
+		"remaining_references", remaining)
+
+	return nil
+}
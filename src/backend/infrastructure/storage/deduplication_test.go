@@ -0,0 +1,118 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert" // v1.8.0+
+	"github.com/stretchr/testify/mock"   // v1.8.0+
+
+	"../../domain/models"
+)
+
+// testContentHash is the SHA-256 hash of testContent
+const testContentHash = "8d430eb73472bd0177cf3cd165c9541c775a59f6871cf2a9e736e40584d24b78"
+
+// mockContentBlobRepository is a mock implementation of the ContentBlobRepository interface for testing
+type mockContentBlobRepository struct {
+	mock.Mock
+}
+
+func (m *mockContentBlobRepository) Acquire(ctx context.Context, tenantID, contentHash string, size int64) (*models.ContentBlob, error) {
+	args := m.Called(ctx, tenantID, contentHash, size)
+	if blob := args.Get(0); blob != nil {
+		return blob.(*models.ContentBlob), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *mockContentBlobRepository) Release(ctx context.Context, tenantID, contentHash string) (*models.ContentBlob, error) {
+	args := m.Called(ctx, tenantID, contentHash)
+	if blob := args.Get(0); blob != nil {
+		return blob.(*models.ContentBlob), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// passthroughTransactionManager runs units of work without a transaction
+type passthroughTransactionManager struct{}
+
+func (passthroughTransactionManager) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// createDeduplicatingStorage creates a storage service counting references in blobRepo
+func createDeduplicatingStorage(provider *mockStorageProvider, blobRepo *mockContentBlobRepository) *storageService {
+	return &storageService{
+		provider:  provider,
+		blobRepo:  blobRepo,
+		txManager: passthroughTransactionManager{},
+	}
+}
+
+// TestStoreDeduplicated_Disabled tests that content-addressed storage requires a blob repository
+func TestStoreDeduplicated_Disabled(t *testing.T) {
+	storage := createTestStorage(new(mockStorageProvider))
+
+	blobPath, err := storage.StoreDeduplicated(context.Background(), testTenantID, testContentHash, int64(len(testContent)), "temp/tenant-123/doc-123")
+
+	assert.Equal(t, errDeduplicationDisabled, err)
+	assert.Empty(t, blobPath)
+}
+
+// TestStoreDeduplicated_InvalidHash tests that only SHA-256 hashes can address content
+func TestStoreDeduplicated_InvalidHash(t *testing.T) {
+	blobRepo := new(mockContentBlobRepository)
+	storage := createDeduplicatingStorage(new(mockStorageProvider), blobRepo)
+
+	for _, contentHash := range []string{"", "N/A", "../other-tenant/" + testContentHash[16:], testContentHash[:32]} {
+		blobPath, err := storage.StoreDeduplicated(context.Background(), testTenantID, contentHash, int64(len(testContent)), "temp/tenant-123/doc-123")
+
+		assert.Error(t, err, contentHash)
+		assert.Empty(t, blobPath)
+	}
+	blobRepo.AssertNotCalled(t, "Acquire", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestStoreDeduplicated_SharedBlob tests that content already stored is not copied again
+func TestStoreDeduplicated_SharedBlob(t *testing.T) {
+	provider := new(mockStorageProvider)
+	blobRepo := new(mockContentBlobRepository)
+	storage := createDeduplicatingStorage(provider, blobRepo)
+	blobRepo.On("Acquire", mock.Anything, testTenantID, testContentHash, int64(len(testContent))).
+		Return(&models.ContentBlob{TenantID: testTenantID, ContentHash: testContentHash, RefCount: 2}, nil)
+	provider.On("Delete", mock.Anything, ContainerTemp, "temp/tenant-123/doc-123").Return(nil)
+
+	blobPath, err := storage.StoreDeduplicated(context.Background(), testTenantID, testContentHash, int64(len(testContent)), "temp/tenant-123/doc-123")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "blobs/"+testTenantID+"/"+testContentHash, blobPath)
+	provider.AssertNotCalled(t, "Copy", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	provider.AssertExpectations(t)
+}
+
+// TestDeleteDocument_SharedBlob tests that deleting shared content only releases a reference
+func TestDeleteDocument_SharedBlob(t *testing.T) {
+	provider := new(mockStorageProvider)
+	blobRepo := new(mockContentBlobRepository)
+	storage := createDeduplicatingStorage(provider, blobRepo)
+	blobRepo.On("Release", mock.Anything, testTenantID, testContentHash).
+		Return(&models.ContentBlob{TenantID: testTenantID, ContentHash: testContentHash, RefCount: 1}, nil)
+
+	err := storage.DeleteDocument(context.Background(), "blobs/"+testTenantID+"/"+testContentHash)
+
+	assert.NoError(t, err)
+	blobRepo.AssertExpectations(t)
+	provider.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestDeleteDocument_InvalidBlobPath tests that malformed content-addressed paths are rejected
+func TestDeleteDocument_InvalidBlobPath(t *testing.T) {
+	blobRepo := new(mockContentBlobRepository)
+	storage := createDeduplicatingStorage(new(mockStorageProvider), blobRepo)
+
+	err := storage.DeleteDocument(context.Background(), "blobs/"+testTenantID+"/nested/"+testContentHash)
+
+	assert.Error(t, err)
+	blobRepo.AssertNotCalled(t, "Release", mock.Anything, mock.Anything, mock.Anything)
+}
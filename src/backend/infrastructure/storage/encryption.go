@@ -0,0 +1,67 @@
+// Package storage implements the StorageService interface on top of the object storage of a
+// cloud provider, so that documents can be kept in S3, Azure Blob Storage, GCS or on local disk.
+package storage
+
+import (
+	"context"
+	"errors"
+
+	"../../pkg/logger"
+)
+
+// encryptionKeyFor returns the KMS key new objects of the tenant are written with
+func (s *storageService) encryptionKeyFor(ctx context.Context, tenantID string) (string, error) {
+	keyID, err := s.GetEncryptionKeyID(ctx, tenantID)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to resolve encryption key",
+			"tenant_id", tenantID,
+			"error", err.Error())
+		return "", err
+	}
+	return keyID, nil
+}
+
+// GetEncryptionKeyID returns the KMS key new content of the tenant is encrypted with.
+// Without a key service, content is encrypted with provider managed keys.
+func (s *storageService) GetEncryptionKeyID(ctx context.Context, tenantID string) (string, error) {
+	if tenantID == "" {
+		return "", errors.New("tenant ID cannot be empty")
+	}
+	if s.keyService == nil {
+		return "", nil
+	}
+	return s.keyService.GetTenantKeyID(ctx, tenantID)
+}
+
+// ReencryptDocument encrypts a stored document again by copying the object onto itself with the
+// new key. The provider decrypts the object with its old key while copying, so the old key has to
+// stay usable until every document encrypted with it has been re-encrypted.
+func (s *storageService) ReencryptDocument(ctx context.Context, storagePath string, keyID string) error {
+	// Validate storage path
+	if storagePath == "" {
+		return errors.New("storage path cannot be empty")
+	}
+
+	// Determine the container based on the storage path
+	container := containerOf(storagePath)
+
+	logger.InfoContext(ctx, "Re-encrypting document",
+		"storage_path", storagePath,
+		"container", container,
+		"key_id", keyID)
+
+	err := s.provider.Copy(ctx, container, storagePath, container, storagePath, ObjectOptions{EncryptionKeyID: keyID})
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to re-encrypt document",
+			"storage_path", storagePath,
+			"key_id", keyID,
+			"error", err.Error())
+		return err
+	}
+
+	logger.InfoContext(ctx, "Document re-encrypted",
+		"storage_path", storagePath,
+		"key_id", keyID)
+
+	return nil
+}
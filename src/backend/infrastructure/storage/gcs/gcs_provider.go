@@ -0,0 +1,141 @@
+// Package gcs implements the StorageProvider interface using Google Cloud Storage for document storage.
+package gcs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	cloudstorage "cloud.google.com/go/storage" // v1.30.0+
+	"google.golang.org/api/option"             // v0.114.0+
+
+	".."
+	"../../../pkg/config"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+)
+
+// gcsProvider implements the StorageProvider interface using Google Cloud Storage, with one bucket per container
+type gcsProvider struct {
+	client *cloudstorage.Client
+	config config.GCSStorageConfig
+}
+
+// NewGCSProvider creates a new Google Cloud Storage provider with the provided configuration.
+// Download URLs are signed with the service account of the credentials.
+func NewGCSProvider(ctx context.Context, cfg config.GCSStorageConfig) (storage.StorageProvider, error) {
+	if cfg.Bucket == "" || cfg.TempBucket == "" || cfg.QuarantineBucket == "" {
+		return nil, errors.NewValidationError("GCS document, temporary and quarantine buckets cannot be empty")
+	}
+
+	var opts []option.ClientOption
+	if cfg.CredentialsFile != "" {
+		opts = append(opts, option.WithCredentialsFile(cfg.CredentialsFile))
+	}
+	if cfg.Endpoint != "" {
+		opts = append(opts, option.WithEndpoint(cfg.Endpoint))
+	}
+
+	client, err := cloudstorage.NewClient(ctx, opts...)
+	if err != nil {
+		logger.Error("Failed to create Google Cloud Storage client", "error", err.Error())
+		return nil, errors.Wrap(err, "failed to create Google Cloud Storage client")
+	}
+
+	return &gcsProvider{
+		client: client,
+		config: cfg,
+	}, nil
+}
+
+// Put uploads content to the container's bucket. The content is uploaded in chunks as it is read,
+// so it never has to fit in memory. Objects are encrypted with Google managed keys.
+func (p *gcsProvider) Put(ctx context.Context, container storage.Container, key string, content io.Reader, size int64, opts storage.ObjectOptions) error {
+	if opts.EncryptionKeyID != "" {
+		return storage.ErrEncryptionKeyNotSupported
+	}
+
+	// Cancelling the context is the only way to abort an upload without leaving a partial object
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	writer := p.object(container, key).NewWriter(ctx)
+	writer.ContentType = opts.ContentType
+
+	if _, err := io.Copy(writer, content); err != nil {
+		cancel()
+		writer.Close()
+		return err
+	}
+	return writer.Close()
+}
+
+// Copy copies an object on the GCS side, keeping its content type
+func (p *gcsProvider) Copy(ctx context.Context, srcContainer storage.Container, srcKey string, dstContainer storage.Container, dstKey string, opts storage.ObjectOptions) error {
+	if opts.EncryptionKeyID != "" {
+		return storage.ErrEncryptionKeyNotSupported
+	}
+	// Objects are always encrypted with Google managed keys, so copying an object onto itself changes nothing
+	if srcContainer == dstContainer && srcKey == dstKey {
+		return nil
+	}
+
+	_, err := p.object(dstContainer, dstKey).CopierFrom(p.object(srcContainer, srcKey)).Run(ctx)
+	return err
+}
+
+// Get downloads an object
+func (p *gcsProvider) Get(ctx context.Context, container storage.Container, key string) (io.ReadCloser, error) {
+	return p.GetRange(ctx, container, key, 0, -1)
+}
+
+// GetRange downloads part of an object, or the rest of it for a negative length. The range is
+// passed to GCS, so only the requested bytes are transferred.
+func (p *gcsProvider) GetRange(ctx context.Context, container storage.Container, key string, offset int64, length int64) (io.ReadCloser, error) {
+	reader, err := p.object(container, key).NewRangeReader(ctx, offset, length)
+	if err != nil {
+		return nil, err
+	}
+	return reader, nil
+}
+
+// Delete deletes an object. Deleting an object that does not exist succeeds, as it does in S3.
+func (p *gcsProvider) Delete(ctx context.Context, container storage.Container, key string) error {
+	err := p.object(container, key).Delete(ctx)
+	if err != nil && err != cloudstorage.ErrObjectNotExist {
+		return err
+	}
+	return nil
+}
+
+// PresignGet returns a V4 signed URL downloading the object as an attachment
+func (p *gcsProvider) PresignGet(ctx context.Context, container storage.Container, key string, fileName string, expiry time.Duration) (string, error) {
+	return p.client.Bucket(p.bucket(container)).SignedURL(key, &cloudstorage.SignedURLOptions{
+		Scheme:  cloudstorage.SigningSchemeV4,
+		Method:  http.MethodGet,
+		Expires: time.Now().Add(expiry),
+		QueryParameters: url.Values{
+			"response-content-disposition": {fmt.Sprintf("attachment; filename=%s", fileName)},
+		},
+	})
+}
+
+// object returns the handle of an object
+func (p *gcsProvider) object(container storage.Container, key string) *cloudstorage.ObjectHandle {
+	return p.client.Bucket(p.bucket(container)).Object(key)
+}
+
+// bucket returns the bucket of a container
+func (p *gcsProvider) bucket(container storage.Container) string {
+	switch container {
+	case storage.ContainerTemp:
+		return p.config.TempBucket
+	case storage.ContainerQuarantine:
+		return p.config.QuarantineBucket
+	default:
+		return p.config.Bucket
+	}
+}
@@ -0,0 +1,232 @@
+// Package local implements the StorageProvider interface on the local filesystem, so the platform
+// can run in development without a cloud storage account. It is not meant for production use:
+// objects are neither replicated nor encrypted, and download URLs only work on the same machine.
+package local
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	".."
+	"../../../pkg/config"
+	"../../../pkg/errors"
+	"../../../pkg/utils"
+)
+
+// localProvider implements the StorageProvider interface with one directory per container
+type localProvider struct {
+	root string
+}
+
+// NewLocalProvider creates a new local filesystem storage provider storing objects under the configured root
+func NewLocalProvider(cfg config.LocalStorageConfig) (storage.StorageProvider, error) {
+	if cfg.Root == "" {
+		return nil, errors.NewValidationError("local storage root cannot be empty")
+	}
+
+	root, err := filepath.Abs(cfg.Root)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to resolve local storage root")
+	}
+	for _, container := range []storage.Container{storage.ContainerDocuments, storage.ContainerTemp, storage.ContainerQuarantine} {
+		if err := utils.EnsureDirectoryExists(filepath.Join(root, string(container))); err != nil {
+			return nil, errors.Wrap(err, "failed to create local storage directory")
+		}
+	}
+
+	return &localProvider{root: root}, nil
+}
+
+// Put writes content to a file. The content is written to a temporary file first and then
+// renamed, so readers never see a partially written object.
+func (p *localProvider) Put(ctx context.Context, container storage.Container, key string, content io.Reader, size int64, opts storage.ObjectOptions) error {
+	if opts.EncryptionKeyID != "" {
+		return storage.ErrEncryptionKeyNotSupported
+	}
+
+	path, err := p.path(container, key)
+	if err != nil {
+		return err
+	}
+	return writeFile(path, content)
+}
+
+// Copy copies a file. Copying a file onto itself leaves it unchanged, as files are not encrypted.
+func (p *localProvider) Copy(ctx context.Context, srcContainer storage.Container, srcKey string, dstContainer storage.Container, dstKey string, opts storage.ObjectOptions) error {
+	if opts.EncryptionKeyID != "" {
+		return storage.ErrEncryptionKeyNotSupported
+	}
+
+	srcPath, err := p.path(srcContainer, srcKey)
+	if err != nil {
+		return err
+	}
+	dstPath, err := p.path(dstContainer, dstKey)
+	if err != nil {
+		return err
+	}
+	if srcPath == dstPath {
+		return nil
+	}
+
+	src, err := openFile(srcPath)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	return writeFile(dstPath, src)
+}
+
+// Get opens a file for reading
+func (p *localProvider) Get(ctx context.Context, container storage.Container, key string) (io.ReadCloser, error) {
+	path, err := p.path(container, key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := openFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// GetRange opens a file for reading length bytes starting at offset
+func (p *localProvider) GetRange(ctx context.Context, container storage.Container, key string, offset int64, length int64) (io.ReadCloser, error) {
+	path, err := p.path(container, key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := openFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return struct {
+		io.Reader
+		io.Closer
+	}{utils.LimitReader(file, length), file}, nil
+}
+
+// Delete deletes a file. Deleting a file that does not exist succeeds, as it does in cloud storage.
+func (p *localProvider) Delete(ctx context.Context, container storage.Container, key string) error {
+	path, err := p.path(container, key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// PresignGet returns a file URL of the object. Files cannot be signed, so the URL does not expire
+// and only works where the storage root is accessible.
+func (p *localProvider) PresignGet(ctx context.Context, container storage.Container, key string, fileName string, expiry time.Duration) (string, error) {
+	path, err := p.path(container, key)
+	if err != nil {
+		return "", err
+	}
+	fileURL := url.URL{Scheme: "file", Path: filepath.ToSlash(path)}
+	return fileURL.String(), nil
+}
+
+// PurgePrefix deletes the files of the objects under prefix. Files are not versioned or
+// replicated, so deleting them erases the objects.
+func (p *localProvider) PurgePrefix(ctx context.Context, container storage.Container, prefix string) (int, error) {
+	return p.walkPrefix(container, prefix, os.Remove)
+}
+
+// CountPrefix returns the number of files of the objects under prefix
+func (p *localProvider) CountPrefix(ctx context.Context, container storage.Container, prefix string) (int, error) {
+	return p.walkPrefix(container, prefix, func(string) error { return nil })
+}
+
+// walkPrefix passes the files of the objects under prefix to visit and returns their number. Only
+// the directory holding the prefix is walked, rather than the whole container.
+func (p *localProvider) walkPrefix(container storage.Container, prefix string, visit func(path string) error) (int, error) {
+	// An empty prefix would reach every tenant
+	dirPrefix := prefix[:strings.LastIndex(prefix, "/")+1]
+	if strings.Trim(dirPrefix, "/") == "" {
+		return 0, errors.NewValidationError(fmt.Sprintf("invalid storage prefix: %s", prefix))
+	}
+	dir, err := p.path(container, dirPrefix)
+	if err != nil {
+		return 0, err
+	}
+
+	containerDir := filepath.Join(p.root, string(container))
+	count := 0
+	err = filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		key := filepath.ToSlash(strings.TrimPrefix(path, containerDir+string(filepath.Separator)))
+		if entry.IsDir() || !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+		if err := visit(path); err != nil {
+			return err
+		}
+		count++
+		return nil
+	})
+	return count, err
+}
+
+// path returns the file of an object, rejecting keys that would resolve outside the container
+func (p *localProvider) path(container storage.Container, key string) (string, error) {
+	dir := filepath.Join(p.root, string(container))
+	path := filepath.Join(dir, filepath.FromSlash(key))
+	if key == "" || !strings.HasPrefix(path, dir+string(filepath.Separator)) {
+		return "", errors.NewValidationError(fmt.Sprintf("invalid storage key: %s", key))
+	}
+	return path, nil
+}
+
+// openFile opens a file, reporting a missing file as a not found error
+func openFile(path string) (*os.File, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, errors.NewResourceNotFoundError("object not found in local storage")
+	}
+	return file, err
+}
+
+// writeFile atomically replaces the file at path with content
+func writeFile(path string, content io.Reader) error {
+	if err := utils.EnsureDirectoryExists(filepath.Dir(path)); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // No-op once the file has been renamed
+
+	if _, err := utils.CopyReader(content, tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,120 @@
+package local
+
+import (
+	"context"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"  // v1.8.0+
+	"github.com/stretchr/testify/require" // v1.8.0+
+
+	".."
+	"../../../pkg/config"
+	"../../../pkg/errors"
+)
+
+const testContent = "test document content"
+
+// createTestProvider creates a local storage provider rooted in a temporary directory
+func createTestProvider(t *testing.T) storage.StorageProvider {
+	provider, err := NewLocalProvider(config.LocalStorageConfig{Root: t.TempDir()})
+	require.NoError(t, err)
+	return provider
+}
+
+// readObject reads an object of the provider
+func readObject(t *testing.T, provider storage.StorageProvider, container storage.Container, key string) string {
+	content, err := provider.Get(context.Background(), container, key)
+	require.NoError(t, err)
+	defer content.Close()
+
+	data, err := ioutil.ReadAll(content)
+	require.NoError(t, err)
+	return string(data)
+}
+
+// TestPutCopyDelete tests the lifecycle of a document moved from temporary to permanent storage
+func TestPutCopyDelete(t *testing.T) {
+	ctx := context.Background()
+	provider := createTestProvider(t)
+
+	err := provider.Put(ctx, storage.ContainerTemp, "temp/tenant-123/doc-123", strings.NewReader(testContent), -1, storage.ObjectOptions{})
+	require.NoError(t, err)
+	err = provider.Copy(ctx, storage.ContainerTemp, "temp/tenant-123/doc-123", storage.ContainerDocuments, "tenant-123/folder-123/doc-123/v1", storage.ObjectOptions{})
+	require.NoError(t, err)
+	require.NoError(t, provider.Delete(ctx, storage.ContainerTemp, "temp/tenant-123/doc-123"))
+
+	assert.Equal(t, testContent, readObject(t, provider, storage.ContainerDocuments, "tenant-123/folder-123/doc-123/v1"))
+	_, err = provider.Get(ctx, storage.ContainerTemp, "temp/tenant-123/doc-123")
+	assert.True(t, errors.IsResourceNotFoundError(err))
+
+	// Deleting a missing object succeeds, as it does in cloud storage
+	assert.NoError(t, provider.Delete(ctx, storage.ContainerTemp, "temp/tenant-123/doc-123"))
+}
+
+// TestGetRange tests reading part of an object
+func TestGetRange(t *testing.T) {
+	ctx := context.Background()
+	provider := createTestProvider(t)
+	require.NoError(t, provider.Put(ctx, storage.ContainerDocuments, "tenant-123/doc", strings.NewReader(testContent), int64(len(testContent)), storage.ObjectOptions{}))
+
+	content, err := provider.GetRange(ctx, storage.ContainerDocuments, "tenant-123/doc", 5, 8)
+	require.NoError(t, err)
+	defer content.Close()
+	data, err := ioutil.ReadAll(content)
+
+	assert.NoError(t, err)
+	assert.Equal(t, testContent[5:13], string(data))
+}
+
+// TestPath_Traversal tests that keys cannot reach files outside their container
+func TestPath_Traversal(t *testing.T) {
+	ctx := context.Background()
+	provider := createTestProvider(t)
+
+	for _, key := range []string{"", "../temp/tenant-123/doc-123", "tenant-123/../../secret"} {
+		err := provider.Put(ctx, storage.ContainerDocuments, key, strings.NewReader(testContent), -1, storage.ObjectOptions{})
+		assert.True(t, errors.IsValidationError(err), key)
+	}
+}
+
+// TestEncryptionKeyNotSupported tests that customer managed keys are rejected rather than ignored
+func TestEncryptionKeyNotSupported(t *testing.T) {
+	provider := createTestProvider(t)
+
+	err := provider.Put(context.Background(), storage.ContainerTemp, "temp/tenant-123/doc-123", strings.NewReader(testContent), -1,
+		storage.ObjectOptions{EncryptionKeyID: "arn:aws:kms:us-east-1:222222222222:key/tenant"})
+
+	assert.Equal(t, storage.ErrEncryptionKeyNotSupported, err)
+}
+
+// TestPurgePrefix tests that the objects under a prefix are deleted, leaving those of other tenants
+func TestPurgePrefix(t *testing.T) {
+	ctx := context.Background()
+	provider := createTestProvider(t)
+	purger := provider.(storage.PrefixPurger)
+	for _, key := range []string{"tenant-123/folder-1/doc-1/v1", "tenant-123/folder-2/doc-2/v1", "tenant-1234/folder-1/doc-1/v1"} {
+		require.NoError(t, provider.Put(ctx, storage.ContainerDocuments, key, strings.NewReader(testContent), -1, storage.ObjectOptions{}))
+	}
+
+	count, err := purger.CountPrefix(ctx, storage.ContainerDocuments, "tenant-123/")
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	deleted, err := purger.PurgePrefix(ctx, storage.ContainerDocuments, "tenant-123/")
+	require.NoError(t, err)
+	assert.Equal(t, 2, deleted)
+
+	count, err = purger.CountPrefix(ctx, storage.ContainerDocuments, "tenant-123/")
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+	assert.Equal(t, testContent, readObject(t, provider, storage.ContainerDocuments, "tenant-1234/folder-1/doc-1/v1"))
+
+	// Prefixes of tenants without objects have nothing to purge, and an empty prefix is refused
+	deleted, err = purger.PurgePrefix(ctx, storage.ContainerQuarantine, "quarantine/tenant-123/")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, deleted)
+	_, err = purger.PurgePrefix(ctx, storage.ContainerDocuments, "")
+	assert.True(t, errors.IsValidationError(err))
+}
@@ -0,0 +1,114 @@
+// Package storage implements the StorageService interface on top of the object storage of a
+// cloud provider, so that documents can be kept in S3, Azure Blob Storage, GCS or on local disk.
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+// Storage providers selectable through the configuration
+const (
+	// ProviderS3 stores documents in AWS S3 or an S3 compatible service
+	ProviderS3 = "s3"
+
+	// ProviderAzure stores documents in Azure Blob Storage
+	ProviderAzure = "azure"
+
+	// ProviderGCS stores documents in Google Cloud Storage
+	ProviderGCS = "gcs"
+
+	// ProviderLocal stores documents on the local filesystem, for development
+	ProviderLocal = "local"
+)
+
+// Container identifies one of the buckets documents are stored in. Each provider maps the
+// containers to buckets, blob containers or directories of its own configuration.
+type Container string
+
+const (
+	// ContainerDocuments holds processed documents, and tenant bundles for moving tenants between environments
+	ContainerDocuments Container = "documents"
+
+	// ContainerTemp holds documents while they are processed, and export archives
+	ContainerTemp Container = "temp"
+
+	// ContainerQuarantine holds documents in which a virus was detected
+	ContainerQuarantine Container = "quarantine"
+)
+
+// ErrEncryptionKeyNotSupported is returned by providers that cannot encrypt objects with a
+// customer managed KMS key
+var ErrEncryptionKeyNotSupported = errors.New("storage provider does not support customer managed encryption keys")
+
+// ObjectOptions holds the properties objects are written with
+type ObjectOptions struct {
+	// ContentType is the MIME type of the object
+	ContentType string
+
+	// EncryptionKeyID is the KMS key the object is encrypted with; empty for provider managed keys
+	EncryptionKeyID string
+}
+
+// StorageProvider stores objects in the containers of an object storage service. Providers only
+// move bytes; storage paths, tenant isolation and deduplication are handled by the storage service.
+type StorageProvider interface {
+	// Put stores content as an object. Size is -1 when the length of the content is not known
+	// up front, in which case the content is uploaded as it is read.
+	Put(ctx context.Context, container Container, key string, content io.Reader, size int64, opts ObjectOptions) error
+
+	// Copy copies an object within the storage service. Copying an object onto itself rewrites it
+	// with the given encryption key, keeping its content type.
+	Copy(ctx context.Context, srcContainer Container, srcKey string, dstContainer Container, dstKey string, opts ObjectOptions) error
+
+	// Get returns the content of an object
+	Get(ctx context.Context, container Container, key string) (io.ReadCloser, error)
+
+	// GetRange returns length bytes of an object starting at offset
+	GetRange(ctx context.Context, container Container, key string, offset int64, length int64) (io.ReadCloser, error)
+
+	// Delete deletes an object
+	Delete(ctx context.Context, container Container, key string) error
+
+	// PresignGet returns a URL downloading an object as fileName without further authentication,
+	// valid for the given duration
+	PresignGet(ctx context.Context, container Container, key string, fileName string, expiry time.Duration) (string, error)
+}
+
+// ReplicationReport is the outcome of comparing the replica of the document container with it
+type ReplicationReport struct {
+	// Checked is the number of documents expected in the replica
+	Checked int
+
+	// Missing is the number of documents absent from the replica
+	Missing int
+
+	// Mismatched is the number of documents whose size differs in the replica
+	Mismatched int
+
+	// Samples are the keys of some of the missing and mismatched documents, to start an
+	// investigation from
+	Samples []string
+}
+
+// ReplicationChecker is implemented by providers whose document container is replicated to
+// another region, so that a replica that stopped replicating is noticed before it is failed over to
+type ReplicationChecker interface {
+	// CheckReplication compares the replica with the document container, skipping the documents
+	// written within settle, which replication may not have copied yet
+	CheckReplication(ctx context.Context, settle time.Duration) (ReplicationReport, error)
+}
+
+// PrefixPurger is implemented by providers that can permanently delete all objects under a key
+// prefix, so that the data of an offboarded tenant can be erased and its erasure verified
+type PrefixPurger interface {
+	// PurgePrefix deletes every object under prefix in a container, with its earlier versions and
+	// its copies in replicas of the container. It returns the number of objects deleted.
+	PurgePrefix(ctx context.Context, container Container, prefix string) (int, error)
+
+	// CountPrefix returns the number of objects under prefix in a container, counting earlier
+	// versions and copies in replicas as PurgePrefix deletes them
+	CountPrefix(ctx context.Context, container Container, prefix string) (int, error)
+}
@@ -0,0 +1,31 @@
+// Package providers creates the storage provider selected by the configuration. It is separate
+// from package storage because the drivers themselves depend on package storage.
+package providers
+
+import (
+	"context"
+	"fmt"
+
+	".."
+	"../../../pkg/config"
+	"../azure"
+	"../gcs"
+	"../local"
+	"../s3"
+)
+
+// New creates the storage provider selected by cfg.Storage.Provider, defaulting to S3
+func New(ctx context.Context, cfg config.StorageConfig, s3Config config.S3Config) (storage.StorageProvider, error) {
+	switch cfg.Provider {
+	case "", storage.ProviderS3:
+		return s3.NewS3Provider(s3Config)
+	case storage.ProviderAzure:
+		return azure.NewAzureProvider(cfg.Azure)
+	case storage.ProviderGCS:
+		return gcs.NewGCSProvider(ctx, cfg.GCS)
+	case storage.ProviderLocal:
+		return local.NewLocalProvider(cfg.Local)
+	default:
+		return nil, fmt.Errorf("unsupported storage provider: %s", cfg.Provider)
+	}
+}
@@ -0,0 +1,50 @@
+// Package s3 implements the StorageProvider interface using AWS S3 for document storage.
+package s3
+
+import (
+	"github.com/aws/aws-sdk-go/aws"                  // v1.44.0+
+	"github.com/aws/aws-sdk-go/service/s3"           // v1.44.0+
+	"github.com/aws/aws-sdk-go/service/s3/s3manager" // v1.44.0+
+)
+
+// objectEncryption holds the server-side encryption objects are written with. Objects are
+// encrypted with SSE-KMS under keyID, or with S3 managed keys when keyID is empty and S3 managed
+// encryption is not disabled.
+type objectEncryption struct {
+	keyID            string
+	disableS3Managed bool
+}
+
+// none returns whether objects are written without server-side encryption
+func (e objectEncryption) none() bool {
+	return e.keyID == "" && e.disableS3Managed
+}
+
+// applyToUpload sets the encryption of an uploaded object
+func (e objectEncryption) applyToUpload(input *s3manager.UploadInput) {
+	if e.none() {
+		return
+	}
+	if e.keyID == "" {
+		input.ServerSideEncryption = aws.String(s3.ServerSideEncryptionAes256)
+		return
+	}
+	input.ServerSideEncryption = aws.String(s3.ServerSideEncryptionAwsKms)
+	input.SSEKMSKeyId = aws.String(e.keyID)
+	// Bucket keys let S3 reuse data keys across objects instead of calling KMS for every object
+	input.BucketKeyEnabled = aws.Bool(true)
+}
+
+// applyToCopy sets the encryption of a copied object
+func (e objectEncryption) applyToCopy(input *s3.CopyObjectInput) {
+	if e.none() {
+		return
+	}
+	if e.keyID == "" {
+		input.ServerSideEncryption = aws.String(s3.ServerSideEncryptionAes256)
+		return
+	}
+	input.ServerSideEncryption = aws.String(s3.ServerSideEncryptionAwsKms)
+	input.SSEKMSKeyId = aws.String(e.keyID)
+	input.BucketKeyEnabled = aws.Bool(true)
+}
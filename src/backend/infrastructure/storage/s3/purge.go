@@ -0,0 +1,103 @@
+package s3
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"        // v1.44.0+
+	"github.com/aws/aws-sdk-go/service/s3" // v1.44.0+
+
+	".."
+	"../../../pkg/errors"
+)
+
+// purgeTarget is a bucket objects are purged from, with the client of its region
+type purgeTarget struct {
+	client S3API
+	bucket string
+}
+
+// PurgePrefix deletes every version and delete marker under prefix in the bucket of a container,
+// and in the replica of the document bucket, as S3 replication does not replicate deletions.
+// Deleting the current version of an object in a versioned bucket would only hide it behind a
+// delete marker, so versions are deleted by ID.
+func (p *s3Provider) PurgePrefix(ctx context.Context, container storage.Container, prefix string) (int, error) {
+	deleted := 0
+	err := p.visitPrefix(ctx, container, prefix, func(client S3API, bucket string, objects []*s3.ObjectIdentifier) error {
+		output, err := client.DeleteObjectsWithContext(ctx, &s3.DeleteObjectsInput{
+			Bucket: aws.String(bucket),
+			Delete: &s3.Delete{Objects: objects, Quiet: aws.Bool(true)},
+		})
+		if err != nil {
+			return errors.NewDependencyError(fmt.Sprintf("failed to delete objects under %s in bucket %s: %v", prefix, bucket, err))
+		}
+		if len(output.Errors) > 0 {
+			failed := output.Errors[0]
+			return errors.NewDependencyError(fmt.Sprintf("failed to delete %d objects under %s in bucket %s, such as %s: %s",
+				len(output.Errors), prefix, bucket, aws.StringValue(failed.Key), aws.StringValue(failed.Message)))
+		}
+		deleted += len(objects)
+		return nil
+	})
+	return deleted, err
+}
+
+// CountPrefix returns the number of versions and delete markers under prefix in the bucket of a
+// container and in the replica of the document bucket
+func (p *s3Provider) CountPrefix(ctx context.Context, container storage.Container, prefix string) (int, error) {
+	count := 0
+	err := p.visitPrefix(ctx, container, prefix, func(client S3API, bucket string, objects []*s3.ObjectIdentifier) error {
+		count += len(objects)
+		return nil
+	})
+	return count, err
+}
+
+// visitPrefix passes the versions and delete markers under prefix to visit a page at a time, first
+// in the bucket of the container and then in its replica. A page holds at most 1000 objects, the
+// most a DeleteObjects request takes.
+func (p *s3Provider) visitPrefix(ctx context.Context, container storage.Container, prefix string, visit func(S3API, string, []*s3.ObjectIdentifier) error) error {
+	// An empty prefix would reach every tenant
+	if strings.Trim(prefix, "/") == "" {
+		return errors.NewValidationError("storage prefix cannot be empty")
+	}
+
+	targets := []purgeTarget{{p.client, p.bucket(container)}}
+	if p.replica != nil && container == storage.ContainerDocuments {
+		targets = append(targets, purgeTarget{p.replica.client, p.replica.bucket})
+	}
+
+	for _, target := range targets {
+		input := &s3.ListObjectVersionsInput{
+			Bucket: aws.String(target.bucket),
+			Prefix: aws.String(prefix),
+		}
+		for {
+			output, err := target.client.ListObjectVersionsWithContext(ctx, input)
+			if err != nil {
+				return errors.NewDependencyError(fmt.Sprintf("failed to list objects under %s in bucket %s: %v", prefix, target.bucket, err))
+			}
+
+			objects := make([]*s3.ObjectIdentifier, 0, len(output.Versions)+len(output.DeleteMarkers))
+			for _, version := range output.Versions {
+				objects = append(objects, &s3.ObjectIdentifier{Key: version.Key, VersionId: version.VersionId})
+			}
+			for _, marker := range output.DeleteMarkers {
+				objects = append(objects, &s3.ObjectIdentifier{Key: marker.Key, VersionId: marker.VersionId})
+			}
+			if len(objects) > 0 {
+				if err := visit(target.client, target.bucket, objects); err != nil {
+					return err
+				}
+			}
+
+			if !aws.BoolValue(output.IsTruncated) {
+				break
+			}
+			input.KeyMarker = output.NextKeyMarker
+			input.VersionIdMarker = output.NextVersionIdMarker
+		}
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+package s3
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"         // v1.44.0+
+	"github.com/aws/aws-sdk-go/aws/awserr"  // v1.44.0+
+	"github.com/aws/aws-sdk-go/aws/request" // v1.44.0+
+	"github.com/aws/aws-sdk-go/service/s3"  // v1.44.0+
+
+	".."
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+	"../../../pkg/metrics"
+)
+
+const (
+	// defaultFailoverCooldown is how long reads stay on the replica after a regional error when
+	// the configuration sets no cooldown
+	defaultFailoverCooldown = time.Minute
+
+	// maxReplicationSamples bounds the keys a consistency check reports
+	maxReplicationSamples = 100
+)
+
+// replica is the replica of the document bucket in another region. Reads fail over to it when the
+// primary region answers with a regional error, and stay on it for the cooldown, so a region that
+// is down is not retried on every read.
+type replica struct {
+	client   S3API
+	bucket   string
+	cooldown time.Duration
+
+	// primaryDownUntil is the Unix time in nanoseconds until which reads skip the primary
+	primaryDownUntil atomic.Int64
+}
+
+// newReplica creates the replica of the configuration read through client
+func newReplica(client S3API, bucket string, cooldown string) (*replica, error) {
+	r := &replica{client: client, bucket: bucket, cooldown: defaultFailoverCooldown}
+	if cooldown != "" {
+		parsed, err := time.ParseDuration(cooldown)
+		if err != nil || parsed < 0 {
+			return nil, errors.NewValidationError(fmt.Sprintf("invalid S3 replica failover cooldown %q", cooldown))
+		}
+		r.cooldown = parsed
+	}
+	return r, nil
+}
+
+// primaryDown returns whether reads skip the primary region
+func (r *replica) primaryDown() bool {
+	return time.Now().UnixNano() < r.primaryDownUntil.Load()
+}
+
+// markPrimaryDown sends reads to the replica for the cooldown
+func (r *replica) markPrimaryDown(cause error) {
+	if !r.primaryDown() {
+		logger.Warn("Document storage failing over reads to the replica region", "bucket", r.bucket, "cooldown", r.cooldown.String(), "error", cause)
+	}
+	r.primaryDownUntil.Store(time.Now().Add(r.cooldown).UnixNano())
+}
+
+// isRegionalError returns whether err means the region of a bucket cannot serve it, rather than
+// that the request or the object is at fault: a server error, or a request that could not reach
+// S3 or timed out. Errors of a cancelled request are the caller's and never fail over.
+func isRegionalError(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+	if failure, ok := err.(awserr.RequestFailure); ok {
+		return failure.StatusCode() >= http.StatusInternalServerError
+	}
+	if awsErr, ok := err.(awserr.Error); ok {
+		switch awsErr.Code() {
+		case request.ErrCodeRequestError, request.ErrCodeResponseTimeout, request.ErrCodeRead:
+			return true
+		}
+	}
+	return false
+}
+
+// read runs a read against the bucket of container, or against the replica when the read fails
+// with a regional error or the primary failed within the cooldown. Only the document bucket is
+// replicated; temporary and quarantined objects are always read from the primary.
+func (p *s3Provider) read(ctx context.Context, container storage.Container, operation string, fn func(client S3API, bucket string) error) error {
+	if p.replica == nil || container != storage.ContainerDocuments {
+		return fn(p.client, p.bucket(container))
+	}
+
+	if !p.replica.primaryDown() {
+		err := fn(p.client, p.bucket(container))
+		if !isRegionalError(ctx, err) {
+			return err
+		}
+		p.replica.markPrimaryDown(err)
+	}
+	metrics.IncStorageFailoverReads(operation)
+	return fn(p.replica.client, p.replica.bucket)
+}
+
+// CheckReplication compares the replica with the document bucket. Both listings are in key order,
+// so they are walked side by side a page at a time however many documents there are. Sizes are
+// compared rather than ETags, which differ between the buckets for KMS encrypted and multipart
+// objects.
+func (p *s3Provider) CheckReplication(ctx context.Context, settle time.Duration) (storage.ReplicationReport, error) {
+	var report storage.ReplicationReport
+	if p.replica == nil {
+		return report, errors.NewValidationError("document storage has no replica to check")
+	}
+
+	cutoff := time.Now().Add(-settle)
+	primary := &objectLister{client: p.client, bucket: p.config.Bucket}
+	secondary := &objectLister{client: p.replica.client, bucket: p.replica.bucket}
+	source, err := primary.next(ctx)
+	if err != nil {
+		return report, err
+	}
+	copied, err := secondary.next(ctx)
+	if err != nil {
+		return report, err
+	}
+
+	for source != nil {
+		key := aws.StringValue(source.Key)
+		switch {
+		case copied != nil && aws.StringValue(copied.Key) < key:
+			// Deletions are not replicated, so the replica may hold documents since deleted
+			if copied, err = secondary.next(ctx); err != nil {
+				return report, err
+			}
+			continue
+		case aws.TimeValue(source.LastModified).After(cutoff):
+			// Not expected in the replica yet
+		case copied == nil || aws.StringValue(copied.Key) > key:
+			report.Checked++
+			report.Missing++
+			addSample(&report, key)
+		default:
+			report.Checked++
+			if aws.Int64Value(copied.Size) != aws.Int64Value(source.Size) {
+				report.Mismatched++
+				addSample(&report, key)
+			}
+		}
+
+		if copied != nil && aws.StringValue(copied.Key) == key {
+			if copied, err = secondary.next(ctx); err != nil {
+				return report, err
+			}
+		}
+		if source, err = primary.next(ctx); err != nil {
+			return report, err
+		}
+	}
+	return report, nil
+}
+
+// objectLister lists the objects of a bucket in key order, a page at a time
+type objectLister struct {
+	client S3API
+	bucket string
+	page   []*s3.Object
+	token  *string
+	done   bool
+}
+
+// next returns the next object of the bucket, or nil once all are listed
+func (l *objectLister) next(ctx context.Context) (*s3.Object, error) {
+	for len(l.page) == 0 {
+		if l.done {
+			return nil, nil
+		}
+		output, err := l.client.ListObjectsV2WithContext(ctx, &s3.ListObjectsV2Input{
+			Bucket:            aws.String(l.bucket),
+			ContinuationToken: l.token,
+		})
+		if err != nil {
+			return nil, errors.NewDependencyError(fmt.Sprintf("failed to list bucket %s: %v", l.bucket, err))
+		}
+		l.page = output.Contents
+		l.token = output.NextContinuationToken
+		l.done = !aws.BoolValue(output.IsTruncated)
+	}
+
+	object := l.page[0]
+	l.page = l.page[1:]
+	return object, nil
+}
+
+// addSample adds key to the samples of report until it holds the maximum
+func addSample(report *storage.ReplicationReport, key string) {
+	if len(report.Samples) < maxReplicationSamples {
+		report.Samples = append(report.Samples, key)
+	}
+}
@@ -0,0 +1,320 @@
+// Package s3 implements the StorageProvider interface using AWS S3 for document storage.
+package s3
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"                                 // v1.44.0+
+	"github.com/aws/aws-sdk-go/aws/credentials"                     // v1.44.0+
+	"github.com/aws/aws-sdk-go/aws/request"                         // v1.44.0+
+	"github.com/aws/aws-sdk-go/aws/session"                         // v1.44.0+
+	"github.com/aws/aws-sdk-go/service/s3"                          // v1.44.0+
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"                // v1.44.0+
+	"github.com/aws/aws-sdk-go/service/s3/s3manager/s3manageriface" // v1.44.0+
+	"go.opentelemetry.io/otel/trace"                                // v1.11.0+
+
+	".."
+	"../../../pkg/config"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+	"../../../pkg/metrics"
+	"../../../pkg/tracing"
+)
+
+// S3API is the subset of the S3 client used by the provider
+type S3API interface {
+	CopyObjectWithContext(ctx aws.Context, input *s3.CopyObjectInput, opts ...request.Option) (*s3.CopyObjectOutput, error)
+	GetObjectWithContext(ctx aws.Context, input *s3.GetObjectInput, opts ...request.Option) (*s3.GetObjectOutput, error)
+	DeleteObjectWithContext(ctx aws.Context, input *s3.DeleteObjectInput, opts ...request.Option) (*s3.DeleteObjectOutput, error)
+	GetObjectRequest(input *s3.GetObjectInput) (*request.Request, *s3.GetObjectOutput)
+	HeadBucketWithContext(ctx aws.Context, input *s3.HeadBucketInput, opts ...request.Option) (*s3.HeadBucketOutput, error)
+	ListObjectsV2WithContext(ctx aws.Context, input *s3.ListObjectsV2Input, opts ...request.Option) (*s3.ListObjectsV2Output, error)
+	ListObjectVersionsWithContext(ctx aws.Context, input *s3.ListObjectVersionsInput, opts ...request.Option) (*s3.ListObjectVersionsOutput, error)
+	DeleteObjectsWithContext(ctx aws.Context, input *s3.DeleteObjectsInput, opts ...request.Option) (*s3.DeleteObjectsOutput, error)
+}
+
+// s3Provider implements the StorageProvider interface using AWS S3, with one bucket per container
+// and, when configured, a replica of the document bucket in another region serving reads
+type s3Provider struct {
+	client   S3API
+	uploader s3manageriface.UploaderAPI
+	config   config.S3Config
+	replica  *replica
+}
+
+// NewS3Provider creates a new S3 storage provider with the provided configuration. A custom
+// endpoint points the provider at an S3 compatible service such as MinIO or Ceph RGW instead of AWS.
+func NewS3Provider(cfg config.S3Config) (storage.StorageProvider, error) {
+	sess, err := newSession(cfg, cfg.Region, cfg.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	var replicaClient S3API
+	if cfg.Replica.Bucket != "" {
+		region := cfg.Replica.Region
+		if region == "" {
+			region = cfg.Region
+		}
+		replicaSess, err := newSession(cfg, region, cfg.Replica.Endpoint)
+		if err != nil {
+			return nil, err
+		}
+		replicaClient = s3.New(replicaSess)
+	}
+
+	return NewS3ProviderWithReplica(s3.New(sess), s3manager.NewUploader(sess), replicaClient, cfg)
+}
+
+// newSession creates a traced AWS session for the S3 endpoint of a region, with the credentials
+// and transport settings of cfg
+func newSession(cfg config.S3Config, region string, endpoint string) (*session.Session, error) {
+	awsConfig := &aws.Config{
+		Region:           aws.String(region),
+		S3ForcePathStyle: aws.Bool(cfg.ForcePathStyle),
+		DisableSSL:       aws.Bool(!cfg.UseSSL),
+	}
+	if endpoint != "" {
+		awsConfig.Endpoint = aws.String(endpoint)
+	}
+	if cfg.AccessKey != "" {
+		awsConfig.Credentials = credentials.NewStaticCredentials(cfg.AccessKey, cfg.SecretKey, "")
+	}
+	if cfg.SkipTLSVerify {
+		logger.Warn("TLS certificate verification is disabled for document storage", "endpoint", endpoint)
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+		awsConfig.HTTPClient = &http.Client{Transport: transport}
+	}
+
+	// Create AWS session
+	sess, err := session.NewSession(awsConfig)
+	if err != nil {
+		logger.Error("Failed to create AWS session", "error", err.Error())
+		return nil, errors.Wrap(err, "failed to create AWS session for document storage")
+	}
+	traceRequests(&sess.Handlers)
+	return sess, nil
+}
+
+// requestSpanKey is the context key the span of an S3 request is kept under until it completes
+type requestSpanKey struct{}
+
+// traceRequests records a client span for each request sent with handlers, including the parts of
+// multipart uploads. Presigned requests are not sent by the provider and are not traced.
+func traceRequests(handlers *request.Handlers) {
+	handlers.Build.PushFrontNamed(request.NamedHandler{
+		Name: "tracing.StartSpan",
+		Fn: func(r *request.Request) {
+			if r.ExpireTime > 0 {
+				return
+			}
+			ctx, span := tracing.StartSpan(r.Context(), "S3 "+r.Operation.Name, trace.WithSpanKind(trace.SpanKindClient))
+			tracing.AddAttribute(span, "aws.service", "s3")
+			tracing.AddAttribute(span, "aws.operation", r.Operation.Name)
+			r.SetContext(context.WithValue(ctx, requestSpanKey{}, span))
+		},
+	})
+	handlers.Complete.PushBackNamed(request.NamedHandler{
+		Name: "tracing.EndSpan",
+		Fn: func(r *request.Request) {
+			// Requests that failed before they were built have no span of their own
+			span, ok := r.Context().Value(requestSpanKey{}).(trace.Span)
+			if !ok {
+				return
+			}
+			if r.HTTPResponse != nil {
+				tracing.AddAttribute(span, "http.status_code", r.HTTPResponse.StatusCode)
+			}
+			tracing.EndSpan(span, r.Error)
+		},
+	})
+}
+
+// NewS3ProviderWithClient creates an S3 storage provider using the given S3 client and uploader
+func NewS3ProviderWithClient(client S3API, uploader s3manageriface.UploaderAPI, cfg config.S3Config) (storage.StorageProvider, error) {
+	return NewS3ProviderWithReplica(client, uploader, nil, cfg)
+}
+
+// NewS3ProviderWithReplica creates an S3 storage provider using the given S3 client and uploader,
+// failing reads of documents over to the replica bucket of cfg through replicaClient. Reads do not
+// fail over when replicaClient is nil.
+func NewS3ProviderWithReplica(client S3API, uploader s3manageriface.UploaderAPI, replicaClient S3API, cfg config.S3Config) (storage.StorageProvider, error) {
+	if client == nil {
+		return nil, errors.NewValidationError("S3 client cannot be nil")
+	}
+	if uploader == nil {
+		return nil, errors.NewValidationError("S3 uploader cannot be nil")
+	}
+	if cfg.Bucket == "" || cfg.TempBucket == "" || cfg.QuarantineBucket == "" {
+		return nil, errors.NewValidationError("S3 document, temporary and quarantine buckets cannot be empty")
+	}
+
+	provider := &s3Provider{
+		client:   client,
+		uploader: uploader,
+		config:   cfg,
+	}
+	if replicaClient != nil {
+		if cfg.Replica.Bucket == "" {
+			return nil, errors.NewValidationError("S3 replica bucket cannot be empty")
+		}
+		r, err := newReplica(replicaClient, cfg.Replica.Bucket, cfg.Replica.FailoverCooldown)
+		if err != nil {
+			return nil, err
+		}
+		provider.replica = r
+	}
+	return provider, nil
+}
+
+// Put uploads content to the container's bucket. Content of unknown size is sent as a
+// multipart upload, so it never has to fit in memory.
+func (p *s3Provider) Put(ctx context.Context, container storage.Container, key string, content io.Reader, size int64, opts storage.ObjectOptions) error {
+	uploadInput := &s3manager.UploadInput{
+		Bucket: aws.String(p.bucket(container)),
+		Key:    aws.String(key),
+		Body:   content,
+	}
+	if opts.ContentType != "" {
+		uploadInput.ContentType = aws.String(opts.ContentType)
+	}
+	if size >= 0 {
+		uploadInput.ContentLength = aws.Int64(size)
+	}
+	p.encryption(opts).applyToUpload(uploadInput) // Enable server-side encryption
+
+	_, err := p.uploader.UploadWithContext(ctx, uploadInput)
+	return err
+}
+
+// Copy copies an object between buckets on the S3 side, without transferring its content
+func (p *s3Provider) Copy(ctx context.Context, srcContainer storage.Container, srcKey string, dstContainer storage.Container, dstKey string, opts storage.ObjectOptions) error {
+	encryption := p.encryption(opts)
+	// Without server-side encryption a copy onto itself changes nothing, and S3 rejects it
+	if encryption.none() && srcContainer == dstContainer && srcKey == dstKey {
+		return nil
+	}
+
+	copyInput := &s3.CopyObjectInput{
+		Bucket:     aws.String(p.bucket(dstContainer)),
+		CopySource: aws.String(fmt.Sprintf("%s/%s", p.bucket(srcContainer), srcKey)),
+		Key:        aws.String(dstKey),
+		// Keep the content type; a copy onto itself is allowed because the encryption is replaced
+		MetadataDirective: aws.String(s3.MetadataDirectiveCopy),
+	}
+	encryption.applyToCopy(copyInput) // Enable server-side encryption
+
+	_, err := p.client.CopyObjectWithContext(ctx, copyInput)
+	return err
+}
+
+// Get returns the content of an object, from the replica region when the primary fails
+func (p *s3Provider) Get(ctx context.Context, container storage.Container, key string) (io.ReadCloser, error) {
+	var body io.ReadCloser
+	err := p.read(ctx, container, "get", func(client S3API, bucket string) error {
+		result, err := client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(key),
+		})
+		if err != nil {
+			return err
+		}
+		body = result.Body
+		return nil
+	})
+	return body, err
+}
+
+// GetRange returns part of an object. The range is passed to S3, so only the requested bytes are transferred.
+func (p *s3Provider) GetRange(ctx context.Context, container storage.Container, key string, offset int64, length int64) (io.ReadCloser, error) {
+	var body io.ReadCloser
+	err := p.read(ctx, container, "get_range", func(client S3API, bucket string) error {
+		result, err := client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(key),
+			Range:  aws.String(fmt.Sprintf("bytes=%d-%d", offset, offset+length-1)),
+		})
+		if err != nil {
+			return err
+		}
+		body = result.Body
+		return nil
+	})
+	return body, err
+}
+
+// Delete deletes an object
+func (p *s3Provider) Delete(ctx context.Context, container storage.Container, key string) error {
+	_, err := p.client.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(p.bucket(container)),
+		Key:    aws.String(key),
+	})
+	return err
+}
+
+// PresignGet returns a presigned GetObject URL downloading the object as an attachment. Signing
+// does not reach S3, so documents are signed for the replica region while reads are failed over.
+func (p *s3Provider) PresignGet(ctx context.Context, container storage.Container, key string, fileName string, expiry time.Duration) (string, error) {
+	client, bucket := p.client, p.bucket(container)
+	if p.replica != nil && container == storage.ContainerDocuments && p.replica.primaryDown() {
+		client, bucket = p.replica.client, p.replica.bucket
+		metrics.IncStorageFailoverReads("presign")
+	}
+
+	req, _ := client.GetObjectRequest(&s3.GetObjectInput{
+		Bucket:                     aws.String(bucket),
+		Key:                        aws.String(key),
+		ResponseContentDisposition: aws.String(fmt.Sprintf("attachment; filename=%s", fileName)),
+	})
+	req.SetContext(ctx)
+
+	return req.Presign(expiry)
+}
+
+// Ping checks the document bucket exists and the provider's credentials can access it. When the
+// primary region fails with a replica configured, reads are failed over and the provider stays
+// ready as long as the replica can be reached, so documents can still be downloaded.
+func (p *s3Provider) Ping(ctx context.Context) error {
+	_, err := p.client.HeadBucketWithContext(ctx, &s3.HeadBucketInput{
+		Bucket: aws.String(p.config.Bucket),
+	})
+	if err == nil {
+		return nil
+	}
+	if p.replica != nil && isRegionalError(ctx, err) {
+		p.replica.markPrimaryDown(err)
+		_, replicaErr := p.replica.client.HeadBucketWithContext(ctx, &s3.HeadBucketInput{
+			Bucket: aws.String(p.replica.bucket),
+		})
+		if replicaErr == nil {
+			return nil
+		}
+	}
+	return errors.NewDependencyError(fmt.Sprintf("failed to access bucket %s: %s", p.config.Bucket, err.Error()))
+}
+
+// bucket returns the bucket of a container
+func (p *s3Provider) bucket(container storage.Container) string {
+	switch container {
+	case storage.ContainerTemp:
+		return p.config.TempBucket
+	case storage.ContainerQuarantine:
+		return p.config.QuarantineBucket
+	default:
+		return p.config.Bucket
+	}
+}
+
+// encryption returns the server-side encryption an object is written with
+func (p *s3Provider) encryption(opts storage.ObjectOptions) objectEncryption {
+	return objectEncryption{
+		keyID:            opts.EncryptionKeyID,
+		disableS3Managed: p.config.DisableServerSideEncryption,
+	}
+}
@@ -0,0 +1,375 @@
+package s3
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"                  // v1.44.0+
+	"github.com/aws/aws-sdk-go/aws/awserr"           // v1.44.0+
+	"github.com/aws/aws-sdk-go/aws/credentials"      // v1.44.0+
+	"github.com/aws/aws-sdk-go/aws/request"          // v1.44.0+
+	"github.com/aws/aws-sdk-go/aws/session"          // v1.44.0+
+	"github.com/aws/aws-sdk-go/service/s3"           // v1.44.0+
+	"github.com/aws/aws-sdk-go/service/s3/s3manager" // v1.44.0+
+	"github.com/stretchr/testify/assert"             // v1.8.0+
+	"github.com/stretchr/testify/mock"               // v1.8.0+
+	"github.com/stretchr/testify/require"            // v1.8.0+
+
+	".."
+	"../../../pkg/config"
+	"../../../pkg/errors"
+)
+
+const testKeyID = "arn:aws:kms:us-east-1:222222222222:key/tenant"
+
+// Test helper function to create a test S3 configuration
+func createTestConfig() config.S3Config {
+	return config.S3Config{
+		Region:           "us-east-1",
+		Endpoint:         "http://localhost:4566",
+		AccessKey:        "test",
+		SecretKey:        "test",
+		Bucket:           "test-bucket",
+		TempBucket:       "test-temp-bucket",
+		QuarantineBucket: "test-quarantine-bucket",
+		ForcePathStyle:   true,
+	}
+}
+
+// mockS3Client is a mock implementation of the S3API interface for testing
+type mockS3Client struct {
+	S3API
+	mock.Mock
+}
+
+func (m *mockS3Client) CopyObjectWithContext(ctx aws.Context, input *s3.CopyObjectInput, opts ...request.Option) (*s3.CopyObjectOutput, error) {
+	args := m.Called(ctx, input)
+	return &s3.CopyObjectOutput{}, args.Error(0)
+}
+
+func (m *mockS3Client) GetObjectWithContext(ctx aws.Context, input *s3.GetObjectInput, opts ...request.Option) (*s3.GetObjectOutput, error) {
+	args := m.Called(ctx, input)
+	if err := args.Error(0); err != nil {
+		return nil, err
+	}
+	return &s3.GetObjectOutput{Body: io.NopCloser(strings.NewReader("content"))}, nil
+}
+
+func (m *mockS3Client) ListObjectsV2WithContext(ctx aws.Context, input *s3.ListObjectsV2Input, opts ...request.Option) (*s3.ListObjectsV2Output, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*s3.ListObjectsV2Output), args.Error(1)
+}
+
+func (m *mockS3Client) ListObjectVersionsWithContext(ctx aws.Context, input *s3.ListObjectVersionsInput, opts ...request.Option) (*s3.ListObjectVersionsOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*s3.ListObjectVersionsOutput), args.Error(1)
+}
+
+func (m *mockS3Client) DeleteObjectsWithContext(ctx aws.Context, input *s3.DeleteObjectsInput, opts ...request.Option) (*s3.DeleteObjectsOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*s3.DeleteObjectsOutput), args.Error(1)
+}
+
+// mockUploader is a mock implementation of the UploaderAPI interface for testing
+type mockUploader struct {
+	mock.Mock
+}
+
+func (m *mockUploader) Upload(input *s3manager.UploadInput, opts ...func(*s3manager.Uploader)) (*s3manager.UploadOutput, error) {
+	return m.UploadWithContext(context.Background(), input, opts...)
+}
+
+func (m *mockUploader) UploadWithContext(ctx aws.Context, input *s3manager.UploadInput, opts ...func(*s3manager.Uploader)) (*s3manager.UploadOutput, error) {
+	args := m.Called(ctx, input)
+	return &s3manager.UploadOutput{}, args.Error(0)
+}
+
+// TestPut tests that uploads go to the container's bucket with S3 managed encryption by default
+func TestPut(t *testing.T) {
+	uploader := new(mockUploader)
+	provider, err := NewS3ProviderWithClient(new(mockS3Client), uploader, createTestConfig())
+	require.NoError(t, err)
+	uploader.On("UploadWithContext", mock.Anything, mock.MatchedBy(func(input *s3manager.UploadInput) bool {
+		return aws.StringValue(input.Bucket) == "test-temp-bucket" &&
+			aws.StringValue(input.ServerSideEncryption) == s3.ServerSideEncryptionAes256 &&
+			input.SSEKMSKeyId == nil &&
+			input.ContentLength == nil // Unknown sizes are uploaded in parts
+	})).Return(nil)
+
+	err = provider.Put(context.Background(), storage.ContainerTemp, "temp/exports/tenant-123/export-1.zip", strings.NewReader("archive"), -1,
+		storage.ObjectOptions{ContentType: "application/zip"})
+
+	assert.NoError(t, err)
+	uploader.AssertExpectations(t)
+}
+
+// TestCopy_TenantKey tests that copies are encrypted with SSE-KMS under the tenant's key
+func TestCopy_TenantKey(t *testing.T) {
+	client := new(mockS3Client)
+	provider, err := NewS3ProviderWithClient(client, new(mockUploader), createTestConfig())
+	require.NoError(t, err)
+	client.On("CopyObjectWithContext", mock.Anything, mock.MatchedBy(func(input *s3.CopyObjectInput) bool {
+		return aws.StringValue(input.CopySource) == "test-temp-bucket/temp/tenant-123/doc-123" &&
+			aws.StringValue(input.Bucket) == "test-quarantine-bucket" &&
+			aws.StringValue(input.Key) == "quarantine/tenant-123/doc-123" &&
+			aws.StringValue(input.ServerSideEncryption) == s3.ServerSideEncryptionAwsKms &&
+			aws.StringValue(input.SSEKMSKeyId) == testKeyID
+	})).Return(nil)
+
+	err = provider.Copy(context.Background(), storage.ContainerTemp, "temp/tenant-123/doc-123", storage.ContainerQuarantine, "quarantine/tenant-123/doc-123",
+		storage.ObjectOptions{EncryptionKeyID: testKeyID})
+
+	assert.NoError(t, err)
+	client.AssertExpectations(t)
+}
+
+// TestPut_ServerSideEncryptionDisabled tests that S3 compatible services without key management
+// receive no encryption headers
+func TestPut_ServerSideEncryptionDisabled(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.DisableServerSideEncryption = true
+	uploader := new(mockUploader)
+	provider, err := NewS3ProviderWithClient(new(mockS3Client), uploader, cfg)
+	require.NoError(t, err)
+	uploader.On("UploadWithContext", mock.Anything, mock.MatchedBy(func(input *s3manager.UploadInput) bool {
+		return input.ServerSideEncryption == nil && input.SSEKMSKeyId == nil
+	})).Return(nil)
+
+	err = provider.Put(context.Background(), storage.ContainerTemp, "temp/tenant-123/doc-123", strings.NewReader("content"), 7, storage.ObjectOptions{})
+
+	assert.NoError(t, err)
+	uploader.AssertExpectations(t)
+}
+
+// TestCopy_ServerSideEncryptionDisabledOntoItself tests that re-encrypting an object without
+// server-side encryption does not send a copy S3 would reject
+func TestCopy_ServerSideEncryptionDisabledOntoItself(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.DisableServerSideEncryption = true
+	client := new(mockS3Client)
+	provider, err := NewS3ProviderWithClient(client, new(mockUploader), cfg)
+	require.NoError(t, err)
+
+	err = provider.Copy(context.Background(), storage.ContainerDocuments, "tenant-123/doc-123/v1", storage.ContainerDocuments, "tenant-123/doc-123/v1",
+		storage.ObjectOptions{})
+
+	assert.NoError(t, err)
+	client.AssertNotCalled(t, "CopyObjectWithContext", mock.Anything, mock.Anything)
+}
+
+// TestNewS3Provider_CustomEndpoint tests creating a provider for a self-hosted S3 compatible service
+func TestNewS3Provider_CustomEndpoint(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.Endpoint = "https://minio.internal:9000"
+	cfg.UseSSL = true
+	cfg.SkipTLSVerify = true
+
+	provider, err := NewS3Provider(cfg)
+
+	require.NoError(t, err)
+	assert.NotNil(t, provider)
+}
+
+// TestNewS3ProviderWithClient_MissingBucket tests that every container needs a bucket
+func TestNewS3ProviderWithClient_MissingBucket(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.QuarantineBucket = ""
+
+	_, err := NewS3ProviderWithClient(new(mockS3Client), new(mockUploader), cfg)
+
+	assert.Error(t, err)
+}
+
+// createReplicaConfig returns the test configuration with a replica of the document bucket
+func createReplicaConfig() config.S3Config {
+	cfg := createTestConfig()
+	cfg.Replica = config.S3ReplicaConfig{Region: "us-west-2", Bucket: "test-replica-bucket", FailoverCooldown: "1m"}
+	return cfg
+}
+
+// TestGet_FailsOverToReplica tests that a regional error sends document reads to the replica for
+// the cooldown
+func TestGet_FailsOverToReplica(t *testing.T) {
+	primary, secondary := new(mockS3Client), new(mockS3Client)
+	provider, err := NewS3ProviderWithReplica(primary, new(mockUploader), secondary, createReplicaConfig())
+	require.NoError(t, err)
+	regionalErr := awserr.NewRequestFailure(awserr.New("ServiceUnavailable", "unavailable", nil), http.StatusServiceUnavailable, "request-1")
+	primary.On("GetObjectWithContext", mock.Anything, mock.Anything).Return(regionalErr)
+	secondary.On("GetObjectWithContext", mock.Anything, mock.MatchedBy(func(input *s3.GetObjectInput) bool {
+		return aws.StringValue(input.Bucket) == "test-replica-bucket"
+	})).Return(nil)
+
+	for i := 0; i < 2; i++ {
+		body, err := provider.Get(context.Background(), storage.ContainerDocuments, "tenant-123/doc-123/v1")
+		require.NoError(t, err)
+		body.Close()
+	}
+
+	// Test that the primary is skipped during the cooldown
+	primary.AssertNumberOfCalls(t, "GetObjectWithContext", 1)
+	secondary.AssertNumberOfCalls(t, "GetObjectWithContext", 2)
+}
+
+// TestGet_ObjectErrorsDoNotFailOver tests that errors of the object itself and reads of containers
+// that are not replicated stay on the primary
+func TestGet_ObjectErrorsDoNotFailOver(t *testing.T) {
+	primary, secondary := new(mockS3Client), new(mockS3Client)
+	provider, err := NewS3ProviderWithReplica(primary, new(mockUploader), secondary, createReplicaConfig())
+	require.NoError(t, err)
+	notFound := awserr.NewRequestFailure(awserr.New(s3.ErrCodeNoSuchKey, "not found", nil), http.StatusNotFound, "request-1")
+	primary.On("GetObjectWithContext", mock.Anything, mock.MatchedBy(func(input *s3.GetObjectInput) bool {
+		return aws.StringValue(input.Bucket) == "test-bucket"
+	})).Return(notFound)
+	regionalErr := awserr.NewRequestFailure(awserr.New("InternalError", "internal error", nil), http.StatusInternalServerError, "request-2")
+	primary.On("GetObjectWithContext", mock.Anything, mock.MatchedBy(func(input *s3.GetObjectInput) bool {
+		return aws.StringValue(input.Bucket) == "test-temp-bucket"
+	})).Return(regionalErr)
+
+	_, err = provider.Get(context.Background(), storage.ContainerDocuments, "tenant-123/doc-123/v1")
+	assert.Error(t, err)
+	_, err = provider.Get(context.Background(), storage.ContainerTemp, "temp/tenant-123/doc-123")
+	assert.Error(t, err)
+
+	secondary.AssertNotCalled(t, "GetObjectWithContext", mock.Anything, mock.Anything)
+}
+
+// TestPresignGet_FailsOverToReplica tests that download URLs are signed for the replica region while
+// the primary is down
+func TestPresignGet_FailsOverToReplica(t *testing.T) {
+	newClient := func(region string) *s3.S3 {
+		sess := session.Must(session.NewSession(&aws.Config{
+			Region:      aws.String(region),
+			Credentials: credentials.NewStaticCredentials("test", "test", ""),
+		}))
+		return s3.New(sess)
+	}
+	provider, err := NewS3ProviderWithReplica(newClient("us-east-1"), new(mockUploader), newClient("us-west-2"), createReplicaConfig())
+	require.NoError(t, err)
+
+	url, err := provider.PresignGet(context.Background(), storage.ContainerDocuments, "tenant-123/doc-123/v1", "report.pdf", time.Minute)
+	require.NoError(t, err)
+	assert.Contains(t, url, "test-bucket")
+
+	provider.(*s3Provider).replica.markPrimaryDown(awserr.New(request.ErrCodeRequestError, "connection refused", nil))
+	url, err = provider.PresignGet(context.Background(), storage.ContainerDocuments, "tenant-123/doc-123/v1", "report.pdf", time.Minute)
+	require.NoError(t, err)
+	assert.Contains(t, url, "test-replica-bucket")
+	assert.Contains(t, url, "us-west-2")
+}
+
+// TestCheckReplication tests that documents missing from or differing in the replica are reported,
+// skipping those too recent to have been replicated
+func TestCheckReplication(t *testing.T) {
+	primary, secondary := new(mockS3Client), new(mockS3Client)
+	provider, err := NewS3ProviderWithReplica(primary, new(mockUploader), secondary, createReplicaConfig())
+	require.NoError(t, err)
+	old, recent := time.Now().Add(-time.Hour), time.Now()
+	object := func(key string, size int64, modified time.Time) *s3.Object {
+		return &s3.Object{Key: aws.String(key), Size: aws.Int64(size), LastModified: aws.Time(modified)}
+	}
+
+	primary.On("ListObjectsV2WithContext", mock.Anything, mock.MatchedBy(func(input *s3.ListObjectsV2Input) bool {
+		return input.ContinuationToken == nil
+	})).Return(&s3.ListObjectsV2Output{
+		Contents:              []*s3.Object{object("a", 10, old), object("b", 20, old)},
+		IsTruncated:           aws.Bool(true),
+		NextContinuationToken: aws.String("page-2"),
+	}, nil)
+	primary.On("ListObjectsV2WithContext", mock.Anything, mock.MatchedBy(func(input *s3.ListObjectsV2Input) bool {
+		return aws.StringValue(input.ContinuationToken) == "page-2"
+	})).Return(&s3.ListObjectsV2Output{
+		Contents:    []*s3.Object{object("c", 30, old), object("d", 40, recent)},
+		IsTruncated: aws.Bool(false),
+	}, nil)
+	secondary.On("ListObjectsV2WithContext", mock.Anything, mock.Anything).Return(&s3.ListObjectsV2Output{
+		Contents:    []*s3.Object{object("0-deleted", 5, old), object("a", 10, old), object("c", 31, old)},
+		IsTruncated: aws.Bool(false),
+	}, nil)
+
+	report, err := provider.(storage.ReplicationChecker).CheckReplication(context.Background(), 15*time.Minute)
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, report.Checked)
+	assert.Equal(t, 1, report.Missing)
+	assert.Equal(t, 1, report.Mismatched)
+	assert.Equal(t, []string{"b", "c"}, report.Samples)
+}
+
+// TestPurgePrefix tests that every version and delete marker under a prefix is deleted, in the
+// replica as well as in the document bucket
+func TestPurgePrefix(t *testing.T) {
+	primary, secondary := new(mockS3Client), new(mockS3Client)
+	provider, err := NewS3ProviderWithReplica(primary, new(mockUploader), secondary, createReplicaConfig())
+	require.NoError(t, err)
+	version := func(key, id string) *s3.ObjectVersion {
+		return &s3.ObjectVersion{Key: aws.String(key), VersionId: aws.String(id)}
+	}
+
+	primary.On("ListObjectVersionsWithContext", mock.Anything, mock.MatchedBy(func(input *s3.ListObjectVersionsInput) bool {
+		return aws.StringValue(input.Bucket) == "test-bucket" && aws.StringValue(input.Prefix) == "tenant-123/" && input.KeyMarker == nil
+	})).Return(&s3.ListObjectVersionsOutput{
+		Versions:            []*s3.ObjectVersion{version("tenant-123/doc-1/v1", "a1"), version("tenant-123/doc-1/v1", "a0")},
+		DeleteMarkers:       []*s3.DeleteMarkerEntry{{Key: aws.String("tenant-123/doc-2/v1"), VersionId: aws.String("b1")}},
+		IsTruncated:         aws.Bool(true),
+		NextKeyMarker:       aws.String("tenant-123/doc-2/v1"),
+		NextVersionIdMarker: aws.String("b1"),
+	}, nil)
+	primary.On("ListObjectVersionsWithContext", mock.Anything, mock.MatchedBy(func(input *s3.ListObjectVersionsInput) bool {
+		return aws.StringValue(input.KeyMarker) == "tenant-123/doc-2/v1" && aws.StringValue(input.VersionIdMarker) == "b1"
+	})).Return(&s3.ListObjectVersionsOutput{
+		Versions:    []*s3.ObjectVersion{version("tenant-123/doc-3/v1", "c1")},
+		IsTruncated: aws.Bool(false),
+	}, nil)
+	secondary.On("ListObjectVersionsWithContext", mock.Anything, mock.MatchedBy(func(input *s3.ListObjectVersionsInput) bool {
+		return aws.StringValue(input.Bucket) == "test-replica-bucket"
+	})).Return(&s3.ListObjectVersionsOutput{
+		Versions:    []*s3.ObjectVersion{version("tenant-123/doc-1/v1", "r1")},
+		IsTruncated: aws.Bool(false),
+	}, nil)
+	primary.On("DeleteObjectsWithContext", mock.Anything, mock.MatchedBy(func(input *s3.DeleteObjectsInput) bool {
+		return aws.StringValue(input.Bucket) == "test-bucket" && len(input.Delete.Objects) == 3 &&
+			aws.StringValue(input.Delete.Objects[2].VersionId) == "b1"
+	})).Return(&s3.DeleteObjectsOutput{}, nil).Once()
+	primary.On("DeleteObjectsWithContext", mock.Anything, mock.MatchedBy(func(input *s3.DeleteObjectsInput) bool {
+		return aws.StringValue(input.Bucket) == "test-bucket" && len(input.Delete.Objects) == 1
+	})).Return(&s3.DeleteObjectsOutput{}, nil).Once()
+	secondary.On("DeleteObjectsWithContext", mock.Anything, mock.MatchedBy(func(input *s3.DeleteObjectsInput) bool {
+		return aws.StringValue(input.Bucket) == "test-replica-bucket" && aws.StringValue(input.Delete.Objects[0].VersionId) == "r1"
+	})).Return(&s3.DeleteObjectsOutput{}, nil)
+
+	deleted, err := provider.(storage.PrefixPurger).PurgePrefix(context.Background(), storage.ContainerDocuments, "tenant-123/")
+
+	require.NoError(t, err)
+	assert.Equal(t, 5, deleted)
+	primary.AssertExpectations(t)
+	secondary.AssertExpectations(t)
+}
+
+// TestPurgePrefix_Errors tests that objects S3 failed to delete fail the purge, and that an empty
+// prefix, which would reach every tenant, is refused
+func TestPurgePrefix_Errors(t *testing.T) {
+	client := new(mockS3Client)
+	provider, err := NewS3ProviderWithClient(client, new(mockUploader), createTestConfig())
+	require.NoError(t, err)
+	purger := provider.(storage.PrefixPurger)
+
+	client.On("ListObjectVersionsWithContext", mock.Anything, mock.Anything).Return(&s3.ListObjectVersionsOutput{
+		Versions:    []*s3.ObjectVersion{{Key: aws.String("temp/tenant-123/doc-1"), VersionId: aws.String("null")}},
+		IsTruncated: aws.Bool(false),
+	}, nil)
+	client.On("DeleteObjectsWithContext", mock.Anything, mock.Anything).Return(&s3.DeleteObjectsOutput{
+		Errors: []*s3.Error{{Key: aws.String("temp/tenant-123/doc-1"), Message: aws.String("Access Denied")}},
+	}, nil)
+
+	_, err = purger.PurgePrefix(context.Background(), storage.ContainerTemp, "temp/tenant-123/")
+	assert.Error(t, err)
+
+	for _, prefix := range []string{"", "/"} {
+		_, err = purger.PurgePrefix(context.Background(), storage.ContainerDocuments, prefix)
+		assert.True(t, errors.IsValidationError(err), prefix)
+	}
+}
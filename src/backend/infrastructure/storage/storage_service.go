@@ -0,0 +1,567 @@
+// Package storage implements the StorageService interface on top of the object storage of a
+// cloud provider, so that documents can be kept in S3, Azure Blob Storage, GCS or on local disk.
+package storage
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"../../domain/repositories"
+	"../../domain/services"
+	"../../pkg/logger"
+	"../../pkg/utils"
+)
+
+// Storage path prefixes selecting the container of a document
+const (
+	tempPathPrefix       = "temp/"
+	quarantinePathPrefix = "quarantine/"
+)
+
+// storageService implements the StorageService interface using a StorageProvider
+type storageService struct {
+	provider StorageProvider
+
+	// Resolves the KMS key of each tenant; nil to encrypt with provider managed keys
+	keyService services.EncryptionKeyService
+
+	// Reference counts of content-addressed storage; nil when deduplication is disabled
+	blobRepo  repositories.ContentBlobRepository
+	txManager repositories.TransactionManager
+}
+
+// NewStorageService creates a new storage service keeping documents in the provider's object storage
+func NewStorageService(provider StorageProvider) (services.StorageService, error) {
+	if provider == nil {
+		return nil, fmt.Errorf("storage provider cannot be nil")
+	}
+
+	return &storageService{provider: provider}, nil
+}
+
+// NewTenantStorageService creates a new storage service for tenant documents. Content is encrypted
+// with the KMS key keyService resolves for its tenant, and identical content of a tenant can be
+// stored once, counting the references to it in blobRepo.
+func NewTenantStorageService(provider StorageProvider, keyService services.EncryptionKeyService, blobRepo repositories.ContentBlobRepository, txManager repositories.TransactionManager) (services.StorageService, error) {
+	if provider == nil {
+		return nil, fmt.Errorf("storage provider cannot be nil")
+	}
+	if blobRepo != nil && txManager == nil {
+		return nil, fmt.Errorf("transaction manager cannot be nil when deduplication is enabled")
+	}
+
+	return &storageService{
+		provider:   provider,
+		keyService: keyService,
+		blobRepo:   blobRepo,
+		txManager:  txManager,
+	}, nil
+}
+
+// StoreTemporary stores a document in temporary storage during processing.
+// It ensures tenant isolation by using tenantID in the storage path.
+func (s *storageService) StoreTemporary(ctx context.Context, tenantID string, documentID string, content io.Reader, size int64, contentType string) (string, error) {
+	// Validate inputs
+	if tenantID == "" {
+		return "", errors.New("tenant ID cannot be empty")
+	}
+	if documentID == "" {
+		return "", errors.New("document ID cannot be empty")
+	}
+	if content == nil {
+		return "", errors.New("content cannot be nil")
+	}
+
+	// Generate temporary storage path with tenant isolation
+	storagePath := fmt.Sprintf("%s%s/%s", tempPathPrefix, tenantID, documentID)
+
+	keyID, err := s.encryptionKeyFor(ctx, tenantID)
+	if err != nil {
+		return "", err
+	}
+
+	// Log the upload operation
+	logger.InfoContext(ctx, "Storing document in temporary storage",
+		"tenant_id", tenantID,
+		"document_id", documentID,
+		"size", size,
+		"content_type", contentType,
+		"storage_path", storagePath)
+
+	err = s.provider.Put(ctx, ContainerTemp, storagePath, content, size, ObjectOptions{
+		ContentType:     contentType,
+		EncryptionKeyID: keyID,
+	})
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to upload document to temporary storage",
+			"tenant_id", tenantID,
+			"document_id", documentID,
+			"error", err.Error())
+		return "", err
+	}
+
+	// Log successful upload
+	logger.InfoContext(ctx, "Document stored in temporary storage",
+		"tenant_id", tenantID,
+		"document_id", documentID,
+		"storage_path", storagePath)
+
+	return storagePath, nil
+}
+
+// StorePermanent moves a document from temporary to permanent storage after processing.
+// It ensures tenant isolation by using tenantID in the storage path.
+func (s *storageService) StorePermanent(ctx context.Context, tenantID string, documentID string, versionID string, folderID string, tempPath string) (string, error) {
+	// Validate inputs
+	if tenantID == "" {
+		return "", errors.New("tenant ID cannot be empty")
+	}
+	if documentID == "" {
+		return "", errors.New("document ID cannot be empty")
+	}
+	if versionID == "" {
+		return "", errors.New("version ID cannot be empty")
+	}
+	if tempPath == "" {
+		return "", errors.New("temporary path cannot be empty")
+	}
+
+	// Generate permanent storage path with tenant isolation
+	permanentPath := fmt.Sprintf("%s/%s/%s/%s", tenantID, folderID, documentID, versionID)
+
+	keyID, err := s.encryptionKeyFor(ctx, tenantID)
+	if err != nil {
+		return "", err
+	}
+
+	// Log the move operation
+	logger.InfoContext(ctx, "Moving document from temporary to permanent storage",
+		"tenant_id", tenantID,
+		"document_id", documentID,
+		"version_id", versionID,
+		"folder_id", folderID,
+		"temp_path", tempPath,
+		"permanent_path", permanentPath)
+
+	// Copy object from temporary to permanent storage
+	err = s.provider.Copy(ctx, ContainerTemp, tempPath, ContainerDocuments, permanentPath, ObjectOptions{EncryptionKeyID: keyID})
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to copy document from temporary to permanent storage",
+			"tenant_id", tenantID,
+			"document_id", documentID,
+			"error", err.Error())
+		return "", err
+	}
+
+	s.deleteTemporary(ctx, tenantID, tempPath)
+
+	// Log successful move
+	logger.InfoContext(ctx, "Document moved to permanent storage",
+		"tenant_id", tenantID,
+		"document_id", documentID,
+		"permanent_path", permanentPath)
+
+	return permanentPath, nil
+}
+
+// MoveToQuarantine moves a document from temporary to quarantine storage when a virus is detected.
+// It ensures tenant isolation by using tenantID in the storage path.
+func (s *storageService) MoveToQuarantine(ctx context.Context, tenantID string, documentID string, tempPath string) (string, error) {
+	// Validate inputs
+	if tenantID == "" {
+		return "", errors.New("tenant ID cannot be empty")
+	}
+	if documentID == "" {
+		return "", errors.New("document ID cannot be empty")
+	}
+	if tempPath == "" {
+		return "", errors.New("temporary path cannot be empty")
+	}
+
+	// Generate quarantine storage path with tenant isolation
+	quarantinePath := fmt.Sprintf("%s%s/%s", quarantinePathPrefix, tenantID, documentID)
+
+	keyID, err := s.encryptionKeyFor(ctx, tenantID)
+	if err != nil {
+		return "", err
+	}
+
+	// Log the quarantine operation
+	logger.InfoContext(ctx, "Moving document from temporary to quarantine storage",
+		"tenant_id", tenantID,
+		"document_id", documentID,
+		"temp_path", tempPath,
+		"quarantine_path", quarantinePath)
+
+	// Copy object from temporary to quarantine storage
+	err = s.provider.Copy(ctx, ContainerTemp, tempPath, ContainerQuarantine, quarantinePath, ObjectOptions{EncryptionKeyID: keyID})
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to copy document from temporary to quarantine storage",
+			"tenant_id", tenantID,
+			"document_id", documentID,
+			"error", err.Error())
+		return "", err
+	}
+
+	s.deleteTemporary(ctx, tenantID, tempPath)
+
+	// Log successful quarantine
+	logger.InfoContext(ctx, "Document moved to quarantine storage",
+		"tenant_id", tenantID,
+		"document_id", documentID,
+		"quarantine_path", quarantinePath)
+
+	return quarantinePath, nil
+}
+
+// ReleaseFromQuarantine moves a quarantined document back to the temporary storage path it was uploaded to.
+// The quarantined copy is only deleted once the document has been restored.
+func (s *storageService) ReleaseFromQuarantine(ctx context.Context, tenantID string, documentID string, quarantinePath string) (string, error) {
+	// Validate inputs
+	if tenantID == "" {
+		return "", errors.New("tenant ID cannot be empty")
+	}
+	if documentID == "" {
+		return "", errors.New("document ID cannot be empty")
+	}
+	if !strings.HasPrefix(quarantinePath, quarantinePathPrefix) {
+		return "", errors.New("path is not a quarantine path")
+	}
+
+	// Generate the temporary storage path the document was uploaded to
+	tempPath := fmt.Sprintf("%s%s/%s", tempPathPrefix, tenantID, documentID)
+
+	keyID, err := s.encryptionKeyFor(ctx, tenantID)
+	if err != nil {
+		return "", err
+	}
+
+	logger.InfoContext(ctx, "Releasing document from quarantine storage",
+		"tenant_id", tenantID,
+		"document_id", documentID,
+		"quarantine_path", quarantinePath,
+		"temp_path", tempPath)
+
+	// Copy object from quarantine back to temporary storage
+	err = s.provider.Copy(ctx, ContainerQuarantine, quarantinePath, ContainerTemp, tempPath, ObjectOptions{EncryptionKeyID: keyID})
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to copy document from quarantine to temporary storage",
+			"tenant_id", tenantID,
+			"document_id", documentID,
+			"error", err.Error())
+		return "", err
+	}
+
+	if err := s.provider.Delete(ctx, ContainerQuarantine, quarantinePath); err != nil {
+		logger.WarnContext(ctx, "Failed to delete document from quarantine storage",
+			"tenant_id", tenantID,
+			"quarantine_path", quarantinePath,
+			"error", err.Error())
+		// The document has been released; a leftover quarantined copy is harmless
+	}
+
+	logger.InfoContext(ctx, "Document released from quarantine storage",
+		"tenant_id", tenantID,
+		"document_id", documentID,
+		"temp_path", tempPath)
+
+	return tempPath, nil
+}
+
+// GetDocument retrieves a document from storage.
+func (s *storageService) GetDocument(ctx context.Context, storagePath string) (io.ReadCloser, error) {
+	// Validate storage path
+	if storagePath == "" {
+		return nil, errors.New("storage path cannot be empty")
+	}
+
+	// Determine the container based on the storage path
+	container := containerOf(storagePath)
+
+	// Log the download operation
+	logger.InfoContext(ctx, "Retrieving document from storage",
+		"storage_path", storagePath,
+		"container", container)
+
+	content, err := s.provider.Get(ctx, container, storagePath)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to retrieve document from storage",
+			"storage_path", storagePath,
+			"error", err.Error())
+		return nil, err
+	}
+
+	return content, nil
+}
+
+// HashDocument reads a document back from storage and calculates the SHA-256 hash of its content.
+// The content is streamed through the hash, so documents of any size can be verified.
+func (s *storageService) HashDocument(ctx context.Context, storagePath string) (string, error) {
+	content, err := s.GetDocument(ctx, storagePath)
+	if err != nil {
+		return "", err
+	}
+	defer content.Close()
+
+	hash, err := utils.HashReader(content, utils.HashAlgorithmSHA256)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to hash document content",
+			"storage_path", storagePath,
+			"error", err.Error())
+		return "", fmt.Errorf("failed to hash document content: %w", err)
+	}
+
+	return hash, nil
+}
+
+// GetDocumentRange retrieves part of a document from storage.
+// The range is passed to the provider, so only the requested bytes are transferred.
+func (s *storageService) GetDocumentRange(ctx context.Context, storagePath string, offset int64, length int64) (io.ReadCloser, error) {
+	// Validate inputs
+	if storagePath == "" {
+		return nil, errors.New("storage path cannot be empty")
+	}
+	if offset < 0 || length <= 0 {
+		return nil, errors.New("range must have a non-negative offset and a positive length")
+	}
+
+	// Determine the container based on the storage path
+	container := containerOf(storagePath)
+
+	// Log the download operation
+	logger.InfoContext(ctx, "Retrieving document range from storage",
+		"storage_path", storagePath,
+		"container", container,
+		"offset", offset,
+		"length", length)
+
+	content, err := s.provider.GetRange(ctx, container, storagePath, offset, length)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to retrieve document range from storage",
+			"storage_path", storagePath,
+			"offset", offset,
+			"length", length,
+			"error", err.Error())
+		return nil, err
+	}
+
+	return content, nil
+}
+
+// GetPresignedURL generates a presigned URL for direct document download.
+func (s *storageService) GetPresignedURL(ctx context.Context, storagePath string, fileName string, expirationSeconds int) (string, error) {
+	// Validate inputs
+	if storagePath == "" {
+		return "", errors.New("storage path cannot be empty")
+	}
+	if fileName == "" {
+		return "", errors.New("file name cannot be empty")
+	}
+	if expirationSeconds <= 0 {
+		return "", errors.New("expiration seconds must be positive")
+	}
+
+	// Log the presigned URL generation
+	logger.InfoContext(ctx, "Generating presigned URL for document download",
+		"storage_path", storagePath,
+		"file_name", fileName,
+		"expiration_seconds", expirationSeconds)
+
+	url, err := s.provider.PresignGet(ctx, containerOf(storagePath), storagePath, fileName, time.Duration(expirationSeconds)*time.Second)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to generate presigned URL",
+			"storage_path", storagePath,
+			"error", err.Error())
+		return "", err
+	}
+
+	logger.InfoContext(ctx, "Presigned URL generated successfully",
+		"storage_path", storagePath,
+		"expiration_seconds", expirationSeconds)
+
+	return url, nil
+}
+
+// DeleteDocument deletes a document from storage.
+func (s *storageService) DeleteDocument(ctx context.Context, storagePath string) error {
+	// Validate storage path
+	if storagePath == "" {
+		return errors.New("storage path cannot be empty")
+	}
+
+	// Shared content is only deleted with its last reference
+	if strings.HasPrefix(storagePath, blobPathPrefix) {
+		return s.releaseBlob(ctx, storagePath)
+	}
+
+	// Determine the container based on the storage path
+	container := containerOf(storagePath)
+
+	// Log the delete operation
+	logger.InfoContext(ctx, "Deleting document from storage",
+		"storage_path", storagePath,
+		"container", container)
+
+	if err := s.provider.Delete(ctx, container, storagePath); err != nil {
+		logger.ErrorContext(ctx, "Failed to delete document from storage",
+			"storage_path", storagePath,
+			"error", err.Error())
+		return err
+	}
+
+	logger.InfoContext(ctx, "Document deleted from storage",
+		"storage_path", storagePath)
+
+	return nil
+}
+
+// CreateBatchArchive creates a compressed archive of multiple documents.
+// The archive is streamed: each document is copied from its object into the ZIP writer as the
+// returned reader is consumed, so memory use does not grow with the size of the batch. A failure
+// while building the archive is returned by Read. Closing the reader early stops the archive.
+func (s *storageService) CreateBatchArchive(ctx context.Context, storagePaths []string, filenames []string) (io.ReadCloser, error) {
+	// Validate inputs
+	if len(storagePaths) == 0 {
+		return nil, errors.New("storage paths cannot be empty")
+	}
+
+	if len(storagePaths) != len(filenames) {
+		return nil, errors.New("number of storage paths must match number of filenames")
+	}
+
+	// Log the batch archive creation
+	logger.InfoContext(ctx, "Creating batch archive",
+		"document_count", len(storagePaths))
+
+	reader, writer := io.Pipe()
+	go func() {
+		writer.CloseWithError(s.writeBatchArchive(ctx, storagePaths, filenames, writer))
+	}()
+
+	return reader, nil
+}
+
+// writeBatchArchive writes a ZIP archive of the documents to w, one object at a time
+func (s *storageService) writeBatchArchive(ctx context.Context, storagePaths []string, filenames []string, w io.Writer) error {
+	zipWriter := zip.NewWriter(w)
+
+	// Add each document to the archive
+	for i, storagePath := range storagePaths {
+		// Get the document content
+		reader, err := s.GetDocument(ctx, storagePath)
+		if err != nil {
+			logger.ErrorContext(ctx, "Failed to retrieve document for batch archive",
+				"storage_path", storagePath,
+				"error", err.Error())
+			return err
+		}
+
+		// Create a new file in the ZIP archive
+		filename := filenames[i]
+		writer, err := zipWriter.Create(filename)
+		if err != nil {
+			logger.ErrorContext(ctx, "Failed to create file in ZIP archive",
+				"filename", filename,
+				"error", err.Error())
+			reader.Close()
+			return err
+		}
+
+		// Copy the document content to the ZIP file
+		_, err = utils.CopyReader(reader, writer)
+		reader.Close()
+		if err != nil {
+			logger.ErrorContext(ctx, "Failed to copy document content to ZIP archive",
+				"storage_path", storagePath,
+				"error", err.Error())
+			return err
+		}
+	}
+
+	// Close the ZIP writer to write the central directory
+	if err := zipWriter.Close(); err != nil {
+		logger.ErrorContext(ctx, "Failed to close ZIP writer",
+			"error", err.Error())
+		return err
+	}
+
+	logger.InfoContext(ctx, "Batch archive created successfully",
+		"document_count", len(storagePaths))
+
+	return nil
+}
+
+// StoreArchive stores an archive in temporary storage while it is being written.
+// The content is uploaded as it is read, so the archive never has to fit in memory.
+func (s *storageService) StoreArchive(ctx context.Context, tenantID string, archiveID string, content io.Reader) (string, error) {
+	// Validate inputs
+	if tenantID == "" {
+		return "", errors.New("tenant ID cannot be empty")
+	}
+	if archiveID == "" {
+		return "", errors.New("archive ID cannot be empty")
+	}
+	if content == nil {
+		return "", errors.New("content cannot be nil")
+	}
+
+	// Generate archive storage path with tenant isolation
+	storagePath := fmt.Sprintf("%sexports/%s/%s.zip", tempPathPrefix, tenantID, archiveID)
+
+	keyID, err := s.encryptionKeyFor(ctx, tenantID)
+	if err != nil {
+		return "", err
+	}
+
+	logger.InfoContext(ctx, "Storing archive",
+		"tenant_id", tenantID,
+		"archive_id", archiveID,
+		"storage_path", storagePath)
+
+	err = s.provider.Put(ctx, ContainerTemp, storagePath, content, -1, ObjectOptions{
+		ContentType:     "application/zip",
+		EncryptionKeyID: keyID,
+	})
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to upload archive",
+			"tenant_id", tenantID,
+			"archive_id", archiveID,
+			"error", err.Error())
+		return "", err
+	}
+
+	logger.InfoContext(ctx, "Archive stored",
+		"tenant_id", tenantID,
+		"archive_id", archiveID,
+		"storage_path", storagePath)
+
+	return storagePath, nil
+}
+
+// deleteTemporary deletes a document from temporary storage once it has been moved
+func (s *storageService) deleteTemporary(ctx context.Context, tenantID string, tempPath string) {
+	if err := s.provider.Delete(ctx, ContainerTemp, tempPath); err != nil {
+		logger.WarnContext(ctx, "Failed to delete document from temporary storage",
+			"tenant_id", tenantID,
+			"temp_path", tempPath,
+			"error", err.Error())
+		// We continue even if deletion fails - the temp container should have lifecycle policies
+	}
+}
+
+// containerOf returns the container a storage path is stored in, based on the path prefix
+func containerOf(storagePath string) Container {
+	switch {
+	case strings.HasPrefix(storagePath, tempPathPrefix):
+		return ContainerTemp
+	case strings.HasPrefix(storagePath, quarantinePathPrefix):
+		return ContainerQuarantine
+	default:
+		return ContainerDocuments
+	}
+}
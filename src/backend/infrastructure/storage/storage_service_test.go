@@ -0,0 +1,348 @@
+package storage
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"  // v1.8.0+
+	"github.com/stretchr/testify/mock"    // v1.8.0+
+	"github.com/stretchr/testify/require" // v1.8.0+
+
+	"../../domain/services"
+)
+
+// Test constants
+const (
+	testTenantID    = "tenant-123"
+	testDocumentID  = "doc-123"
+	testVersionID   = "v1"
+	testFolderID    = "folder-123"
+	testContent     = "test document content"
+	testContentType = "application/pdf"
+	testKeyID       = "arn:aws:kms:us-east-1:222222222222:key/tenant"
+)
+
+// mockStorageProvider is a mock implementation of the StorageProvider interface for testing
+type mockStorageProvider struct {
+	mock.Mock
+}
+
+func (m *mockStorageProvider) Put(ctx context.Context, container Container, key string, content io.Reader, size int64, opts ObjectOptions) error {
+	args := m.Called(ctx, container, key, content, size, opts)
+	return args.Error(0)
+}
+
+func (m *mockStorageProvider) Copy(ctx context.Context, srcContainer Container, srcKey string, dstContainer Container, dstKey string, opts ObjectOptions) error {
+	args := m.Called(ctx, srcContainer, srcKey, dstContainer, dstKey, opts)
+	return args.Error(0)
+}
+
+func (m *mockStorageProvider) Get(ctx context.Context, container Container, key string) (io.ReadCloser, error) {
+	args := m.Called(ctx, container, key)
+	if content := args.Get(0); content != nil {
+		return content.(io.ReadCloser), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *mockStorageProvider) GetRange(ctx context.Context, container Container, key string, offset int64, length int64) (io.ReadCloser, error) {
+	args := m.Called(ctx, container, key, offset, length)
+	if content := args.Get(0); content != nil {
+		return content.(io.ReadCloser), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *mockStorageProvider) Delete(ctx context.Context, container Container, key string) error {
+	args := m.Called(ctx, container, key)
+	return args.Error(0)
+}
+
+func (m *mockStorageProvider) PresignGet(ctx context.Context, container Container, key string, fileName string, expiry time.Duration) (string, error) {
+	args := m.Called(ctx, container, key, fileName, expiry)
+	return args.String(0), args.Error(1)
+}
+
+// mockKeyService is a mock implementation of the EncryptionKeyService interface for testing
+type mockKeyService struct {
+	services.EncryptionKeyService
+	mock.Mock
+}
+
+func (m *mockKeyService) GetTenantKeyID(ctx context.Context, tenantID string) (string, error) {
+	args := m.Called(ctx, tenantID)
+	return args.String(0), args.Error(1)
+}
+
+// createTestStorage creates a storage service without encryption keys or deduplication on the provider
+func createTestStorage(provider *mockStorageProvider) *storageService {
+	return &storageService{provider: provider}
+}
+
+// TestNewStorageService tests the creation of a new storage service
+func TestNewStorageService(t *testing.T) {
+	storage, err := NewStorageService(new(mockStorageProvider))
+	assert.NoError(t, err)
+	assert.NotNil(t, storage)
+
+	_, err = NewStorageService(nil)
+	assert.Error(t, err)
+
+	_, err = NewTenantStorageService(new(mockStorageProvider), nil, new(mockContentBlobRepository), nil)
+	assert.Error(t, err)
+}
+
+// TestStoreTemporary tests storing a document in the temporary container
+func TestStoreTemporary(t *testing.T) {
+	provider := new(mockStorageProvider)
+	storage := createTestStorage(provider)
+	content := bytes.NewReader([]byte(testContent))
+	provider.On("Put", mock.Anything, ContainerTemp, "temp/tenant-123/doc-123", content, int64(len(testContent)),
+		ObjectOptions{ContentType: testContentType}).Return(nil)
+
+	storagePath, err := storage.StoreTemporary(context.Background(), testTenantID, testDocumentID, content, int64(len(testContent)), testContentType)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "temp/tenant-123/doc-123", storagePath)
+	provider.AssertExpectations(t)
+}
+
+// TestStoreTemporary_Error tests error handling when storing a document fails
+func TestStoreTemporary_Error(t *testing.T) {
+	provider := new(mockStorageProvider)
+	storage := createTestStorage(provider)
+	provider.On("Put", mock.Anything, ContainerTemp, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(errors.New("simulated upload error"))
+
+	storagePath, err := storage.StoreTemporary(context.Background(), testTenantID, testDocumentID, strings.NewReader(testContent), int64(len(testContent)), testContentType)
+
+	assert.Error(t, err)
+	assert.Empty(t, storagePath)
+	assert.Contains(t, err.Error(), "simulated upload error")
+}
+
+// TestStoreTemporary_TenantKey tests that documents are written with their tenant's encryption key
+func TestStoreTemporary_TenantKey(t *testing.T) {
+	provider := new(mockStorageProvider)
+	keyService := new(mockKeyService)
+	storage := &storageService{provider: provider, keyService: keyService}
+	keyService.On("GetTenantKeyID", mock.Anything, testTenantID).Return(testKeyID, nil)
+	provider.On("Put", mock.Anything, ContainerTemp, mock.Anything, mock.Anything, mock.Anything,
+		ObjectOptions{ContentType: testContentType, EncryptionKeyID: testKeyID}).Return(nil)
+
+	_, err := storage.StoreTemporary(context.Background(), testTenantID, testDocumentID, strings.NewReader(testContent), int64(len(testContent)), testContentType)
+
+	assert.NoError(t, err)
+	provider.AssertExpectations(t)
+}
+
+// TestStorePermanent tests moving a document from the temporary to the documents container
+func TestStorePermanent(t *testing.T) {
+	provider := new(mockStorageProvider)
+	storage := createTestStorage(provider)
+	provider.On("Copy", mock.Anything, ContainerTemp, "temp/tenant-123/doc-123", ContainerDocuments, "tenant-123/folder-123/doc-123/v1", ObjectOptions{}).Return(nil)
+	provider.On("Delete", mock.Anything, ContainerTemp, "temp/tenant-123/doc-123").Return(nil)
+
+	permanentPath, err := storage.StorePermanent(context.Background(), testTenantID, testDocumentID, testVersionID, testFolderID, "temp/tenant-123/doc-123")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "tenant-123/folder-123/doc-123/v1", permanentPath)
+	provider.AssertExpectations(t)
+}
+
+// TestStorePermanent_Error tests that the temporary copy is kept when the move fails
+func TestStorePermanent_Error(t *testing.T) {
+	provider := new(mockStorageProvider)
+	storage := createTestStorage(provider)
+	provider.On("Copy", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(errors.New("simulated copy error"))
+
+	permanentPath, err := storage.StorePermanent(context.Background(), testTenantID, testDocumentID, testVersionID, testFolderID, "temp/tenant-123/doc-123")
+
+	assert.Error(t, err)
+	assert.Empty(t, permanentPath)
+	provider.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestMoveToQuarantine tests moving a document from the temporary to the quarantine container
+func TestMoveToQuarantine(t *testing.T) {
+	provider := new(mockStorageProvider)
+	storage := createTestStorage(provider)
+	provider.On("Copy", mock.Anything, ContainerTemp, "temp/tenant-123/doc-123", ContainerQuarantine, "quarantine/tenant-123/doc-123", ObjectOptions{}).Return(nil)
+	// A failed cleanup of the temporary copy does not fail the move
+	provider.On("Delete", mock.Anything, ContainerTemp, "temp/tenant-123/doc-123").Return(errors.New("simulated delete error"))
+
+	quarantinePath, err := storage.MoveToQuarantine(context.Background(), testTenantID, testDocumentID, "temp/tenant-123/doc-123")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "quarantine/tenant-123/doc-123", quarantinePath)
+	provider.AssertExpectations(t)
+}
+
+// TestReleaseFromQuarantine tests moving a quarantined document back to its temporary path
+func TestReleaseFromQuarantine(t *testing.T) {
+	provider := new(mockStorageProvider)
+	storage := createTestStorage(provider)
+	provider.On("Copy", mock.Anything, ContainerQuarantine, "quarantine/tenant-123/doc-123", ContainerTemp, "temp/tenant-123/doc-123", ObjectOptions{}).Return(nil)
+	provider.On("Delete", mock.Anything, ContainerQuarantine, "quarantine/tenant-123/doc-123").Return(nil)
+
+	tempPath, err := storage.ReleaseFromQuarantine(context.Background(), testTenantID, testDocumentID, "quarantine/tenant-123/doc-123")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "temp/tenant-123/doc-123", tempPath)
+	provider.AssertExpectations(t)
+
+	// Only quarantined documents can be released
+	_, err = storage.ReleaseFromQuarantine(context.Background(), testTenantID, testDocumentID, "tenant-123/folder-123/doc-123/v1")
+	assert.Error(t, err)
+}
+
+// TestGetDocumentRange tests that ranges are read from the provider
+func TestGetDocumentRange(t *testing.T) {
+	provider := new(mockStorageProvider)
+	storage := createTestStorage(provider)
+	provider.On("GetRange", mock.Anything, ContainerDocuments, "tenant-123/folder-123/doc-123/v1", int64(5), int64(8)).
+		Return(ioutil.NopCloser(strings.NewReader(testContent[5:13])), nil)
+
+	content, err := storage.GetDocumentRange(context.Background(), "tenant-123/folder-123/doc-123/v1", 5, 8)
+	require.NoError(t, err)
+	data, err := ioutil.ReadAll(content)
+
+	assert.NoError(t, err)
+	assert.Equal(t, testContent[5:13], string(data))
+
+	_, err = storage.GetDocumentRange(context.Background(), "tenant-123/folder-123/doc-123/v1", 0, 0)
+	assert.Error(t, err)
+}
+
+// TestHashDocument tests hashing the content read back from storage
+func TestHashDocument(t *testing.T) {
+	provider := new(mockStorageProvider)
+	storage := createTestStorage(provider)
+	provider.On("Get", mock.Anything, ContainerDocuments, "tenant-123/folder-123/doc-123/v1").
+		Return(ioutil.NopCloser(strings.NewReader(testContent)), nil)
+	provider.On("Get", mock.Anything, ContainerTemp, "temp/tenant-123/doc-123").
+		Return(nil, errors.New("object not found"))
+
+	hash, err := storage.HashDocument(context.Background(), "tenant-123/folder-123/doc-123/v1")
+	assert.NoError(t, err)
+	assert.Equal(t, testContentHash, hash)
+
+	_, err = storage.HashDocument(context.Background(), "temp/tenant-123/doc-123")
+	assert.Error(t, err)
+}
+
+// TestGetPresignedURL tests generating a download URL and validating its inputs
+func TestGetPresignedURL(t *testing.T) {
+	provider := new(mockStorageProvider)
+	storage := createTestStorage(provider)
+	provider.On("PresignGet", mock.Anything, ContainerTemp, "temp/exports/tenant-123/export-1.zip", "export.zip", time.Hour).
+		Return("https://storage.example.com/export-1.zip?signature=abc", nil)
+
+	url, err := storage.GetPresignedURL(context.Background(), "temp/exports/tenant-123/export-1.zip", "export.zip", 3600)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://storage.example.com/export-1.zip?signature=abc", url)
+
+	_, err = storage.GetPresignedURL(context.Background(), "temp/exports/tenant-123/export-1.zip", "", 3600)
+	assert.Error(t, err)
+	_, err = storage.GetPresignedURL(context.Background(), "temp/exports/tenant-123/export-1.zip", "export.zip", 0)
+	assert.Error(t, err)
+}
+
+// TestDeleteDocument tests deleting a document from its container
+func TestDeleteDocument(t *testing.T) {
+	provider := new(mockStorageProvider)
+	storage := createTestStorage(provider)
+	provider.On("Delete", mock.Anything, ContainerQuarantine, "quarantine/tenant-123/doc-123").Return(nil)
+
+	err := storage.DeleteDocument(context.Background(), "quarantine/tenant-123/doc-123")
+
+	assert.NoError(t, err)
+	provider.AssertExpectations(t)
+}
+
+// TestReencryptDocument tests that documents are re-encrypted by copying them onto themselves
+func TestReencryptDocument(t *testing.T) {
+	provider := new(mockStorageProvider)
+	storage := createTestStorage(provider)
+	provider.On("Copy", mock.Anything, ContainerDocuments, "tenant-123/folder-123/doc-123/v1", ContainerDocuments, "tenant-123/folder-123/doc-123/v1",
+		ObjectOptions{EncryptionKeyID: testKeyID}).Return(nil)
+
+	err := storage.ReencryptDocument(context.Background(), "tenant-123/folder-123/doc-123/v1", testKeyID)
+
+	assert.NoError(t, err)
+	provider.AssertExpectations(t)
+}
+
+// TestCreateBatchArchive tests creating a compressed archive of multiple documents
+func TestCreateBatchArchive(t *testing.T) {
+	provider := new(mockStorageProvider)
+	storage := createTestStorage(provider)
+	provider.On("Get", mock.Anything, ContainerDocuments, mock.Anything).
+		Return(ioutil.NopCloser(strings.NewReader(testContent)), nil).Once()
+	provider.On("Get", mock.Anything, ContainerDocuments, mock.Anything).
+		Return(ioutil.NopCloser(strings.NewReader(testContent)), nil).Once()
+
+	storagePaths := []string{"tenant-123/folder-123/doc-1/v1", "tenant-123/folder-123/doc-2/v1"}
+	filenames := []string{"document1.pdf", "document2.pdf"}
+
+	archive, err := storage.CreateBatchArchive(context.Background(), storagePaths, filenames)
+	require.NoError(t, err)
+	archiveContent, err := ioutil.ReadAll(archive)
+	require.NoError(t, err)
+
+	zipReader, err := zip.NewReader(bytes.NewReader(archiveContent), int64(len(archiveContent)))
+	require.NoError(t, err)
+	require.Len(t, zipReader.File, 2)
+	for i, expectedName := range filenames {
+		assert.Equal(t, expectedName, zipReader.File[i].Name)
+
+		fileReader, err := zipReader.File[i].Open()
+		require.NoError(t, err)
+		fileContent, err := ioutil.ReadAll(fileReader)
+		fileReader.Close()
+
+		assert.NoError(t, err)
+		assert.Equal(t, testContent, string(fileContent))
+	}
+}
+
+// TestCreateBatchArchive_Error tests error handling when creating a batch archive fails
+func TestCreateBatchArchive_Error(t *testing.T) {
+	provider := new(mockStorageProvider)
+	storage := createTestStorage(provider)
+	provider.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("simulated get error"))
+
+	archive, err := storage.CreateBatchArchive(context.Background(), []string{"tenant-123/folder-123/doc-1/v1"}, []string{"document1.pdf"})
+
+	// The archive is streamed, so the error surfaces while reading it
+	assert.NoError(t, err)
+	assert.NotNil(t, archive)
+	_, err = ioutil.ReadAll(archive)
+	archive.Close()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "simulated get error")
+}
+
+// TestContainerOf tests resolving the container of a storage path
+func TestContainerOf(t *testing.T) {
+	testCases := map[string]Container{
+		"temp/tenant-123/doc-123":              ContainerTemp,
+		"temp/exports/tenant-123/export-1.zip": ContainerTemp,
+		"quarantine/tenant-123/doc-123":        ContainerQuarantine,
+		"tenant-123/folder-123/doc-123/v1":     ContainerDocuments,
+		"blobs/tenant-123/" + testContentHash:  ContainerDocuments,
+		"tenant-123/temp/doc-123/v1":           ContainerDocuments,
+	}
+
+	for storagePath, expected := range testCases {
+		assert.Equal(t, expected, containerOf(storagePath), storagePath)
+	}
+}
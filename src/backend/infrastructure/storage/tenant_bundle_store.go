@@ -0,0 +1,74 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"../../domain/services"
+)
+
+// tenantBundlePathPrefix is where tenant bundles are kept in the document container, which is
+// versioned and replicated to the replica region, so a bundle outlives the loss of its region
+const tenantBundlePathPrefix = "tenant-bundles/"
+
+// tenantBundleStore implements the TenantBundleStore interface using a StorageProvider
+type tenantBundleStore struct {
+	provider StorageProvider
+}
+
+// NewTenantBundleStore creates a new store keeping tenant bundles in the provider's document container
+func NewTenantBundleStore(provider StorageProvider) (services.TenantBundleStore, error) {
+	if provider == nil {
+		return nil, fmt.Errorf("storage provider cannot be nil")
+	}
+
+	return &tenantBundleStore{provider: provider}, nil
+}
+
+// PutBundleFile stores a file of a bundle. Bundles hold the records of a whole tenant, so they are
+// encrypted with provider managed keys rather than a tenant key, which the environment a bundle is
+// imported into may not have access to.
+func (s *tenantBundleStore) PutBundleFile(ctx context.Context, bundle string, name string, content io.Reader) error {
+	key, err := bundleKey(bundle, name)
+	if err != nil {
+		return err
+	}
+	if content == nil {
+		return errors.New("content cannot be nil")
+	}
+
+	return s.provider.Put(ctx, ContainerDocuments, key, content, -1, ObjectOptions{
+		ContentType: "application/octet-stream",
+	})
+}
+
+// GetBundleFile retrieves a file of a bundle
+func (s *tenantBundleStore) GetBundleFile(ctx context.Context, bundle string, name string) (io.ReadCloser, error) {
+	key, err := bundleKey(bundle, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.provider.Get(ctx, ContainerDocuments, key)
+}
+
+// bundleKey returns the key of a bundle file, refusing bundle paths and names that would reach
+// outside the bundle prefix
+func bundleKey(bundle string, name string) (string, error) {
+	if bundle == "" {
+		return "", errors.New("bundle cannot be empty")
+	}
+	if name == "" {
+		return "", errors.New("bundle file name cannot be empty")
+	}
+
+	key := path.Join(tenantBundlePathPrefix, bundle, name)
+	if !strings.HasPrefix(key, tenantBundlePathPrefix) || strings.Contains(bundle+"/"+name, "..") {
+		return "", fmt.Errorf("invalid bundle file %s/%s", bundle, name)
+	}
+	return key, nil
+}
@@ -0,0 +1,56 @@
+package storage
+
+import (
+	"context"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"  // v1.8.0+
+	"github.com/stretchr/testify/mock"    // v1.8.0+
+	"github.com/stretchr/testify/require" // v1.8.0+
+)
+
+// TestTenantBundleStore tests that bundle files are kept in the document container under the bundle prefix
+func TestTenantBundleStore(t *testing.T) {
+	provider := new(mockStorageProvider)
+	store, err := NewTenantBundleStore(provider)
+	require.NoError(t, err)
+
+	content := strings.NewReader(testContent)
+	provider.On("Put", mock.Anything, ContainerDocuments, "tenant-bundles/tenant-123/20240101T000000Z/content/v1", content, int64(-1),
+		ObjectOptions{ContentType: "application/octet-stream"}).Return(nil)
+	provider.On("Get", mock.Anything, ContainerDocuments, "tenant-bundles/tenant-123/20240101T000000Z/manifest.json").
+		Return(ioutil.NopCloser(strings.NewReader("{}")), nil)
+
+	err = store.PutBundleFile(context.Background(), "tenant-123/20240101T000000Z", "content/v1", content)
+	require.NoError(t, err)
+
+	manifest, err := store.GetBundleFile(context.Background(), "tenant-123/20240101T000000Z", "manifest.json")
+	require.NoError(t, err)
+	defer manifest.Close()
+	provider.AssertExpectations(t)
+}
+
+// TestTenantBundleStore_InvalidPath tests that bundle paths cannot reach outside the bundle prefix
+func TestTenantBundleStore_InvalidPath(t *testing.T) {
+	provider := new(mockStorageProvider)
+	store, err := NewTenantBundleStore(provider)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		bundle string
+		name   string
+	}{
+		{"", "manifest.json"},
+		{"tenant-123/20240101T000000Z", ""},
+		{"../tenant-123", "manifest.json"},
+		{"tenant-123/20240101T000000Z", "../../../tenant-123/folder-123/doc-123/v1"},
+	}
+
+	for _, tc := range testCases {
+		_, err := store.GetBundleFile(context.Background(), tc.bundle, tc.name)
+		assert.Error(t, err, "%s/%s", tc.bundle, tc.name)
+	}
+	provider.AssertNotCalled(t, "Get", mock.Anything, mock.Anything, mock.Anything)
+}
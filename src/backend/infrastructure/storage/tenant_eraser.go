@@ -0,0 +1,87 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"../../domain/services"
+)
+
+// thumbnailPathPrefix is the path prefix the thumbnail generator stores thumbnails under
+const thumbnailPathPrefix = "thumbnails/"
+
+// tenantStorageEraser implements the TenantStorageEraser interface using a StorageProvider that
+// can purge prefixes
+type tenantStorageEraser struct {
+	purger PrefixPurger
+}
+
+// NewTenantStorageEraser creates a new eraser of the objects tenants keep with the provider. The
+// provider must be able to purge prefixes.
+func NewTenantStorageEraser(provider StorageProvider) (services.TenantStorageEraser, error) {
+	if provider == nil {
+		return nil, fmt.Errorf("storage provider cannot be nil")
+	}
+	purger, ok := provider.(PrefixPurger)
+	if !ok {
+		return nil, fmt.Errorf("storage provider %T cannot purge objects by prefix", provider)
+	}
+
+	return &tenantStorageEraser{purger: purger}, nil
+}
+
+// tenantPrefix is a prefix objects of a tenant are stored under in a container
+type tenantPrefix struct {
+	container Container
+	prefix    string
+}
+
+// String returns the prefix as it is named in destruction reports
+func (p tenantPrefix) String() string {
+	return string(p.container) + ":" + p.prefix
+}
+
+// tenantPrefixes returns the prefixes the objects of a tenant are stored under: document versions,
+// deduplicated content, thumbnails and tenant bundles in the document container, uploads being
+// processed and export archives in the temporary container, and infected uploads in quarantine
+func tenantPrefixes(tenantID string) []tenantPrefix {
+	return []tenantPrefix{
+		{ContainerDocuments, tenantID + "/"},
+		{ContainerDocuments, blobPathPrefix + tenantID + "/"},
+		{ContainerDocuments, thumbnailPathPrefix + tenantID + "/"},
+		{ContainerDocuments, tenantBundlePathPrefix + tenantID + "/"},
+		{ContainerTemp, tempPathPrefix + tenantID + "/"},
+		{ContainerTemp, tempPathPrefix + "exports/" + tenantID + "/"},
+		{ContainerQuarantine, quarantinePathPrefix + tenantID + "/"},
+	}
+}
+
+// EraseTenant purges the prefixes of a tenant one after the other
+func (e *tenantStorageEraser) EraseTenant(ctx context.Context, tenantID string) (map[string]int, error) {
+	return e.visitPrefixes(ctx, tenantID, e.purger.PurgePrefix)
+}
+
+// CountTenant counts the objects left under each prefix of a tenant
+func (e *tenantStorageEraser) CountTenant(ctx context.Context, tenantID string) (map[string]int, error) {
+	return e.visitPrefixes(ctx, tenantID, e.purger.CountPrefix)
+}
+
+// visitPrefixes passes each prefix of a tenant to visit and returns the number of objects visit
+// returned for it, up to the first error
+func (e *tenantStorageEraser) visitPrefixes(ctx context.Context, tenantID string, visit func(context.Context, Container, string) (int, error)) (map[string]int, error) {
+	// Tenant IDs are UUIDs; anything else could widen the prefixes to other tenants
+	if tenantID == "" || strings.ContainsAny(tenantID, "/.") {
+		return nil, fmt.Errorf("invalid tenant ID %q", tenantID)
+	}
+
+	counts := make(map[string]int)
+	for _, prefix := range tenantPrefixes(tenantID) {
+		count, err := visit(ctx, prefix.container, prefix.prefix)
+		if err != nil {
+			return counts, fmt.Errorf("%s: %w", prefix, err)
+		}
+		counts[prefix.String()] = count
+	}
+	return counts, nil
+}
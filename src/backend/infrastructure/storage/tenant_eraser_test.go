@@ -0,0 +1,77 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"  // v1.8.0+
+	"github.com/stretchr/testify/mock"    // v1.8.0+
+	"github.com/stretchr/testify/require" // v1.8.0+
+)
+
+// mockPurgingStorageProvider is a mock storage provider that can purge prefixes
+type mockPurgingStorageProvider struct {
+	mockStorageProvider
+}
+
+func (m *mockPurgingStorageProvider) PurgePrefix(ctx context.Context, container Container, prefix string) (int, error) {
+	args := m.Called(ctx, container, prefix)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *mockPurgingStorageProvider) CountPrefix(ctx context.Context, container Container, prefix string) (int, error) {
+	args := m.Called(ctx, container, prefix)
+	return args.Int(0), args.Error(1)
+}
+
+// TestTenantStorageEraser tests that every prefix of the tenant is purged and reported
+func TestTenantStorageEraser(t *testing.T) {
+	provider := new(mockPurgingStorageProvider)
+	eraser, err := NewTenantStorageEraser(provider)
+	require.NoError(t, err)
+
+	provider.On("PurgePrefix", mock.Anything, ContainerDocuments, "tenant-123/").Return(4, nil)
+	provider.On("PurgePrefix", mock.Anything, ContainerDocuments, "thumbnails/tenant-123/").Return(2, nil)
+	provider.On("PurgePrefix", mock.Anything, ContainerQuarantine, "quarantine/tenant-123/").Return(1, nil)
+	provider.On("PurgePrefix", mock.Anything, mock.Anything, mock.Anything).Return(0, nil)
+
+	deleted, err := eraser.EraseTenant(context.Background(), "tenant-123")
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{
+		"documents:tenant-123/":                4,
+		"documents:blobs/tenant-123/":          0,
+		"documents:thumbnails/tenant-123/":     2,
+		"documents:tenant-bundles/tenant-123/": 0,
+		"temp:temp/tenant-123/":                0,
+		"temp:temp/exports/tenant-123/":        0,
+		"quarantine:quarantine/tenant-123/":    1,
+	}, deleted)
+	provider.AssertNumberOfCalls(t, "PurgePrefix", 7)
+}
+
+// TestTenantStorageEraser_Errors tests that the prefixes purged before a failure are reported, and
+// that tenant IDs which would widen the prefixes are refused
+func TestTenantStorageEraser_Errors(t *testing.T) {
+	provider := new(mockPurgingStorageProvider)
+	eraser, err := NewTenantStorageEraser(provider)
+	require.NoError(t, err)
+
+	provider.On("CountPrefix", mock.Anything, ContainerDocuments, "tenant-123/").Return(3, nil)
+	provider.On("CountPrefix", mock.Anything, ContainerDocuments, "blobs/tenant-123/").Return(0, errors.New("access denied"))
+
+	counts, err := eraser.CountTenant(context.Background(), "tenant-123")
+	assert.Error(t, err)
+	assert.Equal(t, map[string]int{"documents:tenant-123/": 3}, counts)
+
+	for _, tenantID := range []string{"", "..", "tenant-123/folder-123"} {
+		_, err := eraser.EraseTenant(context.Background(), tenantID)
+		assert.Error(t, err, tenantID)
+	}
+	provider.AssertNotCalled(t, "PurgePrefix", mock.Anything, mock.Anything, mock.Anything)
+
+	// Providers that cannot purge prefixes cannot erase tenants
+	_, err = NewTenantStorageEraser(new(mockStorageProvider))
+	assert.Error(t, err)
+}
@@ -12,6 +12,8 @@ import (
 	"net"
 	"time"
 
+	"../../../domain/models"
+	"../../../domain/services"
 	"../../../pkg/errors"
 	"../../../pkg/logger"
 )
@@ -50,13 +52,14 @@ func NewClamAVClient(address string) (*clamAVClient, error) {
 	return client, nil
 }
 
-// ScanStream scans a document stream for viruses
-func (c *clamAVClient) ScanStream(ctx context.Context, reader io.Reader) (bool, string, error) {
+// ScanStream scans a document stream for viruses. Returns a scan result constant and the name of
+// the virus when the document is infected.
+func (c *clamAVClient) ScanStream(ctx context.Context, reader io.Reader) (string, string, error) {
 	log := logger.WithContext(ctx)
 	log.Info("Starting virus scan")
 	
 	if reader == nil {
-		return false, "", errors.NewValidationError("Reader cannot be nil")
+		return services.ScanResultError, "", errors.NewValidationError("Reader cannot be nil")
 	}
 	
 	// Establish connection to ClamAV daemon with timeout
@@ -64,7 +67,7 @@ func (c *clamAVClient) ScanStream(ctx context.Context, reader io.Reader) (bool,
 	if err != nil {
 		log = logger.WithError(err)
 		log.Error("Failed to connect to ClamAV daemon")
-		return false, "", errors.NewDependencyError(fmt.Sprintf("Failed to connect to ClamAV: %s", err.Error()))
+		return services.ScanResultError, "", errors.NewDependencyError(fmt.Sprintf("Failed to connect to ClamAV: %s", err.Error()))
 	}
 	defer conn.Close()
 	
@@ -72,14 +75,14 @@ func (c *clamAVClient) ScanStream(ctx context.Context, reader io.Reader) (bool,
 	if err := conn.SetDeadline(time.Now().Add(c.timeout)); err != nil {
 		log = logger.WithError(err)
 		log.Error("Failed to set connection deadline")
-		return false, "", errors.NewDependencyError(fmt.Sprintf("Failed to set connection deadline: %s", err.Error()))
+		return services.ScanResultError, "", errors.NewDependencyError(fmt.Sprintf("Failed to set connection deadline: %s", err.Error()))
 	}
 	
 	// Send INSTREAM command to ClamAV
 	if _, err := conn.Write(inStreamCommand); err != nil {
 		log = logger.WithError(err)
 		log.Error("Failed to send INSTREAM command")
-		return false, "", errors.NewDependencyError(fmt.Sprintf("Failed to send INSTREAM command: %s", err.Error()))
+		return services.ScanResultError, "", errors.NewDependencyError(fmt.Sprintf("Failed to send INSTREAM command: %s", err.Error()))
 	}
 	
 	// Read document content in chunks and send to ClamAV
@@ -89,7 +92,7 @@ func (c *clamAVClient) ScanStream(ctx context.Context, reader io.Reader) (bool,
 		select {
 		case <-ctx.Done():
 			log.Error("Context canceled during virus scan")
-			return false, "", errors.Wrap(ctx.Err(), "Context canceled during virus scan")
+			return services.ScanResultError, "", errors.Wrap(ctx.Err(), "Context canceled during virus scan")
 		default:
 			// Continue processing
 		}
@@ -108,14 +111,14 @@ func (c *clamAVClient) ScanStream(ctx context.Context, reader io.Reader) (bool,
 			if _, err := conn.Write(sizeBytes); err != nil {
 				log = logger.WithError(err)
 				log.Error("Failed to send chunk size")
-				return false, "", errors.NewDependencyError(fmt.Sprintf("Failed to send chunk size: %s", err.Error()))
+				return services.ScanResultError, "", errors.NewDependencyError(fmt.Sprintf("Failed to send chunk size: %s", err.Error()))
 			}
 			
 			// Send chunk data
 			if _, err := conn.Write(buf[:n]); err != nil {
 				log = logger.WithError(err)
 				log.Error("Failed to send chunk data")
-				return false, "", errors.NewDependencyError(fmt.Sprintf("Failed to send chunk data: %s", err.Error()))
+				return services.ScanResultError, "", errors.NewDependencyError(fmt.Sprintf("Failed to send chunk data: %s", err.Error()))
 			}
 		}
 		
@@ -124,7 +127,7 @@ func (c *clamAVClient) ScanStream(ctx context.Context, reader io.Reader) (bool,
 			if readErr != io.EOF {
 				log = logger.WithError(readErr)
 				log.Error("Error reading document content")
-				return false, "", errors.Wrap(readErr, "Error reading document content")
+				return services.ScanResultError, "", errors.Wrap(readErr, "Error reading document content")
 			}
 			break
 		}
@@ -135,7 +138,7 @@ func (c *clamAVClient) ScanStream(ctx context.Context, reader io.Reader) (bool,
 	if _, err := conn.Write(zeroSizeBytes); err != nil {
 		log = logger.WithError(err)
 		log.Error("Failed to send end of stream signal")
-		return false, "", errors.NewDependencyError(fmt.Sprintf("Failed to send end of stream signal: %s", err.Error()))
+		return services.ScanResultError, "", errors.NewDependencyError(fmt.Sprintf("Failed to send end of stream signal: %s", err.Error()))
 	}
 	
 	// Read response from ClamAV
@@ -146,13 +149,13 @@ func (c *clamAVClient) ScanStream(ctx context.Context, reader io.Reader) (bool,
 	if err := scanner.Err(); err != nil {
 		log = logger.WithError(err)
 		log.Error("Failed to read scan response")
-		return false, "", errors.NewDependencyError(fmt.Sprintf("Failed to read scan response: %s", err.Error()))
+		return services.ScanResultError, "", errors.NewDependencyError(fmt.Sprintf("Failed to read scan response: %s", err.Error()))
 	}
 	
 	// Parse response to determine if document is clean
 	if bytes.Contains(response, okResponse) {
 		log.Info("Document scan completed: clean")
-		return true, "", nil
+		return services.ScanResultClean, "", nil
 	} else if bytes.Contains(response, foundResponse) {
 		// Extract virus name from response
 		parts := bytes.SplitN(response, []byte(": "), 2)
@@ -163,16 +166,21 @@ func (c *clamAVClient) ScanStream(ctx context.Context, reader io.Reader) (bool,
 		}
 		
 		log.Info("Document scan completed: virus found", "virus", virusName)
-		return false, virusName, errors.NewSecurityError(fmt.Sprintf("Virus detected: %s", virusName))
+		return services.ScanResultInfected, virusName, nil
 	} else if bytes.Contains(response, errorResponse) {
 		errorMsg := string(response)
 		log.Error("Document scan error", "error", errorMsg)
-		return false, "", errors.NewDependencyError(fmt.Sprintf("ClamAV scan error: %s", errorMsg))
+		return services.ScanResultError, "", errors.NewDependencyError(fmt.Sprintf("ClamAV scan error: %s", errorMsg))
 	}
 	
 	// Unknown response
 	log.Error("Document scan returned unknown response", "response", string(response))
-	return false, "", errors.NewDependencyError(fmt.Sprintf("Unknown ClamAV response: %s", string(response)))
+	return services.ScanResultError, "", errors.NewDependencyError(fmt.Sprintf("Unknown ClamAV response: %s", string(response)))
+}
+
+// Name returns the name tenants select the ClamAV engine by
+func (c *clamAVClient) Name() string {
+	return models.ScanEngineClamAV
 }
 
 // Ping checks if ClamAV daemon is available
@@ -224,6 +232,56 @@ func (c *clamAVClient) Ping(ctx context.Context) error {
 	return nil
 }
 
+// SignatureVersion returns the version of the signature database loaded by the ClamAV daemon.
+// The daemon reports its version as "ClamAV 1.0.1/26890/Mon Apr 10 08:17:26 2023", where the
+// second field is the version of the signature database.
+func (c *clamAVClient) SignatureVersion(ctx context.Context) (string, error) {
+	log := logger.WithContext(ctx)
+	
+	// Establish connection to ClamAV daemon
+	conn, err := net.DialTimeout("tcp", c.address, c.timeout)
+	if err != nil {
+		log = logger.WithError(err)
+		log.Error("Failed to connect to ClamAV")
+		return "", errors.NewDependencyError(fmt.Sprintf("Failed to connect to ClamAV: %s", err.Error()))
+	}
+	defer conn.Close()
+	
+	// Set deadline based on timeout
+	if err := conn.SetDeadline(time.Now().Add(c.timeout)); err != nil {
+		log = logger.WithError(err)
+		log.Error("Failed to set connection deadline")
+		return "", errors.NewDependencyError(fmt.Sprintf("Failed to set connection deadline: %s", err.Error()))
+	}
+	
+	// Send VERSION command to ClamAV
+	if _, err := conn.Write([]byte("VERSION\n")); err != nil {
+		log = logger.WithError(err)
+		log.Error("Failed to send VERSION command")
+		return "", errors.NewDependencyError(fmt.Sprintf("Failed to send VERSION command: %s", err.Error()))
+	}
+	
+	// Read response from ClamAV
+	scanner := bufio.NewScanner(conn)
+	scanner.Scan()
+	response := scanner.Bytes()
+	
+	if err := scanner.Err(); err != nil {
+		log = logger.WithError(err)
+		log.Error("Failed to read version response")
+		return "", errors.NewDependencyError(fmt.Sprintf("Failed to read version response: %s", err.Error()))
+	}
+	
+	// Extract the signature database version, which is missing when no database is loaded
+	parts := bytes.Split(response, []byte("/"))
+	if len(parts) < 3 || len(bytes.TrimSpace(parts[1])) == 0 {
+		log.Error("ClamAV version response has no signature database version", "response", string(response))
+		return "", errors.NewDependencyError(fmt.Sprintf("Unexpected ClamAV version response: %s", string(response)))
+	}
+	
+	return string(bytes.TrimSpace(parts[1])), nil
+}
+
 // SetTimeout sets the timeout for ClamAV operations
 func (c *clamAVClient) SetTimeout(timeout time.Duration) {
 	c.timeout = timeout
@@ -0,0 +1,62 @@
+package clamav
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startFakeDaemon starts a ClamAV daemon stand-in answering one command with the given response
+// and returns its address
+func startFakeDaemon(t *testing.T, response string) (string, <-chan string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	commands := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		command, _ := bufio.NewReader(conn).ReadString('\n')
+		commands <- command
+		conn.Write([]byte(response + "\n"))
+	}()
+
+	return listener.Addr().String(), commands
+}
+
+// TestClamAVClient_SignatureVersion tests reading the signature database version from the daemon
+func TestClamAVClient_SignatureVersion(t *testing.T) {
+	address, commands := startFakeDaemon(t, "ClamAV 1.0.1/26890/Mon Apr 10 08:17:26 2023")
+
+	client, err := NewClamAVClient(address)
+	require.NoError(t, err)
+
+	version, err := client.SignatureVersion(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "26890", version)
+	assert.Equal(t, "VERSION\n", <-commands)
+}
+
+// TestClamAVClient_SignatureVersion_NoDatabase tests that a daemon without a loaded signature
+// database is reported as an error
+func TestClamAVClient_SignatureVersion_NoDatabase(t *testing.T) {
+	address, _ := startFakeDaemon(t, "ClamAV 1.0.1")
+
+	client, err := NewClamAVClient(address)
+	require.NoError(t, err)
+
+	version, err := client.SignatureVersion(context.Background())
+
+	assert.Error(t, err)
+	assert.Empty(t, version)
+}
@@ -0,0 +1,195 @@
+package clamav
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/trace" // v1.11.0+
+
+	"src/backend/domain/services"
+	"src/backend/pkg/config"
+	"src/backend/pkg/errors"
+	"src/backend/pkg/logger"
+	"src/backend/pkg/tracing"
+)
+
+// Defaults for the scan worker pool when config.Scanning.Workers leaves them unset
+const (
+	defaultScanConcurrency       = 4
+	defaultScanVisibilityTimeout = 5 * time.Minute
+	defaultScanDrainTimeout      = 25 * time.Second
+)
+
+// dequeueErrorBackoff is how long a worker waits before polling again after the queue failed
+const dequeueErrorBackoff = 5 * time.Second
+
+// ScanWorkerPool processes the scan queue with a number of workers in parallel. Each worker long
+// polls the queue for a task and processes it, extending the visibility of the task while the scan
+// runs so that scans of large files are not picked up by another worker.
+type ScanWorkerPool struct {
+	scanner           services.VirusScanningService
+	scanQueue         services.ScanQueue
+	concurrency       int
+	visibilityTimeout time.Duration
+	drainTimeout      time.Duration
+}
+
+// NewScanWorkerPool creates a ScanWorkerPool processing the tasks of scanQueue with scanner
+func NewScanWorkerPool(scanner services.VirusScanningService, scanQueue services.ScanQueue, cfg config.ScanWorkersConfig) (*ScanWorkerPool, error) {
+	if scanner == nil {
+		return nil, errors.NewValidationError("scanner cannot be nil")
+	}
+	if scanQueue == nil {
+		return nil, errors.NewValidationError("scanQueue cannot be nil")
+	}
+	if cfg.Concurrency < 0 {
+		return nil, errors.NewValidationError("scan worker concurrency cannot be negative")
+	}
+
+	concurrency := cfg.Concurrency
+	if concurrency == 0 {
+		concurrency = defaultScanConcurrency
+	}
+
+	visibilityTimeout, err := parseWorkerDuration(cfg.VisibilityTimeout, defaultScanVisibilityTimeout)
+	if err != nil || visibilityTimeout < time.Second {
+		return nil, errors.NewValidationError("invalid scan worker visibility timeout: " + cfg.VisibilityTimeout)
+	}
+
+	drainTimeout, err := parseWorkerDuration(cfg.DrainTimeout, defaultScanDrainTimeout)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid scan worker drain timeout: " + cfg.DrainTimeout)
+	}
+
+	return &ScanWorkerPool{
+		scanner:           scanner,
+		scanQueue:         scanQueue,
+		concurrency:       concurrency,
+		visibilityTimeout: visibilityTimeout,
+		drainTimeout:      drainTimeout,
+	}, nil
+}
+
+// Run processes the scan queue until ctx is cancelled. Workers then stop polling, and Run returns
+// once the tasks being processed have finished. Tasks still running after the drain timeout are
+// cancelled; they become visible in the queue again and are picked up after a restart.
+func (p *ScanWorkerPool) Run(ctx context.Context) {
+	// Tasks are processed with their own context, so that they can finish after polling stopped
+	processCtx, cancelProcessing := context.WithCancel(context.Background())
+	defer cancelProcessing()
+
+	var wg sync.WaitGroup
+	for i := 0; i < p.concurrency; i++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			p.runWorker(ctx, processCtx, worker)
+		}(i)
+	}
+
+	drained := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		return
+	case <-ctx.Done():
+	}
+
+	logger.Info("Draining scan workers", "timeout", p.drainTimeout)
+	select {
+	case <-drained:
+		logger.Info("Scan workers drained")
+	case <-time.After(p.drainTimeout):
+		logger.Warn("Scan workers did not drain in time, cancelling running scans")
+		cancelProcessing()
+		<-drained
+	}
+}
+
+// runWorker polls the queue for tasks and processes them one at a time until ctx is cancelled
+func (p *ScanWorkerPool) runWorker(ctx context.Context, processCtx context.Context, worker int) {
+	for ctx.Err() == nil {
+		// The queue long polls, so an empty result already waited for a task to arrive
+		task, err := p.scanQueue.Dequeue(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			logger.Error("Failed to dequeue scan task", "error", err, "worker", worker)
+			select {
+			case <-time.After(dequeueErrorBackoff):
+			case <-ctx.Done():
+			}
+			continue
+		}
+		if task == nil {
+			continue
+		}
+
+		p.processTask(processCtx, *task, worker)
+	}
+}
+
+// processTask processes a task, extending its visibility at half the visibility timeout until the
+// scan finishes. The task is processed in a span continuing the trace of the upload that queued it,
+// and logged with the upload's request ID.
+func (p *ScanWorkerPool) processTask(ctx context.Context, task services.ScanTask, worker int) {
+	ctx = logger.ContextWithRequestID(ctx, task.TraceContext[logger.RequestIDKey])
+	ctx, span := tracing.StartSpan(tracing.Extract(ctx, task.TraceContext), "ScanWorker.ProcessScanTask", trace.WithSpanKind(trace.SpanKindConsumer))
+	tracing.AddAttribute(span, "document.id", task.DocumentID)
+	tracing.AddAttribute(span, "tenant.id", task.TenantID)
+	tracing.AddAttribute(span, "scan.priority", task.Priority)
+	tracing.AddAttribute(span, "scan.retry_count", task.RetryCount)
+
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	defer func() {
+		close(done)
+		<-stopped
+	}()
+
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(p.visibilityTimeout / 2)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := p.scanQueue.ExtendVisibility(ctx, task, p.visibilityTimeout); err != nil {
+					logger.Warn("Failed to extend scan task visibility", "error", err, "documentID", task.DocumentID)
+				}
+			case <-done:
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	err := p.scanner.ProcessScanTask(ctx, task)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to process scan task", "error", err, "worker", worker,
+			"documentID", task.DocumentID, "tenantID", task.TenantID)
+	}
+	tracing.EndSpan(span, err)
+}
+
+// parseWorkerDuration parses a duration setting, returning the default when it is unset
+func parseWorkerDuration(value string, defaultValue time.Duration) (time.Duration, error) {
+	if value == "" {
+		return defaultValue, nil
+	}
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if parsed < 0 {
+		return 0, errors.NewValidationError("duration cannot be negative")
+	}
+	return parsed, nil
+}
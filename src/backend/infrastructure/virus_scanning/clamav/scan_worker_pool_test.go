@@ -0,0 +1,143 @@
+package clamav
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"src/backend/domain/services"
+	"src/backend/pkg/config"
+)
+
+// fakePoolQueue is a scan queue handing out a fixed list of tasks and recording visibility extensions
+type fakePoolQueue struct {
+	services.ScanQueue
+	mu         sync.Mutex
+	tasks      []services.ScanTask
+	extensions []string
+}
+
+func (q *fakePoolQueue) Dequeue(ctx context.Context) (*services.ScanTask, error) {
+	q.mu.Lock()
+	if len(q.tasks) > 0 {
+		task := q.tasks[0]
+		q.tasks = q.tasks[1:]
+		q.mu.Unlock()
+		return &task, nil
+	}
+	q.mu.Unlock()
+
+	// Stand in for long polling an empty queue
+	select {
+	case <-time.After(10 * time.Millisecond):
+	case <-ctx.Done():
+	}
+	return nil, nil
+}
+
+func (q *fakePoolQueue) ExtendVisibility(ctx context.Context, task services.ScanTask, timeout time.Duration) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.extensions = append(q.extensions, task.ReceiptHandle)
+	return nil
+}
+
+// fakePoolScanner is a scanner recording the tasks it processed, each taking scanTime
+type fakePoolScanner struct {
+	services.VirusScanningService
+	mu        sync.Mutex
+	scanTime  time.Duration
+	processed []string
+	cancelled int
+}
+
+func (s *fakePoolScanner) ProcessScanTask(ctx context.Context, task services.ScanTask) error {
+	select {
+	case <-time.After(s.scanTime):
+	case <-ctx.Done():
+		s.mu.Lock()
+		s.cancelled++
+		s.mu.Unlock()
+		return ctx.Err()
+	}
+	s.mu.Lock()
+	s.processed = append(s.processed, task.DocumentID)
+	s.mu.Unlock()
+	return nil
+}
+
+// TestScanWorkerPool_ProcessesTasksConcurrently tests that the workers process tasks in parallel
+// and that the tasks being processed at shutdown are drained
+func TestScanWorkerPool_ProcessesTasksConcurrently(t *testing.T) {
+	queue := &fakePoolQueue{tasks: []services.ScanTask{
+		{DocumentID: "doc-1", ReceiptHandle: "r-1"},
+		{DocumentID: "doc-2", ReceiptHandle: "r-2"},
+		{DocumentID: "doc-3", ReceiptHandle: "r-3"},
+		{DocumentID: "doc-4", ReceiptHandle: "r-4"},
+	}}
+	scanner := &fakePoolScanner{scanTime: 200 * time.Millisecond}
+
+	pool, err := NewScanWorkerPool(scanner, queue, config.ScanWorkersConfig{Concurrency: 4})
+	require.NoError(t, err)
+
+	// Shut down while the scans are running; they all started at once and finish during the drain
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	pool.Run(ctx)
+
+	assert.ElementsMatch(t, []string{"doc-1", "doc-2", "doc-3", "doc-4"}, scanner.processed)
+	assert.Less(t, time.Since(start), 600*time.Millisecond)
+}
+
+// TestScanWorkerPool_ExtendsVisibility tests that the visibility of long running scans is extended
+func TestScanWorkerPool_ExtendsVisibility(t *testing.T) {
+	queue := &fakePoolQueue{tasks: []services.ScanTask{{DocumentID: "doc-1", ReceiptHandle: "r-1"}}}
+	scanner := &fakePoolScanner{scanTime: 1200 * time.Millisecond}
+
+	pool, err := NewScanWorkerPool(scanner, queue, config.ScanWorkersConfig{Concurrency: 1, VisibilityTimeout: "1s"})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	pool.Run(ctx)
+
+	assert.Equal(t, []string{"doc-1"}, scanner.processed)
+	assert.Contains(t, queue.extensions, "r-1")
+}
+
+// TestScanWorkerPool_DrainTimeout tests that scans still running after the drain timeout are cancelled
+func TestScanWorkerPool_DrainTimeout(t *testing.T) {
+	queue := &fakePoolQueue{tasks: []services.ScanTask{{DocumentID: "doc-1", ReceiptHandle: "r-1"}}}
+	scanner := &fakePoolScanner{scanTime: time.Minute}
+
+	pool, err := NewScanWorkerPool(scanner, queue, config.ScanWorkersConfig{Concurrency: 1, DrainTimeout: "50ms"})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	pool.Run(ctx)
+
+	assert.Empty(t, scanner.processed)
+	assert.Equal(t, 1, scanner.cancelled)
+}
+
+// TestNewScanWorkerPool_InvalidConfig tests that invalid settings are rejected
+func TestNewScanWorkerPool_InvalidConfig(t *testing.T) {
+	scanner := &fakePoolScanner{}
+	queue := &fakePoolQueue{}
+
+	_, err := NewScanWorkerPool(scanner, queue, config.ScanWorkersConfig{Concurrency: -1})
+	assert.Error(t, err)
+
+	_, err = NewScanWorkerPool(scanner, queue, config.ScanWorkersConfig{VisibilityTimeout: "100ms"})
+	assert.Error(t, err)
+
+	_, err = NewScanWorkerPool(scanner, queue, config.ScanWorkersConfig{DrainTimeout: "soon"})
+	assert.Error(t, err)
+}
@@ -9,6 +9,8 @@ import (
 	"sync"
 	"time"
 
+	"src/backend/domain/models"
+	"src/backend/domain/repositories"
 	"src/backend/domain/services"
 	"src/backend/pkg/errors"
 	"src/backend/pkg/logger"
@@ -19,17 +21,12 @@ import (
 // Maximum number of retry attempts for scan tasks
 const maxRetries = 3
 
-// Metric constants for virus scanning
-const scannerMetricPrefix = "virus_scanner"
-const documentScannedCounter = scannerMetricPrefix + "_documents_scanned_total"
-const documentInfectedCounter = scannerMetricPrefix + "_documents_infected_total"
-const documentCleanCounter = scannerMetricPrefix + "_documents_clean_total"
-const scanErrorCounter = scannerMetricPrefix + "_scan_errors_total"
-const scanDurationHistogram = scannerMetricPrefix + "_scan_duration_seconds"
-
-// VirusScanner implements the VirusScanningService interface using ClamAV.
+// VirusScanner implements the VirusScanningService interface using ClamAV, or the scanning engines
+// each tenant selected.
 type VirusScanner struct {
-	scannerClient   services.ScannerClient
+	engineSelector  services.ScanningEngineSelector
+	quarantineRepo  repositories.QuarantineRepository // Records quarantined versions; nil to only move them to quarantine storage
+	verdictCache    services.ScanVerdictCache          // Clean verdicts of recently scanned content; nil to scan every upload
 	scanQueue       services.ScanQueue
 	storageService  services.StorageService
 	eventService    services.EventServiceInterface
@@ -48,6 +45,33 @@ func NewVirusScanner(scannerClient services.ScannerClient, scanQueue services.Sc
 		return nil, errors.NewValidationError("scannerClient cannot be nil")
 	}
 	
+	return newVirusScanner(singleEngineSelector{scannerClient: scannerClient}, nil, nil, scanQueue, storageService, eventService, cfg)
+}
+
+// NewMultiEngineVirusScanner creates a VirusScanningService that scans the documents of each tenant
+// with the engines chosen by engineSelector, and records the versions it quarantines in quarantineRepo
+// for security administrators to review. Uploads of content verdictCache knows to be clean are not
+// scanned again; verdictCache may be nil to scan every upload.
+func NewMultiEngineVirusScanner(engineSelector services.ScanningEngineSelector, quarantineRepo repositories.QuarantineRepository,
+                                verdictCache services.ScanVerdictCache, scanQueue services.ScanQueue, storageService services.StorageService,
+                                eventService services.EventServiceInterface, cfg config.Config) (services.VirusScanningService, error) {
+	// Validate that quarantineRepo is not nil
+	if quarantineRepo == nil {
+		return nil, errors.NewValidationError("quarantineRepo cannot be nil")
+	}
+	
+	return newVirusScanner(engineSelector, quarantineRepo, verdictCache, scanQueue, storageService, eventService, cfg)
+}
+
+// newVirusScanner creates a VirusScanner, validating its dependencies
+func newVirusScanner(engineSelector services.ScanningEngineSelector, quarantineRepo repositories.QuarantineRepository,
+                     verdictCache services.ScanVerdictCache, scanQueue services.ScanQueue, storageService services.StorageService,
+                     eventService services.EventServiceInterface, cfg config.Config) (services.VirusScanningService, error) {
+	// Validate that engineSelector is not nil
+	if engineSelector == nil {
+		return nil, errors.NewValidationError("engineSelector cannot be nil")
+	}
+	
 	// Validate that scanQueue is not nil
 	if scanQueue == nil {
 		return nil, errors.NewValidationError("scanQueue cannot be nil")
@@ -65,7 +89,9 @@ func NewVirusScanner(scannerClient services.ScannerClient, scanQueue services.Sc
 	
 	// Create and return a new VirusScanner instance
 	return &VirusScanner{
-		scannerClient:  scannerClient,
+		engineSelector: engineSelector,
+		quarantineRepo: quarantineRepo,
+		verdictCache:   verdictCache,
 		scanQueue:      scanQueue,
 		storageService: storageService,
 		eventService:   eventService,
@@ -75,8 +101,8 @@ func NewVirusScanner(scannerClient services.ScannerClient, scanQueue services.Sc
 	}, nil
 }
 
-// QueueForScanning queues a document for virus scanning
-func (v *VirusScanner) QueueForScanning(ctx context.Context, documentID, versionID, tenantID, storagePath string) error {
+// QueueForScanning queues a document for virus scanning in the queue of its priority
+func (v *VirusScanner) QueueForScanning(ctx context.Context, documentID, versionID, tenantID, storagePath, contentHash, priority string) error {
 	// Get logger with context
 	log := logger.WithContext(ctx)
 	
@@ -92,13 +118,22 @@ func (v *VirusScanner) QueueForScanning(ctx context.Context, documentID, version
 		return err
 	}
 	
+	if priority == "" {
+		priority = services.ScanPriorityNormal
+	}
+	if !services.IsValidScanPriority(priority) {
+		return errors.NewValidationError(fmt.Sprintf("invalid scan priority: %s", priority))
+	}
+	
 	// Create a scan task
 	task := services.ScanTask{
 		DocumentID:  documentID,
 		VersionID:   versionID,
 		TenantID:    tenantID,
 		StoragePath: storagePath,
+		ContentHash: contentHash,
 		RetryCount:  0,
+		Priority:    priority,
 	}
 	
 	// Enqueue the task
@@ -114,7 +149,8 @@ func (v *VirusScanner) QueueForScanning(ctx context.Context, documentID, version
 	log.Info("Document queued for virus scanning", 
 		"documentID", documentID, 
 		"tenantID", tenantID,
-		"storagePath", storagePath)
+		"storagePath", storagePath,
+		"priority", priority)
 	return nil
 }
 
@@ -185,8 +221,18 @@ func (v *VirusScanner) ProcessScanQueue(ctx context.Context, batchSize int) (int
 	return processed, nil
 }
 
-// ScanDocument scans a document for viruses
+// ProcessScanTask processes a scan task dequeued by a worker of the scan worker pool
+func (v *VirusScanner) ProcessScanTask(ctx context.Context, task services.ScanTask) error {
+	return v.processScanTask(ctx, task)
+}
+
+// ScanDocument scans a document for viruses with the default engines
 func (v *VirusScanner) ScanDocument(ctx context.Context, storagePath string) (string, string, error) {
+	return v.scanTenantDocument(ctx, "", storagePath)
+}
+
+// scanTenantDocument scans a document for viruses with the engines its tenant selected
+func (v *VirusScanner) scanTenantDocument(ctx context.Context, tenantID string, storagePath string) (string, string, error) {
 	// Get logger with context
 	log := logger.WithContext(ctx)
 	
@@ -199,6 +245,13 @@ func (v *VirusScanner) ScanDocument(ctx context.Context, storagePath string) (st
 	
 	log.Info("Scanning document for viruses", "storagePath", storagePath)
 	
+	// Resolve the engines the document is scanned with
+	scannerClient, err := v.engineSelector.EngineForTenant(ctx, tenantID)
+	if err != nil {
+		log.WithError(err).Error("Failed to select scanning engines", "tenantID", tenantID)
+		return services.ScanResultError, "", errors.Wrap(err, "failed to select scanning engines")
+	}
+	
 	// Start timer for scan duration metrics
 	startTime := time.Now()
 	
@@ -211,26 +264,22 @@ func (v *VirusScanner) ScanDocument(ctx context.Context, storagePath string) (st
 	defer content.Close()
 	
 	// Call scannerClient.ScanStream to scan the document
-	result, details, err := v.scannerClient.ScanStream(ctx, content)
+	result, details, err := scannerClient.ScanStream(ctx, content)
 	
-	// Record scan duration metric
+	// Record the verdict and duration of the scan
 	scanDuration := time.Since(startTime)
-	metrics.ObserveHistogram(scanDurationHistogram, scanDuration.Seconds())
-	
-	// Increment appropriate counter based on scan result
-	metrics.IncrementCounter(documentScannedCounter, 1)
 	
 	if err != nil {
 		log.WithError(err).Error("Error scanning document", "storagePath", storagePath)
-		metrics.IncrementCounter(scanErrorCounter, 1)
+		metrics.ObserveScan(services.ScanResultError, scanDuration)
 		return services.ScanResultError, fmt.Sprintf("scan error: %s", err.Error()), errors.Wrap(err, "failed to scan document")
 	} else if result == services.ScanResultInfected {
 		log.Warn("Virus detected in document", "storagePath", storagePath, "virusDetails", details)
-		metrics.IncrementCounter(documentInfectedCounter, 1)
+		metrics.IncVirusDetections()
 	} else {
 		log.Info("Document scan completed successfully", "storagePath", storagePath, "result", result)
-		metrics.IncrementCounter(documentCleanCounter, 1)
 	}
+	metrics.ObserveScan(result, scanDuration)
 	
 	return result, details, nil
 }
@@ -309,8 +358,44 @@ func (v *VirusScanner) processScanTask(ctx context.Context, task services.ScanTa
 	
 	log.Info("Processing scan task")
 	
-	// Call ScanDocument to scan the document
-	result, details, err := v.ScanDocument(ctx, task.StoragePath)
+	// A security administrator overrode the verdict of an earlier scan, so the document is clean by decision
+	if task.OverriddenBy != "" {
+		log.Info("Scan verdict overridden, marking as clean", "overriddenBy", task.OverriddenBy)
+		
+		v.publishLifecycleEvent(ctx, models.EventTypeDocumentClean, task, map[string]interface{}{
+			"overriddenBy": task.OverriddenBy,
+		})
+		
+		if completeErr := v.scanQueue.Complete(ctx, task); completeErr != nil {
+			log.WithError(completeErr).Error("Failed to mark scan task as complete")
+			return errors.Wrap(completeErr, "failed to mark scan task as complete")
+		}
+		return nil
+	}
+	
+	// Identical content of the tenant was recently scanned clean, so the document is clean without scanning it again
+	if v.verdictCache != nil && v.verdictCache.IsKnownClean(ctx, task.TenantID, task.ContentHash) {
+		log.Info("Content recently scanned clean, marking as clean")
+		metrics.IncScanVerdictCacheHits()
+		
+		v.publishLifecycleEvent(ctx, models.EventTypeDocumentClean, task, map[string]interface{}{
+			"cachedVerdict": true,
+		})
+		
+		if completeErr := v.scanQueue.Complete(ctx, task); completeErr != nil {
+			log.WithError(completeErr).Error("Failed to mark scan task as complete")
+			return errors.Wrap(completeErr, "failed to mark scan task as complete")
+		}
+		return nil
+	}
+	
+	// Publish document.scanning the first time the task is picked up; retries are the same scan
+	if task.RetryCount == 0 {
+		v.publishLifecycleEvent(ctx, models.EventTypeDocumentScanning, task, nil)
+	}
+	
+	// Scan the document with the engines of its tenant
+	result, details, err := v.scanTenantDocument(ctx, task.TenantID, task.StoragePath)
 	
 	// Handle scan result based on outcome
 	if err != nil {
@@ -337,15 +422,10 @@ func (v *VirusScanner) processScanTask(ctx context.Context, task services.ScanTa
 		}
 		
 		// Publish document.scan_failed event with error details
-		_, pubErr := v.eventService.CreateAndPublishDocumentEvent(ctx, "document.scan_failed", 
-			task.TenantID, task.DocumentID, map[string]interface{}{
-				"error": err.Error(),
-				"scanAttempts": task.RetryCount,
-			})
-		
-		if pubErr != nil {
-			log.WithError(pubErr).Error("Failed to publish scan failed event")
-		}
+		v.publishLifecycleEvent(ctx, models.EventTypeDocumentScanFailed, task, map[string]interface{}{
+			"error":        err.Error(),
+			"scanAttempts": task.RetryCount,
+		})
 		
 		return nil
 	}
@@ -354,16 +434,14 @@ func (v *VirusScanner) processScanTask(ctx context.Context, task services.ScanTa
 	if result == services.ScanResultClean {
 		log.Info("Document scan clean, marking as complete")
 		
-		// Publish document.scanned event with clean status
-		_, pubErr := v.eventService.CreateAndPublishDocumentEvent(ctx, "document.scanned", 
-			task.TenantID, task.DocumentID, map[string]interface{}{
-				"status": "clean",
-			})
-		
-		if pubErr != nil {
-			log.WithError(pubErr).Error("Failed to publish document scanned event")
+		// Remember the verdict, so identical uploads of the tenant are not scanned again
+		if v.verdictCache != nil {
+			v.verdictCache.RecordClean(ctx, task.TenantID, task.ContentHash)
 		}
 		
+		// Publish document.clean event
+		v.publishLifecycleEvent(ctx, models.EventTypeDocumentClean, task, nil)
+		
 		// Mark task as complete in queue
 		if completeErr := v.scanQueue.Complete(ctx, task); completeErr != nil {
 			log.WithError(completeErr).Error("Failed to mark scan task as complete")
@@ -382,17 +460,21 @@ func (v *VirusScanner) processScanTask(ctx context.Context, task services.ScanTa
 			return errors.Wrap(quarErr, "failed to move infected document to quarantine")
 		}
 		
-		// Publish document.quarantined event with virus details
-		_, pubErr := v.eventService.CreateAndPublishDocumentEvent(ctx, "document.quarantined", 
-			task.TenantID, task.DocumentID, map[string]interface{}{
-				"reason": details,
-				"quarantinePath": quarantinePath,
-			})
-		
-		if pubErr != nil {
-			log.WithError(pubErr).Error("Failed to publish document quarantined event")
+		// Record the quarantined version with its verdict, so security administrators can review it.
+		// The content has already left temporary storage, so a failure cannot be retried by scanning again.
+		if v.quarantineRepo != nil {
+			item := models.NewQuarantinedItem(task.TenantID, task.DocumentID, task.VersionID, quarantinePath, details)
+			if _, recErr := v.quarantineRepo.Create(ctx, item); recErr != nil {
+				log.WithError(recErr).Error("Failed to record quarantined document", "quarantinePath", quarantinePath)
+			}
 		}
 		
+		// Publish document.quarantined event with virus details
+		v.publishLifecycleEvent(ctx, models.EventTypeDocumentQuarantined, task, map[string]interface{}{
+			"reason":         details,
+			"quarantinePath": quarantinePath,
+		})
+		
 		// Mark task as complete in queue
 		if completeErr := v.scanQueue.Complete(ctx, task); completeErr != nil {
 			log.WithError(completeErr).Error("Failed to mark scan task as complete")
@@ -405,6 +487,37 @@ func (v *VirusScanner) processScanTask(ctx context.Context, task services.ScanTa
 	return nil
 }
 
+// publishLifecycleEvent publishes a document lifecycle event for the version of a scan task.
+// Failures are logged rather than returned, so a publishing problem never fails the scan itself.
+func (v *VirusScanner) publishLifecycleEvent(ctx context.Context, eventType string, task services.ScanTask, additionalData map[string]interface{}) {
+	// The priority lets consumers such as the search indexer process the version with the same urgency
+	payload := map[string]interface{}{
+		"versionID": task.VersionID,
+		"priority":  task.Priority,
+	}
+	for k, val := range additionalData {
+		payload[k] = val
+	}
+	
+	_, err := v.eventService.CreateAndPublishDocumentEvent(ctx, eventType, task.TenantID, task.DocumentID, payload)
+	if err != nil {
+		logger.WithContext(ctx).WithError(err).Error("Failed to publish document lifecycle event",
+			"eventType", eventType,
+			"documentID", task.DocumentID,
+			"versionID", task.VersionID)
+	}
+}
+
+// singleEngineSelector is a ScanningEngineSelector scanning the documents of every tenant with one scanner
+type singleEngineSelector struct {
+	scannerClient services.ScannerClient
+}
+
+// EngineForTenant returns the scanner regardless of the tenant
+func (s singleEngineSelector) EngineForTenant(ctx context.Context, tenantID string) (services.ScannerClient, error) {
+	return s.scannerClient, nil
+}
+
 // validateInput validates input parameters
 func (v *VirusScanner) validateInput(params map[string]string) error {
 	// Check each parameter in the map
@@ -11,11 +11,61 @@ import (
 	"github.com/stretchr/testify/mock" // v1.8.0+
 	"github.com/stretchr/testify/require" // v1.8.0+
 
+	"../../../domain/models"
 	"../../../domain/services"
 	"../../../pkg/config"
+	"../../../pkg/utils"
 	"../../../test/mockery"
 )
 
+// mockQuarantineRepository is a mock implementation of the QuarantineRepository interface for testing
+type mockQuarantineRepository struct {
+	mock.Mock
+}
+
+func (m *mockQuarantineRepository) Create(ctx context.Context, item *models.QuarantinedItem) (string, error) {
+	args := m.Called(ctx, item)
+	return args.String(0), args.Error(1)
+}
+
+func (m *mockQuarantineRepository) GetByID(ctx context.Context, id string, tenantID string) (*models.QuarantinedItem, error) {
+	args := m.Called(ctx, id, tenantID)
+	if item := args.Get(0); item != nil {
+		return item.(*models.QuarantinedItem), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *mockQuarantineRepository) List(ctx context.Context, tenantID string, status string, pagination *utils.Pagination) (utils.PaginatedResult[models.QuarantinedItem], error) {
+	args := m.Called(ctx, tenantID, status, pagination)
+	return args.Get(0).(utils.PaginatedResult[models.QuarantinedItem]), args.Error(1)
+}
+
+func (m *mockQuarantineRepository) Resolve(ctx context.Context, item *models.QuarantinedItem) error {
+	args := m.Called(ctx, item)
+	return args.Error(0)
+}
+
+// fakeScanVerdictCache is a ScanVerdictCache remembering clean verdicts in a map keyed by tenant and content hash
+type fakeScanVerdictCache struct {
+	clean map[string]bool
+}
+
+func (c *fakeScanVerdictCache) IsKnownClean(ctx context.Context, tenantID, contentHash string) bool {
+	return contentHash != "" && c.clean[tenantID+":"+contentHash]
+}
+
+func (c *fakeScanVerdictCache) RecordClean(ctx context.Context, tenantID, contentHash string) {
+	if contentHash != "" {
+		c.clean[tenantID+":"+contentHash] = true
+	}
+}
+
+func (c *fakeScanVerdictCache) Invalidate(ctx context.Context) error {
+	c.clean = make(map[string]bool)
+	return nil
+}
+
 // TestNewVirusScanner tests the creation of a new VirusScanner instance
 func TestNewVirusScanner(t *testing.T) {
 	// Create mock dependencies
@@ -134,11 +184,13 @@ func TestVirusScanner_QueueForScanning(t *testing.T) {
 			   task.VersionID == "ver-123" &&
 			   task.TenantID == "tenant-123" &&
 			   task.StoragePath == "path/to/document" &&
-			   task.RetryCount == 0
+			   task.ContentHash == "hash-123" &&
+			   task.RetryCount == 0 &&
+			   task.Priority == services.ScanPriorityNormal
 	})).Return(nil)
 
-	// Call QueueForScanning
-	err = scanner.QueueForScanning(context.Background(), "doc-123", "ver-123", "tenant-123", "path/to/document")
+	// Call QueueForScanning without a priority hint
+	err = scanner.QueueForScanning(context.Background(), "doc-123", "ver-123", "tenant-123", "path/to/document", "hash-123", "")
 	
 	// Assert expectations
 	assert.NoError(t, err)
@@ -174,6 +226,7 @@ func TestVirusScanner_QueueForScanning_ValidationErrors(t *testing.T) {
 		versionID   string
 		tenantID    string
 		storagePath string
+		priority    string
 	}{
 		{
 			name:        "Empty DocumentID",
@@ -203,12 +256,20 @@ func TestVirusScanner_QueueForScanning_ValidationErrors(t *testing.T) {
 			tenantID:    "tenant-123",
 			storagePath: "",
 		},
+		{
+			name:        "Invalid Priority",
+			documentID:  "doc-123",
+			versionID:   "ver-123",
+			tenantID:    "tenant-123",
+			storagePath: "path/to/document",
+			priority:    "urgent",
+		},
 	}
 
 	// Run test cases
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			err = scanner.QueueForScanning(context.Background(), tc.documentID, tc.versionID, tc.tenantID, tc.storagePath)
+			err = scanner.QueueForScanning(context.Background(), tc.documentID, tc.versionID, tc.tenantID, tc.storagePath, "", tc.priority)
 			assert.Error(t, err)
 		})
 	}
@@ -240,7 +301,7 @@ func TestVirusScanner_QueueForScanning_QueueError(t *testing.T) {
 	mockScanQueue.On("Enqueue", mock.Anything, mock.Anything).Return(errors.New("queue error"))
 
 	// Call QueueForScanning
-	err = scanner.QueueForScanning(context.Background(), "doc-123", "ver-123", "tenant-123", "path/to/document")
+	err = scanner.QueueForScanning(context.Background(), "doc-123", "ver-123", "tenant-123", "path/to/document", "", services.ScanPriorityHigh)
 	
 	// Assert expectations
 	assert.Error(t, err)
@@ -303,7 +364,8 @@ func TestVirusScanner_ProcessScanQueue(t *testing.T) {
 	mockScanQueue.On("Complete", mock.Anything, *task2).Return(nil)
 
 	// Set up expectations for publishing events
-	mockEventService.On("CreateAndPublishDocumentEvent", mock.Anything, "document.scanned", mock.Anything).Return(nil).Twice()
+	mockEventService.On("CreateAndPublishDocumentEvent", mock.Anything, "document.scanning", mock.Anything).Return(nil).Twice()
+	mockEventService.On("CreateAndPublishDocumentEvent", mock.Anything, "document.clean", mock.Anything).Return(nil).Twice()
 
 	// Call ProcessScanQueue
 	count, err := scanner.ProcessScanQueue(context.Background(), 10)
@@ -822,7 +884,8 @@ func TestVirusScanner_processScanTask_Clean(t *testing.T) {
 	mockScanQueue.On("Complete", mock.Anything, *task).Return(nil)
 
 	// Set up expectations for event publishing
-	mockEventService.On("CreateAndPublishDocumentEvent", mock.Anything, "document.scanned", mock.Anything).Return(nil)
+	mockEventService.On("CreateAndPublishDocumentEvent", mock.Anything, "document.scanning", mock.Anything).Return(nil)
+	mockEventService.On("CreateAndPublishDocumentEvent", mock.Anything, "document.clean", mock.Anything).Return(nil)
 
 	// Call processScanTask - assuming it's exported for testing
 	// If processScanTask is not exported, we would test this through ProcessScanQueue
@@ -882,6 +945,7 @@ func TestVirusScanner_processScanTask_Infected(t *testing.T) {
 	mockScanQueue.On("Complete", mock.Anything, *task).Return(nil)
 
 	// Set up expectations for event publishing
+	mockEventService.On("CreateAndPublishDocumentEvent", mock.Anything, "document.scanning", mock.Anything).Return(nil)
 	mockEventService.On("CreateAndPublishDocumentEvent", mock.Anything, "document.quarantined", mock.Anything).Return(nil)
 
 	// Call processScanTask
@@ -895,6 +959,183 @@ func TestVirusScanner_processScanTask_Infected(t *testing.T) {
 	mockEventService.AssertExpectations(t)
 }
 
+// TestVirusScanner_processScanTask_Infected_RecordsQuarantine tests that quarantined versions are
+// recorded with their verdict, and that a failure to record them does not fail the scan task
+func TestVirusScanner_processScanTask_Infected_RecordsQuarantine(t *testing.T) {
+	// Create mock dependencies
+	mockScannerClient := new(mockery.ScannerClient)
+	mockScanQueue := new(mockery.ScanQueue)
+	mockStorageService := new(mockery.StorageService)
+	mockEventService := new(mockery.EventServiceInterface)
+	mockQuarantineRepo := new(mockQuarantineRepository)
+
+	// Create a new VirusScanner recording quarantined versions
+	scanner, err := NewMultiEngineVirusScanner(singleEngineSelector{scannerClient: mockScannerClient}, mockQuarantineRepo,
+		nil, mockScanQueue, mockStorageService, mockEventService, config.Config{})
+	require.NoError(t, err)
+
+	// Create a test task
+	task := services.ScanTask{
+		DocumentID:  "doc-123",
+		VersionID:   "ver-123",
+		TenantID:    "tenant-123",
+		StoragePath: "temp/tenant-123/doc-123",
+		RetryCount:  1,
+	}
+
+	// Set up expectations for scanning with infected result and moving to quarantine
+	mockStorageService.On("GetDocument", mock.Anything, task.StoragePath).Return(bytes.NewReader([]byte("infected content")), nil)
+	mockScannerClient.On("ScanStream", mock.Anything, mock.Anything).Return(services.ScanResultInfected, "EICAR-Test-Signature", nil)
+	mockStorageService.On("MoveToQuarantine", mock.Anything, task.TenantID, task.DocumentID, task.VersionID, task.StoragePath).
+		Return("quarantine/tenant-123/doc-123", nil)
+	mockEventService.On("CreateAndPublishDocumentEvent", mock.Anything, "document.quarantined", mock.Anything).Return(nil)
+	mockScanQueue.On("Complete", mock.Anything, task).Return(nil)
+
+	// The quarantined version is recorded with its verdict; the record failing does not fail the task
+	mockQuarantineRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *models.QuarantinedItem) bool {
+		return item.TenantID == task.TenantID &&
+			item.DocumentID == task.DocumentID &&
+			item.VersionID == task.VersionID &&
+			item.StoragePath == "quarantine/tenant-123/doc-123" &&
+			item.ScanDetails == "EICAR-Test-Signature" &&
+			item.Status == models.QuarantineStatusQuarantined
+	})).Return("", errors.New("database unavailable"))
+
+	// Call processScanTask
+	err = scanner.(*VirusScanner).processScanTask(context.Background(), task)
+
+	// Assert expectations
+	assert.NoError(t, err)
+	mockQuarantineRepo.AssertExpectations(t)
+	mockScanQueue.AssertExpectations(t)
+}
+
+// TestVirusScanner_processScanTask_Overridden tests that a document released from quarantine with an
+// overridden verdict is marked clean without being scanned again
+func TestVirusScanner_processScanTask_Overridden(t *testing.T) {
+	// Create mock dependencies
+	mockScannerClient := new(mockery.ScannerClient)
+	mockScanQueue := new(mockery.ScanQueue)
+	mockStorageService := new(mockery.StorageService)
+	mockEventService := new(mockery.EventServiceInterface)
+
+	// Create a new VirusScanner
+	scanner, err := NewMultiEngineVirusScanner(singleEngineSelector{scannerClient: mockScannerClient}, new(mockQuarantineRepository),
+		nil, mockScanQueue, mockStorageService, mockEventService, config.Config{})
+	require.NoError(t, err)
+
+	// Create a test task released by a security administrator
+	task := services.ScanTask{
+		DocumentID:   "doc-123",
+		VersionID:    "ver-123",
+		TenantID:     "tenant-123",
+		StoragePath:  "temp/tenant-123/doc-123",
+		OverriddenBy: "admin-123",
+	}
+
+	// Set up expectations for the clean event and task completion
+	mockEventService.On("CreateAndPublishDocumentEvent", mock.Anything, "document.clean", mock.Anything).Return(nil)
+	mockScanQueue.On("Complete", mock.Anything, task).Return(nil)
+
+	// Call processScanTask
+	err = scanner.(*VirusScanner).processScanTask(context.Background(), task)
+
+	// Assert expectations
+	assert.NoError(t, err)
+	mockEventService.AssertExpectations(t)
+	mockScanQueue.AssertExpectations(t)
+	mockStorageService.AssertNotCalled(t, "GetDocument", mock.Anything, mock.Anything)
+	mockScannerClient.AssertNotCalled(t, "ScanStream", mock.Anything, mock.Anything)
+}
+
+// TestVirusScanner_processScanTask_CachedVerdict tests that content recently scanned clean for the
+// tenant is marked clean without being scanned again
+func TestVirusScanner_processScanTask_CachedVerdict(t *testing.T) {
+	// Create mock dependencies
+	mockScannerClient := new(mockery.ScannerClient)
+	mockScanQueue := new(mockery.ScanQueue)
+	mockStorageService := new(mockery.StorageService)
+	mockEventService := new(mockery.EventServiceInterface)
+	verdictCache := &fakeScanVerdictCache{clean: map[string]bool{"tenant-123:hash-123": true}}
+
+	// Create a new VirusScanner caching clean verdicts
+	scanner, err := NewMultiEngineVirusScanner(singleEngineSelector{scannerClient: mockScannerClient}, new(mockQuarantineRepository),
+		verdictCache, mockScanQueue, mockStorageService, mockEventService, config.Config{})
+	require.NoError(t, err)
+
+	// Create a test task for content scanned clean before
+	task := services.ScanTask{
+		DocumentID:  "doc-123",
+		VersionID:   "ver-123",
+		TenantID:    "tenant-123",
+		StoragePath: "temp/tenant-123/doc-123",
+		ContentHash: "hash-123",
+	}
+
+	// Set up expectations for the clean event and task completion
+	mockEventService.On("CreateAndPublishDocumentEvent", mock.Anything, "document.clean", mock.Anything).Return(nil)
+	mockScanQueue.On("Complete", mock.Anything, task).Return(nil)
+
+	// Call processScanTask
+	err = scanner.(*VirusScanner).processScanTask(context.Background(), task)
+
+	// Assert expectations
+	assert.NoError(t, err)
+	mockEventService.AssertExpectations(t)
+	mockScanQueue.AssertExpectations(t)
+	mockStorageService.AssertNotCalled(t, "GetDocument", mock.Anything, mock.Anything)
+	mockScannerClient.AssertNotCalled(t, "ScanStream", mock.Anything, mock.Anything)
+}
+
+// TestVirusScanner_processScanTask_RecordsCleanVerdict tests that clean verdicts are cached for the
+// tenant and that other tenants uploading the same content are still scanned
+func TestVirusScanner_processScanTask_RecordsCleanVerdict(t *testing.T) {
+	// Create mock dependencies
+	mockScannerClient := new(mockery.ScannerClient)
+	mockScanQueue := new(mockery.ScanQueue)
+	mockStorageService := new(mockery.StorageService)
+	mockEventService := new(mockery.EventServiceInterface)
+	verdictCache := &fakeScanVerdictCache{clean: map[string]bool{"tenant-456:hash-123": true}}
+
+	// Create a new VirusScanner caching clean verdicts
+	scanner, err := NewMultiEngineVirusScanner(singleEngineSelector{scannerClient: mockScannerClient}, new(mockQuarantineRepository),
+		verdictCache, mockScanQueue, mockStorageService, mockEventService, config.Config{})
+	require.NoError(t, err)
+
+	// Create a test task for content only another tenant scanned clean before
+	task := services.ScanTask{
+		DocumentID:  "doc-123",
+		VersionID:   "ver-123",
+		TenantID:    "tenant-123",
+		StoragePath: "temp/tenant-123/doc-123",
+		ContentHash: "hash-123",
+	}
+
+	// Set up expectations for scanning with clean result
+	mockStorageService.On("GetDocument", mock.Anything, task.StoragePath).Return(bytes.NewReader([]byte("test content")), nil)
+	mockScannerClient.On("ScanStream", mock.Anything, mock.Anything).Return(services.ScanResultClean, "", nil)
+	mockEventService.On("CreateAndPublishDocumentEvent", mock.Anything, "document.scanning", mock.Anything).Return(nil)
+	mockEventService.On("CreateAndPublishDocumentEvent", mock.Anything, "document.clean", mock.Anything).Return(nil)
+	mockScanQueue.On("Complete", mock.Anything, task).Return(nil)
+
+	// Call processScanTask
+	err = scanner.(*VirusScanner).processScanTask(context.Background(), task)
+
+	// Assert expectations
+	assert.NoError(t, err)
+	mockScannerClient.AssertExpectations(t)
+	assert.True(t, verdictCache.IsKnownClean(context.Background(), "tenant-123", "hash-123"))
+}
+
+// TestNewMultiEngineVirusScanner_NilQuarantineRepository tests that the quarantine repository is required
+func TestNewMultiEngineVirusScanner_NilQuarantineRepository(t *testing.T) {
+	scanner, err := NewMultiEngineVirusScanner(singleEngineSelector{scannerClient: new(mockery.ScannerClient)}, nil,
+		nil, new(mockery.ScanQueue), new(mockery.StorageService), new(mockery.EventServiceInterface), config.Config{})
+
+	assert.Error(t, err)
+	assert.Nil(t, scanner)
+}
+
 // TestVirusScanner_processScanTask_Error_Retry tests processing a scan task with an error that triggers retry
 func TestVirusScanner_processScanTask_Error_Retry(t *testing.T) {
 	// Create mock dependencies
@@ -0,0 +1,106 @@
+// Package external provides a scanning engine that passes content through to an external service
+// and returns its verdict, for scanners that are only reachable over HTTP such as cloud scanning APIs.
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"../../../domain/models"
+	"../../../domain/services"
+	"../../../pkg/config"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+)
+
+// defaultTimeout is the timeout for scan operations when none is configured
+const defaultTimeout = 60 * time.Second
+
+// maxVerdictSize limits the verdict read from the service
+const maxVerdictSize = 64 * 1024
+
+// verdictResponse is the verdict the service returns for the content
+type verdictResponse struct {
+	Verdict string `json:"verdict"` // clean or infected
+	Details string `json:"details"` // Name of the threat when infected
+}
+
+// verdictEngine implements the ScanningEngine interface by posting content to an external verdict service
+type verdictEngine struct {
+	client *http.Client
+	config config.ExternalScanConfig
+}
+
+// NewVerdictEngine creates a scanning engine returning the verdicts of the service at cfg.URL
+func NewVerdictEngine(cfg config.ExternalScanConfig) (services.ScanningEngine, error) {
+	timeout := defaultTimeout
+	if cfg.Timeout > 0 {
+		timeout = time.Duration(cfg.Timeout) * time.Second
+	}
+	return NewVerdictEngineWithClient(&http.Client{Timeout: timeout}, cfg)
+}
+
+// NewVerdictEngineWithClient creates an external verdict scanning engine using the given HTTP client
+func NewVerdictEngineWithClient(client *http.Client, cfg config.ExternalScanConfig) (services.ScanningEngine, error) {
+	if client == nil {
+		return nil, errors.NewValidationError("HTTP client cannot be nil")
+	}
+	if cfg.URL == "" {
+		return nil, errors.NewValidationError("external scanning service URL cannot be empty")
+	}
+
+	return &verdictEngine{
+		client: client,
+		config: cfg,
+	}, nil
+}
+
+// Name returns the name tenants select the external verdict engine by
+func (e *verdictEngine) Name() string {
+	return models.ScanEngineExternal
+}
+
+// ScanStream streams content to the service and returns its verdict. The service answers with a JSON
+// object such as {"verdict": "infected", "details": "EICAR-Test-File"}.
+func (e *verdictEngine) ScanStream(ctx context.Context, content io.Reader) (string, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.config.URL, content)
+	if err != nil {
+		return services.ScanResultError, "", errors.Wrap(err, "failed to create verdict request")
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("Accept", "application/json")
+	if e.config.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+e.config.APIKey)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to reach external scanning service", "error", err.Error())
+		return services.ScanResultError, "", errors.NewDependencyError(fmt.Sprintf("failed to reach external scanning service: %s", err.Error()))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		logger.ErrorContext(ctx, "External scanning service failed to scan document", "status", resp.StatusCode)
+		return services.ScanResultError, "", errors.NewDependencyError(fmt.Sprintf("external scanning service returned status %d", resp.StatusCode))
+	}
+
+	var verdict verdictResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxVerdictSize)).Decode(&verdict); err != nil {
+		return services.ScanResultError, "", errors.NewDependencyError(fmt.Sprintf("invalid verdict from external scanning service: %s", err.Error()))
+	}
+
+	// Anything but an explicit verdict is an error, so an unexpected answer never passes as clean
+	switch verdict.Verdict {
+	case services.ScanResultClean:
+		return services.ScanResultClean, "", nil
+	case services.ScanResultInfected:
+		return services.ScanResultInfected, verdict.Details, nil
+	default:
+		return services.ScanResultError, "", errors.NewDependencyError(fmt.Sprintf("unknown verdict from external scanning service: %q", verdict.Verdict))
+	}
+}
@@ -0,0 +1,77 @@
+package external
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"  // v1.8.0+
+	"github.com/stretchr/testify/require" // v1.8.0+
+
+	"../../../domain/services"
+	"../../../pkg/config"
+)
+
+const testContent = "test document content"
+
+// createTestEngine creates an engine for a verdict service answering with status and body
+func createTestEngine(t *testing.T, status int, body string) services.ScanningEngine {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, _ := ioutil.ReadAll(r.Body)
+		if string(content) != testContent || r.Header.Get("Authorization") != "Bearer test-key" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	engine, err := NewVerdictEngine(config.ExternalScanConfig{URL: server.URL, APIKey: "test-key"})
+	require.NoError(t, err)
+	return engine
+}
+
+// TestScanStream tests that the verdict of the service is passed through
+func TestScanStream(t *testing.T) {
+	tests := []struct {
+		name            string
+		status          int
+		body            string
+		expectedResult  string
+		expectedDetails string
+		expectError     bool
+	}{
+		{"Clean", http.StatusOK, `{"verdict":"clean"}`, services.ScanResultClean, "", false},
+		{"Infected", http.StatusOK, `{"verdict":"infected","details":"EICAR-Test-File"}`, services.ScanResultInfected, "EICAR-Test-File", false},
+		{"Unknown verdict", http.StatusOK, `{"verdict":"suspicious"}`, services.ScanResultError, "", true},
+		{"Invalid verdict", http.StatusOK, `clean`, services.ScanResultError, "", true},
+		{"Service error", http.StatusServiceUnavailable, ``, services.ScanResultError, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := createTestEngine(t, tt.status, tt.body)
+
+			result, details, err := engine.ScanStream(context.Background(), strings.NewReader(testContent))
+
+			assert.Equal(t, tt.expectedResult, result)
+			assert.Equal(t, tt.expectedDetails, details)
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// TestNewVerdictEngine_MissingURL tests that the service URL is required
+func TestNewVerdictEngine_MissingURL(t *testing.T) {
+	_, err := NewVerdictEngine(config.ExternalScanConfig{})
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,196 @@
+// Package icap provides a scanning engine for commercial virus scanners served over ICAP (RFC 3507),
+// such as Symantec, McAfee, Kaspersky or Trend Micro scanning appliances.
+package icap
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"../../../domain/models"
+	"../../../domain/services"
+	"../../../pkg/config"
+	"../../../pkg/errors"
+	"../../../pkg/logger"
+)
+
+// Default values and constants
+const (
+	defaultPort    = "1344"
+	defaultTimeout = 60 * time.Second
+	chunkSize      = 32 * 1024
+)
+
+// Headers ICAP servers report infections in. Vendors differ in which one they use.
+var infectionHeaders = []string{"X-Infection-Found", "X-Virus-Id", "X-Violations-Found"}
+
+// encapsulatedRequest and encapsulatedResponse are the HTTP messages the content is wrapped in.
+// The scanner only looks at the response body, but many servers insist on both headers.
+const (
+	encapsulatedRequest  = "GET /document HTTP/1.1\r\nHost: document-mgmt\r\n\r\n"
+	encapsulatedResponse = "HTTP/1.1 200 OK\r\nContent-Type: application/octet-stream\r\nTransfer-Encoding: chunked\r\n\r\n"
+)
+
+// icapEngine implements the ScanningEngine interface by sending content to an ICAP service in a RESPMOD request
+type icapEngine struct {
+	serviceURL *url.URL
+	address    string
+	timeout    time.Duration
+}
+
+// NewICAPEngine creates a scanning engine for the ICAP service at cfg.URL
+func NewICAPEngine(cfg config.ICAPScanConfig) (services.ScanningEngine, error) {
+	serviceURL, err := url.Parse(cfg.URL)
+	if err != nil || serviceURL.Scheme != "icap" || serviceURL.Hostname() == "" {
+		return nil, errors.NewValidationError(fmt.Sprintf("invalid ICAP service URL: %s", cfg.URL))
+	}
+
+	port := serviceURL.Port()
+	if port == "" {
+		port = defaultPort
+	}
+	timeout := defaultTimeout
+	if cfg.Timeout > 0 {
+		timeout = time.Duration(cfg.Timeout) * time.Second
+	}
+
+	return &icapEngine{
+		serviceURL: serviceURL,
+		address:    net.JoinHostPort(serviceURL.Hostname(), port),
+		timeout:    timeout,
+	}, nil
+}
+
+// Name returns the name tenants select the ICAP engine by
+func (e *icapEngine) Name() string {
+	return models.ScanEngineICAP
+}
+
+// ScanStream scans content with the ICAP service. The service answers 204 when the content is clean;
+// any other successful answer means it blocked or rewrote the content, which counts as infected.
+func (e *icapEngine) ScanStream(ctx context.Context, content io.Reader) (string, string, error) {
+	dialer := net.Dialer{Timeout: e.timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", e.address)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to connect to ICAP service", "address", e.address, "error", err.Error())
+		return services.ScanResultError, "", errors.NewDependencyError(fmt.Sprintf("failed to connect to ICAP service: %s", err.Error()))
+	}
+	defer conn.Close()
+
+	deadline := time.Now().Add(e.timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		return services.ScanResultError, "", errors.NewDependencyError(fmt.Sprintf("failed to set ICAP connection deadline: %s", err.Error()))
+	}
+
+	if err := e.writeRequest(conn, content); err != nil {
+		return services.ScanResultError, "", errors.NewDependencyError(fmt.Sprintf("failed to send content to ICAP service: %s", err.Error()))
+	}
+
+	status, header, err := readResponse(bufio.NewReader(conn))
+	if err != nil {
+		return services.ScanResultError, "", errors.NewDependencyError(fmt.Sprintf("failed to read ICAP response: %s", err.Error()))
+	}
+
+	switch {
+	case status == 204:
+		return services.ScanResultClean, "", nil
+	case status == 200:
+		details := infectionDetails(header)
+		if details == "" {
+			details = "content blocked by ICAP service"
+		}
+		logger.WarnContext(ctx, "ICAP service found document infected", "details", details)
+		return services.ScanResultInfected, details, nil
+	default:
+		logger.ErrorContext(ctx, "ICAP service failed to scan document", "status", status)
+		return services.ScanResultError, "", errors.NewDependencyError(fmt.Sprintf("ICAP service returned status %d", status))
+	}
+}
+
+// writeRequest writes a RESPMOD request carrying content as the body of an HTTP response, in chunks
+func (e *icapEngine) writeRequest(conn net.Conn, content io.Reader) error {
+	w := bufio.NewWriterSize(conn, chunkSize+16)
+
+	fmt.Fprintf(w, "RESPMOD %s ICAP/1.0\r\n", e.serviceURL.String())
+	fmt.Fprintf(w, "Host: %s\r\n", e.serviceURL.Host)
+	fmt.Fprintf(w, "Allow: 204\r\n")
+	fmt.Fprintf(w, "Encapsulated: req-hdr=0, res-hdr=%d, res-body=%d\r\n\r\n",
+		len(encapsulatedRequest), len(encapsulatedRequest)+len(encapsulatedResponse))
+	w.WriteString(encapsulatedRequest)
+	w.WriteString(encapsulatedResponse)
+
+	buf := make([]byte, chunkSize)
+	for {
+		n, readErr := content.Read(buf)
+		if n > 0 {
+			fmt.Fprintf(w, "%x\r\n", n)
+			w.Write(buf[:n])
+			if _, err := w.WriteString("\r\n"); err != nil {
+				return err
+			}
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return readErr
+		}
+	}
+
+	w.WriteString("0\r\n\r\n")
+	return w.Flush()
+}
+
+// readResponse reads the status code and headers of an ICAP response
+func readResponse(r *bufio.Reader) (int, textproto.MIMEHeader, error) {
+	reader := textproto.NewReader(r)
+	line, err := reader.ReadLine()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	// The status line looks like "ICAP/1.0 204 No Content"
+	parts := strings.SplitN(line, " ", 3)
+	if len(parts) < 2 || !strings.HasPrefix(parts[0], "ICAP/") {
+		return 0, nil, fmt.Errorf("malformed status line: %q", line)
+	}
+	status, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, nil, fmt.Errorf("malformed status line: %q", line)
+	}
+
+	header, err := reader.ReadMIMEHeader()
+	if err != nil && err != io.EOF {
+		return 0, nil, err
+	}
+	return status, header, nil
+}
+
+// infectionDetails returns what the ICAP service reported about an infection, if anything
+func infectionDetails(header textproto.MIMEHeader) string {
+	// X-Infection-Found looks like "Type=0; Resolution=2; Threat=EICAR-Test-File;"
+	if value := header.Get("X-Infection-Found"); value != "" {
+		for _, field := range strings.Split(value, ";") {
+			field = strings.TrimSpace(field)
+			if strings.HasPrefix(field, "Threat=") {
+				return strings.TrimPrefix(field, "Threat=")
+			}
+		}
+	}
+	for _, name := range infectionHeaders {
+		if value := strings.TrimSpace(header.Get(name)); value != "" {
+			return value
+		}
+	}
+	return ""
+}
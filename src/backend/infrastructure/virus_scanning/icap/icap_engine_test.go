@@ -0,0 +1,99 @@
+package icap
+
+import (
+	"bufio"
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http/httputil"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"  // v1.8.0+
+	"github.com/stretchr/testify/require" // v1.8.0+
+
+	"../../../domain/services"
+	"../../../pkg/config"
+)
+
+const testContent = "test document content"
+
+// startICAPServer starts an ICAP server answering every request with response. It returns the
+// service URL and a channel receiving the body of each request.
+func startICAPServer(t *testing.T, response string) (string, <-chan string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	bodies := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		reader := textproto.NewReader(bufio.NewReader(conn))
+		// Skip the ICAP request and the encapsulated HTTP request and response headers
+		for i := 0; i < 3; i++ {
+			reader.ReadLine()
+			reader.ReadMIMEHeader()
+		}
+		body, _ := ioutil.ReadAll(httputil.NewChunkedReader(reader.R))
+		bodies <- string(body)
+
+		conn.Write([]byte(response))
+	}()
+
+	return "icap://" + listener.Addr().String() + "/avscan", bodies
+}
+
+// TestScanStream_Clean tests that a 204 answer means the content is clean and that the content is sent in full
+func TestScanStream_Clean(t *testing.T) {
+	serviceURL, bodies := startICAPServer(t, "ICAP/1.0 204 No Content\r\nISTag: \"1\"\r\n\r\n")
+	engine, err := NewICAPEngine(config.ICAPScanConfig{URL: serviceURL})
+	require.NoError(t, err)
+
+	result, details, err := engine.ScanStream(context.Background(), strings.NewReader(testContent))
+
+	assert.NoError(t, err)
+	assert.Equal(t, services.ScanResultClean, result)
+	assert.Empty(t, details)
+	assert.Equal(t, testContent, <-bodies)
+}
+
+// TestScanStream_Infected tests that the threat reported by the service is returned
+func TestScanStream_Infected(t *testing.T) {
+	serviceURL, _ := startICAPServer(t, "ICAP/1.0 200 OK\r\n"+
+		"X-Infection-Found: Type=0; Resolution=2; Threat=EICAR-Test-File;\r\n"+
+		"Encapsulated: null-body=0\r\n\r\n")
+	engine, err := NewICAPEngine(config.ICAPScanConfig{URL: serviceURL})
+	require.NoError(t, err)
+
+	result, details, err := engine.ScanStream(context.Background(), strings.NewReader(testContent))
+
+	assert.NoError(t, err)
+	assert.Equal(t, services.ScanResultInfected, result)
+	assert.Equal(t, "EICAR-Test-File", details)
+}
+
+// TestScanStream_ServiceError tests that a failing service is an error rather than a verdict
+func TestScanStream_ServiceError(t *testing.T) {
+	serviceURL, _ := startICAPServer(t, "ICAP/1.0 500 Server Error\r\n\r\n")
+	engine, err := NewICAPEngine(config.ICAPScanConfig{URL: serviceURL})
+	require.NoError(t, err)
+
+	result, _, err := engine.ScanStream(context.Background(), strings.NewReader(testContent))
+
+	assert.Error(t, err)
+	assert.Equal(t, services.ScanResultError, result)
+}
+
+// TestNewICAPEngine_InvalidURL tests that the service URL must be an icap:// URL
+func TestNewICAPEngine_InvalidURL(t *testing.T) {
+	for _, serviceURL := range []string{"", "http://icap.internal/avscan", "icap:///avscan"} {
+		_, err := NewICAPEngine(config.ICAPScanConfig{URL: serviceURL})
+		assert.Error(t, err, serviceURL)
+	}
+}
@@ -9,10 +9,11 @@ import (
 	"fmt"  // standard library - For formatted output
 	"os"   // standard library - For accessing command-line arguments and environment
 
-	"src/backend/cmd/api"    // For starting the API server
-	"src/backend/cmd/worker" // For starting the worker process
-	"src/backend/pkg/config" // For loading application configuration
-	"src/backend/pkg/logger" // For application logging
+	"src/backend/cmd/api"     // For starting the API server
+	"src/backend/cmd/migrate" // For applying and reverting database migrations
+	"src/backend/cmd/worker"  // For starting the worker process
+	"src/backend/pkg/config"  // For loading application configuration
+	"src/backend/pkg/logger"  // For application logging
 )
 
 // version is the application version
@@ -22,7 +23,7 @@ const version = "1.0.0"
 func main() {
 	// Define command-line flags for service type (api or worker)
 	var serviceType string
-	flag.StringVar(&serviceType, "service", "api", "Service type (api or worker)")
+	flag.StringVar(&serviceType, "service", "api", "Service type (api, worker, dlq-replay, tenant-transfer, tenant-offboard or migrate)")
 
 	// Parse command-line flags
 	flag.Parse()
@@ -54,13 +55,25 @@ func main() {
 		// If service type is 'worker', call worker.main()
 		logger.Info("Starting worker service")
 		worker.Main()
+	case "dlq-replay":
+		// If service type is 'dlq-replay', inspect or re-drive the scan dead letter queue
+		os.Exit(worker.DLQReplay(cfg, flag.Args()))
+	case "tenant-transfer":
+		// If service type is 'tenant-transfer', export or import a whole tenant
+		os.Exit(worker.TenantTransfer(cfg, flag.Args()))
+	case "tenant-offboard":
+		// If service type is 'tenant-offboard', schedule, cancel or report on tenant deletions
+		os.Exit(worker.TenantOffboard(cfg, flag.Args()))
+	case "migrate":
+		// If service type is 'migrate', apply, revert or verify the database migrations
+		os.Exit(migrate.Run(cfg, flag.Args()))
 	case "version":
 		// If service type is 'version', print version information
 		printVersion()
 	default:
 		// If service type is invalid, log error and exit with non-zero status
 		logger.Error("Invalid service type", "serviceType", serviceType)
-		fmt.Println("Invalid service type. Use 'api' or 'worker'.")
+		fmt.Println("Invalid service type. Use 'api', 'worker', 'dlq-replay', 'tenant-transfer', 'tenant-offboard' or 'migrate'.")
 		os.Exit(1)
 	}
 }
@@ -46,23 +46,89 @@ type Config struct {
 	// S3 configuration for AWS S3 document storage
 	S3 S3Config
 
+	// Storage configuration selecting the object storage documents are kept in
+	Storage StorageConfig
+
 	// Elasticsearch configuration for document search
 	Elasticsearch ElasticsearchConfig
 
+	// Search configuration selecting the search index documents are indexed in
+	Search SearchConfig
+
 	// JWT configuration for authentication
 	JWT JWTConfig
 
 	// Log configuration for application logging
 	Log LogConfig
 
+	// Tracing configuration for exporting distributed traces
+	Tracing TracingConfig
+
+	// Metrics configuration for the Prometheus metrics endpoint
+	Metrics MetricsConfig
+
 	// ClamAV configuration for virus scanning
 	ClamAV ClamAVConfig
 
+	// Scanning configuration of the scanning engines besides ClamAV and of engine selection
+	Scanning ScanningConfig
+
 	// SQS configuration for AWS SQS message queues
 	SQS SQSConfig
 
 	// SNS configuration for AWS SNS event publishing
 	SNS SNSConfig
+
+	// Messaging configuration selecting the message bus events and scan tasks go through
+	Messaging MessagingConfig
+
+	// Audit configuration for forwarding audit logs to a SIEM
+	Audit AuditConfig
+
+	// SMTP configuration for outgoing email such as guest invitations
+	SMTP SMTPConfig
+
+	// OIDC configuration for single sign-on through external identity providers
+	OIDC OIDCConfig
+
+	// Redis configuration for shared state such as the token revocation list
+	Redis RedisConfig
+
+	// Quota configuration for default per-tenant storage limits and per-user upload limits
+	Quota QuotaConfig
+
+	// RateLimiter configuration for throttling API requests per tenant, user and route
+	RateLimiter RateLimiterConfig
+
+	// Idempotency configuration for Idempotency-Key handling of mutating requests
+	Idempotency IdempotencyConfig
+
+	// GRPC configuration for the gRPC API served next to the REST API
+	GRPC GRPCConfig
+
+	// SFTP configuration for the SFTP ingestion gateway
+	SFTP SFTPConfig
+
+	// EmailIn configuration for creating documents from emailed attachments
+	EmailIn EmailInConfig
+
+	// Signature configuration of the electronic signature providers documents can be sent to
+	Signature SignatureConfig
+
+	// Offboarding configuration for deleting tenants that leave the platform
+	Offboarding OffboardingConfig
+
+	// Platform configuration for the platform API through which operators provision tenants
+	Platform PlatformConfig
+
+	// Classification configuration for detecting personal data in document content
+	Classification ClassificationConfig
+
+	// NetworkPolicy configuration for enforcing the networks and countries tenants allow requests from
+	NetworkPolicy NetworkPolicyConfig
+
+	// BulkImport configuration for importing legacy archives into tenants
+	BulkImport BulkImportConfig
 }
 
 // ServerConfig holds HTTP server configuration
@@ -90,6 +156,14 @@ type ServerConfig struct {
 
 	// KeyFile path for TLS private key
 	KeyFile string
+
+	// PublicURL is the externally reachable base URL of the API, used to build share links
+	PublicURL string
+
+	// TrustedProxies lists the addresses or CIDR ranges of the load balancers and proxies in front of
+	// the API. Client addresses are only taken from the X-Forwarded-For header set by these; when
+	// empty, the address of the connection is the client address.
+	TrustedProxies []string
 }
 
 // DatabaseConfig holds PostgreSQL database configuration
@@ -120,6 +194,41 @@ type DatabaseConfig struct {
 
 	// ConnMaxLifetime is the maximum lifetime of a connection
 	ConnMaxLifetime string
+
+	// ConnMaxIdleTime is the time after which an idle connection is closed
+	ConnMaxIdleTime string
+
+	// QueryTimeout bounds each read query, and its wait for a connection, unless the caller's
+	// context has an earlier deadline, such as "30s"
+	QueryTimeout string
+
+	// StatementTimeout is the statement_timeout of the server on each connection, bounding every
+	// statement including writes; no timeout is set when empty
+	StatementTimeout string
+
+	// SlowQueryThreshold is the duration past which a query is logged as slow, such as "200ms"
+	SlowQueryThreshold string
+
+	// Replicas are the read replicas serving the read-only queries of repositories, with the
+	// credentials, database and pool settings of the primary; reads use the primary when empty
+	Replicas []DatabaseReplicaConfig
+
+	// ReplicaMaxLag is the replication lag past which a replica stops serving reads until it
+	// catches up, such as "5s"
+	ReplicaMaxLag string
+
+	// MigrateOnStart applies the pending schema migrations when the API starts; when unset the API
+	// refuses to start until they are applied with the migrate service
+	MigrateOnStart bool
+}
+
+// DatabaseReplicaConfig holds the address of a PostgreSQL read replica
+type DatabaseReplicaConfig struct {
+	// Host of the replica
+	Host string
+
+	// Port of the replica
+	Port int
 }
 
 // S3Config holds AWS S3 configuration for document storage
@@ -127,10 +236,10 @@ type S3Config struct {
 	// Region is the AWS region
 	Region string
 
-	// Endpoint is the S3 endpoint URL (for custom endpoints)
+	// Endpoint is the S3 endpoint URL (for custom endpoints such as MinIO or Ceph RGW)
 	Endpoint string
 
-	// AccessKey for S3 authentication
+	// AccessKey for S3 authentication; the default AWS credential chain is used when empty
 	AccessKey string
 
 	// SecretKey for S3 authentication
@@ -145,11 +254,120 @@ type S3Config struct {
 	// QuarantineBucket is the bucket for quarantined documents
 	QuarantineBucket string
 
+	// KMSKeyID is the AWS KMS key documents are encrypted with when their tenant brings no key of
+	// its own. Documents are encrypted with S3 managed keys when empty.
+	KMSKeyID string
+
+	// KMSEndpoint is the KMS endpoint URL (for custom endpoints). It is separate from Endpoint
+	// because S3 compatible services do not serve the KMS API.
+	KMSEndpoint string
+
+	// DisableServerSideEncryption stores documents without S3 managed encryption, for S3 compatible
+	// services that have no key management configured. Tenant KMS keys are still applied.
+	DisableServerSideEncryption bool
+
 	// UseSSL enables SSL for S3 connections
 	UseSSL bool
 
-	// ForcePathStyle enables path-style S3 URLs
+	// SkipTLSVerify skips verification of the endpoint's TLS certificate, for on-premises services
+	// with self-signed certificates. It must not be enabled against AWS.
+	SkipTLSVerify bool
+
+	// ForcePathStyle enables path-style S3 URLs (bucket in the path instead of the host name),
+	// which most S3 compatible services require
 	ForcePathStyle bool
+
+	// Replica is the bucket in another region the document bucket is replicated to, serving reads
+	// and download URLs while the primary region fails; reads never fail over when it has no bucket
+	Replica S3ReplicaConfig
+}
+
+// S3ReplicaConfig holds the replica of the document bucket in another region, kept in sync by S3
+// replication, with the credentials of the primary
+type S3ReplicaConfig struct {
+	// Region is the AWS region of the replica bucket
+	Region string
+
+	// Endpoint is the S3 endpoint URL of the replica region (for custom endpoints)
+	Endpoint string
+
+	// Bucket is the replica of the document bucket
+	Bucket string
+
+	// FailoverCooldown is how long reads go to the replica after a regional error of the primary
+	// before the primary is tried again, such as "1m"
+	FailoverCooldown string
+
+	// ConsistencyCheckInterval is the interval at which the worker compares the replica with the
+	// document bucket, such as "6h"; the check does not run when empty
+	ConsistencyCheckInterval string
+
+	// ReplicationSettleTime is the age objects must have before the consistency check expects them
+	// in the replica, leaving time for S3 replication to copy them, such as "15m"
+	ReplicationSettleTime string
+}
+
+// StorageConfig holds configuration for selecting the object storage provider of documents
+type StorageConfig struct {
+	// Provider selects where documents are stored (s3, azure, gcs, local); defaults to s3,
+	// which is configured by S3Config
+	Provider string
+
+	// Azure configuration for the Azure Blob Storage provider
+	Azure AzureStorageConfig
+
+	// GCS configuration for the Google Cloud Storage provider
+	GCS GCSStorageConfig
+
+	// Local configuration for the local filesystem provider
+	Local LocalStorageConfig
+}
+
+// AzureStorageConfig holds Azure Blob Storage configuration for document storage
+type AzureStorageConfig struct {
+	// AccountName is the storage account name
+	AccountName string
+
+	// AccountKey is the shared key of the storage account, also used to sign download URLs
+	AccountKey string
+
+	// Endpoint is the blob service URL (for custom endpoints such as Azurite); defaults to
+	// https://<account>.blob.core.windows.net
+	Endpoint string
+
+	// Container is the main container for document storage
+	Container string
+
+	// TempContainer is the container for temporary document storage
+	TempContainer string
+
+	// QuarantineContainer is the container for quarantined documents
+	QuarantineContainer string
+}
+
+// GCSStorageConfig holds Google Cloud Storage configuration for document storage
+type GCSStorageConfig struct {
+	// CredentialsFile is the path of a service account key file; application default
+	// credentials are used when empty
+	CredentialsFile string
+
+	// Endpoint is the storage API URL (for custom endpoints such as emulators)
+	Endpoint string
+
+	// Bucket is the main bucket for document storage
+	Bucket string
+
+	// TempBucket is the bucket for temporary document storage
+	TempBucket string
+
+	// QuarantineBucket is the bucket for quarantined documents
+	QuarantineBucket string
+}
+
+// LocalStorageConfig holds local filesystem configuration for document storage in development
+type LocalStorageConfig struct {
+	// Root is the directory documents are stored under, one subdirectory per container
+	Root string
 }
 
 // ElasticsearchConfig holds Elasticsearch configuration for document search
@@ -168,6 +386,70 @@ type ElasticsearchConfig struct {
 
 	// IndexPrefix is the prefix for Elasticsearch indices
 	IndexPrefix string
+
+	// IndexStrategy selects how tenants are split into indices: per_tenant, the default, gives each
+	// tenant an index of its own named IndexPrefix-<tenant ID>; shared stores all tenants in the
+	// index named IndexPrefix, routing the documents of each tenant to a single shard
+	IndexStrategy string
+
+	// ILMPolicy is the name of an existing index lifecycle policy attached to the indices as they
+	// are created; empty attaches none. Not supported by OpenSearch, whose ISM policies are
+	// attached to new indices by the ism_template of the policy.
+	ILMPolicy string
+
+	// DedicatedTenants lists the IDs of tenants given an index of their own with the shared
+	// strategy, isolating large tenants from the others. Searches of a tenant added to the list
+	// use its own index, which is empty until the index of the tenant is rebuilt.
+	DedicatedTenants []string
+
+	// Refresh selects when documents indexed by the API become searchable, unless the request
+	// selects otherwise: none returns once the document is written, leaving it to the periodic
+	// refresh; wait_for, the default, waits for the periodic refresh; immediate refreshes the index
+	Refresh string
+
+	// Bulk configures the bulk requests documents are indexed with when indices are rebuilt
+	Bulk ElasticsearchBulkConfig
+}
+
+// ElasticsearchBulkConfig holds the configuration of bulk indexing. Documents are buffered and
+// sent together once enough are waiting or the oldest has waited for the flush interval.
+type ElasticsearchBulkConfig struct {
+	// FlushActions is the number of buffered documents that are sent in one bulk request
+	FlushActions int
+
+	// FlushBytes is the size in bytes of the buffered documents that are sent in one bulk request
+	FlushBytes int
+
+	// FlushInterval is how long buffered documents wait for the buffer to fill, such as 5s
+	FlushInterval string
+
+	// MaxRetries is how many times documents rejected with 429 Too Many Requests are sent again,
+	// waiting longer before every attempt
+	MaxRetries int
+}
+
+// SearchConfig holds configuration selecting the search index of the platform
+type SearchConfig struct {
+	// Provider selects the index documents are searched in (elasticsearch, elasticsearch7,
+	// opensearch, postgres, memory); defaults to elasticsearch, Elasticsearch 8. The servers are
+	// configured by ElasticsearchConfig. postgres uses the full-text search of the database, for
+	// small deployments. The memory index is lost on restart and only meant for development.
+	Provider string
+
+	// OpenSearch configuration of AWS OpenSearch request signing
+	OpenSearch OpenSearchConfig
+}
+
+// OpenSearchConfig holds the AWS settings of the opensearch search provider
+type OpenSearchConfig struct {
+	// Region is the AWS region requests are signed for with SigV4, using the default AWS
+	// credential chain. Requests are not signed when empty, for self-managed OpenSearch clusters
+	// using the username and password of ElasticsearchConfig.
+	Region string
+
+	// Service is the service name requests are signed for; defaults to es, the service name of
+	// Amazon OpenSearch Service domains
+	Service string
 }
 
 // JWTConfig holds JWT authentication configuration
@@ -210,6 +492,73 @@ type LogConfig struct {
 
 	// FilePath is the path for log files
 	FilePath string
+
+	// Access configures the access log of API requests
+	Access AccessLogConfig
+}
+
+// AccessLogConfig holds configuration of the access log of API requests
+type AccessLogConfig struct {
+	// Enabled turns on logging a line for each API request
+	Enabled bool
+
+	// SampleRate is the ratio of successful requests logged (0.0 to 1.0); failed requests are always logged
+	SampleRate float64
+
+	// LogHeaders adds the request headers to each line, with the values of redacted headers replaced
+	LogHeaders bool
+
+	// RedactHeaders are headers whose values are replaced in the log, besides the credentials
+	// headers that are always redacted
+	RedactHeaders []string
+
+	// RedactQueryParams are query parameters whose values are replaced in the log, besides the
+	// tokens and signatures that are always redacted
+	RedactQueryParams []string
+
+	// SkipPaths are paths that are not logged, such as the health check endpoints
+	SkipPaths []string
+}
+
+// TracingConfig holds distributed tracing configuration
+type TracingConfig struct {
+	// Enabled turns on recording and exporting spans
+	Enabled bool
+
+	// Provider is the exporter spans are sent with (otlp, jaeger)
+	Provider string
+
+	// Endpoint of the collector, such as otel-collector:4317 for OTLP over gRPC
+	Endpoint string
+
+	// ServiceName is the prefix of the service names of the API and the worker
+	ServiceName string
+
+	// SampleRate is the ratio of traces recorded (0.0 to 1.0)
+	SampleRate float64
+
+	// Insecure sends spans to an OTLP collector without TLS
+	Insecure bool
+}
+
+// MetricsConfig holds Prometheus metrics configuration
+type MetricsConfig struct {
+	// Enabled turns on collecting metrics
+	Enabled bool
+
+	// Endpoint is the path metrics are scraped from
+	Endpoint string
+
+	// Port the metrics endpoint is served on, separately from the API
+	Port int
+
+	// Namespace is the prefix of the names of all metrics
+	Namespace string
+
+	// TenantLabelLimit is the number of tenants labelled by their ID; the requests and documents
+	// of further tenants are counted under the "other" tenant to bound the number of series.
+	// A negative limit counts all tenants under "other".
+	TenantLabelLimit int
 }
 
 // ClamAVConfig holds ClamAV virus scanning configuration
@@ -222,6 +571,97 @@ type ClamAVConfig struct {
 
 	// Timeout for scan operations in seconds
 	Timeout int
+
+	// RescanWindow is how far back uploads are scanned again when the signature database is
+	// updated, such as 24h. Zero disables rescanning on signature updates.
+	RescanWindow string
+}
+
+// ScanningConfig holds the configuration of virus scanning engines and which of them scan documents
+type ScanningConfig struct {
+	// DefaultEngines are the engines documents are scanned with when their tenant selects none.
+	// The no-op engine "none" passes every document, and is only meant for development.
+	DefaultEngines []string
+
+	// ICAP configuration of a commercial scanner behind an ICAP server
+	ICAP ICAPScanConfig
+
+	// External configuration of an external service returning a verdict for the content
+	External ExternalScanConfig
+
+	// Workers configuration of the worker pool processing the scan queue
+	Workers ScanWorkersConfig
+
+	// VerdictCacheTTL is how long a clean verdict is reused for uploads of identical content by the
+	// same tenant, such as 24h, instead of scanning them again. Zero scans every upload.
+	VerdictCacheTTL string
+
+	// Inline configuration of scanning small uploads while they are uploaded
+	Inline InlineScanConfig
+}
+
+// InlineScanConfig holds the configuration of inline scanning: uploads up to MaxBytes are scanned
+// while they are uploaded and available as soon as they are clean, instead of being queued
+type InlineScanConfig struct {
+	// MaxBytes is the size of the largest upload scanned inline. Zero queues every upload.
+	MaxBytes int64
+
+	// Timeout is how long an inline scan may take, such as 5s, before the upload is queued instead
+	Timeout string
+}
+
+// ScanWorkersConfig holds the configuration of the worker pool processing the scan queue
+type ScanWorkersConfig struct {
+	// Concurrency is the number of scan tasks processed in parallel
+	Concurrency int
+
+	// WaitTime is how long a worker long polls the queue for a task, at most 20s
+	WaitTime string
+
+	// VisibilityTimeout is how long a dequeued task is hidden from other workers, such as 5m.
+	// It is extended while the task is processed, so scans of large files may take longer.
+	VisibilityTimeout string
+
+	// DrainTimeout is how long tasks being processed at shutdown may take to finish, such as 25s
+	DrainTimeout string
+
+	// PriorityWeights are the shares of tasks taken from the high, normal and low priority queues
+	// while all of them have tasks waiting
+	PriorityWeights ScanPriorityWeights
+}
+
+// ScanPriorityWeights holds the relative weights the scan queues of each priority are consumed with.
+// Queues are never starved: a queue with weight 0 is still consumed when the others are empty.
+type ScanPriorityWeights struct {
+	// High weight of the queue of interactive uploads
+	High int
+
+	// Normal weight of the queue of uploads without a priority hint
+	Normal int
+
+	// Low weight of the queue of bulk imports and rescans
+	Low int
+}
+
+// ICAPScanConfig holds the configuration of the ICAP scanning engine. The engine is available when URL is set.
+type ICAPScanConfig struct {
+	// URL of the ICAP service, such as icap://icap.internal:1344/avscan
+	URL string
+
+	// Timeout for scan operations in seconds
+	Timeout int
+}
+
+// ExternalScanConfig holds the configuration of the external verdict engine. The engine is available when URL is set.
+type ExternalScanConfig struct {
+	// URL the content is posted to for a verdict
+	URL string
+
+	// APIKey is sent as a bearer token to authenticate with the service
+	APIKey string
+
+	// Timeout for scan operations in seconds
+	Timeout int
 }
 
 // SQSConfig holds AWS SQS configuration for message queues
@@ -275,6 +715,484 @@ type SNSConfig struct {
 	UseSSL bool
 }
 
+// MessagingConfig holds configuration selecting the message bus of the platform
+type MessagingConfig struct {
+	// Provider selects the message bus events are published to and scan tasks are queued on
+	// (aws, kafka, rabbitmq, nats, memory); defaults to aws, which is configured by SQSConfig and
+	// SNSConfig. The memory message bus only reaches the same process and is meant for development.
+	Provider string
+
+	// Kafka configuration for the Kafka provider
+	Kafka KafkaConfig
+
+	// RabbitMQ configuration for the RabbitMQ provider
+	RabbitMQ RabbitMQConfig
+
+	// NATS configuration for the NATS JetStream provider
+	NATS NATSConfig
+}
+
+// KafkaConfig holds Kafka configuration for event publishing and the scan queue
+type KafkaConfig struct {
+	// Brokers are the addresses (host:port) of the brokers to bootstrap from
+	Brokers []string
+
+	// ClientID identifies the platform in broker logs and quotas
+	ClientID string
+
+	// TopicPrefix is prepended to the topic names; defaults to the environment
+	TopicPrefix string
+
+	// ConsumerGroup is the consumer group the scan workers share; defaults to
+	// <topic prefix>-scan-workers
+	ConsumerGroup string
+
+	// TLS enables TLS for broker connections
+	TLS bool
+
+	// SkipTLSVerify disables verification of the broker certificates
+	SkipTLSVerify bool
+
+	// SASLMechanism enables SASL authentication (plain, scram-sha-256, scram-sha-512)
+	SASLMechanism string
+
+	// Username for SASL authentication
+	Username string
+
+	// Password for SASL authentication
+	Password string
+}
+
+// RabbitMQConfig holds RabbitMQ configuration for event publishing and the scan queue
+type RabbitMQConfig struct {
+	// URL of the broker (amqp:// or amqps://) including the credentials and virtual host
+	URL string
+
+	// SkipTLSVerify disables verification of the broker certificate for amqps URLs
+	SkipTLSVerify bool
+
+	// NamePrefix is prepended to the exchange and queue names; defaults to the environment
+	NamePrefix string
+
+	// Prefetch is the number of unacknowledged scan tasks a worker process holds per priority
+	// queue; defaults to the scan worker concurrency
+	Prefetch int
+}
+
+// NATSConfig holds NATS JetStream configuration for event publishing and the scan queue
+type NATSConfig struct {
+	// URL of the server (nats:// or tls://), or a comma separated list of the servers of a cluster
+	URL string
+
+	// CredentialsFile is the path of a .creds file with the user JWT and NKey seed, for servers
+	// using decentralized authentication
+	CredentialsFile string
+
+	// NamePrefix is prepended to the stream, consumer and subject names; defaults to the environment
+	NamePrefix string
+
+	// Replicas is the number of replicas of the streams; defaults to 1 for single node servers
+	Replicas int
+}
+
+// AuditConfig holds configuration for forwarding audit logs to a SIEM
+type AuditConfig struct {
+	// Exporter selects where audit logs are forwarded (none, syslog, s3)
+	Exporter string
+
+	// BatchSize is the maximum number of audit log entries forwarded per batch
+	BatchSize int
+
+	// Interval is the time to wait between forwarding runs when no entries are pending
+	Interval string
+
+	// SettleDelay is how old an entry must be before it is forwarded, so that entries
+	// written by transactions that commit late are not skipped
+	SettleDelay string
+
+	// Syslog configuration for the syslog/CEF exporter
+	Syslog AuditSyslogConfig
+
+	// S3 configuration for the S3 JSONL exporter
+	S3 AuditS3Config
+}
+
+// AuditSyslogConfig holds configuration for forwarding audit logs to a syslog endpoint in CEF format
+type AuditSyslogConfig struct {
+	// Network is the transport used to reach the syslog endpoint (udp, tcp, tls)
+	Network string
+
+	// Address is the host:port of the syslog endpoint
+	Address string
+
+	// Facility is the syslog facility code (0-23); defaults to 13 (log audit)
+	Facility int
+
+	// AppName is the syslog APP-NAME reported with each message
+	AppName string
+
+	// Timeout for connecting to and writing to the syslog endpoint
+	Timeout string
+}
+
+// AuditS3Config holds configuration for batching audit logs to an S3 bucket as JSON Lines
+type AuditS3Config struct {
+	// Region is the AWS region
+	Region string
+
+	// Endpoint is the S3 endpoint URL (for custom endpoints)
+	Endpoint string
+
+	// AccessKey for S3 authentication
+	AccessKey string
+
+	// SecretKey for S3 authentication
+	SecretKey string
+
+	// Bucket receives the audit log batches
+	Bucket string
+
+	// Prefix is prepended to the object keys of audit log batches
+	Prefix string
+
+	// UseSSL enables SSL for S3 connections
+	UseSSL bool
+
+	// ForcePathStyle enables path-style S3 URLs
+	ForcePathStyle bool
+}
+
+// SMTPConfig holds configuration for sending email through an SMTP relay
+type SMTPConfig struct {
+	// Host of the SMTP relay; email is disabled when empty
+	Host string
+
+	// Port of the SMTP relay
+	Port int
+
+	// Username for SMTP authentication; authentication is skipped when empty
+	Username string
+
+	// Password for SMTP authentication
+	Password string
+
+	// From is the sender address of outgoing email
+	From string
+}
+
+// OIDCConfig holds the external identity providers tenants sign in with
+type OIDCConfig struct {
+	// Providers lists the identity provider of each tenant using single sign-on
+	Providers []OIDCProviderConfig
+}
+
+// OIDCProviderConfig holds the OpenID Connect identity provider (Okta, Azure AD, ...) of a tenant
+type OIDCProviderConfig struct {
+	// TenantID of the tenant whose users sign in through this provider
+	TenantID string
+
+	// Issuer URL of the provider, used for discovery and checked against the iss claim
+	Issuer string
+
+	// ClientID registered with the provider, checked against the aud claim
+	ClientID string
+
+	// UsernameClaim names the claim holding the username; defaults to preferred_username
+	UsernameClaim string
+
+	// EmailClaim names the claim holding the email address; defaults to email
+	EmailClaim string
+
+	// GroupsClaim names the claim holding the user's IdP groups; defaults to groups
+	GroupsClaim string
+
+	// RoleMappings maps IdP group names to tenant roles
+	RoleMappings map[string]string
+
+	// DefaultRole is given to provisioned users none of whose groups are mapped
+	DefaultRole string
+
+	// JITProvisioning creates users on their first sign-in when enabled
+	JITProvisioning bool
+}
+
+// RedisConfig holds Redis connection configuration
+type RedisConfig struct {
+	// Address of the Redis server (host:port)
+	Address string
+
+	// Password for Redis authentication; empty for no authentication
+	Password string
+
+	// DB is the Redis database number
+	DB int
+
+	// PoolSize is the maximum number of connections in the pool
+	PoolSize int
+
+	// InMemory keeps the token revocation list, rate limit buckets and idempotency keys in the
+	// API process instead of Redis. It is only meant for development with a single API instance.
+	InMemory bool
+}
+
+// QuotaConfig holds the default storage limits of tenants without a configured quota, and the
+// default upload limits of users without a limit of their own or of one of their roles
+type QuotaConfig struct {
+	// DefaultMaxStorageBytes is the storage a tenant may use, in bytes; 0 for unlimited
+	DefaultMaxStorageBytes int64
+
+	// DefaultMaxDocuments is the number of documents a tenant may store; 0 for unlimited
+	DefaultMaxDocuments int64
+
+	// DefaultMaxFileSizeBytes is the largest file a user may upload, in bytes; 0 for unlimited
+	DefaultMaxFileSizeBytes int64
+
+	// DefaultMaxDailyUploadBytes is the volume a user may upload per UTC day, in bytes; 0 for unlimited
+	DefaultMaxDailyUploadBytes int64
+}
+
+// RateLimiterConfig holds the token bucket limits of authenticated API requests. A request is
+// throttled when any bucket that applies to it is empty.
+type RateLimiterConfig struct {
+	// Enabled turns rate limiting on
+	Enabled bool
+
+	// Tenant limits the requests of all users and API keys of a tenant together
+	Tenant RateLimitRule
+
+	// User limits the requests of each user, or of each API key
+	User RateLimitRule
+
+	// Routes limit the requests of each user to specific routes, such as uploads
+	Routes []RouteRateLimitRule
+}
+
+// RateLimitRule holds the sustained rate and burst size of a token bucket; a zero rate disables it
+type RateLimitRule struct {
+	// RequestsPerMinute is the sustained rate the bucket refills at
+	RequestsPerMinute int
+
+	// BurstSize is the number of requests allowed at once; 0 for one minute's worth
+	BurstSize int
+}
+
+// RouteRateLimitRule holds the rate limit of a route
+type RouteRateLimitRule struct {
+	// Method is the HTTP method of the route
+	Method string
+
+	// Path is the route's path pattern, such as /api/v1/documents/:id/content
+	Path string
+
+	// RequestsPerMinute is the sustained rate the bucket refills at
+	RequestsPerMinute int
+
+	// BurstSize is the number of requests allowed at once; 0 for one minute's worth
+	BurstSize int
+}
+
+// IdempotencyConfig holds the configuration of idempotency keys
+type IdempotencyConfig struct {
+	// Enabled turns Idempotency-Key handling on
+	Enabled bool
+
+	// KeyTTL is how long keys and their responses are remembered, such as 24h
+	KeyTTL string
+}
+
+// GRPCConfig holds gRPC server configuration
+type GRPCConfig struct {
+	// Enabled starts the gRPC server next to the HTTP server
+	Enabled bool
+
+	// Port to listen on
+	Port int
+}
+
+// SFTPConfig holds configuration for the SFTP ingestion gateway
+type SFTPConfig struct {
+	// Host to listen on
+	Host string
+
+	// Port to listen on
+	Port int
+
+	// HostKeyFile is the PEM file holding the gateway's SSH host key
+	HostKeyFile string
+}
+
+// EmailInConfig holds configuration for ingesting the attachments of emails that Amazon SES stores
+// in an S3 bucket
+type EmailInConfig struct {
+	// Enabled starts polling the bucket in the worker
+	Enabled bool
+
+	// Domain receives the emails; each tenant's inbox is <tenant ID>@<domain>
+	Domain string
+
+	// BatchSize is the maximum number of emails handled per run
+	BatchSize int
+
+	// Interval is the time to wait between runs when no emails are pending, such as 30s
+	Interval string
+
+	// Region is the AWS region
+	Region string
+
+	// Endpoint is the S3 endpoint URL (for custom endpoints)
+	Endpoint string
+
+	// AccessKey for S3 authentication
+	AccessKey string
+
+	// SecretKey for S3 authentication
+	SecretKey string
+
+	// Bucket is where the SES receipt rule stores received emails
+	Bucket string
+
+	// Prefix is the object key prefix configured on the SES receipt rule
+	Prefix string
+
+	// UseSSL enables SSL for S3 connections
+	UseSSL bool
+
+	// ForcePathStyle enables path-style S3 URLs
+	ForcePathStyle bool
+}
+
+// SignatureConfig holds the configuration of the electronic signature providers. Providers post
+// envelope status updates to Server.PublicURL, which must be reachable from the provider.
+type SignatureConfig struct {
+	// DocuSign configuration of DocuSign eSignature
+	DocuSign DocuSignConfig
+
+	// AdobeSign configuration of Adobe Acrobat Sign
+	AdobeSign AdobeSignConfig
+
+	// Timeout for provider API calls in seconds
+	Timeout int
+}
+
+// DocuSignConfig holds the configuration of the DocuSign provider. The provider is available when AccountID is set.
+type DocuSignConfig struct {
+	// BaseURL of the eSignature REST API, such as https://demo.docusign.net/restapi
+	BaseURL string
+
+	// AccountID of the DocuSign account envelopes are sent from
+	AccountID string
+
+	// AccessToken is an OAuth access token of the account's integration user
+	AccessToken string
+
+	// HMACKey is the Connect HMAC key status updates are signed with
+	HMACKey string
+}
+
+// AdobeSignConfig holds the configuration of the Adobe Sign provider. The provider is available when BaseURL is set.
+type AdobeSignConfig struct {
+	// BaseURL of the REST API of the account's shard, such as https://api.na1.adobesign.com/api/rest/v6
+	BaseURL string
+
+	// AccessToken is an integration key or OAuth access token of the account
+	AccessToken string
+
+	// ClientID of the API application webhooks are registered with; webhook calls must carry it
+	ClientID string
+}
+
+// OffboardingConfig holds the configuration of tenant deletions. The worker erases tenants whose
+// grace period is over when ReportSigningKeyFile is set.
+type OffboardingConfig struct {
+	// GracePeriod is how long a tenant stays frozen before it is erased, such as "720h"; 30 days when empty
+	GracePeriod string
+
+	// ReportSigningKeyFile is the PEM file holding the PKCS #8 Ed25519 private key destruction
+	// reports are signed with
+	ReportSigningKeyFile string
+}
+
+// PlatformConfig holds the configuration of the platform API, which platform operators use to
+// provision tenants. The platform API is only served when OperatorTokenFile is set.
+type PlatformConfig struct {
+	// OperatorTokenFile is the file holding the bearer token platform operators authenticate with
+	OperatorTokenFile string
+
+	// TemplateTenantID is the tenant holding the folder templates new tenants can be provisioned
+	// with; new tenants cannot be given folders from a template when empty
+	TemplateTenantID string
+}
+
+// ClassificationConfig holds the configuration of the classification of document content for personal
+// data. Tenants select the detectors their documents are classified with; the regex detector is
+// always available.
+type ClassificationConfig struct {
+	// MaxContentBytes is how much of the content of each document version is classified; 1 MiB when zero
+	MaxContentBytes int64
+
+	// Comprehend configuration of the AWS Comprehend detector
+	Comprehend ComprehendConfig
+}
+
+// ComprehendConfig holds the configuration of the AWS Comprehend detector. The detector is available when Region is set.
+type ComprehendConfig struct {
+	// Region is the AWS region of the Comprehend service
+	Region string
+
+	// Endpoint is the Comprehend endpoint URL (for custom endpoints)
+	Endpoint string
+
+	// AccessKey is the AWS access key ID; the default credential chain is used when empty
+	AccessKey string
+
+	// SecretKey is the AWS secret access key
+	SecretKey string
+
+	// LanguageCode of the document text, "en" when empty
+	LanguageCode string
+
+	// MinScore is the confidence from which detected entities count, 0.8 when zero
+	MinScore float64
+}
+
+// NetworkPolicyConfig holds the configuration of tenant network policies. Tenants set the networks
+// and countries they allow requests from in their settings.
+type NetworkPolicyConfig struct {
+	// GeoIPDatabase is the path of the MaxMind country database used to look up the country of client
+	// addresses. Without it, requests of tenants blocking countries are refused unless they come
+	// from a private network.
+	GeoIPDatabase string
+}
+
+// BulkImportConfig holds the configuration of bulk imports, which the worker runs to import
+// directory trees and S3 prefixes of legacy archives into tenants
+type BulkImportConfig struct {
+	// Enabled runs the scheduled imports in the worker
+	Enabled bool
+
+	// Concurrency is the number of files of an import uploaded at the same time; 4 when unset
+	Concurrency int
+
+	// Region is the AWS region of the buckets archives are imported from. The region, endpoint and
+	// credentials of S3 are used when it is empty.
+	Region string
+
+	// Endpoint is the S3 endpoint URL (for custom endpoints)
+	Endpoint string
+
+	// AccessKey for S3 authentication; the default AWS credential chain is used when empty
+	AccessKey string
+
+	// SecretKey for S3 authentication
+	SecretKey string
+
+	// UseSSL enables SSL for S3 connections
+	UseSSL bool
+
+	// ForcePathStyle enables path-style S3 URLs
+	ForcePathStyle bool
+}
+
 // Load loads the configuration from all sources
 func Load(cfg interface{}) error {
 	// Ensure cfg is a pointer to a struct
@@ -0,0 +1,353 @@
+package delta
+
+import (
+	"bufio"           // standard library
+	"bytes"           // standard library
+	"crypto/sha256"   // standard library
+	"encoding/binary" // standard library
+	"fmt"             // standard library
+	"io"              // standard library
+)
+
+// Patch operations. A patch is a header followed by operations, and ends with opEnd.
+const (
+	opEnd  byte = 0 // End of the patch
+	opCopy byte = 1 // Copy blocks of the base version: uint32 index of the first block, uint32 number of blocks
+	opData byte = 2 // Write new bytes: uint32 length, then the bytes
+)
+
+// patchMagic starts every patch
+var patchMagic = [4]byte{'D', 'P', 'A', 'T'}
+
+// patchHeaderSize is the size of the magic, format version and block size starting every patch
+const patchHeaderSize = 9
+
+// Base is the version a patch was built against, read by the ranges of blocks the patch copies
+type Base interface {
+	OpenRange(offset int64, length int64) (io.ReadCloser, error)
+}
+
+// BaseFunc adapts a function to the Base interface
+type BaseFunc func(offset int64, length int64) (io.ReadCloser, error)
+
+// OpenRange calls f(offset, length)
+func (f BaseFunc) OpenRange(offset int64, length int64) (io.ReadCloser, error) {
+	return f(offset, length)
+}
+
+// Stats describes the content rebuilt from a patch
+type Stats struct {
+	Size        int64 // Size of the rebuilt content in bytes
+	CopiedBytes int64 // Bytes copied from the base version
+	DataBytes   int64 // Bytes sent in the patch
+}
+
+// Diff reads new content and writes the patch rebuilding it from the version the signature was
+// calculated for. Blocks of the version found anywhere in the content are copied; everything else
+// is sent as data. The content is read once, so it can be larger than memory.
+func Diff(signature *Signature, content io.Reader, w io.Writer) error {
+	if !ValidBlockSize(signature.BlockSize) {
+		return ErrInvalidBlockSize
+	}
+
+	d := &differ{
+		signature: signature,
+		index:     make(map[uint32][]int, len(signature.Blocks)),
+		reader:    bufio.NewReader(content),
+		patch:     newPatchWriter(w),
+		buf:       make([]byte, 2*signature.BlockSize),
+	}
+	for i, block := range signature.Blocks {
+		d.index[block.Weak] = append(d.index[block.Weak], i)
+	}
+	if err := d.patch.header(signature.BlockSize); err != nil {
+		return err
+	}
+
+	if err := d.fill(); err != nil {
+		return err
+	}
+	rolling := newRollingChecksum(d.window())
+	for d.start < d.end {
+		// Copy the block the window holds, then start over with the next window
+		if block, ok := d.match(rolling.sum()); ok {
+			if err := d.patch.data(d.buf[d.literalStart:d.start]); err != nil {
+				return err
+			}
+			if err := d.patch.copy(block); err != nil {
+				return err
+			}
+			d.start, d.literalStart = d.end, d.end
+			if err := d.fill(); err != nil {
+				return err
+			}
+			rolling = newRollingChecksum(d.window())
+			continue
+		}
+
+		// Move the window by a byte, which becomes data of the patch
+		out := d.buf[d.start]
+		d.start++
+		more, err := d.next()
+		if err != nil {
+			return err
+		}
+		if more {
+			rolling.roll(out, d.buf[d.end-1])
+		} else {
+			rolling.shrink(out)
+		}
+	}
+
+	if err := d.patch.data(d.buf[d.literalStart:d.start]); err != nil {
+		return err
+	}
+	return d.patch.close()
+}
+
+// differ holds the window Diff moves over the new content. The window is buf[start:end]; the bytes
+// before it from literalStart on did not match any block and are not written to the patch yet.
+type differ struct {
+	signature    *Signature
+	index        map[uint32][]int // Indexes of the blocks with each weak checksum
+	reader       *bufio.Reader
+	patch        *patchWriter
+	buf          []byte
+	start        int
+	end          int
+	literalStart int
+	eof          bool
+}
+
+// window returns the bytes of the window
+func (d *differ) window() []byte {
+	return d.buf[d.start:d.end]
+}
+
+// fill reads content until the window holds a block or the content ends
+func (d *differ) fill() error {
+	for d.end-d.start < d.signature.BlockSize {
+		more, err := d.next()
+		if err != nil || !more {
+			return err
+		}
+	}
+	return nil
+}
+
+// next appends the next byte of content to the window, returning false at the end of the content.
+// When the buffer is full, the pending data is written to the patch to make room.
+func (d *differ) next() (bool, error) {
+	if d.eof {
+		return false, nil
+	}
+	c, err := d.reader.ReadByte()
+	if err == io.EOF {
+		d.eof = true
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if d.end == len(d.buf) {
+		if err := d.patch.data(d.buf[d.literalStart:d.start]); err != nil {
+			return false, err
+		}
+		d.end = copy(d.buf, d.buf[d.start:d.end])
+		d.start, d.literalStart = 0, 0
+	}
+	d.buf[d.end] = c
+	d.end++
+	return true, nil
+}
+
+// match finds a block of the signature with the content of the window. The strong hash of the
+// window is only calculated when a block has the same weak checksum.
+func (d *differ) match(weak uint32) (int, bool) {
+	candidates := d.index[weak]
+	if len(candidates) == 0 {
+		return 0, false
+	}
+
+	window := d.window()
+	strong := sha256.Sum256(window)
+	for _, block := range candidates {
+		if d.signature.blockLength(block) == len(window) && bytes.Equal(d.signature.Blocks[block].Strong[:], strong[:StrongHashSize]) {
+			return block, true
+		}
+	}
+	return 0, false
+}
+
+// patchWriter encodes patch operations, merging copies of consecutive blocks into one operation
+type patchWriter struct {
+	w         *bufio.Writer
+	copyStart int
+	copyCount int
+}
+
+// newPatchWriter creates a patchWriter writing to w
+func newPatchWriter(w io.Writer) *patchWriter {
+	return &patchWriter{w: bufio.NewWriter(w)}
+}
+
+// header writes the header of the patch
+func (p *patchWriter) header(blockSize int) error {
+	header := make([]byte, 0, patchHeaderSize)
+	header = append(header, patchMagic[:]...)
+	header = append(header, formatVersion)
+	header = binary.BigEndian.AppendUint32(header, uint32(blockSize))
+	_, err := p.w.Write(header)
+	return err
+}
+
+// copy copies a block of the base version
+func (p *patchWriter) copy(block int) error {
+	if p.copyCount > 0 && p.copyStart+p.copyCount == block {
+		p.copyCount++
+		return nil
+	}
+	if err := p.flushCopy(); err != nil {
+		return err
+	}
+	p.copyStart, p.copyCount = block, 1
+	return nil
+}
+
+// data writes new bytes
+func (p *patchWriter) data(data []byte) error {
+	if len(data) == 0 {
+		return nil
+	}
+	if err := p.flushCopy(); err != nil {
+		return err
+	}
+	op := binary.BigEndian.AppendUint32([]byte{opData}, uint32(len(data)))
+	if _, err := p.w.Write(op); err != nil {
+		return err
+	}
+	_, err := p.w.Write(data)
+	return err
+}
+
+// flushCopy writes the pending copy operation
+func (p *patchWriter) flushCopy() error {
+	if p.copyCount == 0 {
+		return nil
+	}
+	op := binary.BigEndian.AppendUint32([]byte{opCopy}, uint32(p.copyStart))
+	op = binary.BigEndian.AppendUint32(op, uint32(p.copyCount))
+	p.copyCount = 0
+	_, err := p.w.Write(op)
+	return err
+}
+
+// close ends the patch
+func (p *patchWriter) close() error {
+	if err := p.flushCopy(); err != nil {
+		return err
+	}
+	if err := p.w.WriteByte(opEnd); err != nil {
+		return err
+	}
+	return p.w.Flush()
+}
+
+// Apply rebuilds content from a patch built against the base version of baseSize bytes, writing it
+// to w. Each copy operation reads one range of the base version, so only the copied blocks are read.
+// Patches that are truncated, malformed or copy blocks the base version does not have return an
+// error wrapping ErrInvalidPatch.
+func Apply(base Base, baseSize int64, patch io.Reader, w io.Writer) (Stats, error) {
+	var stats Stats
+	reader := bufio.NewReader(patch)
+
+	header := make([]byte, patchHeaderSize)
+	if err := readPatch(reader, header); err != nil {
+		return stats, err
+	}
+	if !bytes.Equal(header[:4], patchMagic[:]) || header[4] != formatVersion {
+		return stats, fmt.Errorf("%w: unknown format", ErrInvalidPatch)
+	}
+	blockSize := int64(binary.BigEndian.Uint32(header[5:]))
+	if !ValidBlockSize(int(blockSize)) {
+		return stats, fmt.Errorf("%w: %v", ErrInvalidPatch, ErrInvalidBlockSize)
+	}
+	baseBlocks := (baseSize + blockSize - 1) / blockSize
+
+	operand := make([]byte, 8)
+	for {
+		op, err := reader.ReadByte()
+		if err == io.EOF {
+			return stats, fmt.Errorf("%w: patch is truncated", ErrInvalidPatch)
+		}
+		if err != nil {
+			return stats, err
+		}
+
+		switch op {
+		case opEnd:
+			return stats, nil
+
+		case opCopy:
+			if err := readPatch(reader, operand); err != nil {
+				return stats, err
+			}
+			start := int64(binary.BigEndian.Uint32(operand[:4]))
+			count := int64(binary.BigEndian.Uint32(operand[4:]))
+			if count == 0 || start+count > baseBlocks {
+				return stats, fmt.Errorf("%w: blocks %d to %d are not in the base version of %d blocks", ErrInvalidPatch, start, start+count-1, baseBlocks)
+			}
+			offset := start * blockSize
+			length := count * blockSize
+			if offset+length > baseSize {
+				length = baseSize - offset
+			}
+
+			content, err := base.OpenRange(offset, length)
+			if err != nil {
+				return stats, err
+			}
+			n, err := io.Copy(w, content)
+			content.Close()
+			stats.Size += n
+			stats.CopiedBytes += n
+			if err != nil {
+				return stats, err
+			}
+			if n != length {
+				return stats, fmt.Errorf("base version ended after %d of %d bytes at offset %d", n, length, offset)
+			}
+
+		case opData:
+			if err := readPatch(reader, operand[:4]); err != nil {
+				return stats, err
+			}
+			length := int64(binary.BigEndian.Uint32(operand[:4]))
+			if length == 0 {
+				return stats, fmt.Errorf("%w: empty data", ErrInvalidPatch)
+			}
+			n, err := io.CopyN(w, reader, length)
+			stats.Size += n
+			stats.DataBytes += n
+			if err == io.EOF {
+				return stats, fmt.Errorf("%w: patch is truncated", ErrInvalidPatch)
+			}
+			if err != nil {
+				return stats, err
+			}
+
+		default:
+			return stats, fmt.Errorf("%w: unknown operation %d", ErrInvalidPatch, op)
+		}
+	}
+}
+
+// readPatch fills p from the patch, reporting a patch that ends early as truncated
+func readPatch(reader io.Reader, p []byte) error {
+	_, err := io.ReadFull(reader, p)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return fmt.Errorf("%w: patch is truncated", ErrInvalidPatch)
+	}
+	return err
+}
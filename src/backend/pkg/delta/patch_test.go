@@ -0,0 +1,139 @@
+// Package delta provides tests for building and applying patches
+package delta
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"  // v1.8.0+
+	"github.com/stretchr/testify/require" // v1.8.0+
+)
+
+// bytesBase serves ranges of a base version held in memory and counts the bytes read
+type bytesBase struct {
+	content []byte
+	read    int64
+}
+
+// OpenRange returns the range of the content
+func (b *bytesBase) OpenRange(offset int64, length int64) (io.ReadCloser, error) {
+	if offset < 0 || offset+length > int64(len(b.content)) {
+		return nil, errors.New("range outside the base version")
+	}
+	b.read += length
+	return io.NopCloser(bytes.NewReader(b.content[offset : offset+length])), nil
+}
+
+// roundTrip builds the patch from base to target, applies it and returns the patch and the stats
+func roundTrip(t *testing.T, base, target []byte, blockSize int) ([]byte, Stats) {
+	signature, err := ComputeSignature(bytes.NewReader(base), blockSize)
+	require.NoError(t, err)
+
+	var patch bytes.Buffer
+	require.NoError(t, Diff(signature, bytes.NewReader(target), &patch))
+
+	var rebuilt bytes.Buffer
+	stats, err := Apply(&bytesBase{content: base}, int64(len(base)), bytes.NewReader(patch.Bytes()), &rebuilt)
+	require.NoError(t, err)
+	require.True(t, bytes.Equal(target, rebuilt.Bytes()), "rebuilt content differs from the target")
+	assert.Equal(t, int64(len(target)), stats.Size)
+	return patch.Bytes(), stats
+}
+
+// randomContent returns reproducible random content
+func randomContent(seed int64, size int) []byte {
+	content := make([]byte, size)
+	rand.New(rand.NewSource(seed)).Read(content)
+	return content
+}
+
+// TestDiffAndApply tests that applying the patch of a changed version rebuilds it while sending
+// little more than the changed bytes
+func TestDiffAndApply(t *testing.T) {
+	blockSize := 1024
+	base := randomContent(1, 200*blockSize+123)
+
+	// Test a version changed in the middle, with bytes inserted so every later block moves
+	target := append([]byte(nil), base[:50*blockSize+10]...)
+	target = append(target, randomContent(2, 3000)...)
+	target = append(target, base[50*blockSize+10:]...)
+	copy(target[150*blockSize:], randomContent(3, 500))
+
+	patch, stats := roundTrip(t, base, target, blockSize)
+	assert.Less(t, len(patch), 8*blockSize)
+	assert.Less(t, stats.DataBytes, int64(8*blockSize))
+	assert.Greater(t, stats.CopiedBytes, int64(190*blockSize))
+
+	// Test an unchanged version, copied in a single operation
+	patch, stats = roundTrip(t, base, base, blockSize)
+	assert.Equal(t, patchHeaderSize+9+1, len(patch))
+	assert.Zero(t, stats.DataBytes)
+
+	// Test a version appended to
+	roundTrip(t, base, append(append([]byte(nil), base...), randomContent(4, 5000)...), blockSize)
+
+	// Test a version truncated in the middle of a block
+	roundTrip(t, base, base[:77*blockSize+5], blockSize)
+
+	// Test content sharing nothing with the base version
+	_, stats = roundTrip(t, base, randomContent(5, 10*blockSize), blockSize)
+	assert.Zero(t, stats.CopiedBytes)
+
+	// Test empty versions
+	roundTrip(t, base, nil, blockSize)
+	roundTrip(t, nil, base, blockSize)
+}
+
+// TestApply_OnlyReadsCopiedBlocks tests that only the blocks the patch copies are read from the base version
+func TestApply_OnlyReadsCopiedBlocks(t *testing.T) {
+	blockSize := 1024
+	base := randomContent(6, 100*blockSize)
+	target := append(append([]byte(nil), base[:10*blockSize]...), randomContent(7, 2000)...)
+
+	signature, err := ComputeSignature(bytes.NewReader(base), blockSize)
+	require.NoError(t, err)
+	var patch bytes.Buffer
+	require.NoError(t, Diff(signature, bytes.NewReader(target), &patch))
+
+	source := &bytesBase{content: base}
+	_, err = Apply(source, int64(len(base)), &patch, io.Discard)
+	require.NoError(t, err)
+	assert.Equal(t, int64(10*blockSize), source.read)
+}
+
+// TestApply_InvalidPatch tests that malformed patches are refused
+func TestApply_InvalidPatch(t *testing.T) {
+	blockSize := 1024
+	base := randomContent(8, 4*blockSize)
+	signature, err := ComputeSignature(bytes.NewReader(base), blockSize)
+	require.NoError(t, err)
+	var patch bytes.Buffer
+	require.NoError(t, Diff(signature, bytes.NewReader(base), &patch))
+
+	apply := func(patch []byte) error {
+		_, err := Apply(&bytesBase{content: base}, int64(len(base)), bytes.NewReader(patch), io.Discard)
+		return err
+	}
+
+	// Test a truncated patch
+	assert.ErrorIs(t, apply(patch.Bytes()[:patch.Len()-1]), ErrInvalidPatch)
+
+	// Test something else than a patch
+	assert.ErrorIs(t, apply([]byte("not a patch at all")), ErrInvalidPatch)
+
+	// Test copying blocks the base version does not have
+	outOfRange := append([]byte(nil), patch.Bytes()[:patchHeaderSize]...)
+	outOfRange = append(outOfRange, opCopy, 0, 0, 0, 3, 0, 0, 0, 2, opEnd)
+	assert.ErrorIs(t, apply(outOfRange), ErrInvalidPatch)
+
+	// Test an unknown operation
+	unknown := append(append([]byte(nil), patch.Bytes()[:patchHeaderSize]...), 9)
+	assert.ErrorIs(t, apply(unknown), ErrInvalidPatch)
+
+	// Test a patch against a base version with different blocks than it was built for
+	_, err = Apply(&bytesBase{content: base[:blockSize]}, int64(blockSize), bytes.NewReader(patch.Bytes()), io.Discard)
+	assert.ErrorIs(t, err, ErrInvalidPatch)
+}
@@ -0,0 +1,203 @@
+// Package delta implements rsync-style delta transfers of document versions. The server sends the
+// block signature of a version the client already has, the client sends back a patch copying the
+// unchanged blocks of that version and carrying only the changed bytes, and the server applies the
+// patch to rebuild the new version. It has no dependencies on the rest of the platform so clients
+// can import it to build patches.
+package delta
+
+import (
+	"bufio"           // standard library
+	"bytes"           // standard library
+	"crypto/sha256"   // standard library
+	"encoding/binary" // standard library
+	"errors"          // standard library
+	"fmt"             // standard library
+	"io"              // standard library
+)
+
+const (
+	// DefaultBlockSize is the block size signatures are calculated with when none is requested.
+	// A 2 GB version has a signature of about 640 KB.
+	DefaultBlockSize = 64 * 1024
+
+	// MinBlockSize is the smallest block size a signature can be calculated with
+	MinBlockSize = 512
+
+	// MaxBlockSize is the largest block size a signature can be calculated with
+	MaxBlockSize = 4 * 1024 * 1024
+
+	// StrongHashSize is the number of leading bytes of a block's SHA-256 hash kept in signatures
+	StrongHashSize = 16
+
+	// formatVersion identifies the encoding of signatures and patches
+	formatVersion = 1
+)
+
+// signatureMagic starts every encoded signature
+var signatureMagic = [4]byte{'D', 'S', 'I', 'G'}
+
+// signatureHeaderSize is the size of the magic, format version, block size, size and block count
+// starting every encoded signature
+const signatureHeaderSize = 21
+
+// Signature and patch errors
+var (
+	ErrInvalidBlockSize = fmt.Errorf("block size must be between %d and %d bytes", MinBlockSize, MaxBlockSize)
+	ErrInvalidSignature = errors.New("delta signature is malformed")
+	ErrInvalidPatch     = errors.New("delta patch is malformed")
+)
+
+// BlockChecksum holds the checksums of a block of a version: the rolling weak checksum the client
+// searches its content with, and the truncated SHA-256 hash confirming a match
+type BlockChecksum struct {
+	Weak   uint32
+	Strong [StrongHashSize]byte
+}
+
+// Signature describes the content of a version as the checksums of its consecutive blocks. Every
+// block is BlockSize bytes long except the last one, which holds the rest of the content.
+type Signature struct {
+	BlockSize int
+	Size      int64
+	Blocks    []BlockChecksum
+}
+
+// ValidBlockSize checks if signatures can be calculated with the block size
+func ValidBlockSize(blockSize int) bool {
+	return blockSize >= MinBlockSize && blockSize <= MaxBlockSize
+}
+
+// ComputeSignature reads content and calculates its signature with the block size
+func ComputeSignature(content io.Reader, blockSize int) (*Signature, error) {
+	if !ValidBlockSize(blockSize) {
+		return nil, ErrInvalidBlockSize
+	}
+
+	signature := &Signature{BlockSize: blockSize}
+	block := make([]byte, blockSize)
+	for {
+		n, err := io.ReadFull(content, block)
+		if n > 0 {
+			signature.Blocks = append(signature.Blocks, checksumBlock(block[:n]))
+			signature.Size += int64(n)
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return signature, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// blockLength returns the length of the block with the index
+func (s *Signature) blockLength(index int) int {
+	if rest := s.Size - int64(index)*int64(s.BlockSize); rest < int64(s.BlockSize) {
+		return int(rest)
+	}
+	return s.BlockSize
+}
+
+// EncodedSize returns the size of the binary encoding of the signature in bytes
+func (s *Signature) EncodedSize() int64 {
+	return signatureHeaderSize + int64(len(s.Blocks))*(4+StrongHashSize)
+}
+
+// WriteTo writes the binary encoding of the signature: a header with the block size, the size and
+// the number of blocks, followed by the weak checksum and strong hash of each block
+func (s *Signature) WriteTo(w io.Writer) (int64, error) {
+	bw := bufio.NewWriter(w)
+	header := make([]byte, 0, signatureHeaderSize)
+	header = append(header, signatureMagic[:]...)
+	header = append(header, formatVersion)
+	header = binary.BigEndian.AppendUint32(header, uint32(s.BlockSize))
+	header = binary.BigEndian.AppendUint64(header, uint64(s.Size))
+	header = binary.BigEndian.AppendUint32(header, uint32(len(s.Blocks)))
+	written, _ := bw.Write(header)
+
+	var weak [4]byte
+	for _, block := range s.Blocks {
+		binary.BigEndian.PutUint32(weak[:], block.Weak)
+		n, _ := bw.Write(weak[:])
+		written += n
+		n, _ = bw.Write(block.Strong[:])
+		written += n
+	}
+	return int64(written), bw.Flush()
+}
+
+// ReadSignature decodes a signature written by Signature.WriteTo
+func ReadSignature(r io.Reader) (*Signature, error) {
+	header := make([]byte, signatureHeaderSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, ErrInvalidSignature
+	}
+	if !bytes.Equal(header[:4], signatureMagic[:]) || header[4] != formatVersion {
+		return nil, ErrInvalidSignature
+	}
+
+	signature := &Signature{
+		BlockSize: int(binary.BigEndian.Uint32(header[5:9])),
+		Size:      int64(binary.BigEndian.Uint64(header[9:17])),
+	}
+	count := int64(binary.BigEndian.Uint32(header[17:21]))
+	if !ValidBlockSize(signature.BlockSize) || signature.Size < 0 ||
+		count != (signature.Size+int64(signature.BlockSize)-1)/int64(signature.BlockSize) {
+		return nil, ErrInvalidSignature
+	}
+
+	signature.Blocks = make([]BlockChecksum, count)
+	entry := make([]byte, 4+StrongHashSize)
+	br := bufio.NewReader(r)
+	for i := range signature.Blocks {
+		if _, err := io.ReadFull(br, entry); err != nil {
+			return nil, ErrInvalidSignature
+		}
+		signature.Blocks[i].Weak = binary.BigEndian.Uint32(entry[:4])
+		copy(signature.Blocks[i].Strong[:], entry[4:])
+	}
+	return signature, nil
+}
+
+// checksumBlock calculates the checksums of a block
+func checksumBlock(block []byte) BlockChecksum {
+	checksum := BlockChecksum{Weak: newRollingChecksum(block).sum()}
+	strong := sha256.Sum256(block)
+	copy(checksum.Strong[:], strong[:StrongHashSize])
+	return checksum
+}
+
+// rollingChecksum is the weak checksum of rsync over a window of bytes, which can be moved by a
+// byte without reading the whole window again
+type rollingChecksum struct {
+	a, b   uint32
+	length uint32
+}
+
+// newRollingChecksum calculates the checksum of a window
+func newRollingChecksum(window []byte) rollingChecksum {
+	r := rollingChecksum{length: uint32(len(window))}
+	for i, c := range window {
+		r.a += uint32(c)
+		r.b += uint32(len(window)-i) * uint32(c)
+	}
+	return r
+}
+
+// sum returns the checksum, both halves taken modulo 2^16
+func (r rollingChecksum) sum() uint32 {
+	return r.a&0xffff | (r.b&0xffff)<<16
+}
+
+// roll moves the window by a byte, dropping out and adding in
+func (r *rollingChecksum) roll(out, in byte) {
+	r.a = r.a - uint32(out) + uint32(in)
+	r.b = r.b - r.length*uint32(out) + r.a
+}
+
+// shrink drops the first byte of the window, once the end of the content is reached
+func (r *rollingChecksum) shrink(out byte) {
+	r.a -= uint32(out)
+	r.b -= r.length * uint32(out)
+	r.length--
+}
@@ -0,0 +1,87 @@
+// Package delta provides tests for block signatures
+package delta
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"  // v1.8.0+
+	"github.com/stretchr/testify/require" // v1.8.0+
+)
+
+// TestComputeSignature tests that signatures hold one checksum per block, the last block holding the rest
+func TestComputeSignature(t *testing.T) {
+	content := make([]byte, 2*MinBlockSize+100)
+	rand.New(rand.NewSource(1)).Read(content)
+
+	signature, err := ComputeSignature(bytes.NewReader(content), MinBlockSize)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(content)), signature.Size)
+	assert.Len(t, signature.Blocks, 3)
+	assert.Equal(t, checksumBlock(content[:MinBlockSize]), signature.Blocks[0])
+	assert.Equal(t, checksumBlock(content[2*MinBlockSize:]), signature.Blocks[2])
+	assert.Equal(t, 100, signature.blockLength(2))
+
+	// Test empty content
+	signature, err = ComputeSignature(bytes.NewReader(nil), MinBlockSize)
+	require.NoError(t, err)
+	assert.Empty(t, signature.Blocks)
+
+	// Test invalid block sizes
+	_, err = ComputeSignature(bytes.NewReader(content), MinBlockSize-1)
+	assert.ErrorIs(t, err, ErrInvalidBlockSize)
+	_, err = ComputeSignature(bytes.NewReader(content), MaxBlockSize+1)
+	assert.ErrorIs(t, err, ErrInvalidBlockSize)
+}
+
+// TestReadSignature tests that encoded signatures decode to the same signature
+func TestReadSignature(t *testing.T) {
+	content := make([]byte, 5*MinBlockSize+7)
+	rand.New(rand.NewSource(2)).Read(content)
+	signature, err := ComputeSignature(bytes.NewReader(content), MinBlockSize)
+	require.NoError(t, err)
+
+	var encoded bytes.Buffer
+	n, err := signature.WriteTo(&encoded)
+	require.NoError(t, err)
+	assert.Equal(t, int64(encoded.Len()), n)
+	assert.Equal(t, signature.EncodedSize(), n)
+
+	decoded, err := ReadSignature(bytes.NewReader(encoded.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, signature, decoded)
+
+	// Test a truncated signature
+	_, err = ReadSignature(bytes.NewReader(encoded.Bytes()[:encoded.Len()-1]))
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+
+	// Test a block count that does not match the size
+	tampered := append([]byte(nil), encoded.Bytes()...)
+	tampered[20]++
+	_, err = ReadSignature(bytes.NewReader(tampered))
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+
+	// Test something else than a signature
+	_, err = ReadSignature(bytes.NewReader([]byte("not a signature at all")))
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+}
+
+// TestRollingChecksum tests that rolling the checksum matches calculating it over the moved window
+func TestRollingChecksum(t *testing.T) {
+	content := make([]byte, 300)
+	rand.New(rand.NewSource(3)).Read(content)
+	window := 64
+
+	rolling := newRollingChecksum(content[:window])
+	for i := 1; i+window <= len(content); i++ {
+		rolling.roll(content[i-1], content[i+window-1])
+		require.Equal(t, newRollingChecksum(content[i:i+window]).sum(), rolling.sum(), "window at %d", i)
+	}
+
+	tail := len(content) - window
+	for i := tail + 1; i < len(content); i++ {
+		rolling.shrink(content[i-1])
+		require.Equal(t, newRollingChecksum(content[i:]).sum(), rolling.sum(), "window at %d", i)
+	}
+}
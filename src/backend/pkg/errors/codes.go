@@ -0,0 +1,113 @@
+package errors
+
+import (
+	"net/http" // standard library
+)
+
+// Default codes of the errors of each type that were not given a more specific one
+const (
+	CodeInvalidRequest     = "invalid_request"
+	CodeNotFound           = "not_found"
+	CodeForbidden          = "forbidden"
+	CodeUnauthenticated    = "unauthenticated"
+	CodeSecurityViolation  = "security_violation"
+	CodeInternalError      = "internal_error"
+	CodeServiceUnavailable = "service_unavailable"
+	CodeQuotaExceeded      = "quota_exceeded"
+)
+
+// Codes of the errors clients are expected to react to. Codes are part of the API: they are never
+// renamed, and each code keeps its error type and HTTP status.
+const (
+	CodeInvalidRequestFormat         = "invalid_request_format"
+	CodeValidationFailed             = "validation_failed"
+	CodeTenantContextRequired        = "tenant_context_required"
+	CodeUserContextRequired          = "user_context_required"
+	CodeAuthenticationRequired       = "authentication_required"
+	CodeInvalidAuthenticationToken   = "invalid_authentication_token"
+	CodeInvalidAPIKey                = "invalid_api_key"
+	CodeAmbiguousCredentials         = "ambiguous_credentials"
+	CodeInvalidGuestToken            = "invalid_guest_token"
+	CodeInvalidPlatformOperatorToken = "invalid_platform_operator_token"
+	CodeReauthenticationRequired     = "reauthentication_required"
+	CodeShareLinkPasswordInvalid     = "share_link_password_invalid"
+	CodeInsufficientPermissions      = "insufficient_permissions"
+	CodeTenantAccessDenied           = "tenant_access_denied"
+	CodeResourceAccessDenied         = "resource_access_denied"
+	CodeNetworkAccessDenied          = "network_access_denied"
+	CodeDownloadViewOnly             = "download_view_only"
+	CodeDownloadSignInRequired       = "download_sign_in_required"
+	CodeShareLinkPersonalData        = "share_link_personal_data"
+	CodeDocumentQuarantined          = "document_quarantined"
+	CodeShareLinkUnavailable         = "share_link_unavailable"
+	CodeIdempotencyKeyInProgress     = "idempotency_key_in_progress"
+	CodeIdempotencyKeyReused         = "idempotency_key_reused"
+	CodeStorageQuotaExceeded         = "storage_quota_exceeded"
+	CodeDocumentQuotaExceeded        = "document_quota_exceeded"
+	CodeDownloadLimitReached         = "download_limit_reached"
+	CodeRateLimitExceeded            = "rate_limit_exceeded"
+)
+
+// CodeInfo describes an error code clients can receive
+type CodeInfo struct {
+	Code   string // Machine-readable code of the error
+	Type   string // Type of the errors with the code
+	Status int    // HTTP status of the responses with the code
+}
+
+// catalog lists every code clients can receive, the default codes of each type first
+var catalog = []CodeInfo{
+	{Code: CodeInvalidRequest, Type: ErrorTypeValidation, Status: http.StatusBadRequest},
+	{Code: CodeNotFound, Type: ErrorTypeNotFound, Status: http.StatusNotFound},
+	{Code: CodeForbidden, Type: ErrorTypeAuthorization, Status: http.StatusForbidden},
+	{Code: CodeUnauthenticated, Type: ErrorTypeAuthentication, Status: http.StatusUnauthorized},
+	{Code: CodeSecurityViolation, Type: ErrorTypeSecurity, Status: http.StatusForbidden},
+	{Code: CodeInternalError, Type: ErrorTypeInternal, Status: http.StatusInternalServerError},
+	{Code: CodeServiceUnavailable, Type: ErrorTypeDependency, Status: http.StatusServiceUnavailable},
+	{Code: CodeQuotaExceeded, Type: ErrorTypeQuotaExceeded, Status: http.StatusTooManyRequests},
+
+	{Code: CodeInvalidRequestFormat, Type: ErrorTypeValidation, Status: http.StatusBadRequest},
+	{Code: CodeValidationFailed, Type: ErrorTypeValidation, Status: http.StatusBadRequest},
+	{Code: CodeTenantContextRequired, Type: ErrorTypeAuthentication, Status: http.StatusUnauthorized},
+	{Code: CodeUserContextRequired, Type: ErrorTypeAuthentication, Status: http.StatusUnauthorized},
+	{Code: CodeAuthenticationRequired, Type: ErrorTypeAuthentication, Status: http.StatusUnauthorized},
+	{Code: CodeInvalidAuthenticationToken, Type: ErrorTypeAuthentication, Status: http.StatusUnauthorized},
+	{Code: CodeInvalidAPIKey, Type: ErrorTypeAuthentication, Status: http.StatusUnauthorized},
+	{Code: CodeAmbiguousCredentials, Type: ErrorTypeAuthentication, Status: http.StatusUnauthorized},
+	{Code: CodeInvalidGuestToken, Type: ErrorTypeAuthentication, Status: http.StatusUnauthorized},
+	{Code: CodeInvalidPlatformOperatorToken, Type: ErrorTypeAuthentication, Status: http.StatusUnauthorized},
+	{Code: CodeReauthenticationRequired, Type: ErrorTypeAuthentication, Status: http.StatusUnauthorized},
+	{Code: CodeShareLinkPasswordInvalid, Type: ErrorTypeAuthentication, Status: http.StatusUnauthorized},
+	{Code: CodeInsufficientPermissions, Type: ErrorTypeAuthorization, Status: http.StatusForbidden},
+	{Code: CodeTenantAccessDenied, Type: ErrorTypeAuthorization, Status: http.StatusForbidden},
+	{Code: CodeResourceAccessDenied, Type: ErrorTypeAuthorization, Status: http.StatusForbidden},
+	{Code: CodeNetworkAccessDenied, Type: ErrorTypeAuthorization, Status: http.StatusForbidden},
+	{Code: CodeDownloadViewOnly, Type: ErrorTypeAuthorization, Status: http.StatusForbidden},
+	{Code: CodeDownloadSignInRequired, Type: ErrorTypeAuthorization, Status: http.StatusForbidden},
+	{Code: CodeShareLinkPersonalData, Type: ErrorTypeAuthorization, Status: http.StatusForbidden},
+	{Code: CodeDocumentQuarantined, Type: ErrorTypeSecurity, Status: http.StatusForbidden},
+	{Code: CodeShareLinkUnavailable, Type: ErrorTypeNotFound, Status: http.StatusNotFound},
+	{Code: CodeIdempotencyKeyInProgress, Type: ErrorTypeValidation, Status: http.StatusConflict},
+	{Code: CodeIdempotencyKeyReused, Type: ErrorTypeValidation, Status: http.StatusUnprocessableEntity},
+	{Code: CodeStorageQuotaExceeded, Type: ErrorTypeQuotaExceeded, Status: http.StatusRequestEntityTooLarge},
+	{Code: CodeDocumentQuotaExceeded, Type: ErrorTypeQuotaExceeded, Status: http.StatusTooManyRequests},
+	{Code: CodeDownloadLimitReached, Type: ErrorTypeQuotaExceeded, Status: http.StatusTooManyRequests},
+	{Code: CodeRateLimitExceeded, Type: ErrorTypeQuotaExceeded, Status: http.StatusTooManyRequests},
+}
+
+// Catalog returns every code clients can receive, the default codes of each type first.
+func Catalog() []CodeInfo {
+	codes := make([]CodeInfo, len(catalog))
+	copy(codes, catalog)
+	return codes
+}
+
+// LookupCode returns the description of a code, and false when clients cannot receive it.
+func LookupCode(code string) (CodeInfo, bool) {
+	for _, info := range catalog {
+		if info.Code == code {
+			return info, true
+		}
+	}
+	return CodeInfo{}, false
+}
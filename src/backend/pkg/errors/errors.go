@@ -8,6 +8,7 @@ import (
 	"errors" // standard library
 	"fmt"    // standard library
 	"net/http" // standard library
+	"strings"  // standard library
 )
 
 // Error type constants for categorizing different errors
@@ -19,15 +20,42 @@ const (
 	ErrorTypeSecurity      = "security"
 	ErrorTypeInternal      = "internal"
 	ErrorTypeDependency    = "dependency"
+	ErrorTypeQuotaExceeded = "quota_exceeded"
 )
 
+// defaultCodes are the codes of errors of each type that were not given a more specific one
+var defaultCodes = map[string]string{
+	ErrorTypeValidation:     CodeInvalidRequest,
+	ErrorTypeNotFound:       CodeNotFound,
+	ErrorTypeAuthorization:  CodeForbidden,
+	ErrorTypeAuthentication: CodeUnauthenticated,
+	ErrorTypeSecurity:       CodeSecurityViolation,
+	ErrorTypeInternal:       CodeInternalError,
+	ErrorTypeDependency:     CodeServiceUnavailable,
+	ErrorTypeQuotaExceeded:  CodeQuotaExceeded,
+}
+
+// FieldViolation describes a field of a request breaking a validation rule
+type FieldViolation struct {
+	Field   string // Path of the field in the request, such as files[0].name
+	Rule    string // Rule the field breaks, such as required or max
+	Message string // Message describing the violation
+}
+
 // AppError is a custom error type that provides additional context for application errors
-// including error type, HTTP status code, message, and original cause.
+// including error type, HTTP status code, message, and original cause. Errors that clients
+// react to also carry a machine-readable code, and the key and parameters of the message
+// shown for them in the caller's language. Validation errors of requests also carry every
+// field violation of the request.
 type AppError struct {
-	errorType  string
-	statusCode int
-	message    string
-	cause      error
+	errorType     string
+	statusCode    int
+	message       string
+	cause         error
+	code          string
+	messageKey    string
+	messageParams map[string]string
+	violations    []FieldViolation
 }
 
 // Error implements the error interface.
@@ -61,6 +89,21 @@ func (e *AppError) WithCause(cause error) *AppError {
 	return e
 }
 
+// WithCode sets the machine-readable code of the error and returns the AppError for chaining.
+// Unless a message key is set, the code is also the key of the error's localized message.
+func (e *AppError) WithCode(code string) *AppError {
+	e.code = code
+	return e
+}
+
+// WithMessageKey sets the key of the error's localized message and the values filled into its
+// placeholders, and returns the AppError for chaining.
+func (e *AppError) WithMessageKey(key string, params map[string]string) *AppError {
+	e.messageKey = key
+	e.messageParams = params
+	return e
+}
+
 // Type gets the error type.
 func (e *AppError) Type() string {
 	return e.errorType
@@ -76,6 +119,34 @@ func (e *AppError) Cause() error {
 	return e.cause
 }
 
+// Code gets the machine-readable code of the error, the default code of its type when it
+// was not given one.
+func (e *AppError) Code() string {
+	if e.code != "" {
+		return e.code
+	}
+	return DefaultCode(e.errorType)
+}
+
+// MessageKey gets the key of the error's localized message, its code when no key was set
+// and empty when the error has neither, in which case its message is not localized.
+func (e *AppError) MessageKey() string {
+	if e.messageKey != "" {
+		return e.messageKey
+	}
+	return e.code
+}
+
+// MessageParams gets the values filled into the placeholders of the error's localized message.
+func (e *AppError) MessageParams() map[string]string {
+	return e.messageParams
+}
+
+// Violations gets the field violations of the request the error was returned for.
+func (e *AppError) Violations() []FieldViolation {
+	return e.violations
+}
+
 // NewValidationError creates a new validation error with the given message.
 func NewValidationError(message string) error {
 	return &AppError{
@@ -85,6 +156,22 @@ func NewValidationError(message string) error {
 	}
 }
 
+// NewFieldValidationError creates a new validation error reporting every field violation of a
+// request, so that clients can fix all of them at once.
+func NewFieldValidationError(violations []FieldViolation) error {
+	messages := make([]string, len(violations))
+	for i, violation := range violations {
+		messages[i] = violation.Message
+	}
+	return &AppError{
+		errorType:  ErrorTypeValidation,
+		statusCode: http.StatusBadRequest,
+		message:    strings.Join(messages, "; "),
+		code:       CodeValidationFailed,
+		violations: violations,
+	}
+}
+
 // NewResourceNotFoundError creates a new resource not found error with the given message.
 func NewResourceNotFoundError(message string) error {
 	return &AppError{
@@ -139,6 +226,26 @@ func NewDependencyError(message string) error {
 	}
 }
 
+// NewQuotaExceededError creates a new quota exceeded error with the given message.
+// It is used when a request would exceed a count limit, such as the number of documents.
+func NewQuotaExceededError(message string) error {
+	return &AppError{
+		errorType:  ErrorTypeQuotaExceeded,
+		statusCode: http.StatusTooManyRequests,
+		message:    message,
+	}
+}
+
+// NewStorageQuotaExceededError creates a new quota exceeded error with the given message.
+// It is used when a request payload does not fit the remaining storage.
+func NewStorageQuotaExceededError(message string) error {
+	return &AppError{
+		errorType:  ErrorTypeQuotaExceeded,
+		statusCode: http.StatusRequestEntityTooLarge,
+		message:    message,
+	}
+}
+
 // Wrap wraps an existing error with additional context.
 func Wrap(err error, message string) error {
 	if err == nil {
@@ -147,12 +254,17 @@ func Wrap(err error, message string) error {
 
 	var appErr *AppError
 	if errors.As(err, &appErr) {
-		// If it's already an AppError, create a new one with the same type and status code
+		// If it's already an AppError, create a new one with the same type, status code and code,
+		// so that the wrapped error is still shown to clients as the original one
 		return &AppError{
-			errorType:  appErr.errorType,
-			statusCode: appErr.statusCode,
-			message:    message,
-			cause:      err,
+			errorType:     appErr.errorType,
+			statusCode:    appErr.statusCode,
+			message:       message,
+			cause:         err,
+			code:          appErr.code,
+			messageKey:    appErr.messageKey,
+			messageParams: appErr.messageParams,
+			violations:    appErr.violations,
 		}
 	}
 
@@ -165,6 +277,45 @@ func Wrap(err error, message string) error {
 	}
 }
 
+// WithCode sets the machine-readable code of err when it is an AppError and returns it, so that
+// errors can be declared with their code:
+//
+//	var ErrShareLinkUnavailable = errors.WithCode(errors.NewResourceNotFoundError("..."), errors.CodeShareLinkUnavailable)
+//
+// Codes clients can receive must be listed in the catalog of codes.go.
+func WithCode(err error, code string) error {
+	if appErr, ok := err.(*AppError); ok {
+		appErr.WithCode(code)
+	}
+	return err
+}
+
+// WithStatusCode sets the HTTP status code of err when it is an AppError and returns it, for errors
+// answered with another status than the one of their type.
+func WithStatusCode(err error, statusCode int) error {
+	if appErr, ok := err.(*AppError); ok {
+		appErr.WithStatusCode(statusCode)
+	}
+	return err
+}
+
+// WithMessageKey sets the key and parameters of the localized message of err when it is an
+// AppError and returns it.
+func WithMessageKey(err error, key string, params map[string]string) error {
+	if appErr, ok := err.(*AppError); ok {
+		appErr.WithMessageKey(key, params)
+	}
+	return err
+}
+
+// DefaultCode returns the code of errors of the given type that were not given a more specific one.
+func DefaultCode(errorType string) string {
+	if code, ok := defaultCodes[errorType]; ok {
+		return code
+	}
+	return CodeInternalError
+}
+
 // GetErrorType extracts the error type from an error if it's an AppError.
 func GetErrorType(err error) string {
 	if err == nil {
@@ -193,6 +344,35 @@ func GetStatusCode(err error) int {
 	return http.StatusInternalServerError
 }
 
+// GetCode extracts the machine-readable code from an error, the code of internal errors when it
+// is not an AppError.
+func GetCode(err error) string {
+	var appErr *AppError
+	if errors.As(err, &appErr) {
+		return appErr.Code()
+	}
+	return CodeInternalError
+}
+
+// GetMessageKey extracts the key and parameters of the localized message from an error. The key
+// is empty when the error's message is not localized.
+func GetMessageKey(err error) (string, map[string]string) {
+	var appErr *AppError
+	if errors.As(err, &appErr) {
+		return appErr.MessageKey(), appErr.MessageParams()
+	}
+	return "", nil
+}
+
+// GetViolations extracts the field violations from an error, nil when it reports none.
+func GetViolations(err error) []FieldViolation {
+	var appErr *AppError
+	if errors.As(err, &appErr) {
+		return appErr.violations
+	}
+	return nil
+}
+
 // IsValidationError checks if an error is a validation error.
 func IsValidationError(err error) bool {
 	return GetErrorType(err) == ErrorTypeValidation
@@ -221,4 +401,9 @@ func IsSecurityError(err error) bool {
 // IsDependencyError checks if an error is a dependency error.
 func IsDependencyError(err error) bool {
 	return GetErrorType(err) == ErrorTypeDependency
-}
\ No newline at end of file
+}
+
+// IsQuotaExceededError checks if an error is a quota exceeded error.
+func IsQuotaExceededError(err error) bool {
+	return GetErrorType(err) == ErrorTypeQuotaExceeded
+}
@@ -0,0 +1,118 @@
+// Package i18n renders the messages the Document Management Platform shows to people, such as
+// the messages of API errors, in their language. Messages are looked up by key in the catalog of
+// the language, and fall back to English when the language or the key is not translated.
+package i18n
+
+import (
+	"context" // standard library
+	"sort"    // standard library
+	"strconv" // standard library
+	"strings" // standard library
+)
+
+// DefaultLanguage is the language messages are shown in when no other language was asked for
+const DefaultLanguage = "en"
+
+// languageKey is the context key of the language messages are shown in
+type languageKey struct{}
+
+// ContextWithLanguage returns a copy of ctx carrying the language messages are shown in
+func ContextWithLanguage(ctx context.Context, language string) context.Context {
+	return context.WithValue(ctx, languageKey{}, language)
+}
+
+// LanguageFromContext returns the language carried by ctx, DefaultLanguage when it carries none
+func LanguageFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return DefaultLanguage
+	}
+	if language, ok := ctx.Value(languageKey{}).(string); ok && language != "" {
+		return language
+	}
+	return DefaultLanguage
+}
+
+// Supported returns the translated language matching a BCP 47 language tag, such as pt for pt-BR,
+// and false when messages are not translated to the tag's language
+func Supported(tag string) (string, bool) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if tag == "" {
+		return "", false
+	}
+	if _, ok := catalogs[tag]; ok {
+		return tag, true
+	}
+	if base, _, found := strings.Cut(tag, "-"); found {
+		if _, ok := catalogs[base]; ok {
+			return base, true
+		}
+	}
+	return "", false
+}
+
+// acceptedLanguage is a language range of an Accept-Language header and its quality
+type acceptedLanguage struct {
+	tag     string
+	quality float64
+}
+
+// Match returns the translated language a client prefers most according to the value of its
+// Accept-Language header, such as "fr-CH, fr;q=0.9, en;q=0.8", and false when it accepts none of
+// them. The wildcard is ignored, so that clients accepting any language get the default of their
+// tenant rather than an arbitrary one.
+func Match(acceptLanguage string) (string, bool) {
+	var accepted []acceptedLanguage
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = strings.TrimSpace(tag)
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		quality := 1.0
+		if value, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil || parsed < 0 || parsed > 1 {
+				continue
+			}
+			quality = parsed
+		}
+		if quality == 0 {
+			continue
+		}
+		accepted = append(accepted, acceptedLanguage{tag: tag, quality: quality})
+	}
+
+	// Keep the order of the header between languages of the same quality
+	sort.SliceStable(accepted, func(i, j int) bool {
+		return accepted[i].quality > accepted[j].quality
+	})
+	for _, language := range accepted {
+		if supported, ok := Supported(language.tag); ok {
+			return supported, true
+		}
+	}
+	return "", false
+}
+
+// Translate returns the message with the key in the language, with its {name} placeholders
+// replaced by the params. The English message is returned when the message is not translated to
+// the language, and false when there is no message with the key.
+func Translate(language, key string, params map[string]string) (string, bool) {
+	message, ok := catalogs[language][key]
+	if !ok {
+		message, ok = catalogs[DefaultLanguage][key]
+		if !ok {
+			return "", false
+		}
+	}
+
+	if len(params) == 0 {
+		return message, true
+	}
+	replacements := make([]string, 0, 2*len(params))
+	for name, value := range params {
+		replacements = append(replacements, "{"+name+"}", value)
+	}
+	return strings.NewReplacer(replacements...).Replace(message), true
+}
@@ -0,0 +1,83 @@
+// Package i18n provides tests for the message catalogs and language negotiation
+package i18n
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert" // v1.8.0+
+)
+
+// TestMatch tests choosing the language of an Accept-Language header
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		expected       string
+		ok             bool
+	}{
+		{"exact language", "de", "de", true},
+		{"regional variant falls back to its language", "pt-BR", "pt", true},
+		{"case insensitive", "FR-ca", "fr", true},
+		{"highest quality first", "en;q=0.5, es;q=0.9", "es", true},
+		{"header order between equal qualities", "fr, de", "fr", true},
+		{"untranslated languages are skipped", "ja, it;q=0.9, de;q=0.8", "de", true},
+		{"refused languages are skipped", "de;q=0, en;q=0.1", "en", true},
+		{"malformed qualities are skipped", "de;q=abc, fr;q=2, es;q=0.3", "es", true},
+		{"wildcard is ignored", "*", "", false},
+		{"no translated language", "ja, ko", "", false},
+		{"empty header", "", "", false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			language, ok := Match(tc.acceptLanguage)
+			assert.Equal(t, tc.expected, language)
+			assert.Equal(t, tc.ok, ok)
+		})
+	}
+}
+
+// TestTranslate tests looking up messages and filling in their placeholders
+func TestTranslate(t *testing.T) {
+	message, ok := Translate("de", "authentication_required", nil)
+	assert.True(t, ok)
+	assert.Equal(t, "Eine Anmeldung ist erforderlich.", message)
+
+	// Test filling in placeholders
+	message, ok = Translate("fr", "rate_limit_exceeded_retry", map[string]string{"seconds": "30"})
+	assert.True(t, ok)
+	assert.Equal(t, "Trop de requêtes. Veuillez réessayer dans 30 secondes.", message)
+
+	// Test the English fallback of untranslated languages
+	message, ok = Translate("ja", "authentication_required", nil)
+	assert.True(t, ok)
+	assert.Equal(t, "Authentication is required.", message)
+
+	// Test unknown keys
+	_, ok = Translate("en", "no_such_message", nil)
+	assert.False(t, ok)
+}
+
+// TestCatalogsComplete tests that every English message is translated with the same placeholders
+func TestCatalogsComplete(t *testing.T) {
+	placeholder := regexp.MustCompile(`\{[a-z_]+\}`)
+
+	for language, catalog := range catalogs {
+		assert.Len(t, catalog, len(catalogs[DefaultLanguage]), "catalog %s", language)
+		for key, english := range catalogs[DefaultLanguage] {
+			message, ok := catalog[key]
+			if assert.True(t, ok, "%s is not translated to %s", key, language) {
+				assert.ElementsMatch(t, placeholder.FindAllString(english, -1), placeholder.FindAllString(message, -1),
+					"placeholders of %s in %s", key, language)
+			}
+		}
+	}
+}
+
+// TestLanguageFromContext tests carrying the language in a context
+func TestLanguageFromContext(t *testing.T) {
+	assert.Equal(t, DefaultLanguage, LanguageFromContext(context.Background()))
+	assert.Equal(t, "es", LanguageFromContext(ContextWithLanguage(context.Background(), "es")))
+}
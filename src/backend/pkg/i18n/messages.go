@@ -0,0 +1,238 @@
+package i18n
+
+// catalogs are the messages of each translated language by key. The keys of error messages are
+// the codes of the errors they describe, and the keys of the titles of error responses are the
+// types of the errors prefixed with error_type. Every message of the English catalog must be
+// translated in the others, with the same placeholders.
+var catalogs = map[string]map[string]string{
+	"en": {
+		"invalid_request":                 "The request is invalid.",
+		"not_found":                       "The requested resource was not found.",
+		"forbidden":                       "You are not allowed to perform this action.",
+		"unauthenticated":                 "The request could not be authenticated.",
+		"security_violation":              "The request was refused for security reasons.",
+		"service_unavailable":             "A service the platform depends on is unavailable. Please try again later.",
+		"quota_exceeded":                  "A limit of your organization has been reached.",
+		"invalid_request_format":          "The request is not in the expected format.",
+		"validation_failed":               "Some fields of the request are invalid.",
+		"tenant_context_required":         "The request is not associated with a tenant.",
+		"user_context_required":           "The request is not associated with a user.",
+		"authentication_required":         "Authentication is required.",
+		"invalid_authentication_token":    "The authentication token is missing or invalid.",
+		"insufficient_permissions":        "You do not have permission to perform this action.",
+		"invalid_api_key":                 "The API key is invalid.",
+		"ambiguous_credentials":           "Use either an API key or a bearer token, not both.",
+		"invalid_guest_token":             "The guest token is missing or invalid.",
+		"invalid_platform_operator_token": "The platform operator token is missing or invalid.",
+		"tenant_access_denied":            "You are not allowed to access this tenant.",
+		"resource_access_denied":          "You are not allowed to access this resource.",
+		"idempotency_key_reused":          "This Idempotency-Key was already used for a different request.",
+		"idempotency_key_in_progress":     "A request with this Idempotency-Key is still being processed.",
+		"rate_limit_exceeded":             "Too many requests. Please try again later.",
+		"rate_limit_exceeded_retry":       "Too many requests. Please try again in {seconds} seconds.",
+		"network_access_denied":           "Your organization does not allow access from this network.",
+		"storage_quota_exceeded":          "Your organization's storage quota has been reached.",
+		"document_quota_exceeded":         "Your organization's document quota has been reached.",
+		"download_view_only":              "Documents in this folder can be viewed but not downloaded.",
+		"download_limit_reached":          "The daily download limit of this folder has been reached. Please try again tomorrow.",
+		"reauthentication_required":       "Sign in again to download documents from this folder.",
+		"download_sign_in_required":       "Documents in this folder can only be downloaded by signed-in users.",
+		"document_quarantined":            "This document has been quarantined due to security concerns.",
+		"share_link_unavailable":          "This share link does not exist or is no longer available.",
+		"share_link_password_invalid":     "The share link password is missing or incorrect.",
+		"share_link_personal_data":        "Documents containing personal data cannot be shared through public links.",
+		"internal_error":                  "An internal server error occurred.",
+		"error_type.validation":           "Invalid request",
+		"error_type.not_found":            "Not found",
+		"error_type.authorization":        "Forbidden",
+		"error_type.authentication":       "Unauthenticated",
+		"error_type.security":             "Security violation",
+		"error_type.internal":             "Internal error",
+		"error_type.dependency":           "Service unavailable",
+		"error_type.quota_exceeded":       "Limit exceeded",
+	},
+	"de": {
+		"invalid_request":                 "Die Anfrage ist ungültig.",
+		"not_found":                       "Die angeforderte Ressource wurde nicht gefunden.",
+		"forbidden":                       "Sie dürfen diese Aktion nicht ausführen.",
+		"unauthenticated":                 "Die Anfrage konnte nicht authentifiziert werden.",
+		"security_violation":              "Die Anfrage wurde aus Sicherheitsgründen abgelehnt.",
+		"service_unavailable":             "Ein Dienst, von dem die Plattform abhängt, ist nicht verfügbar. Bitte versuchen Sie es später erneut.",
+		"quota_exceeded":                  "Ein Limit Ihrer Organisation wurde erreicht.",
+		"invalid_request_format":          "Die Anfrage hat nicht das erwartete Format.",
+		"validation_failed":               "Einige Felder der Anfrage sind ungültig.",
+		"tenant_context_required":         "Die Anfrage ist keinem Mandanten zugeordnet.",
+		"user_context_required":           "Die Anfrage ist keinem Benutzer zugeordnet.",
+		"authentication_required":         "Eine Anmeldung ist erforderlich.",
+		"invalid_authentication_token":    "Das Authentifizierungstoken fehlt oder ist ungültig.",
+		"insufficient_permissions":        "Sie sind nicht berechtigt, diese Aktion auszuführen.",
+		"invalid_api_key":                 "Der API-Schlüssel ist ungültig.",
+		"ambiguous_credentials":           "Verwenden Sie entweder einen API-Schlüssel oder ein Bearer-Token, nicht beides.",
+		"invalid_guest_token":             "Das Gasttoken fehlt oder ist ungültig.",
+		"invalid_platform_operator_token": "Das Token des Plattformbetreibers fehlt oder ist ungültig.",
+		"tenant_access_denied":            "Sie haben keinen Zugriff auf diesen Mandanten.",
+		"resource_access_denied":          "Sie haben keinen Zugriff auf diese Ressource.",
+		"idempotency_key_reused":          "Dieser Idempotency-Key wurde bereits für eine andere Anfrage verwendet.",
+		"idempotency_key_in_progress":     "Eine Anfrage mit diesem Idempotency-Key wird noch verarbeitet.",
+		"rate_limit_exceeded":             "Zu viele Anfragen. Bitte versuchen Sie es später erneut.",
+		"rate_limit_exceeded_retry":       "Zu viele Anfragen. Bitte versuchen Sie es in {seconds} Sekunden erneut.",
+		"network_access_denied":           "Ihre Organisation erlaubt keinen Zugriff aus diesem Netzwerk.",
+		"storage_quota_exceeded":          "Das Speicherkontingent Ihrer Organisation ist erreicht.",
+		"document_quota_exceeded":         "Das Dokumentkontingent Ihrer Organisation ist erreicht.",
+		"download_view_only":              "Dokumente in diesem Ordner können angezeigt, aber nicht heruntergeladen werden.",
+		"download_limit_reached":          "Das tägliche Download-Limit dieses Ordners ist erreicht. Bitte versuchen Sie es morgen erneut.",
+		"reauthentication_required":       "Melden Sie sich erneut an, um Dokumente aus diesem Ordner herunterzuladen.",
+		"download_sign_in_required":       "Dokumente in diesem Ordner können nur von angemeldeten Benutzern heruntergeladen werden.",
+		"document_quarantined":            "Dieses Dokument wurde aus Sicherheitsgründen unter Quarantäne gestellt.",
+		"share_link_unavailable":          "Dieser Freigabelink existiert nicht oder ist nicht mehr verfügbar.",
+		"share_link_password_invalid":     "Das Passwort des Freigabelinks fehlt oder ist falsch.",
+		"share_link_personal_data":        "Dokumente mit personenbezogenen Daten können nicht über öffentliche Links geteilt werden.",
+		"internal_error":                  "Ein interner Serverfehler ist aufgetreten.",
+		"error_type.validation":           "Ungültige Anfrage",
+		"error_type.not_found":            "Nicht gefunden",
+		"error_type.authorization":        "Zugriff verweigert",
+		"error_type.authentication":       "Nicht angemeldet",
+		"error_type.security":             "Sicherheitsverstoß",
+		"error_type.internal":             "Interner Fehler",
+		"error_type.dependency":           "Dienst nicht verfügbar",
+		"error_type.quota_exceeded":       "Limit überschritten",
+	},
+	"es": {
+		"invalid_request":                 "La solicitud no es válida.",
+		"not_found":                       "No se encontró el recurso solicitado.",
+		"forbidden":                       "No puede realizar esta acción.",
+		"unauthenticated":                 "No se pudo autenticar la solicitud.",
+		"security_violation":              "La solicitud se rechazó por motivos de seguridad.",
+		"service_unavailable":             "Un servicio del que depende la plataforma no está disponible. Vuelva a intentarlo más tarde.",
+		"quota_exceeded":                  "Se alcanzó un límite de su organización.",
+		"invalid_request_format":          "La solicitud no tiene el formato esperado.",
+		"validation_failed":               "Algunos campos de la solicitud no son válidos.",
+		"tenant_context_required":         "La solicitud no está asociada a ningún inquilino.",
+		"user_context_required":           "La solicitud no está asociada a ningún usuario.",
+		"authentication_required":         "Se requiere autenticación.",
+		"invalid_authentication_token":    "Falta el token de autenticación o no es válido.",
+		"insufficient_permissions":        "No tiene permiso para realizar esta acción.",
+		"invalid_api_key":                 "La clave de API no es válida.",
+		"ambiguous_credentials":           "Use una clave de API o un token bearer, pero no ambos.",
+		"invalid_guest_token":             "Falta el token de invitado o no es válido.",
+		"invalid_platform_operator_token": "Falta el token de operador de la plataforma o no es válido.",
+		"tenant_access_denied":            "No tiene permiso para acceder a este inquilino.",
+		"resource_access_denied":          "No tiene permiso para acceder a este recurso.",
+		"idempotency_key_reused":          "Esta Idempotency-Key ya se usó para otra solicitud.",
+		"idempotency_key_in_progress":     "Una solicitud con esta Idempotency-Key todavía se está procesando.",
+		"rate_limit_exceeded":             "Demasiadas solicitudes. Vuelva a intentarlo más tarde.",
+		"rate_limit_exceeded_retry":       "Demasiadas solicitudes. Vuelva a intentarlo en {seconds} segundos.",
+		"network_access_denied":           "Su organización no permite el acceso desde esta red.",
+		"storage_quota_exceeded":          "Se alcanzó la cuota de almacenamiento de su organización.",
+		"document_quota_exceeded":         "Se alcanzó la cuota de documentos de su organización.",
+		"download_view_only":              "Los documentos de esta carpeta se pueden ver, pero no descargar.",
+		"download_limit_reached":          "Se alcanzó el límite diario de descargas de esta carpeta. Vuelva a intentarlo mañana.",
+		"reauthentication_required":       "Vuelva a iniciar sesión para descargar documentos de esta carpeta.",
+		"download_sign_in_required":       "Solo los usuarios que iniciaron sesión pueden descargar documentos de esta carpeta.",
+		"document_quarantined":            "Este documento se puso en cuarentena por motivos de seguridad.",
+		"share_link_unavailable":          "Este enlace compartido no existe o ya no está disponible.",
+		"share_link_password_invalid":     "Falta la contraseña del enlace compartido o es incorrecta.",
+		"share_link_personal_data":        "Los documentos con datos personales no se pueden compartir mediante enlaces públicos.",
+		"internal_error":                  "Se produjo un error interno del servidor.",
+		"error_type.validation":           "Solicitud no válida",
+		"error_type.not_found":            "No encontrado",
+		"error_type.authorization":        "Acceso denegado",
+		"error_type.authentication":       "No autenticado",
+		"error_type.security":             "Infracción de seguridad",
+		"error_type.internal":             "Error interno",
+		"error_type.dependency":           "Servicio no disponible",
+		"error_type.quota_exceeded":       "Límite superado",
+	},
+	"fr": {
+		"invalid_request":                 "La requête est invalide.",
+		"not_found":                       "La ressource demandée est introuvable.",
+		"forbidden":                       "Vous ne pouvez pas effectuer cette action.",
+		"unauthenticated":                 "La requête n'a pas pu être authentifiée.",
+		"security_violation":              "La requête a été refusée pour des raisons de sécurité.",
+		"service_unavailable":             "Un service dont dépend la plateforme est indisponible. Veuillez réessayer plus tard.",
+		"quota_exceeded":                  "Une limite de votre organisation a été atteinte.",
+		"invalid_request_format":          "La requête n'est pas au format attendu.",
+		"validation_failed":               "Certains champs de la requête sont invalides.",
+		"tenant_context_required":         "La requête n'est associée à aucun locataire.",
+		"user_context_required":           "La requête n'est associée à aucun utilisateur.",
+		"authentication_required":         "Une authentification est requise.",
+		"invalid_authentication_token":    "Le jeton d'authentification est manquant ou invalide.",
+		"insufficient_permissions":        "Vous n'êtes pas autorisé à effectuer cette action.",
+		"invalid_api_key":                 "La clé d'API est invalide.",
+		"ambiguous_credentials":           "Utilisez soit une clé d'API, soit un jeton bearer, mais pas les deux.",
+		"invalid_guest_token":             "Le jeton d'invité est manquant ou invalide.",
+		"invalid_platform_operator_token": "Le jeton d'opérateur de la plateforme est manquant ou invalide.",
+		"tenant_access_denied":            "Vous n'êtes pas autorisé à accéder à ce locataire.",
+		"resource_access_denied":          "Vous n'êtes pas autorisé à accéder à cette ressource.",
+		"idempotency_key_reused":          "Cette Idempotency-Key a déjà été utilisée pour une autre requête.",
+		"idempotency_key_in_progress":     "Une requête avec cette Idempotency-Key est encore en cours de traitement.",
+		"rate_limit_exceeded":             "Trop de requêtes. Veuillez réessayer plus tard.",
+		"rate_limit_exceeded_retry":       "Trop de requêtes. Veuillez réessayer dans {seconds} secondes.",
+		"network_access_denied":           "Votre organisation n'autorise pas l'accès depuis ce réseau.",
+		"storage_quota_exceeded":          "Le quota de stockage de votre organisation est atteint.",
+		"document_quota_exceeded":         "Le quota de documents de votre organisation est atteint.",
+		"download_view_only":              "Les documents de ce dossier peuvent être consultés mais pas téléchargés.",
+		"download_limit_reached":          "La limite quotidienne de téléchargements de ce dossier est atteinte. Veuillez réessayer demain.",
+		"reauthentication_required":       "Reconnectez-vous pour télécharger les documents de ce dossier.",
+		"download_sign_in_required":       "Les documents de ce dossier ne peuvent être téléchargés que par des utilisateurs connectés.",
+		"document_quarantined":            "Ce document a été mis en quarantaine pour des raisons de sécurité.",
+		"share_link_unavailable":          "Ce lien de partage n'existe pas ou n'est plus disponible.",
+		"share_link_password_invalid":     "Le mot de passe du lien de partage est manquant ou incorrect.",
+		"share_link_personal_data":        "Les documents contenant des données personnelles ne peuvent pas être partagés par des liens publics.",
+		"internal_error":                  "Une erreur interne du serveur s'est produite.",
+		"error_type.validation":           "Requête invalide",
+		"error_type.not_found":            "Introuvable",
+		"error_type.authorization":        "Accès refusé",
+		"error_type.authentication":       "Non authentifié",
+		"error_type.security":             "Violation de sécurité",
+		"error_type.internal":             "Erreur interne",
+		"error_type.dependency":           "Service indisponible",
+		"error_type.quota_exceeded":       "Limite dépassée",
+	},
+	"pt": {
+		"invalid_request":                 "A solicitação é inválida.",
+		"not_found":                       "O recurso solicitado não foi encontrado.",
+		"forbidden":                       "Você não pode realizar esta ação.",
+		"unauthenticated":                 "Não foi possível autenticar a solicitação.",
+		"security_violation":              "A solicitação foi recusada por motivos de segurança.",
+		"service_unavailable":             "Um serviço do qual a plataforma depende está indisponível. Tente novamente mais tarde.",
+		"quota_exceeded":                  "Um limite da sua organização foi atingido.",
+		"invalid_request_format":          "A solicitação não está no formato esperado.",
+		"validation_failed":               "Alguns campos da solicitação são inválidos.",
+		"tenant_context_required":         "A solicitação não está associada a nenhum locatário.",
+		"user_context_required":           "A solicitação não está associada a nenhum usuário.",
+		"authentication_required":         "É necessário autenticar-se.",
+		"invalid_authentication_token":    "O token de autenticação está ausente ou é inválido.",
+		"insufficient_permissions":        "Você não tem permissão para realizar esta ação.",
+		"invalid_api_key":                 "A chave de API é inválida.",
+		"ambiguous_credentials":           "Use uma chave de API ou um token bearer, mas não ambos.",
+		"invalid_guest_token":             "O token de convidado está ausente ou é inválido.",
+		"invalid_platform_operator_token": "O token de operador da plataforma está ausente ou é inválido.",
+		"tenant_access_denied":            "Você não tem permissão para acessar este locatário.",
+		"resource_access_denied":          "Você não tem permissão para acessar este recurso.",
+		"idempotency_key_reused":          "Esta Idempotency-Key já foi usada em outra solicitação.",
+		"idempotency_key_in_progress":     "Uma solicitação com esta Idempotency-Key ainda está sendo processada.",
+		"rate_limit_exceeded":             "Muitas solicitações. Tente novamente mais tarde.",
+		"rate_limit_exceeded_retry":       "Muitas solicitações. Tente novamente em {seconds} segundos.",
+		"network_access_denied":           "Sua organização não permite o acesso a partir desta rede.",
+		"storage_quota_exceeded":          "A cota de armazenamento da sua organização foi atingida.",
+		"document_quota_exceeded":         "A cota de documentos da sua organização foi atingida.",
+		"download_view_only":              "Os documentos desta pasta podem ser visualizados, mas não baixados.",
+		"download_limit_reached":          "O limite diário de downloads desta pasta foi atingido. Tente novamente amanhã.",
+		"reauthentication_required":       "Entre novamente para baixar documentos desta pasta.",
+		"download_sign_in_required":       "Os documentos desta pasta só podem ser baixados por usuários conectados.",
+		"document_quarantined":            "Este documento foi colocado em quarentena por motivos de segurança.",
+		"share_link_unavailable":          "Este link de compartilhamento não existe ou não está mais disponível.",
+		"share_link_password_invalid":     "A senha do link de compartilhamento está ausente ou incorreta.",
+		"share_link_personal_data":        "Documentos com dados pessoais não podem ser compartilhados por links públicos.",
+		"internal_error":                  "Ocorreu um erro interno no servidor.",
+		"error_type.validation":           "Solicitação inválida",
+		"error_type.not_found":            "Não encontrado",
+		"error_type.authorization":        "Acesso negado",
+		"error_type.authentication":       "Não autenticado",
+		"error_type.security":             "Violação de segurança",
+		"error_type.internal":             "Erro interno",
+		"error_type.dependency":           "Serviço indisponível",
+		"error_type.quota_exceeded":       "Limite excedido",
+	},
+}
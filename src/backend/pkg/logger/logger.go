@@ -10,6 +10,7 @@ import (
 	"os"
 	"time"
 
+	"go.opentelemetry.io/otel/trace" // v1.11.0+
 	"go.uber.org/zap" // v1.24.0+
 	"go.uber.org/zap/zapcore" // v1.24.0+
 )
@@ -212,20 +213,45 @@ func WithContext(ctx context.Context) *zap.Logger {
 		fields = append(fields, zap.String("request_id", requestID))
 	}
 	
-	// Extract trace ID from context if present
-	if traceID, ok := ctx.Value(contextKeyTraceID).(string); ok && traceID != "" {
-		fields = append(fields, zap.String("trace_id", traceID))
-	}
-	
-	// Extract span ID from context if present
-	if spanID, ok := ctx.Value(contextKeySpanID).(string); ok && spanID != "" {
-		fields = append(fields, zap.String("span_id", spanID))
+	// Extract trace ID and span ID from the span of the context, or from context values if present
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		fields = append(fields,
+			zap.String("trace_id", spanContext.TraceID().String()),
+			zap.String("span_id", spanContext.SpanID().String()))
+	} else {
+		if traceID, ok := ctx.Value(contextKeyTraceID).(string); ok && traceID != "" {
+			fields = append(fields, zap.String("trace_id", traceID))
+		}
+		if spanID, ok := ctx.Value(contextKeySpanID).(string); ok && spanID != "" {
+			fields = append(fields, zap.String("span_id", spanID))
+		}
 	}
 	
 	// Return logger with added fields
 	return logger.With(fields...)
 }
 
+// RequestIDKey is the name the request ID is carried under in log fields and message attributes
+const RequestIDKey = contextKeyRequestID
+
+// ContextWithRequestID returns a copy of the context carrying the request ID, which WithContext
+// adds to log entries. An empty request ID returns the context unchanged.
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	if requestID == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, contextKeyRequestID, requestID)
+}
+
+// RequestIDFromContext returns the request ID carried by the context, or an empty string
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	requestID, _ := ctx.Value(contextKeyRequestID).(string)
+	return requestID
+}
+
 // WithField creates a logger with an additional field
 func WithField(key string, value interface{}) *zap.Logger {
 	// Check if initialized, if not return no-op logger
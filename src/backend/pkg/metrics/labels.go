@@ -0,0 +1,97 @@
+package metrics
+
+import (
+	"mime"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Label values of the values that are not labelled by themselves to bound the number of series
+const (
+	labelOther     = "other"
+	labelNone      = "none"
+	labelUnmatched = "unmatched"
+)
+
+// Default numbers of distinct values of the labels taking values from requests
+const (
+	defaultTenantLabelLimit      = 100
+	defaultContentTypeLabelLimit = 50
+)
+
+// knownMethods are the HTTP methods labelled by themselves, including the WebDAV methods of mounted
+// folders; requests with other methods are labelled OTHER
+var knownMethods = map[string]bool{
+	"GET": true, "HEAD": true, "POST": true, "PUT": true, "PATCH": true, "DELETE": true, "OPTIONS": true,
+	"PROPFIND": true, "PROPPATCH": true, "MKCOL": true, "COPY": true, "MOVE": true, "LOCK": true, "UNLOCK": true,
+}
+
+// labelLimiter bounds the number of distinct values of a label. The first values seen up to the limit
+// are labelled by themselves for the life of the process and further values are labelled "other", so
+// a tenant keeps its series once it has one.
+type labelLimiter struct {
+	mu     sync.Mutex
+	limit  int
+	values map[string]struct{}
+}
+
+// newLabelLimiter creates a labelLimiter keeping limit distinct values. A negative limit labels all values "other".
+func newLabelLimiter(limit int) *labelLimiter {
+	return &labelLimiter{limit: limit, values: make(map[string]struct{})}
+}
+
+// label returns the label value of value
+func (l *labelLimiter) label(value string) string {
+	if value == "" {
+		return labelNone
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, ok := l.values[value]; ok {
+		return value
+	}
+	if len(l.values) >= l.limit {
+		return labelOther
+	}
+	l.values[value] = struct{}{}
+	return value
+}
+
+// methodLabel returns the label value of an HTTP method
+func methodLabel(method string) string {
+	method = strings.ToUpper(method)
+	if knownMethods[method] {
+		return method
+	}
+	return "OTHER"
+}
+
+// routeLabel returns the label value of the route template of a request. Requests that match no route
+// share a label instead of labelling each path that was tried.
+func routeLabel(route string) string {
+	if route == "" {
+		return labelUnmatched
+	}
+	return route
+}
+
+// statusLabel returns the label value of an HTTP status code
+func statusLabel(status int) string {
+	return strconv.Itoa(status)
+}
+
+// mediaType returns the media type of a content type without its parameters, or "other" when it
+// cannot be parsed
+func mediaType(contentType string) string {
+	if contentType == "" {
+		return ""
+	}
+	parsed, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return labelOther
+	}
+	return parsed
+}
@@ -0,0 +1,38 @@
+// Package metrics provides tests for the label cardinality controls
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert" // v1.8.0+
+)
+
+// TestLabelLimiter tests that values past the limit are labelled "other" while known values keep their label
+func TestLabelLimiter(t *testing.T) {
+	limiter := newLabelLimiter(2)
+
+	assert.Equal(t, "tenant-1", limiter.label("tenant-1"))
+	assert.Equal(t, "tenant-2", limiter.label("tenant-2"))
+	assert.Equal(t, "other", limiter.label("tenant-3"))
+	assert.Equal(t, "tenant-1", limiter.label("tenant-1"))
+
+	// Test that an empty value does not use up the limit
+	assert.Equal(t, "none", newLabelLimiter(0).label(""))
+
+	// Test that a negative limit labels all values "other"
+	assert.Equal(t, "other", newLabelLimiter(-1).label("tenant-1"))
+}
+
+// TestRequestLabels tests the labels of the method, route and content type of requests
+func TestRequestLabels(t *testing.T) {
+	assert.Equal(t, "GET", methodLabel("get"))
+	assert.Equal(t, "PROPFIND", methodLabel("PROPFIND"))
+	assert.Equal(t, "OTHER", methodLabel("BREW"))
+
+	assert.Equal(t, "/api/v1/documents/:id", routeLabel("/api/v1/documents/:id"))
+	assert.Equal(t, "unmatched", routeLabel(""))
+
+	assert.Equal(t, "text/plain", mediaType("text/plain; charset=utf-8"))
+	assert.Equal(t, "other", mediaType("not a media type;"))
+	assert.Equal(t, "", mediaType(""))
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promauto" // v1.14.0+
 	"github.com/prometheus/client_golang/prometheus/promhttp" // v1.14.0+
 
+	"src/backend/pkg/config"
 	"src/backend/pkg/logger"
 )
 
@@ -25,22 +26,38 @@ var (
 	initLock    sync.Mutex
 	namespace   = "document_mgmt"
 
+	// Limiters of the labels taking values from requests
+	tenantLabels      = newLabelLimiter(defaultTenantLabelLimit)
+	contentTypeLabels = newLabelLimiter(defaultContentTypeLabelLimit)
+
 	// HTTP metrics
-	httpRequestsTotal    prometheus.Counter
-	httpRequestDuration  prometheus.Histogram
-	httpRequestsInFlight prometheus.Gauge
+	httpRequestsTotal     prometheus.CounterVec
+	httpRequestDuration   prometheus.HistogramVec
+	httpRequestsInFlight  prometheus.Gauge
+	httpRequestsThrottled prometheus.CounterVec
+
+	// gRPC metrics
+	grpcRequestsTotal   prometheus.CounterVec
+	grpcRequestDuration prometheus.HistogramVec
 
 	// Document metrics
 	documentUploadsTotal       prometheus.CounterVec
 	documentDownloadsTotal     prometheus.CounterVec
-	documentSearchesTotal      prometheus.Counter
+	documentSearchesTotal      prometheus.CounterVec
+	documentSearchDuration     prometheus.HistogramVec
 	documentProcessingDuration prometheus.Histogram
 
 	// Security metrics
 	virusDetectionsTotal prometheus.Counter
+	scanVerdictsTotal    prometheus.CounterVec
+	scanDuration         prometheus.Histogram
+	scanQueueDepth       prometheus.GaugeVec
+	scanVerdictCacheHits prometheus.Counter
 
 	// Storage metrics
-	storageUsageBytes prometheus.GaugeVec
+	storageUsageBytes              prometheus.GaugeVec
+	storageFailoverReadsTotal      prometheus.CounterVec
+	storageReplicationInconsistent prometheus.GaugeVec
 )
 
 // MetricsConfig defines configuration options for the metrics system
@@ -55,19 +72,41 @@ type MetricsConfig struct {
 	EndpointPort int
 	// Namespace is the prefix for all metrics
 	Namespace string
+	// TenantLabelLimit is the number of tenants labelled by their ID before further tenants are
+	// labelled "other"; zero uses the default and a negative limit labels all tenants "other"
+	TenantLabelLimit int
 }
 
 // NewMetricsConfig creates a new MetricsConfig with default values
 func NewMetricsConfig() MetricsConfig {
 	return MetricsConfig{
-		Enabled:         true,
-		EnableEndpoint:  true,
-		EndpointAddress: "0.0.0.0",
-		EndpointPort:    9090,
-		Namespace:       "document_mgmt",
+		Enabled:          true,
+		EnableEndpoint:   true,
+		EndpointAddress:  "0.0.0.0",
+		EndpointPort:     9090,
+		Namespace:        "document_mgmt",
+		TenantLabelLimit: defaultTenantLabelLimit,
 	}
 }
 
+// NewMetricsConfigFromConfig creates a MetricsConfig from the metrics section of the application
+// configuration, with the defaults of NewMetricsConfig for the settings it leaves empty
+func NewMetricsConfigFromConfig(cfg config.MetricsConfig) MetricsConfig {
+	metricsConfig := NewMetricsConfig()
+	metricsConfig.Enabled = cfg.Enabled
+	metricsConfig.EnableEndpoint = cfg.Enabled
+	if cfg.Port != 0 {
+		metricsConfig.EndpointPort = cfg.Port
+	}
+	if cfg.Namespace != "" {
+		metricsConfig.Namespace = cfg.Namespace
+	}
+	if cfg.TenantLabelLimit != 0 {
+		metricsConfig.TenantLabelLimit = cfg.TenantLabelLimit
+	}
+	return metricsConfig
+}
+
 // Timer is used for measuring operation duration
 type Timer struct {
 	operation string
@@ -88,8 +127,6 @@ func (t *Timer) ObserveDuration() time.Duration {
 
 	// Record duration based on operation type
 	switch t.operation {
-	case "http_request":
-		ObserveHTTPRequestDuration(duration)
 	case "document_processing":
 		ObserveDocumentProcessingDuration(duration)
 	}
@@ -107,6 +144,12 @@ func Init(config MetricsConfig) error {
 		return nil
 	}
 
+	// Leave metrics uninitialized when disabled, so recording them does nothing
+	if !config.Enabled {
+		logger.Info("Metrics collection disabled")
+		return nil
+	}
+
 	// Create a new registry
 	registry = prometheus.NewRegistry()
 
@@ -119,6 +162,14 @@ func Init(config MetricsConfig) error {
 		namespace = config.Namespace
 	}
 
+	// Bound the number of tenants labelled by their ID
+	tenantLabelLimit := config.TenantLabelLimit
+	if tenantLabelLimit == 0 {
+		tenantLabelLimit = defaultTenantLabelLimit
+	}
+	tenantLabels = newLabelLimiter(tenantLabelLimit)
+	contentTypeLabels = newLabelLimiter(defaultContentTypeLabelLimit)
+
 	// Initialize all metrics
 	initializeMetrics()
 
@@ -151,18 +202,19 @@ func Init(config MetricsConfig) error {
 // initializeMetrics creates and registers all metrics
 func initializeMetrics() {
 	// HTTP metrics
-	httpRequestsTotal = promauto.With(registry).NewCounter(prometheus.CounterOpts{
+	httpRequestsTotal = *promauto.With(registry).NewCounterVec(prometheus.CounterOpts{
 		Namespace: namespace,
 		Name:      "http_requests_total",
 		Help:      "Total number of HTTP requests",
-	})
+	}, []string{"method", "route", "status", "tenant_id"})
 
-	httpRequestDuration = promauto.With(registry).NewHistogram(prometheus.HistogramOpts{
+	// Durations are not labelled by tenant, which would multiply the series of every bucket
+	httpRequestDuration = *promauto.With(registry).NewHistogramVec(prometheus.HistogramOpts{
 		Namespace: namespace,
 		Name:      "http_request_duration_seconds",
 		Help:      "HTTP request duration in seconds",
 		Buckets:   prometheus.DefBuckets,
-	})
+	}, []string{"method", "route", "status"})
 
 	httpRequestsInFlight = promauto.With(registry).NewGauge(prometheus.GaugeOpts{
 		Namespace: namespace,
@@ -170,6 +222,26 @@ func initializeMetrics() {
 		Help:      "Current number of HTTP requests in flight",
 	})
 
+	httpRequestsThrottled = *promauto.With(registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_throttled_total",
+		Help:      "Total number of HTTP requests rejected by rate limiting",
+	}, []string{"scope", "route"})
+
+	// gRPC metrics
+	grpcRequestsTotal = *promauto.With(registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "grpc_requests_total",
+		Help:      "Total number of gRPC requests",
+	}, []string{"method", "code"})
+
+	grpcRequestDuration = *promauto.With(registry).NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "grpc_request_duration_seconds",
+		Help:      "gRPC request duration in seconds",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method"})
+
 	// Document metrics
 	documentUploadsTotal = *promauto.With(registry).NewCounterVec(prometheus.CounterOpts{
 		Namespace: namespace,
@@ -183,11 +255,18 @@ func initializeMetrics() {
 		Help:      "Total number of document downloads",
 	}, []string{"tenant_id", "content_type"})
 
-	documentSearchesTotal = promauto.With(registry).NewCounter(prometheus.CounterOpts{
+	documentSearchesTotal = *promauto.With(registry).NewCounterVec(prometheus.CounterOpts{
 		Namespace: namespace,
 		Name:      "document_searches_total",
 		Help:      "Total number of document searches",
-	})
+	}, []string{"kind", "outcome"})
+
+	documentSearchDuration = *promauto.With(registry).NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "document_search_duration_seconds",
+		Help:      "Document search duration in seconds",
+		Buckets:   []float64{0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2, 5},
+	}, []string{"kind"})
 
 	documentProcessingDuration = promauto.With(registry).NewHistogram(prometheus.HistogramOpts{
 		Namespace: namespace,
@@ -203,12 +282,49 @@ func initializeMetrics() {
 		Help:      "Total number of virus detections",
 	})
 
+	scanVerdictsTotal = *promauto.With(registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "scan_verdicts_total",
+		Help:      "Total number of virus scans by verdict",
+	}, []string{"verdict"})
+
+	scanDuration = promauto.With(registry).NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "scan_duration_seconds",
+		Help:      "Virus scan duration in seconds",
+		Buckets:   []float64{0.1, 0.25, 0.5, 1, 2, 5, 10, 30, 60},
+	})
+
+	scanQueueDepth = *promauto.With(registry).NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "scan_queue_depth",
+		Help:      "Current number of scan tasks waiting in the scan queue",
+	}, []string{"priority"})
+
+	scanVerdictCacheHits = promauto.With(registry).NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "scan_verdict_cache_hits_total",
+		Help:      "Total number of uploads marked clean without scanning, as identical content was recently scanned clean",
+	})
+
 	// Storage metrics
 	storageUsageBytes = *promauto.With(registry).NewGaugeVec(prometheus.GaugeOpts{
 		Namespace: namespace,
 		Name:      "storage_usage_bytes",
 		Help:      "Current storage usage in bytes",
 	}, []string{"tenant_id", "bucket_type"})
+
+	storageFailoverReadsTotal = *promauto.With(registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "storage_failover_reads_total",
+		Help:      "Total number of document reads served by the replica region",
+	}, []string{"operation"})
+
+	storageReplicationInconsistent = *promauto.With(registry).NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "storage_replication_inconsistent_objects",
+		Help:      "Number of documents missing from or differing in the replica at the last consistency check",
+	}, []string{"state"})
 }
 
 // Shutdown stops the metrics system, closing the HTTP server if running
@@ -246,20 +362,15 @@ func Handler() http.Handler {
 	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
 }
 
-// IncHTTPRequests increments the HTTP requests counter
-func IncHTTPRequests() {
-	if !initialized {
-		return
-	}
-	httpRequestsTotal.Inc()
-}
-
-// ObserveHTTPRequestDuration records the duration of an HTTP request
-func ObserveHTTPRequestDuration(duration time.Duration) {
+// ObserveHTTPRequest records an HTTP request to the route template route that ended with status. The
+// tenant is labelled by its ID while the tenant label limit allows it.
+func ObserveHTTPRequest(method, route string, status int, tenantID string, duration time.Duration) {
 	if !initialized {
 		return
 	}
-	httpRequestDuration.Observe(duration.Seconds())
+	method, route, code := methodLabel(method), routeLabel(route), statusLabel(status)
+	httpRequestsTotal.WithLabelValues(method, route, code, tenantLabels.label(tenantID)).Inc()
+	httpRequestDuration.WithLabelValues(method, route, code).Observe(duration.Seconds())
 }
 
 // IncHTTPRequestsInFlight increments the gauge of in-flight HTTP requests
@@ -278,12 +389,36 @@ func DecHTTPRequestsInFlight() {
 	httpRequestsInFlight.Dec()
 }
 
+// IncHTTPRequestsThrottled increments the counter of requests rejected by the rate limit of a scope
+func IncHTTPRequestsThrottled(scope, route string) {
+	if !initialized {
+		return
+	}
+	httpRequestsThrottled.WithLabelValues(scope, route).Inc()
+}
+
+// IncGRPCRequests increments the counter of gRPC requests to a method that ended with a status code
+func IncGRPCRequests(method, code string) {
+	if !initialized {
+		return
+	}
+	grpcRequestsTotal.WithLabelValues(method, code).Inc()
+}
+
+// ObserveGRPCRequestDuration records the duration of a gRPC request to a method
+func ObserveGRPCRequestDuration(method string, duration time.Duration) {
+	if !initialized {
+		return
+	}
+	grpcRequestDuration.WithLabelValues(method).Observe(duration.Seconds())
+}
+
 // IncDocumentUploads increments the document uploads counter
 func IncDocumentUploads(tenantID, contentType string) {
 	if !initialized {
 		return
 	}
-	documentUploadsTotal.WithLabelValues(tenantID, contentType).Inc()
+	documentUploadsTotal.WithLabelValues(tenantLabels.label(tenantID), contentTypeLabels.label(mediaType(contentType))).Inc()
 }
 
 // IncDocumentDownloads increments the document downloads counter
@@ -291,15 +426,20 @@ func IncDocumentDownloads(tenantID, contentType string) {
 	if !initialized {
 		return
 	}
-	documentDownloadsTotal.WithLabelValues(tenantID, contentType).Inc()
+	documentDownloadsTotal.WithLabelValues(tenantLabels.label(tenantID), contentTypeLabels.label(mediaType(contentType))).Inc()
 }
 
-// IncDocumentSearches increments the document searches counter
-func IncDocumentSearches() {
+// ObserveDocumentSearch records a document search of a kind, such as content or metadata, and whether it failed
+func ObserveDocumentSearch(kind string, duration time.Duration, err error) {
 	if !initialized {
 		return
 	}
-	documentSearchesTotal.Inc()
+	outcome := "success"
+	if err != nil {
+		outcome = "error"
+	}
+	documentSearchesTotal.WithLabelValues(kind, outcome).Inc()
+	documentSearchDuration.WithLabelValues(kind).Observe(duration.Seconds())
 }
 
 // ObserveDocumentProcessingDuration records the duration of document processing
@@ -318,12 +458,56 @@ func IncVirusDetections() {
 	virusDetectionsTotal.Inc()
 }
 
+// ObserveScan records a virus scan that ended with a verdict, such as clean, infected or error
+func ObserveScan(verdict string, duration time.Duration) {
+	if !initialized {
+		return
+	}
+	scanVerdictsTotal.WithLabelValues(verdict).Inc()
+	scanDuration.Observe(duration.Seconds())
+}
+
+// SetScanQueueDepth sets the number of scan tasks waiting in the scan queue of a priority
+func SetScanQueueDepth(priority string, depth int) {
+	if !initialized {
+		return
+	}
+	scanQueueDepth.WithLabelValues(priority).Set(float64(depth))
+}
+
+// IncScanVerdictCacheHits increments the counter of uploads marked clean with a cached verdict
+func IncScanVerdictCacheHits() {
+	if !initialized {
+		return
+	}
+	scanVerdictCacheHits.Inc()
+}
+
 // SetStorageUsage sets the current storage usage in bytes
 func SetStorageUsage(tenantID, bucketType string, bytes float64) {
 	if !initialized {
 		return
 	}
-	storageUsageBytes.WithLabelValues(tenantID, bucketType).Set(bytes)
+	storageUsageBytes.WithLabelValues(tenantLabels.label(tenantID), bucketType).Set(bytes)
+}
+
+// IncStorageFailoverReads records a read of an operation, such as get or presign, served by the
+// replica region because the primary region failed
+func IncStorageFailoverReads(operation string) {
+	if !initialized {
+		return
+	}
+	storageFailoverReadsTotal.WithLabelValues(operation).Inc()
+}
+
+// SetStorageReplicationInconsistencies sets the number of documents the last consistency check
+// found missing from the replica, or differing in it
+func SetStorageReplicationInconsistencies(missing, mismatched int) {
+	if !initialized {
+		return
+	}
+	storageReplicationInconsistent.WithLabelValues("missing").Set(float64(missing))
+	storageReplicationInconsistent.WithLabelValues("mismatched").Set(float64(mismatched))
 }
 
 // RegisterCustomCounter registers a custom counter metric
@@ -7,8 +7,10 @@ package tracing
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
 
+	"../config" // For the tracing configuration of the application
 	"../logger" // For logging tracing initialization and errors
 
 	"go.opentelemetry.io/otel" // v1.11.0+
@@ -33,6 +35,9 @@ var (
 	defaultSamplingRatio = 0.1
 )
 
+// defaultServiceName is the name services are traced as when the configuration names none
+const defaultServiceName = "document-mgmt"
+
 // TracingConfig defines the configuration for the tracing system
 type TracingConfig struct {
 	// Enabled indicates whether tracing is enabled
@@ -49,19 +54,49 @@ type TracingConfig struct {
 	Endpoint string
 	// SamplingRatio is the ratio of requests to sample (0.0 to 1.0)
 	SamplingRatio float64
+	// Insecure sends spans to an OTLP collector without TLS
+	Insecure bool
 }
 
 // NewTracingConfig creates a new TracingConfig with default values
 func NewTracingConfig() TracingConfig {
 	return TracingConfig{
 		Enabled:        true,
-		ServiceName:    "document-mgmt-platform",
+		ServiceName:    defaultServiceName,
 		ServiceVersion: "1.0.0",
 		Environment:    "development",
-		ExporterType:   "jaeger",
-		Endpoint:       "http://localhost:14268/api/traces",
+		ExporterType:   "otlp",
+		Endpoint:       "localhost:4317",
 		SamplingRatio:  defaultSamplingRatio,
+		Insecure:       true,
+	}
+}
+
+// NewTracingConfigFromConfig creates the TracingConfig of a component of the platform, such as "api"
+// or "worker", from the application configuration. Each component is traced as a service of its own,
+// named after the configured service name and the component.
+func NewTracingConfigFromConfig(cfg config.TracingConfig, environment string, component string) TracingConfig {
+	tracingConfig := NewTracingConfig()
+	tracingConfig.Enabled = cfg.Enabled
+	tracingConfig.Environment = environment
+	tracingConfig.Insecure = cfg.Insecure
+
+	serviceName := cfg.ServiceName
+	if serviceName == "" {
+		serviceName = defaultServiceName
+	}
+	tracingConfig.ServiceName = serviceName + "-" + component
+
+	if cfg.Provider != "" {
+		tracingConfig.ExporterType = cfg.Provider
+	}
+	if cfg.Endpoint != "" {
+		tracingConfig.Endpoint = cfg.Endpoint
+	}
+	if cfg.SampleRate > 0 {
+		tracingConfig.SamplingRatio = cfg.SampleRate
 	}
+	return tracingConfig
 }
 
 // Init initializes the tracing system with the specified configuration
@@ -92,11 +127,12 @@ func Init(config TracingConfig) error {
 		samplingRatio = defaultSamplingRatio
 	}
 
-	// Create a trace provider with the exporter and sampling configuration
+	// Create a trace provider with the exporter and sampling configuration. Traces started by a
+	// caller keep the caller's sampling decision, so a trace is recorded by all services or none.
 	tp := sdktrace.NewTracerProvider(
 		sdktrace.WithBatcher(exporter),
 		sdktrace.WithResource(res),
-		sdktrace.WithSampler(sdktrace.TraceIDRatioBased(samplingRatio)),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(samplingRatio))),
 	)
 
 	// Set the global trace provider
@@ -144,15 +180,16 @@ func Shutdown() error {
 	return nil
 }
 
-// StartSpan starts a new span with the given name and parent context
-func StartSpan(ctx context.Context, name string) (context.Context, trace.Span) {
-	// If not initialized, return a no-op span
+// StartSpan starts a new span with the given name and parent context. Options such as
+// trace.WithSpanKind describe the span further.
+func StartSpan(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	// If not initialized, return a no-op span that does not end the span of ctx
 	if !initialized {
-		return ctx, trace.SpanFromContext(ctx)
+		return ctx, trace.SpanFromContext(context.Background())
 	}
 
 	// Start a new span with the given name and parent context
-	return tracer.Start(ctx, name)
+	return tracer.Start(ctx, name, opts...)
 }
 
 // EndSpan ends a span with optional status and attributes
@@ -261,6 +298,73 @@ func SpanIDFromContext(ctx context.Context) string {
 	return spanContext.SpanID().String()
 }
 
+// Inject returns the trace context of ctx as a map of W3C trace context fields, such as traceparent,
+// for carrying the trace in the attributes of a queued message. It is nil when ctx carries no trace
+// or tracing is disabled.
+func Inject(ctx context.Context) map[string]string {
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	if len(carrier) == 0 {
+		return nil
+	}
+	return carrier
+}
+
+// Extract returns ctx continuing the trace of a carrier created by Inject, such as the attributes of
+// a dequeued message. ctx is returned unchanged when the carrier holds no trace context.
+func Extract(ctx context.Context, carrier map[string]string) context.Context {
+	if len(carrier) == 0 {
+		return ctx
+	}
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(carrier))
+}
+
+// ExtractHTTP returns ctx continuing the trace of the W3C trace context headers of an HTTP request
+func ExtractHTTP(ctx context.Context, header http.Header) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(header))
+}
+
+// transport is an http.RoundTripper recording a client span for each request and passing the trace
+// context to the server in the request headers
+type transport struct {
+	base      http.RoundTripper
+	component string
+}
+
+// NewTransport wraps base, http.DefaultTransport when nil, to trace the requests of a client of an
+// HTTP based dependency, such as Elasticsearch. Spans are named after the component and the method.
+func NewTransport(base http.RoundTripper, component string) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{base: base, component: component}
+}
+
+// RoundTrip sends a request in a client span
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := StartSpan(req.Context(), fmt.Sprintf("%s %s", t.component, req.Method), trace.WithSpanKind(trace.SpanKindClient))
+	AddAttribute(span, "http.method", req.Method)
+	AddAttribute(span, "http.url", req.URL.Redacted())
+
+	// The request is cloned before its headers are changed, as RoundTrippers must not modify requests
+	req = req.Clone(ctx)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	resp, err := t.base.RoundTrip(req)
+	if err == nil {
+		AddAttribute(span, "http.status_code", resp.StatusCode)
+		if resp.StatusCode >= http.StatusInternalServerError {
+			err = fmt.Errorf("%s responded %s", t.component, resp.Status)
+		}
+	}
+	EndSpan(span, err)
+
+	if resp == nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // createResource creates a resource with service information
 func createResource(serviceName, serviceVersion, environment string) *resource.Resource {
 	// Create a resource with service name, version, and environment
@@ -287,14 +391,12 @@ func getHostname() string {
 func createExporter(config TracingConfig) (sdktrace.SpanExporter, error) {
 	switch config.ExporterType {
 	case "otlp":
-		// Create OTLP exporter for OpenTelemetry Collector
-		return otlptrace.New(
-			context.Background(),
-			otlptracegrpc.NewClient(
-				otlptracegrpc.WithEndpoint(config.Endpoint),
-				otlptracegrpc.WithInsecure(), // For development; use TLS in production
-			),
-		)
+		// Create OTLP exporter for OpenTelemetry Collector, sending spans over TLS unless configured insecure
+		options := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(config.Endpoint)}
+		if config.Insecure {
+			options = append(options, otlptracegrpc.WithInsecure())
+		}
+		return otlptrace.New(context.Background(), otlptracegrpc.NewClient(options...))
 	case "jaeger":
 		// Create Jaeger exporter
 		return jaeger.New(
@@ -109,4 +109,47 @@ func IsValidHashAlgorithm(algorithm string) bool {
 	default:
 		return false
 	}
-}
\ No newline at end of file
+}
+
+// IsValidHash checks if value is a hex encoded hash of the specified algorithm.
+func IsValidHash(value string, algorithm string) bool {
+	hasher, err := GetHasher(algorithm)
+	if err != nil {
+		return false
+	}
+	if len(value) != hasher.Size()*2 {
+		return false
+	}
+
+	_, err = hex.DecodeString(value)
+	return err == nil
+}
+
+// HashingReader calculates a hash of the data read through it, so content can be
+// hashed while it is streamed elsewhere without reading it twice.
+type HashingReader struct {
+	reader io.Reader
+	hasher hash.Hash
+}
+
+// NewHashingReader creates a HashingReader that hashes the data of reader using the specified algorithm.
+func NewHashingReader(reader io.Reader, algorithm string) (*HashingReader, error) {
+	hasher, err := GetHasher(algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	return &HashingReader{reader: reader, hasher: hasher}, nil
+}
+
+// Read reads from the underlying reader and adds the data read to the hash.
+func (r *HashingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.hasher.Write(p[:n])
+	return n, err
+}
+
+// Sum returns the hex encoded hash of the data read so far.
+func (r *HashingReader) Sum() string {
+	return hex.EncodeToString(r.hasher.Sum(nil))
+}
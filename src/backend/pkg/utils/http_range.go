@@ -0,0 +1,106 @@
+// Package utils provides HTTP range utility functions for the Document Management Platform.
+// This file contains utilities for serving parts of document content in response to
+// Range and If-Range request headers, supporting resumable downloads and media seeking.
+package utils
+
+import (
+	"errors"   // standard library
+	"fmt"      // standard library
+	"net/http" // standard library
+	"strconv"  // standard library
+	"strings"  // standard library
+	"time"     // standard library
+)
+
+// ErrRangeNotSatisfiable is returned when a requested range lies outside of the content
+var ErrRangeNotSatisfiable = errors.New("requested range not satisfiable")
+
+// ByteRange is a range of Length bytes starting at offset Start
+type ByteRange struct {
+	Start  int64
+	Length int64
+}
+
+// ContentRange returns the Content-Range header value of the range within content of the given size
+func (r ByteRange) ContentRange(size int64) string {
+	return fmt.Sprintf("bytes %d-%d/%d", r.Start, r.Start+r.Length-1, size)
+}
+
+// ParseRange parses a Range header value against content of the given size. It returns nil for
+// an empty or unsupported header, which means the whole content should be served. Only single
+// ranges are supported, so a request for several ranges is answered with the whole content.
+func ParseRange(header string, size int64) (*ByteRange, error) {
+	if header == "" || !strings.HasPrefix(header, "bytes=") {
+		return nil, nil
+	}
+	spec := strings.TrimSpace(strings.TrimPrefix(header, "bytes="))
+	if strings.Contains(spec, ",") {
+		return nil, nil
+	}
+
+	dash := strings.Index(spec, "-")
+	if dash < 0 {
+		return nil, nil
+	}
+	first, last := strings.TrimSpace(spec[:dash]), strings.TrimSpace(spec[dash+1:])
+
+	// A suffix range "-N" selects the last N bytes
+	if first == "" {
+		n, err := strconv.ParseInt(last, 10, 64)
+		if err != nil || n < 0 {
+			return nil, nil
+		}
+		if n == 0 || size == 0 {
+			return nil, ErrRangeNotSatisfiable
+		}
+		if n > size {
+			n = size
+		}
+		return &ByteRange{Start: size - n, Length: n}, nil
+	}
+
+	start, err := strconv.ParseInt(first, 10, 64)
+	if err != nil || start < 0 {
+		return nil, nil
+	}
+	if start >= size {
+		return nil, ErrRangeNotSatisfiable
+	}
+
+	// An open range "N-" runs to the end of the content
+	end := size - 1
+	if last != "" {
+		end, err = strconv.ParseInt(last, 10, 64)
+		if err != nil || end < start {
+			return nil, nil
+		}
+		if end >= size {
+			end = size - 1
+		}
+	}
+
+	return &ByteRange{Start: start, Length: end - start + 1}, nil
+}
+
+// IfRangeMatches checks an If-Range header value against the current entity tag and modification
+// time of the content. An empty header always matches; otherwise a range may only be served when
+// the client's copy is still current, and the whole content must be sent instead.
+func IfRangeMatches(ifRange string, etag string, lastModified time.Time) bool {
+	if ifRange == "" {
+		return true
+	}
+
+	// Entity tags use strong comparison, so weak tags never match
+	if strings.HasPrefix(ifRange, "W/") {
+		return false
+	}
+	if strings.HasPrefix(ifRange, `"`) {
+		return etag != "" && ifRange == etag
+	}
+
+	since, err := http.ParseTime(ifRange)
+	if err != nil {
+		return false
+	}
+	return lastModified.Truncate(time.Second).Equal(since)
+}
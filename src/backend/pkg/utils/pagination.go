@@ -2,8 +2,13 @@
 package utils
 
 import (
-	"math" // standard library - For mathematical operations in pagination calculations
-	"strconv" // standard library - For string to integer conversions in pagination parameters
+	"encoding/base64" // standard library - For encoding continuation tokens
+	"encoding/json"   // standard library - For the content of continuation tokens
+	"errors"          // standard library - For sort parameter parsing errors
+	"fmt"             // standard library - For sort parameter parsing errors
+	"math"            // standard library - For mathematical operations in pagination calculations
+	"strconv"         // standard library - For string to integer conversions in pagination parameters
+	"strings"         // standard library - For parsing and formatting sort parameters
 )
 
 // Default pagination constants
@@ -16,10 +21,68 @@ const (
 	MaxPageSize = 100
 )
 
+// Sort directions
+const (
+	SortAscending  = "asc"
+	SortDescending = "desc"
+)
+
+// Sort fields of document and folder listings and searches. Folders have no size, and only
+// searches rank by relevance.
+const (
+	SortFieldRelevance = "relevance"
+	SortFieldName      = "name"
+	SortFieldCreatedAt = "created_at"
+	SortFieldUpdatedAt = "updated_at"
+	SortFieldSize      = "size"
+)
+
+// MaxSortKeys is the maximum number of keys results can be sorted by
+const MaxSortKeys = 3
+
+// ErrSortTooManyKeys is returned when parsing more than MaxSortKeys sort keys
+var ErrSortTooManyKeys = fmt.Errorf("at most %d sort keys are allowed", MaxSortKeys)
+
+// ErrInvalidCursor is returned when a continuation token is malformed or was issued for a listing
+// sorted differently
+var ErrInvalidCursor = errors.New("invalid pagination cursor")
+
+// SortKey is a field results are sorted by, and the direction they are sorted in.
+type SortKey struct {
+	Field     string
+	Direction string
+}
+
+// Descending returns whether the key sorts from the highest value to the lowest.
+func (k SortKey) Descending() bool {
+	return k.Direction == SortDescending
+}
+
 // Pagination represents pagination parameters for requests.
 type Pagination struct {
 	Page     int
 	PageSize int
+	// Sort holds the keys results are sorted by, the first key first. Results are in the default
+	// order of the listing or search when it is empty.
+	Sort []SortKey
+	// Cursor is the continuation token of the previous page, its NextCursor. Listings return the
+	// results following that page, seeking past it instead of skipping Page-1 pages, when it is set.
+	Cursor string
+	// NextCursor is set by listings to the continuation token of the page following the results,
+	// and left empty when the results are the last page.
+	NextCursor string
+}
+
+// WithSort sets the keys results are sorted by and returns the pagination.
+func (p *Pagination) WithSort(sort []SortKey) *Pagination {
+	p.Sort = sort
+	return p
+}
+
+// WithCursor sets the continuation token of the previous page and returns the pagination.
+func (p *Pagination) WithCursor(cursor string) *Pagination {
+	p.Cursor = cursor
+	return p
 }
 
 // GetOffset calculates the offset for database queries based on page and page size.
@@ -40,6 +103,9 @@ type PageInfo struct {
 	TotalItems  int64 `json:"totalItems"`
 	HasNext     bool  `json:"hasNext"`
 	HasPrevious bool  `json:"hasPrevious"`
+	// NextCursor is the continuation token of the next page, to pass as the cursor of the request
+	// for it; keyset pages stay fast however deep they are, unlike page numbers.
+	NextCursor string `json:"nextCursor,omitempty"`
 }
 
 // PaginatedResult is a generic container for paginated results of any type.
@@ -92,20 +158,150 @@ func ParsePaginationFromStrings(pageStr, pageSizeStr string) *Pagination {
 	return NewPagination(page, pageSize)
 }
 
+// ParseSort parses sort keys from a comma-separated list of fields, each optionally followed by
+// :asc or :desc, such as "name,created_at:desc". Fields without a direction are sorted in
+// defaultDirection, ascending when it is empty. Which fields can be sorted by is left to callers.
+func ParseSort(value string, defaultDirection string) ([]SortKey, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+	if defaultDirection == "" {
+		defaultDirection = SortAscending
+	}
+	if defaultDirection != SortAscending && defaultDirection != SortDescending {
+		return nil, errors.New("sort order must be 'asc' or 'desc'")
+	}
+
+	parts := strings.Split(value, ",")
+	if len(parts) > MaxSortKeys {
+		return nil, ErrSortTooManyKeys
+	}
+
+	keys := make([]SortKey, 0, len(parts))
+	seen := make(map[string]bool, len(parts))
+	for _, part := range parts {
+		field, direction, found := strings.Cut(strings.TrimSpace(part), ":")
+		field = strings.TrimSpace(field)
+		if field == "" {
+			return nil, errors.New("sort field cannot be empty")
+		}
+		if seen[field] {
+			return nil, fmt.Errorf("results cannot be sorted by %s more than once", field)
+		}
+		seen[field] = true
+
+		direction = strings.ToLower(strings.TrimSpace(direction))
+		if !found {
+			direction = defaultDirection
+		} else if direction != SortAscending && direction != SortDescending {
+			return nil, fmt.Errorf("sort direction of %s must be 'asc' or 'desc'", field)
+		}
+		keys = append(keys, SortKey{Field: field, Direction: direction})
+	}
+	return keys, nil
+}
+
+// FormatSort formats sort keys as ParseSort parses them, with every direction explicit.
+func FormatSort(sort []SortKey) string {
+	parts := make([]string, len(sort))
+	for i, key := range sort {
+		parts[i] = key.Field + ":" + key.Direction
+	}
+	return strings.Join(parts, ",")
+}
+
+// cursor is the content of a continuation token: the sort of the listing it was issued for, and
+// the values of the last result of a page for each of the listing's sort keys.
+type cursor struct {
+	Sort   string            `json:"s"`
+	Values []json.RawMessage `json:"v"`
+}
+
+// EncodeCursor returns the continuation token of the results following the result whose values
+// for the listing's sort keys are values. Tokens are opaque to clients.
+func EncodeCursor(sort []SortKey, values ...interface{}) string {
+	c := cursor{Sort: FormatSort(sort), Values: make([]json.RawMessage, len(values))}
+	for i, value := range values {
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return ""
+		}
+		c.Values[i] = raw
+	}
+	token, err := json.Marshal(c)
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(token)
+}
+
+// DecodeCursor decodes the values of a continuation token into dest, which holds a pointer for
+// each value EncodeCursor was called with. It returns ErrInvalidCursor when the token is malformed
+// or was issued for a listing with another sort.
+func DecodeCursor(token string, sort []SortKey, dest ...interface{}) error {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return ErrInvalidCursor
+	}
+	var c cursor
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return ErrInvalidCursor
+	}
+	if c.Sort != FormatSort(sort) || len(c.Values) != len(dest) {
+		return ErrInvalidCursor
+	}
+	for i, value := range c.Values {
+		if err := json.Unmarshal(value, dest[i]); err != nil {
+			return ErrInvalidCursor
+		}
+	}
+	return nil
+}
+
+// CursorOffset returns the offset of the first result of the page, following the pagination's
+// cursor when it has one, for listings that cannot seek whose continuation tokens hold offsets.
+func (p *Pagination) CursorOffset() (int, error) {
+	if p.Cursor == "" {
+		return p.GetOffset(), nil
+	}
+	var offset int
+	if err := DecodeCursor(p.Cursor, p.Sort, &offset); err != nil || offset < 0 {
+		return 0, ErrInvalidCursor
+	}
+	return offset, nil
+}
+
+// SetNextOffset sets the next cursor of a listing whose continuation tokens hold offsets to the
+// offset of the next page, or clears it when there are no more than next results.
+func (p *Pagination) SetNextOffset(next int, total int64) {
+	p.NextCursor = ""
+	if int64(next) < total {
+		p.NextCursor = EncodeCursor(p.Sort, next)
+	}
+}
+
 // NewPageInfo creates a new PageInfo instance with pagination metadata.
 func NewPageInfo(pagination *Pagination, totalItems int64) PageInfo {
 	// Calculate total pages based on totalItems and pagination.PageSize
 	totalPages := int(math.Ceil(float64(totalItems) / float64(pagination.PageSize)))
 	
 	// Create a new PageInfo instance with the pagination parameters and calculated values
-	return PageInfo{
+	pageInfo := PageInfo{
 		Page:        pagination.Page,
 		PageSize:    pagination.PageSize,
 		TotalPages:  totalPages,
 		TotalItems:  totalItems,
 		HasNext:     pagination.Page < totalPages,
 		HasPrevious: pagination.Page > 1,
+		NextCursor:  pagination.NextCursor,
+	}
+
+	// A page following a cursor has no page number; whether another follows is told by its cursor
+	if pagination.Cursor != "" {
+		pageInfo.HasNext = pagination.NextCursor != ""
+		pageInfo.HasPrevious = true
 	}
+	return pageInfo
 }
 
 // NewPaginatedResult creates a new PaginatedResult instance with items and pagination information.
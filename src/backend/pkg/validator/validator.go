@@ -16,7 +16,7 @@ import (
 
 var (
 	// validate is a singleton instance of the validator
-	validate = validator.New()
+	validate = newValidate()
 
 	// emailRegex is a compiled regular expression for validating email addresses
 	emailRegex = regexp.MustCompile(`^[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}$`)
@@ -25,8 +25,33 @@ var (
 	uuidRegex = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
 )
 
-// Validate validates a struct using struct tags and returns validation errors.
-// It supports all the validation tags provided by github.com/go-playground/validator/v10.
+// newValidate creates the validator checking the binding tags of request DTOs, the tags Gin
+// checks when binding requests, and naming fields as clients send them
+func newValidate() *validator.Validate {
+	v := validator.New()
+	v.SetTagName("binding")
+	v.RegisterTagNameFunc(requestFieldName)
+	return v
+}
+
+// requestFieldName returns the name of a field in requests: its JSON name, otherwise its form
+// name, otherwise its Go name
+func requestFieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "form"} {
+		name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+// Validate validates a struct using its binding tags and returns a validation error reporting
+// every field violation, whose fields are named as in requests. It supports all the validation
+// tags provided by github.com/go-playground/validator/v10.
 func Validate(s interface{}) error {
 	val := reflect.ValueOf(s)
 	if val.Kind() == reflect.Ptr {
@@ -39,12 +64,120 @@ func Validate(s interface{}) error {
 
 	err := validate.Struct(s)
 	if err != nil {
+		if violations := FieldViolations(err); len(violations) > 0 {
+			return errors.NewFieldValidationError(violations)
+		}
 		return errors.NewValidationError(formatValidationErrors(err))
 	}
 	
 	return nil
 }
 
+// FieldViolations converts the errors of the validator library, such as those Gin returns when
+// binding requests, to field violations. It returns nil for other errors.
+func FieldViolations(err error) []errors.FieldViolation {
+	validationErrors, ok := err.(validator.ValidationErrors)
+	if !ok {
+		return nil
+	}
+
+	violations := make([]errors.FieldViolation, 0, len(validationErrors))
+	for _, e := range validationErrors {
+		// The namespace starts with the name of the validated struct, which is not part of requests
+		field := e.Namespace()
+		if _, path, found := strings.Cut(field, "."); found {
+			field = path
+		}
+		violations = append(violations, errors.FieldViolation{
+			Field:   field,
+			Rule:    e.Tag(),
+			Message: violationMessage(field, e.Tag(), e.Param()),
+		})
+	}
+	return violations
+}
+
+// StructValidator validates the structs Gin binds requests to as Validate does. Set it as Gin's
+// binding.Validator so that binding reports every field violation, named as in requests.
+type StructValidator struct{}
+
+// ValidateStruct validates a struct, a pointer to a struct, or each element of a slice or array.
+// Other values are not validated.
+func (StructValidator) ValidateStruct(obj interface{}) error {
+	val := reflect.ValueOf(obj)
+	for val.Kind() == reflect.Ptr && !val.IsNil() {
+		val = val.Elem()
+	}
+
+	switch val.Kind() {
+	case reflect.Struct:
+		return Validate(obj)
+	case reflect.Slice, reflect.Array:
+		var violations []errors.FieldViolation
+		for i := 0; i < val.Len(); i++ {
+			err := StructValidator{}.ValidateStruct(val.Index(i).Interface())
+			for _, violation := range errors.GetViolations(err) {
+				violation.Field = fmt.Sprintf("[%d].%s", i, violation.Field)
+				violations = append(violations, violation)
+			}
+		}
+		if len(violations) > 0 {
+			return errors.NewFieldValidationError(violations)
+		}
+	}
+	return nil
+}
+
+// Engine returns the validator of the validator library Validate uses
+func (StructValidator) Engine() interface{} {
+	return validate
+}
+
+// Violations collects the field violations of a request, so that checks beyond binding tags are
+// reported together instead of stopping at the first one:
+//
+//	var violations validator.Violations
+//	violations.Merge(validator.Validate(request))
+//	violations.Check("name", "max", validator.ValidateMaxLength(request.Name, 255, "name"))
+//	return violations.Err()
+type Violations struct {
+	violations []errors.FieldViolation
+}
+
+// Add records that a field breaks a rule
+func (v *Violations) Add(field, rule, message string) {
+	v.violations = append(v.violations, errors.FieldViolation{Field: field, Rule: rule, Message: message})
+}
+
+// Check records the error of a check of a field, if any, as a violation of the rule
+func (v *Violations) Check(field, rule string, err error) {
+	if err == nil {
+		return
+	}
+	v.Add(field, rule, err.Error())
+}
+
+// Merge records the violations reported by err, such as an error of Validate. Errors reporting no
+// violation are recorded as a violation of the request as a whole.
+func (v *Violations) Merge(err error) {
+	if err == nil {
+		return
+	}
+	if violations := errors.GetViolations(err); len(violations) > 0 {
+		v.violations = append(v.violations, violations...)
+		return
+	}
+	v.Add("", "valid", err.Error())
+}
+
+// Err returns a validation error reporting the recorded violations, nil when there are none
+func (v *Violations) Err() error {
+	if len(v.violations) == 0 {
+		return nil
+	}
+	return errors.NewFieldValidationError(v.violations)
+}
+
 // ValidateField validates a single field against a specific validation tag.
 // For example, ValidateField("test@example.com", "email") or ValidateField(42, "gte=0,lte=100").
 func ValidateField(field interface{}, tag string) error {
@@ -187,35 +320,32 @@ func formatValidationErrors(err error) string {
 	errorMessages := make([]string, 0, len(validationErrors))
 	
 	for _, e := range validationErrors {
-		fieldName := e.Field()
-		tag := e.Tag()
-		param := e.Param()
-		
-		var errorMessage string
-		
-		switch tag {
-		case "required":
-			errorMessage = fmt.Sprintf("%s is required", fieldName)
-		case "email":
-			errorMessage = fmt.Sprintf("%s must be a valid email address", fieldName)
-		case "min":
-			errorMessage = fmt.Sprintf("%s must be at least %s characters long", fieldName, param)
-		case "max":
-			errorMessage = fmt.Sprintf("%s must not exceed %s characters", fieldName, param)
-		case "gte":
-			errorMessage = fmt.Sprintf("%s must be greater than or equal to %s", fieldName, param)
-		case "lte":
-			errorMessage = fmt.Sprintf("%s must be less than or equal to %s", fieldName, param)
-		case "oneof":
-			errorMessage = fmt.Sprintf("%s must be one of: %s", fieldName, param)
-		case "uuid":
-			errorMessage = fmt.Sprintf("%s must be a valid UUID", fieldName)
-		default:
-			errorMessage = fmt.Sprintf("%s failed validation for tag %s: %s", fieldName, tag, param)
-		}
-		
-		errorMessages = append(errorMessages, errorMessage)
+		errorMessages = append(errorMessages, violationMessage(e.Field(), e.Tag(), e.Param()))
 	}
 	
 	return strings.Join(errorMessages, "; ")
-}
\ No newline at end of file
+}
+
+// violationMessage returns the user-friendly message of a field breaking a validation tag
+func violationMessage(fieldName, tag, param string) string {
+	switch tag {
+	case "required":
+		return fmt.Sprintf("%s is required", fieldName)
+	case "email":
+		return fmt.Sprintf("%s must be a valid email address", fieldName)
+	case "min":
+		return fmt.Sprintf("%s must be at least %s characters long", fieldName, param)
+	case "max":
+		return fmt.Sprintf("%s must not exceed %s characters", fieldName, param)
+	case "gte":
+		return fmt.Sprintf("%s must be greater than or equal to %s", fieldName, param)
+	case "lte":
+		return fmt.Sprintf("%s must be less than or equal to %s", fieldName, param)
+	case "oneof":
+		return fmt.Sprintf("%s must be one of: %s", fieldName, param)
+	case "uuid":
+		return fmt.Sprintf("%s must be a valid UUID", fieldName)
+	default:
+		return fmt.Sprintf("%s failed validation for tag %s: %s", fieldName, tag, param)
+	}
+}
@@ -0,0 +1,82 @@
+// Package validator provides tests for reporting the field violations of requests
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"  // v1.8.0+
+	"github.com/stretchr/testify/require" // v1.8.0+
+
+	"../errors"
+)
+
+// invitationRequest is a request DTO with binding tags
+type invitationRequest struct {
+	Email        string           `json:"email" binding:"required,email"`
+	ResourceType string           `json:"resource_type" binding:"required,oneof=document folder"`
+	Note         string           `json:"note" binding:"max=10"`
+	Page         int              `form:"page" binding:"gte=0"`
+	Recipients   []recipientEntry `json:"recipients" binding:"dive"`
+}
+
+// recipientEntry is a nested request DTO
+type recipientEntry struct {
+	Name string `json:"name" binding:"required"`
+}
+
+func TestValidate_ReportsEveryViolation(t *testing.T) {
+	err := Validate(&invitationRequest{
+		Email:      "not-an-email",
+		Note:       "far too long a note",
+		Page:       -1,
+		Recipients: []recipientEntry{{Name: "Ana"}, {}},
+	})
+	require.Error(t, err)
+
+	assert.True(t, errors.IsValidationError(err))
+	assert.Equal(t, errors.CodeValidationFailed, errors.GetCode(err))
+	assert.Equal(t, []errors.FieldViolation{
+		{Field: "email", Rule: "email", Message: "email must be a valid email address"},
+		{Field: "resource_type", Rule: "required", Message: "resource_type is required"},
+		{Field: "note", Rule: "max", Message: "note must not exceed 10 characters"},
+		{Field: "page", Rule: "gte", Message: "page must be greater than or equal to 0"},
+		{Field: "recipients[1].name", Rule: "required", Message: "recipients[1].name is required"},
+	}, errors.GetViolations(err))
+}
+
+func TestValidate_Valid(t *testing.T) {
+	assert.NoError(t, Validate(invitationRequest{Email: "ana@example.com", ResourceType: "folder"}))
+}
+
+func TestStructValidator(t *testing.T) {
+	validator := StructValidator{}
+
+	err := validator.ValidateStruct(&[]recipientEntry{{Name: "Ana"}, {}})
+	require.Error(t, err)
+	assert.Equal(t, []errors.FieldViolation{
+		{Field: "[1].name", Rule: "required", Message: "name is required"},
+	}, errors.GetViolations(err))
+
+	// Values other than structs are not validated
+	assert.NoError(t, validator.ValidateStruct(map[string]string{}))
+	assert.NoError(t, validator.ValidateStruct(nil))
+}
+
+func TestViolations(t *testing.T) {
+	var violations Violations
+	assert.NoError(t, violations.Err())
+
+	violations.Merge(Validate(&invitationRequest{Email: "ana@example.com"}))
+	violations.Check("note", "characters", nil)
+	violations.Check("note", "max", ValidateMaxLength("far too long a note", 10, "note"))
+	violations.Add("recipients", "required", "at least one recipient is required")
+
+	err := violations.Err()
+	require.Error(t, err)
+	assert.Equal(t, []errors.FieldViolation{
+		{Field: "resource_type", Rule: "required", Message: "resource_type is required"},
+		{Field: "note", Rule: "max", Message: "note must not exceed 10 characters"},
+		{Field: "recipients", Rule: "required", Message: "at least one recipient is required"},
+	}, errors.GetViolations(err))
+	assert.Equal(t, "resource_type is required; note must not exceed 10 characters; at least one recipient is required", err.Error())
+}
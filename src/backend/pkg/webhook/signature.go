@@ -0,0 +1,124 @@
+// Package webhook provides helpers for signing webhook payloads and verifying the
+// X-Signature header sent with every webhook delivery. It has no dependencies on the
+// rest of the platform so integrators can import it to authenticate incoming calls.
+package webhook
+
+import (
+	"crypto/hmac"   // standard library
+	"crypto/sha256" // standard library
+	"encoding/hex"  // standard library
+	"errors"        // standard library
+	"fmt"           // standard library
+	"strconv"       // standard library
+	"strings"       // standard library
+	"time"          // standard library
+)
+
+const (
+	// SignatureHeader is the HTTP header that carries the delivery signature
+	SignatureHeader = "X-Signature"
+
+	// SignatureVersion identifies the signing scheme (HMAC-SHA256 over "timestamp.payload")
+	SignatureVersion = "v1"
+
+	// DefaultTolerance is the maximum accepted age of a signature timestamp
+	DefaultTolerance = 5 * time.Minute
+)
+
+// Verification errors
+var (
+	ErrMissingSignature       = errors.New("webhook signature header is missing")
+	ErrInvalidSignatureHeader = errors.New("webhook signature header is malformed")
+	ErrSignatureExpired       = errors.New("webhook signature timestamp is outside the tolerance window")
+	ErrSignatureMismatch      = errors.New("webhook signature does not match payload")
+)
+
+// ComputeSignature returns the hex-encoded HMAC-SHA256 of "timestamp.payload" using the secret
+func ComputeSignature(payload []byte, secret string, timestamp time.Time) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// BuildSignatureHeader builds the X-Signature header value for a payload.
+// One signature is emitted per secret so receivers keep working while a secret is being rotated.
+// The resulting format is "t=<unix timestamp>,v1=<signature>[,v1=<signature>...]".
+func BuildSignatureHeader(payload []byte, timestamp time.Time, secrets ...string) string {
+	parts := []string{"t=" + strconv.FormatInt(timestamp.Unix(), 10)}
+	for _, secret := range secrets {
+		if secret == "" {
+			continue
+		}
+		parts = append(parts, SignatureVersion+"="+ComputeSignature(payload, secret, timestamp))
+	}
+	return strings.Join(parts, ",")
+}
+
+// VerifySignature checks that the X-Signature header value was produced for the payload with the secret
+// and that its timestamp is within the tolerance window. A tolerance of zero disables the age check.
+func VerifySignature(payload []byte, header string, secret string, tolerance time.Duration) error {
+	return verifySignatureAt(payload, header, secret, tolerance, time.Now())
+}
+
+// verifySignatureAt is VerifySignature with an explicit clock, used for testing
+func verifySignatureAt(payload []byte, header string, secret string, tolerance time.Duration, now time.Time) error {
+	if strings.TrimSpace(header) == "" {
+		return ErrMissingSignature
+	}
+
+	timestamp, signatures, err := parseSignatureHeader(header)
+	if err != nil {
+		return err
+	}
+
+	if tolerance > 0 {
+		age := now.Sub(timestamp)
+		if age < 0 {
+			age = -age
+		}
+		if age > tolerance {
+			return ErrSignatureExpired
+		}
+	}
+
+	expected := []byte(ComputeSignature(payload, secret, timestamp))
+	for _, signature := range signatures {
+		if hmac.Equal(expected, []byte(signature)) {
+			return nil
+		}
+	}
+
+	return ErrSignatureMismatch
+}
+
+// parseSignatureHeader extracts the timestamp and the v1 signatures from a header value
+func parseSignatureHeader(header string) (time.Time, []string, error) {
+	var timestamp time.Time
+	var signatures []string
+
+	for _, part := range strings.Split(header, ",") {
+		kv := strings.SplitN(strings.TrimSpace(part), "=", 2)
+		if len(kv) != 2 {
+			return time.Time{}, nil, ErrInvalidSignatureHeader
+		}
+
+		switch kv[0] {
+		case "t":
+			unix, err := strconv.ParseInt(kv[1], 10, 64)
+			if err != nil {
+				return time.Time{}, nil, fmt.Errorf("%w: invalid timestamp", ErrInvalidSignatureHeader)
+			}
+			timestamp = time.Unix(unix, 0)
+		case SignatureVersion:
+			signatures = append(signatures, kv[1])
+		}
+	}
+
+	if timestamp.IsZero() || len(signatures) == 0 {
+		return time.Time{}, nil, ErrInvalidSignatureHeader
+	}
+
+	return timestamp, signatures, nil
+}
@@ -0,0 +1,56 @@
+// Package webhook provides tests for the webhook signature helpers
+package webhook
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert" // v1.8.0+
+)
+
+// TestVerifySignature tests signature verification against headers built by BuildSignatureHeader
+func TestVerifySignature(t *testing.T) {
+	payload := []byte(`{"documentID":"doc-123"}`)
+	secret := "whsec_test"
+	now := time.Unix(1700000000, 0)
+
+	// Test a valid header
+	header := BuildSignatureHeader(payload, now, secret)
+	assert.NoError(t, verifySignatureAt(payload, header, secret, DefaultTolerance, now))
+
+	// Test a tampered payload
+	err := verifySignatureAt([]byte(`{"documentID":"doc-999"}`), header, secret, DefaultTolerance, now)
+	assert.ErrorIs(t, err, ErrSignatureMismatch)
+
+	// Test a wrong secret
+	err = verifySignatureAt(payload, header, "whsec_other", DefaultTolerance, now)
+	assert.ErrorIs(t, err, ErrSignatureMismatch)
+
+	// Test an expired timestamp
+	err = verifySignatureAt(payload, header, secret, DefaultTolerance, now.Add(10*time.Minute))
+	assert.ErrorIs(t, err, ErrSignatureExpired)
+
+	// Test that a zero tolerance disables the age check
+	assert.NoError(t, verifySignatureAt(payload, header, secret, 0, now.Add(24*time.Hour)))
+}
+
+// TestVerifySignature_RotatedSecrets tests that either secret verifies during rotation
+func TestVerifySignature_RotatedSecrets(t *testing.T) {
+	payload := []byte(`{"folderID":"folder-123"}`)
+	now := time.Unix(1700000000, 0)
+
+	header := BuildSignatureHeader(payload, now, "whsec_new", "whsec_old")
+	assert.NoError(t, verifySignatureAt(payload, header, "whsec_new", DefaultTolerance, now))
+	assert.NoError(t, verifySignatureAt(payload, header, "whsec_old", DefaultTolerance, now))
+}
+
+// TestVerifySignature_MalformedHeader tests rejection of missing and malformed headers
+func TestVerifySignature_MalformedHeader(t *testing.T) {
+	payload := []byte(`{}`)
+	now := time.Unix(1700000000, 0)
+
+	assert.ErrorIs(t, verifySignatureAt(payload, "", "secret", DefaultTolerance, now), ErrMissingSignature)
+	assert.ErrorIs(t, verifySignatureAt(payload, "garbage", "secret", DefaultTolerance, now), ErrInvalidSignatureHeader)
+	assert.ErrorIs(t, verifySignatureAt(payload, "t=abc,v1=00", "secret", DefaultTolerance, now), ErrInvalidSignatureHeader)
+	assert.ErrorIs(t, verifySignatureAt(payload, "t=1700000000", "secret", DefaultTolerance, now), ErrInvalidSignatureHeader)
+}
@@ -2,8 +2,9 @@
 set -e  # Exit immediately if a command exits with a non-zero status
 
 # Constants
-MIGRATIONS_DIR="src/backend/infrastructure/persistence/postgres/migrations"
-CONFIG_FILE="${CONFIG_FILE:-config/default.yml}"
+BACKEND_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")/.." && pwd)"
+MIGRATIONS_DIR="${BACKEND_DIR}/infrastructure/persistence/postgres/migrations"
+MIGRATE_CMD="${MIGRATE_CMD:-go run .}"
 ENV="${ENV:-development}"
 VERBOSE="${VERBOSE:-false}"
 
@@ -16,16 +17,18 @@ print_usage() {
     echo "Commands:"
     echo "  up [steps]        Apply all or a specific number of pending migrations"
     echo "  down <steps>      Rollback a specific number of migrations"
+    echo "  status            List the migrations and whether each is applied"
+    echo "  verify            Check applied migrations against their checksums"
+    echo "  baseline <ver>    Record migrations up to a version as applied without running them"
     echo "  create <name>     Create a new migration with the given name"
     echo ""
     echo "Options:"
     echo "  -h, --help        Show this help message"
-    echo "  -c, --config      Specify the config file (default: $CONFIG_FILE)"
     echo "  -e, --env         Specify the environment (default: $ENV)"
     echo "  -v, --verbose     Enable verbose output"
     echo ""
     echo "Environment Variables:"
-    echo "  CONFIG_FILE       Config file path"
+    echo "  MIGRATE_CMD       Command running the service binary (default: $MIGRATE_CMD)"
     echo "  ENV               Environment (development, staging, production)"
     echo "  VERBOSE           Enable verbose output (true/false)"
     echo ""
@@ -33,13 +36,14 @@ print_usage() {
     echo "  $(basename "$0") up                 # Apply all pending migrations"
     echo "  $(basename "$0") up 1               # Apply the next pending migration"
     echo "  $(basename "$0") down 1             # Rollback the most recent migration"
+    echo "  $(basename "$0") status             # Show which migrations are applied"
     echo "  $(basename "$0") create add_users   # Create migration files named add_users"
 }
 
 # Parse arguments
 parse_args() {
     local TEMP
-    TEMP=$(getopt -o "hc:e:v" --long "help,config:,env:,verbose" -n "$(basename "$0")" -- "$@")
+    TEMP=$(getopt -o "he:v" --long "help,env:,verbose" -n "$(basename "$0")" -- "$@")
     
     if [ $? -ne 0 ]; then
         echo "Error: Invalid arguments" >&2
@@ -55,10 +59,6 @@ parse_args() {
                 print_usage
                 exit 0
                 ;;
-            -c|--config)
-                CONFIG_FILE="$2"
-                shift 2
-                ;;
             -e|--env)
                 ENV="$2"
                 shift 2
@@ -82,124 +82,37 @@ parse_args() {
     echo "$@"
 }
 
-# Load database configuration from config file
-load_config() {
-    if [ ! -f "$CONFIG_FILE" ]; then
-        log "error" "Config file not found: $CONFIG_FILE"
-        exit 1
-    fi
-    
-    # Extract database configuration using a simple grep and awk approach
-    # In a production environment, consider using a proper YAML parser
-    local host=$(grep -A 10 "Database:" "$CONFIG_FILE" | grep "Host:" | awk '{print $2}')
-    local port=$(grep -A 10 "Database:" "$CONFIG_FILE" | grep "Port:" | awk '{print $2}')
-    local user=$(grep -A 10 "Database:" "$CONFIG_FILE" | grep "User:" | awk '{print $2}')
-    local password=$(grep -A 10 "Database:" "$CONFIG_FILE" | grep "Password:" | awk '{print $2}')
-    local dbname=$(grep -A 10 "Database:" "$CONFIG_FILE" | grep "DBName:" | awk '{print $2}')
-    local sslmode=$(grep -A 10 "Database:" "$CONFIG_FILE" | grep "SSLMode:" | awk '{print $2}')
-    
-    # Set default values if not found
-    host=${host:-localhost}
-    port=${port:-5432}
-    user=${user:-postgres}
-    password=${password:-postgres}
-    dbname=${dbname:-documentdb}
-    sslmode=${sslmode:-disable}
-    
-    # Return database configuration as a space-separated string
-    echo "$host $port $user $password $dbname $sslmode"
-}
-
-# Build PostgreSQL connection string
-build_dsn() {
-    local config=($1)
-    local host=${config[0]}
-    local port=${config[1]}
-    local user=${config[2]}
-    local password=${config[3]}
-    local dbname=${config[4]}
-    local sslmode=${config[5]}
-    
-    # Format the connection string
-    echo "postgres://$user:$password@$host:$port/$dbname?sslmode=$sslmode"
-}
-
-# Check if migrate is installed
-check_migrate() {
-    if ! command -v migrate &> /dev/null; then
-        log "error" "migrate command not found. Please install golang-migrate:"
-        log "error" "  go install -tags 'postgres' github.com/golang-migrate/migrate/v4/cmd/migrate@latest"
-        return 1
-    fi
-    return 0
+# Run the migrate service of the application with the given arguments. The migrations are built
+# into the binary, which records each one with its checksum in schema_migration_history.
+run_migrate() {
+    log "debug" "Running: $MIGRATE_CMD -service=migrate $*"
+    (cd "$BACKEND_DIR" && ENV="$ENV" $MIGRATE_CMD -service=migrate "$@")
 }
 
 # Apply pending migrations
 migrate_up() {
     local steps=$1
-    
-    # Check if migrate is installed
-    check_migrate || return 1
-    
-    log "info" "Loading database configuration..."
-    local db_config=$(load_config)
-    local dsn=$(build_dsn "$db_config")
-    
-    log "info" "Applying migrations..."
-    
+
     if [ -n "$steps" ]; then
         log "info" "Applying $steps migration(s)..."
-        migrate -path "$MIGRATIONS_DIR" -database "$dsn" up "$steps"
+        run_migrate up "$steps"
     else
         log "info" "Applying all pending migrations..."
-        migrate -path "$MIGRATIONS_DIR" -database "$dsn" up
-    fi
-    
-    local status=$?
-    if [ $status -eq 0 ]; then
-        log "info" "Migrations applied successfully"
-        return 0
-    elif [ $status -eq 1 ]; then
-        # No change (which migrate treats as an error, but we don't)
-        log "info" "No migrations to apply"
-        return 0
-    else
-        log "error" "Failed to apply migrations (status: $status)"
-        return 1
+        run_migrate up
     fi
 }
 
 # Rollback applied migrations
 migrate_down() {
     local steps=$1
-    
+
     if [ -z "$steps" ]; then
         log "error" "Number of migrations to rollback is required"
         return 1
     fi
-    
-    # Check if migrate is installed
-    check_migrate || return 1
-    
-    log "info" "Loading database configuration..."
-    local db_config=$(load_config)
-    local dsn=$(build_dsn "$db_config")
-    
+
     log "info" "Rolling back $steps migration(s)..."
-    migrate -path "$MIGRATIONS_DIR" -database "$dsn" down "$steps"
-    
-    local status=$?
-    if [ $status -eq 0 ]; then
-        log "info" "Migrations rolled back successfully"
-        return 0
-    elif [ $status -eq 1 ]; then
-        # No change (which migrate treats as an error, but we don't)
-        log "info" "No migrations to roll back"
-        return 0
-    else
-        log "error" "Failed to roll back migrations (status: $status)"
-        return 1
-    fi
+    run_migrate down "$steps"
 }
 
 # Create new migration files
@@ -220,10 +133,11 @@ create_migration() {
     # Check if migrations directory exists
     check_migrations_dir
     
-    # Generate timestamp for version
-    local timestamp=$(date +%Y%m%d%H%M%S)
-    local up_file="${MIGRATIONS_DIR}/${timestamp}_${name}.up.sql"
-    local down_file="${MIGRATIONS_DIR}/${timestamp}_${name}.down.sql"
+    # Number the migration after the latest one, as migrations are applied in version order
+    local latest=$(ls "$MIGRATIONS_DIR" | grep -oE '^[0-9]+' | sort -n | tail -1)
+    local version=$(printf "%02d" $((10#${latest:-0} + 1)))
+    local up_file="${MIGRATIONS_DIR}/${version}_${name}.up.sql"
+    local down_file="${MIGRATIONS_DIR}/${version}_${name}.down.sql"
     
     # Create empty migration files
     touch "$up_file"
@@ -299,6 +213,19 @@ main() {
             migrate_down "${cmd_args[0]}"
             exit $?
             ;;
+        status|verify)
+            run_migrate "$cmd"
+            exit $?
+            ;;
+        baseline)
+            if [ ${#cmd_args[@]} -eq 0 ]; then
+                log "error" "Version to baseline at is required"
+                print_usage
+                exit 1
+            fi
+            run_migrate baseline "${cmd_args[0]}"
+            exit $?
+            ;;
         create)
             if [ ${#cmd_args[@]} -eq 0 ]; then
                 log "error" "Migration name is required"
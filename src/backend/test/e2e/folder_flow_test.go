@@ -4,6 +4,7 @@ package e2e
 import (
 	"context"
 	"fmt"
+	"io"
 	"testing"
 	"time"
 
@@ -79,6 +80,8 @@ func (s *FolderFlowTestSuite) SetupTest() {
 		mockPermissionRepo,
 		mockAuthService,
 		s.eventService,
+		&MockTransactionManager{},
+		&MockAuditService{},
 	)
 
 	// Create folder use case with dependencies
@@ -714,7 +717,7 @@ func (s *FolderFlowTestSuite) TestFolderPermissions() {
 	mockPermissionRepo.On("GetByResourceID", mock.Anything, models.ResourceTypeFolder, folderID, s.testTenantID).Return([]*models.Permission{}, nil).Once()
 	
 	// Act - Create permission
-	createdPermissionID, err := s.folderUseCase.CreateFolderPermission(ctx, folderID, roleID, permissionType, s.testTenantID, s.testUserID)
+	createdPermissionID, err := s.folderUseCase.CreateFolderPermission(ctx, folderID, models.GranteeTypeRole, roleID, permissionType, s.testTenantID, s.testUserID)
 	
 	// Assert
 	s.Require().NoError(err)
@@ -1144,6 +1147,11 @@ func (m *MockEventService) CreateAndPublishDocumentEvent(ctx context.Context, ev
 	return args.String(0), args.Error(1)
 }
 
+func (m *MockEventService) CreateAndPublishDocumentsEvent(ctx context.Context, eventType string, tenantID string, documentIDs []string, additionalData map[string]interface{}) (string, error) {
+	args := m.Called(ctx, eventType, tenantID, documentIDs, additionalData)
+	return args.String(0), args.Error(1)
+}
+
 func (m *MockEventService) CreateEvent(ctx context.Context, event *models.Event) (string, error) {
 	args := m.Called(ctx, event)
 	return args.String(0), args.Error(1)
@@ -1154,6 +1162,40 @@ func (m *MockEventService) PublishEvent(ctx context.Context, event *models.Event
 	return args.Error(0)
 }
 
+// MockTransactionManager runs units of work directly without a database transaction
+type MockTransactionManager struct{}
+
+func (m *MockTransactionManager) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// MockAuditService accepts audit entries without recording them
+type MockAuditService struct{}
+
+func (m *MockAuditService) Record(ctx context.Context, entry *models.AuditLog) error {
+	return nil
+}
+
+func (m *MockAuditService) RecordAction(ctx context.Context, tenantID, actorID, action, resourceType, resourceID string, before, after map[string]interface{}) error {
+	return nil
+}
+
+func (m *MockAuditService) GetAuditLog(ctx context.Context, id string, tenantID string) (*models.AuditLog, error) {
+	return nil, nil
+}
+
+func (m *MockAuditService) ListAuditLogs(ctx context.Context, tenantID string, filter models.AuditLogFilter, pagination *utils.Pagination) (utils.PaginatedResult[models.AuditLog], error) {
+	return utils.PaginatedResult[models.AuditLog]{}, nil
+}
+
+func (m *MockAuditService) ExportAuditLogs(ctx context.Context, tenantID string, filter models.AuditLogFilter, format string, w io.Writer) (int, error) {
+	return 0, nil
+}
+
+func (m *MockAuditService) EnsurePartitions(ctx context.Context, now time.Time) error {
+	return nil
+}
+
 // Global mocks for dependencies
 var (
 	mockDocumentRepo   *MockDocumentRepository
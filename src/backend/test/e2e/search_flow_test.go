@@ -225,12 +225,13 @@ func (s *SearchFlowTestSuite) TestMetadataSearch() {
 		"SearchByMetadata", 
 		mock.Anything, 
 		searchMetadata, 
+		[]models.MetadataFilter(nil), 
 		s.testTenantID, 
 		pagination,
 	).Return(expectedResult, nil)
 	
 	// Call searchUseCase.SearchByMetadata with metadata criteria
-	result, err := s.searchUseCase.SearchByMetadata(ctx, searchMetadata, s.testTenantID, pagination)
+	result, err := s.searchUseCase.SearchByMetadata(ctx, searchMetadata, nil, s.testTenantID, pagination)
 	
 	// Assert that correct documents are returned in search results
 	require.NoError(s.T(), err, "Search by metadata should not return an error")
@@ -246,11 +247,12 @@ func (s *SearchFlowTestSuite) TestMetadataSearch() {
 		"SearchByMetadata", 
 		mock.Anything, 
 		searchMetadata, 
+		[]models.MetadataFilter(nil), 
 		otherTenantID, 
 		pagination,
 	).Return(utils.PaginatedResult[models.Document]{}, nil)
 	
-	otherResult, err := s.searchUseCase.SearchByMetadata(ctx, searchMetadata, otherTenantID, pagination)
+	otherResult, err := s.searchUseCase.SearchByMetadata(ctx, searchMetadata, nil, otherTenantID, pagination)
 	require.NoError(s.T(), err, "Search in other tenant should not return an error")
 	assert.Equal(s.T(), 0, len(otherResult.Items), "Search in other tenant should return 0 documents")
 }
@@ -310,12 +312,13 @@ func (s *SearchFlowTestSuite) TestCombinedSearch() {
 		mock.Anything, 
 		searchContent, 
 		searchMetadata, 
+		[]models.MetadataFilter(nil), 
 		s.testTenantID, 
 		pagination,
 	).Return(expectedResult, nil)
 	
 	// Call searchUseCase.CombinedSearch with content query and metadata criteria
-	result, err := s.searchUseCase.CombinedSearch(ctx, searchContent, searchMetadata, s.testTenantID, pagination)
+	result, err := s.searchUseCase.CombinedSearch(ctx, searchContent, searchMetadata, nil, s.testTenantID, pagination)
 	
 	// Assert that correct documents are returned in search results
 	require.NoError(s.T(), err, "Combined search should not return an error")
@@ -332,11 +335,12 @@ func (s *SearchFlowTestSuite) TestCombinedSearch() {
 		mock.Anything, 
 		searchContent, 
 		searchMetadata, 
+		[]models.MetadataFilter(nil), 
 		otherTenantID, 
 		pagination,
 	).Return(utils.PaginatedResult[models.Document]{}, nil)
 	
-	otherResult, err := s.searchUseCase.CombinedSearch(ctx, searchContent, searchMetadata, otherTenantID, pagination)
+	otherResult, err := s.searchUseCase.CombinedSearch(ctx, searchContent, searchMetadata, nil, otherTenantID, pagination)
 	require.NoError(s.T(), err, "Search in other tenant should not return an error")
 	assert.Equal(s.T(), 0, len(otherResult.Items), "Search in other tenant should return 0 documents")
 }
@@ -554,12 +558,12 @@ func (s *SearchFlowTestSuite) TestSearchValidationErrors() {
 	assert.True(s.T(), errors.IsValidationError(err), "Error should be a validation error")
 	
 	// Call searchUseCase.SearchByMetadata with empty metadata
-	_, err = s.searchUseCase.SearchByMetadata(ctx, nil, s.testTenantID, nil)
+	_, err = s.searchUseCase.SearchByMetadata(ctx, nil, nil, s.testTenantID, nil)
 	assert.Error(s.T(), err, "Empty metadata should return an error")
 	assert.True(s.T(), errors.IsValidationError(err), "Error should be a validation error")
 	
 	// Call searchUseCase.CombinedSearch with empty query and metadata
-	_, err = s.searchUseCase.CombinedSearch(ctx, "", nil, s.testTenantID, nil)
+	_, err = s.searchUseCase.CombinedSearch(ctx, "", nil, nil, s.testTenantID, nil)
 	assert.Error(s.T(), err, "Empty combined criteria should return an error")
 	assert.True(s.T(), errors.IsValidationError(err), "Error should be a validation error")
 	
@@ -1080,11 +1084,21 @@ func (m *mockDocumentRepository) DeleteMetadata(ctx context.Context, documentID
 	return args.Error(0)
 }
 
+func (m *mockDocumentRepository) BulkUpdateMetadata(ctx context.Context, documentIDs []string, set map[string]string, remove []string, tenantID string) error {
+	args := m.Called(ctx, documentIDs, set, remove, tenantID)
+	return args.Error(0)
+}
+
 func (m *mockDocumentRepository) GetDocumentsByIDs(ctx context.Context, ids []string, tenantID string) ([]*models.Document, error) {
 	args := m.Called(ctx, ids, tenantID)
 	return args.Get(0).([]*models.Document), args.Error(1)
 }
 
+func (m *mockDocumentRepository) GetUsage(ctx context.Context, tenantID string) (int64, int64, error) {
+	args := m.Called(ctx, tenantID)
+	return args.Get(0).(int64), args.Get(1).(int64), args.Error(2)
+}
+
 type mockStorageService struct {
 	mock.Mock
 }
@@ -1102,13 +1116,13 @@ func (m *mockSearchService) SearchByContent(ctx context.Context, query string, t
 	return args.Get(0).(utils.PaginatedResult[models.Document]), args.Error(1)
 }
 
-func (m *mockSearchService) SearchByMetadata(ctx context.Context, metadata map[string]string, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Document], error) {
-	args := m.Called(ctx, metadata, tenantID, pagination)
+func (m *mockSearchService) SearchByMetadata(ctx context.Context, metadata map[string]string, filters []models.MetadataFilter, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Document], error) {
+	args := m.Called(ctx, metadata, filters, tenantID, pagination)
 	return args.Get(0).(utils.PaginatedResult[models.Document]), args.Error(1)
 }
 
-func (m *mockSearchService) CombinedSearch(ctx context.Context, contentQuery string, metadata map[string]string, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Document], error) {
-	args := m.Called(ctx, contentQuery, metadata, tenantID, pagination)
+func (m *mockSearchService) CombinedSearch(ctx context.Context, contentQuery string, metadata map[string]string, filters []models.MetadataFilter, tenantID string, pagination *utils.Pagination) (utils.PaginatedResult[models.Document], error) {
+	args := m.Called(ctx, contentQuery, metadata, filters, tenantID, pagination)
 	return args.Get(0).(utils.PaginatedResult[models.Document]), args.Error(1)
 }
 
@@ -1125,4 +1139,9 @@ func (m *mockSearchService) IndexDocument(ctx context.Context, documentID string
 func (m *mockSearchService) RemoveDocumentFromIndex(ctx context.Context, documentID string, tenantID string) error {
 	args := m.Called(ctx, documentID, tenantID)
 	return args.Error(0)
+}
+
+func (m *mockSearchService) UpdateIndexedMetadata(ctx context.Context, documents []*models.Document, tenantID string) error {
+	args := m.Called(ctx, documents, tenantID)
+	return args.Error(0)
 }
\ No newline at end of file
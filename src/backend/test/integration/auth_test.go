@@ -3,6 +3,7 @@ package integration
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -32,6 +33,11 @@ type AuthTestSuite struct {
 	testRoles   []string
 	userRepo    *mockUserRepository
 	tenantRepo  *mockTenantRepository
+	roleRepo    *mockRoleRepository
+	permissionRepo *mockPermissionRepository
+	apiKeyRepo     *mockAPIKeyRepository
+	revocationRepo *mockTokenRevocationRepository
+	sessionRepo    *mockSessionRepository
 }
 
 // mockUserRepository is a mock implementation of the UserRepository interface
@@ -62,6 +68,166 @@ func (m *mockTenantRepository) GetByID(ctx context.Context, id string) (*models.
 	return args.Get(0).(*models.Tenant), args.Error(1)
 }
 
+// mockRoleRepository is an in-memory implementation of the RoleRepository permission lookup.
+// It is seeded with the system role permissions and can hold custom roles.
+type mockRoleRepository struct {
+	mock.Mock
+	permissions map[string][]string
+}
+
+// newMockRoleRepository creates a role repository seeded with the system role permissions
+func newMockRoleRepository() *mockRoleRepository {
+	permissions := make(map[string][]string, len(models.DefaultRolePermissions))
+	for name, granted := range models.DefaultRolePermissions {
+		permissions[name] = granted
+	}
+	return &mockRoleRepository{permissions: permissions}
+}
+
+// GetPermissionsForRoles is an in-memory implementation of RoleRepository.GetPermissionsForRoles
+func (m *mockRoleRepository) GetPermissionsForRoles(ctx context.Context, tenantID string, roleNames []string) ([]string, error) {
+	var result []string
+	for _, name := range roleNames {
+		result = append(result, m.permissions[name]...)
+	}
+	return result, nil
+}
+
+// GetIDsByNames is an in-memory implementation of RoleRepository.GetIDsByNames.
+// Role IDs are derived from the role names.
+func (m *mockRoleRepository) GetIDsByNames(ctx context.Context, tenantID string, roleNames []string) ([]string, error) {
+	ids := make([]string, 0, len(roleNames))
+	for _, name := range roleNames {
+		if _, ok := m.permissions[name]; ok {
+			ids = append(ids, "role-"+name)
+		}
+	}
+	return ids, nil
+}
+
+// mockPermissionRepository is an in-memory implementation of the PermissionRepository grant lookup
+type mockPermissionRepository struct {
+	mock.Mock
+	grants []*models.Permission
+}
+
+// CheckGranteePermission is an in-memory implementation of PermissionRepository.CheckGranteePermission
+func (m *mockPermissionRepository) CheckGranteePermission(ctx context.Context, granteeType string, granteeIDs []string, resourceType, resourceID, permissionType, tenantID string) (bool, error) {
+	for _, grant := range m.grants {
+		if grant.GranteeType() != granteeType || grant.TenantID != tenantID ||
+			grant.ResourceType != resourceType || grant.ResourceID != resourceID || grant.PermissionType != permissionType {
+			continue
+		}
+		for _, id := range granteeIDs {
+			if grant.GranteeID() == id {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// mockAPIKeyRepository is an in-memory implementation of the APIKeyRepository lookup by ID
+type mockAPIKeyRepository struct {
+	mock.Mock
+	keys []*models.APIKey
+}
+
+// GetByID is an in-memory implementation of APIKeyRepository.GetByID
+func (m *mockAPIKeyRepository) GetByID(ctx context.Context, id string, tenantID string) (*models.APIKey, error) {
+	for _, key := range m.keys {
+		if key.ID == id && key.TenantID == tenantID {
+			return key, nil
+		}
+	}
+	return nil, errors.NewResourceNotFoundError("API key not found")
+}
+
+// mockTokenRevocationRepository is an in-memory implementation of the TokenRevocationRepository
+type mockTokenRevocationRepository struct {
+	revoked map[string]time.Time
+}
+
+// newMockTokenRevocationRepository creates an empty revocation list
+func newMockTokenRevocationRepository() *mockTokenRevocationRepository {
+	return &mockTokenRevocationRepository{revoked: make(map[string]time.Time)}
+}
+
+// Revoke is an in-memory implementation of TokenRevocationRepository.Revoke
+func (m *mockTokenRevocationRepository) Revoke(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	m.revoked[tokenID] = expiresAt
+	return nil
+}
+
+// IsRevoked is an in-memory implementation of TokenRevocationRepository.IsRevoked
+func (m *mockTokenRevocationRepository) IsRevoked(ctx context.Context, tokenID string) (bool, error) {
+	expiresAt, ok := m.revoked[tokenID]
+	return ok && time.Now().Before(expiresAt), nil
+}
+
+// mockSessionRepository is an in-memory implementation of the SessionRepository
+type mockSessionRepository struct {
+	sessions map[string]*models.Session
+}
+
+// newMockSessionRepository creates an empty session store
+func newMockSessionRepository() *mockSessionRepository {
+	return &mockSessionRepository{sessions: make(map[string]*models.Session)}
+}
+
+// Create is an in-memory implementation of SessionRepository.Create
+func (m *mockSessionRepository) Create(ctx context.Context, session *models.Session) (string, error) {
+	session.ID = fmt.Sprintf("session-%d", len(m.sessions)+1)
+	m.sessions[session.ID] = session
+	return session.ID, nil
+}
+
+// GetByID is an in-memory implementation of SessionRepository.GetByID
+func (m *mockSessionRepository) GetByID(ctx context.Context, id string, tenantID string) (*models.Session, error) {
+	if session, ok := m.sessions[id]; ok && session.TenantID == tenantID {
+		return session, nil
+	}
+	return nil, errors.NewResourceNotFoundError("session not found")
+}
+
+// ListActiveByUser is an in-memory implementation of SessionRepository.ListActiveByUser
+func (m *mockSessionRepository) ListActiveByUser(ctx context.Context, userID string, tenantID string) ([]models.Session, error) {
+	var sessions []models.Session
+	for _, session := range m.sessions {
+		if session.UserID == userID && session.TenantID == tenantID && session.IsActive(time.Now()) {
+			sessions = append(sessions, *session)
+		}
+	}
+	return sessions, nil
+}
+
+// Extend is an in-memory implementation of SessionRepository.Extend
+func (m *mockSessionRepository) Extend(ctx context.Context, id string, tenantID string, lastUsedAt, expiresAt time.Time) error {
+	session, err := m.GetByID(ctx, id, tenantID)
+	if err != nil {
+		return err
+	}
+	if session.IsRevoked() {
+		return errors.NewResourceNotFoundError("session not found")
+	}
+	session.LastUsedAt = lastUsedAt
+	session.ExpiresAt = expiresAt
+	return nil
+}
+
+// Revoke is an in-memory implementation of SessionRepository.Revoke
+func (m *mockSessionRepository) Revoke(ctx context.Context, id string, tenantID string) error {
+	session, err := m.GetByID(ctx, id, tenantID)
+	if err != nil {
+		return err
+	}
+	if session.RevokedAt == nil {
+		now := time.Now()
+		session.RevokedAt = &now
+	}
+	return nil
+}
+
 // SetupSuite sets up the test suite before any tests run
 func (s *AuthTestSuite) SetupSuite() {
 	// Set up test data
@@ -116,10 +282,15 @@ hjhgGjwVbh2xW0xVUQJR+YCsw5j5ZfkKRQOxlwIDAQAB
 	// Create mock repositories
 	s.userRepo = new(mockUserRepository)
 	s.tenantRepo = new(mockTenantRepository)
+	s.roleRepo = newMockRoleRepository()
+	s.permissionRepo = new(mockPermissionRepository)
+	s.apiKeyRepo = new(mockAPIKeyRepository)
+	s.revocationRepo = newMockTokenRevocationRepository()
+	s.sessionRepo = newMockSessionRepository()
 
 	// Create JWT auth service
 	var err error
-	s.authService, err = jwtauth.NewJWTService(s.userRepo, s.tenantRepo, s.jwtConfig)
+	s.authService, err = jwtauth.NewJWTService(s.userRepo, s.tenantRepo, s.roleRepo, s.permissionRepo, s.apiKeyRepo, s.revocationRepo, s.sessionRepo, s.jwtConfig)
 	require.NoError(s.T(), err, "Failed to create JWT auth service")
 }
 
@@ -128,10 +299,15 @@ func (s *AuthTestSuite) SetupTest() {
 	// Reset mocks before each test
 	s.userRepo = new(mockUserRepository)
 	s.tenantRepo = new(mockTenantRepository)
+	s.roleRepo = newMockRoleRepository()
+	s.permissionRepo = new(mockPermissionRepository)
+	s.apiKeyRepo = new(mockAPIKeyRepository)
+	s.revocationRepo = newMockTokenRevocationRepository()
+	s.sessionRepo = newMockSessionRepository()
 
 	// Create auth service with fresh mocks
 	var err error
-	s.authService, err = jwtauth.NewJWTService(s.userRepo, s.tenantRepo, s.jwtConfig)
+	s.authService, err = jwtauth.NewJWTService(s.userRepo, s.tenantRepo, s.roleRepo, s.permissionRepo, s.apiKeyRepo, s.revocationRepo, s.sessionRepo, s.jwtConfig)
 	require.NoError(s.T(), err, "Failed to create JWT auth service")
 
 	// Set up common mock behaviors
@@ -223,7 +399,7 @@ func (s *AuthTestSuite) TestValidateToken_UserNotFound() {
 	s.userRepo = new(mockUserRepository)
 	s.userRepo.On("GetByID", mock.Anything, "unknown-user", s.testTenantID).Return(nil, errors.NewResourceNotFoundError("user not found"))
 	s.tenantRepo.On("GetByID", mock.Anything, s.testTenantID).Return(s.createTestTenant(), nil)
-	s.authService, err = jwtauth.NewJWTService(s.userRepo, s.tenantRepo, s.jwtConfig)
+	s.authService, err = jwtauth.NewJWTService(s.userRepo, s.tenantRepo, s.roleRepo, s.permissionRepo, s.apiKeyRepo, s.revocationRepo, s.sessionRepo, s.jwtConfig)
 	assert.NoError(s.T(), err, "Failed to create JWT auth service")
 
 	// Validate the token
@@ -251,7 +427,7 @@ func (s *AuthTestSuite) TestValidateToken_UserInactive() {
 	s.tenantRepo = new(mockTenantRepository)
 	s.userRepo.On("GetByID", mock.Anything, "inactive-user", s.testTenantID).Return(inactiveUser, nil)
 	s.tenantRepo.On("GetByID", mock.Anything, s.testTenantID).Return(s.createTestTenant(), nil)
-	s.authService, err = jwtauth.NewJWTService(s.userRepo, s.tenantRepo, s.jwtConfig)
+	s.authService, err = jwtauth.NewJWTService(s.userRepo, s.tenantRepo, s.roleRepo, s.permissionRepo, s.apiKeyRepo, s.revocationRepo, s.sessionRepo, s.jwtConfig)
 	assert.NoError(s.T(), err, "Failed to create JWT auth service")
 
 	// Validate the token
@@ -274,7 +450,7 @@ func (s *AuthTestSuite) TestValidateToken_TenantNotFound() {
 	s.tenantRepo = new(mockTenantRepository)
 	s.userRepo.On("GetByID", mock.Anything, s.testUserID, "unknown-tenant").Return(s.createTestUser(), nil)
 	s.tenantRepo.On("GetByID", mock.Anything, "unknown-tenant").Return(nil, errors.NewResourceNotFoundError("tenant not found"))
-	s.authService, err = jwtauth.NewJWTService(s.userRepo, s.tenantRepo, s.jwtConfig)
+	s.authService, err = jwtauth.NewJWTService(s.userRepo, s.tenantRepo, s.roleRepo, s.permissionRepo, s.apiKeyRepo, s.revocationRepo, s.sessionRepo, s.jwtConfig)
 	assert.NoError(s.T(), err, "Failed to create JWT auth service")
 
 	// Validate the token
@@ -302,7 +478,7 @@ func (s *AuthTestSuite) TestValidateToken_TenantInactive() {
 	s.tenantRepo = new(mockTenantRepository)
 	s.userRepo.On("GetByID", mock.Anything, s.testUserID, "inactive-tenant").Return(s.createTestUser(), nil)
 	s.tenantRepo.On("GetByID", mock.Anything, "inactive-tenant").Return(inactiveTenant, nil)
-	s.authService, err = jwtauth.NewJWTService(s.userRepo, s.tenantRepo, s.jwtConfig)
+	s.authService, err = jwtauth.NewJWTService(s.userRepo, s.tenantRepo, s.roleRepo, s.permissionRepo, s.apiKeyRepo, s.revocationRepo, s.sessionRepo, s.jwtConfig)
 	assert.NoError(s.T(), err, "Failed to create JWT auth service")
 
 	// Validate the token
@@ -329,6 +505,170 @@ func (s *AuthTestSuite) TestRefreshToken() {
 	assert.NotEqual(s.T(), refreshToken, newRefreshToken, "New refresh token should be different from old one")
 }
 
+// TestInvalidateToken tests that a revoked token is rejected before it expires
+func (s *AuthTestSuite) TestInvalidateToken() {
+	token := s.generateTestToken()
+
+	// The token is valid until it is revoked
+	_, _, err := s.authService.ValidateToken(context.Background(), token)
+	assert.NoError(s.T(), err, "Token validation should succeed before revocation")
+
+	err = s.authService.InvalidateToken(context.Background(), token)
+	assert.NoError(s.T(), err, "Token invalidation should not fail")
+
+	// The revoked token is rejected
+	_, _, err = s.authService.ValidateToken(context.Background(), token)
+	assert.Error(s.T(), err, "Validation should fail for a revoked token")
+	assert.True(s.T(), errors.IsAuthenticationError(err), "Error should be an authentication error")
+
+	// Other tokens of the same user are unaffected
+	_, _, err = s.authService.ValidateToken(context.Background(), s.generateTestToken())
+	assert.NoError(s.T(), err, "Validation should succeed for a token that was not revoked")
+}
+
+// TestInvalidateToken_RefreshToken tests that a revoked refresh token cannot be used
+func (s *AuthTestSuite) TestInvalidateToken_RefreshToken() {
+	// A revoked refresh token is rejected before any user lookup
+	s.userRepo = new(mockUserRepository)
+	s.tenantRepo = new(mockTenantRepository)
+	var err error
+	s.authService, err = jwtauth.NewJWTService(s.userRepo, s.tenantRepo, s.roleRepo, s.permissionRepo, s.apiKeyRepo, s.revocationRepo, s.sessionRepo, s.jwtConfig)
+	require.NoError(s.T(), err, "Failed to create JWT auth service")
+
+	refreshToken, err := s.authService.GenerateRefreshToken(context.Background(), s.testUserID, s.testTenantID, time.Hour)
+	require.NoError(s.T(), err, "Refresh token generation should not fail")
+
+	err = s.authService.InvalidateToken(context.Background(), refreshToken)
+	assert.NoError(s.T(), err, "Token invalidation should not fail")
+
+	newRefreshToken, err := s.authService.RefreshToken(context.Background(), refreshToken)
+	assert.Error(s.T(), err, "Refresh should fail for a revoked refresh token")
+	assert.Empty(s.T(), newRefreshToken, "No refresh token should be issued")
+}
+
+// TestGuestToken tests that a guest token round-trips its scope
+func (s *AuthTestSuite) TestGuestToken() {
+	// Guest tokens never look up a user
+	var err error
+	s.userRepo = new(mockUserRepository)
+	s.authService, err = jwtauth.NewJWTService(s.userRepo, s.tenantRepo, s.roleRepo, s.permissionRepo, s.apiKeyRepo, s.revocationRepo, s.sessionRepo, s.jwtConfig)
+	require.NoError(s.T(), err, "Failed to create JWT auth service")
+
+	scope := models.NewGuestScope(s.testTenantID, "Reviewer@Example.com", models.ResourceTypeFolder, "folder-789", s.testUserID, time.Now().Add(24*time.Hour))
+	token, err := s.authService.GenerateGuestToken(context.Background(), scope)
+	assert.NoError(s.T(), err, "Guest token generation should not fail")
+
+	// Validate the guest token
+	validated, err := s.authService.ValidateGuestToken(context.Background(), token)
+	assert.NoError(s.T(), err, "Guest token validation should not fail")
+	assert.Equal(s.T(), s.testTenantID, validated.TenantID, "Guest scope should contain correct tenant ID")
+	assert.Equal(s.T(), "reviewer@example.com", validated.GuestEmail, "Guest scope should contain normalized email")
+	assert.Equal(s.T(), models.ResourceTypeFolder, validated.ResourceType, "Guest scope should contain resource type")
+	assert.Equal(s.T(), "folder-789", validated.ResourceID, "Guest scope should contain resource ID")
+	assert.Equal(s.T(), []string{models.PermissionTypeRead}, validated.Permissions, "Guest scope should be read-only")
+
+	// A guest token is not a user token
+	tenantID, roles, err := s.authService.ValidateToken(context.Background(), token)
+	assert.Error(s.T(), err, "Guest token should not be accepted as a user token")
+	assert.Empty(s.T(), tenantID, "Tenant ID should be empty for guest token")
+	assert.Empty(s.T(), roles, "Roles should be empty for guest token")
+}
+
+// TestGuestToken_Invalid tests that guest tokens cannot be minted with broad scopes and user tokens are not guest tokens
+func (s *AuthTestSuite) TestGuestToken_Invalid() {
+	var err error
+	s.userRepo = new(mockUserRepository)
+	s.tenantRepo = new(mockTenantRepository)
+	s.authService, err = jwtauth.NewJWTService(s.userRepo, s.tenantRepo, s.roleRepo, s.permissionRepo, s.apiKeyRepo, s.revocationRepo, s.sessionRepo, s.jwtConfig)
+	require.NoError(s.T(), err, "Failed to create JWT auth service")
+
+	// Write permissions are rejected
+	scope := models.NewGuestScope(s.testTenantID, "reviewer@example.com", models.ResourceTypeDocument, "doc-789", s.testUserID, time.Now().Add(time.Hour))
+	scope.Permissions = append(scope.Permissions, models.PermissionTypeWrite)
+	_, err = s.authService.GenerateGuestToken(context.Background(), scope)
+	assert.Error(s.T(), err, "Guest token with write permission should not be generated")
+
+	// Expiries beyond the maximum are rejected
+	scope = models.NewGuestScope(s.testTenantID, "reviewer@example.com", models.ResourceTypeDocument, "doc-789", s.testUserID, time.Now().Add(models.GuestTokenMaxExpiry+time.Hour))
+	_, err = s.authService.GenerateGuestToken(context.Background(), scope)
+	assert.Error(s.T(), err, "Guest token beyond the maximum expiry should not be generated")
+
+	// A user token is not a guest token
+	token, err := s.authService.GenerateToken(context.Background(), s.testUserID, s.testTenantID, s.testRoles, time.Hour)
+	assert.NoError(s.T(), err, "Token generation should not fail")
+	validated, err := s.authService.ValidateGuestToken(context.Background(), token)
+	assert.Error(s.T(), err, "User token should not be accepted as a guest token")
+	assert.Nil(s.T(), validated, "Guest scope should be nil for user token")
+}
+
+// TestMFAToken tests that a partial-auth token identifies its user but is not accepted as an access token
+func (s *AuthTestSuite) TestMFAToken() {
+	// Partial-auth tokens never look up a user or tenant
+	var err error
+	s.userRepo = new(mockUserRepository)
+	s.tenantRepo = new(mockTenantRepository)
+	s.authService, err = jwtauth.NewJWTService(s.userRepo, s.tenantRepo, s.roleRepo, s.permissionRepo, s.apiKeyRepo, s.revocationRepo, s.sessionRepo, s.jwtConfig)
+	require.NoError(s.T(), err, "Failed to create JWT auth service")
+
+	token, err := s.authService.GenerateMFAToken(context.Background(), s.testUserID, s.testTenantID)
+	assert.NoError(s.T(), err, "MFA token generation should not fail")
+
+	// Validate the MFA token
+	userID, tenantID, err := s.authService.ValidateMFAToken(context.Background(), token)
+	assert.NoError(s.T(), err, "MFA token validation should not fail")
+	assert.Equal(s.T(), s.testUserID, userID, "MFA token should contain correct user ID")
+	assert.Equal(s.T(), s.testTenantID, tenantID, "MFA token should contain correct tenant ID")
+
+	// An MFA token is not an access token
+	_, _, err = s.authService.ValidateToken(context.Background(), token)
+	assert.Error(s.T(), err, "MFA token should not be accepted as an access token")
+
+	// An access token is not an MFA token
+	userID, _, err = s.authService.ValidateMFAToken(context.Background(), s.generateTestToken())
+	assert.Error(s.T(), err, "Access token should not be accepted as an MFA token")
+	assert.Empty(s.T(), userID, "User ID should be empty for access token")
+
+	// A used MFA token is rejected
+	err = s.authService.InvalidateToken(context.Background(), token)
+	assert.NoError(s.T(), err, "Token invalidation should not fail")
+	_, _, err = s.authService.ValidateMFAToken(context.Background(), token)
+	assert.Error(s.T(), err, "Validation should fail for a revoked MFA token")
+}
+
+// TestSession tests that revoking a session rejects all of its tokens
+func (s *AuthTestSuite) TestSession() {
+	token, refreshToken, err := s.authService.CreateSession(context.Background(), s.testUserID, s.testTenantID, s.testRoles)
+	assert.NoError(s.T(), err, "Session creation should not fail")
+
+	sessions, err := s.sessionRepo.ListActiveByUser(context.Background(), s.testUserID, s.testTenantID)
+	assert.NoError(s.T(), err, "Listing sessions should not fail")
+	require.Len(s.T(), sessions, 1, "Session should be recorded")
+
+	// Tokens refreshed within the session stay in it
+	newToken, err := s.authService.RefreshToken(context.Background(), refreshToken)
+	assert.NoError(s.T(), err, "Refresh should not fail")
+
+	for _, t := range []string{token, newToken} {
+		_, _, err = s.authService.ValidateToken(context.Background(), t)
+		assert.NoError(s.T(), err, "Token validation should not fail")
+	}
+
+	// Revoke the session
+	err = s.authService.RevokeSession(context.Background(), sessions[0].ID, s.testTenantID)
+	assert.NoError(s.T(), err, "Session revocation should not fail")
+
+	for _, t := range []string{token, newToken} {
+		_, _, err = s.authService.ValidateToken(context.Background(), t)
+		assert.Error(s.T(), err, "Validation should fail for a token of a revoked session")
+	}
+	_, err = s.authService.RefreshToken(context.Background(), refreshToken)
+	assert.Error(s.T(), err, "Refresh should fail for a revoked session")
+
+	sessions, err = s.sessionRepo.ListActiveByUser(context.Background(), s.testUserID, s.testTenantID)
+	assert.NoError(s.T(), err, "Listing sessions should not fail")
+	assert.Empty(s.T(), sessions, "Revoked session should not be listed")
+}
+
 // TestVerifyPermission tests permission verification functionality
 func (s *AuthTestSuite) TestVerifyPermission() {
 	// Set up user with specific roles
@@ -339,7 +679,7 @@ func (s *AuthTestSuite) TestVerifyPermission() {
 	s.userRepo.On("GetByID", mock.Anything, s.testUserID, s.testTenantID).Return(user, nil)
 	
 	var err error
-	s.authService, err = jwtauth.NewJWTService(s.userRepo, s.tenantRepo, s.jwtConfig)
+	s.authService, err = jwtauth.NewJWTService(s.userRepo, s.tenantRepo, s.roleRepo, s.permissionRepo, s.apiKeyRepo, s.revocationRepo, s.sessionRepo, s.jwtConfig)
 	assert.NoError(s.T(), err, "Failed to create JWT auth service")
 
 	// Test read permission (all users have read permission)
@@ -368,7 +708,7 @@ func (s *AuthTestSuite) TestVerifyPermission() {
 	s.userRepo = new(mockUserRepository)
 	s.userRepo.On("GetByID", mock.Anything, s.testUserID, s.testTenantID).Return(user, nil)
 	
-	s.authService, err = jwtauth.NewJWTService(s.userRepo, s.tenantRepo, s.jwtConfig)
+	s.authService, err = jwtauth.NewJWTService(s.userRepo, s.tenantRepo, s.roleRepo, s.permissionRepo, s.apiKeyRepo, s.revocationRepo, s.sessionRepo, s.jwtConfig)
 	assert.NoError(s.T(), err, "Failed to create JWT auth service")
 
 	// Test manage_folders permission again with admin role
@@ -377,6 +717,33 @@ func (s *AuthTestSuite) TestVerifyPermission() {
 	assert.True(s.T(), hasPermission, "User with admin role should have manage_folders permission")
 }
 
+// TestVerifyPermission_CustomRole tests permission verification for a tenant-defined role
+func (s *AuthTestSuite) TestVerifyPermission_CustomRole() {
+	// Set up user with a custom role that can read and delete but not write
+	user := s.createTestUser()
+	user.Roles = []string{"retention-officer"}
+	s.roleRepo.permissions["retention-officer"] = []string{models.RolePermissionRead, models.RolePermissionDelete}
+
+	s.userRepo = new(mockUserRepository)
+	s.userRepo.On("GetByID", mock.Anything, s.testUserID, s.testTenantID).Return(user, nil)
+
+	var err error
+	s.authService, err = jwtauth.NewJWTService(s.userRepo, s.tenantRepo, s.roleRepo, s.permissionRepo, s.apiKeyRepo, s.revocationRepo, s.sessionRepo, s.jwtConfig)
+	assert.NoError(s.T(), err, "Failed to create JWT auth service")
+
+	hasPermission, err := s.authService.VerifyPermission(context.Background(), s.testUserID, s.testTenantID, auth.PermissionDelete)
+	assert.NoError(s.T(), err, "Permission verification should not fail")
+	assert.True(s.T(), hasPermission, "User with custom role should have delete permission")
+
+	hasPermission, err = s.authService.VerifyPermission(context.Background(), s.testUserID, s.testTenantID, auth.PermissionWrite)
+	assert.NoError(s.T(), err, "Permission verification should not fail")
+	assert.False(s.T(), hasPermission, "User with custom role should not have write permission")
+
+	// Unknown permissions are rejected rather than denied
+	_, err = s.authService.VerifyPermission(context.Background(), s.testUserID, s.testTenantID, "approve")
+	assert.Error(s.T(), err, "Unknown permission should be rejected")
+}
+
 // TestVerifyResourceAccess tests resource access verification functionality
 func (s *AuthTestSuite) TestVerifyResourceAccess() {
 	// Set up user with specific roles
@@ -387,7 +754,7 @@ func (s *AuthTestSuite) TestVerifyResourceAccess() {
 	s.userRepo.On("GetByID", mock.Anything, s.testUserID, s.testTenantID).Return(user, nil)
 	
 	var err error
-	s.authService, err = jwtauth.NewJWTService(s.userRepo, s.tenantRepo, s.jwtConfig)
+	s.authService, err = jwtauth.NewJWTService(s.userRepo, s.tenantRepo, s.roleRepo, s.permissionRepo, s.apiKeyRepo, s.revocationRepo, s.sessionRepo, s.jwtConfig)
 	assert.NoError(s.T(), err, "Failed to create JWT auth service")
 
 	// Test read access to document
@@ -411,6 +778,93 @@ func (s *AuthTestSuite) TestVerifyResourceAccess() {
 	assert.False(s.T(), hasAccess, "User without admin role should not have manage_folders access to folder")
 }
 
+// TestVerifyResourceAccess_Grants tests resource access granted directly to a user, to a group and to a role
+func (s *AuthTestSuite) TestVerifyResourceAccess_Grants() {
+	// Set up a reader who belongs to a group
+	user := s.createTestUser()
+	user.Roles = []string{"reader"}
+	user.Groups = []string{"group-legal"}
+
+	s.userRepo = new(mockUserRepository)
+	s.userRepo.On("GetByID", mock.Anything, s.testUserID, s.testTenantID).Return(user, nil)
+
+	userGrant, err := models.NewGrant(models.GranteeTypeUser, s.testUserID, auth.ResourceTypeFolder, "folder-123", "write", s.testTenantID, "admin-user")
+	require.NoError(s.T(), err)
+	groupGrant, err := models.NewGrant(models.GranteeTypeGroup, "group-legal", auth.ResourceTypeDocument, "doc-123", "delete", s.testTenantID, "admin-user")
+	require.NoError(s.T(), err)
+	roleGrant, err := models.NewGrant(models.GranteeTypeRole, "role-reader", auth.ResourceTypeFolder, "folder-456", "delete", s.testTenantID, "admin-user")
+	require.NoError(s.T(), err)
+	s.permissionRepo.grants = []*models.Permission{userGrant, groupGrant, roleGrant}
+
+	s.authService, err = jwtauth.NewJWTService(s.userRepo, s.tenantRepo, s.roleRepo, s.permissionRepo, s.apiKeyRepo, s.revocationRepo, s.sessionRepo, s.jwtConfig)
+	require.NoError(s.T(), err, "Failed to create JWT auth service")
+
+	// Direct user grant
+	hasAccess, err := s.authService.VerifyResourceAccess(context.Background(), s.testUserID, s.testTenantID, auth.ResourceTypeFolder, "folder-123", "write")
+	assert.NoError(s.T(), err, "Resource access verification should not fail")
+	assert.True(s.T(), hasAccess, "User granted write on the folder should have write access")
+
+	// Group grant
+	hasAccess, err = s.authService.VerifyResourceAccess(context.Background(), s.testUserID, s.testTenantID, auth.ResourceTypeDocument, "doc-123", "delete")
+	assert.NoError(s.T(), err, "Resource access verification should not fail")
+	assert.True(s.T(), hasAccess, "Member of a group granted delete on the document should have delete access")
+
+	// Role grant on the resource
+	hasAccess, err = s.authService.VerifyResourceAccess(context.Background(), s.testUserID, s.testTenantID, auth.ResourceTypeFolder, "folder-456", "delete")
+	assert.NoError(s.T(), err, "Resource access verification should not fail")
+	assert.True(s.T(), hasAccess, "Role granted delete on the folder should give delete access")
+
+	// Grants do not carry over to other resources
+	hasAccess, err = s.authService.VerifyResourceAccess(context.Background(), s.testUserID, s.testTenantID, auth.ResourceTypeFolder, "folder-789", "write")
+	assert.NoError(s.T(), err, "Resource access verification should not fail")
+	assert.False(s.T(), hasAccess, "Reader without a grant should not have write access")
+}
+
+// TestVerifyPermission_APIKey tests that API key principals are evaluated with the key's role
+func (s *AuthTestSuite) TestVerifyPermission_APIKey() {
+	// API key principals never look up a user
+	s.userRepo = new(mockUserRepository)
+	s.tenantRepo = new(mockTenantRepository)
+
+	expiresAt := time.Now().Add(time.Hour)
+	key, _, err := models.NewAPIKey(s.testTenantID, "CI pipeline", "reader", s.testUserID, &expiresAt)
+	require.NoError(s.T(), err)
+	key.ID = "key-123"
+	revoked, _, err := models.NewAPIKey(s.testTenantID, "Old integration", "administrator", s.testUserID, nil)
+	require.NoError(s.T(), err)
+	revoked.ID = "key-456"
+	revoked.Revoke()
+	s.apiKeyRepo.keys = []*models.APIKey{key, revoked}
+
+	roleGrant, err := models.NewGrant(models.GranteeTypeRole, "role-reader", auth.ResourceTypeFolder, "folder-456", "delete", s.testTenantID, "admin-user")
+	require.NoError(s.T(), err)
+	s.permissionRepo.grants = []*models.Permission{roleGrant}
+
+	s.authService, err = jwtauth.NewJWTService(s.userRepo, s.tenantRepo, s.roleRepo, s.permissionRepo, s.apiKeyRepo, s.revocationRepo, s.sessionRepo, s.jwtConfig)
+	require.NoError(s.T(), err, "Failed to create JWT auth service")
+
+	// The key's role grants read but not write
+	hasPermission, err := s.authService.VerifyPermission(context.Background(), key.PrincipalID(), s.testTenantID, auth.PermissionRead)
+	assert.NoError(s.T(), err, "Permission verification should not fail")
+	assert.True(s.T(), hasPermission, "Reader API key should have read permission")
+	hasPermission, err = s.authService.VerifyPermission(context.Background(), key.PrincipalID(), s.testTenantID, auth.PermissionWrite)
+	assert.NoError(s.T(), err, "Permission verification should not fail")
+	assert.False(s.T(), hasPermission, "Reader API key should not have write permission")
+
+	// Role grants on a resource apply to keys with the role
+	hasAccess, err := s.authService.VerifyResourceAccess(context.Background(), key.PrincipalID(), s.testTenantID, auth.ResourceTypeFolder, "folder-456", "delete")
+	assert.NoError(s.T(), err, "Resource access verification should not fail")
+	assert.True(s.T(), hasAccess, "Role granted delete on the folder should give the API key delete access")
+
+	// Keys do not work in other tenants, and revoked keys have no permissions
+	hasPermission, err = s.authService.VerifyPermission(context.Background(), key.PrincipalID(), "other-tenant", auth.PermissionRead)
+	assert.NoError(s.T(), err, "Permission verification should not fail")
+	assert.False(s.T(), hasPermission, "API key should have no permissions in another tenant")
+	hasPermission, err = s.authService.VerifyPermission(context.Background(), revoked.PrincipalID(), s.testTenantID, auth.PermissionRead)
+	assert.NoError(s.T(), err, "Permission verification should not fail")
+	assert.False(s.T(), hasPermission, "Revoked API key should have no permissions")
+}
+
 // TestVerifyTenantAccess tests tenant access verification functionality
 func (s *AuthTestSuite) TestVerifyTenantAccess() {
 	// Test with matching tenant ID
@@ -420,7 +874,7 @@ func (s *AuthTestSuite) TestVerifyTenantAccess() {
 	s.userRepo.On("GetByID", mock.Anything, s.testUserID, s.testTenantID).Return(user, nil)
 	
 	var err error
-	s.authService, err = jwtauth.NewJWTService(s.userRepo, s.tenantRepo, s.jwtConfig)
+	s.authService, err = jwtauth.NewJWTService(s.userRepo, s.tenantRepo, s.roleRepo, s.permissionRepo, s.apiKeyRepo, s.revocationRepo, s.sessionRepo, s.jwtConfig)
 	assert.NoError(s.T(), err, "Failed to create JWT auth service")
 
 	hasAccess, err := s.authService.VerifyTenantAccess(context.Background(), s.testUserID, s.testTenantID)
@@ -434,7 +888,7 @@ func (s *AuthTestSuite) TestVerifyTenantAccess() {
 	s.userRepo = new(mockUserRepository)
 	s.userRepo.On("GetByID", mock.Anything, s.testUserID, "different-tenant").Return(otherTenantUser, nil)
 	
-	s.authService, err = jwtauth.NewJWTService(s.userRepo, s.tenantRepo, s.jwtConfig)
+	s.authService, err = jwtauth.NewJWTService(s.userRepo, s.tenantRepo, s.roleRepo, s.permissionRepo, s.apiKeyRepo, s.revocationRepo, s.sessionRepo, s.jwtConfig)
 	assert.NoError(s.T(), err, "Failed to create JWT auth service")
 
 	hasAccess, err = s.authService.VerifyTenantAccess(context.Background(), s.testUserID, "different-tenant")
@@ -530,7 +984,7 @@ func (s *AuthTestSuite) TestRequireRole() {
 	s.userRepo = new(mockUserRepository)
 	s.userRepo.On("GetByID", mock.Anything, s.testUserID, s.testTenantID).Return(adminUser, nil)
 	
-	s.authService, err = jwtauth.NewJWTService(s.userRepo, s.tenantRepo, s.jwtConfig)
+	s.authService, err = jwtauth.NewJWTService(s.userRepo, s.tenantRepo, s.roleRepo, s.permissionRepo, s.apiKeyRepo, s.revocationRepo, s.sessionRepo, s.jwtConfig)
 	assert.NoError(s.T(), err, "Failed to create JWT auth service")
 
 	// Test admin endpoint with admin role
@@ -548,7 +1002,7 @@ func (s *AuthTestSuite) TestRequireRole() {
 	s.userRepo = new(mockUserRepository)
 	s.userRepo.On("GetByID", mock.Anything, s.testUserID, s.testTenantID).Return(contribUser, nil)
 	
-	s.authService, err = jwtauth.NewJWTService(s.userRepo, s.tenantRepo, s.jwtConfig)
+	s.authService, err = jwtauth.NewJWTService(s.userRepo, s.tenantRepo, s.roleRepo, s.permissionRepo, s.apiKeyRepo, s.revocationRepo, s.sessionRepo, s.jwtConfig)
 	assert.NoError(s.T(), err, "Failed to create JWT auth service")
 
 	// Test admin endpoint with contributor role
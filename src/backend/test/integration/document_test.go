@@ -76,7 +76,7 @@ func (s *DocumentRepositorySuite) SetupSuite() {
 	s.Require().NoError(err, "Failed to get database instance")
 
 	// Run migrations for required models
-	err = postgres.Migrate(&models.Document{}, &models.DocumentMetadata{}, &models.DocumentVersion{}, &models.Tag{})
+	err = postgres.CheckMigrations(context.Background(), true)
 	s.Require().NoError(err, "Failed to run migrations")
 
 	// Create document repository
@@ -49,7 +49,7 @@ func (s *FolderTestSuite) SetupSuite() {
 	require.NoError(s.T(), err, "Failed to initialize database connection")
 
 	// Run migrations to ensure schema is up to date
-	err = postgres.Migrate(&models.Folder{})
+	err = postgres.CheckMigrations(context.Background(), true)
 	require.NoError(s.T(), err, "Failed to migrate database schema")
 
 	// Create folder repository instance
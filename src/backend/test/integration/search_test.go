@@ -5,7 +5,6 @@ import (
 	"context"
 	"os"
 	"testing"
-	"time"
 	"fmt"
 
 	"github.com/google/uuid" // v1.3.0+
@@ -66,7 +65,7 @@ func (s *SearchServiceSuite) SetupSuite() {
 	s.Require().NoError(err, "Failed to get database instance")
 
 	// Run migrations
-	err = postgres.Migrate(&models.Document{}, &models.DocumentMetadata{}, &models.DocumentVersion{}, &models.Tag{})
+	err = postgres.CheckMigrations(context.Background(), true)
 	s.Require().NoError(err, "Failed to run migrations")
 
 	// Create document repository
@@ -95,15 +94,19 @@ func (s *SearchServiceSuite) SetupSuite() {
 	s.Require().NoError(err, "Failed to create search indexer")
 
 	// Create search query executor
-	searchQueryExecutor, err := elasticsearch.NewElasticsearchQueryExecutor(esClient)
+	searchQueryExecutor, err := elasticsearch.NewElasticsearchQueryExecutor(esClient, documentIndex)
 	s.Require().NoError(err, "Failed to create search query executor")
 
+	// Create metadata schema service resolving metadata filters
+	metadataSchemas, err := services.NewMetadataSchemaService(postgres.NewMetadataFieldRepository())
+	s.Require().NoError(err, "Failed to create metadata schema service")
+
 	// Create search service
-	s.searchService, err = services.NewSearchService(searchIndexer, searchQueryExecutor, s.documentRepo)
+	s.searchService, err = services.NewSearchService(searchIndexer, searchQueryExecutor, s.documentRepo, metadataSchemas)
 	s.Require().NoError(err, "Failed to create search service")
 
-	// Create background context for tests
-	s.ctx = context.Background()
+	// Create background context for tests, waiting for indexed documents to be searchable
+	s.ctx = services.ContextWithIndexRefresh(context.Background(), services.IndexRefreshWaitFor)
 }
 
 // TearDownSuite tears down the test suite by closing the database connection
@@ -142,9 +145,6 @@ func (s *SearchServiceSuite) TestSearchByContent() {
 	err = s.indexTestDocument(docID3, testTenantID2, content3)
 	s.Require().NoError(err)
 	
-	// Wait for indexing to complete
-	time.Sleep(1 * time.Second)
-	
 	// Search for "test document" in tenant 1
 	pagination := utils.NewPagination(1, 10)
 	result, err := s.searchService.SearchByContent(s.ctx, "test document", testTenantID1, pagination)
@@ -228,16 +228,13 @@ func (s *SearchServiceSuite) TestSearchByMetadata() {
 	err = s.indexTestDocument(docID3, testTenantID2, content3)
 	s.Require().NoError(err)
 	
-	// Wait for indexing to complete
-	time.Sleep(1 * time.Second)
-	
 	// Search by metadata criteria and tenant 1 ID
 	pagination := utils.NewPagination(1, 10)
 	metadata := map[string]string{
 		"category": "report",
 	}
 	
-	result, err := s.searchService.SearchByMetadata(s.ctx, metadata, testTenantID1, pagination)
+	result, err := s.searchService.SearchByMetadata(s.ctx, metadata, nil, testTenantID1, pagination)
 	
 	// Assert that only tenant 1's matching documents are returned
 	s.Require().NoError(err)
@@ -251,7 +248,7 @@ func (s *SearchServiceSuite) TestSearchByMetadata() {
 		"department": "finance",
 	}
 	
-	result, err = s.searchService.SearchByMetadata(s.ctx, metadata, testTenantID1, pagination)
+	result, err = s.searchService.SearchByMetadata(s.ctx, metadata, nil, testTenantID1, pagination)
 	s.Require().NoError(err)
 	s.Require().Equal(1, len(result.Items), "Should return one document matching all metadata criteria")
 	s.Assert().Equal(docID2, result.Items[0].ID, "Should return the correct document")
@@ -261,12 +258,12 @@ func (s *SearchServiceSuite) TestSearchByMetadata() {
 		"category": "nonexistent",
 	}
 	
-	result, err = s.searchService.SearchByMetadata(s.ctx, metadata, testTenantID1, pagination)
+	result, err = s.searchService.SearchByMetadata(s.ctx, metadata, nil, testTenantID1, pagination)
 	s.Require().NoError(err)
 	s.Assert().Equal(0, len(result.Items), "Should return empty results for non-matching metadata")
 	
 	// Test with empty metadata criteria (should return validation error)
-	_, err = s.searchService.SearchByMetadata(s.ctx, map[string]string{}, testTenantID1, pagination)
+	_, err = s.searchService.SearchByMetadata(s.ctx, map[string]string{}, nil, testTenantID1, pagination)
 	s.Require().Error(err)
 	s.Assert().True(errors.IsValidationError(err), "Empty metadata should return validation error")
 	
@@ -275,7 +272,7 @@ func (s *SearchServiceSuite) TestSearchByMetadata() {
 		"category": "report",
 	}
 	
-	result, err = s.searchService.SearchByMetadata(s.ctx, metadata, testTenantID2, pagination)
+	result, err = s.searchService.SearchByMetadata(s.ctx, metadata, nil, testTenantID2, pagination)
 	s.Require().NoError(err)
 	s.Assert().Equal(1, len(result.Items), "Should return only documents from tenant 2")
 	s.Assert().Equal(docID3, result.Items[0].ID, "Should return the correct document from tenant 2")
@@ -324,16 +321,13 @@ func (s *SearchServiceSuite) TestCombinedSearch() {
 	err = s.indexTestDocument(docID3, testTenantID2, content3)
 	s.Require().NoError(err)
 	
-	// Wait for indexing to complete
-	time.Sleep(1 * time.Second)
-	
 	// Call searchService.CombinedSearch with content query, metadata criteria, and tenant 1 ID
 	pagination := utils.NewPagination(1, 10)
 	metadata := map[string]string{
 		"category": "report",
 	}
 	
-	result, err := s.searchService.CombinedSearch(s.ctx, "engineering", metadata, testTenantID1, pagination)
+	result, err := s.searchService.CombinedSearch(s.ctx, "engineering", metadata, nil, testTenantID1, pagination)
 	
 	// Assert that only tenant 1's matching documents are returned
 	s.Require().NoError(err)
@@ -342,7 +336,7 @@ func (s *SearchServiceSuite) TestCombinedSearch() {
 	s.Assert().Equal(docID1, result.Items[0].ID, "Should return the correct document")
 	
 	// Test with content query only
-	result, err = s.searchService.CombinedSearch(s.ctx, "marketing presentation", nil, testTenantID1, pagination)
+	result, err = s.searchService.CombinedSearch(s.ctx, "marketing presentation", nil, nil, testTenantID1, pagination)
 	s.Require().NoError(err)
 	s.Require().Equal(1, len(result.Items), "Should return one document matching content criteria")
 	s.Assert().Equal(docID2, result.Items[0].ID, "Should return the correct document")
@@ -351,7 +345,7 @@ func (s *SearchServiceSuite) TestCombinedSearch() {
 	metadata = map[string]string{
 		"department": "marketing",
 	}
-	result, err = s.searchService.CombinedSearch(s.ctx, "", metadata, testTenantID1, pagination)
+	result, err = s.searchService.CombinedSearch(s.ctx, "", metadata, nil, testTenantID1, pagination)
 	s.Require().NoError(err)
 	s.Require().Equal(1, len(result.Items), "Should return one document matching metadata criteria")
 	s.Assert().Equal(docID2, result.Items[0].ID, "Should return the correct document")
@@ -360,12 +354,12 @@ func (s *SearchServiceSuite) TestCombinedSearch() {
 	metadata = map[string]string{
 		"category": "report",
 	}
-	result, err = s.searchService.CombinedSearch(s.ctx, "nonexistent", metadata, testTenantID1, pagination)
+	result, err = s.searchService.CombinedSearch(s.ctx, "nonexistent", metadata, nil, testTenantID1, pagination)
 	s.Require().NoError(err)
 	s.Assert().Equal(0, len(result.Items), "Should return empty results for non-matching criteria")
 	
 	// Test with empty criteria (should return validation error)
-	_, err = s.searchService.CombinedSearch(s.ctx, "", map[string]string{}, testTenantID1, pagination)
+	_, err = s.searchService.CombinedSearch(s.ctx, "", map[string]string{}, nil, testTenantID1, pagination)
 	s.Require().Error(err)
 	s.Assert().True(errors.IsValidationError(err), "Empty criteria should return validation error")
 	
@@ -373,7 +367,7 @@ func (s *SearchServiceSuite) TestCombinedSearch() {
 	metadata = map[string]string{
 		"category": "report",
 	}
-	result, err = s.searchService.CombinedSearch(s.ctx, "engineering", metadata, testTenantID2, pagination)
+	result, err = s.searchService.CombinedSearch(s.ctx, "engineering", metadata, nil, testTenantID2, pagination)
 	s.Require().NoError(err)
 	s.Assert().Equal(1, len(result.Items), "Should return only documents from tenant 2")
 	s.Assert().Equal(docID3, result.Items[0].ID, "Should return the correct document from tenant 2")
@@ -402,9 +396,6 @@ func (s *SearchServiceSuite) TestSearchInFolder() {
 	err = s.indexTestDocument(docID3, testTenantID2, content3)
 	s.Require().NoError(err)
 	
-	// Wait for indexing to complete
-	time.Sleep(1 * time.Second)
-	
 	// Call searchService.SearchInFolder with folder ID, search query, and tenant 1 ID
 	pagination := utils.NewPagination(1, 10)
 	result, err := s.searchService.SearchInFolder(s.ctx, testFolderID1, "project information", testTenantID1, pagination)
@@ -453,9 +444,6 @@ func (s *SearchServiceSuite) TestIndexDocument() {
 	err := s.searchService.IndexDocument(s.ctx, docID, testTenantID1, content)
 	s.Require().NoError(err, "Document indexing should succeed")
 	
-	// Wait for indexing to complete
-	time.Sleep(1 * time.Second)
-	
 	// Search for the document content to verify indexing
 	pagination := utils.NewPagination(1, 10)
 	result, err := s.searchService.SearchByContent(s.ctx, "indexing functionality", testTenantID1, pagination)
@@ -482,9 +470,6 @@ func (s *SearchServiceSuite) TestRemoveDocumentFromIndex() {
 	err := s.searchService.IndexDocument(s.ctx, docID, testTenantID1, content)
 	s.Require().NoError(err, "Document indexing should succeed")
 	
-	// Wait for indexing to complete
-	time.Sleep(1 * time.Second)
-	
 	// Verify the document is searchable
 	pagination := utils.NewPagination(1, 10)
 	result, err := s.searchService.SearchByContent(s.ctx, "removal from index", testTenantID1, pagination)
@@ -495,9 +480,6 @@ func (s *SearchServiceSuite) TestRemoveDocumentFromIndex() {
 	err = s.searchService.RemoveDocumentFromIndex(s.ctx, docID, testTenantID1)
 	s.Require().NoError(err, "Document removal should succeed")
 	
-	// Wait for removal to complete
-	time.Sleep(1 * time.Second)
-	
 	// Search for the document content to verify removal
 	result, err = s.searchService.SearchByContent(s.ctx, "removal from index", testTenantID1, pagination)
 	s.Require().NoError(err)
@@ -524,9 +506,6 @@ func (s *SearchServiceSuite) TestPaginationInSearch() {
 		s.Require().NoError(err)
 	}
 	
-	// Wait for indexing to complete
-	time.Sleep(2 * time.Second)
-	
 	// Call searchService.SearchByContent with different page sizes and page numbers
 	testCases := []struct {
 		pageSize    int